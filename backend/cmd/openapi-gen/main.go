@@ -0,0 +1,79 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Command openapi-gen regenerates the API specification from the server's
+// route table, and the typed Go client from the specification. Run it from
+// the backend module root (make openapi):
+//
+//	go run ./cmd/openapi-gen
+//
+// It writes internal/apispec/openapi.json and pkg/apiclient/zz_generated.go,
+// and prints the registrations it could not read. -check compares instead of
+// writing and exits 1 when either file is out of date.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/opendefender/openrisk/internal/apispec"
+)
+
+func main() {
+	var (
+		dir     = flag.String("dir", ".", "backend module root")
+		server  = flag.String("server", "./cmd/server", "server main package")
+		overlay = flag.String("overlay", "../docs/openapi.yaml", "hand-written prose for operations")
+		spec    = flag.String("spec", "internal/apispec/openapi.json", "specification output")
+		client  = flag.String("client", "pkg/apiclient/zz_generated.go", "Go client output")
+		check   = flag.Bool("check", false, "fail if the outputs are out of date instead of writing them")
+	)
+	flag.Parse()
+
+	res, err := apispec.Generate(apispec.Config{
+		Dir:         *dir,
+		MainPackage: *server,
+		Overlay:     *overlay,
+		Title:       "OpenRisk API",
+		Version:     "1.0.0",
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "openapi-gen:", err)
+		os.Exit(1)
+	}
+	for _, w := range res.Warnings {
+		fmt.Fprintln(os.Stderr, "warning:", w)
+	}
+	code, err := apispec.GenerateClient(res.JSON, "apiclient")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "openapi-gen:", err)
+		os.Exit(1)
+	}
+
+	stale := false
+	for _, out := range []struct {
+		path string
+		data []byte
+	}{{*spec, res.JSON}, {*client, code}} {
+		if *check {
+			old, _ := os.ReadFile(out.path)
+			if !bytes.Equal(old, out.data) {
+				fmt.Fprintf(os.Stderr, "%s is out of date; run make openapi\n", out.path)
+				stale = true
+			}
+			continue
+		}
+		if err := os.WriteFile(out.path, out.data, 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, "openapi-gen:", err)
+			os.Exit(1)
+		}
+	}
+	if stale {
+		os.Exit(1)
+	}
+	fmt.Printf("%d operations\n", len(res.Routes))
+}
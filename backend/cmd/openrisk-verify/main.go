@@ -0,0 +1,189 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Command openrisk-verify checks an exported audit segment offline: the hash
+// chain, and every signed checkpoint against the chain entry it names. It never
+// talks to an OpenRisk server and trusts nothing in the segment file itself —
+// the public key and the TSA root are passed in, from wherever the auditor got
+// them. See docs/AUDIT_ANCHORING.md.
+//
+//	openrisk-verify --key openrisk-audit.pem [--checkpoints kept.json]
+//	                [--tsa-ca tsa-root.pem] [--strict] [-o text|json] segment.json
+package main
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/opendefender/openrisk/internal/application/governance"
+	"github.com/opendefender/openrisk/pkg/crypto"
+)
+
+// Exit codes: a segment that fails verification is distinct from one the
+// command could not check at all.
+const (
+	exitOK     = 0
+	exitFailed = 1 // the segment does not verify (or, with --strict, has warnings)
+	exitError  = 2 // usage or unreadable input
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// files is a repeatable path flag.
+type files []string
+
+func (f *files) String() string     { return strings.Join(*f, ",") }
+func (f *files) Set(v string) error { *f = append(*f, v); return nil }
+
+// run is main without the process: it is what the tests drive.
+func run(args []string, stdout, stderr io.Writer) int {
+	var keys, checkpoints, tsaCAs files
+	var strict bool
+	var output string
+	fs := flag.NewFlagSet("openrisk-verify", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: openrisk-verify --key <public-key.pem> [flags] <segment.json>")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Flags:")
+		fs.PrintDefaults()
+	}
+	fs.Var(&keys, "key", "trusted checkpoint public key (PEM); repeat for rotated keys")
+	fs.Var(&checkpoints, "checkpoints", "checkpoints kept outside the product (list response, JSON array or webhook deliveries); repeatable")
+	fs.Var(&tsaCAs, "tsa-ca", "root certificate(s) of the timestamp authority (PEM); repeatable")
+	fs.BoolVar(&strict, "strict", false, "treat warnings (unanchored entries, unchecked timestamps) as failures")
+	fs.StringVar(&output, "o", "text", "output format: text or json")
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return exitOK
+			}
+			return exitError
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	if len(positional) != 1 || len(keys) == 0 || (output != "text" && output != "json") {
+		fs.Usage()
+		return exitError
+	}
+
+	rep, err := verify(positional[0], keys, checkpoints, tsaCAs)
+	if err != nil {
+		fmt.Fprintln(stderr, "openrisk-verify:", err)
+		return exitError
+	}
+	if output == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(rep)
+	} else {
+		printReport(stdout, rep, strict)
+	}
+	if !rep.Valid || (strict && len(rep.Warnings) > 0) {
+		return exitFailed
+	}
+	return exitOK
+}
+
+func verify(segmentPath string, keyPaths, checkpointPaths, caPaths []string) (*governance.AuditSegmentReport, error) {
+	raw, err := os.ReadFile(segmentPath)
+	if err != nil {
+		return nil, err
+	}
+	var seg governance.AuditSegment
+	if err := json.Unmarshal(raw, &seg); err != nil {
+		return nil, fmt.Errorf("%s: %w", segmentPath, err)
+	}
+
+	var opts governance.AuditSegmentVerifyOptions
+	for _, p := range keyPaths {
+		pemBytes, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		pub, err := crypto.ParseEd25519PublicKeyPEM(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+		opts.Keys = append(opts.Keys, pub)
+	}
+	for _, p := range checkpointPaths {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		cps, err := governance.ParseCheckpoints(b)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+		opts.Checkpoints = append(opts.Checkpoints, cps...)
+	}
+	if len(caPaths) > 0 {
+		opts.TSARoots = x509.NewCertPool()
+		for _, p := range caPaths {
+			b, err := os.ReadFile(p)
+			if err != nil {
+				return nil, err
+			}
+			if !opts.TSARoots.AppendCertsFromPEM(b) {
+				return nil, fmt.Errorf("%s: no PEM certificate found", p)
+			}
+		}
+	}
+	rep := governance.VerifyAuditSegment(&seg, opts)
+	return &rep, nil
+}
+
+func printReport(w io.Writer, rep *governance.AuditSegmentReport, strict bool) {
+	fmt.Fprintf(w, "segment      tenant %s, sequences %d–%d (%d entries, %d seals)\n",
+		rep.TenantID, rep.FromSequence, rep.ToSequence, rep.Chain.TotalEvents, rep.Chain.Seals)
+	if rep.Chain.Valid {
+		fmt.Fprintf(w, "chain        intact, head %s\n", rep.Chain.HeadHash)
+	} else {
+		fmt.Fprintf(w, "chain        BROKEN (%d breaks)\n", len(rep.Chain.Breaks))
+	}
+	for _, v := range rep.Checkpoints {
+		ts := v.Timestamp
+		if v.TimestampedAt != nil {
+			ts += " " + v.TimestampedAt.Format("2006-01-02T15:04:05Z")
+		}
+		fmt.Fprintf(w, "checkpoint   %d  key %s  signature %s  head %s  timestamp %s\n",
+			v.Sequence, v.KeyID, v.Signature, v.Head, ts)
+	}
+	if rep.AnchoredThrough > 0 {
+		fmt.Fprintf(w, "anchored     through sequence %d (%d entries after it unanchored)\n", rep.AnchoredThrough, rep.Unanchored)
+	} else {
+		fmt.Fprintln(w, "anchored     no")
+	}
+	for _, s := range rep.Warnings {
+		fmt.Fprintln(w, "warning:", s)
+	}
+	for _, s := range rep.Failures {
+		fmt.Fprintln(w, "FAIL:", s)
+	}
+	switch {
+	case !rep.Valid:
+		fmt.Fprintln(w, "result       INVALID")
+	case strict && len(rep.Warnings) > 0:
+		fmt.Fprintln(w, "result       INVALID (--strict: warnings are failures)")
+	default:
+		fmt.Fprintln(w, "result       valid")
+	}
+}
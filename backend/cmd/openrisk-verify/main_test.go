@@ -0,0 +1,159 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/application/governance"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/crypto"
+	"github.com/opendefender/openrisk/pkg/crypto/tsatest"
+)
+
+// fixture writes a three-entry segment checkpointed at its head, the signer's
+// public key and the TSA root into a temp directory.
+type fixture struct {
+	dir, segment, key, tsaCA string
+	seg                      governance.AuditSegment
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	tsa := tsatest.NewServer()
+	t.Cleanup(tsa.Close)
+	signer, err := crypto.NewSignerFromSecret("verify-command-test-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenant := uuid.New()
+	var events []domain.AuditEvent
+	prev := domain.GenesisHash
+	for i, s := range []string{"risk created", "risk accepted", "risk closed"} {
+		e := domain.AuditEvent{TenantID: tenant, Action: domain.AuditActionUpdate, EntityType: "risk", Summary: s}
+		e.SealChain(int64(i+1), prev)
+		prev = e.Hash
+		events = append(events, e)
+	}
+	head := events[len(events)-1]
+	c := domain.AuditCheckpoint{ID: uuid.New(), TenantID: tenant, Sequence: head.Sequence, HeadHash: head.Hash,
+		SignedAt: time.Now().UTC().Truncate(time.Microsecond), Algorithm: signer.Algorithm(), KeyID: signer.KeyID()}
+	sig := signer.Sign(c.SignedPayload())
+	c.Signature = base64.StdEncoding.EncodeToString(sig)
+	sum := sha256.Sum256(sig)
+	token, _, err := crypto.NewTimestampClient(tsa.URL).Timestamp(t.Context(), sum[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.TimestampToken = token
+
+	f := &fixture{dir: t.TempDir()}
+	f.seg = governance.AuditSegment{Kind: governance.AuditSegmentKind, Version: 1, TenantID: tenant, ExportedAt: time.Now().UTC(),
+		FromSequence: 1, ToSequence: 3, Events: events, Seals: []domain.AuditChainSeal{}, Checkpoints: []domain.AuditCheckpoint{c}}
+	f.segment = f.write(t, "segment.json", mustJSON(t, f.seg))
+	f.key = f.write(t, "key.pem", signer.PublicKeyPEM())
+	f.tsaCA = f.write(t, "tsa.pem", tsa.RootPEM)
+	return f
+}
+
+func (f *fixture) write(t *testing.T, name string, b []byte) string {
+	t.Helper()
+	p := filepath.Join(f.dir, name)
+	if err := os.WriteFile(p, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func runVerify(args ...string) (int, string, string) {
+	var out, errOut bytes.Buffer
+	code := run(args, &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestVerify_ValidSegment(t *testing.T) {
+	f := newFixture(t)
+	code, out, stderr := runVerify("--key", f.key, "--tsa-ca", f.tsaCA, "--strict", f.segment)
+	if code != exitOK {
+		t.Fatalf("exit %d\n%s%s", code, out, stderr)
+	}
+	for _, want := range []string{"chain        intact", "signature valid", "head match", "timestamp verified", "result       valid"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+
+	// Without the TSA root the token's signature still checks, but --strict
+	// refuses to call that verified.
+	if code, out, _ := runVerify("--key", f.key, f.segment); code != exitOK || !strings.Contains(out, "warning:") {
+		t.Fatalf("exit %d\n%s", code, out)
+	}
+	if code, _, _ := runVerify("--key", f.key, "--strict", f.segment); code != exitFailed {
+		t.Fatalf("--strict with warnings: exit %d", code)
+	}
+}
+
+func TestVerify_RewrittenSegmentFails(t *testing.T) {
+	f := newFixture(t)
+	// The operator rewrites entry 2, re-hashes the chain and drops the
+	// checkpoint from the export; the auditor still has the delivered copy.
+	kept := f.write(t, "kept.json", mustJSON(t, map[string]any{"items": f.seg.Checkpoints}))
+	seg := f.seg
+	seg.Events = append([]domain.AuditEvent(nil), f.seg.Events...)
+	seg.Events[1].Summary = "risk rejected"
+	for i := 1; i < len(seg.Events); i++ {
+		seg.Events[i].SealChain(seg.Events[i].Sequence, seg.Events[i-1].Hash)
+	}
+	seg.Checkpoints = []domain.AuditCheckpoint{}
+	rewritten := f.write(t, "rewritten.json", mustJSON(t, seg))
+
+	if code, out, _ := runVerify("--key", f.key, rewritten); code != exitOK || !strings.Contains(out, "anchored     no") {
+		t.Fatalf("on its own the rewrite is consistent: exit %d\n%s", code, out)
+	}
+	code, out, _ := runVerify("--key", f.key, "--checkpoints", kept, "-o", "json", rewritten)
+	if code != exitFailed {
+		t.Fatalf("exit %d\n%s", code, out)
+	}
+	var rep governance.AuditSegmentReport
+	if err := json.Unmarshal([]byte(out), &rep); err != nil {
+		t.Fatal(err)
+	}
+	if rep.Valid || len(rep.Checkpoints) != 1 || rep.Checkpoints[0].Head != governance.CheckpointHeadMismatch {
+		t.Fatalf("expected the kept checkpoint to expose the rewrite: %+v", rep)
+	}
+}
+
+func TestVerify_Usage(t *testing.T) {
+	f := newFixture(t)
+	if code, _, _ := runVerify(f.segment); code != exitError {
+		t.Fatalf("a key is required: exit %d", code)
+	}
+	if code, _, stderr := runVerify("--key", filepath.Join(f.dir, "missing.pem"), f.segment); code != exitError || stderr == "" {
+		t.Fatalf("unreadable key: exit %d", code)
+	}
+	if code, _, _ := runVerify("--key", f.key, "--tsa-ca", f.key, f.segment); code != exitError {
+		t.Fatalf("a CA file without certificates: exit %d", code)
+	}
+}
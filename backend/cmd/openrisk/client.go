@@ -0,0 +1,43 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/opendefender/openrisk/pkg/apiclient"
+)
+
+// client is the generated API client, authenticated with a personal access
+// token.
+type client struct {
+	*apiclient.Client
+}
+
+func newClient(server, token string, timeout time.Duration) *client {
+	c := apiclient.New(server, token)
+	c.HTTPClient = &http.Client{Timeout: timeout}
+	c.UserAgent = "openrisk-cli/" + Version
+	return &client{c}
+}
+
+// explain words an API error for someone holding a token; other errors pass
+// through.
+func explain(err error) error {
+	apiErr, ok := err.(*apiclient.APIError)
+	if !ok {
+		return err
+	}
+	switch apiErr.StatusCode {
+	case http.StatusUnauthorized:
+		return fmt.Errorf("unauthorized: the token is missing, expired or revoked (%s)", apiErr.Message)
+	case http.StatusForbidden:
+		return fmt.Errorf("forbidden: the token's scopes or role do not allow this (%s)", apiErr.Message)
+	}
+	return fmt.Errorf("server returned %d: %s", apiErr.StatusCode, apiErr.Message)
+}
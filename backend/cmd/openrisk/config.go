@@ -0,0 +1,212 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendefender/openrisk/pkg/apiclient"
+)
+
+func runConfig(g *globals, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: openrisk config export|import [flags]")
+	}
+	switch args[0] {
+	case "export":
+		return runConfigExport(g, args[1:])
+	case "import":
+		return runConfigImport(g, args[1:])
+	}
+	return fmt.Errorf("unknown config command %q (export or import)", args[0])
+}
+
+// runConfigExport writes the tenant configuration as YAML (or JSON with -o
+// json). Keys keep the server's order, so successive exports diff cleanly.
+func runConfigExport(g *globals, args []string) error {
+	fs := g.flags("config export", "[-f FILE]")
+	file := fs.String("f", "", "write to FILE instead of stdout")
+	if _, err := g.parse(fs, args); err != nil {
+		return err
+	}
+	if g.output == outputJUnit {
+		return errors.New("config export writes YAML (default) or JSON")
+	}
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	var raw json.RawMessage
+	// Raw, not the generated Document: the YAML keeps the server's key order and
+	// every field, so an export imports back unchanged.
+	if err := c.Do(context.Background(), http.MethodGet, "/api/v1/config/export", nil, nil, &raw); err != nil {
+		return err
+	}
+
+	out := []byte(raw)
+	if g.output == outputJSON {
+		var v any
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return err
+		}
+		if out, err = json.MarshalIndent(v, "", "  "); err != nil {
+			return err
+		}
+		out = append(out, '\n')
+	} else if out, err = jsonToYAML(raw); err != nil {
+		return err
+	}
+
+	if *file == "" {
+		_, err = g.stdout.Write(out)
+		return err
+	}
+	if err := os.WriteFile(*file, out, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(g.stderr, "wrote %s\n", *file)
+	return nil
+}
+
+// runConfigImport applies a YAML (or JSON) configuration document and prints
+// what changed. --dry-run prints what would change.
+func runConfigImport(g *globals, args []string) error {
+	fs := g.flags("config import", "-f FILE [--dry-run]")
+	var (
+		file   = fs.String("f", "", "configuration document to apply (- for stdin)")
+		dryRun = fs.Bool("dry-run", false, "report the changes without applying them")
+	)
+	if _, err := g.parse(fs, args); err != nil {
+		return err
+	}
+	if g.output == outputJUnit {
+		return errors.New("junit output is available for push, vulns and gate")
+	}
+	if *file == "" {
+		fs.Usage()
+		return errors.New("-f is required")
+	}
+	var data []byte
+	var err error
+	if *file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(*file)
+	}
+	if err != nil {
+		return err
+	}
+	doc, err := yamlToJSON(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *file, err)
+	}
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+
+	var res apiclient.TenantconfigResult
+	q := url.Values{}
+	if *dryRun {
+		q.Set("dry_run", "true")
+	}
+	if err := c.Do(context.Background(), http.MethodPost, "/api/v1/config/import", q, json.RawMessage(doc), &res); err != nil {
+		return err
+	}
+	if g.output == outputJSON {
+		return writeJSON(g.stdout, res)
+	}
+	rows := make([][]string, 0, len(res.Changes))
+	for _, ch := range res.Changes {
+		rows = append(rows, []string{ch.Section, ch.Key, ch.Action})
+	}
+	if err := writeTable(g.stdout, []string{"SECTION", "KEY", "ACTION"}, rows); err != nil {
+		return err
+	}
+	verb := "applied"
+	if res.DryRun {
+		verb = "dry run"
+	}
+	fmt.Fprintf(g.stderr, "%s: %d created, %d updated, %d unchanged\n", verb, res.Created, res.Updated, res.Unchanged)
+	return nil
+}
+
+// jsonToYAML re-encodes a JSON document as block YAML, keeping key order.
+// JSON is YAML, so decoding it into a node tree keeps the order; only the
+// flow styles need dropping.
+func jsonToYAML(data []byte) ([]byte, error) {
+	var n yaml.Node
+	if err := yaml.Unmarshal(data, &n); err != nil {
+		return nil, err
+	}
+	blockStyle(&n)
+	return yaml.Marshal(&n)
+}
+
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+// yamlToJSON decodes a YAML document (JSON included) into JSON for the API.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	if _, ok := v.(map[string]any); !ok {
+		return nil, errors.New("a configuration document is a YAML mapping")
+	}
+	return json.Marshal(jsonCompatible(v))
+}
+
+// jsonCompatible turns yaml.v3's decoded values into what encoding/json
+// accepts: mappings with non-string keys are keyed by their text.
+func jsonCompatible(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			t[k] = jsonCompatible(e)
+		}
+		return t
+	case map[any]any:
+		out := make(map[string]any, len(t))
+		for k, e := range t {
+			out[keyString(k)] = jsonCompatible(e)
+		}
+		return out
+	case []any:
+		for i, e := range t {
+			t[i] = jsonCompatible(e)
+		}
+		return t
+	}
+	return v
+}
+
+func keyString(k any) string {
+	switch t := k.(type) {
+	case string:
+		return t
+	case int:
+		return strconv.Itoa(t)
+	case bool:
+		return strconv.FormatBool(t)
+	}
+	return fmt.Sprint(k)
+}
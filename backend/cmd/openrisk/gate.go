@@ -0,0 +1,255 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opendefender/openrisk/pkg/apiclient"
+)
+
+// errGateFailed makes the process exit 1 without printing an error: the
+// report already says why.
+var errGateFailed = errors.New("gate failed")
+
+// baseline is the set of findings a team has accepted as pre-existing. It is
+// a file in the repository, so accepting a finding is a reviewed commit.
+type baseline struct {
+	Version   int            `json:"version"`
+	AssetID   string         `json:"asset_id,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	Findings  []baselineItem `json:"findings"`
+}
+
+type baselineItem struct {
+	ID    string `json:"id"`
+	CVEID string `json:"cve_id,omitempty"`
+	Title string `json:"title"`
+}
+
+// gateReport is the gate's verdict, as printed with -o json.
+type gateReport struct {
+	Passed    bool            `json:"passed"`
+	AssetID   string          `json:"asset_id,omitempty"`
+	Criteria  string          `json:"criteria"`
+	Since     *time.Time      `json:"since,omitempty"`
+	Matching  int             `json:"matching"`
+	Baselined int             `json:"baselined"`
+	New       []vulnerability `json:"new"`
+}
+
+func runGate(g *globals, args []string) error {
+	fs := g.flags("gate", "[flags]")
+	var (
+		asset    = fs.String("asset", "", "asset id or name (default: the whole tenant)")
+		tiers    = fs.String("tier", "P1", "comma-separated priority tiers that fail the gate (empty: none)")
+		kev      = fs.Bool("kev", true, "fail on CISA Known Exploited Vulnerabilities of any tier")
+		baseFile = fs.String("baseline", "", "baseline file: findings listed in it are not new")
+		update   = fs.Bool("update-baseline", false, "write the current findings to --baseline and pass")
+		since    = fs.String("since", "", "only findings first seen at or after this instant (RFC 3339 or YYYY-MM-DD)")
+	)
+	if _, err := g.parse(fs, args); err != nil {
+		return err
+	}
+	tierList := splitList(strings.ToUpper(*tiers))
+	if len(tierList) == 0 && !*kev {
+		return errors.New("nothing to gate on: set --tier or --kev")
+	}
+	if *update && *baseFile == "" {
+		return errors.New("--update-baseline needs --baseline")
+	}
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	report := gateReport{Criteria: gateCriteria(tierList, *kev), New: []vulnerability{}}
+	base := apiclient.VulnerabilityListParams{Status: openStatuses}
+	if *asset != "" {
+		if report.AssetID, err = resolveAsset(ctx, c, *asset); err != nil {
+			return err
+		}
+		base.AssetID = report.AssetID
+	}
+	if *since != "" {
+		at, err := parseSince(*since)
+		if err != nil {
+			return err
+		}
+		report.Since = &at
+		base.FirstSeenAfter = at.Format(time.RFC3339)
+	}
+
+	// The server ANDs its filters, so "P1 or KEV" is two queries, unioned.
+	var queries []apiclient.VulnerabilityListParams
+	if len(tierList) > 0 {
+		q := base
+		q.Tier = strings.Join(tierList, ",")
+		queries = append(queries, q)
+	}
+	if *kev {
+		q := base
+		q.KEV = "true"
+		queries = append(queries, q)
+	}
+	matching, err := unionVulns(ctx, c, queries)
+	if err != nil {
+		return err
+	}
+	report.Matching = len(matching)
+
+	if *update {
+		b := baseline{Version: 1, AssetID: report.AssetID, CreatedAt: time.Now().UTC(), Findings: []baselineItem{}}
+		for _, v := range matching {
+			b.Findings = append(b.Findings, baselineItem{ID: v.ID, CVEID: v.CVEID, Title: v.Title})
+		}
+		if err := writeBaseline(*baseFile, b); err != nil {
+			return err
+		}
+		fmt.Fprintf(g.stderr, "baseline %s: %d findings\n", *baseFile, len(b.Findings))
+		report.Passed, report.Baselined = true, len(matching)
+		return printGate(g, report, matching, nil)
+	}
+
+	known := map[string]bool{}
+	if *baseFile != "" {
+		b, err := readBaseline(*baseFile)
+		if err != nil {
+			return err
+		}
+		for _, f := range b.Findings {
+			known[f.ID] = true
+		}
+	}
+	for _, v := range matching {
+		if known[v.ID] {
+			report.Baselined++
+			continue
+		}
+		report.New = append(report.New, v)
+	}
+	report.Passed = len(report.New) == 0
+
+	if err := printGate(g, report, matching, known); err != nil {
+		return err
+	}
+	if !report.Passed {
+		fmt.Fprintf(g.stderr, "gate failed: %d new %s finding(s) (%d matching, %d in baseline)\n",
+			len(report.New), report.Criteria, report.Matching, report.Baselined)
+		return errGateFailed
+	}
+	fmt.Fprintf(g.stderr, "gate passed: no new %s findings (%d matching, %d in baseline)\n",
+		report.Criteria, report.Matching, report.Baselined)
+	return nil
+}
+
+func printGate(g *globals, report gateReport, matching []vulnerability, known map[string]bool) error {
+	switch g.output {
+	case outputJSON:
+		return writeJSON(g.stdout, report)
+	case outputJUnit:
+		suite := &junitSuite{Name: "openrisk gate (" + report.Criteria + ")"}
+		for _, v := range matching {
+			var f *junitFailure
+			if !known[v.ID] && !report.Passed {
+				f = vulnFailure(v)
+			}
+			suite.add(vulnClass(v), vulnName(v), f)
+		}
+		if len(matching) == 0 {
+			suite.add("openrisk.gate", "no "+report.Criteria+" findings", nil)
+		}
+		return writeJUnit(g.stdout, suite)
+	}
+	if len(report.New) == 0 {
+		return nil
+	}
+	rows := make([][]string, 0, len(report.New))
+	for _, v := range report.New {
+		rows = append(rows, []string{
+			orDash(v.PriorityTier), string(v.Severity), kevMark(v.KEV), orDash(v.CVEID),
+			truncate(v.Title, 60), orDash(truncate(v.AssetName, 30)), v.FirstSeen.Format("2006-01-02"), v.ID,
+		})
+	}
+	return writeTable(g.stdout, []string{"TIER", "SEVERITY", "KEV", "CVE", "TITLE", "ASSET", "FIRST SEEN", "ID"}, rows)
+}
+
+// unionVulns runs every query to the last page and merges the results by id,
+// highest priority first.
+func unionVulns(ctx context.Context, c *client, queries []apiclient.VulnerabilityListParams) ([]vulnerability, error) {
+	byID := map[string]vulnerability{}
+	for _, q := range queries {
+		items, _, err := listVulns(ctx, c, q, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range items {
+			byID[v.ID] = v
+		}
+	}
+	out := make([]vulnerability, 0, len(byID))
+	for _, v := range byID {
+		out = append(out, v)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].PriorityScore != out[j].PriorityScore {
+			return out[i].PriorityScore > out[j].PriorityScore
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+func gateCriteria(tiers []string, kev bool) string {
+	parts := append([]string{}, tiers...)
+	if kev {
+		parts = append(parts, "KEV")
+	}
+	return strings.Join(parts, "/")
+}
+
+// readBaseline reads a baseline file. A missing file is an empty baseline, so
+// the first run of a new pipeline gates on everything rather than erroring.
+func readBaseline(path string) (*baseline, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &baseline{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var b baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", path, err)
+	}
+	return &b, nil
+}
+
+func writeBaseline(path string, b baseline) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
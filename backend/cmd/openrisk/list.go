@@ -0,0 +1,299 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opendefender/openrisk/pkg/apiclient"
+)
+
+// vulnerability is a finding, as the generated client decodes it.
+type vulnerability = apiclient.Vulnerability
+
+// openStatuses are the statuses of a finding still waiting on someone.
+const openStatuses = "open,triaged,in_remediation"
+
+func unresolved(status string) bool {
+	for _, s := range strings.Split(openStatuses, ",") {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// pageLimit is the server's largest page.
+const pageLimit = 200
+
+// listVulns fetches up to max findings matching params (max <= 0: all of
+// them), following pages.
+func listVulns(ctx context.Context, c *client, params apiclient.VulnerabilityListParams, max int) ([]vulnerability, int, error) {
+	var out []vulnerability
+	total := 0
+	for page := 1; ; page++ {
+		limit := pageLimit
+		if max > 0 && max-len(out) < limit {
+			limit = max - len(out)
+		}
+		params.Page, params.Limit = strconv.Itoa(page), strconv.Itoa(limit)
+		res, err := c.VulnerabilityList(ctx, &params)
+		if err != nil {
+			return nil, 0, err
+		}
+		total = int(res.Total)
+		out = append(out, res.Items...)
+		if len(res.Items) == 0 || len(out) >= total || (max > 0 && len(out) >= max) {
+			return out, total, nil
+		}
+	}
+}
+
+func runVulns(g *globals, args []string) error {
+	fs := g.flags("vulns", "[flags]")
+	var (
+		search   = fs.String("q", "", "search CVE id and title")
+		severity = fs.String("severity", "", "comma-separated severities (critical,high,medium,low,info)")
+		status   = fs.String("status", "", "comma-separated statuses (default: any)")
+		tier     = fs.String("tier", "", "comma-separated priority tiers (P1,P2,P3,P4)")
+		source   = fs.String("source", "", "comma-separated sources (sarif,sbom,nessus,…)")
+		kev      = fs.Bool("kev", false, "only CISA Known Exploited Vulnerabilities")
+		minCVSS  = fs.Float64("min-cvss", 0, "minimum CVSS score")
+		asset    = fs.String("asset", "", "asset id or name")
+		since    = fs.String("since", "", "first seen at or after (RFC 3339 or YYYY-MM-DD)")
+		limit    = fs.Int("limit", 50, "maximum findings to list (0: all)")
+	)
+	if _, err := g.parse(fs, args); err != nil {
+		return err
+	}
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	params := apiclient.VulnerabilityListParams{
+		Q:        *search,
+		Severity: *severity,
+		Status:   *status,
+		Tier:     strings.ToUpper(*tier),
+		Source:   *source,
+	}
+	if *kev {
+		params.KEV = "true"
+	}
+	if *minCVSS > 0 {
+		params.MinCVSS = strconv.FormatFloat(*minCVSS, 'f', -1, 64)
+	}
+	if *asset != "" {
+		if params.AssetID, err = resolveAsset(ctx, c, *asset); err != nil {
+			return err
+		}
+	}
+	if *since != "" {
+		at, err := parseSince(*since)
+		if err != nil {
+			return err
+		}
+		params.FirstSeenAfter = at.Format(time.RFC3339)
+	}
+
+	items, total, err := listVulns(ctx, c, params, *limit)
+	if err != nil {
+		return err
+	}
+	switch g.output {
+	case outputJSON:
+		return writeJSON(g.stdout, map[string]any{"items": items, "total": total})
+	case outputJUnit:
+		suite := &junitSuite{Name: "openrisk vulnerabilities"}
+		for _, v := range items {
+			suite.add(vulnClass(v), vulnName(v), vulnFailure(v))
+		}
+		return writeJUnit(g.stdout, suite)
+	}
+	rows := make([][]string, 0, len(items))
+	for _, v := range items {
+		rows = append(rows, []string{
+			orDash(v.PriorityTier), string(v.Severity), kevMark(v.KEV), orDash(v.CVEID),
+			truncate(v.Title, 60), orDash(truncate(v.AssetName, 30)), string(v.Status), v.ID,
+		})
+	}
+	if err := writeTable(g.stdout, []string{"TIER", "SEVERITY", "KEV", "CVE", "TITLE", "ASSET", "STATUS", "ID"}, rows); err != nil {
+		return err
+	}
+	if total > len(items) {
+		fmt.Fprintf(g.stderr, "%d of %d shown (use --limit 0 for all)\n", len(items), total)
+	}
+	return nil
+}
+
+func runRisks(g *globals, args []string) error {
+	fs := g.flags("risks", "[flags]")
+	var (
+		search      = fs.String("q", "", "search title and description")
+		status      = fs.String("status", "", "comma-separated statuses")
+		criticality = fs.String("criticality", "", "comma-separated criticalities")
+		source      = fs.String("source", "", "comma-separated sources")
+		tag         = fs.String("tag", "", "tag")
+		minScore    = fs.Float64("min-score", 0, "minimum score")
+		mine        = fs.Bool("mine", false, "only risks the token's user owns, works on or reviews")
+		limit       = fs.Int("limit", 50, "maximum risks to list (at most 200)")
+	)
+	if _, err := g.parse(fs, args); err != nil {
+		return err
+	}
+	if g.output == outputJUnit {
+		return errors.New("junit output is available for push, vulns and gate")
+	}
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+
+	n := *limit
+	if n <= 0 || n > pageLimit {
+		n = pageLimit
+	}
+	params := &apiclient.ListRisksParams{
+		Q:           *search,
+		Status:      *status,
+		Criticality: *criticality,
+		Source:      *source,
+		Tag:         *tag,
+		Limit:       strconv.Itoa(n),
+		SortBy:      "score",
+		SortDir:     "desc",
+	}
+	if *minScore > 0 {
+		params.MinScore = strconv.FormatFloat(*minScore, 'f', -1, 64)
+	}
+	if *mine {
+		params.Mine = "true"
+	}
+	res, err := c.ListRisks(context.Background(), params)
+	if err != nil {
+		return err
+	}
+	if g.output == outputJSON {
+		return writeJSON(g.stdout, res)
+	}
+	rows := make([][]string, 0, len(res.Items))
+	for _, r := range res.Items {
+		rows = append(rows, []string{
+			strconv.FormatFloat(r.Score, 'f', 1, 64), orDash(string(r.Criticality)), orDash(string(r.Status)),
+			truncate(r.Title, 60), orDash(truncate(r.Owner, 24)), r.ID,
+		})
+	}
+	if err := writeTable(g.stdout, []string{"SCORE", "CRITICALITY", "STATUS", "TITLE", "OWNER", "ID"}, rows); err != nil {
+		return err
+	}
+	if int(res.Total) > len(res.Items) {
+		fmt.Fprintf(g.stderr, "%d of %d shown\n", len(res.Items), res.Total)
+	}
+	return nil
+}
+
+// resolveAsset accepts an asset id or its name. A name must match exactly one
+// asset (case-insensitively): a gate silently checking the wrong asset would
+// pass for the wrong reason.
+func resolveAsset(ctx context.Context, c *client, ref string) (string, error) {
+	if looksLikeUUID(ref) {
+		return ref, nil
+	}
+	assets, err := c.ListAssets(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve asset %q: %w", ref, explain(err))
+	}
+	var ids []string
+	for _, a := range assets {
+		if strings.EqualFold(a.Name, ref) {
+			ids = append(ids, a.ID)
+		}
+	}
+	switch len(ids) {
+	case 1:
+		return ids[0], nil
+	case 0:
+		return "", fmt.Errorf("no asset named %q", ref)
+	default:
+		return "", fmt.Errorf("%d assets are named %q; pass the asset id instead", len(ids), ref)
+	}
+}
+
+func looksLikeUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case i == 8 || i == 13 || i == 18 || i == 23:
+			if r != '-' {
+				return false
+			}
+		case !strings.ContainsRune("0123456789abcdefABCDEF", r):
+			return false
+		}
+	}
+	return true
+}
+
+// parseSince accepts an RFC 3339 instant or a date (midnight UTC).
+func parseSince(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q (expected RFC 3339 or YYYY-MM-DD)", s)
+	}
+	return t, nil
+}
+
+func kevMark(kev bool) string {
+	if kev {
+		return "KEV"
+	}
+	return "-"
+}
+
+func vulnClass(v vulnerability) string {
+	if v.AssetName != "" {
+		return "openrisk." + v.AssetName
+	}
+	return "openrisk.unassigned"
+}
+
+func vulnName(v vulnerability) string {
+	name := v.Title
+	if v.CVEID != "" && !strings.Contains(name, v.CVEID) {
+		name = v.CVEID + " " + name
+	}
+	return name
+}
+
+// vulnFailure reports an unresolved finding as a failure: a JUnit report of
+// findings is read as "what still needs fixing".
+func vulnFailure(v vulnerability) *junitFailure {
+	if !unresolved(string(v.Status)) {
+		return nil
+	}
+	kev := ""
+	if v.KEV {
+		kev = ", known exploited"
+	}
+	return &junitFailure{
+		Message: fmt.Sprintf("%s %s finding%s", orDash(v.PriorityTier), v.Severity, kev),
+		Type:    string(v.Severity),
+		Body: fmt.Sprintf("id: %s\ncve: %s\ncvss: %.1f\nsource: %s\nstatus: %s\nfirst seen: %s\n",
+			v.ID, orDash(v.CVEID), v.CVSSScore, v.Source, v.Status, v.FirstSeen.Format(time.RFC3339)),
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Command openrisk is the OpenRisk command-line client, for CI pipelines and
+// administrators. It authenticates with a personal access token and talks to
+// the public /api/v1 surface only, so anything it does can be scripted with
+// curl too.
+//
+//	openrisk push      upload SARIF, CycloneDX or scanner findings
+//	openrisk vulns     list vulnerabilities
+//	openrisk risks     list risks
+//	openrisk gate      fail a build on new P1/KEV findings
+//	openrisk config    export or import tenant configuration as YAML
+//	openrisk report    generate a report and download it
+//
+// The server and token come from --server/--token or OPENRISK_SERVER and
+// OPENRISK_TOKEN. See docs/CLI.md.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Version is stamped at build time (-ldflags "-X main.Version=…").
+var Version = "dev"
+
+// Exit codes. A failed gate is distinct from a broken invocation, so a
+// pipeline can tell "your change introduced a P1" from "the CLI could not run".
+const (
+	exitOK     = 0
+	exitFailed = 1 // the gate found new findings
+	exitError  = 2 // usage, network or server error
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+type command struct {
+	name    string
+	summary string
+	run     func(g *globals, args []string) error
+}
+
+var commands = []command{
+	{"push", "upload a SARIF, CycloneDX or scanner findings file", runPush},
+	{"vulns", "list vulnerabilities", runVulns},
+	{"risks", "list risks", runRisks},
+	{"gate", "fail when new P1 or KEV findings appeared on an asset", runGate},
+	{"config", "export or import tenant configuration (YAML)", runConfig},
+	{"report", "list report types, or generate and download a report", runReport},
+}
+
+// run is main without the process: it is what the tests drive.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stdout)
+		return exitOK
+	}
+	if args[0] == "version" || args[0] == "--version" {
+		fmt.Fprintln(stdout, "openrisk", Version, runtime.GOOS+"/"+runtime.GOARCH)
+		return exitOK
+	}
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		g := &globals{stdout: stdout, stderr: stderr}
+		err := cmd.run(g, args[1:])
+		switch {
+		case err == nil:
+			return exitOK
+		case errors.Is(err, flag.ErrHelp):
+			return exitOK
+		case errors.Is(err, errGateFailed):
+			return exitFailed
+		default:
+			fmt.Fprintln(stderr, "openrisk:", explain(err))
+			return exitError
+		}
+	}
+	fmt.Fprintf(stderr, "openrisk: unknown command %q\n\n", args[0])
+	usage(stderr)
+	return exitError
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: openrisk <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Every command takes --server, --token and -o table|json|junit.")
+	fmt.Fprintln(w, "Run `openrisk <command> -h` for its flags.")
+}
+
+// globals are the flags every command shares, and where it writes.
+type globals struct {
+	server  string
+	token   string
+	output  string
+	timeout time.Duration
+
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// flags returns a FlagSet for a command with the shared flags registered.
+func (g *globals) flags(name, usageLine string) *flag.FlagSet {
+	fs := flag.NewFlagSet("openrisk "+name, flag.ContinueOnError)
+	fs.SetOutput(g.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(g.stderr, "Usage: openrisk %s %s\n\nFlags:\n", name, usageLine)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&g.server, "server", envOr("OPENRISK_SERVER", "http://localhost:8080"), "OpenRisk base URL (OPENRISK_SERVER)")
+	fs.StringVar(&g.token, "token", os.Getenv("OPENRISK_TOKEN"), "personal access token (OPENRISK_TOKEN)")
+	fs.StringVar(&g.output, "o", "table", "output format: table, json or junit")
+	fs.DurationVar(&g.timeout, "timeout", 60*time.Second, "timeout for each API request")
+	return fs
+}
+
+// parse parses a command's flags, letting flags and positional arguments mix
+// (`openrisk push scan.sarif --asset web`), and checks the shared ones.
+func (g *globals) parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	switch g.output {
+	case outputTable, outputJSON, outputJUnit:
+	default:
+		return nil, fmt.Errorf("unknown output format %q (table, json or junit)", g.output)
+	}
+	return positional, nil
+}
+
+// client returns an API client, refusing to run without a token: every
+// endpoint the CLI calls is authenticated, and a 401 is a worse error message.
+func (g *globals) client() (*client, error) {
+	if strings.TrimSpace(g.token) == "" {
+		return nil, errors.New("no token: pass --token or set OPENRISK_TOKEN to a personal access token")
+	}
+	return newClient(g.server, g.token, g.timeout), nil
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeAPI serves the handful of endpoints the CLI calls.
+type fakeAPI struct {
+	t       *testing.T
+	vulns   []vulnerability
+	queries []string
+	upload  map[string]string
+	config  map[string]any
+	imports []map[string]any
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer pat_test" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid token"})
+		return
+	}
+	write := func(status int, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	switch r.Method + " " + r.URL.Path {
+	case "GET /api/v1/vulnerabilities":
+		q := r.URL.Query()
+		f.queries = append(f.queries, q.Encode())
+		var items []vulnerability
+		for _, v := range f.vulns {
+			if t := q.Get("tier"); t != "" && !strings.Contains(t, v.PriorityTier) {
+				continue
+			}
+			if q.Get("kev") == "true" && !v.KEV {
+				continue
+			}
+			if a := q.Get("asset_id"); a != "" && (v.AssetID == nil || *v.AssetID != a) {
+				continue
+			}
+			items = append(items, v)
+		}
+		write(http.StatusOK, map[string]any{"items": items, "total": len(items), "page": 1, "limit": pageLimit})
+	case "GET /api/v1/assets":
+		write(http.StatusOK, []map[string]string{
+			{"id": "6f1c2e0a-0000-4000-8000-000000000001", "name": "web"},
+			{"id": "6f1c2e0a-0000-4000-8000-000000000002", "name": "db"},
+		})
+	case "POST /api/v1/vulnerabilities/upload":
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			f.t.Fatalf("multipart: %v", err)
+		}
+		f.upload = map[string]string{}
+		for k, v := range r.MultipartForm.Value {
+			f.upload[k] = v[0]
+		}
+		file, hdr, err := r.FormFile("file")
+		if err != nil {
+			f.t.Fatalf("file part: %v", err)
+		}
+		data, _ := io.ReadAll(file)
+		f.upload["filename"] = hdr.Filename
+		f.upload["content"] = string(data)
+		write(http.StatusCreated, map[string]any{"source": f.upload["format"], "received": 2, "created": 2})
+	case "GET /api/v1/config/export":
+		write(http.StatusOK, f.config)
+	case "POST /api/v1/config/import":
+		var doc map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&doc); err != nil {
+			f.t.Fatalf("import body: %v", err)
+		}
+		f.imports = append(f.imports, doc)
+		write(http.StatusOK, map[string]any{
+			"dry_run": r.URL.Query().Get("dry_run") == "true",
+			"updated": 1,
+			"changes": []map[string]string{{"section": "scoring_weights", "key": "weights", "action": "update"}},
+		})
+	default:
+		write(http.StatusNotFound, map[string]string{"error": "not found"})
+	}
+}
+
+func newFakeAPI(t *testing.T) (*fakeAPI, string) {
+	t.Helper()
+	f := &fakeAPI{t: t}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv.URL
+}
+
+func runCLI(t *testing.T, server string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	args = append(args, "--server", server, "--token", "pat_test")
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func strp(s string) *string { return &s }
+
+const webID = "6f1c2e0a-0000-4000-8000-000000000001"
+
+func TestGate_FailsOnNewFindingsAndPassesOnceBaselined(t *testing.T) {
+	api, server := newFakeAPI(t)
+	api.vulns = []vulnerability{
+		{ID: "v1", CVEID: "CVE-2026-0001", Title: "RCE", Severity: "critical", PriorityTier: "P1", PriorityScore: 95, AssetID: strp(webID), AssetName: "web", Status: "open"},
+		{ID: "v2", CVEID: "CVE-2025-0002", Title: "Old KEV", Severity: "medium", PriorityTier: "P3", PriorityScore: 40, KEV: true, AssetID: strp(webID), AssetName: "web", Status: "open"},
+		{ID: "v3", Title: "Noise", Severity: "low", PriorityTier: "P4", AssetID: strp(webID), AssetName: "web", Status: "open"},
+	}
+	baselineFile := filepath.Join(t.TempDir(), "openrisk-baseline.json")
+
+	code, out, stderr := runCLI(t, server, "gate", "--asset", "web", "--baseline", baselineFile)
+	if code != exitFailed {
+		t.Fatalf("exit = %d, want %d; stderr: %s", code, exitFailed, stderr)
+	}
+	if !strings.Contains(out, "CVE-2026-0001") || !strings.Contains(out, "CVE-2025-0002") || strings.Contains(out, "Noise") {
+		t.Fatalf("table should list the P1 and the KEV finding only:\n%s", out)
+	}
+	for _, q := range api.queries {
+		if !strings.Contains(q, "asset_id="+webID) || !strings.Contains(q, "status=open%2Ctriaged%2Cin_remediation") {
+			t.Fatalf("query not scoped to the asset's open findings: %s", q)
+		}
+	}
+
+	if code, _, stderr = runCLI(t, server, "gate", "--asset", "web", "--baseline", baselineFile, "--update-baseline"); code != exitOK {
+		t.Fatalf("update-baseline exit = %d; stderr: %s", code, stderr)
+	}
+	if code, _, stderr = runCLI(t, server, "gate", "--asset", "web", "--baseline", baselineFile); code != exitOK {
+		t.Fatalf("baselined gate exit = %d; stderr: %s", code, stderr)
+	}
+
+	// A new P1 after the baseline fails the gate again, and only it is reported.
+	api.vulns = append(api.vulns, vulnerability{ID: "v4", CVEID: "CVE-2026-0004", Title: "New RCE", Severity: "critical", PriorityTier: "P1", PriorityScore: 90, AssetID: strp(webID), AssetName: "web", Status: "open"})
+	code, out, _ = runCLI(t, server, "gate", "--asset", "web", "--baseline", baselineFile, "-o", "json")
+	if code != exitFailed {
+		t.Fatalf("exit = %d, want %d", code, exitFailed)
+	}
+	var rep gateReport
+	if err := json.Unmarshal([]byte(out), &rep); err != nil {
+		t.Fatalf("json output: %v\n%s", err, out)
+	}
+	if rep.Passed || rep.Matching != 3 || rep.Baselined != 2 || len(rep.New) != 1 || rep.New[0].ID != "v4" {
+		t.Fatalf("report = %+v", rep)
+	}
+}
+
+func TestGate_JUnitMarksOnlyNewFindingsAsFailures(t *testing.T) {
+	api, server := newFakeAPI(t)
+	api.vulns = []vulnerability{
+		{ID: "v1", CVEID: "CVE-2026-0001", Title: "RCE", Severity: "critical", PriorityTier: "P1", AssetName: "web", Status: "open"},
+		{ID: "v2", CVEID: "CVE-2026-0002", Title: "SQLi", Severity: "high", PriorityTier: "P1", AssetName: "web", Status: "open"},
+	}
+	baselineFile := filepath.Join(t.TempDir(), "baseline.json")
+	if err := writeBaseline(baselineFile, baseline{Version: 1, Findings: []baselineItem{{ID: "v1"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	code, out, _ := runCLI(t, server, "gate", "--baseline", baselineFile, "-o", "junit")
+	if code != exitFailed {
+		t.Fatalf("exit = %d, want %d", code, exitFailed)
+	}
+	var suite struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Cases    []struct {
+			Name    string    `xml:"name,attr"`
+			Failure *struct{} `xml:"failure"`
+		} `xml:"testcase"`
+	}
+	if err := xml.Unmarshal([]byte(out), &suite); err != nil {
+		t.Fatalf("junit output: %v\n%s", err, out)
+	}
+	if suite.Tests != 2 || suite.Failures != 1 {
+		t.Fatalf("suite = %+v", suite)
+	}
+	for _, c := range suite.Cases {
+		if (c.Failure != nil) != strings.Contains(c.Name, "CVE-2026-0002") {
+			t.Fatalf("case %q failure = %v", c.Name, c.Failure != nil)
+		}
+	}
+}
+
+func TestPush_DetectsFormatAndResolvesAssetName(t *testing.T) {
+	api, server := newFakeAPI(t)
+	dir := t.TempDir()
+	sarif := filepath.Join(dir, "results.json")
+	if err := os.WriteFile(sarif, []byte(`{"version":"2.1.0","$schema":"https://json.schemastore.org/sarif-2.1.0.json","runs":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	code, out, stderr := runCLI(t, server, "push", sarif, "--asset", "web", "--auto-create-risk")
+	if code != exitOK {
+		t.Fatalf("exit = %d; stderr: %s", code, stderr)
+	}
+	if api.upload["format"] != "sarif" || api.upload["default_asset_id"] != webID || api.upload["auto_create_risk"] != "true" || api.upload["filename"] != "results.json" {
+		t.Fatalf("upload = %+v", api.upload)
+	}
+	if !strings.Contains(out, "results.json") {
+		t.Fatalf("table:\n%s", out)
+	}
+
+	unknown := filepath.Join(dir, "export.json")
+	if err := os.WriteFile(unknown, []byte(`[{"plugin_id":1}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if code, _, _ = runCLI(t, server, "push", unknown); code != exitError {
+		t.Fatalf("undetectable format: exit = %d, want %d", code, exitError)
+	}
+	if code, _, stderr = runCLI(t, server, "push", unknown, "--format", "nessus"); code != exitOK || api.upload["format"] != "nessus" {
+		t.Fatalf("explicit format: exit = %d, upload = %+v; stderr: %s", code, api.upload, stderr)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	cases := []struct {
+		path, body, want string
+	}{
+		{"scan.json", `{"bomFormat":"CycloneDX","specVersion":"1.5"}`, "cyclonedx"},
+		{"scan.json", `{"version":"2.1.0","runs":[]}`, "sarif"},
+		{"codeql.sarif", `not json`, "sarif"},
+		{"app.cdx.json", `{}`, "cyclonedx"},
+		{"bom.json", `{}`, "cyclonedx"},
+	}
+	for _, c := range cases {
+		got, err := detectFormat(c.path, []byte(c.body))
+		if err != nil || got != c.want {
+			t.Errorf("detectFormat(%q) = %q, %v; want %q", c.path, got, err, c.want)
+		}
+	}
+	if _, err := detectFormat("findings.json", []byte(`[]`)); err == nil {
+		t.Error("a bare JSON array should need --format")
+	}
+}
+
+func TestConfig_ExportsYAMLAndImportsItBack(t *testing.T) {
+	api, server := newFakeAPI(t)
+	api.config = map[string]any{
+		"api_version": "openrisk/v1",
+		"kind":        "TenantConfig",
+		"risk_categories": []any{
+			map[string]any{"slug": "cyber", "name": "Cyber", "active": true},
+		},
+		"scoring_weights": map[string]any{"impact": 0.5, "probability": 0.5},
+	}
+	file := filepath.Join(t.TempDir(), "openrisk.yaml")
+
+	code, _, stderr := runCLI(t, server, "config", "export", "-f", file)
+	if code != exitOK {
+		t.Fatalf("export exit = %d; stderr: %s", code, stderr)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "kind: TenantConfig\n") || strings.Contains(string(data), "{") {
+		t.Fatalf("export should be block YAML:\n%s", data)
+	}
+
+	code, out, stderr := runCLI(t, server, "config", "import", "-f", file, "--dry-run")
+	if code != exitOK {
+		t.Fatalf("import exit = %d; stderr: %s", code, stderr)
+	}
+	if len(api.imports) != 1 {
+		t.Fatalf("imports = %d", len(api.imports))
+	}
+	got, _ := json.Marshal(api.imports[0])
+	want, _ := json.Marshal(api.config)
+	if string(got) != string(want) {
+		t.Fatalf("round trip changed the document:\n got %s\nwant %s", got, want)
+	}
+	if !strings.Contains(out, "scoring_weights") || !strings.Contains(stderr, "dry run: 0 created, 1 updated") {
+		t.Fatalf("stdout:\n%s\nstderr:\n%s", out, stderr)
+	}
+}
+
+func TestRun_ReportsAuthAndUsageErrors(t *testing.T) {
+	_, server := newFakeAPI(t)
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"vulns", "--server", server, "--token", "pat_wrong"}, &stdout, &stderr); code != exitError {
+		t.Fatalf("bad token: exit = %d", code)
+	}
+	if !strings.Contains(stderr.String(), "unauthorized") {
+		t.Fatalf("stderr = %q", stderr.String())
+	}
+
+	stderr.Reset()
+	t.Setenv("OPENRISK_TOKEN", "")
+	if code := run([]string{"vulns", "--server", server}, &stdout, &stderr); code != exitError || !strings.Contains(stderr.String(), "no token") {
+		t.Fatalf("missing token: stderr = %q", stderr.String())
+	}
+	if code := run([]string{"vulns", "-o", "xml", "--token", "x"}, &stdout, &stderr); code != exitError {
+		t.Fatalf("unknown output: exit = %d", code)
+	}
+	if code := run([]string{"frobnicate"}, &stdout, &stderr); code != exitError {
+		t.Fatalf("unknown command: exit = %d", code)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/opendefender/openrisk/pkg/apiclient"
+)
+
+// pushResult is one file's outcome: the server's ingest summary, or the
+// reason it was refused.
+type pushResult struct {
+	File     string `json:"file"`
+	Format   string `json:"format"`
+	Source   string `json:"source,omitempty"`
+	Received int    `json:"received"`
+	Created  int    `json:"created"`
+	Updated  int    `json:"updated"`
+	Skipped  int    `json:"skipped"`
+	Error    string `json:"error,omitempty"`
+}
+
+func runPush(g *globals, args []string) error {
+	fs := g.flags("push", "[flags] FILE...")
+	var (
+		format     = fs.String("format", "", "sarif, cyclonedx, or a scanner source (nessus, qualys, …); detected for SARIF and CycloneDX")
+		asset      = fs.String("asset", "", "asset id or name every finding is attached to")
+		autoRisk   = fs.Bool("auto-create-risk", false, "let P1/KEV findings on a known asset open risks")
+		autoTicket = fs.Bool("auto-create-ticket", false, "let P1/KEV findings open tickets")
+	)
+	files, err := g.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		fs.Usage()
+		return errors.New("no file to push")
+	}
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	assetID := ""
+	if *asset != "" {
+		if assetID, err = resolveAsset(ctx, c, *asset); err != nil {
+			return err
+		}
+	}
+
+	results := make([]pushResult, 0, len(files))
+	failed := 0
+	for _, path := range files {
+		res := pushResult{File: path}
+		data, err := os.ReadFile(path)
+		if err == nil {
+			res.Format = *format
+			if res.Format == "" {
+				res.Format, err = detectFormat(path, data)
+			}
+		}
+		if err == nil {
+			form := apiclient.Form{
+				Fields: map[string]string{
+					"format":             res.Format,
+					"auto_create_risk":   strconv.FormatBool(*autoRisk),
+					"auto_create_ticket": strconv.FormatBool(*autoTicket),
+				},
+				Files: map[string]apiclient.File{"file": {Name: filepath.Base(path), Data: data}},
+			}
+			if assetID != "" {
+				form.Fields["default_asset_id"] = assetID
+			}
+			var out *apiclient.IngestResult
+			out, err = c.UploadVulnerabilityFile(ctx, form)
+			if err == nil {
+				res.Source, res.Received, res.Created, res.Updated, res.Skipped =
+					string(out.Source), int(out.Received), int(out.Created), int(out.Updated), int(out.Skipped)
+			}
+		}
+		if err != nil {
+			res.Error = explain(err).Error()
+			failed++
+		}
+		results = append(results, res)
+	}
+
+	switch g.output {
+	case outputJSON:
+		if err := writeJSON(g.stdout, map[string]any{"files": results}); err != nil {
+			return err
+		}
+	case outputJUnit:
+		suite := &junitSuite{Name: "openrisk push"}
+		for _, r := range results {
+			var f *junitFailure
+			if r.Error != "" {
+				f = &junitFailure{Message: r.Error}
+			}
+			suite.add("openrisk.push", r.File, f)
+		}
+		if err := writeJUnit(g.stdout, suite); err != nil {
+			return err
+		}
+	default:
+		rows := make([][]string, 0, len(results))
+		for _, r := range results {
+			rows = append(rows, []string{
+				r.File, orDash(r.Format), strconv.Itoa(r.Received), strconv.Itoa(r.Created),
+				strconv.Itoa(r.Updated), strconv.Itoa(r.Skipped), orDash(r.Error),
+			})
+		}
+		if err := writeTable(g.stdout, []string{"FILE", "FORMAT", "RECEIVED", "CREATED", "UPDATED", "SKIPPED", "ERROR"}, rows); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files could not be pushed", failed, len(files))
+	}
+	return nil
+}
+
+// detectFormat recognises SARIF and CycloneDX by content, then by name.
+// Anything else needs --format: a scanner's JSON export does not say which
+// scanner wrote it.
+func detectFormat(path string, data []byte) (string, error) {
+	var probe struct {
+		Schema    string          `json:"$schema"`
+		Version   string          `json:"version"`
+		Runs      json.RawMessage `json:"runs"`
+		BOMFormat string          `json:"bomFormat"`
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		if json.Unmarshal(trimmed, &probe) == nil {
+			switch {
+			case strings.EqualFold(probe.BOMFormat, "CycloneDX"):
+				return "cyclonedx", nil
+			case probe.Runs != nil && (strings.Contains(strings.ToLower(probe.Schema), "sarif") || strings.HasPrefix(probe.Version, "2.")):
+				return "sarif", nil
+			}
+		}
+	}
+	name := strings.ToLower(filepath.Base(path))
+	switch {
+	case strings.HasSuffix(name, ".sarif") || strings.HasSuffix(name, ".sarif.json"):
+		return "sarif", nil
+	case strings.HasSuffix(name, ".cdx.json") || name == "bom.json":
+		return "cyclonedx", nil
+	}
+	return "", fmt.Errorf("%s: cannot tell the format; pass --format (sarif, cyclonedx or a scanner source)", path)
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opendefender/openrisk/pkg/apiclient"
+)
+
+// terminal reports whether a report has finished generating, either way.
+func terminal(r *apiclient.DomainReport) bool {
+	return r.RunState == apiclient.ReportRunStateSucceeded || r.RunState == apiclient.ReportRunStateFailed
+}
+
+func runReport(g *globals, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: openrisk report types|create [flags]")
+	}
+	switch args[0] {
+	case "types":
+		return runReportTypes(g, args[1:])
+	case "create":
+		return runReportCreate(g, args[1:])
+	}
+	return fmt.Errorf("unknown report command %q (types or create)", args[0])
+}
+
+func runReportTypes(g *globals, args []string) error {
+	fs := g.flags("report types", "[--locale fr|en]")
+	locale := fs.String("locale", "en", "language of titles: fr or en")
+	if _, err := g.parse(fs, args); err != nil {
+		return err
+	}
+	if g.output == outputJUnit {
+		return errors.New("junit output is available for push, vulns and gate")
+	}
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	res, err := c.ReportCatalogue(context.Background(), &apiclient.ReportCatalogueParams{Locale: *locale})
+	if err != nil {
+		return err
+	}
+	if g.output == outputJSON {
+		return writeJSON(g.stdout, res)
+	}
+	rows := make([][]string, 0, len(res.Types))
+	for _, t := range res.Types {
+		formats := make([]string, 0, len(t.Formats))
+		for _, f := range t.Formats {
+			formats = append(formats, f.Key)
+		}
+		rows = append(rows, []string{t.Type, strings.Join(formats, ","), t.Scope, t.Title})
+	}
+	return writeTable(g.stdout, []string{"TYPE", "FORMATS", "NEEDS", "TITLE"}, rows)
+}
+
+// runReportCreate queues a report. With --wait or --out it follows the report
+// to completion, and --out downloads it.
+func runReportCreate(g *globals, args []string) error {
+	fs := g.flags("report create", "--type TYPE [flags]")
+	var (
+		typ       = fs.String("type", "", "report type (see `openrisk report types`)")
+		format    = fs.String("format", "pdf", "pdf, docx or xlsx")
+		locale    = fs.String("locale", "", "fr or en (default: the server's)")
+		from      = fs.String("from", "", "period start, YYYY-MM-DD")
+		to        = fs.String("to", "", "period end, YYYY-MM-DD")
+		framework = fs.String("framework", "", "framework id, for compliance_framework")
+		audit     = fs.String("audit", "", "audit id, for audit")
+		wait      = fs.Bool("wait", false, "wait until the report is generated")
+		out       = fs.String("out", "", "download the report to this file or directory (implies --wait)")
+		poll      = fs.Duration("poll", 2*time.Second, "interval between progress checks")
+		maxWait   = fs.Duration("max-wait", 10*time.Minute, "give up waiting after this long")
+	)
+	if _, err := g.parse(fs, args); err != nil {
+		return err
+	}
+	if g.output == outputJUnit {
+		return errors.New("junit output is available for push, vulns and gate")
+	}
+	if *typ == "" {
+		fs.Usage()
+		return errors.New("--type is required")
+	}
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	rep, err := c.ReportCreate(ctx, &apiclient.ReportCreateRequest{
+		Type:        *typ,
+		Format:      *format,
+		Locale:      *locale,
+		From:        *from,
+		To:          *to,
+		FrameworkID: *framework,
+		AuditID:     *audit,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(g.stderr, "report %s queued\n", rep.ID)
+
+	if *wait || *out != "" {
+		deadline := time.Now().Add(*maxWait)
+		for !terminal(rep) {
+			if time.Now().After(deadline) {
+				return fmt.Errorf("report %s still %s after %s", rep.ID, rep.RunState, *maxWait)
+			}
+			time.Sleep(*poll)
+			if rep, err = c.ReportGet(ctx, rep.ID); err != nil {
+				return err
+			}
+			if rep.Step != "" {
+				fmt.Fprintf(g.stderr, "  %3d%% %s\n", rep.Progress, rep.Step)
+			}
+		}
+		if rep.RunState == apiclient.ReportRunStateFailed {
+			return fmt.Errorf("report %s failed: %s", rep.ID, orDash(rep.Error))
+		}
+	}
+	if *out != "" {
+		data, err := c.ReportDownload(ctx, rep.ID)
+		if err != nil {
+			return err
+		}
+		path := *out
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			name := rep.Filename
+			if name == "" {
+				name = rep.ID + "." + string(rep.Format)
+			}
+			path = filepath.Join(path, filepath.Base(name))
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(g.stderr, "downloaded %s (%d bytes)\n", path, len(data))
+	}
+
+	if g.output == outputJSON {
+		return writeJSON(g.stdout, rep)
+	}
+	return writeTable(g.stdout, []string{"ID", "TYPE", "FORMAT", "STATE", "PROGRESS", "TITLE"}, [][]string{{
+		rep.ID, string(rep.Type), string(rep.Format), string(rep.RunState), strconv.FormatInt(rep.Progress, 10) + "%", orDash(rep.Title),
+	}})
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opendefender/openrisk/pkg/ai"
+)
+
+// serverAIProvider reads the server-wide LLM default — what organisations
+// without their own AI provider setting get.
+//
+//	AI_PROVIDER          template | anthropic | openai_compatible. Unset keeps
+//	                     the historical behaviour: Claude when ANTHROPIC_API_KEY
+//	                     is set, the template otherwise.
+//	AI_BASE_URL          openai_compatible API root incl. /v1
+//	                     (http://vllm:8000/v1, http://ollama:11434/v1)
+//	AI_MODEL             model name (falls back to ANTHROPIC_MODEL for anthropic)
+//	AI_API_KEY           provider key (falls back to ANTHROPIC_API_KEY for anthropic)
+//	AI_TIMEOUT_SECONDS   per-completion timeout, openai_compatible only
+//	AI_MAX_TOKENS        reply cap, openai_compatible only
+//	AI_OUTPUT_MODE       json_schema (default) | json_object | prompt
+//
+// An air-gapped install sets AI_PROVIDER=openai_compatible so that even
+// organisations that never open their AI settings stay on the local model.
+//
+// This default may sit on a private address. An organisation's own base_url
+// (PUT /ai/provider) may only with AI_ALLOW_PRIVATE_NETWORKS=true, which is
+// off by default; see ProviderSettingsService.AllowPrivateNetworks.
+func serverAIProvider() ai.ProviderConfig {
+	provider := ai.Provider(strings.ToLower(strings.TrimSpace(os.Getenv("AI_PROVIDER"))))
+	cfg := ai.ProviderConfig{
+		Provider:   provider,
+		BaseURL:    strings.TrimSpace(os.Getenv("AI_BASE_URL")),
+		Model:      strings.TrimSpace(os.Getenv("AI_MODEL")),
+		APIKey:     strings.TrimSpace(os.Getenv("AI_API_KEY")),
+		OutputMode: ai.OutputMode(strings.ToLower(strings.TrimSpace(os.Getenv("AI_OUTPUT_MODE")))),
+		// The operator's own endpoint, typically a model on the same network.
+		AllowPrivateNetworks: true,
+	}
+	if n, err := strconv.Atoi(strings.TrimSpace(os.Getenv("AI_TIMEOUT_SECONDS"))); err == nil && n > 0 {
+		cfg.Timeout = time.Duration(n) * time.Second
+	}
+	if n, err := strconv.Atoi(strings.TrimSpace(os.Getenv("AI_MAX_TOKENS"))); err == nil && n > 0 {
+		cfg.MaxTokens = n
+	}
+	if provider == "" || provider == ai.ProviderAnthropic {
+		if cfg.APIKey == "" {
+			cfg.APIKey = strings.TrimSpace(os.Getenv("ANTHROPIC_API_KEY"))
+		}
+		if cfg.Model == "" {
+			cfg.Model = strings.TrimSpace(os.Getenv("ANTHROPIC_MODEL"))
+		}
+	}
+	if provider == "" {
+		cfg.Provider = ai.ProviderTemplate
+		if cfg.APIKey != "" {
+			cfg.Provider = ai.ProviderAnthropic
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		// Degrade rather than refuse to start: the AI features are advisory and
+		// the template keeps them answering.
+		log.Printf("AI: AI_PROVIDER=%s is misconfigured (%v) — using the deterministic template", cfg.Provider, err)
+		cfg = ai.ProviderConfig{Provider: ai.ProviderTemplate}
+	}
+	return cfg
+}
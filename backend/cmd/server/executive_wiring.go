@@ -9,6 +9,7 @@ import (
 	"context"
 
 	appactivation "github.com/opendefender/openrisk/internal/application/activation"
+	"github.com/opendefender/openrisk/internal/application/appetite"
 	"github.com/opendefender/openrisk/internal/application/compliance"
 	"github.com/opendefender/openrisk/internal/application/dashboard"
 	"github.com/opendefender/openrisk/internal/application/risk"
@@ -53,6 +54,7 @@ func newExecutiveDashboardHandler(
 	incidentSvc *service.IncidentService,
 	quantifier *crq.Quantifier,
 	aha *appactivation.AhaRecorder,
+	appetiteSvc *appetite.Service,
 ) *handlers.ExecutiveDashboardHandler {
 	uc := dashboard.NewGetExecutiveDashboardUseCase().
 		WithFinancial(financialUC).
@@ -61,6 +63,7 @@ func newExecutiveDashboardHandler(
 		WithVulnerabilities(vulnRepo).
 		WithIncidents(incidentSourceAdapter{svc: incidentSvc}).
 		WithQuantifier(quantifier).
+		WithAppetite(appetiteSvc).
 		// The Aha moment (spec §7) is detected exactly where the cyber score is
 		// computed: this is the only place that knows both that a score was
 		// produced from the tenant's own data AND how many compliance gaps were
@@ -10,6 +10,8 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/application/appetite"
+	"github.com/opendefender/openrisk/internal/application/governance"
 	riskapp "github.com/opendefender/openrisk/internal/application/risk"
 	"github.com/opendefender/openrisk/internal/domain"
 	"github.com/opendefender/openrisk/internal/infrastructure/repository"
@@ -128,3 +130,28 @@ func (a *approvalCheckerAdapter) HasApprovedAcceptance(ctx context.Context, tena
 	}
 	return false, pending, nil
 }
+
+// ---------------------------------------------------------------------------
+// Guard 4 — "a risk above appetite needs a valid appetite exception". The
+// standing is answered by appetite.Service itself; this adapter lets it OPEN
+// the exception through the approval engine without importing governance.
+// ---------------------------------------------------------------------------
+
+type appetiteExceptionSubmitter struct {
+	submit *governance.SubmitApprovalRequestUseCase
+}
+
+func newAppetiteExceptionSubmitter(submit *governance.SubmitApprovalRequestUseCase) appetite.ExceptionSubmitter {
+	return &appetiteExceptionSubmitter{submit: submit}
+}
+
+func (a *appetiteExceptionSubmitter) SubmitAppetiteException(ctx context.Context, tenantID, requesterID, riskID uuid.UUID, title, justification string, payload domain.JSONMap) (*domain.ApprovalRequest, error) {
+	return a.submit.Execute(ctx, tenantID, requesterID, governance.SubmitApprovalInput{
+		EntityType:  domain.AppetiteExceptionEntityType,
+		EntityID:    riskID.String(),
+		Action:      domain.AppetiteExceptionAction,
+		Title:       title,
+		Description: justification,
+		Payload:     payload,
+	})
+}
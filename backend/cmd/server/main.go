@@ -27,31 +27,50 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog"
 
+	"github.com/opendefender/openrisk/internal/apispec"
+	"github.com/opendefender/openrisk/internal/application/accesspolicy"
 	appactivation "github.com/opendefender/openrisk/internal/application/activation"
 	appai "github.com/opendefender/openrisk/internal/application/ai"
+	appetiteapp "github.com/opendefender/openrisk/internal/application/appetite"
 	assetapp "github.com/opendefender/openrisk/internal/application/asset"
 	"github.com/opendefender/openrisk/internal/application/assetschema"
 	"github.com/opendefender/openrisk/internal/application/auth"
 	appauto "github.com/opendefender/openrisk/internal/application/automation"
+	biaapp "github.com/opendefender/openrisk/internal/application/bia"
 	billingapp "github.com/opendefender/openrisk/internal/application/billing"
 	"github.com/opendefender/openrisk/internal/application/board"
+	bowtieapp "github.com/opendefender/openrisk/internal/application/bowtie"
 	"github.com/opendefender/openrisk/internal/application/compliance"
 	"github.com/opendefender/openrisk/internal/application/complianceaudit"
+	controltestapp "github.com/opendefender/openrisk/internal/application/controltest"
+	"github.com/opendefender/openrisk/internal/application/dataimport"
 	entapp "github.com/opendefender/openrisk/internal/application/entitlements"
 	"github.com/opendefender/openrisk/internal/application/evidence"
 	"github.com/opendefender/openrisk/internal/application/governance"
+	"github.com/opendefender/openrisk/internal/application/graphapi"
+	groupapp "github.com/opendefender/openrisk/internal/application/group"
 	appinc "github.com/opendefender/openrisk/internal/application/incident"
+	kriapp "github.com/opendefender/openrisk/internal/application/kri"
 	"github.com/opendefender/openrisk/internal/application/membership"
 	appmitigation "github.com/opendefender/openrisk/internal/application/mitigation"
 	notificationapp "github.com/opendefender/openrisk/internal/application/notification"
 	"github.com/opendefender/openrisk/internal/application/orgdeletion"
 	"github.com/opendefender/openrisk/internal/application/ownership"
+	"github.com/opendefender/openrisk/internal/application/plugins"
+	programmeapp "github.com/opendefender/openrisk/internal/application/programme"
+	registersnapshotapp "github.com/opendefender/openrisk/internal/application/registersnapshot"
 	appreport "github.com/opendefender/openrisk/internal/application/report"
 	"github.com/opendefender/openrisk/internal/application/reportjob"
 	"github.com/opendefender/openrisk/internal/application/risk"
 	scanapp "github.com/opendefender/openrisk/internal/application/scanner"
+	scenarioapp "github.com/opendefender/openrisk/internal/application/scenario"
 	searchapp "github.com/opendefender/openrisk/internal/application/search"
+	siemapp "github.com/opendefender/openrisk/internal/application/siem"
+	"github.com/opendefender/openrisk/internal/application/tenantconfig"
+	thehiveapp "github.com/opendefender/openrisk/internal/application/thehive"
+	vendorapp "github.com/opendefender/openrisk/internal/application/vendor"
 	vulnapp "github.com/opendefender/openrisk/internal/application/vulnerability"
+	"github.com/opendefender/openrisk/internal/application/webhooks"
 	coreauth "github.com/opendefender/openrisk/internal/auth"
 	"github.com/opendefender/openrisk/internal/config"
 	"github.com/opendefender/openrisk/internal/domain"
@@ -73,10 +92,12 @@ import (
 	redisclient "github.com/opendefender/openrisk/internal/infrastructure/redis"
 	"github.com/opendefender/openrisk/internal/infrastructure/repository"
 	"github.com/opendefender/openrisk/internal/infrastructure/scanmitigation"
+	"github.com/opendefender/openrisk/internal/infrastructure/vendorrisk"
 	"github.com/opendefender/openrisk/internal/infrastructure/vulnrisk"
 	"github.com/opendefender/openrisk/internal/infrastructure/workers"
 	"github.com/opendefender/openrisk/internal/middleware"
 	"github.com/opendefender/openrisk/internal/migrations"
+	"github.com/opendefender/openrisk/internal/pluginhost"
 	scanpkg "github.com/opendefender/openrisk/internal/scanner"
 	"github.com/opendefender/openrisk/internal/scanner/collectors"
 	"github.com/opendefender/openrisk/internal/service"
@@ -85,13 +106,13 @@ import (
 	pkgbilling "github.com/opendefender/openrisk/pkg/billing"
 	"github.com/opendefender/openrisk/pkg/cache"
 	"github.com/opendefender/openrisk/pkg/crq"
+	"github.com/opendefender/openrisk/pkg/crypto"
 	"github.com/opendefender/openrisk/pkg/cti"
 	ent "github.com/opendefender/openrisk/pkg/entitlements"
 	"github.com/opendefender/openrisk/pkg/hibp"
 	"github.com/opendefender/openrisk/pkg/notify"
 	"github.com/opendefender/openrisk/pkg/pwpolicy"
 	"github.com/opendefender/openrisk/pkg/scoring"
-	"github.com/opendefender/openrisk/pkg/storage"
 )
 
 // Version and Commit are injected at build time via ldflags
@@ -117,6 +138,16 @@ func main() {
 		return
 	}
 
+	// Storage subcommand: `<server> storage migrate [--dry-run]` copies evidence
+	// files from the local driver into the configured one. Needs the database and
+	// the storage env, not the RSA keys or Redis — see storage_wiring.go.
+	if len(os.Args) > 1 && os.Args[1] == "storage" {
+		if err := runStorageCLI(os.Args[2:]); err != nil {
+			log.Fatalf("%v", err)
+		}
+		return
+	}
+
 	// =========================================================================
 	// 1. CONFIGURATION & INFRASTRUCTURE
 	// =========================================================================
@@ -326,6 +357,10 @@ func main() {
 		// release.
 		&domain.Evidence{},
 		&domain.EvidenceControlLink{},
+		// Chain of custody for evidence files: append-only, one row per view,
+		// download, review, replacement or package, each also chained into
+		// audit_events.
+		&domain.EvidenceCustodyEvent{},
 		// Security Automation / SOAR (spec §10 « Automatisation »): tenant-scoped
 		// playbooks (trigger + conditions + action chain + SLA policy), their
 		// execution audit trail, and the live SLA countdowns the monitor escalates.
@@ -337,8 +372,72 @@ func main() {
 		// (append-only who/what/when/before→after), time-boxed delegations, and
 		// the configurable Maker-Checker approval engine (workflows + requests).
 		&domain.IncidentPostMortem{},
+		// Breach-notification clocks (GDPR / NIS2 / DORA): per-jurisdiction
+		// profiles, the classification decision on each incident, and one dated
+		// obligation per reporting stage.
+		&domain.RegulatoryProfile{},
+		&domain.IncidentRegulatoryAssessment{},
+		&domain.RegulatoryClock{},
+		// TheHive 5 case sync: the tenant connection, incident↔case and
+		// action↔task links, and case observables with their attributed asset.
+		&domain.TheHiveConnection{},
+		&domain.TheHiveCaseLink{},
+		&domain.TheHiveTaskLink{},
+		&domain.TheHiveObservable{},
+		// Business impact analysis: RTO/RPO/MTPD per business process and the
+		// recovery capability declared on each supporting asset.
+		&domain.BusinessImpactAnalysis{},
+		&domain.RecoveryCapability{},
+		// Board-approved risk appetite statements per category / business unit.
+		&domain.RiskAppetiteStatement{},
+		// Key risk indicators and their value history.
+		&domain.KRI{},
+		&domain.KRIValue{},
+		// Per-organisation LLM provider for the AI assistant and board report.
+		&domain.AIProviderSetting{},
+		// Vendor register, custom questionnaires and token-link assessments.
+		&domain.Vendor{},
+		&domain.VendorQuestionnaireTemplate{},
+		&domain.VendorAssessment{},
+		// Tenant risk scenarios (the built-in library lives in code).
+		&domain.RiskScenario{},
+		// Control test plans and their results.
+		&domain.ControlTestPlan{},
+		&domain.ControlTest{},
+		// Immutable register snapshots (daily close and on demand).
+		&domain.RegisterSnapshot{},
+		&domain.RegisterSnapshotRisk{},
+		// Bowtie analyses, one per risk.
+		&domain.Bowtie{},
+		&domain.OrganizationLink{},
+		// Mitigation programmes, their links, work breakdown and capacities.
+		&domain.MitigationProgramme{},
+		&domain.ProgrammeRiskLink{},
+		&domain.ProgrammeControlLink{},
+		&domain.ProgrammeAction{},
+		&domain.ProgrammeDependency{},
+		&domain.AssigneeCapacity{},
+		// Import wizard batches, their staged rows and what they wrote.
+		&domain.ImportBatch{},
+		&domain.ImportBatchRow{},
+		&domain.ImportBatchRecord{},
+		&domain.ImportMappingProfile{},
+		// Connector plugins: signed WASM packages, their installs and run log.
+		&domain.PluginPackage{},
+		&domain.PluginInstall{},
+		&domain.PluginRun{},
+		// GraphQL automatic persisted queries.
+		&domain.GraphQLPersistedQuery{},
+		// Outbound webhooks: subscriptions and their delivery log.
+		&domain.WebhookSubscription{},
+		&domain.WebhookDelivery{},
+		&domain.SIEMDestination{},
+		// Record-level access policies and the attributes they read on members.
+		&domain.AccessPolicy{},
+		&domain.AccessSubjectAttributes{},
 		&domain.AuditEvent{},
 		&domain.AuditChainSeal{},
+		&domain.AuditCheckpoint{},
 		&domain.AuditRetentionPolicy{},
 		&domain.Delegation{},
 		&domain.ApprovalWorkflow{},
@@ -463,18 +562,12 @@ func main() {
 	log.Println("Scoring: Engine initialized (pure, zero dependencies)")
 
 	// Initialize file storage (compliance evidence, etc.)
-	// STORAGE_DRIVER selects the backend; only "local" exists today. An
-	// S3-backed driver can be added later behind the same storage.Storage
-	// interface without touching any use case or handler.
-	storageLocalPath := os.Getenv("STORAGE_LOCAL_PATH")
-	if storageLocalPath == "" {
-		storageLocalPath = "./uploads"
-	}
-	fileStorage, err := storage.NewLocalStorage(storageLocalPath)
+	// STORAGE_DRIVER selects the backend ("local" or "s3", see
+	// storage_wiring.go). Use cases and handlers only see storage.Storage.
+	fileStorage, err := newFileStorage(context.Background())
 	if err != nil {
-		log.Fatal("Storage: failed to initialize local storage: ", err)
+		log.Fatal("Storage: ", err)
 	}
-	log.Println("Storage: local driver initialized at", storageLocalPath)
 
 	// Initialize Score Worker (listens to Redis events)
 	zeroLogger := zerolog.New(os.Stderr).With().Timestamp().Logger()
@@ -493,24 +586,19 @@ func main() {
 	// Ils respectent les interfaces définies dans core/ports
 	theHiveAdapter := thehive.NewTheHiveAdapter(cfg.Integrations.TheHive)
 
-	// Get organization ID for SyncEngine (multi-tenant scoping - Rule 1)
-	// In a multi-tenant setup, there would be one SyncEngine per organization
-	// For now, we use the default organization from environment or placeholder
-	organizationID := os.Getenv("SYNC_ORGANIZATION_ID")
-	if organizationID == "" {
-		// Fall back to first organization in DB or placeholder
-		// TODO: In production, each organization should have its own SyncEngine instance
-		organizationID = "550e8400-e29b-41d4-a716-446655440000" // Default placeholder
-		log.Println("Warning: SYNC_ORGANIZATION_ID not set, using default placeholder. Set this env var for proper multi-tenant operation.")
+	// The legacy SyncEngine polls ONE organisation through the v0 adapter, which
+	// answers mock cases when unconfigured. It only runs when an operator names
+	// that organisation explicitly: the per-tenant, bi-directional TheHive 5 sync
+	// (Settings → Integrations → TheHive, see the incidents section) is the
+	// connector, and a placeholder tenant receiving mock incidents helps nobody.
+	if organizationID := os.Getenv("SYNC_ORGANIZATION_ID"); organizationID != "" {
+		// Initialisation du Moteur de Synchro (Background Worker)
+		// Il tourne indépendamment de l'API HTTP
+		syncEngine := workers.NewSyncEngine(theHiveAdapter, organizationID)
+		syncEngine.Start(context.Background())
+		log.Println("OpenDefender SyncEngine started in background")
 	}
 
-	// Initialisation du Moteur de Synchro (Background Worker)
-	// Il tourne indépendamment de l'API HTTP
-	syncEngine := workers.NewSyncEngine(theHiveAdapter, organizationID)
-	syncEngine.Start(context.Background())
-
-	log.Println("OpenDefender SyncEngine started in background")
-
 	// =========================================================================
 	// 4. WEB SERVER SETUP (Fiber)
 	// =========================================================================
@@ -863,6 +951,14 @@ func main() {
 		})
 	})
 
+	// The API's own OpenAPI 3.1 description, generated from this route table by
+	// make openapi (internal/apispec). Public: clients fetch it before they have
+	// a token.
+	api.Get("/openapi.json", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		return c.Send(apispec.Spec)
+	})
+
 	// Brute-force protection on credential endpoints (5 attempts / 15 min per IP).
 	// Backed by Redis so the counter is shared across every instance of a
 	// horizontally-scaled deployment; degrades gracefully to a per-instance
@@ -965,6 +1061,26 @@ func main() {
 	var vulnWebhookHandler *handlers.VulnWebhookHandler
 	app.Post("/api/v1/vulnerabilities/webhook/:source", func(c *fiber.Ctx) error { return vulnWebhookHandler.Ingest(c) })
 
+	// TheHive notifier webhook — same token-not-JWT scheme, one token per tenant
+	// connection. Assigned in the incidents section below.
+	var theHiveHandler *handlers.TheHiveHandler
+	app.Post("/api/v1/integrations/thehive/webhook", func(c *fiber.Ctx) error { return theHiveHandler.Webhook(c) })
+
+	// Connector plugin webhook — the install's token selects the install whose
+	// transform_webhook hook turns the push into findings. Assigned with the
+	// scan pipeline below.
+	var pluginHandler *handlers.PluginHandler
+	app.Post("/api/v1/plugins/webhook", func(c *fiber.Ctx) error { return pluginHandler.Webhook(c) })
+
+	// Vendor questionnaire portal — a supplier answers through the link they
+	// were sent, with no OpenRisk account, so these sit outside the JWT gate.
+	// The link token is the credential and resolves the tenant; the auth
+	// throttle bounds guessing. Assigned in the vendor section below.
+	var vendorPortalHandler *handlers.VendorHandler
+	app.Get("/api/v1/vendor-portal", authRateLimit, func(c *fiber.Ctx) error { return vendorPortalHandler.Portal(c) })
+	app.Post("/api/v1/vendor-portal/answers", authRateLimit, func(c *fiber.Ctx) error { return vendorPortalHandler.PortalAnswers(c) })
+	app.Post("/api/v1/vendor-portal/documents", authRateLimit, func(c *fiber.Ctx) error { return vendorPortalHandler.PortalDocument(c) })
+
 	// --- Routes Protégées (Nécessitent JWT) ---
 	// Le middleware injecte user_id et role dans le contexte
 	// L5 — PAT authentication runs BEFORE the JWT gate: it authenticates PAT-shaped
@@ -1010,6 +1126,20 @@ func main() {
 	// agent, request id, timestamp). Adding a route adds coverage automatically.
 	protected.Use(middleware.AuditMutations(auditChainRepo))
 
+	// Record-level access policies (docs/ACCESS_POLICIES.md): resolve the
+	// caller's scope once per request and hand it to the repositories on the
+	// request context, so every risk and evidence query is filtered in SQL.
+	// Mounted before every business route for the same reason as the journal.
+	// A record's sub-resources (/risks/:id/kris, /timeline, /evidence/:id/custody,
+	// …) live in other tables, so the record itself is checked first: they
+	// answer 404 like the record does.
+	accessPolicyRepo := repository.NewGormAccessPolicyRepository(database.DB)
+	accessPolicyService := accesspolicy.NewService(accessPolicyRepo).
+		WithAudit(governance.NewAuditRecorder(auditChainRepo))
+	protected.Use(middleware.AccessPolicies(accessPolicyService))
+	protected.Use("/risks/:id", middleware.VisibleRecord(accessPolicyRepo, domain.AccessResourceRisk, "id"))
+	protected.Use("/evidence/:evidenceId", middleware.VisibleRecord(accessPolicyRepo, domain.AccessResourceEvidence, "evidenceId"))
+
 	// =========================================================================
 	// Open-core: entitlements + billing. This is where the plan model becomes
 	// enforceable. The entitlement service resolves a tenant's effective plan
@@ -1161,16 +1291,34 @@ func main() {
 		WithCurrency(orgRepo).
 		WithRates(fxWorker)
 	markReviewedUseCase := risk.NewMarkRiskReviewedUseCase(riskRepo)
-	// The lifecycle FSM enforces its guards server-side. Its two inspectors are
+	// Risk appetite: the board's statements per category and business unit,
+	// the register evaluated against them, and exceptions through the approval
+	// engine. Built here because the lifecycle FSM below asks it whether a risk
+	// may be accepted; the exception submitter is attached in the governance
+	// block, once the approval use case exists.
+	appetiteService := appetiteapp.NewService(
+		repository.NewGormRiskAppetiteRepository(database.DB),
+		riskRepo,
+		repository.NewGormRiskCategoryRepository(database.DB),
+		repository.NewGormApprovalRepository(database.DB),
+	).WithQuantifier(riskQuantifier).WithVisibility(riskRepo).WithAudit(governance.NewAuditRecorder(auditChainRepo))
+	// Outbound webhooks. Built this early because the use cases below publish
+	// to it; publishing only queues rows. Secret storage, the routes and the
+	// delivery worker are attached in the webhooks block further down.
+	webhookService := webhooks.NewService(repository.NewGormWebhookRepository(database.DB))
+	// The lifecycle FSM enforces its guards server-side. Its inspectors are
 	// wired here so "IN_TREATMENT needs an active mitigation", "MITIGATED needs
-	// every sub-action done" and "RESIDUAL_ACCEPTED needs a validated Governance
-	// approval" are answered from real data rather than trusted from the client.
+	// every sub-action done", "RESIDUAL_ACCEPTED needs a validated Governance
+	// approval" and "above appetite needs a valid exception" are answered from
+	// real data rather than trusted from the client.
 	transitionStateUseCase := risk.NewTransitionRiskStateUseCase(riskRepo).
 		WithMitigations(newMitigationInspector(
 			repository.NewGormMitigationRepository(database.DB),
 			repository.NewGormMitigationSubActionRepository(database.DB),
 		)).
-		WithApprovals(newApprovalChecker(repository.NewGormApprovalRepository(database.DB)))
+		WithApprovals(newApprovalChecker(repository.NewGormApprovalRepository(database.DB))).
+		WithAppetite(appetiteService).
+		WithEvents(webhookService)
 	riskHandler := handlers.NewRiskHandler(createRiskUseCase, getRiskUseCase, listRisksUseCase, updateRiskUseCase, deleteRiskUseCase, markReviewedUseCase, transitionStateUseCase, redisClientInstance, riskQuantifier).
 		WithFinancialPresenters(financialPresenters)
 
@@ -1185,12 +1333,29 @@ func main() {
 	financialAnalyticsHandler := handlers.NewFinancialAnalyticsHandler(financialSummaryUseCase).
 		WithCurrencyWriter(orgRepo)
 
+	// Register snapshots: the register frozen at every close of day and on
+	// demand, so ?as_of= on the register, dashboard and export reads answers
+	// "what did it look like on 31 December". The as_of wrappers sit outside
+	// the cache: without the parameter the live handler (and its cache) runs.
+	// Reading a past register, as_of included, needs risks:snapshots:read.
+	registerSnapshotService := registersnapshotapp.NewService(
+		repository.NewGormRegisterSnapshotRepository(database.DB),
+		riskControlMappingRepo,
+	).WithQuantifier(riskQuantifier).
+		WithAudit(governance.NewAuditRecorder(auditChainRepo))
+	registerSnapshotHandler := handlers.NewRegisterSnapshotHandler(registerSnapshotService)
+
 	// NOTE: same bug class as compliance (see comment above complianceFrameworkRead) —
 	// middleware.RequirePermissions reads the legacy *domain.UserClaims, which the RS256
 	// middleware on `protected` never populates. Using middleware.RequirePermission instead.
 	protected.Get("/risks",
 		middleware.RequirePermission("risks:read"),
-		cacheableHandlers.CacheRiskListGET(riskHandler.GetRisks))
+		registerSnapshotHandler.AsOfRisks(cacheableHandlers.CacheRiskListGET(riskHandler.GetRisks)))
+	protected.Get("/register-snapshots", middleware.RequirePermission("risks:snapshots:read"), registerSnapshotHandler.List)
+	protected.Post("/register-snapshots", middleware.RequirePermission("risks:update"), registerSnapshotHandler.Take)
+	protected.Get("/register-snapshots/diff", middleware.RequirePermission("risks:snapshots:read"), registerSnapshotHandler.Diff)
+	protected.Get("/register-snapshots/:id", middleware.RequirePermission("risks:snapshots:read"), registerSnapshotHandler.Get)
+	protected.Get("/register-snapshots/:id/risks", middleware.RequirePermission("risks:snapshots:read"), registerSnapshotHandler.Risks)
 	// --- Risk taxonomy (spec §3): three separate concepts, three separate
 	// columns. tags stay a free array on the risk; categories are the tenant's
 	// CONTROLLED vocabulary; control mappings are references to real compliance
@@ -1251,6 +1416,46 @@ func main() {
 	protected.Delete("/risk-categories/:id",
 		middleware.RequireRole("admin", "root"), riskTaxonomyHandler.DeleteCategory)
 
+	// Risk appetite. Statements record what the board approved, so only an
+	// admin writes them; the evaluation is read by anyone who reads the
+	// register; an exception is requested by whoever may edit the risk, and
+	// decided in Governance like every other approval.
+	riskAppetiteHandler := handlers.NewRiskAppetiteHandler(appetiteService)
+	protected.Get("/risk-appetite/statements",
+		middleware.RequirePermission("risks:read"), riskAppetiteHandler.ListStatements)
+	protected.Post("/risk-appetite/statements",
+		middleware.RequireRole("admin", "root"), riskAppetiteHandler.CreateStatement)
+	protected.Put("/risk-appetite/statements/:id",
+		middleware.RequireRole("admin", "root"), riskAppetiteHandler.UpdateStatement)
+	protected.Delete("/risk-appetite/statements/:id",
+		middleware.RequireRole("admin", "root"), riskAppetiteHandler.DeleteStatement)
+	protected.Get("/risk-appetite/evaluation",
+		middleware.RequirePermission("risks:read"), riskAppetiteHandler.Evaluate)
+	protected.Post("/risk-appetite/exceptions", riskUpdate, riskAppetiteHandler.RequestException)
+
+	// Key risk indicators. Query KRIs are evaluated by the refresh worker over
+	// the tenant's own register; the others are fed by API push or CSV upload.
+	// A crossing fires the kri_threshold_crossed automation trigger and, when
+	// the KRI asks for it, raises its risks' probability and makes their review
+	// due. Reading follows the register; defining and feeding follow risk edits.
+	kriService := kriapp.NewService(
+		repository.NewGormKRIRepository(database.DB),
+		riskRepo,
+		repository.NewGormKRIQueryEvaluator(database.DB),
+	).WithEscalator(riskRepo).
+		WithEvents(autoinfra.NewKRIEventPublisher(redisClientInstance)).
+		WithAudit(governance.NewAuditRecorder(auditChainRepo))
+	kriHandler := handlers.NewKRIHandler(kriService)
+	protected.Get("/kris", middleware.RequirePermission("risks:read"), kriHandler.List)
+	protected.Post("/kris", riskUpdate, kriHandler.Create)
+	protected.Get("/kris/:id", middleware.RequirePermission("risks:read"), kriHandler.Get)
+	protected.Put("/kris/:id", riskUpdate, kriHandler.Update)
+	protected.Delete("/kris/:id", riskUpdate, kriHandler.Delete)
+	protected.Post("/kris/:id/values", riskUpdate, kriHandler.PushValue)
+	protected.Post("/kris/:id/values/csv", riskUpdate, kriHandler.ImportCSV)
+	protected.Post("/kris/:id/refresh", riskUpdate, kriHandler.Refresh)
+	protected.Get("/risks/:id/kris", middleware.RequirePermission("risks:read"), kriHandler.ListForRisk)
+
 	// Mitigation Plans (CRUD). NOTE: this whole module previously used
 	// middleware.RequireRole ("writerRole"), which reads c.Locals("role") — a flat
 	// string AuthMiddlewareRS256 never sets (it sets "org_roles", a map, instead).
@@ -1294,6 +1499,30 @@ func main() {
 	// source, which authenticated nobody once the repository was public.
 	protected.Post("/scanner/mitigations/auto-complete", mitigationUpdate, handlers.AutoCompleteMitigationSubAction)
 
+	// Mitigation programmes: one project treating many risks and controls,
+	// scheduled from its actions' effort, assignees and dependencies. They ride
+	// the mitigation permissions — a programme is a shared mitigation plan. The
+	// per-risk projection sits with the risk and follows the register.
+	programmeHandler := handlers.NewMitigationProgrammeHandler(
+		programmeapp.NewService(repository.NewGormMitigationProgrammeRepository(database.DB)).
+			WithAudit(governance.NewAuditRecorder(auditChainRepo)))
+	protected.Get("/mitigation-programmes", mitigationRead, programmeHandler.List)
+	protected.Post("/mitigation-programmes", mitigationCreate, programmeHandler.Create)
+	protected.Get("/mitigation-programmes/:id", mitigationRead, programmeHandler.Get)
+	protected.Put("/mitigation-programmes/:id", mitigationUpdate, programmeHandler.Update)
+	protected.Delete("/mitigation-programmes/:id", mitigationDelete, programmeHandler.Delete)
+	protected.Get("/mitigation-programmes/:id/projection", mitigationRead, programmeHandler.Projection)
+	protected.Post("/mitigation-programmes/:id/risks", mitigationUpdate, programmeHandler.LinkRisk)
+	protected.Delete("/mitigation-programmes/:id/risks/:riskId", mitigationUpdate, programmeHandler.UnlinkRisk)
+	protected.Post("/mitigation-programmes/:id/controls", mitigationUpdate, programmeHandler.LinkControl)
+	protected.Delete("/mitigation-programmes/:id/controls/:controlId", mitigationUpdate, programmeHandler.UnlinkControl)
+	protected.Post("/mitigation-programmes/:id/actions", mitigationUpdate, programmeHandler.AddAction)
+	protected.Put("/programme-actions/:id", mitigationUpdate, programmeHandler.UpdateAction)
+	protected.Delete("/programme-actions/:id", mitigationUpdate, programmeHandler.DeleteAction)
+	protected.Get("/programme-capacities", mitigationRead, programmeHandler.Capacities)
+	protected.Put("/programme-capacities/:userId", mitigationUpdate, programmeHandler.SetCapacity)
+	protected.Get("/risks/:id/programme-projection", middleware.RequirePermission("risks:read"), programmeHandler.RiskProjection)
+
 	// Compliance Frameworks (M1 — see ROADMAP.md §3)
 	complianceRepo := repository.NewGormComplianceRepository(database.DB)
 	createFrameworkUC := compliance.NewCreateFrameworkUseCase(complianceRepo)
@@ -1325,8 +1554,16 @@ func main() {
 	} else if moved > 0 {
 		log.Printf("Evidence: migrated %d pre-library attachment(s) into the evidence library", moved)
 	}
+	// complianceAuditRepo is built here rather than with the audit module below
+	// because evidence packages can be scoped to an audit.
+	complianceAuditRepo := repository.NewGormComplianceAuditRepository(database.DB)
+	// One signer for everything verified outside the product: evidence
+	// packages here, audit checkpoints with the governance module below.
+	exportSigner := newExportSigner()
 	evidenceService := evidence.NewService(evidenceRepo, complianceRepo, fileStorage).
-		WithUserLookup(userRepo)
+		WithUserLookup(userRepo).
+		WithCustody(evidenceRepo, governance.NewAuditRecorder(auditChainRepo)).
+		WithPackaging(complianceAuditRepo, exportSigner)
 	evidenceHandler := handlers.NewEvidenceHandler(evidenceService)
 
 	// Curated crosswalks are materialised at import time and the head start they
@@ -1407,6 +1644,40 @@ func main() {
 	protected.Get("/compliance/evidences/:evidenceId/download", complianceEvidenceRead, evidenceHandler.Download)
 	protected.Delete("/compliance/evidences/:evidenceId", complianceEvidenceDelete, evidenceHandler.Delete)
 
+	// Control effectiveness testing. A control's design and operating test
+	// plans ride the control's own permissions; recording a test re-derives the
+	// residual of every risk mapped to the control, so a failed control shows in
+	// the register the moment it is recorded. The owner notifier is attached in
+	// the notifications section, where its use case exists. The residual routes
+	// sit with the risk they describe: reading follows the register, applying
+	// follows risk edits.
+	controlTestService := controltestapp.NewService(repository.NewGormControlTestRepository(database.DB)).
+		WithEvents(autoinfra.NewKRIEventPublisher(redisClientInstance)).
+		WithAudit(governance.NewAuditRecorder(auditChainRepo))
+	controlTestHandler := handlers.NewControlTestHandler(controlTestService)
+	protected.Get("/compliance/controls/:controlId/testing", complianceControlRead, controlTestHandler.ControlTesting)
+	protected.Post("/compliance/controls/:controlId/test-plans", complianceControlUpdate, controlTestHandler.CreatePlan)
+	protected.Get("/control-test-plans/due", complianceControlRead, controlTestHandler.DuePlans)
+	protected.Put("/control-test-plans/:id", complianceControlUpdate, controlTestHandler.UpdatePlan)
+	protected.Delete("/control-test-plans/:id", complianceControlUpdate, controlTestHandler.DeletePlan)
+	protected.Post("/control-test-plans/:id/tests", complianceControlUpdate, controlTestHandler.RecordTest)
+	protected.Get("/risks/:id/residual", middleware.RequirePermission("risks:read"), controlTestHandler.Residual)
+	protected.Post("/risks/:id/residual", riskUpdate, controlTestHandler.ApplyResidual)
+
+	// Bowtie analysis. A barrier's health is derived from the tests of its
+	// control and the status of its mitigation, so the bowtie sits here, next
+	// to the control tests it reads. Like the residual, reading follows the
+	// register and editing or applying the suggestion follows risk edits.
+	bowtieHandler := handlers.NewBowtieHandler(
+		bowtieapp.NewService(repository.NewGormBowtieRepository(database.DB)).
+			WithEvents(autoinfra.NewKRIEventPublisher(redisClientInstance)).
+			WithAudit(governance.NewAuditRecorder(auditChainRepo)))
+	protected.Get("/risks/:id/bowtie", middleware.RequirePermission("risks:read"), bowtieHandler.Get)
+	protected.Get("/risks/:id/bowtie/pdf", middleware.RequirePermission("risks:read"), bowtieHandler.PDF)
+	protected.Put("/risks/:id/bowtie", riskUpdate, bowtieHandler.Save)
+	protected.Delete("/risks/:id/bowtie", riskUpdate, bowtieHandler.Delete)
+	protected.Post("/risks/:id/bowtie/apply-suggestion", riskUpdate, bowtieHandler.ApplySuggestion)
+
 	// -------------------------------------------------------------------------
 	// Evidence library (spec §1). One artifact, N controls, an expiry and a
 	// review verdict — plus the "missing evidence" worklist per framework.
@@ -1418,12 +1689,22 @@ func main() {
 	// trap this codebase has hit on every module.
 	// -------------------------------------------------------------------------
 	protected.Get("/evidence/missing", complianceEvidenceRead, evidenceHandler.Missing)
+	// Evidence packages: a signed zip for a framework or an audit, verifiable
+	// offline. Read tier — it hands out nothing a reader could not download one
+	// file at a time.
+	protected.Post("/evidence/packages", complianceEvidenceRead, evidenceHandler.Package)
 	protected.Get("/evidence", complianceEvidenceRead, evidenceHandler.List)
 	protected.Post("/evidence", complianceEvidenceCreate, evidenceHandler.Create)
 	protected.Get("/evidence/:evidenceId", complianceEvidenceRead, evidenceHandler.Get)
 	protected.Patch("/evidence/:evidenceId", complianceEvidenceCreate, evidenceHandler.Update)
 	protected.Delete("/evidence/:evidenceId", complianceEvidenceDelete, evidenceHandler.Delete)
 	protected.Get("/evidence/:evidenceId/download", complianceEvidenceRead, evidenceHandler.Download)
+	protected.Get("/evidence/:evidenceId/download-link", complianceEvidenceRead, evidenceHandler.DownloadLink)
+	// Integrity: re-hash against the upload digest, the custody log, and a new
+	// version of the file (which moves the digest and is itself in custody).
+	protected.Post("/evidence/:evidenceId/verify", complianceEvidenceRead, evidenceHandler.Verify)
+	protected.Get("/evidence/:evidenceId/custody", complianceEvidenceRead, evidenceHandler.Custody)
+	protected.Put("/evidence/:evidenceId/file", complianceEvidenceCreate, evidenceHandler.Replace)
 	// Reuse: attach an artifact the tenant already holds to further controls.
 	protected.Post("/evidence/:evidenceId/links", complianceEvidenceCreate, evidenceHandler.Link)
 	protected.Delete("/evidence/:evidenceId/links/:controlId", complianceEvidenceCreate, evidenceHandler.Unlink)
@@ -1431,12 +1712,53 @@ func main() {
 	// the same tier as attaching evidence.
 	protected.Post("/evidence/:evidenceId/review", complianceEvidenceCreate, evidenceHandler.Review)
 
+	// Vendor risk. The register and its questionnaires follow risk edits;
+	// answers and documents land in the evidence library above, pending review,
+	// and a score under the pass mark proposes a DRAFT vendor risk. The draft
+	// notifier is attached in the notifications section, where its use case
+	// exists.
+	vendorService := vendorapp.NewService(repository.NewGormVendorRepository(database.DB)).
+		WithEvidence(newVendorEvidenceRecorder(evidenceService)).
+		WithRiskProposer(vendorrisk.NewRiskCreator(database.DB)).
+		WithMailer(authmail.NewVendorAssessmentMailer(emailTransport)).
+		WithOrganizations(orgRepo).
+		WithAudit(governance.NewAuditRecorder(auditChainRepo)).
+		WithBaseURL(appBaseURL)
+	vendorHandler := handlers.NewVendorHandler(vendorService)
+	vendorPortalHandler = vendorHandler
+	protected.Get("/vendors", middleware.RequirePermission("risks:read"), vendorHandler.ListVendors)
+	protected.Post("/vendors", riskUpdate, vendorHandler.CreateVendor)
+	protected.Get("/vendors/:id", middleware.RequirePermission("risks:read"), vendorHandler.GetVendor)
+	protected.Put("/vendors/:id", riskUpdate, vendorHandler.UpdateVendor)
+	protected.Delete("/vendors/:id", riskUpdate, vendorHandler.DeleteVendor)
+	protected.Post("/vendors/:id/assessments", riskUpdate, vendorHandler.SendAssessment)
+	protected.Post("/vendor-assessments/:id/revoke", riskUpdate, vendorHandler.RevokeAssessment)
+	protected.Get("/vendor-questionnaires", middleware.RequirePermission("risks:read"), vendorHandler.ListTemplates)
+	protected.Post("/vendor-questionnaires", riskUpdate, vendorHandler.CreateTemplate)
+	protected.Get("/vendor-questionnaires/:id", middleware.RequirePermission("risks:read"), vendorHandler.GetTemplate)
+	protected.Put("/vendor-questionnaires/:id", riskUpdate, vendorHandler.UpdateTemplate)
+	protected.Delete("/vendor-questionnaires/:id", riskUpdate, vendorHandler.DeleteTemplate)
+
+	// Risk scenarios. Reading the library and its coverage report is a risk
+	// read; editing scenarios and instantiating one — which drafts risks —
+	// follow risk edits. /coverage is registered before /:id so it is not read
+	// as a scenario id.
+	scenarioHandler := handlers.NewRiskScenarioHandler(
+		scenarioapp.NewService(repository.NewGormRiskScenarioRepository(database.DB)).
+			WithAudit(governance.NewAuditRecorder(auditChainRepo)))
+	protected.Get("/risk-scenarios", middleware.RequirePermission("risks:read"), scenarioHandler.ListScenarios)
+	protected.Get("/risk-scenarios/coverage", middleware.RequirePermission("risks:read"), scenarioHandler.Coverage)
+	protected.Post("/risk-scenarios", riskUpdate, scenarioHandler.CreateScenario)
+	protected.Get("/risk-scenarios/:id", middleware.RequirePermission("risks:read"), scenarioHandler.GetScenario)
+	protected.Put("/risk-scenarios/:id", riskUpdate, scenarioHandler.UpdateScenario)
+	protected.Delete("/risk-scenarios/:id", riskUpdate, scenarioHandler.DeleteScenario)
+	protected.Post("/risk-scenarios/:id/instantiate", riskUpdate, scenarioHandler.Instantiate)
+
 	// -------------------------------------------------------------------------
 	// Compliance audits ("Audits") + remediation plans ("Plans de remédiation").
 	// One Gorm repo backs both aggregates. New permission strings — admin/root
 	// hold "*" so they're granted; a future Profile rule can open them per-role.
 	// -------------------------------------------------------------------------
-	complianceAuditRepo := repository.NewGormComplianceAuditRepository(database.DB)
 	complianceAuditHandler := handlers.NewComplianceAuditHandler(
 		complianceaudit.NewCreateAuditUseCase(complianceAuditRepo),
 		complianceaudit.NewListAuditsUseCase(complianceAuditRepo),
@@ -1480,15 +1802,17 @@ func main() {
 	// generated as drafts, editable, and must be approved before diffusion.
 	// =========================================================================
 	boardRepo := repository.NewGormBoardReportRepository(database.DB)
-	boardAdvisor := ai.NewAdvisor(os.Getenv("ANTHROPIC_API_KEY"), os.Getenv("ANTHROPIC_MODEL"))
+	aiDefaultProvider := serverAIProvider()
+	boardAdvisor := ai.NewAdvisorForProvider(aiDefaultProvider)
 	if _, isTemplate := boardAdvisor.(*ai.TemplateAdvisor); isTemplate {
-		log.Println("Board Report: no ANTHROPIC_API_KEY set — using deterministic template advisor")
+		log.Println("Board Report: no LLM provider configured — using deterministic template advisor")
 	} else {
-		log.Printf("Board Report: Claude advisor enabled (%s)", boardAdvisor.Name())
+		log.Printf("Board Report: %s advisor enabled (%s)", aiDefaultProvider.Provider, boardAdvisor.Name())
 	}
 	generateBoardUC := board.NewGenerateBoardReportUseCase(
 		boardRepo, riskRepo, complianceRepo, orgRepo, boardAdvisor, board.DefaultExposureModel(),
-	).WithActivation(activationRecorder)
+	).WithActivation(activationRecorder).WithAppetite(appetiteService).WithKRIs(kriService).
+		WithRegisterChanges(registerSnapshotService)
 	getBoardUC := board.NewGetBoardReportUseCase(boardRepo)
 	listBoardUC := board.NewListBoardReportsUseCase(boardRepo)
 	updateBoardUC := board.NewUpdateBoardReportUseCase(boardRepo)
@@ -1605,6 +1929,27 @@ func main() {
 	protected.Put("/attack-surface/schemas/:category", adminOnly, assetSchemaHandler.UpdateSchema)
 	protected.Post("/attack-surface/schemas/:category/reset", adminOnly, assetSchemaHandler.ResetSchema)
 
+	// Import wizard: bulk upsert of risks, assets, controls and mitigations from
+	// another tool's export, by ExternalID, with a dry run and a rollback. One
+	// batch can rewrite a large part of the register across several modules, so
+	// it is an administrator's operation rather than any one module's "create".
+	importHandler := handlers.NewImportHandler(
+		dataimport.NewService(repository.NewGormImportRepository(database.DB)).
+			WithAssetSchemas(assetSchemaSvc).
+			WithAudit(governance.NewAuditRecorder(auditChainRepo)))
+	protected.Get("/imports", adminOnly, importHandler.List)
+	protected.Post("/imports", adminOnly, importHandler.Upload)
+	protected.Get("/imports/targets", adminOnly, importHandler.Targets)
+	protected.Get("/imports/:id", adminOnly, importHandler.Get)
+	protected.Delete("/imports/:id", adminOnly, importHandler.Delete)
+	protected.Post("/imports/:id/dry-run", adminOnly, importHandler.DryRun)
+	protected.Post("/imports/:id/apply", adminOnly, importHandler.Apply)
+	protected.Post("/imports/:id/rollback", adminOnly, importHandler.Rollback)
+	protected.Get("/import-profiles", adminOnly, importHandler.ListProfiles)
+	protected.Post("/import-profiles", adminOnly, importHandler.CreateProfile)
+	protected.Put("/import-profiles/:id", adminOnly, importHandler.UpdateProfile)
+	protected.Delete("/import-profiles/:id", adminOnly, importHandler.DeleteProfile)
+
 	// Attack Surface — topology. Wired later than the asset block because the
 	// node badges need the vulnerability repository; see the "topology wiring"
 	// block after the vulnerability module.
@@ -1712,6 +2057,7 @@ func main() {
 	protected.Get("/vulnerability-connectors", vulnRead, vulnHandler.ListConnectors)
 	protected.Get("/vulnerabilities/stats", vulnRead, vulnHandler.Stats)
 	protected.Post("/vulnerabilities/ingest", vulnWrite, vulnHandler.Ingest)
+	protected.Post("/vulnerabilities/upload", vulnWrite, vulnHandler.Upload)
 	// Integration + ticketing config (static prefixes before /vulnerabilities/:id).
 	protected.Get("/vulnerabilities/integrations", vulnRead, vulnIntegHandler.ListIntegrations)
 	protected.Post("/vulnerabilities/integrations", vulnWrite, vulnIntegHandler.SaveIntegration)
@@ -1756,17 +2102,42 @@ func main() {
 	// Placed here (rather than beside the other asset routes) because the node
 	// badges read open-vulnerability counts, which needs vulnRepo — declared
 	// just above. The vulnerability counter is optional: if it fails, the graph
-	// still renders, minus one badge.
+	// still renders, minus one badge. The attack-path analysis is the opposite:
+	// its hop costs ARE the vulnerability signals, so it fails rather than
+	// ranking paths on nothing.
 	assetTopologyHandler := handlers.NewAssetTopologyHandler(
 		assetapp.NewGetTopologyUseCase(assetRepo, assetDepRepo).WithVulnCounter(vulnRepo),
 		assetapp.NewGetCompromiseChainUseCase(assetRepo, assetDepRepo),
+		assetapp.NewGetAttackPathsUseCase(assetRepo, assetDepRepo, vulnRepo),
 	)
-	// Static sub-paths BEFORE /:id (the Fiber trap): "edge-types" must never be
-	// parsed as an asset UUID.
+	// Static sub-paths BEFORE /:id (the Fiber trap): "edge-types" and
+	// "attack-paths" must never be parsed as an asset UUID.
 	protected.Get("/attack-surface/topology/edge-types", assetRead, assetTopologyHandler.GetEdgeTypes)
+	protected.Get("/attack-surface/topology/attack-paths", assetRead, vulnRead, assetTopologyHandler.GetAttackPaths)
 	protected.Get("/attack-surface/topology", assetRead, assetTopologyHandler.GetTopology)
 	protected.Get("/attack-surface/topology/:id/compromise-chain", assetRead, assetTopologyHandler.GetCompromiseChain)
 
+	// --- Business impact analysis ---------------------------------------------
+	// A BIA hangs off a business_process asset; its RTO/RPO propagate down the
+	// same dependency graph as the topology above. Saving one re-feeds the
+	// process's expected downtime into its risks' CRQ (only where downtime was
+	// not entered by hand — see Risk.DowntimeHoursSource).
+	biaHandler := handlers.NewBIAHandler(
+		biaapp.NewService(repository.NewGormBIARepository(database.DB), assetRepo, assetDepRepo).
+			WithDowntimeSink(riskRepo).
+			WithAudit(governance.NewAuditRecorder(auditChainRepo)),
+	)
+	protected.Get("/bia/processes", assetRead, biaHandler.ListAnalyses)
+	protected.Get("/bia/processes/:assetId", assetRead, biaHandler.GetAnalysis)
+	protected.Put("/bia/processes/:assetId", assetUpdate, biaHandler.SaveAnalysis)
+	protected.Delete("/bia/processes/:assetId", assetUpdate, biaHandler.DeleteAnalysis)
+	protected.Get("/bia/processes/:assetId/report", assetRead, biaHandler.Report)
+	protected.Get("/bia/capabilities", assetRead, biaHandler.ListCapabilities)
+	protected.Put("/bia/capabilities/:assetId", assetUpdate, biaHandler.SaveCapability)
+	protected.Delete("/bia/capabilities/:assetId", assetUpdate, biaHandler.DeleteCapability)
+	protected.Get("/bia/propagation", assetRead, biaHandler.Propagation)
+	protected.Post("/bia/sync", assetUpdate, biaHandler.Sync)
+
 	// =========================================================================
 	// AI GRC Assistant (spec §12 — see ROADMAP.md Module 12).
 	// Unified AI service over the tenant's own GRC data: treatment-plan
@@ -1778,30 +2149,44 @@ func main() {
 	// every endpoint works out of the box with no key. Reuses the same key/model
 	// env vars as the board report.
 	// =========================================================================
-	aiAssistant := ai.NewAssistant(os.Getenv("ANTHROPIC_API_KEY"), os.Getenv("ANTHROPIC_MODEL"))
+	aiAssistant := ai.NewAssistantForProvider(aiDefaultProvider)
 	if ai.IsLLMBacked(aiAssistant) {
-		log.Printf("AI Assistant: Claude enabled (%s)", aiAssistant.Name())
+		log.Printf("AI Assistant: %s enabled (%s)", aiDefaultProvider.Provider, aiAssistant.Name())
 	} else {
-		log.Println("AI Assistant: no ANTHROPIC_API_KEY set — using deterministic template assistant")
+		log.Println("AI Assistant: no LLM provider configured — using deterministic template assistant")
 	}
-	aiTreatmentUC := appai.NewSuggestTreatmentPlanUseCase(aiAssistant, riskRepo).WithAssetReader(assetRepo)
-	aiEmergingUC := appai.NewDetectEmergingRisksUseCase(aiAssistant).WithRiskLister(riskRepo)
+	// Organisations may override the server default with their own provider —
+	// typically a self-hosted OpenAI-compatible model when risk data may not
+	// leave their network. Keys are sealed with the integration cipher. Their
+	// base URL may be an internal address only with AI_ALLOW_PRIVATE_NETWORKS=true.
+	aiProviders := appai.NewProviderSettingsService(
+		repository.NewGormAIProviderSettingRepository(database.DB), vulnIntegCipher, aiAssistant, boardAdvisor,
+	).WithAudit(governance.NewAuditRecorder(auditChainRepo)).
+		AllowPrivateNetworks(os.Getenv("AI_ALLOW_PRIVATE_NETWORKS") == "true")
+	generateBoardUC.WithAdvisors(aiProviders)
+	aiTreatmentUC := appai.NewSuggestTreatmentPlanUseCase(aiAssistant, riskRepo).WithAssetReader(assetRepo).WithProviders(aiProviders)
+	aiEmergingUC := appai.NewDetectEmergingRisksUseCase(aiAssistant).WithRiskLister(riskRepo).WithProviders(aiProviders)
 	// One reader spanning both halves of the register (see evidence_wiring.go).
 	aiComplianceReader := newAIComplianceReader(complianceRepo, evidenceRepo)
 	aiQueryUC := appai.NewAssistantQueryUseCase(aiAssistant).
 		WithRisks(riskRepo).
 		WithCompliance(aiComplianceReader).
 		WithVulns(vulnRepo).
-		WithOrgs(orgRepo)
-	aiAuditReportUC := appai.NewGenerateAuditReportUseCase(aiAssistant, complianceAuditRepo).WithGapAnalyzer(getGapAnalysisUC)
-	aiEvidenceUC := appai.NewAnalyzeEvidenceUseCase(aiAssistant, aiComplianceReader)
-	aiHandler := handlers.NewAIHandler(aiAssistant, aiTreatmentUC, aiEmergingUC, aiQueryUC, aiAuditReportUC, aiEvidenceUC)
+		WithOrgs(orgRepo).
+		WithProviders(aiProviders)
+	aiAuditReportUC := appai.NewGenerateAuditReportUseCase(aiAssistant, complianceAuditRepo).WithGapAnalyzer(getGapAnalysisUC).WithProviders(aiProviders)
+	aiEvidenceUC := appai.NewAnalyzeEvidenceUseCase(aiAssistant, aiComplianceReader).WithProviders(aiProviders)
+	aiHandler := handlers.NewAIHandler(aiAssistant, aiTreatmentUC, aiEmergingUC, aiQueryUC, aiAuditReportUC, aiEvidenceUC).
+		WithProviders(aiProviders)
 
 	// AI features are advisory (non-mutating): guarded by the read permission of
 	// the relevant module. The assistant/emerging endpoints use risks:read.
 	aiRiskRead := middleware.RequirePermission("risks:read")
 	aiComplianceRead := middleware.RequirePermission("compliance:read")
 	protected.Get("/ai/status", aiHandler.Status)
+	protected.Get("/ai/provider", middleware.RequireRole("admin"), aiHandler.GetProvider)
+	protected.Put("/ai/provider", middleware.RequireRole("admin"), aiHandler.SaveProvider)
+	protected.Delete("/ai/provider", middleware.RequireRole("admin"), aiHandler.DeleteProvider)
 	protected.Post("/ai/assistant/query", aiRiskRead, featAI, aiHandler.AssistantQuery)
 	protected.Post("/ai/emerging-risks", aiRiskRead, featAI, aiHandler.DetectEmergingRisks)
 	protected.Post("/ai/risks/:id/treatment-plan", aiRiskRead, featAI, aiHandler.SuggestTreatmentPlan)
@@ -1888,7 +2273,7 @@ func main() {
 	protected.Get("/bulk-operations/:id", bulkOpHandler.GetBulkOperation)
 
 	// --- Incidents (Protected routes) ---
-	incidentService := service.NewIncidentService(database.DB)
+	incidentService := service.NewIncidentService(database.DB).WithEvents(webhookService)
 
 	// The structured review. Its two collaborators — the multi-channel dispatcher
 	// and the mitigation use case — are built further down (the automation block),
@@ -1904,6 +2289,30 @@ func main() {
 		WithUserLookup(userRepo)
 	incidentService.WithPostMortemGate(incinfra.NewPostMortemGate(postMortemService))
 
+	// Regulatory notification clocks. The reminder channel is the automation
+	// dispatcher, attached with the incident notifier further down.
+	regulatoryService := appinc.NewRegulatoryService(repository.NewGormRegulatoryRepository(database.DB), incidentService).
+		WithCatalog(complianceRepo).
+		WithEvidenceLinker(evidenceService).
+		WithTimeline(incidentService).
+		WithAudit(governance.NewAuditRecorder(auditChainRepo)).
+		WithPostMortems(postMortemRepo)
+
+	// TheHive 5 case sync: incidents ⇄ cases, tasks ⇄ actions, observables →
+	// assets. The API key shares the connector key family (SCANNER_CREDENTIAL_KEY).
+	// The base URL may not be an internal address unless
+	// THEHIVE_ALLOW_PRIVATE_NETWORKS=true, for on-premise instances.
+	theHiveAllowPrivate := os.Getenv("THEHIVE_ALLOW_PRIVATE_NETWORKS") == "true"
+	theHiveSync := thehiveapp.NewSyncService(repository.NewGormTheHiveRepository(database.DB), incidentService, thehive.NewDialer(theHiveAllowPrivate), vulnIntegCipher).
+		WithAssets(assetRepo).
+		WithAudit(governance.NewAuditRecorder(auditChainRepo)).
+		AllowPrivateNetworks(theHiveAllowPrivate)
+	theHiveHandler = handlers.NewTheHiveHandler(theHiveSync)
+	protected.Get("/integrations/thehive", middleware.RequireRole("admin"), theHiveHandler.GetConnection)
+	protected.Put("/integrations/thehive", middleware.RequireRole("admin"), theHiveHandler.SaveConnection)
+	protected.Delete("/integrations/thehive", middleware.RequireRole("admin"), theHiveHandler.DeleteConnection)
+	protected.Post("/integrations/thehive/sync", incidentUpdate, theHiveHandler.SyncNow)
+
 	// =========================================================================
 	// Reporting engine (spec §5). Asynchronous generation into PDF / DOCX /
 	// XLSX, six report types, a document language chosen independently of the
@@ -1970,10 +2379,19 @@ func main() {
 	protected.Post("/reports/:reportId/transition", middleware.RequirePermission("reports:board:approve"), reportHandler.Transition)
 
 	incidentHandler := handlers.NewIncidentHandler(incidentService).
-		WithPostMortems(postMortemService)
+		WithPostMortems(postMortemService).
+		WithRegulatory(regulatoryService)
 	incidentsGroup := protected.Group("/incidents")
 	incidentsGroup.Post("", incidentCreate, incidentHandler.CreateIncident)
 	incidentsGroup.Get("/stats", incidentHandler.GetIncidentStats)
+	incidentsGroup.Get("/regulatory/regimes", incidentHandler.ListRegulatoryRegimes)
+	incidentsGroup.Get("/regulatory/profiles", incidentHandler.ListRegulatoryProfiles)
+	incidentsGroup.Post("/regulatory/profiles", incidentUpdate, incidentHandler.CreateRegulatoryProfile)
+	incidentsGroup.Put("/regulatory/profiles/:profileId", incidentUpdate, incidentHandler.UpdateRegulatoryProfile)
+	incidentsGroup.Delete("/regulatory/profiles/:profileId", incidentUpdate, incidentHandler.DeleteRegulatoryProfile)
+	incidentsGroup.Get("/regulatory/clocks", incidentHandler.ListOpenRegulatoryClocks)
+	incidentsGroup.Get("/regulatory/clocks/:clockId/draft", incidentHandler.GetRegulatoryDraft)
+	incidentsGroup.Post("/regulatory/clocks/:clockId/submit", incidentUpdate, incidentHandler.SubmitRegulatoryNotification)
 	// Static sub-paths before /:id (Fiber trap).
 	incidentsGroup.Get("/origins", incidentHandler.ListIncidentOrigins)
 	incidentsGroup.Get("", incidentHandler.ListIncidents)
@@ -1984,6 +2402,10 @@ func main() {
 	incidentsGroup.Get("/:id/post-mortem", incidentHandler.GetPostMortem)
 	incidentsGroup.Put("/:id/post-mortem", incidentUpdate, incidentHandler.SavePostMortem)
 	incidentsGroup.Post("/:id/post-mortem/publish", incidentUpdate, incidentHandler.PublishPostMortem)
+	incidentsGroup.Get("/:id/regulatory", incidentHandler.GetRegulatoryStatus)
+	incidentsGroup.Get("/:id/thehive", theHiveHandler.GetIncidentLink)
+	incidentsGroup.Post("/:id/thehive", incidentUpdate, theHiveHandler.PushIncident)
+	incidentsGroup.Put("/:id/regulatory", incidentUpdate, incidentHandler.ClassifyRegulatory)
 	incidentsGroup.Post("/:id/risks/:riskId", incidentUpdate, incidentHandler.LinkRisk)
 	incidentsGroup.Post("/:id/actions", incidentUpdate, incidentHandler.CreateIncidentAction)
 	incidentsGroup.Get("/:id/actions", incidentHandler.GetIncidentActions)
@@ -2007,6 +2429,8 @@ func main() {
 	// the same pointer, so ordering is the only constraint. A draft nobody is
 	// told about is a draft nobody reviews.
 	vulnIngestUC.WithRiskProposalNotifier(vulnrisk.NewDraftRiskNotifier(database.DB, notificationUseCase))
+	vendorService.WithRiskProposalNotifier(vendorrisk.NewDraftRiskNotifier(database.DB, notificationUseCase))
+	controlTestService.WithNotifier(notificationUseCase)
 	notificationsGroup := protected.Group("/notifications")
 	notificationsGroup.Get("", notificationHandler.GetNotifications)
 	notificationsGroup.Get("/unread-count", notificationHandler.GetUnreadCount)
@@ -2035,7 +2459,7 @@ func main() {
 	protected.Get("/analytics/mitigations/metrics", analyticsHandler.GetMitigationMetrics)
 	protected.Get("/analytics/frameworks", analyticsHandler.GetFrameworkAnalytics)
 	protected.Get("/analytics/dashboard", analyticsHandler.GetDashboardSnapshot)
-	protected.Get("/analytics/export", analyticsHandler.GetExportData)
+	protected.Get("/analytics/export", registerSnapshotHandler.AsOfExport(analyticsHandler.GetExportData))
 	// Financial Risk Quantification dashboard (spec §9) — tenant-wide portfolio
 	// ALE / worst-case / residual / remediation budget / ROSI for the CFO/CISO screen.
 	protected.Get("/analytics/financial",
@@ -2050,7 +2474,7 @@ func main() {
 	// existing tenant-scoped sources; every source is nil-safe in the use case.
 	executiveDashboardHandler := newExecutiveDashboardHandler(
 		financialSummaryUseCase, riskRepo, getGapAnalysisUC, vulnRepo, incidentService, riskQuantifier,
-		ahaRecorder,
+		ahaRecorder, appetiteService,
 	)
 	protected.Get("/analytics/executive",
 		middleware.RequirePermission("risks:read"), featExecutive, executiveDashboardHandler.GetExecutiveDashboard)
@@ -2058,11 +2482,11 @@ func main() {
 	// --- Enhanced Dashboard Analytics (Protected routes) ---
 	dashboardDataService := service.NewDashboardDataService(database.DB, nil)
 	enhancedDashboardHandler := handlers.NewEnhancedDashboardHandler(dashboardDataService)
-	protected.Get("/dashboard/metrics", enhancedDashboardHandler.GetDashboardMetrics)
+	protected.Get("/dashboard/metrics", registerSnapshotHandler.AsOfDashboardMetrics(enhancedDashboardHandler.GetDashboardMetrics))
 	protected.Get("/dashboard/risk-trends", enhancedDashboardHandler.GetRiskTrends)
-	protected.Get("/dashboard/severity-distribution", enhancedDashboardHandler.GetSeverityDistribution)
+	protected.Get("/dashboard/severity-distribution", registerSnapshotHandler.AsOfSeverityDistribution(enhancedDashboardHandler.GetSeverityDistribution))
 	protected.Get("/dashboard/mitigation-status", enhancedDashboardHandler.GetMitigationStatus)
-	protected.Get("/dashboard/top-risks", enhancedDashboardHandler.GetTopRisks)
+	protected.Get("/dashboard/top-risks", registerSnapshotHandler.AsOfTopRisks(enhancedDashboardHandler.GetTopRisks))
 	protected.Get("/dashboard/mitigation-progress", enhancedDashboardHandler.GetMitigationProgress)
 	protected.Get("/dashboard/complete", enhancedDashboardHandler.GetCompleteDashboard)
 
@@ -2244,6 +2668,29 @@ func main() {
 	// forward declaration above); assign the handler they capture.
 	invitationPublicHandler = memberHandler
 
+	// Group hierarchy. A holding links its subsidiaries (group:manage, admins
+	// hold it through "*"); a link grants nothing until the subsidiary's own
+	// administrator accepts it from inside that organization. The roll-up
+	// routes read the current organization and the subsidiaries below it, so
+	// "group:read" is checked where it means something — in the parent — and
+	// a subsidiary's members gain nothing over their siblings or their parent.
+	groupHandler := handlers.NewGroupHandler(
+		groupapp.NewService(repository.NewGormOrgHierarchyRepository(database.DB), riskQuantifier).
+			WithPresenters(financialPresenters).
+			WithAudit(governance.NewAuditRecorder(auditChainRepo)))
+	groupRead := middleware.RequirePermission("group:read")
+	groupManage := middleware.RequirePermission("group:manage")
+	protected.Get("/group/links", middleware.RequirePermission("group:read", "group:manage"), groupHandler.ListLinks)
+	protected.Post("/group/links", groupManage, groupHandler.RequestLink)
+	protected.Post("/group/links/:id/accept", groupManage, groupHandler.AcceptLink)
+	protected.Delete("/group/links/:id", groupManage, groupHandler.RevokeLink)
+	protected.Get("/group/entities", groupRead, groupHandler.Entities)
+	protected.Get("/group/heatmap", groupRead, groupHandler.Heatmap)
+	protected.Get("/group/categories", groupRead, groupHandler.Categories)
+	protected.Get("/group/monte-carlo", groupRead, groupHandler.MonteCarlo)
+	protected.Get("/group/compliance", groupRead, groupHandler.Compliance)
+	protected.Get("/group/risks", groupRead, groupHandler.Risks)
+
 	// The RBAC catalog: the permission vocabulary and the business-role presets,
 	// readable by any authenticated member so the UI can render the permission
 	// matrix. It is reference material about the product, not data about anyone's
@@ -2360,7 +2807,15 @@ func main() {
 			if user, uerr := userRepo.GetByID(ctx, userID); uerr == nil && user != nil && user.Email != "" {
 				_ = emailTransport.SendEmail(ctx, user.Email, subject, message)
 			}
-		}, zeroLogger)
+		}, zeroLogger).
+		WithExpiryAnnouncements(evidenceRepo, func(ctx context.Context, ev domain.Evidence) {
+			if err := webhookService.Publish(ctx, ev.TenantID, domain.WebhookEvidenceExpired, domain.WebhookEvidenceExpiredData{
+				EvidenceID: ev.ID, Title: ev.Title, ValidUntil: *ev.ValidUntil,
+				OwnerID: ev.Ownership.OwnerID, AssigneeID: ev.Ownership.AssigneeID,
+			}); err != nil {
+				zeroLogger.Warn().Err(err).Msg("evidence expiry: webhook publish failed")
+			}
+		})
 	go evidenceExpiryWorker.Start(context.Background())
 
 	scanPipeline := scanpkg.NewPipeline(scanRegistry, scanPreview, scanNotifier, zeroLogger)
@@ -2375,6 +2830,89 @@ func main() {
 	mitigationDetector := scanmitigation.NewDetector(database.DB, autoCompleteUC, ctiSubActionRepo, redisClientInstance, zeroLogger)
 	scanPipeline = scanPipeline.WithMitigationDetector(mitigationDetector)
 
+	// Connector plugins: signed WASM bundles run in a wazero sandbox. Bundles
+	// must be signed by a key in PLUGIN_TRUSTED_KEYS ("id:base64,..."); with
+	// none configured every upload is refused. Findings go through vulnerability
+	// ingest, emitted assets into a scan preview for review.
+	pluginKeys, err := pluginhost.ParseKeyring(os.Getenv("PLUGIN_TRUSTED_KEYS"))
+	if err != nil {
+		log.Fatalf("invalid PLUGIN_TRUSTED_KEYS: %v", err)
+	}
+	if len(pluginKeys) == 0 {
+		log.Println("PLUGIN_TRUSTED_KEYS is empty: plugin uploads are disabled")
+	}
+	pluginRuntime := pluginhost.NewHost().AllowPrivateNetworks(os.Getenv("PLUGIN_ALLOW_PRIVATE_NETWORKS") == "true")
+	pluginHandler = handlers.NewPluginHandler(
+		plugins.NewService(repository.NewGormPluginRepository(database.DB), pluginRuntime, pluginKeys, vulnIntegCipher).
+			WithFindings(plugins.IngestAdapter{Ingest: vulnIngestUC}).
+			WithAssets(plugins.PreviewAdapter{Pipeline: scanPipeline}).
+			WithAudit(governance.NewAuditRecorder(auditChainRepo)))
+	protected.Get("/plugins", adminOnly, pluginHandler.ListPackages)
+	protected.Post("/plugins", adminOnly, pluginHandler.Upload)
+	protected.Get("/plugins/:id", adminOnly, pluginHandler.GetPackage)
+	protected.Delete("/plugins/:id", adminOnly, pluginHandler.DeletePackage)
+	protected.Get("/plugin-installs", adminOnly, pluginHandler.ListInstalls)
+	protected.Post("/plugin-installs", adminOnly, pluginHandler.CreateInstall)
+	protected.Get("/plugin-installs/:id", adminOnly, pluginHandler.GetInstall)
+	protected.Put("/plugin-installs/:id", adminOnly, pluginHandler.UpdateInstall)
+	protected.Delete("/plugin-installs/:id", adminOnly, pluginHandler.DeleteInstall)
+	protected.Post("/plugin-installs/:id/run", adminOnly, pluginHandler.Run)
+	protected.Get("/plugin-installs/:id/runs", adminOnly, pluginHandler.Runs)
+
+	// Outbound webhooks (docs/WEBHOOKS.md): signing secrets are encrypted with
+	// the connector key family and shown once. Deliveries go out through a
+	// client that refuses internal addresses unless
+	// WEBHOOK_ALLOW_PRIVATE_NETWORKS=true. The bridge relays the events that
+	// already travel on Redis; the other four are published by their use cases.
+	webhookService.WithCipher(vulnIntegCipher).
+		WithAudit(governance.NewAuditRecorder(auditChainRepo)).
+		AllowPrivateNetworks(os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS") == "true")
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	protected.Get("/webhook-events", adminOnly, webhookHandler.Events)
+	protected.Get("/webhook-events/:type/schema", adminOnly, webhookHandler.EventSchema)
+	protected.Get("/webhooks", adminOnly, webhookHandler.List)
+	protected.Post("/webhooks", adminOnly, webhookHandler.Create)
+	protected.Get("/webhooks/:id", adminOnly, webhookHandler.Get)
+	protected.Put("/webhooks/:id", adminOnly, webhookHandler.Update)
+	protected.Delete("/webhooks/:id", adminOnly, webhookHandler.Delete)
+	protected.Post("/webhooks/:id/rotate-secret", adminOnly, webhookHandler.RotateSecret)
+	protected.Get("/webhooks/:id/deliveries", adminOnly, webhookHandler.Deliveries)
+	protected.Get("/webhook-deliveries/:id", adminOnly, webhookHandler.GetDelivery)
+	protected.Post("/webhook-deliveries/:id/redeliver", adminOnly, webhookHandler.Redeliver)
+	workers.NewWebhookDeliveryWorker(webhookService, zeroLogger).Start(context.Background())
+	go workers.NewWebhookEventBridge(redisClientInstance, webhookService, zeroLogger).Start(context.Background())
+
+	// Access policies: administration is admin-only; the attribute catalogue and
+	// explain are open to every member (explain redacts what they cannot see).
+	accessPolicyHandler := handlers.NewAccessPolicyHandler(accessPolicyService)
+	protected.Get("/access-policies/attributes", accessPolicyHandler.Attributes)
+	protected.Get("/access-policies/explain", accessPolicyHandler.Explain)
+	protected.Get("/access-policies/subjects/:id", adminOnly, accessPolicyHandler.GetSubject)
+	protected.Put("/access-policies/subjects/:id", adminOnly, accessPolicyHandler.SetSubjectAttributes)
+	protected.Get("/access-policies", adminOnly, accessPolicyHandler.List)
+	protected.Post("/access-policies", adminOnly, accessPolicyHandler.Create)
+	protected.Get("/access-policies/:id", adminOnly, accessPolicyHandler.Get)
+	protected.Put("/access-policies/:id", adminOnly, accessPolicyHandler.Update)
+	protected.Delete("/access-policies/:id", adminOnly, accessPolicyHandler.Delete)
+
+	// SIEM export (docs/SIEM_EXPORT.md): the audit chain and the auth log,
+	// streamed per tenant over syslog/TLS or HEC as CEF or OCSF. HEC tokens
+	// are encrypted like webhook secrets; internal collectors need
+	// SIEM_ALLOW_PRIVATE_NETWORKS=true.
+	siemService := siemapp.NewService(repository.NewGormSIEMRepository(database.DB)).
+		WithCipher(vulnIntegCipher).
+		WithAudit(governance.NewAuditRecorder(auditChainRepo)).
+		AllowPrivateNetworks(os.Getenv("SIEM_ALLOW_PRIVATE_NETWORKS") == "true").
+		WithProductVersion(Version)
+	siemHandler := handlers.NewSIEMHandler(siemService)
+	protected.Get("/siem-destinations", adminOnly, siemHandler.List)
+	protected.Post("/siem-destinations", adminOnly, siemHandler.Create)
+	protected.Get("/siem-destinations/:id", adminOnly, siemHandler.Get)
+	protected.Put("/siem-destinations/:id", adminOnly, siemHandler.Update)
+	protected.Delete("/siem-destinations/:id", adminOnly, siemHandler.Delete)
+	protected.Post("/siem-destinations/:id/backfill", adminOnly, siemHandler.Backfill)
+	workers.NewSIEMExportWorker(siemService, zeroLogger).Start(context.Background())
+
 	// Assign the forward-declared SSE handler (route registered earlier on `app`,
 	// before the /api/v1 JWT middleware).
 	mitigationEventsHandler = handlers.NewMitigationEventsHandler(redisClientInstance, rsaKeys, jtiBlacklistChecker)
@@ -2481,6 +3019,19 @@ func main() {
 	)
 	protected.Get("/search", searchHandler.Search)
 
+	// GraphQL read API: joined views over risks, assets, vulnerabilities and
+	// controls. The routes only need a session — every field checks its own
+	// permission and every query is costed before it runs (GRAPHQL_MAX_COST,
+	// default 50000 objects).
+	graphMaxCost, _ := strconv.Atoi(strings.TrimSpace(os.Getenv("GRAPHQL_MAX_COST")))
+	graphqlHandler := handlers.NewGraphQLHandler(
+		graphapi.New(repository.NewGormGraphRepository(database.DB)).
+			WithLimits(graphapi.Limits{MaxCost: graphMaxCost}).
+			WithPersistedQueries(repository.NewGormPersistedQueryRepository(database.DB)))
+	protected.Post("/graphql", graphqlHandler.Query)
+	protected.Get("/graphql", graphqlHandler.QueryGet)
+	protected.Get("/graphql/schema", graphqlHandler.Schema)
+
 	// =========================================================================
 	// SCORE — the single authority for every number this product calls a "score"
 	//
@@ -2602,6 +3153,7 @@ func main() {
 	// A second dispatcher would mean a tenant configuring Slack twice and
 	// wondering why only half their alerts arrive.
 	incidentService.WithNotifier(incinfra.NewNotifier(automationNotifier, zeroLogger))
+	regulatoryService.WithAlerter(incinfra.NewDeadlineAlerter(automationNotifier, zeroLogger))
 
 	automationSLAService := appauto.NewSLAService(slaTrackerRepo, zeroLogger).
 		WithNotifier(automationNotifier).
@@ -2662,12 +3214,31 @@ func main() {
 	protected.Get("/automation/rules/:id/executions", automationRead, automationHandler.ListRuleExecutions)
 	protected.Post("/automation/executions/:id/replay", automationWrite, automationHandler.ReplayExecution)
 
+	// Tenant configuration as a document, for GitOps through `openrisk config
+	// export|import`: automation rules, risk taxonomy, asset schemas and scoring
+	// weights, keyed by natural key so one file applies to any tenant. Every
+	// section is an admin setting, so the whole document is admin-only.
+	tenantConfigHandler := handlers.NewTenantConfigHandler(
+		tenantconfig.NewService(automationRuleRepo,
+			repository.NewGormRiskCategoryRepository(database.DB),
+			repository.NewGormAssetTypeSchemaRepository(database.DB),
+			smartWeightsRepo,
+		).WithAudit(governance.NewAuditRecorder(auditChainRepo)))
+	protected.Get("/config/export", adminOnly, tenantConfigHandler.Export)
+	protected.Post("/config/import", adminOnly, tenantConfigHandler.Import)
+
 	// Background workers: the SOAR engine (event-driven) and the SLA monitor (cadence).
 	automationWorker := workers.NewAutomationWorker(redisClientInstance, automationEngine, zeroLogger)
 	go automationWorker.Start(context.Background())
 	slaMonitor := workers.NewSLAMonitor(automationSLAService, zeroLogger)
 	go slaMonitor.Start(context.Background())
-	log.Println("Automation: SOAR engine + SLA monitor started (triggers: vulnerability.detected, risk.score_updated)")
+	regulatoryMonitor := workers.NewRegulatoryClockMonitor(regulatoryService, zeroLogger)
+	go regulatoryMonitor.Start(context.Background())
+	go workers.NewTheHiveSyncWorker(theHiveSync, zeroLogger).Start(context.Background())
+	go workers.NewKRIRefreshWorker(kriService, zeroLogger).Start(context.Background())
+	go workers.NewRegisterSnapshotWorker(registerSnapshotService, zeroLogger).Start(context.Background())
+	go workers.NewVendorReassessmentWorker(vendorService, zeroLogger).Start(context.Background())
+	log.Println("Automation: SOAR engine + SLA monitor started (triggers: vulnerability.detected, risk.score_updated, kri.threshold_crossed)")
 
 	// =========================================================================
 	// 5.10 GOVERNANCE (spec §15 « Gouvernance »)
@@ -2685,6 +3256,32 @@ func main() {
 	approvalRepo := repository.NewGormApprovalRepository(database.DB)
 	governanceRecorder := governance.NewAuditRecorder(auditChainRepo)
 
+	// External anchoring: the chain head is signed with the export key (and
+	// countersigned by AUDIT_TSA_URL when set), so a rewrite of the whole chain
+	// by someone with database access no longer matches checkpoints kept
+	// elsewhere. Without the key there is nothing verifiable to offer; the
+	// endpoints answer 503 and the worker does not run.
+	auditCheckpointRepo := repository.NewGormAuditCheckpointRepository(database.DB)
+	var (
+		checkpointAudit    *governance.CheckpointAuditChainUseCase
+		listCheckpoints    *governance.ListAuditCheckpointsUseCase
+		exportAuditSegment *governance.ExportAuditSegmentUseCase
+	)
+	if exportSigner != nil {
+		checkpointAudit = governance.NewCheckpointAuditChainUseCase(auditCheckpointRepo, exportSigner).
+			WithEvents(webhookService)
+		if tsaURL := strings.TrimSpace(os.Getenv("AUDIT_TSA_URL")); tsaURL != "" {
+			checkpointAudit.WithTimestamper(crypto.NewTimestampClient(tsaURL))
+			log.Printf("Audit checkpoints: timestamping with %s", tsaURL)
+		}
+		listCheckpoints = governance.NewListAuditCheckpointsUseCase(auditCheckpointRepo)
+		exportAuditSegment = governance.NewExportAuditSegmentUseCase(auditCheckpointRepo, auditChainRepo).
+			WithPublicKey(checkpointAudit.PublicKey())
+		checkpointInterval, _ := time.ParseDuration(strings.TrimSpace(os.Getenv("AUDIT_CHECKPOINT_INTERVAL")))
+		go workers.NewAuditCheckpointWorker(checkpointAudit, zeroLogger).
+			WithInterval(checkpointInterval).Start(context.Background())
+	}
+
 	// An approval inbox nobody is told to open is a queue, not a workflow: the
 	// notifier alerts whoever can sign right now (named approvers, role holders,
 	// and anyone currently covering for one of them by delegation), and tells the
@@ -2693,6 +3290,13 @@ func main() {
 	approvalNotifier := govinfra.NewApprovalNotifier(database.DB, notificationUseCase, emailTransport, zeroLogger)
 	approvalRoles := govinfra.NewRoleResolver(database.DB)
 
+	submitApproval := governance.NewSubmitApprovalRequestUseCase(approvalRepo, approvalRepo).
+		WithRecorder(governanceRecorder).
+		WithNotifier(approvalNotifier)
+	// Appetite exceptions are ordinary approval requests, so they reach the same
+	// inboxes, notifications and audit trail as every other decision.
+	appetiteService.WithExceptionSubmitter(newAppetiteExceptionSubmitter(submitApproval))
+
 	governanceHandler := handlers.NewGovernanceHandler(handlers.GovernanceDeps{
 		ListAudit: governance.NewListAuditEventsUseCase(auditChainRepo).WithUserLookup(userRepo),
 		Recorder:  governanceRecorder,
@@ -2703,6 +3307,10 @@ func main() {
 		SetRetention: governance.NewSetRetentionPolicyUseCase(auditRetentionRepo).WithRecorder(governanceRecorder),
 		PruneAudit:   governance.NewPruneAuditTrailUseCase(auditChainRepo, auditRetentionRepo),
 
+		CheckpointAudit:    checkpointAudit,
+		ListCheckpoints:    listCheckpoints,
+		ExportAuditSegment: exportAuditSegment,
+
 		CreateDelegation: governance.NewCreateDelegationUseCase(delegationRepo).WithRecorder(governanceRecorder).WithUserLookup(userRepo),
 		ListDelegations:  governance.NewListDelegationsUseCase(delegationRepo).WithUserLookup(userRepo),
 		RevokeDelegation: governance.NewRevokeDelegationUseCase(delegationRepo).WithRecorder(governanceRecorder),
@@ -2713,13 +3321,12 @@ func main() {
 		UpdateWorkflow: governance.NewUpdateWorkflowUseCase(approvalRepo),
 		DeleteWorkflow: governance.NewDeleteWorkflowUseCase(approvalRepo),
 
-		SubmitApproval: governance.NewSubmitApprovalRequestUseCase(approvalRepo, approvalRepo).
-			WithRecorder(governanceRecorder).
-			WithNotifier(approvalNotifier),
+		SubmitApproval: submitApproval,
 		DecideApproval: governance.NewDecideApprovalUseCase(approvalRepo).
 			WithRecorder(governanceRecorder).
 			WithNotifier(approvalNotifier).
-			WithDelegations(delegationRepo, approvalRoles),
+			WithDelegations(delegationRepo, approvalRoles).
+			WithEvents(webhookService),
 		ApprovalDetail: governance.NewGetApprovalDetailUseCase(approvalRepo).
 			WithDelegations(delegationRepo, approvalRoles).
 			WithUserLookup(userRepo),
@@ -2734,10 +3341,14 @@ func main() {
 	// (Fiber trap: /:id would otherwise swallow "export" and "verify").
 	protected.Get("/governance/audit-events/export", governanceAdmin, governanceHandler.ExportAuditEvents)
 	protected.Get("/governance/audit-events/verify", governanceAdmin, governanceHandler.VerifyAuditChain)
+	protected.Get("/governance/audit-events/segment", governanceAdmin, governanceHandler.ExportAuditSegment)
 	protected.Get("/governance/audit-events", governanceAdmin, governanceHandler.ListAuditEvents)
 	protected.Get("/governance/audit-retention", governanceAdmin, governanceHandler.GetAuditRetention)
 	protected.Put("/governance/audit-retention", governanceAdmin, governanceHandler.SetAuditRetention)
 	protected.Post("/governance/audit-retention/apply", governanceAdmin, governanceHandler.RunAuditRetention)
+	protected.Get("/governance/audit-checkpoints/public-key", governanceAdmin, governanceHandler.AuditCheckpointPublicKey)
+	protected.Get("/governance/audit-checkpoints", governanceAdmin, governanceHandler.ListAuditCheckpoints)
+	protected.Post("/governance/audit-checkpoints", governanceAdmin, governanceHandler.CreateAuditCheckpoint)
 
 	// Nightly-ish retention sweep. No-op for every tenant that kept the default
 	// (keep forever) — nothing is deleted unless someone configured a window.
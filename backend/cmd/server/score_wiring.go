@@ -52,6 +52,11 @@ func (a incidentPressureAdapter) OpenIncidentCounts(_ context.Context, tenantID
 	return analytics.OpenCount, analytics.CriticalOpen, nil
 }
 
+// OpenIncidentCountsForAsset returns (open, criticalOpen) for one asset.
+func (a incidentPressureAdapter) OpenIncidentCountsForAsset(_ context.Context, tenantID, assetID uuid.UUID) (int, int, error) {
+	return a.svc.OpenIncidentCountsForAsset(tenantID.String(), assetID.String())
+}
+
 // newScoreHandler assembles the ONE score endpoint from the already-constructed,
 // tenant-scoped sources. Every source is optional in the use case, so a missing
 // one degrades its own factor instead of failing the request.
@@ -72,6 +77,7 @@ func newScoreHandler(
 		WithVulns(vulnRepo).
 		WithMitigations(mitigationRepo).
 		WithCompliance(complianceCoverageAdapter{gaps: gapUC}).
-		WithIncidents(incidentPressureAdapter{svc: incidentSvc})
+		WithIncidents(incidentPressureAdapter{svc: incidentSvc}).
+		WithAssetIncidents(incidentPressureAdapter{svc: incidentSvc})
 	return handlers.NewScoreHandler(uc)
 }
@@ -0,0 +1,32 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/opendefender/openrisk/pkg/crypto"
+)
+
+// newExportSigner builds the Ed25519 signer for documents verified outside the
+// product (evidence packages, audit checkpoints) from EXPORT_SIGNING_KEY. Nil
+// when unset: evidence packages are then produced unsigned and say so, and
+// audit checkpoints are not offered at all.
+func newExportSigner() *crypto.Signer {
+	raw := os.Getenv("EXPORT_SIGNING_KEY")
+	if raw == "" {
+		log.Println("Signing: EXPORT_SIGNING_KEY not set — evidence packages will be unsigned and audit checkpoints are disabled")
+		return nil
+	}
+	s, err := crypto.NewSignerFromSecret(raw)
+	if err != nil {
+		log.Printf("Signing: EXPORT_SIGNING_KEY rejected (%v) — evidence packages will be unsigned and audit checkpoints are disabled", err)
+		return nil
+	}
+	log.Printf("Signing: export signing key loaded (Ed25519, key id %s)", s.KeyID())
+	return s
+}
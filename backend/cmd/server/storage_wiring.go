@@ -0,0 +1,181 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/opendefender/openrisk/internal/application/evidence"
+	"github.com/opendefender/openrisk/internal/infrastructure/database"
+	"github.com/opendefender/openrisk/internal/infrastructure/repository"
+	"github.com/opendefender/openrisk/pkg/storage"
+)
+
+// newFileStorage builds the evidence blob store from the environment.
+//
+//	STORAGE_DRIVER          local (default) | s3
+//	STORAGE_LOCAL_PATH      local root, default ./uploads
+//	S3_BUCKET, S3_REGION, S3_ENDPOINT, S3_ACCESS_KEY_ID, S3_SECRET_ACCESS_KEY,
+//	S3_FORCE_PATH_STYLE, S3_PREFIX
+//	S3_OBJECT_LOCK_MODE     GOVERNANCE | COMPLIANCE (empty: no per-object lock)
+//	S3_OBJECT_LOCK_DAYS     retention period for the lock
+//	STORAGE_ENCRYPTION_KEY  optional; overrides MFA_ENCRYPTION_KEY as the key
+//	                        wrapping the per-tenant data keys (any length;
+//	                        hashed to 32 bytes, the MFA_ENCRYPTION_KEY convention)
+//
+// S3 objects are always envelope-encrypted. With neither key set the server
+// refuses to start on s3 rather than fall back to the published dev key.
+//
+// S3 credentials may be left empty to use the SDK's default chain (IRSA on EKS,
+// an instance profile, AWS_* env).
+func newFileStorage(ctx context.Context) (storage.Storage, error) {
+	switch driver := strings.ToLower(strings.TrimSpace(os.Getenv("STORAGE_DRIVER"))); driver {
+	case "", "local":
+		return newLocalFileStorage()
+	case "s3":
+		cfg, err := s3ConfigFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		s, err := storage.NewS3Storage(ctx, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize S3 storage: %w", err)
+		}
+		log.Printf("Storage: s3 driver initialized (bucket=%s, prefix=%q, object_lock=%s, envelope_encryption=%t)",
+			cfg.Bucket, cfg.Prefix, orNone(string(cfg.RetentionMode)), s.Encrypted())
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_DRIVER %q (expected local or s3)", driver)
+	}
+}
+
+func newLocalFileStorage() (*storage.LocalStorage, error) {
+	path := os.Getenv("STORAGE_LOCAL_PATH")
+	if path == "" {
+		path = "./uploads"
+	}
+	s, err := storage.NewLocalStorage(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize local storage: %w", err)
+	}
+	log.Println("Storage: local driver initialized at", path)
+	return s, nil
+}
+
+func s3ConfigFromEnv() (storage.S3Config, error) {
+	cfg := storage.S3Config{
+		Bucket:          strings.TrimSpace(os.Getenv("S3_BUCKET")),
+		Region:          strings.TrimSpace(os.Getenv("S3_REGION")),
+		Endpoint:        strings.TrimSpace(os.Getenv("S3_ENDPOINT")),
+		AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		UsePathStyle:    os.Getenv("S3_FORCE_PATH_STYLE") == "true",
+		Prefix:          strings.Trim(strings.TrimSpace(os.Getenv("S3_PREFIX")), "/"),
+	}
+	if cfg.Prefix != "" {
+		cfg.Prefix += "/"
+	}
+
+	switch mode := strings.ToUpper(strings.TrimSpace(os.Getenv("S3_OBJECT_LOCK_MODE"))); mode {
+	case "":
+	case string(types.ObjectLockModeGovernance), string(types.ObjectLockModeCompliance):
+		cfg.RetentionMode = types.ObjectLockMode(mode)
+		days, err := strconv.Atoi(strings.TrimSpace(os.Getenv("S3_OBJECT_LOCK_DAYS")))
+		if err != nil || days <= 0 {
+			return cfg, errors.New("S3_OBJECT_LOCK_MODE is set but S3_OBJECT_LOCK_DAYS is not a positive number of days")
+		}
+		cfg.RetentionDays = days
+	default:
+		return cfg, fmt.Errorf("invalid S3_OBJECT_LOCK_MODE %q (expected GOVERNANCE or COMPLIANCE)", mode)
+	}
+
+	key, err := storageMasterKey()
+	if err != nil {
+		return cfg, err
+	}
+	cfg.MasterKey = key
+	return cfg, nil
+}
+
+// storageMasterKey is the key wrapping the per-tenant data keys: the pkg/crypto
+// AES key (MFA_ENCRYPTION_KEY), unless STORAGE_ENCRYPTION_KEY overrides it.
+func storageMasterKey() ([]byte, error) {
+	raw := os.Getenv("STORAGE_ENCRYPTION_KEY")
+	if raw == "" {
+		raw = os.Getenv("MFA_ENCRYPTION_KEY")
+	}
+	if raw == "" {
+		return nil, errors.New("STORAGE_DRIVER=s3 needs MFA_ENCRYPTION_KEY (or STORAGE_ENCRYPTION_KEY) to envelope-encrypt evidence")
+	}
+	key := sha256.Sum256([]byte(raw))
+	return key[:], nil
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+// runStorageCLI implements `<server> storage migrate [--dry-run]`: copy every
+// evidence file from STORAGE_LOCAL_PATH into the store STORAGE_DRIVER selects and
+// repoint Evidence.FileRef. Safe to re-run; see evidence.RelocateFiles.
+func runStorageCLI(args []string) error {
+	if len(args) == 0 || args[0] != "migrate" {
+		return errors.New("storage: expected a subcommand: migrate [--dry-run]")
+	}
+	dryRun := false
+	for _, a := range args[1:] {
+		switch a {
+		case "--dry-run":
+			dryRun = true
+		default:
+			return fmt.Errorf("storage migrate: unknown flag %q", a)
+		}
+	}
+
+	ctx := context.Background()
+	to, err := newFileStorage(ctx)
+	if err != nil {
+		return err
+	}
+	if _, isLocal := to.(*storage.LocalStorage); isLocal {
+		return errors.New("storage migrate: STORAGE_DRIVER is local — set it to the destination driver (s3) first")
+	}
+	from, err := newLocalFileStorage()
+	if err != nil {
+		return err
+	}
+
+	database.Connect()
+	res, err := evidence.RelocateFiles(ctx, repository.NewGormEvidenceRepository(database.DB), from, to, dryRun)
+	if err != nil {
+		return fmt.Errorf("storage migrate: %w", err)
+	}
+	verb := "moved"
+	if dryRun {
+		verb = "would move"
+	}
+	log.Printf("storage migrate: scanned %d, %s %d, missing from source %d, failed %d",
+		res.Scanned, verb, res.Moved, res.Missing, res.Failed)
+	for _, e := range res.Errors {
+		log.Printf("storage migrate: %s", e)
+	}
+	if res.Failed > 0 {
+		return fmt.Errorf("storage migrate: %d file(s) failed; re-run after fixing them (completed files are skipped)", res.Failed)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package main
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/application/evidence"
+	vendorapp "github.com/opendefender/openrisk/internal/application/vendor"
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// vendorEvidenceRecorder files what a vendor submits through the portal in the
+// evidence library. A document is a document; the questionnaire response is
+// the vendor's own statement, so an attestation. Both arrive pending review —
+// the vendor vouching for itself is the claim, not the verdict — and with no
+// collector, because nobody in the tenant collected them.
+type vendorEvidenceRecorder struct {
+	svc *evidence.Service
+}
+
+func newVendorEvidenceRecorder(svc *evidence.Service) *vendorEvidenceRecorder {
+	return &vendorEvidenceRecorder{svc: svc}
+}
+
+func (r *vendorEvidenceRecorder) RecordVendorEvidence(ctx context.Context, tenantID uuid.UUID, in vendorapp.EvidenceInput) (uuid.UUID, error) {
+	evType := domain.EvidenceTypeAttestation
+	if in.Content != nil {
+		evType = domain.EvidenceTypeDocument
+	}
+	ev, err := r.svc.Create(ctx, tenantID, evidence.CreateInput{
+		Title:        in.Title,
+		Type:         string(evType),
+		Description:  in.Description,
+		Source:       string(domain.EvidenceSourceVendor),
+		SourceDetail: in.SourceDetail,
+		Filename:     in.Filename,
+		Content:      in.Content,
+		Review:       string(domain.EvidenceReviewPending),
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return ev.ID, nil
+}
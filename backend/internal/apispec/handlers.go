@@ -0,0 +1,528 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package apispec
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"mime"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// handlerFacts is what a handler's body shows about its contract: the
+// parameters it reads, the body it parses and the responses it writes.
+type handlerFacts struct {
+	query      map[string]string // name → JSON type
+	pathFormat map[string]string // name → format (uuid) or type (integer)
+	body       types.Type
+	form       map[string]bool // multipart field → is a file
+	responses  map[string]*responseFacts
+}
+
+type responseFacts struct {
+	contentType string
+	schemas     []map[string]any
+}
+
+type handlerWalker struct {
+	prog    *program
+	schemas *schemaSet
+	module  string
+	facts   *handlerFacts
+	visited map[*ast.FuncDecl]bool
+}
+
+// analyzeHandler reads a handler's body, and the bodies of the same-package
+// helpers it hands the request context to (writeAppError and the like).
+func analyzeHandler(prog *program, schemas *schemaSet, module string, h *handlerRef) *handlerFacts {
+	w := &handlerWalker{prog: prog, schemas: schemas, module: module, visited: map[*ast.FuncDecl]bool{},
+		facts: &handlerFacts{query: map[string]string{}, pathFormat: map[string]string{}, form: map[string]bool{}, responses: map[string]*responseFacts{}}}
+	if h == nil {
+		return w.facts
+	}
+	body := (*ast.BlockStmt)(nil)
+	switch {
+	case h.lit != nil:
+		body = h.lit.Body
+	case h.decl != nil:
+		body = h.decl.Body
+		w.visited[h.decl] = true
+	}
+	if body != nil {
+		w.walk(h.pkg, body, 0)
+	}
+	return w.facts
+}
+
+func (w *handlerWalker) walk(pkg *sourcePackage, body ast.Node, depth int) {
+	contentType := detectContentType(pkg, body)
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && isFiberCtx(pkg.info.Types[sel.X].Type) {
+			w.ctxCall(pkg, call, sel, contentType)
+			return true
+		}
+		fn := funcOf(pkg, call.Fun)
+		if fn == nil || fn.Pkg() == nil {
+			return true
+		}
+		switch {
+		case fn.Pkg().Path() == "github.com/google/uuid" && fn.Name() == "Parse" && len(call.Args) == 1:
+			if name, ok := paramsArg(pkg, call.Args[0]); ok {
+				w.facts.pathFormat[name] = "uuid"
+			}
+		case fn.Pkg().Path() == "encoding/json" && fn.Name() == "Unmarshal" && len(call.Args) == 2:
+			if isCtxBody(pkg, call.Args[0]) {
+				w.setBody(pkg.info.Types[call.Args[1]].Type)
+			}
+		case depth < 3 && fn.Pkg().Path() == pkg.path && passesCtx(pkg, call):
+			sp, decl, err := w.prog.funcDecl(fn)
+			if err == nil && !w.visited[decl] {
+				w.visited[decl] = true
+				w.walk(sp, decl.Body, depth+1)
+			}
+		}
+		return true
+	})
+}
+
+func (w *handlerWalker) ctxCall(pkg *sourcePackage, call *ast.CallExpr, sel *ast.SelectorExpr, contentType string) {
+	arg := func(i int) (string, bool) {
+		if i >= len(call.Args) {
+			return "", false
+		}
+		return constString(pkg, call.Args[i])
+	}
+	switch sel.Sel.Name {
+	case "Query", "QueryInt", "QueryBool", "QueryFloat":
+		if name, ok := arg(0); ok {
+			typ := map[string]string{"Query": "string", "QueryInt": "integer", "QueryBool": "boolean", "QueryFloat": "number"}[sel.Sel.Name]
+			if cur, seen := w.facts.query[name]; !seen || cur == "string" {
+				w.facts.query[name] = typ
+			}
+		}
+	case "ParamsInt":
+		if name, ok := arg(0); ok {
+			w.facts.pathFormat[name] = "integer"
+		}
+	case "BodyParser":
+		if len(call.Args) == 1 {
+			w.setBody(pkg.info.Types[call.Args[0]].Type)
+		}
+	case "QueryParser":
+		if len(call.Args) == 1 {
+			w.queryStruct(pkg.info.Types[call.Args[0]].Type)
+		}
+	case "FormFile":
+		if name, ok := arg(0); ok {
+			w.facts.form[name] = true
+		}
+	case "FormValue":
+		if name, ok := arg(0); ok && !w.facts.form[name] {
+			w.facts.form[name] = false
+		}
+	case "JSON":
+		if len(call.Args) >= 1 {
+			status := statusOf(pkg, sel.X)
+			w.respond(status, "application/json", w.valueSchema(pkg, call.Args[0], status))
+		}
+	case "XML":
+		w.respond(statusOf(pkg, sel.X), "application/xml", map[string]any{})
+	case "SendStatus":
+		if s, ok := constInt(pkg, call.Args[0]); ok {
+			w.respond(s, "", nil)
+		}
+	case "Send", "SendString", "SendStream", "SendFile", "Download":
+		ct := contentType
+		if ct == "" {
+			ct = "application/octet-stream"
+			if sel.Sel.Name == "SendString" {
+				ct = "text/plain"
+			}
+		}
+		w.respond(statusOf(pkg, sel.X), ct, binarySchema(ct))
+	case "Redirect":
+		w.respond("302", "", nil)
+	}
+}
+
+func (w *handlerWalker) setBody(t types.Type) {
+	if t == nil || w.facts.body != nil {
+		return
+	}
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	w.facts.body = t
+}
+
+// queryStruct adds the fields of a QueryParser target, by their query tag.
+func (w *handlerWalker) queryStruct(t types.Type) {
+	if t == nil {
+		return
+	}
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		name, _, _ := strings.Cut(reflect.StructTag(st.Tag(i)).Get("query"), ",")
+		if name == "" || name == "-" || !f.Exported() {
+			continue
+		}
+		typ, _ := w.schemas.of(f.Type())["type"].(string)
+		if typ == "" || typ == "object" {
+			typ = "string"
+		}
+		w.facts.query[name] = typ
+	}
+}
+
+func (w *handlerWalker) respond(status, contentType string, schema map[string]any) {
+	r, ok := w.facts.responses[status]
+	if !ok {
+		r = &responseFacts{contentType: contentType}
+		w.facts.responses[status] = r
+	}
+	if schema == nil {
+		return
+	}
+	if r.contentType == "" {
+		r.contentType = contentType
+	}
+	for _, s := range r.schemas {
+		if reflect.DeepEqual(s, schema) {
+			return
+		}
+	}
+	r.schemas = append(r.schemas, schema)
+}
+
+// valueSchema is the schema of a value handed to c.JSON. A fiber.Map literal
+// is described key by key; errors share the Error component.
+func (w *handlerWalker) valueSchema(pkg *sourcePackage, e ast.Expr, status string) map[string]any {
+	if status == "default" || status >= "400" {
+		return map[string]any{"$ref": "#/components/schemas/Error"}
+	}
+	if lit, ok := e.(*ast.CompositeLit); ok {
+		if _, isMap := pkg.info.Types[lit].Type.Underlying().(*types.Map); isMap {
+			return w.mapLiteral(pkg, lit)
+		}
+	}
+	tv, ok := pkg.info.Types[e]
+	if !ok || tv.IsNil() {
+		return map[string]any{}
+	}
+	return w.schemas.of(tv.Type)
+}
+
+func (w *handlerWalker) mapLiteral(pkg *sourcePackage, lit *ast.CompositeLit) map[string]any {
+	props := map[string]any{}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := constString(pkg, kv.Key)
+		if !ok {
+			return map[string]any{"type": "object"}
+		}
+		if inner, ok := kv.Value.(*ast.CompositeLit); ok {
+			if t := pkg.info.Types[inner].Type; t != nil {
+				if _, isMap := t.Underlying().(*types.Map); isMap {
+					props[key] = w.mapLiteral(pkg, inner)
+					continue
+				}
+			}
+		}
+		tv, ok := pkg.info.Types[kv.Value]
+		if !ok || tv.IsNil() || tv.Type == nil {
+			props[key] = map[string]any{}
+			continue
+		}
+		props[key] = w.schemas.of(tv.Type)
+	}
+	out := map[string]any{"type": "object"}
+	if len(props) > 0 {
+		out["properties"] = props
+	}
+	return out
+}
+
+// statusOf reads the status a response is written with: c.Status(201).JSON.
+func statusOf(pkg *sourcePackage, recv ast.Expr) string {
+	call, ok := recv.(*ast.CallExpr)
+	if !ok {
+		return "200"
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "200"
+	}
+	if sel.Sel.Name != "Status" || len(call.Args) != 1 {
+		return statusOf(pkg, sel.X)
+	}
+	if s, ok := constInt(pkg, call.Args[0]); ok {
+		return s
+	}
+	return "default"
+}
+
+// detectContentType finds the content type a handler sets for a non-JSON
+// response: c.Set("Content-Type", …) or c.Type("pdf").
+func detectContentType(pkg *sourcePackage, body ast.Node) string {
+	ct := ""
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || ct != "" {
+			return ct == ""
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !isFiberCtx(pkg.info.Types[sel.X].Type) {
+			return true
+		}
+		switch sel.Sel.Name {
+		case "Set":
+			if len(call.Args) == 2 {
+				if h, ok := constString(pkg, call.Args[0]); ok && strings.EqualFold(h, "Content-Type") {
+					if v, ok := constString(pkg, call.Args[1]); ok {
+						ct = v
+					} else {
+						ct = "application/octet-stream"
+					}
+				}
+			}
+		case "Type":
+			if len(call.Args) >= 1 {
+				if ext, ok := constString(pkg, call.Args[0]); ok {
+					ct = mime.TypeByExtension("." + ext)
+				}
+			}
+		}
+		return true
+	})
+	if i := strings.Index(ct, ";"); i >= 0 {
+		ct = strings.TrimSpace(ct[:i])
+	}
+	return ct
+}
+
+func binarySchema(ct string) map[string]any {
+	switch {
+	case ct == "application/json":
+		return map[string]any{}
+	case strings.HasPrefix(ct, "text/"):
+		return map[string]any{"type": "string"}
+	}
+	return map[string]any{"type": "string", "contentMediaType": ct}
+}
+
+func paramsArg(pkg *sourcePackage, e ast.Expr) (string, bool) {
+	call, ok := e.(*ast.CallExpr)
+	if !ok || len(call.Args) < 1 {
+		return "", false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Params" || !isFiberCtx(pkg.info.Types[sel.X].Type) {
+		return "", false
+	}
+	return constString(pkg, call.Args[0])
+}
+
+func isCtxBody(pkg *sourcePackage, e ast.Expr) bool {
+	call, ok := e.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && (sel.Sel.Name == "Body" || sel.Sel.Name == "BodyRaw") && isFiberCtx(pkg.info.Types[sel.X].Type)
+}
+
+func passesCtx(pkg *sourcePackage, call *ast.CallExpr) bool {
+	for _, a := range call.Args {
+		if isFiberCtx(pkg.info.Types[a].Type) {
+			return true
+		}
+	}
+	return false
+}
+
+func funcOf(pkg *sourcePackage, e ast.Expr) *types.Func {
+	switch e := e.(type) {
+	case *ast.Ident:
+		fn, _ := pkg.info.Uses[e].(*types.Func)
+		return fn
+	case *ast.SelectorExpr:
+		if sel, ok := pkg.info.Selections[e]; ok {
+			fn, _ := sel.Obj().(*types.Func)
+			return fn
+		}
+		fn, _ := pkg.info.Uses[e.Sel].(*types.Func)
+		return fn
+	}
+	return nil
+}
+
+func constString(pkg *sourcePackage, e ast.Expr) (string, bool) {
+	tv, ok := pkg.info.Types[e]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+func constInt(pkg *sourcePackage, e ast.Expr) (string, bool) {
+	tv, ok := pkg.info.Types[e]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.Int {
+		return "", false
+	}
+	return tv.Value.ExactString(), true
+}
+
+var (
+	routeInComment = regexp.MustCompile(`^(?:(?:GET|POST|PUT|PATCH|DELETE)\s+/\S*\s*(?:,|·|and|&)?\s*)+`)
+	leadingDash    = regexp.MustCompile(`^[\s—–\-:·]+`)
+	routeInParens  = regexp.MustCompile(`\s*\((?:GET|POST|PUT|PATCH|DELETE)\s+/[^)]*\)`)
+	routeLine      = regexp.MustCompile(`^(?:GET|POST|PUT|PATCH|DELETE)\s+/\S*$`)
+)
+
+// describe derives a summary and description from a doc comment: swag-style
+// @Summary/@Description when present, else the prose after the function name
+// and any "GET /path" it repeats.
+func describe(doc, name string) (summary, description string) {
+	var lines, swagDesc []string
+	for _, line := range strings.Split(doc, "\n") {
+		t := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(t, "@Summary"):
+			summary = strings.TrimSpace(strings.TrimPrefix(t, "@Summary"))
+		case strings.HasPrefix(t, "@Description"):
+			swagDesc = append(swagDesc, strings.TrimSpace(strings.TrimPrefix(t, "@Description")))
+		case strings.HasPrefix(t, "@"):
+		case routeLine.MatchString(t):
+			// "GET /api/v1/dashboard/complete" on a line of its own.
+		default:
+			lines = append(lines, line)
+		}
+	}
+	paras := strings.Split(strings.TrimSpace(strings.Join(lines, "\n")), "\n\n")
+	for i, p := range paras {
+		paras[i] = strings.Join(strings.Fields(p), " ")
+	}
+	text := strings.TrimSpace(strings.Join(paras, "\n\n"))
+	if name != "" {
+		if rest, ok := strings.CutPrefix(text, name); ok && (rest == "" || !isIdentRune(rest[0])) {
+			text = strings.TrimSpace(rest)
+		}
+	}
+	text = strings.TrimSpace(strings.TrimPrefix(text, "godoc"))
+	text = routeInComment.ReplaceAllString(text, "")
+	text = leadingDash.ReplaceAllString(text, "")
+	for _, prefix := range []string{"handles ", "is the handler for ", "serves ", "is "} {
+		text = strings.TrimPrefix(text, prefix)
+	}
+	text = routeInParens.ReplaceAllString(text, "")
+	text = routeInComment.ReplaceAllString(text, "")
+	text = leadingDash.ReplaceAllString(text, "")
+	text = upperFirst(strings.TrimSpace(text))
+	if len(swagDesc) > 0 {
+		description = strings.Join(swagDesc, " ")
+	} else if text != "" {
+		description = text
+	}
+	if summary == "" && text != "" {
+		summary = firstSentence(text)
+	}
+	if description == summary {
+		description = ""
+	}
+	return summary, description
+}
+
+func firstSentence(s string) string {
+	s = strings.SplitN(s, "\n\n", 2)[0]
+	s = strings.Join(strings.Fields(s), " ")
+	for i := 0; i+1 < len(s); i++ {
+		if s[i] == '.' && s[i+1] == ' ' && !strings.HasSuffix(s[:i], "e.g") && !strings.HasSuffix(s[:i], "i.e") {
+			return s[:i]
+		}
+	}
+	return strings.TrimSuffix(s, ".")
+}
+
+// humanize turns an identifier into words: GetRiskFinancial → Get risk financial.
+func humanize(id string) string {
+	var words []string
+	var cur []rune
+	rs := []rune(id)
+	for i, r := range rs {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(rs[i-1]) || (i+1 < len(rs) && unicode.IsLower(rs[i+1]))) {
+			words = append(words, string(cur))
+			cur = nil
+		}
+		cur = append(cur, r)
+	}
+	words = append(words, string(cur))
+	for i := 1; i < len(words); i++ {
+		if !isAcronym(words[i]) {
+			words[i] = strings.ToLower(words[i])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func isAcronym(w string) bool {
+	return len(w) > 1 && strings.ToUpper(w) == w
+}
+
+func isIdentRune(b byte) bool {
+	return b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func statusText(status string) string {
+	if status == "default" {
+		return "Error"
+	}
+	var code int
+	for _, c := range status {
+		code = code*10 + int(c-'0')
+	}
+	if t := http.StatusText(code); t != "" {
+		return t
+	}
+	return "Status " + status
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
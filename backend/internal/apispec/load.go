@@ -0,0 +1,185 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package apispec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// program is the server's source, type-checked. Dependencies come from the
+// compiler's export data (`go list -export`), so only the packages whose
+// bodies the generator reads — the server's main package and the handler
+// packages — are parsed and checked from source.
+type program struct {
+	fset    *token.FileSet
+	imp     types.ImporterFrom
+	listed  map[string]*listedPackage
+	checked map[string]*sourcePackage
+}
+
+type listedPackage struct {
+	ImportPath string
+	Name       string
+	Dir        string
+	Export     string
+	GoFiles    []string
+	Module     *struct{ Path string }
+}
+
+// sourcePackage is a package checked from source: its syntax and type info.
+type sourcePackage struct {
+	path  string
+	types *types.Package
+	files []*ast.File
+	info  *types.Info
+}
+
+// loadProgram lists mainPkg's dependencies from the module rooted at dir and
+// type-checks mainPkg from source.
+func loadProgram(dir, mainPkg string) (*program, *sourcePackage, error) {
+	cmd := exec.Command("go", "list", "-deps", "-export", "-json=ImportPath,Name,Dir,Export,GoFiles,Module", mainPkg)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, nil, fmt.Errorf("go list %s: %v: %s", mainPkg, err, strings.TrimSpace(stderr.String()))
+	}
+	p := &program{fset: token.NewFileSet(), listed: map[string]*listedPackage{}, checked: map[string]*sourcePackage{}}
+	var main *listedPackage
+	dec := json.NewDecoder(&stdout)
+	for {
+		var lp listedPackage
+		if err := dec.Decode(&lp); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, err
+		}
+		p.listed[lp.ImportPath] = &lp
+		if lp.Name == "main" {
+			main = &lp
+		}
+	}
+	if main == nil {
+		return nil, nil, fmt.Errorf("%s is not a main package", mainPkg)
+	}
+	p.imp = importer.ForCompiler(p.fset, "gc", func(path string) (io.ReadCloser, error) {
+		lp, ok := p.listed[path]
+		if !ok || lp.Export == "" {
+			return nil, fmt.Errorf("no export data for %s", path)
+		}
+		return os.Open(lp.Export)
+	}).(types.ImporterFrom)
+	pkg, err := p.source(main.ImportPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, pkg, nil
+}
+
+// source returns a package parsed and type-checked from source, once.
+func (p *program) source(path string) (*sourcePackage, error) {
+	if sp, ok := p.checked[path]; ok {
+		return sp, nil
+	}
+	lp, ok := p.listed[path]
+	if !ok {
+		return nil, fmt.Errorf("package %s is not a dependency of the server", path)
+	}
+	sp := &sourcePackage{path: path, info: &types.Info{
+		Types:      map[ast.Expr]types.TypeAndValue{},
+		Defs:       map[*ast.Ident]types.Object{},
+		Uses:       map[*ast.Ident]types.Object{},
+		Selections: map[*ast.SelectorExpr]*types.Selection{},
+	}}
+	for _, name := range lp.GoFiles {
+		f, err := parser.ParseFile(p.fset, filepath.Join(lp.Dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		sp.files = append(sp.files, f)
+	}
+	conf := types.Config{Importer: p.imp}
+	checkPath := path
+	if lp.Name == "main" {
+		checkPath = "main"
+	}
+	tp, err := conf.Check(checkPath, p.fset, sp.files, sp.info)
+	if err != nil {
+		return nil, fmt.Errorf("type-checking %s: %w", path, err)
+	}
+	sp.types = tp
+	p.checked[path] = sp
+	return sp, nil
+}
+
+// inModule reports whether a package belongs to the module being documented,
+// as opposed to the standard library or a dependency.
+func (p *program) inModule(path, module string) bool {
+	return path == module || strings.HasPrefix(path, module+"/")
+}
+
+// funcDecl finds the declaration of fn in its package's source.
+func (p *program) funcDecl(fn *types.Func) (*sourcePackage, *ast.FuncDecl, error) {
+	if fn.Pkg() == nil {
+		return nil, nil, fmt.Errorf("%s has no package", fn.Name())
+	}
+	sp, err := p.source(fn.Pkg().Path())
+	if err != nil {
+		return nil, nil, err
+	}
+	recv := ""
+	if sig, ok := fn.Type().(*types.Signature); ok && sig.Recv() != nil {
+		recv = namedOf(sig.Recv().Type())
+	}
+	for _, f := range sp.files {
+		for _, d := range f.Decls {
+			fd, ok := d.(*ast.FuncDecl)
+			if !ok || fd.Name.Name != fn.Name() || fd.Body == nil {
+				continue
+			}
+			if recvName(fd) == recv {
+				return sp, fd, nil
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("no source for %s", fn.FullName())
+}
+
+func namedOf(t types.Type) string {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if n, ok := t.(*types.Named); ok {
+		return n.Obj().Name()
+	}
+	return ""
+}
+
+func recvName(fd *ast.FuncDecl) string {
+	if fd.Recv == nil || len(fd.Recv.List) == 0 {
+		return ""
+	}
+	t := fd.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	if id, ok := t.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
@@ -0,0 +1,460 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package apispec
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// Route is one registration in the server's route table, with what its
+// middleware requires.
+type Route struct {
+	Method string
+	Path   string // OpenAPI form: /api/v1/risks/{id}
+	Pos    token.Position
+
+	Authenticated bool
+	Permissions   []string // any of them (middleware.RequirePermission)
+	Roles         []string // any of them (middleware.RequireRole)
+	Features      []string // plan features (middleware.RequireFeature)
+	Capacities    []string // plan limits checked on create (middleware.RequireCapacity)
+
+	handler *handlerRef
+}
+
+// handlerRef is the code that answers a route: a handler method or function,
+// or an inline closure.
+type handlerRef struct {
+	name string // e.g. VulnerabilityHandler.List; empty for closures
+	typ  string // receiver type, e.g. VulnerabilityHandler
+	fn   string // function or method name
+	pkg  *sourcePackage
+	decl *ast.FuncDecl // nil for closures
+	lit  *ast.FuncLit  // closures
+}
+
+const fiberPath = "github.com/gofiber/fiber/v2"
+
+var routeMethods = map[string]string{
+	"Get": "get", "Post": "post", "Put": "put", "Patch": "patch", "Delete": "delete", "Head": "head", "Options": "options",
+}
+
+// router is what a router variable stands for: the prefix its routes get and
+// the middleware its Group() call attached.
+type router struct {
+	prefix string
+	mw     []ast.Expr
+}
+
+// authGate is a Use() of an authentication middleware: routes registered
+// after it under its prefix are authenticated, whichever router variable they
+// were registered on (fiber's Use returns the same group).
+type authGate struct {
+	prefix string
+	pos    token.Pos
+}
+
+type routeScanner struct {
+	prog    *program
+	pkg     *sourcePackage
+	mwPkg   string
+	routers map[types.Object]*router
+	defs    map[types.Object]ast.Expr
+	gates   []authGate
+	routes  []Route
+	skipped []string
+}
+
+// extractRoutes walks the server's main package in source order and returns
+// every route it registers.
+func extractRoutes(prog *program, pkg *sourcePackage, module string) ([]Route, []string) {
+	s := &routeScanner{
+		prog: prog, pkg: pkg, mwPkg: module + "/internal/middleware",
+		routers: map[types.Object]*router{}, defs: map[types.Object]ast.Expr{},
+	}
+	files := append([]*ast.File(nil), pkg.files...)
+	sort.Slice(files, func(i, j int) bool {
+		return prog.fset.File(files[i].Pos()).Name() < prog.fset.File(files[j].Pos()).Name()
+	})
+	for _, f := range files {
+		ast.Inspect(f, s.visit)
+	}
+	return s.routes, s.skipped
+}
+
+func (s *routeScanner) visit(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		if len(n.Lhs) != len(n.Rhs) {
+			return true
+		}
+		for i, lhs := range n.Lhs {
+			id, ok := lhs.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			obj := s.pkg.info.Defs[id]
+			if obj == nil {
+				obj = s.pkg.info.Uses[id]
+			}
+			if obj == nil {
+				continue
+			}
+			s.defs[obj] = n.Rhs[i]
+			if r := s.routerOf(n.Rhs[i]); r != nil {
+				s.routers[obj] = r
+			}
+		}
+	case *ast.ValueSpec:
+		for i, id := range n.Names {
+			if i < len(n.Values) {
+				if obj := s.pkg.info.Defs[id]; obj != nil {
+					s.defs[obj] = n.Values[i]
+				}
+			}
+		}
+	case *ast.CallExpr:
+		s.call(n)
+	}
+	return true
+}
+
+// routerOf evaluates an expression to the router it denotes, recording an
+// authentication gate when the expression is a Use() of one.
+func (s *routeScanner) routerOf(e ast.Expr) *router {
+	switch e := e.(type) {
+	case *ast.Ident:
+		if obj := s.pkg.info.Uses[e]; obj != nil {
+			return s.routers[obj]
+		}
+		if obj := s.pkg.info.Defs[e]; obj != nil {
+			return s.routers[obj]
+		}
+	case *ast.ParenExpr:
+		return s.routerOf(e.X)
+	case *ast.CallExpr:
+		fn := s.callee(e)
+		if fn == nil {
+			return nil
+		}
+		if fn.Pkg() != nil && fn.Pkg().Path() == fiberPath && fn.Name() == "New" {
+			return &router{}
+		}
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok || !s.isRouter(sel.X) {
+			return nil
+		}
+		base := s.routerOf(sel.X)
+		if base == nil {
+			return nil
+		}
+		switch fn.Name() {
+		case "Group":
+			if len(e.Args) == 0 {
+				return nil
+			}
+			p, ok := s.stringValue(e.Args[0])
+			if !ok {
+				return nil
+			}
+			return &router{prefix: joinPath(base.prefix, p), mw: append(append([]ast.Expr(nil), base.mw...), e.Args[1:]...)}
+		case "Use":
+			for _, a := range e.Args {
+				if s.isAuth(a) {
+					s.gates = append(s.gates, authGate{prefix: base.prefix, pos: e.Pos()})
+				}
+			}
+			return base
+		}
+	}
+	return nil
+}
+
+func (s *routeScanner) isRouter(e ast.Expr) bool {
+	tv, ok := s.pkg.info.Types[e]
+	if !ok {
+		return false
+	}
+	t := tv.Type
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	n, ok := types.Unalias(t).(*types.Named)
+	if !ok || n.Obj().Pkg() == nil || n.Obj().Pkg().Path() != fiberPath {
+		return false
+	}
+	switch n.Obj().Name() {
+	case "App", "Router", "Group":
+		return true
+	}
+	return false
+}
+
+// call records a route registration, or a bare Use() that gates a prefix.
+func (s *routeScanner) call(c *ast.CallExpr) {
+	sel, ok := c.Fun.(*ast.SelectorExpr)
+	if !ok || !s.isRouter(sel.X) {
+		return
+	}
+	if sel.Sel.Name == "Use" {
+		s.routerOf(c)
+		return
+	}
+	method, ok := routeMethods[sel.Sel.Name]
+	if !ok || len(c.Args) < 2 {
+		return
+	}
+	base := s.routerOf(sel.X)
+	pos := s.prog.fset.Position(c.Pos())
+	if base == nil {
+		s.skipped = append(s.skipped, pos.String()+": route on an untracked router")
+		return
+	}
+	p, ok := s.stringValue(c.Args[0])
+	if !ok {
+		s.skipped = append(s.skipped, pos.String()+": route path is not a constant")
+		return
+	}
+	path := joinPath(base.prefix, p)
+	r := Route{Method: method, Path: openAPIPath(path), Pos: pos}
+	mw := append(append([]ast.Expr(nil), base.mw...), c.Args[1:len(c.Args)-1]...)
+	for _, m := range mw {
+		s.annotate(&r, m)
+	}
+	for _, g := range s.gates {
+		if g.pos < c.Pos() && underPrefix(path, g.prefix) {
+			r.Authenticated = true
+		}
+	}
+	r.handler = s.handlerOf(c.Args[len(c.Args)-1], 0)
+	s.routes = append(s.routes, r)
+}
+
+// annotate reads what a middleware expression requires.
+func (s *routeScanner) annotate(r *Route, e ast.Expr) {
+	call, ok := s.resolve(e).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	fn := s.callee(call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != s.mwPkg {
+		return
+	}
+	switch fn.Name() {
+	case "Protected", "OptionalAuth", "MFATokenMiddleware":
+		r.Authenticated = true
+	case "RequirePermission":
+		r.Permissions = appendUnique(r.Permissions, s.stringArgs(call.Args)...)
+	case "RequireRole":
+		r.Roles = appendUnique(r.Roles, s.stringArgs(call.Args)...)
+	case "RequireFeature":
+		r.Features = appendUnique(r.Features, s.stringArgs(call.Args[1:])...)
+	case "RequireCapacity":
+		r.Capacities = appendUnique(r.Capacities, s.stringArgs(call.Args[1:])...)
+	}
+}
+
+func (s *routeScanner) isAuth(e ast.Expr) bool {
+	call, ok := s.resolve(e).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn := s.callee(call)
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == s.mwPkg && fn.Name() == "Protected"
+}
+
+// handlerOf resolves a route's last argument to the code that answers it. A
+// wrapper call (caching, snapshots) is looked through to the handler it wraps.
+func (s *routeScanner) handlerOf(e ast.Expr, depth int) *handlerRef {
+	if depth > 4 {
+		return nil
+	}
+	switch e := e.(type) {
+	case *ast.FuncLit:
+		// func(c *fiber.Ctx) error { return h.Stream(c) } documents h.Stream.
+		if len(e.Body.List) == 1 {
+			if ret, ok := e.Body.List[0].(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+				if call, ok := ret.Results[0].(*ast.CallExpr); ok && len(call.Args) == 1 {
+					if fn := s.callee(call); fn != nil && isHandlerType(fn.Type()) {
+						if h := s.declared(fn); h != nil {
+							return h
+						}
+					}
+				}
+			}
+		}
+		return &handlerRef{pkg: s.pkg, lit: e}
+	case *ast.ParenExpr:
+		return s.handlerOf(e.X, depth+1)
+	case *ast.Ident, *ast.SelectorExpr:
+		if fn := s.funcValue(e); fn != nil {
+			return s.declared(fn)
+		}
+		if def := s.resolve(e); def != e {
+			return s.handlerOf(def, depth+1)
+		}
+	case *ast.CallExpr:
+		for i := len(e.Args) - 1; i >= 0; i-- {
+			if isHandlerType(s.pkg.info.Types[e.Args[i]].Type) {
+				if h := s.handlerOf(e.Args[i], depth+1); h != nil {
+					return h
+				}
+			}
+		}
+		// A factory returning a closure: document the closure.
+		if fn := s.callee(e); fn != nil {
+			if h := s.declared(fn); h != nil && h.decl != nil {
+				if lit := returnedClosure(h.decl); lit != nil {
+					return &handlerRef{name: h.name, typ: h.typ, fn: h.fn, pkg: h.pkg, decl: h.decl, lit: lit}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *routeScanner) declared(fn *types.Func) *handlerRef {
+	sp, decl, err := s.prog.funcDecl(fn)
+	if err != nil {
+		return nil
+	}
+	h := &handlerRef{fn: fn.Name(), name: fn.Name(), pkg: sp, decl: decl}
+	if t := recvName(decl); t != "" {
+		h.typ = t
+		h.name = t + "." + fn.Name()
+	}
+	return h
+}
+
+// funcValue returns the function or method an expression names, if any.
+func (s *routeScanner) funcValue(e ast.Expr) *types.Func {
+	switch e := e.(type) {
+	case *ast.Ident:
+		fn, _ := s.pkg.info.Uses[e].(*types.Func)
+		return fn
+	case *ast.SelectorExpr:
+		if sel, ok := s.pkg.info.Selections[e]; ok {
+			fn, _ := sel.Obj().(*types.Func)
+			return fn
+		}
+		fn, _ := s.pkg.info.Uses[e.Sel].(*types.Func)
+		return fn
+	}
+	return nil
+}
+
+func (s *routeScanner) callee(c *ast.CallExpr) *types.Func {
+	return s.funcValue(c.Fun)
+}
+
+// resolve follows a local variable to the expression it was assigned.
+func (s *routeScanner) resolve(e ast.Expr) ast.Expr {
+	for i := 0; i < 8; i++ {
+		id, ok := e.(*ast.Ident)
+		if !ok {
+			return e
+		}
+		def, ok := s.defs[s.pkg.info.Uses[id]]
+		if !ok {
+			return e
+		}
+		e = def
+	}
+	return e
+}
+
+func (s *routeScanner) stringValue(e ast.Expr) (string, bool) {
+	tv, ok := s.pkg.info.Types[e]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+func (s *routeScanner) stringArgs(args []ast.Expr) []string {
+	var out []string
+	for _, a := range args {
+		if v, ok := s.stringValue(a); ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// isHandlerType reports whether t is func(*fiber.Ctx) error (fiber.Handler).
+func isHandlerType(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	sig, ok := t.Underlying().(*types.Signature)
+	return ok && sig.Params().Len() == 1 && sig.Results().Len() == 1 && isFiberCtx(sig.Params().At(0).Type())
+}
+
+func isFiberCtx(t types.Type) bool {
+	p, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	n, ok := p.Elem().(*types.Named)
+	return ok && n.Obj().Pkg() != nil && n.Obj().Pkg().Path() == fiberPath && n.Obj().Name() == "Ctx"
+}
+
+func returnedClosure(fd *ast.FuncDecl) *ast.FuncLit {
+	var lit *ast.FuncLit
+	ast.Inspect(fd.Body, func(n ast.Node) bool {
+		if ret, ok := n.(*ast.ReturnStmt); ok && lit == nil && len(ret.Results) == 1 {
+			lit, _ = ret.Results[0].(*ast.FuncLit)
+		}
+		return lit == nil
+	})
+	return lit
+}
+
+func joinPath(prefix, p string) string {
+	if p == "" || p == "/" {
+		if prefix == "" {
+			return "/"
+		}
+		return prefix
+	}
+	return strings.TrimRight(prefix, "/") + "/" + strings.TrimLeft(p, "/")
+}
+
+func underPrefix(path, prefix string) bool {
+	return prefix == "" || path == prefix || strings.HasPrefix(path, strings.TrimRight(prefix, "/")+"/")
+}
+
+// openAPIPath turns fiber's :param (and :param?) segments into {param}.
+func openAPIPath(p string) string {
+	segs := strings.Split(p, "/")
+	for i, seg := range segs {
+		if strings.HasPrefix(seg, ":") {
+			segs[i] = "{" + strings.TrimSuffix(seg[1:], "?") + "}"
+		}
+	}
+	return strings.Join(segs, "/")
+}
+
+func appendUnique(list []string, vals ...string) []string {
+	for _, v := range vals {
+		found := false
+		for _, l := range list {
+			if l == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
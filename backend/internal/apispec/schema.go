@@ -0,0 +1,484 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package apispec
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// schemaSet turns Go types into JSON Schema (OpenAPI 3.1). Named types of the
+// documented module become components; everything else is inlined.
+type schemaSet struct {
+	prog   *program
+	module string
+
+	components map[string]*component // by qualified name (pkgpath.Name)
+	docs       map[string]*pkgDocs   // by package path
+}
+
+type component struct {
+	key    string
+	pkg    string // package name, to disambiguate
+	name   string
+	schema map[string]any
+}
+
+// refPrefix marks a reference by qualified name; finalRefs rewrites it once
+// every component has its final, unique name.
+const refPrefix = "#ref:"
+
+func newSchemaSet(prog *program, module string) *schemaSet {
+	return &schemaSet{prog: prog, module: module, components: map[string]*component{}, docs: map[string]*pkgDocs{}}
+}
+
+// of returns the schema for a Go type.
+func (s *schemaSet) of(t types.Type) map[string]any {
+	t = types.Unalias(t)
+	switch t := t.(type) {
+	case *types.Pointer:
+		return s.of(t.Elem())
+	case *types.Named:
+		return s.named(t)
+	case *types.Basic:
+		return basicSchema(t)
+	case *types.Slice:
+		if b, ok := t.Elem().Underlying().(*types.Basic); ok && b.Kind() == types.Byte {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": s.of(t.Elem())}
+	case *types.Array:
+		return map[string]any{"type": "array", "items": s.of(t.Elem())}
+	case *types.Map:
+		out := map[string]any{"type": "object"}
+		if elem := s.of(t.Elem()); len(elem) > 0 {
+			out["additionalProperties"] = elem
+		}
+		return out
+	case *types.Struct:
+		return s.structSchema(t, nil)
+	}
+	// Interfaces, funcs, channels: any JSON value.
+	return map[string]any{}
+}
+
+// wellKnown are types from outside the module with a fixed JSON form.
+var wellKnown = map[string]map[string]any{
+	"time.Time":                             {"type": "string", "format": "date-time"},
+	"time.Duration":                         {"type": "integer", "description": "nanoseconds"},
+	"github.com/google/uuid.UUID":           {"type": "string", "format": "uuid"},
+	"github.com/google/uuid.NullUUID":       {"type": []any{"string", "null"}, "format": "uuid"},
+	"encoding/json.RawMessage":              {},
+	"encoding/json.Number":                  {"type": "number"},
+	"gorm.io/gorm.DeletedAt":                {"type": []any{"string", "null"}, "format": "date-time"},
+	"gorm.io/datatypes.JSON":                {},
+	"gorm.io/datatypes.JSONMap":             {"type": "object"},
+	"database/sql.NullString":               {"type": []any{"string", "null"}},
+	"database/sql.NullTime":                 {"type": []any{"string", "null"}, "format": "date-time"},
+	"database/sql.NullInt64":                {"type": []any{"integer", "null"}},
+	"database/sql.NullFloat64":              {"type": []any{"number", "null"}},
+	"database/sql.NullBool":                 {"type": []any{"boolean", "null"}},
+	"github.com/lib/pq.StringArray":         {"type": "array", "items": map[string]any{"type": "string"}},
+	"github.com/gofiber/fiber/v2.Map":       {"type": "object"},
+	"github.com/shopspring/decimal.Decimal": {"type": "string", "format": "decimal"},
+}
+
+func (s *schemaSet) named(t *types.Named) map[string]any {
+	obj := t.Obj()
+	if obj.Pkg() == nil { // error, comparable
+		return map[string]any{}
+	}
+	key := obj.Pkg().Path() + "." + obj.Name()
+	if wk, ok := wellKnown[key]; ok {
+		return copyMap(wk)
+	}
+	if hasMethod(t, "MarshalJSON") {
+		if b, ok := t.Underlying().(*types.Basic); ok {
+			return basicSchema(b)
+		}
+		return map[string]any{}
+	}
+	if hasMethod(t, "MarshalText") {
+		return map[string]any{"type": "string"}
+	}
+	if !s.prog.inModule(obj.Pkg().Path(), s.module) {
+		return s.of(t.Underlying())
+	}
+	if t.TypeArgs().Len() > 0 {
+		key = types.TypeString(t, nil)
+	}
+	if _, ok := s.components[key]; !ok {
+		c := &component{key: key, pkg: obj.Pkg().Name(), name: obj.Name()}
+		if t.TypeArgs().Len() > 0 {
+			for i := 0; i < t.TypeArgs().Len(); i++ {
+				c.name += exportedName(typeArgName(t.TypeArgs().At(i)))
+			}
+		}
+		s.components[key] = c // before recursing: a type may refer to itself
+		c.schema = s.definition(t)
+	}
+	return map[string]any{"$ref": refPrefix + key}
+}
+
+// definition is a module type's component schema: its fields (or enum
+// values) with their doc comments.
+func (s *schemaSet) definition(t *types.Named) map[string]any {
+	docs := s.docsFor(t.Obj().Pkg().Path())
+	var out map[string]any
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		out = s.structSchema(u, docs.fields[t.Obj().Name()])
+	case *types.Basic:
+		out = basicSchema(u)
+		if enum := enumValues(t); len(enum) > 0 {
+			out["enum"] = enum
+		}
+	default:
+		out = s.of(u)
+	}
+	if d := docs.types[t.Obj().Name()]; d != "" {
+		out["description"] = d
+	}
+	return out
+}
+
+// structSchema lists a struct's JSON fields, flattening embedded structs the
+// way encoding/json does.
+func (s *schemaSet) structSchema(st *types.Struct, fieldDocs map[string]string) map[string]any {
+	props := map[string]any{}
+	var required []string
+	s.fields(st, fieldDocs, props, &required, 0)
+	out := map[string]any{"type": "object"}
+	if len(props) > 0 {
+		out["properties"] = props
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		out["required"] = toAny(required)
+	}
+	return out
+}
+
+func (s *schemaSet) fields(st *types.Struct, fieldDocs map[string]string, props map[string]any, required *[]string, depth int) {
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i)).Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Embedded() && name == "" && depth < 4 {
+			et := types.Unalias(f.Type())
+			if p, ok := et.(*types.Pointer); ok {
+				et = p.Elem()
+			}
+			if inner, ok := et.Underlying().(*types.Struct); ok {
+				var innerDocs map[string]string
+				if n, ok := et.(*types.Named); ok && n.Obj().Pkg() != nil {
+					innerDocs = s.docsFor(n.Obj().Pkg().Path()).fields[n.Obj().Name()]
+				}
+				s.fields(inner, innerDocs, props, required, depth+1)
+				continue
+			}
+		}
+		if !f.Exported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name()
+		}
+		if _, dup := props[name]; dup {
+			continue
+		}
+		sch := s.of(f.Type())
+		if strings.Contains(opts, "string") {
+			sch = map[string]any{"type": "string"}
+		}
+		_, isPtr := types.Unalias(f.Type()).(*types.Pointer)
+		omitempty := strings.Contains(opts, "omitempty") || strings.Contains(opts, "omitzero")
+		if isPtr && !omitempty {
+			sch = nullable(sch)
+		}
+		if d := fieldDocs[f.Name()]; d != "" {
+			sch = withDescription(sch, d)
+		}
+		props[name] = sch
+		if !omitempty && !isPtr {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func basicSchema(b *types.Basic) map[string]any {
+	switch {
+	case b.Info()&types.IsBoolean != 0:
+		return map[string]any{"type": "boolean"}
+	case b.Info()&types.IsInteger != 0:
+		return map[string]any{"type": "integer"}
+	case b.Info()&types.IsFloat != 0:
+		return map[string]any{"type": "number"}
+	case b.Info()&types.IsString != 0:
+		return map[string]any{"type": "string"}
+	}
+	return map[string]any{}
+}
+
+// enumValues are the constants a package declares of a named basic type.
+func enumValues(t *types.Named) []any {
+	scope := t.Obj().Pkg().Scope()
+	var strs []string
+	var nums []float64
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), t) {
+			continue
+		}
+		switch c.Val().Kind() {
+		case constant.String:
+			strs = append(strs, constant.StringVal(c.Val()))
+		case constant.Int, constant.Float:
+			f, _ := constant.Float64Val(c.Val())
+			nums = append(nums, f)
+		}
+	}
+	var out []any
+	sort.Strings(strs)
+	for _, v := range uniqueStrings(strs) {
+		out = append(out, v)
+	}
+	sort.Float64s(nums)
+	for _, v := range nums {
+		if v == float64(int64(v)) {
+			out = append(out, int64(v))
+		} else {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func hasMethod(t types.Type, name string) bool {
+	ms := types.NewMethodSet(types.NewPointer(t))
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Obj().Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// nullable allows null alongside a schema.
+func nullable(s map[string]any) map[string]any {
+	if _, ok := s["$ref"]; ok {
+		return map[string]any{"anyOf": []any{s, map[string]any{"type": "null"}}}
+	}
+	switch t := s["type"].(type) {
+	case string:
+		out := copyMap(s)
+		out["type"] = []any{t, "null"}
+		return out
+	}
+	return s
+}
+
+// withDescription adds a description. OpenAPI 3.1 allows it beside a $ref.
+func withDescription(s map[string]any, d string) map[string]any {
+	out := copyMap(s)
+	out["description"] = d
+	return out
+}
+
+// finalRefs picks each component's name — its Go name, qualified by its
+// package when two packages use the same one — and rewrites references.
+func (s *schemaSet) finalRefs(doc any) map[string]any {
+	// Components are named by their exported Go name; types sharing one are
+	// prefixed with their package, and numbered if that is not enough.
+	// "Error" is the shared error component.
+	byName := map[string][]*component{}
+	for _, c := range s.components {
+		byName[exportedName(c.name)] = append(byName[exportedName(c.name)], c)
+	}
+	names := map[string]string{}
+	taken := map[string]bool{"Error": true}
+	for _, name := range sortedKeys(byName) {
+		if cs := byName[name]; len(cs) == 1 && !taken[name] {
+			names[cs[0].key] = name
+			taken[name] = true
+		}
+	}
+	for _, name := range sortedKeys(byName) {
+		cs := byName[name]
+		if names[cs[0].key] != "" {
+			continue
+		}
+		sort.Slice(cs, func(i, j int) bool { return cs[i].key < cs[j].key })
+		for _, c := range cs {
+			n := exportedName(c.pkg) + name
+			for i := 2; taken[n]; i++ {
+				n = exportedName(c.pkg) + name + itoa(i)
+			}
+			names[c.key] = n
+			taken[n] = true
+		}
+	}
+	var rewrite func(v any) any
+	rewrite = func(v any) any {
+		switch v := v.(type) {
+		case map[string]any:
+			for k, e := range v {
+				if k == "$ref" {
+					if r, ok := e.(string); ok && strings.HasPrefix(r, refPrefix) {
+						v[k] = "#/components/schemas/" + names[strings.TrimPrefix(r, refPrefix)]
+						continue
+					}
+				}
+				v[k] = rewrite(e)
+			}
+		case []any:
+			for i, e := range v {
+				v[i] = rewrite(e)
+			}
+		}
+		return v
+	}
+	rewrite(doc)
+	out := map[string]any{}
+	for key, c := range s.components {
+		out[names[key]] = rewrite(c.schema)
+	}
+	return out
+}
+
+// pkgDocs are the doc comments of a package's types and struct fields, read
+// from its source.
+type pkgDocs struct {
+	types  map[string]string
+	fields map[string]map[string]string
+}
+
+func (s *schemaSet) docsFor(path string) *pkgDocs {
+	if d, ok := s.docs[path]; ok {
+		return d
+	}
+	d := &pkgDocs{types: map[string]string{}, fields: map[string]map[string]string{}}
+	s.docs[path] = d
+	lp, ok := s.prog.listed[path]
+	if !ok {
+		return d
+	}
+	fset := token.NewFileSet()
+	for _, name := range lp.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(lp.Dir, name), nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				doc := ts.Doc
+				if doc == nil && len(gd.Specs) == 1 {
+					doc = gd.Doc
+				}
+				if text := commentText(doc); text != "" {
+					d.types[ts.Name.Name] = text
+				}
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				fd := map[string]string{}
+				for _, field := range st.Fields.List {
+					text := commentText(field.Doc)
+					if text == "" {
+						text = commentText(field.Comment)
+					}
+					for _, n := range field.Names {
+						if text != "" {
+							fd[n.Name] = text
+						}
+					}
+				}
+				d.fields[ts.Name.Name] = fd
+			}
+		}
+	}
+	return d
+}
+
+// commentText is a doc comment as one paragraph-preserving string.
+func commentText(cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+	paras := strings.Split(strings.TrimSpace(cg.Text()), "\n\n")
+	for i, p := range paras {
+		paras[i] = strings.Join(strings.Fields(p), " ")
+	}
+	return strings.TrimSpace(strings.Join(paras, "\n\n"))
+}
+
+func typeArgName(t types.Type) string {
+	switch t := types.Unalias(t).(type) {
+	case *types.Named:
+		return t.Obj().Name()
+	case *types.Pointer:
+		return typeArgName(t.Elem())
+	case *types.Slice:
+		return typeArgName(t.Elem()) + "List"
+	}
+	return t.String()
+}
+
+// exportedName upper-cases the first letter.
+func exportedName(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func copyMap(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+func toAny(ss []string) []any {
+	out := make([]any, len(ss))
+	for i, s := range ss {
+		out[i] = s
+	}
+	return out
+}
+
+func uniqueStrings(ss []string) []string {
+	var out []string
+	for i, s := range ss {
+		if i == 0 || s != ss[i-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func itoa(i int) string { return strconv.Itoa(i) }
@@ -0,0 +1,560 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package apispec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GenerateClient writes the typed Go client for a specification: a struct or
+// named type per component, and a Client method per operation. The hand-written
+// half of the package (Client, do, Form, APIError) lives in pkg/apiclient.
+func GenerateClient(spec []byte, pkg string) ([]byte, error) {
+	var doc struct {
+		Paths      map[string]map[string]*specOp `json:"paths"`
+		Components struct {
+			Schemas map[string]*specSchema `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, err
+	}
+	g := &clientGen{schemas: doc.Components.Schemas, used: map[string]bool{}, structs: map[string]bool{}}
+	for name, s := range doc.Components.Schemas {
+		g.used[goName(name)] = true
+		if kind, _ := s.kind(); kind == "object" && len(s.Properties) > 0 {
+			g.structs[goName(name)] = true
+		}
+	}
+	g.used["Client"], g.used["Form"], g.used["File"], g.used["APIError"] = true, true, true, true
+
+	var ops []*specOp
+	for p, item := range doc.Paths {
+		for method, op := range item {
+			op.method, op.path = strings.ToUpper(method), p
+			ops = append(ops, op)
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool { return goName(ops[i].OperationID) < goName(ops[j].OperationID) })
+	for _, op := range ops {
+		g.used[goName(op.OperationID)+"Params"] = true
+	}
+	for _, op := range ops {
+		g.operation(op)
+	}
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		g.component(goName(name), doc.Components.Schemas[name])
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by cmd/openapi-gen from internal/apispec/openapi.json. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	body := append(g.methods.Bytes(), g.types.Bytes()...)
+	out.WriteString("import (\n")
+	for _, imp := range []struct{ path, use string }{
+		{"context", "context."}, {"encoding/json", "json."}, {"net/url", "url."}, {"strconv", "strconv."}, {"time", "time."},
+	} {
+		if bytes.Contains(body, []byte(imp.use)) {
+			fmt.Fprintf(&out, "%q\n", imp.path)
+		}
+	}
+	out.WriteString(")\n\n")
+	out.Write(body)
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated client: %w", err)
+	}
+	return src, nil
+}
+
+type specOp struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description"`
+	Deprecated  bool                 `json:"deprecated"`
+	Parameters  []specParam          `json:"parameters"`
+	RequestBody *specBody            `json:"requestBody"`
+	Responses   map[string]*specBody `json:"responses"`
+	Permissions []string             `json:"x-permissions"`
+	Roles       []string             `json:"x-roles"`
+	Features    []string             `json:"x-features"`
+
+	method, path string
+}
+
+type specParam struct {
+	Name   string      `json:"name"`
+	In     string      `json:"in"`
+	Schema *specSchema `json:"schema"`
+}
+
+type specBody struct {
+	Content map[string]struct {
+		Schema *specSchema `json:"schema"`
+	} `json:"content"`
+}
+
+type specSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 any                    `json:"type"`
+	Format               string                 `json:"format"`
+	Description          string                 `json:"description"`
+	Enum                 []any                  `json:"enum"`
+	Properties           map[string]*specSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	Items                *specSchema            `json:"items"`
+	AdditionalProperties *specSchema            `json:"additionalProperties"`
+	AnyOf                []*specSchema          `json:"anyOf"`
+	OneOf                []*specSchema          `json:"oneOf"`
+	ContentMediaType     string                 `json:"contentMediaType"`
+}
+
+// kind is the schema's non-null type, and whether null is allowed.
+func (s *specSchema) kind() (string, bool) {
+	switch t := s.Type.(type) {
+	case string:
+		return t, t == "null"
+	case []any:
+		kind, null := "", false
+		for _, x := range t {
+			if x == "null" {
+				null = true
+			} else if str, ok := x.(string); ok {
+				kind = str
+			}
+		}
+		return kind, null
+	}
+	return "", false
+}
+
+type clientGen struct {
+	schemas map[string]*specSchema
+	used    map[string]bool
+	structs map[string]bool
+	methods bytes.Buffer
+	types   bytes.Buffer
+}
+
+// component declares a component: a struct, a string enum or a named type.
+func (g *clientGen) component(name string, s *specSchema) {
+	kind, _ := s.kind()
+	if kind == "object" && len(s.Properties) > 0 {
+		g.structType(name, s)
+		return
+	}
+	var b bytes.Buffer
+	writeDoc(&b, name, s.Description)
+	if kind == "string" && len(s.Enum) > 0 {
+		fmt.Fprintf(&b, "type %s string\n\nconst (\n", name)
+		seen := map[string]bool{}
+		for _, v := range s.Enum {
+			str, ok := v.(string)
+			if !ok || goName(str) == "" || seen[name+goName(str)] {
+				continue
+			}
+			seen[name+goName(str)] = true
+			fmt.Fprintf(&b, "%s %s = %q\n", name+goName(str), name, str)
+		}
+		b.WriteString(")\n\n")
+	} else {
+		fmt.Fprintf(&b, "type %s %s\n\n", name, g.goType(name, s, true))
+	}
+	g.types.Write(b.Bytes())
+}
+
+// structType declares an object schema as a struct. Inline objects among its
+// fields are declared first, so each declaration is written whole.
+func (g *clientGen) structType(name string, s *specSchema) {
+	required := map[string]bool{}
+	for _, r := range s.Required {
+		required[r] = true
+	}
+	g.structs[name] = true
+	var b bytes.Buffer
+	writeDoc(&b, name, s.Description)
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	fields := map[string]bool{}
+	for _, prop := range sortedKeys(s.Properties) {
+		ps := s.Properties[prop]
+		field := goName(prop)
+		if field == "" {
+			field = "Field"
+		}
+		for fields[field] {
+			field += "_"
+		}
+		fields[field] = true
+		if ps.Description != "" {
+			for _, line := range strings.Split(wrap(ps.Description, 72), "\n") {
+				fmt.Fprintf(&b, "// %s\n", line)
+			}
+		}
+		tag := prop
+		if !required[prop] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&b, "%s %s `json:%q`\n", field, g.goType(name+field, ps, required[prop]), tag)
+	}
+	b.WriteString("}\n\n")
+	g.types.Write(b.Bytes())
+}
+
+// goType maps a schema to a Go type, declaring inline objects as structs
+// named after where they appear.
+func (g *clientGen) goType(hint string, s *specSchema, required bool) string {
+	if s == nil {
+		return "json.RawMessage"
+	}
+	if s.Ref != "" {
+		name := goName(strings.TrimPrefix(s.Ref, "#/components/schemas/"))
+		if target := g.schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]; target != nil {
+			if kind, _ := target.kind(); kind == "object" && len(target.Properties) > 0 && !required {
+				return "*" + name
+			}
+		}
+		return name
+	}
+	if len(s.AnyOf) == 2 {
+		for i, alt := range s.AnyOf {
+			if kind, null := alt.kind(); kind == "" && null {
+				t := g.goType(hint, s.AnyOf[1-i], true)
+				if strings.HasPrefix(t, "*") || strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") || t == "json.RawMessage" {
+					return t
+				}
+				return "*" + t
+			}
+		}
+	}
+	kind, null := s.kind()
+	var t string
+	switch kind {
+	case "string":
+		switch {
+		case s.Format == "date-time":
+			t = "time.Time"
+		case s.ContentMediaType != "":
+			t = "[]byte"
+		default:
+			t = "string"
+		}
+	case "integer":
+		t = "int64"
+	case "number":
+		t = "float64"
+	case "boolean":
+		t = "bool"
+	case "array":
+		return "[]" + g.goType(singular(hint), s.Items, true)
+	case "object":
+		switch {
+		case len(s.Properties) > 0:
+			name := g.fresh(hint)
+			g.structType(name, s)
+			t = name
+		case s.AdditionalProperties != nil:
+			return "map[string]" + g.goType(hint+"Value", s.AdditionalProperties, true)
+		default:
+			return "map[string]any"
+		}
+	default:
+		return "json.RawMessage"
+	}
+	if null || (t == "time.Time" && !required) {
+		return "*" + t
+	}
+	return t
+}
+
+// operation declares a Client method, and its Params struct when the
+// operation takes query parameters.
+func (g *clientGen) operation(op *specOp) {
+	name := goName(op.OperationID)
+	var pathArgs []specParam
+	var query []specParam
+	for _, p := range op.Parameters {
+		switch p.In {
+		case "path":
+			pathArgs = append(pathArgs, p)
+		case "query":
+			query = append(query, p)
+		}
+	}
+
+	args := []string{"ctx context.Context"}
+	for _, p := range pathArgs {
+		args = append(args, argName(p.Name)+" "+scalarType(p.Schema))
+	}
+	bodyArg := "nil"
+	if op.RequestBody != nil {
+		if _, ok := op.RequestBody.Content["multipart/form-data"]; ok {
+			args = append(args, "form Form")
+			bodyArg = "form"
+		} else if c, ok := op.RequestBody.Content["application/json"]; ok {
+			t := g.goType(name+"Request", c.Schema, true)
+			if g.isStruct(t) {
+				t = "*" + t
+			}
+			args = append(args, "body "+t)
+			bodyArg = "body"
+		}
+	}
+	if len(query) > 0 {
+		args = append(args, "params *"+name+"Params")
+	}
+
+	result, raw := g.result(name, op)
+	returns := "error"
+	if result != "" {
+		returns = "(" + result + ", error)"
+	}
+
+	var b strings.Builder
+	summary := strings.TrimSuffix(strings.TrimSpace(op.Summary), ".")
+	fmt.Fprintf(&b, "// %s calls %s %s: %s.\n", name, op.method, op.path, summary)
+	switch len(op.Permissions) {
+	case 0:
+	case 1:
+		fmt.Fprintf(&b, "// It needs the %s permission.\n", op.Permissions[0])
+	default:
+		fmt.Fprintf(&b, "// It needs one of the permissions %s.\n", strings.Join(op.Permissions, ", "))
+	}
+	switch len(op.Roles) {
+	case 0:
+	case 1:
+		fmt.Fprintf(&b, "// It needs the %s role.\n", op.Roles[0])
+	default:
+		fmt.Fprintf(&b, "// It needs one of the roles %s.\n", strings.Join(op.Roles, ", "))
+	}
+	if len(op.Features) > 0 {
+		fmt.Fprintf(&b, "// The organisation's plan must include %s.\n", strings.Join(op.Features, ", "))
+	}
+	if op.Deprecated {
+		b.WriteString("//\n// Deprecated: see the API documentation.\n")
+	}
+	fmt.Fprintf(&b, "func (c *Client) %s(%s) %s {\n", name, strings.Join(args, ", "), returns)
+
+	pathExpr := strconv.Quote(op.path)
+	for _, p := range pathArgs {
+		arg := argName(p.Name)
+		val := "url.PathEscape(" + arg + ")"
+		if scalarType(p.Schema) == "int64" {
+			val = "strconv.FormatInt(" + arg + ", 10)"
+		}
+		pathExpr = strings.Replace(pathExpr, "{"+p.Name+"}", `" + `+val+` + "`, 1)
+	}
+	pathExpr = strings.TrimSuffix(strings.TrimPrefix(pathExpr, `"" + `), ` + ""`)
+	queryArg := "nil"
+	if len(query) > 0 {
+		b.WriteString("q := url.Values{}\nif params != nil {\n")
+		for _, p := range query {
+			field := goName(p.Name)
+			switch scalarType(p.Schema) {
+			case "int64":
+				fmt.Fprintf(&b, "if params.%s != nil {\nq.Set(%q, strconv.FormatInt(*params.%s, 10))\n}\n", field, p.Name, field)
+			case "float64":
+				fmt.Fprintf(&b, "if params.%s != nil {\nq.Set(%q, strconv.FormatFloat(*params.%s, 'f', -1, 64))\n}\n", field, p.Name, field)
+			case "bool":
+				fmt.Fprintf(&b, "if params.%s != nil {\nq.Set(%q, strconv.FormatBool(*params.%s))\n}\n", field, p.Name, field)
+			default:
+				fmt.Fprintf(&b, "if params.%s != \"\" {\nq.Set(%q, params.%s)\n}\n", field, p.Name, field)
+			}
+		}
+		b.WriteString("}\n")
+		queryArg = "q"
+	}
+	switch {
+	case result == "":
+		fmt.Fprintf(&b, "return c.do(ctx, %q, %s, %s, %s, nil)\n}\n\n", op.method, pathExpr, queryArg, bodyArg)
+	case raw:
+		fmt.Fprintf(&b, "var out []byte\nerr := c.do(ctx, %q, %s, %s, %s, &out)\nreturn out, err\n}\n\n", op.method, pathExpr, queryArg, bodyArg)
+	case strings.HasPrefix(result, "*"):
+		fmt.Fprintf(&b, "out := new(%s)\nif err := c.do(ctx, %q, %s, %s, %s, out); err != nil {\nreturn nil, err\n}\nreturn out, nil\n}\n\n",
+			strings.TrimPrefix(result, "*"), op.method, pathExpr, queryArg, bodyArg)
+	default:
+		fmt.Fprintf(&b, "var out %s\nerr := c.do(ctx, %q, %s, %s, %s, &out)\nreturn out, err\n}\n\n", result, op.method, pathExpr, queryArg, bodyArg)
+	}
+
+	if len(query) > 0 {
+		fmt.Fprintf(&b, "// %sParams are %s's query parameters. Zero values are not sent.\ntype %sParams struct {\n", name, name, name)
+		for _, p := range query {
+			t := scalarType(p.Schema)
+			if t != "string" {
+				t = "*" + t
+			}
+			fmt.Fprintf(&b, "%s %s\n", goName(p.Name), t)
+		}
+		b.WriteString("}\n\n")
+	}
+	g.methods.WriteString(b.String())
+}
+
+// result is the Go type of an operation's first successful response with a
+// body, and whether it is returned as raw bytes.
+func (g *clientGen) result(name string, op *specOp) (string, bool) {
+	for _, status := range sortedKeys(op.Responses) {
+		if !strings.HasPrefix(status, "2") {
+			continue
+		}
+		content := op.Responses[status].Content
+		if len(content) == 0 {
+			continue
+		}
+		c, ok := content["application/json"]
+		if !ok {
+			return "[]byte", true
+		}
+		t := g.goType(name+"Response", c.Schema, true)
+		if g.isStruct(t) {
+			t = "*" + t
+		}
+		return t, false
+	}
+	return "", false
+}
+
+// isStruct reports whether a generated type name is a struct, which methods
+// take and return by pointer.
+func (g *clientGen) isStruct(t string) bool {
+	return g.structs[t]
+}
+
+func (g *clientGen) fresh(hint string) string {
+	name := hint
+	for i := 2; g.used[name]; i++ {
+		name = hint + strconv.Itoa(i)
+	}
+	g.used[name] = true
+	return name
+}
+
+func scalarType(s *specSchema) string {
+	if s == nil {
+		return "string"
+	}
+	switch kind, _ := s.kind(); kind {
+	case "integer":
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	}
+	return "string"
+}
+
+func writeDoc(b *bytes.Buffer, name, description string) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return
+	}
+	first := name + " is " + lowerFirst(description)
+	if strings.HasPrefix(description, name+" ") {
+		first = description
+	}
+	for _, line := range strings.Split(wrap(first, 72), "\n") {
+		fmt.Fprintf(b, "// %s\n", line)
+	}
+}
+
+func wrap(s string, width int) string {
+	var lines []string
+	line := ""
+	for _, w := range strings.Fields(s) {
+		if line != "" && len(line)+1+len(w) > width {
+			lines = append(lines, line)
+			line = w
+			continue
+		}
+		if line != "" {
+			line += " "
+		}
+		line += w
+	}
+	return strings.Join(append(lines, line), "\n")
+}
+
+// goName turns a JSON or schema name into an exported Go identifier, with Go's
+// initialisms: "asset_id" → AssetID, "sla-breach" → SLABreach.
+func goName(s string) string {
+	var b strings.Builder
+	for _, part := range words(s) {
+		if strings.EqualFold(part, "ids") {
+			b.WriteString("IDs")
+			continue
+		}
+		if up := strings.ToUpper(part); initialisms[up] {
+			b.WriteString(up)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	out := b.String()
+	if out != "" && unicode.IsDigit(rune(out[0])) {
+		out = "X" + out
+	}
+	return out
+}
+
+// words splits an identifier at punctuation and lower-to-upper case changes:
+// "reportId" and "report_id" both give [report Id].
+func words(s string) []string {
+	var out []string
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		start := 0
+		runes := []rune(field)
+		for i := 1; i < len(runes); i++ {
+			if unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i-1]) {
+				out = append(out, string(runes[start:i]))
+				start = i
+			}
+		}
+		out = append(out, string(runes[start:]))
+	}
+	return out
+}
+
+var initialisms = map[string]bool{
+	"ACL": true, "AI": true, "API": true, "BIA": true, "CPU": true, "CSV": true, "CTI": true, "CVE": true, "CVSS": true,
+	"DNS": true, "EPSS": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true,
+	"IP": true, "JSON": true, "JWT": true, "KEV": true, "KRI": true, "MFA": true, "OTP": true,
+	"PDF": true, "RBAC": true, "SAML": true, "SIEM": true, "SLA": true, "SQL": true, "SSO": true, "TLS": true, "TTL": true,
+	"UI": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// argName names a path parameter's argument, clear of Go keywords and the
+// generated method's own variables.
+func argName(param string) string {
+	name := lowerFirst(goName(param))
+	if token.IsKeyword(name) || generatedLocals[name] {
+		name += "Arg"
+	}
+	return name
+}
+
+var generatedLocals = map[string]bool{"ctx": true, "c": true, "q": true, "out": true, "err": true, "body": true, "form": true, "params": true}
+
+// singular names the element type of an inline array: Items → Item.
+func singular(s string) string {
+	switch {
+	case strings.HasSuffix(s, "ies"):
+		return strings.TrimSuffix(s, "ies") + "y"
+	case strings.HasSuffix(s, "ses"), strings.HasSuffix(s, "ss"):
+		return s + "Item"
+	case strings.HasSuffix(s, "s"):
+		return strings.TrimSuffix(s, "s")
+	}
+	return s + "Item"
+}
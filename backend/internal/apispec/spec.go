@@ -0,0 +1,427 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package apispec generates the API's OpenAPI 3.1 specification from
+// the server itself, and a typed Go client from the specification.
+//
+// The route table in cmd/server is type-checked and walked: every
+// app/group registration becomes an operation. Its middleware gives the
+// authentication, permission, role and plan-feature requirements (annotated
+// as x-permissions, x-roles, x-features and x-capacities). Its handler's body
+// gives the path and query parameters it reads, the type it parses the body
+// into and the types it answers with. Go types become JSON Schema components,
+// with their doc comments as descriptions.
+//
+// Prose lives in docs/openapi.yaml: an operation documented there keeps its
+// summary, description, tags and operationId. Everything else is generated,
+// so the specification cannot drift from the routes; the contract test fails
+// when the committed openapi.json does not match the code.
+package apispec
+
+//go:generate go run ../../cmd/openapi-gen -dir ../.. -overlay ../../../docs/openapi.yaml -spec openapi.json -client ../../pkg/apiclient/zz_generated.go
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the generated specification, as committed next to this file and
+// served at GET /api/v1/openapi.json.
+//
+//go:embed openapi.json
+var Spec []byte
+
+// Config says where the server's source and the prose overlay are.
+type Config struct {
+	Dir         string // the backend module root
+	MainPackage string // the server, e.g. ./cmd/server
+	Overlay     string // docs/openapi.yaml; optional
+	Title       string
+	Version     string
+}
+
+// Result is a generated specification and what went into it.
+type Result struct {
+	JSON   []byte
+	Routes []Route
+	// Warnings are registrations the generator could not read (a path that is
+	// not a constant, a router it could not follow) and overlay operations
+	// that match no route.
+	Warnings []string
+}
+
+// Generate builds the specification from the server's source.
+func Generate(cfg Config) (*Result, error) {
+	prog, mainPkg, err := loadProgram(cfg.Dir, cfg.MainPackage)
+	if err != nil {
+		return nil, err
+	}
+	module := ""
+	for _, lp := range prog.listed {
+		if lp.Name == "main" && lp.Module != nil {
+			module = lp.Module.Path
+		}
+	}
+	if module == "" {
+		return nil, fmt.Errorf("%s is not in a module", cfg.MainPackage)
+	}
+	routes, warnings := extractRoutes(prog, mainPkg, module)
+	overlay, err := loadOverlay(cfg.Overlay)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := newSchemaSet(prog, module)
+	paths := map[string]any{}
+	used := map[string]bool{}
+	var kept []Route
+	seen := map[string]bool{}
+	for _, r := range routes {
+		key := r.Method + " " + r.Path
+		if seen[key] {
+			warnings = append(warnings, fmt.Sprintf("%s: %s registered twice; the first registration is documented", r.Pos, key))
+			continue
+		}
+		seen[key] = true
+		kept = append(kept, r)
+		facts := analyzeHandler(prog, schemas, module, r.handler)
+		op := operation(r, facts, schemas)
+		if o, ok := overlay[overlayKey(r.Method, r.Path)]; ok {
+			o.apply(op)
+			used[overlayKey(r.Method, r.Path)] = true
+		}
+		item, _ := paths[r.Path].(map[string]any)
+		if item == nil {
+			item = map[string]any{}
+			paths[r.Path] = item
+		}
+		item[r.Method] = op
+	}
+	for _, key := range sortedKeys(overlay) {
+		if !used[key] {
+			warnings = append(warnings, "docs/openapi.yaml documents "+key+", which no route serves")
+		}
+	}
+	uniqueOperationIDs(paths)
+
+	components := schemas.finalRefs(paths)
+	components["Error"] = map[string]any{
+		"type":        "object",
+		"description": "An error. Handlers answer {error}; the authentication and permission middleware answer {code, message}.",
+		"properties": map[string]any{
+			"error":   map[string]any{"type": "string"},
+			"code":    map[string]any{},
+			"message": map[string]any{"type": "string"},
+			"details": map[string]any{},
+		},
+	}
+	doc := map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":   cfg.Title,
+			"version": cfg.Version,
+			"description": "Generated from the server's route table and handler types " +
+				"(internal/apispec). x-permissions lists the permissions a route accepts " +
+				"(any one suffices), x-roles the roles, x-features the plan features it needs " +
+				"and x-capacities the plan limits it checks.",
+		},
+		"servers": []any{map[string]any{"url": "/"}},
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas": components,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{
+					"type":        "http",
+					"scheme":      "bearer",
+					"description": "A JWT access token from POST /api/v1/auth/login, or a personal access token.",
+				},
+			},
+		},
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	sort.Strings(warnings)
+	return &Result{JSON: buf.Bytes(), Routes: kept, Warnings: warnings}, nil
+}
+
+// operation assembles one route's operation object.
+func operation(r Route, facts *handlerFacts, schemas *schemaSet) map[string]any {
+	op := map[string]any{}
+	name, doc := "", ""
+	// A handler from a dependency (the metrics adaptor) documents itself, not
+	// the route.
+	if h := r.handler; h != nil && schemas.prog.inModule(h.pkg.path, schemas.module) {
+		name = h.fn
+		if h.decl != nil && h.decl.Doc != nil {
+			doc = h.decl.Doc.Text()
+		}
+		if h.name != "" {
+			op["x-handler"] = h.pkg.types.Name() + "." + h.name
+		}
+	}
+	summary, description := describe(doc, name)
+	if summary == "" {
+		if name != "" {
+			summary = humanize(name)
+		} else {
+			summary = strings.ToUpper(r.Method) + " " + r.Path
+		}
+	}
+	op["summary"] = summary
+	if description != "" {
+		op["description"] = description
+	}
+	if strings.Contains(doc, "Deprecated:") {
+		op["deprecated"] = true
+	}
+	op["operationId"] = operationID(r, name != "")
+	op["tags"] = []any{tagOf(r.Path)}
+
+	var params []any
+	for _, p := range pathParams(r.Path) {
+		s := map[string]any{"type": "string"}
+		switch facts.pathFormat[p] {
+		case "uuid":
+			s["format"] = "uuid"
+		case "integer":
+			s = map[string]any{"type": "integer"}
+		}
+		params = append(params, map[string]any{"name": p, "in": "path", "required": true, "schema": s})
+	}
+	for _, q := range sortedKeys(facts.query) {
+		params = append(params, map[string]any{"name": q, "in": "query", "schema": map[string]any{"type": facts.query[q]}})
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+
+	if r.Method != "get" && r.Method != "head" {
+		switch {
+		case len(facts.form) > 0:
+			props := map[string]any{}
+			for _, f := range sortedKeys(facts.form) {
+				if facts.form[f] {
+					props[f] = map[string]any{"type": "string", "contentMediaType": "application/octet-stream"}
+				} else {
+					props[f] = map[string]any{"type": "string"}
+				}
+			}
+			op["requestBody"] = map[string]any{"required": true, "content": map[string]any{
+				"multipart/form-data": map[string]any{"schema": map[string]any{"type": "object", "properties": props}},
+			}}
+		case facts.body != nil:
+			op["requestBody"] = map[string]any{"required": true, "content": map[string]any{
+				"application/json": map[string]any{"schema": schemas.of(facts.body)},
+			}}
+		}
+	}
+
+	responses := map[string]any{}
+	for _, status := range sortedKeys(facts.responses) {
+		rf := facts.responses[status]
+		resp := map[string]any{"description": statusText(status)}
+		if len(rf.schemas) > 0 && rf.contentType != "" {
+			s := rf.schemas[0]
+			if len(rf.schemas) > 1 {
+				alts := make([]any, len(rf.schemas))
+				for i, x := range rf.schemas {
+					alts[i] = x
+				}
+				s = map[string]any{"oneOf": alts}
+			}
+			resp["content"] = map[string]any{rf.contentType: map[string]any{"schema": s}}
+		}
+		responses[status] = resp
+	}
+	if len(responses) == 0 {
+		responses["200"] = map[string]any{"description": "OK"}
+	}
+	if r.Authenticated {
+		if _, ok := responses["401"]; !ok {
+			responses["401"] = map[string]any{"description": "Unauthorized", "content": errorContent()}
+		}
+		op["security"] = []any{map[string]any{"bearerAuth": []any{}}}
+	} else {
+		op["security"] = []any{}
+	}
+	if len(r.Permissions)+len(r.Roles) > 0 {
+		if _, ok := responses["403"]; !ok {
+			responses["403"] = map[string]any{"description": "Forbidden", "content": errorContent()}
+		}
+	}
+	op["responses"] = responses
+
+	if len(r.Permissions) > 0 {
+		op["x-permissions"] = toAny(r.Permissions)
+	}
+	if len(r.Roles) > 0 {
+		op["x-roles"] = toAny(r.Roles)
+	}
+	if len(r.Features) > 0 {
+		op["x-features"] = toAny(r.Features)
+	}
+	if len(r.Capacities) > 0 {
+		op["x-capacities"] = toAny(r.Capacities)
+	}
+	return op
+}
+
+func errorContent() map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Error"}}}
+}
+
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+func pathParams(p string) []string {
+	var out []string
+	for _, m := range pathParam.FindAllStringSubmatch(p, -1) {
+		out = append(out, m[1])
+	}
+	return out
+}
+
+// operationID names an operation after its handler (VulnerabilityHandler.List
+// → vulnerabilityList), or after its method and path for a closure.
+func operationID(r Route, byHandler bool) string {
+	if h := r.handler; byHandler && h != nil && h.fn != "" {
+		if h.typ != "" {
+			return lowerFirst(strings.TrimSuffix(h.typ, "Handler")) + exportedName(h.fn)
+		}
+		return lowerFirst(h.fn)
+	}
+	id := r.Method
+	for _, seg := range strings.Split(strings.TrimPrefix(r.Path, "/api/v1"), "/") {
+		if seg == "" || strings.HasPrefix(seg, "{") {
+			continue
+		}
+		for _, part := range strings.FieldsFunc(seg, func(c rune) bool { return c == '-' || c == '_' || c == '.' }) {
+			id += exportedName(part)
+		}
+	}
+	return id
+}
+
+// uniqueOperationIDs suffixes the operationIds a handler shares between
+// routes, in path order.
+func uniqueOperationIDs(paths map[string]any) {
+	count := map[string]int{}
+	for _, p := range sortedKeys(paths) {
+		item := paths[p].(map[string]any)
+		for _, m := range sortedKeys(item) {
+			op := item[m].(map[string]any)
+			id := op["operationId"].(string)
+			count[id]++
+			if count[id] > 1 {
+				op["operationId"] = fmt.Sprintf("%s%d", id, count[id])
+			}
+		}
+	}
+}
+
+func tagOf(p string) string {
+	for _, seg := range strings.Split(strings.TrimPrefix(p, "/api/v1"), "/") {
+		if seg != "" && !strings.HasPrefix(seg, "{") {
+			return seg
+		}
+	}
+	return "root"
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	// Keep acronyms readable: KRIHandler → kri, not kRI.
+	n := 0
+	for n < len(s) && s[n] >= 'A' && s[n] <= 'Z' {
+		n++
+	}
+	switch {
+	case n == 0:
+		return s
+	case n == 1 || n == len(s):
+		return strings.ToLower(s[:n]) + s[n:]
+	default:
+		return strings.ToLower(s[:n-1]) + s[n-1:]
+	}
+}
+
+// overlayOp is the prose docs/openapi.yaml gives an operation.
+type overlayOp struct {
+	Summary     string   `yaml:"summary"`
+	Description string   `yaml:"description"`
+	Tags        []string `yaml:"tags"`
+	OperationID string   `yaml:"operationId"`
+	Deprecated  bool     `yaml:"deprecated"`
+}
+
+func (o overlayOp) apply(op map[string]any) {
+	if o.Summary != "" {
+		op["summary"] = strings.TrimSpace(o.Summary)
+	}
+	if o.Description != "" {
+		op["description"] = strings.TrimSpace(o.Description)
+	}
+	if len(o.Tags) > 0 {
+		op["tags"] = toAny(o.Tags)
+	}
+	if o.OperationID != "" {
+		op["operationId"] = o.OperationID
+	}
+	if o.Deprecated {
+		op["deprecated"] = true
+	}
+}
+
+// loadOverlay reads the hand-written specification's operations, keyed by
+// method and parameter-blind path. Its paths are relative to /api/v1.
+func loadOverlay(path string) (map[string]overlayOp, error) {
+	out := map[string]overlayOp{}
+	if path == "" {
+		return out, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Paths map[string]map[string]yaml.Node `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for p, item := range doc.Paths {
+		for method, node := range item {
+			if _, ok := routeMethodSet[method]; !ok {
+				continue
+			}
+			var op overlayOp
+			if err := node.Decode(&op); err != nil {
+				return nil, fmt.Errorf("%s: %s %s: %w", path, method, p, err)
+			}
+			out[overlayKey(method, "/api/v1"+p)] = op
+		}
+	}
+	return out, nil
+}
+
+var routeMethodSet = map[string]struct{}{"get": {}, "post": {}, "put": {}, "patch": {}, "delete": {}, "head": {}, "options": {}}
+
+func overlayKey(method, p string) string {
+	return strings.ToUpper(method) + " " + pathParam.ReplaceAllString(p, "{}")
+}
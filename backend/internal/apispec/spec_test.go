@@ -0,0 +1,139 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package apispec
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+// generated runs the generator over the real server once per test binary.
+var generated *Result
+
+func generate(t *testing.T) *Result {
+	t.Helper()
+	if generated == nil {
+		res, err := Generate(Config{
+			Dir:         "../..",
+			MainPackage: "./cmd/server",
+			Overlay:     "../../../docs/openapi.yaml",
+			Title:       "OpenRisk API",
+			Version:     "1.0.0",
+		})
+		if err != nil {
+			t.Fatalf("generating the specification: %v", err)
+		}
+		generated = res
+	}
+	return generated
+}
+
+// TestSpecDocumentsEveryRoute is the contract test: every route the server
+// registers must be in the committed openapi.json, as the code describes it
+// today.
+func TestSpecDocumentsEveryRoute(t *testing.T) {
+	res := generate(t)
+
+	var committed struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(Spec, &committed); err != nil {
+		t.Fatalf("committed openapi.json: %v", err)
+	}
+	var undocumented []string
+	for _, r := range res.Routes {
+		if _, ok := committed.Paths[r.Path][r.Method]; !ok {
+			undocumented = append(undocumented, strings.ToUpper(r.Method)+" "+r.Path+" ("+r.Pos.String()+")")
+		}
+	}
+	if len(undocumented) > 0 {
+		t.Errorf("%d routes are not in internal/apispec/openapi.json; run make openapi:\n  %s",
+			len(undocumented), strings.Join(undocumented, "\n  "))
+	}
+	if !bytes.Equal(res.JSON, Spec) {
+		t.Error("internal/apispec/openapi.json does not match the routes and handler types; run make openapi")
+	}
+}
+
+func TestOverlayHasNoStaleOperations(t *testing.T) {
+	for _, w := range generate(t).Warnings {
+		if strings.Contains(w, "no route serves") {
+			t.Error(w)
+		}
+	}
+}
+
+func TestClientIsCurrent(t *testing.T) {
+	want, err := GenerateClient(Spec, "apiclient")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("../../pkg/apiclient/zz_generated.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("pkg/apiclient/zz_generated.go does not match openapi.json; run make openapi")
+	}
+}
+
+func TestSpecAnnotatesGates(t *testing.T) {
+	var doc struct {
+		Paths map[string]map[string]struct {
+			OperationID string              `json:"operationId"`
+			Security    []map[string]any    `json:"security"`
+			Permissions []string            `json:"x-permissions"`
+			Parameters  []map[string]any    `json:"parameters"`
+			Responses   map[string]struct{} `json:"responses"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(generate(t).JSON, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	upload := doc.Paths["/api/v1/vulnerabilities/upload"]["post"]
+	if len(upload.Security) != 1 || len(upload.Permissions) != 1 || upload.Permissions[0] != "vulnerabilities:update" {
+		t.Errorf("POST /vulnerabilities/upload: security %v, x-permissions %v", upload.Security, upload.Permissions)
+	}
+	if _, ok := upload.Responses["403"]; !ok {
+		t.Error("a permission-gated operation should document 403")
+	}
+
+	login := doc.Paths["/api/v1/auth/login"]["post"]
+	if login.OperationID == "" || len(login.Security) != 0 {
+		t.Errorf("POST /auth/login should be public, got security %v", login.Security)
+	}
+
+	ids := map[string]string{}
+	for p, item := range doc.Paths {
+		for m, op := range item {
+			if prev, dup := ids[op.OperationID]; dup {
+				t.Errorf("operationId %s is used by %s and %s %s", op.OperationID, prev, m, p)
+			}
+			ids[op.OperationID] = m + " " + p
+		}
+	}
+}
+
+func TestGoName(t *testing.T) {
+	for in, want := range map[string]string{
+		"asset_id":       "AssetID",
+		"reportId":       "ReportID",
+		"control_ids":    "ControlIDs",
+		"sla-breach":     "SLABreach",
+		"listRisks":      "ListRisks",
+		"2fa":            "X2fa",
+		"ale_usd":        "AleUsd",
+		"getOpenAPISpec": "GetOpenAPISpec",
+	} {
+		if got := goName(in); got != want {
+			t.Errorf("goName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,390 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package accesspolicy manages record-level access policies and resolves the
+// access scope every request carries to the repositories. The policies of a
+// tenant and the attributes of a member are cached briefly so resolving a
+// scope costs nothing on most requests; a change made through this service is
+// visible at once on this replica and within the cache TTL on the others. See
+// docs/ACCESS_POLICIES.md.
+package accesspolicy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// AuditSink records policy and attribute changes in the audit chain.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// Service is the access policy use cases.
+type Service struct {
+	repo  domain.AccessPolicyRepository
+	audit AuditSink
+
+	now      func() time.Time
+	ttl      time.Duration
+	mu       sync.Mutex
+	policies map[uuid.UUID]cachedPolicies
+	subjects map[subjectKey]cachedSubject
+}
+
+type cachedPolicies struct {
+	enabled []domain.AccessPolicy
+	exp     time.Time
+}
+
+type subjectKey struct{ tenant, user uuid.UUID }
+
+type cachedSubject struct {
+	subject *domain.AccessSubject
+	exp     time.Time
+}
+
+// NewService builds the service.
+func NewService(repo domain.AccessPolicyRepository) *Service {
+	return &Service{
+		repo:     repo,
+		now:      time.Now,
+		ttl:      30 * time.Second,
+		policies: make(map[uuid.UUID]cachedPolicies),
+		subjects: make(map[subjectKey]cachedSubject),
+	}
+}
+
+// WithAudit enables audit recording.
+func (s *Service) WithAudit(a AuditSink) *Service {
+	s.audit = a
+	return s
+}
+
+// =============================================================================
+// Scope resolution
+// =============================================================================
+
+// Scope returns the access scope of a request, or nil when the tenant has no
+// enabled policy. tokenRole is the role the session carries; it is used only
+// for a user with no membership row in the tenant (a platform root).
+func (s *Service) Scope(ctx context.Context, tenantID, userID uuid.UUID, tokenRole domain.MemberRole) (*domain.AccessScope, error) {
+	policies, err := s.enabledPolicies(ctx, tenantID)
+	if err != nil || len(policies) == 0 {
+		return nil, err
+	}
+	subject, err := s.subject(ctx, tenantID, userID, tokenRole)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.AccessScope{Subject: *subject, Policies: policies}, nil
+}
+
+func (s *Service) enabledPolicies(ctx context.Context, tenantID uuid.UUID) ([]domain.AccessPolicy, error) {
+	now := s.now()
+	s.mu.Lock()
+	if hit, ok := s.policies[tenantID]; ok && hit.exp.After(now) {
+		s.mu.Unlock()
+		return hit.enabled, nil
+	}
+	s.mu.Unlock()
+
+	rows, err := s.repo.List(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load access policies: %w", err)
+	}
+	var enabled []domain.AccessPolicy
+	for _, p := range rows {
+		if p.Enabled {
+			enabled = append(enabled, p)
+		}
+	}
+	s.mu.Lock()
+	s.policies[tenantID] = cachedPolicies{enabled: enabled, exp: now.Add(s.ttl)}
+	s.mu.Unlock()
+	return enabled, nil
+}
+
+func (s *Service) subject(ctx context.Context, tenantID, userID uuid.UUID, tokenRole domain.MemberRole) (*domain.AccessSubject, error) {
+	key := subjectKey{tenantID, userID}
+	now := s.now()
+	s.mu.Lock()
+	if hit, ok := s.subjects[key]; ok && hit.exp.After(now) {
+		s.mu.Unlock()
+		return hit.subject, nil
+	}
+	s.mu.Unlock()
+
+	subject, err := s.repo.Subject(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if subject == nil {
+		subject = &domain.AccessSubject{UserID: userID, Role: tokenRole}
+		if subject.Role == "" {
+			subject.Role = domain.RoleUser
+		}
+	}
+	s.mu.Lock()
+	s.subjects[key] = cachedSubject{subject: subject, exp: now.Add(s.ttl)}
+	s.mu.Unlock()
+	return subject, nil
+}
+
+func (s *Service) invalidatePolicies(tenantID uuid.UUID) {
+	s.mu.Lock()
+	delete(s.policies, tenantID)
+	s.mu.Unlock()
+}
+
+func (s *Service) invalidateSubject(tenantID, userID uuid.UUID) {
+	s.mu.Lock()
+	delete(s.subjects, subjectKey{tenantID, userID})
+	s.mu.Unlock()
+}
+
+// =============================================================================
+// Policies
+// =============================================================================
+
+// PolicyInput creates or replaces a policy.
+type PolicyInput struct {
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Resource    domain.AccessResource   `json:"resource"`
+	Effect      domain.AccessEffect     `json:"effect"`
+	Subjects    domain.AccessSubjects   `json:"subjects"`
+	Condition   *domain.AccessCondition `json:"condition"`
+	// Enabled defaults to true on create and is left alone on update when nil.
+	Enabled *bool `json:"enabled"`
+}
+
+// Policies lists the tenant's policies, enabled or not.
+func (s *Service) Policies(ctx context.Context, tenantID uuid.UUID) ([]domain.AccessPolicy, error) {
+	rows, err := s.repo.List(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if rows == nil {
+		rows = []domain.AccessPolicy{}
+	}
+	return rows, nil
+}
+
+// Policy returns one policy.
+func (s *Service) Policy(ctx context.Context, tenantID, id uuid.UUID) (*domain.AccessPolicy, error) {
+	p, err := s.repo.Get(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if p == nil {
+		return nil, domain.NewNotFoundError("access policy", id)
+	}
+	return p, nil
+}
+
+// CreatePolicy adds a policy. It is in force on the next request.
+func (s *Service) CreatePolicy(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, in PolicyInput) (*domain.AccessPolicy, error) {
+	now := s.now()
+	p := &domain.AccessPolicy{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Enabled:   true,
+		CreatedBy: actor,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	apply(p, in)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, p); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	s.invalidatePolicies(tenantID)
+	s.record(ctx, tenantID, actor, domain.AuditActionCreate, "access_policy", p.ID.String(),
+		fmt.Sprintf("Created access policy %q (%s %s)", p.Name, p.Effect, p.Resource), policyAudit(p))
+	return p, nil
+}
+
+// UpdatePolicy replaces a policy's definition.
+func (s *Service) UpdatePolicy(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID, in PolicyInput) (*domain.AccessPolicy, error) {
+	p, err := s.Policy(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	apply(p, in)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	p.UpdatedAt = s.now()
+	if err := s.repo.Update(ctx, p); err != nil {
+		return nil, err
+	}
+	s.invalidatePolicies(tenantID)
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, "access_policy", p.ID.String(),
+		fmt.Sprintf("Updated access policy %q", p.Name), policyAudit(p))
+	return p, nil
+}
+
+// DeletePolicy removes a policy.
+func (s *Service) DeletePolicy(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) error {
+	p, err := s.Policy(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, tenantID, id); err != nil {
+		return err
+	}
+	s.invalidatePolicies(tenantID)
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, "access_policy", id.String(),
+		fmt.Sprintf("Deleted access policy %q", p.Name), nil)
+	return nil
+}
+
+func apply(p *domain.AccessPolicy, in PolicyInput) {
+	p.Name = in.Name
+	p.Description = in.Description
+	p.Resource = in.Resource
+	p.Effect = in.Effect
+	p.Subjects = in.Subjects
+	p.Condition = in.Condition
+	if in.Enabled != nil {
+		p.Enabled = *in.Enabled
+	}
+}
+
+func policyAudit(p *domain.AccessPolicy) domain.JSONMap {
+	return domain.JSONMap{
+		"name": p.Name, "resource": p.Resource, "effect": p.Effect,
+		"subjects": p.Subjects, "condition": p.Condition, "enabled": p.Enabled,
+	}
+}
+
+// =============================================================================
+// Member attributes
+// =============================================================================
+
+// Subject returns what policies know about a member: role, business role,
+// department and assigned attributes.
+func (s *Service) Subject(ctx context.Context, tenantID, userID uuid.UUID) (*domain.AccessSubject, error) {
+	subject, err := s.repo.Subject(ctx, tenantID, userID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if subject == nil {
+		return nil, domain.NewNotFoundError("member", userID)
+	}
+	if subject.Attributes == nil {
+		subject.Attributes = map[string][]string{}
+	}
+	return subject, nil
+}
+
+// SetSubjectAttributes replaces the attributes assigned to a member.
+func (s *Service) SetSubjectAttributes(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, userID uuid.UUID, attrs map[string][]string) (*domain.AccessSubject, error) {
+	if _, err := s.Subject(ctx, tenantID, userID); err != nil {
+		return nil, err
+	}
+	clean, err := domain.ValidateSubjectAttributes(attrs)
+	if err != nil {
+		return nil, err
+	}
+	row := &domain.AccessSubjectAttributes{
+		TenantID: tenantID, UserID: userID, Attributes: clean,
+		UpdatedBy: actor, UpdatedAt: s.now(),
+	}
+	if err := s.repo.SaveSubjectAttributes(ctx, row); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	s.invalidateSubject(tenantID, userID)
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, "access_subject", userID.String(),
+		"Updated a member's access attributes", domain.JSONMap{"attributes": clean})
+	return s.Subject(ctx, tenantID, userID)
+}
+
+// =============================================================================
+// Explain
+// =============================================================================
+
+// Explanation answers "why can (or can't) this user see this record".
+type Explanation struct {
+	Resource domain.AccessResource `json:"resource"`
+	RecordID uuid.UUID             `json:"record_id"`
+	Subject  domain.AccessSubject  `json:"subject"`
+	// Record holds the attribute values the policies were evaluated against.
+	// Omitted when the record is hidden from a caller who is not an
+	// administrator: explaining a refusal must not disclose the record.
+	Record   domain.AccessRecord   `json:"record,omitempty"`
+	Decision domain.AccessDecision `json:"decision"`
+	// QueryVisible is what the repositories' SQL decides for the same user and
+	// record. It always equals Decision.Visible; a difference is a bug.
+	QueryVisible bool `json:"query_visible"`
+}
+
+// Explain evaluates the tenant's current policies for one user and one record,
+// bypassing the caches so the answer reflects the stored state. full keeps
+// the record's attributes and the predicate traces for a hidden record; only
+// administrators get them.
+func (s *Service) Explain(ctx context.Context, tenantID, userID uuid.UUID, r domain.AccessResource, id uuid.UUID, full bool) (*Explanation, error) {
+	if !r.IsValid() {
+		return nil, domain.NewValidationError(fmt.Sprintf("unknown resource %q (use risk or evidence)", r))
+	}
+	rec, err := s.repo.Record(ctx, tenantID, r, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if rec == nil {
+		return nil, domain.NewNotFoundError(string(r), id)
+	}
+	subject, err := s.repo.Subject(ctx, tenantID, userID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if subject == nil {
+		return nil, domain.NewNotFoundError("member", userID)
+	}
+	rows, err := s.repo.List(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	var scope *domain.AccessScope
+	if len(rows) > 0 {
+		scope = &domain.AccessScope{Subject: *subject, Policies: rows}
+	}
+
+	out := &Explanation{Resource: r, RecordID: id, Subject: *subject, Record: rec, Decision: scope.Decide(r, rec)}
+	out.QueryVisible, err = s.repo.Visible(domain.WithAccessScope(ctx, scope), tenantID, r, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if !full && !out.Decision.Visible {
+		out.Record = nil
+		for i := range out.Decision.Policies {
+			out.Decision.Policies[i].Trace = nil
+		}
+	}
+	return out, nil
+}
+
+func (s *Service) record(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, action domain.AuditAction, entity, id, summary string, after domain.JSONMap) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    actor,
+		Action:     action,
+		EntityType: entity,
+		EntityID:   id,
+		Summary:    summary,
+		After:      after,
+	})
+}
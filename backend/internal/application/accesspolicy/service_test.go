@@ -0,0 +1,175 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package accesspolicy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+type memPolicies struct {
+	policies map[uuid.UUID]domain.AccessPolicy
+	members  map[uuid.UUID]domain.AccessSubject
+	records  map[uuid.UUID]domain.AccessRecord
+	lists    int
+}
+
+func newMemPolicies() *memPolicies {
+	return &memPolicies{policies: map[uuid.UUID]domain.AccessPolicy{},
+		members: map[uuid.UUID]domain.AccessSubject{}, records: map[uuid.UUID]domain.AccessRecord{}}
+}
+
+func (m *memPolicies) List(_ context.Context, tenantID uuid.UUID) ([]domain.AccessPolicy, error) {
+	m.lists++
+	var out []domain.AccessPolicy
+	for _, p := range m.policies {
+		if p.TenantID == tenantID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+func (m *memPolicies) Get(_ context.Context, tenantID, id uuid.UUID) (*domain.AccessPolicy, error) {
+	if p, ok := m.policies[id]; ok && p.TenantID == tenantID {
+		return &p, nil
+	}
+	return nil, nil
+}
+func (m *memPolicies) Create(_ context.Context, p *domain.AccessPolicy) error {
+	m.policies[p.ID] = *p
+	return nil
+}
+func (m *memPolicies) Update(_ context.Context, p *domain.AccessPolicy) error {
+	m.policies[p.ID] = *p
+	return nil
+}
+func (m *memPolicies) Delete(_ context.Context, _, id uuid.UUID) error {
+	delete(m.policies, id)
+	return nil
+}
+func (m *memPolicies) Subject(_ context.Context, _, userID uuid.UUID) (*domain.AccessSubject, error) {
+	if s, ok := m.members[userID]; ok {
+		return &s, nil
+	}
+	return nil, nil
+}
+func (m *memPolicies) SaveSubjectAttributes(_ context.Context, a *domain.AccessSubjectAttributes) error {
+	s := m.members[a.UserID]
+	s.Attributes = a.Attributes
+	m.members[a.UserID] = s
+	return nil
+}
+func (m *memPolicies) Record(_ context.Context, _ uuid.UUID, _ domain.AccessResource, id uuid.UUID) (domain.AccessRecord, error) {
+	return m.records[id], nil
+}
+func (m *memPolicies) Visible(ctx context.Context, _ uuid.UUID, r domain.AccessResource, id uuid.UUID) (bool, error) {
+	return domain.AccessScopeFrom(ctx).Decide(r, m.records[id]).Visible, nil
+}
+
+type memAudit struct{ events []domain.AuditEvent }
+
+func (a *memAudit) Record(_ context.Context, e domain.AuditEvent) { a.events = append(a.events, e) }
+
+func TestScope_CachedAndInvalidatedByWrites(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemPolicies()
+	audit := &memAudit{}
+	svc := NewService(repo).WithAudit(audit)
+	tenant, user, actor := uuid.New(), uuid.New(), uuid.New()
+	repo.members[user] = domain.AccessSubject{UserID: user, Role: domain.RoleUser}
+
+	scope, err := svc.Scope(ctx, tenant, user, "")
+	require.NoError(t, err)
+	assert.Nil(t, scope, "no policy, no scope")
+	_, err = svc.Scope(ctx, tenant, user, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.lists, "the tenant's policies are cached")
+
+	p, err := svc.CreatePolicy(ctx, tenant, &actor, PolicyInput{Name: "hide secrets", Resource: domain.AccessResourceRisk,
+		Effect: domain.AccessDeny, Condition: &domain.AccessCondition{Attr: "tags", Op: domain.AccessOpIn, Values: []string{"secret"}}})
+	require.NoError(t, err)
+	assert.True(t, p.Enabled)
+	scope, err = svc.Scope(ctx, tenant, user, "")
+	require.NoError(t, err)
+	require.NotNil(t, scope, "a write is in force on the next request")
+	assert.Len(t, scope.Policies, 1)
+
+	off := false
+	_, err = svc.UpdatePolicy(ctx, tenant, &actor, p.ID, PolicyInput{Name: "hide secrets", Resource: domain.AccessResourceRisk,
+		Effect: domain.AccessDeny, Enabled: &off})
+	require.NoError(t, err)
+	scope, err = svc.Scope(ctx, tenant, user, "")
+	require.NoError(t, err)
+	assert.Nil(t, scope, "disabled policies are not in force")
+
+	_, err = svc.CreatePolicy(ctx, tenant, &actor, PolicyInput{Name: "bad", Resource: domain.AccessResourceRisk, Effect: domain.AccessAllow,
+		Condition: &domain.AccessCondition{Attr: "nope", Op: domain.AccessOpIn, Values: []string{"x"}}})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "got %v", err)
+
+	require.NoError(t, svc.DeletePolicy(ctx, tenant, &actor, p.ID))
+	_, err = svc.Policy(ctx, tenant, p.ID)
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+	require.Len(t, audit.events, 3)
+	assert.Equal(t, "access_policy", audit.events[0].EntityType)
+}
+
+func TestScope_NonMemberUsesTheTokenRole(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemPolicies()
+	svc := NewService(repo)
+	tenant, root := uuid.New(), uuid.New()
+	_, err := svc.CreatePolicy(ctx, tenant, nil, PolicyInput{Name: "p", Resource: domain.AccessResourceRisk, Effect: domain.AccessAllow})
+	require.NoError(t, err)
+
+	scope, err := svc.Scope(ctx, tenant, root, domain.RoleRoot)
+	require.NoError(t, err)
+	require.NotNil(t, scope)
+	assert.True(t, scope.Subject.Exempt())
+
+	_, err = svc.SetSubjectAttributes(ctx, tenant, nil, root, map[string][]string{"audit_scope": {"iso"}})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "attributes belong to members")
+}
+
+func TestExplain_RedactsHiddenRecordsForMembers(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemPolicies()
+	svc := NewService(repo)
+	tenant, user, risk := uuid.New(), uuid.New(), uuid.New()
+	repo.members[user] = domain.AccessSubject{UserID: user, Role: domain.RoleUser}
+	repo.records[risk] = domain.AccessRecord{"id": {risk.String()}, "tags": {"secret"}}
+
+	out, err := svc.Explain(ctx, tenant, user, domain.AccessResourceRisk, risk, false)
+	require.NoError(t, err)
+	assert.True(t, out.Decision.Visible)
+	assert.True(t, out.QueryVisible)
+
+	_, err = svc.CreatePolicy(ctx, tenant, nil, PolicyInput{Name: "hide secrets", Resource: domain.AccessResourceRisk,
+		Effect: domain.AccessDeny, Condition: &domain.AccessCondition{Attr: "tags", Op: domain.AccessOpIn, Values: []string{"secret"}}})
+	require.NoError(t, err)
+	out, err = svc.Explain(ctx, tenant, user, domain.AccessResourceRisk, risk, false)
+	require.NoError(t, err)
+	assert.False(t, out.Decision.Visible)
+	assert.False(t, out.QueryVisible)
+	assert.Contains(t, out.Decision.Reason, "hide secrets")
+	assert.Nil(t, out.Record, "a refusal does not disclose the record")
+	require.Len(t, out.Decision.Policies, 1)
+	assert.Empty(t, out.Decision.Policies[0].Trace)
+
+	out, err = svc.Explain(ctx, tenant, user, domain.AccessResourceRisk, risk, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"secret"}, out.Record["tags"])
+	assert.NotEmpty(t, out.Decision.Policies[0].Trace)
+
+	_, err = svc.Explain(ctx, tenant, user, "asset", risk, true)
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+}
@@ -30,6 +30,7 @@ type EvidenceAssessmentResult struct {
 // documented next step; without it the assistant lowers its confidence rather than
 // asserting a false "satisfies".
 type AnalyzeEvidenceUseCase struct {
+	providers  AssistantSource // optional
 	assistant  llm.Assistant
 	compliance ComplianceReader
 }
@@ -38,6 +39,13 @@ func NewAnalyzeEvidenceUseCase(assistant llm.Assistant, compliance ComplianceRea
 	return &AnalyzeEvidenceUseCase{assistant: assistant, compliance: compliance}
 }
 
+// WithProviders resolves the assistant per organisation instead of always using
+// the one passed to the constructor.
+func (uc *AnalyzeEvidenceUseCase) WithProviders(p AssistantSource) *AnalyzeEvidenceUseCase {
+	uc.providers = p
+	return uc
+}
+
 // Execute loads the evidence + control context and asks the assistant for a verdict.
 func (uc *AnalyzeEvidenceUseCase) Execute(ctx context.Context, tenantID, evidenceID uuid.UUID, locale string) (*EvidenceAssessmentResult, error) {
 	evidence, err := uc.compliance.GetEvidenceByID(ctx, tenantID, evidenceID)
@@ -76,7 +84,7 @@ func (uc *AnalyzeEvidenceUseCase) Execute(ctx context.Context, tenantID, evidenc
 		}
 	}
 
-	assessment, generatedBy := invoke(uc.assistant, func(a llm.Assistant) (llm.EvidenceAssessment, error) {
+	assessment, generatedBy := invoke(assistantFor(ctx, uc.providers, tenantID, uc.assistant), func(a llm.Assistant) (llm.EvidenceAssessment, error) {
 		return a.AnalyzeEvidence(ctx, ec)
 	})
 	return &EvidenceAssessmentResult{Assessment: assessment, GeneratedBy: generatedBy}, nil
@@ -30,6 +30,7 @@ type AssistantAnswerResult struct {
 // vulnerabilities — and hands the top matches to the assistant as RAG context.
 // Every source is optional and nil-safe.
 type AssistantQueryUseCase struct {
+	providers  AssistantSource // optional
 	assistant  llm.Assistant
 	risks      RiskLister       // optional
 	compliance ComplianceReader // optional
@@ -41,6 +42,13 @@ func NewAssistantQueryUseCase(assistant llm.Assistant) *AssistantQueryUseCase {
 	return &AssistantQueryUseCase{assistant: assistant}
 }
 
+// WithProviders resolves the assistant per organisation instead of always using
+// the one passed to the constructor.
+func (uc *AssistantQueryUseCase) WithProviders(p AssistantSource) *AssistantQueryUseCase {
+	uc.providers = p
+	return uc
+}
+
 func (uc *AssistantQueryUseCase) WithRisks(r RiskLister) *AssistantQueryUseCase {
 	uc.risks = r
 	return uc
@@ -78,7 +86,7 @@ func (uc *AssistantQueryUseCase) Execute(ctx context.Context, tenantID uuid.UUID
 		OrgName:  uc.orgName(ctx, tenantID),
 	}
 
-	answer, generatedBy := invoke(uc.assistant, func(a llm.Assistant) (llm.AssistantAnswer, error) {
+	answer, generatedBy := invoke(assistantFor(ctx, uc.providers, tenantID, uc.assistant), func(a llm.Assistant) (llm.AssistantAnswer, error) {
 		return a.Answer(ctx, query)
 	})
 	return &AssistantAnswerResult{Answer: answer, GeneratedBy: generatedBy, Retrieved: snippets}, nil
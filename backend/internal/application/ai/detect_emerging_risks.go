@@ -27,13 +27,21 @@ type EmergingRisksResult struct {
 // re-proposing what is already tracked.
 type DetectEmergingRisksUseCase struct {
 	assistant llm.Assistant
-	risks     RiskLister // optional
+	providers AssistantSource // optional
+	risks     RiskLister      // optional
 }
 
 func NewDetectEmergingRisksUseCase(assistant llm.Assistant) *DetectEmergingRisksUseCase {
 	return &DetectEmergingRisksUseCase{assistant: assistant}
 }
 
+// WithProviders resolves the assistant per organisation instead of always using
+// the one passed to the constructor.
+func (uc *DetectEmergingRisksUseCase) WithProviders(p AssistantSource) *DetectEmergingRisksUseCase {
+	uc.providers = p
+	return uc
+}
+
 // WithRiskLister supplies existing risk titles for de-duplication.
 func (uc *DetectEmergingRisksUseCase) WithRiskLister(r RiskLister) *DetectEmergingRisksUseCase {
 	uc.risks = r
@@ -73,7 +81,7 @@ func (uc *DetectEmergingRisksUseCase) Execute(ctx context.Context, tenantID uuid
 		}
 	}
 
-	res, generatedBy := invoke(uc.assistant, func(a llm.Assistant) (llm.EmergingRisksResult, error) {
+	res, generatedBy := invoke(assistantFor(ctx, uc.providers, tenantID, uc.assistant), func(a llm.Assistant) (llm.EmergingRisksResult, error) {
 		return a.DetectEmergingRisks(ctx, input)
 	})
 	return &EmergingRisksResult{Result: res, GeneratedBy: generatedBy}, nil
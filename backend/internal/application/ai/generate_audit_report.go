@@ -26,6 +26,7 @@ type AuditReportResult struct {
 // the open remediation count — all optional/nil-safe.
 type GenerateAuditReportUseCase struct {
 	assistant llm.Assistant
+	providers AssistantSource // optional
 	audits    AuditReader
 	gap       GapAnalyzer // optional
 }
@@ -34,6 +35,13 @@ func NewGenerateAuditReportUseCase(assistant llm.Assistant, audits AuditReader)
 	return &GenerateAuditReportUseCase{assistant: assistant, audits: audits}
 }
 
+// WithProviders resolves the assistant per organisation instead of always using
+// the one passed to the constructor.
+func (uc *GenerateAuditReportUseCase) WithProviders(p AssistantSource) *GenerateAuditReportUseCase {
+	uc.providers = p
+	return uc
+}
+
 // WithGapAnalyzer enriches the report with a live gap analysis.
 func (uc *GenerateAuditReportUseCase) WithGapAnalyzer(g GapAnalyzer) *GenerateAuditReportUseCase {
 	uc.gap = g
@@ -98,7 +106,7 @@ func (uc *GenerateAuditReportUseCase) Execute(ctx context.Context, tenantID, aud
 		}
 	}
 
-	report, generatedBy := invoke(uc.assistant, func(a llm.Assistant) (llm.AuditNarrative, error) {
+	report, generatedBy := invoke(assistantFor(ctx, uc.providers, tenantID, uc.assistant), func(a llm.Assistant) (llm.AuditNarrative, error) {
 		return a.SummarizeAudit(ctx, auditCtx)
 	})
 	return &AuditReportResult{Report: report, GeneratedBy: generatedBy}, nil
@@ -77,6 +77,26 @@ type OrgLookup interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error)
 }
 
+// AssistantSource resolves the assistant an organisation has chosen (its own
+// self-hosted model, Claude, or the template). *ProviderSettingsService
+// satisfies it. Optional: without one every use case uses the assistant it was
+// built with.
+type AssistantSource interface {
+	AssistantFor(ctx context.Context, tenantID uuid.UUID) llm.Assistant
+}
+
+// assistantFor picks the organisation's assistant when a source is wired, the
+// use case's own one otherwise.
+func assistantFor(ctx context.Context, src AssistantSource, tenantID uuid.UUID, fallback llm.Assistant) llm.Assistant {
+	if src == nil {
+		return fallback
+	}
+	if a := src.AssistantFor(ctx, tenantID); a != nil {
+		return a
+	}
+	return fallback
+}
+
 // invoke runs an assistant call with a deterministic template fallback and returns
 // the provider name (the model that actually produced the result). It mirrors the
 // board report's best-effort narrate contract.
@@ -0,0 +1,270 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: LicenseRef-OpenRisk-Commercial
+// This file is part of the OpenRisk Enterprise Edition and is NOT covered by the
+// AGPL; it is licensed under the OpenRisk Commercial License (see LICENSE.commercial).
+
+package ai
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	llm "github.com/opendefender/openrisk/pkg/ai"
+)
+
+// SecretCipher encrypts provider API keys at rest. The scanner's AES-256-GCM
+// CredentialCipher (SCANNER_CREDENTIAL_KEY) satisfies it.
+type SecretCipher interface {
+	EncryptString(plaintext string) (string, error)
+	DecryptString(ciphertext string) (string, error)
+}
+
+// AuditSink records provider changes in the tamper-evident audit chain. Where
+// risk data may be sent is exactly the kind of setting an auditor asks about.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// ProviderSettingsService manages each organisation's LLM provider and resolves
+// the Assistant / Advisor a request should use. Organisations without a setting
+// get the server defaults built in the composition root.
+//
+// Resolution fails closed: if the stored setting cannot be read or its key
+// cannot be decrypted, the organisation gets the template, never the server
+// default. An organisation that chose a self-hosted model did so to keep data
+// in-house; silently routing it to an external API because of a storage hiccup
+// would defeat the point.
+type ProviderSettingsService struct {
+	repo             domain.AIProviderSettingRepository
+	cipher           SecretCipher
+	defaultAssistant llm.Assistant
+	defaultAdvisor   llm.Advisor
+	audit            AuditSink
+	allowPrivate     bool
+	now              func() time.Time
+}
+
+// NewProviderSettingsService builds the service. The defaults are what an
+// organisation without a setting gets; nil means the template.
+func NewProviderSettingsService(
+	repo domain.AIProviderSettingRepository,
+	cipher SecretCipher,
+	defaultAssistant llm.Assistant,
+	defaultAdvisor llm.Advisor,
+) *ProviderSettingsService {
+	if defaultAssistant == nil {
+		defaultAssistant = llm.NewTemplateAssistant()
+	}
+	if defaultAdvisor == nil {
+		defaultAdvisor = llm.NewTemplateAdvisor()
+	}
+	return &ProviderSettingsService{
+		repo: repo, cipher: cipher,
+		defaultAssistant: defaultAssistant, defaultAdvisor: defaultAdvisor,
+		now: time.Now,
+	}
+}
+
+// WithAudit attaches the optional audit sink.
+func (s *ProviderSettingsService) WithAudit(a AuditSink) *ProviderSettingsService {
+	s.audit = a
+	return s
+}
+
+// AllowPrivateNetworks lets organisations point base_url at private and
+// loopback addresses, for self-hosted models on the internal network. Operator
+// setting (AI_ALLOW_PRIVATE_NETWORKS); off, such URLs are refused when saved
+// and on every connection.
+func (s *ProviderSettingsService) AllowPrivateNetworks(allow bool) *ProviderSettingsService {
+	s.allowPrivate = allow
+	return s
+}
+
+// DefaultAssistant is what organisations without a setting use.
+func (s *ProviderSettingsService) DefaultAssistant() llm.Assistant { return s.defaultAssistant }
+
+// ProviderInput is the body of PUT /ai/provider.
+type ProviderInput struct {
+	Provider string `json:"provider"`
+	// BaseURL is the openai_compatible API root. A private or loopback
+	// address is refused unless the operator sets AI_ALLOW_PRIVATE_NETWORKS.
+	BaseURL        string `json:"base_url"`
+	Model          string `json:"model"`
+	APIKey         string `json:"api_key"`
+	ClearAPIKey    bool   `json:"clear_api_key"`
+	TimeoutSeconds int    `json:"timeout_seconds"`
+	MaxTokens      int    `json:"max_tokens"`
+	OutputMode     string `json:"output_mode"`
+}
+
+// Get returns the organisation's setting, or a NotFound error when it uses the
+// server default.
+func (s *ProviderSettingsService) Get(ctx context.Context, tenantID uuid.UUID) (*domain.AIProviderSetting, error) {
+	st, err := s.repo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if st == nil {
+		return nil, domain.NewNotFoundError("ai provider setting", tenantID)
+	}
+	st.HasAPIKey = st.EncryptedAPIKey != ""
+	return st, nil
+}
+
+// Save creates or replaces the organisation's setting. A blank api_key keeps the
+// stored one — but only while the provider and base URL are unchanged, so a key
+// issued for one endpoint is never sent to another.
+func (s *ProviderSettingsService) Save(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, in ProviderInput) (*domain.AIProviderSetting, error) {
+	provider := llm.Provider(strings.ToLower(strings.TrimSpace(in.Provider)))
+	base := strings.TrimRight(strings.TrimSpace(in.BaseURL), "/")
+	if in.TimeoutSeconds < 0 || in.TimeoutSeconds > 900 {
+		return nil, domain.NewValidationError("timeout_seconds must be between 0 (default) and 900")
+	}
+	if in.MaxTokens < 0 || in.MaxTokens > 32768 {
+		return nil, domain.NewValidationError("max_tokens must be between 0 (default) and 32768")
+	}
+
+	existing, err := s.repo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	now := s.now()
+	st := &domain.AIProviderSetting{ID: uuid.New(), TenantID: tenantID, CreatedAt: now}
+	if existing != nil {
+		st = existing
+		if string(provider) != st.Provider || base != st.BaseURL {
+			st.EncryptedAPIKey = ""
+		}
+	}
+	st.Provider = string(provider)
+	st.BaseURL = base
+	st.Model = strings.TrimSpace(in.Model)
+	st.TimeoutSeconds = in.TimeoutSeconds
+	st.MaxTokens = in.MaxTokens
+	st.OutputMode = strings.ToLower(strings.TrimSpace(in.OutputMode))
+	st.UpdatedBy = actor
+	st.UpdatedAt = now
+	if provider == llm.ProviderTemplate {
+		st.BaseURL, st.Model, st.EncryptedAPIKey = "", "", ""
+	}
+	if in.ClearAPIKey {
+		st.EncryptedAPIKey = ""
+	}
+
+	key := strings.TrimSpace(in.APIKey)
+	cfg := s.config(st, key)
+	if key == "" && st.EncryptedAPIKey != "" {
+		// Validation only needs to know a key exists.
+		cfg.APIKey = "stored"
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, domain.NewValidationError(err.Error())
+	}
+	if key != "" && provider != llm.ProviderTemplate {
+		ct, err := s.cipher.EncryptString(key)
+		if err != nil {
+			return nil, err
+		}
+		st.EncryptedAPIKey = ct
+	}
+
+	if err := s.repo.Save(ctx, st); err != nil {
+		return nil, domain.NewInternalError("failed to save ai provider setting: " + err.Error())
+	}
+	action := domain.AuditActionUpdate
+	if existing == nil {
+		action = domain.AuditActionCreate
+	}
+	s.record(ctx, tenantID, actor, action, st, "AI provider set to "+st.Provider)
+	st.HasAPIKey = st.EncryptedAPIKey != ""
+	return st, nil
+}
+
+// Delete removes the setting; the organisation reverts to the server default.
+func (s *ProviderSettingsService) Delete(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID) error {
+	st, err := s.Get(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, tenantID); err != nil {
+		return err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, st, "AI provider reset to server default")
+	return nil
+}
+
+// AssistantFor returns the assistant the organisation's requests should use.
+func (s *ProviderSettingsService) AssistantFor(ctx context.Context, tenantID uuid.UUID) llm.Assistant {
+	cfg, ok := s.resolve(ctx, tenantID)
+	if !ok {
+		return s.defaultAssistant
+	}
+	return llm.NewAssistantForProvider(cfg)
+}
+
+// AdvisorFor returns the board-narrative advisor for the organisation.
+func (s *ProviderSettingsService) AdvisorFor(ctx context.Context, tenantID uuid.UUID) llm.Advisor {
+	cfg, ok := s.resolve(ctx, tenantID)
+	if !ok {
+		return s.defaultAdvisor
+	}
+	return llm.NewAdvisorForProvider(cfg)
+}
+
+// resolve loads the organisation's provider config. ok=false means "no setting,
+// use the default"; any failure yields the template config (fail closed).
+func (s *ProviderSettingsService) resolve(ctx context.Context, tenantID uuid.UUID) (llm.ProviderConfig, bool) {
+	closed := llm.ProviderConfig{Provider: llm.ProviderTemplate}
+	st, err := s.repo.Get(ctx, tenantID)
+	if err != nil {
+		return closed, true
+	}
+	if st == nil {
+		return llm.ProviderConfig{}, false
+	}
+	key := ""
+	if st.EncryptedAPIKey != "" {
+		if key, err = s.cipher.DecryptString(st.EncryptedAPIKey); err != nil {
+			return closed, true
+		}
+	}
+	return s.config(st, key), true
+}
+
+func (s *ProviderSettingsService) config(st *domain.AIProviderSetting, apiKey string) llm.ProviderConfig {
+	return llm.ProviderConfig{
+		Provider:   llm.Provider(st.Provider),
+		BaseURL:    st.BaseURL,
+		Model:      st.Model,
+		APIKey:     apiKey,
+		Timeout:    time.Duration(st.TimeoutSeconds) * time.Second,
+		MaxTokens:  st.MaxTokens,
+		OutputMode: llm.OutputMode(st.OutputMode),
+
+		AllowPrivateNetworks: s.allowPrivate,
+	}
+}
+
+func (s *ProviderSettingsService) record(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, action domain.AuditAction, st *domain.AIProviderSetting, summary string) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    actor,
+		Action:     action,
+		EntityType: "ai_provider_setting",
+		EntityID:   st.ID.String(),
+		Summary:    summary,
+		After: domain.JSONMap{
+			"provider":    st.Provider,
+			"base_url":    st.BaseURL,
+			"model":       st.Model,
+			"output_mode": st.OutputMode,
+		},
+	})
+}
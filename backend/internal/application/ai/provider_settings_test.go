@@ -0,0 +1,187 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: LicenseRef-OpenRisk-Commercial
+// This file is part of the OpenRisk Enterprise Edition and is NOT covered by the
+// AGPL; it is licensed under the OpenRisk Commercial License (see LICENSE.commercial).
+
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	llm "github.com/opendefender/openrisk/pkg/ai"
+)
+
+type memProviderRepo struct {
+	rows map[uuid.UUID]*domain.AIProviderSetting
+	err  error
+}
+
+func (m *memProviderRepo) Get(_ context.Context, tenantID uuid.UUID) (*domain.AIProviderSetting, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if r, ok := m.rows[tenantID]; ok {
+		cp := *r
+		return &cp, nil
+	}
+	return nil, nil
+}
+func (m *memProviderRepo) Save(_ context.Context, s *domain.AIProviderSetting) error {
+	cp := *s
+	m.rows[s.TenantID] = &cp
+	return nil
+}
+func (m *memProviderRepo) Delete(_ context.Context, tenantID uuid.UUID) error {
+	delete(m.rows, tenantID)
+	return nil
+}
+
+// prefixCipher "encrypts" by prefixing, and refuses anything it did not write.
+type prefixCipher struct{}
+
+func (prefixCipher) EncryptString(p string) (string, error) { return "enc:" + p, nil }
+func (prefixCipher) DecryptString(c string) (string, error) {
+	if !strings.HasPrefix(c, "enc:") {
+		return "", errors.New("bad ciphertext")
+	}
+	return strings.TrimPrefix(c, "enc:"), nil
+}
+
+func newProviderService() (*ProviderSettingsService, *memProviderRepo) {
+	repo := &memProviderRepo{rows: map[uuid.UUID]*domain.AIProviderSetting{}}
+	// The server default is LLM-backed so fail-closed resolution is observable.
+	def := llm.NewClaudeAssistant("server-key", "")
+	return NewProviderSettingsService(repo, prefixCipher{}, def, nil), repo
+}
+
+func TestProviderSettings_SaveValidatesAndGuardsTheKey(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newProviderService()
+	tenant := uuid.New()
+
+	_, err := svc.Save(ctx, tenant, nil, ProviderInput{Provider: "openai_compatible", BaseURL: "http://vllm:8000/v1"})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("a missing model must be a validation error, got %v", err)
+	}
+	if _, err := svc.Save(ctx, tenant, nil, ProviderInput{Provider: "gpt-cloud"}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("an unknown provider must be a validation error, got %v", err)
+	}
+
+	st, err := svc.Save(ctx, tenant, nil, ProviderInput{
+		Provider: "openai_compatible", BaseURL: "http://vllm:8000/v1/", Model: "qwen2.5-14b-instruct", APIKey: "local-key",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !st.HasAPIKey || st.BaseURL != "http://vllm:8000/v1" {
+		t.Fatalf("unexpected setting: %+v", st)
+	}
+
+	// Same endpoint, new model, blank key: the stored key is kept.
+	if _, err := svc.Save(ctx, tenant, nil, ProviderInput{Provider: "openai_compatible", BaseURL: "http://vllm:8000/v1", Model: "llama3.1:70b"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.rows[tenant].EncryptedAPIKey != "enc:local-key" {
+		t.Fatalf("key should be kept for the same endpoint")
+	}
+
+	// A different endpoint never inherits the key.
+	if _, err := svc.Save(ctx, tenant, nil, ProviderInput{Provider: "openai_compatible", BaseURL: "http://other:8000/v1", Model: "m"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.rows[tenant].EncryptedAPIKey != "" {
+		t.Fatalf("key must not follow a base_url change")
+	}
+
+	// Switching to anthropic without a key is refused rather than reusing one.
+	if _, err := svc.Save(ctx, tenant, nil, ProviderInput{Provider: "anthropic"}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("anthropic without a key must be refused, got %v", err)
+	}
+
+	// An internal address needs the operator's consent.
+	internal := ProviderInput{Provider: "openai_compatible", BaseURL: "http://169.254.169.254/v1", Model: "m"}
+	_, err = svc.Save(ctx, tenant, nil, internal)
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("an internal base_url must be refused, got %v", err)
+	}
+	if !strings.Contains(domain.MessageFromError(err), "AI_ALLOW_PRIVATE_NETWORKS") {
+		t.Fatalf("the refusal must name the operator flag, got %q", domain.MessageFromError(err))
+	}
+	if _, err := svc.AllowPrivateNetworks(true).Save(ctx, tenant, nil, internal); err != nil {
+		t.Fatalf("unexpected error with private networks allowed: %v", err)
+	}
+}
+
+func TestProviderSettings_ResolutionFailsClosed(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newProviderService()
+	tenant := uuid.New()
+
+	if _, ok := svc.AssistantFor(ctx, tenant).(*llm.ClaudeAssistant); !ok {
+		t.Fatalf("no setting should use the server default")
+	}
+
+	if _, err := svc.Save(ctx, tenant, nil, ProviderInput{Provider: "openai_compatible", BaseURL: "http://vllm:8000/v1", Model: "m", APIKey: "k"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := svc.AssistantFor(ctx, tenant).(*llm.OpenAICompatAssistant); !ok {
+		t.Fatalf("expected the organisation's self-hosted assistant")
+	}
+	if _, ok := svc.AdvisorFor(ctx, tenant).(*llm.OpenAICompatAdvisor); !ok {
+		t.Fatalf("expected the organisation's self-hosted advisor")
+	}
+
+	// An undecryptable key must not reroute the organisation to the external default.
+	repo.rows[tenant].EncryptedAPIKey = "garbage"
+	if llm.IsLLMBacked(svc.AssistantFor(ctx, tenant)) {
+		t.Fatalf("decrypt failure must resolve to the template")
+	}
+	repo.err = errors.New("db down")
+	if llm.IsLLMBacked(svc.AssistantFor(ctx, tenant)) {
+		t.Fatalf("a storage error must resolve to the template")
+	}
+}
+
+// The same validation and fallback apply whichever provider answers: a local
+// model that returns off-schema JSON gets the template, a valid reply is used.
+func TestUseCase_FallsBackOnMalformedSelfHostedReply(t *testing.T) {
+	reply := `{"answer":"","sources":[]}` // empty answer violates the schema
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]any{"content": reply}, "finish_reason": "stop"}},
+		})
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	svc, _ := newProviderService()
+	svc.AllowPrivateNetworks(true) // the fake model listens on loopback
+	tenant := uuid.New()
+	if _, err := svc.Save(ctx, tenant, nil, ProviderInput{Provider: "openai_compatible", BaseURL: srv.URL + "/v1", Model: "mistral-7b"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	uc := NewAssistantQueryUseCase(tmpl).WithProviders(svc)
+
+	res, err := uc.Execute(ctx, tenant, QueryInput{Question: "Quels risques sur le SI paie ?"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.GeneratedBy != "template" {
+		t.Fatalf("malformed reply should fall back to the template, got %q", res.GeneratedBy)
+	}
+
+	reply = `{"answer":"Deux risques critiques.","sources":["RSK-1"]}`
+	res, _ = uc.Execute(ctx, tenant, QueryInput{Question: "Quels risques sur le SI paie ?"})
+	if res.GeneratedBy != "mistral-7b" || res.Answer.Answer != "Deux risques critiques." {
+		t.Fatalf("valid reply should be used, got %+v", res)
+	}
+}
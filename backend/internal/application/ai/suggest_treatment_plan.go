@@ -25,6 +25,7 @@ type TreatmentPlanResult struct {
 // linked asset's context.
 type SuggestTreatmentPlanUseCase struct {
 	assistant llm.Assistant
+	providers AssistantSource // optional
 	risks     RiskReader
 	assets    AssetReader // optional
 }
@@ -33,6 +34,13 @@ func NewSuggestTreatmentPlanUseCase(assistant llm.Assistant, risks RiskReader) *
 	return &SuggestTreatmentPlanUseCase{assistant: assistant, risks: risks}
 }
 
+// WithProviders resolves the assistant per organisation instead of always using
+// the one passed to the constructor.
+func (uc *SuggestTreatmentPlanUseCase) WithProviders(p AssistantSource) *SuggestTreatmentPlanUseCase {
+	uc.providers = p
+	return uc
+}
+
 // WithAssetReader enriches the plan with the linked asset's context.
 func (uc *SuggestTreatmentPlanUseCase) WithAssetReader(a AssetReader) *SuggestTreatmentPlanUseCase {
 	uc.assets = a
@@ -73,7 +81,7 @@ func (uc *SuggestTreatmentPlanUseCase) Execute(ctx context.Context, tenantID, ri
 		}
 	}
 
-	plan, generatedBy := invoke(uc.assistant, func(a llm.Assistant) (llm.TreatmentPlan, error) {
+	plan, generatedBy := invoke(assistantFor(ctx, uc.providers, tenantID, uc.assistant), func(a llm.Assistant) (llm.TreatmentPlan, error) {
 		return a.SuggestTreatmentPlan(ctx, rc)
 	})
 	return &TreatmentPlanResult{Plan: plan, GeneratedBy: generatedBy}, nil
@@ -0,0 +1,659 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package appetite is the risk appetite module: the board's appetite statements,
+// the evaluation of the register against them, and the exceptions that let a
+// risk above appetite stay open as a recorded decision rather than an oversight.
+package appetite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/crq"
+)
+
+// RiskLister returns the tenant's active risks with their financial inputs
+// (GormRiskRepository.ListRisksForFinancial).
+type RiskLister interface {
+	ListRisksForFinancial(ctx context.Context, tenantID uuid.UUID) ([]domain.Risk, error)
+}
+
+// RiskVisibility tells which risks the request's access policies let its user
+// see (GormRiskRepository.VisibleRiskIDs). Optional: without it every risk is
+// listed.
+type RiskVisibility interface {
+	VisibleRiskIDs(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error)
+}
+
+// CategoryLookup resolves a risk category, tenant-scoped, so a statement can
+// neither point at another tenant's category nor at nothing.
+type CategoryLookup interface {
+	GetByID(ctx context.Context, id, tenantID uuid.UUID) (*domain.RiskCategory, error)
+	List(ctx context.Context, tenantID uuid.UUID, includeInactive bool) ([]domain.RiskCategory, error)
+}
+
+// ExceptionReader lists approval requests; the service reads the appetite
+// exceptions among them (domain.ApprovalRequestRepository satisfies it).
+type ExceptionReader interface {
+	ListRequests(ctx context.Context, tenantID uuid.UUID, f domain.ApprovalRequestFilter) ([]domain.ApprovalRequest, error)
+}
+
+// ExceptionSubmitter opens an appetite exception in the approval engine. The
+// adapter lives in cmd/server so this package does not import governance.
+// Optional: without it exceptions can be read but not requested.
+type ExceptionSubmitter interface {
+	SubmitAppetiteException(ctx context.Context, tenantID, requesterID, riskID uuid.UUID, title, justification string, payload domain.JSONMap) (*domain.ApprovalRequest, error)
+}
+
+// AuditSink records statement changes in the tamper-evident audit chain. What
+// the board approved, and when it changed, is exactly what an auditor asks for.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// maxExceptionDays caps an exception's validity. An exception without a
+// horizon is an acceptance nobody has to look at again.
+const maxExceptionDays = 366
+
+// Service manages statements, evaluates the register and requests exceptions.
+type Service struct {
+	repo       domain.RiskAppetiteRepository
+	risks      RiskLister
+	categories CategoryLookup
+	exceptions ExceptionReader
+	submitter  ExceptionSubmitter
+	quantifier *crq.Quantifier
+	visibility RiskVisibility
+	audit      AuditSink
+	now        func() time.Time
+}
+
+// NewService builds the service.
+func NewService(repo domain.RiskAppetiteRepository, risks RiskLister, categories CategoryLookup, exceptions ExceptionReader) *Service {
+	return &Service{repo: repo, risks: risks, categories: categories, exceptions: exceptions, now: time.Now}
+}
+
+// WithQuantifier enables the ALE P90 tolerance. Without it that tolerance is
+// reported as not computable rather than as met.
+func (s *Service) WithQuantifier(q *crq.Quantifier) *Service {
+	s.quantifier = q
+	return s
+}
+
+// WithExceptionSubmitter enables POST /risk-appetite/exceptions.
+func (s *Service) WithExceptionSubmitter(e ExceptionSubmitter) *Service {
+	s.submitter = e
+	return s
+}
+
+// WithVisibility applies access policies to the risks an evaluation lists.
+// Tolerances are measured on the whole register either way: a policy hides
+// which risks breach, not whether the organisation is within appetite.
+func (s *Service) WithVisibility(v RiskVisibility) *Service {
+	s.visibility = v
+	return s
+}
+
+// WithAudit attaches the optional audit sink.
+func (s *Service) WithAudit(a AuditSink) *Service {
+	s.audit = a
+	return s
+}
+
+// WithClock overrides the clock (tests).
+func (s *Service) WithClock(now func() time.Time) *Service {
+	s.now = now
+	return s
+}
+
+// StatementInput is the body of POST/PUT /risk-appetite/statements.
+type StatementInput struct {
+	Scope             domain.AppetiteScope `json:"scope"`
+	CategoryID        *uuid.UUID           `json:"category_id"`
+	BusinessUnit      string               `json:"business_unit"`
+	Statement         string               `json:"statement"`
+	MaxScore          *float64             `json:"max_score"`
+	MaxSmartScore     *float64             `json:"max_smart_score"`
+	MaxALEP90XAF      *float64             `json:"max_ale_p90_xaf"`
+	MaxCriticalCount  *int                 `json:"max_critical_count"`
+	ApprovedOn        *time.Time           `json:"approved_on"`
+	ApprovalReference string               `json:"approval_reference"`
+}
+
+// ExceptionInput is the body of POST /risk-appetite/exceptions.
+type ExceptionInput struct {
+	RiskID        uuid.UUID `json:"risk_id"`
+	Justification string    `json:"justification"`
+	ValidUntil    time.Time `json:"valid_until"`
+}
+
+// Evaluation is the register measured against appetite.
+type Evaluation struct {
+	EvaluatedAt time.Time         `json:"evaluated_at"`
+	Statements  []StatementResult `json:"statements"`
+	// Risks lists every risk above an in-force statement, worst first, except
+	// those the user's access policies hide. Summary counts them all.
+	Risks   []RiskResult `json:"risks"`
+	Summary Summary      `json:"summary"`
+}
+
+// StatementResult is one statement's scope measured against its tolerances.
+type StatementResult struct {
+	Statement     domain.RiskAppetiteStatement `json:"statement"`
+	ScopeLabel    string                       `json:"scope_label"`
+	InForce       bool                         `json:"in_force"`
+	RisksInScope  int                          `json:"risks_in_scope"`
+	CriticalCount int                          `json:"critical_count"`
+	// ALEP90XAF is nil when no quantifier is wired or the scope is empty.
+	ALEP90XAF *float64 `json:"ale_p90_xaf"`
+	// Breaches are the portfolio tolerances exceeded.
+	Breaches   []domain.AppetiteBreach `json:"breaches"`
+	RisksAbove int                     `json:"risks_above"`
+	// Breached is true when either the portfolio or any risk in scope is above.
+	Breached bool `json:"breached"`
+}
+
+// ScopedBreach is a tolerance exceeded under a named statement.
+type ScopedBreach struct {
+	StatementID uuid.UUID `json:"statement_id"`
+	ScopeLabel  string    `json:"scope_label"`
+	domain.AppetiteBreach
+}
+
+// RiskResult is one risk above appetite.
+type RiskResult struct {
+	RiskID      uuid.UUID                `json:"risk_id"`
+	Title       string                   `json:"title"`
+	Criticality string                   `json:"criticality"`
+	Score       float64                  `json:"score"`
+	State       domain.RiskState         `json:"lifecycle_state"`
+	Breaches    []ScopedBreach           `json:"breaches"`
+	Exception   domain.AppetiteException `json:"exception"`
+}
+
+// Summary is the headline the dashboard and the board report quote.
+type Summary struct {
+	StatementsInForce int `json:"statements_in_force"`
+	ScopesBreached    int `json:"scopes_breached"`
+	RisksAbove        int `json:"risks_above"`
+	// WithoutException are risks above appetite with no valid exception: the
+	// number that should be zero.
+	WithoutException  int `json:"without_exception"`
+	PendingExceptions int `json:"pending_exceptions"`
+}
+
+// ---------------------------------------------------------------------------
+// Statements
+// ---------------------------------------------------------------------------
+
+func (s *Service) ListStatements(ctx context.Context, tenantID uuid.UUID) ([]domain.RiskAppetiteStatement, error) {
+	rows, err := s.repo.List(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if rows == nil {
+		rows = []domain.RiskAppetiteStatement{}
+	}
+	return rows, nil
+}
+
+// SaveStatement creates (id nil) or replaces a statement.
+func (s *Service) SaveStatement(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id *uuid.UUID, in StatementInput) (*domain.RiskAppetiteStatement, error) {
+	existing, err := s.repo.List(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	st := &domain.RiskAppetiteStatement{ID: uuid.New(), TenantID: tenantID}
+	action := domain.AuditActionCreate
+	if id != nil {
+		cur, err := s.repo.Get(ctx, tenantID, *id)
+		if err != nil {
+			return nil, domain.NewInternalError(err.Error())
+		}
+		if cur == nil {
+			return nil, domain.NewNotFoundError("risk appetite statement", *id)
+		}
+		st = cur
+		action = domain.AuditActionUpdate
+	}
+	st.Scope = in.Scope
+	st.CategoryID = in.CategoryID
+	st.BusinessUnit = in.BusinessUnit
+	st.Statement = in.Statement
+	st.MaxScore, st.MaxSmartScore, st.MaxALEP90XAF, st.MaxCriticalCount = in.MaxScore, in.MaxSmartScore, in.MaxALEP90XAF, in.MaxCriticalCount
+	st.ApprovedOn = in.ApprovedOn
+	st.ApprovalReference = strings.TrimSpace(in.ApprovalReference)
+	st.UpdatedBy = actor
+	if err := st.Validate(); err != nil {
+		return nil, err
+	}
+	if st.ApprovedOn != nil && st.ApprovedOn.After(s.now()) {
+		return nil, domain.NewValidationError("approved_on cannot be in the future")
+	}
+	if st.CategoryID != nil {
+		cat, err := s.categories.GetByID(ctx, *st.CategoryID, tenantID)
+		if err != nil {
+			return nil, domain.NewInternalError(err.Error())
+		}
+		if cat == nil {
+			return nil, domain.NewValidationError("category_id does not name a category of this organisation")
+		}
+	}
+	for i := range existing {
+		if existing[i].ID != st.ID && existing[i].SameScope(st) {
+			return nil, &domain.AppError{Err: domain.ErrConflict, Code: http.StatusConflict,
+				Message: "an appetite statement already covers this scope; edit it instead"}
+		}
+	}
+	if err := s.repo.Save(ctx, st); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	s.record(ctx, tenantID, actor, action, st.ID, "Risk appetite statement saved", domain.JSONMap{
+		"scope":              st.Scope,
+		"category_id":        st.CategoryID,
+		"business_unit":      st.BusinessUnit,
+		"statement":          st.Statement,
+		"max_score":          st.MaxScore,
+		"max_smart_score":    st.MaxSmartScore,
+		"max_ale_p90_xaf":    st.MaxALEP90XAF,
+		"max_critical_count": st.MaxCriticalCount,
+		"approved_on":        st.ApprovedOn,
+		"approval_reference": st.ApprovalReference,
+	})
+	return st, nil
+}
+
+func (s *Service) DeleteStatement(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) error {
+	if err := s.repo.Delete(ctx, tenantID, id); err != nil {
+		return err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, id, "Risk appetite statement deleted", nil)
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Evaluation
+// ---------------------------------------------------------------------------
+
+// Evaluate measures the register against every statement. Draft statements are
+// evaluated for information (their StatementResult is filled) but flag no risk
+// and do not count in the summary: only what the board approved is enforced.
+func (s *Service) Evaluate(ctx context.Context, tenantID uuid.UUID) (*Evaluation, error) {
+	stmts, err := s.repo.List(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	all, err := s.register(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list risks: " + err.Error())
+	}
+	risks := make([]*domain.Risk, 0, len(all))
+	for i := range all {
+		if domain.CountsTowardAppetite(&all[i]) {
+			risks = append(risks, &all[i])
+		}
+	}
+	labels := s.scopeLabels(ctx, tenantID)
+
+	ev := &Evaluation{EvaluatedAt: s.now().UTC(), Statements: make([]StatementResult, 0, len(stmts)), Risks: []RiskResult{}}
+	above := map[uuid.UUID]*RiskResult{}
+	for i := range stmts {
+		st := &stmts[i]
+		label := labels.of(st)
+		res := StatementResult{Statement: *st, ScopeLabel: label, InForce: st.InForce(), Breaches: []domain.AppetiteBreach{}}
+
+		var inScope []*domain.Risk
+		for _, r := range risks {
+			if st.Covers(r) {
+				inScope = append(inScope, r)
+				if domain.IsCriticalRisk(r) {
+					res.CriticalCount++
+				}
+			}
+		}
+		res.RisksInScope = len(inScope)
+		if st.MaxALEP90XAF != nil && s.quantifier != nil && len(inScope) > 0 {
+			p90 := s.portfolioP90(inScope)
+			res.ALEP90XAF = &p90
+		}
+		res.Breaches = append(res.Breaches, st.PortfolioBreaches(res.CriticalCount, res.ALEP90XAF)...)
+		countBreached := st.MaxCriticalCount != nil && res.CriticalCount > *st.MaxCriticalCount
+
+		for _, r := range inScope {
+			breaches := st.RiskBreaches(r)
+			// Over the critical-count tolerance, every critical risk in scope is
+			// part of the excess: none of them is individually innocent.
+			if countBreached && domain.IsCriticalRisk(r) {
+				breaches = append(breaches, domain.AppetiteBreach{Metric: domain.AppetiteMetricCriticalCount,
+					Value: float64(res.CriticalCount), Limit: float64(*st.MaxCriticalCount)})
+			}
+			if len(breaches) == 0 {
+				continue
+			}
+			res.RisksAbove++
+			if !st.InForce() {
+				continue
+			}
+			rr, ok := above[r.ID]
+			if !ok {
+				rr = &RiskResult{RiskID: r.ID, Title: riskTitle(r), Criticality: string(r.Criticality), Score: r.Score, State: r.State()}
+				above[r.ID] = rr
+			}
+			for _, b := range breaches {
+				rr.Breaches = append(rr.Breaches, ScopedBreach{StatementID: st.ID, ScopeLabel: label, AppetiteBreach: b})
+			}
+		}
+		res.Breached = len(res.Breaches) > 0 || res.RisksAbove > 0
+		if st.InForce() {
+			ev.Summary.StatementsInForce++
+			if res.Breached {
+				ev.Summary.ScopesBreached++
+			}
+		}
+		ev.Statements = append(ev.Statements, res)
+	}
+
+	if len(above) > 0 {
+		reqs, err := s.exceptionRequests(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]uuid.UUID, 0, len(above))
+		for id := range above {
+			ids = append(ids, id)
+		}
+		visible, err := s.visible(ctx, tenantID, ids)
+		if err != nil {
+			return nil, err
+		}
+		now := s.now()
+		for _, rr := range above {
+			rr.Exception = domain.ResolveAppetiteException(reqs, rr.RiskID, now)
+			if visible == nil || visible[rr.RiskID] {
+				ev.Risks = append(ev.Risks, *rr)
+			}
+			ev.Summary.RisksAbove++
+			if !rr.Exception.Valid() {
+				ev.Summary.WithoutException++
+			}
+			if rr.Exception.Status == domain.AppetiteExceptionPending {
+				ev.Summary.PendingExceptions++
+			}
+		}
+	}
+	// Worst first: no exception before an exception, then by score.
+	sort.SliceStable(ev.Risks, func(i, j int) bool {
+		vi, vj := ev.Risks[i].Exception.Valid(), ev.Risks[j].Exception.Valid()
+		if vi != vj {
+			return !vi
+		}
+		if ev.Risks[i].Score != ev.Risks[j].Score {
+			return ev.Risks[i].Score > ev.Risks[j].Score
+		}
+		return ev.Risks[i].RiskID.String() < ev.Risks[j].RiskID.String()
+	})
+	return ev, nil
+}
+
+// AppetiteStanding answers the lifecycle guard (risk.AppetiteChecker): is this
+// risk above an in-force statement, and where does its exception stand? It
+// skips the loss simulation — ALE P90 is a portfolio tolerance and flags no
+// single risk.
+func (s *Service) AppetiteStanding(ctx context.Context, tenantID uuid.UUID, r *domain.Risk) (bool, domain.AppetiteException, error) {
+	none := domain.AppetiteException{Status: domain.AppetiteExceptionNone}
+	stmts, err := s.repo.List(ctx, tenantID)
+	if err != nil {
+		return false, none, domain.NewInternalError(err.Error())
+	}
+	var critical map[uuid.UUID]int // statement id → critical count, loaded lazily
+	above := false
+	for i := range stmts {
+		st := &stmts[i]
+		if !st.InForce() || !st.Covers(r) {
+			continue
+		}
+		if len(st.RiskBreaches(r)) > 0 {
+			above = true
+			break
+		}
+		if st.MaxCriticalCount != nil && domain.IsCriticalRisk(r) {
+			if critical == nil {
+				if critical, err = s.criticalCounts(ctx, tenantID, stmts); err != nil {
+					return false, none, err
+				}
+			}
+			if critical[st.ID] > *st.MaxCriticalCount {
+				above = true
+				break
+			}
+		}
+	}
+	if !above {
+		return false, none, nil
+	}
+	reqs, err := s.exceptionRequests(ctx, tenantID)
+	if err != nil {
+		return true, none, err
+	}
+	return true, domain.ResolveAppetiteException(reqs, r.ID, s.now()), nil
+}
+
+// ---------------------------------------------------------------------------
+// Exceptions
+// ---------------------------------------------------------------------------
+
+// RequestException opens an appetite exception for a risk above appetite. It
+// refuses a risk within appetite (there is nothing to except) and a risk that
+// already has a pending or valid exception.
+func (s *Service) RequestException(ctx context.Context, tenantID, requesterID uuid.UUID, in ExceptionInput) (*domain.ApprovalRequest, error) {
+	if s.submitter == nil {
+		return nil, domain.NewInternalError("appetite exceptions are not available: the approval engine is not configured")
+	}
+	in.Justification = strings.TrimSpace(in.Justification)
+	if in.RiskID == uuid.Nil {
+		return nil, domain.NewValidationError("risk_id is required")
+	}
+	if in.Justification == "" {
+		return nil, domain.NewValidationError("justification is required: an exception is a decision, and the approver needs its reasons")
+	}
+	now := s.now()
+	if !in.ValidUntil.After(now) {
+		return nil, domain.NewValidationError("valid_until must be in the future")
+	}
+	if in.ValidUntil.After(now.AddDate(0, 0, maxExceptionDays)) {
+		return nil, domain.NewValidationError(fmt.Sprintf("valid_until cannot be more than %d days ahead", maxExceptionDays))
+	}
+
+	ev, err := s.Evaluate(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	var target *RiskResult
+	for i := range ev.Risks {
+		if ev.Risks[i].RiskID == in.RiskID {
+			target = &ev.Risks[i]
+			break
+		}
+	}
+	if target == nil {
+		if !s.riskExists(ctx, tenantID, in.RiskID) {
+			return nil, domain.NewNotFoundError("risk", in.RiskID)
+		}
+		return nil, domain.NewValidationError("this risk is within appetite: no exception is needed")
+	}
+	switch target.Exception.Status {
+	case domain.AppetiteExceptionPending, domain.AppetiteExceptionApproved:
+		return nil, &domain.AppError{Err: domain.ErrConflict, Code: http.StatusConflict,
+			Message: fmt.Sprintf("this risk already has a %s appetite exception", target.Exception.Status)}
+	}
+
+	breaches := make([]interface{}, 0, len(target.Breaches))
+	for _, b := range target.Breaches {
+		breaches = append(breaches, map[string]interface{}{
+			"statement_id": b.StatementID.String(), "scope": b.ScopeLabel,
+			"metric": string(b.Metric), "value": b.Value, "limit": b.Limit,
+		})
+	}
+	payload := domain.JSONMap{
+		"risk_id":     in.RiskID.String(),
+		"risk_title":  target.Title,
+		"valid_until": in.ValidUntil.UTC().Format(time.RFC3339),
+		"breaches":    breaches,
+	}
+	title := "Appetite exception: " + target.Title
+	return s.submitter.SubmitAppetiteException(ctx, tenantID, requesterID, in.RiskID, title, in.Justification, payload)
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func (s *Service) exceptionRequests(ctx context.Context, tenantID uuid.UUID) ([]domain.ApprovalRequest, error) {
+	if s.exceptions == nil {
+		return nil, nil
+	}
+	reqs, err := s.exceptions.ListRequests(ctx, tenantID, domain.ApprovalRequestFilter{EntityType: domain.AppetiteExceptionEntityType})
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list appetite exceptions: " + err.Error())
+	}
+	return reqs, nil
+}
+
+func (s *Service) criticalCounts(ctx context.Context, tenantID uuid.UUID, stmts []domain.RiskAppetiteStatement) (map[uuid.UUID]int, error) {
+	all, err := s.register(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list risks: " + err.Error())
+	}
+	out := make(map[uuid.UUID]int, len(stmts))
+	for i := range all {
+		r := &all[i]
+		if !domain.CountsTowardAppetite(r) || !domain.IsCriticalRisk(r) {
+			continue
+		}
+		for j := range stmts {
+			if stmts[j].Covers(r) {
+				out[stmts[j].ID]++
+			}
+		}
+	}
+	return out, nil
+}
+
+func (s *Service) riskExists(ctx context.Context, tenantID, riskID uuid.UUID) bool {
+	all, err := s.register(ctx, tenantID)
+	if err != nil {
+		return false
+	}
+	for i := range all {
+		if all[i].ID == riskID {
+			visible, err := s.visible(ctx, tenantID, []uuid.UUID{riskID})
+			return err == nil && (visible == nil || visible[riskID])
+		}
+	}
+	return false
+}
+
+// register lists every risk of the tenant, whatever the user may see:
+// tolerances are measured on the whole register, and visible filters the list.
+func (s *Service) register(ctx context.Context, tenantID uuid.UUID) ([]domain.Risk, error) {
+	return s.risks.ListRisksForFinancial(domain.WithAccessScope(ctx, nil), tenantID)
+}
+
+// visible answers which of the risks the user may see; nil means all of them.
+func (s *Service) visible(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	if s.visibility == nil {
+		return nil, nil
+	}
+	out, err := s.visibility.VisibleRiskIDs(ctx, tenantID, ids)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to apply access policies: " + err.Error())
+	}
+	return out, nil
+}
+
+// portfolioP90 runs one shared Monte Carlo across the scope, with the same
+// quantifier and run parameters as the financial summary so the figures agree.
+func (s *Service) portfolioP90(risks []*domain.Risk) float64 {
+	sims := make([]crq.SimulationInput, 0, len(risks))
+	for _, r := range risks {
+		sims = append(sims, s.quantifier.SimulationInputFor(financialInputs(r), string(r.Criticality)))
+	}
+	return crq.SimulatePortfolio(sims, crq.DefaultIterations, crq.DefaultSeed).P90
+}
+
+// scopeLabeller names a statement's scope for humans: the category's name, or
+// the business unit as written.
+type scopeLabeller map[uuid.UUID]string
+
+func (s *Service) scopeLabels(ctx context.Context, tenantID uuid.UUID) scopeLabeller {
+	out := scopeLabeller{}
+	if s.categories == nil {
+		return out
+	}
+	cats, err := s.categories.List(ctx, tenantID, true)
+	if err != nil {
+		return out
+	}
+	for _, c := range cats {
+		out[c.ID] = c.Name
+	}
+	return out
+}
+
+func (l scopeLabeller) of(st *domain.RiskAppetiteStatement) string {
+	if st.Scope == domain.AppetiteScopeBusinessUnit {
+		return st.BusinessUnit
+	}
+	if st.CategoryID != nil {
+		if name, ok := l[*st.CategoryID]; ok {
+			return name
+		}
+	}
+	return "?"
+}
+
+func financialInputs(r *domain.Risk) crq.FinancialInputs {
+	return crq.FinancialInputs{
+		SLEXAF:                  r.SLEXAF,
+		ARO:                     r.ARO,
+		DowntimeHours:           r.DowntimeHours,
+		HourlyDowntimeCostXAF:   r.HourlyDowntimeCostXAF,
+		DataLossCostXAF:         r.DataLossCostXAF,
+		FinesXAF:                r.FinesXAF,
+		OtherDirectCostXAF:      r.OtherDirectCostXAF,
+		RemediationCostXAF:      r.RemediationCostXAF,
+		MitigationEffectiveness: r.MitigationEffectiveness,
+	}
+}
+
+func riskTitle(r *domain.Risk) string {
+	if strings.TrimSpace(r.Title) != "" {
+		return r.Title
+	}
+	return r.Name
+}
+
+func (s *Service) record(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, action domain.AuditAction, id uuid.UUID, summary string, after domain.JSONMap) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    actor,
+		Action:     action,
+		EntityType: "risk_appetite_statement",
+		EntityID:   id.String(),
+		Summary:    summary,
+		After:      after,
+	})
+}
@@ -0,0 +1,312 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package appetite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/crq"
+)
+
+type memStatements struct {
+	rows map[uuid.UUID]domain.RiskAppetiteStatement
+}
+
+func (m *memStatements) List(_ context.Context, tenantID uuid.UUID) ([]domain.RiskAppetiteStatement, error) {
+	var out []domain.RiskAppetiteStatement
+	for _, s := range m.rows {
+		if s.TenantID == tenantID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+func (m *memStatements) Get(_ context.Context, tenantID, id uuid.UUID) (*domain.RiskAppetiteStatement, error) {
+	if s, ok := m.rows[id]; ok && s.TenantID == tenantID {
+		return &s, nil
+	}
+	return nil, nil
+}
+func (m *memStatements) Save(_ context.Context, s *domain.RiskAppetiteStatement) error {
+	m.rows[s.ID] = *s
+	return nil
+}
+func (m *memStatements) Delete(_ context.Context, _, id uuid.UUID) error {
+	delete(m.rows, id)
+	return nil
+}
+
+type memRisks struct{ rows []domain.Risk }
+
+func (m *memRisks) ListRisksForFinancial(_ context.Context, tenantID uuid.UUID) ([]domain.Risk, error) {
+	var out []domain.Risk
+	for _, r := range m.rows {
+		if r.TenantID == tenantID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// memVisibility hides the listed risks, as a deny policy would.
+type memVisibility struct{ hidden map[uuid.UUID]bool }
+
+func (m *memVisibility) VisibleRiskIDs(_ context.Context, _ uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	out := map[uuid.UUID]bool{}
+	for _, id := range ids {
+		out[id] = !m.hidden[id]
+	}
+	return out, nil
+}
+
+type memCategories struct{ rows []domain.RiskCategory }
+
+func (m *memCategories) GetByID(_ context.Context, id, tenantID uuid.UUID) (*domain.RiskCategory, error) {
+	for _, c := range m.rows {
+		if c.ID == id && c.TenantID == tenantID {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+func (m *memCategories) List(_ context.Context, tenantID uuid.UUID, _ bool) ([]domain.RiskCategory, error) {
+	return m.rows, nil
+}
+
+type memRequests struct{ rows []domain.ApprovalRequest }
+
+func (m *memRequests) ListRequests(_ context.Context, _ uuid.UUID, f domain.ApprovalRequestFilter) ([]domain.ApprovalRequest, error) {
+	var out []domain.ApprovalRequest
+	for _, r := range m.rows {
+		if f.EntityType == "" || r.EntityType == f.EntityType {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// memSubmitter files the request straight into memRequests, as the approval
+// engine would.
+type memSubmitter struct{ reqs *memRequests }
+
+func (m *memSubmitter) SubmitAppetiteException(_ context.Context, tenantID, requesterID, riskID uuid.UUID, title, justification string, payload domain.JSONMap) (*domain.ApprovalRequest, error) {
+	r := domain.ApprovalRequest{
+		ID: uuid.New(), TenantID: tenantID, RequestedBy: requesterID, Title: title, Description: justification,
+		EntityType: domain.AppetiteExceptionEntityType, EntityID: riskID.String(), Action: domain.AppetiteExceptionAction,
+		Status: domain.ApprovalPending, Payload: payload,
+	}
+	m.reqs.rows = append(m.reqs.rows, r)
+	return &r, nil
+}
+
+type register struct {
+	tenant       uuid.UUID
+	cyber        domain.RiskCategory
+	svc          *Service
+	repo         *memStatements
+	reqs         *memRequests
+	r1, r2, r3   domain.Risk
+	now          time.Time
+	retailID     uuid.UUID
+	draftCyberID uuid.UUID
+}
+
+// Retail (approved): score ≤ 10, at most one critical risk.
+// Cyber (draft): score ≤ 1 — evaluated, never enforced.
+//
+//	r1 Retail/cyber  score 14 CRITICAL  → score + critical count
+//	r2 Retail        score 5  CRITICAL  → critical count
+//	r3 Retail        score 25 closed    → not exposure
+//	r4 Wholesale     score 9            → within (draft cyber does not count)
+func newRegister(t *testing.T) *register {
+	t.Helper()
+	e := &register{tenant: uuid.New(), repo: &memStatements{rows: map[uuid.UUID]domain.RiskAppetiteStatement{}}, reqs: &memRequests{},
+		now: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)}
+	e.cyber = domain.RiskCategory{ID: uuid.New(), TenantID: e.tenant, Name: "Cyber"}
+	mk := func(bu string, cat *uuid.UUID, score float64, crit domain.CriticalityLevel, state domain.RiskState) domain.Risk {
+		r := domain.Risk{ID: uuid.New(), TenantID: e.tenant, Title: bu + "-risk", BusinessUnit: bu, CategoryID: cat, Score: score, Criticality: crit}
+		r.SetState(state)
+		return r
+	}
+	e.r1 = mk("Retail", &e.cyber.ID, 14, domain.RiskCriticalityCritical, domain.StateInTreatment)
+	e.r2 = mk("retail", nil, 5, domain.RiskCriticalityCritical, domain.StateAssessed)
+	e.r3 = mk("Retail", nil, 25, domain.RiskCriticalityCritical, domain.StateClosed)
+	r4 := mk("Wholesale", &e.cyber.ID, 9, domain.RiskCriticalityHigh, domain.StateAssessed)
+	e.svc = NewService(e.repo, &memRisks{rows: []domain.Risk{e.r1, e.r2, e.r3, r4}}, &memCategories{rows: []domain.RiskCategory{e.cyber}}, e.reqs).
+		WithExceptionSubmitter(&memSubmitter{reqs: e.reqs}).
+		WithQuantifier(crq.NewQuantifier(crq.DefaultXAFPerUSD, crq.DefaultReference())).
+		WithClock(func() time.Time { return e.now })
+
+	ctx := context.Background()
+	approved := e.now.AddDate(0, -1, 0)
+	one := 1
+	st, err := e.svc.SaveStatement(ctx, e.tenant, nil, nil, StatementInput{
+		Scope: domain.AppetiteScopeBusinessUnit, BusinessUnit: "Retail", Statement: "No critical retail exposure beyond one.",
+		MaxScore: f(10), MaxCriticalCount: &one, ApprovedOn: &approved, ApprovalReference: "BOARD-2026-09",
+	})
+	require.NoError(t, err)
+	e.retailID = st.ID
+	st, err = e.svc.SaveStatement(ctx, e.tenant, nil, nil, StatementInput{
+		Scope: domain.AppetiteScopeCategory, CategoryID: &e.cyber.ID, Statement: "Near-zero cyber appetite.", MaxScore: f(1),
+	})
+	require.NoError(t, err)
+	e.draftCyberID = st.ID
+	return e
+}
+
+func f(v float64) *float64 { return &v }
+
+func TestSaveStatement_ValidatesScopeAndUniqueness(t *testing.T) {
+	ctx := context.Background()
+	e := newRegister(t)
+
+	_, err := e.svc.SaveStatement(ctx, e.tenant, nil, nil, StatementInput{
+		Scope: domain.AppetiteScopeBusinessUnit, BusinessUnit: " RETAIL ", Statement: "again", MaxScore: f(5),
+	})
+	assert.True(t, errors.Is(err, domain.ErrConflict), "one statement per scope, whatever the case, got %v", err)
+
+	stranger := uuid.New()
+	_, err = e.svc.SaveStatement(ctx, e.tenant, nil, nil, StatementInput{
+		Scope: domain.AppetiteScopeCategory, CategoryID: &stranger, Statement: "x", MaxScore: f(5),
+	})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "another tenant's category must be refused, got %v", err)
+
+	future := e.now.AddDate(0, 0, 1)
+	_, err = e.svc.SaveStatement(ctx, e.tenant, nil, nil, StatementInput{
+		Scope: domain.AppetiteScopeBusinessUnit, BusinessUnit: "Treasury", Statement: "x", MaxScore: f(5), ApprovedOn: &future,
+	})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "the board cannot have approved it tomorrow, got %v", err)
+
+	// Editing a statement keeps its scope without tripping over itself.
+	id := e.retailID
+	_, err = e.svc.SaveStatement(ctx, e.tenant, nil, &id, StatementInput{
+		Scope: domain.AppetiteScopeBusinessUnit, BusinessUnit: "Retail", Statement: "Reworded.", MaxScore: f(12),
+	})
+	require.NoError(t, err)
+	_, err = e.svc.SaveStatement(ctx, uuid.New(), nil, &id, StatementInput{
+		Scope: domain.AppetiteScopeBusinessUnit, BusinessUnit: "Retail", Statement: "x", MaxScore: f(1),
+	})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "another tenant's statement is a 404, got %v", err)
+}
+
+func TestEvaluate_FlagsRisksAndPortfoliosAboveInForceAppetite(t *testing.T) {
+	ctx := context.Background()
+	e := newRegister(t)
+
+	ev, err := e.svc.Evaluate(ctx, e.tenant)
+	require.NoError(t, err)
+	byID := map[uuid.UUID]StatementResult{}
+	for _, s := range ev.Statements {
+		byID[s.Statement.ID] = s
+	}
+	retail := byID[e.retailID]
+	assert.True(t, retail.InForce)
+	assert.Equal(t, 2, retail.RisksInScope, "the closed risk is not exposure")
+	assert.Equal(t, 2, retail.CriticalCount)
+	require.Len(t, retail.Breaches, 1)
+	assert.Equal(t, domain.AppetiteMetricCriticalCount, retail.Breaches[0].Metric)
+	assert.Equal(t, 2, retail.RisksAbove)
+
+	draft := byID[e.draftCyberID]
+	assert.False(t, draft.InForce)
+	assert.Equal(t, "Cyber", draft.ScopeLabel)
+	assert.Equal(t, 2, draft.RisksAbove, "a draft is still evaluated for information")
+
+	require.Len(t, ev.Risks, 2, "only in-force statements flag risks")
+	assert.Equal(t, e.r1.ID, ev.Risks[0].RiskID, "worst score first")
+	assert.Len(t, ev.Risks[0].Breaches, 2, "score and critical count")
+	assert.Equal(t, Summary{StatementsInForce: 1, ScopesBreached: 1, RisksAbove: 2, WithoutException: 2}, ev.Summary)
+
+	// An approved exception moves r1 behind the one nobody decided on.
+	e.reqs.rows = append(e.reqs.rows, domain.ApprovalRequest{
+		ID: uuid.New(), EntityType: domain.AppetiteExceptionEntityType, EntityID: e.r1.ID.String(), Status: domain.ApprovalApproved,
+		Payload: domain.JSONMap{"valid_until": e.now.AddDate(0, 3, 0).Format(time.RFC3339)},
+	})
+	ev, err = e.svc.Evaluate(ctx, e.tenant)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ev.Summary.WithoutException)
+	assert.Equal(t, e.r2.ID, ev.Risks[0].RiskID)
+	assert.True(t, ev.Risks[1].Exception.Valid())
+
+	// The ALE tolerance is a portfolio property.
+	id := e.retailID
+	approved := e.now.AddDate(0, -1, 0)
+	_, err = e.svc.SaveStatement(ctx, e.tenant, nil, &id, StatementInput{
+		Scope: domain.AppetiteScopeBusinessUnit, BusinessUnit: "Retail", Statement: "x", MaxALEP90XAF: f(1), ApprovedOn: &approved,
+	})
+	require.NoError(t, err)
+	ev, err = e.svc.Evaluate(ctx, e.tenant)
+	require.NoError(t, err)
+	for _, s := range ev.Statements {
+		if s.Statement.ID == e.retailID {
+			require.NotNil(t, s.ALEP90XAF)
+			require.Len(t, s.Breaches, 1)
+			assert.Equal(t, domain.AppetiteMetricALEP90, s.Breaches[0].Metric)
+			assert.Zero(t, s.RisksAbove, "no single risk is above a portfolio loss tolerance")
+		}
+	}
+}
+
+func TestEvaluate_ListsOnlyVisibleRisksAndCountsThemAll(t *testing.T) {
+	ctx := context.Background()
+	e := newRegister(t)
+	e.svc.WithVisibility(&memVisibility{hidden: map[uuid.UUID]bool{e.r1.ID: true}})
+
+	ev, err := e.svc.Evaluate(ctx, e.tenant)
+	require.NoError(t, err)
+	require.Len(t, ev.Risks, 1, "a hidden risk is not listed")
+	assert.Equal(t, e.r2.ID, ev.Risks[0].RiskID)
+	assert.Equal(t, Summary{StatementsInForce: 1, ScopesBreached: 1, RisksAbove: 2, WithoutException: 2}, ev.Summary,
+		"the tolerances and counts still cover the whole register")
+
+	_, err = e.svc.RequestException(ctx, e.tenant, uuid.New(), ExceptionInput{
+		RiskID: e.r1.ID, Justification: "compensating controls", ValidUntil: e.now.AddDate(0, 1, 0)})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "no exception on a risk the user cannot see, got %v", err)
+}
+
+func TestRequestException_AndLifecycleStanding(t *testing.T) {
+	ctx := context.Background()
+	e := newRegister(t)
+	requester := uuid.New()
+	until := e.now.AddDate(0, 6, 0)
+
+	_, err := e.svc.RequestException(ctx, e.tenant, requester, ExceptionInput{RiskID: e.r1.ID, ValidUntil: until})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "a justification is required, got %v", err)
+	_, err = e.svc.RequestException(ctx, e.tenant, requester, ExceptionInput{RiskID: e.r1.ID, Justification: "x", ValidUntil: e.now.AddDate(2, 0, 0)})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "an exception has a horizon, got %v", err)
+	_, err = e.svc.RequestException(ctx, e.tenant, requester, ExceptionInput{RiskID: uuid.New(), Justification: "x", ValidUntil: until})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "unknown risk, got %v", err)
+
+	above, exc, err := e.svc.AppetiteStanding(ctx, e.tenant, &e.r2)
+	require.NoError(t, err)
+	assert.True(t, above, "a critical risk in a scope over its critical count is above appetite")
+	assert.Equal(t, domain.AppetiteExceptionNone, exc.Status)
+
+	req, err := e.svc.RequestException(ctx, e.tenant, requester, ExceptionInput{RiskID: e.r2.ID, Justification: "Compensating monitoring until the Q1 migration.", ValidUntil: until})
+	require.NoError(t, err)
+	assert.Equal(t, until.Format(time.RFC3339), req.Payload["valid_until"])
+	assert.NotEmpty(t, req.Payload["breaches"])
+
+	_, err = e.svc.RequestException(ctx, e.tenant, requester, ExceptionInput{RiskID: e.r2.ID, Justification: "again", ValidUntil: until})
+	assert.True(t, errors.Is(err, domain.ErrConflict), "one exception in flight per risk, got %v", err)
+	_, exc, err = e.svc.AppetiteStanding(ctx, e.tenant, &e.r2)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AppetiteExceptionPending, exc.Status)
+
+	// Within appetite: nothing to except, and the guard is transparent.
+	r4 := domain.Risk{ID: uuid.New(), TenantID: e.tenant, BusinessUnit: "Wholesale", Score: 9}
+	above, _, err = e.svc.AppetiteStanding(ctx, e.tenant, &r4)
+	require.NoError(t, err)
+	assert.False(t, above)
+}
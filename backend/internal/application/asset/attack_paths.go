@@ -0,0 +1,60 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package asset
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// ExploitabilitySource summarises open vulnerabilities per asset. Unlike the
+// topology's VulnCounter it is NOT optional: without it every hop costs the
+// same and the analysis collapses back into the unweighted compromise chain,
+// which would be returned under a name that promises more.
+type ExploitabilitySource interface {
+	ExploitabilityByAsset(ctx context.Context, tenantID uuid.UUID) (map[uuid.UUID]domain.AssetExploitability, error)
+}
+
+// GetAttackPathsUseCase ranks the cheapest routes from the internet to the
+// tenant's crown jewels.
+type GetAttackPathsUseCase struct {
+	assets  domain.AssetRepository
+	deps    domain.AssetDependencyRepository
+	exploit ExploitabilitySource
+}
+
+func NewGetAttackPathsUseCase(assets domain.AssetRepository, deps domain.AssetDependencyRepository, exploit ExploitabilitySource) *GetAttackPathsUseCase {
+	return &GetAttackPathsUseCase{assets: assets, deps: deps, exploit: exploit}
+}
+
+// Execute returns the top-k paths and their choke points. k and maxHops of 0
+// take the domain defaults; out-of-range values are clamped there.
+func (uc *GetAttackPathsUseCase) Execute(ctx context.Context, tenantID uuid.UUID, k, maxHops int) (*domain.AttackPathAnalysis, error) {
+	if tenantID == uuid.Nil {
+		return nil, domain.NewForbiddenError("missing tenant context")
+	}
+	if maxHops > 2*domain.DefaultAttackPathMaxHops {
+		return nil, domain.NewValidationError("max_hops must not exceed 16")
+	}
+
+	assets, err := uc.assets.List(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to load assets: " + err.Error())
+	}
+	deps, err := uc.deps.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to load dependencies: " + err.Error())
+	}
+	exploit, err := uc.exploit.ExploitabilityByAsset(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to load vulnerability signals: " + err.Error())
+	}
+
+	analysis := domain.BuildAttackPaths(assets, deps, exploit, domain.AttackPathOptions{K: k, MaxHops: maxHops})
+	return &analysis, nil
+}
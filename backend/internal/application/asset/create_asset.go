@@ -7,6 +7,7 @@ package asset
 
 import (
 	"context"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/opendefender/openrisk/internal/domain"
@@ -18,6 +19,8 @@ type CreateAssetInput struct {
 	Type        string
 	Criticality domain.AssetCriticality
 	Owner       string
+	// BusinessUnit is the part of the organisation the asset belongs to.
+	BusinessUnit string
 	// Category selects the typed attribute schema. Optional: an asset created
 	// without one is untyped and simply has no attributes (that is the state
 	// every pre-existing asset is in).
@@ -98,15 +101,16 @@ func (uc *CreateAssetUseCase) Execute(ctx context.Context, tenantID uuid.UUID, i
 	}
 
 	assetEntity := &domain.Asset{
-		ID:          uuid.New(),
-		TenantID:    tenantID,
-		Name:        input.Name,
-		Type:        input.Type,
-		Criticality: criticality,
-		Owner:       input.Owner,
-		Source:      "MANUAL",
-		Category:    category,
-		Attributes:  attrs,
+		ID:           uuid.New(),
+		TenantID:     tenantID,
+		Name:         input.Name,
+		Type:         input.Type,
+		Criticality:  criticality,
+		Owner:        input.Owner,
+		BusinessUnit: strings.TrimSpace(input.BusinessUnit),
+		Source:       "MANUAL",
+		Category:     category,
+		Attributes:   attrs,
 	}
 	assetEntity.RefreshFingerprints(defs)
 
@@ -7,6 +7,7 @@ package asset
 
 import (
 	"context"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/opendefender/openrisk/internal/domain"
@@ -18,6 +19,8 @@ type UpdateAssetInput struct {
 	Type        *string
 	Criticality *domain.AssetCriticality
 	Owner       *string
+	// BusinessUnit: nil leaves it alone, "" clears it.
+	BusinessUnit *string
 	// Category re-types the asset. Changing it re-validates the attribute bag
 	// against the NEW schema — an asset moved from Server to Vendor cannot keep
 	// its firmware version.
@@ -100,6 +103,9 @@ func (uc *UpdateAssetUseCase) Execute(ctx context.Context, tenantID uuid.UUID, a
 	if input.Owner != nil {
 		existing.Owner = *input.Owner
 	}
+	if input.BusinessUnit != nil {
+		existing.BusinessUnit = strings.TrimSpace(*input.BusinessUnit)
+	}
 	if input.Criticality != nil && *input.Criticality != oldCriticality {
 		existing.Criticality = *input.Criticality
 		criticalityChanged = true
@@ -540,6 +540,11 @@ func sampleContext(trigger domain.AutomationTrigger, tenantID uuid.UUID) Trigger
 		tc.Ref = "sample:risk"
 		tc.Subject = "Sample critical risk"
 		tc.Title = "Sample critical risk"
+	case domain.TriggerKRIThresholdCrossed:
+		tc.Ref = "sample:kri"
+		tc.Subject = "Sample KRI moved from amber to red"
+		tc.Title = "Sample KRI moved from amber to red"
+		tc.Severity = domain.KRIStatusRed.Severity()
 	case domain.TriggerIncidentCreated:
 		tc.Ref = "sample:incident"
 		tc.Subject = "Sample critical incident"
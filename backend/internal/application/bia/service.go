@@ -0,0 +1,393 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package bia is the business impact analysis module: the recovery objectives
+// of business processes, the recovery capabilities of what they run on, the
+// propagation that checks the one against the other, and the feed of the
+// resulting downtime into the CRQ inputs of the processes' risks.
+package bia
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// DowntimeSink writes the BIA-derived downtime onto the risks of a process
+// asset (GormRiskRepository.ApplyBIADowntime). Optional: without it the report
+// still shows the expected downtime, it just is not fed to the CRQ.
+type DowntimeSink interface {
+	ApplyBIADowntime(ctx context.Context, tenantID, assetID uuid.UUID, hours *float64) (int64, error)
+}
+
+// AuditSink records BIA changes in the tamper-evident audit chain. Recovery
+// objectives are what a continuity auditor asks to see the history of.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// Service manages analyses and capabilities and runs the propagation.
+type Service struct {
+	repo     domain.BIARepository
+	assets   domain.AssetRepository
+	deps     domain.AssetDependencyRepository
+	downtime DowntimeSink
+	audit    AuditSink
+	now      func() time.Time
+}
+
+// NewService builds the service.
+func NewService(repo domain.BIARepository, assets domain.AssetRepository, deps domain.AssetDependencyRepository) *Service {
+	return &Service{repo: repo, assets: assets, deps: deps, now: time.Now}
+}
+
+// WithDowntimeSink feeds expected downtime into the CRQ inputs. Optional.
+func (s *Service) WithDowntimeSink(d DowntimeSink) *Service {
+	s.downtime = d
+	return s
+}
+
+// WithAudit attaches the optional audit sink.
+func (s *Service) WithAudit(a AuditSink) *Service {
+	s.audit = a
+	return s
+}
+
+// AnalysisInput is the body of PUT /bia/processes/:assetId.
+type AnalysisInput struct {
+	MTPDHours   float64            `json:"mtpd_hours"`
+	RTOHours    float64            `json:"rto_hours"`
+	RPOHours    float64            `json:"rpo_hours"`
+	ImpactCurve domain.ImpactCurve `json:"impact_curve"`
+	Owner       string             `json:"owner"`
+	Notes       string             `json:"notes"`
+}
+
+// CapabilityInput is the body of PUT /bia/capabilities/:assetId.
+type CapabilityInput struct {
+	RTCHours     *float64   `json:"rtc_hours"`
+	RPCHours     *float64   `json:"rpc_hours"`
+	Basis        string     `json:"basis"`
+	LastTestedAt *time.Time `json:"last_tested_at"`
+	Notes        string     `json:"notes"`
+}
+
+// Propagation is the estate-wide view: every supporting asset, the strictest
+// objectives it inherits, and whether it meets them.
+type Propagation struct {
+	Processes    int                          `json:"processes"`
+	Requirements []domain.RecoveryRequirement `json:"requirements"`
+	Gaps         int                          `json:"gaps"`
+	Undeclared   int                          `json:"undeclared"`
+}
+
+// SyncResult reports what a CRQ feed run changed.
+type SyncResult struct {
+	Processes    int   `json:"processes"`
+	RisksUpdated int64 `json:"risks_updated"`
+}
+
+func (s *Service) ListAnalyses(ctx context.Context, tenantID uuid.UUID) ([]domain.BusinessImpactAnalysis, error) {
+	rows, err := s.repo.ListAnalyses(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if rows == nil {
+		rows = []domain.BusinessImpactAnalysis{}
+	}
+	return rows, nil
+}
+
+func (s *Service) GetAnalysis(ctx context.Context, tenantID, assetID uuid.UUID) (*domain.BusinessImpactAnalysis, error) {
+	b, err := s.repo.GetAnalysis(ctx, tenantID, assetID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if b == nil {
+		return nil, domain.NewNotFoundError("business impact analysis", assetID)
+	}
+	return b, nil
+}
+
+// SaveAnalysis creates or replaces a process's BIA, then refreshes the CRQ
+// feed. The asset is loaded tenant-scoped first, so another tenant's asset id
+// is a 404 and never gets an analysis attached.
+func (s *Service) SaveAnalysis(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, assetID uuid.UUID, in AnalysisInput) (*domain.BusinessImpactAnalysis, error) {
+	asset, err := s.requireAsset(ctx, tenantID, assetID)
+	if err != nil {
+		return nil, err
+	}
+	// Objectives belong to the process, not to the systems that support it —
+	// those get a capability, and inherit their targets through propagation.
+	if asset.Category != domain.CategoryProcess {
+		return nil, domain.NewValidationError(fmt.Sprintf("a BIA applies to %s assets; %s is %s — declare its recovery capability instead", domain.CategoryProcess, asset.Name, asset.Category))
+	}
+	existing, err := s.repo.GetAnalysis(ctx, tenantID, assetID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	now := s.now()
+	b := &domain.BusinessImpactAnalysis{ID: uuid.New(), TenantID: tenantID, AssetID: assetID, CreatedAt: now}
+	if existing != nil {
+		b = existing
+	}
+	b.MTPDHours, b.RTOHours, b.RPOHours = in.MTPDHours, in.RTOHours, in.RPOHours
+	b.ImpactCurve = in.ImpactCurve
+	if b.ImpactCurve == nil {
+		b.ImpactCurve = domain.ImpactCurve{}
+	}
+	b.Owner, b.Notes = strings.TrimSpace(in.Owner), strings.TrimSpace(in.Notes)
+	b.UpdatedBy, b.UpdatedAt = actor, now
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveAnalysis(ctx, b); err != nil {
+		return nil, domain.NewInternalError("failed to save business impact analysis: " + err.Error())
+	}
+
+	action := domain.AuditActionUpdate
+	if existing == nil {
+		action = domain.AuditActionCreate
+	}
+	s.record(ctx, tenantID, actor, action, "business_impact_analysis", b.ID,
+		fmt.Sprintf("BIA of %s: RTO %gh, RPO %gh, MTPD %gh", asset.Name, b.RTOHours, b.RPOHours, b.MTPDHours),
+		domain.JSONMap{"asset_id": assetID.String(), "mtpd_hours": b.MTPDHours, "rto_hours": b.RTOHours, "rpo_hours": b.RPOHours})
+	s.syncAfterChange(ctx, tenantID)
+	return b, nil
+}
+
+// DeleteAnalysis removes a process's BIA and withdraws the downtime it fed.
+func (s *Service) DeleteAnalysis(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, assetID uuid.UUID) error {
+	b, err := s.GetAnalysis(ctx, tenantID, assetID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteAnalysis(ctx, tenantID, assetID); err != nil {
+		return err
+	}
+	if s.downtime != nil {
+		if _, err := s.downtime.ApplyBIADowntime(ctx, tenantID, assetID, nil); err != nil {
+			log.Printf("bia: clearing downtime of %s: %v", assetID, err)
+		}
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, "business_impact_analysis", b.ID,
+		"BIA deleted", domain.JSONMap{"asset_id": assetID.String()})
+	s.syncAfterChange(ctx, tenantID)
+	return nil
+}
+
+func (s *Service) ListCapabilities(ctx context.Context, tenantID uuid.UUID) ([]domain.RecoveryCapability, error) {
+	rows, err := s.repo.ListCapabilities(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if rows == nil {
+		rows = []domain.RecoveryCapability{}
+	}
+	return rows, nil
+}
+
+// SaveCapability declares what a supporting asset can deliver. Every process
+// standing on it may change verdict, so the CRQ feed is refreshed.
+func (s *Service) SaveCapability(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, assetID uuid.UUID, in CapabilityInput) (*domain.RecoveryCapability, error) {
+	asset, err := s.requireAsset(ctx, tenantID, assetID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.GetCapability(ctx, tenantID, assetID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	now := s.now()
+	c := &domain.RecoveryCapability{ID: uuid.New(), TenantID: tenantID, AssetID: assetID, CreatedAt: now}
+	if existing != nil {
+		c = existing
+	}
+	c.RTCHours, c.RPCHours = in.RTCHours, in.RPCHours
+	c.Basis = domain.RecoveryBasis(strings.ToLower(strings.TrimSpace(in.Basis)))
+	c.LastTestedAt, c.Notes = in.LastTestedAt, strings.TrimSpace(in.Notes)
+	c.UpdatedBy, c.UpdatedAt = actor, now
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveCapability(ctx, c); err != nil {
+		return nil, domain.NewInternalError("failed to save recovery capability: " + err.Error())
+	}
+
+	action := domain.AuditActionUpdate
+	if existing == nil {
+		action = domain.AuditActionCreate
+	}
+	after := domain.JSONMap{"asset_id": assetID.String(), "basis": string(c.Basis)}
+	if c.RTCHours != nil {
+		after["rtc_hours"] = *c.RTCHours
+	}
+	if c.RPCHours != nil {
+		after["rpc_hours"] = *c.RPCHours
+	}
+	s.record(ctx, tenantID, actor, action, "recovery_capability", c.ID, "Recovery capability of "+asset.Name, after)
+	s.syncAfterChange(ctx, tenantID)
+	return c, nil
+}
+
+func (s *Service) DeleteCapability(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, assetID uuid.UUID) error {
+	c, err := s.repo.GetCapability(ctx, tenantID, assetID)
+	if err != nil {
+		return domain.NewInternalError(err.Error())
+	}
+	if c == nil {
+		return domain.NewNotFoundError("recovery capability", assetID)
+	}
+	if err := s.repo.DeleteCapability(ctx, tenantID, assetID); err != nil {
+		return err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, "recovery_capability", c.ID,
+		"Recovery capability deleted", domain.JSONMap{"asset_id": assetID.String()})
+	s.syncAfterChange(ctx, tenantID)
+	return nil
+}
+
+// Report builds the BIA report of one process.
+func (s *Service) Report(ctx context.Context, tenantID, assetID uuid.UUID) (*domain.BIAReport, error) {
+	b, err := s.GetAnalysis(ctx, tenantID, assetID)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.load(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	rep := domain.BuildBIAReport(*b, g.names[assetID], g.deps, g.caps, g.names)
+	return &rep, nil
+}
+
+// Propagation runs the estate-wide check over every process at once.
+func (s *Service) Propagation(ctx context.Context, tenantID uuid.UUID) (*Propagation, error) {
+	analyses, err := s.ListAnalyses(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.load(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	out := &Propagation{
+		Processes:    len(analyses),
+		Requirements: domain.PropagateRecovery(analyses, g.deps, g.caps, g.names),
+	}
+	for _, r := range out.Requirements {
+		switch r.Status {
+		case domain.RecoveryGap:
+			out.Gaps++
+		case domain.RecoveryUndeclared:
+			out.Undeclared++
+		}
+	}
+	return out, nil
+}
+
+// Sync feeds every process's expected downtime into the DowntimeHours CRQ
+// input of the risks on that process. It runs after every analysis or
+// capability change; POST /bia/sync is there for dependency edits, which this
+// module does not observe.
+func (s *Service) Sync(ctx context.Context, tenantID uuid.UUID) (*SyncResult, error) {
+	analyses, err := s.ListAnalyses(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	res := &SyncResult{Processes: len(analyses)}
+	if s.downtime == nil || len(analyses) == 0 {
+		return res, nil
+	}
+	g, err := s.load(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range analyses {
+		rep := domain.BuildBIAReport(b, g.names[b.AssetID], g.deps, g.caps, g.names)
+		hours := rep.ExpectedDowntimeHours
+		n, err := s.downtime.ApplyBIADowntime(ctx, tenantID, b.AssetID, &hours)
+		if err != nil {
+			return nil, domain.NewInternalError(err.Error())
+		}
+		res.RisksUpdated += n
+	}
+	return res, nil
+}
+
+// syncAfterChange refreshes the CRQ feed after a write. A failure is logged,
+// not returned: the write itself succeeded, and POST /bia/sync retries.
+func (s *Service) syncAfterChange(ctx context.Context, tenantID uuid.UUID) {
+	if s.downtime == nil {
+		return
+	}
+	if _, err := s.Sync(ctx, tenantID); err != nil {
+		log.Printf("bia: downtime sync for tenant %s: %v", tenantID, err)
+	}
+}
+
+func (s *Service) requireAsset(ctx context.Context, tenantID, assetID uuid.UUID) (*domain.Asset, error) {
+	if tenantID == uuid.Nil {
+		return nil, domain.NewForbiddenError("missing tenant context")
+	}
+	a, err := s.assets.GetByID(ctx, assetID, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to load asset: " + err.Error())
+	}
+	if a == nil {
+		return nil, domain.NewNotFoundError("asset", assetID)
+	}
+	return a, nil
+}
+
+type graph struct {
+	deps  []domain.AssetDependency
+	caps  map[uuid.UUID]domain.RecoveryCapability
+	names map[uuid.UUID]string
+}
+
+func (s *Service) load(ctx context.Context, tenantID uuid.UUID) (*graph, error) {
+	assets, err := s.assets.List(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to load assets: " + err.Error())
+	}
+	deps, err := s.deps.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to load dependencies: " + err.Error())
+	}
+	caps, err := s.repo.ListCapabilities(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	g := &graph{deps: deps, caps: make(map[uuid.UUID]domain.RecoveryCapability, len(caps)), names: make(map[uuid.UUID]string, len(assets))}
+	for _, a := range assets {
+		g.names[a.ID] = a.Name
+	}
+	for _, c := range caps {
+		g.caps[c.AssetID] = c
+	}
+	return g, nil
+}
+
+func (s *Service) record(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, action domain.AuditAction, entityType string, id uuid.UUID, summary string, after domain.JSONMap) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    actor,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   id.String(),
+		Summary:    summary,
+		After:      after,
+	})
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package bia
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+type memRepo struct {
+	analyses map[uuid.UUID]domain.BusinessImpactAnalysis
+	caps     map[uuid.UUID]domain.RecoveryCapability
+}
+
+func newMemRepo() *memRepo {
+	return &memRepo{analyses: map[uuid.UUID]domain.BusinessImpactAnalysis{}, caps: map[uuid.UUID]domain.RecoveryCapability{}}
+}
+
+func (m *memRepo) ListAnalyses(_ context.Context, tenantID uuid.UUID) ([]domain.BusinessImpactAnalysis, error) {
+	var out []domain.BusinessImpactAnalysis
+	for _, b := range m.analyses {
+		if b.TenantID == tenantID {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+func (m *memRepo) GetAnalysis(_ context.Context, tenantID, assetID uuid.UUID) (*domain.BusinessImpactAnalysis, error) {
+	if b, ok := m.analyses[assetID]; ok && b.TenantID == tenantID {
+		return &b, nil
+	}
+	return nil, nil
+}
+func (m *memRepo) SaveAnalysis(_ context.Context, b *domain.BusinessImpactAnalysis) error {
+	m.analyses[b.AssetID] = *b
+	return nil
+}
+func (m *memRepo) DeleteAnalysis(_ context.Context, _, assetID uuid.UUID) error {
+	delete(m.analyses, assetID)
+	return nil
+}
+func (m *memRepo) ListCapabilities(_ context.Context, tenantID uuid.UUID) ([]domain.RecoveryCapability, error) {
+	var out []domain.RecoveryCapability
+	for _, c := range m.caps {
+		if c.TenantID == tenantID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+func (m *memRepo) GetCapability(_ context.Context, tenantID, assetID uuid.UUID) (*domain.RecoveryCapability, error) {
+	if c, ok := m.caps[assetID]; ok && c.TenantID == tenantID {
+		return &c, nil
+	}
+	return nil, nil
+}
+func (m *memRepo) SaveCapability(_ context.Context, c *domain.RecoveryCapability) error {
+	m.caps[c.AssetID] = *c
+	return nil
+}
+func (m *memRepo) DeleteCapability(_ context.Context, _, assetID uuid.UUID) error {
+	delete(m.caps, assetID)
+	return nil
+}
+
+// memAssets implements only what the service calls; the embedded interface
+// panics on anything else, which is what a test wants.
+type memAssets struct {
+	domain.AssetRepository
+	rows []domain.Asset
+}
+
+func (m *memAssets) GetByID(_ context.Context, id, tenantID uuid.UUID) (*domain.Asset, error) {
+	for _, a := range m.rows {
+		if a.ID == id && a.TenantID == tenantID {
+			return &a, nil
+		}
+	}
+	return nil, nil
+}
+func (m *memAssets) List(_ context.Context, tenantID uuid.UUID) ([]domain.Asset, error) {
+	var out []domain.Asset
+	for _, a := range m.rows {
+		if a.TenantID == tenantID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+type memDeps struct {
+	domain.AssetDependencyRepository
+	rows []domain.AssetDependency
+}
+
+func (m *memDeps) ListByTenant(_ context.Context, _ uuid.UUID) ([]domain.AssetDependency, error) {
+	return m.rows, nil
+}
+
+type memDowntime struct{ applied map[uuid.UUID]*float64 }
+
+func (m *memDowntime) ApplyBIADowntime(_ context.Context, _, assetID uuid.UUID, hours *float64) (int64, error) {
+	m.applied[assetID] = hours
+	return 1, nil
+}
+
+type estate struct {
+	tenant                                     uuid.UUID
+	payroll, intranet, app, server, db, dbHost domain.Asset
+	svc                                        *Service
+	repo                                       *memRepo
+	downtime                                   *memDowntime
+}
+
+// payroll ─depends_on→ hr-app ─runs_on→ srv-app
+// intranet ─depends_on→ hr-app ─stores_data_in→ db ─runs_on→ db-host
+func newEstate() *estate {
+	e := &estate{tenant: uuid.New(), repo: newMemRepo(), downtime: &memDowntime{applied: map[uuid.UUID]*float64{}}}
+	mk := func(name string, cat domain.AssetCategory) domain.Asset {
+		return domain.Asset{ID: uuid.New(), TenantID: e.tenant, Name: name, Category: cat}
+	}
+	e.payroll, e.intranet = mk("payroll", domain.CategoryProcess), mk("intranet", domain.CategoryProcess)
+	e.app, e.server = mk("hr-app", domain.CategoryApplication), mk("srv-app", domain.CategoryServer)
+	e.db, e.dbHost = mk("hr-db", domain.CategoryDatabase), mk("db-host", domain.CategoryServer)
+	dep := func(src, dst domain.Asset, t domain.DependencyType) domain.AssetDependency {
+		return domain.AssetDependency{ID: uuid.New(), TenantID: e.tenant, SourceAssetID: src.ID, TargetAssetID: dst.ID, Type: t}
+	}
+	assets := &memAssets{rows: []domain.Asset{e.payroll, e.intranet, e.app, e.server, e.db, e.dbHost}}
+	deps := &memDeps{rows: []domain.AssetDependency{
+		dep(e.payroll, e.app, domain.DepDependsOn),
+		dep(e.intranet, e.app, domain.DepDependsOn),
+		dep(e.app, e.server, domain.DepRunsOn),
+		dep(e.app, e.db, domain.DepStoresDataIn),
+		dep(e.db, e.dbHost, domain.DepRunsOn),
+	}}
+	e.svc = NewService(e.repo, assets, deps).WithDowntimeSink(e.downtime)
+	return e
+}
+
+func f(v float64) *float64 { return &v }
+
+func TestSaveAnalysis_ValidatesAndIsTenantScoped(t *testing.T) {
+	ctx := context.Background()
+	e := newEstate()
+
+	_, err := e.svc.SaveAnalysis(ctx, e.tenant, nil, e.payroll.ID, AnalysisInput{MTPDHours: 4, RTOHours: 8})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "RTO above MTPD must be refused, got %v", err)
+
+	_, err = e.svc.SaveAnalysis(ctx, e.tenant, nil, e.payroll.ID, AnalysisInput{
+		MTPDHours: 24, RTOHours: 4,
+		ImpactCurve: domain.ImpactCurve{{AfterHours: 8, Level: 4, CostXAF: 100}, {AfterHours: 4, Level: 5, CostXAF: 200}},
+	})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "an unordered curve must be refused, got %v", err)
+
+	_, err = e.svc.SaveAnalysis(ctx, e.tenant, nil, e.app.ID, AnalysisInput{MTPDHours: 24, RTOHours: 4})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "objectives go on processes, not applications, got %v", err)
+
+	_, err = e.svc.SaveAnalysis(ctx, uuid.New(), nil, e.payroll.ID, AnalysisInput{MTPDHours: 24, RTOHours: 4})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "another tenant's asset must be a 404, got %v", err)
+}
+
+func TestPropagation_StrictestObjectiveWinsAndGapsAreFlagged(t *testing.T) {
+	ctx := context.Background()
+	e := newEstate()
+	_, err := e.svc.SaveAnalysis(ctx, e.tenant, nil, e.payroll.ID, AnalysisInput{MTPDHours: 24, RTOHours: 4, RPOHours: 1})
+	require.NoError(t, err)
+	_, err = e.svc.SaveAnalysis(ctx, e.tenant, nil, e.intranet.ID, AnalysisInput{MTPDHours: 168, RTOHours: 72, RPOHours: 24})
+	require.NoError(t, err)
+	_, err = e.svc.SaveCapability(ctx, e.tenant, nil, e.server.ID, CapabilityInput{RTCHours: f(2), Basis: "tested"})
+	require.NoError(t, err)
+	_, err = e.svc.SaveCapability(ctx, e.tenant, nil, e.app.ID, CapabilityInput{RTCHours: f(1)})
+	require.NoError(t, err)
+	_, err = e.svc.SaveCapability(ctx, e.tenant, nil, e.db.ID, CapabilityInput{RTCHours: f(8), RPCHours: f(24)})
+	require.NoError(t, err)
+
+	prop, err := e.svc.Propagation(ctx, e.tenant)
+	require.NoError(t, err)
+	assert.Equal(t, 2, prop.Processes)
+	byAsset := map[uuid.UUID]domain.RecoveryRequirement{}
+	for _, r := range prop.Requirements {
+		byAsset[r.AssetID] = r
+	}
+	require.Len(t, byAsset, 4, "the processes themselves are not requirements")
+
+	db := byAsset[e.db.ID]
+	assert.Equal(t, 4.0, db.RequiredRTOHours, "payroll's 4h beats the intranet's 72h")
+	assert.Equal(t, e.payroll.ID, db.RTODrivenBy)
+	require.NotNil(t, db.RequiredRPOHours)
+	assert.Equal(t, 1.0, *db.RequiredRPOHours)
+	assert.Equal(t, domain.RecoveryGap, db.Status)
+	assert.Len(t, db.Gaps, 2, "both the restore time and the data loss miss")
+	assert.Equal(t, []uuid.UUID{e.payroll.ID, e.app.ID, e.db.ID}, db.Path)
+
+	// The app server holds no process data: no RPO is inherited, and its 2h
+	// restore meets the 4h RTO.
+	srv := byAsset[e.server.ID]
+	assert.Nil(t, srv.RequiredRPOHours)
+	assert.Equal(t, domain.RecoveryOK, srv.Status)
+
+	// The database host holds the database's data and declared nothing.
+	host := byAsset[e.dbHost.ID]
+	require.NotNil(t, host.RequiredRPOHours)
+	assert.Equal(t, domain.RecoveryUndeclared, host.Status)
+	assert.Equal(t, 1, prop.Gaps)
+	assert.Equal(t, 1, prop.Undeclared)
+
+	// The worst declared restore (8h) is what payroll's risks plan for.
+	rep, err := e.svc.Report(ctx, e.tenant, e.payroll.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 8.0, rep.ExpectedDowntimeHours)
+	assert.False(t, rep.WithinRTO)
+	assert.True(t, rep.WithinMTPD)
+	require.NotNil(t, e.downtime.applied[e.payroll.ID])
+	assert.Equal(t, 8.0, *e.downtime.applied[e.payroll.ID])
+	// The intranet's RTO already exceeds every restore time.
+	assert.Equal(t, 72.0, *e.downtime.applied[e.intranet.ID])
+
+	// Deleting the analysis withdraws the downtime it fed.
+	require.NoError(t, e.svc.DeleteAnalysis(ctx, e.tenant, nil, e.payroll.ID))
+	assert.Nil(t, e.downtime.applied[e.payroll.ID])
+}
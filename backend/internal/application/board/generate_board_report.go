@@ -35,6 +35,10 @@ type GenerateBoardReportUseCase struct {
 	exposure   ExposureModel
 	fallback   ai.Advisor
 	activation ActivationRecorder
+	advisors   AdvisorSource
+	appetite   AppetiteSource
+	kris       KRISource
+	changes    RegisterDiffSource
 }
 
 // ActivationRecorder notes the "generated a report" milestone. Narrow port,
@@ -49,6 +53,33 @@ func (uc *GenerateBoardReportUseCase) WithActivation(rec ActivationRecorder) *Ge
 	return uc
 }
 
+// WithAdvisors resolves the advisor per organisation instead of always using the
+// one passed to the constructor.
+func (uc *GenerateBoardReportUseCase) WithAdvisors(src AdvisorSource) *GenerateBoardReportUseCase {
+	uc.advisors = src
+	return uc
+}
+
+// WithAppetite adds the register measured against the board's own appetite
+// statements to the report and to the narrative.
+func (uc *GenerateBoardReportUseCase) WithAppetite(src AppetiteSource) *GenerateBoardReportUseCase {
+	uc.appetite = src
+	return uc
+}
+
+// WithKRIs adds the key risk indicators and their trends to the report.
+func (uc *GenerateBoardReportUseCase) WithKRIs(src KRISource) *GenerateBoardReportUseCase {
+	uc.kris = src
+	return uc
+}
+
+// WithRegisterChanges adds what moved in the register since the previous
+// period: new, closed, up-scored and down-scored risks.
+func (uc *GenerateBoardReportUseCase) WithRegisterChanges(src RegisterDiffSource) *GenerateBoardReportUseCase {
+	uc.changes = src
+	return uc
+}
+
 func NewGenerateBoardReportUseCase(
 	reports domain.BoardReportRepository,
 	risks RiskPostureSource,
@@ -76,13 +107,15 @@ type GenerateBoardReportInput struct {
 }
 
 // Execute builds and persists a draft board report for (tenantID). requestedBy is
-// recorded as the author.
+// recorded as the author. The report covers the whole register, whoever
+// generates it: the caller's access policies do not apply.
 func (uc *GenerateBoardReportUseCase) Execute(
 	ctx context.Context,
 	tenantID uuid.UUID,
 	requestedBy uuid.UUID,
 	input GenerateBoardReportInput,
 ) (*domain.BoardReport, error) {
+	ctx = domain.WithAccessScope(ctx, nil)
 	locale := ai.Locale(input.Locale).Normalize()
 	period := input.PeriodLabel
 	if period == "" {
@@ -109,6 +142,24 @@ func (uc *GenerateBoardReportUseCase) Execute(
 
 	orgName := uc.resolveOrgName(ctx, tenantID)
 
+	// --- Appetite (optional) ---
+	appetiteSnap, err := uc.appetiteSnapshot(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate appetite: %w", err)
+	}
+
+	// --- Key risk indicators (optional) ---
+	kriSnap, err := uc.kriSnapshot(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("load kris: %w", err)
+	}
+
+	// --- Register changes over the period (optional) ---
+	changesSnap, err := uc.registerChanges(ctx, tenantID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("diff register: %w", err)
+	}
+
 	posture := ai.BoardPosture{
 		Locale:                   locale,
 		OrganizationName:         orgName,
@@ -121,12 +172,25 @@ func (uc *GenerateBoardReportUseCase) Execute(
 		FinancialExposureFCFA:    exposure,
 		Frameworks:               toAIFrameworks(frameworks),
 		OverallCompliancePercent: overallPct,
+		Appetite:                 toAIAppetite(appetiteSnap),
 	}
 
 	// --- Narrative (LLM best-effort, deterministic fallback) ---
-	narrative, generatedBy := uc.narrate(ctx, posture)
+	narrative, generatedBy := uc.narrate(ctx, tenantID, posture)
 
 	snapshot, _ := json.Marshal(frameworks)
+	var appetiteJSON datatypes.JSON
+	if appetiteSnap != nil {
+		appetiteJSON, _ = json.Marshal(appetiteSnap)
+	}
+	var kriJSON datatypes.JSON
+	if kriSnap != nil {
+		kriJSON, _ = json.Marshal(kriSnap)
+	}
+	var changesJSON datatypes.JSON
+	if changesSnap != nil {
+		changesJSON, _ = json.Marshal(changesSnap)
+	}
 
 	title := reportTitle(orgName, period, locale)
 
@@ -145,6 +209,9 @@ func (uc *GenerateBoardReportUseCase) Execute(
 		FinancialExposureFCFA:    exposure,
 		OverallCompliancePercent: overallPct,
 		FrameworksSnapshot:       datatypes.JSON(snapshot),
+		AppetiteSnapshot:         appetiteJSON,
+		KRISnapshot:              kriJSON,
+		RegisterChanges:          changesJSON,
 		ExecutiveSummary:         narrative.ExecutiveSummary,
 		RiskCommentary:           narrative.RiskCommentary,
 		ComplianceCommentary:     narrative.ComplianceCommentary,
@@ -171,10 +238,16 @@ func (uc *GenerateBoardReportUseCase) Execute(
 
 // narrate calls the configured advisor and falls back to the template on error,
 // returning the narrative and the name of whichever advisor actually produced it.
-func (uc *GenerateBoardReportUseCase) narrate(ctx context.Context, posture ai.BoardPosture) (ai.BoardNarrative, string) {
-	if uc.advisor != nil {
-		if n, err := uc.advisor.GenerateBoardNarrative(ctx, posture); err == nil {
-			return n, uc.advisor.Name()
+func (uc *GenerateBoardReportUseCase) narrate(ctx context.Context, tenantID uuid.UUID, posture ai.BoardPosture) (ai.BoardNarrative, string) {
+	advisor := uc.advisor
+	if uc.advisors != nil {
+		if a := uc.advisors.AdvisorFor(ctx, tenantID); a != nil {
+			advisor = a
+		}
+	}
+	if advisor != nil {
+		if n, err := advisor.GenerateBoardNarrative(ctx, posture); err == nil {
+			return n, advisor.Name()
 		}
 		// fall through to the deterministic fallback on any advisor error.
 	}
@@ -255,6 +328,181 @@ func toAIFrameworks(fws []FrameworkSnapshot) []ai.FrameworkPosture {
 	return out
 }
 
+// maxAppetiteRisks caps the risks listed in a report's appetite section: the
+// board reads the worst offenders, the full list lives in the register.
+const maxAppetiteRisks = 10
+
+// appetiteSnapshot evaluates the register against the appetite statements. It
+// is nil when no source is wired or no statement is in force, so a report never
+// claims the register is "within" an appetite nobody approved.
+func (uc *GenerateBoardReportUseCase) appetiteSnapshot(ctx context.Context, tenantID uuid.UUID) (*AppetiteSnapshot, error) {
+	if uc.appetite == nil {
+		return nil, nil
+	}
+	ev, err := uc.appetite.Evaluate(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if ev == nil || ev.Summary.StatementsInForce == 0 {
+		return nil, nil
+	}
+	snap := &AppetiteSnapshot{
+		StatementsInForce: ev.Summary.StatementsInForce,
+		RisksAbove:        ev.Summary.RisksAbove,
+		WithoutException:  ev.Summary.WithoutException,
+		PendingExceptions: ev.Summary.PendingExceptions,
+		BreachedScopes:    []AppetiteScopeSnapshot{},
+		TopRisks:          []AppetiteRiskSnapshot{},
+	}
+	for _, st := range ev.Statements {
+		if !st.InForce || !st.Breached {
+			continue
+		}
+		snap.BreachedScopes = append(snap.BreachedScopes, AppetiteScopeSnapshot{
+			ScopeLabel: st.ScopeLabel,
+			Statement:  st.Statement.Statement,
+			RisksAbove: st.RisksAbove,
+			Breaches:   st.Breaches,
+		})
+	}
+	// ev.Risks is already ordered: without a valid exception first, then by score.
+	for i, r := range ev.Risks {
+		if i == maxAppetiteRisks {
+			break
+		}
+		snap.TopRisks = append(snap.TopRisks, AppetiteRiskSnapshot{
+			Title:           r.Title,
+			Criticality:     r.Criticality,
+			Score:           r.Score,
+			ExceptionStatus: r.Exception.Status,
+			ValidUntil:      r.Exception.ValidUntil,
+		})
+	}
+	return snap, nil
+}
+
+// maxBoardKRIs caps the indicators a report shows; maxKRIPoints caps each
+// sparkline, which is a shape to read at a glance, not a chart.
+const (
+	maxBoardKRIs      = 12
+	maxKRIPoints      = 30
+	boardKRITrendDays = 90
+)
+
+// kriSnapshot freezes the KRIs and their last quarter. It is nil when no
+// source is wired or the tenant has no KRI.
+func (uc *GenerateBoardReportUseCase) kriSnapshot(ctx context.Context, tenantID uuid.UUID) (*KRISnapshot, error) {
+	if uc.kris == nil {
+		return nil, nil
+	}
+	trends, err := uc.kris.Trends(ctx, tenantID, nil, boardKRITrendDays)
+	if err != nil {
+		return nil, err
+	}
+	if len(trends) == 0 {
+		return nil, nil
+	}
+	snap := &KRISnapshot{Indicators: []KRIIndicatorSnapshot{}}
+	// trends are already ordered red, amber, green, unknown.
+	for _, t := range trends {
+		switch t.Status {
+		case domain.KRIStatusRed:
+			snap.Red++
+		case domain.KRIStatusAmber:
+			snap.Amber++
+		case domain.KRIStatusGreen:
+			snap.Green++
+		}
+		if len(snap.Indicators) == maxBoardKRIs {
+			continue
+		}
+		points := make([]float64, 0, len(t.Values))
+		for _, v := range t.Values {
+			points = append(points, v.Value)
+		}
+		snap.Indicators = append(snap.Indicators, KRIIndicatorSnapshot{
+			Name:           t.Name,
+			Unit:           t.Unit,
+			Status:         t.Status,
+			Direction:      t.Direction,
+			Value:          t.LastValue,
+			AmberThreshold: t.AmberThreshold,
+			RedThreshold:   t.RedThreshold,
+			Points:         thinPoints(points, maxKRIPoints),
+		})
+	}
+	return snap, nil
+}
+
+// maxBoardMovers caps each list of moved risks; the counts stay exact.
+const maxBoardMovers = 8
+
+// registerChanges compares the register a month before now with the live one.
+// It is nil when no source is wired or no snapshot is that old. Like the rest
+// of the report it covers the whole register, whoever generates it.
+func (uc *GenerateBoardReportUseCase) registerChanges(ctx context.Context, tenantID uuid.UUID, now time.Time) (*RegisterChangesSnapshot, error) {
+	if uc.changes == nil {
+		return nil, nil
+	}
+	d, err := uc.changes.DiffSince(ctx, tenantID, now.AddDate(0, -1, 0))
+	if err != nil || d == nil {
+		return nil, err
+	}
+	return &RegisterChangesSnapshot{
+		Since:           d.From.AsOf,
+		New:             d.Summary.New,
+		Closed:          d.Summary.Closed,
+		UpScored:        d.Summary.UpScored,
+		DownScored:      d.Summary.DownScored,
+		NewRisks:        movers(d.New),
+		ClosedRisks:     movers(d.Closed),
+		UpScoredRisks:   movers(d.UpScored),
+		DownScoredRisks: movers(d.DownScored),
+	}, nil
+}
+
+func movers(entries []domain.RegisterDiffEntry) []RegisterChangeRisk {
+	out := make([]RegisterChangeRisk, 0, len(entries))
+	for _, e := range entries {
+		if len(out) == maxBoardMovers {
+			break
+		}
+		out = append(out, RegisterChangeRisk{Title: e.Title, Criticality: e.Criticality, FromScore: e.FromScore, ToScore: e.ToScore})
+	}
+	return out
+}
+
+// thinPoints keeps at most max points, evenly spaced, always keeping the last
+// one so the sparkline ends on the current value.
+func thinPoints(points []float64, max int) []float64 {
+	if len(points) <= max {
+		return points
+	}
+	out := make([]float64, 0, max)
+	step := float64(len(points)-1) / float64(max-1)
+	for i := 0; i < max; i++ {
+		out = append(out, points[int(float64(i)*step+0.5)])
+	}
+	out[max-1] = points[len(points)-1]
+	return out
+}
+
+func toAIAppetite(snap *AppetiteSnapshot) *ai.AppetitePosture {
+	if snap == nil {
+		return nil
+	}
+	scopes := make([]string, 0, len(snap.BreachedScopes))
+	for _, s := range snap.BreachedScopes {
+		scopes = append(scopes, s.ScopeLabel)
+	}
+	return &ai.AppetitePosture{
+		StatementsInForce: snap.StatementsInForce,
+		BreachedScopes:    scopes,
+		RisksAbove:        snap.RisksAbove,
+		WithoutException:  snap.WithoutException,
+	}
+}
+
 // monthLabel formats a "Month YYYY" label in the given locale.
 func monthLabel(t time.Time, locale ai.Locale) string {
 	if locale == ai.LocaleEN {
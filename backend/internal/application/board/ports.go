@@ -11,10 +11,15 @@ package board
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/opendefender/openrisk/internal/application/appetite"
+	"github.com/opendefender/openrisk/internal/application/kri"
+	"github.com/opendefender/openrisk/internal/application/registersnapshot"
 	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/ai"
 )
 
 // RiskPostureSource counts a tenant's active risks by criticality level.
@@ -30,6 +35,31 @@ type OrganizationLookup interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error)
 }
 
+// AdvisorSource resolves the advisor an organisation has chosen (its own
+// self-hosted model, Claude, or the template). The AI provider settings service
+// satisfies it; nil-safe.
+type AdvisorSource interface {
+	AdvisorFor(ctx context.Context, tenantID uuid.UUID) ai.Advisor
+}
+
+// AppetiteSource evaluates the register against the board's appetite
+// statements. *appetite.Service satisfies it; nil-safe.
+type AppetiteSource interface {
+	Evaluate(ctx context.Context, tenantID uuid.UUID) (*appetite.Evaluation, error)
+}
+
+// KRISource lists the tenant's key risk indicators with their recent values.
+// *kri.Service satisfies it; nil-safe.
+type KRISource interface {
+	Trends(ctx context.Context, tenantID uuid.UUID, riskID *uuid.UUID, days int) ([]kri.Trend, error)
+}
+
+// RegisterDiffSource compares the register as it stood at a date with the
+// live one. *registersnapshot.Service satisfies it; nil-safe.
+type RegisterDiffSource interface {
+	DiffSince(ctx context.Context, tenantID uuid.UUID, since time.Time) (*registersnapshot.Diff, error)
+}
+
 // UserLookup resolves who generated/approved a report.
 // *repository.GormUserRepository satisfies it.
 type UserLookup interface {
@@ -47,3 +77,85 @@ type FrameworkSnapshot struct {
 	Implemented     int     `json:"implemented"`
 	PercentComplete float64 `json:"percent_complete"`
 }
+
+// AppetiteSnapshot is the register measured against the board's appetite,
+// frozen into BoardReport.AppetiteSnapshot at generation time. Only in-force
+// statements are reported: a draft is not yet the board's appetite.
+type AppetiteSnapshot struct {
+	StatementsInForce int                     `json:"statements_in_force"`
+	RisksAbove        int                     `json:"risks_above"`
+	WithoutException  int                     `json:"without_exception"`
+	PendingExceptions int                     `json:"pending_exceptions"`
+	BreachedScopes    []AppetiteScopeSnapshot `json:"breached_scopes"`
+	// TopRisks are the worst risks above appetite, those without a valid
+	// exception first. Capped at maxAppetiteRisks.
+	TopRisks []AppetiteRiskSnapshot `json:"top_risks"`
+}
+
+// AppetiteScopeSnapshot is one in-force statement whose scope is above appetite.
+type AppetiteScopeSnapshot struct {
+	ScopeLabel string                  `json:"scope_label"`
+	Statement  string                  `json:"statement"`
+	RisksAbove int                     `json:"risks_above"`
+	Breaches   []domain.AppetiteBreach `json:"breaches"`
+}
+
+// AppetiteRiskSnapshot is one risk above appetite and the state of its exception.
+type AppetiteRiskSnapshot struct {
+	Title           string                         `json:"title"`
+	Criticality     string                         `json:"criticality"`
+	Score           float64                        `json:"score"`
+	ExceptionStatus domain.AppetiteExceptionStatus `json:"exception_status"`
+	ValidUntil      *time.Time                     `json:"valid_until,omitempty"`
+}
+
+// KRISnapshot is the tenant's key risk indicators at generation time, frozen
+// into BoardReport.KRISnapshot: each one's band, value and recent trend.
+type KRISnapshot struct {
+	Red   int `json:"red"`
+	Amber int `json:"amber"`
+	Green int `json:"green"`
+	// Indicators are red first, then amber, then green, capped at
+	// maxBoardKRIs.
+	Indicators []KRIIndicatorSnapshot `json:"indicators"`
+}
+
+// KRIIndicatorSnapshot is one KRI and its trend. Points are the values of the
+// trend window, oldest first, thinned to at most maxKRIPoints.
+type KRIIndicatorSnapshot struct {
+	Name           string              `json:"name"`
+	Unit           string              `json:"unit,omitempty"`
+	Status         domain.KRIStatus    `json:"status"`
+	Direction      domain.KRIDirection `json:"direction"`
+	Value          *float64            `json:"value,omitempty"`
+	AmberThreshold float64             `json:"amber_threshold"`
+	RedThreshold   float64             `json:"red_threshold"`
+	Points         []float64           `json:"points"`
+}
+
+// RegisterChangesSnapshot is what moved in the register over the reporting
+// period, frozen into BoardReport.RegisterChanges: the live register at
+// generation compared with the snapshot standing for the period's start.
+type RegisterChangesSnapshot struct {
+	// Since is when the compared snapshot stands for, which may predate the
+	// period's start when no snapshot was taken exactly then.
+	Since      time.Time `json:"since"`
+	New        int       `json:"new"`
+	Closed     int       `json:"closed"`
+	UpScored   int       `json:"up_scored"`
+	DownScored int       `json:"down_scored"`
+	// Each list is capped at maxBoardMovers, largest movement first.
+	NewRisks        []RegisterChangeRisk `json:"new_risks"`
+	ClosedRisks     []RegisterChangeRisk `json:"closed_risks"`
+	UpScoredRisks   []RegisterChangeRisk `json:"up_scored_risks"`
+	DownScoredRisks []RegisterChangeRisk `json:"down_scored_risks"`
+}
+
+// RegisterChangeRisk is one risk that moved. A score is nil on the side where
+// the risk did not exist.
+type RegisterChangeRisk struct {
+	Title       string   `json:"title"`
+	Criticality string   `json:"criticality"`
+	FromScore   *float64 `json:"from_score,omitempty"`
+	ToScore     *float64 `json:"to_score,omitempty"`
+}
@@ -0,0 +1,501 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package bowtie manages a risk's bowtie analysis: the threats, barriers, top
+// event and consequences, the health each barrier derives from its control's
+// tests and its mitigation's status, the graph the UI and the PDF figure draw,
+// and the probability/impact suggestion degraded barriers lead to.
+package bowtie
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// AuditSink records bowtie edits and applied suggestions in the audit chain.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// RiskEvents asks the Score Engine to re-score a risk whose probability or
+// impact a suggestion changed. Optional and best-effort.
+type RiskEvents interface {
+	PublishRiskUpdated(ctx context.Context, r *domain.Risk) error
+}
+
+// Service is the bowtie use cases.
+type Service struct {
+	repo   domain.BowtieRepository
+	audit  AuditSink
+	events RiskEvents
+	now    func() time.Time
+}
+
+// NewService builds the service.
+func NewService(repo domain.BowtieRepository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// WithAudit enables audit recording.
+func (s *Service) WithAudit(a AuditSink) *Service {
+	s.audit = a
+	return s
+}
+
+// WithEvents enables re-scoring of risks a suggestion was applied to.
+func (s *Service) WithEvents(e RiskEvents) *Service {
+	s.events = e
+	return s
+}
+
+// WithClock overrides the clock (tests).
+func (s *Service) WithClock(now func() time.Time) *Service {
+	s.now = now
+	return s
+}
+
+// =============================================================================
+// Graph
+// =============================================================================
+
+// Node kinds of the graph.
+const (
+	NodeThreat            = "threat"
+	NodePreventiveBarrier = "preventive_barrier"
+	NodeTopEvent          = "top_event"
+	NodeRecoveryBarrier   = "recovery_barrier"
+	NodeConsequence       = "consequence"
+)
+
+// Node is one box of the bowtie. Barrier nodes carry their health and what
+// it was derived from; the others carry none.
+type Node struct {
+	ID              string               `json:"id"`
+	Kind            string               `json:"kind"`
+	Label           string               `json:"label"`
+	Description     string               `json:"description,omitempty"`
+	PathID          string               `json:"path_id,omitempty"`
+	Health          domain.BarrierHealth `json:"health,omitempty"`
+	ControlID       *uuid.UUID           `json:"control_id,omitempty"`
+	ControlLabel    string               `json:"control_label,omitempty"`
+	Effectiveness   *float64             `json:"effectiveness,omitempty"`
+	TestOverdue     bool                 `json:"test_overdue,omitempty"`
+	MitigationID    *uuid.UUID           `json:"mitigation_id,omitempty"`
+	MitigationTitle string               `json:"mitigation_title,omitempty"`
+	MitigationState string               `json:"mitigation_status,omitempty"`
+}
+
+// Edge joins two nodes, left to right.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// SideHealth counts one side's barriers by health.
+type SideHealth struct {
+	Healthy  int     `json:"healthy"`
+	Degraded int     `json:"degraded"`
+	Failed   int     `json:"failed"`
+	Unknown  int     `json:"unknown"`
+	Loss     float64 `json:"loss"`
+}
+
+func (h *SideHealth) add(v domain.BarrierHealth) {
+	switch v {
+	case domain.BarrierHealthy:
+		h.Healthy++
+	case domain.BarrierDegraded:
+		h.Degraded++
+	case domain.BarrierFailed:
+		h.Failed++
+	default:
+		h.Unknown++
+	}
+}
+
+// Suggestion is what the barriers make of the risk's figures.
+type Suggestion struct {
+	CurrentProbability float64 `json:"current_probability"`
+	CurrentImpact      float64 `json:"current_impact"`
+	Probability        float64 `json:"probability"`
+	Impact             float64 `json:"impact"`
+	Raises             bool    `json:"raises"`
+}
+
+// Graph is GET /risks/:id/bowtie. Bowtie is nil, and the lists empty, when
+// the risk has none yet.
+type Graph struct {
+	RiskID     uuid.UUID      `json:"risk_id"`
+	RiskTitle  string         `json:"risk_title"`
+	Bowtie     *domain.Bowtie `json:"bowtie"`
+	Nodes      []Node         `json:"nodes"`
+	Edges      []Edge         `json:"edges"`
+	Preventive SideHealth     `json:"preventive"`
+	Recovery   SideHealth     `json:"recovery"`
+	Suggestion Suggestion     `json:"suggestion"`
+}
+
+// Get returns the risk's bowtie as a graph, with barrier health derived now.
+func (s *Service) Get(ctx context.Context, tenantID, riskID uuid.UUID) (*Graph, error) {
+	risk, err := s.risk(ctx, tenantID, riskID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.repo.Get(ctx, tenantID, riskID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	return s.graph(ctx, risk, b)
+}
+
+// Save replaces the risk's bowtie. Linked controls must be the tenant's and
+// linked mitigations the risk's own. The losses already applied to the risk
+// carry over: an edit does not make them count twice.
+func (s *Service) Save(ctx context.Context, tenantID, riskID uuid.UUID, actor *uuid.UUID, in domain.Bowtie) (*Graph, error) {
+	risk, err := s.risk(ctx, tenantID, riskID)
+	if err != nil {
+		return nil, err
+	}
+	b := &in
+	b.ID, b.TenantID, b.RiskID, b.UpdatedBy = uuid.Nil, tenantID, riskID, actor
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkLinks(ctx, b); err != nil {
+		return nil, err
+	}
+	b.AppliedPreventiveLoss, b.AppliedRecoveryLoss = 0, 0
+	if prev, err := s.repo.Get(ctx, tenantID, riskID); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	} else if prev != nil {
+		b.AppliedPreventiveLoss, b.AppliedRecoveryLoss = prev.AppliedPreventiveLoss, prev.AppliedRecoveryLoss
+	}
+	if err := s.repo.Save(ctx, b); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if s.audit != nil {
+		s.audit.Record(ctx, domain.AuditEvent{
+			TenantID: tenantID, ActorID: actor, Action: "bowtie.saved",
+			EntityType: "risk", EntityID: riskID.String(),
+			Summary: fmt.Sprintf("Bowtie saved: %d threats, %d consequences, %d barriers",
+				len(b.Threats), len(b.Consequences), len(b.Barriers)),
+			After: domain.JSONMap{"top_event": b.TopEvent, "barriers": len(b.Barriers)},
+		})
+	}
+	return s.graph(ctx, risk, b)
+}
+
+// Delete removes the risk's bowtie.
+func (s *Service) Delete(ctx context.Context, tenantID, riskID uuid.UUID, actor *uuid.UUID) error {
+	if _, err := s.risk(ctx, tenantID, riskID); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, tenantID, riskID); err != nil {
+		return err
+	}
+	if s.audit != nil {
+		s.audit.Record(ctx, domain.AuditEvent{
+			TenantID: tenantID, ActorID: actor, Action: "bowtie.deleted",
+			EntityType: "risk", EntityID: riskID.String(), Summary: "Bowtie deleted",
+		})
+	}
+	return nil
+}
+
+// ApplySuggestion writes the suggested probability and impact onto the risk
+// and remembers the losses they now account for. It refuses when the
+// barriers suggest nothing higher: the suggestion only ever raises, and
+// lowering the figures is a human's call on the risk form.
+func (s *Service) ApplySuggestion(ctx context.Context, tenantID, riskID uuid.UUID, actor *uuid.UUID) (*Graph, error) {
+	g, err := s.Get(ctx, tenantID, riskID)
+	if err != nil {
+		return nil, err
+	}
+	if g.Bowtie == nil {
+		return nil, domain.NewNotFoundError("bowtie", riskID)
+	}
+	sg := g.Suggestion
+	if !sg.Raises {
+		return nil, domain.NewValidationError("the bowtie's barriers do not raise this risk's probability or impact")
+	}
+	applied := domain.BarrierSuggestion{
+		Probability: sg.Probability, Impact: sg.Impact,
+		PreventiveLoss: math.Max(g.Preventive.Loss, g.Bowtie.AppliedPreventiveLoss),
+		RecoveryLoss:   math.Max(g.Recovery.Loss, g.Bowtie.AppliedRecoveryLoss),
+	}
+	if err := s.repo.ApplySuggestion(ctx, tenantID, riskID, applied); err != nil {
+		return nil, err
+	}
+	g.Bowtie.AppliedPreventiveLoss, g.Bowtie.AppliedRecoveryLoss = applied.PreventiveLoss, applied.RecoveryLoss
+	if s.audit != nil {
+		s.audit.Record(ctx, domain.AuditEvent{
+			TenantID: tenantID, ActorID: actor, Action: "bowtie.suggestion_applied",
+			EntityType: "risk", EntityID: riskID.String(),
+			Summary: fmt.Sprintf("Bowtie barriers raised probability %.3f → %.3f and impact %.1f → %.1f",
+				sg.CurrentProbability, sg.Probability, sg.CurrentImpact, sg.Impact),
+			After: domain.JSONMap{
+				"probability": sg.Probability, "impact": sg.Impact,
+				"preventive_loss": g.Preventive.Loss, "recovery_loss": g.Recovery.Loss,
+			},
+		})
+	}
+	risk, err := s.risk(ctx, tenantID, riskID)
+	if err != nil {
+		return nil, err
+	}
+	if s.events != nil {
+		_ = s.events.PublishRiskUpdated(ctx, risk)
+	}
+	return s.graph(ctx, risk, g.Bowtie)
+}
+
+// =============================================================================
+// Internals
+// =============================================================================
+
+func (s *Service) risk(ctx context.Context, tenantID, id uuid.UUID) (*domain.Risk, error) {
+	r, err := s.repo.GetRisk(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if r == nil {
+		return nil, domain.NewNotFoundError("risk", id)
+	}
+	return r, nil
+}
+
+func (s *Service) checkLinks(ctx context.Context, b *domain.Bowtie) error {
+	controlIDs := linkedControls(b)
+	if len(controlIDs) > 0 {
+		controls, err := s.repo.Controls(ctx, b.TenantID, controlIDs)
+		if err != nil {
+			return domain.NewInternalError(err.Error())
+		}
+		known := map[uuid.UUID]bool{}
+		for _, c := range controls {
+			known[c.ID] = true
+		}
+		for _, id := range controlIDs {
+			if !known[id] {
+				return domain.NewValidationError(fmt.Sprintf("control %s does not exist", id))
+			}
+		}
+	}
+	var mitigationIDs []uuid.UUID
+	for _, br := range b.Barriers {
+		if br.MitigationID != nil {
+			mitigationIDs = append(mitigationIDs, *br.MitigationID)
+		}
+	}
+	if len(mitigationIDs) > 0 {
+		mits, err := s.repo.Mitigations(ctx, b.TenantID, b.RiskID)
+		if err != nil {
+			return domain.NewInternalError(err.Error())
+		}
+		own := map[uuid.UUID]bool{}
+		for _, m := range mits {
+			own[m.ID] = true
+		}
+		for _, id := range mitigationIDs {
+			if !own[id] {
+				return domain.NewValidationError(fmt.Sprintf("mitigation %s is not one of this risk's", id))
+			}
+		}
+	}
+	return nil
+}
+
+func linkedControls(b *domain.Bowtie) []uuid.UUID {
+	seen := map[uuid.UUID]bool{}
+	var ids []uuid.UUID
+	for _, br := range b.Barriers {
+		if br.ControlID != nil && !seen[*br.ControlID] {
+			seen[*br.ControlID] = true
+			ids = append(ids, *br.ControlID)
+		}
+	}
+	return ids
+}
+
+// evidence is what the barriers of one bowtie link to, loaded in three
+// queries whatever the number of barriers.
+type evidence struct {
+	controls    map[uuid.UUID]domain.ComplianceControl
+	tests       map[uuid.UUID]domain.BarrierControlEvidence
+	mitigations map[uuid.UUID]domain.Mitigation
+}
+
+func (s *Service) loadEvidence(ctx context.Context, b *domain.Bowtie) (*evidence, error) {
+	ev := &evidence{
+		controls:    map[uuid.UUID]domain.ComplianceControl{},
+		tests:       map[uuid.UUID]domain.BarrierControlEvidence{},
+		mitigations: map[uuid.UUID]domain.Mitigation{},
+	}
+	if ids := linkedControls(b); len(ids) > 0 {
+		controls, err := s.repo.Controls(ctx, b.TenantID, ids)
+		if err != nil {
+			return nil, domain.NewInternalError(err.Error())
+		}
+		for _, c := range controls {
+			ev.controls[c.ID] = c
+		}
+		plans, err := s.repo.TestPlans(ctx, b.TenantID, ids)
+		if err != nil {
+			return nil, domain.NewInternalError(err.Error())
+		}
+		ev.tests = controlEvidence(controls, plans, s.now())
+	}
+	mits, err := s.repo.Mitigations(ctx, b.TenantID, b.RiskID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	for _, m := range mits {
+		ev.mitigations[m.ID] = m
+	}
+	return ev, nil
+}
+
+// controlEvidence folds each control's test plans into its latest design and
+// operating scores, the way control testing does, and flags a tested control
+// whose next test date has passed.
+func controlEvidence(controls []domain.ComplianceControl, plans []domain.ControlTestPlan, now time.Time) map[uuid.UUID]domain.BarrierControlEvidence {
+	type scores struct {
+		design, operating *float64
+		overdue           bool
+	}
+	by := map[uuid.UUID]*scores{}
+	for _, p := range plans {
+		sc := by[p.ControlID]
+		if sc == nil {
+			sc = &scores{}
+			by[p.ControlID] = sc
+		}
+		if p.LastEffectiveness == nil {
+			continue
+		}
+		v := *p.LastEffectiveness
+		if p.Kind == domain.ControlTestDesign {
+			sc.design = &v
+		} else {
+			sc.operating = &v
+		}
+		if !p.NextTestAt.After(now) {
+			sc.overdue = true
+		}
+	}
+	out := map[uuid.UUID]domain.BarrierControlEvidence{}
+	for _, c := range controls {
+		e := domain.BarrierControlEvidence{Status: c.Status}
+		if sc := by[c.ID]; sc != nil {
+			e.Tested = sc.design != nil || sc.operating != nil
+			e.Effectiveness = domain.ControlEffectiveness(sc.design, sc.operating)
+			e.Overdue = sc.overdue
+		}
+		out[c.ID] = e
+	}
+	return out
+}
+
+func (s *Service) graph(ctx context.Context, risk *domain.Risk, b *domain.Bowtie) (*Graph, error) {
+	g := &Graph{
+		RiskID: risk.ID, RiskTitle: risk.Title, Nodes: []Node{}, Edges: []Edge{},
+		Suggestion: Suggestion{
+			CurrentProbability: risk.Probability, CurrentImpact: risk.Impact,
+			Probability: risk.Probability, Impact: risk.Impact,
+		},
+	}
+	if g.RiskTitle == "" {
+		g.RiskTitle = risk.Name
+	}
+	if b == nil {
+		return g, nil
+	}
+	g.Bowtie = b
+	ev, err := s.loadEvidence(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	const top = "top_event"
+	var preventive, recovery []domain.BarrierHealth
+	for _, t := range b.Threats {
+		prev := "threat:" + t.ID
+		g.Nodes = append(g.Nodes, Node{ID: prev, Kind: NodeThreat, Label: t.Label, Description: t.Description})
+		for _, br := range b.BarriersOn(t.ID) {
+			n := ev.barrierNode(br)
+			preventive = append(preventive, n.Health)
+			g.Preventive.add(n.Health)
+			g.Nodes = append(g.Nodes, n)
+			g.Edges = append(g.Edges, Edge{From: prev, To: n.ID})
+			prev = n.ID
+		}
+		g.Edges = append(g.Edges, Edge{From: prev, To: top})
+	}
+	g.Nodes = append(g.Nodes, Node{ID: top, Kind: NodeTopEvent, Label: b.TopEvent, Description: b.Hazard})
+	for _, c := range b.Consequences {
+		prev := top
+		for _, br := range b.BarriersOn(c.ID) {
+			n := ev.barrierNode(br)
+			recovery = append(recovery, n.Health)
+			g.Recovery.add(n.Health)
+			g.Nodes = append(g.Nodes, n)
+			g.Edges = append(g.Edges, Edge{From: prev, To: n.ID})
+			prev = n.ID
+		}
+		id := "consequence:" + c.ID
+		g.Nodes = append(g.Nodes, Node{ID: id, Kind: NodeConsequence, Label: c.Label, Description: c.Description})
+		g.Edges = append(g.Edges, Edge{From: prev, To: id})
+	}
+
+	sg := domain.SuggestFromBarriers(risk.Probability, risk.Impact, preventive, recovery,
+		b.AppliedPreventiveLoss, b.AppliedRecoveryLoss)
+	g.Preventive.Loss, g.Recovery.Loss = sg.PreventiveLoss, sg.RecoveryLoss
+	g.Suggestion.Probability, g.Suggestion.Impact = sg.Probability, sg.Impact
+	g.Suggestion.Raises = sg.Raises(risk.Probability, risk.Impact)
+	return g, nil
+}
+
+func (ev *evidence) barrierNode(br domain.BowtieBarrier) Node {
+	kind := NodePreventiveBarrier
+	if br.Kind == domain.BarrierRecovery {
+		kind = NodeRecoveryBarrier
+	}
+	n := Node{ID: "barrier:" + br.ID, Kind: kind, Label: br.Label, PathID: br.PathID}
+	var control *domain.BarrierControlEvidence
+	if br.ControlID != nil {
+		n.ControlID = br.ControlID
+		if c, ok := ev.controls[*br.ControlID]; ok {
+			n.ControlLabel = c.Name
+			if c.ReferenceCode != "" {
+				n.ControlLabel = c.ReferenceCode + " " + c.Name
+			}
+			e := ev.tests[c.ID]
+			control = &e
+			if e.Tested {
+				v := e.Effectiveness
+				n.Effectiveness = &v
+			}
+			n.TestOverdue = e.Overdue
+		}
+	}
+	var status *domain.MitigationStatus
+	if br.MitigationID != nil {
+		n.MitigationID = br.MitigationID
+		if m, ok := ev.mitigations[*br.MitigationID]; ok {
+			st := m.Status
+			status = &st
+			n.MitigationTitle = m.Title
+			n.MitigationState = string(m.Status)
+		}
+	}
+	n.Health = domain.BarrierHealthOf(control, status)
+	return n
+}
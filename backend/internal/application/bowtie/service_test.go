@@ -0,0 +1,218 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package bowtie
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+type memBowties struct {
+	risks       map[uuid.UUID]*domain.Risk
+	bowties     map[uuid.UUID]domain.Bowtie
+	controls    map[uuid.UUID]domain.ComplianceControl
+	plans       []domain.ControlTestPlan
+	mitigations []domain.Mitigation
+}
+
+func (m *memBowties) GetRisk(_ context.Context, tenantID, id uuid.UUID) (*domain.Risk, error) {
+	if r, ok := m.risks[id]; ok && r.TenantID == tenantID {
+		c := *r
+		return &c, nil
+	}
+	return nil, nil
+}
+func (m *memBowties) Get(_ context.Context, tenantID, riskID uuid.UUID) (*domain.Bowtie, error) {
+	if b, ok := m.bowties[riskID]; ok && b.TenantID == tenantID {
+		return &b, nil
+	}
+	return nil, nil
+}
+func (m *memBowties) Save(_ context.Context, b *domain.Bowtie) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	m.bowties[b.RiskID] = *b
+	return nil
+}
+func (m *memBowties) Delete(_ context.Context, tenantID, riskID uuid.UUID) error {
+	if b, ok := m.bowties[riskID]; !ok || b.TenantID != tenantID {
+		return domain.NewNotFoundError("bowtie", riskID)
+	}
+	delete(m.bowties, riskID)
+	return nil
+}
+func (m *memBowties) Controls(_ context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]domain.ComplianceControl, error) {
+	var out []domain.ComplianceControl
+	for _, id := range ids {
+		if c, ok := m.controls[id]; ok && c.TenantID == tenantID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+func (m *memBowties) TestPlans(_ context.Context, tenantID uuid.UUID, controlIDs []uuid.UUID) ([]domain.ControlTestPlan, error) {
+	var out []domain.ControlTestPlan
+	for _, p := range m.plans {
+		for _, id := range controlIDs {
+			if p.TenantID == tenantID && p.ControlID == id {
+				out = append(out, p)
+			}
+		}
+	}
+	return out, nil
+}
+func (m *memBowties) Mitigations(_ context.Context, tenantID, riskID uuid.UUID) ([]domain.Mitigation, error) {
+	var out []domain.Mitigation
+	for _, x := range m.mitigations {
+		if x.TenantID == tenantID && x.RiskID == riskID {
+			out = append(out, x)
+		}
+	}
+	return out, nil
+}
+func (m *memBowties) ApplySuggestion(_ context.Context, tenantID, riskID uuid.UUID, s domain.BarrierSuggestion) error {
+	r, ok := m.risks[riskID]
+	b, has := m.bowties[riskID]
+	if !ok || !has || r.TenantID != tenantID {
+		return domain.NewNotFoundError("risk", riskID)
+	}
+	r.Probability, r.Impact = s.Probability, s.Impact
+	b.AppliedPreventiveLoss, b.AppliedRecoveryLoss = s.PreventiveLoss, s.RecoveryLoss
+	m.bowties[riskID] = b
+	return nil
+}
+
+type recordedEvents struct{ risks []uuid.UUID }
+
+func (e *recordedEvents) PublishRiskUpdated(_ context.Context, r *domain.Risk) error {
+	e.risks = append(e.risks, r.ID)
+	return nil
+}
+
+func TestBowtie_GraphHealthAndSuggestion(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	tenant := uuid.New()
+	risk := &domain.Risk{ID: uuid.New(), TenantID: tenant, Title: "Fraude au virement", Probability: 0.4, Impact: 6}
+	dual, recall := uuid.New(), uuid.New()
+	weak, done, planned := 0.3, uuid.New(), uuid.New()
+	repo := &memBowties{
+		risks:   map[uuid.UUID]*domain.Risk{risk.ID: risk},
+		bowties: map[uuid.UUID]domain.Bowtie{},
+		controls: map[uuid.UUID]domain.ComplianceControl{
+			dual:   {ID: dual, TenantID: tenant, ReferenceCode: "A.5.3", Name: "Séparation des tâches", Status: domain.ControlStatusImplemented},
+			recall: {ID: recall, TenantID: tenant, Name: "Rappel de fonds", Status: domain.ControlStatusImplemented},
+		},
+		plans: []domain.ControlTestPlan{
+			{ID: uuid.New(), TenantID: tenant, ControlID: dual, Kind: domain.ControlTestOperating, LastEffectiveness: &weak, NextTestAt: now.AddDate(0, 1, 0)},
+		},
+		mitigations: []domain.Mitigation{
+			{ID: done, TenantID: tenant, RiskID: risk.ID, Title: "Formation", Status: domain.MitigationDone},
+			{ID: planned, TenantID: tenant, RiskID: risk.ID, Title: "Callback", Status: domain.MitigationInProgress},
+		},
+	}
+	events := &recordedEvents{}
+	svc := NewService(repo).WithEvents(events).WithClock(func() time.Time { return now })
+
+	g, err := svc.Get(ctx, tenant, risk.ID)
+	require.NoError(t, err)
+	assert.Nil(t, g.Bowtie)
+	assert.Empty(t, g.Nodes)
+
+	in := domain.Bowtie{
+		TopEvent:     "Virement frauduleux émis",
+		Threats:      domain.BowtiePaths{{ID: "phish", Label: "Hameçonnage"}},
+		Consequences: domain.BowtiePaths{{ID: "loss", Label: "Perte financière"}},
+		Barriers: domain.BowtieBarriers{
+			{ID: "sod", Kind: domain.BarrierPreventive, PathID: "phish", Label: "Double validation", ControlID: &dual},
+			{ID: "train", Kind: domain.BarrierPreventive, PathID: "phish", Label: "Sensibilisation", Position: 1, MitigationID: &done},
+			{ID: "recall", Kind: domain.BarrierRecovery, PathID: "loss", Label: "Rappel SWIFT", ControlID: &recall, MitigationID: &planned},
+		},
+	}
+	g, err = svc.Save(ctx, tenant, risk.ID, nil, in)
+	require.NoError(t, err)
+	require.NotNil(t, g.Bowtie)
+
+	health := map[string]domain.BarrierHealth{}
+	for _, n := range g.Nodes {
+		health[n.ID] = n.Health
+	}
+	assert.Equal(t, domain.BarrierFailed, health["barrier:sod"], "tested at 0.3")
+	assert.Equal(t, domain.BarrierHealthy, health["barrier:train"])
+	assert.Equal(t, domain.BarrierDegraded, health["barrier:recall"], "untested control, mitigation in progress")
+	assert.Equal(t, []Edge{
+		{From: "threat:phish", To: "barrier:sod"}, {From: "barrier:sod", To: "barrier:train"},
+		{From: "barrier:train", To: "top_event"},
+		{From: "top_event", To: "barrier:recall"}, {From: "barrier:recall", To: "consequence:loss"},
+	}, g.Edges)
+	assert.Equal(t, SideHealth{Healthy: 1, Failed: 1, Loss: 0.5}, g.Preventive)
+	assert.True(t, g.Suggestion.Raises)
+	assert.InDelta(t, 0.55, g.Suggestion.Probability, 1e-9)
+	assert.InDelta(t, 7, g.Suggestion.Impact, 1e-9)
+
+	g, err = svc.ApplySuggestion(ctx, tenant, risk.ID, nil)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.55, repo.risks[risk.ID].Probability, 1e-9)
+	assert.InDelta(t, 7, repo.risks[risk.ID].Impact, 1e-9)
+	assert.Equal(t, []uuid.UUID{risk.ID}, events.risks)
+	assert.InDelta(t, 0.55, g.Suggestion.CurrentProbability, 1e-9)
+
+	assert.False(t, g.Suggestion.Raises, "the same weak barriers do not raise the risk twice")
+	_, err = svc.ApplySuggestion(ctx, tenant, risk.ID, nil)
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+
+	// An edit keeps the applied losses; a further failure raises again.
+	in.Barriers[1].MitigationID = &planned
+	g, err = svc.Save(ctx, tenant, risk.ID, nil, in)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, g.Bowtie.AppliedPreventiveLoss, 1e-9)
+	assert.InDelta(t, 0.75, g.Preventive.Loss, 1e-9, "failed + degraded")
+	assert.True(t, g.Suggestion.Raises)
+	assert.InDelta(t, 0.606, g.Suggestion.Probability, 1e-9, "0.55 + 0.45 × 0.25 × 0.5")
+	assert.InDelta(t, 7, g.Suggestion.Impact, 1e-9)
+}
+
+func TestBowtie_SaveRejectsForeignLinks(t *testing.T) {
+	ctx := context.Background()
+	tenant, other := uuid.New(), uuid.New()
+	risk := &domain.Risk{ID: uuid.New(), TenantID: tenant}
+	otherRisk := uuid.New()
+	theirs, elsewhere := uuid.New(), uuid.New()
+	repo := &memBowties{
+		risks:       map[uuid.UUID]*domain.Risk{risk.ID: risk},
+		bowties:     map[uuid.UUID]domain.Bowtie{},
+		controls:    map[uuid.UUID]domain.ComplianceControl{theirs: {ID: theirs, TenantID: other}},
+		mitigations: []domain.Mitigation{{ID: elsewhere, TenantID: tenant, RiskID: otherRisk}},
+	}
+	svc := NewService(repo)
+	base := func(b domain.BowtieBarrier) domain.Bowtie {
+		b.Kind, b.PathID, b.Label = domain.BarrierPreventive, "t", "b"
+		return domain.Bowtie{TopEvent: "x", Threats: domain.BowtiePaths{{ID: "t", Label: "t"}}, Barriers: domain.BowtieBarriers{b}}
+	}
+
+	_, err := svc.Save(ctx, tenant, risk.ID, nil, base(domain.BowtieBarrier{ControlID: &theirs}))
+	assert.True(t, errors.Is(err, domain.ErrValidation), "another tenant's control")
+	_, err = svc.Save(ctx, tenant, risk.ID, nil, base(domain.BowtieBarrier{MitigationID: &elsewhere}))
+	assert.True(t, errors.Is(err, domain.ErrValidation), "another risk's mitigation")
+	_, err = svc.Save(ctx, other, risk.ID, nil, base(domain.BowtieBarrier{}))
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "another tenant's risk")
+
+	_, err = svc.ApplySuggestion(ctx, tenant, risk.ID, nil)
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "no bowtie, nothing to apply")
+	_, err = svc.Save(ctx, tenant, risk.ID, nil, base(domain.BowtieBarrier{}))
+	require.NoError(t, err)
+	_, err = svc.ApplySuggestion(ctx, tenant, risk.ID, nil)
+	assert.True(t, errors.Is(err, domain.ErrValidation), "unknown barriers raise nothing")
+}
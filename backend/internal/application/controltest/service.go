@@ -0,0 +1,619 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package controltest manages control effectiveness testing: the design and
+// operating test plans of a compliance control, the tests recorded against
+// them, and the residual score each risk derives from the tested
+// effectiveness of the controls it is mapped to.
+package controltest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// AuditSink records plan changes, tests and residual derivations in the audit
+// chain.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// InAppNotifier is the narrow slice of application/notification.UseCase this
+// package needs. Optional: without it a failed test still raises the
+// residuals, nobody is told.
+type InAppNotifier interface {
+	NotifyInApp(userID, tenantID uuid.UUID, notifType domain.NotificationType, subject, message string, resourceID *uuid.UUID, resourceType string) error
+}
+
+// RiskEvents asks the Score Engine to re-score a risk whose mitigation credit
+// moved. Optional and best-effort.
+type RiskEvents interface {
+	PublishRiskUpdated(ctx context.Context, r *domain.Risk) error
+}
+
+// Service is control testing's use cases.
+type Service struct {
+	repo     domain.ControlTestRepository
+	audit    AuditSink
+	notifier InAppNotifier
+	events   RiskEvents
+	now      func() time.Time
+}
+
+// NewService builds the service.
+func NewService(repo domain.ControlTestRepository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// WithAudit enables audit recording.
+func (s *Service) WithAudit(a AuditSink) *Service {
+	s.audit = a
+	return s
+}
+
+// WithNotifier tells risk owners when a failed test raised their risk.
+func (s *Service) WithNotifier(n InAppNotifier) *Service {
+	s.notifier = n
+	return s
+}
+
+// WithEvents enables re-scoring of the risks a test moved.
+func (s *Service) WithEvents(e RiskEvents) *Service {
+	s.events = e
+	return s
+}
+
+// WithClock overrides the clock (tests).
+func (s *Service) WithClock(now func() time.Time) *Service {
+	s.now = now
+	return s
+}
+
+// =============================================================================
+// Plans
+// =============================================================================
+
+// PlanInput is the body of POST /compliance/controls/:controlId/test-plans
+// and PUT /control-test-plans/:id. NextTestAt defaults to now: a new plan is
+// due straight away, because until it is tested the control earns no credit.
+type PlanInput struct {
+	Kind          domain.ControlTestKind `json:"kind"`
+	Procedure     string                 `json:"procedure"`
+	SampleSize    int                    `json:"sample_size"`
+	FrequencyDays int                    `json:"frequency_days"`
+	TesterID      *uuid.UUID             `json:"tester_id"`
+	NextTestAt    *time.Time             `json:"next_test_at"`
+}
+
+// ControlTesting is everything GET /compliance/controls/:controlId/testing
+// shows: the plans, the latest tests and the effectiveness they add up to.
+type ControlTesting struct {
+	ControlID     uuid.UUID                `json:"control_id"`
+	Plans         []domain.ControlTestPlan `json:"plans"`
+	Tests         []domain.ControlTest     `json:"tests"`
+	Effectiveness Effectiveness            `json:"effectiveness"`
+}
+
+// Effectiveness is a control's latest design and operating scores and their
+// combination (domain.ControlEffectiveness).
+type Effectiveness struct {
+	Design    *float64 `json:"design"`
+	Operating *float64 `json:"operating"`
+	Value     float64  `json:"value"`
+	Tested    bool     `json:"tested"`
+}
+
+// recentTests bounds the history ControlTesting returns.
+const recentTests = 50
+
+// ControlTesting returns a control's plans, recent tests and effectiveness.
+func (s *Service) ControlTesting(ctx context.Context, tenantID, controlID uuid.UUID) (*ControlTesting, error) {
+	if _, err := s.control(ctx, tenantID, controlID); err != nil {
+		return nil, err
+	}
+	plans, err := s.repo.ListPlans(ctx, tenantID, []uuid.UUID{controlID})
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	tests, err := s.repo.ListTests(ctx, tenantID, controlID, recentTests)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	s.markDue(plans)
+	return &ControlTesting{
+		ControlID:     controlID,
+		Plans:         nonNilPlans(plans),
+		Tests:         nonNilTests(tests),
+		Effectiveness: effectivenessOf(plans)[controlID],
+	}, nil
+}
+
+// DuePlans lists the tenant's plans whose next test date has passed — the
+// testers' worklist.
+func (s *Service) DuePlans(ctx context.Context, tenantID uuid.UUID) ([]domain.ControlTestPlan, error) {
+	plans, err := s.repo.DuePlans(ctx, tenantID, s.now())
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	s.markDue(plans)
+	return nonNilPlans(plans), nil
+}
+
+// CreatePlan adds a plan to a control. A control has at most one plan per
+// kind: two operating plans would give two answers to "does it run?".
+func (s *Service) CreatePlan(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, controlID uuid.UUID, in PlanInput) (*domain.ControlTestPlan, error) {
+	if _, err := s.control(ctx, tenantID, controlID); err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.ListPlans(ctx, tenantID, []uuid.UUID{controlID})
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	for _, p := range existing {
+		if p.Kind == in.Kind {
+			return nil, domain.NewValidationError(fmt.Sprintf("this control already has a %s test plan", in.Kind))
+		}
+	}
+	p := &domain.ControlTestPlan{
+		ID:         uuid.New(),
+		TenantID:   tenantID,
+		ControlID:  controlID,
+		Kind:       in.Kind,
+		NextTestAt: s.now(),
+		CreatedBy:  actor,
+	}
+	if err := s.savePlan(ctx, p, in); err != nil {
+		return nil, err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionCreate, "control_test_plan", p.ID, "Control test plan created", domain.JSONMap{
+		"control_id": controlID.String(), "kind": string(p.Kind), "frequency_days": p.FrequencyDays,
+	})
+	return p, nil
+}
+
+// UpdatePlan changes a plan's procedure, sampling, cadence or tester. The
+// kind is fixed: a plan's history only means something for the kind it was
+// recorded under.
+func (s *Service) UpdatePlan(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID, in PlanInput) (*domain.ControlTestPlan, error) {
+	p, err := s.plan(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if in.Kind != "" && in.Kind != p.Kind {
+		return nil, domain.NewValidationError("a test plan's kind cannot change; create a plan of the other kind instead")
+	}
+	in.Kind = p.Kind
+	if err := s.savePlan(ctx, p, in); err != nil {
+		return nil, err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, "control_test_plan", p.ID, "Control test plan updated", domain.JSONMap{
+		"frequency_days": p.FrequencyDays, "sample_size": p.SampleSize,
+	})
+	return p, nil
+}
+
+func (s *Service) savePlan(ctx context.Context, p *domain.ControlTestPlan, in PlanInput) error {
+	p.Procedure = in.Procedure
+	p.SampleSize = in.SampleSize
+	p.FrequencyDays = in.FrequencyDays
+	p.TesterID = in.TesterID
+	if in.NextTestAt != nil {
+		p.NextTestAt = in.NextTestAt.UTC()
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if err := s.repo.SavePlan(ctx, p); err != nil {
+		return domain.NewInternalError(err.Error())
+	}
+	p.Due = !p.NextTestAt.After(s.now())
+	return nil
+}
+
+// DeletePlan removes a plan and its tests. Linked risks keep the residual
+// they derived until their next derivation, which no longer counts the plan.
+func (s *Service) DeletePlan(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) error {
+	if _, err := s.plan(ctx, tenantID, id); err != nil {
+		return err
+	}
+	if err := s.repo.DeletePlan(ctx, tenantID, id); err != nil {
+		return err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, "control_test_plan", id, "Control test plan deleted", nil)
+	return nil
+}
+
+// =============================================================================
+// Tests
+// =============================================================================
+
+// TestInput is the body of POST /control-test-plans/:id/tests.
+// SamplesTested defaults to the plan's sample size, TesterID to the caller
+// and PerformedAt to now.
+type TestInput struct {
+	PerformedAt   *time.Time               `json:"performed_at"`
+	SamplesTested int                      `json:"samples_tested"`
+	Exceptions    int                      `json:"exceptions"`
+	Result        domain.ControlTestResult `json:"result"`
+	Notes         string                   `json:"notes"`
+	TesterID      *uuid.UUID               `json:"tester_id"`
+}
+
+// ResidualChange is what a test did to one linked risk.
+type ResidualChange struct {
+	RiskID     uuid.UUID `json:"risk_id"`
+	Title      string    `json:"title"`
+	Before     *float64  `json:"before"`
+	After      float64   `json:"after"`
+	Mitigation float64   `json:"mitigation"`
+}
+
+// TestOutcome says what recording a test did.
+type TestOutcome struct {
+	Test          *domain.ControlTest     `json:"test"`
+	Plan          *domain.ControlTestPlan `json:"plan"`
+	Effectiveness Effectiveness           `json:"effectiveness"`
+	Risks         []ResidualChange        `json:"risks"`
+	// Notified counts the owners told that a failed test raised their risk.
+	Notified int `json:"notified"`
+}
+
+// RecordTest records a test against a plan, re-derives the residual of every
+// risk mapped to the control and, when the control failed, tells each of those
+// risks' owners.
+//
+// A backdated test older than the plan's latest is kept as history but does
+// not become the plan's result: effectiveness is what the control did last.
+func (s *Service) RecordTest(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, planID uuid.UUID, in TestInput) (*TestOutcome, error) {
+	p, err := s.plan(ctx, tenantID, planID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	performed := now
+	if in.PerformedAt != nil {
+		performed = in.PerformedAt.UTC()
+		if performed.After(now) {
+			return nil, domain.NewValidationError("performed_at cannot be in the future")
+		}
+	}
+	t := &domain.ControlTest{
+		ID:            uuid.New(),
+		TenantID:      tenantID,
+		PlanID:        p.ID,
+		ControlID:     p.ControlID,
+		Kind:          p.Kind,
+		TesterID:      in.TesterID,
+		PerformedAt:   performed,
+		SamplesTested: in.SamplesTested,
+		Exceptions:    in.Exceptions,
+		Result:        in.Result,
+		Notes:         in.Notes,
+	}
+	if t.TesterID == nil {
+		t.TesterID = actor
+	}
+	if t.SamplesTested == 0 {
+		t.SamplesTested = p.SampleSize
+	}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	t.Effectiveness = t.Score()
+
+	latest := p.LastTestedAt == nil || !performed.Before(*p.LastTestedAt)
+	if latest {
+		eff := t.Effectiveness
+		p.LastTestedAt = &performed
+		p.LastResult = t.Result
+		p.LastEffectiveness = &eff
+		p.NextTestAt = performed.AddDate(0, 0, p.FrequencyDays)
+	}
+	if err := s.repo.RecordTest(ctx, t, p); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	p.Due = !p.NextTestAt.After(now)
+
+	out := &TestOutcome{Test: t, Plan: p, Risks: []ResidualChange{}}
+	plans, err := s.repo.ListPlans(ctx, tenantID, []uuid.UUID{p.ControlID})
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	out.Effectiveness = effectivenessOf(plans)[p.ControlID]
+
+	if latest {
+		riskIDs, err := s.repo.LinkedRiskIDs(ctx, tenantID, p.ControlID)
+		if err != nil {
+			return nil, domain.NewInternalError(err.Error())
+		}
+		for _, id := range riskIDs {
+			change, risk, err := s.derive(ctx, tenantID, id)
+			if err != nil {
+				return nil, err
+			}
+			if change == nil {
+				continue
+			}
+			out.Risks = append(out.Risks, *change)
+			if t.Result == domain.ControlTestFail && s.notifyOwner(tenantID, risk, change, p) {
+				out.Notified++
+			}
+		}
+	}
+
+	s.record(ctx, tenantID, actor, domain.AuditActionCreate, "control_test", t.ID, "Control test recorded", domain.JSONMap{
+		"plan_id":        p.ID.String(),
+		"control_id":     p.ControlID.String(),
+		"kind":           string(t.Kind),
+		"result":         string(t.Result),
+		"samples_tested": t.SamplesTested,
+		"exceptions":     t.Exceptions,
+		"effectiveness":  t.Effectiveness,
+		"risks_updated":  len(out.Risks),
+	})
+	return out, nil
+}
+
+// notifyOwner tells a risk's owner that a failed test raised its residual.
+// Best-effort: a notification failure never undoes a recorded test.
+func (s *Service) notifyOwner(tenantID uuid.UUID, r *domain.Risk, c *ResidualChange, p *domain.ControlTestPlan) bool {
+	if s.notifier == nil || r.OwnerID == nil {
+		return false
+	}
+	before := "non renseigné"
+	if c.Before != nil {
+		before = fmt.Sprintf("%.2f", *c.Before)
+	}
+	kind := "d'efficacité opérationnelle"
+	if p.Kind == domain.ControlTestDesign {
+		kind = "de conception"
+	}
+	subject := fmt.Sprintf("Contrôle en échec : risque résiduel relevé pour %s", c.Title)
+	message := fmt.Sprintf(
+		"Un test %s d'un contrôle lié à ce risque a échoué. Le contrôle ne compte plus dans la réduction du risque.\n\nRisque résiduel : %s → %.2f",
+		kind, before, c.After,
+	)
+	id := r.ID
+	return s.notifier.NotifyInApp(*r.OwnerID, tenantID, domain.NotificationTypeRiskUpdate, subject, message, &id, "risk") == nil
+}
+
+// =============================================================================
+// Residual derivation
+// =============================================================================
+
+// ControlContribution is one mapped control's part in a risk's residual.
+type ControlContribution struct {
+	ControlID     uuid.UUID            `json:"control_id"`
+	FrameworkID   uuid.UUID            `json:"framework_id"`
+	ReferenceCode string               `json:"reference_code"`
+	Name          string               `json:"name"`
+	Status        domain.ControlStatus `json:"status"`
+	Effectiveness
+}
+
+// ResidualDerivation shows how a risk's residual follows from its controls.
+type ResidualDerivation struct {
+	RiskID   uuid.UUID             `json:"risk_id"`
+	Inherent float64               `json:"inherent"`
+	Controls []ControlContribution `json:"controls"`
+	// Mitigation is the share of the risk the controls remove, capped at
+	// domain.MaxControlCredit.
+	Mitigation float64 `json:"mitigation"`
+	Residual   float64 `json:"residual"`
+	// Current is the residual on the risk now, and Source where it came from;
+	// they differ from the derivation until it is applied.
+	Current *float64 `json:"current"`
+	Source  string   `json:"source"`
+}
+
+// Residual shows the derivation without writing it.
+func (s *Service) Residual(ctx context.Context, tenantID, riskID uuid.UUID) (*ResidualDerivation, error) {
+	risk, err := s.risk(ctx, tenantID, riskID)
+	if err != nil {
+		return nil, err
+	}
+	return s.derivation(ctx, tenantID, risk)
+}
+
+// ApplyResidual derives the residual and writes it onto the risk. A risk with
+// no mapped control has nothing to derive from; its typed figures stand.
+func (s *Service) ApplyResidual(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, riskID uuid.UUID) (*ResidualDerivation, error) {
+	risk, err := s.risk(ctx, tenantID, riskID)
+	if err != nil {
+		return nil, err
+	}
+	d, err := s.derivation(ctx, tenantID, risk)
+	if err != nil {
+		return nil, err
+	}
+	if len(d.Controls) == 0 {
+		return nil, domain.NewValidationError("this risk is not mapped to any control; map it to the controls that treat it first")
+	}
+	if err := s.write(ctx, risk, d); err != nil {
+		return nil, err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, "risk", riskID, "Residual risk derived from control tests", domain.JSONMap{
+		"residual": d.Residual, "mitigation": d.Mitigation, "controls": len(d.Controls),
+	})
+	d.Current, d.Source = &d.Residual, domain.ResidualSourceControls
+	return d, nil
+}
+
+// derive re-derives one linked risk after a test. (nil, nil, nil) when the
+// risk vanished or has no mapped control.
+func (s *Service) derive(ctx context.Context, tenantID, riskID uuid.UUID) (*ResidualChange, *domain.Risk, error) {
+	risk, err := s.repo.GetRisk(ctx, tenantID, riskID)
+	if err != nil {
+		return nil, nil, domain.NewInternalError(err.Error())
+	}
+	if risk == nil {
+		return nil, nil, nil
+	}
+	d, err := s.derivation(ctx, tenantID, risk)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(d.Controls) == 0 {
+		return nil, nil, nil
+	}
+	if err := s.write(ctx, risk, d); err != nil {
+		return nil, nil, err
+	}
+	title := risk.Title
+	if title == "" {
+		title = risk.Name
+	}
+	return &ResidualChange{RiskID: risk.ID, Title: title, Before: d.Current, After: d.Residual, Mitigation: d.Mitigation}, risk, nil
+}
+
+func (s *Service) derivation(ctx context.Context, tenantID uuid.UUID, risk *domain.Risk) (*ResidualDerivation, error) {
+	controls, err := s.repo.MappedControls(ctx, tenantID, risk.ID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	ids := make([]uuid.UUID, 0, len(controls))
+	for _, c := range controls {
+		ids = append(ids, c.ID)
+	}
+	plans, err := s.repo.ListPlans(ctx, tenantID, ids)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	byControl := effectivenessOf(plans)
+
+	d := &ResidualDerivation{
+		RiskID:   risk.ID,
+		Inherent: risk.Score,
+		Controls: make([]ControlContribution, 0, len(controls)),
+		Current:  risk.ResidualRisk,
+		Source:   risk.ResidualSource,
+	}
+	values := make([]float64, 0, len(controls))
+	for _, c := range controls {
+		e := byControl[c.ID]
+		d.Controls = append(d.Controls, ControlContribution{
+			ControlID: c.ID, FrameworkID: c.FrameworkID, ReferenceCode: c.ReferenceCode,
+			Name: c.Name, Status: c.Status, Effectiveness: e,
+		})
+		values = append(values, e.Value)
+	}
+	d.Mitigation, d.Residual = domain.DeriveResidual(risk.Score, values)
+	return d, nil
+}
+
+func (s *Service) write(ctx context.Context, risk *domain.Risk, d *ResidualDerivation) error {
+	if err := s.repo.SetDerivedResidual(ctx, risk.TenantID, risk.ID, d.Mitigation, d.Residual); err != nil {
+		return domain.NewInternalError(err.Error())
+	}
+	if s.events != nil {
+		m := d.Mitigation
+		risk.MitigationEffectiveness = &m
+		_ = s.events.PublishRiskUpdated(ctx, risk)
+	}
+	return nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// effectivenessOf folds plans into each control's Effectiveness.
+func effectivenessOf(plans []domain.ControlTestPlan) map[uuid.UUID]Effectiveness {
+	out := map[uuid.UUID]Effectiveness{}
+	for _, p := range plans {
+		e := out[p.ControlID]
+		if p.LastEffectiveness != nil {
+			v := *p.LastEffectiveness
+			if p.Kind == domain.ControlTestDesign {
+				e.Design = &v
+			} else {
+				e.Operating = &v
+			}
+		}
+		out[p.ControlID] = e
+	}
+	for id, e := range out {
+		e.Tested = e.Design != nil || e.Operating != nil
+		e.Value = domain.ControlEffectiveness(e.Design, e.Operating)
+		out[id] = e
+	}
+	return out
+}
+
+func (s *Service) markDue(plans []domain.ControlTestPlan) {
+	now := s.now()
+	for i := range plans {
+		plans[i].Due = !plans[i].NextTestAt.After(now)
+	}
+	sort.SliceStable(plans, func(i, j int) bool { return plans[i].NextTestAt.Before(plans[j].NextTestAt) })
+}
+
+func (s *Service) control(ctx context.Context, tenantID, id uuid.UUID) (*domain.ComplianceControl, error) {
+	c, err := s.repo.GetControl(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if c == nil {
+		return nil, domain.NewNotFoundError("control", id)
+	}
+	return c, nil
+}
+
+func (s *Service) plan(ctx context.Context, tenantID, id uuid.UUID) (*domain.ControlTestPlan, error) {
+	p, err := s.repo.GetPlan(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if p == nil {
+		return nil, domain.NewNotFoundError("control test plan", id)
+	}
+	return p, nil
+}
+
+func (s *Service) risk(ctx context.Context, tenantID, id uuid.UUID) (*domain.Risk, error) {
+	r, err := s.repo.GetRisk(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if r == nil {
+		return nil, domain.NewNotFoundError("risk", id)
+	}
+	return r, nil
+}
+
+func nonNilPlans(p []domain.ControlTestPlan) []domain.ControlTestPlan {
+	if p == nil {
+		return []domain.ControlTestPlan{}
+	}
+	return p
+}
+
+func nonNilTests(t []domain.ControlTest) []domain.ControlTest {
+	if t == nil {
+		return []domain.ControlTest{}
+	}
+	return t
+}
+
+func (s *Service) record(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, action domain.AuditAction, entity string, id uuid.UUID, summary string, after domain.JSONMap) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    actor,
+		Action:     action,
+		EntityType: entity,
+		EntityID:   id.String(),
+		Summary:    summary,
+		After:      after,
+	})
+}
@@ -0,0 +1,283 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package controltest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+type memControlTests struct {
+	controls map[uuid.UUID]domain.ComplianceControl
+	plans    map[uuid.UUID]domain.ControlTestPlan
+	tests    []domain.ControlTest
+	risks    map[uuid.UUID]*domain.Risk
+	// mapped is risk → controls.
+	mapped map[uuid.UUID][]uuid.UUID
+}
+
+func newMemControlTests() *memControlTests {
+	return &memControlTests{
+		controls: map[uuid.UUID]domain.ComplianceControl{},
+		plans:    map[uuid.UUID]domain.ControlTestPlan{},
+		risks:    map[uuid.UUID]*domain.Risk{},
+		mapped:   map[uuid.UUID][]uuid.UUID{},
+	}
+}
+
+func (m *memControlTests) GetControl(_ context.Context, tenantID, id uuid.UUID) (*domain.ComplianceControl, error) {
+	if c, ok := m.controls[id]; ok && c.TenantID == tenantID {
+		return &c, nil
+	}
+	return nil, nil
+}
+func (m *memControlTests) ListPlans(_ context.Context, tenantID uuid.UUID, controlIDs []uuid.UUID) ([]domain.ControlTestPlan, error) {
+	var out []domain.ControlTestPlan
+	for _, p := range m.plans {
+		if p.TenantID != tenantID {
+			continue
+		}
+		for _, id := range controlIDs {
+			if p.ControlID == id {
+				out = append(out, p)
+			}
+		}
+	}
+	return out, nil
+}
+func (m *memControlTests) DuePlans(_ context.Context, tenantID uuid.UUID, at time.Time) ([]domain.ControlTestPlan, error) {
+	var out []domain.ControlTestPlan
+	for _, p := range m.plans {
+		if p.TenantID == tenantID && !p.NextTestAt.After(at) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+func (m *memControlTests) GetPlan(_ context.Context, tenantID, id uuid.UUID) (*domain.ControlTestPlan, error) {
+	if p, ok := m.plans[id]; ok && p.TenantID == tenantID {
+		return &p, nil
+	}
+	return nil, nil
+}
+func (m *memControlTests) SavePlan(_ context.Context, p *domain.ControlTestPlan) error {
+	m.plans[p.ID] = *p
+	return nil
+}
+func (m *memControlTests) DeletePlan(_ context.Context, tenantID, id uuid.UUID) error {
+	delete(m.plans, id)
+	return nil
+}
+func (m *memControlTests) RecordTest(_ context.Context, t *domain.ControlTest, p *domain.ControlTestPlan) error {
+	m.tests = append(m.tests, *t)
+	m.plans[p.ID] = *p
+	return nil
+}
+func (m *memControlTests) ListTests(_ context.Context, tenantID, controlID uuid.UUID, _ int) ([]domain.ControlTest, error) {
+	var out []domain.ControlTest
+	for _, t := range m.tests {
+		if t.TenantID == tenantID && t.ControlID == controlID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+func (m *memControlTests) GetRisk(_ context.Context, tenantID, id uuid.UUID) (*domain.Risk, error) {
+	if r, ok := m.risks[id]; ok && r.TenantID == tenantID {
+		cp := *r
+		return &cp, nil
+	}
+	return nil, nil
+}
+func (m *memControlTests) LinkedRiskIDs(_ context.Context, tenantID, controlID uuid.UUID) ([]uuid.UUID, error) {
+	var out []uuid.UUID
+	for riskID, controls := range m.mapped {
+		for _, c := range controls {
+			if c == controlID && m.risks[riskID].TenantID == tenantID {
+				out = append(out, riskID)
+			}
+		}
+	}
+	return out, nil
+}
+func (m *memControlTests) MappedControls(_ context.Context, tenantID, riskID uuid.UUID) ([]domain.ComplianceControl, error) {
+	var out []domain.ComplianceControl
+	for _, id := range m.mapped[riskID] {
+		out = append(out, m.controls[id])
+	}
+	return out, nil
+}
+func (m *memControlTests) SetDerivedResidual(_ context.Context, tenantID, riskID uuid.UUID, mitigation, residual float64) error {
+	r := m.risks[riskID]
+	r.ResidualRisk, r.MitigationEffectiveness, r.ResidualSource = &residual, &mitigation, domain.ResidualSourceControls
+	return nil
+}
+
+type notice struct {
+	user    uuid.UUID
+	subject string
+}
+
+type recordedNotices struct{ sent []notice }
+
+func (n *recordedNotices) NotifyInApp(userID, _ uuid.UUID, _ domain.NotificationType, subject, _ string, _ *uuid.UUID, _ string) error {
+	n.sent = append(n.sent, notice{user: userID, subject: subject})
+	return nil
+}
+
+type fixture struct {
+	repo              *memControlTests
+	svc               *Service
+	notices           *recordedNotices
+	tenant            uuid.UUID
+	backups, access   uuid.UUID
+	owned, unowned    uuid.UUID
+	owner             uuid.UUID
+	now               time.Time
+	design, operating *domain.ControlTestPlan
+}
+
+// newFixture: two controls, two risks both mapped to backups, the first also
+// to access control. Backups has a design and an operating plan.
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{repo: newMemControlTests(), notices: &recordedNotices{}, tenant: uuid.New(), owner: uuid.New()}
+	f.now = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	f.svc = NewService(f.repo).WithNotifier(f.notices).WithClock(func() time.Time { return f.now })
+
+	f.backups, f.access = uuid.New(), uuid.New()
+	f.repo.controls[f.backups] = domain.ComplianceControl{ID: f.backups, TenantID: f.tenant, ReferenceCode: "A.8.13", Name: "Backups"}
+	f.repo.controls[f.access] = domain.ComplianceControl{ID: f.access, TenantID: f.tenant, ReferenceCode: "A.5.15", Name: "Access control"}
+
+	f.owned, f.unowned = uuid.New(), uuid.New()
+	f.repo.risks[f.owned] = &domain.Risk{ID: f.owned, TenantID: f.tenant, Title: "Ransomware on ERP", Score: 8, Ownership: domain.Ownership{OwnerID: &f.owner}}
+	f.repo.risks[f.unowned] = &domain.Risk{ID: f.unowned, TenantID: f.tenant, Title: "Data loss", Score: 5}
+	f.repo.mapped[f.owned] = []uuid.UUID{f.backups, f.access}
+	f.repo.mapped[f.unowned] = []uuid.UUID{f.backups}
+
+	ctx := context.Background()
+	var err error
+	f.design, err = f.svc.CreatePlan(ctx, f.tenant, nil, f.backups, PlanInput{Kind: domain.ControlTestDesign})
+	require.NoError(t, err)
+	f.operating, err = f.svc.CreatePlan(ctx, f.tenant, nil, f.backups, PlanInput{Kind: domain.ControlTestOperating, SampleSize: 25})
+	require.NoError(t, err)
+	return f
+}
+
+func TestCreatePlan_OnePerKindAndDueAtOnce(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	assert.True(t, f.operating.Due, "an untested control is due straight away")
+	assert.Equal(t, domain.DefaultOperatingTestDays, f.operating.FrequencyDays)
+
+	_, err := f.svc.CreatePlan(ctx, f.tenant, nil, f.backups, PlanInput{Kind: domain.ControlTestOperating})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "a second operating plan is refused")
+	_, err = f.svc.CreatePlan(ctx, uuid.New(), nil, f.backups, PlanInput{Kind: domain.ControlTestDesign})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "another tenant's control is not found")
+	_, err = f.svc.UpdatePlan(ctx, f.tenant, nil, f.design.ID, PlanInput{Kind: domain.ControlTestOperating})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "a plan's kind is fixed")
+
+	due, err := f.svc.DuePlans(ctx, f.tenant)
+	require.NoError(t, err)
+	assert.Len(t, due, 2)
+}
+
+func TestRecordTest_PassLowersResidualAndMovesPlanOn(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	_, err := f.svc.RecordTest(ctx, f.tenant, nil, f.design.ID, TestInput{Result: domain.ControlTestPass})
+	require.NoError(t, err)
+	out, err := f.svc.RecordTest(ctx, f.tenant, nil, f.operating.ID, TestInput{Result: domain.ControlTestPass, Exceptions: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, 25, out.Test.SamplesTested, "samples default to the plan's sample size")
+	assert.InDelta(t, 0.96, out.Test.Effectiveness, 1e-9)
+	assert.InDelta(t, 0.96, out.Effectiveness.Value, 1e-9, "design 1 × operating 0.96")
+	assert.Equal(t, f.now.AddDate(0, 0, 90), out.Plan.NextTestAt)
+	assert.False(t, out.Plan.Due)
+	require.Len(t, out.Risks, 2)
+	assert.Zero(t, out.Notified, "a pass tells nobody")
+
+	owned := f.repo.risks[f.owned]
+	require.NotNil(t, owned.ResidualRisk)
+	assert.InDelta(t, 0.8, *owned.ResidualRisk, 1e-9, "0.96 is capped at MaxControlCredit: 8 × (1 - 0.9)")
+	assert.Equal(t, domain.ResidualSourceControls, owned.ResidualSource)
+}
+
+func TestRecordTest_FailRaisesResidualAndNotifiesOwners(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	_, err := f.svc.RecordTest(ctx, f.tenant, nil, f.operating.ID, TestInput{Result: domain.ControlTestPass})
+	require.NoError(t, err)
+	before := *f.repo.risks[f.owned].ResidualRisk
+
+	f.now = f.now.Add(24 * time.Hour)
+	out, err := f.svc.RecordTest(ctx, f.tenant, nil, f.operating.ID, TestInput{Result: domain.ControlTestFail, Exceptions: 6})
+	require.NoError(t, err)
+
+	after := *f.repo.risks[f.owned].ResidualRisk
+	assert.Greater(t, after, before)
+	assert.InDelta(t, 8, after, 1e-9, "no control left working: residual = inherent")
+	assert.Equal(t, 1, out.Notified, "the unowned risk has nobody to tell")
+	require.Len(t, f.notices.sent, 1)
+	assert.Equal(t, f.owner, f.notices.sent[0].user)
+	assert.Contains(t, f.notices.sent[0].subject, "Ransomware on ERP")
+}
+
+func TestRecordTest_BackdatedTestIsHistoryOnly(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	_, err := f.svc.RecordTest(ctx, f.tenant, nil, f.operating.ID, TestInput{Result: domain.ControlTestPass})
+	require.NoError(t, err)
+
+	old := f.now.AddDate(0, -3, 0)
+	out, err := f.svc.RecordTest(ctx, f.tenant, nil, f.operating.ID, TestInput{Result: domain.ControlTestFail, PerformedAt: &old})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ControlTestPass, out.Plan.LastResult, "an older test does not replace the latest")
+	assert.Empty(t, out.Risks)
+	assert.Empty(t, f.notices.sent)
+
+	future := f.now.Add(time.Hour)
+	_, err = f.svc.RecordTest(ctx, f.tenant, nil, f.operating.ID, TestInput{Result: domain.ControlTestPass, PerformedAt: &future})
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+}
+
+func TestResidual_DerivationAndApply(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	d, err := f.svc.Residual(ctx, f.tenant, f.owned)
+	require.NoError(t, err)
+	require.Len(t, d.Controls, 2)
+	assert.False(t, d.Controls[0].Tested)
+	assert.Equal(t, 8.0, d.Residual, "untested controls earn no credit")
+	assert.Nil(t, f.repo.risks[f.owned].ResidualRisk, "reading a derivation writes nothing")
+
+	_, err = f.svc.RecordTest(ctx, f.tenant, nil, f.design.ID, TestInput{Result: domain.ControlTestPass})
+	require.NoError(t, err)
+	d, err = f.svc.ApplyResidual(ctx, f.tenant, nil, f.owned)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, d.Mitigation, 1e-9, "a design test alone earns half")
+	assert.InDelta(t, 4, d.Residual, 1e-9)
+	assert.Equal(t, domain.ResidualSourceControls, d.Source)
+
+	lone := uuid.New()
+	f.repo.risks[lone] = &domain.Risk{ID: lone, TenantID: f.tenant, Score: 3}
+	_, err = f.svc.ApplyResidual(ctx, f.tenant, nil, lone)
+	assert.True(t, errors.Is(err, domain.ErrValidation), "a risk without controls keeps its typed figures")
+	_, err = f.svc.Residual(ctx, uuid.New(), f.owned)
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+}
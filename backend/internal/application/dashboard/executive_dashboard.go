@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/application/appetite"
 	"github.com/opendefender/openrisk/internal/application/compliance"
 	"github.com/opendefender/openrisk/internal/application/risk"
 	"github.com/opendefender/openrisk/internal/domain"
@@ -62,6 +63,12 @@ type IncidentSource interface {
 	IncidentAnalytics(ctx context.Context, tenantID string, months int) (*IncidentAnalytics, error)
 }
 
+// AppetiteSource yields the register evaluated against the board's appetite.
+// Satisfied by *appetite.Service.
+type AppetiteSource interface {
+	Evaluate(ctx context.Context, tenantID uuid.UUID) (*appetite.Evaluation, error)
+}
+
 // MonthlyRiskPoint is one month of the register's risk evolution.
 type MonthlyRiskPoint struct {
 	Month    string  `json:"month"` // "YYYY-MM"
@@ -104,6 +111,13 @@ type KRI struct {
 	Severity string  `json:"severity"` // ok | warn | critical
 }
 
+// AppetiteHeadline is the appetite status shown next to the KRIs: the summary
+// counts, and which scopes are breached, by name.
+type AppetiteHeadline struct {
+	appetite.Summary
+	BreachedScopes []string `json:"breached_scopes"`
+}
+
 // ExecRisk is one row of the "top 10 risks" table/heatmap.
 type ExecRisk struct {
 	ID          string    `json:"id"`
@@ -155,6 +169,8 @@ type ExecutiveDashboard struct {
 	RiskDistribution []DistributionSlice  `json:"risk_distribution"`
 	Compliance       []ComplianceCoverage `json:"compliance"`
 	IncidentTrend    []IncidentTrendPoint `json:"incident_trend"`
+	// Appetite is nil when no appetite statement is in force.
+	Appetite *AppetiteHeadline `json:"appetite,omitempty"`
 }
 
 // ---------------------------------------------------------------------------
@@ -188,6 +204,7 @@ type GetExecutiveDashboardUseCase struct {
 	incidents  IncidentSource
 	quantifier *crq.Quantifier
 	activation AhaRecorder
+	appetite   AppetiteSource
 }
 
 // WithActivation attaches the optional Aha recorder.
@@ -225,6 +242,10 @@ func (uc *GetExecutiveDashboardUseCase) WithQuantifier(q *crq.Quantifier) *GetEx
 	uc.quantifier = q
 	return uc
 }
+func (uc *GetExecutiveDashboardUseCase) WithAppetite(s AppetiteSource) *GetExecutiveDashboardUseCase {
+	uc.appetite = s
+	return uc
+}
 
 // Execute assembles the dashboard for a tenant. A failure in any single source is
 // swallowed (that slice degrades to empty) so the board always renders — an
@@ -344,6 +365,24 @@ func (uc *GetExecutiveDashboardUseCase) Execute(ctx context.Context, tenantID uu
 		)
 	}
 
+	// --- Risk appetite -----------------------------------------------------
+	// The KRI counts risks above appetite WITHOUT a valid exception: a risk
+	// the board has knowingly excepted is a decision, not an alert.
+	if uc.appetite != nil {
+		if ev, err := uc.appetite.Evaluate(ctx, tenantID); err == nil && ev != nil && ev.Summary.StatementsInForce > 0 {
+			h := &AppetiteHeadline{Summary: ev.Summary, BreachedScopes: []string{}}
+			for _, st := range ev.Statements {
+				if st.InForce && st.Breached {
+					h.BreachedScopes = append(h.BreachedScopes, st.ScopeLabel)
+				}
+			}
+			out.Appetite = h
+			out.KRIs = append(out.KRIs,
+				KRI{Key: "appetite_breaches", Label: "Risques hors appétence", Value: float64(ev.Summary.WithoutException), Unit: "", Severity: sevForCount(ev.Summary.WithoutException, 1, 3)},
+			)
+		}
+	}
+
 	// --- Cyber score (widget 6) ---------------------------------------------
 	complV, complOK := complianceAxisValue(complAxisImpl, complAxisTotal)
 	riskV, riskOK := riskAxisValue(critCount, highCount, critCount+highCount+distTotal(out.RiskDistribution))
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/application/appetite"
 	"github.com/opendefender/openrisk/internal/application/compliance"
 	"github.com/opendefender/openrisk/internal/application/risk"
 	"github.com/opendefender/openrisk/internal/domain"
@@ -54,6 +55,12 @@ func (f fakeIncidents) IncidentAnalytics(context.Context, string, int) (*Inciden
 	return f.a, nil
 }
 
+type fakeAppetite struct{ ev *appetite.Evaluation }
+
+func (f fakeAppetite) Evaluate(context.Context, uuid.UUID) (*appetite.Evaluation, error) {
+	return f.ev, nil
+}
+
 // --- tests ------------------------------------------------------------------
 
 func TestExecutiveDashboard_Success(t *testing.T) {
@@ -158,3 +165,40 @@ func TestExecutiveDashboard_DegradesWithNoSources(t *testing.T) {
 		t.Errorf("neutral score expected, got %d/%s", out.CyberScore.Score, out.CyberScore.Grade)
 	}
 }
+
+func TestExecutiveDashboard_AppetiteBreachesCountOnlyRisksWithoutException(t *testing.T) {
+	ev := &appetite.Evaluation{
+		Statements: []appetite.StatementResult{
+			{ScopeLabel: "Retail", InForce: true, Breached: true},
+			{ScopeLabel: "Cyber", InForce: false, Breached: true},
+			{ScopeLabel: "Treasury", InForce: true},
+		},
+		Summary: appetite.Summary{StatementsInForce: 2, ScopesBreached: 1, RisksAbove: 3, WithoutException: 2},
+	}
+	out, err := NewGetExecutiveDashboardUseCase().WithAppetite(fakeAppetite{ev: ev}).Execute(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if out.Appetite == nil || len(out.Appetite.BreachedScopes) != 1 || out.Appetite.BreachedScopes[0] != "Retail" {
+		t.Fatalf("only in-force breached scopes are named, got %+v", out.Appetite)
+	}
+	var kri *KRI
+	for i := range out.KRIs {
+		if out.KRIs[i].Key == "appetite_breaches" {
+			kri = &out.KRIs[i]
+		}
+	}
+	if kri == nil || kri.Value != 2 || kri.Severity != "warn" {
+		t.Fatalf("appetite KRI = %+v", kri)
+	}
+
+	// No statement in force: no headline, no KRI — absence of appetite is not
+	// "within appetite".
+	out, err = NewGetExecutiveDashboardUseCase().WithAppetite(fakeAppetite{ev: &appetite.Evaluation{}}).Execute(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if out.Appetite != nil {
+		t.Fatalf("no headline expected, got %+v", out.Appetite)
+	}
+}
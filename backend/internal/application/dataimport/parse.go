@@ -0,0 +1,220 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package dataimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// Supported upload formats.
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+	FormatXLSX = "xlsx"
+)
+
+// table is a parsed file: its header and one column → cell map per row.
+type table struct {
+	columns []string
+	rows    []map[string]string
+}
+
+// detectFormat takes the declared format, falling back on the file extension.
+func detectFormat(declared, fileName string) (string, error) {
+	f := strings.ToLower(strings.TrimSpace(declared))
+	if f == "" {
+		f = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileName)), ".")
+	}
+	switch f {
+	case FormatCSV, FormatJSON, FormatXLSX:
+		return f, nil
+	case "txt":
+		return FormatCSV, nil
+	}
+	return "", domain.NewValidationError(fmt.Sprintf("unsupported file format %q (expected csv, json or xlsx)", f))
+}
+
+// parseTable reads an upload into a table, whatever its format.
+func parseTable(format string, r io.Reader) (*table, error) {
+	raw, err := io.ReadAll(io.LimitReader(r, domain.MaxImportBytes+1))
+	if err != nil {
+		return nil, domain.NewValidationError("could not read the file")
+	}
+	if len(raw) > domain.MaxImportBytes {
+		return nil, domain.NewValidationError(fmt.Sprintf("file is larger than %d MB", domain.MaxImportBytes>>20))
+	}
+
+	var grid [][]string
+	switch format {
+	case FormatCSV:
+		grid, err = readCSV(raw)
+	case FormatXLSX:
+		grid, err = readXLSX(raw)
+	case FormatJSON:
+		return readJSON(raw)
+	default:
+		return nil, domain.NewValidationError("unsupported file format")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fromGrid(grid)
+}
+
+// fromGrid turns a header row plus data rows into a table. Blank header cells
+// are named after their position so no data is dropped silently; fully blank
+// rows are skipped.
+func fromGrid(grid [][]string) (*table, error) {
+	if len(grid) == 0 {
+		return nil, domain.NewValidationError("the file is empty")
+	}
+	header := grid[0]
+	cols := make([]string, len(header))
+	seen := map[string]int{}
+	for i, h := range header {
+		h = strings.TrimSpace(h)
+		if h == "" {
+			h = fmt.Sprintf("column_%d", i+1)
+		}
+		if n := seen[h]; n > 0 {
+			seen[h] = n + 1
+			h = fmt.Sprintf("%s (%d)", h, n+1)
+		} else {
+			seen[h] = 1
+		}
+		cols[i] = h
+	}
+
+	t := &table{columns: cols}
+	for _, rec := range grid[1:] {
+		row := make(map[string]string, len(cols))
+		blank := true
+		for i, col := range cols {
+			if i < len(rec) {
+				v := strings.TrimSpace(rec[i])
+				row[col] = v
+				if v != "" {
+					blank = false
+				}
+			}
+		}
+		if blank {
+			continue
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t, t.check()
+}
+
+func (t *table) check() error {
+	if len(t.rows) == 0 {
+		return domain.NewValidationError("the file has a header but no rows")
+	}
+	if len(t.rows) > domain.MaxImportRows {
+		return domain.NewValidationError(fmt.Sprintf("the file has more than %d rows", domain.MaxImportRows))
+	}
+	return nil
+}
+
+// readCSV accepts comma- and semicolon-separated files (the latter is what a
+// French-locale spreadsheet saves), with or without a byte-order mark.
+func readCSV(raw []byte) ([][]string, error) {
+	raw = bytes.TrimPrefix(raw, []byte("\xef\xbb\xbf"))
+	cr := csv.NewReader(bytes.NewReader(raw))
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	firstLine, _, _ := bytes.Cut(raw, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		cr.Comma = ';'
+	}
+	grid, err := cr.ReadAll()
+	if err != nil {
+		return nil, domain.NewValidationError("malformed CSV: " + err.Error())
+	}
+	return grid, nil
+}
+
+// readJSON accepts an array of flat objects, or an object wrapping one under
+// "items", "records" or "result" (ServiceNow's table API shape). Nested values
+// are kept as their JSON text; arrays of scalars are joined with ";".
+func readJSON(raw []byte) (*table, error) {
+	var items []map[string]any
+	if err := json.Unmarshal(raw, &items); err != nil {
+		var wrapped map[string]json.RawMessage
+		if json.Unmarshal(raw, &wrapped) != nil {
+			return nil, domain.NewValidationError("malformed JSON: expected an array of objects")
+		}
+		found := false
+		for _, k := range []string{"items", "records", "result", "data"} {
+			if inner, ok := wrapped[k]; ok && json.Unmarshal(inner, &items) == nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, domain.NewValidationError("malformed JSON: expected an array of objects")
+		}
+	}
+
+	t := &table{}
+	known := map[string]bool{}
+	for _, it := range items {
+		keys := make([]string, 0, len(it))
+		for k := range it {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		row := make(map[string]string, len(it))
+		for _, k := range keys {
+			if !known[k] {
+				known[k] = true
+				t.columns = append(t.columns, k)
+			}
+			row[k] = strings.TrimSpace(jsonCell(it[k]))
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t, t.check()
+}
+
+func jsonCell(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	case []any:
+		parts := make([]string, 0, len(x))
+		for _, e := range x {
+			parts = append(parts, jsonCell(e))
+		}
+		return strings.Join(parts, ";")
+	case map[string]any:
+		// ServiceNow reference fields export as {"display_value": …, "value": …}.
+		if dv, ok := x["display_value"]; ok {
+			return jsonCell(dv)
+		}
+		if val, ok := x["value"]; ok {
+			return jsonCell(val)
+		}
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
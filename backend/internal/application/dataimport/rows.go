@@ -0,0 +1,766 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package dataimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// rowReader reads one staged row through a mapping and collects what is wrong
+// with it. Every builder below follows the same rule for updates: a blank cell
+// leaves the field as it is. A column is mapped to say "this is where the value
+// lives", not "blank it when the cell is empty" — which is what an export from
+// a tool that omits unset fields would otherwise do to the register.
+type rowReader struct {
+	row    int
+	values domain.ImportRowValues
+	cols   map[string]string // target key → source column
+	errs   []domain.ImportRowError
+}
+
+func (r *rowReader) get(key string) string {
+	col, ok := r.cols[key]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(r.values[col])
+}
+
+func (r *rowReader) fail(key, reason string) {
+	r.errs = append(r.errs, domain.ImportRowError{Row: r.row, Column: r.cols[key], Reason: reason})
+}
+
+// prefixed returns the non-blank cells mapped to targets of a family, keyed
+// by the part after the prefix.
+func (r *rowReader) prefixed(prefix string) map[string]string {
+	out := map[string]string{}
+	for key := range r.cols {
+		if name, ok := strings.CutPrefix(key, prefix); ok {
+			if v := r.get(key); v != "" {
+				out[name] = v
+			}
+		}
+	}
+	return out
+}
+
+// buildContext is what every builder needs besides the row.
+type buildContext struct {
+	tenantID uuid.UUID
+	actor    uuid.UUID
+	lookups  *domain.ImportLookups
+	schemas  map[domain.AssetCategory][]domain.AttributeDef
+}
+
+func (b *buildContext) existing(r *rowReader) (domain.ImportExisting, bool) {
+	ext := r.get("external_id")
+	if ext == "" {
+		return domain.ImportExisting{}, false
+	}
+	e, ok := b.lookups.Existing[strings.ToLower(ext)]
+	return e, ok
+}
+
+func (b *buildContext) user(r *rowReader, key string) *uuid.UUID {
+	email := strings.ToLower(r.get(key))
+	if email == "" {
+		return nil
+	}
+	id, ok := b.lookups.Users[email]
+	if !ok {
+		r.fail(key, fmt.Sprintf("%s is not a member of this organisation", email))
+		return nil
+	}
+	return &id
+}
+
+// ---------------------------------------------------------------------------
+// Risks.
+// ---------------------------------------------------------------------------
+
+func (b *buildContext) risk(r *rowReader) *domain.ImportWrite {
+	existing, update := b.existing(r)
+	title := r.get("title")
+	if !update && title == "" {
+		r.fail("title", "title is required")
+	}
+	if len(title) > 255 {
+		r.fail("title", "title must be 255 characters or less")
+	}
+	description := r.get("description")
+
+	var prob, impact *float64
+	if v := r.get("probability"); v != "" {
+		if p, err := parseProbability(v); err != nil {
+			r.fail("probability", err.Error())
+		} else {
+			prob = &p
+		}
+	}
+	if v := r.get("impact"); v != "" {
+		if f, err := parseNumber(v); err != nil || f < 0 || f > 10 {
+			r.fail("impact", fmt.Sprintf("%q: impact must be a number between 0 and 10", v))
+		} else {
+			impact = &f
+		}
+	}
+
+	var state *domain.RiskState
+	if v := r.get("status"); v != "" {
+		if s, ok := parseRiskState(v); ok {
+			state = &s
+		} else {
+			r.fail("status", fmt.Sprintf("%q is not a risk status", v))
+		}
+	}
+
+	var categoryID *uuid.UUID
+	if v := r.get("category"); v != "" {
+		if id, ok := b.lookups.Categories[strings.ToLower(v)]; ok {
+			categoryID = &id
+		} else {
+			r.fail("category", fmt.Sprintf("no risk category is named %q", v))
+		}
+	}
+	var assetID *uuid.UUID
+	if v := r.get("asset_external_id"); v != "" {
+		if id, ok := b.lookups.Assets[strings.ToLower(v)]; ok {
+			assetID = &id
+		} else {
+			r.fail("asset_external_id", fmt.Sprintf("no asset has the external ID or name %q", v))
+		}
+	}
+	owner := b.user(r, "owner_email")
+	assignee := b.user(r, "assignee_email")
+	tags := parseList(r.get("tags"))
+	frameworks := parseList(r.get("frameworks"))
+	businessUnit := r.get("business_unit")
+	custom := b.customFields(r)
+
+	if len(r.errs) > 0 {
+		return nil
+	}
+
+	if update {
+		u := map[string]any{}
+		if title != "" {
+			u["name"], u["title"] = title, title
+		}
+		if description != "" {
+			u["description"] = description
+		}
+		if prob != nil || impact != nil {
+			p, i := existing.Probability, existing.Impact
+			if prob != nil {
+				p = *prob
+			}
+			if impact != nil {
+				i = *impact
+			}
+			score := p * i
+			u["probability"], u["impact"], u["score"] = p, i, score
+			u["criticality"] = domain.CriticalityFromScore(score)
+		}
+		if state != nil {
+			var tmp domain.Risk
+			tmp.SetState(*state)
+			u["status"], u["lifecycle_phase"], u["lifecycle_state"] = tmp.Status, tmp.LifecyclePhase, tmp.LifecycleState
+		}
+		if len(tags) > 0 {
+			u["tags"] = tags
+		}
+		if len(frameworks) > 0 {
+			u["frameworks"] = frameworks
+		}
+		if categoryID != nil {
+			u["category_id"] = *categoryID
+		}
+		if owner != nil {
+			u["owner_id"] = *owner
+		}
+		if assignee != nil {
+			u["assignee_id"], u["assigned_to"] = *assignee, *assignee
+		}
+		if businessUnit != "" {
+			u["business_unit"] = businessUnit
+		}
+		if assetID != nil {
+			u["asset_id"] = *assetID
+		}
+		if len(custom) > 0 {
+			u["custom_fields"] = mergeJSON(existing.CustomFields, custom)
+		}
+		return &domain.ImportWrite{Row: r.row, EntityID: existing.ID, Update: u}
+	}
+
+	risk := &domain.Risk{
+		ID:             uuid.New(),
+		TenantID:       b.tenantID,
+		OrganizationID: b.tenantID,
+		Name:           title,
+		Title:          title,
+		Description:    description,
+		Tags:           tags,
+		Frameworks:     frameworks,
+		CategoryID:     categoryID,
+		BusinessUnit:   businessUnit,
+		AssetID:        assetID,
+		Source:         domain.SourceImport,
+		ExternalID:     r.get("external_id"),
+		CreatedBy:      b.actor,
+	}
+	if prob != nil {
+		risk.Probability = *prob
+	}
+	if impact != nil {
+		risk.Impact = *impact
+	}
+	// A migrated risk was identified in the tool it came from; starting it as a
+	// draft would hide the whole imported register behind a review queue.
+	if state != nil {
+		risk.SetState(*state)
+	} else {
+		risk.SetState(domain.StateIdentified)
+	}
+	risk.OwnerID = owner
+	if risk.OwnerID == nil && b.actor != uuid.Nil {
+		actor := b.actor
+		risk.OwnerID = &actor
+	}
+	if assignee != nil {
+		risk.AssigneeID, risk.AssignedTo = assignee, assignee
+	}
+	if len(custom) > 0 {
+		risk.CustomFields = mergeJSON(nil, custom)
+	}
+	risk.Score = risk.Impact * risk.Probability
+	risk.Criticality = domain.CriticalityFromScore(risk.Score)
+	return &domain.ImportWrite{Row: r.row, EntityID: risk.ID, Create: risk}
+}
+
+// customFields validates the row's custom-field cells against the tenant's
+// definitions and returns them typed.
+func (b *buildContext) customFields(r *rowReader) map[string]any {
+	cells := r.prefixed(domain.ImportCustomPrefix)
+	if len(cells) == 0 {
+		return nil
+	}
+	defs := make(map[string]domain.CustomField, len(b.lookups.CustomFields))
+	for _, f := range b.lookups.CustomFields {
+		defs[f.Name] = f
+	}
+	names := make([]string, 0, len(cells))
+	for name := range cells {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out := make(map[string]any, len(cells))
+	for _, name := range names {
+		raw := cells[name]
+		key := domain.ImportCustomPrefix + name
+		def, ok := defs[name]
+		if !ok {
+			r.fail(key, fmt.Sprintf("no custom field is named %q", name))
+			continue
+		}
+		v, err := customValue(def, raw)
+		if err != nil {
+			r.fail(key, err.Error())
+			continue
+		}
+		out[name] = v
+	}
+	return out
+}
+
+func customValue(def domain.CustomField, raw string) (any, error) {
+	var rules domain.CustomFieldValidation
+	if len(def.Validation) > 0 {
+		_ = json.Unmarshal(def.Validation, &rules)
+	}
+	switch def.FieldType {
+	case domain.CustomFieldTypeNumber:
+		f, err := parseNumber(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not a number", def.Name, raw)
+		}
+		if rules.Min != nil && f < *rules.Min || rules.Max != nil && f > *rules.Max {
+			return nil, fmt.Errorf("%s: %v is out of range", def.Name, f)
+		}
+		return f, nil
+	case domain.CustomFieldTypeCheckbox:
+		v, ok := parseBool(raw)
+		if !ok {
+			return nil, fmt.Errorf("%s: %q is not yes/no", def.Name, raw)
+		}
+		return v, nil
+	case domain.CustomFieldTypeDate:
+		t, err := parseDate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not a date", def.Name, raw)
+		}
+		return t.Format("2006-01-02"), nil
+	case domain.CustomFieldTypeChoice:
+		if len(rules.AllowedValues) > 0 {
+			for _, a := range rules.AllowedValues {
+				if strings.EqualFold(a, raw) {
+					return a, nil
+				}
+			}
+			return nil, fmt.Errorf("%s: %q is not one of %s", def.Name, raw, strings.Join(rules.AllowedValues, ", "))
+		}
+	}
+	if rules.MaxLength != nil && len(raw) > *rules.MaxLength {
+		return nil, fmt.Errorf("%s: longer than %d characters", def.Name, *rules.MaxLength)
+	}
+	return raw, nil
+}
+
+func mergeJSON(current datatypes.JSON, add map[string]any) datatypes.JSON {
+	merged := map[string]any{}
+	if len(current) > 0 {
+		_ = json.Unmarshal(current, &merged)
+	}
+	for k, v := range add {
+		merged[k] = v
+	}
+	out, _ := json.Marshal(merged)
+	return datatypes.JSON(out)
+}
+
+// ---------------------------------------------------------------------------
+// Assets.
+// ---------------------------------------------------------------------------
+
+func (b *buildContext) asset(r *rowReader) *domain.ImportWrite {
+	existing, update := b.existing(r)
+	name := r.get("name")
+	if !update && name == "" {
+		r.fail("name", "name is required")
+	}
+
+	var category domain.AssetCategory
+	if v := r.get("category"); v != "" {
+		c, err := domain.ParseAssetCategory(strings.ToLower(v))
+		if err != nil {
+			r.fail("category", fmt.Sprintf("%q is not an asset category", v))
+		} else {
+			category = c
+		}
+	}
+	var criticality domain.AssetCriticality
+	if v := r.get("criticality"); v != "" {
+		switch c := domain.AssetCriticality(strings.ToUpper(v)); c {
+		case domain.CriticalityLow, domain.CriticalityMedium, domain.CriticalityHigh, domain.CriticalityCritical:
+			criticality = c
+		default:
+			r.fail("criticality", fmt.Sprintf("%q is not LOW, MEDIUM, HIGH or CRITICAL", v))
+		}
+	}
+
+	// Typed attributes go through the tenant's schema, exactly as on the asset
+	// form: the whole bag is validated, so an update merges the row's cells
+	// into the attributes the asset already has.
+	cells := r.prefixed(domain.ImportAttributePrefix)
+	cat := category
+	var current domain.AssetAttributes
+	if update && existing.Asset != nil {
+		if cat == "" {
+			cat = existing.Asset.Category
+		}
+		current = existing.Asset.Attributes
+	}
+	var attrs domain.AssetAttributes
+	var defs []domain.AttributeDef
+	if len(cells) > 0 || (category != "" && !update) {
+		if cat == "" {
+			r.fail("category", "a category is required to import typed attributes")
+		} else {
+			bag := map[string]any{}
+			for k, v := range current {
+				bag[k] = v
+			}
+			for k, v := range cells {
+				bag[k] = v
+			}
+			defs = b.schemas[cat]
+			a, err := domain.ValidateAttributes(defs, bag)
+			if err != nil {
+				r.errs = append(r.errs, domain.ImportRowError{Row: r.row, Reason: err.Error()})
+			} else {
+				attrs = a
+			}
+		}
+	}
+
+	if len(r.errs) > 0 {
+		return nil
+	}
+
+	if update {
+		u := map[string]any{}
+		if name != "" {
+			u["name"] = name
+		}
+		if v := r.get("type"); v != "" {
+			u["type"] = v
+		}
+		if category != "" {
+			u["category"] = category
+		}
+		if criticality != "" {
+			u["criticality"] = criticality
+		}
+		if v := r.get("owner"); v != "" {
+			u["owner"] = v
+		}
+		if attrs != nil {
+			a := domain.Asset{Attributes: attrs}
+			if existing.Asset != nil {
+				a.Hostnames, a.IPAddresses, a.CPEs = existing.Asset.Hostnames, existing.Asset.IPAddresses, existing.Asset.CPEs
+				a.CloudResourceID = existing.Asset.CloudResourceID
+			}
+			a.RefreshFingerprints(defs)
+			u["attributes"] = attrs
+			u["hostnames"], u["ip_addresses"], u["cpes"] = a.Hostnames, a.IPAddresses, a.CPEs
+			u["cloud_resource_id"] = a.CloudResourceID
+		}
+		return &domain.ImportWrite{Row: r.row, EntityID: existing.ID, Update: u}
+	}
+
+	if criticality == "" {
+		criticality = domain.CriticalityMedium
+	}
+	asset := &domain.Asset{
+		ID:             uuid.New(),
+		TenantID:       b.tenantID,
+		OrganizationID: b.tenantID,
+		Name:           name,
+		Type:           r.get("type"),
+		Criticality:    criticality,
+		Owner:          r.get("owner"),
+		Source:         "IMPORT",
+		ExternalID:     r.get("external_id"),
+		Category:       category,
+		Attributes:     attrs,
+		CPEs:           pq.StringArray{},
+	}
+	asset.RefreshFingerprints(defs)
+	return &domain.ImportWrite{Row: r.row, EntityID: asset.ID, Create: asset}
+}
+
+// ---------------------------------------------------------------------------
+// Controls.
+// ---------------------------------------------------------------------------
+
+func (b *buildContext) control(r *rowReader) *domain.ImportWrite {
+	existing, update := b.existing(r)
+	name := r.get("name")
+	if !update && name == "" {
+		r.fail("name", "name is required")
+	}
+	var frameworkID *uuid.UUID
+	if v := r.get("framework"); v != "" {
+		if id, ok := b.lookups.Frameworks[strings.ToLower(v)]; ok {
+			frameworkID = &id
+		} else {
+			r.fail("framework", fmt.Sprintf("no framework is named %q", v))
+		}
+	} else if !update {
+		r.fail("framework", "framework is required")
+	}
+	var status domain.ControlStatus
+	if v := r.get("status"); v != "" {
+		if s, ok := parseControlStatus(v); ok {
+			status = s
+		} else {
+			r.fail("status", fmt.Sprintf("%q is not a control status", v))
+		}
+	}
+	if len(r.errs) > 0 {
+		return nil
+	}
+
+	if update {
+		u := map[string]any{}
+		if name != "" {
+			u["name"] = name
+		}
+		if frameworkID != nil {
+			u["framework_id"] = *frameworkID
+		}
+		for _, k := range []string{"reference_code", "description", "source_reference"} {
+			if v := r.get(k); v != "" {
+				u[k] = v
+			}
+		}
+		if status != "" {
+			u["status"] = status
+		}
+		return &domain.ImportWrite{Row: r.row, EntityID: existing.ID, Update: u}
+	}
+
+	if status == "" {
+		status = domain.ControlStatusNotImplemented
+	}
+	c := &domain.ComplianceControl{
+		ID:              uuid.New(),
+		TenantID:        b.tenantID,
+		FrameworkID:     *frameworkID,
+		ReferenceCode:   r.get("reference_code"),
+		Name:            name,
+		Description:     r.get("description"),
+		SourceReference: r.get("source_reference"),
+		Status:          status,
+		ExternalID:      r.get("external_id"),
+	}
+	return &domain.ImportWrite{Row: r.row, EntityID: c.ID, Create: c}
+}
+
+// ---------------------------------------------------------------------------
+// Mitigations.
+// ---------------------------------------------------------------------------
+
+func (b *buildContext) mitigation(r *rowReader) *domain.ImportWrite {
+	existing, update := b.existing(r)
+	title := r.get("title")
+	if !update && title == "" {
+		r.fail("title", "title is required")
+	}
+	var riskID *uuid.UUID
+	if v := r.get("risk_external_id"); v != "" {
+		if id, ok := b.lookups.Risks[strings.ToLower(v)]; ok {
+			riskID = &id
+		} else {
+			r.fail("risk_external_id", fmt.Sprintf("no risk has the external ID %q — import the risks first", v))
+		}
+	} else if !update {
+		r.fail("risk_external_id", "the risk is required")
+	}
+	var status domain.MitigationStatus
+	if v := r.get("status"); v != "" {
+		if s, ok := parseMitigationStatus(v); ok {
+			status = s
+		} else {
+			r.fail("status", fmt.Sprintf("%q is not a mitigation status", v))
+		}
+	}
+	var priority domain.MitigationPriority
+	if v := r.get("priority"); v != "" {
+		switch p := domain.MitigationPriority(strings.ToLower(v)); p {
+		case domain.PriorityLow, domain.PriorityMedium, domain.PriorityHigh, domain.PriorityCritical:
+			priority = p
+		default:
+			r.fail("priority", fmt.Sprintf("%q is not low, medium, high or critical", v))
+		}
+	}
+	var due *time.Time
+	if v := r.get("due_date"); v != "" {
+		if t, err := parseDate(v); err != nil {
+			r.fail("due_date", fmt.Sprintf("%q is not a date", v))
+		} else {
+			due = &t
+		}
+	}
+	owner := b.user(r, "owner_email")
+	assignee := b.user(r, "assignee_email")
+	if len(r.errs) > 0 {
+		return nil
+	}
+
+	if update {
+		u := map[string]any{}
+		if title != "" {
+			u["title"] = title
+		}
+		if v := r.get("description"); v != "" {
+			u["description"] = v
+		}
+		if riskID != nil {
+			u["risk_id"] = *riskID
+		}
+		if status != "" {
+			u["status"] = status
+		}
+		if priority != "" {
+			u["priority"] = priority
+		}
+		if due != nil {
+			u["due_date"] = *due
+		}
+		if owner != nil {
+			u["owner_id"] = *owner
+		}
+		if assignee != nil {
+			u["assignee_id"] = *assignee
+		}
+		return &domain.ImportWrite{Row: r.row, EntityID: existing.ID, Update: u}
+	}
+
+	if status == "" {
+		status = domain.MitigationPlanned
+	}
+	if priority == "" {
+		priority = domain.PriorityMedium
+	}
+	m := &domain.Mitigation{
+		ID:             uuid.New(),
+		TenantID:       b.tenantID,
+		OrganizationID: b.tenantID,
+		RiskID:         *riskID,
+		Title:          title,
+		Description:    r.get("description"),
+		Status:         status,
+		Priority:       priority,
+		Progress:       domain.ComputeMitigationProgress(status, 0, 0),
+		CreatedBy:      b.actor,
+		Source:         domain.SourceImport,
+		DueDate:        due,
+		ExternalID:     r.get("external_id"),
+		AssignedTo:     domain.UUIDArray{},
+	}
+	m.OwnerID, m.AssigneeID = owner, assignee
+	return &domain.ImportWrite{Row: r.row, EntityID: m.ID, Create: m}
+}
+
+// ---------------------------------------------------------------------------
+// Cell parsing.
+// ---------------------------------------------------------------------------
+
+func parseNumber(s string) (float64, error) {
+	s = strings.TrimSpace(strings.ReplaceAll(s, ",", "."))
+	return strconv.ParseFloat(s, 64)
+}
+
+// parseProbability accepts a share (0.4) or a percentage ("40%", "40 %").
+func parseProbability(s string) (float64, error) {
+	if pct, ok := strings.CutSuffix(strings.TrimSpace(s), "%"); ok {
+		f, err := parseNumber(pct)
+		if err != nil || f < 0 || f > 100 {
+			return 0, fmt.Errorf("%q: probability must be between 0%% and 100%%", s)
+		}
+		return f / 100, nil
+	}
+	f, err := parseNumber(s)
+	if err != nil || f < 0 || f > 1 {
+		return 0, fmt.Errorf("%q: probability must be between 0 and 1, or a percentage", s)
+	}
+	return f, nil
+}
+
+var dateLayouts = []string{time.RFC3339, "2006-01-02", "2006-01-02 15:04:05", "02/01/2006", "2006/01/02", "02.01.2006"}
+
+func parseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	// Spreadsheets store dates as a serial day count from 1899-12-30.
+	if n, err := strconv.ParseFloat(s, 64); err == nil && n > 0 && n < 100000 {
+		return time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(n)), nil
+	}
+	return time.Time{}, fmt.Errorf("not a date")
+}
+
+func parseBool(s string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "true", "yes", "y", "oui", "x":
+		return true, true
+	case "0", "false", "no", "n", "non":
+		return false, true
+	}
+	return false, false
+}
+
+// parseList splits a multi-value cell on ";", "|" or ",".
+func parseList(s string) pq.StringArray {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	sep := ","
+	if strings.ContainsAny(s, ";|") {
+		sep = ";"
+		s = strings.ReplaceAll(s, "|", ";")
+	}
+	var out pq.StringArray
+	for _, p := range strings.Split(s, sep) {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func statusKey(s string) string {
+	return strings.Trim(strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(s))), "_")
+}
+
+// parseRiskState accepts a lifecycle state key or one of the legacy statuses
+// other tools (and older OpenRisk exports) use.
+func parseRiskState(s string) (domain.RiskState, bool) {
+	k := statusKey(s)
+	if domain.IsRiskState(domain.RiskState(k)) {
+		return domain.RiskState(k), true
+	}
+	switch k {
+	case "open", "new", "identified":
+		return domain.StateIdentified, true
+	case "in_progress", "active", "treatment", "mitigating":
+		return domain.StateInTreatment, true
+	case "mitigated", "treated":
+		return domain.StateMitigated, true
+	case "accepted", "risk_accepted":
+		return domain.StateResidualAccepted, true
+	case "closed", "retired":
+		return domain.StateClosed, true
+	case "draft":
+		return domain.StateDraft, true
+	}
+	return "", false
+}
+
+func parseControlStatus(s string) (domain.ControlStatus, bool) {
+	switch statusKey(s) {
+	case "not_implemented", "not_started", "missing", "no":
+		return domain.ControlStatusNotImplemented, true
+	case "in_progress", "partial", "partially_implemented":
+		return domain.ControlStatusInProgress, true
+	case "implemented", "done", "yes", "effective":
+		return domain.ControlStatusImplemented, true
+	case "not_applicable", "n/a", "na":
+		return domain.ControlStatusNotApplicable, true
+	}
+	return "", false
+}
+
+func parseMitigationStatus(s string) (domain.MitigationStatus, bool) {
+	switch statusKey(s) {
+	case "planned", "todo", "to_do", "open", "new":
+		return domain.MitigationPlanned, true
+	case "in_progress", "doing", "work_in_progress":
+		return domain.MitigationInProgress, true
+	case "review", "in_review":
+		return domain.MitigationReview, true
+	case "done", "completed", "closed", "closed_complete":
+		return domain.MitigationDone, true
+	case "cancelled", "canceled", "closed_incomplete":
+		return domain.MitigationCancelled, true
+	}
+	return "", false
+}
@@ -0,0 +1,609 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package dataimport is the staged import wizard: upload a CSV, JSON or XLSX
+// export of risks, assets, controls or mitigations from another tool, map its
+// columns (a suggestion is made, a saved profile can be reused), dry-run the
+// mapping for row-level errors, apply it as an upsert by ExternalID, and roll
+// the whole batch back if it was wrong. See domain/import_batch.go.
+package dataimport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// AuditSink records imports in the audit chain.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// AssetSchemas lists the tenant's asset attribute schemas, one per category.
+// Satisfied by application/assetschema.Service.
+type AssetSchemas interface {
+	List(ctx context.Context, tenantID uuid.UUID) ([]domain.AssetTypeSchema, error)
+}
+
+// Service is the import wizard's use cases.
+type Service struct {
+	repo    domain.ImportRepository
+	schemas AssetSchemas
+	audit   AuditSink
+	now     func() time.Time
+}
+
+// NewService builds the service.
+func NewService(repo domain.ImportRepository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// WithAssetSchemas wires typed asset attributes. Without it an asset import
+// offers no attribute columns and refuses rows that set a category, the same
+// rule the asset form applies when schemas are unavailable.
+func (s *Service) WithAssetSchemas(a AssetSchemas) *Service {
+	s.schemas = a
+	return s
+}
+
+// WithAudit enables audit recording.
+func (s *Service) WithAudit(a AuditSink) *Service {
+	s.audit = a
+	return s
+}
+
+// WithClock overrides the clock (tests).
+func (s *Service) WithClock(now func() time.Time) *Service {
+	s.now = now
+	return s
+}
+
+// =============================================================================
+// Upload
+// =============================================================================
+
+// UploadInput describes an uploaded file. Format falls back on the extension.
+type UploadInput struct {
+	Entity   domain.ImportEntity `json:"entity"`
+	FileName string              `json:"file_name"`
+	Format   string              `json:"format"`
+}
+
+// Staged is a batch as the wizard shows it: the detected columns, the fields
+// they can map to, the mapping to start from and the first rows.
+type Staged struct {
+	Batch     *domain.ImportBatch      `json:"batch"`
+	Targets   []domain.ImportTarget    `json:"targets"`
+	Mapping   domain.ImportMapping     `json:"mapping"`
+	Suggested bool                     `json:"suggested"`
+	Sample    []domain.ImportRowValues `json:"sample"`
+}
+
+const sampleRows = 5
+
+// Upload parses a file, stages its rows and suggests a mapping.
+func (s *Service) Upload(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, in UploadInput, r io.Reader) (*Staged, error) {
+	if !in.Entity.IsValid() {
+		return nil, domain.NewValidationError("entity must be risk, asset, control or mitigation")
+	}
+	format, err := detectFormat(in.Format, in.FileName)
+	if err != nil {
+		return nil, err
+	}
+	t, err := parseTable(format, r)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &domain.ImportBatch{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Entity:    in.Entity,
+		Status:    domain.ImportStaged,
+		FileName:  truncate(in.FileName, 255),
+		Format:    format,
+		Columns:   t.columns,
+		RowCount:  len(t.rows),
+		CreatedBy: actor,
+	}
+	rows := make([]domain.ImportBatchRow, len(t.rows))
+	for i, v := range t.rows {
+		rows[i] = domain.ImportBatchRow{BatchID: b.ID, RowNo: i + 1, TenantID: tenantID, Values: v}
+	}
+	if err := s.repo.StageBatch(ctx, b, rows); err != nil {
+		return nil, domain.NewInternalError("failed to stage import: " + err.Error())
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionCreate, b.ID,
+		fmt.Sprintf("Staged %d %s rows from %s", b.RowCount, b.Entity, b.FileName), domain.JSONMap{"format": format})
+	return s.staged(ctx, b, rows)
+}
+
+// Get returns a staged batch as Upload did, with the batch's own mapping when
+// it has one.
+func (s *Service) Get(ctx context.Context, tenantID, id uuid.UUID) (*Staged, error) {
+	b, err := s.load(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.Rows(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to read import rows: " + err.Error())
+	}
+	return s.staged(ctx, b, rows)
+}
+
+func (s *Service) staged(ctx context.Context, b *domain.ImportBatch, rows []domain.ImportBatchRow) (*Staged, error) {
+	targets, err := s.Targets(ctx, b.TenantID, b.Entity)
+	if err != nil {
+		return nil, err
+	}
+	out := &Staged{Batch: b, Targets: targets, Mapping: b.Mapping, Sample: []domain.ImportRowValues{}}
+	if len(out.Mapping) == 0 {
+		out.Mapping = domain.SuggestImportMapping(b.Entity, b.Columns, targets[len(domain.ImportTargets(b.Entity)):])
+		out.Suggested = true
+	}
+	for i := 0; i < len(rows) && i < sampleRows; i++ {
+		out.Sample = append(out.Sample, rows[i].Values)
+	}
+	return out, nil
+}
+
+// List returns the tenant's batches, newest first.
+func (s *Service) List(ctx context.Context, tenantID uuid.UUID) ([]domain.ImportBatch, error) {
+	items, err := s.repo.ListBatches(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list imports: " + err.Error())
+	}
+	return items, nil
+}
+
+// Delete discards a batch that is not applied. An applied batch has to be
+// rolled back first: its records are the only way back.
+func (s *Service) Delete(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) error {
+	b, err := s.load(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if b.Status == domain.ImportApplied {
+		return conflict("an applied import must be rolled back before it is deleted")
+	}
+	if err := s.repo.DeleteBatch(ctx, tenantID, id); err != nil {
+		return domain.NewInternalError("failed to delete import: " + err.Error())
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, id, "Deleted import "+b.FileName, nil)
+	return nil
+}
+
+// Targets lists what a column can be mapped to for an entity: its fixed
+// fields, then the tenant's risk custom fields or asset attributes.
+func (s *Service) Targets(ctx context.Context, tenantID uuid.UUID, entity domain.ImportEntity) ([]domain.ImportTarget, error) {
+	if !entity.IsValid() {
+		return nil, domain.NewValidationError("entity must be risk, asset, control or mitigation")
+	}
+	targets := append([]domain.ImportTarget{}, domain.ImportTargets(entity)...)
+	switch entity {
+	case domain.ImportRisks:
+		lk, err := s.repo.Lookups(ctx, tenantID, entity)
+		if err != nil {
+			return nil, domain.NewInternalError("failed to load custom fields: " + err.Error())
+		}
+		targets = append(targets, customTargets(lk.CustomFields)...)
+	case domain.ImportAssets:
+		schemas, err := s.assetSchemas(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, attributeTargets(schemas)...)
+	}
+	return targets, nil
+}
+
+func customTargets(fields []domain.CustomField) []domain.ImportTarget {
+	out := make([]domain.ImportTarget, 0, len(fields))
+	for _, f := range fields {
+		label := f.DisplayName
+		if label == "" {
+			label = f.Name
+		}
+		out = append(out, domain.ImportTarget{
+			Key:     domain.ImportCustomPrefix + f.Name,
+			Label:   label,
+			Aliases: []string{domain.NormaliseImportHeader(f.Name), domain.NormaliseImportHeader(f.DisplayName)},
+		})
+	}
+	return out
+}
+
+// attributeTargets lists every attribute key of every category once. The
+// category of each row decides which of them are valid for it.
+func attributeTargets(schemas map[domain.AssetCategory][]domain.AttributeDef) []domain.ImportTarget {
+	cats := make([]string, 0, len(schemas))
+	for c := range schemas {
+		cats = append(cats, string(c))
+	}
+	sort.Strings(cats)
+	seen := map[string]bool{}
+	var out []domain.ImportTarget
+	for _, c := range cats {
+		for _, d := range schemas[domain.AssetCategory(c)] {
+			if seen[d.Key] {
+				continue
+			}
+			seen[d.Key] = true
+			out = append(out, domain.ImportTarget{
+				Key:     domain.ImportAttributePrefix + d.Key,
+				Label:   d.Label,
+				Aliases: []string{domain.NormaliseImportHeader(d.Key), domain.NormaliseImportHeader(d.Label)},
+			})
+		}
+	}
+	return out
+}
+
+func (s *Service) assetSchemas(ctx context.Context, tenantID uuid.UUID) (map[domain.AssetCategory][]domain.AttributeDef, error) {
+	out := map[domain.AssetCategory][]domain.AttributeDef{}
+	if s.schemas == nil {
+		return out, nil
+	}
+	list, err := s.schemas.List(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sc := range list {
+		out[sc.Category] = sc.Attributes
+	}
+	return out, nil
+}
+
+// =============================================================================
+// Dry run / apply / rollback
+// =============================================================================
+
+// MappingInput selects the mapping to run: explicit, or a saved profile's.
+type MappingInput struct {
+	Mapping   domain.ImportMapping `json:"mapping"`
+	ProfileID *uuid.UUID           `json:"profile_id"`
+}
+
+// ApplyInput is the body of POST /imports/:id/apply. Rows with errors block
+// the import unless SkipInvalid is set, in which case they are left out.
+type ApplyInput struct {
+	MappingInput
+	SkipInvalid bool `json:"skip_invalid"`
+}
+
+// Report is what a dry run found, or what an apply did.
+type Report struct {
+	BatchID uuid.UUID                `json:"batch_id"`
+	Status  domain.ImportBatchStatus `json:"status"`
+	Rows    int                      `json:"rows"`
+	Valid   int                      `json:"valid"`
+	Creates int                      `json:"creates"`
+	Updates int                      `json:"updates"`
+	Errors  []domain.ImportRowError  `json:"errors"`
+}
+
+// DryRun maps and validates every staged row without writing anything but the
+// mapping, which is kept on the batch for the next step.
+func (s *Service) DryRun(ctx context.Context, tenantID, id uuid.UUID, in MappingInput) (*Report, error) {
+	b, err := s.loadStaged(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	report, _, err := s.plan(ctx, b, nil, in)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveBatch(ctx, b); err != nil {
+		return nil, domain.NewInternalError("failed to save import mapping: " + err.Error())
+	}
+	return report, nil
+}
+
+// Apply writes the batch: every valid row, in one transaction.
+func (s *Service) Apply(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID, in ApplyInput) (*Report, error) {
+	b, err := s.loadStaged(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	report, writes, err := s.plan(ctx, b, actor, in.MappingInput)
+	if err != nil {
+		return nil, err
+	}
+	if len(report.Errors) > 0 && !in.SkipInvalid {
+		return nil, domain.NewValidationError(fmt.Sprintf(
+			"%d row(s) have errors; correct the file or the mapping, or apply with skip_invalid", report.Rows-report.Valid))
+	}
+	if len(writes) == 0 {
+		return nil, domain.NewValidationError("no row can be imported")
+	}
+
+	now := s.now().UTC()
+	b.Status = domain.ImportApplied
+	b.AppliedAt = &now
+	b.Created, b.Updated, b.Skipped = report.Creates, report.Updates, report.Rows-report.Valid
+	if err := s.repo.Apply(ctx, b, writes); err != nil {
+		return nil, domain.NewInternalError("failed to apply import: " + err.Error())
+	}
+	report.Status = b.Status
+	s.record(ctx, tenantID, actor, domain.AuditActionCreate, b.ID,
+		fmt.Sprintf("Imported %s file %s: %d created, %d updated, %d skipped", b.Entity, b.FileName, b.Created, b.Updated, b.Skipped),
+		domain.JSONMap{"created": b.Created, "updated": b.Updated, "skipped": b.Skipped})
+	return report, nil
+}
+
+// Rollback undoes an applied batch. It refuses when a row it wrote was edited
+// after the import, unless force is set: putting back the pre-import values
+// would silently discard that later edit.
+func (s *Service) Rollback(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID, force bool) (*domain.ImportBatch, error) {
+	b, err := s.load(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if b.Status != domain.ImportApplied {
+		return nil, conflict("only an applied import can be rolled back")
+	}
+	now := s.now().UTC()
+	b.Status = domain.ImportRolledBack
+	b.RolledBackAt = &now
+	conflicts, err := s.repo.Rollback(ctx, b, force)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to roll back import: " + err.Error())
+	}
+	if len(conflicts) > 0 {
+		return nil, conflict(fmt.Sprintf(
+			"%d record(s) were modified after the import; roll back with force to overwrite those changes", len(conflicts)))
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, b.ID,
+		fmt.Sprintf("Rolled back import %s (%d created, %d updated)", b.FileName, b.Created, b.Updated), nil)
+	return b, nil
+}
+
+// plan maps every staged row of a batch into a write, or into errors.
+func (s *Service) plan(ctx context.Context, b *domain.ImportBatch, actor *uuid.UUID, in MappingInput) (*Report, []domain.ImportWrite, error) {
+	mapping, err := s.resolveMapping(ctx, b, in)
+	if err != nil {
+		return nil, nil, err
+	}
+	lk, err := s.repo.Lookups(ctx, b.TenantID, b.Entity)
+	if err != nil {
+		return nil, nil, domain.NewInternalError("failed to load import lookups: " + err.Error())
+	}
+	bc := &buildContext{tenantID: b.TenantID, lookups: lk}
+	if actor != nil {
+		bc.actor = *actor
+	}
+	extra := customTargets(lk.CustomFields)
+	if b.Entity == domain.ImportAssets {
+		if bc.schemas, err = s.assetSchemas(ctx, b.TenantID); err != nil {
+			return nil, nil, err
+		}
+		extra = attributeTargets(bc.schemas)
+	}
+	if err := mapping.Validate(b.Entity, extra); err != nil {
+		return nil, nil, err
+	}
+	for col := range mapping {
+		if !containsColumn(b.Columns, col) {
+			return nil, nil, domain.NewValidationError(fmt.Sprintf("the file has no column %q", col))
+		}
+	}
+	b.Mapping = mapping
+
+	rows, err := s.repo.Rows(ctx, b.TenantID, b.ID)
+	if err != nil {
+		return nil, nil, domain.NewInternalError("failed to read import rows: " + err.Error())
+	}
+
+	build := map[domain.ImportEntity]func(*rowReader) *domain.ImportWrite{
+		domain.ImportRisks:       bc.risk,
+		domain.ImportAssets:      bc.asset,
+		domain.ImportControls:    bc.control,
+		domain.ImportMitigations: bc.mitigation,
+	}[b.Entity]
+
+	report := &Report{BatchID: b.ID, Status: b.Status, Rows: len(rows), Errors: []domain.ImportRowError{}}
+	cols := mapping.Targets()
+	firstSeen := map[string]int{}
+	var writes []domain.ImportWrite
+	for _, row := range rows {
+		rr := &rowReader{row: row.RowNo, values: row.Values, cols: cols}
+		// Two rows with one ExternalID would both be "new" against the
+		// register and create a duplicate — the thing the key exists to stop.
+		if ext := strings.ToLower(rr.get("external_id")); ext != "" {
+			if first, dup := firstSeen[ext]; dup {
+				rr.fail("external_id", fmt.Sprintf("external ID %q is also on row %d", rr.get("external_id"), first))
+			} else {
+				firstSeen[ext] = row.RowNo
+			}
+		}
+		var w *domain.ImportWrite
+		if len(rr.errs) == 0 {
+			w = build(rr)
+		}
+		if w == nil {
+			report.Errors = append(report.Errors, rr.errs...)
+			continue
+		}
+		report.Valid++
+		if w.Create != nil {
+			report.Creates++
+		} else {
+			report.Updates++
+		}
+		writes = append(writes, *w)
+	}
+	return report, writes, nil
+}
+
+func (s *Service) resolveMapping(ctx context.Context, b *domain.ImportBatch, in MappingInput) (domain.ImportMapping, error) {
+	if len(in.Mapping) > 0 {
+		return in.Mapping, nil
+	}
+	if in.ProfileID != nil {
+		p, err := s.repo.GetProfile(ctx, b.TenantID, *in.ProfileID)
+		if err != nil {
+			return nil, domain.NewInternalError("failed to load mapping profile: " + err.Error())
+		}
+		if p == nil {
+			return nil, domain.NewNotFoundError("mapping profile", in.ProfileID.String())
+		}
+		if p.Entity != b.Entity {
+			return nil, domain.NewValidationError(fmt.Sprintf("profile %q maps %s files, not %s", p.Name, p.Entity, b.Entity))
+		}
+		return p.Mapping, nil
+	}
+	if len(b.Mapping) > 0 {
+		return b.Mapping, nil
+	}
+	return nil, domain.NewValidationError("a mapping or a mapping profile is required")
+}
+
+func containsColumn(cols []string, col string) bool {
+	for _, c := range cols {
+		if c == col {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Service) load(ctx context.Context, tenantID, id uuid.UUID) (*domain.ImportBatch, error) {
+	b, err := s.repo.GetBatch(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to load import: " + err.Error())
+	}
+	if b == nil {
+		return nil, domain.NewNotFoundError("import", id.String())
+	}
+	return b, nil
+}
+
+func (s *Service) loadStaged(ctx context.Context, tenantID, id uuid.UUID) (*domain.ImportBatch, error) {
+	b, err := s.load(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if b.Status != domain.ImportStaged {
+		return nil, conflict(fmt.Sprintf("this import is %s; upload the file again to import it anew", b.Status))
+	}
+	return b, nil
+}
+
+// =============================================================================
+// Mapping profiles
+// =============================================================================
+
+// ProfileInput is the body of POST /import-profiles and PUT /import-profiles/:id.
+type ProfileInput struct {
+	Name    string               `json:"name"`
+	Entity  domain.ImportEntity  `json:"entity"`
+	Mapping domain.ImportMapping `json:"mapping"`
+}
+
+// ListProfiles returns the tenant's saved mappings, for one entity or all.
+func (s *Service) ListProfiles(ctx context.Context, tenantID uuid.UUID, entity domain.ImportEntity) ([]domain.ImportMappingProfile, error) {
+	if entity != "" && !entity.IsValid() {
+		return nil, domain.NewValidationError("entity must be risk, asset, control or mitigation")
+	}
+	items, err := s.repo.ListProfiles(ctx, tenantID, entity)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list mapping profiles: " + err.Error())
+	}
+	return items, nil
+}
+
+// SaveProfile creates a profile (id nil) or replaces one.
+func (s *Service) SaveProfile(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id *uuid.UUID, in ProfileInput) (*domain.ImportMappingProfile, error) {
+	in.Name = strings.TrimSpace(in.Name)
+	if in.Name == "" || len(in.Name) > 120 {
+		return nil, domain.NewValidationError("a profile name of 1 to 120 characters is required")
+	}
+	if !in.Entity.IsValid() {
+		return nil, domain.NewValidationError("entity must be risk, asset, control or mitigation")
+	}
+	if len(in.Mapping) == 0 {
+		return nil, domain.NewValidationError("a profile must map at least one column")
+	}
+	// Columns are the source tool's and cannot be checked here, but the targets
+	// can: a profile that maps onto a field that does not exist would only fail
+	// at the next import.
+	targets, err := s.Targets(ctx, tenantID, in.Entity)
+	if err != nil {
+		return nil, err
+	}
+	if err := in.Mapping.Validate(in.Entity, targets[len(domain.ImportTargets(in.Entity)):]); err != nil {
+		return nil, err
+	}
+
+	p := &domain.ImportMappingProfile{ID: uuid.New(), TenantID: tenantID, CreatedBy: actor}
+	action := domain.AuditActionCreate
+	if id != nil {
+		existing, err := s.repo.GetProfile(ctx, tenantID, *id)
+		if err != nil {
+			return nil, domain.NewInternalError("failed to load mapping profile: " + err.Error())
+		}
+		if existing == nil {
+			return nil, domain.NewNotFoundError("mapping profile", id.String())
+		}
+		p, action = existing, domain.AuditActionUpdate
+	}
+	p.Name, p.Entity, p.Mapping = in.Name, in.Entity, in.Mapping
+	if err := s.repo.SaveProfile(ctx, p); err != nil {
+		return nil, domain.NewInternalError("failed to save mapping profile: " + err.Error())
+	}
+	s.record(ctx, tenantID, actor, action, p.ID, "Saved import mapping profile "+p.Name, nil)
+	return p, nil
+}
+
+// DeleteProfile removes a saved mapping.
+func (s *Service) DeleteProfile(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) error {
+	p, err := s.repo.GetProfile(ctx, tenantID, id)
+	if err != nil {
+		return domain.NewInternalError("failed to load mapping profile: " + err.Error())
+	}
+	if p == nil {
+		return domain.NewNotFoundError("mapping profile", id.String())
+	}
+	if err := s.repo.DeleteProfile(ctx, tenantID, id); err != nil {
+		return domain.NewInternalError("failed to delete mapping profile: " + err.Error())
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, id, "Deleted import mapping profile "+p.Name, nil)
+	return nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+func (s *Service) record(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, action domain.AuditAction, id uuid.UUID, summary string, after domain.JSONMap) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    actor,
+		Action:     action,
+		EntityType: "import_batch",
+		EntityID:   id.String(),
+		Summary:    summary,
+		After:      after,
+	})
+}
+
+func conflict(msg string) error {
+	return &domain.AppError{Err: domain.ErrConflict, Code: http.StatusConflict, Message: msg}
+}
@@ -0,0 +1,339 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package dataimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+type memImports struct {
+	batches  map[uuid.UUID]domain.ImportBatch
+	rows     map[uuid.UUID][]domain.ImportBatchRow
+	profiles map[uuid.UUID]domain.ImportMappingProfile
+	lookups  domain.ImportLookups
+	applied  []domain.ImportWrite
+	// conflicts is what the next Rollback reports.
+	conflicts []uuid.UUID
+}
+
+func newMemImports() *memImports {
+	return &memImports{
+		batches:  map[uuid.UUID]domain.ImportBatch{},
+		rows:     map[uuid.UUID][]domain.ImportBatchRow{},
+		profiles: map[uuid.UUID]domain.ImportMappingProfile{},
+		lookups: domain.ImportLookups{
+			Existing: map[string]domain.ImportExisting{}, Users: map[string]uuid.UUID{},
+			Categories: map[string]uuid.UUID{}, Frameworks: map[string]uuid.UUID{},
+			Risks: map[string]uuid.UUID{}, Assets: map[string]uuid.UUID{},
+		},
+	}
+}
+
+func (m *memImports) ListBatches(_ context.Context, tenantID uuid.UUID) ([]domain.ImportBatch, error) {
+	var out []domain.ImportBatch
+	for _, b := range m.batches {
+		if b.TenantID == tenantID {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+func (m *memImports) GetBatch(_ context.Context, tenantID, id uuid.UUID) (*domain.ImportBatch, error) {
+	if b, ok := m.batches[id]; ok && b.TenantID == tenantID {
+		return &b, nil
+	}
+	return nil, nil
+}
+func (m *memImports) StageBatch(_ context.Context, b *domain.ImportBatch, rows []domain.ImportBatchRow) error {
+	m.batches[b.ID], m.rows[b.ID] = *b, rows
+	return nil
+}
+func (m *memImports) SaveBatch(_ context.Context, b *domain.ImportBatch) error {
+	m.batches[b.ID] = *b
+	return nil
+}
+func (m *memImports) DeleteBatch(_ context.Context, _, id uuid.UUID) error {
+	delete(m.batches, id)
+	return nil
+}
+func (m *memImports) Rows(_ context.Context, tenantID, batchID uuid.UUID) ([]domain.ImportBatchRow, error) {
+	if b, ok := m.batches[batchID]; !ok || b.TenantID != tenantID {
+		return nil, nil
+	}
+	return m.rows[batchID], nil
+}
+func (m *memImports) Records(context.Context, uuid.UUID, uuid.UUID) ([]domain.ImportBatchRecord, error) {
+	return nil, nil
+}
+func (m *memImports) ListProfiles(_ context.Context, tenantID uuid.UUID, entity domain.ImportEntity) ([]domain.ImportMappingProfile, error) {
+	var out []domain.ImportMappingProfile
+	for _, p := range m.profiles {
+		if p.TenantID == tenantID && (entity == "" || p.Entity == entity) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+func (m *memImports) GetProfile(_ context.Context, tenantID, id uuid.UUID) (*domain.ImportMappingProfile, error) {
+	if p, ok := m.profiles[id]; ok && p.TenantID == tenantID {
+		return &p, nil
+	}
+	return nil, nil
+}
+func (m *memImports) SaveProfile(_ context.Context, p *domain.ImportMappingProfile) error {
+	m.profiles[p.ID] = *p
+	return nil
+}
+func (m *memImports) DeleteProfile(_ context.Context, _, id uuid.UUID) error {
+	delete(m.profiles, id)
+	return nil
+}
+func (m *memImports) Lookups(context.Context, uuid.UUID, domain.ImportEntity) (*domain.ImportLookups, error) {
+	lk := m.lookups
+	return &lk, nil
+}
+func (m *memImports) Apply(_ context.Context, b *domain.ImportBatch, writes []domain.ImportWrite) error {
+	m.applied = writes
+	m.batches[b.ID] = *b
+	return nil
+}
+func (m *memImports) Rollback(_ context.Context, b *domain.ImportBatch, force bool) ([]uuid.UUID, error) {
+	if len(m.conflicts) > 0 && !force {
+		return m.conflicts, nil
+	}
+	m.batches[b.ID] = *b
+	return nil, nil
+}
+
+type fixedSchemas []domain.AssetTypeSchema
+
+func (f fixedSchemas) List(context.Context, uuid.UUID) ([]domain.AssetTypeSchema, error) {
+	return f, nil
+}
+
+func upload(t *testing.T, svc *Service, tenant uuid.UUID, entity domain.ImportEntity, name, body string) *Staged {
+	t.Helper()
+	st, err := svc.Upload(context.Background(), tenant, nil, UploadInput{Entity: entity, FileName: name}, strings.NewReader(body))
+	require.NoError(t, err)
+	return st
+}
+
+func TestUpload_SuggestsMappingIncludingCustomFields(t *testing.T) {
+	repo := newMemImports()
+	repo.lookups.CustomFields = []domain.CustomField{{Name: "department", DisplayName: "Département", FieldType: domain.CustomFieldTypeText}}
+	svc := NewService(repo)
+
+	st := upload(t, svc, uuid.New(), domain.ImportRisks, "archer.csv",
+		"\xef\xbb\xbfRisk ID;Risk Title;Likelihood;Owner;Département;Comment\nR-1;Phishing;40%;a@x.io;IT;n/a\n")
+	assert.Equal(t, domain.ImportColumns{"Risk ID", "Risk Title", "Likelihood", "Owner", "Département", "Comment"}, st.Batch.Columns)
+	assert.True(t, st.Suggested)
+	assert.Equal(t, domain.ImportMapping{
+		"Risk ID": "external_id", "Risk Title": "title", "Likelihood": "probability",
+		"Owner": "owner_email", "Département": "custom:department",
+	}, st.Mapping, "unrecognised columns are left for the user")
+	require.Len(t, st.Sample, 1)
+	assert.Equal(t, "Phishing", st.Sample[0]["Risk Title"])
+}
+
+func TestDryRun_ReportsRowErrorsAndUpserts(t *testing.T) {
+	ctx := context.Background()
+	tenant, owner := uuid.New(), uuid.New()
+	repo := newMemImports()
+	repo.lookups.Users["owner@x.io"] = owner
+	repo.lookups.Existing["r-1"] = domain.ImportExisting{ID: uuid.New(), Probability: 0.5, Impact: 4}
+	svc := NewService(repo)
+
+	st := upload(t, svc, tenant, domain.ImportRisks, "risks.csv", strings.Join([]string{
+		"id,title,probability,impact,owner",
+		"R-1,,0.8,,owner@x.io",        // update: blank title keeps the current one
+		"R-2,Ransomware,150%,5,",      // probability out of range
+		"R-3,Fraud,0.2,3,nobody@x.io", // not a member
+		"R-3,Fraud again,0.2,3,",      // duplicate external ID
+		"R-4,Outage,0.1,2,owner@x.io", // create
+	}, "\n"))
+
+	report, err := svc.DryRun(ctx, tenant, st.Batch.ID, MappingInput{Mapping: st.Mapping})
+	require.NoError(t, err)
+	assert.Equal(t, 5, report.Rows)
+	assert.Equal(t, 2, report.Valid)
+	assert.Equal(t, 1, report.Creates)
+	assert.Equal(t, 1, report.Updates)
+	require.Len(t, report.Errors, 3)
+	assert.Equal(t, 2, report.Errors[0].Row)
+	assert.Equal(t, "probability", report.Errors[0].Column)
+	assert.Contains(t, report.Errors[1].Reason, "not a member")
+	assert.Equal(t, 4, report.Errors[2].Row)
+	assert.Equal(t, "id", report.Errors[2].Column)
+
+	_, err = svc.Apply(ctx, tenant, nil, st.Batch.ID, ApplyInput{})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "errors block the import unless skipped")
+	assert.Empty(t, repo.applied)
+
+	report, err = svc.Apply(ctx, tenant, nil, st.Batch.ID, ApplyInput{SkipInvalid: true})
+	require.NoError(t, err, "the dry run's mapping was kept on the batch")
+	assert.Equal(t, domain.ImportApplied, report.Status)
+	require.Len(t, repo.applied, 2)
+	update := repo.applied[0].Update
+	assert.NotContains(t, update, "title")
+	assert.InDelta(t, 3.2, update["score"], 1e-9, "the new probability is scored with the current impact")
+	assert.Equal(t, owner, update["owner_id"])
+	created := repo.applied[1].Create.(*domain.Risk)
+	assert.Equal(t, "R-4", created.ExternalID)
+	assert.Equal(t, domain.SourceImport, created.Source)
+	saved := repo.batches[st.Batch.ID]
+	assert.Equal(t, 3, saved.Skipped)
+
+	_, err = svc.DryRun(ctx, tenant, st.Batch.ID, MappingInput{})
+	assert.True(t, errors.Is(err, domain.ErrConflict), "an applied batch is not re-run")
+}
+
+func TestDryRun_RejectsMappingsOntoUnknownTargetsOrMissingRequired(t *testing.T) {
+	ctx := context.Background()
+	tenant := uuid.New()
+	svc := NewService(newMemImports())
+	st := upload(t, svc, tenant, domain.ImportControls, "controls.csv", "ref,name\nA.5.1,Policies\n")
+
+	_, err := svc.DryRun(ctx, tenant, st.Batch.ID, MappingInput{Mapping: domain.ImportMapping{"ref": "reference_code", "name": "name"}})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "framework is required")
+	_, err = svc.DryRun(ctx, tenant, st.Batch.ID, MappingInput{Mapping: domain.ImportMapping{"name": "nickname"}})
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+	_, err = svc.DryRun(ctx, tenant, st.Batch.ID, MappingInput{Mapping: domain.ImportMapping{"missing": "name"}})
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+}
+
+func TestAssetImport_ValidatesSchemaAttributes(t *testing.T) {
+	ctx := context.Background()
+	tenant := uuid.New()
+	schemas := fixedSchemas{{Category: domain.CategoryServer, Attributes: []domain.AttributeDef{
+		{Key: "hostname", Label: "Hostname", Type: domain.AttrString, Required: true},
+		{Key: "cpu_cores", Label: "CPU cores", Type: domain.AttrInteger},
+	}}}
+	svc := NewService(newMemImports()).WithAssetSchemas(schemas)
+
+	st := upload(t, svc, tenant, domain.ImportAssets, "cmdb.csv", strings.Join([]string{
+		"asset tag,name,category,hostname,cpu cores",
+		"A-1,web-01,server,web-01.lan,8",
+		"A-2,web-02,server,,4",
+		"A-3,web-03,server,web-03.lan,four",
+	}, "\n"))
+	assert.Equal(t, "attr:hostname", st.Mapping["hostname"])
+	assert.Equal(t, "attr:cpu_cores", st.Mapping["cpu cores"])
+
+	report, err := svc.DryRun(ctx, tenant, st.Batch.ID, MappingInput{Mapping: st.Mapping})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Valid)
+	require.Len(t, report.Errors, 2)
+	assert.Contains(t, report.Errors[0].Reason, "Hostname")
+	assert.Contains(t, report.Errors[1].Reason, "CPU cores")
+}
+
+func TestRollback_RefusesConflictsUnlessForced(t *testing.T) {
+	ctx := context.Background()
+	tenant := uuid.New()
+	repo := newMemImports()
+	fw := uuid.New()
+	repo.lookups.Frameworks["iso 27001"] = fw
+	svc := NewService(repo)
+	st := upload(t, svc, tenant, domain.ImportControls, "controls.json",
+		`{"result":[{"sys_id":"c1","framework":{"display_value":"ISO 27001"},"name":"Policies"}]}`)
+
+	_, err := svc.Rollback(ctx, tenant, nil, st.Batch.ID, false)
+	assert.True(t, errors.Is(err, domain.ErrConflict), "a staged batch has nothing to roll back")
+
+	_, err = svc.Apply(ctx, tenant, nil, st.Batch.ID, ApplyInput{MappingInput: MappingInput{Mapping: st.Mapping}})
+	require.NoError(t, err)
+	assert.Equal(t, fw, repo.applied[0].Create.(*domain.ComplianceControl).FrameworkID)
+
+	repo.conflicts = []uuid.UUID{repo.applied[0].EntityID}
+	_, err = svc.Rollback(ctx, tenant, nil, st.Batch.ID, false)
+	assert.True(t, errors.Is(err, domain.ErrConflict))
+	assert.Equal(t, domain.ImportApplied, repo.batches[st.Batch.ID].Status)
+	assert.True(t, errors.Is(svc.Delete(ctx, tenant, nil, st.Batch.ID), domain.ErrConflict), "an applied batch cannot be deleted")
+
+	b, err := svc.Rollback(ctx, tenant, nil, st.Batch.ID, true)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ImportRolledBack, b.Status)
+}
+
+func TestProfiles_ApplyByReference(t *testing.T) {
+	ctx := context.Background()
+	tenant := uuid.New()
+	repo := newMemImports()
+	repo.lookups.Risks["r-1"] = uuid.New()
+	svc := NewService(repo)
+
+	_, err := svc.SaveProfile(ctx, tenant, nil, nil, ProfileInput{Name: "Jira", Entity: domain.ImportMitigations,
+		Mapping: domain.ImportMapping{"Summary": "title"}})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "a profile must map the required fields")
+
+	p, err := svc.SaveProfile(ctx, tenant, nil, nil, ProfileInput{Name: "Jira", Entity: domain.ImportMitigations,
+		Mapping: domain.ImportMapping{"Summary": "title", "Parent": "risk_external_id", "Key": "external_id"}})
+	require.NoError(t, err)
+
+	st := upload(t, svc, tenant, domain.ImportMitigations, "jira.csv", "Key,Summary,Parent\nSEC-1,Patch VPN,R-1\nSEC-2,MFA,R-9\n")
+	report, err := svc.DryRun(ctx, tenant, st.Batch.ID, MappingInput{ProfileID: &p.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Valid)
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, "Parent", report.Errors[0].Column)
+
+	other := upload(t, svc, tenant, domain.ImportRisks, "risks.csv", "title\nA\n")
+	_, err = svc.DryRun(ctx, tenant, other.Batch.ID, MappingInput{ProfileID: &p.ID})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "a mitigation profile does not apply to risks")
+}
+
+func TestParseTable_XLSX(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range map[string]string{
+		"xl/workbook.xml": `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Risks" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/sharedStrings.xml":       `<sst><si><t>Title</t></si><si><t>Impact</t></si><si><r><t>Data </t></r><r><t>leak</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>` +
+			`<row r="2"><c r="A2" t="s"><v>2</v></c><c r="C2"><v>7</v></c></row>` +
+			`</sheetData></worksheet>`,
+	} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	tbl, err := parseTable(FormatXLSX, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Title", "column_2", "Impact"}, tbl.columns)
+	require.Len(t, tbl.rows, 1)
+	assert.Equal(t, "Data leak", tbl.rows[0]["Title"])
+	assert.Equal(t, "7", tbl.rows[0]["Impact"])
+}
+
+func TestParseTable_RejectsEmptyAndOversized(t *testing.T) {
+	_, err := parseTable(FormatCSV, strings.NewReader("title\n"))
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+
+	rows := make([]map[string]string, domain.MaxImportRows+1)
+	for i := range rows {
+		rows[i] = map[string]string{"t": "x"}
+	}
+	raw, _ := json.Marshal(rows)
+	_, err = parseTable(FormatJSON, bytes.NewReader(raw))
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package dataimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// readXLSX returns the cells of the first worksheet of a workbook.
+//
+// An .xlsx file is a zip of XML parts; reading values out of it needs the
+// workbook (sheet order), its relationships (which part is that sheet), the
+// shared-string table and the sheet itself. That is all this does: no styles,
+// no formulas (the cached value is read), no dates beyond what the cell holds.
+// It covers what GRC tools and spreadsheets export, without a dependency.
+func readXLSX(raw []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		return nil, domain.NewValidationError("malformed XLSX: not a zip archive")
+	}
+	parts := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		parts[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(parts)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := sharedStrings(parts["xl/sharedStrings.xml"])
+	if err != nil {
+		return nil, err
+	}
+	sheet := parts[sheetPath]
+	if sheet == nil {
+		return nil, domain.NewValidationError("malformed XLSX: first worksheet is missing")
+	}
+
+	var ws struct {
+		Rows []struct {
+			Cells []struct {
+				Ref    string `xml:"r,attr"`
+				Type   string `xml:"t,attr"`
+				Value  string `xml:"v"`
+				Inline struct {
+					Text string `xml:"t"`
+					Runs []struct {
+						Text string `xml:"t"`
+					} `xml:"r"`
+				} `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodePart(sheet, &ws); err != nil {
+		return nil, err
+	}
+
+	grid := make([][]string, 0, len(ws.Rows))
+	for _, row := range ws.Rows {
+		var cells []string
+		for i, c := range row.Cells {
+			col := i
+			if ci := columnIndex(c.Ref); ci >= 0 {
+				col = ci
+			}
+			for len(cells) <= col {
+				cells = append(cells, "")
+			}
+			switch c.Type {
+			case "s":
+				if n, err := strconv.Atoi(c.Value); err == nil && n >= 0 && n < len(shared) {
+					cells[col] = shared[n]
+				}
+			case "inlineStr":
+				text := c.Inline.Text
+				for _, r := range c.Inline.Runs {
+					text += r.Text
+				}
+				cells[col] = text
+			case "b":
+				cells[col] = map[string]string{"1": "true", "0": "false"}[c.Value]
+			default:
+				cells[col] = c.Value
+			}
+		}
+		grid = append(grid, cells)
+	}
+	return grid, nil
+}
+
+func firstSheetPath(parts map[string]*zip.File) (string, error) {
+	var wb struct {
+		Sheets []struct {
+			RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodePart(parts["xl/workbook.xml"], &wb); err != nil || len(wb.Sheets) == 0 {
+		return "", domain.NewValidationError("malformed XLSX: the workbook lists no sheet")
+	}
+	var rels struct {
+		Rels []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodePart(parts["xl/_rels/workbook.xml.rels"], &rels); err != nil {
+		return "", domain.NewValidationError("malformed XLSX: workbook relationships are missing")
+	}
+	for _, r := range rels.Rels {
+		if r.ID != wb.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(r.Target, "/") {
+			return strings.TrimPrefix(r.Target, "/"), nil
+		}
+		return path.Join("xl", r.Target), nil
+	}
+	return "", domain.NewValidationError("malformed XLSX: first worksheet is missing")
+}
+
+func sharedStrings(f *zip.File) ([]string, error) {
+	if f == nil {
+		return nil, nil // a workbook of numbers only has no shared strings
+	}
+	var sst struct {
+		Items []struct {
+			Text string `xml:"t"`
+			Runs []struct {
+				Text string `xml:"t"`
+			} `xml:"r"`
+		} `xml:"si"`
+	}
+	if err := decodePart(f, &sst); err != nil {
+		return nil, err
+	}
+	out := make([]string, len(sst.Items))
+	for i, it := range sst.Items {
+		text := it.Text
+		for _, r := range it.Runs {
+			text += r.Text
+		}
+		out[i] = text
+	}
+	return out, nil
+}
+
+func decodePart(f *zip.File, into any) error {
+	if f == nil {
+		return domain.NewValidationError("malformed XLSX: a required part is missing")
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return domain.NewValidationError("malformed XLSX: " + err.Error())
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(io.LimitReader(rc, 8*domain.MaxImportBytes)).Decode(into); err != nil {
+		return domain.NewValidationError("malformed XLSX: " + err.Error())
+	}
+	return nil
+}
+
+// columnIndex turns a cell reference ("C7", "AA12") into a 0-based column.
+func columnIndex(ref string) int {
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		n = n*26 + int(r-'A'+1)
+	}
+	return n - 1
+}
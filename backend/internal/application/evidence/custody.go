@@ -0,0 +1,269 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package evidence
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/internal/infrastructure/audittrail"
+)
+
+// AuditSink records an event on the tenant's hash-chained audit trail.
+// Satisfied by governance.AuditRecorder.
+type AuditSink interface {
+	Record(ctx context.Context, ev domain.AuditEvent)
+}
+
+// ErrIntegrity is returned (wrapped) when stored bytes no longer hash to the
+// digest recorded at upload.
+var ErrIntegrity = errors.New("evidence file does not match its recorded digest")
+
+// WithCustody turns on the chain-of-custody log. Both are optional and
+// nil-safe: without them the library still hashes uploads and verifies
+// downloads, it just keeps no record of who did so.
+func (s *Service) WithCustody(repo domain.EvidenceCustodyRepository, audit AuditSink) *Service {
+	if repo != nil {
+		s.custody = repo
+	}
+	if audit != nil {
+		s.audit = audit
+	}
+	return s
+}
+
+// custodyAuditAction maps a custody verb onto the audit trail's vocabulary, so
+// the journal filters ("show me every download") work without knowing custody
+// exists.
+func custodyAuditAction(a domain.CustodyAction) domain.AuditAction {
+	switch a {
+	case domain.CustodyCollected:
+		return domain.AuditActionCreate
+	case domain.CustodyDownloaded:
+		return domain.AuditActionDownload
+	case domain.CustodyPackaged:
+		return domain.AuditActionExport
+	case domain.CustodyReviewed, domain.CustodyReplaced:
+		return domain.AuditActionUpdate
+	default:
+		return domain.AuditActionView
+	}
+}
+
+// recordCustody appends one custody entry and chains it into the audit trail.
+//
+// Best-effort, like every other journal write in the product: a custody store
+// that is down must not make proof unreadable in the middle of an audit. The
+// failure is logged loudly instead, because a gap in custody is exactly what an
+// auditor will ask about.
+func (s *Service) recordCustody(ctx context.Context, ev *domain.Evidence, action domain.CustodyAction, detail string) {
+	if s.custody == nil && s.audit == nil {
+		return
+	}
+	entry := &domain.EvidenceCustodyEvent{
+		ID:         uuid.New(),
+		TenantID:   ev.TenantID,
+		EvidenceID: ev.ID,
+		Action:     action,
+		SHA256:     ev.SHA256,
+		Detail:     detail,
+		CreatedAt:  s.now().UTC(),
+	}
+	if actor, ok := audittrail.ActorFromContext(ctx); ok {
+		entry.ActorID = actor.ID
+		entry.IPAddress = actor.IPAddress
+	}
+	if s.custody != nil {
+		if err := s.custody.AppendCustody(ctx, entry); err != nil {
+			log.Printf("evidence: custody entry %s for %s not recorded: %v", action, ev.ID, err)
+			return
+		}
+	}
+	if s.audit == nil {
+		return
+	}
+	summary := fmt.Sprintf("Evidence %q %s", ev.Title, action)
+	if detail != "" {
+		summary += ": " + detail
+	}
+	// The custody id and digest ride in the chained entry. Editing a custody row
+	// afterwards therefore leaves it disagreeing with a record that cannot be
+	// edited without breaking the chain.
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   ev.TenantID,
+		ActorID:    entry.ActorID,
+		Action:     custodyAuditAction(action),
+		EntityType: "evidence",
+		EntityID:   ev.ID.String(),
+		Summary:    summary,
+		After: domain.JSONMap{
+			"custody_id":     entry.ID.String(),
+			"custody_action": string(action),
+			"sha256":         ev.SHA256,
+		},
+	})
+}
+
+// Custody returns an artifact's chain of custody, oldest first.
+func (s *Service) Custody(ctx context.Context, tenantID, id uuid.UUID) ([]domain.EvidenceCustodyEvent, error) {
+	ev, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if ev == nil {
+		return nil, domain.NewNotFoundError("evidence", id)
+	}
+	if s.custody == nil {
+		return []domain.EvidenceCustodyEvent{}, nil
+	}
+	rows, err := s.custody.ListCustody(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if rows == nil {
+		rows = []domain.EvidenceCustodyEvent{}
+	}
+	if s.users != nil {
+		var ids []uuid.UUID
+		for _, r := range rows {
+			if r.ActorID != nil {
+				ids = append(ids, *r.ActorID)
+			}
+		}
+		if len(ids) > 0 {
+			if emails, err := s.users.EmailsByIDs(ctx, ids); err == nil {
+				for i := range rows {
+					if rows[i].ActorID != nil {
+						rows[i].ActorEmail = emails[*rows[i].ActorID]
+					}
+				}
+			}
+		}
+	}
+	return rows, nil
+}
+
+// Verify re-reads the stored bytes and compares them with the digest recorded
+// at upload. The answer is recorded in the chain of custody either way — a
+// passing check is itself something an auditor may want to see dated.
+func (s *Service) Verify(ctx context.Context, tenantID, id uuid.UUID) (*domain.EvidenceIntegrity, error) {
+	ev, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if ev == nil {
+		return nil, domain.NewNotFoundError("evidence", id)
+	}
+	if ev.FileRef == "" {
+		return nil, domain.NewValidationError("this evidence holds no file (it is a link or a statement)")
+	}
+	content, err := s.storage.Open(ctx, ev.FileRef)
+	if err != nil {
+		return nil, domain.NewInternalError("evidence file is missing from storage: " + err.Error())
+	}
+	defer content.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, content)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to read evidence file: " + err.Error())
+	}
+	res := &domain.EvidenceIntegrity{
+		EvidenceID: ev.ID,
+		Expected:   ev.SHA256,
+		Actual:     hex.EncodeToString(h.Sum(nil)),
+		SizeBytes:  n,
+		Recorded:   ev.SHA256 != "",
+		CheckedAt:  s.now().UTC(),
+	}
+	res.Verified = res.Recorded && res.Actual == res.Expected
+
+	switch {
+	case res.Verified:
+		s.recordCustody(ctx, ev, domain.CustodyVerified, "digest matches")
+	case res.Recorded:
+		s.recordCustody(ctx, ev, domain.CustodyIntegrityFailure, "stored bytes hash to "+res.Actual)
+	default:
+		s.recordCustody(ctx, ev, domain.CustodyVerified, "no digest on record; current digest "+res.Actual)
+	}
+	return res, nil
+}
+
+// hashingReader counts and hashes what flows through it. Used on upload, where
+// the digest has to be computed in the same pass that writes to storage: the
+// body is a stream and there is no second read.
+type hashingReader struct {
+	r io.Reader
+	h hash.Hash
+	n int64
+}
+
+func newHashingReader(r io.Reader) *hashingReader {
+	return &hashingReader{r: r, h: sha256.New()}
+}
+
+func (h *hashingReader) Read(p []byte) (int, error) {
+	n, err := h.r.Read(p)
+	if n > 0 {
+		h.h.Write(p[:n])
+		h.n += int64(n)
+	}
+	return n, err
+}
+
+func (h *hashingReader) Sum() string { return hex.EncodeToString(h.h.Sum(nil)) }
+
+// verifyingReader checks a download against the recorded digest as it streams.
+//
+// The mismatch can only be known at EOF, after the bytes have been sent, so it
+// surfaces as a read error on the final chunk: the HTTP response is cut short
+// rather than completing normally, and the client sees a failed download
+// instead of a silently different file. The custody log gets the failure.
+type verifyingReader struct {
+	rc       io.ReadCloser
+	h        hash.Hash
+	expected string
+	onFail   func(actual string)
+	failed   bool
+}
+
+func (v *verifyingReader) Read(p []byte) (int, error) {
+	n, err := v.rc.Read(p)
+	if n > 0 {
+		v.h.Write(p[:n])
+	}
+	if err == io.EOF && !v.failed {
+		if actual := hex.EncodeToString(v.h.Sum(nil)); !strings.EqualFold(actual, v.expected) {
+			v.failed = true
+			if v.onFail != nil {
+				v.onFail(actual)
+			}
+			return n, fmt.Errorf("%w (expected %s, got %s)", ErrIntegrity, v.expected, actual)
+		}
+	}
+	return n, err
+}
+
+func (v *verifyingReader) Close() error { return v.rc.Close() }
+
+// actorFrom returns the acting user stamped on the request context, if any.
+func actorFrom(ctx context.Context) (*uuid.UUID, bool) {
+	actor, ok := audittrail.ActorFromContext(ctx)
+	if !ok || actor.ID == nil {
+		return nil, false
+	}
+	return actor.ID, true
+}
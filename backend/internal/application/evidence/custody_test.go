@@ -0,0 +1,310 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package evidence
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/internal/infrastructure/audittrail"
+	"github.com/opendefender/openrisk/pkg/crypto"
+)
+
+type fakeCustody struct{ rows []domain.EvidenceCustodyEvent }
+
+func (f *fakeCustody) AppendCustody(_ context.Context, e *domain.EvidenceCustodyEvent) error {
+	f.rows = append(f.rows, *e)
+	return nil
+}
+
+func (f *fakeCustody) ListCustody(_ context.Context, tenantID, evidenceID uuid.UUID) ([]domain.EvidenceCustodyEvent, error) {
+	var out []domain.EvidenceCustodyEvent
+	for _, r := range f.rows {
+		if r.TenantID == tenantID && r.EvidenceID == evidenceID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+type fakeAudit struct{ events []domain.AuditEvent }
+
+func (f *fakeAudit) Record(_ context.Context, ev domain.AuditEvent) { f.events = append(f.events, ev) }
+
+func (f *fakeCustody) actions() []domain.CustodyAction {
+	out := make([]domain.CustodyAction, 0, len(f.rows))
+	for _, r := range f.rows {
+		out = append(out, r.Action)
+	}
+	return out
+}
+
+func digest(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestCustody_UploadIsHashedAndEveryHandlingIsChained(t *testing.T) {
+	f := setup(t)
+	custody, audit := &fakeCustody{}, &fakeAudit{}
+	f.svc.WithCustody(custody, audit)
+	actor := uuid.New()
+	ctx := audittrail.WithActor(context.Background(), audittrail.Actor{ID: &actor, TenantID: f.tenant, IPAddress: "10.0.0.7"})
+
+	ev, err := f.svc.Create(ctx, f.tenant, CreateInput{Title: "Pen test", Filename: "report.pdf", Content: strings.NewReader("findings")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.SHA256 != digest("findings") || ev.SizeBytes != int64(len("findings")) {
+		t.Fatalf("digest not recorded on upload: %s / %d", ev.SHA256, ev.SizeBytes)
+	}
+
+	if _, err := f.svc.Get(ctx, f.tenant, ev.ID); err != nil {
+		t.Fatal(err)
+	}
+	_, rc, err := f.svc.Download(ctx, f.tenant, ev.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body, err := io.ReadAll(rc); err != nil || string(body) != "findings" {
+		t.Fatalf("intact download must read cleanly: %q, %v", body, err)
+	}
+	if _, err := f.svc.Review(ctx, f.tenant, ev.ID, actor, "accepted", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []domain.CustodyAction{domain.CustodyCollected, domain.CustodyViewed, domain.CustodyDownloaded, domain.CustodyReviewed}
+	if got := custody.actions(); len(got) != len(want) {
+		t.Fatalf("custody = %v, want %v", got, want)
+	}
+	if len(audit.events) != len(custody.rows) {
+		t.Fatalf("every custody entry must be chained: %d audit vs %d custody", len(audit.events), len(custody.rows))
+	}
+	for i, row := range custody.rows {
+		if row.ActorID == nil || *row.ActorID != actor || row.IPAddress != "10.0.0.7" {
+			t.Fatalf("custody row %d not attributed: %+v", i, row)
+		}
+		if audit.events[i].After["custody_id"] != row.ID.String() {
+			t.Fatalf("audit entry %d does not name its custody row", i)
+		}
+	}
+	if audit.events[2].Action != domain.AuditActionDownload {
+		t.Fatalf("download should journal as download, got %s", audit.events[2].Action)
+	}
+}
+
+func TestCustody_TamperedFileFailsDownloadAndVerify(t *testing.T) {
+	f := setup(t)
+	custody := &fakeCustody{}
+	f.svc.WithCustody(custody, &fakeAudit{})
+	ctx := context.Background()
+
+	ev, _ := f.svc.Create(ctx, f.tenant, CreateInput{Title: "SOC 2 report", Filename: "soc2.pdf", Content: strings.NewReader("original")})
+	// Someone with bucket access swaps the bytes.
+	f.store.saved[ev.FileRef] = []byte("forged")
+
+	_, rc, err := f.svc.Download(ctx, f.tenant, ev.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(rc); !errors.Is(err, ErrIntegrity) {
+		t.Fatalf("a tampered download must fail at EOF, got %v", err)
+	}
+
+	res, err := f.svc.Verify(ctx, f.tenant, ev.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Verified || !res.Recorded || res.Actual != digest("forged") {
+		t.Fatalf("verify should report the mismatch: %+v", res)
+	}
+
+	failures := 0
+	for _, a := range custody.actions() {
+		if a == domain.CustodyIntegrityFailure {
+			failures++
+		}
+	}
+	if failures != 2 {
+		t.Fatalf("both failures belong in custody, got %v", custody.actions())
+	}
+}
+
+func TestReplace_MovesDigestAndKeepsThePreviousOneInCustody(t *testing.T) {
+	f := setup(t)
+	custody := &fakeCustody{}
+	f.svc.WithCustody(custody, nil)
+	ctx := context.Background()
+
+	ev, _ := f.svc.Create(ctx, f.tenant, CreateInput{
+		Title: "ISO certificate", Filename: "iso-2025.pdf", Content: strings.NewReader("v1"),
+		ControlIDs: []uuid.UUID{f.c1},
+	})
+	oldRef := ev.FileRef
+
+	got, err := f.svc.Replace(ctx, f.tenant, ev.ID, ReplaceInput{Filename: "iso-2026.pdf", Content: strings.NewReader("v2")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SHA256 != digest("v2") || got.Filename != "iso-2026.pdf" || len(got.ControlIDs) != 1 {
+		t.Fatalf("replacement should keep the row and its links, move the digest: %+v", got)
+	}
+	if _, still := f.store.saved[oldRef]; still {
+		t.Fatal("the previous file should be released")
+	}
+	last := custody.rows[len(custody.rows)-1]
+	if last.Action != domain.CustodyReplaced || !strings.Contains(last.Detail, digest("v1")) {
+		t.Fatalf("custody must carry the replaced digest: %+v", last)
+	}
+
+	// An expiry already past cannot carry over to a file collected today.
+	past := f.now.Add(-1)
+	ev2, _ := f.svc.Create(ctx, f.tenant, CreateInput{
+		Title: "Old cert", Filename: "c.pdf", Content: strings.NewReader("x"),
+		CollectedAt: evAt(f.now.AddDate(-2, 0, 0)), ValidUntil: &past,
+	})
+	if _, err := f.svc.Replace(ctx, f.tenant, ev2.ID, ReplaceInput{Content: strings.NewReader("y")}); err == nil {
+		t.Fatal("replacing an expired artifact without a new expiry should be refused")
+	}
+}
+
+type fakeAudits map[uuid.UUID]domain.ComplianceAudit
+
+func (f fakeAudits) GetAuditByID(_ context.Context, id, tenantID uuid.UUID) (*domain.ComplianceAudit, error) {
+	a, ok := f[id]
+	if !ok || a.TenantID != tenantID {
+		return nil, nil
+	}
+	return &a, nil
+}
+
+// readZip indexes an archive by entry name.
+func readZip(t *testing.T, b []byte) map[string][]byte {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := map[string][]byte{}
+	for _, zf := range zr.File {
+		rc, err := zf.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		out[zf.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+	return out
+}
+
+func TestBuildPackage_SignedManifestVerifiesOffline(t *testing.T) {
+	f := setup(t)
+	signer, err := crypto.NewSignerFromSecret("a-test-signing-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	auditID := uuid.New()
+	custody := &fakeCustody{}
+	f.svc.WithCustody(custody, nil).WithPackaging(fakeAudits{
+		auditID: {ID: auditID, TenantID: f.tenant, Title: "Stage 2", FrameworkID: &f.fw},
+	}, signer)
+	ctx := context.Background()
+
+	withFile, _ := f.svc.Create(ctx, f.tenant, CreateInput{Title: "Policy", Filename: "../../policy.pdf", Content: strings.NewReader("policy text"), ControlIDs: []uuid.UUID{f.c1, f.c2}})
+	link, _ := f.svc.Create(ctx, f.tenant, CreateInput{Title: "Wiki", ExternalURL: "https://wiki/x", ControlIDs: []uuid.UUID{f.c2}})
+	// Not linked to the framework: must stay out of the package.
+	_, _ = f.svc.Create(ctx, f.tenant, CreateInput{Title: "Unrelated", Description: "loose"})
+
+	var buf bytes.Buffer
+	m, err := f.svc.BuildPackage(ctx, f.tenant, PackageScope{AuditID: &auditID}, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Items) != 2 || m.Scope.AuditTitle != "Stage 2" || !m.Signature.Signed {
+		t.Fatalf("unexpected manifest: %+v", m)
+	}
+
+	files := readZip(t, buf.Bytes())
+	manifest := files[packageManifest]
+	if err := crypto.VerifyEd25519PEM(files[packagePublicKey], manifest, files[packageSignature]); err != nil {
+		t.Fatalf("manifest signature must verify with the shipped key: %v", err)
+	}
+	var decoded PackageManifest
+	if err := json.Unmarshal(manifest, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	for _, it := range decoded.Items {
+		if it.EvidenceID == link.ID {
+			if it.File != nil {
+				t.Fatal("a link holds no file")
+			}
+			continue
+		}
+		if it.EvidenceID != withFile.ID || it.File == nil {
+			t.Fatalf("unexpected item %+v", it)
+		}
+		if strings.Contains(it.File.Path, "..") {
+			t.Fatalf("archive path escapes its directory: %s", it.File.Path)
+		}
+		body := files[it.File.Path]
+		if digest(string(body)) != it.File.SHA256 || it.File.SHA256 != withFile.SHA256 {
+			t.Fatal("packaged file does not match the digest the manifest pins")
+		}
+	}
+
+	// A manifest edited after signing no longer verifies.
+	forged := bytes.Replace(manifest, []byte("Stage 2"), []byte("Stage 3"), 1)
+	if err := crypto.VerifyEd25519PEM(files[packagePublicKey], forged, files[packageSignature]); err == nil {
+		t.Fatal("an edited manifest must not verify")
+	}
+
+	packaged := 0
+	for _, a := range custody.actions() {
+		if a == domain.CustodyPackaged {
+			packaged++
+		}
+	}
+	if packaged != 2 {
+		t.Fatalf("each packaged artifact gets a custody entry, got %v", custody.actions())
+	}
+}
+
+func TestBuildPackage_RefusesTamperedFilesAndUnscopedRequests(t *testing.T) {
+	f := setup(t)
+	ctx := context.Background()
+
+	if _, err := f.svc.BuildPackage(ctx, f.tenant, PackageScope{}, io.Discard); err == nil {
+		t.Fatal("a package needs a framework or an audit")
+	}
+
+	ev, _ := f.svc.Create(ctx, f.tenant, CreateInput{Title: "Policy", Filename: "p.pdf", Content: strings.NewReader("v1"), ControlIDs: []uuid.UUID{f.c1}})
+	f.store.saved[ev.FileRef] = []byte("v1-edited")
+	m, err := f.svc.BuildPackage(ctx, f.tenant, PackageScope{FrameworkID: &f.fw}, io.Discard)
+	if err == nil || m != nil {
+		t.Fatal("a package must not be produced around a file that fails its digest")
+	}
+
+	// Unsigned deployments still get a package, one that says why it is unsigned.
+	f.store.saved[ev.FileRef] = []byte("v1")
+	m, err = f.svc.BuildPackage(ctx, f.tenant, PackageScope{FrameworkID: &f.fw}, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Signature.Signed || m.Signature.Reason == "" {
+		t.Fatalf("unsigned package must state why: %+v", m.Signature)
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package evidence
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/crypto"
+)
+
+// AuditLookup resolves a compliance audit so a package can be scoped to it.
+type AuditLookup interface {
+	GetAuditByID(ctx context.Context, id, tenantID uuid.UUID) (*domain.ComplianceAudit, error)
+}
+
+// WithPackaging enables evidence packages. The signer is optional: without one
+// packages are still produced, with a manifest that says plainly it is unsigned
+// and why.
+func (s *Service) WithPackaging(audits AuditLookup, signer *crypto.Signer) *Service {
+	if audits != nil {
+		s.audits = audits
+	}
+	if signer != nil {
+		s.signer = signer
+	}
+	return s
+}
+
+// PackageFormat versions the manifest layout. A verifier that sees a format it
+// does not know should refuse rather than half-check.
+const PackageFormat = "openrisk-evidence-package/1"
+
+// Paths inside the package. Fixed so the verification instructions can name
+// them.
+const (
+	packageManifest  = "manifest.json"
+	packageSignature = "manifest.sig"
+	packagePublicKey = "signing-key.pem"
+	packageReadme    = "VERIFY.txt"
+)
+
+// PackageScope selects what goes into a package: one framework, or the scope of
+// one audit (its framework, or every framework for a program-wide audit).
+type PackageScope struct {
+	FrameworkID *uuid.UUID
+	AuditID     *uuid.UUID
+}
+
+// PackageManifest is the signed index of a package. Every file it lists is
+// pinned by digest, so the signature over the manifest covers the files too.
+type PackageManifest struct {
+	Format      string                `json:"format"`
+	TenantID    uuid.UUID             `json:"tenant_id"`
+	GeneratedAt time.Time             `json:"generated_at"`
+	GeneratedBy *uuid.UUID            `json:"generated_by,omitempty"`
+	Scope       PackageManifestScope  `json:"scope"`
+	Items       []PackageManifestItem `json:"items"`
+	Signature   PackageSignatureInfo  `json:"signature"`
+}
+
+type PackageManifestScope struct {
+	AuditID     *uuid.UUID  `json:"audit_id,omitempty"`
+	AuditTitle  string      `json:"audit_title,omitempty"`
+	Frameworks  []uuid.UUID `json:"framework_ids"`
+	Description string      `json:"description"`
+}
+
+type PackageManifestItem struct {
+	EvidenceID  uuid.UUID                   `json:"evidence_id"`
+	Title       string                      `json:"title"`
+	Type        domain.EvidenceType         `json:"type"`
+	Status      domain.EvidenceStatus       `json:"status"`
+	Review      domain.EvidenceReview       `json:"review"`
+	CollectedAt time.Time                   `json:"collected_at"`
+	ValidUntil  *time.Time                  `json:"valid_until,omitempty"`
+	ExternalURL string                      `json:"external_url,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Controls    []domain.EvidenceControlRef `json:"controls"`
+	File        *PackageManifestFile        `json:"file,omitempty"`
+}
+
+type PackageManifestFile struct {
+	Path      string `json:"path"`
+	SHA256    string `json:"sha256"`
+	SizeBytes int64  `json:"size_bytes"`
+	// DigestRecordedAtUpload is false for files uploaded before digests were
+	// kept: the package pins them from now on, but cannot vouch for what they
+	// were before.
+	DigestRecordedAtUpload bool `json:"digest_recorded_at_upload"`
+}
+
+// PackageSignatureInfo describes the detached signature in manifest.sig. It is
+// part of the signed manifest, so it cannot be swapped for another key's.
+type PackageSignatureInfo struct {
+	Signed    bool   `json:"signed"`
+	Algorithm string `json:"algorithm,omitempty"`
+	KeyID     string `json:"key_id,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// BuildPackage writes a zip of the scope's evidence to w: every file under
+// files/, a manifest pinning each by SHA-256, a detached Ed25519 signature over
+// the manifest, the public key, and instructions to check it all offline.
+//
+// Each file is re-hashed as it is copied into the archive. A file that no
+// longer matches its recorded digest fails the whole package (and is written to
+// its custody log): a package is a claim that what is inside is what was
+// collected, and one bad file makes that claim false for all of it.
+//
+// w should be a temporary file, not a response body — a failure part-way
+// through leaves a truncated archive that must not reach anyone.
+func (s *Service) BuildPackage(ctx context.Context, tenantID uuid.UUID, scope PackageScope, w io.Writer) (*PackageManifest, error) {
+	frameworks, desc, audit, err := s.resolvePackageScope(ctx, tenantID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Union of the frameworks' evidence, de-duplicated: one artifact answering
+	// two frameworks of a program-wide audit is one file in the package.
+	seen := map[uuid.UUID]bool{}
+	var items []domain.Evidence
+	for _, fw := range frameworks {
+		fw := fw
+		rows, _, err := s.repo.List(ctx, tenantID, domain.EvidenceFilter{FrameworkID: &fw})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			if !seen[r.ID] {
+				seen[r.ID] = true
+				items = append(items, r)
+			}
+		}
+	}
+	if err := s.decorateMany(ctx, tenantID, items); err != nil {
+		return nil, err
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID.String() < items[j].ID.String() })
+
+	m := &PackageManifest{
+		Format:      PackageFormat,
+		TenantID:    tenantID,
+		GeneratedAt: s.now().UTC(),
+		Scope:       PackageManifestScope{Frameworks: frameworks, Description: desc},
+		Items:       make([]PackageManifestItem, 0, len(items)),
+	}
+	if actor, ok := actorFrom(ctx); ok {
+		m.GeneratedBy = actor
+	}
+	if audit != nil {
+		m.Scope.AuditID, m.Scope.AuditTitle = &audit.ID, audit.Title
+	}
+
+	zw := zip.NewWriter(w)
+	for i := range items {
+		ev := &items[i]
+		item := PackageManifestItem{
+			EvidenceID: ev.ID, Title: ev.Title, Type: ev.Type, Status: ev.Status, Review: ev.Review,
+			CollectedAt: ev.CollectedAt, ValidUntil: ev.ValidUntil, ExternalURL: ev.ExternalURL,
+			Description: ev.Description, Controls: ev.Controls,
+		}
+		if ev.FileRef != "" {
+			f, err := s.packFile(ctx, zw, ev)
+			if err != nil {
+				return nil, err
+			}
+			item.File = f
+		}
+		m.Items = append(m.Items, item)
+	}
+
+	if s.signer != nil {
+		m.Signature = PackageSignatureInfo{Signed: true, Algorithm: s.signer.Algorithm(), KeyID: s.signer.KeyID()}
+	} else {
+		m.Signature = PackageSignatureInfo{
+			Reason: "EXPORT_SIGNING_KEY is not configured on this deployment; the per-file digests still detect a file changed after packaging, but not a manifest rewritten with it",
+		}
+	}
+	body, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, domain.NewInternalError("failed to encode package manifest: " + err.Error())
+	}
+	if err := writeZipEntry(zw, packageManifest, body); err != nil {
+		return nil, err
+	}
+	if s.signer != nil {
+		if err := writeZipEntry(zw, packageSignature, s.signer.Sign(body)); err != nil {
+			return nil, err
+		}
+		if err := writeZipEntry(zw, packagePublicKey, s.signer.PublicKeyPEM()); err != nil {
+			return nil, err
+		}
+	}
+	if err := writeZipEntry(zw, packageReadme, []byte(packageInstructions(m))); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, domain.NewInternalError("failed to finish package: " + err.Error())
+	}
+
+	detail := "evidence package: " + desc
+	for i := range items {
+		s.recordCustody(ctx, &items[i], domain.CustodyPackaged, detail)
+	}
+	return m, nil
+}
+
+func (s *Service) resolvePackageScope(ctx context.Context, tenantID uuid.UUID, scope PackageScope) ([]uuid.UUID, string, *domain.ComplianceAudit, error) {
+	frameworkID := scope.FrameworkID
+	var audit *domain.ComplianceAudit
+	if scope.AuditID != nil {
+		if s.audits == nil {
+			return nil, "", nil, domain.NewValidationError("audit-scoped packages are not available")
+		}
+		a, err := s.audits.GetAuditByID(ctx, *scope.AuditID, tenantID)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		if a == nil {
+			return nil, "", nil, domain.NewNotFoundError("audit", *scope.AuditID)
+		}
+		if frameworkID != nil && a.FrameworkID != nil && *frameworkID != *a.FrameworkID {
+			return nil, "", nil, domain.NewValidationError("framework_id does not match the audit's framework")
+		}
+		audit = a
+		if frameworkID == nil {
+			frameworkID = a.FrameworkID
+		}
+	}
+
+	if frameworkID != nil {
+		fw, err := s.controls.GetFrameworkByID(ctx, *frameworkID, tenantID)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		if fw == nil {
+			return nil, "", nil, domain.NewNotFoundError("framework", *frameworkID)
+		}
+		desc := "framework " + fw.Name
+		if audit != nil {
+			desc = "audit " + audit.Title + " (" + fw.Name + ")"
+		}
+		return []uuid.UUID{fw.ID}, desc, audit, nil
+	}
+	if audit == nil {
+		// A whole-library dump is not an audit package; asking for a scope keeps
+		// auditors from being handed proof for things they are not auditing.
+		return nil, "", nil, domain.NewValidationError("framework_id or audit_id is required")
+	}
+
+	fws, err := s.controls.ListFrameworks(ctx, tenantID)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(fws))
+	for _, fw := range fws {
+		ids = append(ids, fw.ID)
+	}
+	return ids, "audit " + audit.Title + " (program-wide)", audit, nil
+}
+
+// packFile copies one artifact into the archive, hashing it on the way.
+func (s *Service) packFile(ctx context.Context, zw *zip.Writer, ev *domain.Evidence) (*PackageManifestFile, error) {
+	src, err := s.storage.Open(ctx, ev.FileRef)
+	if err != nil {
+		return nil, domain.NewInternalError(fmt.Sprintf("evidence %s: file is missing from storage: %v", ev.ID, err))
+	}
+	defer src.Close()
+
+	name := ev.Filename
+	if name == "" {
+		name = ev.Title
+	}
+	p := path.Join("files", ev.ID.String(), safeArchiveName(name))
+	dst, err := zw.Create(p)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to write package: " + err.Error())
+	}
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(dst, h), src)
+	if err != nil {
+		return nil, domain.NewInternalError(fmt.Sprintf("evidence %s: failed to read file: %v", ev.ID, err))
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+	if ev.SHA256 != "" && !strings.EqualFold(sum, ev.SHA256) {
+		s.recordCustody(ctx, ev, domain.CustodyIntegrityFailure, "packaging read bytes hashing to "+sum)
+		return nil, &domain.AppError{
+			Err:     domain.ErrConflict,
+			Message: fmt.Sprintf("evidence %q no longer matches the digest recorded at upload; package not produced", ev.Title),
+			Code:    http.StatusConflict,
+		}
+	}
+	return &PackageManifestFile{Path: p, SHA256: sum, SizeBytes: n, DigestRecordedAtUpload: ev.SHA256 != ""}, nil
+}
+
+var unsafeArchiveChars = regexp.MustCompile(`[^A-Za-z0-9._ -]+`)
+
+// safeArchiveName keeps a stored filename from escaping its directory when the
+// archive is extracted ("../../etc/cron.d/x" is a valid upload name).
+func safeArchiveName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.TrimSpace(unsafeArchiveChars.ReplaceAllString(name, "_"))
+	if name == "" || name == "." || name == ".." {
+		return "file"
+	}
+	return name
+}
+
+func writeZipEntry(zw *zip.Writer, name string, body []byte) error {
+	w, err := zw.Create(name)
+	if err == nil {
+		_, err = w.Write(body)
+	}
+	if err != nil {
+		return domain.NewInternalError("failed to write package: " + err.Error())
+	}
+	return nil
+}
+
+func packageInstructions(m *PackageManifest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "OpenRisk evidence package — %s\n", m.Scope.Description)
+	fmt.Fprintf(&b, "Generated %s, %d artifact(s).\n\n", m.GeneratedAt.Format(time.RFC3339), len(m.Items))
+	if m.Signature.Signed {
+		fmt.Fprintf(&b, "1. Check the manifest signature (%s, key %s):\n\n", m.Signature.Algorithm, m.Signature.KeyID)
+		fmt.Fprintf(&b, "     openssl pkeyutl -verify -pubin -inkey %s -rawin -in %s -sigfile %s\n\n",
+			packagePublicKey, packageManifest, packageSignature)
+		b.WriteString("   Compare the key with the one your organisation published; a package\n")
+		b.WriteString("   signed by a key you were not given proves nothing.\n\n")
+	} else {
+		fmt.Fprintf(&b, "1. This package is NOT signed: %s.\n\n", m.Signature.Reason)
+	}
+	b.WriteString("2. Check every file against the digest the manifest pins it to:\n\n")
+	b.WriteString("     sha256sum files/*/*\n\n")
+	b.WriteString("   and compare with items[].file.sha256 in manifest.json. Files marked\n")
+	b.WriteString("   digest_recorded_at_upload=false predate digest tracking.\n")
+	return b.String()
+}
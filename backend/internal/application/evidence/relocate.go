@@ -0,0 +1,108 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package evidence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/storage"
+)
+
+// FileRefStore is the cross-tenant slice of the evidence repository the storage
+// migration needs. Kept out of domain.EvidenceRepository on purpose: every other
+// caller is tenant-scoped, and giving them a method that walks all tenants would
+// be one typo away from a leak.
+type FileRefStore interface {
+	ListWithFiles(ctx context.Context, after uuid.UUID, limit int) ([]domain.Evidence, error)
+	SwapFileRef(ctx context.Context, id uuid.UUID, from, to string) (bool, error)
+}
+
+// RelocateResult summarises one migration run.
+type RelocateResult struct {
+	Scanned int `json:"scanned"`
+	Moved   int `json:"moved"`
+	// Missing counts rows whose key is not in the source store — already moved
+	// by an earlier run, or a file lost before the migration. Either way there
+	// is nothing to copy.
+	Missing int      `json:"missing"`
+	Failed  int      `json:"failed"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+const relocateBatch = 200
+
+// RelocateFiles copies every evidence file from one store to another and
+// repoints Evidence.FileRef at the copy.
+//
+// Resumable and idempotent: a row is repointed only after its copy is written,
+// and a row already repointed is found missing in the source on the next run and
+// skipped. Source files are never deleted — the operator retires the old volume
+// once the new store has been checked, not the migration on its own say-so.
+func RelocateFiles(ctx context.Context, refs FileRefStore, from, to storage.Storage, dryRun bool) (*RelocateResult, error) {
+	res := &RelocateResult{}
+	after := uuid.Nil
+	for {
+		batch, err := refs.ListWithFiles(ctx, after, relocateBatch)
+		if err != nil {
+			return res, err
+		}
+		if len(batch) == 0 {
+			return res, nil
+		}
+		for i := range batch {
+			ev := &batch[i]
+			after = ev.ID
+			res.Scanned++
+			if err := relocateOne(ctx, refs, from, to, ev, dryRun, res); err != nil {
+				res.Failed++
+				res.Errors = append(res.Errors, fmt.Sprintf("%s: %v", ev.ID, err))
+			}
+		}
+	}
+}
+
+func relocateOne(ctx context.Context, refs FileRefStore, from, to storage.Storage, ev *domain.Evidence, dryRun bool, res *RelocateResult) error {
+	src, err := from.Open(ctx, ev.FileRef)
+	if errors.Is(err, storage.ErrNotFound) {
+		res.Missing++
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	if dryRun {
+		res.Moved++
+		return nil
+	}
+
+	name := ev.Filename
+	if name == "" {
+		name = ev.Title
+	}
+	key, err := to.Save(ctx, ev.TenantID, name, src)
+	if err != nil {
+		return err
+	}
+	swapped, err := refs.SwapFileRef(ctx, ev.ID, ev.FileRef, key)
+	if err != nil || !swapped {
+		// The row changed under us (or the write failed): the copy is orphaned,
+		// not authoritative. Remove it rather than leave two candidates.
+		_ = to.Delete(ctx, key)
+		if err != nil {
+			return err
+		}
+		return errors.New("file was replaced during migration; left as is")
+	}
+	res.Moved++
+	return nil
+}
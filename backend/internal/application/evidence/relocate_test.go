@@ -0,0 +1,109 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package evidence
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/storage"
+)
+
+type fakeFileRefs struct {
+	rows map[uuid.UUID]*domain.Evidence
+}
+
+func (f *fakeFileRefs) ListWithFiles(_ context.Context, after uuid.UUID, limit int) ([]domain.Evidence, error) {
+	var out []domain.Evidence
+	for _, e := range f.rows {
+		if e.FileRef != "" && strings.Compare(e.ID.String(), after.String()) > 0 {
+			out = append(out, *e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID.String() < out[j].ID.String() })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (f *fakeFileRefs) SwapFileRef(_ context.Context, id uuid.UUID, from, to string) (bool, error) {
+	e, ok := f.rows[id]
+	if !ok || e.FileRef != from {
+		return false, nil
+	}
+	e.FileRef = to
+	return true, nil
+}
+
+func TestRelocateFiles_CopiesRepointsAndIsResumable(t *testing.T) {
+	ctx := context.Background()
+	from, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tenant := uuid.New()
+	key, err := from.Save(ctx, tenant, "policy.pdf", strings.NewReader("signed policy"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	withFile := &domain.Evidence{ID: uuid.New(), TenantID: tenant, FileRef: key, Filename: "policy.pdf"}
+	lost := &domain.Evidence{ID: uuid.New(), TenantID: tenant, FileRef: tenant.String() + "/gone.pdf"}
+	statement := &domain.Evidence{ID: uuid.New(), TenantID: tenant, Description: "attested inline"}
+	refs := &fakeFileRefs{rows: map[uuid.UUID]*domain.Evidence{
+		withFile.ID: withFile, lost.ID: lost, statement.ID: statement,
+	}}
+
+	dry, err := RelocateFiles(ctx, refs, from, to, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dry.Moved != 1 || withFile.FileRef != key {
+		t.Fatalf("dry run must count without repointing: %+v, ref=%s", dry, withFile.FileRef)
+	}
+
+	res, err := RelocateFiles(ctx, refs, from, to, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Scanned != 2 || res.Moved != 1 || res.Missing != 1 || res.Failed != 0 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if withFile.FileRef == key || !strings.HasPrefix(withFile.FileRef, tenant.String()+"/") {
+		t.Fatalf("file ref not repointed into the tenant namespace: %s", withFile.FileRef)
+	}
+	rc, err := to.Open(ctx, withFile.FileRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(body) != "signed policy" {
+		t.Fatalf("copied content differs: %q", body)
+	}
+	// The source is left for the operator to retire.
+	if _, err := from.Open(ctx, key); err != nil {
+		t.Fatalf("source file must not be deleted: %v", err)
+	}
+
+	again, err := RelocateFiles(ctx, refs, from, to, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Moved != 0 || again.Missing != 2 {
+		t.Fatalf("second run must be a no-op: %+v", again)
+	}
+}
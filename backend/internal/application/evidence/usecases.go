@@ -14,12 +14,16 @@ package evidence
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
 	"io"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/crypto"
 	"github.com/opendefender/openrisk/pkg/storage"
 )
 
@@ -52,6 +56,10 @@ type Service struct {
 	controls ControlLookup
 	storage  storage.Storage
 	users    UserLookup
+	custody  domain.EvidenceCustodyRepository
+	audit    AuditSink
+	audits   AuditLookup
+	signer   *crypto.Signer
 	// now is injectable so expiry behaviour is testable without sleeping.
 	now func() time.Time
 }
@@ -172,11 +180,17 @@ func (s *Service) Create(ctx context.Context, tenantID uuid.UUID, in CreateInput
 	}
 
 	if in.Content != nil {
-		key, err := s.storage.Save(ctx, tenantID, in.Filename, in.Content)
+		// Hashed on the way in, in the same pass as the write: the digest is of
+		// the bytes storage actually received, not of a second read that could
+		// differ from them.
+		hr := newHashingReader(in.Content)
+		key, err := s.storage.Save(ctx, tenantID, in.Filename, hr)
 		if err != nil {
 			return nil, domain.NewInternalError("failed to store evidence file: " + err.Error())
 		}
 		ev.FileRef = key
+		ev.SHA256 = hr.Sum()
+		ev.SizeBytes = hr.n
 	}
 
 	if err := s.repo.Create(ctx, ev); err != nil {
@@ -199,6 +213,7 @@ func (s *Service) Create(ctx context.Context, tenantID uuid.UUID, in CreateInput
 		}
 	}
 
+	s.recordCustody(ctx, ev, domain.CustodyCollected, "")
 	return s.decorateOne(ctx, tenantID, ev)
 }
 
@@ -214,6 +229,7 @@ func (s *Service) Get(ctx context.Context, tenantID, id uuid.UUID) (*domain.Evid
 	if ev == nil {
 		return nil, domain.NewNotFoundError("evidence", id)
 	}
+	s.recordCustody(ctx, ev, domain.CustodyViewed, "")
 	return s.decorateOne(ctx, tenantID, ev)
 }
 
@@ -295,6 +311,10 @@ func (s *Service) ListByControl(ctx context.Context, tenantID, controlID uuid.UU
 
 // Download streams the artifact's bytes. The only path to file content: it
 // re-verifies tenant ownership on every call rather than trusting a key.
+//
+// The stream is checked against the recorded digest as it is read; a file that
+// no longer matches fails the read at EOF (ErrIntegrity) and is written into the
+// chain of custody as an integrity failure.
 func (s *Service) Download(ctx context.Context, tenantID, id uuid.UUID) (*domain.Evidence, io.ReadCloser, error) {
 	ev, err := s.repo.GetByID(ctx, tenantID, id)
 	if err != nil {
@@ -312,7 +332,70 @@ func (s *Service) Download(ctx context.Context, tenantID, id uuid.UUID) (*domain
 	if err != nil {
 		return nil, nil, domain.NewInternalError("evidence file is missing from storage: " + err.Error())
 	}
-	return ev, content, nil
+	s.recordCustody(ctx, ev, domain.CustodyDownloaded, "")
+	if ev.SHA256 == "" {
+		// Uploaded before digests existed: nothing to check against.
+		return ev, content, nil
+	}
+	// The failure is recorded after the handler has returned (the body streams
+	// afterwards), so it must not inherit the request's cancellation.
+	detached := context.WithoutCancel(ctx)
+	return ev, &verifyingReader{
+		rc:       content,
+		h:        sha256.New(),
+		expected: ev.SHA256,
+		onFail: func(actual string) {
+			s.recordCustody(detached, ev, domain.CustodyIntegrityFailure, "download served bytes hashing to "+actual)
+		},
+	}, nil
+}
+
+// DownloadLinkTTL bounds a presigned download URL. Long enough to survive a
+// slow click-through, short enough that a link pasted into a ticket is dead by
+// the time anyone else reads it.
+const DownloadLinkTTL = 5 * time.Minute
+
+// DownloadLink returns a short-lived direct URL to the artifact's bytes, for
+// drivers that can issue one. Same ownership check as Download; the URL is the
+// capability, so it is only ever minted for a caller who could read the file.
+//
+// Conflict when the driver cannot presign (local disk, or S3 with envelope
+// encryption): the client falls back to the streaming endpoint.
+func (s *Service) DownloadLink(ctx context.Context, tenantID, id uuid.UUID) (string, time.Time, error) {
+	ev, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if ev == nil {
+		return "", time.Time{}, domain.NewNotFoundError("evidence", id)
+	}
+	if ev.FileRef == "" {
+		return "", time.Time{}, domain.NewValidationError("this evidence holds no file (it is a link or a statement)")
+	}
+	unsupported := &domain.AppError{
+		Err:     domain.ErrConflict,
+		Message: "direct download links are not available on this deployment — use the download endpoint",
+		Code:    http.StatusConflict,
+	}
+	p, ok := s.storage.(storage.Presigner)
+	if !ok {
+		return "", time.Time{}, unsupported
+	}
+	name := ev.Filename
+	if name == "" {
+		name = ev.Title
+	}
+	url, err := p.PresignGet(ctx, ev.FileRef, name, DownloadLinkTTL)
+	if errors.Is(err, storage.ErrPresignUnsupported) {
+		return "", time.Time{}, unsupported
+	}
+	if err != nil {
+		return "", time.Time{}, domain.NewInternalError("failed to sign download link: " + err.Error())
+	}
+	// Recorded when the link is minted: the object store serves the bytes, so
+	// this is the last moment the product sees who is taking them.
+	s.recordCustody(ctx, ev, domain.CustodyDownloaded, "direct download link issued")
+	return url, s.now().Add(DownloadLinkTTL), nil
 }
 
 // =============================================================================
@@ -411,9 +494,82 @@ func (s *Service) Review(ctx context.Context, tenantID, id, reviewer uuid.UUID,
 	if err := s.repo.Update(ctx, ev); err != nil {
 		return nil, err
 	}
+	s.recordCustody(ctx, ev, domain.CustodyReviewed, string(r))
+	return s.decorateOne(ctx, tenantID, ev)
+}
+
+// Replace swaps the artifact's file for a new version — a re-scanned
+// certificate, a corrected export. The row, its links and its history stay;
+// the custody log records the digest it had before and the one it has now.
+//
+// Collection time moves to now: the new bytes were collected today, and keeping
+// the old date would certify a file as collected on a day it did not exist.
+func (s *Service) Replace(ctx context.Context, tenantID, id uuid.UUID, in ReplaceInput) (*domain.Evidence, error) {
+	filename, content := in.Filename, in.Content
+	if content == nil {
+		return nil, domain.NewValidationError("a file is required")
+	}
+	ev, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if ev == nil {
+		return nil, domain.NewNotFoundError("evidence", id)
+	}
+	if strings.TrimSpace(filename) == "" {
+		filename = ev.Filename
+	}
+	collectedAt := s.now()
+	if in.ValidUntil != nil {
+		ev.ValidUntil = in.ValidUntil
+	}
+	if ev.ValidUntil != nil && !ev.ValidUntil.After(collectedAt) {
+		// Typically the old file's expiry, already past. Dropping it would file
+		// the new version as never expiring; the caller has to say when it does.
+		return nil, domain.NewValidationError("valid_until must be after collected_at — give the new file's expiry")
+	}
+
+	hr := newHashingReader(content)
+	key, err := s.storage.Save(ctx, tenantID, filename, hr)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to store evidence file: " + err.Error())
+	}
+	oldRef, oldSum := ev.FileRef, ev.SHA256
+	ev.FileRef, ev.Filename = key, filename
+	ev.SHA256, ev.SizeBytes = hr.Sum(), hr.n
+	ev.CollectedAt = collectedAt
+	if err := s.repo.Update(ctx, ev); err != nil {
+		_ = s.storage.Delete(ctx, key)
+		return nil, err
+	}
+	if oldRef != "" {
+		// Best-effort, as in Delete. Under object lock the store keeps the old
+		// version until retention lapses, which is the point of locking it.
+		_ = s.storage.Delete(ctx, oldRef)
+	}
+	detail := "first file attached"
+	if oldRef != "" {
+		detail = "previous sha256 " + orUnrecorded(oldSum)
+	}
+	s.recordCustody(ctx, ev, domain.CustodyReplaced, detail)
 	return s.decorateOne(ctx, tenantID, ev)
 }
 
+// ReplaceInput is a new version of an artifact's file. ValidUntil is optional
+// and, when nil, the current expiry is kept.
+type ReplaceInput struct {
+	Filename   string
+	Content    io.Reader
+	ValidUntil *time.Time
+}
+
+func orUnrecorded(sum string) string {
+	if sum == "" {
+		return "(not recorded)"
+	}
+	return sum
+}
+
 // Delete removes the artifact, its links and its bytes.
 func (s *Service) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
 	ev, err := s.repo.GetByID(ctx, tenantID, id)
@@ -26,6 +26,9 @@ type fakeRepo struct {
 	items    map[uuid.UUID]*domain.Evidence
 	links    []domain.EvidenceControlLink
 	failNext error
+	// frameworkOf resolves a control's framework for FrameworkID filters; set by
+	// setup from the fake control register.
+	frameworkOf func(controlID uuid.UUID) uuid.UUID
 }
 
 func newFakeRepo() *fakeRepo { return &fakeRepo{items: map[uuid.UUID]*domain.Evidence{}} }
@@ -78,6 +81,9 @@ func (r *fakeRepo) List(_ context.Context, tenantID uuid.UUID, f domain.Evidence
 		if f.ControlID != nil && !r.linked(e.ID, *f.ControlID) {
 			continue
 		}
+		if f.FrameworkID != nil && !r.linkedToFramework(e.ID, *f.FrameworkID) {
+			continue
+		}
 		out = append(out, *e)
 	}
 	total := int64(len(out))
@@ -99,6 +105,15 @@ func (r *fakeRepo) linked(evID, controlID uuid.UUID) bool {
 	return false
 }
 
+func (r *fakeRepo) linkedToFramework(evID, frameworkID uuid.UUID) bool {
+	for _, l := range r.links {
+		if l.EvidenceID == evID && r.frameworkOf != nil && r.frameworkOf(l.ControlID) == frameworkID {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *fakeRepo) Delete(_ context.Context, tenantID, id uuid.UUID) error {
 	e, ok := r.items[id]
 	if !ok || e.TenantID != tenantID {
@@ -282,6 +297,7 @@ func setup(t *testing.T) *fixture {
 	fc.controls[c2] = domain.ComplianceControl{ID: c2, TenantID: tenant, FrameworkID: fw, ReferenceCode: "A.8.2", Name: "Privileged access", Status: domain.ControlStatusInProgress}
 
 	repo, store := newFakeRepo(), newFakeStorage()
+	repo.frameworkOf = func(id uuid.UUID) uuid.UUID { return fc.controls[id].FrameworkID }
 	svc := NewService(repo, fc, store).WithClock(func() time.Time { return now })
 	return &fixture{svc: svc, repo: repo, controls: fc, store: store, tenant: tenant, other: other, fw: fw, c1: c1, c2: c2, now: now}
 }
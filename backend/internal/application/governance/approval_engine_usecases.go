@@ -47,6 +47,13 @@ type RoleResolver interface {
 	RolesFor(ctx context.Context, tenantID, userID uuid.UUID) ([]string, error)
 }
 
+// EventPublisher announces outcomes to webhook subscribers. Satisfied
+// structurally by application/webhooks.Service. Optional and best-effort, like
+// the notifier.
+type EventPublisher interface {
+	Publish(ctx context.Context, tenantID uuid.UUID, eventType domain.WebhookEventType, data any) error
+}
+
 // ApproverIdentity is what the handler knows from the token, before delegations.
 type ApproverIdentity struct {
 	UserID  uuid.UUID
@@ -74,6 +81,7 @@ type DecideApprovalUseCase struct {
 	notifier    ApprovalNotifier
 	delegations DelegationResolver
 	roles       RoleResolver
+	events      EventPublisher
 }
 
 func NewDecideApprovalUseCase(r domain.ApprovalRequestRepository) *DecideApprovalUseCase {
@@ -94,6 +102,12 @@ func (uc *DecideApprovalUseCase) WithDelegations(d DelegationResolver, r RoleRes
 	return uc
 }
 
+// WithEvents announces each request reaching its outcome as approval.decided.
+func (uc *DecideApprovalUseCase) WithEvents(e EventPublisher) *DecideApprovalUseCase {
+	uc.events = e
+	return uc
+}
+
 func (uc *DecideApprovalUseCase) Execute(ctx context.Context, tenantID, id uuid.UUID, who ApproverIdentity, in DecideInput) (*domain.ApprovalRequest, error) {
 	decision := strings.ToLower(strings.TrimSpace(in.Decision))
 	if decision != "approve" && decision != "reject" {
@@ -158,6 +172,19 @@ func (uc *DecideApprovalUseCase) Execute(ctx context.Context, tenantID, id uuid.
 
 	uc.record(ctx, tenantID, approver.UserID, req, decision, comment, step)
 	uc.announce(ctx, tenantID, req)
+	if uc.events != nil && req.Status != domain.ApprovalPending {
+		_ = uc.events.Publish(ctx, tenantID, domain.WebhookApprovalDecided, domain.WebhookApprovalDecidedData{
+			ApprovalID:  req.ID,
+			Title:       req.Title,
+			RequestType: req.RequestType,
+			EntityType:  req.EntityType,
+			EntityID:    req.EntityID,
+			Status:      req.Status,
+			DecidedBy:   approver.Email,
+			Comment:     comment,
+			ResolvedAt:  req.ResolvedAt,
+		})
+	}
 	return req, nil
 }
 
@@ -0,0 +1,320 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package governance
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/crypto"
+	"github.com/opendefender/openrisk/pkg/crypto/tsatest"
+)
+
+// fakeCheckpointRepo holds one tenant-partitioned chain and its checkpoints.
+type fakeCheckpointRepo struct {
+	events      []domain.AuditEvent
+	checkpoints []domain.AuditCheckpoint
+	seals       []domain.AuditChainSeal
+}
+
+func (f *fakeCheckpointRepo) appendEvents(tenantID uuid.UUID, summaries ...string) {
+	for _, s := range summaries {
+		var seq int64
+		prev := domain.GenesisHash
+		for _, e := range f.events {
+			if e.TenantID == tenantID {
+				seq, prev = e.Sequence, e.Hash
+			}
+		}
+		e := domain.AuditEvent{TenantID: tenantID, Action: domain.AuditActionUpdate, EntityType: "risk", Summary: s}
+		e.SealChain(seq+1, prev)
+		f.events = append(f.events, e)
+	}
+}
+
+func (f *fakeCheckpointRepo) Create(_ context.Context, c *domain.AuditCheckpoint) (bool, error) {
+	for _, have := range f.checkpoints {
+		if have.TenantID == c.TenantID && have.Sequence == c.Sequence {
+			return false, nil
+		}
+	}
+	f.checkpoints = append(f.checkpoints, *c)
+	return true, nil
+}
+func (f *fakeCheckpointRepo) List(_ context.Context, tenantID uuid.UUID, limit int) ([]domain.AuditCheckpoint, error) {
+	var out []domain.AuditCheckpoint
+	for _, c := range f.checkpoints {
+		if c.TenantID == tenantID {
+			out = append(out, c)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Sequence > out[j].Sequence })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+func (f *fakeCheckpointRepo) InRange(_ context.Context, tenantID uuid.UUID, from, to int64) ([]domain.AuditCheckpoint, error) {
+	var out []domain.AuditCheckpoint
+	for _, c := range f.checkpoints {
+		if c.TenantID == tenantID && c.Sequence >= from && c.Sequence <= to {
+			out = append(out, c)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Sequence < out[j].Sequence })
+	return out, nil
+}
+func (f *fakeCheckpointRepo) Head(_ context.Context, tenantID uuid.UUID) (*domain.AuditChainHead, error) {
+	var head *domain.AuditChainHead
+	for _, e := range f.events {
+		if e.TenantID == tenantID {
+			head = &domain.AuditChainHead{TenantID: tenantID, Sequence: e.Sequence, Hash: e.Hash}
+		}
+	}
+	return head, nil
+}
+func (f *fakeCheckpointRepo) DueHeads(ctx context.Context, limit int) ([]domain.AuditChainHead, error) {
+	seen := map[uuid.UUID]bool{}
+	var out []domain.AuditChainHead
+	for _, e := range f.events {
+		if seen[e.TenantID] {
+			continue
+		}
+		seen[e.TenantID] = true
+		h, _ := f.Head(ctx, e.TenantID)
+		latest, _ := f.List(ctx, e.TenantID, 1)
+		if len(latest) == 0 || latest[0].Sequence < h.Sequence {
+			out = append(out, *h)
+		}
+	}
+	return out, nil
+}
+func (f *fakeCheckpointRepo) EventsInRange(_ context.Context, tenantID uuid.UUID, from, to int64) ([]domain.AuditEvent, error) {
+	var out []domain.AuditEvent
+	for _, e := range f.events {
+		if e.TenantID == tenantID && e.Sequence >= from && e.Sequence <= to {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// The chain side only needs seals here.
+func (f *fakeCheckpointRepo) Append(context.Context, *domain.AuditEvent) error { return nil }
+func (f *fakeCheckpointRepo) ListSeals(_ context.Context, tenantID uuid.UUID) ([]domain.AuditChainSeal, error) {
+	var out []domain.AuditChainSeal
+	for _, s := range f.seals {
+		if s.TenantID == tenantID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+type fakeChainRepo struct{ *fakeCheckpointRepo }
+
+func (f fakeChainRepo) List(context.Context, uuid.UUID, domain.AuditEventFilter) ([]domain.AuditEvent, int64, error) {
+	return nil, 0, nil
+}
+func (f fakeChainRepo) ListAll(context.Context, uuid.UUID, domain.AuditEventFilter) ([]domain.AuditEvent, error) {
+	return nil, nil
+}
+func (f fakeChainRepo) Prune(context.Context, uuid.UUID, time.Time) (*domain.AuditChainSeal, error) {
+	return nil, nil
+}
+
+type recordingPublisher struct{ published []any }
+
+func (p *recordingPublisher) Publish(_ context.Context, _ uuid.UUID, t domain.WebhookEventType, data any) error {
+	if t == domain.WebhookAuditCheckpointed {
+		p.published = append(p.published, data)
+	}
+	return nil
+}
+
+func newTestSigner(t *testing.T, secret string) *crypto.Signer {
+	t.Helper()
+	s, err := crypto.NewSignerFromSecret(secret)
+	if err != nil {
+		t.Fatalf("signer: %v", err)
+	}
+	return s
+}
+
+func TestCheckpointAuditChain_SignTimestampPublishAndVerify(t *testing.T) {
+	ctx := context.Background()
+	tsa := tsatest.NewServer()
+	defer tsa.Close()
+	repo := &fakeCheckpointRepo{}
+	tenant := uuid.New()
+	repo.appendEvents(tenant, "a", "b", "c")
+
+	signer := newTestSigner(t, "checkpoint-test-secret")
+	pub := &recordingPublisher{}
+	uc := NewCheckpointAuditChainUseCase(repo, signer).
+		WithTimestamper(crypto.NewTimestampClient(tsa.URL)).WithEvents(pub)
+
+	c, created, err := uc.Execute(ctx, tenant)
+	if err != nil || !created {
+		t.Fatalf("expected a new checkpoint, got created=%v err=%v", created, err)
+	}
+	if c.Sequence != 3 || c.HeadHash != repo.events[2].Hash || len(c.TimestampToken) == 0 || c.TimestampError != "" {
+		t.Fatalf("checkpoint does not cover the timestamped head: %+v", c)
+	}
+	if len(pub.published) != 1 {
+		t.Fatalf("expected one audit.checkpoint_created, got %d", len(pub.published))
+	}
+	again, created, err := uc.Execute(ctx, tenant)
+	if err != nil || created || again.ID != c.ID {
+		t.Fatalf("an unchanged head must return the existing checkpoint, got created=%v err=%v", created, err)
+	}
+	if len(pub.published) != 1 {
+		t.Fatal("no event for a checkpoint that was not created")
+	}
+
+	repo.appendEvents(tenant, "d")
+	repo.appendEvents(uuid.New(), "other tenant")
+	n, err := uc.ExecuteDue(ctx)
+	if err != nil || n != 2 {
+		t.Fatalf("expected both grown chains checkpointed, got %d (%v)", n, err)
+	}
+	if n, _ := uc.ExecuteDue(ctx); n != 0 {
+		t.Fatalf("nothing is due after a sweep, got %d", n)
+	}
+
+	seg, err := NewExportAuditSegmentUseCase(repo, fakeChainRepo{repo}).WithPublicKey(uc.PublicKey()).Execute(ctx, tenant, 0, 0)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if seg.FromSequence != 1 || seg.ToSequence != 4 || len(seg.Checkpoints) != 2 {
+		t.Fatalf("unexpected segment bounds %d–%d with %d checkpoints", seg.FromSequence, seg.ToSequence, len(seg.Checkpoints))
+	}
+
+	// Through a file, the way an auditor receives it.
+	raw, _ := json.Marshal(seg)
+	var onDisk AuditSegment
+	if err := json.Unmarshal(raw, &onDisk); err != nil {
+		t.Fatal(err)
+	}
+	rep := VerifyAuditSegment(&onDisk, AuditSegmentVerifyOptions{Keys: []ed25519.PublicKey{signer.PublicKey()}, TSARoots: tsa.Roots})
+	if !rep.Valid || rep.AnchoredThrough != 4 || rep.Unanchored != 0 || len(rep.Warnings) != 0 {
+		t.Fatalf("expected a clean, fully anchored segment: %+v", rep)
+	}
+	for _, v := range rep.Checkpoints {
+		if v.Timestamp != CheckpointTSVerified {
+			t.Fatalf("checkpoint %d timestamp %q", v.Sequence, v.Timestamp)
+		}
+	}
+
+	// Without the TSA root the tokens still verify, but say what was not checked.
+	rep = VerifyAuditSegment(&onDisk, AuditSegmentVerifyOptions{Keys: []ed25519.PublicKey{signer.PublicKey()}})
+	if !rep.Valid || len(rep.Warnings) != 1 || !strings.Contains(rep.Warnings[0], "root") {
+		t.Fatalf("expected a single root warning: %+v", rep.Warnings)
+	}
+}
+
+func TestVerifyAuditSegment_DetectsAWholeChainRewrite(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeCheckpointRepo{}
+	tenant := uuid.New()
+	repo.appendEvents(tenant, "approved by alice", "risk closed", "exported")
+	signer := newTestSigner(t, "checkpoint-test-secret")
+	uc := NewCheckpointAuditChainUseCase(repo, signer)
+	c, _, err := uc.Execute(ctx, tenant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The auditor kept the delivered checkpoint.
+	kept, _ := json.Marshal([]map[string]any{{
+		"id": uuid.New(), "type": domain.WebhookAuditCheckpointed, "tenant_id": tenant,
+		"data": domain.WebhookAuditCheckpointData{
+			CheckpointID: c.ID, Sequence: c.Sequence, HeadHash: c.HeadHash, SignedAt: c.SignedAt,
+			Algorithm: c.Algorithm, KeyID: c.KeyID, Signature: c.Signature,
+		},
+	}})
+	external, err := ParseCheckpoints(kept)
+	if err != nil || len(external) != 1 {
+		t.Fatalf("parse: %v (%d)", err, len(external))
+	}
+
+	// Someone with database access rewrites entry 2 and re-hashes everything
+	// after it, then drops the checkpoints that would contradict them.
+	repo.events[1].Summary = "approved by mallory"
+	for i := 1; i < len(repo.events); i++ {
+		repo.events[i].SealChain(repo.events[i].Sequence, repo.events[i-1].Hash)
+	}
+	repo.checkpoints = nil
+	seg, err := NewExportAuditSegmentUseCase(repo, fakeChainRepo{repo}).Execute(ctx, tenant, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []ed25519.PublicKey{signer.PublicKey()}
+
+	rep := VerifyAuditSegment(seg, AuditSegmentVerifyOptions{Keys: keys})
+	if !rep.Valid || !rep.Chain.Valid || rep.AnchoredThrough != 0 {
+		t.Fatalf("the rewritten chain is internally consistent: %+v", rep)
+	}
+	rep = VerifyAuditSegment(seg, AuditSegmentVerifyOptions{Keys: keys, Checkpoints: external})
+	if rep.Valid || len(rep.Checkpoints) != 1 || rep.Checkpoints[0].Head != CheckpointHeadMismatch {
+		t.Fatalf("the kept checkpoint must expose the rewrite: %+v", rep)
+	}
+
+	// Re-signing the rewrite with a key of their own does not help either.
+	forger := newTestSigner(t, "a-key-the-auditor-never-saw")
+	repo.checkpoints = nil
+	fc, _, _ := NewCheckpointAuditChainUseCase(repo, forger).Execute(ctx, tenant)
+	rep = VerifyAuditSegment(seg, AuditSegmentVerifyOptions{Keys: keys, Checkpoints: []domain.AuditCheckpoint{*fc}})
+	if rep.Valid || rep.Checkpoints[0].Signature != CheckpointSigUnknownKey {
+		t.Fatalf("a checkpoint from an untrusted key must fail: %+v", rep.Checkpoints)
+	}
+
+	// Nor does editing a genuine checkpoint's head.
+	edited := external[0]
+	edited.HeadHash = seg.Events[2].Hash
+	rep = VerifyAuditSegment(seg, AuditSegmentVerifyOptions{Keys: keys, Checkpoints: []domain.AuditCheckpoint{edited}})
+	if rep.Valid || rep.Checkpoints[0].Signature != CheckpointSigInvalid {
+		t.Fatalf("an edited checkpoint must fail its signature: %+v", rep.Checkpoints)
+	}
+}
+
+func TestCheckpointAuditChain_TSAOutageStillCheckpoints(t *testing.T) {
+	ctx := context.Background()
+	tsa := tsatest.NewServer()
+	defer tsa.Close()
+	tsa.Refuse(true)
+	repo := &fakeCheckpointRepo{}
+	tenant := uuid.New()
+	repo.appendEvents(tenant, "a", "b")
+	signer := newTestSigner(t, "checkpoint-test-secret")
+
+	c, created, err := NewCheckpointAuditChainUseCase(repo, signer).
+		WithTimestamper(crypto.NewTimestampClient(tsa.URL)).Execute(ctx, tenant)
+	if err != nil || !created || c.TimestampToken != nil || !strings.Contains(c.TimestampError, "refused") {
+		t.Fatalf("expected a signed checkpoint recording the TSA failure: %+v (%v)", c, err)
+	}
+
+	// A segment cut from the middle verifies without its predecessor.
+	seg, err := NewExportAuditSegmentUseCase(repo, fakeChainRepo{repo}).Execute(ctx, tenant, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rep := VerifyAuditSegment(seg, AuditSegmentVerifyOptions{Keys: []ed25519.PublicKey{signer.PublicKey()}})
+	if !rep.Valid || rep.AnchoredThrough != 2 || len(rep.Warnings) != 1 || !strings.Contains(rep.Warnings[0], "not timestamped") {
+		t.Fatalf("expected a valid segment with one timestamp warning: %+v", rep)
+	}
+
+	if _, _, err := NewCheckpointAuditChainUseCase(repo, signer).Execute(ctx, uuid.New()); err == nil {
+		t.Fatal("an empty trail has nothing to checkpoint")
+	}
+}
@@ -0,0 +1,600 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package governance
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/crypto"
+)
+
+// =============================================================================
+// Checkpoints
+// =============================================================================
+
+// Timestamper countersigns a digest with a trusted time (RFC 3161). Satisfied
+// by crypto.TimestampClient; tests hand in tsatest.
+type Timestamper interface {
+	URL() string
+	Timestamp(ctx context.Context, digest []byte) (token []byte, genTime time.Time, err error)
+}
+
+// dueHeadsPerSweep bounds one worker pass; tenants left over are picked up by
+// the next one.
+const dueHeadsPerSweep = 500
+
+// CheckpointAuditChainUseCase signs a tenant's chain head (see
+// domain.AuditCheckpoint).
+type CheckpointAuditChainUseCase struct {
+	repo   domain.AuditCheckpointRepository
+	signer *crypto.Signer
+	tsa    Timestamper
+	events EventPublisher
+	now    func() time.Time
+}
+
+// NewCheckpointAuditChainUseCase needs the deployment signer: an unsigned
+// checkpoint would prove nothing, so there is no unsigned mode.
+func NewCheckpointAuditChainUseCase(repo domain.AuditCheckpointRepository, signer *crypto.Signer) *CheckpointAuditChainUseCase {
+	return &CheckpointAuditChainUseCase{repo: repo, signer: signer, now: time.Now}
+}
+
+// WithTimestamper countersigns every checkpoint with a timestamp authority.
+func (uc *CheckpointAuditChainUseCase) WithTimestamper(t Timestamper) *CheckpointAuditChainUseCase {
+	uc.tsa = t
+	return uc
+}
+
+// WithEvents delivers each new checkpoint as audit.checkpoint_created, so
+// subscribers keep copies outside this database.
+func (uc *CheckpointAuditChainUseCase) WithEvents(e EventPublisher) *CheckpointAuditChainUseCase {
+	uc.events = e
+	return uc
+}
+
+func (uc *CheckpointAuditChainUseCase) WithClock(now func() time.Time) *CheckpointAuditChainUseCase {
+	uc.now = now
+	return uc
+}
+
+// PublicKey is the key checkpoints verify against.
+func (uc *CheckpointAuditChainUseCase) PublicKey() *AuditPublicKey {
+	return &AuditPublicKey{Algorithm: uc.signer.Algorithm(), KeyID: uc.signer.KeyID(), PEM: string(uc.signer.PublicKeyPEM())}
+}
+
+// Execute checkpoints the tenant's current head. created is false when the
+// head already has a checkpoint; that checkpoint is returned instead.
+func (uc *CheckpointAuditChainUseCase) Execute(ctx context.Context, tenantID uuid.UUID) (*domain.AuditCheckpoint, bool, error) {
+	if tenantID == uuid.Nil {
+		return nil, false, domain.NewValidationError("tenant is required")
+	}
+	head, err := uc.repo.Head(ctx, tenantID)
+	if err != nil {
+		return nil, false, err
+	}
+	if head == nil {
+		return nil, false, domain.NewValidationError("the audit trail is empty; there is nothing to checkpoint")
+	}
+	latest, err := uc.repo.List(ctx, tenantID, 1)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(latest) == 1 && latest[0].Sequence >= head.Sequence {
+		return &latest[0], false, nil
+	}
+	return uc.checkpoint(ctx, *head)
+}
+
+// ExecuteDue checkpoints every tenant whose chain has grown since its last
+// checkpoint. A tenant that fails is retried on the next sweep.
+func (uc *CheckpointAuditChainUseCase) ExecuteDue(ctx context.Context) (int, error) {
+	heads, err := uc.repo.DueHeads(ctx, dueHeadsPerSweep)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, h := range heads {
+		if ctx.Err() != nil {
+			return n, ctx.Err()
+		}
+		if _, created, err := uc.checkpoint(ctx, h); err == nil && created {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (uc *CheckpointAuditChainUseCase) checkpoint(ctx context.Context, head domain.AuditChainHead) (*domain.AuditCheckpoint, bool, error) {
+	c := &domain.AuditCheckpoint{
+		ID:       uuid.New(),
+		TenantID: head.TenantID,
+		Sequence: head.Sequence,
+		HeadHash: head.Hash,
+		// Postgres keeps microseconds; truncating first keeps the signed
+		// payload identical after a round trip through the table.
+		SignedAt:  uc.now().UTC().Truncate(time.Microsecond),
+		Algorithm: uc.signer.Algorithm(),
+		KeyID:     uc.signer.KeyID(),
+	}
+	sig := uc.signer.Sign(c.SignedPayload())
+	c.Signature = base64.StdEncoding.EncodeToString(sig)
+
+	if uc.tsa != nil {
+		// The TSA countersigns the signature, not the head: the token then
+		// dates the whole statement, key and all.
+		c.TSAURL = uc.tsa.URL()
+		sum := sha256.Sum256(sig)
+		token, at, err := uc.tsa.Timestamp(ctx, sum[:])
+		if err != nil {
+			// A TSA outage must not stop the checkpoint; the signature alone
+			// still pins the head, and the gap is visible on the row.
+			c.TimestampError = err.Error()
+		} else {
+			stamp := at.UTC().Truncate(time.Microsecond)
+			c.TimestampToken = token
+			c.TimestampedAt = &stamp
+		}
+	}
+
+	created, err := uc.repo.Create(ctx, c)
+	if err != nil {
+		return nil, false, err
+	}
+	if !created {
+		existing, err := uc.repo.InRange(ctx, head.TenantID, head.Sequence, head.Sequence)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(existing) == 0 {
+			return nil, false, errors.New("audit checkpoint vanished after a conflicting insert")
+		}
+		return &existing[0], false, nil
+	}
+	if uc.events != nil {
+		_ = uc.events.Publish(ctx, c.TenantID, domain.WebhookAuditCheckpointed, domain.WebhookAuditCheckpointData{
+			CheckpointID:   c.ID,
+			Sequence:       c.Sequence,
+			HeadHash:       c.HeadHash,
+			SignedAt:       c.SignedAt,
+			Algorithm:      c.Algorithm,
+			KeyID:          c.KeyID,
+			Signature:      c.Signature,
+			TimestampToken: c.TimestampToken,
+			TimestampedAt:  c.TimestampedAt,
+		})
+	}
+	return c, true, nil
+}
+
+// ListAuditCheckpointsUseCase returns a tenant's latest checkpoints.
+type ListAuditCheckpointsUseCase struct {
+	repo domain.AuditCheckpointRepository
+}
+
+func NewListAuditCheckpointsUseCase(repo domain.AuditCheckpointRepository) *ListAuditCheckpointsUseCase {
+	return &ListAuditCheckpointsUseCase{repo: repo}
+}
+
+func (uc *ListAuditCheckpointsUseCase) Execute(ctx context.Context, tenantID uuid.UUID, limit int) ([]domain.AuditCheckpoint, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+	return uc.repo.List(ctx, tenantID, limit)
+}
+
+// =============================================================================
+// Segment export and offline verification
+// =============================================================================
+
+// MaxAuditSegmentEvents bounds one segment. Larger ranges are exported in
+// parts; consecutive parts verify independently.
+const MaxAuditSegmentEvents = 100000
+
+// AuditPublicKey is the key checkpoints are signed with.
+type AuditPublicKey struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"key_id"`
+	PEM       string `json:"pem"`
+}
+
+// AuditSegment is a contiguous stretch of a tenant's chain with everything
+// needed to check it away from the product: the entries, the retention seals
+// that explain gaps, and the checkpoints inside the range.
+//
+// The embedded public key is a convenience for reading the file only. A
+// verifier must be given the key from somewhere the exporter does not control;
+// otherwise whoever rewrote the chain could ship a matching key alongside it.
+type AuditSegment struct {
+	Kind         string                   `json:"kind"`
+	Version      int                      `json:"version"`
+	TenantID     uuid.UUID                `json:"tenant_id"`
+	ExportedAt   time.Time                `json:"exported_at"`
+	FromSequence int64                    `json:"from_sequence"`
+	ToSequence   int64                    `json:"to_sequence"`
+	Events       []domain.AuditEvent      `json:"events"`
+	Seals        []domain.AuditChainSeal  `json:"seals"`
+	Checkpoints  []domain.AuditCheckpoint `json:"checkpoints"`
+	PublicKey    *AuditPublicKey          `json:"public_key,omitempty"`
+}
+
+// AuditSegmentKind identifies a segment file.
+const AuditSegmentKind = "openrisk.audit-segment"
+
+// ExportAuditSegmentUseCase cuts a segment out of a tenant's chain.
+type ExportAuditSegmentUseCase struct {
+	checkpoints domain.AuditCheckpointRepository
+	chain       domain.AuditChainRepository
+	key         *AuditPublicKey
+}
+
+func NewExportAuditSegmentUseCase(checkpoints domain.AuditCheckpointRepository, chain domain.AuditChainRepository) *ExportAuditSegmentUseCase {
+	return &ExportAuditSegmentUseCase{checkpoints: checkpoints, chain: chain}
+}
+
+// WithPublicKey embeds the signing key in exported segments.
+func (uc *ExportAuditSegmentUseCase) WithPublicKey(k *AuditPublicKey) *ExportAuditSegmentUseCase {
+	uc.key = k
+	return uc
+}
+
+// Execute exports sequences [from, to]. Zero means "from the oldest surviving
+// entry" and "through the head" respectively.
+func (uc *ExportAuditSegmentUseCase) Execute(ctx context.Context, tenantID uuid.UUID, from, to int64) (*AuditSegment, error) {
+	if tenantID == uuid.Nil {
+		return nil, domain.NewValidationError("tenant is required")
+	}
+	if from < 0 || to < 0 {
+		return nil, domain.NewValidationError("from and to must be positive sequences")
+	}
+	head, err := uc.checkpoints.Head(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return nil, domain.NewValidationError("the audit trail is empty")
+	}
+	if to == 0 || to > head.Sequence {
+		to = head.Sequence
+	}
+	if from == 0 {
+		from = 1
+	}
+	if from > to {
+		return nil, domain.NewValidationError("from must not be after to")
+	}
+	if to-from+1 > MaxAuditSegmentEvents {
+		return nil, domain.NewValidationError("a segment holds at most " + strconv.Itoa(MaxAuditSegmentEvents) + " entries; export the range in parts")
+	}
+	events, err := uc.checkpoints.EventsInRange(ctx, tenantID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, domain.NewValidationError("no audit entries survive in that range")
+	}
+	first, last := events[0].Sequence, events[len(events)-1].Sequence
+
+	all, err := uc.chain.ListSeals(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	seals := []domain.AuditChainSeal{}
+	for _, s := range all {
+		// The seal just before the first entry is what it links back to.
+		if s.ToSequence >= first-1 && s.FromSequence <= last {
+			seals = append(seals, s)
+		}
+	}
+	cps, err := uc.checkpoints.InRange(ctx, tenantID, first, last)
+	if err != nil {
+		return nil, err
+	}
+	if cps == nil {
+		cps = []domain.AuditCheckpoint{}
+	}
+	return &AuditSegment{
+		Kind:         AuditSegmentKind,
+		Version:      1,
+		TenantID:     tenantID,
+		ExportedAt:   time.Now().UTC(),
+		FromSequence: first,
+		ToSequence:   last,
+		Events:       events,
+		Seals:        seals,
+		Checkpoints:  cps,
+		PublicKey:    uc.key,
+	}, nil
+}
+
+// Checkpoint verdicts.
+const (
+	CheckpointSigValid      = "valid"
+	CheckpointSigInvalid    = "invalid"
+	CheckpointSigUnknownKey = "unknown_key"
+
+	CheckpointHeadMatch    = "match"
+	CheckpointHeadMismatch = "mismatch"
+	CheckpointHeadOutside  = "outside_segment"
+
+	CheckpointTSNone         = "none"
+	CheckpointTSVerified     = "verified"
+	CheckpointTSUnanchored   = "signature_only" // token signature checked, TSA root not given
+	CheckpointTSInvalid      = "invalid"
+	CheckpointTSNotIssued    = "not_issued" // the TSA was configured but failed at the time
+	checkpointTimestampSlack = 5 * time.Minute
+)
+
+// AuditCheckpointVerdict is what offline verification concluded about one
+// checkpoint.
+type AuditCheckpointVerdict struct {
+	Sequence      int64      `json:"sequence"`
+	KeyID         string     `json:"key_id"`
+	SignedAt      time.Time  `json:"signed_at"`
+	Signature     string     `json:"signature"`
+	Head          string     `json:"head"`
+	Timestamp     string     `json:"timestamp"`
+	TimestampedAt *time.Time `json:"timestamped_at,omitempty"`
+	Valid         bool       `json:"valid"`
+	Detail        string     `json:"detail,omitempty"`
+}
+
+// AuditSegmentReport is the verdict on a segment.
+type AuditSegmentReport struct {
+	TenantID     uuid.UUID                `json:"tenant_id"`
+	Valid        bool                     `json:"valid"`
+	FromSequence int64                    `json:"from_sequence"`
+	ToSequence   int64                    `json:"to_sequence"`
+	Chain        domain.AuditChainReport  `json:"chain"`
+	Checkpoints  []AuditCheckpointVerdict `json:"checkpoints"`
+	// AnchoredThrough is the highest sequence pinned by a valid checkpoint;
+	// Unanchored counts the entries after it, which only the chain vouches for.
+	AnchoredThrough int64    `json:"anchored_through"`
+	Unanchored      int64    `json:"unanchored"`
+	Failures        []string `json:"failures"`
+	Warnings        []string `json:"warnings"`
+}
+
+// AuditSegmentVerifyOptions is what the verifier trusts. Nothing in the segment
+// itself is trusted.
+type AuditSegmentVerifyOptions struct {
+	// Keys are the deployment public keys, obtained out of band.
+	Keys []ed25519.PublicKey
+	// TSARoots anchors timestamp tokens to a TSA; nil checks the token's
+	// signature only.
+	TSARoots *x509.CertPool
+	// Checkpoints are copies kept elsewhere (webhook deliveries, a published
+	// feed), checked alongside the segment's own.
+	Checkpoints []domain.AuditCheckpoint
+}
+
+// VerifyAuditSegment checks a segment without touching the product: the chain
+// must be intact, and every checkpoint that falls inside it must carry a valid
+// signature by a trusted key over the entry hash actually found there. A
+// checkpoint kept outside the database that no longer matches is how a rewrite
+// of the whole chain shows up.
+func VerifyAuditSegment(seg *AuditSegment, opts AuditSegmentVerifyOptions) AuditSegmentReport {
+	rep := AuditSegmentReport{
+		TenantID:     seg.TenantID,
+		FromSequence: seg.FromSequence,
+		ToSequence:   seg.ToSequence,
+		Checkpoints:  []AuditCheckpointVerdict{},
+		Failures:     []string{},
+		Warnings:     []string{},
+	}
+	fail := func(s string) { rep.Failures = append(rep.Failures, s) }
+	warn := func(s string) { rep.Warnings = append(rep.Warnings, s) }
+
+	if seg.Kind != AuditSegmentKind || seg.Version != 1 {
+		fail("not an " + AuditSegmentKind + " v1 file")
+		return rep
+	}
+	if len(seg.Events) == 0 {
+		fail("the segment holds no entries")
+		return rep
+	}
+
+	rep.Chain = domain.VerifyAuditChain(seg.TenantID, seg.Events, seg.Seals)
+	// A segment cut from the middle legitimately starts without its
+	// predecessor; its first link is vouched for by the checkpoints instead.
+	breaks := rep.Chain.Breaks[:0]
+	for _, b := range rep.Chain.Breaks {
+		if b.Kind == domain.BreakUnsealedHead && b.Sequence == seg.FromSequence {
+			continue
+		}
+		breaks = append(breaks, b)
+	}
+	rep.Chain.Breaks = breaks
+	rep.Chain.Valid = len(breaks) == 0
+	for _, b := range breaks {
+		fail("chain " + b.Kind + " at sequence " + strconv.FormatInt(b.Sequence, 10) + ": " + b.Detail)
+	}
+	if seg.Events[0].Sequence != seg.FromSequence || seg.Events[len(seg.Events)-1].Sequence != seg.ToSequence {
+		fail("the entries do not span the declared range " + strconv.FormatInt(seg.FromSequence, 10) + "–" + strconv.FormatInt(seg.ToSequence, 10))
+	}
+	hashAt := make(map[int64]string, len(seg.Events))
+	for _, e := range seg.Events {
+		if e.TenantID != seg.TenantID {
+			fail("entry " + strconv.FormatInt(e.Sequence, 10) + " belongs to another tenant")
+		}
+		hashAt[e.Sequence] = e.Hash
+	}
+
+	keys := make(map[string]ed25519.PublicKey, len(opts.Keys))
+	for _, k := range opts.Keys {
+		keys[crypto.PublicKeyID(k)] = k
+	}
+	unrooted := false
+	seen := map[string]bool{}
+	for _, c := range append(append([]domain.AuditCheckpoint{}, seg.Checkpoints...), opts.Checkpoints...) {
+		if seen[c.Signature] {
+			continue
+		}
+		seen[c.Signature] = true
+		if c.TenantID != seg.TenantID {
+			warn("skipped checkpoint " + strconv.FormatInt(c.Sequence, 10) + " of another tenant")
+			continue
+		}
+		v := verifyCheckpoint(c, keys, hashAt, opts.TSARoots)
+		rep.Checkpoints = append(rep.Checkpoints, v)
+		seq := strconv.FormatInt(c.Sequence, 10)
+		switch {
+		case !v.Valid:
+			fail("checkpoint " + seq + ": " + v.Detail)
+		case v.Head == CheckpointHeadMatch && c.Sequence > rep.AnchoredThrough:
+			rep.AnchoredThrough = c.Sequence
+		}
+		switch v.Timestamp {
+		case CheckpointTSUnanchored:
+			unrooted = true
+		case CheckpointTSNotIssued:
+			warn("checkpoint " + seq + " was not timestamped: " + c.TimestampError)
+		}
+	}
+	if unrooted {
+		warn("timestamp tokens were checked without the TSA's root certificate; pass it to tie them to the TSA")
+	}
+
+	switch {
+	case rep.AnchoredThrough == 0:
+		rep.Unanchored = int64(len(seg.Events))
+		warn("no checkpoint anchors this segment; its entries are only consistent with each other")
+	case rep.AnchoredThrough < seg.ToSequence:
+		for _, e := range seg.Events {
+			if e.Sequence > rep.AnchoredThrough {
+				rep.Unanchored++
+			}
+		}
+		warn(strconv.FormatInt(rep.Unanchored, 10) + " entries after sequence " + strconv.FormatInt(rep.AnchoredThrough, 10) + " are not covered by a checkpoint yet")
+	}
+	rep.Valid = len(rep.Failures) == 0
+	return rep
+}
+
+func verifyCheckpoint(c domain.AuditCheckpoint, keys map[string]ed25519.PublicKey, hashAt map[int64]string, roots *x509.CertPool) AuditCheckpointVerdict {
+	v := AuditCheckpointVerdict{
+		Sequence: c.Sequence, KeyID: c.KeyID, SignedAt: c.SignedAt,
+		Signature: CheckpointSigInvalid, Head: CheckpointHeadOutside, Timestamp: CheckpointTSNone,
+	}
+	sig, err := base64.StdEncoding.DecodeString(c.Signature)
+	pub, known := keys[c.KeyID]
+	switch {
+	case !known:
+		v.Signature = CheckpointSigUnknownKey
+		v.Detail = "signed by key " + c.KeyID + ", which is not among the trusted keys"
+		return v
+	case err != nil || c.Algorithm != "Ed25519" || !ed25519.Verify(pub, c.SignedPayload(), sig):
+		v.Detail = "signature does not verify; the checkpoint was altered or forged"
+		return v
+	}
+	v.Signature = CheckpointSigValid
+
+	if h, ok := hashAt[c.Sequence]; ok {
+		if h != c.HeadHash {
+			v.Head = CheckpointHeadMismatch
+			v.Detail = "signed head " + c.HeadHash + " but the entry at this sequence hashes to " + h + "; the chain was rewritten after the checkpoint"
+			return v
+		}
+		v.Head = CheckpointHeadMatch
+	}
+
+	switch {
+	case len(c.TimestampToken) > 0:
+		sum := sha256.Sum256(sig)
+		info, err := crypto.VerifyTimestamp(c.TimestampToken, sum[:], roots)
+		if err != nil {
+			v.Timestamp = CheckpointTSInvalid
+			v.Detail = "timestamp token: " + err.Error()
+			return v
+		}
+		at := info.GenTime.UTC()
+		v.TimestampedAt = &at
+		// The signer's clock is the server's own; the TSA's is not. A token
+		// from well before the claimed signing time means that claim is false.
+		if at.Add(checkpointTimestampSlack).Before(c.SignedAt) {
+			v.Timestamp = CheckpointTSInvalid
+			v.Detail = "timestamped at " + at.Format(time.RFC3339) + ", before the claimed signing time " + c.SignedAt.UTC().Format(time.RFC3339)
+			return v
+		}
+		v.Timestamp = CheckpointTSVerified
+		if !info.ChainVerified {
+			v.Timestamp = CheckpointTSUnanchored
+		}
+	case c.TimestampError != "":
+		v.Timestamp = CheckpointTSNotIssued
+	}
+	v.Valid = true
+	return v
+}
+
+// ParseCheckpoints reads checkpoints kept outside the product: a JSON array of
+// checkpoints, the list endpoint's {"items": [...]}, or audit.checkpoint_created
+// webhook deliveries (one envelope or an array of them).
+func ParseCheckpoints(raw []byte) ([]domain.AuditCheckpoint, error) {
+	type envelope struct {
+		Type     domain.WebhookEventType            `json:"type"`
+		TenantID uuid.UUID                          `json:"tenant_id"`
+		Data     *domain.WebhookAuditCheckpointData `json:"data"`
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		var wrapped struct {
+			Items       []json.RawMessage `json:"items"`
+			Checkpoints []json.RawMessage `json:"checkpoints"`
+			Type        string            `json:"type"`
+		}
+		if err := json.Unmarshal(raw, &wrapped); err != nil {
+			return nil, errors.New("checkpoints file is not JSON: " + err.Error())
+		}
+		switch {
+		case wrapped.Type != "":
+			items = []json.RawMessage{raw}
+		case wrapped.Items != nil:
+			items = wrapped.Items
+		default:
+			items = wrapped.Checkpoints
+		}
+	}
+
+	out := make([]domain.AuditCheckpoint, 0, len(items))
+	for i, r := range items {
+		var env envelope
+		if err := json.Unmarshal(r, &env); err != nil {
+			return nil, errors.New("checkpoint " + strconv.Itoa(i) + ": " + err.Error())
+		}
+		if env.Type != "" {
+			if env.Type != domain.WebhookAuditCheckpointed || env.Data == nil {
+				continue // other deliveries in the same log
+			}
+			d := env.Data
+			out = append(out, domain.AuditCheckpoint{
+				ID: d.CheckpointID, TenantID: env.TenantID, Sequence: d.Sequence, HeadHash: d.HeadHash,
+				SignedAt: d.SignedAt, Algorithm: d.Algorithm, KeyID: d.KeyID, Signature: d.Signature,
+				TimestampToken: d.TimestampToken, TimestampedAt: d.TimestampedAt,
+			})
+			continue
+		}
+		var c domain.AuditCheckpoint
+		if err := json.Unmarshal(r, &c); err != nil {
+			return nil, errors.New("checkpoint " + strconv.Itoa(i) + ": " + err.Error())
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
@@ -0,0 +1,511 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package graphapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/ast"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// Query cost.
+//
+// The cost of a query is the number of objects it can return at most: a
+// list field counts as its `first` argument times one plus the cost of what
+// is selected under it, a single object as one plus its selection, a scalar
+// as nothing. risks(first: 50) { assets(first: 10) { name } } costs
+// 50 × (1 + 10) = 550. Depth alone does not bound a query — three levels of
+// 500-row lists are 125 million rows — so the cost is computed, and checked
+// against the limit, before anything is resolved.
+//
+// The schema library keeps its document parser internal, so the little of
+// a document the cost needs — operations, fragments, fields and their
+// arguments — is read here. It only ever sees documents the library has
+// already validated.
+
+// fieldCost is what the cost needs to know about a schema field.
+type fieldCost struct {
+	object bool   // returns an object, not a scalar
+	list   bool   // returns a list of it
+	typ    string // the object type
+	first  int    // the default of its `first` argument
+}
+
+// costModel is the schema's fields by type and name.
+type costModel map[string]map[string]fieldCost
+
+func newCostModel(s *ast.Schema) costModel {
+	m := costModel{}
+	for _, obj := range s.Objects {
+		fields := map[string]fieldCost{}
+		for _, f := range obj.Fields {
+			fc := fieldCost{first: domain.GraphDefaultPage}
+			t := f.Type
+			for {
+				if nn, ok := t.(*ast.NonNull); ok {
+					t = nn.OfType
+					continue
+				}
+				if l, ok := t.(*ast.List); ok {
+					fc.list = true
+					t = l.OfType
+					continue
+				}
+				break
+			}
+			if o, ok := t.(*ast.ObjectTypeDefinition); ok {
+				fc.object, fc.typ = true, o.Name
+			}
+			if arg := f.Arguments.Get("first"); arg != nil && arg.Default != nil {
+				if n, ok := arg.Default.Deserialize(nil).(int32); ok {
+					fc.first = int(n)
+				}
+			}
+			fields[f.Name] = fc
+		}
+		m[obj.Name] = fields
+	}
+	return m
+}
+
+// costCeiling stops the arithmetic from overflowing on absurd documents; any
+// cost above it is over every sensible limit anyway.
+const costCeiling = 1 << 40
+
+// cost returns the cost of the operation the request runs.
+func (m costModel) cost(query, operationName string, vars map[string]any) (int, error) {
+	doc, err := parseDocument(query)
+	if err != nil {
+		return 0, err
+	}
+	var op *operation
+	for _, o := range doc.operations {
+		if operationName == "" || o.name == operationName {
+			op = o
+			break
+		}
+	}
+	if op == nil {
+		return 0, fmt.Errorf("no operation named %q", operationName)
+	}
+	w := costWalk{model: m, doc: doc, vars: vars, defaults: op.defaults, visiting: map[string]bool{}}
+	return w.selections(op.selections, "Query"), nil
+}
+
+type costWalk struct {
+	model    costModel
+	doc      *document
+	vars     map[string]any
+	defaults map[string]any
+	visiting map[string]bool
+}
+
+func (w *costWalk) selections(sels []selection, typ string) int {
+	total := 0
+	for _, s := range sels {
+		switch {
+		case s.spread != "":
+			frag := w.doc.fragments[s.spread]
+			if frag == nil || w.visiting[s.spread] {
+				continue
+			}
+			w.visiting[s.spread] = true
+			total += w.selections(frag.selections, orType(frag.on, typ))
+			w.visiting[s.spread] = false
+		case s.field == "":
+			total += w.selections(s.selections, orType(s.on, typ))
+		case strings.HasPrefix(s.field, "__"):
+			total++
+		default:
+			fc, ok := w.model[typ][s.field]
+			if !ok || !fc.object {
+				continue
+			}
+			n := 1
+			if fc.list {
+				n = w.first(s.args["first"], fc.first)
+			}
+			total += n * (1 + w.selections(s.selections, fc.typ))
+		}
+		if total > costCeiling {
+			return costCeiling
+		}
+	}
+	return total
+}
+
+// first resolves a `first` argument: a literal, a variable or the default,
+// bounded by the page maximum the resolvers enforce.
+func (w *costWalk) first(v any, def int) int {
+	if name, ok := v.(variable); ok {
+		v = w.vars[string(name)]
+		if v == nil {
+			v = w.defaults[string(name)]
+		}
+	}
+	n := def
+	switch x := v.(type) {
+	case int64:
+		n = int(x)
+	case float64:
+		n = int(x)
+	case int32:
+		n = int(x)
+	case int:
+		n = x
+	}
+	if n < 0 {
+		return 0
+	}
+	if n > domain.GraphMaxPage {
+		return domain.GraphMaxPage
+	}
+	return n
+}
+
+func orType(cond, typ string) string {
+	if cond != "" {
+		return cond
+	}
+	return typ
+}
+
+// --- documents -------------------------------------------------------------
+
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	name       string
+	defaults   map[string]any
+	selections []selection
+}
+
+type fragment struct {
+	on         string
+	selections []selection
+}
+
+// selection is a field (field set), a fragment spread (spread set) or an
+// inline fragment (neither).
+type selection struct {
+	field      string
+	args       map[string]any
+	spread     string
+	on         string
+	selections []selection
+}
+
+// variable is a $reference in an argument.
+type variable string
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+type parser struct {
+	src string
+	pos int
+	tok token
+	err error
+}
+
+func parseDocument(src string) (*document, error) {
+	p := &parser{src: src}
+	p.advance()
+	doc := &document{fragments: map[string]*fragment{}}
+	for p.tok.kind != tokEOF && p.err == nil {
+		switch {
+		case p.peek(tokPunct, "{"):
+			doc.operations = append(doc.operations, &operation{selections: p.selectionSet()})
+		case p.peek(tokName, "fragment"):
+			p.advance()
+			name := p.name()
+			p.expect(tokName, "on")
+			f := &fragment{on: p.name()}
+			p.directives()
+			f.selections = p.selectionSet()
+			doc.fragments[name] = f
+		case p.tok.kind == tokName:
+			p.advance() // query, mutation or subscription
+			op := &operation{defaults: map[string]any{}}
+			if p.tok.kind == tokName {
+				op.name = p.name()
+			}
+			if p.accept("(") {
+				for !p.accept(")") && p.err == nil {
+					p.expect(tokPunct, "$")
+					name := p.name()
+					p.expect(tokPunct, ":")
+					p.typeRef()
+					if p.accept("=") {
+						op.defaults[name] = p.value()
+					}
+					p.directives()
+				}
+			}
+			p.directives()
+			op.selections = p.selectionSet()
+			doc.operations = append(doc.operations, op)
+		default:
+			p.fail("unexpected %q", p.tok.text)
+		}
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return doc, nil
+}
+
+func (p *parser) selectionSet() []selection {
+	p.expect(tokPunct, "{")
+	var out []selection
+	for !p.accept("}") && p.err == nil {
+		if p.accept("...") {
+			if p.tok.kind == tokName && p.tok.text != "on" {
+				s := selection{spread: p.name()}
+				p.directives()
+				out = append(out, s)
+				continue
+			}
+			var s selection
+			if p.accept("on") {
+				s.on = p.name()
+			}
+			p.directives()
+			s.selections = p.selectionSet()
+			out = append(out, s)
+			continue
+		}
+		s := selection{field: p.name()}
+		if p.accept(":") {
+			s.field = p.name()
+		}
+		if p.accept("(") {
+			s.args = map[string]any{}
+			for !p.accept(")") && p.err == nil {
+				name := p.name()
+				p.expect(tokPunct, ":")
+				s.args[name] = p.value()
+			}
+		}
+		p.directives()
+		if p.peek(tokPunct, "{") {
+			s.selections = p.selectionSet()
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func (p *parser) directives() {
+	for p.accept("@") {
+		p.name()
+		if p.accept("(") {
+			for !p.accept(")") && p.err == nil {
+				p.name()
+				p.expect(tokPunct, ":")
+				p.value()
+			}
+		}
+	}
+}
+
+func (p *parser) typeRef() {
+	if p.accept("[") {
+		p.typeRef()
+		p.expect(tokPunct, "]")
+	} else {
+		p.name()
+	}
+	p.accept("!")
+}
+
+// value reads an argument value. Only numbers and variables matter to the
+// cost; everything else is read to get past it.
+func (p *parser) value() any {
+	t := p.tok
+	switch {
+	case t.kind == tokPunct && t.text == "$":
+		p.advance()
+		return variable(p.name())
+	case t.kind == tokInt:
+		p.advance()
+		n, _ := strconv.ParseInt(t.text, 10, 64)
+		return n
+	case t.kind == tokFloat:
+		p.advance()
+		f, _ := strconv.ParseFloat(t.text, 64)
+		return f
+	case t.kind == tokString, t.kind == tokName:
+		p.advance()
+		return t.text
+	case p.accept("["):
+		var list []any
+		for !p.accept("]") && p.err == nil {
+			list = append(list, p.value())
+		}
+		return list
+	case p.accept("{"):
+		obj := map[string]any{}
+		for !p.accept("}") && p.err == nil {
+			name := p.name()
+			p.expect(tokPunct, ":")
+			obj[name] = p.value()
+		}
+		return obj
+	}
+	p.fail("unexpected %q", t.text)
+	return nil
+}
+
+func (p *parser) name() string {
+	t := p.tok
+	if t.kind != tokName {
+		p.fail("expected a name, found %q", t.text)
+		return ""
+	}
+	p.advance()
+	return t.text
+}
+
+func (p *parser) peek(kind tokenKind, text string) bool {
+	return p.tok.kind == kind && p.tok.text == text
+}
+
+// accept consumes the punctuator or keyword text if it is next.
+func (p *parser) accept(text string) bool {
+	if p.err == nil && (p.tok.kind == tokPunct || p.tok.kind == tokName) && p.tok.text == text {
+		p.advance()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(kind tokenKind, text string) {
+	if !p.peek(kind, text) {
+		p.fail("expected %q, found %q", text, p.tok.text)
+		return
+	}
+	p.advance()
+}
+
+func (p *parser) fail(format string, args ...any) {
+	if p.err == nil {
+		p.err = fmt.Errorf("graphql: "+format, args...)
+	}
+	p.tok = token{kind: tokEOF}
+}
+
+// advance reads the next token, skipping whitespace, commas and comments.
+func (p *parser) advance() {
+	if p.err != nil {
+		return
+	}
+	src := p.src
+	for p.pos < len(src) {
+		c := src[p.pos]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' {
+			p.pos++
+			continue
+		}
+		if strings.HasPrefix(src[p.pos:], "\uFEFF") {
+			p.pos += len("\uFEFF")
+			continue
+		}
+		if c == '#' {
+			for p.pos < len(src) && src[p.pos] != '\n' && src[p.pos] != '\r' {
+				p.pos++
+			}
+			continue
+		}
+		break
+	}
+	if p.pos >= len(src) {
+		p.tok = token{kind: tokEOF}
+		return
+	}
+	start := p.pos
+	c := src[p.pos]
+	switch {
+	case strings.HasPrefix(src[p.pos:], "..."):
+		p.pos += 3
+		p.tok = token{tokPunct, "..."}
+	case strings.ContainsRune("!$&()/:=@[]{}|", rune(c)):
+		p.pos++
+		p.tok = token{tokPunct, string(c)}
+	case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+		for p.pos < len(src) && isNameByte(src[p.pos]) {
+			p.pos++
+		}
+		p.tok = token{tokName, src[start:p.pos]}
+	case c == '-' || c >= '0' && c <= '9':
+		p.pos++
+		kind := tokInt
+		for p.pos < len(src) {
+			d := src[p.pos]
+			if d == '.' || d == 'e' || d == 'E' || (d == '+' || d == '-') && (src[p.pos-1] == 'e' || src[p.pos-1] == 'E') {
+				kind = tokFloat
+			} else if d < '0' || d > '9' {
+				break
+			}
+			p.pos++
+		}
+		p.tok = token{kind, src[start:p.pos]}
+	case strings.HasPrefix(src[p.pos:], `"""`):
+		p.pos += 3
+		for p.pos < len(src) && !strings.HasPrefix(src[p.pos:], `"""`) {
+			if strings.HasPrefix(src[p.pos:], `\"""`) {
+				p.pos += 3
+			}
+			p.pos++
+		}
+		if p.pos >= len(src) {
+			p.fail("unterminated block string")
+			return
+		}
+		p.pos += 3
+		p.tok = token{tokString, src[start+3 : p.pos-3]}
+	case c == '"':
+		p.pos++
+		for p.pos < len(src) && src[p.pos] != '"' && src[p.pos] != '\n' {
+			if src[p.pos] == '\\' {
+				p.pos++
+			}
+			p.pos++
+		}
+		if p.pos >= len(src) || src[p.pos] != '"' {
+			p.fail("unterminated string")
+			return
+		}
+		p.pos++
+		p.tok = token{tokString, src[start+1 : p.pos-1]}
+	default:
+		p.fail("unexpected character %q", c)
+	}
+}
+
+func isNameByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
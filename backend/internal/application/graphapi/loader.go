@@ -0,0 +1,117 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package graphapi
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Batching.
+//
+// A field under a list — Risk.assets under risks(first: 50) — is resolved
+// once per parent, concurrently. Loading per parent is the N+1 the REST
+// clients suffer today. Instead every row the request materialises is
+// recorded in a keySet for its type, and the first parent to ask for a
+// relation fetches it for every parent recorded so far, in one repository
+// call. The children that fetch returns are recorded in turn, so the next
+// level is one call too. Siblings are always recorded before any of them
+// resolves its fields: a list resolver records its whole page before
+// returning it, and a batch records every child before any parent sees its
+// own.
+
+// keySet is the ids of one type the request has materialised, in order.
+type keySet struct {
+	mu    sync.Mutex
+	order []uuid.UUID
+	has   map[uuid.UUID]bool
+}
+
+func (s *keySet) add(ids ...uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.has == nil {
+		s.has = map[uuid.UUID]bool{}
+	}
+	for _, id := range ids {
+		if !s.has[id] {
+			s.has[id] = true
+			s.order = append(s.order, id)
+		}
+	}
+}
+
+// without returns the recorded ids not yet in done.
+func without[V any](s *keySet, done map[uuid.UUID]V) []uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []uuid.UUID
+	for _, id := range s.order {
+		if _, ok := done[id]; !ok {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// batch loads one relation, keyed by parent id.
+type batch[V any] struct {
+	mu      sync.Mutex
+	parents *keySet
+	fetch   func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]V, error)
+	done    map[uuid.UUID]V
+}
+
+// load returns the parent's value, fetching it together with every recorded
+// parent not loaded yet. Concurrent callers wait for the fetch in flight and
+// are then served from it.
+func (b *batch[V]) load(ctx context.Context, id uuid.UUID) (V, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if v, ok := b.done[id]; ok {
+		return v, nil
+	}
+	b.parents.add(id)
+	ids := without(b.parents, b.done)
+	got, err := b.fetch(ctx, ids)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	for _, k := range ids {
+		b.done[k] = got[k]
+	}
+	return b.done[id], nil
+}
+
+// loaders is one request's batches. A batch is keyed by its relation and its
+// arguments: Asset.vulnerabilities(tier: ["P1"]) and an aliased
+// Asset.vulnerabilities(tier: ["P2"]) in the same query are two batches.
+type loaders struct {
+	risks, assets, vulnerabilities, controls keySet
+	// vulnAssets are the assets the loaded vulnerabilities point at.
+	vulnAssets keySet
+
+	mu      sync.Mutex
+	batches map[string]any
+}
+
+func newLoaders() *loaders {
+	return &loaders{batches: map[string]any{}}
+}
+
+func batchFor[V any](l *loaders, key string, parents *keySet, fetch func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]V, error)) *batch[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if b, ok := l.batches[key].(*batch[V]); ok {
+		return b
+	}
+	b := &batch[V]{parents: parents, fetch: fetch, done: map[uuid.UUID]V{}}
+	l.batches[key] = b
+	return b
+}
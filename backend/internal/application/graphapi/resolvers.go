@@ -0,0 +1,628 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package graphapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// request is the state of one execution: who asks, for which tenant, and the
+// request's batches. The root resolver finds it in the context; every object
+// resolver below carries it.
+type request struct {
+	viewer Viewer
+	repo   domain.GraphReadRepository
+	perms  map[string]string
+	l      *loaders
+}
+
+type requestKey struct{}
+
+func requestFrom(ctx context.Context) *request {
+	q, _ := ctx.Value(requestKey{}).(*request)
+	return q
+}
+
+// authorize enforces the field's @permission, if it has one.
+func (q *request) authorize(field string) error {
+	if p, ok := q.perms[field]; ok && (q.viewer.Can == nil || !q.viewer.Can(p)) {
+		return &fieldError{code: "FORBIDDEN", msg: "missing permission " + p, ext: map[string]any{"permission": p}}
+	}
+	return nil
+}
+
+// fieldError is a resolver error with a machine-readable code in the
+// response's extensions.
+type fieldError struct {
+	code string
+	msg  string
+	ext  map[string]any
+}
+
+func (e *fieldError) Error() string { return e.msg }
+
+func (e *fieldError) Extensions() map[string]any {
+	out := map[string]any{"code": e.code}
+	for k, v := range e.ext {
+		out[k] = v
+	}
+	return out
+}
+
+func badInput(format string, args ...any) error {
+	return &fieldError{code: "BAD_USER_INPUT", msg: fmt.Sprintf(format, args...)}
+}
+
+// internal hides a repository error — SQL included — behind a fixed message.
+func internal(what string) error {
+	return &fieldError{code: "INTERNAL", msg: "failed to load " + what}
+}
+
+func pageArgs(first, offset int32) (int, int, error) {
+	if first < 1 || first > domain.GraphMaxPage {
+		return 0, 0, badInput("first must be between 1 and %d", domain.GraphMaxPage)
+	}
+	if offset < 0 {
+		return 0, 0, badInput("offset must not be negative")
+	}
+	return int(first), int(offset), nil
+}
+
+func parseID(id graphql.ID) (uuid.UUID, error) {
+	u, err := uuid.Parse(string(id))
+	if err != nil {
+		return uuid.Nil, badInput("%q is not a valid id", string(id))
+	}
+	return u, nil
+}
+
+func strs(p *[]string) []string {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+// matches reports whether v is one of allowed, ignoring case; an empty
+// filter matches everything.
+func matches(v string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(v, a) {
+			return true
+		}
+	}
+	return false
+}
+
+func optionalID(id *uuid.UUID) *graphql.ID {
+	if id == nil {
+		return nil
+	}
+	g := graphql.ID(id.String())
+	return &g
+}
+
+func strList(v []string) []string {
+	if v == nil {
+		return []string{}
+	}
+	return v
+}
+
+// --- Query -----------------------------------------------------------------
+
+// root is the Query type. It is stateless: the request is in the context.
+type root struct{}
+
+type riskListArgs struct {
+	Criticality *[]string
+	Status      *[]string
+	MinScore    *float64
+	First       int32
+	Offset      int32
+}
+
+func (*root) Risks(ctx context.Context, args riskListArgs) (*[]*riskResolver, error) {
+	q := requestFrom(ctx)
+	if err := q.authorize("Query.risks"); err != nil {
+		return nil, err
+	}
+	first, offset, err := pageArgs(args.First, args.Offset)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.repo.ListRisks(ctx, q.viewer.TenantID, domain.GraphRiskFilter{
+		Criticality: strs(args.Criticality), Status: strs(args.Status), MinScore: args.MinScore,
+		Limit: first, Offset: offset,
+	})
+	if err != nil {
+		return nil, internal("risks")
+	}
+	return q.risks(rows), nil
+}
+
+func (*root) Risk(ctx context.Context, args struct{ ID graphql.ID }) (*riskResolver, error) {
+	q := requestFrom(ctx)
+	if err := q.authorize("Query.risk"); err != nil {
+		return nil, err
+	}
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.repo.RisksByID(ctx, q.viewer.TenantID, []uuid.UUID{id})
+	if err != nil {
+		return nil, internal("risk")
+	}
+	return one(q.risks(rows)), nil
+}
+
+type assetListArgs struct {
+	Criticality *[]string
+	Type        *string
+	First       int32
+	Offset      int32
+}
+
+func (*root) Assets(ctx context.Context, args assetListArgs) (*[]*assetResolver, error) {
+	q := requestFrom(ctx)
+	if err := q.authorize("Query.assets"); err != nil {
+		return nil, err
+	}
+	first, offset, err := pageArgs(args.First, args.Offset)
+	if err != nil {
+		return nil, err
+	}
+	f := domain.GraphAssetFilter{Criticality: strs(args.Criticality), Limit: first, Offset: offset}
+	if args.Type != nil {
+		f.Type = *args.Type
+	}
+	rows, err := q.repo.ListAssets(ctx, q.viewer.TenantID, f)
+	if err != nil {
+		return nil, internal("assets")
+	}
+	return q.assets(rows), nil
+}
+
+func (*root) Asset(ctx context.Context, args struct{ ID graphql.ID }) (*assetResolver, error) {
+	q := requestFrom(ctx)
+	if err := q.authorize("Query.asset"); err != nil {
+		return nil, err
+	}
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.repo.AssetsByID(ctx, q.viewer.TenantID, []uuid.UUID{id})
+	if err != nil {
+		return nil, internal("asset")
+	}
+	return one(q.assets(rows)), nil
+}
+
+type vulnListArgs struct {
+	Severity *[]string
+	Tier     *[]string
+	Status   *[]string
+	KEV      *bool
+	First    int32
+	Offset   int32
+}
+
+func (a vulnListArgs) filter() domain.GraphVulnerabilityFilter {
+	return domain.GraphVulnerabilityFilter{Severity: strs(a.Severity), Tier: strs(a.Tier), Status: strs(a.Status), KEV: a.KEV}
+}
+
+func (*root) Vulnerabilities(ctx context.Context, args vulnListArgs) (*[]*vulnResolver, error) {
+	q := requestFrom(ctx)
+	if err := q.authorize("Query.vulnerabilities"); err != nil {
+		return nil, err
+	}
+	first, offset, err := pageArgs(args.First, args.Offset)
+	if err != nil {
+		return nil, err
+	}
+	f := args.filter()
+	f.Limit, f.Offset = first, offset
+	rows, err := q.repo.ListVulnerabilities(ctx, q.viewer.TenantID, f)
+	if err != nil {
+		return nil, internal("vulnerabilities")
+	}
+	return q.vulns(rows), nil
+}
+
+func (*root) Vulnerability(ctx context.Context, args struct{ ID graphql.ID }) (*vulnResolver, error) {
+	q := requestFrom(ctx)
+	if err := q.authorize("Query.vulnerability"); err != nil {
+		return nil, err
+	}
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.repo.VulnerabilitiesByID(ctx, q.viewer.TenantID, []uuid.UUID{id})
+	if err != nil {
+		return nil, internal("vulnerability")
+	}
+	return one(q.vulns(rows)), nil
+}
+
+type controlListArgs struct {
+	Status      *[]string
+	FrameworkID *graphql.ID
+	First       int32
+	Offset      int32
+}
+
+func (*root) Controls(ctx context.Context, args controlListArgs) (*[]*controlResolver, error) {
+	q := requestFrom(ctx)
+	if err := q.authorize("Query.controls"); err != nil {
+		return nil, err
+	}
+	first, offset, err := pageArgs(args.First, args.Offset)
+	if err != nil {
+		return nil, err
+	}
+	f := domain.GraphControlFilter{Status: strs(args.Status), Limit: first, Offset: offset}
+	if args.FrameworkID != nil {
+		id, err := parseID(*args.FrameworkID)
+		if err != nil {
+			return nil, err
+		}
+		f.FrameworkID = &id
+	}
+	rows, err := q.repo.ListControls(ctx, q.viewer.TenantID, f)
+	if err != nil {
+		return nil, internal("controls")
+	}
+	return q.controls(rows), nil
+}
+
+func (*root) Control(ctx context.Context, args struct{ ID graphql.ID }) (*controlResolver, error) {
+	q := requestFrom(ctx)
+	if err := q.authorize("Query.control"); err != nil {
+		return nil, err
+	}
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.repo.ControlsByID(ctx, q.viewer.TenantID, []uuid.UUID{id})
+	if err != nil {
+		return nil, internal("control")
+	}
+	return one(q.controls(rows)), nil
+}
+
+// one is the single lookup's answer: the row, or null when the id is
+// unknown or belongs to another tenant.
+func one[T any](list *[]*T) *T {
+	if len(*list) == 0 {
+		return nil
+	}
+	return (*list)[0]
+}
+
+// The wrappers record the rows they are given before returning them, so a
+// relation asked for by any of them is loaded for all of them.
+
+func (q *request) risks(rows []domain.Risk) *[]*riskResolver {
+	out := make([]*riskResolver, len(rows))
+	for i := range rows {
+		q.l.risks.add(rows[i].ID)
+		out[i] = &riskResolver{q: q, r: &rows[i]}
+	}
+	return &out
+}
+
+func (q *request) assets(rows []domain.Asset) *[]*assetResolver {
+	out := make([]*assetResolver, len(rows))
+	for i := range rows {
+		q.l.assets.add(rows[i].ID)
+		out[i] = &assetResolver{q: q, a: &rows[i]}
+	}
+	return &out
+}
+
+func (q *request) vulns(rows []domain.Vulnerability) *[]*vulnResolver {
+	out := make([]*vulnResolver, len(rows))
+	for i := range rows {
+		q.l.vulnerabilities.add(rows[i].ID)
+		if rows[i].AssetID != nil {
+			q.l.vulnAssets.add(*rows[i].AssetID)
+		}
+		out[i] = &vulnResolver{q: q, v: &rows[i]}
+	}
+	return &out
+}
+
+func (q *request) controls(rows []domain.ComplianceControl) *[]*controlResolver {
+	out := make([]*controlResolver, len(rows))
+	for i := range rows {
+		q.l.controls.add(rows[i].ID)
+		out[i] = &controlResolver{q: q, c: &rows[i]}
+	}
+	return &out
+}
+
+// relation loads a list relation for one parent through the request's batch
+// for (relation, arguments). keep filters and pages each parent's children
+// in memory; record registers the children that survive it.
+func relation[T any](ctx context.Context, q *request, key string, parents *keySet, parent uuid.UUID,
+	fetch func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]T, error),
+	keep func(T) bool, limit int, record func([]T)) ([]T, error) {
+	b := batchFor(q.l, key, parents, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]T, error) {
+		got, err := fetch(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for id, rows := range got {
+			kept := rows[:0:0]
+			for _, r := range rows {
+				if len(kept) < limit && keep(r) {
+					kept = append(kept, r)
+				}
+			}
+			got[id] = kept
+			record(kept)
+		}
+		return got, nil
+	})
+	return b.load(ctx, parent)
+}
+
+func relationKey(field string, args ...any) string {
+	return fmt.Sprintf("%s%v", field, args)
+}
+
+// --- Risk ------------------------------------------------------------------
+
+type riskResolver struct {
+	q *request
+	r *domain.Risk
+}
+
+func (r *riskResolver) ID() graphql.ID { return graphql.ID(r.r.ID.String()) }
+
+func (r *riskResolver) Title() string {
+	if r.r.Title != "" {
+		return r.r.Title
+	}
+	return r.r.Name
+}
+
+func (r *riskResolver) Description() string     { return r.r.Description }
+func (r *riskResolver) Score() float64          { return r.r.Score }
+func (r *riskResolver) Probability() float64    { return r.r.Probability }
+func (r *riskResolver) Impact() float64         { return r.r.Impact }
+func (r *riskResolver) Criticality() string     { return string(r.r.Criticality) }
+func (r *riskResolver) Status() string          { return string(r.r.Status) }
+func (r *riskResolver) LifecycleState() string  { return string(r.r.LifecycleState) }
+func (r *riskResolver) Treatment() string       { return string(r.r.TreatmentPlan) }
+func (r *riskResolver) ResidualRisk() *float64  { return r.r.ResidualRisk }
+func (r *riskResolver) BusinessUnit() string    { return r.r.BusinessUnit }
+func (r *riskResolver) Tags() []string          { return strList(r.r.Tags) }
+func (r *riskResolver) OwnerID() *graphql.ID    { return optionalID(r.r.OwnerID) }
+func (r *riskResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.r.CreatedAt} }
+func (r *riskResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.r.UpdatedAt} }
+
+func (r *riskResolver) Assets(ctx context.Context, args struct {
+	Criticality *[]string
+	First       int32
+}) (*[]*assetResolver, error) {
+	if err := r.q.authorize("Risk.assets"); err != nil {
+		return nil, err
+	}
+	limit, _, err := pageArgs(args.First, 0)
+	if err != nil {
+		return nil, err
+	}
+	crit := strs(args.Criticality)
+	q := r.q
+	rows, err := relation(ctx, q, relationKey("Risk.assets", crit, limit), &q.l.risks, r.r.ID,
+		func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]domain.Asset, error) {
+			return q.repo.AssetsOfRisks(ctx, q.viewer.TenantID, ids)
+		},
+		func(a domain.Asset) bool { return matches(string(a.Criticality), crit) }, limit,
+		func(rows []domain.Asset) { q.assets(rows) })
+	if err != nil {
+		return nil, internal("assets")
+	}
+	return q.assets(rows), nil
+}
+
+func (r *riskResolver) Controls(ctx context.Context, args struct {
+	Status *[]string
+	First  int32
+}) (*[]*controlResolver, error) {
+	if err := r.q.authorize("Risk.controls"); err != nil {
+		return nil, err
+	}
+	limit, _, err := pageArgs(args.First, 0)
+	if err != nil {
+		return nil, err
+	}
+	status := strs(args.Status)
+	q := r.q
+	rows, err := relation(ctx, q, relationKey("Risk.controls", status, limit), &q.l.risks, r.r.ID,
+		func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]domain.ComplianceControl, error) {
+			return q.repo.ControlsOfRisks(ctx, q.viewer.TenantID, ids)
+		},
+		func(c domain.ComplianceControl) bool { return matches(string(c.Status), status) }, limit,
+		func(rows []domain.ComplianceControl) { q.controls(rows) })
+	if err != nil {
+		return nil, internal("controls")
+	}
+	return q.controls(rows), nil
+}
+
+// --- Asset -----------------------------------------------------------------
+
+type assetResolver struct {
+	q *request
+	a *domain.Asset
+}
+
+func (r *assetResolver) ID() graphql.ID          { return graphql.ID(r.a.ID.String()) }
+func (r *assetResolver) Name() string            { return r.a.Name }
+func (r *assetResolver) Type() string            { return r.a.Type }
+func (r *assetResolver) Category() string        { return string(r.a.Category) }
+func (r *assetResolver) Criticality() string     { return string(r.a.Criticality) }
+func (r *assetResolver) Owner() string           { return r.a.Owner }
+func (r *assetResolver) Hostnames() []string     { return strList(r.a.Hostnames) }
+func (r *assetResolver) IPAddresses() []string   { return strList(r.a.IPAddresses) }
+func (r *assetResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.a.CreatedAt} }
+func (r *assetResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.a.UpdatedAt} }
+
+type riskRelationArgs struct {
+	Criticality *[]string
+	Status      *[]string
+	First       int32
+}
+
+func (r *assetResolver) Risks(ctx context.Context, args riskRelationArgs) (*[]*riskResolver, error) {
+	if err := r.q.authorize("Asset.risks"); err != nil {
+		return nil, err
+	}
+	q := r.q
+	return risksOf(ctx, q, "Asset.risks", &q.l.assets, r.a.ID, args,
+		func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]domain.Risk, error) {
+			return q.repo.RisksOfAssets(ctx, q.viewer.TenantID, ids)
+		})
+}
+
+func (r *assetResolver) Vulnerabilities(ctx context.Context, args vulnListArgs) (*[]*vulnResolver, error) {
+	if err := r.q.authorize("Asset.vulnerabilities"); err != nil {
+		return nil, err
+	}
+	limit, _, err := pageArgs(args.First, 0)
+	if err != nil {
+		return nil, err
+	}
+	f := args.filter()
+	q := r.q
+	// The filter goes to the database: an asset can carry thousands of
+	// findings, of which the query wants a handful.
+	rows, err := relation(ctx, q, relationKey("Asset.vulnerabilities", f.Severity, f.Tier, f.Status, f.KEV != nil && *f.KEV, f.KEV == nil, limit),
+		&q.l.assets, r.a.ID,
+		func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]domain.Vulnerability, error) {
+			return q.repo.VulnerabilitiesOfAssets(ctx, q.viewer.TenantID, ids, f)
+		},
+		func(domain.Vulnerability) bool { return true }, limit,
+		func(rows []domain.Vulnerability) { q.vulns(rows) })
+	if err != nil {
+		return nil, internal("vulnerabilities")
+	}
+	return q.vulns(rows), nil
+}
+
+func risksOf(ctx context.Context, q *request, field string, parents *keySet, parent uuid.UUID, args riskRelationArgs,
+	fetch func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]domain.Risk, error)) (*[]*riskResolver, error) {
+	limit, _, err := pageArgs(args.First, 0)
+	if err != nil {
+		return nil, err
+	}
+	crit, status := strs(args.Criticality), strs(args.Status)
+	rows, err := relation(ctx, q, relationKey(field, crit, status, limit), parents, parent, fetch,
+		func(r domain.Risk) bool {
+			return matches(string(r.Criticality), crit) && matches(string(r.Status), status)
+		}, limit,
+		func(rows []domain.Risk) { q.risks(rows) })
+	if err != nil {
+		return nil, internal("risks")
+	}
+	return q.risks(rows), nil
+}
+
+// --- Vulnerability ---------------------------------------------------------
+
+type vulnResolver struct {
+	q *request
+	v *domain.Vulnerability
+}
+
+func (r *vulnResolver) ID() graphql.ID          { return graphql.ID(r.v.ID.String()) }
+func (r *vulnResolver) CveID() string           { return r.v.CVEID }
+func (r *vulnResolver) Title() string           { return r.v.Title }
+func (r *vulnResolver) Severity() string        { return string(r.v.Severity) }
+func (r *vulnResolver) CvssScore() float64      { return r.v.CVSSScore }
+func (r *vulnResolver) Epss() float64           { return r.v.EPSS }
+func (r *vulnResolver) Kev() bool               { return r.v.KEV }
+func (r *vulnResolver) ExploitAvailable() bool  { return r.v.ExploitAvailable }
+func (r *vulnResolver) PriorityScore() float64  { return r.v.PriorityScore }
+func (r *vulnResolver) PriorityTier() string    { return r.v.PriorityTier }
+func (r *vulnResolver) Status() string          { return string(r.v.Status) }
+func (r *vulnResolver) Source() string          { return string(r.v.Source) }
+func (r *vulnResolver) FirstSeen() graphql.Time { return graphql.Time{Time: r.v.FirstSeen} }
+func (r *vulnResolver) LastSeen() graphql.Time  { return graphql.Time{Time: r.v.LastSeen} }
+func (r *vulnResolver) AssetID() *graphql.ID    { return optionalID(r.v.AssetID) }
+
+func (r *vulnResolver) Asset(ctx context.Context) (*assetResolver, error) {
+	if err := r.q.authorize("Vulnerability.asset"); err != nil {
+		return nil, err
+	}
+	if r.v.AssetID == nil {
+		return nil, nil
+	}
+	q := r.q
+	b := batchFor(q.l, "Vulnerability.asset", &q.l.vulnAssets,
+		func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Asset, error) {
+			rows, err := q.repo.AssetsByID(ctx, q.viewer.TenantID, ids)
+			if err != nil {
+				return nil, err
+			}
+			q.assets(rows)
+			out := make(map[uuid.UUID]*domain.Asset, len(rows))
+			for i := range rows {
+				out[rows[i].ID] = &rows[i]
+			}
+			return out, nil
+		})
+	a, err := b.load(ctx, *r.v.AssetID)
+	if err != nil {
+		return nil, internal("asset")
+	}
+	if a == nil {
+		return nil, nil
+	}
+	return &assetResolver{q: q, a: a}, nil
+}
+
+// --- Control ---------------------------------------------------------------
+
+type controlResolver struct {
+	q *request
+	c *domain.ComplianceControl
+}
+
+func (r *controlResolver) ID() graphql.ID          { return graphql.ID(r.c.ID.String()) }
+func (r *controlResolver) ReferenceCode() string   { return r.c.ReferenceCode }
+func (r *controlResolver) Name() string            { return r.c.Name }
+func (r *controlResolver) Description() string     { return r.c.Description }
+func (r *controlResolver) Status() string          { return string(r.c.Status) }
+func (r *controlResolver) FrameworkID() graphql.ID { return graphql.ID(r.c.FrameworkID.String()) }
+func (r *controlResolver) Framework() string       { return r.c.Framework.Name }
+
+func (r *controlResolver) Risks(ctx context.Context, args riskRelationArgs) (*[]*riskResolver, error) {
+	if err := r.q.authorize("Control.risks"); err != nil {
+		return nil, err
+	}
+	q := r.q
+	return risksOf(ctx, q, "Control.risks", &q.l.controls, r.c.ID, args,
+		func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]domain.Risk, error) {
+			return q.repo.RisksOfControls(ctx, q.viewer.TenantID, ids)
+		})
+}
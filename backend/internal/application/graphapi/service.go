@@ -0,0 +1,241 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package graphapi is the read-only GraphQL API over risks, assets,
+// vulnerabilities and compliance controls: the joined views ("critical risks,
+// their assets, the open P1 findings on those assets and the status of the
+// mapped controls") that would otherwise take one REST list call per record.
+//
+// It adds no access path of its own. The tenant comes from the caller's
+// session and every repository query is scoped by it, relations included; each
+// object field carries the same resource:action permission its REST route
+// requires (@permission in schema.graphql), checked per field, so a caller
+// without assets:read gets its risks with `assets: null` and a FORBIDDEN error
+// rather than either the assets or nothing at all. A query's cost is computed
+// and bounded before it runs, and relations are batched: one repository call
+// per relation per level, whatever the page size.
+package graphapi
+
+import (
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	graphql "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// Viewer is who runs the query. Can reports whether the caller holds a
+// permission, with the wildcard semantics of the REST middleware.
+type Viewer struct {
+	TenantID uuid.UUID
+	UserID   *uuid.UUID
+	Can      func(permission string) bool
+}
+
+// Limits bound what one query may ask for.
+type Limits struct {
+	// MaxCost is the most objects a query may be able to return (see cost.go).
+	MaxCost int
+	// MaxDepth is the deepest field nesting allowed.
+	MaxDepth int
+	// MaxQueryBytes is the longest query document accepted.
+	MaxQueryBytes int
+}
+
+// DefaultLimits allow the dashboard queries — 50 risks × 10 assets × 20
+// findings is 10 550 — with room to spare, and stop a three-level walk of
+// full pages.
+var DefaultLimits = Limits{MaxCost: 50000, MaxDepth: 8, MaxQueryBytes: 32 << 10}
+
+// Params is a GraphQL request as POSTed (or sent as GET parameters).
+type Params struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+	Extensions    struct {
+		PersistedQuery *PersistedQueryRef `json:"persistedQuery,omitempty"`
+	} `json:"extensions"`
+}
+
+// PersistedQueryRef is the automatic persisted query extension: the client
+// sends the hash alone and, when the server does not know it yet, the hash
+// with the query.
+type PersistedQueryRef struct {
+	Version    int    `json:"version"`
+	Sha256Hash string `json:"sha256Hash"`
+}
+
+// Result is a GraphQL response. Data is absent when the query was rejected
+// before it ran.
+type Result struct {
+	Data       json.RawMessage         `json:"data,omitempty"`
+	Errors     []*gqlerrors.QueryError `json:"errors,omitempty"`
+	Extensions map[string]any          `json:"extensions,omitempty"`
+}
+
+// Service runs queries.
+type Service struct {
+	repo      domain.GraphReadRepository
+	persisted domain.GraphQLPersistedQueryRepository // optional
+	limits    Limits
+	schema    *graphql.Schema
+	perms     map[string]string
+	costs     costModel
+}
+
+// New builds the service with DefaultLimits and no persisted queries.
+func New(repo domain.GraphReadRepository) *Service {
+	return (&Service{repo: repo}).WithLimits(DefaultLimits)
+}
+
+// WithPersistedQueries enables automatic persisted queries, stored per tenant.
+func (s *Service) WithPersistedQueries(repo domain.GraphQLPersistedQueryRepository) *Service {
+	s.persisted = repo
+	return s
+}
+
+// WithLimits replaces the limits; a zero field keeps the default.
+func (s *Service) WithLimits(l Limits) *Service {
+	if l.MaxCost <= 0 {
+		l.MaxCost = DefaultLimits.MaxCost
+	}
+	if l.MaxDepth <= 0 {
+		l.MaxDepth = DefaultLimits.MaxDepth
+	}
+	if l.MaxQueryBytes <= 0 {
+		l.MaxQueryBytes = DefaultLimits.MaxQueryBytes
+	}
+	s.limits = l
+	s.schema = graphql.MustParseSchema(schemaSDL, &root{},
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(l.MaxDepth),
+		graphql.MaxQueryLength(l.MaxQueryBytes),
+		graphql.MaxParallelism(10),
+	)
+	s.perms = permissions(s.schema)
+	s.costs = newCostModel(s.schema.ASTSchema())
+	return s
+}
+
+// SDL is the schema document, served for client code generation.
+func (s *Service) SDL() string { return schemaSDL }
+
+// permissions reads the @permission directives into "Type.field" →
+// permission, the table the resolvers authorize against.
+func permissions(schema *graphql.Schema) map[string]string {
+	out := map[string]string{}
+	for _, obj := range schema.ASTSchema().Objects {
+		for _, f := range obj.Fields {
+			d := f.Directives.Get("permission")
+			if d == nil {
+				continue
+			}
+			if arg, ok := d.Arguments.Get("requires"); ok {
+				if p, ok := arg.Deserialize(nil).(string); ok {
+					out[obj.Name+"."+f.Name] = p
+				}
+			}
+		}
+	}
+	return out
+}
+
+// Execute runs one query for the viewer. Rejections — unknown persisted
+// query, invalid document, cost over the limit — come back as a Result with
+// errors and no data, as GraphQL clients expect, never as a Go error.
+func (s *Service) Execute(ctx context.Context, viewer Viewer, req Params) *Result {
+	query, rejected := s.resolvePersisted(ctx, viewer, req)
+	if rejected != nil {
+		return rejected
+	}
+	if strings.TrimSpace(query) == "" {
+		return reject("BAD_REQUEST", "query is required", nil)
+	}
+	if len(query) > s.limits.MaxQueryBytes {
+		return reject("BAD_REQUEST", fmt.Sprintf("query is longer than %d bytes", s.limits.MaxQueryBytes), nil)
+	}
+	if errs := s.schema.ValidateWithVariables(query, req.Variables); len(errs) > 0 {
+		for _, e := range errs {
+			if e.Extensions == nil {
+				e.Extensions = map[string]any{"code": "GRAPHQL_VALIDATION_FAILED"}
+			}
+		}
+		return &Result{Errors: errs}
+	}
+	cost, err := s.costs.cost(query, req.OperationName, req.Variables)
+	if err != nil {
+		return reject("GRAPHQL_VALIDATION_FAILED", err.Error(), nil)
+	}
+	if cost > s.limits.MaxCost {
+		return reject("COST_LIMIT_EXCEEDED",
+			fmt.Sprintf("query cost %d exceeds the limit of %d; lower `first` or select fewer relations", cost, s.limits.MaxCost),
+			map[string]any{"cost": cost, "limit": s.limits.MaxCost})
+	}
+	if pq := req.Extensions.PersistedQuery; pq != nil && req.Query != "" && s.persisted != nil {
+		// Registered only once it is known to be valid and affordable.
+		_ = s.persisted.SavePersistedQuery(ctx, &domain.GraphQLPersistedQuery{
+			TenantID: viewer.TenantID, Hash: strings.ToLower(pq.Sha256Hash), Query: req.Query, CreatedBy: viewer.UserID,
+		})
+	}
+
+	q := &request{viewer: viewer, repo: s.repo, perms: s.perms, l: newLoaders()}
+	res := s.schema.Exec(context.WithValue(ctx, requestKey{}, q), query, req.OperationName, req.Variables)
+	out := &Result{Data: res.Data, Errors: res.Errors, Extensions: res.Extensions}
+	if out.Extensions == nil {
+		out.Extensions = map[string]any{}
+	}
+	out.Extensions["cost"] = map[string]any{"requested": cost, "limit": s.limits.MaxCost}
+	return out
+}
+
+// resolvePersisted returns the query the request runs: its own text, or the
+// persisted one its hash names.
+func (s *Service) resolvePersisted(ctx context.Context, viewer Viewer, req Params) (string, *Result) {
+	pq := req.Extensions.PersistedQuery
+	if pq == nil {
+		return req.Query, nil
+	}
+	if s.persisted == nil {
+		return "", reject("PERSISTED_QUERY_NOT_SUPPORTED", "PersistedQueryNotSupported", nil)
+	}
+	if pq.Version != 1 {
+		return "", reject("BAD_REQUEST", "unsupported persisted query version", nil)
+	}
+	hash := strings.ToLower(pq.Sha256Hash)
+	if req.Query != "" {
+		sum := sha256.Sum256([]byte(req.Query))
+		if hex.EncodeToString(sum[:]) != hash {
+			return "", reject("BAD_REQUEST", "provided sha256Hash does not match query", nil)
+		}
+		return req.Query, nil
+	}
+	stored, err := s.persisted.GetPersistedQuery(ctx, viewer.TenantID, hash)
+	if err != nil {
+		return "", reject("INTERNAL", "failed to load persisted query", nil)
+	}
+	if stored == nil {
+		return "", reject("PERSISTED_QUERY_NOT_FOUND", "PersistedQueryNotFound", nil)
+	}
+	return stored.Query, nil
+}
+
+func reject(code, msg string, ext map[string]any) *Result {
+	e := &gqlerrors.QueryError{Message: msg, Extensions: map[string]any{"code": code}}
+	for k, v := range ext {
+		e.Extensions[k] = v
+	}
+	return &Result{Errors: []*gqlerrors.QueryError{e}}
+}
@@ -0,0 +1,449 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package graphapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// fakeGraph is an in-memory GraphReadRepository over one tenant's records. It
+// counts calls per method and records the tenant of every call.
+type fakeGraph struct {
+	mu      sync.Mutex
+	calls   map[string]int
+	tenants map[uuid.UUID]bool
+
+	risks      []domain.Risk
+	assets     []domain.Asset
+	vulns      []domain.Vulnerability
+	controls   []domain.ComplianceControl
+	riskAssets map[uuid.UUID][]uuid.UUID // risk → assets
+	riskCtrls  map[uuid.UUID][]uuid.UUID // risk → controls
+}
+
+func (f *fakeGraph) call(name string, tenantID uuid.UUID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.calls == nil {
+		f.calls, f.tenants = map[string]int{}, map[uuid.UUID]bool{}
+	}
+	f.calls[name]++
+	f.tenants[tenantID] = true
+}
+
+func (f *fakeGraph) count(name string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[name]
+}
+
+func pick[T any](rows []T, id func(T) uuid.UUID, ids []uuid.UUID) []T {
+	var out []T
+	for _, r := range rows {
+		for _, want := range ids {
+			if id(r) == want {
+				out = append(out, r)
+			}
+		}
+	}
+	return out
+}
+
+func head[T any](rows []T, limit int) []T {
+	if limit > 0 && len(rows) > limit {
+		return rows[:limit]
+	}
+	return rows
+}
+
+func riskID(r domain.Risk) uuid.UUID                 { return r.ID }
+func assetID(a domain.Asset) uuid.UUID               { return a.ID }
+func vulnID(v domain.Vulnerability) uuid.UUID        { return v.ID }
+func controlID(c domain.ComplianceControl) uuid.UUID { return c.ID }
+
+func (f *fakeGraph) ListRisks(_ context.Context, t uuid.UUID, flt domain.GraphRiskFilter) ([]domain.Risk, error) {
+	f.call("ListRisks", t)
+	var out []domain.Risk
+	for _, r := range f.risks {
+		if matches(string(r.Criticality), flt.Criticality) {
+			out = append(out, r)
+		}
+	}
+	return head(out, flt.Limit), nil
+}
+
+func (f *fakeGraph) ListAssets(_ context.Context, t uuid.UUID, flt domain.GraphAssetFilter) ([]domain.Asset, error) {
+	f.call("ListAssets", t)
+	return head(f.assets, flt.Limit), nil
+}
+
+func (f *fakeGraph) ListVulnerabilities(_ context.Context, t uuid.UUID, flt domain.GraphVulnerabilityFilter) ([]domain.Vulnerability, error) {
+	f.call("ListVulnerabilities", t)
+	return head(f.vulns, flt.Limit), nil
+}
+
+func (f *fakeGraph) ListControls(_ context.Context, t uuid.UUID, flt domain.GraphControlFilter) ([]domain.ComplianceControl, error) {
+	f.call("ListControls", t)
+	return head(f.controls, flt.Limit), nil
+}
+
+func (f *fakeGraph) RisksByID(_ context.Context, t uuid.UUID, ids []uuid.UUID) ([]domain.Risk, error) {
+	f.call("RisksByID", t)
+	return pick(f.risks, riskID, ids), nil
+}
+
+func (f *fakeGraph) AssetsByID(_ context.Context, t uuid.UUID, ids []uuid.UUID) ([]domain.Asset, error) {
+	f.call("AssetsByID", t)
+	return pick(f.assets, assetID, ids), nil
+}
+
+func (f *fakeGraph) VulnerabilitiesByID(_ context.Context, t uuid.UUID, ids []uuid.UUID) ([]domain.Vulnerability, error) {
+	f.call("VulnerabilitiesByID", t)
+	return pick(f.vulns, vulnID, ids), nil
+}
+
+func (f *fakeGraph) ControlsByID(_ context.Context, t uuid.UUID, ids []uuid.UUID) ([]domain.ComplianceControl, error) {
+	f.call("ControlsByID", t)
+	return pick(f.controls, controlID, ids), nil
+}
+
+func (f *fakeGraph) AssetsOfRisks(_ context.Context, t uuid.UUID, ids []uuid.UUID) (map[uuid.UUID][]domain.Asset, error) {
+	f.call("AssetsOfRisks", t)
+	out := map[uuid.UUID][]domain.Asset{}
+	for _, id := range ids {
+		out[id] = pick(f.assets, assetID, f.riskAssets[id])
+	}
+	return out, nil
+}
+
+func (f *fakeGraph) RisksOfAssets(_ context.Context, t uuid.UUID, ids []uuid.UUID) (map[uuid.UUID][]domain.Risk, error) {
+	f.call("RisksOfAssets", t)
+	out := map[uuid.UUID][]domain.Risk{}
+	for _, id := range ids {
+		for _, r := range f.risks {
+			for _, a := range f.riskAssets[r.ID] {
+				if a == id {
+					out[id] = append(out[id], r)
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeGraph) ControlsOfRisks(_ context.Context, t uuid.UUID, ids []uuid.UUID) (map[uuid.UUID][]domain.ComplianceControl, error) {
+	f.call("ControlsOfRisks", t)
+	out := map[uuid.UUID][]domain.ComplianceControl{}
+	for _, id := range ids {
+		out[id] = pick(f.controls, controlID, f.riskCtrls[id])
+	}
+	return out, nil
+}
+
+func (f *fakeGraph) RisksOfControls(_ context.Context, t uuid.UUID, ids []uuid.UUID) (map[uuid.UUID][]domain.Risk, error) {
+	f.call("RisksOfControls", t)
+	return map[uuid.UUID][]domain.Risk{}, nil
+}
+
+func (f *fakeGraph) VulnerabilitiesOfAssets(_ context.Context, t uuid.UUID, ids []uuid.UUID, flt domain.GraphVulnerabilityFilter) (map[uuid.UUID][]domain.Vulnerability, error) {
+	f.call("VulnerabilitiesOfAssets", t)
+	out := map[uuid.UUID][]domain.Vulnerability{}
+	for _, v := range f.vulns {
+		if v.AssetID == nil || !matches(v.PriorityTier, flt.Tier) {
+			continue
+		}
+		for _, id := range ids {
+			if *v.AssetID == id {
+				out[id] = append(out[id], v)
+			}
+		}
+	}
+	return out, nil
+}
+
+// newFakeGraph builds five critical risks, each on its own asset with two
+// findings (one P1, one P3) and mapped to one control.
+func newFakeGraph() *fakeGraph {
+	f := &fakeGraph{riskAssets: map[uuid.UUID][]uuid.UUID{}, riskCtrls: map[uuid.UUID][]uuid.UUID{}}
+	for i := 0; i < 5; i++ {
+		r := domain.Risk{Title: "risk", Criticality: "critical", Score: float64(20 - i)}
+		r.ID = uuid.New()
+		a := domain.Asset{Name: "asset"}
+		a.ID = uuid.New()
+		c := domain.ComplianceControl{Name: "control", Status: "implemented"}
+		c.ID = uuid.New()
+		for _, tier := range []string{"P1", "P3"} {
+			v := domain.Vulnerability{CVEID: "CVE-2026-0001", PriorityTier: tier, AssetID: &a.ID}
+			v.ID = uuid.New()
+			f.vulns = append(f.vulns, v)
+		}
+		f.risks = append(f.risks, r)
+		f.assets = append(f.assets, a)
+		f.controls = append(f.controls, c)
+		f.riskAssets[r.ID] = []uuid.UUID{a.ID}
+		f.riskCtrls[r.ID] = []uuid.UUID{c.ID}
+	}
+	return f
+}
+
+type fakePersisted struct {
+	mu   sync.Mutex
+	rows map[string]domain.GraphQLPersistedQuery
+}
+
+func (f *fakePersisted) GetPersistedQuery(_ context.Context, t uuid.UUID, hash string) (*domain.GraphQLPersistedQuery, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	q, ok := f.rows[t.String()+hash]
+	if !ok {
+		return nil, nil
+	}
+	return &q, nil
+}
+
+func (f *fakePersisted) SavePersistedQuery(_ context.Context, q *domain.GraphQLPersistedQuery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.rows == nil {
+		f.rows = map[string]domain.GraphQLPersistedQuery{}
+	}
+	f.rows[q.TenantID.String()+q.Hash] = *q
+	return nil
+}
+
+func viewer(tenant uuid.UUID, perms ...string) Viewer {
+	return Viewer{TenantID: tenant, Can: func(p string) bool {
+		for _, have := range perms {
+			if have == p {
+				return true
+			}
+		}
+		return false
+	}}
+}
+
+var allPerms = []string{"risks:read", "assets:read", "vulnerabilities:read", "compliance:controls:read"}
+
+const dashboardQuery = `
+query Dashboard($tier: [String!]) {
+  risks(criticality: ["CRITICAL"], first: 20) {
+    id
+    title
+    assets(first: 10) {
+      name
+      vulnerabilities(tier: $tier, first: 20) { cveId priorityTier asset { name } }
+    }
+    controls { name status }
+  }
+}`
+
+func decode(t *testing.T, res *Result) map[string]any {
+	t.Helper()
+	var data map[string]any
+	if len(res.Data) > 0 {
+		if err := json.Unmarshal(res.Data, &data); err != nil {
+			t.Fatalf("invalid data: %v", err)
+		}
+	}
+	return data
+}
+
+func code(res *Result) string {
+	if len(res.Errors) == 0 {
+		return ""
+	}
+	c, _ := res.Errors[0].Extensions["code"].(string)
+	return c
+}
+
+func TestExecute_JoinedViewIsBatchedPerLevel(t *testing.T) {
+	repo := newFakeGraph()
+	tenant := uuid.New()
+	res := New(repo).Execute(context.Background(), viewer(tenant, allPerms...),
+		Params{Query: dashboardQuery, Variables: map[string]any{"tier": []any{"P1"}}})
+	if len(res.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", res.Errors)
+	}
+	risks := decode(t, res)["risks"].([]any)
+	if len(risks) != 5 {
+		t.Fatalf("want 5 risks, got %d", len(risks))
+	}
+	for _, r := range risks {
+		assets := r.(map[string]any)["assets"].([]any)
+		if len(assets) != 1 {
+			t.Fatalf("want 1 asset per risk, got %d", len(assets))
+		}
+		vulns := assets[0].(map[string]any)["vulnerabilities"].([]any)
+		if len(vulns) != 1 || vulns[0].(map[string]any)["priorityTier"] != "P1" {
+			t.Fatalf("want the asset's one P1 finding, got %v", vulns)
+		}
+		if ctrls := r.(map[string]any)["controls"].([]any); len(ctrls) != 1 {
+			t.Fatalf("want 1 control per risk, got %d", len(ctrls))
+		}
+	}
+	// Five risks, five assets, ten findings: one call per relation, not one
+	// per parent.
+	for _, m := range []string{"ListRisks", "AssetsOfRisks", "ControlsOfRisks", "VulnerabilitiesOfAssets", "AssetsByID"} {
+		if n := repo.count(m); n != 1 {
+			t.Errorf("%s called %d times, want 1", m, n)
+		}
+	}
+	if len(repo.tenants) != 1 || !repo.tenants[tenant] {
+		t.Errorf("repository saw tenants %v, want only %s", repo.tenants, tenant)
+	}
+	if c, ok := res.Extensions["cost"].(map[string]any); !ok || c["requested"].(int) <= 0 {
+		t.Errorf("missing cost extension: %v", res.Extensions)
+	}
+}
+
+func TestExecute_FieldWithoutPermissionIsNullAndForbidden(t *testing.T) {
+	repo := newFakeGraph()
+	res := New(repo).Execute(context.Background(), viewer(uuid.New(), "risks:read"),
+		Params{Query: `{ risks { title assets { name } } }`})
+	risks := decode(t, res)["risks"].([]any)
+	if len(risks) != 5 {
+		t.Fatalf("risks:read should still see the risks, got %d", len(risks))
+	}
+	if a := risks[0].(map[string]any)["assets"]; a != nil {
+		t.Fatalf("assets must be null without assets:read, got %v", a)
+	}
+	if code(res) != "FORBIDDEN" || res.Errors[0].Extensions["permission"] != "assets:read" {
+		t.Fatalf("want FORBIDDEN naming assets:read, got %v", res.Errors)
+	}
+	if repo.count("AssetsOfRisks") != 0 {
+		t.Fatal("a forbidden relation must not be loaded")
+	}
+
+	res = New(repo).Execute(context.Background(), viewer(uuid.New()), Params{Query: `{ risks { title } }`})
+	if decode(t, res)["risks"] != nil || code(res) != "FORBIDDEN" {
+		t.Fatalf("no permission: want null risks and FORBIDDEN, got %s %v", res.Data, res.Errors)
+	}
+}
+
+func TestExecute_RejectsQueriesOverTheCostLimit(t *testing.T) {
+	repo := newFakeGraph()
+	svc := New(repo).WithLimits(Limits{MaxCost: 1000})
+	// 500 × (1 + 500 × (1 + 1)) = 500 500, well over 1000.
+	res := svc.Execute(context.Background(), viewer(uuid.New(), allPerms...),
+		Params{Query: `{ risks(first: 500) { assets(first: $n) { vulnerabilities(first: 1) { asset { name } } } } }`})
+	if code(res) != "GRAPHQL_VALIDATION_FAILED" {
+		t.Fatalf("undeclared variable should fail validation, got %v", res.Errors)
+	}
+
+	res = svc.Execute(context.Background(), viewer(uuid.New(), allPerms...), Params{
+		Query:     `query($n: Int) { risks(first: 500) { ...A } } fragment A on Risk { assets(first: $n) { vulnerabilities(first: 1) { id } } }`,
+		Variables: map[string]any{"n": 500},
+	})
+	if code(res) != "COST_LIMIT_EXCEEDED" {
+		t.Fatalf("want COST_LIMIT_EXCEEDED, got %v", res.Errors)
+	}
+	if res.Data != nil || repo.count("ListRisks") != 0 {
+		t.Fatal("a query over the limit must not run")
+	}
+	if res.Errors[0].Extensions["cost"].(int) <= 1000 {
+		t.Fatalf("reported cost %v should exceed the limit", res.Errors[0].Extensions["cost"])
+	}
+
+	res = svc.Execute(context.Background(), viewer(uuid.New(), allPerms...), Params{Query: `{ risks(first: 10) { title } }`})
+	if len(res.Errors) > 0 {
+		t.Fatalf("a cheap query should run: %v", res.Errors)
+	}
+}
+
+func TestCost(t *testing.T) {
+	m := New(newFakeGraph()).costs
+	cases := []struct {
+		query string
+		vars  map[string]any
+		want  int
+	}{
+		{`{ risks(first: 50) { assets(first: 10) { name } } }`, nil, 50 * (1 + 10)},
+		{`{ risks { title } }`, nil, 50},
+		{`{ risk(id: "x") { assets { vulnerabilities(first: 2) { asset { name } } } } }`, nil, 1 + 50*(1+2*(1+1))},
+		{`query($n: Int = 7) { assets(first: $n) { id } }`, nil, 7},
+		{`query($n: Int = 7) { assets(first: $n) { id } }`, map[string]any{"n": 3}, 3},
+		{`{ a: risks(first: 2) { id } b: risks(first: 3) { id } }`, nil, 5},
+		{`{ ... on Query { risks(first: 9000) { id } } }`, nil, domain.GraphMaxPage},
+		{`{ __typename risks(first: 1) { __typename } }`, nil, 1 + 1*(1+1)},
+	}
+	for _, tc := range cases {
+		got, err := m.cost(tc.query, "", tc.vars)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.query, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: cost %d, want %d", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestExecute_PersistedQueries(t *testing.T) {
+	const query = `{ risks(first: 1) { title } }`
+	sum := sha256.Sum256([]byte(query))
+	hash := hex.EncodeToString(sum[:])
+	ref := func(h string) Params {
+		var r Params
+		r.Extensions.PersistedQuery = &PersistedQueryRef{Version: 1, Sha256Hash: h}
+		return r
+	}
+	tenant := uuid.New()
+	store := &fakePersisted{}
+	svc := New(newFakeGraph()).WithPersistedQueries(store)
+	v := viewer(tenant, allPerms...)
+
+	if res := svc.Execute(context.Background(), v, ref(hash)); code(res) != "PERSISTED_QUERY_NOT_FOUND" {
+		t.Fatalf("unknown hash: want PERSISTED_QUERY_NOT_FOUND, got %v", res.Errors)
+	}
+
+	wrong := ref(strings.Repeat("0", 64))
+	wrong.Query = query
+	if res := svc.Execute(context.Background(), v, wrong); code(res) != "BAD_REQUEST" {
+		t.Fatalf("mismatched hash: want BAD_REQUEST, got %v", res.Errors)
+	}
+
+	register := ref(hash)
+	register.Query = query
+	if res := svc.Execute(context.Background(), v, register); len(res.Errors) > 0 {
+		t.Fatalf("register: %v", res.Errors)
+	}
+	res := svc.Execute(context.Background(), v, ref(hash))
+	if len(res.Errors) > 0 || len(decode(t, res)["risks"].([]any)) != 1 {
+		t.Fatalf("persisted query should run: %s %v", res.Data, res.Errors)
+	}
+
+	// Another tenant does not see it.
+	if res := svc.Execute(context.Background(), viewer(uuid.New(), allPerms...), ref(hash)); code(res) != "PERSISTED_QUERY_NOT_FOUND" {
+		t.Fatalf("other tenant: want PERSISTED_QUERY_NOT_FOUND, got %v", res.Errors)
+	}
+
+	if res := New(newFakeGraph()).Execute(context.Background(), v, ref(hash)); code(res) != "PERSISTED_QUERY_NOT_SUPPORTED" {
+		t.Fatalf("without a store: want PERSISTED_QUERY_NOT_SUPPORTED, got %v", res.Errors)
+	}
+}
+
+// Every field that returns records must name the permission its REST route
+// requires; a relation added without one would be readable by any member.
+func TestEveryObjectFieldRequiresAPermission(t *testing.T) {
+	svc := New(newFakeGraph())
+	for typ, fields := range svc.costs {
+		if strings.HasPrefix(typ, "__") {
+			continue
+		}
+		for name, fc := range fields {
+			if fc.object && svc.perms[typ+"."+name] == "" {
+				t.Errorf("%s.%s returns %s without @permission", typ, name, fc.typ)
+			}
+		}
+	}
+}
@@ -0,0 +1,897 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package group links organizations into a holding hierarchy and rolls the
+// subsidiaries' registers up into the parent: an aggregated heatmap, category
+// totals, a portfolio Monte Carlo across entities, a consolidated compliance
+// posture and a read-only drill-down.
+//
+// Every read starts from the caller's own organization and walks ACTIVE links
+// downwards; the resulting tenant set is the only thing the repository is ever
+// asked about. A subsidiary that has not accepted the link, or has revoked it,
+// is simply not in the set, so its data stays exactly as isolated as before.
+package group
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/application/risk"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/crq"
+)
+
+// AuditSink records link requests, acceptances and revocations.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// Presenters resolves the display currency of the organization the roll-up is
+// read from. risk.FinancialPresenterFactory satisfies it.
+type Presenters interface {
+	For(ctx context.Context, tenantID uuid.UUID) crq.Presenter
+}
+
+// Service is the group hierarchy use cases.
+type Service struct {
+	repo       domain.OrgHierarchyRepository
+	quantifier *crq.Quantifier
+	presenters Presenters
+	audit      AuditSink
+	now        func() time.Time
+}
+
+// NewService builds the service.
+func NewService(repo domain.OrgHierarchyRepository, quantifier *crq.Quantifier) *Service {
+	return &Service{repo: repo, quantifier: quantifier, now: time.Now}
+}
+
+// WithPresenters presents the Monte Carlo in the parent's display currency.
+func (s *Service) WithPresenters(p Presenters) *Service {
+	s.presenters = p
+	return s
+}
+
+// WithAudit enables audit recording.
+func (s *Service) WithAudit(a AuditSink) *Service {
+	s.audit = a
+	return s
+}
+
+// WithClock overrides the clock (tests).
+func (s *Service) WithClock(now func() time.Time) *Service {
+	s.now = now
+	return s
+}
+
+// =============================================================================
+// Links
+// =============================================================================
+
+// LinkRequest names the organization to take in as a subsidiary, by id or by
+// slug — the holding's administrator is not necessarily a member of it.
+type LinkRequest struct {
+	ChildID   *uuid.UUID `json:"child_id"`
+	ChildSlug string     `json:"child_slug"`
+}
+
+// PendingLink is what the requesting side learns of a link it asks for: an id
+// to follow or revoke it by. Whoever is behind the slug or id stays unnamed
+// until they accept, so a request cannot be used to look organizations up.
+type PendingLink struct {
+	ID     uuid.UUID                     `json:"id"`
+	Status domain.OrganizationLinkStatus `json:"status"`
+}
+
+// Links lists the links the organization is either side of, both names filled
+// — except a subsidiary's on a link it never accepted.
+func (s *Service) Links(ctx context.Context, orgID uuid.UUID) ([]domain.OrganizationLink, error) {
+	links, err := s.repo.LinksOf(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, 2*len(links))
+	for _, l := range links {
+		ids = append(ids, l.ParentID, l.ChildID)
+	}
+	orgs, err := s.repo.Organizations(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uuid.UUID]string, len(orgs))
+	for _, o := range orgs {
+		names[o.ID] = o.Name
+	}
+	for i := range links {
+		links[i].ParentName = names[links[i].ParentID]
+		if links[i].ChildID == orgID || links[i].AcceptedAt != nil {
+			links[i].ChildName = names[links[i].ChildID]
+		}
+	}
+	return links, nil
+}
+
+// RequestLink asks for child to become a subsidiary of parent. The link stays
+// pending — and grants nothing — until the child's administrator accepts it.
+func (s *Service) RequestLink(ctx context.Context, parentID uuid.UUID, actor *uuid.UUID, in LinkRequest) (*PendingLink, error) {
+	child, err := s.resolveChild(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	if child.ID == parentID {
+		return nil, domain.NewValidationError("an organization cannot be its own subsidiary")
+	}
+	if err := s.checkAttachable(ctx, parentID, child.ID); err != nil {
+		return nil, err
+	}
+
+	l := &domain.OrganizationLink{
+		ID:          uuid.New(),
+		ParentID:    parentID,
+		ChildID:     child.ID,
+		Status:      domain.OrgLinkPending,
+		RequestedBy: actor,
+	}
+	if err := s.repo.SaveLink(ctx, l); err != nil {
+		return nil, err
+	}
+	s.record(ctx, parentID, actor, "organization_link.requested", l, "Subsidiary link requested")
+	return &PendingLink{ID: l.ID, Status: l.Status}, nil
+}
+
+// AcceptLink is the child's consent. Only the child side can accept, and the
+// hierarchy is checked again: another link may have been accepted since this
+// one was asked for.
+func (s *Service) AcceptLink(ctx context.Context, childID, linkID uuid.UUID, actor *uuid.UUID) (*domain.OrganizationLink, error) {
+	l, err := s.repo.GetLink(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if l == nil || l.ChildID != childID {
+		return nil, domain.NewNotFoundError("organization link", linkID)
+	}
+	if l.Status != domain.OrgLinkPending {
+		return nil, domain.NewValidationError("only a pending link can be accepted")
+	}
+	below, err := s.descendants(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := below[l.ParentID]; ok {
+		return nil, domain.NewValidationError("accepting this link would make the hierarchy circular")
+	}
+
+	now := s.now().UTC()
+	l.Status, l.AcceptedBy, l.AcceptedAt = domain.OrgLinkActive, actor, &now
+	if err := s.repo.SaveLink(ctx, l); err != nil {
+		return nil, err
+	}
+	s.record(ctx, childID, actor, "organization_link.accepted", l, "Parent organization link accepted")
+	return l, nil
+}
+
+// RevokeLink ends a pending or active link. Either side may revoke: the parent
+// lets a subsidiary go, the subsidiary withdraws its consent.
+func (s *Service) RevokeLink(ctx context.Context, orgID, linkID uuid.UUID, actor *uuid.UUID) (*domain.OrganizationLink, error) {
+	l, err := s.repo.GetLink(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if l == nil || (l.ParentID != orgID && l.ChildID != orgID) {
+		return nil, domain.NewNotFoundError("organization link", linkID)
+	}
+	if !l.Live() {
+		return nil, domain.NewValidationError("the link is already revoked")
+	}
+	now := s.now().UTC()
+	l.Status, l.RevokedBy, l.RevokedAt = domain.OrgLinkRevoked, actor, &now
+	if err := s.repo.SaveLink(ctx, l); err != nil {
+		return nil, err
+	}
+	s.record(ctx, orgID, actor, "organization_link.revoked", l, "Organization link revoked")
+	return l, nil
+}
+
+func (s *Service) resolveChild(ctx context.Context, in LinkRequest) (*domain.Organization, error) {
+	var (
+		org *domain.Organization
+		ref interface{}
+		err error
+	)
+	switch {
+	case in.ChildID != nil && *in.ChildID != uuid.Nil:
+		ref = *in.ChildID
+		org, err = s.repo.GetOrganization(ctx, *in.ChildID)
+	case strings.TrimSpace(in.ChildSlug) != "":
+		ref = strings.TrimSpace(in.ChildSlug)
+		org, err = s.repo.GetOrganizationBySlug(ctx, strings.TrimSpace(in.ChildSlug))
+	default:
+		return nil, domain.NewValidationError("child_id or child_slug is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if org == nil || !org.IsActive {
+		return nil, domain.NewNotFoundError("organization", ref)
+	}
+	return org, nil
+}
+
+// checkAttachable keeps the hierarchy a tree: the child has no other parent,
+// and the parent is not already somewhere below the child.
+func (s *Service) checkAttachable(ctx context.Context, parentID, childID uuid.UUID) error {
+	existing, err := s.repo.LiveParentLink(ctx, childID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if existing.ParentID == parentID {
+			return domain.NewConflictError("organization link", "child_id")
+		}
+		return domain.NewValidationError("this organization already belongs to another group")
+	}
+	below, err := s.descendants(ctx, childID)
+	if err != nil {
+		return err
+	}
+	if _, ok := below[parentID]; ok {
+		return domain.NewValidationError("linking would make the hierarchy circular")
+	}
+	return nil
+}
+
+func (s *Service) record(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, action domain.AuditAction, l *domain.OrganizationLink, summary string) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    actor,
+		Action:     action,
+		EntityType: "organization_link",
+		EntityID:   l.ID.String(),
+		Summary:    summary,
+		After: domain.JSONMap{
+			"parent_id": l.ParentID.String(),
+			"child_id":  l.ChildID.String(),
+			"status":    string(l.Status),
+		},
+	})
+}
+
+// =============================================================================
+// Scope
+// =============================================================================
+
+// Entity is one organization of the group with its headline counts.
+type Entity struct {
+	OrgID         uuid.UUID  `json:"org_id"`
+	Name          string     `json:"name"`
+	Slug          string     `json:"slug"`
+	ParentID      *uuid.UUID `json:"parent_id,omitempty"`
+	Depth         int        `json:"depth"`
+	Risks         int        `json:"risks"`
+	CriticalRisks int        `json:"critical_risks"`
+	HighRisks     int        `json:"high_risks"`
+}
+
+// scope is the group as seen from its root: the root first, then every active
+// descendant depth first, siblings by name — the order the tree is drawn in.
+type scope struct {
+	entities []Entity
+	index    map[uuid.UUID]int
+}
+
+func (sc *scope) ids() []uuid.UUID {
+	out := make([]uuid.UUID, len(sc.entities))
+	for i, e := range sc.entities {
+		out[i] = e.OrgID
+	}
+	return out
+}
+
+func (sc *scope) has(id uuid.UUID) bool {
+	_, ok := sc.index[id]
+	return ok
+}
+
+// descendants walks active links below root, bounded by MaxGroupDepth. The
+// visited set makes a cycle that slipped into the table harmless.
+func (s *Service) descendants(ctx context.Context, root uuid.UUID) (map[uuid.UUID]domain.OrganizationLink, error) {
+	out := map[uuid.UUID]domain.OrganizationLink{}
+	frontier := []uuid.UUID{root}
+	for depth := 0; depth < domain.MaxGroupDepth && len(frontier) > 0; depth++ {
+		links, err := s.repo.ActiveChildren(ctx, frontier)
+		if err != nil {
+			return nil, err
+		}
+		frontier = frontier[:0]
+		for _, l := range links {
+			if _, seen := out[l.ChildID]; seen || l.ChildID == root {
+				continue
+			}
+			out[l.ChildID] = l
+			frontier = append(frontier, l.ChildID)
+		}
+	}
+	return out, nil
+}
+
+func (s *Service) resolve(ctx context.Context, root uuid.UUID) (*scope, error) {
+	below, err := s.descendants(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	ids := []uuid.UUID{root}
+	for id := range below {
+		ids = append(ids, id)
+	}
+	orgs, err := s.repo.Organizations(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]domain.Organization, len(orgs))
+	for _, o := range orgs {
+		byID[o.ID] = o
+	}
+
+	sc := &scope{index: map[uuid.UUID]int{}}
+	var add func(id uuid.UUID, parent *uuid.UUID, depth int)
+	add = func(id uuid.UUID, parent *uuid.UUID, depth int) {
+		o, ok := byID[id]
+		if !ok || sc.has(id) {
+			return
+		}
+		sc.index[id] = len(sc.entities)
+		sc.entities = append(sc.entities, Entity{OrgID: id, Name: o.Name, Slug: o.Slug, ParentID: parent, Depth: depth})
+		var children []domain.Organization
+		for childID, l := range below {
+			if l.ParentID == id {
+				if c, ok := byID[childID]; ok {
+					children = append(children, c)
+				}
+			}
+		}
+		sort.Slice(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+		for _, c := range children {
+			pid := id
+			add(c.ID, &pid, depth+1)
+		}
+	}
+	add(root, nil, 0)
+	if len(sc.entities) == 0 {
+		return nil, domain.NewNotFoundError("organization", root)
+	}
+	return sc, nil
+}
+
+// Entities returns the group tree, root first, with per-entity risk counts.
+func (s *Service) Entities(ctx context.Context, root uuid.UUID) ([]Entity, error) {
+	sc, err := s.resolve(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	risks, err := s.repo.GroupRisks(ctx, root, sc.ids())
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range risks {
+		i, ok := sc.index[r.TenantID]
+		if !ok {
+			continue
+		}
+		e := &sc.entities[i]
+		e.Risks++
+		switch criticality(&r) {
+		case "critical":
+			e.CriticalRisks++
+		case "high":
+			e.HighRisks++
+		}
+	}
+	return sc.entities, nil
+}
+
+// =============================================================================
+// Heatmap and categories
+// =============================================================================
+
+// EntityCount is one entity's share of a cell or a category.
+type EntityCount struct {
+	OrgID uuid.UUID `json:"org_id"`
+	Name  string    `json:"name"`
+	Count int       `json:"count"`
+}
+
+// HeatmapCell is one cell of the consolidated 5×5 matrix with the entities
+// that put risks into it, largest first.
+type HeatmapCell struct {
+	Probability int           `json:"probability"`
+	Impact      int           `json:"impact"`
+	Count       int           `json:"count"`
+	ByEntity    []EntityCount `json:"by_entity"`
+}
+
+// Heatmap is the group's consolidated matrix.
+type Heatmap struct {
+	Entities int           `json:"entities"`
+	Total    int           `json:"total"`
+	Cells    []HeatmapCell `json:"cells"`
+}
+
+// Heatmap bands every risk of the group onto the 5×5 matrix.
+func (s *Service) Heatmap(ctx context.Context, root uuid.UUID) (*Heatmap, error) {
+	sc, risks, err := s.scopedRisks(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	type key struct{ p, i int }
+	cells := map[key]map[uuid.UUID]int{}
+	for _, r := range risks {
+		p, i := domain.HeatmapBand(r.Probability, r.Impact)
+		k := key{p, i}
+		if cells[k] == nil {
+			cells[k] = map[uuid.UUID]int{}
+		}
+		cells[k][r.TenantID]++
+	}
+	out := &Heatmap{Entities: len(sc.entities), Total: len(risks), Cells: []HeatmapCell{}}
+	for k, byEntity := range cells {
+		cell := HeatmapCell{Probability: k.p, Impact: k.i, ByEntity: sc.breakdown(byEntity)}
+		for _, n := range byEntity {
+			cell.Count += n
+		}
+		out.Cells = append(out.Cells, cell)
+	}
+	sort.Slice(out.Cells, func(a, b int) bool {
+		if out.Cells[a].Probability != out.Cells[b].Probability {
+			return out.Cells[a].Probability > out.Cells[b].Probability
+		}
+		return out.Cells[a].Impact > out.Cells[b].Impact
+	})
+	return out, nil
+}
+
+// CategoryTotal is one category of the consolidated register. Categories are
+// matched across entities by slug, which survives renames; risks without a
+// category share the empty slug.
+type CategoryTotal struct {
+	Slug          string        `json:"slug"`
+	Name          string        `json:"name"`
+	Count         int           `json:"count"`
+	CriticalRisks int           `json:"critical_risks"`
+	HighRisks     int           `json:"high_risks"`
+	AvgScore      float64       `json:"avg_score"`
+	ByEntity      []EntityCount `json:"by_entity"`
+}
+
+// Categories totals the group's risks by category.
+func (s *Service) Categories(ctx context.Context, root uuid.UUID) ([]CategoryTotal, error) {
+	sc, risks, err := s.scopedRisks(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	slugs, names, err := s.categoryNames(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	type acc struct {
+		total    CategoryTotal
+		score    float64
+		byEntity map[uuid.UUID]int
+	}
+	bySlug := map[string]*acc{}
+	for _, r := range risks {
+		slug := ""
+		if r.CategoryID != nil {
+			slug = slugs[*r.CategoryID]
+		}
+		a := bySlug[slug]
+		if a == nil {
+			a = &acc{total: CategoryTotal{Slug: slug, Name: names[slug]}, byEntity: map[uuid.UUID]int{}}
+			bySlug[slug] = a
+		}
+		a.total.Count++
+		a.score += r.Score
+		a.byEntity[r.TenantID]++
+		switch criticality(&r) {
+		case "critical":
+			a.total.CriticalRisks++
+		case "high":
+			a.total.HighRisks++
+		}
+	}
+	out := make([]CategoryTotal, 0, len(bySlug))
+	for _, a := range bySlug {
+		a.total.AvgScore = round3(a.score / float64(a.total.Count))
+		a.total.ByEntity = sc.breakdown(a.byEntity)
+		out = append(out, a.total)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Slug < out[j].Slug
+	})
+	return out, nil
+}
+
+// categoryNames maps every entity's category ids to a shared slug, and each
+// slug to a display name — the root's own name wins, being the vocabulary the
+// group reads in.
+func (s *Service) categoryNames(ctx context.Context, sc *scope) (map[uuid.UUID]string, map[string]string, error) {
+	cats, err := s.repo.GroupCategories(ctx, sc.ids())
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.SliceStable(cats, func(i, j int) bool { return sc.index[cats[i].TenantID] < sc.index[cats[j].TenantID] })
+	slugs := make(map[uuid.UUID]string, len(cats))
+	names := map[string]string{}
+	for _, c := range cats {
+		slugs[c.ID] = c.Slug
+		if _, ok := names[c.Slug]; !ok {
+			names[c.Slug] = c.Name
+		}
+	}
+	return slugs, names, nil
+}
+
+// =============================================================================
+// Monte Carlo
+// =============================================================================
+
+// MaxIterations caps a group simulation: the group holds many registers and a
+// request should not be able to pin a core for minutes.
+const MaxIterations = 20_000
+
+// EntityLoss is one entity's own annual loss band.
+type EntityLoss struct {
+	OrgID uuid.UUID               `json:"org_id"`
+	Name  string                  `json:"name"`
+	Risks int                     `json:"risks"`
+	Loss  crq.DistributionAmounts `json:"loss"`
+}
+
+// PortfolioSimulation is the group's annual loss band and each entity's.
+//
+// The group band is ONE simulation over every risk of every entity, not the
+// sum of the entity bands: not every subsidiary has its worst year at once.
+// Diversification is how much the entities' P90s summed overstate the group's.
+type PortfolioSimulation struct {
+	Risks           int                     `json:"risks"`
+	Group           crq.DistributionAmounts `json:"group"`
+	Entities        []EntityLoss            `json:"entities"`
+	SumOfEntityP90  crq.Amount              `json:"sum_of_entity_p90"`
+	Diversification crq.Amount              `json:"diversification"`
+	ComputedAt      time.Time               `json:"computed_at"`
+}
+
+// MonteCarlo runs the portfolio simulation across the group. Iterations and
+// seed default to the single-register figures so a one-entity group reads the
+// same as that entity's own financial summary.
+func (s *Service) MonteCarlo(ctx context.Context, root uuid.UUID, iterations int, seed int64) (*PortfolioSimulation, error) {
+	if iterations <= 0 {
+		iterations = crq.DefaultIterations
+	}
+	if iterations > MaxIterations {
+		iterations = MaxIterations
+	}
+	if seed == 0 {
+		seed = crq.DefaultSeed
+	}
+	sc, risks, err := s.scopedRisks(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	pres := s.presenter(ctx, root)
+
+	all := make([]crq.SimulationInput, 0, len(risks))
+	perEntity := make([][]crq.SimulationInput, len(sc.entities))
+	for i := range risks {
+		r := &risks[i]
+		in := s.quantifier.SimulationInputFor(risk.FinancialInputsOf(r), string(r.Criticality))
+		all = append(all, in)
+		if idx, ok := sc.index[r.TenantID]; ok {
+			perEntity[idx] = append(perEntity[idx], in)
+		}
+	}
+
+	group := crq.SimulatePortfolio(all, iterations, seed)
+	out := &PortfolioSimulation{
+		Risks:      len(risks),
+		Group:      pres.Present(group),
+		Entities:   make([]EntityLoss, 0, len(sc.entities)),
+		ComputedAt: s.now().UTC(),
+	}
+	var sumP90 float64
+	for i, e := range sc.entities {
+		d := crq.SimulatePortfolio(perEntity[i], iterations, seed)
+		sumP90 += d.P90
+		out.Entities = append(out.Entities, EntityLoss{OrgID: e.OrgID, Name: e.Name, Risks: len(perEntity[i]), Loss: pres.Present(d)})
+	}
+	out.SumOfEntityP90 = pres.Amount(sumP90)
+	div := sumP90 - group.P90
+	if div < 0 {
+		div = 0
+	}
+	out.Diversification = pres.Amount(div)
+	return out, nil
+}
+
+func (s *Service) presenter(ctx context.Context, root uuid.UUID) crq.Presenter {
+	if s.presenters != nil {
+		return s.presenters.For(ctx, root)
+	}
+	return crq.NewPresenter(crq.CurrencyXAF, crq.DefaultRateTable(), s.quantifier.XAFPerUSD)
+}
+
+// =============================================================================
+// Compliance
+// =============================================================================
+
+// EntityPosture is one entity's progress on a framework.
+type EntityPosture struct {
+	OrgID           uuid.UUID `json:"org_id"`
+	Name            string    `json:"name"`
+	Applicable      int       `json:"applicable"`
+	Implemented     int       `json:"implemented"`
+	PercentComplete float64   `json:"percent_complete"`
+}
+
+// FrameworkPosture is the consolidated posture on one framework. Entities'
+// frameworks are matched by the catalog they were imported from, falling back
+// to name and version for one built by hand. PercentComplete weighs every
+// applicable control of the group equally; Weakest is the entity furthest
+// behind, the figure a group CISO acts on.
+type FrameworkPosture struct {
+	Key             string          `json:"key"`
+	Name            string          `json:"name"`
+	Version         string          `json:"version"`
+	Total           int             `json:"total"`
+	Applicable      int             `json:"applicable"`
+	Implemented     int             `json:"implemented"`
+	InProgress      int             `json:"in_progress"`
+	PercentComplete float64         `json:"percent_complete"`
+	Weakest         *EntityPosture  `json:"weakest,omitempty"`
+	ByEntity        []EntityPosture `json:"by_entity"`
+}
+
+// Compliance consolidates the group's frameworks. Progress is counted the way
+// compliance.GetComplianceProgressUseCase counts it: implemented over
+// applicable, not-applicable controls left out.
+func (s *Service) Compliance(ctx context.Context, root uuid.UUID) ([]FrameworkPosture, error) {
+	sc, err := s.resolve(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.GroupControls(ctx, sc.ids())
+	if err != nil {
+		return nil, err
+	}
+
+	type acc struct {
+		posture  FrameworkPosture
+		byEntity map[uuid.UUID]*EntityPosture
+	}
+	byKey := map[string]*acc{}
+	for _, row := range rows {
+		idx, ok := sc.index[row.TenantID]
+		if !ok {
+			continue
+		}
+		key := row.CatalogKey
+		if key == "" {
+			key = strings.ToLower(strings.TrimSpace(row.FrameworkName)) + "@" + strings.TrimSpace(row.FrameworkVersion)
+		}
+		a := byKey[key]
+		if a == nil {
+			a = &acc{
+				posture:  FrameworkPosture{Key: key, Name: row.FrameworkName, Version: row.FrameworkVersion},
+				byEntity: map[uuid.UUID]*EntityPosture{},
+			}
+			byKey[key] = a
+		}
+		if idx == 0 {
+			a.posture.Name, a.posture.Version = row.FrameworkName, row.FrameworkVersion
+		}
+		ep := a.byEntity[row.TenantID]
+		if ep == nil {
+			ep = &EntityPosture{OrgID: row.TenantID, Name: sc.entities[idx].Name}
+			a.byEntity[row.TenantID] = ep
+		}
+		a.posture.Total++
+		switch row.Status {
+		case domain.ControlStatusNotApplicable:
+			continue
+		case domain.ControlStatusImplemented:
+			a.posture.Implemented++
+			ep.Implemented++
+		case domain.ControlStatusInProgress:
+			a.posture.InProgress++
+		}
+		a.posture.Applicable++
+		ep.Applicable++
+	}
+
+	out := make([]FrameworkPosture, 0, len(byKey))
+	for _, a := range byKey {
+		p := a.posture
+		p.PercentComplete = percent(p.Implemented, p.Applicable)
+		p.ByEntity = make([]EntityPosture, 0, len(a.byEntity))
+		for _, ep := range a.byEntity {
+			ep.PercentComplete = percent(ep.Implemented, ep.Applicable)
+			p.ByEntity = append(p.ByEntity, *ep)
+		}
+		sort.Slice(p.ByEntity, func(i, j int) bool {
+			if p.ByEntity[i].PercentComplete != p.ByEntity[j].PercentComplete {
+				return p.ByEntity[i].PercentComplete < p.ByEntity[j].PercentComplete
+			}
+			return p.ByEntity[i].Name < p.ByEntity[j].Name
+		})
+		for i := range p.ByEntity {
+			if p.ByEntity[i].Applicable > 0 {
+				w := p.ByEntity[i]
+				p.Weakest = &w
+				break
+			}
+		}
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// =============================================================================
+// Drill-down
+// =============================================================================
+
+// RiskFilter narrows the drill-down. Zero fields do not filter; Category "-"
+// selects uncategorised risks.
+type RiskFilter struct {
+	OrgID       *uuid.UUID
+	Probability int
+	Impact      int
+	Category    string
+	Limit       int
+}
+
+// GroupRisk is one read-only row of the drill-down.
+type GroupRisk struct {
+	ID           uuid.UUID `json:"id"`
+	OrgID        uuid.UUID `json:"org_id"`
+	OrgName      string    `json:"org_name"`
+	Title        string    `json:"title"`
+	Category     string    `json:"category,omitempty"`
+	Probability  float64   `json:"probability"`
+	Impact       float64   `json:"impact"`
+	Score        float64   `json:"score"`
+	Criticality  string    `json:"criticality"`
+	Status       string    `json:"status"`
+	BusinessUnit string    `json:"business_unit,omitempty"`
+}
+
+// defaultDrillLimit / maxDrillLimit bound the drill-down page.
+const (
+	defaultDrillLimit = 100
+	maxDrillLimit     = 500
+)
+
+// Risks is the drill-down behind a heatmap cell, a category or an entity. An
+// entity outside the group reads as not found, exactly like a risk of another
+// tenant would.
+func (s *Service) Risks(ctx context.Context, root uuid.UUID, f RiskFilter) ([]GroupRisk, error) {
+	sc, err := s.resolve(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	ids := sc.ids()
+	if f.OrgID != nil {
+		if !sc.has(*f.OrgID) {
+			return nil, domain.NewNotFoundError("organization", *f.OrgID)
+		}
+		ids = []uuid.UUID{*f.OrgID}
+	}
+	risks, err := s.repo.GroupRisks(ctx, root, ids)
+	if err != nil {
+		return nil, err
+	}
+	slugs, names, err := s.categoryNames(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = defaultDrillLimit
+	}
+	if limit > maxDrillLimit {
+		limit = maxDrillLimit
+	}
+	out := []GroupRisk{}
+	for i := range risks {
+		r := &risks[i]
+		p, im := domain.HeatmapBand(r.Probability, r.Impact)
+		if (f.Probability > 0 && p != f.Probability) || (f.Impact > 0 && im != f.Impact) {
+			continue
+		}
+		slug := ""
+		if r.CategoryID != nil {
+			slug = slugs[*r.CategoryID]
+		}
+		if f.Category != "" && !(f.Category == "-" && slug == "") && f.Category != slug {
+			continue
+		}
+		title := r.Title
+		if strings.TrimSpace(title) == "" {
+			title = r.Name
+		}
+		out = append(out, GroupRisk{
+			ID: r.ID, OrgID: r.TenantID, OrgName: sc.entities[sc.index[r.TenantID]].Name,
+			Title: title, Category: names[slug],
+			Probability: r.Probability, Impact: r.Impact, Score: r.Score,
+			Criticality: string(r.Criticality), Status: string(r.Status), BusinessUnit: r.BusinessUnit,
+		})
+		if len(out) == limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+func (s *Service) scopedRisks(ctx context.Context, root uuid.UUID) (*scope, []domain.Risk, error) {
+	sc, err := s.resolve(ctx, root)
+	if err != nil {
+		return nil, nil, err
+	}
+	risks, err := s.repo.GroupRisks(ctx, root, sc.ids())
+	if err != nil {
+		return nil, nil, err
+	}
+	return sc, risks, nil
+}
+
+// breakdown names per-entity counts, largest first.
+func (sc *scope) breakdown(counts map[uuid.UUID]int) []EntityCount {
+	out := make([]EntityCount, 0, len(counts))
+	for id, n := range counts {
+		i, ok := sc.index[id]
+		if !ok {
+			continue
+		}
+		out = append(out, EntityCount{OrgID: id, Name: sc.entities[i].Name, Count: n})
+	}
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].Count != out[b].Count {
+			return out[a].Count > out[b].Count
+		}
+		return out[a].Name < out[b].Name
+	})
+	return out
+}
+
+// criticality folds the legacy upper-case levels onto the current ones.
+func criticality(r *domain.Risk) string {
+	return strings.ToLower(strings.TrimSpace(string(r.Criticality)))
+}
+
+func percent(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return round1(float64(n) / float64(d) * 100)
+}
+
+func round1(v float64) float64 { return math.Round(v*10) / 10 }
+func round3(v float64) float64 { return math.Round(v*1000) / 1000 }
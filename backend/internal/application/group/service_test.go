@@ -0,0 +1,288 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package group
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/crq"
+)
+
+// memHierarchy is an in-memory OrgHierarchyRepository. Its register reads
+// honour the tenant set exactly like the Gorm one.
+type memHierarchy struct {
+	orgs     map[uuid.UUID]domain.Organization
+	links    map[uuid.UUID]domain.OrganizationLink
+	risks    []domain.Risk
+	cats     []domain.RiskCategory
+	controls []domain.GroupControlRow
+}
+
+func newMemHierarchy() *memHierarchy {
+	return &memHierarchy{orgs: map[uuid.UUID]domain.Organization{}, links: map[uuid.UUID]domain.OrganizationLink{}}
+}
+
+func (m *memHierarchy) org(name string) uuid.UUID {
+	id := uuid.New()
+	m.orgs[id] = domain.Organization{ID: id, Name: name, Slug: name, IsActive: true}
+	return id
+}
+
+func in(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, x := range ids {
+		if x == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *memHierarchy) GetOrganization(_ context.Context, id uuid.UUID) (*domain.Organization, error) {
+	if o, ok := m.orgs[id]; ok {
+		return &o, nil
+	}
+	return nil, nil
+}
+
+func (m *memHierarchy) GetOrganizationBySlug(_ context.Context, slug string) (*domain.Organization, error) {
+	for _, o := range m.orgs {
+		if o.Slug == slug {
+			return &o, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memHierarchy) Organizations(_ context.Context, ids []uuid.UUID) ([]domain.Organization, error) {
+	var out []domain.Organization
+	for _, o := range m.orgs {
+		if in(ids, o.ID) {
+			out = append(out, o)
+		}
+	}
+	return out, nil
+}
+
+func (m *memHierarchy) GetLink(_ context.Context, id uuid.UUID) (*domain.OrganizationLink, error) {
+	if l, ok := m.links[id]; ok {
+		return &l, nil
+	}
+	return nil, nil
+}
+
+func (m *memHierarchy) SaveLink(_ context.Context, l *domain.OrganizationLink) error {
+	m.links[l.ID] = *l
+	return nil
+}
+
+func (m *memHierarchy) LinksOf(_ context.Context, orgID uuid.UUID) ([]domain.OrganizationLink, error) {
+	var out []domain.OrganizationLink
+	for _, l := range m.links {
+		if l.ParentID == orgID || l.ChildID == orgID {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func (m *memHierarchy) LiveParentLink(_ context.Context, orgID uuid.UUID) (*domain.OrganizationLink, error) {
+	for _, l := range m.links {
+		if l.ChildID == orgID && l.Live() {
+			return &l, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memHierarchy) ActiveChildren(_ context.Context, parentIDs []uuid.UUID) ([]domain.OrganizationLink, error) {
+	var out []domain.OrganizationLink
+	for _, l := range m.links {
+		if l.Status == domain.OrgLinkActive && in(parentIDs, l.ParentID) {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func (m *memHierarchy) GroupRisks(_ context.Context, _ uuid.UUID, ids []uuid.UUID) ([]domain.Risk, error) {
+	var out []domain.Risk
+	for _, r := range m.risks {
+		if in(ids, r.TenantID) {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (m *memHierarchy) GroupCategories(_ context.Context, ids []uuid.UUID) ([]domain.RiskCategory, error) {
+	var out []domain.RiskCategory
+	for _, c := range m.cats {
+		if in(ids, c.TenantID) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (m *memHierarchy) GroupControls(_ context.Context, ids []uuid.UUID) ([]domain.GroupControlRow, error) {
+	var out []domain.GroupControlRow
+	for _, c := range m.controls {
+		if in(ids, c.TenantID) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func newTestService(m *memHierarchy) *Service {
+	return NewService(m, crq.NewQuantifier(crq.DefaultXAFPerUSD, crq.DefaultReference()))
+}
+
+func TestGroup_LinkNeedsConsentAndStaysATree(t *testing.T) {
+	ctx := context.Background()
+	m := newMemHierarchy()
+	svc := newTestService(m)
+	holding, sub, subsub, rival := m.org("holding"), m.org("sub"), m.org("subsub"), m.org("rival")
+	m.risks = []domain.Risk{{ID: uuid.New(), TenantID: sub, Name: "Fraude", Probability: 0.5, Impact: 6}}
+
+	l, err := svc.RequestLink(ctx, holding, nil, LinkRequest{ChildSlug: "sub"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrgLinkPending, l.Status)
+	links, err := svc.Links(ctx, holding)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.Empty(t, links[0].ChildName, "the subsidiary is not named before it accepts")
+	links, err = svc.Links(ctx, sub)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.NotEmpty(t, links[0].ParentName, "the subsidiary sees who is asking")
+
+	ents, err := svc.Entities(ctx, holding)
+	require.NoError(t, err)
+	assert.Len(t, ents, 1, "a pending link grants nothing")
+
+	_, err = svc.AcceptLink(ctx, holding, l.ID, nil)
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "only the child side can accept")
+	_, err = svc.AcceptLink(ctx, sub, l.ID, nil)
+	require.NoError(t, err)
+	links, err = svc.Links(ctx, holding)
+	require.NoError(t, err)
+	assert.NotEmpty(t, links[0].ChildName, "named once accepted")
+
+	_, err = svc.RequestLink(ctx, rival, nil, LinkRequest{ChildSlug: "sub"})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "one parent at a time")
+	_, err = svc.RequestLink(ctx, holding, nil, LinkRequest{ChildSlug: "sub"})
+	assert.True(t, errors.Is(err, domain.ErrConflict))
+	_, err = svc.RequestLink(ctx, holding, nil, LinkRequest{ChildSlug: "holding"})
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+
+	deep, err := svc.RequestLink(ctx, sub, nil, LinkRequest{ChildSlug: "subsub"})
+	require.NoError(t, err)
+	_, err = svc.AcceptLink(ctx, subsub, deep.ID, nil)
+	require.NoError(t, err)
+	_, err = svc.RequestLink(ctx, subsub, nil, LinkRequest{ChildSlug: "holding"})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "no cycles")
+
+	ents, err = svc.Entities(ctx, holding)
+	require.NoError(t, err)
+	require.Len(t, ents, 3)
+	assert.Equal(t, holding, ents[0].OrgID)
+	assert.Equal(t, 2, ents[2].Depth)
+	assert.Equal(t, 1, ents[1].Risks)
+
+	fromSub, err := svc.Entities(ctx, sub)
+	require.NoError(t, err)
+	assert.Len(t, fromSub, 2, "a subsidiary sees below itself, never its parent")
+
+	_, err = svc.Risks(ctx, sub, RiskFilter{OrgID: &holding})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "drilling into the parent is not allowed")
+
+	_, err = svc.RevokeLink(ctx, sub, l.ID, nil)
+	require.NoError(t, err, "the subsidiary withdraws its consent")
+	ents, err = svc.Entities(ctx, holding)
+	require.NoError(t, err)
+	assert.Len(t, ents, 1)
+	_, err = svc.Risks(ctx, holding, RiskFilter{OrgID: &sub})
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+}
+
+func TestGroup_RollUp(t *testing.T) {
+	ctx := context.Background()
+	m := newMemHierarchy()
+	svc := newTestService(m)
+	holding, a, b := m.org("holding"), m.org("a"), m.org("b")
+	for _, child := range []uuid.UUID{a, b} {
+		id := uuid.New()
+		m.links[id] = domain.OrganizationLink{ID: id, ParentID: holding, ChildID: child, Status: domain.OrgLinkActive}
+	}
+
+	catA, catB := uuid.New(), uuid.New()
+	m.cats = []domain.RiskCategory{
+		{ID: catA, TenantID: a, Slug: "fraud", Name: "Fraude (A)"},
+		{ID: catB, TenantID: holding, Slug: "fraud", Name: "Fraude"},
+	}
+	sle, aro := 1_000_000.0, 1.0
+	m.risks = []domain.Risk{
+		{ID: uuid.New(), TenantID: a, Name: "a1", Probability: 0.9, Impact: 9, Score: 8, Criticality: "critical", CategoryID: &catA, SLEXAF: &sle, ARO: &aro},
+		{ID: uuid.New(), TenantID: b, Name: "b1", Probability: 0.9, Impact: 9, Score: 7, Criticality: "HIGH", SLEXAF: &sle, ARO: &aro},
+		{ID: uuid.New(), TenantID: holding, Name: "h1", Probability: 0.1, Impact: 1, Score: 1, CategoryID: &catB},
+		{ID: uuid.New(), TenantID: uuid.New(), Name: "foreign", Probability: 0.9, Impact: 9},
+	}
+	m.controls = []domain.GroupControlRow{
+		{TenantID: a, FrameworkName: "ISO 27001", CatalogKey: "iso27001", Status: domain.ControlStatusImplemented},
+		{TenantID: a, FrameworkName: "ISO 27001", CatalogKey: "iso27001", Status: domain.ControlStatusImplemented},
+		{TenantID: b, FrameworkName: "ISO/IEC 27001", CatalogKey: "iso27001", Status: domain.ControlStatusNotImplemented},
+		{TenantID: b, FrameworkName: "ISO/IEC 27001", CatalogKey: "iso27001", Status: domain.ControlStatusNotApplicable},
+	}
+
+	hm, err := svc.Heatmap(ctx, holding)
+	require.NoError(t, err)
+	assert.Equal(t, 3, hm.Total, "the foreign risk is not part of the group")
+	assert.Equal(t, 5, hm.Cells[0].Probability)
+	assert.Equal(t, 5, hm.Cells[0].Impact)
+	assert.Equal(t, 2, hm.Cells[0].Count)
+	assert.Len(t, hm.Cells[0].ByEntity, 2)
+
+	cats, err := svc.Categories(ctx, holding)
+	require.NoError(t, err)
+	require.Len(t, cats, 2)
+	assert.Equal(t, "fraud", cats[0].Slug, "matched across entities by slug")
+	assert.Equal(t, "Fraude", cats[0].Name, "the root's vocabulary names it")
+	assert.Equal(t, 2, cats[0].Count)
+	assert.Equal(t, 1, cats[0].CriticalRisks)
+
+	cells, err := svc.Risks(ctx, holding, RiskFilter{Probability: 5, Impact: 5})
+	require.NoError(t, err)
+	assert.Len(t, cells, 2)
+	uncategorised, err := svc.Risks(ctx, holding, RiskFilter{Category: "-"})
+	require.NoError(t, err)
+	require.Len(t, uncategorised, 1)
+	assert.Equal(t, "b1", uncategorised[0].Title)
+
+	posture, err := svc.Compliance(ctx, holding)
+	require.NoError(t, err)
+	require.Len(t, posture, 1, "frameworks from the same catalog consolidate despite names")
+	assert.Equal(t, 3, posture[0].Applicable)
+	assert.InDelta(t, 66.7, posture[0].PercentComplete, 0.01)
+	require.NotNil(t, posture[0].Weakest)
+	assert.Equal(t, b, posture[0].Weakest.OrgID)
+
+	sim, err := svc.MonteCarlo(ctx, holding, 2000, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 3, sim.Risks)
+	require.Len(t, sim.Entities, 3)
+	assert.GreaterOrEqual(t, sim.SumOfEntityP90.XAF, sim.Group.P90.XAF, "entities' P90s summed never undercut the group's")
+	again, err := svc.MonteCarlo(ctx, holding, 2000, 7)
+	require.NoError(t, err)
+	assert.Equal(t, sim.Group.P50, again.Group.P50, "deterministic for a seed")
+}
@@ -0,0 +1,1006 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package incident
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// ControlCatalog is the slice of the compliance store used to find the control a
+// regime discharges in the tenant's imported framework. Satisfied by
+// GormComplianceRepository.
+type ControlCatalog interface {
+	ListFrameworks(ctx context.Context, tenantID uuid.UUID) ([]domain.ComplianceFramework, error)
+	ListControlsByFramework(ctx context.Context, tenantID, frameworkID uuid.UUID) ([]domain.ComplianceControl, error)
+}
+
+// EvidenceLinker files a submission receipt against a control. Satisfied by the
+// evidence Service, so the link lands in the same coverage the auditor reads.
+type EvidenceLinker interface {
+	Link(ctx context.Context, tenantID, evidenceID uuid.UUID, controlIDs []uuid.UUID, note string, actor uuid.UUID) (*domain.Evidence, error)
+}
+
+// TimelineWriter appends to the incident's own timeline. Satisfied by the legacy
+// IncidentService.
+type TimelineWriter interface {
+	AddTimelineEntry(incidentID uint, eventType, message, metadata, createdBy string) error
+}
+
+// AuditSink records an event on the tenant's hash-chained audit trail.
+// Satisfied by governance.AuditRecorder.
+type AuditSink interface {
+	Record(ctx context.Context, ev domain.AuditEvent)
+}
+
+// DeadlineAlert is one reminder about one clock.
+type DeadlineAlert struct {
+	Clock     *domain.RegulatoryClock
+	Incident  *domain.Incident
+	Profile   *domain.RegulatoryProfile
+	Remaining time.Duration // negative once overdue
+}
+
+// DeadlineAlerter delivers a reminder through the automation channels.
+type DeadlineAlerter interface {
+	AlertDeadline(ctx context.Context, a DeadlineAlert) error
+}
+
+// escalationMarks are the fractions of a clock's window at which its owners are
+// reminded. Past the deadline the reminder repeats hourly until someone records
+// the submission — an overdue notification is still owed, and late is better
+// than never in every one of the three texts.
+var escalationMarks = []float64{0.5, 0.75, 0.9}
+
+const overdueReminderEvery = time.Hour
+
+// RegulatoryService turns an incident's classification into statutory clocks.
+type RegulatoryService struct {
+	repo        domain.RegulatoryRepository
+	incidents   IncidentReader
+	catalog     ControlCatalog
+	evidence    EvidenceLinker
+	alerter     DeadlineAlerter
+	timeline    TimelineWriter
+	audit       AuditSink
+	postMortems domain.IncidentPostMortemRepository
+	now         func() time.Time
+}
+
+// NewRegulatoryService builds the service. Everything attached with a With*
+// method is optional: without a catalog clocks carry no control, without an
+// alerter the sweep only advances its schedule.
+func NewRegulatoryService(repo domain.RegulatoryRepository, incidents IncidentReader) *RegulatoryService {
+	return &RegulatoryService{repo: repo, incidents: incidents, now: time.Now}
+}
+
+func (s *RegulatoryService) WithCatalog(c ControlCatalog) *RegulatoryService {
+	s.catalog = c
+	return s
+}
+func (s *RegulatoryService) WithEvidenceLinker(l EvidenceLinker) *RegulatoryService {
+	s.evidence = l
+	return s
+}
+func (s *RegulatoryService) WithAlerter(a DeadlineAlerter) *RegulatoryService {
+	s.alerter = a
+	return s
+}
+func (s *RegulatoryService) WithTimeline(t TimelineWriter) *RegulatoryService {
+	s.timeline = t
+	return s
+}
+func (s *RegulatoryService) WithAudit(a AuditSink) *RegulatoryService {
+	s.audit = a
+	return s
+}
+
+// WithPostMortems lets the final reports pre-fill the root cause from the
+// incident's review.
+func (s *RegulatoryService) WithPostMortems(r domain.IncidentPostMortemRepository) *RegulatoryService {
+	s.postMortems = r
+	return s
+}
+
+// WithClock overrides time (tests).
+func (s *RegulatoryService) WithClock(f func() time.Time) *RegulatoryService {
+	s.now = f
+	return s
+}
+
+// =============================================================================
+// Profiles
+// =============================================================================
+
+// ProfileInput is the editable part of a jurisdiction profile.
+type ProfileInput struct {
+	Regime           string
+	Jurisdiction     string
+	Authority        string
+	AuthorityContact string
+	ContactPoint     string
+	Criteria         domain.RegulatoryCriteria
+	EscalateChannels []string
+	EscalateToRole   string
+	Enabled          bool
+}
+
+// Profiles lists the tenant's jurisdiction profiles, seeding one EU-wide profile
+// per regime the first time. The seeds carry each regime's textbook trigger, so
+// a tenant that never opens the settings page still gets clocks on a personal-
+// data breach.
+func (s *RegulatoryService) Profiles(ctx context.Context, tenantID uuid.UUID) ([]domain.RegulatoryProfile, error) {
+	rows, err := s.repo.ListProfiles(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > 0 {
+		return rows, nil
+	}
+	now := s.now().UTC()
+	for _, spec := range domain.RegulatoryRegimes() {
+		p := domain.RegulatoryProfile{
+			ID:           uuid.New(),
+			TenantID:     tenantID,
+			Regime:       spec.Regime,
+			Jurisdiction: domain.RegulatoryJurisdictionEU,
+			Authority:    defaultAuthority(spec.Regime),
+			Criteria:     spec.DefaultCriteria,
+			Enabled:      true,
+			BuiltIn:      true,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		if err := s.repo.SaveProfile(ctx, &p); err != nil {
+			return nil, err
+		}
+		rows = append(rows, p)
+	}
+	return rows, nil
+}
+
+func defaultAuthority(r domain.RegulatoryRegime) string {
+	switch r {
+	case domain.RegimeGDPR:
+		return "Lead supervisory authority"
+	case domain.RegimeNIS2:
+		return "National CSIRT / competent authority"
+	case domain.RegimeDORA:
+		return "Competent financial supervisor"
+	}
+	return ""
+}
+
+// SaveProfile creates (id nil) or updates a profile.
+func (s *RegulatoryService) SaveProfile(ctx context.Context, tenantID uuid.UUID, id *uuid.UUID, in ProfileInput) (*domain.RegulatoryProfile, error) {
+	regime, err := domain.ParseRegulatoryRegime(in.Regime)
+	if err != nil {
+		return nil, err
+	}
+	jurisdiction := strings.ToUpper(strings.TrimSpace(in.Jurisdiction))
+	if jurisdiction == "" {
+		jurisdiction = domain.RegulatoryJurisdictionEU
+	}
+	if jurisdiction != domain.RegulatoryJurisdictionEU && !isCountryCode(jurisdiction) {
+		return nil, domain.NewValidationError("jurisdiction must be a two-letter country code or EU")
+	}
+	if in.Criteria.IsEmpty() {
+		return nil, domain.NewValidationError("at least one classification criterion is required; a profile that matches every incident would bury the real deadlines")
+	}
+	if in.Criteria.MinSeverity != "" && incidentSeverityOK(in.Criteria.MinSeverity) == "" {
+		return nil, domain.NewValidationError("min_severity must be low, medium, high or critical")
+	}
+	channels := make(domain.StringList, 0, len(in.EscalateChannels))
+	for _, ch := range in.EscalateChannels {
+		ch = strings.ToLower(strings.TrimSpace(ch))
+		if !domain.IsAutomationChannel(ch) {
+			return nil, domain.NewValidationError("unknown escalation channel: " + ch)
+		}
+		channels = append(channels, ch)
+	}
+
+	existing, err := s.Profiles(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	var p *domain.RegulatoryProfile
+	for i := range existing {
+		e := existing[i]
+		if id != nil && e.ID == *id {
+			p = &e
+			continue
+		}
+		if e.Regime == regime && e.Jurisdiction == jurisdiction {
+			return nil, &domain.AppError{Err: domain.ErrConflict, Code: http.StatusConflict,
+				Message: fmt.Sprintf("a %s profile for %s already exists", strings.ToUpper(string(regime)), jurisdiction)}
+		}
+	}
+	now := s.now().UTC()
+	if id != nil && p == nil {
+		return nil, domain.NewNotFoundError("regulatory profile", *id)
+	}
+	if p == nil {
+		p = &domain.RegulatoryProfile{ID: uuid.New(), TenantID: tenantID, CreatedAt: now}
+	}
+	p.Regime = regime
+	p.Jurisdiction = jurisdiction
+	p.Authority = strings.TrimSpace(in.Authority)
+	p.AuthorityContact = strings.TrimSpace(in.AuthorityContact)
+	p.ContactPoint = strings.TrimSpace(in.ContactPoint)
+	p.Criteria = in.Criteria
+	p.EscalateChannels = channels
+	p.EscalateToRole = strings.TrimSpace(in.EscalateToRole)
+	p.Enabled = in.Enabled
+	p.UpdatedAt = now
+	if err := s.repo.SaveProfile(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// DeleteProfile removes a tenant-defined profile. The built-in EU profiles can
+// only be disabled: deleting one would reseed it on the next read.
+func (s *RegulatoryService) DeleteProfile(ctx context.Context, tenantID, id uuid.UUID) error {
+	p, err := s.repo.GetProfile(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return domain.NewNotFoundError("regulatory profile", id)
+	}
+	if p.BuiltIn {
+		return domain.NewValidationError("built-in profiles cannot be deleted; disable it instead")
+	}
+	return s.repo.DeleteProfile(ctx, tenantID, id)
+}
+
+func isCountryCode(s string) bool {
+	if len(s) != 2 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func incidentSeverityOK(s string) string {
+	switch v := strings.ToLower(strings.TrimSpace(s)); v {
+	case "low", "medium", "high", "critical":
+		return v
+	}
+	return ""
+}
+
+// =============================================================================
+// Classification
+// =============================================================================
+
+// ClassifyInput is the classification decision.
+type ClassifyInput struct {
+	PersonalData     bool
+	Significant      bool
+	Major            bool
+	AffectedSubjects int
+	DataCategories   []string
+	Jurisdictions    []string
+	CrossBorder      bool
+	Malicious        bool
+	Consequences     string
+	Notes            string
+	// AwareAt overrides when the organisation became aware. Nil keeps the
+	// recorded value (the incident's creation on first classification).
+	AwareAt *time.Time
+}
+
+// ProfileEvaluation explains, per applicable profile, why a regime did or did
+// not start.
+type ProfileEvaluation struct {
+	ProfileID    uuid.UUID               `json:"profile_id"`
+	Regime       domain.RegulatoryRegime `json:"regime"`
+	Jurisdiction string                  `json:"jurisdiction"`
+	Applies      bool                    `json:"applies"`
+	Reason       string                  `json:"reason,omitempty"`
+}
+
+// ClockView is a clock with the numbers the UI counts down from.
+type ClockView struct {
+	domain.RegulatoryClock
+	Overdue          bool  `json:"overdue"`
+	SubmittedLate    bool  `json:"submitted_late"`
+	RemainingSeconds int64 `json:"remaining_seconds"`
+}
+
+// RegulatoryStatus is an incident's regulatory picture.
+type RegulatoryStatus struct {
+	Assessment  *domain.IncidentRegulatoryAssessment `json:"assessment"`
+	Clocks      []ClockView                          `json:"clocks"`
+	Evaluations []ProfileEvaluation                  `json:"evaluations,omitempty"`
+}
+
+// Status returns the incident's assessment and clocks. Assessment is nil until
+// someone classifies the incident.
+func (s *RegulatoryService) Status(ctx context.Context, tenantID uuid.UUID, incidentID uint) (*RegulatoryStatus, error) {
+	if _, err := s.incident(tenantID, incidentID); err != nil {
+		return nil, err
+	}
+	a, err := s.repo.GetAssessment(ctx, tenantID, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	clocks, err := s.repo.ListClocks(ctx, tenantID, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	return &RegulatoryStatus{Assessment: a, Clocks: s.views(clocks)}, nil
+}
+
+// Classify records the classification decision and starts, re-times or
+// withdraws the clocks it implies.
+//
+// Re-classification is normal — the first hours of an incident are guesswork —
+// so this is idempotent over the decision: running it twice with the same input
+// changes nothing, and narrowing it withdraws only clocks that are still
+// pending. A submitted notification stays submitted; you cannot un-tell an
+// authority.
+func (s *RegulatoryService) Classify(ctx context.Context, tenantID uuid.UUID, incidentID uint, in ClassifyInput, actor *uuid.UUID) (*RegulatoryStatus, error) {
+	inc, err := s.incident(tenantID, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	if in.AffectedSubjects < 0 {
+		return nil, domain.NewValidationError("affected_subjects cannot be negative")
+	}
+	if in.AwareAt != nil && in.AwareAt.After(now) {
+		return nil, domain.NewValidationError("aware_at cannot be in the future")
+	}
+	jurisdictions := make(domain.StringList, 0, len(in.Jurisdictions))
+	for _, j := range in.Jurisdictions {
+		j = strings.ToUpper(strings.TrimSpace(j))
+		if j == "" {
+			continue
+		}
+		if j != domain.RegulatoryJurisdictionEU && !isCountryCode(j) {
+			return nil, domain.NewValidationError("jurisdictions must be two-letter country codes: " + j)
+		}
+		jurisdictions = append(jurisdictions, j)
+	}
+
+	a, err := s.repo.GetAssessment(ctx, tenantID, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		a = &domain.IncidentRegulatoryAssessment{
+			ID: uuid.New(), TenantID: tenantID, IncidentID: incidentID,
+			AwareAt: inc.CreatedAt.UTC(), CreatedAt: now,
+		}
+		if a.AwareAt.IsZero() {
+			a.AwareAt = now
+		}
+	}
+	if in.AwareAt != nil {
+		a.AwareAt = in.AwareAt.UTC()
+	}
+	a.PersonalData = in.PersonalData
+	a.Significant = in.Significant
+	a.Major = in.Major
+	a.AffectedSubjects = in.AffectedSubjects
+	a.DataCategories = domain.StringList(in.DataCategories)
+	a.Jurisdictions = jurisdictions
+	a.CrossBorder = in.CrossBorder
+	a.Malicious = in.Malicious
+	a.Consequences = strings.TrimSpace(in.Consequences)
+	a.Notes = strings.TrimSpace(in.Notes)
+	a.ClassifiedAt = now
+	a.ClassifiedBy = actor
+	a.UpdatedAt = now
+	if err := s.repo.SaveAssessment(ctx, a); err != nil {
+		return nil, err
+	}
+
+	profiles, err := s.Profiles(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	clocks, err := s.repo.ListClocks(ctx, tenantID, incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluations := []ProfileEvaluation{}
+	applicable := applicableProfiles(profiles, a.Jurisdictions)
+	matched := map[uuid.UUID]bool{}
+	var started []string
+	for _, p := range applicable {
+		ok, reason := p.Criteria.Matches(inc, a)
+		evaluations = append(evaluations, ProfileEvaluation{
+			ProfileID: p.ID, Regime: p.Regime, Jurisdiction: p.Jurisdiction, Applies: ok, Reason: reason,
+		})
+		if !ok {
+			continue
+		}
+		matched[p.ID] = true
+		n, err := s.startClocks(ctx, inc, a, p, &clocks, now)
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			started = append(started, fmt.Sprintf("%s (%s)", strings.ToUpper(string(p.Regime)), p.Jurisdiction))
+		}
+	}
+
+	var withdrawn int
+	for i := range clocks {
+		c := &clocks[i]
+		if matched[c.ProfileID] || c.Status != domain.ClockPending {
+			continue
+		}
+		c.Status = domain.ClockWithdrawn
+		c.WithdrawnReason = withdrawalReason(c, evaluations)
+		c.NextEscalationAt = nil
+		c.UpdatedAt = now
+		if err := s.repo.SaveClock(ctx, c); err != nil {
+			return nil, err
+		}
+		withdrawn++
+	}
+	if err := s.retime(ctx, a, clocks, now); err != nil {
+		return nil, err
+	}
+
+	summary := fmt.Sprintf("Incident INC-%d classified", incidentID)
+	if len(started) > 0 {
+		summary += "; notification clocks started: " + strings.Join(started, ", ")
+	}
+	if withdrawn > 0 {
+		summary += fmt.Sprintf("; %d pending clock(s) withdrawn", withdrawn)
+	}
+	s.note(ctx, tenantID, incidentID, actor, domain.AuditActionUpdate, summary, domain.JSONMap{
+		"personal_data": a.PersonalData, "significant": a.Significant, "major": a.Major,
+		"jurisdictions": []string(a.Jurisdictions), "aware_at": a.AwareAt,
+	})
+
+	return &RegulatoryStatus{Assessment: a, Clocks: s.views(clocks), Evaluations: evaluations}, nil
+}
+
+// applicableProfiles picks, per regime, the enabled profile of each of the
+// incident's jurisdictions, falling back to the EU profile for a jurisdiction
+// nobody configured. An incident in FR and DE with only an FR profile is
+// therefore tracked under FR's authority and the EU default.
+func applicableProfiles(profiles []domain.RegulatoryProfile, jurisdictions domain.StringList) []domain.RegulatoryProfile {
+	if len(jurisdictions) == 0 {
+		jurisdictions = domain.StringList{domain.RegulatoryJurisdictionEU}
+	}
+	byKey := map[string]domain.RegulatoryProfile{}
+	for _, p := range profiles {
+		if p.Enabled {
+			byKey[string(p.Regime)+"/"+p.Jurisdiction] = p
+		}
+	}
+	seen := map[uuid.UUID]bool{}
+	var out []domain.RegulatoryProfile
+	for _, spec := range domain.RegulatoryRegimes() {
+		for _, j := range jurisdictions {
+			p, ok := byKey[string(spec.Regime)+"/"+j]
+			if !ok {
+				p, ok = byKey[string(spec.Regime)+"/"+domain.RegulatoryJurisdictionEU]
+			}
+			if ok && !seen[p.ID] {
+				seen[p.ID] = true
+				out = append(out, p)
+			}
+		}
+	}
+	return out
+}
+
+func withdrawalReason(c *domain.RegulatoryClock, evaluations []ProfileEvaluation) string {
+	for _, e := range evaluations {
+		if e.ProfileID == c.ProfileID && e.Reason != "" {
+			return "reclassified: " + e.Reason
+		}
+	}
+	return "reclassified: jurisdiction no longer in scope"
+}
+
+// startClocks makes sure every stage of the profile's regime has a clock,
+// reinstating withdrawn ones. Returns how many were started or reinstated.
+func (s *RegulatoryService) startClocks(ctx context.Context, inc *domain.Incident, a *domain.IncidentRegulatoryAssessment, p domain.RegulatoryProfile, clocks *[]domain.RegulatoryClock, now time.Time) (int, error) {
+	spec, _ := domain.LookupRegulatoryRegime(p.Regime)
+	frameworkID, controlID := s.resolveControl(ctx, p.TenantID, spec)
+	started := 0
+	for seq, stage := range spec.Stages {
+		var existing *domain.RegulatoryClock
+		for i := range *clocks {
+			if (*clocks)[i].ProfileID == p.ID && (*clocks)[i].Stage == stage.Key {
+				existing = &(*clocks)[i]
+				break
+			}
+		}
+		if existing != nil {
+			if existing.Status == domain.ClockWithdrawn {
+				existing.Status = domain.ClockPending
+				existing.WithdrawnReason = ""
+				existing.EscalationLevel = 0
+				existing.UpdatedAt = now
+				started++
+			}
+			continue
+		}
+		*clocks = append(*clocks, domain.RegulatoryClock{
+			ID:           uuid.New(),
+			TenantID:     p.TenantID,
+			IncidentID:   inc.ID,
+			ProfileID:    p.ID,
+			Regime:       p.Regime,
+			Jurisdiction: p.Jurisdiction,
+			Authority:    p.Authority,
+			Stage:        stage.Key,
+			StageLabel:   stage.Label,
+			Sequence:     seq,
+			Status:       domain.ClockPending,
+			FrameworkID:  frameworkID,
+			ControlID:    controlID,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		})
+		started++
+	}
+	return started, nil
+}
+
+// retime recomputes every pending clock's deadline and reminder schedule, in
+// stage order, and persists them. A stage anchored on its predecessor counts
+// from the predecessor's submission once there is one.
+func (s *RegulatoryService) retime(ctx context.Context, a *domain.IncidentRegulatoryAssessment, clocks []domain.RegulatoryClock, now time.Time) error {
+	sort.SliceStable(clocks, func(i, j int) bool {
+		if clocks[i].ProfileID != clocks[j].ProfileID {
+			return clocks[i].ProfileID.String() < clocks[j].ProfileID.String()
+		}
+		return clocks[i].Sequence < clocks[j].Sequence
+	})
+	var previous time.Time
+	var lastProfile uuid.UUID
+	for i := range clocks {
+		c := &clocks[i]
+		if c.ProfileID != lastProfile {
+			previous = a.AwareAt
+			lastProfile = c.ProfileID
+		}
+		spec, ok := domain.LookupRegulatoryRegime(c.Regime)
+		if ok && c.Sequence < len(spec.Stages) && c.Status == domain.ClockPending {
+			// The classification anchor is when this regime first applied to the
+			// incident — the clock's own start — not the latest re-classification,
+			// which would otherwise push DORA's 4 h out every time someone edits
+			// a field.
+			due := spec.Stages[c.Sequence].Deadline(a.AwareAt, c.CreatedAt, previous)
+			if !due.Equal(c.DueAt) || c.NextEscalationAt == nil {
+				c.DueAt = due
+				next := nextEscalation(c, now)
+				c.NextEscalationAt = &next
+				c.UpdatedAt = now
+			}
+			if err := s.repo.SaveClock(ctx, c); err != nil {
+				return err
+			}
+		}
+		switch {
+		case c.Status == domain.ClockSubmitted && c.SubmittedAt != nil:
+			previous = *c.SubmittedAt
+		default:
+			previous = c.DueAt
+		}
+	}
+	return nil
+}
+
+// nextEscalation is the next reminder instant after now.
+func nextEscalation(c *domain.RegulatoryClock, now time.Time) time.Time {
+	start := c.CreatedAt
+	window := c.DueAt.Sub(start)
+	if window > 0 {
+		for _, f := range escalationMarks {
+			if t := start.Add(time.Duration(float64(window) * f)); t.After(now) {
+				return t
+			}
+		}
+	}
+	if c.DueAt.After(now) {
+		return c.DueAt
+	}
+	return now.Add(overdueReminderEvery)
+}
+
+// resolveControl finds the regime's control in the tenant's imported framework.
+// Best-effort: an unimported catalog leaves the clock without a control, and the
+// submission still records its evidence — just unlinked.
+func (s *RegulatoryService) resolveControl(ctx context.Context, tenantID uuid.UUID, spec domain.RegulatoryRegimeSpec) (*uuid.UUID, *uuid.UUID) {
+	if s.catalog == nil {
+		return nil, nil
+	}
+	frameworks, err := s.catalog.ListFrameworks(ctx, tenantID)
+	if err != nil {
+		return nil, nil
+	}
+	for _, fw := range frameworks {
+		if fw.CatalogKey != spec.CatalogKey {
+			continue
+		}
+		fwID := fw.ID
+		controls, err := s.catalog.ListControlsByFramework(ctx, tenantID, fw.ID)
+		if err != nil {
+			return &fwID, nil
+		}
+		for _, c := range controls {
+			if strings.EqualFold(strings.TrimSpace(c.ReferenceCode), spec.ControlRef) {
+				cID := c.ID
+				return &fwID, &cID
+			}
+		}
+		return &fwID, nil
+	}
+	return nil, nil
+}
+
+// =============================================================================
+// Drafts and submission
+// =============================================================================
+
+// DraftField is one answer on the authority's form.
+type DraftField struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	Value string `json:"value"`
+	// Prefilled is false when the incident does not know the answer and a human
+	// has to write it — the UI highlights these.
+	Prefilled bool `json:"prefilled"`
+}
+
+// NotificationDraft is a ready-to-send notification for one clock.
+type NotificationDraft struct {
+	Clock            ClockView    `json:"clock"`
+	Regime           string       `json:"regime"`
+	Authority        string       `json:"authority"`
+	AuthorityContact string       `json:"authority_contact,omitempty"`
+	Fields           []DraftField `json:"fields"`
+}
+
+var draftLabels = map[string]string{
+	domain.FieldNature:          "Nature of the incident",
+	domain.FieldDetectedAt:      "Detected at",
+	domain.FieldAwareAt:         "Organisation became aware at",
+	domain.FieldSeverity:        "Severity",
+	domain.FieldCategories:      "Categories of data concerned",
+	domain.FieldAffected:        "Approximate number of subjects / users affected",
+	domain.FieldConsequences:    "Likely consequences",
+	domain.FieldMeasures:        "Measures taken or proposed",
+	domain.FieldContact:         "Contact point",
+	domain.FieldMalicious:       "Suspected unlawful or malicious act",
+	domain.FieldCrossBorder:     "Cross-border impact",
+	domain.FieldRootCause:       "Root cause",
+	domain.FieldAssets:          "Affected assets / services",
+	domain.FieldStatus:          "Current status",
+	domain.FieldClassification:  "Classification criteria met",
+	domain.FieldRecoveryActions: "Recovery actions",
+}
+
+// Draft pre-fills a clock's notification from the incident and its assessment.
+// Generated on every read rather than stored, so the draft follows the incident
+// until the moment it is submitted and frozen.
+func (s *RegulatoryService) Draft(ctx context.Context, tenantID, clockID uuid.UUID) (*NotificationDraft, error) {
+	c, err := s.clock(ctx, tenantID, clockID)
+	if err != nil {
+		return nil, err
+	}
+	inc, err := s.incident(tenantID, c.IncidentID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := s.repo.GetAssessment(ctx, tenantID, c.IncidentID)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		return nil, domain.NewValidationError("the incident has not been classified")
+	}
+	p, err := s.repo.GetProfile(ctx, tenantID, c.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		p = &domain.RegulatoryProfile{Authority: c.Authority}
+	}
+	spec, _ := domain.LookupRegulatoryRegime(c.Regime)
+	var stage domain.RegulatoryStageSpec
+	if c.Sequence < len(spec.Stages) {
+		stage = spec.Stages[c.Sequence]
+	}
+	answers := s.prefill(ctx, inc, a, p)
+	d := &NotificationDraft{
+		Clock:            s.view(*c),
+		Regime:           spec.Name,
+		Authority:        p.Authority,
+		AuthorityContact: p.AuthorityContact,
+		Fields:           make([]DraftField, 0, len(stage.Fields)),
+	}
+	for _, key := range stage.Fields {
+		v := answers[key]
+		d.Fields = append(d.Fields, DraftField{Key: key, Label: draftLabels[key], Value: v, Prefilled: v != ""})
+	}
+	return d, nil
+}
+
+func (s *RegulatoryService) prefill(ctx context.Context, inc *domain.Incident, a *domain.IncidentRegulatoryAssessment, p *domain.RegulatoryProfile) map[string]string {
+	yesNo := func(b bool) string {
+		if b {
+			return "Yes"
+		}
+		return "No"
+	}
+	out := map[string]string{
+		domain.FieldNature:      strings.TrimSpace(inc.Title + "\n\n" + inc.Description),
+		domain.FieldDetectedAt:  inc.CreatedAt.UTC().Format(time.RFC3339),
+		domain.FieldAwareAt:     a.AwareAt.UTC().Format(time.RFC3339),
+		domain.FieldSeverity:    strings.ToUpper(inc.Severity),
+		domain.FieldMalicious:   yesNo(a.Malicious),
+		domain.FieldCrossBorder: yesNo(a.CrossBorder),
+		domain.FieldStatus:      inc.Status,
+		domain.FieldContact:     p.ContactPoint,
+	}
+	if len(a.DataCategories) > 0 {
+		out[domain.FieldCategories] = strings.Join(a.DataCategories, ", ")
+	}
+	if a.AffectedSubjects > 0 {
+		out[domain.FieldAffected] = fmt.Sprintf("%d", a.AffectedSubjects)
+	}
+	out[domain.FieldConsequences] = a.Consequences
+	out[domain.FieldMeasures] = strings.TrimSpace(inc.Resolution)
+	if n := len(inc.AssetIDs); n > 0 {
+		out[domain.FieldAssets] = fmt.Sprintf("%d asset(s): %s", n, strings.Join(inc.AssetIDs, ", "))
+	}
+	var met []string
+	if a.PersonalData {
+		met = append(met, "personal data")
+	}
+	if a.Significant {
+		met = append(met, "significant")
+	}
+	if a.Major {
+		met = append(met, "major")
+	}
+	if a.AffectedSubjects > 0 {
+		met = append(met, fmt.Sprintf("%d affected", a.AffectedSubjects))
+	}
+	out[domain.FieldClassification] = strings.Join(met, ", ")
+	if s.postMortems != nil {
+		if pm, err := s.postMortems.Get(ctx, a.TenantID, inc.ID); err == nil && pm != nil {
+			out[domain.FieldRootCause] = strings.TrimSpace(pm.RootCause)
+			var actions []string
+			for _, ca := range pm.CorrectiveActions {
+				actions = append(actions, ca.Title)
+			}
+			out[domain.FieldRecoveryActions] = strings.Join(actions, "; ")
+		}
+	}
+	return out
+}
+
+// SubmitInput records that a notification went out.
+type SubmitInput struct {
+	Reference   string
+	SubmittedAt *time.Time
+	// EvidenceID is the receipt (or the sent notification) already uploaded to
+	// the evidence library.
+	EvidenceID *uuid.UUID
+	// Content is what was actually sent. Nil freezes the current draft.
+	Content map[string]string
+}
+
+// Submit records a submission and its proof. Either an authority reference or
+// an evidence file is required: "we sent it" with nothing to show for it is not
+// a record anybody can rely on.
+func (s *RegulatoryService) Submit(ctx context.Context, tenantID, clockID uuid.UUID, in SubmitInput, actor *uuid.UUID) (*ClockView, error) {
+	c, err := s.clock(ctx, tenantID, clockID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status != domain.ClockPending {
+		return nil, &domain.AppError{Err: domain.ErrConflict, Code: http.StatusConflict,
+			Message: fmt.Sprintf("this notification is %s; only a pending one can be submitted", c.Status)}
+	}
+	in.Reference = strings.TrimSpace(in.Reference)
+	if in.Reference == "" && in.EvidenceID == nil {
+		return nil, domain.NewValidationError("a submission needs the authority's reference or an evidence file")
+	}
+	now := s.now().UTC()
+	at := now
+	if in.SubmittedAt != nil {
+		at = in.SubmittedAt.UTC()
+		if at.After(now) {
+			return nil, domain.NewValidationError("submitted_at cannot be in the future")
+		}
+	}
+	content := domain.JSONMap{}
+	if in.Content != nil {
+		for k, v := range in.Content {
+			content[k] = v
+		}
+	} else if d, err := s.Draft(ctx, tenantID, clockID); err == nil {
+		for _, f := range d.Fields {
+			content[f.Key] = f.Value
+		}
+	}
+
+	if in.EvidenceID != nil && s.evidence != nil && c.ControlID != nil {
+		var linker uuid.UUID
+		if actor != nil {
+			linker = *actor
+		}
+		note := fmt.Sprintf("%s %s submission for INC-%d", strings.ToUpper(string(c.Regime)), c.StageLabel, c.IncidentID)
+		if _, err := s.evidence.Link(ctx, tenantID, *in.EvidenceID, []uuid.UUID{*c.ControlID}, note, linker); err != nil {
+			return nil, err
+		}
+	}
+
+	c.Status = domain.ClockSubmitted
+	c.SubmittedAt = &at
+	c.SubmittedBy = actor
+	c.SubmissionReference = in.Reference
+	c.SubmissionEvidenceID = in.EvidenceID
+	c.SubmittedContent = content
+	c.NextEscalationAt = nil
+	c.UpdatedAt = now
+	if err := s.repo.SaveClock(ctx, c); err != nil {
+		return nil, err
+	}
+
+	// Later stages that count from this one move with it.
+	if a, err := s.repo.GetAssessment(ctx, tenantID, c.IncidentID); err == nil && a != nil {
+		if clocks, err := s.repo.ListClocks(ctx, tenantID, c.IncidentID); err == nil {
+			if err := s.retime(ctx, a, clocks, now); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	summary := fmt.Sprintf("%s %s submitted to %s", strings.ToUpper(string(c.Regime)), c.StageLabel, c.Authority)
+	if c.SubmittedLate() {
+		summary += fmt.Sprintf(" (%s late)", at.Sub(c.DueAt).Round(time.Minute))
+	}
+	after := domain.JSONMap{"clock_id": c.ID.String(), "stage": c.Stage, "reference": c.SubmissionReference, "due_at": c.DueAt}
+	if c.SubmissionEvidenceID != nil {
+		after["evidence_id"] = c.SubmissionEvidenceID.String()
+	}
+	s.note(ctx, tenantID, c.IncidentID, actor, domain.AuditActionUpdate, summary, after)
+	v := s.view(*c)
+	return &v, nil
+}
+
+// OpenClocks lists the tenant's pending clocks, soonest first — the "what is
+// due to a regulator" board.
+func (s *RegulatoryService) OpenClocks(ctx context.Context, tenantID uuid.UUID) ([]ClockView, error) {
+	rows, err := s.repo.ListOpenClocks(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.views(rows), nil
+}
+
+// =============================================================================
+// Escalation
+// =============================================================================
+
+// SweepEscalations reminds the owners of every clock whose next reminder is due
+// and schedules the one after. Cross-tenant; called by the monitor worker.
+//
+// The schedule advances even when delivery fails: a dead Slack webhook must not
+// turn into a reminder every minute on the channels that do work.
+func (s *RegulatoryService) SweepEscalations(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.repo.ListEscalationDue(ctx, now, 200)
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for i := range due {
+		c := &due[i]
+		inc, ierr := s.incidents.GetIncident(c.TenantID.String(), c.IncidentID)
+		if ierr == nil && inc != nil && s.alerter != nil {
+			p, _ := s.repo.GetProfile(ctx, c.TenantID, c.ProfileID)
+			if aerr := s.alerter.AlertDeadline(ctx, DeadlineAlert{
+				Clock: c, Incident: inc, Profile: p, Remaining: c.DueAt.Sub(now),
+			}); aerr == nil {
+				sent++
+			}
+		}
+		at := now.UTC()
+		c.EscalationLevel++
+		c.LastEscalatedAt = &at
+		next := nextEscalation(c, now)
+		c.NextEscalationAt = &next
+		c.UpdatedAt = at
+		if err := s.repo.SaveClock(ctx, c); err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// =============================================================================
+// helpers
+// =============================================================================
+
+func (s *RegulatoryService) incident(tenantID uuid.UUID, incidentID uint) (*domain.Incident, error) {
+	inc, err := s.incidents.GetIncident(tenantID.String(), incidentID)
+	if err != nil || inc == nil {
+		return nil, domain.NewNotFoundError("incident", incidentID)
+	}
+	return inc, nil
+}
+
+func (s *RegulatoryService) clock(ctx context.Context, tenantID, id uuid.UUID) (*domain.RegulatoryClock, error) {
+	c, err := s.repo.GetClock(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, domain.NewNotFoundError("regulatory clock", id)
+	}
+	return c, nil
+}
+
+func (s *RegulatoryService) view(c domain.RegulatoryClock) ClockView {
+	now := s.now()
+	v := ClockView{RegulatoryClock: c, Overdue: c.IsOverdue(now), SubmittedLate: c.SubmittedLate()}
+	if c.Status == domain.ClockPending {
+		v.RemainingSeconds = int64(c.DueAt.Sub(now) / time.Second)
+	}
+	return v
+}
+
+func (s *RegulatoryService) views(rows []domain.RegulatoryClock) []ClockView {
+	out := make([]ClockView, 0, len(rows))
+	for _, c := range rows {
+		out = append(out, s.view(c))
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].DueAt.Before(out[j].DueAt) })
+	return out
+}
+
+// note writes the decision to the incident timeline and the audit chain, both
+// best-effort: the clocks are already saved, and a journal outage must not make
+// a DPO redo a classification at hour 70.
+func (s *RegulatoryService) note(ctx context.Context, tenantID uuid.UUID, incidentID uint, actor *uuid.UUID, action domain.AuditAction, summary string, after domain.JSONMap) {
+	if s.timeline != nil {
+		by := ""
+		if actor != nil {
+			by = actor.String()
+		}
+		_ = s.timeline.AddTimelineEntry(incidentID, "regulatory", summary, "", by)
+	}
+	if s.audit != nil {
+		s.audit.Record(ctx, domain.AuditEvent{
+			TenantID:   tenantID,
+			ActorID:    actor,
+			Action:     action,
+			EntityType: "incident",
+			EntityID:   fmt.Sprintf("%d", incidentID),
+			Summary:    summary,
+			After:      after,
+		})
+	}
+}
@@ -0,0 +1,398 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package incident
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+type fakeRegulatoryRepo struct {
+	profiles    map[uuid.UUID]domain.RegulatoryProfile
+	assessments map[uint]domain.IncidentRegulatoryAssessment
+	clocks      map[uuid.UUID]domain.RegulatoryClock
+}
+
+func newFakeRegulatoryRepo() *fakeRegulatoryRepo {
+	return &fakeRegulatoryRepo{
+		profiles:    map[uuid.UUID]domain.RegulatoryProfile{},
+		assessments: map[uint]domain.IncidentRegulatoryAssessment{},
+		clocks:      map[uuid.UUID]domain.RegulatoryClock{},
+	}
+}
+
+func (r *fakeRegulatoryRepo) ListProfiles(_ context.Context, tenantID uuid.UUID) ([]domain.RegulatoryProfile, error) {
+	var out []domain.RegulatoryProfile
+	for _, p := range r.profiles {
+		if p.TenantID == tenantID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+func (r *fakeRegulatoryRepo) GetProfile(_ context.Context, tenantID, id uuid.UUID) (*domain.RegulatoryProfile, error) {
+	p, ok := r.profiles[id]
+	if !ok || p.TenantID != tenantID {
+		return nil, nil
+	}
+	return &p, nil
+}
+func (r *fakeRegulatoryRepo) SaveProfile(_ context.Context, p *domain.RegulatoryProfile) error {
+	r.profiles[p.ID] = *p
+	return nil
+}
+func (r *fakeRegulatoryRepo) DeleteProfile(_ context.Context, _, id uuid.UUID) error {
+	delete(r.profiles, id)
+	return nil
+}
+func (r *fakeRegulatoryRepo) GetAssessment(_ context.Context, tenantID uuid.UUID, incidentID uint) (*domain.IncidentRegulatoryAssessment, error) {
+	a, ok := r.assessments[incidentID]
+	if !ok || a.TenantID != tenantID {
+		return nil, nil
+	}
+	return &a, nil
+}
+func (r *fakeRegulatoryRepo) SaveAssessment(_ context.Context, a *domain.IncidentRegulatoryAssessment) error {
+	r.assessments[a.IncidentID] = *a
+	return nil
+}
+func (r *fakeRegulatoryRepo) ListClocks(_ context.Context, tenantID uuid.UUID, incidentID uint) ([]domain.RegulatoryClock, error) {
+	var out []domain.RegulatoryClock
+	for _, c := range r.clocks {
+		if c.TenantID == tenantID && c.IncidentID == incidentID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+func (r *fakeRegulatoryRepo) ListOpenClocks(_ context.Context, tenantID uuid.UUID) ([]domain.RegulatoryClock, error) {
+	var out []domain.RegulatoryClock
+	for _, c := range r.clocks {
+		if c.TenantID == tenantID && c.Status == domain.ClockPending {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+func (r *fakeRegulatoryRepo) GetClock(_ context.Context, tenantID, id uuid.UUID) (*domain.RegulatoryClock, error) {
+	c, ok := r.clocks[id]
+	if !ok || c.TenantID != tenantID {
+		return nil, nil
+	}
+	return &c, nil
+}
+func (r *fakeRegulatoryRepo) SaveClock(_ context.Context, c *domain.RegulatoryClock) error {
+	r.clocks[c.ID] = *c
+	return nil
+}
+func (r *fakeRegulatoryRepo) ListEscalationDue(_ context.Context, now time.Time, _ int) ([]domain.RegulatoryClock, error) {
+	var out []domain.RegulatoryClock
+	for _, c := range r.clocks {
+		if c.Status == domain.ClockPending && c.NextEscalationAt != nil && !c.NextEscalationAt.After(now) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRegulatoryRepo) byStage(regime domain.RegulatoryRegime, stage string) domain.RegulatoryClock {
+	for _, c := range r.clocks {
+		if c.Regime == regime && c.Stage == stage {
+			return c
+		}
+	}
+	return domain.RegulatoryClock{}
+}
+
+type fakeCatalog struct {
+	framework domain.ComplianceFramework
+	control   domain.ComplianceControl
+}
+
+func (f fakeCatalog) ListFrameworks(context.Context, uuid.UUID) ([]domain.ComplianceFramework, error) {
+	return []domain.ComplianceFramework{f.framework}, nil
+}
+func (f fakeCatalog) ListControlsByFramework(_ context.Context, _, frameworkID uuid.UUID) ([]domain.ComplianceControl, error) {
+	if frameworkID != f.framework.ID {
+		return nil, nil
+	}
+	return []domain.ComplianceControl{f.control}, nil
+}
+
+type fakeLinker struct {
+	evidence uuid.UUID
+	controls []uuid.UUID
+}
+
+func (f *fakeLinker) Link(_ context.Context, _, evidenceID uuid.UUID, controlIDs []uuid.UUID, _ string, _ uuid.UUID) (*domain.Evidence, error) {
+	f.evidence = evidenceID
+	f.controls = controlIDs
+	return &domain.Evidence{ID: evidenceID}, nil
+}
+
+type fakeAlerter struct{ alerts []DeadlineAlert }
+
+func (f *fakeAlerter) AlertDeadline(_ context.Context, a DeadlineAlert) error {
+	f.alerts = append(f.alerts, a)
+	return nil
+}
+
+type regulatoryFixture struct {
+	svc     *RegulatoryService
+	repo    *fakeRegulatoryRepo
+	tenant  uuid.UUID
+	inc     *domain.Incident
+	now     *time.Time
+	control uuid.UUID
+	linker  *fakeLinker
+	alerter *fakeAlerter
+}
+
+func newRegulatoryFixture(t *testing.T) *regulatoryFixture {
+	t.Helper()
+	tenant := uuid.New()
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	inc := &domain.Incident{
+		ID: 7, TenantID: tenant.String(), Title: "Customer database exfiltrated",
+		Description: "Backup bucket left public", IncidentType: "breach",
+		Severity: "high", Status: "investigating", CreatedAt: now.Add(-2 * time.Hour),
+	}
+	f := &regulatoryFixture{
+		repo: newFakeRegulatoryRepo(), tenant: tenant, inc: inc, now: &now,
+		control: uuid.New(), linker: &fakeLinker{}, alerter: &fakeAlerter{},
+	}
+	gdpr := domain.ComplianceFramework{ID: uuid.New(), CatalogKey: "gdpr-2016-679"}
+	f.svc = NewRegulatoryService(f.repo, fakeIncidents{inc: inc}).
+		WithCatalog(fakeCatalog{framework: gdpr, control: domain.ComplianceControl{ID: f.control, ReferenceCode: "Art.33"}}).
+		WithEvidenceLinker(f.linker).
+		WithAlerter(f.alerter).
+		WithClock(func() time.Time { return *f.now })
+	return f
+}
+
+func TestRegulatory_PersonalDataBreachStartsGDPRClock(t *testing.T) {
+	f := newRegulatoryFixture(t)
+	st, err := f.svc.Classify(context.Background(), f.tenant, 7, ClassifyInput{PersonalData: true, AffectedSubjects: 1200}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(st.Clocks) != 1 {
+		t.Fatalf("want only the GDPR clock, got %d", len(st.Clocks))
+	}
+	c := st.Clocks[0]
+	if c.Regime != domain.RegimeGDPR || !c.DueAt.Equal(f.inc.CreatedAt.Add(72*time.Hour)) {
+		t.Fatalf("GDPR clock must be due 72h after awareness, got %s %s", c.Regime, c.DueAt)
+	}
+	if c.ControlID == nil || *c.ControlID != f.control {
+		t.Fatal("clock must point at the imported Art.33 control")
+	}
+	var explained int
+	for _, e := range st.Evaluations {
+		if !e.Applies && e.Reason != "" {
+			explained++
+		}
+	}
+	if explained != 2 {
+		t.Fatalf("NIS2 and DORA must each say why they did not start, got %+v", st.Evaluations)
+	}
+}
+
+func TestRegulatory_NIS2FinalReportCountsFromNotification(t *testing.T) {
+	f := newRegulatoryFixture(t)
+	ctx := context.Background()
+	if _, err := f.svc.Classify(ctx, f.tenant, 7, ClassifyInput{Significant: true}, nil); err != nil {
+		t.Fatal(err)
+	}
+	aware := f.inc.CreatedAt
+	early := f.repo.byStage(domain.RegimeNIS2, "early_warning")
+	notif := f.repo.byStage(domain.RegimeNIS2, "notification")
+	final := f.repo.byStage(domain.RegimeNIS2, "final_report")
+	if !early.DueAt.Equal(aware.Add(24*time.Hour)) || !notif.DueAt.Equal(aware.Add(72*time.Hour)) {
+		t.Fatalf("early warning 24h / notification 72h, got %s / %s", early.DueAt, notif.DueAt)
+	}
+	if !final.DueAt.Equal(notif.DueAt.AddDate(0, 1, 0)) {
+		t.Fatalf("final report one month after the notification deadline, got %s", final.DueAt)
+	}
+
+	// Notified early: the final report moves with the actual submission.
+	*f.now = f.now.Add(20 * time.Hour)
+	if _, err := f.svc.Submit(ctx, f.tenant, notif.ID, SubmitInput{Reference: "CSIRT-42"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	final = f.repo.byStage(domain.RegimeNIS2, "final_report")
+	if !final.DueAt.Equal(f.now.AddDate(0, 1, 0)) {
+		t.Fatalf("final report must count from the submission, got %s", final.DueAt)
+	}
+}
+
+func TestRegulatory_DORAInitialIsCappedFromAwareness(t *testing.T) {
+	f := newRegulatoryFixture(t)
+	f.inc.CreatedAt = f.now.Add(-22 * time.Hour)
+	if _, err := f.svc.Classify(context.Background(), f.tenant, 7, ClassifyInput{Major: true}, nil); err != nil {
+		t.Fatal(err)
+	}
+	initial := f.repo.byStage(domain.RegimeDORA, "initial")
+	if want := f.inc.CreatedAt.Add(24 * time.Hour); !initial.DueAt.Equal(want) {
+		t.Fatalf("4h from classification would exceed 24h from awareness: want %s, got %s", want, initial.DueAt)
+	}
+	inter := f.repo.byStage(domain.RegimeDORA, "intermediate")
+	if !inter.DueAt.Equal(initial.DueAt.Add(72 * time.Hour)) {
+		t.Fatalf("intermediate 72h after the initial notification, got %s", inter.DueAt)
+	}
+}
+
+func TestRegulatory_ReclassificationWithdrawsOnlyPendingClocks(t *testing.T) {
+	f := newRegulatoryFixture(t)
+	ctx := context.Background()
+	if _, err := f.svc.Classify(ctx, f.tenant, 7, ClassifyInput{Significant: true}, nil); err != nil {
+		t.Fatal(err)
+	}
+	early := f.repo.byStage(domain.RegimeNIS2, "early_warning")
+	if _, err := f.svc.Submit(ctx, f.tenant, early.ID, SubmitInput{Reference: "EW-1"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.svc.Classify(ctx, f.tenant, 7, ClassifyInput{Significant: false}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.repo.byStage(domain.RegimeNIS2, "early_warning").Status; got != domain.ClockSubmitted {
+		t.Fatalf("a submitted notification stays submitted, got %s", got)
+	}
+	notif := f.repo.byStage(domain.RegimeNIS2, "notification")
+	if notif.Status != domain.ClockWithdrawn || notif.WithdrawnReason == "" {
+		t.Fatalf("pending clock must be withdrawn with a reason, got %s %q", notif.Status, notif.WithdrawnReason)
+	}
+
+	// Back in scope: the same clock comes back rather than a duplicate.
+	if _, err := f.svc.Classify(ctx, f.tenant, 7, ClassifyInput{Significant: true}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.repo.clocks); n != 3 {
+		t.Fatalf("reinstating must not duplicate clocks, have %d", n)
+	}
+	if got := f.repo.byStage(domain.RegimeNIS2, "notification").Status; got != domain.ClockPending {
+		t.Fatalf("clock must be reinstated, got %s", got)
+	}
+}
+
+func TestRegulatory_JurisdictionProfileOverridesEUDefault(t *testing.T) {
+	f := newRegulatoryFixture(t)
+	ctx := context.Background()
+	if _, err := f.svc.SaveProfile(ctx, f.tenant, nil, ProfileInput{
+		Regime: "gdpr", Jurisdiction: "fr", Authority: "CNIL", Enabled: true,
+		Criteria: domain.RegulatoryCriteria{RequirePersonalData: true, MinAffectedSubjects: 10},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	st, err := f.svc.Classify(ctx, f.tenant, 7, ClassifyInput{PersonalData: true, AffectedSubjects: 3, Jurisdictions: []string{"FR"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(st.Clocks) != 0 {
+		t.Fatalf("FR threshold of 10 subjects not met; no clock expected, got %d", len(st.Clocks))
+	}
+	st, err = f.svc.Classify(ctx, f.tenant, 7, ClassifyInput{PersonalData: true, AffectedSubjects: 30, Jurisdictions: []string{"FR"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(st.Clocks) != 1 || st.Clocks[0].Authority != "CNIL" {
+		t.Fatalf("FR profile must win over the EU default, got %+v", st.Clocks)
+	}
+
+	if _, err := f.svc.SaveProfile(ctx, f.tenant, nil, ProfileInput{Regime: "gdpr", Jurisdiction: "FR", Enabled: true,
+		Criteria: domain.RegulatoryCriteria{RequirePersonalData: true}}); err == nil {
+		t.Fatal("a second GDPR profile for FR must be refused")
+	}
+	if _, err := f.svc.SaveProfile(ctx, f.tenant, nil, ProfileInput{Regime: "gdpr", Jurisdiction: "DE", Enabled: true}); err == nil {
+		t.Fatal("a profile without criteria must be refused")
+	}
+}
+
+func TestRegulatory_SubmitNeedsProofAndLinksEvidence(t *testing.T) {
+	f := newRegulatoryFixture(t)
+	ctx := context.Background()
+	st, err := f.svc.Classify(ctx, f.tenant, 7, ClassifyInput{PersonalData: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := st.Clocks[0].ID
+	if _, err := f.svc.Submit(ctx, f.tenant, id, SubmitInput{}, nil); err == nil {
+		t.Fatal("a submission with neither reference nor evidence must be refused")
+	}
+	receipt := uuid.New()
+	v, err := f.svc.Submit(ctx, f.tenant, id, SubmitInput{EvidenceID: &receipt}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.linker.evidence != receipt || len(f.linker.controls) != 1 || f.linker.controls[0] != f.control {
+		t.Fatal("the receipt must be linked to the Art.33 control")
+	}
+	if v.SubmittedContent["nature"] == nil {
+		t.Fatal("the sent content must be frozen from the draft")
+	}
+	if _, err := f.svc.Submit(ctx, f.tenant, id, SubmitInput{Reference: "again"}, nil); err == nil {
+		t.Fatal("a submitted clock cannot be submitted twice")
+	}
+}
+
+func TestRegulatory_DraftIsPrefilledFromIncident(t *testing.T) {
+	f := newRegulatoryFixture(t)
+	st, err := f.svc.Classify(context.Background(), f.tenant, 7, ClassifyInput{
+		PersonalData: true, AffectedSubjects: 1200, DataCategories: []string{"email", "address"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := f.svc.Draft(context.Background(), f.tenant, st.Clocks[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]DraftField{}
+	for _, fd := range d.Fields {
+		got[fd.Key] = fd
+	}
+	if got[domain.FieldAffected].Value != "1200" || got[domain.FieldCategories].Value != "email, address" {
+		t.Fatalf("draft must carry the assessment, got %+v", d.Fields)
+	}
+	if got[domain.FieldMeasures].Prefilled {
+		t.Fatal("measures are unknown until the incident records a resolution; must be flagged for a human")
+	}
+}
+
+func TestRegulatory_SweepRemindsAtMarksThenHourly(t *testing.T) {
+	f := newRegulatoryFixture(t)
+	ctx := context.Background()
+	f.inc.CreatedAt = *f.now // aware now: GDPR window is exactly 72h
+	if _, err := f.svc.Classify(ctx, f.tenant, 7, ClassifyInput{PersonalData: true}, nil); err != nil {
+		t.Fatal(err)
+	}
+	start := *f.now
+	check := func(at time.Duration, want int) {
+		t.Helper()
+		n, err := f.svc.SweepEscalations(ctx, start.Add(at))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != want {
+			t.Fatalf("at +%s: want %d reminder(s), got %d", at, want, n)
+		}
+	}
+	check(35*time.Hour, 0)
+	check(36*time.Hour, 1) // 50 %
+	check(40*time.Hour, 0)
+	check(54*time.Hour, 1) // 75 %
+	check(time.Duration(64.8*float64(time.Hour)), 1)
+	check(72*time.Hour, 1) // deadline
+	check(72*time.Hour+30*time.Minute, 0)
+	check(73*time.Hour, 1) // hourly once overdue
+	if last := f.alerter.alerts[len(f.alerter.alerts)-1]; last.Remaining >= 0 {
+		t.Fatal("overdue reminder must carry a negative remaining time")
+	}
+}
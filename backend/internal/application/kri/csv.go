@@ -0,0 +1,136 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package kri
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// CSV upload limits. A KRI's history is a few points a month; a file larger
+// than this is not a KRI extract.
+const (
+	maxCSVBytes = 2 << 20
+	maxCSVRows  = 10000
+)
+
+// csvDateLayouts are the date forms accepted in the measured_at column, most
+// specific first. Day-first is the French spreadsheet default.
+var csvDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"02/01/2006",
+}
+
+// ImportCSV records the values of an uploaded file: two columns, measured_at
+// then value, with or without a header row (a header may name them in either
+// order), separated by commas or semicolons. The file is all-or-nothing: one
+// bad line rejects it, with the line number, so a half-imported month never
+// reads as a trend.
+func (s *Service) ImportCSV(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID, r io.Reader) (*RecordResult, error) {
+	k, err := s.loadFed(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	ms, err := parseCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.recordValues(ctx, k, actor, domain.KRISourceCSV, ms)
+}
+
+func parseCSV(r io.Reader) ([]measurement, error) {
+	raw, err := io.ReadAll(io.LimitReader(r, maxCSVBytes+1))
+	if err != nil {
+		return nil, domain.NewValidationError("could not read the file")
+	}
+	if len(raw) > maxCSVBytes {
+		return nil, domain.NewValidationError("file is larger than 2 MB")
+	}
+	raw = bytes.TrimPrefix(raw, []byte("\xef\xbb\xbf"))
+	cr := csv.NewReader(bytes.NewReader(raw))
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	firstLine, _, _ := bytes.Cut(raw, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		cr.Comma = ';'
+	}
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, domain.NewValidationError("malformed CSV: " + err.Error())
+	}
+
+	dateCol, valueCol, skipped := 0, 1, 0
+	if len(rows) > 0 && isHeader(rows[0]) {
+		for i, h := range rows[0] {
+			switch strings.ToLower(strings.TrimSpace(h)) {
+			case "measured_at", "date", "timestamp":
+				dateCol = i
+			case "value", "valeur":
+				valueCol = i
+			}
+		}
+		rows, skipped = rows[1:], 1
+	}
+	if len(rows) == 0 {
+		return nil, domain.NewValidationError("the file has no values")
+	}
+	if len(rows) > maxCSVRows {
+		return nil, domain.NewValidationError(fmt.Sprintf("the file has more than %d rows", maxCSVRows))
+	}
+
+	out := make([]measurement, 0, len(rows))
+	for i, row := range rows {
+		line := i + 1 + skipped
+		if len(row) == 1 && strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		if len(row) <= dateCol || len(row) <= valueCol {
+			return nil, domain.NewValidationError(fmt.Sprintf("line %d: expected measured_at and value", line))
+		}
+		at, err := parseCSVDate(row[dateCol])
+		if err != nil {
+			return nil, domain.NewValidationError(fmt.Sprintf("line %d: %q is not a date", line, row[dateCol]))
+		}
+		v, err := domain.ParseKRIValue(row[valueCol])
+		if err != nil {
+			return nil, domain.NewValidationError(fmt.Sprintf("line %d: %q is not a number", line, row[valueCol]))
+		}
+		out = append(out, measurement{at: at, value: v})
+	}
+	return out, nil
+}
+
+// isHeader reports a first row that is labels rather than a measurement.
+func isHeader(row []string) bool {
+	for _, cell := range row {
+		if _, err := parseCSVDate(cell); err == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func parseCSVDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range csvDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised date %q", s)
+}
@@ -0,0 +1,449 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package kri manages key risk indicators: their definitions, the values fed
+// into them (computed, pushed or uploaded), and what happens when one crosses
+// a threshold.
+package kri
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// QueryEvaluator computes a query KRI's value over the tenant's data.
+// *repository.GormKRIQueryEvaluator satisfies it.
+type QueryEvaluator interface {
+	EvaluateKRIQuery(ctx context.Context, tenantID uuid.UUID, q domain.KRIQuery, now time.Time) (float64, error)
+}
+
+// RiskLookup checks that linked risks exist. *repository.GormRiskRepository
+// satisfies it.
+type RiskLookup interface {
+	GetByID(ctx context.Context, id uuid.UUID, tenantID uuid.UUID) (*domain.Risk, error)
+}
+
+// RiskEscalator raises a risk's probability and makes its review due.
+// *repository.GormRiskRepository satisfies it. Optional: without it
+// bump_probability is recorded but has no effect.
+type RiskEscalator interface {
+	RaiseProbabilityForReview(ctx context.Context, tenantID, riskID uuid.UUID, step float64, at time.Time) (*domain.Risk, error)
+}
+
+// EventPublisher announces crossings to the automation engine and raised
+// risks to the Score Engine. Optional and best-effort: a failed publish never
+// loses a recorded value.
+type EventPublisher interface {
+	PublishKRIThresholdCrossed(ctx context.Context, k *domain.KRI, c domain.KRICrossing, measuredAt time.Time) error
+	PublishRiskUpdated(ctx context.Context, r *domain.Risk) error
+}
+
+// AuditSink records KRI changes and crossings in the tamper-evident audit
+// chain.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// DefaultTrendDays is the history a trend (sparkline) covers unless asked
+// otherwise; maxTrendDays bounds what a caller may ask for.
+const (
+	DefaultTrendDays = 90
+	maxTrendDays     = 730
+)
+
+// Service manages KRIs and records their values.
+type Service struct {
+	repo      domain.KRIRepository
+	risks     RiskLookup
+	evaluator QueryEvaluator
+	escalator RiskEscalator
+	events    EventPublisher
+	audit     AuditSink
+	now       func() time.Time
+}
+
+// NewService builds the service.
+func NewService(repo domain.KRIRepository, risks RiskLookup, evaluator QueryEvaluator) *Service {
+	return &Service{repo: repo, risks: risks, evaluator: evaluator, now: time.Now}
+}
+
+// WithEscalator enables bump_probability.
+func (s *Service) WithEscalator(e RiskEscalator) *Service {
+	s.escalator = e
+	return s
+}
+
+// WithEvents enables the kri_threshold_crossed automation trigger and the
+// re-score of raised risks.
+func (s *Service) WithEvents(p EventPublisher) *Service {
+	s.events = p
+	return s
+}
+
+// WithAudit enables audit recording.
+func (s *Service) WithAudit(a AuditSink) *Service {
+	s.audit = a
+	return s
+}
+
+// WithClock overrides the clock (tests).
+func (s *Service) WithClock(now func() time.Time) *Service {
+	s.now = now
+	return s
+}
+
+// Input is the body of POST/PUT /kris.
+type Input struct {
+	Name            string              `json:"name"`
+	Description     string              `json:"description"`
+	Unit            string              `json:"unit"`
+	Source          domain.KRISource    `json:"source"`
+	Query           domain.KRIQuery     `json:"query"`
+	RefreshMinutes  int                 `json:"refresh_minutes"`
+	Direction       domain.KRIDirection `json:"direction"`
+	AmberThreshold  float64             `json:"amber_threshold"`
+	RedThreshold    float64             `json:"red_threshold"`
+	RiskIDs         []uuid.UUID         `json:"risk_ids"`
+	BumpProbability bool                `json:"bump_probability"`
+	ProbabilityStep float64             `json:"probability_step"`
+}
+
+// ValueInput is one pushed value. MeasuredAt defaults to now.
+type ValueInput struct {
+	Value      float64    `json:"value"`
+	MeasuredAt *time.Time `json:"measured_at"`
+}
+
+// RecordResult says what recording values did.
+type RecordResult struct {
+	KRI      *domain.KRI         `json:"kri"`
+	Recorded int                 `json:"recorded"`
+	Crossing *domain.KRICrossing `json:"crossing,omitempty"`
+	// RaisedRisks are the linked risks whose probability was raised.
+	RaisedRisks []uuid.UUID `json:"raised_risks,omitempty"`
+}
+
+// Trend is a KRI with its recent values, oldest first — what a sparkline
+// draws.
+type Trend struct {
+	domain.KRI
+	Values []domain.KRIValue `json:"values"`
+}
+
+// List returns the tenant's KRIs.
+func (s *Service) List(ctx context.Context, tenantID uuid.UUID) ([]domain.KRI, error) {
+	rows, err := s.repo.List(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	return rows, nil
+}
+
+// Trends returns every KRI with its last days of values, optionally only the
+// ones linked to riskID. Red first, then amber, then by name.
+func (s *Service) Trends(ctx context.Context, tenantID uuid.UUID, riskID *uuid.UUID, days int) ([]Trend, error) {
+	rows, err := s.repo.List(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	since := s.now().AddDate(0, 0, -clampDays(days))
+	out := make([]Trend, 0, len(rows))
+	for i := range rows {
+		if riskID != nil && !rows[i].Links(*riskID) {
+			continue
+		}
+		vals, err := s.repo.History(ctx, tenantID, rows[i].ID, since)
+		if err != nil {
+			return nil, domain.NewInternalError(err.Error())
+		}
+		out = append(out, Trend{KRI: rows[i], Values: vals})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		ri, rj := statusOrder(out[i].Status), statusOrder(out[j].Status)
+		if ri != rj {
+			return ri < rj
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+// Get returns one KRI with its last days of values.
+func (s *Service) Get(ctx context.Context, tenantID, id uuid.UUID, days int) (*Trend, error) {
+	k, err := s.load(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := s.repo.History(ctx, tenantID, id, s.now().AddDate(0, 0, -clampDays(days)))
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	return &Trend{KRI: *k, Values: vals}, nil
+}
+
+// Save creates (id nil) or replaces a KRI. Changing thresholds re-bands the
+// current value without counting as a crossing: nothing was measured.
+func (s *Service) Save(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id *uuid.UUID, in Input) (*domain.KRI, error) {
+	k := &domain.KRI{ID: uuid.New(), TenantID: tenantID, CreatedBy: actor, Status: domain.KRIStatusUnknown}
+	action := domain.AuditActionCreate
+	if id != nil {
+		cur, err := s.load(ctx, tenantID, *id)
+		if err != nil {
+			return nil, err
+		}
+		k = cur
+		action = domain.AuditActionUpdate
+	}
+	k.Name, k.Description, k.Unit = in.Name, strings.TrimSpace(in.Description), in.Unit
+	k.Source, k.Query, k.RefreshMinutes = in.Source, in.Query, in.RefreshMinutes
+	k.Direction, k.AmberThreshold, k.RedThreshold = in.Direction, in.AmberThreshold, in.RedThreshold
+	k.BumpProbability, k.ProbabilityStep = in.BumpProbability, in.ProbabilityStep
+	k.RiskIDs = domain.StringList{}
+	seen := map[uuid.UUID]bool{}
+	for _, rid := range in.RiskIDs {
+		if seen[rid] {
+			continue
+		}
+		seen[rid] = true
+		r, err := s.risks.GetByID(ctx, rid, tenantID)
+		if err != nil {
+			return nil, domain.NewInternalError(err.Error())
+		}
+		if r == nil {
+			return nil, domain.NewValidationError(fmt.Sprintf("risk %s does not exist in this organisation", rid))
+		}
+		k.RiskIDs = append(k.RiskIDs, rid.String())
+	}
+	if err := k.Validate(); err != nil {
+		return nil, err
+	}
+	if k.LastValue != nil {
+		k.Status = k.Classify(*k.LastValue)
+	}
+	if err := s.repo.Save(ctx, k); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	s.record(ctx, tenantID, actor, action, k.ID, "KRI saved", domain.JSONMap{
+		"name": k.Name, "source": string(k.Source), "direction": string(k.Direction),
+		"amber_threshold": k.AmberThreshold, "red_threshold": k.RedThreshold,
+		"risk_ids": []string(k.RiskIDs), "bump_probability": k.BumpProbability,
+	})
+	return k, nil
+}
+
+// Delete removes a KRI and its history.
+func (s *Service) Delete(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) error {
+	if _, err := s.load(ctx, tenantID, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, tenantID, id); err != nil {
+		return domain.NewInternalError(err.Error())
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, id, "KRI deleted", nil)
+	return nil
+}
+
+// PushValue records one value sent by another system.
+func (s *Service) PushValue(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID, in ValueInput) (*RecordResult, error) {
+	k, err := s.loadFed(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	at := s.now()
+	if in.MeasuredAt != nil {
+		at = *in.MeasuredAt
+	}
+	return s.recordValues(ctx, k, actor, domain.KRISourcePush, []measurement{{at: at, value: in.Value}})
+}
+
+// Refresh evaluates a query KRI now, outside its cadence.
+func (s *Service) Refresh(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) (*RecordResult, error) {
+	k, err := s.load(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if k.Source != domain.KRISourceQuery {
+		return nil, domain.NewValidationError("only a query KRI can be refreshed; this one is fed by " + string(k.Source))
+	}
+	return s.evaluate(ctx, k, actor)
+}
+
+// SweepDue evaluates every query KRI whose cadence has elapsed, across
+// tenants. A failing query is recorded on its own KRI and does not stop the
+// others.
+func (s *Service) SweepDue(ctx context.Context, now time.Time) (int, error) {
+	rows, err := s.repo.ListQueryKRIs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for i := range rows {
+		if !rows[i].RefreshDue(now) {
+			continue
+		}
+		_, _ = s.evaluate(ctx, &rows[i], nil)
+		n++
+	}
+	return n, nil
+}
+
+func (s *Service) evaluate(ctx context.Context, k *domain.KRI, actor *uuid.UUID) (*RecordResult, error) {
+	if s.evaluator == nil {
+		return nil, domain.NewValidationError("query KRIs are not available on this deployment")
+	}
+	now := s.now()
+	v, err := s.evaluator.EvaluateKRIQuery(ctx, k.TenantID, k.Query, now)
+	if err != nil {
+		// Keep the failure visible on the KRI; the last good value stays.
+		k.LastError = err.Error()
+		_ = s.repo.Save(ctx, k)
+		return nil, domain.NewInternalError("kri query failed: " + err.Error())
+	}
+	k.LastError = ""
+	return s.recordValues(ctx, k, actor, domain.KRISourceQuery, []measurement{{at: now, value: v}})
+}
+
+type measurement struct {
+	at    time.Time
+	value float64
+}
+
+// recordValues is the one way values enter a KRI: it keeps them all, moves
+// the current state only for values newer than the current one (a backfill
+// fills the history, it does not rewrite today), and reacts to a crossing.
+func (s *Service) recordValues(ctx context.Context, k *domain.KRI, actor *uuid.UUID, source domain.KRISource, ms []measurement) (*RecordResult, error) {
+	now := s.now()
+	sort.SliceStable(ms, func(i, j int) bool { return ms[i].at.Before(ms[j].at) })
+	values := make([]domain.KRIValue, 0, len(ms))
+	for _, m := range ms {
+		if m.at.After(now.Add(5 * time.Minute)) {
+			return nil, domain.NewValidationError("measured_at cannot be in the future")
+		}
+		values = append(values, domain.KRIValue{
+			ID: uuid.New(), TenantID: k.TenantID, KRIID: k.ID,
+			Value: m.value, Status: k.Classify(m.value), Source: source,
+			MeasuredAt: m.at.UTC(), RecordedBy: actor,
+		})
+	}
+	res := &RecordResult{KRI: k, Recorded: len(values)}
+	prev := k.Status
+	if n := len(values); n > 0 && (k.LastMeasuredAt == nil || !values[n-1].MeasuredAt.Before(*k.LastMeasuredAt)) {
+		latest := values[n-1]
+		k.LastValue = &latest.Value
+		k.LastMeasuredAt = &latest.MeasuredAt
+		k.Status = latest.Status
+		res.Crossing = domain.CrossingBetween(prev, k.Status)
+	}
+	if err := s.repo.RecordValues(ctx, k, values); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if res.Crossing != nil {
+		res.RaisedRisks = s.react(ctx, k, actor, *res.Crossing)
+	}
+	return res, nil
+}
+
+// react tells the automation engine about a crossing and, for a move into red
+// on a KRI that asks for it, raises the linked risks' probability and makes
+// their review due now. Best-effort: the value is already recorded.
+func (s *Service) react(ctx context.Context, k *domain.KRI, actor *uuid.UUID, c domain.KRICrossing) []uuid.UUID {
+	if s.events != nil {
+		_ = s.events.PublishKRIThresholdCrossed(ctx, k, c, *k.LastMeasuredAt)
+	}
+	var raised []uuid.UUID
+	if c.IntoRed() && k.BumpProbability && s.escalator != nil {
+		now := s.now()
+		for _, rid := range k.LinkedRiskIDs() {
+			r, err := s.escalator.RaiseProbabilityForReview(ctx, k.TenantID, rid, k.ProbabilityStep, now)
+			if err != nil || r == nil {
+				continue
+			}
+			raised = append(raised, rid)
+			if s.events != nil {
+				_ = s.events.PublishRiskUpdated(ctx, r)
+			}
+		}
+	}
+	ids := make([]string, 0, len(raised))
+	for _, id := range raised {
+		ids = append(ids, id.String())
+	}
+	s.record(ctx, k.TenantID, actor, domain.AuditActionUpdate, k.ID,
+		fmt.Sprintf("KRI %q crossed from %s to %s", k.Name, c.From, c.To), domain.JSONMap{
+			"from": string(c.From), "to": string(c.To), "value": *k.LastValue, "raised_risks": ids,
+		})
+	return raised
+}
+
+// load returns the tenant's KRI or a not-found error.
+func (s *Service) load(ctx context.Context, tenantID, id uuid.UUID) (*domain.KRI, error) {
+	k, err := s.repo.Get(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if k == nil {
+		return nil, domain.NewNotFoundError("kri", id)
+	}
+	return k, nil
+}
+
+// loadFed returns a KRI that accepts values from outside. A query KRI's
+// values are what its query says; accepting others would make its history
+// say two things.
+func (s *Service) loadFed(ctx context.Context, tenantID, id uuid.UUID) (*domain.KRI, error) {
+	k, err := s.load(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if k.Source == domain.KRISourceQuery {
+		return nil, domain.NewValidationError("a query KRI computes its own values; use refresh")
+	}
+	return k, nil
+}
+
+func (s *Service) record(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, action domain.AuditAction, id uuid.UUID, summary string, after domain.JSONMap) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    actor,
+		Action:     action,
+		EntityType: "kri",
+		EntityID:   id.String(),
+		Summary:    summary,
+		After:      after,
+	})
+}
+
+func clampDays(days int) int {
+	if days <= 0 {
+		return DefaultTrendDays
+	}
+	if days > maxTrendDays {
+		return maxTrendDays
+	}
+	return days
+}
+
+func statusOrder(s domain.KRIStatus) int {
+	switch s {
+	case domain.KRIStatusRed:
+		return 0
+	case domain.KRIStatusAmber:
+		return 1
+	case domain.KRIStatusGreen:
+		return 2
+	default:
+		return 3
+	}
+}
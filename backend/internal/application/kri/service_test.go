@@ -0,0 +1,326 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package kri
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+type memKRIs struct {
+	rows   map[uuid.UUID]domain.KRI
+	values []domain.KRIValue
+}
+
+func newMemKRIs() *memKRIs { return &memKRIs{rows: map[uuid.UUID]domain.KRI{}} }
+
+func (m *memKRIs) List(_ context.Context, tenantID uuid.UUID) ([]domain.KRI, error) {
+	var out []domain.KRI
+	for _, k := range m.rows {
+		if k.TenantID == tenantID {
+			out = append(out, k)
+		}
+	}
+	return out, nil
+}
+func (m *memKRIs) Get(_ context.Context, tenantID, id uuid.UUID) (*domain.KRI, error) {
+	if k, ok := m.rows[id]; ok && k.TenantID == tenantID {
+		return &k, nil
+	}
+	return nil, nil
+}
+func (m *memKRIs) Save(_ context.Context, k *domain.KRI) error {
+	m.rows[k.ID] = *k
+	return nil
+}
+func (m *memKRIs) Delete(_ context.Context, _, id uuid.UUID) error {
+	delete(m.rows, id)
+	return nil
+}
+func (m *memKRIs) ListQueryKRIs(_ context.Context) ([]domain.KRI, error) {
+	var out []domain.KRI
+	for _, k := range m.rows {
+		if k.Source == domain.KRISourceQuery {
+			out = append(out, k)
+		}
+	}
+	return out, nil
+}
+func (m *memKRIs) RecordValues(_ context.Context, k *domain.KRI, values []domain.KRIValue) error {
+	m.values = append(m.values, values...)
+	m.rows[k.ID] = *k
+	return nil
+}
+func (m *memKRIs) History(_ context.Context, tenantID, kriID uuid.UUID, since time.Time) ([]domain.KRIValue, error) {
+	var out []domain.KRIValue
+	for _, v := range m.values {
+		if v.TenantID == tenantID && v.KRIID == kriID && !v.MeasuredAt.Before(since) {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+type memRisks struct{ rows map[uuid.UUID]*domain.Risk }
+
+func (m *memRisks) GetByID(_ context.Context, id, tenantID uuid.UUID) (*domain.Risk, error) {
+	if r, ok := m.rows[id]; ok && r.TenantID == tenantID {
+		return r, nil
+	}
+	return nil, nil
+}
+func (m *memRisks) RaiseProbabilityForReview(_ context.Context, tenantID, riskID uuid.UUID, step float64, at time.Time) (*domain.Risk, error) {
+	r, ok := m.rows[riskID]
+	if !ok || r.TenantID != tenantID {
+		return nil, nil
+	}
+	r.Probability += step
+	if r.Probability > 1 {
+		r.Probability = 1
+	}
+	r.NextReviewAt = &at
+	return r, nil
+}
+
+type recordedEvents struct {
+	crossings []domain.KRICrossing
+	updated   []uuid.UUID
+}
+
+func (e *recordedEvents) PublishKRIThresholdCrossed(_ context.Context, _ *domain.KRI, c domain.KRICrossing, _ time.Time) error {
+	e.crossings = append(e.crossings, c)
+	return nil
+}
+func (e *recordedEvents) PublishRiskUpdated(_ context.Context, r *domain.Risk) error {
+	e.updated = append(e.updated, r.ID)
+	return nil
+}
+
+type fixedEvaluator struct {
+	value float64
+	err   error
+}
+
+func (f *fixedEvaluator) EvaluateKRIQuery(context.Context, uuid.UUID, domain.KRIQuery, time.Time) (float64, error) {
+	return f.value, f.err
+}
+
+type fixture struct {
+	svc    *Service
+	repo   *memKRIs
+	risks  *memRisks
+	events *recordedEvents
+	eval   *fixedEvaluator
+	tenant uuid.UUID
+	risk   *domain.Risk
+	now    time.Time
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		repo:   newMemKRIs(),
+		events: &recordedEvents{},
+		eval:   &fixedEvaluator{},
+		tenant: uuid.New(),
+		now:    time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.risk = &domain.Risk{ID: uuid.New(), TenantID: f.tenant, Title: "Ransomware", Probability: 0.5}
+	f.risks = &memRisks{rows: map[uuid.UUID]*domain.Risk{f.risk.ID: f.risk}}
+	f.svc = NewService(f.repo, f.risks, f.eval).
+		WithEscalator(f.risks).
+		WithEvents(f.events).
+		WithClock(func() time.Time { return f.now })
+	return f
+}
+
+func (f *fixture) pushKRI(t *testing.T, bump bool) *domain.KRI {
+	t.Helper()
+	k, err := f.svc.Save(context.Background(), f.tenant, nil, nil, Input{
+		Name: "Unpatched KEV", Source: domain.KRISourcePush,
+		AmberThreshold: 5, RedThreshold: 10,
+		RiskIDs: []uuid.UUID{f.risk.ID}, BumpProbability: bump, ProbabilityStep: 0.2,
+	})
+	require.NoError(t, err)
+	return k
+}
+
+func (f *fixture) push(t *testing.T, id uuid.UUID, v float64, at time.Time) *RecordResult {
+	t.Helper()
+	res, err := f.svc.PushValue(context.Background(), f.tenant, nil, id, ValueInput{Value: v, MeasuredAt: &at})
+	require.NoError(t, err)
+	return res
+}
+
+func TestSave_RejectsUnknownRisk(t *testing.T) {
+	f := newFixture()
+	_, err := f.svc.Save(context.Background(), f.tenant, nil, nil, Input{
+		Name: "x", Source: domain.KRISourcePush, AmberThreshold: 1, RedThreshold: 2,
+		RiskIDs: []uuid.UUID{uuid.New()},
+	})
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+
+	// A risk of another organisation is not linkable either.
+	other := &domain.Risk{ID: uuid.New(), TenantID: uuid.New()}
+	f.risks.rows[other.ID] = other
+	_, err = f.svc.Save(context.Background(), f.tenant, nil, nil, Input{
+		Name: "x", Source: domain.KRISourcePush, AmberThreshold: 1, RedThreshold: 2,
+		RiskIDs: []uuid.UUID{other.ID},
+	})
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+}
+
+func TestPushValue_CrossingIntoRedRaisesLinkedRisk(t *testing.T) {
+	f := newFixture()
+	k := f.pushKRI(t, true)
+
+	res := f.push(t, k.ID, 3, f.now.Add(-2*time.Hour))
+	assert.Nil(t, res.Crossing, "a first green reading is not a crossing")
+	assert.Equal(t, domain.KRIStatusGreen, res.KRI.Status)
+
+	res = f.push(t, k.ID, 7, f.now.Add(-time.Hour))
+	require.NotNil(t, res.Crossing)
+	assert.Equal(t, domain.KRIStatusAmber, res.Crossing.To)
+	assert.Empty(t, res.RaisedRisks, "amber does not raise the risk")
+
+	res = f.push(t, k.ID, 12, f.now)
+	require.NotNil(t, res.Crossing)
+	assert.True(t, res.Crossing.IntoRed())
+	assert.Equal(t, []uuid.UUID{f.risk.ID}, res.RaisedRisks)
+	assert.InDelta(t, 0.7, f.risk.Probability, 1e-9)
+	require.NotNil(t, f.risk.NextReviewAt)
+	assert.True(t, f.risk.NextReviewAt.Equal(f.now))
+	assert.Len(t, f.events.crossings, 2)
+	assert.Equal(t, []uuid.UUID{f.risk.ID}, f.events.updated)
+
+	// Staying red is not a new crossing and does not raise the risk again.
+	res = f.push(t, k.ID, 15, f.now)
+	assert.Nil(t, res.Crossing)
+	assert.InDelta(t, 0.7, f.risk.Probability, 1e-9)
+}
+
+func TestPushValue_WithoutBumpOnlyAlerts(t *testing.T) {
+	f := newFixture()
+	k := f.pushKRI(t, false)
+	res := f.push(t, k.ID, 20, f.now)
+	require.NotNil(t, res.Crossing)
+	assert.Empty(t, res.RaisedRisks)
+	assert.InDelta(t, 0.5, f.risk.Probability, 1e-9)
+	assert.Len(t, f.events.crossings, 1)
+}
+
+func TestPushValue_BackfillDoesNotMoveCurrentState(t *testing.T) {
+	f := newFixture()
+	k := f.pushKRI(t, true)
+	f.push(t, k.ID, 3, f.now)
+
+	res := f.push(t, k.ID, 50, f.now.AddDate(0, 0, -10))
+	assert.Nil(t, res.Crossing)
+	assert.Equal(t, domain.KRIStatusGreen, res.KRI.Status)
+	assert.Equal(t, 3.0, *res.KRI.LastValue)
+	assert.Len(t, f.repo.values, 2, "the backfilled value is still history")
+	assert.Equal(t, domain.KRIStatusRed, f.repo.values[1].Status)
+}
+
+func TestPushValue_RejectsFutureAndQueryKRIs(t *testing.T) {
+	f := newFixture()
+	k := f.pushKRI(t, false)
+	later := f.now.Add(time.Hour)
+	_, err := f.svc.PushValue(context.Background(), f.tenant, nil, k.ID, ValueInput{Value: 1, MeasuredAt: &later})
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+
+	q, err := f.svc.Save(context.Background(), f.tenant, nil, nil, Input{
+		Name: "Open risks", Source: domain.KRISourceQuery, AmberThreshold: 10, RedThreshold: 20,
+		Query: domain.KRIQuery{Subject: domain.KRISubjectRisks, Aggregate: domain.KRIAggCount},
+	})
+	require.NoError(t, err)
+	_, err = f.svc.PushValue(context.Background(), f.tenant, nil, q.ID, ValueInput{Value: 1})
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+
+	_, err = f.svc.PushValue(context.Background(), uuid.New(), nil, k.ID, ValueInput{Value: 1})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "another tenant cannot feed the KRI")
+}
+
+func TestImportCSV(t *testing.T) {
+	f := newFixture()
+	k := f.pushKRI(t, false)
+
+	csv := "date;valeur\n2026-09-01;4,5\n2026-09-15;6\n\n01/09/2026;12\n"
+	res, err := f.svc.ImportCSV(context.Background(), f.tenant, nil, k.ID, strings.NewReader(csv))
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Recorded)
+	// The latest measurement (15 September) is the current value.
+	assert.Equal(t, 6.0, *res.KRI.LastValue)
+	assert.Equal(t, domain.KRIStatusAmber, res.KRI.Status)
+
+	before := len(f.repo.values)
+	_, err = f.svc.ImportCSV(context.Background(), f.tenant, nil, k.ID,
+		strings.NewReader("measured_at,value\n2026-09-20,3\n2026-09-21,lots\n"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+	assert.Contains(t, err.Error(), "line 3")
+	assert.Len(t, f.repo.values, before, "a bad file records nothing")
+
+	_, err = f.svc.ImportCSV(context.Background(), f.tenant, nil, k.ID, strings.NewReader("measured_at,value\n"))
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+}
+
+func TestSweepDue_EvaluatesQueryKRIs(t *testing.T) {
+	f := newFixture()
+	q, err := f.svc.Save(context.Background(), f.tenant, nil, nil, Input{
+		Name: "Critical open risks", Source: domain.KRISourceQuery, AmberThreshold: 2, RedThreshold: 4,
+		Query: domain.KRIQuery{Subject: domain.KRISubjectRisks, Aggregate: domain.KRIAggCount},
+	})
+	require.NoError(t, err)
+	f.pushKRI(t, false)
+
+	f.eval.value = 3
+	n, err := f.svc.SweepDue(context.Background(), f.now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n, "only the query KRI is evaluated")
+	got, _ := f.repo.Get(context.Background(), f.tenant, q.ID)
+	assert.Equal(t, domain.KRIStatusAmber, got.Status)
+
+	n, _ = f.svc.SweepDue(context.Background(), f.now.Add(10*time.Minute))
+	assert.Equal(t, 0, n, "not due again before its cadence")
+
+	f.eval.err = errors.New("relation does not exist")
+	f.now = f.now.Add(2 * time.Hour)
+	_, _ = f.svc.SweepDue(context.Background(), f.now)
+	got, _ = f.repo.Get(context.Background(), f.tenant, q.ID)
+	assert.Contains(t, got.LastError, "relation does not exist")
+	assert.Equal(t, 3.0, *got.LastValue, "a failed run keeps the last good value")
+}
+
+func TestTrends_FiltersByRiskAndOrdersRedFirst(t *testing.T) {
+	f := newFixture()
+	linked := f.pushKRI(t, false)
+	unlinked, err := f.svc.Save(context.Background(), f.tenant, nil, nil, Input{
+		Name: "Phishing clicks", Source: domain.KRISourcePush, AmberThreshold: 1, RedThreshold: 2,
+	})
+	require.NoError(t, err)
+	f.push(t, unlinked.ID, 5, f.now)
+	f.push(t, linked.ID, 1, f.now)
+
+	all, err := f.svc.Trends(context.Background(), f.tenant, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, unlinked.ID, all[0].ID, "red first")
+
+	forRisk, err := f.svc.Trends(context.Background(), f.tenant, &f.risk.ID, 30)
+	require.NoError(t, err)
+	require.Len(t, forRisk, 1)
+	assert.Equal(t, linked.ID, forRisk[0].ID)
+	assert.Len(t, forRisk[0].Values, 1)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	vulnapp "github.com/opendefender/openrisk/internal/application/vulnerability"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/internal/pluginhost"
+	scanpkg "github.com/opendefender/openrisk/internal/scanner"
+)
+
+// IngestAdapter feeds plugin findings through vulnerability ingest, under the
+// "plugin" source and its generic normaliser.
+type IngestAdapter struct{ Ingest *vulnapp.IngestUseCase }
+
+func (a IngestAdapter) IngestFindings(ctx context.Context, tenantID uuid.UUID, findings []map[string]any) (int, error) {
+	res, err := a.Ingest.Execute(ctx, tenantID, vulnapp.IngestInput{Source: domain.VulnSourcePlugin, Findings: findings})
+	if err != nil {
+		return 0, err
+	}
+	return res.Created + res.Updated, nil
+}
+
+// PreviewAdapter stages emitted assets as a scan preview: the same review step
+// a cloud scan goes through, found at /infrastructure/scans/<run id>.
+type PreviewAdapter struct{ Pipeline *scanpkg.Pipeline }
+
+func (a PreviewAdapter) StageAssets(ctx context.Context, tenantID, installID, runID uuid.UUID, actor *uuid.UUID, assets []pluginhost.Asset) error {
+	meta := scanpkg.PreviewMeta{JobID: runID, ConfigID: installID, TenantID: tenantID, Provider: domain.ProviderPlugin}
+	if actor != nil {
+		meta.TriggeredBy = *actor
+	}
+	discoveries := make([]scanpkg.AssetDiscovery, 0, len(assets))
+	for _, as := range assets {
+		if as.ExternalID == "" || as.Name == "" {
+			return fmt.Errorf("emitted asset %q has no external_id or name", as.Name)
+		}
+		d := scanpkg.AssetDiscovery{
+			ExternalID:  as.ExternalID,
+			Name:        as.Name,
+			Type:        domain.AssetType(as.Type),
+			Environment: as.Environment,
+			Tags:        as.Tags,
+			RawMetadata: as.Metadata,
+		}
+		for src, dst := range map[string]**string{as.IP: &d.IP, as.Hostname: &d.Hostname, as.OS: &d.OS} {
+			if src != "" {
+				v := src
+				*dst = &v
+			}
+		}
+		discoveries = append(discoveries, d)
+	}
+	_, err := a.Pipeline.Ingest(ctx, meta, discoveries, nil, nil)
+	return err
+}
@@ -0,0 +1,677 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package plugins manages connector plugins: signed WebAssembly bundles a
+// tenant uploads, installs with explicit grants, and runs. What a hook returns
+// goes through the same pipelines as the built-in connectors — findings
+// through vulnerability ingest, assets into a scan preview for review — so a
+// partner's connector is held to the same rules as ours. The sandbox itself is
+// internal/pluginhost.
+package plugins
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/internal/pluginhost"
+)
+
+// Runtime is the sandbox. *pluginhost.Host satisfies it.
+type Runtime interface {
+	Inspect(ctx context.Context, module []byte) (*pluginhost.ModuleInfo, error)
+	Invoke(ctx context.Context, module []byte, call pluginhost.Call) (*pluginhost.Result, error)
+}
+
+// SecretCipher encrypts granted secrets at rest. The scanner's AES-256-GCM
+// CredentialCipher (SCANNER_CREDENTIAL_KEY) satisfies it.
+type SecretCipher interface {
+	EncryptCredentials(creds map[string]string) (string, error)
+	DecryptCredentials(ciphertext string) (map[string]string, error)
+}
+
+// Findings ingests findings a plugin pulled or transformed and reports how
+// many were created or updated.
+type Findings interface {
+	IngestFindings(ctx context.Context, tenantID uuid.UUID, findings []map[string]any) (int, error)
+}
+
+// Assets stages emitted assets as a scan preview under runID, for a person to
+// import or ignore. Nothing reaches the inventory without that review.
+type Assets interface {
+	StageAssets(ctx context.Context, tenantID, installID, runID uuid.UUID, actor *uuid.UUID, assets []pluginhost.Asset) error
+}
+
+// AuditSink records package and install changes in the audit chain.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// Service is the plugin use cases.
+type Service struct {
+	repo     domain.PluginRepository
+	runtime  Runtime
+	keys     pluginhost.Keyring
+	cipher   SecretCipher
+	findings Findings
+	assets   Assets
+	audit    AuditSink
+	now      func() time.Time
+}
+
+// NewService builds the service. Bundles are accepted only when signed by one
+// of keys.
+func NewService(repo domain.PluginRepository, runtime Runtime, keys pluginhost.Keyring, cipher SecretCipher) *Service {
+	return &Service{repo: repo, runtime: runtime, keys: keys, cipher: cipher, now: time.Now}
+}
+
+// WithFindings wires where pulled and transformed findings go. Without it the
+// pull_findings and transform_webhook hooks are refused.
+func (s *Service) WithFindings(f Findings) *Service {
+	s.findings = f
+	return s
+}
+
+// WithAssets wires where emitted assets are staged. Without it emit_assets is
+// refused.
+func (s *Service) WithAssets(a Assets) *Service {
+	s.assets = a
+	return s
+}
+
+// WithAudit enables audit recording.
+func (s *Service) WithAudit(a AuditSink) *Service {
+	s.audit = a
+	return s
+}
+
+// WithClock overrides the clock (tests).
+func (s *Service) WithClock(now func() time.Time) *Service {
+	s.now = now
+	return s
+}
+
+// =============================================================================
+// Packages
+// =============================================================================
+
+// Upload verifies a bundle and stores it as a package. The signature, the
+// manifest and the module's exports must all agree.
+func (s *Service) Upload(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, data []byte) (*domain.PluginPackage, error) {
+	b, err := pluginhost.ReadBundle(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.keys.Verify(b); err != nil {
+		return nil, err
+	}
+	info, err := s.runtime.Inspect(ctx, b.Module)
+	if err != nil {
+		return nil, err
+	}
+	exported := map[domain.PluginHook]bool{}
+	for _, h := range info.Hooks {
+		exported[h] = true
+	}
+	for _, h := range b.Manifest.Hooks {
+		if !exported[h] {
+			return nil, domain.NewValidationError(fmt.Sprintf("manifest declares %s but the module does not export it", h))
+		}
+	}
+	if existing, err := s.repo.FindPackage(ctx, tenantID, b.Manifest.Name, b.Manifest.Version); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	} else if existing != nil {
+		return nil, conflict(fmt.Sprintf("%s %s is already uploaded; publish a new version", b.Manifest.Name, b.Manifest.Version))
+	}
+
+	hooks := make(domain.StringList, 0, len(b.Manifest.Hooks))
+	for _, h := range b.Manifest.Hooks {
+		hooks = append(hooks, string(h))
+	}
+	p := &domain.PluginPackage{
+		ID:               uuid.New(),
+		TenantID:         tenantID,
+		Name:             b.Manifest.Name,
+		Version:          b.Manifest.Version,
+		Publisher:        b.Manifest.Publisher,
+		Description:      b.Manifest.Description,
+		ABIVersion:       b.Manifest.ABI,
+		Hooks:            hooks,
+		RequestedHosts:   domain.StringList(b.Manifest.Hosts),
+		RequestedSecrets: domain.StringList(b.Manifest.Secrets),
+		KeyID:            b.KeyID,
+		Digest:           b.Digest(),
+		Size:             len(b.Module),
+		Module:           b.Module,
+		UploadedBy:       actor,
+		CreatedAt:        s.now(),
+	}
+	if err := s.repo.CreatePackage(ctx, p); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionCreate, "plugin_package", p.ID,
+		fmt.Sprintf("Uploaded plugin %s %s (%s, key %s)", p.Name, p.Version, p.Publisher, p.KeyID),
+		domain.JSONMap{"digest": p.Digest, "hooks": p.Hooks})
+	return p, nil
+}
+
+// Packages lists the tenant's packages.
+func (s *Service) Packages(ctx context.Context, tenantID uuid.UUID) ([]domain.PluginPackage, error) {
+	rows, err := s.repo.ListPackages(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	return rows, nil
+}
+
+// Package returns one package.
+func (s *Service) Package(ctx context.Context, tenantID, id uuid.UUID) (*domain.PluginPackage, error) {
+	p, err := s.repo.GetPackage(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if p == nil {
+		return nil, domain.NewNotFoundError("plugin", id)
+	}
+	return p, nil
+}
+
+// DeletePackage removes a package nothing is installed from.
+func (s *Service) DeletePackage(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) error {
+	p, err := s.Package(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	n, err := s.repo.CountInstalls(ctx, tenantID, id)
+	if err != nil {
+		return domain.NewInternalError(err.Error())
+	}
+	if n > 0 {
+		return conflict(fmt.Sprintf("%s %s is installed %d time(s); remove the installs first", p.Name, p.Version, n))
+	}
+	if err := s.repo.DeletePackage(ctx, tenantID, id); err != nil {
+		return err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, "plugin_package", id,
+		fmt.Sprintf("Deleted plugin %s %s", p.Name, p.Version), nil)
+	return nil
+}
+
+// =============================================================================
+// Installs
+// =============================================================================
+
+// InstallInput creates or updates an install. On update, nil fields are left
+// alone; a secret set to "" is revoked.
+type InstallInput struct {
+	PackageID    uuid.UUID         `json:"package_id"`
+	Name         *string           `json:"name"`
+	Enabled      *bool             `json:"enabled"`
+	Config       map[string]any    `json:"config"`
+	AllowedHosts []string          `json:"allowed_hosts"`
+	Secrets      map[string]string `json:"secrets"`
+	MemoryPages  *int              `json:"memory_pages"`
+	TimeoutMS    *int              `json:"timeout_ms"`
+}
+
+// Installs lists the tenant's installs with their package.
+func (s *Service) Installs(ctx context.Context, tenantID uuid.UUID) ([]domain.PluginInstall, error) {
+	rows, err := s.repo.ListInstalls(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	pkgs, err := s.repo.ListPackages(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	byID := map[uuid.UUID]*domain.PluginPackage{}
+	for i := range pkgs {
+		byID[pkgs[i].ID] = &pkgs[i]
+	}
+	for i := range rows {
+		rows[i].Package = byID[rows[i].PackageID]
+	}
+	return rows, nil
+}
+
+// Install returns one install with its package (module not loaded into JSON).
+func (s *Service) Install(ctx context.Context, tenantID, id uuid.UUID) (*domain.PluginInstall, error) {
+	in, err := s.repo.GetInstall(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if in == nil {
+		return nil, domain.NewNotFoundError("plugin install", id)
+	}
+	if in.Package, err = s.Package(ctx, tenantID, in.PackageID); err != nil {
+		return nil, err
+	}
+	return in, nil
+}
+
+// CreateInstall installs a package with the given grants.
+func (s *Service) CreateInstall(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, in InstallInput) (*domain.PluginInstall, error) {
+	p, err := s.Package(ctx, tenantID, in.PackageID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	inst := &domain.PluginInstall{
+		ID:           uuid.New(),
+		TenantID:     tenantID,
+		PackageID:    p.ID,
+		Name:         p.Name,
+		Enabled:      true,
+		MemoryPages:  domain.PluginDefaultMemoryPages,
+		TimeoutMS:    domain.PluginDefaultTimeoutMS,
+		AllowedHosts: domain.StringList{},
+		SecretNames:  domain.StringList{},
+		WebhookToken: newWebhookToken(),
+		CreatedBy:    actor,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := s.apply(inst, p, in, map[string]string{}); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveInstall(ctx, inst); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionCreate, "plugin_install", inst.ID,
+		fmt.Sprintf("Installed plugin %s %s as %q", p.Name, p.Version, inst.Name), grantsAudit(inst))
+	inst.Package = p
+	return inst, nil
+}
+
+// UpdateInstall changes an install's grants, limits or configuration.
+func (s *Service) UpdateInstall(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID, in InstallInput) (*domain.PluginInstall, error) {
+	inst, err := s.Install(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := s.secrets(inst)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(inst, inst.Package, in, secrets); err != nil {
+		return nil, err
+	}
+	inst.UpdatedAt = s.now()
+	if err := s.repo.SaveInstall(ctx, inst); err != nil {
+		return nil, err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, "plugin_install", inst.ID,
+		fmt.Sprintf("Updated plugin install %q", inst.Name), grantsAudit(inst))
+	return inst, nil
+}
+
+// DeleteInstall removes an install and its run log.
+func (s *Service) DeleteInstall(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) error {
+	inst, err := s.Install(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteInstall(ctx, tenantID, id); err != nil {
+		return err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, "plugin_install", id,
+		fmt.Sprintf("Removed plugin install %q", inst.Name), nil)
+	return nil
+}
+
+// apply validates the input against what the package requested and writes it
+// onto inst. secrets holds the currently granted values.
+func (s *Service) apply(inst *domain.PluginInstall, p *domain.PluginPackage, in InstallInput, secrets map[string]string) error {
+	if in.Name != nil {
+		name := strings.TrimSpace(*in.Name)
+		if name == "" || len(name) > 120 {
+			return domain.NewValidationError("name is required (at most 120 characters)")
+		}
+		inst.Name = name
+	}
+	if in.Enabled != nil {
+		inst.Enabled = *in.Enabled
+	}
+	if in.Config != nil {
+		raw, err := json.Marshal(in.Config)
+		if err != nil {
+			return domain.NewValidationError("config is not valid JSON")
+		}
+		inst.Config = datatypes.JSON(raw)
+	}
+	if in.AllowedHosts != nil {
+		hosts := domain.StringList{}
+		for _, h := range in.AllowedHosts {
+			h = strings.ToLower(strings.TrimSpace(h))
+			if h == "" {
+				continue
+			}
+			if !granted(h, p.RequestedHosts) {
+				return domain.NewValidationError(fmt.Sprintf("%s %s does not request host %s", p.Name, p.Version, h))
+			}
+			hosts = append(hosts, h)
+		}
+		inst.AllowedHosts = hosts
+	}
+	if in.Secrets != nil {
+		requested := map[string]bool{}
+		for _, n := range p.RequestedSecrets {
+			requested[n] = true
+		}
+		for k, v := range in.Secrets {
+			if !requested[k] {
+				return domain.NewValidationError(fmt.Sprintf("%s %s does not request secret %s", p.Name, p.Version, k))
+			}
+			if v == "" {
+				delete(secrets, k)
+			} else {
+				secrets[k] = v
+			}
+		}
+		if s.cipher == nil && len(secrets) > 0 {
+			return domain.NewInternalError("secret storage is not configured")
+		}
+		names := domain.StringList{}
+		for k := range secrets {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		inst.SecretNames = names
+		inst.EncryptedSecrets = ""
+		if len(secrets) > 0 {
+			enc, err := s.cipher.EncryptCredentials(secrets)
+			if err != nil {
+				return domain.NewInternalError("failed to encrypt secrets")
+			}
+			inst.EncryptedSecrets = enc
+		}
+	}
+	if in.MemoryPages != nil {
+		if *in.MemoryPages < 1 || *in.MemoryPages > domain.PluginMaxMemoryPages {
+			return domain.NewValidationError(fmt.Sprintf("memory_pages must be between 1 and %d", domain.PluginMaxMemoryPages))
+		}
+		inst.MemoryPages = *in.MemoryPages
+	}
+	if in.TimeoutMS != nil {
+		if *in.TimeoutMS < 100 || *in.TimeoutMS > domain.PluginMaxTimeoutMS {
+			return domain.NewValidationError(fmt.Sprintf("timeout_ms must be between 100 and %d", domain.PluginMaxTimeoutMS))
+		}
+		inst.TimeoutMS = *in.TimeoutMS
+	}
+	return nil
+}
+
+// granted reports whether host is covered by what the package requested: the
+// same name, or a subdomain of a requested wildcard. A wildcard can only be
+// granted when it was requested as such.
+func granted(host string, requested []string) bool {
+	for _, r := range requested {
+		if strings.EqualFold(host, r) {
+			return true
+		}
+	}
+	return !strings.HasPrefix(host, "*.") && pluginhost.HostAllowed(host, requested)
+}
+
+func (s *Service) secrets(inst *domain.PluginInstall) (map[string]string, error) {
+	if inst.EncryptedSecrets == "" || s.cipher == nil {
+		return map[string]string{}, nil
+	}
+	m, err := s.cipher.DecryptCredentials(inst.EncryptedSecrets)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to decrypt plugin secrets")
+	}
+	return m, nil
+}
+
+// =============================================================================
+// Runs
+// =============================================================================
+
+// RunInput asks for one hook to run. Ticket is the create_ticket payload.
+type RunInput struct {
+	Hook   domain.PluginHook  `json:"hook"`
+	Ticket *pluginhost.Ticket `json:"ticket,omitempty"`
+}
+
+// RunResult is a finished run with what it produced.
+type RunResult struct {
+	Run *domain.PluginRun `json:"run"`
+	// PreviewJobID is the scan preview emitted assets were staged under.
+	PreviewJobID *uuid.UUID               `json:"preview_job_id,omitempty"`
+	Ticket       *pluginhost.TicketOutput `json:"ticket,omitempty"`
+}
+
+// Runs lists an install's recent runs.
+func (s *Service) Runs(ctx context.Context, tenantID, installID uuid.UUID) ([]domain.PluginRun, error) {
+	if _, err := s.Install(ctx, tenantID, installID); err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.ListRuns(ctx, tenantID, installID, 50)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	return rows, nil
+}
+
+// Run invokes a hook on demand. transform_webhook only runs on a push.
+func (s *Service) Run(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, installID uuid.UUID, in RunInput) (*RunResult, error) {
+	inst, err := s.Install(ctx, tenantID, installID)
+	if err != nil {
+		return nil, err
+	}
+	switch in.Hook {
+	case domain.HookPullFindings, domain.HookEmitAssets:
+		return s.run(ctx, inst, actor, in.Hook, pluginhost.PullInput{Config: config(inst)})
+	case domain.HookCreateTicket:
+		if in.Ticket == nil || strings.TrimSpace(in.Ticket.Summary) == "" {
+			return nil, domain.NewValidationError("ticket.summary is required")
+		}
+		return s.run(ctx, inst, actor, in.Hook, pluginhost.TicketInput{Config: config(inst), Ticket: *in.Ticket})
+	case domain.HookTransformWebhook:
+		return nil, domain.NewValidationError("transform_webhook runs when the tool posts to the install's webhook")
+	}
+	return nil, domain.NewValidationError(fmt.Sprintf("unknown hook %q", in.Hook))
+}
+
+// Receive runs transform_webhook for a push authenticated by an install's
+// webhook token. It returns (nil, nil) when the token matches no enabled
+// install, so the caller can answer uniformly.
+func (s *Service) Receive(ctx context.Context, token string, headers map[string]string, body []byte) (*RunResult, error) {
+	found, err := s.repo.GetInstallByWebhookToken(ctx, token)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if found == nil || !found.Enabled {
+		return nil, nil
+	}
+	inst, err := s.Install(ctx, found.TenantID, found.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.run(ctx, inst, nil, domain.HookTransformWebhook,
+		pluginhost.WebhookInput{Config: config(inst), Headers: headers, Body: string(body)})
+}
+
+// run invokes hook, hands its output to the matching pipeline and records the
+// run whatever happens. A failed run is returned as a result, not an error:
+// the failure is the plugin's, and it is recorded.
+func (s *Service) run(ctx context.Context, inst *domain.PluginInstall, actor *uuid.UUID, hook domain.PluginHook, input any) (*RunResult, error) {
+	if !inst.Enabled {
+		return nil, conflict(fmt.Sprintf("plugin install %q is disabled", inst.Name))
+	}
+	if !inst.Package.HasHook(hook) {
+		return nil, domain.NewValidationError(fmt.Sprintf("%s does not implement %s", inst.Package.Name, hook))
+	}
+	if ((hook == domain.HookPullFindings || hook == domain.HookTransformWebhook) && s.findings == nil) ||
+		(hook == domain.HookEmitAssets && s.assets == nil) {
+		return nil, domain.NewInternalError(fmt.Sprintf("%s is not available on this server", hook))
+	}
+	secrets, err := s.secrets(inst)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+
+	run := &domain.PluginRun{
+		ID:          uuid.New(),
+		TenantID:    inst.TenantID,
+		InstallID:   inst.ID,
+		Hook:        hook,
+		Status:      domain.PluginRunOK,
+		Logs:        domain.StringList{},
+		TriggeredBy: actor,
+		CreatedAt:   s.now(),
+	}
+	out := &RunResult{Run: run}
+	res, err := s.runtime.Invoke(ctx, inst.Package.Module, pluginhost.Call{
+		Hook:   hook,
+		Input:  raw,
+		Grants: pluginhost.Grants{AllowedHosts: inst.AllowedHosts, Secrets: secrets},
+		Limits: pluginhost.Limits{
+			MemoryPages: uint32(inst.MemoryPages),
+			Timeout:     time.Duration(inst.TimeoutMS) * time.Millisecond,
+		},
+	})
+	if res != nil {
+		run.DurationMS, run.HTTPCalls = res.Duration.Milliseconds(), res.HTTPCalls
+		run.Logs = append(run.Logs, res.Logs...)
+	}
+	if err == nil {
+		err = s.deliver(ctx, inst, actor, hook, res.Output, out)
+	}
+	if err != nil {
+		run.Status, run.Error = domain.PluginRunFailed, truncate(err.Error(), 2000)
+	}
+	if rerr := s.repo.RecordRun(ctx, run); rerr != nil {
+		return nil, domain.NewInternalError(rerr.Error())
+	}
+	return out, nil
+}
+
+// deliver decodes a hook's output and hands it on.
+func (s *Service) deliver(ctx context.Context, inst *domain.PluginInstall, actor *uuid.UUID, hook domain.PluginHook, output []byte, out *RunResult) error {
+	switch hook {
+	case domain.HookPullFindings, domain.HookTransformWebhook:
+		var f pluginhost.FindingsOutput
+		if err := decode(output, &f, &f.Error); err != nil {
+			return err
+		}
+		out.Run.Items = len(f.Findings)
+		if len(f.Findings) == 0 {
+			return nil
+		}
+		_, err := s.findings.IngestFindings(ctx, inst.TenantID, f.Findings)
+		return err
+	case domain.HookEmitAssets:
+		var a pluginhost.AssetsOutput
+		if err := decode(output, &a, &a.Error); err != nil {
+			return err
+		}
+		out.Run.Items = len(a.Assets)
+		if len(a.Assets) == 0 {
+			return nil
+		}
+		if err := s.assets.StageAssets(ctx, inst.TenantID, inst.ID, out.Run.ID, actor, a.Assets); err != nil {
+			return err
+		}
+		out.PreviewJobID = &out.Run.ID
+	case domain.HookCreateTicket:
+		var t pluginhost.TicketOutput
+		if err := decode(output, &t, &t.Error); err != nil {
+			return err
+		}
+		if t.Key == "" {
+			return errors.New("plugin returned no ticket key")
+		}
+		out.Run.Items = 1
+		out.Ticket = &t
+	}
+	return nil
+}
+
+// decode reads a hook output; a non-empty error member fails the run.
+func decode(output []byte, into any, errField *string) error {
+	if len(output) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(output, into); err != nil {
+		return fmt.Errorf("plugin returned invalid JSON: %w", err)
+	}
+	if *errField != "" {
+		return errors.New(*errField)
+	}
+	return nil
+}
+
+func config(inst *domain.PluginInstall) map[string]any {
+	m := map[string]any{}
+	if len(inst.Config) > 0 {
+		_ = json.Unmarshal(inst.Config, &m)
+	}
+	return m
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+func newWebhookToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return strings.ReplaceAll(uuid.NewString()+uuid.NewString(), "-", "")
+	}
+	return hex.EncodeToString(b)
+}
+
+func grantsAudit(inst *domain.PluginInstall) domain.JSONMap {
+	return domain.JSONMap{
+		"enabled":       inst.Enabled,
+		"allowed_hosts": inst.AllowedHosts,
+		"secret_names":  inst.SecretNames,
+		"memory_pages":  inst.MemoryPages,
+		"timeout_ms":    inst.TimeoutMS,
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
+
+func (s *Service) record(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, action domain.AuditAction, entity string, id uuid.UUID, summary string, after domain.JSONMap) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    actor,
+		Action:     action,
+		EntityType: entity,
+		EntityID:   id.String(),
+		Summary:    summary,
+		After:      after,
+	})
+}
+
+func conflict(msg string) error {
+	return &domain.AppError{Err: domain.ErrConflict, Code: http.StatusConflict, Message: msg}
+}
@@ -0,0 +1,331 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package plugins
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/internal/pluginhost"
+)
+
+type memPlugins struct {
+	packages map[uuid.UUID]domain.PluginPackage
+	installs map[uuid.UUID]domain.PluginInstall
+	runs     []domain.PluginRun
+}
+
+func newMemPlugins() *memPlugins {
+	return &memPlugins{packages: map[uuid.UUID]domain.PluginPackage{}, installs: map[uuid.UUID]domain.PluginInstall{}}
+}
+
+func (m *memPlugins) ListPackages(_ context.Context, tenantID uuid.UUID) ([]domain.PluginPackage, error) {
+	var out []domain.PluginPackage
+	for _, p := range m.packages {
+		if p.TenantID == tenantID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+func (m *memPlugins) GetPackage(_ context.Context, tenantID, id uuid.UUID) (*domain.PluginPackage, error) {
+	if p, ok := m.packages[id]; ok && p.TenantID == tenantID {
+		return &p, nil
+	}
+	return nil, nil
+}
+func (m *memPlugins) FindPackage(_ context.Context, tenantID uuid.UUID, name, version string) (*domain.PluginPackage, error) {
+	for _, p := range m.packages {
+		if p.TenantID == tenantID && p.Name == name && p.Version == version {
+			return &p, nil
+		}
+	}
+	return nil, nil
+}
+func (m *memPlugins) CreatePackage(_ context.Context, p *domain.PluginPackage) error {
+	m.packages[p.ID] = *p
+	return nil
+}
+func (m *memPlugins) DeletePackage(_ context.Context, _, id uuid.UUID) error {
+	delete(m.packages, id)
+	return nil
+}
+func (m *memPlugins) ListInstalls(_ context.Context, tenantID uuid.UUID) ([]domain.PluginInstall, error) {
+	var out []domain.PluginInstall
+	for _, in := range m.installs {
+		if in.TenantID == tenantID {
+			out = append(out, in)
+		}
+	}
+	return out, nil
+}
+func (m *memPlugins) GetInstall(_ context.Context, tenantID, id uuid.UUID) (*domain.PluginInstall, error) {
+	if in, ok := m.installs[id]; ok && in.TenantID == tenantID {
+		return &in, nil
+	}
+	return nil, nil
+}
+func (m *memPlugins) GetInstallByWebhookToken(_ context.Context, token string) (*domain.PluginInstall, error) {
+	for _, in := range m.installs {
+		if token != "" && in.WebhookToken == token {
+			return &in, nil
+		}
+	}
+	return nil, nil
+}
+func (m *memPlugins) CountInstalls(_ context.Context, tenantID, packageID uuid.UUID) (int64, error) {
+	var n int64
+	for _, in := range m.installs {
+		if in.TenantID == tenantID && in.PackageID == packageID {
+			n++
+		}
+	}
+	return n, nil
+}
+func (m *memPlugins) SaveInstall(_ context.Context, in *domain.PluginInstall) error {
+	cp := *in
+	cp.Package = nil
+	m.installs[in.ID] = cp
+	return nil
+}
+func (m *memPlugins) DeleteInstall(_ context.Context, _, id uuid.UUID) error {
+	delete(m.installs, id)
+	return nil
+}
+func (m *memPlugins) RecordRun(_ context.Context, r *domain.PluginRun) error {
+	m.runs = append(m.runs, *r)
+	return nil
+}
+func (m *memPlugins) ListRuns(_ context.Context, tenantID, installID uuid.UUID, _ int) ([]domain.PluginRun, error) {
+	var out []domain.PluginRun
+	for _, r := range m.runs {
+		if r.TenantID == tenantID && r.InstallID == installID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// fakeRuntime exports the hooks it is given and answers every call with
+// output, remembering the last call.
+type fakeRuntime struct {
+	hooks  []domain.PluginHook
+	output string
+	err    error
+	last   pluginhost.Call
+}
+
+func (f *fakeRuntime) Inspect(context.Context, []byte) (*pluginhost.ModuleInfo, error) {
+	return &pluginhost.ModuleInfo{ABI: pluginhost.ABIVersion, Hooks: f.hooks}, nil
+}
+func (f *fakeRuntime) Invoke(_ context.Context, _ []byte, call pluginhost.Call) (*pluginhost.Result, error) {
+	f.last = call
+	if f.err != nil {
+		return &pluginhost.Result{Logs: []string{"boom"}}, f.err
+	}
+	return &pluginhost.Result{Output: []byte(f.output), HTTPCalls: 1}, nil
+}
+
+// plainCipher stands in for AES-GCM; the service only needs the round trip.
+type plainCipher struct{}
+
+func (plainCipher) EncryptCredentials(m map[string]string) (string, error) {
+	b, err := json.Marshal(m)
+	return "enc:" + string(b), err
+}
+func (plainCipher) DecryptCredentials(s string) (map[string]string, error) {
+	m := map[string]string{}
+	err := json.Unmarshal([]byte(s[len("enc:"):]), &m)
+	return m, err
+}
+
+type sinks struct {
+	findings [][]map[string]any
+	assets   [][]pluginhost.Asset
+	runIDs   []uuid.UUID
+}
+
+func (s *sinks) IngestFindings(_ context.Context, _ uuid.UUID, f []map[string]any) (int, error) {
+	s.findings = append(s.findings, f)
+	return len(f), nil
+}
+func (s *sinks) StageAssets(_ context.Context, _, _, runID uuid.UUID, _ *uuid.UUID, a []pluginhost.Asset) error {
+	s.assets = append(s.assets, a)
+	s.runIDs = append(s.runIDs, runID)
+	return nil
+}
+
+var allHooks = []domain.PluginHook{domain.HookPullFindings, domain.HookEmitAssets, domain.HookCreateTicket, domain.HookTransformWebhook}
+
+func fixture(t *testing.T, rt *fakeRuntime) (*Service, *memPlugins, *sinks, func(m pluginhost.Manifest) []byte) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	repo := newMemPlugins()
+	sk := &sinks{}
+	svc := NewService(repo, rt, pluginhost.Keyring{"acme": pub}, plainCipher{}).WithFindings(sk).WithAssets(sk)
+	sign := func(m pluginhost.Manifest) []byte {
+		data, err := pluginhost.WriteBundle(m, []byte("\x00asm\x01\x00\x00\x00"), "acme", priv)
+		require.NoError(t, err)
+		return data
+	}
+	return svc, repo, sk, sign
+}
+
+func manifest(hooks ...domain.PluginHook) pluginhost.Manifest {
+	return pluginhost.Manifest{Name: "acme-scanner", Version: "1.0.0", Publisher: "Acme", Hooks: hooks,
+		Hosts: []string{"api.acme.test", "*.acme.cloud"}, Secrets: []string{"api_key"}}
+}
+
+func TestUpload_VerifiesSignatureExportsAndUniqueness(t *testing.T) {
+	ctx, tenant := context.Background(), uuid.New()
+	rt := &fakeRuntime{hooks: []domain.PluginHook{domain.HookPullFindings}}
+	svc, _, _, sign := fixture(t, rt)
+
+	p, err := svc.Upload(ctx, tenant, nil, sign(manifest(domain.HookPullFindings)))
+	require.NoError(t, err)
+	assert.Equal(t, "acme-scanner", p.Name)
+	assert.Equal(t, "acme", p.KeyID)
+	assert.Len(t, p.Digest, 64)
+
+	_, err = svc.Upload(ctx, tenant, nil, sign(manifest(domain.HookPullFindings)))
+	assert.True(t, errors.Is(err, domain.ErrConflict), "the same name and version twice")
+
+	m := manifest(domain.HookPullFindings, domain.HookCreateTicket)
+	m.Version = "1.1.0"
+	_, err = svc.Upload(ctx, tenant, nil, sign(m))
+	assert.ErrorContains(t, err, "does not export", "a hook declared but not exported")
+
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	m.Version, m.Hooks = "1.2.0", []domain.PluginHook{domain.HookPullFindings}
+	forged, err := pluginhost.WriteBundle(m, []byte("\x00asm\x01\x00\x00\x00"), "acme", priv)
+	require.NoError(t, err)
+	_, err = svc.Upload(ctx, tenant, nil, forged)
+	assert.Error(t, err, "a bundle signed with an untrusted key")
+}
+
+func TestInstall_GrantsAreASubsetOfTheRequest(t *testing.T) {
+	ctx, tenant := context.Background(), uuid.New()
+	svc, repo, _, sign := fixture(t, &fakeRuntime{hooks: allHooks})
+	p, err := svc.Upload(ctx, tenant, nil, sign(manifest(domain.HookPullFindings)))
+	require.NoError(t, err)
+
+	_, err = svc.CreateInstall(ctx, tenant, nil, InstallInput{PackageID: p.ID, AllowedHosts: []string{"evil.test"}})
+	assert.ErrorContains(t, err, "does not request host")
+	_, err = svc.CreateInstall(ctx, tenant, nil, InstallInput{PackageID: p.ID, AllowedHosts: []string{"*.test"}})
+	assert.Error(t, err, "a wildcard broader than the request")
+	_, err = svc.CreateInstall(ctx, tenant, nil, InstallInput{PackageID: p.ID, Secrets: map[string]string{"db_password": "x"}})
+	assert.ErrorContains(t, err, "does not request secret")
+	big := domain.PluginMaxMemoryPages + 1
+	_, err = svc.CreateInstall(ctx, tenant, nil, InstallInput{PackageID: p.ID, MemoryPages: &big})
+	assert.Error(t, err)
+
+	inst, err := svc.CreateInstall(ctx, tenant, nil, InstallInput{PackageID: p.ID,
+		AllowedHosts: []string{"API.acme.test", "eu.acme.cloud"}, Secrets: map[string]string{"api_key": "s3cret"}})
+	require.NoError(t, err)
+	assert.Equal(t, domain.StringList{"api.acme.test", "eu.acme.cloud"}, inst.AllowedHosts)
+	assert.Equal(t, domain.StringList{"api_key"}, inst.SecretNames)
+	assert.NotEmpty(t, inst.WebhookToken)
+
+	_, err = svc.UpdateInstall(ctx, tenant, nil, inst.ID, InstallInput{Secrets: map[string]string{"api_key": ""}})
+	require.NoError(t, err)
+	assert.Empty(t, repo.installs[inst.ID].SecretNames, "an empty value revokes the secret")
+	assert.Empty(t, repo.installs[inst.ID].EncryptedSecrets)
+
+	assert.True(t, errors.Is(svc.DeletePackage(ctx, tenant, nil, p.ID), domain.ErrConflict), "a package still installed")
+	_, err = svc.Install(ctx, uuid.New(), inst.ID)
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "another tenant's install")
+}
+
+func TestRun_DeliversOutputAndRecordsEveryRun(t *testing.T) {
+	ctx, tenant, actor := context.Background(), uuid.New(), uuid.New()
+	rt := &fakeRuntime{hooks: allHooks}
+	svc, repo, sk, sign := fixture(t, rt)
+	p, err := svc.Upload(ctx, tenant, nil, sign(manifest(allHooks...)))
+	require.NoError(t, err)
+	inst, err := svc.CreateInstall(ctx, tenant, nil, InstallInput{PackageID: p.ID,
+		AllowedHosts: []string{"api.acme.test"}, Secrets: map[string]string{"api_key": "s3cret"},
+		Config: map[string]any{"region": "eu"}})
+	require.NoError(t, err)
+
+	rt.output = `{"findings":[{"title":"CVE-1"},{"title":"CVE-2"}]}`
+	res, err := svc.Run(ctx, tenant, &actor, inst.ID, RunInput{Hook: domain.HookPullFindings})
+	require.NoError(t, err)
+	assert.Equal(t, domain.PluginRunOK, res.Run.Status)
+	assert.Equal(t, 2, res.Run.Items)
+	require.Len(t, sk.findings, 1)
+	assert.Equal(t, "s3cret", rt.last.Grants.Secrets["api_key"], "granted secrets reach the sandbox")
+	assert.Equal(t, []string{"api.acme.test"}, rt.last.Grants.AllowedHosts)
+	assert.JSONEq(t, `{"config":{"region":"eu"}}`, string(rt.last.Input))
+
+	rt.output = `{"assets":[{"external_id":"i-1","name":"web-1","type":"vm"}]}`
+	res, err = svc.Run(ctx, tenant, &actor, inst.ID, RunInput{Hook: domain.HookEmitAssets})
+	require.NoError(t, err)
+	require.NotNil(t, res.PreviewJobID)
+	assert.Equal(t, res.Run.ID, *res.PreviewJobID, "assets are staged under the run for review")
+	assert.Equal(t, []uuid.UUID{res.Run.ID}, sk.runIDs)
+
+	_, err = svc.Run(ctx, tenant, &actor, inst.ID, RunInput{Hook: domain.HookCreateTicket})
+	assert.Error(t, err, "a ticket needs a summary")
+	rt.output = `{"key":"SEC-42","url":"https://acme.test/SEC-42"}`
+	res, err = svc.Run(ctx, tenant, &actor, inst.ID, RunInput{Hook: domain.HookCreateTicket, Ticket: &pluginhost.Ticket{Summary: "Patch web-1"}})
+	require.NoError(t, err)
+	assert.Equal(t, "SEC-42", res.Ticket.Key)
+
+	rt.output = `{"error":"401 from upstream"}`
+	res, err = svc.Run(ctx, tenant, &actor, inst.ID, RunInput{Hook: domain.HookPullFindings})
+	require.NoError(t, err, "a plugin failure is a failed run, not a request error")
+	assert.Equal(t, domain.PluginRunFailed, res.Run.Status)
+	assert.Equal(t, "401 from upstream", res.Run.Error)
+
+	rt.err = pluginhost.ErrTimeout
+	res, err = svc.Run(ctx, tenant, &actor, inst.ID, RunInput{Hook: domain.HookPullFindings})
+	require.NoError(t, err)
+	assert.Equal(t, domain.PluginRunFailed, res.Run.Status)
+	assert.Equal(t, []string{"boom"}, []string(res.Run.Logs))
+
+	assert.Len(t, repo.runs, 5, "every invocation is recorded")
+	assert.Equal(t, domain.PluginRunFailed, repo.runs[4].Status)
+
+	off := false
+	_, err = svc.UpdateInstall(ctx, tenant, nil, inst.ID, InstallInput{Enabled: &off})
+	require.NoError(t, err)
+	_, err = svc.Run(ctx, tenant, &actor, inst.ID, RunInput{Hook: domain.HookPullFindings})
+	assert.True(t, errors.Is(err, domain.ErrConflict), "a disabled install does not run")
+}
+
+func TestReceive_RunsTransformForAKnownToken(t *testing.T) {
+	ctx, tenant := context.Background(), uuid.New()
+	rt := &fakeRuntime{hooks: allHooks, output: `{"findings":[{"title":"pushed"}]}`}
+	svc, _, sk, sign := fixture(t, rt)
+	p, err := svc.Upload(ctx, tenant, nil, sign(manifest(allHooks...)))
+	require.NoError(t, err)
+	inst, err := svc.CreateInstall(ctx, tenant, nil, InstallInput{PackageID: p.ID})
+	require.NoError(t, err)
+
+	res, err := svc.Receive(ctx, "nope", nil, []byte(`{}`))
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = svc.Receive(ctx, inst.WebhookToken, map[string]string{"X-Event": "scan"}, []byte(`{"id":1}`))
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, domain.HookTransformWebhook, res.Run.Hook)
+	assert.Len(t, sk.findings, 1)
+	assert.JSONEq(t, `{"config":{},"headers":{"X-Event":"scan"},"body":"{\"id\":1}"}`, string(rt.last.Input))
+
+	_, err = svc.Run(ctx, tenant, nil, inst.ID, RunInput{Hook: domain.HookTransformWebhook})
+	assert.Error(t, err, "transform_webhook only runs on a push")
+}
@@ -0,0 +1,813 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package programme manages mitigation programmes: projects shared by many
+// risks and controls, their work breakdown with effort, assignees and
+// dependencies, the schedule and critical path computed from it, and the
+// residual each linked risk can expect given how the programme is tracking.
+package programme
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// AuditSink records programme changes in the audit chain.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// Service is mitigation programmes' use cases.
+type Service struct {
+	repo  domain.MitigationProgrammeRepository
+	audit AuditSink
+	now   func() time.Time
+}
+
+// NewService builds the service.
+func NewService(repo domain.MitigationProgrammeRepository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// WithAudit enables audit recording.
+func (s *Service) WithAudit(a AuditSink) *Service {
+	s.audit = a
+	return s
+}
+
+// WithClock overrides the clock (tests).
+func (s *Service) WithClock(now func() time.Time) *Service {
+	s.now = now
+	return s
+}
+
+// =============================================================================
+// Programmes
+// =============================================================================
+
+// ProgrammeInput is the body of POST /mitigation-programmes and
+// PUT /mitigation-programmes/:id. StartDate defaults to today.
+type ProgrammeInput struct {
+	Title       string                  `json:"title"`
+	Description string                  `json:"description"`
+	Status      domain.MitigationStatus `json:"status"`
+	OwnerID     *uuid.UUID              `json:"owner_id"`
+	StartDate   *time.Time              `json:"start_date"`
+	TargetDate  *time.Time              `json:"target_date"`
+}
+
+// LinkedRisk is a risk a programme treats, as the programme page shows it.
+type LinkedRisk struct {
+	RiskID            uuid.UUID `json:"risk_id"`
+	Title             string    `json:"title"`
+	Score             float64   `json:"score"`
+	Criticality       string    `json:"criticality"`
+	ExpectedReduction float64   `json:"expected_reduction"`
+}
+
+// LinkedControl is a control a programme implements or strengthens.
+type LinkedControl struct {
+	ControlID     uuid.UUID `json:"control_id"`
+	ReferenceCode string    `json:"reference_code"`
+	Name          string    `json:"name"`
+}
+
+// Detail is everything GET /mitigation-programmes/:id shows.
+type Detail struct {
+	Programme *domain.MitigationProgramme `json:"programme"`
+	Risks     []LinkedRisk                `json:"risks"`
+	Controls  []LinkedControl             `json:"controls"`
+	Actions   []domain.ProgrammeAction    `json:"actions"`
+	Schedule  *domain.ProgrammeSchedule   `json:"schedule"`
+}
+
+// List returns the tenant's programmes with their counts, progress and
+// projected end.
+func (s *Service) List(ctx context.Context, tenantID uuid.UUID) ([]domain.MitigationProgramme, error) {
+	programmes, err := s.repo.ListProgrammes(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if len(programmes) == 0 {
+		return []domain.MitigationProgramme{}, nil
+	}
+	ids := make([]uuid.UUID, len(programmes))
+	for i, p := range programmes {
+		ids[i] = p.ID
+	}
+	risks, err := s.repo.RiskLinks(ctx, tenantID, ids)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	controls, err := s.repo.ControlLinks(ctx, tenantID, ids)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	schedules, err := s.schedules(ctx, tenantID, programmes)
+	if err != nil {
+		return nil, err
+	}
+	riskCount, controlCount := map[uuid.UUID]int{}, map[uuid.UUID]int{}
+	for _, l := range risks {
+		riskCount[l.ProgrammeID]++
+	}
+	for _, l := range controls {
+		controlCount[l.ProgrammeID]++
+	}
+	for i := range programmes {
+		p := &programmes[i]
+		p.RiskCount = riskCount[p.ID]
+		p.ControlCount = controlCount[p.ID]
+		applySchedule(p, schedules[p.ID])
+	}
+	return programmes, nil
+}
+
+// Get returns a programme with its links, actions and schedule.
+func (s *Service) Get(ctx context.Context, tenantID, id uuid.UUID) (*Detail, error) {
+	p, err := s.programme(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	ids := []uuid.UUID{id}
+	links, err := s.repo.RiskLinks(ctx, tenantID, ids)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	riskIDs := make([]uuid.UUID, len(links))
+	for i, l := range links {
+		riskIDs[i] = l.RiskID
+	}
+	risks, err := s.risksByID(ctx, tenantID, riskIDs)
+	if err != nil {
+		return nil, err
+	}
+	d := &Detail{Programme: p, Risks: []LinkedRisk{}, Controls: []LinkedControl{}}
+	for _, l := range links {
+		r, ok := risks[l.RiskID]
+		if !ok {
+			continue // soft-deleted since it was linked
+		}
+		d.Risks = append(d.Risks, LinkedRisk{
+			RiskID: r.ID, Title: riskTitle(r), Score: r.Score,
+			Criticality: string(r.Criticality), ExpectedReduction: l.ExpectedReduction,
+		})
+	}
+	sort.SliceStable(d.Risks, func(i, j int) bool { return d.Risks[i].Score > d.Risks[j].Score })
+
+	controls, err := s.repo.ControlLinks(ctx, tenantID, ids)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	for _, l := range controls {
+		c, err := s.repo.GetControl(ctx, tenantID, l.ControlID)
+		if err != nil {
+			return nil, domain.NewInternalError(err.Error())
+		}
+		if c == nil {
+			continue
+		}
+		d.Controls = append(d.Controls, LinkedControl{ControlID: c.ID, ReferenceCode: c.ReferenceCode, Name: c.Name})
+	}
+	sort.SliceStable(d.Controls, func(i, j int) bool { return d.Controls[i].ReferenceCode < d.Controls[j].ReferenceCode })
+
+	actions, err := s.repo.Actions(ctx, tenantID, ids)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	d.Actions = nonNilActions(actions)
+	schedules, err := s.schedules(ctx, tenantID, []domain.MitigationProgramme{*p})
+	if err != nil {
+		return nil, err
+	}
+	d.Schedule = schedules[p.ID]
+	p.RiskCount, p.ControlCount = len(d.Risks), len(d.Controls)
+	applySchedule(p, d.Schedule)
+	return d, nil
+}
+
+// Create starts a programme.
+func (s *Service) Create(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, in ProgrammeInput) (*domain.MitigationProgramme, error) {
+	p := &domain.MitigationProgramme{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		StartDate: s.now(),
+		CreatedBy: actor,
+	}
+	if err := s.save(ctx, p, in); err != nil {
+		return nil, err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionCreate, p.ID, "Mitigation programme created", domain.JSONMap{
+		"title": p.Title, "status": string(p.Status),
+	})
+	return p, nil
+}
+
+// Update edits a programme. Moving the target date re-projects every linked
+// risk on the next read; nothing is stored on the risks.
+func (s *Service) Update(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID, in ProgrammeInput) (*domain.MitigationProgramme, error) {
+	p, err := s.programme(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	before := domain.JSONMap{"status": string(p.Status), "target_date": p.TargetDate}
+	if err := s.save(ctx, p, in); err != nil {
+		return nil, err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, p.ID, "Mitigation programme updated", domain.JSONMap{
+		"before": before, "status": string(p.Status), "target_date": p.TargetDate,
+	})
+	return p, nil
+}
+
+func (s *Service) save(ctx context.Context, p *domain.MitigationProgramme, in ProgrammeInput) error {
+	p.Title = in.Title
+	p.Description = in.Description
+	p.Status = in.Status
+	p.OwnerID = in.OwnerID
+	if in.StartDate != nil {
+		p.StartDate = *in.StartDate
+	}
+	p.TargetDate = in.TargetDate
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if err := s.repo.SaveProgramme(ctx, p); err != nil {
+		return domain.NewInternalError(err.Error())
+	}
+	return nil
+}
+
+// Delete removes a programme with its links and work breakdown. The risks and
+// controls it treated are untouched.
+func (s *Service) Delete(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) error {
+	p, err := s.programme(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteProgramme(ctx, tenantID, id); err != nil {
+		return err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, id, "Mitigation programme deleted", domain.JSONMap{"title": p.Title})
+	return nil
+}
+
+// =============================================================================
+// Links
+// =============================================================================
+
+// RiskLinkInput is the body of POST /mitigation-programmes/:id/risks.
+type RiskLinkInput struct {
+	RiskID            uuid.UUID `json:"risk_id"`
+	ExpectedReduction float64   `json:"expected_reduction"`
+}
+
+// LinkRisk links a risk to the programme, or updates the reduction it
+// expects from it.
+func (s *Service) LinkRisk(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, programmeID uuid.UUID, in RiskLinkInput) (*domain.ProgrammeRiskLink, error) {
+	if _, err := s.programme(ctx, tenantID, programmeID); err != nil {
+		return nil, err
+	}
+	risks, err := s.risksByID(ctx, tenantID, []uuid.UUID{in.RiskID})
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := risks[in.RiskID]; !ok {
+		return nil, domain.NewNotFoundError("risk", in.RiskID)
+	}
+	l := &domain.ProgrammeRiskLink{
+		TenantID:          tenantID,
+		ProgrammeID:       programmeID,
+		RiskID:            in.RiskID,
+		ExpectedReduction: in.ExpectedReduction,
+		CreatedAt:         s.now(),
+	}
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveRiskLink(ctx, l); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, programmeID, "Risk linked to mitigation programme", domain.JSONMap{
+		"risk_id": in.RiskID.String(), "expected_reduction": l.ExpectedReduction,
+	})
+	return l, nil
+}
+
+// UnlinkRisk removes a risk from the programme.
+func (s *Service) UnlinkRisk(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, programmeID, riskID uuid.UUID) error {
+	if _, err := s.programme(ctx, tenantID, programmeID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteRiskLink(ctx, tenantID, programmeID, riskID); err != nil {
+		return err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, programmeID, "Risk unlinked from mitigation programme", domain.JSONMap{
+		"risk_id": riskID.String(),
+	})
+	return nil
+}
+
+// ControlLinkInput is the body of POST /mitigation-programmes/:id/controls.
+type ControlLinkInput struct {
+	ControlID uuid.UUID `json:"control_id"`
+}
+
+// LinkControl links a compliance control to the programme. Linking twice is
+// a no-op.
+func (s *Service) LinkControl(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, programmeID uuid.UUID, in ControlLinkInput) (*domain.ProgrammeControlLink, error) {
+	if _, err := s.programme(ctx, tenantID, programmeID); err != nil {
+		return nil, err
+	}
+	c, err := s.repo.GetControl(ctx, tenantID, in.ControlID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if c == nil {
+		return nil, domain.NewNotFoundError("control", in.ControlID)
+	}
+	l := &domain.ProgrammeControlLink{TenantID: tenantID, ProgrammeID: programmeID, ControlID: c.ID, CreatedAt: s.now()}
+	if err := s.repo.SaveControlLink(ctx, l); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, programmeID, "Control linked to mitigation programme", domain.JSONMap{
+		"control_id": c.ID.String(),
+	})
+	return l, nil
+}
+
+// UnlinkControl removes a control from the programme.
+func (s *Service) UnlinkControl(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, programmeID, controlID uuid.UUID) error {
+	if _, err := s.programme(ctx, tenantID, programmeID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteControlLink(ctx, tenantID, programmeID, controlID); err != nil {
+		return err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, programmeID, "Control unlinked from mitigation programme", domain.JSONMap{
+		"control_id": controlID.String(),
+	})
+	return nil
+}
+
+// =============================================================================
+// Actions
+// =============================================================================
+
+// ActionInput is the body of POST /mitigation-programmes/:id/actions and
+// PUT /programme-actions/:id. DependsOn replaces the action's dependencies.
+type ActionInput struct {
+	Title      string      `json:"title"`
+	EffortDays float64     `json:"effort_days"`
+	AssigneeID *uuid.UUID  `json:"assignee_id"`
+	Order      int         `json:"order"`
+	DependsOn  []uuid.UUID `json:"depends_on"`
+	Completed  bool        `json:"completed"`
+}
+
+// AddAction adds an action to the programme.
+func (s *Service) AddAction(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, programmeID uuid.UUID, in ActionInput) (*domain.ProgrammeAction, error) {
+	if _, err := s.programme(ctx, tenantID, programmeID); err != nil {
+		return nil, err
+	}
+	a := &domain.ProgrammeAction{ID: uuid.New(), TenantID: tenantID, ProgrammeID: programmeID}
+	if err := s.saveAction(ctx, a, in, true); err != nil {
+		return nil, err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, programmeID, "Programme action added", domain.JSONMap{
+		"action_id": a.ID.String(), "title": a.Title, "effort_days": a.EffortDays,
+	})
+	return a, nil
+}
+
+// UpdateAction edits an action, including marking it done.
+func (s *Service) UpdateAction(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID, in ActionInput) (*domain.ProgrammeAction, error) {
+	a, err := s.action(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	wasCompleted := a.Completed
+	if err := s.saveAction(ctx, a, in, false); err != nil {
+		return nil, err
+	}
+	summary := "Programme action updated"
+	if a.Completed && !wasCompleted {
+		summary = "Programme action completed"
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, a.ProgrammeID, summary, domain.JSONMap{
+		"action_id": a.ID.String(), "completed": a.Completed, "effort_days": a.EffortDays,
+	})
+	return a, nil
+}
+
+// saveAction applies the input, checks the dependencies stay inside the
+// programme and acyclic, and stores the action.
+func (s *Service) saveAction(ctx context.Context, a *domain.ProgrammeAction, in ActionInput, isNew bool) error {
+	a.Title = in.Title
+	a.EffortDays = in.EffortDays
+	a.AssigneeID = in.AssigneeID
+	a.Order = in.Order
+	a.DependsOn = dedupe(in.DependsOn)
+	switch {
+	case in.Completed && !a.Completed:
+		now := s.now()
+		a.CompletedAt = &now
+	case !in.Completed:
+		a.CompletedAt = nil
+	}
+	a.Completed = in.Completed
+	if err := a.Validate(); err != nil {
+		return err
+	}
+
+	siblings, err := s.repo.Actions(ctx, a.TenantID, []uuid.UUID{a.ProgrammeID})
+	if err != nil {
+		return domain.NewInternalError(err.Error())
+	}
+	if isNew && len(siblings) >= domain.MaxProgrammeActions {
+		return domain.NewValidationError(fmt.Sprintf("a programme has at most %d actions", domain.MaxProgrammeActions))
+	}
+	known := map[uuid.UUID]bool{}
+	var deps []domain.ProgrammeDependency
+	candidate := []domain.ProgrammeAction{*a}
+	for _, sib := range siblings {
+		if sib.ID == a.ID {
+			continue
+		}
+		known[sib.ID] = true
+		candidate = append(candidate, sib)
+		for _, d := range sib.DependsOn {
+			deps = append(deps, domain.ProgrammeDependency{ActionID: sib.ID, DependsOnID: d})
+		}
+	}
+	for _, d := range a.DependsOn {
+		if !known[d] {
+			return domain.NewValidationError("depends_on must list actions of the same programme")
+		}
+		deps = append(deps, domain.ProgrammeDependency{ActionID: a.ID, DependsOnID: d})
+	}
+	if _, err := domain.ScheduleProgramme(candidate, deps, nil, s.now()); err != nil {
+		return err
+	}
+	if err := s.repo.SaveAction(ctx, a); err != nil {
+		return domain.NewInternalError(err.Error())
+	}
+	return nil
+}
+
+// DeleteAction removes an action. Actions that depended on it lose that
+// dependency rather than blocking the delete.
+func (s *Service) DeleteAction(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) error {
+	a, err := s.action(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteAction(ctx, tenantID, id); err != nil {
+		return err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, a.ProgrammeID, "Programme action deleted", domain.JSONMap{
+		"action_id": id.String(), "title": a.Title,
+	})
+	return nil
+}
+
+// =============================================================================
+// Capacity
+// =============================================================================
+
+// CapacityInput is the body of PUT /programme-capacities/:userId.
+type CapacityInput struct {
+	FTE float64 `json:"fte"`
+}
+
+// Capacities lists the assignees whose capacity differs from full time.
+func (s *Service) Capacities(ctx context.Context, tenantID uuid.UUID) ([]domain.AssigneeCapacity, error) {
+	rows, err := s.repo.Capacities(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if rows == nil {
+		rows = []domain.AssigneeCapacity{}
+	}
+	return rows, nil
+}
+
+// SetCapacity sets the share of a person's week available to programme work.
+func (s *Service) SetCapacity(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, userID uuid.UUID, in CapacityInput) (*domain.AssigneeCapacity, error) {
+	c := &domain.AssigneeCapacity{TenantID: tenantID, UserID: userID, FTE: in.FTE, UpdatedAt: s.now()}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveCapacity(ctx, c); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if s.audit != nil {
+		s.audit.Record(ctx, domain.AuditEvent{
+			TenantID: tenantID, ActorID: actor, Action: domain.AuditActionUpdate,
+			EntityType: "assignee_capacity", EntityID: userID.String(),
+			Summary: "Programme capacity set", After: domain.JSONMap{"fte": c.FTE},
+		})
+	}
+	return c, nil
+}
+
+// =============================================================================
+// Projection
+// =============================================================================
+
+// Contribution is what one programme is expected to remove from a risk.
+type Contribution struct {
+	ProgrammeID       uuid.UUID               `json:"programme_id"`
+	Title             string                  `json:"title"`
+	Status            domain.MitigationStatus `json:"status"`
+	ExpectedReduction float64                 `json:"expected_reduction"`
+	TargetDate        *time.Time              `json:"target_date,omitempty"`
+	ProjectedEnd      time.Time               `json:"projected_end"`
+	SlipDays          int                     `json:"slip_days"`
+	// ReductionAtTarget is the reduction delivered by the target date at the
+	// current projection: the expected reduction scaled by the share of the
+	// work projected done by then.
+	ReductionAtTarget float64 `json:"reduction_at_target"`
+}
+
+// RiskProjection is a risk's expected residual from its programmes — as
+// planned, and as they are tracking.
+type RiskProjection struct {
+	RiskID   uuid.UUID `json:"risk_id"`
+	Title    string    `json:"title"`
+	Inherent float64   `json:"inherent"`
+	// Planned*: every programme delivered by its target date.
+	PlannedReduction float64 `json:"planned_reduction"`
+	PlannedResidual  float64 `json:"planned_residual"`
+	// Projected*: what the programmes deliver by their target dates given
+	// their schedules. Lower than planned when one slips.
+	ProjectedReduction float64 `json:"projected_reduction"`
+	ProjectedResidual  float64 `json:"projected_residual"`
+	// FullReductionAt is when the last programme is projected to finish.
+	FullReductionAt *time.Time     `json:"full_reduction_at,omitempty"`
+	Slipping        bool           `json:"slipping"`
+	Programmes      []Contribution `json:"programmes"`
+}
+
+// Projection projects the programme's schedule onto every risk it treats,
+// each risk counting all of its programmes, not just this one.
+func (s *Service) Projection(ctx context.Context, tenantID, programmeID uuid.UUID) ([]RiskProjection, error) {
+	if _, err := s.programme(ctx, tenantID, programmeID); err != nil {
+		return nil, err
+	}
+	links, err := s.repo.RiskLinks(ctx, tenantID, []uuid.UUID{programmeID})
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	out := []RiskProjection{}
+	for _, l := range links {
+		p, err := s.riskProjection(ctx, tenantID, l.RiskID)
+		if err != nil {
+			return nil, err
+		}
+		if p != nil {
+			out = append(out, *p)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		gi := out[i].ProjectedResidual - out[i].PlannedResidual
+		gj := out[j].ProjectedResidual - out[j].PlannedResidual
+		if gi != gj {
+			return gi > gj
+		}
+		return out[i].Inherent > out[j].Inherent
+	})
+	return out, nil
+}
+
+// RiskProjection is one risk's projection across its programmes.
+func (s *Service) RiskProjection(ctx context.Context, tenantID, riskID uuid.UUID) (*RiskProjection, error) {
+	p, err := s.riskProjection(ctx, tenantID, riskID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, domain.NewNotFoundError("risk", riskID)
+	}
+	return p, nil
+}
+
+func (s *Service) riskProjection(ctx context.Context, tenantID, riskID uuid.UUID) (*RiskProjection, error) {
+	risks, err := s.risksByID(ctx, tenantID, []uuid.UUID{riskID})
+	if err != nil {
+		return nil, err
+	}
+	r, ok := risks[riskID]
+	if !ok {
+		return nil, nil
+	}
+	links, err := s.repo.RiskLinksOf(ctx, tenantID, riskID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	out := &RiskProjection{RiskID: r.ID, Title: riskTitle(r), Inherent: r.Score, Programmes: []Contribution{}}
+	var planned, projected []float64
+	for _, l := range links {
+		p, err := s.repo.GetProgramme(ctx, tenantID, l.ProgrammeID)
+		if err != nil {
+			return nil, domain.NewInternalError(err.Error())
+		}
+		if p == nil || p.Status == domain.MitigationCancelled {
+			// A cancelled programme removes nothing, as with cancelled plans.
+			continue
+		}
+		schedules, err := s.schedules(ctx, tenantID, []domain.MitigationProgramme{*p})
+		if err != nil {
+			return nil, err
+		}
+		sched := schedules[p.ID]
+		c := Contribution{
+			ProgrammeID:       p.ID,
+			Title:             p.Title,
+			Status:            p.Status,
+			ExpectedReduction: l.ExpectedReduction,
+			TargetDate:        p.TargetDate,
+			ProjectedEnd:      sched.ProjectedEnd,
+			SlipDays:          sched.SlipDays,
+			ReductionAtTarget: l.ExpectedReduction,
+		}
+		if p.Status != domain.MitigationDone && p.TargetDate != nil {
+			c.ReductionAtTarget = round4(l.ExpectedReduction * sched.ShareDoneBy(*p.TargetDate))
+		}
+		if c.ReductionAtTarget < c.ExpectedReduction {
+			out.Slipping = true
+		}
+		if p.Status != domain.MitigationDone && (out.FullReductionAt == nil || sched.ProjectedEnd.After(*out.FullReductionAt)) {
+			end := sched.ProjectedEnd
+			out.FullReductionAt = &end
+		}
+		planned = append(planned, c.ExpectedReduction)
+		projected = append(projected, c.ReductionAtTarget)
+		out.Programmes = append(out.Programmes, c)
+	}
+	out.PlannedReduction = domain.CombineReductions(planned)
+	out.PlannedResidual = domain.ProjectResidual(r.Score, planned)
+	out.ProjectedReduction = domain.CombineReductions(projected)
+	out.ProjectedResidual = domain.ProjectResidual(r.Score, projected)
+	return out, nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// schedules computes the schedule of each programme, from its start date or
+// today, whichever is later.
+func (s *Service) schedules(ctx context.Context, tenantID uuid.UUID, programmes []domain.MitigationProgramme) (map[uuid.UUID]*domain.ProgrammeSchedule, error) {
+	ids := make([]uuid.UUID, len(programmes))
+	for i, p := range programmes {
+		ids[i] = p.ID
+	}
+	actions, err := s.repo.Actions(ctx, tenantID, ids)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	deps, err := s.repo.Dependencies(ctx, tenantID, ids)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	caps, err := s.repo.Capacities(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	capacity := make(map[uuid.UUID]float64, len(caps))
+	for _, c := range caps {
+		capacity[c.UserID] = c.FTE
+	}
+	byProgramme := map[uuid.UUID][]domain.ProgrammeAction{}
+	for _, a := range actions {
+		byProgramme[a.ProgrammeID] = append(byProgramme[a.ProgrammeID], a)
+	}
+	depsBy := map[uuid.UUID][]domain.ProgrammeDependency{}
+	for _, d := range deps {
+		depsBy[d.ProgrammeID] = append(depsBy[d.ProgrammeID], d)
+	}
+
+	today := s.now()
+	out := make(map[uuid.UUID]*domain.ProgrammeSchedule, len(programmes))
+	for _, p := range programmes {
+		start := p.StartDate
+		if today.After(start) {
+			start = today
+		}
+		sched, err := domain.ScheduleProgramme(byProgramme[p.ID], depsBy[p.ID], capacity, start)
+		if err != nil {
+			return nil, err
+		}
+		if p.TargetDate != nil {
+			sched.TargetDate = p.TargetDate
+			sched.SlipDays = int(sched.ProjectedEnd.Sub(*p.TargetDate).Hours() / 24)
+		}
+		out[p.ID] = sched
+	}
+	return out, nil
+}
+
+func applySchedule(p *domain.MitigationProgramme, sched *domain.ProgrammeSchedule) {
+	if sched == nil {
+		return
+	}
+	end := sched.ProjectedEnd
+	p.ProjectedEnd = &end
+	p.SlipDays = sched.SlipDays
+	p.Progress = sched.Progress
+	if p.Status == domain.MitigationDone {
+		p.Progress = 100
+	}
+}
+
+func (s *Service) risksByID(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]domain.Risk, error) {
+	out := map[uuid.UUID]domain.Risk{}
+	if len(ids) == 0 {
+		return out, nil
+	}
+	risks, err := s.repo.Risks(ctx, tenantID, ids)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	for _, r := range risks {
+		out[r.ID] = r
+	}
+	return out, nil
+}
+
+func (s *Service) programme(ctx context.Context, tenantID, id uuid.UUID) (*domain.MitigationProgramme, error) {
+	p, err := s.repo.GetProgramme(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if p == nil {
+		return nil, domain.NewNotFoundError("mitigation programme", id)
+	}
+	return p, nil
+}
+
+func (s *Service) action(ctx context.Context, tenantID, id uuid.UUID) (*domain.ProgrammeAction, error) {
+	a, err := s.repo.GetAction(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if a == nil {
+		return nil, domain.NewNotFoundError("programme action", id)
+	}
+	return a, nil
+}
+
+func riskTitle(r domain.Risk) string {
+	if r.Title != "" {
+		return r.Title
+	}
+	return r.Name
+}
+
+func dedupe(ids []uuid.UUID) []uuid.UUID {
+	seen := map[uuid.UUID]bool{}
+	out := []uuid.UUID{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func nonNilActions(a []domain.ProgrammeAction) []domain.ProgrammeAction {
+	if a == nil {
+		return []domain.ProgrammeAction{}
+	}
+	return a
+}
+
+func round4(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
+
+func (s *Service) record(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, action domain.AuditAction, id uuid.UUID, summary string, after domain.JSONMap) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    actor,
+		Action:     action,
+		EntityType: "mitigation_programme",
+		EntityID:   id.String(),
+		Summary:    summary,
+		After:      after,
+	})
+}
@@ -0,0 +1,282 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package programme
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+type memProgrammes struct {
+	programmes map[uuid.UUID]domain.MitigationProgramme
+	riskLinks  []domain.ProgrammeRiskLink
+	ctlLinks   []domain.ProgrammeControlLink
+	actions    map[uuid.UUID]domain.ProgrammeAction
+	caps       []domain.AssigneeCapacity
+	risks      []domain.Risk
+	controls   []domain.ComplianceControl
+}
+
+func newMemProgrammes() *memProgrammes {
+	return &memProgrammes{programmes: map[uuid.UUID]domain.MitigationProgramme{}, actions: map[uuid.UUID]domain.ProgrammeAction{}}
+}
+
+func inScope(ids []uuid.UUID, id uuid.UUID) bool {
+	if ids == nil {
+		return true
+	}
+	for _, x := range ids {
+		if x == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *memProgrammes) ListProgrammes(_ context.Context, tenantID uuid.UUID) ([]domain.MitigationProgramme, error) {
+	var out []domain.MitigationProgramme
+	for _, p := range m.programmes {
+		if p.TenantID == tenantID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+func (m *memProgrammes) GetProgramme(_ context.Context, tenantID, id uuid.UUID) (*domain.MitigationProgramme, error) {
+	if p, ok := m.programmes[id]; ok && p.TenantID == tenantID {
+		return &p, nil
+	}
+	return nil, nil
+}
+func (m *memProgrammes) SaveProgramme(_ context.Context, p *domain.MitigationProgramme) error {
+	m.programmes[p.ID] = *p
+	return nil
+}
+func (m *memProgrammes) DeleteProgramme(_ context.Context, _, id uuid.UUID) error {
+	delete(m.programmes, id)
+	return nil
+}
+func (m *memProgrammes) RiskLinks(_ context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]domain.ProgrammeRiskLink, error) {
+	var out []domain.ProgrammeRiskLink
+	for _, l := range m.riskLinks {
+		if l.TenantID == tenantID && inScope(ids, l.ProgrammeID) {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+func (m *memProgrammes) RiskLinksOf(_ context.Context, tenantID, riskID uuid.UUID) ([]domain.ProgrammeRiskLink, error) {
+	var out []domain.ProgrammeRiskLink
+	for _, l := range m.riskLinks {
+		if l.TenantID == tenantID && l.RiskID == riskID {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+func (m *memProgrammes) SaveRiskLink(_ context.Context, l *domain.ProgrammeRiskLink) error {
+	for i, x := range m.riskLinks {
+		if x.ProgrammeID == l.ProgrammeID && x.RiskID == l.RiskID {
+			m.riskLinks[i].ExpectedReduction = l.ExpectedReduction
+			return nil
+		}
+	}
+	m.riskLinks = append(m.riskLinks, *l)
+	return nil
+}
+func (m *memProgrammes) DeleteRiskLink(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error {
+	return nil
+}
+func (m *memProgrammes) ControlLinks(_ context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]domain.ProgrammeControlLink, error) {
+	var out []domain.ProgrammeControlLink
+	for _, l := range m.ctlLinks {
+		if l.TenantID == tenantID && inScope(ids, l.ProgrammeID) {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+func (m *memProgrammes) SaveControlLink(_ context.Context, l *domain.ProgrammeControlLink) error {
+	m.ctlLinks = append(m.ctlLinks, *l)
+	return nil
+}
+func (m *memProgrammes) DeleteControlLink(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error {
+	return nil
+}
+func (m *memProgrammes) Actions(_ context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]domain.ProgrammeAction, error) {
+	var out []domain.ProgrammeAction
+	for _, a := range m.actions {
+		if a.TenantID == tenantID && inScope(ids, a.ProgrammeID) {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+func (m *memProgrammes) GetAction(_ context.Context, tenantID, id uuid.UUID) (*domain.ProgrammeAction, error) {
+	if a, ok := m.actions[id]; ok && a.TenantID == tenantID {
+		return &a, nil
+	}
+	return nil, nil
+}
+func (m *memProgrammes) SaveAction(_ context.Context, a *domain.ProgrammeAction) error {
+	m.actions[a.ID] = *a
+	return nil
+}
+func (m *memProgrammes) DeleteAction(_ context.Context, _, id uuid.UUID) error {
+	delete(m.actions, id)
+	return nil
+}
+func (m *memProgrammes) Dependencies(_ context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]domain.ProgrammeDependency, error) {
+	var out []domain.ProgrammeDependency
+	for _, a := range m.actions {
+		if a.TenantID != tenantID || !inScope(ids, a.ProgrammeID) {
+			continue
+		}
+		for _, d := range a.DependsOn {
+			out = append(out, domain.ProgrammeDependency{TenantID: tenantID, ProgrammeID: a.ProgrammeID, ActionID: a.ID, DependsOnID: d})
+		}
+	}
+	return out, nil
+}
+func (m *memProgrammes) Capacities(context.Context, uuid.UUID) ([]domain.AssigneeCapacity, error) {
+	return m.caps, nil
+}
+func (m *memProgrammes) SaveCapacity(_ context.Context, c *domain.AssigneeCapacity) error {
+	m.caps = append(m.caps, *c)
+	return nil
+}
+func (m *memProgrammes) Risks(_ context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]domain.Risk, error) {
+	var out []domain.Risk
+	for _, r := range m.risks {
+		if r.TenantID == tenantID && inScope(ids, r.ID) {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+func (m *memProgrammes) GetControl(_ context.Context, tenantID, id uuid.UUID) (*domain.ComplianceControl, error) {
+	for _, c := range m.controls {
+		if c.ID == id && c.TenantID == tenantID {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func date(s string) *time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return &t
+}
+
+func TestProgramme_SlippageProjectsOntoEveryLinkedRisk(t *testing.T) {
+	ctx := context.Background()
+	m := newMemProgrammes()
+	monday := *date("2026-10-19")
+	svc := NewService(m).WithClock(func() time.Time { return monday.Add(9 * time.Hour) })
+	tenant, alice := uuid.New(), uuid.New()
+	ransomware := domain.Risk{ID: uuid.New(), TenantID: tenant, Title: "Ransomware", Score: 8}
+	phishing := domain.Risk{ID: uuid.New(), TenantID: tenant, Title: "Phishing", Score: 6}
+	m.risks = []domain.Risk{ransomware, phishing}
+
+	edr, err := svc.Create(ctx, tenant, nil, ProgrammeInput{Title: "Deploy EDR fleet-wide", TargetDate: date("2026-10-23")})
+	require.NoError(t, err)
+	for _, link := range []RiskLinkInput{{ransomware.ID, 0.5}, {phishing.ID, 0.4}} {
+		_, err = svc.LinkRisk(ctx, tenant, nil, edr.ID, link)
+		require.NoError(t, err)
+	}
+	_, err = svc.LinkRisk(ctx, tenant, nil, edr.ID, RiskLinkInput{RiskID: ransomware.ID, ExpectedReduction: 0.95})
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+
+	backups, err := svc.Create(ctx, tenant, nil, ProgrammeInput{Title: "Offline backups", TargetDate: date("2026-12-31")})
+	require.NoError(t, err)
+	_, err = svc.LinkRisk(ctx, tenant, nil, backups.ID, RiskLinkInput{RiskID: ransomware.ID, ExpectedReduction: 0.5})
+	require.NoError(t, err)
+
+	pilot, err := svc.AddAction(ctx, tenant, nil, edr.ID, ActionInput{Title: "Pilot", EffortDays: 3, AssigneeID: &alice})
+	require.NoError(t, err)
+	rollout, err := svc.AddAction(ctx, tenant, nil, edr.ID, ActionInput{Title: "Rollout", EffortDays: 4, AssigneeID: &alice, DependsOn: []uuid.UUID{pilot.ID}})
+	require.NoError(t, err)
+
+	_, err = svc.UpdateAction(ctx, tenant, nil, pilot.ID, ActionInput{Title: "Pilot", EffortDays: 3, DependsOn: []uuid.UUID{rollout.ID}})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "no cycles")
+	foreign, err := svc.AddAction(ctx, tenant, nil, backups.ID, ActionInput{Title: "Buy tapes", EffortDays: 1})
+	require.NoError(t, err)
+	_, err = svc.AddAction(ctx, tenant, nil, edr.ID, ActionInput{Title: "X", DependsOn: []uuid.UUID{foreign.ID}})
+	assert.True(t, errors.Is(err, domain.ErrValidation), "dependencies stay inside the programme")
+
+	d, err := svc.Get(ctx, tenant, edr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{pilot.ID, rollout.ID}, d.Schedule.CriticalPath)
+	assert.Equal(t, *date("2026-10-27"), d.Schedule.ProjectedEnd, "seven working days from Monday")
+	assert.Equal(t, 4, d.Schedule.SlipDays)
+	assert.Equal(t, 2, d.Programme.RiskCount)
+
+	proj, err := svc.Projection(ctx, tenant, edr.ID)
+	require.NoError(t, err)
+	require.Len(t, proj, 2)
+	var r RiskProjection
+	for _, p := range proj {
+		if p.RiskID == ransomware.ID {
+			r = p
+		}
+	}
+	require.Len(t, r.Programmes, 2, "a risk's projection counts all of its programmes")
+	assert.InDelta(t, 2.0, r.PlannedResidual, 1e-9, "8 × (1 − 0.5) × (1 − 0.5)")
+	assert.True(t, r.Slipping)
+	assert.Greater(t, r.ProjectedResidual, r.PlannedResidual, "slippage raises the expected residual")
+	assert.InDelta(t, 0.5*3.0/7.0, r.Programmes[0].ReductionAtTarget, 1e-4, "only the pilot lands by the target")
+
+	// Finishing the pilot and moving the target out puts the programme back
+	// on track.
+	done, err := svc.UpdateAction(ctx, tenant, nil, pilot.ID, ActionInput{Title: "Pilot", EffortDays: 3, AssigneeID: &alice, Completed: true})
+	require.NoError(t, err)
+	require.NotNil(t, done.CompletedAt)
+	_, err = svc.Update(ctx, tenant, nil, edr.ID, ProgrammeInput{Title: "Deploy EDR fleet-wide", TargetDate: date("2026-10-30")})
+	require.NoError(t, err)
+	rp, err := svc.RiskProjection(ctx, tenant, ransomware.ID)
+	require.NoError(t, err)
+	assert.False(t, rp.Slipping)
+	assert.InDelta(t, rp.PlannedResidual, rp.ProjectedResidual, 1e-9)
+
+	// A cancelled programme contributes nothing.
+	_, err = svc.Update(ctx, tenant, nil, backups.ID, ProgrammeInput{Title: "Offline backups", Status: domain.MitigationCancelled})
+	require.NoError(t, err)
+	rp, err = svc.RiskProjection(ctx, tenant, ransomware.ID)
+	require.NoError(t, err)
+	assert.Len(t, rp.Programmes, 1)
+	assert.InDelta(t, 4.0, rp.PlannedResidual, 1e-9)
+}
+
+func TestProgramme_TenantScoped(t *testing.T) {
+	ctx := context.Background()
+	m := newMemProgrammes()
+	svc := NewService(m)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	p, err := svc.Create(ctx, tenantA, nil, ProgrammeInput{Title: "EDR"})
+	require.NoError(t, err)
+
+	_, err = svc.Get(ctx, tenantB, p.ID)
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+	_, err = svc.AddAction(ctx, tenantB, nil, p.ID, ActionInput{Title: "Sneak"})
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+
+	other := domain.Risk{ID: uuid.New(), TenantID: tenantB, Title: "Theirs"}
+	m.risks = append(m.risks, other)
+	_, err = svc.LinkRisk(ctx, tenantA, nil, p.ID, RiskLinkInput{RiskID: other.ID, ExpectedReduction: 0.3})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "another tenant's risk cannot be linked")
+
+	list, err := svc.List(ctx, tenantB)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
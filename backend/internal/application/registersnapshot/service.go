@@ -0,0 +1,328 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package registersnapshot freezes the risk register: immutable snapshots
+// taken on demand and at every close of day, "as of" reads that resolve a date
+// to the snapshot standing for it, and the diff between two registers that the
+// board report embeds.
+package registersnapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/crq"
+)
+
+// MappingSource resolves the control mappings of many risks in one query.
+// *repository.GormRiskControlMappingRepository satisfies it.
+type MappingSource interface {
+	ListByRisks(ctx context.Context, tenantID uuid.UUID, riskIDs []uuid.UUID) (map[uuid.UUID][]domain.RiskControlMapping, error)
+}
+
+// AuditSink records manual snapshots in the audit chain. The daily ones are
+// not audited: they are the platform's, not anyone's decision.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// Service is the register snapshot use cases.
+type Service struct {
+	repo       domain.RegisterSnapshotRepository
+	mappings   MappingSource
+	quantifier *crq.Quantifier
+	audit      AuditSink
+	now        func() time.Time
+}
+
+// NewService builds the service. mappings may be nil: snapshots then carry no
+// control mappings.
+func NewService(repo domain.RegisterSnapshotRepository, mappings MappingSource) *Service {
+	return &Service{repo: repo, mappings: mappings, now: time.Now}
+}
+
+// WithQuantifier freezes each risk's ALE into the snapshot. Without it the
+// ALE columns stay at zero.
+func (s *Service) WithQuantifier(q *crq.Quantifier) *Service {
+	s.quantifier = q
+	return s
+}
+
+// WithAudit enables audit recording.
+func (s *Service) WithAudit(a AuditSink) *Service {
+	s.audit = a
+	return s
+}
+
+// WithClock overrides the clock (tests).
+func (s *Service) WithClock(now func() time.Time) *Service {
+	s.now = now
+	return s
+}
+
+// ParseAsOf reads an as_of parameter: an RFC 3339 instant, or a bare date
+// meaning the END of that day (UTC), so "2026-12-31" is the register at the
+// close of 31 December, not at its first second.
+func ParseAsOf(raw string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t.UTC(), nil
+	}
+	if d, err := time.Parse("2006-01-02", raw); err == nil {
+		return d.AddDate(0, 0, 1), nil
+	}
+	return time.Time{}, domain.NewValidationError("as_of must be a date (YYYY-MM-DD) or an RFC 3339 instant")
+}
+
+// =============================================================================
+// Taking snapshots
+// =============================================================================
+
+// Take freezes the register now, on request.
+func (s *Service) Take(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, label string) (*domain.RegisterSnapshot, error) {
+	label = strings.TrimSpace(label)
+	if len(label) > 255 {
+		return nil, domain.NewValidationError("label must be at most 255 characters")
+	}
+	now := s.now().UTC()
+	snap, err := s.take(ctx, tenantID, domain.RegisterSnapshotManual, now, label, actor)
+	if err != nil {
+		return nil, err
+	}
+	if s.audit != nil {
+		s.audit.Record(ctx, domain.AuditEvent{
+			TenantID: tenantID, ActorID: actor, Action: "register_snapshot.taken",
+			EntityType: "register_snapshot", EntityID: snap.ID.String(),
+			Summary: fmt.Sprintf("Register snapshot of %d risks", snap.RiskCount),
+			After:   domain.JSONMap{"label": snap.Label, "as_of": snap.AsOf, "risk_count": snap.RiskCount},
+		})
+	}
+	return snap, nil
+}
+
+// SweepDue takes the close-of-day snapshot of every tenant that does not have
+// today's yet. The snapshot stands for the midnight that opened today; a
+// failing tenant does not stop the others.
+func (s *Service) SweepDue(ctx context.Context, now time.Time) (int, error) {
+	closeOfDay := now.UTC().Truncate(24 * time.Hour)
+	tenants, err := s.repo.TenantsDue(ctx, closeOfDay)
+	if err != nil {
+		return 0, err
+	}
+	label := "Clôture du " + closeOfDay.AddDate(0, 0, -1).Format("2006-01-02")
+	var errs []error
+	n := 0
+	for _, tenantID := range tenants {
+		if _, err := s.take(ctx, tenantID, domain.RegisterSnapshotScheduled, closeOfDay, label, nil); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", tenantID, err))
+			continue
+		}
+		n++
+	}
+	return n, errors.Join(errs...)
+}
+
+// take freezes the whole register, whoever asks: the caller's access policies
+// filter the reads of a snapshot, not its content.
+func (s *Service) take(ctx context.Context, tenantID uuid.UUID, kind domain.RegisterSnapshotKind, asOf time.Time, label string, actor *uuid.UUID) (*domain.RegisterSnapshot, error) {
+	ctx = domain.WithAccessScope(ctx, nil)
+	snap := &domain.RegisterSnapshot{
+		ID: uuid.New(), TenantID: tenantID, Kind: kind, Label: label,
+		AsOf: asOf, TakenAt: s.now().UTC(), TakenBy: actor,
+	}
+	rows, err := s.liveRows(ctx, tenantID, snap.ID)
+	if err != nil {
+		return nil, err
+	}
+	snap.Summarize(rows)
+	if err := s.repo.Create(ctx, snap, rows); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// liveRows copies the register as it stands into snapshot rows.
+func (s *Service) liveRows(ctx context.Context, tenantID, snapshotID uuid.UUID) ([]domain.RegisterSnapshotRisk, error) {
+	risks, err := s.repo.LiveRisks(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	mappings := map[uuid.UUID][]domain.RiskControlMapping{}
+	if s.mappings != nil && len(risks) > 0 {
+		ids := make([]uuid.UUID, len(risks))
+		for i := range risks {
+			ids[i] = risks[i].ID
+		}
+		if mappings, err = s.mappings.ListByRisks(ctx, tenantID, ids); err != nil {
+			return nil, err
+		}
+	}
+	rows := make([]domain.RegisterSnapshotRisk, 0, len(risks))
+	for i := range risks {
+		r := &risks[i]
+		row := domain.RegisterSnapshotRisk{
+			SnapshotID: snapshotID, RiskID: r.ID, TenantID: tenantID,
+			Title: r.Title, Status: r.Status, LifecycleState: r.LifecycleState,
+			Criticality: strings.ToLower(string(r.Criticality)),
+			Score:       r.Score, Probability: r.Probability, Impact: r.Impact,
+			ResidualRisk: r.ResidualRisk, OwnerID: r.OwnerID,
+			BusinessUnit: r.BusinessUnit, CategoryID: r.CategoryID,
+			Tags:          append([]string{}, r.Tags...),
+			Controls:      []string{},
+			Assets:        []string{},
+			RiskCreatedAt: r.CreatedAt, RiskUpdatedAt: r.UpdatedAt,
+		}
+		if row.Title == "" {
+			row.Title = r.Name
+		}
+		if s.quantifier != nil {
+			row.ALEXAF = s.quantifier.Quantify(r.SLEXAF, r.ARO, string(r.Criticality)).ALE.XAF
+		}
+		for _, m := range mappings[r.ID] {
+			row.Controls = append(row.Controls, m.Label())
+		}
+		for _, a := range r.Assets {
+			row.Assets = append(row.Assets, a.Name)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// =============================================================================
+// Reading
+// =============================================================================
+
+// maxListed caps GET /register-snapshots: a year of daily closes and then
+// some.
+const maxListed = 400
+
+// List returns the tenant's snapshots, newest first.
+func (s *Service) List(ctx context.Context, tenantID uuid.UUID) ([]domain.RegisterSnapshot, error) {
+	return s.repo.List(ctx, tenantID, maxListed)
+}
+
+// Get returns one snapshot.
+func (s *Service) Get(ctx context.Context, tenantID, id uuid.UUID) (*domain.RegisterSnapshot, error) {
+	snap, err := s.repo.Get(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if snap == nil {
+		return nil, domain.NewNotFoundError("register snapshot", id)
+	}
+	return snap, nil
+}
+
+// Resolve returns the snapshot standing for the register at asOf: the latest
+// one at or before it. There is none before the tenant's first snapshot.
+func (s *Service) Resolve(ctx context.Context, tenantID uuid.UUID, asOf time.Time) (*domain.RegisterSnapshot, error) {
+	snap, err := s.repo.AtOrBefore(ctx, tenantID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if snap == nil {
+		return nil, domain.NewNotFoundError("register snapshot", asOf.Format(time.RFC3339))
+	}
+	return snap, nil
+}
+
+// Page is a page of a frozen register.
+type Page struct {
+	Snapshot *domain.RegisterSnapshot      `json:"snapshot"`
+	Items    []domain.RegisterSnapshotRisk `json:"items"`
+	Total    int64                         `json:"total"`
+}
+
+// Rows pages through one snapshot.
+func (s *Service) Rows(ctx context.Context, tenantID, id uuid.UUID, f domain.RegisterSnapshotFilter) (*Page, error) {
+	snap, err := s.Get(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.page(ctx, snap, f)
+}
+
+// RowsAsOf pages through the register as it stood at asOf.
+func (s *Service) RowsAsOf(ctx context.Context, tenantID uuid.UUID, asOf time.Time, f domain.RegisterSnapshotFilter) (*Page, error) {
+	snap, err := s.Resolve(ctx, tenantID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	return s.page(ctx, snap, f)
+}
+
+func (s *Service) page(ctx context.Context, snap *domain.RegisterSnapshot, f domain.RegisterSnapshotFilter) (*Page, error) {
+	items, total, err := s.repo.Rows(ctx, snap.TenantID, snap.ID, f)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []domain.RegisterSnapshotRisk{}
+	}
+	return &Page{Snapshot: snap, Items: items, Total: total}, nil
+}
+
+// =============================================================================
+// Diff
+// =============================================================================
+
+// Diff is what changed between two registers. To is nil when the later side
+// is the live register rather than a snapshot.
+type Diff struct {
+	From *domain.RegisterSnapshot `json:"from"`
+	To   *domain.RegisterSnapshot `json:"to"`
+	domain.RegisterDiff
+}
+
+// Diff compares two snapshots. The order of the ids does not matter: the
+// earlier one is always the "from" side.
+func (s *Service) Diff(ctx context.Context, tenantID, a, b uuid.UUID) (*Diff, error) {
+	from, err := s.Get(ctx, tenantID, a)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.Get(ctx, tenantID, b)
+	if err != nil {
+		return nil, err
+	}
+	if to.AsOf.Before(from.AsOf) {
+		from, to = to, from
+	}
+	fromRows, err := s.repo.AllRows(ctx, tenantID, from.ID)
+	if err != nil {
+		return nil, err
+	}
+	toRows, err := s.repo.AllRows(ctx, tenantID, to.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &Diff{From: from, To: to, RegisterDiff: domain.DiffRegisters(fromRows, toRows)}, nil
+}
+
+// DiffSince compares the register as it stood at since with the live
+// register. It returns (nil, nil) when no snapshot is that old: there is
+// nothing honest to compare against.
+func (s *Service) DiffSince(ctx context.Context, tenantID uuid.UUID, since time.Time) (*Diff, error) {
+	from, err := s.repo.AtOrBefore(ctx, tenantID, since)
+	if err != nil || from == nil {
+		return nil, err
+	}
+	fromRows, err := s.repo.AllRows(ctx, tenantID, from.ID)
+	if err != nil {
+		return nil, err
+	}
+	live, err := s.liveRows(ctx, tenantID, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Diff{From: from, RegisterDiff: domain.DiffRegisters(fromRows, live)}, nil
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package registersnapshot
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/crq"
+)
+
+// memSnapshots is an in-memory domain.RegisterSnapshotRepository whose live
+// register the test edits directly.
+type memSnapshots struct {
+	live  map[uuid.UUID][]domain.Risk
+	snaps []domain.RegisterSnapshot
+	rows  map[uuid.UUID][]domain.RegisterSnapshotRisk
+}
+
+func newMem() *memSnapshots {
+	return &memSnapshots{live: map[uuid.UUID][]domain.Risk{}, rows: map[uuid.UUID][]domain.RegisterSnapshotRisk{}}
+}
+
+// LiveRisks hides the whole register from anyone a risk policy applies to,
+// which is enough to tell a scoped read from an unscoped one.
+func (m *memSnapshots) LiveRisks(ctx context.Context, tenantID uuid.UUID) ([]domain.Risk, error) {
+	if deny, allow := domain.AccessScopeFrom(ctx).Applicable(domain.AccessResourceRisk); len(deny) > 0 || len(allow) > 0 {
+		return nil, nil
+	}
+	return append([]domain.Risk{}, m.live[tenantID]...), nil
+}
+
+func (m *memSnapshots) Create(_ context.Context, s *domain.RegisterSnapshot, rows []domain.RegisterSnapshotRisk) error {
+	m.snaps = append(m.snaps, *s)
+	m.rows[s.ID] = append([]domain.RegisterSnapshotRisk{}, rows...)
+	return nil
+}
+
+func (m *memSnapshots) Get(_ context.Context, tenantID, id uuid.UUID) (*domain.RegisterSnapshot, error) {
+	for i := range m.snaps {
+		if m.snaps[i].ID == id && m.snaps[i].TenantID == tenantID {
+			s := m.snaps[i]
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memSnapshots) AtOrBefore(_ context.Context, tenantID uuid.UUID, at time.Time) (*domain.RegisterSnapshot, error) {
+	var best *domain.RegisterSnapshot
+	for i := range m.snaps {
+		s := m.snaps[i]
+		if s.TenantID == tenantID && !s.AsOf.After(at) && (best == nil || s.AsOf.After(best.AsOf)) {
+			best = &s
+		}
+	}
+	return best, nil
+}
+
+func (m *memSnapshots) List(_ context.Context, tenantID uuid.UUID, _ int) ([]domain.RegisterSnapshot, error) {
+	var out []domain.RegisterSnapshot
+	for _, s := range m.snaps {
+		if s.TenantID == tenantID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (m *memSnapshots) Rows(_ context.Context, tenantID, id uuid.UUID, _ domain.RegisterSnapshotFilter) ([]domain.RegisterSnapshotRisk, int64, error) {
+	var out []domain.RegisterSnapshotRisk
+	for _, r := range m.rows[id] {
+		if r.TenantID == tenantID {
+			out = append(out, r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	return out, int64(len(out)), nil
+}
+
+func (m *memSnapshots) AllRows(ctx context.Context, tenantID, id uuid.UUID) ([]domain.RegisterSnapshotRisk, error) {
+	rows, _, err := m.Rows(ctx, tenantID, id, domain.RegisterSnapshotFilter{})
+	return rows, err
+}
+
+func (m *memSnapshots) TenantsDue(_ context.Context, asOf time.Time) ([]uuid.UUID, error) {
+	var out []uuid.UUID
+	for tenantID, risks := range m.live {
+		if len(risks) == 0 {
+			continue
+		}
+		done := false
+		for _, s := range m.snaps {
+			if s.TenantID == tenantID && s.Kind == domain.RegisterSnapshotScheduled && !s.AsOf.Before(asOf) {
+				done = true
+			}
+		}
+		if !done {
+			out = append(out, tenantID)
+		}
+	}
+	return out, nil
+}
+
+type staticMappings map[uuid.UUID][]domain.RiskControlMapping
+
+func (s staticMappings) ListByRisks(_ context.Context, _ uuid.UUID, _ []uuid.UUID) (map[uuid.UUID][]domain.RiskControlMapping, error) {
+	return s, nil
+}
+
+func risk(tenantID uuid.UUID, title string, score float64) domain.Risk {
+	return domain.Risk{
+		ID: uuid.New(), TenantID: tenantID, Title: title, Score: score, Status: domain.RiskOpen,
+		Criticality: domain.CriticalityFromScore(score),
+	}
+}
+
+func TestParseAsOf(t *testing.T) {
+	d, err := ParseAsOf("2026-12-31")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), d, "a date means the close of that day")
+
+	i, err := ParseAsOf("2026-12-31T15:04:05+01:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 12, 31, 14, 4, 5, 0, time.UTC), i)
+
+	_, err = ParseAsOf("last christmas")
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+}
+
+func TestTake_FreezesScoresOwnersALEAndMappings(t *testing.T) {
+	ctx := context.Background()
+	mem := newMem()
+	tenantID, owner := uuid.New(), uuid.New()
+	r := risk(tenantID, "Rançongiciel", 8)
+	r.OwnerID = &owner
+	sle, aro := 10_000_000.0, 0.5
+	r.SLEXAF, r.ARO = &sle, &aro
+	r.Assets = []*domain.Asset{{Name: "Core banking"}}
+	mem.live[tenantID] = []domain.Risk{r, risk(tenantID, "Fraude", 3)}
+	ctrl := uuid.New()
+	mappings := staticMappings{r.ID: {{RiskID: r.ID, ControlID: &ctrl, FrameworkName: "ISO 27001", ControlCode: "A.8.13"}}}
+
+	svc := NewService(mem, mappings).WithQuantifier(crq.NewQuantifier(600, crq.DefaultReference()))
+	snap, err := svc.Take(ctx, tenantID, &owner, "Avant audit COBAC")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RegisterSnapshotManual, snap.Kind)
+	assert.Equal(t, 2, snap.RiskCount)
+	assert.Equal(t, 1, snap.Critical)
+
+	page, err := svc.Rows(ctx, tenantID, snap.ID, domain.RegisterSnapshotFilter{})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	top := page.Items[0]
+	assert.Equal(t, "Rançongiciel", top.Title)
+	assert.Equal(t, &owner, top.OwnerID)
+	assert.InDelta(t, 5_000_000, top.ALEXAF, 1e-6)
+	assert.Equal(t, []string{"ISO 27001 · A.8.13"}, []string(top.Controls))
+	assert.Equal(t, []string{"Core banking"}, []string(top.Assets))
+
+	// Editing the live register afterwards leaves the snapshot alone.
+	mem.live[tenantID][0].Score = 1
+	again, err := svc.Rows(ctx, tenantID, snap.ID, domain.RegisterSnapshotFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 8.0, again.Items[0].Score)
+
+	_, err = svc.Get(ctx, uuid.New(), snap.ID)
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "another tenant cannot read the snapshot")
+}
+
+func TestTake_FreezesTheWholeRegisterWhoeverTakesIt(t *testing.T) {
+	mem := newMem()
+	tenantID := uuid.New()
+	mem.live[tenantID] = []domain.Risk{risk(tenantID, "A", 5), risk(tenantID, "B", 2)}
+	ctx := domain.WithAccessScope(context.Background(), &domain.AccessScope{
+		Subject:  domain.AccessSubject{UserID: uuid.New(), Role: domain.RoleUser},
+		Policies: []domain.AccessPolicy{{Name: "p", Resource: domain.AccessResourceRisk, Effect: domain.AccessDeny, Enabled: true}},
+	})
+
+	svc := NewService(mem, nil)
+	snap, err := svc.Take(ctx, tenantID, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, snap.RiskCount)
+	assert.Len(t, mem.rows[snap.ID], 2, "the policies filter the reads of a snapshot, not its content")
+}
+
+func TestSweepDue_OneCloseOfDayPerTenant(t *testing.T) {
+	ctx := context.Background()
+	mem := newMem()
+	a, b := uuid.New(), uuid.New()
+	mem.live[a] = []domain.Risk{risk(a, "A", 5)}
+	mem.live[b] = []domain.Risk{risk(b, "B", 5)}
+	svc := NewService(mem, nil)
+
+	now := time.Date(2027, 1, 1, 0, 7, 0, 0, time.UTC)
+	n, err := svc.SweepDue(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	n, err = svc.SweepDue(ctx, now.Add(10*time.Minute))
+	require.NoError(t, err)
+	assert.Zero(t, n, "the second sweep of the day finds nothing due")
+
+	yearEnd, err := ParseAsOf("2026-12-31")
+	require.NoError(t, err)
+	page, err := svc.RowsAsOf(ctx, a, yearEnd, domain.RegisterSnapshotFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, domain.RegisterSnapshotScheduled, page.Snapshot.Kind)
+	assert.Equal(t, "Clôture du 2026-12-31", page.Snapshot.Label)
+	assert.EqualValues(t, 1, page.Total)
+
+	_, err = svc.RowsAsOf(ctx, a, yearEnd.AddDate(0, 0, -1), domain.RegisterSnapshotFilter{})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "nothing stands for a date before the first snapshot")
+}
+
+func TestDiff_SnapshotsAndLive(t *testing.T) {
+	ctx := context.Background()
+	mem := newMem()
+	tenantID := uuid.New()
+	stays, worsens, closes := risk(tenantID, "stays", 4), risk(tenantID, "worsens", 3), risk(tenantID, "closes", 6)
+	mem.live[tenantID] = []domain.Risk{stays, worsens, closes}
+
+	clock := time.Date(2026, 11, 30, 12, 0, 0, 0, time.UTC)
+	svc := NewService(mem, nil).WithClock(func() time.Time { return clock })
+	nov, err := svc.Take(ctx, tenantID, nil, "")
+	require.NoError(t, err)
+
+	worsens.Score = 7.5
+	fresh := risk(tenantID, "fresh", 9)
+	mem.live[tenantID] = []domain.Risk{stays, worsens, fresh}
+	clock = clock.AddDate(0, 1, 0)
+	dec, err := svc.Take(ctx, tenantID, nil, "")
+	require.NoError(t, err)
+
+	d, err := svc.Diff(ctx, tenantID, dec.ID, nov.ID)
+	require.NoError(t, err)
+	assert.Equal(t, nov.ID, d.From.ID, "the earlier snapshot is always the from side")
+	assert.Equal(t, domain.RegisterDiffSummary{New: 1, Closed: 1, UpScored: 1, Unchanged: 1}, d.Summary)
+
+	live, err := svc.DiffSince(ctx, tenantID, clock.AddDate(0, 0, -15))
+	require.NoError(t, err)
+	require.NotNil(t, live)
+	assert.Equal(t, nov.ID, live.From.ID)
+	assert.Nil(t, live.To)
+	assert.Equal(t, 1, live.Summary.New)
+
+	none, err := svc.DiffSince(ctx, tenantID, clock.AddDate(-1, 0, 0))
+	require.NoError(t, err)
+	assert.Nil(t, none)
+}
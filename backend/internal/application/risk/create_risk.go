@@ -8,6 +8,7 @@ package risk
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/opendefender/openrisk/internal/domain"
@@ -32,11 +33,16 @@ type CreateRiskInput struct {
 	// may be unclassified, and forcing a pick at creation only teaches people to
 	// choose the first entry.
 	CategoryID *uuid.UUID
-	Source     string // parsed into domain.RiskSource in Execute()
-	ExternalID string
-	CreatedBy  uuid.UUID // the authenticated user creating the risk
-	SLEXAF     *float64  // CRQ: single loss expectancy (XAF), optional
-	ARO        *float64  // CRQ: annualized rate of occurrence, optional
+	// BusinessUnit is the owning part of the organisation, free text; it is
+	// what business-unit appetite statements match on.
+	BusinessUnit string
+	// Classification is the confidentiality level access policies test.
+	Classification string
+	Source         string // parsed into domain.RiskSource in Execute()
+	ExternalID     string
+	CreatedBy      uuid.UUID // the authenticated user creating the risk
+	SLEXAF         *float64  // CRQ: single loss expectancy (XAF), optional
+	ARO            *float64  // CRQ: annualized rate of occurrence, optional
 	// Full financial-quantification drivers (spec §9). All optional XAF amounts.
 	DowntimeHours           *float64
 	HourlyDowntimeCostXAF   *float64
@@ -111,6 +117,8 @@ func (uc *CreateRiskUseCase) Execute(ctx context.Context, orgID uuid.UUID, input
 		Frameworks:     input.Frameworks,
 		Owner:          input.Owner,
 		CategoryID:     input.CategoryID,
+		BusinessUnit:   strings.TrimSpace(input.BusinessUnit),
+		Classification: strings.TrimSpace(input.Classification),
 		Source:         source,
 		ExternalID:     input.ExternalID,
 		TenantID:       orgID,
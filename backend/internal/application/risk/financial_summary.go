@@ -234,6 +234,11 @@ func (uc *FinancialSummaryUseCase) clock() func() time.Time {
 	return time.Now
 }
 
+// FinancialInputsOf maps a risk's stored drivers onto the CRQ input struct, for
+// callers outside this package that run the model over risks they loaded
+// themselves (the group roll-up).
+func FinancialInputsOf(r *domain.Risk) crq.FinancialInputs { return financialInputs(r) }
+
 // financialInputs maps a risk's stored drivers onto the CRQ input struct. Kept
 // here (not in the handler helper) so the use case has no handler dependency.
 func financialInputs(r *domain.Risk) crq.FinancialInputs {
@@ -56,6 +56,21 @@ type ApprovalChecker interface {
 	HasApprovedAcceptance(ctx context.Context, tenantID, riskID uuid.UUID) (approved bool, pendingRequestID *uuid.UUID, err error)
 }
 
+// AppetiteChecker answers the appetite guard: is this risk above the board's
+// appetite, and if so where does its exception stand? Satisfied structurally by
+// application/appetite.Service. Optional port.
+type AppetiteChecker interface {
+	// AppetiteStanding reports whether the risk breaches an in-force appetite
+	// statement and the resolved state of its appetite exception.
+	AppetiteStanding(ctx context.Context, tenantID uuid.UUID, r *domain.Risk) (above bool, exception domain.AppetiteException, err error)
+}
+
+// EventPublisher announces domain events to webhook subscribers. Satisfied
+// structurally by application/webhooks.Service. Optional port.
+type EventPublisher interface {
+	Publish(ctx context.Context, tenantID uuid.UUID, eventType domain.WebhookEventType, data any) error
+}
+
 // TransitionRiskStateInput is the payload of POST /risks/:id/transition.
 type TransitionRiskStateInput struct {
 	To      domain.RiskState
@@ -74,6 +89,8 @@ type TransitionRiskStateUseCase struct {
 	riskRepo    domain.RiskRepository
 	mitigations MitigationInspector
 	approvals   ApprovalChecker
+	appetite    AppetiteChecker
+	events      EventPublisher
 }
 
 func NewTransitionRiskStateUseCase(riskRepo domain.RiskRepository) *TransitionRiskStateUseCase {
@@ -92,6 +109,18 @@ func (uc *TransitionRiskStateUseCase) WithApprovals(a ApprovalChecker) *Transiti
 	return uc
 }
 
+// WithAppetite attaches the checker backing the appetite-exception guard.
+func (uc *TransitionRiskStateUseCase) WithAppetite(a AppetiteChecker) *TransitionRiskStateUseCase {
+	uc.appetite = a
+	return uc
+}
+
+// WithEvents announces each transition as risk.state_changed.
+func (uc *TransitionRiskStateUseCase) WithEvents(e EventPublisher) *TransitionRiskStateUseCase {
+	uc.events = e
+	return uc
+}
+
 // AvailableTransitions answers GET /risks/:id/transitions: every reachable
 // state, whether it is allowed right now, and what is blocking it otherwise.
 //
@@ -239,6 +268,18 @@ func (uc *TransitionRiskStateUseCase) Execute(ctx context.Context, tenantID, ris
 		fmt.Printf("Warning: failed to audit lifecycle transition on risk %s: %v\n", riskID, err)
 	}
 
+	// Best-effort too: a subscriber's queue must not undo a transition.
+	if uc.events != nil {
+		data := domain.WebhookRiskStateChangedData{RiskID: riskID, Title: r.Title, From: current, To: target, Comment: in.Comment}
+		if in.Actor != uuid.Nil {
+			actor := in.Actor
+			data.ActorID = &actor
+		}
+		if err := uc.events.Publish(ctx, tenantID, domain.WebhookRiskStateChanged, data); err != nil {
+			fmt.Printf("Warning: failed to publish lifecycle transition on risk %s: %v\n", riskID, err)
+		}
+	}
+
 	return r, nil
 }
 
@@ -307,6 +348,18 @@ func (uc *TransitionRiskStateUseCase) evaluate(ctx context.Context, tenantID uui
 				}
 				return guard, "L'acceptation du risque résiduel exige une approbation Gouvernance validée. Soumettez-en une d'abord.", nil
 			}
+
+		case domain.GuardAppetiteException:
+			if uc.appetite == nil {
+				return guard, unverifiable(en, "risk appetite"), nil
+			}
+			above, exc, err := uc.appetite.AppetiteStanding(ctx, tenantID, r)
+			if err != nil {
+				return "", "", err
+			}
+			if above && !exc.Valid() {
+				return guard, appetiteReason(exc, en), nil
+			}
 		}
 	}
 	return "", "", nil
@@ -344,6 +397,27 @@ func incompleteReason(plans []MitigationSnapshot, en bool) string {
 	return ""
 }
 
+// appetiteReason words the appetite block by where the exception stands, so an
+// owner whose exception lapsed is told to renew it rather than to start over.
+func appetiteReason(exc domain.AppetiteException, en bool) string {
+	switch exc.Status {
+	case domain.AppetiteExceptionPending:
+		if en {
+			return fmt.Sprintf("This risk is above the board's appetite and its exception request %s is still pending.", shortID(*exc.RequestID))
+		}
+		return fmt.Sprintf("Ce risque dépasse l'appétence du conseil et sa demande de dérogation %s est encore en attente.", shortID(*exc.RequestID))
+	case domain.AppetiteExceptionExpired:
+		if en {
+			return "This risk is above the board's appetite and its exception has expired. Request a renewal."
+		}
+		return "Ce risque dépasse l'appétence du conseil et sa dérogation a expiré. Demandez-en le renouvellement."
+	}
+	if en {
+		return "This risk is above the board's appetite. Keeping it requires an approved appetite exception."
+	}
+	return "Ce risque dépasse l'appétence du conseil. Le conserver exige une dérogation d'appétence approuvée."
+}
+
 func unverifiable(en bool, what string) string {
 	if en {
 		return "This precondition cannot be verified right now (" + what + " unavailable). The transition is blocked rather than assumed."
@@ -36,6 +36,16 @@ func (f *fakeApprovals) HasApprovedAcceptance(context.Context, uuid.UUID, uuid.U
 	return f.approved, f.pending, f.err
 }
 
+type fakeAppetite struct {
+	above     bool
+	exception domain.AppetiteException
+	err       error
+}
+
+func (f *fakeAppetite) AppetiteStanding(context.Context, uuid.UUID, *domain.Risk) (bool, domain.AppetiteException, error) {
+	return f.above, f.exception, f.err
+}
+
 // stateRepo is a minimal in-memory RiskRepository for the FSM tests. It is
 // tenant-scoped like the real one: a mismatched tenant reads back as nil.
 type stateRepo struct {
@@ -216,7 +226,7 @@ func TestTransition_ResidualAccepted_RequiresGovernanceApproval(t *testing.T) {
 
 	uc := NewTransitionRiskStateUseCase(repo).
 		WithMitigations(&fakeInspector{plans: []MitigationSnapshot{openPlan(1)}}).
-		WithApprovals(&fakeApprovals{approved: false})
+		WithApprovals(&fakeApprovals{approved: false}).WithAppetite(&fakeAppetite{})
 	_, err := uc.Execute(context.Background(), tenant, id, TransitionRiskStateInput{To: domain.StateResidualAccepted})
 	if err == nil {
 		t.Fatal("accepting residual risk without an approval must be refused")
@@ -230,7 +240,7 @@ func TestTransition_ResidualAccepted_RequiresGovernanceApproval(t *testing.T) {
 	pending := uuid.New()
 	uc = NewTransitionRiskStateUseCase(repo).
 		WithMitigations(&fakeInspector{plans: []MitigationSnapshot{openPlan(1)}}).
-		WithApprovals(&fakeApprovals{approved: false, pending: &pending})
+		WithApprovals(&fakeApprovals{approved: false, pending: &pending}).WithAppetite(&fakeAppetite{})
 	_, err = uc.Execute(context.Background(), tenant, id, TransitionRiskStateInput{To: domain.StateResidualAccepted})
 	if err == nil || !strings.Contains(err.Error(), pending.String()[:8]) {
 		t.Fatalf("a pending request must be named in the reason, got %v", err)
@@ -240,7 +250,7 @@ func TestTransition_ResidualAccepted_RequiresGovernanceApproval(t *testing.T) {
 	// risk is precisely the decision to stop treating it.
 	uc = NewTransitionRiskStateUseCase(repo).
 		WithMitigations(&fakeInspector{plans: []MitigationSnapshot{openPlan(3)}}).
-		WithApprovals(&fakeApprovals{approved: true})
+		WithApprovals(&fakeApprovals{approved: true}).WithAppetite(&fakeAppetite{})
 	got, err := uc.Execute(context.Background(), tenant, id, TransitionRiskStateInput{To: domain.StateResidualAccepted})
 	if err != nil {
 		t.Fatalf("an approved acceptance must go through: %v", err)
@@ -250,6 +260,45 @@ func TestTransition_ResidualAccepted_RequiresGovernanceApproval(t *testing.T) {
 	}
 }
 
+// Above appetite, a governance approval is not enough: the exception must be
+// approved and still valid. Within appetite the guard is transparent.
+func TestTransition_ResidualAccepted_AboveAppetiteRequiresException(t *testing.T) {
+	repo, tenant, id := newFixture(domain.StateInTreatment)
+	build := func(a *fakeAppetite) *TransitionRiskStateUseCase {
+		return NewTransitionRiskStateUseCase(repo).
+			WithMitigations(&fakeInspector{plans: []MitigationSnapshot{openPlan(1)}}).
+			WithApprovals(&fakeApprovals{approved: true}).
+			WithAppetite(a)
+	}
+
+	_, err := build(&fakeAppetite{above: true}).Execute(context.Background(), tenant, id, TransitionRiskStateInput{To: domain.StateResidualAccepted})
+	if err == nil || !strings.Contains(err.Error(), "appétence") {
+		t.Fatalf("above appetite without an exception must be refused, got %v", err)
+	}
+
+	pending := uuid.New()
+	_, err = build(&fakeAppetite{above: true, exception: domain.AppetiteException{Status: domain.AppetiteExceptionPending, RequestID: &pending}}).
+		Execute(context.Background(), tenant, id, TransitionRiskStateInput{To: domain.StateResidualAccepted, Locale: "en"})
+	if err == nil || !strings.Contains(err.Error(), pending.String()[:8]) {
+		t.Fatalf("a pending exception must be named in the reason, got %v", err)
+	}
+
+	_, err = build(&fakeAppetite{above: true, exception: domain.AppetiteException{Status: domain.AppetiteExceptionExpired}}).
+		Execute(context.Background(), tenant, id, TransitionRiskStateInput{To: domain.StateResidualAccepted, Locale: "en"})
+	if err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Fatalf("an expired exception must say so, got %v", err)
+	}
+
+	got, err := build(&fakeAppetite{above: true, exception: domain.AppetiteException{Status: domain.AppetiteExceptionApproved}}).
+		Execute(context.Background(), tenant, id, TransitionRiskStateInput{To: domain.StateResidualAccepted})
+	if err != nil {
+		t.Fatalf("an approved exception must let the acceptance through: %v", err)
+	}
+	if got.LifecycleState != domain.StateResidualAccepted {
+		t.Fatalf("state=%q", got.LifecycleState)
+	}
+}
+
 // An unwired guard BLOCKS. An unverifiable precondition is not a satisfied one,
 // and in a security tool the honest failure is the safe one.
 func TestTransition_UnwiredGuardBlocksRatherThanPasses(t *testing.T) {
@@ -345,7 +394,7 @@ func TestAvailableTransitions_ExposesBlockersRatherThanHidingThem(t *testing.T)
 	repo, tenant, id := newFixture(domain.StateInTreatment)
 	uc := NewTransitionRiskStateUseCase(repo).
 		WithMitigations(&fakeInspector{plans: []MitigationSnapshot{openPlan(2)}}).
-		WithApprovals(&fakeApprovals{approved: false})
+		WithApprovals(&fakeApprovals{approved: false}).WithAppetite(&fakeAppetite{})
 
 	view, err := uc.AvailableTransitions(context.Background(), tenant, id, "fr")
 	if err != nil {
@@ -394,7 +443,7 @@ func TestAvailableTransitions_AllowedWhenGuardsAreSatisfied(t *testing.T) {
 	repo, tenant, id := newFixture(domain.StateInTreatment)
 	view, err := NewTransitionRiskStateUseCase(repo).
 		WithMitigations(&fakeInspector{plans: []MitigationSnapshot{donePlan()}}).
-		WithApprovals(&fakeApprovals{approved: true}).
+		WithApprovals(&fakeApprovals{approved: true}).WithAppetite(&fakeAppetite{}).
 		AvailableTransitions(context.Background(), tenant, id, "en")
 	if err != nil {
 		t.Fatalf("AvailableTransitions: %v", err)
@@ -460,7 +509,7 @@ func TestTransition_FullLifecycleWalk(t *testing.T) {
 	inspector := &fakeInspector{}
 	uc := NewTransitionRiskStateUseCase(repo).
 		WithMitigations(inspector).
-		WithApprovals(&fakeApprovals{approved: false})
+		WithApprovals(&fakeApprovals{approved: false}).WithAppetite(&fakeAppetite{})
 	ctx := context.Background()
 
 	step := func(to domain.RiskState) {
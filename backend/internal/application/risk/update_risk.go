@@ -8,6 +8,7 @@ package risk
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -33,6 +34,10 @@ type UpdateRiskInput struct {
 	// CategoryID is tri-state via NullableUUID for the same reason ownership is:
 	// omitting it must not clear it.
 	Category domain.NullableUUID
+	// BusinessUnit: nil leaves it alone, "" clears it.
+	BusinessUnit *string
+	// Classification: nil leaves it alone, "" clears it.
+	Classification *string
 	// CRQ monetary inputs (XAF). Pointers so a partial update can set or clear them.
 	SLEXAF *float64
 	ARO    *float64
@@ -119,6 +124,12 @@ func (uc *UpdateRiskUseCase) Execute(ctx context.Context, orgID uuid.UUID, riskI
 	if input.Category.Present {
 		risk.CategoryID = input.Category.Value
 	}
+	if input.BusinessUnit != nil {
+		risk.BusinessUnit = strings.TrimSpace(*input.BusinessUnit)
+	}
+	if input.Classification != nil {
+		risk.Classification = strings.TrimSpace(*input.Classification)
+	}
 	if input.SLEXAF != nil {
 		if *input.SLEXAF < 0 {
 			return nil, domain.NewValidationError("single loss expectancy (sle_xaf) cannot be negative")
@@ -149,6 +160,8 @@ func (uc *UpdateRiskUseCase) Execute(ctx context.Context, orgID uuid.UUID, riskI
 	}
 	if input.DowntimeHours != nil {
 		risk.DowntimeHours = input.DowntimeHours
+		// Typed in by hand: the BIA no longer owns this figure.
+		risk.DowntimeHoursSource = ""
 	}
 	if input.HourlyDowntimeCostXAF != nil {
 		risk.HourlyDowntimeCostXAF = input.HourlyDowntimeCostXAF
@@ -0,0 +1,525 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package scenario manages the risk-scenario library: the built-in scenarios
+// and a tenant's own, instantiating one against assets as pre-filled draft
+// risks, and the ATT&CK coverage report the scenarios' control mappings make
+// possible.
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/compliance"
+)
+
+// MaxInstantiateAssets bounds one instantiation. A scenario run against the
+// whole estate in one click is a register nobody will review.
+const MaxInstantiateAssets = 100
+
+// AuditSink records library changes and instantiations in the audit chain.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// Service is the scenario library's use cases.
+type Service struct {
+	repo  domain.RiskScenarioRepository
+	audit AuditSink
+	now   func() time.Time
+}
+
+// NewService builds the service.
+func NewService(repo domain.RiskScenarioRepository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// WithAudit enables audit recording.
+func (s *Service) WithAudit(a AuditSink) *Service {
+	s.audit = a
+	return s
+}
+
+// WithClock overrides the clock (tests).
+func (s *Service) WithClock(now func() time.Time) *Service {
+	s.now = now
+	return s
+}
+
+// =============================================================================
+// Library
+// =============================================================================
+
+// Library is GET /risk-scenarios: the built-in release and the tenant's own
+// scenarios after it.
+type Library struct {
+	LibraryVersion string                `json:"library_version"`
+	Scenarios      []domain.RiskScenario `json:"scenarios"`
+}
+
+// ScenarioInput is the body of POST/PUT /risk-scenarios.
+type ScenarioInput struct {
+	Key               string                     `json:"key"`
+	Title             string                     `json:"title"`
+	Description       string                     `json:"description"`
+	ThreatActors      domain.ThreatActors        `json:"threat_actors"`
+	Techniques        domain.ScenarioTechniques  `json:"techniques"`
+	AssetCategories   []string                   `json:"asset_categories"`
+	LEF               domain.FAIRRange           `json:"lef"`
+	LossMagnitude     domain.FAIRRange           `json:"loss_magnitude"`
+	Impact            float64                    `json:"impact"`
+	SuggestedControls domain.ScenarioControlRefs `json:"suggested_controls"`
+}
+
+func (s *Service) ListScenarios(ctx context.Context, tenantID uuid.UUID) (*Library, error) {
+	all, err := s.scenarios(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return &Library{LibraryVersion: domain.ScenarioLibraryVersion, Scenarios: all}, nil
+}
+
+func (s *Service) scenarios(ctx context.Context, tenantID uuid.UUID) ([]domain.RiskScenario, error) {
+	custom, err := s.repo.ListScenarios(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	return append(domain.BuiltInScenarios(), custom...), nil
+}
+
+func (s *Service) GetScenario(ctx context.Context, tenantID, id uuid.UUID) (*domain.RiskScenario, error) {
+	if sc, ok := domain.BuiltInScenario(id); ok {
+		return sc, nil
+	}
+	sc, err := s.repo.GetScenario(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if sc == nil {
+		return nil, domain.NewNotFoundError("risk scenario", id)
+	}
+	return sc, nil
+}
+
+// SaveScenario creates (id nil) or updates a custom scenario; every update
+// bumps its version. Built-ins are read-only: a tenant copies one and adapts
+// the copy, and the library release stays what it says it is.
+func (s *Service) SaveScenario(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id *uuid.UUID, in ScenarioInput) (*domain.RiskScenario, error) {
+	var sc *domain.RiskScenario
+	action := domain.AuditActionCreate
+	if id == nil {
+		sc = &domain.RiskScenario{ID: uuid.New(), TenantID: tenantID, Version: 1, CreatedBy: actor}
+	} else {
+		if _, ok := domain.BuiltInScenario(*id); ok {
+			return nil, domain.NewValidationError("built-in scenarios cannot be edited — copy it into a custom one")
+		}
+		existing, err := s.GetScenario(ctx, tenantID, *id)
+		if err != nil {
+			return nil, err
+		}
+		sc, action = existing, domain.AuditActionUpdate
+		sc.Version++
+	}
+	sc.Key, sc.Title, sc.Description = in.Key, in.Title, in.Description
+	sc.ThreatActors, sc.Techniques, sc.AssetCategories = in.ThreatActors, in.Techniques, in.AssetCategories
+	sc.LEF, sc.LossMagnitude, sc.Impact = in.LEF, in.LossMagnitude, in.Impact
+	sc.SuggestedControls = in.SuggestedControls
+	if err := sc.Validate(); err != nil {
+		return nil, err
+	}
+	if err := ValidateControlRefs(sc.ControlRefs()); err != nil {
+		return nil, err
+	}
+	for _, b := range domain.BuiltInScenarios() {
+		if b.Key == sc.Key {
+			return nil, domain.NewValidationError(fmt.Sprintf("key %q belongs to a built-in scenario", sc.Key))
+		}
+	}
+	taken, err := s.repo.KeyTaken(ctx, tenantID, sc.Key, sc.ID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if taken {
+		return nil, domain.NewValidationError(fmt.Sprintf("another scenario already uses key %q", sc.Key))
+	}
+	if err := s.repo.SaveScenario(ctx, sc); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	s.record(ctx, tenantID, actor, action, sc.ID, "Risk scenario saved", domain.JSONMap{
+		"key": sc.Key, "version": sc.Version, "techniques": len(sc.Techniques),
+	})
+	return sc, nil
+}
+
+func (s *Service) DeleteScenario(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) error {
+	if _, ok := domain.BuiltInScenario(id); ok {
+		return domain.NewValidationError("built-in scenarios cannot be deleted")
+	}
+	if err := s.repo.DeleteScenario(ctx, tenantID, id); err != nil {
+		return err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, id, "Risk scenario deleted", nil)
+	return nil
+}
+
+// ValidateControlRefs checks every reference resolves to a control of a
+// catalog in pkg/compliance. A reference to nothing would never match a
+// tenant control, and the technique would read as uncovered forever.
+func ValidateControlRefs(refs []domain.ScenarioControlRef) error {
+	known := map[string]map[string]bool{}
+	for _, r := range refs {
+		codes, ok := known[r.Catalog]
+		if !ok {
+			cat, found := compliance.Get(r.Catalog)
+			if !found {
+				return domain.NewValidationError(fmt.Sprintf("unknown control catalog %q", r.Catalog))
+			}
+			codes = make(map[string]bool, len(cat.Controls))
+			for _, c := range cat.Controls {
+				codes[c.ReferenceCode] = true
+			}
+			known[r.Catalog] = codes
+		}
+		if !codes[r.Ref] {
+			return domain.NewValidationError(fmt.Sprintf("catalog %q has no control %q", r.Catalog, r.Ref))
+		}
+	}
+	return nil
+}
+
+// =============================================================================
+// Instantiation
+// =============================================================================
+
+// InstantiateInput is the body of POST /risk-scenarios/:id/instantiate.
+type InstantiateInput struct {
+	AssetIDs []uuid.UUID `json:"asset_ids"`
+}
+
+// DraftedRisk is one risk an instantiation produced or found.
+type DraftedRisk struct {
+	RiskID    uuid.UUID `json:"risk_id"`
+	AssetID   uuid.UUID `json:"asset_id"`
+	AssetName string    `json:"asset_name"`
+	Title     string    `json:"title,omitempty"`
+}
+
+// InstantiateResult reports what instantiation did. Existing lists assets that
+// already had a live risk from this scenario: instantiating twice adds
+// nothing. UnmatchedControls are the scenario's control references the tenant
+// has no control for — the frameworks it has not imported, in effect.
+type InstantiateResult struct {
+	ScenarioID        uuid.UUID                   `json:"scenario_id"`
+	ScenarioVersion   int                         `json:"scenario_version"`
+	Created           []DraftedRisk               `json:"created"`
+	Existing          []DraftedRisk               `json:"existing"`
+	MappedControls    int                         `json:"mapped_controls"`
+	UnmatchedControls []domain.ScenarioControlRef `json:"unmatched_controls"`
+}
+
+// Instantiate drafts one risk per selected asset from the scenario.
+//
+// Every risk is a DRAFT: the figures are the scenario's typical ranges, not an
+// assessment of this asset, and the risk goes through the same review as any
+// machine-proposed one. Each is linked to its asset and mapped to every tenant
+// control that answers one of the scenario's control references, so the
+// register's "Référentiel" column is filled from the start.
+func (s *Service) Instantiate(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID, in InstantiateInput) (*InstantiateResult, error) {
+	sc, err := s.GetScenario(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	ids := dedupe(in.AssetIDs)
+	if len(ids) == 0 {
+		return nil, domain.NewValidationError("select at least one asset")
+	}
+	if len(ids) > MaxInstantiateAssets {
+		return nil, domain.NewValidationError(fmt.Sprintf("at most %d assets per instantiation", MaxInstantiateAssets))
+	}
+	assets, err := s.repo.ListAssets(ctx, tenantID, ids)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	byID := make(map[uuid.UUID]*domain.Asset, len(assets))
+	for i := range assets {
+		byID[assets[i].ID] = &assets[i]
+	}
+	for _, aid := range ids {
+		a, ok := byID[aid]
+		if !ok {
+			return nil, domain.NewNotFoundError("asset", aid)
+		}
+		if !sc.AppliesTo(a.Category) {
+			return nil, domain.NewValidationError(fmt.Sprintf("scenario %q does not apply to %s (%s assets)", sc.Title, a.Name, a.Category))
+		}
+	}
+	existing, err := s.repo.ExistingScenarioRisks(ctx, tenantID, sc.ID, ids)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	controls, err := s.repo.ListCatalogControls(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	matched, unmatched := matchControls(sc.ControlRefs(), controls)
+
+	var by uuid.UUID
+	if actor != nil {
+		by = *actor
+	}
+	now := s.now()
+	res := &InstantiateResult{
+		ScenarioID: sc.ID, ScenarioVersion: sc.Version,
+		Created: []DraftedRisk{}, Existing: []DraftedRisk{}, UnmatchedControls: unmatched,
+	}
+	var risks []*domain.Risk
+	var mappings []domain.RiskControlMapping
+	for _, aid := range ids {
+		a := byID[aid]
+		if riskID, ok := existing[aid]; ok {
+			res.Existing = append(res.Existing, DraftedRisk{RiskID: riskID, AssetID: aid, AssetName: a.Name})
+			continue
+		}
+		risk := sc.DraftRisk(a, by, now)
+		risks = append(risks, risk)
+		for _, c := range matched {
+			controlID := c.ControlID
+			mappings = append(mappings, domain.RiskControlMapping{
+				ID: uuid.New(), TenantID: tenantID, RiskID: risk.ID,
+				FrameworkID: c.FrameworkID, ControlID: &controlID,
+				Note:      fmt.Sprintf("Suggested by scenario %q", sc.Key),
+				CreatedBy: actor, Source: domain.SourceScenario,
+			})
+		}
+		res.Created = append(res.Created, DraftedRisk{RiskID: risk.ID, AssetID: aid, AssetName: a.Name, Title: risk.Title})
+	}
+	if len(risks) > 0 {
+		if err := s.repo.CreateScenarioRisks(ctx, risks, mappings); err != nil {
+			return nil, domain.NewInternalError(err.Error())
+		}
+		res.MappedControls = len(matched)
+		s.record(ctx, tenantID, actor, domain.AuditActionCreate, sc.ID, "Risk scenario instantiated", domain.JSONMap{
+			"key": sc.Key, "version": sc.Version, "risks": len(risks), "mapped_controls": len(matched),
+		})
+	}
+	return res, nil
+}
+
+// matchControls returns the tenant controls answering any of refs, once each,
+// and the refs none answers.
+func matchControls(refs []domain.ScenarioControlRef, controls []domain.ScenarioCatalogControl) ([]domain.ScenarioCatalogControl, []domain.ScenarioControlRef) {
+	index := indexControls(controls)
+	seen := map[uuid.UUID]bool{}
+	var matched []domain.ScenarioCatalogControl
+	unmatched := []domain.ScenarioControlRef{}
+	for _, r := range refs {
+		hits := index[r]
+		if len(hits) == 0 {
+			unmatched = append(unmatched, r)
+			continue
+		}
+		for _, c := range hits {
+			if !seen[c.ControlID] {
+				seen[c.ControlID] = true
+				matched = append(matched, c)
+			}
+		}
+	}
+	return matched, unmatched
+}
+
+func indexControls(controls []domain.ScenarioCatalogControl) map[domain.ScenarioControlRef][]domain.ScenarioCatalogControl {
+	index := make(map[domain.ScenarioControlRef][]domain.ScenarioCatalogControl, len(controls))
+	for _, c := range controls {
+		index[c.Ref()] = append(index[c.Ref()], c)
+	}
+	return index
+}
+
+func dedupe(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	out := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if id != uuid.Nil && !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// =============================================================================
+// Coverage
+// =============================================================================
+
+// CoverageStatus is how well the tenant's controls answer a technique.
+type CoverageStatus string
+
+const (
+	// CoverageCovered: at least one mitigating control is implemented.
+	CoverageCovered CoverageStatus = "covered"
+	// CoveragePartial: none implemented, but one is in progress.
+	CoveragePartial CoverageStatus = "partial"
+	// CoverageUncovered: no mitigating control is implemented or in progress —
+	// including when the tenant has not imported any catalog the technique's
+	// mitigations come from.
+	CoverageUncovered CoverageStatus = "uncovered"
+)
+
+// MitigationCoverage is one control reference of a technique and the tenant
+// controls answering it. An empty Controls means the tenant has no such
+// control.
+type MitigationCoverage struct {
+	domain.ScenarioControlRef
+	Controls []CoveredControl `json:"controls"`
+}
+
+// CoveredControl is a tenant control answering a reference.
+type CoveredControl struct {
+	ControlID   uuid.UUID            `json:"control_id"`
+	FrameworkID uuid.UUID            `json:"framework_id"`
+	Name        string               `json:"name"`
+	Status      domain.ControlStatus `json:"status"`
+}
+
+// TechniqueCoverage is one row of the coverage report.
+type TechniqueCoverage struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Tactic      string               `json:"tactic"`
+	Status      CoverageStatus       `json:"status"`
+	Scenarios   []string             `json:"scenarios"`
+	Mitigations []MitigationCoverage `json:"mitigations"`
+}
+
+// CoverageSummary counts techniques by status.
+type CoverageSummary struct {
+	Techniques int `json:"techniques"`
+	Covered    int `json:"covered"`
+	Partial    int `json:"partial"`
+	Uncovered  int `json:"uncovered"`
+}
+
+// CoverageReport is GET /risk-scenarios/coverage.
+type CoverageReport struct {
+	LibraryVersion string              `json:"library_version"`
+	Summary        CoverageSummary     `json:"summary"`
+	Techniques     []TechniqueCoverage `json:"techniques"`
+}
+
+// Coverage reports, for every ATT&CK technique the tenant's scenario library
+// uses (or one scenario's, when scenarioID is set), whether a control of the
+// tenant mitigates it. Uncovered techniques come first: the report is a work
+// list, not a scorecard.
+func (s *Service) Coverage(ctx context.Context, tenantID uuid.UUID, scenarioID *uuid.UUID) (*CoverageReport, error) {
+	var scenarios []domain.RiskScenario
+	if scenarioID != nil {
+		sc, err := s.GetScenario(ctx, tenantID, *scenarioID)
+		if err != nil {
+			return nil, err
+		}
+		scenarios = []domain.RiskScenario{*sc}
+	} else {
+		all, err := s.scenarios(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		scenarios = all
+	}
+	controls, err := s.repo.ListCatalogControls(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	index := indexControls(controls)
+
+	rows := map[string]*TechniqueCoverage{}
+	refsOf := map[string]map[domain.ScenarioControlRef]bool{}
+	var order []string
+	for _, sc := range scenarios {
+		for _, t := range sc.Techniques {
+			row, ok := rows[t.ID]
+			if !ok {
+				row = &TechniqueCoverage{ID: t.ID, Name: t.Name, Tactic: t.Tactic, Scenarios: []string{}, Mitigations: []MitigationCoverage{}}
+				rows[t.ID], refsOf[t.ID] = row, map[domain.ScenarioControlRef]bool{}
+				order = append(order, t.ID)
+			}
+			row.Scenarios = append(row.Scenarios, sc.Key)
+			// Two scenarios may map the same technique differently (a custom one
+			// adding a control of the tenant's own catalog): the union counts.
+			for _, r := range t.Mitigations {
+				if refsOf[t.ID][r] {
+					continue
+				}
+				refsOf[t.ID][r] = true
+				m := MitigationCoverage{ScenarioControlRef: r, Controls: []CoveredControl{}}
+				for _, c := range index[r] {
+					m.Controls = append(m.Controls, CoveredControl{ControlID: c.ControlID, FrameworkID: c.FrameworkID, Name: c.Name, Status: c.Status})
+				}
+				row.Mitigations = append(row.Mitigations, m)
+			}
+		}
+	}
+
+	report := &CoverageReport{LibraryVersion: domain.ScenarioLibraryVersion, Techniques: make([]TechniqueCoverage, 0, len(order))}
+	for _, id := range order {
+		row := rows[id]
+		row.Status = techniqueStatus(row.Mitigations)
+		switch row.Status {
+		case CoverageCovered:
+			report.Summary.Covered++
+		case CoveragePartial:
+			report.Summary.Partial++
+		default:
+			report.Summary.Uncovered++
+		}
+		report.Techniques = append(report.Techniques, *row)
+	}
+	report.Summary.Techniques = len(report.Techniques)
+	rank := map[CoverageStatus]int{CoverageUncovered: 0, CoveragePartial: 1, CoverageCovered: 2}
+	sort.SliceStable(report.Techniques, func(i, j int) bool {
+		a, b := report.Techniques[i], report.Techniques[j]
+		if rank[a.Status] != rank[b.Status] {
+			return rank[a.Status] < rank[b.Status]
+		}
+		return a.ID < b.ID
+	})
+	return report, nil
+}
+
+func techniqueStatus(ms []MitigationCoverage) CoverageStatus {
+	status := CoverageUncovered
+	for _, m := range ms {
+		for _, c := range m.Controls {
+			switch c.Status {
+			case domain.ControlStatusImplemented:
+				return CoverageCovered
+			case domain.ControlStatusInProgress:
+				status = CoveragePartial
+			}
+		}
+	}
+	return status
+}
+
+func (s *Service) record(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, action domain.AuditAction, id uuid.UUID, summary string, after domain.JSONMap) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    actor,
+		Action:     action,
+		EntityType: "risk_scenario",
+		EntityID:   id.String(),
+		Summary:    summary,
+		After:      after,
+	})
+}
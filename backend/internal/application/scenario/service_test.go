@@ -0,0 +1,253 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package scenario
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+type memScenarios struct {
+	scenarios map[uuid.UUID]domain.RiskScenario
+	assets    map[uuid.UUID]domain.Asset
+	controls  []domain.ScenarioCatalogControl
+	risks     []*domain.Risk
+	mappings  []domain.RiskControlMapping
+}
+
+func newMemScenarios() *memScenarios {
+	return &memScenarios{scenarios: map[uuid.UUID]domain.RiskScenario{}, assets: map[uuid.UUID]domain.Asset{}}
+}
+
+func (m *memScenarios) ListScenarios(_ context.Context, tenantID uuid.UUID) ([]domain.RiskScenario, error) {
+	var out []domain.RiskScenario
+	for _, s := range m.scenarios {
+		if s.TenantID == tenantID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+func (m *memScenarios) GetScenario(_ context.Context, tenantID, id uuid.UUID) (*domain.RiskScenario, error) {
+	if s, ok := m.scenarios[id]; ok && s.TenantID == tenantID {
+		return &s, nil
+	}
+	return nil, nil
+}
+func (m *memScenarios) SaveScenario(_ context.Context, s *domain.RiskScenario) error {
+	m.scenarios[s.ID] = *s
+	return nil
+}
+func (m *memScenarios) DeleteScenario(_ context.Context, tenantID, id uuid.UUID) error {
+	if s, ok := m.scenarios[id]; !ok || s.TenantID != tenantID {
+		return domain.NewNotFoundError("risk scenario", id)
+	}
+	delete(m.scenarios, id)
+	return nil
+}
+func (m *memScenarios) KeyTaken(_ context.Context, tenantID uuid.UUID, key string, except uuid.UUID) (bool, error) {
+	for _, s := range m.scenarios {
+		if s.TenantID == tenantID && s.Key == key && s.ID != except {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+func (m *memScenarios) ListCatalogControls(context.Context, uuid.UUID) ([]domain.ScenarioCatalogControl, error) {
+	return m.controls, nil
+}
+func (m *memScenarios) ListAssets(_ context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]domain.Asset, error) {
+	var out []domain.Asset
+	for _, id := range ids {
+		if a, ok := m.assets[id]; ok && a.TenantID == tenantID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+func (m *memScenarios) ExistingScenarioRisks(_ context.Context, tenantID, scenarioID uuid.UUID, _ []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	out := map[uuid.UUID]uuid.UUID{}
+	for _, r := range m.risks {
+		if r.TenantID == tenantID && *r.SourceScenarioID == scenarioID {
+			out[*r.AssetID] = r.ID
+		}
+	}
+	return out, nil
+}
+func (m *memScenarios) CreateScenarioRisks(_ context.Context, risks []*domain.Risk, mappings []domain.RiskControlMapping) error {
+	m.risks = append(m.risks, risks...)
+	m.mappings = append(m.mappings, mappings...)
+	return nil
+}
+
+var fixedNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func newTestService() (*Service, *memScenarios) {
+	repo := newMemScenarios()
+	return NewService(repo).WithClock(func() time.Time { return fixedNow }), repo
+}
+
+func customInput() ScenarioInput {
+	return ScenarioInput{
+		Key: "payroll-fraud", Title: "Payroll diversion",
+		ThreatActors: domain.ThreatActors{{Name: "Fraudster", Kind: domain.ThreatActorCriminal}},
+		Techniques: domain.ScenarioTechniques{{ID: "T1078", Mitigations: []domain.ScenarioControlRef{
+			{Catalog: "cis-v8", Ref: "CIS-5"}, {Catalog: "iso27001-2022", Ref: "A.8.5"},
+		}}},
+		AssetCategories: []string{"application"},
+		LEF:             domain.FAIRRange{Min: 0.1, Mode: 0.5, Max: 2},
+		LossMagnitude:   domain.FAIRRange{Min: 1e6, Mode: 5e6, Max: 2e7},
+		Impact:          6,
+	}
+}
+
+// The built-in library must only name controls that exist in pkg/compliance:
+// a reference to nothing would leave its technique uncovered in every tenant.
+func TestBuiltInScenarios_ControlRefsResolve(t *testing.T) {
+	for _, sc := range domain.BuiltInScenarios() {
+		assert.NoError(t, ValidateControlRefs(sc.ControlRefs()), sc.Key)
+	}
+}
+
+func TestSaveScenario_VersionsAndGuards(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestService()
+	tenant, other := uuid.New(), uuid.New()
+
+	sc, err := svc.SaveScenario(ctx, tenant, nil, nil, customInput())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sc.Version)
+	assert.Equal(t, "Valid Accounts", sc.Techniques[0].Name)
+
+	in := customInput()
+	in.Title = "Payroll diversion fraud"
+	updated, err := svc.SaveScenario(ctx, tenant, nil, &sc.ID, in)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Version, "every update bumps the version")
+
+	lib, err := svc.ListScenarios(ctx, tenant)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScenarioLibraryVersion, lib.LibraryVersion)
+	assert.Len(t, lib.Scenarios, len(domain.BuiltInScenarios())+1)
+
+	_, err = svc.SaveScenario(ctx, tenant, nil, nil, customInput())
+	assert.ErrorIs(t, err, domain.ErrValidation, "key already taken")
+	dup := customInput()
+	dup.Key = "ransomware-erp"
+	_, err = svc.SaveScenario(ctx, tenant, nil, nil, dup)
+	assert.ErrorIs(t, err, domain.ErrValidation, "built-in key")
+	bad := customInput()
+	bad.Key = "other"
+	bad.SuggestedControls = domain.ScenarioControlRefs{{Catalog: "cis-v8", Ref: "CIS-99"}}
+	_, err = svc.SaveScenario(ctx, tenant, nil, nil, bad)
+	assert.ErrorIs(t, err, domain.ErrValidation, "unknown control")
+
+	builtIn := domain.BuiltInScenarioID("bec-fraud")
+	_, err = svc.SaveScenario(ctx, tenant, nil, &builtIn, customInput())
+	assert.ErrorIs(t, err, domain.ErrValidation)
+	assert.ErrorIs(t, svc.DeleteScenario(ctx, tenant, nil, builtIn), domain.ErrValidation)
+
+	_, err = svc.SaveScenario(ctx, other, nil, &sc.ID, customInput())
+	assert.ErrorIs(t, err, domain.ErrNotFound, "another tenant's scenario")
+	assert.ErrorIs(t, svc.DeleteScenario(ctx, other, nil, sc.ID), domain.ErrNotFound)
+}
+
+func TestInstantiate_DraftsMapsAndIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newTestService()
+	tenant := uuid.New()
+	erp := domain.Asset{ID: uuid.New(), TenantID: tenant, Name: "ERP", Category: domain.CategoryApplication, Criticality: domain.CriticalityHigh}
+	legacy := domain.Asset{ID: uuid.New(), TenantID: tenant, Name: "Old box"}
+	repo.assets[erp.ID], repo.assets[legacy.ID] = erp, legacy
+	backup := domain.ScenarioCatalogControl{ControlID: uuid.New(), FrameworkID: uuid.New(), CatalogKey: "iso27001-2022",
+		ReferenceCode: "A.8.13", Status: domain.ControlStatusImplemented}
+	repo.controls = []domain.ScenarioCatalogControl{backup}
+
+	id := domain.BuiltInScenarioID("ransomware-erp")
+	actor := uuid.New()
+	res, err := svc.Instantiate(ctx, tenant, &actor, id, InstantiateInput{AssetIDs: []uuid.UUID{erp.ID, legacy.ID, erp.ID}})
+	require.NoError(t, err)
+	require.Len(t, res.Created, 2, "duplicates in the selection are dropped")
+	assert.Equal(t, 1, res.MappedControls)
+	assert.NotEmpty(t, res.UnmatchedControls)
+	require.Len(t, repo.mappings, 2, "A.8.13 maps once per risk although two techniques name it")
+	assert.Equal(t, backup.ControlID, *repo.mappings[0].ControlID)
+	assert.Equal(t, domain.SourceScenario, repo.mappings[0].Source)
+	for _, r := range repo.risks {
+		assert.Equal(t, domain.StateDraft, r.State())
+		assert.Equal(t, actor, r.CreatedBy)
+	}
+
+	again, err := svc.Instantiate(ctx, tenant, &actor, id, InstantiateInput{AssetIDs: []uuid.UUID{erp.ID}})
+	require.NoError(t, err)
+	assert.Empty(t, again.Created)
+	require.Len(t, again.Existing, 1)
+	assert.Len(t, repo.risks, 2)
+}
+
+func TestInstantiate_Rejections(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newTestService()
+	tenant, other := uuid.New(), uuid.New()
+	laptop := domain.Asset{ID: uuid.New(), TenantID: tenant, Name: "Laptop", Category: domain.CategoryWorkstation}
+	foreign := domain.Asset{ID: uuid.New(), TenantID: other, Name: "Theirs", Category: domain.CategoryCloud}
+	repo.assets[laptop.ID], repo.assets[foreign.ID] = laptop, foreign
+	cloud := domain.BuiltInScenarioID("cloud-data-exposure")
+
+	_, err := svc.Instantiate(ctx, tenant, nil, cloud, InstantiateInput{AssetIDs: []uuid.UUID{laptop.ID}})
+	assert.ErrorIs(t, err, domain.ErrValidation, "a workstation is not a cloud data store")
+	_, err = svc.Instantiate(ctx, tenant, nil, cloud, InstantiateInput{AssetIDs: []uuid.UUID{foreign.ID}})
+	assert.ErrorIs(t, err, domain.ErrNotFound, "another tenant's asset")
+	_, err = svc.Instantiate(ctx, tenant, nil, cloud, InstantiateInput{})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+	_, err = svc.Instantiate(ctx, tenant, nil, uuid.New(), InstantiateInput{AssetIDs: []uuid.UUID{laptop.ID}})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Empty(t, repo.risks)
+}
+
+func TestCoverage_UncoveredFirst(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newTestService()
+	tenant := uuid.New()
+	repo.controls = []domain.ScenarioCatalogControl{
+		{ControlID: uuid.New(), CatalogKey: "cis-v8", ReferenceCode: "CIS-5", Status: domain.ControlStatusImplemented},
+		{ControlID: uuid.New(), CatalogKey: "cis-v8", ReferenceCode: "CIS-9", Status: domain.ControlStatusInProgress},
+		{ControlID: uuid.New(), CatalogKey: "cis-v8", ReferenceCode: "CIS-14", Status: domain.ControlStatusNotApplicable},
+	}
+
+	id := domain.BuiltInScenarioID("bec-fraud")
+	report, err := svc.Coverage(ctx, tenant, &id)
+	require.NoError(t, err)
+	status := map[string]CoverageStatus{}
+	for _, row := range report.Techniques {
+		status[row.ID] = row.Status
+	}
+	assert.Equal(t, CoverageCovered, status["T1078"], "CIS-5 implemented")
+	assert.Equal(t, CoveragePartial, status["T1566"], "CIS-9 in progress")
+	assert.Equal(t, CoverageUncovered, status["T1656"], "a not-applicable control mitigates nothing")
+	assert.Equal(t, CoverageUncovered, report.Techniques[0].Status, "uncovered techniques come first")
+	assert.Equal(t, report.Summary.Techniques, report.Summary.Covered+report.Summary.Partial+report.Summary.Uncovered)
+
+	all, err := svc.Coverage(ctx, tenant, nil)
+	require.NoError(t, err)
+	assert.Greater(t, all.Summary.Techniques, report.Summary.Techniques)
+	for _, row := range all.Techniques {
+		if row.ID == "T1078" {
+			assert.Greater(t, len(row.Scenarios), 1, "a technique lists every scenario using it")
+		}
+	}
+
+	unknown := uuid.New()
+	_, err = svc.Coverage(ctx, tenant, &unknown)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
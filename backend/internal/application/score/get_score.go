@@ -67,6 +67,11 @@ type (
 	IncidentPressureReader interface {
 		OpenIncidentCounts(ctx context.Context, tenantID uuid.UUID) (open, criticalOpen int, err error)
 	}
+	// AssetIncidentReader reports open/critical incident counts for one asset
+	// (incidents whose impact lists it).
+	AssetIncidentReader interface {
+		OpenIncidentCountsForAsset(ctx context.Context, tenantID, assetID uuid.UUID) (open, criticalOpen int, err error)
+	}
 	// MitigationReader lists the mitigation plans of one risk, for the residual
 	// score. Signature mirrors GormMitigationRepository exactly (string tenant,
 	// no context) — this port exists to consume that repository, not to redesign it.
@@ -77,16 +82,17 @@ type (
 
 // UseCase computes the canonical score for any scope.
 type UseCase struct {
-	riskCounts RiskCounter
-	risk       RiskReader
-	risks      RiskLister
-	assets     AssetReader
-	compliance ComplianceCounter
-	vulnStats  VulnStatsReader
-	vulns      VulnListReader
-	incidents  IncidentPressureReader
-	mitigation MitigationReader
-	now        func() time.Time
+	riskCounts     RiskCounter
+	risk           RiskReader
+	risks          RiskLister
+	assets         AssetReader
+	compliance     ComplianceCounter
+	vulnStats      VulnStatsReader
+	vulns          VulnListReader
+	incidents      IncidentPressureReader
+	assetIncidents AssetIncidentReader
+	mitigation     MitigationReader
+	now            func() time.Time
 }
 
 // New builds a use case with no sources attached.
@@ -103,6 +109,10 @@ func (uc *UseCase) WithVulnStats(s VulnStatsReader) *UseCase        { uc.vulnSta
 func (uc *UseCase) WithVulns(s VulnListReader) *UseCase             { uc.vulns = s; return uc }
 func (uc *UseCase) WithIncidents(s IncidentPressureReader) *UseCase { uc.incidents = s; return uc }
 func (uc *UseCase) WithMitigations(s MitigationReader) *UseCase     { uc.mitigation = s; return uc }
+func (uc *UseCase) WithAssetIncidents(s AssetIncidentReader) *UseCase {
+	uc.assetIncidents = s
+	return uc
+}
 
 // Execute computes the score for one scope.
 //
@@ -336,6 +346,16 @@ func (uc *UseCase) assetScore(ctx context.Context, tenantID, assetID uuid.UUID)
 		}
 	}
 
+	// Incident pressure on the asset: the open incidents that name it in their
+	// impact (declared by hand, or attributed from TheHive observables).
+	if uc.assetIncidents != nil {
+		if open, criticalOpen, err := uc.assetIncidents.OpenIncidentCountsForAsset(ctx, tenantID, assetID); err == nil {
+			in.OpenIncidents = open
+			in.CriticalOpenIncidents = criticalOpen
+			in.HasIncidentData = true
+		}
+	}
+
 	// Internet exposure is NOT wired: domain.Asset carries no reachability signal
 	// today (no tags, and Type alone does not settle it — a "Server" may be
 	// air-gapped or public). HasExposureData stays false, so the factor is
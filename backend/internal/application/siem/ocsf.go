@@ -0,0 +1,168 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package siem
+
+import (
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// OCSFVersion is the schema version the events conform to.
+const OCSFVersion = "1.1.0"
+
+// OCSF class and category ids used here.
+const (
+	ocsfCategoryIAM         = 3
+	ocsfClassAuthentication = 3002
+	ocsfCategoryApplication = 6
+	ocsfClassAPIActivity    = 6003
+)
+
+// ocsfAudit maps an audit chain event to OCSF API Activity. The chain fields
+// that have no OCSF attribute (sequence, hashes) go in unmapped, under the
+// names the audit API uses.
+func ocsfAudit(version string, e domain.AuditEvent, severity int) map[string]any {
+	activity := 99 // Other
+	switch e.Action {
+	case domain.AuditActionCreate, domain.AuditActionSubmit:
+		activity = 1
+	case domain.AuditActionView, domain.AuditActionDownload, domain.AuditActionExport:
+		activity = 2
+	case domain.AuditActionUpdate, domain.AuditActionApprove, domain.AuditActionReject, domain.AuditActionDelegate:
+		activity = 3
+	case domain.AuditActionDelete, domain.AuditActionRevoke:
+		activity = 4
+	}
+	status := 1 // Success
+	if e.StatusCode >= 400 {
+		status = 2
+	}
+
+	user := map[string]any{}
+	if e.ActorID != nil {
+		user["uid"] = e.ActorID.String()
+	}
+	if e.ActorEmail != "" {
+		user["email_addr"] = e.ActorEmail
+	}
+	ev := map[string]any{
+		"category_uid": ocsfCategoryApplication,
+		"class_uid":    ocsfClassAPIActivity,
+		"activity_id":  activity,
+		"type_uid":     ocsfClassAPIActivity*100 + activity,
+		"time":         e.CreatedAt.UnixMilli(),
+		"severity_id":  ocsfSeverity(severity),
+		"status_id":    status,
+		"message":      e.Summary,
+		"metadata":     ocsfMetadata(version, e.ID.String(), "audit", e.TenantID.String()),
+		"actor":        map[string]any{"user": user},
+		"api": map[string]any{
+			"operation": string(e.Action),
+			"request":   map[string]any{"uid": e.RequestID},
+		},
+		"src_endpoint": map[string]any{"ip": clientIP(e.IPAddress)},
+		"resources":    []map[string]any{{"type": e.EntityType, "uid": e.EntityID}},
+		"unmapped": map[string]any{
+			"sequence":       e.Sequence,
+			"hash":           e.Hash,
+			"prev_hash":      e.PrevHash,
+			"changed_fields": e.ChangedFields,
+			"source":         e.Source,
+		},
+	}
+	if e.Method != "" || e.Path != "" || e.UserAgent != "" {
+		ev["http_request"] = map[string]any{
+			"http_method": e.Method,
+			"url":         map[string]any{"path": e.Path},
+			"user_agent":  e.UserAgent,
+		}
+	}
+	if e.StatusCode != 0 {
+		ev["http_response"] = map[string]any{"code": e.StatusCode}
+	}
+	return ev
+}
+
+// ocsfAuth maps an auth log entry to OCSF Authentication.
+func ocsfAuth(version string, l domain.AuthAuditLog, severity int) map[string]any {
+	activity := 99 // Other
+	switch l.Action {
+	case "login", "mfa_verify", "pat_use":
+		activity = 1 // Logon
+	case "logout", "session_revoke", "session_revoke_all":
+		activity = 2 // Logoff
+	case "refresh", "refresh_reuse":
+		activity = 3 // Authentication Ticket
+	}
+	status := 1
+	if !l.Success {
+		status = 2
+	}
+	tenant := ""
+	if l.TenantID != nil {
+		tenant = l.TenantID.String()
+	}
+	user := map[string]any{}
+	if l.UserID != nil {
+		user["uid"] = l.UserID.String()
+	}
+	src := map[string]any{"ip": clientIP(l.IP)}
+	if l.GeoCountry != nil {
+		src["location"] = map[string]any{"country": *l.GeoCountry}
+	}
+	ev := map[string]any{
+		"category_uid": ocsfCategoryIAM,
+		"class_uid":    ocsfClassAuthentication,
+		"activity_id":  activity,
+		"type_uid":     ocsfClassAuthentication*100 + activity,
+		"time":         l.CreatedAt.UnixMilli(),
+		"severity_id":  ocsfSeverity(severity),
+		"status_id":    status,
+		"is_mfa":       l.Action == "mfa_verify",
+		"metadata":     ocsfMetadata(version, l.ID.String(), "auth", tenant),
+		"user":         user,
+		"src_endpoint": src,
+		"http_request": map[string]any{"user_agent": l.UserAgent},
+		"unmapped":     map[string]any{"action": l.Action},
+	}
+	if l.FailureReason != nil {
+		ev["status_detail"] = *l.FailureReason
+	}
+	if l.DeviceFingerprint != nil {
+		ev["device"] = map[string]any{"uid": *l.DeviceFingerprint}
+	}
+	return ev
+}
+
+func ocsfMetadata(version, uid, logName, tenant string) map[string]any {
+	return map[string]any{
+		"version":    OCSFVersion,
+		"uid":        uid,
+		"log_name":   logName,
+		"tenant_uid": tenant,
+		"product": map[string]any{
+			"name":        cefProduct,
+			"vendor_name": cefVendor,
+			"version":     version,
+		},
+	}
+}
+
+// ocsfSeverity maps the 0-10 severity onto severity_id: Informational, Low,
+// Medium, High, Critical.
+func ocsfSeverity(severity int) int {
+	switch {
+	case severity >= 9:
+		return 5
+	case severity >= 7:
+		return 4
+	case severity >= 5:
+		return 3
+	case severity >= 4:
+		return 2
+	default:
+		return 1
+	}
+}
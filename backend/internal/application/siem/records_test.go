@@ -0,0 +1,95 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package siem
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+func auditEvent() domain.AuditEvent {
+	actor := uuid.New()
+	return domain.AuditEvent{
+		ID: uuid.New(), TenantID: uuid.New(), ActorID: &actor,
+		Action: domain.AuditActionDelete, EntityType: "user", EntityID: "42",
+		Summary:   "Deleted user a|b=c",
+		IPAddress: "203.0.113.9, 10.0.0.1", UserAgent: "curl/8", Method: "DELETE", Path: "/api/v1/users/42",
+		StatusCode: 204, Sequence: 17, Hash: "abc", PrevHash: "def",
+		CreatedAt: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestCEF_HeaderAndExtensionAreEscaped(t *testing.T) {
+	e := auditEvent()
+	e.ChangedFields = domain.StringList{"email", "role"}
+	recs, err := EncodeAudit(domain.SIEMFormatCEF, "1.4.0", []domain.AuditEvent{e})
+	require.NoError(t, err)
+	line := string(recs[0].Body)
+
+	assert.True(t, strings.HasPrefix(line, `CEF:0|OpenDefender|OpenRisk|1.4.0|audit.user.delete|Deleted user a\|b=c|6|`), line)
+	assert.Contains(t, line, "src=203.0.113.9 ", "the first forwarded hop, as an address")
+	assert.Contains(t, line, "cn1Label=sequence cn1=17")
+	assert.Contains(t, line, "cs4Label=hash cs4=abc")
+	assert.Contains(t, line, "cs6=email,role")
+	assert.Contains(t, line, "outcome=success")
+	assert.NotContains(t, line, "\n")
+
+	e.Summary = "line\nbreak"
+	e.EntityID = `a=b\c`
+	recs, _ = EncodeAudit(domain.SIEMFormatCEF, "1.4.0", []domain.AuditEvent{e})
+	line = string(recs[0].Body)
+	assert.Contains(t, line, "|line break|", "no line break in the header")
+	assert.Contains(t, line, `cs3=a\=b\\c`)
+}
+
+func TestCEF_AuthSeverityFollowsTheSignal(t *testing.T) {
+	tenant, user := uuid.New(), uuid.New()
+	reason := "invalid_password"
+	logs := []domain.AuthAuditLog{
+		{ID: uuid.New(), TenantID: &tenant, UserID: &user, Action: "login", Success: true, IP: "198.51.100.4"},
+		{ID: uuid.New(), TenantID: &tenant, UserID: &user, Action: "login", Success: false, FailureReason: &reason},
+		{ID: uuid.New(), TenantID: &tenant, UserID: &user, Action: "refresh_reuse", Success: false},
+	}
+	recs, err := EncodeAuth(domain.SIEMFormatCEF, "dev", logs)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 6, 9}, []int{recs[0].Severity, recs[1].Severity, recs[2].Severity})
+	assert.Contains(t, string(recs[1].Body), "|auth.login|login failure|6|")
+	assert.Contains(t, string(recs[1].Body), "reason=invalid_password")
+}
+
+func TestOCSF_ClassesAndIDs(t *testing.T) {
+	recs, err := EncodeAudit(domain.SIEMFormatOCSF, "1.4.0", []domain.AuditEvent{auditEvent()})
+	require.NoError(t, err)
+	var ev map[string]any
+	require.NoError(t, json.Unmarshal(recs[0].Body, &ev))
+	assert.EqualValues(t, 6003, ev["class_uid"])
+	assert.EqualValues(t, 6, ev["category_uid"])
+	assert.EqualValues(t, 4, ev["activity_id"], "delete")
+	assert.EqualValues(t, 600304, ev["type_uid"])
+	assert.EqualValues(t, 3, ev["severity_id"])
+	meta := ev["metadata"].(map[string]any)
+	assert.Equal(t, OCSFVersion, meta["version"])
+	assert.Equal(t, "1.4.0", meta["product"].(map[string]any)["version"])
+	assert.EqualValues(t, 17, ev["unmapped"].(map[string]any)["sequence"])
+
+	tenant := uuid.New()
+	recs, err = EncodeAuth(domain.SIEMFormatOCSF, "dev", []domain.AuthAuditLog{
+		{ID: uuid.New(), TenantID: &tenant, Action: "logout", Success: true},
+	})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(recs[0].Body, &ev))
+	assert.EqualValues(t, 3002, ev["class_uid"])
+	assert.EqualValues(t, 300202, ev["type_uid"], "logoff")
+	assert.EqualValues(t, 1, ev["status_id"])
+}
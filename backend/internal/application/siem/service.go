@@ -0,0 +1,536 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package siem streams the audit chain and the auth log to a tenant's SIEM
+// over syslog (RFC 5424 over TLS) or an HTTP Event Collector, encoded as CEF
+// or OCSF. Each destination keeps a cursor per log; the export worker ships
+// what lies past it and moves it only once the batch is accepted. See
+// docs/SIEM_EXPORT.md.
+package siem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/netguard"
+)
+
+// SecretCipher encrypts HEC tokens at rest. The scanner's AES-256-GCM
+// CredentialCipher (SCANNER_CREDENTIAL_KEY) satisfies it.
+type SecretCipher interface {
+	EncryptCredentials(creds map[string]string) (string, error)
+	DecryptCredentials(ciphertext string) (map[string]string, error)
+}
+
+// AuditSink records destination changes in the audit chain.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+const (
+	// exportLease is how long a claimed destination belongs to its worker.
+	// A run stops taking new batches at half of it, so only a worker that
+	// died loses its claim.
+	exportLease = 5 * time.Minute
+	// authSettle keeps the exporter this far behind the auth log. Entries are
+	// stamped before they are inserted, so one can land with a created_at
+	// just behind a cursor that already moved past; reading only entries
+	// older than this leaves inserts time to commit. The audit chain needs
+	// no such lag — sequences are assigned under a per-tenant lock.
+	authSettle = 30 * time.Second
+	firstRetry = 30 * time.Second
+	maxRetry   = 15 * time.Minute
+)
+
+// errLeaseLost stops a run whose claim was taken over or voided by a rewind.
+var errLeaseLost = errors.New("siem: lease lost")
+
+// Service is the SIEM export use cases.
+type Service struct {
+	repo      domain.SIEMRepository
+	cipher    SecretCipher
+	audit     AuditSink
+	transport *transport
+	version   string
+	now       func() time.Time
+}
+
+// NewService builds the service.
+func NewService(repo domain.SIEMRepository) *Service {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "openrisk"
+	}
+	return &Service{repo: repo, transport: &transport{hostname: host}, version: "dev", now: time.Now}
+}
+
+// WithCipher wires HEC token storage. Without it HEC destinations cannot be
+// created.
+func (s *Service) WithCipher(c SecretCipher) *Service {
+	s.cipher = c
+	return s
+}
+
+// WithAudit enables audit recording.
+func (s *Service) WithAudit(a AuditSink) *Service {
+	s.audit = a
+	return s
+}
+
+// AllowPrivateNetworks lets destinations be internal collectors — the usual
+// case for a self-hosted install, whose SIEM sits on the same network.
+func (s *Service) AllowPrivateNetworks(allow bool) *Service {
+	s.transport.allowPrivate = allow
+	return s
+}
+
+// WithProductVersion sets the version reported in CEF headers and OCSF
+// metadata.
+func (s *Service) WithProductVersion(v string) *Service {
+	if v != "" {
+		s.version = v
+	}
+	return s
+}
+
+// WithClock overrides the clock (tests).
+func (s *Service) WithClock(now func() time.Time) *Service {
+	s.now = now
+	return s
+}
+
+// =============================================================================
+// Destinations
+// =============================================================================
+
+// DestinationInput creates or updates a destination. Nil fields are
+// unchanged on update. Token is the HEC token; an empty string keeps the
+// stored one.
+type DestinationInput struct {
+	Name      *string  `json:"name"`
+	Transport *string  `json:"transport"`
+	Format    *string  `json:"format"`
+	Endpoint  *string  `json:"endpoint"`
+	Sources   []string `json:"sources"`
+	Enabled   *bool    `json:"enabled"`
+	CACertPEM *string  `json:"ca_cert_pem"`
+	Token     *string  `json:"token"`
+}
+
+// BackfillInput rewinds a destination. FromSequence re-ships the audit chain
+// from that sequence; AuthSince re-ships the auth log from that instant.
+type BackfillInput struct {
+	FromSequence *int64     `json:"from_sequence"`
+	AuthSince    *time.Time `json:"auth_since"`
+}
+
+// Destinations lists the tenant's destinations.
+func (s *Service) Destinations(ctx context.Context, tenantID uuid.UUID) ([]domain.SIEMDestination, error) {
+	items, err := s.repo.ListDestinations(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	return items, nil
+}
+
+// Destination returns one destination.
+func (s *Service) Destination(ctx context.Context, tenantID, id uuid.UUID) (*domain.SIEMDestination, error) {
+	d, err := s.repo.GetDestination(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if d == nil {
+		return nil, domain.NewNotFoundError("SIEM destination", id)
+	}
+	return d, nil
+}
+
+// CreateDestination adds a destination. It starts at the head of both logs:
+// what happened before it existed is shipped only when asked for, with
+// Backfill.
+func (s *Service) CreateDestination(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, in DestinationInput) (*domain.SIEMDestination, error) {
+	if in.Name == nil || in.Transport == nil || in.Format == nil || in.Endpoint == nil || len(in.Sources) == 0 {
+		return nil, domain.NewValidationError("name, transport, format, endpoint and sources are required")
+	}
+	head, err := s.repo.AuditHead(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	now := s.now().UTC()
+	d := &domain.SIEMDestination{
+		ID:            uuid.New(),
+		TenantID:      tenantID,
+		Enabled:       true,
+		AuditCursor:   head,
+		AuthCursorAt:  &now,
+		NextAttemptAt: now,
+		CreatedBy:     actor,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.apply(d, in); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateDestination(ctx, d); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionCreate, d.ID,
+		fmt.Sprintf("Created SIEM destination %q (%s, %s) to %s", d.Name, d.Transport, d.Format, d.Endpoint), destinationAudit(d))
+	return d, nil
+}
+
+// UpdateDestination changes a destination's configuration and makes it due
+// now, so a corrected endpoint is tried without waiting out the backoff.
+func (s *Service) UpdateDestination(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID, in DestinationInput) (*domain.SIEMDestination, error) {
+	d, err := s.Destination(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(d, in); err != nil {
+		return nil, err
+	}
+	d.UpdatedAt = s.now().UTC()
+	d.NextAttemptAt = d.UpdatedAt
+	if err := s.repo.UpdateDestination(ctx, d); err != nil {
+		return nil, err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, d.ID,
+		fmt.Sprintf("Updated SIEM destination %q", d.Name), destinationAudit(d))
+	return d, nil
+}
+
+// DeleteDestination removes a destination.
+func (s *Service) DeleteDestination(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) error {
+	d, err := s.Destination(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteDestination(ctx, tenantID, id); err != nil {
+		return err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, id,
+		fmt.Sprintf("Deleted SIEM destination %q", d.Name), nil)
+	return nil
+}
+
+// Backfill rewinds a destination so the worker re-ships from the given
+// point. A log that is not named keeps its cursor. What the SIEM already
+// holds from the rewound range arrives again; records carry stable ids to
+// deduplicate on.
+func (s *Service) Backfill(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID, in BackfillInput) (*domain.SIEMDestination, error) {
+	if in.FromSequence == nil && in.AuthSince == nil {
+		return nil, domain.NewValidationError("from_sequence or auth_since is required")
+	}
+	d, err := s.Destination(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	auditCursor, authSince := d.AuditCursor, d.AuthCursorAt
+	var parts []string
+	if in.FromSequence != nil {
+		head, err := s.repo.AuditHead(ctx, tenantID)
+		if err != nil {
+			return nil, domain.NewInternalError(err.Error())
+		}
+		if *in.FromSequence < 1 || *in.FromSequence > head+1 {
+			return nil, domain.NewValidationError(fmt.Sprintf("from_sequence must be between 1 and %d", head+1))
+		}
+		auditCursor = *in.FromSequence - 1
+		parts = append(parts, fmt.Sprintf("audit chain from sequence %d", *in.FromSequence))
+	}
+	if in.AuthSince != nil {
+		since := in.AuthSince.UTC()
+		authSince = &since
+		parts = append(parts, "auth log since "+since.Format(time.RFC3339))
+	}
+	if err := s.repo.Rewind(ctx, tenantID, id, auditCursor, authSince, s.now().UTC()); err != nil {
+		return nil, err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, id,
+		fmt.Sprintf("Backfill of SIEM destination %q: %s", d.Name, strings.Join(parts, ", ")), nil)
+	return s.Destination(ctx, tenantID, id)
+}
+
+func (s *Service) apply(d *domain.SIEMDestination, in DestinationInput) error {
+	if in.Name != nil {
+		name := strings.TrimSpace(*in.Name)
+		if name == "" || len(name) > 120 {
+			return domain.NewValidationError("name is required (at most 120 characters)")
+		}
+		d.Name = name
+	}
+	if in.Transport != nil {
+		t := domain.SIEMTransport(*in.Transport)
+		if !t.IsValid() {
+			return domain.NewValidationError(`transport must be "syslog" or "hec"`)
+		}
+		d.Transport = t
+	}
+	if in.Format != nil {
+		f := domain.SIEMFormat(*in.Format)
+		if !f.IsValid() {
+			return domain.NewValidationError(`format must be "cef" or "ocsf"`)
+		}
+		d.Format = f
+	}
+	if in.Sources != nil {
+		seen := map[string]bool{}
+		var sources domain.StringList
+		for _, raw := range in.Sources {
+			if !domain.SIEMSource(raw).IsValid() {
+				return domain.NewValidationError(fmt.Sprintf("unknown source %q", raw))
+			}
+			if !seen[raw] {
+				seen[raw] = true
+				sources = append(sources, raw)
+			}
+		}
+		if len(sources) == 0 {
+			return domain.NewValidationError("choose at least one source")
+		}
+		sort.Strings(sources)
+		d.Sources = sources
+	}
+	if in.Enabled != nil {
+		d.Enabled = *in.Enabled
+	}
+	if in.CACertPEM != nil {
+		pem := strings.TrimSpace(*in.CACertPEM)
+		if pem != "" {
+			if _, err := clientTLS(pem); err != nil {
+				return domain.NewValidationError(err.Error())
+			}
+		}
+		d.CACertPEM = pem
+	}
+	if in.Endpoint != nil {
+		d.Endpoint = strings.TrimSpace(*in.Endpoint)
+	}
+	// Checked after every field is applied: whether the endpoint is valid
+	// depends on the transport, and either may be what changed.
+	if err := s.checkEndpoint(d.Transport, d.Endpoint); err != nil {
+		return err
+	}
+	if in.Token != nil && *in.Token != "" {
+		if s.cipher == nil {
+			return domain.NewInternalError("SIEM token storage is not configured")
+		}
+		enc, err := s.cipher.EncryptCredentials(map[string]string{"token": *in.Token})
+		if err != nil {
+			return domain.NewInternalError(err.Error())
+		}
+		d.EncryptedToken = enc
+	}
+	if d.Transport == domain.SIEMTransportHEC && d.EncryptedToken == "" {
+		return domain.NewValidationError("token is required for an HEC destination")
+	}
+	return nil
+}
+
+// checkEndpoint validates an endpoint for its transport: host:port for
+// syslog, an https URL for HEC. Internal addresses are refused here as well
+// as at connection time, so a mistake shows when the destination is saved.
+func (s *Service) checkEndpoint(t domain.SIEMTransport, endpoint string) error {
+	var host string
+	switch t {
+	case domain.SIEMTransportSyslog:
+		h, port, err := net.SplitHostPort(endpoint)
+		if err != nil || h == "" || port == "" {
+			return domain.NewValidationError("a syslog endpoint is host:port")
+		}
+		host = h
+	case domain.SIEMTransportHEC:
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Host == "" {
+			return domain.NewValidationError("an HEC endpoint is an absolute URL")
+		}
+		if u.Scheme != "https" && !(u.Scheme == "http" && s.transport.allowPrivate) {
+			return domain.NewValidationError("an HEC endpoint must use https")
+		}
+		if u.User != nil {
+			return domain.NewValidationError("put the HEC token in token, not in the URL")
+		}
+		host = u.Hostname()
+	}
+	if s.transport.allowPrivate {
+		return nil
+	}
+	if netguard.InternalHost(host) {
+		return domain.NewValidationError("endpoint must be reachable from the internet")
+	}
+	return nil
+}
+
+// =============================================================================
+// Shipping
+// =============================================================================
+
+// ShipNext claims one due destination and ships what its cursors have not
+// reached yet. False when nothing was due.
+func (s *Service) ShipNext(ctx context.Context) (bool, error) {
+	d, err := s.repo.ClaimDestination(ctx, s.now().UTC(), exportLease)
+	if err != nil || d == nil {
+		return false, err
+	}
+	err = s.ship(ctx, d)
+	if errors.Is(err, errLeaseLost) {
+		return true, nil
+	}
+	now := s.now().UTC()
+	if err != nil {
+		d.ConsecutiveFailures++
+		d.LastError = truncate(err.Error(), 500)
+		d.LastErrorAt = &now
+		d.NextAttemptAt = now.Add(Backoff(d.ConsecutiveFailures))
+	} else {
+		d.NextAttemptAt = now
+	}
+	d.LeaseUntil = nil
+	if _, cerr := s.repo.Checkpoint(ctx, d); cerr != nil {
+		return true, cerr
+	}
+	return true, nil
+}
+
+// ship sends batches, source by source, checkpointing after each, until
+// every cursor is at the head or the run has used half its lease.
+func (s *Service) ship(ctx context.Context, d *domain.SIEMDestination) error {
+	token := ""
+	if d.Transport == domain.SIEMTransportHEC {
+		if s.cipher == nil {
+			return errors.New("SIEM token storage is not configured")
+		}
+		creds, err := s.cipher.DecryptCredentials(d.EncryptedToken)
+		if err != nil {
+			return fmt.Errorf("token: %w", err)
+		}
+		token = creds["token"]
+	}
+	started := s.now()
+	for s.now().Sub(started) < exportLease/2 {
+		full := false
+		for _, source := range domain.SIEMSources {
+			if !d.Ships(source) {
+				continue
+			}
+			n, err := s.shipBatch(ctx, d, source, token)
+			if err != nil {
+				return err
+			}
+			full = full || n == domain.SIEMBatchSize
+		}
+		if !full {
+			return nil
+		}
+	}
+	return nil
+}
+
+// shipBatch sends the next batch of one source and, once it is accepted,
+// moves that cursor.
+func (s *Service) shipBatch(ctx context.Context, d *domain.SIEMDestination, source domain.SIEMSource, token string) (int, error) {
+	var (
+		records []Record
+		advance func()
+	)
+	switch source {
+	case domain.SIEMSourceAudit:
+		events, err := s.repo.AuditEventsAfter(ctx, d.TenantID, d.AuditCursor, domain.SIEMBatchSize)
+		if err != nil || len(events) == 0 {
+			return 0, err
+		}
+		if records, err = EncodeAudit(d.Format, s.version, events); err != nil {
+			return 0, err
+		}
+		last := events[len(events)-1].Sequence
+		advance = func() { d.AuditCursor = last }
+	case domain.SIEMSourceAuth:
+		logs, err := s.repo.AuthLogsAfter(ctx, d.TenantID, d.AuthCursorAt, d.AuthCursorID,
+			s.now().UTC().Add(-authSettle), domain.SIEMBatchSize)
+		if err != nil || len(logs) == 0 {
+			return 0, err
+		}
+		if records, err = EncodeAuth(d.Format, s.version, logs); err != nil {
+			return 0, err
+		}
+		last := logs[len(logs)-1]
+		advance = func() { at, id := last.CreatedAt, last.ID; d.AuthCursorAt, d.AuthCursorID = &at, &id }
+	}
+
+	if err := s.transport.send(ctx, d, token, records); err != nil {
+		return 0, err
+	}
+	advance()
+	now := s.now().UTC()
+	d.LastShippedAt = &now
+	d.ConsecutiveFailures = 0
+	d.LastError = ""
+	ok, err := s.repo.Checkpoint(ctx, d)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, errLeaseLost
+	}
+	return len(records), nil
+}
+
+// Backoff is the wait after the n-th consecutive failure: 30 s, doubling,
+// capped at 15 minutes. Short next to webhooks' because the SIEM is the
+// tenant's own and usually back soon; nothing is lost meanwhile, the cursor
+// waits.
+func Backoff(failures int) time.Duration {
+	d := firstRetry
+	for i := 1; i < failures && d < maxRetry; i++ {
+		d *= 2
+	}
+	if d > maxRetry {
+		d = maxRetry
+	}
+	return d
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
+
+func destinationAudit(d *domain.SIEMDestination) domain.JSONMap {
+	return domain.JSONMap{
+		"name":      d.Name,
+		"transport": string(d.Transport),
+		"format":    string(d.Format),
+		"endpoint":  d.Endpoint,
+		"sources":   []string(d.Sources),
+		"enabled":   d.Enabled,
+	}
+}
+
+func (s *Service) record(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, action domain.AuditAction, id uuid.UUID, summary string, after domain.JSONMap) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    actor,
+		Action:     action,
+		EntityType: "siem_destination",
+		EntityID:   id.String(),
+		Summary:    summary,
+		After:      after,
+	})
+}
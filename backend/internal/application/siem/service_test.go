@@ -0,0 +1,446 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package siem
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+type memSIEM struct {
+	dests map[uuid.UUID]domain.SIEMDestination
+	audit []domain.AuditEvent
+	auth  []domain.AuthAuditLog
+}
+
+func newMemSIEM() *memSIEM { return &memSIEM{dests: map[uuid.UUID]domain.SIEMDestination{}} }
+
+func (m *memSIEM) ListDestinations(_ context.Context, tenantID uuid.UUID) ([]domain.SIEMDestination, error) {
+	var out []domain.SIEMDestination
+	for _, d := range m.dests {
+		if d.TenantID == tenantID {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+func (m *memSIEM) GetDestination(_ context.Context, tenantID, id uuid.UUID) (*domain.SIEMDestination, error) {
+	if d, ok := m.dests[id]; ok && d.TenantID == tenantID {
+		return &d, nil
+	}
+	return nil, nil
+}
+func (m *memSIEM) CreateDestination(_ context.Context, d *domain.SIEMDestination) error {
+	m.dests[d.ID] = *d
+	return nil
+}
+func (m *memSIEM) UpdateDestination(_ context.Context, d *domain.SIEMDestination) error {
+	cur := m.dests[d.ID]
+	cur.Name, cur.Transport, cur.Format, cur.Endpoint = d.Name, d.Transport, d.Format, d.Endpoint
+	cur.Sources, cur.Enabled, cur.CACertPEM, cur.EncryptedToken = d.Sources, d.Enabled, d.CACertPEM, d.EncryptedToken
+	cur.NextAttemptAt, cur.UpdatedAt = d.NextAttemptAt, d.UpdatedAt
+	m.dests[d.ID] = cur
+	return nil
+}
+func (m *memSIEM) DeleteDestination(_ context.Context, _, id uuid.UUID) error {
+	delete(m.dests, id)
+	return nil
+}
+func (m *memSIEM) Rewind(_ context.Context, _, id uuid.UUID, cursor int64, since *time.Time, now time.Time) error {
+	d := m.dests[id]
+	d.AuditCursor, d.AuthCursorAt, d.AuthCursorID = cursor, since, nil
+	d.LeaseToken, d.LeaseUntil, d.ConsecutiveFailures, d.NextAttemptAt = nil, nil, 0, now
+	m.dests[id] = d
+	return nil
+}
+func (m *memSIEM) AuditHead(_ context.Context, tenantID uuid.UUID) (int64, error) {
+	var head int64
+	for _, e := range m.audit {
+		if e.TenantID == tenantID && e.Sequence > head {
+			head = e.Sequence
+		}
+	}
+	return head, nil
+}
+func (m *memSIEM) AuditEventsAfter(_ context.Context, tenantID uuid.UUID, after int64, limit int) ([]domain.AuditEvent, error) {
+	var out []domain.AuditEvent
+	for _, e := range m.audit {
+		if e.TenantID == tenantID && e.Sequence > after && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+func (m *memSIEM) AuthLogsAfter(_ context.Context, tenantID uuid.UUID, at *time.Time, id *uuid.UUID, until time.Time, limit int) ([]domain.AuthAuditLog, error) {
+	var out []domain.AuthAuditLog
+	for _, l := range m.auth {
+		if l.TenantID == nil || *l.TenantID != tenantID || !l.CreatedAt.Before(until) {
+			continue
+		}
+		if at != nil && (l.CreatedAt.Before(*at) || (id != nil && !l.CreatedAt.After(*at))) {
+			continue
+		}
+		if len(out) < limit {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+func (m *memSIEM) ClaimDestination(_ context.Context, now time.Time, lease time.Duration) (*domain.SIEMDestination, error) {
+	for id, d := range m.dests {
+		if d.Enabled && !d.NextAttemptAt.After(now) && (d.LeaseUntil == nil || !d.LeaseUntil.After(now)) {
+			token, until := uuid.New(), now.Add(lease)
+			d.LeaseToken, d.LeaseUntil = &token, &until
+			m.dests[id] = d
+			return &d, nil
+		}
+	}
+	return nil, nil
+}
+func (m *memSIEM) Checkpoint(_ context.Context, d *domain.SIEMDestination) (bool, error) {
+	cur := m.dests[d.ID]
+	if cur.LeaseToken == nil || d.LeaseToken == nil || *cur.LeaseToken != *d.LeaseToken {
+		return false, nil
+	}
+	m.dests[d.ID] = *d
+	return true, nil
+}
+
+func (m *memSIEM) addAudit(tenant uuid.UUID, n int, at time.Time) {
+	head, _ := m.AuditHead(context.Background(), tenant)
+	for i := 1; i <= n; i++ {
+		m.audit = append(m.audit, domain.AuditEvent{ID: uuid.New(), TenantID: tenant, Action: domain.AuditActionUpdate,
+			EntityType: "risk", EntityID: strconv.Itoa(i), Summary: "Updated risk", Sequence: head + int64(i), CreatedAt: at})
+	}
+}
+
+type jsonCipher struct{}
+
+func (jsonCipher) EncryptCredentials(m map[string]string) (string, error) {
+	b, err := json.Marshal(m)
+	return string(b), err
+}
+func (jsonCipher) DecryptCredentials(s string) (map[string]string, error) {
+	var m map[string]string
+	return m, json.Unmarshal([]byte(s), &m)
+}
+
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// selfSigned issues a certificate for 127.0.0.1, returning it with its PEM.
+func selfSigned(t *testing.T) (tls.Certificate, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "siem.test"},
+		NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour),
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")}, IsCA: true, BasicConstraintsValid: true,
+		KeyUsage: x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key},
+		string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// syslogCollector is a TLS syslog listener that unframes octet-counted
+// messages.
+type syslogCollector struct {
+	ln   net.Listener
+	mu   sync.Mutex
+	msgs []string
+	done chan struct{}
+}
+
+func newSyslogCollector(t *testing.T, cert tls.Certificate) *syslogCollector {
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	require.NoError(t, err)
+	c := &syslogCollector{ln: ln, done: make(chan struct{}, 16)}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go c.read(conn)
+		}
+	}()
+	return c
+}
+
+func (c *syslogCollector) read(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		n, err := r.ReadString(' ')
+		if err != nil {
+			c.done <- struct{}{}
+			return
+		}
+		size, _ := strconv.Atoi(strings.TrimSpace(n))
+		buf := make([]byte, size)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			c.done <- struct{}{}
+			return
+		}
+		c.mu.Lock()
+		c.msgs = append(c.msgs, string(buf))
+		c.mu.Unlock()
+	}
+}
+
+func (c *syslogCollector) wait(t *testing.T) []string {
+	t.Helper()
+	select {
+	case <-c.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("collector saw no complete batch")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.msgs...)
+}
+
+func setup(t *testing.T) (*Service, *memSIEM, *clock) {
+	t.Helper()
+	repo := newMemSIEM()
+	c := &clock{t: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)}
+	svc := NewService(repo).WithCipher(jsonCipher{}).WithClock(c.now).AllowPrivateNetworks(true).WithProductVersion("1.4.0")
+	return svc, repo, c
+}
+
+func strp(s string) *string { return &s }
+
+func TestShip_SyslogOverTLSFromTheCursor(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, c := setup(t)
+	cert, caPEM := selfSigned(t)
+	collector := newSyslogCollector(t, cert)
+	tenant, other := uuid.New(), uuid.New()
+	repo.addAudit(tenant, 3, c.now().Add(-time.Hour))
+
+	d, err := svc.CreateDestination(ctx, tenant, nil, DestinationInput{
+		Name: strp("Sentinel"), Transport: strp("syslog"), Format: strp("cef"),
+		Endpoint: strp(collector.ln.Addr().String()), Sources: []string{"audit"}, CACertPEM: &caPEM,
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, d.AuditCursor, "a new destination starts at the head")
+
+	repo.addAudit(tenant, 2, c.now())
+	repo.addAudit(other, 4, c.now())
+	worked, err := svc.ShipNext(ctx)
+	require.NoError(t, err)
+	require.True(t, worked)
+
+	msgs := collector.wait(t)
+	require.Len(t, msgs, 2, "only what lies past the cursor, only this tenant's")
+	// <13*8+6>1 TIMESTAMP HOST openrisk - audit - CEF:...
+	assert.True(t, strings.HasPrefix(msgs[0], "<110>1 2026-03-02T09:00:00.000Z "), msgs[0])
+	assert.Contains(t, msgs[0], " openrisk - audit - CEF:0|OpenDefender|OpenRisk|1.4.0|audit.risk.update|")
+	assert.Contains(t, msgs[0], "cn1=4")
+	assert.Contains(t, msgs[1], "cn1=5")
+
+	got, _ := repo.GetDestination(ctx, tenant, d.ID)
+	assert.EqualValues(t, 5, got.AuditCursor)
+	assert.NotNil(t, got.LastShippedAt)
+	assert.Nil(t, got.LeaseUntil, "the claim is released")
+}
+
+func TestShip_HECFailureKeepsTheCursorAndRetries(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, c := setup(t)
+	var mu sync.Mutex
+	status := http.StatusServiceUnavailable
+	var bodies []string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "Splunk hec-token", r.Header.Get("Authorization"))
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+
+	tenant := uuid.New()
+	d, err := svc.CreateDestination(ctx, tenant, nil, DestinationInput{
+		Name: strp("Splunk"), Transport: strp("hec"), Format: strp("ocsf"),
+		Endpoint: strp(srv.URL + "/services/collector/event"), Sources: []string{"audit", "auth"},
+		CACertPEM: &caPEM, Token: strp("hec-token"),
+	})
+	require.NoError(t, err)
+	repo.addAudit(tenant, 2, c.now())
+
+	_, err = svc.ShipNext(ctx)
+	require.NoError(t, err)
+	got, _ := repo.GetDestination(ctx, tenant, d.ID)
+	assert.EqualValues(t, 0, got.AuditCursor, "a refused batch does not move the cursor")
+	assert.Equal(t, 1, got.ConsecutiveFailures)
+	assert.Contains(t, got.LastError, "HTTP 503")
+	assert.Equal(t, c.now().Add(30*time.Second), got.NextAttemptAt)
+
+	worked, _ := svc.ShipNext(ctx)
+	assert.False(t, worked, "not due during the backoff")
+
+	c.advance(30 * time.Second)
+	status = http.StatusOK
+	_, err = svc.ShipNext(ctx)
+	require.NoError(t, err)
+	got, _ = repo.GetDestination(ctx, tenant, d.ID)
+	assert.EqualValues(t, 2, got.AuditCursor)
+	assert.Zero(t, got.ConsecutiveFailures)
+	assert.Empty(t, got.LastError)
+
+	require.Len(t, bodies, 2, "the refused batch is sent again whole")
+	assert.Equal(t, bodies[0], bodies[1])
+	lines := strings.Split(strings.TrimSpace(bodies[1]), "\n")
+	require.Len(t, lines, 2)
+	var ev struct {
+		Sourcetype string         `json:"sourcetype"`
+		Source     string         `json:"source"`
+		Event      map[string]any `json:"event"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &ev))
+	assert.Equal(t, "openrisk:ocsf", ev.Sourcetype)
+	assert.Equal(t, "openrisk:audit", ev.Source)
+	assert.EqualValues(t, 6003, ev.Event["class_uid"])
+}
+
+func TestShip_AuthLogStaysBehindBySettleWindow(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, c := setup(t)
+	cert, caPEM := selfSigned(t)
+	collector := newSyslogCollector(t, cert)
+	tenant := uuid.New()
+	d, err := svc.CreateDestination(ctx, tenant, nil, DestinationInput{
+		Name: strp("QRadar"), Transport: strp("syslog"), Format: strp("cef"),
+		Endpoint: strp(collector.ln.Addr().String()), Sources: []string{"auth"}, CACertPEM: &caPEM,
+	})
+	require.NoError(t, err)
+
+	user := uuid.New()
+	old := domain.AuthAuditLog{ID: uuid.New(), TenantID: &tenant, UserID: &user, Action: "login", Success: true,
+		CreatedAt: c.now().Add(time.Minute)}
+	fresh := domain.AuthAuditLog{ID: uuid.New(), TenantID: &tenant, UserID: &user, Action: "logout", Success: true,
+		CreatedAt: c.now().Add(2 * time.Minute)}
+	repo.auth = []domain.AuthAuditLog{old, fresh}
+	c.advance(2*time.Minute + 10*time.Second)
+
+	_, err = svc.ShipNext(ctx)
+	require.NoError(t, err)
+	msgs := collector.wait(t)
+	require.Len(t, msgs, 1, "the entry younger than the settle window waits")
+	assert.True(t, strings.HasPrefix(msgs[0], "<86>1 "), "authpriv, informational: "+msgs[0])
+	assert.Contains(t, msgs[0], " auth - CEF:0|")
+	got, _ := repo.GetDestination(ctx, tenant, d.ID)
+	require.NotNil(t, got.AuthCursorID)
+	assert.Equal(t, old.ID, *got.AuthCursorID)
+}
+
+func TestBackfill_RewindsTheCursor(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, c := setup(t)
+	tenant := uuid.New()
+	repo.addAudit(tenant, 10, c.now())
+	d, err := svc.CreateDestination(ctx, tenant, nil, DestinationInput{
+		Name: strp("Elastic"), Transport: strp("syslog"), Format: strp("ocsf"),
+		Endpoint: strp("127.0.0.1:6514"), Sources: []string{"audit"},
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, d.AuditCursor)
+
+	from := int64(4)
+	got, err := svc.Backfill(ctx, tenant, nil, d.ID, BackfillInput{FromSequence: &from})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, got.AuditCursor, "sequence 4 is the next one shipped")
+
+	tooFar := int64(12)
+	_, err = svc.Backfill(ctx, tenant, nil, d.ID, BackfillInput{FromSequence: &tooFar})
+	assert.Error(t, err)
+	_, err = svc.Backfill(ctx, tenant, nil, d.ID, BackfillInput{})
+	assert.Error(t, err)
+	_, err = svc.Backfill(ctx, uuid.New(), nil, d.ID, BackfillInput{FromSequence: &from})
+	assert.Error(t, err, "another tenant's destination")
+}
+
+func TestDestination_EndpointRules(t *testing.T) {
+	ctx := context.Background()
+	svc, _, _ := setup(t)
+	svc.AllowPrivateNetworks(false)
+	tenant := uuid.New()
+	create := func(transport, endpoint string, token *string) error {
+		_, err := svc.CreateDestination(ctx, tenant, nil, DestinationInput{
+			Name: strp("x"), Transport: &transport, Format: strp("cef"), Endpoint: &endpoint,
+			Sources: []string{"audit"}, Token: token,
+		})
+		return err
+	}
+	assert.NoError(t, create("syslog", "siem.example.com:6514", nil))
+	assert.Error(t, create("syslog", "siem.example.com", nil), "no port")
+	assert.Error(t, create("syslog", "10.1.2.3:6514", nil), "internal address")
+	assert.NoError(t, create("hec", "https://splunk.example.com:8088/services/collector/event", strp("t")))
+	assert.Error(t, create("hec", "https://splunk.example.com:8088/services/collector/event", nil), "token required")
+	assert.Error(t, create("hec", "http://splunk.example.com:8088/", strp("t")), "https only")
+	assert.Error(t, create("kafka", "siem.example.com:9092", nil))
+
+	svc.AllowPrivateNetworks(true)
+	assert.NoError(t, create("syslog", "10.1.2.3:6514", nil))
+	assert.NoError(t, create("hec", "http://10.1.2.3:8088/services/collector/event", strp("t")))
+
+	items, err := svc.Destinations(ctx, tenant)
+	require.NoError(t, err)
+	names := make([]string, 0, len(items))
+	for _, d := range items {
+		names = append(names, string(d.Transport))
+		assert.NotContains(t, mustJSON(t, d), "encrypted_token", "the token never leaves the server")
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"hec", "hec", "syslog", "syslog"}, names)
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestBackoff_DoublesToFifteenMinutes(t *testing.T) {
+	assert.Equal(t, 30*time.Second, Backoff(1))
+	assert.Equal(t, 2*time.Minute, Backoff(3))
+	assert.Equal(t, 15*time.Minute, Backoff(20))
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package siem
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/netguard"
+)
+
+// sendTimeout bounds one batch on either transport.
+const sendTimeout = 30 * time.Second
+
+// Syslog facilities (RFC 5424 §6.2.1): the audit chain is "log audit", the
+// auth log "security/authorization".
+const (
+	facilityAuthPriv = 10
+	facilityLogAudit = 13
+)
+
+// transport sends batches. It holds no connection between batches: a batch
+// is one TLS connection for syslog, one request for HEC, and either the whole
+// batch is accepted or none of it counts.
+type transport struct {
+	allowPrivate bool
+	hostname     string
+}
+
+func (t *transport) send(ctx context.Context, d *domain.SIEMDestination, token string, batch []Record) error {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	tlsConfig, err := clientTLS(d.CACertPEM)
+	if err != nil {
+		return err
+	}
+	if d.Transport == domain.SIEMTransportHEC {
+		return t.sendHEC(ctx, d, token, tlsConfig, batch)
+	}
+	return t.sendSyslog(ctx, d, tlsConfig, batch)
+}
+
+// sendSyslog writes the batch as octet-counted RFC 5424 messages (RFC 5425
+// framing). Syslog has no acknowledgement: the batch counts as sent once it
+// is written and the TLS session is closed cleanly. A collector that drops
+// what it received after that loses it; one that drops the connection
+// mid-batch makes the whole batch go again.
+func (t *transport) sendSyslog(ctx context.Context, d *domain.SIEMDestination, tlsConfig *tls.Config, batch []Record) error {
+	host, _, err := net.SplitHostPort(d.Endpoint)
+	if err != nil {
+		return err
+	}
+	cfg := tlsConfig.Clone()
+	cfg.ServerName = host
+	dialer := &tls.Dialer{NetDialer: t.dialer(), Config: cfg}
+	conn, err := dialer.DialContext(ctx, "tcp", d.Endpoint)
+	if err != nil {
+		return fmt.Errorf("syslog connect: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	w := bufio.NewWriter(conn)
+	for _, r := range batch {
+		msg := t.syslogMessage(r)
+		if _, err := w.WriteString(strconv.Itoa(len(msg)) + " "); err != nil {
+			return fmt.Errorf("syslog write: %w", err)
+		}
+		if _, err := w.Write(msg); err != nil {
+			return fmt.Errorf("syslog write: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("syslog write: %w", err)
+	}
+	if err := conn.(*tls.Conn).CloseWrite(); err != nil {
+		return fmt.Errorf("syslog close: %w", err)
+	}
+	return nil
+}
+
+// syslogMessage is one RFC 5424 message:
+//
+//	<PRI>1 TIMESTAMP HOSTNAME openrisk - MSGID - BODY
+//
+// MSGID is the source ("audit" or "auth"); there is no structured data, the
+// body carries every field.
+func (t *transport) syslogMessage(r Record) []byte {
+	facility := facilityLogAudit
+	if r.Source == domain.SIEMSourceAuth {
+		facility = facilityAuthPriv
+	}
+	var b bytes.Buffer
+	b.WriteString("<" + strconv.Itoa(facility*8+syslogSeverity(r.Severity)) + ">1 ")
+	b.WriteString(r.Time.UTC().Format("2006-01-02T15:04:05.000Z07:00"))
+	b.WriteString(" " + t.hostname + " openrisk - " + string(r.Source) + " - ")
+	b.Write(r.Body)
+	return b.Bytes()
+}
+
+// syslogSeverity maps the 0-10 severity onto syslog's: critical, error,
+// warning, notice, informational.
+func syslogSeverity(severity int) int {
+	switch {
+	case severity >= 9:
+		return 2
+	case severity >= 7:
+		return 3
+	case severity >= 5:
+		return 4
+	case severity >= 4:
+		return 5
+	default:
+		return 6
+	}
+}
+
+// sendHEC posts the batch to an HTTP Event Collector, one event object per
+// record, concatenated. A 2xx is the collector's acknowledgement of the whole
+// batch.
+func (t *transport) sendHEC(ctx context.Context, d *domain.SIEMDestination, token string, tlsConfig *tls.Config, batch []Record) error {
+	sourcetype := "openrisk:cef"
+	if d.Format == domain.SIEMFormatOCSF {
+		sourcetype = "openrisk:ocsf"
+	}
+	var body bytes.Buffer
+	for _, r := range batch {
+		var event any = string(r.Body)
+		if d.Format == domain.SIEMFormatOCSF {
+			event = json.RawMessage(r.Body)
+		}
+		line, err := json.Marshal(map[string]any{
+			"time":       float64(r.Time.UnixMilli()) / 1000,
+			"host":       t.hostname,
+			"source":     "openrisk:" + string(r.Source),
+			"sourcetype": sourcetype,
+			"event":      event,
+			"fields":     map[string]any{"tenant_id": d.TenantID.String()},
+		})
+		if err != nil {
+			return err
+		}
+		body.Write(line)
+		body.WriteByte('\n')
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.Endpoint, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Splunk "+token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "OpenRisk-SIEM-Export/1.0")
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext:         t.dialer().DialContext,
+			TLSClientConfig:     tlsConfig,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	defer client.CloseIdleConnections()
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("hec: %w", err)
+	}
+	defer resp.Body.Close()
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("hec: HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+	}
+	return nil
+}
+
+// dialer refuses internal addresses unless private networks are allowed. The
+// check runs on the resolved address, so a hostname cannot smuggle one in.
+func (t *transport) dialer() *net.Dialer {
+	return &net.Dialer{Timeout: 10 * time.Second, Control: netguard.Control(t.allowPrivate, "SIEM export")}
+}
+
+// clientTLS trusts caPEM alone when given, the system roots otherwise.
+func clientTLS(caPEM string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caPEM == "" {
+		return cfg, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(caPEM)) {
+		return nil, errors.New("ca_cert_pem holds no PEM certificate")
+	}
+	cfg.RootCAs = pool
+	return cfg, nil
+}
@@ -0,0 +1,579 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package tenantconfig exports and imports a tenant's configuration — its
+// automation rules, risk taxonomy, asset attribute schemas and scoring weights —
+// as one portable document, so it can live in git and be applied by the
+// `openrisk` CLI. Documents carry natural keys (rule name, category slug, asset
+// category) instead of ids, so the same file applies to any tenant.
+package tenantconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// APIVersion and Kind identify a configuration document.
+const (
+	APIVersion = "openrisk/v1"
+	Kind       = "TenantConfig"
+)
+
+// Sections of a document, as they appear in a Change.
+const (
+	SectionAutomationRules = "automation_rules"
+	SectionRiskCategories  = "risk_categories"
+	SectionAssetSchemas    = "asset_schemas"
+	SectionScoringWeights  = "scoring_weights"
+)
+
+// Document is a tenant's configuration. A section left out of an imported
+// document is not touched, and an import never deletes what the document does
+// not mention.
+type Document struct {
+	APIVersion      string     `json:"api_version"`
+	Kind            string     `json:"kind"`
+	AutomationRules []Rule     `json:"automation_rules,omitempty"`
+	RiskCategories  []Category `json:"risk_categories,omitempty"`
+	AssetSchemas    []Schema   `json:"asset_schemas,omitempty"`
+	ScoringWeights  *Weights   `json:"scoring_weights,omitempty"`
+}
+
+// Rule is an automation rule, keyed by name.
+type Rule struct {
+	Name        string                      `json:"name"`
+	Description string                      `json:"description,omitempty"`
+	Enabled     *bool                       `json:"enabled,omitempty"`
+	Trigger     domain.AutomationTrigger    `json:"trigger"`
+	Conditions  domain.AutomationConditions `json:"conditions"`
+	Actions     domain.AutomationActionList `json:"actions"`
+	SLA         domain.AutomationSLAConfig  `json:"sla"`
+	Priority    int                         `json:"priority,omitempty"`
+}
+
+// Category is a risk taxonomy entry, keyed by slug. An empty slug is derived
+// from the name, as the category form does.
+type Category struct {
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Color       string `json:"color,omitempty"`
+	SortOrder   int    `json:"sort_order"`
+	Active      *bool  `json:"active,omitempty"`
+}
+
+// Schema is an asset category's attribute schema, keyed by category.
+type Schema struct {
+	Category   domain.AssetCategory  `json:"category"`
+	Label      string                `json:"label,omitempty"`
+	Attributes []domain.AttributeDef `json:"attributes"`
+}
+
+// Weights are the smart-risk factor weights.
+type Weights struct {
+	BusinessCriticality float64 `json:"business_criticality"`
+	InternetExposure    float64 `json:"internet_exposure"`
+	Vulnerabilities     float64 `json:"vulnerabilities"`
+	ControlMaturity     float64 `json:"control_maturity"`
+	IncidentHistory     float64 `json:"incident_history"`
+	Exploitability      float64 `json:"exploitability"`
+	FinancialValue      float64 `json:"financial_value"`
+	ThreatIntel         float64 `json:"threat_intel"`
+}
+
+// Change is what an import does, or would do, to one item.
+type Change struct {
+	Section string `json:"section"`
+	Key     string `json:"key"`
+	Action  string `json:"action"` // create | update | unchanged
+}
+
+// Change actions.
+const (
+	ActionCreate    = "create"
+	ActionUpdate    = "update"
+	ActionUnchanged = "unchanged"
+)
+
+// Result is the outcome of an import. A dry run reports the same changes
+// without writing them.
+type Result struct {
+	DryRun    bool     `json:"dry_run"`
+	Created   int      `json:"created"`
+	Updated   int      `json:"updated"`
+	Unchanged int      `json:"unchanged"`
+	Changes   []Change `json:"changes"`
+}
+
+// AuditSink records imports in the audit chain.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// Service exports and imports tenant configuration.
+type Service struct {
+	rules      domain.AutomationRuleRepository
+	categories domain.RiskCategoryRepository
+	schemas    domain.AssetTypeSchemaRepository
+	weights    domain.RiskScoringWeightsRepository
+	audit      AuditSink
+	now        func() time.Time
+}
+
+// NewService builds the service.
+func NewService(rules domain.AutomationRuleRepository, categories domain.RiskCategoryRepository,
+	schemas domain.AssetTypeSchemaRepository, weights domain.RiskScoringWeightsRepository) *Service {
+	return &Service{rules: rules, categories: categories, schemas: schemas, weights: weights, now: time.Now}
+}
+
+// WithAudit enables audit recording.
+func (s *Service) WithAudit(a AuditSink) *Service {
+	s.audit = a
+	return s
+}
+
+// WithClock overrides the clock (tests).
+func (s *Service) WithClock(now func() time.Time) *Service {
+	s.now = now
+	return s
+}
+
+// =============================================================================
+// Export
+// =============================================================================
+
+// Export returns the tenant's configuration. Asset schemas are exported only
+// when customised: an untouched category follows the shipped default, and
+// pinning it in a file would stop it following future defaults.
+func (s *Service) Export(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID) (*Document, error) {
+	doc := &Document{APIVersion: APIVersion, Kind: Kind}
+
+	rules, err := s.rules.List(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list automation rules: " + err.Error())
+	}
+	for i := range rules {
+		doc.AutomationRules = append(doc.AutomationRules, ruleOf(&rules[i]))
+	}
+
+	cats, err := s.categories.List(ctx, tenantID, true)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list risk categories: " + err.Error())
+	}
+	for i := range cats {
+		doc.RiskCategories = append(doc.RiskCategories, categoryOf(&cats[i]))
+	}
+
+	schemas, err := s.schemas.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list asset schemas: " + err.Error())
+	}
+	for _, cat := range domain.AssetCategories {
+		for i := range schemas {
+			if schemas[i].Category == cat && schemas[i].Customized {
+				doc.AssetSchemas = append(doc.AssetSchemas, schemaOf(&schemas[i]))
+			}
+		}
+	}
+
+	w, err := s.weights.GetByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to load scoring weights: " + err.Error())
+	}
+	if w != nil {
+		doc.ScoringWeights = weightsOf(w)
+	}
+
+	s.record(ctx, tenantID, actor, domain.AuditActionExport,
+		fmt.Sprintf("Exported configuration: %d automation rules, %d risk categories, %d asset schemas",
+			len(doc.AutomationRules), len(doc.RiskCategories), len(doc.AssetSchemas)), nil)
+	return doc, nil
+}
+
+// =============================================================================
+// Import
+// =============================================================================
+
+// Import applies a document. The whole document is checked before anything is
+// written, so a typo in the last rule does not leave the first ones applied.
+func (s *Service) Import(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, doc Document, dryRun bool) (*Result, error) {
+	if doc.APIVersion != "" && doc.APIVersion != APIVersion {
+		return nil, domain.NewValidationError(fmt.Sprintf("unsupported api_version %q (expected %q)", doc.APIVersion, APIVersion))
+	}
+	if doc.Kind != "" && doc.Kind != Kind {
+		return nil, domain.NewValidationError(fmt.Sprintf("unsupported kind %q (expected %q)", doc.Kind, Kind))
+	}
+
+	var steps []step
+	for _, plan := range []func(context.Context, uuid.UUID, *uuid.UUID, Document) ([]step, error){
+		s.planRules, s.planCategories, s.planSchemas, s.planWeights,
+	} {
+		more, err := plan(ctx, tenantID, actor, doc)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, more...)
+	}
+
+	res := &Result{DryRun: dryRun, Changes: make([]Change, 0, len(steps))}
+	for _, st := range steps {
+		res.Changes = append(res.Changes, st.change)
+		switch st.change.Action {
+		case ActionCreate:
+			res.Created++
+		case ActionUpdate:
+			res.Updated++
+		default:
+			res.Unchanged++
+		}
+	}
+	if dryRun {
+		return res, nil
+	}
+
+	for _, st := range steps {
+		if st.apply == nil {
+			continue
+		}
+		if err := st.apply(ctx); err != nil {
+			return nil, domain.NewInternalError(fmt.Sprintf("failed to save %s %q: %s", st.change.Section, st.change.Key, err.Error()))
+		}
+	}
+	if res.Created+res.Updated > 0 {
+		s.record(ctx, tenantID, actor, domain.AuditActionUpdate,
+			fmt.Sprintf("Imported configuration: %d created, %d updated, %d unchanged", res.Created, res.Updated, res.Unchanged),
+			domain.JSONMap{"changes": changedOnly(res.Changes)})
+	}
+	return res, nil
+}
+
+// step is one planned change and the write that performs it (nil when the
+// item is unchanged).
+type step struct {
+	change Change
+	apply  func(ctx context.Context) error
+}
+
+func (s *Service) planRules(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, doc Document) ([]step, error) {
+	if len(doc.AutomationRules) == 0 {
+		return nil, nil
+	}
+	existing, err := s.rules.List(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list automation rules: " + err.Error())
+	}
+	byName := map[string][]*domain.AutomationRule{}
+	for i := range existing {
+		key := strings.ToLower(strings.TrimSpace(existing[i].Name))
+		byName[key] = append(byName[key], &existing[i])
+	}
+	createdBy := uuid.Nil
+	if actor != nil {
+		createdBy = *actor
+	}
+
+	seen := map[string]bool{}
+	steps := make([]step, 0, len(doc.AutomationRules))
+	for i, in := range doc.AutomationRules {
+		name := strings.TrimSpace(in.Name)
+		key := strings.ToLower(name)
+		if name == "" {
+			return nil, domain.NewValidationError(fmt.Sprintf("automation_rules[%d]: name is required", i))
+		}
+		if seen[key] {
+			return nil, domain.NewValidationError(fmt.Sprintf("automation_rules[%d]: rule %q appears twice", i, name))
+		}
+		seen[key] = true
+		if len(byName[key]) > 1 {
+			return nil, conflict(fmt.Sprintf("automation_rules[%d]: the tenant has %d rules named %q; rename them before importing", i, len(byName[key]), name))
+		}
+
+		var current *domain.AutomationRule
+		if len(byName[key]) == 1 {
+			current = byName[key][0]
+		}
+		next := &domain.AutomationRule{
+			ID: uuid.New(), TenantID: tenantID, Enabled: true, Priority: 100, CreatedBy: createdBy,
+		}
+		if current != nil {
+			cp := *current
+			next = &cp
+		}
+		next.Name = name
+		next.Description = in.Description
+		if in.Enabled != nil {
+			next.Enabled = *in.Enabled
+		}
+		next.Trigger = in.Trigger
+		next.Conditions = in.Conditions
+		next.Actions = in.Actions
+		next.SLA = in.SLA
+		if in.Priority > 0 {
+			next.Priority = in.Priority
+		}
+		if err := next.Validate(); err != nil {
+			return nil, itemError(SectionAutomationRules, i, err)
+		}
+
+		ch := Change{Section: SectionAutomationRules, Key: name}
+		switch {
+		case current == nil:
+			ch.Action = ActionCreate
+			steps = append(steps, step{ch, func(ctx context.Context) error { return s.rules.Create(ctx, next) }})
+		case same(ruleOf(current), ruleOf(next)):
+			ch.Action = ActionUnchanged
+			steps = append(steps, step{change: ch})
+		default:
+			ch.Action = ActionUpdate
+			steps = append(steps, step{ch, func(ctx context.Context) error { return s.rules.Update(ctx, next) }})
+		}
+	}
+	return steps, nil
+}
+
+func (s *Service) planCategories(ctx context.Context, tenantID uuid.UUID, _ *uuid.UUID, doc Document) ([]step, error) {
+	if len(doc.RiskCategories) == 0 {
+		return nil, nil
+	}
+	existing, err := s.categories.List(ctx, tenantID, true)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list risk categories: " + err.Error())
+	}
+	bySlug := map[string]*domain.RiskCategory{}
+	for i := range existing {
+		bySlug[existing[i].Slug] = &existing[i]
+	}
+
+	seen := map[string]bool{}
+	steps := make([]step, 0, len(doc.RiskCategories))
+	for i, in := range doc.RiskCategories {
+		slug := domain.Slugify(in.Slug)
+		if slug == "" {
+			slug = domain.Slugify(in.Name)
+		}
+		current := bySlug[slug]
+		next := &domain.RiskCategory{TenantID: tenantID, Slug: slug, Color: "neutral", Active: true}
+		if current != nil {
+			cp := *current
+			next = &cp
+		}
+		if name := strings.TrimSpace(in.Name); name != "" || current == nil {
+			next.Name = name
+		}
+		next.Description = in.Description
+		if in.Color != "" {
+			next.Color = in.Color
+		}
+		next.SortOrder = in.SortOrder
+		if in.Active != nil {
+			next.Active = *in.Active
+		}
+		if err := next.Validate(); err != nil {
+			return nil, itemError(SectionRiskCategories, i, err)
+		}
+		if seen[next.Slug] {
+			return nil, domain.NewValidationError(fmt.Sprintf("risk_categories[%d]: slug %q appears twice", i, next.Slug))
+		}
+		seen[next.Slug] = true
+
+		ch := Change{Section: SectionRiskCategories, Key: next.Slug}
+		switch {
+		case current == nil:
+			ch.Action = ActionCreate
+			steps = append(steps, step{ch, func(ctx context.Context) error { return s.categories.Create(ctx, next) }})
+		case same(categoryOf(current), categoryOf(next)):
+			ch.Action = ActionUnchanged
+			steps = append(steps, step{change: ch})
+		default:
+			ch.Action = ActionUpdate
+			steps = append(steps, step{ch, func(ctx context.Context) error { return s.categories.Update(ctx, next) }})
+		}
+	}
+	return steps, nil
+}
+
+func (s *Service) planSchemas(ctx context.Context, tenantID uuid.UUID, _ *uuid.UUID, doc Document) ([]step, error) {
+	seen := map[domain.AssetCategory]bool{}
+	steps := make([]step, 0, len(doc.AssetSchemas))
+	for i, in := range doc.AssetSchemas {
+		cat, err := domain.ParseAssetCategory(string(in.Category))
+		if err != nil {
+			return nil, itemError(SectionAssetSchemas, i, err)
+		}
+		if seen[cat] {
+			return nil, domain.NewValidationError(fmt.Sprintf("asset_schemas[%d]: category %q appears twice", i, cat))
+		}
+		seen[cat] = true
+		if err := domain.ValidateSchema(in.Attributes); err != nil {
+			return nil, itemError(SectionAssetSchemas, i, err)
+		}
+
+		current, err := s.schemas.GetByCategory(ctx, tenantID, cat)
+		if err != nil {
+			return nil, domain.NewInternalError("failed to load asset schema: " + err.Error())
+		}
+		base := current
+		if base == nil {
+			base = domain.DefaultSchemaFor(tenantID, cat)
+		}
+		next := *base
+		if next.ID == uuid.Nil {
+			next.ID = uuid.New()
+		}
+		next.TenantID = tenantID
+		if in.Label != "" {
+			next.Label = in.Label
+		}
+		next.Attributes = in.Attributes
+
+		ch := Change{Section: SectionAssetSchemas, Key: string(cat)}
+		switch {
+		case base.Customized && same(schemaOf(base), schemaOf(&next)):
+			ch.Action = ActionUnchanged
+			steps = append(steps, step{change: ch})
+		default:
+			ch.Action = ActionUpdate
+			if current == nil {
+				ch.Action = ActionCreate
+			}
+			next.Customized = true
+			next.Version = base.Version + 1
+			steps = append(steps, step{ch, func(ctx context.Context) error { return s.schemas.Upsert(ctx, &next) }})
+		}
+	}
+	return steps, nil
+}
+
+func (s *Service) planWeights(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, doc Document) ([]step, error) {
+	if doc.ScoringWeights == nil {
+		return nil, nil
+	}
+	current, err := s.weights.GetByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to load scoring weights: " + err.Error())
+	}
+	next := domain.DefaultRiskScoringWeights(tenantID)
+	if current != nil {
+		cp := *current
+		next = &cp
+	}
+	in := doc.ScoringWeights
+	next.BusinessCriticality = in.BusinessCriticality
+	next.InternetExposure = in.InternetExposure
+	next.Vulnerabilities = in.Vulnerabilities
+	next.ControlMaturity = in.ControlMaturity
+	next.IncidentHistory = in.IncidentHistory
+	next.Exploitability = in.Exploitability
+	next.FinancialValue = in.FinancialValue
+	next.ThreatIntel = in.ThreatIntel
+	if err := next.Validate(); err != nil {
+		return nil, domain.NewValidationError("scoring_weights: " + appMessage(err))
+	}
+
+	ch := Change{Section: SectionScoringWeights, Key: "weights"}
+	switch {
+	case current != nil && same(weightsOf(current), weightsOf(next)):
+		ch.Action = ActionUnchanged
+		return []step{{change: ch}}, nil
+	case current == nil:
+		ch.Action = ActionCreate
+	default:
+		ch.Action = ActionUpdate
+	}
+	if actor != nil {
+		next.UpdatedBy = *actor
+	}
+	next.UpdatedAt = s.now()
+	return []step{{ch, func(ctx context.Context) error { return s.weights.Upsert(ctx, next) }}}, nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+func ruleOf(r *domain.AutomationRule) Rule {
+	enabled := r.Enabled
+	return Rule{
+		Name: r.Name, Description: r.Description, Enabled: &enabled, Trigger: r.Trigger,
+		Conditions: r.Conditions, Actions: r.Actions, SLA: r.SLA, Priority: r.Priority,
+	}
+}
+
+func categoryOf(c *domain.RiskCategory) Category {
+	active := c.Active
+	return Category{
+		Slug: c.Slug, Name: c.Name, Description: c.Description, Color: c.Color,
+		SortOrder: c.SortOrder, Active: &active,
+	}
+}
+
+func schemaOf(s *domain.AssetTypeSchema) Schema {
+	return Schema{Category: s.Category, Label: s.Label, Attributes: s.Attributes}
+}
+
+func weightsOf(w *domain.RiskScoringWeights) *Weights {
+	return &Weights{
+		BusinessCriticality: w.BusinessCriticality, InternetExposure: w.InternetExposure,
+		Vulnerabilities: w.Vulnerabilities, ControlMaturity: w.ControlMaturity,
+		IncidentHistory: w.IncidentHistory, Exploitability: w.Exploitability,
+		FinancialValue: w.FinancialValue, ThreatIntel: w.ThreatIntel,
+	}
+}
+
+// same compares two portable items by their JSON form, which is exactly what
+// a document round-trips.
+func same(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
+
+func changedOnly(changes []Change) []Change {
+	out := make([]Change, 0, len(changes))
+	for _, c := range changes {
+		if c.Action != ActionUnchanged {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func itemError(section string, i int, err error) error {
+	return domain.NewValidationError(fmt.Sprintf("%s[%d]: %s", section, i, appMessage(err)))
+}
+
+func appMessage(err error) string {
+	if ae, ok := err.(*domain.AppError); ok && ae.Message != "" {
+		return ae.Message
+	}
+	return err.Error()
+}
+
+func (s *Service) record(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, action domain.AuditAction, summary string, after domain.JSONMap) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    actor,
+		Action:     action,
+		EntityType: "tenant_config",
+		EntityID:   tenantID.String(),
+		Summary:    summary,
+		After:      after,
+	})
+}
+
+func conflict(msg string) error {
+	return &domain.AppError{Err: domain.ErrConflict, Code: http.StatusConflict, Message: msg}
+}
@@ -0,0 +1,294 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package tenantconfig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+type memRules struct {
+	domain.AutomationRuleRepository
+	items []domain.AutomationRule
+}
+
+func (m *memRules) List(_ context.Context, tenantID uuid.UUID) ([]domain.AutomationRule, error) {
+	var out []domain.AutomationRule
+	for _, r := range m.items {
+		if r.TenantID == tenantID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+func (m *memRules) Create(_ context.Context, r *domain.AutomationRule) error {
+	m.items = append(m.items, *r)
+	return nil
+}
+func (m *memRules) Update(_ context.Context, r *domain.AutomationRule) error {
+	for i := range m.items {
+		if m.items[i].ID == r.ID && m.items[i].TenantID == r.TenantID {
+			m.items[i] = *r
+			return nil
+		}
+	}
+	return errors.New("not found")
+}
+
+type memCategories struct {
+	domain.RiskCategoryRepository
+	items []domain.RiskCategory
+}
+
+func (m *memCategories) List(_ context.Context, tenantID uuid.UUID, _ bool) ([]domain.RiskCategory, error) {
+	var out []domain.RiskCategory
+	for _, c := range m.items {
+		if c.TenantID == tenantID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+func (m *memCategories) Create(_ context.Context, c *domain.RiskCategory) error {
+	c.ID = uuid.New()
+	m.items = append(m.items, *c)
+	return nil
+}
+func (m *memCategories) Update(_ context.Context, c *domain.RiskCategory) error {
+	for i := range m.items {
+		if m.items[i].ID == c.ID {
+			m.items[i] = *c
+			return nil
+		}
+	}
+	return errors.New("not found")
+}
+
+type memSchemas struct {
+	domain.AssetTypeSchemaRepository
+	items []domain.AssetTypeSchema
+}
+
+func (m *memSchemas) ListByTenant(_ context.Context, tenantID uuid.UUID) ([]domain.AssetTypeSchema, error) {
+	var out []domain.AssetTypeSchema
+	for _, s := range m.items {
+		if s.TenantID == tenantID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+func (m *memSchemas) GetByCategory(_ context.Context, tenantID uuid.UUID, cat domain.AssetCategory) (*domain.AssetTypeSchema, error) {
+	for _, s := range m.items {
+		if s.TenantID == tenantID && s.Category == cat {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+func (m *memSchemas) Upsert(_ context.Context, s *domain.AssetTypeSchema) error {
+	for i := range m.items {
+		if m.items[i].TenantID == s.TenantID && m.items[i].Category == s.Category {
+			m.items[i] = *s
+			return nil
+		}
+	}
+	m.items = append(m.items, *s)
+	return nil
+}
+
+type memWeights struct {
+	domain.RiskScoringWeightsRepository
+	rows map[uuid.UUID]domain.RiskScoringWeights
+}
+
+func (m *memWeights) GetByTenant(_ context.Context, tenantID uuid.UUID) (*domain.RiskScoringWeights, error) {
+	if w, ok := m.rows[tenantID]; ok {
+		return &w, nil
+	}
+	return nil, nil
+}
+func (m *memWeights) Upsert(_ context.Context, w *domain.RiskScoringWeights) error {
+	m.rows[w.TenantID] = *w
+	return nil
+}
+
+type memAudit struct{ events []domain.AuditEvent }
+
+func (m *memAudit) Record(_ context.Context, e domain.AuditEvent) { m.events = append(m.events, e) }
+
+type fixture struct {
+	svc        *Service
+	rules      *memRules
+	categories *memCategories
+	schemas    *memSchemas
+	weights    *memWeights
+	audit      *memAudit
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		rules: &memRules{}, categories: &memCategories{},
+		schemas: &memSchemas{}, weights: &memWeights{rows: map[uuid.UUID]domain.RiskScoringWeights{}},
+		audit: &memAudit{},
+	}
+	f.svc = NewService(f.rules, f.categories, f.schemas, f.weights).WithAudit(f.audit)
+	return f
+}
+
+func sampleDoc() Document {
+	return Document{
+		APIVersion: APIVersion, Kind: Kind,
+		AutomationRules: []Rule{{
+			Name: "Critical KEV", Trigger: domain.TriggerVulnerabilityDetected,
+			Conditions: domain.AutomationConditions{KEVOnly: true},
+			Actions:    domain.AutomationActionList{{Type: domain.ActionCreateRisk}},
+		}},
+		RiskCategories: []Category{{Name: "Supply chain", Color: "high", SortOrder: 7}},
+		AssetSchemas: []Schema{{Category: domain.CategoryServer, Label: "Serveur", Attributes: []domain.AttributeDef{
+			{Key: "hostname", Label: "Nom d'hôte", Type: domain.AttrString, Required: true},
+		}}},
+		ScoringWeights: &Weights{BusinessCriticality: 0.5, Vulnerabilities: 0.5},
+	}
+}
+
+func TestImport_DryRunPlansWithoutWriting(t *testing.T) {
+	f := newFixture()
+	tenant := uuid.New()
+
+	res, err := f.svc.Import(context.Background(), tenant, nil, sampleDoc(), true)
+	require.NoError(t, err)
+	assert.True(t, res.DryRun)
+	assert.Equal(t, 4, res.Created)
+	assert.Equal(t, []Change{
+		{SectionAutomationRules, "Critical KEV", ActionCreate},
+		{SectionRiskCategories, "supply-chain", ActionCreate},
+		{SectionAssetSchemas, "server", ActionCreate},
+		{SectionScoringWeights, "weights", ActionCreate},
+	}, res.Changes)
+	assert.Empty(t, f.rules.items)
+	assert.Empty(t, f.categories.items)
+	assert.Empty(t, f.schemas.items)
+	assert.Empty(t, f.weights.rows)
+	assert.Empty(t, f.audit.events, "a dry run is not audited")
+}
+
+func TestImport_UpsertsByNaturalKeyAndIsIdempotent(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+	tenant, actor := uuid.New(), uuid.New()
+
+	_, err := f.svc.Import(ctx, tenant, &actor, sampleDoc(), false)
+	require.NoError(t, err)
+	require.Len(t, f.rules.items, 1)
+	assert.True(t, f.rules.items[0].Enabled, "an omitted enabled flag defaults to on")
+	assert.Equal(t, 100, f.rules.items[0].Priority)
+	assert.Equal(t, actor, f.rules.items[0].CreatedBy)
+	require.Len(t, f.categories.items, 1)
+	assert.Equal(t, "supply-chain", f.categories.items[0].Slug)
+	require.Len(t, f.schemas.items, 1)
+	assert.True(t, f.schemas.items[0].Customized)
+	assert.Equal(t, 2, f.schemas.items[0].Version, "the shipped default was version 1")
+	assert.Equal(t, actor, f.weights.rows[tenant].UpdatedBy)
+	require.Len(t, f.audit.events, 1)
+
+	res, err := f.svc.Import(ctx, tenant, &actor, sampleDoc(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 4, res.Unchanged, "re-applying the same document changes nothing")
+	assert.Len(t, f.rules.items, 1)
+	assert.Len(t, f.audit.events, 1, "a no-op import is not audited")
+
+	doc := sampleDoc()
+	off := false
+	doc.AutomationRules[0].Name = "critical kev" // names match case-insensitively
+	doc.AutomationRules[0].Enabled = &off
+	doc.RiskCategories = nil
+	doc.AssetSchemas = nil
+	doc.ScoringWeights = nil
+	res, err = f.svc.Import(ctx, tenant, &actor, doc, false)
+	require.NoError(t, err)
+	assert.Equal(t, []Change{{SectionAutomationRules, "critical kev", ActionUpdate}}, res.Changes)
+	require.Len(t, f.rules.items, 1)
+	assert.False(t, f.rules.items[0].Enabled)
+	assert.Len(t, f.categories.items, 1, "an omitted section is left alone")
+}
+
+func TestImport_ValidatesWholeDocumentBeforeWriting(t *testing.T) {
+	f := newFixture()
+	tenant := uuid.New()
+
+	doc := sampleDoc()
+	doc.ScoringWeights = &Weights{BusinessCriticality: 2}
+	_, err := f.svc.Import(context.Background(), tenant, nil, doc, false)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+	assert.Contains(t, err.Error(), "scoring_weights")
+	assert.Empty(t, f.rules.items, "earlier sections are not applied when a later one is invalid")
+
+	doc = sampleDoc()
+	doc.AutomationRules = append(doc.AutomationRules, doc.AutomationRules[0])
+	_, err = f.svc.Import(context.Background(), tenant, nil, doc, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "appears twice")
+
+	doc = sampleDoc()
+	doc.AssetSchemas[0].Category = "toaster"
+	_, err = f.svc.Import(context.Background(), tenant, nil, doc, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "asset_schemas[0]")
+
+	_, err = f.svc.Import(context.Background(), tenant, nil, Document{Kind: "Something"}, false)
+	require.Error(t, err)
+}
+
+func TestImport_AmbiguousRuleNameConflicts(t *testing.T) {
+	f := newFixture()
+	tenant := uuid.New()
+	for i := 0; i < 2; i++ {
+		f.rules.items = append(f.rules.items, domain.AutomationRule{ID: uuid.New(), TenantID: tenant, Name: "Critical KEV"})
+	}
+	_, err := f.svc.Import(context.Background(), tenant, nil, sampleDoc(), false)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrConflict))
+}
+
+func TestExport_RoundTripsThroughImport(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+	tenant := uuid.New()
+	_, err := f.svc.Import(ctx, tenant, nil, sampleDoc(), false)
+	require.NoError(t, err)
+	f.schemas.items = append(f.schemas.items, *domain.DefaultSchemaFor(tenant, domain.CategoryCloud))
+
+	doc, err := f.svc.Export(ctx, tenant, nil)
+	require.NoError(t, err)
+	assert.Equal(t, APIVersion, doc.APIVersion)
+	assert.Len(t, doc.AutomationRules, 1)
+	assert.Len(t, doc.RiskCategories, 1)
+	assert.Len(t, doc.AssetSchemas, 1, "untouched default schemas are not pinned")
+	require.NotNil(t, doc.ScoringWeights)
+
+	raw, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var back Document
+	require.NoError(t, json.Unmarshal(raw, &back))
+	res, err := f.svc.Import(ctx, tenant, nil, back, true)
+	require.NoError(t, err)
+	assert.Equal(t, 4, res.Unchanged)
+
+	other := uuid.New()
+	res, err = f.svc.Import(ctx, other, nil, back, true)
+	require.NoError(t, err)
+	assert.Equal(t, 4, res.Created, "a document applies to any tenant")
+}
@@ -0,0 +1,680 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package thehive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/assetmatch"
+)
+
+// ---------------------------------------------------------------------------
+// Vocabulary mapping
+//
+// The two tools do not share a status list. The sync compares them in OpenRisk's
+// vocabulary, collapsed to what both can express: open, in_progress, resolved.
+// "closed" and "resolved" are one state here — TheHive has a single closed
+// stage — so moving an incident from resolved to closed is not a change TheHive
+// needs to hear about.
+// ---------------------------------------------------------------------------
+
+func canonicalStatus(local string) string {
+	switch strings.ToLower(strings.TrimSpace(local)) {
+	case "in_progress", "investigating":
+		return "in_progress"
+	case "resolved", "closed":
+		return "resolved"
+	default:
+		return "open"
+	}
+}
+
+// remoteStage resolves a case to its stage. TheHive 5 returns stage alongside
+// status; older payloads (and webhooks) may carry only the status.
+func remoteStage(rc *RemoteCase) string {
+	if rc.Stage != "" {
+		return rc.Stage
+	}
+	switch rc.Status {
+	case "New", "":
+		return "New"
+	case "InProgress":
+		return "InProgress"
+	default:
+		return "Closed"
+	}
+}
+
+func statusFromRemote(rc *RemoteCase) string {
+	switch remoteStage(rc) {
+	case "InProgress":
+		return "in_progress"
+	case "Closed":
+		return "resolved"
+	default:
+		return "open"
+	}
+}
+
+// remoteStatusFor picks the TheHive status for a canonical local status.
+// TruePositive is the closing status: an incident is, by definition, one.
+func remoteStatusFor(canonical string) string {
+	switch canonical {
+	case "in_progress":
+		return "InProgress"
+	case "resolved":
+		return "TruePositive"
+	default:
+		return "New"
+	}
+}
+
+func severityFromRemote(sev int) string {
+	switch {
+	case sev >= 4:
+		return "critical"
+	case sev == 3:
+		return "high"
+	case sev == 2:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+func severityToRemote(sev string) int {
+	switch strings.ToLower(sev) {
+	case "critical":
+		return 4
+	case "high":
+		return 3
+	case "medium":
+		return 2
+	default:
+		return 1
+	}
+}
+
+func canonicalTaskStatus(local string) string {
+	switch strings.ToLower(local) {
+	case "in_progress", "completed", "cancelled":
+		return strings.ToLower(local)
+	default:
+		return "pending"
+	}
+}
+
+func taskStatusFromRemote(remote string) string {
+	switch remote {
+	case "InProgress":
+		return "in_progress"
+	case "Completed":
+		return "completed"
+	case "Cancel":
+		return "cancelled"
+	default:
+		return "pending"
+	}
+}
+
+func taskStatusToRemote(canonical string) string {
+	switch canonical {
+	case "in_progress":
+		return "InProgress"
+	case "completed":
+		return "Completed"
+	case "cancelled":
+		return "Cancel"
+	default:
+		return "Waiting"
+	}
+}
+
+func fingerprint(parts ...string) string {
+	h := sha256.Sum256([]byte(strings.Join(parts, "\x1f")))
+	return hex.EncodeToString(h[:])
+}
+
+func localFingerprint(inc *domain.Incident) string {
+	return fingerprint(inc.Title, canonicalStatus(inc.Status), strings.ToLower(inc.Severity))
+}
+
+func remoteFingerprint(rc *RemoteCase) string {
+	return fingerprint(rc.Title, statusFromRemote(rc), severityFromRemote(rc.Severity))
+}
+
+// Tasks are fingerprinted on status only: the incident service can change an
+// action's status but not rename it, so a title in the hash would be a change
+// the pull side could never apply.
+func actionFingerprint(a *domain.IncidentAction) string {
+	return fingerprint(canonicalTaskStatus(a.Status))
+}
+
+func taskFingerprint(t *RemoteTask) string {
+	return fingerprint(taskStatusFromRemote(t.Status))
+}
+
+// ---------------------------------------------------------------------------
+// Cases
+// ---------------------------------------------------------------------------
+
+// pushIncident creates the incident's case, or reconciles an existing pair.
+func (s *SyncService) pushIncident(ctx context.Context, c *domain.TheHiveConnection, api CaseAPI, inc *domain.Incident, rep *SyncReport) error {
+	link, err := s.repo.GetCaseLinkByIncident(ctx, c.TenantID, inc.ID)
+	if err != nil {
+		return err
+	}
+	if link == nil {
+		if !c.PushIncidents {
+			return nil
+		}
+		return s.createCase(ctx, c, api, inc, rep)
+	}
+	rc, err := api.GetCase(ctx, link.CaseID)
+	if err != nil {
+		return err
+	}
+	if rc == nil {
+		return s.caseGone(ctx, link)
+	}
+	return s.reconcile(ctx, c, api, link, inc, rc, rep)
+}
+
+func (s *SyncService) createCase(ctx context.Context, c *domain.TheHiveConnection, api CaseAPI, inc *domain.Incident, rep *SyncReport) error {
+	description := inc.Description
+	if strings.TrimSpace(description) == "" {
+		// TheHive rejects a case without a description.
+		description = inc.Title
+	}
+	status := canonicalStatus(inc.Status)
+	created, err := api.CreateCase(ctx, RemoteCase{
+		Title:       inc.Title,
+		Description: description,
+		Severity:    severityToRemote(inc.Severity),
+		Status:      remoteStatusFor(status),
+		Tags:        []string{"openrisk", fmt.Sprintf("openrisk:incident:%d", inc.ID)},
+	})
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	link := &domain.TheHiveCaseLink{
+		ID:                uuid.New(),
+		TenantID:          c.TenantID,
+		ConnectionID:      c.ID,
+		IncidentID:        inc.ID,
+		CaseID:            created.ID,
+		CaseNumber:        created.Number,
+		LocalFingerprint:  localFingerprint(inc),
+		RemoteFingerprint: remoteFingerprint(created),
+		LastSyncedAt:      now,
+		LastDirection:     domain.TheHiveDirectionPush,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if !created.UpdatedAt.IsZero() {
+		t := created.UpdatedAt
+		link.RemoteUpdatedAt = &t
+	}
+	if err := s.repo.SaveCaseLink(ctx, link); err != nil {
+		return err
+	}
+	rep.Created++
+	s.timeline(inc.ID, fmt.Sprintf("Pushed to TheHive as case #%d", created.Number), created.ID)
+	return s.syncTasks(ctx, c, api, link, inc)
+}
+
+// pullCase imports an unknown case (when the connection imports) or reconciles
+// a linked one.
+func (s *SyncService) pullCase(ctx context.Context, c *domain.TheHiveConnection, api CaseAPI, rc *RemoteCase, rep *SyncReport) error {
+	link, err := s.repo.GetCaseLinkByCase(ctx, c.TenantID, rc.ID)
+	if err != nil {
+		return err
+	}
+	if link == nil {
+		if !c.ImportCases || pushedByUs(rc) {
+			return nil
+		}
+		return s.importCase(ctx, c, api, rc, rep)
+	}
+	inc, err := s.incidents.GetIncident(c.TenantID.String(), link.IncidentID)
+	if err != nil {
+		link.LastError = "incident no longer exists in OpenRisk"
+		link.UpdatedAt = s.now()
+		return s.repo.SaveCaseLink(ctx, link)
+	}
+	return s.reconcile(ctx, c, api, link, inc, rc, rep)
+}
+
+// pushedByUs spots a case we created whose link was never recorded (a crash
+// between the two writes). Importing it would mirror the incident into a twin.
+func pushedByUs(rc *RemoteCase) bool {
+	for _, t := range rc.Tags {
+		if strings.HasPrefix(t, "openrisk:incident:") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SyncService) importCase(ctx context.Context, c *domain.TheHiveConnection, api CaseAPI, rc *RemoteCase, rep *SyncReport) error {
+	tenant := c.TenantID.String()
+	description := rc.Description
+	if strings.TrimSpace(description) == "" {
+		description = rc.Title
+	}
+	inc, err := s.incidents.CreateIncident(tenant, domain.IncidentCreateRequest{
+		Title:        rc.Title,
+		Description:  description,
+		IncidentType: "security_incident",
+		Severity:     severityFromRemote(rc.Severity),
+		Source:       "thehive",
+		ReportedBy:   syncActor,
+		Origin:       domain.OriginIntegration,
+		OriginDetail: fmt.Sprintf("TheHive case #%d", rc.Number),
+	})
+	if err != nil {
+		return err
+	}
+	if status := statusFromRemote(rc); status != "open" {
+		req := domain.IncidentUpdateRequest{Status: status}
+		if status == "resolved" {
+			req.Resolution = "Closed in TheHive as " + rc.Status
+		}
+		if updated, err := s.incidents.UpdateIncident(tenant, inc.ID, req, syncActor); err == nil {
+			inc = updated
+		}
+		if fresh, err := s.incidents.GetIncident(tenant, inc.ID); err == nil {
+			inc = fresh
+		}
+	}
+	now := s.now()
+	link := &domain.TheHiveCaseLink{
+		ID:                uuid.New(),
+		TenantID:          c.TenantID,
+		ConnectionID:      c.ID,
+		IncidentID:        inc.ID,
+		CaseID:            rc.ID,
+		CaseNumber:        rc.Number,
+		LocalFingerprint:  localFingerprint(inc),
+		RemoteFingerprint: remoteFingerprint(rc),
+		LastSyncedAt:      now,
+		LastDirection:     domain.TheHiveDirectionPull,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if !rc.UpdatedAt.IsZero() {
+		t := rc.UpdatedAt
+		link.RemoteUpdatedAt = &t
+	}
+	if err := s.repo.SaveCaseLink(ctx, link); err != nil {
+		return err
+	}
+	rep.Imported++
+	if err := s.syncTasks(ctx, c, api, link, inc); err != nil {
+		return err
+	}
+	return s.syncObservables(ctx, c, api, link, inc)
+}
+
+// reconcile propagates whichever side moved since the last exchange. When both
+// did, the later write wins: there is no merge of a status, and asking a human
+// to arbitrate every race between an analyst and a risk owner would stall the
+// sync on the incidents that matter most.
+func (s *SyncService) reconcile(ctx context.Context, c *domain.TheHiveConnection, api CaseAPI, link *domain.TheHiveCaseLink, inc *domain.Incident, rc *RemoteCase, rep *SyncReport) error {
+	tenant := c.TenantID.String()
+	lfp, rfp := localFingerprint(inc), remoteFingerprint(rc)
+	localMoved := lfp != link.LocalFingerprint
+	remoteMoved := rfp != link.RemoteFingerprint
+	link.LastError = ""
+
+	switch {
+	case lfp == rfp:
+		// Already in step — typically our own push coming back as a webhook.
+	case localMoved && (!remoteMoved || !rc.UpdatedAt.After(inc.UpdatedAt)):
+		if err := api.UpdateCase(ctx, link.CaseID, casePatch(inc, rc)); err != nil {
+			return err
+		}
+		rfp = lfp
+		link.LastDirection = domain.TheHiveDirectionPush
+		rep.Pushed++
+	case remoteMoved:
+		req := incidentPatch(rc, inc)
+		if _, err := s.incidents.UpdateIncident(tenant, inc.ID, req, syncActor); err != nil {
+			// The incident service refused it — most often TheHive closing a
+			// CRITICAL incident whose post-mortem is not published. The refusal
+			// is recorded, the remote state is marked as seen so it is not
+			// retried every tick, and the pair stays divergent until one side
+			// moves again.
+			link.LastError = err.Error()
+			s.timeline(inc.ID, "TheHive change not applied: "+err.Error(), link.CaseID)
+		} else {
+			if fresh, err := s.incidents.GetIncident(tenant, inc.ID); err == nil {
+				inc = fresh
+			}
+			lfp = localFingerprint(inc)
+			link.LastDirection = domain.TheHiveDirectionPull
+			rep.Pulled++
+		}
+	default:
+		// Divergent but neither side moved: a refusal recorded earlier. Wait.
+	}
+
+	now := s.now()
+	link.LocalFingerprint, link.RemoteFingerprint = lfp, rfp
+	link.LastSyncedAt = now
+	link.UpdatedAt = now
+	if !rc.UpdatedAt.IsZero() {
+		t := rc.UpdatedAt
+		link.RemoteUpdatedAt = &t
+	}
+	if rc.Number != 0 {
+		link.CaseNumber = rc.Number
+	}
+	if err := s.repo.SaveCaseLink(ctx, link); err != nil {
+		return err
+	}
+	if err := s.syncTasks(ctx, c, api, link, inc); err != nil {
+		return err
+	}
+	return s.syncObservables(ctx, c, api, link, inc)
+}
+
+func (s *SyncService) caseGone(ctx context.Context, link *domain.TheHiveCaseLink) error {
+	link.LastError = "case no longer exists in TheHive"
+	link.UpdatedAt = s.now()
+	return s.repo.SaveCaseLink(ctx, link)
+}
+
+// casePatch carries only the fields that differ, so a severity change does not
+// also re-send a status TheHive may have refined (FalsePositive → TruePositive).
+func casePatch(inc *domain.Incident, rc *RemoteCase) map[string]any {
+	patch := map[string]any{}
+	if inc.Title != rc.Title {
+		patch["title"] = inc.Title
+	}
+	if sev := severityToRemote(inc.Severity); sev != rc.Severity {
+		patch["severity"] = sev
+	}
+	if status := canonicalStatus(inc.Status); status != statusFromRemote(rc) {
+		patch["status"] = remoteStatusFor(status)
+		if status == "resolved" {
+			summary := inc.Resolution
+			if strings.TrimSpace(summary) == "" {
+				summary = "Resolved in OpenRisk"
+			}
+			patch["summary"] = summary
+		}
+	}
+	return patch
+}
+
+func incidentPatch(rc *RemoteCase, inc *domain.Incident) domain.IncidentUpdateRequest {
+	var req domain.IncidentUpdateRequest
+	if rc.Title != inc.Title {
+		req.Title = rc.Title
+	}
+	if sev := severityFromRemote(rc.Severity); sev != strings.ToLower(inc.Severity) {
+		req.Severity = sev
+	}
+	if status := statusFromRemote(rc); status != canonicalStatus(inc.Status) {
+		req.Status = status
+		if status == "resolved" {
+			req.Resolution = "Closed in TheHive as " + rc.Status
+		}
+	}
+	return req
+}
+
+// ---------------------------------------------------------------------------
+// Tasks
+// ---------------------------------------------------------------------------
+
+// syncTasks mirrors actions and tasks: an unlinked action becomes a task, an
+// unlinked task becomes an action, and linked pairs reconcile their status.
+func (s *SyncService) syncTasks(ctx context.Context, c *domain.TheHiveConnection, api CaseAPI, link *domain.TheHiveCaseLink, inc *domain.Incident) error {
+	tenant := c.TenantID.String()
+	actions, err := s.incidents.GetIncidentActions(tenant, inc.ID)
+	if err != nil {
+		return err
+	}
+	tasks, err := api.ListTasks(ctx, link.CaseID)
+	if err != nil {
+		return err
+	}
+	links, err := s.repo.ListTaskLinks(ctx, c.TenantID, link.ID)
+	if err != nil {
+		return err
+	}
+	byAction := make(map[uint]*domain.TheHiveTaskLink, len(links))
+	byTask := make(map[string]*domain.TheHiveTaskLink, len(links))
+	for i := range links {
+		byAction[links[i].ActionID] = &links[i]
+		byTask[links[i].TaskID] = &links[i]
+	}
+	taskByID := make(map[string]*RemoteTask, len(tasks))
+	for i := range tasks {
+		taskByID[tasks[i].ID] = &tasks[i]
+	}
+	now := s.now()
+
+	for i := range actions {
+		a := &actions[i]
+		tl := byAction[a.ID]
+		if tl == nil {
+			rt := RemoteTask{Title: a.Title, Description: a.Description, Status: taskStatusToRemote(canonicalTaskStatus(a.Status))}
+			if !a.DueDate.IsZero() {
+				due := a.DueDate
+				rt.DueDate = &due
+			}
+			created, err := api.CreateTask(ctx, link.CaseID, rt)
+			if err != nil {
+				return err
+			}
+			fp := actionFingerprint(a)
+			if err := s.repo.SaveTaskLink(ctx, &domain.TheHiveTaskLink{
+				ID: uuid.New(), TenantID: c.TenantID, CaseLinkID: link.ID,
+				ActionID: a.ID, TaskID: created.ID,
+				LocalFingerprint: fp, RemoteFingerprint: fp,
+				CreatedAt: now, UpdatedAt: now,
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+		t := taskByID[tl.TaskID]
+		if t == nil {
+			continue // deleted in TheHive; the action stays
+		}
+		lfp, rfp := actionFingerprint(a), taskFingerprint(t)
+		if lfp == rfp {
+			if tl.LocalFingerprint == lfp && tl.RemoteFingerprint == rfp {
+				continue
+			}
+		} else {
+			localMoved := lfp != tl.LocalFingerprint
+			remoteMoved := rfp != tl.RemoteFingerprint
+			actionAt := a.UpdatedAt
+			if actionAt.IsZero() {
+				actionAt = a.CreatedAt
+			}
+			switch {
+			case localMoved && (!remoteMoved || !t.UpdatedAt.After(actionAt)):
+				status := taskStatusToRemote(canonicalTaskStatus(a.Status))
+				if err := api.UpdateTask(ctx, t.ID, map[string]any{"status": status}); err != nil {
+					return err
+				}
+				rfp = lfp
+			case remoteMoved:
+				if err := s.incidents.UpdateIncidentAction(tenant, a.ID, taskStatusFromRemote(t.Status)); err != nil {
+					return err
+				}
+				lfp = rfp
+			}
+		}
+		tl.LocalFingerprint, tl.RemoteFingerprint = lfp, rfp
+		tl.UpdatedAt = now
+		if err := s.repo.SaveTaskLink(ctx, tl); err != nil {
+			return err
+		}
+	}
+
+	for i := range tasks {
+		t := &tasks[i]
+		if byTask[t.ID] != nil {
+			continue
+		}
+		var due time.Time
+		if t.DueDate != nil {
+			due = *t.DueDate
+		}
+		a, err := s.incidents.CreateIncidentAction(tenant, inc.ID, t.Title, t.Description, due, "")
+		if err != nil {
+			return err
+		}
+		if status := taskStatusFromRemote(t.Status); status != "pending" {
+			if err := s.incidents.UpdateIncidentAction(tenant, a.ID, status); err != nil {
+				return err
+			}
+		}
+		fp := taskFingerprint(t)
+		if err := s.repo.SaveTaskLink(ctx, &domain.TheHiveTaskLink{
+			ID: uuid.New(), TenantID: c.TenantID, CaseLinkID: link.ID,
+			ActionID: a.ID, TaskID: t.ID,
+			LocalFingerprint: fp, RemoteFingerprint: fp,
+			CreatedAt: now, UpdatedAt: now,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Observables
+// ---------------------------------------------------------------------------
+
+// findingFor turns an observable that names a machine into an assetmatch
+// finding. Hashes, URLs, mail addresses and the like name no machine; they are
+// recorded but never attributed.
+func findingFor(o RemoteObservable) (assetmatch.Finding, bool) {
+	data := strings.TrimSpace(o.Data)
+	if data == "" {
+		return assetmatch.Finding{}, false
+	}
+	switch strings.ToLower(o.DataType) {
+	case "ip":
+		return assetmatch.Finding{IPs: []string{data}}, true
+	case "hostname", "fqdn", "domain":
+		return assetmatch.Finding{Hostname: data, AssetName: data}, true
+	default:
+		return assetmatch.Finding{}, false
+	}
+}
+
+// syncObservables records the case's new observables and attributes those that
+// match one asset confidently enough (the same bar vulnerability correlation
+// uses). Newly attributed assets are added to the incident, which is what puts
+// the incident on the asset's score.
+func (s *SyncService) syncObservables(ctx context.Context, c *domain.TheHiveConnection, api CaseAPI, link *domain.TheHiveCaseLink, inc *domain.Incident) error {
+	remote, err := api.ListObservables(ctx, link.CaseID)
+	if err != nil {
+		return err
+	}
+	if len(remote) == 0 {
+		return nil
+	}
+	known, err := s.repo.ListObservables(ctx, c.TenantID, link.ID)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(known))
+	for _, o := range known {
+		seen[o.ObservableID] = true
+	}
+
+	var candidates []assetmatch.Candidate
+	loaded := false
+	now := s.now()
+	var attributed []string
+	for _, o := range remote {
+		if seen[o.ID] {
+			continue
+		}
+		row := &domain.TheHiveObservable{
+			ID: uuid.New(), TenantID: c.TenantID, CaseLinkID: link.ID,
+			ObservableID: o.ID, DataType: strings.ToLower(o.DataType), Data: o.Data,
+			CreatedAt: now, UpdatedAt: now,
+		}
+		if f, ok := findingFor(o); ok && s.assets != nil {
+			if !loaded {
+				candidates, err = s.candidates(ctx, c.TenantID)
+				if err != nil {
+					return err
+				}
+				loaded = true
+			}
+			res := assetmatch.Correlate(f, candidates)
+			if res.Best != nil {
+				row.Confidence = res.Best.Confidence
+				row.MatchReason = res.Best.Reason
+				if !res.Ambiguous && res.Best.Confidence >= assetmatch.AutoAssignThreshold {
+					if id, err := uuid.Parse(res.Best.AssetID); err == nil {
+						row.AssetID = &id
+						attributed = append(attributed, id.String())
+					}
+				} else if res.Ambiguous {
+					row.MatchReason = "ambiguous: " + row.MatchReason
+				}
+			}
+		}
+		if err := s.repo.SaveObservable(ctx, row); err != nil {
+			return err
+		}
+	}
+
+	if len(attributed) > 0 {
+		if err := s.incidents.LinkAssets(c.TenantID.String(), inc.ID, attributed); err != nil {
+			return err
+		}
+		s.timeline(inc.ID, fmt.Sprintf("%d asset(s) attributed from TheHive observables", len(attributed)), strings.Join(attributed, ","))
+	}
+	return nil
+}
+
+func (s *SyncService) candidates(ctx context.Context, tenantID uuid.UUID) ([]assetmatch.Candidate, error) {
+	assets, err := s.assets.List(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]assetmatch.Candidate, 0, len(assets))
+	for i := range assets {
+		a := &assets[i]
+		out = append(out, assetmatch.Candidate{
+			ID:              a.ID.String(),
+			Name:            a.Name,
+			ExternalID:      a.ExternalID,
+			Hostnames:       a.Hostnames,
+			IPs:             a.IPAddresses,
+			CloudResourceID: a.CloudResourceID,
+		})
+	}
+	return out, nil
+}
+
+// timeline writes a sync entry. Best effort: the sync itself has already
+// happened, and the link row records it either way.
+func (s *SyncService) timeline(incidentID uint, message, caseRef string) {
+	_ = s.incidents.AddTimelineEntry(incidentID, "integration", message, caseRef, syncActor)
+}
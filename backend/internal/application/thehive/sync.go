@@ -0,0 +1,532 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package thehive keeps OpenRisk incidents and TheHive 5 cases in step.
+//
+// The SOC works in TheHive; risk, compliance and the regulator clocks live here.
+// Without a real connector the two drift within hours — a case closed in TheHive
+// stays "open" in the register, a severity raised by the analyst never reaches
+// the asset it hit — and somebody reconciles them by hand, late. This package is
+// the connector: incidents are pushed as cases, cases are imported as incidents,
+// and once a pair is linked its status, severity and tasks travel both ways.
+// Case observables that name a machine are matched onto the asset inventory so
+// incident impact reaches asset scoring.
+package thehive
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/netguard"
+)
+
+// RemoteCase is the slice of a TheHive case the sync reads and writes.
+type RemoteCase struct {
+	ID          string
+	Number      int
+	Title       string
+	Description string
+	// Severity is TheHive's 1 (low) … 4 (critical).
+	Severity int
+	// Status is TheHive 5's case status: New, InProgress, or one of the closing
+	// statuses (TruePositive, FalsePositive, Indeterminate, Duplicated, Other…).
+	// Stage (New / InProgress / Closed) is what it resolves to, and is what the
+	// sync keys on, because closing statuses are configurable per instance.
+	Status    string
+	Stage     string
+	Tags      []string
+	UpdatedAt time.Time
+}
+
+// RemoteTask is one task of a case.
+type RemoteTask struct {
+	ID          string
+	Title       string
+	Description string
+	// Status: Waiting, InProgress, Completed or Cancel.
+	Status    string
+	DueDate   *time.Time
+	UpdatedAt time.Time
+}
+
+// RemoteObservable is one observable of a case.
+type RemoteObservable struct {
+	ID       string
+	DataType string
+	Data     string
+}
+
+// CaseAPI is TheHive as the sync sees it. The v1 REST client in
+// infrastructure/integrations/thehive implements it; tests use a fake.
+type CaseAPI interface {
+	CreateCase(ctx context.Context, c RemoteCase) (*RemoteCase, error)
+	// UpdateCase patches only the fields in patch; absent keys are left alone.
+	UpdateCase(ctx context.Context, caseID string, patch map[string]any) error
+	GetCase(ctx context.Context, caseID string) (*RemoteCase, error)
+	ListCasesUpdatedSince(ctx context.Context, since time.Time, limit int) ([]RemoteCase, error)
+
+	ListTasks(ctx context.Context, caseID string) ([]RemoteTask, error)
+	CreateTask(ctx context.Context, caseID string, t RemoteTask) (*RemoteTask, error)
+	UpdateTask(ctx context.Context, taskID string, patch map[string]any) error
+
+	ListObservables(ctx context.Context, caseID string) ([]RemoteObservable, error)
+}
+
+// Dialer builds a CaseAPI for one connection with its decrypted API key.
+type Dialer func(baseURL, organisation, apiKey string) CaseAPI
+
+// Incidents is the part of the incident service the sync drives. It is the
+// legacy, non-context, string-tenant service; going through it (rather than the
+// table) is what keeps the post-mortem gate, the timeline and the notifications
+// in force for changes that arrive from TheHive.
+type Incidents interface {
+	GetIncident(tenantID string, incidentID uint) (*domain.Incident, error)
+	CreateIncident(tenantID string, req domain.IncidentCreateRequest) (*domain.Incident, error)
+	UpdateIncident(tenantID string, incidentID uint, req domain.IncidentUpdateRequest, updatedBy string) (*domain.Incident, error)
+	ListIncidentsUpdatedSince(tenantID string, since time.Time, limit int) ([]domain.Incident, error)
+	LinkAssets(tenantID string, incidentID uint, assetIDs []string) error
+	AddTimelineEntry(incidentID uint, eventType, message, metadata, createdBy string) error
+
+	GetIncidentActions(tenantID string, incidentID uint) ([]domain.IncidentAction, error)
+	CreateIncidentAction(tenantID string, incidentID uint, title, description string, dueDate time.Time, assignedTo string) (*domain.IncidentAction, error)
+	UpdateIncidentAction(tenantID string, actionID uint, status string) error
+}
+
+// AssetLister is the inventory observables are matched against. Optional:
+// without it observables are recorded but never attributed.
+type AssetLister interface {
+	List(ctx context.Context, tenantID uuid.UUID) ([]domain.Asset, error)
+}
+
+// SecretCipher encrypts the API key at rest. The scanner's AES-256-GCM
+// CredentialCipher (SCANNER_CREDENTIAL_KEY) satisfies it.
+type SecretCipher interface {
+	EncryptString(plaintext string) (string, error)
+	DecryptString(ciphertext string) (string, error)
+}
+
+// AuditSink records connection changes in the tamper-evident audit chain.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// syncActor is the updatedBy / reportedBy the sync writes under, so the
+// incident timeline says which changes came from TheHive.
+const syncActor = "thehive-sync"
+
+// pageSize bounds one pull or push pass; the high-water marks carry the rest
+// over to the next one.
+const pageSize = 100
+
+// SyncService is the connector.
+type SyncService struct {
+	repo      domain.TheHiveRepository
+	incidents Incidents
+	dial      Dialer
+	cipher    SecretCipher
+	assets    AssetLister
+	audit     AuditSink
+	now       func() time.Time
+	// allowPrivate lets base_url be an internal address. The Dialer enforces
+	// the same rule on every connection; this only refuses it at save time.
+	allowPrivate bool
+}
+
+// NewSyncService builds the connector. Assets and audit are optional.
+func NewSyncService(repo domain.TheHiveRepository, incidents Incidents, dial Dialer, cipher SecretCipher) *SyncService {
+	return &SyncService{repo: repo, incidents: incidents, dial: dial, cipher: cipher, now: time.Now}
+}
+
+// WithAssets enables observable → asset attribution.
+func (s *SyncService) WithAssets(a AssetLister) *SyncService { s.assets = a; return s }
+
+// WithAudit records connection changes in the audit chain.
+func (s *SyncService) WithAudit(a AuditSink) *SyncService { s.audit = a; return s }
+
+// AllowPrivateNetworks lets connections target private and loopback
+// addresses, for TheHive instances on the internal network. Operator setting
+// (THEHIVE_ALLOW_PRIVATE_NETWORKS); pass the same value to the Dialer.
+func (s *SyncService) AllowPrivateNetworks(allow bool) *SyncService {
+	s.allowPrivate = allow
+	return s
+}
+
+// WithClock overrides the clock (tests).
+func (s *SyncService) WithClock(now func() time.Time) *SyncService { s.now = now; return s }
+
+// ---------------------------------------------------------------------------
+// Connection
+// ---------------------------------------------------------------------------
+
+// ConnectionInput is the create-or-update payload.
+type ConnectionInput struct {
+	Name         string
+	Enabled      bool
+	BaseURL      string
+	Organisation string
+	// APIKey empty keeps the stored key: the key is write-only, so a form that
+	// re-saves the other fields must not wipe it.
+	APIKey                 string
+	PushIncidents          bool
+	ImportCases            bool
+	PollMinutes            int
+	RegenerateWebhookToken bool
+}
+
+// GetConnection returns the tenant's connection, or a not-found error.
+func (s *SyncService) GetConnection(ctx context.Context, tenantID uuid.UUID) (*domain.TheHiveConnection, error) {
+	c, err := s.repo.GetConnection(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if c == nil {
+		return nil, domain.NewNotFoundError("thehive connection", tenantID)
+	}
+	c.HasAPIKey = c.EncryptedAPIKey != ""
+	return c, nil
+}
+
+// SaveConnection creates or updates the tenant's connection.
+func (s *SyncService) SaveConnection(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, in ConnectionInput) (*domain.TheHiveConnection, error) {
+	base := strings.TrimRight(strings.TrimSpace(in.BaseURL), "/")
+	u, err := url.Parse(base)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, domain.NewValidationError("base_url must be an absolute http(s) URL")
+	}
+	if !s.allowPrivate && netguard.InternalHost(u.Hostname()) {
+		return nil, domain.NewValidationError("base_url must be reachable from the internet")
+	}
+	if in.PollMinutes < 0 || in.PollMinutes > 24*60 {
+		return nil, domain.NewValidationError("poll_minutes must be between 0 (webhook only) and 1440")
+	}
+
+	existing, err := s.repo.GetConnection(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	now := s.now()
+	c := &domain.TheHiveConnection{
+		ID:             uuid.New(),
+		TenantID:       tenantID,
+		LastSyncStatus: "never",
+		CreatedAt:      now,
+	}
+	if existing != nil {
+		c = existing
+	}
+	c.Name = strings.TrimSpace(in.Name)
+	if c.Name == "" {
+		c.Name = "TheHive"
+	}
+	c.Enabled = in.Enabled
+	c.BaseURL = base
+	c.Organisation = strings.TrimSpace(in.Organisation)
+	c.PushIncidents = in.PushIncidents
+	c.ImportCases = in.ImportCases
+	c.PollMinutes = in.PollMinutes
+	c.UpdatedAt = now
+
+	if key := strings.TrimSpace(in.APIKey); key != "" {
+		ct, err := s.cipher.EncryptString(key)
+		if err != nil {
+			return nil, err
+		}
+		c.EncryptedAPIKey = ct
+	}
+	if c.EncryptedAPIKey == "" {
+		return nil, domain.NewValidationError("api_key is required")
+	}
+	if c.WebhookToken == "" || in.RegenerateWebhookToken {
+		c.WebhookToken = newWebhookToken()
+	}
+
+	if err := s.repo.SaveConnection(ctx, c); err != nil {
+		return nil, domain.NewInternalError("failed to save thehive connection: " + err.Error())
+	}
+	action := domain.AuditActionUpdate
+	if existing == nil {
+		action = domain.AuditActionCreate
+	}
+	s.record(ctx, tenantID, actor, action, c, "TheHive connection saved ("+c.BaseURL+")")
+	c.HasAPIKey = true
+	return c, nil
+}
+
+// DeleteConnection removes the connection. Links are kept: reconnecting the
+// same instance later picks the pairs up where they were.
+func (s *SyncService) DeleteConnection(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID) error {
+	c, err := s.GetConnection(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteConnection(ctx, tenantID); err != nil {
+		return err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, c, "TheHive connection removed")
+	return nil
+}
+
+func (s *SyncService) record(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, action domain.AuditAction, c *domain.TheHiveConnection, summary string) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    actor,
+		Action:     action,
+		EntityType: "thehive_connection",
+		EntityID:   c.ID.String(),
+		Summary:    summary,
+		After: domain.JSONMap{
+			"base_url":       c.BaseURL,
+			"organisation":   c.Organisation,
+			"enabled":        c.Enabled,
+			"push_incidents": c.PushIncidents,
+			"import_cases":   c.ImportCases,
+			"poll_minutes":   c.PollMinutes,
+		},
+	})
+}
+
+// newWebhookToken returns a URL-safe 32-byte hex token.
+func newWebhookToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return strings.ReplaceAll(uuid.NewString(), "-", "") + strings.ReplaceAll(uuid.NewString(), "-", "")
+	}
+	return hex.EncodeToString(b)
+}
+
+// ---------------------------------------------------------------------------
+// Sync entry points
+// ---------------------------------------------------------------------------
+
+// SyncReport is what one pass did.
+type SyncReport struct {
+	Pulled   int      `json:"pulled"`
+	Pushed   int      `json:"pushed"`
+	Imported int      `json:"imported"`
+	Created  int      `json:"created"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// SyncTenant runs a full pass for one tenant: pull the cases changed since the
+// cursor, then push the incidents changed since the last push.
+func (s *SyncService) SyncTenant(ctx context.Context, tenantID uuid.UUID) (*SyncReport, error) {
+	c, err := s.GetConnection(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !c.Enabled {
+		return nil, domain.NewValidationError("the TheHive connection is disabled")
+	}
+	return s.sync(ctx, c)
+}
+
+// SweepDue runs the fallback poll for every connection whose cadence is due.
+// Returns how many connections were synced.
+func (s *SyncService) SweepDue(ctx context.Context, now time.Time) (int, error) {
+	conns, err := s.repo.ListEnabledConnections(ctx)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for i := range conns {
+		if !conns[i].PollDue(now) {
+			continue
+		}
+		// A failing instance is recorded on its own connection; it must not stop
+		// the other tenants' sync.
+		_, _ = s.sync(ctx, &conns[i])
+		n++
+	}
+	return n, nil
+}
+
+func (s *SyncService) sync(ctx context.Context, c *domain.TheHiveConnection) (*SyncReport, error) {
+	api, err := s.client(c)
+	if err != nil {
+		return nil, err
+	}
+	rep := &SyncReport{}
+	now := s.now()
+
+	since := c.CreatedAt
+	if c.Cursor != nil {
+		since = *c.Cursor
+	}
+	// Both passes walk oldest-first and stop moving their high-water mark at the
+	// first failure, so a case or incident that failed is retried next time
+	// instead of being stepped over.
+	cases, pullErr := api.ListCasesUpdatedSince(ctx, since, pageSize)
+	if pullErr == nil {
+		held := false
+		for i := range cases {
+			rc := cases[i]
+			if err := s.pullCase(ctx, c, api, &rc, rep); err != nil {
+				rep.Errors = append(rep.Errors, fmt.Sprintf("case %s: %v", rc.ID, err))
+				held = true
+				continue
+			}
+			if !held && (c.Cursor == nil || rc.UpdatedAt.After(*c.Cursor)) {
+				t := rc.UpdatedAt
+				c.Cursor = &t
+			}
+		}
+	} else {
+		rep.Errors = append(rep.Errors, "pull: "+pullErr.Error())
+	}
+
+	pushedSince := c.CreatedAt
+	if c.PushedUntil != nil {
+		pushedSince = *c.PushedUntil
+	}
+	incidents, pushErr := s.incidents.ListIncidentsUpdatedSince(c.TenantID.String(), pushedSince, pageSize)
+	if pushErr == nil {
+		held := false
+		for i := range incidents {
+			inc := incidents[i]
+			if err := s.pushIncident(ctx, c, api, &inc, rep); err != nil {
+				rep.Errors = append(rep.Errors, fmt.Sprintf("incident %d: %v", inc.ID, err))
+				held = true
+				continue
+			}
+			if !held && (c.PushedUntil == nil || inc.UpdatedAt.After(*c.PushedUntil)) {
+				t := inc.UpdatedAt
+				c.PushedUntil = &t
+			}
+		}
+	} else {
+		rep.Errors = append(rep.Errors, "push: "+pushErr.Error())
+	}
+
+	c.LastSyncAt = &now
+	c.LastSyncStatus = "ok"
+	c.LastSyncError = ""
+	if len(rep.Errors) > 0 {
+		c.LastSyncStatus = "error"
+		c.LastSyncError = strings.Join(rep.Errors, "; ")
+	}
+	if err := s.repo.SaveConnection(ctx, c); err != nil {
+		return rep, domain.NewInternalError("failed to record sync state: " + err.Error())
+	}
+	return rep, nil
+}
+
+// PushIncident pushes one incident right away (the "send to TheHive" button),
+// creating its case if it has none.
+func (s *SyncService) PushIncident(ctx context.Context, tenantID uuid.UUID, incidentID uint) (*domain.TheHiveCaseLink, error) {
+	c, err := s.GetConnection(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !c.Enabled {
+		return nil, domain.NewValidationError("the TheHive connection is disabled")
+	}
+	api, err := s.client(c)
+	if err != nil {
+		return nil, err
+	}
+	inc, err := s.incidents.GetIncident(tenantID.String(), incidentID)
+	if err != nil {
+		return nil, domain.NewNotFoundError("incident", incidentID)
+	}
+	// An explicit push creates the case even when automatic push is off.
+	forced := *c
+	forced.PushIncidents = true
+	if err := s.pushIncident(ctx, &forced, api, inc, &SyncReport{}); err != nil {
+		return nil, err
+	}
+	return s.repo.GetCaseLinkByIncident(ctx, tenantID, incidentID)
+}
+
+// CaseLink returns the incident's link, or a not-found error.
+func (s *SyncService) CaseLink(ctx context.Context, tenantID uuid.UUID, incidentID uint) (*domain.TheHiveCaseLink, []domain.TheHiveObservable, error) {
+	l, err := s.repo.GetCaseLinkByIncident(ctx, tenantID, incidentID)
+	if err != nil {
+		return nil, nil, domain.NewInternalError(err.Error())
+	}
+	if l == nil {
+		return nil, nil, domain.NewNotFoundError("thehive case link", incidentID)
+	}
+	obs, err := s.repo.ListObservables(ctx, tenantID, l.ID)
+	if err != nil {
+		return nil, nil, domain.NewInternalError(err.Error())
+	}
+	return l, obs, nil
+}
+
+// WebhookEvent is TheHive 5's notifier payload, reduced to what routing needs.
+type WebhookEvent struct {
+	Operation  string `json:"operation"`
+	ObjectType string `json:"objectType"`
+	ObjectID   string `json:"objectId"`
+	RootID     string `json:"rootId"`
+}
+
+// ErrUnknownWebhook is returned for a token that matches no enabled connection.
+var ErrUnknownWebhook = fmt.Errorf("unknown or disabled thehive webhook token")
+
+// HandleWebhook resolves the token to its tenant and pulls the case the event
+// is about. The payload is only a pointer: the case is re-read from the API, so
+// a forged or stale body cannot write anything TheHive itself does not say.
+func (s *SyncService) HandleWebhook(ctx context.Context, token string, ev WebhookEvent) (*SyncReport, error) {
+	if token == "" {
+		return nil, ErrUnknownWebhook
+	}
+	c, err := s.repo.GetConnectionByWebhookToken(ctx, token)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if c == nil || !c.Enabled {
+		return nil, ErrUnknownWebhook
+	}
+	rep := &SyncReport{}
+
+	caseID := ev.RootID
+	if strings.EqualFold(ev.ObjectType, "case") || caseID == "" {
+		caseID = ev.ObjectID
+	}
+	// Deleting a case in TheHive does not delete the incident: the register
+	// outlives the SOC tool's housekeeping.
+	if caseID == "" || strings.EqualFold(ev.Operation, "delete") && strings.EqualFold(ev.ObjectType, "case") {
+		return rep, nil
+	}
+
+	api, err := s.client(c)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := api.GetCase(ctx, caseID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch case %s: %w", caseID, err)
+	}
+	if rc == nil {
+		return rep, nil
+	}
+	if err := s.pullCase(ctx, c, api, rc, rep); err != nil {
+		return rep, err
+	}
+	return rep, nil
+}
+
+func (s *SyncService) client(c *domain.TheHiveConnection) (CaseAPI, error) {
+	key, err := s.cipher.DecryptString(c.EncryptedAPIKey)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return nil, domain.NewValidationError("the TheHive connection has no API key")
+	}
+	return s.dial(c.BaseURL, c.Organisation, key), nil
+}
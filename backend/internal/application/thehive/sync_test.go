@@ -0,0 +1,597 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package thehive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// ---------------------------------------------------------------------------
+// Fakes
+// ---------------------------------------------------------------------------
+
+type memRepo struct {
+	conns     map[uuid.UUID]*domain.TheHiveConnection
+	caseLinks map[uuid.UUID]*domain.TheHiveCaseLink
+	taskLinks map[uuid.UUID]*domain.TheHiveTaskLink
+	obs       map[uuid.UUID]*domain.TheHiveObservable
+}
+
+func newMemRepo() *memRepo {
+	return &memRepo{
+		conns:     map[uuid.UUID]*domain.TheHiveConnection{},
+		caseLinks: map[uuid.UUID]*domain.TheHiveCaseLink{},
+		taskLinks: map[uuid.UUID]*domain.TheHiveTaskLink{},
+		obs:       map[uuid.UUID]*domain.TheHiveObservable{},
+	}
+}
+
+func (r *memRepo) GetConnection(_ context.Context, tenantID uuid.UUID) (*domain.TheHiveConnection, error) {
+	if c, ok := r.conns[tenantID]; ok {
+		cp := *c
+		return &cp, nil
+	}
+	return nil, nil
+}
+func (r *memRepo) GetConnectionByWebhookToken(_ context.Context, token string) (*domain.TheHiveConnection, error) {
+	for _, c := range r.conns {
+		if c.WebhookToken == token {
+			cp := *c
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+func (r *memRepo) ListEnabledConnections(context.Context) ([]domain.TheHiveConnection, error) {
+	var out []domain.TheHiveConnection
+	for _, c := range r.conns {
+		if c.Enabled {
+			out = append(out, *c)
+		}
+	}
+	return out, nil
+}
+func (r *memRepo) SaveConnection(_ context.Context, c *domain.TheHiveConnection) error {
+	cp := *c
+	r.conns[c.TenantID] = &cp
+	return nil
+}
+func (r *memRepo) DeleteConnection(_ context.Context, tenantID uuid.UUID) error {
+	delete(r.conns, tenantID)
+	return nil
+}
+func (r *memRepo) GetCaseLinkByIncident(_ context.Context, tenantID uuid.UUID, incidentID uint) (*domain.TheHiveCaseLink, error) {
+	for _, l := range r.caseLinks {
+		if l.TenantID == tenantID && l.IncidentID == incidentID {
+			cp := *l
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+func (r *memRepo) GetCaseLinkByCase(_ context.Context, tenantID uuid.UUID, caseID string) (*domain.TheHiveCaseLink, error) {
+	for _, l := range r.caseLinks {
+		if l.TenantID == tenantID && l.CaseID == caseID {
+			cp := *l
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+func (r *memRepo) SaveCaseLink(_ context.Context, l *domain.TheHiveCaseLink) error {
+	cp := *l
+	r.caseLinks[l.ID] = &cp
+	return nil
+}
+func (r *memRepo) ListTaskLinks(_ context.Context, tenantID, caseLinkID uuid.UUID) ([]domain.TheHiveTaskLink, error) {
+	var out []domain.TheHiveTaskLink
+	for _, l := range r.taskLinks {
+		if l.TenantID == tenantID && l.CaseLinkID == caseLinkID {
+			out = append(out, *l)
+		}
+	}
+	return out, nil
+}
+func (r *memRepo) SaveTaskLink(_ context.Context, l *domain.TheHiveTaskLink) error {
+	cp := *l
+	r.taskLinks[l.ID] = &cp
+	return nil
+}
+func (r *memRepo) ListObservables(_ context.Context, tenantID, caseLinkID uuid.UUID) ([]domain.TheHiveObservable, error) {
+	var out []domain.TheHiveObservable
+	for _, o := range r.obs {
+		if o.TenantID == tenantID && o.CaseLinkID == caseLinkID {
+			out = append(out, *o)
+		}
+	}
+	return out, nil
+}
+func (r *memRepo) SaveObservable(_ context.Context, o *domain.TheHiveObservable) error {
+	cp := *o
+	r.obs[o.ID] = &cp
+	return nil
+}
+
+// memIncidents mimics the incident service, including its post-mortem gate on
+// closing CRITICAL incidents and the updated_at bump on action changes.
+type memIncidents struct {
+	clock     func() time.Time
+	seq       uint
+	incidents map[uint]*domain.Incident
+	actions   map[uint]*domain.IncidentAction
+	timeline  []string
+	gateOpen  bool
+}
+
+func newMemIncidents(clock func() time.Time) *memIncidents {
+	return &memIncidents{clock: clock, incidents: map[uint]*domain.Incident{}, actions: map[uint]*domain.IncidentAction{}}
+}
+
+func (m *memIncidents) GetIncident(tenantID string, id uint) (*domain.Incident, error) {
+	inc, ok := m.incidents[id]
+	if !ok || inc.TenantID != tenantID {
+		return nil, domain.ErrNotFound
+	}
+	cp := *inc
+	return &cp, nil
+}
+func (m *memIncidents) CreateIncident(tenantID string, req domain.IncidentCreateRequest) (*domain.Incident, error) {
+	m.seq++
+	inc := &domain.Incident{
+		ID: m.seq, TenantID: tenantID, Title: req.Title, Description: req.Description,
+		Severity: req.Severity, Status: "open", Origin: req.Origin, OriginDetail: req.OriginDetail,
+		CreatedAt: m.clock(), UpdatedAt: m.clock(),
+	}
+	m.incidents[inc.ID] = inc
+	cp := *inc
+	return &cp, nil
+}
+func (m *memIncidents) UpdateIncident(tenantID string, id uint, req domain.IncidentUpdateRequest, _ string) (*domain.Incident, error) {
+	inc, ok := m.incidents[id]
+	if !ok || inc.TenantID != tenantID {
+		return nil, domain.ErrNotFound
+	}
+	sev := inc.Severity
+	if req.Severity != "" {
+		sev = req.Severity
+	}
+	if (req.Status == "resolved" || req.Status == "closed") && sev == "critical" && !m.gateOpen {
+		return nil, domain.NewValidationError("a CRITICAL incident cannot be closed without a published post-mortem")
+	}
+	if req.Title != "" {
+		inc.Title = req.Title
+	}
+	if req.Status != "" {
+		inc.Status = req.Status
+	}
+	inc.Severity = sev
+	inc.UpdatedAt = m.clock()
+	cp := *inc
+	return &cp, nil
+}
+func (m *memIncidents) ListIncidentsUpdatedSince(tenantID string, since time.Time, limit int) ([]domain.Incident, error) {
+	var out []domain.Incident
+	for _, inc := range m.incidents {
+		if inc.TenantID == tenantID && inc.UpdatedAt.After(since) {
+			out = append(out, *inc)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].UpdatedAt.Before(out[j].UpdatedAt) })
+	return out, nil
+}
+func (m *memIncidents) LinkAssets(tenantID string, id uint, assetIDs []string) error {
+	inc := m.incidents[id]
+	inc.AssetIDs = append(inc.AssetIDs, assetIDs...)
+	return nil
+}
+func (m *memIncidents) AddTimelineEntry(_ uint, _, message, _, _ string) error {
+	m.timeline = append(m.timeline, message)
+	return nil
+}
+func (m *memIncidents) GetIncidentActions(_ string, id uint) ([]domain.IncidentAction, error) {
+	var out []domain.IncidentAction
+	for _, a := range m.actions {
+		if a.IncidentID == id {
+			out = append(out, *a)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+func (m *memIncidents) CreateIncidentAction(_ string, incidentID uint, title, desc string, due time.Time, assignee string) (*domain.IncidentAction, error) {
+	m.seq++
+	a := &domain.IncidentAction{ID: m.seq, IncidentID: incidentID, Title: title, Description: desc, DueDate: due, Status: "pending", CreatedAt: m.clock()}
+	m.actions[a.ID] = a
+	m.incidents[incidentID].UpdatedAt = m.clock()
+	cp := *a
+	return &cp, nil
+}
+func (m *memIncidents) UpdateIncidentAction(_ string, actionID uint, status string) error {
+	a := m.actions[actionID]
+	a.Status = status
+	a.UpdatedAt = m.clock()
+	m.incidents[a.IncidentID].UpdatedAt = m.clock()
+	return nil
+}
+
+// fakeHive is an in-memory TheHive.
+type fakeHive struct {
+	clock       func() time.Time
+	seq         int
+	cases       map[string]*RemoteCase
+	tasks       map[string][]*RemoteTask
+	observables map[string][]RemoteObservable
+	caseUpdates int
+}
+
+func newFakeHive(clock func() time.Time) *fakeHive {
+	return &fakeHive{clock: clock, cases: map[string]*RemoteCase{}, tasks: map[string][]*RemoteTask{}, observables: map[string][]RemoteObservable{}}
+}
+
+func (f *fakeHive) CreateCase(_ context.Context, c RemoteCase) (*RemoteCase, error) {
+	f.seq++
+	c.ID, c.Number, c.UpdatedAt = fmt.Sprintf("~%d", f.seq), f.seq, f.clock()
+	f.cases[c.ID] = &c
+	cp := c
+	return &cp, nil
+}
+func (f *fakeHive) UpdateCase(_ context.Context, id string, patch map[string]any) error {
+	c := f.cases[id]
+	if v, ok := patch["title"].(string); ok {
+		c.Title = v
+	}
+	if v, ok := patch["severity"].(int); ok {
+		c.Severity = v
+	}
+	if v, ok := patch["status"].(string); ok {
+		c.Status, c.Stage = v, ""
+	}
+	c.UpdatedAt = f.clock()
+	f.caseUpdates++
+	return nil
+}
+func (f *fakeHive) GetCase(_ context.Context, id string) (*RemoteCase, error) {
+	c, ok := f.cases[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *c
+	return &cp, nil
+}
+func (f *fakeHive) ListCasesUpdatedSince(_ context.Context, since time.Time, _ int) ([]RemoteCase, error) {
+	var out []RemoteCase
+	for _, c := range f.cases {
+		if c.UpdatedAt.After(since) {
+			out = append(out, *c)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].UpdatedAt.Before(out[j].UpdatedAt) })
+	return out, nil
+}
+func (f *fakeHive) ListTasks(_ context.Context, caseID string) ([]RemoteTask, error) {
+	var out []RemoteTask
+	for _, t := range f.tasks[caseID] {
+		out = append(out, *t)
+	}
+	return out, nil
+}
+func (f *fakeHive) CreateTask(_ context.Context, caseID string, t RemoteTask) (*RemoteTask, error) {
+	f.seq++
+	t.ID, t.UpdatedAt = fmt.Sprintf("~t%d", f.seq), f.clock()
+	f.tasks[caseID] = append(f.tasks[caseID], &t)
+	cp := t
+	return &cp, nil
+}
+func (f *fakeHive) UpdateTask(_ context.Context, id string, patch map[string]any) error {
+	for _, ts := range f.tasks {
+		for _, t := range ts {
+			if t.ID == id {
+				t.Status = patch["status"].(string)
+				t.UpdatedAt = f.clock()
+			}
+		}
+	}
+	return nil
+}
+func (f *fakeHive) ListObservables(_ context.Context, caseID string) ([]RemoteObservable, error) {
+	return f.observables[caseID], nil
+}
+
+// plainCipher "encrypts" by prefixing, which is enough to prove the key is
+// never stored as given.
+type plainCipher struct{}
+
+func (plainCipher) EncryptString(s string) (string, error) { return "enc:" + s, nil }
+func (plainCipher) DecryptString(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	if len(s) < 4 || s[:4] != "enc:" {
+		return "", errors.New("bad ciphertext")
+	}
+	return s[4:], nil
+}
+
+type memAssets []domain.Asset
+
+func (a memAssets) List(context.Context, uuid.UUID) ([]domain.Asset, error) { return a, nil }
+
+type harness struct {
+	svc       *SyncService
+	repo      *memRepo
+	incidents *memIncidents
+	hive      *fakeHive
+	tenant    uuid.UUID
+	now       time.Time
+}
+
+func (h *harness) advance(d time.Duration) { h.now = h.now.Add(d) }
+
+func newHarness(t *testing.T, in ConnectionInput) *harness {
+	t.Helper()
+	h := &harness{tenant: uuid.New(), now: time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)}
+	clock := func() time.Time { return h.now }
+	h.repo = newMemRepo()
+	h.incidents = newMemIncidents(clock)
+	h.hive = newFakeHive(clock)
+	var dialedKey string
+	h.svc = NewSyncService(h.repo, h.incidents, func(_, _, key string) CaseAPI {
+		dialedKey = key
+		return h.hive
+	}, plainCipher{}).WithClock(clock)
+
+	if in.BaseURL == "" {
+		in.BaseURL = "https://hive.example"
+	}
+	if in.APIKey == "" {
+		in.APIKey = "secret"
+	}
+	in.Enabled = true
+	_, err := h.svc.SaveConnection(context.Background(), h.tenant, nil, in)
+	require.NoError(t, err)
+	h.advance(time.Minute)
+	t.Cleanup(func() {
+		if dialedKey != "" {
+			assert.Equal(t, "secret", dialedKey, "the client must be dialed with the decrypted key")
+		}
+	})
+	return h
+}
+
+func (h *harness) sync(t *testing.T) *SyncReport {
+	t.Helper()
+	rep, err := h.svc.SyncTenant(context.Background(), h.tenant)
+	require.NoError(t, err)
+	require.Empty(t, rep.Errors)
+	return rep
+}
+
+// ---------------------------------------------------------------------------
+// Tests
+// ---------------------------------------------------------------------------
+
+func TestSaveConnection_ValidatesAndKeepsTheKey(t *testing.T) {
+	h := newHarness(t, ConnectionInput{PushIncidents: true, PollMinutes: 5})
+	ctx := context.Background()
+
+	_, err := h.svc.SaveConnection(ctx, h.tenant, nil, ConnectionInput{BaseURL: "hive.example", APIKey: "x"})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+	_, err = h.svc.SaveConnection(ctx, h.tenant, nil, ConnectionInput{BaseURL: "https://hive.example", PollMinutes: -1})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+	_, err = h.svc.SaveConnection(ctx, h.tenant, nil, ConnectionInput{BaseURL: "http://169.254.169.254", APIKey: "x"})
+	assert.ErrorIs(t, err, domain.ErrValidation, "internal addresses are refused")
+
+	before, _ := h.svc.GetConnection(ctx, h.tenant)
+	c, err := h.svc.SaveConnection(ctx, h.tenant, nil, ConnectionInput{BaseURL: "https://hive.example/", Enabled: true, Organisation: "soc"})
+	require.NoError(t, err)
+	assert.Equal(t, "enc:secret", c.EncryptedAPIKey, "a blank api_key keeps the stored one")
+	assert.Equal(t, "https://hive.example", c.BaseURL)
+	assert.Equal(t, before.WebhookToken, c.WebhookToken, "the webhook token survives a re-save")
+	assert.True(t, c.HasAPIKey)
+}
+
+func TestSync_PushCreatesCaseAndTasksWithoutEcho(t *testing.T) {
+	h := newHarness(t, ConnectionInput{PushIncidents: true})
+	inc, _ := h.incidents.CreateIncident(h.tenant.String(), domain.IncidentCreateRequest{
+		Title: "Phishing wave", Description: "finance mailbox", Severity: "high",
+	})
+	_, _ = h.incidents.CreateIncidentAction(h.tenant.String(), inc.ID, "Reset passwords", "", time.Time{}, "")
+	h.advance(time.Minute)
+
+	rep := h.sync(t)
+	assert.Equal(t, 1, rep.Created)
+	require.Len(t, h.hive.cases, 1)
+	rc := h.hive.cases["~1"]
+	assert.Equal(t, 3, rc.Severity)
+	assert.Equal(t, "New", rc.Status)
+	assert.Contains(t, rc.Tags, fmt.Sprintf("openrisk:incident:%d", inc.ID))
+	require.Len(t, h.hive.tasks["~1"], 1)
+	assert.Equal(t, "Waiting", h.hive.tasks["~1"][0].Status)
+
+	// The case we just created comes back on the next pull; nothing may bounce.
+	h.advance(time.Minute)
+	rep = h.sync(t)
+	assert.Zero(t, rep.Pushed+rep.Pulled+rep.Created+rep.Imported)
+	assert.Zero(t, h.hive.caseUpdates)
+	assert.Len(t, h.incidents.actions, 1, "our own task must not come back as a second action")
+}
+
+func TestSync_BothDirectionsAndLastWriterWins(t *testing.T) {
+	h := newHarness(t, ConnectionInput{PushIncidents: true})
+	tenant := h.tenant.String()
+	inc, _ := h.incidents.CreateIncident(tenant, domain.IncidentCreateRequest{Title: "Beaconing", Severity: "medium"})
+	h.advance(time.Minute)
+	h.sync(t)
+
+	// TheHive moves: analyst starts work.
+	h.advance(time.Minute)
+	_ = h.hive.UpdateCase(context.Background(), "~1", map[string]any{"status": "InProgress"})
+	h.advance(time.Minute)
+	rep := h.sync(t)
+	assert.Equal(t, 1, rep.Pulled)
+	got, _ := h.incidents.GetIncident(tenant, inc.ID)
+	assert.Equal(t, "in_progress", got.Status)
+
+	// OpenRisk moves: the risk owner raises severity.
+	h.advance(time.Minute)
+	_, _ = h.incidents.UpdateIncident(tenant, inc.ID, domain.IncidentUpdateRequest{Severity: "high"}, "u")
+	h.advance(time.Minute)
+	rep = h.sync(t)
+	assert.Equal(t, 1, rep.Pushed)
+	assert.Equal(t, 3, h.hive.cases["~1"].Severity)
+	assert.Equal(t, "InProgress", h.hive.cases["~1"].Status, "only changed fields are pushed")
+
+	// Both move; TheHive's change is later and wins.
+	h.advance(time.Minute)
+	_, _ = h.incidents.UpdateIncident(tenant, inc.ID, domain.IncidentUpdateRequest{Severity: "low"}, "u")
+	h.advance(time.Minute)
+	_ = h.hive.UpdateCase(context.Background(), "~1", map[string]any{"severity": 4})
+	h.advance(time.Minute)
+	h.sync(t)
+	got, _ = h.incidents.GetIncident(tenant, inc.ID)
+	assert.Equal(t, "critical", got.Severity)
+	assert.Equal(t, 4, h.hive.cases["~1"].Severity)
+}
+
+func TestSync_RefusedCloseIsRecordedNotRetried(t *testing.T) {
+	h := newHarness(t, ConnectionInput{PushIncidents: true})
+	tenant := h.tenant.String()
+	inc, _ := h.incidents.CreateIncident(tenant, domain.IncidentCreateRequest{Title: "Ransomware", Severity: "critical"})
+	h.advance(time.Minute)
+	h.sync(t)
+
+	h.advance(time.Minute)
+	_ = h.hive.UpdateCase(context.Background(), "~1", map[string]any{"status": "TruePositive"})
+	h.advance(time.Minute)
+	h.sync(t)
+
+	got, _ := h.incidents.GetIncident(tenant, inc.ID)
+	assert.Equal(t, "open", got.Status, "the post-mortem gate still applies to TheHive")
+	link, _ := h.repo.GetCaseLinkByIncident(context.Background(), h.tenant, inc.ID)
+	assert.Contains(t, link.LastError, "post-mortem")
+
+	// Nothing moved since: no retry, and crucially no push reopening the case.
+	updates := h.hive.caseUpdates
+	h.advance(time.Minute)
+	h.sync(t)
+	assert.Equal(t, updates, h.hive.caseUpdates)
+	assert.Equal(t, "TruePositive", h.hive.cases["~1"].Status)
+}
+
+func TestSync_TaskStatusTravelsBothWays(t *testing.T) {
+	h := newHarness(t, ConnectionInput{PushIncidents: true})
+	tenant := h.tenant.String()
+	inc, _ := h.incidents.CreateIncident(tenant, domain.IncidentCreateRequest{Title: "Lateral movement", Severity: "high"})
+	action, _ := h.incidents.CreateIncidentAction(tenant, inc.ID, "Isolate host", "", time.Time{}, "")
+	h.advance(time.Minute)
+	h.sync(t)
+
+	// Remote: analyst completes the task.
+	taskID := h.hive.tasks["~1"][0].ID
+	h.advance(time.Minute)
+	_ = h.hive.UpdateTask(context.Background(), taskID, map[string]any{"status": "Completed"})
+	h.advance(time.Minute)
+	h.sync(t)
+	assert.Equal(t, "completed", h.incidents.actions[action.ID].Status)
+
+	// Remote: a new task appears → a new action.
+	h.advance(time.Minute)
+	_, _ = h.hive.CreateTask(context.Background(), "~1", RemoteTask{Title: "Collect memory", Status: "InProgress"})
+	h.hive.cases["~1"].UpdatedAt = h.now
+	h.advance(time.Minute)
+	h.sync(t)
+	actions, _ := h.incidents.GetIncidentActions(tenant, inc.ID)
+	require.Len(t, actions, 2)
+	assert.Equal(t, "Collect memory", actions[1].Title)
+	assert.Equal(t, "in_progress", actions[1].Status)
+
+	// Local: the second action is completed here → pushed.
+	h.advance(time.Minute)
+	_ = h.incidents.UpdateIncidentAction(tenant, actions[1].ID, "completed")
+	h.advance(time.Minute)
+	h.sync(t)
+	assert.Equal(t, "Completed", h.hive.tasks["~1"][1].Status)
+}
+
+func TestSync_ImportMapsObservablesOntoAssets(t *testing.T) {
+	h := newHarness(t, ConnectionInput{ImportCases: true})
+	web := domain.Asset{ID: uuid.New(), Name: "web-prod-01", Hostnames: pq.StringArray{"web-prod-01.corp.example"}, IPAddresses: pq.StringArray{"10.0.0.5"}}
+	db := domain.Asset{ID: uuid.New(), Name: "db-prod-01", IPAddresses: pq.StringArray{"10.0.0.9"}}
+	h.svc.WithAssets(memAssets{web, db})
+
+	h.hive.cases["~77"] = &RemoteCase{
+		ID: "~77", Number: 77, Title: "C2 callback", Description: "EDR alert",
+		Severity: 3, Status: "InProgress", UpdatedAt: h.now.Add(time.Second),
+	}
+	h.hive.observables["~77"] = []RemoteObservable{
+		{ID: "~o1", DataType: "ip", Data: "10.0.0.5"},
+		{ID: "~o2", DataType: "hash", Data: "44d88612fea8a8f36de82e1278abb02f"},
+		{ID: "~o3", DataType: "ip", Data: "203.0.113.7"},
+	}
+	h.advance(time.Minute)
+	rep := h.sync(t)
+	assert.Equal(t, 1, rep.Imported)
+
+	link, _ := h.repo.GetCaseLinkByCase(context.Background(), h.tenant, "~77")
+	require.NotNil(t, link)
+	inc, _ := h.incidents.GetIncident(h.tenant.String(), link.IncidentID)
+	assert.Equal(t, "high", inc.Severity)
+	assert.Equal(t, "in_progress", inc.Status)
+	assert.Equal(t, domain.OriginIntegration, inc.Origin)
+	assert.Equal(t, []string{web.ID.String()}, []string(inc.AssetIDs))
+
+	obs, _ := h.repo.ListObservables(context.Background(), h.tenant, link.ID)
+	require.Len(t, obs, 3, "every observable is recorded, attributed or not")
+	for _, o := range obs {
+		if o.ObservableID == "~o1" {
+			require.NotNil(t, o.AssetID)
+			assert.Equal(t, web.ID, *o.AssetID)
+		} else {
+			assert.Nil(t, o.AssetID, "%s must not be guessed onto an asset", o.Data)
+		}
+	}
+
+	// A re-sync does not attribute the same asset twice.
+	h.hive.cases["~77"].UpdatedAt = h.now
+	h.advance(time.Minute)
+	h.sync(t)
+	inc, _ = h.incidents.GetIncident(h.tenant.String(), link.IncidentID)
+	assert.Len(t, inc.AssetIDs, 1)
+}
+
+func TestHandleWebhook_ResolvesTokenAndPullsTheCase(t *testing.T) {
+	h := newHarness(t, ConnectionInput{ImportCases: true})
+	ctx := context.Background()
+	conn, _ := h.svc.GetConnection(ctx, h.tenant)
+
+	_, err := h.svc.HandleWebhook(ctx, "forged", WebhookEvent{ObjectType: "Case", ObjectID: "~1"})
+	assert.ErrorIs(t, err, ErrUnknownWebhook)
+
+	h.hive.cases["~9"] = &RemoteCase{ID: "~9", Number: 9, Title: "Web shell", Severity: 2, Status: "New", UpdatedAt: h.now}
+	rep, err := h.svc.HandleWebhook(ctx, conn.WebhookToken, WebhookEvent{
+		Operation: "create", ObjectType: "Case_Task", ObjectID: "~t1", RootID: "~9",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, rep.Imported, "a task event routes to its case via rootId")
+
+	// Case deletion in TheHive never deletes the incident.
+	rep, err = h.svc.HandleWebhook(ctx, conn.WebhookToken, WebhookEvent{Operation: "delete", ObjectType: "Case", ObjectID: "~9"})
+	require.NoError(t, err)
+	assert.Zero(t, rep.Imported+rep.Pulled)
+	assert.Len(t, h.incidents.incidents, 1)
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package webhooks
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/opendefender/openrisk/pkg/netguard"
+)
+
+// HTTPDoer sends a delivery. *http.Client satisfies it.
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// NewHTTPClient builds the client deliveries go out through. Unless
+// allowPrivate, it refuses internal addresses (netguard) because a
+// subscription URL is chosen by a tenant, and the server must not become their
+// way into the network it runs on. Redirects are not followed: a 3xx is a
+// failed attempt.
+func NewHTTPClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, Control: netguard.Control(allowPrivate, "webhooks")}
+	return &http.Client{
+		Timeout: 15 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			MaxIdleConns:        20,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+}
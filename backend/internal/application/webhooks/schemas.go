@@ -0,0 +1,63 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package webhooks
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// The JSON Schema of every event's envelope, one file per type and version.
+// TestSchemas_MatchThePayloadStructs keeps them in step with the domain
+// payload structs.
+//
+//go:embed schemas/v1/*.json
+var schemaFS embed.FS
+
+// SchemaName is the envelope's "schema" value for t at the current version.
+func SchemaName(t domain.WebhookEventType) string {
+	return fmt.Sprintf("openrisk.webhook.%s.v%d", t, domain.WebhookSchemaVersion)
+}
+
+// EventInfo describes an event type for GET /webhook-events.
+type EventInfo struct {
+	Type          domain.WebhookEventType `json:"type"`
+	Description   string                  `json:"description"`
+	SchemaVersion int                     `json:"schema_version"`
+	Schema        string                  `json:"schema"`
+}
+
+// Events lists every event type a subscription can choose.
+func Events() []EventInfo {
+	out := make([]EventInfo, 0, len(domain.WebhookEventTypes))
+	for _, t := range domain.WebhookEventTypes {
+		info := EventInfo{Type: t, SchemaVersion: domain.WebhookSchemaVersion, Schema: SchemaName(t)}
+		if raw, err := Schema(t); err == nil {
+			var doc struct {
+				Description string `json:"description"`
+			}
+			_ = json.Unmarshal(raw, &doc)
+			info.Description = doc.Description
+		}
+		out = append(out, info)
+	}
+	return out
+}
+
+// Schema returns the JSON Schema of t's envelope at the current version.
+func Schema(t domain.WebhookEventType) (json.RawMessage, error) {
+	if !t.IsValid() {
+		return nil, domain.NewNotFoundError("webhook event type", string(t))
+	}
+	raw, err := schemaFS.ReadFile(fmt.Sprintf("schemas/v%d/%s.json", domain.WebhookSchemaVersion, t))
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	return raw, nil
+}
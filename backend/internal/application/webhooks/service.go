@@ -0,0 +1,636 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package webhooks delivers domain events to integrators' endpoints. A
+// subscription chooses event types; Publish queues one delivery per matching
+// subscription; the delivery worker signs and sends each one, retrying with
+// exponential backoff, and turns off a subscription whose endpoint has been
+// failing for long enough. See docs/WEBHOOKS.md for the receiver's side.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/netguard"
+)
+
+// SecretCipher encrypts signing secrets at rest. The scanner's AES-256-GCM
+// CredentialCipher (SCANNER_CREDENTIAL_KEY) satisfies it.
+type SecretCipher interface {
+	EncryptCredentials(creds map[string]string) (string, error)
+	DecryptCredentials(ciphertext string) (map[string]string, error)
+}
+
+// AuditSink records subscription changes in the audit chain.
+type AuditSink interface {
+	Record(ctx context.Context, e domain.AuditEvent)
+}
+
+// deliveryLease is how long a claimed delivery belongs to its worker. Well
+// above the client's timeout, so only a worker that died loses its claim.
+const deliveryLease = 2 * time.Minute
+
+// Service is the webhook use cases.
+type Service struct {
+	repo         domain.WebhookRepository
+	cipher       SecretCipher
+	audit        AuditSink
+	client       HTTPDoer
+	allowPrivate bool
+	now          func() time.Time
+}
+
+// NewService builds the service. Publish works with only the repository;
+// managing subscriptions needs WithCipher.
+func NewService(repo domain.WebhookRepository) *Service {
+	return &Service{repo: repo, client: NewHTTPClient(false), now: time.Now}
+}
+
+// WithCipher wires secret storage. Without it subscriptions cannot be created
+// and deliveries cannot be signed.
+func (s *Service) WithCipher(c SecretCipher) *Service {
+	s.cipher = c
+	return s
+}
+
+// WithAudit enables audit recording.
+func (s *Service) WithAudit(a AuditSink) *Service {
+	s.audit = a
+	return s
+}
+
+// AllowPrivateNetworks lets subscriptions target private and loopback
+// addresses, and plain http, for self-hosted deployments whose receivers live
+// on the internal network.
+func (s *Service) AllowPrivateNetworks(allow bool) *Service {
+	s.allowPrivate = allow
+	s.client = NewHTTPClient(allow)
+	return s
+}
+
+// WithHTTPClient overrides how deliveries are sent (tests).
+func (s *Service) WithHTTPClient(c HTTPDoer) *Service {
+	s.client = c
+	return s
+}
+
+// WithClock overrides the clock (tests).
+func (s *Service) WithClock(now func() time.Time) *Service {
+	s.now = now
+	return s
+}
+
+// =============================================================================
+// Publishing
+// =============================================================================
+
+// Publish queues an event for every enabled subscription of the tenant that
+// wants it. It only writes rows, so producers call it inline; the sending
+// happens in the delivery worker.
+func (s *Service) Publish(ctx context.Context, tenantID uuid.UUID, eventType domain.WebhookEventType, data any) error {
+	return s.PublishEvent(ctx, uuid.New(), tenantID, eventType, data)
+}
+
+// PublishEvent is Publish with a caller-chosen event id. Publishing the same
+// id twice queues it once per subscription, which is how several replicas
+// relaying the same message stay one delivery.
+func (s *Service) PublishEvent(ctx context.Context, eventID, tenantID uuid.UUID, eventType domain.WebhookEventType, data any) error {
+	if !eventType.IsValid() {
+		return fmt.Errorf("unknown webhook event type %q", eventType)
+	}
+	subs, err := s.repo.EnabledSubscriptions(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	now := s.now().UTC()
+	var body []byte
+	var ds []domain.WebhookDelivery
+	for i := range subs {
+		if !subs[i].Wants(eventType) {
+			continue
+		}
+		if body == nil {
+			body, err = json.Marshal(domain.WebhookEnvelope{
+				ID:            eventID,
+				Type:          eventType,
+				SchemaVersion: domain.WebhookSchemaVersion,
+				Schema:        SchemaName(eventType),
+				TenantID:      tenantID,
+				OccurredAt:    now,
+				Data:          data,
+			})
+			if err != nil {
+				return fmt.Errorf("marshal %s event: %w", eventType, err)
+			}
+		}
+		ds = append(ds, domain.WebhookDelivery{
+			ID:             uuid.New(),
+			TenantID:       tenantID,
+			SubscriptionID: subs[i].ID,
+			EventID:        eventID,
+			EventType:      eventType,
+			Payload:        datatypes.JSON(body),
+			Status:         domain.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+	}
+	return s.repo.EnqueueDeliveries(ctx, ds)
+}
+
+// =============================================================================
+// Subscriptions
+// =============================================================================
+
+// SubscriptionInput creates or updates a subscription. On update, nil fields
+// are left alone.
+type SubscriptionInput struct {
+	Name       *string  `json:"name"`
+	URL        *string  `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Enabled    *bool    `json:"enabled"`
+}
+
+// SubscriptionWithSecret is a subscription with its signing secret, returned
+// once: on create and on rotation.
+type SubscriptionWithSecret struct {
+	domain.WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+// Subscriptions lists the tenant's subscriptions.
+func (s *Service) Subscriptions(ctx context.Context, tenantID uuid.UUID) ([]domain.WebhookSubscription, error) {
+	rows, err := s.repo.ListSubscriptions(ctx, tenantID)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	return rows, nil
+}
+
+// Subscription returns one subscription.
+func (s *Service) Subscription(ctx context.Context, tenantID, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	sub, err := s.repo.GetSubscription(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if sub == nil {
+		return nil, domain.NewNotFoundError("webhook", id)
+	}
+	return sub, nil
+}
+
+// CreateSubscription adds an endpoint with a fresh signing secret.
+func (s *Service) CreateSubscription(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, in SubscriptionInput) (*SubscriptionWithSecret, error) {
+	if in.Name == nil || in.URL == nil || len(in.EventTypes) == 0 {
+		return nil, domain.NewValidationError("name, url and event_types are required")
+	}
+	now := s.now()
+	sub := &domain.WebhookSubscription{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Enabled:   true,
+		CreatedBy: actor,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.apply(sub, in); err != nil {
+		return nil, err
+	}
+	secret := newSecret()
+	if err := s.storeSecrets(sub, secret, ""); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveSubscription(ctx, sub); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionCreate, sub.ID,
+		fmt.Sprintf("Created webhook %q to %s", sub.Name, sub.URL), subscriptionAudit(sub))
+	return &SubscriptionWithSecret{WebhookSubscription: *sub, Secret: secret}, nil
+}
+
+// UpdateSubscription changes a subscription. Re-enabling one clears its
+// failure streak, so an endpoint that was fixed gets a fresh allowance.
+func (s *Service) UpdateSubscription(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID, in SubscriptionInput) (*domain.WebhookSubscription, error) {
+	sub, err := s.Subscription(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	wasEnabled := sub.Enabled
+	if err := s.apply(sub, in); err != nil {
+		return nil, err
+	}
+	if sub.Enabled && !wasEnabled {
+		sub.ConsecutiveFailures = 0
+		sub.FailingSince = nil
+		sub.DisabledReason = ""
+	}
+	sub.UpdatedAt = s.now()
+	if err := s.repo.SaveSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, sub.ID,
+		fmt.Sprintf("Updated webhook %q", sub.Name), subscriptionAudit(sub))
+	return sub, nil
+}
+
+// DeleteSubscription removes a subscription and its delivery log.
+func (s *Service) DeleteSubscription(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) error {
+	sub, err := s.Subscription(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteSubscription(ctx, tenantID, id); err != nil {
+		return err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionDelete, id,
+		fmt.Sprintf("Deleted webhook %q", sub.Name), nil)
+	return nil
+}
+
+// RotateSecret issues a new signing secret. The one it replaces keeps signing
+// alongside it for domain.WebhookPreviousSecretTTL, so the receiver can be
+// switched over without refusing a delivery in between.
+func (s *Service) RotateSecret(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) (*SubscriptionWithSecret, error) {
+	sub, err := s.Subscription(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	current, _, err := s.secrets(sub)
+	if err != nil {
+		return nil, err
+	}
+	secret := newSecret()
+	if err := s.storeSecrets(sub, secret, current); err != nil {
+		return nil, err
+	}
+	sub.UpdatedAt = s.now()
+	if err := s.repo.SaveSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionUpdate, sub.ID,
+		fmt.Sprintf("Rotated the signing secret of webhook %q", sub.Name),
+		domain.JSONMap{"previous_secret_expires_at": sub.PreviousSecretExpiresAt})
+	return &SubscriptionWithSecret{WebhookSubscription: *sub, Secret: secret}, nil
+}
+
+// apply validates the input and writes it onto sub.
+func (s *Service) apply(sub *domain.WebhookSubscription, in SubscriptionInput) error {
+	if in.Name != nil {
+		name := strings.TrimSpace(*in.Name)
+		if name == "" || len(name) > 120 {
+			return domain.NewValidationError("name is required (at most 120 characters)")
+		}
+		sub.Name = name
+	}
+	if in.URL != nil {
+		u, err := s.checkURL(*in.URL)
+		if err != nil {
+			return err
+		}
+		sub.URL = u
+	}
+	if in.EventTypes != nil {
+		seen := map[domain.WebhookEventType]bool{}
+		types := domain.StringList{}
+		for _, raw := range in.EventTypes {
+			t := domain.WebhookEventType(strings.ToLower(strings.TrimSpace(raw)))
+			if !t.IsValid() {
+				return domain.NewValidationError(fmt.Sprintf("unknown event type %q", raw))
+			}
+			if !seen[t] {
+				seen[t] = true
+				types = append(types, string(t))
+			}
+		}
+		if len(types) == 0 {
+			return domain.NewValidationError("choose at least one event type")
+		}
+		sort.Strings(types)
+		sub.EventTypes = types
+	}
+	if in.Enabled != nil {
+		sub.Enabled = *in.Enabled
+		if sub.Enabled {
+			sub.DisabledReason = ""
+		}
+	}
+	return nil
+}
+
+// checkURL accepts https endpoints, and http ones where private networks are
+// allowed. An address literal that could never be reached is refused here,
+// where the person typing it sees why; hostnames are checked on every dial.
+func (s *Service) checkURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || len(raw) > 2000 {
+		return "", domain.NewValidationError("url must be an absolute URL")
+	}
+	switch {
+	case u.Scheme == "https":
+	case u.Scheme == "http" && s.allowPrivate:
+	default:
+		return "", domain.NewValidationError("url must use https")
+	}
+	if u.User != nil {
+		return "", domain.NewValidationError("url must not carry credentials; deliveries are authenticated by their signature")
+	}
+	if !s.allowPrivate {
+		if netguard.InternalHost(u.Hostname()) {
+			return "", domain.NewValidationError("url must be reachable from the internet")
+		}
+	}
+	return u.String(), nil
+}
+
+func (s *Service) storeSecrets(sub *domain.WebhookSubscription, current, previous string) error {
+	if s.cipher == nil {
+		return domain.NewInternalError("secret storage is not configured")
+	}
+	secrets := map[string]string{"current": current}
+	sub.PreviousSecretExpiresAt = nil
+	if previous != "" {
+		secrets["previous"] = previous
+		until := s.now().Add(domain.WebhookPreviousSecretTTL)
+		sub.PreviousSecretExpiresAt = &until
+	}
+	enc, err := s.cipher.EncryptCredentials(secrets)
+	if err != nil {
+		return domain.NewInternalError("failed to encrypt the signing secret")
+	}
+	sub.EncryptedSecrets = enc
+	return nil
+}
+
+// secrets returns the current signing secret and, while it is still valid,
+// the previous one.
+func (s *Service) secrets(sub *domain.WebhookSubscription) (current, previous string, err error) {
+	if s.cipher == nil {
+		return "", "", domain.NewInternalError("secret storage is not configured")
+	}
+	m, err := s.cipher.DecryptCredentials(sub.EncryptedSecrets)
+	if err != nil {
+		return "", "", domain.NewInternalError("failed to decrypt the signing secret")
+	}
+	if sub.PreviousSecretExpiresAt != nil && s.now().Before(*sub.PreviousSecretExpiresAt) {
+		previous = m["previous"]
+	}
+	return m["current"], previous, nil
+}
+
+// =============================================================================
+// Delivery log
+// =============================================================================
+
+// Deliveries lists a subscription's most recent deliveries, without payloads.
+func (s *Service) Deliveries(ctx context.Context, tenantID, subscriptionID uuid.UUID) ([]domain.WebhookDelivery, error) {
+	if _, err := s.Subscription(ctx, tenantID, subscriptionID); err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.ListDeliveries(ctx, tenantID, subscriptionID, 100)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	return rows, nil
+}
+
+// Delivery returns one delivery with the payload it sends.
+func (s *Service) Delivery(ctx context.Context, tenantID, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	d, err := s.repo.GetDelivery(ctx, tenantID, id)
+	if err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	if d == nil {
+		return nil, domain.NewNotFoundError("webhook delivery", id)
+	}
+	return d, nil
+}
+
+// Redeliver queues the same event again, as a new delivery with its own
+// attempts. The envelope is unchanged — same event id — so a receiver that
+// did process the first one can tell.
+func (s *Service) Redeliver(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	orig, err := s.Delivery(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := s.Subscription(ctx, tenantID, orig.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if !sub.Enabled {
+		return nil, &domain.AppError{Err: domain.ErrConflict, Code: http.StatusConflict,
+			Message: fmt.Sprintf("webhook %q is disabled; enable it before redelivering", sub.Name)}
+	}
+	now := s.now().UTC()
+	d := domain.WebhookDelivery{
+		ID:             uuid.New(),
+		TenantID:       tenantID,
+		SubscriptionID: orig.SubscriptionID,
+		EventID:        orig.EventID,
+		EventType:      orig.EventType,
+		Payload:        orig.Payload,
+		Status:         domain.WebhookDeliveryPending,
+		NextAttemptAt:  now,
+		RedeliveryOf:   &orig.ID,
+		RequestedBy:    actor,
+		CreatedAt:      now,
+	}
+	if err := s.repo.EnqueueDeliveries(ctx, []domain.WebhookDelivery{d}); err != nil {
+		return nil, domain.NewInternalError(err.Error())
+	}
+	s.record(ctx, tenantID, actor, domain.AuditActionCreate, sub.ID,
+		fmt.Sprintf("Redelivered %s event %s to webhook %q", orig.EventType, orig.EventID, sub.Name),
+		domain.JSONMap{"delivery_id": d.ID, "redelivery_of": orig.ID})
+	return &d, nil
+}
+
+// =============================================================================
+// Delivery
+// =============================================================================
+
+// DeliverNext claims one due delivery and makes an attempt at it. It reports
+// whether there was one. The outcome of the attempt is recorded on the
+// delivery, not returned: only a failure to claim is an error.
+func (s *Service) DeliverNext(ctx context.Context) (bool, error) {
+	d, err := s.repo.ClaimDueDelivery(ctx, s.now(), deliveryLease)
+	if err != nil {
+		return false, err
+	}
+	if d == nil {
+		return false, nil
+	}
+	return true, s.attempt(ctx, d)
+}
+
+func (s *Service) attempt(ctx context.Context, d *domain.WebhookDelivery) error {
+	sub, err := s.repo.GetSubscriptionForDelivery(ctx, d)
+	if err != nil {
+		return err
+	}
+	switch {
+	case sub == nil:
+		return s.finish(ctx, d, false, "the webhook was deleted")
+	case !sub.Enabled:
+		return s.finish(ctx, d, false, "the webhook is disabled")
+	}
+	current, previous, err := s.secrets(sub)
+	if err != nil {
+		// Not the endpoint's fault: retried, but not counted against it.
+		return s.complete(ctx, d, err.Error())
+	}
+	signing := []string{current}
+	if previous != "" {
+		signing = append(signing, previous)
+	}
+
+	started := s.now()
+	code, sendErr := s.send(ctx, sub.URL, d, signing, started)
+	d.DurationMS = s.now().Sub(started).Milliseconds()
+	d.LastStatusCode = code
+	if err := s.complete(ctx, d, sendErr); err != nil {
+		return err
+	}
+
+	after, err := s.repo.RecordAttempt(ctx, sub.ID, sendErr == "", s.now())
+	if err != nil || after == nil {
+		return err
+	}
+	if sendErr != "" && after.Enabled && after.ConsecutiveFailures >= domain.WebhookDisableAfterFailures &&
+		after.FailingSince != nil && s.now().Sub(*after.FailingSince) >= domain.WebhookDisableAfterPeriod {
+		after.Enabled = false
+		after.DisabledReason = fmt.Sprintf("disabled after %d consecutive failed deliveries since %s; last error: %s",
+			after.ConsecutiveFailures, after.FailingSince.UTC().Format(time.RFC3339), sendErr)
+		after.UpdatedAt = s.now()
+		if err := s.repo.SaveSubscription(ctx, after); err != nil {
+			return err
+		}
+		s.record(ctx, after.TenantID, nil, domain.AuditActionUpdate, after.ID,
+			fmt.Sprintf("Disabled webhook %q after repeated delivery failures", after.Name),
+			domain.JSONMap{"consecutive_failures": after.ConsecutiveFailures, "last_error": sendErr})
+	}
+	return nil
+}
+
+// send makes one request and returns the status code and, on failure, why.
+func (s *Service) send(ctx context.Context, target string, d *domain.WebhookDelivery, secrets []string, at time.Time) (int, string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, "invalid webhook URL"
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "OpenRisk-Webhooks/1.0")
+	req.Header.Set("X-OpenRisk-Event", string(d.EventType))
+	req.Header.Set("X-OpenRisk-Event-Id", d.EventID.String())
+	req.Header.Set("X-OpenRisk-Delivery", d.ID.String())
+	req.Header.Set("X-OpenRisk-Attempt", strconv.Itoa(d.Attempts))
+	req.Header.Set(SignatureHeader, Sign(secrets, at, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, truncate(err.Error(), 500)
+	}
+	defer resp.Body.Close()
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg := fmt.Sprintf("HTTP %d", resp.StatusCode)
+		if body := strings.TrimSpace(string(snippet)); body != "" {
+			msg += ": " + truncate(body, 300)
+		}
+		return resp.StatusCode, msg
+	}
+	return resp.StatusCode, ""
+}
+
+// complete records an attempt: success, another try after the backoff, or
+// the end of the road once attempts are exhausted.
+func (s *Service) complete(ctx context.Context, d *domain.WebhookDelivery, failure string) error {
+	if failure == "" {
+		return s.finish(ctx, d, true, "")
+	}
+	if d.Attempts >= domain.WebhookMaxAttempts {
+		return s.finish(ctx, d, false, failure)
+	}
+	d.Status = domain.WebhookDeliveryPending
+	d.LastError = failure
+	d.NextAttemptAt = s.now().Add(Backoff(d.Attempts))
+	_, err := s.repo.CompleteAttempt(ctx, d)
+	return err
+}
+
+func (s *Service) finish(ctx context.Context, d *domain.WebhookDelivery, succeeded bool, failure string) error {
+	now := s.now()
+	d.NextAttemptAt = now
+	d.LastError = failure
+	if succeeded {
+		d.Status = domain.WebhookDeliverySucceeded
+		d.DeliveredAt = &now
+	} else {
+		d.Status = domain.WebhookDeliveryFailed
+	}
+	_, err := s.repo.CompleteAttempt(ctx, d)
+	return err
+}
+
+// Backoff is the wait after the given number of failed attempts: 30s after
+// the first, doubling each time, never more than domain.WebhookMaxRetry.
+func Backoff(attempts int) time.Duration {
+	d := domain.WebhookFirstRetry
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= domain.WebhookMaxRetry {
+			return domain.WebhookMaxRetry
+		}
+	}
+	return d
+}
+
+func newSecret() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return "whsec_" + hex.EncodeToString(b)
+}
+
+func subscriptionAudit(sub *domain.WebhookSubscription) domain.JSONMap {
+	return domain.JSONMap{"url": sub.URL, "event_types": sub.EventTypes, "enabled": sub.Enabled}
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "…"
+}
+
+func (s *Service) record(ctx context.Context, tenantID uuid.UUID, actor *uuid.UUID, action domain.AuditAction, id uuid.UUID, summary string, after domain.JSONMap) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, domain.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    actor,
+		Action:     action,
+		EntityType: "webhook",
+		EntityID:   id.String(),
+		Summary:    summary,
+		After:      after,
+	})
+}
@@ -0,0 +1,475 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+type memWebhooks struct {
+	subs       map[uuid.UUID]domain.WebhookSubscription
+	deliveries map[uuid.UUID]domain.WebhookDelivery
+}
+
+func newMemWebhooks() *memWebhooks {
+	return &memWebhooks{subs: map[uuid.UUID]domain.WebhookSubscription{}, deliveries: map[uuid.UUID]domain.WebhookDelivery{}}
+}
+
+func (m *memWebhooks) ListSubscriptions(_ context.Context, tenantID uuid.UUID) ([]domain.WebhookSubscription, error) {
+	var out []domain.WebhookSubscription
+	for _, s := range m.subs {
+		if s.TenantID == tenantID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+func (m *memWebhooks) GetSubscription(_ context.Context, tenantID, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	if s, ok := m.subs[id]; ok && s.TenantID == tenantID {
+		return &s, nil
+	}
+	return nil, nil
+}
+func (m *memWebhooks) EnabledSubscriptions(ctx context.Context, tenantID uuid.UUID) ([]domain.WebhookSubscription, error) {
+	all, _ := m.ListSubscriptions(ctx, tenantID)
+	var out []domain.WebhookSubscription
+	for _, s := range all {
+		if s.Enabled {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+func (m *memWebhooks) SaveSubscription(_ context.Context, s *domain.WebhookSubscription) error {
+	m.subs[s.ID] = *s
+	return nil
+}
+func (m *memWebhooks) DeleteSubscription(_ context.Context, _, id uuid.UUID) error {
+	delete(m.subs, id)
+	return nil
+}
+func (m *memWebhooks) EnqueueDeliveries(_ context.Context, ds []domain.WebhookDelivery) error {
+	for _, d := range ds {
+		dup := false
+		for _, e := range m.deliveries {
+			if d.RedeliveryOf == nil && e.RedeliveryOf == nil && e.SubscriptionID == d.SubscriptionID && e.EventID == d.EventID {
+				dup = true
+			}
+		}
+		if !dup {
+			m.deliveries[d.ID] = d
+		}
+	}
+	return nil
+}
+func (m *memWebhooks) GetDelivery(_ context.Context, tenantID, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	if d, ok := m.deliveries[id]; ok && d.TenantID == tenantID {
+		return &d, nil
+	}
+	return nil, nil
+}
+func (m *memWebhooks) ListDeliveries(_ context.Context, tenantID, subscriptionID uuid.UUID, _ int) ([]domain.WebhookDelivery, error) {
+	var out []domain.WebhookDelivery
+	for _, d := range m.deliveries {
+		if d.TenantID == tenantID && d.SubscriptionID == subscriptionID {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+func (m *memWebhooks) ClaimDueDelivery(_ context.Context, now time.Time, lease time.Duration) (*domain.WebhookDelivery, error) {
+	var due []domain.WebhookDelivery
+	for _, d := range m.deliveries {
+		if (d.Status == domain.WebhookDeliveryPending || d.Status == domain.WebhookDeliveryDelivering) && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	if len(due) == 0 {
+		return nil, nil
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+	d := due[0]
+	d.Status, d.Attempts, d.NextAttemptAt = domain.WebhookDeliveryDelivering, d.Attempts+1, now.Add(lease)
+	m.deliveries[d.ID] = d
+	return &d, nil
+}
+func (m *memWebhooks) CompleteAttempt(_ context.Context, d *domain.WebhookDelivery) (bool, error) {
+	m.deliveries[d.ID] = *d
+	return true, nil
+}
+func (m *memWebhooks) GetSubscriptionForDelivery(ctx context.Context, d *domain.WebhookDelivery) (*domain.WebhookSubscription, error) {
+	return m.GetSubscription(ctx, d.TenantID, d.SubscriptionID)
+}
+func (m *memWebhooks) RecordAttempt(_ context.Context, id uuid.UUID, ok bool, at time.Time) (*domain.WebhookSubscription, error) {
+	s, found := m.subs[id]
+	if !found {
+		return nil, nil
+	}
+	if ok {
+		s.ConsecutiveFailures, s.FailingSince = 0, nil
+	} else {
+		s.ConsecutiveFailures++
+		if s.FailingSince == nil {
+			s.FailingSince = &at
+		}
+	}
+	m.subs[id] = s
+	return &s, nil
+}
+
+// jsonCipher stands in for the AES cipher: the service only needs a round trip.
+type jsonCipher struct{}
+
+func (jsonCipher) EncryptCredentials(m map[string]string) (string, error) {
+	b, err := json.Marshal(m)
+	return string(b), err
+}
+func (jsonCipher) DecryptCredentials(s string) (map[string]string, error) {
+	var m map[string]string
+	return m, json.Unmarshal([]byte(s), &m)
+}
+
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// receiver is an endpoint that checks signatures against a secret and
+// answers with the configured status.
+type receiver struct {
+	mu       sync.Mutex
+	secret   string
+	status   int
+	now      func() time.Time
+	verified []error
+	bodies   [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bodies = append(r.bodies, body)
+	r.verified = append(r.verified, Verify(req.Header.Get(SignatureHeader), r.secret, body, r.now(), DefaultTolerance))
+	w.WriteHeader(r.status)
+}
+
+func setup(t *testing.T) (*Service, *memWebhooks, *clock, *receiver, *httptest.Server) {
+	t.Helper()
+	repo := newMemWebhooks()
+	c := &clock{t: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)}
+	rcv := &receiver{status: 200, now: c.now}
+	srv := httptest.NewTLSServer(rcv)
+	t.Cleanup(srv.Close)
+	// The test server listens on loopback, which only private-network mode
+	// accepts; its TLS client then replaces the guarded one.
+	svc := NewService(repo).WithCipher(jsonCipher{}).WithClock(c.now).AllowPrivateNetworks(true).WithHTTPClient(srv.Client())
+	return svc, repo, c, rcv, srv
+}
+
+func subscribe(t *testing.T, svc *Service, tenant uuid.UUID, url string, types ...string) *SubscriptionWithSecret {
+	t.Helper()
+	name := "SIEM"
+	sub, err := svc.CreateSubscription(context.Background(), tenant, nil, SubscriptionInput{Name: &name, URL: &url, EventTypes: types})
+	require.NoError(t, err)
+	return sub
+}
+
+func TestPublish_QueuesOnlyForSubscribersOfTheType(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _, _, srv := setup(t)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	wants := subscribe(t, svc, tenantA, srv.URL, "risk.state_changed")
+	subscribe(t, svc, tenantA, srv.URL, "incident.declared")
+	subscribe(t, svc, tenantB, srv.URL, "risk.state_changed")
+
+	riskID := uuid.New()
+	require.NoError(t, svc.Publish(ctx, tenantA, domain.WebhookRiskStateChanged,
+		domain.WebhookRiskStateChangedData{RiskID: riskID, Title: "Ransomware", From: domain.StateDraft, To: domain.StateInTreatment}))
+
+	require.Len(t, repo.deliveries, 1, "one subscriber of the type, in the publishing tenant")
+	for _, d := range repo.deliveries {
+		assert.Equal(t, wants.ID, d.SubscriptionID)
+		var env map[string]any
+		require.NoError(t, json.Unmarshal(d.Payload, &env))
+		assert.Equal(t, "risk.state_changed", env["type"])
+		assert.Equal(t, "openrisk.webhook.risk.state_changed.v1", env["schema"])
+		assert.EqualValues(t, 1, env["schema_version"])
+		assert.Equal(t, riskID.String(), env["data"].(map[string]any)["risk_id"])
+	}
+
+	// The same event id again is the same event: not queued twice.
+	id := uuid.New()
+	require.NoError(t, svc.PublishEvent(ctx, id, tenantA, domain.WebhookIncidentDeclared, domain.WebhookIncidentDeclaredData{}))
+	require.NoError(t, svc.PublishEvent(ctx, id, tenantA, domain.WebhookIncidentDeclared, domain.WebhookIncidentDeclaredData{}))
+	assert.Len(t, repo.deliveries, 2)
+}
+
+func TestDelivery_SignedAndVerifiableWithEitherSecretDuringRotation(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, c, rcv, srv := setup(t)
+	tenant := uuid.New()
+	sub := subscribe(t, svc, tenant, srv.URL, "incident.declared")
+	assert.True(t, strings.HasPrefix(sub.Secret, "whsec_"))
+	rcv.secret = sub.Secret
+
+	require.NoError(t, svc.Publish(ctx, tenant, domain.WebhookIncidentDeclared, domain.WebhookIncidentDeclaredData{IncidentID: 7}))
+	worked, err := svc.DeliverNext(ctx)
+	require.NoError(t, err)
+	require.True(t, worked)
+	require.Len(t, rcv.verified, 1)
+	assert.NoError(t, rcv.verified[0])
+
+	// Rotate: the receiver still holds the old secret, and is not cut off.
+	rotated, err := svc.RotateSecret(ctx, tenant, nil, sub.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, sub.Secret, rotated.Secret)
+	require.NoError(t, svc.Publish(ctx, tenant, domain.WebhookIncidentDeclared, domain.WebhookIncidentDeclaredData{IncidentID: 8}))
+	_, err = svc.DeliverNext(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, rcv.verified[1], "the previous secret still signs during the grace period")
+	assert.NoError(t, Verify(signatureNow(t, svc, repo, tenant), rotated.Secret, nil, c.now(), DefaultTolerance))
+
+	// After the grace period only the new secret signs.
+	c.advance(domain.WebhookPreviousSecretTTL + time.Minute)
+	require.NoError(t, svc.Publish(ctx, tenant, domain.WebhookIncidentDeclared, domain.WebhookIncidentDeclaredData{IncidentID: 9}))
+	_, err = svc.DeliverNext(ctx)
+	require.NoError(t, err)
+	assert.Error(t, rcv.verified[2], "the replaced secret has expired")
+	rcv.secret = rotated.Secret
+	_, err = svc.Redeliver(ctx, tenant, nil, latest(repo).ID)
+	require.NoError(t, err)
+	_, err = svc.DeliverNext(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, rcv.verified[3])
+}
+
+// signatureNow signs an empty body the way the service would right now.
+func signatureNow(t *testing.T, svc *Service, repo *memWebhooks, tenant uuid.UUID) string {
+	t.Helper()
+	subs, _ := repo.ListSubscriptions(context.Background(), tenant)
+	require.Len(t, subs, 1)
+	cur, prev, err := svc.secrets(&subs[0])
+	require.NoError(t, err)
+	return Sign([]string{cur, prev}, svc.now(), nil)
+}
+
+func latest(repo *memWebhooks) domain.WebhookDelivery {
+	var out domain.WebhookDelivery
+	for _, d := range repo.deliveries {
+		if d.CreatedAt.After(out.CreatedAt) || (d.CreatedAt.Equal(out.CreatedAt) && d.Attempts > 0) {
+			out = d
+		}
+	}
+	return out
+}
+
+func TestVerify_RefusesReplayAndTampering(t *testing.T) {
+	now := time.Now()
+	body := []byte(`{"id":"x"}`)
+	h := Sign([]string{"s3cret"}, now, body)
+	assert.NoError(t, Verify(h, "s3cret", body, now.Add(time.Minute), DefaultTolerance))
+	assert.Error(t, Verify(h, "s3cret", body, now.Add(time.Hour), DefaultTolerance), "too old: a replay")
+	assert.Error(t, Verify(h, "s3cret", []byte(`{"id":"y"}`), now, DefaultTolerance), "body changed")
+	assert.Error(t, Verify(h, "other", body, now, DefaultTolerance))
+	assert.Error(t, Verify("v1=abc", "s3cret", body, now, DefaultTolerance), "no timestamp")
+}
+
+func TestDelivery_RetriesWithBackoffThenGivesUp(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, c, rcv, srv := setup(t)
+	rcv.status = 503
+	tenant := uuid.New()
+	subscribe(t, svc, tenant, srv.URL, "evidence.expired")
+	require.NoError(t, svc.Publish(ctx, tenant, domain.WebhookEvidenceExpired, domain.WebhookEvidenceExpiredData{}))
+
+	for i := 1; i <= domain.WebhookMaxAttempts; i++ {
+		worked, err := svc.DeliverNext(ctx)
+		require.NoError(t, err)
+		require.True(t, worked, "attempt %d", i)
+		d := latest(repo)
+		assert.Equal(t, i, d.Attempts)
+		assert.Equal(t, 503, d.LastStatusCode)
+		if i < domain.WebhookMaxAttempts {
+			assert.Equal(t, domain.WebhookDeliveryPending, d.Status)
+			assert.Equal(t, c.now().Add(Backoff(i)), d.NextAttemptAt)
+			worked, _ = svc.DeliverNext(ctx)
+			assert.False(t, worked, "not due before its backoff")
+			c.advance(Backoff(i))
+		} else {
+			assert.Equal(t, domain.WebhookDeliveryFailed, d.Status)
+			assert.Contains(t, d.LastError, "HTTP 503")
+		}
+	}
+	assert.Len(t, rcv.bodies, domain.WebhookMaxAttempts)
+}
+
+func TestBackoff_DoublesAndIsCapped(t *testing.T) {
+	assert.Equal(t, 30*time.Second, Backoff(1))
+	assert.Equal(t, time.Minute, Backoff(2))
+	assert.Equal(t, 8*time.Minute, Backoff(5))
+	assert.Equal(t, domain.WebhookMaxRetry, Backoff(40))
+}
+
+func TestDelivery_DisablesOnlyAfterSustainedFailure(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, c, rcv, srv := setup(t)
+	rcv.status = 500
+	tenant := uuid.New()
+	sub := subscribe(t, svc, tenant, srv.URL, "approval.decided")
+
+	fail := func(n int) {
+		for i := 0; i < n; i++ {
+			require.NoError(t, svc.Publish(ctx, tenant, domain.WebhookApprovalDecided, domain.WebhookApprovalDecidedData{}))
+			_, err := svc.DeliverNext(ctx)
+			require.NoError(t, err)
+		}
+	}
+	// A burst of failures in a short outage: many attempts, not long enough.
+	fail(domain.WebhookDisableAfterFailures + 5)
+	s, _ := repo.GetSubscription(ctx, tenant, sub.ID)
+	assert.True(t, s.Enabled, "a burst during a short outage does not disable")
+
+	// One success resets the streak.
+	rcv.status = 200
+	fail(1)
+	s, _ = repo.GetSubscription(ctx, tenant, sub.ID)
+	assert.Zero(t, s.ConsecutiveFailures)
+	assert.Nil(t, s.FailingSince)
+
+	// Failing for longer than the period, with enough attempts: disabled.
+	rcv.status = 500
+	fail(1)
+	c.advance(domain.WebhookDisableAfterPeriod)
+	fail(domain.WebhookDisableAfterFailures)
+	s, _ = repo.GetSubscription(ctx, tenant, sub.ID)
+	assert.False(t, s.Enabled)
+	assert.Contains(t, s.DisabledReason, "HTTP 500")
+
+	// Nothing more is sent; queued deliveries fail without a request.
+	sent := len(rcv.bodies)
+	require.NoError(t, repo.EnqueueDeliveries(ctx, []domain.WebhookDelivery{{ID: uuid.New(), TenantID: tenant,
+		SubscriptionID: sub.ID, EventID: uuid.New(), Status: domain.WebhookDeliveryPending, NextAttemptAt: c.now()}}))
+	require.NoError(t, svc.Publish(ctx, tenant, domain.WebhookApprovalDecided, domain.WebhookApprovalDecidedData{}))
+	for worked := true; worked; {
+		var err error
+		worked, err = svc.DeliverNext(ctx)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, sent, len(rcv.bodies))
+
+	// Re-enabling gives the endpoint a fresh allowance.
+	on := true
+	s, err := svc.UpdateSubscription(ctx, tenant, nil, sub.ID, SubscriptionInput{Enabled: &on})
+	require.NoError(t, err)
+	assert.True(t, s.Enabled)
+	assert.Zero(t, s.ConsecutiveFailures)
+	assert.Empty(t, s.DisabledReason)
+}
+
+func TestRedeliver_SameEventAsANewDelivery(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _, rcv, srv := setup(t)
+	tenant := uuid.New()
+	sub := subscribe(t, svc, tenant, srv.URL, "incident.declared")
+	rcv.secret = sub.Secret
+	require.NoError(t, svc.Publish(ctx, tenant, domain.WebhookIncidentDeclared, domain.WebhookIncidentDeclaredData{IncidentID: 3}))
+	_, err := svc.DeliverNext(ctx)
+	require.NoError(t, err)
+	orig := latest(repo)
+	require.Equal(t, domain.WebhookDeliverySucceeded, orig.Status)
+
+	again, err := svc.Redeliver(ctx, tenant, nil, orig.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, orig.ID, again.ID)
+	assert.Equal(t, orig.EventID, again.EventID)
+	assert.Equal(t, orig.ID, *again.RedeliveryOf)
+	_, err = svc.DeliverNext(ctx)
+	require.NoError(t, err)
+	require.Len(t, rcv.bodies, 2)
+	assert.Equal(t, rcv.bodies[0], rcv.bodies[1], "the envelope is unchanged")
+
+	_, err = svc.Redeliver(ctx, uuid.New(), nil, orig.ID)
+	assert.Error(t, err, "another tenant cannot redeliver it")
+}
+
+func TestSubscription_URLAndEventTypesAreChecked(t *testing.T) {
+	ctx := context.Background()
+	svc, _, _, _, _ := setup(t)
+	svc.AllowPrivateNetworks(false)
+	tenant := uuid.New()
+	name := "x"
+	create := func(url string, types ...string) error {
+		_, err := svc.CreateSubscription(ctx, tenant, nil, SubscriptionInput{Name: &name, URL: &url, EventTypes: types})
+		return err
+	}
+	assert.NoError(t, create("https://hooks.example.com/openrisk", "risk.score_updated"))
+	assert.Error(t, create("http://hooks.example.com/openrisk", "risk.score_updated"), "https only")
+	assert.Error(t, create("https://10.0.0.4/hook", "risk.score_updated"), "private address")
+	assert.Error(t, create("https://localhost/hook", "risk.score_updated"))
+	assert.Error(t, create("https://user:pw@hooks.example.com/", "risk.score_updated"))
+	assert.Error(t, create("https://hooks.example.com/", "risk.deleted"), "unknown event type")
+
+	svc.AllowPrivateNetworks(true)
+	assert.NoError(t, create("http://10.0.0.4/hook", "risk.score_updated"))
+}
+
+// Each schema's data properties must be exactly the payload struct's JSON
+// fields, so a field added to one and not the other fails here.
+func TestSchemas_MatchThePayloadStructs(t *testing.T) {
+	payloads := map[domain.WebhookEventType]any{
+		domain.WebhookRiskStateChanged:      domain.WebhookRiskStateChangedData{},
+		domain.WebhookRiskScoreUpdated:      domain.WebhookRiskScoreUpdatedData{},
+		domain.WebhookVulnerabilityDetected: domain.WebhookVulnerabilityDetectedData{},
+		domain.WebhookEvidenceExpired:       domain.WebhookEvidenceExpiredData{},
+		domain.WebhookApprovalDecided:       domain.WebhookApprovalDecidedData{},
+		domain.WebhookIncidentDeclared:      domain.WebhookIncidentDeclaredData{},
+		domain.WebhookAuditCheckpointed:     domain.WebhookAuditCheckpointData{},
+	}
+	require.Len(t, payloads, len(domain.WebhookEventTypes))
+	for _, et := range domain.WebhookEventTypes {
+		raw, err := Schema(et)
+		require.NoError(t, err, et)
+		var doc struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		}
+		require.NoError(t, json.Unmarshal(raw, &doc), et)
+		var data struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		}
+		require.NoError(t, json.Unmarshal(doc.Properties["data"], &data), et)
+
+		var inSchema, inStruct []string
+		for k := range data.Properties {
+			inSchema = append(inSchema, k)
+		}
+		rt := reflect.TypeOf(payloads[et])
+		for i := 0; i < rt.NumField(); i++ {
+			tag := strings.Split(rt.Field(i).Tag.Get("json"), ",")[0]
+			inStruct = append(inStruct, tag)
+		}
+		sort.Strings(inSchema)
+		sort.Strings(inStruct)
+		assert.Equal(t, inStruct, inSchema, et)
+	}
+	assert.Len(t, Events(), len(domain.WebhookEventTypes))
+}
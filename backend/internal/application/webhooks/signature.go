@@ -0,0 +1,87 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader carries the delivery signature:
+//
+//	X-OpenRisk-Webhook-Signature: t=1767225600,v1=5257a869...,v1=9c1e20d4...
+//
+// Each v1 is the hex HMAC-SHA256 of "<t>.<body>" under one signing secret.
+// There are two during a rotation — the new secret's and the previous one's —
+// and a receiver accepts the request when any of them matches its secret.
+// Signing the timestamp with the body is what lets a receiver refuse a
+// replayed request.
+//
+// Distinct from the automation channel's X-OpenRisk-Signature (a bare
+// "sha256=<hex>" over the body), which receivers of that channel already
+// parse.
+const SignatureHeader = "X-OpenRisk-Webhook-Signature"
+
+// DefaultTolerance is how old a signed timestamp Verify accepts by default.
+const DefaultTolerance = 5 * time.Minute
+
+// Sign builds the signature header value for body, sent at t, under every
+// secret given.
+func Sign(secrets []string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	parts := []string{"t=" + ts}
+	for _, s := range secrets {
+		parts = append(parts, "v1="+mac(s, ts, body))
+	}
+	return strings.Join(parts, ",")
+}
+
+// Verify checks a signature header against a receiver's secret. It is what a
+// receiver written in Go runs, and what docs/WEBHOOKS.md describes for
+// everyone else.
+func Verify(header, secret string, body []byte, now time.Time, tolerance time.Duration) error {
+	var ts string
+	var sigs []string
+	for _, part := range strings.Split(header, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			sigs = append(sigs, v)
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || len(sigs) == 0 {
+		return errors.New("malformed signature header")
+	}
+	if d := now.Sub(time.Unix(unix, 0)); d > tolerance || d < -tolerance {
+		return errors.New("signature timestamp outside the tolerance")
+	}
+	want := mac(secret, ts, body)
+	for _, s := range sigs {
+		if hmac.Equal([]byte(s), []byte(want)) {
+			return nil
+		}
+	}
+	return errors.New("no signature matches")
+}
+
+func mac(secret, ts string, body []byte) string {
+	m := hmac.New(sha256.New, []byte(secret))
+	m.Write([]byte(ts))
+	m.Write([]byte{'.'})
+	m.Write(body)
+	return hex.EncodeToString(m.Sum(nil))
+}
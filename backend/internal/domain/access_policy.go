@@ -0,0 +1,693 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---------------------------------------------------------------------------
+// Record-level access policies (attribute-based access control).
+//
+// A permission (`risk:read:team`) says whether a user may read risks at all.
+// An AccessPolicy says WHICH risks: "auditors read only the risks and evidence
+// of the frameworks in their audit scope", "a BU manager sees the risks whose
+// asset belongs to their BU", "confidential-tagged risks are visible to their
+// owner and the CISO only". A policy names the users it applies to and a
+// condition over the record's attributes, which may refer to the user's own
+// attributes ($user.business_unit).
+//
+// The same condition is evaluated in two places and both must agree: compiled
+// to SQL by the repository, so a hidden record never leaves the database (list,
+// search, get by id, delete), and evaluated here in Go, so the explain endpoint
+// can say which policy decided and why. See docs/ACCESS_POLICIES.md.
+// ---------------------------------------------------------------------------
+
+// AccessResource names a record type policies can govern.
+type AccessResource string
+
+const (
+	AccessResourceRisk     AccessResource = "risk"
+	AccessResourceEvidence AccessResource = "evidence"
+)
+
+// AccessEffect is what a policy does to the records its condition matches.
+type AccessEffect string
+
+const (
+	// AccessAllow turns the resource into an allow-list for the users the
+	// policy applies to: they see a record only if at least one of their allow
+	// policies matches it. Users no allow policy applies to are not narrowed.
+	AccessAllow AccessEffect = "allow"
+	// AccessDeny hides the matching records. A deny always wins over an allow.
+	AccessDeny AccessEffect = "deny"
+)
+
+// AccessOp is a predicate operator.
+type AccessOp string
+
+const (
+	// AccessOpIn holds when the attribute has at least one value among Values.
+	// For a list attribute (tags, framework) that means any element. Matching
+	// is case-insensitive: tags and business units are free text.
+	AccessOpIn AccessOp = "in"
+	// AccessOpEmpty holds when the attribute has no value at all.
+	AccessOpEmpty AccessOp = "empty"
+)
+
+// AccessUserRef prefixes a value that is replaced by the user's attribute of
+// that name ("$user.business_unit"). In an attribute name, the "user." prefix
+// reads the user's attribute instead of the record's.
+const (
+	AccessUserRef  = "$user."
+	accessUserAttr = "user."
+)
+
+// Built-in user attributes. Every other name is read from the attributes an
+// administrator stored for the member (AccessSubjectAttributes).
+const (
+	AccessUserID           = "id"
+	AccessUserRole         = "role"
+	AccessUserBusinessRole = "business_role"
+	AccessUserDepartment   = "department"
+)
+
+var accessBuiltinUserAttrs = []string{AccessUserID, AccessUserRole, AccessUserBusinessRole, AccessUserDepartment}
+
+// AccessAttribute describes one record attribute a condition may test.
+type AccessAttribute struct {
+	Name        string `json:"name"`
+	List        bool   `json:"list"`
+	Description string `json:"description"`
+}
+
+// AccessAttributes is the catalogue of record attributes per resource. The SQL
+// compiler and the record loader in the repository implement exactly these; a
+// name missing here is refused when a policy is saved.
+var AccessAttributes = map[AccessResource][]AccessAttribute{
+	AccessResourceRisk: {
+		{Name: "id", Description: "the risk's id"},
+		{Name: "owner_id", Description: "the accountable owner"},
+		{Name: "assignee_id", Description: "the assignee"},
+		{Name: "reviewer_id", Description: "the reviewer"},
+		{Name: "created_by", Description: "who created the risk"},
+		{Name: "tags", List: true, Description: "free-text tags"},
+		{Name: "category_id", Description: "the controlled category"},
+		{Name: "business_unit", Description: "the business unit that owns the exposure"},
+		{Name: "classification", Description: "the confidentiality classification"},
+		{Name: "status", Description: "open, in_progress, mitigated, accepted or closed"},
+		{Name: "criticality", Description: "low, medium, high or critical"},
+		{Name: "asset.business_unit", List: true, Description: "business units of the linked assets"},
+		{Name: "asset.criticality", List: true, Description: "criticality of the linked assets"},
+		{Name: "framework", List: true, Description: "frameworks of the mapped controls"},
+	},
+	AccessResourceEvidence: {
+		{Name: "id", Description: "the evidence's id"},
+		{Name: "owner_id", Description: "the accountable owner"},
+		{Name: "assignee_id", Description: "the assignee"},
+		{Name: "reviewer_id", Description: "the reviewer"},
+		{Name: "collected_by", Description: "who collected it"},
+		{Name: "type", Description: "the evidence type"},
+		{Name: "review", Description: "pending, accepted or rejected"},
+		{Name: "source", Description: "where the evidence came from"},
+		{Name: "control", List: true, Description: "the linked controls"},
+		{Name: "framework", List: true, Description: "frameworks of the linked controls"},
+	},
+}
+
+// LookupAccessAttribute finds a record attribute of a resource.
+func LookupAccessAttribute(r AccessResource, name string) (AccessAttribute, bool) {
+	for _, a := range AccessAttributes[r] {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return AccessAttribute{}, false
+}
+
+// IsValid reports whether r is a governed resource.
+func (r AccessResource) IsValid() bool {
+	_, ok := AccessAttributes[r]
+	return ok
+}
+
+// AccessSubjects selects the users a policy applies to. Every non-empty field
+// must match (AND); within a field any entry matches (OR). The zero value
+// applies to every user.
+type AccessSubjects struct {
+	Roles         []MemberRole      `json:"roles,omitempty"`
+	BusinessRoles []BusinessRoleKey `json:"business_roles,omitempty"`
+	Users         []uuid.UUID       `json:"users,omitempty"`
+	// Attributes requires, per key, that the user carries one of the values.
+	Attributes map[string][]string `json:"attributes,omitempty"`
+}
+
+func (s AccessSubjects) Value() (driver.Value, error) { return json.Marshal(s) }
+
+func (s *AccessSubjects) Scan(value interface{}) error {
+	return scanJSONColumn(value, s, "AccessSubjects")
+}
+
+// Matches reports whether the policy applies to u.
+func (s AccessSubjects) Matches(u *AccessSubject) bool {
+	if len(s.Roles) > 0 && !containsFold(rolesToStrings(s.Roles), string(u.Role)) {
+		return false
+	}
+	if len(s.BusinessRoles) > 0 && !containsFold(businessRolesToStrings(s.BusinessRoles), string(u.BusinessRole)) {
+		return false
+	}
+	if len(s.Users) > 0 {
+		found := false
+		for _, id := range s.Users {
+			if id == u.UserID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for key, want := range s.Attributes {
+		if !intersectsFold(u.Values(key), want) {
+			return false
+		}
+	}
+	return true
+}
+
+// AccessCondition is a condition over a record. Exactly one form is set: All
+// (every child holds), Any (one child holds), Not, or a predicate
+// Attr/Op/Values.
+type AccessCondition struct {
+	All []AccessCondition `json:"all,omitempty"`
+	Any []AccessCondition `json:"any,omitempty"`
+	Not *AccessCondition  `json:"not,omitempty"`
+
+	// Attr is a record attribute from AccessAttributes, or "user.<name>".
+	Attr string   `json:"attr,omitempty"`
+	Op   AccessOp `json:"op,omitempty"`
+	// Values are literals or "$user.<name>" references.
+	Values []string `json:"values,omitempty"`
+}
+
+// maxConditionDepth bounds nesting: a policy is read by people, and the SQL it
+// compiles to is run on every listing.
+const maxConditionDepth = 8
+
+func (c AccessCondition) isPredicate() bool { return c.Attr != "" || c.Op != "" }
+
+func (c *AccessCondition) validate(r AccessResource, depth int) error {
+	if depth > maxConditionDepth {
+		return NewValidationError(fmt.Sprintf("condition is nested deeper than %d levels", maxConditionDepth))
+	}
+	forms := 0
+	if c.All != nil {
+		forms++
+	}
+	if c.Any != nil {
+		forms++
+	}
+	if c.Not != nil {
+		forms++
+	}
+	if c.isPredicate() {
+		forms++
+	}
+	if forms != 1 {
+		return NewValidationError("each condition must be exactly one of all, any, not or an attr/op predicate")
+	}
+	if (c.All != nil && len(c.All) == 0) || (c.Any != nil && len(c.Any) == 0) {
+		return NewValidationError("all and any need at least one condition")
+	}
+	for i := range c.All {
+		if err := c.All[i].validate(r, depth+1); err != nil {
+			return err
+		}
+	}
+	for i := range c.Any {
+		if err := c.Any[i].validate(r, depth+1); err != nil {
+			return err
+		}
+	}
+	if c.Not != nil {
+		return c.Not.validate(r, depth+1)
+	}
+	if !c.isPredicate() {
+		return nil
+	}
+	if name, ok := strings.CutPrefix(c.Attr, accessUserAttr); ok {
+		if !validAccessAttrKey(name) {
+			return NewValidationError(fmt.Sprintf("invalid user attribute %q", c.Attr))
+		}
+	} else if _, ok := LookupAccessAttribute(r, c.Attr); !ok {
+		return NewValidationError(fmt.Sprintf("unknown %s attribute %q", r, c.Attr))
+	}
+	switch c.Op {
+	case AccessOpIn:
+		if len(c.Values) == 0 {
+			return NewValidationError(fmt.Sprintf("%s: op \"in\" needs at least one value", c.Attr))
+		}
+		for _, v := range c.Values {
+			if name, ok := strings.CutPrefix(v, AccessUserRef); ok && !validAccessAttrKey(name) {
+				return NewValidationError(fmt.Sprintf("invalid user reference %q", v))
+			}
+		}
+	case AccessOpEmpty:
+		if len(c.Values) > 0 {
+			return NewValidationError(fmt.Sprintf("%s: op \"empty\" takes no values", c.Attr))
+		}
+	default:
+		return NewValidationError(fmt.Sprintf("unknown operator %q (use in or empty)", c.Op))
+	}
+	return nil
+}
+
+func (c *AccessCondition) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+	return json.Marshal(c)
+}
+
+func (c *AccessCondition) Scan(value interface{}) error {
+	return scanJSONColumn(value, c, "AccessCondition")
+}
+
+// AccessPolicy is one tenant rule.
+type AccessPolicy struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID    uuid.UUID      `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Name        string         `gorm:"size:120;not null" json:"name"`
+	Description string         `gorm:"type:text;not null;default:''" json:"description"`
+	Resource    AccessResource `gorm:"type:varchar(32);not null" json:"resource"`
+	Effect      AccessEffect   `gorm:"type:varchar(8);not null" json:"effect"`
+	Subjects    AccessSubjects `gorm:"type:jsonb;not null" json:"subjects"`
+	// Condition selects the records; nil matches every record of the resource.
+	Condition *AccessCondition `gorm:"type:jsonb" json:"condition"`
+	Enabled   bool             `gorm:"not null;default:true" json:"enabled"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (AccessPolicy) TableName() string { return "access_policies" }
+
+// Validate checks a policy before it is stored.
+func (p *AccessPolicy) Validate() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return NewValidationError("name is required")
+	}
+	if len(p.Name) > 120 {
+		return NewValidationError("name must be at most 120 characters")
+	}
+	if !p.Resource.IsValid() {
+		return NewValidationError(fmt.Sprintf("unknown resource %q (use risk or evidence)", p.Resource))
+	}
+	if p.Effect != AccessAllow && p.Effect != AccessDeny {
+		return NewValidationError(fmt.Sprintf("unknown effect %q (use allow or deny)", p.Effect))
+	}
+	for _, r := range p.Subjects.Roles {
+		if r != RoleRoot && r != RoleAdmin && r != RoleUser {
+			return NewValidationError(fmt.Sprintf("unknown role %q", r))
+		}
+	}
+	for _, br := range p.Subjects.BusinessRoles {
+		if !IsBusinessRole(br) {
+			return NewValidationError(fmt.Sprintf("unknown business role %q", br))
+		}
+	}
+	for key := range p.Subjects.Attributes {
+		if !validAccessAttrKey(key) {
+			return NewValidationError(fmt.Sprintf("invalid user attribute %q", key))
+		}
+	}
+	if p.Condition != nil {
+		return p.Condition.validate(p.Resource, 1)
+	}
+	return nil
+}
+
+// AccessSubject is the user a decision is made for.
+type AccessSubject struct {
+	UserID       uuid.UUID           `json:"user_id"`
+	Role         MemberRole          `json:"role"`
+	BusinessRole BusinessRoleKey     `json:"business_role,omitempty"`
+	Department   string              `json:"department,omitempty"`
+	Attributes   map[string][]string `json:"attributes,omitempty"`
+}
+
+// Exempt reports whether policies do not apply to the user. Administrators
+// write the policies; narrowing what they see would not protect anything and
+// would let a mistaken policy hide records from the only people able to fix it.
+func (u *AccessSubject) Exempt() bool {
+	return u.Role == RoleAdmin || u.Role == RoleRoot
+}
+
+// Values returns the user's values for an attribute name, without empties.
+func (u *AccessSubject) Values(name string) []string {
+	var vs []string
+	switch name {
+	case AccessUserID:
+		vs = []string{u.UserID.String()}
+	case AccessUserRole:
+		vs = []string{string(u.Role)}
+	case AccessUserBusinessRole:
+		vs = []string{string(u.BusinessRole)}
+	case AccessUserDepartment:
+		vs = []string{u.Department}
+	default:
+		vs = u.Attributes[name]
+	}
+	return nonEmpty(vs)
+}
+
+// AccessRecord is a record's attribute values, keyed by attribute name. The
+// repository fills it with the same values its SQL compares against.
+type AccessRecord map[string][]string
+
+// AccessScope is what a request carries to the repositories: the user and the
+// tenant's enabled policies. A nil scope (workers, system calls) is not
+// narrowed.
+type AccessScope struct {
+	Subject  AccessSubject
+	Policies []AccessPolicy
+}
+
+// Applicable returns the policies that apply to the scope's user for r, deny
+// policies first. None for an exempt user.
+func (s *AccessScope) Applicable(r AccessResource) (deny, allow []AccessPolicy) {
+	if s == nil || s.Subject.Exempt() {
+		return nil, nil
+	}
+	for _, p := range s.Policies {
+		if !p.Enabled || p.Resource != r || !p.Subjects.Matches(&s.Subject) {
+			continue
+		}
+		if p.Effect == AccessDeny {
+			deny = append(deny, p)
+		} else {
+			allow = append(allow, p)
+		}
+	}
+	return deny, allow
+}
+
+// ResolveValues expands the literals and $user references of a predicate,
+// without empties. An unresolvable reference contributes nothing, so "in" over
+// it is false: a BU manager with no business unit sees nothing, not everything.
+func (u *AccessSubject) ResolveValues(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if name, ok := strings.CutPrefix(v, AccessUserRef); ok {
+			out = append(out, u.Values(name)...)
+			continue
+		}
+		out = append(out, v)
+	}
+	return nonEmpty(out)
+}
+
+// Eval evaluates c against a record for a user. The trace explains the result
+// one predicate per line.
+func (c *AccessCondition) Eval(rec AccessRecord, u *AccessSubject, trace *[]string) bool {
+	switch {
+	case c == nil:
+		return true
+	case c.All != nil:
+		for i := range c.All {
+			if !c.All[i].Eval(rec, u, trace) {
+				return false
+			}
+		}
+		return true
+	case c.Any != nil:
+		for i := range c.Any {
+			if c.Any[i].Eval(rec, u, trace) {
+				return true
+			}
+		}
+		return false
+	case c.Not != nil:
+		return !c.Not.Eval(rec, u, trace)
+	}
+
+	var have []string
+	if name, ok := strings.CutPrefix(c.Attr, accessUserAttr); ok {
+		have = u.Values(name)
+	} else {
+		have = nonEmpty(rec[c.Attr])
+	}
+	var ok bool
+	switch c.Op {
+	case AccessOpIn:
+		want := u.ResolveValues(c.Values)
+		ok = intersectsFold(have, want)
+		appendTrace(trace, fmt.Sprintf("%s %v in %v: %t", c.Attr, have, want, ok))
+	case AccessOpEmpty:
+		ok = len(have) == 0
+		appendTrace(trace, fmt.Sprintf("%s %v empty: %t", c.Attr, have, ok))
+	}
+	return ok
+}
+
+// AccessPolicyVerdict is one policy's part in a decision.
+type AccessPolicyVerdict struct {
+	PolicyID uuid.UUID    `json:"policy_id"`
+	Name     string       `json:"name"`
+	Effect   AccessEffect `json:"effect"`
+	// Matched is whether the condition held for the record.
+	Matched bool `json:"matched"`
+	// Trace lists the predicates evaluated, in order.
+	Trace []string `json:"trace,omitempty"`
+}
+
+// AccessDecision explains whether a user sees a record.
+type AccessDecision struct {
+	Visible bool   `json:"visible"`
+	Reason  string `json:"reason"`
+	// Policies holds the verdict of every policy that applies to the user;
+	// policies for other users or other resources are not listed.
+	Policies []AccessPolicyVerdict `json:"policies"`
+}
+
+// Decide evaluates the scope's policies for one record: a matching deny hides
+// it; otherwise, when allow policies apply, one of them must match.
+func (s *AccessScope) Decide(r AccessResource, rec AccessRecord) AccessDecision {
+	d := AccessDecision{Policies: []AccessPolicyVerdict{}}
+	if s == nil {
+		d.Visible, d.Reason = true, "no access policies are in force"
+		return d
+	}
+	if s.Subject.Exempt() {
+		d.Visible, d.Reason = true, fmt.Sprintf("access policies do not apply to the %s role", s.Subject.Role)
+		return d
+	}
+	deny, allow := s.Applicable(r)
+	var denied, allowed []string
+	for _, p := range append(deny, allow...) {
+		var trace []string
+		matched := p.Condition.Eval(rec, &s.Subject, &trace)
+		d.Policies = append(d.Policies, AccessPolicyVerdict{
+			PolicyID: p.ID, Name: p.Name, Effect: p.Effect, Matched: matched, Trace: trace,
+		})
+		if matched && p.Effect == AccessDeny {
+			denied = append(denied, p.Name)
+		}
+		if matched && p.Effect == AccessAllow {
+			allowed = append(allowed, p.Name)
+		}
+	}
+	switch {
+	case len(denied) > 0:
+		d.Reason = fmt.Sprintf("hidden by deny policy %s", quoteList(denied))
+	case len(allow) == 0:
+		d.Visible, d.Reason = true, "no allow policy restricts this user"
+	case len(allowed) > 0:
+		d.Visible, d.Reason = true, fmt.Sprintf("allowed by %s", quoteList(allowed))
+	default:
+		d.Reason = fmt.Sprintf("allow policies apply to this user and none matches (%s)", quoteList(policyNames(allow)))
+	}
+	return d
+}
+
+type accessScopeKey struct{}
+
+// WithAccessScope attaches a request's access scope to ctx.
+func WithAccessScope(ctx context.Context, s *AccessScope) context.Context {
+	return context.WithValue(ctx, accessScopeKey{}, s)
+}
+
+// AccessScopeFrom returns the scope attached to ctx, or nil.
+func AccessScopeFrom(ctx context.Context) *AccessScope {
+	s, _ := ctx.Value(accessScopeKey{}).(*AccessScope)
+	return s
+}
+
+// AccessSubjectAttributes are the attributes an administrator assigns to a
+// member for policies to test: the business units a manager answers for, the
+// frameworks in an auditor's scope.
+type AccessSubjectAttributes struct {
+	TenantID   uuid.UUID             `gorm:"type:uuid;primaryKey" json:"tenant_id"`
+	UserID     uuid.UUID             `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Attributes AccessAttributeValues `gorm:"type:jsonb;not null" json:"attributes"`
+	UpdatedBy  *uuid.UUID            `gorm:"type:uuid" json:"updated_by,omitempty"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+}
+
+func (AccessSubjectAttributes) TableName() string { return "access_subject_attributes" }
+
+// AccessAttributeValues is a jsonb map of attribute name to values.
+type AccessAttributeValues map[string][]string
+
+func (v AccessAttributeValues) Value() (driver.Value, error) {
+	if v == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (v *AccessAttributeValues) Scan(value interface{}) error {
+	return scanJSONColumn(value, v, "AccessAttributeValues")
+}
+
+var accessAttrKeyRe = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+func validAccessAttrKey(k string) bool { return accessAttrKeyRe.MatchString(k) }
+
+// ValidateSubjectAttributes normalises an attribute assignment: keys are
+// lower-case identifiers that do not shadow a built-in, values are trimmed and
+// de-duplicated.
+func ValidateSubjectAttributes(in map[string][]string) (AccessAttributeValues, error) {
+	out := AccessAttributeValues{}
+	for key, values := range in {
+		if !validAccessAttrKey(key) {
+			return nil, NewValidationError(fmt.Sprintf("invalid attribute name %q (lower-case letters, digits and _)", key))
+		}
+		for _, b := range accessBuiltinUserAttrs {
+			if key == b {
+				return nil, NewValidationError(fmt.Sprintf("%q is a built-in attribute and cannot be assigned", key))
+			}
+		}
+		seen := map[string]bool{}
+		var clean []string
+		for _, v := range values {
+			v = strings.TrimSpace(v)
+			if v == "" || seen[strings.ToLower(v)] {
+				continue
+			}
+			seen[strings.ToLower(v)] = true
+			clean = append(clean, v)
+		}
+		if len(clean) > 0 {
+			sort.Strings(clean)
+			out[key] = clean
+		}
+	}
+	return out, nil
+}
+
+// AccessPolicyRepository stores policies and member attributes, and reads the
+// attributes of a record the way the SQL scope sees them.
+type AccessPolicyRepository interface {
+	List(ctx context.Context, tenantID uuid.UUID) ([]AccessPolicy, error)
+	Get(ctx context.Context, tenantID, id uuid.UUID) (*AccessPolicy, error)
+	Create(ctx context.Context, p *AccessPolicy) error
+	Update(ctx context.Context, p *AccessPolicy) error
+	Delete(ctx context.Context, tenantID, id uuid.UUID) error
+
+	// Subject returns the member's role, business role, department and
+	// assigned attributes; nil when the user is not a member of the tenant.
+	Subject(ctx context.Context, tenantID, userID uuid.UUID) (*AccessSubject, error)
+	SaveSubjectAttributes(ctx context.Context, a *AccessSubjectAttributes) error
+
+	// Record returns a record's attributes, ignoring any scope; nil when the
+	// record does not exist in the tenant.
+	Record(ctx context.Context, tenantID uuid.UUID, r AccessResource, id uuid.UUID) (AccessRecord, error)
+	// Visible runs the scope attached to ctx against one record, in SQL.
+	Visible(ctx context.Context, tenantID uuid.UUID, r AccessResource, id uuid.UUID) (bool, error)
+}
+
+func appendTrace(trace *[]string, line string) {
+	if trace != nil {
+		*trace = append(*trace, line)
+	}
+}
+
+func nonEmpty(vs []string) []string {
+	var out []string
+	for _, v := range vs {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func intersectsFold(have, want []string) bool {
+	for _, h := range have {
+		if containsFold(want, h) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(list []string, v string) bool {
+	for _, x := range list {
+		if strings.EqualFold(x, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func rolesToStrings(rs []MemberRole) []string {
+	out := make([]string, len(rs))
+	for i, r := range rs {
+		out[i] = string(r)
+	}
+	return out
+}
+
+func businessRolesToStrings(rs []BusinessRoleKey) []string {
+	out := make([]string, len(rs))
+	for i, r := range rs {
+		out[i] = string(r)
+	}
+	return out
+}
+
+func policyNames(ps []AccessPolicy) []string {
+	out := make([]string, len(ps))
+	for i, p := range ps {
+		out[i] = p.Name
+	}
+	return out
+}
+
+func quoteList(names []string) string {
+	q := make([]string, len(names))
+	for i, n := range names {
+		q[i] = fmt.Sprintf("%q", n)
+	}
+	return strings.Join(q, ", ")
+}
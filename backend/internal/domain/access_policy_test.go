@@ -0,0 +1,119 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessPolicy_Validate(t *testing.T) {
+	in := func(attr string, values ...string) *AccessCondition {
+		return &AccessCondition{Attr: attr, Op: AccessOpIn, Values: values}
+	}
+	base := func(c *AccessCondition) AccessPolicy {
+		return AccessPolicy{Name: "p", Resource: AccessResourceRisk, Effect: AccessAllow, Condition: c}
+	}
+	require.NoError(t, (&AccessPolicy{Name: "p", Resource: AccessResourceEvidence, Effect: AccessDeny}).Validate(),
+		"no condition matches every record")
+	ok := base(&AccessCondition{All: []AccessCondition{
+		*in("asset.business_unit", "$user.business_unit"),
+		{Not: &AccessCondition{Attr: "classification", Op: AccessOpEmpty}},
+		*in("user.department", "Finance"),
+	}})
+	require.NoError(t, ok.Validate())
+
+	for name, p := range map[string]AccessPolicy{
+		"unknown resource":   {Name: "p", Resource: "asset", Effect: AccessAllow},
+		"unknown effect":     {Name: "p", Resource: AccessResourceRisk, Effect: "maybe"},
+		"unknown attribute":  base(in("collected_by", "x")),
+		"two forms":          base(&AccessCondition{Attr: "tags", Op: AccessOpIn, Values: []string{"a"}, Not: in("tags", "b")}),
+		"empty any":          base(&AccessCondition{Any: []AccessCondition{}}),
+		"in without values":  base(in("tags")),
+		"empty with values":  base(&AccessCondition{Attr: "tags", Op: AccessOpEmpty, Values: []string{"a"}}),
+		"bad user reference": base(in("owner_id", "$user.Not-A-Key")),
+		"unknown business role": {Name: "p", Resource: AccessResourceRisk, Effect: AccessAllow,
+			Subjects: AccessSubjects{BusinessRoles: []BusinessRoleKey{"janitor"}}},
+	} {
+		assert.Error(t, p.Validate(), name)
+	}
+
+	deep := in("tags", "a")
+	for i := 0; i < maxConditionDepth; i++ {
+		deep = &AccessCondition{Not: deep}
+	}
+	p := base(deep)
+	assert.Error(t, p.Validate(), "nesting is bounded")
+}
+
+func TestAccessCondition_JSONRoundTrip(t *testing.T) {
+	c := &AccessCondition{Any: []AccessCondition{
+		{Attr: "tags", Op: AccessOpIn, Values: []string{"confidential"}},
+		{Not: &AccessCondition{Attr: "framework", Op: AccessOpEmpty}},
+	}}
+	v, err := c.Value()
+	require.NoError(t, err)
+	var back AccessCondition
+	require.NoError(t, back.Scan(v))
+	assert.Equal(t, *c, back)
+}
+
+func TestAccessScope_Decide(t *testing.T) {
+	me := uuid.New()
+	confidential := AccessPolicy{ID: uuid.New(), Name: "confidential", Resource: AccessResourceRisk, Effect: AccessDeny, Enabled: true,
+		Condition: &AccessCondition{All: []AccessCondition{
+			{Attr: "tags", Op: AccessOpIn, Values: []string{"Confidential"}},
+			{Not: &AccessCondition{Attr: "owner_id", Op: AccessOpIn, Values: []string{"$user.id"}}},
+		}}}
+	myBU := AccessPolicy{ID: uuid.New(), Name: "my BU", Resource: AccessResourceRisk, Effect: AccessAllow, Enabled: true,
+		Subjects:  AccessSubjects{Attributes: map[string][]string{"business_unit": {"retail", "online"}}},
+		Condition: &AccessCondition{Attr: "asset.business_unit", Op: AccessOpIn, Values: []string{"$user.business_unit"}}}
+	scope := &AccessScope{
+		Subject:  AccessSubject{UserID: me, Role: RoleUser, Attributes: map[string][]string{"business_unit": {"Retail"}}},
+		Policies: []AccessPolicy{confidential, myBU},
+	}
+
+	d := scope.Decide(AccessResourceRisk, AccessRecord{"asset.business_unit": {"retail", "corporate"}})
+	assert.True(t, d.Visible, d.Reason)
+	assert.Len(t, d.Policies, 2)
+
+	d = scope.Decide(AccessResourceRisk, AccessRecord{"asset.business_unit": {"corporate"}})
+	assert.False(t, d.Visible, "an allow policy applies and none matches")
+
+	d = scope.Decide(AccessResourceRisk, AccessRecord{"asset.business_unit": {"retail"}, "tags": {"confidential"}})
+	assert.False(t, d.Visible, "deny wins over allow")
+	assert.Contains(t, d.Reason, "confidential")
+
+	d = scope.Decide(AccessResourceRisk, AccessRecord{"asset.business_unit": {"retail"}, "tags": {"confidential"}, "owner_id": {me.String()}})
+	assert.True(t, d.Visible, "the owner is carved out of the deny")
+
+	// The allow policy's subjects do not match a user without the attribute:
+	// only the deny applies, and everything else stays visible.
+	scope.Subject.Attributes = nil
+	d = scope.Decide(AccessResourceRisk, AccessRecord{"asset.business_unit": {"corporate"}})
+	assert.True(t, d.Visible, d.Reason)
+	assert.Len(t, d.Policies, 1)
+
+	assert.True(t, scope.Decide(AccessResourceEvidence, AccessRecord{}).Visible, "policies are per resource")
+	scope.Subject.Role = RoleAdmin
+	assert.True(t, scope.Decide(AccessResourceRisk, AccessRecord{"tags": {"confidential"}}).Visible, "administrators are exempt")
+	var none *AccessScope
+	assert.True(t, none.Decide(AccessResourceRisk, AccessRecord{}).Visible)
+}
+
+func TestValidateSubjectAttributes(t *testing.T) {
+	got, err := ValidateSubjectAttributes(map[string][]string{"audit_scope": {" iso ", "soc2", "iso", ""}})
+	require.NoError(t, err)
+	assert.Equal(t, AccessAttributeValues{"audit_scope": {"iso", "soc2"}}, got)
+
+	_, err = ValidateSubjectAttributes(map[string][]string{"department": {"x"}})
+	assert.Error(t, err, "built-in attributes come from the member, not from here")
+	_, err = ValidateSubjectAttributes(map[string][]string{"Audit Scope": {"x"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AIProviderSetting is an organisation's choice of LLM backend for the AI
+// assistant and the board report. Without a row the organisation uses the
+// server default (AI_PROVIDER, or Claude when ANTHROPIC_API_KEY is set).
+//
+// The setting exists for regulated customers that may not send risk data to an
+// external API: they point OpenRisk at a model they host (vLLM, llama.cpp
+// server, Ollama) or switch the LLM off entirely with the "template" provider.
+// Whatever the provider, replies are validated against the same schemas and a
+// malformed one falls back to the deterministic template.
+type AIProviderSetting struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:uq_ai_provider_settings_tenant" json:"tenant_id"`
+
+	// Provider is template|anthropic|openai_compatible (pkg/ai.Provider).
+	Provider string `gorm:"size:32;not null" json:"provider"`
+	// BaseURL is the OpenAI-compatible API root including /v1; unused for the
+	// other providers.
+	BaseURL string `gorm:"size:512" json:"base_url,omitempty"`
+	Model   string `gorm:"size:128" json:"model,omitempty"`
+	// EncryptedAPIKey is the AES-256-GCM ciphertext of the provider key; it is
+	// never returned by the API. Optional for openai_compatible.
+	EncryptedAPIKey string `gorm:"type:text" json:"-"`
+
+	// TimeoutSeconds bounds one completion (0 = provider default).
+	TimeoutSeconds int `gorm:"not null" json:"timeout_seconds"`
+	// MaxTokens caps the reply length (0 = provider default).
+	MaxTokens int `gorm:"not null" json:"max_tokens"`
+	// OutputMode is json_schema|json_object|prompt: how the server is asked for
+	// JSON. Empty means json_schema.
+	OutputMode string `gorm:"size:16" json:"output_mode,omitempty"`
+
+	UpdatedBy *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+
+	// Computed, NOT persisted.
+	HasAPIKey bool `gorm:"-" json:"has_api_key"`
+}
+
+// TableName pins the table name.
+func (AIProviderSetting) TableName() string { return "ai_provider_settings" }
+
+// AIProviderSettingRepository persists per-organisation provider settings.
+// Get returns (nil, nil) when the organisation has none.
+type AIProviderSettingRepository interface {
+	Get(ctx context.Context, tenantID uuid.UUID) (*AIProviderSetting, error)
+	Save(ctx context.Context, s *AIProviderSetting) error
+	Delete(ctx context.Context, tenantID uuid.UUID) error
+}
@@ -87,6 +87,13 @@ func ApprovalRequestTypes() []ApprovalRequestType {
 			Label: "Dispense de contrôle", LabelEN: "Control waiver",
 			Description: "Marquer un contrôle comme non applicable, avec justification.",
 		},
+		{
+			Key: "appetite_exception", EntityType: AppetiteExceptionEntityType, Action: AppetiteExceptionAction,
+			Label: "Dérogation à l'appétence au risque", LabelEN: "Risk appetite exception",
+			Description: "Maintenir ouvert un risque au-delà de l'appétence approuvée par le conseil, jusqu'à une date de fin.",
+			LinkedToLifecycle: "Requis pour faire passer à RESIDUAL_ACCEPTED un risque au-delà de l'appétence : " +
+				"sans dérogation approuvée et en cours de validité, la transition est refusée.",
+		},
 	}
 }
 
@@ -50,6 +50,10 @@ type Asset struct {
 	Type           string           `json:"type"` // Server, Laptop, Database, SaaS
 	Criticality    AssetCriticality `gorm:"default:'MEDIUM'" json:"criticality"`
 	Owner          string           `json:"owner"`
+	// BusinessUnit is the part of the organisation the asset belongs to. Free
+	// text, compared case-insensitively, like Risk.BusinessUnit; access
+	// policies use it to show a BU's managers the risks on its assets.
+	BusinessUnit string `gorm:"size:128;not null;default:'';index" json:"business_unit"`
 
 	// Relation Many-to-Many avec Risk
 	Risks []*Risk `gorm:"many2many:risk_assets;" json:"risks,omitempty"`
@@ -38,13 +38,16 @@ const (
 	CategoryCloud       AssetCategory = "cloud"
 	CategoryVendor      AssetCategory = "vendor"
 	CategoryData        AssetCategory = "data_processing"
+	// CategoryProcess is a business process — what a business impact analysis
+	// (see bia.go) is written for, and where recovery objectives start.
+	CategoryProcess AssetCategory = "business_process"
 )
 
 // AssetCategories is the ordered, complete list of supported categories. The
 // order is the one the UI renders.
 var AssetCategories = []AssetCategory{
 	CategoryServer, CategoryWorkstation, CategoryApplication, CategoryDatabase,
-	CategoryNetwork, CategoryCloud, CategoryVendor, CategoryData,
+	CategoryNetwork, CategoryCloud, CategoryVendor, CategoryData, CategoryProcess,
 }
 
 // ParseAssetCategory validates a category string. An empty value is NOT silently
@@ -63,6 +63,16 @@ func internetExposedDef() AttributeDef {
 	}
 }
 
+// crownJewelDef marks an asset as an attacker's end goal — the target set of
+// the attack-path analysis (see IsCrownJewel).
+func crownJewelDef() AttributeDef {
+	return AttributeDef{
+		Key: "crown_jewel", Label: "Actif joyau", LabelEN: "Crown jewel",
+		Type: AttrBoolean, Group: "Sécurité",
+		Help: "Actif dont la compromission est l'objectif d'un attaquant. Cible de l'analyse des chemins d'attaque.",
+	}
+}
+
 func cpesDef() AttributeDef {
 	return AttributeDef{
 		Key: "cpes", Label: "CPE (logiciels identifiés)", LabelEN: "CPE",
@@ -90,6 +100,7 @@ func DefaultAttributes(cat AssetCategory) []AttributeDef {
 			{Key: "physical_location", Label: "Localisation", LabelEN: "Location", Type: AttrString, Group: "Exploitation"},
 			{Key: "last_patched", Label: "Dernier correctif appliqué", LabelEN: "Last patched", Type: AttrDate, Group: "Exploitation"},
 			{Key: "backup_enabled", Label: "Sauvegardé", LabelEN: "Backed up", Type: AttrBoolean, Group: "Exploitation"},
+			crownJewelDef(),
 			cpesDef(),
 		}
 
@@ -107,6 +118,7 @@ func DefaultAttributes(cat AssetCategory) []AttributeDef {
 			{Key: "edr_installed", Label: "EDR installé", LabelEN: "EDR installed", Type: AttrBoolean, Group: "Sécurité"},
 			{Key: "mobile_device", Label: "Poste nomade", LabelEN: "Mobile device", Type: AttrBoolean, Group: "Sécurité"},
 			{Key: "last_seen", Label: "Dernière connexion", LabelEN: "Last seen", Type: AttrDate, Group: "Exploitation"},
+			crownJewelDef(),
 			cpesDef(),
 		}
 
@@ -126,6 +138,7 @@ func DefaultAttributes(cat AssetCategory) []AttributeDef {
 				Enum: []string{"public", "interne", "confidentiel", "secret"}},
 			{Key: "business_owner", Label: "Responsable métier", LabelEN: "Business owner", Type: AttrString, Group: "Rattachement"},
 			{Key: "source_repository", Label: "Dépôt de code", LabelEN: "Source repository", Type: AttrURL, Group: "Exploitation"},
+			crownJewelDef(),
 			cpesDef(),
 		}
 
@@ -144,6 +157,7 @@ func DefaultAttributes(cat AssetCategory) []AttributeDef {
 				Enum: []string{"aucune", "quotidienne", "hebdomadaire", "mensuelle", "continue"}},
 			{Key: "retention_days", Label: "Rétention (jours)", LabelEN: "Retention (days)", Type: AttrInteger, Group: "Conformité", Min: f(0)},
 			{Key: "record_count", Label: "Volume (enregistrements)", LabelEN: "Record count", Type: AttrInteger, Group: "Conformité", Min: f(0)},
+			crownJewelDef(),
 			cpesDef(),
 		}
 
@@ -162,6 +176,7 @@ func DefaultAttributes(cat AssetCategory) []AttributeDef {
 			{Key: "managed_subnets", Label: "Sous-réseaux gérés", LabelEN: "Managed subnets", Type: AttrStringList, Group: "Exposition"},
 			internetExposedDef(),
 			{Key: "end_of_support", Label: "Fin de support", LabelEN: "End of support", Type: AttrDate, Group: "Exploitation"},
+			crownJewelDef(),
 			cpesDef(),
 		}
 
@@ -180,6 +195,7 @@ func DefaultAttributes(cat AssetCategory) []AttributeDef {
 			internetExposedDef(),
 			{Key: "encryption_enabled", Label: "Chiffrement activé", LabelEN: "Encryption enabled", Type: AttrBoolean, Group: "Sécurité"},
 			{Key: "cloud_tags", Label: "Étiquettes cloud", LabelEN: "Cloud tags", Type: AttrStringList, Group: "Exploitation"},
+			crownJewelDef(),
 			cpesDef(),
 		}
 
@@ -219,6 +235,22 @@ func DefaultAttributes(cat AssetCategory) []AttributeDef {
 			{Key: "dpia_required", Label: "AIPD requise", LabelEN: "DPIA required", Type: AttrBoolean, Group: "Conformité"},
 			{Key: "dpia_completed", Label: "AIPD réalisée", LabelEN: "DPIA completed", Type: AttrBoolean, Group: "Conformité"},
 			{Key: "record_volume", Label: "Volume (personnes)", LabelEN: "Volume (data subjects)", Type: AttrInteger, Group: "Conformité", Min: f(0)},
+			crownJewelDef(),
+		}
+
+	case CategoryProcess:
+		// The recovery objectives themselves live in the process's business
+		// impact analysis, not here: they come with an impact curve and drive a
+		// propagation, which a flat attribute cannot carry.
+		return []AttributeDef{
+			{Key: "process_owner", Label: "Responsable du processus", LabelEN: "Process owner", Type: AttrString, Required: true, Group: "Rattachement"},
+			{Key: "department", Label: "Direction / service", LabelEN: "Department", Type: AttrString, Group: "Rattachement"},
+			{Key: "description", Label: "Description", LabelEN: "Description", Type: AttrText, Group: "Identité"},
+			{Key: "peak_periods", Label: "Périodes critiques", LabelEN: "Peak periods", Type: AttrStringList, Group: "Continuité",
+				Help: "Clôture mensuelle, paie, soldes… Une interruption à ces dates pèse plus lourd."},
+			{Key: "manual_workaround", Label: "Mode dégradé manuel", LabelEN: "Manual workaround", Type: AttrBoolean, Group: "Continuité"},
+			{Key: "regulatory_obligation", Label: "Obligation réglementaire", LabelEN: "Regulatory obligation", Type: AttrBoolean, Group: "Conformité"},
+			crownJewelDef(),
 		}
 	}
 	return nil
@@ -243,6 +275,8 @@ func DefaultCategoryLabel(cat AssetCategory) string {
 		return "Fournisseur / tiers"
 	case CategoryData:
 		return "Données / traitement"
+	case CategoryProcess:
+		return "Processus métier"
 	}
 	return string(cat)
 }
@@ -28,10 +28,11 @@ func TestDefaultSchemas_AreValid(t *testing.T) {
 	}
 }
 
-// The 8 categories the spec names must all exist and be parseable.
+// The 8 categories the spec names, plus the business process the BIA starts
+// from, must all exist and be parseable.
 func TestAssetCategories_Complete(t *testing.T) {
 	want := []string{"server", "workstation", "application", "database",
-		"network", "cloud", "vendor", "data_processing"}
+		"network", "cloud", "vendor", "data_processing", "business_process"}
 	if len(AssetCategories) != len(want) {
 		t.Fatalf("expected %d categories, got %d", len(want), len(AssetCategories))
 	}
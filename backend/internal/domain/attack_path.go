@@ -0,0 +1,575 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ---------------------------------------------------------------------------
+// Attack-path analysis.
+//
+// BuildCompromiseChain answers "this asset fell — what else?" with an
+// unweighted BFS: every reachable asset counts the same. That is the right
+// answer for blast radius and the wrong one for remediation planning, where
+// the question is "which way in would an attacker actually take?". Here the
+// same graph is walked from the internet-exposed assets to the crown jewels,
+// and each hop costs what it would cost to compromise the next asset: cheap
+// when it carries a known-exploited vulnerability, expensive when it carries
+// nothing exploitable at all.
+//
+// The costs are a relative ordering, not a probability. Two paths of cost 4
+// and 12 say "the first is the one to fix first", not "the first is three
+// times likelier". Treating them as more than that would be false precision.
+// ---------------------------------------------------------------------------
+
+// Defaults and bounds for BuildAttackPaths.
+const (
+	DefaultAttackPathK = 10
+	MaxAttackPathK     = 50
+	// DefaultAttackPathMaxHops bounds path length. Real intrusions that matter
+	// are a handful of pivots long; beyond this a "path" is an artefact of a
+	// densely connected graph rather than a route anyone would take.
+	DefaultAttackPathMaxHops = 8
+)
+
+// CrownJewelTags are the tag values that mark an asset as a crown jewel when it
+// is tagged rather than flagged — the same spelling the scanner normaliser
+// already treats as high-value.
+var CrownJewelTags = []string{"crown-jewel", "crown_jewel", "crownjewel"}
+
+// IsCrownJewel reports whether an asset is an attacker's end goal: the
+// `crown_jewel` attribute is true, or one of its tag lists carries a
+// CrownJewelTags value. Criticality alone does not qualify — CRITICAL is set
+// generously, and a path analysis ending at half the estate says nothing.
+func IsCrownJewel(a *Asset) bool {
+	if a == nil || a.Attributes == nil {
+		return false
+	}
+	if b, ok := a.Attributes["crown_jewel"].(bool); ok && b {
+		return true
+	}
+	for _, key := range []string{"tags", "cloud_tags"} {
+		for _, tag := range attrStringList(a.Attributes, key) {
+			t := strings.ToLower(strings.TrimSpace(tag))
+			for _, want := range CrownJewelTags {
+				if t == want {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// attrStringList reads a string-list attribute, which arrives as []string when
+// built in code and []any once it has been through JSON.
+func attrStringList(attrs AssetAttributes, key string) []string {
+	switch v := attrs[key].(type) {
+	case []string:
+		return v
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// AssetExploitability summarises an asset's OPEN vulnerabilities as the path
+// engine needs them: the strongest exploitation signal present, not a list.
+type AssetExploitability struct {
+	OpenVulns int     `json:"open_vulns"`
+	KEV       bool    `json:"kev"`
+	MaxEPSS   float64 `json:"max_epss"`
+	// BestTier is the strongest priority tier among the open vulnerabilities
+	// (P1 strongest), empty when none has been tiered.
+	BestTier string `json:"best_tier,omitempty"`
+}
+
+// tierEase maps a priority tier onto the same 0..1 scale as EPSS. The tiers
+// already fold CVSS, EPSS, KEV and asset criticality (see the prioritisation
+// engine), so they are trusted as a floor rather than recomputed here.
+var tierEase = map[string]float64{"P1": 0.9, "P2": 0.7, "P3": 0.4, "P4": 0.2}
+
+// Ease is how easy the asset is to compromise, 0 (nothing exploitable known)
+// to 1 (a known-exploited vulnerability is open on it). The strongest signal
+// wins: one KEV entry is enough for an attacker, however clean the rest is.
+func (e AssetExploitability) Ease() float64 {
+	if e.OpenVulns == 0 {
+		return 0
+	}
+	if e.KEV {
+		return 1
+	}
+	ease := tierEase[strings.ToUpper(strings.TrimSpace(e.BestTier))]
+	if e.MaxEPSS > ease {
+		ease = e.MaxEPSS
+	}
+	return math.Min(math.Max(ease, 0), 1)
+}
+
+// compromiseCost turns ease into the cost of taking the asset: 1 for a KEV,
+// 10 for an asset with nothing exploitable known. Never zero — an asset with
+// no open vulnerabilities is still reachable through stolen credentials or a
+// misconfiguration the register does not know about, just not cheaply.
+func compromiseCost(e AssetExploitability) float64 {
+	return 1 + 9*(1-e.Ease())
+}
+
+// attackEdgeFactor weighs a hop by how directly the relation hands an attacker
+// the next asset. Keyed on the STORED type, not the folded one: runs_on and
+// authenticates_via fold onto different legends for good reasons, and they
+// are very different pivots.
+//
+//   - connects_to, stores_data_in, processes_data_of: the source already
+//     holds a network path or the credentials to the target — 1.0.
+//   - depends_on, authenticates_via: a trust relation the attacker must
+//     abuse rather than simply follow — 1.25.
+//   - runs_on, hosted_on, hosted_by, backs_up_to: a workload-to-host escape or
+//     reaching into a backup system — 1.5.
+//   - managed_by: pivoting into the party that operates the asset — 2.0.
+func attackEdgeFactor(t DependencyType) float64 {
+	switch t {
+	case DepConnectsTo, DepStoresDataIn, DepProcessesDataOf:
+		return 1.0
+	case DepRunsOn, DepHostedOn, DepHostedBy, DepBacksUpTo:
+		return 1.5
+	case DepManagedBy:
+		return 2.0
+	default:
+		return 1.25
+	}
+}
+
+// AttackPathHop is one asset on a path, with what it cost to reach.
+type AttackPathHop struct {
+	AssetID uuid.UUID `json:"asset_id"`
+	Name    string    `json:"name"`
+	// EdgeID / EdgeType are the dependency walked INTO this asset; nil on the
+	// entry point, which is reached from the internet rather than an edge.
+	EdgeID   *uuid.UUID     `json:"edge_id,omitempty"`
+	EdgeType DependencyType `json:"edge_type,omitempty"`
+	// Cost is this hop's share of the path cost.
+	Cost           float64             `json:"cost"`
+	Exploitability AssetExploitability `json:"exploitability"`
+}
+
+// AttackPath is one route from an internet-exposed asset to a crown jewel.
+// Node and edge ids are the topology's own, so the view highlights a path by
+// id without a second lookup.
+type AttackPath struct {
+	Rank     int             `json:"rank"`
+	Cost     float64         `json:"cost"`
+	EntryID  uuid.UUID       `json:"entry_id"`
+	TargetID uuid.UUID       `json:"target_id"`
+	Hops     []AttackPathHop `json:"hops"`
+	EdgeIDs  []uuid.UUID     `json:"edge_ids"`
+}
+
+// ChokePoint is an asset many of the returned paths go through. Fixing its
+// exploitable vulnerabilities raises the cost of every one of them at once,
+// which is what makes it the better remediation target than the single
+// highest-CVSS finding elsewhere.
+type ChokePoint struct {
+	AssetID        uuid.UUID           `json:"asset_id"`
+	Name           string              `json:"name"`
+	Paths          int                 `json:"paths"`
+	Share          float64             `json:"share"` // Paths / len(returned paths)
+	EntryPoint     bool                `json:"entry_point"`
+	Exploitability AssetExploitability `json:"exploitability"`
+}
+
+// AttackPathOptions tunes BuildAttackPaths. Zero values take the defaults.
+type AttackPathOptions struct {
+	K       int
+	MaxHops int
+}
+
+// AttackPathAnalysis is the whole answer for a tenant.
+type AttackPathAnalysis struct {
+	Paths       []AttackPath `json:"paths"`
+	ChokePoints []ChokePoint `json:"choke_points"`
+	EntryPoints int          `json:"entry_points"`
+	CrownJewels int          `json:"crown_jewels"`
+	K           int          `json:"k"`
+	MaxHops     int          `json:"max_hops"`
+}
+
+// attackGraph is the weighted, simplified graph the search runs over. Parallel
+// edges between the same pair collapse onto the cheapest one: an attacker takes
+// the easier of two relations, and keeping both would make Yen return the same
+// route twice.
+type attackGraph struct {
+	adj    map[uuid.UUID][]attackEdge
+	entry  map[uuid.UUID]bool
+	target map[uuid.UUID]bool
+	cost   map[uuid.UUID]float64
+}
+
+type attackEdge struct {
+	to     uuid.UUID
+	id     uuid.UUID
+	typ    DependencyType
+	weight float64
+}
+
+// BuildAttackPaths finds the k cheapest simple paths from any internet-exposed
+// asset to any crown jewel, following dependency edges FORWARDS (see
+// BuildCompromiseChain for why forwards is the attacker's direction).
+//
+// Entering an asset costs compromiseCost(asset) × attackEdgeFactor(edge); the
+// entry point costs its compromiseCost alone. The k paths come from Yen's
+// algorithm over a virtual source feeding every entry point and a virtual sink
+// fed by every crown jewel, so "top k" ranks across all entry/target pairs
+// rather than k per pair.
+//
+// Choke points are counted over the returned paths, excluding each path's own
+// crown jewel: the jewel is on every path to it by definition, and "protect
+// the thing you are protecting" is not advice.
+func BuildAttackPaths(assets []Asset, deps []AssetDependency, exploit map[uuid.UUID]AssetExploitability, opts AttackPathOptions) AttackPathAnalysis {
+	if opts.K <= 0 {
+		opts.K = DefaultAttackPathK
+	}
+	if opts.K > MaxAttackPathK {
+		opts.K = MaxAttackPathK
+	}
+	if opts.MaxHops <= 0 {
+		opts.MaxHops = DefaultAttackPathMaxHops
+	}
+
+	names := make(map[uuid.UUID]string, len(assets))
+	g := attackGraph{
+		adj:    map[uuid.UUID][]attackEdge{},
+		entry:  map[uuid.UUID]bool{},
+		target: map[uuid.UUID]bool{},
+		cost:   make(map[uuid.UUID]float64, len(assets)),
+	}
+	for i := range assets {
+		a := &assets[i]
+		names[a.ID] = a.Name
+		g.cost[a.ID] = compromiseCost(exploit[a.ID])
+		if IsInternetExposed(a) {
+			g.entry[a.ID] = true
+		}
+		if IsCrownJewel(a) {
+			g.target[a.ID] = true
+		}
+	}
+	best := map[[2]uuid.UUID]attackEdge{}
+	for _, d := range deps {
+		if d.SourceAssetID == d.TargetAssetID {
+			continue
+		}
+		// An edge to an asset outside the list (deleted, or another tenant's
+		// id that slipped in) is not walkable.
+		if _, ok := g.cost[d.SourceAssetID]; !ok {
+			continue
+		}
+		toCost, ok := g.cost[d.TargetAssetID]
+		if !ok {
+			continue
+		}
+		e := attackEdge{to: d.TargetAssetID, id: d.ID, typ: d.Type, weight: attackEdgeFactor(d.Type) * toCost}
+		key := [2]uuid.UUID{d.SourceAssetID, d.TargetAssetID}
+		if cur, ok := best[key]; !ok || e.weight < cur.weight ||
+			(e.weight == cur.weight && e.id.String() < cur.id.String()) {
+			best[key] = e
+		}
+	}
+	for key, e := range best {
+		g.adj[key[0]] = append(g.adj[key[0]], e)
+	}
+	// Deterministic neighbour order, so equal-cost ties resolve the same way
+	// on every call and the view does not reshuffle between refreshes.
+	for src := range g.adj {
+		sort.Slice(g.adj[src], func(i, j int) bool {
+			return g.adj[src][i].to.String() < g.adj[src][j].to.String()
+		})
+	}
+
+	out := AttackPathAnalysis{
+		Paths:       []AttackPath{},
+		ChokePoints: []ChokePoint{},
+		EntryPoints: len(g.entry),
+		CrownJewels: len(g.target),
+		K:           opts.K,
+		MaxHops:     opts.MaxHops,
+	}
+	if len(g.entry) == 0 || len(g.target) == 0 {
+		return out
+	}
+
+	for i, p := range g.kShortest(opts.K, opts.MaxHops) {
+		path := AttackPath{
+			Rank:     i + 1,
+			Cost:     round2(p.cost),
+			EntryID:  p.nodes[0],
+			TargetID: p.nodes[len(p.nodes)-1],
+			Hops:     make([]AttackPathHop, len(p.nodes)),
+			EdgeIDs:  make([]uuid.UUID, 0, len(p.edges)),
+		}
+		for j, n := range p.nodes {
+			hop := AttackPathHop{AssetID: n, Name: names[n], Exploitability: exploit[n], Cost: round2(g.cost[n])}
+			if j > 0 {
+				e := p.edges[j-1]
+				id := e.id
+				hop.EdgeID, hop.EdgeType, hop.Cost = &id, e.typ, round2(e.weight)
+				path.EdgeIDs = append(path.EdgeIDs, id)
+			}
+			path.Hops[j] = hop
+		}
+		out.Paths = append(out.Paths, path)
+	}
+
+	counts := map[uuid.UUID]int{}
+	for _, p := range out.Paths {
+		for _, h := range p.Hops {
+			if h.AssetID != p.TargetID {
+				counts[h.AssetID]++
+			}
+		}
+	}
+	for id, n := range counts {
+		out.ChokePoints = append(out.ChokePoints, ChokePoint{
+			AssetID: id, Name: names[id], Paths: n,
+			Share:          round2(float64(n) / float64(len(out.Paths))),
+			EntryPoint:     g.entry[id],
+			Exploitability: exploit[id],
+		})
+	}
+	// Most paths first; among equals the most exploitable, because that is
+	// the one a patch actually moves.
+	sort.Slice(out.ChokePoints, func(i, j int) bool {
+		a, b := out.ChokePoints[i], out.ChokePoints[j]
+		if a.Paths != b.Paths {
+			return a.Paths > b.Paths
+		}
+		if ea, eb := a.Exploitability.Ease(), b.Exploitability.Ease(); ea != eb {
+			return ea > eb
+		}
+		return a.AssetID.String() < b.AssetID.String()
+	})
+	return out
+}
+
+func round2(v float64) float64 { return math.Round(v*100) / 100 }
+
+// graphPath is a path in the attack graph: nodes[0] is an entry point,
+// edges[i] leads from nodes[i] to nodes[i+1].
+type graphPath struct {
+	nodes []uuid.UUID
+	edges []attackEdge
+	cost  float64
+}
+
+func (p graphPath) key() string {
+	var b strings.Builder
+	for _, n := range p.nodes {
+		b.WriteString(n.String())
+	}
+	return b.String()
+}
+
+// kShortest is Yen's algorithm. The virtual source is implicit: the first hop
+// of every path is an entry point, costed at its compromise cost. Spur paths
+// rooted at the virtual source are simply "start from a different entry point",
+// which shortest handles by excluding the blocked entries.
+func (g *attackGraph) kShortest(k, maxHops int) []graphPath {
+	first, ok := g.shortest(nil, nil, nil, maxHops)
+	if !ok {
+		return nil
+	}
+	found := []graphPath{first}
+	seen := map[string]bool{first.key(): true}
+	var candidates []graphPath
+
+	for len(found) < k {
+		prev := found[len(found)-1]
+		// Spur from the virtual source (i = -1) and from every node but the last.
+		for i := -1; i < len(prev.nodes)-1; i++ {
+			rootNodes := prev.nodes[:i+1]
+			blockedEdges := map[[2]uuid.UUID]bool{}
+			blockedEntries := map[uuid.UUID]bool{}
+			for _, p := range found {
+				if len(p.nodes) <= i+1 || !samePrefix(p.nodes, rootNodes) {
+					continue
+				}
+				if i < 0 {
+					blockedEntries[p.nodes[0]] = true
+				} else {
+					blockedEdges[[2]uuid.UUID{p.nodes[i], p.nodes[i+1]}] = true
+				}
+			}
+			blockedNodes := map[uuid.UUID]bool{}
+			for _, n := range rootNodes[:max(len(rootNodes)-1, 0)] {
+				blockedNodes[n] = true
+			}
+
+			var root graphPath
+			if i >= 0 {
+				root = graphPath{nodes: append([]uuid.UUID(nil), rootNodes...), edges: append([]attackEdge(nil), prev.edges[:i]...)}
+				root.cost = g.cost[root.nodes[0]]
+				for _, e := range root.edges {
+					root.cost += e.weight
+				}
+			}
+			spur, ok := g.shortest(&root, blockedNodes, func(from uuid.UUID, e attackEdge) bool {
+				if from == uuid.Nil {
+					return blockedEntries[e.to]
+				}
+				return blockedEdges[[2]uuid.UUID{from, e.to}]
+			}, maxHops)
+			if !ok || seen[spur.key()] {
+				continue
+			}
+			seen[spur.key()] = true
+			candidates = append(candidates, spur)
+		}
+		if len(candidates) == 0 {
+			break
+		}
+		sort.SliceStable(candidates, func(a, b int) bool {
+			if candidates[a].cost != candidates[b].cost {
+				return candidates[a].cost < candidates[b].cost
+			}
+			if len(candidates[a].nodes) != len(candidates[b].nodes) {
+				return len(candidates[a].nodes) < len(candidates[b].nodes)
+			}
+			return candidates[a].key() < candidates[b].key()
+		})
+		found = append(found, candidates[0])
+		candidates = candidates[1:]
+	}
+	return found
+}
+
+func samePrefix(nodes, prefix []uuid.UUID) bool {
+	if len(nodes) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if nodes[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// shortest is Dijkstra from root's last node (or from the virtual source when
+// root is empty) to the nearest crown jewel, avoiding blocked nodes and any
+// edge blocked() refuses. It returns the FULL path, root included. Every
+// weight is positive, so the first crown jewel popped is the cheapest arrival.
+//
+// maxHops is a pruning bound, not an exact hop-constrained search: a node is
+// settled once, on its cheapest arrival, so a cheap-but-long detour can hide a
+// dearer route that would have fitted. At the default bound that trade buys a
+// search that stays linear-ish on a 2 000-asset estate, and the paths it drops
+// are the unlikely ones.
+func (g *attackGraph) shortest(root *graphPath, blockedNodes map[uuid.UUID]bool, blocked func(from uuid.UUID, e attackEdge) bool, maxHops int) (graphPath, bool) {
+	pq := &labelHeap{}
+	push := func(l *pathLabel) { heap.Push(pq, l) }
+
+	onRoot := map[uuid.UUID]bool{}
+	var start *pathLabel
+	if root != nil && len(root.nodes) > 0 {
+		for _, n := range root.nodes {
+			onRoot[n] = true
+		}
+		start = &pathLabel{node: root.nodes[len(root.nodes)-1], cost: root.cost, hops: len(root.nodes)}
+		push(start)
+	} else {
+		// Virtual source: one edge per entry point.
+		for id := range g.entry {
+			if blockedNodes[id] || (blocked != nil && blocked(uuid.Nil, attackEdge{to: id})) {
+				continue
+			}
+			push(&pathLabel{node: id, cost: g.cost[id], hops: 1})
+		}
+	}
+
+	settled := map[uuid.UUID]bool{}
+	for pq.Len() > 0 {
+		cur := heap.Pop(pq).(*pathLabel)
+		if settled[cur.node] {
+			continue
+		}
+		settled[cur.node] = true
+		if g.target[cur.node] {
+			var rev []*pathLabel
+			for l := cur; l != nil && l != start; l = l.prev {
+				rev = append(rev, l)
+			}
+			p := graphPath{cost: cur.cost}
+			if root != nil {
+				p.nodes = append(p.nodes, root.nodes...)
+				p.edges = append(p.edges, root.edges...)
+			}
+			for i := len(rev) - 1; i >= 0; i-- {
+				if rev[i].prev != nil {
+					p.edges = append(p.edges, rev[i].via)
+				}
+				p.nodes = append(p.nodes, rev[i].node)
+			}
+			return p, true
+		}
+		if cur.hops >= maxHops {
+			continue
+		}
+		for _, e := range g.adj[cur.node] {
+			if settled[e.to] || blockedNodes[e.to] || onRoot[e.to] {
+				continue
+			}
+			if blocked != nil && blocked(cur.node, e) {
+				continue
+			}
+			push(&pathLabel{node: e.to, cost: cur.cost + e.weight, hops: cur.hops + 1, prev: cur, via: e})
+		}
+	}
+	return graphPath{}, false
+}
+
+// pathLabel is one tentative arrival in the search; prev/via rebuild the path.
+type pathLabel struct {
+	node uuid.UUID
+	cost float64
+	hops int
+	prev *pathLabel
+	via  attackEdge
+}
+
+// labelHeap orders by cost, then hop count, then node id — the last two only
+// so that ties break the same way every time.
+type labelHeap []*pathLabel
+
+func (h labelHeap) Len() int { return len(h) }
+func (h labelHeap) Less(i, j int) bool {
+	if h[i].cost != h[j].cost {
+		return h[i].cost < h[j].cost
+	}
+	if h[i].hops != h[j].hops {
+		return h[i].hops < h[j].hops
+	}
+	return h[i].node.String() < h[j].node.String()
+}
+func (h labelHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *labelHeap) Push(x any)   { *h = append(*h, x.(*pathLabel)) }
+func (h *labelHeap) Pop() any {
+	old := *h
+	n := len(old)
+	it := old[n-1]
+	*h = old[:n-1]
+	return it
+}
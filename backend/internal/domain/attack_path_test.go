@@ -0,0 +1,141 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func pathAsset(name string, attrs AssetAttributes) Asset {
+	return Asset{ID: uuid.New(), Name: name, Attributes: attrs}
+}
+
+func TestIsCrownJewel(t *testing.T) {
+	if !IsCrownJewel(&Asset{Attributes: AssetAttributes{"crown_jewel": true}}) {
+		t.Error("crown_jewel=true must qualify")
+	}
+	if !IsCrownJewel(&Asset{Attributes: AssetAttributes{"cloud_tags": []any{"prod", "Crown-Jewel"}}}) {
+		t.Error("a crown-jewel tag must qualify, whatever its case")
+	}
+	if IsCrownJewel(&Asset{Criticality: CriticalityCritical}) {
+		t.Error("criticality alone must not qualify")
+	}
+}
+
+func TestAssetExploitability_Ease(t *testing.T) {
+	if (AssetExploitability{}).Ease() != 0 {
+		t.Error("no open vulnerability means nothing exploitable")
+	}
+	if (AssetExploitability{OpenVulns: 3, KEV: true, MaxEPSS: 0.01}).Ease() != 1 {
+		t.Error("one KEV entry dominates")
+	}
+	if e := (AssetExploitability{OpenVulns: 1, MaxEPSS: 0.2, BestTier: "P2"}).Ease(); e != 0.7 {
+		t.Errorf("the tier floors a low EPSS, got %v", e)
+	}
+	if e := (AssetExploitability{OpenVulns: 1, MaxEPSS: 0.95, BestTier: "P4"}).Ease(); e != 0.95 {
+		t.Errorf("a high EPSS beats a weak tier, got %v", e)
+	}
+}
+
+// Two ways from the web front to the database: through an API server carrying
+// a KEV, or through a clean batch host. The KEV route must rank first even
+// though both are the same length, and the web front and the API server are
+// the choke points.
+func TestBuildAttackPaths_RanksByExploitabilityAndFindsChokePoints(t *testing.T) {
+	web := pathAsset("web", AssetAttributes{"internet_exposed": true})
+	api := pathAsset("api", nil)
+	batch := pathAsset("batch", nil)
+	vault := pathAsset("vault", nil)
+	db := pathAsset("db", AssetAttributes{"crown_jewel": true})
+	deps := []AssetDependency{
+		edge(web.ID, api.ID, DepConnectsTo),
+		edge(web.ID, batch.ID, DepConnectsTo),
+		edge(api.ID, db.ID, DepStoresDataIn),
+		edge(batch.ID, db.ID, DepStoresDataIn),
+		edge(api.ID, vault.ID, DepAuthenticatesVia),
+		edge(vault.ID, db.ID, DepConnectsTo),
+	}
+	exploit := map[uuid.UUID]AssetExploitability{
+		web.ID: {OpenVulns: 1, MaxEPSS: 0.5},
+		api.ID: {OpenVulns: 2, KEV: true},
+	}
+
+	res := BuildAttackPaths([]Asset{web, api, batch, vault, db}, deps, exploit, AttackPathOptions{K: 5})
+	if res.EntryPoints != 1 || res.CrownJewels != 1 {
+		t.Fatalf("expected 1 entry and 1 jewel, got %d/%d", res.EntryPoints, res.CrownJewels)
+	}
+	if len(res.Paths) != 3 {
+		t.Fatalf("expected the 3 simple paths, got %d", len(res.Paths))
+	}
+	first := res.Paths[0]
+	if len(first.Hops) != 3 || first.Hops[1].AssetID != api.ID || first.TargetID != db.ID {
+		t.Fatalf("the KEV route must rank first, got %+v", first.Hops)
+	}
+	// web 5.5 + api 1×1.0 + db 10×1.0
+	if first.Cost != 16.5 {
+		t.Errorf("unexpected cost %v", first.Cost)
+	}
+	if first.Hops[0].EdgeID != nil || len(first.EdgeIDs) != 2 {
+		t.Errorf("the entry hop has no edge, the rest do: %+v", first)
+	}
+	for i := 1; i < len(res.Paths); i++ {
+		if res.Paths[i].Cost < res.Paths[i-1].Cost {
+			t.Fatalf("paths must come cheapest first")
+		}
+	}
+
+	if len(res.ChokePoints) == 0 || res.ChokePoints[0].AssetID != web.ID || res.ChokePoints[0].Paths != 3 {
+		t.Fatalf("the entry point is on every path, got %+v", res.ChokePoints)
+	}
+	if !res.ChokePoints[0].EntryPoint {
+		t.Error("the web front is an entry point")
+	}
+	if res.ChokePoints[1].AssetID != api.ID || res.ChokePoints[1].Paths != 2 {
+		t.Errorf("the api server is on two paths, got %+v", res.ChokePoints[1])
+	}
+	for _, cp := range res.ChokePoints {
+		if cp.AssetID == db.ID {
+			t.Error("the crown jewel itself is not a choke point")
+		}
+	}
+}
+
+// Paths follow dependencies forwards only: a jewel that depends on the exposed
+// asset is not reachable from it.
+func TestBuildAttackPaths_DirectionCyclesAndHopBound(t *testing.T) {
+	web := pathAsset("web", AssetAttributes{"internet_exposed": true})
+	a := pathAsset("a", nil)
+	b := pathAsset("b", nil)
+	jewel := pathAsset("jewel", AssetAttributes{"crown_jewel": true})
+
+	back := BuildAttackPaths([]Asset{web, jewel}, []AssetDependency{edge(jewel.ID, web.ID, DepDependsOn)}, nil, AttackPathOptions{})
+	if len(back.Paths) != 0 {
+		t.Fatalf("a backwards edge is not an attack path, got %+v", back.Paths)
+	}
+
+	deps := []AssetDependency{
+		edge(web.ID, a.ID, DepConnectsTo),
+		edge(a.ID, web.ID, DepConnectsTo), // cycle
+		edge(a.ID, b.ID, DepConnectsTo),
+		edge(b.ID, jewel.ID, DepConnectsTo),
+	}
+	all := []Asset{web, a, b, jewel}
+	if res := BuildAttackPaths(all, deps, nil, AttackPathOptions{K: 10}); len(res.Paths) != 1 || len(res.Paths[0].Hops) != 4 {
+		t.Fatalf("the cycle must not produce extra paths, got %+v", res.Paths)
+	}
+	if res := BuildAttackPaths(all, deps, nil, AttackPathOptions{MaxHops: 3}); len(res.Paths) != 0 {
+		t.Fatalf("a 4-asset path exceeds max_hops=3, got %+v", res.Paths)
+	}
+}
+
+func TestBuildAttackPaths_NoEntryOrNoJewel(t *testing.T) {
+	web := pathAsset("web", AssetAttributes{"internet_exposed": true})
+	db := pathAsset("db", nil)
+	res := BuildAttackPaths([]Asset{web, db}, []AssetDependency{edge(web.ID, db.ID, DepConnectsTo)}, nil, AttackPathOptions{})
+	if res.CrownJewels != 0 || res.Paths == nil || res.ChokePoints == nil {
+		t.Fatalf("no jewel must yield empty arrays, not null: %+v", res)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---------------------------------------------------------------------------
+// External anchoring of the audit chain.
+//
+// The hash chain proves the trail is internally consistent, but someone with
+// write access to the database can rewrite every entry and recompute every
+// hash. A checkpoint pins the chain head at a moment in time outside the
+// database: the head hash is signed with the deployment key and, when a
+// timestamp authority is configured, countersigned by it (RFC 3161). Once a
+// checkpoint has left the system — published, delivered by webhook, filed by an
+// auditor — a rewrite of anything at or before its sequence no longer matches
+// it, and a timestamped checkpoint cannot be re-issued with an earlier date.
+// ---------------------------------------------------------------------------
+
+// AuditCheckpointFormat names the signed payload layout. A new layout gets a
+// new name; verifiers refuse one they do not know.
+const AuditCheckpointFormat = "openrisk.audit-checkpoint.v1"
+
+// AuditCheckpoint is a signed statement of a tenant's chain head.
+type AuditCheckpoint struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_audit_checkpoint_tenant_seq,priority:1" json:"tenant_id"`
+	Sequence int64     `gorm:"not null;uniqueIndex:idx_audit_checkpoint_tenant_seq,priority:2" json:"sequence"`
+	HeadHash string    `gorm:"type:varchar(64);not null" json:"head_hash"`
+	// SignedAt is part of the signed payload.
+	SignedAt  time.Time `gorm:"not null" json:"signed_at"`
+	Algorithm string    `gorm:"type:varchar(16);not null" json:"algorithm"`
+	KeyID     string    `gorm:"type:varchar(32);not null" json:"key_id"`
+	// Signature is the base64 signature over SignedPayload.
+	Signature string `gorm:"type:text;not null" json:"signature"`
+
+	// The RFC 3161 countersignature over SHA-256(signature), when a TSA is
+	// configured. TimestampError keeps why a configured TSA gave none.
+	TSAURL         string     `gorm:"column:tsa_url;type:text;not null;default:''" json:"tsa_url,omitempty"`
+	TimestampToken []byte     `json:"timestamp_token,omitempty"`
+	TimestampedAt  *time.Time `json:"timestamped_at,omitempty"`
+	TimestampError string     `gorm:"type:text;not null;default:''" json:"timestamp_error,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (AuditCheckpoint) TableName() string { return "audit_checkpoints" }
+
+// SignedPayload is the exact byte sequence the signature covers. Like
+// AuditEvent.CanonicalPayload, its field order is fixed forever.
+func (c *AuditCheckpoint) SignedPayload() []byte {
+	var b strings.Builder
+	write := func(k, v string) {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(v)
+		b.WriteByte('\n')
+	}
+	b.WriteString(AuditCheckpointFormat)
+	b.WriteByte('\n')
+	write("tenant", c.TenantID.String())
+	write("seq", strconv.FormatInt(c.Sequence, 10))
+	write("head", c.HeadHash)
+	write("at", c.SignedAt.UTC().Format(time.RFC3339Nano))
+	write("key", c.KeyID)
+	return []byte(b.String())
+}
+
+// AuditChainHead is the last entry of a tenant's chain.
+type AuditChainHead struct {
+	TenantID uuid.UUID
+	Sequence int64
+	Hash     string
+}
+
+// AuditCheckpointRepository stores checkpoints and reads the chain ranges they
+// are checked against. Checkpoints are append-only, like the events.
+type AuditCheckpointRepository interface {
+	// Create stores c; false when the tenant already has a checkpoint at that
+	// sequence (another replica got there first).
+	Create(ctx context.Context, c *AuditCheckpoint) (bool, error)
+	// List returns the tenant's latest checkpoints, newest first.
+	List(ctx context.Context, tenantID uuid.UUID, limit int) ([]AuditCheckpoint, error)
+	// InRange returns the checkpoints whose sequence lies in [from, to],
+	// oldest first.
+	InRange(ctx context.Context, tenantID uuid.UUID, from, to int64) ([]AuditCheckpoint, error)
+	// Head returns the tenant's chain head (nil when the chain is empty).
+	Head(ctx context.Context, tenantID uuid.UUID) (*AuditChainHead, error)
+	// DueHeads returns the heads of tenants whose chain has grown past their
+	// latest checkpoint, across every tenant.
+	DueHeads(ctx context.Context, limit int) ([]AuditChainHead, error)
+	// EventsInRange returns the tenant's events with sequence in [from, to],
+	// ordered by sequence.
+	EventsInRange(ctx context.Context, tenantID uuid.UUID, from, to int64) ([]AuditEvent, error)
+}
@@ -46,6 +46,10 @@ const (
 	TriggerRiskScoreUpdated AutomationTrigger = "risk_score_updated"
 	// TriggerIncidentCreated fires when an incident is opened.
 	TriggerIncidentCreated AutomationTrigger = "incident_created"
+	// TriggerKRIThresholdCrossed fires when a key risk indicator's latest value
+	// moves it into another green/amber/red band. Severity is the new band
+	// (red = high, amber = medium, green = low); RiskID is its first linked risk.
+	TriggerKRIThresholdCrossed AutomationTrigger = "kri_threshold_crossed"
 	// TriggerManual is a rule only ever run on explicit user request (test/dry-run).
 	TriggerManual AutomationTrigger = "manual"
 )
@@ -54,7 +58,7 @@ const (
 func ParseAutomationTrigger(s string) (AutomationTrigger, error) {
 	switch AutomationTrigger(s) {
 	case TriggerVulnerabilityDetected, TriggerRiskCreated, TriggerRiskScoreUpdated,
-		TriggerIncidentCreated, TriggerManual:
+		TriggerIncidentCreated, TriggerKRIThresholdCrossed, TriggerManual:
 		return AutomationTrigger(s), nil
 	default:
 		return "", NewValidationError("invalid automation trigger: " + s)
@@ -43,6 +43,7 @@ func TriggerLabel(t AutomationTrigger, locale string) string {
 		TriggerRiskCreated:           "un risque est créé",
 		TriggerRiskScoreUpdated:      "le score d'un risque change",
 		TriggerIncidentCreated:       "un incident est déclaré",
+		TriggerKRIThresholdCrossed:   "un indicateur clé de risque change de seuil",
 		TriggerManual:                "je lance la règle manuellement",
 	}
 	en := map[AutomationTrigger]string{
@@ -50,6 +51,7 @@ func TriggerLabel(t AutomationTrigger, locale string) string {
 		TriggerRiskCreated:           "a risk is created",
 		TriggerRiskScoreUpdated:      "a risk score changes",
 		TriggerIncidentCreated:       "an incident is declared",
+		TriggerKRIThresholdCrossed:   "a key risk indicator crosses a threshold",
 		TriggerManual:                "I run the rule manually",
 	}
 	if normLocale(locale) == LocaleEN {
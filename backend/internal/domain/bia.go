@@ -0,0 +1,522 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---------------------------------------------------------------------------
+// Business impact analysis (ISO 22301 §8.2.2).
+//
+// A business process is an asset like any other — usually of category
+// business_process — that carries a BusinessImpactAnalysis: how long it may be
+// down before the damage is unacceptable (MTPD), the recovery time and point
+// objectives the organisation commits to (RTO/RPO), and how the impact grows
+// with the outage.
+//
+// The objectives only mean something if what the process stands on can meet
+// them. A payroll process with a 4-hour RTO that runs on a server restored from
+// a weekly tape has a 4-hour RTO on paper only. So the engine walks the
+// dependency graph down from each process, hands every supporting asset the
+// objectives it inherits, and compares them with the RecoveryCapability that
+// asset declares.
+// ---------------------------------------------------------------------------
+
+// ImpactPoint is one step of an impact-over-time curve: after AfterHours of
+// outage the impact has reached Level and has cost CostXAF in total.
+type ImpactPoint struct {
+	AfterHours float64 `json:"after_hours"`
+	// Level is 1 (negligible) … 5 (catastrophic), the scale the BIA workshop
+	// uses for the non-financial impacts (legal, reputational, operational).
+	Level   int     `json:"level"`
+	CostXAF float64 `json:"cost_xaf"`
+	Note    string  `json:"note,omitempty"`
+}
+
+// ImpactCurve is a process's impact over time, ordered by AfterHours.
+type ImpactCurve []ImpactPoint
+
+// Validate checks the curve is ordered and never improves with time: an outage
+// does not get cheaper by lasting longer, and a curve that says so is a typo.
+func (c ImpactCurve) Validate() error {
+	for i, p := range c {
+		if p.AfterHours < 0 || math.IsNaN(p.AfterHours) {
+			return NewValidationError(fmt.Sprintf("impact_curve[%d].after_hours cannot be negative", i))
+		}
+		if p.Level < 1 || p.Level > 5 {
+			return NewValidationError(fmt.Sprintf("impact_curve[%d].level must be between 1 and 5", i))
+		}
+		if p.CostXAF < 0 {
+			return NewValidationError(fmt.Sprintf("impact_curve[%d].cost_xaf cannot be negative", i))
+		}
+		if i == 0 {
+			continue
+		}
+		prev := c[i-1]
+		if p.AfterHours <= prev.AfterHours {
+			return NewValidationError("impact_curve points must be in increasing after_hours order")
+		}
+		if p.Level < prev.Level || p.CostXAF < prev.CostXAF {
+			return NewValidationError(fmt.Sprintf("impact_curve[%d] is lower than the point before it: impact cannot decrease over time", i))
+		}
+	}
+	return nil
+}
+
+// At returns the impact after the given outage: the level of the last point
+// reached, and the cost interpolated linearly between points (flat past the
+// last one — the curve says nothing about what happens beyond it, and
+// extrapolating would invent a number). Before the first point the level is 1
+// and the cost ramps up from zero.
+func (c ImpactCurve) At(hours float64) (level int, costXAF float64) {
+	level = 1
+	if len(c) == 0 || hours < c[0].AfterHours {
+		if len(c) > 0 && c[0].AfterHours > 0 {
+			return level, c[0].CostXAF * math.Max(hours, 0) / c[0].AfterHours
+		}
+		return level, 0
+	}
+	for i, p := range c {
+		if hours < p.AfterHours {
+			prev := c[i-1]
+			frac := (hours - prev.AfterHours) / (p.AfterHours - prev.AfterHours)
+			return prev.Level, prev.CostXAF + frac*(p.CostXAF-prev.CostXAF)
+		}
+	}
+	last := c[len(c)-1]
+	return last.Level, last.CostXAF
+}
+
+// Value implements driver.Valuer (jsonb).
+func (c ImpactCurve) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(c)
+	return string(b), err
+}
+
+// Scan implements sql.Scanner.
+func (c *ImpactCurve) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*c = ImpactCurve{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("ImpactCurve: unsupported scan type %T", value)
+	}
+	if len(b) == 0 {
+		*c = ImpactCurve{}
+		return nil
+	}
+	return json.Unmarshal(b, c)
+}
+
+// BusinessImpactAnalysis is the BIA of one business process. One per asset.
+type BusinessImpactAnalysis struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:uq_bia_tenant_asset,priority:1" json:"tenant_id"`
+	// AssetID is the process asset this analysis describes.
+	AssetID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:uq_bia_tenant_asset,priority:2" json:"asset_id"`
+
+	// MTPDHours is the maximum tolerable period of disruption: past it the
+	// damage is unacceptable whatever it costs to avoid.
+	MTPDHours float64 `gorm:"type:numeric(10,2);not null" json:"mtpd_hours"`
+	// RTOHours is the committed recovery time; never above the MTPD.
+	RTOHours float64 `gorm:"type:numeric(10,2);not null" json:"rto_hours"`
+	// RPOHours is the tolerable data loss, in hours of work. 0 means none.
+	RPOHours float64 `gorm:"type:numeric(10,2);not null" json:"rpo_hours"`
+
+	ImpactCurve ImpactCurve `gorm:"type:jsonb" json:"impact_curve"`
+	Owner       string      `gorm:"size:255" json:"owner,omitempty"`
+	Notes       string      `gorm:"type:text" json:"notes,omitempty"`
+
+	UpdatedBy *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TableName pins the table name.
+func (BusinessImpactAnalysis) TableName() string { return "business_impact_analyses" }
+
+// Validate enforces the relations ISO 22301 implies between the objectives.
+func (b *BusinessImpactAnalysis) Validate() error {
+	if b.MTPDHours <= 0 {
+		return NewValidationError("mtpd_hours must be positive")
+	}
+	if b.RTOHours <= 0 {
+		return NewValidationError("rto_hours must be positive")
+	}
+	if b.RTOHours > b.MTPDHours {
+		return NewValidationError("rto_hours cannot exceed mtpd_hours: recovering after the maximum tolerable disruption is recovering too late")
+	}
+	if b.RPOHours < 0 {
+		return NewValidationError("rpo_hours cannot be negative")
+	}
+	return b.ImpactCurve.Validate()
+}
+
+// RecoveryBasis says how much a declared capability can be trusted.
+type RecoveryBasis string
+
+const (
+	// RecoveryBasisTested — demonstrated in a restore or failover exercise.
+	RecoveryBasisTested RecoveryBasis = "tested"
+	// RecoveryBasisContractual — a supplier's SLA.
+	RecoveryBasisContractual RecoveryBasis = "contractual"
+	// RecoveryBasisEstimated — an engineer's estimate, never exercised.
+	RecoveryBasisEstimated RecoveryBasis = "estimated"
+)
+
+// RecoveryCapability is what a supporting asset can actually deliver: how long
+// it takes to restore (RTC) and how much data a restore loses (RPC, typically
+// the backup or replication interval). Either may be nil when not declared.
+type RecoveryCapability struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:uq_recovery_capabilities_tenant_asset,priority:1" json:"tenant_id"`
+	AssetID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:uq_recovery_capabilities_tenant_asset,priority:2" json:"asset_id"`
+
+	RTCHours     *float64      `gorm:"type:numeric(10,2)" json:"rtc_hours"`
+	RPCHours     *float64      `gorm:"type:numeric(10,2)" json:"rpc_hours"`
+	Basis        RecoveryBasis `gorm:"type:varchar(16);not null" json:"basis"`
+	LastTestedAt *time.Time    `json:"last_tested_at,omitempty"`
+	Notes        string        `gorm:"type:text" json:"notes,omitempty"`
+
+	UpdatedBy *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TableName pins the table name.
+func (RecoveryCapability) TableName() string { return "recovery_capabilities" }
+
+// Validate checks the capability declares something and nothing negative.
+func (r *RecoveryCapability) Validate() error {
+	if r.RTCHours == nil && r.RPCHours == nil {
+		return NewValidationError("declare at least one of rtc_hours or rpc_hours")
+	}
+	if r.RTCHours != nil && *r.RTCHours < 0 {
+		return NewValidationError("rtc_hours cannot be negative")
+	}
+	if r.RPCHours != nil && *r.RPCHours < 0 {
+		return NewValidationError("rpc_hours cannot be negative")
+	}
+	switch r.Basis {
+	case RecoveryBasisTested, RecoveryBasisContractual, RecoveryBasisEstimated:
+	case "":
+		r.Basis = RecoveryBasisEstimated
+	default:
+		return NewValidationError("basis must be tested, contractual or estimated")
+	}
+	return nil
+}
+
+// BIARepository persists analyses and capabilities, both keyed by
+// (tenant, asset). Get* return (nil, nil) when absent.
+type BIARepository interface {
+	ListAnalyses(ctx context.Context, tenantID uuid.UUID) ([]BusinessImpactAnalysis, error)
+	GetAnalysis(ctx context.Context, tenantID, assetID uuid.UUID) (*BusinessImpactAnalysis, error)
+	SaveAnalysis(ctx context.Context, b *BusinessImpactAnalysis) error
+	DeleteAnalysis(ctx context.Context, tenantID, assetID uuid.UUID) error
+
+	ListCapabilities(ctx context.Context, tenantID uuid.UUID) ([]RecoveryCapability, error)
+	GetCapability(ctx context.Context, tenantID, assetID uuid.UUID) (*RecoveryCapability, error)
+	SaveCapability(ctx context.Context, c *RecoveryCapability) error
+	DeleteCapability(ctx context.Context, tenantID, assetID uuid.UUID) error
+}
+
+// ---------------------------------------------------------------------------
+// Propagation.
+// ---------------------------------------------------------------------------
+
+// continuityEdge reports whether recovery objectives flow across an edge, and
+// whether the edge carries the process's DATA (so the RPO flows too).
+//
+// Edges read "source needs target", so objectives flow forwards, like an
+// attacker. The hosting and data relations carry them; so does depends_on,
+// because it is how a process is usually linked to the applications it uses,
+// and authenticates_via, because nothing recovers until users can log in.
+// connects_to (a peer the source calls, often degradable) and managed_by (a
+// party, not a system) do not: an objective on them would be a guess.
+func continuityEdge(t DependencyType) (carries bool, data bool) {
+	switch t {
+	case DepStoresDataIn, DepProcessesDataOf, DepBacksUpTo:
+		return true, true
+	case DepRunsOn, DepHostedOn, DepHostedBy, DepDependsOn, DepAuthenticatesVia:
+		return true, false
+	default:
+		return false, false
+	}
+}
+
+// RecoveryStatus is the verdict on one supporting asset.
+type RecoveryStatus string
+
+const (
+	RecoveryOK         RecoveryStatus = "ok"
+	RecoveryGap        RecoveryStatus = "gap"
+	RecoveryUndeclared RecoveryStatus = "undeclared"
+)
+
+// RecoveryRequirement is what one supporting asset must deliver, where the
+// requirement comes from, and whether its declared capability meets it.
+type RecoveryRequirement struct {
+	AssetID uuid.UUID `json:"asset_id"`
+	Name    string    `json:"name"`
+	Depth   int       `json:"depth"`
+	// Path runs from the driving process to this asset, both included, so the
+	// report can say "payroll → hr-app → db-01".
+	Path []uuid.UUID `json:"path"`
+
+	RequiredRTOHours float64    `json:"required_rto_hours"`
+	RTODrivenBy      uuid.UUID  `json:"rto_driven_by"`
+	RequiredRPOHours *float64   `json:"required_rpo_hours,omitempty"`
+	RPODrivenBy      *uuid.UUID `json:"rpo_driven_by,omitempty"`
+
+	DeclaredRTCHours *float64      `json:"declared_rtc_hours,omitempty"`
+	DeclaredRPCHours *float64      `json:"declared_rpc_hours,omitempty"`
+	Basis            RecoveryBasis `json:"basis,omitempty"`
+
+	Status RecoveryStatus `json:"status"`
+	Gaps   []string       `json:"gaps"`
+}
+
+// continuityReach is one asset reached from a process.
+type continuityReach struct {
+	depth    int
+	dataHeld bool
+	path     []uuid.UUID
+}
+
+// walkContinuity does a BFS forwards from a process over continuity edges.
+// An asset is a data holder when it was reached through a data edge, or is
+// hosted under one (the server a database runs on holds the database's data).
+// States are (asset, data-held) pairs so an asset first reached as a plain
+// host and later as a data holder is not lost to the visited set.
+func walkContinuity(process uuid.UUID, adj map[uuid.UUID][]AssetDependency) map[uuid.UUID]continuityReach {
+	type state struct {
+		id   uuid.UUID
+		data bool
+	}
+	out := map[uuid.UUID]continuityReach{}
+	seen := map[state]bool{{process, false}: true}
+	paths := map[state][]uuid.UUID{{process, false}: {process}}
+	frontier := []state{{process, false}}
+	for depth := 1; len(frontier) > 0; depth++ {
+		var next []state
+		for _, cur := range frontier {
+			for _, d := range adj[cur.id] {
+				carries, data := continuityEdge(d.Type)
+				if !carries || d.TargetAssetID == process {
+					continue
+				}
+				st := state{d.TargetAssetID, cur.data || data}
+				if seen[st] {
+					continue
+				}
+				seen[st] = true
+				p := append(append([]uuid.UUID(nil), paths[cur]...), st.id)
+				paths[st] = p
+				next = append(next, st)
+				r, ok := out[st.id]
+				if !ok {
+					r = continuityReach{depth: depth, path: p}
+				}
+				if st.data && !r.dataHeld {
+					r.dataHeld = true
+				}
+				out[st.id] = r
+			}
+		}
+		frontier = next
+	}
+	return out
+}
+
+// PropagateRecovery hands every supporting asset the strictest objectives it
+// inherits from the processes above it and checks its declared capability.
+// The strictest wins because an asset shared by payroll (RTO 4h) and the
+// intranet (RTO 72h) must be restored in 4h — the intranet gets that for free.
+//
+// Each asset is compared on its own capability. A process whose application
+// restores in 3h on a server that restores in 3h needs 6h if the two recover
+// one after the other; that serial sum is not modelled here and is called out
+// on the report rather than silently assumed away.
+func PropagateRecovery(analyses []BusinessImpactAnalysis, deps []AssetDependency, caps map[uuid.UUID]RecoveryCapability, names map[uuid.UUID]string) []RecoveryRequirement {
+	adj := map[uuid.UUID][]AssetDependency{}
+	for _, d := range deps {
+		if d.SourceAssetID != d.TargetAssetID {
+			adj[d.SourceAssetID] = append(adj[d.SourceAssetID], d)
+		}
+	}
+	isProcess := make(map[uuid.UUID]bool, len(analyses))
+	for _, b := range analyses {
+		isProcess[b.AssetID] = true
+	}
+
+	reqs := map[uuid.UUID]*RecoveryRequirement{}
+	for _, b := range analyses {
+		for id, reach := range walkContinuity(b.AssetID, adj) {
+			// A process reached from another process keeps its own BIA: its
+			// objectives are stated, not inherited.
+			if isProcess[id] {
+				continue
+			}
+			r, ok := reqs[id]
+			if !ok {
+				r = &RecoveryRequirement{AssetID: id, Name: names[id], Depth: reach.depth, Path: reach.path,
+					RequiredRTOHours: b.RTOHours, RTODrivenBy: b.AssetID}
+				reqs[id] = r
+			} else if b.RTOHours < r.RequiredRTOHours {
+				r.RequiredRTOHours, r.RTODrivenBy, r.Depth, r.Path = b.RTOHours, b.AssetID, reach.depth, reach.path
+			}
+			if reach.dataHeld && (r.RequiredRPOHours == nil || b.RPOHours < *r.RequiredRPOHours) {
+				rpo, by := b.RPOHours, b.AssetID
+				r.RequiredRPOHours, r.RPODrivenBy = &rpo, &by
+			}
+		}
+	}
+
+	out := make([]RecoveryRequirement, 0, len(reqs))
+	for _, r := range reqs {
+		r.Gaps = []string{}
+		if c, ok := caps[r.AssetID]; ok {
+			r.DeclaredRTCHours, r.DeclaredRPCHours, r.Basis = c.RTCHours, c.RPCHours, c.Basis
+		}
+		r.Status = assessRecovery(r)
+		out = append(out, *r)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if recoveryStatusRank(out[i].Status) != recoveryStatusRank(out[j].Status) {
+			return recoveryStatusRank(out[i].Status) < recoveryStatusRank(out[j].Status)
+		}
+		if out[i].RequiredRTOHours != out[j].RequiredRTOHours {
+			return out[i].RequiredRTOHours < out[j].RequiredRTOHours
+		}
+		return out[i].AssetID.String() < out[j].AssetID.String()
+	})
+	return out
+}
+
+// recoveryStatusRank orders the verdicts worst first, so a report reads
+// top-down.
+func recoveryStatusRank(s RecoveryStatus) int {
+	switch s {
+	case RecoveryGap:
+		return 0
+	case RecoveryUndeclared:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// assessRecovery fills r.Gaps and returns the verdict. A capability that is
+// missing for an objective that applies is "undeclared", not "ok": silence is
+// not a recovery plan, but neither is it proof of a gap.
+func assessRecovery(r *RecoveryRequirement) RecoveryStatus {
+	undeclared := false
+	if r.DeclaredRTCHours == nil {
+		undeclared = true
+	} else if *r.DeclaredRTCHours > r.RequiredRTOHours {
+		r.Gaps = append(r.Gaps, fmt.Sprintf("restores in %gh, inherited RTO is %gh", *r.DeclaredRTCHours, r.RequiredRTOHours))
+	}
+	if r.RequiredRPOHours != nil {
+		if r.DeclaredRPCHours == nil {
+			undeclared = true
+		} else if *r.DeclaredRPCHours > *r.RequiredRPOHours {
+			r.Gaps = append(r.Gaps, fmt.Sprintf("loses up to %gh of data, inherited RPO is %gh", *r.DeclaredRPCHours, *r.RequiredRPOHours))
+		}
+	}
+	switch {
+	case len(r.Gaps) > 0:
+		return RecoveryGap
+	case undeclared:
+		return RecoveryUndeclared
+	default:
+		return RecoveryOK
+	}
+}
+
+// BIAReport is the per-process view: the analysis, what the process stands
+// on, and what a realistic outage looks like given the declared capabilities.
+type BIAReport struct {
+	ProcessID   uuid.UUID              `json:"process_id"`
+	ProcessName string                 `json:"process_name"`
+	Analysis    BusinessImpactAnalysis `json:"analysis"`
+	// Dependencies are judged against THIS process's objectives only; the
+	// estate-wide view (PropagateRecovery over every process) may be stricter.
+	Dependencies []RecoveryRequirement `json:"dependencies"`
+	Gaps         int                   `json:"gaps"`
+	Undeclared   int                   `json:"undeclared"`
+
+	// ExpectedDowntimeHours is the outage to plan for: the RTO, or the slowest
+	// declared restore among the dependencies when that is longer. It is what
+	// feeds the CRQ DowntimeHours input of the process's risks.
+	ExpectedDowntimeHours float64 `json:"expected_downtime_hours"`
+	WithinRTO             bool    `json:"within_rto"`
+	WithinMTPD            bool    `json:"within_mtpd"`
+	ImpactLevelAtExpected int     `json:"impact_level_at_expected"`
+	ImpactCostAtExpected  float64 `json:"impact_cost_xaf_at_expected"`
+	ImpactLevelAtRTO      int     `json:"impact_level_at_rto"`
+	ImpactCostAtRTO       float64 `json:"impact_cost_xaf_at_rto"`
+
+	// Caveats are the report's honest limits (serial recovery, undeclared
+	// capabilities) so nobody reads a green report as more than it is.
+	Caveats []string `json:"caveats"`
+}
+
+// BuildBIAReport assembles the report for one process.
+func BuildBIAReport(b BusinessImpactAnalysis, processName string, deps []AssetDependency, caps map[uuid.UUID]RecoveryCapability, names map[uuid.UUID]string) BIAReport {
+	rep := BIAReport{
+		ProcessID:    b.AssetID,
+		ProcessName:  processName,
+		Analysis:     b,
+		Dependencies: PropagateRecovery([]BusinessImpactAnalysis{b}, deps, caps, names),
+		Caveats:      []string{},
+	}
+	expected := b.RTOHours
+	for _, d := range rep.Dependencies {
+		switch d.Status {
+		case RecoveryGap:
+			rep.Gaps++
+		case RecoveryUndeclared:
+			rep.Undeclared++
+		}
+		if d.DeclaredRTCHours != nil && *d.DeclaredRTCHours > expected {
+			expected = *d.DeclaredRTCHours
+		}
+	}
+	rep.ExpectedDowntimeHours = expected
+	rep.WithinRTO = expected <= b.RTOHours
+	rep.WithinMTPD = expected <= b.MTPDHours
+	rep.ImpactLevelAtExpected, rep.ImpactCostAtExpected = b.ImpactCurve.At(expected)
+	rep.ImpactLevelAtRTO, rep.ImpactCostAtRTO = b.ImpactCurve.At(b.RTOHours)
+	rep.ImpactCostAtExpected = math.Round(rep.ImpactCostAtExpected)
+	rep.ImpactCostAtRTO = math.Round(rep.ImpactCostAtRTO)
+
+	if rep.Undeclared > 0 {
+		rep.Caveats = append(rep.Caveats, fmt.Sprintf("%d supporting asset(s) declare no recovery capability; the expected downtime assumes they meet the RTO", rep.Undeclared))
+	}
+	if len(rep.Dependencies) > 1 {
+		rep.Caveats = append(rep.Caveats, "each dependency is compared on its own restore time; assets restored one after another add up")
+	}
+	return rep
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package domain
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestImpactCurve_ValidateAndAt(t *testing.T) {
+	c := ImpactCurve{{AfterHours: 4, Level: 2, CostXAF: 1000}, {AfterHours: 24, Level: 4, CostXAF: 11000}}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("valid curve refused: %v", err)
+	}
+	for _, tc := range []struct {
+		hours float64
+		level int
+		cost  float64
+	}{
+		{2, 1, 500},
+		{14, 2, 6000},
+		{100, 4, 11000}, // flat past the last point
+	} {
+		lvl, cost := c.At(tc.hours)
+		if lvl != tc.level || math.Abs(cost-tc.cost) > 0.01 {
+			t.Errorf("At(%v) = (%d, %v), want (%d, %v)", tc.hours, lvl, cost, tc.level, tc.cost)
+		}
+	}
+
+	decreasing := ImpactCurve{{AfterHours: 4, Level: 3, CostXAF: 1000}, {AfterHours: 8, Level: 2, CostXAF: 2000}}
+	if decreasing.Validate() == nil {
+		t.Error("an impact that falls over time must be refused")
+	}
+}
+
+func TestBusinessImpactAnalysis_Validate(t *testing.T) {
+	b := &BusinessImpactAnalysis{TenantID: uuid.New(), AssetID: uuid.New(), MTPDHours: 8, RTOHours: 24}
+	if b.Validate() == nil {
+		t.Error("an RTO beyond the MTPD must be refused")
+	}
+	b.RTOHours = 4
+	if err := b.Validate(); err != nil {
+		t.Errorf("RTO within MTPD refused: %v", err)
+	}
+}
+
+func TestPropagateRecovery_ConnectsToDoesNotCarryRecovery(t *testing.T) {
+	tenant := uuid.New()
+	process, app, partner := uuid.New(), uuid.New(), uuid.New()
+	analyses := []BusinessImpactAnalysis{{TenantID: tenant, AssetID: process, MTPDHours: 24, RTOHours: 4, RPOHours: 1}}
+	deps := []AssetDependency{
+		{SourceAssetID: process, TargetAssetID: app, Type: DepDependsOn},
+		{SourceAssetID: app, TargetAssetID: partner, Type: DepConnectsTo},
+	}
+	reqs := PropagateRecovery(analyses, deps, nil, map[uuid.UUID]string{})
+	if len(reqs) != 1 || reqs[0].AssetID != app {
+		t.Fatalf("only the app should inherit the objective, got %+v", reqs)
+	}
+	if reqs[0].RequiredRPOHours != nil {
+		t.Error("depends_on carries no data: the app inherits no RPO")
+	}
+	if reqs[0].Status != RecoveryUndeclared {
+		t.Errorf("status = %s, want undeclared", reqs[0].Status)
+	}
+}
@@ -54,6 +54,16 @@ type BoardReport struct {
 	// FrameworksSnapshot is a JSON array of the per-framework advancement at
 	// generation time (name, version, percent, counts) — rendered in the PDF table.
 	FrameworksSnapshot datatypes.JSON `gorm:"type:jsonb" json:"frameworks_snapshot"`
+	// AppetiteSnapshot is the appetite evaluation at generation time: each
+	// in-force statement's scope and breaches, and the risks above appetite
+	// with the state of their exception. Null when no statement is in force.
+	AppetiteSnapshot datatypes.JSON `gorm:"type:jsonb" json:"appetite_snapshot,omitempty"`
+	// KRISnapshot is the key risk indicators at generation time: each one's
+	// band, current value and recent trend. Null when the tenant has none.
+	KRISnapshot datatypes.JSON `gorm:"type:jsonb" json:"kri_snapshot,omitempty"`
+	// RegisterChanges is what moved in the register over the month before
+	// generation. Null when no snapshot is old enough to compare against.
+	RegisterChanges datatypes.JSON `gorm:"type:jsonb" json:"register_changes,omitempty"`
 
 	// --- Narrative (editable while draft) ---
 	ExecutiveSummary     string         `gorm:"type:text" json:"executive_summary"`
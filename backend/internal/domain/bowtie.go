@@ -0,0 +1,417 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---------------------------------------------------------------------------
+// Bowtie analysis.
+//
+// Safety and operational-risk teams do not describe a risk as a paragraph but
+// as a bowtie: on the left the threats that can cause the top event (the
+// moment control is lost), each crossed by preventive barriers; on the right
+// the consequences the top event can lead to, each crossed by recovery
+// barriers that limit the damage.
+//
+// A barrier is only as good as the evidence behind it, so a barrier links to a
+// compliance control, one of the risk's mitigations, or both, and its health
+// is derived on read from the control's tests and the mitigation's status
+// (BarrierHealthOf) — never typed. Degraded preventive barriers make the top
+// event more likely; degraded recovery barriers make it worse when it
+// happens. SuggestFromBarriers turns that into a suggested probability and
+// impact for the risk, which a human applies or not.
+//
+// A risk has at most one bowtie, stored as one row whose threats,
+// consequences and barriers are jsonb lists: it is drawn and edited as a
+// whole.
+// ---------------------------------------------------------------------------
+
+// BowtieBarrierKind is which side of the top event a barrier stands on.
+type BowtieBarrierKind string
+
+const (
+	// BarrierPreventive stands between a threat and the top event.
+	BarrierPreventive BowtieBarrierKind = "preventive"
+	// BarrierRecovery stands between the top event and a consequence.
+	BarrierRecovery BowtieBarrierKind = "recovery"
+)
+
+// Bowtie size limits: beyond them the figure is no longer readable.
+const (
+	MaxBowtiePaths    = 12 // threats, and consequences, each
+	MaxBowtieBarriers = 60
+)
+
+// BowtiePath is a threat (left side) or a consequence (right side). ID is a
+// short key unique within the bowtie, assigned on save when blank, that
+// barriers use to say which path they cross.
+type BowtiePath struct {
+	ID          string `json:"id"`
+	Label       string `json:"label"`
+	Description string `json:"description,omitempty"`
+}
+
+// BowtiePaths is a jsonb list of paths.
+type BowtiePaths []BowtiePath
+
+func (ps BowtiePaths) Value() (driver.Value, error) { return json.Marshal(ps) }
+
+func (ps *BowtiePaths) Scan(value interface{}) error {
+	return scanJSONColumn(value, ps, "BowtiePaths")
+}
+
+// BowtieBarrier is one barrier on one path. PathID names a threat for a
+// preventive barrier and a consequence for a recovery one; barriers on the
+// same path are drawn in Position order, from the threat inwards or from the
+// top event outwards.
+type BowtieBarrier struct {
+	ID           string            `json:"id"`
+	Kind         BowtieBarrierKind `json:"kind"`
+	PathID       string            `json:"path_id"`
+	Label        string            `json:"label"`
+	Position     int               `json:"position"`
+	ControlID    *uuid.UUID        `json:"control_id,omitempty"`
+	MitigationID *uuid.UUID        `json:"mitigation_id,omitempty"`
+}
+
+// BowtieBarriers is a jsonb list of barriers.
+type BowtieBarriers []BowtieBarrier
+
+func (bs BowtieBarriers) Value() (driver.Value, error) { return json.Marshal(bs) }
+
+func (bs *BowtieBarriers) Scan(value interface{}) error {
+	return scanJSONColumn(value, bs, "BowtieBarriers")
+}
+
+// Bowtie is a risk's bowtie diagram.
+type Bowtie struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	RiskID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"risk_id"`
+
+	// Hazard is what is being controlled ("customer funds in transit");
+	// TopEvent the moment control over it is lost ("unauthorised transfer
+	// leaves the bank").
+	Hazard   string `gorm:"size:255;not null;default:''" json:"hazard"`
+	TopEvent string `gorm:"size:255;not null" json:"top_event"`
+
+	Threats      BowtiePaths    `gorm:"type:jsonb" json:"threats"`
+	Consequences BowtiePaths    `gorm:"type:jsonb" json:"consequences"`
+	Barriers     BowtieBarriers `gorm:"type:jsonb" json:"barriers"`
+
+	// AppliedPreventiveLoss and AppliedRecoveryLoss are the barrier losses
+	// the risk's figures have already been raised by (see
+	// SuggestFromBarriers). They only ever grow: the suggestion never lowers
+	// the figures when barriers recover, so the raise is still in them.
+	AppliedPreventiveLoss float64 `gorm:"type:numeric(5,4);not null;default:0" json:"applied_preventive_loss"`
+	AppliedRecoveryLoss   float64 `gorm:"type:numeric(5,4);not null;default:0" json:"applied_recovery_loss"`
+
+	UpdatedBy *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (Bowtie) TableName() string { return "bowties" }
+
+// Validate normalises the bowtie, assigns keys to new paths and barriers, and
+// checks that every barrier crosses a path on its own side.
+func (b *Bowtie) Validate() error {
+	b.Hazard = strings.TrimSpace(b.Hazard)
+	b.TopEvent = strings.TrimSpace(b.TopEvent)
+	if b.TopEvent == "" {
+		return NewValidationError("top_event is required")
+	}
+	if len(b.TopEvent) > 255 || len(b.Hazard) > 255 {
+		return NewValidationError("hazard and top_event must be at most 255 characters")
+	}
+	if len(b.Threats) > MaxBowtiePaths || len(b.Consequences) > MaxBowtiePaths {
+		return NewValidationError(fmt.Sprintf("a bowtie has at most %d threats and %d consequences", MaxBowtiePaths, MaxBowtiePaths))
+	}
+	if len(b.Barriers) > MaxBowtieBarriers {
+		return NewValidationError(fmt.Sprintf("a bowtie has at most %d barriers", MaxBowtieBarriers))
+	}
+	if b.Threats == nil {
+		b.Threats = BowtiePaths{}
+	}
+	if b.Consequences == nil {
+		b.Consequences = BowtiePaths{}
+	}
+	if b.Barriers == nil {
+		b.Barriers = BowtieBarriers{}
+	}
+
+	ids := map[string]bool{}
+	side := map[string]BowtieBarrierKind{}
+	for _, list := range []struct {
+		paths BowtiePaths
+		kind  BowtieBarrierKind
+		what  string
+	}{{b.Threats, BarrierPreventive, "threat"}, {b.Consequences, BarrierRecovery, "consequence"}} {
+		for i := range list.paths {
+			p := &list.paths[i]
+			p.Label = strings.TrimSpace(p.Label)
+			p.Description = strings.TrimSpace(p.Description)
+			if p.Label == "" {
+				return NewValidationError(fmt.Sprintf("every %s needs a label", list.what))
+			}
+			if err := assignBowtieKey(&p.ID, ids); err != nil {
+				return err
+			}
+			side[p.ID] = list.kind
+		}
+	}
+	for i := range b.Barriers {
+		br := &b.Barriers[i]
+		br.Label = strings.TrimSpace(br.Label)
+		if br.Label == "" {
+			return NewValidationError("every barrier needs a label")
+		}
+		switch br.Kind {
+		case BarrierPreventive, BarrierRecovery:
+		default:
+			return NewValidationError("barrier kind must be preventive or recovery")
+		}
+		kind, ok := side[br.PathID]
+		if !ok {
+			return NewValidationError(fmt.Sprintf("barrier %q crosses no threat or consequence of this bowtie", br.Label))
+		}
+		if kind != br.Kind {
+			return NewValidationError(fmt.Sprintf("barrier %q: a preventive barrier crosses a threat, a recovery barrier a consequence", br.Label))
+		}
+		if err := assignBowtieKey(&br.ID, ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func assignBowtieKey(id *string, seen map[string]bool) error {
+	*id = strings.TrimSpace(*id)
+	if *id == "" {
+		*id = uuid.NewString()[:8]
+		for seen[*id] {
+			*id = uuid.NewString()[:8]
+		}
+	}
+	if len(*id) > 36 {
+		return NewValidationError("bowtie element ids are at most 36 characters")
+	}
+	if seen[*id] {
+		return NewValidationError(fmt.Sprintf("bowtie element id %q is used twice", *id))
+	}
+	seen[*id] = true
+	return nil
+}
+
+// BarriersOn returns the barriers crossing one path, in drawing order.
+func (b *Bowtie) BarriersOn(pathID string) []BowtieBarrier {
+	var out []BowtieBarrier
+	for _, br := range b.Barriers {
+		if br.PathID == pathID {
+			out = append(out, br)
+		}
+	}
+	for i := 1; i < len(out); i++ {
+		for j := i; j > 0 && out[j].Position < out[j-1].Position; j-- {
+			out[j], out[j-1] = out[j-1], out[j]
+		}
+	}
+	return out
+}
+
+// ---------------------------------------------------------------------------
+// Barrier health
+// ---------------------------------------------------------------------------
+
+// BarrierHealth is how far a barrier can be relied on.
+type BarrierHealth string
+
+const (
+	BarrierHealthy  BarrierHealth = "healthy"
+	BarrierDegraded BarrierHealth = "degraded"
+	BarrierFailed   BarrierHealth = "failed"
+	// BarrierUnknown: nothing says whether the barrier works — it is linked
+	// to nothing, or to a control nobody has tested yet.
+	BarrierUnknown BarrierHealth = "unknown"
+)
+
+// Effectiveness thresholds for a tested control.
+const (
+	BarrierHealthyEffectiveness  = 0.8
+	BarrierDegradedEffectiveness = 0.5
+)
+
+// BarrierControlEvidence is what the control linked to a barrier shows.
+type BarrierControlEvidence struct {
+	Status ControlStatus
+	// Tested is false until the control has a design or operating result;
+	// Effectiveness is then ControlEffectiveness of the latest ones.
+	Tested        bool
+	Effectiveness float64
+	// Overdue: one of the control's test plans is past its next date.
+	Overdue bool
+}
+
+// BarrierHealthOf derives a barrier's health from the control and the
+// mitigation it is linked to (either may be nil). With both, the worse one
+// wins; an unknown side never hides what the other one shows.
+//
+// A control is healthy when tested at BarrierHealthyEffectiveness or better
+// and not overdue, degraded down to BarrierDegradedEffectiveness (or when a
+// good result has gone stale), failed below. A control declared not
+// implemented is a barrier that does not exist. A mitigation is healthy once
+// done, degraded while being put in place, and failed while only planned or
+// when cancelled.
+func BarrierHealthOf(control *BarrierControlEvidence, mitigation *MitigationStatus) BarrierHealth {
+	var healths []BarrierHealth
+	if control != nil {
+		healths = append(healths, controlBarrierHealth(control))
+	}
+	if mitigation != nil {
+		healths = append(healths, mitigationBarrierHealth(*mitigation))
+	}
+	worst := BarrierUnknown
+	for _, h := range healths {
+		if h == BarrierUnknown {
+			continue
+		}
+		if worst == BarrierUnknown || barrierHealthRank[h] > barrierHealthRank[worst] {
+			worst = h
+		}
+	}
+	return worst
+}
+
+var barrierHealthRank = map[BarrierHealth]int{BarrierHealthy: 0, BarrierDegraded: 1, BarrierFailed: 2}
+
+func controlBarrierHealth(c *BarrierControlEvidence) BarrierHealth {
+	if c.Status == ControlStatusNotImplemented {
+		return BarrierFailed
+	}
+	if !c.Tested {
+		return BarrierUnknown
+	}
+	switch {
+	case c.Effectiveness < BarrierDegradedEffectiveness:
+		return BarrierFailed
+	case c.Effectiveness < BarrierHealthyEffectiveness || c.Overdue:
+		return BarrierDegraded
+	default:
+		return BarrierHealthy
+	}
+}
+
+func mitigationBarrierHealth(s MitigationStatus) BarrierHealth {
+	switch s {
+	case MitigationDone:
+		return BarrierHealthy
+	case MitigationInProgress, MitigationReview:
+		return BarrierDegraded
+	default:
+		return BarrierFailed
+	}
+}
+
+// BarrierLoss is the share of its protection a barrier in this health has
+// lost. An unknown barrier counts as intact: the suggestion raises a risk on
+// evidence of weakness, not on the absence of evidence.
+func BarrierLoss(h BarrierHealth) float64 {
+	switch h {
+	case BarrierFailed:
+		return 1
+	case BarrierDegraded:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// MaxBarrierRaise is the share of the headroom (to probability 1, to impact
+// 10) a side whose barriers have all failed adds to the risk.
+const MaxBarrierRaise = 0.5
+
+// BarrierSuggestion is the probability and impact a risk's barriers suggest.
+// A figure is only raised, never lowered: healthy barriers are what the
+// current assessment already assumes.
+type BarrierSuggestion struct {
+	Probability float64 `json:"probability"`
+	Impact      float64 `json:"impact"`
+	// PreventiveLoss and RecoveryLoss are the mean BarrierLoss on each side.
+	PreventiveLoss float64 `json:"preventive_loss"`
+	RecoveryLoss   float64 `json:"recovery_loss"`
+}
+
+// Raises reports whether the suggestion differs from the current figures.
+func (s BarrierSuggestion) Raises(probability, impact float64) bool {
+	return s.Probability > probability || s.Impact > impact
+}
+
+// SuggestFromBarriers raises probability (0–1) by the preventive side's loss
+// and impact (0–10) by the recovery side's, each by at most MaxBarrierRaise of
+// its headroom. applied is the loss already folded into the figures by an
+// earlier application (Bowtie.AppliedPreventiveLoss and
+// AppliedRecoveryLoss): only the loss beyond it raises them again, so
+// applying the same suggestion twice does not compound.
+func SuggestFromBarriers(probability, impact float64, preventive, recovery []BarrierHealth, appliedPreventive, appliedRecovery float64) BarrierSuggestion {
+	s := BarrierSuggestion{
+		PreventiveLoss: meanBarrierLoss(preventive),
+		RecoveryLoss:   meanBarrierLoss(recovery),
+	}
+	pLoss := math.Max(0, s.PreventiveLoss-appliedPreventive)
+	iLoss := math.Max(0, s.RecoveryLoss-appliedRecovery)
+	s.Probability = math.Round((probability+(1-probability)*pLoss*MaxBarrierRaise)*1000) / 1000
+	s.Impact = math.Round((impact+(10-impact)*iLoss*MaxBarrierRaise)*10) / 10
+	if s.Probability < probability {
+		s.Probability = probability
+	}
+	if s.Impact < impact {
+		s.Impact = impact
+	}
+	return s
+}
+
+func meanBarrierLoss(hs []BarrierHealth) float64 {
+	if len(hs) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, h := range hs {
+		sum += BarrierLoss(h)
+	}
+	return math.Round(sum/float64(len(hs))*10000) / 10000
+}
+
+// BowtieRepository persists bowties and reads the evidence their barriers
+// link to.
+type BowtieRepository interface {
+	GetRisk(ctx context.Context, tenantID, id uuid.UUID) (*Risk, error)
+	// Get returns the risk's bowtie, nil when it has none.
+	Get(ctx context.Context, tenantID, riskID uuid.UUID) (*Bowtie, error)
+	// Save inserts or replaces the risk's bowtie.
+	Save(ctx context.Context, b *Bowtie) error
+	Delete(ctx context.Context, tenantID, riskID uuid.UUID) error
+
+	// Controls loads the tenant's controls among ids; unknown ids are left out.
+	Controls(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]ComplianceControl, error)
+	// TestPlans lists the test plans of the given controls.
+	TestPlans(ctx context.Context, tenantID uuid.UUID, controlIDs []uuid.UUID) ([]ControlTestPlan, error)
+	// Mitigations lists the risk's mitigations.
+	Mitigations(ctx context.Context, tenantID, riskID uuid.UUID) ([]Mitigation, error)
+
+	// ApplySuggestion writes the suggested figures onto the risk and its
+	// losses onto the bowtie's applied losses, in one transaction.
+	ApplySuggestion(ctx context.Context, tenantID, riskID uuid.UUID, s BarrierSuggestion) error
+}
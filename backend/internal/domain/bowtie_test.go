@@ -0,0 +1,94 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBowtie_ValidateAssignsKeysAndChecksSides(t *testing.T) {
+	b := &Bowtie{
+		TopEvent:     "  Virement frauduleux émis  ",
+		Threats:      BowtiePaths{{Label: "Hameçonnage d'un opérateur"}, {ID: "insider", Label: "Fraude interne"}},
+		Consequences: BowtiePaths{{ID: "loss", Label: "Perte financière"}},
+		Barriers: BowtieBarriers{
+			{Kind: BarrierPreventive, PathID: "insider", Label: "Double validation", Position: 2},
+			{Kind: BarrierPreventive, PathID: "insider", Label: "Revue des habilitations", Position: 1},
+			{Kind: BarrierRecovery, PathID: "loss", Label: "Rappel de fonds SWIFT"},
+		},
+	}
+	require.NoError(t, b.Validate())
+	assert.Equal(t, "Virement frauduleux émis", b.TopEvent)
+	assert.NotEmpty(t, b.Threats[0].ID, "a new threat gets a key")
+	for _, br := range b.Barriers {
+		assert.NotEmpty(t, br.ID)
+	}
+	on := b.BarriersOn("insider")
+	require.Len(t, on, 2)
+	assert.Equal(t, "Revue des habilitations", on[0].Label, "barriers are drawn in position order")
+
+	wrongSide := &Bowtie{
+		TopEvent:     "x",
+		Threats:      BowtiePaths{{ID: "t", Label: "t"}},
+		Consequences: BowtiePaths{{ID: "c", Label: "c"}},
+		Barriers:     BowtieBarriers{{Kind: BarrierRecovery, PathID: "t", Label: "b"}},
+	}
+	assert.True(t, errors.Is(wrongSide.Validate(), ErrValidation))
+
+	dangling := &Bowtie{TopEvent: "x", Barriers: BowtieBarriers{{Kind: BarrierPreventive, PathID: "nope", Label: "b"}}}
+	assert.True(t, errors.Is(dangling.Validate(), ErrValidation))
+
+	dup := &Bowtie{TopEvent: "x", Threats: BowtiePaths{{ID: "a", Label: "a"}}, Consequences: BowtiePaths{{ID: "a", Label: "b"}}}
+	assert.True(t, errors.Is(dup.Validate(), ErrValidation))
+
+	assert.True(t, errors.Is((&Bowtie{}).Validate(), ErrValidation), "a bowtie needs its top event")
+}
+
+func TestBarrierHealthOf(t *testing.T) {
+	done, planned, inProgress := MitigationDone, MitigationPlanned, MitigationInProgress
+	tested := func(e float64) *BarrierControlEvidence {
+		return &BarrierControlEvidence{Status: ControlStatusImplemented, Tested: true, Effectiveness: e}
+	}
+
+	assert.Equal(t, BarrierUnknown, BarrierHealthOf(nil, nil))
+	assert.Equal(t, BarrierUnknown, BarrierHealthOf(&BarrierControlEvidence{Status: ControlStatusImplemented}, nil), "untested")
+	assert.Equal(t, BarrierFailed, BarrierHealthOf(&BarrierControlEvidence{Status: ControlStatusNotImplemented}, nil))
+	assert.Equal(t, BarrierHealthy, BarrierHealthOf(tested(0.9), nil))
+	assert.Equal(t, BarrierDegraded, BarrierHealthOf(tested(0.6), nil))
+	assert.Equal(t, BarrierFailed, BarrierHealthOf(tested(0), nil))
+
+	stale := tested(0.95)
+	stale.Overdue = true
+	assert.Equal(t, BarrierDegraded, BarrierHealthOf(stale, nil), "a good result gone stale")
+
+	assert.Equal(t, BarrierHealthy, BarrierHealthOf(nil, &done))
+	assert.Equal(t, BarrierDegraded, BarrierHealthOf(nil, &inProgress))
+	assert.Equal(t, BarrierFailed, BarrierHealthOf(nil, &planned))
+
+	assert.Equal(t, BarrierFailed, BarrierHealthOf(tested(0.9), &planned), "the worse side wins")
+	assert.Equal(t, BarrierHealthy, BarrierHealthOf(&BarrierControlEvidence{Status: ControlStatusImplemented}, &done),
+		"an untested control does not hide a done mitigation")
+}
+
+func TestSuggestFromBarriers(t *testing.T) {
+	s := SuggestFromBarriers(0.4, 6, []BarrierHealth{BarrierFailed, BarrierHealthy}, []BarrierHealth{BarrierDegraded}, 0, 0)
+	assert.InDelta(t, 0.5, s.PreventiveLoss, 1e-9)
+	assert.InDelta(t, 0.5, s.RecoveryLoss, 1e-9)
+	assert.InDelta(t, 0.55, s.Probability, 1e-9, "0.4 + 0.6 × 0.5 × 0.5")
+	assert.InDelta(t, 7, s.Impact, 1e-9, "6 + 4 × 0.5 × 0.5")
+	assert.True(t, s.Raises(0.4, 6))
+
+	intact := SuggestFromBarriers(0.4, 6, []BarrierHealth{BarrierHealthy, BarrierUnknown}, nil, 0, 0)
+	assert.False(t, intact.Raises(0.4, 6), "unknown barriers raise nothing")
+
+	again := SuggestFromBarriers(0.55, 7, []BarrierHealth{BarrierFailed, BarrierHealthy}, []BarrierHealth{BarrierDegraded}, 0.5, 0.5)
+	assert.False(t, again.Raises(0.55, 7), "losses already applied are not applied twice")
+	assert.InDelta(t, 0.5, again.PreventiveLoss, 1e-9, "the loss itself is still reported")
+}
@@ -50,6 +50,9 @@ const (
 	// PermGroupOrg covers administering the organization itself: its members,
 	// their invitations, the membership audit trail and the org profile.
 	PermGroupOrg PermissionGroup = "organization"
+	// PermGroupGroup covers the holding view: reading the subsidiaries' rolled-up
+	// registers and linking organizations into the group.
+	PermGroupGroup PermissionGroup = "group"
 )
 
 // PermissionDef documents one permission string with bilingual labels so the
@@ -71,6 +74,9 @@ var PermissionCatalog = []PermissionDef{
 	{"risks:create", PermGroupRisks, "Créer des risques", "Create risks"},
 	{"risks:update", PermGroupRisks, "Modifier les risques", "Update risks"},
 	{"risks:delete", PermGroupRisks, "Supprimer des risques", "Delete risks"},
+	// The register as it stood at a past date: a grant of its own, apart
+	// from reading today's register.
+	{"risks:snapshots:read", PermGroupRisks, "Lire les instantanés du registre", "Read register snapshots"},
 	// Assets
 	{"assets:read", PermGroupAssets, "Lire les actifs", "Read assets"},
 	{"assets:create", PermGroupAssets, "Créer des actifs", "Create assets"},
@@ -131,6 +137,11 @@ var PermissionCatalog = []PermissionDef{
 	{"organization:members:update", PermGroupOrg, "Modifier le rôle des membres", "Change member roles"},
 	{"organization:members:deactivate", PermGroupOrg, "Désactiver ou révoquer des membres", "Deactivate or revoke members"},
 	{"organization:audit:read", PermGroupOrg, "Consulter l'audit des accès", "Read membership audit"},
+	// Group hierarchy. Held in the PARENT organization: "group:read" reads the
+	// subsidiaries that accepted the link, read-only; it grants nothing inside
+	// the subsidiaries themselves.
+	{"group:read", PermGroupGroup, "Consulter la consolidation groupe", "Read group roll-up"},
+	{"group:manage", PermGroupGroup, "Gérer les liens entre organisations", "Manage organization links"},
 }
 
 // catalogIndex is a fast membership set over PermissionCatalog keys.
@@ -192,7 +203,7 @@ var businessRoles = []BusinessRole{
 		DescriptionEN:  "Chief information security officer: cyber risks, vulnerabilities, incidents, security controls and KPIs.",
 		DefaultLanding: "/",
 		Permissions: []PermissionKey{
-			"risks:read", "risks:create", "risks:update",
+			"risks:read", "risks:create", "risks:update", "risks:snapshots:read",
 			"vulnerabilities:read", "vulnerabilities:update",
 			"incidents:read", "incidents:create", "incidents:update",
 			"mitigations:read", "mitigations:create", "mitigations:update",
@@ -201,6 +212,7 @@ var businessRoles = []BusinessRole{
 			"automation:read", "automation:write",
 			"scanner:read", "scanner:scan", "scanner:import",
 			"reports:board:read",
+			"group:read",
 		},
 	},
 	{
@@ -211,7 +223,7 @@ var businessRoles = []BusinessRole{
 		DescriptionEN:  "IT director: global IT view, assets, availability and IT risks.",
 		DefaultLanding: "/assets",
 		Permissions: []PermissionKey{
-			"risks:read", "risks:create", "risks:update",
+			"risks:read", "risks:create", "risks:update", "risks:snapshots:read",
 			"assets:read", "assets:create", "assets:update", "assets:delete",
 			"mitigations:read", "mitigations:create", "mitigations:update",
 			"vulnerabilities:read",
@@ -230,7 +242,7 @@ var businessRoles = []BusinessRole{
 		DescriptionEN:  "Risk management: register, assessments, treatments, heatmaps and reporting.",
 		DefaultLanding: "/risks",
 		Permissions: []PermissionKey{
-			"risks:read", "risks:create", "risks:update", "risks:delete",
+			"risks:read", "risks:create", "risks:update", "risks:delete", "risks:snapshots:read",
 			"mitigations:read", "mitigations:create", "mitigations:update", "mitigations:delete",
 			"assets:read",
 			"vulnerabilities:read",
@@ -247,7 +259,7 @@ var businessRoles = []BusinessRole{
 		DescriptionEN:  "Audit: audit campaigns, findings, evidences, action plans and history (broad read + audit management).",
 		DefaultLanding: "/compliance",
 		Permissions: []PermissionKey{
-			"risks:read",
+			"risks:read", "risks:snapshots:read",
 			"assets:read",
 			"mitigations:read",
 			"vulnerabilities:read",
@@ -266,7 +278,7 @@ var businessRoles = []BusinessRole{
 		DescriptionEN:  "Compliance: frameworks, controls, evidences, remediation plans and regulatory obligations.",
 		DefaultLanding: "/compliance",
 		Permissions: []PermissionKey{
-			"risks:read",
+			"risks:read", "risks:snapshots:read",
 			"assets:read",
 			"mitigations:read",
 			"compliance:read",
@@ -286,7 +298,7 @@ var businessRoles = []BusinessRole{
 		DescriptionEN:  "Internal control: control testing, evidences, audits and remediation plans.",
 		DefaultLanding: "/compliance",
 		Permissions: []PermissionKey{
-			"risks:read",
+			"risks:read", "risks:snapshots:read",
 			"assets:read",
 			"mitigations:read", "mitigations:update",
 			"compliance:read",
@@ -367,7 +379,7 @@ var businessRoles = []BusinessRole{
 		DescriptionEN:  "Read-only access to the whole GRC posture.",
 		DefaultLanding: "/",
 		Permissions: []PermissionKey{
-			"risks:read",
+			"risks:read", "risks:snapshots:read",
 			"assets:read",
 			"mitigations:read",
 			"vulnerabilities:read",
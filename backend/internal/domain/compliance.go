@@ -71,6 +71,9 @@ type ComplianceControl struct {
 	// optional for ad-hoc controls a tenant creates by hand.
 	SourceReference string        `gorm:"size:255;not null;default:''" json:"source_reference"`
 	Status          ControlStatus `gorm:"type:varchar(30);not null;default:'not_implemented'" json:"status"`
+	// ExternalID is the control's key in the tool it was imported from — the
+	// upsert key of a re-import. Empty for controls created here.
+	ExternalID string `gorm:"size:255;index" json:"external_id,omitempty"`
 
 	// Relations
 	Framework ComplianceFramework `gorm:"foreignKey:FrameworkID" json:"framework,omitempty"`
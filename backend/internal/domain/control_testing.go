@@ -0,0 +1,247 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---------------------------------------------------------------------------
+// Control effectiveness testing.
+//
+// A ComplianceControl's Status says whether it was put in place, not whether
+// it works. A ControlTestPlan says how a control is tested — design (would it
+// stop the risk if it ran as described?) or operating (did it actually run,
+// on a sample of real occurrences?) — how many samples, by whom and how often.
+// Each execution is a ControlTest with its samples, exceptions and result, and
+// scores the control between 0 and 1.
+//
+// The scores replace two hand-typed numbers on the risk: ResidualRisk and
+// MitigationEffectiveness. A risk mapped to controls through
+// RiskControlMapping derives them from the tested effectiveness of those
+// controls (DeriveResidual), so a failed test raises every linked risk's
+// residual instead of leaving an optimistic figure nobody revisits.
+// ---------------------------------------------------------------------------
+
+// ControlTestKind is what a plan tests.
+type ControlTestKind string
+
+const (
+	// ControlTestDesign: a walkthrough of how the control is meant to work.
+	ControlTestDesign ControlTestKind = "design"
+	// ControlTestOperating: a sample of real occurrences checked for the
+	// control having run.
+	ControlTestOperating ControlTestKind = "operating"
+)
+
+// ControlTestResult is the tester's conclusion.
+type ControlTestResult string
+
+const (
+	ControlTestPass ControlTestResult = "pass"
+	// ControlTestExceptions: the control works but not reliably — some
+	// samples failed beyond what the tester will tolerate.
+	ControlTestExceptions ControlTestResult = "exceptions"
+	ControlTestFail       ControlTestResult = "fail"
+)
+
+// Default cadences: a design rarely changes, its operation has to be shown
+// every quarter.
+const (
+	DefaultDesignTestDays    = 365
+	DefaultOperatingTestDays = 90
+)
+
+// MaxControlCredit caps the share of a risk its controls can remove, the same
+// cap the Score Engine puts on mitigation-plan credit: controls reduce a risk,
+// they do not abolish it.
+const MaxControlCredit = 0.9
+
+// ControlTestPlan is how one control is tested, at most one plan per control
+// and kind.
+type ControlTestPlan struct {
+	ID        uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID  uuid.UUID       `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	ControlID uuid.UUID       `gorm:"type:uuid;not null;index" json:"control_id"`
+	Kind      ControlTestKind `gorm:"type:varchar(16);not null" json:"kind"`
+
+	Procedure string `gorm:"type:text" json:"procedure"`
+	// SampleSize is how many occurrences each test examines. A design
+	// walkthrough is one sample.
+	SampleSize    int        `gorm:"not null;default:1" json:"sample_size"`
+	FrequencyDays int        `gorm:"not null" json:"frequency_days"`
+	TesterID      *uuid.UUID `gorm:"type:uuid" json:"tester_id,omitempty"`
+	NextTestAt    time.Time  `gorm:"not null;index" json:"next_test_at"`
+
+	// The latest test, denormalised so effectiveness is one read per control.
+	LastTestedAt      *time.Time        `json:"last_tested_at,omitempty"`
+	LastResult        ControlTestResult `gorm:"type:varchar(16);default:''" json:"last_result,omitempty"`
+	LastEffectiveness *float64          `gorm:"type:numeric(5,4)" json:"last_effectiveness,omitempty"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+
+	// Due is computed on read: the next test date has passed.
+	Due bool `gorm:"-" json:"due"`
+}
+
+func (ControlTestPlan) TableName() string { return "control_test_plans" }
+
+// Validate normalises the plan and fills the kind's default cadence.
+func (p *ControlTestPlan) Validate() error {
+	switch p.Kind {
+	case ControlTestDesign, ControlTestOperating:
+	default:
+		return NewValidationError("kind must be design or operating")
+	}
+	p.Procedure = strings.TrimSpace(p.Procedure)
+	if p.SampleSize == 0 {
+		p.SampleSize = 1
+	}
+	if p.SampleSize < 0 || p.SampleSize > 1000 {
+		return NewValidationError("sample_size must be between 1 and 1000")
+	}
+	if p.FrequencyDays == 0 {
+		p.FrequencyDays = DefaultOperatingTestDays
+		if p.Kind == ControlTestDesign {
+			p.FrequencyDays = DefaultDesignTestDays
+		}
+	}
+	if p.FrequencyDays < 1 || p.FrequencyDays > 1095 {
+		return NewValidationError("frequency_days must be between 1 and 1095")
+	}
+	return nil
+}
+
+// ControlTest is one execution of a plan.
+type ControlTest struct {
+	ID        uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID  uuid.UUID       `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	PlanID    uuid.UUID       `gorm:"type:uuid;not null;index" json:"plan_id"`
+	ControlID uuid.UUID       `gorm:"type:uuid;not null;index" json:"control_id"`
+	Kind      ControlTestKind `gorm:"type:varchar(16);not null" json:"kind"`
+
+	TesterID      *uuid.UUID        `gorm:"type:uuid" json:"tester_id,omitempty"`
+	PerformedAt   time.Time         `gorm:"not null" json:"performed_at"`
+	SamplesTested int               `gorm:"not null" json:"samples_tested"`
+	Exceptions    int               `gorm:"not null;default:0" json:"exceptions"`
+	Result        ControlTestResult `gorm:"type:varchar(16);not null" json:"result"`
+	// Effectiveness is Score() at the time of the test, kept so a later change
+	// of the formula never rewrites history.
+	Effectiveness float64 `gorm:"type:numeric(5,4);not null" json:"effectiveness"`
+	Notes         string  `gorm:"type:text" json:"notes"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (ControlTest) TableName() string { return "control_tests" }
+
+// Validate checks the samples against the result.
+func (t *ControlTest) Validate() error {
+	switch t.Result {
+	case ControlTestPass, ControlTestExceptions, ControlTestFail:
+	default:
+		return NewValidationError("result must be pass, exceptions or fail")
+	}
+	if t.SamplesTested < 1 {
+		return NewValidationError("samples_tested must be at least 1")
+	}
+	if t.Exceptions < 0 || t.Exceptions > t.SamplesTested {
+		return NewValidationError("exceptions must be between 0 and samples_tested")
+	}
+	if t.Result == ControlTestExceptions && t.Exceptions == 0 {
+		return NewValidationError("a result with exceptions needs at least one failed sample")
+	}
+	t.Notes = strings.TrimSpace(t.Notes)
+	return nil
+}
+
+// Score is the test's effectiveness in [0,1]: the share of samples in which
+// the control worked, halved when the tester judged the exceptions
+// intolerable, and zero for a failed control — a control that failed its
+// test is not partly effective, it is not relied on.
+func (t *ControlTest) Score() float64 {
+	if t.Result == ControlTestFail || t.SamplesTested < 1 {
+		return 0
+	}
+	s := 1 - float64(t.Exceptions)/float64(t.SamplesTested)
+	if t.Result == ControlTestExceptions {
+		s /= 2
+	}
+	return math.Round(s*10000) / 10000
+}
+
+// ControlEffectiveness combines a control's latest design and operating
+// scores (nil when never tested).
+//
+// An operating test exercises the design, so both tested multiply. A design
+// tested but never shown to operate earns half its score; an untested control
+// earns nothing — the point of testing is that claimed controls stop counting
+// until someone checks them.
+func ControlEffectiveness(design, operating *float64) float64 {
+	switch {
+	case design != nil && operating != nil:
+		return *design * *operating
+	case operating != nil:
+		return *operating
+	case design != nil:
+		return *design / 2
+	default:
+		return 0
+	}
+}
+
+// DeriveResidual turns a risk's inherent score and its controls'
+// effectiveness into the share of the risk they remove and the residual
+// score. Controls are treated as independent layers — each removes its share
+// of what the others let through — and the total is capped at
+// MaxControlCredit.
+func DeriveResidual(inherent float64, effectiveness []float64) (mitigation, residual float64) {
+	pass := 1.0
+	for _, e := range effectiveness {
+		pass *= 1 - math.Max(0, math.Min(1, e))
+	}
+	mitigation = math.Min(1-pass, MaxControlCredit)
+	mitigation = math.Round(mitigation*10000) / 10000
+	residual = math.Round(inherent*(1-mitigation)*1000) / 1000
+	return mitigation, residual
+}
+
+// ResidualSourceControls marks a risk whose ResidualRisk and
+// MitigationEffectiveness were derived from control tests rather than typed.
+const ResidualSourceControls = "controls"
+
+// ControlTestRepository persists plans and tests and reaches the risks a
+// control is mapped to. Every method is tenant-scoped; Get* return
+// (nil, nil) when the row is absent.
+type ControlTestRepository interface {
+	GetControl(ctx context.Context, tenantID, id uuid.UUID) (*ComplianceControl, error)
+	ListPlans(ctx context.Context, tenantID uuid.UUID, controlIDs []uuid.UUID) ([]ControlTestPlan, error)
+	// DuePlans lists the plans whose next test is at or before `at`.
+	DuePlans(ctx context.Context, tenantID uuid.UUID, at time.Time) ([]ControlTestPlan, error)
+	GetPlan(ctx context.Context, tenantID, id uuid.UUID) (*ControlTestPlan, error)
+	SavePlan(ctx context.Context, p *ControlTestPlan) error
+	// DeletePlan removes the plan and its tests.
+	DeletePlan(ctx context.Context, tenantID, id uuid.UUID) error
+	// RecordTest stores the test and the plan's new last/next fields together.
+	RecordTest(ctx context.Context, t *ControlTest, p *ControlTestPlan) error
+	ListTests(ctx context.Context, tenantID, controlID uuid.UUID, limit int) ([]ControlTest, error)
+
+	GetRisk(ctx context.Context, tenantID, id uuid.UUID) (*Risk, error)
+	// LinkedRiskIDs lists the live risks mapped to the control.
+	LinkedRiskIDs(ctx context.Context, tenantID, controlID uuid.UUID) ([]uuid.UUID, error)
+	// MappedControls lists the live controls a risk is mapped to. Mappings to a
+	// whole framework (no control) are not controls and are left out.
+	MappedControls(ctx context.Context, tenantID, riskID uuid.UUID) ([]ComplianceControl, error)
+	// SetDerivedResidual writes the derived figures and marks their source.
+	SetDerivedResidual(ctx context.Context, tenantID, riskID uuid.UUID, mitigation, residual float64) error
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControlTest_ValidateAndScore(t *testing.T) {
+	cases := []struct {
+		name    string
+		test    ControlTest
+		wantErr bool
+		score   float64
+	}{
+		{"clean pass", ControlTest{Result: ControlTestPass, SamplesTested: 25}, false, 1},
+		{"pass within tolerance", ControlTest{Result: ControlTestPass, SamplesTested: 25, Exceptions: 1}, false, 0.96},
+		{"exceptions halve the score", ControlTest{Result: ControlTestExceptions, SamplesTested: 20, Exceptions: 4}, false, 0.4},
+		{"fail earns nothing", ControlTest{Result: ControlTestFail, SamplesTested: 10, Exceptions: 1}, false, 0},
+		{"exceptions without a failed sample", ControlTest{Result: ControlTestExceptions, SamplesTested: 5}, true, 0},
+		{"more exceptions than samples", ControlTest{Result: ControlTestPass, SamplesTested: 2, Exceptions: 3}, true, 0},
+		{"no samples", ControlTest{Result: ControlTestPass}, true, 0},
+		{"unknown result", ControlTest{Result: "maybe", SamplesTested: 1}, true, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.test.Validate()
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, ErrValidation))
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tc.score, tc.test.Score(), 1e-9)
+		})
+	}
+}
+
+func TestControlTestPlan_ValidateDefaults(t *testing.T) {
+	design := ControlTestPlan{Kind: ControlTestDesign}
+	require.NoError(t, design.Validate())
+	assert.Equal(t, DefaultDesignTestDays, design.FrequencyDays)
+	assert.Equal(t, 1, design.SampleSize)
+
+	operating := ControlTestPlan{Kind: ControlTestOperating, SampleSize: 25}
+	require.NoError(t, operating.Validate())
+	assert.Equal(t, DefaultOperatingTestDays, operating.FrequencyDays)
+
+	assert.Error(t, (&ControlTestPlan{Kind: "audit"}).Validate())
+	assert.Error(t, (&ControlTestPlan{Kind: ControlTestDesign, FrequencyDays: 2000}).Validate())
+}
+
+func TestControlEffectiveness(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	assert.Equal(t, 0.0, ControlEffectiveness(nil, nil), "an untested control earns nothing")
+	assert.InDelta(t, 0.4, ControlEffectiveness(f(0.8), nil), 1e-9, "design alone earns half")
+	assert.InDelta(t, 0.9, ControlEffectiveness(nil, f(0.9)), 1e-9)
+	assert.InDelta(t, 0.72, ControlEffectiveness(f(0.8), f(0.9)), 1e-9)
+}
+
+func TestDeriveResidual(t *testing.T) {
+	m, r := DeriveResidual(8, nil)
+	assert.Equal(t, 0.0, m)
+	assert.Equal(t, 8.0, r, "no effective control: the residual is the inherent score")
+
+	// Independent layers: 1 - (1-0.5)(1-0.5) = 0.75.
+	m, r = DeriveResidual(8, []float64{0.5, 0.5})
+	assert.InDelta(t, 0.75, m, 1e-9)
+	assert.InDelta(t, 2, r, 1e-9)
+
+	m, r = DeriveResidual(8, []float64{1, 1})
+	assert.InDelta(t, MaxControlCredit, m, 1e-9, "controls never abolish a risk")
+	assert.InDelta(t, 0.8, r, 1e-9)
+
+	_, before := DeriveResidual(8, []float64{0.9, 0.5})
+	_, after := DeriveResidual(8, []float64{0, 0.5})
+	assert.Greater(t, after, before, "a control failing its test raises the residual")
+}
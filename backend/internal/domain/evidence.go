@@ -55,6 +55,9 @@ const (
 	EvidenceSourceIntegration EvidenceSource = "integration"
 	EvidenceSourceScanner     EvidenceSource = "scanner"
 	EvidenceSourceAutomation  EvidenceSource = "automation"
+	// EvidenceSourceVendor is a questionnaire answer or document a supplier
+	// submitted through an assessment link.
+	EvidenceSourceVendor EvidenceSource = "vendor"
 )
 
 // ParseEvidenceSource validates a source (empty → manual).
@@ -63,7 +66,7 @@ func ParseEvidenceSource(s string) (EvidenceSource, error) {
 		return EvidenceSourceManual, nil
 	}
 	switch EvidenceSource(s) {
-	case EvidenceSourceManual, EvidenceSourceIntegration, EvidenceSourceScanner, EvidenceSourceAutomation:
+	case EvidenceSourceManual, EvidenceSourceIntegration, EvidenceSourceScanner, EvidenceSourceAutomation, EvidenceSourceVendor:
 		return EvidenceSource(s), nil
 	default:
 		return "", NewValidationError(fmt.Sprintf("invalid evidence source: %q", s))
@@ -144,6 +147,13 @@ type Evidence struct {
 	// (an attestation recorded inline, a link to a system of record).
 	FileRef  string `gorm:"type:text;not null;default:''" json:"file_ref"`
 	Filename string `gorm:"size:255;not null;default:''" json:"filename"`
+	// SHA256 is the hex digest of the bytes as received, computed while they were
+	// streamed to storage and re-checked on every download. It is what lets an
+	// auditor be shown that the file in front of them is the one collected on
+	// CollectedAt. Empty for evidence without a file, and for files uploaded
+	// before digests existed (those read as "unverified", never as "verified").
+	SHA256    string `gorm:"size:64;not null;default:'';index" json:"sha256"`
+	SizeBytes int64  `gorm:"not null;default:0" json:"size_bytes"`
 	// ExternalURL points at evidence that lives in another system of record. Kept
 	// distinct from FileRef so the UI never offers a download for something the
 	// product does not hold the bytes of.
@@ -173,6 +183,10 @@ type Evidence struct {
 	// reminder is noise, a reminder sent twice because the send succeeded and the
 	// stamp failed is a bug that trains people to ignore the channel.
 	ReminderSentAt *time.Time `json:"reminder_sent_at,omitempty"`
+	// ExpiryAnnouncedAt stamps when the lapse was announced to webhook
+	// subscribers (evidence.expired). Later than ValidUntil once announced; a
+	// renewal moves ValidUntil past it, which re-arms the announcement.
+	ExpiryAnnouncedAt *time.Time `json:"-"`
 
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CustodyAction is one kind of handling of an evidence artifact.
+type CustodyAction string
+
+const (
+	CustodyCollected  CustodyAction = "collected"
+	CustodyViewed     CustodyAction = "viewed"
+	CustodyDownloaded CustodyAction = "downloaded"
+	CustodyReviewed   CustodyAction = "reviewed"
+	CustodyReplaced   CustodyAction = "replaced"
+	CustodyPackaged   CustodyAction = "packaged"
+	CustodyVerified   CustodyAction = "verified"
+	// CustodyIntegrityFailure is written when the bytes read back from storage do
+	// not hash to the recorded digest. It is the one entry nobody should ever see,
+	// and the one an auditor most needs to.
+	CustodyIntegrityFailure CustodyAction = "integrity_failure"
+)
+
+// EvidenceCustodyEvent is one line of an artifact's chain of custody: who
+// handled it, how, when, and what its digest was at that moment.
+//
+// Every entry is ALSO written to the hash-chained audit trail (its id rides in
+// the audit entry's after-snapshot). The custody table is the convenient,
+// per-artifact read; the audit chain is what makes it tamper-evident — a
+// custody row edited after the fact no longer matches the chained entry that
+// names it.
+type EvidenceCustodyEvent struct {
+	ID         uuid.UUID     `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID   uuid.UUID     `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	EvidenceID uuid.UUID     `gorm:"type:uuid;not null;index" json:"evidence_id"`
+	Action     CustodyAction `gorm:"type:varchar(24);not null;index" json:"action"`
+	ActorID    *uuid.UUID    `gorm:"type:uuid;index" json:"actor_id"`
+	// SHA256 is the digest the artifact carried when this happened; for a
+	// replacement, the NEW digest (the old one is in Detail).
+	SHA256    string    `gorm:"size:64;not null;default:''" json:"sha256"`
+	Detail    string    `gorm:"type:text" json:"detail"`
+	IPAddress string    `gorm:"size:64" json:"ip_address,omitempty"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+
+	ActorEmail string `gorm:"-" json:"actor_email,omitempty"`
+}
+
+func (EvidenceCustodyEvent) TableName() string { return "evidence_custody_events" }
+
+// EvidenceCustodyRepository is the append-only store for custody entries.
+// There is no update and no delete, by construction.
+type EvidenceCustodyRepository interface {
+	AppendCustody(ctx context.Context, e *EvidenceCustodyEvent) error
+	ListCustody(ctx context.Context, tenantID, evidenceID uuid.UUID) ([]EvidenceCustodyEvent, error)
+}
+
+// EvidenceIntegrity is the outcome of re-hashing an artifact's stored bytes.
+type EvidenceIntegrity struct {
+	EvidenceID uuid.UUID `json:"evidence_id"`
+	Expected   string    `json:"expected_sha256"`
+	Actual     string    `json:"actual_sha256"`
+	SizeBytes  int64     `json:"size_bytes"`
+	// Verified is true only when a recorded digest exists AND matches. A file
+	// uploaded before digests existed is not "verified", it is "unverifiable".
+	Verified  bool      `json:"verified"`
+	Recorded  bool      `json:"recorded"`
+	CheckedAt time.Time `json:"checked_at"`
+}
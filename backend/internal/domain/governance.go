@@ -91,6 +91,11 @@ const (
 	AuditActionRevoke   AuditAction = "revoke"   // delegation revoked / access revoked
 	AuditActionLogin    AuditAction = "login"
 	AuditActionExport   AuditAction = "export"
+	// Reads are not journaled in general — a trail of every page view is a trail
+	// nobody reads. These two exist for the few records whose chain of custody
+	// is itself evidence (see EvidenceCustodyEvent).
+	AuditActionView     AuditAction = "view"
+	AuditActionDownload AuditAction = "download"
 )
 
 // AuditEvent is one immutable row in the audit trail. There is intentionally no
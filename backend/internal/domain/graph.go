@@ -0,0 +1,117 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---------------------------------------------------------------------------
+// GraphQL read API.
+//
+// Dashboards want joined views — critical risks, their assets, the open P1
+// findings on those assets and the status of the controls the risks map to —
+// which over REST is one list call per entity and one more per row. The
+// GraphQL endpoint (internal/application/graphapi) answers them in one request.
+// It only reads, and it reads through the batch-shaped port below: one query
+// per relation per request, whatever the number of parent rows.
+// ---------------------------------------------------------------------------
+
+// Page size bounds for the GraphQL lists. A list argument above
+// GraphMaxPage is refused rather than silently truncated.
+const (
+	GraphDefaultPage = 50
+	GraphMaxPage     = 500
+)
+
+// GraphRiskFilter narrows a root risk list. Values are matched
+// case-insensitively: the register holds both "critical" and "CRITICAL".
+type GraphRiskFilter struct {
+	Criticality []string
+	Status      []string
+	MinScore    *float64
+	Limit       int
+	Offset      int
+}
+
+// GraphAssetFilter narrows a root asset list.
+type GraphAssetFilter struct {
+	Criticality []string
+	Type        string
+	Limit       int
+	Offset      int
+}
+
+// GraphVulnerabilityFilter narrows a vulnerability list, at the root or under
+// an asset. Under an asset Limit and Offset are ignored: the caller pages each
+// asset's findings itself.
+type GraphVulnerabilityFilter struct {
+	Severity []string
+	Tier     []string
+	Status   []string
+	KEV      *bool
+	Limit    int
+	Offset   int
+}
+
+// GraphControlFilter narrows a root control list.
+type GraphControlFilter struct {
+	Status      []string
+	FrameworkID *uuid.UUID
+	Limit       int
+	Offset      int
+}
+
+// GraphReadRepository is the read side of the GraphQL API. Every method takes
+// the tenant and every query it runs is scoped to it, join tables included;
+// nothing in a GraphQL document can name another tenant. The relation methods
+// take a batch of parent ids and answer for all of them at once.
+type GraphReadRepository interface {
+	ListRisks(ctx context.Context, tenantID uuid.UUID, f GraphRiskFilter) ([]Risk, error)
+	ListAssets(ctx context.Context, tenantID uuid.UUID, f GraphAssetFilter) ([]Asset, error)
+	ListVulnerabilities(ctx context.Context, tenantID uuid.UUID, f GraphVulnerabilityFilter) ([]Vulnerability, error)
+	ListControls(ctx context.Context, tenantID uuid.UUID, f GraphControlFilter) ([]ComplianceControl, error)
+
+	RisksByID(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]Risk, error)
+	AssetsByID(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]Asset, error)
+	VulnerabilitiesByID(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]Vulnerability, error)
+	ControlsByID(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]ComplianceControl, error)
+
+	// AssetsOfRisks follows both the risk's own asset_id and the risk_assets
+	// links.
+	AssetsOfRisks(ctx context.Context, tenantID uuid.UUID, riskIDs []uuid.UUID) (map[uuid.UUID][]Asset, error)
+	RisksOfAssets(ctx context.Context, tenantID uuid.UUID, assetIDs []uuid.UUID) (map[uuid.UUID][]Risk, error)
+	// ControlsOfRisks follows risk_control_mappings that name a control; a
+	// mapping to a whole framework has no control to return.
+	ControlsOfRisks(ctx context.Context, tenantID uuid.UUID, riskIDs []uuid.UUID) (map[uuid.UUID][]ComplianceControl, error)
+	RisksOfControls(ctx context.Context, tenantID uuid.UUID, controlIDs []uuid.UUID) (map[uuid.UUID][]Risk, error)
+	VulnerabilitiesOfAssets(ctx context.Context, tenantID uuid.UUID, assetIDs []uuid.UUID, f GraphVulnerabilityFilter) (map[uuid.UUID][]Vulnerability, error)
+}
+
+// GraphQLPersistedQuery is a query document registered under its SHA-256, so
+// a dashboard sends the hash instead of the document on every refresh (the
+// automatic persisted query protocol). Kept per tenant: one organisation's
+// saved queries are not another's to run or enumerate.
+type GraphQLPersistedQuery struct {
+	TenantID  uuid.UUID  `gorm:"type:uuid;primaryKey" json:"tenant_id"`
+	Hash      string     `gorm:"size:64;primaryKey" json:"hash"`
+	Query     string     `gorm:"type:text;not null" json:"query"`
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (GraphQLPersistedQuery) TableName() string { return "graphql_persisted_queries" }
+
+// GraphQLPersistedQueryRepository stores persisted queries.
+type GraphQLPersistedQueryRepository interface {
+	// GetPersistedQuery returns (nil, nil) when the hash is unknown.
+	GetPersistedQuery(ctx context.Context, tenantID uuid.UUID, hash string) (*GraphQLPersistedQuery, error)
+	// SavePersistedQuery is idempotent: saving a known hash again is a no-op.
+	SavePersistedQuery(ctx context.Context, q *GraphQLPersistedQuery) error
+}
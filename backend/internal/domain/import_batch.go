@@ -0,0 +1,466 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// ---------------------------------------------------------------------------
+// Staged imports.
+//
+// Moving a register out of a spreadsheet, Eramba or ServiceNow GRC means a
+// file whose columns are named by somebody else. An import therefore happens
+// in stages:
+//
+//	upload   the file is parsed and its rows STAGED as raw strings; the
+//	         columns are matched against the entity's target fields and a
+//	         mapping is suggested
+//	dry run  the mapping is applied to every staged row; each row is
+//	         validated and classified (create / update) without writing
+//	apply    the valid rows are written in one transaction, upserting by
+//	         ExternalID, and every write is recorded on the batch
+//	rollback the batch is undone from those records: created rows are
+//	         removed, updated rows get their previous values back
+//
+// A mapping is column → target key. Keys are the fixed fields of the entity
+// (ImportTargets) plus two open families: "custom:<name>" for a risk custom
+// field and "attr:<key>" for a typed asset attribute.
+// ---------------------------------------------------------------------------
+
+// ImportEntity is what a batch imports.
+type ImportEntity string
+
+const (
+	ImportRisks       ImportEntity = "risk"
+	ImportAssets      ImportEntity = "asset"
+	ImportControls    ImportEntity = "control"
+	ImportMitigations ImportEntity = "mitigation"
+)
+
+// IsValid reports whether e is an importable entity.
+func (e ImportEntity) IsValid() bool {
+	switch e {
+	case ImportRisks, ImportAssets, ImportControls, ImportMitigations:
+		return true
+	}
+	return false
+}
+
+// ImportBatchStatus is where a batch stands.
+type ImportBatchStatus string
+
+const (
+	ImportStaged     ImportBatchStatus = "staged"
+	ImportApplied    ImportBatchStatus = "applied"
+	ImportRolledBack ImportBatchStatus = "rolled_back"
+)
+
+// ImportRecordAction says what applying a row did.
+type ImportRecordAction string
+
+const (
+	ImportCreated ImportRecordAction = "created"
+	ImportUpdated ImportRecordAction = "updated"
+)
+
+// Prefixes of the open target families.
+const (
+	ImportCustomPrefix    = "custom:"
+	ImportAttributePrefix = "attr:"
+)
+
+// Limits on one upload. A register of a few thousand rows is a migration; a
+// file past these is a data dump and belongs in a script against the API.
+const (
+	MaxImportBytes = 5 << 20
+	MaxImportRows  = 5000
+)
+
+// ImportMapping maps a source column to a target key. A column absent from the
+// map, or mapped to "", is ignored.
+type ImportMapping map[string]string
+
+// Value implements driver.Valuer for JSONB persistence.
+func (m ImportMapping) Value() (driver.Value, error) { return jsonValue(map[string]string(m)) }
+
+// Scan implements sql.Scanner for JSONB persistence.
+func (m *ImportMapping) Scan(v any) error {
+	return jsonScan(v, (*map[string]string)(m), "ImportMapping")
+}
+
+// Targets returns the distinct target keys the mapping uses.
+func (m ImportMapping) Targets() map[string]string {
+	out := make(map[string]string, len(m))
+	for col, target := range m {
+		if target != "" {
+			out[target] = col
+		}
+	}
+	return out
+}
+
+// Validate checks that every target is known for the entity (extra carries the
+// open-family targets the tenant has) and that no target is fed by two columns.
+func (m ImportMapping) Validate(entity ImportEntity, extra []ImportTarget) error {
+	known := map[string]bool{}
+	for _, t := range ImportTargets(entity) {
+		known[t.Key] = true
+	}
+	for _, t := range extra {
+		known[t.Key] = true
+	}
+	seen := map[string]string{}
+	for col, target := range m {
+		if target == "" {
+			continue
+		}
+		if !known[target] {
+			return NewValidationError(fmt.Sprintf("column %q: %q is not a field of a %s", col, target, entity))
+		}
+		if other, dup := seen[target]; dup {
+			return NewValidationError(fmt.Sprintf("columns %q and %q both map to %s", other, col, target))
+		}
+		seen[target] = col
+	}
+	for _, t := range ImportTargets(entity) {
+		if t.Required && seen[t.Key] == "" {
+			return NewValidationError(fmt.Sprintf("no column is mapped to %s, which is required", t.Key))
+		}
+	}
+	return nil
+}
+
+// ImportRowValues is one staged row: column → raw cell text.
+type ImportRowValues map[string]string
+
+// Value implements driver.Valuer for JSONB persistence.
+func (r ImportRowValues) Value() (driver.Value, error) { return jsonValue(map[string]string(r)) }
+
+// Scan implements sql.Scanner for JSONB persistence.
+func (r *ImportRowValues) Scan(v any) error {
+	return jsonScan(v, (*map[string]string)(r), "ImportRowValues")
+}
+
+// ImportColumns is the ordered header of a staged file.
+type ImportColumns []string
+
+// Value implements driver.Valuer for JSONB persistence.
+func (c ImportColumns) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(c))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner for JSONB persistence.
+func (c *ImportColumns) Scan(v any) error {
+	switch b := v.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(b, (*[]string)(c))
+	case string:
+		return json.Unmarshal([]byte(b), (*[]string)(c))
+	}
+	return fmt.Errorf("cannot scan %T into ImportColumns", v)
+}
+
+func jsonValue(m map[string]string) (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func jsonScan(v any, into *map[string]string, what string) error {
+	switch b := v.(type) {
+	case nil:
+		*into = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(b, into)
+	case string:
+		return json.Unmarshal([]byte(b), into)
+	}
+	return fmt.Errorf("cannot scan %T into %s", v, what)
+}
+
+// ImportBatch is one uploaded file and what became of it.
+type ImportBatch struct {
+	ID       uuid.UUID         `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID uuid.UUID         `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Entity   ImportEntity      `gorm:"type:varchar(16);not null" json:"entity"`
+	Status   ImportBatchStatus `gorm:"type:varchar(16);not null;default:'staged';index" json:"status"`
+	FileName string            `gorm:"size:255;not null;default:''" json:"file_name"`
+	Format   string            `gorm:"size:8;not null" json:"format"`
+
+	Columns ImportColumns `gorm:"type:jsonb" json:"columns"`
+	// Mapping is the one last dry-run or applied; a rollback does not need it
+	// (the records carry everything) but a reader asking "what went where?" does.
+	Mapping  ImportMapping `gorm:"type:jsonb" json:"mapping"`
+	RowCount int           `gorm:"not null;default:0" json:"row_count"`
+
+	Created int `gorm:"not null;default:0" json:"created"`
+	Updated int `gorm:"not null;default:0" json:"updated"`
+	Skipped int `gorm:"not null;default:0" json:"skipped"`
+
+	CreatedBy    *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	AppliedAt    *time.Time `json:"applied_at,omitempty"`
+	RolledBackAt *time.Time `json:"rolled_back_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (ImportBatch) TableName() string { return "import_batches" }
+
+// ImportBatchRow is one staged row, kept until the batch is deleted so a
+// mapping can be changed and dry-run again without uploading the file twice.
+type ImportBatchRow struct {
+	BatchID  uuid.UUID       `gorm:"type:uuid;primaryKey" json:"batch_id"`
+	RowNo    int             `gorm:"primaryKey;autoIncrement:false" json:"row"`
+	TenantID uuid.UUID       `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Values   ImportRowValues `gorm:"type:jsonb" json:"values"`
+}
+
+func (ImportBatchRow) TableName() string { return "import_batch_rows" }
+
+// ImportBatchRecord is one write an applied batch made. Previous holds the
+// columns an update overwrote, as they were; WrittenAt is the entity's
+// updated_at as the import left it, so a rollback can tell a row somebody
+// edited since from one nobody touched.
+type ImportBatchRecord struct {
+	BatchID   uuid.UUID          `gorm:"type:uuid;primaryKey" json:"batch_id"`
+	EntityID  uuid.UUID          `gorm:"type:uuid;primaryKey" json:"entity_id"`
+	TenantID  uuid.UUID          `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	RowNo     int                `gorm:"not null" json:"row"`
+	Action    ImportRecordAction `gorm:"type:varchar(8);not null" json:"action"`
+	Previous  datatypes.JSON     `gorm:"type:jsonb" json:"previous,omitempty"`
+	WrittenAt time.Time          `gorm:"not null" json:"written_at"`
+}
+
+func (ImportBatchRecord) TableName() string { return "import_batch_records" }
+
+// ImportMappingProfile is a saved mapping, e.g. "ServiceNow GRC risks", so the
+// next export from the same tool maps in one click.
+type ImportMappingProfile struct {
+	ID        uuid.UUID     `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID  uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:ux_import_profiles_tenant_name,priority:1" json:"tenant_id"`
+	Entity    ImportEntity  `gorm:"type:varchar(16);not null;uniqueIndex:ux_import_profiles_tenant_name,priority:2" json:"entity"`
+	Name      string        `gorm:"size:120;not null;uniqueIndex:ux_import_profiles_tenant_name,priority:3" json:"name"`
+	Mapping   ImportMapping `gorm:"type:jsonb" json:"mapping"`
+	CreatedBy *uuid.UUID    `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+func (ImportMappingProfile) TableName() string { return "import_mapping_profiles" }
+
+// ImportTarget is a field a column can be mapped to.
+type ImportTarget struct {
+	Key      string `json:"key"`
+	Label    string `json:"label"`
+	Required bool   `json:"required"`
+	// Aliases are the header spellings suggested onto this field, compared
+	// after normalisation (lower case, letters and digits only).
+	Aliases []string `json:"-"`
+}
+
+var importTargets = map[ImportEntity][]ImportTarget{
+	ImportRisks: {
+		{Key: "external_id", Label: "External ID", Aliases: []string{"externalid", "id", "ref", "reference", "riskid", "number", "sysid", "uexternalid", "key"}},
+		{Key: "title", Label: "Title", Required: true, Aliases: []string{"title", "name", "riskname", "risktitle", "shortdescription", "risk", "intitule", "titre", "nom"}},
+		{Key: "description", Label: "Description", Aliases: []string{"description", "details", "desc", "riskdescription"}},
+		{Key: "probability", Label: "Probability (0–1 or %)", Aliases: []string{"probability", "likelihood", "inherentlikelihood", "probabilite", "vraisemblance"}},
+		{Key: "impact", Label: "Impact (0–10)", Aliases: []string{"impact", "inherentimpact", "severity", "gravite"}},
+		{Key: "status", Label: "Status", Aliases: []string{"status", "state", "statut", "etat"}},
+		{Key: "tags", Label: "Tags", Aliases: []string{"tags", "labels", "keywords"}},
+		{Key: "frameworks", Label: "Frameworks", Aliases: []string{"frameworks", "framework", "compliance", "referentiels"}},
+		{Key: "category", Label: "Category (name or slug)", Aliases: []string{"category", "riskcategory", "categorie", "type"}},
+		{Key: "owner_email", Label: "Owner (email)", Aliases: []string{"owner", "owneremail", "riskowner", "responsable", "proprietaire"}},
+		{Key: "assignee_email", Label: "Assignee (email)", Aliases: []string{"assignee", "assigneeemail", "assignedto", "stakeholder"}},
+		{Key: "business_unit", Label: "Business unit", Aliases: []string{"businessunit", "department", "entity", "direction"}},
+		{Key: "asset_external_id", Label: "Asset (external ID or name)", Aliases: []string{"asset", "assetid", "assetexternalid", "configurationitem", "ci", "actif"}},
+	},
+	ImportAssets: {
+		{Key: "external_id", Label: "External ID", Aliases: []string{"externalid", "id", "ref", "reference", "assetid", "assettag", "sysid", "uexternalid", "key"}},
+		{Key: "name", Label: "Name", Required: true, Aliases: []string{"name", "assetname", "hostname", "title", "nom"}},
+		{Key: "type", Label: "Type (free text)", Aliases: []string{"type", "assettype", "class", "sysclassname"}},
+		{Key: "category", Label: "Category", Aliases: []string{"category", "assetcategory", "categorie"}},
+		{Key: "criticality", Label: "Criticality", Aliases: []string{"criticality", "businesscriticality", "criticite", "importance"}},
+		{Key: "owner", Label: "Owner", Aliases: []string{"owner", "ownedby", "managedby", "responsable", "proprietaire"}},
+	},
+	ImportControls: {
+		{Key: "external_id", Label: "External ID", Aliases: []string{"externalid", "id", "sysid", "controlid", "uexternalid", "key"}},
+		{Key: "framework", Label: "Framework (name or ID)", Required: true, Aliases: []string{"framework", "frameworkname", "standard", "authoritysource", "referentiel"}},
+		{Key: "reference_code", Label: "Reference code", Aliases: []string{"referencecode", "reference", "ref", "code", "number", "clause", "controlreference"}},
+		{Key: "name", Label: "Name", Required: true, Aliases: []string{"name", "title", "controlname", "shortdescription", "intitule", "nom"}},
+		{Key: "description", Label: "Description", Aliases: []string{"description", "details", "objective", "controlobjective"}},
+		{Key: "source_reference", Label: "Source reference", Aliases: []string{"sourcereference", "source", "citation"}},
+		{Key: "status", Label: "Status", Aliases: []string{"status", "state", "implementationstatus", "statut"}},
+	},
+	ImportMitigations: {
+		{Key: "external_id", Label: "External ID", Aliases: []string{"externalid", "id", "sysid", "number", "uexternalid", "key"}},
+		{Key: "title", Label: "Title", Required: true, Aliases: []string{"title", "name", "shortdescription", "mitigation", "action", "intitule"}},
+		{Key: "risk_external_id", Label: "Risk (external ID)", Required: true, Aliases: []string{"risk", "riskid", "riskexternalid", "riskref", "parentrisk", "risque"}},
+		{Key: "description", Label: "Description", Aliases: []string{"description", "details", "desc"}},
+		{Key: "status", Label: "Status", Aliases: []string{"status", "state", "statut"}},
+		{Key: "priority", Label: "Priority", Aliases: []string{"priority", "priorite"}},
+		{Key: "due_date", Label: "Due date", Aliases: []string{"duedate", "due", "deadline", "targetdate", "echeance"}},
+		{Key: "owner_email", Label: "Owner (email)", Aliases: []string{"owner", "owneremail", "responsable"}},
+		{Key: "assignee_email", Label: "Assignee (email)", Aliases: []string{"assignee", "assignedto", "assigneeemail"}},
+	},
+}
+
+// ImportTargets lists the fixed fields of an entity, in display order.
+func ImportTargets(entity ImportEntity) []ImportTarget {
+	return importTargets[entity]
+}
+
+// NormaliseImportHeader reduces a header to lower-case letters and digits, so
+// "Short description", "short_description" and "SHORT-DESCRIPTION" compare equal.
+func NormaliseImportHeader(h string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(removeAccents(h)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func removeAccents(s string) string {
+	r := strings.NewReplacer("é", "e", "è", "e", "ê", "e", "à", "a", "â", "a", "î", "i", "ô", "o", "û", "u", "ù", "u", "ç", "c",
+		"É", "E", "È", "E", "À", "A", "Ç", "C")
+	return r.Replace(s)
+}
+
+// SuggestImportMapping proposes a target for every column it recognises.
+//
+// A column is matched on its normalised header, exactly: first against the
+// fixed fields' aliases in order, then against extra (custom fields and asset
+// attributes, aliased by their name and label). Each target is suggested at
+// most once, to the first column that claims it. No fuzzy matching — a wrong
+// guess that looks right is worse than a blank the user fills in.
+func SuggestImportMapping(entity ImportEntity, columns []string, extra []ImportTarget) ImportMapping {
+	candidates := append(append([]ImportTarget{}, ImportTargets(entity)...), extra...)
+	out := ImportMapping{}
+	taken := map[string]bool{}
+	for _, col := range columns {
+		norm := NormaliseImportHeader(col)
+		if norm == "" {
+			continue
+		}
+		for _, t := range candidates {
+			if taken[t.Key] || !containsString(t.Aliases, norm) {
+				continue
+			}
+			out[col] = t.Key
+			taken[t.Key] = true
+			break
+		}
+	}
+	return out
+}
+
+// ImportRowError is one reason a row cannot be imported. Column is the source
+// column when the problem is with one cell.
+type ImportRowError struct {
+	Row    int    `json:"row"`
+	Column string `json:"column,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// ImportWrite is one row ready to be applied: either a new entity (Create, a
+// pointer to the model) or the columns to overwrite on an existing one.
+type ImportWrite struct {
+	Row      int
+	EntityID uuid.UUID
+	Create   any
+	Update   map[string]any
+}
+
+// ImportExisting is an entity a row would update, with the current values an
+// update has to merge with rather than overwrite: a risk's score inputs and
+// custom fields; for an asset, its category, typed attributes and
+// correlation fingerprints (Asset, nil for other entities).
+type ImportExisting struct {
+	ID           uuid.UUID
+	Probability  float64
+	Impact       float64
+	CustomFields datatypes.JSON
+	Asset        *Asset
+}
+
+// ImportLookups is everything the rows of a batch may refer to by a human key,
+// loaded once per dry run. Keys are lower-cased.
+type ImportLookups struct {
+	// Existing maps the ExternalID of an entity of the batch's kind to the
+	// entity: the upsert key.
+	Existing map[string]ImportExisting
+	// Users maps a tenant member's email to their user ID.
+	Users map[string]uuid.UUID
+	// Categories maps a risk category's name and slug to its ID.
+	Categories map[string]uuid.UUID
+	// Frameworks maps a compliance framework's name and ID to its ID.
+	Frameworks map[string]uuid.UUID
+	// Risks maps a risk's ExternalID to its ID (mitigation imports).
+	Risks map[string]uuid.UUID
+	// Assets maps an asset's ExternalID and name to its ID (risk imports).
+	Assets map[string]uuid.UUID
+	// CustomFields are the tenant's risk custom fields.
+	CustomFields []CustomField
+}
+
+// ImportRepository persists batches, profiles and the writes of an import.
+//
+// ABSOLUTE RULE: every method filters by tenant_id.
+type ImportRepository interface {
+	ListBatches(ctx context.Context, tenantID uuid.UUID) ([]ImportBatch, error)
+	// GetBatch returns (nil, nil) when the batch does not exist for the tenant.
+	GetBatch(ctx context.Context, tenantID, id uuid.UUID) (*ImportBatch, error)
+	// StageBatch writes a new batch and its rows.
+	StageBatch(ctx context.Context, b *ImportBatch, rows []ImportBatchRow) error
+	SaveBatch(ctx context.Context, b *ImportBatch) error
+	DeleteBatch(ctx context.Context, tenantID, id uuid.UUID) error
+	Rows(ctx context.Context, tenantID, batchID uuid.UUID) ([]ImportBatchRow, error)
+	Records(ctx context.Context, tenantID, batchID uuid.UUID) ([]ImportBatchRecord, error)
+
+	ListProfiles(ctx context.Context, tenantID uuid.UUID, entity ImportEntity) ([]ImportMappingProfile, error)
+	GetProfile(ctx context.Context, tenantID, id uuid.UUID) (*ImportMappingProfile, error)
+	SaveProfile(ctx context.Context, p *ImportMappingProfile) error
+	DeleteProfile(ctx context.Context, tenantID, id uuid.UUID) error
+
+	Lookups(ctx context.Context, tenantID uuid.UUID, entity ImportEntity) (*ImportLookups, error)
+
+	// Apply performs the writes of a batch in one transaction, records each on
+	// the batch and saves the batch (its counts and status) with them. Either
+	// every row lands or none does.
+	Apply(ctx context.Context, b *ImportBatch, writes []ImportWrite) error
+	// Rollback undoes an applied batch in one transaction: created entities are
+	// deleted, updated ones get their previous columns back. Unless force is
+	// set it refuses when any entity was modified after the import, and
+	// returns those IDs.
+	Rollback(ctx context.Context, b *ImportBatch, force bool) (conflicts []uuid.UUID, err error)
+}
@@ -0,0 +1,511 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---------------------------------------------------------------------------
+// Key Risk Indicators.
+//
+// CustomMetric stores a free-text Formula that nothing can evaluate safely. A
+// KRI is the first-class replacement: a measured value, linked to the risks it
+// is an early warning for, with green/amber/red thresholds and a declared data
+// source:
+//
+//   - query: a KRIQuery over the tenant's own risks, vulnerabilities, assets
+//     or incidents. Declarative, not SQL — every field it may read or filter
+//     on is in kriQueryFields, so a KRI definition can never become a query
+//     the platform did not write.
+//   - push:  values POSTed by another system through the API.
+//   - csv:   values uploaded as a file (typically a monthly extract).
+//
+// Every value is kept (KRIValue), so a KRI has a trend, not just a colour.
+// When the latest value moves the KRI into another band it is a crossing:
+// the automation engine is told (TriggerKRIThresholdCrossed) and, if the KRI
+// asks for it, a move into red raises the linked risks' probability and puts
+// them up for review.
+// ---------------------------------------------------------------------------
+
+// KRISource is where a KRI's values come from.
+type KRISource string
+
+const (
+	KRISourceQuery KRISource = "query"
+	KRISourcePush  KRISource = "push"
+	KRISourceCSV   KRISource = "csv"
+)
+
+// KRIDirection says which way is bad.
+type KRIDirection string
+
+const (
+	// KRIHigherIsWorse: open critical vulnerabilities, incidents this month.
+	KRIHigherIsWorse KRIDirection = "higher_is_worse"
+	// KRILowerIsWorse: backup success rate, patch coverage.
+	KRILowerIsWorse KRIDirection = "lower_is_worse"
+)
+
+// KRIStatus is the band a value falls in.
+type KRIStatus string
+
+const (
+	KRIStatusUnknown KRIStatus = "unknown" // never measured
+	KRIStatusGreen   KRIStatus = "green"
+	KRIStatusAmber   KRIStatus = "amber"
+	KRIStatusRed     KRIStatus = "red"
+)
+
+// rank orders bands for crossing detection. Unknown ranks with green: the
+// first measurement landing in green is not news, landing in red is.
+func (s KRIStatus) rank() int {
+	switch s {
+	case KRIStatusAmber:
+		return 1
+	case KRIStatusRed:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// Severity maps a band onto the automation severity scale, so a rule's
+// min_severity condition can gate on it (amber = medium, red = high).
+func (s KRIStatus) Severity() string {
+	switch s {
+	case KRIStatusRed:
+		return "high"
+	case KRIStatusAmber:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// KRICrossing describes a band change between two measurements.
+type KRICrossing struct {
+	From     KRIStatus `json:"from"`
+	To       KRIStatus `json:"to"`
+	Worsened bool      `json:"worsened"`
+}
+
+// CrossingBetween returns the crossing from prev to next, or nil when the band
+// did not change (unknown → green included: nothing to react to).
+func CrossingBetween(prev, next KRIStatus) *KRICrossing {
+	if prev == next || next == KRIStatusUnknown || prev.rank() == next.rank() {
+		return nil
+	}
+	return &KRICrossing{From: prev, To: next, Worsened: next.rank() > prev.rank()}
+}
+
+// IntoRed reports a crossing that lands in red from a better band — the one
+// that may raise linked risks' probability.
+func (c *KRICrossing) IntoRed() bool {
+	return c != nil && c.To == KRIStatusRed && c.Worsened
+}
+
+// ---------------------------------------------------------------------------
+// Declarative queries.
+// ---------------------------------------------------------------------------
+
+// KRISubject is the table a query KRI reads.
+type KRISubject string
+
+const (
+	KRISubjectRisks           KRISubject = "risks"
+	KRISubjectVulnerabilities KRISubject = "vulnerabilities"
+	KRISubjectAssets          KRISubject = "assets"
+	KRISubjectIncidents       KRISubject = "incidents"
+)
+
+// KRIAggregate is how the matching rows are reduced to one value.
+type KRIAggregate string
+
+const (
+	KRIAggCount KRIAggregate = "count"
+	KRIAggSum   KRIAggregate = "sum"
+	KRIAggAvg   KRIAggregate = "avg"
+	KRIAggMin   KRIAggregate = "min"
+	KRIAggMax   KRIAggregate = "max"
+)
+
+// KRIFilterOp is a filter comparison.
+type KRIFilterOp string
+
+const (
+	KRIOpEq  KRIFilterOp = "eq"
+	KRIOpNeq KRIFilterOp = "neq"
+	KRIOpIn  KRIFilterOp = "in"
+	KRIOpGte KRIFilterOp = "gte" // numeric fields only
+	KRIOpLte KRIFilterOp = "lte" // numeric fields only
+)
+
+// KRIFieldKind is how a whitelisted field compares.
+type KRIFieldKind int
+
+const (
+	KRIFieldText KRIFieldKind = iota
+	KRIFieldNumber
+	KRIFieldBool
+)
+
+// kriQueryFields is the whole surface a query KRI can touch: per subject, the
+// fields it may filter or aggregate on. The field name is the column name, so
+// the evaluator interpolates only strings found here.
+var kriQueryFields = map[KRISubject]map[string]KRIFieldKind{
+	KRISubjectRisks: {
+		"status": KRIFieldText, "criticality": KRIFieldText, "lifecycle_state": KRIFieldText,
+		"business_unit": KRIFieldText, "category_id": KRIFieldText,
+		"score": KRIFieldNumber, "smart_score": KRIFieldNumber, "probability": KRIFieldNumber, "impact": KRIFieldNumber,
+	},
+	KRISubjectVulnerabilities: {
+		"severity": KRIFieldText, "status": KRIFieldText, "priority_tier": KRIFieldText, "source": KRIFieldText,
+		"kev":        KRIFieldBool,
+		"cvss_score": KRIFieldNumber, "epss": KRIFieldNumber, "priority_score": KRIFieldNumber,
+	},
+	KRISubjectAssets: {
+		"criticality": KRIFieldText, "type": KRIFieldText, "category": KRIFieldText, "source": KRIFieldText,
+	},
+	KRISubjectIncidents: {
+		"severity": KRIFieldText, "status": KRIFieldText, "incident_type": KRIFieldText, "origin": KRIFieldText,
+	},
+}
+
+// KRIQueryField returns the kind of a whitelisted field, and false when the
+// field is not queryable on that subject.
+func KRIQueryField(subject KRISubject, field string) (KRIFieldKind, bool) {
+	k, ok := kriQueryFields[subject][field]
+	return k, ok
+}
+
+// KRIFilter restricts the rows a query counts. Value is used by eq/neq/gte/lte,
+// Values by in.
+type KRIFilter struct {
+	Field  string      `json:"field"`
+	Op     KRIFilterOp `json:"op"`
+	Value  string      `json:"value,omitempty"`
+	Values []string    `json:"values,omitempty"`
+}
+
+// KRIQuery is a query KRI's definition.
+type KRIQuery struct {
+	Subject   KRISubject   `json:"subject"`
+	Aggregate KRIAggregate `json:"aggregate"`
+	// Field is the numeric field aggregated; empty for count.
+	Field   string      `json:"field,omitempty"`
+	Filters []KRIFilter `json:"filters,omitempty"`
+	// WindowDays limits the rows to those created in the last N days
+	// ("incidents this month"). 0 = no window.
+	WindowDays int `json:"window_days,omitempty"`
+}
+
+// Validate checks the query only touches whitelisted fields in ways that make
+// sense for their kind.
+func (q KRIQuery) Validate() error {
+	fields, ok := kriQueryFields[q.Subject]
+	if !ok {
+		return NewValidationError("kri query subject must be risks, vulnerabilities, assets or incidents")
+	}
+	switch q.Aggregate {
+	case KRIAggCount:
+		if q.Field != "" {
+			return NewValidationError("a count kri query takes no field")
+		}
+	case KRIAggSum, KRIAggAvg, KRIAggMin, KRIAggMax:
+		if fields[q.Field] != KRIFieldNumber || q.Field == "" {
+			return NewValidationError(fmt.Sprintf("%s needs a numeric field of %s", q.Aggregate, q.Subject))
+		}
+	default:
+		return NewValidationError("kri query aggregate must be count, sum, avg, min or max")
+	}
+	if q.WindowDays < 0 || q.WindowDays > 3660 {
+		return NewValidationError("kri query window_days must be between 0 and 3660")
+	}
+	for _, f := range q.Filters {
+		kind, ok := fields[f.Field]
+		if !ok {
+			return NewValidationError(fmt.Sprintf("%s cannot be filtered on %q", q.Subject, f.Field))
+		}
+		switch f.Op {
+		case KRIOpEq, KRIOpNeq:
+			if kind == KRIFieldBool && f.Value != "true" && f.Value != "false" {
+				return NewValidationError(fmt.Sprintf("%s is true or false", f.Field))
+			}
+		case KRIOpIn:
+			if len(f.Values) == 0 {
+				return NewValidationError(fmt.Sprintf("filter in on %s needs values", f.Field))
+			}
+		case KRIOpGte, KRIOpLte:
+			if kind != KRIFieldNumber {
+				return NewValidationError(fmt.Sprintf("%s is not numeric", f.Field))
+			}
+			if _, err := parseKRIFloat(f.Value); err != nil {
+				return NewValidationError(fmt.Sprintf("%s %s needs a number", f.Field, f.Op))
+			}
+		default:
+			return NewValidationError("kri filter op must be eq, neq, in, gte or lte")
+		}
+	}
+	return nil
+}
+
+// Value/Scan let GORM persist the query as a jsonb column.
+func (q KRIQuery) Value() (driver.Value, error) { return json.Marshal(q) }
+
+func (q *KRIQuery) Scan(value interface{}) error {
+	if value == nil {
+		*q = KRIQuery{}
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		if s, ok := value.(string); ok {
+			b = []byte(s)
+		} else {
+			return fmt.Errorf("kri query: unsupported scan type %T", value)
+		}
+	}
+	if len(b) == 0 {
+		*q = KRIQuery{}
+		return nil
+	}
+	return json.Unmarshal(b, q)
+}
+
+// ParseKRIValue reads a measured value, accepting a decimal comma (French
+// spreadsheets export "12,5").
+func ParseKRIValue(s string) (float64, error) {
+	return parseKRIFloat(s)
+}
+
+func parseKRIFloat(s string) (float64, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	if strings.Count(s, ",") == 1 && !strings.Contains(s, ".") {
+		s = strings.Replace(s, ",", ".", 1)
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("not a finite number")
+	}
+	return v, nil
+}
+
+// ---------------------------------------------------------------------------
+// The indicator and its values.
+// ---------------------------------------------------------------------------
+
+// Default and floor of a query KRI's refresh cadence, and the default step a
+// red crossing adds to a linked risk's probability.
+const (
+	DefaultKRIRefreshMinutes  = 60
+	MinKRIRefreshMinutes      = 5
+	DefaultKRIProbabilityBump = 0.1
+	maxKRIProbabilityStep     = 0.5
+)
+
+// KRI is a key risk indicator.
+type KRI struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+
+	Name        string `gorm:"size:160;not null" json:"name"`
+	Description string `gorm:"type:text" json:"description"`
+	Unit        string `gorm:"size:32" json:"unit"`
+
+	Source KRISource `gorm:"type:varchar(16);not null;index" json:"source"`
+	// Query is read only when Source is query.
+	Query          KRIQuery `gorm:"type:jsonb" json:"query"`
+	RefreshMinutes int      `gorm:"default:60" json:"refresh_minutes"`
+
+	Direction      KRIDirection `gorm:"type:varchar(20);not null" json:"direction"`
+	AmberThreshold float64      `gorm:"type:numeric(18,4)" json:"amber_threshold"`
+	RedThreshold   float64      `gorm:"type:numeric(18,4)" json:"red_threshold"`
+
+	// RiskIDs are the risks this indicator is an early warning for.
+	RiskIDs StringList `gorm:"type:jsonb" json:"risk_ids"`
+	// BumpProbability raises each linked risk's probability by ProbabilityStep
+	// (and puts it up for review) when the KRI crosses into red.
+	BumpProbability bool    `gorm:"default:false" json:"bump_probability"`
+	ProbabilityStep float64 `gorm:"type:numeric(4,3);default:0.1" json:"probability_step"`
+
+	// Current state, denormalised from the latest value.
+	Status         KRIStatus  `gorm:"type:varchar(12);default:'unknown';index" json:"status"`
+	LastValue      *float64   `gorm:"type:numeric(18,4)" json:"last_value"`
+	LastMeasuredAt *time.Time `json:"last_measured_at"`
+	// LastError is the last query evaluation failure, cleared on success.
+	LastError string `gorm:"type:text" json:"last_error,omitempty"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TableName pins the table name.
+func (KRI) TableName() string { return "key_risk_indicators" }
+
+// Validate normalises and checks a KRI before persistence.
+func (k *KRI) Validate() error {
+	k.Name = strings.TrimSpace(k.Name)
+	k.Unit = strings.TrimSpace(k.Unit)
+	if k.Name == "" {
+		return NewValidationError("kri name is required")
+	}
+	switch k.Source {
+	case KRISourceQuery:
+		if err := k.Query.Validate(); err != nil {
+			return err
+		}
+		if k.RefreshMinutes == 0 {
+			k.RefreshMinutes = DefaultKRIRefreshMinutes
+		}
+		if k.RefreshMinutes < MinKRIRefreshMinutes {
+			return NewValidationError(fmt.Sprintf("refresh_minutes must be at least %d", MinKRIRefreshMinutes))
+		}
+	case KRISourcePush, KRISourceCSV:
+		k.Query = KRIQuery{}
+	default:
+		return NewValidationError("kri source must be query, push or csv")
+	}
+	switch k.Direction {
+	case "":
+		k.Direction = KRIHigherIsWorse
+		fallthrough
+	case KRIHigherIsWorse:
+		if k.AmberThreshold > k.RedThreshold {
+			return NewValidationError("when higher is worse, the amber threshold cannot be above the red one")
+		}
+	case KRILowerIsWorse:
+		if k.AmberThreshold < k.RedThreshold {
+			return NewValidationError("when lower is worse, the amber threshold cannot be below the red one")
+		}
+	default:
+		return NewValidationError("kri direction must be higher_is_worse or lower_is_worse")
+	}
+	for _, id := range k.RiskIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return NewValidationError("risk_ids must be risk uuids")
+		}
+	}
+	if k.BumpProbability {
+		if len(k.RiskIDs) == 0 {
+			return NewValidationError("bump_probability needs at least one linked risk")
+		}
+		if k.ProbabilityStep == 0 {
+			k.ProbabilityStep = DefaultKRIProbabilityBump
+		}
+		if k.ProbabilityStep < 0 || k.ProbabilityStep > maxKRIProbabilityStep {
+			return NewValidationError("probability_step must be between 0 and 0.5")
+		}
+	}
+	if k.Status == "" {
+		k.Status = KRIStatusUnknown
+	}
+	return nil
+}
+
+// Classify returns the band a value falls in. A value exactly on a threshold
+// is in that threshold's band.
+func (k *KRI) Classify(v float64) KRIStatus {
+	if k.Direction == KRILowerIsWorse {
+		switch {
+		case v <= k.RedThreshold:
+			return KRIStatusRed
+		case v <= k.AmberThreshold:
+			return KRIStatusAmber
+		}
+		return KRIStatusGreen
+	}
+	switch {
+	case v >= k.RedThreshold:
+		return KRIStatusRed
+	case v >= k.AmberThreshold:
+		return KRIStatusAmber
+	}
+	return KRIStatusGreen
+}
+
+// RefreshDue reports whether a query KRI should be re-evaluated at now.
+func (k *KRI) RefreshDue(now time.Time) bool {
+	if k.Source != KRISourceQuery {
+		return false
+	}
+	if k.LastMeasuredAt == nil {
+		return true
+	}
+	every := k.RefreshMinutes
+	if every < MinKRIRefreshMinutes {
+		every = DefaultKRIRefreshMinutes
+	}
+	return !now.Before(k.LastMeasuredAt.Add(time.Duration(every) * time.Minute))
+}
+
+// LinkedRiskIDs parses RiskIDs, skipping anything malformed.
+func (k *KRI) LinkedRiskIDs() []uuid.UUID {
+	out := make([]uuid.UUID, 0, len(k.RiskIDs))
+	for _, s := range k.RiskIDs {
+		if id, err := uuid.Parse(s); err == nil {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// Links reports whether the KRI is linked to a risk.
+func (k *KRI) Links(riskID uuid.UUID) bool {
+	for _, id := range k.LinkedRiskIDs() {
+		if id == riskID {
+			return true
+		}
+	}
+	return false
+}
+
+// KRIValue is one measurement, kept forever: the trend is the point.
+type KRIValue struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	KRIID      uuid.UUID  `gorm:"column:kri_id;type:uuid;not null;index" json:"kri_id"`
+	Value      float64    `gorm:"type:numeric(18,4)" json:"value"`
+	Status     KRIStatus  `gorm:"type:varchar(12)" json:"status"`
+	Source     KRISource  `gorm:"type:varchar(16)" json:"source"`
+	MeasuredAt time.Time  `gorm:"not null;index" json:"measured_at"`
+	RecordedBy *uuid.UUID `gorm:"type:uuid" json:"recorded_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// TableName pins the table name.
+func (KRIValue) TableName() string { return "kri_values" }
+
+// KRIRepository persists KRIs and their history. Every method but
+// ListQueryKRIs is tenant-scoped; ListQueryKRIs is the refresh worker's
+// cross-tenant sweep.
+type KRIRepository interface {
+	List(ctx context.Context, tenantID uuid.UUID) ([]KRI, error)
+	// Get returns (nil, nil) when the KRI does not exist in the tenant.
+	Get(ctx context.Context, tenantID, id uuid.UUID) (*KRI, error)
+	Save(ctx context.Context, k *KRI) error
+	// Delete removes the KRI and its history.
+	Delete(ctx context.Context, tenantID, id uuid.UUID) error
+	ListQueryKRIs(ctx context.Context) ([]KRI, error)
+	// RecordValues appends values and saves the KRI's current state in one
+	// transaction, so history and status never disagree.
+	RecordValues(ctx context.Context, k *KRI, values []KRIValue) error
+	// History returns a KRI's values measured at or after since, oldest first.
+	History(ctx context.Context, tenantID, kriID uuid.UUID, since time.Time) ([]KRIValue, error)
+}
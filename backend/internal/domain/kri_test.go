@@ -0,0 +1,147 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestKRIQuery_Validate(t *testing.T) {
+	ok := []KRIQuery{
+		{Subject: KRISubjectVulnerabilities, Aggregate: KRIAggCount, Filters: []KRIFilter{
+			{Field: "kev", Op: KRIOpEq, Value: "true"},
+			{Field: "status", Op: KRIOpIn, Values: []string{"open", "in_progress"}},
+			{Field: "cvss_score", Op: KRIOpGte, Value: "9,0"},
+		}},
+		{Subject: KRISubjectRisks, Aggregate: KRIAggAvg, Field: "score"},
+		{Subject: KRISubjectIncidents, Aggregate: KRIAggCount, WindowDays: 30},
+	}
+	for i, q := range ok {
+		if err := q.Validate(); err != nil {
+			t.Fatalf("case %d refused: %v", i, err)
+		}
+	}
+
+	bad := map[string]KRIQuery{
+		"unknown subject":        {Subject: "users", Aggregate: KRIAggCount},
+		"sum without field":      {Subject: KRISubjectRisks, Aggregate: KRIAggSum},
+		"sum of text":            {Subject: KRISubjectRisks, Aggregate: KRIAggSum, Field: "status"},
+		"count with field":       {Subject: KRISubjectRisks, Aggregate: KRIAggCount, Field: "score"},
+		"column not whitelisted": {Subject: KRISubjectRisks, Aggregate: KRIAggCount, Filters: []KRIFilter{{Field: "tenant_id", Op: KRIOpEq, Value: "x"}}},
+		"injection as field":     {Subject: KRISubjectRisks, Aggregate: KRIAggCount, Filters: []KRIFilter{{Field: "1=1 OR status", Op: KRIOpEq, Value: "x"}}},
+		"gte on text":            {Subject: KRISubjectRisks, Aggregate: KRIAggCount, Filters: []KRIFilter{{Field: "status", Op: KRIOpGte, Value: "1"}}},
+		"gte not a number":       {Subject: KRISubjectRisks, Aggregate: KRIAggCount, Filters: []KRIFilter{{Field: "score", Op: KRIOpGte, Value: "high"}}},
+		"bool not a bool":        {Subject: KRISubjectVulnerabilities, Aggregate: KRIAggCount, Filters: []KRIFilter{{Field: "kev", Op: KRIOpEq, Value: "yes"}}},
+		"in without values":      {Subject: KRISubjectRisks, Aggregate: KRIAggCount, Filters: []KRIFilter{{Field: "status", Op: KRIOpIn}}},
+	}
+	for name, q := range bad {
+		if err := q.Validate(); !errors.Is(err, ErrValidation) {
+			t.Fatalf("%s: want validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestKRI_ValidateAndClassify(t *testing.T) {
+	riskID := uuid.NewString()
+	k := KRI{Name: " Open KEV ", Source: KRISourcePush, AmberThreshold: 5, RedThreshold: 10,
+		RiskIDs: StringList{riskID}, BumpProbability: true,
+		Query: KRIQuery{Subject: KRISubjectRisks}}
+	if err := k.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if k.Name != "Open KEV" || k.Direction != KRIHigherIsWorse || k.ProbabilityStep != DefaultKRIProbabilityBump || k.Status != KRIStatusUnknown {
+		t.Fatalf("defaults not applied: %+v", k)
+	}
+	if k.Query.Subject != "" {
+		t.Fatal("a pushed KRI carries no query")
+	}
+	for v, want := range map[float64]KRIStatus{4.9: KRIStatusGreen, 5: KRIStatusAmber, 9.99: KRIStatusAmber, 10: KRIStatusRed} {
+		if got := k.Classify(v); got != want {
+			t.Fatalf("higher is worse, %v: got %s want %s", v, got, want)
+		}
+	}
+
+	backup := KRI{Name: "Backup success", Source: KRISourceCSV, Direction: KRILowerIsWorse, AmberThreshold: 98, RedThreshold: 95, Unit: "%"}
+	if err := backup.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for v, want := range map[float64]KRIStatus{99: KRIStatusGreen, 98: KRIStatusAmber, 95: KRIStatusRed, 80: KRIStatusRed} {
+		if got := backup.Classify(v); got != want {
+			t.Fatalf("lower is worse, %v: got %s want %s", v, got, want)
+		}
+	}
+
+	bad := map[string]KRI{
+		"no name":               {Source: KRISourcePush},
+		"unknown source":        {Name: "x", Source: "formula"},
+		"inverted thresholds":   {Name: "x", Source: KRISourcePush, AmberThreshold: 10, RedThreshold: 5},
+		"inverted lower":        {Name: "x", Source: KRISourcePush, Direction: KRILowerIsWorse, AmberThreshold: 90, RedThreshold: 95},
+		"query without a query": {Name: "x", Source: KRISourceQuery},
+		"refresh too often":     {Name: "x", Source: KRISourceQuery, RefreshMinutes: 1, Query: KRIQuery{Subject: KRISubjectRisks, Aggregate: KRIAggCount}},
+		"bump without risks":    {Name: "x", Source: KRISourcePush, BumpProbability: true},
+		"bump too large":        {Name: "x", Source: KRISourcePush, BumpProbability: true, RiskIDs: StringList{riskID}, ProbabilityStep: 0.8},
+		"bad risk id":           {Name: "x", Source: KRISourcePush, RiskIDs: StringList{"R-12"}},
+	}
+	for name, k := range bad {
+		if err := k.Validate(); !errors.Is(err, ErrValidation) {
+			t.Fatalf("%s: want validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestCrossingBetween(t *testing.T) {
+	if c := CrossingBetween(KRIStatusUnknown, KRIStatusGreen); c != nil {
+		t.Fatalf("a first green reading is not a crossing: %+v", c)
+	}
+	if c := CrossingBetween(KRIStatusAmber, KRIStatusAmber); c != nil {
+		t.Fatal("same band is not a crossing")
+	}
+	if c := CrossingBetween(KRIStatusUnknown, KRIStatusRed); !c.IntoRed() {
+		t.Fatalf("a first red reading is news: %+v", c)
+	}
+	if c := CrossingBetween(KRIStatusRed, KRIStatusAmber); c == nil || c.Worsened || c.IntoRed() {
+		t.Fatalf("red → amber is an improving crossing: %+v", c)
+	}
+	if c := CrossingBetween(KRIStatusGreen, KRIStatusAmber); c == nil || !c.Worsened || c.IntoRed() {
+		t.Fatalf("green → amber worsens without reaching red: %+v", c)
+	}
+}
+
+func TestKRI_RefreshDue(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	k := KRI{Source: KRISourceQuery, RefreshMinutes: 60}
+	if !k.RefreshDue(now) {
+		t.Fatal("never measured → due")
+	}
+	last := now.Add(-30 * time.Minute)
+	k.LastMeasuredAt = &last
+	if k.RefreshDue(now) {
+		t.Fatal("measured 30 minutes ago on an hourly cadence → not due")
+	}
+	if !k.RefreshDue(now.Add(30 * time.Minute)) {
+		t.Fatal("an hour later → due")
+	}
+	k.Source = KRISourcePush
+	if k.RefreshDue(now.Add(24 * time.Hour)) {
+		t.Fatal("pushed values are never refreshed by the platform")
+	}
+}
+
+func TestParseKRIValue(t *testing.T) {
+	for in, want := range map[string]float64{"12.5": 12.5, " 12,5 ": 12.5, "1 200": 1200, "-3": -3} {
+		got, err := ParseKRIValue(in)
+		if err != nil || got != want {
+			t.Fatalf("%q: got %v, %v", in, got, err)
+		}
+	}
+	for _, in := range []string{"", "abc", "12abc", "NaN", "1,2,3"} {
+		if _, err := ParseKRIValue(in); err == nil {
+			t.Fatalf("%q should not parse", in)
+		}
+	}
+}
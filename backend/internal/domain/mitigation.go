@@ -92,6 +92,10 @@ type Mitigation struct {
 	// Link to scanner config if auto-detected
 	ScannerConfigID *uuid.UUID `gorm:"type:uuid;index" json:"scanner_config_id"`
 
+	// ExternalID is the plan's key in the tool it was imported from — the
+	// upsert key of a re-import. Empty for plans created here.
+	ExternalID string `gorm:"size:255;index" json:"external_id,omitempty"`
+
 	// Timestamps
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
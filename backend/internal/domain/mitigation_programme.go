@@ -0,0 +1,605 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---------------------------------------------------------------------------
+// Mitigation programmes.
+//
+// A Mitigation treats exactly one risk. A MitigationProgramme is one project —
+// "deploy EDR fleet-wide" — that treats many risks and strengthens many
+// controls at once, so it is planned once instead of being copied onto twenty
+// risks. Each linked risk states the share of it the programme removes once
+// delivered (ProgrammeRiskLink.ExpectedReduction).
+//
+// The programme's work is a set of ProgrammeActions with effort estimates,
+// an assignee and finish-to-start dependencies. ScheduleProgramme lays them
+// out against each assignee's capacity, finds the critical path and projects
+// the completion date; a programme projected past its target date delivers
+// only the share of its work done by then, and every linked risk's expected
+// residual moves with it (ProjectResidual).
+// ---------------------------------------------------------------------------
+
+// MaxProgrammeReduction caps what one programme claims to remove from a risk,
+// the same cap controls and mitigation plans get.
+const MaxProgrammeReduction = MaxControlCredit
+
+// MaxProgrammeActions bounds a programme's work breakdown. Beyond it the
+// programme is a portfolio and should be split.
+const MaxProgrammeActions = 500
+
+// MitigationProgramme is a mitigation project shared by many risks and
+// controls. Status reuses the plan statuses.
+type MitigationProgramme struct {
+	ID          uuid.UUID        `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID    uuid.UUID        `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Title       string           `gorm:"size:255;not null" json:"title"`
+	Description string           `gorm:"type:text" json:"description"`
+	Status      MitigationStatus `gorm:"type:varchar(20);not null;default:'PLANNED'" json:"status"`
+	OwnerID     *uuid.UUID       `gorm:"type:uuid;index" json:"owner_id,omitempty"`
+
+	// StartDate is when work may begin; the scheduler never plans before it,
+	// nor before today.
+	StartDate time.Time `gorm:"not null" json:"start_date"`
+	// TargetDate is the committed delivery date. Optional: without one there
+	// is nothing to slip against.
+	TargetDate *time.Time `json:"target_date,omitempty"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+
+	// Computed on read.
+	RiskCount    int        `gorm:"-" json:"risk_count"`
+	ControlCount int        `gorm:"-" json:"control_count"`
+	Progress     int        `gorm:"-" json:"progress"`
+	ProjectedEnd *time.Time `gorm:"-" json:"projected_end,omitempty"`
+	SlipDays     int        `gorm:"-" json:"slip_days"`
+}
+
+func (MitigationProgramme) TableName() string { return "mitigation_programmes" }
+
+// Validate normalises the programme.
+func (p *MitigationProgramme) Validate() error {
+	p.Title = strings.TrimSpace(p.Title)
+	p.Description = strings.TrimSpace(p.Description)
+	if p.Title == "" {
+		return NewValidationError("title is required")
+	}
+	if len(p.Title) > 255 {
+		return NewValidationError("title must be at most 255 characters")
+	}
+	if p.Status == "" {
+		p.Status = MitigationPlanned
+	}
+	switch p.Status {
+	case MitigationPlanned, MitigationInProgress, MitigationReview, MitigationDone, MitigationCancelled:
+	default:
+		return NewValidationError("invalid status")
+	}
+	if p.StartDate.IsZero() {
+		return NewValidationError("start_date is required")
+	}
+	p.StartDate = dateOf(p.StartDate)
+	if p.TargetDate != nil {
+		t := dateOf(*p.TargetDate)
+		if t.Before(p.StartDate) {
+			return NewValidationError("target_date cannot be before start_date")
+		}
+		p.TargetDate = &t
+	}
+	return nil
+}
+
+// ProgrammeRiskLink ties a programme to a risk it treats.
+type ProgrammeRiskLink struct {
+	TenantID    uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	ProgrammeID uuid.UUID `gorm:"type:uuid;primaryKey" json:"programme_id"`
+	RiskID      uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"risk_id"`
+	// ExpectedReduction is the share of the risk the programme removes once
+	// delivered, in [0, MaxProgrammeReduction].
+	ExpectedReduction float64   `gorm:"type:numeric(5,4);not null" json:"expected_reduction"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func (ProgrammeRiskLink) TableName() string { return "mitigation_programme_risks" }
+
+// Validate checks the expected reduction.
+func (l *ProgrammeRiskLink) Validate() error {
+	if l.ExpectedReduction < 0 || l.ExpectedReduction > MaxProgrammeReduction {
+		return NewValidationError("expected_reduction must be between 0 and 0.9")
+	}
+	l.ExpectedReduction = math.Round(l.ExpectedReduction*10000) / 10000
+	return nil
+}
+
+// ProgrammeControlLink ties a programme to a compliance control it implements
+// or strengthens.
+type ProgrammeControlLink struct {
+	TenantID    uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	ProgrammeID uuid.UUID `gorm:"type:uuid;primaryKey" json:"programme_id"`
+	ControlID   uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"control_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (ProgrammeControlLink) TableName() string { return "mitigation_programme_controls" }
+
+// ProgrammeAction is one piece of a programme's work.
+type ProgrammeAction struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID    uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	ProgrammeID uuid.UUID `gorm:"type:uuid;not null;index" json:"programme_id"`
+	Title       string    `gorm:"size:255;not null" json:"title"`
+	// EffortDays is the estimate in person-days. Zero makes a milestone.
+	EffortDays float64    `gorm:"type:numeric(8,2);not null;default:0" json:"effort_days"`
+	AssigneeID *uuid.UUID `gorm:"type:uuid;index" json:"assignee_id,omitempty"`
+	Order      int        `gorm:"not null;default:0" json:"order"`
+
+	Completed   bool       `gorm:"not null;default:false" json:"completed"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// DependsOn lists the actions that must finish before this one starts.
+	// Stored in programme_action_dependencies.
+	DependsOn []uuid.UUID `gorm:"-" json:"depends_on"`
+}
+
+func (ProgrammeAction) TableName() string { return "programme_actions" }
+
+// Validate normalises the action.
+func (a *ProgrammeAction) Validate() error {
+	a.Title = strings.TrimSpace(a.Title)
+	if a.Title == "" {
+		return NewValidationError("title is required")
+	}
+	if len(a.Title) > 255 {
+		return NewValidationError("title must be at most 255 characters")
+	}
+	if a.EffortDays < 0 || a.EffortDays > 10000 {
+		return NewValidationError("effort_days must be between 0 and 10000")
+	}
+	for _, d := range a.DependsOn {
+		if d == a.ID {
+			return NewValidationError("an action cannot depend on itself")
+		}
+	}
+	return nil
+}
+
+// ProgrammeDependency is one finish-to-start edge: ActionID starts once
+// DependsOnID has finished.
+type ProgrammeDependency struct {
+	TenantID    uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	ProgrammeID uuid.UUID `gorm:"type:uuid;not null;index" json:"programme_id"`
+	ActionID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"action_id"`
+	DependsOnID uuid.UUID `gorm:"type:uuid;primaryKey" json:"depends_on_id"`
+}
+
+func (ProgrammeDependency) TableName() string { return "programme_action_dependencies" }
+
+// AssigneeCapacity is the share of a person's working week available to
+// programme work, as a full-time equivalent in (0, 1]. A person without a row
+// is full time.
+type AssigneeCapacity struct {
+	TenantID  uuid.UUID `gorm:"type:uuid;primaryKey" json:"tenant_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	FTE       float64   `gorm:"type:numeric(4,2);not null" json:"fte"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (AssigneeCapacity) TableName() string { return "assignee_capacities" }
+
+// Validate checks the FTE.
+func (c *AssigneeCapacity) Validate() error {
+	if c.FTE <= 0 || c.FTE > 1 {
+		return NewValidationError("fte must be greater than 0 and at most 1")
+	}
+	c.FTE = math.Round(c.FTE*100) / 100
+	if c.FTE == 0 {
+		return NewValidationError("fte must be at least 0.01")
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Scheduling
+// ---------------------------------------------------------------------------
+
+// ScheduledAction is one action laid out on the calendar.
+type ScheduledAction struct {
+	ActionID     uuid.UUID  `json:"action_id"`
+	Title        string     `json:"title"`
+	AssigneeID   *uuid.UUID `json:"assignee_id,omitempty"`
+	EffortDays   float64    `json:"effort_days"`
+	DurationDays int        `json:"duration_days"`
+	Start        time.Time  `json:"start"`
+	Finish       time.Time  `json:"finish"`
+	// SlackDays is how many working days the action can slip without moving
+	// the programme's end.
+	SlackDays int  `json:"slack_days"`
+	Critical  bool `json:"critical"`
+	Completed bool `json:"completed"`
+}
+
+// ProgrammeSchedule is the scheduler's answer for one programme.
+type ProgrammeSchedule struct {
+	Start        time.Time  `json:"start"`
+	ProjectedEnd time.Time  `json:"projected_end"`
+	TargetDate   *time.Time `json:"target_date,omitempty"`
+	// SlipDays is the calendar days the projected end lands after the target
+	// (negative when ahead, zero without a target).
+	SlipDays     int               `json:"slip_days"`
+	Progress     int               `json:"progress"`
+	CriticalPath []uuid.UUID       `json:"critical_path"`
+	Actions      []ScheduledAction `json:"actions"`
+}
+
+// ShareDoneBy is the share of the programme's effort projected complete by t:
+// completed actions plus the open ones scheduled to finish on or before it.
+// A programme without effort is all-or-nothing on its projected end.
+func (s *ProgrammeSchedule) ShareDoneBy(t time.Time) float64 {
+	t = dateOf(t)
+	var total, done float64
+	for _, a := range s.Actions {
+		total += a.EffortDays
+		if a.Completed || !a.Finish.After(t) {
+			done += a.EffortDays
+		}
+	}
+	if total == 0 {
+		if s.ProjectedEnd.After(t) {
+			return 0
+		}
+		return 1
+	}
+	return done / total
+}
+
+// ScheduleProgramme lays the open actions out from `start` in working days
+// (Monday to Friday).
+//
+// An action lasts ceil(effort / FTE) working days for its assignee's FTE
+// (capacity, 1 when absent). It starts once every action it depends on has
+// finished and its assignee is free: one person works one action at a time,
+// so two independent actions on the same person run one after the other.
+// When several actions are ready, the one with the longest chain of work
+// behind it goes first. Completed actions take no time.
+//
+// The critical path is the open actions with no slack, computed over both the
+// dependencies and the assignee's sequence — a person who is the bottleneck
+// is on the critical path even where the dependency graph says otherwise.
+//
+// Capacity is per programme: the same person on two programmes is counted
+// fully in each.
+func ScheduleProgramme(actions []ProgrammeAction, deps []ProgrammeDependency, capacity map[uuid.UUID]float64, start time.Time) (*ProgrammeSchedule, error) {
+	start = nextWorkingDay(dateOf(start))
+	n := len(actions)
+	index := make(map[uuid.UUID]int, n)
+	for i, a := range actions {
+		index[a.ID] = i
+	}
+	preds := make([][]int, n)
+	succs := make([][]int, n)
+	for _, d := range deps {
+		a, ok1 := index[d.ActionID]
+		b, ok2 := index[d.DependsOnID]
+		if !ok1 || !ok2 || a == b {
+			continue
+		}
+		preds[a] = append(preds[a], b)
+		succs[b] = append(succs[b], a)
+	}
+
+	dur := make([]int, n)
+	for i, a := range actions {
+		if a.Completed || a.EffortDays <= 0 {
+			continue
+		}
+		fte := 1.0
+		if a.AssigneeID != nil {
+			if c, ok := capacity[*a.AssigneeID]; ok && c > 0 {
+				fte = c
+			}
+		}
+		dur[i] = int(math.Ceil(a.EffortDays/fte - 1e-9))
+	}
+
+	// Topological order, rejecting cycles.
+	indeg := make([]int, n)
+	for i := range preds {
+		indeg[i] = len(preds[i])
+	}
+	var topo, queue []int
+	for i := 0; i < n; i++ {
+		if indeg[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		topo = append(topo, i)
+		for _, j := range succs[i] {
+			indeg[j]--
+			if indeg[j] == 0 {
+				queue = append(queue, j)
+			}
+		}
+	}
+	if len(topo) != n {
+		return nil, NewValidationError("action dependencies form a cycle")
+	}
+
+	// Priority: the longest chain of work from the action to the end.
+	tail := make([]int, n)
+	for k := n - 1; k >= 0; k-- {
+		i := topo[k]
+		best := 0
+		for _, j := range succs[i] {
+			if tail[j] > best {
+				best = tail[j]
+			}
+		}
+		tail[i] = dur[i] + best
+	}
+
+	// Forward pass: list scheduling, one action at a time per assignee.
+	es := make([]int, n)
+	ef := make([]int, n)
+	prevOnAssignee := make([]int, n)
+	free := map[uuid.UUID]int{}
+	last := map[uuid.UUID]int{}
+	remaining := make([]int, n)
+	for i := range preds {
+		remaining[i] = len(preds[i])
+		prevOnAssignee[i] = -1
+	}
+	var ready []int
+	for i := 0; i < n; i++ {
+		if remaining[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	for len(ready) > 0 {
+		sort.SliceStable(ready, func(x, y int) bool {
+			a, b := ready[x], ready[y]
+			if tail[a] != tail[b] {
+				return tail[a] > tail[b]
+			}
+			if actions[a].Order != actions[b].Order {
+				return actions[a].Order < actions[b].Order
+			}
+			return actions[a].ID.String() < actions[b].ID.String()
+		})
+		i := ready[0]
+		ready = ready[1:]
+		for _, p := range preds[i] {
+			if ef[p] > es[i] {
+				es[i] = ef[p]
+			}
+		}
+		if a := actions[i].AssigneeID; a != nil && dur[i] > 0 {
+			if f := free[*a]; f > es[i] {
+				es[i] = f
+			}
+			if p, ok := last[*a]; ok {
+				prevOnAssignee[i] = p
+			}
+			last[*a] = i
+		}
+		ef[i] = es[i] + dur[i]
+		if a := actions[i].AssigneeID; a != nil && dur[i] > 0 {
+			free[*a] = ef[i]
+		}
+		for _, j := range succs[i] {
+			remaining[j]--
+			if remaining[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+
+	end := 0
+	for i := 0; i < n; i++ {
+		if ef[i] > end {
+			end = ef[i]
+		}
+	}
+
+	// Backward pass over dependencies and assignee sequences.
+	nextOnAssignee := make([]int, n)
+	for i := range nextOnAssignee {
+		nextOnAssignee[i] = -1
+	}
+	for i, p := range prevOnAssignee {
+		if p >= 0 {
+			nextOnAssignee[p] = i
+		}
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(x, y int) bool { return ef[order[x]] > ef[order[y]] })
+	lf := make([]int, n)
+	for _, i := range order {
+		lf[i] = end
+		for _, j := range succs[i] {
+			if ls := lf[j] - dur[j]; ls < lf[i] {
+				lf[i] = ls
+			}
+		}
+		if j := nextOnAssignee[i]; j >= 0 {
+			if ls := lf[j] - dur[j]; ls < lf[i] {
+				lf[i] = ls
+			}
+		}
+	}
+
+	out := &ProgrammeSchedule{Start: start, CriticalPath: []uuid.UUID{}, Actions: make([]ScheduledAction, 0, n)}
+	var totalEffort, doneEffort float64
+	var lastDone *time.Time
+	var critical []int
+	for i, a := range actions {
+		sa := ScheduledAction{
+			ActionID:     a.ID,
+			Title:        a.Title,
+			AssigneeID:   a.AssigneeID,
+			EffortDays:   a.EffortDays,
+			DurationDays: dur[i],
+			Completed:    a.Completed,
+		}
+		totalEffort += a.EffortDays
+		if a.Completed {
+			doneEffort += a.EffortDays
+			d := start
+			if a.CompletedAt != nil {
+				d = dateOf(*a.CompletedAt)
+				if lastDone == nil || d.After(*lastDone) {
+					lastDone = &d
+				}
+			}
+			sa.Start, sa.Finish = d, d
+		} else {
+			sa.Start = addWorkingDays(start, es[i])
+			sa.Finish = sa.Start
+			if dur[i] > 0 {
+				sa.Finish = addWorkingDays(start, ef[i]-1)
+			}
+			sa.SlackDays = lf[i] - ef[i]
+			if sa.SlackDays == 0 && end > 0 {
+				sa.Critical = true
+				critical = append(critical, i)
+			}
+		}
+		out.Actions = append(out.Actions, sa)
+	}
+	sort.SliceStable(critical, func(x, y int) bool { return es[critical[x]] < es[critical[y]] })
+	for _, i := range critical {
+		out.CriticalPath = append(out.CriticalPath, actions[i].ID)
+	}
+	sort.SliceStable(out.Actions, func(x, y int) bool {
+		if !out.Actions[x].Start.Equal(out.Actions[y].Start) {
+			return out.Actions[x].Start.Before(out.Actions[y].Start)
+		}
+		return out.Actions[x].Title < out.Actions[y].Title
+	})
+
+	switch {
+	case end > 0:
+		out.ProjectedEnd = addWorkingDays(start, end-1)
+	case lastDone != nil:
+		out.ProjectedEnd = *lastDone
+	default:
+		out.ProjectedEnd = start
+	}
+	if totalEffort > 0 {
+		out.Progress = int(doneEffort * 100 / totalEffort)
+	} else if n > 0 {
+		done := 0
+		for _, a := range actions {
+			if a.Completed {
+				done++
+			}
+		}
+		out.Progress = done * 100 / n
+	}
+	return out, nil
+}
+
+// ---------------------------------------------------------------------------
+// Projection onto risks
+// ---------------------------------------------------------------------------
+
+// CombineReductions treats each programme as an independent layer — each
+// removes its share of what the others let through — capped at
+// MaxProgrammeReduction, as DeriveResidual does for controls.
+func CombineReductions(reductions []float64) float64 {
+	pass := 1.0
+	for _, r := range reductions {
+		pass *= 1 - math.Max(0, math.Min(1, r))
+	}
+	return math.Round(math.Min(1-pass, MaxProgrammeReduction)*10000) / 10000
+}
+
+// ProjectResidual is a risk's residual score once the given reductions apply.
+func ProjectResidual(inherent float64, reductions []float64) float64 {
+	return math.Round(inherent*(1-CombineReductions(reductions))*1000) / 1000
+}
+
+// MitigationProgrammeRepository persists programmes, their links and work
+// breakdown. Every method is tenant-scoped; Get* return (nil, nil) when the
+// row is absent.
+type MitigationProgrammeRepository interface {
+	ListProgrammes(ctx context.Context, tenantID uuid.UUID) ([]MitigationProgramme, error)
+	GetProgramme(ctx context.Context, tenantID, id uuid.UUID) (*MitigationProgramme, error)
+	SaveProgramme(ctx context.Context, p *MitigationProgramme) error
+	// DeleteProgramme removes the programme, its links, actions and
+	// dependencies.
+	DeleteProgramme(ctx context.Context, tenantID, id uuid.UUID) error
+
+	// RiskLinks lists the links of the given programmes (all when nil).
+	RiskLinks(ctx context.Context, tenantID uuid.UUID, programmeIDs []uuid.UUID) ([]ProgrammeRiskLink, error)
+	// RiskLinksOf lists the links treating the risk.
+	RiskLinksOf(ctx context.Context, tenantID, riskID uuid.UUID) ([]ProgrammeRiskLink, error)
+	SaveRiskLink(ctx context.Context, l *ProgrammeRiskLink) error
+	DeleteRiskLink(ctx context.Context, tenantID, programmeID, riskID uuid.UUID) error
+
+	// ControlLinks lists the links of the given programmes (all when nil).
+	ControlLinks(ctx context.Context, tenantID uuid.UUID, programmeIDs []uuid.UUID) ([]ProgrammeControlLink, error)
+	SaveControlLink(ctx context.Context, l *ProgrammeControlLink) error
+	DeleteControlLink(ctx context.Context, tenantID, programmeID, controlID uuid.UUID) error
+
+	// Actions lists the actions of the given programmes, with their DependsOn.
+	Actions(ctx context.Context, tenantID uuid.UUID, programmeIDs []uuid.UUID) ([]ProgrammeAction, error)
+	GetAction(ctx context.Context, tenantID, id uuid.UUID) (*ProgrammeAction, error)
+	// SaveAction stores the action and replaces its dependencies together.
+	SaveAction(ctx context.Context, a *ProgrammeAction) error
+	// DeleteAction removes the action and every dependency on or of it.
+	DeleteAction(ctx context.Context, tenantID, id uuid.UUID) error
+	Dependencies(ctx context.Context, tenantID uuid.UUID, programmeIDs []uuid.UUID) ([]ProgrammeDependency, error)
+
+	Capacities(ctx context.Context, tenantID uuid.UUID) ([]AssigneeCapacity, error)
+	SaveCapacity(ctx context.Context, c *AssigneeCapacity) error
+
+	// Risks reads the live risks among ids.
+	Risks(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]Risk, error)
+	GetControl(ctx context.Context, tenantID, id uuid.UUID) (*ComplianceControl, error)
+}
+
+// dateOf truncates t to its UTC calendar day.
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func nextWorkingDay(t time.Time) time.Time {
+	for t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+// addWorkingDays moves n working days on from a working day.
+func addWorkingDays(t time.Time, n int) time.Time {
+	t = nextWorkingDay(t)
+	for n > 0 {
+		t = nextWorkingDay(t.AddDate(0, 0, 1))
+		n--
+	}
+	return t
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ymd(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+func TestScheduleProgramme(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	a := ProgrammeAction{ID: uuid.New(), Title: "Pilot", EffortDays: 5, AssigneeID: &alice}
+	b := ProgrammeAction{ID: uuid.New(), Title: "Policy", EffortDays: 3, AssigneeID: &alice}
+	c := ProgrammeAction{ID: uuid.New(), Title: "Rollout", EffortDays: 2, AssigneeID: &bob}
+	deps := []ProgrammeDependency{{ActionID: c.ID, DependsOnID: a.ID}}
+	start := ymd("2026-10-17") // a Saturday: work starts on Monday
+
+	s, err := ScheduleProgramme([]ProgrammeAction{a, b, c}, deps, nil, start)
+	require.NoError(t, err)
+	assert.Equal(t, ymd("2026-10-19"), s.Start)
+	// Alice does the pilot (days 0-4), then the policy (5-7); Bob rolls out
+	// after the pilot (5-6). Alice is the bottleneck.
+	assert.Equal(t, ymd("2026-10-28"), s.ProjectedEnd)
+	assert.Equal(t, []uuid.UUID{a.ID, b.ID}, s.CriticalPath)
+	byID := map[uuid.UUID]ScheduledAction{}
+	for _, sa := range s.Actions {
+		byID[sa.ActionID] = sa
+	}
+	assert.Equal(t, ymd("2026-10-23"), byID[a.ID].Finish)
+	assert.Equal(t, ymd("2026-10-26"), byID[c.ID].Start, "finish-to-start across the weekend")
+	assert.Equal(t, 1, byID[c.ID].SlackDays)
+	assert.InDelta(t, 0.5, s.ShareDoneBy(ymd("2026-10-23")), 1e-9)
+
+	// Bob at half time doubles the rollout and moves the critical path.
+	s, err = ScheduleProgramme([]ProgrammeAction{a, b, c}, deps, map[uuid.UUID]float64{bob: 0.5}, start)
+	require.NoError(t, err)
+	assert.Equal(t, ymd("2026-10-29"), s.ProjectedEnd)
+	assert.Equal(t, []uuid.UUID{a.ID, c.ID}, s.CriticalPath)
+
+	// Completed work takes no time and counts as progress.
+	a.Completed = true
+	s, err = ScheduleProgramme([]ProgrammeAction{a, b, c}, deps, nil, start)
+	require.NoError(t, err)
+	assert.Equal(t, 50, s.Progress)
+	assert.Equal(t, ymd("2026-10-21"), s.ProjectedEnd)
+
+	_, err = ScheduleProgramme([]ProgrammeAction{a, c}, []ProgrammeDependency{
+		{ActionID: c.ID, DependsOnID: a.ID}, {ActionID: a.ID, DependsOnID: c.ID},
+	}, nil, start)
+	assert.True(t, errors.Is(err, ErrValidation), "cycles are rejected")
+}
+
+func TestProjectResidual(t *testing.T) {
+	assert.Equal(t, 0.0, CombineReductions(nil))
+	assert.InDelta(t, 0.75, CombineReductions([]float64{0.5, 0.5}), 1e-9)
+	assert.InDelta(t, MaxProgrammeReduction, CombineReductions([]float64{0.9, 0.9}), 1e-9)
+	assert.InDelta(t, 2.0, ProjectResidual(8, []float64{0.5, 0.5}), 1e-9)
+}
+
+func TestProgrammeValidation(t *testing.T) {
+	p := MitigationProgramme{Title: "  EDR  ", StartDate: ymd("2026-11-02")}
+	require.NoError(t, p.Validate())
+	assert.Equal(t, "EDR", p.Title)
+	assert.Equal(t, MitigationPlanned, p.Status)
+
+	early := ymd("2026-10-01")
+	p.TargetDate = &early
+	assert.True(t, errors.Is(p.Validate(), ErrValidation))
+
+	l := ProgrammeRiskLink{ExpectedReduction: 0.95}
+	assert.True(t, errors.Is(l.Validate(), ErrValidation))
+	c := AssigneeCapacity{FTE: 0}
+	assert.True(t, errors.Is(c.Validate(), ErrValidation))
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationLinkStatus is where a parent/child link stands. A link is asked
+// for by the parent and only grants anything once the child has accepted it:
+// a holding cannot read a subsidiary's register on its own say-so.
+type OrganizationLinkStatus string
+
+const (
+	OrgLinkPending OrganizationLinkStatus = "pending"
+	OrgLinkActive  OrganizationLinkStatus = "active"
+	OrgLinkRevoked OrganizationLinkStatus = "revoked"
+)
+
+// MaxGroupDepth bounds how far down a group resolves its entities. A holding
+// with subsidiaries of subsidiaries is three levels; eight is room to spare and
+// keeps a malformed chain from walking forever.
+const MaxGroupDepth = 8
+
+// OrganizationLink makes ChildID a subsidiary of ParentID. While it is active,
+// members of the parent holding "group:read" can read the child's register in
+// aggregate and drill into it; nothing in the link lets anybody write to the
+// child, and nothing lets the child read its parent.
+//
+// An organization has at most one parent at a time (a pending or active link
+// as child), which is what keeps the hierarchy a tree and the roll-up free of
+// double counting. Revoked links are kept for the record.
+type OrganizationLink struct {
+	ID       uuid.UUID              `gorm:"type:uuid;primaryKey" json:"id"`
+	ParentID uuid.UUID              `gorm:"type:uuid;not null;index" json:"parent_id"`
+	ChildID  uuid.UUID              `gorm:"type:uuid;not null;index" json:"child_id"`
+	Status   OrganizationLinkStatus `gorm:"type:varchar(16);not null;default:'pending';index" json:"status"`
+
+	RequestedBy *uuid.UUID `gorm:"type:uuid" json:"requested_by,omitempty"`
+	AcceptedBy  *uuid.UUID `gorm:"type:uuid" json:"accepted_by,omitempty"`
+	AcceptedAt  *time.Time `json:"accepted_at,omitempty"`
+	RevokedBy   *uuid.UUID `gorm:"type:uuid" json:"revoked_by,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+
+	// Filled on read so the settings screen can name both sides.
+	ParentName string `gorm:"-" json:"parent_name,omitempty"`
+	ChildName  string `gorm:"-" json:"child_name,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName overrides the default GORM table name.
+func (OrganizationLink) TableName() string { return "organization_links" }
+
+// Live reports whether the link still stands, accepted or not.
+func (l *OrganizationLink) Live() bool {
+	return l.Status == OrgLinkPending || l.Status == OrgLinkActive
+}
+
+// HeatmapBand maps a stored probability [0,1] and impact [0,10] onto the 1-5
+// display bands of the heatmap. It is the same banding the dashboard does in
+// SQL; a risk at exactly 0 still lands in band 1 rather than vanishing.
+func HeatmapBand(probability, impact float64) (p, i int) {
+	band := func(v float64) int {
+		b := int(math.Ceil(v))
+		if b < 1 {
+			return 1
+		}
+		if b > 5 {
+			return 5
+		}
+		return b
+	}
+	return band(probability * 5), band(impact / 2)
+}
+
+// GroupControlRow is one compliance control of a group entity, reduced to what
+// the consolidated posture needs: which framework it belongs to and its status.
+type GroupControlRow struct {
+	TenantID         uuid.UUID     `json:"tenant_id"`
+	FrameworkName    string        `json:"framework_name"`
+	FrameworkVersion string        `json:"framework_version"`
+	CatalogKey       string        `json:"catalog_key"`
+	Status           ControlStatus `json:"status"`
+}
+
+// OrgHierarchyRepository stores organization links and reads the registers
+// of a group's entities. The read methods take the explicit set of tenants
+// the caller resolved from active links; they never widen it themselves.
+type OrgHierarchyRepository interface {
+	GetOrganization(ctx context.Context, id uuid.UUID) (*Organization, error)
+	GetOrganizationBySlug(ctx context.Context, slug string) (*Organization, error)
+	Organizations(ctx context.Context, ids []uuid.UUID) ([]Organization, error)
+
+	GetLink(ctx context.Context, id uuid.UUID) (*OrganizationLink, error)
+	SaveLink(ctx context.Context, l *OrganizationLink) error
+	// LinksOf lists the links an organization is either side of, newest first.
+	LinksOf(ctx context.Context, orgID uuid.UUID) ([]OrganizationLink, error)
+	// LiveParentLink returns the pending or active link making orgID a child.
+	LiveParentLink(ctx context.Context, orgID uuid.UUID) (*OrganizationLink, error)
+	// ActiveChildren returns the active links whose parent is one of parentIDs.
+	ActiveChildren(ctx context.Context, parentIDs []uuid.UUID) ([]OrganizationLink, error)
+
+	// GroupRisks lists the risks of tenantIDs. The caller's access policies
+	// filter those of root, the caller's own organization.
+	GroupRisks(ctx context.Context, root uuid.UUID, tenantIDs []uuid.UUID) ([]Risk, error)
+	GroupCategories(ctx context.Context, tenantIDs []uuid.UUID) ([]RiskCategory, error)
+	GroupControls(ctx context.Context, tenantIDs []uuid.UUID) ([]GroupControlRow, error)
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// HeatmapBand must band exactly like the dashboard's SQL, or the group matrix
+// and an entity's own matrix would disagree about the same risk.
+func TestHeatmapBand(t *testing.T) {
+	for _, tc := range []struct {
+		p, i   float64
+		wp, wi int
+	}{
+		{0, 0, 1, 1},
+		{0.2, 2, 1, 1},
+		{0.21, 2.1, 2, 2},
+		{0.5, 5, 3, 3},
+		{1, 10, 5, 5},
+	} {
+		p, i := HeatmapBand(tc.p, tc.i)
+		assert.Equal(t, tc.wp, p, "probability %v", tc.p)
+		assert.Equal(t, tc.wi, i, "impact %v", tc.i)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// ---------------------------------------------------------------------------
+// Connector plugins.
+//
+// A scanner or ticketing integration used to mean code in the core tree. A
+// plugin is a WebAssembly module shipped in a signed bundle: the tenant uploads
+// the bundle (a PluginPackage), then installs it with explicit grants — which
+// hosts it may call, which secrets it may read, how much memory and time it
+// gets (a PluginInstall). Every invocation of one of its hooks is recorded as
+// a PluginRun. The sandbox and the host ABI live in internal/pluginhost.
+// ---------------------------------------------------------------------------
+
+// PluginHook is an entry point a plugin can export.
+type PluginHook string
+
+const (
+	// HookPullFindings polls a tool and returns vulnerability findings.
+	HookPullFindings PluginHook = "pull_findings"
+	// HookEmitAssets returns discovered assets, staged as a scan preview.
+	HookEmitAssets PluginHook = "emit_assets"
+	// HookCreateTicket opens a ticket in an external tracker.
+	HookCreateTicket PluginHook = "create_ticket"
+	// HookTransformWebhook turns an inbound webhook payload into findings.
+	HookTransformWebhook PluginHook = "transform_webhook"
+)
+
+// PluginHooks is every hook of the current ABI, in display order.
+var PluginHooks = []PluginHook{HookPullFindings, HookEmitAssets, HookCreateTicket, HookTransformWebhook}
+
+// IsValid reports whether h is a hook of the current ABI.
+func (h PluginHook) IsValid() bool {
+	for _, k := range PluginHooks {
+		if h == k {
+			return true
+		}
+	}
+	return false
+}
+
+// Sandbox limits. An install may ask for less than the maximum, never more.
+const (
+	PluginDefaultMemoryPages = 256 // 16 MiB
+	PluginMaxMemoryPages     = 1024
+	PluginDefaultTimeoutMS   = 10_000
+	PluginMaxTimeoutMS       = 60_000
+	// MaxPluginBundleBytes bounds an uploaded bundle.
+	MaxPluginBundleBytes = 20 << 20
+)
+
+// PluginPackage is an uploaded, signature-verified plugin version. The module
+// bytes are kept so an install never depends on the bundle still existing
+// anywhere else.
+type PluginPackage struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:ux_plugin_packages_tenant_version,priority:1" json:"tenant_id"`
+	Name        string    `gorm:"size:120;not null;uniqueIndex:ux_plugin_packages_tenant_version,priority:2" json:"name"`
+	Version     string    `gorm:"size:40;not null;uniqueIndex:ux_plugin_packages_tenant_version,priority:3" json:"version"`
+	Publisher   string    `gorm:"size:120;not null" json:"publisher"`
+	Description string    `gorm:"type:text;not null;default:''" json:"description"`
+	ABIVersion  int       `gorm:"not null" json:"abi_version"`
+	// Hooks are the entry points the module exports.
+	Hooks StringList `gorm:"type:jsonb" json:"hooks"`
+	// RequestedHosts and RequestedSecrets are what the manifest asks for. An
+	// install can grant a subset, never more.
+	RequestedHosts   StringList `gorm:"type:jsonb" json:"requested_hosts"`
+	RequestedSecrets StringList `gorm:"type:jsonb" json:"requested_secrets"`
+	// KeyID names the trusted publisher key the bundle was signed with.
+	KeyID string `gorm:"size:120;not null" json:"key_id"`
+	// Digest is the hex SHA-256 of the module.
+	Digest     string     `gorm:"size:64;not null" json:"digest"`
+	Size       int        `gorm:"not null" json:"size"`
+	Module     []byte     `gorm:"not null" json:"-"`
+	UploadedBy *uuid.UUID `gorm:"type:uuid" json:"uploaded_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (PluginPackage) TableName() string { return "plugin_packages" }
+
+// HasHook reports whether the package exports h.
+func (p *PluginPackage) HasHook(h PluginHook) bool {
+	for _, k := range p.Hooks {
+		if PluginHook(k) == h {
+			return true
+		}
+	}
+	return false
+}
+
+// PluginInstall is a package enabled for the tenant with its grants.
+type PluginInstall struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID  uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	PackageID uuid.UUID `gorm:"type:uuid;not null;index" json:"package_id"`
+	Name      string    `gorm:"size:120;not null" json:"name"`
+	Enabled   bool      `gorm:"not null;default:true" json:"enabled"`
+	// Config is handed to every hook as its "config" input.
+	Config datatypes.JSON `gorm:"type:jsonb" json:"config"`
+	// AllowedHosts are the hosts the plugin may reach through the host's
+	// http_request; anything else is refused before a connection is made.
+	AllowedHosts StringList `gorm:"type:jsonb" json:"allowed_hosts"`
+	// SecretNames lists the granted secrets; their values are encrypted in
+	// EncryptedSecrets and never returned.
+	SecretNames      StringList `gorm:"type:jsonb" json:"secret_names"`
+	EncryptedSecrets string     `gorm:"type:text;not null;default:''" json:"-"`
+	MemoryPages      int        `gorm:"not null" json:"memory_pages"`
+	TimeoutMS        int        `gorm:"not null" json:"timeout_ms"`
+	// WebhookToken authenticates pushes to the transform_webhook hook. Every
+	// install gets one; pushes are refused when the package lacks the hook.
+	WebhookToken  string          `gorm:"size:80;uniqueIndex" json:"webhook_token,omitempty"`
+	LastRunAt     *time.Time      `json:"last_run_at,omitempty"`
+	LastRunStatus PluginRunStatus `gorm:"type:varchar(16);not null;default:''" json:"last_run_status,omitempty"`
+	CreatedBy     *uuid.UUID      `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+
+	Package *PluginPackage `gorm:"-" json:"package,omitempty"`
+}
+
+func (PluginInstall) TableName() string { return "plugin_installs" }
+
+// PluginRunStatus is the outcome of one invocation.
+type PluginRunStatus string
+
+const (
+	PluginRunOK     PluginRunStatus = "ok"
+	PluginRunFailed PluginRunStatus = "failed"
+)
+
+// PluginRun records one hook invocation: what it did, how long it took, what
+// the plugin logged and, on failure, why.
+type PluginRun struct {
+	ID         uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID   uuid.UUID       `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	InstallID  uuid.UUID       `gorm:"type:uuid;not null;index" json:"install_id"`
+	Hook       PluginHook      `gorm:"type:varchar(32);not null" json:"hook"`
+	Status     PluginRunStatus `gorm:"type:varchar(16);not null" json:"status"`
+	DurationMS int64           `gorm:"not null;default:0" json:"duration_ms"`
+	HTTPCalls  int             `gorm:"not null;default:0" json:"http_calls"`
+	// Items counts what the hook produced: findings, assets or a ticket.
+	Items       int        `gorm:"not null;default:0" json:"items"`
+	Error       string     `gorm:"type:text;not null;default:''" json:"error,omitempty"`
+	Logs        StringList `gorm:"type:jsonb" json:"logs"`
+	TriggeredBy *uuid.UUID `gorm:"type:uuid" json:"triggered_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (PluginRun) TableName() string { return "plugin_runs" }
+
+// PluginRepository persists packages, installs and runs. Every method is
+// tenant-scoped except GetInstallByWebhookToken: the token is the credential
+// and resolves the tenant.
+type PluginRepository interface {
+	ListPackages(ctx context.Context, tenantID uuid.UUID) ([]PluginPackage, error)
+	// GetPackage returns (nil, nil) when absent. The module is loaded.
+	GetPackage(ctx context.Context, tenantID, id uuid.UUID) (*PluginPackage, error)
+	FindPackage(ctx context.Context, tenantID uuid.UUID, name, version string) (*PluginPackage, error)
+	CreatePackage(ctx context.Context, p *PluginPackage) error
+	DeletePackage(ctx context.Context, tenantID, id uuid.UUID) error
+
+	ListInstalls(ctx context.Context, tenantID uuid.UUID) ([]PluginInstall, error)
+	// GetInstall returns (nil, nil) when absent.
+	GetInstall(ctx context.Context, tenantID, id uuid.UUID) (*PluginInstall, error)
+	GetInstallByWebhookToken(ctx context.Context, token string) (*PluginInstall, error)
+	CountInstalls(ctx context.Context, tenantID, packageID uuid.UUID) (int64, error)
+	SaveInstall(ctx context.Context, in *PluginInstall) error
+	DeleteInstall(ctx context.Context, tenantID, id uuid.UUID) error
+
+	// RecordRun saves a run and stamps the install's last run with it.
+	RecordRun(ctx context.Context, r *PluginRun) error
+	ListRuns(ctx context.Context, tenantID, installID uuid.UUID, limit int) ([]PluginRun, error)
+}
@@ -234,6 +234,7 @@ var StandardRoles = map[string]StandardRole{
 		Description: "Read-only access for auditing and reporting",
 		Permissions: []string{
 			"risks:read",
+			"risks:snapshots:read",
 			"mitigations:read",
 			"reports:export",
 			"compliance:read",
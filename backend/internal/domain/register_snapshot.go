@@ -0,0 +1,301 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ---------------------------------------------------------------------------
+// Register snapshots.
+//
+// RiskHistory records how ONE risk changed, row by row, as Risk.AfterSave
+// fires. It cannot answer "what did the whole register look like on 31
+// December": risks deleted since then have no row to replay, the ALE is never
+// persisted, and control mappings are not part of a risk's history at all.
+//
+// A snapshot is the register frozen at a moment: one immutable row per live
+// risk with its score, state, owner, ALE and control mappings, plus the
+// portfolio totals. Snapshots are taken on demand and once a day by a worker
+// (close of day, UTC); they are never updated, and the repository exposes no
+// way to.
+//
+// "As of T" resolves to the latest snapshot whose AsOf is at or before T.
+// Responses carry the snapshot's own AsOf so a reader always knows which
+// state they were given.
+// ---------------------------------------------------------------------------
+
+// RegisterSnapshotKind says why a snapshot exists.
+type RegisterSnapshotKind string
+
+const (
+	// RegisterSnapshotScheduled is the daily close-of-day snapshot.
+	RegisterSnapshotScheduled RegisterSnapshotKind = "scheduled"
+	// RegisterSnapshotManual was asked for, e.g. ahead of an audit.
+	RegisterSnapshotManual RegisterSnapshotKind = "manual"
+)
+
+// RegisterSnapshot is the header of a frozen register: when it stands for and
+// the portfolio totals at that moment.
+type RegisterSnapshot struct {
+	ID       uuid.UUID            `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID uuid.UUID            `gorm:"type:uuid;not null;index:idx_register_snapshots_tenant_as_of,priority:1" json:"tenant_id"`
+	Kind     RegisterSnapshotKind `gorm:"type:varchar(16);not null" json:"kind"`
+	Label    string               `gorm:"size:255;not null;default:''" json:"label"`
+
+	// AsOf is the moment the snapshot stands for. A manual snapshot stands for
+	// the moment it was taken; a scheduled one for the midnight (UTC) that
+	// closed the day, and is taken by the first sweep after it.
+	AsOf    time.Time `gorm:"not null;index:idx_register_snapshots_tenant_as_of,priority:2" json:"as_of"`
+	TakenAt time.Time `gorm:"not null" json:"taken_at"`
+
+	RiskCount    int     `gorm:"not null;default:0" json:"risk_count"`
+	OpenCount    int     `gorm:"not null;default:0" json:"open_count"`
+	Critical     int     `gorm:"not null;default:0" json:"critical"`
+	High         int     `gorm:"not null;default:0" json:"high"`
+	Medium       int     `gorm:"not null;default:0" json:"medium"`
+	Low          int     `gorm:"not null;default:0" json:"low"`
+	AverageScore float64 `gorm:"not null;default:0" json:"average_score"`
+	TotalALEXAF  float64 `gorm:"not null;default:0" json:"total_ale_xaf"`
+
+	TakenBy   *uuid.UUID `gorm:"type:uuid" json:"taken_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (RegisterSnapshot) TableName() string { return "register_snapshots" }
+
+// RegisterSnapshotRisk is one risk as it stood in a snapshot. It copies what
+// a year-end reader asks about rather than referencing the live row, which may
+// since have changed or been deleted.
+type RegisterSnapshotRisk struct {
+	SnapshotID uuid.UUID `gorm:"type:uuid;primaryKey" json:"snapshot_id"`
+	RiskID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"risk_id"`
+	TenantID   uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+
+	Title          string     `gorm:"size:255;not null" json:"title"`
+	Status         RiskStatus `gorm:"type:varchar(20)" json:"status"`
+	LifecycleState RiskState  `gorm:"type:varchar(24)" json:"lifecycle_state"`
+	Criticality    string     `gorm:"type:varchar(20)" json:"criticality"`
+	Score          float64    `json:"score"`
+	Probability    float64    `json:"probability"`
+	Impact         float64    `json:"impact"`
+	ResidualRisk   *float64   `json:"residual_risk,omitempty"`
+	OwnerID        *uuid.UUID `gorm:"type:uuid" json:"owner_id,omitempty"`
+	BusinessUnit   string     `gorm:"size:128" json:"business_unit,omitempty"`
+	CategoryID     *uuid.UUID `gorm:"type:uuid" json:"category_id,omitempty"`
+	ALEXAF         float64    `json:"ale_xaf"`
+
+	Tags pq.StringArray `gorm:"type:text[]" json:"tags"`
+	// Controls are the risk's control mappings as the register badge shows
+	// them ("ISO 27001 · A.8.13"): labels rather than ids, because a mapping
+	// or control deleted later must still read correctly here.
+	Controls pq.StringArray `gorm:"type:text[]" json:"controls"`
+	Assets   pq.StringArray `gorm:"type:text[]" json:"assets"`
+
+	RiskCreatedAt time.Time `json:"risk_created_at"`
+	RiskUpdatedAt time.Time `json:"risk_updated_at"`
+}
+
+func (RegisterSnapshotRisk) TableName() string { return "register_snapshot_risks" }
+
+// IsClosed reports whether the risk had left the register's working set. The
+// legacy status and the lifecycle state are both honoured: either closes it.
+func (r RegisterSnapshotRisk) IsClosed() bool {
+	return r.Status == RiskClosed || r.LifecycleState == StateClosed
+}
+
+// Summarize fills the snapshot's totals from its rows.
+func (s *RegisterSnapshot) Summarize(rows []RegisterSnapshotRisk) {
+	s.RiskCount = len(rows)
+	s.OpenCount, s.Critical, s.High, s.Medium, s.Low = 0, 0, 0, 0, 0
+	s.AverageScore, s.TotalALEXAF = 0, 0
+	var sum float64
+	for _, r := range rows {
+		sum += r.Score
+		if r.IsClosed() {
+			continue
+		}
+		s.OpenCount++
+		s.TotalALEXAF += r.ALEXAF
+		switch strings.ToLower(r.Criticality) {
+		case string(CriticalityCriticalNew):
+			s.Critical++
+		case string(CriticalityHighNew):
+			s.High++
+		case string(CriticalityMediumNew):
+			s.Medium++
+		default:
+			s.Low++
+		}
+	}
+	if len(rows) > 0 {
+		s.AverageScore = math.Round(sum/float64(len(rows))*1000) / 1000
+	}
+}
+
+// RegisterSnapshotFilter narrows an "as of" listing. It mirrors the register's
+// own list filters that still make sense on a frozen copy.
+type RegisterSnapshotFilter struct {
+	Query       string
+	Status      string
+	Criticality string
+	OwnerID     *uuid.UUID
+	MinScore    *float64
+	Page        int
+	Limit       int
+}
+
+// RegisterSnapshotRepository stores snapshots. It is append-only: there is no
+// update, and snapshots go only when their tenant does.
+type RegisterSnapshotRepository interface {
+	// LiveRisks reads the register as it stands, assets preloaded.
+	LiveRisks(ctx context.Context, tenantID uuid.UUID) ([]Risk, error)
+	// Create stores a snapshot and its rows in one transaction.
+	Create(ctx context.Context, s *RegisterSnapshot, rows []RegisterSnapshotRisk) error
+	// Get returns (nil, nil) when absent or owned by another tenant.
+	Get(ctx context.Context, tenantID, id uuid.UUID) (*RegisterSnapshot, error)
+	// AtOrBefore is the latest snapshot with AsOf <= at, or (nil, nil).
+	AtOrBefore(ctx context.Context, tenantID uuid.UUID, at time.Time) (*RegisterSnapshot, error)
+	// List returns snapshots newest first.
+	List(ctx context.Context, tenantID uuid.UUID, limit int) ([]RegisterSnapshot, error)
+	// Rows pages through one snapshot's risks, highest score first.
+	Rows(ctx context.Context, tenantID, snapshotID uuid.UUID, f RegisterSnapshotFilter) ([]RegisterSnapshotRisk, int64, error)
+	// AllRows returns every risk of one snapshot.
+	AllRows(ctx context.Context, tenantID, snapshotID uuid.UUID) ([]RegisterSnapshotRisk, error)
+	// TenantsDue lists tenants with live risks and no scheduled snapshot at
+	// or after asOf.
+	TenantsDue(ctx context.Context, asOf time.Time) ([]uuid.UUID, error)
+}
+
+// ---------------------------------------------------------------------------
+// Diff
+// ---------------------------------------------------------------------------
+
+// scoreEpsilon ignores score noise below the register's stored precision
+// (numeric(8,3)).
+const scoreEpsilon = 0.0005
+
+// RegisterDiffEntry is one risk that changed between two registers. Scores
+// and statuses are empty on the side where the risk did not exist.
+type RegisterDiffEntry struct {
+	RiskID      uuid.UUID  `json:"risk_id"`
+	Title       string     `json:"title"`
+	Criticality string     `json:"criticality"`
+	FromScore   *float64   `json:"from_score,omitempty"`
+	ToScore     *float64   `json:"to_score,omitempty"`
+	Delta       float64    `json:"delta"`
+	FromStatus  RiskStatus `json:"from_status,omitempty"`
+	ToStatus    RiskStatus `json:"to_status,omitempty"`
+	FromALEXAF  float64    `json:"from_ale_xaf"`
+	ToALEXAF    float64    `json:"to_ale_xaf"`
+}
+
+// RegisterDiffSummary counts each bucket of a diff.
+type RegisterDiffSummary struct {
+	New        int `json:"new"`
+	Closed     int `json:"closed"`
+	UpScored   int `json:"up_scored"`
+	DownScored int `json:"down_scored"`
+	Unchanged  int `json:"unchanged"`
+}
+
+// RegisterDiff is what changed from one register to a later one.
+//
+//   - New: in the later register, not in the earlier one, and not closed.
+//   - Closed: open in the earlier register, and closed or gone in the later.
+//   - UpScored / DownScored: open in both, score moved.
+//
+// A risk reopened between the two counts as neither new nor closed; its score
+// movement, if any, still shows.
+type RegisterDiff struct {
+	Summary    RegisterDiffSummary `json:"summary"`
+	New        []RegisterDiffEntry `json:"new"`
+	Closed     []RegisterDiffEntry `json:"closed"`
+	UpScored   []RegisterDiffEntry `json:"up_scored"`
+	DownScored []RegisterDiffEntry `json:"down_scored"`
+}
+
+// DiffRegisters compares two registers. New risks are listed highest score
+// first; the other buckets by the size of the movement.
+func DiffRegisters(from, to []RegisterSnapshotRisk) RegisterDiff {
+	d := RegisterDiff{
+		New: []RegisterDiffEntry{}, Closed: []RegisterDiffEntry{},
+		UpScored: []RegisterDiffEntry{}, DownScored: []RegisterDiffEntry{},
+	}
+	before := make(map[uuid.UUID]RegisterSnapshotRisk, len(from))
+	for _, r := range from {
+		before[r.RiskID] = r
+	}
+	seen := make(map[uuid.UUID]bool, len(to))
+	for _, r := range to {
+		seen[r.RiskID] = true
+		old, existed := before[r.RiskID]
+		if !existed {
+			if !r.IsClosed() {
+				d.New = append(d.New, diffEntry(nil, &r))
+			}
+			continue
+		}
+		e := diffEntry(&old, &r)
+		switch {
+		case !old.IsClosed() && r.IsClosed():
+			d.Closed = append(d.Closed, e)
+		case old.IsClosed() || r.IsClosed():
+			// Reopened, or closed on both sides: not part of the movement.
+		case e.Delta > scoreEpsilon:
+			d.UpScored = append(d.UpScored, e)
+		case e.Delta < -scoreEpsilon:
+			d.DownScored = append(d.DownScored, e)
+		default:
+			d.Summary.Unchanged++
+		}
+	}
+	for _, old := range from {
+		if !seen[old.RiskID] && !old.IsClosed() {
+			d.Closed = append(d.Closed, diffEntry(&old, nil))
+		}
+	}
+
+	sort.SliceStable(d.New, func(i, j int) bool { return *d.New[i].ToScore > *d.New[j].ToScore })
+	byMovement := func(es []RegisterDiffEntry) {
+		sort.SliceStable(es, func(i, j int) bool { return math.Abs(es[i].Delta) > math.Abs(es[j].Delta) })
+	}
+	byMovement(d.Closed)
+	byMovement(d.UpScored)
+	byMovement(d.DownScored)
+
+	d.Summary.New = len(d.New)
+	d.Summary.Closed = len(d.Closed)
+	d.Summary.UpScored = len(d.UpScored)
+	d.Summary.DownScored = len(d.DownScored)
+	return d
+}
+
+func diffEntry(from, to *RegisterSnapshotRisk) RegisterDiffEntry {
+	var e RegisterDiffEntry
+	var fromScore, toScore float64
+	if from != nil {
+		s := from.Score
+		e.RiskID, e.Title, e.Criticality = from.RiskID, from.Title, from.Criticality
+		e.FromScore, e.FromStatus, e.FromALEXAF = &s, from.Status, from.ALEXAF
+		fromScore = s
+	}
+	if to != nil {
+		s := to.Score
+		e.RiskID, e.Title, e.Criticality = to.RiskID, to.Title, to.Criticality
+		e.ToScore, e.ToStatus, e.ToALEXAF = &s, to.Status, to.ALEXAF
+		toScore = s
+	}
+	e.Delta = math.Round((toScore-fromScore)*1000) / 1000
+	return e
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func snapRow(id uuid.UUID, title string, score float64, status RiskStatus) RegisterSnapshotRisk {
+	return RegisterSnapshotRisk{RiskID: id, Title: title, Score: score, Status: status, Criticality: string(CriticalityFromScore(score))}
+}
+
+func TestDiffRegisters(t *testing.T) {
+	stay, up, down, closing, gone, fresh, reopened := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	from := []RegisterSnapshotRisk{
+		snapRow(stay, "stable", 4, RiskOpen),
+		snapRow(up, "worse", 3, RiskOpen),
+		snapRow(down, "better", 8, RiskInProgress),
+		snapRow(closing, "closed in period", 5, RiskOpen),
+		snapRow(gone, "deleted in period", 6, RiskOpen),
+		snapRow(reopened, "reopened", 2, RiskClosed),
+	}
+	to := []RegisterSnapshotRisk{
+		snapRow(stay, "stable", 4.0001, RiskOpen),
+		snapRow(up, "worse", 7.5, RiskOpen),
+		snapRow(down, "better", 2, RiskMitigated),
+		snapRow(closing, "closed in period", 5, RiskClosed),
+		snapRow(fresh, "new", 9, RiskOpen),
+		snapRow(reopened, "reopened", 2, RiskOpen),
+	}
+
+	d := DiffRegisters(from, to)
+	assert.Equal(t, RegisterDiffSummary{New: 1, Closed: 2, UpScored: 1, DownScored: 1, Unchanged: 1}, d.Summary)
+
+	require.Len(t, d.New, 1)
+	assert.Equal(t, fresh, d.New[0].RiskID)
+	assert.Nil(t, d.New[0].FromScore)
+
+	require.Len(t, d.UpScored, 1)
+	assert.InDelta(t, 4.5, d.UpScored[0].Delta, 1e-9)
+	require.Len(t, d.DownScored, 1)
+	assert.InDelta(t, -6, d.DownScored[0].Delta, 1e-9)
+
+	// A deleted risk is closed as far as the register is concerned; it has no
+	// "to" side at all.
+	require.Len(t, d.Closed, 2)
+	ids := []uuid.UUID{d.Closed[0].RiskID, d.Closed[1].RiskID}
+	assert.ElementsMatch(t, []uuid.UUID{closing, gone}, ids)
+	for _, e := range d.Closed {
+		if e.RiskID == gone {
+			assert.Nil(t, e.ToScore)
+		}
+	}
+}
+
+func TestDiffRegisters_LifecycleStateCloses(t *testing.T) {
+	id := uuid.New()
+	from := []RegisterSnapshotRisk{snapRow(id, "r", 5, RiskOpen)}
+	closed := snapRow(id, "r", 5, RiskOpen)
+	closed.LifecycleState = StateClosed
+	d := DiffRegisters(from, []RegisterSnapshotRisk{closed})
+	assert.Equal(t, 1, d.Summary.Closed)
+}
+
+func TestRegisterSnapshot_Summarize(t *testing.T) {
+	rows := []RegisterSnapshotRisk{
+		snapRow(uuid.New(), "a", 8, RiskOpen),
+		snapRow(uuid.New(), "b", 5, RiskOpen),
+		snapRow(uuid.New(), "c", 1, RiskOpen),
+		snapRow(uuid.New(), "d", 9, RiskClosed),
+	}
+	rows[0].ALEXAF, rows[1].ALEXAF, rows[3].ALEXAF = 1000, 500, 99999
+
+	var s RegisterSnapshot
+	s.Summarize(rows)
+	assert.Equal(t, 4, s.RiskCount)
+	assert.Equal(t, 3, s.OpenCount)
+	assert.Equal(t, 1, s.Critical)
+	assert.Equal(t, 1, s.High)
+	assert.Equal(t, 1, s.Low)
+	assert.InDelta(t, 5.75, s.AverageScore, 1e-9)
+	assert.InDelta(t, 1500, s.TotalALEXAF, 1e-9, "a closed risk no longer carries exposure")
+}
@@ -0,0 +1,462 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---------------------------------------------------------------------------
+// Regulatory breach-notification clocks.
+//
+// GDPR, NIS2 and DORA each put a statutory deadline on telling an authority
+// about an incident, and the deadline starts when the organisation becomes
+// aware — not when someone remembers the regulation exists. These types turn a
+// classification decision on an incident into dated obligations that escalate
+// on their own, carry a pre-filled notification, and keep the proof that it was
+// sent.
+//
+// The regimes and their stages are code (they are the law, not a setting). What
+// a tenant configures is per jurisdiction: which authority, which classification
+// criteria trigger the regime there, and who is chased when a clock runs down.
+// ---------------------------------------------------------------------------
+
+// RegulatoryRegime identifies a notification regime.
+type RegulatoryRegime string
+
+const (
+	RegimeGDPR RegulatoryRegime = "gdpr"
+	RegimeNIS2 RegulatoryRegime = "nis2"
+	RegimeDORA RegulatoryRegime = "dora"
+)
+
+// ClockAnchor says what a stage's deadline counts from.
+type ClockAnchor string
+
+const (
+	// AnchorAwareness — when the organisation became aware of the incident.
+	AnchorAwareness ClockAnchor = "awareness"
+	// AnchorClassification — when it was classified as reportable (DORA counts
+	// the initial notification from classification as "major").
+	AnchorClassification ClockAnchor = "classification"
+	// AnchorPreviousStage — the submission of the preceding stage, or its
+	// deadline while it has not been submitted. A late early warning must not
+	// also buy a late notification.
+	AnchorPreviousStage ClockAnchor = "previous_stage"
+)
+
+// RegulatoryStageSpec is one deadline within a regime.
+type RegulatoryStageSpec struct {
+	Key    string      `json:"key"`
+	Label  string      `json:"label"`
+	Anchor ClockAnchor `json:"anchor"`
+	// Within is the allowance from the anchor; WithinMonths is used instead for
+	// "one month" stages, which are calendar months, not 30 days.
+	Within       time.Duration `json:"within_ns,omitempty"`
+	WithinMonths int           `json:"within_months,omitempty"`
+	// CapFromAwareness bounds the deadline from awareness regardless of anchor:
+	// DORA's initial notification is 4 h after classification but never later
+	// than 24 h after awareness.
+	CapFromAwareness time.Duration `json:"cap_from_awareness_ns,omitempty"`
+	// Fields are the notification's contents, in the order the authority's form
+	// asks for them. Pre-filled from the incident where the incident knows.
+	Fields []string `json:"fields"`
+}
+
+// Deadline computes the stage's due date from its anchor instants.
+func (s RegulatoryStageSpec) Deadline(awareAt, classifiedAt, previous time.Time) time.Time {
+	anchor := awareAt
+	switch s.Anchor {
+	case AnchorClassification:
+		anchor = classifiedAt
+	case AnchorPreviousStage:
+		anchor = previous
+	}
+	due := anchor.Add(s.Within)
+	if s.WithinMonths > 0 {
+		due = anchor.AddDate(0, s.WithinMonths, 0)
+	}
+	if s.CapFromAwareness > 0 {
+		if limit := awareAt.Add(s.CapFromAwareness); due.After(limit) {
+			due = limit
+		}
+	}
+	return due
+}
+
+// RegulatoryRegimeSpec describes a regime and ties it to its compliance catalog,
+// so a clock can point at the control it discharges (GDPR Art.33, NIS2 Art.23,
+// DORA Art.19) in the tenant's imported framework.
+type RegulatoryRegimeSpec struct {
+	Regime      RegulatoryRegime      `json:"regime"`
+	Name        string                `json:"name"`
+	CatalogKey  string                `json:"catalog_key"`
+	ControlRef  string                `json:"control_ref"`
+	Description string                `json:"description"`
+	Stages      []RegulatoryStageSpec `json:"stages"`
+	// DefaultCriteria are used by the built-in EU-wide profile.
+	DefaultCriteria RegulatoryCriteria `json:"default_criteria"`
+}
+
+// Notification field keys. Shared across regimes where the authorities ask the
+// same question, so one pre-fill routine answers all three.
+const (
+	FieldNature          = "nature"
+	FieldDetectedAt      = "detected_at"
+	FieldAwareAt         = "aware_at"
+	FieldSeverity        = "severity"
+	FieldCategories      = "data_categories"
+	FieldAffected        = "affected_subjects"
+	FieldConsequences    = "likely_consequences"
+	FieldMeasures        = "measures_taken"
+	FieldContact         = "contact_point"
+	FieldMalicious       = "suspected_malicious"
+	FieldCrossBorder     = "cross_border_impact"
+	FieldRootCause       = "root_cause"
+	FieldAssets          = "affected_assets"
+	FieldStatus          = "current_status"
+	FieldClassification  = "classification_criteria"
+	FieldRecoveryActions = "recovery_actions"
+)
+
+// RegulatoryRegimes is the built-in catalogue. Deadlines follow the texts:
+// GDPR art. 33(1); NIS2 art. 23(4)(a)(b)(d); DORA art. 19(4) with the reporting
+// RTS (initial 4 h from classification / 24 h from awareness, intermediate 72 h
+// after the initial notification, final one month after the intermediate).
+func RegulatoryRegimes() []RegulatoryRegimeSpec {
+	return []RegulatoryRegimeSpec{
+		{
+			Regime: RegimeGDPR, Name: "GDPR personal-data breach", CatalogKey: "gdpr-2016-679", ControlRef: "Art.33",
+			Description: "Notify the supervisory authority of a personal-data breach within 72 hours of becoming aware of it.",
+			Stages: []RegulatoryStageSpec{{
+				Key: "notification", Label: "Notification to the supervisory authority (art. 33)",
+				Anchor: AnchorAwareness, Within: 72 * time.Hour,
+				Fields: []string{FieldNature, FieldAwareAt, FieldCategories, FieldAffected, FieldConsequences, FieldMeasures, FieldContact},
+			}},
+			DefaultCriteria: RegulatoryCriteria{RequirePersonalData: true},
+		},
+		{
+			Regime: RegimeNIS2, Name: "NIS2 significant incident", CatalogKey: "nis2-2022-2555", ControlRef: "Art.23",
+			Description: "Early warning within 24 hours, incident notification within 72 hours, final report within one month of the notification.",
+			Stages: []RegulatoryStageSpec{
+				{
+					Key: "early_warning", Label: "Early warning (art. 23(4)(a))",
+					Anchor: AnchorAwareness, Within: 24 * time.Hour,
+					Fields: []string{FieldNature, FieldAwareAt, FieldMalicious, FieldCrossBorder},
+				},
+				{
+					Key: "notification", Label: "Incident notification (art. 23(4)(b))",
+					Anchor: AnchorAwareness, Within: 72 * time.Hour,
+					Fields: []string{FieldNature, FieldSeverity, FieldAffected, FieldAssets, FieldMalicious, FieldCrossBorder, FieldMeasures},
+				},
+				{
+					Key: "final_report", Label: "Final report (art. 23(4)(d))",
+					Anchor: AnchorPreviousStage, WithinMonths: 1,
+					Fields: []string{FieldNature, FieldSeverity, FieldRootCause, FieldMeasures, FieldCrossBorder, FieldStatus},
+				},
+			},
+			DefaultCriteria: RegulatoryCriteria{RequireSignificant: true},
+		},
+		{
+			Regime: RegimeDORA, Name: "DORA major ICT-related incident", CatalogKey: "dora-2022-2554", ControlRef: "Art.19",
+			Description: "Initial notification within 4 hours of classification (24 hours of awareness at most), intermediate report within 72 hours, final report within one month.",
+			Stages: []RegulatoryStageSpec{
+				{
+					Key: "initial", Label: "Initial notification",
+					Anchor: AnchorClassification, Within: 4 * time.Hour, CapFromAwareness: 24 * time.Hour,
+					Fields: []string{FieldNature, FieldAwareAt, FieldClassification, FieldAffected, FieldCrossBorder},
+				},
+				{
+					Key: "intermediate", Label: "Intermediate report",
+					Anchor: AnchorPreviousStage, Within: 72 * time.Hour,
+					Fields: []string{FieldNature, FieldSeverity, FieldAssets, FieldAffected, FieldMeasures, FieldStatus},
+				},
+				{
+					Key: "final", Label: "Final report",
+					Anchor: AnchorPreviousStage, WithinMonths: 1,
+					Fields: []string{FieldNature, FieldRootCause, FieldMeasures, FieldRecoveryActions, FieldStatus},
+				},
+			},
+			DefaultCriteria: RegulatoryCriteria{RequireMajor: true},
+		},
+	}
+}
+
+// LookupRegulatoryRegime returns the spec for a regime.
+func LookupRegulatoryRegime(r RegulatoryRegime) (RegulatoryRegimeSpec, bool) {
+	for _, spec := range RegulatoryRegimes() {
+		if spec.Regime == r {
+			return spec, true
+		}
+	}
+	return RegulatoryRegimeSpec{}, false
+}
+
+// ParseRegulatoryRegime validates a regime key.
+func ParseRegulatoryRegime(s string) (RegulatoryRegime, error) {
+	r := RegulatoryRegime(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := LookupRegulatoryRegime(r); !ok {
+		return "", NewValidationError(fmt.Sprintf("unknown regulatory regime: %q", s))
+	}
+	return r, nil
+}
+
+// ---------------------------------------------------------------------------
+// Classification
+// ---------------------------------------------------------------------------
+
+// RegulatoryCriteria decide whether a regime applies to a classified incident
+// in one jurisdiction. Every set criterion must hold; an empty criteria set
+// matches nothing (a profile that fires on every incident is a misconfiguration
+// that buries the real deadlines).
+type RegulatoryCriteria struct {
+	RequirePersonalData bool `json:"require_personal_data,omitempty"`
+	RequireSignificant  bool `json:"require_significant,omitempty"`
+	RequireMajor        bool `json:"require_major,omitempty"`
+	// IncidentTypes, when set, restrict the regime to these incident types.
+	IncidentTypes []string `json:"incident_types,omitempty"`
+	// MinSeverity, when set, is the lowest incident severity that qualifies.
+	MinSeverity string `json:"min_severity,omitempty"`
+	// MinAffectedSubjects, when positive, is the smallest qualifying headcount.
+	MinAffectedSubjects int `json:"min_affected_subjects,omitempty"`
+}
+
+// IsEmpty reports whether no criterion is set.
+func (c RegulatoryCriteria) IsEmpty() bool {
+	return !c.RequirePersonalData && !c.RequireSignificant && !c.RequireMajor &&
+		len(c.IncidentTypes) == 0 && c.MinSeverity == "" && c.MinAffectedSubjects <= 0
+}
+
+func incidentSeverityRank(s string) int {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "low":
+		return 1
+	case "medium":
+		return 2
+	case "high":
+		return 3
+	case "critical":
+		return 4
+	}
+	return 0
+}
+
+// Matches evaluates the criteria against an incident and its assessment, and
+// says why not when they do not — "no clock started" needs a reason a DPO can
+// check.
+func (c RegulatoryCriteria) Matches(inc *Incident, a *IncidentRegulatoryAssessment) (bool, string) {
+	if c.IsEmpty() {
+		return false, "profile has no classification criteria"
+	}
+	if c.RequirePersonalData && !a.PersonalData {
+		return false, "not a personal-data breach"
+	}
+	if c.RequireSignificant && !a.Significant {
+		return false, "not classified as significant"
+	}
+	if c.RequireMajor && !a.Major {
+		return false, "not classified as major"
+	}
+	if len(c.IncidentTypes) > 0 {
+		ok := false
+		for _, t := range c.IncidentTypes {
+			if strings.EqualFold(strings.TrimSpace(t), inc.IncidentType) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false, fmt.Sprintf("incident type %q is not in scope", inc.IncidentType)
+		}
+	}
+	if c.MinSeverity != "" && incidentSeverityRank(inc.Severity) < incidentSeverityRank(c.MinSeverity) {
+		return false, fmt.Sprintf("severity %s is below %s", inc.Severity, c.MinSeverity)
+	}
+	if c.MinAffectedSubjects > 0 && a.AffectedSubjects < c.MinAffectedSubjects {
+		return false, fmt.Sprintf("%d affected subject(s), threshold %d", a.AffectedSubjects, c.MinAffectedSubjects)
+	}
+	return true, ""
+}
+
+// Value implements driver.Valuer (jsonb).
+func (c RegulatoryCriteria) Value() (driver.Value, error) {
+	b, err := json.Marshal(c)
+	return string(b), err
+}
+
+// Scan implements sql.Scanner.
+func (c *RegulatoryCriteria) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*c = RegulatoryCriteria{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("RegulatoryCriteria: unsupported scan type %T", value)
+	}
+	if len(b) == 0 {
+		*c = RegulatoryCriteria{}
+		return nil
+	}
+	return json.Unmarshal(b, c)
+}
+
+// RegulatoryJurisdictionEU is the catch-all jurisdiction: a profile for it
+// applies when no profile names one of the incident's jurisdictions.
+const RegulatoryJurisdictionEU = "EU"
+
+// RegulatoryProfile is a tenant's configuration of one regime in one
+// jurisdiction: the authority to notify, what qualifies, and who is chased.
+type RegulatoryProfile struct {
+	ID       uuid.UUID        `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID uuid.UUID        `gorm:"type:uuid;not null;index;uniqueIndex:uq_regulatory_profiles_scope,priority:1" json:"tenant_id"`
+	Regime   RegulatoryRegime `gorm:"type:varchar(16);not null;index;uniqueIndex:uq_regulatory_profiles_scope,priority:2" json:"regime"`
+	// Jurisdiction is an ISO 3166-1 alpha-2 code, or "EU" for the catch-all.
+	Jurisdiction string `gorm:"size:8;not null;uniqueIndex:uq_regulatory_profiles_scope,priority:3" json:"jurisdiction"`
+	Authority    string `gorm:"size:255" json:"authority"`
+	// AuthorityContact is where the notification goes: a portal URL or an
+	// address. Shown on the draft so nobody hunts for it at hour 71.
+	AuthorityContact string             `gorm:"size:512" json:"authority_contact"`
+	Criteria         RegulatoryCriteria `gorm:"type:jsonb" json:"criteria"`
+	// EscalateChannels / EscalateToRole route the reminders through the
+	// automation dispatcher. Empty channels means in-app and email.
+	EscalateChannels StringList `gorm:"type:jsonb" json:"escalate_channels"`
+	EscalateToRole   string     `gorm:"size:32" json:"escalate_to_role"`
+	// ContactPoint pre-fills the "who can the authority call" field.
+	ContactPoint string `gorm:"size:255" json:"contact_point"`
+	Enabled      bool   `gorm:"not null;default:true" json:"enabled"`
+	BuiltIn      bool   `gorm:"not null;default:false" json:"built_in"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (RegulatoryProfile) TableName() string { return "regulatory_profiles" }
+
+// IncidentRegulatoryAssessment is the classification decision on one incident.
+// The judgement calls ("is this significant?") are a human's, recorded with who
+// made them and when; the product only applies the configured criteria to them.
+type IncidentRegulatoryAssessment struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID   uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	IncidentID uint      `gorm:"not null;uniqueIndex" json:"incident_id"`
+
+	PersonalData     bool       `json:"personal_data"`
+	Significant      bool       `json:"significant"`
+	Major            bool       `json:"major"`
+	AffectedSubjects int        `json:"affected_subjects"`
+	DataCategories   StringList `gorm:"type:jsonb" json:"data_categories"`
+	Jurisdictions    StringList `gorm:"type:jsonb" json:"jurisdictions"`
+	CrossBorder      bool       `json:"cross_border"`
+	Malicious        bool       `json:"suspected_malicious"`
+	Consequences     string     `gorm:"type:text" json:"likely_consequences"`
+	Notes            string     `gorm:"type:text" json:"notes"`
+
+	// AwareAt is when the organisation became aware. Defaults to the incident's
+	// creation; it is editable because detection often predates the ticket.
+	AwareAt      time.Time  `json:"aware_at"`
+	ClassifiedAt time.Time  `json:"classified_at"`
+	ClassifiedBy *uuid.UUID `gorm:"type:uuid" json:"classified_by,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (IncidentRegulatoryAssessment) TableName() string { return "incident_regulatory_assessments" }
+
+// RegulatoryClockStatus is where one deadline stands.
+type RegulatoryClockStatus string
+
+const (
+	ClockPending   RegulatoryClockStatus = "pending"
+	ClockSubmitted RegulatoryClockStatus = "submitted"
+	// ClockWithdrawn — the incident was reclassified out of the regime before
+	// this stage was due. Kept, not deleted: "we started the clock and then
+	// decided it was not reportable" is itself a decision an authority may ask
+	// about.
+	ClockWithdrawn RegulatoryClockStatus = "withdrawn"
+)
+
+// RegulatoryClock is one dated reporting obligation on one incident.
+type RegulatoryClock struct {
+	ID           uuid.UUID        `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID     uuid.UUID        `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	IncidentID   uint             `gorm:"not null;index" json:"incident_id"`
+	ProfileID    uuid.UUID        `gorm:"type:uuid;not null;index" json:"profile_id"`
+	Regime       RegulatoryRegime `gorm:"type:varchar(16);not null;index" json:"regime"`
+	Jurisdiction string           `gorm:"size:8" json:"jurisdiction"`
+	Authority    string           `gorm:"size:255" json:"authority"`
+	Stage        string           `gorm:"size:32;not null" json:"stage"`
+	StageLabel   string           `gorm:"size:128" json:"stage_label"`
+	Sequence     int              `gorm:"not null;default:0" json:"sequence"`
+
+	DueAt  time.Time             `gorm:"index" json:"due_at"`
+	Status RegulatoryClockStatus `gorm:"type:varchar(16);not null;index;default:'pending'" json:"status"`
+
+	// The control this obligation discharges, resolved from the regime's catalog
+	// in the tenant's imported framework. Nil when the catalog is not imported.
+	FrameworkID *uuid.UUID `gorm:"type:uuid" json:"framework_id,omitempty"`
+	ControlID   *uuid.UUID `gorm:"type:uuid" json:"control_id,omitempty"`
+
+	EscalationLevel  int        `gorm:"not null;default:0" json:"escalation_level"`
+	LastEscalatedAt  *time.Time `json:"last_escalated_at,omitempty"`
+	NextEscalationAt *time.Time `gorm:"index" json:"next_escalation_at,omitempty"`
+
+	SubmittedAt         *time.Time `json:"submitted_at,omitempty"`
+	SubmittedBy         *uuid.UUID `gorm:"type:uuid" json:"submitted_by,omitempty"`
+	SubmissionReference string     `gorm:"size:255" json:"submission_reference,omitempty"`
+	// SubmissionEvidenceID is the authority's receipt (or the sent notification)
+	// filed in the evidence library and linked to ControlID.
+	SubmissionEvidenceID *uuid.UUID `gorm:"type:uuid" json:"submission_evidence_id,omitempty"`
+	// SubmittedContent freezes what was sent, so a later edit of the incident
+	// does not rewrite history.
+	SubmittedContent JSONMap `gorm:"type:jsonb" json:"submitted_content,omitempty"`
+	WithdrawnReason  string  `gorm:"type:text" json:"withdrawn_reason,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (RegulatoryClock) TableName() string { return "regulatory_clocks" }
+
+// IsOverdue reports whether a pending clock is past its deadline.
+func (c *RegulatoryClock) IsOverdue(now time.Time) bool {
+	return c.Status == ClockPending && now.After(c.DueAt)
+}
+
+// SubmittedLate reports whether a submitted clock missed its deadline.
+func (c *RegulatoryClock) SubmittedLate() bool {
+	return c.Status == ClockSubmitted && c.SubmittedAt != nil && c.SubmittedAt.After(c.DueAt)
+}
+
+// RegulatoryRepository persists profiles, assessments and clocks. Tenant-scoped
+// except ListEscalationDue, the cross-tenant sweep's read.
+type RegulatoryRepository interface {
+	ListProfiles(ctx context.Context, tenantID uuid.UUID) ([]RegulatoryProfile, error)
+	GetProfile(ctx context.Context, tenantID, id uuid.UUID) (*RegulatoryProfile, error)
+	SaveProfile(ctx context.Context, p *RegulatoryProfile) error
+	DeleteProfile(ctx context.Context, tenantID, id uuid.UUID) error
+
+	GetAssessment(ctx context.Context, tenantID uuid.UUID, incidentID uint) (*IncidentRegulatoryAssessment, error)
+	SaveAssessment(ctx context.Context, a *IncidentRegulatoryAssessment) error
+
+	ListClocks(ctx context.Context, tenantID uuid.UUID, incidentID uint) ([]RegulatoryClock, error)
+	ListOpenClocks(ctx context.Context, tenantID uuid.UUID) ([]RegulatoryClock, error)
+	GetClock(ctx context.Context, tenantID, id uuid.UUID) (*RegulatoryClock, error)
+	SaveClock(ctx context.Context, c *RegulatoryClock) error
+	ListEscalationDue(ctx context.Context, now time.Time, limit int) ([]RegulatoryClock, error)
+}
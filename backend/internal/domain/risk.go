@@ -154,6 +154,7 @@ const (
 	SourceScanAuto RiskSource = "scan_auto" // From vulnerability scanner
 	SourceImport   RiskSource = "import"    // Imported from file
 	SourceVendor   RiskSource = "vendor"    // From vendor assessment
+	SourceScenario RiskSource = "scenario"  // Instantiated from a risk scenario
 	SourceAI       RiskSource = "ai"        // AI-generated
 )
 
@@ -166,7 +167,7 @@ func ParseRiskSource(s string) (RiskSource, error) {
 		return SourceManual, nil
 	}
 	switch RiskSource(s) {
-	case SourceManual, SourceCTIAuto, SourceScanAuto, SourceImport, SourceVendor, SourceAI:
+	case SourceManual, SourceCTIAuto, SourceScanAuto, SourceImport, SourceVendor, SourceScenario, SourceAI:
 		return RiskSource(s), nil
 	default:
 		return "", NewValidationError(fmt.Sprintf("invalid risk source: %q", s))
@@ -248,6 +249,16 @@ type Risk struct {
 	CategoryID *uuid.UUID    `gorm:"type:uuid;index" json:"category_id"`
 	Category   *RiskCategory `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
 
+	// BusinessUnit is the part of the organisation that owns the exposure →
+	// column "Entité". Free text (the tenant has no org chart yet); appetite
+	// statements scoped to a business unit match it case-insensitively.
+	BusinessUnit string `gorm:"size:128;index" json:"business_unit,omitempty"`
+
+	// Classification is the risk's confidentiality level (public, internal,
+	// confidential…), free text like BusinessUnit. Access policies test it to
+	// keep sensitive risks to the people entitled to them.
+	Classification string `gorm:"size:32;not null;default:'';index" json:"classification,omitempty"`
+
 	// ControlMappings are references to REAL compliance controls → column
 	// "Référentiel". Loaded by the list/get use cases, never stored inline.
 	ControlMappings []RiskControlMapping `gorm:"-" json:"control_mappings,omitempty"`
@@ -266,6 +277,11 @@ type Risk struct {
 	TreatmentPlan   RiskTreatment `gorm:"type:varchar(20);default:'mitigate'" json:"treatment_plan"` // accept|mitigate|transfer|avoid
 	ResidualRisk    *float64      `gorm:"type:numeric(8,3)" json:"residual_risk"`                    // Score after treatments
 	LastMitigatedAt *time.Time    `json:"last_mitigated_at"`
+	// ResidualSource says where ResidualRisk and MitigationEffectiveness came
+	// from: "" when typed in, "controls" when derived from the tested
+	// effectiveness of the mapped controls (see DeriveResidual). Once derived,
+	// the next control test rewrites them.
+	ResidualSource string `gorm:"size:16;default:''" json:"residual_source,omitempty"`
 
 	// Cyber Risk Quantification (CRQ) — monetary loss inputs (pkg/crq). Optional:
 	// when both are set, ALE = SLE × ARO; otherwise a reference value per
@@ -284,6 +300,11 @@ type Risk struct {
 	OtherDirectCostXAF      *float64 `gorm:"type:numeric(16,2)" json:"other_direct_cost_xaf"`    // any other direct per-incident cost
 	RemediationCostXAF      *float64 `gorm:"type:numeric(16,2)" json:"remediation_cost_xaf"`     // budget to deploy the control
 	MitigationEffectiveness *float64 `gorm:"type:numeric(5,4)" json:"mitigation_effectiveness"`  // [0,1] share of ALE removed
+	// DowntimeHoursSource says where DowntimeHours came from: "" when typed in,
+	// "bia" when derived from the business impact analysis of the risk's asset
+	// (see bia.BIAService.Sync). Only a "bia" value is ever refreshed by the
+	// BIA; a figure someone entered by hand is theirs.
+	DowntimeHoursSource string `gorm:"size:8;default:''" json:"downtime_hours_source,omitempty"`
 
 	// Computed, NOT persisted — filled by the handler via pkg/crq before responding.
 	ALEXAF   float64 `gorm:"-" json:"ale_xaf"`   // annual loss expectancy (XAF)
@@ -318,6 +339,12 @@ type Risk struct {
 	// items nobody can evaluate, and the honest response to that is to ignore
 	// all of them.
 	SourceVulnerabilityID *uuid.UUID `gorm:"type:uuid;index" json:"source_vulnerability_id,omitempty"`
+	// SourceVendorID is the vendor whose failed assessment proposed the risk.
+	SourceVendorID *uuid.UUID `gorm:"type:uuid;index" json:"source_vendor_id,omitempty"`
+	// SourceScenarioID and SourceScenarioVersion name the scenario template
+	// (see scenario.go) the risk was drafted from, and the version it had then.
+	SourceScenarioID      *uuid.UUID `gorm:"type:uuid;index" json:"source_scenario_id,omitempty"`
+	SourceScenarioVersion *int       `json:"source_scenario_version,omitempty"`
 	// SourceRuleReason is the rule's own explanation, captured at creation time
 	// ("CVSS 9.8 ≥ 7.0, asset criticality CRITICAL ≥ HIGH"). Frozen: re-deriving
 	// it later would describe the rule as it is NOW, not the rule that fired.
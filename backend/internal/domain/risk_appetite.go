@@ -0,0 +1,296 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---------------------------------------------------------------------------
+// Risk appetite and tolerance.
+//
+// PhaseEvaluated has always said "prioritised vs risk appetite"; this is the
+// appetite. A statement is the board's wording ("we accept no critical cyber
+// risk without a funded plan") plus the tolerances that make it checkable. It
+// is scoped to one risk category or one business unit, because that is how
+// boards actually express appetite — nobody approves a single number for the
+// whole organisation.
+//
+// Two kinds of tolerance live on one statement:
+//
+//   - per-risk: score and SmartScore. Every risk in scope above either is
+//     above appetite on its own.
+//   - portfolio: ALE P90 (the scope's total annual loss at the 90th
+//     percentile) and the number of critical risks. These are properties of
+//     the scope, not of any one risk.
+//
+// A risk above appetite may stay open, but only as a decision: an approved
+// exception through the approval engine (AppetiteExceptionEntityType). That is
+// enforced where risk is formally kept open — RESIDUAL_ACCEPTED — and reported
+// everywhere else.
+// ---------------------------------------------------------------------------
+
+// AppetiteScope says what a statement covers.
+type AppetiteScope string
+
+const (
+	AppetiteScopeCategory     AppetiteScope = "category"
+	AppetiteScopeBusinessUnit AppetiteScope = "business_unit"
+)
+
+// AppetiteMetric names what a tolerance bounds.
+type AppetiteMetric string
+
+const (
+	AppetiteMetricScore         AppetiteMetric = "score"
+	AppetiteMetricSmartScore    AppetiteMetric = "smart_score"
+	AppetiteMetricALEP90        AppetiteMetric = "ale_p90_xaf"
+	AppetiteMetricCriticalCount AppetiteMetric = "critical_count"
+)
+
+// Appetite exceptions are approval requests of this (entity_type, action), with
+// the risk id as entity id. See ApprovalRequestTypes.
+const (
+	AppetiteExceptionEntityType = "risk_appetite_exception"
+	AppetiteExceptionAction     = "grant"
+)
+
+// maxRiskScore is the ceiling of Score (P ≤ 1 × I ≤ 10 × asset criticality ≤ 3).
+const maxRiskScore = 30
+
+// RiskAppetiteStatement is one board-approved appetite statement.
+type RiskAppetiteStatement struct {
+	ID       uuid.UUID     `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID uuid.UUID     `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Scope    AppetiteScope `gorm:"type:varchar(16);not null" json:"scope"`
+	// Exactly one of CategoryID / BusinessUnit is set, matching Scope.
+	CategoryID   *uuid.UUID `gorm:"type:uuid;index" json:"category_id,omitempty"`
+	BusinessUnit string     `gorm:"size:128" json:"business_unit,omitempty"`
+	// Statement is the board's own wording, kept verbatim: the tolerances are
+	// how the tool checks it, not a replacement for it.
+	Statement string `gorm:"type:text;not null" json:"statement"`
+
+	// Tolerances. nil = not bounded on that metric.
+	MaxScore         *float64 `gorm:"type:numeric(8,3)" json:"max_score"`
+	MaxSmartScore    *float64 `gorm:"type:numeric(5,2)" json:"max_smart_score"`
+	MaxALEP90XAF     *float64 `gorm:"type:numeric(18,2)" json:"max_ale_p90_xaf"`
+	MaxCriticalCount *int     `json:"max_critical_count"`
+
+	// ApprovedOn is the date the board approved the statement and
+	// ApprovalReference the minute or resolution that records it. A statement
+	// without ApprovedOn is a draft: shown, evaluated for information, never
+	// enforced. Appetite nobody approved is an opinion.
+	ApprovedOn        *time.Time `json:"approved_on,omitempty"`
+	ApprovalReference string     `gorm:"size:255" json:"approval_reference,omitempty"`
+
+	UpdatedBy *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (RiskAppetiteStatement) TableName() string { return "risk_appetite_statements" }
+
+// Validate enforces the statement's invariants and normalises the business unit.
+func (s *RiskAppetiteStatement) Validate() error {
+	s.Statement = strings.TrimSpace(s.Statement)
+	s.BusinessUnit = strings.TrimSpace(s.BusinessUnit)
+	if s.Statement == "" {
+		return NewValidationError("statement is required")
+	}
+	switch s.Scope {
+	case AppetiteScopeCategory:
+		if s.CategoryID == nil || *s.CategoryID == uuid.Nil {
+			return NewValidationError("a category statement needs category_id")
+		}
+		if s.BusinessUnit != "" {
+			return NewValidationError("a category statement cannot also name a business_unit")
+		}
+	case AppetiteScopeBusinessUnit:
+		if s.BusinessUnit == "" {
+			return NewValidationError("a business unit statement needs business_unit")
+		}
+		if s.CategoryID != nil {
+			return NewValidationError("a business unit statement cannot also name a category_id")
+		}
+	default:
+		return NewValidationError(fmt.Sprintf("scope must be %q or %q", AppetiteScopeCategory, AppetiteScopeBusinessUnit))
+	}
+	if s.MaxScore == nil && s.MaxSmartScore == nil && s.MaxALEP90XAF == nil && s.MaxCriticalCount == nil {
+		return NewValidationError("a statement needs at least one tolerance (max_score, max_smart_score, max_ale_p90_xaf or max_critical_count)")
+	}
+	if s.MaxScore != nil && (*s.MaxScore < 0 || *s.MaxScore > maxRiskScore) {
+		return NewValidationError(fmt.Sprintf("max_score must be between 0 and %d", maxRiskScore))
+	}
+	if s.MaxSmartScore != nil && (*s.MaxSmartScore < 0 || *s.MaxSmartScore > 100) {
+		return NewValidationError("max_smart_score must be between 0 and 100")
+	}
+	if s.MaxALEP90XAF != nil && *s.MaxALEP90XAF < 0 {
+		return NewValidationError("max_ale_p90_xaf cannot be negative")
+	}
+	if s.MaxCriticalCount != nil && *s.MaxCriticalCount < 0 {
+		return NewValidationError("max_critical_count cannot be negative")
+	}
+	if s.ApprovedOn == nil && s.ApprovalReference != "" {
+		return NewValidationError("approval_reference without approved_on: record the date the board approved it")
+	}
+	return nil
+}
+
+// InForce reports whether the statement is board-approved and so enforced.
+func (s *RiskAppetiteStatement) InForce() bool { return s.ApprovedOn != nil }
+
+// SameScope reports whether two statements cover the same population. One
+// statement per scope: two tolerances on the same risks would make "above
+// appetite" depend on which one was read first.
+func (s *RiskAppetiteStatement) SameScope(o *RiskAppetiteStatement) bool {
+	if s.Scope != o.Scope {
+		return false
+	}
+	if s.Scope == AppetiteScopeCategory {
+		return s.CategoryID != nil && o.CategoryID != nil && *s.CategoryID == *o.CategoryID
+	}
+	return strings.EqualFold(s.BusinessUnit, o.BusinessUnit)
+}
+
+// Covers reports whether a risk falls in the statement's scope. Business units
+// are free text on the risk, so they match case-insensitively.
+func (s *RiskAppetiteStatement) Covers(r *Risk) bool {
+	switch s.Scope {
+	case AppetiteScopeCategory:
+		return s.CategoryID != nil && r.CategoryID != nil && *r.CategoryID == *s.CategoryID
+	case AppetiteScopeBusinessUnit:
+		return s.BusinessUnit != "" && strings.EqualFold(strings.TrimSpace(r.BusinessUnit), s.BusinessUnit)
+	}
+	return false
+}
+
+// AppetiteBreach is one tolerance exceeded.
+type AppetiteBreach struct {
+	Metric AppetiteMetric `json:"metric"`
+	Value  float64        `json:"value"`
+	Limit  float64        `json:"limit"`
+}
+
+// RiskBreaches returns the per-risk tolerances a risk exceeds. A SmartScore
+// that was never computed is not compared: zero would read as "well within".
+func (s *RiskAppetiteStatement) RiskBreaches(r *Risk) []AppetiteBreach {
+	var out []AppetiteBreach
+	if s.MaxScore != nil && r.Score > *s.MaxScore {
+		out = append(out, AppetiteBreach{Metric: AppetiteMetricScore, Value: r.Score, Limit: *s.MaxScore})
+	}
+	if s.MaxSmartScore != nil && r.SmartComputedAt != nil && r.SmartScore > *s.MaxSmartScore {
+		out = append(out, AppetiteBreach{Metric: AppetiteMetricSmartScore, Value: r.SmartScore, Limit: *s.MaxSmartScore})
+	}
+	return out
+}
+
+// PortfolioBreaches returns the scope-level tolerances exceeded. aleP90 is nil
+// when the loss distribution could not be computed, and then not compared.
+func (s *RiskAppetiteStatement) PortfolioBreaches(criticalCount int, aleP90 *float64) []AppetiteBreach {
+	var out []AppetiteBreach
+	if s.MaxALEP90XAF != nil && aleP90 != nil && *aleP90 > *s.MaxALEP90XAF {
+		out = append(out, AppetiteBreach{Metric: AppetiteMetricALEP90, Value: *aleP90, Limit: *s.MaxALEP90XAF})
+	}
+	if s.MaxCriticalCount != nil && criticalCount > *s.MaxCriticalCount {
+		out = append(out, AppetiteBreach{Metric: AppetiteMetricCriticalCount, Value: float64(criticalCount), Limit: float64(*s.MaxCriticalCount)})
+	}
+	return out
+}
+
+// CountsTowardAppetite reports whether a risk is part of the exposure appetite
+// is measured against: everything in the register that is not a draft and not
+// already dealt with. RESIDUAL_ACCEPTED counts — accepted risk is still risk.
+func CountsTowardAppetite(r *Risk) bool {
+	switch r.State() {
+	case StateDraft, StateMitigated, StateClosed:
+		return false
+	}
+	return true
+}
+
+// IsCriticalRisk is the critical-count predicate: the stored criticality band.
+func IsCriticalRisk(r *Risk) bool {
+	return strings.EqualFold(strings.TrimSpace(string(r.Criticality)), string(RiskCriticalityCritical))
+}
+
+// AppetiteExceptionStatus is where a risk stands on its exception.
+type AppetiteExceptionStatus string
+
+const (
+	AppetiteExceptionNone     AppetiteExceptionStatus = "none"
+	AppetiteExceptionPending  AppetiteExceptionStatus = "pending"
+	AppetiteExceptionApproved AppetiteExceptionStatus = "approved"
+	// AppetiteExceptionExpired is an approved exception past its valid_until.
+	// Kept distinct from none so the owner is told to renew, not to start over.
+	AppetiteExceptionExpired AppetiteExceptionStatus = "expired"
+)
+
+// AppetiteException is the resolved exception standing of one risk.
+type AppetiteException struct {
+	Status     AppetiteExceptionStatus `json:"status"`
+	RequestID  *uuid.UUID              `json:"request_id,omitempty"`
+	ValidUntil *time.Time              `json:"valid_until,omitempty"`
+}
+
+// Valid reports whether the exception currently authorises keeping the risk open.
+func (e AppetiteException) Valid() bool { return e.Status == AppetiteExceptionApproved }
+
+// ResolveAppetiteException picks a risk's standing from its exception requests.
+// A valid approval wins over anything else; then a pending request; then an
+// expired approval. valid_until travels in the request payload as RFC 3339.
+func ResolveAppetiteException(reqs []ApprovalRequest, riskID uuid.UUID, now time.Time) AppetiteException {
+	want := riskID.String()
+	out := AppetiteException{Status: AppetiteExceptionNone}
+	for i := range reqs {
+		r := &reqs[i]
+		if r.EntityType != AppetiteExceptionEntityType || r.EntityID != want {
+			continue
+		}
+		id := r.ID
+		switch r.Status {
+		case ApprovalApproved:
+			until := appetiteValidUntil(r.Payload)
+			if until != nil && !now.Before(*until) {
+				if out.Status == AppetiteExceptionNone {
+					out = AppetiteException{Status: AppetiteExceptionExpired, RequestID: &id, ValidUntil: until}
+				}
+				continue
+			}
+			return AppetiteException{Status: AppetiteExceptionApproved, RequestID: &id, ValidUntil: until}
+		case ApprovalPending:
+			if out.Status != AppetiteExceptionPending {
+				out = AppetiteException{Status: AppetiteExceptionPending, RequestID: &id}
+			}
+		}
+	}
+	return out
+}
+
+func appetiteValidUntil(p JSONMap) *time.Time {
+	raw, ok := p["valid_until"].(string)
+	if !ok || raw == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// RiskAppetiteRepository is the tenant-scoped store for appetite statements.
+type RiskAppetiteRepository interface {
+	List(ctx context.Context, tenantID uuid.UUID) ([]RiskAppetiteStatement, error)
+	// Get returns (nil, nil) when absent or owned by another tenant.
+	Get(ctx context.Context, tenantID, id uuid.UUID) (*RiskAppetiteStatement, error)
+	Save(ctx context.Context, s *RiskAppetiteStatement) error
+	Delete(ctx context.Context, tenantID, id uuid.UUID) error
+}
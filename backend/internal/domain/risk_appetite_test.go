@@ -0,0 +1,126 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func fptr(v float64) *float64 { return &v }
+func iptr(v int) *int         { return &v }
+
+func TestRiskAppetiteStatement_Validate(t *testing.T) {
+	cat := uuid.New()
+	now := time.Now()
+	ok := []RiskAppetiteStatement{
+		{Scope: AppetiteScopeCategory, CategoryID: &cat, Statement: "x", MaxScore: fptr(12)},
+		{Scope: AppetiteScopeBusinessUnit, BusinessUnit: " Retail ", Statement: "x", MaxALEP90XAF: fptr(5e8), ApprovedOn: &now, ApprovalReference: "BOARD-7"},
+	}
+	for i := range ok {
+		if err := ok[i].Validate(); err != nil {
+			t.Fatalf("case %d refused: %v", i, err)
+		}
+	}
+	if ok[1].BusinessUnit != "Retail" {
+		t.Fatalf("business unit not trimmed: %q", ok[1].BusinessUnit)
+	}
+
+	bad := map[string]RiskAppetiteStatement{
+		"no tolerance":           {Scope: AppetiteScopeCategory, CategoryID: &cat, Statement: "x"},
+		"no statement":           {Scope: AppetiteScopeCategory, CategoryID: &cat, MaxScore: fptr(1)},
+		"category without id":    {Scope: AppetiteScopeCategory, Statement: "x", MaxScore: fptr(1)},
+		"both scopes":            {Scope: AppetiteScopeBusinessUnit, BusinessUnit: "R", CategoryID: &cat, Statement: "x", MaxScore: fptr(1)},
+		"score above ceiling":    {Scope: AppetiteScopeBusinessUnit, BusinessUnit: "R", Statement: "x", MaxScore: fptr(31)},
+		"smart above 100":        {Scope: AppetiteScopeBusinessUnit, BusinessUnit: "R", Statement: "x", MaxSmartScore: fptr(101)},
+		"reference without date": {Scope: AppetiteScopeBusinessUnit, BusinessUnit: "R", Statement: "x", MaxScore: fptr(1), ApprovalReference: "BOARD-7"},
+		"unknown scope":          {Scope: "group", Statement: "x", MaxScore: fptr(1)},
+	}
+	for name, s := range bad {
+		if err := s.Validate(); !errors.Is(err, ErrValidation) {
+			t.Fatalf("%s: want validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestRiskAppetiteStatement_CoversAndBreaches(t *testing.T) {
+	cat := uuid.New()
+	bu := RiskAppetiteStatement{Scope: AppetiteScopeBusinessUnit, BusinessUnit: "Retail", MaxScore: fptr(10), MaxSmartScore: fptr(60)}
+	byCat := RiskAppetiteStatement{Scope: AppetiteScopeCategory, CategoryID: &cat, MaxCriticalCount: iptr(1), MaxALEP90XAF: fptr(1000)}
+
+	r := &Risk{BusinessUnit: "retail ", CategoryID: &cat, Score: 14, SmartScore: 90}
+	if !bu.Covers(r) || !byCat.Covers(r) {
+		t.Fatal("business units match case-insensitively and categories by id")
+	}
+	if bu.Covers(&Risk{BusinessUnit: "Wholesale"}) || byCat.Covers(&Risk{}) {
+		t.Fatal("an unrelated or unclassified risk is out of scope")
+	}
+
+	// SmartScore never computed → not compared, even though 90 > 60.
+	got := bu.RiskBreaches(r)
+	if len(got) != 1 || got[0].Metric != AppetiteMetricScore || got[0].Limit != 10 {
+		t.Fatalf("breaches = %+v", got)
+	}
+	at := time.Now()
+	r.SmartComputedAt = &at
+	if got := bu.RiskBreaches(r); len(got) != 2 {
+		t.Fatalf("a computed SmartScore is compared: %+v", got)
+	}
+
+	if got := byCat.PortfolioBreaches(1, nil); len(got) != 0 {
+		t.Fatalf("at the limit is within appetite, and no ALE is no comparison: %+v", got)
+	}
+	if got := byCat.PortfolioBreaches(2, fptr(2000)); len(got) != 2 {
+		t.Fatalf("both portfolio tolerances exceeded: %+v", got)
+	}
+}
+
+func TestCountsTowardAppetite(t *testing.T) {
+	for state, want := range map[RiskState]bool{
+		StateDraft: false, StateAssessed: true, StateInTreatment: true,
+		StateResidualAccepted: true, StateMitigated: false, StateClosed: false,
+	} {
+		r := &Risk{}
+		r.SetState(state)
+		if got := CountsTowardAppetite(r); got != want {
+			t.Fatalf("%s: got %v want %v", state, got, want)
+		}
+	}
+}
+
+func TestResolveAppetiteException(t *testing.T) {
+	riskID := uuid.New()
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	req := func(status ApprovalStatus, until string) ApprovalRequest {
+		p := JSONMap{}
+		if until != "" {
+			p["valid_until"] = until
+		}
+		return ApprovalRequest{ID: uuid.New(), EntityType: AppetiteExceptionEntityType, EntityID: riskID.String(), Status: status, Payload: p}
+	}
+
+	if got := ResolveAppetiteException(nil, riskID, now); got.Status != AppetiteExceptionNone {
+		t.Fatalf("no request → none, got %v", got.Status)
+	}
+	expired := req(ApprovalApproved, "2026-09-01T00:00:00Z")
+	if got := ResolveAppetiteException([]ApprovalRequest{expired}, riskID, now); got.Status != AppetiteExceptionExpired {
+		t.Fatalf("past valid_until → expired, got %v", got.Status)
+	}
+	pending := req(ApprovalPending, "")
+	if got := ResolveAppetiteException([]ApprovalRequest{expired, pending}, riskID, now); got.Status != AppetiteExceptionPending || *got.RequestID != pending.ID {
+		t.Fatalf("a renewal in flight → pending, got %+v", got)
+	}
+	valid := req(ApprovalApproved, "2027-01-01T00:00:00Z")
+	if got := ResolveAppetiteException([]ApprovalRequest{pending, expired, valid}, riskID, now); !got.Valid() || *got.RequestID != valid.ID {
+		t.Fatalf("a valid approval wins, got %+v", got)
+	}
+	other := req(ApprovalApproved, "")
+	other.EntityID = uuid.NewString()
+	if got := ResolveAppetiteException([]ApprovalRequest{other}, riskID, now); got.Status != AppetiteExceptionNone {
+		t.Fatalf("another risk's exception is not this one's, got %v", got.Status)
+	}
+}
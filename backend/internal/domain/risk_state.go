@@ -279,6 +279,11 @@ const (
 	// GuardGovernanceApproval — RESIDUAL_ACCEPTED requires an approved
 	// Governance request. Accepting residual risk is a decision, not a dropdown.
 	GuardGovernanceApproval TransitionGuard = "governance_approval"
+	// GuardAppetiteException — RESIDUAL_ACCEPTED of a risk above the board's
+	// appetite additionally requires a valid appetite exception. Accepting risk
+	// the board said it would not carry is a decision above the risk owner's.
+	// A risk within appetite passes this guard untouched.
+	GuardAppetiteException TransitionGuard = "appetite_exception"
 )
 
 // GuardsFor returns the preconditions a target state imposes.
@@ -289,7 +294,7 @@ func GuardsFor(target RiskState) []TransitionGuard {
 	case StateMitigated:
 		return []TransitionGuard{GuardSubActionsComplete}
 	case StateResidualAccepted:
-		return []TransitionGuard{GuardGovernanceApproval}
+		return []TransitionGuard{GuardGovernanceApproval, GuardAppetiteException}
 	default:
 		return nil
 	}
@@ -136,7 +136,7 @@ func TestGuardsFor_MatchTheSpec(t *testing.T) {
 	cases := map[RiskState][]TransitionGuard{
 		StateInTreatment:      {GuardActiveMitigation},
 		StateMitigated:        {GuardSubActionsComplete},
-		StateResidualAccepted: {GuardGovernanceApproval},
+		StateResidualAccepted: {GuardGovernanceApproval, GuardAppetiteException},
 		StateClosed:           nil,
 		StateIdentified:       nil,
 	}
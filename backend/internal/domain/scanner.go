@@ -62,6 +62,10 @@ const (
 	ProviderM365            ScannerProvider = "m365"             // users/devices via Microsoft Graph
 	ProviderGitHub          ScannerProvider = "github"           // repositories via the GitHub API
 	ProviderGitLab          ScannerProvider = "gitlab"           // projects via the GitLab API
+
+	// ProviderPlugin tags previews staged by a connector plugin's emit_assets
+	// hook. It is not a scan configuration provider, so Valid() refuses it.
+	ProviderPlugin ScannerProvider = "plugin"
 )
 
 // IsAgentBased reports whether the provider is executed by an on-prem Agent
@@ -0,0 +1,397 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ---------------------------------------------------------------------------
+// Risk scenarios.
+//
+// A risk in the register is free text: two analysts writing up "ransomware"
+// produce two different titles, two guesses at frequency and no link to the
+// techniques an attacker would actually use. A scenario is the reusable
+// template behind such a risk:
+//
+//   - who attacks (threat actors) and how (MITRE ATT&CK technique ids);
+//   - which asset categories it applies to;
+//   - typical FAIR ranges: loss event frequency and loss magnitude, as
+//     min / most likely / max, the shape pkg/crq already simulates;
+//   - the controls that mitigate it, as references into the regulatory
+//     catalogs (pkg/compliance), per technique and for the scenario as a
+//     whole.
+//
+// Instantiating a scenario against assets drafts one risk per asset with
+// those figures pre-filled and the matching tenant controls linked. Because
+// techniques name their mitigations, the same data answers the coverage
+// question: which techniques have no implemented control in this tenant.
+//
+// The built-in library (scenario_library.go) lives in code, is versioned as a
+// whole, and is read-only; tenants add their own scenarios beside it.
+// ---------------------------------------------------------------------------
+
+// ScenarioControlRef points at one control of a regulatory catalog, e.g.
+// {iso27001-2022, A.8.13}. It is matched against the tenant's controls by the
+// framework's catalog key and the control's reference code.
+type ScenarioControlRef struct {
+	Catalog string `json:"catalog"`
+	Ref     string `json:"ref"`
+}
+
+func (r ScenarioControlRef) String() string { return r.Catalog + ":" + r.Ref }
+
+// ScenarioControlRefs is a jsonb list of control references.
+type ScenarioControlRefs []ScenarioControlRef
+
+func (rs ScenarioControlRefs) Value() (driver.Value, error) { return json.Marshal(rs) }
+
+func (rs *ScenarioControlRefs) Scan(value interface{}) error {
+	return scanJSONColumn(value, rs, "ScenarioControlRefs")
+}
+
+// ScenarioTechnique is one ATT&CK technique a scenario uses, with the controls
+// that mitigate it.
+type ScenarioTechnique struct {
+	ID          string               `json:"id"` // T1486, T1566.001
+	Name        string               `json:"name"`
+	Tactic      string               `json:"tactic"`
+	Mitigations []ScenarioControlRef `json:"mitigations"`
+}
+
+// ScenarioTechniques is a jsonb list of techniques.
+type ScenarioTechniques []ScenarioTechnique
+
+func (ts ScenarioTechniques) Value() (driver.Value, error) { return json.Marshal(ts) }
+
+func (ts *ScenarioTechniques) Scan(value interface{}) error {
+	return scanJSONColumn(value, ts, "ScenarioTechniques")
+}
+
+// ThreatActorKind classifies who is behind a scenario.
+type ThreatActorKind string
+
+const (
+	ThreatActorCriminal    ThreatActorKind = "criminal"
+	ThreatActorNationState ThreatActorKind = "nation_state"
+	ThreatActorHacktivist  ThreatActorKind = "hacktivist"
+	ThreatActorInsider     ThreatActorKind = "insider"
+	ThreatActorPartner     ThreatActorKind = "partner"
+)
+
+// ThreatActor is a class of attacker, not a named group: "ransomware affiliate"
+// rather than a brand that changes every quarter.
+type ThreatActor struct {
+	Name       string          `json:"name"`
+	Kind       ThreatActorKind `json:"kind"`
+	Motivation string          `json:"motivation"`
+}
+
+// ThreatActors is a jsonb list of threat actors.
+type ThreatActors []ThreatActor
+
+func (as ThreatActors) Value() (driver.Value, error) { return json.Marshal(as) }
+
+func (as *ThreatActors) Scan(value interface{}) error {
+	return scanJSONColumn(value, as, "ThreatActors")
+}
+
+// FAIRRange is a min / most likely / max estimate, the PERT shape pkg/crq
+// samples from.
+type FAIRRange struct {
+	Min  float64 `json:"min"`
+	Mode float64 `json:"mode"`
+	Max  float64 `json:"max"`
+}
+
+func (r FAIRRange) Value() (driver.Value, error) { return json.Marshal(r) }
+
+func (r *FAIRRange) Scan(value interface{}) error {
+	return scanJSONColumn(value, r, "FAIRRange")
+}
+
+func (r FAIRRange) validate(what string) error {
+	if r.Min < 0 || r.Mode < r.Min || r.Max < r.Mode {
+		return NewValidationError(fmt.Sprintf("%s must satisfy 0 ≤ min ≤ mode ≤ max", what))
+	}
+	if r.Max == 0 {
+		return NewValidationError(fmt.Sprintf("%s cannot be all zero", what))
+	}
+	return nil
+}
+
+// RiskScenario is a scenario template. Built-in scenarios are not stored: they
+// live in code with stable ids (see scenario_library.go), and only a tenant's
+// own scenarios have rows.
+type RiskScenario struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+
+	// Key is a stable slug ("ransomware-erp"). Risks are tagged with it.
+	Key string `gorm:"size:64;not null" json:"key"`
+	// Version counts content changes. A risk records the version it was drafted
+	// from, so an analyst can tell a risk from an outdated template.
+	Version     int    `gorm:"not null;default:1" json:"version"`
+	Title       string `gorm:"size:200;not null" json:"title"`
+	Description string `gorm:"type:text" json:"description"`
+
+	ThreatActors ThreatActors       `gorm:"type:jsonb" json:"threat_actors"`
+	Techniques   ScenarioTechniques `gorm:"type:jsonb" json:"techniques"`
+	// AssetCategories the scenario applies to. Empty means any asset.
+	AssetCategories pq.StringArray `gorm:"type:text[]" json:"asset_categories"`
+
+	// LEF is the loss event frequency, in events per year.
+	LEF FAIRRange `gorm:"column:lef;type:jsonb" json:"lef"`
+	// LossMagnitude is the loss per event, in XAF.
+	LossMagnitude FAIRRange `gorm:"type:jsonb" json:"loss_magnitude"`
+	// Impact is the Score Engine impact (0–10) a drafted risk starts from.
+	Impact float64 `gorm:"type:numeric(4,2);not null" json:"impact"`
+	// SuggestedControls mitigate the scenario as a whole rather than one
+	// technique: incident response, backups tested for restore, insurance.
+	SuggestedControls ScenarioControlRefs `gorm:"type:jsonb" json:"suggested_controls"`
+
+	BuiltIn bool `gorm:"-" json:"built_in"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TableName pins the table name.
+func (RiskScenario) TableName() string { return "risk_scenarios" }
+
+var (
+	scenarioKeyRE       = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,63}$`)
+	attackTechniqueIDRE = regexp.MustCompile(`^T[0-9]{4}(\.[0-9]{3})?$`)
+)
+
+// Validate normalises and checks a scenario. Technique names and tactics are
+// filled in from the ATT&CK table when the id is known and they were left
+// blank. Whether a control reference exists in its catalog is checked by the
+// application layer, which owns the catalogs.
+func (s *RiskScenario) Validate() error {
+	s.Key = strings.ToLower(strings.TrimSpace(s.Key))
+	s.Title = strings.TrimSpace(s.Title)
+	if s.Title == "" {
+		return NewValidationError("scenario title is required")
+	}
+	if !scenarioKeyRE.MatchString(s.Key) {
+		return NewValidationError("scenario key must be 2–64 lowercase letters, digits or dashes")
+	}
+	for i := range s.ThreatActors {
+		a := &s.ThreatActors[i]
+		a.Name = strings.TrimSpace(a.Name)
+		if a.Name == "" {
+			return NewValidationError("every threat actor needs a name")
+		}
+		switch a.Kind {
+		case ThreatActorCriminal, ThreatActorNationState, ThreatActorHacktivist, ThreatActorInsider, ThreatActorPartner:
+		default:
+			return NewValidationError("threat actor kind must be criminal, nation_state, hacktivist, insider or partner")
+		}
+	}
+	if len(s.Techniques) == 0 {
+		return NewValidationError("a scenario needs at least one ATT&CK technique")
+	}
+	seen := map[string]bool{}
+	for i := range s.Techniques {
+		t := &s.Techniques[i]
+		t.ID = strings.ToUpper(strings.TrimSpace(t.ID))
+		if !attackTechniqueIDRE.MatchString(t.ID) {
+			return NewValidationError(fmt.Sprintf("%q is not an ATT&CK technique id (T1234 or T1234.001)", t.ID))
+		}
+		if seen[t.ID] {
+			return NewValidationError(fmt.Sprintf("technique %s is listed twice", t.ID))
+		}
+		seen[t.ID] = true
+		if known, ok := AttackTechniqueByID(t.ID); ok {
+			if t.Name == "" {
+				t.Name = known.Name
+			}
+			if t.Tactic == "" {
+				t.Tactic = known.Tactic
+			}
+		}
+		if err := validateControlRefs(t.Mitigations); err != nil {
+			return err
+		}
+	}
+	cats := make(pq.StringArray, 0, len(s.AssetCategories))
+	for _, c := range s.AssetCategories {
+		cat, err := ParseAssetCategory(c)
+		if err != nil {
+			return err
+		}
+		cats = append(cats, string(cat))
+	}
+	s.AssetCategories = cats
+	if err := s.LEF.validate("lef"); err != nil {
+		return err
+	}
+	if err := s.LossMagnitude.validate("loss_magnitude"); err != nil {
+		return err
+	}
+	if s.Impact <= 0 || s.Impact > 10 {
+		return NewValidationError("impact must be greater than 0 and at most 10")
+	}
+	return validateControlRefs(s.SuggestedControls)
+}
+
+func validateControlRefs(refs []ScenarioControlRef) error {
+	for i := range refs {
+		refs[i].Catalog = strings.TrimSpace(refs[i].Catalog)
+		refs[i].Ref = strings.TrimSpace(refs[i].Ref)
+		if refs[i].Catalog == "" || refs[i].Ref == "" {
+			return NewValidationError("a control reference needs a catalog and a ref")
+		}
+	}
+	return nil
+}
+
+// ControlRefs is every control the scenario names — technique mitigations
+// first, then the suggested ones — without duplicates.
+func (s *RiskScenario) ControlRefs() []ScenarioControlRef {
+	seen := map[ScenarioControlRef]bool{}
+	var out []ScenarioControlRef
+	add := func(refs []ScenarioControlRef) {
+		for _, r := range refs {
+			if !seen[r] {
+				seen[r] = true
+				out = append(out, r)
+			}
+		}
+	}
+	for _, t := range s.Techniques {
+		add(t.Mitigations)
+	}
+	add(s.SuggestedControls)
+	return out
+}
+
+// AppliesTo reports whether the scenario can be instantiated against an asset
+// of the category. An asset written before categories existed has none, and
+// is let through: refusing it would punish the inventory's age, not a mistake.
+func (s *RiskScenario) AppliesTo(cat AssetCategory) bool {
+	if len(s.AssetCategories) == 0 || cat == "" {
+		return true
+	}
+	for _, c := range s.AssetCategories {
+		if AssetCategory(c) == cat {
+			return true
+		}
+	}
+	return false
+}
+
+// AnnualProbability is the chance of at least one loss event in a year, given
+// the most likely frequency: 1 − e^(−LEF) under a Poisson arrival model. It is
+// clamped to [0.01, 0.99] so a drafted risk is never certain nor impossible.
+func (s *RiskScenario) AnnualProbability() float64 {
+	p := 1 - math.Exp(-s.LEF.Mode)
+	p = math.Round(p*100) / 100
+	return math.Min(0.99, math.Max(0.01, p))
+}
+
+// DraftRisk pre-fills a DRAFT risk for the scenario on one asset.
+//
+// Probability comes from the frequency range, impact from the scenario, and
+// the score follows the Score Engine (P × I × asset criticality factor). SLE
+// and ARO take the most likely loss and frequency so the CRQ view shows a
+// figure at once; the analyst refines them while the risk is a draft.
+func (s *RiskScenario) DraftRisk(asset *Asset, actor uuid.UUID, now time.Time) *Risk {
+	prob := s.AnnualProbability()
+	score := math.Round(prob*s.Impact*asset.Criticality.ScoreFactor()*1000) / 1000
+	crit := CriticalityFromScore(score)
+	title := fmt.Sprintf("%s — %s", s.Title, asset.Name)
+	sle, aro := s.LossMagnitude.Mode, s.LEF.Mode
+
+	tags := pq.StringArray{"scenario", "scenario:" + s.Key}
+	for _, t := range s.Techniques {
+		tags = append(tags, "attack:"+t.ID)
+	}
+	assetID := asset.ID
+	scenarioID := s.ID
+	version := s.Version
+
+	risk := &Risk{
+		ID:                    uuid.New(),
+		TenantID:              asset.TenantID,
+		OrganizationID:        asset.TenantID,
+		Name:                  title,
+		Title:                 title,
+		Description:           s.Description,
+		Probability:           prob,
+		Impact:                s.Impact,
+		Score:                 score,
+		Criticality:           crit,
+		Level:                 strings.ToUpper(string(crit)),
+		CreatedBy:             actor,
+		AssetID:               &assetID,
+		Source:                SourceScenario,
+		Tags:                  tags,
+		TreatmentPlan:         TreatmentMitigate,
+		SLEXAF:                &sle,
+		ARO:                   &aro,
+		SourceScenarioID:      &scenarioID,
+		SourceScenarioVersion: &version,
+		SourceRuleReason:      fmt.Sprintf("Drafted from scenario %q v%d", s.Key, s.Version),
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}
+	risk.SetState(StateDraft)
+	return risk
+}
+
+// ScenarioCatalogControl is a tenant control imported from a regulatory
+// catalog, the unit coverage and instantiation match control references on.
+type ScenarioCatalogControl struct {
+	ControlID     uuid.UUID
+	FrameworkID   uuid.UUID
+	CatalogKey    string
+	ReferenceCode string
+	Name          string
+	Status        ControlStatus
+}
+
+// Ref is the catalog reference the control answers to.
+func (c ScenarioCatalogControl) Ref() ScenarioControlRef {
+	return ScenarioControlRef{Catalog: c.CatalogKey, Ref: c.ReferenceCode}
+}
+
+// RiskScenarioRepository persists a tenant's own scenarios and drafts the
+// risks instantiating one creates. Every method is tenant-scoped.
+type RiskScenarioRepository interface {
+	ListScenarios(ctx context.Context, tenantID uuid.UUID) ([]RiskScenario, error)
+	// GetScenario returns (nil, nil) when the scenario does not exist in the
+	// tenant.
+	GetScenario(ctx context.Context, tenantID, id uuid.UUID) (*RiskScenario, error)
+	SaveScenario(ctx context.Context, s *RiskScenario) error
+	DeleteScenario(ctx context.Context, tenantID, id uuid.UUID) error
+	// KeyTaken reports whether another scenario of the tenant uses the key.
+	KeyTaken(ctx context.Context, tenantID uuid.UUID, key string, except uuid.UUID) (bool, error)
+
+	// ListCatalogControls returns the tenant's controls that came from a
+	// regulatory catalog.
+	ListCatalogControls(ctx context.Context, tenantID uuid.UUID) ([]ScenarioCatalogControl, error)
+	// ListAssets returns the tenant's assets among ids; others are dropped.
+	ListAssets(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]Asset, error)
+	// ExistingScenarioRisks maps asset id to the live risk already drafted
+	// from the scenario on that asset.
+	ExistingScenarioRisks(ctx context.Context, tenantID, scenarioID uuid.UUID, assetIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error)
+	// CreateScenarioRisks creates the risks, links each to its asset and adds
+	// the control mappings, in one transaction.
+	CreateScenarioRisks(ctx context.Context, risks []*Risk, mappings []RiskControlMapping) error
+}
@@ -0,0 +1,314 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ---------------------------------------------------------------------------
+// Built-in scenario library.
+//
+// ScenarioLibraryVersion names the library as a whole; each scenario also
+// carries its own Version, bumped whenever its content changes, which is what
+// a drafted risk records. Technique ids and names are MITRE ATT&CK Enterprise;
+// control references use the catalog keys of pkg/compliance, and the scenario
+// service's tests fail if one of them stops resolving.
+//
+// Frequencies and losses are deliberately wide starting ranges for a mid-sized
+// organisation in the XAF zone, not benchmarks: the point of instantiating a
+// scenario is that the analyst narrows them for their own assets.
+// ---------------------------------------------------------------------------
+
+// ScenarioLibraryVersion identifies the built-in library release.
+const ScenarioLibraryVersion = "2026.10"
+
+// Catalog keys the built-in library references.
+const (
+	catISO27001 = "iso27001-2022"
+	catCISv8    = "cis-v8"
+	catNIST     = "nist-800-53-r5"
+)
+
+// AttackTechnique is an entry of the ATT&CK table below.
+type AttackTechnique struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Tactic string `json:"tactic"`
+}
+
+// attackTechniques covers the techniques the built-in library uses, so custom
+// scenarios that reuse them get names and tactics without retyping. Unknown
+// (valid) ids are still accepted on custom scenarios.
+var attackTechniques = map[string]AttackTechnique{
+	"T1005":     {"T1005", "Data from Local System", "collection"},
+	"T1021":     {"T1021", "Remote Services", "lateral-movement"},
+	"T1048":     {"T1048", "Exfiltration Over Alternative Protocol", "exfiltration"},
+	"T1052":     {"T1052", "Exfiltration Over Physical Medium", "exfiltration"},
+	"T1059":     {"T1059", "Command and Scripting Interpreter", "execution"},
+	"T1068":     {"T1068", "Exploitation for Privilege Escalation", "privilege-escalation"},
+	"T1078":     {"T1078", "Valid Accounts", "initial-access"},
+	"T1110.004": {"T1110.004", "Credential Stuffing", "credential-access"},
+	"T1114.003": {"T1114.003", "Email Forwarding Rule", "collection"},
+	"T1133":     {"T1133", "External Remote Services", "initial-access"},
+	"T1190":     {"T1190", "Exploit Public-Facing Application", "initial-access"},
+	"T1195.002": {"T1195.002", "Compromise Software Supply Chain", "initial-access"},
+	"T1199":     {"T1199", "Trusted Relationship", "initial-access"},
+	"T1213":     {"T1213", "Data from Information Repositories", "collection"},
+	"T1486":     {"T1486", "Data Encrypted for Impact", "impact"},
+	"T1490":     {"T1490", "Inhibit System Recovery", "impact"},
+	"T1498":     {"T1498", "Network Denial of Service", "impact"},
+	"T1499":     {"T1499", "Endpoint Denial of Service", "impact"},
+	"T1530":     {"T1530", "Data from Cloud Storage", "collection"},
+	"T1534":     {"T1534", "Internal Spearphishing", "lateral-movement"},
+	"T1539":     {"T1539", "Steal Web Session Cookie", "credential-access"},
+	"T1552.001": {"T1552.001", "Credentials In Files", "credential-access"},
+	"T1566":     {"T1566", "Phishing", "initial-access"},
+	"T1567":     {"T1567", "Exfiltration Over Web Service", "exfiltration"},
+	"T1619":     {"T1619", "Cloud Storage Object Discovery", "discovery"},
+	"T1656":     {"T1656", "Impersonation", "defense-evasion"},
+	"T1657":     {"T1657", "Financial Theft", "impact"},
+}
+
+// AttackTechniqueByID looks a technique up in the ATT&CK table.
+func AttackTechniqueByID(id string) (AttackTechnique, bool) {
+	t, ok := attackTechniques[id]
+	return t, ok
+}
+
+// techniqueMitigations is the control mapping of each technique: what an
+// ISO 27001 Annex A, CIS v8 or NIST 800-53 programme has that blunts it.
+var techniqueMitigations = map[string][]ScenarioControlRef{
+	"T1005":     refs(iso("A.8.1", "A.8.12"), cis("CIS-3"), nist("AC", "MP")),
+	"T1021":     refs(iso("A.8.2", "A.8.22"), cis("CIS-6", "CIS-12"), nist("AC", "SC")),
+	"T1048":     refs(iso("A.8.12", "A.8.20"), cis("CIS-13"), nist("SC")),
+	"T1052":     refs(iso("A.7.10", "A.8.12"), cis("CIS-3"), nist("MP")),
+	"T1059":     refs(iso("A.8.19"), cis("CIS-2", "CIS-10"), nist("CM", "SI")),
+	"T1068":     refs(iso("A.8.2", "A.8.8"), cis("CIS-7"), nist("RA", "SI")),
+	"T1078":     refs(iso("A.5.17", "A.5.18", "A.8.5"), cis("CIS-5", "CIS-6"), nist("AC", "IA")),
+	"T1110.004": refs(iso("A.8.5", "A.8.16"), cis("CIS-5", "CIS-6", "CIS-13"), nist("AC", "IA")),
+	"T1114.003": refs(iso("A.5.14", "A.8.16"), cis("CIS-8", "CIS-9"), nist("AU", "SI")),
+	"T1133":     refs(iso("A.8.5", "A.8.20"), cis("CIS-6", "CIS-12"), nist("AC", "IA")),
+	"T1190":     refs(iso("A.8.8", "A.8.26", "A.8.29"), cis("CIS-7", "CIS-16"), nist("RA", "SI")),
+	"T1195.002": refs(iso("A.5.21", "A.8.19"), cis("CIS-2", "CIS-15"), nist("SA", "SR")),
+	"T1199":     refs(iso("A.5.19", "A.5.22"), cis("CIS-15"), nist("AC", "SR")),
+	"T1213":     refs(iso("A.5.15", "A.8.3"), cis("CIS-3", "CIS-6"), nist("AC", "AU")),
+	"T1486":     refs(iso("A.8.7", "A.8.13"), cis("CIS-10", "CIS-11"), nist("CP", "SI")),
+	"T1490":     refs(iso("A.5.30", "A.8.13"), cis("CIS-11"), nist("CP")),
+	"T1498":     refs(iso("A.8.6", "A.8.14", "A.8.21"), cis("CIS-12", "CIS-13"), nist("CP", "SC")),
+	"T1499":     refs(iso("A.8.6", "A.8.14"), cis("CIS-13"), nist("SC")),
+	"T1530":     refs(iso("A.5.23", "A.8.3", "A.8.24"), cis("CIS-3", "CIS-6"), nist("AC", "SC")),
+	"T1534":     refs(iso("A.6.3"), cis("CIS-9", "CIS-14"), nist("AT")),
+	"T1539":     refs(iso("A.8.5", "A.8.26"), cis("CIS-16"), nist("IA", "SC")),
+	"T1552.001": refs(iso("A.5.17", "A.8.28"), cis("CIS-3", "CIS-16"), nist("IA", "SA")),
+	"T1566":     refs(iso("A.6.3", "A.8.23"), cis("CIS-9", "CIS-14"), nist("AT", "SI")),
+	"T1567":     refs(iso("A.8.12", "A.8.23"), cis("CIS-3", "CIS-13"), nist("SC", "SI")),
+	"T1619":     refs(iso("A.5.23", "A.8.9"), cis("CIS-3", "CIS-4"), nist("AC", "CM")),
+	"T1656":     refs(iso("A.6.3"), cis("CIS-14"), nist("AT")),
+	"T1657":     refs(iso("A.5.3", "A.5.37"), cis("CIS-14"), nist("AC")),
+}
+
+func iso(codes ...string) []ScenarioControlRef  { return catalogRefs(catISO27001, codes) }
+func cis(codes ...string) []ScenarioControlRef  { return catalogRefs(catCISv8, codes) }
+func nist(codes ...string) []ScenarioControlRef { return catalogRefs(catNIST, codes) }
+
+func catalogRefs(catalog string, codes []string) []ScenarioControlRef {
+	out := make([]ScenarioControlRef, 0, len(codes))
+	for _, c := range codes {
+		out = append(out, ScenarioControlRef{Catalog: catalog, Ref: c})
+	}
+	return out
+}
+
+func refs(groups ...[]ScenarioControlRef) []ScenarioControlRef {
+	var out []ScenarioControlRef
+	for _, g := range groups {
+		out = append(out, g...)
+	}
+	return out
+}
+
+// techniques builds a scenario's technique list from the tables above.
+func techniques(ids ...string) ScenarioTechniques {
+	out := make(ScenarioTechniques, 0, len(ids))
+	for _, id := range ids {
+		t := attackTechniques[id]
+		out = append(out, ScenarioTechnique{
+			ID: t.ID, Name: t.Name, Tactic: t.Tactic,
+			Mitigations: append([]ScenarioControlRef(nil), techniqueMitigations[id]...),
+		})
+	}
+	return out
+}
+
+func categories(cats ...AssetCategory) pq.StringArray {
+	out := make(pq.StringArray, 0, len(cats))
+	for _, c := range cats {
+		out = append(out, string(c))
+	}
+	return out
+}
+
+// BuiltInScenarioID is the stable id of a built-in scenario.
+func BuiltInScenarioID(key string) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("openrisk:risk-scenario:"+key))
+}
+
+// incidentResponse is suggested by every built-in scenario: whatever the
+// technique, the loss depends on how fast the organisation responds.
+func incidentResponse() []ScenarioControlRef {
+	return refs(iso("A.5.24", "A.5.26"), cis("CIS-17"), nist("IR"))
+}
+
+// BuiltInScenarios returns fresh copies of the built-in library, ordered by
+// key.
+func BuiltInScenarios() []RiskScenario {
+	const million = 1_000_000
+	out := []RiskScenario{
+		{
+			Key:         "ransomware-erp",
+			Version:     1,
+			Title:       "Ransomware on the ERP",
+			Description: "A ransomware affiliate gets in through phishing or an exposed remote access service, moves to the ERP servers, steals data for extortion and encrypts the production and backup systems. Finance, procurement and payroll stop until restore.",
+			ThreatActors: ThreatActors{
+				{Name: "Ransomware-as-a-service affiliate", Kind: ThreatActorCriminal, Motivation: "Extortion"},
+			},
+			Techniques:        techniques("T1566", "T1133", "T1078", "T1021", "T1567", "T1490", "T1486"),
+			AssetCategories:   categories(CategoryApplication, CategoryServer, CategoryDatabase),
+			LEF:               FAIRRange{Min: 0.05, Mode: 0.2, Max: 0.6},
+			LossMagnitude:     FAIRRange{Min: 50 * million, Mode: 250 * million, Max: 1500 * million},
+			Impact:            9,
+			SuggestedControls: refs(incidentResponse(), iso("A.5.29"), cis("CIS-11"), nist("CP")),
+		},
+		{
+			Key:         "bec-fraud",
+			Version:     1,
+			Title:       "Business email compromise payment fraud",
+			Description: "A fraudster takes over or impersonates a mailbox in the finance chain — an executive, a buyer, a supplier — and has a payment sent to an account they control, often hiding the replies with a forwarding rule.",
+			ThreatActors: ThreatActors{
+				{Name: "BEC fraud ring", Kind: ThreatActorCriminal, Motivation: "Financial gain"},
+			},
+			Techniques:        techniques("T1566", "T1078", "T1114.003", "T1534", "T1656", "T1657"),
+			AssetCategories:   categories(CategoryApplication, CategoryCloud, CategoryProcess),
+			LEF:               FAIRRange{Min: 0.2, Mode: 1, Max: 3},
+			LossMagnitude:     FAIRRange{Min: 5 * million, Mode: 30 * million, Max: 200 * million},
+			Impact:            7,
+			SuggestedControls: refs(incidentResponse(), iso("A.5.3"), nist("AC")),
+		},
+		{
+			Key:         "cloud-data-exposure",
+			Version:     1,
+			Title:       "Cloud data exposure",
+			Description: "A storage bucket, snapshot or database is left reachable from the internet, or its keys leak in a repository, and an opportunistic scanner copies the data. The loss is notification, regulatory fines and customer trust.",
+			ThreatActors: ThreatActors{
+				{Name: "Opportunistic scanner", Kind: ThreatActorCriminal, Motivation: "Data resale"},
+			},
+			Techniques:        techniques("T1552.001", "T1078", "T1619", "T1530"),
+			AssetCategories:   categories(CategoryCloud, CategoryDatabase, CategoryData),
+			LEF:               FAIRRange{Min: 0.05, Mode: 0.3, Max: 1},
+			LossMagnitude:     FAIRRange{Min: 20 * million, Mode: 150 * million, Max: 1000 * million},
+			Impact:            8,
+			SuggestedControls: refs(incidentResponse(), iso("A.5.34"), nist("PT")),
+		},
+		{
+			Key:         "credential-stuffing-portal",
+			Version:     1,
+			Title:       "Credential stuffing on a customer portal",
+			Description: "Attackers replay leaked username and password pairs against a customer-facing login, take over accounts and use them for fraud or to harvest personal data.",
+			ThreatActors: ThreatActors{
+				{Name: "Account takeover crew", Kind: ThreatActorCriminal, Motivation: "Fraud"},
+			},
+			Techniques:        techniques("T1110.004", "T1078", "T1539", "T1657"),
+			AssetCategories:   categories(CategoryApplication, CategoryCloud),
+			LEF:               FAIRRange{Min: 0.5, Mode: 2, Max: 6},
+			LossMagnitude:     FAIRRange{Min: 2 * million, Mode: 15 * million, Max: 100 * million},
+			Impact:            6,
+			SuggestedControls: incidentResponse(),
+		},
+		{
+			Key:         "insider-exfiltration",
+			Version:     1,
+			Title:       "Insider data exfiltration",
+			Description: "An employee or contractor, often on their way out, copies customer files, pricing or designs to personal storage or removable media.",
+			ThreatActors: ThreatActors{
+				{Name: "Departing employee", Kind: ThreatActorInsider, Motivation: "Personal gain"},
+				{Name: "Contractor with standing access", Kind: ThreatActorPartner, Motivation: "Personal gain"},
+			},
+			Techniques:        techniques("T1213", "T1005", "T1567", "T1052", "T1048"),
+			AssetCategories:   categories(CategoryDatabase, CategoryData, CategoryWorkstation, CategoryApplication),
+			LEF:               FAIRRange{Min: 0.1, Mode: 0.5, Max: 2},
+			LossMagnitude:     FAIRRange{Min: 10 * million, Mode: 80 * million, Max: 500 * million},
+			Impact:            7,
+			SuggestedControls: refs(incidentResponse(), iso("A.6.5", "A.8.12"), nist("PS")),
+		},
+		{
+			Key:         "supply-chain-update",
+			Version:     1,
+			Title:       "Compromised software update",
+			Description: "A supplier's build or update channel is compromised and a trojanised update is installed across the estate, giving the attacker a foothold through a trusted relationship.",
+			ThreatActors: ThreatActors{
+				{Name: "State-sponsored intrusion set", Kind: ThreatActorNationState, Motivation: "Espionage"},
+			},
+			Techniques:        techniques("T1195.002", "T1199", "T1059", "T1078"),
+			AssetCategories:   categories(CategoryServer, CategoryWorkstation, CategoryApplication, CategoryVendor),
+			LEF:               FAIRRange{Min: 0.01, Mode: 0.05, Max: 0.2},
+			LossMagnitude:     FAIRRange{Min: 100 * million, Mode: 500 * million, Max: 3000 * million},
+			Impact:            9,
+			SuggestedControls: refs(incidentResponse(), cis("CIS-15"), nist("SR")),
+		},
+		{
+			Key:         "ddos-online-services",
+			Version:     1,
+			Title:       "DDoS on online services",
+			Description: "A volumetric or application-layer flood takes customer-facing services offline, as protest or to back a ransom demand.",
+			ThreatActors: ThreatActors{
+				{Name: "Hacktivist collective", Kind: ThreatActorHacktivist, Motivation: "Ideology"},
+				{Name: "DDoS extortion group", Kind: ThreatActorCriminal, Motivation: "Extortion"},
+			},
+			Techniques:        techniques("T1498", "T1499"),
+			AssetCategories:   categories(CategoryNetwork, CategoryApplication, CategoryCloud),
+			LEF:               FAIRRange{Min: 0.2, Mode: 1, Max: 4},
+			LossMagnitude:     FAIRRange{Min: 2 * million, Mode: 20 * million, Max: 150 * million},
+			Impact:            6,
+			SuggestedControls: refs(incidentResponse(), iso("A.5.30")),
+		},
+		{
+			Key:         "web-app-exploitation",
+			Version:     1,
+			Title:       "Exploitation of an internet-facing application",
+			Description: "An attacker exploits a known or zero-day vulnerability in an exposed application, escalates privileges on the host and extracts the data behind it.",
+			ThreatActors: ThreatActors{
+				{Name: "Initial access broker", Kind: ThreatActorCriminal, Motivation: "Access resale"},
+			},
+			Techniques:        techniques("T1190", "T1059", "T1068", "T1005", "T1048"),
+			AssetCategories:   categories(CategoryApplication, CategoryServer),
+			LEF:               FAIRRange{Min: 0.1, Mode: 0.5, Max: 2},
+			LossMagnitude:     FAIRRange{Min: 20 * million, Mode: 120 * million, Max: 800 * million},
+			Impact:            8,
+			SuggestedControls: refs(incidentResponse(), cis("CIS-18"), nist("CA")),
+		},
+	}
+	for i := range out {
+		out[i].ID = BuiltInScenarioID(out[i].Key)
+		out[i].BuiltIn = true
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+// BuiltInScenario returns the built-in scenario with the given id.
+func BuiltInScenario(id uuid.UUID) (*RiskScenario, bool) {
+	for _, s := range BuiltInScenarios() {
+		if s.ID == id {
+			s := s
+			return &s, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestBuiltInScenarios_ValidAndStable(t *testing.T) {
+	seen := map[string]bool{}
+	for _, s := range BuiltInScenarios() {
+		s := s
+		if err := s.Validate(); err != nil {
+			t.Errorf("%s: %v", s.Key, err)
+		}
+		if seen[s.Key] {
+			t.Errorf("duplicate key %s", s.Key)
+		}
+		seen[s.Key] = true
+		if s.ID != BuiltInScenarioID(s.Key) || !s.BuiltIn || s.Version < 1 {
+			t.Errorf("%s: id/built_in/version not set", s.Key)
+		}
+		for _, tech := range s.Techniques {
+			if tech.Name == "" || len(tech.Mitigations) == 0 {
+				t.Errorf("%s: technique %s has no name or mitigation", s.Key, tech.ID)
+			}
+		}
+	}
+	for _, key := range []string{"ransomware-erp", "bec-fraud", "cloud-data-exposure"} {
+		if !seen[key] {
+			t.Errorf("library is missing %s", key)
+		}
+	}
+	if got, ok := BuiltInScenario(BuiltInScenarioID("bec-fraud")); !ok || got.Key != "bec-fraud" {
+		t.Errorf("BuiltInScenario lookup: %v %v", got, ok)
+	}
+}
+
+func TestRiskScenario_Validate(t *testing.T) {
+	valid := func() RiskScenario {
+		return RiskScenario{
+			Key: "Payroll-Fraud", Title: "Payroll fraud",
+			Techniques:      ScenarioTechniques{{ID: "t1078"}},
+			AssetCategories: []string{"Application"},
+			LEF:             FAIRRange{Min: 0.1, Mode: 0.5, Max: 1},
+			LossMagnitude:   FAIRRange{Min: 1, Mode: 2, Max: 3},
+			Impact:          5,
+		}
+	}
+	s := valid()
+	if err := s.Validate(); err != nil {
+		t.Fatalf("valid scenario rejected: %v", err)
+	}
+	if s.Key != "payroll-fraud" || s.Techniques[0].ID != "T1078" || s.Techniques[0].Name != "Valid Accounts" || s.AssetCategories[0] != "application" {
+		t.Errorf("not normalised: %+v", s)
+	}
+
+	cases := map[string]func(*RiskScenario){
+		"bad technique":  func(s *RiskScenario) { s.Techniques[0].ID = "1078" },
+		"no technique":   func(s *RiskScenario) { s.Techniques = nil },
+		"dup technique":  func(s *RiskScenario) { s.Techniques = append(s.Techniques, ScenarioTechnique{ID: "T1078"}) },
+		"bad category":   func(s *RiskScenario) { s.AssetCategories = []string{"toaster"} },
+		"unordered lef":  func(s *RiskScenario) { s.LEF = FAIRRange{Min: 2, Mode: 1, Max: 3} },
+		"zero loss":      func(s *RiskScenario) { s.LossMagnitude = FAIRRange{} },
+		"impact":         func(s *RiskScenario) { s.Impact = 11 },
+		"bad key":        func(s *RiskScenario) { s.Key = "x" },
+		"bad actor kind": func(s *RiskScenario) { s.ThreatActors = ThreatActors{{Name: "X", Kind: "alien"}} },
+		"empty ref":      func(s *RiskScenario) { s.SuggestedControls = ScenarioControlRefs{{Catalog: "cis-v8"}} },
+	}
+	for name, mutate := range cases {
+		s := valid()
+		mutate(&s)
+		if err := s.Validate(); !errors.Is(err, ErrValidation) {
+			t.Errorf("%s: want validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestRiskScenario_AppliesTo(t *testing.T) {
+	s := RiskScenario{AssetCategories: []string{"cloud", "database"}}
+	if !s.AppliesTo(CategoryCloud) || s.AppliesTo(CategoryWorkstation) {
+		t.Error("category filter wrong")
+	}
+	if !s.AppliesTo("") {
+		t.Error("an uncategorised asset must be accepted")
+	}
+	if !(&RiskScenario{}).AppliesTo(CategoryNetwork) {
+		t.Error("a scenario without categories applies to any asset")
+	}
+}
+
+func TestRiskScenario_DraftRisk(t *testing.T) {
+	s, _ := BuiltInScenario(BuiltInScenarioID("ransomware-erp"))
+	tenant := uuid.New()
+	asset := &Asset{ID: uuid.New(), TenantID: tenant, Name: "SAP ECC", Criticality: CriticalityCritical}
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	r := s.DraftRisk(asset, uuid.Nil, now)
+	if r.State() != StateDraft || r.Source != SourceScenario {
+		t.Errorf("state %s source %s, want draft/scenario", r.State(), r.Source)
+	}
+	if r.Title != "Ransomware on the ERP — SAP ECC" || r.TenantID != tenant || *r.AssetID != asset.ID {
+		t.Errorf("wrong title/tenant/asset: %q", r.Title)
+	}
+	// LEF mode 0.2 → P(≥1 event) = 1 − e^−0.2 ≈ 0.18; score 0.18 × 9 × 3.0.
+	if r.Probability != 0.18 || r.Score != 4.86 || r.Criticality != CriticalityHighNew {
+		t.Errorf("probability %v score %v criticality %s", r.Probability, r.Score, r.Criticality)
+	}
+	if *r.ARO != 0.2 || *r.SLEXAF != 250_000_000 {
+		t.Errorf("ARO %v SLE %v", *r.ARO, *r.SLEXAF)
+	}
+	if *r.SourceScenarioID != s.ID || *r.SourceScenarioVersion != s.Version {
+		t.Error("scenario provenance missing")
+	}
+	want := map[string]bool{"scenario:ransomware-erp": false, "attack:T1486": false}
+	for _, tag := range r.Tags {
+		if _, ok := want[tag]; ok {
+			want[tag] = true
+		}
+	}
+	for tag, found := range want {
+		if !found {
+			t.Errorf("missing tag %s in %v", tag, r.Tags)
+		}
+	}
+}
+
+func TestRiskScenario_AnnualProbabilityClamped(t *testing.T) {
+	if p := (&RiskScenario{LEF: FAIRRange{Mode: 50}}).AnnualProbability(); p != 0.99 {
+		t.Errorf("high frequency: %v", p)
+	}
+	if p := (&RiskScenario{LEF: FAIRRange{Mode: 0.001}}).AnnualProbability(); p != 0.01 {
+		t.Errorf("low frequency: %v", p)
+	}
+}
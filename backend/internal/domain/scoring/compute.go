@@ -45,10 +45,11 @@ var (
 		FactorAssetCriticality: 0.20,
 	}
 	assetWeights = map[FactorKey]float64{
-		FactorCriticality:           0.35,
-		FactorLinkedRiskExposure:    0.35,
+		FactorCriticality:           0.30,
+		FactorLinkedRiskExposure:    0.30,
 		FactorVulnerabilityPressure: 0.20,
 		FactorInternetExposure:      0.10,
+		FactorIncidentPressure:      0.10,
 	}
 )
 
@@ -152,6 +153,13 @@ type AssetInput struct {
 	InternetFacing  bool
 	HasExposureData bool
 
+	// OpenIncidents / CriticalOpenIncidents count the unresolved incidents
+	// whose impact lists this asset. An asset that is being hit right now is
+	// more exposed than its configuration alone says.
+	OpenIncidents         int
+	CriticalOpenIncidents int
+	HasIncidentData       bool
+
 	MitigationEffectiveness float64
 }
 
@@ -182,6 +190,12 @@ func ComputeAsset(in AssetInput, at time.Time) Result {
 			raw:       boolRaw(in.InternetFacing),
 			available: in.HasExposureData,
 		},
+		{
+			key:       FactorIncidentPressure,
+			weight:    assetWeights[FactorIncidentPressure],
+			raw:       incidentPressure(in.OpenIncidents, in.CriticalOpenIncidents),
+			available: in.HasIncidentData,
+		},
 	}
 
 	inherent, breakdown := combine(factors)
@@ -192,6 +206,7 @@ func ComputeAsset(in AssetInput, at time.Time) Result {
 		"max_cvss":             in.MaxCVSS,
 		"internet_facing":      in.InternetFacing,
 		"linked_risks":         in.HasLinkedRisks,
+		"open_incidents":       in.OpenIncidents,
 	}
 
 	return finish(ScopeAsset, inherent, in.MitigationEffectiveness, at, inputs, breakdown)
@@ -70,6 +70,7 @@ func Describe() ModelDescription {
 			}),
 			describeScope(ScopeAsset, assetWeights, []FactorKey{
 				FactorCriticality, FactorLinkedRiskExposure, FactorVulnerabilityPressure, FactorInternetExposure,
+				FactorIncidentPressure,
 			}),
 		},
 		InputBounds: map[string][]float64{
@@ -0,0 +1,168 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// SIEM export — streaming the audit trail to a security operations platform
+// =============================================================================
+
+// SIEMTransport is how records reach the SIEM.
+type SIEMTransport string
+
+const (
+	// SIEMTransportSyslog is RFC 5424 syslog over TCP with TLS (RFC 5425),
+	// octet-counted. Splunk, Elastic (Filebeat/Logstash), Sentinel (AMA) and
+	// QRadar all listen for it.
+	SIEMTransportSyslog SIEMTransport = "syslog"
+	// SIEMTransportHEC is an HTTP Event Collector endpoint (Splunk HEC, and
+	// the collectors that speak its protocol).
+	SIEMTransportHEC SIEMTransport = "hec"
+)
+
+// IsValid reports whether t is a known transport.
+func (t SIEMTransport) IsValid() bool {
+	return t == SIEMTransportSyslog || t == SIEMTransportHEC
+}
+
+// SIEMFormat is how each record is encoded.
+type SIEMFormat string
+
+const (
+	// SIEMFormatCEF is ArcSight Common Event Format, one line per record.
+	SIEMFormatCEF SIEMFormat = "cef"
+	// SIEMFormatOCSF is an Open Cybersecurity Schema Framework 1.1 event, as
+	// JSON: API Activity for the audit chain, Authentication for the auth log.
+	SIEMFormatOCSF SIEMFormat = "ocsf"
+)
+
+// IsValid reports whether f is a known format.
+func (f SIEMFormat) IsValid() bool {
+	return f == SIEMFormatCEF || f == SIEMFormatOCSF
+}
+
+// SIEMSource is a log a destination receives.
+type SIEMSource string
+
+const (
+	// SIEMSourceAudit is the hash-chained audit trail (AuditEvent).
+	SIEMSourceAudit SIEMSource = "audit"
+	// SIEMSourceAuth is the authentication log (AuthAuditLog): logins,
+	// refreshes, MFA, tokens, sessions.
+	SIEMSourceAuth SIEMSource = "auth"
+)
+
+// SIEMSources lists the sources in the order they are shipped.
+var SIEMSources = []SIEMSource{SIEMSourceAudit, SIEMSourceAuth}
+
+// IsValid reports whether s is a known source.
+func (s SIEMSource) IsValid() bool {
+	return s == SIEMSourceAudit || s == SIEMSourceAuth
+}
+
+// SIEMBatchSize is how many records of one source go in one send.
+const SIEMBatchSize = 500
+
+// SIEMDestination is one tenant's SIEM endpoint, with the cursors recording
+// how far each source has been shipped.
+//
+// Delivery is at-least-once: a cursor moves only after the transport has
+// accepted the batch, so a crash between sending and recording re-sends that
+// batch. Records carry stable ids (the audit event id and chain sequence, the
+// auth log id) for the SIEM to deduplicate on.
+type SIEMDestination struct {
+	ID        uuid.UUID     `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID  uuid.UUID     `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Name      string        `gorm:"size:120;not null" json:"name"`
+	Transport SIEMTransport `gorm:"type:varchar(16);not null" json:"transport"`
+	Format    SIEMFormat    `gorm:"type:varchar(16);not null" json:"format"`
+	// Endpoint is host:port for syslog and the collector URL for HEC.
+	Endpoint string     `gorm:"type:text;not null" json:"endpoint"`
+	Sources  StringList `gorm:"type:jsonb" json:"sources"`
+	Enabled  bool       `gorm:"not null;default:true" json:"enabled"`
+	// CACertPEM pins the CA the SIEM's certificate must chain to, for
+	// collectors behind a private CA. Empty uses the system roots.
+	CACertPEM string `gorm:"type:text;not null;default:''" json:"ca_cert_pem,omitempty"`
+	// EncryptedToken is the HEC token. Never returned.
+	EncryptedToken string `gorm:"type:text;not null;default:''" json:"-"`
+
+	// AuditCursor is the last audit chain sequence shipped.
+	AuditCursor int64 `gorm:"not null;default:0" json:"audit_cursor"`
+	// AuthCursorAt and AuthCursorID are the last auth log entry shipped, in
+	// (created_at, id) order — the auth log has no sequence of its own.
+	AuthCursorAt *time.Time `json:"auth_cursor_at,omitempty"`
+	AuthCursorID *uuid.UUID `gorm:"type:uuid" json:"auth_cursor_id,omitempty"`
+
+	// Shipping status. ConsecutiveFailures drives the retry backoff and
+	// resets on the first batch accepted.
+	LastShippedAt       *time.Time `json:"last_shipped_at,omitempty"`
+	LastError           string     `gorm:"type:text;not null;default:''" json:"last_error,omitempty"`
+	LastErrorAt         *time.Time `json:"last_error_at,omitempty"`
+	ConsecutiveFailures int        `gorm:"not null;default:0" json:"consecutive_failures"`
+	NextAttemptAt       time.Time  `gorm:"not null;index" json:"next_attempt_at"`
+
+	// LeaseToken and LeaseUntil are the export worker's claim. A checkpoint
+	// only lands while the token still matches, so a worker that outlived its
+	// lease — or whose cursor was rewound under it — cannot move the cursor.
+	LeaseToken *uuid.UUID `gorm:"type:uuid" json:"-"`
+	LeaseUntil *time.Time `json:"-"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (SIEMDestination) TableName() string { return "siem_destinations" }
+
+// Ships reports whether the destination receives source.
+func (d *SIEMDestination) Ships(source SIEMSource) bool {
+	for _, s := range d.Sources {
+		if SIEMSource(s) == source {
+			return true
+		}
+	}
+	return false
+}
+
+// SIEMRepository persists destinations and reads the logs they ship.
+// Administrative methods are tenant-scoped; the export worker's act on rows
+// that carry their own tenant.
+type SIEMRepository interface {
+	ListDestinations(ctx context.Context, tenantID uuid.UUID) ([]SIEMDestination, error)
+	// GetDestination returns nil, nil when the tenant has no such destination.
+	GetDestination(ctx context.Context, tenantID, id uuid.UUID) (*SIEMDestination, error)
+	CreateDestination(ctx context.Context, d *SIEMDestination) error
+	// UpdateDestination writes the configuration columns and NextAttemptAt
+	// only; cursors and status belong to the worker and to Rewind.
+	UpdateDestination(ctx context.Context, d *SIEMDestination) error
+	DeleteDestination(ctx context.Context, tenantID, id uuid.UUID) error
+	// Rewind moves the cursors back (backfill), voids any worker's lease and
+	// makes the destination due at now.
+	Rewind(ctx context.Context, tenantID, id uuid.UUID, auditCursor int64, authSince *time.Time, now time.Time) error
+
+	// AuditHead is the tenant's latest audit chain sequence, 0 when empty.
+	AuditHead(ctx context.Context, tenantID uuid.UUID) (int64, error)
+	// AuditEventsAfter returns the tenant's events with sequence > after, in
+	// sequence order.
+	AuditEventsAfter(ctx context.Context, tenantID uuid.UUID, after int64, limit int) ([]AuditEvent, error)
+	// AuthLogsAfter returns the tenant's auth log entries after the
+	// (at, id) cursor and created before until, in that order.
+	AuthLogsAfter(ctx context.Context, tenantID uuid.UUID, at *time.Time, id *uuid.UUID, until time.Time, limit int) ([]AuthAuditLog, error)
+
+	// ClaimDestination leases one enabled destination that is due, setting
+	// LeaseToken and LeaseUntil. Nil when none is.
+	ClaimDestination(ctx context.Context, now time.Time, lease time.Duration) (*SIEMDestination, error)
+	// Checkpoint writes the cursors and status (and the lease fields, so
+	// clearing them releases the claim), if d still holds its lease. False
+	// when the lease was lost.
+	Checkpoint(ctx context.Context, d *SIEMDestination) (bool, error)
+}
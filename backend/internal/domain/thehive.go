@@ -0,0 +1,177 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TheHiveConnection is a tenant's link to its TheHive 5 instance. One per
+// tenant: a SOC runs one case-management system, and two connections would mean
+// two places an incident could be mirrored to with no way to say which is right.
+//
+// Sync is bi-directional. OpenRisk incidents are pushed as cases (PushIncidents),
+// TheHive cases are imported as incidents (ImportCases), and once a pair is
+// linked its status, severity and tasks travel both ways. TheHive's webhooks
+// drive the pull side; PollMinutes is the fallback for instances whose webhook
+// cannot reach us (and for the webhooks that get lost anyway).
+type TheHiveConnection struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:uq_thehive_connections_tenant" json:"tenant_id"`
+
+	Name    string `gorm:"size:128" json:"name"`
+	Enabled bool   `gorm:"not null" json:"enabled"`
+
+	// BaseURL is the instance root (https://thehive.example.org), without /api.
+	// Organisation is sent as X-Organisation so a multi-org API key acts in the
+	// right organisation rather than its default one.
+	BaseURL      string `gorm:"size:512;not null" json:"base_url"`
+	Organisation string `gorm:"size:128" json:"organisation,omitempty"`
+	// EncryptedAPIKey is the AES-256-GCM ciphertext of the API key; it is never
+	// returned by the API.
+	EncryptedAPIKey string `gorm:"type:text" json:"-"`
+
+	PushIncidents bool `gorm:"not null" json:"push_incidents"`
+	ImportCases   bool `gorm:"not null" json:"import_cases"`
+	// PollMinutes is the polling cadence; 0 means webhook-only.
+	PollMinutes int `gorm:"not null" json:"poll_minutes"`
+
+	// WebhookToken authenticates TheHive's notifier calls to
+	// /api/v1/integrations/thehive/webhook. Unique so the token alone identifies
+	// the tenant.
+	WebhookToken string `gorm:"size:80;uniqueIndex:uq_thehive_connections_webhook" json:"webhook_token,omitempty"`
+
+	// Cursor is the highest remote _updatedAt already pulled; PushedUntil the
+	// highest local incident updated_at already pushed. Both are high-water
+	// marks, so a missed tick is caught up on the next one.
+	Cursor      *time.Time `json:"cursor,omitempty"`
+	PushedUntil *time.Time `json:"pushed_until,omitempty"`
+
+	LastSyncAt     *time.Time `json:"last_sync_at,omitempty"`
+	LastSyncStatus string     `gorm:"size:16;not null;default:'never'" json:"last_sync_status"` // never|ok|error
+	LastSyncError  string     `gorm:"type:text" json:"last_sync_error,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Computed, NOT persisted.
+	HasAPIKey bool `gorm:"-" json:"has_api_key"`
+}
+
+// TableName pins the table name.
+func (TheHiveConnection) TableName() string { return "thehive_connections" }
+
+// PollDue reports whether the fallback poll should run at now.
+func (c *TheHiveConnection) PollDue(now time.Time) bool {
+	if !c.Enabled || c.PollMinutes <= 0 {
+		return false
+	}
+	return c.LastSyncAt == nil || !now.Before(c.LastSyncAt.Add(time.Duration(c.PollMinutes)*time.Minute))
+}
+
+// Sync directions, recorded on each link so the UI can say which side won the
+// last exchange.
+const (
+	TheHiveDirectionPush = "push"
+	TheHiveDirectionPull = "pull"
+)
+
+// TheHiveCaseLink pairs one incident with one TheHive case.
+//
+// The two fingerprints are what stop the sync from echoing: each is a hash of
+// the shared fields (title, status, severity) as they stood on that side after
+// the last exchange. A side whose current hash still matches has not changed
+// since, so only the side that moved is propagated — and our own push, coming
+// back as a webhook, hashes to what we just recorded and is ignored.
+type TheHiveCaseLink struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:uq_thehive_case_links_incident,priority:1;uniqueIndex:uq_thehive_case_links_case,priority:1" json:"tenant_id"`
+	ConnectionID uuid.UUID `gorm:"type:uuid;not null;index" json:"connection_id"`
+	IncidentID   uint      `gorm:"not null;uniqueIndex:uq_thehive_case_links_incident,priority:2" json:"incident_id"`
+	CaseID       string    `gorm:"size:64;not null;uniqueIndex:uq_thehive_case_links_case,priority:2" json:"case_id"`
+	CaseNumber   int       `json:"case_number,omitempty"`
+
+	LocalFingerprint  string     `gorm:"size:64" json:"-"`
+	RemoteFingerprint string     `gorm:"size:64" json:"-"`
+	RemoteUpdatedAt   *time.Time `json:"remote_updated_at,omitempty"`
+
+	LastSyncedAt  time.Time `json:"last_synced_at"`
+	LastDirection string    `gorm:"size:8" json:"last_direction"`
+	// LastError is the most recent refusal, e.g. TheHive closing a CRITICAL
+	// incident whose post-mortem is not published yet. Cleared on success.
+	LastError string `gorm:"type:text" json:"last_error,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName pins the table name.
+func (TheHiveCaseLink) TableName() string { return "thehive_case_links" }
+
+// TheHiveTaskLink pairs an incident action with a task of the linked case. Same
+// two-fingerprint scheme as the case link, over title and status.
+type TheHiveTaskLink struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:uq_thehive_task_links_action,priority:1;uniqueIndex:uq_thehive_task_links_task,priority:1" json:"tenant_id"`
+	CaseLinkID uuid.UUID `gorm:"type:uuid;not null;index" json:"case_link_id"`
+	ActionID   uint      `gorm:"not null;uniqueIndex:uq_thehive_task_links_action,priority:2" json:"action_id"`
+	TaskID     string    `gorm:"size:64;not null;uniqueIndex:uq_thehive_task_links_task,priority:2" json:"task_id"`
+
+	LocalFingerprint  string `gorm:"size:64" json:"-"`
+	RemoteFingerprint string `gorm:"size:64" json:"-"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName pins the table name.
+func (TheHiveTaskLink) TableName() string { return "thehive_task_links" }
+
+// TheHiveObservable is one observable of a linked case and the asset it was
+// attributed to. AssetID is nil when nothing in the inventory matched with
+// enough confidence, and always nil for data types that do not name a machine
+// (hashes, URLs, mail addresses): those are kept so the analyst can see what
+// the case carries, not guessed onto an asset.
+type TheHiveObservable struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID     uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:uq_thehive_observables_remote,priority:1" json:"tenant_id"`
+	CaseLinkID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"case_link_id"`
+	ObservableID string     `gorm:"size:64;not null;uniqueIndex:uq_thehive_observables_remote,priority:2" json:"observable_id"`
+	DataType     string     `gorm:"size:32;not null" json:"data_type"`
+	Data         string     `gorm:"type:text" json:"data"`
+	AssetID      *uuid.UUID `gorm:"type:uuid;index" json:"asset_id,omitempty"`
+	Confidence   float64    `json:"confidence"`
+	MatchReason  string     `gorm:"size:255" json:"match_reason,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TableName pins the table name.
+func (TheHiveObservable) TableName() string { return "thehive_observables" }
+
+// TheHiveRepository persists connections, links and observables. Every method
+// is tenant-scoped except GetConnectionByWebhookToken (the token IS the tenant
+// identity) and ListEnabledConnections (the worker's cross-tenant sweep).
+type TheHiveRepository interface {
+	GetConnection(ctx context.Context, tenantID uuid.UUID) (*TheHiveConnection, error)
+	GetConnectionByWebhookToken(ctx context.Context, token string) (*TheHiveConnection, error)
+	ListEnabledConnections(ctx context.Context) ([]TheHiveConnection, error)
+	SaveConnection(ctx context.Context, c *TheHiveConnection) error
+	DeleteConnection(ctx context.Context, tenantID uuid.UUID) error
+
+	GetCaseLinkByIncident(ctx context.Context, tenantID uuid.UUID, incidentID uint) (*TheHiveCaseLink, error)
+	GetCaseLinkByCase(ctx context.Context, tenantID uuid.UUID, caseID string) (*TheHiveCaseLink, error)
+	SaveCaseLink(ctx context.Context, l *TheHiveCaseLink) error
+
+	ListTaskLinks(ctx context.Context, tenantID, caseLinkID uuid.UUID) ([]TheHiveTaskLink, error)
+	SaveTaskLink(ctx context.Context, l *TheHiveTaskLink) error
+
+	ListObservables(ctx context.Context, tenantID, caseLinkID uuid.UUID) ([]TheHiveObservable, error)
+	SaveObservable(ctx context.Context, o *TheHiveObservable) error
+}
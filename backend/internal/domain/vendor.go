@@ -0,0 +1,720 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---------------------------------------------------------------------------
+// Third-party (vendor) risk.
+//
+// Until now a vendor was only an AssetCategory and a RiskSource value. The
+// vendor register gives procurement a first-class record per supplier:
+//
+//   - a tier, derived from what data the vendor touches and how critical the
+//     service is — never typed in, so two reviewers cannot tier the same
+//     supplier differently;
+//   - questionnaires (built-in SIG-lite and CAIQ-lite sets, or a custom one)
+//     whose questions carry weights, so the score is a number the programme
+//     can threshold rather than a pile of answers;
+//   - an assessment the vendor answers through an expiring link, without an
+//     OpenRisk account. The link is a bearer credential and is treated like an
+//     invitation token: random, hashed at rest, shown once;
+//   - a reassessment date set by the tier, so tier-1 suppliers come back every
+//     year and tier-3 ones every three.
+//
+// Answers and attached documents become evidence, and a score under the
+// questionnaire's pass mark proposes a vendor risk as a DRAFT through the same
+// review flow as scanner-proposed risks.
+// ---------------------------------------------------------------------------
+
+// VendorDataAccess is the most sensitive class of data the vendor processes or
+// can reach.
+type VendorDataAccess string
+
+const (
+	VendorDataNone         VendorDataAccess = "none"
+	VendorDataInternal     VendorDataAccess = "internal"
+	VendorDataConfidential VendorDataAccess = "confidential"
+	VendorDataPersonal     VendorDataAccess = "personal"
+	// VendorDataRegulated is special-category personal data, payment card or
+	// health data: the classes a breach notification regime is written about.
+	VendorDataRegulated VendorDataAccess = "regulated"
+)
+
+func (a VendorDataAccess) rank() int {
+	switch a {
+	case VendorDataInternal:
+		return 1
+	case VendorDataConfidential:
+		return 2
+	case VendorDataPersonal:
+		return 3
+	case VendorDataRegulated:
+		return 4
+	}
+	return 0
+}
+
+// VendorCriticality is how much the business depends on the service.
+type VendorCriticality string
+
+const (
+	VendorCriticalityLow      VendorCriticality = "low"
+	VendorCriticalityMedium   VendorCriticality = "medium"
+	VendorCriticalityHigh     VendorCriticality = "high"
+	VendorCriticalityCritical VendorCriticality = "critical"
+)
+
+func (c VendorCriticality) rank() int {
+	switch c {
+	case VendorCriticalityMedium:
+		return 1
+	case VendorCriticalityHigh:
+		return 2
+	case VendorCriticalityCritical:
+		return 3
+	}
+	return 0
+}
+
+// VendorTier is the assessment depth and cadence a vendor warrants; tier 1 is
+// the most scrutinised.
+type VendorTier int
+
+const (
+	VendorTier1 VendorTier = 1
+	VendorTier2 VendorTier = 2
+	VendorTier3 VendorTier = 3
+)
+
+// ComputeVendorTier tiers a vendor by data access and criticality.
+//
+// Either axis alone can make a vendor tier 1: a critical service is a
+// continuity risk whatever data it sees, and a processor of regulated data is
+// a breach-notification risk however trivial the service. Otherwise the two
+// ranks add up (0–7): 5 and above is tier 1, 3 and above tier 2.
+func ComputeVendorTier(access VendorDataAccess, crit VendorCriticality) VendorTier {
+	if crit == VendorCriticalityCritical || access == VendorDataRegulated {
+		return VendorTier1
+	}
+	switch points := access.rank() + crit.rank(); {
+	case points >= 5:
+		return VendorTier1
+	case points >= 3:
+		return VendorTier2
+	}
+	return VendorTier3
+}
+
+// ReassessmentYears is how long, in years, an assessment of a vendor of this
+// tier stays current: one year for tier 1, two for tier 2, three for tier 3.
+func (t VendorTier) ReassessmentYears() int {
+	switch t {
+	case VendorTier1:
+		return 1
+	case VendorTier2:
+		return 2
+	}
+	return 3
+}
+
+// NextAssessmentAfter is when a vendor of this tier assessed at `from` is due
+// again.
+func (t VendorTier) NextAssessmentAfter(from time.Time) time.Time {
+	return from.AddDate(t.ReassessmentYears(), 0, 0)
+}
+
+// VendorStatus is the vendor's place in the relationship.
+type VendorStatus string
+
+const (
+	VendorStatusActive     VendorStatus = "active"
+	VendorStatusOnboarding VendorStatus = "onboarding"
+	VendorStatusOffboarded VendorStatus = "offboarded"
+)
+
+// Vendor is one supplier in the register.
+type Vendor struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+
+	Name         string `gorm:"size:200;not null" json:"name"`
+	Website      string `gorm:"size:255" json:"website"`
+	Services     string `gorm:"type:text" json:"services"`
+	ContactName  string `gorm:"size:160" json:"contact_name"`
+	ContactEmail string `gorm:"size:255" json:"contact_email"`
+
+	DataAccess  VendorDataAccess  `gorm:"type:varchar(16);not null" json:"data_access"`
+	Criticality VendorCriticality `gorm:"type:varchar(16);not null" json:"criticality"`
+	// Tier is derived from DataAccess and Criticality on every save.
+	Tier   VendorTier   `gorm:"not null;index" json:"tier"`
+	Status VendorStatus `gorm:"type:varchar(16);default:'active';index" json:"status"`
+
+	// AssetID links the vendor to its asset (AssetCategory vendor), when the
+	// inventory has one.
+	AssetID *uuid.UUID `gorm:"type:uuid" json:"asset_id,omitempty"`
+	OwnerID *uuid.UUID `gorm:"type:uuid" json:"owner_id,omitempty"`
+
+	// Outcome of the latest submitted assessment.
+	LastScore        *float64   `gorm:"type:numeric(5,2)" json:"last_score"`
+	LastAssessedAt   *time.Time `json:"last_assessed_at"`
+	NextAssessmentAt *time.Time `gorm:"index" json:"next_assessment_at"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TableName pins the table name.
+func (Vendor) TableName() string { return "vendors" }
+
+// Validate normalises and checks a vendor, and derives its tier.
+func (v *Vendor) Validate() error {
+	v.Name = strings.TrimSpace(v.Name)
+	v.Website = strings.TrimSpace(v.Website)
+	v.ContactName = strings.TrimSpace(v.ContactName)
+	v.ContactEmail = strings.TrimSpace(v.ContactEmail)
+	if v.Name == "" {
+		return NewValidationError("vendor name is required")
+	}
+	if v.ContactEmail != "" {
+		if _, err := mail.ParseAddress(v.ContactEmail); err != nil {
+			return NewValidationError("contact_email is not a valid address")
+		}
+	}
+	switch v.DataAccess {
+	case VendorDataNone, VendorDataInternal, VendorDataConfidential, VendorDataPersonal, VendorDataRegulated:
+	case "":
+		return NewValidationError("data_access is required")
+	default:
+		return NewValidationError("data_access must be none, internal, confidential, personal or regulated")
+	}
+	switch v.Criticality {
+	case VendorCriticalityLow, VendorCriticalityMedium, VendorCriticalityHigh, VendorCriticalityCritical:
+	case "":
+		return NewValidationError("criticality is required")
+	default:
+		return NewValidationError("criticality must be low, medium, high or critical")
+	}
+	switch v.Status {
+	case "":
+		v.Status = VendorStatusActive
+	case VendorStatusActive, VendorStatusOnboarding, VendorStatusOffboarded:
+	default:
+		return NewValidationError("status must be active, onboarding or offboarded")
+	}
+	v.Tier = ComputeVendorTier(v.DataAccess, v.Criticality)
+	return nil
+}
+
+// ReassessmentDue reports whether the vendor should be sent a new assessment
+// at now. An offboarded vendor is never due; one never assessed is due at
+// once.
+func (v *Vendor) ReassessmentDue(now time.Time) bool {
+	if v.Status == VendorStatusOffboarded {
+		return false
+	}
+	return v.NextAssessmentAt == nil || !now.Before(*v.NextAssessmentAt)
+}
+
+// ---------------------------------------------------------------------------
+// Questionnaires.
+// ---------------------------------------------------------------------------
+
+// VendorQuestionType is how a question is answered, and therefore scored.
+type VendorQuestionType string
+
+const (
+	// VendorQuestionYesNo scores yes as 1 and no as 0.
+	VendorQuestionYesNo VendorQuestionType = "yes_no"
+	// VendorQuestionChoice scores the chosen option's Score (0–1).
+	VendorQuestionChoice VendorQuestionType = "choice"
+	// VendorQuestionText is read by the reviewer and never scored.
+	VendorQuestionText VendorQuestionType = "text"
+	// VendorQuestionDocument scores 1 when a document is attached.
+	VendorQuestionDocument VendorQuestionType = "document"
+)
+
+// VendorAnswerNA is the answer to a question that does not apply. It is only
+// accepted where the question allows it, and leaves the question out of the
+// score rather than counting it as a failure.
+const VendorAnswerNA = "n/a"
+
+// VendorQuestionOption is one choice of a choice question.
+type VendorQuestionOption struct {
+	Value string  `json:"value"`
+	Label string  `json:"label"`
+	Score float64 `json:"score"`
+}
+
+// VendorQuestion is one question of a questionnaire.
+type VendorQuestion struct {
+	ID       string             `json:"id"`
+	Section  string             `json:"section"`
+	Text     string             `json:"text"`
+	Help     string             `json:"help,omitempty"`
+	Type     VendorQuestionType `json:"type"`
+	Weight   float64            `json:"weight"`
+	Required bool               `json:"required"`
+	AllowNA  bool               `json:"allow_na"`
+	// Options are read only for choice questions.
+	Options []VendorQuestionOption `json:"options,omitempty"`
+}
+
+// Scored reports whether the question counts towards the score.
+func (q VendorQuestion) Scored() bool {
+	return q.Type != VendorQuestionText && q.Weight > 0
+}
+
+// VendorQuestions is a questionnaire's question list, persisted as jsonb.
+type VendorQuestions []VendorQuestion
+
+func (qs VendorQuestions) Value() (driver.Value, error) { return json.Marshal(qs) }
+
+func (qs *VendorQuestions) Scan(value interface{}) error {
+	return scanJSONColumn(value, qs, "vendor questions")
+}
+
+// Validate checks the question list of a questionnaire.
+func (qs VendorQuestions) Validate() error {
+	if len(qs) == 0 {
+		return NewValidationError("a questionnaire needs at least one question")
+	}
+	if len(qs) > MaxVendorQuestions {
+		return NewValidationError(fmt.Sprintf("a questionnaire has at most %d questions", MaxVendorQuestions))
+	}
+	seen := make(map[string]bool, len(qs))
+	scored := false
+	for i := range qs {
+		q := &qs[i]
+		q.ID = strings.TrimSpace(q.ID)
+		q.Text = strings.TrimSpace(q.Text)
+		q.Section = strings.TrimSpace(q.Section)
+		if q.ID == "" {
+			return NewValidationError(fmt.Sprintf("question %d has no id", i+1))
+		}
+		if seen[q.ID] {
+			return NewValidationError(fmt.Sprintf("question id %q is used twice", q.ID))
+		}
+		seen[q.ID] = true
+		if q.Text == "" {
+			return NewValidationError(fmt.Sprintf("question %q has no text", q.ID))
+		}
+		if q.Weight < 0 || q.Weight > 10 || math.IsNaN(q.Weight) {
+			return NewValidationError(fmt.Sprintf("question %q: weight must be between 0 and 10", q.ID))
+		}
+		switch q.Type {
+		case VendorQuestionYesNo, VendorQuestionText, VendorQuestionDocument:
+			q.Options = nil
+		case VendorQuestionChoice:
+			if len(q.Options) < 2 {
+				return NewValidationError(fmt.Sprintf("question %q: a choice needs at least two options", q.ID))
+			}
+			values := make(map[string]bool, len(q.Options))
+			for _, o := range q.Options {
+				if strings.TrimSpace(o.Value) == "" || values[o.Value] || o.Value == VendorAnswerNA {
+					return NewValidationError(fmt.Sprintf("question %q: option values must be distinct and non-empty", q.ID))
+				}
+				values[o.Value] = true
+				if o.Score < 0 || o.Score > 1 {
+					return NewValidationError(fmt.Sprintf("question %q: option scores are between 0 and 1", q.ID))
+				}
+			}
+		default:
+			return NewValidationError(fmt.Sprintf("question %q: type must be yes_no, choice, text or document", q.ID))
+		}
+		if q.Scored() {
+			scored = true
+		}
+	}
+	if !scored {
+		return NewValidationError("a questionnaire needs at least one weighted question to be scored")
+	}
+	return nil
+}
+
+// Find returns the question with the given id.
+func (qs VendorQuestions) Find(id string) (VendorQuestion, bool) {
+	for _, q := range qs {
+		if q.ID == id {
+			return q, true
+		}
+	}
+	return VendorQuestion{}, false
+}
+
+// Score is the weighted score of a set of answers, 0–100.
+//
+// A scored question that was not answered counts as 0: silence on "do you
+// encrypt backups?" is not a pass. A question answered n/a (where allowed) is
+// left out of both sides of the ratio.
+func (qs VendorQuestions) Score(answers VendorAnswers) float64 {
+	byID := answers.byQuestion()
+	var got, max float64
+	for _, q := range qs {
+		if !q.Scored() {
+			continue
+		}
+		a, answered := byID[q.ID]
+		if answered && q.AllowNA && a.Value == VendorAnswerNA {
+			continue
+		}
+		max += q.Weight
+		if answered {
+			got += q.Weight * q.answerScore(a)
+		}
+	}
+	if max == 0 {
+		return 100
+	}
+	return math.Round(got/max*10000) / 100
+}
+
+func (q VendorQuestion) answerScore(a VendorAnswer) float64 {
+	switch q.Type {
+	case VendorQuestionYesNo:
+		if a.Value == "yes" {
+			return 1
+		}
+	case VendorQuestionChoice:
+		for _, o := range q.Options {
+			if o.Value == a.Value {
+				return o.Score
+			}
+		}
+	case VendorQuestionDocument:
+		if len(a.EvidenceIDs) > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// VendorAnswer is the vendor's answer to one question. EvidenceIDs are the
+// evidence records the answer and its attachments became.
+type VendorAnswer struct {
+	QuestionID  string     `json:"question_id"`
+	Value       string     `json:"value"`
+	Comment     string     `json:"comment,omitempty"`
+	EvidenceIDs StringList `json:"evidence_ids,omitempty"`
+}
+
+// VendorAnswers is an assessment's answer list, persisted as jsonb.
+type VendorAnswers []VendorAnswer
+
+func (as VendorAnswers) Value() (driver.Value, error) { return json.Marshal(as) }
+
+func (as *VendorAnswers) Scan(value interface{}) error {
+	return scanJSONColumn(value, as, "vendor answers")
+}
+
+func (as VendorAnswers) byQuestion() map[string]VendorAnswer {
+	m := make(map[string]VendorAnswer, len(as))
+	for _, a := range as {
+		m[a.QuestionID] = a
+	}
+	return m
+}
+
+// Find returns the answer to a question.
+func (as VendorAnswers) Find(questionID string) (VendorAnswer, bool) {
+	a, ok := as.byQuestion()[questionID]
+	return a, ok
+}
+
+// ValidateAgainst normalises answers and checks them against the questions:
+// every answer must name a question and be a value that question accepts.
+// When final is set (a submission, not a saved draft) every required question
+// must be answered.
+func (as VendorAnswers) ValidateAgainst(qs VendorQuestions, final bool) error {
+	seen := make(map[string]bool, len(as))
+	for i := range as {
+		a := &as[i]
+		a.Value = strings.TrimSpace(a.Value)
+		a.Comment = strings.TrimSpace(a.Comment)
+		if len(a.Comment) > maxVendorAnswerLen || len(a.Value) > maxVendorAnswerLen {
+			return NewValidationError(fmt.Sprintf("answer to %q is too long", a.QuestionID))
+		}
+		q, ok := qs.Find(a.QuestionID)
+		if !ok {
+			return NewValidationError(fmt.Sprintf("%q is not a question of this questionnaire", a.QuestionID))
+		}
+		if seen[a.QuestionID] {
+			return NewValidationError(fmt.Sprintf("question %q is answered twice", a.QuestionID))
+		}
+		seen[a.QuestionID] = true
+		if a.Value == VendorAnswerNA {
+			if !q.AllowNA {
+				return NewValidationError(fmt.Sprintf("question %q cannot be answered n/a", q.ID))
+			}
+			continue
+		}
+		switch q.Type {
+		case VendorQuestionYesNo:
+			if a.Value != "" && a.Value != "yes" && a.Value != "no" {
+				return NewValidationError(fmt.Sprintf("question %q is answered yes or no", q.ID))
+			}
+		case VendorQuestionChoice:
+			if a.Value == "" {
+				break
+			}
+			valid := false
+			for _, o := range q.Options {
+				valid = valid || o.Value == a.Value
+			}
+			if !valid {
+				return NewValidationError(fmt.Sprintf("%q is not an option of question %q", a.Value, q.ID))
+			}
+		}
+	}
+	if !final {
+		return nil
+	}
+	for _, q := range qs {
+		if !q.Required {
+			continue
+		}
+		a, ok := as.Find(q.ID)
+		if !ok || (a.Value == "" && len(a.EvidenceIDs) == 0) {
+			return NewValidationError(fmt.Sprintf("question %q is required", q.ID))
+		}
+	}
+	return nil
+}
+
+// Questionnaire limits.
+const (
+	MaxVendorQuestions = 300
+	maxVendorAnswerLen = 8000
+	// DefaultVendorPassScore is the pass mark of a questionnaire that does not
+	// set one.
+	DefaultVendorPassScore = 70.0
+)
+
+// VendorTemplateKind tells built-in questionnaires from tenant-built ones.
+type VendorTemplateKind string
+
+const (
+	VendorTemplateSIGLite  VendorTemplateKind = "sig_lite"
+	VendorTemplateCAIQLite VendorTemplateKind = "caiq_lite"
+	VendorTemplateCustom   VendorTemplateKind = "custom"
+)
+
+// VendorQuestionnaireTemplate is a questionnaire an assessment is sent from.
+// Built-in templates are not stored: they live in code (vendor_templates.go)
+// with stable ids, and only custom templates have rows.
+type VendorQuestionnaireTemplate struct {
+	ID          uuid.UUID          `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID    uuid.UUID          `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Kind        VendorTemplateKind `gorm:"type:varchar(16);not null" json:"kind"`
+	Name        string             `gorm:"size:160;not null" json:"name"`
+	Description string             `gorm:"type:text" json:"description"`
+	Questions   VendorQuestions    `gorm:"type:jsonb" json:"questions"`
+	// PassScore is the score (0–100) under which a submitted assessment
+	// proposes a vendor risk.
+	PassScore float64 `gorm:"type:numeric(5,2)" json:"pass_score"`
+	BuiltIn   bool    `gorm:"-" json:"built_in"`
+
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TableName pins the table name.
+func (VendorQuestionnaireTemplate) TableName() string { return "vendor_questionnaire_templates" }
+
+// Validate normalises and checks a custom template.
+func (t *VendorQuestionnaireTemplate) Validate() error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return NewValidationError("questionnaire name is required")
+	}
+	t.Kind = VendorTemplateCustom
+	if t.PassScore == 0 {
+		t.PassScore = DefaultVendorPassScore
+	}
+	if t.PassScore < 0 || t.PassScore > 100 {
+		return NewValidationError("pass_score must be between 0 and 100")
+	}
+	return t.Questions.Validate()
+}
+
+// ---------------------------------------------------------------------------
+// Assessments.
+// ---------------------------------------------------------------------------
+
+// VendorAssessmentStatus is the stored state of an assessment. As with
+// invitations, expiry is not stored: State(now) projects it.
+type VendorAssessmentStatus string
+
+const (
+	VendorAssessmentSent       VendorAssessmentStatus = "sent"
+	VendorAssessmentInProgress VendorAssessmentStatus = "in_progress"
+	VendorAssessmentSubmitted  VendorAssessmentStatus = "submitted"
+	VendorAssessmentRevoked    VendorAssessmentStatus = "revoked"
+	VendorAssessmentExpired    VendorAssessmentStatus = "expired"
+)
+
+// Link lifetime bounds, in days.
+const (
+	DefaultVendorLinkDays = 30
+	MaxVendorLinkDays     = 90
+)
+
+// VendorAssessment is one questionnaire sent to one vendor.
+//
+// The questions are copied from the template when the assessment is sent, so
+// editing a template never changes what a vendor is halfway through answering,
+// nor how a past submission was scored.
+type VendorAssessment struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	VendorID uuid.UUID `gorm:"type:uuid;not null;index" json:"vendor_id"`
+
+	TemplateID   uuid.UUID          `gorm:"type:uuid" json:"template_id"`
+	TemplateKind VendorTemplateKind `gorm:"type:varchar(16)" json:"template_kind"`
+	TemplateName string             `gorm:"size:160" json:"template_name"`
+	Questions    VendorQuestions    `gorm:"type:jsonb" json:"questions"`
+	PassScore    float64            `gorm:"type:numeric(5,2)" json:"pass_score"`
+
+	// TokenHash is SHA-256(token) as hex; the token itself is never stored.
+	TokenHash string                 `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	SentTo    string                 `gorm:"size:255" json:"sent_to"`
+	ExpiresAt time.Time              `gorm:"not null" json:"expires_at"`
+	Status    VendorAssessmentStatus `gorm:"type:varchar(16);not null;index" json:"status"`
+
+	Answers     VendorAnswers `gorm:"type:jsonb" json:"answers"`
+	Score       *float64      `gorm:"type:numeric(5,2)" json:"score"`
+	SubmittedAt *time.Time    `json:"submitted_at"`
+	// ResponseEvidenceID is the evidence record the submitted answers became.
+	ResponseEvidenceID *uuid.UUID `gorm:"type:uuid" json:"response_evidence_id,omitempty"`
+	// ProposedRiskID is the draft risk a failing score proposed.
+	ProposedRiskID *uuid.UUID `gorm:"type:uuid" json:"proposed_risk_id,omitempty"`
+
+	SentBy    *uuid.UUID `gorm:"type:uuid" json:"sent_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TableName pins the table name.
+func (VendorAssessment) TableName() string { return "vendor_assessments" }
+
+// State projects the effective state at now: an open assessment past its
+// expiry is expired.
+func (a *VendorAssessment) State(now time.Time) VendorAssessmentStatus {
+	if (a.Status == VendorAssessmentSent || a.Status == VendorAssessmentInProgress) && !now.Before(a.ExpiresAt) {
+		return VendorAssessmentExpired
+	}
+	return a.Status
+}
+
+// Open reports whether the vendor may still answer at now.
+func (a *VendorAssessment) Open(now time.Time) bool {
+	s := a.State(now)
+	return s == VendorAssessmentSent || s == VendorAssessmentInProgress
+}
+
+// Passed reports whether a submitted assessment reached its pass mark.
+func (a *VendorAssessment) Passed() bool {
+	return a.Score != nil && *a.Score >= a.PassScore
+}
+
+// NewVendorAssessmentToken mints a link token and its stored hash. The
+// plaintext is returned once, to be put in the link and forgotten.
+func NewVendorAssessmentToken() (token, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashVendorAssessmentToken(token), nil
+}
+
+// HashVendorAssessmentToken is the one-way transform between a link token and
+// what the database holds.
+func HashVendorAssessmentToken(token string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	return hex.EncodeToString(sum[:])
+}
+
+// VendorAssessmentTokenMatches compares a presented token against a stored
+// hash in constant time.
+func VendorAssessmentTokenMatches(token, storedHash string) bool {
+	if token == "" || storedHash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(HashVendorAssessmentToken(token)), []byte(storedHash)) == 1
+}
+
+// scanJSONColumn decodes a jsonb column into dst.
+func scanJSONColumn(value interface{}, dst interface{}, what string) error {
+	if value == nil {
+		return nil
+	}
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("%s: unsupported scan type %T", what, value)
+	}
+	if len(b) == 0 {
+		return nil
+	}
+	return json.Unmarshal(b, dst)
+}
+
+// VendorFilter narrows the vendor list.
+type VendorFilter struct {
+	Tier   VendorTier
+	Status VendorStatus
+	Query  string
+}
+
+// VendorRepository persists the vendor register, custom questionnaires and
+// assessments. Every method is tenant-scoped except the two the public link
+// and the reassessment worker need: GetAssessmentByTokenHash (the token IS the
+// scope) and ListDueForReassessment (a cross-tenant sweep).
+type VendorRepository interface {
+	ListVendors(ctx context.Context, tenantID uuid.UUID, f VendorFilter) ([]Vendor, error)
+	// GetVendor returns (nil, nil) when the vendor does not exist in the tenant.
+	GetVendor(ctx context.Context, tenantID, id uuid.UUID) (*Vendor, error)
+	SaveVendor(ctx context.Context, v *Vendor) error
+	// DeleteVendor removes the vendor and its assessments.
+	DeleteVendor(ctx context.Context, tenantID, id uuid.UUID) error
+	ListDueForReassessment(ctx context.Context, now time.Time) ([]Vendor, error)
+
+	ListTemplates(ctx context.Context, tenantID uuid.UUID) ([]VendorQuestionnaireTemplate, error)
+	GetTemplate(ctx context.Context, tenantID, id uuid.UUID) (*VendorQuestionnaireTemplate, error)
+	SaveTemplate(ctx context.Context, t *VendorQuestionnaireTemplate) error
+	DeleteTemplate(ctx context.Context, tenantID, id uuid.UUID) error
+
+	ListAssessments(ctx context.Context, tenantID, vendorID uuid.UUID) ([]VendorAssessment, error)
+	GetAssessment(ctx context.Context, tenantID, id uuid.UUID) (*VendorAssessment, error)
+	GetAssessmentByTokenHash(ctx context.Context, hash string) (*VendorAssessment, error)
+	SaveAssessment(ctx context.Context, a *VendorAssessment) error
+	// CompleteAssessment saves a submitted assessment and the vendor's new
+	// score and reassessment date in one transaction.
+	CompleteAssessment(ctx context.Context, a *VendorAssessment, v *Vendor) error
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import "github.com/google/uuid"
+
+// ---------------------------------------------------------------------------
+// Built-in vendor questionnaires.
+//
+// SIG-lite and CAIQ-lite follow the domain structure of the Shared Assessments
+// SIG Lite and the CSA CAIQ, but the questions are OpenRisk's own short
+// wording, not the licensed question banks: a programme that holds those
+// licences imports them as a custom questionnaire. The built-ins are what a
+// team without one can send on day one.
+//
+// They are not stored. Their ids are derived from their kind, so an assessment
+// sent from one still names it after a restart, and a tenant can copy one into
+// the custom builder to adapt it.
+// ---------------------------------------------------------------------------
+
+// BuiltInVendorTemplateID is the stable id of a built-in questionnaire.
+func BuiltInVendorTemplateID(kind VendorTemplateKind) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("openrisk:vendor-questionnaire:"+string(kind)))
+}
+
+// BuiltInVendorTemplates returns fresh copies of the built-in questionnaires.
+func BuiltInVendorTemplates() []VendorQuestionnaireTemplate {
+	return []VendorQuestionnaireTemplate{
+		{
+			ID:          BuiltInVendorTemplateID(VendorTemplateSIGLite),
+			Kind:        VendorTemplateSIGLite,
+			Name:        "SIG-lite",
+			Description: "Short third-party security questionnaire modelled on the SIG Lite domains.",
+			PassScore:   DefaultVendorPassScore,
+			BuiltIn:     true,
+			Questions:   sigLiteQuestions(),
+		},
+		{
+			ID:          BuiltInVendorTemplateID(VendorTemplateCAIQLite),
+			Kind:        VendorTemplateCAIQLite,
+			Name:        "CAIQ-lite",
+			Description: "Cloud provider questionnaire modelled on the CSA CAIQ control domains.",
+			PassScore:   DefaultVendorPassScore,
+			BuiltIn:     true,
+			Questions:   caiqLiteQuestions(),
+		},
+	}
+}
+
+// BuiltInVendorTemplate returns the built-in questionnaire with the given id.
+func BuiltInVendorTemplate(id uuid.UUID) (*VendorQuestionnaireTemplate, bool) {
+	for _, t := range BuiltInVendorTemplates() {
+		if t.ID == id {
+			t := t
+			return &t, true
+		}
+	}
+	return nil, false
+}
+
+// maturityOptions is the answer scale shared by the "how far along" questions.
+func maturityOptions() []VendorQuestionOption {
+	return []VendorQuestionOption{
+		{Value: "none", Label: "Not in place", Score: 0},
+		{Value: "partial", Label: "Partially in place", Score: 0.5},
+		{Value: "full", Label: "In place and reviewed", Score: 1},
+	}
+}
+
+func vendorYesNo(id, section, text string, weight float64, required bool) VendorQuestion {
+	return VendorQuestion{ID: id, Section: section, Text: text, Type: VendorQuestionYesNo, Weight: weight, Required: required}
+}
+
+func sigLiteQuestions() VendorQuestions {
+	return VendorQuestions{
+		{ID: "gov.policy", Section: "Governance", Type: VendorQuestionChoice, Weight: 2, Required: true,
+			Text: "Do you maintain an information security policy approved by management?", Options: maturityOptions()},
+		vendorYesNo("gov.owner", "Governance", "Is a named person accountable for information security?", 1, true),
+		{ID: "gov.certs", Section: "Governance", Type: VendorQuestionDocument, Weight: 2,
+			Text: "Attach any current certification or attestation (ISO 27001 certificate, SOC 2 report…)."},
+		vendorYesNo("hr.screening", "Human resources", "Are staff with access to customer data background-checked where the law allows?", 1, false),
+		vendorYesNo("hr.training", "Human resources", "Do staff receive security awareness training at least yearly?", 1, true),
+		{ID: "iam.mfa", Section: "Access control", Type: VendorQuestionChoice, Weight: 3, Required: true,
+			Text: "Is multi-factor authentication enforced for remote and administrative access?", Options: maturityOptions()},
+		vendorYesNo("iam.review", "Access control", "Are access rights to customer data reviewed at least every six months?", 2, true),
+		vendorYesNo("ops.patch", "Operations", "Are critical security patches applied within 30 days?", 2, true),
+		vendorYesNo("ops.backup", "Operations", "Are backups encrypted, kept off-site and restore-tested?", 2, true),
+		vendorYesNo("ops.logging", "Operations", "Are security events logged and retained for at least 90 days?", 1, false),
+		vendorYesNo("data.encrypt", "Data protection", "Is customer data encrypted in transit and at rest?", 3, true),
+		{ID: "data.subprocessors", Section: "Data protection", Type: VendorQuestionText, Required: true,
+			Text: "List the subprocessors that will handle our data, and their locations."},
+		{ID: "ir.plan", Section: "Incident response", Type: VendorQuestionChoice, Weight: 2, Required: true,
+			Text: "Do you have a tested incident response plan?", Options: maturityOptions()},
+		vendorYesNo("ir.notify", "Incident response", "Will you notify us of a breach affecting our data within 72 hours?", 3, true),
+		{ID: "bc.plan", Section: "Business continuity", Type: VendorQuestionChoice, Weight: 2, AllowNA: true,
+			Text: "Is there a business continuity plan covering the service you provide us?", Options: maturityOptions()},
+	}
+}
+
+func caiqLiteQuestions() VendorQuestions {
+	return VendorQuestions{
+		{ID: "grc.program", Section: "Governance, risk & compliance", Type: VendorQuestionChoice, Weight: 2, Required: true,
+			Text: "Is there an information security programme with an annual risk assessment?", Options: maturityOptions()},
+		{ID: "grc.audit", Section: "Audit assurance", Type: VendorQuestionDocument, Weight: 3,
+			Text: "Attach your latest independent audit report or certificate (SOC 2 Type II, ISO 27001, CSA STAR…)."},
+		vendorYesNo("iam.mfa", "Identity & access", "Is MFA enforced for all administrative access to the cloud platform?", 3, true),
+		vendorYesNo("iam.sso", "Identity & access", "Can customers federate their own identity provider (SAML/OIDC)?", 1, false),
+		vendorYesNo("iam.least", "Identity & access", "Is provider staff access to customer tenants least-privilege and logged?", 2, true),
+		vendorYesNo("ekm.rest", "Encryption & key management", "Is customer data encrypted at rest by default?", 3, true),
+		{ID: "ekm.keys", Section: "Encryption & key management", Type: VendorQuestionChoice, Weight: 1, AllowNA: true,
+			Text: "Who controls the encryption keys?", Options: []VendorQuestionOption{
+				{Value: "provider", Label: "Provider-managed", Score: 0.5},
+				{Value: "customer", Label: "Customer-managed (BYOK/HYOK)", Score: 1},
+			}},
+		vendorYesNo("dsp.location", "Data security & privacy", "Can the customer choose the region where data is stored?", 2, true),
+		vendorYesNo("dsp.deletion", "Data security & privacy", "Is customer data securely deleted on contract termination, with a certificate on request?", 2, true),
+		vendorYesNo("tvm.scan", "Threat & vulnerability management", "Are the platform and its dependencies scanned for vulnerabilities at least monthly?", 2, true),
+		vendorYesNo("tvm.pentest", "Threat & vulnerability management", "Is an independent penetration test run at least yearly?", 2, true),
+		vendorYesNo("log.customer", "Logging & monitoring", "Are audit logs of customer-tenant activity available to the customer?", 1, false),
+		vendorYesNo("sef.notify", "Security incident management", "Are customers notified of security incidents affecting them within 72 hours?", 3, true),
+		{ID: "bcr.rto", Section: "Business continuity & resilience", Type: VendorQuestionText,
+			Text: "State the recovery time and recovery point objectives of the service."},
+		vendorYesNo("bcr.multiaz", "Business continuity & resilience", "Is the service deployed across more than one availability zone or data centre?", 2, false),
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestComputeVendorTier(t *testing.T) {
+	cases := []struct {
+		access VendorDataAccess
+		crit   VendorCriticality
+		want   VendorTier
+	}{
+		{VendorDataNone, VendorCriticalityLow, VendorTier3},
+		{VendorDataInternal, VendorCriticalityMedium, VendorTier3},
+		{VendorDataConfidential, VendorCriticalityMedium, VendorTier2},
+		{VendorDataPersonal, VendorCriticalityLow, VendorTier2},
+		{VendorDataPersonal, VendorCriticalityHigh, VendorTier1},
+		{VendorDataNone, VendorCriticalityCritical, VendorTier1},
+		{VendorDataRegulated, VendorCriticalityLow, VendorTier1},
+	}
+	for _, c := range cases {
+		if got := ComputeVendorTier(c.access, c.crit); got != c.want {
+			t.Errorf("%s/%s: tier %d, want %d", c.access, c.crit, got, c.want)
+		}
+	}
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if got := VendorTier1.NextAssessmentAfter(from); !got.Equal(from.AddDate(1, 0, 0)) {
+		t.Errorf("tier 1 reassessment %v", got)
+	}
+	if got := VendorTier3.NextAssessmentAfter(from); !got.Equal(from.AddDate(3, 0, 0)) {
+		t.Errorf("tier 3 reassessment %v", got)
+	}
+}
+
+func TestVendor_ValidateDerivesTier(t *testing.T) {
+	v := &Vendor{Name: "  Acme Cloud ", DataAccess: VendorDataRegulated, Criticality: VendorCriticalityLow, Tier: VendorTier3}
+	if err := v.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if v.Tier != VendorTier1 || v.Name != "Acme Cloud" || v.Status != VendorStatusActive {
+		t.Fatalf("got %+v", v)
+	}
+	bad := []*Vendor{
+		{DataAccess: VendorDataNone, Criticality: VendorCriticalityLow},
+		{Name: "x", Criticality: VendorCriticalityLow},
+		{Name: "x", DataAccess: "secret", Criticality: VendorCriticalityLow},
+		{Name: "x", DataAccess: VendorDataNone, Criticality: VendorCriticalityLow, ContactEmail: "not-an-address"},
+	}
+	for i, b := range bad {
+		if err := b.Validate(); !errors.Is(err, ErrValidation) {
+			t.Errorf("case %d: want validation error, got %v", i, err)
+		}
+	}
+	now := time.Now()
+	off := &Vendor{Status: VendorStatusOffboarded}
+	if off.ReassessmentDue(now) {
+		t.Error("an offboarded vendor is never due")
+	}
+	if !(&Vendor{Status: VendorStatusActive}).ReassessmentDue(now) {
+		t.Error("a never-assessed vendor is due")
+	}
+}
+
+func TestVendorQuestions_Score(t *testing.T) {
+	qs := VendorQuestions{
+		{ID: "mfa", Text: "MFA?", Type: VendorQuestionYesNo, Weight: 3, Required: true},
+		{ID: "ir", Text: "IR plan?", Type: VendorQuestionChoice, Weight: 2, Options: maturityOptions()},
+		{ID: "cert", Text: "Certificate", Type: VendorQuestionDocument, Weight: 1},
+		{ID: "bcp", Text: "BCP?", Type: VendorQuestionYesNo, Weight: 4, AllowNA: true},
+		{ID: "notes", Text: "Notes", Type: VendorQuestionText},
+	}
+	if err := qs.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	answers := VendorAnswers{
+		{QuestionID: "mfa", Value: "yes"},
+		{QuestionID: "ir", Value: "partial"},
+		{QuestionID: "bcp", Value: VendorAnswerNA},
+		{QuestionID: "notes", Value: "anything"},
+	}
+	if err := answers.ValidateAgainst(qs, true); err != nil {
+		t.Fatal(err)
+	}
+	// (3·1 + 2·0.5 + 1·0) / (3+2+1): n/a is out, the missing document is a 0.
+	if got := qs.Score(answers); got != 66.67 {
+		t.Fatalf("score %v", got)
+	}
+	answers[2].Value = "no"
+	if got := qs.Score(answers); got != 40 {
+		t.Fatalf("score with bcp=no %v", got)
+	}
+	answers = append(answers, VendorAnswer{QuestionID: "cert", EvidenceIDs: StringList{"e1"}})
+	if got := qs.Score(answers); got != 50 {
+		t.Fatalf("score with certificate %v", got)
+	}
+}
+
+func TestVendorAnswers_ValidateAgainst(t *testing.T) {
+	qs := VendorQuestions{
+		{ID: "mfa", Text: "MFA?", Type: VendorQuestionYesNo, Weight: 1, Required: true},
+		{ID: "ir", Text: "IR plan?", Type: VendorQuestionChoice, Weight: 1, Options: maturityOptions()},
+	}
+	bad := []VendorAnswers{
+		{{QuestionID: "nope", Value: "yes"}},
+		{{QuestionID: "mfa", Value: "maybe"}},
+		{{QuestionID: "mfa", Value: VendorAnswerNA}},
+		{{QuestionID: "ir", Value: "excellent"}},
+		{{QuestionID: "mfa", Value: "yes"}, {QuestionID: "mfa", Value: "no"}},
+	}
+	for i, as := range bad {
+		if err := as.ValidateAgainst(qs, false); !errors.Is(err, ErrValidation) {
+			t.Errorf("case %d: want validation error, got %v", i, err)
+		}
+	}
+	draft := VendorAnswers{{QuestionID: "ir", Value: "full"}}
+	if err := draft.ValidateAgainst(qs, false); err != nil {
+		t.Errorf("a draft may leave required questions open: %v", err)
+	}
+	if err := draft.ValidateAgainst(qs, true); !errors.Is(err, ErrValidation) {
+		t.Errorf("a submission may not: %v", err)
+	}
+}
+
+func TestVendorQuestions_Validate(t *testing.T) {
+	bad := []VendorQuestions{
+		nil,
+		{{ID: "a", Text: "t", Type: VendorQuestionText}},
+		{{ID: "a", Text: "t", Type: VendorQuestionYesNo, Weight: 1}, {ID: "a", Text: "u", Type: VendorQuestionYesNo, Weight: 1}},
+		{{ID: "a", Text: "t", Type: VendorQuestionChoice, Weight: 1, Options: []VendorQuestionOption{{Value: "x", Score: 1}}}},
+		{{ID: "a", Text: "t", Type: VendorQuestionChoice, Weight: 1, Options: []VendorQuestionOption{{Value: "x", Score: 1}, {Value: "y", Score: 2}}}},
+		{{ID: "a", Text: "t", Type: "essay", Weight: 1}},
+		{{ID: "a", Text: "t", Type: VendorQuestionYesNo, Weight: 11}},
+	}
+	for i, qs := range bad {
+		if err := qs.Validate(); !errors.Is(err, ErrValidation) {
+			t.Errorf("case %d: want validation error, got %v", i, err)
+		}
+	}
+	for _, tpl := range BuiltInVendorTemplates() {
+		if err := tpl.Questions.Validate(); err != nil {
+			t.Errorf("built-in %s: %v", tpl.Name, err)
+		}
+		got, ok := BuiltInVendorTemplate(tpl.ID)
+		if !ok || got.Kind != tpl.Kind {
+			t.Errorf("built-in %s is not found by its id", tpl.Name)
+		}
+	}
+}
+
+func TestVendorAssessment_StateAndToken(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	token, hash, err := NewVendorAssessmentToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VendorAssessmentTokenMatches(token, hash) || VendorAssessmentTokenMatches(token+"x", hash) || VendorAssessmentTokenMatches("", hash) {
+		t.Fatal("token matching is wrong")
+	}
+	a := &VendorAssessment{Status: VendorAssessmentSent, ExpiresAt: now.Add(time.Hour)}
+	if !a.Open(now) {
+		t.Fatal("open before expiry")
+	}
+	if a.State(now.Add(time.Hour)) != VendorAssessmentExpired || a.Open(now.Add(time.Hour)) {
+		t.Fatal("expired at expiry")
+	}
+	a.Status = VendorAssessmentSubmitted
+	if a.State(now.Add(48*time.Hour)) != VendorAssessmentSubmitted {
+		t.Fatal("a submitted assessment does not expire")
+	}
+	score := 69.99
+	a.Score, a.PassScore = &score, 70
+	if a.Passed() {
+		t.Fatal("under the pass mark")
+	}
+}
@@ -82,6 +82,9 @@ const (
 	VulnSourceCrowdStrike   VulnSource = "crowdstrike"    // CrowdStrike Falcon Spotlight
 	VulnSourceScanner       VulnSource = "scanner"        // OpenRisk built-in scanner (Module 6)
 	VulnSourceManual        VulnSource = "manual"
+	VulnSourcePlugin        VulnSource = "plugin" // a connector plugin (internal/pluginhost)
+	VulnSourceSARIF         VulnSource = "sarif"  // SARIF upload (code, IaC and container scanners)
+	VulnSourceSBOM          VulnSource = "sbom"   // CycloneDX SBOM upload
 )
 
 // SupportedVulnSources is the ordered list of integration sources surfaced in
@@ -89,7 +92,7 @@ const (
 var SupportedVulnSources = []VulnSource{
 	VulnSourceNessus, VulnSourceOpenVAS, VulnSourceQualys, VulnSourceMSDefender,
 	VulnSourceAWSInspector, VulnSourceAzureDefender, VulnSourceCrowdStrike,
-	VulnSourceScanner, VulnSourceManual,
+	VulnSourceScanner, VulnSourceManual, VulnSourcePlugin, VulnSourceSARIF, VulnSourceSBOM,
 }
 
 // ParseVulnSource validates a source string (empty → manual).
@@ -7,6 +7,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -26,6 +27,9 @@ type VulnerabilityQuery struct {
 	// attribute: no asset at all, or an ambiguous match. These are what the
 	// "Unassigned vulnerabilities" screen exists to resolve.
 	UnassignedOnly bool
+	// FirstSeenAfter keeps findings first seen at or after this instant — what
+	// a CI gate means by "new since the baseline".
+	FirstSeenAfter *time.Time
 
 	Page  int
 	Limit int
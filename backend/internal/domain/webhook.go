@@ -0,0 +1,299 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// ---------------------------------------------------------------------------
+// Outbound webhooks.
+//
+// The automation "notify" channel posts a chat message to a URL, which is fine
+// for a person and useless for a program. A WebhookSubscription is the program
+// case: an integrator picks domain events, and every occurrence is queued as a
+// WebhookDelivery carrying a versioned, schema-described JSON envelope, signed
+// with the subscription's secret and retried with backoff until the receiver
+// acknowledges it. The delivery log is what the integrator debugs against, and
+// what an administrator redelivers from. See docs/WEBHOOKS.md.
+// ---------------------------------------------------------------------------
+
+// WebhookEventType names a domain event a subscription can receive.
+type WebhookEventType string
+
+const (
+	WebhookRiskStateChanged      WebhookEventType = "risk.state_changed"
+	WebhookRiskScoreUpdated      WebhookEventType = "risk.score_updated"
+	WebhookVulnerabilityDetected WebhookEventType = "vulnerability.detected"
+	WebhookEvidenceExpired       WebhookEventType = "evidence.expired"
+	WebhookApprovalDecided       WebhookEventType = "approval.decided"
+	WebhookIncidentDeclared      WebhookEventType = "incident.declared"
+	WebhookAuditCheckpointed     WebhookEventType = "audit.checkpoint_created"
+)
+
+// WebhookEventTypes is every event a subscription can choose, in display order.
+var WebhookEventTypes = []WebhookEventType{
+	WebhookRiskStateChanged, WebhookRiskScoreUpdated, WebhookVulnerabilityDetected,
+	WebhookEvidenceExpired, WebhookApprovalDecided, WebhookIncidentDeclared,
+	WebhookAuditCheckpointed,
+}
+
+// IsValid reports whether t is a known event type.
+func (t WebhookEventType) IsValid() bool {
+	for _, k := range WebhookEventTypes {
+		if t == k {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookSchemaVersion is the envelope and payload version sent today. A
+// breaking change to any payload ships as a new version next to this one; a
+// field is only ever added to an existing version.
+const WebhookSchemaVersion = 1
+
+// Delivery policy.
+const (
+	// WebhookMaxAttempts bounds the tries of one delivery. With the backoff
+	// below the last one happens a little over four hours after the event.
+	WebhookMaxAttempts = 10
+	// WebhookFirstRetry is the wait after the first failure; it doubles after
+	// each one, up to WebhookMaxRetry.
+	WebhookFirstRetry = 30 * time.Second
+	WebhookMaxRetry   = 6 * time.Hour
+	// A subscription is disabled once it has failed WebhookDisableAfterFailures
+	// attempts in a row AND has been failing for WebhookDisableAfterPeriod. The
+	// period is longer than one delivery's whole retry schedule, so neither a
+	// single bad event nor a burst during a short outage turns it off.
+	WebhookDisableAfterFailures = 15
+	WebhookDisableAfterPeriod   = 6 * time.Hour
+	// WebhookPreviousSecretTTL is how long the secret a rotation replaced keeps
+	// signing next to the new one, so receivers can switch without a gap.
+	WebhookPreviousSecretTTL = 24 * time.Hour
+)
+
+// WebhookEnvelope is the body of every delivery. Data is the event's payload,
+// described by the JSON Schema named in Schema.
+type WebhookEnvelope struct {
+	// ID identifies the event. A redelivery carries the same ID, so receivers
+	// deduplicate on it.
+	ID            uuid.UUID        `json:"id"`
+	Type          WebhookEventType `json:"type"`
+	SchemaVersion int              `json:"schema_version"`
+	// Schema is "openrisk.webhook.<type>.v<version>", served by
+	// GET /webhook-events/:type/schema.
+	Schema     string    `json:"schema"`
+	TenantID   uuid.UUID `json:"tenant_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+// ---- v1 payloads. Receivers parse these: fields are added, never renamed. ----
+
+// WebhookRiskStateChangedData is the payload of risk.state_changed.
+type WebhookRiskStateChangedData struct {
+	RiskID  uuid.UUID  `json:"risk_id"`
+	Title   string     `json:"title"`
+	From    RiskState  `json:"from"`
+	To      RiskState  `json:"to"`
+	Comment string     `json:"comment,omitempty"`
+	ActorID *uuid.UUID `json:"actor_id,omitempty"`
+}
+
+// WebhookRiskScoreUpdatedData is the payload of risk.score_updated.
+type WebhookRiskScoreUpdatedData struct {
+	RiskID       uuid.UUID `json:"risk_id"`
+	OldScore     float64   `json:"old_score"`
+	NewScore     float64   `json:"new_score"`
+	Delta        float64   `json:"delta"`
+	Criticality  string    `json:"criticality"`
+	CalculatedAt string    `json:"calculated_at"`
+}
+
+// WebhookVulnerabilityDetectedData is the payload of vulnerability.detected.
+type WebhookVulnerabilityDetectedData struct {
+	VulnerabilityID uuid.UUID  `json:"vulnerability_id"`
+	CVEID           string     `json:"cve_id,omitempty"`
+	Title           string     `json:"title"`
+	Severity        string     `json:"severity"`
+	CVSS            float64    `json:"cvss"`
+	KEV             bool       `json:"kev"`
+	PriorityTier    string     `json:"priority_tier,omitempty"`
+	AssetID         *uuid.UUID `json:"asset_id,omitempty"`
+	AssetName       string     `json:"asset_name,omitempty"`
+	Source          string     `json:"source,omitempty"`
+}
+
+// WebhookEvidenceExpiredData is the payload of evidence.expired.
+type WebhookEvidenceExpiredData struct {
+	EvidenceID uuid.UUID  `json:"evidence_id"`
+	Title      string     `json:"title"`
+	ValidUntil time.Time  `json:"valid_until"`
+	OwnerID    *uuid.UUID `json:"owner_id,omitempty"`
+	AssigneeID *uuid.UUID `json:"assignee_id,omitempty"`
+}
+
+// WebhookApprovalDecidedData is the payload of approval.decided, sent when a
+// request reaches its outcome (approved or rejected), not for each signature.
+type WebhookApprovalDecidedData struct {
+	ApprovalID  uuid.UUID      `json:"approval_id"`
+	Title       string         `json:"title"`
+	RequestType string         `json:"request_type,omitempty"`
+	EntityType  string         `json:"entity_type"`
+	EntityID    string         `json:"entity_id,omitempty"`
+	Status      ApprovalStatus `json:"status"`
+	DecidedBy   string         `json:"decided_by,omitempty"`
+	Comment     string         `json:"comment,omitempty"`
+	ResolvedAt  *time.Time     `json:"resolved_at,omitempty"`
+}
+
+// WebhookIncidentDeclaredData is the payload of incident.declared.
+type WebhookIncidentDeclaredData struct {
+	IncidentID   uint     `json:"incident_id"`
+	Title        string   `json:"title"`
+	Severity     string   `json:"severity"`
+	IncidentType string   `json:"incident_type,omitempty"`
+	Origin       string   `json:"origin"`
+	RiskIDs      []string `json:"risk_ids"`
+	AssetIDs     []string `json:"asset_ids"`
+}
+
+// WebhookAuditCheckpointData is the payload of audit.checkpoint_created. It
+// carries the whole signed checkpoint, so a receiver that keeps the payloads
+// holds copies the database cannot take back (see AuditCheckpoint).
+type WebhookAuditCheckpointData struct {
+	CheckpointID   uuid.UUID  `json:"checkpoint_id"`
+	Sequence       int64      `json:"sequence"`
+	HeadHash       string     `json:"head_hash"`
+	SignedAt       time.Time  `json:"signed_at"`
+	Algorithm      string     `json:"algorithm"`
+	KeyID          string     `json:"key_id"`
+	Signature      string     `json:"signature"`
+	TimestampToken []byte     `json:"timestamp_token,omitempty"`
+	TimestampedAt  *time.Time `json:"timestamped_at,omitempty"`
+}
+
+// WebhookSubscription is an endpoint and the events it receives.
+type WebhookSubscription struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Name       string     `gorm:"size:120;not null" json:"name"`
+	URL        string     `gorm:"type:text;not null" json:"url"`
+	EventTypes StringList `gorm:"type:jsonb" json:"event_types"`
+	Enabled    bool       `gorm:"not null;default:true" json:"enabled"`
+	// DisabledReason says why delivery stopped when it was not a person's
+	// choice — the auto-disable records the last error here.
+	DisabledReason string `gorm:"type:text;not null;default:''" json:"disabled_reason,omitempty"`
+	// EncryptedSecrets holds the signing secrets ("current", and "previous"
+	// during a rotation). Never returned: the secret is shown once, on create
+	// and on rotation.
+	EncryptedSecrets        string     `gorm:"type:text;not null;default:''" json:"-"`
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty"`
+	// ConsecutiveFailures and FailingSince describe the current failure
+	// streak; both reset on the first successful attempt.
+	ConsecutiveFailures int        `gorm:"not null;default:0" json:"consecutive_failures"`
+	FailingSince        *time.Time `json:"failing_since,omitempty"`
+	LastDeliveryAt      *time.Time `json:"last_delivery_at,omitempty"`
+	CreatedBy           *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+func (WebhookSubscription) TableName() string { return "webhook_subscriptions" }
+
+// Wants reports whether the subscription receives t.
+func (s *WebhookSubscription) Wants(t WebhookEventType) bool {
+	for _, k := range s.EventTypes {
+		if WebhookEventType(k) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus is where a delivery stands.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending waits for its next attempt.
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryDelivering is claimed by a worker. A claim is a lease:
+	// once NextAttemptAt passes, a crashed worker's delivery is claimable again.
+	WebhookDeliveryDelivering WebhookDeliveryStatus = "delivering"
+	WebhookDeliverySucceeded  WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryFailed is final: attempts exhausted, or the subscription
+	// was disabled or deleted before it could be sent.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event queued for one subscription, and its log.
+type WebhookDelivery struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID       uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	SubscriptionID uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:ux_webhook_deliveries_event,priority:1,where:redelivery_of IS NULL" json:"subscription_id"`
+	// EventID is the envelope id. An event is queued once per subscription —
+	// the unique index absorbs a second publication of the same event — except
+	// for redeliveries, which are new rows on purpose.
+	EventID   uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:ux_webhook_deliveries_event,priority:2,where:redelivery_of IS NULL" json:"event_id"`
+	EventType WebhookEventType `gorm:"type:varchar(40);not null" json:"event_type"`
+	// Payload is the envelope exactly as it is sent; the signature is computed
+	// over these bytes at each attempt.
+	Payload        datatypes.JSON        `gorm:"type:jsonb;not null" json:"payload,omitempty"`
+	Status         WebhookDeliveryStatus `gorm:"type:varchar(16);not null;index" json:"status"`
+	Attempts       int                   `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time             `gorm:"not null;index" json:"next_attempt_at"`
+	LastStatusCode int                   `gorm:"not null;default:0" json:"last_status_code,omitempty"`
+	LastError      string                `gorm:"type:text;not null;default:''" json:"last_error,omitempty"`
+	DurationMS     int64                 `gorm:"not null;default:0" json:"duration_ms"`
+	// RedeliveryOf is the delivery this one was redelivered from.
+	RedeliveryOf *uuid.UUID `gorm:"type:uuid" json:"redelivery_of,omitempty"`
+	RequestedBy  *uuid.UUID `gorm:"type:uuid" json:"requested_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	DeliveredAt  *time.Time `json:"delivered_at,omitempty"`
+}
+
+func (WebhookDelivery) TableName() string { return "webhook_deliveries" }
+
+// WebhookRepository persists subscriptions and deliveries. Every method is
+// tenant-scoped except the worker's: ClaimDueDelivery, CompleteAttempt,
+// GetSubscriptionForDelivery and RecordAttempt run without a session and act
+// on rows that carry their own tenant.
+type WebhookRepository interface {
+	ListSubscriptions(ctx context.Context, tenantID uuid.UUID) ([]WebhookSubscription, error)
+	// GetSubscription returns (nil, nil) when absent.
+	GetSubscription(ctx context.Context, tenantID, id uuid.UUID) (*WebhookSubscription, error)
+	// EnabledSubscriptions returns the tenant's enabled subscriptions.
+	EnabledSubscriptions(ctx context.Context, tenantID uuid.UUID) ([]WebhookSubscription, error)
+	SaveSubscription(ctx context.Context, s *WebhookSubscription) error
+	// DeleteSubscription removes the subscription and its delivery log.
+	DeleteSubscription(ctx context.Context, tenantID, id uuid.UUID) error
+
+	// EnqueueDeliveries inserts deliveries, skipping any whose (subscription,
+	// event) is already queued.
+	EnqueueDeliveries(ctx context.Context, ds []WebhookDelivery) error
+	// GetDelivery returns (nil, nil) when absent.
+	GetDelivery(ctx context.Context, tenantID, id uuid.UUID) (*WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, tenantID, subscriptionID uuid.UUID, limit int) ([]WebhookDelivery, error)
+
+	// ClaimDueDelivery takes the oldest delivery due at now, across tenants:
+	// it becomes delivering, its attempt is counted and it is leased until
+	// now+lease. Returns (nil, nil) when nothing is due.
+	ClaimDueDelivery(ctx context.Context, now time.Time, lease time.Duration) (*WebhookDelivery, error)
+	// CompleteAttempt writes the outcome of the attempt d was claimed for. It
+	// reports false when the lease was lost and another worker re-claimed it.
+	CompleteAttempt(ctx context.Context, d *WebhookDelivery) (bool, error)
+	// GetSubscriptionForDelivery loads a delivery's subscription by its own
+	// tenant; (nil, nil) when it was deleted.
+	GetSubscriptionForDelivery(ctx context.Context, d *WebhookDelivery) (*WebhookSubscription, error)
+	// RecordAttempt updates the subscription's failure streak after an attempt
+	// and returns it as it now stands.
+	RecordAttempt(ctx context.Context, subscriptionID uuid.UUID, succeeded bool, at time.Time) (*WebhookSubscription, error)
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/application/accesspolicy"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/internal/middleware"
+)
+
+// AccessPolicyHandler exposes record-level access policies, the attributes
+// assigned to members, and the explain endpoint.
+type AccessPolicyHandler struct {
+	svc *accesspolicy.Service
+}
+
+// NewAccessPolicyHandler builds the handler.
+func NewAccessPolicyHandler(svc *accesspolicy.Service) *AccessPolicyHandler {
+	return &AccessPolicyHandler{svc: svc}
+}
+
+// Attributes GET /access-policies/attributes — the record attributes a
+// condition can test, per resource.
+func (h *AccessPolicyHandler) Attributes(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"items": domain.AccessAttributes})
+}
+
+// List GET /access-policies
+func (h *AccessPolicyHandler) List(c *fiber.Ctx) error {
+	items, err := h.svc.Policies(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// Create POST /access-policies
+func (h *AccessPolicyHandler) Create(c *fiber.Ctx) error {
+	var in accesspolicy.PolicyInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	p, err := h.svc.CreatePolicy(c.UserContext(), tenantID(c), optionalActor(c), in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(p)
+}
+
+// Get GET /access-policies/:id
+func (h *AccessPolicyHandler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid policy id"})
+	}
+	p, err := h.svc.Policy(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(p)
+}
+
+// Update PUT /access-policies/:id — replaces the definition.
+func (h *AccessPolicyHandler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid policy id"})
+	}
+	var in accesspolicy.PolicyInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	p, err := h.svc.UpdatePolicy(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(p)
+}
+
+// Delete DELETE /access-policies/:id
+func (h *AccessPolicyHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid policy id"})
+	}
+	if err := h.svc.DeletePolicy(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetSubject GET /access-policies/subjects/:id — what policies know about a
+// member: role, business role, department and assigned attributes.
+func (h *AccessPolicyHandler) GetSubject(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid user id"})
+	}
+	s, err := h.svc.Subject(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(s)
+}
+
+type subjectAttributesInput struct {
+	Attributes map[string][]string `json:"attributes"`
+}
+
+// SetSubjectAttributes PUT /access-policies/subjects/:id — replaces the
+// member's assigned attributes, e.g. {"attributes": {"audit_scope": ["iso27001"]}}.
+func (h *AccessPolicyHandler) SetSubjectAttributes(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid user id"})
+	}
+	var in subjectAttributesInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	s, err := h.svc.SetSubjectAttributes(c.UserContext(), tenantID(c), optionalActor(c), id, in.Attributes)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(s)
+}
+
+// Explain GET /access-policies/explain?resource=risk&id=… — why the caller
+// can or cannot see a record. An administrator may add user_id to ask on
+// behalf of another member, and always gets the record's attributes and the
+// predicate traces; anyone else gets them only for a record they can see.
+func (h *AccessPolicyHandler) Explain(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Query("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid record id"})
+	}
+	tenant := tenantID(c)
+	admin := isTenantAdmin(c, tenant)
+	subject := userID(c)
+	if raw := c.Query("user_id"); raw != "" {
+		other, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid user_id"})
+		}
+		if other != subject && !admin {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "only administrators can explain access for another user"})
+		}
+		subject = other
+	}
+	out, err := h.svc.Explain(c.UserContext(), tenant, subject, domain.AccessResource(c.Query("resource")), id, admin)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(out)
+}
+
+// isTenantAdmin reports whether the caller administers the tenant: the
+// wildcard permission, or the admin or root role in this organization.
+func isTenantAdmin(c *fiber.Ctx, tenant uuid.UUID) bool {
+	claims := middleware.GetUserClaims(c)
+	if claims == nil {
+		return false
+	}
+	if claims.HasPermission("*") {
+		return true
+	}
+	role := claims.OrgRoles[tenant]
+	return role == string(domain.RoleAdmin) || role == string(domain.RoleRoot)
+}
@@ -19,8 +19,8 @@ import (
 // AIHandler wires the GRC AI assistant use cases (spec §12) to HTTP. Every AI call
 // is best-effort: the use cases fall back to a deterministic template assistant, so
 // these endpoints always return 200 with a result even without an API key. The
-// response records generated_by so the UI can show whether Claude or the template
-// produced it.
+// response records generated_by so the UI can show which model (Claude, the
+// organisation's self-hosted one, or the template) produced it.
 type AIHandler struct {
 	assistant     llm.Assistant
 	treatmentUC   *appai.SuggestTreatmentPlanUseCase
@@ -28,6 +28,7 @@ type AIHandler struct {
 	queryUC       *appai.AssistantQueryUseCase
 	auditReportUC *appai.GenerateAuditReportUseCase
 	evidenceUC    *appai.AnalyzeEvidenceUseCase
+	providers     *appai.ProviderSettingsService // optional
 }
 
 func NewAIHandler(
@@ -61,15 +62,69 @@ func localeOf(c *fiber.Ctx, field string) string {
 	return "fr"
 }
 
+// WithProviders enables per-organisation provider settings (/ai/provider) and
+// makes Status report the organisation's provider rather than the server's.
+func (h *AIHandler) WithProviders(p *appai.ProviderSettingsService) *AIHandler {
+	h.providers = p
+	return h
+}
+
 // Status reports whether a real LLM is configured and which model is active.
+// source is "organization" when the organisation picked its own provider,
+// "server" otherwise.
 // GET /ai/status
 func (h *AIHandler) Status(c *fiber.Ctx) error {
+	assistant, source := h.assistant, "server"
+	if h.providers != nil {
+		assistant = h.providers.AssistantFor(c.UserContext(), tenantID(c))
+		if _, err := h.providers.Get(c.UserContext(), tenantID(c)); err == nil {
+			source = "organization"
+		}
+	}
 	return c.JSON(fiber.Map{
-		"llm_enabled": llm.IsLLMBacked(h.assistant),
-		"model":       h.assistant.Name(),
+		"llm_enabled": llm.IsLLMBacked(assistant),
+		"model":       assistant.Name(),
+		"source":      source,
 	})
 }
 
+// --- Provider settings ------------------------------------------------------
+
+// GetProvider returns the organisation's provider setting; 404 means it uses
+// the server default.
+// GET /ai/provider
+func (h *AIHandler) GetProvider(c *fiber.Ctx) error {
+	st, err := h.providers.Get(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(st)
+}
+
+// SaveProvider sets the organisation's provider. A blank api_key keeps the
+// stored one for the same endpoint.
+// PUT /ai/provider
+func (h *AIHandler) SaveProvider(c *fiber.Ctx) error {
+	var in appai.ProviderInput
+	if err := c.BodyParser(&in); err != nil {
+		return writeAppError(c, domain.NewValidationError("invalid request body"))
+	}
+	st, err := h.providers.Save(c.UserContext(), tenantID(c), optionalActor(c), in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(st)
+}
+
+// DeleteProvider reverts the organisation to the server default.
+// DELETE /ai/provider
+func (h *AIHandler) DeleteProvider(c *fiber.Ctx) error {
+	if err := h.providers.Delete(c.UserContext(), tenantID(c), optionalActor(c)); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
 // --- 1. Treatment plan ------------------------------------------------------
 
 type treatmentPlanInput struct {
@@ -55,6 +55,8 @@ type createAssetInput struct {
 	Type        string `json:"type"`
 	Criticality string `json:"criticality" validate:"omitempty,oneof=LOW MEDIUM HIGH CRITICAL"`
 	Owner       string `json:"owner"`
+	// BusinessUnit — the part of the organisation the asset belongs to.
+	BusinessUnit string `json:"business_unit" validate:"omitempty,max=128"`
 	// Category is NOT validated with `oneof` here: the authoritative list lives
 	// in domain.AssetCategories, and duplicating it in a struct tag is how the
 	// two drift. The use case parses it and returns a named validation error.
@@ -73,12 +75,13 @@ func (h *AssetHandler) CreateAsset(c *fiber.Ctx) error {
 	}
 
 	assetEntity, err := h.createAssetUC.Execute(c.UserContext(), tenantID(c), assetuc.CreateAssetInput{
-		Name:        input.Name,
-		Type:        input.Type,
-		Criticality: domain.AssetCriticality(input.Criticality),
-		Owner:       input.Owner,
-		Category:    domain.AssetCategory(input.Category),
-		Attributes:  input.Attributes,
+		Name:         input.Name,
+		Type:         input.Type,
+		Criticality:  domain.AssetCriticality(input.Criticality),
+		Owner:        input.Owner,
+		BusinessUnit: input.BusinessUnit,
+		Category:     domain.AssetCategory(input.Category),
+		Attributes:   input.Attributes,
 	})
 	if err != nil {
 		return writeAppError(c, err)
@@ -128,7 +131,9 @@ type updateAssetInput struct {
 	Type        *string `json:"type" validate:"omitempty"`
 	Criticality *string `json:"criticality" validate:"omitempty,oneof=LOW MEDIUM HIGH CRITICAL"`
 	Owner       *string `json:"owner" validate:"omitempty"`
-	Category    *string `json:"category" validate:"omitempty"`
+	// BusinessUnit: absent leaves it, "" clears it.
+	BusinessUnit *string `json:"business_unit" validate:"omitempty,max=128"`
+	Category     *string `json:"category" validate:"omitempty"`
 	// Attributes replaces the whole bag when present; absent leaves it alone.
 	Attributes map[string]any `json:"attributes"`
 }
@@ -149,7 +154,8 @@ func (h *AssetHandler) UpdateAsset(c *fiber.Ctx) error {
 
 	ucInput := assetuc.UpdateAssetInput{
 		Name: input.Name, Type: input.Type, Owner: input.Owner,
-		Attributes: input.Attributes,
+		BusinessUnit: input.BusinessUnit,
+		Attributes:   input.Attributes,
 	}
 	if input.Criticality != nil {
 		crit := domain.AssetCriticality(*input.Criticality)
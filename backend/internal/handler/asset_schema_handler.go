@@ -23,7 +23,7 @@ func NewAssetSchemaHandler(svc *assetschema.Service) *AssetSchemaHandler {
 	return &AssetSchemaHandler{svc: svc}
 }
 
-// ListSchemas returns all 8 category schemas for the tenant.
+// ListSchemas returns one schema per asset category for the tenant.
 // GET /attack-surface/schemas
 func (h *AssetSchemaHandler) ListSchemas(c *fiber.Ctx) error {
 	schemas, err := h.svc.List(c.UserContext(), tenantID(c))
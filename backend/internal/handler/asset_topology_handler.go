@@ -16,17 +16,20 @@ import (
 )
 
 // AssetTopologyHandler serves the attack-surface topology: the dependency graph
-// as the view needs it, and the compromise chain of a single asset.
+// as the view needs it, the compromise chain of a single asset, and the
+// vulnerability-weighted attack paths to the crown jewels.
 type AssetTopologyHandler struct {
-	topologyUC *assetuc.GetTopologyUseCase
-	chainUC    *assetuc.GetCompromiseChainUseCase
+	topologyUC    *assetuc.GetTopologyUseCase
+	chainUC       *assetuc.GetCompromiseChainUseCase
+	attackPathsUC *assetuc.GetAttackPathsUseCase
 }
 
 func NewAssetTopologyHandler(
 	topology *assetuc.GetTopologyUseCase,
 	chain *assetuc.GetCompromiseChainUseCase,
+	attackPaths *assetuc.GetAttackPathsUseCase,
 ) *AssetTopologyHandler {
-	return &AssetTopologyHandler{topologyUC: topology, chainUC: chain}
+	return &AssetTopologyHandler{topologyUC: topology, chainUC: chain, attackPathsUC: attackPaths}
 }
 
 // GetTopology returns the tenant's asset graph.
@@ -60,6 +63,32 @@ func (h *AssetTopologyHandler) GetCompromiseChain(c *fiber.Ctx) error {
 	return c.JSON(chain)
 }
 
+// GetAttackPaths returns the k cheapest paths from internet-exposed assets to
+// crown jewels, and the assets most of them go through.
+// GET /attack-surface/topology/attack-paths?k=10&max_hops=8
+func (h *AssetTopologyHandler) GetAttackPaths(c *fiber.Ctx) error {
+	k, maxHops := 0, 0
+	if raw := c.Query("k"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			return c.Status(400).JSON(fiber.Map{"error": "k must be a positive integer"})
+		}
+		k = n
+	}
+	if raw := c.Query("max_hops"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			return c.Status(400).JSON(fiber.Map{"error": "max_hops must be a positive integer"})
+		}
+		maxHops = n
+	}
+	analysis, err := h.attackPathsUC.Execute(c.UserContext(), tenantID(c), k, maxHops)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(analysis)
+}
+
 // GetEdgeTypes returns the topology edge vocabulary so the legend is served by
 // the same source that folds stored types onto it.
 // GET /attack-surface/topology/edge-types
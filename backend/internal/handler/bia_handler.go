@@ -0,0 +1,153 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	biaapp "github.com/opendefender/openrisk/internal/application/bia"
+)
+
+// BIAHandler exposes business impact analyses on business processes, the
+// recovery capabilities declared on supporting assets, and the propagation of
+// the former onto the latter.
+type BIAHandler struct {
+	svc *biaapp.Service
+}
+
+// NewBIAHandler builds the handler.
+func NewBIAHandler(svc *biaapp.Service) *BIAHandler {
+	return &BIAHandler{svc: svc}
+}
+
+func biaAssetID(c *fiber.Ctx) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Params("assetId"))
+	return id, err == nil
+}
+
+// ListAnalyses GET /bia/processes
+func (h *BIAHandler) ListAnalyses(c *fiber.Ctx) error {
+	rows, err := h.svc.ListAnalyses(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(rows)
+}
+
+// GetAnalysis GET /bia/processes/:assetId
+func (h *BIAHandler) GetAnalysis(c *fiber.Ctx) error {
+	id, ok := biaAssetID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid asset id"})
+	}
+	b, err := h.svc.GetAnalysis(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(b)
+}
+
+// SaveAnalysis PUT /bia/processes/:assetId
+func (h *BIAHandler) SaveAnalysis(c *fiber.Ctx) error {
+	id, ok := biaAssetID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid asset id"})
+	}
+	var in biaapp.AnalysisInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	b, err := h.svc.SaveAnalysis(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(b)
+}
+
+// DeleteAnalysis DELETE /bia/processes/:assetId
+func (h *BIAHandler) DeleteAnalysis(c *fiber.Ctx) error {
+	id, ok := biaAssetID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid asset id"})
+	}
+	if err := h.svc.DeleteAnalysis(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Report GET /bia/processes/:assetId/report — expected downtime against the
+// process's RTO and MTPD, the impact at that point, and every gap behind it.
+func (h *BIAHandler) Report(c *fiber.Ctx) error {
+	id, ok := biaAssetID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid asset id"})
+	}
+	rep, err := h.svc.Report(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(rep)
+}
+
+// ListCapabilities GET /bia/capabilities
+func (h *BIAHandler) ListCapabilities(c *fiber.Ctx) error {
+	rows, err := h.svc.ListCapabilities(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(rows)
+}
+
+// SaveCapability PUT /bia/capabilities/:assetId
+func (h *BIAHandler) SaveCapability(c *fiber.Ctx) error {
+	id, ok := biaAssetID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid asset id"})
+	}
+	var in biaapp.CapabilityInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	rc, err := h.svc.SaveCapability(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(rc)
+}
+
+// DeleteCapability DELETE /bia/capabilities/:assetId
+func (h *BIAHandler) DeleteCapability(c *fiber.Ctx) error {
+	id, ok := biaAssetID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid asset id"})
+	}
+	if err := h.svc.DeleteCapability(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Propagation GET /bia/propagation
+func (h *BIAHandler) Propagation(c *fiber.Ctx) error {
+	p, err := h.svc.Propagation(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(p)
+}
+
+// Sync POST /bia/sync — re-pushes every process's expected downtime onto the
+// risks of that process. Saves already do this; the endpoint is for after
+// bulk dependency or capability edits made elsewhere.
+func (h *BIAHandler) Sync(c *fiber.Ctx) error {
+	res, err := h.svc.Sync(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(res)
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/opendefender/openrisk/pkg/monitoring"
+	"strconv"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
@@ -231,9 +232,109 @@ func (h *BoardReportHandler) toReportData(ctx context.Context, br *domain.BoardR
 	if br.ApprovedBy != nil {
 		data.ApprovedBy = h.resolveUser(ctx, *br.ApprovedBy)
 	}
+	data.Appetite = toReportAppetite(br.AppetiteSnapshot)
+	data.KRIs = toReportKRIs(br.KRISnapshot)
+	data.Changes = toReportChanges(br.RegisterChanges)
 	return data
 }
 
+// toReportAppetite decodes the appetite snapshot frozen at generation time.
+// Reports generated before appetite existed, or with no statement in force,
+// carry none and render without the section.
+func toReportAppetite(raw []byte) *report.BoardAppetite {
+	if len(raw) == 0 {
+		return nil
+	}
+	var snap board.AppetiteSnapshot
+	if err := json.Unmarshal(raw, &snap); err != nil || snap.StatementsInForce == 0 {
+		return nil
+	}
+	out := &report.BoardAppetite{
+		StatementsInForce: snap.StatementsInForce,
+		RisksAbove:        snap.RisksAbove,
+		WithoutException:  snap.WithoutException,
+		PendingExceptions: snap.PendingExceptions,
+	}
+	for _, sc := range snap.BreachedScopes {
+		row := report.BoardAppetiteScope{Label: sc.ScopeLabel, Statement: sc.Statement, RisksAbove: sc.RisksAbove}
+		for _, b := range sc.Breaches {
+			value, limit := fmt.Sprintf("%.0f", b.Value), fmt.Sprintf("%.0f", b.Limit)
+			if b.Metric == domain.AppetiteMetricALEP90 {
+				value, limit = ai.FormatFCFA(int64(b.Value)), ai.FormatFCFA(int64(b.Limit))
+			}
+			row.Breaches = append(row.Breaches, report.BoardAppetiteBreach{Metric: string(b.Metric), Value: value, Limit: limit})
+		}
+		out.BreachedScopes = append(out.BreachedScopes, row)
+	}
+	for _, r := range snap.TopRisks {
+		out.TopRisks = append(out.TopRisks, report.BoardAppetiteRisk{
+			Title:           r.Title,
+			Criticality:     r.Criticality,
+			Score:           r.Score,
+			ExceptionStatus: string(r.ExceptionStatus),
+			ValidUntil:      r.ValidUntil,
+		})
+	}
+	return out
+}
+
+// toReportKRIs decodes the KRI snapshot frozen at generation time. Reports
+// generated before KRIs existed carry none and render without the section.
+func toReportKRIs(raw []byte) *report.BoardKRIs {
+	if len(raw) == 0 {
+		return nil
+	}
+	var snap board.KRISnapshot
+	if err := json.Unmarshal(raw, &snap); err != nil || len(snap.Indicators) == 0 {
+		return nil
+	}
+	out := &report.BoardKRIs{Red: snap.Red, Amber: snap.Amber, Green: snap.Green}
+	for _, k := range snap.Indicators {
+		value := ""
+		if k.Value != nil {
+			value = strings.TrimSpace(strconv.FormatFloat(*k.Value, 'f', -1, 64) + " " + k.Unit)
+		}
+		out.Indicators = append(out.Indicators, report.BoardKRI{
+			Name:   k.Name,
+			Status: string(k.Status),
+			Value:  value,
+			Points: k.Points,
+		})
+	}
+	return out
+}
+
+// toReportChanges decodes the register changes frozen at generation time.
+// Reports generated with no snapshot old enough carry none and render without
+// the section.
+func toReportChanges(raw []byte) *report.BoardRegisterChanges {
+	if len(raw) == 0 {
+		return nil
+	}
+	var snap board.RegisterChangesSnapshot
+	if err := json.Unmarshal(raw, &snap); err != nil {
+		return nil
+	}
+	conv := func(in []board.RegisterChangeRisk) []report.BoardRegisterChange {
+		out := make([]report.BoardRegisterChange, 0, len(in))
+		for _, r := range in {
+			out = append(out, report.BoardRegisterChange{Title: r.Title, Criticality: r.Criticality, FromScore: r.FromScore, ToScore: r.ToScore})
+		}
+		return out
+	}
+	return &report.BoardRegisterChanges{
+		Since:           snap.Since,
+		New:             snap.New,
+		Closed:          snap.Closed,
+		UpScored:        snap.UpScored,
+		DownScored:      snap.DownScored,
+		NewRisks:        conv(snap.NewRisks),
+		ClosedRisks:     conv(snap.ClosedRisks),
+		UpScoredRisks:   conv(snap.UpScoredRisks),
+		DownScoredRisks: conv(snap.DownScoredRisks),
+	}
+}
+
 // resolveUser best-effort resolves a user's display label; a missing user never
 // fails PDF rendering.
 func (h *BoardReportHandler) resolveUser(ctx context.Context, id uuid.UUID) string {
@@ -0,0 +1,141 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	bowtieapp "github.com/opendefender/openrisk/internal/application/bowtie"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/report"
+)
+
+// BowtieHandler exposes a risk's bowtie: the JSON graph the UI draws, its
+// edition, the PDF figure and the probability/impact suggestion.
+type BowtieHandler struct {
+	svc *bowtieapp.Service
+}
+
+// NewBowtieHandler builds the handler.
+func NewBowtieHandler(svc *bowtieapp.Service) *BowtieHandler {
+	return &BowtieHandler{svc: svc}
+}
+
+// Get GET /risks/:id/bowtie
+func (h *BowtieHandler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid risk id"})
+	}
+	g, err := h.svc.Get(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(g)
+}
+
+// Save PUT /risks/:id/bowtie — replaces the whole bowtie.
+func (h *BowtieHandler) Save(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid risk id"})
+	}
+	var in domain.Bowtie
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	g, err := h.svc.Save(c.UserContext(), tenantID(c), id, optionalActor(c), in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(g)
+}
+
+// Delete DELETE /risks/:id/bowtie
+func (h *BowtieHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid risk id"})
+	}
+	if err := h.svc.Delete(c.UserContext(), tenantID(c), id, optionalActor(c)); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(204)
+}
+
+// ApplySuggestion POST /risks/:id/bowtie/apply-suggestion
+func (h *BowtieHandler) ApplySuggestion(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid risk id"})
+	}
+	g, err := h.svc.ApplySuggestion(c.UserContext(), tenantID(c), id, optionalActor(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(g)
+}
+
+// PDF GET /risks/:id/bowtie/pdf?locale=en — the bowtie as a one-page figure.
+func (h *BowtieHandler) PDF(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid risk id"})
+	}
+	g, err := h.svc.Get(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	if g.Bowtie == nil {
+		return writeAppError(c, domain.NewNotFoundError("bowtie", id))
+	}
+	locale := report.LocaleFR
+	if c.Query("locale") == "en" {
+		locale = report.LocaleEN
+	}
+	pdf, err := report.RenderBowtiePDF(toBowtieFigure(g, locale))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "failed to render bowtie"})
+	}
+	c.Set("Content-Type", "application/pdf")
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "bowtie-"+id.String()[:8]+".pdf"))
+	return c.Send(pdf)
+}
+
+// toBowtieFigure maps the graph into the render-ready shape, walking each
+// path's barriers in drawing order.
+func toBowtieFigure(g *bowtieapp.Graph, locale report.Locale) report.BowtieFigureData {
+	b := g.Bowtie
+	health := map[string]string{}
+	for _, n := range g.Nodes {
+		health[n.ID] = string(n.Health)
+	}
+	path := func(p domain.BowtiePath) report.BowtieFigurePath {
+		fp := report.BowtieFigurePath{Label: p.Label}
+		for _, br := range b.BarriersOn(p.ID) {
+			fp.Barriers = append(fp.Barriers, report.BowtieFigureBarrier{Label: br.Label, Health: health["barrier:"+br.ID]})
+		}
+		return fp
+	}
+	data := report.BowtieFigureData{
+		Locale: locale, RiskTitle: g.RiskTitle, Hazard: b.Hazard, TopEvent: b.TopEvent,
+		GeneratedAt: time.Now(),
+		Probability: g.Suggestion.CurrentProbability, Impact: g.Suggestion.CurrentImpact,
+		SuggestedProbability: g.Suggestion.Probability, SuggestedImpact: g.Suggestion.Impact,
+		Raises: g.Suggestion.Raises,
+	}
+	for _, t := range b.Threats {
+		data.Threats = append(data.Threats, path(t))
+	}
+	for _, cq := range b.Consequences {
+		data.Consequences = append(data.Consequences, path(cq))
+	}
+	return data
+}
@@ -50,7 +50,7 @@ func setupComplianceSchema(t *testing.T) *gorm.DB {
 	`).Error)
 	require.NoError(t, db.Exec(`
 		CREATE TABLE compliance_controls (
-			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, framework_id TEXT NOT NULL,
+			id TEXT PRIMARY KEY, external_id TEXT, tenant_id TEXT NOT NULL, framework_id TEXT NOT NULL,
 			reference_code TEXT NOT NULL DEFAULT '', name TEXT NOT NULL, description TEXT,
 			source_reference TEXT NOT NULL DEFAULT '',
 			status TEXT NOT NULL DEFAULT 'not_implemented',
@@ -0,0 +1,135 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	controltestapp "github.com/opendefender/openrisk/internal/application/controltest"
+)
+
+// ControlTestHandler exposes control test plans, test results and the residual
+// each risk derives from them.
+type ControlTestHandler struct {
+	svc *controltestapp.Service
+}
+
+// NewControlTestHandler builds the handler.
+func NewControlTestHandler(svc *controltestapp.Service) *ControlTestHandler {
+	return &ControlTestHandler{svc: svc}
+}
+
+// ControlTesting GET /compliance/controls/:controlId/testing
+func (h *ControlTestHandler) ControlTesting(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("controlId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid control id"})
+	}
+	out, err := h.svc.ControlTesting(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(out)
+}
+
+// CreatePlan POST /compliance/controls/:controlId/test-plans
+func (h *ControlTestHandler) CreatePlan(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("controlId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid control id"})
+	}
+	var in controltestapp.PlanInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	p, err := h.svc.CreatePlan(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(p)
+}
+
+// DuePlans GET /control-test-plans/due — the testers' worklist.
+func (h *ControlTestHandler) DuePlans(c *fiber.Ctx) error {
+	plans, err := h.svc.DuePlans(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(plans)
+}
+
+// UpdatePlan PUT /control-test-plans/:id
+func (h *ControlTestHandler) UpdatePlan(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid plan id"})
+	}
+	var in controltestapp.PlanInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	p, err := h.svc.UpdatePlan(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(p)
+}
+
+// DeletePlan DELETE /control-test-plans/:id
+func (h *ControlTestHandler) DeletePlan(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid plan id"})
+	}
+	if err := h.svc.DeletePlan(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// RecordTest POST /control-test-plans/:id/tests
+func (h *ControlTestHandler) RecordTest(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid plan id"})
+	}
+	var in controltestapp.TestInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	out, err := h.svc.RecordTest(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(out)
+}
+
+// Residual GET /risks/:id/residual — the derivation, without writing it.
+func (h *ControlTestHandler) Residual(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid risk id"})
+	}
+	d, err := h.svc.Residual(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(d)
+}
+
+// ApplyResidual POST /risks/:id/residual — derive and write.
+func (h *ControlTestHandler) ApplyResidual(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid risk id"})
+	}
+	d, err := h.svc.ApplyResidual(c.UserContext(), tenantID(c), optionalActor(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(d)
+}
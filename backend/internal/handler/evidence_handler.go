@@ -7,6 +7,8 @@ package handler
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -152,7 +154,7 @@ func (h *EvidenceHandler) Create(c *fiber.Ctx) error {
 		in.ControlIDs = ids
 	}
 
-	ev, err := h.svc.Create(c.UserContext(), tenantID(c), in)
+	ev, err := h.svc.Create(govCtx(c), tenantID(c), in)
 	if err != nil {
 		return writeAppError(c, err)
 	}
@@ -192,7 +194,7 @@ func (h *EvidenceHandler) CreateForControl(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	ev, err := h.svc.Create(c.UserContext(), tenantID(c), evidence.CreateInput{
+	ev, err := h.svc.Create(govCtx(c), tenantID(c), evidence.CreateInput{
 		Title:       c.FormValue("title"),
 		Type:        c.FormValue("type"),
 		Description: c.FormValue("description"),
@@ -262,7 +264,7 @@ func (h *EvidenceHandler) Get(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(400).JSON(fiber.Map{"error": "invalid evidence id"})
 	}
-	ev, err := h.svc.Get(c.UserContext(), tenantID(c), id)
+	ev, err := h.svc.Get(govCtx(c), tenantID(c), id)
 	if err != nil {
 		return writeAppError(c, err)
 	}
@@ -331,7 +333,7 @@ func (h *EvidenceHandler) Review(c *fiber.Ctx) error {
 	if err := c.BodyParser(&body); err != nil {
 		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
 	}
-	ev, err := h.svc.Review(c.UserContext(), tenantID(c), id, userID(c), body.Review, body.Note)
+	ev, err := h.svc.Review(govCtx(c), tenantID(c), id, userID(c), body.Review, body.Note)
 	if err != nil {
 		return writeAppError(c, err)
 	}
@@ -395,7 +397,7 @@ func (h *EvidenceHandler) Download(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(400).JSON(fiber.Map{"error": "invalid evidence id"})
 	}
-	ev, content, err := h.svc.Download(c.UserContext(), tenantID(c), id)
+	ev, content, err := h.svc.Download(govCtx(c), tenantID(c), id)
 	if err != nil {
 		return writeAppError(c, err)
 	}
@@ -410,6 +412,21 @@ func (h *EvidenceHandler) Download(c *fiber.Ctx) error {
 	return c.SendStream(content)
 }
 
+// DownloadLink mints a short-lived direct URL to the bytes, so large files are
+// served by the object store rather than proxied through the API. 409 when the
+// deployment cannot presign; the client then uses Download.
+func (h *EvidenceHandler) DownloadLink(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("evidenceId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid evidence id"})
+	}
+	url, expiresAt, err := h.svc.DownloadLink(govCtx(c), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"url": url, "expires_at": expiresAt})
+}
+
 // ListByControl returns the artifacts attached to one control.
 func (h *EvidenceHandler) ListByControl(c *fiber.Ctx) error {
 	controlID, err := uuid.Parse(c.Params("controlId"))
@@ -440,3 +457,118 @@ func (h *EvidenceHandler) Missing(c *fiber.Ctx) error {
 	}
 	return c.JSON(fiber.Map{"frameworks": cov})
 }
+
+// Replace uploads a new version of an artifact's file (multipart: file, and an
+// optional valid_until for the new version). The custody log keeps the digest
+// of the one it replaced.
+func (h *EvidenceHandler) Replace(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("evidenceId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid evidence id"})
+	}
+	fh, err := c.FormFile("file")
+	if err != nil || fh == nil {
+		return c.Status(400).JSON(fiber.Map{"error": "a file is required"})
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "failed to read uploaded file"})
+	}
+	defer f.Close()
+	validUntil, err := parseDate(c.FormValue("valid_until"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ev, err := h.svc.Replace(govCtx(c), tenantID(c), id, evidence.ReplaceInput{
+		Filename: fh.Filename, Content: f, ValidUntil: validUntil,
+	})
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(ev)
+}
+
+// Verify re-hashes the stored file against the digest recorded at upload.
+func (h *EvidenceHandler) Verify(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("evidenceId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid evidence id"})
+	}
+	res, err := h.svc.Verify(govCtx(c), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(res)
+}
+
+// Custody returns the artifact's chain of custody, oldest first.
+func (h *EvidenceHandler) Custody(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("evidenceId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid evidence id"})
+	}
+	rows, err := h.svc.Custody(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": rows})
+}
+
+// Package builds a signed evidence package (zip) for a framework or an audit.
+//
+// The archive is assembled in a temporary file and only sent once complete: a
+// package that fails part-way (a file that no longer matches its digest) must
+// produce an error, not a truncated zip that looks like a smaller package.
+func (h *EvidenceHandler) Package(c *fiber.Ctx) error {
+	var body struct {
+		FrameworkID string `json:"framework_id"`
+		AuditID     string `json:"audit_id"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	var scope evidence.PackageScope
+	if body.FrameworkID != "" {
+		id, err := uuid.Parse(body.FrameworkID)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid framework id"})
+		}
+		scope.FrameworkID = &id
+	}
+	if body.AuditID != "" {
+		id, err := uuid.Parse(body.AuditID)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid audit id"})
+		}
+		scope.AuditID = &id
+	}
+
+	tmp, err := os.CreateTemp("", "evidence-package-*.zip")
+	if err != nil {
+		return writeAppError(c, domain.NewInternalError("failed to stage package: "+err.Error()))
+	}
+	m, err := h.svc.BuildPackage(govCtx(c), tenantID(c), scope, tmp)
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return writeAppError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="evidence-package-%s.zip"`,
+		m.GeneratedAt.Format("20060102-150405")))
+	c.Set(fiber.HeaderContentType, "application/zip")
+	// Closed (and removed) by fasthttp once the body has been written.
+	return c.SendStream(&removeOnClose{File: tmp})
+}
+
+// removeOnClose deletes a staged temp file once the response has consumed it.
+type removeOnClose struct{ *os.File }
+
+func (r *removeOnClose) Close() error {
+	err := r.File.Close()
+	os.Remove(r.File.Name())
+	return err
+}
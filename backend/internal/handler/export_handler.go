@@ -13,6 +13,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/opendefender/openrisk/internal/domain"
 	"github.com/opendefender/openrisk/internal/infrastructure/database"
+	"github.com/opendefender/openrisk/internal/infrastructure/repository"
 )
 
 // ExportRisksPDF génère un rapport PDF de tous les risques actifs.
@@ -22,7 +23,11 @@ func ExportRisksPDF(c *fiber.Ctx) error {
 	tenantID := safeGetUUID(c, "tenant_id")
 
 	// 1. Récupérer les données
-	if err := database.DB.Where("deleted_at IS NULL AND tenant_id = ?", tenantID).Preload("Assets").Find(&risks).Error; err != nil {
+	// Access policies apply to the report like to the register: it lists only
+	// the risks its reader can see.
+	if err := database.DB.WithContext(c.UserContext()).
+		Scopes(repository.AccessScoped(c.UserContext(), domain.AccessResourceRisk)).
+		Where("deleted_at IS NULL AND tenant_id = ?", tenantID).Preload("Assets").Find(&risks).Error; err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch risks for export"})
 	}
 
@@ -38,6 +38,12 @@ type GovernanceHandler struct {
 	setRetention *governance.SetRetentionPolicyUseCase
 	pruneAudit   *governance.PruneAuditTrailUseCase
 
+	// External anchoring. Nil without EXPORT_SIGNING_KEY: a checkpoint nobody
+	// can verify is not offered.
+	checkpointAudit  *governance.CheckpointAuditChainUseCase
+	listCheckpoints  *governance.ListAuditCheckpointsUseCase
+	exportAuditRange *governance.ExportAuditSegmentUseCase
+
 	createDelegation *governance.CreateDelegationUseCase
 	listDelegations  *governance.ListDelegationsUseCase
 	revokeDelegation *governance.RevokeDelegationUseCase
@@ -67,6 +73,10 @@ type GovernanceDeps struct {
 	SetRetention *governance.SetRetentionPolicyUseCase
 	PruneAudit   *governance.PruneAuditTrailUseCase
 
+	CheckpointAudit    *governance.CheckpointAuditChainUseCase
+	ListCheckpoints    *governance.ListAuditCheckpointsUseCase
+	ExportAuditSegment *governance.ExportAuditSegmentUseCase
+
 	CreateDelegation *governance.CreateDelegationUseCase
 	ListDelegations  *governance.ListDelegationsUseCase
 	RevokeDelegation *governance.RevokeDelegationUseCase
@@ -94,6 +104,9 @@ func NewGovernanceHandler(d GovernanceDeps) *GovernanceHandler {
 		getRetention:     d.GetRetention,
 		setRetention:     d.SetRetention,
 		pruneAudit:       d.PruneAudit,
+		checkpointAudit:  d.CheckpointAudit,
+		listCheckpoints:  d.ListCheckpoints,
+		exportAuditRange: d.ExportAuditSegment,
 		createDelegation: d.CreateDelegation,
 		listDelegations:  d.ListDelegations,
 		revokeDelegation: d.RevokeDelegation,
@@ -377,6 +390,89 @@ func (h *GovernanceHandler) RunAuditRetention(c *fiber.Ctx) error {
 	return c.JSON(res)
 }
 
+// ListAuditCheckpoints GET /governance/audit-checkpoints — the latest signed
+// chain heads, newest first.
+func (h *GovernanceHandler) ListAuditCheckpoints(c *fiber.Ctx) error {
+	if h.listCheckpoints == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "audit checkpoints are not available on this deployment (EXPORT_SIGNING_KEY is not set)"})
+	}
+	items, err := h.listCheckpoints.Execute(c.UserContext(), tenantID(c), c.QueryInt("limit", 50))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	if items == nil {
+		items = []domain.AuditCheckpoint{}
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// CreateAuditCheckpoint POST /governance/audit-checkpoints — signs the current
+// head now instead of waiting for the hourly sweep. 200 with the existing
+// checkpoint when the head has not moved.
+func (h *GovernanceHandler) CreateAuditCheckpoint(c *fiber.Ctx) error {
+	if h.checkpointAudit == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "audit checkpoints are not available on this deployment (EXPORT_SIGNING_KEY is not set)"})
+	}
+	cp, created, err := h.checkpointAudit.Execute(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	if created {
+		return c.Status(201).JSON(cp)
+	}
+	return c.JSON(cp)
+}
+
+// AuditCheckpointPublicKey GET /governance/audit-checkpoints/public-key — the
+// PEM checkpoints verify against. Publish it somewhere this deployment's
+// operators cannot edit; a verifier should never take it from the segment.
+func (h *GovernanceHandler) AuditCheckpointPublicKey(c *fiber.Ctx) error {
+	if h.checkpointAudit == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "audit checkpoints are not available on this deployment (EXPORT_SIGNING_KEY is not set)"})
+	}
+	k := h.checkpointAudit.PublicKey()
+	c.Set("X-OpenRisk-Key-Id", k.KeyID)
+	c.Set("Content-Type", "application/x-pem-file")
+	return c.SendString(k.PEM)
+}
+
+// ExportAuditSegment GET /governance/audit-events/segment?from=&to= — a chain
+// segment with its seals and checkpoints, for openrisk-verify.
+func (h *GovernanceHandler) ExportAuditSegment(c *fiber.Ctx) error {
+	if h.exportAuditRange == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "audit segment export is not available on this deployment (EXPORT_SIGNING_KEY is not set)"})
+	}
+	var from, to int64
+	for name, dst := range map[string]*int64{"from": &from, "to": &to} {
+		if raw := c.Query(name); raw != "" {
+			v, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				return c.Status(400).JSON(fiber.Map{"error": name + " must be a sequence number"})
+			}
+			*dst = v
+		}
+	}
+	seg, err := h.exportAuditRange.Execute(c.UserContext(), tenantID(c), from, to)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	if h.recorder != nil {
+		uid := userID(c)
+		h.recorder.Record(govCtx(c), domain.AuditEvent{
+			TenantID:   tenantID(c),
+			ActorID:    &uid,
+			Action:     domain.AuditActionExport,
+			EntityType: "audit_events",
+			EntityID:   "-",
+			Summary: "exported audit segment " + strconv.FormatInt(seg.FromSequence, 10) + "–" + strconv.FormatInt(seg.ToSequence, 10) +
+				" (" + strconv.Itoa(len(seg.Checkpoints)) + " checkpoints)",
+		})
+	}
+	c.Set("Content-Disposition", "attachment; filename=audit-segment-"+
+		strconv.FormatInt(seg.FromSequence, 10)+"-"+strconv.FormatInt(seg.ToSequence, 10)+".json")
+	return c.JSON(seg)
+}
+
 func validLabel(ok bool) string {
 	if ok {
 		return "true"
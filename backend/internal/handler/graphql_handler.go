@@ -0,0 +1,71 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/opendefender/openrisk/internal/application/graphapi"
+	"github.com/opendefender/openrisk/internal/middleware"
+)
+
+// GraphQLHandler serves the read-only GraphQL API (docs/GRAPHQL.md). The
+// route itself only requires a session: permissions are checked per field.
+type GraphQLHandler struct {
+	svc *graphapi.Service
+}
+
+// NewGraphQLHandler builds the handler.
+func NewGraphQLHandler(svc *graphapi.Service) *GraphQLHandler {
+	return &GraphQLHandler{svc: svc}
+}
+
+// Query POST /graphql — {"query", "operationName", "variables", "extensions"}.
+// A query the API rejects (invalid, too costly, unknown persisted hash) is
+// still a 200 with `errors`, as GraphQL clients expect; only a body that is
+// not a GraphQL request is a 400.
+func (h *GraphQLHandler) Query(c *fiber.Ctx) error {
+	var req graphapi.Params
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid GraphQL request body"})
+	}
+	return c.JSON(h.svc.Execute(c.UserContext(), h.viewer(c), req))
+}
+
+// QueryGet GET /graphql?query=...&operationName=...&variables=...&extensions=...
+//
+// The form persisted queries are sent in, so a CDN or browser cache can key
+// on the URL; variables and extensions are JSON-encoded parameters.
+func (h *GraphQLHandler) QueryGet(c *fiber.Ctx) error {
+	req := graphapi.Params{Query: c.Query("query"), OperationName: c.Query("operationName")}
+	if v := c.Query("variables"); v != "" {
+		if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "variables must be a JSON object"})
+		}
+	}
+	if v := c.Query("extensions"); v != "" {
+		if err := json.Unmarshal([]byte(v), &req.Extensions); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "extensions must be a JSON object"})
+		}
+	}
+	return c.JSON(h.svc.Execute(c.UserContext(), h.viewer(c), req))
+}
+
+// Schema GET /graphql/schema — the SDL, for client code generation.
+func (h *GraphQLHandler) Schema(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, "application/graphql; charset=utf-8")
+	return c.SendString(h.svc.SDL())
+}
+
+func (h *GraphQLHandler) viewer(c *fiber.Ctx) graphapi.Viewer {
+	return graphapi.Viewer{
+		TenantID: tenantID(c),
+		UserID:   optionalActor(c),
+		Can:      func(p string) bool { return middleware.HasPermission(c, p) },
+	}
+}
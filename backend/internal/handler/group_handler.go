@@ -0,0 +1,153 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	groupapp "github.com/opendefender/openrisk/internal/application/group"
+)
+
+// GroupHandler exposes the organization hierarchy: the links between a
+// holding and its subsidiaries, and the read-only roll-up of their registers.
+// Every route reads from the caller's current organization — the group is
+// always the one below it.
+type GroupHandler struct {
+	svc *groupapp.Service
+}
+
+// NewGroupHandler builds the handler.
+func NewGroupHandler(svc *groupapp.Service) *GroupHandler {
+	return &GroupHandler{svc: svc}
+}
+
+// ListLinks GET /group/links — both directions: subsidiaries asked for or
+// linked, and the parent this organization was asked to join.
+func (h *GroupHandler) ListLinks(c *fiber.Ctx) error {
+	links, err := h.svc.Links(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": links})
+}
+
+// RequestLink POST /group/links — asks an organization to become a subsidiary.
+func (h *GroupHandler) RequestLink(c *fiber.Ctx) error {
+	var in groupapp.LinkRequest
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	l, err := h.svc.RequestLink(c.UserContext(), tenantID(c), optionalActor(c), in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(l)
+}
+
+// AcceptLink POST /group/links/:id/accept — the subsidiary's consent.
+func (h *GroupHandler) AcceptLink(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid link id"})
+	}
+	l, err := h.svc.AcceptLink(c.UserContext(), tenantID(c), id, optionalActor(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(l)
+}
+
+// RevokeLink DELETE /group/links/:id — either side ends the link.
+func (h *GroupHandler) RevokeLink(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid link id"})
+	}
+	l, err := h.svc.RevokeLink(c.UserContext(), tenantID(c), id, optionalActor(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(l)
+}
+
+// Entities GET /group/entities
+func (h *GroupHandler) Entities(c *fiber.Ctx) error {
+	entities, err := h.svc.Entities(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": entities})
+}
+
+// Heatmap GET /group/heatmap
+func (h *GroupHandler) Heatmap(c *fiber.Ctx) error {
+	m, err := h.svc.Heatmap(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(m)
+}
+
+// Categories GET /group/categories
+func (h *GroupHandler) Categories(c *fiber.Ctx) error {
+	totals, err := h.svc.Categories(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": totals})
+}
+
+// MonteCarlo GET /group/monte-carlo?iterations=&seed=
+func (h *GroupHandler) MonteCarlo(c *fiber.Ctx) error {
+	var seed int64
+	if raw := c.Query("seed"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid seed"})
+		}
+		seed = v
+	}
+	sim, err := h.svc.MonteCarlo(c.UserContext(), tenantID(c), c.QueryInt("iterations"), seed)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(sim)
+}
+
+// Compliance GET /group/compliance
+func (h *GroupHandler) Compliance(c *fiber.Ctx) error {
+	postures, err := h.svc.Compliance(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": postures})
+}
+
+// Risks GET /group/risks?org_id=&probability=&impact=&category=&limit= — the
+// drill-down behind a heatmap cell, a category or an entity.
+func (h *GroupHandler) Risks(c *fiber.Ctx) error {
+	f := groupapp.RiskFilter{
+		Probability: c.QueryInt("probability"),
+		Impact:      c.QueryInt("impact"),
+		Category:    c.Query("category"),
+		Limit:       c.QueryInt("limit"),
+	}
+	if raw := c.Query("org_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid org_id"})
+		}
+		f.OrgID = &id
+	}
+	risks, err := h.svc.Risks(c.UserContext(), tenantID(c), f)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": risks})
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/application/dataimport"
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// ImportHandler exposes the import wizard: upload, mapping, dry run, apply
+// and rollback of batches of risks, assets, controls and mitigations, and the
+// saved mapping profiles.
+type ImportHandler struct {
+	svc *dataimport.Service
+}
+
+// NewImportHandler builds the handler.
+func NewImportHandler(svc *dataimport.Service) *ImportHandler {
+	return &ImportHandler{svc: svc}
+}
+
+// List GET /imports
+func (h *ImportHandler) List(c *fiber.Ctx) error {
+	items, err := h.svc.List(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// Targets GET /imports/targets?entity=risk — the fields a column can map to.
+func (h *ImportHandler) Targets(c *fiber.Ctx) error {
+	items, err := h.svc.Targets(c.UserContext(), tenantID(c), domain.ImportEntity(c.Query("entity")))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// Upload POST /imports — multipart "file", with "entity" and an optional
+// "format" (csv, json or xlsx; the extension decides otherwise).
+func (h *ImportHandler) Upload(c *fiber.Ctx) error {
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "file is required"})
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "failed to read uploaded file"})
+	}
+	defer f.Close()
+	in := dataimport.UploadInput{
+		Entity:   domain.ImportEntity(c.FormValue("entity")),
+		FileName: fh.Filename,
+		Format:   c.FormValue("format"),
+	}
+	res, err := h.svc.Upload(c.UserContext(), tenantID(c), optionalActor(c), in, f)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(res)
+}
+
+// Get GET /imports/:id
+func (h *ImportHandler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid import id"})
+	}
+	res, err := h.svc.Get(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(res)
+}
+
+// Delete DELETE /imports/:id
+func (h *ImportHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid import id"})
+	}
+	if err := h.svc.Delete(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// DryRun POST /imports/:id/dry-run
+func (h *ImportHandler) DryRun(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid import id"})
+	}
+	var in dataimport.MappingInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	res, err := h.svc.DryRun(c.UserContext(), tenantID(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(res)
+}
+
+// Apply POST /imports/:id/apply
+func (h *ImportHandler) Apply(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid import id"})
+	}
+	var in dataimport.ApplyInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	res, err := h.svc.Apply(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(res)
+}
+
+// Rollback POST /imports/:id/rollback[?force=true]
+func (h *ImportHandler) Rollback(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid import id"})
+	}
+	res, err := h.svc.Rollback(c.UserContext(), tenantID(c), optionalActor(c), id, c.Query("force") == "true")
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(res)
+}
+
+// ListProfiles GET /import-profiles[?entity=risk]
+func (h *ImportHandler) ListProfiles(c *fiber.Ctx) error {
+	items, err := h.svc.ListProfiles(c.UserContext(), tenantID(c), domain.ImportEntity(c.Query("entity")))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// CreateProfile POST /import-profiles
+func (h *ImportHandler) CreateProfile(c *fiber.Ctx) error {
+	var in dataimport.ProfileInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	p, err := h.svc.SaveProfile(c.UserContext(), tenantID(c), optionalActor(c), nil, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(p)
+}
+
+// UpdateProfile PUT /import-profiles/:id
+func (h *ImportHandler) UpdateProfile(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid profile id"})
+	}
+	var in dataimport.ProfileInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	p, err := h.svc.SaveProfile(c.UserContext(), tenantID(c), optionalActor(c), &id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(p)
+}
+
+// DeleteProfile DELETE /import-profiles/:id
+func (h *ImportHandler) DeleteProfile(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid profile id"})
+	}
+	if err := h.svc.DeleteProfile(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
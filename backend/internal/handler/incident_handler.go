@@ -24,6 +24,8 @@ type IncidentHandler struct {
 	incidentService *service.IncidentService
 	// postMortems backs the structured review. Optional.
 	postMortems *appinc.PostMortemService
+	// regulatory backs the breach-notification clocks. Optional.
+	regulatory *appinc.RegulatoryService
 }
 
 // NewIncidentHandler creates a new incident handler
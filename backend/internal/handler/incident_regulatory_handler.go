@@ -0,0 +1,309 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	appinc "github.com/opendefender/openrisk/internal/application/incident"
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// ---------------------------------------------------------------------------
+// Regulatory breach notification: classifying an incident against GDPR, NIS2
+// and DORA, the clocks that starts, the pre-filled notifications and the record
+// of what was sent.
+// ---------------------------------------------------------------------------
+
+// WithRegulatory attaches the notification-clock service. Optional: without it
+// the regulatory endpoints answer 503.
+func (h *IncidentHandler) WithRegulatory(s *appinc.RegulatoryService) *IncidentHandler {
+	h.regulatory = s
+	return h
+}
+
+func (h *IncidentHandler) regulatoryUnavailable(c *fiber.Ctx) error {
+	return c.Status(503).JSON(fiber.Map{"error": "regulatory notification tracking is not available on this deployment"})
+}
+
+// regulatoryTenant resolves the tenant for the incident-less regulatory routes.
+func regulatoryTenant(c *fiber.Ctx) (uuid.UUID, error) {
+	tenantID, err := uuid.Parse(strings.TrimSpace(safeGetString(c, "tenant_id")))
+	if err != nil {
+		return uuid.Nil, c.Status(401).JSON(fiber.Map{"error": "no tenant in context"})
+	}
+	return tenantID, nil
+}
+
+func optionalActor(c *fiber.Ctx) *uuid.UUID {
+	if uid := userID(c); uid != uuid.Nil {
+		return &uid
+	}
+	return nil
+}
+
+// ListRegulatoryRegimes GET /incidents/regulatory/regimes
+//
+// The built-in regimes with their stages and deadlines, so the settings page
+// shows what each profile will start without duplicating the law in the UI.
+func (h *IncidentHandler) ListRegulatoryRegimes(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"items": domain.RegulatoryRegimes()})
+}
+
+// ListRegulatoryProfiles GET /incidents/regulatory/profiles
+func (h *IncidentHandler) ListRegulatoryProfiles(c *fiber.Ctx) error {
+	if h.regulatory == nil {
+		return h.regulatoryUnavailable(c)
+	}
+	tenantID, err := regulatoryTenant(c)
+	if err != nil {
+		return err
+	}
+	rows, uerr := h.regulatory.Profiles(c.UserContext(), tenantID)
+	if uerr != nil {
+		return writeAppError(c, uerr)
+	}
+	return c.JSON(fiber.Map{"items": rows})
+}
+
+type regulatoryProfileBody struct {
+	Regime           string                    `json:"regime"`
+	Jurisdiction     string                    `json:"jurisdiction"`
+	Authority        string                    `json:"authority"`
+	AuthorityContact string                    `json:"authority_contact"`
+	ContactPoint     string                    `json:"contact_point"`
+	Criteria         domain.RegulatoryCriteria `json:"criteria"`
+	EscalateChannels []string                  `json:"escalate_channels"`
+	EscalateToRole   string                    `json:"escalate_to_role"`
+	Enabled          *bool                     `json:"enabled"`
+}
+
+func (b regulatoryProfileBody) input() appinc.ProfileInput {
+	enabled := true
+	if b.Enabled != nil {
+		enabled = *b.Enabled
+	}
+	return appinc.ProfileInput{
+		Regime:           b.Regime,
+		Jurisdiction:     b.Jurisdiction,
+		Authority:        b.Authority,
+		AuthorityContact: b.AuthorityContact,
+		ContactPoint:     b.ContactPoint,
+		Criteria:         b.Criteria,
+		EscalateChannels: b.EscalateChannels,
+		EscalateToRole:   b.EscalateToRole,
+		Enabled:          enabled,
+	}
+}
+
+// CreateRegulatoryProfile POST /incidents/regulatory/profiles
+func (h *IncidentHandler) CreateRegulatoryProfile(c *fiber.Ctx) error {
+	if h.regulatory == nil {
+		return h.regulatoryUnavailable(c)
+	}
+	tenantID, err := regulatoryTenant(c)
+	if err != nil {
+		return err
+	}
+	var body regulatoryProfileBody
+	if perr := c.BodyParser(&body); perr != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": perr.Error()})
+	}
+	p, uerr := h.regulatory.SaveProfile(c.UserContext(), tenantID, nil, body.input())
+	if uerr != nil {
+		return writeAppError(c, uerr)
+	}
+	return c.Status(201).JSON(p)
+}
+
+// UpdateRegulatoryProfile PUT /incidents/regulatory/profiles/:profileId
+func (h *IncidentHandler) UpdateRegulatoryProfile(c *fiber.Ctx) error {
+	if h.regulatory == nil {
+		return h.regulatoryUnavailable(c)
+	}
+	tenantID, err := regulatoryTenant(c)
+	if err != nil {
+		return err
+	}
+	id, perr := uuid.Parse(c.Params("profileId"))
+	if perr != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid profile id"})
+	}
+	var body regulatoryProfileBody
+	if perr := c.BodyParser(&body); perr != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": perr.Error()})
+	}
+	p, uerr := h.regulatory.SaveProfile(c.UserContext(), tenantID, &id, body.input())
+	if uerr != nil {
+		return writeAppError(c, uerr)
+	}
+	return c.JSON(p)
+}
+
+// DeleteRegulatoryProfile DELETE /incidents/regulatory/profiles/:profileId
+func (h *IncidentHandler) DeleteRegulatoryProfile(c *fiber.Ctx) error {
+	if h.regulatory == nil {
+		return h.regulatoryUnavailable(c)
+	}
+	tenantID, err := regulatoryTenant(c)
+	if err != nil {
+		return err
+	}
+	id, perr := uuid.Parse(c.Params("profileId"))
+	if perr != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid profile id"})
+	}
+	if uerr := h.regulatory.DeleteProfile(c.UserContext(), tenantID, id); uerr != nil {
+		return writeAppError(c, uerr)
+	}
+	return c.SendStatus(204)
+}
+
+// ListOpenRegulatoryClocks GET /incidents/regulatory/clocks
+//
+// Every pending notification across the tenant's incidents, soonest first.
+func (h *IncidentHandler) ListOpenRegulatoryClocks(c *fiber.Ctx) error {
+	if h.regulatory == nil {
+		return h.regulatoryUnavailable(c)
+	}
+	tenantID, err := regulatoryTenant(c)
+	if err != nil {
+		return err
+	}
+	rows, uerr := h.regulatory.OpenClocks(c.UserContext(), tenantID)
+	if uerr != nil {
+		return writeAppError(c, uerr)
+	}
+	return c.JSON(fiber.Map{"items": rows})
+}
+
+// GetRegulatoryStatus GET /incidents/:id/regulatory
+func (h *IncidentHandler) GetRegulatoryStatus(c *fiber.Ctx) error {
+	if h.regulatory == nil {
+		return h.regulatoryUnavailable(c)
+	}
+	tenantID, incidentID, err := h.reviewTarget(c)
+	if err != nil {
+		return err
+	}
+	st, uerr := h.regulatory.Status(c.UserContext(), tenantID, incidentID)
+	if uerr != nil {
+		return writeAppError(c, uerr)
+	}
+	return c.JSON(st)
+}
+
+type classifyBody struct {
+	PersonalData     bool       `json:"personal_data"`
+	Significant      bool       `json:"significant"`
+	Major            bool       `json:"major"`
+	AffectedSubjects int        `json:"affected_subjects"`
+	DataCategories   []string   `json:"data_categories"`
+	Jurisdictions    []string   `json:"jurisdictions"`
+	CrossBorder      bool       `json:"cross_border"`
+	Malicious        bool       `json:"suspected_malicious"`
+	Consequences     string     `json:"likely_consequences"`
+	Notes            string     `json:"notes"`
+	AwareAt          *time.Time `json:"aware_at"`
+}
+
+// ClassifyRegulatory PUT /incidents/:id/regulatory
+//
+// Records the classification and answers with the clocks it started, re-timed
+// or withdrew, plus the per-profile reasoning.
+func (h *IncidentHandler) ClassifyRegulatory(c *fiber.Ctx) error {
+	if h.regulatory == nil {
+		return h.regulatoryUnavailable(c)
+	}
+	tenantID, incidentID, err := h.reviewTarget(c)
+	if err != nil {
+		return err
+	}
+	var body classifyBody
+	if perr := c.BodyParser(&body); perr != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": perr.Error()})
+	}
+	st, uerr := h.regulatory.Classify(govCtx(c), tenantID, incidentID, appinc.ClassifyInput{
+		PersonalData:     body.PersonalData,
+		Significant:      body.Significant,
+		Major:            body.Major,
+		AffectedSubjects: body.AffectedSubjects,
+		DataCategories:   body.DataCategories,
+		Jurisdictions:    body.Jurisdictions,
+		CrossBorder:      body.CrossBorder,
+		Malicious:        body.Malicious,
+		Consequences:     body.Consequences,
+		Notes:            body.Notes,
+		AwareAt:          body.AwareAt,
+	}, optionalActor(c))
+	if uerr != nil {
+		return writeAppError(c, uerr)
+	}
+	return c.JSON(st)
+}
+
+// GetRegulatoryDraft GET /incidents/regulatory/clocks/:clockId/draft
+func (h *IncidentHandler) GetRegulatoryDraft(c *fiber.Ctx) error {
+	if h.regulatory == nil {
+		return h.regulatoryUnavailable(c)
+	}
+	tenantID, err := regulatoryTenant(c)
+	if err != nil {
+		return err
+	}
+	id, perr := uuid.Parse(c.Params("clockId"))
+	if perr != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid clock id"})
+	}
+	d, uerr := h.regulatory.Draft(c.UserContext(), tenantID, id)
+	if uerr != nil {
+		return writeAppError(c, uerr)
+	}
+	return c.JSON(d)
+}
+
+type submitNotificationBody struct {
+	Reference   string            `json:"reference"`
+	SubmittedAt *time.Time        `json:"submitted_at"`
+	EvidenceID  *uuid.UUID        `json:"evidence_id"`
+	Content     map[string]string `json:"content"`
+}
+
+// SubmitRegulatoryNotification POST /incidents/regulatory/clocks/:clockId/submit
+//
+// Stops a clock with the proof of submission: the authority's reference and/or
+// the receipt, uploaded to the evidence library first.
+func (h *IncidentHandler) SubmitRegulatoryNotification(c *fiber.Ctx) error {
+	if h.regulatory == nil {
+		return h.regulatoryUnavailable(c)
+	}
+	tenantID, err := regulatoryTenant(c)
+	if err != nil {
+		return err
+	}
+	id, perr := uuid.Parse(c.Params("clockId"))
+	if perr != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid clock id"})
+	}
+	var body submitNotificationBody
+	if perr := c.BodyParser(&body); perr != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": perr.Error()})
+	}
+	v, uerr := h.regulatory.Submit(govCtx(c), tenantID, id, appinc.SubmitInput{
+		Reference:   body.Reference,
+		SubmittedAt: body.SubmittedAt,
+		EvidenceID:  body.EvidenceID,
+		Content:     body.Content,
+	}, optionalActor(c))
+	if uerr != nil {
+		return writeAppError(c, uerr)
+	}
+	return c.JSON(v)
+}
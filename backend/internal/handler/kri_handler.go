@@ -0,0 +1,157 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	kriapp "github.com/opendefender/openrisk/internal/application/kri"
+)
+
+// KRIHandler exposes key risk indicators: their definitions, the values fed
+// into them, and their trends.
+type KRIHandler struct {
+	svc *kriapp.Service
+}
+
+// NewKRIHandler builds the handler.
+func NewKRIHandler(svc *kriapp.Service) *KRIHandler {
+	return &KRIHandler{svc: svc}
+}
+
+// List GET /kris — every KRI with its recent values (?days=, default 90), red
+// first.
+func (h *KRIHandler) List(c *fiber.Ctx) error {
+	rows, err := h.svc.Trends(c.UserContext(), tenantID(c), nil, c.QueryInt("days"))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(rows)
+}
+
+// ListForRisk GET /risks/:id/kris — the KRIs watching one risk, for the risk
+// drawer's sparklines.
+func (h *KRIHandler) ListForRisk(c *fiber.Ctx) error {
+	riskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid risk id"})
+	}
+	rows, err := h.svc.Trends(c.UserContext(), tenantID(c), &riskID, c.QueryInt("days"))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(rows)
+}
+
+// Get GET /kris/:id
+func (h *KRIHandler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid kri id"})
+	}
+	k, err := h.svc.Get(c.UserContext(), tenantID(c), id, c.QueryInt("days"))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(k)
+}
+
+// Create POST /kris
+func (h *KRIHandler) Create(c *fiber.Ctx) error {
+	var in kriapp.Input
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	k, err := h.svc.Save(c.UserContext(), tenantID(c), optionalActor(c), nil, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(k)
+}
+
+// Update PUT /kris/:id
+func (h *KRIHandler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid kri id"})
+	}
+	var in kriapp.Input
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	k, err := h.svc.Save(c.UserContext(), tenantID(c), optionalActor(c), &id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(k)
+}
+
+// Delete DELETE /kris/:id
+func (h *KRIHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid kri id"})
+	}
+	if err := h.svc.Delete(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// PushValue POST /kris/:id/values — one value from another system
+// ({"value": 12, "measured_at": "..."}).
+func (h *KRIHandler) PushValue(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid kri id"})
+	}
+	var in kriapp.ValueInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	res, err := h.svc.PushValue(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(res)
+}
+
+// ImportCSV POST /kris/:id/values/csv — multipart "file" with measured_at and
+// value columns. All-or-nothing.
+func (h *KRIHandler) ImportCSV(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid kri id"})
+	}
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "file is required"})
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "failed to read uploaded file"})
+	}
+	defer f.Close()
+	res, err := h.svc.ImportCSV(c.UserContext(), tenantID(c), optionalActor(c), id, f)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(res)
+}
+
+// Refresh POST /kris/:id/refresh — evaluate a query KRI now.
+func (h *KRIHandler) Refresh(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid kri id"})
+	}
+	res, err := h.svc.Refresh(c.UserContext(), tenantID(c), optionalActor(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(res)
+}
@@ -38,7 +38,7 @@ import (
 // because AutoMigrate cannot render the Postgres defaults on the domain models.
 const mitigationPlansDDL = `
 CREATE TABLE IF NOT EXISTS mitigations (
-	id TEXT PRIMARY KEY,
+	id TEXT PRIMARY KEY, external_id TEXT,
 	tenant_id TEXT NOT NULL,
 	risk_id TEXT,
 	title TEXT,
@@ -0,0 +1,254 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	programmeapp "github.com/opendefender/openrisk/internal/application/programme"
+)
+
+// MitigationProgrammeHandler exposes mitigation programmes: the projects
+// shared by many risks and controls, their actions, schedule and the
+// residual projection of the risks they treat.
+type MitigationProgrammeHandler struct {
+	svc *programmeapp.Service
+}
+
+// NewMitigationProgrammeHandler builds the handler.
+func NewMitigationProgrammeHandler(svc *programmeapp.Service) *MitigationProgrammeHandler {
+	return &MitigationProgrammeHandler{svc: svc}
+}
+
+// List GET /mitigation-programmes
+func (h *MitigationProgrammeHandler) List(c *fiber.Ctx) error {
+	items, err := h.svc.List(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// Create POST /mitigation-programmes
+func (h *MitigationProgrammeHandler) Create(c *fiber.Ctx) error {
+	var in programmeapp.ProgrammeInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	p, err := h.svc.Create(c.UserContext(), tenantID(c), optionalActor(c), in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(p)
+}
+
+// Get GET /mitigation-programmes/:id
+func (h *MitigationProgrammeHandler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid programme id"})
+	}
+	d, err := h.svc.Get(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(d)
+}
+
+// Update PUT /mitigation-programmes/:id
+func (h *MitigationProgrammeHandler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid programme id"})
+	}
+	var in programmeapp.ProgrammeInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	p, err := h.svc.Update(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(p)
+}
+
+// Delete DELETE /mitigation-programmes/:id
+func (h *MitigationProgrammeHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid programme id"})
+	}
+	if err := h.svc.Delete(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Projection GET /mitigation-programmes/:id/projection — the expected residual
+// of every linked risk, as planned and as the schedule is tracking.
+func (h *MitigationProgrammeHandler) Projection(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid programme id"})
+	}
+	items, err := h.svc.Projection(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// LinkRisk POST /mitigation-programmes/:id/risks
+func (h *MitigationProgrammeHandler) LinkRisk(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid programme id"})
+	}
+	var in programmeapp.RiskLinkInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	l, err := h.svc.LinkRisk(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(l)
+}
+
+// UnlinkRisk DELETE /mitigation-programmes/:id/risks/:riskId
+func (h *MitigationProgrammeHandler) UnlinkRisk(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid programme id"})
+	}
+	riskID, err := uuid.Parse(c.Params("riskId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid risk id"})
+	}
+	if err := h.svc.UnlinkRisk(c.UserContext(), tenantID(c), optionalActor(c), id, riskID); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// LinkControl POST /mitigation-programmes/:id/controls
+func (h *MitigationProgrammeHandler) LinkControl(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid programme id"})
+	}
+	var in programmeapp.ControlLinkInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	l, err := h.svc.LinkControl(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(l)
+}
+
+// UnlinkControl DELETE /mitigation-programmes/:id/controls/:controlId
+func (h *MitigationProgrammeHandler) UnlinkControl(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid programme id"})
+	}
+	controlID, err := uuid.Parse(c.Params("controlId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid control id"})
+	}
+	if err := h.svc.UnlinkControl(c.UserContext(), tenantID(c), optionalActor(c), id, controlID); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// AddAction POST /mitigation-programmes/:id/actions
+func (h *MitigationProgrammeHandler) AddAction(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid programme id"})
+	}
+	var in programmeapp.ActionInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	a, err := h.svc.AddAction(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(a)
+}
+
+// UpdateAction PUT /programme-actions/:id
+func (h *MitigationProgrammeHandler) UpdateAction(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid action id"})
+	}
+	var in programmeapp.ActionInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	a, err := h.svc.UpdateAction(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(a)
+}
+
+// DeleteAction DELETE /programme-actions/:id
+func (h *MitigationProgrammeHandler) DeleteAction(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid action id"})
+	}
+	if err := h.svc.DeleteAction(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Capacities GET /programme-capacities
+func (h *MitigationProgrammeHandler) Capacities(c *fiber.Ctx) error {
+	items, err := h.svc.Capacities(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// SetCapacity PUT /programme-capacities/:userId
+func (h *MitigationProgrammeHandler) SetCapacity(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid user id"})
+	}
+	var in programmeapp.CapacityInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	out, err := h.svc.SetCapacity(c.UserContext(), tenantID(c), optionalActor(c), userID, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(out)
+}
+
+// RiskProjection GET /risks/:id/programme-projection
+func (h *MitigationProgrammeHandler) RiskProjection(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid risk id"})
+	}
+	p, err := h.svc.RiskProjection(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(p)
+}
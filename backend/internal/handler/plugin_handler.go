@@ -0,0 +1,214 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/application/plugins"
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// PluginHandler exposes connector plugins: signed bundle upload, installs
+// with their grants, on-demand runs and the run log, and the token-
+// authenticated webhook that feeds transform_webhook.
+type PluginHandler struct {
+	svc *plugins.Service
+}
+
+// NewPluginHandler builds the handler.
+func NewPluginHandler(svc *plugins.Service) *PluginHandler {
+	return &PluginHandler{svc: svc}
+}
+
+// ListPackages GET /plugins
+func (h *PluginHandler) ListPackages(c *fiber.Ctx) error {
+	items, err := h.svc.Packages(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// Upload POST /plugins — multipart "bundle": a zip of manifest.json,
+// plugin.wasm and signature.json (see docs/PLUGINS.md).
+func (h *PluginHandler) Upload(c *fiber.Ctx) error {
+	fh, err := c.FormFile("bundle")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "bundle is required"})
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "failed to read uploaded bundle"})
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, domain.MaxPluginBundleBytes+1))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "failed to read uploaded bundle"})
+	}
+	p, err := h.svc.Upload(c.UserContext(), tenantID(c), optionalActor(c), data)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(p)
+}
+
+// GetPackage GET /plugins/:id
+func (h *PluginHandler) GetPackage(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid plugin id"})
+	}
+	p, err := h.svc.Package(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(p)
+}
+
+// DeletePackage DELETE /plugins/:id
+func (h *PluginHandler) DeletePackage(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid plugin id"})
+	}
+	if err := h.svc.DeletePackage(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListInstalls GET /plugin-installs
+func (h *PluginHandler) ListInstalls(c *fiber.Ctx) error {
+	items, err := h.svc.Installs(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// CreateInstall POST /plugin-installs
+func (h *PluginHandler) CreateInstall(c *fiber.Ctx) error {
+	var in plugins.InstallInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	inst, err := h.svc.CreateInstall(c.UserContext(), tenantID(c), optionalActor(c), in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(inst)
+}
+
+// GetInstall GET /plugin-installs/:id
+func (h *PluginHandler) GetInstall(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid plugin install id"})
+	}
+	inst, err := h.svc.Install(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(inst)
+}
+
+// UpdateInstall PUT /plugin-installs/:id
+func (h *PluginHandler) UpdateInstall(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid plugin install id"})
+	}
+	var in plugins.InstallInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	inst, err := h.svc.UpdateInstall(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(inst)
+}
+
+// DeleteInstall DELETE /plugin-installs/:id
+func (h *PluginHandler) DeleteInstall(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid plugin install id"})
+	}
+	if err := h.svc.DeleteInstall(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Run POST /plugin-installs/:id/run — {"hook": "pull_findings"}. A plugin
+// failure is a 200 with a failed run; the request itself succeeded.
+func (h *PluginHandler) Run(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid plugin install id"})
+	}
+	var in plugins.RunInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	res, err := h.svc.Run(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(res)
+}
+
+// Runs GET /plugin-installs/:id/runs
+func (h *PluginHandler) Runs(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid plugin install id"})
+	}
+	items, err := h.svc.Runs(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// Webhook POST /api/v1/plugins/webhook — token-authenticated push handed to
+// the install's transform_webhook hook. The token never reaches the plugin.
+func (h *PluginHandler) Webhook(c *fiber.Ctx) error {
+	token := webhookToken(c)
+	if token == "" {
+		return c.Status(401).JSON(fiber.Map{"error": "missing webhook token"})
+	}
+	headers := map[string]string{}
+	c.Request().Header.VisitAll(func(k, v []byte) {
+		name := http.CanonicalHeaderKey(string(k))
+		switch strings.ToLower(name) {
+		case "authorization", "x-webhook-token", "cookie":
+			return
+		}
+		headers[name] = string(v)
+	})
+	res, err := h.svc.Receive(c.UserContext(), token, headers, c.Body())
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	if res == nil {
+		// Uniform 401 whether the token is unknown or the install disabled.
+		return c.Status(401).JSON(fiber.Map{"error": "invalid or disabled webhook token"})
+	}
+	return c.Status(202).JSON(fiber.Map{
+		"run_id": res.Run.ID,
+		"status": res.Run.Status,
+		"items":  res.Run.Items,
+		"error":  res.Run.Error,
+	})
+}
@@ -0,0 +1,333 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/application/registersnapshot"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/internal/middleware"
+	"github.com/opendefender/openrisk/internal/service"
+)
+
+// RegisterSnapshotHandler exposes the frozen register: the snapshots
+// themselves, the diff between two of them, and the as_of variants of the
+// register, dashboard and export reads.
+type RegisterSnapshotHandler struct {
+	svc *registersnapshot.Service
+}
+
+// NewRegisterSnapshotHandler builds the handler.
+func NewRegisterSnapshotHandler(svc *registersnapshot.Service) *RegisterSnapshotHandler {
+	return &RegisterSnapshotHandler{svc: svc}
+}
+
+// List GET /register-snapshots — newest first.
+func (h *RegisterSnapshotHandler) List(c *fiber.Ctx) error {
+	rows, err := h.svc.List(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	if rows == nil {
+		rows = []domain.RegisterSnapshot{}
+	}
+	return c.JSON(rows)
+}
+
+// Take POST /register-snapshots — freeze the register now. Body: {"label"}.
+func (h *RegisterSnapshotHandler) Take(c *fiber.Ctx) error {
+	var in struct {
+		Label string `json:"label"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&in); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+		}
+	}
+	snap, err := h.svc.Take(c.UserContext(), tenantID(c), optionalActor(c), in.Label)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(snap)
+}
+
+// Get GET /register-snapshots/:id
+func (h *RegisterSnapshotHandler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid snapshot id"})
+	}
+	snap, err := h.svc.Get(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(snap)
+}
+
+// Risks GET /register-snapshots/:id/risks — a page of the frozen register,
+// filtered like GET /risks (q, status, criticality, owner_id, min_score).
+func (h *RegisterSnapshotHandler) Risks(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid snapshot id"})
+	}
+	page, err := h.svc.Rows(c.UserContext(), tenantID(c), id, snapshotFilter(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(page)
+}
+
+// Diff GET /register-snapshots/diff?from=&to= — new, closed, up-scored and
+// down-scored risks between two snapshots. Without `to`, the live register is
+// the later side.
+func (h *RegisterSnapshotHandler) Diff(c *fiber.Ctx) error {
+	from, err := uuid.Parse(c.Query("from"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "from must be a snapshot id"})
+	}
+	if raw := c.Query("to"); raw != "" {
+		to, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "to must be a snapshot id"})
+		}
+		d, err := h.svc.Diff(c.UserContext(), tenantID(c), from, to)
+		if err != nil {
+			return writeAppError(c, err)
+		}
+		return c.JSON(d)
+	}
+	snap, err := h.svc.Get(c.UserContext(), tenantID(c), from)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	d, err := h.svc.DiffSince(c.UserContext(), tenantID(c), snap.AsOf)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(d)
+}
+
+// =============================================================================
+// as_of reads
+// =============================================================================
+//
+// Each wrapper serves the frozen register when the request carries ?as_of=
+// and hands over to the live handler otherwise, so the live paths (and their
+// cache) are untouched. The response names the snapshot that answered: a date
+// between two snapshots is served by the earlier one.
+
+// snapshotsRead is the permission the snapshot routes require; the as_of
+// reads check it themselves, since their live side only needs risks:read.
+const snapshotsRead = "risks:snapshots:read"
+
+// resolveAsOf returns the snapshot standing for ?as_of=, or nil when the
+// parameter is absent.
+func (h *RegisterSnapshotHandler) resolveAsOf(c *fiber.Ctx) (*domain.RegisterSnapshot, error) {
+	raw := c.Query("as_of")
+	if raw == "" {
+		return nil, nil
+	}
+	if !middleware.HasPermission(c, snapshotsRead) {
+		return nil, domain.NewForbiddenError("as_of needs the " + snapshotsRead + " permission")
+	}
+	asOf, err := registersnapshot.ParseAsOf(raw)
+	if err != nil {
+		return nil, err
+	}
+	return h.svc.Resolve(c.UserContext(), tenantID(c), asOf)
+}
+
+// AsOfRisks wraps GET /risks.
+func (h *RegisterSnapshotHandler) AsOfRisks(live fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		snap, err := h.resolveAsOf(c)
+		if err != nil {
+			return writeAppError(c, err)
+		}
+		if snap == nil {
+			return live(c)
+		}
+		page, err := h.svc.Rows(c.UserContext(), tenantID(c), snap.ID, snapshotFilter(c))
+		if err != nil {
+			return writeAppError(c, err)
+		}
+		return c.JSON(fiber.Map{"items": page.Items, "total": page.Total, "snapshot": snap})
+	}
+}
+
+// AsOfDashboardMetrics wraps GET /dashboard/metrics. Only what a snapshot
+// freezes is answered; the SLA and trend figures are live-only and stay zero.
+func (h *RegisterSnapshotHandler) AsOfDashboardMetrics(live fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		snap, err := h.resolveAsOf(c)
+		if err != nil {
+			return writeAppError(c, err)
+		}
+		if snap == nil {
+			return live(c)
+		}
+		metrics := service.DashboardMetrics{
+			AverageRiskScore: snap.AverageScore,
+			TotalRisks:       int64(snap.RiskCount),
+			ActiveRisks:      int64(snap.OpenCount),
+			UpdatedAt:        snap.AsOf,
+		}
+		if snap.RiskCount > 0 {
+			metrics.MitigationRate = float64(snap.RiskCount-snap.OpenCount) / float64(snap.RiskCount) * 100
+		}
+		return c.JSON(struct {
+			service.DashboardMetrics
+			Snapshot *domain.RegisterSnapshot `json:"snapshot"`
+		}{metrics, snap})
+	}
+}
+
+// AsOfSeverityDistribution wraps GET /dashboard/severity-distribution: the open
+// risks by criticality.
+func (h *RegisterSnapshotHandler) AsOfSeverityDistribution(live fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		snap, err := h.resolveAsOf(c)
+		if err != nil {
+			return writeAppError(c, err)
+		}
+		if snap == nil {
+			return live(c)
+		}
+		return c.JSON(struct {
+			service.RiskSeverityDistribution
+			Snapshot *domain.RegisterSnapshot `json:"snapshot"`
+		}{service.RiskSeverityDistribution{
+			Critical: int64(snap.Critical),
+			High:     int64(snap.High),
+			Medium:   int64(snap.Medium),
+			Low:      int64(snap.Low),
+		}, snap})
+	}
+}
+
+// AsOfTopRisks wraps GET /dashboard/top-risks.
+func (h *RegisterSnapshotHandler) AsOfTopRisks(live fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		snap, err := h.resolveAsOf(c)
+		if err != nil {
+			return writeAppError(c, err)
+		}
+		if snap == nil {
+			return live(c)
+		}
+		limit := c.QueryInt("limit", 5)
+		if limit <= 0 || limit > 50 {
+			limit = 5
+		}
+		page, err := h.svc.Rows(c.UserContext(), tenantID(c), snap.ID, domain.RegisterSnapshotFilter{Limit: limit})
+		if err != nil {
+			return writeAppError(c, err)
+		}
+		top := make([]service.TopRisk, 0, len(page.Items))
+		for _, r := range page.Items {
+			top = append(top, service.TopRisk{
+				ID:          r.RiskID.String(),
+				Name:        r.Title,
+				Score:       r.Score,
+				Severity:    r.Criticality,
+				Status:      string(r.Status),
+				LastUpdated: r.RiskUpdatedAt,
+			})
+		}
+		return c.JSON(fiber.Map{"top_risks": top, "count": len(top), "snapshot": snap})
+	}
+}
+
+// AsOfExport wraps GET /analytics/export: the snapshot's figures and its whole
+// register, as json or csv.
+func (h *RegisterSnapshotHandler) AsOfExport(live fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		snap, err := h.resolveAsOf(c)
+		if err != nil {
+			return writeAppError(c, err)
+		}
+		if snap == nil {
+			return live(c)
+		}
+		page, err := h.svc.Rows(c.UserContext(), tenantID(c), snap.ID, domain.RegisterSnapshotFilter{})
+		if err != nil {
+			return writeAppError(c, err)
+		}
+		name := "register-" + snap.AsOf.Format("2006-01-02")
+		switch c.Query("format", "json") {
+		case "json":
+			c.Set("Content-Disposition", "attachment; filename="+name+".json")
+			return c.JSON(page)
+		case "csv":
+			c.Set("Content-Disposition", "attachment; filename="+name+".csv")
+			c.Set("Content-Type", "text/csv")
+			return writeSnapshotCSV(c, page)
+		default:
+			return c.Status(400).JSON(fiber.Map{"error": "unsupported format"})
+		}
+	}
+}
+
+func writeSnapshotCSV(c *fiber.Ctx, page *registersnapshot.Page) error {
+	w := csv.NewWriter(c.Response().BodyWriter())
+	s := page.Snapshot
+	_ = w.Write([]string{"OpenRisk register as of", s.AsOf.Format(time.RFC3339)})
+	_ = w.Write([]string{"Snapshot", s.ID.String(), string(s.Kind), s.Label})
+	_ = w.Write([]string{"Risks", strconv.Itoa(s.RiskCount), "Open", strconv.Itoa(s.OpenCount)})
+	_ = w.Write([]string{"Total ALE (XAF)", strconv.FormatFloat(s.TotalALEXAF, 'f', 0, 64)})
+	_ = w.Write(nil)
+	_ = w.Write([]string{"risk_id", "title", "status", "lifecycle_state", "criticality", "score",
+		"probability", "impact", "owner_id", "business_unit", "ale_xaf", "controls", "assets", "tags"})
+	for _, r := range page.Items {
+		owner := ""
+		if r.OwnerID != nil {
+			owner = r.OwnerID.String()
+		}
+		_ = w.Write([]string{
+			r.RiskID.String(), r.Title, string(r.Status), string(r.LifecycleState), r.Criticality,
+			strconv.FormatFloat(r.Score, 'f', 2, 64),
+			strconv.FormatFloat(r.Probability, 'f', -1, 64), strconv.FormatFloat(r.Impact, 'f', -1, 64), owner, r.BusinessUnit,
+			strconv.FormatFloat(r.ALEXAF, 'f', 0, 64),
+			strings.Join(r.Controls, "; "), strings.Join(r.Assets, "; "), strings.Join(r.Tags, "; "),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// snapshotFilter reads the GET /risks filters that a snapshot can answer,
+// with the same page size defaults.
+func snapshotFilter(c *fiber.Ctx) domain.RegisterSnapshotFilter {
+	f := domain.RegisterSnapshotFilter{
+		Query:       c.Query("q"),
+		Status:      c.Query("status"),
+		Criticality: c.Query("criticality"),
+		Page:        c.QueryInt("page", 1),
+		Limit:       20,
+	}
+	if l := c.QueryInt("limit"); l > 0 && l <= 200 {
+		f.Limit = l
+	}
+	if raw := c.Query("owner_id"); raw != "" {
+		if id, err := uuid.Parse(raw); err == nil {
+			f.OwnerID = &id
+		}
+	}
+	if raw := c.Query("min_score"); raw != "" {
+		if v, err := strconv.ParseFloat(raw, 64); err == nil {
+			f.MinScore = &v
+		}
+	}
+	return f
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/application/appetite"
+)
+
+// RiskAppetiteHandler exposes the board's appetite statements, the register
+// evaluated against them, and the exception requests that keep a risk above
+// appetite open.
+type RiskAppetiteHandler struct {
+	svc *appetite.Service
+}
+
+// NewRiskAppetiteHandler builds the handler.
+func NewRiskAppetiteHandler(svc *appetite.Service) *RiskAppetiteHandler {
+	return &RiskAppetiteHandler{svc: svc}
+}
+
+// ListStatements GET /risk-appetite/statements
+func (h *RiskAppetiteHandler) ListStatements(c *fiber.Ctx) error {
+	rows, err := h.svc.ListStatements(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(rows)
+}
+
+// CreateStatement POST /risk-appetite/statements
+func (h *RiskAppetiteHandler) CreateStatement(c *fiber.Ctx) error {
+	var in appetite.StatementInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	st, err := h.svc.SaveStatement(c.UserContext(), tenantID(c), optionalActor(c), nil, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(st)
+}
+
+// UpdateStatement PUT /risk-appetite/statements/:id
+func (h *RiskAppetiteHandler) UpdateStatement(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid statement id"})
+	}
+	var in appetite.StatementInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	st, err := h.svc.SaveStatement(c.UserContext(), tenantID(c), optionalActor(c), &id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(st)
+}
+
+// DeleteStatement DELETE /risk-appetite/statements/:id
+func (h *RiskAppetiteHandler) DeleteStatement(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid statement id"})
+	}
+	if err := h.svc.DeleteStatement(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Evaluate GET /risk-appetite/evaluation — every statement's scope measured
+// against its tolerances, and every risk above an in-force statement with the
+// state of its exception.
+func (h *RiskAppetiteHandler) Evaluate(c *fiber.Ctx) error {
+	ev, err := h.svc.Evaluate(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(ev)
+}
+
+// RequestException POST /risk-appetite/exceptions — opens an approval request
+// to keep a risk above appetite open until valid_until.
+func (h *RiskAppetiteHandler) RequestException(c *fiber.Ctx) error {
+	actor := optionalActor(c)
+	if actor == nil {
+		return c.Status(401).JSON(fiber.Map{"error": "authentication required"})
+	}
+	var in appetite.ExceptionInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	req, err := h.svc.RequestException(c.UserContext(), tenantID(c), *actor, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(req)
+}
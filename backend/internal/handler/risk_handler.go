@@ -245,6 +245,10 @@ type CreateRiskInput struct {
 	// CategoryID is the tenant's CONTROLLED classification. Optional — a risk
 	// stays creatable without one, exactly like the compliance mapping.
 	CategoryID *string `json:"category_id" validate:"omitempty,uuid4"`
+	// BusinessUnit — the owning entity, free text. Optional.
+	BusinessUnit string `json:"business_unit" validate:"omitempty,max=128"`
+	// Classification — confidentiality level, free text. Optional.
+	Classification string `json:"classification" validate:"omitempty,max=32"`
 	// Ownership — responsable / exécutant / validateur, as picked in <UserPicker>.
 	// Embedded so the three keys sit at the top level of the payload.
 	domain.OwnershipPatch
@@ -273,6 +277,10 @@ type UpdateRiskInput struct {
 	Frameworks  []string `json:"frameworks" validate:"omitempty,dive,required"`
 	// Category is tri-state like ownership: absent leaves it, null clears it.
 	Category domain.NullableUUID `json:"category_id"`
+	// BusinessUnit: absent leaves it, "" clears it.
+	BusinessUnit *string `json:"business_unit" validate:"omitempty,max=128"`
+	// Classification: absent leaves it, "" clears it.
+	Classification *string `json:"classification" validate:"omitempty,max=32"`
 	// Ownership — tri-state: a key absent from the body leaves the slot alone,
 	// an explicit null unassigns it. Embedded so the three keys sit at the top
 	// level of the payload.
@@ -342,6 +350,9 @@ func (h *RiskHandler) CreateRisk(c *fiber.Ctx) error {
 		OtherDirectCostXAF:      input.OtherDirectCostXAF,
 		RemediationCostXAF:      input.RemediationCostXAF,
 		MitigationEffectiveness: input.MitigationEffectiveness,
+
+		BusinessUnit:   input.BusinessUnit,
+		Classification: input.Classification,
 	}
 
 	domainRisk, err := h.createRiskUseCase.Execute(stdCtx, orgID, ucInput)
@@ -580,6 +591,8 @@ func (h *RiskHandler) UpdateRisk(c *fiber.Ctx) error {
 		Frameworks:         input.Frameworks,
 		Ownership:          input.OwnershipPatch,
 		Category:           input.Category,
+		BusinessUnit:       input.BusinessUnit,
+		Classification:     input.Classification,
 		Actor:              actorID,
 		Locale:             c.Query("locale", "fr"),
 		SLEXAF:             input.SLEXAF,
@@ -20,6 +20,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
+	"github.com/opendefender/openrisk/internal/application/appetite"
 	applicationrisk "github.com/opendefender/openrisk/internal/application/risk"
 	"github.com/opendefender/openrisk/internal/domain"
 	"github.com/opendefender/openrisk/internal/infrastructure/database"
@@ -60,7 +61,7 @@ func newLifecycleHarness(t *testing.T) *lifecycleHarness {
 		status TEXT, changed_by TEXT, change_type TEXT, created_at DATETIME
 	);`).Error)
 	require.NoError(t, db.Exec(`CREATE TABLE mitigations (
-		id TEXT PRIMARY KEY, tenant_id TEXT, risk_id TEXT,
+		id TEXT PRIMARY KEY, external_id TEXT, tenant_id TEXT, risk_id TEXT,
 		title TEXT, description TEXT, status TEXT, priority TEXT,
 		owner_id TEXT, assignee_id TEXT, reviewer_id TEXT,
 		assigned_to TEXT, progress INTEGER DEFAULT 0,
@@ -89,6 +90,8 @@ func newLifecycleHarness(t *testing.T) *lifecycleHarness {
 		requested_by TEXT, resolved_at DATETIME, created_at DATETIME, updated_at DATETIME
 	);`).Error)
 
+	require.NoError(t, db.AutoMigrate(&domain.RiskAppetiteStatement{}))
+
 	database.DB = db
 
 	h := &lifecycleHarness{db: db, tenantID: uuid.New(), userID: uuid.New()}
@@ -98,9 +101,13 @@ func newLifecycleHarness(t *testing.T) *lifecycleHarness {
 	subRepo := repository.NewGormMitigationSubActionRepository(db)
 
 	// The real guard adapters, over the real repositories — the whole point.
+	// No appetite statement is in force, so the appetite guard passes.
+	appetiteSvc := appetite.NewService(repository.NewGormRiskAppetiteRepository(db), riskRepo,
+		repository.NewGormRiskCategoryRepository(db), repository.NewGormApprovalRepository(db))
 	transitionUC := applicationrisk.NewTransitionRiskStateUseCase(riskRepo).
 		WithMitigations(&e2eMitigationInspector{plans: planRepo, subs: subRepo}).
-		WithApprovals(&e2eApprovalChecker{db: db})
+		WithApprovals(&e2eApprovalChecker{db: db}).
+		WithAppetite(appetiteSvc)
 
 	riskHandler := NewRiskHandler(
 		applicationrisk.NewCreateRiskUseCase(riskRepo),
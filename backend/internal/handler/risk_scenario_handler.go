@@ -0,0 +1,122 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	scenarioapp "github.com/opendefender/openrisk/internal/application/scenario"
+)
+
+// RiskScenarioHandler exposes the risk-scenario library, instantiation against
+// assets and the ATT&CK coverage report.
+type RiskScenarioHandler struct {
+	svc *scenarioapp.Service
+}
+
+// NewRiskScenarioHandler builds the handler.
+func NewRiskScenarioHandler(svc *scenarioapp.Service) *RiskScenarioHandler {
+	return &RiskScenarioHandler{svc: svc}
+}
+
+// ListScenarios GET /risk-scenarios — built-ins first, then the tenant's own.
+func (h *RiskScenarioHandler) ListScenarios(c *fiber.Ctx) error {
+	lib, err := h.svc.ListScenarios(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(lib)
+}
+
+// GetScenario GET /risk-scenarios/:id
+func (h *RiskScenarioHandler) GetScenario(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid scenario id"})
+	}
+	sc, err := h.svc.GetScenario(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(sc)
+}
+
+// CreateScenario POST /risk-scenarios
+func (h *RiskScenarioHandler) CreateScenario(c *fiber.Ctx) error {
+	var in scenarioapp.ScenarioInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	sc, err := h.svc.SaveScenario(c.UserContext(), tenantID(c), optionalActor(c), nil, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(sc)
+}
+
+// UpdateScenario PUT /risk-scenarios/:id
+func (h *RiskScenarioHandler) UpdateScenario(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid scenario id"})
+	}
+	var in scenarioapp.ScenarioInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	sc, err := h.svc.SaveScenario(c.UserContext(), tenantID(c), optionalActor(c), &id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(sc)
+}
+
+// DeleteScenario DELETE /risk-scenarios/:id
+func (h *RiskScenarioHandler) DeleteScenario(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid scenario id"})
+	}
+	if err := h.svc.DeleteScenario(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Instantiate POST /risk-scenarios/:id/instantiate — drafts one risk per asset.
+func (h *RiskScenarioHandler) Instantiate(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid scenario id"})
+	}
+	var in scenarioapp.InstantiateInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	res, err := h.svc.Instantiate(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(res)
+}
+
+// Coverage GET /risk-scenarios/coverage?scenario_id=
+func (h *RiskScenarioHandler) Coverage(c *fiber.Ctx) error {
+	var scenarioID *uuid.UUID
+	if s := c.Query("scenario_id"); s != "" {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid scenario_id"})
+		}
+		scenarioID = &id
+	}
+	report, err := h.svc.Coverage(c.UserContext(), tenantID(c), scenarioID)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(report)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/application/siem"
+)
+
+// SIEMHandler exposes SIEM export destinations and their backfill.
+type SIEMHandler struct {
+	svc *siem.Service
+}
+
+// NewSIEMHandler builds the handler.
+func NewSIEMHandler(svc *siem.Service) *SIEMHandler {
+	return &SIEMHandler{svc: svc}
+}
+
+// List GET /siem-destinations
+func (h *SIEMHandler) List(c *fiber.Ctx) error {
+	items, err := h.svc.Destinations(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// Create POST /siem-destinations — shipping starts at the current head of
+// each log; use backfill for history.
+func (h *SIEMHandler) Create(c *fiber.Ctx) error {
+	var in siem.DestinationInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	d, err := h.svc.CreateDestination(c.UserContext(), tenantID(c), optionalActor(c), in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(d)
+}
+
+// Get GET /siem-destinations/:id — with its cursors and last error.
+func (h *SIEMHandler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid destination id"})
+	}
+	d, err := h.svc.Destination(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(d)
+}
+
+// Update PUT /siem-destinations/:id
+func (h *SIEMHandler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid destination id"})
+	}
+	var in siem.DestinationInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	d, err := h.svc.UpdateDestination(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(d)
+}
+
+// Delete DELETE /siem-destinations/:id
+func (h *SIEMHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid destination id"})
+	}
+	if err := h.svc.DeleteDestination(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Backfill POST /siem-destinations/:id/backfill — rewinds the cursors; the
+// worker re-ships from there.
+func (h *SIEMHandler) Backfill(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid destination id"})
+	}
+	var in siem.BackfillInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	d, err := h.svc.Backfill(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusAccepted).JSON(d)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/opendefender/openrisk/internal/application/tenantconfig"
+)
+
+// TenantConfigHandler exports and imports the tenant's configuration document
+// (automation rules, risk taxonomy, asset schemas, scoring weights), the
+// server half of `openrisk config export|import`.
+type TenantConfigHandler struct {
+	svc *tenantconfig.Service
+}
+
+// NewTenantConfigHandler builds the handler.
+func NewTenantConfigHandler(svc *tenantconfig.Service) *TenantConfigHandler {
+	return &TenantConfigHandler{svc: svc}
+}
+
+// Export GET /config/export
+func (h *TenantConfigHandler) Export(c *fiber.Ctx) error {
+	doc, err := h.svc.Export(c.UserContext(), tenantID(c), optionalActor(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(doc)
+}
+
+// Import POST /config/import?dry_run=true — the document as JSON. A dry run
+// returns the same changes without writing them.
+func (h *TenantConfigHandler) Import(c *fiber.Ctx) error {
+	var doc tenantconfig.Document
+	if err := c.BodyParser(&doc); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	res, err := h.svc.Import(c.UserContext(), tenantID(c), optionalActor(c), doc, c.QueryBool("dry_run"))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(res)
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	thehiveapp "github.com/opendefender/openrisk/internal/application/thehive"
+)
+
+// TheHiveHandler exposes the TheHive 5 case sync: the tenant's connection, a
+// manual sync, a per-incident push and link view, and the inbound webhook.
+type TheHiveHandler struct {
+	sync *thehiveapp.SyncService
+}
+
+// NewTheHiveHandler builds the handler.
+func NewTheHiveHandler(sync *thehiveapp.SyncService) *TheHiveHandler {
+	return &TheHiveHandler{sync: sync}
+}
+
+// GetConnection GET /integrations/thehive
+func (h *TheHiveHandler) GetConnection(c *fiber.Ctx) error {
+	conn, err := h.sync.GetConnection(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(conn)
+}
+
+type theHiveConnectionBody struct {
+	Name                   string `json:"name"`
+	Enabled                *bool  `json:"enabled"`
+	BaseURL                string `json:"base_url"`
+	Organisation           string `json:"organisation"`
+	APIKey                 string `json:"api_key"`
+	PushIncidents          bool   `json:"push_incidents"`
+	ImportCases            bool   `json:"import_cases"`
+	PollMinutes            *int   `json:"poll_minutes"`
+	RegenerateWebhookToken bool   `json:"regenerate_webhook_token"`
+}
+
+// SaveConnection PUT /integrations/thehive
+func (h *TheHiveHandler) SaveConnection(c *fiber.Ctx) error {
+	var body theHiveConnectionBody
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	enabled := true
+	if body.Enabled != nil {
+		enabled = *body.Enabled
+	}
+	// Five minutes unless told otherwise: webhooks carry the urgent changes, the
+	// poll only has to catch what they drop.
+	poll := 5
+	if body.PollMinutes != nil {
+		poll = *body.PollMinutes
+	}
+	conn, err := h.sync.SaveConnection(c.UserContext(), tenantID(c), optionalActor(c), thehiveapp.ConnectionInput{
+		Name:                   body.Name,
+		Enabled:                enabled,
+		BaseURL:                body.BaseURL,
+		Organisation:           body.Organisation,
+		APIKey:                 body.APIKey,
+		PushIncidents:          body.PushIncidents,
+		ImportCases:            body.ImportCases,
+		PollMinutes:            poll,
+		RegenerateWebhookToken: body.RegenerateWebhookToken,
+	})
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(conn)
+}
+
+// DeleteConnection DELETE /integrations/thehive
+func (h *TheHiveHandler) DeleteConnection(c *fiber.Ctx) error {
+	if err := h.sync.DeleteConnection(c.UserContext(), tenantID(c), optionalActor(c)); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// SyncNow POST /integrations/thehive/sync
+func (h *TheHiveHandler) SyncNow(c *fiber.Ctx) error {
+	rep, err := h.sync.SyncTenant(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(rep)
+}
+
+// PushIncident POST /incidents/:id/thehive
+func (h *TheHiveHandler) PushIncident(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid incident id"})
+	}
+	link, aerr := h.sync.PushIncident(c.UserContext(), tenantID(c), uint(id))
+	if aerr != nil {
+		return writeAppError(c, aerr)
+	}
+	return c.JSON(link)
+}
+
+// GetIncidentLink GET /incidents/:id/thehive — the linked case and the
+// observables it carried, with the asset each was attributed to.
+func (h *TheHiveHandler) GetIncidentLink(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid incident id"})
+	}
+	link, obs, aerr := h.sync.CaseLink(c.UserContext(), tenantID(c), uint(id))
+	if aerr != nil {
+		return writeAppError(c, aerr)
+	}
+	return c.JSON(fiber.Map{"link": link, "observables": obs})
+}
+
+// Webhook POST /api/v1/integrations/thehive/webhook — TheHive's notifier. It
+// authenticates with the connection's opaque token (not a user JWT), so it is
+// mounted before the JWT gate like the vulnerability webhooks, and answers a
+// uniform 401 for every token that does not resolve.
+func (h *TheHiveHandler) Webhook(c *fiber.Ctx) error {
+	var ev thehiveapp.WebhookEvent
+	if err := json.Unmarshal(c.Body(), &ev); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid payload"})
+	}
+	rep, err := h.sync.HandleWebhook(c.UserContext(), webhookToken(c), ev)
+	if errors.Is(err, thehiveapp.ErrUnknownWebhook) {
+		return c.Status(401).JSON(fiber.Map{"error": "invalid or disabled webhook token"})
+	}
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(202).JSON(rep)
+}
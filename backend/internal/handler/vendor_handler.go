@@ -0,0 +1,243 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	vendorapp "github.com/opendefender/openrisk/internal/application/vendor"
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// VendorHandler exposes the vendor register, its questionnaires and
+// assessments, and — on separate, unauthenticated routes — the portal a vendor
+// answers through.
+type VendorHandler struct {
+	svc *vendorapp.Service
+}
+
+// NewVendorHandler builds the handler.
+func NewVendorHandler(svc *vendorapp.Service) *VendorHandler {
+	return &VendorHandler{svc: svc}
+}
+
+// ListVendors GET /vendors?tier=&status=&q=
+func (h *VendorHandler) ListVendors(c *fiber.Ctx) error {
+	f := domain.VendorFilter{Status: domain.VendorStatus(c.Query("status")), Query: c.Query("q")}
+	if t := c.Query("tier"); t != "" {
+		n, err := strconv.Atoi(t)
+		if err != nil || n < 1 || n > 3 {
+			return c.Status(400).JSON(fiber.Map{"error": "tier must be 1, 2 or 3"})
+		}
+		f.Tier = domain.VendorTier(n)
+	}
+	rows, err := h.svc.ListVendors(c.UserContext(), tenantID(c), f)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(rows)
+}
+
+// GetVendor GET /vendors/:id — the vendor with its assessments.
+func (h *VendorHandler) GetVendor(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid vendor id"})
+	}
+	v, err := h.svc.GetVendor(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(v)
+}
+
+// CreateVendor POST /vendors
+func (h *VendorHandler) CreateVendor(c *fiber.Ctx) error {
+	var in vendorapp.VendorInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	v, err := h.svc.SaveVendor(c.UserContext(), tenantID(c), optionalActor(c), nil, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(v)
+}
+
+// UpdateVendor PUT /vendors/:id
+func (h *VendorHandler) UpdateVendor(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid vendor id"})
+	}
+	var in vendorapp.VendorInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	v, err := h.svc.SaveVendor(c.UserContext(), tenantID(c), optionalActor(c), &id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(v)
+}
+
+// DeleteVendor DELETE /vendors/:id
+func (h *VendorHandler) DeleteVendor(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid vendor id"})
+	}
+	if err := h.svc.DeleteVendor(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// SendAssessment POST /vendors/:id/assessments — snapshots a questionnaire and
+// mints the vendor's link. The link is in the response only when it was not
+// emailed.
+func (h *VendorHandler) SendAssessment(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid vendor id"})
+	}
+	var in vendorapp.SendInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	res, err := h.svc.SendAssessment(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(res)
+}
+
+// RevokeAssessment POST /vendor-assessments/:id/revoke
+func (h *VendorHandler) RevokeAssessment(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid assessment id"})
+	}
+	a, err := h.svc.RevokeAssessment(c.UserContext(), tenantID(c), optionalActor(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(a)
+}
+
+// ListTemplates GET /vendor-questionnaires — built-ins, then custom ones.
+func (h *VendorHandler) ListTemplates(c *fiber.Ctx) error {
+	rows, err := h.svc.ListTemplates(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(rows)
+}
+
+// GetTemplate GET /vendor-questionnaires/:id
+func (h *VendorHandler) GetTemplate(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid questionnaire id"})
+	}
+	t, err := h.svc.GetTemplate(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(t)
+}
+
+// CreateTemplate POST /vendor-questionnaires
+func (h *VendorHandler) CreateTemplate(c *fiber.Ctx) error {
+	var in vendorapp.TemplateInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	t, err := h.svc.SaveTemplate(c.UserContext(), tenantID(c), optionalActor(c), nil, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(t)
+}
+
+// UpdateTemplate PUT /vendor-questionnaires/:id
+func (h *VendorHandler) UpdateTemplate(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid questionnaire id"})
+	}
+	var in vendorapp.TemplateInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	t, err := h.svc.SaveTemplate(c.UserContext(), tenantID(c), optionalActor(c), &id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(t)
+}
+
+// DeleteTemplate DELETE /vendor-questionnaires/:id
+func (h *VendorHandler) DeleteTemplate(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid questionnaire id"})
+	}
+	if err := h.svc.DeleteTemplate(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ---------------------------------------------------------------------------
+// Vendor portal — mounted OUTSIDE the JWT gate. The token is the credential;
+// it travels in the query string only for the read (the link itself), and in
+// the body for everything that writes.
+// ---------------------------------------------------------------------------
+
+// Portal GET /vendor-portal?token=
+func (h *VendorHandler) Portal(c *fiber.Ctx) error {
+	view, err := h.svc.Portal(c.UserContext(), c.Query("token"))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(view)
+}
+
+// PortalAnswers POST /vendor-portal/answers
+func (h *VendorHandler) PortalAnswers(c *fiber.Ctx) error {
+	var in vendorapp.AnswersInput
+	if err := c.BodyParser(&in); err != nil {
+		return writeAppError(c, domain.NewValidationError("invalid request body"))
+	}
+	view, err := h.svc.SaveAnswers(c.UserContext(), in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(view)
+}
+
+// PortalDocument POST /vendor-portal/documents — multipart: token,
+// question_id, file.
+func (h *VendorHandler) PortalDocument(c *fiber.Ctx) error {
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "file is required"})
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "failed to read uploaded file"})
+	}
+	defer f.Close()
+	view, err := h.svc.UploadDocument(c.UserContext(), c.FormValue("token"), c.FormValue("question_id"), fh.Filename, f)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(view)
+}
@@ -6,8 +6,10 @@
 package handler
 
 import (
+	"io"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -84,6 +86,55 @@ func (h *VulnerabilityHandler) Ingest(c *fiber.Ctx) error {
 	return c.Status(201).JSON(res)
 }
 
+// Upload POST /vulnerabilities/upload — multipart "file" with "format" (sarif,
+// cyclonedx, or a scanner source whose JSON export it is), an optional
+// "default_asset_id" and the auto_create_* flags. This is what `openrisk push`
+// sends from CI. A file with no findings is a 200, not an error: a clean scan
+// is a normal outcome.
+func (h *VulnerabilityHandler) Upload(c *fiber.Ctx) error {
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "file is required"})
+	}
+	if fh.Size > vulnscan.MaxFileBytes {
+		return c.Status(413).JSON(fiber.Map{"error": "findings file is too large"})
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "failed to read uploaded file"})
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, vulnscan.MaxFileBytes))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "failed to read uploaded file"})
+	}
+	source, findings, err := vulnscan.ParseFile(c.FormValue("format"), data)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	if len(findings) == 0 {
+		return c.JSON(vulnapp.IngestResult{Source: source, Vulnerabilities: []domain.Vulnerability{}})
+	}
+	in := vulnapp.IngestInput{
+		Source:           source,
+		Findings:         findings,
+		AutoCreateRisk:   c.FormValue("auto_create_risk") == "true",
+		AutoCreateTicket: c.FormValue("auto_create_ticket") == "true",
+	}
+	if v := c.FormValue("default_asset_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid default_asset_id"})
+		}
+		in.DefaultAssetID = &id
+	}
+	res, err := h.ingest.Execute(c.UserContext(), h.tenant(c), in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(201).JSON(res)
+}
+
 // List GET /vulnerabilities — filtered, prioritised register.
 func (h *VulnerabilityHandler) List(c *fiber.Ctx) error {
 	q := domain.NewVulnerabilityQuery()
@@ -115,6 +166,13 @@ func (h *VulnerabilityHandler) List(c *fiber.Ctx) error {
 			q.AssetID = &id
 		}
 	}
+	if v := c.Query("first_seen_after"); v != "" {
+		at, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "first_seen_after must be an RFC 3339 timestamp"})
+		}
+		q.FirstSeenAfter = &at
+	}
 	if v := c.Query("page"); v != "" {
 		if p, err := strconv.Atoi(v); err == nil {
 			q.Page = p
@@ -0,0 +1,159 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/application/webhooks"
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// WebhookHandler exposes outbound webhook subscriptions, their delivery log
+// and redelivery, and the catalogue of events with their JSON Schemas.
+type WebhookHandler struct {
+	svc *webhooks.Service
+}
+
+// NewWebhookHandler builds the handler.
+func NewWebhookHandler(svc *webhooks.Service) *WebhookHandler {
+	return &WebhookHandler{svc: svc}
+}
+
+// Events GET /webhook-events
+func (h *WebhookHandler) Events(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"items": webhooks.Events()})
+}
+
+// EventSchema GET /webhook-events/:type/schema — the JSON Schema of the
+// event's envelope at the current version.
+func (h *WebhookHandler) EventSchema(c *fiber.Ctx) error {
+	raw, err := webhooks.Schema(domain.WebhookEventType(c.Params("type")))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	c.Set(fiber.HeaderContentType, "application/schema+json")
+	return c.Send(raw)
+}
+
+// List GET /webhooks
+func (h *WebhookHandler) List(c *fiber.Ctx) error {
+	items, err := h.svc.Subscriptions(c.UserContext(), tenantID(c))
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// Create POST /webhooks — the response carries the signing secret, which is
+// not shown again.
+func (h *WebhookHandler) Create(c *fiber.Ctx) error {
+	var in webhooks.SubscriptionInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	sub, err := h.svc.CreateSubscription(c.UserContext(), tenantID(c), optionalActor(c), in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(sub)
+}
+
+// Get GET /webhooks/:id
+func (h *WebhookHandler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid webhook id"})
+	}
+	sub, err := h.svc.Subscription(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(sub)
+}
+
+// Update PUT /webhooks/:id
+func (h *WebhookHandler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid webhook id"})
+	}
+	var in webhooks.SubscriptionInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid input", "details": err.Error()})
+	}
+	sub, err := h.svc.UpdateSubscription(c.UserContext(), tenantID(c), optionalActor(c), id, in)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(sub)
+}
+
+// Delete DELETE /webhooks/:id
+func (h *WebhookHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid webhook id"})
+	}
+	if err := h.svc.DeleteSubscription(c.UserContext(), tenantID(c), optionalActor(c), id); err != nil {
+		return writeAppError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// RotateSecret POST /webhooks/:id/rotate-secret — returns the new secret
+// once; the previous one keeps signing for 24 hours.
+func (h *WebhookHandler) RotateSecret(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid webhook id"})
+	}
+	sub, err := h.svc.RotateSecret(c.UserContext(), tenantID(c), optionalActor(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(sub)
+}
+
+// Deliveries GET /webhooks/:id/deliveries — the most recent 100.
+func (h *WebhookHandler) Deliveries(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid webhook id"})
+	}
+	items, err := h.svc.Deliveries(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(fiber.Map{"items": items})
+}
+
+// GetDelivery GET /webhook-deliveries/:id — with the payload it sends.
+func (h *WebhookHandler) GetDelivery(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid delivery id"})
+	}
+	d, err := h.svc.Delivery(c.UserContext(), tenantID(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.JSON(d)
+}
+
+// Redeliver POST /webhook-deliveries/:id/redeliver
+func (h *WebhookHandler) Redeliver(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid delivery id"})
+	}
+	d, err := h.svc.Redeliver(c.UserContext(), tenantID(c), optionalActor(c), id)
+	if err != nil {
+		return writeAppError(c, err)
+	}
+	return c.Status(fiber.StatusAccepted).JSON(d)
+}
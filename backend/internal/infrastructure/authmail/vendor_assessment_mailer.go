@@ -0,0 +1,75 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package authmail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/opendefender/openrisk/internal/application/vendor"
+)
+
+// VendorAssessmentMailer sends a supplier the link to its security
+// questionnaire. Synchronous, with the transport's real error, for the reason
+// given on InvitationMailer: the sender needs to know whether it went out, and
+// is handed the link to share by hand when it did not.
+type VendorAssessmentMailer struct {
+	sender  Sender
+	product string
+}
+
+// NewVendorAssessmentMailer builds the mailer over a transport.
+func NewVendorAssessmentMailer(sender Sender) *VendorAssessmentMailer {
+	return &VendorAssessmentMailer{sender: sender, product: "OpenRisk"}
+}
+
+// SendVendorAssessment delivers the link, returning the transport's real error.
+func (m *VendorAssessmentMailer) SendVendorAssessment(ctx context.Context, mail vendor.AssessmentMail) error {
+	if m == nil || m.sender == nil {
+		return fmt.Errorf("no email transport configured")
+	}
+	c := vendorAssessmentCopy(mail)
+	mm := &Mailer{product: m.product}
+	return m.sender.SendEmail(ctx, mail.To, c.subject, mm.render(c))
+}
+
+// vendorAssessmentCopy builds the message. render() escapes every string.
+func vendorAssessmentCopy(mail vendor.AssessmentMail) copyBlock {
+	org := strings.TrimSpace(mail.OrgName)
+	if strings.EqualFold(mail.Locale, "en") {
+		if org == "" {
+			org = "Your customer"
+		}
+		return copyBlock{
+			subject: fmt.Sprintf("%s: security questionnaire for %s", org, mail.VendorName),
+			heading: "Security questionnaire",
+			paragraphs: []string{
+				fmt.Sprintf("%s asks %s to complete the %s security questionnaire as part of its supplier risk programme.", org, mail.VendorName, mail.QuestionnaireName),
+				"No account is needed: the link below opens the questionnaire. You can save your answers and come back before submitting, and attach supporting documents such as certificates or audit reports.",
+				fmt.Sprintf("The link expires on %s.", mail.ExpiresAt.Format("2 January 2006 at 15:04 MST")),
+			},
+			ctaLabel: "Open the questionnaire",
+			ctaURL:   mail.LinkURL,
+			footnote: "Anyone holding this link can answer on your behalf — do not forward it outside the people who should answer.",
+		}
+	}
+	if org == "" {
+		org = "Votre client"
+	}
+	return copyBlock{
+		subject: fmt.Sprintf("%s : questionnaire sécurité pour %s", org, mail.VendorName),
+		heading: "Questionnaire sécurité",
+		paragraphs: []string{
+			fmt.Sprintf("%s demande à %s de remplir le questionnaire sécurité %s dans le cadre de son suivi des risques fournisseurs.", org, mail.VendorName, mail.QuestionnaireName),
+			"Aucun compte n'est nécessaire : le lien ci-dessous ouvre le questionnaire. Vous pouvez enregistrer vos réponses et y revenir avant de le soumettre, et joindre des documents (certificats, rapports d'audit…).",
+			fmt.Sprintf("Le lien expire le %s.", frenchDate(mail.ExpiresAt)),
+		},
+		ctaLabel: "Ouvrir le questionnaire",
+		ctaURL:   mail.LinkURL,
+		footnote: "Toute personne détenant ce lien peut répondre en votre nom — ne le transférez qu'aux personnes chargées d'y répondre.",
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package authmail
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opendefender/openrisk/internal/application/vendor"
+)
+
+func TestVendorAssessmentMailer_SendsLinkAndReportsFailure(t *testing.T) {
+	mail := vendor.AssessmentMail{
+		To: "security@acme.example", VendorName: "Acme & Co", OrgName: "Banque Atlantique",
+		QuestionnaireName: "SIG-lite", LinkURL: "https://openrisk.test/vendor-assessment?token=abc123",
+		ExpiresAt: time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC),
+	}
+	s := &capturingSender{}
+	if err := NewVendorAssessmentMailer(s).SendVendorAssessment(context.Background(), mail); err != nil {
+		t.Fatal(err)
+	}
+	if s.to != mail.To || !strings.Contains(s.subject, "Banque Atlantique") {
+		t.Fatalf("to=%q subject=%q", s.to, s.subject)
+	}
+	if !strings.Contains(s.body, "token=abc123") || !strings.Contains(s.body, "Acme &amp; Co") {
+		t.Fatal("the body carries the link and escapes the vendor name")
+	}
+
+	mail.Locale = "en"
+	if err := NewVendorAssessmentMailer(s).SendVendorAssessment(context.Background(), mail); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(s.body, "Open the questionnaire") {
+		t.Fatal("english copy")
+	}
+
+	down := &capturingSender{err: errors.New("smtp down")}
+	if err := NewVendorAssessmentMailer(down).SendVendorAssessment(context.Background(), mail); err == nil {
+		t.Fatal("a transport failure is reported")
+	}
+	if err := NewVendorAssessmentMailer(nil).SendVendorAssessment(context.Background(), mail); err == nil {
+		t.Fatal("no transport is an error, never a silent success")
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: LicenseRef-OpenRisk-Commercial
+// This file is part of the OpenRisk Enterprise Edition and is NOT covered by the
+// AGPL; it is licensed under the OpenRisk Commercial License (see LICENSE.commercial).
+
+package automation
+
+import (
+	"context"
+	"time"
+
+	kriapp "github.com/opendefender/openrisk/internal/application/kri"
+	"github.com/opendefender/openrisk/internal/domain"
+	redisclient "github.com/opendefender/openrisk/internal/infrastructure/redis"
+	"github.com/opendefender/openrisk/pkg/events"
+)
+
+// KRIEventPublisher publishes the kri.threshold_crossed Redis event that fires
+// the SOAR engine's kri_threshold_crossed trigger, and risk.updated for a risk
+// a red KRI raised so the Score Engine re-scores it. It implements
+// kriapp.EventPublisher.
+type KRIEventPublisher struct {
+	redis *redisclient.Client
+}
+
+// NewKRIEventPublisher builds the publisher.
+func NewKRIEventPublisher(redis *redisclient.Client) *KRIEventPublisher {
+	return &KRIEventPublisher{redis: redis}
+}
+
+var _ kriapp.EventPublisher = (*KRIEventPublisher)(nil)
+
+// PublishKRIThresholdCrossed maps a crossing to the event payload.
+func (p *KRIEventPublisher) PublishKRIThresholdCrossed(ctx context.Context, k *domain.KRI, c domain.KRICrossing, measuredAt time.Time) error {
+	value := 0.0
+	if k.LastValue != nil {
+		value = *k.LastValue
+	}
+	evt := events.KRIThresholdCrossedEvent{
+		KRIID:      k.ID.String(),
+		TenantID:   k.TenantID.String(),
+		Name:       k.Name,
+		Value:      value,
+		Unit:       k.Unit,
+		From:       string(c.From),
+		To:         string(c.To),
+		Worsened:   c.Worsened,
+		RiskIDs:    append([]string{}, k.RiskIDs...),
+		MeasuredAt: measuredAt.UTC().Format(time.RFC3339),
+	}
+	return p.redis.Publish(ctx, events.KRIThresholdCrossed, evt)
+}
+
+// PublishRiskUpdated asks the Score Engine to re-score a raised risk, with the
+// same asset-criticality factor the risk handler sends (neutral without
+// assets).
+func (p *KRIEventPublisher) PublishRiskUpdated(ctx context.Context, r *domain.Risk) error {
+	crit := 1.0
+	if len(r.Assets) > 0 {
+		var sum float64
+		for _, a := range r.Assets {
+			sum += a.Criticality.ScoreFactor()
+		}
+		crit = sum / float64(len(r.Assets))
+	}
+	evt := events.RiskUpdatedEvent{
+		RiskID:           r.ID.String(),
+		TenantID:         r.TenantID.String(),
+		Probability:      float64(r.Probability),
+		Impact:           float64(r.Impact),
+		AssetCriticality: crit,
+		TriggeredBy:      "system",
+	}
+	return p.redis.Publish(ctx, events.RiskUpdated, evt)
+}
@@ -54,7 +54,7 @@ func (r *SubjectResolver) Resolve(ctx context.Context, tenantID uuid.UUID, trigg
 
 func defaultSubjectFor(t domain.AutomationTrigger) string {
 	switch t {
-	case domain.TriggerRiskCreated, domain.TriggerRiskScoreUpdated:
+	case domain.TriggerRiskCreated, domain.TriggerRiskScoreUpdated, domain.TriggerKRIThresholdCrossed:
 		return "risk"
 	case domain.TriggerIncidentCreated:
 		return "incident"
@@ -13,6 +13,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
@@ -241,3 +242,91 @@ func (g *PostMortemGate) PublishedReviewExists(ctx context.Context, tenantID str
 	}
 	return false, "the post-mortem is complete but not published yet"
 }
+
+// =============================================================================
+// Regulatory deadline reminders
+// =============================================================================
+
+// DeadlineAlerter reminds people that a notification to a regulator is coming
+// due. It goes to the profile's escalation audience and to the incident's owner
+// and assignee — the people who can actually file it — rather than to the
+// stakeholder list, which is about the incident, not the paperwork.
+type DeadlineAlerter struct {
+	dispatch ChannelDispatcher
+	logger   zerolog.Logger
+}
+
+// NewDeadlineAlerter builds the regulatory reminder adapter.
+func NewDeadlineAlerter(dispatch ChannelDispatcher, logger zerolog.Logger) *DeadlineAlerter {
+	return &DeadlineAlerter{dispatch: dispatch, logger: logger}
+}
+
+var _ appinc.DeadlineAlerter = (*DeadlineAlerter)(nil)
+
+// AlertDeadline sends one reminder. Severity climbs as the window closes, so the
+// channels that filter on severity (SMS) only fire when it is genuinely late.
+func (a *DeadlineAlerter) AlertDeadline(ctx context.Context, al appinc.DeadlineAlert) error {
+	if a == nil || a.dispatch == nil || al.Clock == nil || al.Incident == nil {
+		return nil
+	}
+	c, inc := al.Clock, al.Incident
+	regime := strings.ToUpper(string(c.Regime))
+	severity, when := "medium", "due in "+al.Remaining.Round(time.Minute).String()
+	switch {
+	case al.Remaining <= 0:
+		severity, when = "critical", "OVERDUE by "+(-al.Remaining).Round(time.Minute).String()
+	case al.Remaining <= 6*time.Hour:
+		severity = "high"
+	}
+	subject := fmt.Sprintf("[%s] %s %s for INC-%d %s", strings.ToUpper(severity), regime, c.StageLabel, inc.ID, when)
+	body := fmt.Sprintf("The %s to %s for incident INC-%d (%s) is %s (deadline %s UTC).\n\n"+
+		"Open the incident's regulatory tab for the pre-filled notification, then record the submission and its receipt.",
+		c.StageLabel, c.Authority, inc.ID, inc.Title, when, c.DueAt.UTC().Format("2006-01-02 15:04"))
+
+	channels := []string{domain.ChannelInApp, domain.ChannelEmail}
+	role := "admin"
+	if p := al.Profile; p != nil {
+		if len(p.EscalateChannels) > 0 {
+			channels = p.EscalateChannels
+		}
+		if p.EscalateToRole != "" {
+			role = p.EscalateToRole
+		}
+	}
+	facts := []appauto.Fact{
+		{Label: "Reference", Value: fmt.Sprintf("INC-%d", inc.ID)},
+		{Label: "Regime", Value: regime + " (" + c.Jurisdiction + ")"},
+		{Label: "Deadline", Value: c.DueAt.UTC().Format(time.RFC3339)},
+		{Label: "Reminder", Value: fmt.Sprintf("#%d", c.EscalationLevel+1)},
+	}
+	reqs := []appauto.NotifyRequest{{TargetRole: role}}
+	for _, id := range []*uuid.UUID{inc.OwnerID, inc.AssigneeID} {
+		if id != nil {
+			uid := *id
+			reqs = append(reqs, appauto.NotifyRequest{OwnerID: &uid})
+		}
+	}
+	var lastErr error
+	delivered := 0
+	for _, req := range reqs {
+		req.TenantID = c.TenantID
+		req.Channels = channels
+		req.Severity = severity
+		req.Subject = subject
+		req.Message = body
+		req.Facts = facts
+		req.ResourceType = "incident"
+		got, err := a.dispatch.Notify(ctx, req)
+		if err != nil && len(got) == 0 {
+			lastErr = err
+			continue
+		}
+		delivered++
+	}
+	if delivered == 0 && lastErr != nil {
+		a.logger.Warn().Err(lastErr).Uint("incident", inc.ID).Str("regime", regime).
+			Msg("regulatory deadline reminder could not be delivered on any channel")
+		return lastErr
+	}
+	return nil
+}
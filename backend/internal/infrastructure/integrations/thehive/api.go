@@ -0,0 +1,286 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package thehive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	thehiveapp "github.com/opendefender/openrisk/internal/application/thehive"
+	"github.com/opendefender/openrisk/pkg/netguard"
+)
+
+// Client speaks TheHive 5's v1 REST API for the bi-directional case sync. It is
+// separate from TheHiveAdapter (the legacy read-only poller behind SyncEngine),
+// which targets the v0 /api/case endpoint and falls back to mock data; nothing
+// here ever invents a case.
+type Client struct {
+	baseURL      string
+	organisation string
+	apiKey       string
+	http         *http.Client
+}
+
+var _ thehiveapp.CaseAPI = (*Client)(nil)
+
+// NewClient builds a v1 client. baseURL is the instance root, without /api.
+func NewClient(baseURL, organisation, apiKey string) *Client {
+	return &Client{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		organisation: organisation,
+		apiKey:       apiKey,
+		http:         &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// NewDialer is the thehiveapp.Dialer for production wiring. The base URL is
+// chosen by a tenant, so unless allowPrivate its clients refuse internal
+// addresses on every connection (netguard).
+func NewDialer(allowPrivate bool) thehiveapp.Dialer {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: 10 * time.Second, Control: netguard.Control(allowPrivate, "TheHive sync")}).DialContext
+	return func(baseURL, organisation, apiKey string) thehiveapp.CaseAPI {
+		c := NewClient(baseURL, organisation, apiKey)
+		c.http.Transport = transport
+		return c
+	}
+}
+
+// apiCase is the v1 case representation. Timestamps are epoch milliseconds.
+type apiCase struct {
+	ID          string   `json:"_id"`
+	UpdatedAt   int64    `json:"_updatedAt"`
+	CreatedAt   int64    `json:"_createdAt"`
+	Number      int      `json:"number"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Severity    int      `json:"severity"`
+	Status      string   `json:"status"`
+	Stage       string   `json:"stage"`
+	Tags        []string `json:"tags"`
+}
+
+func (c apiCase) remote() thehiveapp.RemoteCase {
+	updated := c.UpdatedAt
+	if updated == 0 {
+		updated = c.CreatedAt
+	}
+	return thehiveapp.RemoteCase{
+		ID: c.ID, Number: c.Number, Title: c.Title, Description: c.Description,
+		Severity: c.Severity, Status: c.Status, Stage: c.Stage, Tags: c.Tags,
+		UpdatedAt: time.UnixMilli(updated).UTC(),
+	}
+}
+
+type apiTask struct {
+	ID          string `json:"_id"`
+	UpdatedAt   int64  `json:"_updatedAt"`
+	CreatedAt   int64  `json:"_createdAt"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	DueDate     *int64 `json:"dueDate,omitempty"`
+}
+
+func (t apiTask) remote() thehiveapp.RemoteTask {
+	updated := t.UpdatedAt
+	if updated == 0 {
+		updated = t.CreatedAt
+	}
+	out := thehiveapp.RemoteTask{
+		ID: t.ID, Title: t.Title, Description: t.Description, Status: t.Status,
+		UpdatedAt: time.UnixMilli(updated).UTC(),
+	}
+	if t.DueDate != nil && *t.DueDate > 0 {
+		due := time.UnixMilli(*t.DueDate).UTC()
+		out.DueDate = &due
+	}
+	return out
+}
+
+type apiObservable struct {
+	ID       string `json:"_id"`
+	DataType string `json:"dataType"`
+	Data     string `json:"data"`
+}
+
+// CreateCase opens a case.
+func (c *Client) CreateCase(ctx context.Context, rc thehiveapp.RemoteCase) (*thehiveapp.RemoteCase, error) {
+	body := map[string]any{
+		"title":       rc.Title,
+		"description": rc.Description,
+		"severity":    rc.Severity,
+		"tags":        rc.Tags,
+	}
+	if rc.Status != "" {
+		body["status"] = rc.Status
+	}
+	var out apiCase
+	if err := c.do(ctx, http.MethodPost, "/api/v1/case", body, &out); err != nil {
+		return nil, err
+	}
+	r := out.remote()
+	return &r, nil
+}
+
+// UpdateCase patches a case.
+func (c *Client) UpdateCase(ctx context.Context, caseID string, patch map[string]any) error {
+	if len(patch) == 0 {
+		return nil
+	}
+	return c.do(ctx, http.MethodPatch, "/api/v1/case/"+url.PathEscape(caseID), patch, nil)
+}
+
+// GetCase returns the case, or nil when it does not exist (deleted, or not
+// visible to this organisation).
+func (c *Client) GetCase(ctx context.Context, caseID string) (*thehiveapp.RemoteCase, error) {
+	var out apiCase
+	err := c.do(ctx, http.MethodGet, "/api/v1/case/"+url.PathEscape(caseID), nil, &out)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	r := out.remote()
+	return &r, nil
+}
+
+// ListCasesUpdatedSince lists cases changed after since, oldest change first.
+func (c *Client) ListCasesUpdatedSince(ctx context.Context, since time.Time, limit int) ([]thehiveapp.RemoteCase, error) {
+	q := map[string]any{"query": []map[string]any{
+		{"_name": "listCase"},
+		{"_name": "filter", "_gt": map[string]any{"_field": "_updatedAt", "_value": since.UnixMilli()}},
+		{"_name": "sort", "_fields": []map[string]string{{"_updatedAt": "asc"}}},
+		{"_name": "page", "from": 0, "to": limit},
+	}}
+	var rows []apiCase
+	if err := c.do(ctx, http.MethodPost, "/api/v1/query?name=openrisk-cases", q, &rows); err != nil {
+		return nil, err
+	}
+	out := make([]thehiveapp.RemoteCase, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, r.remote())
+	}
+	return out, nil
+}
+
+// ListTasks lists a case's tasks.
+func (c *Client) ListTasks(ctx context.Context, caseID string) ([]thehiveapp.RemoteTask, error) {
+	var rows []apiTask
+	if err := c.do(ctx, http.MethodPost, "/api/v1/query?name=openrisk-tasks", caseQuery(caseID, "tasks"), &rows); err != nil {
+		return nil, err
+	}
+	out := make([]thehiveapp.RemoteTask, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, r.remote())
+	}
+	return out, nil
+}
+
+// CreateTask adds a task to a case.
+func (c *Client) CreateTask(ctx context.Context, caseID string, t thehiveapp.RemoteTask) (*thehiveapp.RemoteTask, error) {
+	body := map[string]any{"title": t.Title, "description": t.Description, "status": t.Status}
+	if t.DueDate != nil {
+		body["dueDate"] = t.DueDate.UnixMilli()
+	}
+	var out apiTask
+	if err := c.do(ctx, http.MethodPost, "/api/v1/case/"+url.PathEscape(caseID)+"/task", body, &out); err != nil {
+		return nil, err
+	}
+	r := out.remote()
+	return &r, nil
+}
+
+// UpdateTask patches a task.
+func (c *Client) UpdateTask(ctx context.Context, taskID string, patch map[string]any) error {
+	if len(patch) == 0 {
+		return nil
+	}
+	return c.do(ctx, http.MethodPatch, "/api/v1/task/"+url.PathEscape(taskID), patch, nil)
+}
+
+// ListObservables lists a case's observables.
+func (c *Client) ListObservables(ctx context.Context, caseID string) ([]thehiveapp.RemoteObservable, error) {
+	var rows []apiObservable
+	if err := c.do(ctx, http.MethodPost, "/api/v1/query?name=openrisk-observables", caseQuery(caseID, "observables"), &rows); err != nil {
+		return nil, err
+	}
+	out := make([]thehiveapp.RemoteObservable, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, thehiveapp.RemoteObservable{ID: r.ID, DataType: r.DataType, Data: r.Data})
+	}
+	return out, nil
+}
+
+func caseQuery(caseID, child string) map[string]any {
+	return map[string]any{"query": []map[string]any{
+		{"_name": "getCase", "idOrName": caseID},
+		{"_name": child},
+	}}
+}
+
+// apiError is a non-2xx answer.
+type apiError struct {
+	Status int
+	Body   string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("thehive: HTTP %d: %s", e.Status, e.Body)
+}
+
+func isNotFound(err error) bool {
+	ae, ok := err.(*apiError)
+	return ok && ae.Status == http.StatusNotFound
+}
+
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("thehive: encode request: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("thehive: build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.organisation != "" {
+		req.Header.Set("X-Organisation", c.organisation)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("thehive: %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &apiError{Status: resp.StatusCode, Body: strings.TrimSpace(string(msg))}
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("thehive: decode %s %s: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package thehive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	thehiveapp "github.com/opendefender/openrisk/internal/application/thehive"
+)
+
+// fakeTheHive is a local stand-in for the v1 API: enough of case, task, query
+// and observable handling to drive the client end to end.
+type fakeTheHive struct {
+	mu          sync.Mutex
+	seq         int
+	clock       int64
+	cases       map[string]map[string]any
+	tasks       map[string][]map[string]any // case id → tasks
+	observables map[string][]map[string]any
+	headers     http.Header
+}
+
+func newFakeTheHive() *fakeTheHive {
+	return &fakeTheHive{
+		clock:       time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC).UnixMilli(),
+		cases:       map[string]map[string]any{},
+		tasks:       map[string][]map[string]any{},
+		observables: map[string][]map[string]any{},
+	}
+}
+
+func (f *fakeTheHive) tick() int64 { f.clock += 1000; return f.clock }
+
+func (f *fakeTheHive) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.headers = r.Header.Clone()
+	if r.Header.Get("Authorization") != "Bearer secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var body map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	path := r.URL.Path
+
+	switch {
+	case r.Method == http.MethodPost && path == "/api/v1/case":
+		f.seq++
+		id := fmt.Sprintf("~%d", 4000+f.seq)
+		body["_id"], body["number"], body["_updatedAt"] = id, f.seq, f.tick()
+		f.cases[id] = body
+		_ = json.NewEncoder(w).Encode(body)
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/api/v1/case/"):
+		c, ok := f.cases[strings.TrimPrefix(path, "/api/v1/case/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(c)
+	case r.Method == http.MethodPatch && strings.HasPrefix(path, "/api/v1/case/"):
+		c := f.cases[strings.TrimPrefix(path, "/api/v1/case/")]
+		for k, v := range body {
+			c[k] = v
+		}
+		c["_updatedAt"] = f.tick()
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/task"):
+		caseID := strings.TrimSuffix(strings.TrimPrefix(path, "/api/v1/case/"), "/task")
+		f.seq++
+		body["_id"], body["_updatedAt"] = fmt.Sprintf("~t%d", f.seq), f.tick()
+		f.tasks[caseID] = append(f.tasks[caseID], body)
+		_ = json.NewEncoder(w).Encode(body)
+	case r.Method == http.MethodPost && path == "/api/v1/query":
+		ops := body["query"].([]any)
+		first := ops[0].(map[string]any)
+		switch first["_name"] {
+		case "listCase":
+			since := int64(ops[1].(map[string]any)["_gt"].(map[string]any)["_value"].(float64))
+			out := []map[string]any{}
+			for _, c := range f.cases {
+				if c["_updatedAt"].(int64) > since {
+					out = append(out, c)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(out)
+		case "getCase":
+			id := first["idOrName"].(string)
+			if ops[1].(map[string]any)["_name"] == "tasks" {
+				_ = json.NewEncoder(w).Encode(f.tasks[id])
+			} else {
+				_ = json.NewEncoder(w).Encode(f.observables[id])
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestClient_CaseRoundTripAgainstFakeServer(t *testing.T) {
+	fake := newFakeTheHive()
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+	ctx := context.Background()
+	c := NewClient(srv.URL+"/", "soc-org", "secret")
+
+	created, err := c.CreateCase(ctx, thehiveapp.RemoteCase{
+		Title: "Ransomware on FS-01", Description: "encrypted shares", Severity: 4,
+		Status: "New", Tags: []string{"openrisk"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "~4001", created.ID)
+	assert.Equal(t, 1, created.Number)
+	assert.Equal(t, "soc-org", fake.headers.Get("X-Organisation"))
+
+	require.NoError(t, c.UpdateCase(ctx, created.ID, map[string]any{"status": "InProgress"}))
+	got, err := c.GetCase(ctx, created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "InProgress", got.Status)
+	assert.True(t, got.UpdatedAt.After(created.UpdatedAt))
+
+	missing, err := c.GetCase(ctx, "~nope")
+	require.NoError(t, err, "a missing case is nil, not an error")
+	assert.Nil(t, missing)
+
+	list, err := c.ListCasesUpdatedSince(ctx, created.UpdatedAt, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "Ransomware on FS-01", list[0].Title)
+
+	due := time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC)
+	task, err := c.CreateTask(ctx, created.ID, thehiveapp.RemoteTask{Title: "Isolate host", Status: "Waiting", DueDate: &due})
+	require.NoError(t, err)
+	tasks, err := c.ListTasks(ctx, created.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, task.ID, tasks[0].ID)
+	require.NotNil(t, tasks[0].DueDate)
+	assert.True(t, due.Equal(*tasks[0].DueDate))
+
+	fake.observables[created.ID] = []map[string]any{{"_id": "~o1", "dataType": "ip", "data": "10.0.0.5"}}
+	obs, err := c.ListObservables(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []thehiveapp.RemoteObservable{{ID: "~o1", DataType: "ip", Data: "10.0.0.5"}}, obs)
+}
+
+func TestClient_SurfacesAuthFailures(t *testing.T) {
+	srv := httptest.NewServer(newFakeTheHive())
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL, "", "wrong").GetCase(context.Background(), "~1")
+	require.Error(t, err, "a 401 must not read as a missing case")
+	assert.Contains(t, err.Error(), "401")
+}
+
+func TestNewDialer_RefusesInternalAddresses(t *testing.T) {
+	srv := httptest.NewServer(newFakeTheHive())
+	defer srv.Close()
+
+	_, err := NewDialer(false)(srv.URL, "", "secret").GetCase(context.Background(), "~1")
+	require.Error(t, err, "a loopback instance is refused on the connection")
+	assert.Contains(t, err.Error(), "not reachable from TheHive sync")
+
+	_, err = NewDialer(true)(srv.URL, "", "secret").GetCase(context.Background(), "~1")
+	assert.NoError(t, err, "operators may allow private networks")
+}
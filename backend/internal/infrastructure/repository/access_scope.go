@@ -0,0 +1,224 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// AccessScoped narrows a query over a governed resource to the records the
+// request's access scope lets its user see (domain.AccessScope). A context
+// without a scope — a worker, a system call — or a user no policy applies to
+// leaves the query as it is.
+//
+// The main table of the query must be the resource's table (risks, evidences).
+// Every read a user can reach goes through this: list, search, get by id,
+// delete. A record hidden here is a record that does not exist for the user,
+// which is why the use cases answer 404 rather than 403 for it.
+func AccessScoped(ctx context.Context, r domain.AccessResource) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		sql, args, ok := accessScopeSQL(tx.Dialector.Name() == "postgres", domain.AccessScopeFrom(ctx), r)
+		if !ok {
+			return tx
+		}
+		return tx.Where(sql, args...)
+	}
+}
+
+// accessColumn is how one record attribute reads in SQL. A scalar attribute is
+// a column of the main row. A list attribute is the set of values of an
+// EXISTS subquery correlated to the main row.
+type accessColumn struct {
+	scalar string
+
+	from  string
+	where string
+	value string
+}
+
+// accessColumns implements domain.AccessAttributes. Record in the
+// policy repository read the same values for the explain endpoint; the two are
+// tested against each other.
+var accessColumns = map[domain.AccessResource]map[string]accessColumn{
+	domain.AccessResourceRisk: {
+		"id":             {scalar: "risks.id"},
+		"owner_id":       {scalar: "risks.owner_id"},
+		"assignee_id":    {scalar: "risks.assignee_id"},
+		"reviewer_id":    {scalar: "risks.reviewer_id"},
+		"created_by":     {scalar: "risks.created_by"},
+		"category_id":    {scalar: "risks.category_id"},
+		"business_unit":  {scalar: "risks.business_unit"},
+		"classification": {scalar: "risks.classification"},
+		"status":         {scalar: "risks.status"},
+		"criticality":    {scalar: "risks.criticality"},
+		"tags":           {from: "unnest(risks.tags) AS t(v)", where: "1=1", value: "t.v"},
+		// A risk's assets are its direct asset and its many-to-many links.
+		"asset.business_unit": {from: "assets a", where: riskAssetsWhere, value: "a.business_unit"},
+		"asset.criticality":   {from: "assets a", where: riskAssetsWhere, value: "a.criticality"},
+		"framework": {
+			from:  "risk_control_mappings m",
+			where: "m.risk_id = risks.id AND m.tenant_id = risks.tenant_id AND m.deleted_at IS NULL",
+			value: "m.framework_id",
+		},
+	},
+	domain.AccessResourceEvidence: {
+		"id":           {scalar: "evidences.id"},
+		"owner_id":     {scalar: "evidences.owner_id"},
+		"assignee_id":  {scalar: "evidences.assignee_id"},
+		"reviewer_id":  {scalar: "evidences.reviewer_id"},
+		"collected_by": {scalar: "evidences.collected_by"},
+		"type":         {scalar: "evidences.type"},
+		"review":       {scalar: "evidences.review"},
+		"source":       {scalar: "evidences.source"},
+		"control": {
+			from:  "evidence_control_links l",
+			where: "l.evidence_id = evidences.id AND l.tenant_id = evidences.tenant_id",
+			value: "l.control_id",
+		},
+		"framework": {
+			from:  "evidence_control_links l JOIN compliance_controls cc ON cc.id = l.control_id AND cc.tenant_id = l.tenant_id",
+			where: "l.evidence_id = evidences.id AND l.tenant_id = evidences.tenant_id AND cc.deleted_at IS NULL",
+			value: "cc.framework_id",
+		},
+	},
+}
+
+const riskAssetsWhere = `a.tenant_id = risks.tenant_id AND a.deleted_at IS NULL
+	AND (a.id = risks.asset_id OR a.id IN (SELECT ra.asset_id FROM risk_assets ra WHERE ra.risk_id = risks.id))`
+
+// accessScopeSQL compiles the policies that apply to the scope's user into a
+// WHERE fragment: NOT (deny) for every deny policy, and the OR of the allow
+// conditions when there is at least one allow policy. ok is false when
+// nothing applies.
+//
+// Every predicate compiles to a two-valued expression (never NULL), so NOT
+// means exactly what it means in domain.AccessCondition.Eval.
+func accessScopeSQL(pg bool, scope *domain.AccessScope, r domain.AccessResource) (string, []any, bool) {
+	deny, allow := scope.Applicable(r)
+	if len(deny) == 0 && len(allow) == 0 {
+		return "", nil, false
+	}
+	c := &accessCompiler{pg: pg, r: r, subject: &scope.Subject}
+	var parts []string
+	for _, p := range deny {
+		parts = append(parts, "NOT "+c.cond(p.Condition))
+	}
+	if len(allow) > 0 {
+		var ors []string
+		for _, p := range allow {
+			ors = append(ors, c.cond(p.Condition))
+		}
+		parts = append(parts, "("+strings.Join(ors, " OR ")+")")
+	}
+	return strings.Join(parts, " AND "), c.args, true
+}
+
+const (
+	sqlTrue  = "(1=1)"
+	sqlFalse = "(1=0)"
+)
+
+type accessCompiler struct {
+	pg      bool
+	r       domain.AccessResource
+	subject *domain.AccessSubject
+	args    []any
+}
+
+func (c *accessCompiler) cond(cond *domain.AccessCondition) string {
+	switch {
+	case cond == nil:
+		return sqlTrue
+	case cond.All != nil:
+		return c.join(cond.All, " AND ", sqlTrue)
+	case cond.Any != nil:
+		return c.join(cond.Any, " OR ", sqlFalse)
+	case cond.Not != nil:
+		return "(NOT " + c.cond(cond.Not) + ")"
+	}
+
+	// A test of the user's own attributes is a constant for this request.
+	if strings.HasPrefix(cond.Attr, "user.") {
+		if cond.Eval(nil, c.subject, nil) {
+			return sqlTrue
+		}
+		return sqlFalse
+	}
+	col, ok := accessColumns[c.r][cond.Attr]
+	if !ok {
+		// Unknown attributes are refused when a policy is saved; one that slips
+		// through matches nothing, as it does in Eval.
+		return sqlFalse
+	}
+
+	switch cond.Op {
+	case domain.AccessOpIn:
+		want := lowerAll(c.subject.ResolveValues(cond.Values))
+		if len(want) == 0 {
+			return sqlFalse
+		}
+		if cond.Attr == "tags" && !c.pg {
+			return c.sqliteTagsIn(want)
+		}
+		if col.scalar != "" {
+			c.args = append(c.args, want)
+			return "(" + col.scalar + " IS NOT NULL AND LOWER(CAST(" + col.scalar + " AS TEXT)) IN ?)"
+		}
+		c.args = append(c.args, want)
+		return "EXISTS (SELECT 1 FROM " + col.from + " WHERE " + col.where +
+			" AND LOWER(CAST(" + col.value + " AS TEXT)) IN ?)"
+	case domain.AccessOpEmpty:
+		if cond.Attr == "tags" && !c.pg {
+			return "(risks.tags IS NULL OR risks.tags IN ('', '{}'))"
+		}
+		if col.scalar != "" {
+			return "(" + col.scalar + " IS NULL OR CAST(" + col.scalar + " AS TEXT) = '')"
+		}
+		return "NOT EXISTS (SELECT 1 FROM " + col.from + " WHERE " + col.where +
+			" AND " + col.value + " IS NOT NULL AND CAST(" + col.value + " AS TEXT) <> '')"
+	}
+	return sqlFalse
+}
+
+func (c *accessCompiler) join(children []domain.AccessCondition, op, empty string) string {
+	if len(children) == 0 {
+		return empty
+	}
+	parts := make([]string, len(children))
+	for i := range children {
+		parts[i] = c.cond(&children[i])
+	}
+	return "(" + strings.Join(parts, op) + ")"
+}
+
+// sqliteTagsIn matches tags on sqlite, where text[] is stored as the Postgres
+// array literal pq writes ({"a","b"}, every element quoted). Test databases
+// only; production is Postgres and unnests the array.
+func (c *accessCompiler) sqliteTagsIn(want []string) string {
+	likes := make([]string, len(want))
+	for i, w := range want {
+		likes[i] = `LOWER(risks.tags) LIKE ? ESCAPE '\'`
+		c.args = append(c.args, `%"`+escapeLike(w)+`"%`)
+	}
+	return "(risks.tags IS NOT NULL AND (" + strings.Join(likes, " OR ") + "))"
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func lowerAll(vs []string) []string {
+	out := make([]string, len(vs))
+	for i, v := range vs {
+		out[i] = strings.ToLower(v)
+	}
+	return out
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormAccessPolicyRepository implements domain.AccessPolicyRepository.
+type GormAccessPolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAccessPolicyRepository builds the policy store.
+func NewGormAccessPolicyRepository(db *gorm.DB) *GormAccessPolicyRepository {
+	return &GormAccessPolicyRepository{db: db}
+}
+
+var _ domain.AccessPolicyRepository = (*GormAccessPolicyRepository)(nil)
+
+func (r *GormAccessPolicyRepository) List(ctx context.Context, tenantID uuid.UUID) ([]domain.AccessPolicy, error) {
+	var rows []domain.AccessPolicy
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).
+		Order("resource, name, id").Find(&rows).Error
+	return rows, err
+}
+
+func (r *GormAccessPolicyRepository) Get(ctx context.Context, tenantID, id uuid.UUID) (*domain.AccessPolicy, error) {
+	var p domain.AccessPolicy
+	err := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).Take(&p).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *GormAccessPolicyRepository) Create(ctx context.Context, p *domain.AccessPolicy) error {
+	if p.TenantID == uuid.Nil {
+		return domain.NewValidationError("tenant_id is required")
+	}
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(p).Error
+}
+
+func (r *GormAccessPolicyRepository) Update(ctx context.Context, p *domain.AccessPolicy) error {
+	res := r.db.WithContext(ctx).Model(&domain.AccessPolicy{}).
+		Where("id = ? AND tenant_id = ?", p.ID, p.TenantID).
+		Select("name", "description", "resource", "effect", "subjects", "condition", "enabled", "updated_at").
+		Updates(p)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("access policy", p.ID)
+	}
+	return nil
+}
+
+func (r *GormAccessPolicyRepository) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&domain.AccessPolicy{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("access policy", id)
+	}
+	return nil
+}
+
+func (r *GormAccessPolicyRepository) Subject(ctx context.Context, tenantID, userID uuid.UUID) (*domain.AccessSubject, error) {
+	var row struct {
+		Role         string
+		BusinessRole string
+		Department   string
+	}
+	err := r.db.WithContext(ctx).Table("organization_members m").
+		Select("m.role, m.business_role, COALESCE(u.department, '') AS department").
+		Joins("LEFT JOIN users u ON u.id = m.user_id").
+		Where("m.organization_id = ? AND m.user_id = ?", tenantID, userID).
+		Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read member: %w", err)
+	}
+	s := &domain.AccessSubject{
+		UserID:       userID,
+		Role:         domain.MemberRole(row.Role),
+		BusinessRole: domain.BusinessRoleKey(row.BusinessRole),
+		Department:   row.Department,
+	}
+	var attrs domain.AccessSubjectAttributes
+	err = r.db.WithContext(ctx).Where("tenant_id = ? AND user_id = ?", tenantID, userID).Take(&attrs).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to read member attributes: %w", err)
+	}
+	s.Attributes = attrs.Attributes
+	return s, nil
+}
+
+func (r *GormAccessPolicyRepository) SaveSubjectAttributes(ctx context.Context, a *domain.AccessSubjectAttributes) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"attributes", "updated_by", "updated_at"}),
+	}).Create(a).Error
+}
+
+// Record reads a record's attributes from the same columns and joins the SQL
+// scope compiles against (accessColumns), without applying any scope.
+func (r *GormAccessPolicyRepository) Record(ctx context.Context, tenantID uuid.UUID, res domain.AccessResource, id uuid.UUID) (domain.AccessRecord, error) {
+	switch res {
+	case domain.AccessResourceRisk:
+		return r.riskRecord(ctx, tenantID, id)
+	case domain.AccessResourceEvidence:
+		return r.evidenceRecord(ctx, tenantID, id)
+	}
+	return nil, domain.NewValidationError(fmt.Sprintf("unknown resource %q", res))
+}
+
+func (r *GormAccessPolicyRepository) riskRecord(ctx context.Context, tenantID, id uuid.UUID) (domain.AccessRecord, error) {
+	var risk domain.Risk
+	err := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).Take(&risk).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rec := domain.AccessRecord{
+		"id":             {risk.ID.String()},
+		"owner_id":       uuidValues(risk.OwnerID),
+		"assignee_id":    uuidValues(risk.AssigneeID),
+		"reviewer_id":    uuidValues(risk.ReviewerID),
+		"created_by":     {risk.CreatedBy.String()},
+		"category_id":    uuidValues(risk.CategoryID),
+		"business_unit":  {risk.BusinessUnit},
+		"classification": {risk.Classification},
+		"status":         {string(risk.Status)},
+		"criticality":    {string(risk.Criticality)},
+		"tags":           risk.Tags,
+	}
+
+	var assets []struct {
+		BusinessUnit string
+		Criticality  string
+	}
+	err = r.db.WithContext(ctx).Table("assets a").Select("a.business_unit, a.criticality").
+		Where(`a.tenant_id = ? AND a.deleted_at IS NULL
+			AND (a.id = ? OR a.id IN (SELECT ra.asset_id FROM risk_assets ra WHERE ra.risk_id = ?))`,
+			tenantID, risk.AssetID, risk.ID).
+		Scan(&assets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read risk assets: %w", err)
+	}
+	for _, a := range assets {
+		rec["asset.business_unit"] = append(rec["asset.business_unit"], a.BusinessUnit)
+		rec["asset.criticality"] = append(rec["asset.criticality"], a.Criticality)
+	}
+
+	var frameworks []string
+	err = r.db.WithContext(ctx).Table("risk_control_mappings").Distinct("framework_id").
+		Where("risk_id = ? AND tenant_id = ? AND deleted_at IS NULL", risk.ID, tenantID).
+		Pluck("framework_id", &frameworks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read risk frameworks: %w", err)
+	}
+	rec["framework"] = frameworks
+	return rec, nil
+}
+
+func (r *GormAccessPolicyRepository) evidenceRecord(ctx context.Context, tenantID, id uuid.UUID) (domain.AccessRecord, error) {
+	var ev domain.Evidence
+	err := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).Take(&ev).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rec := domain.AccessRecord{
+		"id":           {ev.ID.String()},
+		"owner_id":     uuidValues(ev.OwnerID),
+		"assignee_id":  uuidValues(ev.AssigneeID),
+		"reviewer_id":  uuidValues(ev.ReviewerID),
+		"collected_by": uuidValues(ev.CollectedBy),
+		"type":         {string(ev.Type)},
+		"review":       {string(ev.Review)},
+		"source":       {string(ev.Source)},
+	}
+
+	var links []struct {
+		ControlID   string
+		FrameworkID *string
+	}
+	err = r.db.WithContext(ctx).Table("evidence_control_links l").
+		Select("l.control_id, cc.framework_id").
+		Joins("LEFT JOIN compliance_controls cc ON cc.id = l.control_id AND cc.tenant_id = l.tenant_id AND cc.deleted_at IS NULL").
+		Where("l.evidence_id = ? AND l.tenant_id = ?", ev.ID, tenantID).
+		Scan(&links).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read evidence links: %w", err)
+	}
+	for _, l := range links {
+		rec["control"] = append(rec["control"], l.ControlID)
+		if l.FrameworkID != nil {
+			rec["framework"] = append(rec["framework"], *l.FrameworkID)
+		}
+	}
+	return rec, nil
+}
+
+// Visible runs the scope attached to ctx against one live record.
+func (r *GormAccessPolicyRepository) Visible(ctx context.Context, tenantID uuid.UUID, res domain.AccessResource, id uuid.UUID) (bool, error) {
+	var model any
+	switch res {
+	case domain.AccessResourceRisk:
+		model = &domain.Risk{}
+	case domain.AccessResourceEvidence:
+		model = &domain.Evidence{}
+	default:
+		return false, domain.NewValidationError(fmt.Sprintf("unknown resource %q", res))
+	}
+	var n int64
+	err := r.db.WithContext(ctx).Model(model).Scopes(AccessScoped(ctx, res)).
+		Where("id = ? AND tenant_id = ?", id, tenantID).Count(&n).Error
+	return n > 0, err
+}
+
+func uuidValues(id *uuid.UUID) []string {
+	if id == nil {
+		return nil
+	}
+	return []string{id.String()}
+}
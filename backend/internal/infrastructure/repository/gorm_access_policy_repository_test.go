@@ -0,0 +1,349 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/internal/testsupport/sqliteschema"
+)
+
+func newAccessPolicyTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent), DisableForeignKeyConstraintWhenMigrating: true,
+	})
+	require.NoError(t, err)
+	for _, ddl := range []string{
+		`CREATE TABLE risks (id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, deleted_at DATETIME)`,
+		`CREATE TABLE assets (id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, deleted_at DATETIME)`,
+		`CREATE TABLE risk_assets (risk_id TEXT NOT NULL, asset_id TEXT NOT NULL)`,
+		`CREATE TABLE compliance_controls (id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, framework_id TEXT NOT NULL, deleted_at DATETIME)`,
+		`CREATE TABLE risk_control_mappings (id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, risk_id TEXT NOT NULL,
+			framework_id TEXT NOT NULL, control_id TEXT, deleted_at DATETIME)`,
+		`CREATE TABLE organization_members (id TEXT PRIMARY KEY, organization_id TEXT NOT NULL, user_id TEXT NOT NULL,
+			role TEXT NOT NULL, business_role TEXT)`,
+		`CREATE TABLE users (id TEXT PRIMARY KEY, department TEXT)`,
+	} {
+		require.NoError(t, db.Exec(ddl).Error)
+	}
+	require.NoError(t, sqliteschema.Reconcile(db, "risks", &domain.Risk{}))
+	require.NoError(t, sqliteschema.Reconcile(db, "assets", &domain.Asset{}))
+	require.NoError(t, db.AutoMigrate(&domain.Evidence{}, &domain.EvidenceControlLink{},
+		&domain.AccessPolicy{}, &domain.AccessSubjectAttributes{}))
+	return db
+}
+
+// The explain endpoint evaluates policies in Go (AccessScope.Decide) while
+// the repositories filter in SQL (AccessScoped). This checks that both give
+// the same answer for every user and every record, with the three policies
+// docs/ACCESS_POLICIES.md walks through, and that a query through a scoped
+// repository returns exactly the visible records.
+func TestAccessPolicies_SQLAgreesWithEvaluator(t *testing.T) {
+	ctx := context.Background()
+	db := newAccessPolicyTestDB(t)
+	repo := NewGormAccessPolicyRepository(db)
+	tenant := uuid.New()
+	exec := func(sql string, args ...any) { require.NoError(t, db.Exec(sql, args...).Error) }
+
+	iso, soc := uuid.New(), uuid.New()
+	ctlISO, ctlSOC := uuid.New(), uuid.New()
+	exec(`INSERT INTO compliance_controls (id, tenant_id, framework_id) VALUES (?, ?, ?), (?, ?, ?)`,
+		ctlISO, tenant, iso, ctlSOC, tenant, soc)
+
+	retail, corporate := uuid.New(), uuid.New()
+	exec(`INSERT INTO assets (id, tenant_id, name, business_unit) VALUES (?, ?, 'pos', 'Retail'), (?, ?, 'erp', 'Corporate')`,
+		retail, tenant, corporate, tenant)
+
+	users := map[string]uuid.UUID{}
+	for _, m := range []struct{ name, role, business string }{
+		{"admin", "admin", ""}, {"auditor", "user", "auditor"}, {"manager", "user", "risk_manager"},
+		{"owner", "user", "risk_owner"}, {"ciso", "user", "rssi"}, {"analyst", "user", "security_analyst"},
+	} {
+		id := uuid.New()
+		users[m.name] = id
+		exec(`INSERT INTO organization_members (id, organization_id, user_id, role, business_role) VALUES (?, ?, ?, ?, ?)`,
+			uuid.New(), tenant, id, m.role, m.business)
+	}
+	require.NoError(t, repo.SaveSubjectAttributes(ctx, &domain.AccessSubjectAttributes{TenantID: tenant, UserID: users["auditor"],
+		Attributes: domain.AccessAttributeValues{"audit_scope": {iso.String()}}}))
+	require.NoError(t, repo.SaveSubjectAttributes(ctx, &domain.AccessSubjectAttributes{TenantID: tenant, UserID: users["manager"],
+		Attributes: domain.AccessAttributeValues{"business_unit": {"retail"}}}))
+
+	risks := map[string]uuid.UUID{}
+	addRisk := func(name, tags, classification string, owner *uuid.UUID, asset *uuid.UUID) uuid.UUID {
+		id := uuid.New()
+		risks[name] = id
+		exec(`INSERT INTO risks (id, tenant_id, title, tags, classification, owner_id, asset_id) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			id, tenant, name, tags, classification, owner, asset)
+		return id
+	}
+	owner := users["owner"]
+	addRisk("retail-direct", `{"pci"}`, "", nil, &retail)
+	linked := addRisk("retail-linked", `{}`, "", nil, nil)
+	exec(`INSERT INTO risk_assets (risk_id, asset_id) VALUES (?, ?)`, linked, retail)
+	mapped := addRisk("iso-mapped", `{"iso"}`, "", nil, &corporate)
+	exec(`INSERT INTO risk_control_mappings (id, tenant_id, risk_id, framework_id, control_id) VALUES (?, ?, ?, ?, ?)`,
+		uuid.New(), tenant, mapped, iso, ctlISO)
+	addRisk("secret", `{"Confidential"}`, "", &owner, &corporate)
+	addRisk("classified", `{}`, "confidential", nil, &retail)
+	addRisk("plain", `{}`, "", nil, nil)
+
+	evidence := map[string]uuid.UUID{}
+	for _, e := range []struct {
+		name    string
+		control *uuid.UUID
+	}{{"iso-proof", &ctlISO}, {"soc-proof", &ctlSOC}, {"unlinked", nil}} {
+		ev := domain.Evidence{ID: uuid.New(), TenantID: tenant, Title: e.name}
+		require.NoError(t, db.Create(&ev).Error)
+		evidence[e.name] = ev.ID
+		if e.control != nil {
+			require.NoError(t, db.Create(&domain.EvidenceControlLink{ID: uuid.New(), TenantID: tenant, EvidenceID: ev.ID, ControlID: *e.control}).Error)
+		}
+	}
+
+	auditors := domain.AccessSubjects{BusinessRoles: []domain.BusinessRoleKey{domain.BusinessRoleAuditor}}
+	inScope := &domain.AccessCondition{Attr: "framework", Op: domain.AccessOpIn, Values: []string{"$user.audit_scope"}}
+	for _, p := range []domain.AccessPolicy{
+		{Name: "Auditors read their audit scope", Resource: domain.AccessResourceRisk, Effect: domain.AccessAllow,
+			Subjects: auditors, Condition: inScope},
+		{Name: "Auditors read evidence of their audit scope", Resource: domain.AccessResourceEvidence, Effect: domain.AccessAllow,
+			Subjects: auditors, Condition: inScope},
+		{Name: "BU managers see their BU", Resource: domain.AccessResourceRisk, Effect: domain.AccessAllow,
+			Subjects:  domain.AccessSubjects{BusinessRoles: []domain.BusinessRoleKey{domain.BusinessRoleRiskManager}},
+			Condition: &domain.AccessCondition{Attr: "asset.business_unit", Op: domain.AccessOpIn, Values: []string{"$user.business_unit"}}},
+		{Name: "Confidential risks: owner and CISO only", Resource: domain.AccessResourceRisk, Effect: domain.AccessDeny,
+			Condition: &domain.AccessCondition{All: []domain.AccessCondition{
+				{Any: []domain.AccessCondition{
+					{Attr: "tags", Op: domain.AccessOpIn, Values: []string{"confidential"}},
+					{Attr: "classification", Op: domain.AccessOpIn, Values: []string{"confidential"}},
+				}},
+				{Not: &domain.AccessCondition{Attr: "owner_id", Op: domain.AccessOpIn, Values: []string{"$user.id"}}},
+				{Not: &domain.AccessCondition{Attr: "user.business_role", Op: domain.AccessOpIn, Values: []string{"rssi"}}},
+			}}},
+	} {
+		p.TenantID, p.Enabled = tenant, true
+		require.NoError(t, p.Validate())
+		require.NoError(t, repo.Create(ctx, &p))
+	}
+	policies, err := repo.List(ctx, tenant)
+	require.NoError(t, err)
+	require.Len(t, policies, 4)
+
+	want := map[string][]string{
+		"admin":   {"retail-direct", "retail-linked", "iso-mapped", "secret", "classified", "plain"},
+		"auditor": {"iso-mapped"},
+		"manager": {"retail-direct", "retail-linked"},
+		"owner":   {"retail-direct", "retail-linked", "iso-mapped", "secret", "plain"},
+		"ciso":    {"retail-direct", "retail-linked", "iso-mapped", "secret", "classified", "plain"},
+		"analyst": {"retail-direct", "retail-linked", "iso-mapped", "plain"},
+	}
+	for who, userID := range users {
+		subject, err := repo.Subject(ctx, tenant, userID)
+		require.NoError(t, err)
+		require.NotNil(t, subject)
+		scope := &domain.AccessScope{Subject: *subject, Policies: policies}
+		sctx := domain.WithAccessScope(ctx, scope)
+
+		var visible []string
+		for name, id := range risks {
+			rec, err := repo.Record(ctx, tenant, domain.AccessResourceRisk, id)
+			require.NoError(t, err)
+			decision := scope.Decide(domain.AccessResourceRisk, rec)
+			inSQL, err := repo.Visible(sctx, tenant, domain.AccessResourceRisk, id)
+			require.NoError(t, err)
+			assert.Equal(t, decision.Visible, inSQL, "%s on risk %s: Go says %v (%s)", who, name, decision.Visible, decision.Reason)
+			if inSQL {
+				visible = append(visible, name)
+			}
+		}
+		assert.ElementsMatch(t, want[who], visible, who)
+
+		var listed []string
+		require.NoError(t, db.Model(&domain.Risk{}).Scopes(AccessScoped(sctx, domain.AccessResourceRisk)).
+			Where("tenant_id = ?", tenant).Pluck("title", &listed).Error)
+		assert.ElementsMatch(t, want[who], listed, who)
+
+		for name, id := range evidence {
+			rec, err := repo.Record(ctx, tenant, domain.AccessResourceEvidence, id)
+			require.NoError(t, err)
+			decision := scope.Decide(domain.AccessResourceEvidence, rec)
+			inSQL, err := repo.Visible(sctx, tenant, domain.AccessResourceEvidence, id)
+			require.NoError(t, err)
+			assert.Equal(t, decision.Visible, inSQL, "%s on evidence %s", who, name)
+			assert.Equal(t, who != "auditor" || name == "iso-proof", inSQL, "%s on evidence %s", who, name)
+		}
+	}
+
+	// Without a scope on the context, a worker sees everything.
+	all, err := repo.Visible(ctx, tenant, domain.AccessResourceRisk, risks["classified"])
+	require.NoError(t, err)
+	assert.True(t, all)
+}
+
+// The isolation registry cites this test for the /access-policies routes.
+func TestAccessPolicyRepo_TenantScoped(t *testing.T) {
+	ctx := context.Background()
+	db := newAccessPolicyTestDB(t)
+	repo := NewGormAccessPolicyRepository(db)
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	p := &domain.AccessPolicy{TenantID: tenantA, Name: "p", Resource: domain.AccessResourceRisk,
+		Effect: domain.AccessDeny, Enabled: true,
+		Condition: &domain.AccessCondition{Attr: "tags", Op: domain.AccessOpIn, Values: []string{"secret"}}}
+	require.NoError(t, repo.Create(ctx, p))
+
+	got, err := repo.Get(ctx, tenantB, p.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got, "another tenant cannot read the policy")
+	list, err := repo.List(ctx, tenantB)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+	other := *p
+	other.TenantID, other.Name = tenantB, "hijack"
+	assert.Error(t, repo.Update(ctx, &other), "another tenant cannot update the policy")
+	assert.Error(t, repo.Delete(ctx, tenantB, p.ID), "another tenant cannot delete the policy")
+
+	got, err = repo.Get(ctx, tenantA, p.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "p", got.Name)
+	assert.Equal(t, []string{"secret"}, got.Condition.Values)
+
+	// A member of tenant A is not a subject of tenant B.
+	user := uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO organization_members (id, organization_id, user_id, role) VALUES (?, ?, ?, 'user')`,
+		uuid.New(), tenantA, user).Error)
+	s, err := repo.Subject(ctx, tenantB, user)
+	require.NoError(t, err)
+	assert.Nil(t, s)
+
+	// Another tenant's record does not exist for explain.
+	risk := uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO risks (id, tenant_id, title) VALUES (?, ?, 'r')`, risk, tenantA).Error)
+	rec, err := repo.Record(ctx, tenantB, domain.AccessResourceRisk, risk)
+	require.NoError(t, err)
+	assert.Nil(t, rec)
+}
+
+// The per-risk lookups of the bowtie, the control-test residual, the
+// mitigation programmes and the appetite evaluation read risks by id outside
+// the risk repository's list and get; they must hide what those hide.
+func TestAccessPolicies_PerRiskLookupsAreScoped(t *testing.T) {
+	db := newAccessPolicyTestDB(t)
+	tenant, shown, hidden := uuid.New(), uuid.New(), uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO risks (id, tenant_id, title, classification) VALUES (?, ?, 'a', ''), (?, ?, 'b', 'confidential')`,
+		shown, tenant, hidden, tenant).Error)
+	ctx := domain.WithAccessScope(context.Background(), &domain.AccessScope{
+		Subject: domain.AccessSubject{UserID: uuid.New(), Role: domain.RoleUser},
+		Policies: []domain.AccessPolicy{{Name: "confidential", Resource: domain.AccessResourceRisk, Effect: domain.AccessDeny, Enabled: true,
+			Condition: &domain.AccessCondition{Attr: "classification", Op: domain.AccessOpIn, Values: []string{"confidential"}}}},
+	})
+
+	bowtie := NewGormBowtieRepository(db)
+	controls := NewGormControlTestRepository(db)
+	for _, id := range []uuid.UUID{shown, hidden} {
+		b, err := bowtie.GetRisk(ctx, tenant, id)
+		require.NoError(t, err)
+		assert.Equal(t, id == shown, b != nil, "bowtie")
+		c, err := controls.GetRisk(ctx, tenant, id)
+		require.NoError(t, err)
+		assert.Equal(t, id == shown, c != nil, "control tests")
+	}
+	rows, err := NewGormMitigationProgrammeRepository(db).Risks(ctx, tenant, []uuid.UUID{shown, hidden})
+	require.NoError(t, err)
+	require.Len(t, rows, 1, "programmes")
+	assert.Equal(t, shown, rows[0].ID)
+	visible, err := NewGormRiskRepository(db).VisibleRiskIDs(ctx, tenant, []uuid.UUID{shown, hidden})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]bool{shown: true}, visible, "appetite evaluation")
+
+	b, err := bowtie.GetRisk(context.Background(), tenant, hidden)
+	require.NoError(t, err)
+	assert.NotNil(t, b, "without a scope, a worker sees everything")
+}
+
+func TestAccessPolicies_RegisterSnapshotRowsAreScoped(t *testing.T) {
+	db := newAccessPolicyTestDB(t)
+	require.NoError(t, db.AutoMigrate(&domain.RegisterSnapshot{}, &domain.RegisterSnapshotRisk{}))
+	tenant, shown, hidden, deleted := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO risks (id, tenant_id, title, classification) VALUES (?, ?, 'a', ''), (?, ?, 'b', 'confidential')`,
+		shown, tenant, hidden, tenant).Error)
+	repo := NewGormRegisterSnapshotRepository(db)
+	snap := takeSnapshot(t, repo, tenant, domain.RegisterSnapshotManual, time.Now().UTC(),
+		domain.RegisterSnapshotRisk{RiskID: shown, Title: "a"},
+		domain.RegisterSnapshotRisk{RiskID: hidden, Title: "b"},
+		domain.RegisterSnapshotRisk{RiskID: deleted, Title: "c"},
+	)
+	ctx := domain.WithAccessScope(context.Background(), &domain.AccessScope{
+		Subject: domain.AccessSubject{UserID: uuid.New(), Role: domain.RoleUser},
+		Policies: []domain.AccessPolicy{{Name: "confidential", Resource: domain.AccessResourceRisk, Effect: domain.AccessDeny, Enabled: true,
+			Condition: &domain.AccessCondition{Attr: "classification", Op: domain.AccessOpIn, Values: []string{"confidential"}}}},
+	})
+
+	rows, total, err := repo.Rows(ctx, tenant, snap.ID, domain.RegisterSnapshotFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+	require.Len(t, rows, 1)
+	assert.Equal(t, shown, rows[0].RiskID, "neither the hidden risk nor one deleted since")
+	live, err := repo.LiveRisks(ctx, tenant)
+	require.NoError(t, err)
+	require.Len(t, live, 1)
+	assert.Equal(t, shown, live[0].ID)
+
+	all, err := repo.AllRows(context.Background(), tenant, snap.ID)
+	require.NoError(t, err)
+	assert.Len(t, all, 3, "without a scope, every row")
+}
+
+func TestAccessPolicies_RiskRegisterReadsAreScoped(t *testing.T) {
+	db := newAccessPolicyTestDB(t)
+	tenant, shown, hidden := uuid.New(), uuid.New(), uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO risks (id, tenant_id, title, classification, criticality, source, score) VALUES (?, ?, 'a', '', 'LOW', 'scan', 1), (?, ?, 'b', 'confidential', 'HIGH', 'scan', 1)`,
+		shown, tenant, hidden, tenant).Error)
+	ctx := domain.WithAccessScope(context.Background(), &domain.AccessScope{
+		Subject: domain.AccessSubject{UserID: uuid.New(), Role: domain.RoleUser},
+		Policies: []domain.AccessPolicy{{Name: "confidential", Resource: domain.AccessResourceRisk, Effect: domain.AccessDeny, Enabled: true,
+			Condition: &domain.AccessCondition{Attr: "classification", Op: domain.AccessOpIn, Values: []string{"confidential"}}}},
+	})
+	repo := NewGormRiskRepository(db)
+
+	risks, err := repo.ListRisksForFinancial(ctx, tenant)
+	require.NoError(t, err)
+	require.Len(t, risks, 1, "financial list")
+	assert.Equal(t, shown, risks[0].ID)
+	bySource, err := repo.GetBySource(ctx, tenant, "scan")
+	require.NoError(t, err)
+	require.Len(t, bySource, 1, "by source")
+	assert.Equal(t, shown, bySource[0].ID)
+	counts, err := repo.CountRisksByCriticality(ctx, tenant)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"low": 1}, counts, "criticality counts")
+
+	require.NoError(t, db.Exec(`CREATE TABLE risk_histories (id TEXT PRIMARY KEY)`).Error)
+	require.NoError(t, sqliteschema.Reconcile(db, "risk_histories", &domain.RiskHistory{}))
+	score := 9.0
+	n, err := repo.BulkUpdate(ctx, tenant, []domain.RiskUpdate{{ID: shown, Score: &score}, {ID: hidden, Score: &score}})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n, "bulk update leaves the hidden risk alone")
+	var hiddenScore float64
+	require.NoError(t, db.Raw(`SELECT score FROM risks WHERE id = ?`, hidden).Scan(&hiddenScore).Error)
+	assert.Equal(t, 1.0, hiddenScore)
+
+	all, err := repo.ListRisksForFinancial(context.Background(), tenant)
+	require.NoError(t, err)
+	assert.Len(t, all, 2, "without a scope, every risk")
+}
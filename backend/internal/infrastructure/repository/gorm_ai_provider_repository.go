@@ -0,0 +1,80 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormAIProviderSettingRepository stores each organisation's LLM provider
+// choice. One row per tenant; every query is tenant-scoped.
+type GormAIProviderSettingRepository struct{ db *gorm.DB }
+
+// NewGormAIProviderSettingRepository builds the store.
+func NewGormAIProviderSettingRepository(db *gorm.DB) *GormAIProviderSettingRepository {
+	return &GormAIProviderSettingRepository{db: db}
+}
+
+var _ domain.AIProviderSettingRepository = (*GormAIProviderSettingRepository)(nil)
+
+func (r *GormAIProviderSettingRepository) Get(ctx context.Context, tenantID uuid.UUID) (*domain.AIProviderSetting, error) {
+	var s domain.AIProviderSetting
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Take(&s).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ai provider setting: %w", err)
+	}
+	return &s, nil
+}
+
+// Save inserts or fully rewrites the row. Zero values are written (Select("*"))
+// so clearing the key or the timeout sticks; the update is scoped to the tenant
+// so an id from another tenant can never be overwritten.
+func (r *GormAIProviderSettingRepository) Save(ctx context.Context, s *domain.AIProviderSetting) error {
+	if s.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant_id is required")
+	}
+	var n int64
+	if err := r.db.WithContext(ctx).Model(&domain.AIProviderSetting{}).Where("id = ?", s.ID).Count(&n).Error; err != nil {
+		return fmt.Errorf("failed to save ai provider setting: %w", err)
+	}
+	if n == 0 {
+		if err := r.db.WithContext(ctx).Create(s).Error; err != nil {
+			return fmt.Errorf("failed to save ai provider setting: %w", err)
+		}
+		return nil
+	}
+	res := r.db.WithContext(ctx).Model(s).
+		Where("id = ? AND tenant_id = ?", s.ID, s.TenantID).
+		Select("*").Omit("created_at").
+		Updates(s)
+	if res.Error != nil {
+		return fmt.Errorf("failed to save ai provider setting: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("ai provider setting", s.ID)
+	}
+	return nil
+}
+
+func (r *GormAIProviderSettingRepository) Delete(ctx context.Context, tenantID uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Delete(&domain.AIProviderSetting{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete ai provider setting: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("ai provider setting", tenantID)
+	}
+	return nil
+}
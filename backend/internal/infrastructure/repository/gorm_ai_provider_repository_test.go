@@ -0,0 +1,57 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestAIProviderSettingRepo_SaveGetDeleteTenantScoped(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.AIProviderSetting{}))
+	repo := NewGormAIProviderSettingRepository(db)
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	s := &domain.AIProviderSetting{
+		ID: uuid.New(), TenantID: tenantA, Provider: "openai_compatible",
+		BaseURL: "http://vllm:8000/v1", Model: "qwen2.5-14b-instruct",
+		EncryptedAPIKey: "ct", TimeoutSeconds: 180, MaxTokens: 2048,
+	}
+	require.NoError(t, repo.Save(ctx, s))
+
+	none, err := repo.Get(ctx, tenantB)
+	require.NoError(t, err)
+	assert.Nil(t, none)
+
+	// Zero values are written on update: dropping the key and the timeout sticks.
+	s.EncryptedAPIKey, s.TimeoutSeconds = "", 0
+	require.NoError(t, repo.Save(ctx, s))
+	got, err := repo.Get(ctx, tenantA)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Empty(t, got.EncryptedAPIKey)
+	assert.Equal(t, 0, got.TimeoutSeconds)
+	assert.Equal(t, 2048, got.MaxTokens)
+
+	hijack := *got
+	hijack.TenantID = tenantB
+	assert.Error(t, repo.Save(ctx, &hijack))
+
+	assert.Error(t, repo.Delete(ctx, tenantB))
+	require.NoError(t, repo.Delete(ctx, tenantA))
+	gone, _ := repo.Get(ctx, tenantA)
+	assert.Nil(t, gone)
+}
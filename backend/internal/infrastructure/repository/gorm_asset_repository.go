@@ -75,7 +75,7 @@ func (r *GormAssetRepository) Update(ctx context.Context, asset *domain.Asset) e
 	result := r.db.WithContext(ctx).
 		Model(&domain.Asset{}).
 		Where("id = ? AND tenant_id = ?", asset.ID, asset.TenantID).
-		Select("name", "type", "criticality", "owner").
+		Select("name", "type", "criticality", "owner", "business_unit").
 		Updates(asset)
 
 	if result.Error != nil {
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormAuditCheckpointRepository stores signed chain checkpoints. Rows are never
+// updated: migration 0078 puts the audit trail's append-only trigger on the
+// table.
+type GormAuditCheckpointRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAuditCheckpointRepository builds the checkpoint store.
+func NewGormAuditCheckpointRepository(db *gorm.DB) *GormAuditCheckpointRepository {
+	return &GormAuditCheckpointRepository{db: db}
+}
+
+func (r *GormAuditCheckpointRepository) Create(ctx context.Context, c *domain.AuditCheckpoint) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "tenant_id"}, {Name: "sequence"}}, DoNothing: true}).
+		Create(c)
+	if res.Error != nil {
+		return false, fmt.Errorf("failed to store audit checkpoint: %w", res.Error)
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *GormAuditCheckpointRepository) List(ctx context.Context, tenantID uuid.UUID, limit int) ([]domain.AuditCheckpoint, error) {
+	var rows []domain.AuditCheckpoint
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).
+		Order("sequence DESC").Limit(limit).Find(&rows).Error
+	return rows, err
+}
+
+func (r *GormAuditCheckpointRepository) InRange(ctx context.Context, tenantID uuid.UUID, from, to int64) ([]domain.AuditCheckpoint, error) {
+	var rows []domain.AuditCheckpoint
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND sequence >= ? AND sequence <= ?", tenantID, from, to).
+		Order("sequence ASC").Find(&rows).Error
+	return rows, err
+}
+
+func (r *GormAuditCheckpointRepository) Head(ctx context.Context, tenantID uuid.UUID) (*domain.AuditChainHead, error) {
+	var e domain.AuditEvent
+	err := r.db.WithContext(ctx).Select("tenant_id", "sequence", "hash").
+		Where("tenant_id = ?", tenantID).Order("sequence DESC").Take(&e).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.AuditChainHead{TenantID: e.TenantID, Sequence: e.Sequence, Hash: e.Hash}, nil
+}
+
+func (r *GormAuditCheckpointRepository) DueHeads(ctx context.Context, limit int) ([]domain.AuditChainHead, error) {
+	var rows []struct {
+		TenantID uuid.UUID
+		Sequence int64
+		Hash     string
+	}
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT e.tenant_id, e.sequence, e.hash
+		FROM audit_events e
+		JOIN (SELECT tenant_id, MAX(sequence) AS seq FROM audit_events GROUP BY tenant_id) h
+		  ON h.tenant_id = e.tenant_id AND h.seq = e.sequence
+		LEFT JOIN (SELECT tenant_id, MAX(sequence) AS seq FROM audit_checkpoints GROUP BY tenant_id) c
+		  ON c.tenant_id = e.tenant_id
+		WHERE c.seq IS NULL OR c.seq < e.sequence
+		ORDER BY e.tenant_id
+		LIMIT ?`, limit).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit chain heads: %w", err)
+	}
+	out := make([]domain.AuditChainHead, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, domain.AuditChainHead{TenantID: row.TenantID, Sequence: row.Sequence, Hash: row.Hash})
+	}
+	return out, nil
+}
+
+func (r *GormAuditCheckpointRepository) EventsInRange(ctx context.Context, tenantID uuid.UUID, from, to int64) ([]domain.AuditEvent, error) {
+	var rows []domain.AuditEvent
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND sequence >= ? AND sequence <= ?", tenantID, from, to).
+		Order("sequence ASC").Find(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+func TestAuditCheckpointRepo_HeadsAndDueTenants(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.AuditEvent{}, &domain.AuditCheckpoint{}))
+	chain := NewGormAuditChainRepository(db)
+	repo := NewGormAuditCheckpointRepository(db)
+	tenantA, tenantB, empty := uuid.New(), uuid.New(), uuid.New()
+
+	for _, tenant := range []uuid.UUID{tenantA, tenantA, tenantA, tenantB} {
+		require.NoError(t, chain.Append(ctx, &domain.AuditEvent{TenantID: tenant, Action: domain.AuditActionCreate, EntityType: "risk"}))
+	}
+	head, err := repo.Head(ctx, tenantA)
+	require.NoError(t, err)
+	require.NotNil(t, head)
+	assert.Equal(t, int64(3), head.Sequence)
+	head, err = repo.Head(ctx, empty)
+	require.NoError(t, err)
+	assert.Nil(t, head)
+
+	due, err := repo.DueHeads(ctx, 10)
+	require.NoError(t, err)
+	assert.Len(t, due, 2, "neither tenant has a checkpoint yet")
+
+	at := time.Now().UTC().Truncate(time.Microsecond)
+	c := &domain.AuditCheckpoint{ID: uuid.New(), TenantID: tenantA, Sequence: 3, HeadHash: "h", SignedAt: at,
+		Algorithm: "Ed25519", KeyID: "k", Signature: "s", TimestampToken: []byte{1, 2}}
+	created, err := repo.Create(ctx, c)
+	require.NoError(t, err)
+	assert.True(t, created)
+	dup := *c
+	dup.ID = uuid.New()
+	created, err = repo.Create(ctx, &dup)
+	require.NoError(t, err)
+	assert.False(t, created, "one checkpoint per tenant and sequence")
+
+	due, err = repo.DueHeads(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, tenantB, due[0].TenantID)
+
+	got, err := repo.InRange(ctx, tenantA, 1, 3)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, c.SignedPayload(), got[0].SignedPayload(), "the signed payload survives the round trip")
+	assert.Equal(t, []byte{1, 2}, got[0].TimestampToken)
+	list, err := repo.List(ctx, tenantB, 10)
+	require.NoError(t, err)
+	assert.Empty(t, list, "checkpoints are tenant-scoped")
+
+	events, err := repo.EventsInRange(ctx, tenantA, 2, 3)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.True(t, events[1].VerifyHash())
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormBIARepository stores business impact analyses and the recovery
+// capabilities of supporting assets. Both are keyed by (tenant, asset) and
+// every query is tenant-scoped.
+type GormBIARepository struct{ db *gorm.DB }
+
+// NewGormBIARepository builds the store.
+func NewGormBIARepository(db *gorm.DB) *GormBIARepository {
+	return &GormBIARepository{db: db}
+}
+
+var _ domain.BIARepository = (*GormBIARepository)(nil)
+
+func (r *GormBIARepository) ListAnalyses(ctx context.Context, tenantID uuid.UUID) ([]domain.BusinessImpactAnalysis, error) {
+	var rows []domain.BusinessImpactAnalysis
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("rto_hours ASC, created_at ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list business impact analyses: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormBIARepository) GetAnalysis(ctx context.Context, tenantID, assetID uuid.UUID) (*domain.BusinessImpactAnalysis, error) {
+	var b domain.BusinessImpactAnalysis
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND asset_id = ?", tenantID, assetID).Take(&b).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get business impact analysis: %w", err)
+	}
+	return &b, nil
+}
+
+func (r *GormBIARepository) SaveAnalysis(ctx context.Context, b *domain.BusinessImpactAnalysis) error {
+	return r.save(ctx, &domain.BusinessImpactAnalysis{}, b.ID, b.TenantID, b, "business impact analysis")
+}
+
+func (r *GormBIARepository) DeleteAnalysis(ctx context.Context, tenantID, assetID uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("tenant_id = ? AND asset_id = ?", tenantID, assetID).Delete(&domain.BusinessImpactAnalysis{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete business impact analysis: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("business impact analysis", assetID)
+	}
+	return nil
+}
+
+func (r *GormBIARepository) ListCapabilities(ctx context.Context, tenantID uuid.UUID) ([]domain.RecoveryCapability, error) {
+	var rows []domain.RecoveryCapability
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("created_at ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list recovery capabilities: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormBIARepository) GetCapability(ctx context.Context, tenantID, assetID uuid.UUID) (*domain.RecoveryCapability, error) {
+	var c domain.RecoveryCapability
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND asset_id = ?", tenantID, assetID).Take(&c).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recovery capability: %w", err)
+	}
+	return &c, nil
+}
+
+func (r *GormBIARepository) SaveCapability(ctx context.Context, c *domain.RecoveryCapability) error {
+	return r.save(ctx, &domain.RecoveryCapability{}, c.ID, c.TenantID, c, "recovery capability")
+}
+
+func (r *GormBIARepository) DeleteCapability(ctx context.Context, tenantID, assetID uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("tenant_id = ? AND asset_id = ?", tenantID, assetID).Delete(&domain.RecoveryCapability{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete recovery capability: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("recovery capability", assetID)
+	}
+	return nil
+}
+
+// save inserts or updates by id. The update is tenant-scoped and writes every
+// column, so clearing an optional value (a capability's RPC) sticks.
+func (r *GormBIARepository) save(ctx context.Context, model interface{}, id, tenantID uuid.UUID, row interface{}, what string) error {
+	if tenantID == uuid.Nil {
+		return fmt.Errorf("tenant_id is required")
+	}
+	var n int64
+	if err := r.db.WithContext(ctx).Model(model).Where("id = ?", id).Count(&n).Error; err != nil {
+		return fmt.Errorf("failed to save %s: %w", what, err)
+	}
+	if n == 0 {
+		if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+			return fmt.Errorf("failed to save %s: %w", what, err)
+		}
+		return nil
+	}
+	res := r.db.WithContext(ctx).Model(row).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		Select("*").Omit("created_at").
+		Updates(row)
+	if res.Error != nil {
+		return fmt.Errorf("failed to save %s: %w", what, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError(what, id)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestBIARepo_AnalysesAndCapabilitiesTenantScoped(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.BusinessImpactAnalysis{}, &domain.RecoveryCapability{}))
+	repo := NewGormBIARepository(db)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	process, server := uuid.New(), uuid.New()
+
+	b := &domain.BusinessImpactAnalysis{
+		ID: uuid.New(), TenantID: tenantA, AssetID: process,
+		MTPDHours: 24, RTOHours: 8, RPOHours: 1,
+		ImpactCurve: domain.ImpactCurve{{AfterHours: 4, Level: 2, CostXAF: 1e6}, {AfterHours: 24, Level: 5, CostXAF: 2e7}},
+	}
+	require.NoError(t, repo.SaveAnalysis(ctx, b))
+	got, err := repo.GetAnalysis(ctx, tenantA, process)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Len(t, got.ImpactCurve, 2)
+	assert.Equal(t, 5, got.ImpactCurve[1].Level)
+
+	none, err := repo.GetAnalysis(ctx, tenantB, process)
+	require.NoError(t, err)
+	assert.Nil(t, none)
+	hijack := *got
+	hijack.TenantID = tenantB
+	assert.Error(t, repo.SaveAnalysis(ctx, &hijack))
+	assert.Error(t, repo.DeleteAnalysis(ctx, tenantB, process))
+
+	rtc, rpc := 12.0, 24.0
+	c := &domain.RecoveryCapability{ID: uuid.New(), TenantID: tenantA, AssetID: server, RTCHours: &rtc, RPCHours: &rpc, Basis: domain.RecoveryBasisTested}
+	require.NoError(t, repo.SaveCapability(ctx, c))
+	// Clearing an optional value on update sticks.
+	c.RPCHours = nil
+	require.NoError(t, repo.SaveCapability(ctx, c))
+	gotCap, err := repo.GetCapability(ctx, tenantA, server)
+	require.NoError(t, err)
+	require.NotNil(t, gotCap)
+	assert.Nil(t, gotCap.RPCHours)
+	assert.Equal(t, 12.0, *gotCap.RTCHours)
+
+	listB, err := repo.ListCapabilities(ctx, tenantB)
+	require.NoError(t, err)
+	assert.Empty(t, listB)
+	require.NoError(t, repo.DeleteCapability(ctx, tenantA, server))
+	gone, _ := repo.GetCapability(ctx, tenantA, server)
+	assert.Nil(t, gone)
+}
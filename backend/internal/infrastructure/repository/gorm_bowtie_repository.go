@@ -0,0 +1,171 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormBowtieRepository stores risk bowties and reads the controls, test plans
+// and mitigations their barriers link to. Every query is tenant-scoped.
+type GormBowtieRepository struct{ db *gorm.DB }
+
+// NewGormBowtieRepository builds the store.
+func NewGormBowtieRepository(db *gorm.DB) *GormBowtieRepository {
+	return &GormBowtieRepository{db: db}
+}
+
+var _ domain.BowtieRepository = (*GormBowtieRepository)(nil)
+
+func (r *GormBowtieRepository) GetRisk(ctx context.Context, tenantID, id uuid.UUID) (*domain.Risk, error) {
+	var risk domain.Risk
+	err := r.db.WithContext(ctx).Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
+		Where("tenant_id = ? AND id = ?", tenantID, id).Take(&risk).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get risk: %w", err)
+	}
+	return &risk, nil
+}
+
+func (r *GormBowtieRepository) Get(ctx context.Context, tenantID, riskID uuid.UUID) (*domain.Bowtie, error) {
+	var b domain.Bowtie
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND risk_id = ?", tenantID, riskID).Take(&b).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bowtie: %w", err)
+	}
+	return &b, nil
+}
+
+// Save keys the bowtie on its risk: a second save replaces the first and
+// keeps its id and creation date.
+func (r *GormBowtieRepository) Save(ctx context.Context, b *domain.Bowtie) error {
+	if b.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant_id is required")
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing domain.Bowtie
+		err := tx.Select("id", "created_at").
+			Where("tenant_id = ? AND risk_id = ?", b.TenantID, b.RiskID).
+			Take(&existing).Error
+		switch {
+		case err == gorm.ErrRecordNotFound:
+			if b.ID == uuid.Nil {
+				b.ID = uuid.New()
+			}
+			if err := tx.Create(b).Error; err != nil {
+				return fmt.Errorf("failed to save bowtie: %w", err)
+			}
+			return nil
+		case err != nil:
+			return fmt.Errorf("failed to save bowtie: %w", err)
+		}
+		b.ID, b.CreatedAt = existing.ID, existing.CreatedAt
+		if err := tx.Model(b).
+			Where("tenant_id = ? AND id = ?", b.TenantID, b.ID).
+			Select("*").Omit("created_at").
+			Updates(b).Error; err != nil {
+			return fmt.Errorf("failed to save bowtie: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *GormBowtieRepository) Delete(ctx context.Context, tenantID, riskID uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("tenant_id = ? AND risk_id = ?", tenantID, riskID).Delete(&domain.Bowtie{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete bowtie: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("bowtie", riskID)
+	}
+	return nil
+}
+
+func (r *GormBowtieRepository) Controls(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]domain.ComplianceControl, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var rows []domain.ComplianceControl
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id IN ?", tenantID, ids).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list bowtie controls: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormBowtieRepository) TestPlans(ctx context.Context, tenantID uuid.UUID, controlIDs []uuid.UUID) ([]domain.ControlTestPlan, error) {
+	if len(controlIDs) == 0 {
+		return nil, nil
+	}
+	var rows []domain.ControlTestPlan
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND control_id IN ?", tenantID, controlIDs).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list bowtie control test plans: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormBowtieRepository) Mitigations(ctx context.Context, tenantID, riskID uuid.UUID) ([]domain.Mitigation, error) {
+	var rows []domain.Mitigation
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND risk_id = ?", tenantID, riskID).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list bowtie mitigations: %w", err)
+	}
+	return rows, nil
+}
+
+// ApplySuggestion writes the figures as a targeted column update that skips
+// hooks, for the reason given on
+// GormControlTestRepository.SetDerivedResidual, and records the suggestion's
+// losses as the bowtie's applied losses in the same transaction.
+func (r *GormBowtieRepository) ApplySuggestion(ctx context.Context, tenantID, riskID uuid.UUID, s domain.BarrierSuggestion) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		res := tx.Session(&gorm.Session{SkipHooks: true}).Model(&domain.Risk{}).
+			Where("tenant_id = ? AND id = ?", tenantID, riskID).
+			Updates(map[string]interface{}{
+				"probability": s.Probability,
+				"impact":      s.Impact,
+				"updated_at":  now,
+			})
+		if res.Error != nil {
+			return fmt.Errorf("failed to update risk probability and impact: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return domain.NewNotFoundError("risk", riskID)
+		}
+		res = tx.Model(&domain.Bowtie{}).
+			Where("tenant_id = ? AND risk_id = ?", tenantID, riskID).
+			Updates(map[string]interface{}{
+				"applied_preventive_loss": s.PreventiveLoss,
+				"applied_recovery_loss":   s.RecoveryLoss,
+				"updated_at":              now,
+			})
+		if res.Error != nil {
+			return fmt.Errorf("failed to record applied bowtie loss: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return domain.NewNotFoundError("bowtie", riskID)
+		}
+		return nil
+	})
+}
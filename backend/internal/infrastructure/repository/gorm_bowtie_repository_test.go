@@ -0,0 +1,116 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newBowtieDB migrates bowties and test plans from their models and
+// hand-writes the columns of the tables the repository reads: their models
+// carry postgres-only defaults.
+func newBowtieDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.Bowtie{}, &domain.ControlTestPlan{}))
+	for _, ddl := range []string{
+		`CREATE TABLE compliance_controls (
+			id TEXT PRIMARY KEY, external_id TEXT, tenant_id TEXT NOT NULL, framework_id TEXT NOT NULL,
+			reference_code TEXT, name TEXT, description TEXT, source_reference TEXT, status TEXT,
+			created_at DATETIME, updated_at DATETIME, deleted_at DATETIME)`,
+		`CREATE TABLE mitigations (
+			id TEXT PRIMARY KEY, external_id TEXT, tenant_id TEXT NOT NULL, risk_id TEXT NOT NULL, title TEXT, status TEXT,
+			created_at DATETIME, updated_at DATETIME, deleted_at DATETIME)`,
+		`CREATE TABLE risks (
+			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, probability REAL, impact REAL,
+			updated_at DATETIME, deleted_at DATETIME)`,
+	} {
+		require.NoError(t, db.Exec(ddl).Error)
+	}
+	return db
+}
+
+// The isolation registry cites this test for /risks/{id}/bowtie.
+func TestBowtieRepo_TenantScoped(t *testing.T) {
+	ctx := context.Background()
+	db := newBowtieDB(t)
+	repo := NewGormBowtieRepository(db)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	riskID, control, foreignControl := uuid.New(), uuid.New(), uuid.New()
+
+	require.NoError(t, db.Exec(`INSERT INTO risks (id, tenant_id, probability, impact) VALUES (?, ?, 0.2, 4)`, riskID, tenantA).Error)
+	require.NoError(t, db.Exec(`INSERT INTO compliance_controls (id, tenant_id, framework_id, name, status) VALUES
+		(?, ?, ?, 'Backups', 'implemented'), (?, ?, ?, 'Theirs', 'implemented')`,
+		control, tenantA, uuid.New(), foreignControl, tenantB, uuid.New()).Error)
+	require.NoError(t, db.Exec(`INSERT INTO mitigations (id, tenant_id, risk_id, title, status, deleted_at) VALUES
+		(?, ?, ?, 'Restore drill', 'DONE', NULL), (?, ?, ?, 'Old plan', 'PLANNED', CURRENT_TIMESTAMP)`,
+		uuid.New(), tenantA, riskID, uuid.New(), tenantA, riskID).Error)
+
+	b := &domain.Bowtie{
+		TenantID: tenantA, RiskID: riskID, TopEvent: "Data lost",
+		Threats:      domain.BowtiePaths{{ID: "t1", Label: "Ransomware"}},
+		Consequences: domain.BowtiePaths{{ID: "c1", Label: "Outage"}},
+		Barriers:     domain.BowtieBarriers{{ID: "b1", Kind: domain.BarrierRecovery, PathID: "c1", Label: "Restore", ControlID: &control}},
+	}
+	require.NoError(t, repo.Save(ctx, b))
+	first := b.ID
+
+	again := &domain.Bowtie{TenantID: tenantA, RiskID: riskID, TopEvent: "Data destroyed", Threats: domain.BowtiePaths{}, Consequences: domain.BowtiePaths{}, Barriers: domain.BowtieBarriers{}}
+	require.NoError(t, repo.Save(ctx, again))
+	assert.Equal(t, first, again.ID, "a risk keeps one bowtie")
+	got, err := repo.Get(ctx, tenantA, riskID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "Data destroyed", got.TopEvent)
+	assert.Empty(t, got.Barriers)
+
+	none, err := repo.Get(ctx, tenantB, riskID)
+	require.NoError(t, err)
+	assert.Nil(t, none)
+	risk, err := repo.GetRisk(ctx, tenantB, riskID)
+	require.NoError(t, err)
+	assert.Nil(t, risk)
+
+	controls, err := repo.Controls(ctx, tenantA, []uuid.UUID{control, foreignControl})
+	require.NoError(t, err)
+	require.Len(t, controls, 1, "another tenant's control is not linkable")
+	assert.Equal(t, control, controls[0].ID)
+
+	mits, err := repo.Mitigations(ctx, tenantA, riskID)
+	require.NoError(t, err)
+	require.Len(t, mits, 1, "deleted mitigations are left out")
+	mits, err = repo.Mitigations(ctx, tenantB, riskID)
+	require.NoError(t, err)
+	assert.Empty(t, mits)
+
+	sg := domain.BarrierSuggestion{Probability: 0.35, Impact: 5.5, PreventiveLoss: 0.5, RecoveryLoss: 0.25}
+	assert.Error(t, repo.ApplySuggestion(ctx, tenantB, riskID, sg), "another tenant cannot write the figures")
+	require.NoError(t, repo.ApplySuggestion(ctx, tenantA, riskID, sg))
+	var row struct{ Probability, Impact float64 }
+	require.NoError(t, db.Raw(`SELECT probability, impact FROM risks WHERE id = ?`, riskID).Scan(&row).Error)
+	assert.InDelta(t, 0.35, row.Probability, 1e-9)
+	assert.InDelta(t, 5.5, row.Impact, 1e-9)
+	got, err = repo.Get(ctx, tenantA, riskID)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, got.AppliedPreventiveLoss, 1e-9)
+	assert.InDelta(t, 0.25, got.AppliedRecoveryLoss, 1e-9)
+
+	assert.Error(t, repo.Delete(ctx, tenantB, riskID))
+	require.NoError(t, repo.Delete(ctx, tenantA, riskID))
+	got, err = repo.Get(ctx, tenantA, riskID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
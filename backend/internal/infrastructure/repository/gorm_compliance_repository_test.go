@@ -59,7 +59,7 @@ func setupComplianceRepo(t *testing.T) *GormComplianceRepository {
 	// compliance_controls (tenant-scoped)
 	require.NoError(t, db.Exec(`
 		CREATE TABLE compliance_controls (
-			id TEXT PRIMARY KEY,
+			id TEXT PRIMARY KEY, external_id TEXT,
 			tenant_id TEXT NOT NULL,
 			framework_id TEXT NOT NULL,
 			reference_code TEXT NOT NULL DEFAULT '',
@@ -0,0 +1,201 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormControlTestRepository stores control test plans and their executions,
+// and reads the risks a control is mapped to. Every query is tenant-scoped.
+type GormControlTestRepository struct{ db *gorm.DB }
+
+// NewGormControlTestRepository builds the store.
+func NewGormControlTestRepository(db *gorm.DB) *GormControlTestRepository {
+	return &GormControlTestRepository{db: db}
+}
+
+var _ domain.ControlTestRepository = (*GormControlTestRepository)(nil)
+
+func (r *GormControlTestRepository) GetControl(ctx context.Context, tenantID, id uuid.UUID) (*domain.ComplianceControl, error) {
+	var c domain.ComplianceControl
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&c).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get control: %w", err)
+	}
+	return &c, nil
+}
+
+func (r *GormControlTestRepository) ListPlans(ctx context.Context, tenantID uuid.UUID, controlIDs []uuid.UUID) ([]domain.ControlTestPlan, error) {
+	var rows []domain.ControlTestPlan
+	q := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if controlIDs != nil {
+		if len(controlIDs) == 0 {
+			return nil, nil
+		}
+		q = q.Where("control_id IN ?", controlIDs)
+	}
+	if err := q.Order("next_test_at ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list control test plans: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormControlTestRepository) DuePlans(ctx context.Context, tenantID uuid.UUID, at time.Time) ([]domain.ControlTestPlan, error) {
+	var rows []domain.ControlTestPlan
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND next_test_at <= ?", tenantID, at).
+		Order("next_test_at ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list due control test plans: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormControlTestRepository) GetPlan(ctx context.Context, tenantID, id uuid.UUID) (*domain.ControlTestPlan, error) {
+	var p domain.ControlTestPlan
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&p).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get control test plan: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *GormControlTestRepository) SavePlan(ctx context.Context, p *domain.ControlTestPlan) error {
+	return saveTenantRow(r.db.WithContext(ctx), p, p.ID, p.TenantID, "control test plan")
+}
+
+func (r *GormControlTestRepository) DeletePlan(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tenant_id = ? AND plan_id = ?", tenantID, id).Delete(&domain.ControlTest{}).Error; err != nil {
+			return fmt.Errorf("failed to delete control tests: %w", err)
+		}
+		res := tx.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&domain.ControlTestPlan{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to delete control test plan: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return domain.NewNotFoundError("control test plan", id)
+		}
+		return nil
+	})
+}
+
+// RecordTest appends the test and moves the plan on in one transaction: a test
+// on file whose plan still shows the old result would give two answers to
+// "does this control work?".
+func (r *GormControlTestRepository) RecordTest(ctx context.Context, t *domain.ControlTest, p *domain.ControlTestPlan) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(t).Error; err != nil {
+			return fmt.Errorf("failed to record control test: %w", err)
+		}
+		res := tx.Model(&domain.ControlTestPlan{}).
+			Where("tenant_id = ? AND id = ?", p.TenantID, p.ID).
+			Updates(map[string]interface{}{
+				"last_tested_at":     p.LastTestedAt,
+				"last_result":        p.LastResult,
+				"last_effectiveness": p.LastEffectiveness,
+				"next_test_at":       p.NextTestAt,
+				"updated_at":         time.Now(),
+			})
+		if res.Error != nil {
+			return fmt.Errorf("failed to update control test plan: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return domain.NewNotFoundError("control test plan", p.ID)
+		}
+		return nil
+	})
+}
+
+func (r *GormControlTestRepository) ListTests(ctx context.Context, tenantID, controlID uuid.UUID, limit int) ([]domain.ControlTest, error) {
+	var rows []domain.ControlTest
+	q := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND control_id = ?", tenantID, controlID).
+		Order("performed_at DESC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list control tests: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormControlTestRepository) GetRisk(ctx context.Context, tenantID, id uuid.UUID) (*domain.Risk, error) {
+	var risk domain.Risk
+	err := r.db.WithContext(ctx).Preload("Assets").Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
+		Where("tenant_id = ? AND id = ?", tenantID, id).Take(&risk).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get risk: %w", err)
+	}
+	return &risk, nil
+}
+
+func (r *GormControlTestRepository) LinkedRiskIDs(ctx context.Context, tenantID, controlID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Table("risk_control_mappings AS m").
+		Distinct("m.risk_id").
+		Joins("JOIN risks AS r ON r.id = m.risk_id AND r.tenant_id = m.tenant_id AND r.deleted_at IS NULL").
+		Where("m.tenant_id = ? AND m.control_id = ? AND m.deleted_at IS NULL", tenantID, controlID).
+		Pluck("m.risk_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to list risks linked to control: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *GormControlTestRepository) MappedControls(ctx context.Context, tenantID, riskID uuid.UUID) ([]domain.ComplianceControl, error) {
+	var rows []domain.ComplianceControl
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id IN (?)", tenantID,
+			r.db.Table("risk_control_mappings").
+				Select("control_id").
+				Where("tenant_id = ? AND risk_id = ? AND control_id IS NOT NULL AND deleted_at IS NULL", tenantID, riskID)).
+		Order("reference_code ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list risk controls: %w", err)
+	}
+	return rows, nil
+}
+
+// SetDerivedResidual is a targeted column update, like the other figures the
+// platform derives onto a risk: it must not run the full Save path and rewrite
+// fields someone is editing. Hooks are skipped because Risk.AfterSave would
+// snapshot the empty model into risk_histories — a history row for no risk.
+func (r *GormControlTestRepository) SetDerivedResidual(ctx context.Context, tenantID, riskID uuid.UUID, mitigation, residual float64) error {
+	res := r.db.WithContext(ctx).Session(&gorm.Session{SkipHooks: true}).Model(&domain.Risk{}).
+		Where("tenant_id = ? AND id = ?", tenantID, riskID).
+		Updates(map[string]interface{}{
+			"residual_risk":            residual,
+			"mitigation_effectiveness": mitigation,
+			"residual_source":          domain.ResidualSourceControls,
+			"updated_at":               time.Now(),
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to update residual risk: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("risk", riskID)
+	}
+	return nil
+}
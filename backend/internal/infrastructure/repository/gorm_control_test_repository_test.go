@@ -0,0 +1,142 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newControlTestDB migrates the plan and test tables from their models and
+// hand-writes the columns of the tables the repository joins: their models
+// carry postgres-only defaults.
+func newControlTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.ControlTestPlan{}, &domain.ControlTest{}))
+	for _, ddl := range []string{
+		`CREATE TABLE compliance_controls (
+			id TEXT PRIMARY KEY, external_id TEXT, tenant_id TEXT NOT NULL, framework_id TEXT NOT NULL,
+			reference_code TEXT, name TEXT, description TEXT, source_reference TEXT, status TEXT,
+			created_at DATETIME, updated_at DATETIME, deleted_at DATETIME)`,
+		`CREATE TABLE risk_control_mappings (
+			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, risk_id TEXT NOT NULL, framework_id TEXT NOT NULL,
+			control_id TEXT, deleted_at DATETIME)`,
+		`CREATE TABLE risks (
+			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, residual_risk REAL, mitigation_effectiveness REAL,
+			residual_source TEXT DEFAULT '', updated_at DATETIME, deleted_at DATETIME)`,
+	} {
+		require.NoError(t, db.Exec(ddl).Error)
+	}
+	return db
+}
+
+// The isolation registry cites this test for /control-test-plans/{id}.
+func TestControlTestRepo_TenantScoped(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGormControlTestRepository(newControlTestDB(t))
+	tenantA, tenantB := uuid.New(), uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	p := &domain.ControlTestPlan{
+		ID: uuid.New(), TenantID: tenantA, ControlID: uuid.New(), Kind: domain.ControlTestOperating,
+		SampleSize: 25, FrequencyDays: 90, NextTestAt: now.Add(-time.Hour),
+	}
+	require.NoError(t, repo.SavePlan(ctx, p))
+
+	eff := 0.96
+	p.LastTestedAt, p.LastResult, p.LastEffectiveness = &now, domain.ControlTestPass, &eff
+	p.NextTestAt = now.AddDate(0, 0, 90)
+	test := &domain.ControlTest{
+		ID: uuid.New(), TenantID: tenantA, PlanID: p.ID, ControlID: p.ControlID, Kind: p.Kind,
+		PerformedAt: now, SamplesTested: 25, Exceptions: 1, Result: domain.ControlTestPass, Effectiveness: eff,
+	}
+	require.NoError(t, repo.RecordTest(ctx, test, p))
+
+	got, err := repo.GetPlan(ctx, tenantA, p.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, domain.ControlTestPass, got.LastResult)
+	require.NotNil(t, got.LastEffectiveness)
+	assert.InDelta(t, 0.96, *got.LastEffectiveness, 1e-9)
+	due, err := repo.DuePlans(ctx, tenantA, now)
+	require.NoError(t, err)
+	assert.Empty(t, due, "a recorded test moves the plan's next date on")
+
+	none, err := repo.GetPlan(ctx, tenantB, p.ID)
+	require.NoError(t, err)
+	assert.Nil(t, none)
+	tests, err := repo.ListTests(ctx, tenantB, p.ControlID, 0)
+	require.NoError(t, err)
+	assert.Empty(t, tests)
+	plans, err := repo.ListPlans(ctx, tenantB, nil)
+	require.NoError(t, err)
+	assert.Empty(t, plans)
+	p.TenantID = tenantB
+	assert.Error(t, repo.SavePlan(ctx, p), "an update cannot move a plan across tenants")
+	assert.Error(t, repo.RecordTest(ctx, &domain.ControlTest{ID: uuid.New(), TenantID: tenantB, PlanID: p.ID}, p))
+	assert.Error(t, repo.DeletePlan(ctx, tenantB, p.ID))
+
+	require.NoError(t, repo.DeletePlan(ctx, tenantA, p.ID))
+	tests, err = repo.ListTests(ctx, tenantA, p.ControlID, 0)
+	require.NoError(t, err)
+	assert.Empty(t, tests, "deleting a plan deletes its tests")
+}
+
+func TestControlTestRepo_LinkedRisksAndResidual(t *testing.T) {
+	ctx := context.Background()
+	db := newControlTestDB(t)
+	repo := NewGormControlTestRepository(db)
+	tenant, other := uuid.New(), uuid.New()
+	fw, control, second := uuid.New(), uuid.New(), uuid.New()
+
+	require.NoError(t, db.Exec(`INSERT INTO compliance_controls (id, tenant_id, framework_id, reference_code, name, status) VALUES
+		(?, ?, ?, 'A.8.13', 'Backups', 'implemented'), (?, ?, ?, 'A.5.15', 'Access control', 'implemented')`,
+		control, tenant, fw, second, tenant, fw).Error)
+	live, deleted, foreign := uuid.New(), uuid.New(), uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO risks (id, tenant_id, deleted_at) VALUES (?, ?, NULL), (?, ?, CURRENT_TIMESTAMP), (?, ?, NULL)`,
+		live, tenant, deleted, tenant, foreign, other).Error)
+	require.NoError(t, db.Exec(`INSERT INTO risk_control_mappings (id, tenant_id, risk_id, framework_id, control_id, deleted_at) VALUES
+		(?, ?, ?, ?, ?, NULL), (?, ?, ?, ?, ?, NULL), (?, ?, ?, ?, NULL, NULL),
+		(?, ?, ?, ?, ?, NULL), (?, ?, ?, ?, ?, NULL), (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)`,
+		uuid.New(), tenant, live, fw, control,
+		uuid.New(), tenant, live, fw, control,
+		uuid.New(), tenant, live, fw,
+		uuid.New(), tenant, deleted, fw, control,
+		uuid.New(), other, foreign, fw, control,
+		uuid.New(), tenant, live, fw, second).Error)
+
+	ids, err := repo.LinkedRiskIDs(ctx, tenant, control)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{live}, ids, "deleted risks, other tenants and duplicate mappings are left out")
+
+	controls, err := repo.MappedControls(ctx, tenant, live)
+	require.NoError(t, err)
+	require.Len(t, controls, 1, "framework-only and deleted mappings are not controls")
+	assert.Equal(t, control, controls[0].ID)
+
+	require.NoError(t, repo.SetDerivedResidual(ctx, tenant, live, 0.6, 4.2))
+	var row struct {
+		ResidualRisk            float64
+		MitigationEffectiveness float64
+		ResidualSource          string
+	}
+	require.NoError(t, db.Raw(`SELECT residual_risk, mitigation_effectiveness, residual_source FROM risks WHERE id = ?`, live).Scan(&row).Error)
+	assert.InDelta(t, 4.2, row.ResidualRisk, 1e-9)
+	assert.InDelta(t, 0.6, row.MitigationEffectiveness, 1e-9)
+	assert.Equal(t, domain.ResidualSourceControls, row.ResidualSource)
+	assert.Error(t, repo.SetDerivedResidual(ctx, other, live, 0, 1), "another tenant cannot write the residual")
+}
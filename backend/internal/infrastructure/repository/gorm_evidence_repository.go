@@ -128,6 +128,7 @@ func (r *GormEvidenceRepository) Update(ctx context.Context, e *domain.Evidence)
 func (r *GormEvidenceRepository) GetByID(ctx context.Context, tenantID, id uuid.UUID) (*domain.Evidence, error) {
 	var ev domain.Evidence
 	err := r.db.WithContext(ctx).
+		Scopes(AccessScoped(ctx, domain.AccessResourceEvidence)).
 		Where("id = ? AND tenant_id = ?", id, tenantID).
 		First(&ev).Error
 	if err != nil {
@@ -142,7 +143,9 @@ func (r *GormEvidenceRepository) GetByID(ctx context.Context, tenantID, id uuid.
 // List returns a filtered page of the library plus the total matching count (so
 // the UI can paginate without a second round trip).
 func (r *GormEvidenceRepository) List(ctx context.Context, tenantID uuid.UUID, f domain.EvidenceFilter) ([]domain.Evidence, int64, error) {
-	q := r.db.WithContext(ctx).Model(&domain.Evidence{}).Where("evidences.tenant_id = ?", tenantID)
+	q := r.db.WithContext(ctx).Model(&domain.Evidence{}).
+		Scopes(AccessScoped(ctx, domain.AccessResourceEvidence)).
+		Where("evidences.tenant_id = ?", tenantID)
 
 	if f.Type != "" {
 		q = q.Where("evidences.type = ?", f.Type)
@@ -201,7 +204,8 @@ func (r *GormEvidenceRepository) List(ctx context.Context, tenantID uuid.UUID, f
 // history, and it is still there.
 func (r *GormEvidenceRepository) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		res := tx.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&domain.Evidence{})
+		res := tx.Scopes(AccessScoped(ctx, domain.AccessResourceEvidence)).
+			Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&domain.Evidence{})
 		if res.Error != nil {
 			return res.Error
 		}
@@ -336,3 +340,73 @@ func (r *GormEvidenceRepository) MarkReminded(ctx context.Context, id uuid.UUID,
 		Where("id = ?", id).
 		UpdateColumn("reminder_sent_at", at).Error
 }
+
+// ListExpired returns accepted artifacts that have lapsed and whose lapse has
+// not been announced. Cross-tenant, like ListExpiring.
+func (r *GormEvidenceRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]domain.Evidence, error) {
+	if limit <= 0 {
+		limit = 500
+	}
+	var out []domain.Evidence
+	err := r.db.WithContext(ctx).
+		Where("valid_until IS NOT NULL AND valid_until <= ?", now).
+		Where("review = ?", domain.EvidenceReviewAccepted).
+		Where("expiry_announced_at IS NULL OR expiry_announced_at < valid_until").
+		Order("valid_until ASC").
+		Limit(limit).
+		Find(&out).Error
+	return out, err
+}
+
+func (r *GormEvidenceRepository) MarkExpiryAnnounced(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.Evidence{}).
+		Where("id = ?", id).
+		UpdateColumn("expiry_announced_at", at).Error
+}
+
+// ListWithFiles pages through every artifact that holds bytes, across tenants,
+// in id order. Only the storage migration calls it: it is the one job whose
+// unit of work is the blob, not the tenant.
+func (r *GormEvidenceRepository) ListWithFiles(ctx context.Context, after uuid.UUID, limit int) ([]domain.Evidence, error) {
+	var out []domain.Evidence
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("file_ref <> ''").
+		Where("id > ?", after).
+		Order("id ASC").
+		Limit(limit).
+		Find(&out).Error
+	return out, err
+}
+
+// SwapFileRef repoints an artifact at a new storage key, but only if it still
+// points at the old one — a file replaced while the migration was copying it
+// keeps the replacement. Soft-deleted rows are included: their bytes may still
+// be under retention and must follow the rest of the register.
+func (r *GormEvidenceRepository) SwapFileRef(ctx context.Context, id uuid.UUID, from, to string) (bool, error) {
+	res := r.db.WithContext(ctx).Unscoped().
+		Model(&domain.Evidence{}).
+		Where("id = ? AND file_ref = ?", id, from).
+		UpdateColumn("file_ref", to)
+	return res.RowsAffected == 1, res.Error
+}
+
+// AppendCustody implements domain.EvidenceCustodyRepository. Insert-only.
+func (r *GormEvidenceRepository) AppendCustody(ctx context.Context, e *domain.EvidenceCustodyEvent) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now().UTC()
+	}
+	return r.db.WithContext(ctx).Create(e).Error
+}
+
+func (r *GormEvidenceRepository) ListCustody(ctx context.Context, tenantID, evidenceID uuid.UUID) ([]domain.EvidenceCustodyEvent, error) {
+	var out []domain.EvidenceCustodyEvent
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND evidence_id = ?", tenantID, evidenceID).
+		Order("created_at ASC").
+		Find(&out).Error
+	return out, err
+}
@@ -29,6 +29,7 @@ func setupEvidenceRepo(t *testing.T) (*GormEvidenceRepository, *gorm.DB) {
 	require.NoError(t, db.AutoMigrate(
 		&domain.Evidence{},
 		&domain.EvidenceControlLink{},
+		&domain.EvidenceCustodyEvent{},
 		&evJoinedControl{},
 	))
 	return NewGormEvidenceRepository(db), db
@@ -258,3 +259,21 @@ func TestEvidenceRepo_ListFiltersByControlAndFramework(t *testing.T) {
 }
 
 func evPtr(t time.Time) *time.Time { return &t }
+
+func TestEvidenceRepo_CustodyIsTenantScopedAndOrdered(t *testing.T) {
+	repo, _ := setupEvidenceRepo(t)
+	ctx := context.Background()
+	tenant, other, evID := uuid.New(), uuid.New(), uuid.New()
+	t0 := time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.AppendCustody(ctx, &domain.EvidenceCustodyEvent{TenantID: tenant, EvidenceID: evID, Action: domain.CustodyDownloaded, CreatedAt: t0.Add(time.Hour)}))
+	require.NoError(t, repo.AppendCustody(ctx, &domain.EvidenceCustodyEvent{TenantID: tenant, EvidenceID: evID, Action: domain.CustodyCollected, SHA256: "ab", CreatedAt: t0}))
+	require.NoError(t, repo.AppendCustody(ctx, &domain.EvidenceCustodyEvent{TenantID: other, EvidenceID: evID, Action: domain.CustodyViewed}))
+
+	rows, err := repo.ListCustody(ctx, tenant, evID)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, domain.CustodyCollected, rows[0].Action)
+	assert.Equal(t, "ab", rows[0].SHA256)
+	assert.Equal(t, domain.CustodyDownloaded, rows[1].Action)
+}
@@ -0,0 +1,345 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormGraphRepository is the read side of the GraphQL API. Relations are
+// resolved in two tenant-scoped steps — the (parent, child) pairs, then the
+// children by id — so a link row pointing at another tenant's record (the
+// join tables carry no tenant_id) never surfaces that record.
+type GormGraphRepository struct{ db *gorm.DB }
+
+// NewGormGraphRepository builds the store.
+func NewGormGraphRepository(db *gorm.DB) *GormGraphRepository {
+	return &GormGraphRepository{db: db}
+}
+
+var _ domain.GraphReadRepository = (*GormGraphRepository)(nil)
+
+// Orderings shared by the root lists and the relations, so a risk's assets
+// come back in the same order as the asset list would show them.
+const (
+	graphRiskOrder    = "score DESC, id"
+	graphAssetOrder   = "name, id"
+	graphVulnOrder    = "priority_score DESC, id"
+	graphControlOrder = "reference_code, name, id"
+)
+
+func (r *GormGraphRepository) ListRisks(ctx context.Context, tenantID uuid.UUID, f domain.GraphRiskFilter) ([]domain.Risk, error) {
+	tx := r.db.WithContext(ctx).Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).Where("tenant_id = ?", tenantID)
+	tx = whereLowerIn(tx, "criticality", f.Criticality)
+	tx = whereLowerIn(tx, "status", f.Status)
+	if f.MinScore != nil {
+		tx = tx.Where("score >= ?", *f.MinScore)
+	}
+	var rows []domain.Risk
+	if err := graphPage(tx, f.Limit, f.Offset).Order(graphRiskOrder).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list risks: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormGraphRepository) ListAssets(ctx context.Context, tenantID uuid.UUID, f domain.GraphAssetFilter) ([]domain.Asset, error) {
+	tx := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	tx = whereLowerIn(tx, "criticality", f.Criticality)
+	if f.Type != "" {
+		tx = tx.Where("LOWER(type) = ?", strings.ToLower(f.Type))
+	}
+	var rows []domain.Asset
+	if err := graphPage(tx, f.Limit, f.Offset).Order(graphAssetOrder).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list assets: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormGraphRepository) ListVulnerabilities(ctx context.Context, tenantID uuid.UUID, f domain.GraphVulnerabilityFilter) ([]domain.Vulnerability, error) {
+	tx := graphVulnFilter(r.db.WithContext(ctx).Omit("raw_data").Where("tenant_id = ?", tenantID), f)
+	var rows []domain.Vulnerability
+	if err := graphPage(tx, f.Limit, f.Offset).Order(graphVulnOrder).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list vulnerabilities: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormGraphRepository) ListControls(ctx context.Context, tenantID uuid.UUID, f domain.GraphControlFilter) ([]domain.ComplianceControl, error) {
+	tx := r.db.WithContext(ctx).Preload("Framework").Where("tenant_id = ?", tenantID)
+	tx = whereLowerIn(tx, "status", f.Status)
+	if f.FrameworkID != nil {
+		tx = tx.Where("framework_id = ?", *f.FrameworkID)
+	}
+	var rows []domain.ComplianceControl
+	if err := graphPage(tx, f.Limit, f.Offset).Order(graphControlOrder).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list controls: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormGraphRepository) RisksByID(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]domain.Risk, error) {
+	var rows []domain.Risk
+	if len(ids) == 0 {
+		return rows, nil
+	}
+	// Every relation that yields risks resolves them here, so a hidden risk
+	// never comes back through an asset or a control either.
+	if err := r.db.WithContext(ctx).Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
+		Where("tenant_id = ? AND id IN ?", tenantID, ids).
+		Order(graphRiskOrder).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load risks: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormGraphRepository) AssetsByID(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]domain.Asset, error) {
+	var rows []domain.Asset
+	if len(ids) == 0 {
+		return rows, nil
+	}
+	if err := r.db.WithContext(ctx).Where("tenant_id = ? AND id IN ?", tenantID, ids).
+		Order(graphAssetOrder).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load assets: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormGraphRepository) VulnerabilitiesByID(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]domain.Vulnerability, error) {
+	var rows []domain.Vulnerability
+	if len(ids) == 0 {
+		return rows, nil
+	}
+	if err := r.db.WithContext(ctx).Omit("raw_data").Where("tenant_id = ? AND id IN ?", tenantID, ids).
+		Order(graphVulnOrder).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load vulnerabilities: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormGraphRepository) ControlsByID(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]domain.ComplianceControl, error) {
+	var rows []domain.ComplianceControl
+	if len(ids) == 0 {
+		return rows, nil
+	}
+	if err := r.db.WithContext(ctx).Preload("Framework").Where("tenant_id = ? AND id IN ?", tenantID, ids).
+		Order(graphControlOrder).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load controls: %w", err)
+	}
+	return rows, nil
+}
+
+// graphLink is one (parent, child) pair of a relation.
+type graphLink struct {
+	Parent uuid.UUID
+	Child  uuid.UUID
+}
+
+func (r *GormGraphRepository) AssetsOfRisks(ctx context.Context, tenantID uuid.UUID, riskIDs []uuid.UUID) (map[uuid.UUID][]domain.Asset, error) {
+	if len(riskIDs) == 0 {
+		return map[uuid.UUID][]domain.Asset{}, nil
+	}
+	var links, direct []graphLink
+	if err := r.db.WithContext(ctx).Table("risk_assets").
+		Select("risk_assets.risk_id AS parent, risk_assets.asset_id AS child").
+		Joins("JOIN risks ON risks.id = risk_assets.risk_id").
+		Where("risks.tenant_id = ? AND risks.deleted_at IS NULL AND risk_assets.risk_id IN ?", tenantID, riskIDs).
+		Scan(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to load risk assets: %w", err)
+	}
+	if err := r.db.WithContext(ctx).Model(&domain.Risk{}).
+		Select("id AS parent, asset_id AS child").
+		Where("tenant_id = ? AND id IN ? AND asset_id IS NOT NULL", tenantID, riskIDs).
+		Scan(&direct).Error; err != nil {
+		return nil, fmt.Errorf("failed to load risk assets: %w", err)
+	}
+	links = append(direct, links...)
+	assets, err := r.AssetsByID(ctx, tenantID, linkChildren(links))
+	if err != nil {
+		return nil, err
+	}
+	return groupLinks(links, assets, func(a domain.Asset) uuid.UUID { return a.ID }), nil
+}
+
+func (r *GormGraphRepository) RisksOfAssets(ctx context.Context, tenantID uuid.UUID, assetIDs []uuid.UUID) (map[uuid.UUID][]domain.Risk, error) {
+	if len(assetIDs) == 0 {
+		return map[uuid.UUID][]domain.Risk{}, nil
+	}
+	var links, direct []graphLink
+	if err := r.db.WithContext(ctx).Table("risk_assets").
+		Select("risk_assets.asset_id AS parent, risk_assets.risk_id AS child").
+		Joins("JOIN assets ON assets.id = risk_assets.asset_id").
+		Where("assets.tenant_id = ? AND assets.deleted_at IS NULL AND risk_assets.asset_id IN ?", tenantID, assetIDs).
+		Scan(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to load asset risks: %w", err)
+	}
+	if err := r.db.WithContext(ctx).Model(&domain.Risk{}).
+		Select("asset_id AS parent, id AS child").
+		Where("tenant_id = ? AND asset_id IN ?", tenantID, assetIDs).
+		Scan(&direct).Error; err != nil {
+		return nil, fmt.Errorf("failed to load asset risks: %w", err)
+	}
+	links = append(direct, links...)
+	risks, err := r.RisksByID(ctx, tenantID, linkChildren(links))
+	if err != nil {
+		return nil, err
+	}
+	return groupLinks(links, risks, func(x domain.Risk) uuid.UUID { return x.ID }), nil
+}
+
+func (r *GormGraphRepository) ControlsOfRisks(ctx context.Context, tenantID uuid.UUID, riskIDs []uuid.UUID) (map[uuid.UUID][]domain.ComplianceControl, error) {
+	if len(riskIDs) == 0 {
+		return map[uuid.UUID][]domain.ComplianceControl{}, nil
+	}
+	var links []graphLink
+	if err := r.db.WithContext(ctx).Model(&domain.RiskControlMapping{}).
+		Select("risk_id AS parent, control_id AS child").
+		Where("tenant_id = ? AND risk_id IN ? AND control_id IS NOT NULL", tenantID, riskIDs).
+		Scan(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to load risk controls: %w", err)
+	}
+	controls, err := r.ControlsByID(ctx, tenantID, linkChildren(links))
+	if err != nil {
+		return nil, err
+	}
+	return groupLinks(links, controls, func(c domain.ComplianceControl) uuid.UUID { return c.ID }), nil
+}
+
+func (r *GormGraphRepository) RisksOfControls(ctx context.Context, tenantID uuid.UUID, controlIDs []uuid.UUID) (map[uuid.UUID][]domain.Risk, error) {
+	if len(controlIDs) == 0 {
+		return map[uuid.UUID][]domain.Risk{}, nil
+	}
+	var links []graphLink
+	if err := r.db.WithContext(ctx).Model(&domain.RiskControlMapping{}).
+		Select("control_id AS parent, risk_id AS child").
+		Where("tenant_id = ? AND control_id IN ?", tenantID, controlIDs).
+		Scan(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to load control risks: %w", err)
+	}
+	risks, err := r.RisksByID(ctx, tenantID, linkChildren(links))
+	if err != nil {
+		return nil, err
+	}
+	return groupLinks(links, risks, func(x domain.Risk) uuid.UUID { return x.ID }), nil
+}
+
+func (r *GormGraphRepository) VulnerabilitiesOfAssets(ctx context.Context, tenantID uuid.UUID, assetIDs []uuid.UUID, f domain.GraphVulnerabilityFilter) (map[uuid.UUID][]domain.Vulnerability, error) {
+	out := make(map[uuid.UUID][]domain.Vulnerability, len(assetIDs))
+	if len(assetIDs) == 0 {
+		return out, nil
+	}
+	tx := r.db.WithContext(ctx).Omit("raw_data").Where("tenant_id = ? AND asset_id IN ?", tenantID, assetIDs)
+	var rows []domain.Vulnerability
+	if err := graphVulnFilter(tx, f).Order(graphVulnOrder).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load asset vulnerabilities: %w", err)
+	}
+	for _, v := range rows {
+		out[*v.AssetID] = append(out[*v.AssetID], v)
+	}
+	return out, nil
+}
+
+// GormPersistedQueryRepository stores GraphQL persisted queries by (tenant,
+// hash).
+type GormPersistedQueryRepository struct{ db *gorm.DB }
+
+// NewGormPersistedQueryRepository builds the store.
+func NewGormPersistedQueryRepository(db *gorm.DB) *GormPersistedQueryRepository {
+	return &GormPersistedQueryRepository{db: db}
+}
+
+var _ domain.GraphQLPersistedQueryRepository = (*GormPersistedQueryRepository)(nil)
+
+func (r *GormPersistedQueryRepository) GetPersistedQuery(ctx context.Context, tenantID uuid.UUID, hash string) (*domain.GraphQLPersistedQuery, error) {
+	var q domain.GraphQLPersistedQuery
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND hash = ?", tenantID, hash).Take(&q).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get persisted query: %w", err)
+	}
+	return &q, nil
+}
+
+func (r *GormPersistedQueryRepository) SavePersistedQuery(ctx context.Context, q *domain.GraphQLPersistedQuery) error {
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(q).Error; err != nil {
+		return fmt.Errorf("failed to save persisted query: %w", err)
+	}
+	return nil
+}
+
+// whereIn adds a case-insensitive IN filter when values is non-empty.
+func whereLowerIn(tx *gorm.DB, column string, values []string) *gorm.DB {
+	if len(values) == 0 {
+		return tx
+	}
+	lower := make([]string, len(values))
+	for i, v := range values {
+		lower[i] = strings.ToLower(v)
+	}
+	return tx.Where("LOWER("+column+") IN ?", lower)
+}
+
+func graphVulnFilter(tx *gorm.DB, f domain.GraphVulnerabilityFilter) *gorm.DB {
+	tx = whereLowerIn(tx, "severity", f.Severity)
+	tx = whereLowerIn(tx, "priority_tier", f.Tier)
+	tx = whereLowerIn(tx, "status", f.Status)
+	if f.KEV != nil {
+		tx = tx.Where("kev = ?", *f.KEV)
+	}
+	return tx
+}
+
+func graphPage(tx *gorm.DB, limit, offset int) *gorm.DB {
+	if limit <= 0 {
+		limit = domain.GraphDefaultPage
+	}
+	if offset > 0 {
+		tx = tx.Offset(offset)
+	}
+	return tx.Limit(limit)
+}
+
+func linkChildren(links []graphLink) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(links))
+	out := make([]uuid.UUID, 0, len(links))
+	for _, l := range links {
+		if !seen[l.Child] {
+			seen[l.Child] = true
+			out = append(out, l.Child)
+		}
+	}
+	return out
+}
+
+// groupLinks hands each parent its children, in the order rows came back in
+// and without duplicates. A link whose child is not in rows — deleted, or
+// another tenant's — is dropped.
+func groupLinks[T any](links []graphLink, rows []T, id func(T) uuid.UUID) map[uuid.UUID][]T {
+	parents := make(map[uuid.UUID][]uuid.UUID, len(links))
+	seen := make(map[graphLink]bool, len(links))
+	for _, l := range links {
+		if !seen[l] {
+			seen[l] = true
+			parents[l.Child] = append(parents[l.Child], l.Parent)
+		}
+	}
+	out := make(map[uuid.UUID][]T)
+	for _, row := range rows {
+		for _, parent := range parents[id(row)] {
+			out[parent] = append(out[parent], row)
+		}
+	}
+	return out
+}
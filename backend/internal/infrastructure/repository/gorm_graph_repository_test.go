@@ -0,0 +1,123 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/internal/testsupport/sqliteschema"
+)
+
+func newGraphTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	for _, ddl := range []string{
+		`CREATE TABLE risks (id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, deleted_at DATETIME)`,
+		`CREATE TABLE assets (id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, deleted_at DATETIME)`,
+		`CREATE TABLE risk_assets (risk_id TEXT NOT NULL, asset_id TEXT NOT NULL)`,
+		`CREATE TABLE vulnerabilities (id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL)`,
+		`CREATE TABLE compliance_frameworks (id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, name TEXT NOT NULL)`,
+		`CREATE TABLE compliance_controls (id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, framework_id TEXT NOT NULL)`,
+		`CREATE TABLE risk_control_mappings (id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, risk_id TEXT NOT NULL,
+			framework_id TEXT NOT NULL, control_id TEXT, deleted_at DATETIME)`,
+	} {
+		require.NoError(t, db.Exec(ddl).Error)
+	}
+	require.NoError(t, sqliteschema.Reconcile(db, "risks", &domain.Risk{}))
+	require.NoError(t, sqliteschema.Reconcile(db, "assets", &domain.Asset{}))
+	require.NoError(t, sqliteschema.Reconcile(db, "vulnerabilities", &domain.Vulnerability{}))
+	require.NoError(t, sqliteschema.Reconcile(db, "compliance_controls", &domain.ComplianceControl{}))
+	require.NoError(t, sqliteschema.Reconcile(db, "compliance_frameworks", &domain.ComplianceFramework{}))
+	require.NoError(t, db.AutoMigrate(&domain.GraphQLPersistedQuery{}))
+	return db
+}
+
+// The join tables carry no tenant_id, so a link row can point across
+// tenants (a bad import, a restore). Neither side of such a row may surface.
+// The isolation registry's note on /graphql cites this test.
+func TestGraphRepo_RelationsNeverCrossTenants(t *testing.T) {
+	ctx := context.Background()
+	db := newGraphTestDB(t)
+	repo := NewGormGraphRepository(db)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	riskA, riskB, assetA, assetB := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	fwA, fwB, ctrlA, ctrlB := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	exec := func(sql string, args ...any) { require.NoError(t, db.Exec(sql, args...).Error) }
+	exec(`INSERT INTO risks (id, tenant_id, title, score, criticality) VALUES (?, ?, 'A', 9, 'CRITICAL'), (?, ?, 'B', 9, 'CRITICAL')`,
+		riskA, tenantA, riskB, tenantB)
+	exec(`INSERT INTO assets (id, tenant_id, name) VALUES (?, ?, 'a'), (?, ?, 'b')`, assetA, tenantA, assetB, tenantB)
+	exec(`INSERT INTO risk_assets (risk_id, asset_id) VALUES (?, ?), (?, ?), (?, ?)`,
+		riskA, assetA, riskA, assetB, riskB, assetA)
+	exec(`INSERT INTO vulnerabilities (id, tenant_id, asset_id, cve_id, priority_tier, priority_score) VALUES (?, ?, ?, 'CVE-A', 'P1', 90), (?, ?, ?, 'CVE-B', 'P1', 95)`,
+		uuid.New(), tenantA, assetA, uuid.New(), tenantB, assetA)
+	exec(`INSERT INTO compliance_frameworks (id, tenant_id, name) VALUES (?, ?, 'ISO 27001'), (?, ?, 'SOC 2')`, fwA, tenantA, fwB, tenantB)
+	exec(`INSERT INTO compliance_controls (id, tenant_id, framework_id, name, reference_code) VALUES (?, ?, ?, 'ctl-a', 'A.1'), (?, ?, ?, 'ctl-b', 'B.1')`,
+		ctrlA, tenantA, fwA, ctrlB, tenantB, fwB)
+	exec(`INSERT INTO risk_control_mappings (id, tenant_id, risk_id, framework_id, control_id) VALUES (?, ?, ?, ?, ?), (?, ?, ?, ?, ?)`,
+		uuid.New(), tenantA, riskA, fwA, ctrlA, uuid.New(), tenantA, riskA, fwB, ctrlB)
+
+	assets, err := repo.AssetsOfRisks(ctx, tenantA, []uuid.UUID{riskA, riskB})
+	require.NoError(t, err)
+	require.Len(t, assets[riskA], 1)
+	assert.Equal(t, assetA, assets[riskA][0].ID)
+	assert.Empty(t, assets[riskB], "another tenant's risk is not a parent")
+
+	risks, err := repo.RisksOfAssets(ctx, tenantA, []uuid.UUID{assetA})
+	require.NoError(t, err)
+	require.Len(t, risks[assetA], 1)
+	assert.Equal(t, riskA, risks[assetA][0].ID)
+
+	controls, err := repo.ControlsOfRisks(ctx, tenantA, []uuid.UUID{riskA})
+	require.NoError(t, err)
+	require.Len(t, controls[riskA], 1)
+	assert.Equal(t, ctrlA, controls[riskA][0].ID)
+	assert.Equal(t, "ISO 27001", controls[riskA][0].Framework.Name)
+
+	vulns, err := repo.VulnerabilitiesOfAssets(ctx, tenantA, []uuid.UUID{assetA}, domain.GraphVulnerabilityFilter{Tier: []string{"p1"}})
+	require.NoError(t, err)
+	require.Len(t, vulns[assetA], 1)
+	assert.Equal(t, "CVE-A", vulns[assetA][0].CVEID)
+
+	got, err := repo.RisksByID(ctx, tenantA, []uuid.UUID{riskA, riskB})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, riskA, got[0].ID)
+
+	list, err := repo.ListRisks(ctx, tenantB, domain.GraphRiskFilter{Criticality: []string{"critical"}})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, riskB, list[0].ID)
+}
+
+func TestPersistedQueryRepo_TenantScopedAndIdempotent(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGormPersistedQueryRepository(newGraphTestDB(t))
+	tenantA, tenantB := uuid.New(), uuid.New()
+	hash := "4f6f0c3b1d5c5e0f3b8a0a1d2c3e4f5061728394a5b6c7d8e9f0a1b2c3d4e5f6"
+
+	require.NoError(t, repo.SavePersistedQuery(ctx, &domain.GraphQLPersistedQuery{TenantID: tenantA, Hash: hash, Query: "{ risks { id } }"}))
+	require.NoError(t, repo.SavePersistedQuery(ctx, &domain.GraphQLPersistedQuery{TenantID: tenantA, Hash: hash, Query: "{ risks { id } }"}))
+
+	q, err := repo.GetPersistedQuery(ctx, tenantA, hash)
+	require.NoError(t, err)
+	require.NotNil(t, q)
+	assert.Equal(t, "{ risks { id } }", q.Query)
+
+	q, err = repo.GetPersistedQuery(ctx, tenantB, hash)
+	require.NoError(t, err)
+	assert.Nil(t, q)
+}
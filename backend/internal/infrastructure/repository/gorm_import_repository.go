@@ -0,0 +1,487 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormImportRepository stores import batches, their staged rows and the
+// record of what each applied batch wrote. Every query is tenant-scoped.
+type GormImportRepository struct{ db *gorm.DB }
+
+// NewGormImportRepository builds the store.
+func NewGormImportRepository(db *gorm.DB) *GormImportRepository {
+	return &GormImportRepository{db: db}
+}
+
+var _ domain.ImportRepository = (*GormImportRepository)(nil)
+
+func (r *GormImportRepository) ListBatches(ctx context.Context, tenantID uuid.UUID) ([]domain.ImportBatch, error) {
+	var rows []domain.ImportBatch
+	if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).
+		Order("created_at DESC").Limit(200).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list imports: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormImportRepository) GetBatch(ctx context.Context, tenantID, id uuid.UUID) (*domain.ImportBatch, error) {
+	var b domain.ImportBatch
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&b).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import: %w", err)
+	}
+	return &b, nil
+}
+
+func (r *GormImportRepository) StageBatch(ctx context.Context, b *domain.ImportBatch, rows []domain.ImportBatchRow) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(b).Error; err != nil {
+			return fmt.Errorf("failed to stage import: %w", err)
+		}
+		if err := tx.CreateInBatches(rows, 500).Error; err != nil {
+			return fmt.Errorf("failed to stage import rows: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *GormImportRepository) SaveBatch(ctx context.Context, b *domain.ImportBatch) error {
+	return saveTenantRow(r.db.WithContext(ctx), b, b.ID, b.TenantID, "import")
+}
+
+func (r *GormImportRepository) DeleteBatch(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, child := range []interface{}{&domain.ImportBatchRow{}, &domain.ImportBatchRecord{}} {
+			if err := tx.Where("tenant_id = ? AND batch_id = ?", tenantID, id).Delete(child).Error; err != nil {
+				return fmt.Errorf("failed to delete import: %w", err)
+			}
+		}
+		res := tx.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&domain.ImportBatch{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to delete import: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return domain.NewNotFoundError("import", id)
+		}
+		return nil
+	})
+}
+
+func (r *GormImportRepository) Rows(ctx context.Context, tenantID, batchID uuid.UUID) ([]domain.ImportBatchRow, error) {
+	var rows []domain.ImportBatchRow
+	if err := r.db.WithContext(ctx).Where("tenant_id = ? AND batch_id = ?", tenantID, batchID).
+		Order("row_no ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list import rows: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormImportRepository) Records(ctx context.Context, tenantID, batchID uuid.UUID) ([]domain.ImportBatchRecord, error) {
+	return importRecords(r.db.WithContext(ctx), tenantID, batchID)
+}
+
+func importRecords(db *gorm.DB, tenantID, batchID uuid.UUID) ([]domain.ImportBatchRecord, error) {
+	var rows []domain.ImportBatchRecord
+	if err := db.Where("tenant_id = ? AND batch_id = ?", tenantID, batchID).
+		Order("row_no ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list import records: %w", err)
+	}
+	return rows, nil
+}
+
+// ---------------------------------------------------------------------------
+// Mapping profiles
+// ---------------------------------------------------------------------------
+
+func (r *GormImportRepository) ListProfiles(ctx context.Context, tenantID uuid.UUID, entity domain.ImportEntity) ([]domain.ImportMappingProfile, error) {
+	q := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if entity != "" {
+		q = q.Where("entity = ?", entity)
+	}
+	var rows []domain.ImportMappingProfile
+	if err := q.Order("entity ASC, name ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list mapping profiles: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormImportRepository) GetProfile(ctx context.Context, tenantID, id uuid.UUID) (*domain.ImportMappingProfile, error) {
+	var p domain.ImportMappingProfile
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&p).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mapping profile: %w", err)
+	}
+	return &p, nil
+}
+
+// SaveProfile writes a profile. Saving under a name the tenant already uses
+// for that entity replaces that profile's mapping.
+func (r *GormImportRepository) SaveProfile(ctx context.Context, p *domain.ImportMappingProfile) error {
+	var n int64
+	if err := r.db.WithContext(ctx).Model(p).Where("id = ?", p.ID).Count(&n).Error; err != nil {
+		return fmt.Errorf("failed to save mapping profile: %w", err)
+	}
+	if n > 0 {
+		return saveTenantRow(r.db.WithContext(ctx), p, p.ID, p.TenantID, "mapping profile")
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "entity"}, {Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"mapping", "updated_at"}),
+	}).Create(p).Error; err != nil {
+		return fmt.Errorf("failed to save mapping profile: %w", err)
+	}
+	// On conflict the row kept its own ID; hand that back to the caller.
+	var saved domain.ImportMappingProfile
+	if err := r.db.WithContext(ctx).Where("tenant_id = ? AND entity = ? AND name = ?", p.TenantID, p.Entity, p.Name).
+		Take(&saved).Error; err != nil {
+		return fmt.Errorf("failed to save mapping profile: %w", err)
+	}
+	*p = saved
+	return nil
+}
+
+func (r *GormImportRepository) DeleteProfile(ctx context.Context, tenantID, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&domain.ImportMappingProfile{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete mapping profile: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("mapping profile", id)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Lookups
+// ---------------------------------------------------------------------------
+
+// Lookups loads what rows of this entity can refer to, and nothing else: a
+// control import does not need the tenant's users.
+func (r *GormImportRepository) Lookups(ctx context.Context, tenantID uuid.UUID, entity domain.ImportEntity) (*domain.ImportLookups, error) {
+	db := r.db.WithContext(ctx)
+	lk := &domain.ImportLookups{
+		Existing:   map[string]domain.ImportExisting{},
+		Users:      map[string]uuid.UUID{},
+		Categories: map[string]uuid.UUID{},
+		Frameworks: map[string]uuid.UUID{},
+		Risks:      map[string]uuid.UUID{},
+		Assets:     map[string]uuid.UUID{},
+	}
+
+	switch entity {
+	case domain.ImportRisks:
+		var risks []domain.Risk
+		if err := db.Select("id, external_id, probability, impact, custom_fields").
+			Where("tenant_id = ? AND external_id <> ''", tenantID).Find(&risks).Error; err != nil {
+			return nil, fmt.Errorf("failed to load risks: %w", err)
+		}
+		for _, x := range risks {
+			lk.Existing[strings.ToLower(x.ExternalID)] = domain.ImportExisting{
+				ID: x.ID, Probability: x.Probability, Impact: x.Impact, CustomFields: x.CustomFields,
+			}
+		}
+		var cats []domain.RiskCategory
+		if err := db.Where("tenant_id = ?", tenantID).Find(&cats).Error; err != nil {
+			return nil, fmt.Errorf("failed to load risk categories: %w", err)
+		}
+		for _, c := range cats {
+			lk.Categories[strings.ToLower(c.Slug)] = c.ID
+			lk.Categories[strings.ToLower(c.Name)] = c.ID
+		}
+		var assets []domain.Asset
+		if err := db.Select("id, name, external_id").Where("tenant_id = ?", tenantID).Find(&assets).Error; err != nil {
+			return nil, fmt.Errorf("failed to load assets: %w", err)
+		}
+		// Names first, so an external ID that happens to equal another
+		// asset's name wins: it is the more specific key.
+		for _, a := range assets {
+			lk.Assets[strings.ToLower(a.Name)] = a.ID
+		}
+		for _, a := range assets {
+			if a.ExternalID != "" {
+				lk.Assets[strings.ToLower(a.ExternalID)] = a.ID
+			}
+		}
+		if err := db.Where("tenant_id = ? AND scope = ?", tenantID, domain.CustomFieldScopeRisk).
+			Order("name ASC").Find(&lk.CustomFields).Error; err != nil {
+			return nil, fmt.Errorf("failed to load custom fields: %w", err)
+		}
+		if err := r.loadUsers(db, tenantID, lk); err != nil {
+			return nil, err
+		}
+
+	case domain.ImportAssets:
+		var assets []domain.Asset
+		if err := db.Where("tenant_id = ? AND external_id <> ''", tenantID).Find(&assets).Error; err != nil {
+			return nil, fmt.Errorf("failed to load assets: %w", err)
+		}
+		for i := range assets {
+			a := &assets[i]
+			lk.Existing[strings.ToLower(a.ExternalID)] = domain.ImportExisting{ID: a.ID, Asset: a}
+		}
+
+	case domain.ImportControls:
+		var controls []domain.ComplianceControl
+		if err := db.Select("id, external_id").Where("tenant_id = ? AND external_id <> ''", tenantID).
+			Find(&controls).Error; err != nil {
+			return nil, fmt.Errorf("failed to load controls: %w", err)
+		}
+		for _, c := range controls {
+			lk.Existing[strings.ToLower(c.ExternalID)] = domain.ImportExisting{ID: c.ID}
+		}
+		var fws []domain.ComplianceFramework
+		if err := db.Select("id, name, version").Where("tenant_id = ?", tenantID).Find(&fws).Error; err != nil {
+			return nil, fmt.Errorf("failed to load frameworks: %w", err)
+		}
+		for _, f := range fws {
+			lk.Frameworks[strings.ToLower(f.Name)] = f.ID
+			if f.Version != "" {
+				lk.Frameworks[strings.ToLower(f.Name+" "+f.Version)] = f.ID
+			}
+			lk.Frameworks[f.ID.String()] = f.ID
+		}
+
+	case domain.ImportMitigations:
+		var plans []domain.Mitigation
+		if err := db.Select("id, external_id").Where("tenant_id = ? AND external_id <> ''", tenantID).
+			Find(&plans).Error; err != nil {
+			return nil, fmt.Errorf("failed to load mitigations: %w", err)
+		}
+		for _, m := range plans {
+			lk.Existing[strings.ToLower(m.ExternalID)] = domain.ImportExisting{ID: m.ID}
+		}
+		var risks []domain.Risk
+		if err := db.Select("id, external_id").Where("tenant_id = ? AND external_id <> ''", tenantID).
+			Find(&risks).Error; err != nil {
+			return nil, fmt.Errorf("failed to load risks: %w", err)
+		}
+		for _, x := range risks {
+			lk.Risks[strings.ToLower(x.ExternalID)] = x.ID
+		}
+		if err := r.loadUsers(db, tenantID, lk); err != nil {
+			return nil, err
+		}
+	}
+	return lk, nil
+}
+
+// loadUsers maps the emails of the tenant's members — only members: an email
+// that belongs to a user of another organisation must not resolve.
+func (r *GormImportRepository) loadUsers(db *gorm.DB, tenantID uuid.UUID, lk *domain.ImportLookups) error {
+	var users []struct {
+		ID    uuid.UUID
+		Email string
+	}
+	if err := db.Table("users").Select("users.id, users.email").
+		Joins("JOIN organization_members om ON om.user_id = users.id").
+		Where("om.organization_id = ?", tenantID).Scan(&users).Error; err != nil {
+		return fmt.Errorf("failed to load members: %w", err)
+	}
+	for _, u := range users {
+		lk.Users[strings.ToLower(u.Email)] = u.ID
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Apply / rollback
+// ---------------------------------------------------------------------------
+
+// importModel returns a zero model of the entity, for table and hooks.
+func importModel(e domain.ImportEntity) interface{} {
+	switch e {
+	case domain.ImportRisks:
+		return &domain.Risk{}
+	case domain.ImportAssets:
+		return &domain.Asset{}
+	case domain.ImportControls:
+		return &domain.ComplianceControl{}
+	default:
+		return &domain.Mitigation{}
+	}
+}
+
+// Apply writes every row and records, per entity, what rollback needs: that
+// it was created, or the values it had before the columns the row set.
+func (r *GormImportRepository) Apply(ctx context.Context, b *domain.ImportBatch, writes []domain.ImportWrite) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, w := range writes {
+			rec := domain.ImportBatchRecord{BatchID: b.ID, EntityID: w.EntityID, TenantID: b.TenantID, RowNo: w.Row}
+			if w.Create != nil {
+				rec.Action = domain.ImportCreated
+				if err := tx.Create(w.Create).Error; err != nil {
+					return fmt.Errorf("failed to import row %d: %w", w.Row, err)
+				}
+			} else {
+				rec.Action = domain.ImportUpdated
+				if b.Entity == domain.ImportMitigations {
+					if err := mitigationProgress(tx, w); err != nil {
+						return err
+					}
+				}
+				prev, err := snapshotColumns(tx, b.Entity, b.TenantID, w.EntityID, w.Update)
+				if err != nil {
+					return fmt.Errorf("failed to import row %d: %w", w.Row, err)
+				}
+				rec.Previous = prev
+				if err := tx.Model(importModel(b.Entity)).
+					Where("tenant_id = ? AND id = ?", b.TenantID, w.EntityID).
+					Updates(w.Update).Error; err != nil {
+					return fmt.Errorf("failed to import row %d: %w", w.Row, err)
+				}
+			}
+			at, err := updatedAt(tx, b.Entity, b.TenantID, w.EntityID)
+			if err != nil || at == nil {
+				return fmt.Errorf("failed to import row %d: the written record cannot be read back", w.Row)
+			}
+			rec.WrittenAt = *at
+			if err := tx.Create(&rec).Error; err != nil {
+				return fmt.Errorf("failed to record import row %d: %w", w.Row, err)
+			}
+		}
+		if err := tx.Model(b).Where("tenant_id = ?", b.TenantID).
+			Select("status", "mapping", "created", "updated", "skipped", "applied_at", "updated_at").
+			Updates(b).Error; err != nil {
+			return fmt.Errorf("failed to save import: %w", err)
+		}
+		return nil
+	})
+}
+
+// mitigationProgress recomputes an updated plan's progress when the row
+// changes its status: progress is derived, never imported.
+func mitigationProgress(tx *gorm.DB, w domain.ImportWrite) error {
+	status, ok := w.Update["status"].(domain.MitigationStatus)
+	if !ok {
+		return nil
+	}
+	var c struct{ Total, Done int }
+	if err := tx.Table("mitigation_subactions").
+		Select("COUNT(*) AS total, COALESCE(SUM(CASE WHEN completed THEN 1 ELSE 0 END), 0) AS done").
+		Where("mitigation_id = ? AND deleted_at IS NULL", w.EntityID).Scan(&c).Error; err != nil {
+		return fmt.Errorf("failed to import row %d: %w", w.Row, err)
+	}
+	w.Update["progress"] = domain.ComputeMitigationProgress(status, c.Total, c.Done)
+	return nil
+}
+
+// snapshotColumns reads the current values of the columns an update is about
+// to overwrite, as JSON. Byte values (JSON columns on most drivers) are kept
+// as text so they restore as what they were rather than as base64.
+func snapshotColumns(tx *gorm.DB, e domain.ImportEntity, tenantID, id uuid.UUID, update map[string]any) ([]byte, error) {
+	cols := make([]string, 0, len(update))
+	for c := range update {
+		cols = append(cols, c)
+	}
+	sort.Strings(cols)
+	prev := map[string]any{}
+	if err := tx.Model(importModel(e)).Select(cols).
+		Where("tenant_id = ? AND id = ?", tenantID, id).Take(&prev).Error; err != nil {
+		return nil, err
+	}
+	for k, v := range prev {
+		if raw, ok := v.([]byte); ok {
+			prev[k] = string(raw)
+		}
+	}
+	return json.Marshal(prev)
+}
+
+// updatedAt reads an entity's updated_at, deleted or not; nil when the row is
+// gone altogether.
+func updatedAt(tx *gorm.DB, e domain.ImportEntity, tenantID, id uuid.UUID) (*time.Time, error) {
+	var rows []struct{ UpdatedAt time.Time }
+	if err := tx.Model(importModel(e)).Unscoped().Select("updated_at").
+		Where("tenant_id = ? AND id = ?", tenantID, id).Limit(1).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0].UpdatedAt, nil
+}
+
+// Rollback deletes what the batch created and restores what it updated. A
+// record whose entity changed since (its updated_at moved, or an updated
+// entity is gone) is a conflict; with force the import's own values are
+// undone anyway and a vanished entity is skipped.
+func (r *GormImportRepository) Rollback(ctx context.Context, b *domain.ImportBatch, force bool) ([]uuid.UUID, error) {
+	var conflicts []uuid.UUID
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		records, err := importRecords(tx, b.TenantID, b.ID)
+		if err != nil {
+			return err
+		}
+		current := make([]*time.Time, len(records))
+		for i, rec := range records {
+			at, err := updatedAt(tx, b.Entity, b.TenantID, rec.EntityID)
+			if err != nil {
+				return fmt.Errorf("failed to check imported record: %w", err)
+			}
+			current[i] = at
+			changed := at != nil && !at.Equal(rec.WrittenAt)
+			gone := at == nil && rec.Action == domain.ImportUpdated
+			if changed || gone {
+				conflicts = append(conflicts, rec.EntityID)
+			}
+		}
+		if len(conflicts) > 0 && !force {
+			return nil
+		}
+		conflicts = nil
+
+		for i, rec := range records {
+			if current[i] == nil {
+				continue
+			}
+			q := tx.Model(importModel(b.Entity)).Where("tenant_id = ? AND id = ?", b.TenantID, rec.EntityID)
+			if rec.Action == domain.ImportCreated {
+				if err := q.Unscoped().Delete(importModel(b.Entity)).Error; err != nil {
+					return fmt.Errorf("failed to remove imported record: %w", err)
+				}
+				continue
+			}
+			var prev map[string]any
+			if err := json.Unmarshal(rec.Previous, &prev); err != nil {
+				return fmt.Errorf("failed to read the pre-import values: %w", err)
+			}
+			if len(prev) == 0 {
+				continue
+			}
+			if err := q.Updates(prev).Error; err != nil {
+				return fmt.Errorf("failed to restore imported record: %w", err)
+			}
+		}
+		if err := tx.Model(b).Where("tenant_id = ?", b.TenantID).
+			Select("status", "rolled_back_at", "updated_at").Updates(b).Error; err != nil {
+			return fmt.Errorf("failed to save import: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+func newImportDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&domain.ImportBatch{}, &domain.ImportBatchRow{}, &domain.ImportBatchRecord{}, &domain.ImportMappingProfile{},
+	))
+	for _, ddl := range []string{
+		`CREATE TABLE compliance_frameworks (
+			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, name TEXT, version TEXT, deleted_at DATETIME)`,
+		`CREATE TABLE compliance_controls (
+			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, framework_id TEXT NOT NULL,
+			reference_code TEXT, name TEXT, description TEXT, source_reference TEXT, status TEXT,
+			external_id TEXT, created_at DATETIME, updated_at DATETIME, deleted_at DATETIME)`,
+	} {
+		require.NoError(t, db.Exec(ddl).Error)
+	}
+	return db
+}
+
+func controlName(t *testing.T, db *gorm.DB, id uuid.UUID) string {
+	t.Helper()
+	var names []string
+	require.NoError(t, db.Raw(`SELECT name FROM compliance_controls WHERE id = ?`, id).Scan(&names).Error)
+	if len(names) == 0 {
+		return ""
+	}
+	return names[0]
+}
+
+// The isolation registry cites this test for the /imports and
+// /import-profiles routes.
+func TestImportRepo_ApplyAndRollback(t *testing.T) {
+	ctx := context.Background()
+	db := newImportDB(t)
+	repo := NewGormImportRepository(db)
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	fw := uuid.New()
+	existing := uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO compliance_frameworks (id, tenant_id, name, version) VALUES (?, ?, 'ISO 27001', '2022')`, fw, tenantA).Error)
+	require.NoError(t, db.Exec(`INSERT INTO compliance_controls (id, tenant_id, framework_id, name, status, external_id, updated_at)
+		VALUES (?, ?, ?, 'Old name', 'not_implemented', 'C-1', ?)`, existing, tenantA, fw, time.Now().Add(-time.Hour)).Error)
+
+	b := &domain.ImportBatch{ID: uuid.New(), TenantID: tenantA, Entity: domain.ImportControls, Status: domain.ImportStaged,
+		Format: "csv", Columns: domain.ImportColumns{"id", "name"}, RowCount: 2}
+	require.NoError(t, repo.StageBatch(ctx, b, []domain.ImportBatchRow{
+		{BatchID: b.ID, RowNo: 1, TenantID: tenantA, Values: domain.ImportRowValues{"id": "C-1", "name": "New name"}},
+		{BatchID: b.ID, RowNo: 2, TenantID: tenantA, Values: domain.ImportRowValues{"id": "C-2", "name": "Fresh"}},
+	}))
+	got, err := repo.GetBatch(ctx, tenantB, b.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got, "another tenant cannot read the batch")
+	rows, err := repo.Rows(ctx, tenantB, b.ID)
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+
+	lk, err := repo.Lookups(ctx, tenantA, domain.ImportControls)
+	require.NoError(t, err)
+	assert.Equal(t, existing, lk.Existing["c-1"].ID)
+	assert.Equal(t, fw, lk.Frameworks["iso 27001 2022"])
+	lkB, err := repo.Lookups(ctx, tenantB, domain.ImportControls)
+	require.NoError(t, err)
+	assert.Empty(t, lkB.Existing)
+	assert.Empty(t, lkB.Frameworks)
+
+	created := &domain.ComplianceControl{ID: uuid.New(), TenantID: tenantA, FrameworkID: fw, Name: "Fresh",
+		Status: domain.ControlStatus("not_implemented"), ExternalID: "C-2"}
+	apply := func() {
+		now := time.Now()
+		b.Status, b.AppliedAt = domain.ImportApplied, &now
+		require.NoError(t, repo.Apply(ctx, b, []domain.ImportWrite{
+			{Row: 1, EntityID: existing, Update: map[string]any{"name": "New name"}},
+			{Row: 2, EntityID: created.ID, Create: created},
+		}))
+	}
+	apply()
+	assert.Equal(t, "New name", controlName(t, db, existing))
+	assert.Equal(t, "Fresh", controlName(t, db, created.ID))
+	records, err := repo.Records(ctx, tenantA, b.ID)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, domain.ImportUpdated, records[0].Action)
+	assert.JSONEq(t, `{"name":"Old name"}`, string(records[0].Previous))
+
+	b.Status = domain.ImportRolledBack
+	conflicts, err := repo.Rollback(ctx, b, false)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+	assert.Equal(t, "Old name", controlName(t, db, existing), "updated records get their previous values back")
+	assert.Empty(t, controlName(t, db, created.ID), "created records are removed")
+	saved, err := repo.GetBatch(ctx, tenantA, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ImportRolledBack, saved.Status)
+
+	// A record edited after the import blocks the rollback unless forced.
+	require.NoError(t, db.Exec(`DELETE FROM import_batch_records`).Error)
+	apply()
+	require.NoError(t, db.Exec(`UPDATE compliance_controls SET name = 'Edited', updated_at = ? WHERE id = ?`,
+		time.Now().Add(time.Hour), existing).Error)
+	conflicts, err = repo.Rollback(ctx, b, false)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{existing}, conflicts)
+	assert.Equal(t, "Fresh", controlName(t, db, created.ID), "a refused rollback changes nothing")
+
+	conflicts, err = repo.Rollback(ctx, b, true)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+	assert.Equal(t, "Old name", controlName(t, db, existing))
+	assert.Empty(t, controlName(t, db, created.ID))
+}
+
+func TestImportRepo_ProfileNameIsUpsertKey(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGormImportRepository(newImportDB(t))
+	tenant := uuid.New()
+
+	first := &domain.ImportMappingProfile{ID: uuid.New(), TenantID: tenant, Entity: domain.ImportRisks, Name: "Archer",
+		Mapping: domain.ImportMapping{"Risk Title": "title"}}
+	require.NoError(t, repo.SaveProfile(ctx, first))
+	again := &domain.ImportMappingProfile{ID: uuid.New(), TenantID: tenant, Entity: domain.ImportRisks, Name: "Archer",
+		Mapping: domain.ImportMapping{"Title": "title"}}
+	require.NoError(t, repo.SaveProfile(ctx, again))
+	assert.Equal(t, first.ID, again.ID, "the saved profile keeps its ID")
+
+	items, err := repo.ListProfiles(ctx, tenant, domain.ImportRisks)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, domain.ImportMapping{"Title": "title"}, items[0].Mapping)
+
+	other, err := repo.ListProfiles(ctx, uuid.New(), "")
+	require.NoError(t, err)
+	assert.Empty(t, other)
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormKRIRepository stores key risk indicators and their value history.
+// Every query but ListQueryKRIs is tenant-scoped.
+type GormKRIRepository struct{ db *gorm.DB }
+
+// NewGormKRIRepository builds the store.
+func NewGormKRIRepository(db *gorm.DB) *GormKRIRepository {
+	return &GormKRIRepository{db: db}
+}
+
+var _ domain.KRIRepository = (*GormKRIRepository)(nil)
+
+func (r *GormKRIRepository) List(ctx context.Context, tenantID uuid.UUID) ([]domain.KRI, error) {
+	var rows []domain.KRI
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("name ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list kris: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormKRIRepository) Get(ctx context.Context, tenantID, id uuid.UUID) (*domain.KRI, error) {
+	var k domain.KRI
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&k).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kri: %w", err)
+	}
+	return &k, nil
+}
+
+// Save inserts or updates by id. The update is tenant-scoped and writes every
+// column, so clearing a field sticks.
+func (r *GormKRIRepository) Save(ctx context.Context, k *domain.KRI) error {
+	return saveKRI(r.db.WithContext(ctx), k)
+}
+
+func saveKRI(db *gorm.DB, k *domain.KRI) error {
+	if k.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant_id is required")
+	}
+	var n int64
+	if err := db.Model(&domain.KRI{}).Where("id = ?", k.ID).Count(&n).Error; err != nil {
+		return fmt.Errorf("failed to save kri: %w", err)
+	}
+	if n == 0 {
+		if err := db.Create(k).Error; err != nil {
+			return fmt.Errorf("failed to save kri: %w", err)
+		}
+		return nil
+	}
+	res := db.Model(k).
+		Where("id = ? AND tenant_id = ?", k.ID, k.TenantID).
+		Select("*").Omit("created_at").
+		Updates(k)
+	if res.Error != nil {
+		return fmt.Errorf("failed to save kri: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("kri", k.ID)
+	}
+	return nil
+}
+
+// Delete removes the KRI and its history in one transaction.
+func (r *GormKRIRepository) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&domain.KRI{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to delete kri: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return domain.NewNotFoundError("kri", id)
+		}
+		if err := tx.Where("tenant_id = ? AND kri_id = ?", tenantID, id).Delete(&domain.KRIValue{}).Error; err != nil {
+			return fmt.Errorf("failed to delete kri history: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListQueryKRIs returns every query-sourced KRI across tenants, for the
+// refresh worker.
+func (r *GormKRIRepository) ListQueryKRIs(ctx context.Context) ([]domain.KRI, error) {
+	var rows []domain.KRI
+	if err := r.db.WithContext(ctx).
+		Where("source = ?", domain.KRISourceQuery).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list query kris: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormKRIRepository) RecordValues(ctx context.Context, k *domain.KRI, values []domain.KRIValue) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range values {
+			if values[i].TenantID != k.TenantID || values[i].KRIID != k.ID {
+				return fmt.Errorf("kri value does not belong to kri %s", k.ID)
+			}
+		}
+		if len(values) > 0 {
+			if err := tx.CreateInBatches(values, 500).Error; err != nil {
+				return fmt.Errorf("failed to record kri values: %w", err)
+			}
+		}
+		return saveKRI(tx, k)
+	})
+}
+
+func (r *GormKRIRepository) History(ctx context.Context, tenantID, kriID uuid.UUID, since time.Time) ([]domain.KRIValue, error) {
+	var rows []domain.KRIValue
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND kri_id = ? AND measured_at >= ?", tenantID, kriID, since).
+		Order("measured_at ASC, created_at ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load kri history: %w", err)
+	}
+	return rows, nil
+}
+
+// ---------------------------------------------------------------------------
+// Query evaluation.
+// ---------------------------------------------------------------------------
+
+// GormKRIQueryEvaluator computes a query KRI's value over the tenant's own
+// rows. It only ever interpolates names that domain.KRIQueryField accepts;
+// every value is a bound parameter.
+type GormKRIQueryEvaluator struct{ db *gorm.DB }
+
+// NewGormKRIQueryEvaluator builds the evaluator.
+func NewGormKRIQueryEvaluator(db *gorm.DB) *GormKRIQueryEvaluator {
+	return &GormKRIQueryEvaluator{db: db}
+}
+
+// EvaluateKRIQuery runs q for tenantID at now. An aggregate over no rows is 0.
+func (e *GormKRIQueryEvaluator) EvaluateKRIQuery(ctx context.Context, tenantID uuid.UUID, q domain.KRIQuery, now time.Time) (float64, error) {
+	if err := q.Validate(); err != nil {
+		return 0, err
+	}
+	// Tables are named after their subject; incidents keep tenant ids as text,
+	// which compares equal to the uuid's string form on both drivers.
+	tx := e.db.WithContext(ctx).Table(string(q.Subject)).
+		Where("tenant_id = ? AND deleted_at IS NULL", tenantID.String())
+	if q.WindowDays > 0 {
+		tx = tx.Where("created_at >= ?", now.AddDate(0, 0, -q.WindowDays))
+	}
+	for _, f := range q.Filters {
+		kind, ok := domain.KRIQueryField(q.Subject, f.Field)
+		if !ok {
+			return 0, domain.NewValidationError("kri filter field not allowed: " + f.Field)
+		}
+		col := f.Field
+		switch f.Op {
+		case domain.KRIOpEq, domain.KRIOpNeq:
+			op := "="
+			if f.Op == domain.KRIOpNeq {
+				op = "<>"
+			}
+			var v interface{} = f.Value
+			if kind == domain.KRIFieldBool {
+				v = f.Value == "true"
+			} else if kind == domain.KRIFieldNumber {
+				n, err := domain.ParseKRIValue(f.Value)
+				if err != nil {
+					return 0, domain.NewValidationError(col + " needs a number")
+				}
+				v = n
+			}
+			tx = tx.Where(col+" "+op+" ?", v)
+		case domain.KRIOpIn:
+			tx = tx.Where(col+" IN ?", f.Values)
+		case domain.KRIOpGte, domain.KRIOpLte:
+			n, err := domain.ParseKRIValue(f.Value)
+			if err != nil {
+				return 0, domain.NewValidationError(col + " needs a number")
+			}
+			op := ">="
+			if f.Op == domain.KRIOpLte {
+				op = "<="
+			}
+			tx = tx.Where(col+" "+op+" ?", n)
+		}
+	}
+
+	if q.Aggregate == domain.KRIAggCount {
+		var n int64
+		if err := tx.Count(&n).Error; err != nil {
+			return 0, fmt.Errorf("failed to evaluate kri query: %w", err)
+		}
+		return float64(n), nil
+	}
+	var out sql.NullString
+	expr := fmt.Sprintf("%s(%s)", aggregateSQL(q.Aggregate), q.Field)
+	if err := tx.Select(expr).Scan(&out).Error; err != nil {
+		return 0, fmt.Errorf("failed to evaluate kri query: %w", err)
+	}
+	if !out.Valid || out.String == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(out.String, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read kri query result %q: %w", out.String, err)
+	}
+	return v, nil
+}
+
+func aggregateSQL(a domain.KRIAggregate) string {
+	switch a {
+	case domain.KRIAggSum:
+		return "SUM"
+	case domain.KRIAggAvg:
+		return "AVG"
+	case domain.KRIAggMin:
+		return "MIN"
+	default:
+		return "MAX"
+	}
+}
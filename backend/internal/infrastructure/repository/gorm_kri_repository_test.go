@@ -0,0 +1,135 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestKRIRepo_TenantScopedWithHistory(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.KRI{}, &domain.KRIValue{}))
+	repo := NewGormKRIRepository(db)
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	k := &domain.KRI{ID: uuid.New(), TenantID: tenantA, Name: "Open KEV", Source: domain.KRISourcePush,
+		Direction: domain.KRIHigherIsWorse, AmberThreshold: 1, RedThreshold: 5, Status: domain.KRIStatusUnknown,
+		RiskIDs: domain.StringList{uuid.NewString()}}
+	require.NoError(t, repo.Save(ctx, k))
+
+	day := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	v := func(d int, x float64) domain.KRIValue {
+		return domain.KRIValue{ID: uuid.New(), TenantID: tenantA, KRIID: k.ID, Value: x,
+			Status: k.Classify(x), Source: domain.KRISourcePush, MeasuredAt: day.AddDate(0, 0, d)}
+	}
+	last, at := 6.0, day.AddDate(0, 0, 2)
+	k.LastValue, k.LastMeasuredAt, k.Status = &last, &at, domain.KRIStatusRed
+	require.NoError(t, repo.RecordValues(ctx, k, []domain.KRIValue{v(2, 6), v(0, 0), v(1, 2)}))
+
+	got, err := repo.Get(ctx, tenantA, k.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, domain.KRIStatusRed, got.Status)
+	assert.Equal(t, 6.0, *got.LastValue)
+	assert.Len(t, got.RiskIDs, 1)
+
+	hist, err := repo.History(ctx, tenantA, k.ID, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Len(t, hist, 2)
+	assert.Equal(t, 2.0, hist[0].Value, "oldest first")
+
+	// A value of another tenant cannot be slipped into the KRI.
+	stray := v(3, 1)
+	stray.TenantID = tenantB
+	assert.Error(t, repo.RecordValues(ctx, k, []domain.KRIValue{stray}))
+
+	none, err := repo.Get(ctx, tenantB, k.ID)
+	require.NoError(t, err)
+	assert.Nil(t, none)
+	histB, err := repo.History(ctx, tenantB, k.ID, day)
+	require.NoError(t, err)
+	assert.Empty(t, histB)
+	assert.Error(t, repo.Delete(ctx, tenantB, k.ID))
+
+	require.NoError(t, repo.Delete(ctx, tenantA, k.ID))
+	hist, _ = repo.History(ctx, tenantA, k.ID, day)
+	assert.Empty(t, hist, "history goes with the KRI")
+}
+
+func TestKRIQueryEvaluator_AggregatesTenantRowsOnly(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	// Hand-written DDL: the model's gen_random_uuid() default is Postgres-only.
+	require.NoError(t, db.Exec(`
+		CREATE TABLE vulnerabilities (
+			id TEXT PRIMARY KEY,
+			tenant_id TEXT NOT NULL,
+			title TEXT NOT NULL,
+			kev NUMERIC,
+			cvss_score NUMERIC,
+			status TEXT DEFAULT 'open',
+			created_at DATETIME,
+			updated_at DATETIME,
+			deleted_at DATETIME
+		);
+	`).Error)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	vuln := func(tenant uuid.UUID, kev bool, cvss float64, status domain.VulnStatus, age int) {
+		require.NoError(t, db.Select("id", "tenant_id", "title", "kev", "cvss_score", "status", "created_at", "updated_at").
+			Create(&domain.Vulnerability{
+				ID: uuid.New(), TenantID: tenant, Title: "v", KEV: kev, CVSSScore: cvss, Status: status,
+				CreatedAt: now.AddDate(0, 0, -age),
+			}).Error)
+	}
+	vuln(tenantA, true, 9.8, domain.VulnStatusOpen, 1)
+	vuln(tenantA, true, 7.5, domain.VulnStatusTriaged, 40)
+	vuln(tenantA, false, 5.0, domain.VulnStatusOpen, 2)
+	vuln(tenantA, true, 9.0, domain.VulnStatusRemediated, 3)
+	vuln(tenantB, true, 10, domain.VulnStatusOpen, 1)
+
+	eval := NewGormKRIQueryEvaluator(db)
+	openKEV := domain.KRIQuery{Subject: domain.KRISubjectVulnerabilities, Aggregate: domain.KRIAggCount,
+		Filters: []domain.KRIFilter{
+			{Field: "kev", Op: domain.KRIOpEq, Value: "true"},
+			{Field: "status", Op: domain.KRIOpIn, Values: []string{"open", "triaged"}},
+		}}
+	n, err := eval.EvaluateKRIQuery(ctx, tenantA, openKEV, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, n)
+
+	openKEV.WindowDays = 30
+	n, err = eval.EvaluateKRIQuery(ctx, tenantA, openKEV, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, n, "the window drops the 40-day-old finding")
+
+	avg, err := eval.EvaluateKRIQuery(ctx, tenantA, domain.KRIQuery{Subject: domain.KRISubjectVulnerabilities,
+		Aggregate: domain.KRIAggAvg, Field: "cvss_score",
+		Filters: []domain.KRIFilter{{Field: "status", Op: domain.KRIOpNeq, Value: "remediated"}}}, now)
+	require.NoError(t, err)
+	assert.InDelta(t, (9.8+7.5+5.0)/3, avg, 1e-9)
+
+	empty, err := eval.EvaluateKRIQuery(ctx, uuid.New(), domain.KRIQuery{Subject: domain.KRISubjectVulnerabilities,
+		Aggregate: domain.KRIAggMax, Field: "cvss_score"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, empty)
+
+	_, err = eval.EvaluateKRIQuery(ctx, tenantA, domain.KRIQuery{Subject: domain.KRISubjectVulnerabilities,
+		Aggregate: domain.KRIAggCount, Filters: []domain.KRIFilter{{Field: "title; DROP TABLE x", Op: domain.KRIOpEq, Value: "1"}}}, now)
+	assert.Error(t, err)
+}
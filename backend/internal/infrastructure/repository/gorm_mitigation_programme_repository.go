@@ -0,0 +1,307 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormMitigationProgrammeRepository stores mitigation programmes, their risk
+// and control links, actions, dependencies and assignee capacities. Every
+// query is tenant-scoped.
+type GormMitigationProgrammeRepository struct{ db *gorm.DB }
+
+// NewGormMitigationProgrammeRepository builds the store.
+func NewGormMitigationProgrammeRepository(db *gorm.DB) *GormMitigationProgrammeRepository {
+	return &GormMitigationProgrammeRepository{db: db}
+}
+
+var _ domain.MitigationProgrammeRepository = (*GormMitigationProgrammeRepository)(nil)
+
+func (r *GormMitigationProgrammeRepository) ListProgrammes(ctx context.Context, tenantID uuid.UUID) ([]domain.MitigationProgramme, error) {
+	var rows []domain.MitigationProgramme
+	if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).
+		Order("start_date ASC, title ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list mitigation programmes: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormMitigationProgrammeRepository) GetProgramme(ctx context.Context, tenantID, id uuid.UUID) (*domain.MitigationProgramme, error) {
+	var p domain.MitigationProgramme
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&p).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mitigation programme: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *GormMitigationProgrammeRepository) SaveProgramme(ctx context.Context, p *domain.MitigationProgramme) error {
+	return saveTenantRow(r.db.WithContext(ctx), p, p.ID, p.TenantID, "mitigation programme")
+}
+
+func (r *GormMitigationProgrammeRepository) DeleteProgramme(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, child := range []interface{}{
+			&domain.ProgrammeDependency{}, &domain.ProgrammeAction{},
+			&domain.ProgrammeRiskLink{}, &domain.ProgrammeControlLink{},
+		} {
+			if err := tx.Where("tenant_id = ? AND programme_id = ?", tenantID, id).Delete(child).Error; err != nil {
+				return fmt.Errorf("failed to delete mitigation programme: %w", err)
+			}
+		}
+		res := tx.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&domain.MitigationProgramme{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to delete mitigation programme: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return domain.NewNotFoundError("mitigation programme", id)
+		}
+		return nil
+	})
+}
+
+// scopedToProgrammes narrows a query to the tenant and, unless ids is nil, to
+// the given programmes. An empty, non-nil id set reads nothing.
+func scopedToProgrammes(q *gorm.DB, tenantID uuid.UUID, ids []uuid.UUID) (*gorm.DB, bool) {
+	q = q.Where("tenant_id = ?", tenantID)
+	if ids != nil {
+		if len(ids) == 0 {
+			return q, false
+		}
+		q = q.Where("programme_id IN ?", ids)
+	}
+	return q, true
+}
+
+func (r *GormMitigationProgrammeRepository) RiskLinks(ctx context.Context, tenantID uuid.UUID, programmeIDs []uuid.UUID) ([]domain.ProgrammeRiskLink, error) {
+	var rows []domain.ProgrammeRiskLink
+	q, ok := scopedToProgrammes(r.db.WithContext(ctx), tenantID, programmeIDs)
+	if !ok {
+		return nil, nil
+	}
+	if err := q.Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list programme risks: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormMitigationProgrammeRepository) RiskLinksOf(ctx context.Context, tenantID, riskID uuid.UUID) ([]domain.ProgrammeRiskLink, error) {
+	var rows []domain.ProgrammeRiskLink
+	if err := r.db.WithContext(ctx).Where("tenant_id = ? AND risk_id = ?", tenantID, riskID).
+		Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list risk programmes: %w", err)
+	}
+	return rows, nil
+}
+
+// SaveRiskLink upserts on (programme, risk): linking again updates the
+// expected reduction.
+func (r *GormMitigationProgrammeRepository) SaveRiskLink(ctx context.Context, l *domain.ProgrammeRiskLink) error {
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "programme_id"}, {Name: "risk_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"expected_reduction"}),
+	}).Create(l).Error; err != nil {
+		return fmt.Errorf("failed to link risk to programme: %w", err)
+	}
+	return nil
+}
+
+func (r *GormMitigationProgrammeRepository) DeleteRiskLink(ctx context.Context, tenantID, programmeID, riskID uuid.UUID) error {
+	res := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND programme_id = ? AND risk_id = ?", tenantID, programmeID, riskID).
+		Delete(&domain.ProgrammeRiskLink{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to unlink risk from programme: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("programme risk", riskID)
+	}
+	return nil
+}
+
+func (r *GormMitigationProgrammeRepository) ControlLinks(ctx context.Context, tenantID uuid.UUID, programmeIDs []uuid.UUID) ([]domain.ProgrammeControlLink, error) {
+	var rows []domain.ProgrammeControlLink
+	q, ok := scopedToProgrammes(r.db.WithContext(ctx), tenantID, programmeIDs)
+	if !ok {
+		return nil, nil
+	}
+	if err := q.Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list programme controls: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormMitigationProgrammeRepository) SaveControlLink(ctx context.Context, l *domain.ProgrammeControlLink) error {
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(l).Error; err != nil {
+		return fmt.Errorf("failed to link control to programme: %w", err)
+	}
+	return nil
+}
+
+func (r *GormMitigationProgrammeRepository) DeleteControlLink(ctx context.Context, tenantID, programmeID, controlID uuid.UUID) error {
+	res := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND programme_id = ? AND control_id = ?", tenantID, programmeID, controlID).
+		Delete(&domain.ProgrammeControlLink{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to unlink control from programme: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("programme control", controlID)
+	}
+	return nil
+}
+
+func (r *GormMitigationProgrammeRepository) Actions(ctx context.Context, tenantID uuid.UUID, programmeIDs []uuid.UUID) ([]domain.ProgrammeAction, error) {
+	var rows []domain.ProgrammeAction
+	q, ok := scopedToProgrammes(r.db.WithContext(ctx), tenantID, programmeIDs)
+	if !ok {
+		return nil, nil
+	}
+	if err := q.Order(`"order" ASC, created_at ASC`).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list programme actions: %w", err)
+	}
+	deps, err := r.Dependencies(ctx, tenantID, programmeIDs)
+	if err != nil {
+		return nil, err
+	}
+	attachDependencies(rows, deps)
+	return rows, nil
+}
+
+func attachDependencies(actions []domain.ProgrammeAction, deps []domain.ProgrammeDependency) {
+	by := map[uuid.UUID][]uuid.UUID{}
+	for _, d := range deps {
+		by[d.ActionID] = append(by[d.ActionID], d.DependsOnID)
+	}
+	for i := range actions {
+		actions[i].DependsOn = by[actions[i].ID]
+		if actions[i].DependsOn == nil {
+			actions[i].DependsOn = []uuid.UUID{}
+		}
+	}
+}
+
+func (r *GormMitigationProgrammeRepository) GetAction(ctx context.Context, tenantID, id uuid.UUID) (*domain.ProgrammeAction, error) {
+	var a domain.ProgrammeAction
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&a).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get programme action: %w", err)
+	}
+	var deps []domain.ProgrammeDependency
+	if err := r.db.WithContext(ctx).Where("tenant_id = ? AND action_id = ?", tenantID, id).Find(&deps).Error; err != nil {
+		return nil, fmt.Errorf("failed to get programme action: %w", err)
+	}
+	rows := []domain.ProgrammeAction{a}
+	attachDependencies(rows, deps)
+	return &rows[0], nil
+}
+
+// SaveAction stores the action and replaces its dependencies in one
+// transaction, so the schedule never sees an action with half its edges.
+func (r *GormMitigationProgrammeRepository) SaveAction(ctx context.Context, a *domain.ProgrammeAction) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := saveTenantRow(tx, a, a.ID, a.TenantID, "programme action"); err != nil {
+			return err
+		}
+		if err := tx.Where("tenant_id = ? AND action_id = ?", a.TenantID, a.ID).Delete(&domain.ProgrammeDependency{}).Error; err != nil {
+			return fmt.Errorf("failed to save programme action dependencies: %w", err)
+		}
+		for _, d := range a.DependsOn {
+			dep := domain.ProgrammeDependency{TenantID: a.TenantID, ProgrammeID: a.ProgrammeID, ActionID: a.ID, DependsOnID: d}
+			if err := tx.Create(&dep).Error; err != nil {
+				return fmt.Errorf("failed to save programme action dependencies: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *GormMitigationProgrammeRepository) DeleteAction(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tenant_id = ? AND (action_id = ? OR depends_on_id = ?)", tenantID, id, id).
+			Delete(&domain.ProgrammeDependency{}).Error; err != nil {
+			return fmt.Errorf("failed to delete programme action dependencies: %w", err)
+		}
+		res := tx.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&domain.ProgrammeAction{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to delete programme action: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return domain.NewNotFoundError("programme action", id)
+		}
+		return nil
+	})
+}
+
+func (r *GormMitigationProgrammeRepository) Dependencies(ctx context.Context, tenantID uuid.UUID, programmeIDs []uuid.UUID) ([]domain.ProgrammeDependency, error) {
+	var rows []domain.ProgrammeDependency
+	q, ok := scopedToProgrammes(r.db.WithContext(ctx), tenantID, programmeIDs)
+	if !ok {
+		return nil, nil
+	}
+	if err := q.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list programme action dependencies: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormMitigationProgrammeRepository) Capacities(ctx context.Context, tenantID uuid.UUID) ([]domain.AssigneeCapacity, error) {
+	var rows []domain.AssigneeCapacity
+	if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list assignee capacities: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormMitigationProgrammeRepository) SaveCapacity(ctx context.Context, c *domain.AssigneeCapacity) error {
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"fte", "updated_at"}),
+	}).Create(c).Error; err != nil {
+		return fmt.Errorf("failed to save assignee capacity: %w", err)
+	}
+	return nil
+}
+
+func (r *GormMitigationProgrammeRepository) Risks(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]domain.Risk, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var rows []domain.Risk
+	if err := r.db.WithContext(ctx).Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
+		Select("id", "tenant_id", "name", "title", "score", "criticality").
+		Where("tenant_id = ? AND id IN ? AND deleted_at IS NULL", tenantID, ids).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list programme risks: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormMitigationProgrammeRepository) GetControl(ctx context.Context, tenantID, id uuid.UUID) (*domain.ComplianceControl, error) {
+	var c domain.ComplianceControl
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&c).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get control: %w", err)
+	}
+	return &c, nil
+}
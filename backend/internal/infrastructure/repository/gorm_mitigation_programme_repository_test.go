@@ -0,0 +1,100 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+func newProgrammeDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&domain.MitigationProgramme{}, &domain.ProgrammeRiskLink{}, &domain.ProgrammeControlLink{},
+		&domain.ProgrammeAction{}, &domain.ProgrammeDependency{}, &domain.AssigneeCapacity{},
+	))
+	require.NoError(t, db.Exec(`CREATE TABLE risks (
+		id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, name TEXT, title TEXT, score REAL, criticality TEXT,
+		deleted_at DATETIME)`).Error)
+	return db
+}
+
+// The isolation registry cites this test for the /mitigation-programmes and
+// /programme-actions routes.
+func TestMitigationProgrammeRepo_TenantScoped(t *testing.T) {
+	ctx := context.Background()
+	db := newProgrammeDB(t)
+	repo := NewGormMitigationProgrammeRepository(db)
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	p := &domain.MitigationProgramme{ID: uuid.New(), TenantID: tenantA, Title: "EDR", Status: domain.MitigationPlanned, StartDate: time.Now()}
+	require.NoError(t, repo.SaveProgramme(ctx, p))
+
+	got, err := repo.GetProgramme(ctx, tenantB, p.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got, "another tenant cannot read the programme")
+
+	riskID := uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO risks (id, tenant_id, name, score) VALUES (?, ?, 'Ransomware', 8)`, riskID, tenantA).Error)
+	require.NoError(t, repo.SaveRiskLink(ctx, &domain.ProgrammeRiskLink{TenantID: tenantA, ProgrammeID: p.ID, RiskID: riskID, ExpectedReduction: 0.4}))
+	require.NoError(t, repo.SaveRiskLink(ctx, &domain.ProgrammeRiskLink{TenantID: tenantA, ProgrammeID: p.ID, RiskID: riskID, ExpectedReduction: 0.6}))
+	links, err := repo.RiskLinksOf(ctx, tenantA, riskID)
+	require.NoError(t, err)
+	require.Len(t, links, 1, "linking again updates the link")
+	assert.InDelta(t, 0.6, links[0].ExpectedReduction, 1e-9)
+
+	risks, err := repo.Risks(ctx, tenantB, []uuid.UUID{riskID})
+	require.NoError(t, err)
+	assert.Empty(t, risks)
+
+	first := &domain.ProgrammeAction{ID: uuid.New(), TenantID: tenantA, ProgrammeID: p.ID, Title: "Pilot", EffortDays: 5, DependsOn: []uuid.UUID{}}
+	require.NoError(t, repo.SaveAction(ctx, first))
+	second := &domain.ProgrammeAction{ID: uuid.New(), TenantID: tenantA, ProgrammeID: p.ID, Title: "Rollout", EffortDays: 10, Order: 1, DependsOn: []uuid.UUID{first.ID}}
+	require.NoError(t, repo.SaveAction(ctx, second))
+
+	actions, err := repo.Actions(ctx, tenantA, []uuid.UUID{p.ID})
+	require.NoError(t, err)
+	require.Len(t, actions, 2)
+	assert.Equal(t, []uuid.UUID{first.ID}, actions[1].DependsOn)
+
+	none, err := repo.Actions(ctx, tenantA, []uuid.UUID{})
+	require.NoError(t, err)
+	assert.Empty(t, none, "an empty scope reads nothing rather than everything")
+
+	a, err := repo.GetAction(ctx, tenantB, second.ID)
+	require.NoError(t, err)
+	assert.Nil(t, a)
+
+	require.NoError(t, repo.DeleteAction(ctx, tenantA, first.ID))
+	deps, err := repo.Dependencies(ctx, tenantA, nil)
+	require.NoError(t, err)
+	assert.Empty(t, deps, "deleting an action drops the edges onto it")
+
+	require.NoError(t, repo.SaveCapacity(ctx, &domain.AssigneeCapacity{TenantID: tenantA, UserID: riskID, FTE: 0.5}))
+	require.NoError(t, repo.SaveCapacity(ctx, &domain.AssigneeCapacity{TenantID: tenantA, UserID: riskID, FTE: 0.8}))
+	caps, err := repo.Capacities(ctx, tenantA)
+	require.NoError(t, err)
+	require.Len(t, caps, 1)
+	assert.InDelta(t, 0.8, caps[0].FTE, 1e-9)
+
+	assert.Error(t, repo.DeleteProgramme(ctx, tenantB, p.ID))
+	require.NoError(t, repo.DeleteProgramme(ctx, tenantA, p.ID))
+	links, err = repo.RiskLinks(ctx, tenantA, nil)
+	require.NoError(t, err)
+	assert.Empty(t, links, "links go with the programme")
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormOrgHierarchyRepository stores organization links and reads group
+// entities' registers. The register reads are scoped to the tenant set the
+// caller passes in — an empty set reads nothing.
+type GormOrgHierarchyRepository struct{ db *gorm.DB }
+
+// NewGormOrgHierarchyRepository builds the store.
+func NewGormOrgHierarchyRepository(db *gorm.DB) *GormOrgHierarchyRepository {
+	return &GormOrgHierarchyRepository{db: db}
+}
+
+var _ domain.OrgHierarchyRepository = (*GormOrgHierarchyRepository)(nil)
+
+func (r *GormOrgHierarchyRepository) GetOrganization(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	var o domain.Organization
+	err := r.db.WithContext(ctx).Where("id = ?", id).Take(&o).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return &o, nil
+}
+
+func (r *GormOrgHierarchyRepository) GetOrganizationBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	var o domain.Organization
+	err := r.db.WithContext(ctx).Where("slug = ?", slug).Take(&o).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return &o, nil
+}
+
+func (r *GormOrgHierarchyRepository) Organizations(ctx context.Context, ids []uuid.UUID) ([]domain.Organization, error) {
+	out := []domain.Organization{}
+	if len(ids) == 0 {
+		return out, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Order("name ASC").Find(&out).Error; err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	return out, nil
+}
+
+func (r *GormOrgHierarchyRepository) GetLink(ctx context.Context, id uuid.UUID) (*domain.OrganizationLink, error) {
+	var l domain.OrganizationLink
+	err := r.db.WithContext(ctx).Where("id = ?", id).Take(&l).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization link: %w", err)
+	}
+	return &l, nil
+}
+
+func (r *GormOrgHierarchyRepository) SaveLink(ctx context.Context, l *domain.OrganizationLink) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	if err := r.db.WithContext(ctx).Save(l).Error; err != nil {
+		return fmt.Errorf("failed to save organization link: %w", err)
+	}
+	return nil
+}
+
+func (r *GormOrgHierarchyRepository) LinksOf(ctx context.Context, orgID uuid.UUID) ([]domain.OrganizationLink, error) {
+	out := []domain.OrganizationLink{}
+	err := r.db.WithContext(ctx).
+		Where("parent_id = ? OR child_id = ?", orgID, orgID).
+		Order("created_at DESC").
+		Find(&out).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization links: %w", err)
+	}
+	return out, nil
+}
+
+func (r *GormOrgHierarchyRepository) LiveParentLink(ctx context.Context, orgID uuid.UUID) (*domain.OrganizationLink, error) {
+	var l domain.OrganizationLink
+	err := r.db.WithContext(ctx).
+		Where("child_id = ? AND status IN ?", orgID, []domain.OrganizationLinkStatus{domain.OrgLinkPending, domain.OrgLinkActive}).
+		Take(&l).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent link: %w", err)
+	}
+	return &l, nil
+}
+
+func (r *GormOrgHierarchyRepository) ActiveChildren(ctx context.Context, parentIDs []uuid.UUID) ([]domain.OrganizationLink, error) {
+	out := []domain.OrganizationLink{}
+	if len(parentIDs) == 0 {
+		return out, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("parent_id IN ? AND status = ?", parentIDs, domain.OrgLinkActive).
+		Find(&out).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list child organizations: %w", err)
+	}
+	return out, nil
+}
+
+// GroupRisks loads the full risk model (no Preload) for the given tenants, the
+// way ListRisksForFinancial does, so the Monte Carlo sees the monetary drivers.
+// The caller's access policies are root's: they filter root's own risks and
+// leave the subsidiaries' alone.
+func (r *GormOrgHierarchyRepository) GroupRisks(ctx context.Context, root uuid.UUID, tenantIDs []uuid.UUID) ([]domain.Risk, error) {
+	out := []domain.Risk{}
+	if len(tenantIDs) == 0 {
+		return out, nil
+	}
+	visible := r.db.WithContext(ctx).
+		Model(&domain.Risk{}).
+		Select("risks.id").
+		Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
+		Where("risks.tenant_id = ?", root)
+	err := r.db.WithContext(ctx).
+		Where("tenant_id IN ?", tenantIDs).
+		Where("tenant_id <> ? OR id IN (?)", root, visible).
+		Order("score DESC").
+		Find(&out).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group risks: %w", err)
+	}
+	return out, nil
+}
+
+func (r *GormOrgHierarchyRepository) GroupCategories(ctx context.Context, tenantIDs []uuid.UUID) ([]domain.RiskCategory, error) {
+	out := []domain.RiskCategory{}
+	if len(tenantIDs) == 0 {
+		return out, nil
+	}
+	if err := r.db.WithContext(ctx).Where("tenant_id IN ?", tenantIDs).Find(&out).Error; err != nil {
+		return nil, fmt.Errorf("failed to list group categories: %w", err)
+	}
+	return out, nil
+}
+
+func (r *GormOrgHierarchyRepository) GroupControls(ctx context.Context, tenantIDs []uuid.UUID) ([]domain.GroupControlRow, error) {
+	out := []domain.GroupControlRow{}
+	if len(tenantIDs) == 0 {
+		return out, nil
+	}
+	err := r.db.WithContext(ctx).
+		Table("compliance_controls AS c").
+		Select("c.tenant_id, f.name AS framework_name, f.version AS framework_version, f.catalog_key, c.status").
+		Joins("JOIN compliance_frameworks f ON f.id = c.framework_id AND f.tenant_id = c.tenant_id AND f.deleted_at IS NULL").
+		Where("c.tenant_id IN ? AND c.deleted_at IS NULL", tenantIDs).
+		Scan(&out).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group controls: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+func newOrgHierarchyDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.OrganizationLink{}))
+	for _, ddl := range []string{
+		`CREATE TABLE organizations (
+			id TEXT PRIMARY KEY, name TEXT, slug TEXT, is_active BOOLEAN DEFAULT 1, settings TEXT,
+			created_at DATETIME, updated_at DATETIME)`,
+		`CREATE TABLE risks (
+			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, name TEXT, title TEXT, probability REAL, impact REAL,
+			score REAL, criticality TEXT, category_id TEXT, classification TEXT, deleted_at DATETIME)`,
+		`CREATE TABLE compliance_frameworks (
+			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, name TEXT, version TEXT, catalog_key TEXT,
+			deleted_at DATETIME)`,
+		`CREATE TABLE compliance_controls (
+			id TEXT PRIMARY KEY, external_id TEXT, tenant_id TEXT NOT NULL, framework_id TEXT NOT NULL, status TEXT,
+			deleted_at DATETIME)`,
+	} {
+		require.NoError(t, db.Exec(ddl).Error)
+	}
+	return db
+}
+
+// The isolation registry cites this test for the /group routes.
+func TestOrgHierarchyRepo_ReadsOnlyTheGivenTenants(t *testing.T) {
+	ctx := context.Background()
+	db := newOrgHierarchyDB(t)
+	repo := NewGormOrgHierarchyRepository(db)
+	holding, sub, outsider := uuid.New(), uuid.New(), uuid.New()
+
+	require.NoError(t, db.Exec(`INSERT INTO organizations (id, name, slug) VALUES (?, 'Holding', 'holding'), (?, 'Sub', 'sub'), (?, 'Other', 'other')`,
+		holding, sub, outsider).Error)
+	require.NoError(t, db.Exec(`INSERT INTO risks (id, tenant_id, name, probability, impact, score, deleted_at) VALUES
+		(?, ?, 'h', 0.2, 4, 1, NULL), (?, ?, 's', 0.8, 9, 5, NULL), (?, ?, 'gone', 0.5, 5, 2, CURRENT_TIMESTAMP), (?, ?, 'o', 0.9, 9, 9, NULL)`,
+		uuid.New(), holding, uuid.New(), sub, uuid.New(), sub, uuid.New(), outsider).Error)
+	fwH, fwO := uuid.New(), uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO compliance_frameworks (id, tenant_id, name, version, catalog_key) VALUES
+		(?, ?, 'ISO 27001', '2022', 'iso27001'), (?, ?, 'ISO 27001', '2022', 'iso27001')`, fwH, holding, fwO, outsider).Error)
+	require.NoError(t, db.Exec(`INSERT INTO compliance_controls (id, tenant_id, framework_id, status) VALUES
+		(?, ?, ?, 'implemented'), (?, ?, ?, 'implemented')`, uuid.New(), holding, fwH, uuid.New(), outsider, fwO).Error)
+
+	group := []uuid.UUID{holding, sub}
+	risks, err := repo.GroupRisks(ctx, holding, group)
+	require.NoError(t, err)
+	require.Len(t, risks, 2, "soft-deleted and foreign risks stay out")
+	assert.Equal(t, "s", risks[0].Name, "highest score first")
+
+	controls, err := repo.GroupControls(ctx, group)
+	require.NoError(t, err)
+	require.Len(t, controls, 1)
+	assert.Equal(t, holding, controls[0].TenantID)
+	assert.Equal(t, "iso27001", controls[0].CatalogKey)
+
+	none, err := repo.GroupRisks(ctx, holding, nil)
+	require.NoError(t, err)
+	assert.Empty(t, none, "an empty scope reads nothing rather than everything")
+
+	link := &domain.OrganizationLink{ParentID: holding, ChildID: sub, Status: domain.OrgLinkActive}
+	require.NoError(t, repo.SaveLink(ctx, link))
+	require.NoError(t, repo.SaveLink(ctx, &domain.OrganizationLink{ParentID: outsider, ChildID: holding, Status: domain.OrgLinkRevoked}))
+
+	children, err := repo.ActiveChildren(ctx, []uuid.UUID{holding})
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+	assert.Equal(t, sub, children[0].ChildID)
+
+	parent, err := repo.LiveParentLink(ctx, holding)
+	require.NoError(t, err)
+	assert.Nil(t, parent, "a revoked link is no parent")
+
+	links, err := repo.LinksOf(ctx, holding)
+	require.NoError(t, err)
+	assert.Len(t, links, 2, "both directions, revoked kept for the record")
+
+	org, err := repo.GetOrganizationBySlug(ctx, "sub")
+	require.NoError(t, err)
+	require.NotNil(t, org)
+	assert.Equal(t, sub, org.ID)
+}
+
+// Every group rollup (entities, heatmap, categories, Monte Carlo, drill-down)
+// reads through GroupRisks, so a risk it leaves out is out of all of them.
+func TestOrgHierarchyRepo_GroupRisksHideTheCallersHiddenRisks(t *testing.T) {
+	db := newOrgHierarchyDB(t)
+	repo := NewGormOrgHierarchyRepository(db)
+	holding, sub := uuid.New(), uuid.New()
+	shown, hidden, subsidiary := uuid.New(), uuid.New(), uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO risks (id, tenant_id, name, score, classification) VALUES
+		(?, ?, 'shown', 1, ''), (?, ?, 'hidden', 2, 'confidential'), (?, ?, 'subsidiary', 3, 'confidential')`,
+		shown, holding, hidden, holding, subsidiary, sub).Error)
+	ctx := domain.WithAccessScope(context.Background(), &domain.AccessScope{
+		Subject: domain.AccessSubject{UserID: uuid.New(), Role: domain.RoleUser},
+		Policies: []domain.AccessPolicy{{Name: "confidential", Resource: domain.AccessResourceRisk, Effect: domain.AccessDeny, Enabled: true,
+			Condition: &domain.AccessCondition{Attr: "classification", Op: domain.AccessOpIn, Values: []string{"confidential"}}}},
+	})
+
+	risks, err := repo.GroupRisks(ctx, holding, []uuid.UUID{holding, sub})
+	require.NoError(t, err)
+	var ids []uuid.UUID
+	for _, r := range risks {
+		ids = append(ids, r.ID)
+	}
+	assert.ElementsMatch(t, []uuid.UUID{shown, subsidiary}, ids, "the holding's policies hide its own risk, not the subsidiary's")
+
+	drill, err := repo.GroupRisks(ctx, holding, []uuid.UUID{holding})
+	require.NoError(t, err)
+	require.Len(t, drill, 1, "an entity drill-down on the holding")
+	assert.Equal(t, shown, drill[0].ID)
+
+	all, err := repo.GroupRisks(context.Background(), holding, []uuid.UUID{holding, sub})
+	require.NoError(t, err)
+	assert.Len(t, all, 3, "without a scope, every risk")
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormPluginRepository stores connector plugin packages, their installs and
+// the run log. Every query is tenant-scoped except the webhook token lookup.
+type GormPluginRepository struct{ db *gorm.DB }
+
+// NewGormPluginRepository builds the store.
+func NewGormPluginRepository(db *gorm.DB) *GormPluginRepository {
+	return &GormPluginRepository{db: db}
+}
+
+var _ domain.PluginRepository = (*GormPluginRepository)(nil)
+
+func (r *GormPluginRepository) ListPackages(ctx context.Context, tenantID uuid.UUID) ([]domain.PluginPackage, error) {
+	var rows []domain.PluginPackage
+	if err := r.db.WithContext(ctx).Omit("module").Where("tenant_id = ?", tenantID).
+		Order("name, created_at DESC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list plugins: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormPluginRepository) GetPackage(ctx context.Context, tenantID, id uuid.UUID) (*domain.PluginPackage, error) {
+	var p domain.PluginPackage
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&p).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plugin: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *GormPluginRepository) FindPackage(ctx context.Context, tenantID uuid.UUID, name, version string) (*domain.PluginPackage, error) {
+	var p domain.PluginPackage
+	err := r.db.WithContext(ctx).Omit("module").
+		Where("tenant_id = ? AND name = ? AND version = ?", tenantID, name, version).Take(&p).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find plugin: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *GormPluginRepository) CreatePackage(ctx context.Context, p *domain.PluginPackage) error {
+	if err := r.db.WithContext(ctx).Create(p).Error; err != nil {
+		return fmt.Errorf("failed to save plugin: %w", err)
+	}
+	return nil
+}
+
+func (r *GormPluginRepository) DeletePackage(ctx context.Context, tenantID, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&domain.PluginPackage{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete plugin: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("plugin", id)
+	}
+	return nil
+}
+
+func (r *GormPluginRepository) ListInstalls(ctx context.Context, tenantID uuid.UUID) ([]domain.PluginInstall, error) {
+	var rows []domain.PluginInstall
+	if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).
+		Order("name").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list plugin installs: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormPluginRepository) GetInstall(ctx context.Context, tenantID, id uuid.UUID) (*domain.PluginInstall, error) {
+	var in domain.PluginInstall
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&in).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plugin install: %w", err)
+	}
+	return &in, nil
+}
+
+func (r *GormPluginRepository) GetInstallByWebhookToken(ctx context.Context, token string) (*domain.PluginInstall, error) {
+	if token == "" {
+		return nil, nil
+	}
+	var in domain.PluginInstall
+	err := r.db.WithContext(ctx).Where("webhook_token = ?", token).Take(&in).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve plugin webhook: %w", err)
+	}
+	return &in, nil
+}
+
+func (r *GormPluginRepository) CountInstalls(ctx context.Context, tenantID, packageID uuid.UUID) (int64, error) {
+	var n int64
+	if err := r.db.WithContext(ctx).Model(&domain.PluginInstall{}).
+		Where("tenant_id = ? AND package_id = ?", tenantID, packageID).Count(&n).Error; err != nil {
+		return 0, fmt.Errorf("failed to count plugin installs: %w", err)
+	}
+	return n, nil
+}
+
+func (r *GormPluginRepository) SaveInstall(ctx context.Context, in *domain.PluginInstall) error {
+	return saveTenantRow(r.db.WithContext(ctx), in, in.ID, in.TenantID, "plugin install")
+}
+
+func (r *GormPluginRepository) DeleteInstall(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tenant_id = ? AND install_id = ?", tenantID, id).Delete(&domain.PluginRun{}).Error; err != nil {
+			return fmt.Errorf("failed to delete plugin install: %w", err)
+		}
+		res := tx.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&domain.PluginInstall{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to delete plugin install: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return domain.NewNotFoundError("plugin install", id)
+		}
+		return nil
+	})
+}
+
+func (r *GormPluginRepository) RecordRun(ctx context.Context, run *domain.PluginRun) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(run).Error; err != nil {
+			return fmt.Errorf("failed to record plugin run: %w", err)
+		}
+		if err := tx.Model(&domain.PluginInstall{}).
+			Where("tenant_id = ? AND id = ?", run.TenantID, run.InstallID).
+			Updates(map[string]interface{}{"last_run_at": run.CreatedAt, "last_run_status": run.Status}).Error; err != nil {
+			return fmt.Errorf("failed to record plugin run: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *GormPluginRepository) ListRuns(ctx context.Context, tenantID, installID uuid.UUID, limit int) ([]domain.PluginRun, error) {
+	var rows []domain.PluginRun
+	if err := r.db.WithContext(ctx).Where("tenant_id = ? AND install_id = ?", tenantID, installID).
+		Order("created_at DESC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list plugin runs: %w", err)
+	}
+	return rows, nil
+}
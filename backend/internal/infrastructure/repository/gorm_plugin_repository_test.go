@@ -0,0 +1,100 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// The isolation registry cites this test for the /plugins and
+// /plugin-installs routes.
+func TestPluginRepo_TenantScopedAndRunLog(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.PluginPackage{}, &domain.PluginInstall{}, &domain.PluginRun{}))
+	repo := NewGormPluginRepository(db)
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	p := &domain.PluginPackage{ID: uuid.New(), TenantID: tenantA, Name: "acme", Version: "1.0.0", Publisher: "Acme",
+		ABIVersion: 1, Hooks: domain.StringList{"pull_findings"}, KeyID: "k", Digest: "d", Size: 3,
+		Module: []byte{0, 'a', 's'}, CreatedAt: time.Now()}
+	require.NoError(t, repo.CreatePackage(ctx, p))
+
+	list, err := repo.ListPackages(ctx, tenantA)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Empty(t, list[0].Module, "listing does not load modules")
+	got, err := repo.GetPackage(ctx, tenantA, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 'a', 's'}, got.Module)
+	got, err = repo.GetPackage(ctx, tenantB, p.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got, "another tenant cannot read the package")
+	found, err := repo.FindPackage(ctx, tenantA, "acme", "1.0.0")
+	require.NoError(t, err)
+	assert.NotNil(t, found)
+	assert.Error(t, repo.DeletePackage(ctx, tenantB, p.ID))
+
+	in := &domain.PluginInstall{ID: uuid.New(), TenantID: tenantA, PackageID: p.ID, Name: "acme", Enabled: true,
+		MemoryPages: 256, TimeoutMS: 10000, WebhookToken: "tok-a"}
+	require.NoError(t, repo.SaveInstall(ctx, in))
+	other := &domain.PluginInstall{ID: uuid.New(), TenantID: tenantA, PackageID: p.ID, Name: "acme-2", Enabled: true,
+		MemoryPages: 256, TimeoutMS: 10000, WebhookToken: "tok-b"}
+	require.NoError(t, repo.SaveInstall(ctx, other))
+	n, err := repo.CountInstalls(ctx, tenantA, p.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+	n, err = repo.CountInstalls(ctx, tenantB, p.ID)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	stolen := *in
+	stolen.TenantID = tenantB
+	assert.Error(t, repo.SaveInstall(ctx, &stolen), "an install cannot move tenant")
+	gi, err := repo.GetInstall(ctx, tenantB, in.ID)
+	require.NoError(t, err)
+	assert.Nil(t, gi)
+
+	byToken, err := repo.GetInstallByWebhookToken(ctx, "tok-a")
+	require.NoError(t, err)
+	require.NotNil(t, byToken)
+	assert.Equal(t, in.ID, byToken.ID)
+	byToken, err = repo.GetInstallByWebhookToken(ctx, "")
+	require.NoError(t, err)
+	assert.Nil(t, byToken)
+
+	at := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, repo.RecordRun(ctx, &domain.PluginRun{ID: uuid.New(), TenantID: tenantA, InstallID: in.ID,
+		Hook: domain.HookPullFindings, Status: domain.PluginRunFailed, Error: "401", CreatedAt: at}))
+	gi, err = repo.GetInstall(ctx, tenantA, in.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PluginRunFailed, gi.LastRunStatus)
+	require.NotNil(t, gi.LastRunAt)
+	runs, err := repo.ListRuns(ctx, tenantA, in.ID, 10)
+	require.NoError(t, err)
+	assert.Len(t, runs, 1)
+	runs, err = repo.ListRuns(ctx, tenantB, in.ID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+
+	assert.Error(t, repo.DeleteInstall(ctx, tenantB, in.ID))
+	require.NoError(t, repo.DeleteInstall(ctx, tenantA, in.ID))
+	var left int64
+	require.NoError(t, db.Model(&domain.PluginRun{}).Count(&left).Error)
+	assert.Zero(t, left, "the run log goes with the install")
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormRegisterSnapshotRepository stores register snapshots. It only ever
+// inserts: the interface has no update, and neither does this type.
+type GormRegisterSnapshotRepository struct{ db *gorm.DB }
+
+// NewGormRegisterSnapshotRepository builds the store.
+func NewGormRegisterSnapshotRepository(db *gorm.DB) *GormRegisterSnapshotRepository {
+	return &GormRegisterSnapshotRepository{db: db}
+}
+
+var _ domain.RegisterSnapshotRepository = (*GormRegisterSnapshotRepository)(nil)
+
+// snapshotRowBatch keeps one INSERT well under postgres' 65535 bind
+// parameters (a row binds about twenty).
+const snapshotRowBatch = 500
+
+// LiveRisks reads the register through the caller's access policies. Taking
+// a snapshot clears the scope first: a snapshot is the whole register.
+func (r *GormRegisterSnapshotRepository) LiveRisks(ctx context.Context, tenantID uuid.UUID) ([]domain.Risk, error) {
+	var risks []domain.Risk
+	if err := r.db.WithContext(ctx).Preload("Assets").
+		Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
+		Where("tenant_id = ?", tenantID).
+		Find(&risks).Error; err != nil {
+		return nil, fmt.Errorf("failed to read the register: %w", err)
+	}
+	return risks, nil
+}
+
+func (r *GormRegisterSnapshotRepository) Create(ctx context.Context, s *domain.RegisterSnapshot, rows []domain.RegisterSnapshotRisk) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(s).Error; err != nil {
+			return fmt.Errorf("failed to create register snapshot: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		if err := tx.CreateInBatches(rows, snapshotRowBatch).Error; err != nil {
+			return fmt.Errorf("failed to store register snapshot rows: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *GormRegisterSnapshotRepository) Get(ctx context.Context, tenantID, id uuid.UUID) (*domain.RegisterSnapshot, error) {
+	var s domain.RegisterSnapshot
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&s).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get register snapshot: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *GormRegisterSnapshotRepository) AtOrBefore(ctx context.Context, tenantID uuid.UUID, at time.Time) (*domain.RegisterSnapshot, error) {
+	var s domain.RegisterSnapshot
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND as_of <= ?", tenantID, at).
+		Order("as_of DESC, taken_at DESC").
+		Take(&s).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve register snapshot: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *GormRegisterSnapshotRepository) List(ctx context.Context, tenantID uuid.UUID, limit int) ([]domain.RegisterSnapshot, error) {
+	var rows []domain.RegisterSnapshot
+	q := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("as_of DESC, taken_at DESC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list register snapshots: %w", err)
+	}
+	return rows, nil
+}
+
+// Rows keeps the rows of the risks the caller may see today. A policy reads
+// the risk as it is now, so the row of a risk deleted since is hidden from
+// anyone a policy applies to.
+func (r *GormRegisterSnapshotRepository) Rows(ctx context.Context, tenantID, snapshotID uuid.UUID, f domain.RegisterSnapshotFilter) ([]domain.RegisterSnapshotRisk, int64, error) {
+	q := r.db.WithContext(ctx).Model(&domain.RegisterSnapshotRisk{}).
+		Where("tenant_id = ? AND snapshot_id = ?", tenantID, snapshotID)
+	if deny, allow := domain.AccessScopeFrom(ctx).Applicable(domain.AccessResourceRisk); len(deny) > 0 || len(allow) > 0 {
+		q = q.Where("risk_id IN (?)", r.db.WithContext(ctx).Model(&domain.Risk{}).
+			Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
+			Where("risks.tenant_id = ?", tenantID).
+			Select("risks.id"))
+	}
+	if s := strings.TrimSpace(f.Query); s != "" {
+		q = q.Where("LOWER(title) LIKE ?", "%"+strings.ToLower(s)+"%")
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+	if f.Criticality != "" {
+		q = q.Where("LOWER(criticality) = ?", strings.ToLower(f.Criticality))
+	}
+	if f.OwnerID != nil {
+		q = q.Where("owner_id = ?", *f.OwnerID)
+	}
+	if f.MinScore != nil {
+		q = q.Where("score >= ?", *f.MinScore)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count register snapshot rows: %w", err)
+	}
+	if f.Limit > 0 {
+		page := f.Page
+		if page < 1 {
+			page = 1
+		}
+		q = q.Offset((page - 1) * f.Limit).Limit(f.Limit)
+	}
+	var rows []domain.RegisterSnapshotRisk
+	if err := q.Order("score DESC, title ASC").Find(&rows).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list register snapshot rows: %w", err)
+	}
+	return rows, total, nil
+}
+
+func (r *GormRegisterSnapshotRepository) AllRows(ctx context.Context, tenantID, snapshotID uuid.UUID) ([]domain.RegisterSnapshotRisk, error) {
+	rows, _, err := r.Rows(ctx, tenantID, snapshotID, domain.RegisterSnapshotFilter{})
+	return rows, err
+}
+
+// TenantsDue is cross-tenant by design, like the other sweeps: it is the
+// worker's only way to find whom to snapshot.
+func (r *GormRegisterSnapshotRepository) TenantsDue(ctx context.Context, asOf time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Model(&domain.Risk{}).
+		Distinct("tenant_id").
+		Where("tenant_id NOT IN (?)",
+			r.db.Model(&domain.RegisterSnapshot{}).
+				Select("tenant_id").
+				Where("kind = ? AND as_of >= ?", domain.RegisterSnapshotScheduled, asOf)).
+		Pluck("tenant_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to list tenants due a register snapshot: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newRegisterSnapshotDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.RegisterSnapshot{}, &domain.RegisterSnapshotRisk{}))
+	require.NoError(t, db.Exec(`CREATE TABLE risks (id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, deleted_at DATETIME)`).Error)
+	return db
+}
+
+func takeSnapshot(t *testing.T, repo *GormRegisterSnapshotRepository, tenantID uuid.UUID, kind domain.RegisterSnapshotKind, asOf time.Time, rows ...domain.RegisterSnapshotRisk) *domain.RegisterSnapshot {
+	t.Helper()
+	s := &domain.RegisterSnapshot{ID: uuid.New(), TenantID: tenantID, Kind: kind, AsOf: asOf, TakenAt: asOf}
+	for i := range rows {
+		rows[i].SnapshotID, rows[i].TenantID = s.ID, tenantID
+	}
+	s.Summarize(rows)
+	require.NoError(t, repo.Create(context.Background(), s, rows))
+	return s
+}
+
+// The isolation registry cites this test for /register-snapshots/{id}.
+func TestRegisterSnapshotRepo_AsOfAndTenantScope(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGormRegisterSnapshotRepository(newRegisterSnapshotDB(t))
+	tenantA, tenantB := uuid.New(), uuid.New()
+	dec31 := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	yearEnd := takeSnapshot(t, repo, tenantA, domain.RegisterSnapshotScheduled, dec31.AddDate(0, 0, 1),
+		domain.RegisterSnapshotRisk{RiskID: uuid.New(), Title: "Ransomware", Score: 8, Status: domain.RiskOpen, Criticality: "critical", Tags: []string{"cyber"}, Controls: []string{"ISO 27001 · A.8.13"}},
+		domain.RegisterSnapshotRisk{RiskID: uuid.New(), Title: "Fraude", Score: 3, Status: domain.RiskOpen, Criticality: "medium"},
+	)
+	takeSnapshot(t, repo, tenantA, domain.RegisterSnapshotScheduled, dec31)
+	takeSnapshot(t, repo, tenantA, domain.RegisterSnapshotManual, dec31.AddDate(0, 0, 5))
+	takeSnapshot(t, repo, tenantB, domain.RegisterSnapshotManual, dec31.AddDate(0, 0, 1))
+
+	got, err := repo.AtOrBefore(ctx, tenantA, dec31.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, yearEnd.ID, got.ID, "the close of 31 December, not the later manual snapshot")
+	assert.Equal(t, 2, got.RiskCount)
+
+	none, err := repo.AtOrBefore(ctx, tenantA, dec31.Add(-time.Second))
+	require.NoError(t, err)
+	assert.Nil(t, none, "nothing was frozen before the first snapshot")
+
+	rows, total, err := repo.Rows(ctx, tenantA, yearEnd.ID, domain.RegisterSnapshotFilter{Criticality: "CRITICAL", Limit: 10})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+	require.Len(t, rows, 1)
+	assert.Equal(t, []string{"ISO 27001 · A.8.13"}, []string(rows[0].Controls))
+
+	other, err := repo.Get(ctx, tenantB, yearEnd.ID)
+	require.NoError(t, err)
+	assert.Nil(t, other)
+	leaked, _, err := repo.Rows(ctx, tenantB, yearEnd.ID, domain.RegisterSnapshotFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, leaked)
+}
+
+func TestRegisterSnapshotRepo_TenantsDue(t *testing.T) {
+	ctx := context.Background()
+	db := newRegisterSnapshotDB(t)
+	repo := NewGormRegisterSnapshotRepository(db)
+	done, due, manualOnly, empty := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	for _, tenant := range []uuid.UUID{done, due, manualOnly} {
+		require.NoError(t, db.Exec(`INSERT INTO risks (id, tenant_id) VALUES (?, ?)`, uuid.New(), tenant).Error)
+	}
+	require.NoError(t, db.Exec(`INSERT INTO risks (id, tenant_id, deleted_at) VALUES (?, ?, ?)`, uuid.New(), empty, time.Now()).Error)
+
+	today := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	takeSnapshot(t, repo, done, domain.RegisterSnapshotScheduled, today)
+	takeSnapshot(t, repo, due, domain.RegisterSnapshotScheduled, today.AddDate(0, 0, -1))
+	takeSnapshot(t, repo, manualOnly, domain.RegisterSnapshotManual, today.Add(time.Hour))
+
+	ids, err := repo.TenantsDue(ctx, today)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{due, manualOnly}, ids,
+		"a manual snapshot does not stand in for the close of day; a register of deleted risks is not snapshotted")
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormRegulatoryRepository stores jurisdiction profiles, incident
+// classifications and the notification clocks they start. Tenant-scoped on every
+// query except ListEscalationDue, which is the monitor's cross-tenant read.
+type GormRegulatoryRepository struct{ db *gorm.DB }
+
+// NewGormRegulatoryRepository builds the store.
+func NewGormRegulatoryRepository(db *gorm.DB) *GormRegulatoryRepository {
+	return &GormRegulatoryRepository{db: db}
+}
+
+var _ domain.RegulatoryRepository = (*GormRegulatoryRepository)(nil)
+
+func (r *GormRegulatoryRepository) ListProfiles(ctx context.Context, tenantID uuid.UUID) ([]domain.RegulatoryProfile, error) {
+	var rows []domain.RegulatoryProfile
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("regime ASC, jurisdiction ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list regulatory profiles: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormRegulatoryRepository) GetProfile(ctx context.Context, tenantID, id uuid.UUID) (*domain.RegulatoryProfile, error) {
+	var p domain.RegulatoryProfile
+	err := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).Take(&p).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get regulatory profile: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *GormRegulatoryRepository) SaveProfile(ctx context.Context, p *domain.RegulatoryProfile) error {
+	return r.save(ctx, &domain.RegulatoryProfile{}, p.ID, p.TenantID, p, "regulatory profile")
+}
+
+func (r *GormRegulatoryRepository) DeleteProfile(ctx context.Context, tenantID, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&domain.RegulatoryProfile{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete regulatory profile: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("regulatory profile", id)
+	}
+	return nil
+}
+
+func (r *GormRegulatoryRepository) GetAssessment(ctx context.Context, tenantID uuid.UUID, incidentID uint) (*domain.IncidentRegulatoryAssessment, error) {
+	var a domain.IncidentRegulatoryAssessment
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND incident_id = ?", tenantID, incidentID).
+		Take(&a).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get regulatory assessment: %w", err)
+	}
+	return &a, nil
+}
+
+func (r *GormRegulatoryRepository) SaveAssessment(ctx context.Context, a *domain.IncidentRegulatoryAssessment) error {
+	return r.save(ctx, &domain.IncidentRegulatoryAssessment{}, a.ID, a.TenantID, a, "regulatory assessment")
+}
+
+func (r *GormRegulatoryRepository) ListClocks(ctx context.Context, tenantID uuid.UUID, incidentID uint) ([]domain.RegulatoryClock, error) {
+	var rows []domain.RegulatoryClock
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND incident_id = ?", tenantID, incidentID).
+		Order("due_at ASC, sequence ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list regulatory clocks: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormRegulatoryRepository) ListOpenClocks(ctx context.Context, tenantID uuid.UUID) ([]domain.RegulatoryClock, error) {
+	var rows []domain.RegulatoryClock
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND status = ?", tenantID, domain.ClockPending).
+		Order("due_at ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list open regulatory clocks: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormRegulatoryRepository) GetClock(ctx context.Context, tenantID, id uuid.UUID) (*domain.RegulatoryClock, error) {
+	var c domain.RegulatoryClock
+	err := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).Take(&c).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get regulatory clock: %w", err)
+	}
+	return &c, nil
+}
+
+func (r *GormRegulatoryRepository) SaveClock(ctx context.Context, c *domain.RegulatoryClock) error {
+	return r.save(ctx, &domain.RegulatoryClock{}, c.ID, c.TenantID, c, "regulatory clock")
+}
+
+// ListEscalationDue returns pending clocks whose next reminder is due, oldest
+// reminder first so a backlog drains fairly across tenants.
+func (r *GormRegulatoryRepository) ListEscalationDue(ctx context.Context, now time.Time, limit int) ([]domain.RegulatoryClock, error) {
+	var rows []domain.RegulatoryClock
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND next_escalation_at IS NOT NULL AND next_escalation_at <= ?", domain.ClockPending, now).
+		Order("next_escalation_at ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list due regulatory escalations: %w", err)
+	}
+	return rows, nil
+}
+
+// save inserts or fully rewrites a row, scoping the update to its tenant so an
+// id from another tenant can never be overwritten.
+func (r *GormRegulatoryRepository) save(ctx context.Context, model interface{}, id, tenantID uuid.UUID, row interface{}, what string) error {
+	if tenantID == uuid.Nil {
+		return fmt.Errorf("tenant_id is required")
+	}
+	var n int64
+	if err := r.db.WithContext(ctx).Model(model).Where("id = ?", id).Count(&n).Error; err != nil {
+		return fmt.Errorf("failed to save %s: %w", what, err)
+	}
+	if n == 0 {
+		if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+			return fmt.Errorf("failed to save %s: %w", what, err)
+		}
+		return nil
+	}
+	res := r.db.WithContext(ctx).Model(row).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		Select("*").Omit("created_at").
+		Updates(row)
+	if res.Error != nil {
+		return fmt.Errorf("failed to save %s: %w", what, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError(what, id)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupRegulatoryRepo(t *testing.T) *GormRegulatoryRepository {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{DisableForeignKeyConstraintWhenMigrating: true})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&domain.RegulatoryProfile{},
+		&domain.IncidentRegulatoryAssessment{},
+		&domain.RegulatoryClock{},
+	))
+	return NewGormRegulatoryRepository(db)
+}
+
+func TestRegulatoryRepo_ClockSaveIsTenantScoped(t *testing.T) {
+	ctx := context.Background()
+	repo := setupRegulatoryRepo(t)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	c := &domain.RegulatoryClock{
+		ID: uuid.New(), TenantID: tenantA, IncidentID: 7, ProfileID: uuid.New(),
+		Regime: domain.RegimeGDPR, Stage: "notification", Status: domain.ClockPending,
+		DueAt: now.Add(72 * time.Hour), CreatedAt: now,
+	}
+	require.NoError(t, repo.SaveClock(ctx, c))
+
+	// Zero values must be written on update: a submitted clock clears its
+	// reminder schedule.
+	c.Status = domain.ClockSubmitted
+	c.SubmissionReference = "CNIL-2026-001"
+	c.SubmittedContent = domain.JSONMap{"nature": "stolen laptop"}
+	require.NoError(t, repo.SaveClock(ctx, c))
+
+	got, err := repo.GetClock(ctx, tenantA, c.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, domain.ClockSubmitted, got.Status)
+	assert.Equal(t, "CNIL-2026-001", got.SubmissionReference)
+	assert.Equal(t, "stolen laptop", got.SubmittedContent["nature"])
+
+	none, err := repo.GetClock(ctx, tenantB, c.ID)
+	require.NoError(t, err)
+	assert.Nil(t, none)
+
+	hijack := *got
+	hijack.TenantID = tenantB
+	hijack.SubmissionReference = "forged"
+	assert.Error(t, repo.SaveClock(ctx, &hijack))
+	got, _ = repo.GetClock(ctx, tenantA, c.ID)
+	assert.Equal(t, "CNIL-2026-001", got.SubmissionReference)
+}
+
+func TestRegulatoryRepo_EscalationDueOnlyPending(t *testing.T) {
+	ctx := context.Background()
+	repo := setupRegulatoryRepo(t)
+	tenant := uuid.New()
+	now := time.Now().UTC()
+	past, future := now.Add(-time.Minute), now.Add(time.Hour)
+
+	mk := func(status domain.RegulatoryClockStatus, next *time.Time) uuid.UUID {
+		c := &domain.RegulatoryClock{
+			ID: uuid.New(), TenantID: tenant, IncidentID: 1, ProfileID: uuid.New(),
+			Regime: domain.RegimeNIS2, Stage: "early_warning", Status: status,
+			DueAt: now.Add(time.Hour), NextEscalationAt: next,
+		}
+		require.NoError(t, repo.SaveClock(ctx, c))
+		return c.ID
+	}
+	due := mk(domain.ClockPending, &past)
+	mk(domain.ClockPending, &future)
+	mk(domain.ClockSubmitted, &past)
+	mk(domain.ClockPending, nil)
+
+	rows, err := repo.ListEscalationDue(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, due, rows[0].ID)
+
+	open, err := repo.ListOpenClocks(ctx, tenant)
+	require.NoError(t, err)
+	assert.Len(t, open, 3)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormRiskAppetiteRepository stores the board's risk appetite statements.
+// Every query is tenant-scoped.
+type GormRiskAppetiteRepository struct{ db *gorm.DB }
+
+// NewGormRiskAppetiteRepository builds the store.
+func NewGormRiskAppetiteRepository(db *gorm.DB) *GormRiskAppetiteRepository {
+	return &GormRiskAppetiteRepository{db: db}
+}
+
+var _ domain.RiskAppetiteRepository = (*GormRiskAppetiteRepository)(nil)
+
+func (r *GormRiskAppetiteRepository) List(ctx context.Context, tenantID uuid.UUID) ([]domain.RiskAppetiteStatement, error) {
+	var rows []domain.RiskAppetiteStatement
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("scope ASC, business_unit ASC, created_at ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list risk appetite statements: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormRiskAppetiteRepository) Get(ctx context.Context, tenantID, id uuid.UUID) (*domain.RiskAppetiteStatement, error) {
+	var s domain.RiskAppetiteStatement
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&s).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get risk appetite statement: %w", err)
+	}
+	return &s, nil
+}
+
+// Save inserts or updates by id. The update is tenant-scoped and writes every
+// column, so clearing a tolerance (nil) sticks.
+func (r *GormRiskAppetiteRepository) Save(ctx context.Context, s *domain.RiskAppetiteStatement) error {
+	if s.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant_id is required")
+	}
+	var n int64
+	if err := r.db.WithContext(ctx).Model(&domain.RiskAppetiteStatement{}).Where("id = ?", s.ID).Count(&n).Error; err != nil {
+		return fmt.Errorf("failed to save risk appetite statement: %w", err)
+	}
+	if n == 0 {
+		if err := r.db.WithContext(ctx).Create(s).Error; err != nil {
+			return fmt.Errorf("failed to save risk appetite statement: %w", err)
+		}
+		return nil
+	}
+	res := r.db.WithContext(ctx).Model(s).
+		Where("id = ? AND tenant_id = ?", s.ID, s.TenantID).
+		Select("*").Omit("created_at").
+		Updates(s)
+	if res.Error != nil {
+		return fmt.Errorf("failed to save risk appetite statement: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("risk appetite statement", s.ID)
+	}
+	return nil
+}
+
+func (r *GormRiskAppetiteRepository) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&domain.RiskAppetiteStatement{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete risk appetite statement: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("risk appetite statement", id)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRiskAppetiteRepo_TenantScopedAndClearsTolerances(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.RiskAppetiteStatement{}))
+	repo := NewGormRiskAppetiteRepository(db)
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	maxScore, maxCrit := 12.0, 2
+	approved := time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)
+	s := &domain.RiskAppetiteStatement{
+		ID: uuid.New(), TenantID: tenantA, Scope: domain.AppetiteScopeBusinessUnit, BusinessUnit: "Retail",
+		Statement: "No critical exposure on customer-facing channels.",
+		MaxScore:  &maxScore, MaxCriticalCount: &maxCrit, ApprovedOn: &approved, ApprovalReference: "BOARD-2026-03",
+	}
+	require.NoError(t, repo.Save(ctx, s))
+	got, err := repo.Get(ctx, tenantA, s.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, 12.0, *got.MaxScore)
+	assert.Equal(t, 2, *got.MaxCriticalCount)
+	assert.True(t, got.InForce())
+
+	// Clearing a tolerance on update sticks.
+	s.MaxScore = nil
+	require.NoError(t, repo.Save(ctx, s))
+	got, err = repo.Get(ctx, tenantA, s.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.MaxScore)
+
+	none, err := repo.Get(ctx, tenantB, s.ID)
+	require.NoError(t, err)
+	assert.Nil(t, none)
+	hijack := *got
+	hijack.TenantID = tenantB
+	assert.Error(t, repo.Save(ctx, &hijack))
+	assert.Error(t, repo.Delete(ctx, tenantB, s.ID))
+	listB, err := repo.List(ctx, tenantB)
+	require.NoError(t, err)
+	assert.Empty(t, listB)
+
+	require.NoError(t, repo.Delete(ctx, tenantA, s.ID))
+	gone, _ := repo.Get(ctx, tenantA, s.ID)
+	assert.Nil(t, gone)
+}
@@ -51,10 +51,12 @@ func (r *GormRiskRepository) Create(ctx context.Context, risk *domain.Risk) erro
 }
 
 // GetByID retrieves a risk by ID scoped to a tenant.
-// Returns (nil, nil) if not found (use case handles 404).
+// Returns (nil, nil) if not found (use case handles 404) — including a risk the
+// request's access policies hide from its user.
 func (r *GormRiskRepository) GetByID(ctx context.Context, id uuid.UUID, tenantID uuid.UUID) (*domain.Risk, error) {
 	var risk domain.Risk
 	err := r.db.WithContext(ctx).
+		Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
 		Where("id = ? AND tenant_id = ?", id, tenantID).
 		Preload("Mitigations").
 		Preload("Assets").
@@ -80,6 +82,7 @@ func (r *GormRiskRepository) List(ctx context.Context, tenantID uuid.UUID, query
 
 	db := r.db.WithContext(ctx).
 		Model(&domain.Risk{}).
+		Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
 		Where("tenant_id = ?", tenantID)
 
 	// Apply filters
@@ -250,6 +253,7 @@ func (r *GormRiskRepository) Update(ctx context.Context, risk *domain.Risk) erro
 // Delete soft-deletes a risk by ID scoped to a tenant.
 func (r *GormRiskRepository) Delete(ctx context.Context, id uuid.UUID, tenantID uuid.UUID) error {
 	result := r.db.WithContext(ctx).
+		Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
 		Where("id = ? AND tenant_id = ?", id, tenantID).
 		Delete(&domain.Risk{})
 
@@ -289,6 +293,7 @@ func (r *GormRiskRepository) CountRisksByCriticality(ctx context.Context, tenant
 	err := r.db.WithContext(ctx).
 		Model(&domain.Risk{}).
 		Select("LOWER(criticality) AS criticality, COUNT(*) AS count").
+		Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
 		Where("tenant_id = ?", tenantID).
 		Group("LOWER(criticality)").
 		Scan(&rows).Error
@@ -324,6 +329,7 @@ func (r *GormRiskRepository) SearchByText(ctx context.Context, tenantID uuid.UUI
 	like := "%" + strings.ToLower(strings.TrimSpace(q)) + "%"
 	var risks []domain.Risk
 	err := r.db.WithContext(ctx).
+		Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
 		Where("tenant_id = ?", tenantID).
 		Where("LOWER(name) LIKE ? OR LOWER(title) LIKE ? OR LOWER(COALESCE(description,'')) LIKE ?", like, like, like).
 		Order("score DESC").
@@ -354,6 +360,7 @@ func (r *GormRiskRepository) CountFinancialCoverage(ctx context.Context, tenantI
 				OR other_direct_cost_xaf IS NOT NULL
 				OR (downtime_hours IS NOT NULL AND hourly_downtime_cost_xaf IS NOT NULL)
 			) AS quantified`).
+		Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
 		Where("tenant_id = ?", tenantID).
 		Scan(&res).Error
 	return int(res.Total), int(res.Quantified), err
@@ -362,6 +369,7 @@ func (r *GormRiskRepository) CountFinancialCoverage(ctx context.Context, tenantI
 func (r *GormRiskRepository) ListRisksForFinancial(ctx context.Context, tenantID uuid.UUID) ([]domain.Risk, error) {
 	var risks []domain.Risk
 	err := r.db.WithContext(ctx).
+		Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
 		Where("tenant_id = ?", tenantID).
 		Find(&risks).Error
 	if err != nil {
@@ -370,6 +378,26 @@ func (r *GormRiskRepository) ListRisksForFinancial(ctx context.Context, tenantID
 	return risks, nil
 }
 
+// VisibleRiskIDs returns which of ids the request's access policies let its
+// user see, for reads that evaluate the whole register but list only what the
+// user may see (the appetite evaluation).
+func (r *GormRiskRepository) VisibleRiskIDs(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	out := make(map[uuid.UUID]bool, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+	var visible []uuid.UUID
+	if err := r.db.WithContext(ctx).Model(&domain.Risk{}).Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
+		Where("tenant_id = ? AND id IN ?", tenantID, ids).
+		Pluck("risks.id", &visible).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range visible {
+		out[id] = true
+	}
+	return out, nil
+}
+
 // TopRisksByScore returns the tenant's highest-scoring active risks, capped at
 // limit, for the executive "top 10 risks" widget. It loads the full model (no
 // hand-written SELECT) so the executive use case can quantify each one's ALE from
@@ -381,6 +409,7 @@ func (r *GormRiskRepository) TopRisksByScore(ctx context.Context, tenantID uuid.
 	}
 	var risks []domain.Risk
 	err := r.db.WithContext(ctx).
+		Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
 		Where("tenant_id = ?", tenantID).
 		Order("score DESC").
 		Limit(limit).
@@ -495,6 +524,60 @@ func (r *GormRiskRepository) UpdateScore(ctx context.Context, riskID uuid.UUID,
 	return nil
 }
 
+// ApplyBIADowntime sets the CRQ downtime input of every risk on an asset from
+// that asset's business impact analysis. A nil hours clears the BIA-derived
+// values (the analysis was deleted). Risks whose downtime was entered by hand
+// are left alone: only NULL or previously BIA-sourced values are written.
+//
+// Targeted column update like UpdateSmartScore, and a concrete method rather
+// than part of domain.RiskRepository, so no mock of that port has to grow.
+func (r *GormRiskRepository) ApplyBIADowntime(ctx context.Context, tenantID, assetID uuid.UUID, hours *float64) (int64, error) {
+	q := r.db.WithContext(ctx).Model(&domain.Risk{}).
+		Where("tenant_id = ? AND asset_id = ?", tenantID, assetID)
+	var res *gorm.DB
+	if hours == nil {
+		res = q.Where("downtime_hours_source = ?", "bia").
+			Updates(map[string]interface{}{"downtime_hours": nil, "downtime_hours_source": "", "updated_at": time.Now()})
+	} else {
+		res = q.Where("downtime_hours IS NULL OR downtime_hours_source = ?", "bia").
+			Updates(map[string]interface{}{"downtime_hours": *hours, "downtime_hours_source": "bia", "updated_at": time.Now()})
+	}
+	if res.Error != nil {
+		return 0, fmt.Errorf("failed to apply BIA downtime: %w", res.Error)
+	}
+	return res.RowsAffected, nil
+}
+
+// RaiseProbabilityForReview adds step to a risk's probability (capped at 1)
+// and makes its review due at, so the owner re-assesses it. This is what a KRI
+// crossing into red does to the risks it watches. Returns the updated risk, or
+// (nil, nil) when the risk is not in the tenant. Targeted column update: the
+// score is recomputed by the Score Engine from the risk.updated event the
+// caller publishes.
+func (r *GormRiskRepository) RaiseProbabilityForReview(ctx context.Context, tenantID, riskID uuid.UUID, step float64, at time.Time) (*domain.Risk, error) {
+	var risk domain.Risk
+	err := r.db.WithContext(ctx).Preload("Assets").Where("id = ? AND tenant_id = ?", riskID, tenantID).Take(&risk).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load risk: %w", err)
+	}
+	p := risk.Probability + step
+	if p > 1 {
+		p = 1
+	}
+	res := r.db.WithContext(ctx).Model(&domain.Risk{}).
+		Where("id = ? AND tenant_id = ?", riskID, tenantID).
+		Updates(map[string]interface{}{"probability": p, "next_review_at": at, "updated_at": time.Now()})
+	if res.Error != nil {
+		return nil, fmt.Errorf("failed to raise risk probability: %w", res.Error)
+	}
+	risk.Probability = p
+	risk.NextReviewAt = &at
+	return &risk, nil
+}
+
 // UpdateSmartScore persists the multifactor smart score, its criticality band,
 // the frozen per-factor breakdown and the computation timestamp for a risk.
 // Targeted column update (like UpdateScore) — does not run the full Save path, so
@@ -539,6 +622,7 @@ func (r *GormRiskRepository) GetRiskScore(ctx context.Context, riskID uuid.UUID,
 
 // GetRisksByAssetID retrieves all risks linked to an asset, so the caller can
 // republish risk.updated for each of them when the asset's criticality changes.
+// Unscoped: only the score worker calls it, and every linked risk is rescored.
 //
 // NOTE: this used to select "assets.criticality as asset_criticality" (a
 // string enum column like "MEDIUM") directly into RiskForScoring's
@@ -662,12 +746,14 @@ func (r *GormRiskRepository) CreateAuditEntry(ctx context.Context, entry *domain
 func (r *GormRiskRepository) GetBySource(ctx context.Context, tenantID uuid.UUID, source string) ([]domain.Risk, error) {
 	var risks []domain.Risk
 	err := r.db.WithContext(ctx).
+		Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
 		Where("tenant_id = ? AND source = ?", tenantID, source).
 		Find(&risks).Error
 	return risks, err
 }
 
 // GetByCVE retrieves a risk by CVE ID.
+// Unscoped: automation uses it to deduplicate ingested CVEs against every risk.
 func (r *GormRiskRepository) GetByCVE(ctx context.Context, cveID string, tenantID uuid.UUID) (*domain.Risk, error) {
 	var risk domain.Risk
 	err := r.db.WithContext(ctx).
@@ -693,6 +779,7 @@ func (r *GormRiskRepository) BulkUpdate(ctx context.Context, tenantID uuid.UUID,
 	updatedCount := int64(0)
 	for _, update := range updates {
 		db := tx.Model(&domain.Risk{}).
+			Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
 			Where("id = ? AND tenant_id = ?", update.ID, tenantID)
 
 		if update.Status != nil {
@@ -738,6 +825,7 @@ func (r *GormRiskRepository) BulkCreate(ctx context.Context, risks []*domain.Ris
 // BulkDelete soft-deletes multiple risks atomically.
 func (r *GormRiskRepository) BulkDelete(ctx context.Context, ids []uuid.UUID, tenantID uuid.UUID) (int64, error) {
 	result := r.db.WithContext(ctx).
+		Scopes(AccessScoped(ctx, domain.AccessResourceRisk)).
 		Where("id IN ? AND tenant_id = ?", ids, tenantID).
 		Delete(&domain.Risk{})
 
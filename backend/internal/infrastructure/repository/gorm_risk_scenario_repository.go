@@ -0,0 +1,166 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormRiskScenarioRepository stores a tenant's own risk scenarios and writes
+// the draft risks instantiating a scenario produces. Every query is
+// tenant-scoped.
+type GormRiskScenarioRepository struct{ db *gorm.DB }
+
+// NewGormRiskScenarioRepository builds the store.
+func NewGormRiskScenarioRepository(db *gorm.DB) *GormRiskScenarioRepository {
+	return &GormRiskScenarioRepository{db: db}
+}
+
+var _ domain.RiskScenarioRepository = (*GormRiskScenarioRepository)(nil)
+
+func (r *GormRiskScenarioRepository) ListScenarios(ctx context.Context, tenantID uuid.UUID) ([]domain.RiskScenario, error) {
+	var rows []domain.RiskScenario
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("key ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list risk scenarios: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormRiskScenarioRepository) GetScenario(ctx context.Context, tenantID, id uuid.UUID) (*domain.RiskScenario, error) {
+	var s domain.RiskScenario
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&s).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get risk scenario: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *GormRiskScenarioRepository) SaveScenario(ctx context.Context, s *domain.RiskScenario) error {
+	return saveTenantRow(r.db.WithContext(ctx), s, s.ID, s.TenantID, "risk scenario")
+}
+
+// DeleteScenario removes the template only. Risks drafted from it keep their
+// source_scenario_id: they are the tenant's risks now, and the id still says
+// where their first figures came from.
+func (r *GormRiskScenarioRepository) DeleteScenario(ctx context.Context, tenantID, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&domain.RiskScenario{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete risk scenario: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("risk scenario", id)
+	}
+	return nil
+}
+
+func (r *GormRiskScenarioRepository) KeyTaken(ctx context.Context, tenantID uuid.UUID, key string, except uuid.UUID) (bool, error) {
+	var n int64
+	if err := r.db.WithContext(ctx).Model(&domain.RiskScenario{}).
+		Where("tenant_id = ? AND key = ? AND id <> ?", tenantID, key, except).
+		Count(&n).Error; err != nil {
+		return false, fmt.Errorf("failed to check scenario key: %w", err)
+	}
+	return n > 0, nil
+}
+
+// ListCatalogControls joins the tenant's controls to their framework's
+// catalog key. Controls of hand-made frameworks (no catalog key) cannot answer
+// a catalog reference and are left out, as are soft-deleted ones.
+func (r *GormRiskScenarioRepository) ListCatalogControls(ctx context.Context, tenantID uuid.UUID) ([]domain.ScenarioCatalogControl, error) {
+	type row struct {
+		ControlID     uuid.UUID
+		FrameworkID   uuid.UUID
+		CatalogKey    string
+		ReferenceCode string
+		Name          string
+		Status        domain.ControlStatus
+	}
+	var rows []row
+	if err := r.db.WithContext(ctx).
+		Table("compliance_controls AS c").
+		Select("c.id AS control_id, c.framework_id, f.catalog_key, c.reference_code, c.name, c.status").
+		Joins("JOIN compliance_frameworks AS f ON f.id = c.framework_id AND f.tenant_id = c.tenant_id").
+		Where("c.tenant_id = ? AND f.catalog_key <> '' AND c.deleted_at IS NULL AND f.deleted_at IS NULL", tenantID).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list catalog controls: %w", err)
+	}
+	out := make([]domain.ScenarioCatalogControl, 0, len(rows))
+	for _, x := range rows {
+		out = append(out, domain.ScenarioCatalogControl(x))
+	}
+	return out, nil
+}
+
+func (r *GormRiskScenarioRepository) ListAssets(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]domain.Asset, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var rows []domain.Asset
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id IN ?", tenantID, ids).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list assets: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormRiskScenarioRepository) ExistingScenarioRisks(ctx context.Context, tenantID, scenarioID uuid.UUID, assetIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	out := map[uuid.UUID]uuid.UUID{}
+	if len(assetIDs) == 0 {
+		return out, nil
+	}
+	var rows []struct {
+		ID      uuid.UUID
+		AssetID uuid.UUID
+	}
+	if err := r.db.WithContext(ctx).Model(&domain.Risk{}).
+		Select("id, asset_id").
+		Where("tenant_id = ? AND source_scenario_id = ? AND asset_id IN ?", tenantID, scenarioID, assetIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to look up scenario risks: %w", err)
+	}
+	for _, x := range rows {
+		out[x.AssetID] = x.ID
+	}
+	return out, nil
+}
+
+// CreateScenarioRisks writes the drafts, their risk_assets rows and their
+// control mappings together: a half-instantiated scenario — risks without
+// their controls — would look complete in the register and be wrong.
+func (r *GormRiskScenarioRepository) CreateScenarioRisks(ctx context.Context, risks []*domain.Risk, mappings []domain.RiskControlMapping) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, risk := range risks {
+			if err := tx.Omit("Assets").Create(risk).Error; err != nil {
+				return fmt.Errorf("failed to create scenario risk: %w", err)
+			}
+			if risk.AssetID == nil {
+				continue
+			}
+			if err := tx.Exec("INSERT INTO risk_assets (risk_id, asset_id) VALUES (?, ?)", risk.ID, *risk.AssetID).Error; err != nil {
+				return fmt.Errorf("failed to link scenario risk to its asset: %w", err)
+			}
+		}
+		if len(mappings) > 0 {
+			if err := tx.Create(&mappings).Error; err != nil {
+				return fmt.Errorf("failed to map scenario risk controls: %w", err)
+			}
+		}
+		return nil
+	})
+}
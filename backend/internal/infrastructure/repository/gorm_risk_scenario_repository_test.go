@@ -0,0 +1,129 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newScenarioTestDB migrates risk_scenarios from the model and hand-writes the
+// columns of the other tables the repository reads: their models carry
+// postgres-only defaults.
+func newScenarioTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.RiskScenario{}))
+	for _, ddl := range []string{
+		`CREATE TABLE compliance_frameworks (
+			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, name TEXT, catalog_key TEXT DEFAULT '',
+			created_at DATETIME, updated_at DATETIME, deleted_at DATETIME)`,
+		`CREATE TABLE compliance_controls (
+			id TEXT PRIMARY KEY, external_id TEXT, tenant_id TEXT NOT NULL, framework_id TEXT NOT NULL,
+			reference_code TEXT, name TEXT, status TEXT,
+			created_at DATETIME, updated_at DATETIME, deleted_at DATETIME)`,
+		`CREATE TABLE risks (
+			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, asset_id TEXT, source_scenario_id TEXT,
+			deleted_at DATETIME)`,
+	} {
+		require.NoError(t, db.Exec(ddl).Error)
+	}
+	return db
+}
+
+func scenarioFixture(tenant uuid.UUID, key string) *domain.RiskScenario {
+	return &domain.RiskScenario{
+		ID: uuid.New(), TenantID: tenant, Key: key, Version: 1, Title: "Payroll fraud",
+		Techniques:    domain.ScenarioTechniques{{ID: "T1078", Mitigations: []domain.ScenarioControlRef{{Catalog: "cis-v8", Ref: "CIS-5"}}}},
+		LEF:           domain.FAIRRange{Min: 0.1, Mode: 0.5, Max: 1},
+		LossMagnitude: domain.FAIRRange{Min: 1, Mode: 2, Max: 3},
+		Impact:        5,
+	}
+}
+
+// The isolation registry cites this test for /risk-scenarios/{id}.
+func TestRiskScenarioRepo_TenantScoped(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGormRiskScenarioRepository(newScenarioTestDB(t))
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	s := scenarioFixture(tenantA, "payroll-fraud")
+	require.NoError(t, repo.SaveScenario(ctx, s))
+	s.Version = 2
+	require.NoError(t, repo.SaveScenario(ctx, s))
+
+	got, err := repo.GetScenario(ctx, tenantA, s.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, 2, got.Version)
+	assert.Equal(t, "CIS-5", got.Techniques[0].Mitigations[0].Ref, "techniques round-trip through jsonb")
+
+	taken, err := repo.KeyTaken(ctx, tenantA, "payroll-fraud", uuid.Nil)
+	require.NoError(t, err)
+	assert.True(t, taken)
+	taken, err = repo.KeyTaken(ctx, tenantA, "payroll-fraud", s.ID)
+	require.NoError(t, err)
+	assert.False(t, taken, "a scenario does not collide with itself")
+	taken, err = repo.KeyTaken(ctx, tenantB, "payroll-fraud", uuid.Nil)
+	require.NoError(t, err)
+	assert.False(t, taken, "keys are per tenant")
+
+	none, err := repo.GetScenario(ctx, tenantB, s.ID)
+	require.NoError(t, err)
+	assert.Nil(t, none)
+	rows, err := repo.ListScenarios(ctx, tenantB)
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+	s.TenantID = tenantB
+	assert.Error(t, repo.SaveScenario(ctx, s), "an update cannot move a scenario across tenants")
+	assert.Error(t, repo.DeleteScenario(ctx, tenantB, s.ID))
+	require.NoError(t, repo.DeleteScenario(ctx, tenantA, s.ID))
+}
+
+func TestRiskScenarioRepo_CatalogControlsAndExistingRisks(t *testing.T) {
+	ctx := context.Background()
+	db := newScenarioTestDB(t)
+	repo := NewGormRiskScenarioRepository(db)
+	tenant, other := uuid.New(), uuid.New()
+
+	cis, handMade, foreign := uuid.New(), uuid.New(), uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO compliance_frameworks (id, tenant_id, name, catalog_key) VALUES
+		(?, ?, 'CIS v8', 'cis-v8'), (?, ?, 'Internal', ''), (?, ?, 'CIS v8', 'cis-v8')`,
+		cis, tenant, handMade, tenant, foreign, other).Error)
+	implemented := uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO compliance_controls (id, tenant_id, framework_id, reference_code, name, status, deleted_at) VALUES
+		(?, ?, ?, 'CIS-5', 'Account Management', 'implemented', NULL),
+		(?, ?, ?, 'CIS-6', 'Access Control Management', 'in_progress', CURRENT_TIMESTAMP),
+		(?, ?, ?, 'CIS-5', 'Our own', 'implemented', NULL),
+		(?, ?, ?, 'CIS-5', 'Account Management', 'implemented', NULL)`,
+		implemented, tenant, cis, uuid.New(), tenant, cis, uuid.New(), tenant, handMade, uuid.New(), other, foreign).Error)
+
+	controls, err := repo.ListCatalogControls(ctx, tenant)
+	require.NoError(t, err)
+	require.Len(t, controls, 1, "deleted controls, hand-made frameworks and other tenants are left out")
+	assert.Equal(t, implemented, controls[0].ControlID)
+	assert.Equal(t, domain.ScenarioControlRef{Catalog: "cis-v8", Ref: "CIS-5"}, controls[0].Ref())
+	assert.Equal(t, domain.ControlStatusImplemented, controls[0].Status)
+
+	scenarioID, asset, riskID := uuid.New(), uuid.New(), uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO risks (id, tenant_id, asset_id, source_scenario_id) VALUES (?, ?, ?, ?)`,
+		riskID, tenant, asset, scenarioID).Error)
+	existing, err := repo.ExistingScenarioRisks(ctx, tenant, scenarioID, []uuid.UUID{asset, uuid.New()})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]uuid.UUID{asset: riskID}, existing)
+	existing, err = repo.ExistingScenarioRisks(ctx, other, scenarioID, []uuid.UUID{asset})
+	require.NoError(t, err)
+	assert.Empty(t, existing)
+}
@@ -71,7 +71,7 @@ func newTaxonomyTestDB(t *testing.T) *gorm.DB {
 	`).Error)
 	require.NoError(t, db.Exec(`
 		CREATE TABLE compliance_controls (
-			id TEXT PRIMARY KEY, tenant_id TEXT NOT NULL, framework_id TEXT NOT NULL,
+			id TEXT PRIMARY KEY, external_id TEXT, tenant_id TEXT NOT NULL, framework_id TEXT NOT NULL,
 			reference_code TEXT, name TEXT,
 			created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
 		);
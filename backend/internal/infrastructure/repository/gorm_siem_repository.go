@@ -0,0 +1,215 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormSIEMRepository stores SIEM export destinations and reads the two logs
+// they ship: the audit chain and the auth log.
+type GormSIEMRepository struct{ db *gorm.DB }
+
+// NewGormSIEMRepository builds the store.
+func NewGormSIEMRepository(db *gorm.DB) *GormSIEMRepository {
+	return &GormSIEMRepository{db: db}
+}
+
+var _ domain.SIEMRepository = (*GormSIEMRepository)(nil)
+
+func (r *GormSIEMRepository) ListDestinations(ctx context.Context, tenantID uuid.UUID) ([]domain.SIEMDestination, error) {
+	var rows []domain.SIEMDestination
+	if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).
+		Order("name").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list SIEM destinations: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormSIEMRepository) GetDestination(ctx context.Context, tenantID, id uuid.UUID) (*domain.SIEMDestination, error) {
+	var d domain.SIEMDestination
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&d).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SIEM destination: %w", err)
+	}
+	return &d, nil
+}
+
+func (r *GormSIEMRepository) CreateDestination(ctx context.Context, d *domain.SIEMDestination) error {
+	if d.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant_id is required")
+	}
+	if err := r.db.WithContext(ctx).Create(d).Error; err != nil {
+		return fmt.Errorf("failed to create SIEM destination: %w", err)
+	}
+	return nil
+}
+
+func (r *GormSIEMRepository) UpdateDestination(ctx context.Context, d *domain.SIEMDestination) error {
+	// Not saveTenantRow: a full-row save would write back the cursors as they
+	// were when the administrator's request read them, over the worker's
+	// progress or a backfill's rewind.
+	res := r.db.WithContext(ctx).Model(&domain.SIEMDestination{}).
+		Where("tenant_id = ? AND id = ?", d.TenantID, d.ID).
+		Updates(map[string]any{
+			"name":            d.Name,
+			"transport":       d.Transport,
+			"format":          d.Format,
+			"endpoint":        d.Endpoint,
+			"sources":         d.Sources,
+			"enabled":         d.Enabled,
+			"ca_cert_pem":     d.CACertPEM,
+			"encrypted_token": d.EncryptedToken,
+			"next_attempt_at": d.NextAttemptAt,
+			"updated_at":      d.UpdatedAt,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to update SIEM destination: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("SIEM destination", d.ID)
+	}
+	return nil
+}
+
+func (r *GormSIEMRepository) DeleteDestination(ctx context.Context, tenantID, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&domain.SIEMDestination{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete SIEM destination: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("SIEM destination", id)
+	}
+	return nil
+}
+
+func (r *GormSIEMRepository) Rewind(ctx context.Context, tenantID, id uuid.UUID, auditCursor int64, authSince *time.Time, now time.Time) error {
+	res := r.db.WithContext(ctx).Model(&domain.SIEMDestination{}).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		Updates(map[string]any{
+			"audit_cursor":         auditCursor,
+			"auth_cursor_at":       authSince,
+			"auth_cursor_id":       nil,
+			"lease_token":          nil,
+			"lease_until":          nil,
+			"consecutive_failures": 0,
+			"next_attempt_at":      now,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to rewind SIEM destination: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("SIEM destination", id)
+	}
+	return nil
+}
+
+func (r *GormSIEMRepository) AuditHead(ctx context.Context, tenantID uuid.UUID) (int64, error) {
+	var head int64
+	if err := r.db.WithContext(ctx).Model(&domain.AuditEvent{}).Where("tenant_id = ?", tenantID).
+		Select("COALESCE(MAX(sequence), 0)").Scan(&head).Error; err != nil {
+		return 0, fmt.Errorf("failed to read audit head: %w", err)
+	}
+	return head, nil
+}
+
+func (r *GormSIEMRepository) AuditEventsAfter(ctx context.Context, tenantID uuid.UUID, after int64, limit int) ([]domain.AuditEvent, error) {
+	var rows []domain.AuditEvent
+	if err := r.db.WithContext(ctx).Where("tenant_id = ? AND sequence > ?", tenantID, after).
+		Order("sequence ASC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read audit events: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormSIEMRepository) AuthLogsAfter(ctx context.Context, tenantID uuid.UUID, at *time.Time, id *uuid.UUID, until time.Time, limit int) ([]domain.AuthAuditLog, error) {
+	q := r.db.WithContext(ctx).Where("tenant_id = ? AND created_at < ?", tenantID, until)
+	switch {
+	case at != nil && id != nil:
+		q = q.Where("created_at > ? OR (created_at = ? AND id > ?)", *at, *at, *id)
+	case at != nil:
+		// A backfill start: everything from that instant on.
+		q = q.Where("created_at >= ?", *at)
+	}
+	var rows []domain.AuthAuditLog
+	if err := q.Order("created_at ASC, id ASC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read auth logs: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormSIEMRepository) ClaimDestination(ctx context.Context, now time.Time, lease time.Duration) (*domain.SIEMDestination, error) {
+	// The report worker's claim shape: read a candidate, then take it with an
+	// update that only matches while nobody else holds it.
+	for attempt := 0; attempt < 5; attempt++ {
+		var candidate domain.SIEMDestination
+		err := r.db.WithContext(ctx).
+			Where("enabled = ? AND next_attempt_at <= ? AND (lease_until IS NULL OR lease_until <= ?)", true, now, now).
+			Order("next_attempt_at ASC").
+			First(&candidate).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		token := uuid.New()
+		until := now.Add(lease)
+		res := r.db.WithContext(ctx).Model(&domain.SIEMDestination{}).
+			Where("id = ? AND enabled = ? AND (lease_until IS NULL OR lease_until <= ?)", candidate.ID, true, now).
+			Updates(map[string]any{"lease_token": token, "lease_until": until})
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		if res.RowsAffected == 0 {
+			continue
+		}
+		// Re-read: the cursors may have moved between the two statements.
+		var claimed domain.SIEMDestination
+		if err := r.db.WithContext(ctx).Where("id = ?", candidate.ID).Take(&claimed).Error; err != nil {
+			return nil, err
+		}
+		return &claimed, nil
+	}
+	return nil, nil
+}
+
+func (r *GormSIEMRepository) Checkpoint(ctx context.Context, d *domain.SIEMDestination) (bool, error) {
+	if d.LeaseToken == nil {
+		return false, nil
+	}
+	// The lease fields are written from d: the worker clears them on its last
+	// checkpoint, which releases the claim.
+	res := r.db.WithContext(ctx).Model(&domain.SIEMDestination{}).
+		Where("id = ? AND lease_token = ?", d.ID, *d.LeaseToken).
+		Updates(map[string]any{
+			"audit_cursor":         d.AuditCursor,
+			"auth_cursor_at":       d.AuthCursorAt,
+			"auth_cursor_id":       d.AuthCursorID,
+			"last_shipped_at":      d.LastShippedAt,
+			"last_error":           d.LastError,
+			"last_error_at":        d.LastErrorAt,
+			"consecutive_failures": d.ConsecutiveFailures,
+			"next_attempt_at":      d.NextAttemptAt,
+			"lease_until":          d.LeaseUntil,
+		})
+	if res.Error != nil {
+		return false, fmt.Errorf("failed to checkpoint SIEM destination: %w", res.Error)
+	}
+	return res.RowsAffected == 1, nil
+}
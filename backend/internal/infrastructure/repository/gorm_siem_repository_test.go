@@ -0,0 +1,124 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/internal/testsupport/sqliteschema"
+)
+
+// The isolation registry cites this test for the /siem-destinations routes.
+func TestSIEMRepo_TenantScopedCursorsAndClaims(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.SIEMDestination{}, &domain.AuditEvent{}))
+	// auth_audit_logs defaults its id with gen_random_uuid(), which SQLite
+	// cannot parse.
+	require.NoError(t, db.Exec(`CREATE TABLE auth_audit_logs (id TEXT PRIMARY KEY)`).Error)
+	require.NoError(t, sqliteschema.Reconcile(db, "auth_audit_logs", &domain.AuthAuditLog{}))
+	repo := NewGormSIEMRepository(db)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	d := &domain.SIEMDestination{ID: uuid.New(), TenantID: tenantA, Name: "Sentinel", Transport: domain.SIEMTransportSyslog,
+		Format: domain.SIEMFormatCEF, Endpoint: "siem.example.com:6514", Sources: domain.StringList{"audit", "auth"},
+		Enabled: true, NextAttemptAt: now}
+	require.NoError(t, repo.CreateDestination(ctx, d))
+	got, err := repo.GetDestination(ctx, tenantB, d.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got, "another tenant cannot read the destination")
+	list, err := repo.ListDestinations(ctx, tenantB)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+	other := *d
+	other.TenantID = tenantB
+	assert.Error(t, repo.UpdateDestination(ctx, &other))
+	assert.Error(t, repo.DeleteDestination(ctx, tenantB, d.ID))
+
+	// The audit chain is read per tenant, strictly after the cursor.
+	for i, tenant := range []uuid.UUID{tenantA, tenantA, tenantB, tenantA} {
+		seq := int64(i + 1)
+		if tenant == tenantB {
+			seq = 1
+		}
+		require.NoError(t, db.Create(&domain.AuditEvent{ID: uuid.New(), TenantID: tenant, Action: domain.AuditActionCreate,
+			EntityType: "risk", EntityID: "r", Sequence: seq, CreatedAt: now}).Error)
+	}
+	head, err := repo.AuditHead(ctx, tenantA)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, head)
+	events, err := repo.AuditEventsAfter(ctx, tenantA, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.EqualValues(t, []int64{2, 4}, []int64{events[0].Sequence, events[1].Sequence})
+
+	// The auth log cursor is (created_at, id), bounded above by the settle
+	// horizon; entries without a tenant are never read.
+	early, late := now.Add(-2*time.Minute), now.Add(-time.Minute)
+	idLow, idHigh := uuid.MustParse("00000000-0000-0000-0000-000000000001"), uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	for _, l := range []domain.AuthAuditLog{
+		{ID: idHigh, TenantID: &tenantA, Action: "login", Success: true, CreatedAt: early},
+		{ID: idLow, TenantID: &tenantA, Action: "logout", Success: true, CreatedAt: early},
+		{ID: uuid.New(), TenantID: &tenantA, Action: "refresh", Success: true, CreatedAt: late},
+		{ID: uuid.New(), TenantID: &tenantA, Action: "login", Success: true, CreatedAt: now},
+		{ID: uuid.New(), TenantID: &tenantB, Action: "login", Success: true, CreatedAt: early},
+		{ID: uuid.New(), Action: "login", Success: false, CreatedAt: early},
+	} {
+		require.NoError(t, db.Create(&l).Error)
+	}
+	logs, err := repo.AuthLogsAfter(ctx, tenantA, nil, nil, now, 10)
+	require.NoError(t, err)
+	require.Len(t, logs, 3, "the entry at the horizon waits")
+	assert.Equal(t, []uuid.UUID{idLow, idHigh}, []uuid.UUID{logs[0].ID, logs[1].ID})
+	logs, err = repo.AuthLogsAfter(ctx, tenantA, &early, &idLow, now, 10)
+	require.NoError(t, err)
+	require.Len(t, logs, 2, "ties on created_at are broken by id")
+	assert.Equal(t, idHigh, logs[0].ID)
+
+	// One claim at a time; a checkpoint needs the current lease.
+	claimed, err := repo.ClaimDestination(ctx, now, 5*time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	require.NotNil(t, claimed.LeaseToken)
+	again, err := repo.ClaimDestination(ctx, now, 5*time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, again, "held until the lease runs out")
+
+	claimed.AuditCursor = 4
+	ok, err := repo.Checkpoint(ctx, claimed)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// An admin edit leaves the worker's progress alone.
+	d.Name = "Sentinel EU"
+	require.NoError(t, repo.UpdateDestination(ctx, d))
+	got, _ = repo.GetDestination(ctx, tenantA, d.ID)
+	assert.Equal(t, "Sentinel EU", got.Name)
+	assert.EqualValues(t, 4, got.AuditCursor)
+
+	// A rewind voids the lease, so the in-flight worker cannot write over it.
+	require.NoError(t, repo.Rewind(ctx, tenantA, d.ID, 1, &early, now))
+	claimed.AuditCursor = 5
+	ok, err = repo.Checkpoint(ctx, claimed)
+	require.NoError(t, err)
+	assert.False(t, ok, "a stale lease does not checkpoint")
+	got, _ = repo.GetDestination(ctx, tenantA, d.ID)
+	assert.EqualValues(t, 1, got.AuditCursor)
+	assert.Nil(t, got.AuthCursorID)
+	assert.Nil(t, got.LeaseUntil)
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormTheHiveRepository stores TheHive connections, case/task links and case
+// observables. Tenant-scoped on every query except the webhook-token lookup and
+// the worker's cross-tenant list of enabled connections.
+type GormTheHiveRepository struct{ db *gorm.DB }
+
+// NewGormTheHiveRepository builds the store.
+func NewGormTheHiveRepository(db *gorm.DB) *GormTheHiveRepository {
+	return &GormTheHiveRepository{db: db}
+}
+
+var _ domain.TheHiveRepository = (*GormTheHiveRepository)(nil)
+
+func (r *GormTheHiveRepository) GetConnection(ctx context.Context, tenantID uuid.UUID) (*domain.TheHiveConnection, error) {
+	var c domain.TheHiveConnection
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Take(&c).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thehive connection: %w", err)
+	}
+	return &c, nil
+}
+
+func (r *GormTheHiveRepository) GetConnectionByWebhookToken(ctx context.Context, token string) (*domain.TheHiveConnection, error) {
+	if token == "" {
+		return nil, nil
+	}
+	var c domain.TheHiveConnection
+	err := r.db.WithContext(ctx).Where("webhook_token = ?", token).Take(&c).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve thehive webhook token: %w", err)
+	}
+	return &c, nil
+}
+
+func (r *GormTheHiveRepository) ListEnabledConnections(ctx context.Context) ([]domain.TheHiveConnection, error) {
+	var rows []domain.TheHiveConnection
+	if err := r.db.WithContext(ctx).
+		Where("enabled = ?", true).
+		Order("last_sync_at ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list thehive connections: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormTheHiveRepository) SaveConnection(ctx context.Context, c *domain.TheHiveConnection) error {
+	return r.save(ctx, &domain.TheHiveConnection{}, c.ID, c.TenantID, c, "thehive connection")
+}
+
+func (r *GormTheHiveRepository) DeleteConnection(ctx context.Context, tenantID uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Delete(&domain.TheHiveConnection{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete thehive connection: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("thehive connection", tenantID)
+	}
+	return nil
+}
+
+func (r *GormTheHiveRepository) GetCaseLinkByIncident(ctx context.Context, tenantID uuid.UUID, incidentID uint) (*domain.TheHiveCaseLink, error) {
+	return r.caseLink(ctx, r.db.Where("tenant_id = ? AND incident_id = ?", tenantID, incidentID))
+}
+
+func (r *GormTheHiveRepository) GetCaseLinkByCase(ctx context.Context, tenantID uuid.UUID, caseID string) (*domain.TheHiveCaseLink, error) {
+	return r.caseLink(ctx, r.db.Where("tenant_id = ? AND case_id = ?", tenantID, caseID))
+}
+
+func (r *GormTheHiveRepository) caseLink(ctx context.Context, q *gorm.DB) (*domain.TheHiveCaseLink, error) {
+	var l domain.TheHiveCaseLink
+	err := q.WithContext(ctx).Take(&l).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thehive case link: %w", err)
+	}
+	return &l, nil
+}
+
+func (r *GormTheHiveRepository) SaveCaseLink(ctx context.Context, l *domain.TheHiveCaseLink) error {
+	return r.save(ctx, &domain.TheHiveCaseLink{}, l.ID, l.TenantID, l, "thehive case link")
+}
+
+func (r *GormTheHiveRepository) ListTaskLinks(ctx context.Context, tenantID, caseLinkID uuid.UUID) ([]domain.TheHiveTaskLink, error) {
+	var rows []domain.TheHiveTaskLink
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND case_link_id = ?", tenantID, caseLinkID).
+		Order("created_at ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list thehive task links: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormTheHiveRepository) SaveTaskLink(ctx context.Context, l *domain.TheHiveTaskLink) error {
+	return r.save(ctx, &domain.TheHiveTaskLink{}, l.ID, l.TenantID, l, "thehive task link")
+}
+
+func (r *GormTheHiveRepository) ListObservables(ctx context.Context, tenantID, caseLinkID uuid.UUID) ([]domain.TheHiveObservable, error) {
+	var rows []domain.TheHiveObservable
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND case_link_id = ?", tenantID, caseLinkID).
+		Order("created_at ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list thehive observables: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormTheHiveRepository) SaveObservable(ctx context.Context, o *domain.TheHiveObservable) error {
+	return r.save(ctx, &domain.TheHiveObservable{}, o.ID, o.TenantID, o, "thehive observable")
+}
+
+// save inserts or fully rewrites a row, scoping the update to its tenant so an
+// id from another tenant can never be overwritten.
+func (r *GormTheHiveRepository) save(ctx context.Context, model interface{}, id, tenantID uuid.UUID, row interface{}, what string) error {
+	if tenantID == uuid.Nil {
+		return fmt.Errorf("tenant_id is required")
+	}
+	var n int64
+	if err := r.db.WithContext(ctx).Model(model).Where("id = ?", id).Count(&n).Error; err != nil {
+		return fmt.Errorf("failed to save %s: %w", what, err)
+	}
+	if n == 0 {
+		if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+			return fmt.Errorf("failed to save %s: %w", what, err)
+		}
+		return nil
+	}
+	res := r.db.WithContext(ctx).Model(row).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		Select("*").Omit("created_at").
+		Updates(row)
+	if res.Error != nil {
+		return fmt.Errorf("failed to save %s: %w", what, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError(what, id)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTheHiveRepo(t *testing.T) *GormTheHiveRepository {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{DisableForeignKeyConstraintWhenMigrating: true})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&domain.TheHiveConnection{},
+		&domain.TheHiveCaseLink{},
+		&domain.TheHiveTaskLink{},
+		&domain.TheHiveObservable{},
+	))
+	return NewGormTheHiveRepository(db)
+}
+
+func TestTheHiveRepo_ConnectionWebhookAndDisable(t *testing.T) {
+	ctx := context.Background()
+	repo := setupTheHiveRepo(t)
+	tenant := uuid.New()
+
+	c := &domain.TheHiveConnection{
+		ID: uuid.New(), TenantID: tenant, Enabled: true, BaseURL: "https://hive.example",
+		EncryptedAPIKey: "ct", PushIncidents: true, PollMinutes: 5, WebhookToken: "tok",
+		LastSyncStatus: "never",
+	}
+	require.NoError(t, repo.SaveConnection(ctx, c))
+
+	got, err := repo.GetConnectionByWebhookToken(ctx, "tok")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, tenant, got.TenantID)
+
+	none, err := repo.GetConnectionByWebhookToken(ctx, "")
+	require.NoError(t, err)
+	assert.Nil(t, none, "an empty token must never match a row")
+
+	// Zero values are written on update: disabling and switching to
+	// webhook-only must stick.
+	c.Enabled, c.PushIncidents, c.PollMinutes = false, false, 0
+	require.NoError(t, repo.SaveConnection(ctx, c))
+	got, _ = repo.GetConnection(ctx, tenant)
+	assert.False(t, got.Enabled)
+	assert.False(t, got.PushIncidents)
+	assert.Equal(t, 0, got.PollMinutes)
+
+	enabled, err := repo.ListEnabledConnections(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, enabled)
+}
+
+func TestTheHiveRepo_LinksAreTenantScoped(t *testing.T) {
+	ctx := context.Background()
+	repo := setupTheHiveRepo(t)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	now := time.Now().UTC()
+
+	l := &domain.TheHiveCaseLink{
+		ID: uuid.New(), TenantID: tenantA, ConnectionID: uuid.New(),
+		IncidentID: 42, CaseID: "~4096", CaseNumber: 7, LastSyncedAt: now,
+	}
+	require.NoError(t, repo.SaveCaseLink(ctx, l))
+
+	byCase, err := repo.GetCaseLinkByCase(ctx, tenantA, "~4096")
+	require.NoError(t, err)
+	require.NotNil(t, byCase)
+	assert.Equal(t, uint(42), byCase.IncidentID)
+
+	other, err := repo.GetCaseLinkByIncident(ctx, tenantB, 42)
+	require.NoError(t, err)
+	assert.Nil(t, other)
+
+	hijack := *byCase
+	hijack.TenantID = tenantB
+	hijack.CaseID = "~evil"
+	assert.Error(t, repo.SaveCaseLink(ctx, &hijack))
+
+	assetID := uuid.New()
+	require.NoError(t, repo.SaveObservable(ctx, &domain.TheHiveObservable{
+		ID: uuid.New(), TenantID: tenantA, CaseLinkID: l.ID, ObservableID: "~o1",
+		DataType: "ip", Data: "10.0.0.5", AssetID: &assetID, Confidence: 0.9,
+	}))
+	obs, err := repo.ListObservables(ctx, tenantA, l.ID)
+	require.NoError(t, err)
+	require.Len(t, obs, 1)
+	assert.Equal(t, assetID, *obs[0].AssetID)
+	obs, _ = repo.ListObservables(ctx, tenantB, l.ID)
+	assert.Empty(t, obs)
+}
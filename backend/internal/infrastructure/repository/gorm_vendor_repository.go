@@ -0,0 +1,230 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormVendorRepository stores the vendor register, custom questionnaires and
+// assessments. Every query is tenant-scoped except GetAssessmentByTokenHash
+// (the link token is the scope) and ListDueForReassessment (the worker's
+// cross-tenant sweep).
+type GormVendorRepository struct{ db *gorm.DB }
+
+// NewGormVendorRepository builds the store.
+func NewGormVendorRepository(db *gorm.DB) *GormVendorRepository {
+	return &GormVendorRepository{db: db}
+}
+
+var _ domain.VendorRepository = (*GormVendorRepository)(nil)
+
+// ---- vendors ---------------------------------------------------------------
+
+func (r *GormVendorRepository) ListVendors(ctx context.Context, tenantID uuid.UUID, f domain.VendorFilter) ([]domain.Vendor, error) {
+	q := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if f.Tier != 0 {
+		q = q.Where("tier = ?", f.Tier)
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+	if s := strings.TrimSpace(f.Query); s != "" {
+		q = q.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(s)+"%")
+	}
+	var rows []domain.Vendor
+	if err := q.Order("tier ASC, name ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list vendors: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormVendorRepository) GetVendor(ctx context.Context, tenantID, id uuid.UUID) (*domain.Vendor, error) {
+	var v domain.Vendor
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&v).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vendor: %w", err)
+	}
+	return &v, nil
+}
+
+func (r *GormVendorRepository) SaveVendor(ctx context.Context, v *domain.Vendor) error {
+	return saveTenantRow(r.db.WithContext(ctx), v, v.ID, v.TenantID, "vendor")
+}
+
+// DeleteVendor removes the vendor and its assessments in one transaction.
+// Evidence the vendor submitted stays in the library: it was proof of
+// something at the time, and the evidence trail is not the register's to prune.
+func (r *GormVendorRepository) DeleteVendor(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&domain.Vendor{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to delete vendor: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return domain.NewNotFoundError("vendor", id)
+		}
+		if err := tx.Where("tenant_id = ? AND vendor_id = ?", tenantID, id).Delete(&domain.VendorAssessment{}).Error; err != nil {
+			return fmt.Errorf("failed to delete vendor assessments: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListDueForReassessment returns, across tenants, the vendors in a
+// relationship whose reassessment date has passed. A vendor never assessed has
+// no date and is not swept: the first assessment is a procurement decision,
+// not a schedule.
+func (r *GormVendorRepository) ListDueForReassessment(ctx context.Context, now time.Time) ([]domain.Vendor, error) {
+	var rows []domain.Vendor
+	if err := r.db.WithContext(ctx).
+		Where("status <> ?", domain.VendorStatusOffboarded).
+		Where("next_assessment_at IS NOT NULL AND next_assessment_at <= ?", now).
+		Order("tenant_id, tier").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list vendors due for reassessment: %w", err)
+	}
+	return rows, nil
+}
+
+// ---- questionnaire templates ----------------------------------------------
+
+func (r *GormVendorRepository) ListTemplates(ctx context.Context, tenantID uuid.UUID) ([]domain.VendorQuestionnaireTemplate, error) {
+	var rows []domain.VendorQuestionnaireTemplate
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("name ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list questionnaires: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormVendorRepository) GetTemplate(ctx context.Context, tenantID, id uuid.UUID) (*domain.VendorQuestionnaireTemplate, error) {
+	var t domain.VendorQuestionnaireTemplate
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&t).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questionnaire: %w", err)
+	}
+	return &t, nil
+}
+
+func (r *GormVendorRepository) SaveTemplate(ctx context.Context, t *domain.VendorQuestionnaireTemplate) error {
+	return saveTenantRow(r.db.WithContext(ctx), t, t.ID, t.TenantID, "questionnaire")
+}
+
+func (r *GormVendorRepository) DeleteTemplate(ctx context.Context, tenantID, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&domain.VendorQuestionnaireTemplate{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete questionnaire: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError("questionnaire", id)
+	}
+	return nil
+}
+
+// ---- assessments -------------------------------------------------------------
+
+func (r *GormVendorRepository) ListAssessments(ctx context.Context, tenantID, vendorID uuid.UUID) ([]domain.VendorAssessment, error) {
+	var rows []domain.VendorAssessment
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND vendor_id = ?", tenantID, vendorID).
+		Order("created_at DESC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list vendor assessments: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormVendorRepository) GetAssessment(ctx context.Context, tenantID, id uuid.UUID) (*domain.VendorAssessment, error) {
+	var a domain.VendorAssessment
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&a).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vendor assessment: %w", err)
+	}
+	return &a, nil
+}
+
+// GetAssessmentByTokenHash resolves a link. Not tenant-scoped by design: the
+// token is the only thing the vendor holds, and the assessment it resolves to
+// carries the tenant from then on.
+func (r *GormVendorRepository) GetAssessmentByTokenHash(ctx context.Context, hash string) (*domain.VendorAssessment, error) {
+	if hash == "" {
+		return nil, nil
+	}
+	var a domain.VendorAssessment
+	err := r.db.WithContext(ctx).Where("token_hash = ?", hash).Take(&a).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve vendor assessment link: %w", err)
+	}
+	return &a, nil
+}
+
+func (r *GormVendorRepository) SaveAssessment(ctx context.Context, a *domain.VendorAssessment) error {
+	return saveTenantRow(r.db.WithContext(ctx), a, a.ID, a.TenantID, "vendor assessment")
+}
+
+func (r *GormVendorRepository) CompleteAssessment(ctx context.Context, a *domain.VendorAssessment, v *domain.Vendor) error {
+	if a.TenantID != v.TenantID || a.VendorID != v.ID {
+		return fmt.Errorf("assessment %s does not belong to vendor %s", a.ID, v.ID)
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := saveTenantRow(tx, a, a.ID, a.TenantID, "vendor assessment"); err != nil {
+			return err
+		}
+		return saveTenantRow(tx, v, v.ID, v.TenantID, "vendor")
+	})
+}
+
+// saveTenantRow inserts or updates a row by id. The update is tenant-scoped
+// and writes every column, so clearing a field sticks.
+func saveTenantRow(db *gorm.DB, row interface{}, id, tenantID uuid.UUID, resource string) error {
+	if tenantID == uuid.Nil {
+		return fmt.Errorf("tenant_id is required")
+	}
+	var n int64
+	if err := db.Model(row).Where("id = ?", id).Count(&n).Error; err != nil {
+		return fmt.Errorf("failed to save %s: %w", resource, err)
+	}
+	if n == 0 {
+		if err := db.Create(row).Error; err != nil {
+			return fmt.Errorf("failed to save %s: %w", resource, err)
+		}
+		return nil
+	}
+	res := db.Model(row).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		Select("*").Omit("created_at").
+		Updates(row)
+	if res.Error != nil {
+		return fmt.Errorf("failed to save %s: %w", resource, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.NewNotFoundError(resource, id)
+	}
+	return nil
+}
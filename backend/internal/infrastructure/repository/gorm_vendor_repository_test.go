@@ -0,0 +1,142 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newVendorTestRepo(t *testing.T) *GormVendorRepository {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.Vendor{}, &domain.VendorQuestionnaireTemplate{}, &domain.VendorAssessment{}))
+	return NewGormVendorRepository(db)
+}
+
+func TestVendorRepo_TenantScoped(t *testing.T) {
+	ctx := context.Background()
+	repo := newVendorTestRepo(t)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	v := &domain.Vendor{ID: uuid.New(), TenantID: tenantA, Name: "Acme Payroll", DataAccess: domain.VendorDataPersonal,
+		Criticality: domain.VendorCriticalityHigh}
+	require.NoError(t, v.Validate())
+	require.NoError(t, repo.SaveVendor(ctx, v))
+	past, later := now.AddDate(0, 0, -1), now.AddDate(1, 0, 0)
+	v.NextAssessmentAt = &past
+	require.NoError(t, repo.SaveVendor(ctx, v))
+	assessed := &domain.Vendor{ID: uuid.New(), TenantID: tenantA, Name: "Box Print", DataAccess: domain.VendorDataNone,
+		Criticality: domain.VendorCriticalityLow, NextAssessmentAt: &later}
+	require.NoError(t, assessed.Validate())
+	require.NoError(t, repo.SaveVendor(ctx, assessed))
+	gone := &domain.Vendor{ID: uuid.New(), TenantID: tenantB, Name: "Old CDN", DataAccess: domain.VendorDataNone,
+		Criticality: domain.VendorCriticalityLow, Status: domain.VendorStatusOffboarded}
+	require.NoError(t, gone.Validate())
+	require.NoError(t, repo.SaveVendor(ctx, gone))
+
+	rows, err := repo.ListVendors(ctx, tenantA, domain.VendorFilter{})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "Acme Payroll", rows[0].Name, "tier 1 first")
+	rows, err = repo.ListVendors(ctx, tenantA, domain.VendorFilter{Query: "box"})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+
+	due, err := repo.ListDueForReassessment(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, due, 1, "past due is swept; scheduled later and offboarded are not")
+	assert.Equal(t, v.ID, due[0].ID)
+
+	none, err := repo.GetVendor(ctx, tenantB, v.ID)
+	require.NoError(t, err)
+	assert.Nil(t, none)
+	v.TenantID = tenantB
+	assert.Error(t, repo.SaveVendor(ctx, v), "an update cannot move a vendor across tenants")
+	v.TenantID = tenantA
+	assert.Error(t, repo.DeleteVendor(ctx, tenantB, v.ID))
+}
+
+func TestVendorRepo_AssessmentLifecycle(t *testing.T) {
+	ctx := context.Background()
+	repo := newVendorTestRepo(t)
+	tenant := uuid.New()
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	v := &domain.Vendor{ID: uuid.New(), TenantID: tenant, Name: "Acme", DataAccess: domain.VendorDataInternal,
+		Criticality: domain.VendorCriticalityMedium}
+	require.NoError(t, v.Validate())
+	require.NoError(t, repo.SaveVendor(ctx, v))
+
+	tpl := domain.BuiltInVendorTemplates()[0]
+	_, hash, err := domain.NewVendorAssessmentToken()
+	require.NoError(t, err)
+	a := &domain.VendorAssessment{ID: uuid.New(), TenantID: tenant, VendorID: v.ID, TemplateID: tpl.ID,
+		TemplateName: tpl.Name, Questions: tpl.Questions, PassScore: tpl.PassScore, TokenHash: hash,
+		ExpiresAt: now.AddDate(0, 0, 30), Status: domain.VendorAssessmentSent}
+	require.NoError(t, repo.SaveAssessment(ctx, a))
+
+	got, err := repo.GetAssessmentByTokenHash(ctx, hash)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Len(t, got.Questions, len(tpl.Questions), "questions are snapshotted")
+	missing, err := repo.GetAssessmentByTokenHash(ctx, "")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	score := 82.5
+	got.Answers = domain.VendorAnswers{{QuestionID: "gov.owner", Value: "yes"}}
+	got.Score, got.Status, got.SubmittedAt = &score, domain.VendorAssessmentSubmitted, &now
+	next := v.Tier.NextAssessmentAfter(now)
+	v.LastScore, v.LastAssessedAt, v.NextAssessmentAt = &score, &now, &next
+	require.NoError(t, repo.CompleteAssessment(ctx, got, v))
+
+	stored, err := repo.GetAssessment(ctx, tenant, a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.VendorAssessmentSubmitted, stored.Status)
+	require.Len(t, stored.Answers, 1)
+	fresh, _ := repo.GetVendor(ctx, tenant, v.ID)
+	require.NotNil(t, fresh.LastScore)
+	assert.Equal(t, 82.5, *fresh.LastScore)
+
+	other := &domain.Vendor{ID: uuid.New(), TenantID: tenant}
+	assert.Error(t, repo.CompleteAssessment(ctx, got, other), "an assessment completes only its own vendor")
+
+	require.NoError(t, repo.DeleteVendor(ctx, tenant, v.ID))
+	list, err := repo.ListAssessments(ctx, tenant, v.ID)
+	require.NoError(t, err)
+	assert.Empty(t, list, "assessments go with the vendor")
+}
+
+func TestVendorRepo_Templates(t *testing.T) {
+	ctx := context.Background()
+	repo := newVendorTestRepo(t)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	tpl := &domain.VendorQuestionnaireTemplate{ID: uuid.New(), TenantID: tenantA, Name: "Marketing SaaS",
+		Questions: domain.VendorQuestions{{ID: "q1", Text: "SSO?", Type: domain.VendorQuestionYesNo, Weight: 1}}}
+	require.NoError(t, tpl.Validate())
+	require.NoError(t, repo.SaveTemplate(ctx, tpl))
+
+	rows, err := repo.ListTemplates(ctx, tenantA)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, domain.VendorTemplateCustom, rows[0].Kind)
+	rows, err = repo.ListTemplates(ctx, tenantB)
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+	assert.Error(t, repo.DeleteTemplate(ctx, tenantB, tpl.ID))
+	require.NoError(t, repo.DeleteTemplate(ctx, tenantA, tpl.ID))
+}
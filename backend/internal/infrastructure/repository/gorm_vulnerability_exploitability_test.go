@@ -0,0 +1,64 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestExploitabilityByAsset_StrongestOpenSignalTenantScoped(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	// Hand-written DDL: the model's gen_random_uuid() default is Postgres-only.
+	// Only the columns the aggregate reads, plus what Create writes.
+	require.NoError(t, db.Exec(`
+		CREATE TABLE vulnerabilities (
+			id TEXT PRIMARY KEY,
+			tenant_id TEXT NOT NULL,
+			title TEXT NOT NULL,
+			asset_id TEXT,
+			kev NUMERIC,
+			epss NUMERIC,
+			priority_tier TEXT,
+			status TEXT DEFAULT 'open',
+			created_at DATETIME,
+			updated_at DATETIME,
+			deleted_at DATETIME
+		);
+	`).Error)
+	repo := NewGormVulnerabilityRepository(db)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	web, db1 := uuid.New(), uuid.New()
+
+	vuln := func(tenant, asset uuid.UUID, kev bool, epss float64, tier string, status domain.VulnStatus) {
+		a := asset
+		require.NoError(t, db.Select("id", "tenant_id", "title", "asset_id", "kev", "epss", "priority_tier", "status", "created_at", "updated_at").
+			Create(&domain.Vulnerability{
+				ID: uuid.New(), TenantID: tenant, Title: "v", AssetID: &a,
+				KEV: kev, EPSS: epss, PriorityTier: tier, Status: status,
+			}).Error)
+	}
+	vuln(tenantA, web, false, 0.3, "P3", domain.VulnStatusOpen)
+	vuln(tenantA, web, false, 0.05, "P2", domain.VulnStatusTriaged)
+	vuln(tenantA, web, true, 0.9, "P1", domain.VulnStatusRemediated) // resolved: ignored
+	vuln(tenantA, db1, false, 0, "", domain.VulnStatusOpen)
+	vuln(tenantB, web, true, 0.97, "P1", domain.VulnStatusOpen) // other tenant
+
+	got, err := repo.ExploitabilityByAsset(ctx, tenantA)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, domain.AssetExploitability{OpenVulns: 2, MaxEPSS: 0.3, BestTier: "P2"}, got[web])
+	assert.Equal(t, domain.AssetExploitability{OpenVulns: 1}, got[db1])
+}
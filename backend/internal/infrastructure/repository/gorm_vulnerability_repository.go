@@ -144,6 +144,9 @@ func (r *GormVulnerabilityRepository) List(ctx context.Context, tenantID uuid.UU
 	if q.MinPriority != nil {
 		tx = tx.Where("priority_score >= ?", *q.MinPriority)
 	}
+	if q.FirstSeenAfter != nil {
+		tx = tx.Where("first_seen >= ?", *q.FirstSeenAfter)
+	}
 	if q.UnassignedOnly {
 		// Two distinct situations, both needing a human: nothing matched, or too
 		// many things matched equally well. They are OR-ed here because the
@@ -231,6 +234,45 @@ func (r *GormVulnerabilityRepository) CountOpenByAsset(ctx context.Context, tena
 	return out, nil
 }
 
+// ExploitabilityByAsset summarises, per asset, the strongest exploitation
+// signal among its unresolved vulnerabilities — the hop costs of the attack-path
+// analysis. Grouped in SQL like CountOpenByAsset: the engine needs one row per
+// asset, not every finding. MIN over the tier works because "P1" < "P4"
+// lexically; NULLIF keeps untiered rows from winning with "".
+func (r *GormVulnerabilityRepository) ExploitabilityByAsset(ctx context.Context, tenantID uuid.UUID) (map[uuid.UUID]domain.AssetExploitability, error) {
+	type row struct {
+		AssetID  uuid.UUID
+		N        int
+		KEV      int
+		MaxEPSS  float64
+		BestTier *string
+	}
+	var rows []row
+	err := r.db.WithContext(ctx).Model(&domain.Vulnerability{}).
+		Select("asset_id, COUNT(*) AS n, MAX(CASE WHEN kev THEN 1 ELSE 0 END) AS kev, "+
+			"COALESCE(MAX(epss), 0) AS max_epss, MIN(NULLIF(priority_tier, '')) AS best_tier").
+		Where("tenant_id = ? AND asset_id IS NOT NULL AND status NOT IN ?",
+			tenantID, []domain.VulnStatus{
+				domain.VulnStatusRemediated,
+				domain.VulnStatusAccepted,
+				domain.VulnStatusFalsePositive,
+			}).
+		Group("asset_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[uuid.UUID]domain.AssetExploitability, len(rows))
+	for _, rw := range rows {
+		e := domain.AssetExploitability{OpenVulns: rw.N, KEV: rw.KEV > 0, MaxEPSS: rw.MaxEPSS}
+		if rw.BestTier != nil {
+			e.BestTier = *rw.BestTier
+		}
+		out[rw.AssetID] = e
+	}
+	return out, nil
+}
+
 func (r *GormVulnerabilityRepository) Stats(ctx context.Context, tenantID uuid.UUID) (*domain.VulnStats, error) {
 	stats := &domain.VulnStats{
 		BySeverity: map[string]int64{},
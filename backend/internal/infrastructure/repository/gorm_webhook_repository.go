@@ -0,0 +1,222 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// GormWebhookRepository stores outbound webhook subscriptions and their
+// delivery log. Administrative queries are tenant-scoped; the delivery
+// worker's are not, and act on rows that carry their own tenant.
+type GormWebhookRepository struct{ db *gorm.DB }
+
+// NewGormWebhookRepository builds the store.
+func NewGormWebhookRepository(db *gorm.DB) *GormWebhookRepository {
+	return &GormWebhookRepository{db: db}
+}
+
+var _ domain.WebhookRepository = (*GormWebhookRepository)(nil)
+
+func (r *GormWebhookRepository) ListSubscriptions(ctx context.Context, tenantID uuid.UUID) ([]domain.WebhookSubscription, error) {
+	var rows []domain.WebhookSubscription
+	if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).
+		Order("name").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormWebhookRepository) GetSubscription(ctx context.Context, tenantID, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	var s domain.WebhookSubscription
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&s).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *GormWebhookRepository) EnabledSubscriptions(ctx context.Context, tenantID uuid.UUID) ([]domain.WebhookSubscription, error) {
+	var rows []domain.WebhookSubscription
+	// event_types is filtered by the caller: a JSON containment test is
+	// spelled differently on Postgres and sqlite, and a tenant has a handful
+	// of subscriptions at most.
+	if err := r.db.WithContext(ctx).Where("tenant_id = ? AND enabled = ?", tenantID, true).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormWebhookRepository) SaveSubscription(ctx context.Context, s *domain.WebhookSubscription) error {
+	return saveTenantRow(r.db.WithContext(ctx), s, s.ID, s.TenantID, "webhook")
+}
+
+func (r *GormWebhookRepository) DeleteSubscription(ctx context.Context, tenantID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tenant_id = ? AND subscription_id = ?", tenantID, id).Delete(&domain.WebhookDelivery{}).Error; err != nil {
+			return fmt.Errorf("failed to delete webhook: %w", err)
+		}
+		res := tx.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&domain.WebhookSubscription{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to delete webhook: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return domain.NewNotFoundError("webhook", id)
+		}
+		return nil
+	})
+}
+
+func (r *GormWebhookRepository) EnqueueDeliveries(ctx context.Context, ds []domain.WebhookDelivery) error {
+	if len(ds) == 0 {
+		return nil
+	}
+	// DO NOTHING against ux_webhook_deliveries_event: every replica bridging
+	// the same Redis message publishes the same event id, and only the first
+	// insert may queue it.
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&ds).Error; err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+func (r *GormWebhookRepository) GetDelivery(ctx context.Context, tenantID, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).Take(&d).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return &d, nil
+}
+
+func (r *GormWebhookRepository) ListDeliveries(ctx context.Context, tenantID, subscriptionID uuid.UUID, limit int) ([]domain.WebhookDelivery, error) {
+	var rows []domain.WebhookDelivery
+	if err := r.db.WithContext(ctx).Omit("payload").
+		Where("tenant_id = ? AND subscription_id = ?", tenantID, subscriptionID).
+		Order("created_at DESC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *GormWebhookRepository) ClaimDueDelivery(ctx context.Context, now time.Time, lease time.Duration) (*domain.WebhookDelivery, error) {
+	// Same claim as GormReportRepository.ClaimQueued: pick a candidate, then
+	// take it with an update conditioned on the attempt count it was read
+	// with. Two workers can read the same row; only one update matches. A
+	// delivering row whose lease has run out is a candidate too — its worker
+	// died mid-attempt — and the attempt count is what keeps the original
+	// worker, should it come back, from writing over the new claim.
+	for attempt := 0; attempt < 5; attempt++ {
+		var candidate domain.WebhookDelivery
+		err := r.db.WithContext(ctx).Omit("payload").
+			Where("status IN ? AND next_attempt_at <= ?",
+				[]domain.WebhookDeliveryStatus{domain.WebhookDeliveryPending, domain.WebhookDeliveryDelivering}, now).
+			Order("next_attempt_at ASC").
+			First(&candidate).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		res := r.db.WithContext(ctx).
+			Model(&domain.WebhookDelivery{}).
+			Where("id = ? AND attempts = ? AND status IN ?", candidate.ID, candidate.Attempts,
+				[]domain.WebhookDeliveryStatus{domain.WebhookDeliveryPending, domain.WebhookDeliveryDelivering}).
+			Updates(map[string]any{
+				"status":          domain.WebhookDeliveryDelivering,
+				"attempts":        candidate.Attempts + 1,
+				"next_attempt_at": now.Add(lease),
+			})
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		if res.RowsAffected == 0 {
+			continue
+		}
+
+		var full domain.WebhookDelivery
+		if err := r.db.WithContext(ctx).Where("id = ?", candidate.ID).Take(&full).Error; err != nil {
+			return nil, err
+		}
+		return &full, nil
+	}
+	return nil, nil
+}
+
+func (r *GormWebhookRepository) CompleteAttempt(ctx context.Context, d *domain.WebhookDelivery) (bool, error) {
+	res := r.db.WithContext(ctx).Model(&domain.WebhookDelivery{}).
+		Where("id = ? AND attempts = ? AND status = ?", d.ID, d.Attempts, domain.WebhookDeliveryDelivering).
+		Updates(map[string]any{
+			"status":           d.Status,
+			"next_attempt_at":  d.NextAttemptAt,
+			"last_status_code": d.LastStatusCode,
+			"last_error":       d.LastError,
+			"duration_ms":      d.DurationMS,
+			"delivered_at":     d.DeliveredAt,
+		})
+	if res.Error != nil {
+		return false, fmt.Errorf("failed to record webhook attempt: %w", res.Error)
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *GormWebhookRepository) GetSubscriptionForDelivery(ctx context.Context, d *domain.WebhookDelivery) (*domain.WebhookSubscription, error) {
+	return r.GetSubscription(ctx, d.TenantID, d.SubscriptionID)
+}
+
+func (r *GormWebhookRepository) RecordAttempt(ctx context.Context, subscriptionID uuid.UUID, succeeded bool, at time.Time) (*domain.WebhookSubscription, error) {
+	db := r.db.WithContext(ctx)
+	q := db.Model(&domain.WebhookSubscription{}).Where("id = ?", subscriptionID)
+	var err error
+	if succeeded {
+		err = q.Updates(map[string]any{
+			"consecutive_failures": 0,
+			"failing_since":        nil,
+			"last_delivery_at":     at,
+		}).Error
+	} else {
+		// Incremented in SQL: concurrent workers failing against the same
+		// endpoint must each count.
+		err = q.Updates(map[string]any{
+			"consecutive_failures": gorm.Expr("consecutive_failures + 1"),
+			"last_delivery_at":     at,
+		}).Error
+		if err == nil {
+			err = db.Model(&domain.WebhookSubscription{}).
+				Where("id = ? AND failing_since IS NULL", subscriptionID).
+				Update("failing_since", at).Error
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to record webhook attempt: %w", err)
+	}
+	var s domain.WebhookSubscription
+	if err := db.Where("id = ?", subscriptionID).Take(&s).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to record webhook attempt: %w", err)
+	}
+	return &s, nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// The isolation registry cites this test for the /webhooks and
+// /webhook-deliveries routes.
+func TestWebhookRepo_TenantScopedQueueAndClaims(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.WebhookSubscription{}, &domain.WebhookDelivery{}))
+	repo := NewGormWebhookRepository(db)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	sub := &domain.WebhookSubscription{ID: uuid.New(), TenantID: tenantA, Name: "SIEM", URL: "https://siem.example.com/in",
+		EventTypes: domain.StringList{"incident.declared"}, Enabled: true, EncryptedSecrets: "x"}
+	require.NoError(t, repo.SaveSubscription(ctx, sub))
+	got, err := repo.GetSubscription(ctx, tenantB, sub.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got, "another tenant cannot read the subscription")
+	list, err := repo.EnabledSubscriptions(ctx, tenantB)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+	assert.Error(t, repo.DeleteSubscription(ctx, tenantB, sub.ID))
+
+	// One delivery per (subscription, event); a redelivery is a new row.
+	eventID := uuid.New()
+	d := domain.WebhookDelivery{ID: uuid.New(), TenantID: tenantA, SubscriptionID: sub.ID, EventID: eventID,
+		EventType: domain.WebhookIncidentDeclared, Payload: datatypes.JSON(`{"id":"e"}`),
+		Status: domain.WebhookDeliveryPending, NextAttemptAt: now, CreatedAt: now}
+	require.NoError(t, repo.EnqueueDeliveries(ctx, []domain.WebhookDelivery{d}))
+	dup := d
+	dup.ID = uuid.New()
+	require.NoError(t, repo.EnqueueDeliveries(ctx, []domain.WebhookDelivery{dup}), "a replica's duplicate is a no-op")
+	again := dup
+	again.ID, again.RedeliveryOf = uuid.New(), &d.ID
+	again.NextAttemptAt = now.Add(time.Hour)
+	require.NoError(t, repo.EnqueueDeliveries(ctx, []domain.WebhookDelivery{again}))
+	items, err := repo.ListDeliveries(ctx, tenantA, sub.ID, 100)
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+	items, err = repo.ListDeliveries(ctx, tenantB, sub.ID, 100)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+	other, err := repo.GetDelivery(ctx, tenantB, d.ID)
+	require.NoError(t, err)
+	assert.Nil(t, other, "another tenant cannot read the delivery")
+
+	// A claim leases the row: a second worker finds nothing due.
+	claimed, err := repo.ClaimDueDelivery(ctx, now, time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	assert.Equal(t, d.ID, claimed.ID)
+	assert.Equal(t, 1, claimed.Attempts)
+	assert.Equal(t, domain.WebhookDeliveryDelivering, claimed.Status)
+	assert.JSONEq(t, `{"id":"e"}`, string(claimed.Payload))
+	none, err := repo.ClaimDueDelivery(ctx, now, time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, none)
+
+	// The lease lapses (the worker died): another worker takes it over, and
+	// the first worker's late result no longer applies.
+	retaken, err := repo.ClaimDueDelivery(ctx, now.Add(2*time.Minute), time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, retaken)
+	assert.Equal(t, 2, retaken.Attempts)
+	claimed.Status = domain.WebhookDeliverySucceeded
+	ok, err := repo.CompleteAttempt(ctx, claimed)
+	require.NoError(t, err)
+	assert.False(t, ok, "a stale attempt cannot complete the delivery")
+	retaken.Status = domain.WebhookDeliverySucceeded
+	ok, err = repo.CompleteAttempt(ctx, retaken)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// The failure streak starts once and resets on success.
+	s, err := repo.RecordAttempt(ctx, sub.ID, false, now)
+	require.NoError(t, err)
+	s, err = repo.RecordAttempt(ctx, sub.ID, false, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.ConsecutiveFailures)
+	require.NotNil(t, s.FailingSince)
+	assert.True(t, s.FailingSince.Equal(now))
+	s, err = repo.RecordAttempt(ctx, sub.ID, true, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, s.ConsecutiveFailures)
+	assert.Nil(t, s.FailingSince)
+
+	require.NoError(t, repo.DeleteSubscription(ctx, tenantA, sub.ID))
+	items, err = repo.ListDeliveries(ctx, tenantA, sub.ID, 100)
+	require.NoError(t, err)
+	assert.Empty(t, items, "deleting a subscription removes its log")
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package vendorrisk wires vendor assessments to the Risk Register: a
+// submission under its questionnaire's pass mark is proposed as a DRAFT vendor
+// risk, through the same review flow as internal/infrastructure/vulnrisk.
+package vendorrisk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// RiskCreator implements vendor.RiskProposer over GORM.
+type RiskCreator struct {
+	db *gorm.DB
+}
+
+func NewRiskCreator(db *gorm.DB) *RiskCreator {
+	return &RiskCreator{db: db}
+}
+
+// ProposeFromVendorAssessment creates (once) a risk for the vendor.
+// Idempotent by (tenant, vendor): a vendor failing again while its risk is in
+// the register — draft or live — returns that risk, and the reviewer is told
+// again rather than handed a duplicate.
+func (c *RiskCreator) ProposeFromVendorAssessment(ctx context.Context, v *domain.Vendor, a *domain.VendorAssessment, reason string) (uuid.UUID, error) {
+	var existing domain.Risk
+	err := c.db.WithContext(ctx).
+		Where("tenant_id = ? AND source = ? AND source_vendor_id = ? AND deleted_at IS NULL",
+			v.TenantID, domain.SourceVendor, v.ID).
+		First(&existing).Error
+	if err == nil {
+		return existing.ID, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return uuid.Nil, fmt.Errorf("failed to check existing vendor risk: %w", err)
+	}
+
+	score := 0.0
+	if a.Score != nil {
+		score = *a.Score
+	}
+	prob, impact := assessmentToProbabilityImpact(v, score)
+	level, crit := tierToLevels(v.Tier)
+	now := time.Now()
+	title := fmt.Sprintf("[Vendor] %s — security assessment below the pass mark", v.Name)
+	desc := fmt.Sprintf("%s\n\nThe vendor answered the %s questionnaire. Review its answers in the vendor register and the evidence library before accepting this risk.", reason, a.TemplateName)
+	if v.Services != "" {
+		desc += "\n\nServices: " + v.Services
+	}
+
+	risk := &domain.Risk{
+		TenantID:       v.TenantID,
+		OrganizationID: v.TenantID,
+		Name:           title,
+		Title:          title,
+		Description:    desc,
+		Probability:    prob,
+		Impact:         impact,
+		Score:          math.Round(prob*impact*1.5*1000) / 1000,
+		Criticality:    crit,
+		Level:          level,
+		CreatedBy:      uuid.Nil, // proposed by the assessment, no human author
+		AssetID:        v.AssetID,
+		Source:         domain.SourceVendor,
+		Tags:           pq.StringArray{"vendor", "third-party", "auto"},
+		TreatmentPlan:  domain.RiskTreatment("mitigate"),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	// DRAFT, ALWAYS — see vulnrisk.RiskCreator for why a machine may propose a
+	// risk but never enter one as live work.
+	risk.SetState(domain.StateDraft)
+	vendorID := v.ID
+	risk.SourceVendorID = &vendorID
+	risk.SourceRuleReason = reason
+	if v.OwnerID != nil {
+		owner := *v.OwnerID
+		risk.Ownership.OwnerID = &owner
+	}
+
+	if err := c.db.WithContext(ctx).Create(risk).Error; err != nil {
+		return uuid.Nil, fmt.Errorf("failed to propose vendor risk: %w", err)
+	}
+	return risk.ID, nil
+}
+
+// assessmentToProbabilityImpact maps an assessment to the Score Engine scales
+// (probability 0.0–1.0, impact 0.0–10.0). Impact follows what the vendor
+// touches and how much the business leans on it — the tier's inputs, not the
+// questionnaire. Probability follows the score: the further under the pass
+// mark, the likelier a weakness is exploited.
+func assessmentToProbabilityImpact(v *domain.Vendor, score float64) (prob, impact float64) {
+	switch v.Tier {
+	case domain.VendorTier1:
+		impact = 8
+	case domain.VendorTier2:
+		impact = 6
+	default:
+		impact = 4
+	}
+	if v.DataAccess == domain.VendorDataRegulated || v.Criticality == domain.VendorCriticalityCritical {
+		impact = 9
+	}
+	prob = math.Round((1-score/100)*100) / 100
+	if prob < 0.2 {
+		prob = 0.2
+	}
+	if prob > 0.9 {
+		prob = 0.9
+	}
+	return prob, impact
+}
+
+func tierToLevels(t domain.VendorTier) (level string, crit domain.CriticalityLevel) {
+	switch t {
+	case domain.VendorTier1:
+		return "HIGH", domain.CriticalityLevel("high")
+	case domain.VendorTier2:
+		return "MEDIUM", domain.CriticalityLevel("medium")
+	default:
+		return "LOW", domain.CriticalityLevel("low")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package vendorrisk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/internal/infrastructure/vulnrisk"
+)
+
+// DraftRiskNotifier tells the tenant's admins — and the vendor's owner, when
+// it has one — that a vendor assessment proposed a draft risk. Best-effort,
+// like its vulnerability counterpart: a notification failure never fails a
+// vendor's submission.
+type DraftRiskNotifier struct {
+	db     *gorm.DB
+	notify vulnrisk.InAppNotifier
+}
+
+func NewDraftRiskNotifier(db *gorm.DB, notify vulnrisk.InAppNotifier) *DraftRiskNotifier {
+	return &DraftRiskNotifier{db: db, notify: notify}
+}
+
+// NotifyVendorRiskProposed notifies the reviewers.
+func (n *DraftRiskNotifier) NotifyVendorRiskProposed(ctx context.Context, tenantID, riskID uuid.UUID, v *domain.Vendor, reason string) {
+	if n == nil || n.notify == nil || n.db == nil {
+		return
+	}
+	defer func() { _ = recover() }()
+
+	var userIDs []uuid.UUID
+	if err := n.db.WithContext(ctx).
+		Model(&domain.OrganizationMember{}).
+		Where("organization_id = ? AND role IN ?", tenantID, []string{"admin", "root"}).
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return
+	}
+	if v.OwnerID != nil {
+		seen := false
+		for _, id := range userIDs {
+			seen = seen || id == *v.OwnerID
+		}
+		if !seen {
+			userIDs = append(userIDs, *v.OwnerID)
+		}
+	}
+
+	subject := fmt.Sprintf("Risque fournisseur proposé (brouillon) : %s", v.Name)
+	message := fmt.Sprintf(
+		"Un questionnaire fournisseur est passé sous son seuil. Le risque a été créé en BROUILLON et n'entrera dans le registre qu'après votre validation.\n\nMotif : %s",
+		reason,
+	)
+	id := riskID
+	for _, uid := range userIDs {
+		_ = n.notify.NotifyInApp(uid, tenantID, domain.NotificationTypeRiskReview, subject, message, &id, "risk")
+	}
+}
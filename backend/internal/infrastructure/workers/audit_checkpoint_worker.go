@@ -0,0 +1,71 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/opendefender/openrisk/internal/application/governance"
+)
+
+// AuditCheckpointWorker signs the audit chain head of every tenant whose trail
+// has grown since its last checkpoint (domain.AuditCheckpoint).
+//
+// The interval is the window an operator could rewrite unnoticed: entries
+// newer than the last checkpoint are only protected by the chain itself. An
+// hour keeps that window short without asking a public TSA for a token per
+// tenant every few seconds. Idle tenants cost nothing — no growth, no
+// checkpoint.
+type AuditCheckpointWorker struct {
+	checkpoint *governance.CheckpointAuditChainUseCase
+	logger     zerolog.Logger
+	interval   time.Duration
+}
+
+// NewAuditCheckpointWorker builds the sweep.
+func NewAuditCheckpointWorker(checkpoint *governance.CheckpointAuditChainUseCase, logger zerolog.Logger) *AuditCheckpointWorker {
+	return &AuditCheckpointWorker{checkpoint: checkpoint, logger: logger, interval: time.Hour}
+}
+
+// WithInterval overrides the sweep cadence (AUDIT_CHECKPOINT_INTERVAL).
+func (w *AuditCheckpointWorker) WithInterval(d time.Duration) *AuditCheckpointWorker {
+	if d > 0 {
+		w.interval = d
+	}
+	return w
+}
+
+// Start runs the sweep until the context is cancelled.
+func (w *AuditCheckpointWorker) Start(ctx context.Context) {
+	if w.checkpoint == nil {
+		return
+	}
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	w.logger.Info().Dur("interval", w.interval).Msg("audit checkpoint worker started")
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.sweep(ctx)
+		}
+	}
+}
+
+func (w *AuditCheckpointWorker) sweep(ctx context.Context) {
+	n, err := w.checkpoint.ExecuteDue(ctx)
+	if err != nil {
+		w.logger.Warn().Err(err).Msg("audit checkpoint sweep failed")
+		return
+	}
+	if n > 0 {
+		w.logger.Info().Int("checkpoints", n).Msg("audit checkpoint sweep complete")
+	}
+}
@@ -8,6 +8,9 @@ package workers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	appauto "github.com/opendefender/openrisk/internal/application/automation"
@@ -25,6 +28,7 @@ import (
 // Channels consumed:
 //   - vulnerability.detected → trigger vulnerability_detected (headline scenario)
 //   - risk.score_updated     → trigger risk_score_updated
+//   - kri.threshold_crossed  → trigger kri_threshold_crossed
 //
 // Graceful shutdown on ctx.Done(). Malformed payloads are logged and skipped —
 // one bad message never stops the loop.
@@ -41,7 +45,7 @@ func NewAutomationWorker(redis *redisclient.Client, engine *appauto.Engine, logg
 
 // Start blocks listening for events until ctx is cancelled.
 func (w *AutomationWorker) Start(ctx context.Context) {
-	pubsub := w.redis.Subscribe(ctx, events.VulnerabilityDetected, events.RiskScoreUpdated)
+	pubsub := w.redis.Subscribe(ctx, events.VulnerabilityDetected, events.RiskScoreUpdated, events.KRIThresholdCrossed)
 	defer pubsub.Close()
 	ch := pubsub.Channel()
 	w.logger.Info().Msg("automation worker started (SOAR engine, listening for triggers)")
@@ -59,6 +63,8 @@ func (w *AutomationWorker) Start(ctx context.Context) {
 				w.handleVulnerabilityDetected(ctx, msg.Payload)
 			case events.RiskScoreUpdated:
 				w.handleRiskScoreUpdated(ctx, msg.Payload)
+			case events.KRIThresholdCrossed:
+				w.handleKRIThresholdCrossed(ctx, msg.Payload)
 			}
 		}
 	}
@@ -119,6 +125,37 @@ func (w *AutomationWorker) handleRiskScoreUpdated(ctx context.Context, payload s
 	w.engine.HandleTrigger(ctx, domain.TriggerRiskScoreUpdated, tc)
 }
 
+// handleKRIThresholdCrossed fires kri_threshold_crossed. The severity is the
+// band reached, so "severity >= high" matches a KRI turning red; the first
+// linked risk is the one actions such as create_ticket attach to.
+func (w *AutomationWorker) handleKRIThresholdCrossed(ctx context.Context, payload string) {
+	var evt events.KRIThresholdCrossedEvent
+	if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+		w.logger.Warn().Err(err).Msg("automation: bad kri.threshold_crossed payload")
+		return
+	}
+	tenantID, err := uuid.Parse(evt.TenantID)
+	if err != nil {
+		return
+	}
+	title := fmt.Sprintf("KRI %s moved from %s to %s (%s %s)", evt.Name, evt.From, evt.To,
+		strconv.FormatFloat(evt.Value, 'f', -1, 64), evt.Unit)
+	tc := appauto.TriggerContext{
+		TenantID: tenantID,
+		Ref:      refFor("kri", evt.KRIID, ""),
+		Subject:  strings.TrimSpace(title),
+		Title:    strings.TrimSpace(title),
+		Severity: domain.KRIStatus(evt.To).Severity(),
+	}
+	for _, rid := range evt.RiskIDs {
+		if id, err := uuid.Parse(rid); err == nil {
+			tc.RiskID = &id
+			break
+		}
+	}
+	w.engine.HandleTrigger(ctx, domain.TriggerKRIThresholdCrossed, tc)
+}
+
 func refFor(kind, primary, fallback string) string {
 	v := primary
 	if v == "" {
@@ -24,6 +24,17 @@ type EvidenceExpiryStore interface {
 	MarkReminded(ctx context.Context, id uuid.UUID, at time.Time) error
 }
 
+// ExpiredEvidenceStore finds lapsed artifacts not yet announced, and stamps
+// the announcement. Cross-tenant, like EvidenceExpiryStore.
+type ExpiredEvidenceStore interface {
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]domain.Evidence, error)
+	MarkExpiryAnnounced(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// AnnounceExpiredFunc tells integrations an artifact has lapsed
+// (evidence.expired).
+type AnnounceExpiredFunc func(ctx context.Context, ev domain.Evidence)
+
 // NotifyExpiryFunc raises the reminder for whoever owns the artifact. Wired in
 // the composition root so the worker never depends on the notification use case.
 type NotifyExpiryFunc func(ctx context.Context, tenantID, userID, evidenceID uuid.UUID, subject, message string)
@@ -45,6 +56,9 @@ type EvidenceExpiryWorker struct {
 	logger   zerolog.Logger
 	interval time.Duration
 	window   time.Duration
+
+	expired  ExpiredEvidenceStore
+	announce AnnounceExpiredFunc
 }
 
 func NewEvidenceExpiryWorker(store EvidenceExpiryStore, notify NotifyExpiryFunc, logger zerolog.Logger) *EvidenceExpiryWorker {
@@ -65,6 +79,15 @@ func (w *EvidenceExpiryWorker) WithInterval(d time.Duration) *EvidenceExpiryWork
 	return w
 }
 
+// WithExpiryAnnouncements adds a second pass to each sweep: every artifact that
+// lapsed since the last one is announced once. The reminder is for the
+// owner, ahead of time; this is for the systems that track the same control.
+func (w *EvidenceExpiryWorker) WithExpiryAnnouncements(store ExpiredEvidenceStore, announce AnnounceExpiredFunc) *EvidenceExpiryWorker {
+	w.expired = store
+	w.announce = announce
+	return w
+}
+
 func (w *EvidenceExpiryWorker) Start(ctx context.Context) {
 	t := time.NewTicker(w.interval)
 	defer t.Stop()
@@ -85,6 +108,7 @@ func (w *EvidenceExpiryWorker) Start(ctx context.Context) {
 // a single pass without a ticker.
 func (w *EvidenceExpiryWorker) Sweep(ctx context.Context) {
 	now := time.Now()
+	w.announceExpired(ctx, now)
 	rows, err := w.store.ListExpiring(ctx, now, w.window, 500)
 	if err != nil {
 		w.logger.Warn().Err(err).Msg("evidence expiry: could not list artifacts approaching their expiry")
@@ -124,6 +148,27 @@ func (w *EvidenceExpiryWorker) Sweep(ctx context.Context) {
 	}
 }
 
+// announceExpired announces each lapse once. Stamped first, for the same
+// reason as the reminder.
+func (w *EvidenceExpiryWorker) announceExpired(ctx context.Context, now time.Time) {
+	if w.expired == nil || w.announce == nil {
+		return
+	}
+	rows, err := w.expired.ListExpired(ctx, now, 500)
+	if err != nil {
+		w.logger.Warn().Err(err).Msg("evidence expiry: could not list lapsed artifacts")
+		return
+	}
+	for i := range rows {
+		if err := w.expired.MarkExpiryAnnounced(ctx, rows[i].ID, now); err != nil {
+			w.logger.Warn().Err(err).Str("evidence_id", rows[i].ID.String()).
+				Msg("evidence expiry: could not stamp the lapse — not announced")
+			continue
+		}
+		w.announce(ctx, rows[i])
+	}
+}
+
 // expiryRecipient picks who to nudge: the person who must refresh the proof,
 // then the person who answers for it, then whoever collected it.
 func expiryRecipient(ev *domain.Evidence) uuid.UUID {
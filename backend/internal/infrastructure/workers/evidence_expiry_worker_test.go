@@ -183,3 +183,37 @@ func contains(haystack, needle string) bool {
 		return false
 	})()
 }
+
+type fakeExpiredStore struct {
+	rows    []domain.Evidence
+	stamped []uuid.UUID
+}
+
+func (s *fakeExpiredStore) ListExpired(context.Context, time.Time, int) ([]domain.Evidence, error) {
+	return s.rows, nil
+}
+
+func (s *fakeExpiredStore) MarkExpiryAnnounced(_ context.Context, id uuid.UUID, _ time.Time) error {
+	s.stamped = append(s.stamped, id)
+	return nil
+}
+
+func TestEvidenceExpiry_AnnouncesLapsedArtifactsOnce(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	ev := domain.Evidence{ID: uuid.New(), TenantID: uuid.New(), Title: "Pentest report", ValidUntil: &past}
+	expired := &fakeExpiredStore{rows: []domain.Evidence{ev}}
+	var announced []uuid.UUID
+	w := NewEvidenceExpiryWorker(&fakeExpiryStore{}, func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID, string, string) {}, silentLogger()).
+		WithExpiryAnnouncements(expired, func(_ context.Context, e domain.Evidence) {
+			announced = append(announced, e.ID)
+		})
+
+	w.Sweep(context.Background())
+
+	if len(announced) != 1 || announced[0] != ev.ID {
+		t.Fatalf("expected the lapsed artifact to be announced, got %v", announced)
+	}
+	if len(expired.stamped) != 1 {
+		t.Fatalf("the announcement must be stamped so it does not fire again")
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package workers
+
+import (
+	"context"
+	"time"
+
+	kriapp "github.com/opendefender/openrisk/internal/application/kri"
+	"github.com/rs/zerolog"
+)
+
+// KRIRefreshWorker evaluates query KRIs on their own cadence. It ticks every
+// minute and lets each KRI's RefreshMinutes decide whether it is due; pushed
+// and uploaded KRIs are never touched.
+type KRIRefreshWorker struct {
+	kris     *kriapp.Service
+	logger   zerolog.Logger
+	interval time.Duration
+}
+
+// NewKRIRefreshWorker builds the worker (default tick: one minute).
+func NewKRIRefreshWorker(kris *kriapp.Service, logger zerolog.Logger) *KRIRefreshWorker {
+	return &KRIRefreshWorker{kris: kris, logger: logger, interval: time.Minute}
+}
+
+// Start runs the loop until ctx is cancelled.
+func (w *KRIRefreshWorker) Start(ctx context.Context) {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+	w.logger.Info().Msg("kri refresh worker started (query KRI evaluation)")
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			w.tick(ctx, now)
+		}
+	}
+}
+
+func (w *KRIRefreshWorker) tick(ctx context.Context, now time.Time) {
+	if n, err := w.kris.SweepDue(ctx, now); err != nil {
+		w.logger.Warn().Err(err).Msg("kri refresh worker: sweep failed")
+	} else if n > 0 {
+		w.logger.Debug().Int("kris", n).Msg("kri refresh worker: evaluated due KRIs")
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package workers
+
+import (
+	"context"
+	"time"
+
+	appinc "github.com/opendefender/openrisk/internal/application/incident"
+	"github.com/rs/zerolog"
+)
+
+// RegulatoryClockMonitor sends the reminders on breach-notification clocks as
+// their windows run down (GDPR 72 h, NIS2 24 h / 72 h / one month, DORA). It runs
+// cross-tenant on a one-minute cadence: the shortest window it watches is DORA's
+// four hours, and a reminder at "90 %" that arrives ten minutes late has eaten
+// half of what was left.
+type RegulatoryClockMonitor struct {
+	clocks   *appinc.RegulatoryService
+	logger   zerolog.Logger
+	interval time.Duration
+}
+
+// NewRegulatoryClockMonitor builds the monitor (default cadence: one minute).
+func NewRegulatoryClockMonitor(clocks *appinc.RegulatoryService, logger zerolog.Logger) *RegulatoryClockMonitor {
+	return &RegulatoryClockMonitor{clocks: clocks, logger: logger, interval: time.Minute}
+}
+
+// Start runs the monitor loop until ctx is cancelled.
+func (m *RegulatoryClockMonitor) Start(ctx context.Context) {
+	t := time.NewTicker(m.interval)
+	defer t.Stop()
+	m.logger.Info().Msg("regulatory clock monitor started (breach-notification reminders)")
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			m.tick(ctx, now)
+		}
+	}
+}
+
+func (m *RegulatoryClockMonitor) tick(ctx context.Context, now time.Time) {
+	if n, err := m.clocks.SweepEscalations(ctx, now); err != nil {
+		m.logger.Warn().Err(err).Msg("regulatory clock monitor: sweep failed")
+	} else if n > 0 {
+		m.logger.Info().Int("reminded", n).Msg("regulatory clock monitor: sent notification deadline reminders")
+	}
+}
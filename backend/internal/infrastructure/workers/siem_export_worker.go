@@ -0,0 +1,76 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// SIEMShipper is what the worker needs from application/siem.
+type SIEMShipper interface {
+	ShipNext(ctx context.Context) (bool, error)
+}
+
+// SIEMExportWorker ships the audit chain and the auth log to every enabled
+// SIEM destination.
+//
+// A poll rather than a subscription to new audit entries: the cursor in the
+// destination row is the only state, so a replica that restarts, a SIEM that
+// was down for an hour and a backfill are all the same case — ship what lies
+// past the cursor. Each pass claims destinations one at a time, so replicas
+// share the work and a destination is never shipped by two at once.
+type SIEMExportWorker struct {
+	shipper  SIEMShipper
+	logger   zerolog.Logger
+	interval time.Duration
+	// concurrency is how many destinations ship at once; a slow collector
+	// holds one sender for at most a batch timeout.
+	concurrency int
+}
+
+func NewSIEMExportWorker(shipper SIEMShipper, logger zerolog.Logger) *SIEMExportWorker {
+	return &SIEMExportWorker{shipper: shipper, logger: logger, interval: 5 * time.Second, concurrency: 2}
+}
+
+// WithInterval overrides the poll cadence (tests).
+func (w *SIEMExportWorker) WithInterval(d time.Duration) *SIEMExportWorker {
+	if d > 0 {
+		w.interval = d
+	}
+	return w
+}
+
+func (w *SIEMExportWorker) Start(ctx context.Context) {
+	w.logger.Info().Int("workers", w.concurrency).Msg("SIEM export worker started")
+	for i := 0; i < w.concurrency; i++ {
+		go w.loop(ctx)
+	}
+}
+
+func (w *SIEMExportWorker) loop(ctx context.Context) {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			for {
+				worked, err := w.shipper.ShipNext(ctx)
+				if err != nil {
+					w.logger.Warn().Err(err).Msg("SIEM export worker: progress could not be recorded")
+					break
+				}
+				if !worked {
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package workers
+
+import (
+	"context"
+	"time"
+
+	thehiveapp "github.com/opendefender/openrisk/internal/application/thehive"
+	"github.com/rs/zerolog"
+)
+
+// TheHiveSyncWorker is the polling fallback of the TheHive case sync. Webhooks
+// carry most changes within seconds; this catches the ones that never arrive
+// (an instance that cannot reach us, a notifier that was down) and pushes local
+// incident changes on each connection's own cadence. It ticks every minute and
+// lets each connection's PollMinutes decide whether it is due.
+type TheHiveSyncWorker struct {
+	sync     *thehiveapp.SyncService
+	logger   zerolog.Logger
+	interval time.Duration
+}
+
+// NewTheHiveSyncWorker builds the worker (default tick: one minute).
+func NewTheHiveSyncWorker(sync *thehiveapp.SyncService, logger zerolog.Logger) *TheHiveSyncWorker {
+	return &TheHiveSyncWorker{sync: sync, logger: logger, interval: time.Minute}
+}
+
+// Start runs the loop until ctx is cancelled.
+func (w *TheHiveSyncWorker) Start(ctx context.Context) {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+	w.logger.Info().Msg("thehive sync worker started (case sync polling fallback)")
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			w.tick(ctx, now)
+		}
+	}
+}
+
+func (w *TheHiveSyncWorker) tick(ctx context.Context, now time.Time) {
+	if n, err := w.sync.SweepDue(ctx, now); err != nil {
+		w.logger.Warn().Err(err).Msg("thehive sync worker: sweep failed")
+	} else if n > 0 {
+		w.logger.Debug().Int("connections", n).Msg("thehive sync worker: synced due connections")
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package workers
+
+import (
+	"context"
+	"time"
+
+	vendorapp "github.com/opendefender/openrisk/internal/application/vendor"
+	"github.com/rs/zerolog"
+)
+
+// VendorReassessmentWorker sends the next questionnaire to vendors whose
+// tier-driven reassessment date has passed. Dates are days apart, so an hourly
+// tick is plenty; a vendor with a link still open is skipped by the sweep.
+type VendorReassessmentWorker struct {
+	vendors  *vendorapp.Service
+	logger   zerolog.Logger
+	interval time.Duration
+}
+
+// NewVendorReassessmentWorker builds the worker (default tick: one hour).
+func NewVendorReassessmentWorker(vendors *vendorapp.Service, logger zerolog.Logger) *VendorReassessmentWorker {
+	return &VendorReassessmentWorker{vendors: vendors, logger: logger, interval: time.Hour}
+}
+
+// Start runs the loop until ctx is cancelled.
+func (w *VendorReassessmentWorker) Start(ctx context.Context) {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+	w.logger.Info().Msg("vendor reassessment worker started")
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			w.tick(ctx, now)
+		}
+	}
+}
+
+func (w *VendorReassessmentWorker) tick(ctx context.Context, now time.Time) {
+	if n, err := w.vendors.SweepDue(ctx, now); err != nil {
+		w.logger.Warn().Err(err).Msg("vendor reassessment worker: sweep failed")
+	} else if n > 0 {
+		w.logger.Info().Int("vendors", n).Msg("vendor reassessment worker: sent reassessments")
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// WebhookDeliverer is what the worker needs from application/webhooks.
+type WebhookDeliverer interface {
+	DeliverNext(ctx context.Context) (bool, error)
+}
+
+// WebhookDeliveryWorker sends queued webhook deliveries.
+//
+// A poll loop, like the report worker, and for the same reason: the delivery
+// row is the queue. An event published while every worker was restarting is
+// still sent on the next tick, and a retry is only a row whose
+// next_attempt_at is in the future.
+type WebhookDeliveryWorker struct {
+	deliverer WebhookDeliverer
+	logger    zerolog.Logger
+	interval  time.Duration
+	// concurrency bounds simultaneous requests. One slow receiver holds a
+	// sender for up to the client timeout; four keeps a single bad endpoint
+	// from delaying every other tenant's deliveries.
+	concurrency int
+}
+
+func NewWebhookDeliveryWorker(deliverer WebhookDeliverer, logger zerolog.Logger) *WebhookDeliveryWorker {
+	return &WebhookDeliveryWorker{deliverer: deliverer, logger: logger, interval: 5 * time.Second, concurrency: 4}
+}
+
+// WithInterval overrides the poll cadence (tests).
+func (w *WebhookDeliveryWorker) WithInterval(d time.Duration) *WebhookDeliveryWorker {
+	if d > 0 {
+		w.interval = d
+	}
+	return w
+}
+
+func (w *WebhookDeliveryWorker) Start(ctx context.Context) {
+	w.logger.Info().Int("workers", w.concurrency).Msg("Webhook delivery worker started")
+	for i := 0; i < w.concurrency; i++ {
+		go w.loop(ctx)
+	}
+}
+
+func (w *WebhookDeliveryWorker) loop(ctx context.Context) {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			// Drain: a burst of events should not take one tick per delivery.
+			for {
+				worked, err := w.deliverer.DeliverNext(ctx)
+				if err != nil {
+					w.logger.Warn().Err(err).Msg("webhook worker: delivery could not be recorded")
+					break
+				}
+				if !worked {
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package workers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	redisclient "github.com/opendefender/openrisk/internal/infrastructure/redis"
+	"github.com/opendefender/openrisk/pkg/events"
+)
+
+// WebhookEventPublisher queues an event under a given id.
+// application/webhooks.Service satisfies it.
+type WebhookEventPublisher interface {
+	PublishEvent(ctx context.Context, eventID, tenantID uuid.UUID, eventType domain.WebhookEventType, data any) error
+}
+
+// webhookEventNamespace derives event ids from Redis messages.
+var webhookEventNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("urn:openrisk:webhook-event"))
+
+// WebhookEventBridge relays the events that already travel on Redis —
+// risk.score_updated from the score worker, vulnerability.detected from
+// ingest — to webhook subscribers. The producers stay unaware of webhooks.
+//
+// Every replica subscribes, so every replica sees each message. The event id
+// is derived from the message itself, and a delivery is queued once per
+// (subscription, event id): whichever replica inserts first wins, the others
+// are no-ops.
+type WebhookEventBridge struct {
+	redis     *redisclient.Client
+	publisher WebhookEventPublisher
+	logger    zerolog.Logger
+}
+
+// NewWebhookEventBridge builds the bridge.
+func NewWebhookEventBridge(redis *redisclient.Client, publisher WebhookEventPublisher, logger zerolog.Logger) *WebhookEventBridge {
+	return &WebhookEventBridge{redis: redis, publisher: publisher, logger: logger}
+}
+
+// Start blocks relaying events until ctx is cancelled.
+func (w *WebhookEventBridge) Start(ctx context.Context) {
+	pubsub := w.redis.Subscribe(ctx, events.RiskScoreUpdated, events.VulnerabilityDetected)
+	defer pubsub.Close()
+	ch := pubsub.Channel()
+	w.logger.Info().Msg("webhook event bridge started (risk.score_updated, vulnerability.detected)")
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-ch:
+			if msg == nil {
+				return
+			}
+			w.Relay(ctx, msg.Channel, msg.Payload)
+		}
+	}
+}
+
+// Relay maps one Redis message to its webhook event. Exported so a test can
+// drive it without Redis. Malformed payloads are logged and skipped.
+func (w *WebhookEventBridge) Relay(ctx context.Context, channel, payload string) {
+	var (
+		tenant string
+		kind   domain.WebhookEventType
+		data   any
+	)
+	switch channel {
+	case events.RiskScoreUpdated:
+		var evt events.RiskScoreUpdatedEvent
+		if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+			w.logger.Warn().Err(err).Msg("webhooks: bad risk.score_updated payload")
+			return
+		}
+		riskID, err := uuid.Parse(evt.RiskID)
+		if err != nil {
+			return
+		}
+		tenant, kind = evt.TenantID, domain.WebhookRiskScoreUpdated
+		data = domain.WebhookRiskScoreUpdatedData{
+			RiskID: riskID, OldScore: evt.OldScore, NewScore: evt.NewScore, Delta: evt.Delta,
+			Criticality: evt.Criticality, CalculatedAt: evt.CalculatedAt,
+		}
+	case events.VulnerabilityDetected:
+		var evt events.VulnerabilityDetectedEvent
+		if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+			w.logger.Warn().Err(err).Msg("webhooks: bad vulnerability.detected payload")
+			return
+		}
+		vulnID, err := uuid.Parse(evt.VulnerabilityID)
+		if err != nil {
+			return
+		}
+		d := domain.WebhookVulnerabilityDetectedData{
+			VulnerabilityID: vulnID, CVEID: evt.CVEID, Title: evt.Title, Severity: evt.Severity,
+			CVSS: evt.CVSS, KEV: evt.KEV, PriorityTier: evt.PriorityTier, AssetName: evt.AssetName, Source: evt.Source,
+		}
+		if id, err := uuid.Parse(evt.AssetID); err == nil && id != uuid.Nil {
+			d.AssetID = &id
+		}
+		tenant, kind, data = evt.TenantID, domain.WebhookVulnerabilityDetected, d
+	default:
+		return
+	}
+	tenantID, err := uuid.Parse(tenant)
+	if err != nil {
+		return
+	}
+	eventID := uuid.NewSHA1(webhookEventNamespace, []byte(channel+"\n"+payload))
+	if err := w.publisher.PublishEvent(ctx, eventID, tenantID, kind, data); err != nil {
+		w.logger.Warn().Err(err).Str("event", string(kind)).Msg("webhooks: could not queue event")
+	}
+}
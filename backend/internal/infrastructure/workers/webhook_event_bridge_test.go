@@ -0,0 +1,57 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package workers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/events"
+)
+
+type queuedEvent struct {
+	id, tenant uuid.UUID
+	kind       domain.WebhookEventType
+	data       any
+}
+
+type fakeWebhookPublisher struct{ queued []queuedEvent }
+
+func (p *fakeWebhookPublisher) PublishEvent(_ context.Context, id, tenant uuid.UUID, kind domain.WebhookEventType, data any) error {
+	p.queued = append(p.queued, queuedEvent{id, tenant, kind, data})
+	return nil
+}
+
+// Every replica relays the same message; they must agree on its event id or
+// subscribers would receive it once per replica.
+func TestWebhookEventBridge_SameMessageSameEventID(t *testing.T) {
+	pub := &fakeWebhookPublisher{}
+	bridge := NewWebhookEventBridge(nil, pub, silentLogger())
+	tenant, risk := uuid.New(), uuid.New()
+	msg := `{"tenant_id":"` + tenant.String() + `","risk_id":"` + risk.String() + `","old_score":4,"new_score":12}`
+
+	bridge.Relay(context.Background(), events.RiskScoreUpdated, msg)
+	bridge.Relay(context.Background(), events.RiskScoreUpdated, msg)
+	bridge.Relay(context.Background(), events.RiskScoreUpdated, `{"tenant_id":"`+tenant.String()+`","risk_id":"`+risk.String()+`","old_score":12,"new_score":15}`)
+	bridge.Relay(context.Background(), events.RiskScoreUpdated, `not json`)
+
+	if len(pub.queued) != 3 {
+		t.Fatalf("expected three relayed events, got %d", len(pub.queued))
+	}
+	if pub.queued[0].id != pub.queued[1].id {
+		t.Fatalf("the same message must map to the same event id")
+	}
+	if pub.queued[0].id == pub.queued[2].id {
+		t.Fatalf("a different message must map to a different event id")
+	}
+	data, ok := pub.queued[0].data.(domain.WebhookRiskScoreUpdatedData)
+	if !ok || data.RiskID != risk || data.NewScore != 12 || pub.queued[0].tenant != tenant {
+		t.Fatalf("unexpected payload %+v", pub.queued[0])
+	}
+}
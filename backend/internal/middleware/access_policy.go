@@ -0,0 +1,88 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// AccessScopeResolver resolves the record-level access scope of a user in a
+// tenant (accesspolicy.Service). A nil scope means no policy applies.
+type AccessScopeResolver interface {
+	Scope(ctx context.Context, tenantID, userID uuid.UUID, tokenRole domain.MemberRole) (*domain.AccessScope, error)
+}
+
+// AccessPolicies attaches the caller's access scope to the request context,
+// where the repositories read it (repository.AccessScoped). Unlike the plan
+// gates it fails CLOSED: a request whose scope cannot be resolved could
+// otherwise read records a policy hides.
+func AccessPolicies(resolver AccessScopeResolver) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if resolver == nil {
+			return c.Next()
+		}
+		rc := GetContext(c)
+		if rc == nil || rc.OrganizationID == uuid.Nil || rc.UserID == uuid.Nil {
+			return c.Next()
+		}
+		tenant, user := rc.OrganizationID, rc.UserID
+		var tokenRole domain.MemberRole
+		if claims := GetUserClaims(c); claims != nil {
+			tokenRole = domain.MemberRole(claims.OrgRoles[tenant])
+		}
+		scope, err := resolver.Scope(c.UserContext(), tenant, user, tokenRole)
+		if err != nil {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "access policies could not be evaluated; try again shortly",
+			})
+		}
+		if scope != nil {
+			c.SetUserContext(domain.WithAccessScope(c.UserContext(), scope))
+		}
+		return c.Next()
+	}
+}
+
+// RecordVisibility tells whether the request's access scope lets its user see
+// one record (repository.GormAccessPolicyRepository).
+type RecordVisibility interface {
+	Visible(ctx context.Context, tenantID uuid.UUID, r domain.AccessResource, id uuid.UUID) (bool, error)
+}
+
+// VisibleRecord answers 404 for a record the caller's policies hide, before
+// the handler runs. It guards the routes nested under a record — a risk's
+// KRIs, timeline, incidents, mitigations — whose rows live in other tables
+// and so are not filtered by the record's own scope: a hidden risk's
+// sub-resources do not exist either. param names the route parameter holding
+// the record's id; a value that is not an id is left to the handler.
+func VisibleRecord(v RecordVisibility, r domain.AccessResource, param string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.UserContext()
+		if deny, allow := domain.AccessScopeFrom(ctx).Applicable(r); len(deny) == 0 && len(allow) == 0 {
+			return c.Next()
+		}
+		rc := GetContext(c)
+		id, err := uuid.Parse(c.Params(param))
+		if rc == nil || err != nil {
+			return c.Next()
+		}
+		ok, err := v.Visible(ctx, rc.OrganizationID, r, id)
+		if err != nil {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "access policies could not be evaluated; try again shortly",
+			})
+		}
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": string(r) + " not found"})
+		}
+		return c.Next()
+	}
+}
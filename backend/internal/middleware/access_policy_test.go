@@ -0,0 +1,55 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+type visibleSet map[uuid.UUID]bool
+
+func (v visibleSet) Visible(_ context.Context, _ uuid.UUID, _ domain.AccessResource, id uuid.UUID) (bool, error) {
+	return v[id], nil
+}
+
+func TestVisibleRecord_HidesTheSubResourcesOfAHiddenRecord(t *testing.T) {
+	shown, hidden := uuid.New(), uuid.New()
+	var scope *domain.AccessScope
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		SetContext(c, &RequestContext{UserID: uuid.New(), OrganizationID: uuid.New()})
+		c.SetUserContext(domain.WithAccessScope(c.UserContext(), scope))
+		return c.Next()
+	})
+	app.Use("/risks/:id", VisibleRecord(visibleSet{shown: true}, domain.AccessResourceRisk, "id"))
+	app.Get("/risks/:id/kris", func(c *fiber.Ctx) error { return c.SendString("kris") })
+	app.Get("/risks/export", func(c *fiber.Ctx) error { return c.SendString("export") })
+
+	status := func(path string) int {
+		resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, 200, status("/risks/"+hidden.String()+"/kris"), "no policy applies, nothing is checked")
+
+	scope = &domain.AccessScope{
+		Subject:  domain.AccessSubject{UserID: uuid.New(), Role: domain.RoleUser},
+		Policies: []domain.AccessPolicy{{Name: "p", Resource: domain.AccessResourceRisk, Effect: domain.AccessDeny, Enabled: true}},
+	}
+	assert.Equal(t, 200, status("/risks/"+shown.String()+"/kris"))
+	assert.Equal(t, 404, status("/risks/"+hidden.String()+"/kris"))
+	assert.Equal(t, 200, status("/risks/export"), "a segment that is not an id is the handler's")
+}
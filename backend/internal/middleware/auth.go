@@ -210,6 +210,15 @@ func GetTenantID(c *fiber.Ctx) (uuid.UUID, error) {
 	return tenantID, nil
 }
 
+// HasPermission reports whether the authenticated caller holds required, with
+// the same wildcard semantics as RequirePermission. For handlers that check
+// per item rather than per route (the GraphQL API checks per field); it reads
+// the effective permissions, so a personal access token is held to its scopes.
+func HasPermission(c *fiber.Ctx, required string) bool {
+	permissions, ok := c.Locals("permissions").([]string)
+	return ok && hasPermission(permissions, required)
+}
+
 // hasPermission checks if permissions array contains required permission.
 // Supports wildcards: "risk:*" matches "risk:read", "risk:write", etc.
 // Supports admin wildcard: "*" matches everything.
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package pluginhost runs connector plugins: WebAssembly modules executed in a
+// pure-Go sandbox (wazero) with no ambient authority. A plugin sees only what
+// the host hands it through a small, versioned ABI — its input, the secrets it
+// was granted, and an HTTP call that refuses any host it was not granted.
+//
+// # ABI version 1
+//
+// A module exports:
+//
+//	memory                                 its linear memory
+//	openrisk_abi() -> i32                  the ABI version it was built for (1)
+//	openrisk_alloc(size i32) -> i32        a buffer of size bytes the host may fill
+//	<hook>(ptr i32, len i32) -> i64        one per implemented hook
+//
+// A hook receives a JSON document at (ptr, len) and returns its JSON result
+// packed as ptr<<32 | len (0 for an empty result). A result carrying a
+// non-empty "error" member fails the run with that message.
+//
+// The host provides, in module "openrisk":
+//
+//	log(ptr i32, len i32)                  a line for the run log
+//	http_request(ptr i32, len i32) -> i64  an HTTPRequest in, an HTTPResponse out
+//	secret_get(ptr i32, len i32) -> i64    a granted secret's value, or 0
+//
+// Returned buffers are allocated with the module's own openrisk_alloc. WASI
+// preview 1 is also available, without a filesystem, arguments or environment,
+// so modules built by the usual toolchains load unchanged.
+package pluginhost
+
+// ABIVersion is the host ABI this build implements.
+const ABIVersion = 1
+
+// HostModule is the import module name of the host functions.
+const HostModule = "openrisk"
+
+const (
+	exportABI   = "openrisk_abi"
+	exportAlloc = "openrisk_alloc"
+)
+
+// HTTPRequest is what a plugin passes to http_request.
+type HTTPRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// HTTPResponse is what http_request returns. Error is set, and Status is 0,
+// when the request was refused or failed before a response.
+type HTTPResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// Hook inputs. Config is the install's configuration.
+type (
+	// PullInput is the pull_findings and emit_assets input.
+	PullInput struct {
+		Config map[string]any `json:"config"`
+	}
+	// TicketInput is the create_ticket input.
+	TicketInput struct {
+		Config map[string]any `json:"config"`
+		Ticket Ticket         `json:"ticket"`
+	}
+	// WebhookInput is the transform_webhook input. Body is the raw payload.
+	WebhookInput struct {
+		Config  map[string]any    `json:"config"`
+		Headers map[string]string `json:"headers"`
+		Body    string            `json:"body"`
+	}
+)
+
+// Ticket is what create_ticket is asked to open.
+type Ticket struct {
+	Summary     string   `json:"summary"`
+	Description string   `json:"description"`
+	Priority    string   `json:"priority,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+}
+
+// Hook outputs.
+type (
+	// FindingsOutput is the pull_findings and transform_webhook result. Each
+	// finding uses the generic vulnerability fields (title, severity, cve,
+	// cvss, asset, external_id, remediation…).
+	FindingsOutput struct {
+		Findings []map[string]any `json:"findings"`
+		Error    string           `json:"error,omitempty"`
+	}
+	// AssetsOutput is the emit_assets result.
+	AssetsOutput struct {
+		Assets []Asset `json:"assets"`
+		Error  string  `json:"error,omitempty"`
+	}
+	// TicketOutput is the create_ticket result.
+	TicketOutput struct {
+		Key   string `json:"key"`
+		URL   string `json:"url,omitempty"`
+		Error string `json:"error,omitempty"`
+	}
+)
+
+// Asset is an emitted asset, in the scanner's discovery vocabulary.
+type Asset struct {
+	ExternalID  string         `json:"external_id"`
+	Name        string         `json:"name"`
+	Type        string         `json:"type"`
+	IP          string         `json:"ip,omitempty"`
+	Hostname    string         `json:"hostname,omitempty"`
+	OS          string         `json:"os,omitempty"`
+	Environment string         `json:"environment,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
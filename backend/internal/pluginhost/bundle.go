@@ -0,0 +1,277 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package pluginhost
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// A bundle is a zip holding three files:
+//
+//	manifest.json   the Manifest
+//	plugin.wasm     the module; its SHA-256 is pinned in the manifest
+//	signature.json  {"key_id": "...", "signature": "<base64 ed25519>"}
+//
+// The signature covers the exact bytes of manifest.json, and the manifest pins
+// the module digest, so neither can be swapped without the publisher's key.
+const (
+	bundleManifest  = "manifest.json"
+	bundleModule    = "plugin.wasm"
+	bundleSignature = "signature.json"
+)
+
+// Manifest describes a plugin and what it needs.
+type Manifest struct {
+	Name        string              `json:"name"`
+	Version     string              `json:"version"`
+	Publisher   string              `json:"publisher"`
+	Description string              `json:"description,omitempty"`
+	ABI         int                 `json:"abi"`
+	Hooks       []domain.PluginHook `json:"hooks"`
+	// Hosts are the hosts the plugin asks to reach ("api.example.com" or
+	// "*.example.com"); Secrets are the secret names it asks to read.
+	Hosts      []string `json:"hosts,omitempty"`
+	Secrets    []string `json:"secrets,omitempty"`
+	WasmSHA256 string   `json:"wasm_sha256"`
+}
+
+var (
+	manifestName = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,119}$`)
+	secretName   = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+)
+
+// Validate checks the manifest against this host.
+func (m *Manifest) Validate() error {
+	if !manifestName.MatchString(m.Name) {
+		return domain.NewValidationError("manifest name must be lower-case letters, digits, '.', '_' or '-'")
+	}
+	if m.Version == "" || len(m.Version) > 40 {
+		return domain.NewValidationError("manifest version is required (at most 40 characters)")
+	}
+	if strings.TrimSpace(m.Publisher) == "" {
+		return domain.NewValidationError("manifest publisher is required")
+	}
+	if m.ABI != ABIVersion {
+		return domain.NewValidationError(fmt.Sprintf("plugin targets ABI %d; this host implements ABI %d", m.ABI, ABIVersion))
+	}
+	if len(m.Hooks) == 0 {
+		return domain.NewValidationError("manifest declares no hooks")
+	}
+	for _, h := range m.Hooks {
+		if !h.IsValid() {
+			return domain.NewValidationError(fmt.Sprintf("unknown hook %q", h))
+		}
+	}
+	for _, h := range m.Hosts {
+		if !validHostPattern(h) {
+			return domain.NewValidationError(fmt.Sprintf("invalid host %q: use a hostname or *.domain", h))
+		}
+	}
+	for _, s := range m.Secrets {
+		if !secretName.MatchString(s) {
+			return domain.NewValidationError(fmt.Sprintf("invalid secret name %q", s))
+		}
+	}
+	return nil
+}
+
+// Bundle is a parsed, not yet verified, bundle.
+type Bundle struct {
+	Manifest    Manifest
+	ManifestRaw []byte
+	Module      []byte
+	KeyID       string
+	Signature   []byte
+}
+
+// Digest is the hex SHA-256 of the module.
+func (b *Bundle) Digest() string {
+	sum := sha256.Sum256(b.Module)
+	return hex.EncodeToString(sum[:])
+}
+
+type signatureFile struct {
+	KeyID     string `json:"key_id"`
+	Signature string `json:"signature"`
+}
+
+// ReadBundle unpacks a bundle. It checks the structure and that the module
+// matches the digest the manifest pins; it does not check the signature.
+func ReadBundle(data []byte) (*Bundle, error) {
+	if len(data) > domain.MaxPluginBundleBytes {
+		return nil, domain.NewValidationError(fmt.Sprintf("bundle exceeds %d MiB", domain.MaxPluginBundleBytes>>20))
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, domain.NewValidationError("bundle is not a zip archive")
+	}
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		switch f.Name {
+		case bundleManifest, bundleModule, bundleSignature:
+		default:
+			continue
+		}
+		if f.UncompressedSize64 > domain.MaxPluginBundleBytes {
+			return nil, domain.NewValidationError(fmt.Sprintf("%s is too large", f.Name))
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, domain.NewValidationError(fmt.Sprintf("cannot read %s: %v", f.Name, err))
+		}
+		b, err := io.ReadAll(io.LimitReader(rc, domain.MaxPluginBundleBytes+1))
+		rc.Close()
+		if err != nil {
+			return nil, domain.NewValidationError(fmt.Sprintf("cannot read %s: %v", f.Name, err))
+		}
+		files[f.Name] = b
+	}
+	for _, name := range []string{bundleManifest, bundleModule, bundleSignature} {
+		if files[name] == nil {
+			return nil, domain.NewValidationError("bundle has no " + name)
+		}
+	}
+
+	b := &Bundle{ManifestRaw: files[bundleManifest], Module: files[bundleModule]}
+	if err := json.Unmarshal(b.ManifestRaw, &b.Manifest); err != nil {
+		return nil, domain.NewValidationError("manifest.json is not valid JSON: " + err.Error())
+	}
+	if err := b.Manifest.Validate(); err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(b.Manifest.WasmSHA256, b.Digest()) {
+		return nil, domain.NewValidationError("plugin.wasm does not match the digest pinned in the manifest")
+	}
+	var sig signatureFile
+	if err := json.Unmarshal(files[bundleSignature], &sig); err != nil {
+		return nil, domain.NewValidationError("signature.json is not valid JSON")
+	}
+	b.KeyID = sig.KeyID
+	if b.Signature, err = base64.StdEncoding.DecodeString(sig.Signature); err != nil {
+		return nil, domain.NewValidationError("signature is not valid base64")
+	}
+	return b, nil
+}
+
+// Keyring is the set of publisher keys bundles may be signed with.
+type Keyring map[string]ed25519.PublicKey
+
+// ParseKeyring reads "key-id:base64-public-key" entries separated by commas,
+// the form of PLUGIN_TRUSTED_KEYS.
+func ParseKeyring(spec string) (Keyring, error) {
+	k := Keyring{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, enc, ok := strings.Cut(entry, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("trusted key %q: expected key-id:base64-public-key", entry)
+		}
+		raw, err := base64.StdEncoding.DecodeString(enc)
+		if err != nil || len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("trusted key %q: not a base64 ed25519 public key", id)
+		}
+		k[id] = ed25519.PublicKey(raw)
+	}
+	return k, nil
+}
+
+// Verify checks the bundle's signature against the keyring.
+func (k Keyring) Verify(b *Bundle) error {
+	if len(k) == 0 {
+		return domain.NewValidationError("no trusted plugin publisher keys are configured (PLUGIN_TRUSTED_KEYS)")
+	}
+	pub, ok := k[b.KeyID]
+	if !ok {
+		return domain.NewValidationError(fmt.Sprintf("bundle is signed with untrusted key %q", b.KeyID))
+	}
+	if !ed25519.Verify(pub, b.ManifestRaw, b.Signature) {
+		return domain.NewValidationError("bundle signature does not verify")
+	}
+	return nil
+}
+
+// WriteBundle builds a signed bundle: it pins the module digest in the
+// manifest, signs the manifest and zips the three files.
+func WriteBundle(m Manifest, module []byte, keyID string, key ed25519.PrivateKey) ([]byte, error) {
+	sum := sha256.Sum256(module)
+	m.WasmSHA256 = hex.EncodeToString(sum[:])
+	if m.ABI == 0 {
+		m.ABI = ABIVersion
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	sig, err := json.Marshal(signatureFile{KeyID: keyID, Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(key, manifest))})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range []struct {
+		name string
+		data []byte
+	}{{bundleManifest, manifest}, {bundleModule, module}, {bundleSignature, sig}} {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(f.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// validHostPattern accepts "host.example.com", "*.example.com" and an IP.
+func validHostPattern(h string) bool {
+	h = strings.TrimPrefix(h, "*.")
+	if h == "" || len(h) > 253 || strings.ContainsAny(h, "/:@ *") {
+		return false
+	}
+	return true
+}
+
+// HostAllowed reports whether host matches one of the patterns. "*.example.com"
+// matches any subdomain of example.com but not example.com itself.
+func HostAllowed(host string, patterns []string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, p := range patterns {
+		p = strings.ToLower(p)
+		if suffix, ok := strings.CutPrefix(p, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == p {
+			return true
+		}
+	}
+	return false
+}
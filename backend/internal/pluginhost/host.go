@@ -0,0 +1,429 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package pluginhost
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+
+	"github.com/opendefender/openrisk/internal/domain"
+	"github.com/opendefender/openrisk/pkg/netguard"
+)
+
+// Per-invocation bounds that are not configurable per install.
+const (
+	maxHTTPCalls     = 50
+	maxResponseBytes = 2 << 20
+	maxOutputBytes   = 8 << 20
+	maxLogLines      = 100
+	maxLogLine       = 1024
+)
+
+// ErrTimeout is returned when a run is stopped at its time limit.
+var ErrTimeout = errors.New("plugin exceeded its time limit")
+
+// Limits bound one invocation. Zero values take the defaults.
+type Limits struct {
+	MemoryPages uint32
+	Timeout     time.Duration
+}
+
+// Grants are what an install lets the plugin use.
+type Grants struct {
+	AllowedHosts []string
+	Secrets      map[string]string
+}
+
+// Call is one hook invocation.
+type Call struct {
+	Hook   domain.PluginHook
+	Input  []byte
+	Grants Grants
+	Limits Limits
+}
+
+// Result is what a finished invocation produced. Logs have granted secret
+// values redacted.
+type Result struct {
+	Output    []byte
+	Logs      []string
+	HTTPCalls int
+	Duration  time.Duration
+}
+
+// ModuleInfo is what Inspect learns about a module.
+type ModuleInfo struct {
+	ABI   int
+	Hooks []domain.PluginHook
+}
+
+// Host compiles and runs plugin modules. It is safe for concurrent use: every
+// invocation gets its own runtime, sharing only the compilation cache.
+type Host struct {
+	cache        wazero.CompilationCache
+	client       *http.Client
+	allowPrivate bool
+}
+
+// NewHost builds a host whose plugins cannot reach loopback, private or
+// link-local addresses, whatever their grants say.
+func NewHost() *Host {
+	h := &Host{cache: wazero.NewCompilationCache()}
+	// Checked on every dial after name resolution, so a granted hostname that
+	// resolves to an internal address is refused too. Read at dial time, so
+	// AllowPrivateNetworks applies to a host already built.
+	dialer := &net.Dialer{Timeout: 10 * time.Second, Control: h.checkAddress}
+	h.client = &http.Client{
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			MaxIdleConns:        10,
+		},
+	}
+	return h
+}
+
+// AllowPrivateNetworks lets plugins reach private and loopback addresses, for
+// self-hosted deployments whose tools live on the internal network.
+func (h *Host) AllowPrivateNetworks(allow bool) *Host {
+	h.allowPrivate = allow
+	return h
+}
+
+func (h *Host) checkAddress(network, address string, c syscall.RawConn) error {
+	return netguard.Control(h.allowPrivate, "plugins")(network, address, c)
+}
+
+func (l Limits) withDefaults() Limits {
+	if l.MemoryPages == 0 {
+		l.MemoryPages = domain.PluginDefaultMemoryPages
+	} else if l.MemoryPages > domain.PluginMaxMemoryPages {
+		l.MemoryPages = domain.PluginMaxMemoryPages
+	}
+	maxTimeout := time.Duration(domain.PluginMaxTimeoutMS) * time.Millisecond
+	if l.Timeout <= 0 {
+		l.Timeout = time.Duration(domain.PluginDefaultTimeoutMS) * time.Millisecond
+	} else if l.Timeout > maxTimeout {
+		l.Timeout = maxTimeout
+	}
+	return l
+}
+
+// Inspect loads a module and checks it against the ABI: the exports it must
+// have, the imports it may have and the version it reports. It returns the
+// hooks the module exports.
+func (h *Host) Inspect(ctx context.Context, module []byte) (*ModuleInfo, error) {
+	lim := Limits{}.withDefaults()
+	ctx, cancel := context.WithTimeout(ctx, lim.Timeout)
+	defer cancel()
+	sess := &session{host: h}
+	mod, closeRT, err := h.instantiate(ctx, module, lim, sess)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRT()
+
+	info := &ModuleInfo{}
+	res, err := mod.ExportedFunction(exportABI).Call(ctx)
+	if err != nil {
+		return nil, domain.NewValidationError("openrisk_abi failed: " + err.Error())
+	}
+	info.ABI = int(api.DecodeI32(res[0]))
+	if info.ABI != ABIVersion {
+		return nil, domain.NewValidationError(fmt.Sprintf("module reports ABI %d; this host implements ABI %d", info.ABI, ABIVersion))
+	}
+	for _, hook := range domain.PluginHooks {
+		if fn := mod.ExportedFunction(string(hook)); fn != nil {
+			if err := checkSignature(fn.Definition(), string(hook),
+				[]api.ValueType{api.ValueTypeI32, api.ValueTypeI32}, []api.ValueType{api.ValueTypeI64}); err != nil {
+				return nil, err
+			}
+			info.Hooks = append(info.Hooks, hook)
+		}
+	}
+	return info, nil
+}
+
+// Invoke runs one hook and returns its raw output.
+func (h *Host) Invoke(ctx context.Context, module []byte, call Call) (*Result, error) {
+	lim := call.Limits.withDefaults()
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, lim.Timeout)
+	defer cancel()
+
+	sess := &session{host: h, grants: call.Grants}
+	res := &Result{}
+	finish := func(err error) (*Result, error) {
+		res.Logs, res.HTTPCalls, res.Duration = sess.logs, sess.httpCalls, time.Since(start)
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = ErrTimeout
+		}
+		return res, err
+	}
+
+	mod, closeRT, err := h.instantiate(ctx, module, lim, sess)
+	if err != nil {
+		return finish(err)
+	}
+	defer closeRT()
+	fn := mod.ExportedFunction(string(call.Hook))
+	if fn == nil {
+		return finish(domain.NewValidationError(fmt.Sprintf("plugin does not implement %s", call.Hook)))
+	}
+	ptr, err := sess.write(ctx, mod, call.Input)
+	if err != nil {
+		return finish(err)
+	}
+	out, err := fn.Call(ctx, uint64(ptr), uint64(len(call.Input)))
+	if err != nil {
+		return finish(fmt.Errorf("plugin trapped: %w", err))
+	}
+	res.Output, err = sess.read(mod, out[0], maxOutputBytes)
+	return finish(err)
+}
+
+// instantiate builds a runtime bounded by lim, links WASI and the host module
+// for sess, and instantiates the module. The returned func closes the runtime.
+func (h *Host) instantiate(ctx context.Context, module []byte, lim Limits, sess *session) (api.Module, func(), error) {
+	cfg := wazero.NewRuntimeConfig().
+		WithCompilationCache(h.cache).
+		WithMemoryLimitPages(lim.MemoryPages).
+		WithCloseOnContextDone(true)
+	rt := wazero.NewRuntimeWithConfig(ctx, cfg)
+	closeRT := func() { _ = rt.Close(context.Background()) }
+
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, rt); err != nil {
+		closeRT()
+		return nil, nil, fmt.Errorf("failed to link WASI: %w", err)
+	}
+	if _, err := rt.NewHostModuleBuilder(HostModule).
+		NewFunctionBuilder().WithFunc(sess.log).Export("log").
+		NewFunctionBuilder().WithFunc(sess.httpRequest).Export("http_request").
+		NewFunctionBuilder().WithFunc(sess.secretGet).Export("secret_get").
+		Instantiate(ctx); err != nil {
+		closeRT()
+		return nil, nil, fmt.Errorf("failed to link host functions: %w", err)
+	}
+
+	compiled, err := rt.CompileModule(ctx, module)
+	if err != nil {
+		closeRT()
+		return nil, nil, domain.NewValidationError("module does not load: " + err.Error())
+	}
+	if err := checkModule(compiled); err != nil {
+		closeRT()
+		return nil, nil, err
+	}
+	mod, err := rt.InstantiateModule(ctx, compiled, wazero.NewModuleConfig().
+		WithName("plugin").
+		WithStartFunctions("_initialize").
+		WithSysWalltime().
+		WithSysNanotime().
+		WithRandSource(rand.Reader))
+	if err != nil {
+		closeRT()
+		return nil, nil, fmt.Errorf("module does not start: %w", err)
+	}
+	return mod, closeRT, nil
+}
+
+// checkModule refuses imports from anywhere but the host and WASI, and a
+// module missing the mandatory exports.
+func checkModule(m wazero.CompiledModule) error {
+	for _, fn := range m.ImportedFunctions() {
+		mod, name, _ := fn.Import()
+		if mod != HostModule && mod != wasi_snapshot_preview1.ModuleName {
+			return domain.NewValidationError(fmt.Sprintf("module imports %s.%s, which this host does not provide", mod, name))
+		}
+	}
+	if _, ok := m.ExportedMemories()["memory"]; !ok {
+		return domain.NewValidationError("module does not export its memory")
+	}
+	exports := m.ExportedFunctions()
+	abi, ok := exports[exportABI]
+	if !ok {
+		return domain.NewValidationError("module does not export " + exportABI)
+	}
+	if err := checkSignature(abi, exportABI, nil, []api.ValueType{api.ValueTypeI32}); err != nil {
+		return err
+	}
+	alloc, ok := exports[exportAlloc]
+	if !ok {
+		return domain.NewValidationError("module does not export " + exportAlloc)
+	}
+	return checkSignature(alloc, exportAlloc, []api.ValueType{api.ValueTypeI32}, []api.ValueType{api.ValueTypeI32})
+}
+
+func checkSignature(def api.FunctionDefinition, name string, params, results []api.ValueType) error {
+	if !bytes.Equal(def.ParamTypes(), params) || !bytes.Equal(def.ResultTypes(), results) {
+		return domain.NewValidationError(fmt.Sprintf("%s has the wrong signature for ABI %d", name, ABIVersion))
+	}
+	return nil
+}
+
+// session is the state of one invocation, reachable from the host functions.
+type session struct {
+	host      *Host
+	grants    Grants
+	logs      []string
+	httpCalls int
+}
+
+// write copies data into a buffer from the module's allocator.
+func (s *session) write(ctx context.Context, mod api.Module, data []byte) (uint32, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+	res, err := mod.ExportedFunction(exportAlloc).Call(ctx, uint64(len(data)))
+	if err != nil {
+		return 0, fmt.Errorf("openrisk_alloc failed: %w", err)
+	}
+	ptr := api.DecodeU32(res[0])
+	if !mod.Memory().Write(ptr, data) {
+		return 0, fmt.Errorf("openrisk_alloc returned an out-of-range buffer")
+	}
+	return ptr, nil
+}
+
+// read copies a packed ptr<<32|len buffer out of the module.
+func (s *session) read(mod api.Module, packed uint64, limit int) ([]byte, error) {
+	ptr, size := uint32(packed>>32), uint32(packed)
+	if size == 0 {
+		return nil, nil
+	}
+	if int(size) > limit {
+		return nil, fmt.Errorf("plugin returned %d bytes; the limit is %d", size, limit)
+	}
+	view, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		return nil, fmt.Errorf("plugin returned an out-of-range buffer")
+	}
+	return bytes.Clone(view), nil
+}
+
+// pack writes data back into the module and returns its packed location.
+func (s *session) pack(ctx context.Context, mod api.Module, data []byte) uint64 {
+	ptr, err := s.write(ctx, mod, data)
+	if err != nil || len(data) == 0 {
+		return 0
+	}
+	return uint64(ptr)<<32 | uint64(len(data))
+}
+
+func (s *session) log(_ context.Context, mod api.Module, ptr, size uint32) {
+	if len(s.logs) >= maxLogLines {
+		return
+	}
+	if size > maxLogLine {
+		size = maxLogLine
+	}
+	view, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		return
+	}
+	line := string(view)
+	for _, v := range s.grants.Secrets {
+		if v != "" {
+			line = strings.ReplaceAll(line, v, "[redacted]")
+		}
+	}
+	s.logs = append(s.logs, line)
+}
+
+func (s *session) secretGet(ctx context.Context, mod api.Module, ptr, size uint32) uint64 {
+	name, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		return 0
+	}
+	v, ok := s.grants.Secrets[string(name)]
+	if !ok {
+		return 0
+	}
+	return s.pack(ctx, mod, []byte(v))
+}
+
+func (s *session) httpRequest(ctx context.Context, mod api.Module, ptr, size uint32) uint64 {
+	resp := s.doHTTP(ctx, mod, ptr, size)
+	out, _ := json.Marshal(resp)
+	return s.pack(ctx, mod, out)
+}
+
+func (s *session) doHTTP(ctx context.Context, mod api.Module, ptr, size uint32) HTTPResponse {
+	raw, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		return HTTPResponse{Error: "request is out of range"}
+	}
+	var in HTTPRequest
+	if err := json.Unmarshal(raw, &in); err != nil {
+		return HTTPResponse{Error: "request is not valid JSON"}
+	}
+	if s.httpCalls >= maxHTTPCalls {
+		return HTTPResponse{Error: fmt.Sprintf("at most %d requests per run", maxHTTPCalls)}
+	}
+	s.httpCalls++
+
+	u, err := url.Parse(in.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return HTTPResponse{Error: "url must be an absolute http(s) URL"}
+	}
+	if !HostAllowed(u.Hostname(), s.grants.AllowedHosts) {
+		return HTTPResponse{Error: fmt.Sprintf("host %s is not granted to this plugin", u.Hostname())}
+	}
+	method := strings.ToUpper(in.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), strings.NewReader(in.Body))
+	if err != nil {
+		return HTTPResponse{Error: err.Error()}
+	}
+	for k, v := range in.Headers {
+		req.Header.Set(k, v)
+	}
+
+	client := *s.host.client
+	client.CheckRedirect = func(r *http.Request, via []*http.Request) error {
+		if len(via) >= 5 {
+			return errors.New("too many redirects")
+		}
+		if !HostAllowed(r.URL.Hostname(), s.grants.AllowedHosts) {
+			return fmt.Errorf("redirect to %s, which is not granted", r.URL.Hostname())
+		}
+		return nil
+	}
+	r, err := client.Do(req)
+	if err != nil {
+		return HTTPResponse{Error: err.Error()}
+	}
+	defer r.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxResponseBytes+1))
+	if err != nil {
+		return HTTPResponse{Error: err.Error()}
+	}
+	if len(body) > maxResponseBytes {
+		return HTTPResponse{Error: fmt.Sprintf("response exceeds %d bytes", maxResponseBytes)}
+	}
+	headers := make(map[string]string, len(r.Header))
+	for k := range r.Header {
+		headers[k] = r.Header.Get(k)
+	}
+	return HTTPResponse{Status: r.StatusCode, Headers: headers, Body: string(body)}
+}
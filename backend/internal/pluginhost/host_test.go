@@ -0,0 +1,336 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package pluginhost
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// ---------------------------------------------------------------------------
+// A minimal WebAssembly assembler, enough to build ABI-1 test modules without
+// a toolchain. Imports are openrisk.http_request (func 0), secret_get (1) and
+// log (2); openrisk_abi (3) and openrisk_alloc (4) follow, then the hooks.
+// Data lives at dataOffset; the bump allocator starts at heapStart.
+// ---------------------------------------------------------------------------
+
+const (
+	dataOffset = 1024
+	heapStart  = 8192
+
+	fnHTTP   = 0
+	fnSecret = 1
+	fnLog    = 2
+)
+
+func uleb(v uint64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			out = append(out, b|0x80)
+			continue
+		}
+		return append(out, b)
+	}
+}
+
+func sleb(v int64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func vec(items ...[]byte) []byte {
+	out := uleb(uint64(len(items)))
+	for _, it := range items {
+		out = append(out, it...)
+	}
+	return out
+}
+
+func name(s string) []byte { return append(uleb(uint64(len(s))), s...) }
+
+func section(id byte, body []byte) []byte {
+	return append(append([]byte{id}, uleb(uint64(len(body)))...), body...)
+}
+
+func cat(parts ...[]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+const (
+	i32 = 0x7f
+	i64 = 0x7e
+)
+
+// Function types: 0 (i32,i32)->i64, 1 ()->i32, 2 (i32)->i32, 3 (i32,i32)->().
+var types = vec(
+	[]byte{0x60, 2, i32, i32, 1, i64},
+	[]byte{0x60, 0, 1, i32},
+	[]byte{0x60, 1, i32, 1, i32},
+	[]byte{0x60, 2, i32, i32, 0},
+)
+
+// Instruction helpers.
+func i32c(v int32) []byte { return append([]byte{0x41}, sleb(int64(v))...) }
+func i64c(v int64) []byte { return append([]byte{0x42}, sleb(v)...) }
+func call(fn int) []byte  { return append([]byte{0x10}, uleb(uint64(fn))...) }
+
+// packed returns the body fragment pushing ptr<<32|len of a data string.
+func packed(off, n int) []byte { return i64c(int64(off)<<32 | int64(n)) }
+
+// echo returns its input unchanged.
+var echo = []byte{0x20, 0, 0xad, 0x42, 32, 0x86, 0x20, 1, 0xad, 0x84}
+
+// spin never returns.
+var spin = cat([]byte{0x03, 0x40, 0x0c, 0x00, 0x0b}, i64c(0))
+
+type testModule struct {
+	abi      int32
+	minPages int
+	data     string
+	hooks    map[domain.PluginHook][]byte
+}
+
+func (m testModule) build() []byte {
+	if m.abi == 0 {
+		m.abi = ABIVersion
+	}
+	if m.minPages == 0 {
+		m.minPages = 1
+	}
+	imports := vec(
+		cat(name(HostModule), name("http_request"), []byte{0x00, 0}),
+		cat(name(HostModule), name("secret_get"), []byte{0x00, 0}),
+		cat(name(HostModule), name("log"), []byte{0x00, 3}),
+	)
+	var hookNames []domain.PluginHook
+	for _, h := range domain.PluginHooks {
+		if _, ok := m.hooks[h]; ok {
+			hookNames = append(hookNames, h)
+		}
+	}
+	funcTypes := [][]byte{{1}, {2}}
+	exports := [][]byte{
+		cat(name("memory"), []byte{0x02, 0}),
+		cat(name(exportABI), []byte{0x00, 3}),
+		cat(name(exportAlloc), []byte{0x00, 4}),
+	}
+	body := func(code []byte) []byte {
+		// one i64 scratch local, after the parameters
+		fn := cat([]byte{1, 1, i64}, code, []byte{0x0b})
+		return append(uleb(uint64(len(fn))), fn...)
+	}
+	bodies := [][]byte{
+		body(i32c(m.abi)),
+		// alloc: old := heap; heap += size; return old
+		body([]byte{0x23, 0, 0x23, 0, 0x20, 0, 0x6a, 0x24, 0}),
+	}
+	for i, h := range hookNames {
+		funcTypes = append(funcTypes, []byte{0})
+		exports = append(exports, cat(name(string(h)), []byte{0x00}, uleb(uint64(5+i))))
+		bodies = append(bodies, body(m.hooks[h]))
+	}
+	return cat(
+		[]byte{0x00, 'a', 's', 'm', 1, 0, 0, 0},
+		section(1, types),
+		section(2, imports),
+		section(3, vec(funcTypes...)),
+		section(5, vec(cat([]byte{0x00}, uleb(uint64(m.minPages))))),
+		section(6, vec(cat([]byte{i32, 0x01}, i32c(heapStart), []byte{0x0b}))),
+		section(7, vec(exports...)),
+		section(10, vec(bodies...)),
+		section(11, vec(cat([]byte{0x00}, i32c(dataOffset), []byte{0x0b}, name(m.data)))),
+	)
+}
+
+// ---------------------------------------------------------------------------
+
+func TestInspect_ReportsHooksAndEnforcesABI(t *testing.T) {
+	h := NewHost()
+	ctx := context.Background()
+
+	info, err := h.Inspect(ctx, testModule{hooks: map[domain.PluginHook][]byte{
+		domain.HookPullFindings:     echo,
+		domain.HookTransformWebhook: echo,
+	}}.build())
+	require.NoError(t, err)
+	assert.Equal(t, ABIVersion, info.ABI)
+	assert.Equal(t, []domain.PluginHook{domain.HookPullFindings, domain.HookTransformWebhook}, info.Hooks)
+
+	_, err = h.Inspect(ctx, testModule{abi: 2}.build())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ABI 2")
+
+	_, err = h.Inspect(ctx, []byte("not wasm"))
+	assert.Error(t, err)
+}
+
+func TestInvoke_InputOutputAndData(t *testing.T) {
+	h := NewHost()
+	out := `{"findings":[{"title":"CVE-2024-0001 in nginx","severity":"high"}]}`
+	mod := testModule{data: out, hooks: map[domain.PluginHook][]byte{
+		domain.HookPullFindings:     packed(dataOffset, len(out)),
+		domain.HookTransformWebhook: echo,
+	}}.build()
+
+	res, err := h.Invoke(context.Background(), mod, Call{Hook: domain.HookPullFindings, Input: []byte(`{}`)})
+	require.NoError(t, err)
+	assert.JSONEq(t, out, string(res.Output))
+
+	res, err = h.Invoke(context.Background(), mod, Call{Hook: domain.HookTransformWebhook, Input: []byte(`{"body":"x"}`)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"body":"x"}`, string(res.Output), "the hook reads the input the host wrote")
+
+	_, err = h.Invoke(context.Background(), mod, Call{Hook: domain.HookCreateTicket})
+	assert.Error(t, err, "a hook the module does not export")
+}
+
+func TestInvoke_SecretsOnlyWhenGranted(t *testing.T) {
+	h := NewHost()
+	mod := testModule{data: "api_key", hooks: map[domain.PluginHook][]byte{
+		domain.HookPullFindings: cat(i32c(dataOffset), i32c(7), call(fnSecret)),
+		// log(secret_get("api_key")), then return nothing
+		domain.HookEmitAssets: cat(
+			i32c(dataOffset), i32c(7), call(fnSecret), []byte{0x21, 2},
+			[]byte{0x20, 2}, i64c(32), []byte{0x88, 0xa7}, []byte{0x20, 2, 0xa7},
+			call(fnLog), i64c(0)),
+	}}.build()
+
+	res, err := h.Invoke(context.Background(), mod, Call{Hook: domain.HookPullFindings,
+		Grants: Grants{Secrets: map[string]string{"api_key": "s3cr3t"}}})
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(res.Output))
+
+	res, err = h.Invoke(context.Background(), mod, Call{Hook: domain.HookPullFindings,
+		Grants: Grants{Secrets: map[string]string{"other": "x"}}})
+	require.NoError(t, err)
+	assert.Empty(t, res.Output, "an ungranted secret reads as nothing")
+
+	res, err = h.Invoke(context.Background(), mod, Call{Hook: domain.HookEmitAssets,
+		Grants: Grants{Secrets: map[string]string{"api_key": "s3cr3t"}}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"[redacted]"}, res.Logs, "granted secret values never reach the run log")
+}
+
+func TestInvoke_HTTPOnlyToGrantedHosts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer t", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	req, _ := json.Marshal(HTTPRequest{Method: "GET", URL: srv.URL + "/findings", Headers: map[string]string{"Authorization": "Bearer t"}})
+	mod := testModule{data: string(req), hooks: map[domain.PluginHook][]byte{
+		domain.HookPullFindings: cat(i32c(dataOffset), i32c(int32(len(req))), call(fnHTTP)),
+	}}.build()
+	invoke := func(h *Host, hosts ...string) HTTPResponse {
+		res, err := h.Invoke(context.Background(), mod, Call{Hook: domain.HookPullFindings, Grants: Grants{AllowedHosts: hosts}})
+		require.NoError(t, err)
+		var out HTTPResponse
+		require.NoError(t, json.Unmarshal(res.Output, &out))
+		return out
+	}
+
+	got := invoke(NewHost().AllowPrivateNetworks(true), u.Hostname())
+	assert.Equal(t, 200, got.Status)
+	assert.Equal(t, `{"ok":true}`, got.Body)
+
+	got = invoke(NewHost().AllowPrivateNetworks(true), "api.example.com")
+	assert.Zero(t, got.Status)
+	assert.Contains(t, got.Error, "not granted")
+
+	got = invoke(NewHost(), u.Hostname())
+	assert.Zero(t, got.Status, "a granted host on a loopback address is still refused")
+	assert.Contains(t, got.Error, "not reachable from plugins")
+}
+
+func TestInvoke_TimeAndMemoryLimits(t *testing.T) {
+	h := NewHost()
+	mod := testModule{hooks: map[domain.PluginHook][]byte{domain.HookPullFindings: spin}}.build()
+	start := time.Now()
+	_, err := h.Invoke(context.Background(), mod, Call{Hook: domain.HookPullFindings, Limits: Limits{Timeout: 100 * time.Millisecond}})
+	assert.True(t, errors.Is(err, ErrTimeout), "got %v", err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	big := testModule{minPages: 64, hooks: map[domain.PluginHook][]byte{domain.HookPullFindings: echo}}.build()
+	_, err = h.Invoke(context.Background(), big, Call{Hook: domain.HookPullFindings, Limits: Limits{MemoryPages: 16}})
+	assert.Error(t, err, "a module needing more memory than granted does not load")
+	_, err = h.Invoke(context.Background(), big, Call{Hook: domain.HookPullFindings, Limits: Limits{MemoryPages: 64}})
+	assert.NoError(t, err)
+}
+
+func TestBundle_SignedRoundTrip(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	mod := testModule{hooks: map[domain.PluginHook][]byte{domain.HookPullFindings: echo}}.build()
+	m := Manifest{Name: "acme-scanner", Version: "1.0.0", Publisher: "Acme", Hooks: []domain.PluginHook{domain.HookPullFindings},
+		Hosts: []string{"api.acme.test"}, Secrets: []string{"api_key"}}
+
+	data, err := WriteBundle(m, mod, "acme-2026", priv)
+	require.NoError(t, err)
+	b, err := ReadBundle(data)
+	require.NoError(t, err)
+	assert.Equal(t, "acme-scanner", b.Manifest.Name)
+	assert.Equal(t, mod, b.Module)
+
+	keys, err := ParseKeyring("acme-2026:" + base64.StdEncoding.EncodeToString(pub))
+	require.NoError(t, err)
+	require.NoError(t, keys.Verify(b))
+
+	_, other, _ := ed25519.GenerateKey(rand.Reader)
+	forged, err := WriteBundle(m, mod, "acme-2026", other)
+	require.NoError(t, err)
+	fb, err := ReadBundle(forged)
+	require.NoError(t, err)
+	assert.Error(t, keys.Verify(fb), "a bundle signed with another key under a trusted id")
+
+	b.KeyID = "unknown"
+	assert.Error(t, keys.Verify(b))
+	assert.Error(t, Keyring{}.Verify(b), "no trusted keys configured")
+
+	swapped := *b
+	swapped.Module = append([]byte(nil), mod...)
+	swapped.Module[len(swapped.Module)-1] ^= 0xff
+	assert.NotEqual(t, b.Manifest.WasmSHA256, swapped.Digest(), "the pinned digest catches a swapped module")
+}
+
+func TestHostAllowed(t *testing.T) {
+	hosts := []string{"api.acme.test", "*.tenable.test"}
+	assert.True(t, HostAllowed("api.acme.test", hosts))
+	assert.True(t, HostAllowed("API.ACME.TEST.", hosts))
+	assert.True(t, HostAllowed("eu.cloud.tenable.test", hosts))
+	assert.False(t, HostAllowed("tenable.test", hosts))
+	assert.False(t, HostAllowed("acme.test", hosts))
+	assert.False(t, HostAllowed("api.acme.test.evil.test", hosts))
+}
@@ -27,6 +27,10 @@
 // handler/mitigation_autocomplete_isolation_test. Detecting the shape statically
 // would mean parsing each handler's body struct, which is a larger change than
 // the gate warrants; until then, body-addressed writes need a probe by hand.
+// The GraphQL API (POST/GET /graphql) is the largest such surface: every id it
+// reads is in the query document. It takes the tenant from the session only,
+// and the read repository scopes each query, both sides of a join included —
+// see repository/gorm_graph_repository_test (TestGraphRepo_RelationsNeverCrossTenants).
 package isolation
 
 import "strings"
@@ -236,6 +240,161 @@ var decisions = []Decision{
 		"application/assetschema: same as above — deletes only the caller's tenant row"},
 	{"/api/v1/attack-surface/topology/{id}/compromise-chain", Covered,
 		"application/asset topology: the origin asset is loaded with GetByID(id, tenant) FIRST, so another tenant's id is a 404 before any graph is walked"},
+
+	// --- Business impact analysis ---------------------------------------------
+	// {id} is an asset id. Writes load the asset with GetByID(id, tenant) before
+	// attaching anything; reads and deletes key on (tenant_id, asset_id).
+	{"/api/v1/bia/*", Covered,
+		"application/bia TestSaveAnalysis_ValidatesAndIsTenantScoped (another tenant's asset is a 404) + repository TestBIARepo_AnalysesAndCapabilitiesTenantScoped"},
+
+	// --- Risk appetite --------------------------------------------------------
+	{"/api/v1/risk-appetite/statements/{id}", Covered,
+		"application/appetite TestSaveStatement_ValidatesScopeAndUniqueness (another tenant's statement is a 404) + repository TestRiskAppetiteRepo_TenantScopedAndClearsTolerances"},
+
+	// --- Key risk indicators --------------------------------------------------
+	{"/api/v1/kris/{id}", Covered,
+		"application/kri TestPushValue_RejectsFutureAndQueryKRIs (another tenant cannot feed the KRI) + repository TestKRIRepo_TenantScopedWithHistory"},
+	{"/api/v1/kris/{id}/values", Covered,
+		"application/kri TestPushValue_RejectsFutureAndQueryKRIs: the KRI is loaded by (tenant, id) before any value is recorded"},
+	{"/api/v1/kris/{id}/values/csv", Covered,
+		"same (tenant, id) load as /values; repository RecordValues refuses a value whose tenant differs from the KRI's"},
+	{"/api/v1/kris/{id}/refresh", Covered,
+		"KRI loaded by (tenant, id); the evaluator binds the KRI's own tenant_id — repository TestKRIQueryEvaluator_AggregatesTenantRowsOnly"},
+	{"/api/v1/risks/{id}/kris", Covered,
+		"application/kri TestTrends_FiltersByRiskAndOrdersRedFirst: lists only the caller's tenant's KRIs, then filters by risk id"},
+
+	// --- Vendor risk ----------------------------------------------------------
+	// The /vendor-portal routes take no id: the link token resolves the
+	// assessment, and its tenant, by hash.
+	{"/api/v1/vendors/{id}", Covered,
+		"application/vendor TestSaveVendor_DerivesTierAndReschedules (another tenant's vendor is a 404) + repository TestVendorRepo_TenantScoped"},
+	{"/api/v1/vendors/{id}/assessments", Covered,
+		"application/vendor TestSendAssessment_DeliveryAndSingleOpenLink: the vendor is loaded by (tenant, id) before a link is minted"},
+	{"/api/v1/vendor-assessments/{id}/revoke", Covered,
+		"application/vendor TestSendAssessment_DeliveryAndSingleOpenLink: another tenant's assessment is a 404"},
+	{"/api/v1/vendor-questionnaires/{id}", Covered,
+		"application/vendor TestTemplates_BuiltInsAreReadOnly (another tenant's questionnaire is a 404) + repository TestVendorRepo_Templates"},
+
+	// --- Risk scenarios -------------------------------------------------------
+	// Built-in scenario ids are shared by every tenant on purpose: the library
+	// is code, and reads of it expose nothing a tenant owns.
+	{"/api/v1/risk-scenarios/{id}", Covered,
+		"application/scenario TestSaveScenario_VersionsAndGuards (another tenant's scenario is a 404) + repository TestRiskScenarioRepo_TenantScoped"},
+	{"/api/v1/risk-scenarios/{id}/instantiate", Covered,
+		"application/scenario TestInstantiate_Rejections: assets are loaded by (tenant, id), another tenant's asset is a 404"},
+
+	// --- Control testing ------------------------------------------------------
+	{"/api/v1/compliance/controls/{id}/testing", Covered,
+		"application/controltest TestCreatePlan_OnePerKindAndDueAtOnce (another tenant's control is a 404)"},
+	{"/api/v1/compliance/controls/{id}/test-plans", Covered,
+		"application/controltest TestCreatePlan_OnePerKindAndDueAtOnce (another tenant's control is a 404)"},
+	{"/api/v1/control-test-plans/{id}", Covered,
+		"repository TestControlTestRepo_TenantScoped: plans are read, updated and deleted by (tenant, id)"},
+	{"/api/v1/control-test-plans/{id}/tests", Covered,
+		"repository TestControlTestRepo_TenantScoped: a test cannot be recorded against another tenant's plan"},
+	{"/api/v1/risks/{id}/residual", Covered,
+		"application/controltest TestResidual_DerivationAndApply (another tenant's risk is a 404) + repository TestControlTestRepo_LinkedRisksAndResidual"},
+
+	// --- Register snapshots ---------------------------------------------------
+	{"/api/v1/register-snapshots/{id}", Covered,
+		"repository TestRegisterSnapshotRepo_AsOfAndTenantScope: snapshots are read by (tenant, id)"},
+	{"/api/v1/register-snapshots/{id}/risks", Covered,
+		"repository TestRegisterSnapshotRepo_AsOfAndTenantScope: rows are read by (tenant, snapshot), another tenant's snapshot is empty"},
+
+	// --- Bowtie analysis ------------------------------------------------------
+	{"/api/v1/risks/{id}/bowtie", Covered,
+		"repository TestBowtieRepo_TenantScoped: bowties, risks and linked controls are read and written by tenant + application/bowtie TestBowtie_SaveRejectsForeignLinks"},
+	{"/api/v1/risks/{id}/bowtie/pdf", Covered,
+		"repository TestBowtieRepo_TenantScoped: the figure is drawn from the tenant-scoped graph"},
+	{"/api/v1/risks/{id}/bowtie/apply-suggestion", Covered,
+		"repository TestBowtieRepo_TenantScoped: another tenant cannot write the figures"},
+
+	// --- Group hierarchy -----------------------------------------------------
+	// Links are the one record two tenants share; each route finds the link by
+	// id and then insists the caller's organization is a side of it.
+	{"/api/v1/group/links/{id}", Covered,
+		"application/group TestGroup_LinkNeedsConsentAndStaysATree: a link is revoked only by one of its two sides"},
+	{"/api/v1/group/links/{id}/accept", Covered,
+		"application/group TestGroup_LinkNeedsConsentAndStaysATree: the parent cannot accept on the child's behalf"},
+
+	// --- Mitigation programmes ------------------------------------------------
+	// Capacities are keyed by (tenant, user): setting one for a user id writes
+	// the caller's tenant's row and reads nothing of another's.
+	{"/api/v1/mitigation-programmes/{id}", Covered,
+		"application/programme TestProgramme_TenantScoped (another tenant's programme is a 404) + repository TestMitigationProgrammeRepo_TenantScoped"},
+	{"/api/v1/mitigation-programmes/{id}/projection", Covered,
+		"application/programme TestProgramme_TenantScoped: the programme is loaded by (tenant, id) before any risk is read"},
+	{"/api/v1/mitigation-programmes/{id}/risks", Covered,
+		"application/programme TestProgramme_TenantScoped: another tenant's risk cannot be linked"},
+	{"/api/v1/mitigation-programmes/{id}/risks/{id}", Covered,
+		"repository TestMitigationProgrammeRepo_TenantScoped: links are deleted by (tenant, programme, risk)"},
+	{"/api/v1/mitigation-programmes/{id}/controls", Covered,
+		"application/programme LinkControl loads the programme and the control by (tenant, id); repository GetControl is tenant-scoped"},
+	{"/api/v1/mitigation-programmes/{id}/controls/{id}", Covered,
+		"repository DeleteControlLink deletes by (tenant, programme, control)"},
+	{"/api/v1/mitigation-programmes/{id}/actions", Covered,
+		"application/programme TestProgramme_TenantScoped: no action can be added to another tenant's programme"},
+	{"/api/v1/programme-actions/{id}", Covered,
+		"repository TestMitigationProgrammeRepo_TenantScoped: actions are read by (tenant, id)"},
+	{"/api/v1/programme-capacities/{id}", Covered,
+		"repository TestMitigationProgrammeRepo_TenantScoped: capacities are upserted on (tenant, user)"},
+	{"/api/v1/risks/{id}/programme-projection", Covered,
+		"application/programme RiskProjection reads the risk by (tenant, id); repository TestMitigationProgrammeRepo_TenantScoped: another tenant's risk reads nothing"},
+
+	// Import wizard: a batch is loaded by (tenant, id) before any
+	// step, and its rows, lookups and writes are all keyed on the batch tenant.
+	{"/api/v1/imports/{id}", Covered,
+		"repository TestImportRepo_ApplyAndRollback: another tenant's batch and rows read nothing"},
+	{"/api/v1/imports/{id}/dry-run", Covered,
+		"repository TestImportRepo_ApplyAndRollback: lookups (existing records, frameworks) are tenant-scoped"},
+	{"/api/v1/imports/{id}/apply", Covered,
+		"application/dataimport Apply loads the batch by (tenant, id); repository Apply writes and records rows under the batch tenant"},
+	{"/api/v1/imports/{id}/rollback", Covered,
+		"repository TestImportRepo_ApplyAndRollback: rollback reads records and restores rows by (tenant, id)"},
+	{"/api/v1/import-profiles/{id}", Covered,
+		"repository TestImportRepo_ProfileNameIsUpsertKey: another tenant lists no profile; profiles are read and deleted by (tenant, id)"},
+
+	// Connector plugins: packages, installs and runs are read and written by
+	// (tenant, id); a run loads the install, and through it the package, under
+	// the caller's tenant.
+	{"/api/v1/plugins/{id}", Covered,
+		"repository TestPluginRepo_TenantScopedAndRunLog: another tenant's package reads nothing and cannot be deleted"},
+	{"/api/v1/plugin-installs/{id}", Covered,
+		"repository TestPluginRepo_TenantScopedAndRunLog: another tenant's install reads nothing, cannot be saved over or deleted"},
+	{"/api/v1/plugin-installs/{id}/run", Covered,
+		"application/plugins Run loads the install by (tenant, id); TestInstall_GrantsAreASubsetOfTheRequest: another tenant's install is not found"},
+	{"/api/v1/plugin-installs/{id}/runs", Covered,
+		"repository TestPluginRepo_TenantScopedAndRunLog: runs are listed by (tenant, install)"},
+
+	// Outbound webhooks: subscriptions and their delivery log are read and
+	// written by (tenant, id); a redelivery copies a row the caller's tenant
+	// could read. The event catalogue is static and shared by every tenant.
+	{"/api/v1/webhook-events/{id}/schema", PublicByDesign,
+		"embedded JSON Schema of an event type; the same documents for every tenant, no tenant data"},
+	{"/api/v1/webhooks/{id}", Covered,
+		"repository TestWebhookRepo_TenantScopedQueueAndClaims: another tenant's subscription reads nothing and cannot be deleted"},
+	{"/api/v1/webhooks/{id}/rotate-secret", Covered,
+		"application/webhooks RotateSecret loads the subscription by (tenant, id); TestWebhookRepo_TenantScopedQueueAndClaims: another tenant reads nothing"},
+	{"/api/v1/webhooks/{id}/deliveries", Covered,
+		"repository TestWebhookRepo_TenantScopedQueueAndClaims: deliveries are listed by (tenant, subscription)"},
+	{"/api/v1/webhook-deliveries/{id}", Covered,
+		"repository TestWebhookRepo_TenantScopedQueueAndClaims: another tenant's delivery reads nothing"},
+	{"/api/v1/webhook-deliveries/{id}/redeliver", Covered,
+		"application/webhooks TestRedeliver_SameEventAsANewDelivery: another tenant cannot redeliver it"},
+
+	// SIEM export destinations: every read and write is by (tenant, id), and
+	// the export itself only reads the destination's own tenant.
+	{"/api/v1/siem-destinations/{id}", Covered,
+		"repository TestSIEMRepo_TenantScopedCursorsAndClaims: another tenant's destination reads nothing and cannot be updated or deleted"},
+	{"/api/v1/siem-destinations/{id}/backfill", Covered,
+		"application/siem TestBackfill_RewindsTheCursor: another tenant's destination cannot be rewound"},
+
+	// Record-level access policies: policies and member attributes are read and
+	// written by (tenant, id); a user of another tenant is not a subject.
+	{"/api/v1/access-policies/{id}", Covered,
+		"repository TestAccessPolicyRepo_TenantScoped: another tenant's policy reads nothing and cannot be updated or deleted"},
+	{"/api/v1/access-policies/subjects/{id}", Covered,
+		"repository TestAccessPolicyRepo_TenantScoped: a member of another tenant is not a subject; application/accesspolicy TestScope_NonMemberUsesTheTokenRole: attributes cannot be set on a non-member"},
 }
 
 // Normalise rewrites a concrete route path into registry pattern form, so
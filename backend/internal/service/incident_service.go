@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/opendefender/openrisk/internal/domain"
 	"github.com/opendefender/openrisk/internal/infrastructure/database"
 	"gorm.io/datatypes"
@@ -36,11 +37,19 @@ type PostMortemGate interface {
 	PublishedReviewExists(ctx context.Context, tenantID string, incidentID uint) (bool, string)
 }
 
+// IncidentEventPublisher announces declarations to webhook subscribers.
+// Satisfied structurally by application/webhooks.Service. Optional and
+// best-effort, like the notifier.
+type IncidentEventPublisher interface {
+	Publish(ctx context.Context, tenantID uuid.UUID, eventType domain.WebhookEventType, data any) error
+}
+
 // IncidentService handles incident management operations
 type IncidentService struct {
 	db       *gorm.DB
 	notifier IncidentNotifier
 	gate     PostMortemGate
+	events   IncidentEventPublisher
 }
 
 // NewIncidentService creates a new incident service
@@ -64,6 +73,12 @@ func (s *IncidentService) WithPostMortemGate(g PostMortemGate) *IncidentService
 	return s
 }
 
+// WithEvents announces each new incident as incident.declared.
+func (s *IncidentService) WithEvents(e IncidentEventPublisher) *IncidentService {
+	s.events = e
+	return s
+}
+
 // CreateIncident creates a new incident
 func (s *IncidentService) CreateIncident(tenantID string, req domain.IncidentCreateRequest) (*domain.Incident, error) {
 	// Validate severity
@@ -129,10 +144,33 @@ func (s *IncidentService) CreateIncident(tenantID string, req domain.IncidentCre
 	if s.notifier != nil {
 		s.notifier.NotifyDeclared(context.Background(), incident)
 	}
+	s.publishDeclared(incident)
 
 	return incident, nil
 }
 
+func (s *IncidentService) publishDeclared(inc *domain.Incident) {
+	if s.events == nil {
+		return
+	}
+	tenantID, err := uuid.Parse(inc.TenantID)
+	if err != nil {
+		return
+	}
+	data := domain.WebhookIncidentDeclaredData{
+		IncidentID:   inc.ID,
+		Title:        inc.Title,
+		Severity:     inc.Severity,
+		IncidentType: inc.IncidentType,
+		Origin:       inc.Origin,
+		RiskIDs:      append([]string{}, inc.RiskIDs...),
+		AssetIDs:     append([]string{}, inc.AssetIDs...),
+	}
+	if err := s.events.Publish(context.Background(), tenantID, domain.WebhookIncidentDeclared, data); err != nil {
+		log.Printf("Warning: failed to publish incident %d to webhooks: %v", inc.ID, err)
+	}
+}
+
 // GetIncident retrieves an incident by ID
 func (s *IncidentService) GetIncident(tenantID string, incidentID uint) (*domain.Incident, error) {
 	var incident domain.Incident
@@ -401,6 +439,7 @@ func (s *IncidentService) CreateIncidentAction(tenantID string, incidentID uint,
 	if err := s.db.Create(action).Error; err != nil {
 		return nil, fmt.Errorf("failed to create action: %w", err)
 	}
+	s.touchIncident(incidentID)
 
 	return action, nil
 }
@@ -439,9 +478,97 @@ func (s *IncidentService) UpdateIncidentAction(tenantID string, actionID uint, s
 		Updates(map[string]interface{}{"status": status, "updated_at": time.Now()}).Error; err != nil {
 		return fmt.Errorf("failed to update action: %w", err)
 	}
+	s.touchIncident(action.IncidentID)
 	return nil
 }
 
+// touchIncident bumps the parent incident's updated_at. An action is part of the
+// incident: anything that watches incidents for change (the TheHive sync's push
+// pass) must see a new or progressed action without reading every action table.
+func (s *IncidentService) touchIncident(incidentID uint) {
+	if err := s.db.Model(&domain.Incident{}).Where("id = ?", incidentID).
+		UpdateColumn("updated_at", time.Now()).Error; err != nil {
+		log.Printf("Warning: failed to touch incident %d: %v", incidentID, err)
+	}
+}
+
+// ListIncidentsUpdatedSince returns a tenant's incidents changed after since,
+// oldest change first — the order a high-water-mark consumer needs.
+func (s *IncidentService) ListIncidentsUpdatedSince(tenantID string, since time.Time, limit int) ([]domain.Incident, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	var incidents []domain.Incident
+	if err := s.db.Where("tenant_id = ? AND updated_at > ?", tenantID, since).
+		Order("updated_at ASC, id ASC").
+		Limit(limit).
+		Find(&incidents).Error; err != nil {
+		return nil, fmt.Errorf("failed to list updated incidents: %w", err)
+	}
+	return incidents, nil
+}
+
+// LinkAssets adds assets to an incident's impact, keeping the ones already
+// there. Additive on purpose: a producer that discovers a host (an observable,
+// a correlation) must never silently drop one an analyst linked by hand.
+func (s *IncidentService) LinkAssets(tenantID string, incidentID uint, assetIDs []string) error {
+	incident, err := s.GetIncident(tenantID, incidentID)
+	if err != nil {
+		return domain.ErrNotFound
+	}
+	merged := append(domain.StringList{}, incident.AssetIDs...)
+	have := make(map[string]bool, len(merged))
+	for _, id := range merged {
+		have[id] = true
+	}
+	added := 0
+	for _, id := range assetIDs {
+		if id == "" || have[id] {
+			continue
+		}
+		have[id] = true
+		merged = append(merged, id)
+		added++
+	}
+	if added == 0 {
+		return nil
+	}
+	if err := s.db.Model(incident).Updates(map[string]interface{}{
+		"asset_ids":  merged,
+		"updated_at": time.Now(),
+	}).Error; err != nil {
+		return fmt.Errorf("failed to link assets: %w", err)
+	}
+	return nil
+}
+
+// OpenIncidentCountsForAsset returns (open, criticalOpen) among the tenant's
+// unresolved incidents that list the asset in their impact. asset_ids is a JSON
+// list, so the match runs here rather than as a dialect-specific containment
+// query; the open set is small by construction.
+func (s *IncidentService) OpenIncidentCountsForAsset(tenantID, assetID string) (int, int, error) {
+	var rows []domain.Incident
+	if err := s.db.Select("id", "severity", "asset_ids").
+		Where("tenant_id = ? AND status NOT IN ?", tenantID, []string{"resolved", "closed"}).
+		Find(&rows).Error; err != nil {
+		return 0, 0, fmt.Errorf("failed to count incidents for asset: %w", err)
+	}
+	open, critical := 0, 0
+	for _, inc := range rows {
+		for _, id := range inc.AssetIDs {
+			if id != assetID {
+				continue
+			}
+			open++
+			if strings.EqualFold(inc.Severity, "critical") {
+				critical++
+			}
+			break
+		}
+	}
+	return open, critical, nil
+}
+
 // CountByOrigin counts a tenant's incidents per origin, for the "where do
 // incidents come from?" page. Returning real counts alongside the catalogue is
 // what stops that page from being a brochure: a source that has never fired
@@ -11,7 +11,7 @@ import "github.com/opendefender/openrisk/internal/domain"
 type ConnectorInfo struct {
 	Source   domain.VulnSource `json:"source"`
 	Label    string            `json:"label"`
-	Category string            `json:"category"`  // network_scanner | edr | cloud
+	Category string            `json:"category"`  // network_scanner | edr | cloud | code
 	Ingest   bool              `json:"ingest"`    // findings can be imported/normalised
 	LivePull bool              `json:"live_pull"` // API polling implemented (vs import-only)
 	Notes    string            `json:"notes"`
@@ -31,5 +31,7 @@ func Connectors() []ConnectorInfo {
 		{domain.VulnSourceAWSInspector, "AWS Inspector", "cloud", true, true, "Import findings or live-pull via SDK."},
 		{domain.VulnSourceAzureDefender, "Microsoft Defender for Cloud", "cloud", true, false, "Import security sub-assessments."},
 		{domain.VulnSourceCrowdStrike, "CrowdStrike Falcon Spotlight", "edr", true, false, "Import combined-vulnerabilities JSON."},
+		{domain.VulnSourceSARIF, "SARIF (code, IaC, containers)", "code", true, false, "Upload SARIF 2.1.0 with `openrisk push`."},
+		{domain.VulnSourceSBOM, "CycloneDX SBOM", "code", true, false, "Upload a CycloneDX JSON SBOM with its vulnerabilities."},
 	}
 }
@@ -0,0 +1,377 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package vulnscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+// Interchange formats an uploaded findings file may be in, besides a scanner's
+// own JSON export (named by its VulnSource).
+const (
+	FormatSARIF     = "sarif"     // SARIF 2.1.0, static analysis and IaC/container scanners
+	FormatCycloneDX = "cyclonedx" // CycloneDX JSON SBOM with a vulnerabilities section
+)
+
+// MaxFileBytes caps an uploaded findings file.
+const MaxFileBytes = 32 << 20
+
+// ParseFile decodes an uploaded findings file into the source it is ingested
+// under and its raw findings, ready for Normalize. A scanner format accepts a
+// JSON array of findings or an object holding one under "findings".
+//
+// A file with nothing to report (a clean SARIF run, an SBOM without
+// vulnerabilities) is valid and yields no findings.
+func ParseFile(format string, data []byte) (domain.VulnSource, []map[string]any, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case FormatSARIF:
+		findings, err := parseSARIF(data)
+		return domain.VulnSourceSARIF, findings, err
+	case FormatCycloneDX, "sbom":
+		findings, err := parseCycloneDX(data)
+		return domain.VulnSourceSBOM, findings, err
+	}
+	src, err := domain.ParseVulnSource(format)
+	if err != nil {
+		return "", nil, domain.NewValidationError(fmt.Sprintf("unsupported format %q: use sarif, cyclonedx or a scanner source", format))
+	}
+	var list []map[string]any
+	if err := json.Unmarshal(data, &list); err == nil {
+		return src, list, nil
+	}
+	var wrapped struct {
+		Findings []map[string]any `json:"findings"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return "", nil, domain.NewValidationError("findings file must be a JSON array or an object with \"findings\"")
+	}
+	return src, wrapped.Findings, nil
+}
+
+// ---- SARIF 2.1.0 ------------------------------------------------------------
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver struct {
+			Name  string      `json:"name"`
+			Rules []sarifRule `json:"rules"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifRule struct {
+	ID               string         `json:"id"`
+	Name             string         `json:"name"`
+	ShortDescription sarifText      `json:"shortDescription"`
+	FullDescription  sarifText      `json:"fullDescription"`
+	Help             sarifText      `json:"help"`
+	Properties       map[string]any `json:"properties"`
+	DefaultConfig    struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           *int              `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifText         `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Fingerprints        map[string]string `json:"fingerprints"`
+	Suppressions        []any             `json:"suppressions"`
+	BaselineState       string            `json:"baselineState"`
+	Properties          map[string]any    `json:"properties"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region struct {
+			StartLine int `json:"startLine"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+}
+
+// parseSARIF flattens every result of every run into a generic finding.
+// Suppressed results and results the tool reports as gone ("absent") are
+// skipped. The rule's security-severity, when the tool sets one (CodeQL,
+// Trivy, Semgrep), is a CVSS-like score and is used as such.
+func parseSARIF(data []byte) ([]map[string]any, error) {
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, domain.NewValidationError("invalid SARIF file: " + err.Error())
+	}
+	if len(log.Runs) == 0 && !strings.HasPrefix(log.Version, "2.") {
+		return nil, domain.NewValidationError("invalid SARIF file: no runs")
+	}
+	var out []map[string]any
+	for _, run := range log.Runs {
+		tool := run.Tool.Driver.Name
+		rules := map[string]*sarifRule{}
+		for i := range run.Tool.Driver.Rules {
+			rules[run.Tool.Driver.Rules[i].ID] = &run.Tool.Driver.Rules[i]
+		}
+		for _, res := range run.Results {
+			if len(res.Suppressions) > 0 || res.BaselineState == "absent" {
+				continue
+			}
+			rule := rules[res.RuleID]
+			if rule == nil && res.RuleIndex != nil && *res.RuleIndex >= 0 && *res.RuleIndex < len(run.Tool.Driver.Rules) {
+				rule = &run.Tool.Driver.Rules[*res.RuleIndex]
+			}
+			ruleID := res.RuleID
+			if ruleID == "" && rule != nil {
+				ruleID = rule.ID
+			}
+
+			level := res.Level
+			title := firstLine(res.Message.Text)
+			var description, help string
+			var score float64
+			if rule != nil {
+				if level == "" {
+					level = rule.DefaultConfig.Level
+				}
+				if t := firstNonEmpty(rule.ShortDescription.Text, rule.Name); t != "" {
+					title = t
+				}
+				description = rule.FullDescription.Text
+				help = rule.Help.Text
+				score = firstFloat(rule.Properties, "security-severity")
+			}
+			if s := firstFloat(res.Properties, "security-severity"); s > 0 {
+				score = s
+			}
+			if title == "" {
+				title = ruleID
+			}
+
+			location := ""
+			if len(res.Locations) > 0 {
+				pl := res.Locations[0].PhysicalLocation
+				location = pl.ArtifactLocation.URI
+				if pl.Region.StartLine > 0 {
+					location += ":" + strconv.Itoa(pl.Region.StartLine)
+				}
+			}
+
+			f := map[string]any{
+				"title":       title,
+				"description": firstNonEmpty(res.Message.Text, description),
+				"severity":    sarifSeverity(level, score),
+				"external_id": sarifExternalID(tool, ruleID, location, res),
+				"remediation": help,
+				"rule_id":     ruleID,
+				"tool":        tool,
+				"location":    location,
+			}
+			if score > 0 {
+				f["cvss"] = score
+			}
+			if cve := cveRe.FindString(ruleID + " " + title); cve != "" {
+				f["cve"] = strings.ToUpper(cve)
+			}
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+// sarifSeverity prefers the numeric security-severity, on the CVSS bands, and
+// falls back on the result level.
+func sarifSeverity(level string, score float64) string {
+	switch {
+	case score >= 9:
+		return string(domain.VulnSeverityCritical)
+	case score >= 7:
+		return string(domain.VulnSeverityHigh)
+	case score >= 4:
+		return string(domain.VulnSeverityMedium)
+	case score > 0:
+		return string(domain.VulnSeverityLow)
+	}
+	switch level {
+	case "error":
+		return string(domain.VulnSeverityHigh)
+	case "warning":
+		return string(domain.VulnSeverityMedium)
+	case "note":
+		return string(domain.VulnSeverityLow)
+	}
+	return string(domain.VulnSeverityInfo)
+}
+
+// sarifExternalID is the finding's dedup key: the tool's own fingerprint when
+// it provides one (stable across line shifts), else rule and location.
+func sarifExternalID(tool, ruleID, location string, res sarifResult) string {
+	for _, fps := range []map[string]string{res.Fingerprints, res.PartialFingerprints} {
+		if len(fps) == 0 {
+			continue
+		}
+		keys := make([]string, 0, len(fps))
+		for k := range fps {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return tool + ":" + ruleID + ":" + fps[keys[0]]
+	}
+	return tool + ":" + ruleID + ":" + location
+}
+
+// ---- CycloneDX --------------------------------------------------------------
+
+type cdxBOM struct {
+	BOMFormat       string          `json:"bomFormat"`
+	Components      []cdxComponent  `json:"components"`
+	Vulnerabilities []cdxVulnerable `json:"vulnerabilities"`
+}
+
+type cdxComponent struct {
+	BOMRef     string         `json:"bom-ref"`
+	Name       string         `json:"name"`
+	Version    string         `json:"version"`
+	PURL       string         `json:"purl"`
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxVulnerable struct {
+	ID     string `json:"id"`
+	Source struct {
+		Name string `json:"name"`
+	} `json:"source"`
+	Ratings []struct {
+		Score    float64 `json:"score"`
+		Severity string  `json:"severity"`
+		Method   string  `json:"method"`
+		Vector   string  `json:"vector"`
+	} `json:"ratings"`
+	Description    string `json:"description"`
+	Detail         string `json:"detail"`
+	Recommendation string `json:"recommendation"`
+	Analysis       struct {
+		State string `json:"state"`
+	} `json:"analysis"`
+	Affects []struct {
+		Ref string `json:"ref"`
+	} `json:"affects"`
+}
+
+var cdxSeverityRank = map[string]int{"critical": 5, "high": 4, "medium": 3, "low": 2, "info": 1}
+
+// parseCycloneDX yields one finding per vulnerability and affected component.
+// Vulnerabilities the producer analysed as not affecting the product
+// (not_affected, false_positive, resolved) are skipped.
+func parseCycloneDX(data []byte) ([]map[string]any, error) {
+	var bom cdxBOM
+	if err := json.Unmarshal(data, &bom); err != nil {
+		return nil, domain.NewValidationError("invalid CycloneDX file: " + err.Error())
+	}
+	if !strings.EqualFold(bom.BOMFormat, "CycloneDX") {
+		return nil, domain.NewValidationError("invalid CycloneDX file: bomFormat must be \"CycloneDX\"")
+	}
+	components := map[string]cdxComponent{}
+	var index func([]cdxComponent)
+	index = func(cs []cdxComponent) {
+		for _, c := range cs {
+			if c.BOMRef != "" {
+				components[c.BOMRef] = c
+			}
+			index(c.Components)
+		}
+	}
+	index(bom.Components)
+
+	var out []map[string]any
+	for _, v := range bom.Vulnerabilities {
+		switch v.Analysis.State {
+		case "not_affected", "false_positive", "resolved", "resolved_with_pedigree":
+			continue
+		}
+		var score float64
+		var severity, vector string
+		for _, r := range v.Ratings {
+			if r.Score > score {
+				score, vector = r.Score, r.Vector
+			}
+			if s := strings.ToLower(r.Severity); cdxSeverityRank[s] > cdxSeverityRank[severity] {
+				severity = s
+			}
+		}
+		refs := make([]string, 0, len(v.Affects))
+		for _, a := range v.Affects {
+			refs = append(refs, a.Ref)
+		}
+		if len(refs) == 0 {
+			refs = []string{""}
+		}
+		for _, ref := range refs {
+			c, ok := components[ref]
+			if !ok {
+				c = cdxComponent{BOMRef: ref, Name: ref}
+			}
+			component := strings.TrimSuffix(c.Name+"@"+c.Version, "@")
+			key := firstNonEmpty(c.PURL, ref)
+			title := v.ID
+			if component != "" {
+				title += " in " + component
+			}
+			f := map[string]any{
+				"title":       title,
+				"description": firstNonEmpty(v.Description, v.Detail),
+				"severity":    severity,
+				"cvss_vector": vector,
+				"external_id": strings.TrimSuffix(v.ID+"@"+key, "@"),
+				"remediation": v.Recommendation,
+				"component":   component,
+				"purl":        c.PURL,
+				"advisory":    v.Source.Name,
+			}
+			if score > 0 {
+				f["cvss"] = score
+			}
+			if cve := cveRe.FindString(v.ID); cve != "" {
+				f["cve"] = strings.ToUpper(cve)
+			}
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
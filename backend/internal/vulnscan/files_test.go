@@ -0,0 +1,112 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package vulnscan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/internal/domain"
+)
+
+const sampleSARIF = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "Trivy", "rules": [
+      {"id": "CVE-2024-3094", "shortDescription": {"text": "xz backdoor"},
+       "help": {"text": "Upgrade xz-utils to 5.6.2"},
+       "properties": {"security-severity": "10.0"}},
+      {"id": "AVD-DS-0002", "shortDescription": {"text": "Image user should not be root"},
+       "defaultConfiguration": {"level": "warning"}}
+    ]}},
+    "results": [
+      {"ruleId": "CVE-2024-3094", "ruleIndex": 0, "level": "error",
+       "message": {"text": "Package: xz-utils\nInstalled: 5.6.0"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "Dockerfile"}, "region": {"startLine": 3}}}]},
+      {"ruleIndex": 1, "message": {"text": "Specify a non-root USER"},
+       "partialFingerprints": {"primaryLocationLineHash": "abc123"}},
+      {"ruleId": "AVD-DS-0002", "message": {"text": "ignored"}, "suppressions": [{"kind": "inSource"}]}
+    ]
+  }]
+}`
+
+func TestParseFile_SARIF(t *testing.T) {
+	src, findings, err := ParseFile("sarif", []byte(sampleSARIF))
+	require.NoError(t, err)
+	assert.Equal(t, domain.VulnSourceSARIF, src)
+	require.Len(t, findings, 2, "suppressed results are skipped")
+
+	nf := Normalize(src, findings[0])
+	assert.Equal(t, "CVE-2024-3094", nf.CVEID)
+	assert.Equal(t, "xz backdoor", nf.Title)
+	assert.Equal(t, "critical", nf.Severity, "security-severity wins over the level")
+	assert.Equal(t, 10.0, nf.CVSSScore)
+	assert.Equal(t, "Trivy:CVE-2024-3094:Dockerfile:3", nf.ExternalID)
+	assert.Equal(t, "Upgrade xz-utils to 5.6.2", nf.RemediationHint)
+
+	nf = Normalize(src, findings[1])
+	assert.Equal(t, "Image user should not be root", nf.Title, "the rule is found by index")
+	assert.Equal(t, "medium", nf.Severity, "the rule's default level applies")
+	assert.Equal(t, "Trivy:AVD-DS-0002:abc123", nf.ExternalID, "a fingerprint is a stable key")
+
+	_, findings, err = ParseFile("sarif", []byte(`{"version":"2.1.0","runs":[{"tool":{"driver":{"name":"x"}},"results":[]}]}`))
+	require.NoError(t, err)
+	assert.Empty(t, findings, "a clean run is valid")
+
+	_, _, err = ParseFile("sarif", []byte(`{"hello":1}`))
+	assert.Error(t, err)
+}
+
+const sampleCycloneDX = `{
+  "bomFormat": "CycloneDX", "specVersion": "1.5",
+  "components": [
+    {"bom-ref": "pkg:npm/lodash@4.17.20", "name": "lodash", "version": "4.17.20", "purl": "pkg:npm/lodash@4.17.20",
+     "components": [{"bom-ref": "nested", "name": "inner", "version": "1.0.0"}]}
+  ],
+  "vulnerabilities": [
+    {"id": "CVE-2021-23337", "source": {"name": "NVD"},
+     "ratings": [{"score": 7.2, "severity": "high", "method": "CVSSv31", "vector": "AV:N/AC:L"}, {"severity": "medium"}],
+     "description": "Command injection", "recommendation": "Upgrade to 4.17.21",
+     "affects": [{"ref": "pkg:npm/lodash@4.17.20"}, {"ref": "nested"}]},
+    {"id": "GHSA-xxxx", "analysis": {"state": "not_affected"}, "affects": [{"ref": "nested"}]}
+  ]
+}`
+
+func TestParseFile_CycloneDX(t *testing.T) {
+	src, findings, err := ParseFile("cyclonedx", []byte(sampleCycloneDX))
+	require.NoError(t, err)
+	assert.Equal(t, domain.VulnSourceSBOM, src)
+	require.Len(t, findings, 2, "one finding per affected component; not_affected is skipped")
+
+	nf := Normalize(src, findings[0])
+	assert.Equal(t, "CVE-2021-23337", nf.CVEID)
+	assert.Equal(t, "CVE-2021-23337 in lodash@4.17.20", nf.Title)
+	assert.Equal(t, "high", nf.Severity, "the highest rating wins")
+	assert.Equal(t, 7.2, nf.CVSSScore)
+	assert.Equal(t, "CVE-2021-23337@pkg:npm/lodash@4.17.20", nf.ExternalID)
+	assert.Equal(t, "CVE-2021-23337 in inner@1.0.0", Normalize(src, findings[1]).Title, "nested components resolve")
+
+	_, _, err = ParseFile("cyclonedx", []byte(`{"bomFormat":"SPDX"}`))
+	assert.Error(t, err)
+}
+
+func TestParseFile_ScannerJSON(t *testing.T) {
+	src, findings, err := ParseFile("nessus", []byte(`[{"plugin_name":"x"}]`))
+	require.NoError(t, err)
+	assert.Equal(t, domain.VulnSourceNessus, src)
+	assert.Len(t, findings, 1)
+
+	_, findings, err = ParseFile("qualys", []byte(`{"findings":[{"a":1},{"b":2}]}`))
+	require.NoError(t, err)
+	assert.Len(t, findings, 2)
+
+	_, _, err = ParseFile("word", []byte(`[]`))
+	assert.Error(t, err)
+	_, _, err = ParseFile("nessus", []byte(`"nope"`))
+	assert.Error(t, err)
+}
@@ -49,7 +49,11 @@ var normalizers = map[domain.VulnSource]Normalizer{
 	domain.VulnSourceAzureDefender: normalizeAzureDefender,
 	domain.VulnSourceCrowdStrike:   normalizeCrowdStrike,
 	domain.VulnSourceManual:        normalizeGeneric,
+	domain.VulnSourcePlugin:        normalizeGeneric,
 	domain.VulnSourceScanner:       normalizeGeneric,
+	// ParseFile flattens SARIF and CycloneDX into the generic shape.
+	domain.VulnSourceSARIF: normalizeGeneric,
+	domain.VulnSourceSBOM:  normalizeGeneric,
 }
 
 // SupportsNormalization reports whether a source has a normaliser.
@@ -8,14 +8,16 @@
 //
 //   - TemplateAdvisor  — a pure, deterministic writer that needs no network and
 //     no API key. It always works and is what the tests pin.
-//   - ClaudeAdvisor    — calls the Claude API (claude-opus-4-8) for a richer,
-//     more natural narrative when ANTHROPIC_API_KEY is configured.
+//   - LLM advisors     — ClaudeAdvisor (the Claude API, claude-opus-4-8) or
+//     OpenAICompatAdvisor (a self-hosted vLLM / llama.cpp / Ollama endpoint)
+//     for a richer, more natural narrative. They share one prompt, one output
+//     schema and one validator (prompts.go, structured.go).
 //
-// Both satisfy the Advisor interface, so the board-report use case never has to
+// All satisfy the Advisor interface, so the board-report use case never has to
 // know which one it holds. The use case treats the LLM as best-effort: if the
-// Claude call fails it falls back to the template, so a board report is always
-// producible. Aggregation and tenant-scoped data access live in the application
-// layer; this package only writes prose from numbers.
+// call fails or the reply breaks the schema it falls back to the template, so a
+// board report is always producible. Aggregation and tenant-scoped data access
+// live in the application layer; this package only writes prose from numbers.
 package ai
 
 import (
@@ -72,6 +74,20 @@ type BoardPosture struct {
 	// Compliance advancement, per framework and overall.
 	Frameworks               []FrameworkPosture
 	OverallCompliancePercent float64
+
+	// Appetite is the register measured against the board's own approved
+	// appetite. nil when no appetite statement is in force.
+	Appetite *AppetitePosture
+}
+
+// AppetitePosture summarises appetite breaches for the board: which scopes are
+// above the appetite it approved, and how many risks stay above it without an
+// approved exception.
+type AppetitePosture struct {
+	StatementsInForce int      `json:"statements_in_force"`
+	BreachedScopes    []string `json:"breached_scopes"`
+	RisksAbove        int      `json:"risks_above"`
+	WithoutException  int      `json:"without_exception"`
 }
 
 // BoardNarrative is the non-technical prose an Advisor produces. Every field is
@@ -8,11 +8,11 @@ package ai
 import "context"
 
 // Assistant is the unified AI surface for the GRC assistant features (spec §12).
-// It is deliberately shaped like the board-report Advisor: two implementations
-// satisfy it — a ClaudeAssistant that calls the Claude API when ANTHROPIC_API_KEY
-// is set, and a deterministic TemplateAssistant that always works with no key and
-// is what the application layer falls back to on any LLM error. The five methods
-// map one-to-one onto the five spec capabilities:
+// It is deliberately shaped like the board-report Advisor: LLM-backed
+// implementations (ClaudeAssistant, OpenAICompatAssistant for self-hosted models)
+// and a deterministic TemplateAssistant that always works with no key and is what
+// the application layer falls back to on any LLM error or off-schema reply. The
+// five methods map one-to-one onto the five spec capabilities:
 //
 //  1. SuggestTreatmentPlan  — risk synthesis + smart remediation plan
 //  2. DetectEmergingRisks   — scan free text (threat-intel, news, logs) for risks
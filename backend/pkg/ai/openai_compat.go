@@ -0,0 +1,256 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: LicenseRef-OpenRisk-Commercial
+// This file is part of the OpenRisk Enterprise Edition and is NOT covered by the
+// AGPL; it is licensed under the OpenRisk Commercial License (see LICENSE.commercial).
+
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/opendefender/openrisk/pkg/netguard"
+)
+
+// OutputMode selects how an OpenAI-compatible server is asked for JSON. Servers
+// differ: vLLM and recent llama.cpp / Ollama honour a json_schema response
+// format (grammar-constrained decoding), older builds only know json_object,
+// and some gateways reject response_format entirely. Whatever the mode, the
+// reply is validated against the same schema afterwards.
+type OutputMode string
+
+const (
+	// OutputJSONSchema sends the capability's schema as a strict json_schema
+	// response format. The default.
+	OutputJSONSchema OutputMode = "json_schema"
+	// OutputJSONObject only asks for "some JSON object"; the schema rides in the
+	// prompt.
+	OutputJSONObject OutputMode = "json_object"
+	// OutputPrompt sends no response_format at all.
+	OutputPrompt OutputMode = "prompt"
+)
+
+// Valid reports whether m is a known mode ("" counts, meaning the default).
+func (m OutputMode) Valid() bool {
+	switch m {
+	case "", OutputJSONSchema, OutputJSONObject, OutputPrompt:
+		return true
+	}
+	return false
+}
+
+const (
+	defaultCompatTimeout   = 120 * time.Second
+	defaultCompatMaxTokens = 4096
+)
+
+// OpenAICompatConfig points at a self-hosted chat-completions endpoint.
+type OpenAICompatConfig struct {
+	// BaseURL is the API root including the version segment, as OpenAI SDKs
+	// expect it: http://vllm:8000/v1, http://ollama:11434/v1,
+	// http://llama:8080/v1. /chat/completions is appended.
+	BaseURL string
+	Model   string
+	// APIKey is optional; llama.cpp and Ollama usually run without one, vLLM
+	// with --api-key and most gateways need it.
+	APIKey string
+	// Timeout bounds one completion. Local models on modest GPUs are slow, so the
+	// default (120s) is longer than Claude's.
+	Timeout    time.Duration
+	MaxTokens  int
+	OutputMode OutputMode
+	// AllowPrivateNetworks lets the client connect to private and loopback
+	// addresses. Off, every connection is checked after name resolution
+	// (netguard), since an organisation chooses BaseURL.
+	AllowPrivateNetworks bool
+}
+
+// openAICompatClient is the transport shared by OpenAICompatAssistant and
+// OpenAICompatAdvisor. It satisfies completer.
+type openAICompatClient struct {
+	cfg  OpenAICompatConfig
+	http *http.Client
+}
+
+func newOpenAICompatClient(cfg OpenAICompatConfig) *openAICompatClient {
+	cfg.BaseURL = strings.TrimRight(strings.TrimSpace(cfg.BaseURL), "/")
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultCompatTimeout
+	}
+	if cfg.MaxTokens <= 0 {
+		cfg.MaxTokens = defaultCompatMaxTokens
+	}
+	if cfg.OutputMode == "" {
+		cfg.OutputMode = OutputJSONSchema
+	}
+	// The per-request context carries the deadline; the client timeout is a
+	// backstop for a server that stalls mid-body.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: 30 * time.Second, Control: netguard.Control(cfg.AllowPrivateNetworks, "the AI assistant")}).DialContext
+	return &openAICompatClient{cfg: cfg, http: &http.Client{Timeout: cfg.Timeout + 5*time.Second, Transport: transport}}
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model          string         `json:"model"`
+	Messages       []chatMessage  `json:"messages"`
+	MaxTokens      int            `json:"max_tokens"`
+	Temperature    float64        `json:"temperature"`
+	Stream         bool           `json:"stream"`
+	ResponseFormat map[string]any `json:"response_format,omitempty"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+func (c *openAICompatClient) complete(ctx context.Context, req completion) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	body := chatRequest{
+		Model: c.cfg.Model,
+		Messages: []chatMessage{
+			{Role: "system", Content: req.System},
+			{Role: "user", Content: req.User},
+		},
+		MaxTokens: c.cfg.MaxTokens,
+		// Low temperature: these are analytical answers decoded into a schema, and
+		// small models drift out of JSON quickly when sampled hot.
+		Temperature: 0.2,
+	}
+	switch c.cfg.OutputMode {
+	case OutputJSONSchema:
+		body.ResponseFormat = map[string]any{
+			"type": "json_schema",
+			"json_schema": map[string]any{
+				"name":   req.Name,
+				"schema": req.Schema,
+				"strict": true,
+			},
+		}
+	case OutputJSONObject:
+		body.ResponseFormat = map[string]any{"type": "json_object"}
+	}
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("openai-compatible: encode request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.BaseURL+"/chat/completions", bytes.NewReader(raw))
+	if err != nil {
+		return "", fmt.Errorf("openai-compatible: build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	if c.cfg.APIKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
+	}
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("openai-compatible: %w", err)
+	}
+	defer resp.Body.Close()
+	payload, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return "", fmt.Errorf("openai-compatible: read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg := strings.TrimSpace(string(payload))
+		if len(msg) > 512 {
+			msg = msg[:512]
+		}
+		return "", fmt.Errorf("openai-compatible: HTTP %d: %s", resp.StatusCode, msg)
+	}
+	var out chatResponse
+	if err := json.Unmarshal(payload, &out); err != nil {
+		return "", fmt.Errorf("openai-compatible: decode response: %w", err)
+	}
+	if out.Error != nil {
+		return "", fmt.Errorf("openai-compatible: %s", out.Error.Message)
+	}
+	if len(out.Choices) == 0 {
+		return "", fmt.Errorf("openai-compatible: no choices in response")
+	}
+	choice := out.Choices[0]
+	// A reply cut at max_tokens is, by construction, unterminated JSON. Say so
+	// rather than letting the decoder report a confusing syntax error.
+	if choice.FinishReason == "length" {
+		return "", fmt.Errorf("openai-compatible: reply truncated at max_tokens (%d)", c.cfg.MaxTokens)
+	}
+	if strings.TrimSpace(choice.Message.Content) == "" {
+		return "", fmt.Errorf("openai-compatible: empty response")
+	}
+	return choice.Message.Content, nil
+}
+
+// OpenAICompatAssistant implements Assistant against a self-hosted,
+// OpenAI-compatible chat-completions server (vLLM, llama.cpp server, Ollama), for
+// organisations that cannot send risk data to an external API. Prompts, schemas
+// and validation are the ones ClaudeAssistant uses; the application layer falls
+// back to the TemplateAssistant on any error exactly as it does for Claude.
+type OpenAICompatAssistant struct {
+	compat *openAICompatClient
+}
+
+// NewOpenAICompatAssistant builds an assistant for cfg.
+func NewOpenAICompatAssistant(cfg OpenAICompatConfig) *OpenAICompatAssistant {
+	return &OpenAICompatAssistant{compat: newOpenAICompatClient(cfg)}
+}
+
+func (a *OpenAICompatAssistant) Name() string { return a.compat.cfg.Model }
+
+func (a *OpenAICompatAssistant) SuggestTreatmentPlan(ctx context.Context, in RiskContext) (TreatmentPlan, error) {
+	return suggestTreatmentPlan(ctx, a.compat, in)
+}
+
+func (a *OpenAICompatAssistant) DetectEmergingRisks(ctx context.Context, in IntelInput) (EmergingRisksResult, error) {
+	return detectEmergingRisks(ctx, a.compat, in)
+}
+
+func (a *OpenAICompatAssistant) Answer(ctx context.Context, in AssistantQuery) (AssistantAnswer, error) {
+	return answer(ctx, a.compat, in)
+}
+
+func (a *OpenAICompatAssistant) SummarizeAudit(ctx context.Context, in AuditContext) (AuditNarrative, error) {
+	return summarizeAudit(ctx, a.compat, in)
+}
+
+func (a *OpenAICompatAssistant) AnalyzeEvidence(ctx context.Context, in EvidenceContext) (EvidenceAssessment, error) {
+	return analyzeEvidence(ctx, a.compat, in)
+}
+
+// OpenAICompatAdvisor writes board narratives through the same endpoint.
+type OpenAICompatAdvisor struct {
+	compat *openAICompatClient
+}
+
+// NewOpenAICompatAdvisor builds an advisor for cfg.
+func NewOpenAICompatAdvisor(cfg OpenAICompatConfig) *OpenAICompatAdvisor {
+	return &OpenAICompatAdvisor{compat: newOpenAICompatClient(cfg)}
+}
+
+func (a *OpenAICompatAdvisor) Name() string { return a.compat.cfg.Model }
+
+func (a *OpenAICompatAdvisor) GenerateBoardNarrative(ctx context.Context, p BoardPosture) (BoardNarrative, error) {
+	return generateBoardNarrative(ctx, a.compat, p)
+}
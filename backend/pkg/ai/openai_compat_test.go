@@ -0,0 +1,187 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: LicenseRef-OpenRisk-Commercial
+// This file is part of the OpenRisk Enterprise Edition and is NOT covered by the
+// AGPL; it is licensed under the OpenRisk Commercial License (see LICENSE.commercial).
+
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeChatServer answers /v1/chat/completions with a fixed content string and
+// records the last request body.
+func fakeChatServer(t *testing.T, content string, last *map[string]any) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if last != nil {
+			_ = json.NewDecoder(r.Body).Decode(last)
+			(*last)["_auth"] = r.Header.Get("Authorization")
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{
+				"message":       map[string]any{"role": "assistant", "content": content},
+				"finish_reason": "stop",
+			}},
+		})
+	}))
+}
+
+func TestOpenAICompatAssistant_SendsSchemaAndDecodes(t *testing.T) {
+	var req map[string]any
+	srv := fakeChatServer(t, `<think>weighing {options}</think>
+{"summary":"Exposed RDP","recommended_strategy":"Mitigate",
+ "actions":[{"title":"Close 3389","description":"firewall","priority":"HIGH"}],"rationale":"internet-facing"}`, &req)
+	defer srv.Close()
+
+	a := NewOpenAICompatAssistant(OpenAICompatConfig{BaseURL: srv.URL + "/v1/", Model: "qwen2.5-14b-instruct", APIKey: "k", AllowPrivateNetworks: true})
+	plan, err := a.SuggestTreatmentPlan(context.Background(), RiskContext{Name: "RDP", Criticality: "critical"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Enum casing is canonicalised; the <think> preamble is ignored.
+	if plan.RecommendedStrategy != "mitigate" || plan.Actions[0].Priority != "high" {
+		t.Fatalf("enums not canonicalised: %+v", plan)
+	}
+	if a.Name() != "qwen2.5-14b-instruct" {
+		t.Fatalf("name should be the model, got %q", a.Name())
+	}
+
+	if req["model"] != "qwen2.5-14b-instruct" || req["_auth"] != "Bearer k" {
+		t.Fatalf("model/auth not sent: %v", req)
+	}
+	rf, _ := req["response_format"].(map[string]any)
+	js, _ := rf["json_schema"].(map[string]any)
+	if rf["type"] != "json_schema" || js["name"] != "treatment_plan" || js["strict"] != true {
+		t.Fatalf("expected a strict json_schema response format, got %v", rf)
+	}
+	schema, _ := js["schema"].(map[string]any)
+	if props, _ := schema["properties"].(map[string]any); props["recommended_strategy"] == nil {
+		t.Fatalf("schema not sent: %v", schema)
+	}
+}
+
+func TestOpenAICompatAssistant_MalformedRepliesAreErrors(t *testing.T) {
+	cases := map[string]string{
+		"not json":         "I think you should mitigate.",
+		"bad enum":         `{"summary":"x","recommended_strategy":"ignore","actions":[{"title":"a","description":"","priority":"high"}],"rationale":""}`,
+		"empty summary":    `{"summary":"  ","recommended_strategy":"accept","actions":[{"title":"a","description":"","priority":"low"}],"rationale":""}`,
+		"missing actions":  `{"summary":"x","recommended_strategy":"accept","rationale":""}`,
+		"wrong type":       `{"summary":"x","recommended_strategy":"accept","actions":"none","rationale":""}`,
+		"unclosed (trunc)": `{"summary":"x","recommended_strategy":"acc`,
+	}
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := fakeChatServer(t, content, nil)
+			defer srv.Close()
+			a := NewOpenAICompatAssistant(OpenAICompatConfig{BaseURL: srv.URL + "/v1", Model: "m", AllowPrivateNetworks: true})
+			if _, err := a.SuggestTreatmentPlan(context.Background(), RiskContext{Name: "r"}); err == nil {
+				t.Fatalf("expected an error so the caller falls back to the template")
+			}
+		})
+	}
+}
+
+func TestOpenAICompatClient_OutputModesAndFailures(t *testing.T) {
+	var req map[string]any
+	srv := fakeChatServer(t, `{"answer":"ok","sources":[]}`, &req)
+	defer srv.Close()
+
+	a := NewOpenAICompatAssistant(OpenAICompatConfig{BaseURL: srv.URL + "/v1", Model: "m", OutputMode: OutputJSONObject, AllowPrivateNetworks: true})
+	if _, err := a.Answer(context.Background(), AssistantQuery{Question: "q"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rf, _ := req["response_format"].(map[string]any); rf["type"] != "json_object" {
+		t.Fatalf("expected json_object, got %v", req["response_format"])
+	}
+	if _, hasAuth := req["_auth"]; hasAuth && req["_auth"] != "" {
+		t.Fatalf("no Authorization header expected without a key, got %v", req["_auth"])
+	}
+
+	a = NewOpenAICompatAssistant(OpenAICompatConfig{BaseURL: srv.URL + "/v1", Model: "m", OutputMode: OutputPrompt, AllowPrivateNetworks: true})
+	req = map[string]any{}
+	_, _ = a.Answer(context.Background(), AssistantQuery{Question: "q"})
+	if _, sent := req["response_format"]; sent {
+		t.Fatalf("prompt mode must not send response_format")
+	}
+
+	truncated := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"{\"answer\":\"par"},"finish_reason":"length"}]}`))
+	}))
+	defer truncated.Close()
+	a = NewOpenAICompatAssistant(OpenAICompatConfig{BaseURL: truncated.URL, Model: "m", AllowPrivateNetworks: true})
+	if _, err := a.Answer(context.Background(), AssistantQuery{}); err == nil || !strings.Contains(err.Error(), "max_tokens") {
+		t.Fatalf("expected a truncation error, got %v", err)
+	}
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(500 * time.Millisecond):
+		}
+	}))
+	defer slow.Close()
+	a = NewOpenAICompatAssistant(OpenAICompatConfig{BaseURL: slow.URL, Model: "m", Timeout: 50 * time.Millisecond, AllowPrivateNetworks: true})
+	if _, err := a.Answer(context.Background(), AssistantQuery{}); err == nil {
+		t.Fatalf("expected the configured timeout to fire")
+	}
+}
+
+func TestProviderConfig_ValidateAndFactory(t *testing.T) {
+	bad := []ProviderConfig{
+		{Provider: "gpt"},
+		{Provider: ProviderAnthropic},
+		{Provider: ProviderOpenAICompat, BaseURL: "vllm:8000", Model: "m"},
+		{Provider: ProviderOpenAICompat, BaseURL: "http://vllm:8000/v1"},
+		{Provider: ProviderOpenAICompat, BaseURL: "http://vllm:8000/v1", Model: "m", OutputMode: "xml"},
+	}
+	for _, cfg := range bad {
+		if cfg.Validate() == nil {
+			t.Fatalf("expected %+v to be rejected", cfg)
+		}
+		if IsLLMBacked(NewAssistantForProvider(cfg)) {
+			t.Fatalf("an invalid config must degrade to the template: %+v", cfg)
+		}
+	}
+
+	internal := ProviderConfig{Provider: ProviderOpenAICompat, BaseURL: "http://127.0.0.1:11434/v1", Model: "m"}
+	if internal.Validate() == nil {
+		t.Fatalf("an internal base_url must be refused unless the operator allows it")
+	}
+	internal.AllowPrivateNetworks = true
+	if err := internal.Validate(); err != nil {
+		t.Fatalf("unexpected error with private networks allowed: %v", err)
+	}
+
+	local := ProviderConfig{Provider: ProviderOpenAICompat, BaseURL: "http://vllm:8000/v1", Model: "llama3.1:8b"}
+	if _, ok := NewAssistantForProvider(local).(*OpenAICompatAssistant); !ok {
+		t.Fatalf("expected an OpenAICompatAssistant")
+	}
+	if _, ok := NewAdvisorForProvider(local).(*OpenAICompatAdvisor); !ok {
+		t.Fatalf("expected an OpenAICompatAdvisor")
+	}
+	if IsLLMBacked(NewAssistantForProvider(ProviderConfig{Provider: ProviderTemplate})) {
+		t.Fatalf("template provider must not be LLM-backed")
+	}
+}
+
+func TestOpenAICompatAssistant_RefusesInternalAddressesOnConnect(t *testing.T) {
+	srv := fakeChatServer(t, `{"summary":"x"}`, nil)
+	defer srv.Close()
+
+	a := NewOpenAICompatAssistant(OpenAICompatConfig{BaseURL: srv.URL + "/v1", Model: "m"})
+	_, err := a.SuggestTreatmentPlan(context.Background(), RiskContext{Name: "RDP"})
+	if err == nil || !strings.Contains(err.Error(), "not reachable from the AI assistant") {
+		t.Fatalf("a loopback endpoint must be refused on connect, got %v", err)
+	}
+}
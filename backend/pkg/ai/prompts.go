@@ -0,0 +1,339 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: LicenseRef-OpenRisk-Commercial
+// This file is part of the OpenRisk Enterprise Edition and is NOT covered by the
+// AGPL; it is licensed under the OpenRisk Commercial License (see LICENSE.commercial).
+
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// The prompts and output schemas below are shared by every LLM provider
+// (ClaudeAssistant, OpenAICompatAssistant and their Advisor twins). Keeping them
+// here rather than on a provider means a tenant that switches from Claude to a
+// self-hosted model gets the same instructions, the same JSON contract and the
+// same validation — only the transport changes.
+
+// langOf maps a locale to a prompt-friendly language name.
+func langOf(l Locale) string {
+	if l.Normalize() == LocaleEN {
+		return "English"
+	}
+	return "français"
+}
+
+// -----------------------------------------------------------------------------
+// 1. Treatment plan
+// -----------------------------------------------------------------------------
+
+var treatmentPlanSchema = object(map[string]jsonSchema{
+	"summary":              text(),
+	"recommended_strategy": enum("mitigate", "accept", "transfer", "avoid"),
+	"actions": array(object(map[string]jsonSchema{
+		"title":       text(),
+		"description": str(),
+		"priority":    enum("high", "medium", "low"),
+	}), 1, 8),
+	"rationale": str(),
+})
+
+func treatmentPlanRequest(in RiskContext) completion {
+	system := "Tu es un expert GRC (gouvernance, risque, conformité) qui aide une équipe sécurité à traiter un risque. " +
+		"À partir du contexte du risque, produis (a) une synthèse claire et non alarmiste, (b) une stratégie de traitement recommandée " +
+		"parmi exactement: mitigate, accept, transfer, avoid, et (c) un plan d'actions concret, ordonné et actionnable. " +
+		"Réponds STRICTEMENT par un objet JSON valide, sans texte autour ni Markdown, avec exactement ces clés: " +
+		"summary (string), recommended_strategy (string: mitigate|accept|transfer|avoid), " +
+		"actions (tableau de 2 à 6 objets {title, description, priority(high|medium|low)}), rationale (string). " +
+		"Rédige toute la prose en " + langOf(in.Locale) + "."
+
+	payload := struct {
+		Name             string   `json:"name"`
+		Description      string   `json:"description"`
+		Criticality      string   `json:"criticality"`
+		Probability      float64  `json:"probability_0_1"`
+		Impact           float64  `json:"impact_0_10"`
+		Score            float64  `json:"score"`
+		Tags             []string `json:"tags"`
+		Frameworks       []string `json:"frameworks"`
+		AssetName        string   `json:"asset_name,omitempty"`
+		AssetType        string   `json:"asset_type,omitempty"`
+		AssetCriticality string   `json:"asset_criticality,omitempty"`
+		ALEFCFA          int64    `json:"annual_loss_expectancy_fcfa,omitempty"`
+	}{
+		in.Name, in.Description, in.Criticality, in.Probability, in.Impact, in.Score,
+		in.Tags, in.Frameworks, in.AssetName, in.AssetType, in.AssetCriticality, in.ALEXAF,
+	}
+	data, _ := json.MarshalIndent(payload, "", "  ")
+	user := "Contexte du risque:\n\n" + string(data) + "\n\nProduis la synthèse et le plan de traitement au format JSON demandé."
+	return completion{Name: "treatment_plan", System: system, User: user, Schema: treatmentPlanSchema}
+}
+
+func suggestTreatmentPlan(ctx context.Context, c completer, in RiskContext) (TreatmentPlan, error) {
+	return runStructured[TreatmentPlan](ctx, c, treatmentPlanRequest(in))
+}
+
+// -----------------------------------------------------------------------------
+// 2. Emerging risk detection
+// -----------------------------------------------------------------------------
+
+var emergingRisksSchema = object(map[string]jsonSchema{
+	"summary": str(),
+	"risks": array(object(map[string]jsonSchema{
+		"title":                 text(),
+		"description":           str(),
+		"category":              str(),
+		"severity":              enum("critical", "high", "medium", "low"),
+		"rationale":             str(),
+		"suggested_probability": number(0, 1),
+		"suggested_impact":      number(0, 10),
+	}), 0, 8),
+})
+
+func emergingRisksRequest(in IntelInput) completion {
+	system := "Tu es un analyste cyber-menaces. On te fournit un texte brut (rapport de threat intelligence, actualité, logs). " +
+		"Identifie les risques émergents pertinents pour une organisation et propose de nouveaux risques à ajouter au registre. " +
+		"N'invente rien qui ne soit pas étayé par le texte. Ignore les risques déjà connus fournis. " +
+		"Réponds STRICTEMENT par un objet JSON valide, sans texte autour ni Markdown, avec exactement ces clés: " +
+		"summary (string), risks (tableau de 0 à 8 objets {title, description, category, severity(critical|high|medium|low), " +
+		"rationale, suggested_probability(0.0-1.0), suggested_impact(0.0-10.0)}). " +
+		"Rédige toute la prose en " + langOf(in.Locale) + "."
+
+	payload := struct {
+		Source     string   `json:"source"`
+		Context    string   `json:"context,omitempty"`
+		KnownRisks []string `json:"known_risks,omitempty"`
+		Text       string   `json:"text"`
+	}{in.Source, in.Context, in.KnownRisks, in.Text}
+	data, _ := json.MarshalIndent(payload, "", "  ")
+	user := "Analyse ce contenu et propose les risques émergents au format JSON demandé:\n\n" + string(data)
+	return completion{Name: "emerging_risks", System: system, User: user, Schema: emergingRisksSchema}
+}
+
+func detectEmergingRisks(ctx context.Context, c completer, in IntelInput) (EmergingRisksResult, error) {
+	return runStructured[EmergingRisksResult](ctx, c, emergingRisksRequest(in))
+}
+
+// -----------------------------------------------------------------------------
+// 3. Natural-language assistant (RAG Q&A)
+// -----------------------------------------------------------------------------
+
+var answerSchema = object(map[string]jsonSchema{
+	"answer":  text(),
+	"sources": array(str(), 0, 0),
+})
+
+func answerRequest(in AssistantQuery) completion {
+	system := "Tu es l'assistant GRC d'OpenRisk. Tu réponds aux questions de l'équipe sécurité sur SA base de connaissances " +
+		"(risques, contrôles de conformité, vulnérabilités) fournie dans le contexte. " +
+		"Base-toi UNIQUEMENT sur le contexte fourni; si l'information manque, dis-le clairement plutôt que d'inventer. " +
+		"Sois concret, cite les références utilisées (codes de contrôle, CVE, noms de risque). " +
+		"Réponds STRICTEMENT par un objet JSON valide, sans texte autour ni Markdown, avec exactement ces clés: " +
+		"answer (string), sources (tableau de chaînes, les références du contexte que tu as utilisées). " +
+		"Rédige la réponse en " + langOf(in.Locale) + "."
+
+	var b strings.Builder
+	if in.OrgName != "" {
+		b.WriteString("Organisation: " + in.OrgName + "\n\n")
+	}
+	b.WriteString("=== Contexte GRC (base de connaissances du tenant) ===\n")
+	if len(in.Snippets) == 0 {
+		b.WriteString("(aucun élément pertinent trouvé)\n")
+	}
+	for _, s := range in.Snippets {
+		b.WriteString(fmt.Sprintf("- [%s] %s — %s: %s\n", s.Kind, s.Ref, s.Title, s.Detail))
+	}
+	if len(in.History) > 0 {
+		b.WriteString("\n=== Historique de conversation ===\n")
+		for _, t := range in.History {
+			b.WriteString(t.Role + ": " + t.Text + "\n")
+		}
+	}
+	b.WriteString("\n=== Question ===\n" + in.Question + "\n\nRéponds au format JSON demandé.")
+	return completion{Name: "assistant_answer", System: system, User: b.String(), Schema: answerSchema}
+}
+
+func answer(ctx context.Context, c completer, in AssistantQuery) (AssistantAnswer, error) {
+	return runStructured[AssistantAnswer](ctx, c, answerRequest(in))
+}
+
+// -----------------------------------------------------------------------------
+// 4. Audit report generation
+// -----------------------------------------------------------------------------
+
+var auditNarrativeSchema = object(map[string]jsonSchema{
+	"executive_summary": text(),
+	"findings":          str(),
+	"recommendations":   array(str(), 0, 0),
+	"conclusion":        str(),
+})
+
+func auditRequest(in AuditContext) completion {
+	system := "Tu es un auditeur senior en conformité qui rédige le rapport exécutif d'une campagne d'audit. " +
+		"À partir des résultats fournis, rédige un rapport clair, factuel et orienté décision, destiné à la direction. " +
+		"Réponds STRICTEMENT par un objet JSON valide, sans texte autour ni Markdown, avec exactement ces clés: " +
+		"executive_summary (string, 3 à 5 phrases), findings (string), recommendations (tableau de 3 à 6 chaînes actionnables), " +
+		"conclusion (string). Rédige toute la prose en " + langOf(in.Locale) + "."
+
+	payload := struct {
+		Title            string         `json:"title"`
+		Type             string         `json:"type"`
+		Status           string         `json:"status"`
+		Auditor          string         `json:"auditor,omitempty"`
+		Scope            string         `json:"scope,omitempty"`
+		Framework        string         `json:"framework,omitempty"`
+		TotalControls    int            `json:"total_controls"`
+		Implemented      int            `json:"implemented"`
+		Gaps             int            `json:"gaps"`
+		PercentComplete  float64        `json:"percent_complete"`
+		OpenRemediations int            `json:"open_remediations"`
+		TopGaps          []AuditGapItem `json:"top_gaps,omitempty"`
+	}{
+		in.Title, in.Type, in.Status, in.Auditor, in.Scope, in.FrameworkName,
+		in.TotalControls, in.Implemented, in.Gaps, in.PercentComplete, in.OpenRemediations, in.TopGaps,
+	}
+	data, _ := json.MarshalIndent(payload, "", "  ")
+	user := "Résultats de l'audit (chiffres à ne pas modifier):\n\n" + string(data) + "\n\nRédige le rapport exécutif au format JSON demandé."
+	return completion{Name: "audit_report", System: system, User: user, Schema: auditNarrativeSchema}
+}
+
+func summarizeAudit(ctx context.Context, c completer, in AuditContext) (AuditNarrative, error) {
+	return runStructured[AuditNarrative](ctx, c, auditRequest(in))
+}
+
+// -----------------------------------------------------------------------------
+// 5. Evidence document analysis
+// -----------------------------------------------------------------------------
+
+var evidenceAssessmentSchema = object(map[string]jsonSchema{
+	"verdict":     enum("satisfies", "partial", "insufficient", "unrelated"),
+	"confidence":  number(0, 1),
+	"rationale":   str(),
+	"gaps":        array(str(), 0, 0),
+	"suggestions": array(str(), 0, 0),
+})
+
+func evidenceRequest(in EvidenceContext) completion {
+	system := "Tu es un auditeur qui vérifie si une preuve documentaire répond bien à l'exigence d'un contrôle de conformité. " +
+		"On te donne l'exigence du contrôle et les informations sur la preuve téléversée (nom de fichier, description, et extrait de contenu si disponible). " +
+		"Évalue si la preuve satisfait le contrôle. Sois prudent: si le contenu n'est pas disponible, base-toi sur les métadonnées et abaisse ta confiance. " +
+		"Réponds STRICTEMENT par un objet JSON valide, sans texte autour ni Markdown, avec exactement ces clés: " +
+		"verdict (string: satisfies|partial|insufficient|unrelated), confidence (0.0-1.0), rationale (string), " +
+		"gaps (tableau de chaînes), suggestions (tableau de chaînes). " +
+		"Rédige toute la prose en " + langOf(in.Locale) + "."
+
+	payload := struct {
+		Framework           string `json:"framework,omitempty"`
+		ControlCode         string `json:"control_code"`
+		ControlName         string `json:"control_name"`
+		ControlDescription  string `json:"control_description"`
+		EvidenceFilename    string `json:"evidence_filename"`
+		EvidenceDescription string `json:"evidence_description,omitempty"`
+		EvidenceExcerpt     string `json:"evidence_excerpt,omitempty"`
+	}{
+		in.FrameworkName, in.ControlCode, in.ControlName, in.ControlDescription,
+		in.EvidenceFilename, in.EvidenceDescription, in.EvidenceExcerpt,
+	}
+	data, _ := json.MarshalIndent(payload, "", "  ")
+	user := "Contrôle et preuve à évaluer:\n\n" + string(data) + "\n\nRends ton verdict au format JSON demandé."
+	return completion{Name: "evidence_assessment", System: system, User: user, Schema: evidenceAssessmentSchema}
+}
+
+func analyzeEvidence(ctx context.Context, c completer, in EvidenceContext) (EvidenceAssessment, error) {
+	return runStructured[EvidenceAssessment](ctx, c, evidenceRequest(in))
+}
+
+// -----------------------------------------------------------------------------
+// Board narrative (Advisor)
+// -----------------------------------------------------------------------------
+
+var boardNarrativeSchema = object(map[string]jsonSchema{
+	"executive_summary":     text(),
+	"risk_commentary":       str(),
+	"compliance_commentary": str(),
+	"financial_commentary":  str(),
+	"recommendations":       array(str(), 0, 0),
+})
+
+// narrativeJSON is the shape the board prompt asks for.
+type narrativeJSON struct {
+	ExecutiveSummary     string   `json:"executive_summary"`
+	RiskCommentary       string   `json:"risk_commentary"`
+	ComplianceCommentary string   `json:"compliance_commentary"`
+	FinancialCommentary  string   `json:"financial_commentary"`
+	Recommendations      []string `json:"recommendations"`
+}
+
+// boardRequest is explicit about audience (board), tone (non-technical),
+// currency (FCFA) and output shape (JSON), so the reply decodes reliably.
+func boardRequest(p BoardPosture) completion {
+	system := "Tu es un conseiller en gouvernance, risque et conformité (GRC) qui rédige des rapports pour un conseil d'administration. " +
+		"Ton public n'est PAS technique : évite le jargon sécurité, les scores bruts et les acronymes non expliqués. " +
+		"Sois factuel, concis, orienté décision. Les montants sont en FCFA. " +
+		"Réponds STRICTEMENT par un objet JSON valide, sans texte autour, sans balises Markdown, avec exactement ces clés : " +
+		"executive_summary (string, 3 à 5 phrases), risk_commentary (string), compliance_commentary (string), " +
+		"financial_commentary (string), recommendations (tableau de 3 à 5 chaînes, chacune une action concrète). " +
+		"Si la posture contient « appetite », le commentaire des risques doit dire explicitement où l'appétence approuvée par le conseil est dépassée. " +
+		"Rédige toute la prose en " + langOf(p.Locale) + "."
+
+	// Feed the model the aggregated figures as compact JSON so it never invents numbers.
+	posture := struct {
+		Organization             string             `json:"organization"`
+		Period                   string             `json:"period"`
+		RisksCritical            int                `json:"risks_critical"`
+		RisksHigh                int                `json:"risks_high"`
+		RisksMedium              int                `json:"risks_medium"`
+		RisksLow                 int                `json:"risks_low"`
+		RisksTotal               int                `json:"risks_total"`
+		FinancialExposureFCFA    int64              `json:"financial_exposure_fcfa"`
+		OverallCompliancePercent float64            `json:"overall_compliance_percent"`
+		Frameworks               []FrameworkPosture `json:"frameworks"`
+		Appetite                 *AppetitePosture   `json:"appetite,omitempty"`
+	}{
+		Organization:             p.OrganizationName,
+		Period:                   p.PeriodLabel,
+		RisksCritical:            p.RisksCritical,
+		RisksHigh:                p.RisksHigh,
+		RisksMedium:              p.RisksMedium,
+		RisksLow:                 p.RisksLow,
+		RisksTotal:               p.RisksTotal,
+		FinancialExposureFCFA:    p.FinancialExposureFCFA,
+		OverallCompliancePercent: p.OverallCompliancePercent,
+		Frameworks:               p.Frameworks,
+		Appetite:                 p.Appetite,
+	}
+	data, _ := json.MarshalIndent(posture, "", "  ")
+
+	user := "Voici la posture agrégée de risque et de conformité (chiffres à ne pas modifier, montant déjà en FCFA) :\n\n" +
+		string(data) +
+		"\n\nRédige le rapport pour le conseil au format JSON demandé."
+	return completion{Name: "board_narrative", System: system, User: user, Schema: boardNarrativeSchema}
+}
+
+func generateBoardNarrative(ctx context.Context, c completer, p BoardPosture) (BoardNarrative, error) {
+	n, err := runStructured[narrativeJSON](ctx, c, boardRequest(p))
+	if err != nil {
+		return BoardNarrative{}, err
+	}
+	return BoardNarrative{
+		ExecutiveSummary:     strings.TrimSpace(n.ExecutiveSummary),
+		RiskCommentary:       strings.TrimSpace(n.RiskCommentary),
+		ComplianceCommentary: strings.TrimSpace(n.ComplianceCommentary),
+		FinancialCommentary:  strings.TrimSpace(n.FinancialCommentary),
+		Recommendations:      n.Recommendations,
+	}, nil
+}
+
+// extractJSONObject returns the substring from the first '{' to the last '}',
+// which strips any ```json fence or lead-in text the model may add.
+func extractJSONObject(s string) string {
+	start := strings.Index(s, "{")
+	end := strings.LastIndex(s, "}")
+	if start < 0 || end < 0 || end < start {
+		return ""
+	}
+	return s[start : end+1]
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: LicenseRef-OpenRisk-Commercial
+// This file is part of the OpenRisk Enterprise Edition and is NOT covered by the
+// AGPL; it is licensed under the OpenRisk Commercial License (see LICENSE.commercial).
+
+package ai
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/opendefender/openrisk/pkg/netguard"
+)
+
+// Provider names an LLM backend. It is what an organisation picks in its AI
+// settings and what the server default (AI_PROVIDER) is set to.
+type Provider string
+
+const (
+	// ProviderTemplate never calls a model: the deterministic templates only.
+	// The right choice for an organisation that allows no LLM at all.
+	ProviderTemplate Provider = "template"
+	// ProviderAnthropic calls the Claude API.
+	ProviderAnthropic Provider = "anthropic"
+	// ProviderOpenAICompat calls a self-hosted OpenAI-compatible endpoint.
+	ProviderOpenAICompat Provider = "openai_compatible"
+)
+
+// ProviderConfig is everything needed to build an Assistant or Advisor for one
+// provider. It is a plain value so the application layer can assemble it from a
+// tenant's stored settings without this package knowing about storage.
+type ProviderConfig struct {
+	Provider   Provider
+	BaseURL    string // openai_compatible only
+	Model      string
+	APIKey     string
+	Timeout    time.Duration // openai_compatible only; 0 = default
+	MaxTokens  int           // openai_compatible only; 0 = default
+	OutputMode OutputMode    // openai_compatible only; "" = json_schema
+	// AllowPrivateNetworks lets BaseURL be a private or loopback address. It is
+	// an operator setting, never stored with an organisation's choice: true for
+	// the server default, AI_ALLOW_PRIVATE_NETWORKS for organisation settings.
+	// That flag is off by default, so an organisation pointing at its own
+	// vLLM or Ollama on an internal address needs the operator to set it.
+	AllowPrivateNetworks bool
+}
+
+// Validate checks the configuration is usable. It does not contact the server.
+func (c ProviderConfig) Validate() error {
+	switch c.Provider {
+	case ProviderTemplate:
+		return nil
+	case ProviderAnthropic:
+		if strings.TrimSpace(c.APIKey) == "" {
+			return fmt.Errorf("an API key is required for the anthropic provider")
+		}
+		return nil
+	case ProviderOpenAICompat:
+		u, err := url.Parse(strings.TrimSpace(c.BaseURL))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("base_url must be an absolute http(s) URL")
+		}
+		if !c.AllowPrivateNetworks && netguard.InternalHost(u.Hostname()) {
+			return fmt.Errorf("base_url resolves to a private address; set AI_ALLOW_PRIVATE_NETWORKS=true to allow self-hosted endpoints")
+		}
+		if strings.TrimSpace(c.Model) == "" {
+			return fmt.Errorf("model is required for the openai_compatible provider")
+		}
+		if !c.OutputMode.Valid() {
+			return fmt.Errorf("output_mode must be json_schema, json_object or prompt")
+		}
+		if c.Timeout < 0 || c.MaxTokens < 0 {
+			return fmt.Errorf("timeout and max_tokens must not be negative")
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown provider %q", c.Provider)
+}
+
+func (c ProviderConfig) compat() OpenAICompatConfig {
+	return OpenAICompatConfig{
+		BaseURL: c.BaseURL, Model: c.Model, APIKey: c.APIKey,
+		Timeout: c.Timeout, MaxTokens: c.MaxTokens, OutputMode: c.OutputMode,
+		AllowPrivateNetworks: c.AllowPrivateNetworks,
+	}
+}
+
+// NewAssistantForProvider builds the assistant cfg describes. An invalid
+// configuration yields the TemplateAssistant — the same degradation as a
+// provider error at call time — so a bad setting never takes a feature down.
+func NewAssistantForProvider(cfg ProviderConfig) Assistant {
+	if cfg.Validate() != nil {
+		return NewTemplateAssistant()
+	}
+	switch cfg.Provider {
+	case ProviderAnthropic:
+		return NewClaudeAssistant(cfg.APIKey, cfg.Model)
+	case ProviderOpenAICompat:
+		return NewOpenAICompatAssistant(cfg.compat())
+	}
+	return NewTemplateAssistant()
+}
+
+// NewAdvisorForProvider is NewAssistantForProvider for board narratives.
+func NewAdvisorForProvider(cfg ProviderConfig) Advisor {
+	if cfg.Validate() != nil {
+		return NewTemplateAdvisor()
+	}
+	switch cfg.Provider {
+	case ProviderAnthropic:
+		return NewClaudeAdvisor(cfg.APIKey, cfg.Model)
+	case ProviderOpenAICompat:
+		return NewOpenAICompatAdvisor(cfg.compat())
+	}
+	return NewTemplateAdvisor()
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: LicenseRef-OpenRisk-Commercial
+// This file is part of the OpenRisk Enterprise Edition and is NOT covered by the
+// AGPL; it is licensed under the OpenRisk Commercial License (see LICENSE.commercial).
+
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// completion is one structured request to an LLM: the prompts plus the JSON
+// schema the reply must satisfy. Every provider receives the same completion for
+// a given capability, and every reply goes through the same decodeStructured, so
+// what counts as a usable answer never depends on which model wrote it.
+type completion struct {
+	// Name labels the schema (e.g. "treatment_plan"); OpenAI-compatible servers
+	// require it alongside a json_schema response format.
+	Name   string
+	System string
+	User   string
+	Schema jsonSchema
+}
+
+// completer is the transport half of an LLM provider: send a completion, return
+// the raw text of the reply. Providers may use the schema to constrain decoding
+// (OpenAI-compatible servers) or rely on the prompt alone (Claude); either way
+// the reply is validated here, not by the provider.
+type completer interface {
+	complete(ctx context.Context, req completion) (string, error)
+}
+
+// runStructured sends req and decodes the validated reply into a T. Any
+// transport error, missing JSON object, schema violation or decode failure is
+// returned, which is the caller's cue to fall back to the template.
+func runStructured[T any](ctx context.Context, c completer, req completion) (T, error) {
+	var out T
+	raw, err := c.complete(ctx, req)
+	if err != nil {
+		return out, err
+	}
+	if err := decodeStructured(raw, req.Schema, &out); err != nil {
+		return out, fmt.Errorf("%s: %w", req.Name, err)
+	}
+	return out, nil
+}
+
+// thinkBlock matches the reasoning preamble some self-hosted models (Qwen,
+// DeepSeek-R1 distils) emit inline before the answer. Its braces would
+// otherwise confuse extractJSONObject.
+var thinkBlock = regexp.MustCompile(`(?s)<think>.*?</think>`)
+
+// decodeStructured extracts the JSON object from a reply (tolerating a fence,
+// surrounding prose or a <think> block), validates it against schema —
+// canonicalising enum casing on the way — and decodes it into dst.
+func decodeStructured(raw string, schema jsonSchema, dst any) error {
+	jsonStr := extractJSONObject(thinkBlock.ReplaceAllString(raw, ""))
+	if jsonStr == "" {
+		return fmt.Errorf("no JSON object found in response")
+	}
+	var doc any
+	if err := json.Unmarshal([]byte(jsonStr), &doc); err != nil {
+		return fmt.Errorf("decode JSON: %w", err)
+	}
+	doc, err := schema.validate("$", doc)
+	if err != nil {
+		return err
+	}
+	normalized, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("re-encode JSON: %w", err)
+	}
+	if err := json.Unmarshal(normalized, dst); err != nil {
+		return fmt.Errorf("decode JSON: %w", err)
+	}
+	return nil
+}
+
+// jsonSchema is the subset of JSON Schema the capabilities use: object,
+// array, string, number; properties/required, items, enum, minLength,
+// minItems/maxItems, minimum/maximum. It is sent verbatim to servers that
+// support schema-constrained output and enforced locally for all providers.
+type jsonSchema map[string]any
+
+// validate checks v against the schema and returns v with enum values folded to
+// their canonical casing ("Mitigate" → "mitigate"), the one liberty a model is
+// allowed. path locates the failure in the error message.
+func (s jsonSchema) validate(path string, v any) (any, error) {
+	switch s["type"] {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected an object", path)
+		}
+		for _, key := range stringList(s["required"]) {
+			if _, present := obj[key]; !present {
+				return nil, fmt.Errorf("%s: missing %q", path, key)
+			}
+		}
+		props, _ := s["properties"].(map[string]any)
+		for key, sub := range props {
+			val, present := obj[key]
+			if !present {
+				continue
+			}
+			fixed, err := asSchema(sub).validate(path+"."+key, val)
+			if err != nil {
+				return nil, err
+			}
+			obj[key] = fixed
+		}
+		return obj, nil
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected an array", path)
+		}
+		if n, ok := intOf(s["minItems"]); ok && len(arr) < n {
+			return nil, fmt.Errorf("%s: expected at least %d items, got %d", path, n, len(arr))
+		}
+		if n, ok := intOf(s["maxItems"]); ok && len(arr) > n {
+			return nil, fmt.Errorf("%s: expected at most %d items, got %d", path, n, len(arr))
+		}
+		if items, ok := s["items"]; ok {
+			for i := range arr {
+				fixed, err := asSchema(items).validate(fmt.Sprintf("%s[%d]", path, i), arr[i])
+				if err != nil {
+					return nil, err
+				}
+				arr[i] = fixed
+			}
+		}
+		return arr, nil
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a string", path)
+		}
+		if n, ok := intOf(s["minLength"]); ok && len(strings.TrimSpace(str)) < n {
+			return nil, fmt.Errorf("%s: must not be empty", path)
+		}
+		if enum := stringList(s["enum"]); len(enum) > 0 {
+			for _, allowed := range enum {
+				if strings.EqualFold(strings.TrimSpace(str), allowed) {
+					return allowed, nil
+				}
+			}
+			return nil, fmt.Errorf("%s: %q is not one of %s", path, str, strings.Join(enum, "|"))
+		}
+		return str, nil
+	case "number":
+		f, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a number", path)
+		}
+		if lo, ok := s["minimum"].(float64); ok && f < lo {
+			return nil, fmt.Errorf("%s: %v is below %v", path, f, lo)
+		}
+		if hi, ok := s["maximum"].(float64); ok && f > hi {
+			return nil, fmt.Errorf("%s: %v is above %v", path, f, hi)
+		}
+		return f, nil
+	}
+	return v, nil
+}
+
+func asSchema(v any) jsonSchema {
+	switch s := v.(type) {
+	case jsonSchema:
+		return s
+	case map[string]any:
+		return s
+	}
+	return jsonSchema{}
+}
+
+func stringList(v any) []string {
+	switch l := v.(type) {
+	case []string:
+		return l
+	case []any:
+		out := make([]string, 0, len(l))
+		for _, x := range l {
+			if s, ok := x.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func intOf(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case float64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+// -----------------------------------------------------------------------------
+// Schema builders — terse helpers so each capability's contract reads as one
+// literal in prompts.go.
+// -----------------------------------------------------------------------------
+
+// object builds a closed object schema in which every property is required,
+// which is what strict json_schema mode on OpenAI-compatible servers expects.
+func object(props map[string]jsonSchema) jsonSchema {
+	properties := make(map[string]any, len(props))
+	required := make([]string, 0, len(props))
+	for k, v := range props {
+		properties[k] = v
+		required = append(required, k)
+	}
+	sort.Strings(required)
+	return jsonSchema{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func str() jsonSchema { return jsonSchema{"type": "string"} }
+
+// text is a string that must carry content; an empty summary or verdict is as
+// useless as a missing one.
+func text() jsonSchema { return jsonSchema{"type": "string", "minLength": 1} }
+
+func enum(values ...string) jsonSchema { return jsonSchema{"type": "string", "enum": values} }
+
+func number(lo, hi float64) jsonSchema {
+	return jsonSchema{"type": "number", "minimum": lo, "maximum": hi}
+}
+
+func array(items jsonSchema, minItems, maxItems int) jsonSchema {
+	s := jsonSchema{"type": "array", "items": items}
+	if minItems > 0 {
+		s["minItems"] = minItems
+	}
+	if maxItems > 0 {
+		s["maxItems"] = maxItems
+	}
+	return s
+}
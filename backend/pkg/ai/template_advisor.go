@@ -66,6 +66,7 @@ func (a *TemplateAdvisor) french(p BoardPosture) BoardNarrative {
 	} else {
 		risk += "Aucun risque de niveau critique n'est ouvert, ce qui traduit une bonne maîtrise des menaces les plus graves. "
 	}
+	risk += a.appetiteSentenceFR(p.Appetite)
 
 	comp := fmt.Sprintf(
 		"La conformité réglementaire consolidée se situe à %.0f %% des contrôles applicables mis en œuvre. ",
@@ -108,6 +109,7 @@ func (a *TemplateAdvisor) english(p BoardPosture) BoardNarrative {
 	} else {
 		risk += "No critical risk is open, reflecting good control over the most severe threats. "
 	}
+	risk += a.appetiteSentenceEN(p.Appetite)
 
 	comp := fmt.Sprintf(
 		"Consolidated regulatory compliance stands at %.0f %% of applicable controls implemented. ",
@@ -168,8 +170,44 @@ func (a *TemplateAdvisor) frameworkSentenceEN(fw []FrameworkPosture) string {
 	return "The least advanced frameworks are: " + strings.Join(parts, ", ") + "."
 }
 
+// appetiteSentenceFR states the register against the appetite the board
+// approved. Empty when no statement is in force: silence is better than
+// implying the register is "within" an appetite nobody set.
+func (a *TemplateAdvisor) appetiteSentenceFR(ap *AppetitePosture) string {
+	if ap == nil || ap.StatementsInForce == 0 {
+		return ""
+	}
+	if len(ap.BreachedScopes) == 0 {
+		return "Le registre reste dans les limites de l'appétence au risque approuvée par le conseil."
+	}
+	s := fmt.Sprintf("L'appétence au risque approuvée par le conseil est dépassée pour : %s ; %d risque(s) se situent au-delà",
+		strings.Join(ap.BreachedScopes, ", "), ap.RisksAbove)
+	if ap.WithoutException > 0 {
+		return s + fmt.Sprintf(", dont %d sans dérogation approuvée.", ap.WithoutException)
+	}
+	return s + ", tous couverts par une dérogation approuvée."
+}
+
+func (a *TemplateAdvisor) appetiteSentenceEN(ap *AppetitePosture) string {
+	if ap == nil || ap.StatementsInForce == 0 {
+		return ""
+	}
+	if len(ap.BreachedScopes) == 0 {
+		return "The register remains within the risk appetite approved by the board."
+	}
+	s := fmt.Sprintf("The risk appetite approved by the board is exceeded for: %s; %d risk(s) sit above it",
+		strings.Join(ap.BreachedScopes, ", "), ap.RisksAbove)
+	if ap.WithoutException > 0 {
+		return s + fmt.Sprintf(", %d of them without an approved exception.", ap.WithoutException)
+	}
+	return s + ", all covered by an approved exception."
+}
+
 func (a *TemplateAdvisor) recommendationsFR(p BoardPosture) []string {
 	var recs []string
+	if p.Appetite != nil && p.Appetite.WithoutException > 0 {
+		recs = append(recs, fmt.Sprintf("Ramener sous l'appétence, ou soumettre à dérogation, les %d risque(s) qui la dépassent sans décision formelle.", p.Appetite.WithoutException))
+	}
 	if p.RisksCritical > 0 {
 		recs = append(recs, fmt.Sprintf("Traiter en priorité les %d risque(s) critique(s) sous 30 jours (plan d'action, propriétaire, échéance).", p.RisksCritical))
 	}
@@ -185,6 +223,9 @@ func (a *TemplateAdvisor) recommendationsFR(p BoardPosture) []string {
 
 func (a *TemplateAdvisor) recommendationsEN(p BoardPosture) []string {
 	var recs []string
+	if p.Appetite != nil && p.Appetite.WithoutException > 0 {
+		recs = append(recs, fmt.Sprintf("Bring back within appetite, or put to a formal exception, the %d risk(s) exceeding it without a decision.", p.Appetite.WithoutException))
+	}
 	if p.RisksCritical > 0 {
 		recs = append(recs, fmt.Sprintf("Treat the %d critical risk(s) as top priority within 30 days (action plan, owner, deadline).", p.RisksCritical))
 	}
@@ -107,6 +107,31 @@ func TestTemplateAdvisor_NoCriticalNoFrameworks(t *testing.T) {
 	}
 }
 
+func TestTemplateAdvisor_Appetite(t *testing.T) {
+	adv := NewTemplateAdvisor()
+	p := samplePosture(LocaleFR)
+	n, _ := adv.GenerateBoardNarrative(context.Background(), p)
+	if strings.Contains(n.RiskCommentary, "appétence") {
+		t.Errorf("no statement in force: appetite must not be mentioned, got: %q", n.RiskCommentary)
+	}
+
+	p.Appetite = &AppetitePosture{StatementsInForce: 2, BreachedScopes: []string{"Catégorie : Cyber"}, RisksAbove: 3, WithoutException: 1}
+	n, _ = adv.GenerateBoardNarrative(context.Background(), p)
+	if !strings.Contains(n.RiskCommentary, "Catégorie : Cyber") || !strings.Contains(n.RiskCommentary, "1 sans dérogation") {
+		t.Errorf("expected the breached scope and the unexcepted count, got: %q", n.RiskCommentary)
+	}
+	if !strings.Contains(n.Recommendations[0], "appétence") {
+		t.Errorf("risks above appetite without a decision should lead the recommendations, got: %q", n.Recommendations[0])
+	}
+
+	p.Locale = LocaleEN
+	p.Appetite = &AppetitePosture{StatementsInForce: 1}
+	n, _ = adv.GenerateBoardNarrative(context.Background(), p)
+	if !strings.Contains(n.RiskCommentary, "within the risk appetite") {
+		t.Errorf("expected the within-appetite sentence, got: %q", n.RiskCommentary)
+	}
+}
+
 func TestNewAdvisor_FallsBackToTemplateWithoutKey(t *testing.T) {
 	if got := NewAdvisor("", ""); got.Name() != "template" {
 		t.Errorf("without an API key NewAdvisor should return the template advisor, got %q", got.Name())
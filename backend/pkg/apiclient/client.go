@@ -0,0 +1,197 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package apiclient is a typed Go client for the OpenRisk API, generated from
+// the server's OpenAPI specification (internal/apispec/openapi.json) by
+// cmd/openapi-gen. Each operation is a Client method named after its
+// operationId; request and response types are the specification's schemas.
+//
+//	c := apiclient.New("https://risk.example.com", os.Getenv("OPENRISK_TOKEN"))
+//	page, err := c.ListRisks(ctx, &apiclient.ListRisksParams{Status: "OPEN"})
+//
+// Only this file is hand-written; zz_generated.go is regenerated by
+// make openapi whenever a route or handler type changes.
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Client calls the OpenRisk API with a bearer token: a personal access token
+// or a JWT access token.
+type Client struct {
+	// BaseURL is the server's root, e.g. https://risk.example.com. A trailing
+	// /api/v1 is accepted and ignored: operation paths include it.
+	BaseURL    string
+	Token      string
+	UserAgent  string
+	HTTPClient *http.Client
+}
+
+// New returns a Client for the server at baseURL.
+func New(baseURL, token string) *Client {
+	base := strings.TrimSuffix(strings.TrimRight(baseURL, "/"), "/api/v1")
+	return &Client{
+		BaseURL:    base,
+		Token:      strings.TrimSpace(token),
+		UserAgent:  "openrisk-apiclient",
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// APIError is a non-2xx answer, carrying the server's own message.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("openrisk: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Form is a multipart request body: plain fields and file parts.
+type Form struct {
+	Fields map[string]string
+	Files  map[string]File
+}
+
+// File is one file part of a Form.
+type File struct {
+	Name string
+	Data []byte
+}
+
+func (f Form) encode() (io.Reader, string, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for _, k := range sortedKeys(f.Fields) {
+		if err := mw.WriteField(k, f.Fields[k]); err != nil {
+			return nil, "", err
+		}
+	}
+	for _, k := range sortedKeys(f.Files) {
+		part, err := mw.CreateFormFile(k, f.Files[k].Name)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(f.Files[k].Data); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, "", err
+	}
+	return &buf, mw.FormDataContentType(), nil
+}
+
+// Do sends a request outside the generated methods. It is for documents that
+// must pass through untouched, e.g. a tenant configuration exported and
+// imported again: decode into a json.RawMessage and the server's key order and
+// fields survive, where a generated struct would normalise them.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	return c.do(ctx, method, path, query, body, out)
+}
+
+// do sends one request. body is nil, a Form or a value sent as JSON; out is
+// nil, a *[]byte that receives the raw body, or a value the JSON answer is
+// decoded into.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var (
+		reader      io.Reader
+		contentType string
+	)
+	switch b := body.(type) {
+	case nil:
+	case Form:
+		var err error
+		if reader, contentType, err = b.encode(); err != nil {
+			return err
+		}
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			return err
+		}
+		reader, contentType = bytes.NewReader(data), "application/json"
+	}
+
+	u := strings.TrimSuffix(strings.TrimRight(c.BaseURL, "/"), "/api/v1") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return err
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		var msg struct {
+			Error   string `json:"error"`
+			Message string `json:"message"`
+		}
+		text := strings.TrimSpace(string(raw))
+		if json.Unmarshal(raw, &msg) == nil {
+			if msg.Error != "" {
+				text = msg.Error
+			} else if msg.Message != "" {
+				text = msg.Message
+			}
+		}
+		return &APIError{StatusCode: resp.StatusCode, Message: text, Body: raw}
+	}
+	switch o := out.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		*o, err = io.ReadAll(resp.Body)
+		return err
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && err != io.EOF {
+		return fmt.Errorf("openrisk: decoding the response to %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_SendsTokenQueryAndDecodesTypedResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			t.Errorf("authorization = %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path != "/api/v1/vulnerabilities" || r.URL.Query().Get("tier") != "P1" || r.URL.Query().Has("q") {
+			t.Errorf("request = %s", r.URL)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"items": []map[string]any{{"id": "v1", "severity": "critical", "kev": true}},
+			"total": 1,
+		})
+	}))
+	defer srv.Close()
+
+	// A base URL with /api/v1 is accepted: operation paths carry the prefix.
+	res, err := New(srv.URL+"/api/v1/", "tok").VulnerabilityList(context.Background(), &VulnerabilityListParams{Tier: "P1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 1 || len(res.Items) != 1 || res.Items[0].Severity != "critical" || !res.Items[0].KEV {
+		t.Fatalf("response = %+v", res)
+	}
+}
+
+func TestClient_EscapesPathParamsAndReturnsRawBodies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v1/reports/a%2Fb/download" {
+			t.Errorf("path = %s", r.URL.EscapedPath())
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte("%PDF-1.7"))
+	}))
+	defer srv.Close()
+
+	data, err := New(srv.URL, "tok").ReportDownload(context.Background(), "a/b")
+	if err != nil || string(data) != "%PDF-1.7" {
+		t.Fatalf("download = %q, %v", data, err)
+	}
+}
+
+func TestClient_UploadsMultipartForms(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, hdr, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("file part: %v", err)
+		}
+		body, _ := io.ReadAll(file)
+		if hdr.Filename != "scan.sarif" || string(body) != "{}" || r.FormValue("format") != "sarif" {
+			t.Errorf("form = %s %q %q", hdr.Filename, body, r.FormValue("format"))
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"source": "sarif", "created": 2})
+	}))
+	defer srv.Close()
+
+	res, err := New(srv.URL, "tok").UploadVulnerabilityFile(context.Background(), Form{
+		Fields: map[string]string{"format": "sarif"},
+		Files:  map[string]File{"file": {Name: "scan.sarif", Data: []byte("{}")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Source != "sarif" || res.Created != 2 {
+		t.Fatalf("result = %+v", res)
+	}
+}
+
+func TestClient_ReturnsAPIErrorWithServerMessage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(map[string]string{"code": "FORBIDDEN", "message": "missing permission risks:read"})
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL, "tok").ListRisks(context.Background(), nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden || apiErr.Message != "missing permission risks:read" {
+		t.Fatalf("err = %v", err)
+	}
+}
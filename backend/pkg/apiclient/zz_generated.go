@@ -0,0 +1,17713 @@
+// Code generated by cmd/openapi-gen from internal/apispec/openapi.json. DO NOT EDIT.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// AIAnalyzeEvidence calls POST /api/v1/ai/evidence/{id}/analyze: Checks whether an uploaded evidence meets its control's intent.
+// It needs the compliance:read permission.
+// The organisation's plan must include ai_advisor.
+func (c *Client) AIAnalyzeEvidence(ctx context.Context, id string, body *EvidenceAnalysisInput, params *AIAnalyzeEvidenceParams) (*EvidenceAssessmentResult, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(EvidenceAssessmentResult)
+	if err := c.do(ctx, "POST", "/api/v1/ai/evidence/"+url.PathEscape(id)+"/analyze", q, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AIAnalyzeEvidenceParams are AIAnalyzeEvidence's query parameters. Zero values are not sent.
+type AIAnalyzeEvidenceParams struct {
+	Locale string
+}
+
+// AIAssistantQuery calls POST /api/v1/ai/assistant/query: Answers a natural-language GRC question grounded in the tenant's knowledge base.
+// It needs the risks:read permission.
+// The organisation's plan must include ai_advisor.
+func (c *Client) AIAssistantQuery(ctx context.Context, body *AssistantQueryInput, params *AIAssistantQueryParams) (*AssistantAnswerResult, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(AssistantAnswerResult)
+	if err := c.do(ctx, "POST", "/api/v1/ai/assistant/query", q, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AIAssistantQueryParams are AIAssistantQuery's query parameters. Zero values are not sent.
+type AIAssistantQueryParams struct {
+	Locale string
+}
+
+// AIDeleteProvider calls DELETE /api/v1/ai/provider: Reverts the organisation to the server default.
+// It needs the admin role.
+func (c *Client) AIDeleteProvider(ctx context.Context) error {
+	return c.do(ctx, "DELETE", "/api/v1/ai/provider", nil, nil, nil)
+}
+
+// AIDetectEmergingRisks calls POST /api/v1/ai/emerging-risks: Scans free text for candidate new risks.
+// It needs the risks:read permission.
+// The organisation's plan must include ai_advisor.
+func (c *Client) AIDetectEmergingRisks(ctx context.Context, body *EmergingRisksInput, params *AIDetectEmergingRisksParams) (*AIEmergingRisksResult, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(AIEmergingRisksResult)
+	if err := c.do(ctx, "POST", "/api/v1/ai/emerging-risks", q, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AIDetectEmergingRisksParams are AIDetectEmergingRisks's query parameters. Zero values are not sent.
+type AIDetectEmergingRisksParams struct {
+	Locale string
+}
+
+// AIGenerateAuditReport calls POST /api/v1/ai/audits/{id}/report: Writes an executive report from an audit campaign.
+// It needs the compliance:read permission.
+// The organisation's plan must include ai_advisor.
+func (c *Client) AIGenerateAuditReport(ctx context.Context, id string, body *AuditReportInput, params *AIGenerateAuditReportParams) (*AuditReportResult, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(AuditReportResult)
+	if err := c.do(ctx, "POST", "/api/v1/ai/audits/"+url.PathEscape(id)+"/report", q, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AIGenerateAuditReportParams are AIGenerateAuditReport's query parameters. Zero values are not sent.
+type AIGenerateAuditReportParams struct {
+	Locale string
+}
+
+// AIGetProvider calls GET /api/v1/ai/provider: Returns the organisation's provider setting; 404 means it uses the server default.
+// It needs the admin role.
+func (c *Client) AIGetProvider(ctx context.Context) (*AIProviderSetting, error) {
+	out := new(AIProviderSetting)
+	if err := c.do(ctx, "GET", "/api/v1/ai/provider", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AISaveProvider calls PUT /api/v1/ai/provider: Sets the organisation's provider.
+// It needs the admin role.
+func (c *Client) AISaveProvider(ctx context.Context, body *ProviderInput) (*AIProviderSetting, error) {
+	out := new(AIProviderSetting)
+	if err := c.do(ctx, "PUT", "/api/v1/ai/provider", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AIStatus calls GET /api/v1/ai/status: Reports whether a real LLM is configured and which model is active.
+func (c *Client) AIStatus(ctx context.Context) (*AIStatusResponse, error) {
+	out := new(AIStatusResponse)
+	if err := c.do(ctx, "GET", "/api/v1/ai/status", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AISuggestTreatmentPlan calls POST /api/v1/ai/risks/{id}/treatment-plan: Synthesises a risk and proposes a remediation plan.
+// It needs the risks:read permission.
+// The organisation's plan must include ai_advisor.
+func (c *Client) AISuggestTreatmentPlan(ctx context.Context, id string, body *TreatmentPlanInput, params *AISuggestTreatmentPlanParams) (*TreatmentPlanResult, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(TreatmentPlanResult)
+	if err := c.do(ctx, "POST", "/api/v1/ai/risks/"+url.PathEscape(id)+"/treatment-plan", q, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AISuggestTreatmentPlanParams are AISuggestTreatmentPlan's query parameters. Zero values are not sent.
+type AISuggestTreatmentPlanParams struct {
+	Locale string
+}
+
+// AcceptGroupLink calls POST /api/v1/group/links/{id}/accept: Accept a parent's link request, as the subsidiary.
+// It needs the group:manage permission.
+func (c *Client) AcceptGroupLink(ctx context.Context, id string) (*OrganizationLink, error) {
+	out := new(OrganizationLink)
+	if err := c.do(ctx, "POST", "/api/v1/group/links/"+url.PathEscape(id)+"/accept", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ActivationCompleteOnboarding calls POST /api/v1/onboarding/complete: Lifts the route guard.
+func (c *Client) ActivationCompleteOnboarding(ctx context.Context) (*WizardState, error) {
+	out := new(WizardState)
+	if err := c.do(ctx, "POST", "/api/v1/onboarding/complete", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ActivationGetActivationState calls GET /api/v1/activation/state: The single source of truth for the get-started panel: the steps with their copy, their completion, their deep links, and the server's instruction to celebrate.
+func (c *Client) ActivationGetActivationState(ctx context.Context) (*State, error) {
+	out := new(State)
+	if err := c.do(ctx, "GET", "/api/v1/activation/state", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ActivationGetOnboardingState calls GET /api/v1/onboarding/state: Drives both the wizard (which step to show, with which answers) and the route guard (`completed`).
+func (c *Client) ActivationGetOnboardingState(ctx context.Context) (*WizardState, error) {
+	out := new(WizardState)
+	if err := c.do(ctx, "GET", "/api/v1/onboarding/state", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ActivationGetOnboardingSuggestions calls GET /api/v1/onboarding/suggestions: Sector/goal-driven content: the sector and goal option lists, the three pre-filled first-risk drafts, and the suggested frameworks.
+func (c *Client) ActivationGetOnboardingSuggestions(ctx context.Context, params *ActivationGetOnboardingSuggestionsParams) (*Suggestions, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Country != "" {
+			q.Set("country", params.Country)
+		}
+		if params.Goal != "" {
+			q.Set("goal", params.Goal)
+		}
+		if params.Industry != "" {
+			q.Set("industry", params.Industry)
+		}
+	}
+	out := new(Suggestions)
+	if err := c.do(ctx, "GET", "/api/v1/onboarding/suggestions", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ActivationGetOnboardingSuggestionsParams are ActivationGetOnboardingSuggestions's query parameters. Zero values are not sent.
+type ActivationGetOnboardingSuggestionsParams struct {
+	Country  string
+	Goal     string
+	Industry string
+}
+
+// ActivationMarkCelebrated calls POST /api/v1/activation/celebrated: Acknowledges that the user has seen a step's celebration.
+func (c *Client) ActivationMarkCelebrated(ctx context.Context, body *CelebratedInput) error {
+	return c.do(ctx, "POST", "/api/v1/activation/celebrated", nil, body, nil)
+}
+
+// ActivationSaveOnboardingStep calls PUT /api/v1/onboarding/steps/{step}: Save onboarding step.
+func (c *Client) ActivationSaveOnboardingStep(ctx context.Context, step string, body *SaveStepInput) (*WizardState, error) {
+	out := new(WizardState)
+	if err := c.do(ctx, "PUT", "/api/v1/onboarding/steps/"+url.PathEscape(step), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AddMitigation calls POST /api/v1/risks/{id}/mitigations: Add mitigation to risk.
+// It needs the mitigations:create permission.
+func (c *Client) AddMitigation(ctx context.Context, id string, body *AddMitigationRequest, params *AddMitigationParams) (*Mitigation, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(Mitigation)
+	if err := c.do(ctx, "POST", "/api/v1/risks/"+url.PathEscape(id)+"/mitigations", q, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AddMitigationParams are AddMitigation's query parameters. Zero values are not sent.
+type AddMitigationParams struct {
+	Locale string
+}
+
+// AddProgrammeAction calls POST /api/v1/mitigation-programmes/{id}/actions: Add an action.
+// It needs the mitigations:update permission.
+func (c *Client) AddProgrammeAction(ctx context.Context, id string, body *ActionInput) (*ProgrammeAction, error) {
+	out := new(ProgrammeAction)
+	if err := c.do(ctx, "POST", "/api/v1/mitigation-programmes/"+url.PathEscape(id)+"/actions", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AddTeamMember calls POST /api/v1/teams/{id}/members/{userId}: Adds a user to a team (admin only).
+// It needs the admin role.
+func (c *Client) AddTeamMember(ctx context.Context, id string, userID string) (*AddTeamMemberResponse, error) {
+	out := new(AddTeamMemberResponse)
+	if err := c.do(ctx, "POST", "/api/v1/teams/"+url.PathEscape(id)+"/members/"+url.PathEscape(userID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsGetDashboardSnapshot calls GET /api/v1/analytics/dashboard: Retrieves a complete dashboard snapshot.
+func (c *Client) AnalyticsGetDashboardSnapshot(ctx context.Context) (*DashboardSnapshot, error) {
+	out := new(DashboardSnapshot)
+	if err := c.do(ctx, "GET", "/api/v1/analytics/dashboard", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsGetExportData calls GET /api/v1/analytics/export: Exports analytics data in various formats.
+func (c *Client) AnalyticsGetExportData(ctx context.Context, params *AnalyticsGetExportDataParams) ([]byte, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Format != "" {
+			q.Set("format", params.Format)
+		}
+	}
+	var out []byte
+	err := c.do(ctx, "GET", "/api/v1/analytics/export", q, nil, &out)
+	return out, err
+}
+
+// AnalyticsGetExportDataParams are AnalyticsGetExportData's query parameters. Zero values are not sent.
+type AnalyticsGetExportDataParams struct {
+	Format string
+}
+
+// AnalyticsGetFrameworkAnalytics calls GET /api/v1/analytics/frameworks: Retrieves compliance analytics by framework.
+func (c *Client) AnalyticsGetFrameworkAnalytics(ctx context.Context) ([]FrameworkAnalytics, error) {
+	var out []FrameworkAnalytics
+	err := c.do(ctx, "GET", "/api/v1/analytics/frameworks", nil, nil, &out)
+	return out, err
+}
+
+// AnalyticsGetMitigationMetrics calls GET /api/v1/analytics/mitigations/metrics: Retrieves mitigation analytics.
+func (c *Client) AnalyticsGetMitigationMetrics(ctx context.Context) (*MitigationMetrics, error) {
+	out := new(MitigationMetrics)
+	if err := c.do(ctx, "GET", "/api/v1/analytics/mitigations/metrics", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsGetRiskMetrics calls GET /api/v1/analytics/risks/metrics: Retrieves aggregated risk metrics.
+func (c *Client) AnalyticsGetRiskMetrics(ctx context.Context) (*RiskMetrics, error) {
+	out := new(RiskMetrics)
+	if err := c.do(ctx, "GET", "/api/v1/analytics/risks/metrics", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsGetRiskTrends calls GET /api/v1/analytics/risks/trends: Retrieves risk trends over time.
+func (c *Client) AnalyticsGetRiskTrends(ctx context.Context, params *AnalyticsGetRiskTrendsParams) (*AnalyticsGetRiskTrendsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Days != "" {
+			q.Set("days", params.Days)
+		}
+	}
+	out := new(AnalyticsGetRiskTrendsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/analytics/risks/trends", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsGetRiskTrendsParams are AnalyticsGetRiskTrends's query parameters. Zero values are not sent.
+type AnalyticsGetRiskTrendsParams struct {
+	Days string
+}
+
+// ApplyImport calls POST /api/v1/imports/{id}/apply: Apply a batch.
+// It needs one of the roles admin, root.
+func (c *Client) ApplyImport(ctx context.Context, id string, body *ApplyInput) (*DataimportReport, error) {
+	out := new(DataimportReport)
+	if err := c.do(ctx, "POST", "/api/v1/imports/"+url.PathEscape(id)+"/apply", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApplyRiskBowtieSuggestion calls POST /api/v1/risks/{id}/bowtie/apply-suggestion: Raise the risk's probability and impact as its barriers suggest.
+// It needs the risks:update permission.
+func (c *Client) ApplyRiskBowtieSuggestion(ctx context.Context, id string) (*Graph, error) {
+	out := new(Graph)
+	if err := c.do(ctx, "POST", "/api/v1/risks/"+url.PathEscape(id)+"/bowtie/apply-suggestion", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApplyRiskResidual calls POST /api/v1/risks/{id}/residual: Derive the residual from control tests and write it onto the risk.
+// It needs the risks:update permission.
+func (c *Client) ApplyRiskResidual(ctx context.Context, id string) (*ResidualDerivation, error) {
+	out := new(ResidualDerivation)
+	if err := c.do(ctx, "POST", "/api/v1/risks/"+url.PathEscape(id)+"/residual", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApproveBoardReport calls POST /api/v1/reports/board/{reportId}/approve: Approve a draft board report (human-in-the-loop).
+// It needs the reports:board:approve permission.
+func (c *Client) ApproveBoardReport(ctx context.Context, reportID string) (*BoardReport, error) {
+	out := new(BoardReport)
+	if err := c.do(ctx, "POST", "/api/v1/reports/board/"+url.PathEscape(reportID)+"/approve", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AssetSchemaGetSchema calls GET /api/v1/attack-surface/schemas/{category}: Get one category's attribute schema.
+// It needs the assets:read permission.
+func (c *Client) AssetSchemaGetSchema(ctx context.Context, category string) (*AssetTypeSchema, error) {
+	out := new(AssetTypeSchema)
+	if err := c.do(ctx, "GET", "/api/v1/attack-surface/schemas/"+url.PathEscape(category), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AssetSchemaListSchemas calls GET /api/v1/attack-surface/schemas: List the tenant's typed attribute schemas (one per category).
+// It needs the assets:read permission.
+func (c *Client) AssetSchemaListSchemas(ctx context.Context) (*AssetSchemaListSchemasResponse, error) {
+	out := new(AssetSchemaListSchemasResponse)
+	if err := c.do(ctx, "GET", "/api/v1/attack-surface/schemas", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AssetSchemaResetSchema calls POST /api/v1/attack-surface/schemas/{category}/reset: Restore the shipped default schema for a category (admin).
+// It needs one of the roles admin, root.
+func (c *Client) AssetSchemaResetSchema(ctx context.Context, category string) (*AssetTypeSchema, error) {
+	out := new(AssetTypeSchema)
+	if err := c.do(ctx, "POST", "/api/v1/attack-surface/schemas/"+url.PathEscape(category)+"/reset", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AssetSchemaUpdateSchema calls PUT /api/v1/attack-surface/schemas/{category}: Replace a category's attribute schema (admin).
+// It needs one of the roles admin, root.
+func (c *Client) AssetSchemaUpdateSchema(ctx context.Context, category string, body *UpdateSchemaInput) (*AssetTypeSchema, error) {
+	out := new(AssetTypeSchema)
+	if err := c.do(ctx, "PUT", "/api/v1/attack-surface/schemas/"+url.PathEscape(category), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AssetTopologyGetAttackPaths calls GET /api/v1/attack-surface/topology/attack-paths: Cheapest attack paths from internet-exposed assets to crown jewels.
+// It needs one of the permissions assets:read, vulnerabilities:read.
+func (c *Client) AssetTopologyGetAttackPaths(ctx context.Context, params *AssetTopologyGetAttackPathsParams) (*AttackPathAnalysis, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.K != "" {
+			q.Set("k", params.K)
+		}
+		if params.MaxHops != "" {
+			q.Set("max_hops", params.MaxHops)
+		}
+	}
+	out := new(AttackPathAnalysis)
+	if err := c.do(ctx, "GET", "/api/v1/attack-surface/topology/attack-paths", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AssetTopologyGetAttackPathsParams are AssetTopologyGetAttackPaths's query parameters. Zero values are not sent.
+type AssetTopologyGetAttackPathsParams struct {
+	K       string
+	MaxHops string
+}
+
+// AssetTopologyGetCompromiseChain calls GET /api/v1/attack-surface/topology/{id}/compromise-chain: Everything impacted by, and reachable from, a compromised asset.
+// It needs the assets:read permission.
+func (c *Client) AssetTopologyGetCompromiseChain(ctx context.Context, id string) (*CompromiseChain, error) {
+	out := new(CompromiseChain)
+	if err := c.do(ctx, "GET", "/api/v1/attack-surface/topology/"+url.PathEscape(id)+"/compromise-chain", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AssetTopologyGetEdgeTypes calls GET /api/v1/attack-surface/topology/edge-types: The four-type edge vocabulary the topology renders.
+// It needs the assets:read permission.
+func (c *Client) AssetTopologyGetEdgeTypes(ctx context.Context) (*AssetTopologyGetEdgeTypesResponse, error) {
+	out := new(AssetTopologyGetEdgeTypesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/attack-surface/topology/edge-types", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AssetTopologyGetTopology calls GET /api/v1/attack-surface/topology: The tenant's asset dependency graph.
+// It needs the assets:read permission.
+func (c *Client) AssetTopologyGetTopology(ctx context.Context, params *AssetTopologyGetTopologyParams) (*AssetTopology, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+	}
+	out := new(AssetTopology)
+	if err := c.do(ctx, "GET", "/api/v1/attack-surface/topology", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AssetTopologyGetTopologyParams are AssetTopologyGetTopology's query parameters. Zero values are not sent.
+type AssetTopologyGetTopologyParams struct {
+	Limit string
+}
+
+// AuditLogGetAuditLogs calls GET /api/v1/audit-logs: Retrieves all audit logs (admin only) Query parameters: page (default 1), limit (default 20), action, result, user_id.
+// It needs the admin role.
+func (c *Client) AuditLogGetAuditLogs(ctx context.Context, params *AuditLogGetAuditLogsParams) (*AuditLogGetAuditLogsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.Page != "" {
+			q.Set("page", params.Page)
+		}
+	}
+	out := new(AuditLogGetAuditLogsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/audit-logs", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuditLogGetAuditLogsParams are AuditLogGetAuditLogs's query parameters. Zero values are not sent.
+type AuditLogGetAuditLogsParams struct {
+	Limit string
+	Page  string
+}
+
+// AuditLogGetAuditLogsByAction calls GET /api/v1/audit-logs/action/{action}: Retrieves audit logs for a specific action (admin only).
+// It needs the admin role.
+func (c *Client) AuditLogGetAuditLogsByAction(ctx context.Context, action string, params *AuditLogGetAuditLogsByActionParams) (*AuditLogGetAuditLogsByActionResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.Page != "" {
+			q.Set("page", params.Page)
+		}
+	}
+	out := new(AuditLogGetAuditLogsByActionResponse)
+	if err := c.do(ctx, "GET", "/api/v1/audit-logs/action/"+url.PathEscape(action), q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuditLogGetAuditLogsByActionParams are AuditLogGetAuditLogsByAction's query parameters. Zero values are not sent.
+type AuditLogGetAuditLogsByActionParams struct {
+	Limit string
+	Page  string
+}
+
+// AuditLogGetUserAuditLogs calls GET /api/v1/audit-logs/user/{user_id}: Retrieves audit logs for a specific user (admin only).
+// It needs the admin role.
+func (c *Client) AuditLogGetUserAuditLogs(ctx context.Context, userID string, params *AuditLogGetUserAuditLogsParams) (*AuditLogGetUserAuditLogsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.Page != "" {
+			q.Set("page", params.Page)
+		}
+	}
+	out := new(AuditLogGetUserAuditLogsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/audit-logs/user/"+url.PathEscape(userID), q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuditLogGetUserAuditLogsParams are AuditLogGetUserAuditLogs's query parameters. Zero values are not sent.
+type AuditLogGetUserAuditLogsParams struct {
+	Limit string
+	Page  string
+}
+
+// AutoCompleteMitigationSubAction calls POST /api/v1/scanner/mitigations/auto-complete: (scanner webhook endpoint).
+// It needs the mitigations:update permission.
+func (c *Client) AutoCompleteMitigationSubAction(ctx context.Context, body *AutoCompleteMitigationSubActionRequest) (*AutoCompleteMitigationSubActionResponse, error) {
+	out := new(AutoCompleteMitigationSubActionResponse)
+	if err := c.do(ctx, "POST", "/api/v1/scanner/mitigations/auto-complete", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationAutomationState calls GET /api/v1/automation/state: The live indicator the UI polls.
+// It needs the automation:read permission.
+func (c *Client) AutomationAutomationState(ctx context.Context, params *AutomationAutomationStateParams) (*AutomationState, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(AutomationState)
+	if err := c.do(ctx, "GET", "/api/v1/automation/state", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationAutomationStateParams are AutomationAutomationState's query parameters. Zero values are not sent.
+type AutomationAutomationStateParams struct {
+	Locale string
+}
+
+// AutomationCancelDryRun calls POST /api/v1/automation/dry-runs/{id}/cancel: Stop an in-flight test.
+// It needs the automation:read permission.
+func (c *Client) AutomationCancelDryRun(ctx context.Context, id string) (*AutomationCancelDryRunResponse, error) {
+	out := new(AutomationCancelDryRunResponse)
+	if err := c.do(ctx, "POST", "/api/v1/automation/dry-runs/"+url.PathEscape(id)+"/cancel", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationCreateRule calls POST /api/v1/automation/rules: Create rule.
+// It needs the automation:write permission.
+func (c *Client) AutomationCreateRule(ctx context.Context, body *RuleBody) (*AutomationRule, error) {
+	out := new(AutomationRule)
+	if err := c.do(ctx, "POST", "/api/v1/automation/rules", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationCreateRuleFromTemplate calls POST /api/v1/automation/templates/{key}/adopt: Create rule from template.
+// It needs the automation:write permission.
+func (c *Client) AutomationCreateRuleFromTemplate(ctx context.Context, key string, body *FromTemplateBody) (*AutomationRule, error) {
+	out := new(AutomationRule)
+	if err := c.do(ctx, "POST", "/api/v1/automation/templates/"+url.PathEscape(key)+"/adopt", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationDeleteRule calls DELETE /api/v1/automation/rules/{id}: Delete rule.
+// It needs the automation:write permission.
+func (c *Client) AutomationDeleteRule(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/automation/rules/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// AutomationDryRunRule calls POST /api/v1/automation/rules/{id}/dry-run: Traces what the rule WOULD do against real tenant data, without touching a single action port.
+// It needs the automation:read permission.
+func (c *Client) AutomationDryRunRule(ctx context.Context, id string, body *DryRunBody) (*DryRunReport, error) {
+	out := new(DryRunReport)
+	if err := c.do(ctx, "POST", "/api/v1/automation/rules/"+url.PathEscape(id)+"/dry-run", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationEnableRule calls POST /api/v1/automation/rules/{id}/enable: Enable rule.
+// It needs the automation:write permission.
+func (c *Client) AutomationEnableRule(ctx context.Context, id string) (*AutomationRule, error) {
+	out := new(AutomationRule)
+	if err := c.do(ctx, "POST", "/api/v1/automation/rules/"+url.PathEscape(id)+"/enable", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationGetChannels calls GET /api/v1/automation/channels: Get channels.
+// It needs the automation:read permission.
+func (c *Client) AutomationGetChannels(ctx context.Context) (*AutomationChannelConfig, error) {
+	out := new(AutomationChannelConfig)
+	if err := c.do(ctx, "GET", "/api/v1/automation/channels", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationGetDryRun calls GET /api/v1/automation/dry-runs/{id}: Re-read a trace by id.
+// It needs the automation:read permission.
+func (c *Client) AutomationGetDryRun(ctx context.Context, id string) (*DryRunReport, error) {
+	out := new(DryRunReport)
+	if err := c.do(ctx, "GET", "/api/v1/automation/dry-runs/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationGetRule calls GET /api/v1/automation/rules/{id}: Get rule.
+// It needs the automation:read permission.
+func (c *Client) AutomationGetRule(ctx context.Context, id string) (*AutomationRule, error) {
+	out := new(AutomationRule)
+	if err := c.do(ctx, "GET", "/api/v1/automation/rules/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationListChannelCatalogue calls GET /api/v1/automation/channels/catalogue: The channels this deployment supports, and which of them this tenant has configured.
+// It needs the automation:read permission.
+func (c *Client) AutomationListChannelCatalogue(ctx context.Context) (*AutomationListChannelCatalogueResponse, error) {
+	out := new(AutomationListChannelCatalogueResponse)
+	if err := c.do(ctx, "GET", "/api/v1/automation/channels/catalogue", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationListExecutions calls GET /api/v1/automation/executions: List executions.
+// It needs the automation:read permission.
+func (c *Client) AutomationListExecutions(ctx context.Context, params *AutomationListExecutionsParams) (*AutomationListExecutionsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != nil {
+			q.Set("limit", strconv.FormatInt(*params.Limit, 10))
+		}
+		if params.Offset != nil {
+			q.Set("offset", strconv.FormatInt(*params.Offset, 10))
+		}
+	}
+	out := new(AutomationListExecutionsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/automation/executions", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationListExecutionsParams are AutomationListExecutions's query parameters. Zero values are not sent.
+type AutomationListExecutionsParams struct {
+	Limit  *int64
+	Offset *int64
+}
+
+// AutomationListRuleExecutions calls GET /api/v1/automation/rules/{id}/executions: List rule executions.
+// It needs the automation:read permission.
+func (c *Client) AutomationListRuleExecutions(ctx context.Context, id string, params *AutomationListRuleExecutionsParams) (*AutomationListRuleExecutionsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != nil {
+			q.Set("limit", strconv.FormatInt(*params.Limit, 10))
+		}
+	}
+	out := new(AutomationListRuleExecutionsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/automation/rules/"+url.PathEscape(id)+"/executions", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationListRuleExecutionsParams are AutomationListRuleExecutions's query parameters. Zero values are not sent.
+type AutomationListRuleExecutionsParams struct {
+	Limit *int64
+}
+
+// AutomationListRules calls GET /api/v1/automation/rules: List rules.
+// It needs the automation:read permission.
+func (c *Client) AutomationListRules(ctx context.Context) (*AutomationListRulesResponse, error) {
+	out := new(AutomationListRulesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/automation/rules", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationListSLA calls GET /api/v1/automation/sla: Live SLA countdowns.
+// It needs the automation:read permission.
+func (c *Client) AutomationListSLA(ctx context.Context) (*AutomationListSLAResponse, error) {
+	out := new(AutomationListSLAResponse)
+	if err := c.do(ctx, "GET", "/api/v1/automation/sla", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationListTemplates calls GET /api/v1/automation/templates: The ready-made playbooks, each rendered as the sentence it will become.
+// It needs the automation:read permission.
+func (c *Client) AutomationListTemplates(ctx context.Context, params *AutomationListTemplatesParams) (*AutomationListTemplatesResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(AutomationListTemplatesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/automation/templates", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationListTemplatesParams are AutomationListTemplates's query parameters. Zero values are not sent.
+type AutomationListTemplatesParams struct {
+	Locale string
+}
+
+// AutomationReplayExecution calls POST /api/v1/automation/executions/{id}/replay: Re-run a past execution against the input recorded with it.
+// It needs the automation:write permission.
+func (c *Client) AutomationReplayExecution(ctx context.Context, id string) (*AutomationExecution, error) {
+	out := new(AutomationExecution)
+	if err := c.do(ctx, "POST", "/api/v1/automation/executions/"+url.PathEscape(id)+"/replay", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationRunRuleNow calls POST /api/v1/automation/rules/{id}/run: Execute a rule for real, now.
+// It needs the automation:write permission.
+func (c *Client) AutomationRunRuleNow(ctx context.Context, id string, body *RunNowBody) (*AutomationExecution, error) {
+	out := new(AutomationExecution)
+	if err := c.do(ctx, "POST", "/api/v1/automation/rules/"+url.PathEscape(id)+"/run", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationSLAStats calls GET /api/v1/automation/sla/stats: SLA stats.
+// It needs the automation:read permission.
+func (c *Client) AutomationSLAStats(ctx context.Context) (*SLAStats, error) {
+	out := new(SLAStats)
+	if err := c.do(ctx, "GET", "/api/v1/automation/sla/stats", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationSaveChannels calls PUT /api/v1/automation/channels: Save channels.
+// It needs one of the roles admin, root.
+func (c *Client) AutomationSaveChannels(ctx context.Context, body *ChannelsBody) (*AutomationChannelConfig, error) {
+	out := new(AutomationChannelConfig)
+	if err := c.do(ctx, "PUT", "/api/v1/automation/channels", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationSuspendRule calls POST /api/v1/automation/rules/{id}/suspend: Suspend rule.
+// It needs the automation:write permission.
+func (c *Client) AutomationSuspendRule(ctx context.Context, id string, body *SuspendBody) (*AutomationRule, error) {
+	out := new(AutomationRule)
+	if err := c.do(ctx, "POST", "/api/v1/automation/rules/"+url.PathEscape(id)+"/suspend", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationTestChannel calls POST /api/v1/automation/channels/test: Deliver one real test message on one channel and report exactly what happened.
+// It needs the automation:write permission.
+func (c *Client) AutomationTestChannel(ctx context.Context, body *ChannelTestBody) (*ChannelTestResult, error) {
+	out := new(ChannelTestResult)
+	if err := c.do(ctx, "POST", "/api/v1/automation/channels/test", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomationUpdateRule calls PUT /api/v1/automation/rules/{id}: Update rule.
+// It needs the automation:write permission.
+func (c *Client) AutomationUpdateRule(ctx context.Context, id string, body *RuleBody) (*AutomationRule, error) {
+	out := new(AutomationRule)
+	if err := c.do(ctx, "PUT", "/api/v1/automation/rules/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BIADeleteAnalysis calls DELETE /api/v1/bia/processes/{assetId}: Delete a process's business impact analysis.
+// It needs the assets:update permission.
+func (c *Client) BIADeleteAnalysis(ctx context.Context, assetID string) error {
+	return c.do(ctx, "DELETE", "/api/v1/bia/processes/"+url.PathEscape(assetID), nil, nil, nil)
+}
+
+// BIADeleteCapability calls DELETE /api/v1/bia/capabilities/{assetId}: Remove an asset's declared recovery capability.
+// It needs the assets:update permission.
+func (c *Client) BIADeleteCapability(ctx context.Context, assetID string) error {
+	return c.do(ctx, "DELETE", "/api/v1/bia/capabilities/"+url.PathEscape(assetID), nil, nil, nil)
+}
+
+// BIAGetAnalysis calls GET /api/v1/bia/processes/{assetId}: Get a process's business impact analysis.
+// It needs the assets:read permission.
+func (c *Client) BIAGetAnalysis(ctx context.Context, assetID string) (*BusinessImpactAnalysis, error) {
+	out := new(BusinessImpactAnalysis)
+	if err := c.do(ctx, "GET", "/api/v1/bia/processes/"+url.PathEscape(assetID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BIAListAnalyses calls GET /api/v1/bia/processes: List the tenant's business impact analyses.
+// It needs the assets:read permission.
+func (c *Client) BIAListAnalyses(ctx context.Context) ([]BusinessImpactAnalysis, error) {
+	var out []BusinessImpactAnalysis
+	err := c.do(ctx, "GET", "/api/v1/bia/processes", nil, nil, &out)
+	return out, err
+}
+
+// BIAListCapabilities calls GET /api/v1/bia/capabilities: List declared recovery capabilities of supporting assets.
+// It needs the assets:read permission.
+func (c *Client) BIAListCapabilities(ctx context.Context) ([]RecoveryCapability, error) {
+	var out []RecoveryCapability
+	err := c.do(ctx, "GET", "/api/v1/bia/capabilities", nil, nil, &out)
+	return out, err
+}
+
+// BIAPropagation calls GET /api/v1/bia/propagation: RTO/RPO propagated onto every supporting asset.
+// It needs the assets:read permission.
+func (c *Client) BIAPropagation(ctx context.Context) (*Propagation, error) {
+	out := new(Propagation)
+	if err := c.do(ctx, "GET", "/api/v1/bia/propagation", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BIAReport calls GET /api/v1/bia/processes/{assetId}/report: BIA report for one process.
+// It needs the assets:read permission.
+func (c *Client) BIAReport(ctx context.Context, assetID string) (*BIAReport, error) {
+	out := new(BIAReport)
+	if err := c.do(ctx, "GET", "/api/v1/bia/processes/"+url.PathEscape(assetID)+"/report", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BIASaveAnalysis calls PUT /api/v1/bia/processes/{assetId}: Create or replace a process's business impact analysis.
+// It needs the assets:update permission.
+func (c *Client) BIASaveAnalysis(ctx context.Context, assetID string, body *AnalysisInput) (*BusinessImpactAnalysis, error) {
+	out := new(BusinessImpactAnalysis)
+	if err := c.do(ctx, "PUT", "/api/v1/bia/processes/"+url.PathEscape(assetID), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BIASaveCapability calls PUT /api/v1/bia/capabilities/{assetId}: Declare what an asset can actually restore (RTC) and lose (RPC).
+// It needs the assets:update permission.
+func (c *Client) BIASaveCapability(ctx context.Context, assetID string, body *CapabilityInput) (*RecoveryCapability, error) {
+	out := new(RecoveryCapability)
+	if err := c.do(ctx, "PUT", "/api/v1/bia/capabilities/"+url.PathEscape(assetID), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BIASync calls POST /api/v1/bia/sync: Re-feed every process's expected downtime into its risks.
+// It needs the assets:update permission.
+func (c *Client) BIASync(ctx context.Context) (*SyncResult, error) {
+	out := new(SyncResult)
+	if err := c.do(ctx, "POST", "/api/v1/bia/sync", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BackfillSIEMDestination calls POST /api/v1/siem-destinations/{id}/backfill: Re-send history from a chain sequence or an instant.
+// It needs one of the roles admin, root.
+func (c *Client) BackfillSIEMDestination(ctx context.Context, id string, body *BackfillInput) (*SIEMDestination, error) {
+	out := new(SIEMDestination)
+	if err := c.do(ctx, "POST", "/api/v1/siem-destinations/"+url.PathEscape(id)+"/backfill", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BillingCancel calls POST /api/v1/billing/cancel: Cancel.
+// It needs one of the roles admin, root.
+func (c *Client) BillingCancel(ctx context.Context) (*Subscription, error) {
+	out := new(Subscription)
+	if err := c.do(ctx, "POST", "/api/v1/billing/cancel", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BillingChangePlan calls POST /api/v1/billing/change-plan: Admin/manual plan application (e.g. Enterprise agreed by sales, or a downgrade to Free).
+// It needs one of the roles admin, root.
+func (c *Client) BillingChangePlan(ctx context.Context, body *HandlerPlanInput) (*Subscription, error) {
+	out := new(Subscription)
+	if err := c.do(ctx, "POST", "/api/v1/billing/change-plan", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BillingCheckout calls POST /api/v1/billing/checkout: Opens a hosted payment session.
+func (c *Client) BillingCheckout(ctx context.Context, body *HandlerPlanInput) (*CheckoutSession, error) {
+	out := new(CheckoutSession)
+	if err := c.do(ctx, "POST", "/api/v1/billing/checkout", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BillingGetBilling calls GET /api/v1/billing: → subscription + invoices + configured providers.
+func (c *Client) BillingGetBilling(ctx context.Context) (*BillingGetBillingResponse, error) {
+	out := new(BillingGetBillingResponse)
+	if err := c.do(ctx, "GET", "/api/v1/billing", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BillingStartTrial calls POST /api/v1/billing/trial: 14-day, no-credit-card trial.
+func (c *Client) BillingStartTrial(ctx context.Context, body *HandlerPlanInput) (*Subscription, error) {
+	out := new(Subscription)
+	if err := c.do(ctx, "POST", "/api/v1/billing/trial", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BulkOperationCreateBulkOperation calls POST /api/v1/bulk-operations: Creates a new bulk operation job.
+func (c *Client) BulkOperationCreateBulkOperation(ctx context.Context, body *CreateBulkOperationRequest) (*BulkOperation, error) {
+	out := new(BulkOperation)
+	if err := c.do(ctx, "POST", "/api/v1/bulk-operations", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BulkOperationGetBulkOperation calls GET /api/v1/bulk-operations/{id}: Retrieves a specific bulk operation.
+func (c *Client) BulkOperationGetBulkOperation(ctx context.Context, id string) (*BulkOperation, error) {
+	out := new(BulkOperation)
+	if err := c.do(ctx, "GET", "/api/v1/bulk-operations/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BulkOperationListBulkOperations calls GET /api/v1/bulk-operations: Lists bulk operations for the authenticated user.
+func (c *Client) BulkOperationListBulkOperations(ctx context.Context, params *BulkOperationListBulkOperationsParams) (*BulkOperationListBulkOperationsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != nil {
+			q.Set("limit", strconv.FormatInt(*params.Limit, 10))
+		}
+		if params.Offset != nil {
+			q.Set("offset", strconv.FormatInt(*params.Offset, 10))
+		}
+	}
+	out := new(BulkOperationListBulkOperationsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/bulk-operations", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BulkOperationListBulkOperationsParams are BulkOperationListBulkOperations's query parameters. Zero values are not sent.
+type BulkOperationListBulkOperationsParams struct {
+	Limit  *int64
+	Offset *int64
+}
+
+// BusinessRoleGetCatalog calls GET /api/v1/rbac/business-roles: Returns the full permission catalog and the business-role presets.
+func (c *Client) BusinessRoleGetCatalog(ctx context.Context) (*RBACCatalogResponse, error) {
+	out := new(RBACCatalogResponse)
+	if err := c.do(ctx, "GET", "/api/v1/rbac/business-roles", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CTIGet calls GET /api/v1/cti/vulnerabilities/{cve}: Returns a single vulnerability by CVE ID.
+// It needs the risks:read permission.
+func (c *Client) CTIGet(ctx context.Context, cve string) (*CTIVulnerability, error) {
+	out := new(CTIVulnerability)
+	if err := c.do(ctx, "GET", "/api/v1/cti/vulnerabilities/"+url.PathEscape(cve), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CTIList calls GET /api/v1/cti/vulnerabilities: Returns a filtered, paginated vulnerability feed.
+// It needs the risks:read permission.
+func (c *Client) CTIList(ctx context.Context, params *CTIListParams) (*CTIListResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.CisaKnown != "" {
+			q.Set("cisa_known", params.CisaKnown)
+		}
+		if params.Cpe != "" {
+			q.Set("cpe", params.Cpe)
+		}
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.Offset != "" {
+			q.Set("offset", params.Offset)
+		}
+		if params.Query != "" {
+			q.Set("query", params.Query)
+		}
+		if params.Severity != "" {
+			q.Set("severity", params.Severity)
+		}
+	}
+	out := new(CTIListResponse)
+	if err := c.do(ctx, "GET", "/api/v1/cti/vulnerabilities", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CTIListParams are CTIList's query parameters. Zero values are not sent.
+type CTIListParams struct {
+	CisaKnown string
+	Cpe       string
+	Limit     string
+	Offset    string
+	Query     string
+	Severity  string
+}
+
+// CTIMatch calls POST /api/v1/cti/match: Manually matches the caller's tenant assets against known CVEs and auto-creates risks.
+// It needs one of the roles admin, root.
+func (c *Client) CTIMatch(ctx context.Context) (*CTIMatchResponse, error) {
+	out := new(CTIMatchResponse)
+	if err := c.do(ctx, "POST", "/api/v1/cti/match", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CTIStats calls GET /api/v1/cti/stats: Returns the Intel Threat headline metrics.
+// It needs the risks:read permission.
+func (c *Client) CTIStats(ctx context.Context) (*CTIStatsResponse, error) {
+	out := new(CTIStatsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/cti/stats", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CTISync calls POST /api/v1/cti/sync: Manually triggers an NVD + CISA KEV synchronization (admin).
+// It needs one of the roles admin, root.
+func (c *Client) CTISync(ctx context.Context) (*CTISyncResponse, error) {
+	out := new(CTISyncResponse)
+	if err := c.do(ctx, "POST", "/api/v1/cti/sync", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CompleteSubAction calls POST /api/v1/mitigations/{id}/sub-actions/{aid}/complete: Marks a subaction as completed.
+// It needs the mitigations:update permission.
+func (c *Client) CompleteSubAction(ctx context.Context, id string, aid string) (*MitigationSubAction, error) {
+	out := new(MitigationSubAction)
+	if err := c.do(ctx, "POST", "/api/v1/mitigations/"+url.PathEscape(id)+"/sub-actions/"+url.PathEscape(aid)+"/complete", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ComplianceGetInheritedCoverage calls GET /api/v1/compliance/frameworks/{frameworkId}/inherited-coverage: How much of this framework the tenant's existing proof already answers.
+// It needs the compliance:controls:read permission.
+func (c *Client) ComplianceGetInheritedCoverage(ctx context.Context, frameworkID string) (*InheritedCoverage, error) {
+	out := new(InheritedCoverage)
+	if err := c.do(ctx, "GET", "/api/v1/compliance/frameworks/"+url.PathEscape(frameworkID)+"/inherited-coverage", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateAccessPolicy calls POST /api/v1/access-policies: Create an access policy.
+// It needs one of the roles admin, root.
+func (c *Client) CreateAccessPolicy(ctx context.Context, body *PolicyInput) (*AccessPolicy, error) {
+	out := new(AccessPolicy)
+	if err := c.do(ctx, "POST", "/api/v1/access-policies", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateAsset calls POST /api/v1/assets: Create asset.
+// It needs the assets:create permission.
+func (c *Client) CreateAsset(ctx context.Context, body *CreateAssetInput) (*Asset, error) {
+	out := new(Asset)
+	if err := c.do(ctx, "POST", "/api/v1/assets", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateAssetDependency calls POST /api/v1/asset-dependencies: Add a directed dependency edge (source depends on target).
+// It needs the assets:update permission.
+func (c *Client) CreateAssetDependency(ctx context.Context, body *CreateAssetDependencyInput) (*AssetDependency, error) {
+	out := new(AssetDependency)
+	if err := c.do(ctx, "POST", "/api/v1/asset-dependencies", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateComplianceAudit calls POST /api/v1/compliance/audits: Plan a compliance audit.
+// It needs the compliance:audits:write permission.
+func (c *Client) CreateComplianceAudit(ctx context.Context, body *CreateAuditBody) (*ComplianceAudit, error) {
+	out := new(ComplianceAudit)
+	if err := c.do(ctx, "POST", "/api/v1/compliance/audits", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateComplianceControl calls POST /api/v1/compliance/frameworks/{frameworkId}/controls: Instantiate a control for a tenant under this framework.
+// It needs the compliance:controls:create permission.
+func (c *Client) CreateComplianceControl(ctx context.Context, frameworkID string, body *CreateControlInput) (*ComplianceControl, error) {
+	out := new(ComplianceControl)
+	if err := c.do(ctx, "POST", "/api/v1/compliance/frameworks/"+url.PathEscape(frameworkID)+"/controls", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateComplianceEvidence calls POST /api/v1/compliance/controls/{controlId}/evidences: Upload a file as evidence for a control.
+// It needs the compliance:evidences:create permission.
+func (c *Client) CreateComplianceEvidence(ctx context.Context, controlID string, form Form) (*Evidence, error) {
+	out := new(Evidence)
+	if err := c.do(ctx, "POST", "/api/v1/compliance/controls/"+url.PathEscape(controlID)+"/evidences", nil, form, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateComplianceFramework calls POST /api/v1/compliance/frameworks: Create a global compliance framework (admin only).
+// It needs the compliance:frameworks:create permission.
+func (c *Client) CreateComplianceFramework(ctx context.Context, body *CreateFrameworkInput) (*ComplianceFramework, error) {
+	out := new(ComplianceFramework)
+	if err := c.do(ctx, "POST", "/api/v1/compliance/frameworks", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateControlMapping calls POST /api/v1/compliance/control-mappings: Link two controls across frameworks.
+// It needs the compliance:controls:update permission.
+func (c *Client) CreateControlMapping(ctx context.Context, body *CreateCrosswalkInput) (*ControlCrosswalk, error) {
+	out := new(ControlCrosswalk)
+	if err := c.do(ctx, "POST", "/api/v1/compliance/control-mappings", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateControlTestPlan calls POST /api/v1/compliance/controls/{controlId}/test-plans: Add a design or operating test plan to a control.
+// It needs the compliance:controls:update permission.
+func (c *Client) CreateControlTestPlan(ctx context.Context, controlID string, body *ControltestPlanInput) (*ControlTestPlan, error) {
+	out := new(ControlTestPlan)
+	if err := c.do(ctx, "POST", "/api/v1/compliance/controls/"+url.PathEscape(controlID)+"/test-plans", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateImportProfile calls POST /api/v1/import-profiles: Save a mapping profile.
+// It needs one of the roles admin, root.
+func (c *Client) CreateImportProfile(ctx context.Context, body *ProfileInput) (*ImportMappingProfile, error) {
+	out := new(ImportMappingProfile)
+	if err := c.do(ctx, "POST", "/api/v1/import-profiles", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateMitigationProgramme calls POST /api/v1/mitigation-programmes: Create a mitigation programme.
+// It needs the mitigations:create permission.
+func (c *Client) CreateMitigationProgramme(ctx context.Context, body *ProgrammeInput) (*MitigationProgramme, error) {
+	out := new(MitigationProgramme)
+	if err := c.do(ctx, "POST", "/api/v1/mitigation-programmes", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateMitigationSubAction calls POST /api/v1/mitigations/{id}/sub-actions: Create mitigation sub-action (checklist item).
+// It needs the mitigations:create permission.
+func (c *Client) CreateMitigationSubAction(ctx context.Context, id string, body *CreateMitigationSubActionRequest) (*MitigationSubAction, error) {
+	out := new(MitigationSubAction)
+	if err := c.do(ctx, "POST", "/api/v1/mitigations/"+url.PathEscape(id)+"/sub-actions", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreatePluginInstall calls POST /api/v1/plugin-installs: Install a plugin package with its grants.
+// It needs one of the roles admin, root.
+func (c *Client) CreatePluginInstall(ctx context.Context, body *InstallInput) (*PluginInstall, error) {
+	out := new(PluginInstall)
+	if err := c.do(ctx, "POST", "/api/v1/plugin-installs", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateRemediation calls POST /api/v1/compliance/remediations: Create a remediation plan.
+// It needs the compliance:remediations:write permission.
+func (c *Client) CreateRemediation(ctx context.Context, body *CreateRemediationBody) (*RemediationPlan, error) {
+	out := new(RemediationPlan)
+	if err := c.do(ctx, "POST", "/api/v1/compliance/remediations", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateRisk calls POST /api/v1/risks: Create a new risk.
+// It needs the risks:create permission.
+func (c *Client) CreateRisk(ctx context.Context, body *CreateRiskInput) (*Risk, error) {
+	out := new(Risk)
+	if err := c.do(ctx, "POST", "/api/v1/risks", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateSIEMDestination calls POST /api/v1/siem-destinations: Stream the audit trail to a collector.
+// It needs one of the roles admin, root.
+func (c *Client) CreateSIEMDestination(ctx context.Context, body *DestinationInput) (*SIEMDestination, error) {
+	out := new(SIEMDestination)
+	if err := c.do(ctx, "POST", "/api/v1/siem-destinations", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateTeam calls POST /api/v1/teams: Creates a new team (admin only).
+// It needs the admin role.
+func (c *Client) CreateTeam(ctx context.Context, body *CreateTeamInput) (*TeamResponseDTO, error) {
+	out := new(TeamResponseDTO)
+	if err := c.do(ctx, "POST", "/api/v1/teams", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateUser calls POST /api/v1/users: Creates a new user (admin only).
+// It needs the admin role.
+func (c *Client) CreateUser(ctx context.Context, body *CreateUserInput) (*UserResponseDTO, error) {
+	out := new(UserResponseDTO)
+	if err := c.do(ctx, "POST", "/api/v1/users", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateWebhook calls POST /api/v1/webhooks: Subscribe an endpoint to events.
+// It needs one of the roles admin, root.
+func (c *Client) CreateWebhook(ctx context.Context, body *SubscriptionInput) (*SubscriptionWithSecret, error) {
+	out := new(SubscriptionWithSecret)
+	if err := c.do(ctx, "POST", "/api/v1/webhooks", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CustomFieldApplyTemplate calls POST /api/v1/custom-fields/templates/{id}/apply: Applies a custom field template.
+func (c *Client) CustomFieldApplyTemplate(ctx context.Context, id string) (*CustomFieldApplyTemplateResponse, error) {
+	out := new(CustomFieldApplyTemplateResponse)
+	if err := c.do(ctx, "POST", "/api/v1/custom-fields/templates/"+url.PathEscape(id)+"/apply", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CustomFieldCreateCustomField calls POST /api/v1/custom-fields: Creates a new custom field for the specified scope (risk or asset).
+func (c *Client) CustomFieldCreateCustomField(ctx context.Context, body *CreateCustomFieldRequest) (*CustomField, error) {
+	out := new(CustomField)
+	if err := c.do(ctx, "POST", "/api/v1/custom-fields", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CustomFieldDeleteCustomField calls DELETE /api/v1/custom-fields/{id}: Deletes a custom field.
+func (c *Client) CustomFieldDeleteCustomField(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/custom-fields/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// CustomFieldGetCustomField calls GET /api/v1/custom-fields/{id}: Retrieves a specific custom field.
+func (c *Client) CustomFieldGetCustomField(ctx context.Context, id string) (*CustomField, error) {
+	out := new(CustomField)
+	if err := c.do(ctx, "GET", "/api/v1/custom-fields/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CustomFieldListCustomFields calls GET /api/v1/custom-fields: Lists all custom fields, optionally filtered by scope.
+func (c *Client) CustomFieldListCustomFields(ctx context.Context, params *CustomFieldListCustomFieldsParams) ([]CustomField, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Scope != "" {
+			q.Set("scope", params.Scope)
+		}
+	}
+	var out []CustomField
+	err := c.do(ctx, "GET", "/api/v1/custom-fields", q, nil, &out)
+	return out, err
+}
+
+// CustomFieldListCustomFieldsParams are CustomFieldListCustomFields's query parameters. Zero values are not sent.
+type CustomFieldListCustomFieldsParams struct {
+	Scope string
+}
+
+// CustomFieldListCustomFieldsByScope calls GET /api/v1/custom-fields/scope/{scope}: Lists fields for a specific scope.
+func (c *Client) CustomFieldListCustomFieldsByScope(ctx context.Context, scope string) ([]CustomField, error) {
+	var out []CustomField
+	err := c.do(ctx, "GET", "/api/v1/custom-fields/scope/"+url.PathEscape(scope), nil, nil, &out)
+	return out, err
+}
+
+// CustomFieldUpdateCustomField calls PATCH /api/v1/custom-fields/{id}: Updates a custom field.
+func (c *Client) CustomFieldUpdateCustomField(ctx context.Context, id string, body *UpdateCustomFieldRequest) (*CustomField, error) {
+	out := new(CustomField)
+	if err := c.do(ctx, "PATCH", "/api/v1/custom-fields/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteAccessPolicy calls DELETE /api/v1/access-policies/{id}: Delete an access policy.
+// It needs one of the roles admin, root.
+func (c *Client) DeleteAccessPolicy(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/access-policies/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DeleteAsset calls DELETE /api/v1/assets/{id}: Delete asset.
+// It needs the assets:delete permission.
+func (c *Client) DeleteAsset(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/assets/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DeleteAssetDependency calls DELETE /api/v1/asset-dependencies/{id}: Delete a dependency edge.
+// It needs the assets:update permission.
+func (c *Client) DeleteAssetDependency(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/asset-dependencies/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DeleteBoardReport calls DELETE /api/v1/reports/board/{reportId}: Delete a board report.
+// It needs the reports:board:delete permission.
+func (c *Client) DeleteBoardReport(ctx context.Context, reportID string) error {
+	return c.do(ctx, "DELETE", "/api/v1/reports/board/"+url.PathEscape(reportID), nil, nil, nil)
+}
+
+// DeleteComplianceAudit calls DELETE /api/v1/compliance/audits/{id}: Delete a compliance audit.
+// It needs the compliance:audits:write permission.
+func (c *Client) DeleteComplianceAudit(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/compliance/audits/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DeleteComplianceControl calls DELETE /api/v1/compliance/controls/{controlId}: Delete a control (admin only).
+// It needs the compliance:controls:delete permission.
+func (c *Client) DeleteComplianceControl(ctx context.Context, controlID string) error {
+	return c.do(ctx, "DELETE", "/api/v1/compliance/controls/"+url.PathEscape(controlID), nil, nil, nil)
+}
+
+// DeleteComplianceEvidence calls DELETE /api/v1/compliance/evidences/{evidenceId}: Delete an evidence record and its file (admin only).
+// It needs the compliance:evidences:delete permission.
+func (c *Client) DeleteComplianceEvidence(ctx context.Context, evidenceID string) error {
+	return c.do(ctx, "DELETE", "/api/v1/compliance/evidences/"+url.PathEscape(evidenceID), nil, nil, nil)
+}
+
+// DeleteComplianceFramework calls DELETE /api/v1/compliance/frameworks/{frameworkId}: Delete a compliance framework.
+// It needs the compliance:frameworks:delete permission.
+func (c *Client) DeleteComplianceFramework(ctx context.Context, frameworkID string) error {
+	return c.do(ctx, "DELETE", "/api/v1/compliance/frameworks/"+url.PathEscape(frameworkID), nil, nil, nil)
+}
+
+// DeleteControlMapping calls DELETE /api/v1/compliance/control-mappings/{mappingId}: Delete a control mapping.
+// It needs the compliance:controls:update permission.
+func (c *Client) DeleteControlMapping(ctx context.Context, mappingID string) error {
+	return c.do(ctx, "DELETE", "/api/v1/compliance/control-mappings/"+url.PathEscape(mappingID), nil, nil, nil)
+}
+
+// DeleteControlTestPlan calls DELETE /api/v1/control-test-plans/{id}: Delete a test plan and its tests.
+// It needs the compliance:controls:update permission.
+func (c *Client) DeleteControlTestPlan(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/control-test-plans/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DeleteImport calls DELETE /api/v1/imports/{id}: Discard a batch.
+// It needs one of the roles admin, root.
+func (c *Client) DeleteImport(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/imports/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DeleteImportProfile calls DELETE /api/v1/import-profiles/{id}: Delete a mapping profile.
+// It needs one of the roles admin, root.
+func (c *Client) DeleteImportProfile(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/import-profiles/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DeleteMitigation calls DELETE /api/v1/mitigations/{id}: Deletes (soft-deletes) a mitigation plan.
+// It needs the mitigations:delete permission.
+func (c *Client) DeleteMitigation(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/mitigations/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DeleteMitigationProgramme calls DELETE /api/v1/mitigation-programmes/{id}: Delete a programme with its links and actions.
+// It needs the mitigations:delete permission.
+func (c *Client) DeleteMitigationProgramme(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/mitigation-programmes/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DeletePlugin calls DELETE /api/v1/plugins/{id}: Delete a plugin package.
+// It needs one of the roles admin, root.
+func (c *Client) DeletePlugin(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/plugins/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DeletePluginInstall calls DELETE /api/v1/plugin-installs/{id}: Remove an install and its run log.
+// It needs one of the roles admin, root.
+func (c *Client) DeletePluginInstall(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/plugin-installs/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DeleteProgrammeAction calls DELETE /api/v1/programme-actions/{id}: Delete an action and every dependency on it.
+// It needs the mitigations:update permission.
+func (c *Client) DeleteProgrammeAction(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/programme-actions/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DeleteRemediation calls DELETE /api/v1/compliance/remediations/{id}: Delete a remediation plan.
+// It needs the compliance:remediations:write permission.
+func (c *Client) DeleteRemediation(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/compliance/remediations/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DeleteRisk calls DELETE /api/v1/risks/{id}: Delete risk.
+// It needs the risks:delete permission.
+func (c *Client) DeleteRisk(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/risks/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DeleteRiskBowtie calls DELETE /api/v1/risks/{id}/bowtie: Delete the risk's bowtie.
+// It needs the risks:update permission.
+func (c *Client) DeleteRiskBowtie(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/risks/"+url.PathEscape(id)+"/bowtie", nil, nil, nil)
+}
+
+// DeleteSIEMDestination calls DELETE /api/v1/siem-destinations/{id}: Delete a SIEM destination.
+// It needs one of the roles admin, root.
+func (c *Client) DeleteSIEMDestination(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/siem-destinations/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DeleteSubAction calls DELETE /api/v1/mitigations/{id}/sub-actions/{aid}: Delete mitigation sub-action.
+// It needs the mitigations:delete permission.
+func (c *Client) DeleteSubAction(ctx context.Context, id string, aid string) error {
+	return c.do(ctx, "DELETE", "/api/v1/mitigations/"+url.PathEscape(id)+"/sub-actions/"+url.PathEscape(aid), nil, nil, nil)
+}
+
+// DeleteTeam calls DELETE /api/v1/teams/{id}: Deletes a team (admin only).
+// It needs the admin role.
+func (c *Client) DeleteTeam(ctx context.Context, id string) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "DELETE", "/api/v1/teams/"+url.PathEscape(id), nil, nil, &out)
+	return out, err
+}
+
+// DeleteUser calls DELETE /api/v1/users/{id}: Deletes a user (admin only).
+// It needs the admin role.
+func (c *Client) DeleteUser(ctx context.Context, id string) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "DELETE", "/api/v1/users/"+url.PathEscape(id), nil, nil, &out)
+	return out, err
+}
+
+// DeleteWebhook calls DELETE /api/v1/webhooks/{id}: Delete a subscription and its delivery log.
+// It needs one of the roles admin, root.
+func (c *Client) DeleteWebhook(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/webhooks/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// DiffRegisterSnapshots calls GET /api/v1/register-snapshots/diff: What changed between two registers.
+// It needs the risks:snapshots:read permission.
+func (c *Client) DiffRegisterSnapshots(ctx context.Context, params *DiffRegisterSnapshotsParams) (*Diff, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.From != "" {
+			q.Set("from", params.From)
+		}
+		if params.To != "" {
+			q.Set("to", params.To)
+		}
+	}
+	out := new(Diff)
+	if err := c.do(ctx, "GET", "/api/v1/register-snapshots/diff", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DiffRegisterSnapshotsParams are DiffRegisterSnapshots's query parameters. Zero values are not sent.
+type DiffRegisterSnapshotsParams struct {
+	From string
+	To   string
+}
+
+// DownloadBoardReportPDF calls GET /api/v1/reports/board/{reportId}/pdf: Download a board report as a polished PDF.
+// It needs the reports:board:read permission.
+func (c *Client) DownloadBoardReportPDF(ctx context.Context, reportID string) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "GET", "/api/v1/reports/board/"+url.PathEscape(reportID)+"/pdf", nil, nil, &out)
+	return out, err
+}
+
+// DownloadComplianceEvidence calls GET /api/v1/compliance/evidences/{evidenceId}/download: Download an evidence file.
+// It needs the compliance:evidences:read permission.
+func (c *Client) DownloadComplianceEvidence(ctx context.Context, evidenceID string) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "GET", "/api/v1/compliance/evidences/"+url.PathEscape(evidenceID)+"/download", nil, nil, &out)
+	return out, err
+}
+
+// DryRunImport calls POST /api/v1/imports/{id}/dry-run: Validate every row against a mapping.
+// It needs one of the roles admin, root.
+func (c *Client) DryRunImport(ctx context.Context, id string, body *MappingInput) (*DataimportReport, error) {
+	out := new(DataimportReport)
+	if err := c.do(ctx, "POST", "/api/v1/imports/"+url.PathEscape(id)+"/dry-run", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EnhancedDashboardGetCompleteDashboard calls GET /api/v1/dashboard/complete: Retrieves all dashboard data in a single request.
+func (c *Client) EnhancedDashboardGetCompleteDashboard(ctx context.Context) (*DashboardAnalytics, error) {
+	out := new(DashboardAnalytics)
+	if err := c.do(ctx, "GET", "/api/v1/dashboard/complete", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EnhancedDashboardGetDashboardMetrics calls GET /api/v1/dashboard/metrics: Retrieves KPI metrics for the dashboard.
+func (c *Client) EnhancedDashboardGetDashboardMetrics(ctx context.Context) (*DashboardMetrics, error) {
+	out := new(DashboardMetrics)
+	if err := c.do(ctx, "GET", "/api/v1/dashboard/metrics", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EnhancedDashboardGetMitigationProgress calls GET /api/v1/dashboard/mitigation-progress: Retrieves mitigation progress tracking data.
+func (c *Client) EnhancedDashboardGetMitigationProgress(ctx context.Context, params *EnhancedDashboardGetMitigationProgressParams) (*EnhancedDashboardGetMitigationProgressResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+	}
+	out := new(EnhancedDashboardGetMitigationProgressResponse)
+	if err := c.do(ctx, "GET", "/api/v1/dashboard/mitigation-progress", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EnhancedDashboardGetMitigationProgressParams are EnhancedDashboardGetMitigationProgress's query parameters. Zero values are not sent.
+type EnhancedDashboardGetMitigationProgressParams struct {
+	Limit string
+}
+
+// EnhancedDashboardGetMitigationStatus calls GET /api/v1/dashboard/mitigation-status: Retrieves mitigation count by status.
+func (c *Client) EnhancedDashboardGetMitigationStatus(ctx context.Context) (*ServiceMitigationStatus, error) {
+	out := new(ServiceMitigationStatus)
+	if err := c.do(ctx, "GET", "/api/v1/dashboard/mitigation-status", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EnhancedDashboardGetRiskTrends calls GET /api/v1/dashboard/risk-trends: Retrieves 7-day risk trend data.
+func (c *Client) EnhancedDashboardGetRiskTrends(ctx context.Context) (*EnhancedDashboardGetRiskTrendsResponse, error) {
+	out := new(EnhancedDashboardGetRiskTrendsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/dashboard/risk-trends", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EnhancedDashboardGetSeverityDistribution calls GET /api/v1/dashboard/severity-distribution: Retrieves risk distribution by severity level.
+func (c *Client) EnhancedDashboardGetSeverityDistribution(ctx context.Context) (*RiskSeverityDistribution, error) {
+	out := new(RiskSeverityDistribution)
+	if err := c.do(ctx, "GET", "/api/v1/dashboard/severity-distribution", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EnhancedDashboardGetTopRisks calls GET /api/v1/dashboard/top-risks: Retrieves the top N risks by score.
+func (c *Client) EnhancedDashboardGetTopRisks(ctx context.Context, params *EnhancedDashboardGetTopRisksParams) (*EnhancedDashboardGetTopRisksResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+	}
+	out := new(EnhancedDashboardGetTopRisksResponse)
+	if err := c.do(ctx, "GET", "/api/v1/dashboard/top-risks", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EnhancedDashboardGetTopRisksParams are EnhancedDashboardGetTopRisks's query parameters. Zero values are not sent.
+type EnhancedDashboardGetTopRisksParams struct {
+	Limit string
+}
+
+// EntitlementGetEntitlements calls GET /api/v1/entitlements: Get entitlements.
+func (c *Client) EntitlementGetEntitlements(ctx context.Context) (*Snapshot, error) {
+	out := new(Snapshot)
+	if err := c.do(ctx, "GET", "/api/v1/entitlements", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvidenceCreate calls POST /api/v1/evidence: Accepts multipart/form-data (with an optional file) or JSON.
+// It needs the compliance:evidences:create permission.
+func (c *Client) EvidenceCreate(ctx context.Context, form Form) (*Evidence, error) {
+	out := new(Evidence)
+	if err := c.do(ctx, "POST", "/api/v1/evidence", nil, form, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvidenceCustody calls GET /api/v1/evidence/{evidenceId}/custody: Returns the artifact's chain of custody, oldest first.
+// It needs the compliance:evidences:read permission.
+func (c *Client) EvidenceCustody(ctx context.Context, evidenceID string) (*EvidenceCustodyResponse, error) {
+	out := new(EvidenceCustodyResponse)
+	if err := c.do(ctx, "GET", "/api/v1/evidence/"+url.PathEscape(evidenceID)+"/custody", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvidenceDelete calls DELETE /api/v1/evidence/{evidenceId}: Delete.
+// It needs the compliance:evidences:delete permission.
+func (c *Client) EvidenceDelete(ctx context.Context, evidenceID string) error {
+	return c.do(ctx, "DELETE", "/api/v1/evidence/"+url.PathEscape(evidenceID), nil, nil, nil)
+}
+
+// EvidenceDownload calls GET /api/v1/evidence/{evidenceId}/download: Streams the artifact's bytes.
+// It needs the compliance:evidences:read permission.
+func (c *Client) EvidenceDownload(ctx context.Context, evidenceID string) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "GET", "/api/v1/evidence/"+url.PathEscape(evidenceID)+"/download", nil, nil, &out)
+	return out, err
+}
+
+// EvidenceDownloadLink calls GET /api/v1/evidence/{evidenceId}/download-link: Mints a short-lived direct URL to the bytes, so large files are served by the object store rather than proxied through the API.
+// It needs the compliance:evidences:read permission.
+func (c *Client) EvidenceDownloadLink(ctx context.Context, evidenceID string) (*EvidenceDownloadLinkResponse, error) {
+	out := new(EvidenceDownloadLinkResponse)
+	if err := c.do(ctx, "GET", "/api/v1/evidence/"+url.PathEscape(evidenceID)+"/download-link", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvidenceGet calls GET /api/v1/evidence/{evidenceId}: Get.
+// It needs the compliance:evidences:read permission.
+func (c *Client) EvidenceGet(ctx context.Context, evidenceID string) (*Evidence, error) {
+	out := new(Evidence)
+	if err := c.do(ctx, "GET", "/api/v1/evidence/"+url.PathEscape(evidenceID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvidenceLink calls POST /api/v1/evidence/{evidenceId}/links: Attaches an existing artifact to one or more controls — reuse without re-upload.
+// It needs the compliance:evidences:create permission.
+func (c *Client) EvidenceLink(ctx context.Context, evidenceID string, body *EvidenceLinkRequest) (*Evidence, error) {
+	out := new(Evidence)
+	if err := c.do(ctx, "POST", "/api/v1/evidence/"+url.PathEscape(evidenceID)+"/links", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvidenceList calls GET /api/v1/evidence: Returns a filtered page of the library.
+// It needs the compliance:evidences:read permission.
+func (c *Client) EvidenceList(ctx context.Context, params *EvidenceListParams) (*ListResult, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.ControlID != "" {
+			q.Set("control_id", params.ControlID)
+		}
+		if params.FrameworkID != "" {
+			q.Set("framework_id", params.FrameworkID)
+		}
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.Offset != "" {
+			q.Set("offset", params.Offset)
+		}
+		if params.Q != "" {
+			q.Set("q", params.Q)
+		}
+		if params.Review != "" {
+			q.Set("review", params.Review)
+		}
+		if params.Type != "" {
+			q.Set("type", params.Type)
+		}
+	}
+	out := new(ListResult)
+	if err := c.do(ctx, "GET", "/api/v1/evidence", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvidenceListParams are EvidenceList's query parameters. Zero values are not sent.
+type EvidenceListParams struct {
+	ControlID   string
+	FrameworkID string
+	Limit       string
+	Offset      string
+	Q           string
+	Review      string
+	Type        string
+}
+
+// EvidenceMissing calls GET /api/v1/evidence/missing: The "what proof am I missing" worklist, for one framework or across all of them.
+// It needs the compliance:evidences:read permission.
+func (c *Client) EvidenceMissing(ctx context.Context, params *EvidenceMissingParams) (*EvidenceMissingResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.FrameworkID != "" {
+			q.Set("framework_id", params.FrameworkID)
+		}
+	}
+	out := new(EvidenceMissingResponse)
+	if err := c.do(ctx, "GET", "/api/v1/evidence/missing", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvidenceMissingParams are EvidenceMissing's query parameters. Zero values are not sent.
+type EvidenceMissingParams struct {
+	FrameworkID string
+}
+
+// EvidencePackage calls POST /api/v1/evidence/packages: Builds a signed evidence package (zip) for a framework or an audit.
+// It needs the compliance:evidences:read permission.
+func (c *Client) EvidencePackage(ctx context.Context, body *EvidencePackageRequest) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "POST", "/api/v1/evidence/packages", nil, body, &out)
+	return out, err
+}
+
+// EvidenceReplace calls PUT /api/v1/evidence/{evidenceId}/file: Uploads a new version of an artifact's file (multipart: file, and an optional valid_until for the new version).
+// It needs the compliance:evidences:create permission.
+func (c *Client) EvidenceReplace(ctx context.Context, evidenceID string, form Form) (*Evidence, error) {
+	out := new(Evidence)
+	if err := c.do(ctx, "PUT", "/api/v1/evidence/"+url.PathEscape(evidenceID)+"/file", nil, form, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvidenceReview calls POST /api/v1/evidence/{evidenceId}/review: Records the human verdict on an artifact.
+// It needs the compliance:evidences:create permission.
+func (c *Client) EvidenceReview(ctx context.Context, evidenceID string, body *EvidenceReviewRequest) (*Evidence, error) {
+	out := new(Evidence)
+	if err := c.do(ctx, "POST", "/api/v1/evidence/"+url.PathEscape(evidenceID)+"/review", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvidenceUnlink calls DELETE /api/v1/evidence/{evidenceId}/links/{controlId}: Unlink.
+// It needs the compliance:evidences:create permission.
+func (c *Client) EvidenceUnlink(ctx context.Context, evidenceID string, controlID string) error {
+	return c.do(ctx, "DELETE", "/api/v1/evidence/"+url.PathEscape(evidenceID)+"/links/"+url.PathEscape(controlID), nil, nil, nil)
+}
+
+// EvidenceUpdate calls PATCH /api/v1/evidence/{evidenceId}: Update.
+// It needs the compliance:evidences:create permission.
+func (c *Client) EvidenceUpdate(ctx context.Context, evidenceID string, body *EvidenceUpdateRequest) (*Evidence, error) {
+	out := new(Evidence)
+	if err := c.do(ctx, "PATCH", "/api/v1/evidence/"+url.PathEscape(evidenceID), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvidenceVerify calls POST /api/v1/evidence/{evidenceId}/verify: Re-hashes the stored file against the digest recorded at upload.
+// It needs the compliance:evidences:read permission.
+func (c *Client) EvidenceVerify(ctx context.Context, evidenceID string) (*EvidenceIntegrity, error) {
+	out := new(EvidenceIntegrity)
+	if err := c.do(ctx, "POST", "/api/v1/evidence/"+url.PathEscape(evidenceID)+"/verify", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExecutiveDashboardGetExecutiveDashboard calls GET /api/v1/analytics/executive: Cyber score, financial exposure, KRIs, top-10 risks, risk & incident trends and compliance coverage for the caller's tenant.
+// It needs the risks:read permission.
+// The organisation's plan must include executive_dashboard.
+func (c *Client) ExecutiveDashboardGetExecutiveDashboard(ctx context.Context) (*ExecutiveDashboard, error) {
+	out := new(ExecutiveDashboard)
+	if err := c.do(ctx, "GET", "/api/v1/analytics/executive", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExplainAccess calls GET /api/v1/access-policies/explain: Why a user can or cannot see a record.
+func (c *Client) ExplainAccess(ctx context.Context, params *ExplainAccessParams) (*Explanation, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.ID != "" {
+			q.Set("id", params.ID)
+		}
+		if params.Resource != "" {
+			q.Set("resource", params.Resource)
+		}
+		if params.UserID != "" {
+			q.Set("user_id", params.UserID)
+		}
+	}
+	out := new(Explanation)
+	if err := c.do(ctx, "GET", "/api/v1/access-policies/explain", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExplainAccessParams are ExplainAccess's query parameters. Zero values are not sent.
+type ExplainAccessParams struct {
+	ID       string
+	Resource string
+	UserID   string
+}
+
+// ExportPDF calls GET /api/v1/export/pdf: Export risks to PDF.
+func (c *Client) ExportPDF(ctx context.Context) error {
+	return c.do(ctx, "GET", "/api/v1/export/pdf", nil, nil, nil)
+}
+
+// ExportTenantConfig calls GET /api/v1/config/export: Export the tenant configuration.
+// It needs one of the roles admin, root.
+func (c *Client) ExportTenantConfig(ctx context.Context) (*Document, error) {
+	out := new(Document)
+	if err := c.do(ctx, "GET", "/api/v1/config/export", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FinancialAnalyticsSetCurrency calls PUT /api/v1/analytics/financial/currency: Changes the tenant's display currency (chosen at onboarding, changeable here).
+// It needs one of the roles admin, root.
+func (c *Client) FinancialAnalyticsSetCurrency(ctx context.Context, body *SetCurrencyInput) (*FinancialAnalyticsSetCurrencyResponse, error) {
+	out := new(FinancialAnalyticsSetCurrencyResponse)
+	if err := c.do(ctx, "PUT", "/api/v1/analytics/financial/currency", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GenerateBoardReport calls POST /api/v1/reports/board: Generate a monthly board report (draft).
+// It needs the reports:board:create permission.
+func (c *Client) GenerateBoardReport(ctx context.Context, body *GenerateBoardReportInput) (*BoardReport, error) {
+	out := new(BoardReport)
+	if err := c.do(ctx, "POST", "/api/v1/reports/board", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GenerateComplianceReport calls GET /api/v1/compliance/frameworks/{frameworkId}/report: Download an official compliance report (PDF) for a framework.
+// It needs the compliance:controls:read permission.
+func (c *Client) GenerateComplianceReport(ctx context.Context, frameworkID string, params *GenerateComplianceReportParams) ([]byte, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	var out []byte
+	err := c.do(ctx, "GET", "/api/v1/compliance/frameworks/"+url.PathEscape(frameworkID)+"/report", q, nil, &out)
+	return out, err
+}
+
+// GenerateComplianceReportParams are GenerateComplianceReport's query parameters. Zero values are not sent.
+type GenerateComplianceReportParams struct {
+	Locale string
+}
+
+// GenerateRemediationsFromAudit calls POST /api/v1/compliance/audits/{id}/generate-remediations: Auto-generate remediation plans for the gaps under an audit's framework.
+// It needs the compliance:remediations:write permission.
+func (c *Client) GenerateRemediationsFromAudit(ctx context.Context, id string) (*GenerateRemediationsResult, error) {
+	out := new(GenerateRemediationsResult)
+	if err := c.do(ctx, "POST", "/api/v1/compliance/audits/"+url.PathEscape(id)+"/generate-remediations", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAccessPolicy calls GET /api/v1/access-policies/{id}: Get an access policy.
+// It needs one of the roles admin, root.
+func (c *Client) GetAccessPolicy(ctx context.Context, id string) (*AccessPolicy, error) {
+	out := new(AccessPolicy)
+	if err := c.do(ctx, "GET", "/api/v1/access-policies/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAccessSubject calls GET /api/v1/access-policies/subjects/{id}: What policies know about a member.
+// It needs one of the roles admin, root.
+func (c *Client) GetAccessSubject(ctx context.Context, id string) (*AccessSubject, error) {
+	out := new(AccessSubject)
+	if err := c.do(ctx, "GET", "/api/v1/access-policies/subjects/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAsset calls GET /api/v1/assets/{id}: Get asset by ID.
+// It needs the assets:read permission.
+func (c *Client) GetAsset(ctx context.Context, id string) (*Asset, error) {
+	out := new(Asset)
+	if err := c.do(ctx, "GET", "/api/v1/assets/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAssetHistory calls GET /api/v1/assets/{id}/history: Get asset history (snapshots taken before each update/delete).
+// It needs the assets:read permission.
+func (c *Client) GetAssetHistory(ctx context.Context, id string) ([]AssetSnapshot, error) {
+	var out []AssetSnapshot
+	err := c.do(ctx, "GET", "/api/v1/assets/"+url.PathEscape(id)+"/history", nil, nil, &out)
+	return out, err
+}
+
+// GetBoardReport calls GET /api/v1/reports/board/{reportId}: Get a board report (JSON).
+// It needs the reports:board:read permission.
+func (c *Client) GetBoardReport(ctx context.Context, reportID string) (*BoardReport, error) {
+	out := new(BoardReport)
+	if err := c.do(ctx, "GET", "/api/v1/reports/board/"+url.PathEscape(reportID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetComplianceAudit calls GET /api/v1/compliance/audits/{id}: Get a compliance audit.
+// It needs the compliance:audits:read permission.
+func (c *Client) GetComplianceAudit(ctx context.Context, id string) (*ComplianceAudit, error) {
+	out := new(ComplianceAudit)
+	if err := c.do(ctx, "GET", "/api/v1/compliance/audits/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetComplianceControl calls GET /api/v1/compliance/controls/{controlId}: Get a control.
+// It needs the compliance:controls:read permission.
+func (c *Client) GetComplianceControl(ctx context.Context, controlID string) (*ComplianceControl, error) {
+	out := new(ComplianceControl)
+	if err := c.do(ctx, "GET", "/api/v1/compliance/controls/"+url.PathEscape(controlID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetComplianceFramework calls GET /api/v1/compliance/frameworks/{frameworkId}: Get a compliance framework.
+// It needs the compliance:frameworks:read permission.
+func (c *Client) GetComplianceFramework(ctx context.Context, frameworkID string) (*ComplianceFramework, error) {
+	out := new(ComplianceFramework)
+	if err := c.do(ctx, "GET", "/api/v1/compliance/frameworks/"+url.PathEscape(frameworkID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetComplianceProgress calls GET /api/v1/compliance/frameworks/{frameworkId}/progress: Get a tenant's compliance progress for a framework.
+// It needs the compliance:controls:read permission.
+func (c *Client) GetComplianceProgress(ctx context.Context, frameworkID string) (*ComplianceProgress, error) {
+	out := new(ComplianceProgress)
+	if err := c.do(ctx, "GET", "/api/v1/compliance/frameworks/"+url.PathEscape(frameworkID)+"/progress", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetControlTesting calls GET /api/v1/compliance/controls/{controlId}/testing: A control's test plans, recent tests and effectiveness.
+// It needs the compliance:controls:read permission.
+func (c *Client) GetControlTesting(ctx context.Context, controlID string) (*ControlTesting, error) {
+	out := new(ControlTesting)
+	if err := c.do(ctx, "GET", "/api/v1/compliance/controls/"+url.PathEscape(controlID)+"/testing", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCurrentUser calls GET /api/v1/users/me: Get current user profile.
+func (c *Client) GetCurrentUser(ctx context.Context) (*UserDTO, error) {
+	out := new(UserDTO)
+	if err := c.do(ctx, "GET", "/api/v1/users/me", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetDashboardStats calls GET /api/v1/stats: Get dashboard statistics.
+func (c *Client) GetDashboardStats(ctx context.Context) (*DashboardStats, error) {
+	out := new(DashboardStats)
+	if err := c.do(ctx, "GET", "/api/v1/stats", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetFinancialSummary calls GET /api/v1/analytics/financial: Tenant-wide financial posture (CFO/CISO dashboard).
+// It needs the risks:read permission.
+// The organisation's plan must include financial_quantification.
+func (c *Client) GetFinancialSummary(ctx context.Context) (*FinancialSummary, error) {
+	out := new(FinancialSummary)
+	if err := c.do(ctx, "GET", "/api/v1/analytics/financial", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetGamificationProfile calls GET /api/v1/gamification/me: Get current user's gamification profile.
+func (c *Client) GetGamificationProfile(ctx context.Context) (*UserStats, error) {
+	out := new(UserStats)
+	if err := c.do(ctx, "GET", "/api/v1/gamification/me", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetGapAnalysis calls GET /api/v1/compliance/gap-analysis: Analyse d'écarts — every unsatisfied control across the tenant's frameworks.
+// It needs the compliance:controls:read permission.
+func (c *Client) GetGapAnalysis(ctx context.Context, params *GetGapAnalysisParams) (*GapAnalysis, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.FrameworkID != "" {
+			q.Set("framework_id", params.FrameworkID)
+		}
+	}
+	out := new(GapAnalysis)
+	if err := c.do(ctx, "GET", "/api/v1/compliance/gap-analysis", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetGapAnalysisParams are GetGapAnalysis's query parameters. Zero values are not sent.
+type GetGapAnalysisParams struct {
+	FrameworkID string
+}
+
+// GetGroupCategories calls GET /api/v1/group/categories: Risk totals by category across the group.
+// It needs the group:read permission.
+func (c *Client) GetGroupCategories(ctx context.Context) (*GetGroupCategoriesResponse, error) {
+	out := new(GetGroupCategoriesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/group/categories", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetGroupCompliance calls GET /api/v1/group/compliance: Consolidated compliance posture per framework.
+// It needs the group:read permission.
+func (c *Client) GetGroupCompliance(ctx context.Context) (*GetGroupComplianceResponse, error) {
+	out := new(GetGroupComplianceResponse)
+	if err := c.do(ctx, "GET", "/api/v1/group/compliance", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetGroupHeatmap calls GET /api/v1/group/heatmap: Consolidated 5×5 heatmap with per-entity breakdown.
+// It needs the group:read permission.
+func (c *Client) GetGroupHeatmap(ctx context.Context) (*Heatmap, error) {
+	out := new(Heatmap)
+	if err := c.do(ctx, "GET", "/api/v1/group/heatmap", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetGroupMonteCarlo calls GET /api/v1/group/monte-carlo: Portfolio Monte Carlo across the group's entities.
+// It needs the group:read permission.
+func (c *Client) GetGroupMonteCarlo(ctx context.Context, params *GetGroupMonteCarloParams) (*PortfolioSimulation, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Iterations != nil {
+			q.Set("iterations", strconv.FormatInt(*params.Iterations, 10))
+		}
+		if params.Seed != "" {
+			q.Set("seed", params.Seed)
+		}
+	}
+	out := new(PortfolioSimulation)
+	if err := c.do(ctx, "GET", "/api/v1/group/monte-carlo", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetGroupMonteCarloParams are GetGroupMonteCarlo's query parameters. Zero values are not sent.
+type GetGroupMonteCarloParams struct {
+	Iterations *int64
+	Seed       string
+}
+
+// GetImport calls GET /api/v1/imports/{id}: Get a batch with its mapping and first rows.
+// It needs one of the roles admin, root.
+func (c *Client) GetImport(ctx context.Context, id string) (*Staged, error) {
+	out := new(Staged)
+	if err := c.do(ctx, "GET", "/api/v1/imports/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMetrics calls GET /metrics: GET /metrics.
+func (c *Client) GetMetrics(ctx context.Context) error {
+	return c.do(ctx, "GET", "/metrics", nil, nil, nil)
+}
+
+// GetMitigation calls GET /api/v1/mitigations/{id}: Retrieves a mitigation plan by ID.
+// It needs the mitigations:read permission.
+func (c *Client) GetMitigation(ctx context.Context, id string) (*Mitigation, error) {
+	out := new(Mitigation)
+	if err := c.do(ctx, "GET", "/api/v1/mitigations/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMitigationMetrics calls GET /api/v1/stats/mitigation-metrics: Retourne les statistiques sur les mitigations.
+func (c *Client) GetMitigationMetrics(ctx context.Context) (*MitigationMetricsData, error) {
+	out := new(MitigationMetricsData)
+	if err := c.do(ctx, "GET", "/api/v1/stats/mitigation-metrics", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMitigationProgramme calls GET /api/v1/mitigation-programmes/{id}: Get a programme with its links, actions and schedule.
+// It needs the mitigations:read permission.
+func (c *Client) GetMitigationProgramme(ctx context.Context, id string) (*Detail, error) {
+	out := new(Detail)
+	if err := c.do(ctx, "GET", "/api/v1/mitigation-programmes/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMitigationProgrammeProjection calls GET /api/v1/mitigation-programmes/{id}/projection: Project the programme's schedule onto its risks.
+// It needs the mitigations:read permission.
+func (c *Client) GetMitigationProgrammeProjection(ctx context.Context, id string) (*GetMitigationProgrammeProjectionResponse, error) {
+	out := new(GetMitigationProgrammeProjectionResponse)
+	if err := c.do(ctx, "GET", "/api/v1/mitigation-programmes/"+url.PathEscape(id)+"/projection", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOpenAPISpec calls GET /api/v1/openapi.json: This API's OpenAPI 3.1 specification.
+func (c *Client) GetOpenAPISpec(ctx context.Context) (json.RawMessage, error) {
+	var out json.RawMessage
+	err := c.do(ctx, "GET", "/api/v1/openapi.json", nil, nil, &out)
+	return out, err
+}
+
+// GetPlugin calls GET /api/v1/plugins/{id}: Get a plugin package.
+// It needs one of the roles admin, root.
+func (c *Client) GetPlugin(ctx context.Context, id string) (*PluginPackage, error) {
+	out := new(PluginPackage)
+	if err := c.do(ctx, "GET", "/api/v1/plugins/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetPluginInstall calls GET /api/v1/plugin-installs/{id}: Get a plugin install.
+// It needs one of the roles admin, root.
+func (c *Client) GetPluginInstall(ctx context.Context, id string) (*PluginInstall, error) {
+	out := new(PluginInstall)
+	if err := c.do(ctx, "GET", "/api/v1/plugin-installs/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRegisterSnapshot calls GET /api/v1/register-snapshots/{id}: Get a register snapshot.
+// It needs the risks:snapshots:read permission.
+func (c *Client) GetRegisterSnapshot(ctx context.Context, id string) (*RegisterSnapshot, error) {
+	out := new(RegisterSnapshot)
+	if err := c.do(ctx, "GET", "/api/v1/register-snapshots/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRisk calls GET /api/v1/risks/{id}: Get risk details.
+// It needs the risks:read permission.
+func (c *Client) GetRisk(ctx context.Context, id string) (*Risk, error) {
+	out := new(Risk)
+	if err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRiskBowtie calls GET /api/v1/risks/{id}/bowtie: The risk's bowtie as a graph, with barrier health derived now.
+// It needs the risks:read permission.
+func (c *Client) GetRiskBowtie(ctx context.Context, id string) (*Graph, error) {
+	out := new(Graph)
+	if err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/bowtie", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRiskBowtiePDF calls GET /api/v1/risks/{id}/bowtie/pdf: The bowtie as a one-page PDF figure.
+// It needs the risks:read permission.
+func (c *Client) GetRiskBowtiePDF(ctx context.Context, id string, params *GetRiskBowtiePDFParams) ([]byte, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	var out []byte
+	err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/bowtie/pdf", q, nil, &out)
+	return out, err
+}
+
+// GetRiskBowtiePDFParams are GetRiskBowtiePDF's query parameters. Zero values are not sent.
+type GetRiskBowtiePDFParams struct {
+	Locale string
+}
+
+// GetRiskDistribution calls GET /api/v1/stats/risk-distribution: Retourne le nombre de risques par niveau de sévérité.
+func (c *Client) GetRiskDistribution(ctx context.Context) ([]RiskDistributionData, error) {
+	var out []RiskDistributionData
+	err := c.do(ctx, "GET", "/api/v1/stats/risk-distribution", nil, nil, &out)
+	return out, err
+}
+
+// GetRiskFinancial calls GET /api/v1/risks/{id}/financial: Full financial assessment for one risk.
+// It needs the risks:read permission.
+// The organisation's plan must include financial_quantification.
+func (c *Client) GetRiskFinancial(ctx context.Context, id string) (*FinancialAssessment, error) {
+	out := new(FinancialAssessment)
+	if err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/financial", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRiskMatrix calls GET /api/v1/stats/risk-matrix: Get risk matrix data (impact vs probability).
+func (c *Client) GetRiskMatrix(ctx context.Context) ([]RiskMatrixCell, error) {
+	var out []RiskMatrixCell
+	err := c.do(ctx, "GET", "/api/v1/stats/risk-matrix", nil, nil, &out)
+	return out, err
+}
+
+// GetRiskProgrammeProjection calls GET /api/v1/risks/{id}/programme-projection: A risk's expected residual across its programmes.
+// It needs the risks:read permission.
+func (c *Client) GetRiskProgrammeProjection(ctx context.Context, id string) (*RiskProjection, error) {
+	out := new(RiskProjection)
+	if err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/programme-projection", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRiskResidual calls GET /api/v1/risks/{id}/residual: How the risk's residual follows from its mapped controls.
+// It needs the risks:read permission.
+func (c *Client) GetRiskResidual(ctx context.Context, id string) (*ResidualDerivation, error) {
+	out := new(ResidualDerivation)
+	if err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/residual", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRiskScoringWeights calls GET /api/v1/risk-scoring/weights: Tenant's smart-risk factor weights.
+// It needs the risks:read permission.
+func (c *Client) GetRiskScoringWeights(ctx context.Context) (*RiskScoringWeights, error) {
+	out := new(RiskScoringWeights)
+	if err := c.do(ctx, "GET", "/api/v1/risk-scoring/weights", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRiskSmartScore calls GET /api/v1/risks/{id}/smart-score: Multifactor smart risk score + breakdown.
+// It needs the risks:read permission.
+// The organisation's plan must include smart_score.
+func (c *Client) GetRiskSmartScore(ctx context.Context, id string) (*SmartResult, error) {
+	out := new(SmartResult)
+	if err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/smart-score", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRiskTrend calls GET /api/v1/stats/trends: Get global risk trend.
+func (c *Client) GetRiskTrend(ctx context.Context) ([]TrendPoint, error) {
+	var out []TrendPoint
+	err := c.do(ctx, "GET", "/api/v1/stats/trends", nil, nil, &out)
+	return out, err
+}
+
+// GetSIEMDestination calls GET /api/v1/siem-destinations/{id}: Get a SIEM destination with its export status.
+// It needs one of the roles admin, root.
+func (c *Client) GetSIEMDestination(ctx context.Context, id string) (*SIEMDestination, error) {
+	out := new(SIEMDestination)
+	if err := c.do(ctx, "GET", "/api/v1/siem-destinations/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetStatus calls GET /api/v1/status: GET /api/v1/status.
+func (c *Client) GetStatus(ctx context.Context) (*GetStatusResponse, error) {
+	out := new(GetStatusResponse)
+	if err := c.do(ctx, "GET", "/api/v1/status", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetTeam calls GET /api/v1/teams/{id}: Retrieves a specific team with members (admin only).
+// It needs the admin role.
+func (c *Client) GetTeam(ctx context.Context, id string) (*TeamDetailDTO, error) {
+	out := new(TeamDetailDTO)
+	if err := c.do(ctx, "GET", "/api/v1/teams/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetTeams calls GET /api/v1/teams: Retrieves all teams (admin only).
+// It needs the admin role.
+func (c *Client) GetTeams(ctx context.Context) ([]TeamResponseDTO, error) {
+	var out []TeamResponseDTO
+	err := c.do(ctx, "GET", "/api/v1/teams", nil, nil, &out)
+	return out, err
+}
+
+// GetTopVulnerabilities calls GET /api/v1/stats/top-vulnerabilities: Retourne les risques les plus critiques.
+func (c *Client) GetTopVulnerabilities(ctx context.Context, params *GetTopVulnerabilitiesParams) ([]TopVulnerability, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != nil {
+			q.Set("limit", strconv.FormatInt(*params.Limit, 10))
+		}
+	}
+	var out []TopVulnerability
+	err := c.do(ctx, "GET", "/api/v1/stats/top-vulnerabilities", q, nil, &out)
+	return out, err
+}
+
+// GetTopVulnerabilitiesParams are GetTopVulnerabilities's query parameters. Zero values are not sent.
+type GetTopVulnerabilitiesParams struct {
+	Limit *int64
+}
+
+// GetUsers calls GET /api/v1/users: Retrieves the users in the caller's tenant (admin only).
+// It needs the admin role.
+func (c *Client) GetUsers(ctx context.Context) ([]UserResponseDTO, error) {
+	var out []UserResponseDTO
+	err := c.do(ctx, "GET", "/api/v1/users", nil, nil, &out)
+	return out, err
+}
+
+// GetWebhook calls GET /api/v1/webhooks/{id}: Get a webhook subscription.
+// It needs one of the roles admin, root.
+func (c *Client) GetWebhook(ctx context.Context, id string) (*WebhookSubscription, error) {
+	out := new(WebhookSubscription)
+	if err := c.do(ctx, "GET", "/api/v1/webhooks/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetWebhookDelivery calls GET /api/v1/webhook-deliveries/{id}: Get a delivery with the payload it sends.
+// It needs one of the roles admin, root.
+func (c *Client) GetWebhookDelivery(ctx context.Context, id string) (*WebhookDelivery, error) {
+	out := new(WebhookDelivery)
+	if err := c.do(ctx, "GET", "/api/v1/webhook-deliveries/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetWebhookEventSchema calls GET /api/v1/webhook-events/{type}/schema: JSON Schema of an event's envelope.
+// It needs one of the roles admin, root.
+func (c *Client) GetWebhookEventSchema(ctx context.Context, typeArg string) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "GET", "/api/v1/webhook-events/"+url.PathEscape(typeArg)+"/schema", nil, nil, &out)
+	return out, err
+}
+
+// GovernanceAuditCheckpointPublicKey calls GET /api/v1/governance/audit-checkpoints/public-key: The PEM checkpoints verify against.
+// It needs one of the roles admin, root.
+func (c *Client) GovernanceAuditCheckpointPublicKey(ctx context.Context) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "GET", "/api/v1/governance/audit-checkpoints/public-key", nil, nil, &out)
+	return out, err
+}
+
+// GovernanceCancelApproval calls POST /api/v1/governance/approvals/{id}/cancel: Cancel approval.
+func (c *Client) GovernanceCancelApproval(ctx context.Context, id string) (*ApprovalRequest, error) {
+	out := new(ApprovalRequest)
+	if err := c.do(ctx, "POST", "/api/v1/governance/approvals/"+url.PathEscape(id)+"/cancel", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceCreateAuditCheckpoint calls POST /api/v1/governance/audit-checkpoints: Signs the current head now instead of waiting for the hourly sweep.
+// It needs one of the roles admin, root.
+func (c *Client) GovernanceCreateAuditCheckpoint(ctx context.Context) (*AuditCheckpoint, error) {
+	out := new(AuditCheckpoint)
+	if err := c.do(ctx, "POST", "/api/v1/governance/audit-checkpoints", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceCreateDelegation calls POST /api/v1/governance/delegations: Create delegation.
+func (c *Client) GovernanceCreateDelegation(ctx context.Context, body *CreateDelegationBody) (*Delegation, error) {
+	out := new(Delegation)
+	if err := c.do(ctx, "POST", "/api/v1/governance/delegations", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceCreateWorkflow calls POST /api/v1/governance/workflows: Create workflow.
+// It needs one of the roles admin, root.
+func (c *Client) GovernanceCreateWorkflow(ctx context.Context, body *WorkflowBody) (*ApprovalWorkflow, error) {
+	out := new(ApprovalWorkflow)
+	if err := c.do(ctx, "POST", "/api/v1/governance/workflows", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceDecideApproval calls POST /api/v1/governance/approvals/{id}/decide: Decide approval.
+func (c *Client) GovernanceDecideApproval(ctx context.Context, id string, body *DecideApprovalBody) (*ApprovalRequest, error) {
+	out := new(ApprovalRequest)
+	if err := c.do(ctx, "POST", "/api/v1/governance/approvals/"+url.PathEscape(id)+"/decide", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceDeleteWorkflow calls DELETE /api/v1/governance/workflows/{id}: Delete workflow.
+// It needs one of the roles admin, root.
+func (c *Client) GovernanceDeleteWorkflow(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/governance/workflows/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// GovernanceEffectiveDelegatedPermissions calls GET /api/v1/governance/delegations/effective: Defaults to the calling user when delegate_id is omitted.
+func (c *Client) GovernanceEffectiveDelegatedPermissions(ctx context.Context, params *GovernanceEffectiveDelegatedPermissionsParams) (*GovernanceEffectiveDelegatedPermissionsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.DelegateID != "" {
+			q.Set("delegate_id", params.DelegateID)
+		}
+	}
+	out := new(GovernanceEffectiveDelegatedPermissionsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/governance/delegations/effective", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceEffectiveDelegatedPermissionsParams are GovernanceEffectiveDelegatedPermissions's query parameters. Zero values are not sent.
+type GovernanceEffectiveDelegatedPermissionsParams struct {
+	DelegateID string
+}
+
+// GovernanceExportAuditEvents calls GET /api/v1/governance/audit-events/export: Both formats carry a signature and the chain verdict at export time: a CSV gets them as header comment lines and response headers, a JSON export as an envelope.
+// It needs one of the roles admin, root.
+func (c *Client) GovernanceExportAuditEvents(ctx context.Context, params *GovernanceExportAuditEventsParams) (json.RawMessage, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Action != "" {
+			q.Set("action", params.Action)
+		}
+		if params.ActorID != "" {
+			q.Set("actor_id", params.ActorID)
+		}
+		if params.EntityID != "" {
+			q.Set("entity_id", params.EntityID)
+		}
+		if params.EntityType != "" {
+			q.Set("entity_type", params.EntityType)
+		}
+		if params.Format != "" {
+			q.Set("format", params.Format)
+		}
+		if params.From != "" {
+			q.Set("from", params.From)
+		}
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.Offset != "" {
+			q.Set("offset", params.Offset)
+		}
+		if params.RequestID != "" {
+			q.Set("request_id", params.RequestID)
+		}
+		if params.Search != "" {
+			q.Set("search", params.Search)
+		}
+		if params.Source != "" {
+			q.Set("source", params.Source)
+		}
+		if params.To != "" {
+			q.Set("to", params.To)
+		}
+	}
+	var out json.RawMessage
+	err := c.do(ctx, "GET", "/api/v1/governance/audit-events/export", q, nil, &out)
+	return out, err
+}
+
+// GovernanceExportAuditEventsParams are GovernanceExportAuditEvents's query parameters. Zero values are not sent.
+type GovernanceExportAuditEventsParams struct {
+	Action     string
+	ActorID    string
+	EntityID   string
+	EntityType string
+	Format     string
+	From       string
+	Limit      string
+	Offset     string
+	RequestID  string
+	Search     string
+	Source     string
+	To         string
+}
+
+// GovernanceExportAuditSegment calls GET /api/v1/governance/audit-events/segment: A chain segment with its seals and checkpoints, for openrisk-verify.
+// It needs one of the roles admin, root.
+func (c *Client) GovernanceExportAuditSegment(ctx context.Context) (*AuditSegment, error) {
+	out := new(AuditSegment)
+	if err := c.do(ctx, "GET", "/api/v1/governance/audit-events/segment", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceGetApproval calls GET /api/v1/governance/approvals/{id}: Get approval.
+func (c *Client) GovernanceGetApproval(ctx context.Context, id string) (json.RawMessage, error) {
+	var out json.RawMessage
+	err := c.do(ctx, "GET", "/api/v1/governance/approvals/"+url.PathEscape(id), nil, nil, &out)
+	return out, err
+}
+
+// GovernanceGetAuditRetention calls GET /api/v1/governance/audit-retention: Get audit retention.
+// It needs one of the roles admin, root.
+func (c *Client) GovernanceGetAuditRetention(ctx context.Context) (*AuditRetentionPolicy, error) {
+	out := new(AuditRetentionPolicy)
+	if err := c.do(ctx, "GET", "/api/v1/governance/audit-retention", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceListApprovals calls GET /api/v1/governance/approvals: List approvals.
+func (c *Client) GovernanceListApprovals(ctx context.Context, params *GovernanceListApprovalsParams) ([]ApprovalRequest, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.EntityType != "" {
+			q.Set("entity_type", params.EntityType)
+		}
+		if params.Mine != "" {
+			q.Set("mine", params.Mine)
+		}
+		if params.Status != "" {
+			q.Set("status", params.Status)
+		}
+	}
+	var out []ApprovalRequest
+	err := c.do(ctx, "GET", "/api/v1/governance/approvals", q, nil, &out)
+	return out, err
+}
+
+// GovernanceListApprovalsParams are GovernanceListApprovals's query parameters. Zero values are not sent.
+type GovernanceListApprovalsParams struct {
+	EntityType string
+	Mine       string
+	Status     string
+}
+
+// GovernanceListAuditCheckpoints calls GET /api/v1/governance/audit-checkpoints: The latest signed chain heads, newest first.
+// It needs one of the roles admin, root.
+func (c *Client) GovernanceListAuditCheckpoints(ctx context.Context, params *GovernanceListAuditCheckpointsParams) (*GovernanceListAuditCheckpointsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != nil {
+			q.Set("limit", strconv.FormatInt(*params.Limit, 10))
+		}
+	}
+	out := new(GovernanceListAuditCheckpointsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/governance/audit-checkpoints", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceListAuditCheckpointsParams are GovernanceListAuditCheckpoints's query parameters. Zero values are not sent.
+type GovernanceListAuditCheckpointsParams struct {
+	Limit *int64
+}
+
+// GovernanceListAuditEvents calls GET /api/v1/governance/audit-events: List audit events.
+// It needs one of the roles admin, root.
+func (c *Client) GovernanceListAuditEvents(ctx context.Context, params *GovernanceListAuditEventsParams) (*AuditEventsResult, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Action != "" {
+			q.Set("action", params.Action)
+		}
+		if params.ActorID != "" {
+			q.Set("actor_id", params.ActorID)
+		}
+		if params.EntityID != "" {
+			q.Set("entity_id", params.EntityID)
+		}
+		if params.EntityType != "" {
+			q.Set("entity_type", params.EntityType)
+		}
+		if params.From != "" {
+			q.Set("from", params.From)
+		}
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.Offset != "" {
+			q.Set("offset", params.Offset)
+		}
+		if params.RequestID != "" {
+			q.Set("request_id", params.RequestID)
+		}
+		if params.Search != "" {
+			q.Set("search", params.Search)
+		}
+		if params.Source != "" {
+			q.Set("source", params.Source)
+		}
+		if params.To != "" {
+			q.Set("to", params.To)
+		}
+	}
+	out := new(AuditEventsResult)
+	if err := c.do(ctx, "GET", "/api/v1/governance/audit-events", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceListAuditEventsParams are GovernanceListAuditEvents's query parameters. Zero values are not sent.
+type GovernanceListAuditEventsParams struct {
+	Action     string
+	ActorID    string
+	EntityID   string
+	EntityType string
+	From       string
+	Limit      string
+	Offset     string
+	RequestID  string
+	Search     string
+	Source     string
+	To         string
+}
+
+// GovernanceListDelegations calls GET /api/v1/governance/delegations: List delegations.
+func (c *Client) GovernanceListDelegations(ctx context.Context, params *GovernanceListDelegationsParams) ([]Delegation, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Active != "" {
+			q.Set("active", params.Active)
+		}
+		if params.DelegateID != "" {
+			q.Set("delegate_id", params.DelegateID)
+		}
+		if params.DelegatorID != "" {
+			q.Set("delegator_id", params.DelegatorID)
+		}
+	}
+	var out []Delegation
+	err := c.do(ctx, "GET", "/api/v1/governance/delegations", q, nil, &out)
+	return out, err
+}
+
+// GovernanceListDelegationsParams are GovernanceListDelegations's query parameters. Zero values are not sent.
+type GovernanceListDelegationsParams struct {
+	Active      string
+	DelegateID  string
+	DelegatorID string
+}
+
+// GovernanceListRequestTypes calls GET /api/v1/governance/request-types: The shared vocabulary of decisions an organisation routes for sign-off.
+func (c *Client) GovernanceListRequestTypes(ctx context.Context) (*GovernanceListRequestTypesResponse, error) {
+	out := new(GovernanceListRequestTypesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/governance/request-types", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceListWorkflows calls GET /api/v1/governance/workflows: List workflows.
+func (c *Client) GovernanceListWorkflows(ctx context.Context) ([]ApprovalWorkflow, error) {
+	var out []ApprovalWorkflow
+	err := c.do(ctx, "GET", "/api/v1/governance/workflows", nil, nil, &out)
+	return out, err
+}
+
+// GovernanceRevokeDelegation calls POST /api/v1/governance/delegations/{id}/revoke: Revoke delegation.
+func (c *Client) GovernanceRevokeDelegation(ctx context.Context, id string) (*Delegation, error) {
+	out := new(Delegation)
+	if err := c.do(ctx, "POST", "/api/v1/governance/delegations/"+url.PathEscape(id)+"/revoke", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceRunAuditRetention calls POST /api/v1/governance/audit-retention/apply: Applies the window now instead of waiting for the nightly sweep.
+// It needs one of the roles admin, root.
+func (c *Client) GovernanceRunAuditRetention(ctx context.Context) (*PruneResult, error) {
+	out := new(PruneResult)
+	if err := c.do(ctx, "POST", "/api/v1/governance/audit-retention/apply", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceSetAuditRetention calls PUT /api/v1/governance/audit-retention: 0 keeps entries forever.
+// It needs one of the roles admin, root.
+func (c *Client) GovernanceSetAuditRetention(ctx context.Context, body *RetentionBody) (*AuditRetentionPolicy, error) {
+	out := new(AuditRetentionPolicy)
+	if err := c.do(ctx, "PUT", "/api/v1/governance/audit-retention", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceSubmitApproval calls POST /api/v1/governance/approvals: Submit approval.
+func (c *Client) GovernanceSubmitApproval(ctx context.Context, body *SubmitApprovalBody) (*ApprovalRequest, error) {
+	out := new(ApprovalRequest)
+	if err := c.do(ctx, "POST", "/api/v1/governance/approvals", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceUpdateWorkflow calls PUT /api/v1/governance/workflows/{id}: Update workflow.
+// It needs one of the roles admin, root.
+func (c *Client) GovernanceUpdateWorkflow(ctx context.Context, id string, body *WorkflowBody) (*ApprovalWorkflow, error) {
+	out := new(ApprovalWorkflow)
+	if err := c.do(ctx, "PUT", "/api/v1/governance/workflows/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GovernanceVerifyAuditChain calls GET /api/v1/governance/audit-events/verify: Recomputes every hash and every link, and reports exactly where the trail was altered, if anywhere.
+// It needs one of the roles admin, root.
+func (c *Client) GovernanceVerifyAuditChain(ctx context.Context) (*AuditChainReport, error) {
+	out := new(AuditChainReport)
+	if err := c.do(ctx, "GET", "/api/v1/governance/audit-events/verify", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GraphqlQuery calls POST /api/v1/graphql: Run a read-only GraphQL query.
+func (c *Client) GraphqlQuery(ctx context.Context, body *Params) (*GraphapiResult, error) {
+	out := new(GraphapiResult)
+	if err := c.do(ctx, "POST", "/api/v1/graphql", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GraphqlQueryGet calls GET /api/v1/graphql: Run a read-only GraphQL query from URL parameters.
+func (c *Client) GraphqlQueryGet(ctx context.Context, params *GraphqlQueryGetParams) (*GraphapiResult, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Extensions != "" {
+			q.Set("extensions", params.Extensions)
+		}
+		if params.OperationName != "" {
+			q.Set("operationName", params.OperationName)
+		}
+		if params.Query != "" {
+			q.Set("query", params.Query)
+		}
+		if params.Variables != "" {
+			q.Set("variables", params.Variables)
+		}
+	}
+	out := new(GraphapiResult)
+	if err := c.do(ctx, "GET", "/api/v1/graphql", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GraphqlQueryGetParams are GraphqlQueryGet's query parameters. Zero values are not sent.
+type GraphqlQueryGetParams struct {
+	Extensions    string
+	OperationName string
+	Query         string
+	Variables     string
+}
+
+// GraphqlSchema calls GET /api/v1/graphql/schema: The GraphQL schema (SDL).
+func (c *Client) GraphqlSchema(ctx context.Context) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "GET", "/api/v1/graphql/schema", nil, nil, &out)
+	return out, err
+}
+
+// HealthCheck calls GET /api/v1/health: Health check.
+func (c *Client) HealthCheck(ctx context.Context) (*HealthCheckResponse, error) {
+	out := new(HealthCheckResponse)
+	if err := c.do(ctx, "GET", "/api/v1/health", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ImportComplianceCatalog calls POST /api/v1/compliance/frameworks/{frameworkId}/import-catalog: Bulk-import a regulatory catalog's controls into a framework (admin only, M2).
+// It needs the compliance:frameworks:create permission.
+func (c *Client) ImportComplianceCatalog(ctx context.Context, frameworkID string, body *ImportCatalogInput) (*ImportCatalogResult, error) {
+	out := new(ImportCatalogResult)
+	if err := c.do(ctx, "POST", "/api/v1/compliance/frameworks/"+url.PathEscape(frameworkID)+"/import-catalog", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ImportTenantConfig calls POST /api/v1/config/import: Apply a tenant configuration document.
+// It needs one of the roles admin, root.
+func (c *Client) ImportTenantConfig(ctx context.Context, body *Document, params *ImportTenantConfigParams) (*TenantconfigResult, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.DryRun != nil {
+			q.Set("dry_run", strconv.FormatBool(*params.DryRun))
+		}
+	}
+	out := new(TenantconfigResult)
+	if err := c.do(ctx, "POST", "/api/v1/config/import", q, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ImportTenantConfigParams are ImportTenantConfig's query parameters. Zero values are not sent.
+type ImportTenantConfigParams struct {
+	DryRun *bool
+}
+
+// IncidentClassifyRegulatory calls PUT /api/v1/incidents/{id}/regulatory: Records the classification and answers with the clocks it started, re-timed or withdrew, plus the per-profile reasoning.
+// It needs the incidents:update permission.
+func (c *Client) IncidentClassifyRegulatory(ctx context.Context, id string, body *ClassifyBody) (*RegulatoryStatus, error) {
+	out := new(RegulatoryStatus)
+	if err := c.do(ctx, "PUT", "/api/v1/incidents/"+url.PathEscape(id)+"/regulatory", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentCreateIncident calls POST /api/v1/incidents: Creates a new incident.
+// It needs the incidents:create permission.
+func (c *Client) IncidentCreateIncident(ctx context.Context, body *IncidentCreateRequest) (*Incident, error) {
+	out := new(Incident)
+	if err := c.do(ctx, "POST", "/api/v1/incidents", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentCreateIncidentAction calls POST /api/v1/incidents/{id}/actions: Creates a mitigation action.
+// It needs the incidents:update permission.
+func (c *Client) IncidentCreateIncidentAction(ctx context.Context, id string, body *IncidentCreateIncidentActionRequest) (*IncidentAction, error) {
+	out := new(IncidentAction)
+	if err := c.do(ctx, "POST", "/api/v1/incidents/"+url.PathEscape(id)+"/actions", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentCreateRegulatoryProfile calls POST /api/v1/incidents/regulatory/profiles: Create regulatory profile.
+// It needs the incidents:update permission.
+func (c *Client) IncidentCreateRegulatoryProfile(ctx context.Context, body *RegulatoryProfileBody) (*RegulatoryProfile, error) {
+	out := new(RegulatoryProfile)
+	if err := c.do(ctx, "POST", "/api/v1/incidents/regulatory/profiles", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentDeleteIncident calls DELETE /api/v1/incidents/{id}: Deletes an incident.
+// It needs the incidents:delete permission.
+func (c *Client) IncidentDeleteIncident(ctx context.Context, id string) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "DELETE", "/api/v1/incidents/"+url.PathEscape(id), nil, nil, &out)
+	return out, err
+}
+
+// IncidentDeleteRegulatoryProfile calls DELETE /api/v1/incidents/regulatory/profiles/{profileId}: Delete regulatory profile.
+// It needs the incidents:update permission.
+func (c *Client) IncidentDeleteRegulatoryProfile(ctx context.Context, profileID string) error {
+	return c.do(ctx, "DELETE", "/api/v1/incidents/regulatory/profiles/"+url.PathEscape(profileID), nil, nil, nil)
+}
+
+// IncidentGetIncident calls GET /api/v1/incidents/{id}: Retrieves an incident by ID.
+func (c *Client) IncidentGetIncident(ctx context.Context, id string) (*Incident, error) {
+	out := new(Incident)
+	if err := c.do(ctx, "GET", "/api/v1/incidents/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentGetIncidentActions calls GET /api/v1/incidents/{id}/actions: Retrieves incident actions.
+func (c *Client) IncidentGetIncidentActions(ctx context.Context, id string) ([]IncidentAction, error) {
+	var out []IncidentAction
+	err := c.do(ctx, "GET", "/api/v1/incidents/"+url.PathEscape(id)+"/actions", nil, nil, &out)
+	return out, err
+}
+
+// IncidentGetIncidentStats calls GET /api/v1/incidents/stats: Returns incident statistics.
+func (c *Client) IncidentGetIncidentStats(ctx context.Context) (map[string]any, error) {
+	var out map[string]any
+	err := c.do(ctx, "GET", "/api/v1/incidents/stats", nil, nil, &out)
+	return out, err
+}
+
+// IncidentGetIncidentTimeline calls GET /api/v1/incidents/{id}/timeline: Retrieves incident timeline.
+func (c *Client) IncidentGetIncidentTimeline(ctx context.Context, id string) ([]IncidentTimeline, error) {
+	var out []IncidentTimeline
+	err := c.do(ctx, "GET", "/api/v1/incidents/"+url.PathEscape(id)+"/timeline", nil, nil, &out)
+	return out, err
+}
+
+// IncidentGetIncidentsForRisk calls GET /api/v1/risks/{id}/incidents: Retrieves incidents for a specific risk.
+func (c *Client) IncidentGetIncidentsForRisk(ctx context.Context, id string) ([]Incident, error) {
+	var out []Incident
+	err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/incidents", nil, nil, &out)
+	return out, err
+}
+
+// IncidentGetPostMortem calls GET /api/v1/incidents/{id}/post-mortem: Get post mortem.
+func (c *Client) IncidentGetPostMortem(ctx context.Context, id string) (*PostMortemView, error) {
+	out := new(PostMortemView)
+	if err := c.do(ctx, "GET", "/api/v1/incidents/"+url.PathEscape(id)+"/post-mortem", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentGetRegulatoryDraft calls GET /api/v1/incidents/regulatory/clocks/{clockId}/draft: Get regulatory draft.
+func (c *Client) IncidentGetRegulatoryDraft(ctx context.Context, clockID string) (*NotificationDraft, error) {
+	out := new(NotificationDraft)
+	if err := c.do(ctx, "GET", "/api/v1/incidents/regulatory/clocks/"+url.PathEscape(clockID)+"/draft", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentGetRegulatoryStatus calls GET /api/v1/incidents/{id}/regulatory: Get regulatory status.
+func (c *Client) IncidentGetRegulatoryStatus(ctx context.Context, id string) (*RegulatoryStatus, error) {
+	out := new(RegulatoryStatus)
+	if err := c.do(ctx, "GET", "/api/v1/incidents/"+url.PathEscape(id)+"/regulatory", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentLinkRisk calls POST /api/v1/incidents/{id}/risks/{riskId}: Links incident to a risk.
+// It needs the incidents:update permission.
+func (c *Client) IncidentLinkRisk(ctx context.Context, id string, riskID string) (*IncidentLinkRiskResponse, error) {
+	out := new(IncidentLinkRiskResponse)
+	if err := c.do(ctx, "POST", "/api/v1/incidents/"+url.PathEscape(id)+"/risks/"+url.PathEscape(riskID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentListIncidentOrigins calls GET /api/v1/incidents/origins: Backs the "Where do incidents come from?" help page.
+func (c *Client) IncidentListIncidentOrigins(ctx context.Context) (*IncidentListIncidentOriginsResponse, error) {
+	out := new(IncidentListIncidentOriginsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/incidents/origins", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentListIncidents calls GET /api/v1/incidents: Lists incidents with filtering.
+func (c *Client) IncidentListIncidents(ctx context.Context, params *IncidentListIncidentsParams) (*IncidentListIncidentsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != nil {
+			q.Set("limit", strconv.FormatInt(*params.Limit, 10))
+		}
+		if params.Offset != nil {
+			q.Set("offset", strconv.FormatInt(*params.Offset, 10))
+		}
+		if params.RiskID != "" {
+			q.Set("risk_id", params.RiskID)
+		}
+		if params.Severity != "" {
+			q.Set("severity", params.Severity)
+		}
+		if params.Status != "" {
+			q.Set("status", params.Status)
+		}
+		if params.Type != "" {
+			q.Set("type", params.Type)
+		}
+	}
+	out := new(IncidentListIncidentsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/incidents", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentListIncidentsParams are IncidentListIncidents's query parameters. Zero values are not sent.
+type IncidentListIncidentsParams struct {
+	Limit    *int64
+	Offset   *int64
+	RiskID   string
+	Severity string
+	Status   string
+	Type     string
+}
+
+// IncidentListOpenRegulatoryClocks calls GET /api/v1/incidents/regulatory/clocks: Every pending notification across the tenant's incidents, soonest first.
+func (c *Client) IncidentListOpenRegulatoryClocks(ctx context.Context) (*IncidentListOpenRegulatoryClocksResponse, error) {
+	out := new(IncidentListOpenRegulatoryClocksResponse)
+	if err := c.do(ctx, "GET", "/api/v1/incidents/regulatory/clocks", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentListRegulatoryProfiles calls GET /api/v1/incidents/regulatory/profiles: List regulatory profiles.
+func (c *Client) IncidentListRegulatoryProfiles(ctx context.Context) (*IncidentListRegulatoryProfilesResponse, error) {
+	out := new(IncidentListRegulatoryProfilesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/incidents/regulatory/profiles", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentListRegulatoryRegimes calls GET /api/v1/incidents/regulatory/regimes: The built-in regimes with their stages and deadlines, so the settings page shows what each profile will start without duplicating the law in the UI.
+func (c *Client) IncidentListRegulatoryRegimes(ctx context.Context) (*IncidentListRegulatoryRegimesResponse, error) {
+	out := new(IncidentListRegulatoryRegimesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/incidents/regulatory/regimes", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentPublishPostMortem calls POST /api/v1/incidents/{id}/post-mortem/publish: Freezes the review and turns its corrective actions into tracked mitigation plans.
+// It needs the incidents:update permission.
+func (c *Client) IncidentPublishPostMortem(ctx context.Context, id string) (*PublishResult, error) {
+	out := new(PublishResult)
+	if err := c.do(ctx, "POST", "/api/v1/incidents/"+url.PathEscape(id)+"/post-mortem/publish", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentSavePostMortem calls PUT /api/v1/incidents/{id}/post-mortem: Save post mortem.
+// It needs the incidents:update permission.
+func (c *Client) IncidentSavePostMortem(ctx context.Context, id string, body *PostMortemBody) (*PostMortemView, error) {
+	out := new(PostMortemView)
+	if err := c.do(ctx, "PUT", "/api/v1/incidents/"+url.PathEscape(id)+"/post-mortem", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentSubmitRegulatoryNotification calls POST /api/v1/incidents/regulatory/clocks/{clockId}/submit: Stops a clock with the proof of submission: the authority's reference and/or the receipt, uploaded to the evidence library first.
+// It needs the incidents:update permission.
+func (c *Client) IncidentSubmitRegulatoryNotification(ctx context.Context, clockID string, body *SubmitNotificationBody) (*ClockView, error) {
+	out := new(ClockView)
+	if err := c.do(ctx, "POST", "/api/v1/incidents/regulatory/clocks/"+url.PathEscape(clockID)+"/submit", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentUpdateIncident calls PUT /api/v1/incidents/{id}: Updates an incident.
+// It needs the incidents:update permission.
+func (c *Client) IncidentUpdateIncident(ctx context.Context, id string, body *IncidentUpdateRequest) (*Incident, error) {
+	out := new(Incident)
+	if err := c.do(ctx, "PUT", "/api/v1/incidents/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentUpdateIncidentAction calls PUT /api/v1/incidents/{id}/actions/{actionId}: Updates action status.
+// It needs the incidents:update permission.
+func (c *Client) IncidentUpdateIncidentAction(ctx context.Context, id string, actionID string, body *IncidentUpdateIncidentActionRequest) (*IncidentUpdateIncidentActionResponse, error) {
+	out := new(IncidentUpdateIncidentActionResponse)
+	if err := c.do(ctx, "PUT", "/api/v1/incidents/"+url.PathEscape(id)+"/actions/"+url.PathEscape(actionID), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncidentUpdateRegulatoryProfile calls PUT /api/v1/incidents/regulatory/profiles/{profileId}: Update regulatory profile.
+// It needs the incidents:update permission.
+func (c *Client) IncidentUpdateRegulatoryProfile(ctx context.Context, profileID string, body *RegulatoryProfileBody) (*RegulatoryProfile, error) {
+	out := new(RegulatoryProfile)
+	if err := c.do(ctx, "PUT", "/api/v1/incidents/regulatory/profiles/"+url.PathEscape(profileID), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KRICreate calls POST /api/v1/kris: Define a KRI.
+// It needs the risks:update permission.
+func (c *Client) KRICreate(ctx context.Context, body *Input) (*DomainKRI, error) {
+	out := new(DomainKRI)
+	if err := c.do(ctx, "POST", "/api/v1/kris", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KRIDelete calls DELETE /api/v1/kris/{id}: Delete a KRI and its history.
+// It needs the risks:update permission.
+func (c *Client) KRIDelete(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/kris/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// KRIGet calls GET /api/v1/kris/{id}: One KRI with its recent values.
+// It needs the risks:read permission.
+func (c *Client) KRIGet(ctx context.Context, id string, params *KRIGetParams) (*Trend, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Days != nil {
+			q.Set("days", strconv.FormatInt(*params.Days, 10))
+		}
+	}
+	out := new(Trend)
+	if err := c.do(ctx, "GET", "/api/v1/kris/"+url.PathEscape(id), q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KRIGetParams are KRIGet's query parameters. Zero values are not sent.
+type KRIGetParams struct {
+	Days *int64
+}
+
+// KRIImportCSV calls POST /api/v1/kris/{id}/values/csv: Upload values from a CSV file.
+// It needs the risks:update permission.
+func (c *Client) KRIImportCSV(ctx context.Context, id string, form Form) (*RecordResult, error) {
+	out := new(RecordResult)
+	if err := c.do(ctx, "POST", "/api/v1/kris/"+url.PathEscape(id)+"/values/csv", nil, form, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KRIList calls GET /api/v1/kris: List KRIs with their recent values.
+// It needs the risks:read permission.
+func (c *Client) KRIList(ctx context.Context, params *KRIListParams) ([]Trend, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Days != nil {
+			q.Set("days", strconv.FormatInt(*params.Days, 10))
+		}
+	}
+	var out []Trend
+	err := c.do(ctx, "GET", "/api/v1/kris", q, nil, &out)
+	return out, err
+}
+
+// KRIListParams are KRIList's query parameters. Zero values are not sent.
+type KRIListParams struct {
+	Days *int64
+}
+
+// KRIListForRisk calls GET /api/v1/risks/{id}/kris: The KRIs watching one risk, with their recent values.
+// It needs the risks:read permission.
+func (c *Client) KRIListForRisk(ctx context.Context, id string, params *KRIListForRiskParams) ([]Trend, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Days != nil {
+			q.Set("days", strconv.FormatInt(*params.Days, 10))
+		}
+	}
+	var out []Trend
+	err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/kris", q, nil, &out)
+	return out, err
+}
+
+// KRIListForRiskParams are KRIListForRisk's query parameters. Zero values are not sent.
+type KRIListForRiskParams struct {
+	Days *int64
+}
+
+// KRIPushValue calls POST /api/v1/kris/{id}/values: Push a value into a push or csv KRI.
+// It needs the risks:update permission.
+func (c *Client) KRIPushValue(ctx context.Context, id string, body *ValueInput) (*RecordResult, error) {
+	out := new(RecordResult)
+	if err := c.do(ctx, "POST", "/api/v1/kris/"+url.PathEscape(id)+"/values", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KRIRefresh calls POST /api/v1/kris/{id}/refresh: Evaluate a query KRI now.
+// It needs the risks:update permission.
+func (c *Client) KRIRefresh(ctx context.Context, id string) (*RecordResult, error) {
+	out := new(RecordResult)
+	if err := c.do(ctx, "POST", "/api/v1/kris/"+url.PathEscape(id)+"/refresh", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KRIUpdate calls PUT /api/v1/kris/{id}: Replace a KRI's definition.
+// It needs the risks:update permission.
+func (c *Client) KRIUpdate(ctx context.Context, id string, body *Input) (*DomainKRI, error) {
+	out := new(DomainKRI)
+	if err := c.do(ctx, "PUT", "/api/v1/kris/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LinkMitigationProgrammeControl calls POST /api/v1/mitigation-programmes/{id}/controls: Link a compliance control.
+// It needs the mitigations:update permission.
+func (c *Client) LinkMitigationProgrammeControl(ctx context.Context, id string, body *ControlLinkInput) (*ProgrammeControlLink, error) {
+	out := new(ProgrammeControlLink)
+	if err := c.do(ctx, "POST", "/api/v1/mitigation-programmes/"+url.PathEscape(id)+"/controls", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LinkMitigationProgrammeRisk calls POST /api/v1/mitigation-programmes/{id}/risks: Link a risk, or change the reduction expected for it.
+// It needs the mitigations:update permission.
+func (c *Client) LinkMitigationProgrammeRisk(ctx context.Context, id string, body *RiskLinkInput) (*ProgrammeRiskLink, error) {
+	out := new(ProgrammeRiskLink)
+	if err := c.do(ctx, "POST", "/api/v1/mitigation-programmes/"+url.PathEscape(id)+"/risks", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListAccessPolicies calls GET /api/v1/access-policies: List record-level access policies.
+// It needs one of the roles admin, root.
+func (c *Client) ListAccessPolicies(ctx context.Context) (*ListAccessPoliciesResponse, error) {
+	out := new(ListAccessPoliciesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/access-policies", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListAccessPolicyAttributes calls GET /api/v1/access-policies/attributes: Record attributes a condition can test, per resource.
+func (c *Client) ListAccessPolicyAttributes(ctx context.Context) (*ListAccessPolicyAttributesResponse, error) {
+	out := new(ListAccessPolicyAttributesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/access-policies/attributes", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListAssetDependencies calls GET /api/v1/asset-dependencies: List the tenant's asset dependency graph (every directed edge).
+// It needs the assets:read permission.
+func (c *Client) ListAssetDependencies(ctx context.Context) ([]AssetDependency, error) {
+	var out []AssetDependency
+	err := c.do(ctx, "GET", "/api/v1/asset-dependencies", nil, nil, &out)
+	return out, err
+}
+
+// ListAssets calls GET /api/v1/assets: List all assets.
+// It needs the assets:read permission.
+func (c *Client) ListAssets(ctx context.Context, params *ListAssetsParams) ([]Asset, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Category != "" {
+			q.Set("category", params.Category)
+		}
+	}
+	var out []Asset
+	err := c.do(ctx, "GET", "/api/v1/assets", q, nil, &out)
+	return out, err
+}
+
+// ListAssetsParams are ListAssets's query parameters. Zero values are not sent.
+type ListAssetsParams struct {
+	Category string
+}
+
+// ListBoardReports calls GET /api/v1/reports/board: List a tenant's board reports (most recent first).
+// It needs the reports:board:read permission.
+func (c *Client) ListBoardReports(ctx context.Context) ([]BoardReport, error) {
+	var out []BoardReport
+	err := c.do(ctx, "GET", "/api/v1/reports/board", nil, nil, &out)
+	return out, err
+}
+
+// ListComplianceAudits calls GET /api/v1/compliance/audits: List a tenant's compliance audits.
+// It needs the compliance:audits:read permission.
+func (c *Client) ListComplianceAudits(ctx context.Context) ([]ComplianceAudit, error) {
+	var out []ComplianceAudit
+	err := c.do(ctx, "GET", "/api/v1/compliance/audits", nil, nil, &out)
+	return out, err
+}
+
+// ListComplianceCatalogs calls GET /api/v1/compliance/catalogs: List regulatory control catalogs available to import (M2).
+// It needs the compliance:frameworks:read permission.
+func (c *Client) ListComplianceCatalogs(ctx context.Context) ([]CatalogSummary, error) {
+	var out []CatalogSummary
+	err := c.do(ctx, "GET", "/api/v1/compliance/catalogs", nil, nil, &out)
+	return out, err
+}
+
+// ListComplianceControls calls GET /api/v1/compliance/frameworks/{frameworkId}/controls: List a tenant's controls for a framework.
+// It needs the compliance:controls:read permission.
+func (c *Client) ListComplianceControls(ctx context.Context, frameworkID string) ([]ComplianceControl, error) {
+	var out []ComplianceControl
+	err := c.do(ctx, "GET", "/api/v1/compliance/frameworks/"+url.PathEscape(frameworkID)+"/controls", nil, nil, &out)
+	return out, err
+}
+
+// ListComplianceEvidences calls GET /api/v1/compliance/controls/{controlId}/evidences: List evidence attached to a control.
+// It needs the compliance:evidences:read permission.
+func (c *Client) ListComplianceEvidences(ctx context.Context, controlID string) ([]Evidence, error) {
+	var out []Evidence
+	err := c.do(ctx, "GET", "/api/v1/compliance/controls/"+url.PathEscape(controlID)+"/evidences", nil, nil, &out)
+	return out, err
+}
+
+// ListComplianceFrameworks calls GET /api/v1/compliance/frameworks: List global compliance frameworks.
+// It needs the compliance:frameworks:read permission.
+func (c *Client) ListComplianceFrameworks(ctx context.Context, params *ListComplianceFrameworksParams) ([]ComplianceFramework, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Imported != "" {
+			q.Set("imported", params.Imported)
+		}
+	}
+	var out []ComplianceFramework
+	err := c.do(ctx, "GET", "/api/v1/compliance/frameworks", q, nil, &out)
+	return out, err
+}
+
+// ListComplianceFrameworksParams are ListComplianceFrameworks's query parameters. Zero values are not sent.
+type ListComplianceFrameworksParams struct {
+	Imported string
+}
+
+// ListControlMappings calls GET /api/v1/compliance/control-mappings: List cross-framework control mappings.
+// It needs the compliance:controls:read permission.
+func (c *Client) ListControlMappings(ctx context.Context, params *ListControlMappingsParams) ([]ControlCrosswalk, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.ControlID != "" {
+			q.Set("control_id", params.ControlID)
+		}
+	}
+	var out []ControlCrosswalk
+	err := c.do(ctx, "GET", "/api/v1/compliance/control-mappings", q, nil, &out)
+	return out, err
+}
+
+// ListControlMappingsParams are ListControlMappings's query parameters. Zero values are not sent.
+type ListControlMappingsParams struct {
+	ControlID string
+}
+
+// ListDueControlTestPlans calls GET /api/v1/control-test-plans/due: Plans whose next test date has passed.
+// It needs the compliance:controls:read permission.
+func (c *Client) ListDueControlTestPlans(ctx context.Context) ([]ControlTestPlan, error) {
+	var out []ControlTestPlan
+	err := c.do(ctx, "GET", "/api/v1/control-test-plans/due", nil, nil, &out)
+	return out, err
+}
+
+// ListGroupEntities calls GET /api/v1/group/entities: The group tree below this organization, with risk counts.
+// It needs the group:read permission.
+func (c *Client) ListGroupEntities(ctx context.Context) (*ListGroupEntitiesResponse, error) {
+	out := new(ListGroupEntitiesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/group/entities", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListGroupLinks calls GET /api/v1/group/links: Links this organization is either side of.
+// It needs one of the permissions group:read, group:manage.
+func (c *Client) ListGroupLinks(ctx context.Context) (*ListGroupLinksResponse, error) {
+	out := new(ListGroupLinksResponse)
+	if err := c.do(ctx, "GET", "/api/v1/group/links", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListGroupRisks calls GET /api/v1/group/risks: Read-only drill-down into the group register.
+// It needs the group:read permission.
+func (c *Client) ListGroupRisks(ctx context.Context, params *ListGroupRisksParams) (*ListGroupRisksResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Category != "" {
+			q.Set("category", params.Category)
+		}
+		if params.Impact != nil {
+			q.Set("impact", strconv.FormatInt(*params.Impact, 10))
+		}
+		if params.Limit != nil {
+			q.Set("limit", strconv.FormatInt(*params.Limit, 10))
+		}
+		if params.OrgID != "" {
+			q.Set("org_id", params.OrgID)
+		}
+		if params.Probability != nil {
+			q.Set("probability", strconv.FormatInt(*params.Probability, 10))
+		}
+	}
+	out := new(ListGroupRisksResponse)
+	if err := c.do(ctx, "GET", "/api/v1/group/risks", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListGroupRisksParams are ListGroupRisks's query parameters. Zero values are not sent.
+type ListGroupRisksParams struct {
+	Category    string
+	Impact      *int64
+	Limit       *int64
+	OrgID       string
+	Probability *int64
+}
+
+// ListImportProfiles calls GET /api/v1/import-profiles: List saved mapping profiles.
+// It needs one of the roles admin, root.
+func (c *Client) ListImportProfiles(ctx context.Context, params *ListImportProfilesParams) (*ListImportProfilesResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Entity != "" {
+			q.Set("entity", params.Entity)
+		}
+	}
+	out := new(ListImportProfilesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/import-profiles", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListImportProfilesParams are ListImportProfiles's query parameters. Zero values are not sent.
+type ListImportProfilesParams struct {
+	Entity string
+}
+
+// ListImportTargets calls GET /api/v1/imports/targets: Fields a column can be mapped to.
+// It needs one of the roles admin, root.
+func (c *Client) ListImportTargets(ctx context.Context, params *ListImportTargetsParams) (*ListImportTargetsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Entity != "" {
+			q.Set("entity", params.Entity)
+		}
+	}
+	out := new(ListImportTargetsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/imports/targets", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListImportTargetsParams are ListImportTargets's query parameters. Zero values are not sent.
+type ListImportTargetsParams struct {
+	Entity string
+}
+
+// ListImports calls GET /api/v1/imports: List import batches.
+// It needs one of the roles admin, root.
+func (c *Client) ListImports(ctx context.Context) (*ListImportsResponse, error) {
+	out := new(ListImportsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/imports", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListMitigationProgrammes calls GET /api/v1/mitigation-programmes: List mitigation programmes.
+// It needs the mitigations:read permission.
+func (c *Client) ListMitigationProgrammes(ctx context.Context) (*ListMitigationProgrammesResponse, error) {
+	out := new(ListMitigationProgrammesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/mitigation-programmes", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListMitigations calls GET /api/v1/mitigations: Retrieves all mitigations for the tenant, optionally filtered by status/priority/risk_id.
+// It needs the mitigations:read permission.
+func (c *Client) ListMitigations(ctx context.Context, params *ListMitigationsParams) (*ListMitigationsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Mine != "" {
+			q.Set("mine", params.Mine)
+		}
+		if params.Priority != "" {
+			q.Set("priority", params.Priority)
+		}
+		if params.RiskID != "" {
+			q.Set("risk_id", params.RiskID)
+		}
+		if params.Status != "" {
+			q.Set("status", params.Status)
+		}
+	}
+	out := new(ListMitigationsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/mitigations", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListMitigationsParams are ListMitigations's query parameters. Zero values are not sent.
+type ListMitigationsParams struct {
+	Mine     string
+	Priority string
+	RiskID   string
+	Status   string
+}
+
+// ListMitigationsByRisk calls GET /api/v1/risks/{id}/mitigations: Retrieves all mitigations for a risk.
+// It needs the mitigations:read permission.
+func (c *Client) ListMitigationsByRisk(ctx context.Context, id string) ([]Mitigation, error) {
+	var out []Mitigation
+	err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/mitigations", nil, nil, &out)
+	return out, err
+}
+
+// ListPluginInstalls calls GET /api/v1/plugin-installs: List plugin installs.
+// It needs one of the roles admin, root.
+func (c *Client) ListPluginInstalls(ctx context.Context) (*ListPluginInstallsResponse, error) {
+	out := new(ListPluginInstallsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/plugin-installs", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListPluginRuns calls GET /api/v1/plugin-installs/{id}/runs: An install's last 50 runs.
+// It needs one of the roles admin, root.
+func (c *Client) ListPluginRuns(ctx context.Context, id string) (*ListPluginRunsResponse, error) {
+	out := new(ListPluginRunsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/plugin-installs/"+url.PathEscape(id)+"/runs", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListPlugins calls GET /api/v1/plugins: List uploaded plugin packages.
+// It needs one of the roles admin, root.
+func (c *Client) ListPlugins(ctx context.Context) (*ListPluginsResponse, error) {
+	out := new(ListPluginsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/plugins", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListProgrammeCapacities calls GET /api/v1/programme-capacities: List assignee capacities.
+// It needs the mitigations:read permission.
+func (c *Client) ListProgrammeCapacities(ctx context.Context) (*ListProgrammeCapacitiesResponse, error) {
+	out := new(ListProgrammeCapacitiesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/programme-capacities", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListRegisterSnapshotRisks calls GET /api/v1/register-snapshots/{id}/risks: Page through a frozen register.
+// It needs the risks:snapshots:read permission.
+func (c *Client) ListRegisterSnapshotRisks(ctx context.Context, id string, params *ListRegisterSnapshotRisksParams) (*Page, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Criticality != "" {
+			q.Set("criticality", params.Criticality)
+		}
+		if params.Limit != nil {
+			q.Set("limit", strconv.FormatInt(*params.Limit, 10))
+		}
+		if params.MinScore != "" {
+			q.Set("min_score", params.MinScore)
+		}
+		if params.OwnerID != "" {
+			q.Set("owner_id", params.OwnerID)
+		}
+		if params.Page != nil {
+			q.Set("page", strconv.FormatInt(*params.Page, 10))
+		}
+		if params.Q != "" {
+			q.Set("q", params.Q)
+		}
+		if params.Status != "" {
+			q.Set("status", params.Status)
+		}
+	}
+	out := new(Page)
+	if err := c.do(ctx, "GET", "/api/v1/register-snapshots/"+url.PathEscape(id)+"/risks", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListRegisterSnapshotRisksParams are ListRegisterSnapshotRisks's query parameters. Zero values are not sent.
+type ListRegisterSnapshotRisksParams struct {
+	Criticality string
+	Limit       *int64
+	MinScore    string
+	OwnerID     string
+	Page        *int64
+	Q           string
+	Status      string
+}
+
+// ListRegisterSnapshots calls GET /api/v1/register-snapshots: List register snapshots, newest first.
+// It needs the risks:snapshots:read permission.
+func (c *Client) ListRegisterSnapshots(ctx context.Context) ([]RegisterSnapshot, error) {
+	var out []RegisterSnapshot
+	err := c.do(ctx, "GET", "/api/v1/register-snapshots", nil, nil, &out)
+	return out, err
+}
+
+// ListRemediations calls GET /api/v1/compliance/remediations: List a tenant's remediation plans.
+// It needs the compliance:remediations:read permission.
+func (c *Client) ListRemediations(ctx context.Context, params *ListRemediationsParams) ([]RemediationPlan, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.AuditID != "" {
+			q.Set("audit_id", params.AuditID)
+		}
+		if params.ControlID != "" {
+			q.Set("control_id", params.ControlID)
+		}
+		if params.FrameworkID != "" {
+			q.Set("framework_id", params.FrameworkID)
+		}
+		if params.Status != "" {
+			q.Set("status", params.Status)
+		}
+	}
+	var out []RemediationPlan
+	err := c.do(ctx, "GET", "/api/v1/compliance/remediations", q, nil, &out)
+	return out, err
+}
+
+// ListRemediationsParams are ListRemediations's query parameters. Zero values are not sent.
+type ListRemediationsParams struct {
+	AuditID     string
+	ControlID   string
+	FrameworkID string
+	Status      string
+}
+
+// ListRisks calls GET /api/v1/risks: List all risks.
+// It needs the risks:read permission.
+func (c *Client) ListRisks(ctx context.Context, params *ListRisksParams) (*ListRisksResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.AssignedTo != "" {
+			q.Set("assigned_to", params.AssignedTo)
+		}
+		if params.CategoryID != "" {
+			q.Set("category_id", params.CategoryID)
+		}
+		if params.Criticality != "" {
+			q.Set("criticality", params.Criticality)
+		}
+		if params.Framework != "" {
+			q.Set("framework", params.Framework)
+		}
+		if params.FrameworkID != "" {
+			q.Set("framework_id", params.FrameworkID)
+		}
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.MaxScore != "" {
+			q.Set("max_score", params.MaxScore)
+		}
+		if params.MinScore != "" {
+			q.Set("min_score", params.MinScore)
+		}
+		if params.Mine != "" {
+			q.Set("mine", params.Mine)
+		}
+		if params.OwnerID != "" {
+			q.Set("owner_id", params.OwnerID)
+		}
+		if params.Page != "" {
+			q.Set("page", params.Page)
+		}
+		if params.Phase != "" {
+			q.Set("phase", params.Phase)
+		}
+		if params.Q != "" {
+			q.Set("q", params.Q)
+		}
+		if params.ReviewerID != "" {
+			q.Set("reviewer_id", params.ReviewerID)
+		}
+		if params.SortBy != "" {
+			q.Set("sort_by", params.SortBy)
+		}
+		if params.SortDir != "" {
+			q.Set("sort_dir", params.SortDir)
+		}
+		if params.Source != "" {
+			q.Set("source", params.Source)
+		}
+		if params.Status != "" {
+			q.Set("status", params.Status)
+		}
+		if params.Tag != "" {
+			q.Set("tag", params.Tag)
+		}
+		if params.Unmapped != "" {
+			q.Set("unmapped", params.Unmapped)
+		}
+	}
+	out := new(ListRisksResponse)
+	if err := c.do(ctx, "GET", "/api/v1/risks", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListRisksParams are ListRisks's query parameters. Zero values are not sent.
+type ListRisksParams struct {
+	AssignedTo  string
+	CategoryID  string
+	Criticality string
+	Framework   string
+	FrameworkID string
+	Limit       string
+	MaxScore    string
+	MinScore    string
+	Mine        string
+	OwnerID     string
+	Page        string
+	Phase       string
+	Q           string
+	ReviewerID  string
+	SortBy      string
+	SortDir     string
+	Source      string
+	Status      string
+	Tag         string
+	Unmapped    string
+}
+
+// ListSIEMDestinations calls GET /api/v1/siem-destinations: List SIEM destinations.
+// It needs one of the roles admin, root.
+func (c *Client) ListSIEMDestinations(ctx context.Context) (*ListSIEMDestinationsResponse, error) {
+	out := new(ListSIEMDestinationsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/siem-destinations", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListWebhookDeliveries calls GET /api/v1/webhooks/{id}/deliveries: A subscription's last 100 deliveries.
+// It needs one of the roles admin, root.
+func (c *Client) ListWebhookDeliveries(ctx context.Context, id string) (*ListWebhookDeliveriesResponse, error) {
+	out := new(ListWebhookDeliveriesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/webhooks/"+url.PathEscape(id)+"/deliveries", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListWebhookEvents calls GET /api/v1/webhook-events: Event types a subscription can receive.
+// It needs one of the roles admin, root.
+func (c *Client) ListWebhookEvents(ctx context.Context) (*ListWebhookEventsResponse, error) {
+	out := new(ListWebhookEventsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/webhook-events", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListWebhooks calls GET /api/v1/webhooks: List webhook subscriptions.
+// It needs one of the roles admin, root.
+func (c *Client) ListWebhooks(ctx context.Context) (*ListWebhooksResponse, error) {
+	out := new(ListWebhooksResponse)
+	if err := c.do(ctx, "GET", "/api/v1/webhooks", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Login calls POST /api/v1/auth/login: Login with email and password.
+func (c *Client) Login(ctx context.Context, body *LoginRequest) (json.RawMessage, error) {
+	var out json.RawMessage
+	err := c.do(ctx, "POST", "/api/v1/auth/login", nil, body, &out)
+	return out, err
+}
+
+// Logout calls POST /api/v1/auth/logout: User logout.
+func (c *Client) Logout(ctx context.Context, body *LogoutRequest) (*LogoutResponse, error) {
+	out := new(LogoutResponse)
+	if err := c.do(ctx, "POST", "/api/v1/auth/logout", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MFAChallenge calls POST /api/v1/auth/mfa/challenge: The second leg of an MFA login.
+func (c *Client) MFAChallenge(ctx context.Context, body *MFAChallengeRequest) (*LoginResponse, error) {
+	out := new(LoginResponse)
+	if err := c.do(ctx, "POST", "/api/v1/auth/mfa/challenge", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MFADisable calls POST /api/v1/auth/mfa/disable: Turns MFA off for the current user.
+func (c *Client) MFADisable(ctx context.Context, body *MFADisableRequest) (*DisableMFAOutput, error) {
+	out := new(DisableMFAOutput)
+	if err := c.do(ctx, "POST", "/api/v1/auth/mfa/disable", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MFASetup calls POST /api/v1/auth/mfa/setup: Begins MFA enrollment: returns the TOTP secret, a QR code and 8 backup codes.
+func (c *Client) MFASetup(ctx context.Context) (*SetupMFAOutput, error) {
+	out := new(SetupMFAOutput)
+	if err := c.do(ctx, "POST", "/api/v1/auth/mfa/setup", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MFAVerify calls POST /api/v1/auth/mfa/verify: Activates MFA after the user confirms a TOTP code from their app.
+func (c *Client) MFAVerify(ctx context.Context, body *MFAVerifyRequest) (json.RawMessage, error) {
+	var out json.RawMessage
+	err := c.do(ctx, "POST", "/api/v1/auth/mfa/verify", nil, body, &out)
+	return out, err
+}
+
+// MarketplaceAddConnectorReview calls POST /api/v1/marketplace/connectors/{id}/reviews: Add connector review.
+func (c *Client) MarketplaceAddConnectorReview(ctx context.Context, id string, body *MarketplaceAddConnectorReviewRequest) (*MarketplaceAddConnectorReviewResponse, error) {
+	out := new(MarketplaceAddConnectorReviewResponse)
+	if err := c.do(ctx, "POST", "/api/v1/marketplace/connectors/"+url.PathEscape(id)+"/reviews", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarketplaceDisableApp calls POST /api/v1/marketplace/apps/{id}/disable: Disable app.
+// It needs one of the roles admin, analyst.
+func (c *Client) MarketplaceDisableApp(ctx context.Context, id string) (*MarketplaceDisableAppResponse, error) {
+	out := new(MarketplaceDisableAppResponse)
+	if err := c.do(ctx, "POST", "/api/v1/marketplace/apps/"+url.PathEscape(id)+"/disable", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarketplaceEnableApp calls POST /api/v1/marketplace/apps/{id}/enable: Enable app.
+// It needs one of the roles admin, analyst.
+func (c *Client) MarketplaceEnableApp(ctx context.Context, id string) (*MarketplaceEnableAppResponse, error) {
+	out := new(MarketplaceEnableAppResponse)
+	if err := c.do(ctx, "POST", "/api/v1/marketplace/apps/"+url.PathEscape(id)+"/enable", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarketplaceGetApp calls GET /api/v1/marketplace/apps/{id}: Get app details.
+// It needs one of the roles admin, analyst.
+func (c *Client) MarketplaceGetApp(ctx context.Context, id string) (*MarketplaceApp, error) {
+	out := new(MarketplaceApp)
+	if err := c.do(ctx, "GET", "/api/v1/marketplace/apps/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarketplaceGetAppLogs calls GET /api/v1/marketplace/apps/{id}/logs: Get app logs.
+// It needs one of the roles admin, analyst.
+func (c *Client) MarketplaceGetAppLogs(ctx context.Context, id string, params *MarketplaceGetAppLogsParams) (*MarketplaceGetAppLogsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Action != "" {
+			q.Set("action", params.Action)
+		}
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.Offset != "" {
+			q.Set("offset", params.Offset)
+		}
+	}
+	out := new(MarketplaceGetAppLogsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/marketplace/apps/"+url.PathEscape(id)+"/logs", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarketplaceGetAppLogsParams are MarketplaceGetAppLogs's query parameters. Zero values are not sent.
+type MarketplaceGetAppLogsParams struct {
+	Action string
+	Limit  string
+	Offset string
+}
+
+// MarketplaceGetConnector calls GET /api/v1/marketplace/connectors/{id}: Get connector details.
+func (c *Client) MarketplaceGetConnector(ctx context.Context, id string) (*Connector, error) {
+	out := new(Connector)
+	if err := c.do(ctx, "GET", "/api/v1/marketplace/connectors/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarketplaceInstallApp calls POST /api/v1/marketplace/apps: Install connector.
+// It needs one of the roles admin, analyst.
+func (c *Client) MarketplaceInstallApp(ctx context.Context, body *MarketplaceInstallAppRequest) (*MarketplaceApp, error) {
+	out := new(MarketplaceApp)
+	if err := c.do(ctx, "POST", "/api/v1/marketplace/apps", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarketplaceListApps calls GET /api/v1/marketplace/apps: List installed apps.
+// It needs one of the roles admin, analyst.
+func (c *Client) MarketplaceListApps(ctx context.Context, params *MarketplaceListAppsParams) (*MarketplaceListAppsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != nil {
+			q.Set("limit", strconv.FormatInt(*params.Limit, 10))
+		}
+		if params.Offset != nil {
+			q.Set("offset", strconv.FormatInt(*params.Offset, 10))
+		}
+	}
+	out := new(MarketplaceListAppsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/marketplace/apps", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarketplaceListAppsParams are MarketplaceListApps's query parameters. Zero values are not sent.
+type MarketplaceListAppsParams struct {
+	Limit  *int64
+	Offset *int64
+}
+
+// MarketplaceListConnectors calls GET /api/v1/marketplace/connectors: List marketplace connectors.
+func (c *Client) MarketplaceListConnectors(ctx context.Context, params *MarketplaceListConnectorsParams) (*MarketplaceListConnectorsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Category != "" {
+			q.Set("category", params.Category)
+		}
+		if params.Limit != nil {
+			q.Set("limit", strconv.FormatInt(*params.Limit, 10))
+		}
+		if params.Offset != nil {
+			q.Set("offset", strconv.FormatInt(*params.Offset, 10))
+		}
+		if params.Status != "" {
+			q.Set("status", params.Status)
+		}
+	}
+	out := new(MarketplaceListConnectorsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/marketplace/connectors", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarketplaceListConnectorsParams are MarketplaceListConnectors's query parameters. Zero values are not sent.
+type MarketplaceListConnectorsParams struct {
+	Category string
+	Limit    *int64
+	Offset   *int64
+	Status   string
+}
+
+// MarketplaceSearchConnectors calls GET /api/v1/marketplace/connectors/search: Search connectors.
+func (c *Client) MarketplaceSearchConnectors(ctx context.Context, params *MarketplaceSearchConnectorsParams) (*MarketplaceSearchConnectorsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != nil {
+			q.Set("limit", strconv.FormatInt(*params.Limit, 10))
+		}
+		if params.Offset != nil {
+			q.Set("offset", strconv.FormatInt(*params.Offset, 10))
+		}
+		if params.Q != "" {
+			q.Set("q", params.Q)
+		}
+	}
+	out := new(MarketplaceSearchConnectorsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/marketplace/connectors/search", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarketplaceSearchConnectorsParams are MarketplaceSearchConnectors's query parameters. Zero values are not sent.
+type MarketplaceSearchConnectorsParams struct {
+	Limit  *int64
+	Offset *int64
+	Q      string
+}
+
+// MarketplaceTriggerSync calls POST /api/v1/marketplace/apps/{id}/sync: Trigger manual sync.
+// It needs one of the roles admin, analyst.
+func (c *Client) MarketplaceTriggerSync(ctx context.Context, id string) (*MarketplaceTriggerSyncResponse, error) {
+	out := new(MarketplaceTriggerSyncResponse)
+	if err := c.do(ctx, "POST", "/api/v1/marketplace/apps/"+url.PathEscape(id)+"/sync", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarketplaceUninstallApp calls DELETE /api/v1/marketplace/apps/{id}: Uninstall app.
+// It needs one of the roles admin, analyst.
+func (c *Client) MarketplaceUninstallApp(ctx context.Context, id string) (*MarketplaceUninstallAppResponse, error) {
+	out := new(MarketplaceUninstallAppResponse)
+	if err := c.do(ctx, "DELETE", "/api/v1/marketplace/apps/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarketplaceUpdateApp calls PUT /api/v1/marketplace/apps/{id}: Update app configuration.
+// It needs one of the roles admin, analyst.
+func (c *Client) MarketplaceUpdateApp(ctx context.Context, id string, body *MarketplaceUpdateAppRequest) (*MarketplaceApp, error) {
+	out := new(MarketplaceApp)
+	if err := c.do(ctx, "PUT", "/api/v1/marketplace/apps/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarketplaceUpdateAppSync calls PUT /api/v1/marketplace/apps/{id}/sync: Update sync configuration.
+// It needs one of the roles admin, analyst.
+func (c *Client) MarketplaceUpdateAppSync(ctx context.Context, id string, body *MarketplaceUpdateAppSyncRequest) (*MarketplaceUpdateAppSyncResponse, error) {
+	out := new(MarketplaceUpdateAppSyncResponse)
+	if err := c.do(ctx, "PUT", "/api/v1/marketplace/apps/"+url.PathEscape(id)+"/sync", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Me calls GET /api/v1/auth/me: Get current user profile.
+func (c *Client) Me(ctx context.Context) (json.RawMessage, error) {
+	var out json.RawMessage
+	err := c.do(ctx, "GET", "/api/v1/auth/me", nil, nil, &out)
+	return out, err
+}
+
+// NotificationDeleteNotification calls DELETE /api/v1/notifications/{notificationId}: Deletes a notification.
+func (c *Client) NotificationDeleteNotification(ctx context.Context, notificationID string) (*NotificationDeleteNotificationResponse, error) {
+	out := new(NotificationDeleteNotificationResponse)
+	if err := c.do(ctx, "DELETE", "/api/v1/notifications/"+url.PathEscape(notificationID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NotificationGetNotificationPreferences calls GET /api/v1/notifications/preferences: Retrieves user's notification preferences.
+func (c *Client) NotificationGetNotificationPreferences(ctx context.Context) (*NotificationPreference, error) {
+	out := new(NotificationPreference)
+	if err := c.do(ctx, "GET", "/api/v1/notifications/preferences", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NotificationGetNotifications calls GET /api/v1/notifications: Retrieves user's notifications.
+func (c *Client) NotificationGetNotifications(ctx context.Context, params *NotificationGetNotificationsParams) (*NotificationGetNotificationsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != nil {
+			q.Set("limit", strconv.FormatInt(*params.Limit, 10))
+		}
+		if params.Offset != nil {
+			q.Set("offset", strconv.FormatInt(*params.Offset, 10))
+		}
+	}
+	out := new(NotificationGetNotificationsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/notifications", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NotificationGetNotificationsParams are NotificationGetNotifications's query parameters. Zero values are not sent.
+type NotificationGetNotificationsParams struct {
+	Limit  *int64
+	Offset *int64
+}
+
+// NotificationGetUnreadCount calls GET /api/v1/notifications/unread-count: Retrieves count of unread notifications.
+func (c *Client) NotificationGetUnreadCount(ctx context.Context) (*NotificationGetUnreadCountResponse, error) {
+	out := new(NotificationGetUnreadCountResponse)
+	if err := c.do(ctx, "GET", "/api/v1/notifications/unread-count", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NotificationMarkAllAsRead calls PATCH /api/v1/notifications/read-all: Marks all notifications as read.
+func (c *Client) NotificationMarkAllAsRead(ctx context.Context) (*NotificationMarkAllAsReadResponse, error) {
+	out := new(NotificationMarkAllAsReadResponse)
+	if err := c.do(ctx, "PATCH", "/api/v1/notifications/read-all", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NotificationMarkAsRead calls PATCH /api/v1/notifications/{notificationId}/read: Marks a notification as read.
+func (c *Client) NotificationMarkAsRead(ctx context.Context, notificationID string) (*NotificationMarkAsReadResponse, error) {
+	out := new(NotificationMarkAsReadResponse)
+	if err := c.do(ctx, "PATCH", "/api/v1/notifications/"+url.PathEscape(notificationID)+"/read", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NotificationTestNotification calls POST /api/v1/notifications/test: Sends a test notification.
+func (c *Client) NotificationTestNotification(ctx context.Context, body *TestRequest) (*NotificationTestNotificationResponse, error) {
+	out := new(NotificationTestNotificationResponse)
+	if err := c.do(ctx, "POST", "/api/v1/notifications/test", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NotificationUpdateNotificationPreferences calls PATCH /api/v1/notifications/preferences: Updates user's notification preferences.
+func (c *Client) NotificationUpdateNotificationPreferences(ctx context.Context, body *UpdateRequest) (*NotificationPreference, error) {
+	out := new(NotificationPreference)
+	if err := c.do(ctx, "PATCH", "/api/v1/notifications/preferences", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OAuth2Callback calls GET /api/v1/auth/oauth2/callback/{provider}: The provider redirect back.
+func (c *Client) OAuth2Callback(ctx context.Context, provider string, params *OAuth2CallbackParams) (*OAuth2CallbackResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Code != "" {
+			q.Set("code", params.Code)
+		}
+		if params.Error != "" {
+			q.Set("error", params.Error)
+		}
+		if params.Lang != "" {
+			q.Set("lang", params.Lang)
+		}
+		if params.State != "" {
+			q.Set("state", params.State)
+		}
+	}
+	out := new(OAuth2CallbackResponse)
+	if err := c.do(ctx, "GET", "/api/v1/auth/oauth2/callback/"+url.PathEscape(provider), q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OAuth2CallbackParams are OAuth2Callback's query parameters. Zero values are not sent.
+type OAuth2CallbackParams struct {
+	Code  string
+	Error string
+	Lang  string
+	State string
+}
+
+// OAuth2Login calls GET /api/v1/auth/oauth2/login/{provider}: Initiates the OAuth2 flow.
+func (c *Client) OAuth2Login(ctx context.Context, provider string, params *OAuth2LoginParams) error {
+	q := url.Values{}
+	if params != nil {
+		if params.Lang != "" {
+			q.Set("lang", params.Lang)
+		}
+		if params.ReturnTo != "" {
+			q.Set("return_to", params.ReturnTo)
+		}
+	}
+	return c.do(ctx, "GET", "/api/v1/auth/oauth2/login/"+url.PathEscape(provider), q, nil, nil)
+}
+
+// OAuth2LoginParams are OAuth2Login's query parameters. Zero values are not sent.
+type OAuth2LoginParams struct {
+	Lang     string
+	ReturnTo string
+}
+
+// OrgDeletionCancelDeletion calls POST /api/v1/organization/deletion/cancel: Cancel deletion.
+// It needs the admin role.
+func (c *Client) OrgDeletionCancelDeletion(ctx context.Context) (*OrgDeletionCancelDeletionResponse, error) {
+	out := new(OrgDeletionCancelDeletionResponse)
+	if err := c.do(ctx, "POST", "/api/v1/organization/deletion/cancel", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrgDeletionExportOrganization calls GET /api/v1/organization/export: → a downloadable JSON bundle of the tenant's data (also the export the deletion flow requires).
+// It needs the admin role.
+func (c *Client) OrgDeletionExportOrganization(ctx context.Context) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "GET", "/api/v1/organization/export", nil, nil, &out)
+	return out, err
+}
+
+// OrgDeletionGetDeletion calls GET /api/v1/organization/deletion: → the pending request or null.
+// It needs the admin role.
+func (c *Client) OrgDeletionGetDeletion(ctx context.Context) (json.RawMessage, error) {
+	var out json.RawMessage
+	err := c.do(ctx, "GET", "/api/v1/organization/deletion", nil, nil, &out)
+	return out, err
+}
+
+// OrgDeletionRequestDeletion calls POST /api/v1/organization/deletion: Request deletion.
+// It needs the admin role.
+func (c *Client) OrgDeletionRequestDeletion(ctx context.Context, body *DeletionInput) (*OrgDeletionRequestDeletionResponse, error) {
+	out := new(OrgDeletionRequestDeletionResponse)
+	if err := c.do(ctx, "POST", "/api/v1/organization/deletion", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizationMemberAcceptInvitation calls POST /api/v1/invitations/accept: Mounted outside the authenticated group so an invitee with no account can redeem their link.
+func (c *Client) OrganizationMemberAcceptInvitation(ctx context.Context, body *OrganizationMemberAcceptInvitationRequest) (*AcceptResult, error) {
+	out := new(AcceptResult)
+	if err := c.do(ctx, "POST", "/api/v1/invitations/accept", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizationMemberCreateInvitation calls POST /api/v1/organization/invitations: Create invitation.
+// It needs the organization:members:invite permission.
+func (c *Client) OrganizationMemberCreateInvitation(ctx context.Context, body *OrganizationMemberCreateInvitationRequest, params *OrganizationMemberCreateInvitationParams) (*InviteResult, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(InviteResult)
+	if err := c.do(ctx, "POST", "/api/v1/organization/invitations", q, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizationMemberCreateInvitationParams are OrganizationMemberCreateInvitation's query parameters. Zero values are not sent.
+type OrganizationMemberCreateInvitationParams struct {
+	Locale string
+}
+
+// OrganizationMemberGetCounts calls GET /api/v1/organization/counts: What the sidebar reads.
+func (c *Client) OrganizationMemberGetCounts(ctx context.Context) (*OrganizationCounts, error) {
+	out := new(OrganizationCounts)
+	if err := c.do(ctx, "GET", "/api/v1/organization/counts", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizationMemberGetMember calls GET /api/v1/organization/members/{memberId}: Get member.
+// It needs the organization:members:read permission.
+func (c *Client) OrganizationMemberGetMember(ctx context.Context, memberID string) (*MemberView, error) {
+	out := new(MemberView)
+	if err := c.do(ctx, "GET", "/api/v1/organization/members/"+url.PathEscape(memberID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizationMemberGetMembershipAudit calls GET /api/v1/organization/members/audit: Get membership audit.
+// It needs the organization:audit:read permission.
+func (c *Client) OrganizationMemberGetMembershipAudit(ctx context.Context, params *OrganizationMemberGetMembershipAuditParams) (*PageAuditEntryView, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Action != "" {
+			q.Set("action", params.Action)
+		}
+		if params.EntityID != "" {
+			q.Set("entity_id", params.EntityID)
+		}
+		if params.EntityType != "" {
+			q.Set("entity_type", params.EntityType)
+		}
+		if params.Q != "" {
+			q.Set("q", params.Q)
+		}
+	}
+	out := new(PageAuditEntryView)
+	if err := c.do(ctx, "GET", "/api/v1/organization/members/audit", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizationMemberGetMembershipAuditParams are OrganizationMemberGetMembershipAudit's query parameters. Zero values are not sent.
+type OrganizationMemberGetMembershipAuditParams struct {
+	Action     string
+	EntityID   string
+	EntityType string
+	Q          string
+}
+
+// OrganizationMemberGetOrganization calls GET /api/v1/organization: Get organization.
+// It needs one of the permissions organization:read, organization:members:read.
+func (c *Client) OrganizationMemberGetOrganization(ctx context.Context) (*OrganizationView, error) {
+	out := new(OrganizationView)
+	if err := c.do(ctx, "GET", "/api/v1/organization", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizationMemberListInvitations calls GET /api/v1/organization/invitations: List invitations.
+// It needs the organization:members:read permission.
+func (c *Client) OrganizationMemberListInvitations(ctx context.Context, params *OrganizationMemberListInvitationsParams) (*PageInvitationView, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Email != "" {
+			q.Set("email", params.Email)
+		}
+		if params.Status != "" {
+			q.Set("status", params.Status)
+		}
+	}
+	out := new(PageInvitationView)
+	if err := c.do(ctx, "GET", "/api/v1/organization/invitations", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizationMemberListInvitationsParams are OrganizationMemberListInvitations's query parameters. Zero values are not sent.
+type OrganizationMemberListInvitationsParams struct {
+	Email  string
+	Status string
+}
+
+// OrganizationMemberListMembers calls GET /api/v1/organization/members: List members.
+// It needs the organization:members:read permission.
+func (c *Client) OrganizationMemberListMembers(ctx context.Context, params *OrganizationMemberListMembersParams) (*PageMemberView, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Q != "" {
+			q.Set("q", params.Q)
+		}
+		if params.Role != "" {
+			q.Set("role", params.Role)
+		}
+		if params.SortBy != "" {
+			q.Set("sort_by", params.SortBy)
+		}
+		if params.SortDir != "" {
+			q.Set("sort_dir", params.SortDir)
+		}
+		if params.Status != "" {
+			q.Set("status", params.Status)
+		}
+	}
+	out := new(PageMemberView)
+	if err := c.do(ctx, "GET", "/api/v1/organization/members", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizationMemberListMembersParams are OrganizationMemberListMembers's query parameters. Zero values are not sent.
+type OrganizationMemberListMembersParams struct {
+	Q       string
+	Role    string
+	SortBy  string
+	SortDir string
+	Status  string
+}
+
+// OrganizationMemberPreviewInvitation calls GET /api/v1/invitations/preview: Public: the holder of a link needs to know what they are being asked to join before signing up.
+func (c *Client) OrganizationMemberPreviewInvitation(ctx context.Context, params *OrganizationMemberPreviewInvitationParams) (*InvitationPreview, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Token != "" {
+			q.Set("token", params.Token)
+		}
+	}
+	out := new(InvitationPreview)
+	if err := c.do(ctx, "GET", "/api/v1/invitations/preview", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizationMemberPreviewInvitationParams are OrganizationMemberPreviewInvitation's query parameters. Zero values are not sent.
+type OrganizationMemberPreviewInvitationParams struct {
+	Token string
+}
+
+// OrganizationMemberResendInvitation calls POST /api/v1/organization/invitations/{invitationId}/resend: Resend invitation.
+// It needs the organization:members:invite permission.
+func (c *Client) OrganizationMemberResendInvitation(ctx context.Context, invitationID string, body *OrganizationMemberResendInvitationRequest, params *OrganizationMemberResendInvitationParams) (*InviteResult, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(InviteResult)
+	if err := c.do(ctx, "POST", "/api/v1/organization/invitations/"+url.PathEscape(invitationID)+"/resend", q, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizationMemberResendInvitationParams are OrganizationMemberResendInvitation's query parameters. Zero values are not sent.
+type OrganizationMemberResendInvitationParams struct {
+	Locale string
+}
+
+// OrganizationMemberRevokeInvitation calls DELETE /api/v1/organization/invitations/{invitationId}: Revoke invitation.
+// It needs the organization:members:invite permission.
+func (c *Client) OrganizationMemberRevokeInvitation(ctx context.Context, invitationID string) (*InvitationView, error) {
+	out := new(InvitationView)
+	if err := c.do(ctx, "DELETE", "/api/v1/organization/invitations/"+url.PathEscape(invitationID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizationMemberUpdateMemberRole calls PUT /api/v1/organization/members/{memberId}/role: Update member role.
+// It needs the organization:members:update permission.
+func (c *Client) OrganizationMemberUpdateMemberRole(ctx context.Context, memberID string, body *OrganizationMemberUpdateMemberRoleRequest) (*MemberView, error) {
+	out := new(MemberView)
+	if err := c.do(ctx, "PUT", "/api/v1/organization/members/"+url.PathEscape(memberID)+"/role", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizationMemberUpdateMemberStatus calls PUT /api/v1/organization/members/{memberId}/status: Update member status.
+// It needs the organization:members:deactivate permission.
+func (c *Client) OrganizationMemberUpdateMemberStatus(ctx context.Context, memberID string, body *OrganizationMemberUpdateMemberStatusRequest) (*MemberView, error) {
+	out := new(MemberView)
+	if err := c.do(ctx, "PUT", "/api/v1/organization/members/"+url.PathEscape(memberID)+"/status", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OwnershipListAssignable calls GET /api/v1/ownership/assignable: List the users and role groups that can be assigned work.
+func (c *Client) OwnershipListAssignable(ctx context.Context, params *OwnershipListAssignableParams) (*AssignableResult, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+		if params.OnlyCapable != "" {
+			q.Set("only_capable", params.OnlyCapable)
+		}
+		if params.Permission != "" {
+			q.Set("permission", params.Permission)
+		}
+		if params.Q != "" {
+			q.Set("q", params.Q)
+		}
+	}
+	out := new(AssignableResult)
+	if err := c.do(ctx, "GET", "/api/v1/ownership/assignable", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OwnershipListAssignableParams are OwnershipListAssignable's query parameters. Zero values are not sent.
+type OwnershipListAssignableParams struct {
+	Locale      string
+	OnlyCapable string
+	Permission  string
+	Q           string
+}
+
+// OwnershipMe calls GET /api/v1/ownership/me: Returns the caller's own identity as an Assignee row, so the picker can offer "Moi" without a second endpoint.
+func (c *Client) OwnershipMe(ctx context.Context, params *OwnershipMeParams) (*Assignee, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(Assignee)
+	if err := c.do(ctx, "GET", "/api/v1/ownership/me", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OwnershipMeParams are OwnershipMe's query parameters. Zero values are not sent.
+type OwnershipMeParams struct {
+	Locale string
+}
+
+// PasswordCheckPassword calls POST /api/v1/auth/password/check: Scores a candidate password and explains it.
+func (c *Client) PasswordCheckPassword(ctx context.Context, body *CheckPasswordRequest) (*Assessment, error) {
+	out := new(Assessment)
+	if err := c.do(ctx, "POST", "/api/v1/auth/password/check", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PasswordForgotPassword calls POST /api/v1/auth/password/forgot: Starts a password reset.
+func (c *Client) PasswordForgotPassword(ctx context.Context, body *ForgotPasswordRequest) (*PasswordForgotPasswordResponse, error) {
+	out := new(PasswordForgotPasswordResponse)
+	if err := c.do(ctx, "POST", "/api/v1/auth/password/forgot", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PasswordResetPassword calls POST /api/v1/auth/password/reset: Sets a new password from a reset link.
+func (c *Client) PasswordResetPassword(ctx context.Context, body *ResetPasswordRequest) (*PasswordResetPasswordResponse, error) {
+	out := new(PasswordResetPasswordResponse)
+	if err := c.do(ctx, "POST", "/api/v1/auth/password/reset", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PatCreate calls POST /api/v1/auth/pat: Mints a new PAT for the authenticated user.
+func (c *Client) PatCreate(ctx context.Context, body *CreatePATRequest) (*PatCreateResponse, error) {
+	out := new(PatCreateResponse)
+	if err := c.do(ctx, "POST", "/api/v1/auth/pat", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PatList calls GET /api/v1/auth/pat: Returns the caller's tokens (metadata only, never the secret).
+func (c *Client) PatList(ctx context.Context) (*PatListResponse, error) {
+	out := new(PatListResponse)
+	if err := c.do(ctx, "GET", "/api/v1/auth/pat", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PatRevoke calls DELETE /api/v1/auth/pat/{id}: Deletes one of the caller's tokens.
+func (c *Client) PatRevoke(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/auth/pat/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// PluginWebhook calls POST /api/v1/plugins/webhook: Push a payload to an install's transform_webhook hook.
+func (c *Client) PluginWebhook(ctx context.Context, params *PluginWebhookParams) (*PluginWebhookResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Token != "" {
+			q.Set("token", params.Token)
+		}
+	}
+	out := new(PluginWebhookResponse)
+	if err := c.do(ctx, "POST", "/api/v1/plugins/webhook", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PluginWebhookParams are PluginWebhook's query parameters. Zero values are not sent.
+type PluginWebhookParams struct {
+	Token string
+}
+
+// RBACRoleAssignPermissionToRole calls POST /api/v1/rbac/roles/{role_id}/permissions: Assigns a permission to a role.
+// It needs the admin role.
+func (c *Client) RBACRoleAssignPermissionToRole(ctx context.Context, roleID string, body *AssignPermissionRequest) (*RBACRoleAssignPermissionToRoleResponse, error) {
+	out := new(RBACRoleAssignPermissionToRoleResponse)
+	if err := c.do(ctx, "POST", "/api/v1/rbac/roles/"+url.PathEscape(roleID)+"/permissions", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACRoleCreateRole calls POST /api/v1/rbac/roles: Creates a new custom role in the tenant.
+// It needs the admin role.
+func (c *Client) RBACRoleCreateRole(ctx context.Context, body *CreateRoleRequest) (*RoleEnhanced, error) {
+	out := new(RoleEnhanced)
+	if err := c.do(ctx, "POST", "/api/v1/rbac/roles", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACRoleDeleteRole calls DELETE /api/v1/rbac/roles/{role_id}: Deletes a custom role.
+// It needs the admin role.
+func (c *Client) RBACRoleDeleteRole(ctx context.Context, roleID string) (*RBACRoleDeleteRoleResponse, error) {
+	out := new(RBACRoleDeleteRoleResponse)
+	if err := c.do(ctx, "DELETE", "/api/v1/rbac/roles/"+url.PathEscape(roleID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACRoleGetRole calls GET /api/v1/rbac/roles/{role_id}: Retrieves a specific role with its permissions.
+// It needs the admin role.
+func (c *Client) RBACRoleGetRole(ctx context.Context, roleID string) (*GetRoleResponse, error) {
+	out := new(GetRoleResponse)
+	if err := c.do(ctx, "GET", "/api/v1/rbac/roles/"+url.PathEscape(roleID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACRoleGetRolePermissions calls GET /api/v1/rbac/roles/{role_id}/permissions: Retrieves all permissions for a role.
+// It needs the admin role.
+func (c *Client) RBACRoleGetRolePermissions(ctx context.Context, roleID string) (*GetRolePermissionsResponse, error) {
+	out := new(GetRolePermissionsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/rbac/roles/"+url.PathEscape(roleID)+"/permissions", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACRoleListRoles calls GET /api/v1/rbac/roles: Retrieves all roles in a tenant.
+// It needs the admin role.
+func (c *Client) RBACRoleListRoles(ctx context.Context, params *RBACRoleListRolesParams) (*ListRolesResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.Offset != "" {
+			q.Set("offset", params.Offset)
+		}
+	}
+	out := new(ListRolesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/rbac/roles", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACRoleListRolesParams are RBACRoleListRoles's query parameters. Zero values are not sent.
+type RBACRoleListRolesParams struct {
+	Limit  string
+	Offset string
+}
+
+// RBACRoleRemovePermissionFromRole calls DELETE /api/v1/rbac/roles/{role_id}/permissions: Removes a permission from a role.
+// It needs the admin role.
+func (c *Client) RBACRoleRemovePermissionFromRole(ctx context.Context, roleID string, body *RemovePermissionRequest) (*RBACRoleRemovePermissionFromRoleResponse, error) {
+	out := new(RBACRoleRemovePermissionFromRoleResponse)
+	if err := c.do(ctx, "DELETE", "/api/v1/rbac/roles/"+url.PathEscape(roleID)+"/permissions", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACRoleUpdateRole calls PATCH /api/v1/rbac/roles/{role_id}: Updates an existing role.
+// It needs the admin role.
+func (c *Client) RBACRoleUpdateRole(ctx context.Context, roleID string, body *UpdateRoleRequest) (*RoleEnhanced, error) {
+	out := new(RoleEnhanced)
+	if err := c.do(ctx, "PATCH", "/api/v1/rbac/roles/"+url.PathEscape(roleID), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACTenantCreateTenant calls POST /api/v1/rbac/tenants: Creates a new tenant (admin only).
+func (c *Client) RBACTenantCreateTenant(ctx context.Context, body *CreateTenantRequest) (*Tenant, error) {
+	out := new(Tenant)
+	if err := c.do(ctx, "POST", "/api/v1/rbac/tenants", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACTenantDeleteTenant calls DELETE /api/v1/rbac/tenants/{tenant_id}: Deletes a tenant (owner only).
+func (c *Client) RBACTenantDeleteTenant(ctx context.Context, tenantID string) (*RBACTenantDeleteTenantResponse, error) {
+	out := new(RBACTenantDeleteTenantResponse)
+	if err := c.do(ctx, "DELETE", "/api/v1/rbac/tenants/"+url.PathEscape(tenantID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACTenantGetTenant calls GET /api/v1/rbac/tenants/{tenant_id}: Retrieves a specific tenant.
+func (c *Client) RBACTenantGetTenant(ctx context.Context, tenantID string) (*GetTenantResponse, error) {
+	out := new(GetTenantResponse)
+	if err := c.do(ctx, "GET", "/api/v1/rbac/tenants/"+url.PathEscape(tenantID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACTenantGetTenantStats calls GET /api/v1/rbac/tenants/{tenant_id}/stats: Retrieves statistics for a tenant.
+func (c *Client) RBACTenantGetTenantStats(ctx context.Context, tenantID string) (*GetTenantStatsResponse, error) {
+	out := new(GetTenantStatsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/rbac/tenants/"+url.PathEscape(tenantID)+"/stats", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACTenantGetTenantUsers calls GET /api/v1/rbac/tenants/{tenant_id}/users: Retrieves all users in a tenant.
+// It needs the admin role.
+func (c *Client) RBACTenantGetTenantUsers(ctx context.Context, tenantID string, params *RBACTenantGetTenantUsersParams) (*GetTenantUsersResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.Offset != "" {
+			q.Set("offset", params.Offset)
+		}
+	}
+	out := new(GetTenantUsersResponse)
+	if err := c.do(ctx, "GET", "/api/v1/rbac/tenants/"+url.PathEscape(tenantID)+"/users", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACTenantGetTenantUsersParams are RBACTenantGetTenantUsers's query parameters. Zero values are not sent.
+type RBACTenantGetTenantUsersParams struct {
+	Limit  string
+	Offset string
+}
+
+// RBACTenantListTenants calls GET /api/v1/rbac/tenants: Retrieves all tenants for a user.
+func (c *Client) RBACTenantListTenants(ctx context.Context, params *RBACTenantListTenantsParams) (*ListTenantsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.Offset != "" {
+			q.Set("offset", params.Offset)
+		}
+	}
+	out := new(ListTenantsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/rbac/tenants", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACTenantListTenantsParams are RBACTenantListTenants's query parameters. Zero values are not sent.
+type RBACTenantListTenantsParams struct {
+	Limit  string
+	Offset string
+}
+
+// RBACTenantUpdateTenant calls PATCH /api/v1/rbac/tenants/{tenant_id}: Updates an existing tenant.
+// It needs the admin role.
+func (c *Client) RBACTenantUpdateTenant(ctx context.Context, tenantID string, body *UpdateTenantRequest) (*Tenant, error) {
+	out := new(Tenant)
+	if err := c.do(ctx, "PATCH", "/api/v1/rbac/tenants/"+url.PathEscape(tenantID), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACUserAddUserToTenant calls POST /api/v1/rbac/users: Adds a user to the current tenant with a role.
+// It needs the admin role.
+func (c *Client) RBACUserAddUserToTenant(ctx context.Context, body *AddUserToTenantRequest) (*RBACUserAddUserToTenantResponse, error) {
+	out := new(RBACUserAddUserToTenantResponse)
+	if err := c.do(ctx, "POST", "/api/v1/rbac/users", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACUserChangeUserRole calls PATCH /api/v1/rbac/users/{user_id}/role: Changes a user's role in the tenant.
+// It needs the admin role.
+func (c *Client) RBACUserChangeUserRole(ctx context.Context, userID string, body *ChangeUserRoleRequest) (*RBACUserChangeUserRoleResponse, error) {
+	out := new(RBACUserChangeUserRoleResponse)
+	if err := c.do(ctx, "PATCH", "/api/v1/rbac/users/"+url.PathEscape(userID)+"/role", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACUserGetTenantUserStats calls GET /api/v1/rbac/users/stats: Retrieves statistics about users in the tenant.
+// It needs the admin role.
+func (c *Client) RBACUserGetTenantUserStats(ctx context.Context) (*GetTenantUsersCountResponse, error) {
+	out := new(GetTenantUsersCountResponse)
+	if err := c.do(ctx, "GET", "/api/v1/rbac/users/stats", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACUserGetUser calls GET /api/v1/rbac/users/{user_id}: Retrieves a specific user's tenant information.
+// It needs the admin role.
+func (c *Client) RBACUserGetUser(ctx context.Context, userID string) (*UserTenant, error) {
+	out := new(UserTenant)
+	if err := c.do(ctx, "GET", "/api/v1/rbac/users/"+url.PathEscape(userID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACUserGetUserPermissions calls GET /api/v1/rbac/users/{user_id}/permissions: Retrieves all permissions for a user in the tenant.
+// It needs the admin role.
+func (c *Client) RBACUserGetUserPermissions(ctx context.Context, userID string) (*GetUserPermissionsResponse, error) {
+	out := new(GetUserPermissionsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/rbac/users/"+url.PathEscape(userID)+"/permissions", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACUserListUsers calls GET /api/v1/rbac/users: Retrieves all users in a tenant.
+// It needs the admin role.
+func (c *Client) RBACUserListUsers(ctx context.Context, params *RBACUserListUsersParams) (*ListUsersResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.Offset != "" {
+			q.Set("offset", params.Offset)
+		}
+	}
+	out := new(ListUsersResponse)
+	if err := c.do(ctx, "GET", "/api/v1/rbac/users", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RBACUserListUsersParams are RBACUserListUsers's query parameters. Zero values are not sent.
+type RBACUserListUsersParams struct {
+	Limit  string
+	Offset string
+}
+
+// RBACUserRemoveUserFromTenant calls DELETE /api/v1/rbac/users/{user_id}: Removes a user from the tenant.
+// It needs the admin role.
+func (c *Client) RBACUserRemoveUserFromTenant(ctx context.Context, userID string) (*RBACUserRemoveUserFromTenantResponse, error) {
+	out := new(RBACUserRemoveUserFromTenantResponse)
+	if err := c.do(ctx, "DELETE", "/api/v1/rbac/users/"+url.PathEscape(userID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RecordControlTest calls POST /api/v1/control-test-plans/{id}/tests: Record a test result.
+// It needs the compliance:controls:update permission.
+func (c *Client) RecordControlTest(ctx context.Context, id string, body *TestInput) (*TestOutcome, error) {
+	out := new(TestOutcome)
+	if err := c.do(ctx, "POST", "/api/v1/control-test-plans/"+url.PathEscape(id)+"/tests", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RedeliverWebhookDelivery calls POST /api/v1/webhook-deliveries/{id}/redeliver: Send a delivery's event again.
+// It needs one of the roles admin, root.
+func (c *Client) RedeliverWebhookDelivery(ctx context.Context, id string) (*WebhookDelivery, error) {
+	out := new(WebhookDelivery)
+	if err := c.do(ctx, "POST", "/api/v1/webhook-deliveries/"+url.PathEscape(id)+"/redeliver", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RefreshToken calls POST /api/v1/auth/refresh: Refresh access token.
+func (c *Client) RefreshToken(ctx context.Context, body *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	out := new(RefreshTokenResponse)
+	if err := c.do(ctx, "POST", "/api/v1/auth/refresh", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Register calls POST /api/v1/auth/register: User registration.
+func (c *Client) Register(ctx context.Context, body *RegisterRequest) (*RegisterResponse, error) {
+	out := new(RegisterResponse)
+	if err := c.do(ctx, "POST", "/api/v1/auth/register", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RemoveTeamMember calls DELETE /api/v1/teams/{id}/members/{userId}: Removes a user from a team (admin only).
+// It needs the admin role.
+func (c *Client) RemoveTeamMember(ctx context.Context, id string, userID string) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "DELETE", "/api/v1/teams/"+url.PathEscape(id)+"/members/"+url.PathEscape(userID), nil, nil, &out)
+	return out, err
+}
+
+// ReorderSubActions calls PATCH /api/v1/mitigations/{id}/reorder-subactions: Updates the order of subactions.
+// It needs the mitigations:update permission.
+func (c *Client) ReorderSubActions(ctx context.Context, id string, body *ReorderSubActionsRequest) ([]MitigationSubAction, error) {
+	var out []MitigationSubAction
+	err := c.do(ctx, "PATCH", "/api/v1/mitigations/"+url.PathEscape(id)+"/reorder-subactions", nil, body, &out)
+	return out, err
+}
+
+// ReportCatalogue calls GET /api/v1/reports/types: What the configurator offers.
+// It needs the compliance:controls:read permission.
+func (c *Client) ReportCatalogue(ctx context.Context, params *ReportCatalogueParams) (*ReportCatalogueResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(ReportCatalogueResponse)
+	if err := c.do(ctx, "GET", "/api/v1/reports/types", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportCatalogueParams are ReportCatalogue's query parameters. Zero values are not sent.
+type ReportCatalogueParams struct {
+	Locale string
+}
+
+// ReportComment calls POST /api/v1/reports/{reportId}/comments: Comment.
+// It needs the compliance:controls:read permission.
+func (c *Client) ReportComment(ctx context.Context, reportID string, body *ReportCommentRequest) (*ReportComment, error) {
+	out := new(ReportComment)
+	if err := c.do(ctx, "POST", "/api/v1/reports/"+url.PathEscape(reportID)+"/comments", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportCompare calls GET /api/v1/reports/{reportId}/compare: What changed between versions.
+// It needs the compliance:controls:read permission.
+func (c *Client) ReportCompare(ctx context.Context, reportID string, params *ReportCompareParams) (*VersionDiff, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.With != "" {
+			q.Set("with", params.With)
+		}
+	}
+	out := new(VersionDiff)
+	if err := c.do(ctx, "GET", "/api/v1/reports/"+url.PathEscape(reportID)+"/compare", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportCompareParams are ReportCompare's query parameters. Zero values are not sent.
+type ReportCompareParams struct {
+	With string
+}
+
+// ReportCreate calls POST /api/v1/reports: Queue a report.
+// It needs the compliance:controls:read permission.
+func (c *Client) ReportCreate(ctx context.Context, body *ReportCreateRequest) (*DomainReport, error) {
+	out := new(DomainReport)
+	if err := c.do(ctx, "POST", "/api/v1/reports", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportDelete calls DELETE /api/v1/reports/{reportId}: Delete.
+// It needs the compliance:controls:read permission.
+func (c *Client) ReportDelete(ctx context.Context, reportID string) error {
+	return c.do(ctx, "DELETE", "/api/v1/reports/"+url.PathEscape(reportID), nil, nil, nil)
+}
+
+// ReportDownload calls GET /api/v1/reports/{reportId}/download: The bytes.
+// It needs the compliance:controls:read permission.
+func (c *Client) ReportDownload(ctx context.Context, reportID string) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "GET", "/api/v1/reports/"+url.PathEscape(reportID)+"/download", nil, nil, &out)
+	return out, err
+}
+
+// ReportGet calls GET /api/v1/reports/{reportId}: Get.
+// It needs the compliance:controls:read permission.
+func (c *Client) ReportGet(ctx context.Context, reportID string) (*DomainReport, error) {
+	out := new(DomainReport)
+	if err := c.do(ctx, "GET", "/api/v1/reports/"+url.PathEscape(reportID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportJobCreate calls POST /api/v1/reports/jobs: Returns 201 with the job, whose id the client redirects to (/reports/jobs/:id).
+// It needs the compliance:controls:read permission.
+func (c *Client) ReportJobCreate(ctx context.Context, body *CreateReportJobInput) (*ReportJob, error) {
+	out := new(ReportJob)
+	if err := c.do(ctx, "POST", "/api/v1/reports/jobs", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportJobDownload calls GET /api/v1/reports/jobs/{jobId}/download: Serving the stored artifact — the document as it was generated, not a fresh render of a register that has since moved on.
+// It needs the compliance:controls:read permission.
+func (c *Client) ReportJobDownload(ctx context.Context, jobID string) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "GET", "/api/v1/reports/jobs/"+url.PathEscape(jobID)+"/download", nil, nil, &out)
+	return out, err
+}
+
+// ReportJobGet calls GET /api/v1/reports/jobs/{jobId}: Get.
+// It needs the compliance:controls:read permission.
+func (c *Client) ReportJobGet(ctx context.Context, jobID string) (*ReportJob, error) {
+	out := new(ReportJob)
+	if err := c.do(ctx, "GET", "/api/v1/reports/jobs/"+url.PathEscape(jobID), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportJobList calls GET /api/v1/reports/jobs: List.
+// It needs the compliance:controls:read permission.
+func (c *Client) ReportJobList(ctx context.Context, params *ReportJobListParams) (*ReportJobListResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != nil {
+			q.Set("limit", strconv.FormatInt(*params.Limit, 10))
+		}
+	}
+	out := new(ReportJobListResponse)
+	if err := c.do(ctx, "GET", "/api/v1/reports/jobs", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportJobListParams are ReportJobList's query parameters. Zero values are not sent.
+type ReportJobListParams struct {
+	Limit *int64
+}
+
+// ReportList calls GET /api/v1/reports: The recent-reports list.
+// It needs the compliance:controls:read permission.
+func (c *Client) ReportList(ctx context.Context, params *ReportListParams) (*ReportListResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Format != "" {
+			q.Set("format", params.Format)
+		}
+		if params.Lifecycle != "" {
+			q.Set("lifecycle", params.Lifecycle)
+		}
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.Offset != "" {
+			q.Set("offset", params.Offset)
+		}
+		if params.Sort != "" {
+			q.Set("sort", params.Sort)
+		}
+		if params.Type != "" {
+			q.Set("type", params.Type)
+		}
+	}
+	out := new(ReportListResponse)
+	if err := c.do(ctx, "GET", "/api/v1/reports", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportListParams are ReportList's query parameters. Zero values are not sent.
+type ReportListParams struct {
+	Format    string
+	Lifecycle string
+	Limit     string
+	Offset    string
+	Sort      string
+	Type      string
+}
+
+// ReportProgress calls GET /api/v1/reports/{reportId}/progress: Server-sent events while it renders.
+// It needs the compliance:controls:read permission.
+func (c *Client) ReportProgress(ctx context.Context, reportID string) error {
+	return c.do(ctx, "GET", "/api/v1/reports/"+url.PathEscape(reportID)+"/progress", nil, nil, nil)
+}
+
+// ReportTransition calls POST /api/v1/reports/{reportId}/transition: Move through the lifecycle.
+// It needs the reports:board:approve permission.
+func (c *Client) ReportTransition(ctx context.Context, reportID string, body *ReportTransitionRequest) (*DomainReport, error) {
+	out := new(DomainReport)
+	if err := c.do(ctx, "POST", "/api/v1/reports/"+url.PathEscape(reportID)+"/transition", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportVerify calls GET /api/v1/reports/{reportId}/verify: Recompute and compare the hash.
+// It needs the compliance:controls:read permission.
+func (c *Client) ReportVerify(ctx context.Context, reportID string) (*VerifyResult, error) {
+	out := new(VerifyResult)
+	if err := c.do(ctx, "GET", "/api/v1/reports/"+url.PathEscape(reportID)+"/verify", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportVersions calls GET /api/v1/reports/{reportId}/versions: The lineage.
+// It needs the compliance:controls:read permission.
+func (c *Client) ReportVersions(ctx context.Context, reportID string) (*ReportVersionsResponse, error) {
+	out := new(ReportVersionsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/reports/"+url.PathEscape(reportID)+"/versions", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RequestGroupLink calls POST /api/v1/group/links: Ask an organization to become a subsidiary.
+// It needs the group:manage permission.
+func (c *Client) RequestGroupLink(ctx context.Context, body *LinkRequest) (*PendingLink, error) {
+	out := new(PendingLink)
+	if err := c.do(ctx, "POST", "/api/v1/group/links", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RevertSubAction calls POST /api/v1/mitigations/{id}/sub-actions/{aid}/revert: Marks a subaction as incomplete.
+// It needs the mitigations:update permission.
+func (c *Client) RevertSubAction(ctx context.Context, id string, aid string) (*MitigationSubAction, error) {
+	out := new(MitigationSubAction)
+	if err := c.do(ctx, "POST", "/api/v1/mitigations/"+url.PathEscape(id)+"/sub-actions/"+url.PathEscape(aid)+"/revert", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RevokeGroupLink calls DELETE /api/v1/group/links/{id}: Revoke a link, from either side.
+// It needs the group:manage permission.
+func (c *Client) RevokeGroupLink(ctx context.Context, id string) (*OrganizationLink, error) {
+	out := new(OrganizationLink)
+	if err := c.do(ctx, "DELETE", "/api/v1/group/links/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskAppetiteCreateStatement calls POST /api/v1/risk-appetite/statements: Create an appetite statement (admin).
+// It needs one of the roles admin, root.
+func (c *Client) RiskAppetiteCreateStatement(ctx context.Context, body *StatementInput) (*RiskAppetiteStatement, error) {
+	out := new(RiskAppetiteStatement)
+	if err := c.do(ctx, "POST", "/api/v1/risk-appetite/statements", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskAppetiteDeleteStatement calls DELETE /api/v1/risk-appetite/statements/{id}: Delete an appetite statement (admin).
+// It needs one of the roles admin, root.
+func (c *Client) RiskAppetiteDeleteStatement(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/risk-appetite/statements/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// RiskAppetiteEvaluate calls GET /api/v1/risk-appetite/evaluation: The register measured against every appetite statement.
+// It needs the risks:read permission.
+func (c *Client) RiskAppetiteEvaluate(ctx context.Context) (*Evaluation, error) {
+	out := new(Evaluation)
+	if err := c.do(ctx, "GET", "/api/v1/risk-appetite/evaluation", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskAppetiteListStatements calls GET /api/v1/risk-appetite/statements: List the board's risk-appetite statements.
+// It needs the risks:read permission.
+func (c *Client) RiskAppetiteListStatements(ctx context.Context) ([]RiskAppetiteStatement, error) {
+	var out []RiskAppetiteStatement
+	err := c.do(ctx, "GET", "/api/v1/risk-appetite/statements", nil, nil, &out)
+	return out, err
+}
+
+// RiskAppetiteRequestException calls POST /api/v1/risk-appetite/exceptions: Request an exception to keep a risk above appetite.
+// It needs the risks:update permission.
+func (c *Client) RiskAppetiteRequestException(ctx context.Context, body *ExceptionInput) (*ApprovalRequest, error) {
+	out := new(ApprovalRequest)
+	if err := c.do(ctx, "POST", "/api/v1/risk-appetite/exceptions", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskAppetiteUpdateStatement calls PUT /api/v1/risk-appetite/statements/{id}: Replace an appetite statement (admin).
+// It needs one of the roles admin, root.
+func (c *Client) RiskAppetiteUpdateStatement(ctx context.Context, id string, body *StatementInput) (*RiskAppetiteStatement, error) {
+	out := new(RiskAppetiteStatement)
+	if err := c.do(ctx, "PUT", "/api/v1/risk-appetite/statements/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskListTransitions calls GET /api/v1/risks/{id}/transitions: The states reachable from here, which of them are allowed right now, and for the rest exactly what is in the way.
+// It needs the risks:read permission.
+func (c *Client) RiskListTransitions(ctx context.Context, id string, params *RiskListTransitionsParams) (*TransitionsView, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(TransitionsView)
+	if err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/transitions", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskListTransitionsParams are RiskListTransitions's query parameters. Zero values are not sent.
+type RiskListTransitionsParams struct {
+	Locale string
+}
+
+// RiskMarkReviewed calls POST /api/v1/risks/{id}/review: Records a review now and reschedules the next one from the risk's cadence.
+// It needs the risks:update permission.
+func (c *Client) RiskMarkReviewed(ctx context.Context, id string) (*Risk, error) {
+	out := new(Risk)
+	if err := c.do(ctx, "POST", "/api/v1/risks/"+url.PathEscape(id)+"/review", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskScenarioCoverage calls GET /api/v1/risk-scenarios/coverage: ATT&CK coverage of the tenant's controls.
+// It needs the risks:read permission.
+func (c *Client) RiskScenarioCoverage(ctx context.Context, params *RiskScenarioCoverageParams) (*CoverageReport, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.ScenarioID != "" {
+			q.Set("scenario_id", params.ScenarioID)
+		}
+	}
+	out := new(CoverageReport)
+	if err := c.do(ctx, "GET", "/api/v1/risk-scenarios/coverage", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskScenarioCoverageParams are RiskScenarioCoverage's query parameters. Zero values are not sent.
+type RiskScenarioCoverageParams struct {
+	ScenarioID string
+}
+
+// RiskScenarioCreateScenario calls POST /api/v1/risk-scenarios: Add a custom scenario.
+// It needs the risks:update permission.
+func (c *Client) RiskScenarioCreateScenario(ctx context.Context, body *ScenarioInput) (*RiskScenario, error) {
+	out := new(RiskScenario)
+	if err := c.do(ctx, "POST", "/api/v1/risk-scenarios", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskScenarioDeleteScenario calls DELETE /api/v1/risk-scenarios/{id}: Delete a custom scenario.
+// It needs the risks:update permission.
+func (c *Client) RiskScenarioDeleteScenario(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/risk-scenarios/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// RiskScenarioGetScenario calls GET /api/v1/risk-scenarios/{id}: One scenario.
+// It needs the risks:read permission.
+func (c *Client) RiskScenarioGetScenario(ctx context.Context, id string) (*RiskScenario, error) {
+	out := new(RiskScenario)
+	if err := c.do(ctx, "GET", "/api/v1/risk-scenarios/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskScenarioInstantiate calls POST /api/v1/risk-scenarios/{id}/instantiate: Draft risks from a scenario on selected assets.
+// It needs the risks:update permission.
+func (c *Client) RiskScenarioInstantiate(ctx context.Context, id string, body *InstantiateInput) (*InstantiateResult, error) {
+	out := new(InstantiateResult)
+	if err := c.do(ctx, "POST", "/api/v1/risk-scenarios/"+url.PathEscape(id)+"/instantiate", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskScenarioListScenarios calls GET /api/v1/risk-scenarios: The scenario library.
+// It needs the risks:read permission.
+func (c *Client) RiskScenarioListScenarios(ctx context.Context) (*Library, error) {
+	out := new(Library)
+	if err := c.do(ctx, "GET", "/api/v1/risk-scenarios", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskScenarioUpdateScenario calls PUT /api/v1/risk-scenarios/{id}: Replace a custom scenario.
+// It needs the risks:update permission.
+func (c *Client) RiskScenarioUpdateScenario(ctx context.Context, id string, body *ScenarioInput) (*RiskScenario, error) {
+	out := new(RiskScenario)
+	if err := c.do(ctx, "PUT", "/api/v1/risk-scenarios/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskTaxonomyCreateCategory calls POST /api/v1/risk-categories: Admin-gated by the route.
+// It needs one of the roles admin, root.
+func (c *Client) RiskTaxonomyCreateCategory(ctx context.Context, body *CategoryInput) (*RiskCategory, error) {
+	out := new(RiskCategory)
+	if err := c.do(ctx, "POST", "/api/v1/risk-categories", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskTaxonomyCreateRiskMapping calls POST /api/v1/risks/{id}/control-mappings: Create risk mapping.
+// It needs the risks:update permission.
+func (c *Client) RiskTaxonomyCreateRiskMapping(ctx context.Context, id string, body *CreateRiskMappingInput) (*RiskControlMapping, error) {
+	out := new(RiskControlMapping)
+	if err := c.do(ctx, "POST", "/api/v1/risks/"+url.PathEscape(id)+"/control-mappings", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskTaxonomyDeleteCategory calls DELETE /api/v1/risk-categories/{id}: Detaches the risks that carried it rather than leaving them pointing at nothing.
+// It needs one of the roles admin, root.
+func (c *Client) RiskTaxonomyDeleteCategory(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/risk-categories/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// RiskTaxonomyDeleteRiskMapping calls DELETE /api/v1/risks/{id}/control-mappings/{mappingId}: Delete risk mapping.
+// It needs the risks:update permission.
+func (c *Client) RiskTaxonomyDeleteRiskMapping(ctx context.Context, id string, mappingID string) error {
+	return c.do(ctx, "DELETE", "/api/v1/risks/"+url.PathEscape(id)+"/control-mappings/"+url.PathEscape(mappingID), nil, nil, nil)
+}
+
+// RiskTaxonomyListCategories calls GET /api/v1/risk-categories: The tenant's controlled vocabulary.
+// It needs the risks:read permission.
+func (c *Client) RiskTaxonomyListCategories(ctx context.Context, params *RiskTaxonomyListCategoriesParams) (*RiskTaxonomyListCategoriesResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.IncludeInactive != "" {
+			q.Set("include_inactive", params.IncludeInactive)
+		}
+		if params.WithCounts != "" {
+			q.Set("with_counts", params.WithCounts)
+		}
+	}
+	out := new(RiskTaxonomyListCategoriesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/risk-categories", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskTaxonomyListCategoriesParams are RiskTaxonomyListCategories's query parameters. Zero values are not sent.
+type RiskTaxonomyListCategoriesParams struct {
+	IncludeInactive string
+	WithCounts      string
+}
+
+// RiskTaxonomyListRiskMappings calls GET /api/v1/risks/{id}/control-mappings: List risk mappings.
+// It needs the risks:read permission.
+func (c *Client) RiskTaxonomyListRiskMappings(ctx context.Context, id string) (*RiskTaxonomyListRiskMappingsResponse, error) {
+	out := new(RiskTaxonomyListRiskMappingsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/control-mappings", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskTaxonomyListUnmappedRisks calls GET /api/v1/risks/unmapped: The catch-up screen for risks nobody mapped to a control.
+// It needs the risks:read permission.
+func (c *Client) RiskTaxonomyListUnmappedRisks(ctx context.Context) (*RiskTaxonomyListUnmappedRisksResponse, error) {
+	out := new(RiskTaxonomyListUnmappedRisksResponse)
+	if err := c.do(ctx, "GET", "/api/v1/risks/unmapped", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskTaxonomyUpdateCategory calls PATCH /api/v1/risk-categories/{id}: Update category.
+// It needs one of the roles admin, root.
+func (c *Client) RiskTaxonomyUpdateCategory(ctx context.Context, id string, body *CategoryInput) (*RiskCategory, error) {
+	out := new(RiskCategory)
+	if err := c.do(ctx, "PATCH", "/api/v1/risk-categories/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskTimelineGetChangesByType calls GET /api/v1/risks/{id}/timeline/changes/{type}: Retrieves history entries of a specific change type.
+func (c *Client) RiskTimelineGetChangesByType(ctx context.Context, id string, typeArg string) (*RiskTimelineGetChangesByTypeResponse, error) {
+	out := new(RiskTimelineGetChangesByTypeResponse)
+	if err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/timeline/changes/"+url.PathEscape(typeArg), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskTimelineGetChangesSince calls GET /api/v1/risks/{id}/timeline/since/{timestamp}: Gets all changes since a specific time.
+func (c *Client) RiskTimelineGetChangesSince(ctx context.Context, id string, timestamp string, params *RiskTimelineGetChangesSinceParams) (*RiskTimelineGetChangesSinceResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Since != nil {
+			q.Set("since", strconv.FormatInt(*params.Since, 10))
+		}
+	}
+	out := new(RiskTimelineGetChangesSinceResponse)
+	if err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/timeline/since/"+url.PathEscape(timestamp), q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskTimelineGetChangesSinceParams are RiskTimelineGetChangesSince's query parameters. Zero values are not sent.
+type RiskTimelineGetChangesSinceParams struct {
+	Since *int64
+}
+
+// RiskTimelineGetRecentActivity calls GET /api/v1/timeline/recent: Gets the most recent changes across all risks.
+func (c *Client) RiskTimelineGetRecentActivity(ctx context.Context, params *RiskTimelineGetRecentActivityParams) (json.RawMessage, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != nil {
+			q.Set("limit", strconv.FormatInt(*params.Limit, 10))
+		}
+	}
+	var out json.RawMessage
+	err := c.do(ctx, "GET", "/api/v1/timeline/recent", q, nil, &out)
+	return out, err
+}
+
+// RiskTimelineGetRecentActivityParams are RiskTimelineGetRecentActivity's query parameters. Zero values are not sent.
+type RiskTimelineGetRecentActivityParams struct {
+	Limit *int64
+}
+
+// RiskTimelineGetRiskTimeline calls GET /api/v1/risks/{id}/timeline: Retrieves the full history/timeline for a risk.
+func (c *Client) RiskTimelineGetRiskTimeline(ctx context.Context, id string, params *RiskTimelineGetRiskTimelineParams) (*RiskTimelineGetRiskTimelineResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != nil {
+			q.Set("limit", strconv.FormatInt(*params.Limit, 10))
+		}
+		if params.Offset != nil {
+			q.Set("offset", strconv.FormatInt(*params.Offset, 10))
+		}
+	}
+	out := new(RiskTimelineGetRiskTimelineResponse)
+	if err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/timeline", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskTimelineGetRiskTimelineParams are RiskTimelineGetRiskTimeline's query parameters. Zero values are not sent.
+type RiskTimelineGetRiskTimelineParams struct {
+	Limit  *int64
+	Offset *int64
+}
+
+// RiskTimelineGetRiskTrend calls GET /api/v1/risks/{id}/timeline/trend: Analyzes the risk score trend over time.
+func (c *Client) RiskTimelineGetRiskTrend(ctx context.Context, id string) (map[string]any, error) {
+	var out map[string]any
+	err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/timeline/trend", nil, nil, &out)
+	return out, err
+}
+
+// RiskTimelineGetScoreChanges calls GET /api/v1/risks/{id}/timeline/score-changes: Retrieves only score change events.
+func (c *Client) RiskTimelineGetScoreChanges(ctx context.Context, id string) (*RiskTimelineGetScoreChangesResponse, error) {
+	out := new(RiskTimelineGetScoreChangesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/timeline/score-changes", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskTimelineGetStatusChanges calls GET /api/v1/risks/{id}/timeline/status-changes: Retrieves only status change events.
+func (c *Client) RiskTimelineGetStatusChanges(ctx context.Context, id string) (*RiskTimelineGetStatusChangesResponse, error) {
+	out := new(RiskTimelineGetStatusChangesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/risks/"+url.PathEscape(id)+"/timeline/status-changes", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskTransitionPhase calls POST /api/v1/risks/{id}/transition: Moves a risk through the single canonical lifecycle.
+// It needs the risks:update permission.
+func (c *Client) RiskTransitionPhase(ctx context.Context, id string, body *TransitionRiskInput, params *RiskTransitionPhaseParams) (*Risk, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(Risk)
+	if err := c.do(ctx, "POST", "/api/v1/risks/"+url.PathEscape(id)+"/transition", q, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RiskTransitionPhaseParams are RiskTransitionPhase's query parameters. Zero values are not sent.
+type RiskTransitionPhaseParams struct {
+	Locale string
+}
+
+// RollbackImport calls POST /api/v1/imports/{id}/rollback: Roll back an applied batch.
+// It needs one of the roles admin, root.
+func (c *Client) RollbackImport(ctx context.Context, id string, params *RollbackImportParams) (*ImportBatch, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Force != "" {
+			q.Set("force", params.Force)
+		}
+	}
+	out := new(ImportBatch)
+	if err := c.do(ctx, "POST", "/api/v1/imports/"+url.PathEscape(id)+"/rollback", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RollbackImportParams are RollbackImport's query parameters. Zero values are not sent.
+type RollbackImportParams struct {
+	Force string
+}
+
+// RotateWebhookSecret calls POST /api/v1/webhooks/{id}/rotate-secret: Rotate the signing secret.
+// It needs one of the roles admin, root.
+func (c *Client) RotateWebhookSecret(ctx context.Context, id string) (*SubscriptionWithSecret, error) {
+	out := new(SubscriptionWithSecret)
+	if err := c.do(ctx, "POST", "/api/v1/webhooks/"+url.PathEscape(id)+"/rotate-secret", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RunPluginInstall calls POST /api/v1/plugin-installs/{id}/run: Run a hook now.
+// It needs one of the roles admin, root.
+func (c *Client) RunPluginInstall(ctx context.Context, id string, body *RunInput) (*RunResult, error) {
+	out := new(RunResult)
+	if err := c.do(ctx, "POST", "/api/v1/plugin-installs/"+url.PathEscape(id)+"/run", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SAMLMetadata calls GET /api/v1/auth/saml2/metadata: Generates SAML2 Service Provider metadata.
+func (c *Client) SAMLMetadata(ctx context.Context) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, "GET", "/api/v1/auth/saml2/metadata", nil, nil, &out)
+	return out, err
+}
+
+// SamL2ACS calls POST /api/v1/auth/saml2/acs: SAML2 Assertion Consumer Service (callback).
+func (c *Client) SamL2ACS(ctx context.Context, form Form) (*SamL2ACSResponse, error) {
+	out := new(SamL2ACSResponse)
+	if err := c.do(ctx, "POST", "/api/v1/auth/saml2/acs", nil, form, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SamL2InitiateLogin calls GET /api/v1/auth/saml2/login: Initiates SAML2 login flow.
+func (c *Client) SamL2InitiateLogin(ctx context.Context) (*SamL2InitiateLoginResponse, error) {
+	out := new(SamL2InitiateLoginResponse)
+	if err := c.do(ctx, "GET", "/api/v1/auth/saml2/login", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SaveRiskBowtie calls PUT /api/v1/risks/{id}/bowtie: Replace the risk's bowtie.
+// It needs the risks:update permission.
+func (c *Client) SaveRiskBowtie(ctx context.Context, id string, body *Bowtie) (*Graph, error) {
+	out := new(Graph)
+	if err := c.do(ctx, "PUT", "/api/v1/risks/"+url.PathEscape(id)+"/bowtie", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScannerAgentHeartbeat calls POST /api/v1/scanner/agent/heartbeat: Keeps the Agent marked online between scans (the SSE connect only refreshes liveness once).
+func (c *Client) ScannerAgentHeartbeat(ctx context.Context, params *ScannerAgentHeartbeatParams) (*ScannerAgentHeartbeatResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Status != "" {
+			q.Set("status", params.Status)
+		}
+	}
+	out := new(ScannerAgentHeartbeatResponse)
+	if err := c.do(ctx, "POST", "/api/v1/scanner/agent/heartbeat", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScannerAgentHeartbeatParams are ScannerAgentHeartbeat's query parameters. Zero values are not sent.
+type ScannerAgentHeartbeatParams struct {
+	Status string
+}
+
+// ScannerAgentPush calls POST /api/v1/scanner/agent/push: Receive an Agent's scan results.
+func (c *Client) ScannerAgentPush(ctx context.Context) (*ScanJob, error) {
+	out := new(ScanJob)
+	if err := c.do(ctx, "POST", "/api/v1/scanner/agent/push", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScannerAgentStream calls GET /api/v1/scanner/agent/stream: SSE of queued jobs + keepalive.
+func (c *Client) ScannerAgentStream(ctx context.Context) error {
+	return c.do(ctx, "GET", "/api/v1/scanner/agent/stream", nil, nil, nil)
+}
+
+// ScannerCreateScanConfig calls POST /api/v1/scanner/configs: Create scan config.
+// It needs the scanner:create permission.
+func (c *Client) ScannerCreateScanConfig(ctx context.Context, body *CreateScanConfigInput) (*ScanConfig, error) {
+	out := new(ScanConfig)
+	if err := c.do(ctx, "POST", "/api/v1/scanner/configs", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScannerDeleteScanConfig calls DELETE /api/v1/scanner/configs/{id}: Delete scan config.
+// It needs the scanner:delete permission.
+func (c *Client) ScannerDeleteScanConfig(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/scanner/configs/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// ScannerGetScanPreview calls GET /api/v1/scanner/jobs/{id}/preview: Get scan preview.
+// It needs the scanner:read permission.
+func (c *Client) ScannerGetScanPreview(ctx context.Context, id string) (*ScanPreview, error) {
+	out := new(ScanPreview)
+	if err := c.do(ctx, "GET", "/api/v1/scanner/jobs/"+url.PathEscape(id)+"/preview", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScannerIgnorePreview calls POST /api/v1/scanner/jobs/{id}/ignore: Ignore preview.
+// It needs the scanner:import permission.
+func (c *Client) ScannerIgnorePreview(ctx context.Context, id string) error {
+	return c.do(ctx, "POST", "/api/v1/scanner/jobs/"+url.PathEscape(id)+"/ignore", nil, nil, nil)
+}
+
+// ScannerImportPreview calls POST /api/v1/scanner/jobs/{id}/import: Import preview.
+// It needs the scanner:import permission.
+func (c *Client) ScannerImportPreview(ctx context.Context, id string, body *ImportPreviewInput) (*ImportPreviewResult, error) {
+	out := new(ImportPreviewResult)
+	if err := c.do(ctx, "POST", "/api/v1/scanner/jobs/"+url.PathEscape(id)+"/import", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScannerIssueRegistrationToken calls POST /api/v1/scanner/configs/{id}/registration-token: Mints the 24h token embedded in an Agent download for this tenant + config, and returns the per-OS download URLs the onboarding page offers.
+// It needs the scanner:create permission.
+func (c *Client) ScannerIssueRegistrationToken(ctx context.Context, id string) (*ScannerIssueRegistrationTokenResponse, error) {
+	out := new(ScannerIssueRegistrationTokenResponse)
+	if err := c.do(ctx, "POST", "/api/v1/scanner/configs/"+url.PathEscape(id)+"/registration-token", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScannerListAgents calls GET /api/v1/scanner/agents: List agents.
+// It needs the scanner:read permission.
+func (c *Client) ScannerListAgents(ctx context.Context) ([]ScannerAgent, error) {
+	var out []ScannerAgent
+	err := c.do(ctx, "GET", "/api/v1/scanner/agents", nil, nil, &out)
+	return out, err
+}
+
+// ScannerListScanConfigs calls GET /api/v1/scanner/configs: List scan configs.
+// It needs the scanner:read permission.
+func (c *Client) ScannerListScanConfigs(ctx context.Context) ([]ScanConfig, error) {
+	var out []ScanConfig
+	err := c.do(ctx, "GET", "/api/v1/scanner/configs", nil, nil, &out)
+	return out, err
+}
+
+// ScannerListScanJobs calls GET /api/v1/scanner/jobs: List scan jobs.
+// It needs the scanner:read permission.
+func (c *Client) ScannerListScanJobs(ctx context.Context) ([]ScanJob, error) {
+	var out []ScanJob
+	err := c.do(ctx, "GET", "/api/v1/scanner/jobs", nil, nil, &out)
+	return out, err
+}
+
+// ScannerRegisterAgent calls POST /api/v1/scanner/agents/register: The Agent's first contact.
+func (c *Client) ScannerRegisterAgent(ctx context.Context, body *RegisterAgentInput) (*RegisterAgentResult, error) {
+	out := new(RegisterAgentResult)
+	if err := c.do(ctx, "POST", "/api/v1/scanner/agents/register", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScannerRevokeAgent calls DELETE /api/v1/scanner/agents/{id}: Revoke agent.
+// It needs the scanner:delete permission.
+func (c *Client) ScannerRevokeAgent(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/scanner/agents/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// ScannerStreamScanEvents calls GET /api/v1/scanner/events: Browser SSE for live scan events (preview ready, scan failed).
+// It needs the scanner:read permission.
+func (c *Client) ScannerStreamScanEvents(ctx context.Context) error {
+	return c.do(ctx, "GET", "/api/v1/scanner/events", nil, nil, nil)
+}
+
+// ScannerTriggerScan calls POST /api/v1/scanner/configs/{id}/scan: Trigger scan.
+// It needs the scanner:scan permission.
+func (c *Client) ScannerTriggerScan(ctx context.Context, id string) (*ScanJob, error) {
+	out := new(ScanJob)
+	if err := c.do(ctx, "POST", "/api/v1/scanner/configs/"+url.PathEscape(id)+"/scan", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScoreEngineClassifyRisk calls POST /api/v1/score-engine/classify: Classer un niveau de risque.
+func (c *Client) ScoreEngineClassifyRisk(ctx context.Context, body *ClassifyRiskInput) (*ScoreEngineClassifyRiskResponse, error) {
+	out := new(ScoreEngineClassifyRiskResponse)
+	if err := c.do(ctx, "POST", "/api/v1/score-engine/classify", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScoreEngineComputeRiskScore calls POST /api/v1/score-engine/compute: Calculer le score de risque.
+func (c *Client) ScoreEngineComputeRiskScore(ctx context.Context, body *ComputeRiskScoreInput) (*ScoreEngineComputeRiskScoreResponse, error) {
+	out := new(ScoreEngineComputeRiskScoreResponse)
+	if err := c.do(ctx, "POST", "/api/v1/score-engine/compute", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScoreEngineCreateScoringConfig calls POST /api/v1/score-engine/configs: Créer une nouvelle configuration de scoring.
+// It needs the admin role.
+func (c *Client) ScoreEngineCreateScoringConfig(ctx context.Context, body *CreateScoringConfigInput, params *ScoreEngineCreateScoringConfigParams) (*ScoringConfig, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.ID != "" {
+			q.Set("id", params.ID)
+		}
+	}
+	out := new(ScoringConfig)
+	if err := c.do(ctx, "POST", "/api/v1/score-engine/configs", q, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScoreEngineCreateScoringConfigParams are ScoreEngineCreateScoringConfig's query parameters. Zero values are not sent.
+type ScoreEngineCreateScoringConfigParams struct {
+	ID string
+}
+
+// ScoreEngineGetRiskMatrix calls GET /api/v1/score-engine/matrix: Récupérer la matrice de risque.
+func (c *Client) ScoreEngineGetRiskMatrix(ctx context.Context, params *ScoreEngineGetRiskMatrixParams) (*ScoreEngineGetRiskMatrixResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.ConfigID != "" {
+			q.Set("config_id", params.ConfigID)
+		}
+	}
+	out := new(ScoreEngineGetRiskMatrixResponse)
+	if err := c.do(ctx, "GET", "/api/v1/score-engine/matrix", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScoreEngineGetRiskMatrixParams are ScoreEngineGetRiskMatrix's query parameters. Zero values are not sent.
+type ScoreEngineGetRiskMatrixParams struct {
+	ConfigID string
+}
+
+// ScoreEngineGetScoringConfig calls GET /api/v1/score-engine/configs/{id}: Récupérer une configuration de scoring.
+func (c *Client) ScoreEngineGetScoringConfig(ctx context.Context, id string) (*ScoringConfig, error) {
+	out := new(ScoringConfig)
+	if err := c.do(ctx, "GET", "/api/v1/score-engine/configs/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScoreEngineGetScoringConfigs calls GET /api/v1/score-engine/configs: Récupérer toutes les configurations de scoring.
+func (c *Client) ScoreEngineGetScoringConfigs(ctx context.Context) (*ScoreEngineGetScoringConfigsResponse, error) {
+	out := new(ScoreEngineGetScoringConfigsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/score-engine/configs", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScoreEngineGetScoringMetrics calls GET /api/v1/score-engine/metrics: Récupérer les métriques de scoring.
+func (c *Client) ScoreEngineGetScoringMetrics(ctx context.Context) (*ScoreEngineGetScoringMetricsResponse, error) {
+	out := new(ScoreEngineGetScoringMetricsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/score-engine/metrics", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScoreEngineUpdateScoringConfig calls PUT /api/v1/score-engine/configs/{id}: Mettre à jour une configuration de scoring.
+// It needs the admin role.
+func (c *Client) ScoreEngineUpdateScoringConfig(ctx context.Context, id string, body *CreateScoringConfigInput) (*ScoringConfig, error) {
+	out := new(ScoringConfig)
+	if err := c.do(ctx, "PUT", "/api/v1/score-engine/configs/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScoreGetScore calls GET /api/v1/score: One endpoint for every surface: the dashboard hero, the sidebar footer, the dedicated page, the register row and the asset drawer all call this.
+func (c *Client) ScoreGetScore(ctx context.Context, params *ScoreGetScoreParams) (*ScoringResult, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.ID != "" {
+			q.Set("id", params.ID)
+		}
+		if params.Scope != "" {
+			q.Set("scope", params.Scope)
+		}
+	}
+	out := new(ScoringResult)
+	if err := c.do(ctx, "GET", "/api/v1/score", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScoreGetScoreParams are ScoreGetScore's query parameters. Zero values are not sent.
+type ScoreGetScoreParams struct {
+	ID    string
+	Scope string
+}
+
+// ScoreGetScoreModel calls GET /api/v1/score/model: The model's own description: scale, band boundaries, factor weights per scope and the formula version.
+func (c *Client) ScoreGetScoreModel(ctx context.Context) (*ModelDescription, error) {
+	out := new(ModelDescription)
+	if err := c.do(ctx, "GET", "/api/v1/score/model", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScorePreviewScore calls POST /api/v1/score/preview: The live figure a form shows while a slider moves.
+func (c *Client) ScorePreviewScore(ctx context.Context, body *PreviewInput) (*ScoringResult, error) {
+	out := new(ScoringResult)
+	if err := c.do(ctx, "POST", "/api/v1/score/preview", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SearchSearch calls GET /api/v1/search: Returns entity hits (risk/asset/vulnerability) the caller may read, in the current tenant.
+func (c *Client) SearchSearch(ctx context.Context, params *SearchSearchParams) (*Response, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Q != "" {
+			q.Set("q", params.Q)
+		}
+	}
+	out := new(Response)
+	if err := c.do(ctx, "GET", "/api/v1/search", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SearchSearchParams are SearchSearch's query parameters. Zero values are not sent.
+type SearchSearchParams struct {
+	Q string
+}
+
+// SessionListSessions calls GET /api/v1/auth/sessions: Returns every device signed in to the caller's account.
+func (c *Client) SessionListSessions(ctx context.Context) (*SessionListSessionsResponse, error) {
+	out := new(SessionListSessionsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/auth/sessions", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SessionRevokeOtherSessions calls DELETE /api/v1/auth/sessions/others: Signs out every device except the caller's own.
+func (c *Client) SessionRevokeOtherSessions(ctx context.Context) (*SessionRevokeOtherSessionsResponse, error) {
+	out := new(SessionRevokeOtherSessionsResponse)
+	if err := c.do(ctx, "DELETE", "/api/v1/auth/sessions/others", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SessionRevokeSession calls DELETE /api/v1/auth/sessions/{id}: Signs one device out.
+func (c *Client) SessionRevokeSession(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/auth/sessions/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// SetAccessSubjectAttributes calls PUT /api/v1/access-policies/subjects/{id}: Replace a member's access attributes.
+// It needs one of the roles admin, root.
+func (c *Client) SetAccessSubjectAttributes(ctx context.Context, id string, body *SubjectAttributesInput) (*AccessSubject, error) {
+	out := new(AccessSubject)
+	if err := c.do(ctx, "PUT", "/api/v1/access-policies/subjects/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SetProgrammeCapacity calls PUT /api/v1/programme-capacities/{userId}: Set the share of a person's week available to programme work.
+// It needs the mitigations:update permission.
+func (c *Client) SetProgrammeCapacity(ctx context.Context, userID string, body *CapacityInput) (*AssigneeCapacity, error) {
+	out := new(AssigneeCapacity)
+	if err := c.do(ctx, "PUT", "/api/v1/programme-capacities/"+url.PathEscape(userID), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SimulateRiskFinancial calls POST /api/v1/risks/{id}/simulate: What-if investment simulation (non-persisting).
+// It needs the risks:read permission.
+// The organisation's plan must include financial_quantification.
+func (c *Client) SimulateRiskFinancial(ctx context.Context, id string, body *SimulateFinancialInput) (*FinancialAssessment, error) {
+	out := new(FinancialAssessment)
+	if err := c.do(ctx, "POST", "/api/v1/risks/"+url.PathEscape(id)+"/simulate", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SimulateRiskSmartScore calls POST /api/v1/risks/{id}/smart-score/simulate: Preview the smart score with custom weights (non-persisting).
+// It needs the risks:read permission.
+// The organisation's plan must include smart_score.
+func (c *Client) SimulateRiskSmartScore(ctx context.Context, id string, body *FactorWeightsInput) (*SmartResult, error) {
+	out := new(SmartResult)
+	if err := c.do(ctx, "POST", "/api/v1/risks/"+url.PathEscape(id)+"/smart-score/simulate", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StreamMitigationEvents calls GET /api/v1/mitigations/events: Stream mitigation changes (server-sent events).
+func (c *Client) StreamMitigationEvents(ctx context.Context, params *StreamMitigationEventsParams) error {
+	q := url.Values{}
+	if params != nil {
+		if params.Token != "" {
+			q.Set("token", params.Token)
+		}
+	}
+	return c.do(ctx, "GET", "/api/v1/mitigations/events", q, nil, nil)
+}
+
+// StreamMitigationEventsParams are StreamMitigationEvents's query parameters. Zero values are not sent.
+type StreamMitigationEventsParams struct {
+	Token string
+}
+
+// SwitchListOrganizations calls GET /api/v1/auth/organizations: Returns the organizations the authenticated user may switch into (their active memberships).
+func (c *Client) SwitchListOrganizations(ctx context.Context) (*SwitchListOrganizationsResponse, error) {
+	out := new(SwitchListOrganizationsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/auth/organizations", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SwitchSwitchOrganization calls POST /api/v1/auth/switch-org: Mints a session scoped to the target organization.
+func (c *Client) SwitchSwitchOrganization(ctx context.Context, body *SwitchOrganizationRequest) (map[string]any, error) {
+	var out map[string]any
+	err := c.do(ctx, "POST", "/api/v1/auth/switch-org", nil, body, &out)
+	return out, err
+}
+
+// TakeRegisterSnapshot calls POST /api/v1/register-snapshots: Freeze the register now.
+// It needs the risks:update permission.
+func (c *Client) TakeRegisterSnapshot(ctx context.Context, body *TakeRegisterSnapshotRequest) (*RegisterSnapshot, error) {
+	out := new(RegisterSnapshot)
+	if err := c.do(ctx, "POST", "/api/v1/register-snapshots", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TelemetryGetTelemetry calls GET /api/v1/telemetry: Get telemetry.
+func (c *Client) TelemetryGetTelemetry(ctx context.Context) (*TelemetryGetTelemetryResponse, error) {
+	out := new(TelemetryGetTelemetryResponse)
+	if err := c.do(ctx, "GET", "/api/v1/telemetry", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TelemetrySetTelemetry calls PUT /api/v1/telemetry: (admin).
+// It needs one of the roles admin, root.
+func (c *Client) TelemetrySetTelemetry(ctx context.Context, body *TelemetryInput) (*TelemetrySetTelemetryResponse, error) {
+	out := new(TelemetrySetTelemetryResponse)
+	if err := c.do(ctx, "PUT", "/api/v1/telemetry", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TestIntegration calls POST /api/v1/integrations/{id}/test: Tests an integration connection (protected route).
+func (c *Client) TestIntegration(ctx context.Context, id string, body *TestIntegrationInput) error {
+	return c.do(ctx, "POST", "/api/v1/integrations/"+url.PathEscape(id)+"/test", nil, body, nil)
+}
+
+// TheHiveDeleteConnection calls DELETE /api/v1/integrations/thehive: Delete connection.
+// It needs the admin role.
+func (c *Client) TheHiveDeleteConnection(ctx context.Context) error {
+	return c.do(ctx, "DELETE", "/api/v1/integrations/thehive", nil, nil, nil)
+}
+
+// TheHiveGetConnection calls GET /api/v1/integrations/thehive: Get connection.
+// It needs the admin role.
+func (c *Client) TheHiveGetConnection(ctx context.Context) (*TheHiveConnection, error) {
+	out := new(TheHiveConnection)
+	if err := c.do(ctx, "GET", "/api/v1/integrations/thehive", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TheHiveGetIncidentLink calls GET /api/v1/incidents/{id}/thehive: The linked case and the observables it carried, with the asset each was attributed to.
+func (c *Client) TheHiveGetIncidentLink(ctx context.Context, id string) (*TheHiveGetIncidentLinkResponse, error) {
+	out := new(TheHiveGetIncidentLinkResponse)
+	if err := c.do(ctx, "GET", "/api/v1/incidents/"+url.PathEscape(id)+"/thehive", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TheHivePushIncident calls POST /api/v1/incidents/{id}/thehive: Push incident.
+// It needs the incidents:update permission.
+func (c *Client) TheHivePushIncident(ctx context.Context, id string) (*TheHiveCaseLink, error) {
+	out := new(TheHiveCaseLink)
+	if err := c.do(ctx, "POST", "/api/v1/incidents/"+url.PathEscape(id)+"/thehive", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TheHiveSaveConnection calls PUT /api/v1/integrations/thehive: Save connection.
+// It needs the admin role.
+func (c *Client) TheHiveSaveConnection(ctx context.Context, body *TheHiveConnectionBody) (*TheHiveConnection, error) {
+	out := new(TheHiveConnection)
+	if err := c.do(ctx, "PUT", "/api/v1/integrations/thehive", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TheHiveSyncNow calls POST /api/v1/integrations/thehive/sync: Sync now.
+// It needs the incidents:update permission.
+func (c *Client) TheHiveSyncNow(ctx context.Context) (*SyncReport, error) {
+	out := new(SyncReport)
+	if err := c.do(ctx, "POST", "/api/v1/integrations/thehive/sync", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TheHiveWebhook calls POST /api/v1/integrations/thehive/webhook: TheHive's notifier.
+func (c *Client) TheHiveWebhook(ctx context.Context, body *WebhookEvent, params *TheHiveWebhookParams) (*SyncReport, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Token != "" {
+			q.Set("token", params.Token)
+		}
+	}
+	out := new(SyncReport)
+	if err := c.do(ctx, "POST", "/api/v1/integrations/thehive/webhook", q, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TheHiveWebhookParams are TheHiveWebhook's query parameters. Zero values are not sent.
+type TheHiveWebhookParams struct {
+	Token string
+}
+
+// TokenCreateToken calls POST /api/v1/tokens: Creates a new API token.
+func (c *Client) TokenCreateToken(ctx context.Context, body *TokenCreateRequest) (*TokenCreateTokenResponse, error) {
+	out := new(TokenCreateTokenResponse)
+	if err := c.do(ctx, "POST", "/api/v1/tokens", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TokenDeleteToken calls DELETE /api/v1/tokens/{id}: Deletes a token.
+func (c *Client) TokenDeleteToken(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/tokens/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// TokenGetToken calls GET /api/v1/tokens/{id}: Retrieves a single token.
+func (c *Client) TokenGetToken(ctx context.Context, id string) (*TokenGetTokenResponse, error) {
+	out := new(TokenGetTokenResponse)
+	if err := c.do(ctx, "GET", "/api/v1/tokens/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TokenListTokens calls GET /api/v1/tokens: Lists all API tokens for the user.
+func (c *Client) TokenListTokens(ctx context.Context) (*TokenListTokensResponse, error) {
+	out := new(TokenListTokensResponse)
+	if err := c.do(ctx, "GET", "/api/v1/tokens", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TokenRevokeToken calls POST /api/v1/tokens/{id}/revoke: Revokes a token.
+func (c *Client) TokenRevokeToken(ctx context.Context, id string, body *TokenRevokeRequest) (*TokenRevokeTokenResponse, error) {
+	out := new(TokenRevokeTokenResponse)
+	if err := c.do(ctx, "POST", "/api/v1/tokens/"+url.PathEscape(id)+"/revoke", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TokenRotateToken calls POST /api/v1/tokens/{id}/rotate: Rotates a token.
+func (c *Client) TokenRotateToken(ctx context.Context, id string) (*TokenRotateTokenResponse, error) {
+	out := new(TokenRotateTokenResponse)
+	if err := c.do(ctx, "POST", "/api/v1/tokens/"+url.PathEscape(id)+"/rotate", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TokenUpdateToken calls PUT /api/v1/tokens/{id}: Updates a token.
+func (c *Client) TokenUpdateToken(ctx context.Context, id string, body *TokenUpdateRequest) (*TokenUpdateTokenResponse, error) {
+	out := new(TokenUpdateTokenResponse)
+	if err := c.do(ctx, "PUT", "/api/v1/tokens/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UnlinkMitigationProgrammeControl calls DELETE /api/v1/mitigation-programmes/{id}/controls/{controlId}: Unlink a compliance control.
+// It needs the mitigations:update permission.
+func (c *Client) UnlinkMitigationProgrammeControl(ctx context.Context, id string, controlID string) error {
+	return c.do(ctx, "DELETE", "/api/v1/mitigation-programmes/"+url.PathEscape(id)+"/controls/"+url.PathEscape(controlID), nil, nil, nil)
+}
+
+// UnlinkMitigationProgrammeRisk calls DELETE /api/v1/mitigation-programmes/{id}/risks/{riskId}: Unlink a risk.
+// It needs the mitigations:update permission.
+func (c *Client) UnlinkMitigationProgrammeRisk(ctx context.Context, id string, riskID string) error {
+	return c.do(ctx, "DELETE", "/api/v1/mitigation-programmes/"+url.PathEscape(id)+"/risks/"+url.PathEscape(riskID), nil, nil, nil)
+}
+
+// UpdateAccessPolicy calls PUT /api/v1/access-policies/{id}: Replace an access policy.
+// It needs one of the roles admin, root.
+func (c *Client) UpdateAccessPolicy(ctx context.Context, id string, body *PolicyInput) (*AccessPolicy, error) {
+	out := new(AccessPolicy)
+	if err := c.do(ctx, "PUT", "/api/v1/access-policies/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateAsset calls PATCH /api/v1/assets/{id}: Update asset.
+// It needs the assets:update permission.
+func (c *Client) UpdateAsset(ctx context.Context, id string, body *UpdateAssetInput) (*Asset, error) {
+	out := new(Asset)
+	if err := c.do(ctx, "PATCH", "/api/v1/assets/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateBoardReport calls PATCH /api/v1/reports/board/{reportId}: Edit a draft board report's narrative.
+// It needs the reports:board:update permission.
+func (c *Client) UpdateBoardReport(ctx context.Context, reportID string, body *UpdateBoardReportInput) (*BoardReport, error) {
+	out := new(BoardReport)
+	if err := c.do(ctx, "PATCH", "/api/v1/reports/board/"+url.PathEscape(reportID), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateComplianceAudit calls PATCH /api/v1/compliance/audits/{id}: Update a compliance audit (status, dates, summary…).
+// It needs the compliance:audits:write permission.
+func (c *Client) UpdateComplianceAudit(ctx context.Context, id string, body *UpdateAuditBody) (*ComplianceAudit, error) {
+	out := new(ComplianceAudit)
+	if err := c.do(ctx, "PATCH", "/api/v1/compliance/audits/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateComplianceControl calls PATCH /api/v1/compliance/controls/{controlId}: Update a control (notably its implementation status).
+// It needs the compliance:controls:update permission.
+func (c *Client) UpdateComplianceControl(ctx context.Context, controlID string, body *UpdateControlInput) (*ComplianceControl, error) {
+	out := new(ComplianceControl)
+	if err := c.do(ctx, "PATCH", "/api/v1/compliance/controls/"+url.PathEscape(controlID), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateControlTestPlan calls PUT /api/v1/control-test-plans/{id}: Update a test plan (its kind is fixed).
+// It needs the compliance:controls:update permission.
+func (c *Client) UpdateControlTestPlan(ctx context.Context, id string, body *ControltestPlanInput) (*ControlTestPlan, error) {
+	out := new(ControlTestPlan)
+	if err := c.do(ctx, "PUT", "/api/v1/control-test-plans/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateImportProfile calls PUT /api/v1/import-profiles/{id}: Update a mapping profile.
+// It needs one of the roles admin, root.
+func (c *Client) UpdateImportProfile(ctx context.Context, id string, body *ProfileInput) (*ImportMappingProfile, error) {
+	out := new(ImportMappingProfile)
+	if err := c.do(ctx, "PUT", "/api/v1/import-profiles/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateMitigation calls PATCH /api/v1/mitigations/{id}: Update mitigation details.
+// It needs the mitigations:update permission.
+func (c *Client) UpdateMitigation(ctx context.Context, id string, body *UpdateMitigationRequest, params *UpdateMitigationParams) (*Mitigation, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(Mitigation)
+	if err := c.do(ctx, "PATCH", "/api/v1/mitigations/"+url.PathEscape(id), q, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateMitigationParams are UpdateMitigation's query parameters. Zero values are not sent.
+type UpdateMitigationParams struct {
+	Locale string
+}
+
+// UpdateMitigationProgramme calls PUT /api/v1/mitigation-programmes/{id}: Update a programme.
+// It needs the mitigations:update permission.
+func (c *Client) UpdateMitigationProgramme(ctx context.Context, id string, body *ProgrammeInput) (*MitigationProgramme, error) {
+	out := new(MitigationProgramme)
+	if err := c.do(ctx, "PUT", "/api/v1/mitigation-programmes/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdatePluginInstall calls PUT /api/v1/plugin-installs/{id}: Update an install's grants, limits or configuration.
+// It needs one of the roles admin, root.
+func (c *Client) UpdatePluginInstall(ctx context.Context, id string, body *InstallInput) (*PluginInstall, error) {
+	out := new(PluginInstall)
+	if err := c.do(ctx, "PUT", "/api/v1/plugin-installs/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateProgrammeAction calls PUT /api/v1/programme-actions/{id}: Update an action, including marking it done.
+// It needs the mitigations:update permission.
+func (c *Client) UpdateProgrammeAction(ctx context.Context, id string, body *ActionInput) (*ProgrammeAction, error) {
+	out := new(ProgrammeAction)
+	if err := c.do(ctx, "PUT", "/api/v1/programme-actions/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateRemediation calls PATCH /api/v1/compliance/remediations/{id}: Update a remediation plan (status, assignee, due date…).
+// It needs the compliance:remediations:write permission.
+func (c *Client) UpdateRemediation(ctx context.Context, id string, body *UpdateRemediationBody) (*RemediationPlan, error) {
+	out := new(RemediationPlan)
+	if err := c.do(ctx, "PATCH", "/api/v1/compliance/remediations/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateRisk calls PATCH /api/v1/risks/{id}: Update risk.
+// It needs the risks:update permission.
+func (c *Client) UpdateRisk(ctx context.Context, id string, body *UpdateRiskInput, params *UpdateRiskParams) (*Risk, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Locale != "" {
+			q.Set("locale", params.Locale)
+		}
+	}
+	out := new(Risk)
+	if err := c.do(ctx, "PATCH", "/api/v1/risks/"+url.PathEscape(id), q, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateRiskParams are UpdateRisk's query parameters. Zero values are not sent.
+type UpdateRiskParams struct {
+	Locale string
+}
+
+// UpdateRiskScoringWeights calls PUT /api/v1/risk-scoring/weights: Update the tenant's factor weights (admin).
+// It needs the admin role.
+func (c *Client) UpdateRiskScoringWeights(ctx context.Context, body *FactorWeightsInput) (*RiskScoringWeights, error) {
+	out := new(RiskScoringWeights)
+	if err := c.do(ctx, "PUT", "/api/v1/risk-scoring/weights", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateSIEMDestination calls PUT /api/v1/siem-destinations/{id}: Update a SIEM destination.
+// It needs one of the roles admin, root.
+func (c *Client) UpdateSIEMDestination(ctx context.Context, id string, body *DestinationInput) (*SIEMDestination, error) {
+	out := new(SIEMDestination)
+	if err := c.do(ctx, "PUT", "/api/v1/siem-destinations/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateSubAction calls PATCH /api/v1/mitigations/{id}/sub-actions/{aid}: Updates a subaction.
+// It needs the mitigations:update permission.
+func (c *Client) UpdateSubAction(ctx context.Context, id string, aid string, body *UpdateSubActionRequest) (*MitigationSubAction, error) {
+	out := new(MitigationSubAction)
+	if err := c.do(ctx, "PATCH", "/api/v1/mitigations/"+url.PathEscape(id)+"/sub-actions/"+url.PathEscape(aid), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateTeam calls PATCH /api/v1/teams/{id}: Updates a team (admin only).
+// It needs the admin role.
+func (c *Client) UpdateTeam(ctx context.Context, id string, body *UpdateTeamInput) (*TeamResponseDTO, error) {
+	out := new(TeamResponseDTO)
+	if err := c.do(ctx, "PATCH", "/api/v1/teams/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateUserProfile calls PATCH /api/v1/users/{id}: Updates the current user's profile.
+func (c *Client) UpdateUserProfile(ctx context.Context, id string, body *UpdateUserProfileInput) (*UserResponseDTO, error) {
+	out := new(UserResponseDTO)
+	if err := c.do(ctx, "PATCH", "/api/v1/users/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateUserRole calls PATCH /api/v1/users/{id}/role: Changes a user's role (admin only).
+// It needs the admin role.
+func (c *Client) UpdateUserRole(ctx context.Context, id string, body *UpdateUserRoleInput) (*UpdateUserRoleResponse, error) {
+	out := new(UpdateUserRoleResponse)
+	if err := c.do(ctx, "PATCH", "/api/v1/users/"+url.PathEscape(id)+"/role", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateUserStatus calls PATCH /api/v1/users/{id}/status: Enables or disables a user (admin only).
+// It needs the admin role.
+func (c *Client) UpdateUserStatus(ctx context.Context, id string, body *UpdateUserStatusInput) (*UpdateUserStatusResponse, error) {
+	out := new(UpdateUserStatusResponse)
+	if err := c.do(ctx, "PATCH", "/api/v1/users/"+url.PathEscape(id)+"/status", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateWebhook calls PUT /api/v1/webhooks/{id}: Update a subscription.
+// It needs one of the roles admin, root.
+func (c *Client) UpdateWebhook(ctx context.Context, id string, body *SubscriptionInput) (*WebhookSubscription, error) {
+	out := new(WebhookSubscription)
+	if err := c.do(ctx, "PUT", "/api/v1/webhooks/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UploadImport calls POST /api/v1/imports: Upload a file to import.
+// It needs one of the roles admin, root.
+func (c *Client) UploadImport(ctx context.Context, form Form) (*Staged, error) {
+	out := new(Staged)
+	if err := c.do(ctx, "POST", "/api/v1/imports", nil, form, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UploadPlugin calls POST /api/v1/plugins: Upload a signed plugin bundle.
+// It needs one of the roles admin, root.
+func (c *Client) UploadPlugin(ctx context.Context, form Form) (*PluginPackage, error) {
+	out := new(PluginPackage)
+	if err := c.do(ctx, "POST", "/api/v1/plugins", nil, form, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UploadVulnerabilityFile calls POST /api/v1/vulnerabilities/upload: Upload a SARIF, CycloneDX or scanner findings file.
+// It needs the vulnerabilities:update permission.
+func (c *Client) UploadVulnerabilityFile(ctx context.Context, form Form) (*IngestResult, error) {
+	out := new(IngestResult)
+	if err := c.do(ctx, "POST", "/api/v1/vulnerabilities/upload", nil, form, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ValidateMitigation calls PATCH /api/v1/mitigations/{id}/validate: Transitions a plan from REVIEW to DONE (reviewer approval).
+// It needs the mitigations:update permission.
+func (c *Client) ValidateMitigation(ctx context.Context, id string) (*Mitigation, error) {
+	out := new(Mitigation)
+	if err := c.do(ctx, "PATCH", "/api/v1/mitigations/"+url.PathEscape(id)+"/validate", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VendorCreateTemplate calls POST /api/v1/vendor-questionnaires: Build a custom questionnaire.
+// It needs the risks:update permission.
+func (c *Client) VendorCreateTemplate(ctx context.Context, body *TemplateInput) (*VendorQuestionnaireTemplate, error) {
+	out := new(VendorQuestionnaireTemplate)
+	if err := c.do(ctx, "POST", "/api/v1/vendor-questionnaires", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VendorCreateVendor calls POST /api/v1/vendors: Add a vendor.
+// It needs the risks:update permission.
+func (c *Client) VendorCreateVendor(ctx context.Context, body *VendorInput) (*Vendor, error) {
+	out := new(Vendor)
+	if err := c.do(ctx, "POST", "/api/v1/vendors", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VendorDeleteTemplate calls DELETE /api/v1/vendor-questionnaires/{id}: Delete a custom questionnaire.
+// It needs the risks:update permission.
+func (c *Client) VendorDeleteTemplate(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/vendor-questionnaires/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// VendorDeleteVendor calls DELETE /api/v1/vendors/{id}: Delete a vendor and its assessments.
+// It needs the risks:update permission.
+func (c *Client) VendorDeleteVendor(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/vendors/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// VendorGetTemplate calls GET /api/v1/vendor-questionnaires/{id}: One questionnaire.
+// It needs the risks:read permission.
+func (c *Client) VendorGetTemplate(ctx context.Context, id string) (*VendorQuestionnaireTemplate, error) {
+	out := new(VendorQuestionnaireTemplate)
+	if err := c.do(ctx, "GET", "/api/v1/vendor-questionnaires/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VendorGetVendor calls GET /api/v1/vendors/{id}: One vendor with its assessments.
+// It needs the risks:read permission.
+func (c *Client) VendorGetVendor(ctx context.Context, id string) (*VendorDetail, error) {
+	out := new(VendorDetail)
+	if err := c.do(ctx, "GET", "/api/v1/vendors/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VendorListTemplates calls GET /api/v1/vendor-questionnaires: List questionnaires.
+// It needs the risks:read permission.
+func (c *Client) VendorListTemplates(ctx context.Context) ([]VendorQuestionnaireTemplate, error) {
+	var out []VendorQuestionnaireTemplate
+	err := c.do(ctx, "GET", "/api/v1/vendor-questionnaires", nil, nil, &out)
+	return out, err
+}
+
+// VendorListVendors calls GET /api/v1/vendors: List the vendor register.
+// It needs the risks:read permission.
+func (c *Client) VendorListVendors(ctx context.Context, params *VendorListVendorsParams) ([]VendorView, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Q != "" {
+			q.Set("q", params.Q)
+		}
+		if params.Status != "" {
+			q.Set("status", params.Status)
+		}
+		if params.Tier != "" {
+			q.Set("tier", params.Tier)
+		}
+	}
+	var out []VendorView
+	err := c.do(ctx, "GET", "/api/v1/vendors", q, nil, &out)
+	return out, err
+}
+
+// VendorListVendorsParams are VendorListVendors's query parameters. Zero values are not sent.
+type VendorListVendorsParams struct {
+	Q      string
+	Status string
+	Tier   string
+}
+
+// VendorPortal calls GET /api/v1/vendor-portal: Open a questionnaire link (vendor, no account).
+func (c *Client) VendorPortal(ctx context.Context, params *VendorPortalParams) (*PortalView, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Token != "" {
+			q.Set("token", params.Token)
+		}
+	}
+	out := new(PortalView)
+	if err := c.do(ctx, "GET", "/api/v1/vendor-portal", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VendorPortalParams are VendorPortal's query parameters. Zero values are not sent.
+type VendorPortalParams struct {
+	Token string
+}
+
+// VendorPortalAnswers calls POST /api/v1/vendor-portal/answers: Save or submit answers (vendor, no account).
+func (c *Client) VendorPortalAnswers(ctx context.Context, body *AnswersInput) (*PortalView, error) {
+	out := new(PortalView)
+	if err := c.do(ctx, "POST", "/api/v1/vendor-portal/answers", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VendorPortalDocument calls POST /api/v1/vendor-portal/documents: Attach a document to an answer (vendor, no account).
+func (c *Client) VendorPortalDocument(ctx context.Context, form Form) (*PortalView, error) {
+	out := new(PortalView)
+	if err := c.do(ctx, "POST", "/api/v1/vendor-portal/documents", nil, form, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VendorRevokeAssessment calls POST /api/v1/vendor-assessments/{id}/revoke: Withdraw an assessment link.
+// It needs the risks:update permission.
+func (c *Client) VendorRevokeAssessment(ctx context.Context, id string) (*AssessmentView, error) {
+	out := new(AssessmentView)
+	if err := c.do(ctx, "POST", "/api/v1/vendor-assessments/"+url.PathEscape(id)+"/revoke", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VendorSendAssessment calls POST /api/v1/vendors/{id}/assessments: Send a questionnaire to a vendor.
+// It needs the risks:update permission.
+func (c *Client) VendorSendAssessment(ctx context.Context, id string, body *SendInput) (*SendResult, error) {
+	out := new(SendResult)
+	if err := c.do(ctx, "POST", "/api/v1/vendors/"+url.PathEscape(id)+"/assessments", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VendorUpdateTemplate calls PUT /api/v1/vendor-questionnaires/{id}: Replace a custom questionnaire.
+// It needs the risks:update permission.
+func (c *Client) VendorUpdateTemplate(ctx context.Context, id string, body *TemplateInput) (*VendorQuestionnaireTemplate, error) {
+	out := new(VendorQuestionnaireTemplate)
+	if err := c.do(ctx, "PUT", "/api/v1/vendor-questionnaires/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VendorUpdateVendor calls PUT /api/v1/vendors/{id}: Replace a vendor's details.
+// It needs the risks:update permission.
+func (c *Client) VendorUpdateVendor(ctx context.Context, id string, body *VendorInput) (*Vendor, error) {
+	out := new(Vendor)
+	if err := c.do(ctx, "PUT", "/api/v1/vendors/"+url.PathEscape(id), nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnCorrelationGetCandidates calls GET /api/v1/vulnerabilities/{id}/match-candidates: Get candidates.
+// It needs the vulnerabilities:read permission.
+func (c *Client) VulnCorrelationGetCandidates(ctx context.Context, id string) (*VulnCorrelationGetCandidatesResponse, error) {
+	out := new(VulnCorrelationGetCandidatesResponse)
+	if err := c.do(ctx, "GET", "/api/v1/vulnerabilities/"+url.PathEscape(id)+"/match-candidates", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnCorrelationListUnassigned calls GET /api/v1/vulnerabilities/unassigned: Findings the correlator would not attribute: nothing matched, or several assets matched equally well.
+// It needs the vulnerabilities:read permission.
+func (c *Client) VulnCorrelationListUnassigned(ctx context.Context, params *VulnCorrelationListUnassignedParams) (*VulnCorrelationListUnassignedResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.Page != "" {
+			q.Set("page", params.Page)
+		}
+		if params.Q != "" {
+			q.Set("q", params.Q)
+		}
+		if params.Severity != "" {
+			q.Set("severity", params.Severity)
+		}
+	}
+	out := new(VulnCorrelationListUnassignedResponse)
+	if err := c.do(ctx, "GET", "/api/v1/vulnerabilities/unassigned", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnCorrelationListUnassignedParams are VulnCorrelationListUnassigned's query parameters. Zero values are not sent.
+type VulnCorrelationListUnassignedParams struct {
+	Limit    string
+	Page     string
+	Q        string
+	Severity string
+}
+
+// VulnCorrelationResolveAsset calls PUT /api/v1/vulnerabilities/{id}/asset: Pin a human's attribution.
+// It needs the vulnerabilities:update permission.
+func (c *Client) VulnCorrelationResolveAsset(ctx context.Context, id string, body *ResolveAssetBody) (*Vulnerability, error) {
+	out := new(Vulnerability)
+	if err := c.do(ctx, "PUT", "/api/v1/vulnerabilities/"+url.PathEscape(id)+"/asset", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnIntegrationCreateTicket calls POST /api/v1/vulnerabilities/{id}/ticket: Open an ITSM ticket for a vuln.
+// It needs the vulnerabilities:update permission.
+func (c *Client) VulnIntegrationCreateTicket(ctx context.Context, id string) (*Vulnerability, error) {
+	out := new(Vulnerability)
+	if err := c.do(ctx, "POST", "/api/v1/vulnerabilities/"+url.PathEscape(id)+"/ticket", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnIntegrationDeleteIntegration calls DELETE /api/v1/vulnerabilities/integrations/{id}: Delete integration.
+// It needs the vulnerabilities:delete permission.
+func (c *Client) VulnIntegrationDeleteIntegration(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/vulnerabilities/integrations/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// VulnIntegrationDeleteTicketing calls DELETE /api/v1/vulnerabilities/ticketing: Delete ticketing.
+// It needs the vulnerabilities:delete permission.
+func (c *Client) VulnIntegrationDeleteTicketing(ctx context.Context) error {
+	return c.do(ctx, "DELETE", "/api/v1/vulnerabilities/ticketing", nil, nil, nil)
+}
+
+// VulnIntegrationGetIntegration calls GET /api/v1/vulnerabilities/integrations/{id}: Get integration.
+// It needs the vulnerabilities:read permission.
+func (c *Client) VulnIntegrationGetIntegration(ctx context.Context, id string) (*VulnIntegration, error) {
+	out := new(VulnIntegration)
+	if err := c.do(ctx, "GET", "/api/v1/vulnerabilities/integrations/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnIntegrationGetTicketing calls GET /api/v1/vulnerabilities/ticketing: Get ticketing.
+// It needs the vulnerabilities:read permission.
+func (c *Client) VulnIntegrationGetTicketing(ctx context.Context) (*VulnTicketingConfig, error) {
+	out := new(VulnTicketingConfig)
+	if err := c.do(ctx, "GET", "/api/v1/vulnerabilities/ticketing", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnIntegrationListIntegrations calls GET /api/v1/vulnerabilities/integrations: List integrations.
+// It needs the vulnerabilities:read permission.
+func (c *Client) VulnIntegrationListIntegrations(ctx context.Context) (*VulnIntegrationListIntegrationsResponse, error) {
+	out := new(VulnIntegrationListIntegrationsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/vulnerabilities/integrations", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnIntegrationSaveIntegration calls POST /api/v1/vulnerabilities/integrations: Create or update a connector config for a source (credentials encrypted at rest).
+// It needs the vulnerabilities:update permission.
+func (c *Client) VulnIntegrationSaveIntegration(ctx context.Context, body *SaveIntegrationBody) (*VulnIntegration, error) {
+	out := new(VulnIntegration)
+	if err := c.do(ctx, "POST", "/api/v1/vulnerabilities/integrations", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnIntegrationSaveTicketing calls PUT /api/v1/vulnerabilities/ticketing: Save ticketing.
+// It needs the vulnerabilities:update permission.
+func (c *Client) VulnIntegrationSaveTicketing(ctx context.Context, body *SaveTicketingBody) (*VulnTicketingConfig, error) {
+	out := new(VulnTicketingConfig)
+	if err := c.do(ctx, "PUT", "/api/v1/vulnerabilities/ticketing", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnIntegrationTriggerPull calls POST /api/v1/vulnerabilities/integrations/{id}/pull: Run a live API pull now.
+// It needs the vulnerabilities:update permission.
+func (c *Client) VulnIntegrationTriggerPull(ctx context.Context, id string) (*LivePullResult, error) {
+	out := new(LivePullResult)
+	if err := c.do(ctx, "POST", "/api/v1/vulnerabilities/integrations/"+url.PathEscape(id)+"/pull", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnRiskRuleBulkReview calls POST /api/v1/attack-surface/draft-risks/review: Bulk review.
+// It needs the risks:update permission.
+func (c *Client) VulnRiskRuleBulkReview(ctx context.Context, body *BulkReviewBody) (*BulkReviewResult, error) {
+	out := new(BulkReviewResult)
+	if err := c.do(ctx, "POST", "/api/v1/attack-surface/draft-risks/review", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnRiskRuleGetRule calls GET /api/v1/attack-surface/risk-rule: Get rule.
+// It needs the vulnerabilities:read permission.
+func (c *Client) VulnRiskRuleGetRule(ctx context.Context) (*VulnRiskRule, error) {
+	out := new(VulnRiskRule)
+	if err := c.do(ctx, "GET", "/api/v1/attack-surface/risk-rule", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnRiskRuleListDrafts calls GET /api/v1/attack-surface/draft-risks: List drafts.
+// It needs the risks:read permission.
+func (c *Client) VulnRiskRuleListDrafts(ctx context.Context, params *VulnRiskRuleListDraftsParams) (*VulnRiskRuleListDraftsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.Page != "" {
+			q.Set("page", params.Page)
+		}
+	}
+	out := new(VulnRiskRuleListDraftsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/attack-surface/draft-risks", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnRiskRuleListDraftsParams are VulnRiskRuleListDrafts's query parameters. Zero values are not sent.
+type VulnRiskRuleListDraftsParams struct {
+	Limit string
+	Page  string
+}
+
+// VulnRiskRulePreviewRule calls POST /api/v1/attack-surface/risk-rule/preview: Runs the rule over the current register WITHOUT creating anything.
+// It needs the vulnerabilities:read permission.
+func (c *Client) VulnRiskRulePreviewRule(ctx context.Context, body map[string]any) (*RulePreview, error) {
+	out := new(RulePreview)
+	if err := c.do(ctx, "POST", "/api/v1/attack-surface/risk-rule/preview", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnRiskRuleUpdateRule calls PUT /api/v1/attack-surface/risk-rule: (admin).
+// It needs one of the roles admin, root.
+func (c *Client) VulnRiskRuleUpdateRule(ctx context.Context, body *UpdateRuleInput) (*VulnRiskRule, error) {
+	out := new(VulnRiskRule)
+	if err := c.do(ctx, "PUT", "/api/v1/attack-surface/risk-rule", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnWebhookIngest calls POST /api/v1/vulnerabilities/webhook/{source}: Token-authenticated push.
+func (c *Client) VulnWebhookIngest(ctx context.Context, source string, params *VulnWebhookIngestParams) (*VulnWebhookIngestResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Token != "" {
+			q.Set("token", params.Token)
+		}
+	}
+	out := new(VulnWebhookIngestResponse)
+	if err := c.do(ctx, "POST", "/api/v1/vulnerabilities/webhook/"+url.PathEscape(source), q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnWebhookIngestParams are VulnWebhookIngest's query parameters. Zero values are not sent.
+type VulnWebhookIngestParams struct {
+	Token string
+}
+
+// VulnerabilityDelete calls DELETE /api/v1/vulnerabilities/{id}: Delete.
+// It needs the vulnerabilities:delete permission.
+func (c *Client) VulnerabilityDelete(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/vulnerabilities/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// VulnerabilityGet calls GET /api/v1/vulnerabilities/{id}: Get.
+// It needs the vulnerabilities:read permission.
+func (c *Client) VulnerabilityGet(ctx context.Context, id string) (*Vulnerability, error) {
+	out := new(Vulnerability)
+	if err := c.do(ctx, "GET", "/api/v1/vulnerabilities/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnerabilityIngest calls POST /api/v1/vulnerabilities/ingest: Normalise + prioritise + upsert findings from a named integration (Nessus/OpenVAS/Qualys/Defender/Inspector/AzureDefender/CrowdStrike).
+// It needs the vulnerabilities:update permission.
+func (c *Client) VulnerabilityIngest(ctx context.Context, body *IngestReqBody) (*IngestResult, error) {
+	out := new(IngestResult)
+	if err := c.do(ctx, "POST", "/api/v1/vulnerabilities/ingest", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnerabilityList calls GET /api/v1/vulnerabilities: Filtered, prioritised register.
+// It needs the vulnerabilities:read permission.
+func (c *Client) VulnerabilityList(ctx context.Context, params *VulnerabilityListParams) (*VulnerabilityListResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.AssetID != "" {
+			q.Set("asset_id", params.AssetID)
+		}
+		if params.FirstSeenAfter != "" {
+			q.Set("first_seen_after", params.FirstSeenAfter)
+		}
+		if params.KEV != "" {
+			q.Set("kev", params.KEV)
+		}
+		if params.Limit != "" {
+			q.Set("limit", params.Limit)
+		}
+		if params.MinCVSS != "" {
+			q.Set("min_cvss", params.MinCVSS)
+		}
+		if params.Page != "" {
+			q.Set("page", params.Page)
+		}
+		if params.Q != "" {
+			q.Set("q", params.Q)
+		}
+		if params.Severity != "" {
+			q.Set("severity", params.Severity)
+		}
+		if params.SortBy != "" {
+			q.Set("sort_by", params.SortBy)
+		}
+		if params.SortDir != "" {
+			q.Set("sort_dir", params.SortDir)
+		}
+		if params.Source != "" {
+			q.Set("source", params.Source)
+		}
+		if params.Status != "" {
+			q.Set("status", params.Status)
+		}
+		if params.Tier != "" {
+			q.Set("tier", params.Tier)
+		}
+	}
+	out := new(VulnerabilityListResponse)
+	if err := c.do(ctx, "GET", "/api/v1/vulnerabilities", q, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnerabilityListParams are VulnerabilityList's query parameters. Zero values are not sent.
+type VulnerabilityListParams struct {
+	AssetID        string
+	FirstSeenAfter string
+	KEV            string
+	Limit          string
+	MinCVSS        string
+	Page           string
+	Q              string
+	Severity       string
+	SortBy         string
+	SortDir        string
+	Source         string
+	Status         string
+	Tier           string
+}
+
+// VulnerabilityListConnectors calls GET /api/v1/vulnerability-connectors: The supported integrations.
+// It needs the vulnerabilities:read permission.
+func (c *Client) VulnerabilityListConnectors(ctx context.Context) (*VulnerabilityListConnectorsResponse, error) {
+	out := new(VulnerabilityListConnectorsResponse)
+	if err := c.do(ctx, "GET", "/api/v1/vulnerability-connectors", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnerabilityStats calls GET /api/v1/vulnerabilities/stats: Stats.
+// It needs the vulnerabilities:read permission.
+func (c *Client) VulnerabilityStats(ctx context.Context) (*VulnStats, error) {
+	out := new(VulnStats)
+	if err := c.do(ctx, "GET", "/api/v1/vulnerabilities/stats", nil, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VulnerabilityUpdateStatus calls PATCH /api/v1/vulnerabilities/{id}/status: Update status.
+// It needs the vulnerabilities:update permission.
+func (c *Client) VulnerabilityUpdateStatus(ctx context.Context, id string, body *VulnerabilityUpdateStatusRequest) (*Vulnerability, error) {
+	out := new(Vulnerability)
+	if err := c.do(ctx, "PATCH", "/api/v1/vulnerabilities/"+url.PathEscape(id)+"/status", nil, body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type AIStatusResponse struct {
+	LlmEnabled bool   `json:"llm_enabled,omitempty"`
+	Model      string `json:"model,omitempty"`
+	Source     string `json:"source,omitempty"`
+}
+
+type AddMitigationRequestSubAction struct {
+	Description string  `json:"description"`
+	DueDate     *string `json:"due_date,omitempty"`
+	Title       string  `json:"title"`
+}
+
+type AddMitigationRequest struct {
+	AssignedTo  []string                        `json:"assigned_to"`
+	AssigneeID  json.RawMessage                 `json:"assignee_id"`
+	Description string                          `json:"description"`
+	DueDate     *string                         `json:"due_date,omitempty"`
+	OwnerID     json.RawMessage                 `json:"owner_id"`
+	Priority    string                          `json:"priority"`
+	ReviewerID  json.RawMessage                 `json:"reviewer_id"`
+	SubActions  []AddMitigationRequestSubAction `json:"sub_actions"`
+	Title       string                          `json:"title"`
+}
+
+type AddTeamMemberResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type AnalyticsGetRiskTrendsResponse struct {
+	Days   int64            `json:"days,omitempty"`
+	Trends []RiskTrendPoint `json:"trends,omitempty"`
+}
+
+type AssetSchemaListSchemasResponse struct {
+	Categories []AssetCategory   `json:"categories,omitempty"`
+	Schemas    []AssetTypeSchema `json:"schemas,omitempty"`
+}
+
+type AssetTopologyGetEdgeTypesResponse struct {
+	EdgeTypes []DependencyType `json:"edge_types,omitempty"`
+}
+
+type AuditLogGetAuditLogsResponse struct {
+	Count int64         `json:"count,omitempty"`
+	Data  []AuditLogDTO `json:"data,omitempty"`
+	Limit int64         `json:"limit,omitempty"`
+	Page  int64         `json:"page,omitempty"`
+}
+
+type AuditLogGetAuditLogsByActionResponse struct {
+	Count int64         `json:"count,omitempty"`
+	Data  []AuditLogDTO `json:"data,omitempty"`
+	Limit int64         `json:"limit,omitempty"`
+	Page  int64         `json:"page,omitempty"`
+}
+
+type AuditLogGetUserAuditLogsResponse struct {
+	Count int64         `json:"count,omitempty"`
+	Data  []AuditLogDTO `json:"data,omitempty"`
+	Limit int64         `json:"limit,omitempty"`
+	Page  int64         `json:"page,omitempty"`
+}
+
+type AutoCompleteMitigationSubActionRequest struct {
+	Evidence     string `json:"evidence"`
+	ScannerJobID string `json:"scanner_job_id"`
+	SubActionID  string `json:"sub_action_id"`
+}
+
+type AutoCompleteMitigationSubActionResponse struct {
+	Status string `json:"status,omitempty"`
+}
+
+type AutomationCancelDryRunResponse struct {
+	Cancelled bool `json:"cancelled,omitempty"`
+}
+
+type AutomationListChannelCatalogueResponse struct {
+	Items []map[string]any `json:"items,omitempty"`
+}
+
+type AutomationListExecutionsResponse struct {
+	Items []ExecutionHistory `json:"items,omitempty"`
+}
+
+type AutomationListRuleExecutionsResponse struct {
+	Items []ExecutionHistory `json:"items,omitempty"`
+}
+
+type AutomationListRulesResponse struct {
+	Items []AutomationRule `json:"items,omitempty"`
+}
+
+type AutomationListSLAResponse struct {
+	Items []SLATrackerView `json:"items,omitempty"`
+}
+
+type AutomationListTemplatesResponse struct {
+	Items []map[string]any `json:"items,omitempty"`
+}
+
+type BillingGetBillingResponse struct {
+	ConfiguredProviders []string      `json:"configured_providers,omitempty"`
+	Invoices            []Invoice     `json:"invoices,omitempty"`
+	Subscription        *Subscription `json:"subscription,omitempty"`
+	TrialDays           int64         `json:"trial_days,omitempty"`
+}
+
+type BulkOperationListBulkOperationsResponse struct {
+	Count      int64           `json:"count,omitempty"`
+	Operations []BulkOperation `json:"operations,omitempty"`
+}
+
+type CTIListResponse struct {
+	Count           int64              `json:"count,omitempty"`
+	Vulnerabilities []CTIVulnerability `json:"vulnerabilities,omitempty"`
+}
+
+type CTIMatchResponse struct {
+	Message      string `json:"message,omitempty"`
+	RisksCreated int64  `json:"risks_created,omitempty"`
+}
+
+type CTIStatsResponse struct {
+	CisaKnown int64 `json:"cisa_known,omitempty"`
+	Critical  int64 `json:"critical,omitempty"`
+	CTIRisks  int64 `json:"cti_risks,omitempty"`
+	New24h    int64 `json:"new_24h,omitempty"`
+	Total     int64 `json:"total,omitempty"`
+}
+
+type CTISyncResponse struct {
+	Message              string `json:"message,omitempty"`
+	TotalVulnerabilities int64  `json:"total_vulnerabilities,omitempty"`
+}
+
+type CreateMitigationSubActionRequest struct {
+	DependsOn   *string `json:"depends_on,omitempty"`
+	Description string  `json:"description"`
+	DueDate     *string `json:"due_date,omitempty"`
+	Title       string  `json:"title"`
+}
+
+type CustomFieldApplyTemplateResponse struct {
+	Count  int64         `json:"count,omitempty"`
+	Fields []CustomField `json:"fields,omitempty"`
+}
+
+type EnhancedDashboardGetMitigationProgressResponse struct {
+	Count       int64                `json:"count,omitempty"`
+	Mitigations []MitigationProgress `json:"mitigations,omitempty"`
+}
+
+type EnhancedDashboardGetRiskTrendsResponse struct {
+	Trends []RiskTrendDataPoint `json:"trends,omitempty"`
+}
+
+type EnhancedDashboardGetTopRisksResponse struct {
+	Count    int64     `json:"count,omitempty"`
+	TopRisks []TopRisk `json:"top_risks,omitempty"`
+}
+
+type EvidenceCustodyResponse struct {
+	Items []EvidenceCustodyEvent `json:"items,omitempty"`
+}
+
+type EvidenceDownloadLinkResponse struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	URL       string     `json:"url,omitempty"`
+}
+
+type EvidenceLinkRequest struct {
+	ControlIDs []string `json:"control_ids"`
+	Note       string   `json:"note"`
+}
+
+type EvidenceMissingResponse struct {
+	Frameworks []FrameworkEvidenceCoverage `json:"frameworks,omitempty"`
+}
+
+type EvidencePackageRequest struct {
+	AuditID     string `json:"audit_id"`
+	FrameworkID string `json:"framework_id"`
+}
+
+type EvidenceReviewRequest struct {
+	Note   string `json:"note"`
+	Review string `json:"review"`
+}
+
+type EvidenceUpdateRequest struct {
+	CollectedAt  *string `json:"collected_at,omitempty"`
+	Description  *string `json:"description,omitempty"`
+	ExternalURL  *string `json:"external_url,omitempty"`
+	SourceDetail *string `json:"source_detail,omitempty"`
+	Title        *string `json:"title,omitempty"`
+	Type         *string `json:"type,omitempty"`
+	ValidUntil   *string `json:"valid_until,omitempty"`
+}
+
+type FinancialAnalyticsSetCurrencyResponse struct {
+	Currency Currency `json:"currency,omitempty"`
+}
+
+type GetGroupCategoriesResponse struct {
+	Items []CategoryTotal `json:"items,omitempty"`
+}
+
+type GetGroupComplianceResponse struct {
+	Items []FrameworkPosture `json:"items,omitempty"`
+}
+
+type GetMitigationProgrammeProjectionResponse struct {
+	Items []RiskProjection `json:"items,omitempty"`
+}
+
+type GetStatusResponse struct {
+	Components []map[string]any `json:"components,omitempty"`
+	Status     string           `json:"status,omitempty"`
+	Version    string           `json:"version,omitempty"`
+}
+
+type GovernanceEffectiveDelegatedPermissionsResponse struct {
+	DelegateID  string   `json:"delegate_id,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+type GovernanceListAuditCheckpointsResponse struct {
+	Items []AuditCheckpoint `json:"items,omitempty"`
+}
+
+type GovernanceListRequestTypesResponse struct {
+	Items []ApprovalRequestType `json:"items,omitempty"`
+}
+
+type HealthCheckResponse struct {
+	Commit   string `json:"commit,omitempty"`
+	Db       string `json:"db,omitempty"`
+	DemoMode bool   `json:"demo_mode,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Version  string `json:"version,omitempty"`
+}
+
+type IncidentCreateIncidentActionRequest struct {
+	AssignedTo  string    `json:"assigned_to"`
+	Description string    `json:"description"`
+	DueDate     time.Time `json:"due_date"`
+	Title       string    `json:"title"`
+}
+
+type IncidentLinkRiskResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type IncidentListIncidentOriginsResponse struct {
+	Items []map[string]any `json:"items,omitempty"`
+	Total int64            `json:"total,omitempty"`
+}
+
+type IncidentListIncidentsResponse struct {
+	Incidents []Incident `json:"incidents,omitempty"`
+	Limit     int64      `json:"limit,omitempty"`
+	Offset    int64      `json:"offset,omitempty"`
+	Total     int64      `json:"total,omitempty"`
+}
+
+type IncidentListOpenRegulatoryClocksResponse struct {
+	Items []ClockView `json:"items,omitempty"`
+}
+
+type IncidentListRegulatoryProfilesResponse struct {
+	Items []RegulatoryProfile `json:"items,omitempty"`
+}
+
+type IncidentListRegulatoryRegimesResponse struct {
+	Items []RegulatoryRegimeSpec `json:"items,omitempty"`
+}
+
+type IncidentUpdateIncidentActionRequest struct {
+	Status string `json:"status"`
+}
+
+type IncidentUpdateIncidentActionResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type ListAccessPoliciesResponse struct {
+	Items []AccessPolicy `json:"items,omitempty"`
+}
+
+type ListAccessPolicyAttributesResponse struct {
+	Items map[string][]AccessAttribute `json:"items,omitempty"`
+}
+
+type ListGroupEntitiesResponse struct {
+	Items []Entity `json:"items,omitempty"`
+}
+
+type ListGroupLinksResponse struct {
+	Items []OrganizationLink `json:"items,omitempty"`
+}
+
+type ListGroupRisksResponse struct {
+	Items []GroupRisk `json:"items,omitempty"`
+}
+
+type ListImportProfilesResponse struct {
+	Items []ImportMappingProfile `json:"items,omitempty"`
+}
+
+type ListImportTargetsResponse struct {
+	Items []ImportTarget `json:"items,omitempty"`
+}
+
+type ListImportsResponse struct {
+	Items []ImportBatch `json:"items,omitempty"`
+}
+
+type ListMitigationProgrammesResponse struct {
+	Items []MitigationProgramme `json:"items,omitempty"`
+}
+
+type ListMitigationsResponse struct {
+	Items []Mitigation `json:"items,omitempty"`
+	Total int64        `json:"total,omitempty"`
+}
+
+type ListPluginInstallsResponse struct {
+	Items []PluginInstall `json:"items,omitempty"`
+}
+
+type ListPluginRunsResponse struct {
+	Items []PluginRun `json:"items,omitempty"`
+}
+
+type ListPluginsResponse struct {
+	Items []PluginPackage `json:"items,omitempty"`
+}
+
+type ListProgrammeCapacitiesResponse struct {
+	Items []AssigneeCapacity `json:"items,omitempty"`
+}
+
+type ListRisksResponse struct {
+	Items []Risk `json:"items,omitempty"`
+	Total int64  `json:"total,omitempty"`
+}
+
+type ListSIEMDestinationsResponse struct {
+	Items []SIEMDestination `json:"items,omitempty"`
+}
+
+type ListWebhookDeliveriesResponse struct {
+	Items []WebhookDelivery `json:"items,omitempty"`
+}
+
+type ListWebhookEventsResponse struct {
+	Items []EventInfo `json:"items,omitempty"`
+}
+
+type ListWebhooksResponse struct {
+	Items []WebhookSubscription `json:"items,omitempty"`
+}
+
+type LogoutResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type MFAChallengeRequest struct {
+	Code              string `json:"code"`
+	DeviceFingerprint string `json:"device_fingerprint,omitempty"`
+}
+
+type MFADisableRequest struct {
+	Password string `json:"password"`
+}
+
+type MFAVerifyRequest struct {
+	Code string `json:"code"`
+}
+
+type MarketplaceAddConnectorReviewRequest struct {
+	Author  string `json:"author"`
+	Comment string `json:"comment"`
+	Rating  int64  `json:"rating"`
+}
+
+type MarketplaceAddConnectorReviewResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type MarketplaceDisableAppResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type MarketplaceEnableAppResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type MarketplaceGetAppLogsResponse struct {
+	Data   []MarketplaceLog `json:"data,omitempty"`
+	Limit  int64            `json:"limit,omitempty"`
+	Offset int64            `json:"offset,omitempty"`
+	Total  int64            `json:"total,omitempty"`
+}
+
+type MarketplaceInstallAppRequest struct {
+	AppName       string         `json:"app_name"`
+	Configuration map[string]any `json:"configuration"`
+	ConnectorID   string         `json:"connector_id"`
+}
+
+type MarketplaceListAppsResponse struct {
+	Data   []MarketplaceApp `json:"data,omitempty"`
+	Limit  int64            `json:"limit,omitempty"`
+	Offset int64            `json:"offset,omitempty"`
+	Total  int64            `json:"total,omitempty"`
+}
+
+type MarketplaceListConnectorsResponse struct {
+	Data   []Connector `json:"data,omitempty"`
+	Limit  int64       `json:"limit,omitempty"`
+	Offset int64       `json:"offset,omitempty"`
+	Total  int64       `json:"total,omitempty"`
+}
+
+type MarketplaceSearchConnectorsResponse struct {
+	Data   []Connector `json:"data,omitempty"`
+	Limit  int64       `json:"limit,omitempty"`
+	Offset int64       `json:"offset,omitempty"`
+	Query  string      `json:"query,omitempty"`
+	Total  int64       `json:"total,omitempty"`
+}
+
+type MarketplaceTriggerSyncResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type MarketplaceUninstallAppResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type MarketplaceUpdateAppRequest struct {
+	Configuration map[string]any `json:"configuration"`
+}
+
+type MarketplaceUpdateAppSyncRequest struct {
+	AutoSync     bool  `json:"auto_sync"`
+	SyncInterval int64 `json:"sync_interval"`
+}
+
+type MarketplaceUpdateAppSyncResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type NotificationDeleteNotificationResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type NotificationGetNotificationsResponse struct {
+	Data   []Notification `json:"data,omitempty"`
+	Limit  int64          `json:"limit,omitempty"`
+	Offset int64          `json:"offset,omitempty"`
+	Total  int64          `json:"total,omitempty"`
+}
+
+type NotificationGetUnreadCountResponse struct {
+	UnreadCount int64 `json:"unread_count,omitempty"`
+}
+
+type NotificationMarkAllAsReadResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type NotificationMarkAsReadResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type NotificationTestNotificationResponse struct {
+	Channel  string `json:"channel,omitempty"`
+	Message  string `json:"message,omitempty"`
+	TenantID string `json:"tenant_id,omitempty"`
+	UserID   string `json:"user_id,omitempty"`
+}
+
+type OAuth2CallbackResponseUser struct {
+	Email    string `json:"email,omitempty"`
+	FullName string `json:"fullName,omitempty"`
+	ID       string `json:"id,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+type OAuth2CallbackResponse struct {
+	AccessToken  string                     `json:"access_token,omitempty"`
+	ExpiresIn    int64                      `json:"expires_in,omitempty"`
+	Provider     string                     `json:"provider,omitempty"`
+	RefreshToken string                     `json:"refresh_token,omitempty"`
+	TokenType    string                     `json:"token_type,omitempty"`
+	User         OAuth2CallbackResponseUser `json:"user,omitempty"`
+}
+
+type OrgDeletionCancelDeletionResponse struct {
+	Canceled bool `json:"canceled,omitempty"`
+}
+
+type OrgDeletionRequestDeletionResponse struct {
+	GraceDays        int64               `json:"grace_days,omitempty"`
+	Request          *OrgDeletionRequest `json:"request,omitempty"`
+	ScheduledPurgeAt *time.Time          `json:"scheduled_purge_at,omitempty"`
+}
+
+type OrganizationMemberAcceptInvitationRequest struct {
+	FullName string `json:"full_name"`
+	Password string `json:"password"`
+	Token    string `json:"token"`
+}
+
+type OrganizationMemberCreateInvitationRequest struct {
+	BusinessRole BusinessRoleKey `json:"business_role"`
+	Email        string          `json:"email"`
+	Locale       string          `json:"locale"`
+	Role         MemberRole      `json:"role"`
+}
+
+type OrganizationMemberResendInvitationRequest struct {
+	Locale string `json:"locale"`
+}
+
+type OrganizationMemberUpdateMemberRoleRequest struct {
+	BusinessRole json.RawMessage `json:"business_role,omitempty"`
+	Role         MemberRole      `json:"role"`
+}
+
+type OrganizationMemberUpdateMemberStatusRequest struct {
+	Reason string           `json:"reason"`
+	Status MembershipStatus `json:"status"`
+}
+
+type PasswordForgotPasswordResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type PasswordResetPasswordResponse struct {
+	Message         string `json:"message,omitempty"`
+	SessionsRevoked bool   `json:"sessions_revoked,omitempty"`
+}
+
+type PatCreateResponse struct {
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	ID          string     `json:"id,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	Scopes      []string   `json:"scopes,omitempty"`
+	Token       string     `json:"token,omitempty"`
+	TokenPrefix string     `json:"token_prefix,omitempty"`
+}
+
+type PatListResponse struct {
+	Tokens []PersonalAccessToken `json:"tokens,omitempty"`
+}
+
+type PluginWebhookResponse struct {
+	Error  string          `json:"error,omitempty"`
+	Items  int64           `json:"items,omitempty"`
+	RunID  string          `json:"run_id,omitempty"`
+	Status PluginRunStatus `json:"status,omitempty"`
+}
+
+type RBACRoleAssignPermissionToRoleResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type RBACRoleDeleteRoleResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type RBACRoleRemovePermissionFromRoleResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type RBACTenantDeleteTenantResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type RBACUserAddUserToTenantResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type RBACUserChangeUserRoleResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type RBACUserRemoveUserFromTenantResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type ReorderSubActionsRequestSubAction struct {
+	ID    string `json:"id"`
+	Order int64  `json:"order"`
+}
+
+type ReorderSubActionsRequest struct {
+	SubActions []ReorderSubActionsRequestSubAction `json:"sub_actions"`
+}
+
+type ReportCatalogueResponse struct {
+	Locales []map[string]any `json:"locales,omitempty"`
+	Types   []TypeDTO        `json:"types,omitempty"`
+}
+
+type ReportCommentRequest struct {
+	Body string `json:"body"`
+}
+
+type ReportCreateRequest struct {
+	AuditID     string   `json:"audit_id"`
+	Format      string   `json:"format"`
+	FrameworkID string   `json:"framework_id"`
+	From        string   `json:"from"`
+	Locale      string   `json:"locale"`
+	Recipients  []string `json:"recipients"`
+	Supersedes  string   `json:"supersedes"`
+	To          string   `json:"to"`
+	Type        string   `json:"type"`
+}
+
+type ReportJobListResponse struct {
+	Data []ReportJob `json:"data,omitempty"`
+}
+
+type ReportListResponse struct {
+	Items []DomainReport `json:"items,omitempty"`
+	Total int64          `json:"total,omitempty"`
+}
+
+type ReportTransitionRequest struct {
+	Comment string `json:"comment"`
+	To      string `json:"to"`
+}
+
+type ReportVersionsResponse struct {
+	Versions []DomainReport `json:"versions,omitempty"`
+}
+
+type RiskTaxonomyListCategoriesResponse struct {
+	Items []RiskCategory `json:"items,omitempty"`
+	Total int64          `json:"total,omitempty"`
+}
+
+type RiskTaxonomyListRiskMappingsResponse struct {
+	Items []RiskControlMapping `json:"items,omitempty"`
+	Total int64                `json:"total,omitempty"`
+}
+
+type RiskTaxonomyListUnmappedRisksResponse struct {
+	Items []UnmappedRisk `json:"items,omitempty"`
+	Total int64          `json:"total,omitempty"`
+}
+
+type RiskTimelineGetChangesByTypeResponse struct {
+	ChangeType string        `json:"change_type,omitempty"`
+	Changes    []RiskHistory `json:"changes,omitempty"`
+	Count      int64         `json:"count,omitempty"`
+}
+
+type RiskTimelineGetChangesSinceResponse struct {
+	Changes []RiskHistory `json:"changes,omitempty"`
+	Count   int64         `json:"count,omitempty"`
+	Since   int64         `json:"since,omitempty"`
+}
+
+type RiskTimelineGetRiskTimelineResponse struct {
+	Count    int64         `json:"count,omitempty"`
+	Limit    int64         `json:"limit,omitempty"`
+	Offset   int64         `json:"offset,omitempty"`
+	Timeline []RiskHistory `json:"timeline,omitempty"`
+	Total    int64         `json:"total,omitempty"`
+}
+
+type RiskTimelineGetScoreChangesResponse struct {
+	Changes []RiskHistory `json:"changes,omitempty"`
+	Count   int64         `json:"count,omitempty"`
+}
+
+type RiskTimelineGetStatusChangesResponse struct {
+	Changes []RiskHistory `json:"changes,omitempty"`
+	Count   int64         `json:"count,omitempty"`
+}
+
+type SamL2ACSResponseUser struct {
+	Email    string `json:"email,omitempty"`
+	FullName string `json:"fullName,omitempty"`
+	ID       string `json:"id,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+type SamL2ACSResponse struct {
+	AccessToken  string               `json:"access_token,omitempty"`
+	ExpiresIn    int64                `json:"expires_in,omitempty"`
+	Provider     string               `json:"provider,omitempty"`
+	RefreshToken string               `json:"refresh_token,omitempty"`
+	TokenType    string               `json:"token_type,omitempty"`
+	User         SamL2ACSResponseUser `json:"user,omitempty"`
+}
+
+type SamL2InitiateLoginResponse struct {
+	RedirectURL string `json:"redirect_url,omitempty"`
+	RequestID   string `json:"request_id,omitempty"`
+}
+
+type ScannerAgentHeartbeatResponse struct {
+	Ok bool `json:"ok,omitempty"`
+}
+
+type ScannerIssueRegistrationTokenResponseDownloads struct {
+	Docker  string `json:"docker,omitempty"`
+	Linux   string `json:"linux,omitempty"`
+	Macos   string `json:"macos,omitempty"`
+	Windows string `json:"windows,omitempty"`
+}
+
+type ScannerIssueRegistrationTokenResponse struct {
+	ConfigID          string                                         `json:"config_id,omitempty"`
+	Downloads         ScannerIssueRegistrationTokenResponseDownloads `json:"downloads,omitempty"`
+	ExpiresAt         *time.Time                                     `json:"expires_at,omitempty"`
+	RegistrationToken string                                         `json:"registration_token,omitempty"`
+}
+
+type ScoreEngineClassifyRiskResponse struct {
+	ConfigID  string           `json:"config_id,omitempty"`
+	Matrix    map[string]int64 `json:"matrix,omitempty"`
+	RiskLevel string           `json:"risk_level,omitempty"`
+	Score     float64          `json:"score,omitempty"`
+}
+
+type ScoreEngineComputeRiskScoreResponse struct {
+	AssetCount  int64   `json:"asset_count,omitempty"`
+	BaseScore   float64 `json:"base_score,omitempty"`
+	ConfigID    string  `json:"config_id,omitempty"`
+	FinalScore  float64 `json:"final_score,omitempty"`
+	Impact      int64   `json:"impact,omitempty"`
+	Probability int64   `json:"probability,omitempty"`
+	RiskLevel   string  `json:"risk_level,omitempty"`
+}
+
+type ScoreEngineGetRiskMatrixResponse struct {
+	ConfigID    string             `json:"config_id,omitempty"`
+	Criticality map[string]float64 `json:"criticality,omitempty"`
+	Formula     string             `json:"formula,omitempty"`
+	Matrix      map[string]int64   `json:"matrix,omitempty"`
+	Weighting   map[string]float64 `json:"weighting,omitempty"`
+}
+
+type ScoreEngineGetScoringConfigsResponse struct {
+	Default *ScoringConfig `json:"default,omitempty"`
+	Message string         `json:"message,omitempty"`
+}
+
+type ScoreEngineGetScoringMetricsResponse struct {
+	AvgScore   float64          `json:"avg_score,omitempty"`
+	Formula    string           `json:"formula,omitempty"`
+	MaxScore   float64          `json:"max_score,omitempty"`
+	RiskStats  []RiskStats      `json:"risk_stats,omitempty"`
+	Thresholds map[string]int64 `json:"thresholds,omitempty"`
+}
+
+type SessionListSessionsResponse struct {
+	Sessions []SessionRecord `json:"sessions,omitempty"`
+	Total    int64           `json:"total,omitempty"`
+}
+
+type SessionRevokeOtherSessionsResponse struct {
+	Revoked int64 `json:"revoked,omitempty"`
+}
+
+type SwitchListOrganizationsResponse struct {
+	Organizations []MembershipSummary `json:"organizations,omitempty"`
+	Total         int64               `json:"total,omitempty"`
+}
+
+type TakeRegisterSnapshotRequest struct {
+	Label string `json:"label"`
+}
+
+type TelemetryGetTelemetryResponse struct {
+	Consent      bool       `json:"consent,omitempty"`
+	Enabled      bool       `json:"enabled,omitempty"`
+	EnvForcedOff bool       `json:"env_forced_off,omitempty"`
+	InstanceID   string     `json:"instance_id,omitempty"`
+	LastSentAt   *time.Time `json:"last_sent_at,omitempty"`
+	SchemaURL    string     `json:"schema_url,omitempty"`
+	Version      string     `json:"version,omitempty"`
+}
+
+type TelemetrySetTelemetryResponse struct {
+	Consent      bool   `json:"consent,omitempty"`
+	Enabled      bool   `json:"enabled,omitempty"`
+	EnvForcedOff bool   `json:"env_forced_off,omitempty"`
+	InstanceID   string `json:"instance_id,omitempty"`
+}
+
+type TheHiveGetIncidentLinkResponse struct {
+	Link        *TheHiveCaseLink    `json:"link,omitempty"`
+	Observables []TheHiveObservable `json:"observables,omitempty"`
+}
+
+type TokenCreateTokenResponseToken struct {
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	ID          string     `json:"id,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	TokenPrefix string     `json:"token_prefix,omitempty"`
+	Type        TokenType  `json:"type,omitempty"`
+	Value       string     `json:"value,omitempty"`
+}
+
+type TokenCreateTokenResponse struct {
+	Token TokenCreateTokenResponseToken `json:"token,omitempty"`
+}
+
+type TokenGetTokenResponseToken struct {
+	CreatedAt   *time.Time  `json:"created_at,omitempty"`
+	Description string      `json:"description,omitempty"`
+	ExpiresAt   *time.Time  `json:"expires_at,omitempty"`
+	ID          string      `json:"id,omitempty"`
+	IPWhitelist []string    `json:"ip_whitelist,omitempty"`
+	LastUsedAt  *time.Time  `json:"last_used_at,omitempty"`
+	Name        string      `json:"name,omitempty"`
+	Permissions []string    `json:"permissions,omitempty"`
+	Scopes      []string    `json:"scopes,omitempty"`
+	Status      TokenStatus `json:"status,omitempty"`
+	TokenPrefix string      `json:"token_prefix,omitempty"`
+	Type        TokenType   `json:"type,omitempty"`
+	UpdatedAt   *time.Time  `json:"updated_at,omitempty"`
+}
+
+type TokenGetTokenResponse struct {
+	Token TokenGetTokenResponseToken `json:"token,omitempty"`
+}
+
+type TokenListTokensResponse struct {
+	Count  int64            `json:"count,omitempty"`
+	Tokens []map[string]any `json:"tokens,omitempty"`
+}
+
+type TokenRevokeTokenResponseToken struct {
+	ID           string      `json:"id,omitempty"`
+	RevokeReason string      `json:"revoke_reason,omitempty"`
+	RevokedAt    *time.Time  `json:"revoked_at,omitempty"`
+	Status       TokenStatus `json:"status,omitempty"`
+}
+
+type TokenRevokeTokenResponse struct {
+	Token TokenRevokeTokenResponseToken `json:"token,omitempty"`
+}
+
+type TokenRotateTokenResponse struct {
+	NewToken  *TokenWithValue `json:"new_token,omitempty"`
+	OldToken  *TokenResponse  `json:"old_token,omitempty"`
+	RotatedAt *time.Time      `json:"rotated_at,omitempty"`
+}
+
+type TokenUpdateTokenResponseToken struct {
+	CreatedAt   *time.Time  `json:"created_at,omitempty"`
+	Description string      `json:"description,omitempty"`
+	ExpiresAt   *time.Time  `json:"expires_at,omitempty"`
+	ID          string      `json:"id,omitempty"`
+	Name        string      `json:"name,omitempty"`
+	Status      TokenStatus `json:"status,omitempty"`
+	TokenPrefix string      `json:"token_prefix,omitempty"`
+	Type        TokenType   `json:"type,omitempty"`
+	UpdatedAt   *time.Time  `json:"updated_at,omitempty"`
+}
+
+type TokenUpdateTokenResponse struct {
+	Token TokenUpdateTokenResponseToken `json:"token,omitempty"`
+}
+
+type UpdateMitigationRequest struct {
+	AssignedTo  []string        `json:"assigned_to"`
+	AssigneeID  json.RawMessage `json:"assignee_id"`
+	Description *string         `json:"description,omitempty"`
+	DueDate     *string         `json:"due_date,omitempty"`
+	OwnerID     json.RawMessage `json:"owner_id"`
+	Priority    *string         `json:"priority,omitempty"`
+	ReviewerID  json.RawMessage `json:"reviewer_id"`
+	Status      *string         `json:"status,omitempty"`
+	Title       *string         `json:"title,omitempty"`
+}
+
+type UpdateSubActionRequest struct {
+	DependsOn   *string `json:"depends_on,omitempty"`
+	Description *string `json:"description,omitempty"`
+	DueDate     *string `json:"due_date,omitempty"`
+	Title       *string `json:"title,omitempty"`
+}
+
+type UpdateUserRoleResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type UpdateUserStatusResponse struct {
+	Message string `json:"message,omitempty"`
+}
+
+type VulnCorrelationGetCandidatesResponse struct {
+	Candidates []AssetCandidate `json:"candidates,omitempty"`
+}
+
+type VulnCorrelationListUnassignedResponse struct {
+	Items []Vulnerability `json:"items,omitempty"`
+	Limit int64           `json:"limit,omitempty"`
+	Page  int64           `json:"page,omitempty"`
+	Total int64           `json:"total,omitempty"`
+}
+
+type VulnIntegrationListIntegrationsResponse struct {
+	Items []VulnIntegration `json:"items,omitempty"`
+}
+
+type VulnRiskRuleListDraftsResponse struct {
+	Items []Risk `json:"items,omitempty"`
+	Limit int64  `json:"limit,omitempty"`
+	Page  int64  `json:"page,omitempty"`
+	Total int64  `json:"total,omitempty"`
+}
+
+type VulnWebhookIngestResponse struct {
+	Accepted bool       `json:"accepted,omitempty"`
+	Created  int64      `json:"created,omitempty"`
+	Received int64      `json:"received,omitempty"`
+	Skipped  int64      `json:"skipped,omitempty"`
+	Source   VulnSource `json:"source,omitempty"`
+	Updated  int64      `json:"updated,omitempty"`
+}
+
+type VulnerabilityListResponse struct {
+	Items []Vulnerability `json:"items,omitempty"`
+	Limit int64           `json:"limit,omitempty"`
+	Page  int64           `json:"page,omitempty"`
+	Total int64           `json:"total,omitempty"`
+}
+
+type VulnerabilityListConnectorsResponse struct {
+	Connectors []ConnectorInfo `json:"connectors,omitempty"`
+}
+
+type VulnerabilityUpdateStatusRequest struct {
+	Status string `json:"status"`
+}
+
+// AIProviderSetting is an organisation's choice of LLM backend for the AI
+// assistant and the board report. Without a row the organisation uses the
+// server default (AI_PROVIDER, or Claude when ANTHROPIC_API_KEY is set).
+// The setting exists for regulated customers that may not send risk data
+// to an external API: they point OpenRisk at a model they host (vLLM,
+// llama.cpp server, Ollama) or switch the LLM off entirely with the
+// "template" provider. Whatever the provider, replies are validated
+// against the same schemas and a malformed one falls back to the
+// deterministic template.
+type AIProviderSetting struct {
+	// BaseURL is the OpenAI-compatible API root including /v1; unused for the
+	// other providers.
+	BaseURL   string    `json:"base_url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// Computed, NOT persisted.
+	HasAPIKey bool   `json:"has_api_key"`
+	ID        string `json:"id"`
+	// MaxTokens caps the reply length (0 = provider default).
+	MaxTokens int64  `json:"max_tokens"`
+	Model     string `json:"model,omitempty"`
+	// OutputMode is json_schema|json_object|prompt: how the server is asked
+	// for JSON. Empty means json_schema.
+	OutputMode string `json:"output_mode,omitempty"`
+	// Provider is template|anthropic|openai_compatible (pkg/ai.Provider).
+	Provider string `json:"provider"`
+	TenantID string `json:"tenant_id"`
+	// TimeoutSeconds bounds one completion (0 = provider default).
+	TimeoutSeconds int64     `json:"timeout_seconds"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	UpdatedBy      string    `json:"updated_by,omitempty"`
+}
+
+// AcceptResult reports which membership was created.
+type AcceptResult struct {
+	// CreatedAccount tells the caller whether it must sign in for the first
+	// time.
+	CreatedAccount   bool       `json:"created_account"`
+	Email            string     `json:"email"`
+	OrganizationID   string     `json:"organization_id"`
+	OrganizationName string     `json:"organization_name,omitempty"`
+	Role             MemberRole `json:"role"`
+	UserID           string     `json:"user_id"`
+}
+
+// AccessAttribute describes one record attribute a condition may test.
+type AccessAttribute struct {
+	Description string `json:"description"`
+	List        bool   `json:"list"`
+	Name        string `json:"name"`
+}
+
+// AccessCondition is a condition over a record. Exactly one form is set:
+// All (every child holds), Any (one child holds), Not, or a predicate
+// Attr/Op/Values.
+type AccessCondition struct {
+	All []AccessCondition `json:"all,omitempty"`
+	Any []AccessCondition `json:"any,omitempty"`
+	// Attr is a record attribute from AccessAttributes, or "user.<name>".
+	Attr string           `json:"attr,omitempty"`
+	Not  *AccessCondition `json:"not,omitempty"`
+	Op   AccessOp         `json:"op,omitempty"`
+	// Values are literals or "$user.<name>" references.
+	Values []string `json:"values,omitempty"`
+}
+
+// AccessDecision explains whether a user sees a record.
+type AccessDecision struct {
+	// Policies holds the verdict of every policy that applies to the user;
+	// policies for other users or other resources are not listed.
+	Policies []AccessPolicyVerdict `json:"policies"`
+	Reason   string                `json:"reason"`
+	Visible  bool                  `json:"visible"`
+}
+
+// AccessEffect is what a policy does to the records its condition matches.
+type AccessEffect string
+
+const (
+	AccessEffectAllow AccessEffect = "allow"
+	AccessEffectDeny  AccessEffect = "deny"
+)
+
+// AccessOp is a predicate operator.
+type AccessOp string
+
+const (
+	AccessOpEmpty AccessOp = "empty"
+	AccessOpIn    AccessOp = "in"
+)
+
+// AccessPolicy is one tenant rule.
+type AccessPolicy struct {
+	// Condition selects the records; nil matches every record of the resource.
+	Condition   json.RawMessage `json:"condition,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	CreatedBy   string          `json:"created_by,omitempty"`
+	Description string          `json:"description"`
+	Effect      AccessEffect    `json:"effect"`
+	Enabled     bool            `json:"enabled"`
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Resource    AccessResource  `json:"resource"`
+	Subjects    AccessSubjects  `json:"subjects"`
+	TenantID    string          `json:"tenant_id"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// AccessPolicyVerdict is one policy's part in a decision.
+type AccessPolicyVerdict struct {
+	Effect AccessEffect `json:"effect"`
+	// Matched is whether the condition held for the record.
+	Matched  bool   `json:"matched"`
+	Name     string `json:"name"`
+	PolicyID string `json:"policy_id"`
+	// Trace lists the predicates evaluated, in order.
+	Trace []string `json:"trace,omitempty"`
+}
+
+// AccessRecord is a record's attribute values, keyed by attribute name.
+// The repository fills it with the same values its SQL compares against.
+type AccessRecord map[string][]string
+
+// AccessResource names a record type policies can govern.
+type AccessResource string
+
+const (
+	AccessResourceEvidence AccessResource = "evidence"
+	AccessResourceRisk     AccessResource = "risk"
+)
+
+// AccessSubject is the user a decision is made for.
+type AccessSubject struct {
+	Attributes   map[string][]string `json:"attributes,omitempty"`
+	BusinessRole BusinessRoleKey     `json:"business_role,omitempty"`
+	Department   string              `json:"department,omitempty"`
+	Role         MemberRole          `json:"role"`
+	UserID       string              `json:"user_id"`
+}
+
+// AccessSubjects selects the users a policy applies to. Every non-empty
+// field must match (AND); within a field any entry matches (OR). The zero
+// value applies to every user.
+type AccessSubjects struct {
+	// Attributes requires, per key, that the user carries one of the values.
+	Attributes    map[string][]string `json:"attributes,omitempty"`
+	BusinessRoles []BusinessRoleKey   `json:"business_roles,omitempty"`
+	Roles         []MemberRole        `json:"roles,omitempty"`
+	Users         []string            `json:"users,omitempty"`
+}
+
+// Action represents an action that can be performed on a resource
+type Action string
+
+const (
+	ActionAssign Action = "assign"
+	ActionDelete Action = "delete"
+	ActionExport Action = "export"
+	ActionManage Action = "manage"
+	ActionRead   Action = "read"
+	ActionWrite  Action = "write"
+)
+
+// ActionInput is the body of POST /mitigation-programmes/:id/actions and
+// PUT /programme-actions/:id. DependsOn replaces the action's
+// dependencies.
+type ActionInput struct {
+	AssigneeID *string  `json:"assignee_id,omitempty"`
+	Completed  bool     `json:"completed"`
+	DependsOn  []string `json:"depends_on"`
+	EffortDays float64  `json:"effort_days"`
+	Order      int64    `json:"order"`
+	Title      string   `json:"title"`
+}
+
+// AddUserToTenantRequest defines request body for adding user to tenant
+type AddUserToTenantRequest struct {
+	RoleID string `json:"role_id"`
+	UserID string `json:"user_id"`
+}
+
+// AgentStatus is the connectivity/activity state of a registered Agent.
+type AgentStatus string
+
+const (
+	AgentStatusError    AgentStatus = "error"
+	AgentStatusOffline  AgentStatus = "offline"
+	AgentStatusOnline   AgentStatus = "online"
+	AgentStatusRevoked  AgentStatus = "revoked"
+	AgentStatusScanning AgentStatus = "scanning"
+)
+
+// AIEmergingRisksResult is emergingRisksResult wraps the assistant output
+// with its provider.
+type AIEmergingRisksResult struct {
+	GeneratedBy string                 `json:"generated_by"`
+	Result      AIEmergingRisksResult2 `json:"result"`
+}
+
+// AIEmergingRisksResult2 is emergingRisksResult carries the detected risks
+// plus a one-line summary.
+type AIEmergingRisksResult2 struct {
+	Risks   []EmergingRisk `json:"risks"`
+	Summary string         `json:"summary"`
+}
+
+// Amount is a currency-aware monetary value: the canonical XAF store, its
+// USD convenience conversion, and the tenant's chosen display currency +
+// value.
+type Amount struct {
+	// tenant display currency
+	Currency Currency `json:"currency"`
+	Usd      float64  `json:"usd"`
+	// amount in Currency
+	Value float64 `json:"value"`
+	Xaf   float64 `json:"xaf"`
+}
+
+// AnalysisInput is the body of PUT /bia/processes/:assetId.
+type AnalysisInput struct {
+	ImpactCurve ImpactCurve `json:"impact_curve"`
+	MtpdHours   float64     `json:"mtpd_hours"`
+	Notes       string      `json:"notes"`
+	Owner       string      `json:"owner"`
+	RpoHours    float64     `json:"rpo_hours"`
+	RtoHours    float64     `json:"rto_hours"`
+}
+
+// AnswersInput is the body of POST /vendor-portal/answers. Submit false
+// saves a draft the vendor can come back to; true hands the answers in,
+// after which the link is closed.
+type AnswersInput struct {
+	Answers VendorAnswers `json:"answers"`
+	Submit  bool          `json:"submit"`
+	Token   string        `json:"token"`
+}
+
+// AppetiteBreach is one tolerance exceeded.
+type AppetiteBreach struct {
+	Limit  float64        `json:"limit"`
+	Metric AppetiteMetric `json:"metric"`
+	Value  float64        `json:"value"`
+}
+
+// AppetiteException is the resolved exception standing of one risk.
+type AppetiteException struct {
+	RequestID  string                  `json:"request_id,omitempty"`
+	Status     AppetiteExceptionStatus `json:"status"`
+	ValidUntil *time.Time              `json:"valid_until,omitempty"`
+}
+
+// AppetiteExceptionStatus is where a risk stands on its exception.
+type AppetiteExceptionStatus string
+
+const (
+	AppetiteExceptionStatusApproved AppetiteExceptionStatus = "approved"
+	AppetiteExceptionStatusExpired  AppetiteExceptionStatus = "expired"
+	AppetiteExceptionStatusNone     AppetiteExceptionStatus = "none"
+	AppetiteExceptionStatusPending  AppetiteExceptionStatus = "pending"
+)
+
+// AppetiteHeadline is the appetite status shown next to the KRIs: the
+// summary counts, and which scopes are breached, by name.
+type AppetiteHeadline struct {
+	BreachedScopes    []string `json:"breached_scopes"`
+	PendingExceptions int64    `json:"pending_exceptions"`
+	RisksAbove        int64    `json:"risks_above"`
+	ScopesBreached    int64    `json:"scopes_breached"`
+	StatementsInForce int64    `json:"statements_in_force"`
+	// WithoutException are risks above appetite with no valid exception: the
+	// number that should be zero.
+	WithoutException int64 `json:"without_exception"`
+}
+
+// AppetiteMetric names what a tolerance bounds.
+type AppetiteMetric string
+
+const (
+	AppetiteMetricAleP90Xaf     AppetiteMetric = "ale_p90_xaf"
+	AppetiteMetricCriticalCount AppetiteMetric = "critical_count"
+	AppetiteMetricScore         AppetiteMetric = "score"
+	AppetiteMetricSmartScore    AppetiteMetric = "smart_score"
+)
+
+// AppetiteScope says what a statement covers.
+type AppetiteScope string
+
+const (
+	AppetiteScopeBusinessUnit AppetiteScope = "business_unit"
+	AppetiteScopeCategory     AppetiteScope = "category"
+)
+
+// ApplyInput is the body of POST /imports/:id/apply. Rows with errors
+// block the import unless SkipInvalid is set, in which case they are left
+// out.
+type ApplyInput struct {
+	Mapping     ImportMapping `json:"mapping"`
+	ProfileID   *string       `json:"profile_id,omitempty"`
+	SkipInvalid bool          `json:"skip_invalid"`
+}
+
+// ApprovalDecision is one approver's sign-off on a step, embedded in the
+// request.
+type ApprovalDecision struct {
+	ApproverEmail string    `json:"approver_email,omitempty"`
+	ApproverID    string    `json:"approver_id"`
+	Comment       string    `json:"comment,omitempty"`
+	DecidedAt     time.Time `json:"decided_at"`
+	// "approve" | "reject"
+	Decision  string `json:"decision"`
+	StepOrder int64  `json:"step_order"`
+}
+
+// ApprovalDecisionList is a jsonb array of decisions.
+type ApprovalDecisionList []ApprovalDecision
+
+// ApprovalDetail is a request plus everything the UI needs to render it
+// without re-deriving the rules: per-step progress, what the caller may
+// do, and why.
+type ApprovalDetail struct {
+	// CanDecide and Verdict answer "why is this button disabled?" before the
+	// user clicks it and gets a 403.
+	CanDecide       bool                 `json:"can_decide"`
+	Expired         bool                 `json:"expired"`
+	OpenSteps       []WorkflowStep       `json:"open_steps"`
+	Progress        []StepProgress       `json:"progress"`
+	Request         json.RawMessage      `json:"request,omitempty"`
+	RequestTypeInfo *ApprovalRequestType `json:"request_type_info,omitempty"`
+	Verdict         EligibilityVerdict   `json:"verdict"`
+}
+
+// ApprovalRequest is a live run of an approval workflow — the state
+// machine. It snapshots the workflow's steps at submit time so later edits
+// to the workflow never rewrite in-flight requests.
+type ApprovalRequest struct {
+	Action      string               `json:"action,omitempty"`
+	CreatedAt   time.Time            `json:"created_at"`
+	CurrentStep int64                `json:"current_step"`
+	Decisions   ApprovalDecisionList `json:"decisions"`
+	Description string               `json:"description,omitempty"`
+	EntityID    string               `json:"entity_id,omitempty"`
+	EntityType  string               `json:"entity_type"`
+	ExpiresAt   *time.Time           `json:"expires_at,omitempty"`
+	ID          string               `json:"id"`
+	// Mode and ExpiresAt are snapshotted from the workflow at submit time,
+	// like Steps: editing the workflow must never move an in-flight request's
+	// goalposts.
+	Mode             string           `json:"mode"`
+	Payload          JSONMap          `json:"payload,omitempty"`
+	RequestType      string           `json:"request_type,omitempty"`
+	RequestedBy      string           `json:"requested_by"`
+	RequestedByEmail string           `json:"requested_by_email,omitempty"`
+	ResolvedAt       *time.Time       `json:"resolved_at,omitempty"`
+	Status           ApprovalStatus   `json:"status"`
+	Steps            WorkflowStepList `json:"steps"`
+	TenantID         string           `json:"tenant_id"`
+	Title            string           `json:"title"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+	WorkflowID       string           `json:"workflow_id,omitempty"`
+	WorkflowName     string           `json:"workflow_name,omitempty"`
+}
+
+// ApprovalRequestType is a kind of decision the organisation routes for
+// sign-off. Shipping a catalogue rather than a free-text field is what
+// makes "types de demandes" a shared vocabulary instead of five spellings
+// of the same thing.
+type ApprovalRequestType struct {
+	Action      string `json:"action"`
+	Description string `json:"description"`
+	EntityType  string `json:"entity_type"`
+	Key         string `json:"key"`
+	Label       string `json:"label"`
+	LabelEn     string `json:"label_en"`
+	// LinkedToLifecycle names the product behaviour that depends on this type,
+	// so an admin can see that deleting the workflow will block something
+	// concrete.
+	LinkedToLifecycle string `json:"linked_to_lifecycle,omitempty"`
+}
+
+// ApprovalStatus is the state of an approval request.
+type ApprovalStatus string
+
+const (
+	ApprovalStatusApproved  ApprovalStatus = "approved"
+	ApprovalStatusCancelled ApprovalStatus = "cancelled"
+	ApprovalStatusExpired   ApprovalStatus = "expired"
+	ApprovalStatusPending   ApprovalStatus = "pending"
+	ApprovalStatusRejected  ApprovalStatus = "rejected"
+)
+
+// ApprovalWorkflow binds an (EntityType, Action) pair to an ordered
+// approval chain. One workflow per (tenant, entity_type, action) is
+// enforced by the application layer. Soft-deletable so history keeps
+// referencing it.
+type ApprovalWorkflow struct {
+	Action      string    `json:"action"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   string    `json:"created_by"`
+	Description string    `json:"description,omitempty"`
+	Enabled     bool      `json:"enabled"`
+	EntityType  string    `json:"entity_type"`
+	// ExpiresInHours closes a request nobody decided in time. 0 = never
+	// expires.
+	ExpiresInHours int64  `json:"expires_in_hours"`
+	ID             string `json:"id"`
+	// Mode is sequential (default) or parallel.
+	Mode string `json:"mode"`
+	Name string `json:"name"`
+	// RequestType is the catalogue key this workflow serves (see
+	// ApprovalRequestTypes). Kept alongside EntityType/Action rather than
+	// instead of them: those two are what SubmitApprovalRequest matches on.
+	RequestType string           `json:"request_type,omitempty"`
+	Steps       WorkflowStepList `json:"steps"`
+	TenantID    string           `json:"tenant_id"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// Assessment is the full verdict on a candidate password.
+type Assessment struct {
+	// Advisory lists non-blocking improvements. Present even when OK.
+	Advisory []Reason `json:"advisory"`
+	// Blocking lists the reasons the password was refused. Empty when OK.
+	Blocking []Reason `json:"blocking"`
+	// BreachCheckSkipped is true when the corpus could not be consulted. It
+	// distinguishes "checked and clean" from "could not check" — see Assess
+	// for why that difference is preserved rather than collapsed.
+	BreachCheckSkipped bool `json:"breach_check_skipped"`
+	// BreachCount is how many times it appears there.
+	BreachCount int64 `json:"breach_count"`
+	// Breached reports presence in the HaveIBeenPwned corpus.
+	Breached bool `json:"breached"`
+	// Length in runes, not bytes.
+	Length int64 `json:"length"`
+	// MinScore is echoed so the meter can draw the pass threshold.
+	MinScore int64 `json:"min_score"`
+	// OK is the only field that decides acceptance.
+	Ok bool `json:"ok"`
+	// Score is the zxcvbn strength score, 0..4. Drives the strength meter.
+	Score int64 `json:"score"`
+}
+
+// AssessmentView is an assessment as the tenant sees it, with its
+// effective state.
+type AssessmentView struct {
+	Answers   VendorAnswers `json:"answers"`
+	CreatedAt time.Time     `json:"created_at"`
+	ExpiresAt time.Time     `json:"expires_at"`
+	ID        string        `json:"id"`
+	PassScore float64       `json:"pass_score"`
+	// ProposedRiskID is the draft risk a failing score proposed.
+	ProposedRiskID string          `json:"proposed_risk_id,omitempty"`
+	Questions      VendorQuestions `json:"questions"`
+	// ResponseEvidenceID is the evidence record the submitted answers became.
+	ResponseEvidenceID string                 `json:"response_evidence_id,omitempty"`
+	Score              *float64               `json:"score,omitempty"`
+	SentBy             string                 `json:"sent_by,omitempty"`
+	SentTo             string                 `json:"sent_to"`
+	State              VendorAssessmentStatus `json:"state"`
+	Status             VendorAssessmentStatus `json:"status"`
+	SubmittedAt        *time.Time             `json:"submitted_at,omitempty"`
+	TemplateID         string                 `json:"template_id"`
+	TemplateKind       VendorTemplateKind     `json:"template_kind"`
+	TemplateName       string                 `json:"template_name"`
+	TenantID           string                 `json:"tenant_id"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+	VendorID           string                 `json:"vendor_id"`
+}
+
+type Asset struct {
+	// Attributes holds the typed, schema-validated attribute values. Every
+	// write path runs ValidateAttributes against the tenant's schema first, so
+	// an out-of-schema key or a malformed value never reaches this column.
+	Attributes AssetAttributes `json:"attributes"`
+	// BusinessUnit is the part of the organisation the asset belongs to. Free
+	// text, compared case-insensitively, like Risk.BusinessUnit; access
+	// policies use it to show a BU's managers the risks on its assets.
+	BusinessUnit string `json:"business_unit"`
+	// Category selects which attribute schema governs this asset (see
+	// AssetTypeSchema). Type stays as the free-text display label — category
+	// is the closed vocabulary the form generator and the validator key off.
+	// Empty on rows written before typed attributes existed: those assets
+	// simply have no typed attributes until someone assigns them a category.
+	Category        AssetCategory `json:"category"`
+	CloudResourceID string        `json:"cloud_resource_id"`
+	// CPEs (Common Platform Enumeration) identify the software/hardware
+	// running on this asset. Populated by the Infrastructure Scanner on import
+	// (and editable manually); CTI matching intersects these against
+	// cti_vulnerabilities.affected_cpe to auto-create risks for exposed CVEs.
+	Cpes        []string         `json:"cpes"`
+	CreatedAt   time.Time        `json:"created_at"`
+	Criticality AssetCriticality `json:"criticality"`
+	ExternalID  string           `json:"external_id"`
+	// --- correlation fingerprints ------------------------------------------
+	// Denormalised out of Attributes on every write (see RefreshFingerprints).
+	// They exist as their own indexed columns because the
+	// vulnerability↔asset correlator matches on them for every ingested
+	// finding: querying a JSONB bag per finding would make a 10k-finding
+	// import quadratic.
+	Hostnames      []string `json:"hostnames"`
+	ID             string   `json:"id"`
+	IPAddresses    []string `json:"ip_addresses"`
+	Name           string   `json:"name"`
+	OrganizationID string   `json:"organization_id"`
+	Owner          string   `json:"owner"`
+	// Relation Many-to-Many avec Risk
+	Risks []Risk `json:"risks,omitempty"`
+	// MANUAL ou OPENASSET
+	Source   string `json:"source"`
+	TenantID string `json:"tenant_id"`
+	// Server, Laptop, Database, SaaS
+	Type      string    `json:"type"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AssetAttributes is the JSONB bag of an asset's typed attribute values.
+// Keys are AttributeDef.Key; values are already coerced to their declared
+// type by ValidateAttributes before they ever reach the database.
+type AssetAttributes map[string]any
+
+// AssetCandidate enriches a stored match candidate with the asset's
+// current name and criticality, so the resolution screen shows what the
+// user is choosing between rather than a list of UUIDs.
+type AssetCandidate struct {
+	AssetID     string   `json:"asset_id"`
+	AssetName   string   `json:"asset_name"`
+	Confidence  float64  `json:"confidence"`
+	Criticality string   `json:"criticality"`
+	Methods     []string `json:"methods"`
+	Reason      string   `json:"reason"`
+}
+
+// AssetCategory is the typed category an asset belongs to. Unlike the
+// free-text Asset.Type (kept for display and for the long tail of things
+// that do not fit), the category is a CLOSED vocabulary: it selects which
+// attribute schema governs the asset, so a Database is never asked for its
+// "assigned user" and a Vendor is never asked for its "firmware version".
+type AssetCategory string
+
+const (
+	AssetCategoryApplication     AssetCategory = "application"
+	AssetCategoryBusinessProcess AssetCategory = "business_process"
+	AssetCategoryCloud           AssetCategory = "cloud"
+	AssetCategoryDataProcessing  AssetCategory = "data_processing"
+	AssetCategoryDatabase        AssetCategory = "database"
+	AssetCategoryNetwork         AssetCategory = "network"
+	AssetCategoryServer          AssetCategory = "server"
+	AssetCategoryVendor          AssetCategory = "vendor"
+	AssetCategoryWorkstation     AssetCategory = "workstation"
+)
+
+type AssetCriticality string
+
+const (
+	AssetCriticalityCRITICAL AssetCriticality = "CRITICAL"
+	AssetCriticalityHIGH     AssetCriticality = "HIGH"
+	AssetCriticalityLOW      AssetCriticality = "LOW"
+	AssetCriticalityMEDIUM   AssetCriticality = "MEDIUM"
+)
+
+// AssetDependency is a directed edge in a tenant's asset dependency graph:
+// SourceAsset depends on TargetAsset. It is the persistence backbone of
+// the dependency cartography — the front-end universe/topology view is
+// built by listing every dependency for the tenant and drawing Source →
+// Target. ABSOLUTE RULE: every query filters by tenant_id; both endpoints
+// must belong to the same tenant (enforced in the use case, never trust
+// the client).
+type AssetDependency struct {
+	CreatedAt     time.Time      `json:"created_at"`
+	Description   string         `json:"description"`
+	ID            string         `json:"id"`
+	SourceAssetID string         `json:"source_asset_id"`
+	TargetAssetID string         `json:"target_asset_id"`
+	TenantID      string         `json:"tenant_id"`
+	Type          DependencyType `json:"type"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// AssetDiscovery is a single asset found by a scan. It is a preview DTO
+// — it is NOT a domain.Asset and is never written to the DB by the
+// pipeline.
+type AssetDiscovery struct {
+	// populated only for agent-based scans
+	AgentID string `json:"agent_id,omitempty"`
+	// critical for CTI matching + auto mitigation
+	Cpe []string `json:"cpe"`
+	// inferred [0.1, 3.0]
+	Criticality float64 `json:"criticality"`
+	// prod|staging|dev|...
+	Environment string         `json:"environment"`
+	ExternalID  string         `json:"external_id"`
+	Hostname    string         `json:"hostname,omitempty"`
+	IP          string         `json:"ip,omitempty"`
+	Location    string         `json:"location,omitempty"`
+	Name        string         `json:"name"`
+	Os          string         `json:"os,omitempty"`
+	OsVersion   string         `json:"os_version,omitempty"`
+	RawMetadata map[string]any `json:"raw_metadata,omitempty"`
+	ScanJobID   string         `json:"scan_job_id"`
+	Tags        []string       `json:"tags"`
+	Type        AssetType      `json:"type"`
+}
+
+// AssetExploitability summarises an asset's OPEN vulnerabilities as the
+// path engine needs them: the strongest exploitation signal present, not a
+// list.
+type AssetExploitability struct {
+	// BestTier is the strongest priority tier among the open vulnerabilities
+	// (P1 strongest), empty when none has been tiered.
+	BestTier  string  `json:"best_tier,omitempty"`
+	KEV       bool    `json:"kev"`
+	MaxEPSS   float64 `json:"max_epss"`
+	OpenVulns int64   `json:"open_vulns"`
+}
+
+// AssetSnapshot captures an asset's state at a point in time, taken
+// immediately before an update or deletion is applied. This is what powers
+// the asset inventory's history view (ROADMAP.md M3): "what did this asset
+// look like, and when did its criticality change". Traceability ("qui a
+// modifié quoi, et quand"): the snapshot records the prior state (the
+// *quoi*, diffable against the current asset), CreatedAt (the *quand*),
+// and ChangedBy — the user who performed the update/delete that
+// superseded this state (the *qui*). ChangedBy may be uuid.Nil for rows
+// written before this field existed or for non-interactive/system changes.
+type AssetSnapshot struct {
+	AssetID string `json:"asset_id"`
+	// ChangedBy is the ID of the user who caused this snapshot to be taken
+	// (i.e. who performed the change). Persisted; nullable for legacy/system
+	// rows.
+	ChangedBy string `json:"changed_by"`
+	// ChangedByEmail is a computed, denormalized display label resolved from
+	// ChangedBy on the read path (ListSnapshots). NOT persisted (gorm:"-") —
+	// it is populated best-effort so the history UI can show a human name
+	// instead of a raw UUID. Empty when the actor is unknown or cannot be
+	// resolved.
+	ChangedByEmail string           `json:"changed_by_email,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	Criticality    AssetCriticality `json:"criticality"`
+	ID             string           `json:"id"`
+	Name           string           `json:"name"`
+	Owner          string           `json:"owner"`
+	// Reason describes why the snapshot was taken: "update" or "delete".
+	Reason   string `json:"reason"`
+	TenantID string `json:"tenant_id"`
+	Type     string `json:"type"`
+}
+
+// AssetTopology is the whole graph for a tenant.
+type AssetTopology struct {
+	Edges     []TopologyEdge `json:"edges"`
+	NodeLimit int64          `json:"node_limit,omitempty"`
+	Nodes     []TopologyNode `json:"nodes"`
+	// Truncated reports that the graph was capped. It is returned rather than
+	// silently trimming, because a topology missing nodes without saying so is
+	// worse than one that renders slowly.
+	Truncated bool           `json:"truncated"`
+	Zones     []TopologyZone `json:"zones"`
+}
+
+// AssetType classifies a discovered asset. It is a string so that it stays
+// assignment-compatible with domain.Asset.Type (a plain string). The
+// scanner pipeline populates AssetDiscovery.Type with one of these; the
+// importer copies it verbatim into Asset.Type when the user promotes a
+// discovery to inventory.
+type AssetType string
+
+const (
+	AssetTypeContainer   AssetType = "Container"
+	AssetTypeDatabase    AssetType = "Database"
+	AssetTypeFunction    AssetType = "Function"
+	AssetTypeIdentity    AssetType = "Identity"
+	AssetTypeNetwork     AssetType = "Network"
+	AssetTypeRepository  AssetType = "Repository"
+	AssetTypeSaaS        AssetType = "SaaS"
+	AssetTypeServer      AssetType = "Server"
+	AssetTypeStorage     AssetType = "Storage"
+	AssetTypeUnknown     AssetType = "Unknown"
+	AssetTypeVM          AssetType = "VM"
+	AssetTypeWorkstation AssetType = "Workstation"
+)
+
+// AssetTypeSchema is a tenant's schema for one asset category. Exactly one
+// row per (tenant, category): a tenant that never edits anything still has
+// a row, seeded from DefaultAttributes, so "what does a Server look like
+// here?" always has a single answer that lives in the database rather than
+// in the client.
+type AssetTypeSchema struct {
+	// Attributes is the schema itself.
+	Attributes AttributeDefList `json:"attributes"`
+	Category   AssetCategory    `json:"category"`
+	CreatedAt  time.Time        `json:"created_at"`
+	// Customized records whether the tenant edited the shipped default. It is
+	// what lets the UI offer "reset to default" honestly.
+	Customized bool   `json:"customized"`
+	ID         string `json:"id"`
+	// Label is the tenant-facing name of the category ("Serveur",
+	// "Fournisseur").
+	Label     string    `json:"label"`
+	TenantID  string    `json:"tenant_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Version increments on every accepted edit, so an attribute bag can be
+	// read against the schema generation that produced it.
+	Version int64 `json:"version"`
+}
+
+// AssignPermissionRequest defines request body for assigning permission
+type AssignPermissionRequest struct {
+	PermissionID string `json:"permission_id"`
+}
+
+// AssignableGroup is a role-shaped bucket of members. The spec asks for
+// "groupes/rôles assignables": a tenant assigns work to "the RSSI" as
+// often as to a named person, and this lets the picker offer both without
+// a second API.
+type AssignableGroup struct {
+	Count int64 `json:"count"`
+	// business role key, or "admins"
+	Key string `json:"key"`
+	// localized label
+	Label   string   `json:"label"`
+	Members []string `json:"members"`
+}
+
+// AssignableResult is what GET /ownership/assignable returns.
+type AssignableResult struct {
+	Groups []AssignableGroup `json:"groups"`
+	// Permission echoes the filter that was applied (empty = none), so the UI
+	// can explain why a name is missing instead of just not showing it.
+	Permission string     `json:"permission,omitempty"`
+	Users      []Assignee `json:"users"`
+}
+
+// Assignee is one pickable row.
+type Assignee struct {
+	BusinessRole BusinessRoleKey `json:"business_role,omitempty"`
+	// BusinessRoleLabel is the human label of the preset, so the picker can
+	// group by job role without shipping the catalogue to the client.
+	BusinessRoleLabel string `json:"business_role_label,omitempty"`
+	// CanAct answers the permission filter: does this member actually hold the
+	// permission the caller asked for? A picker that offers someone who cannot
+	// do the work is a dead control with extra steps.
+	CanAct   bool       `json:"can_act"`
+	Email    string     `json:"email"`
+	FullName string     `json:"full_name"`
+	Initials string     `json:"initials"`
+	IsActive bool       `json:"is_active"`
+	OrgRole  MemberRole `json:"org_role"`
+	UserID   string     `json:"user_id"`
+}
+
+// AssigneeCapacity is the share of a person's working week available to
+// programme work, as a full-time equivalent in (0, 1]. A person without a
+// row is full time.
+type AssigneeCapacity struct {
+	Fte       float64   `json:"fte"`
+	TenantID  string    `json:"tenant_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UserID    string    `json:"user_id"`
+}
+
+// AssistantAnswer is the assistant's reply. Sources lists the
+// human-readable refs it grounded the answer on (e.g. "RSK — Log4Shell",
+// "ISO A.8.8").
+type AssistantAnswer struct {
+	Answer  string   `json:"answer"`
+	Sources []string `json:"sources"`
+}
+
+// AssistantAnswerResult wraps the answer with its provider and the
+// snippets that were retrieved (so the UI can show what the answer was
+// grounded on).
+type AssistantAnswerResult struct {
+	Answer      AssistantAnswer    `json:"answer"`
+	GeneratedBy string             `json:"generated_by"`
+	Retrieved   []KnowledgeSnippet `json:"retrieved"`
+}
+
+type AssistantQueryInputHistoryItem struct {
+	Role string `json:"role"`
+	Text string `json:"text"`
+}
+
+type AssistantQueryInput struct {
+	History  []AssistantQueryInputHistoryItem `json:"history"`
+	Locale   string                           `json:"locale"`
+	Question string                           `json:"question"`
+}
+
+// AttackPath is one route from an internet-exposed asset to a crown jewel.
+// Node and edge ids are the topology's own, so the view highlights a path
+// by id without a second lookup.
+type AttackPath struct {
+	Cost     float64         `json:"cost"`
+	EdgeIDs  []string        `json:"edge_ids"`
+	EntryID  string          `json:"entry_id"`
+	Hops     []AttackPathHop `json:"hops"`
+	Rank     int64           `json:"rank"`
+	TargetID string          `json:"target_id"`
+}
+
+// AttackPathAnalysis is the whole answer for a tenant.
+type AttackPathAnalysis struct {
+	ChokePoints []ChokePoint `json:"choke_points"`
+	CrownJewels int64        `json:"crown_jewels"`
+	EntryPoints int64        `json:"entry_points"`
+	K           int64        `json:"k"`
+	MaxHops     int64        `json:"max_hops"`
+	Paths       []AttackPath `json:"paths"`
+}
+
+// AttackPathHop is one asset on a path, with what it cost to reach.
+type AttackPathHop struct {
+	AssetID string `json:"asset_id"`
+	// Cost is this hop's share of the path cost.
+	Cost float64 `json:"cost"`
+	// EdgeID / EdgeType are the dependency walked INTO this asset; nil on the
+	// entry point, which is reached from the internet rather than an edge.
+	EdgeID         string              `json:"edge_id,omitempty"`
+	EdgeType       DependencyType      `json:"edge_type,omitempty"`
+	Exploitability AssetExploitability `json:"exploitability"`
+	Name           string              `json:"name"`
+}
+
+// AttributeDef declares ONE attribute of a category's schema. The set of
+// these for a category IS the JSON Schema the spec asks for — expressed
+// in a shape the form generator, the validator and the search index can
+// all consume without each re-implementing a JSON Schema dialect.
+type AttributeDef struct {
+	// allowed values for enum/multi_enum
+	Enum []string `json:"enum,omitempty"`
+	// Fingerprint marks an attribute as an identity signal used to correlate
+	// external findings with this asset (see pkg/assetmatch). The value is one
+	// of the FingerprintRole constants.
+	Fingerprint FingerprintRole `json:"fingerprint,omitempty"`
+	Group       string          `json:"group,omitempty"`
+	Help        string          `json:"help,omitempty"`
+	// stable machine key, snake_case
+	Key string `json:"key"`
+	// FR label (product's primary locale)
+	Label    string        `json:"label"`
+	LabelEn  string        `json:"label_en,omitempty"`
+	Max      float64       `json:"max,omitempty"`
+	Min      float64       `json:"min,omitempty"`
+	Required bool          `json:"required,omitempty"`
+	Type     AttributeType `json:"type"`
+}
+
+// AttributeDefList is a JSONB-persisted slice of attribute definitions.
+type AttributeDefList []AttributeDef
+
+// AttributeType is the datatype of one attribute. It drives three things
+// at once, which is why it is a closed set: which widget the generated
+// form renders, which server-side validation runs, and how a search value
+// is compared.
+type AttributeType string
+
+const (
+	AttributeTypeBoolean    AttributeType = "boolean"
+	AttributeTypeCidr       AttributeType = "cidr"
+	AttributeTypeDate       AttributeType = "date"
+	AttributeTypeEmail      AttributeType = "email"
+	AttributeTypeEnum       AttributeType = "enum"
+	AttributeTypeHostname   AttributeType = "hostname"
+	AttributeTypeInteger    AttributeType = "integer"
+	AttributeTypeIP         AttributeType = "ip"
+	AttributeTypeIPList     AttributeType = "ip_list"
+	AttributeTypeMultiEnum  AttributeType = "multi_enum"
+	AttributeTypeNumber     AttributeType = "number"
+	AttributeTypeString     AttributeType = "string"
+	AttributeTypeStringList AttributeType = "string_list"
+	AttributeTypeText       AttributeType = "text"
+	AttributeTypeURL        AttributeType = "url"
+)
+
+// AuditAction is the verb of an audited event.
+type AuditAction string
+
+const (
+	AuditActionApprove  AuditAction = "approve"
+	AuditActionCreate   AuditAction = "create"
+	AuditActionDelegate AuditAction = "delegate"
+	AuditActionDelete   AuditAction = "delete"
+	AuditActionDownload AuditAction = "download"
+	AuditActionExport   AuditAction = "export"
+	AuditActionLogin    AuditAction = "login"
+	AuditActionReject   AuditAction = "reject"
+	AuditActionRevoke   AuditAction = "revoke"
+	AuditActionSubmit   AuditAction = "submit"
+	AuditActionUpdate   AuditAction = "update"
+	AuditActionView     AuditAction = "view"
+)
+
+// AuditChainBreak locates one detected alteration.
+type AuditChainBreak struct {
+	Detail   string `json:"detail"`
+	EventID  string `json:"event_id,omitempty"`
+	Kind     string `json:"kind"`
+	Sequence int64  `json:"sequence"`
+}
+
+// AuditChainReport is the outcome of verifying a tenant's audit chain.
+type AuditChainReport struct {
+	Breaks        []AuditChainBreak `json:"breaks"`
+	CheckedAt     time.Time         `json:"checked_at"`
+	DurationMs    int64             `json:"duration_ms"`
+	FirstSequence int64             `json:"first_sequence"`
+	HeadHash      string            `json:"head_hash"`
+	LastSequence  int64             `json:"last_sequence"`
+	Seals         int64             `json:"seals"`
+	TenantID      string            `json:"tenant_id"`
+	TotalEvents   int64             `json:"total_events"`
+	Valid         bool              `json:"valid"`
+	Verified      int64             `json:"verified"`
+}
+
+// AuditChainSeal records that a contiguous range of a tenant's audit
+// events was removed by retention. It preserves the hash the next
+// surviving event links back to, so verification can cross the gap
+// knowingly instead of reporting a break it cannot explain. Seals
+// themselves are append-only.
+type AuditChainSeal struct {
+	CreatedAt    time.Time `json:"created_at"`
+	FromSequence int64     `json:"from_sequence"`
+	ID           string    `json:"id"`
+	// LastHash is the hash of the last pruned event — the value the first
+	// surviving event's prev_hash must equal.
+	LastHash    string `json:"last_hash"`
+	PrunedCount int64  `json:"pruned_count"`
+	Reason      string `json:"reason"`
+	TenantID    string `json:"tenant_id"`
+	ToSequence  int64  `json:"to_sequence"`
+}
+
+// AuditCheckpoint is a signed statement of a tenant's chain head.
+type AuditCheckpoint struct {
+	Algorithm string    `json:"algorithm"`
+	CreatedAt time.Time `json:"created_at"`
+	HeadHash  string    `json:"head_hash"`
+	ID        string    `json:"id"`
+	KeyID     string    `json:"key_id"`
+	Sequence  int64     `json:"sequence"`
+	// Signature is the base64 signature over SignedPayload.
+	Signature string `json:"signature"`
+	// SignedAt is part of the signed payload.
+	SignedAt       time.Time  `json:"signed_at"`
+	TenantID       string     `json:"tenant_id"`
+	TimestampError string     `json:"timestamp_error,omitempty"`
+	TimestampToken string     `json:"timestamp_token,omitempty"`
+	TimestampedAt  *time.Time `json:"timestamped_at,omitempty"`
+	// The RFC 3161 countersignature over SHA-256(signature), when a TSA is
+	// configured. TimestampError keeps why a configured TSA gave none.
+	TsaURL string `json:"tsa_url,omitempty"`
+}
+
+// AuditEntryView is one row of the membership audit history. It is a
+// projection, not the stored event: the hash-chain fields and the raw HTTP
+// envelope belong to the governance trail, not to a member-management
+// screen.
+type AuditEntryView struct {
+	Action     AuditAction `json:"action"`
+	ActorEmail string      `json:"actor_email,omitempty"`
+	ActorID    string      `json:"actor_id,omitempty"`
+	After      JSONMap     `json:"after,omitempty"`
+	At         time.Time   `json:"at"`
+	Before     JSONMap     `json:"before,omitempty"`
+	EntityID   string      `json:"entity_id"`
+	EntityType string      `json:"entity_type"`
+	ID         string      `json:"id"`
+	IPAddress  string      `json:"ip_address,omitempty"`
+	Summary    string      `json:"summary"`
+}
+
+// AuditEvent is one immutable row in the audit trail. There is
+// intentionally no UpdatedAt and no soft-delete: the repository only ever
+// appends. ActorID is nil for automatic/system events (e.g. a background
+// worker mutation). Each row is chained to the previous one of the same
+// tenant (Sequence / PrevHash / Hash), so any alteration is detectable —
+// see audit_chain.go.
+type AuditEvent struct {
+	Action AuditAction `json:"action"`
+	// ActorEmail is resolved on read (never persisted) so the journal shows a
+	// human name instead of a bare UUID.
+	ActorEmail    string     `json:"actor_email,omitempty"`
+	ActorID       string     `json:"actor_id,omitempty"`
+	After         JSONMap    `json:"after,omitempty"`
+	Before        JSONMap    `json:"before,omitempty"`
+	ChangedFields StringList `json:"changed_fields,omitempty"`
+	// CreatedAt is part of the hashed payload, so it is always set by the
+	// application before sealing — never left to a database default.
+	CreatedAt  time.Time `json:"created_at"`
+	EntityID   string    `json:"entity_id"`
+	EntityType string    `json:"entity_type"`
+	Hash       string    `json:"hash"`
+	// No database-side default on ID: the repository always assigns it before
+	// hashing (the id is part of the hashed payload), and keeping the model
+	// free of Postgres-only DDL lets the same schema stand up under sqlite in
+	// tests — which is how this table's DDL stops drifting away from the
+	// model.
+	ID        string `json:"id"`
+	IPAddress string `json:"ip_address,omitempty"`
+	// HTTP envelope — set when the entry was produced by the mutation
+	// middleware.
+	Method    string `json:"method,omitempty"`
+	Path      string `json:"path,omitempty"`
+	PrevHash  string `json:"prev_hash"`
+	RequestID string `json:"request_id,omitempty"`
+	// Hash chain — Sequence is a per-tenant monotonic counter, PrevHash
+	// links to the preceding entry and Hash covers this entry's full content.
+	// Assigned by the repository inside the append transaction; never by
+	// callers.
+	Sequence int64 `json:"sequence"`
+	// Source is which writer produced the entry: http | gorm | explicit.
+	Source     string `json:"source,omitempty"`
+	StatusCode int64  `json:"status_code,omitempty"`
+	Summary    string `json:"summary"`
+	TenantID   string `json:"tenant_id"`
+	UserAgent  string `json:"user_agent,omitempty"`
+}
+
+// AuditEventsResult is a page of events plus the unfiltered total for
+// paging.
+type AuditEventsResult struct {
+	Events []AuditEvent `json:"events"`
+	Limit  int64        `json:"limit"`
+	Offset int64        `json:"offset"`
+	Total  int64        `json:"total"`
+}
+
+// AuditExport is the JSON export envelope: the entries, the chain verdict
+// at export time, and the signature over both.
+type AuditExport struct {
+	Count        int64           `json:"count"`
+	Events       []AuditEvent    `json:"events"`
+	ExportedAt   time.Time       `json:"exported_at"`
+	ExportedBy   string          `json:"exported_by,omitempty"`
+	Filter       map[string]any  `json:"filter,omitempty"`
+	Kind         string          `json:"kind"`
+	Signature    json.RawMessage `json:"signature,omitempty"`
+	TenantID     string          `json:"tenant_id"`
+	Verification json.RawMessage `json:"verification,omitempty"`
+	Version      int64           `json:"version"`
+}
+
+// AuditExportSignature accompanies an export. It proves the file came from
+// this deployment and has not been edited since. It is deliberately
+// separate from the per-entry hashes: those already prove internal
+// consistency without any key.
+type AuditExportSignature struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"key_id,omitempty"`
+	// Reason explains a missing signature instead of quietly shipping an
+	// unsigned file that looks signed.
+	Reason string `json:"reason,omitempty"`
+	Value  string `json:"value,omitempty"`
+}
+
+type AuditLogDTO struct {
+	Action       string `json:"action"`
+	ErrorMessage string `json:"error_message,omitempty"`
+	ID           string `json:"id"`
+	IPAddress    string `json:"ip_address,omitempty"`
+	Resource     string `json:"resource,omitempty"`
+	ResourceID   string `json:"resource_id,omitempty"`
+	Result       string `json:"result"`
+	Timestamp    string `json:"timestamp"`
+	UserAgent    string `json:"user_agent,omitempty"`
+	UserID       string `json:"user_id,omitempty"`
+}
+
+// AuditNarrative is the executive audit report, ready to export.
+type AuditNarrative struct {
+	Conclusion       string   `json:"conclusion"`
+	ExecutiveSummary string   `json:"executive_summary"`
+	Findings         string   `json:"findings"`
+	Recommendations  []string `json:"recommendations"`
+}
+
+// AuditPublicKey is the key checkpoints are signed with.
+type AuditPublicKey struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"key_id"`
+	Pem       string `json:"pem"`
+}
+
+type AuditReportInput struct {
+	Locale string `json:"locale"`
+}
+
+// AuditReportResult wraps the executive audit narrative with its provider.
+type AuditReportResult struct {
+	GeneratedBy string         `json:"generated_by"`
+	Report      AuditNarrative `json:"report"`
+}
+
+// AuditRetentionPolicy is the tenant's configurable retention window. Zero
+// days means "keep forever" — the safe default for a compliance product,
+// chosen explicitly rather than inherited from an empty column.
+type AuditRetentionPolicy struct {
+	CreatedAt     time.Time  `json:"created_at"`
+	ID            string     `json:"id"`
+	LastPrunedAt  *time.Time `json:"last_pruned_at,omitempty"`
+	RetentionDays int64      `json:"retention_days"`
+	TenantID      string     `json:"tenant_id"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	UpdatedBy     string     `json:"updated_by,omitempty"`
+}
+
+// AuditSegment is a contiguous stretch of a tenant's chain with everything
+// needed to check it away from the product: the entries, the retention
+// seals that explain gaps, and the checkpoints inside the range. The
+// embedded public key is a convenience for reading the file only. A
+// verifier must be given the key from somewhere the exporter does not
+// control; otherwise whoever rewrote the chain could ship a matching key
+// alongside it.
+type AuditSegment struct {
+	Checkpoints  []AuditCheckpoint `json:"checkpoints"`
+	Events       []AuditEvent      `json:"events"`
+	ExportedAt   time.Time         `json:"exported_at"`
+	FromSequence int64             `json:"from_sequence"`
+	Kind         string            `json:"kind"`
+	PublicKey    *AuditPublicKey   `json:"public_key,omitempty"`
+	Seals        []AuditChainSeal  `json:"seals"`
+	TenantID     string            `json:"tenant_id"`
+	ToSequence   int64             `json:"to_sequence"`
+	Version      int64             `json:"version"`
+}
+
+// AuditStatus is the lifecycle state of a compliance audit.
+type AuditStatus string
+
+const (
+	AuditStatusCancelled  AuditStatus = "cancelled"
+	AuditStatusCompleted  AuditStatus = "completed"
+	AuditStatusInProgress AuditStatus = "in_progress"
+	AuditStatusPlanned    AuditStatus = "planned"
+)
+
+// AuditType is the nature of a compliance audit.
+type AuditType string
+
+const (
+	AuditTypeCertification AuditType = "certification"
+	AuditTypeExternal      AuditType = "external"
+	AuditTypeInternal      AuditType = "internal"
+	AuditTypeSurveillance  AuditType = "surveillance"
+)
+
+// AutoMitigation is a finding that was present in the previous scan of the
+// same config but is absent now — i.e. it appears to have been
+// remediated. Surfaced on the "Auto-detected Mitigations" tab of the
+// preview for the user to confirm.
+type AutoMitigation struct {
+	AssetExternalID string    `json:"asset_external_id"`
+	CVE             string    `json:"cve,omitempty"`
+	DetectedAt      time.Time `json:"detected_at"`
+	// why we think it's fixed
+	Evidence string `json:"evidence"`
+	Severity string `json:"severity"`
+	Title    string `json:"title"`
+}
+
+// AutomationAction is one step of the chain. Only the fields relevant to
+// Type are read; the rest stay zero.
+type AutomationAction struct {
+	// notify — one or more of: in_app, email, slack, teams.
+	Channels []string `json:"channels,omitempty"`
+	// notify custom message (templated with {{title}}, {{severity}},
+	// {{cve}}…).
+	Message string `json:"message,omitempty"`
+	// notify recipient hint / assign_owner target: a role (admin, manager) or
+	// a user email/id. Empty on notify falls back to the risk owner + admins.
+	Target string `json:"target,omitempty"`
+	// create_ticket provider override (jira|servicenow). Empty uses the tenant
+	// default.
+	TicketProvider string               `json:"ticket_provider,omitempty"`
+	Type           AutomationActionType `json:"type"`
+}
+
+// AutomationActionList is an ordered list of actions stored as jsonb.
+type AutomationActionList []AutomationAction
+
+// AutomationActionType is a single step in a rule's action chain.
+type AutomationActionType string
+
+const (
+	AutomationActionTypeAssignOwner  AutomationActionType = "assign_owner"
+	AutomationActionTypeCloseTicket  AutomationActionType = "close_ticket"
+	AutomationActionTypeCreateRisk   AutomationActionType = "create_risk"
+	AutomationActionTypeCreateTicket AutomationActionType = "create_ticket"
+	AutomationActionTypeNotify       AutomationActionType = "notify"
+	AutomationActionTypeResolveRisk  AutomationActionType = "resolve_risk"
+	AutomationActionTypeScanAsset    AutomationActionType = "scan_asset"
+	AutomationActionTypeStartSLA     AutomationActionType = "start_sla"
+)
+
+// AutomationChannelConfig is the tenant-level configuration of the
+// outbound alert channels the automation engine can use ("configurer un
+// nouveau canal d'alerte"). One row per tenant. Webhook URLs are
+// write-only: they are never returned to the API (only HasSlack/HasTeams
+// booleans are).
+type AutomationChannelConfig struct {
+	CreatedAt    time.Time `json:"created_at"`
+	DefaultEmail string    `json:"default_email"`
+	// EmailEnabled turns on e-mail delivery for automation alerts.
+	// DefaultEmail is a fallback recipient used when a role-based alert
+	// resolves to nobody.
+	EmailEnabled bool `json:"email_enabled"`
+	// Computed, NOT persisted — surfaced instead of the raw webhook URLs and
+	// credentials, so the UI can show "configured" without ever reading a
+	// secret back out (RULE #6).
+	HasSlack     bool   `json:"has_slack"`
+	HasSms       bool   `json:"has_sms"`
+	HasTeams     bool   `json:"has_teams"`
+	HasWebhook   bool   `json:"has_webhook"`
+	ID           string `json:"id"`
+	SlackEnabled bool   `json:"slack_enabled"`
+	// SMS through an HTTP gateway. Deliberately generic (URL + credentials +
+	// field names) rather than one vendor's SDK: African and European
+	// operators each expose their own HTTP API, and a hard-coded vendor would
+	// serve none of them.
+	SmsEnabled bool `json:"sms_enabled"`
+	// comma-separated E.164
+	SmsRecipients  string `json:"sms_recipients,omitempty"`
+	SmsSender      string `json:"sms_sender,omitempty"`
+	SmsSenderField string `json:"sms_sender_field,omitempty"`
+	// default "message"
+	SmsTextField string `json:"sms_text_field,omitempty"`
+	// default "to"
+	SmsToField   string    `json:"sms_to_field,omitempty"`
+	TeamsEnabled bool      `json:"teams_enabled"`
+	TenantID     string    `json:"tenant_id"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	// Generic outbound webhook — the escape hatch for anything that is not
+	// Slack or Teams (PagerDuty, an internal bus, a customer's own endpoint).
+	WebhookEnabled bool `json:"webhook_enabled"`
+}
+
+// AutomationConditions are the guard clauses a triggering event must
+// satisfy before the action chain runs. A zero value matches everything.
+type AutomationConditions struct {
+	// AssetTags requires the affected asset to carry at least one of these
+	// tags.
+	AssetTags []string `json:"asset_tags,omitempty"`
+	// KEVOnly requires the vulnerability to be CISA Known-Exploited.
+	KEVOnly bool `json:"kev_only,omitempty"`
+	// MinCVSS gates on the CVSS base score (0–10). 0 = no gate.
+	MinCVSS float64 `json:"min_cvss,omitempty"`
+	// MinPriorityTier gates on the vuln priority tier (P1 strongest … P4).
+	// Empty = no gate.
+	MinPriorityTier string `json:"min_priority_tier,omitempty"`
+	// MinSeverity gates on the severity of the triggering object
+	// (low<medium<high<critical). Empty = no gate.
+	MinSeverity string `json:"min_severity,omitempty"`
+}
+
+// AutomationExecution is the audit record of one rule firing.
+type AutomationExecution struct {
+	// resolved on read
+	ActorEmail string     `json:"actor_email,omitempty"`
+	ActorID    string     `json:"actor_id,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	DurationMs int64      `json:"duration_ms"`
+	Error      string     `json:"error,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	ID         string     `json:"id"`
+	Input      JSONMap    `json:"input,omitempty"`
+	// The whole point of a history: what went in, what came out, how long it
+	// took, and who is answerable for it.
+	Mode         string                    `json:"mode"`
+	Output       JSONMap                   `json:"output,omitempty"`
+	ReplayedFrom string                    `json:"replayed_from,omitempty"`
+	RuleID       string                    `json:"rule_id"`
+	RuleName     string                    `json:"rule_name"`
+	Severity     string                    `json:"severity"`
+	StartedAt    time.Time                 `json:"started_at"`
+	Status       AutomationExecutionStatus `json:"status"`
+	Steps        ExecutionStepList         `json:"steps"`
+	Subject      string                    `json:"subject"`
+	TenantID     string                    `json:"tenant_id"`
+	Trigger      AutomationTrigger         `json:"trigger"`
+	// TriggerRef is a stable reference to what fired the rule (e.g.
+	// "cve:CVE-2021-44228", "risk:<uuid>", "vuln:<uuid>"). Subject is a human
+	// summary.
+	TriggerRef string `json:"trigger_ref"`
+}
+
+// AutomationExecutionStatus is the outcome of running a rule once.
+type AutomationExecutionStatus string
+
+const (
+	AutomationExecutionStatusFailed  AutomationExecutionStatus = "failed"
+	AutomationExecutionStatusPartial AutomationExecutionStatus = "partial"
+	AutomationExecutionStatusPending AutomationExecutionStatus = "pending"
+	AutomationExecutionStatusRunning AutomationExecutionStatus = "running"
+	AutomationExecutionStatusSkipped AutomationExecutionStatus = "skipped"
+	AutomationExecutionStatusSuccess AutomationExecutionStatus = "success"
+)
+
+// AutomationRule is a tenant-scoped SOAR playbook: one trigger, a set of
+// conditions, an ordered action chain, and an optional SLA policy.
+type AutomationRule struct {
+	Actions        AutomationActionList `json:"actions"`
+	Conditions     AutomationConditions `json:"conditions"`
+	CreatedAt      time.Time            `json:"created_at"`
+	CreatedBy      string               `json:"created_by"`
+	Description    string               `json:"description"`
+	Enabled        bool                 `json:"enabled"`
+	FailureStreak  int64                `json:"failure_streak"`
+	ID             string               `json:"id"`
+	LastError      string               `json:"last_error,omitempty"`
+	LastExecutedAt *time.Time           `json:"last_executed_at,omitempty"`
+	// Live health, denormalised from the last execution so a rule list can
+	// show its state without N+1 queries.
+	LastStatus string `json:"last_status,omitempty"`
+	// Bookkeeping.
+	LastTriggeredAt *time.Time `json:"last_triggered_at,omitempty"`
+	Name            string     `json:"name"`
+	// Priority orders rules that share a trigger (lower runs first).
+	Priority int64               `json:"priority"`
+	SLA      AutomationSLAConfig `json:"sla"`
+	// Suspension is distinct from "never enabled": a rule someone deliberately
+	// paused should say who paused it, when, and why, so the next person does
+	// not re-enable a rule that was stopped for a reason.
+	SuspendedAt     *time.Time `json:"suspended_at,omitempty"`
+	SuspendedBy     string     `json:"suspended_by,omitempty"`
+	SuspendedReason string     `json:"suspended_reason,omitempty"`
+	// TemplateKey records which ready-made template a rule was created from,
+	// so the UI can say "based on: Critical vulnerability response".
+	TemplateKey  string            `json:"template_key,omitempty"`
+	TenantID     string            `json:"tenant_id"`
+	Trigger      AutomationTrigger `json:"trigger"`
+	TriggerCount int64             `json:"trigger_count"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+// AutomationSLAConfig defines the resolution deadline (by severity) and
+// the escalation policy a start_sla action applies.
+type AutomationSLAConfig struct {
+	// Resolution budget in minutes per severity. 0 = fall back to the next
+	// higher-severity value, else no SLA for that severity.
+	CriticalMinutes int64 `json:"critical_minutes,omitempty"`
+	// EscalateAfterMinutes is how long AFTER the deadline breach to escalate.
+	// 0 escalates immediately on breach.
+	EscalateAfterMinutes int64 `json:"escalate_after_minutes,omitempty"`
+	// EscalateChannels are the channels used for the escalation alert.
+	EscalateChannels []string `json:"escalate_channels,omitempty"`
+	// EscalateToRole is the role notified on escalation (admin|manager). Empty
+	// = admin.
+	EscalateToRole string `json:"escalate_to_role,omitempty"`
+	HighMinutes    int64  `json:"high_minutes,omitempty"`
+	LowMinutes     int64  `json:"low_minutes,omitempty"`
+	MediumMinutes  int64  `json:"medium_minutes,omitempty"`
+}
+
+// AutomationState is the whole module's live state: every rule plus the
+// counts a status strip shows.
+type AutomationState struct {
+	Active     int64       `json:"active"`
+	Degraded   int64       `json:"degraded"`
+	Failing    int64       `json:"failing"`
+	Idle       int64       `json:"idle"`
+	ObservedAt time.Time   `json:"observed_at"`
+	Rules      []RuleState `json:"rules"`
+	Suspended  int64       `json:"suspended"`
+}
+
+// AutomationTrigger is the kind of platform event that can start a
+// workflow.
+type AutomationTrigger string
+
+const (
+	AutomationTriggerIncidentCreated       AutomationTrigger = "incident_created"
+	AutomationTriggerKRIThresholdCrossed   AutomationTrigger = "kri_threshold_crossed"
+	AutomationTriggerManual                AutomationTrigger = "manual"
+	AutomationTriggerRiskCreated           AutomationTrigger = "risk_created"
+	AutomationTriggerRiskScoreUpdated      AutomationTrigger = "risk_score_updated"
+	AutomationTriggerVulnerabilityDetected AutomationTrigger = "vulnerability_detected"
+)
+
+// BIAReport is the per-process view: the analysis, what the process stands
+// on, and what a realistic outage looks like given the declared
+// capabilities.
+type BIAReport struct {
+	Analysis BusinessImpactAnalysis `json:"analysis"`
+	// Caveats are the report's honest limits (serial recovery, undeclared
+	// capabilities) so nobody reads a green report as more than it is.
+	Caveats []string `json:"caveats"`
+	// Dependencies are judged against THIS process's objectives only; the
+	// estate-wide view (PropagateRecovery over every process) may be stricter.
+	Dependencies []RecoveryRequirement `json:"dependencies"`
+	// ExpectedDowntimeHours is the outage to plan for: the RTO, or the slowest
+	// declared restore among the dependencies when that is longer. It is what
+	// feeds the CRQ DowntimeHours input of the process's risks.
+	ExpectedDowntimeHours   float64 `json:"expected_downtime_hours"`
+	Gaps                    int64   `json:"gaps"`
+	ImpactCostXafAtExpected float64 `json:"impact_cost_xaf_at_expected"`
+	ImpactCostXafAtRto      float64 `json:"impact_cost_xaf_at_rto"`
+	ImpactLevelAtExpected   int64   `json:"impact_level_at_expected"`
+	ImpactLevelAtRto        int64   `json:"impact_level_at_rto"`
+	ProcessID               string  `json:"process_id"`
+	ProcessName             string  `json:"process_name"`
+	Undeclared              int64   `json:"undeclared"`
+	WithinMtpd              bool    `json:"within_mtpd"`
+	WithinRto               bool    `json:"within_rto"`
+}
+
+// BackfillInput rewinds a destination. FromSequence re-ships the audit
+// chain from that sequence; AuthSince re-ships the auth log from that
+// instant.
+type BackfillInput struct {
+	AuthSince    *time.Time `json:"auth_since,omitempty"`
+	FromSequence *int64     `json:"from_sequence,omitempty"`
+}
+
+// Badge Definition
+type Badge struct {
+	Description string `json:"description"`
+	// Nom de l'icône Lucide (ex: "Shield")
+	Icon     string `json:"icon"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Unlocked bool   `json:"unlocked"`
+}
+
+// Band is the qualitative reading of a score. The vocabulary is the
+// product's existing one (low / medium / high / critical) — the same
+// words used by domain.AssetCriticality, by the vulnerability tiers, by
+// pkg/scoring's smart model, and by the --low/--medium/--high/--critical
+// design tokens. Introducing a fifth vocabulary for one more surface is
+// how a codebase ends up with four incompatible band mappings in the first
+// place.
+type Band string
+
+const (
+	BandCritical Band = "critical"
+	BandHigh     Band = "high"
+	BandLow      Band = "low"
+	BandMedium   Band = "medium"
+)
+
+// BandRange describes one band's span on the canonical scale.
+type BandRange struct {
+	Band         Band   `json:"band"`
+	LabelI18nKey string `json:"label_i18n_key"`
+	// MaxValue is inclusive for the top band and exclusive below it — the
+	// field says which, so a consumer never has to guess at a boundary.
+	Max          float64 `json:"max"`
+	MaxInclusive bool    `json:"max_inclusive"`
+	Min          float64 `json:"min"`
+}
+
+// BarrierHealth is how far a barrier can be relied on.
+type BarrierHealth string
+
+const (
+	BarrierHealthDegraded BarrierHealth = "degraded"
+	BarrierHealthFailed   BarrierHealth = "failed"
+	BarrierHealthHealthy  BarrierHealth = "healthy"
+	BarrierHealthUnknown  BarrierHealth = "unknown"
+)
+
+// Basis records how an ALE was derived, so the UI can be honest about it.
+type Basis string
+
+const (
+	BasisComposed  Basis = "composed"
+	BasisExplicit  Basis = "explicit"
+	BasisReference Basis = "reference"
+)
+
+// BillingProvider identifies which payment processor backs the
+// subscription.
+type BillingProvider string
+
+const (
+	BillingProviderCinetpay BillingProvider = "cinetpay"
+	BillingProviderManual   BillingProvider = "manual"
+	BillingProviderNotchpay BillingProvider = "notchpay"
+	BillingProviderStripe   BillingProvider = "stripe"
+)
+
+// BoardReport is a tenant-scoped, monthly board-of-directors report. It
+// snapshots the risk/compliance posture at generation time (so the PDF is
+// reproducible even as the underlying data moves) plus an editable,
+// non-technical narrative written by an Advisor (Claude when configured,
+// otherwise a deterministic template). The flow is generate (draft) →
+// edit → approve, all tenant-scoped.
+type BoardReport struct {
+	// AppetiteSnapshot is the appetite evaluation at generation time: each
+	// in-force statement's scope and breaches, and the risks above appetite
+	// with the state of their exception. Null when no statement is in force.
+	AppetiteSnapshot     json.RawMessage `json:"appetite_snapshot,omitempty"`
+	ApprovedAt           *time.Time      `json:"approved_at,omitempty"`
+	ApprovedBy           *string         `json:"approved_by,omitempty"`
+	ComplianceCommentary string          `json:"compliance_commentary"`
+	CreatedAt            time.Time       `json:"created_at"`
+	// --- Audit ---
+	CreatedBy string `json:"created_by"`
+	// --- Narrative (editable while draft) ---
+	ExecutiveSummary      string `json:"executive_summary"`
+	FinancialCommentary   string `json:"financial_commentary"`
+	FinancialExposureFcfa int64  `json:"financial_exposure_fcfa"`
+	// FrameworksSnapshot is a JSON array of the per-framework advancement at
+	// generation time (name, version, percent, counts) — rendered in the PDF
+	// table.
+	FrameworksSnapshot json.RawMessage `json:"frameworks_snapshot"`
+	// GeneratedByModel records provenance: "claude-opus-4-8" when the LLM
+	// wrote it, or "template" when the deterministic fallback did.
+	GeneratedByModel string `json:"generated_by_model"`
+	ID               string `json:"id"`
+	// KRISnapshot is the key risk indicators at generation time: each one's
+	// band, current value and recent trend. Null when the tenant has none.
+	KRISnapshot json.RawMessage `json:"kri_snapshot,omitempty"`
+	// fr | en
+	Locale string `json:"locale"`
+	// snapshot at generation time
+	OrganizationName         string  `json:"organization_name"`
+	OverallCompliancePercent float64 `json:"overall_compliance_percent"`
+	// e.g. "Juillet 2026"
+	PeriodLabel     string   `json:"period_label"`
+	Recommendations []string `json:"recommendations"`
+	// RegisterChanges is what moved in the register over the month before
+	// generation. Null when no snapshot is old enough to compare against.
+	RegisterChanges json.RawMessage `json:"register_changes,omitempty"`
+	RiskCommentary  string          `json:"risk_commentary"`
+	// --- Posture snapshot (frozen at generation time) ---
+	RisksCritical int64             `json:"risks_critical"`
+	RisksHigh     int64             `json:"risks_high"`
+	RisksLow      int64             `json:"risks_low"`
+	RisksMedium   int64             `json:"risks_medium"`
+	RisksTotal    int64             `json:"risks_total"`
+	Status        BoardReportStatus `json:"status"`
+	TenantID      string            `json:"tenant_id"`
+	Title         string            `json:"title"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// BoardReportStatus is the human-in-the-loop lifecycle of a board report.
+type BoardReportStatus string
+
+const (
+	BoardReportStatusApproved BoardReportStatus = "approved"
+	BoardReportStatusDraft    BoardReportStatus = "draft"
+)
+
+// Bowtie is a risk's bowtie diagram.
+type Bowtie struct {
+	// AppliedPreventiveLoss and AppliedRecoveryLoss are the barrier losses the
+	// risk's figures have already been raised by (see SuggestFromBarriers).
+	// They only ever grow: the suggestion never lowers the figures when
+	// barriers recover, so the raise is still in them.
+	AppliedPreventiveLoss float64        `json:"applied_preventive_loss"`
+	AppliedRecoveryLoss   float64        `json:"applied_recovery_loss"`
+	Barriers              BowtieBarriers `json:"barriers"`
+	Consequences          BowtiePaths    `json:"consequences"`
+	CreatedAt             time.Time      `json:"created_at"`
+	// Hazard is what is being controlled ("customer funds in transit");
+	// TopEvent the moment control over it is lost ("unauthorised transfer
+	// leaves the bank").
+	Hazard    string      `json:"hazard"`
+	ID        string      `json:"id"`
+	RiskID    string      `json:"risk_id"`
+	TenantID  string      `json:"tenant_id"`
+	Threats   BowtiePaths `json:"threats"`
+	TopEvent  string      `json:"top_event"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	UpdatedBy string      `json:"updated_by,omitempty"`
+}
+
+// BowtieBarrier is one barrier on one path. PathID names a threat for a
+// preventive barrier and a consequence for a recovery one; barriers on the
+// same path are drawn in Position order, from the threat inwards or from
+// the top event outwards.
+type BowtieBarrier struct {
+	ControlID    string            `json:"control_id,omitempty"`
+	ID           string            `json:"id"`
+	Kind         BowtieBarrierKind `json:"kind"`
+	Label        string            `json:"label"`
+	MitigationID string            `json:"mitigation_id,omitempty"`
+	PathID       string            `json:"path_id"`
+	Position     int64             `json:"position"`
+}
+
+// BowtieBarrierKind is which side of the top event a barrier stands on.
+type BowtieBarrierKind string
+
+const (
+	BowtieBarrierKindPreventive BowtieBarrierKind = "preventive"
+	BowtieBarrierKindRecovery   BowtieBarrierKind = "recovery"
+)
+
+// BowtieBarriers is a jsonb list of barriers.
+type BowtieBarriers []BowtieBarrier
+
+// BowtiePath is a threat (left side) or a consequence (right side). ID is
+// a short key unique within the bowtie, assigned on save when blank, that
+// barriers use to say which path they cross.
+type BowtiePath struct {
+	Description string `json:"description,omitempty"`
+	ID          string `json:"id"`
+	Label       string `json:"label"`
+}
+
+// BowtiePaths is a jsonb list of paths.
+type BowtiePaths []BowtiePath
+
+// BulkOperation represents an async bulk operation job
+type BulkOperation struct {
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CreatedBy    string     `json:"created_by"`
+	ErrorCount   int64      `json:"error_count"`
+	ErrorMessage string     `json:"error_message,omitempty"`
+	// Estimated seconds to completion
+	EstimatedTimeSeconds int64 `json:"estimated_time_seconds,omitempty"`
+	// json, csv, pdf (for export)
+	ExportFormat string `json:"export_format,omitempty"`
+	// Filter & Scope
+	FilterQuery   map[string]any    `json:"filter_query,omitempty"`
+	ID            string            `json:"id"`
+	OperationType BulkOperationType `json:"operation_type"`
+	// Resources processed so far
+	ProcessedCount int64 `json:"processed_count"`
+	// Total resources to process
+	ResourceCount int64 `json:"resource_count"`
+	// Results & Error handling
+	ResultURL string              `json:"result_url,omitempty"`
+	StartedAt *time.Time          `json:"started_at,omitempty"`
+	Status    BulkOperationStatus `json:"status"`
+	// Metadata
+	TenantID string `json:"tenant_id"`
+	// Operation details
+	UpdateData map[string]any `json:"update_data,omitempty"`
+}
+
+// BulkOperationStatus represents the status of a bulk operation
+type BulkOperationStatus string
+
+const (
+	BulkOperationStatusCompleted  BulkOperationStatus = "completed"
+	BulkOperationStatusFailed     BulkOperationStatus = "failed"
+	BulkOperationStatusPending    BulkOperationStatus = "pending"
+	BulkOperationStatusProcessing BulkOperationStatus = "processing"
+)
+
+// BulkOperationType represents the type of bulk operation
+type BulkOperationType string
+
+const (
+	BulkOperationTypeAssignMitigation BulkOperationType = "assign_mitigation"
+	BulkOperationTypeDelete           BulkOperationType = "delete"
+	BulkOperationTypeExport           BulkOperationType = "export"
+	BulkOperationTypeUpdate           BulkOperationType = "update"
+)
+
+type BulkReviewBody struct {
+	// accept | dismiss
+	Decision string   `json:"decision"`
+	RiskIDs  []string `json:"risk_ids"`
+}
+
+// BulkReviewResult reports what happened, per id.
+type BulkReviewResult struct {
+	Accepted  []string `json:"accepted"`
+	Dismissed []string `json:"dismissed"`
+	// risk id → why
+	Failed map[string]string `json:"failed,omitempty"`
+}
+
+// BusinessImpactAnalysis is the BIA of one business process. One per
+// asset.
+type BusinessImpactAnalysis struct {
+	// AssetID is the process asset this analysis describes.
+	AssetID     string      `json:"asset_id"`
+	CreatedAt   time.Time   `json:"created_at"`
+	ID          string      `json:"id"`
+	ImpactCurve ImpactCurve `json:"impact_curve"`
+	// MTPDHours is the maximum tolerable period of disruption: past it the
+	// damage is unacceptable whatever it costs to avoid.
+	MtpdHours float64 `json:"mtpd_hours"`
+	Notes     string  `json:"notes,omitempty"`
+	Owner     string  `json:"owner,omitempty"`
+	// RPOHours is the tolerable data loss, in hours of work. 0 means none.
+	RpoHours float64 `json:"rpo_hours"`
+	// RTOHours is the committed recovery time; never above the MTPD.
+	RtoHours  float64   `json:"rto_hours"`
+	TenantID  string    `json:"tenant_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+}
+
+// BusinessRole is a named, least-privilege permission preset for a GRC job
+// role.
+type BusinessRole struct {
+	// DefaultLanding is the route the frontend redirects to after login for
+	// this role, so each profession lands on a screen relevant to its work.
+	DefaultLanding string          `json:"default_landing"`
+	DescriptionEn  string          `json:"description_en"`
+	DescriptionFr  string          `json:"description_fr"`
+	Key            BusinessRoleKey `json:"key"`
+	LabelEn        string          `json:"label_en"`
+	LabelFr        string          `json:"label_fr"`
+	Permissions    []string        `json:"permissions"`
+}
+
+// BusinessRoleKey identifies a preset. Stored on
+// OrganizationMember.BusinessRole.
+type BusinessRoleKey string
+
+const (
+	BusinessRoleKeyAssetOwner        BusinessRoleKey = "asset_owner"
+	BusinessRoleKeyAuditor           BusinessRoleKey = "auditor"
+	BusinessRoleKeyComplianceOfficer BusinessRoleKey = "compliance_officer"
+	BusinessRoleKeyDsi               BusinessRoleKey = "dsi"
+	BusinessRoleKeyExecutive         BusinessRoleKey = "executive"
+	BusinessRoleKeyInternalControl   BusinessRoleKey = "internal_control"
+	BusinessRoleKeyRiskManager       BusinessRoleKey = "risk_manager"
+	BusinessRoleKeyRiskOwner         BusinessRoleKey = "risk_owner"
+	BusinessRoleKeyRssi              BusinessRoleKey = "rssi"
+	BusinessRoleKeySecurityAnalyst   BusinessRoleKey = "security_analyst"
+	BusinessRoleKeyViewer            BusinessRoleKey = "viewer"
+)
+
+// CTIVulnerability represents a vulnerability pulled from external feeds
+// (NVD, CISA, MITRE). It acts as the core Domain model in the CTI package.
+type CTIVulnerability struct {
+	AffectedCpe     []string        `json:"affected_cpe"`
+	CisaDueDate     *time.Time      `json:"cisa_due_date,omitempty"`
+	CisaKnown       bool            `json:"cisa_known"`
+	CreatedAt       time.Time       `json:"created_at"`
+	CVEID           string          `json:"cve_id"`
+	CVSSV3          float64         `json:"cvss_v3"`
+	Description     string          `json:"description"`
+	LastUpdatedAt   time.Time       `json:"last_updated_at"`
+	MitreTactics    []string        `json:"mitre_tactics"`
+	MitreTechniques []string        `json:"mitre_techniques"`
+	PublishedAt     time.Time       `json:"published_at"`
+	References      json.RawMessage `json:"references"`
+	Remediation     string          `json:"remediation"`
+	// critical | high | medium | low
+	Severity  string    `json:"severity"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CapabilityInput is the body of PUT /bia/capabilities/:assetId.
+type CapabilityInput struct {
+	Basis        string     `json:"basis"`
+	LastTestedAt *time.Time `json:"last_tested_at,omitempty"`
+	Notes        string     `json:"notes"`
+	RpcHours     *float64   `json:"rpc_hours,omitempty"`
+	RtcHours     *float64   `json:"rtc_hours,omitempty"`
+}
+
+// CapacityInput is the body of PUT /programme-capacities/:userId.
+type CapacityInput struct {
+	Fte float64 `json:"fte"`
+}
+
+// CatalogSummary is what a client needs to offer catalogs for import —
+// no tenant/framework context, since catalogs are static reference data
+// shared by every tenant. JSON tags matter here: they must match
+// docs/openapi.yaml's ComplianceCatalogSummary schema (snake_case)
+// exactly, since the frontend's generated types are keyed off that spec.
+type CatalogSummary struct {
+	Available    bool   `json:"available"`
+	ControlCount int64  `json:"control_count"`
+	Description  string `json:"description"`
+	Key          string `json:"key"`
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+}
+
+// Category is a risk taxonomy entry, keyed by slug. An empty slug is
+// derived from the name, as the category form does.
+type Category struct {
+	Active      bool   `json:"active,omitempty"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	SortOrder   int64  `json:"sort_order"`
+}
+
+type CategoryInput struct {
+	Active      *bool  `json:"active,omitempty"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+	Name        string `json:"name"`
+	SortOrder   int64  `json:"sort_order"`
+}
+
+// CategoryTotal is one category of the consolidated register. Categories
+// are matched across entities by slug, which survives renames; risks
+// without a category share the empty slug.
+type CategoryTotal struct {
+	AvgScore      float64       `json:"avg_score"`
+	ByEntity      []EntityCount `json:"by_entity"`
+	Count         int64         `json:"count"`
+	CriticalRisks int64         `json:"critical_risks"`
+	HighRisks     int64         `json:"high_risks"`
+	Name          string        `json:"name"`
+	Slug          string        `json:"slug"`
+}
+
+type CelebratedInput struct {
+	StepKey string `json:"step_key"`
+}
+
+// ChainHop is one asset on a compromise chain, with how far it sits from
+// the origin.
+type ChainHop struct {
+	AssetID string `json:"asset_id"`
+	Depth   int64  `json:"depth"`
+}
+
+// Change is what an import does, or would do, to one item.
+type Change struct {
+	// create | update | unchanged
+	Action  string `json:"action"`
+	Key     string `json:"key"`
+	Section string `json:"section"`
+}
+
+// ChangeUserRoleRequest defines request body for changing user role
+type ChangeUserRoleRequest struct {
+	RoleID string `json:"role_id"`
+}
+
+// ChannelTestBody is channelTestBody names the channel to test.
+type ChannelTestBody struct {
+	Channel string `json:"channel"`
+}
+
+// ChannelTestResult is the outcome of testing one channel.
+type ChannelTestResult struct {
+	Channel string `json:"channel"`
+	// Configured says whether the tenant has the channel set up at all — the
+	// difference between "not configured" and "configured but broken", which
+	// is the first thing an operator needs to know.
+	Configured bool   `json:"configured"`
+	Delivered  bool   `json:"delivered"`
+	Detail     string `json:"detail"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+	// Recipients describes who would have received it, so a "delivered" result
+	// that went to the wrong place is still visible.
+	Recipients string    `json:"recipients,omitempty"`
+	TestedAt   time.Time `json:"tested_at"`
+}
+
+// ChannelsBody is channelsBody is the save payload. Webhook URLs are
+// write-only.
+type ChannelsBody struct {
+	DefaultEmail    string `json:"default_email"`
+	EmailEnabled    bool   `json:"email_enabled"`
+	SlackEnabled    bool   `json:"slack_enabled"`
+	SlackWebhookURL string `json:"slack_webhook_url"`
+	SmsAPIKey       string `json:"sms_api_key"`
+	SmsEnabled      bool   `json:"sms_enabled"`
+	SmsGatewayURL   string `json:"sms_gateway_url"`
+	SmsRecipients   string `json:"sms_recipients"`
+	SmsSender       string `json:"sms_sender"`
+	SmsSenderField  string `json:"sms_sender_field"`
+	SmsTextField    string `json:"sms_text_field"`
+	SmsToField      string `json:"sms_to_field"`
+	TeamsEnabled    bool   `json:"teams_enabled"`
+	TeamsWebhookURL string `json:"teams_webhook_url"`
+	WebhookEnabled  bool   `json:"webhook_enabled"`
+	WebhookSecret   string `json:"webhook_secret"`
+	WebhookURL      string `json:"webhook_url"`
+}
+
+type CheckPasswordRequest struct {
+	// Email/Name let the server judge the password against the identity it
+	// will belong to, the same way registration and reset do.
+	Email    string `json:"email,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Password string `json:"password"`
+}
+
+// CheckoutSession is a hosted payment URL the caller redirects the user
+// to.
+type CheckoutSession struct {
+	Provider  Provider `json:"provider"`
+	Reference string   `json:"reference"`
+	URL       string   `json:"url"`
+}
+
+// ChokePoint is an asset many of the returned paths go through. Fixing its
+// exploitable vulnerabilities raises the cost of every one of them at
+// once, which is what makes it the better remediation target than the
+// single highest-CVSS finding elsewhere.
+type ChokePoint struct {
+	AssetID        string              `json:"asset_id"`
+	EntryPoint     bool                `json:"entry_point"`
+	Exploitability AssetExploitability `json:"exploitability"`
+	Name           string              `json:"name"`
+	Paths          int64               `json:"paths"`
+	// Paths / len(returned paths)
+	Share float64 `json:"share"`
+}
+
+type ClassifyBody struct {
+	AffectedSubjects   int64      `json:"affected_subjects"`
+	AwareAt            *time.Time `json:"aware_at,omitempty"`
+	CrossBorder        bool       `json:"cross_border"`
+	DataCategories     []string   `json:"data_categories"`
+	Jurisdictions      []string   `json:"jurisdictions"`
+	LikelyConsequences string     `json:"likely_consequences"`
+	Major              bool       `json:"major"`
+	Notes              string     `json:"notes"`
+	PersonalData       bool       `json:"personal_data"`
+	Significant        bool       `json:"significant"`
+	SuspectedMalicious bool       `json:"suspected_malicious"`
+}
+
+// ClassifyRiskInput représente les données pour classer un risque
+type ClassifyRiskInput struct {
+	ConfigID string  `json:"config_id"`
+	Score    float64 `json:"score"`
+}
+
+// ClockAnchor says what a stage's deadline counts from.
+type ClockAnchor string
+
+const (
+	ClockAnchorAwareness      ClockAnchor = "awareness"
+	ClockAnchorClassification ClockAnchor = "classification"
+	ClockAnchorPreviousStage  ClockAnchor = "previous_stage"
+)
+
+// ClockView is a clock with the numbers the UI counts down from.
+type ClockView struct {
+	Authority       string    `json:"authority"`
+	ControlID       string    `json:"control_id,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	DueAt           time.Time `json:"due_at"`
+	EscalationLevel int64     `json:"escalation_level"`
+	// The control this obligation discharges, resolved from the regime's
+	// catalog in the tenant's imported framework. Nil when the catalog is not
+	// imported.
+	FrameworkID      string                `json:"framework_id,omitempty"`
+	ID               string                `json:"id"`
+	IncidentID       int64                 `json:"incident_id"`
+	Jurisdiction     string                `json:"jurisdiction"`
+	LastEscalatedAt  *time.Time            `json:"last_escalated_at,omitempty"`
+	NextEscalationAt *time.Time            `json:"next_escalation_at,omitempty"`
+	Overdue          bool                  `json:"overdue"`
+	ProfileID        string                `json:"profile_id"`
+	Regime           RegulatoryRegime      `json:"regime"`
+	RemainingSeconds int64                 `json:"remaining_seconds"`
+	Sequence         int64                 `json:"sequence"`
+	Stage            string                `json:"stage"`
+	StageLabel       string                `json:"stage_label"`
+	Status           RegulatoryClockStatus `json:"status"`
+	// SubmissionEvidenceID is the authority's receipt (or the sent
+	// notification) filed in the evidence library and linked to ControlID.
+	SubmissionEvidenceID string     `json:"submission_evidence_id,omitempty"`
+	SubmissionReference  string     `json:"submission_reference,omitempty"`
+	SubmittedAt          *time.Time `json:"submitted_at,omitempty"`
+	SubmittedBy          string     `json:"submitted_by,omitempty"`
+	// SubmittedContent freezes what was sent, so a later edit of the incident
+	// does not rewrite history.
+	SubmittedContent JSONMap   `json:"submitted_content,omitempty"`
+	SubmittedLate    bool      `json:"submitted_late"`
+	TenantID         string    `json:"tenant_id"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	WithdrawnReason  string    `json:"withdrawn_reason,omitempty"`
+}
+
+type CompletionSource string
+
+const (
+	CompletionSourceAI      CompletionSource = "ai"
+	CompletionSourceManual  CompletionSource = "manual"
+	CompletionSourceScanner CompletionSource = "scanner"
+)
+
+// ComplianceAudit is a tenant-scoped audit: planned, executed, then
+// archived as history. It may target a single framework (FrameworkID set)
+// or the whole compliance program (FrameworkID nil).
+type ComplianceAudit struct {
+	// auditor name or firm
+	Auditor     string     `json:"auditor"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// ComplianceScore is an optional posture snapshot (0–100) recorded when
+	// the audit is completed.
+	ComplianceScore float64   `json:"compliance_score"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       *string   `json:"created_by,omitempty"`
+	// nil = program-wide
+	FrameworkID    *string     `json:"framework_id,omitempty"`
+	ID             string      `json:"id"`
+	ScheduledEnd   *time.Time  `json:"scheduled_end,omitempty"`
+	ScheduledStart *time.Time  `json:"scheduled_start,omitempty"`
+	Scope          string      `json:"scope"`
+	Status         AuditStatus `json:"status"`
+	// conclusions / findings summary
+	Summary   string    `json:"summary"`
+	TenantID  string    `json:"tenant_id"`
+	Title     string    `json:"title"`
+	Type      AuditType `json:"type"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ComplianceControl is a tenant-scoped control linked to a global
+// framework.
+type ComplianceControl struct {
+	CreatedAt   time.Time `json:"created_at"`
+	Description string    `json:"description"`
+	// EvidenceCount is a computed, non-persisted count (gorm:"-": never a
+	// column) of the artifacts in the evidence library that CURRENTLY
+	// substantiate this control — expired and rejected proof excluded.
+	// Populated by the list/get use cases so the UI can show an evidence
+	// badge, and read by the "no implemented without proof" rule. There is
+	// deliberately no Evidences relation here any more. Evidence is reusable
+	// across controls (see domain.Evidence), so it cannot hang off one control
+	// by foreign key; a preload of the old control_evidences table would now
+	// return nothing for anything uploaded since migration 0052.
+	EvidenceCount int64 `json:"evidence_count"`
+	// ExternalID is the control's key in the tool it was imported from — the
+	// upsert key of a re-import. Empty for controls created here.
+	ExternalID string `json:"external_id,omitempty"`
+	// Relations
+	Framework   *ComplianceFramework `json:"framework,omitempty"`
+	FrameworkID string               `json:"framework_id"`
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	// e.g. "A.5.1.1"
+	ReferenceCode string `json:"reference_code"`
+	// SourceReference cites where this control comes from (e.g. "ISO/IEC
+	// 27001:2022, Annexe A, A.5.1" or a COBAC/BCEAO circular article) —
+	// required for controls imported from a regulatory catalog, optional for
+	// ad-hoc controls a tenant creates by hand.
+	SourceReference string        `json:"source_reference"`
+	Status          ControlStatus `json:"status"`
+	TenantID        string        `json:"tenant_id"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+}
+
+// ComplianceCoverage is one framework's completion — powers both the
+// compliance donuts and the control-coverage radar on the frontend.
+type ComplianceCoverage struct {
+	FrameworkID string  `json:"framework_id"`
+	Implemented int64   `json:"implemented"`
+	Name        string  `json:"name"`
+	Percent     float64 `json:"percent"`
+	Total       int64   `json:"total"`
+}
+
+// ComplianceFramework is a tenant-scoped entity: each tenant owns its own
+// frameworks. Examples: ISO 27001, SOC 2, NIST CSF, DORA, COBAC, BCEAO.
+// Two tenants can each hold their own "ISO 27001 / 2022" instance, and
+// deleting one tenant's framework never touches another's. Uniqueness of
+// (name, version) is enforced PER TENANT (partial unique index, migration
+// 0030), not globally.
+type ComplianceFramework struct {
+	// CatalogKey records which regulatory catalog (pkg/compliance) this
+	// framework was imported from, empty for one built by hand. It exists so
+	// the product can tell what a framework IS, not just what it was named.
+	// Curated crosswalks are defined between catalogs, so without this the
+	// only way to know that a framework called "SOC 2 (v2)" holds AICPA
+	// criteria would be to guess from its title — and inherited coverage
+	// would silently stop working the moment somebody renamed one.
+	CatalogKey string `json:"catalog_key"`
+	// Relations (loaded via Preload)
+	Controls    []ComplianceControl `json:"controls,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+	Description string              `json:"description"`
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	TenantID    string              `json:"tenant_id"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	Version     string              `json:"version"`
+}
+
+// ComplianceProgress is a computed DTO (not persisted) summarizing a
+// tenant's advancement on a framework. Mirrored client-side by
+// frontend/src/features/compliance/utils.ts's computeComplianceProgress
+// — keep the formula in sync if it changes here.
+type ComplianceProgress struct {
+	// Total minus not_applicable controls
+	Applicable      int64            `json:"Applicable"`
+	ByStatus        map[string]int64 `json:"ByStatus"`
+	FrameworkID     string           `json:"FrameworkID"`
+	PercentComplete float64          `json:"PercentComplete"`
+	Total           int64            `json:"Total"`
+}
+
+// CompromiseChain is the answer to "this asset is compromised — what
+// else is reachable from it?".
+type CompromiseChain struct {
+	// EdgeIDs is every edge on either path, so the view can highlight the
+	// chain without recomputing it.
+	EdgeIDs []string `json:"edge_ids"`
+	// Impacted are the assets that depend, directly or transitively, on the
+	// origin: what breaks if the origin falls.
+	Impacted []ChainHop `json:"impacted"`
+	OriginID string     `json:"origin_id"`
+	// Reachable are the assets the origin itself depends on or connects to:
+	// where an attacker standing on the origin could move next.
+	Reachable []ChainHop `json:"reachable"`
+}
+
+// ComputeRiskScoreInput représente les données pour calculer un score
+type ComputeRiskScoreInput struct {
+	ApplyTrend  bool     `json:"apply_trend"`
+	AssetIDs    []string `json:"asset_ids"`
+	ConfigID    string   `json:"config_id"`
+	Impact      int64    `json:"impact"`
+	Probability int64    `json:"probability"`
+	TrendFactor float64  `json:"trend_factor"`
+}
+
+// Connector represents a marketplace connector
+type Connector struct {
+	Author       string   `json:"author"`
+	Capabilities []string `json:"capabilities"`
+	Category     string   `json:"category"`
+	// Configuration schema for connector
+	ConfigSchema    map[string]any `json:"config_schema"`
+	CreatedAt       time.Time      `json:"created_at"`
+	Description     string         `json:"description"`
+	Documentation   string         `json:"documentation"`
+	Downloads       int64          `json:"downloads"`
+	Icon            string         `json:"icon"`
+	ID              string         `json:"id"`
+	InstallCount    int64          `json:"install_count"`
+	License         string         `json:"license"`
+	LongDescription string         `json:"long_description"`
+	Name            string         `json:"name"`
+	Rating          float64        `json:"rating"`
+	ReleaseDate     time.Time      `json:"release_date"`
+	// Permissions required by connector
+	RequiredPermissions []string `json:"required_permissions"`
+	// Reviews and ratings
+	Reviews      []ConnectorReview `json:"reviews,omitempty"`
+	SourceURL    string            `json:"source_url"`
+	Status       ConnectorStatus   `json:"status"`
+	SupportEmail string            `json:"support_email"`
+	// Supported frameworks
+	SupportedFrameworks []string  `json:"supported_frameworks"`
+	UpdatedAt           time.Time `json:"updated_at"`
+	Version             string    `json:"version"`
+}
+
+// ConnectorInfo describes a supported vulnerability integration for the
+// UI.
+type ConnectorInfo struct {
+	// network_scanner | edr | cloud | code
+	Category string `json:"category"`
+	// findings can be imported/normalised
+	Ingest bool   `json:"ingest"`
+	Label  string `json:"label"`
+	// API polling implemented (vs import-only)
+	LivePull bool       `json:"live_pull"`
+	Notes    string     `json:"notes"`
+	Source   VulnSource `json:"source"`
+}
+
+// ConnectorReview represents a review of a connector
+type ConnectorReview struct {
+	Author    string    `json:"author"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	// 1-5
+	Rating int64 `json:"rating"`
+}
+
+// ConnectorStatus represents the status of a connector
+type ConnectorStatus string
+
+const (
+	ConnectorStatusActive     ConnectorStatus = "active"
+	ConnectorStatusBeta       ConnectorStatus = "beta"
+	ConnectorStatusDeprecated ConnectorStatus = "deprecated"
+	ConnectorStatusInactive   ConnectorStatus = "inactive"
+)
+
+// Contribution is what one programme is expected to remove from a risk.
+type Contribution struct {
+	ExpectedReduction float64   `json:"expected_reduction"`
+	ProgrammeID       string    `json:"programme_id"`
+	ProjectedEnd      time.Time `json:"projected_end"`
+	// ReductionAtTarget is the reduction delivered by the target date at the
+	// current projection: the expected reduction scaled by the share of the
+	// work projected done by then.
+	ReductionAtTarget float64                `json:"reduction_at_target"`
+	SlipDays          int64                  `json:"slip_days"`
+	Status            DomainMitigationStatus `json:"status"`
+	TargetDate        *time.Time             `json:"target_date,omitempty"`
+	Title             string                 `json:"title"`
+}
+
+// ControlContribution is one mapped control's part in a risk's residual.
+type ControlContribution struct {
+	ControlID     string        `json:"control_id"`
+	Design        *float64      `json:"design,omitempty"`
+	FrameworkID   string        `json:"framework_id"`
+	Name          string        `json:"name"`
+	Operating     *float64      `json:"operating,omitempty"`
+	ReferenceCode string        `json:"reference_code"`
+	Status        ControlStatus `json:"status"`
+	Tested        bool          `json:"tested"`
+	Value         float64       `json:"value"`
+}
+
+// ControlCrosswalk is a tenant-scoped, undirected correspondence between
+// two compliance controls that (normally) live in different frameworks.
+// This is what lets the product answer the question that makes a second
+// framework bearable: "how much of this do I already have?" A tenant
+// importing SOC 2 with a mature ISO programme should be told, before they
+// do anything, that a large fraction of it is already answered by proof
+// they hold — and be able to see exactly which links produced that
+// number, and why. The pair is stored order-independent-safe: the
+// repository refuses a duplicate in either direction (A→B and B→A are
+// the same crosswalk).
+type ControlCrosswalk struct {
+	Coverage  CrosswalkCoverage `json:"coverage"`
+	CreatedAt time.Time         `json:"created_at"`
+	CreatedBy *string           `json:"created_by,omitempty"`
+	// No gen_random_uuid() default: the id is assigned in Go, and a
+	// Postgres-only default means the model cannot migrate under sqlite —
+	// which forces every test to hand-write its schema, which is how three of
+	// them drifted from the struct they were mirroring. Same fix as
+	// domain.AuditEvent.
+	ID     string          `json:"id"`
+	Origin CrosswalkOrigin `json:"origin"`
+	// Rationale says WHY the two correspond. Required, and not decoration: the
+	// number it feeds is one an auditor will push back on, so the tenant has
+	// to be able to read the reasoning, accept it, argue with it, or delete
+	// the link.
+	Rationale string `json:"rationale"`
+	// Computed, NOT persisted — filled by the list use case so the UI can
+	// render each side without extra round-trips.
+	SourceCode          string    `json:"source_code,omitempty"`
+	SourceControlID     string    `json:"source_control_id"`
+	SourceFrameworkID   string    `json:"source_framework_id,omitempty"`
+	SourceFrameworkName string    `json:"source_framework_name,omitempty"`
+	SourceName          string    `json:"source_name,omitempty"`
+	TargetCode          string    `json:"target_code,omitempty"`
+	TargetControlID     string    `json:"target_control_id"`
+	TargetFrameworkID   string    `json:"target_framework_id,omitempty"`
+	TargetFrameworkName string    `json:"target_framework_name,omitempty"`
+	TargetName          string    `json:"target_name,omitempty"`
+	TenantID            string    `json:"tenant_id"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// ControlLinkInput is the body of POST
+// /mitigation-programmes/:id/controls.
+type ControlLinkInput struct {
+	ControlID string `json:"control_id"`
+}
+
+// ControlStatus represents the implementation state of a compliance
+// control.
+type ControlStatus string
+
+const (
+	ControlStatusImplemented    ControlStatus = "implemented"
+	ControlStatusInProgress     ControlStatus = "in_progress"
+	ControlStatusNotApplicable  ControlStatus = "not_applicable"
+	ControlStatusNotImplemented ControlStatus = "not_implemented"
+)
+
+// ControlTest is one execution of a plan.
+type ControlTest struct {
+	ControlID string    `json:"control_id"`
+	CreatedAt time.Time `json:"created_at"`
+	// Effectiveness is Score() at the time of the test, kept so a later change
+	// of the formula never rewrites history.
+	Effectiveness float64           `json:"effectiveness"`
+	Exceptions    int64             `json:"exceptions"`
+	ID            string            `json:"id"`
+	Kind          ControlTestKind   `json:"kind"`
+	Notes         string            `json:"notes"`
+	PerformedAt   time.Time         `json:"performed_at"`
+	PlanID        string            `json:"plan_id"`
+	Result        ControlTestResult `json:"result"`
+	SamplesTested int64             `json:"samples_tested"`
+	TenantID      string            `json:"tenant_id"`
+	TesterID      string            `json:"tester_id,omitempty"`
+}
+
+// ControlTestKind is what a plan tests.
+type ControlTestKind string
+
+const (
+	ControlTestKindDesign    ControlTestKind = "design"
+	ControlTestKindOperating ControlTestKind = "operating"
+)
+
+// ControlTestPlan is how one control is tested, at most one plan per
+// control and kind.
+type ControlTestPlan struct {
+	ControlID string    `json:"control_id"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	// Due is computed on read: the next test date has passed.
+	Due               bool              `json:"due"`
+	FrequencyDays     int64             `json:"frequency_days"`
+	ID                string            `json:"id"`
+	Kind              ControlTestKind   `json:"kind"`
+	LastEffectiveness float64           `json:"last_effectiveness,omitempty"`
+	LastResult        ControlTestResult `json:"last_result,omitempty"`
+	// The latest test, denormalised so effectiveness is one read per control.
+	LastTestedAt *time.Time `json:"last_tested_at,omitempty"`
+	NextTestAt   time.Time  `json:"next_test_at"`
+	Procedure    string     `json:"procedure"`
+	// SampleSize is how many occurrences each test examines. A design
+	// walkthrough is one sample.
+	SampleSize int64     `json:"sample_size"`
+	TenantID   string    `json:"tenant_id"`
+	TesterID   string    `json:"tester_id,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ControlTestResult is the tester's conclusion.
+type ControlTestResult string
+
+const (
+	ControlTestResultExceptions ControlTestResult = "exceptions"
+	ControlTestResultFail       ControlTestResult = "fail"
+	ControlTestResultPass       ControlTestResult = "pass"
+)
+
+// ControlTesting is everything GET /compliance/controls/:controlId/testing
+// shows: the plans, the latest tests and the effectiveness they add up to.
+type ControlTesting struct {
+	ControlID     string            `json:"control_id"`
+	Effectiveness Effectiveness     `json:"effectiveness"`
+	Plans         []ControlTestPlan `json:"plans"`
+	Tests         []ControlTest     `json:"tests"`
+}
+
+// ControltestPlanInput is planInput is the body of POST
+// /compliance/controls/:controlId/test-plans and PUT
+// /control-test-plans/:id. NextTestAt defaults to now: a new plan is due
+// straight away, because until it is tested the control earns no credit.
+type ControltestPlanInput struct {
+	FrequencyDays int64           `json:"frequency_days"`
+	Kind          ControlTestKind `json:"kind"`
+	NextTestAt    *time.Time      `json:"next_test_at,omitempty"`
+	Procedure     string          `json:"procedure"`
+	SampleSize    int64           `json:"sample_size"`
+	TesterID      *string         `json:"tester_id,omitempty"`
+}
+
+// CorrectiveAction is a decision made in the review that must actually
+// happen. The whole point of the field is that it does not stay in a
+// document: publishing the post-mortem turns each action into a real
+// mitigation plan, with an owner and a due date, in the module that tracks
+// those.
+type CorrectiveAction struct {
+	Description string     `json:"description,omitempty"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	ID          string     `json:"id"`
+	// MitigationID is filled once the action has been converted, so the
+	// post-mortem can link to the plan instead of duplicating its state.
+	MitigationID string `json:"mitigation_id,omitempty"`
+	OwnerID      string `json:"owner_id,omitempty"`
+	// critical|high|medium|low
+	Priority string `json:"priority,omitempty"`
+	// RiskID is the risk the mitigation was attached to.
+	RiskID string `json:"risk_id,omitempty"`
+	Status string `json:"status"`
+	Title  string `json:"title"`
+}
+
+// CorrectiveActionList is a jsonb array.
+type CorrectiveActionList []CorrectiveAction
+
+// CoverageReport is GET /risk-scenarios/coverage.
+type CoverageReport struct {
+	LibraryVersion string              `json:"library_version"`
+	Summary        CoverageSummary     `json:"summary"`
+	Techniques     []TechniqueCoverage `json:"techniques"`
+}
+
+// CoverageStatus is how well the tenant's controls answer a technique.
+type CoverageStatus string
+
+const (
+	CoverageStatusCovered   CoverageStatus = "covered"
+	CoverageStatusPartial   CoverageStatus = "partial"
+	CoverageStatusUncovered CoverageStatus = "uncovered"
+)
+
+// CoverageSummary counts techniques by status.
+type CoverageSummary struct {
+	Covered    int64 `json:"covered"`
+	Partial    int64 `json:"partial"`
+	Techniques int64 `json:"techniques"`
+	Uncovered  int64 `json:"uncovered"`
+}
+
+// CoveredControl is a tenant control answering a reference.
+type CoveredControl struct {
+	ControlID   string        `json:"control_id"`
+	FrameworkID string        `json:"framework_id"`
+	Name        string        `json:"name"`
+	Status      ControlStatus `json:"status"`
+}
+
+type CreateAssetDependencyInput struct {
+	Description   string `json:"description"`
+	SourceAssetID string `json:"source_asset_id"`
+	TargetAssetID string `json:"target_asset_id"`
+	Type          string `json:"type"`
+}
+
+type CreateAssetInput struct {
+	Attributes map[string]any `json:"attributes"`
+	// BusinessUnit — the part of the organisation the asset belongs to.
+	BusinessUnit string `json:"business_unit"`
+	// Category is NOT validated with `oneof` here: the authoritative list
+	// lives in domain.AssetCategories, and duplicating it in a struct tag is
+	// how the two drift. The use case parses it and returns a named validation
+	// error.
+	Category    string `json:"category"`
+	Criticality string `json:"criticality"`
+	Name        string `json:"name"`
+	Owner       string `json:"owner"`
+	Type        string `json:"type"`
+}
+
+type CreateAuditBody struct {
+	Auditor        string `json:"auditor"`
+	FrameworkID    string `json:"framework_id"`
+	ScheduledEnd   string `json:"scheduled_end"`
+	ScheduledStart string `json:"scheduled_start"`
+	Scope          string `json:"scope"`
+	Title          string `json:"title"`
+	Type           string `json:"type"`
+}
+
+// CreateBulkOperationRequest represents a request to create a bulk
+// operation
+type CreateBulkOperationRequest struct {
+	// For export operations
+	ExportFormat string `json:"export_format,omitempty"`
+	// MongoDB-like filter
+	FilterQuery map[string]any `json:"filter_query"`
+	// For assign operations
+	MitigationID  string            `json:"mitigation_id,omitempty"`
+	OperationType BulkOperationType `json:"operation_type"`
+	// For update operations
+	UpdateData map[string]any `json:"update_data,omitempty"`
+}
+
+type CreateControlInput struct {
+	Description   string `json:"description"`
+	Name          string `json:"name"`
+	ReferenceCode string `json:"reference_code"`
+}
+
+type CreateCrosswalkInput struct {
+	Coverage        string `json:"coverage"`
+	Rationale       string `json:"rationale"`
+	SourceControlID string `json:"source_control_id"`
+	TargetControlID string `json:"target_control_id"`
+}
+
+// CreateCustomFieldRequest is the request to create a custom field
+type CreateCustomFieldRequest struct {
+	DefaultValue string                 `json:"default_value,omitempty"`
+	Description  string                 `json:"description"`
+	DisplayName  string                 `json:"display_name"`
+	FieldType    CustomFieldType        `json:"field_type"`
+	Name         string                 `json:"name"`
+	Placeholder  string                 `json:"placeholder,omitempty"`
+	Position     int64                  `json:"position"`
+	ReadOnly     bool                   `json:"read_only"`
+	Scope        CustomFieldScope       `json:"scope"`
+	Validation   *CustomFieldValidation `json:"validation,omitempty"`
+	Visible      bool                   `json:"visible"`
+}
+
+type CreateDelegationBody struct {
+	DelegateID string `json:"delegate_id"`
+	// optional; defaults to the caller
+	DelegatorID string   `json:"delegator_id"`
+	EndsAt      string   `json:"ends_at"`
+	Permissions []string `json:"permissions"`
+	Reason      string   `json:"reason"`
+	StartsAt    string   `json:"starts_at"`
+}
+
+type CreateFrameworkInput struct {
+	Description string `json:"description"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+}
+
+type CreatePATRequest struct {
+	Description   string   `json:"description"`
+	ExpiresInDays int64    `json:"expires_in_days,omitempty"`
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+}
+
+type CreateRemediationBody struct {
+	AssignedTo  string `json:"assigned_to"`
+	AuditID     string `json:"audit_id"`
+	ControlID   string `json:"control_id"`
+	Description string `json:"description"`
+	DueDate     string `json:"due_date"`
+	Priority    string `json:"priority"`
+	Title       string `json:"title"`
+}
+
+type CreateReportJobInput struct {
+	Kind   string         `json:"kind"`
+	Params map[string]any `json:"params"`
+}
+
+// CreateRiskInput : DTO pour séparer la logique API de la logique DB
+type CreateRiskInput struct {
+	Aro *float64 `json:"aro,omitempty"`
+	// Liste des UUIDs des assets concernés
+	AssetIDs   []string        `json:"asset_ids"`
+	AssigneeID json.RawMessage `json:"assignee_id"`
+	// BusinessUnit — the owning entity, free text. Optional.
+	BusinessUnit string `json:"business_unit"`
+	// CategoryID is the tenant's CONTROLLED classification. Optional — a
+	// risk stays creatable without one, exactly like the compliance mapping.
+	CategoryID *string `json:"category_id,omitempty"`
+	// Classification — confidentiality level, free text. Optional.
+	Classification  string   `json:"classification"`
+	DataLossCostXaf *float64 `json:"data_loss_cost_xaf,omitempty"`
+	Description     string   `json:"description"`
+	// Full financial-quantification drivers (spec §9). All optional XAF
+	// amounts.
+	DowntimeHours         *float64 `json:"downtime_hours,omitempty"`
+	FinesXaf              *float64 `json:"fines_xaf,omitempty"`
+	Frameworks            []string `json:"frameworks"`
+	HourlyDowntimeCostXaf *float64 `json:"hourly_downtime_cost_xaf,omitempty"`
+	// ERD numeric(5,1) — bounds [0,10]
+	Impact                  float64         `json:"impact"`
+	MitigationEffectiveness *float64        `json:"mitigation_effectiveness,omitempty"`
+	OtherDirectCostXaf      *float64        `json:"other_direct_cost_xaf,omitempty"`
+	OwnerID                 json.RawMessage `json:"owner_id"`
+	// ERD numeric(5,3) — bounds [0,1]
+	Probability        float64         `json:"probability"`
+	RemediationCostXaf *float64        `json:"remediation_cost_xaf,omitempty"`
+	ReviewerID         json.RawMessage `json:"reviewer_id"`
+	// CRQ monetary inputs (XAF). Optional.
+	SleXaf *float64 `json:"sle_xaf,omitempty"`
+	Tags   []string `json:"tags"`
+	Title  string   `json:"title"`
+}
+
+type CreateRiskMappingInput struct {
+	ControlID   *string `json:"control_id,omitempty"`
+	FrameworkID string  `json:"framework_id"`
+	Note        string  `json:"note"`
+}
+
+// CreateRoleRequest defines request body for creating a role
+type CreateRoleRequest struct {
+	Description string `json:"description"`
+	Level       int64  `json:"level"`
+	Name        string `json:"name"`
+}
+
+type CreateScanConfigInput struct {
+	AgentIDs        []string          `json:"agent_ids"`
+	Credentials     map[string]string `json:"credentials"`
+	Name            string            `json:"name"`
+	Options         map[string]any    `json:"options"`
+	Provider        string            `json:"provider"`
+	Regions         []string          `json:"regions"`
+	ScheduleMinutes int64             `json:"schedule_minutes"`
+	Targets         []string          `json:"targets"`
+}
+
+// CreateScoringConfigInput représente la structure pour créer une
+// configuration
+type CreateScoringConfigInput struct {
+	AssetCriticalityMult map[string]float64 `json:"asset_criticality_mult"`
+	BaseFormula          string             `json:"base_formula"`
+	Description          string             `json:"description"`
+	Name                 string             `json:"name"`
+	RiskMatrixThresholds map[string]int64   `json:"risk_matrix_thresholds"`
+	WeightingFactors     map[string]float64 `json:"weighting_factors"`
+}
+
+type CreateTeamInput struct {
+	Description string `json:"description"`
+	Name        string `json:"name"`
+}
+
+// CreateTenantRequest defines request body for creating a tenant
+type CreateTenantRequest struct {
+	Metadata map[string]any `json:"metadata"`
+	Name     string         `json:"name"`
+	Slug     string         `json:"slug"`
+}
+
+type CreateUserInput struct {
+	Department string `json:"department,omitempty"`
+	Email      string `json:"email"`
+	FullName   string `json:"full_name"`
+	Password   string `json:"password"`
+	// admin, analyst, viewer
+	Role     string `json:"role"`
+	Username string `json:"username"`
+}
+
+// CriticalityBucket is the aggregated annual loss for one criticality
+// band.
+type CriticalityBucket struct {
+	Ale         Money  `json:"ale"`
+	Count       int64  `json:"count"`
+	Criticality string `json:"criticality"`
+}
+
+// CrosswalkCoverage says how much of the target control the source control
+// answers — the question the whole feature turns on. Two values, not the
+// previous three (equivalent / partial / related). "Related" was unusable:
+// it fed no decision, because thematic relatedness does not tell anyone
+// whether their existing proof can be reused. What a compliance officer
+// actually asks is binary — can I reuse this as it stands, or is there
+// something left to demonstrate — and that is full versus partial.
+type CrosswalkCoverage string
+
+const (
+	CrosswalkCoverageFull    CrosswalkCoverage = "full"
+	CrosswalkCoveragePartial CrosswalkCoverage = "partial"
+)
+
+// CrosswalkOrigin records who asserted the link. It matters because the
+// two carry different authority. A curated link is the product's editorial
+// judgement, shipped with the catalogs; a manual one is the tenant's own.
+// Showing which is which is what lets someone review what the product
+// claimed on their behalf instead of inheriting it silently.
+type CrosswalkOrigin string
+
+const (
+	CrosswalkOriginCurated CrosswalkOrigin = "curated"
+	CrosswalkOriginManual  CrosswalkOrigin = "manual"
+)
+
+// Currency is an ISO-4217 code the platform can display. The base/store
+// currency is always XAF; the others are display targets converted at the
+// reference rate.
+type Currency string
+
+const (
+	CurrencyEUR Currency = "EUR"
+	CurrencyGHS Currency = "GHS"
+	CurrencyMAD Currency = "MAD"
+	CurrencyNGN Currency = "NGN"
+	CurrencyUSD Currency = "USD"
+	CurrencyXAF Currency = "XAF"
+	CurrencyXOF Currency = "XOF"
+	CurrencyZAR Currency = "ZAR"
+)
+
+// CustodyAction is one kind of handling of an evidence artifact.
+type CustodyAction string
+
+const (
+	CustodyActionCollected        CustodyAction = "collected"
+	CustodyActionDownloaded       CustodyAction = "downloaded"
+	CustodyActionIntegrityFailure CustodyAction = "integrity_failure"
+	CustodyActionPackaged         CustodyAction = "packaged"
+	CustodyActionReplaced         CustodyAction = "replaced"
+	CustodyActionReviewed         CustodyAction = "reviewed"
+	CustodyActionVerified         CustodyAction = "verified"
+	CustodyActionViewed           CustodyAction = "viewed"
+)
+
+// CustomField defines a custom field that can be added to risks or assets.
+// TenantID scopes every field to one tenant (RULE #2); the
+// (tenant_id,name,scope) unique index lets two tenants each define a
+// "Department" field independently.
+type CustomField struct {
+	CreatedAt time.Time `json:"created_at"`
+	// Metadata
+	CreatedBy string `json:"created_by"`
+	// Field configuration
+	DefaultValue string          `json:"default_value,omitempty"`
+	Description  string          `json:"description"`
+	DisplayName  string          `json:"display_name"`
+	FieldType    CustomFieldType `json:"field_type"`
+	ID           string          `json:"id"`
+	// e.g., "Risk ID", "Department"
+	Name        string `json:"name"`
+	Placeholder string `json:"placeholder,omitempty"`
+	// Display settings
+	Position int64 `json:"position"`
+	ReadOnly bool  `json:"read_only"`
+	// "risk" or "asset"
+	Scope      CustomFieldScope `json:"scope"`
+	TenantID   string           `json:"tenant_id"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+	Validation json.RawMessage  `json:"validation,omitempty"`
+	Visible    bool             `json:"visible"`
+}
+
+// CustomFieldScope represents what entity a field applies to
+type CustomFieldScope string
+
+const (
+	CustomFieldScopeAsset CustomFieldScope = "asset"
+	CustomFieldScopeRisk  CustomFieldScope = "risk"
+)
+
+// CustomFieldType represents the data type of a custom field
+type CustomFieldType string
+
+const (
+	CustomFieldTypeCheckbox CustomFieldType = "checkbox"
+	CustomFieldTypeChoice   CustomFieldType = "choice"
+	CustomFieldTypeDate     CustomFieldType = "date"
+	CustomFieldTypeNumber   CustomFieldType = "number"
+	CustomFieldTypeText     CustomFieldType = "text"
+	CustomFieldTypeTextarea CustomFieldType = "textarea"
+)
+
+// CustomFieldValidation defines validation rules for a field
+type CustomFieldValidation struct {
+	AllowedValues []string `json:"allowed_values,omitempty"`
+	Max           float64  `json:"max,omitempty"`
+	MaxLength     int64    `json:"max_length,omitempty"`
+	Min           float64  `json:"min,omitempty"`
+	MinLength     int64    `json:"min_length,omitempty"`
+	// Regex pattern
+	Pattern  string `json:"pattern,omitempty"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// CyberScore is the executive posture grade: a single 0–100 number plus
+// an A–F letter, backed by the weighted components that produced it.
+// Higher is safer.
+type CyberScore struct {
+	Components []ScoreComponent `json:"components"`
+	// A..F
+	Grade string `json:"grade"`
+	// human posture label
+	Label string `json:"label"`
+	// 0..100 (higher = safer)
+	Score int64 `json:"score"`
+}
+
+// DashboardAnalytics combines all dashboard data
+type DashboardAnalytics struct {
+	GeneratedAt          time.Time            `json:"generated_at"`
+	Metrics              json.RawMessage      `json:"metrics,omitempty"`
+	MitigationProgress   []MitigationProgress `json:"mitigation_progress"`
+	MitigationStatus     json.RawMessage      `json:"mitigation_status,omitempty"`
+	RiskTrends           []RiskTrendDataPoint `json:"risk_trends"`
+	SeverityDistribution json.RawMessage      `json:"severity_distribution,omitempty"`
+	TopRisks             []TopRisk            `json:"top_risks"`
+}
+
+// DashboardKRI is krI is one key risk indicator card. Severity drives the
+// color (ok/warn/critical).
+type DashboardKRI struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	// ok | warn | critical
+	Severity string `json:"severity"`
+	// "", "days", "%"
+	Unit  string  `json:"unit"`
+	Value float64 `json:"value"`
+}
+
+// DashboardMetrics represents KPI metrics for the dashboard
+type DashboardMetrics struct {
+	ActiveRisks       int64     `json:"active_risks"`
+	AverageRiskScore  float64   `json:"average_risk_score"`
+	MitigationRate    float64   `json:"mitigation_rate"`
+	OverdueCount      int64     `json:"overdue_count"`
+	SLAComplianceRate float64   `json:"sla_compliance_rate"`
+	TotalRisks        int64     `json:"total_risks"`
+	TrendingUpPercent float64   `json:"trending_up_percent"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// DashboardSnapshot represents a complete dashboard snapshot
+type DashboardSnapshot struct {
+	FrameworkAnalytics []FrameworkAnalytics `json:"framework_analytics"`
+	MitigationMetrics  json.RawMessage      `json:"mitigation_metrics,omitempty"`
+	RiskMetrics        json.RawMessage      `json:"risk_metrics,omitempty"`
+	Timestamp          time.Time            `json:"timestamp"`
+	Trends             []RiskTrendPoint     `json:"trends"`
+}
+
+// DashboardStats is the tenant's headline posture. Every field is a
+// server-side aggregate over the whole register, never a count over a page
+// of results: the dashboard is paginated and a client-side
+// .filter().length silently reports the current page as if it were the
+// tenant.
+type DashboardStats struct {
+	// 100 = safe, 0 = danger
+	GlobalRiskScore int64 `json:"global_risk_score"`
+	HighRisks       int64 `json:"high_risks"`
+	InProgressRisks int64 `json:"in_progress_risks"`
+	MitigatedRisks  int64 `json:"mitigated_risks"`
+	// risks carrying financial drivers
+	QuantifiedRisks int64            `json:"quantified_risks"`
+	RiskMatrix      []MatrixCell     `json:"risk_matrix"`
+	RisksBySeverity map[string]int64 `json:"risks_by_severity"`
+	TotalRisks      int64            `json:"total_risks"`
+}
+
+// DataimportReport is report is what a dry run found, or what an apply
+// did.
+type DataimportReport struct {
+	BatchID string            `json:"batch_id"`
+	Creates int64             `json:"creates"`
+	Errors  []ImportRowError  `json:"errors"`
+	Rows    int64             `json:"rows"`
+	Status  ImportBatchStatus `json:"status"`
+	Updates int64             `json:"updates"`
+	Valid   int64             `json:"valid"`
+}
+
+type DecideApprovalBody struct {
+	Comment  string `json:"comment"`
+	Decision string `json:"decision"`
+	// StepOrder targets one branch of a parallel chain. Omitted in sequential
+	// mode, where there is only ever one open step.
+	StepOrder *int64 `json:"step_order,omitempty"`
+}
+
+// Delegation is a time-boxed grant of the delegator's rights (a permission
+// subset, or "*") to another user. Effective only while active and within
+// [StartsAt, EndsAt]. Resolved live by the ResolveEffectivePermissions use
+// case.
+type Delegation struct {
+	CreatedAt      time.Time        `json:"created_at"`
+	CreatedBy      string           `json:"created_by"`
+	DelegateEmail  string           `json:"delegate_email,omitempty"`
+	DelegateID     string           `json:"delegate_id"`
+	DelegatorEmail string           `json:"delegator_email,omitempty"`
+	DelegatorID    string           `json:"delegator_id"`
+	EndsAt         time.Time        `json:"ends_at"`
+	ID             string           `json:"id"`
+	Permissions    StringList       `json:"permissions"`
+	Reason         string           `json:"reason,omitempty"`
+	RevokedAt      *time.Time       `json:"revoked_at,omitempty"`
+	StartsAt       time.Time        `json:"starts_at"`
+	Status         DelegationStatus `json:"status"`
+	TenantID       string           `json:"tenant_id"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+// DelegationStatus is the lifecycle of a delegation.
+type DelegationStatus string
+
+const (
+	DelegationStatusActive  DelegationStatus = "active"
+	DelegationStatusRevoked DelegationStatus = "revoked"
+)
+
+type DeletionInput struct {
+	ConfirmName string `json:"confirm_name"`
+	MFACode     string `json:"mfa_code"`
+	Reason      string `json:"reason"`
+}
+
+// Delivery reports what happened to the assessment email.
+type Delivery string
+
+const (
+	DeliveryFailed      Delivery = "failed"
+	DeliveryManual      Delivery = "manual"
+	DeliverySent        Delivery = "sent"
+	DeliveryUnavailable Delivery = "unavailable"
+)
+
+// DeliveryStatus reports what actually happened to the invitation email.
+// It is returned to the inviting administrator so the UI can say "sent"
+// only when something was sent.
+type DeliveryStatus string
+
+const (
+	DeliveryStatusFailed      DeliveryStatus = "failed"
+	DeliveryStatusSent        DeliveryStatus = "sent"
+	DeliveryStatusUnavailable DeliveryStatus = "unavailable"
+)
+
+// DependencyType classifies HOW one asset depends on another. It is a
+// small, documented vocabulary (kept flexible on the wire) that powers the
+// asset dependency map ("cartographie des dépendances"): an edge Source
+// --Type--> Target reads "Source <type> Target", e.g. "web-app RUNS_ON
+// srv-01".
+type DependencyType string
+
+const (
+	DependencyTypeAuthenticatesVia DependencyType = "authenticates_via"
+	DependencyTypeBacksUpTo        DependencyType = "backs_up_to"
+	DependencyTypeConnectsTo       DependencyType = "connects_to"
+	DependencyTypeDependsOn        DependencyType = "depends_on"
+	DependencyTypeHostedBy         DependencyType = "hosted_by"
+	DependencyTypeHostedOn         DependencyType = "hosted_on"
+	DependencyTypeManagedBy        DependencyType = "managed_by"
+	DependencyTypeProcessesDataOf  DependencyType = "processes_data_of"
+	DependencyTypeRunsOn           DependencyType = "runs_on"
+	DependencyTypeStoresDataIn     DependencyType = "stores_data_in"
+)
+
+// DestinationInput creates or updates a destination. Nil fields are
+// unchanged on update. Token is the HEC token; an empty string keeps the
+// stored one.
+type DestinationInput struct {
+	CaCertPem *string  `json:"ca_cert_pem,omitempty"`
+	Enabled   *bool    `json:"enabled,omitempty"`
+	Endpoint  *string  `json:"endpoint,omitempty"`
+	Format    *string  `json:"format,omitempty"`
+	Name      *string  `json:"name,omitempty"`
+	Sources   []string `json:"sources"`
+	Token     *string  `json:"token,omitempty"`
+	Transport *string  `json:"transport,omitempty"`
+}
+
+// Detail is everything GET /mitigation-programmes/:id shows.
+type Detail struct {
+	Actions   []ProgrammeAction `json:"actions"`
+	Controls  []LinkedControl   `json:"controls"`
+	Programme json.RawMessage   `json:"programme,omitempty"`
+	Risks     []LinkedRisk      `json:"risks"`
+	Schedule  json.RawMessage   `json:"schedule,omitempty"`
+}
+
+// Diff is what changed between two registers. To is nil when the later
+// side is the live register rather than a snapshot.
+type Diff struct {
+	Closed     []RegisterDiffEntry `json:"closed"`
+	DownScored []RegisterDiffEntry `json:"down_scored"`
+	From       json.RawMessage     `json:"from,omitempty"`
+	New        []RegisterDiffEntry `json:"new"`
+	Summary    RegisterDiffSummary `json:"summary"`
+	To         json.RawMessage     `json:"to,omitempty"`
+	UpScored   []RegisterDiffEntry `json:"up_scored"`
+}
+
+// DisableMFAOutput represents MFA disable response
+type DisableMFAOutput struct {
+	Message string `json:"message"`
+}
+
+// DistributionAmounts is the loss band expressed as currency-aware
+// Amounts.
+type DistributionAmounts struct {
+	FormulaVersion string  `json:"formula_version"`
+	Iterations     int64   `json:"iterations"`
+	Lef            float64 `json:"lef"`
+	Mean           Amount  `json:"mean"`
+	P10            Amount  `json:"p10"`
+	P50            Amount  `json:"p50"`
+	P90            Amount  `json:"p90"`
+	Seed           int64   `json:"seed"`
+}
+
+// DistributionSlice is one wedge of the risk-by-criticality donut.
+type DistributionSlice struct {
+	Count       int64  `json:"count"`
+	Criticality string `json:"criticality"`
+}
+
+// Document is a tenant's configuration. A section left out of an imported
+// document is not touched, and an import never deletes what the document
+// does not mention.
+type Document struct {
+	APIVersion      string     `json:"api_version"`
+	AssetSchemas    []Schema   `json:"asset_schemas,omitempty"`
+	AutomationRules []Rule     `json:"automation_rules,omitempty"`
+	Kind            string     `json:"kind"`
+	RiskCategories  []Category `json:"risk_categories,omitempty"`
+	ScoringWeights  *Weights   `json:"scoring_weights,omitempty"`
+}
+
+// DomainCriticalityLevel is criticalityLevel represents the severity level
+// calculated from score
+type DomainCriticalityLevel string
+
+const (
+	DomainCriticalityLevelCRITICAL DomainCriticalityLevel = "CRITICAL"
+	DomainCriticalityLevelHIGH     DomainCriticalityLevel = "HIGH"
+	DomainCriticalityLevelLOW      DomainCriticalityLevel = "LOW"
+	DomainCriticalityLevelMEDIUM   DomainCriticalityLevel = "MEDIUM"
+	DomainCriticalityLevelCritical DomainCriticalityLevel = "critical"
+	DomainCriticalityLevelHigh     DomainCriticalityLevel = "high"
+	DomainCriticalityLevelLow      DomainCriticalityLevel = "low"
+	DomainCriticalityLevelMedium   DomainCriticalityLevel = "medium"
+)
+
+// DomainKRI is krI is a key risk indicator.
+type DomainKRI struct {
+	AmberThreshold float64 `json:"amber_threshold"`
+	// BumpProbability raises each linked risk's probability by ProbabilityStep
+	// (and puts it up for review) when the KRI crosses into red.
+	BumpProbability bool         `json:"bump_probability"`
+	CreatedAt       time.Time    `json:"created_at"`
+	CreatedBy       string       `json:"created_by,omitempty"`
+	Description     string       `json:"description"`
+	Direction       KRIDirection `json:"direction"`
+	ID              string       `json:"id"`
+	// LastError is the last query evaluation failure, cleared on success.
+	LastError       string     `json:"last_error,omitempty"`
+	LastMeasuredAt  *time.Time `json:"last_measured_at,omitempty"`
+	LastValue       *float64   `json:"last_value,omitempty"`
+	Name            string     `json:"name"`
+	ProbabilityStep float64    `json:"probability_step"`
+	// Query is read only when Source is query.
+	Query          KRIQuery `json:"query"`
+	RedThreshold   float64  `json:"red_threshold"`
+	RefreshMinutes int64    `json:"refresh_minutes"`
+	// RiskIDs are the risks this indicator is an early warning for.
+	RiskIDs StringList `json:"risk_ids"`
+	Source  KRISource  `json:"source"`
+	// Current state, denormalised from the latest value.
+	Status    KRIStatus `json:"status"`
+	TenantID  string    `json:"tenant_id"`
+	Unit      string    `json:"unit"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type DomainMitigationStatus string
+
+const (
+	DomainMitigationStatusCANCELLED  DomainMitigationStatus = "CANCELLED"
+	DomainMitigationStatusDONE       DomainMitigationStatus = "DONE"
+	DomainMitigationStatusINPROGRESS DomainMitigationStatus = "IN_PROGRESS"
+	DomainMitigationStatusPLANNED    DomainMitigationStatus = "PLANNED"
+	DomainMitigationStatusREVIEW     DomainMitigationStatus = "REVIEW"
+)
+
+// DomainReport is report is one generated document: its request, its
+// bytes, its integrity hash and its editorial state.
+type DomainReport struct {
+	ApprovedAt      *time.Time `json:"approved_at,omitempty"`
+	ApprovedBy      string     `json:"approved_by,omitempty"`
+	ApprovedByEmail string     `json:"approved_by_email,omitempty"`
+	// Comments are the review trail, loaded on the detail read.
+	Comments    []ReportComment `json:"comments,omitempty"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+	// ContentFingerprint is SHA-256 of what the report SAYS — the data that
+	// went into it — and it is the value printed on the document itself. It
+	// has to be a different number from ContentHash, and the reason is
+	// arithmetic rather than preference: a file cannot contain the hash of
+	// itself, because printing the hash changes the bytes being hashed. An
+	// earlier version of this code printed the hash of a first render and
+	// stored the hash of a second, so the number on the page silently
+	// disagreed with the number the API served — worse than printing
+	// nothing, because it looked checkable and was not. So the two answer
+	// different questions. The fingerprint says "this is the same content as
+	// that other copy" and survives a re-render in another format; the hash
+	// says "these exact bytes are untampered". Both are exposed, and /verify
+	// reports both.
+	ContentFingerprint string `json:"content_fingerprint,omitempty"`
+	// ContentHash is SHA-256 of the exact bytes served. It is what /verify
+	// recomputes and what the download sends in X-Content-SHA256, so someone
+	// holding the file can prove it is the one the platform produced and that
+	// nobody has edited it since.
+	ContentHash string          `json:"content_hash,omitempty"`
+	ContentType string          `json:"content_type,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Error       string          `json:"error,omitempty"`
+	Filename    string          `json:"filename,omitempty"`
+	Format      ReportFormat    `json:"format"`
+	ID          string          `json:"id"`
+	Lifecycle   ReportLifecycle `json:"lifecycle"`
+	Locale      ReportLocale    `json:"locale"`
+	// Params carries the configurator's answers (period, scope,
+	// recipients…). Opaque so a new report type needs no migration.
+	Params json.RawMessage `json:"params,omitempty"`
+	// Progress is 0-100, published as the job advances so the client shows
+	// real movement rather than an indeterminate spinner.
+	Progress    int64      `json:"progress"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	RequestedBy string     `json:"requested_by"`
+	// RequestedByEmail / ApprovedByEmail name the actors for display.
+	RequestedByEmail string         `json:"requested_by_email,omitempty"`
+	RunState         ReportRunState `json:"run_state"`
+	SizeBytes        int64          `json:"size_bytes,omitempty"`
+	Step             string         `json:"step"`
+	Supersedes       string         `json:"supersedes,omitempty"`
+	// Template identifies the layout AND its version. Stored on the row, not
+	// resolved at read time: a report regenerated a year later under a newer
+	// template is a different document, and an approved one has to keep saying
+	// which layout produced it.
+	TemplateKey     string     `json:"template_key"`
+	TemplateVersion string     `json:"template_version"`
+	TenantID        string     `json:"tenant_id"`
+	Title           string     `json:"title"`
+	Type            ReportType `json:"type"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	// Version counts regenerations of the same report lineage, and Supersedes
+	// points at the one this replaces. Together they are the version history,
+	// and what makes a diff between two versions possible.
+	Version int64 `json:"version"`
+}
+
+// DomainScope is scope represents the scope of a permission
+type DomainScope string
+
+const (
+	DomainScopeAll      DomainScope = "all"
+	DomainScopeAssigned DomainScope = "assigned"
+	DomainScopeNone     DomainScope = "none"
+	DomainScopeOwn      DomainScope = "own"
+)
+
+// DraftField is one answer on the authority's form.
+type DraftField struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	// Prefilled is false when the incident does not know the answer and a
+	// human has to write it — the UI highlights these.
+	Prefilled bool   `json:"prefilled"`
+	Value     string `json:"value"`
+}
+
+// DraftedRisk is one risk an instantiation produced or found.
+type DraftedRisk struct {
+	AssetID   string `json:"asset_id"`
+	AssetName string `json:"asset_name"`
+	RiskID    string `json:"risk_id"`
+	Title     string `json:"title,omitempty"`
+}
+
+type DryRunBodyOverrides struct {
+	AssetTags    []string `json:"asset_tags"`
+	CVSS         float64  `json:"cvss"`
+	KEV          *bool    `json:"kev,omitempty"`
+	PriorityTier string   `json:"priority_tier"`
+	Severity     string   `json:"severity"`
+}
+
+// DryRunBody is dryRunBody is the optional dry-run request. Everything is
+// optional: with an empty body the engine picks the tenant's most relevant
+// live record.
+type DryRunBody struct {
+	Overrides DryRunBodyOverrides `json:"overrides"`
+	SubjectID string              `json:"subject_id"`
+	// vulnerability | risk | incident
+	SubjectType string `json:"subject_type"`
+}
+
+// DryRunReport is the whole trace. SideEffects is always false and is part
+// of the response on purpose: the caller should be able to see the
+// guarantee, not just be told about it in a doc.
+type DryRunReport struct {
+	ConditionsDetail  string `json:"conditions_detail"`
+	ConditionsMatched bool   `json:"conditions_matched"`
+	DurationMs        int64  `json:"duration_ms"`
+	FailedAction      string `json:"failed_action,omitempty"`
+	// The failure point, hoisted so the UI does not have to hunt for it.
+	FailedAtIndex    int64          `json:"failed_at_index,omitempty"`
+	FailureReason    string         `json:"failure_reason,omitempty"`
+	FinishedAt       time.Time      `json:"finished_at"`
+	ID               string         `json:"id"`
+	InitialInput     map[string]any `json:"initial_input"`
+	PayloadAtFailure map[string]any `json:"payload_at_failure,omitempty"`
+	// Where the sample came from. RealSubject=false means no live record
+	// matched the request and the trace ran on a synthetic subject — said
+	// out loud so a green dry run on fake data is never mistaken for a green
+	// run on real data.
+	RealSubject bool   `json:"real_subject"`
+	RuleEnabled bool   `json:"rule_enabled"`
+	RuleID      string `json:"rule_id"`
+	RuleName    string `json:"rule_name"`
+	// always false
+	SideEffects bool      `json:"side_effects"`
+	StartedAt   time.Time `json:"started_at"`
+	// completed | cancelled
+	Status        string            `json:"status"`
+	Steps         []DryRunStep      `json:"steps"`
+	Subject       string            `json:"subject"`
+	SubjectSource string            `json:"subject_source"`
+	TenantID      string            `json:"tenant_id"`
+	Trigger       AutomationTrigger `json:"trigger"`
+	WouldFail     int64             `json:"would_fail"`
+	// Verdict summary.
+	WouldRun  int64 `json:"would_run"`
+	WouldSkip int64 `json:"would_skip"`
+}
+
+// DryRunStep is one simulated action, with the payload as it stood at that
+// instant — the thing you actually need when a rule "does nothing" in
+// prod.
+type DryRunStep struct {
+	Action string `json:"action"`
+	// which port this step needs
+	Capability string `json:"capability,omitempty"`
+	Detail     string `json:"detail"`
+	Index      int64  `json:"index"`
+	// the action's own configuration
+	Params map[string]any `json:"params,omitempty"`
+	// the trigger context entering this step
+	Payload map[string]any `json:"payload"`
+	// what it would add to the context
+	Produces map[string]any `json:"produces,omitempty"`
+	Verdict  string         `json:"verdict"`
+	Wired    bool           `json:"wired"`
+}
+
+// Edge joins two nodes, left to right.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Effectiveness is a control's latest design and operating scores and
+// their combination (domain.ControlEffectiveness).
+type Effectiveness struct {
+	Design    *float64 `json:"design,omitempty"`
+	Operating *float64 `json:"operating,omitempty"`
+	Tested    bool     `json:"tested"`
+	Value     float64  `json:"value"`
+}
+
+// EligibilityVerdict explains a yes or a no. A bare boolean would make the
+// UI guess at the reason, and guessing produces "you are not allowed" with
+// no way forward.
+type EligibilityVerdict struct {
+	Eligible bool   `json:"eligible"`
+	Reason   string `json:"reason"`
+	// ViaDelegation names the person whose rights were used, when they were.
+	ViaDelegation string `json:"via_delegation,omitempty"`
+}
+
+// EmergingRisk is one candidate risk the assistant suggests creating. The
+// suggested P/I are on the Score Engine scale so the UI can pre-fill a
+// draft.
+type EmergingRisk struct {
+	Category    string `json:"category"`
+	Description string `json:"description"`
+	Rationale   string `json:"rationale"`
+	// critical|high|medium|low
+	Severity string `json:"severity"`
+	// 0..10
+	SuggestedImpact float64 `json:"suggested_impact"`
+	// 0..1
+	SuggestedProbability float64 `json:"suggested_probability"`
+	Title                string  `json:"title"`
+}
+
+type EmergingRisksInput struct {
+	Context string `json:"context"`
+	Locale  string `json:"locale"`
+	Source  string `json:"source"`
+	Text    string `json:"text"`
+}
+
+// Entity is one organization of the group with its headline counts.
+type Entity struct {
+	CriticalRisks int64  `json:"critical_risks"`
+	Depth         int64  `json:"depth"`
+	HighRisks     int64  `json:"high_risks"`
+	Name          string `json:"name"`
+	OrgID         string `json:"org_id"`
+	ParentID      string `json:"parent_id,omitempty"`
+	Risks         int64  `json:"risks"`
+	Slug          string `json:"slug"`
+}
+
+// EntityCount is one entity's share of a cell or a category.
+type EntityCount struct {
+	Count int64  `json:"count"`
+	Name  string `json:"name"`
+	OrgID string `json:"org_id"`
+}
+
+// EntityLoss is one entity's own annual loss band.
+type EntityLoss struct {
+	Loss  DistributionAmounts `json:"loss"`
+	Name  string              `json:"name"`
+	OrgID string              `json:"org_id"`
+	Risks int64               `json:"risks"`
+}
+
+// EntityPosture is one entity's progress on a framework.
+type EntityPosture struct {
+	Applicable      int64   `json:"applicable"`
+	Implemented     int64   `json:"implemented"`
+	Name            string  `json:"name"`
+	OrgID           string  `json:"org_id"`
+	PercentComplete float64 `json:"percent_complete"`
+}
+
+// Error is an error. Handlers answer {error}; the authentication and
+// permission middleware answer {code, message}.
+type Error struct {
+	Code    json.RawMessage `json:"code,omitempty"`
+	Details json.RawMessage `json:"details,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Message string          `json:"message,omitempty"`
+}
+
+// Evaluation is the register measured against appetite.
+type Evaluation struct {
+	EvaluatedAt time.Time `json:"evaluated_at"`
+	// Risks lists every risk above an in-force statement, worst first, except
+	// those the user's access policies hide. Summary counts them all.
+	Risks      []RiskResult      `json:"risks"`
+	Statements []StatementResult `json:"statements"`
+	Summary    Summary           `json:"summary"`
+}
+
+// EventInfo describes an event type for GET /webhook-events.
+type EventInfo struct {
+	Description   string           `json:"description"`
+	Schema        string           `json:"schema"`
+	SchemaVersion int64            `json:"schema_version"`
+	Type          WebhookEventType `json:"type"`
+}
+
+// Evidence is a reusable proof artifact in a tenant's evidence library.
+// The defining property is that it is NOT owned by one control. The same
+// SOC 2 bridge letter, ISO certificate or hardening baseline export
+// answers a dozen controls across several frameworks, and re-uploading it
+// per control is both how registers rot (twelve copies, one of them
+// refreshed) and the single biggest source of busywork in a compliance
+// programme. One artifact, N links.
+type Evidence struct {
+	AssigneeEmail string  `json:"assignee_email,omitempty"`
+	AssigneeID    *string `json:"assignee_id,omitempty"`
+	// CollectedAt is when the proof was *taken*, which is not when the row was
+	// created: a January access review uploaded in March is January evidence,
+	// and dating it March would misstate the control's coverage.
+	CollectedAt time.Time `json:"collected_at"`
+	CollectedBy *string   `json:"collected_by,omitempty"`
+	// CollectedByEmail resolves the actor for display; empty when
+	// unresolvable.
+	CollectedByEmail string `json:"collected_by_email,omitempty"`
+	// ControlIDs is the set of controls this artifact currently answers —
+	// the `control_id[]` of the spec, materialised from the link table on
+	// read.
+	ControlIDs []string `json:"control_ids"`
+	// Controls carries a light projection (code, name, framework) for display.
+	Controls  []EvidenceControlRef `json:"controls,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+	// DaysUntilExpiry is negative once expired, nil when the evidence never
+	// expires.
+	DaysUntilExpiry int64  `json:"days_until_expiry,omitempty"`
+	Description     string `json:"description"`
+	// ExternalURL points at evidence that lives in another system of record.
+	// Kept distinct from FileRef so the UI never offers a download for
+	// something the product does not hold the bytes of.
+	ExternalURL string `json:"external_url"`
+	// FileRef is the storage key of the artifact's bytes, served only through
+	// the download endpoint. Empty for evidence that is a statement rather
+	// than a file (an attestation recorded inline, a link to a system of
+	// record).
+	FileRef  string `json:"file_ref"`
+	Filename string `json:"filename"`
+	ID       string `json:"id"`
+	// Computed, NOT persisted — resolved by the list/get use cases through a
+	// UserLookup port so the UI can render an avatar without an extra
+	// round-trip per row. Degrades to empty when the lookup is unavailable.
+	OwnerEmail string  `json:"owner_email,omitempty"`
+	OwnerID    *string `json:"owner_id,omitempty"`
+	// ReminderSentAt stamps the last expiry reminder so a worker that runs on
+	// a cadence cannot spam the owner every tick. Stamped BEFORE the
+	// notification goes out, for the same reason the mitigation due worker
+	// does it: a duplicate reminder is noise, a reminder sent twice because
+	// the send succeeded and the stamp failed is a bug that trains people to
+	// ignore the channel.
+	ReminderSentAt *time.Time     `json:"reminder_sent_at,omitempty"`
+	Review         EvidenceReview `json:"review"`
+	ReviewNote     string         `json:"review_note"`
+	ReviewedAt     *time.Time     `json:"reviewed_at,omitempty"`
+	ReviewedBy     *string        `json:"reviewed_by,omitempty"`
+	ReviewerEmail  string         `json:"reviewer_email,omitempty"`
+	ReviewerID     *string        `json:"reviewer_id,omitempty"`
+	// SHA256 is the hex digest of the bytes as received, computed while they
+	// were streamed to storage and re-checked on every download. It is what
+	// lets an auditor be shown that the file in front of them is the one
+	// collected on CollectedAt. Empty for evidence without a file, and for
+	// files uploaded before digests existed (those read as "unverified", never
+	// as "verified").
+	Sha256       string         `json:"sha256"`
+	SizeBytes    int64          `json:"size_bytes"`
+	Source       EvidenceSource `json:"source"`
+	SourceDetail string         `json:"source_detail"`
+	// Status is the effective state; filled by the use cases on read so the
+	// API and the UI never re-derive it (and never disagree about it).
+	Status     EvidenceStatus `json:"status"`
+	TenantID   string         `json:"tenant_id"`
+	Title      string         `json:"title"`
+	Type       EvidenceType   `json:"type"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	ValidUntil *time.Time     `json:"valid_until,omitempty"`
+}
+
+type EvidenceAnalysisInput struct {
+	Locale string `json:"locale"`
+}
+
+// EvidenceAssessment is the documentary-compliance verdict.
+type EvidenceAssessment struct {
+	// 0..1
+	Confidence  float64  `json:"confidence"`
+	Gaps        []string `json:"gaps"`
+	Rationale   string   `json:"rationale"`
+	Suggestions []string `json:"suggestions"`
+	// satisfies|partial|insufficient|unrelated
+	Verdict string `json:"verdict"`
+}
+
+// EvidenceAssessmentResult wraps the documentary-compliance verdict with
+// its provider.
+type EvidenceAssessmentResult struct {
+	Assessment  EvidenceAssessment `json:"assessment"`
+	GeneratedBy string             `json:"generated_by"`
+}
+
+// EvidenceControlRef is a display projection of one linked control.
+type EvidenceControlRef struct {
+	ControlID     string `json:"control_id"`
+	FrameworkID   string `json:"framework_id"`
+	FrameworkName string `json:"framework_name"`
+	Name          string `json:"name"`
+	ReferenceCode string `json:"reference_code"`
+}
+
+// EvidenceCustodyEvent is one line of an artifact's chain of custody: who
+// handled it, how, when, and what its digest was at that moment. Every
+// entry is ALSO written to the hash-chained audit trail (its id rides in
+// the audit entry's after-snapshot). The custody table is the convenient,
+// per-artifact read; the audit chain is what makes it tamper-evident — a
+// custody row edited after the fact no longer matches the chained entry
+// that names it.
+type EvidenceCustodyEvent struct {
+	Action     CustodyAction `json:"action"`
+	ActorEmail string        `json:"actor_email,omitempty"`
+	ActorID    *string       `json:"actor_id,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+	Detail     string        `json:"detail"`
+	EvidenceID string        `json:"evidence_id"`
+	ID         string        `json:"id"`
+	IPAddress  string        `json:"ip_address,omitempty"`
+	// SHA256 is the digest the artifact carried when this happened; for a
+	// replacement, the NEW digest (the old one is in Detail).
+	Sha256   string `json:"sha256"`
+	TenantID string `json:"tenant_id"`
+}
+
+// EvidenceIntegrity is the outcome of re-hashing an artifact's stored
+// bytes.
+type EvidenceIntegrity struct {
+	ActualSha256   string    `json:"actual_sha256"`
+	CheckedAt      time.Time `json:"checked_at"`
+	EvidenceID     string    `json:"evidence_id"`
+	ExpectedSha256 string    `json:"expected_sha256"`
+	Recorded       bool      `json:"recorded"`
+	SizeBytes      int64     `json:"size_bytes"`
+	// Verified is true only when a recorded digest exists AND matches. A file
+	// uploaded before digests existed is not "verified", it is "unverifiable".
+	Verified bool `json:"verified"`
+}
+
+// EvidenceReview is the human verdict on an artifact: has someone
+// competent looked at it and accepted it as proof? This is deliberately
+// NOT the same field as the freshness state below. A rejected artifact is
+// rejected whether or not it has expired, and an accepted artifact still
+// goes stale on its own schedule. Collapsing the two into one column is
+// how evidence registers end up claiming a control is covered by a
+// screenshot an auditor threw out eighteen months ago.
+type EvidenceReview string
+
+const (
+	EvidenceReviewAccepted EvidenceReview = "accepted"
+	EvidenceReviewPending  EvidenceReview = "pending"
+	EvidenceReviewRejected EvidenceReview = "rejected"
+)
+
+// EvidenceSource records where the artifact came from — a human upload,
+// a connected system, or an automated collector. It is provenance, and it
+// is what lets an auditor weigh a screenshot someone pasted against a
+// configuration pulled straight from the cloud account.
+type EvidenceSource string
+
+const (
+	EvidenceSourceAutomation  EvidenceSource = "automation"
+	EvidenceSourceIntegration EvidenceSource = "integration"
+	EvidenceSourceManual      EvidenceSource = "manual"
+	EvidenceSourceScanner     EvidenceSource = "scanner"
+	EvidenceSourceVendor      EvidenceSource = "vendor"
+)
+
+// EvidenceStatus is the effective state an auditor reads off the register.
+// It is DERIVED, never stored: see Evidence.EffectiveStatus.
+type EvidenceStatus string
+
+const (
+	EvidenceStatusExpired      EvidenceStatus = "expired"
+	EvidenceStatusExpiringSoon EvidenceStatus = "expiring_soon"
+	EvidenceStatusPending      EvidenceStatus = "pending"
+	EvidenceStatusRejected     EvidenceStatus = "rejected"
+	EvidenceStatusValid        EvidenceStatus = "valid"
+)
+
+// EvidenceType is the nature of the artifact, not the file's MIME type. An
+// auditor asks "what kind of proof is this?", not "is it a PDF?" — a
+// policy document, a screen capture, a configuration export, a signed
+// attestation and a log extract carry very different evidential weight,
+// and the same PDF container can hold any of them.
+type EvidenceType string
+
+const (
+	EvidenceTypeAttestation   EvidenceType = "attestation"
+	EvidenceTypeCapture       EvidenceType = "capture"
+	EvidenceTypeConfiguration EvidenceType = "configuration"
+	EvidenceTypeDocument      EvidenceType = "document"
+	EvidenceTypeLog           EvidenceType = "log"
+)
+
+// ExceptionInput is the body of POST /risk-appetite/exceptions.
+type ExceptionInput struct {
+	Justification string    `json:"justification"`
+	RiskID        string    `json:"risk_id"`
+	ValidUntil    time.Time `json:"valid_until"`
+}
+
+// ExecRisk is one row of the "top 10 risks" table/heatmap.
+type ExecRisk struct {
+	Ale         Money  `json:"ale"`
+	Criticality string `json:"criticality"`
+	ID          string `json:"id"`
+	// 1..5 band for the heatmap
+	Impact         int64  `json:"impact"`
+	LifecyclePhase string `json:"lifecycle_phase"`
+	// 1..5 band for the heatmap
+	Probability int64   `json:"probability"`
+	Score       float64 `json:"score"`
+	Status      string  `json:"status"`
+	Title       string  `json:"title"`
+}
+
+// ExecutionHistory is one row of the run history, enriched for display.
+type ExecutionHistory struct {
+	// resolved on read
+	ActorEmail string     `json:"actor_email,omitempty"`
+	ActorID    string     `json:"actor_id,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	DurationMs int64      `json:"duration_ms"`
+	Error      string     `json:"error,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	ID         string     `json:"id"`
+	Input      JSONMap    `json:"input,omitempty"`
+	// The whole point of a history: what went in, what came out, how long it
+	// took, and who is answerable for it.
+	Mode         string                    `json:"mode"`
+	Output       JSONMap                   `json:"output,omitempty"`
+	ReplayedFrom string                    `json:"replayed_from,omitempty"`
+	RuleID       string                    `json:"rule_id"`
+	RuleName     string                    `json:"rule_name"`
+	Severity     string                    `json:"severity"`
+	StartedAt    time.Time                 `json:"started_at"`
+	Status       AutomationExecutionStatus `json:"status"`
+	// StepSummary is "3 ok · 1 skipped · 1 failed" — the one line that
+	// lets a reader triage a list of runs without expanding each one.
+	StepSummary string            `json:"step_summary"`
+	Steps       ExecutionStepList `json:"steps"`
+	Subject     string            `json:"subject"`
+	TenantID    string            `json:"tenant_id"`
+	Trigger     AutomationTrigger `json:"trigger"`
+	// TriggerRef is a stable reference to what fired the rule (e.g.
+	// "cve:CVE-2021-44228", "risk:<uuid>", "vuln:<uuid>"). Subject is a human
+	// summary.
+	TriggerRef string `json:"trigger_ref"`
+}
+
+// ExecutionStep records the outcome of a single action within an
+// execution. Input and Output are what the step received and produced, so
+// a failed run can be read without re-deriving the state the engine was in
+// at that moment.
+type ExecutionStep struct {
+	Action     string         `json:"action"`
+	At         time.Time      `json:"at"`
+	Detail     string         `json:"detail"`
+	DurationMs int64          `json:"duration_ms"`
+	Error      string         `json:"error,omitempty"`
+	Index      int64          `json:"index"`
+	Input      map[string]any `json:"input,omitempty"`
+	Output     map[string]any `json:"output,omitempty"`
+	// success|failed|skipped
+	Status string `json:"status"`
+}
+
+// ExecutionStepList is stored as jsonb.
+type ExecutionStepList []ExecutionStep
+
+// ExecutiveDashboard is the single consolidated payload for spec §11.
+type ExecutiveDashboard struct {
+	// Appetite is nil when no appetite statement is in force.
+	Appetite         *AppetiteHeadline    `json:"appetite,omitempty"`
+	Compliance       []ComplianceCoverage `json:"compliance"`
+	Currency         string               `json:"currency"`
+	CyberScore       CyberScore           `json:"cyber_score"`
+	Financial        FinancialHeadline    `json:"financial"`
+	GeneratedAt      time.Time            `json:"generated_at"`
+	IncidentTrend    []IncidentTrendPoint `json:"incident_trend"`
+	Kris             []DashboardKRI       `json:"kris"`
+	RiskDistribution []DistributionSlice  `json:"risk_distribution"`
+	RiskTrend        []MonthlyRiskPoint   `json:"risk_trend"`
+	TopRisks         []ExecRisk           `json:"top_risks"`
+	XafPerUsd        float64              `json:"xaf_per_usd"`
+}
+
+// Explanation answers "why can (or can't) this user see this record".
+type Explanation struct {
+	Decision AccessDecision `json:"decision"`
+	// QueryVisible is what the repositories' SQL decides for the same user and
+	// record. It always equals Decision.Visible; a difference is a bug.
+	QueryVisible bool `json:"query_visible"`
+	// Record holds the attribute values the policies were evaluated against.
+	// Omitted when the record is hidden from a caller who is not an
+	// administrator: explaining a refusal must not disclose the record.
+	Record   AccessRecord   `json:"record,omitempty"`
+	RecordID string         `json:"record_id"`
+	Resource AccessResource `json:"resource"`
+	Subject  AccessSubject  `json:"subject"`
+}
+
+// FAIRRange is a min / most likely / max estimate, the PERT shape pkg/crq
+// samples from.
+type FAIRRange struct {
+	Max  float64 `json:"max"`
+	Min  float64 `json:"min"`
+	Mode float64 `json:"mode"`
+}
+
+// Factor is one line of the breakdown — the unit of explanation. The
+// arithmetic is deliberately trivial and checkable by eye: Contribution =
+// Weight × Raw, and the contributions sum to Value. Anyone reading the
+// response can verify the score by hand, which is the point: a score
+// nobody can reproduce is a score nobody should act on.
+type Factor struct {
+	// Available is false when the underlying source could not be consulted.
+	// The factor is then excluded from the score and its weight redistributed
+	// — an absent signal must not read as a good one. It is still returned,
+	// so the explainer can say "not measured" instead of quietly showing three
+	// factors where the model has four.
+	Available    bool             `json:"available"`
+	Contribution float64          `json:"contribution"`
+	Factor       ScoringFactorKey `json:"factor"`
+	LabelI18nKey string           `json:"label_i18n_key"`
+	// Raw is this factor's own measurement on the canonical 0–100 scale,
+	// where 100 always means "worst". A factor whose natural direction is the
+	// opposite (control coverage, for instance) is inverted at the point of
+	// measurement, not at the point of display.
+	Raw float64 `json:"raw"`
+	// Weight is normalised: the weights of a breakdown sum to 1.
+	Weight float64 `json:"weight"`
+}
+
+// FactorScore is one row of the breakdown: the normalised risk
+// contribution of a factor, the (normalised) weight applied to it, and the
+// resulting points.
+type FactorScore struct {
+	// Value × Weight × 100 — points this factor adds
+	Contribution float64 `json:"contribution"`
+	// short human explanation of the raw inputs
+	Detail string            `json:"detail"`
+	Key    ScoringFactorKey2 `json:"key"`
+	Label  string            `json:"label"`
+	// factor risk contribution, [0,1] (1 = worst)
+	Value float64 `json:"value"`
+	// normalised weight actually applied, [0,1]
+	Weight float64 `json:"weight"`
+}
+
+// FactorWeightsInput is the full set of eight factor weights (relative;
+// the engine normalises). Used by both the simulator and the
+// weights-update endpoint.
+type FactorWeightsInput struct {
+	BusinessCriticality float64 `json:"business_criticality"`
+	ControlMaturity     float64 `json:"control_maturity"`
+	Exploitability      float64 `json:"exploitability"`
+	FinancialValue      float64 `json:"financial_value"`
+	IncidentHistory     float64 `json:"incident_history"`
+	InternetExposure    float64 `json:"internet_exposure"`
+	ThreatIntel         float64 `json:"threat_intel"`
+	Vulnerabilities     float64 `json:"vulnerabilities"`
+}
+
+// FeatureDTO carries the resolved level and, for disabled features, the
+// least plan that unlocks it (so the frontend can say "available from the
+// Pro plan").
+type FeatureDTO struct {
+	Enabled      bool   `json:"enabled"`
+	Level        string `json:"level"`
+	RequiredPlan string `json:"required_plan"`
+}
+
+// FinancialAssessment is the full monetary view of a single risk — the
+// object a CISO/CFO dashboard renders. Every monetary field carries both
+// currencies.
+type FinancialAssessment struct {
+	// --- Annualized loss ---
+	Ale Money `json:"ale"`
+	// residual ALE post-control
+	AleAfter Money `json:"ale_after"`
+	// average single loss × ARO
+	AleAverage Money `json:"ale_average"`
+	// explicit | reference
+	AleBasis Basis `json:"ale_basis"`
+	// worst single loss × ARO
+	AleWorst Money `json:"ale_worst"`
+	// --- Frequency ---
+	Aro float64 `json:"aro"`
+	// --- FAIR-lite loss distribution (spec §2: P10/P50/P90, never one
+	// number) ---
+	Distribution *DistributionAmounts `json:"distribution,omitempty"`
+	// downtime hours × hourly cost
+	DowntimeCost Money `json:"downtime_cost"`
+	// --- Explainability (spec §4) ---
+	Methodology *Methodology `json:"methodology,omitempty"`
+	// [0,1]
+	MitigationEffectiveness float64 `json:"mitigation_effectiveness"`
+	// --- Treatment / investment (ROSI) ---
+	RemediationCost Money `json:"remediation_cost"`
+	// ALE − ALEAfter (benefit)
+	RiskReduction Money `json:"risk_reduction"`
+	// ratio, e.g. 2.5 = +250%
+	Rosi float64 `json:"rosi"`
+	// false when remediation cost ≤ 0
+	RosiComputable bool `json:"rosi_computable"`
+	// --- Single-event loss magnitude ---
+	Sle Money `json:"sle"`
+	// PERT-expected single loss
+	SleAverage Money `json:"sle_average"`
+	// explicit | composed | reference
+	SleBasis Basis `json:"sle_basis"`
+	// worst-case single loss
+	SleWorst Money `json:"sle_worst"`
+}
+
+// FinancialHeadline is the exposure KPI shown at the top of the board.
+type FinancialHeadline struct {
+	QuantifiedRisks int64 `json:"quantified_risks"`
+	TotalAle        Money `json:"total_ale"`
+	TotalAleWorst   Money `json:"total_ale_worst"`
+	TotalRisks      int64 `json:"total_risks"`
+}
+
+// FinancialSummary is the tenant-wide financial posture rendered by the
+// CFO/CISO dashboard: portfolio ALE (current, worst-case, residual),
+// remediation budget, portfolio ROSI, a breakdown by criticality and the
+// top exposures.
+type FinancialSummary struct {
+	ByCriticality []CriticalityBucket `json:"by_criticality"`
+	// when this snapshot was computed
+	ComputedAt time.Time `json:"computed_at"`
+	// tenant display currency
+	Currency       string `json:"currency"`
+	FormulaVersion string `json:"formula_version"`
+	// reference date of the rate
+	FxAsOf time.Time `json:"fx_as_of"`
+	// XAF per 1 unit of Currency
+	FxRateXaf  float64 `json:"fx_rate_xaf"`
+	Iterations int64   `json:"iterations"`
+	// PortfolioLoss is the FAIR-lite P10/P50/P90 band of TOTAL annual exposure
+	// — the headline figure. A single number is a false certainty (spec
+	// §2).
+	PortfolioLoss           DistributionAmounts `json:"portfolio_loss"`
+	PortfolioRosi           float64             `json:"portfolio_rosi"`
+	PortfolioRosiComputable bool                `json:"portfolio_rosi_computable"`
+	// from SQL aggregate, not a client filter
+	QuantifiedRisks int64              `json:"quantified_risks"`
+	TopRisks        []TopRiskFinancial `json:"top_risks"`
+	TotalAle        Money              `json:"total_ale"`
+	// residual after modeled controls
+	TotalAleAfter    Money `json:"total_ale_after"`
+	TotalAleWorst    Money `json:"total_ale_worst"`
+	TotalRemediation Money `json:"total_remediation"`
+	// benefit of modeled controls
+	TotalRiskReduction Money   `json:"total_risk_reduction"`
+	TotalRisks         int64   `json:"total_risks"`
+	XafPerUsd          float64 `json:"xaf_per_usd"`
+}
+
+// FindingDiscovery is a single vulnerability/misconfiguration found by a
+// scan.
+type FindingDiscovery struct {
+	AffectedCpe []string `json:"affected_cpe"`
+	AgentID     string   `json:"agent_id,omitempty"`
+	// AssetExternalID links a finding back to the discovered asset it belongs
+	// to (its ExternalID). Lets the importer attach findings/risks to the
+	// right asset.
+	AssetExternalID string `json:"asset_external_id,omitempty"`
+	CVE             string `json:"cve,omitempty"`
+	Description     string `json:"description"`
+	// proof: open port, service, vulnerable software…
+	Evidence        string         `json:"evidence"`
+	RawFinding      map[string]any `json:"raw_finding,omitempty"`
+	RemediationHint string         `json:"remediation_hint"`
+	ScanJobID       string         `json:"scan_job_id"`
+	// critical|high|medium|low
+	Severity string `json:"severity"`
+	// security-hub|defender|nmap|osquery|agent
+	Source string `json:"source"`
+	Title  string `json:"title"`
+}
+
+// FingerprintRole labels an attribute as carrying one of the identity
+// signals used to correlate a scanner/CTI finding back to an asset.
+type FingerprintRole string
+
+const (
+	FingerprintRoleCloudID  FingerprintRole = "cloud_id"
+	FingerprintRoleCpe      FingerprintRole = "cpe"
+	FingerprintRoleHostname FingerprintRole = "hostname"
+	FingerprintRoleIP       FingerprintRole = "ip"
+)
+
+type ForgotPasswordRequest struct {
+	Email  string `json:"email"`
+	Locale string `json:"locale,omitempty"`
+}
+
+type FormatDTO struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+}
+
+// FrameworkAnalytics represents framework compliance analytics
+type FrameworkAnalytics struct {
+	AssociatedRisks      int64   `json:"associated_risks"`
+	AverageRiskScore     float64 `json:"average_risk_score"`
+	CompliancePercentage float64 `json:"compliance_percentage"`
+	Framework            string  `json:"framework"`
+	ImplementedControls  int64   `json:"implemented_controls"`
+	TotalControls        int64   `json:"total_controls"`
+}
+
+// FrameworkEvidenceCoverage is the per-framework roll-up.
+type FrameworkEvidenceCoverage struct {
+	CoveredControls int64  `json:"covered_controls"`
+	ExpiringSoon    int64  `json:"expiring_soon"`
+	FrameworkID     string `json:"framework_id"`
+	FrameworkName   string `json:"framework_name"`
+	// Controls lacking proof, worst first. Covered controls are omitted: this
+	// is a worklist, not an inventory.
+	Missing    []MissingControl `json:"missing"`
+	NoEvidence int64            `json:"no_evidence"`
+	// PercentCovered is over controls that need proof. Controls marked
+	// not-applicable are excluded from BOTH sides of the ratio — asking a
+	// tenant to evidence a control they have formally scoped out would
+	// manufacture gaps.
+	PercentCovered float64 `json:"percent_covered"`
+	StaleEvidence  int64   `json:"stale_evidence"`
+	TotalControls  int64   `json:"total_controls"`
+	Version        string  `json:"version"`
+}
+
+// FrameworkGapSummary rolls up gap counts per framework so the UI can
+// show, at a glance, which framework carries the most risk.
+type FrameworkGapSummary struct {
+	FrameworkID     string  `json:"framework_id"`
+	FrameworkName   string  `json:"framework_name"`
+	Gaps            int64   `json:"gaps"`
+	Implemented     int64   `json:"implemented"`
+	InProgress      int64   `json:"in_progress"`
+	NotApplicable   int64   `json:"not_applicable"`
+	NotImplemented  int64   `json:"not_implemented"`
+	PercentComplete float64 `json:"percent_complete"`
+	Total           int64   `json:"total"`
+	Version         string  `json:"version"`
+}
+
+// FrameworkPosture is the consolidated posture on one framework. Entities'
+// frameworks are matched by the catalog they were imported from, falling
+// back to name and version for one built by hand. PercentComplete weighs
+// every applicable control of the group equally; Weakest is the entity
+// furthest behind, the figure a group CISO acts on.
+type FrameworkPosture struct {
+	Applicable      int64           `json:"applicable"`
+	ByEntity        []EntityPosture `json:"by_entity"`
+	Implemented     int64           `json:"implemented"`
+	InProgress      int64           `json:"in_progress"`
+	Key             string          `json:"key"`
+	Name            string          `json:"name"`
+	PercentComplete float64         `json:"percent_complete"`
+	Total           int64           `json:"total"`
+	Version         string          `json:"version"`
+	Weakest         *EntityPosture  `json:"weakest,omitempty"`
+}
+
+// FromTemplateBody is fromTemplateBody optionally renames the adopted
+// rule.
+type FromTemplateBody struct {
+	Name string `json:"name"`
+}
+
+// GapAnalysis is the "analyse d'écarts" DTO: every open gap across a
+// tenant's frameworks, plus per-framework and overall roll-ups. Computed,
+// not persisted.
+type GapAnalysis struct {
+	Frameworks    []FrameworkGapSummary `json:"frameworks"`
+	Gaps          []GapControl          `json:"gaps"`
+	TotalControls int64                 `json:"total_controls"`
+	TotalGaps     int64                 `json:"total_gaps"`
+}
+
+// GapControl is one unsatisfied control surfaced by the gap analysis — a
+// control that is neither implemented nor marked not-applicable, i.e. an
+// open compliance gap the tenant must remediate.
+type GapControl struct {
+	ControlID       string        `json:"control_id"`
+	Description     string        `json:"description"`
+	EvidenceCount   int64         `json:"evidence_count"`
+	FrameworkID     string        `json:"framework_id"`
+	FrameworkName   string        `json:"framework_name"`
+	Name            string        `json:"name"`
+	ReferenceCode   string        `json:"reference_code"`
+	SourceReference string        `json:"source_reference"`
+	Status          ControlStatus `json:"status"`
+}
+
+type GenerateBoardReportInput struct {
+	Locale      string `json:"locale"`
+	PeriodLabel string `json:"period_label"`
+}
+
+// GenerateRemediationsResult reports what an auto-generation run produced.
+type GenerateRemediationsResult struct {
+	// new plans opened
+	Created int64 `json:"created"`
+	// the newly created plans
+	Plans []RemediationPlan `json:"plans"`
+	// gaps that already had an active plan
+	Skipped int64 `json:"skipped"`
+}
+
+// GetRolePermissionsResponse contains role permissions
+type GetRolePermissionsResponse struct {
+	Permissions []PermissionDB `json:"permissions"`
+	RoleID      string         `json:"role_id"`
+}
+
+// GetRoleResponse contains role details with permissions
+type GetRoleResponse struct {
+	Permissions []PermissionDB `json:"permissions"`
+	Role        RoleEnhanced   `json:"role"`
+	UserCount   int64          `json:"user_count"`
+}
+
+// GetTenantResponse contains tenant details with statistics
+type GetTenantResponse struct {
+	CreatedAt string `json:"created_at"`
+	RiskCount int64  `json:"risk_count"`
+	RoleCount int64  `json:"role_count"`
+	Tenant    Tenant `json:"tenant"`
+	UpdatedAt string `json:"updated_at"`
+	UserCount int64  `json:"user_count"`
+}
+
+// GetTenantStatsResponse contains tenant statistics
+type GetTenantStatsResponse struct {
+	MitigationCount int64  `json:"mitigation_count"`
+	Name            string `json:"name"`
+	RiskCount       int64  `json:"risk_count"`
+	RoleCount       int64  `json:"role_count"`
+	TenantID        string `json:"tenant_id"`
+	UserCount       int64  `json:"user_count"`
+}
+
+// GetTenantUsersCountResponse contains user count statistics
+type GetTenantUsersCountResponse struct {
+	ByAdmins   int64 `json:"by_admins"`
+	ByAnalysts int64 `json:"by_analysts"`
+	ByManagers int64 `json:"by_managers"`
+	ByViewers  int64 `json:"by_viewers"`
+	Total      int64 `json:"total"`
+}
+
+// GetTenantUsersResponse contains tenant user list
+type GetTenantUsersResponse struct {
+	HasMore    bool         `json:"has_more"`
+	Limit      int64        `json:"limit"`
+	Offset     int64        `json:"offset"`
+	Total      int64        `json:"total"`
+	TotalPages int64        `json:"total_pages"`
+	Users      []UserTenant `json:"users"`
+}
+
+// GetUserPermissionsResponse contains user's permissions
+type GetUserPermissionsResponse struct {
+	Level       int64    `json:"level"`
+	Permissions []string `json:"permissions"`
+	Role        string   `json:"role"`
+	TenantID    string   `json:"tenant_id"`
+	UserID      string   `json:"user_id"`
+}
+
+// Goal is one answer to "what brings you here?" — it selects the
+// template that gets loaded (which frameworks are suggested first, which
+// landing page).
+type Goal struct {
+	// Frameworks are catalog keys (pkg/compliance) pushed to the top of the
+	// suggestion list when this goal is chosen.
+	Frameworks []string          `json:"frameworks,omitempty"`
+	Key        string            `json:"key"`
+	LabelI18n  map[string]string `json:"label_i18n"`
+	// Landing is where the user is dropped after the wizard.
+	Landing string `json:"landing"`
+}
+
+// Graph is GET /risks/:id/bowtie. Bowtie is nil, and the lists empty, when
+// the risk has none yet.
+type Graph struct {
+	Bowtie     json.RawMessage `json:"bowtie,omitempty"`
+	Edges      []Edge          `json:"edges"`
+	Nodes      []Node          `json:"nodes"`
+	Preventive SideHealth      `json:"preventive"`
+	Recovery   SideHealth      `json:"recovery"`
+	RiskID     string          `json:"risk_id"`
+	RiskTitle  string          `json:"risk_title"`
+	Suggestion Suggestion      `json:"suggestion"`
+}
+
+type GraphapiResultErrorLocation struct {
+	Column int64 `json:"column"`
+	Line   int64 `json:"line"`
+}
+
+type GraphapiResultError struct {
+	Extensions map[string]any                `json:"extensions,omitempty"`
+	Locations  []GraphapiResultErrorLocation `json:"locations,omitempty"`
+	Message    string                        `json:"message"`
+	Path       []json.RawMessage             `json:"path,omitempty"`
+}
+
+// GraphapiResult is result is a GraphQL response. Data is absent when the
+// query was rejected before it ran.
+type GraphapiResult struct {
+	Data       json.RawMessage       `json:"data,omitempty"`
+	Errors     []GraphapiResultError `json:"errors,omitempty"`
+	Extensions map[string]any        `json:"extensions,omitempty"`
+}
+
+// GroupRisk is one read-only row of the drill-down.
+type GroupRisk struct {
+	BusinessUnit string  `json:"business_unit,omitempty"`
+	Category     string  `json:"category,omitempty"`
+	Criticality  string  `json:"criticality"`
+	ID           string  `json:"id"`
+	Impact       float64 `json:"impact"`
+	OrgID        string  `json:"org_id"`
+	OrgName      string  `json:"org_name"`
+	Probability  float64 `json:"probability"`
+	Score        float64 `json:"score"`
+	Status       string  `json:"status"`
+	Title        string  `json:"title"`
+}
+
+type HandlerPlanInput struct {
+	Plan     string `json:"plan"`
+	Provider string `json:"provider"`
+	Region   string `json:"region"`
+}
+
+// Heatmap is the group's consolidated matrix.
+type Heatmap struct {
+	Cells    []HeatmapCell `json:"cells"`
+	Entities int64         `json:"entities"`
+	Total    int64         `json:"total"`
+}
+
+// HeatmapCell is one cell of the consolidated 5×5 matrix with the
+// entities that put risks into it, largest first.
+type HeatmapCell struct {
+	ByEntity    []EntityCount `json:"by_entity"`
+	Count       int64         `json:"count"`
+	Impact      int64         `json:"impact"`
+	Probability int64         `json:"probability"`
+}
+
+// ImpactCurve is a process's impact over time, ordered by AfterHours.
+type ImpactCurve []ImpactPoint
+
+// ImpactPoint is one step of an impact-over-time curve: after AfterHours
+// of outage the impact has reached Level and has cost CostXAF in total.
+type ImpactPoint struct {
+	AfterHours float64 `json:"after_hours"`
+	CostXaf    float64 `json:"cost_xaf"`
+	// Level is 1 (negligible) … 5 (catastrophic), the scale the BIA workshop
+	// uses for the non-financial impacts (legal, reputational, operational).
+	Level int64  `json:"level"`
+	Note  string `json:"note,omitempty"`
+}
+
+// ImportBatch is one uploaded file and what became of it.
+type ImportBatch struct {
+	AppliedAt *time.Time    `json:"applied_at,omitempty"`
+	Columns   ImportColumns `json:"columns"`
+	Created   int64         `json:"created"`
+	CreatedAt time.Time     `json:"created_at"`
+	CreatedBy string        `json:"created_by,omitempty"`
+	Entity    ImportEntity  `json:"entity"`
+	FileName  string        `json:"file_name"`
+	Format    string        `json:"format"`
+	ID        string        `json:"id"`
+	// Mapping is the one last dry-run or applied; a rollback does not need it
+	// (the records carry everything) but a reader asking "what went where?"
+	// does.
+	Mapping      ImportMapping     `json:"mapping"`
+	RolledBackAt *time.Time        `json:"rolled_back_at,omitempty"`
+	RowCount     int64             `json:"row_count"`
+	Skipped      int64             `json:"skipped"`
+	Status       ImportBatchStatus `json:"status"`
+	TenantID     string            `json:"tenant_id"`
+	Updated      int64             `json:"updated"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+// ImportBatchStatus is where a batch stands.
+type ImportBatchStatus string
+
+const (
+	ImportBatchStatusApplied    ImportBatchStatus = "applied"
+	ImportBatchStatusRolledBack ImportBatchStatus = "rolled_back"
+	ImportBatchStatusStaged     ImportBatchStatus = "staged"
+)
+
+type ImportCatalogInput struct {
+	CatalogKey string `json:"catalog_key"`
+}
+
+// ImportCatalogResult reports what happened — importing is idempotent,
+// so re-running it after partial progress (or just to pick up newly-added
+// catalog controls) is safe.
+type ImportCatalogResult struct {
+	// Crosswalks reports the curated correspondences materialised against the
+	// frameworks the tenant already held.
+	Crosswalks *MaterialiseResult `json:"crosswalks,omitempty"`
+	Imported   int64              `json:"imported"`
+	// InheritedCoverage is the head start: how much of what was just imported
+	// is already answered by proof the tenant holds elsewhere. Returned WITH
+	// the import so the answer arrives at the moment the question is asked,
+	// rather than waiting for the user to go looking for it.
+	InheritedCoverage *InheritedCoverage `json:"inherited_coverage,omitempty"`
+	// already existed for this (tenant, framework) by reference_code
+	Skipped int64 `json:"skipped"`
+	Total   int64 `json:"total"`
+}
+
+// ImportColumns is the ordered header of a staged file.
+type ImportColumns []string
+
+// ImportEntity is what a batch imports.
+type ImportEntity string
+
+const (
+	ImportEntityAsset      ImportEntity = "asset"
+	ImportEntityControl    ImportEntity = "control"
+	ImportEntityMitigation ImportEntity = "mitigation"
+	ImportEntityRisk       ImportEntity = "risk"
+)
+
+// ImportMapping maps a source column to a target key. A column absent from
+// the map, or mapped to "", is ignored.
+type ImportMapping map[string]string
+
+// ImportMappingProfile is a saved mapping, e.g. "ServiceNow GRC risks", so
+// the next export from the same tool maps in one click.
+type ImportMappingProfile struct {
+	CreatedAt time.Time     `json:"created_at"`
+	CreatedBy string        `json:"created_by,omitempty"`
+	Entity    ImportEntity  `json:"entity"`
+	ID        string        `json:"id"`
+	Mapping   ImportMapping `json:"mapping"`
+	Name      string        `json:"name"`
+	TenantID  string        `json:"tenant_id"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+type ImportPreviewInputSelection struct {
+	Criticality string `json:"criticality"`
+	ExternalID  string `json:"external_id"`
+}
+
+type ImportPreviewInput struct {
+	Selections []ImportPreviewInputSelection `json:"selections"`
+}
+
+// ImportPreviewResult reports what the import created.
+type ImportPreviewResult struct {
+	AssetsImported int64 `json:"assets_imported"`
+}
+
+// ImportRowError is one reason a row cannot be imported. Column is the
+// source column when the problem is with one cell.
+type ImportRowError struct {
+	Column string `json:"column,omitempty"`
+	Reason string `json:"reason"`
+	Row    int64  `json:"row"`
+}
+
+// ImportRowValues is one staged row: column → raw cell text.
+type ImportRowValues map[string]string
+
+// ImportTarget is a field a column can be mapped to.
+type ImportTarget struct {
+	Key      string `json:"key"`
+	Label    string `json:"label"`
+	Required bool   `json:"required"`
+}
+
+// Incident represents a security incident
+type Incident struct {
+	AssetIDs StringList `json:"asset_ids"`
+	// Deprecated: free-text member, superseded by Ownership.AssigneeID
+	AssignedTo    string     `json:"assigned_to"`
+	AssigneeEmail string     `json:"assignee_email,omitempty"`
+	AssigneeID    *string    `json:"assignee_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+	Description   string     `json:"description"`
+	// external system ref (TheHive, OpenCTI, etc)
+	ExternalID     string          `json:"external_id"`
+	ID             int64           `json:"id"`
+	ImpactedAssets json.RawMessage `json:"impacted_assets"`
+	// vulnerability, breach, attack, data_loss, etc
+	IncidentType string `json:"incident_type"`
+	// Provenance. An incident that appeared on its own is unnerving until you
+	// can see what opened it; these fields are what the "created automatically
+	// by rule X from source Y" banner renders, with a link to the rule itself.
+	Origin string `json:"origin"`
+	// OriginDetail names the concrete thing it came from: "CVE-2021-44228 on
+	// web-prod-01", "scan job 42". Free text on purpose — every source
+	// phrases its own evidence differently.
+	OriginDetail      string `json:"origin_detail,omitempty"`
+	OriginExecutionID string `json:"origin_execution_id,omitempty"`
+	OriginRuleID      string `json:"origin_rule_id,omitempty"`
+	OriginRuleName    string `json:"origin_rule_name,omitempty"`
+	// Computed, NOT persisted — resolved by the list/get use cases through a
+	// UserLookup port so the UI can render an avatar without an extra
+	// round-trip per row. Degrades to empty when the lookup is unavailable.
+	OwnerEmail string  `json:"owner_email,omitempty"`
+	OwnerID    *string `json:"owner_id,omitempty"`
+	// user who reported
+	ReportedBy    string     `json:"reported_by"`
+	Resolution    string     `json:"resolution"`
+	ResolvedAt    *time.Time `json:"resolved_at,omitempty"`
+	ReviewerEmail string     `json:"reviewer_email,omitempty"`
+	ReviewerID    *string    `json:"reviewer_id,omitempty"`
+	// Deprecated: risks use UUIDs; see RiskIDs
+	RiskID *int64 `json:"risk_id,omitempty"`
+	// Real links. RiskID above is a *uint while risks are UUID-keyed, so
+	// incident → risk was structurally impossible; these two carry the
+	// actual ids and are what the UI resolves.
+	RiskIDs StringList `json:"risk_ids"`
+	// critical, high, medium, low
+	Severity string `json:"severity"`
+	// internal, external, third_party
+	Source string `json:"source"`
+	// Stakeholders are the people who must be told, and on which channels.
+	Stakeholders IncidentStakeholderList `json:"stakeholders"`
+	// open, investigating, resolved, closed
+	Status   string `json:"status"`
+	TenantID string `json:"tenant_id"`
+	// array of events
+	Timeline  json.RawMessage `json:"timeline"`
+	Title     string          `json:"title"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// IncidentAction represents mitigation action for incident
+type IncidentAction struct {
+	AssignedTo  string    `json:"assigned_to"`
+	CreatedAt   time.Time `json:"created_at"`
+	Description string    `json:"description"`
+	DueDate     time.Time `json:"due_date"`
+	ID          int64     `json:"id"`
+	Incident    *Incident `json:"incident,omitempty"`
+	IncidentID  int64     `json:"incident_id"`
+	// critical, high, medium, low
+	Priority string `json:"priority"`
+	// pending, in_progress, completed
+	Status    string    `json:"status"`
+	Title     string    `json:"title"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IncidentCreateRequest represents incident creation request
+type IncidentCreateRequest struct {
+	AssetIDs       []string `json:"asset_ids"`
+	Description    string   `json:"description"`
+	ImpactedAssets []string `json:"impacted_assets"`
+	IncidentType   string   `json:"incident_type"`
+	// Provenance, set by automatic producers rather than by a human form.
+	Origin            string  `json:"origin"`
+	OriginDetail      string  `json:"origin_detail"`
+	OriginExecutionID *string `json:"origin_execution_id,omitempty"`
+	OriginRuleID      *string `json:"origin_rule_id,omitempty"`
+	OriginRuleName    string  `json:"origin_rule_name"`
+	ReportedBy        string  `json:"reported_by"`
+	RiskID            *int64  `json:"risk_id,omitempty"`
+	// Declaration fields: what this incident touches and who has to know.
+	RiskIDs      []string              `json:"risk_ids"`
+	Severity     string                `json:"severity"`
+	Source       string                `json:"source"`
+	Stakeholders []IncidentStakeholder `json:"stakeholders"`
+	Title        string                `json:"title"`
+}
+
+// IncidentPostMortem is the structured review of one incident.
+type IncidentPostMortem struct {
+	AuthorEmail string `json:"author_email,omitempty"`
+	AuthorID    string `json:"author_id,omitempty"`
+	// ContributingFactors are the conditions that let the root cause bite.
+	ContributingFactors string               `json:"contributing_factors,omitempty"`
+	CorrectiveActions   CorrectiveActionList `json:"corrective_actions"`
+	CreatedAt           time.Time            `json:"created_at"`
+	// Detection is how it was found — the honest version, including "a
+	// customer told us", which is the finding that changes roadmaps.
+	Detection string `json:"detection,omitempty"`
+	ID        string `json:"id"`
+	// Impact is what it cost: users, data, downtime, money, obligations.
+	Impact         string     `json:"impact"`
+	IncidentID     int64      `json:"incident_id"`
+	LessonsLearned string     `json:"lessons_learned,omitempty"`
+	PublishedAt    *time.Time `json:"published_at,omitempty"`
+	PublishedBy    string     `json:"published_by,omitempty"`
+	// RootCause is the answer to "why did this happen", not "what happened".
+	RootCause string             `json:"root_cause"`
+	Status    string             `json:"status"`
+	Summary   string             `json:"summary"`
+	TenantID  string             `json:"tenant_id"`
+	Timeline  PostMortemTimeline `json:"timeline"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	// WhatWentWell is not optimism: a review that only lists failures teaches
+	// people to hide incidents.
+	WhatWentWell string `json:"what_went_well,omitempty"`
+}
+
+// IncidentRegulatoryAssessment is the classification decision on one
+// incident. The judgement calls ("is this significant?") are a human's,
+// recorded with who made them and when; the product only applies the
+// configured criteria to them.
+type IncidentRegulatoryAssessment struct {
+	AffectedSubjects int64 `json:"affected_subjects"`
+	// AwareAt is when the organisation became aware. Defaults to the
+	// incident's creation; it is editable because detection often predates the
+	// ticket.
+	AwareAt            time.Time  `json:"aware_at"`
+	ClassifiedAt       time.Time  `json:"classified_at"`
+	ClassifiedBy       string     `json:"classified_by,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	CrossBorder        bool       `json:"cross_border"`
+	DataCategories     StringList `json:"data_categories"`
+	ID                 string     `json:"id"`
+	IncidentID         int64      `json:"incident_id"`
+	Jurisdictions      StringList `json:"jurisdictions"`
+	LikelyConsequences string     `json:"likely_consequences"`
+	Major              bool       `json:"major"`
+	Notes              string     `json:"notes"`
+	PersonalData       bool       `json:"personal_data"`
+	Significant        bool       `json:"significant"`
+	SuspectedMalicious bool       `json:"suspected_malicious"`
+	TenantID           string     `json:"tenant_id"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// IncidentStakeholder is somebody who must be told about this incident,
+// and how. Recording the channel per person is what makes "notify the
+// stakeholders" a promise rather than a hope.
+type IncidentStakeholder struct {
+	// in_app|email|slack|teams|webhook|sms
+	Channels []string `json:"channels,omitempty"`
+	Email    string   `json:"email,omitempty"`
+	Name     string   `json:"name,omitempty"`
+	// org role to resolve at send time
+	Role   string `json:"role,omitempty"`
+	UserID string `json:"user_id,omitempty"`
+}
+
+// IncidentStakeholderList is a jsonb array.
+type IncidentStakeholderList []IncidentStakeholder
+
+// IncidentTimeline represents an event in incident timeline
+type IncidentTimeline struct {
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+	// status_change, assignment, comment, action
+	EventType  string          `json:"event_type"`
+	ID         int64           `json:"id"`
+	Incident   *Incident       `json:"incident,omitempty"`
+	IncidentID int64           `json:"incident_id"`
+	Message    string          `json:"message"`
+	Metadata   json.RawMessage `json:"metadata"`
+}
+
+// IncidentTrendPoint is one bar of the incident histogram.
+type IncidentTrendPoint struct {
+	Critical int64 `json:"critical"`
+	High     int64 `json:"high"`
+	// "YYYY-MM"
+	Month string `json:"month"`
+	Total int64  `json:"total"`
+}
+
+// IncidentUpdateRequest represents incident update request
+type IncidentUpdateRequest struct {
+	AssignedTo  string `json:"assigned_to"`
+	Description string `json:"description"`
+	Resolution  string `json:"resolution"`
+	Severity    string `json:"severity"`
+	Status      string `json:"status"`
+	Title       string `json:"title"`
+}
+
+// IngestReqBody is ingestInput is the POST /vulnerabilities/ingest body.
+type IngestReqBody struct {
+	AutoCreateRisk   bool             `json:"auto_create_risk"`
+	AutoCreateTicket bool             `json:"auto_create_ticket"`
+	DefaultAssetID   string           `json:"default_asset_id"`
+	Findings         []map[string]any `json:"findings"`
+	Source           string           `json:"source"`
+}
+
+// IngestResult summarises what an ingest produced.
+type IngestResult struct {
+	Created         int64           `json:"created"`
+	Received        int64           `json:"received"`
+	Skipped         int64           `json:"skipped"`
+	Source          VulnSource      `json:"source"`
+	Updated         int64           `json:"updated"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+}
+
+// InheritedControl is one control of the framework under examination.
+type InheritedControl struct {
+	// AlreadyEvidenced is true when at least one FULL source holds valid proof
+	// — this is what the headline percentage counts.
+	AlreadyEvidenced bool   `json:"already_evidenced"`
+	ControlID        string `json:"control_id"`
+	// Coverage is the strongest inheritance available: full if any source
+	// fully answers it AND holds valid proof, partial if the best on offer is
+	// partial or the source is unevidenced, empty if nothing crosswalks to it.
+	Coverage      CrosswalkCoverage `json:"coverage,omitempty"`
+	Name          string            `json:"name"`
+	ReferenceCode string            `json:"reference_code"`
+	Sources       []InheritedSource `json:"sources"`
+}
+
+// InheritedCoverage answers "how much of this framework do I already
+// have?". This is the number that decides whether a second framework is a
+// quarter's work or a fortnight's. It counts only controls whose
+// crosswalked source holds CURRENTLY VALID evidence — a link to a
+// control someone evidenced with a certificate that expired last year
+// inherits nothing, and saying otherwise would tell a compliance officer
+// they can stop working.
+type InheritedCoverage struct {
+	// AlreadyCoveredControls are fully crosswalked to a control holding valid
+	// proof. The headline.
+	AlreadyCoveredControls int64 `json:"already_covered_controls"`
+	// Controls carries only those with something to inherit — this is a head
+	// start, not an inventory.
+	Controls []InheritedControl `json:"controls"`
+	// CrosswalkedControls have at least one correspondence, evidenced or not.
+	CrosswalkedControls int64  `json:"crosswalked_controls"`
+	FrameworkID         string `json:"framework_id"`
+	FrameworkName       string `json:"framework_name"`
+	// PartiallyCoveredControls have something to inherit but not the whole
+	// thing.
+	PartiallyCoveredControls int64 `json:"partially_covered_controls"`
+	// PercentAlreadyCovered is AlreadyCovered / applicable controls. The
+	// "47%".
+	PercentAlreadyCovered float64 `json:"percent_already_covered"`
+	TotalControls         int64   `json:"total_controls"`
+}
+
+// InheritedSource is one control elsewhere in the tenant's programme that
+// already answers a control of the framework being examined.
+type InheritedSource struct {
+	ControlID string            `json:"control_id"`
+	Coverage  CrosswalkCoverage `json:"coverage"`
+	// EvidenceCount is how much CURRENTLY VALID proof the source control
+	// holds. Zero means the crosswalk exists but inherits nothing — the
+	// distinction the headline number turns on.
+	EvidenceCount int64           `json:"evidence_count"`
+	FrameworkID   string          `json:"framework_id"`
+	FrameworkName string          `json:"framework_name"`
+	Name          string          `json:"name"`
+	Origin        CrosswalkOrigin `json:"origin"`
+	Rationale     string          `json:"rationale"`
+	ReferenceCode string          `json:"reference_code"`
+}
+
+// Input is the body of POST/PUT /kris.
+type Input struct {
+	AmberThreshold  float64      `json:"amber_threshold"`
+	BumpProbability bool         `json:"bump_probability"`
+	Description     string       `json:"description"`
+	Direction       KRIDirection `json:"direction"`
+	Name            string       `json:"name"`
+	ProbabilityStep float64      `json:"probability_step"`
+	Query           KRIQuery     `json:"query"`
+	RedThreshold    float64      `json:"red_threshold"`
+	RefreshMinutes  int64        `json:"refresh_minutes"`
+	RiskIDs         []string     `json:"risk_ids"`
+	Source          KRISource    `json:"source"`
+	Unit            string       `json:"unit"`
+}
+
+// InstallInput creates or updates an install. On update, nil fields are
+// left alone; a secret set to "" is revoked.
+type InstallInput struct {
+	AllowedHosts []string          `json:"allowed_hosts"`
+	Config       map[string]any    `json:"config"`
+	Enabled      *bool             `json:"enabled,omitempty"`
+	MemoryPages  *int64            `json:"memory_pages,omitempty"`
+	Name         *string           `json:"name,omitempty"`
+	PackageID    string            `json:"package_id"`
+	Secrets      map[string]string `json:"secrets"`
+	TimeoutMs    *int64            `json:"timeout_ms,omitempty"`
+}
+
+// InstallationStatus represents the status of a marketplace app
+// installation
+type InstallationStatus string
+
+const (
+	InstallationStatusDisabled    InstallationStatus = "disabled"
+	InstallationStatusError       InstallationStatus = "error"
+	InstallationStatusInstalled   InstallationStatus = "installed"
+	InstallationStatusPending     InstallationStatus = "pending"
+	InstallationStatusUninstalled InstallationStatus = "uninstalled"
+)
+
+// InstantiateInput is the body of POST /risk-scenarios/:id/instantiate.
+type InstantiateInput struct {
+	AssetIDs []string `json:"asset_ids"`
+}
+
+// InstantiateResult reports what instantiation did. Existing lists assets
+// that already had a live risk from this scenario: instantiating twice
+// adds nothing. UnmatchedControls are the scenario's control references
+// the tenant has no control for — the frameworks it has not imported, in
+// effect.
+type InstantiateResult struct {
+	Created           []DraftedRisk        `json:"created"`
+	Existing          []DraftedRisk        `json:"existing"`
+	MappedControls    int64                `json:"mapped_controls"`
+	ScenarioID        string               `json:"scenario_id"`
+	ScenarioVersion   int64                `json:"scenario_version"`
+	UnmatchedControls []ScenarioControlRef `json:"unmatched_controls"`
+}
+
+// InvitationPreview is what an unauthenticated visitor holding a link may
+// see before deciding to accept: which organization, at what role, for
+// which address. Nothing about the organization's contents, its size, or
+// its other members — the link is a bearer credential and it buys
+// exactly this.
+type InvitationPreview struct {
+	Email            string    `json:"email"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	OrganizationName string    `json:"organization_name"`
+	// RequiresSignup tells the UI whether to ask for a password.
+	RequiresSignup bool       `json:"requires_signup"`
+	Role           MemberRole `json:"role"`
+}
+
+// InvitationStatus is the stored lifecycle state of an invitation. Expiry
+// is deliberately NOT a stored state — an invitation expires by the
+// passage of time, so State(now) projects it and no sweeper is needed for
+// correctness.
+type InvitationStatus string
+
+const (
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusExpired  InvitationStatus = "expired"
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+)
+
+// InvitationView is one row of the invitation list. There is deliberately
+// no token field of any kind.
+type InvitationView struct {
+	AcceptedAt   *time.Time      `json:"accepted_at,omitempty"`
+	BusinessRole BusinessRoleKey `json:"business_role,omitempty"`
+	// CanResend mirrors the server's own policy so the UI can disable the
+	// button for the same reason the API would refuse it, instead of guessing.
+	CanResend      bool             `json:"can_resend"`
+	CreatedAt      time.Time        `json:"created_at"`
+	Email          string           `json:"email"`
+	ExpiresAt      time.Time        `json:"expires_at"`
+	ID             string           `json:"id"`
+	InvitedByEmail string           `json:"invited_by_email,omitempty"`
+	InvitedByID    string           `json:"invited_by_id"`
+	LastSentAt     time.Time        `json:"last_sent_at"`
+	RevokedAt      *time.Time       `json:"revoked_at,omitempty"`
+	Role           MemberRole       `json:"role"`
+	SendCount      int64            `json:"send_count"`
+	Status         InvitationStatus `json:"status"`
+}
+
+// InviteResult is the answer to creating or resending an invitation.
+type InviteResult struct {
+	// AcceptURL carries the one-time token and is returned ONLY when the email
+	// did not go out. When mail works, the invitee is the only one who
+	// receives the link, and an administrator has no reason to hold a
+	// credential that authenticates as somebody else. When mail does not work,
+	// withholding it would leave a real invitation that nobody can reach —
+	// so the admin gets it once, to deliver by hand, and it is never returned
+	// again by any listing.
+	AcceptURL string         `json:"accept_url,omitempty"`
+	Delivery  DeliveryStatus `json:"delivery"`
+	// DeliveryDetail explains a non-sent delivery in words the admin can act
+	// on.
+	DeliveryDetail string         `json:"delivery_detail,omitempty"`
+	Invitation     InvitationView `json:"invitation"`
+}
+
+// Invoice is a billing document, mirrored locally from the provider so the
+// billing page can render history without a live provider round-trip.
+type Invoice struct {
+	AmountCents    int64           `json:"amount_cents"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Currency       string          `json:"currency"`
+	HostedURL      string          `json:"hosted_url,omitempty"`
+	ID             string          `json:"id"`
+	Number         string          `json:"number"`
+	OrganizationID string          `json:"organization_id"`
+	PeriodEnd      *time.Time      `json:"period_end,omitempty"`
+	PeriodStart    *time.Time      `json:"period_start,omitempty"`
+	Provider       BillingProvider `json:"provider,omitempty"`
+	ProviderID     string          `json:"provider_id,omitempty"`
+	// paid | open | void | uncollectible
+	Status string `json:"status"`
+}
+
+type JSONMap map[string]any
+
+// KRIAggregate is how the matching rows are reduced to one value.
+type KRIAggregate string
+
+const (
+	KRIAggregateAvg   KRIAggregate = "avg"
+	KRIAggregateCount KRIAggregate = "count"
+	KRIAggregateMax   KRIAggregate = "max"
+	KRIAggregateMin   KRIAggregate = "min"
+	KRIAggregateSum   KRIAggregate = "sum"
+)
+
+// KRICrossing describes a band change between two measurements.
+type KRICrossing struct {
+	From     KRIStatus `json:"from"`
+	To       KRIStatus `json:"to"`
+	Worsened bool      `json:"worsened"`
+}
+
+// KRIDirection says which way is bad.
+type KRIDirection string
+
+const (
+	KRIDirectionHigherIsWorse KRIDirection = "higher_is_worse"
+	KRIDirectionLowerIsWorse  KRIDirection = "lower_is_worse"
+)
+
+// KRIFilter restricts the rows a query counts. Value is used by
+// eq/neq/gte/lte, Values by in.
+type KRIFilter struct {
+	Field  string      `json:"field"`
+	Op     KRIFilterOp `json:"op"`
+	Value  string      `json:"value,omitempty"`
+	Values []string    `json:"values,omitempty"`
+}
+
+// KRIFilterOp is a filter comparison.
+type KRIFilterOp string
+
+const (
+	KRIFilterOpEq  KRIFilterOp = "eq"
+	KRIFilterOpGte KRIFilterOp = "gte"
+	KRIFilterOpIn  KRIFilterOp = "in"
+	KRIFilterOpLte KRIFilterOp = "lte"
+	KRIFilterOpNeq KRIFilterOp = "neq"
+)
+
+// KRIQuery is a query KRI's definition.
+type KRIQuery struct {
+	Aggregate KRIAggregate `json:"aggregate"`
+	// Field is the numeric field aggregated; empty for count.
+	Field   string      `json:"field,omitempty"`
+	Filters []KRIFilter `json:"filters,omitempty"`
+	Subject KRISubject  `json:"subject"`
+	// WindowDays limits the rows to those created in the last N days
+	// ("incidents this month"). 0 = no window.
+	WindowDays int64 `json:"window_days,omitempty"`
+}
+
+// KRISource is where a KRI's values come from.
+type KRISource string
+
+const (
+	KRISourceCSV   KRISource = "csv"
+	KRISourcePush  KRISource = "push"
+	KRISourceQuery KRISource = "query"
+)
+
+// KRIStatus is the band a value falls in.
+type KRIStatus string
+
+const (
+	KRIStatusAmber   KRIStatus = "amber"
+	KRIStatusGreen   KRIStatus = "green"
+	KRIStatusRed     KRIStatus = "red"
+	KRIStatusUnknown KRIStatus = "unknown"
+)
+
+// KRISubject is the table a query KRI reads.
+type KRISubject string
+
+const (
+	KRISubjectAssets          KRISubject = "assets"
+	KRISubjectIncidents       KRISubject = "incidents"
+	KRISubjectRisks           KRISubject = "risks"
+	KRISubjectVulnerabilities KRISubject = "vulnerabilities"
+)
+
+// KRIValue is one measurement, kept forever: the trend is the point.
+type KRIValue struct {
+	CreatedAt  time.Time `json:"created_at"`
+	ID         string    `json:"id"`
+	KRIID      string    `json:"kri_id"`
+	MeasuredAt time.Time `json:"measured_at"`
+	RecordedBy string    `json:"recorded_by,omitempty"`
+	Source     KRISource `json:"source"`
+	Status     KRIStatus `json:"status"`
+	TenantID   string    `json:"tenant_id"`
+	Value      float64   `json:"value"`
+}
+
+// KnowledgeSnippet is one retrieved piece of tenant GRC context. The
+// application layer performs a hybrid keyword retrieval over the tenant's
+// risks, controls and vulnerabilities and passes the top matches here —
+// this is the "RAG context".
+type KnowledgeSnippet struct {
+	Detail string `json:"detail"`
+	// "risk"|"control"|"vulnerability"|"framework"
+	Kind string `json:"kind"`
+	// reference code / CVE / name
+	Ref   string `json:"ref"`
+	Title string `json:"title"`
+}
+
+// Library is GET /risk-scenarios: the built-in release and the tenant's
+// own scenarios after it.
+type Library struct {
+	LibraryVersion string         `json:"library_version"`
+	Scenarios      []RiskScenario `json:"scenarios"`
+}
+
+// LimitDTO carries a cap and its current usage. Limit == -1 means
+// unlimited.
+type LimitDTO struct {
+	Limit int64 `json:"limit"`
+	Used  int64 `json:"used"`
+}
+
+// LinkRequest names the organization to take in as a subsidiary, by id or
+// by slug — the holding's administrator is not necessarily a member of
+// it.
+type LinkRequest struct {
+	ChildID   *string `json:"child_id,omitempty"`
+	ChildSlug string  `json:"child_slug"`
+}
+
+// LinkedControl is a control a programme implements or strengthens.
+type LinkedControl struct {
+	ControlID     string `json:"control_id"`
+	Name          string `json:"name"`
+	ReferenceCode string `json:"reference_code"`
+}
+
+// LinkedRisk is a risk a programme treats, as the programme page shows it.
+type LinkedRisk struct {
+	Criticality       string  `json:"criticality"`
+	ExpectedReduction float64 `json:"expected_reduction"`
+	RiskID            string  `json:"risk_id"`
+	Score             float64 `json:"score"`
+	Title             string  `json:"title"`
+}
+
+// ListResult is a page of the library.
+type ListResult struct {
+	Items []Evidence `json:"items"`
+	// Counts by effective status across the WHOLE filtered set, not just this
+	// page — the tabs above a list must not change meaning when you
+	// paginate.
+	Summary StatusSummary `json:"summary"`
+	Total   int64         `json:"total"`
+}
+
+// ListRolesResponse contains paginated role list
+type ListRolesResponse struct {
+	HasMore    bool           `json:"has_more"`
+	Limit      int64          `json:"limit"`
+	Offset     int64          `json:"offset"`
+	Roles      []RoleEnhanced `json:"roles"`
+	Total      int64          `json:"total"`
+	TotalPages int64          `json:"total_pages"`
+}
+
+// ListTenantsResponse contains paginated tenant list
+type ListTenantsResponse struct {
+	HasMore    bool     `json:"has_more"`
+	Limit      int64    `json:"limit"`
+	Offset     int64    `json:"offset"`
+	Tenants    []Tenant `json:"tenants"`
+	Total      int64    `json:"total"`
+	TotalPages int64    `json:"total_pages"`
+}
+
+// ListUsersResponse contains paginated user list
+type ListUsersResponse struct {
+	HasMore    bool            `json:"has_more"`
+	Limit      int64           `json:"limit"`
+	Offset     int64           `json:"offset"`
+	Total      int64           `json:"total"`
+	TotalPages int64           `json:"total_pages"`
+	Users      json.RawMessage `json:"users"`
+}
+
+// LivePullResult summarises one live pull.
+type LivePullResult struct {
+	Created       int64      `json:"created"`
+	IntegrationID string     `json:"integration_id"`
+	Received      int64      `json:"received"`
+	Skipped       int64      `json:"skipped"`
+	Source        VulnSource `json:"source"`
+	Supported     bool       `json:"supported"`
+	Updated       int64      `json:"updated"`
+}
+
+// LoginRequest represents the login request body
+type LoginRequest struct {
+	DeviceFingerprint string `json:"device_fingerprint,omitempty"`
+	Email             string `json:"email"`
+	Password          string `json:"password"`
+}
+
+// LoginResponse represents the login response
+type LoginResponse struct {
+	// BusinessRole is the member's GRC job-role preset (rssi/dsi/…) or ""
+	// for root/admin. The frontend uses it to choose a role-appropriate
+	// landing.
+	BusinessRole BusinessRoleKey `json:"business_role,omitempty"`
+	// CSRFToken mirrors the readable or_csrf cookie. Returning it here spares
+	// the SPA a cookie read on first load; it is not a secret from the client,
+	// only from other origins.
+	CsrfToken    string          `json:"csrf_token,omitempty"`
+	Organization json.RawMessage `json:"organization,omitempty"`
+	TokenPair    json.RawMessage `json:"token_pair,omitempty"`
+	User         json.RawMessage `json:"user,omitempty"`
+}
+
+// LogoutRequest represents the logout request body
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// MappingInput selects the mapping to run: explicit, or a saved profile's.
+type MappingInput struct {
+	Mapping   ImportMapping `json:"mapping"`
+	ProfileID *string       `json:"profile_id,omitempty"`
+}
+
+// MarketplaceApp represents an installed marketplace application
+type MarketplaceApp struct {
+	AutoSync      bool           `json:"auto_sync"`
+	Configuration map[string]any `json:"configuration"`
+	// Connector details (populated from marketplace)
+	Connector         *Connector         `json:"connector,omitempty"`
+	ConnectorID       string             `json:"connector_id"`
+	CreatedAt         time.Time          `json:"created_at"`
+	Description       string             `json:"description"`
+	Enabled           bool               `json:"enabled"`
+	ID                string             `json:"id"`
+	InstallationDate  time.Time          `json:"installation_date"`
+	IsWebhookVerified bool               `json:"is_webhook_verified"`
+	LastSyncAt        *time.Time         `json:"last_sync_at,omitempty"`
+	LastSyncError     *string            `json:"last_sync_error,omitempty"`
+	LastSyncStatus    string             `json:"last_sync_status"`
+	Name              string             `json:"name"`
+	Status            InstallationStatus `json:"status"`
+	// seconds
+	SyncInterval int64     `json:"sync_interval"`
+	TenantID     string    `json:"tenant_id"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	UserID       string    `json:"user_id"`
+	Version      string    `json:"version"`
+	WebhookURL   string    `json:"webhook_url"`
+}
+
+// MarketplaceLog represents activity logs for marketplace apps
+type MarketplaceLog struct {
+	// install, uninstall, update, enable, disable, sync, config_change
+	Action       string         `json:"action"`
+	AppID        string         `json:"app_id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	Details      map[string]any `json:"details"`
+	ErrorMessage *string        `json:"error_message,omitempty"`
+	// milliseconds
+	ExecutionTime int64  `json:"execution_time"`
+	ID            string `json:"id"`
+	// success, failure
+	Status string `json:"status"`
+	UserID string `json:"user_id"`
+}
+
+// MaterialiseResult reports what was created.
+type MaterialiseResult struct {
+	Created int64 `json:"created"`
+	// Skipped covers links that already existed in either direction —
+	// importing twice must not double anything.
+	Skipped int64 `json:"skipped"`
+	// Unmatched counts curated entries whose control could not be found in
+	// either framework. Reported rather than swallowed: a silently unmatched
+	// crosswalk is an inherited-coverage number that under-reports for no
+	// visible reason.
+	Unmatched int64 `json:"unmatched"`
+}
+
+// MatrixCell is one cell of the 5x5 probability x impact heatmap, counted
+// in SQL. Probability and Impact are 1-5 display bands, not the stored
+// values: the Score Engine works on probability [0,1] and impact [0,10],
+// and the banding is done in the query so every consumer bands
+// identically.
+type MatrixCell struct {
+	Count       int64 `json:"count"`
+	Impact      int64 `json:"impact"`
+	Probability int64 `json:"probability"`
+}
+
+// MemberRole represents a role within an organization
+type MemberRole string
+
+const (
+	MemberRoleAdmin MemberRole = "admin"
+	MemberRoleRoot  MemberRole = "root"
+	MemberRoleUser  MemberRole = "user"
+)
+
+// MemberView is one row of the member list. It carries identity, access
+// and timestamps, and nothing else: no password hash, no MFA state, no
+// tokens.
+type MemberView struct {
+	// Always emitted, never omitempty: a client has to be able to tell "this
+	// member has no preset" from "the server did not say", and the members
+	// screen renders one of those as an empty dropdown and the other as stale.
+	BusinessRole  BusinessRoleKey `json:"business_role"`
+	DeactivatedAt *time.Time      `json:"deactivated_at,omitempty"`
+	Email         string          `json:"email"`
+	FullName      string          `json:"full_name"`
+	InvitedByID   string          `json:"invited_by_id,omitempty"`
+	IsActive      bool            `json:"is_active"`
+	// IsOwner marks the organization owner, whose role and membership are not
+	// administered through this API.
+	IsOwner   bool       `json:"is_owner"`
+	JoinedAt  time.Time  `json:"joined_at"`
+	LastLogin *time.Time `json:"last_login,omitempty"`
+	MemberID  string     `json:"member_id"`
+	OrgRole   MemberRole `json:"org_role"`
+	// Permissions is the member's resolved effective access, so the UI shows
+	// what someone can actually do rather than what their role is called.
+	Permissions []string         `json:"permissions"`
+	RevokedAt   *time.Time       `json:"revoked_at,omitempty"`
+	Status      MembershipStatus `json:"status"`
+	UserID      string           `json:"user_id"`
+}
+
+// MembershipStatus is the lifecycle state of an organization membership.
+type MembershipStatus string
+
+const (
+	MembershipStatusActive      MembershipStatus = "active"
+	MembershipStatusDeactivated MembershipStatus = "deactivated"
+	MembershipStatusInvited     MembershipStatus = "invited"
+	MembershipStatusRevoked     MembershipStatus = "revoked"
+)
+
+// MembershipSummary is one row in a user's organization switcher.
+type MembershipSummary struct {
+	BusinessRole   BusinessRoleKey `json:"business_role,omitempty"`
+	IsDefault      bool            `json:"is_default"`
+	Name           string          `json:"name"`
+	OrganizationID string          `json:"organization_id"`
+	Role           MemberRole      `json:"role"`
+	Slug           string          `json:"slug"`
+}
+
+// Methodology is the audit trail for a quantified figure (spec §4): the
+// model, its exact intrants, assumptions, run parameters and the reference
+// rate used. ComputedAt is stamped by the caller (handler/use case), not
+// the pure engine.
+type Methodology struct {
+	Assumptions    []string  `json:"assumptions"`
+	ComputedAt     time.Time `json:"computed_at"`
+	Currency       Currency  `json:"currency"`
+	DocURL         string    `json:"doc_url"`
+	FormulaVersion string    `json:"formula_version"`
+	FxAsOf         time.Time `json:"fx_as_of"`
+	// XAF per 1 unit of Currency
+	FxRateXaf  float64            `json:"fx_rate_xaf"`
+	Inputs     []MethodologyInput `json:"inputs"`
+	Iterations int64              `json:"iterations"`
+	Model      string             `json:"model"`
+	Seed       int64              `json:"seed"`
+}
+
+// MethodologyInput is one intrant surfaced in the explainability panel:
+// what value went into the model, in what unit, and where it came from.
+type MethodologyInput struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	// "risk-input" | "reference-model" | "derived"
+	Source string  `json:"source"`
+	Unit   string  `json:"unit"`
+	Value  float64 `json:"value"`
+}
+
+// MissingControl is one control and the state of its proof.
+type MissingControl struct {
+	ControlID        string        `json:"control_id"`
+	ControlStatus    ControlStatus `json:"control_status"`
+	CoveringEvidence int64         `json:"covering_evidence"`
+	Kind             MissingKind   `json:"kind"`
+	Name             string        `json:"name"`
+	NearestExpiry    *time.Time    `json:"nearest_expiry,omitempty"`
+	ReferenceCode    string        `json:"reference_code"`
+	// TotalEvidence counts every attached artifact; CoveringEvidence counts
+	// only those that currently substantiate. The gap between the two numbers
+	// is the story: "4 documents, none of them still valid".
+	TotalEvidence int64 `json:"total_evidence"`
+}
+
+// MissingKind distinguishes the two ways a control can lack proof, because
+// they are different jobs for different people on different days.
+type MissingKind string
+
+const (
+	MissingKindCovered       MissingKind = "covered"
+	MissingKindExpiringSoon  MissingKind = "expiring_soon"
+	MissingKindNoEvidence    MissingKind = "no_evidence"
+	MissingKindStaleEvidence MissingKind = "stale_evidence"
+)
+
+// Mitigation represents a mitigation plan for a risk
+type Mitigation struct {
+	ApprovedAt *time.Time `json:"approved_at,omitempty"`
+	ApprovedBy *string    `json:"approved_by,omitempty"`
+	// Deprecated: multi-user assignment (JSONB array). Superseded by
+	// Ownership.AssigneeID, which migration 0044 backfills from its first
+	// element. Kept so existing readers keep working.
+	AssignedTo UUIDArray `json:"assigned_to"`
+	// Legacy: email or UserID
+	Assignee       string     `json:"assignee"`
+	AssigneeEmail  string     `json:"assignee_email,omitempty"`
+	AssigneeID     *string    `json:"assignee_id,omitempty"`
+	AutoDetectedAt *time.Time `json:"auto_detected_at,omitempty"`
+	Cost           int64      `json:"cost"`
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	// Lifecycle tracking
+	CreatedBy   string     `json:"created_by"`
+	Description string     `json:"description"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	// ExternalID is the plan's key in the tool it was imported from — the
+	// upsert key of a re-import. Empty for plans created here.
+	ExternalID     string `json:"external_id,omitempty"`
+	ID             string `json:"id"`
+	MitigationTime int64  `json:"mitigation_time"`
+	// Legacy fields for backwards compatibility
+	OrganizationID string `json:"organization_id"`
+	// Computed, NOT persisted — resolved by the list/get use cases through a
+	// UserLookup port so the UI can render an avatar without an extra
+	// round-trip per row. Degrades to empty when the lookup is unavailable.
+	OwnerEmail string             `json:"owner_email,omitempty"`
+	OwnerID    *string            `json:"owner_id,omitempty"`
+	Priority   MitigationPriority `json:"priority"`
+	// Progress: 0-100, ALWAYS COMPUTED, never accepted from a client. See
+	// ComputeMitigationProgress for the rule. It is persisted only so the
+	// board can sort and filter on it; every mutation recomputes it
+	// server-side.
+	Progress         int64      `json:"progress"`
+	ReminderD1SentAt *time.Time `json:"reminder_d1_sent_at,omitempty"`
+	// Reminder bookkeeping for the D-7 / D-1 due-date nudges. Each is stamped
+	// once, so a sweep that runs every hour does not send the same reminder
+	// twenty-four times. Cleared when the due date moves.
+	ReminderD7SentAt *time.Time `json:"reminder_d7_sent_at,omitempty"`
+	ReviewerEmail    string     `json:"reviewer_email,omitempty"`
+	ReviewerID       *string    `json:"reviewer_id,omitempty"`
+	// Relations
+	Risk   *Risk  `json:"risk,omitempty"`
+	RiskID string `json:"risk_id"`
+	// Link to scanner config if auto-detected
+	ScannerConfigID *string `json:"scanner_config_id,omitempty"`
+	// Source: manual|scanner|cti|ai (using domain.RiskSource shared enum)
+	Source     RiskSource             `json:"source"`
+	Status     DomainMitigationStatus `json:"status"`
+	SubActions []MitigationSubAction  `json:"sub_actions,omitempty"`
+	TenantID   string                 `json:"tenant_id"`
+	// Core fields
+	Title            string    `json:"title"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	WeightedPriority float64   `json:"weighted_priority"`
+}
+
+// MitigationCoverage is one control reference of a technique and the
+// tenant controls answering it. An empty Controls means the tenant has no
+// such control.
+type MitigationCoverage struct {
+	Catalog  string           `json:"catalog"`
+	Controls []CoveredControl `json:"controls"`
+	Ref      string           `json:"ref"`
+}
+
+// MitigationMetrics represents mitigation analytics
+type MitigationMetrics struct {
+	AvgCompletionDays    float64          `json:"avg_completion_days"`
+	CompletedMitigations int64            `json:"completed_mitigations"`
+	CompletionRate       float64          `json:"completion_rate"`
+	MitigationsByRisk    map[string]int64 `json:"mitigations_by_risk"`
+	OverdueMitigations   int64            `json:"overdue_mitigations"`
+	PendingMitigations   int64            `json:"pending_mitigations"`
+	RisksWithMitigation  int64            `json:"risks_with_mitigation"`
+	TotalMitigations     int64            `json:"total_mitigations"`
+}
+
+type MitigationMetricsData struct {
+	AverageTimeDays       float64 `json:"average_time_days"`
+	CompletedMitigations  int64   `json:"completed_mitigations"`
+	CompletionRate        float64 `json:"completion_rate"`
+	InProgressMitigations int64   `json:"in_progress_mitigations"`
+	PlannedMitigations    int64   `json:"planned_mitigations"`
+	TotalMitigations      int64   `json:"total_mitigations"`
+}
+
+type MitigationPriority string
+
+const (
+	MitigationPriorityCritical MitigationPriority = "critical"
+	MitigationPriorityHigh     MitigationPriority = "high"
+	MitigationPriorityLow      MitigationPriority = "low"
+	MitigationPriorityMedium   MitigationPriority = "medium"
+)
+
+// MitigationProgramme is a mitigation project shared by many risks and
+// controls. Status reuses the plan statuses.
+type MitigationProgramme struct {
+	ControlCount int64      `json:"control_count"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CreatedBy    string     `json:"created_by,omitempty"`
+	Description  string     `json:"description"`
+	ID           string     `json:"id"`
+	OwnerID      string     `json:"owner_id,omitempty"`
+	Progress     int64      `json:"progress"`
+	ProjectedEnd *time.Time `json:"projected_end,omitempty"`
+	// Computed on read.
+	RiskCount int64 `json:"risk_count"`
+	SlipDays  int64 `json:"slip_days"`
+	// StartDate is when work may begin; the scheduler never plans before it,
+	// nor before today.
+	StartDate time.Time              `json:"start_date"`
+	Status    DomainMitigationStatus `json:"status"`
+	// TargetDate is the committed delivery date. Optional: without one there
+	// is nothing to slip against.
+	TargetDate *time.Time `json:"target_date,omitempty"`
+	TenantID   string     `json:"tenant_id"`
+	Title      string     `json:"title"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// MitigationProgress represents a mitigation with progress details
+type MitigationProgress struct {
+	Cost          float64   `json:"cost"`
+	DaysRemaining int64     `json:"days_remaining"`
+	DueDate       time.Time `json:"due_date"`
+	ID            string    `json:"id"`
+	LastUpdated   time.Time `json:"last_updated"`
+	Name          string    `json:"name"`
+	Owner         string    `json:"owner,omitempty"`
+	// 0-100
+	Progress int64  `json:"progress"`
+	RiskID   string `json:"risk_id"`
+	RiskName string `json:"risk_name"`
+	Status   string `json:"status"`
+}
+
+// MitigationSubAction represents a sub-task/checklist item within a
+// mitigation plan
+type MitigationSubAction struct {
+	// Auto-detection tracking (scanner ran and detected fix)
+	AutoDetectedAt *time.Time `json:"auto_detected_at,omitempty"`
+	// Completion tracking
+	Completed   bool       `json:"completed"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// nil if auto-detected
+	CompletedBy *string `json:"completed_by,omitempty"`
+	// Source of completion: manual|scanner|ai
+	CompletedSource json.RawMessage `json:"completed_source,omitempty"`
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	// Dependency management: this action depends on DependsOn
+	DependsOn   *string `json:"depends_on,omitempty"`
+	Description string  `json:"description"`
+	// Due date
+	DueDate      *time.Time `json:"due_date,omitempty"`
+	ID           string     `json:"id"`
+	MitigationID string     `json:"mitigation_id"`
+	// Ordering for UI display
+	Order int64 `json:"order"`
+	// Core fields
+	Title     string    `json:"title"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ModelDescription is the payload of GET /score/model.
+type ModelDescription struct {
+	Bands          []BandRange `json:"bands"`
+	FormulaVersion string      `json:"formula_version"`
+	// InputBounds documents the accepted range of every raw input, so a form
+	// can validate against the model rather than against a hard-coded guess.
+	InputBounds map[string][]float64 `json:"input_bounds"`
+	MaxValue    float64              `json:"max_value"`
+	MinValue    float64              `json:"min_value"`
+	Scopes      []ScopeModel         `json:"scopes"`
+}
+
+// Money is an amount expressed in both currencies.
+type Money struct {
+	Usd float64 `json:"usd"`
+	Xaf float64 `json:"xaf"`
+}
+
+// MonthlyRiskPoint is one month of the register's risk evolution.
+type MonthlyRiskPoint struct {
+	AvgScore float64 `json:"avg_score"`
+	Critical int64   `json:"critical"`
+	High     int64   `json:"high"`
+	// "YYYY-MM"
+	Month string `json:"month"`
+	Total int64  `json:"total"`
+}
+
+// Node is one box of the bowtie. Barrier nodes carry their health and what
+// it was derived from; the others carry none.
+type Node struct {
+	ControlID        string        `json:"control_id,omitempty"`
+	ControlLabel     string        `json:"control_label,omitempty"`
+	Description      string        `json:"description,omitempty"`
+	Effectiveness    float64       `json:"effectiveness,omitempty"`
+	Health           BarrierHealth `json:"health,omitempty"`
+	ID               string        `json:"id"`
+	Kind             string        `json:"kind"`
+	Label            string        `json:"label"`
+	MitigationID     string        `json:"mitigation_id,omitempty"`
+	MitigationStatus string        `json:"mitigation_status,omitempty"`
+	MitigationTitle  string        `json:"mitigation_title,omitempty"`
+	PathID           string        `json:"path_id,omitempty"`
+	TestOverdue      bool          `json:"test_overdue,omitempty"`
+}
+
+// Notification represents a user notification JSON tags are explicit
+// because this type crosses the API boundary to the notification bell.
+// Without them encoding/json emits Go field names ("Subject", "ReadAt")
+// and, worse, serialises the User and Tenant relations — shipping a
+// whole user record inside every notification. Both relations are cut from
+// the payload; a caller that needs them can fetch them.
+type Notification struct {
+	Channel     NotificationChannel `json:"channel"`
+	CreatedAt   time.Time           `json:"created_at"`
+	DeliveredAt *time.Time          `json:"delivered_at,omitempty"`
+	// Longer description
+	Description string `json:"description,omitempty"`
+	ID          string `json:"id"`
+	// Notification message
+	Message  string         `json:"message"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+	ReadAt   *time.Time     `json:"read_at,omitempty"`
+	// ID of the resource (risk, mitigation, etc.)
+	ResourceID   string             `json:"resource_id,omitempty"`
+	ResourceType string             `json:"resource_type,omitempty"`
+	SentAt       *time.Time         `json:"sent_at,omitempty"`
+	Status       NotificationStatus `json:"status"`
+	// Email subject or title
+	Subject   string           `json:"subject"`
+	TenantID  string           `json:"tenant_id"`
+	Type      NotificationType `json:"type"`
+	UpdatedAt time.Time        `json:"updated_at"`
+	UserID    string           `json:"user_id"`
+}
+
+// NotificationChannel defines the channel through which to send
+// notification
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail   NotificationChannel = "email"
+	NotificationChannelInApp   NotificationChannel = "in_app"
+	NotificationChannelSlack   NotificationChannel = "slack"
+	NotificationChannelTeams   NotificationChannel = "teams"
+	NotificationChannelWebhook NotificationChannel = "webhook"
+)
+
+// NotificationDraft is a ready-to-send notification for one clock.
+type NotificationDraft struct {
+	Authority        string       `json:"authority"`
+	AuthorityContact string       `json:"authority_contact,omitempty"`
+	Clock            ClockView    `json:"clock"`
+	Fields           []DraftField `json:"fields"`
+	Regime           string       `json:"regime"`
+}
+
+// NotificationPreference defines user notification preferences
+type NotificationPreference struct {
+	CreatedAt time.Time `json:"CreatedAt"`
+	// General preferences
+	DisableAllNotifications bool `json:"DisableAllNotifications"`
+	// Notify N days before deadline
+	EmailDeadlineAdvanceDays int64 `json:"EmailDeadlineAdvanceDays"`
+	EmailOnActionAssigned    bool  `json:"EmailOnActionAssigned"`
+	EmailOnCriticalRisk      bool  `json:"EmailOnCriticalRisk"`
+	// Email preferences
+	EmailOnMitigationDeadline  bool   `json:"EmailOnMitigationDeadline"`
+	EmailOnRiskResolved        bool   `json:"EmailOnRiskResolved"`
+	EmailOnRiskUpdate          bool   `json:"EmailOnRiskUpdate"`
+	EnableDesktopNotifications bool   `json:"EnableDesktopNotifications"`
+	EnableSoundNotifications   bool   `json:"EnableSoundNotifications"`
+	ID                         string `json:"ID"`
+	// Override default channel
+	SlackChannelOverride string `json:"SlackChannelOverride"`
+	// Slack preferences
+	SlackEnabled              bool `json:"SlackEnabled"`
+	SlackOnActionAssigned     bool `json:"SlackOnActionAssigned"`
+	SlackOnCriticalRisk       bool `json:"SlackOnCriticalRisk"`
+	SlackOnMitigationDeadline bool `json:"SlackOnMitigationDeadline"`
+	// Not stored in DB
+	SlackWebhookURL string          `json:"SlackWebhookURL"`
+	Tenant          json.RawMessage `json:"Tenant,omitempty"`
+	TenantID        string          `json:"TenantID"`
+	UpdatedAt       time.Time       `json:"UpdatedAt"`
+	// Relations
+	User   json.RawMessage `json:"User,omitempty"`
+	UserID string          `json:"UserID"`
+	// Webhook preferences
+	WebhookEnabled              bool `json:"WebhookEnabled"`
+	WebhookOnActionAssigned     bool `json:"WebhookOnActionAssigned"`
+	WebhookOnCriticalRisk       bool `json:"WebhookOnCriticalRisk"`
+	WebhookOnMitigationDeadline bool `json:"WebhookOnMitigationDeadline"`
+	// Not stored in DB
+	WebhookSecret string `json:"WebhookSecret"`
+	// Not stored in DB
+	WebhookURL string `json:"WebhookURL"`
+}
+
+// NotificationStatus defines the status of a notification
+type NotificationStatus string
+
+const (
+	NotificationStatusDelivered NotificationStatus = "delivered"
+	NotificationStatusFailed    NotificationStatus = "failed"
+	NotificationStatusPending   NotificationStatus = "pending"
+	NotificationStatusRead      NotificationStatus = "read"
+	NotificationStatusSent      NotificationStatus = "sent"
+)
+
+// NotificationType defines the type of notification
+type NotificationType string
+
+const (
+	NotificationTypeActionAssigned     NotificationType = "action_assigned"
+	NotificationTypeAutomation         NotificationType = "automation"
+	NotificationTypeCriticalRisk       NotificationType = "critical_risk"
+	NotificationTypeMitigationDeadline NotificationType = "mitigation_deadline"
+	NotificationTypeRiskResolved       NotificationType = "risk_resolved"
+	NotificationTypeRiskReview         NotificationType = "risk_review"
+	NotificationTypeRiskUpdate         NotificationType = "risk_update"
+	NotificationTypeScanComplete       NotificationType = "scan_complete"
+	NotificationTypeSLABreach          NotificationType = "sla_breach"
+)
+
+// OrgDeletionRequest records a requested tenant erasure. Creating one
+// requires (enforced at the endpoint, not here): a completed data export,
+// the operator typing the exact organization name, and a fresh MFA
+// challenge. This struct is the durable, auditable record of that intent
+// and its scheduled purge.
+type OrgDeletionRequest struct {
+	CanceledAt  *time.Time `json:"canceled_at,omitempty"`
+	CanceledBy  string     `json:"canceled_by,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// ConfirmedName is the exact name the operator typed to confirm; stored so
+	// the record shows the confirmation actually happened.
+	ConfirmedName string    `json:"confirmed_name"`
+	CreatedAt     time.Time `json:"created_at"`
+	// ExportPath points at the pre-deletion export the operator was required
+	// to produce. Empty is not allowed by the create use case.
+	ExportPath       string            `json:"export_path,omitempty"`
+	ID               string            `json:"id"`
+	OrganizationID   string            `json:"organization_id"`
+	Reason           string            `json:"reason,omitempty"`
+	RequestedBy      string            `json:"requested_by"`
+	ScheduledPurgeAt time.Time         `json:"scheduled_purge_at"`
+	Status           OrgDeletionStatus `json:"status"`
+}
+
+// OrgDeletionStatus is the lifecycle of a danger-zone
+// organization-deletion.
+type OrgDeletionStatus string
+
+const (
+	OrgDeletionStatusCanceled  OrgDeletionStatus = "canceled"
+	OrgDeletionStatusCompleted OrgDeletionStatus = "completed"
+	OrgDeletionStatusPending   OrgDeletionStatus = "pending"
+)
+
+// OrgPlan represents the subscription plan level for an organization
+type OrgPlan string
+
+const (
+	OrgPlanBusiness     OrgPlan = "business"
+	OrgPlanEnterprise   OrgPlan = "enterprise"
+	OrgPlanFree         OrgPlan = "free"
+	OrgPlanPro          OrgPlan = "pro"
+	OrgPlanProfessional OrgPlan = "professional"
+	OrgPlanStarter      OrgPlan = "starter"
+)
+
+// OrgSize represents the size category of an organization
+type OrgSize string
+
+const (
+	OrgSizeX150     OrgSize = "1-50"
+	OrgSizeX1000    OrgSize = "1000+"
+	OrgSizeX2011000 OrgSize = "201-1000"
+	OrgSizeX51200   OrgSize = "51-200"
+)
+
+// Organization represents a tenant/organization in the multi-tenant system
+type Organization struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	Industry  string    `json:"industry,omitempty"`
+	IsActive  bool      `json:"is_active"`
+	LogoURL   string    `json:"logo_url,omitempty"`
+	// Relations
+	Members []OrganizationMember `json:"members,omitempty"`
+	Name    string               `json:"name"`
+	// constraint:- : registration creates the Organization row with a
+	// placeholder OwnerID before the owning User row exists (see
+	// application/auth/register.go), and this is also the other half of a
+	// circular belongsTo with User.DefaultOrg — no DB-level FK either side.
+	Owner     *User           `json:"owner,omitempty"`
+	OwnerID   string          `json:"owner_id"`
+	Plan      OrgPlan         `json:"plan"`
+	Profiles  []Profile       `json:"profiles,omitempty"`
+	Settings  json.RawMessage `json:"settings"`
+	Size      OrgSize         `json:"size,omitempty"`
+	Slug      string          `json:"slug"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// OrganizationCounts is the tenant's membership headline, computed in one
+// pass so the sidebar does not need a query per number.
+type OrganizationCounts struct {
+	ActiveMembers      int64 `json:"active_members"`
+	Admins             int64 `json:"admins"`
+	DeactivatedMembers int64 `json:"deactivated_members"`
+	PendingInvitations int64 `json:"pending_invitations"`
+	RevokedMembers     int64 `json:"revoked_members"`
+	TotalMembers       int64 `json:"total_members"`
+}
+
+// OrganizationLink makes ChildID a subsidiary of ParentID. While it is
+// active, members of the parent holding "group:read" can read the child's
+// register in aggregate and drill into it; nothing in the link lets
+// anybody write to the child, and nothing lets the child read its parent.
+// An organization has at most one parent at a time (a pending or active
+// link as child), which is what keeps the hierarchy a tree and the roll-up
+// free of double counting. Revoked links are kept for the record.
+type OrganizationLink struct {
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	AcceptedBy string     `json:"accepted_by,omitempty"`
+	ChildID    string     `json:"child_id"`
+	ChildName  string     `json:"child_name,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ID         string     `json:"id"`
+	ParentID   string     `json:"parent_id"`
+	// Filled on read so the settings screen can name both sides.
+	ParentName  string                 `json:"parent_name,omitempty"`
+	RequestedBy string                 `json:"requested_by,omitempty"`
+	RevokedAt   *time.Time             `json:"revoked_at,omitempty"`
+	RevokedBy   string                 `json:"revoked_by,omitempty"`
+	Status      OrganizationLinkStatus `json:"status"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+// OrganizationLinkStatus is where a parent/child link stands. A link is
+// asked for by the parent and only grants anything once the child has
+// accepted it: a holding cannot read a subsidiary's register on its own
+// say-so.
+type OrganizationLinkStatus string
+
+const (
+	OrganizationLinkStatusActive  OrganizationLinkStatus = "active"
+	OrganizationLinkStatusPending OrganizationLinkStatus = "pending"
+	OrganizationLinkStatusRevoked OrganizationLinkStatus = "revoked"
+)
+
+// OrganizationMember represents a user's membership in an organization
+type OrganizationMember struct {
+	// BusinessRole is the GRC job-role preset (rssi, dsi, risk_manager, …)
+	// that grants a role='user' member a coherent, least-privilege permission
+	// set at login. Empty for root/admin (they get the "*" wildcard) or for
+	// legacy profile-driven users. See business_roles.go.
+	BusinessRole  BusinessRoleKey `json:"business_role,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DeactivatedAt *time.Time      `json:"deactivated_at,omitempty"`
+	ID            string          `json:"id"`
+	InvitedBy     *User           `json:"invited_by,omitempty"`
+	InvitedByID   string          `json:"invited_by_id,omitempty"`
+	// IsActive is the boolean the login/refresh path reads. It is DERIVED from
+	// Status by SetStatus (membership_lifecycle.go), never written by hand —
+	// two fields free to disagree is how "deactivated but still signing in"
+	// happens.
+	IsActive       bool          `json:"is_active"`
+	JoinedAt       time.Time     `json:"joined_at"`
+	Organization   *Organization `json:"organization,omitempty"`
+	OrganizationID string        `json:"organization_id"`
+	Profile        *Profile      `json:"profile,omitempty"`
+	// For role='user' only
+	ProfileID string     `json:"profile_id,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	Role      MemberRole `json:"role"`
+	// Status is the explicit lifecycle state (active | deactivated | revoked).
+	// Rows written before this column existed carry "" and are read through
+	// EffectiveStatus, which falls back to IsActive.
+	Status    MembershipStatus `json:"status"`
+	UpdatedAt time.Time        `json:"updated_at"`
+	User      *User            `json:"user,omitempty"`
+	UserID    string           `json:"user_id"`
+}
+
+// OrganizationView is the tenant's own profile, as the Settings screen
+// shows it. Every field is read from the organizations row or computed
+// from the tenant's memberships — none of it is inferred from the
+// browser, and none of it is a constant. Settings used to render the
+// organization name out of a login response, the time zone out of
+// Intl.DateTimeFormat() (the VIEWER's zone, not the organization's), and
+// nothing else at all. A field appears here only if the backend genuinely
+// holds it.
+type OrganizationView struct {
+	// CanEdit tells the UI whether to render the profile as a form or as
+	// read-only, using the server's answer rather than its own guess.
+	CanEdit bool `json:"can_edit"`
+	// Counts are the live membership numbers, so the profile and the members
+	// screen can never disagree about how many people are in the organization.
+	Counts    OrganizationCounts `json:"counts"`
+	CreatedAt time.Time          `json:"created_at"`
+	ID        string             `json:"id"`
+	Industry  string             `json:"industry,omitempty"`
+	IsActive  bool               `json:"is_active"`
+	LogoURL   string             `json:"logo_url,omitempty"`
+	Name      string             `json:"name"`
+	OwnerID   string             `json:"owner_id"`
+	OwnerName string             `json:"owner_name,omitempty"`
+	Plan      OrgPlan            `json:"plan"`
+	Size      OrgSize            `json:"size,omitempty"`
+	Slug      string             `json:"slug"`
+	Timezone  string             `json:"timezone,omitempty"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// Page is a page of a frozen register.
+type Page struct {
+	Items    []RegisterSnapshotRisk `json:"items"`
+	Snapshot json.RawMessage        `json:"snapshot,omitempty"`
+	Total    int64                  `json:"total"`
+}
+
+// PageAuditEntryView is page wraps a listing with its total, so a client
+// can paginate over a number that does not change as it pages.
+type PageAuditEntryView struct {
+	Items  []AuditEntryView `json:"items"`
+	Limit  int64            `json:"limit"`
+	Offset int64            `json:"offset"`
+	Total  int64            `json:"total"`
+}
+
+// PageInvitationView is page wraps a listing with its total, so a client
+// can paginate over a number that does not change as it pages.
+type PageInvitationView struct {
+	Items  []InvitationView `json:"items"`
+	Limit  int64            `json:"limit"`
+	Offset int64            `json:"offset"`
+	Total  int64            `json:"total"`
+}
+
+// PageMemberView is page wraps a listing with its total, so a client can
+// paginate over a number that does not change as it pages.
+type PageMemberView struct {
+	Items  []MemberView `json:"items"`
+	Limit  int64        `json:"limit"`
+	Offset int64        `json:"offset"`
+	Total  int64        `json:"total"`
+}
+
+type ParamsExtensions struct {
+	PersistedQuery *PersistedQueryRef `json:"persistedQuery,omitempty"`
+}
+
+// Params is a GraphQL request as POSTed (or sent as GET parameters).
+type Params struct {
+	Extensions    ParamsExtensions `json:"extensions"`
+	OperationName string           `json:"operationName"`
+	Query         string           `json:"query"`
+	Variables     map[string]any   `json:"variables"`
+}
+
+// PendingLink is what the requesting side learns of a link it asks for: an
+// id to follow or revoke it by. Whoever is behind the slug or id stays
+// unnamed until they accept, so a request cannot be used to look
+// organizations up.
+type PendingLink struct {
+	ID     string                 `json:"id"`
+	Status OrganizationLinkStatus `json:"status"`
+}
+
+// PermissionDB represents a permission in the database
+type PermissionDB struct {
+	Action      string          `json:"action"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Description string          `json:"description"`
+	ID          string          `json:"id"`
+	IsSystem    bool            `json:"is_system"`
+	Metadata    json.RawMessage `json:"metadata,omitempty"`
+	Resource    string          `json:"resource"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// PermissionDef documents one permission string with bilingual labels so
+// the frontend can render a self-describing permission matrix.
+type PermissionDef struct {
+	Group   PermissionGroup `json:"group"`
+	Key     string          `json:"key"`
+	LabelEn string          `json:"label_en"`
+	LabelFr string          `json:"label_fr"`
+}
+
+// PermissionGroup buckets permissions by product domain, for the RBAC
+// matrix UI.
+type PermissionGroup string
+
+const (
+	PermissionGroupAssets          PermissionGroup = "assets"
+	PermissionGroupAutomation      PermissionGroup = "automation"
+	PermissionGroupCompliance      PermissionGroup = "compliance"
+	PermissionGroupGroup           PermissionGroup = "group"
+	PermissionGroupIncidents       PermissionGroup = "incidents"
+	PermissionGroupMitigations     PermissionGroup = "mitigations"
+	PermissionGroupOrganization    PermissionGroup = "organization"
+	PermissionGroupReports         PermissionGroup = "reports"
+	PermissionGroupRisks           PermissionGroup = "risks"
+	PermissionGroupScanner         PermissionGroup = "scanner"
+	PermissionGroupVulnerabilities PermissionGroup = "vulnerabilities"
+)
+
+// PersistedQueryRef is the automatic persisted query extension: the client
+// sends the hash alone and, when the server does not know it yet, the hash
+// with the query.
+type PersistedQueryRef struct {
+	Sha256Hash string `json:"sha256Hash"`
+	Version    int64  `json:"version"`
+}
+
+// PersonalAccessToken represents a PAT for API access
+type PersonalAccessToken struct {
+	CreatedAt   time.Time  `json:"created_at"`
+	Description string     `json:"description"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	ID          string     `json:"id"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	Name        string     `json:"name"`
+	// permission scopes
+	Scopes      json.RawMessage `json:"scopes"`
+	TokenPrefix string          `json:"token_prefix"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	// Relations
+	User   *User  `json:"user,omitempty"`
+	UserID string `json:"user_id"`
+}
+
+// PluginHook is an entry point a plugin can export.
+type PluginHook string
+
+const (
+	PluginHookCreateTicket     PluginHook = "create_ticket"
+	PluginHookEmitAssets       PluginHook = "emit_assets"
+	PluginHookPullFindings     PluginHook = "pull_findings"
+	PluginHookTransformWebhook PluginHook = "transform_webhook"
+)
+
+// PluginInstall is a package enabled for the tenant with its grants.
+type PluginInstall struct {
+	// AllowedHosts are the hosts the plugin may reach through the host's
+	// http_request; anything else is refused before a connection is made.
+	AllowedHosts StringList `json:"allowed_hosts"`
+	// Config is handed to every hook as its "config" input.
+	Config        json.RawMessage `json:"config"`
+	CreatedAt     time.Time       `json:"created_at"`
+	CreatedBy     string          `json:"created_by,omitempty"`
+	Enabled       bool            `json:"enabled"`
+	ID            string          `json:"id"`
+	LastRunAt     *time.Time      `json:"last_run_at,omitempty"`
+	LastRunStatus PluginRunStatus `json:"last_run_status,omitempty"`
+	MemoryPages   int64           `json:"memory_pages"`
+	Name          string          `json:"name"`
+	Package       *PluginPackage  `json:"package,omitempty"`
+	PackageID     string          `json:"package_id"`
+	// SecretNames lists the granted secrets; their values are encrypted in
+	// EncryptedSecrets and never returned.
+	SecretNames StringList `json:"secret_names"`
+	TenantID    string     `json:"tenant_id"`
+	TimeoutMs   int64      `json:"timeout_ms"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	// WebhookToken authenticates pushes to the transform_webhook hook. Every
+	// install gets one; pushes are refused when the package lacks the hook.
+	WebhookToken string `json:"webhook_token,omitempty"`
+}
+
+// PluginPackage is an uploaded, signature-verified plugin version. The
+// module bytes are kept so an install never depends on the bundle still
+// existing anywhere else.
+type PluginPackage struct {
+	AbiVersion  int64     `json:"abi_version"`
+	CreatedAt   time.Time `json:"created_at"`
+	Description string    `json:"description"`
+	// Digest is the hex SHA-256 of the module.
+	Digest string `json:"digest"`
+	// Hooks are the entry points the module exports.
+	Hooks StringList `json:"hooks"`
+	ID    string     `json:"id"`
+	// KeyID names the trusted publisher key the bundle was signed with.
+	KeyID     string `json:"key_id"`
+	Name      string `json:"name"`
+	Publisher string `json:"publisher"`
+	// RequestedHosts and RequestedSecrets are what the manifest asks for. An
+	// install can grant a subset, never more.
+	RequestedHosts   StringList `json:"requested_hosts"`
+	RequestedSecrets StringList `json:"requested_secrets"`
+	Size             int64      `json:"size"`
+	TenantID         string     `json:"tenant_id"`
+	UploadedBy       string     `json:"uploaded_by,omitempty"`
+	Version          string     `json:"version"`
+}
+
+// PluginRun records one hook invocation: what it did, how long it took,
+// what the plugin logged and, on failure, why.
+type PluginRun struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	DurationMs int64      `json:"duration_ms"`
+	Error      string     `json:"error,omitempty"`
+	Hook       PluginHook `json:"hook"`
+	HTTPCalls  int64      `json:"http_calls"`
+	ID         string     `json:"id"`
+	InstallID  string     `json:"install_id"`
+	// Items counts what the hook produced: findings, assets or a ticket.
+	Items       int64           `json:"items"`
+	Logs        StringList      `json:"logs"`
+	Status      PluginRunStatus `json:"status"`
+	TenantID    string          `json:"tenant_id"`
+	TriggeredBy string          `json:"triggered_by,omitempty"`
+}
+
+// PluginRunStatus is the outcome of one invocation.
+type PluginRunStatus string
+
+const (
+	PluginRunStatusFailed PluginRunStatus = "failed"
+	PluginRunStatusOk     PluginRunStatus = "ok"
+)
+
+// PolicyInput creates or replaces a policy.
+type PolicyInput struct {
+	Condition   json.RawMessage `json:"condition,omitempty"`
+	Description string          `json:"description"`
+	Effect      AccessEffect    `json:"effect"`
+	// Enabled defaults to true on create and is left alone on update when nil.
+	Enabled  *bool          `json:"enabled,omitempty"`
+	Name     string         `json:"name"`
+	Resource AccessResource `json:"resource"`
+	Subjects AccessSubjects `json:"subjects"`
+}
+
+// PortalView is the assessment as the vendor sees it: the questions, their
+// own answers so far, and nothing of the tenant but its name.
+type PortalView struct {
+	Answers           VendorAnswers          `json:"answers"`
+	ExpiresAt         time.Time              `json:"expires_at"`
+	OrganizationName  string                 `json:"organization_name,omitempty"`
+	QuestionnaireName string                 `json:"questionnaire_name"`
+	Questions         VendorQuestions        `json:"questions"`
+	State             VendorAssessmentStatus `json:"state"`
+	SubmittedAt       *time.Time             `json:"submitted_at,omitempty"`
+	VendorName        string                 `json:"vendor_name"`
+}
+
+// PortfolioSimulation is the group's annual loss band and each entity's.
+// The group band is ONE simulation over every risk of every entity, not
+// the sum of the entity bands: not every subsidiary has its worst year at
+// once. Diversification is how much the entities' P90s summed overstate
+// the group's.
+type PortfolioSimulation struct {
+	ComputedAt      time.Time           `json:"computed_at"`
+	Diversification Amount              `json:"diversification"`
+	Entities        []EntityLoss        `json:"entities"`
+	Group           DistributionAmounts `json:"group"`
+	Risks           int64               `json:"risks"`
+	SumOfEntityP90  Amount              `json:"sum_of_entity_p90"`
+}
+
+// PostMortemBody is postMortemBody is the editable review.
+type PostMortemBody struct {
+	ContributingFactors string                    `json:"contributing_factors"`
+	CorrectiveActions   []CorrectiveAction        `json:"corrective_actions"`
+	Detection           string                    `json:"detection"`
+	Impact              string                    `json:"impact"`
+	LessonsLearned      string                    `json:"lessons_learned"`
+	RootCause           string                    `json:"root_cause"`
+	Summary             string                    `json:"summary"`
+	Timeline            []PostMortemTimelineEntry `json:"timeline"`
+	WhatWentWell        string                    `json:"what_went_well"`
+}
+
+// PostMortemTimeline is a jsonb array.
+type PostMortemTimeline []PostMortemTimelineEntry
+
+// PostMortemTimelineEntry is one moment in the reconstructed story.
+// Separate from IncidentTimeline (the system's own log) because a review
+// timeline is authored: it says what people believed and decided, not only
+// what the platform recorded.
+type PostMortemTimelineEntry struct {
+	At     time.Time `json:"at"`
+	Detail string    `json:"detail,omitempty"`
+	// Kind marks the moments a review is measured by.
+	Kind  string `json:"kind,omitempty"`
+	Title string `json:"title"`
+}
+
+// PostMortemView is a review plus what still stands between it and
+// publication.
+type PostMortemView struct {
+	// BlocksClosure is the sentence the incident screen shows when the review
+	// is the reason the close button is disabled.
+	BlocksClosure string `json:"blocks_closure,omitempty"`
+	// Missing is the checklist a reviewer works through. Empty means
+	// publishable.
+	Missing    []string        `json:"missing"`
+	PostMortem json.RawMessage `json:"post_mortem,omitempty"`
+	// Required says whether this incident's severity makes the review
+	// mandatory before closing.
+	Required bool `json:"required"`
+}
+
+// PreviewInput is the body of POST /score/preview. It carries the values
+// the user is currently editing, so the form can show the real score
+// BEFORE saving. It is deliberately the same computation, in the same
+// package, as the persisted score: a preview that used a different formula
+// would be a lie told at exactly the moment the user is deciding.
+type PreviewInput struct {
+	AssetCriticality        *float64 `json:"asset_criticality,omitempty"`
+	Impact                  *float64 `json:"impact,omitempty"`
+	MitigationEffectiveness *float64 `json:"mitigation_effectiveness,omitempty"`
+	Probability             *float64 `json:"probability,omitempty"`
+	Scope                   string   `json:"scope"`
+}
+
+// Price is the monthly price of a plan in a region. Custom means "sur
+// devis" (contact sales) — amount is not meaningful.
+type Price struct {
+	// integer in the currency's major unit (EUR) or FCFA (XAF)
+	Amount int64 `json:"amount"`
+	// ISO 4217: "EUR" or "XAF"
+	Currency string `json:"currency"`
+	// Enterprise = quote-based
+	Custom bool `json:"custom"`
+	// "month"
+	Period string `json:"period"`
+}
+
+// Profile represents an IAM role/profile within an organization
+type Profile struct {
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   *User     `json:"created_by,omitempty"`
+	CreatedByID string    `json:"created_by_id"`
+	Description string    `json:"description,omitempty"`
+	ID          string    `json:"id"`
+	// auto-assigned to new members
+	IsDefault bool `json:"is_default"`
+	// true = built-in, not deletable
+	IsSystem       bool                 `json:"is_system"`
+	Members        []OrganizationMember `json:"members,omitempty"`
+	Name           string               `json:"name"`
+	Organization   *Organization        `json:"organization,omitempty"`
+	OrganizationID string               `json:"organization_id"`
+	// Relations
+	Permissions []ProfilePermission `json:"permissions,omitempty"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// ProfileEvaluation explains, per applicable profile, why a regime did or
+// did not start.
+type ProfileEvaluation struct {
+	Applies      bool             `json:"applies"`
+	Jurisdiction string           `json:"jurisdiction"`
+	ProfileID    string           `json:"profile_id"`
+	Reason       string           `json:"reason,omitempty"`
+	Regime       RegulatoryRegime `json:"regime"`
+}
+
+// ProfileInput is the body of POST /import-profiles and PUT
+// /import-profiles/:id.
+type ProfileInput struct {
+	Entity  ImportEntity  `json:"entity"`
+	Mapping ImportMapping `json:"mapping"`
+	Name    string        `json:"name"`
+}
+
+// ProfilePermission represents a specific permission granted to a profile
+type ProfilePermission struct {
+	Action     Action          `json:"action"`
+	Conditions json.RawMessage `json:"conditions,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	ID         string          `json:"id"`
+	Profile    *Profile        `json:"profile,omitempty"`
+	ProfileID  string          `json:"profile_id"`
+	Resource   Resource        `json:"resource"`
+	Scope      DomainScope     `json:"scope"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// ProgrammeAction is one piece of a programme's work.
+type ProgrammeAction struct {
+	AssigneeID  string     `json:"assignee_id,omitempty"`
+	Completed   bool       `json:"completed"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	// DependsOn lists the actions that must finish before this one starts.
+	// Stored in programme_action_dependencies.
+	DependsOn []string `json:"depends_on"`
+	// EffortDays is the estimate in person-days. Zero makes a milestone.
+	EffortDays  float64   `json:"effort_days"`
+	ID          string    `json:"id"`
+	Order       int64     `json:"order"`
+	ProgrammeID string    `json:"programme_id"`
+	TenantID    string    `json:"tenant_id"`
+	Title       string    `json:"title"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ProgrammeControlLink ties a programme to a compliance control it
+// implements or strengthens.
+type ProgrammeControlLink struct {
+	ControlID   string    `json:"control_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	ProgrammeID string    `json:"programme_id"`
+	TenantID    string    `json:"tenant_id"`
+}
+
+// ProgrammeInput is the body of POST /mitigation-programmes and PUT
+// /mitigation-programmes/:id. StartDate defaults to today.
+type ProgrammeInput struct {
+	Description string                 `json:"description"`
+	OwnerID     *string                `json:"owner_id,omitempty"`
+	StartDate   *time.Time             `json:"start_date,omitempty"`
+	Status      DomainMitigationStatus `json:"status"`
+	TargetDate  *time.Time             `json:"target_date,omitempty"`
+	Title       string                 `json:"title"`
+}
+
+// ProgrammeRiskLink ties a programme to a risk it treats.
+type ProgrammeRiskLink struct {
+	CreatedAt time.Time `json:"created_at"`
+	// ExpectedReduction is the share of the risk the programme removes once
+	// delivered, in [0, MaxProgrammeReduction].
+	ExpectedReduction float64 `json:"expected_reduction"`
+	ProgrammeID       string  `json:"programme_id"`
+	RiskID            string  `json:"risk_id"`
+	TenantID          string  `json:"tenant_id"`
+}
+
+// ProgrammeSchedule is the scheduler's answer for one programme.
+type ProgrammeSchedule struct {
+	Actions      []ScheduledAction `json:"actions"`
+	CriticalPath []string          `json:"critical_path"`
+	Progress     int64             `json:"progress"`
+	ProjectedEnd time.Time         `json:"projected_end"`
+	// SlipDays is the calendar days the projected end lands after the target
+	// (negative when ahead, zero without a target).
+	SlipDays   int64      `json:"slip_days"`
+	Start      time.Time  `json:"start"`
+	TargetDate *time.Time `json:"target_date,omitempty"`
+}
+
+// Propagation is the estate-wide view: every supporting asset, the
+// strictest objectives it inherits, and whether it meets them.
+type Propagation struct {
+	Gaps         int64                 `json:"gaps"`
+	Processes    int64                 `json:"processes"`
+	Requirements []RecoveryRequirement `json:"requirements"`
+	Undeclared   int64                 `json:"undeclared"`
+}
+
+// Provider identifies a payment gateway.
+type Provider string
+
+const (
+	ProviderCinetpay Provider = "cinetpay"
+	ProviderNotchpay Provider = "notchpay"
+	ProviderStripe   Provider = "stripe"
+)
+
+// ProviderInput is the body of PUT /ai/provider.
+type ProviderInput struct {
+	APIKey string `json:"api_key"`
+	// BaseURL is the openai_compatible API root. A private or loopback address
+	// is refused unless the operator sets AI_ALLOW_PRIVATE_NETWORKS.
+	BaseURL        string `json:"base_url"`
+	ClearAPIKey    bool   `json:"clear_api_key"`
+	MaxTokens      int64  `json:"max_tokens"`
+	Model          string `json:"model"`
+	OutputMode     string `json:"output_mode"`
+	Provider       string `json:"provider"`
+	TimeoutSeconds int64  `json:"timeout_seconds"`
+}
+
+// PruneResult reports what a sweep removed for one tenant.
+type PruneResult struct {
+	Pruned   int64           `json:"pruned"`
+	Seal     *AuditChainSeal `json:"seal,omitempty"`
+	TenantID string          `json:"tenant_id"`
+}
+
+// PublishResult reports what publication produced.
+type PublishResult struct {
+	// MitigationsCreated is how many corrective actions became tracked plans.
+	MitigationsCreated int64 `json:"mitigations_created"`
+	// NotConverted explains, per action, why it did not become a plan —
+	// rather than reporting a clean success over silently dropped work.
+	NotConverted []string        `json:"not_converted,omitempty"`
+	View         json.RawMessage `json:"view,omitempty"`
+}
+
+// RBACCatalogResponse is the self-describing permission matrix payload.
+type RBACCatalogResponse struct {
+	BusinessRoles []BusinessRole  `json:"business_roles"`
+	Permissions   []PermissionDef `json:"permissions"`
+}
+
+// Reason is one policy finding, with a stable code and both renderings.
+// Code is what tests and the SPA switch on; FR/EN are what a human reads.
+// They are phrased as instructions ("add another word"), never as verdicts
+// ("invalid"), because the point is to get the next attempt right.
+type Reason struct {
+	Code string `json:"code"`
+	En   string `json:"en"`
+	Fr   string `json:"fr"`
+}
+
+// RecordResult says what recording values did.
+type RecordResult struct {
+	Crossing *KRICrossing    `json:"crossing,omitempty"`
+	KRI      json.RawMessage `json:"kri,omitempty"`
+	// RaisedRisks are the linked risks whose probability was raised.
+	RaisedRisks []string `json:"raised_risks,omitempty"`
+	Recorded    int64    `json:"recorded"`
+}
+
+// RecoveryBasis says how much a declared capability can be trusted.
+type RecoveryBasis string
+
+const (
+	RecoveryBasisContractual RecoveryBasis = "contractual"
+	RecoveryBasisEstimated   RecoveryBasis = "estimated"
+	RecoveryBasisTested      RecoveryBasis = "tested"
+)
+
+// RecoveryCapability is what a supporting asset can actually deliver: how
+// long it takes to restore (RTC) and how much data a restore loses (RPC,
+// typically the backup or replication interval). Either may be nil when
+// not declared.
+type RecoveryCapability struct {
+	AssetID      string        `json:"asset_id"`
+	Basis        RecoveryBasis `json:"basis"`
+	CreatedAt    time.Time     `json:"created_at"`
+	ID           string        `json:"id"`
+	LastTestedAt *time.Time    `json:"last_tested_at,omitempty"`
+	Notes        string        `json:"notes,omitempty"`
+	RpcHours     *float64      `json:"rpc_hours,omitempty"`
+	RtcHours     *float64      `json:"rtc_hours,omitempty"`
+	TenantID     string        `json:"tenant_id"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+	UpdatedBy    string        `json:"updated_by,omitempty"`
+}
+
+// RecoveryRequirement is what one supporting asset must deliver, where the
+// requirement comes from, and whether its declared capability meets it.
+type RecoveryRequirement struct {
+	AssetID          string        `json:"asset_id"`
+	Basis            RecoveryBasis `json:"basis,omitempty"`
+	DeclaredRpcHours float64       `json:"declared_rpc_hours,omitempty"`
+	DeclaredRtcHours float64       `json:"declared_rtc_hours,omitempty"`
+	Depth            int64         `json:"depth"`
+	Gaps             []string      `json:"gaps"`
+	Name             string        `json:"name"`
+	// Path runs from the driving process to this asset, both included, so the
+	// report can say "payroll → hr-app → db-01".
+	Path             []string       `json:"path"`
+	RequiredRpoHours float64        `json:"required_rpo_hours,omitempty"`
+	RequiredRtoHours float64        `json:"required_rto_hours"`
+	RpoDrivenBy      string         `json:"rpo_driven_by,omitempty"`
+	RtoDrivenBy      string         `json:"rto_driven_by"`
+	Status           RecoveryStatus `json:"status"`
+}
+
+// RecoveryStatus is the verdict on one supporting asset.
+type RecoveryStatus string
+
+const (
+	RecoveryStatusGap        RecoveryStatus = "gap"
+	RecoveryStatusOk         RecoveryStatus = "ok"
+	RecoveryStatusUndeclared RecoveryStatus = "undeclared"
+)
+
+// RefreshTokenRequest represents the refresh token request body
+type RefreshTokenRequest struct {
+	DeviceFingerprint string `json:"device_fingerprint,omitempty"`
+	RefreshToken      string `json:"refresh_token"`
+}
+
+// RefreshTokenResponse represents the refresh token response
+type RefreshTokenResponse struct {
+	// CSRFToken mirrors the refreshed or_csrf cookie (see LoginResponse).
+	CsrfToken string          `json:"csrf_token,omitempty"`
+	TokenPair json.RawMessage `json:"token_pair,omitempty"`
+}
+
+type RegisterAgentInput struct {
+	Hostname string `json:"hostname"`
+	Name     string `json:"name"`
+	Os       string `json:"os"`
+	Version  string `json:"version"`
+}
+
+// RegisterAgentResult is returned ONCE to the Agent. Token and PushSecret
+// are shown only here; the SaaS stores only their hash / ciphertext.
+type RegisterAgentResult struct {
+	Agent json.RawMessage `json:"agent,omitempty"`
+	// HMAC key for signing pushes
+	PushSecret string `json:"push_secret"`
+	// when to re-register for a fresh token
+	RotateAfter time.Time `json:"rotate_after"`
+	// scoped scanner JWT (7d)
+	Token string `json:"token"`
+}
+
+// RegisterDiffEntry is one risk that changed between two registers. Scores
+// and statuses are empty on the side where the risk did not exist.
+type RegisterDiffEntry struct {
+	Criticality string     `json:"criticality"`
+	Delta       float64    `json:"delta"`
+	FromAleXaf  float64    `json:"from_ale_xaf"`
+	FromScore   float64    `json:"from_score,omitempty"`
+	FromStatus  RiskStatus `json:"from_status,omitempty"`
+	RiskID      string     `json:"risk_id"`
+	Title       string     `json:"title"`
+	ToAleXaf    float64    `json:"to_ale_xaf"`
+	ToScore     float64    `json:"to_score,omitempty"`
+	ToStatus    RiskStatus `json:"to_status,omitempty"`
+}
+
+// RegisterDiffSummary counts each bucket of a diff.
+type RegisterDiffSummary struct {
+	Closed     int64 `json:"closed"`
+	DownScored int64 `json:"down_scored"`
+	New        int64 `json:"new"`
+	Unchanged  int64 `json:"unchanged"`
+	UpScored   int64 `json:"up_scored"`
+}
+
+// RegisterRequest represents the registration request body
+type RegisterRequest struct {
+	CompanyName string `json:"company_name"`
+	Email       string `json:"email"`
+	FullName    string `json:"full_name"`
+	Password    string `json:"password"`
+	Username    string `json:"username"`
+}
+
+// RegisterResponse represents the registration response
+type RegisterResponse struct {
+	Message      string          `json:"message"`
+	Organization json.RawMessage `json:"organization,omitempty"`
+	User         json.RawMessage `json:"user,omitempty"`
+}
+
+// RegisterSnapshot is the header of a frozen register: when it stands for
+// and the portfolio totals at that moment.
+type RegisterSnapshot struct {
+	// AsOf is the moment the snapshot stands for. A manual snapshot stands for
+	// the moment it was taken; a scheduled one for the midnight (UTC) that
+	// closed the day, and is taken by the first sweep after it.
+	AsOf         time.Time            `json:"as_of"`
+	AverageScore float64              `json:"average_score"`
+	CreatedAt    time.Time            `json:"created_at"`
+	Critical     int64                `json:"critical"`
+	High         int64                `json:"high"`
+	ID           string               `json:"id"`
+	Kind         RegisterSnapshotKind `json:"kind"`
+	Label        string               `json:"label"`
+	Low          int64                `json:"low"`
+	Medium       int64                `json:"medium"`
+	OpenCount    int64                `json:"open_count"`
+	RiskCount    int64                `json:"risk_count"`
+	TakenAt      time.Time            `json:"taken_at"`
+	TakenBy      string               `json:"taken_by,omitempty"`
+	TenantID     string               `json:"tenant_id"`
+	TotalAleXaf  float64              `json:"total_ale_xaf"`
+}
+
+// RegisterSnapshotKind says why a snapshot exists.
+type RegisterSnapshotKind string
+
+const (
+	RegisterSnapshotKindManual    RegisterSnapshotKind = "manual"
+	RegisterSnapshotKindScheduled RegisterSnapshotKind = "scheduled"
+)
+
+// RegisterSnapshotRisk is one risk as it stood in a snapshot. It copies
+// what a year-end reader asks about rather than referencing the live row,
+// which may since have changed or been deleted.
+type RegisterSnapshotRisk struct {
+	AleXaf       float64  `json:"ale_xaf"`
+	Assets       []string `json:"assets"`
+	BusinessUnit string   `json:"business_unit,omitempty"`
+	CategoryID   string   `json:"category_id,omitempty"`
+	// Controls are the risk's control mappings as the register badge shows
+	// them ("ISO 27001 · A.8.13"): labels rather than ids, because a mapping
+	// or control deleted later must still read correctly here.
+	Controls       []string   `json:"controls"`
+	Criticality    string     `json:"criticality"`
+	Impact         float64    `json:"impact"`
+	LifecycleState RiskState  `json:"lifecycle_state"`
+	OwnerID        string     `json:"owner_id,omitempty"`
+	Probability    float64    `json:"probability"`
+	ResidualRisk   float64    `json:"residual_risk,omitempty"`
+	RiskCreatedAt  time.Time  `json:"risk_created_at"`
+	RiskID         string     `json:"risk_id"`
+	RiskUpdatedAt  time.Time  `json:"risk_updated_at"`
+	Score          float64    `json:"score"`
+	SnapshotID     string     `json:"snapshot_id"`
+	Status         RiskStatus `json:"status"`
+	Tags           []string   `json:"tags"`
+	TenantID       string     `json:"tenant_id"`
+	Title          string     `json:"title"`
+}
+
+// RegulatoryClockStatus is where one deadline stands.
+type RegulatoryClockStatus string
+
+const (
+	RegulatoryClockStatusPending   RegulatoryClockStatus = "pending"
+	RegulatoryClockStatusSubmitted RegulatoryClockStatus = "submitted"
+	RegulatoryClockStatusWithdrawn RegulatoryClockStatus = "withdrawn"
+)
+
+// RegulatoryCriteria decide whether a regime applies to a classified
+// incident in one jurisdiction. Every set criterion must hold; an empty
+// criteria set matches nothing (a profile that fires on every incident is
+// a misconfiguration that buries the real deadlines).
+type RegulatoryCriteria struct {
+	// IncidentTypes, when set, restrict the regime to these incident types.
+	IncidentTypes []string `json:"incident_types,omitempty"`
+	// MinAffectedSubjects, when positive, is the smallest qualifying
+	// headcount.
+	MinAffectedSubjects int64 `json:"min_affected_subjects,omitempty"`
+	// MinSeverity, when set, is the lowest incident severity that qualifies.
+	MinSeverity         string `json:"min_severity,omitempty"`
+	RequireMajor        bool   `json:"require_major,omitempty"`
+	RequirePersonalData bool   `json:"require_personal_data,omitempty"`
+	RequireSignificant  bool   `json:"require_significant,omitempty"`
+}
+
+// RegulatoryProfile is a tenant's configuration of one regime in one
+// jurisdiction: the authority to notify, what qualifies, and who is
+// chased.
+type RegulatoryProfile struct {
+	Authority string `json:"authority"`
+	// AuthorityContact is where the notification goes: a portal URL or an
+	// address. Shown on the draft so nobody hunts for it at hour 71.
+	AuthorityContact string `json:"authority_contact"`
+	BuiltIn          bool   `json:"built_in"`
+	// ContactPoint pre-fills the "who can the authority call" field.
+	ContactPoint string             `json:"contact_point"`
+	CreatedAt    time.Time          `json:"created_at"`
+	Criteria     RegulatoryCriteria `json:"criteria"`
+	Enabled      bool               `json:"enabled"`
+	// EscalateChannels / EscalateToRole route the reminders through the
+	// automation dispatcher. Empty channels means in-app and email.
+	EscalateChannels StringList `json:"escalate_channels"`
+	EscalateToRole   string     `json:"escalate_to_role"`
+	ID               string     `json:"id"`
+	// Jurisdiction is an ISO 3166-1 alpha-2 code, or "EU" for the catch-all.
+	Jurisdiction string           `json:"jurisdiction"`
+	Regime       RegulatoryRegime `json:"regime"`
+	TenantID     string           `json:"tenant_id"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+}
+
+type RegulatoryProfileBody struct {
+	Authority        string             `json:"authority"`
+	AuthorityContact string             `json:"authority_contact"`
+	ContactPoint     string             `json:"contact_point"`
+	Criteria         RegulatoryCriteria `json:"criteria"`
+	Enabled          *bool              `json:"enabled,omitempty"`
+	EscalateChannels []string           `json:"escalate_channels"`
+	EscalateToRole   string             `json:"escalate_to_role"`
+	Jurisdiction     string             `json:"jurisdiction"`
+	Regime           string             `json:"regime"`
+}
+
+// RegulatoryRegime identifies a notification regime.
+type RegulatoryRegime string
+
+const (
+	RegulatoryRegimeDora RegulatoryRegime = "dora"
+	RegulatoryRegimeGdpr RegulatoryRegime = "gdpr"
+	RegulatoryRegimeNis2 RegulatoryRegime = "nis2"
+)
+
+// RegulatoryRegimeSpec describes a regime and ties it to its compliance
+// catalog, so a clock can point at the control it discharges (GDPR Art.33,
+// NIS2 Art.23, DORA Art.19) in the tenant's imported framework.
+type RegulatoryRegimeSpec struct {
+	CatalogKey string `json:"catalog_key"`
+	ControlRef string `json:"control_ref"`
+	// DefaultCriteria are used by the built-in EU-wide profile.
+	DefaultCriteria RegulatoryCriteria    `json:"default_criteria"`
+	Description     string                `json:"description"`
+	Name            string                `json:"name"`
+	Regime          RegulatoryRegime      `json:"regime"`
+	Stages          []RegulatoryStageSpec `json:"stages"`
+}
+
+// RegulatoryStageSpec is one deadline within a regime.
+type RegulatoryStageSpec struct {
+	Anchor ClockAnchor `json:"anchor"`
+	// CapFromAwareness bounds the deadline from awareness regardless of
+	// anchor: DORA's initial notification is 4 h after classification but
+	// never later than 24 h after awareness.
+	CapFromAwarenessNs int64 `json:"cap_from_awareness_ns,omitempty"`
+	// Fields are the notification's contents, in the order the authority's
+	// form asks for them. Pre-filled from the incident where the incident
+	// knows.
+	Fields       []string `json:"fields"`
+	Key          string   `json:"key"`
+	Label        string   `json:"label"`
+	WithinMonths int64    `json:"within_months,omitempty"`
+	// Within is the allowance from the anchor; WithinMonths is used instead
+	// for "one month" stages, which are calendar months, not 30 days.
+	WithinNs int64 `json:"within_ns,omitempty"`
+}
+
+// RegulatoryStatus is an incident's regulatory picture.
+type RegulatoryStatus struct {
+	Assessment  json.RawMessage     `json:"assessment,omitempty"`
+	Clocks      []ClockView         `json:"clocks"`
+	Evaluations []ProfileEvaluation `json:"evaluations,omitempty"`
+}
+
+// RemediationPlan is a tenant-scoped action to close a compliance gap. It
+// is linked to the control it remediates (ControlID) and, optionally, to
+// the audit that surfaced the gap (AuditID). This is compliance-specific
+// remediation, distinct from risk Mitigations (which hang off a Risk).
+type RemediationPlan struct {
+	// Deprecated: superseded by Ownership.AssigneeID (backfilled by migration
+	// 0044). Kept so existing remediation filters keep answering.
+	AssignedTo    *string `json:"assigned_to,omitempty"`
+	AssigneeEmail string  `json:"assignee_email,omitempty"`
+	AssigneeID    *string `json:"assignee_id,omitempty"`
+	// origin audit, if any
+	AuditID     *string    `json:"audit_id,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// Computed, NOT persisted — the linked control's reference code/name,
+	// filled by the list use case for a readable UI without an extra
+	// round-trip.
+	ControlCode string `json:"control_code,omitempty"`
+	// the gap being remediated
+	ControlID   *string    `json:"control_id,omitempty"`
+	ControlName string     `json:"control_name,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CreatedBy   *string    `json:"created_by,omitempty"`
+	Description string     `json:"description"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	// denormalised for filtering
+	FrameworkID *string `json:"framework_id,omitempty"`
+	ID          string  `json:"id"`
+	// Computed, NOT persisted — resolved by the list/get use cases through a
+	// UserLookup port so the UI can render an avatar without an extra
+	// round-trip per row. Degrades to empty when the lookup is unavailable.
+	OwnerEmail    string              `json:"owner_email,omitempty"`
+	OwnerID       *string             `json:"owner_id,omitempty"`
+	Priority      RemediationPriority `json:"priority"`
+	ReviewerEmail string              `json:"reviewer_email,omitempty"`
+	ReviewerID    *string             `json:"reviewer_id,omitempty"`
+	Status        RemediationStatus   `json:"status"`
+	TenantID      string              `json:"tenant_id"`
+	Title         string              `json:"title"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+// RemediationPriority ranks the urgency of a remediation action.
+type RemediationPriority string
+
+const (
+	RemediationPriorityCritical RemediationPriority = "critical"
+	RemediationPriorityHigh     RemediationPriority = "high"
+	RemediationPriorityLow      RemediationPriority = "low"
+	RemediationPriorityMedium   RemediationPriority = "medium"
+)
+
+// RemediationStatus is the lifecycle state of a remediation plan.
+type RemediationStatus string
+
+const (
+	RemediationStatusCancelled  RemediationStatus = "cancelled"
+	RemediationStatusCompleted  RemediationStatus = "completed"
+	RemediationStatusInProgress RemediationStatus = "in_progress"
+	RemediationStatusOpen       RemediationStatus = "open"
+)
+
+// RemovePermissionRequest defines request body for removing permission
+type RemovePermissionRequest struct {
+	PermissionID string `json:"permission_id"`
+}
+
+// ReportComment is one remark in the review trail. Comments live on the
+// report rather than in a generic discussion table because they are part
+// of its record: "approved subject to the Q3 figures being restated" has
+// to travel with the document it qualifies.
+type ReportComment struct {
+	AuthorEmail string    `json:"author_email,omitempty"`
+	AuthorID    string    `json:"author_id"`
+	Body        string    `json:"body"`
+	CreatedAt   time.Time `json:"created_at"`
+	ID          string    `json:"id"`
+	ReportID    string    `json:"report_id"`
+	TenantID    string    `json:"tenant_id"`
+	// Transition records the lifecycle move this comment accompanied, empty
+	// for a plain remark. It is what turns a comment list into an audit trail:
+	// "who approved this, and what did they say when they did".
+	Transition ReportLifecycle `json:"transition,omitempty"`
+}
+
+// ReportFormat is the container the document is delivered in. The choice
+// is not cosmetic: a PDF is what gets signed and filed, a DOCX is what a
+// compliance officer edits before sending it on, and an XLSX is what an
+// auditor filters and pivots. Offering only PDF forces people to retype.
+type ReportFormat string
+
+const (
+	ReportFormatDocx ReportFormat = "docx"
+	ReportFormatPDF  ReportFormat = "pdf"
+	ReportFormatXlsx ReportFormat = "xlsx"
+)
+
+// ReportJob is one requested report and the artifact it produced. Reports
+// are modelled as jobs rather than as a synchronous download because a
+// report is a point-in-time snapshot with an address of its own. That
+// address (/reports/jobs/:id) is what breaks the Compliance <-> Reports
+// navigation loop: asking for a compliance report used to bounce the user
+// to the Reports screen, whose Compliance tile bounced them back, with no
+// artifact produced anywhere in between. Now the request creates a job and
+// the user lands on that job. The rendered bytes are stored on the row so
+// a re-download returns the document that was actually generated, not a
+// fresh render of a register that has since moved on. Reports here are a
+// few hundred KB; if a kind ever outgrows that, Artifact should become an
+// object-store key rather than a blob.
+type ReportJob struct {
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ContentType string     `json:"content_type,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	// Error carries a user-safe failure reason (never a raw internal error).
+	Error    string     `json:"error,omitempty"`
+	Filename string     `json:"filename,omitempty"`
+	ID       string     `json:"id"`
+	Kind     ReportKind `json:"kind"`
+	// Params carries the kind's inputs (framework_id, locale, ...). Kept
+	// opaque so a new report kind needs no migration.
+	Params      json.RawMessage `json:"params,omitempty"`
+	RequestedBy string          `json:"requested_by"`
+	SizeBytes   int64           `json:"size_bytes,omitempty"`
+	Status      ReportJobStatus `json:"status"`
+	TenantID    string          `json:"tenant_id"`
+	// Title is a human label resolved at generation time ("ISO/IEC
+	// 27001:2022"), so the job list reads meaningfully without re-resolving
+	// every framework.
+	Title     string    `json:"title"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReportJobStatus is the job's lifecycle.
+type ReportJobStatus string
+
+const (
+	ReportJobStatusFailed    ReportJobStatus = "failed"
+	ReportJobStatusQueued    ReportJobStatus = "queued"
+	ReportJobStatusRunning   ReportJobStatus = "running"
+	ReportJobStatusSucceeded ReportJobStatus = "succeeded"
+)
+
+// ReportKind is what a job renders. Each kind maps to one generator.
+type ReportKind string
+
+const (
+	ReportKindComplianceFramework ReportKind = "compliance_framework"
+)
+
+// ReportLifecycle is the editorial state: has a human accepted this
+// document?
+type ReportLifecycle string
+
+const (
+	ReportLifecycleApproved  ReportLifecycle = "approved"
+	ReportLifecycleDraft     ReportLifecycle = "draft"
+	ReportLifecycleInReview  ReportLifecycle = "in_review"
+	ReportLifecyclePublished ReportLifecycle = "published"
+)
+
+// ReportLocale is the language the DOCUMENT is written in. Deliberately
+// separate from the interface language. A French-speaking compliance
+// officer routinely produces an English report for a foreign regulator or
+// a group parent; tying the document's language to the one they happen to
+// read the app in forces them to switch the whole product to a language
+// they do not want, generate, and switch back.
+type ReportLocale string
+
+const (
+	ReportLocaleEn ReportLocale = "en"
+	ReportLocaleFr ReportLocale = "fr"
+)
+
+// ReportRunState is the generation lifecycle — whether the bytes exist
+// yet. Distinct from ReportLifecycle below, which is about whether the
+// document is APPROVED. A report can be generated and still be a draft
+// nobody has signed off; conflating the two would make "published" mean
+// "finished rendering".
+type ReportRunState string
+
+const (
+	ReportRunStateFailed    ReportRunState = "failed"
+	ReportRunStateQueued    ReportRunState = "queued"
+	ReportRunStateRunning   ReportRunState = "running"
+	ReportRunStateSucceeded ReportRunState = "succeeded"
+)
+
+// ReportSummary is the comparable face of a report.
+type ReportSummary struct {
+	ContentHash string          `json:"content_hash"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Format      ReportFormat    `json:"format"`
+	ID          string          `json:"id"`
+	Lifecycle   ReportLifecycle `json:"lifecycle"`
+	Locale      ReportLocale    `json:"locale"`
+	SizeBytes   int64           `json:"size_bytes"`
+	Template    string          `json:"template"`
+	Version     int64           `json:"version"`
+}
+
+// ReportType is what a report is about. Six, matching the audiences that
+// ask for them; each maps to one generator per format.
+type ReportType string
+
+const (
+	ReportTypeAudit               ReportType = "audit"
+	ReportTypeBoard               ReportType = "board"
+	ReportTypeComplianceFramework ReportType = "compliance_framework"
+	ReportTypeExecutiveSummary    ReportType = "executive_summary"
+	ReportTypeIncident            ReportType = "incident"
+	ReportTypeRiskRegister        ReportType = "risk_register"
+)
+
+type ResetPasswordRequest struct {
+	Locale      string `json:"locale,omitempty"`
+	NewPassword string `json:"new_password"`
+	Token       string `json:"token"`
+}
+
+// ResidualChange is what a test did to one linked risk.
+type ResidualChange struct {
+	After      float64  `json:"after"`
+	Before     *float64 `json:"before,omitempty"`
+	Mitigation float64  `json:"mitigation"`
+	RiskID     string   `json:"risk_id"`
+	Title      string   `json:"title"`
+}
+
+// ResidualDerivation shows how a risk's residual follows from its
+// controls.
+type ResidualDerivation struct {
+	Controls []ControlContribution `json:"controls"`
+	// Current is the residual on the risk now, and Source where it came from;
+	// they differ from the derivation until it is applied.
+	Current  *float64 `json:"current,omitempty"`
+	Inherent float64  `json:"inherent"`
+	// Mitigation is the share of the risk the controls remove, capped at
+	// domain.MaxControlCredit.
+	Mitigation float64 `json:"mitigation"`
+	Residual   float64 `json:"residual"`
+	RiskID     string  `json:"risk_id"`
+	Source     string  `json:"source"`
+}
+
+type ResolveAssetBody struct {
+	// AssetID may be null to explicitly detach the finding ("this belongs to
+	// nothing we own") — also a decision worth recording.
+	AssetID *string `json:"asset_id,omitempty"`
+}
+
+// Resource represents a resource type in the permission system
+type Resource string
+
+const (
+	ResourceAssets       Resource = "assets"
+	ResourceAuditLogs    Resource = "audit_logs"
+	ResourceConnectors   Resource = "connectors"
+	ResourceGroups       Resource = "groups"
+	ResourceIntegrations Resource = "integrations"
+	ResourceMembers      Resource = "members"
+	ResourceMitigations  Resource = "mitigations"
+	ResourceProfiles     Resource = "profiles"
+	ResourceReports      Resource = "reports"
+	ResourceRisks        Resource = "risks"
+	ResourceSettings     Resource = "settings"
+	ResourceUsers        Resource = "users"
+)
+
+// Response is the endpoint payload.
+type Response struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+}
+
+type RetentionBody struct {
+	RetentionDays int64 `json:"retention_days"`
+}
+
+// Risk represents a business risk with full lifecycle management Follows
+// Clean Architecture: pure domain entity, ZERO external dependencies
+type Risk struct {
+	// "explicit" | "reference"
+	AleBasis string `json:"ale_basis"`
+	// annual loss expectancy (USD)
+	AleUsd float64 `json:"ale_usd"`
+	// Computed, NOT persisted — filled by the handler via pkg/crq before
+	// responding.
+	AleXaf float64 `json:"ale_xaf"`
+	// annualized rate of occurrence (events/year)
+	Aro *float64 `json:"aro,omitempty"`
+	// Asset Association
+	AssetID *string `json:"asset_id,omitempty"`
+	Assets  []Asset `json:"assets,omitempty"`
+	// Deprecated: superseded by Ownership.AssigneeID. Kept (and backfilled
+	// FROM, migration 0044) so pre-existing filters and the
+	// RiskQuery.AssignedTo facet keep answering while callers migrate.
+	AssignedTo    *string `json:"assigned_to,omitempty"`
+	AssigneeEmail string  `json:"assignee_email,omitempty"`
+	AssigneeID    *string `json:"assignee_id,omitempty"`
+	// BusinessUnit is the part of the organisation that owns the exposure →
+	// column "Entité". Free text (the tenant has no org chart yet); appetite
+	// statements scoped to a business unit match it case-insensitively.
+	BusinessUnit string        `json:"business_unit,omitempty"`
+	Category     *RiskCategory `json:"category,omitempty"`
+	// CategoryID points at the tenant's CONTROLLED vocabulary → column
+	// "Catégorie". Nullable: a risk may be unclassified, and forcing a
+	// category at creation would just push people to pick the first entry.
+	CategoryID *string `json:"category_id,omitempty"`
+	// Classification is the risk's confidentiality level (public, internal,
+	// confidential…), free text like BusinessUnit. Access policies test it
+	// to keep sensitive risks to the people entitled to them.
+	Classification string `json:"classification,omitempty"`
+	// Deprecated: never written by anything but duplicate_risk, never read.
+	// Migrated into risk_control_mappings by 0046 where resolvable.
+	ControlIDs []string `json:"control_ids"`
+	// ControlMappings are references to REAL compliance controls → column
+	// "Référentiel". Loaded by the list/get use cases, never stored inline.
+	ControlMappings []RiskControlMapping `json:"control_mappings,omitempty"`
+	// Audit Trail
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+	// low|medium|high|critical
+	Criticality DomainCriticalityLevel `json:"criticality"`
+	// Custom Fields (JSONB for flexibility)
+	CustomFields json.RawMessage `json:"custom_fields,omitempty"`
+	// data recovery / breach cost
+	DataLossCostXaf *float64 `json:"data_loss_cost_xaf,omitempty"`
+	Description     string   `json:"description"`
+	// Full financial-quantification drivers (spec §9). When SLE is not
+	// supplied explicitly it is composed from these: downtime cost + fines +
+	// data loss + other. RemediationCost + MitigationEffectiveness drive ROSI.
+	// All XAF, all optional; the engine (pkg/crq) degrades gracefully to the
+	// reference model.
+	DowntimeHours *float64 `json:"downtime_hours,omitempty"`
+	// DowntimeHoursSource says where DowntimeHours came from: "" when typed
+	// in, "bia" when derived from the business impact analysis of the risk's
+	// asset (see bia.BIAService.Sync). Only a "bia" value is ever refreshed by
+	// the BIA; a figure someone entered by hand is theirs.
+	DowntimeHoursSource string `json:"downtime_hours_source,omitempty"`
+	// ID in external system
+	ExternalID string `json:"external_id"`
+	// regulatory fines
+	FinesXaf *float64 `json:"fines_xaf,omitempty"`
+	// Deprecated (migration 0046): free-text framework names, populated by a
+	// hard-coded dropdown and never checked against the tenant's imported
+	// frameworks. Frozen — no longer read or written. Superseded by
+	// ControlMappings; the column is kept for one release so a rollback is
+	// possible, and dropped in a later migration.
+	Frameworks []string `json:"frameworks"`
+	// cost per hour of downtime
+	HourlyDowntimeCostXaf *float64 `json:"hourly_downtime_cost_xaf,omitempty"`
+	// Primary Key
+	ID     string  `json:"id"`
+	Impact float64 `json:"impact"`
+	// Legacy scoring system (1-5 scale, for backwards compatibility) Will be
+	// deprecated as system migrates to new scale
+	ImpactLegacy    int64      `json:"impact_legacy"`
+	LastMitigatedAt *time.Time `json:"last_mitigated_at,omitempty"`
+	LastReviewedAt  *time.Time `json:"last_reviewed_at,omitempty"`
+	// Legacy: CRITICAL|HIGH|MEDIUM|LOW
+	Level string `json:"level"`
+	// Deprecated as a WRITABLE field: derived from LifecycleState. The column
+	// stays (and stays correct) so the phase facet and any legacy reader keep
+	// working — but nothing sets it independently any more.
+	LifecyclePhase RiskPhase `json:"lifecycle_phase"`
+	// LifecycleState is the SINGLE source of truth for where a risk stands:
+	// DRAFT → IDENTIFIED → ASSESSED → TREATMENT_PLANNED → IN_TREATMENT
+	// → (RESIDUAL_ACCEPTED | MITIGATED) → CLOSED ↘ REOPENED ↗. Status
+	// and LifecyclePhase above are derived from it on every write (SetState),
+	// which is what stops the three from drifting apart.
+	LifecycleState RiskState `json:"lifecycle_state"`
+	// [0,1] share of ALE removed
+	MitigationEffectiveness *float64 `json:"mitigation_effectiveness,omitempty"`
+	// Relations (loaded via Preload)
+	Mitigations []Mitigation `json:"mitigations,omitempty"`
+	// MitigationsCount is computed, NOT persisted: the count of the plans
+	// above. The UI derives its "Créer une mitigation" vs "Voir les
+	// mitigations (n)" button from it, so that button reflects what EXISTS
+	// rather than what the user did in this session — a state the previous
+	// version got wrong the moment you reloaded the page.
+	MitigationsCount int64 `json:"mitigations_count"`
+	// Core Risk Definition
+	Name         string     `json:"name"`
+	NextReviewAt *time.Time `json:"next_review_at,omitempty"`
+	// Legacy alias for TenantID
+	OrganizationID string `json:"organization_id"`
+	// any other direct per-incident cost
+	OtherDirectCostXaf *float64 `json:"other_direct_cost_xaf,omitempty"`
+	// Deprecated: free-text owner (email or user id). Superseded by
+	// Ownership.OwnerID; kept for legacy readers.
+	Owner string `json:"owner"`
+	// Computed, NOT persisted — resolved by the list/get use cases through a
+	// UserLookup port so the UI can render an avatar without an extra
+	// round-trip per row. Degrades to empty when the lookup is unavailable.
+	OwnerEmail string  `json:"owner_email,omitempty"`
+	OwnerID    *string `json:"owner_id,omitempty"`
+	// Risk Scoring (via Score Engine: P × I × AssetCriticality, 3 decimal
+	// places) New system (0.0-1.0, 0.0-10.0, 0.1-3.0)
+	Probability       float64 `json:"probability"`
+	ProbabilityLegacy int64   `json:"probability_legacy"`
+	// budget to deploy the control
+	RemediationCostXaf *float64 `json:"remediation_cost_xaf,omitempty"`
+	// Score after treatments
+	ResidualRisk *float64 `json:"residual_risk,omitempty"`
+	// ResidualSource says where ResidualRisk and MitigationEffectiveness came
+	// from: "" when typed in, "controls" when derived from the tested
+	// effectiveness of the mapped controls (see DeriveResidual). Once derived,
+	// the next control test rewrites them.
+	ResidualSource string `json:"residual_source,omitempty"`
+	// Review cadence — automated risk-review reminders. ReviewIntervalDays =
+	// 0 disables it; NextReviewAt is when the owner is next nudged;
+	// LastReviewedAt is the last time the risk was marked reviewed.
+	ReviewIntervalDays int64   `json:"review_interval_days"`
+	ReviewerEmail      string  `json:"reviewer_email,omitempty"`
+	ReviewerID         *string `json:"reviewer_id,omitempty"`
+	// Calculated ONLY via Score Engine (Redis event)
+	Score float64 `json:"score"`
+	// Cyber Risk Quantification (CRQ) — monetary loss inputs (pkg/crq).
+	// Optional: when both are set, ALE = SLE × ARO; otherwise a reference
+	// value per criticality is used. Amounts are XAF (FCFA); USD is derived on
+	// read.
+	SleXaf          *float64        `json:"sle_xaf,omitempty"`
+	SmartComputedAt *time.Time      `json:"smart_computed_at,omitempty"`
+	SmartFactors    json.RawMessage `json:"smart_factors,omitempty"`
+	// low|medium|high|critical
+	SmartLevel DomainCriticalityLevel `json:"smart_level"`
+	// Smart Risk Calculation (spec §8) — the multifactor score computed by
+	// pkg/scoring.ComputeSmart from eight factors (business criticality,
+	// internet exposure, vulnerabilities, control maturity, incident history,
+	// exploitability, financial value, active threats). Persisted so the
+	// register can sort/badge on it; refreshed on demand via GET
+	// /risks/:id/smart-score. SmartFactors is the frozen per-factor breakdown
+	// (radar-ready []scoring.FactorScore snapshot).
+	SmartScore float64 `json:"smart_score"`
+	// Source Tracking
+	Source RiskSource `json:"source"`
+	// CVE identifier if from CTI
+	SourceCVEID *string `json:"source_cve_id,omitempty"`
+	// SourceRuleReason is the rule's own explanation, captured at creation
+	// time ("CVSS 9.8 ≥ 7.0, asset criticality CRITICAL ≥ HIGH"). Frozen:
+	// re-deriving it later would describe the rule as it is NOW, not the rule
+	// that fired.
+	SourceRuleReason string `json:"source_rule_reason,omitempty"`
+	// SourceScenarioID and SourceScenarioVersion name the scenario template
+	// (see scenario.go) the risk was drafted from, and the version it had
+	// then.
+	SourceScenarioID      string `json:"source_scenario_id,omitempty"`
+	SourceScenarioVersion int64  `json:"source_scenario_version,omitempty"`
+	// SourceVendorID is the vendor whose failed assessment proposed the risk.
+	SourceVendorID string `json:"source_vendor_id,omitempty"`
+	// Origin of an automatically proposed risk (Attack Surface §4). A machine
+	// may propose a risk, but the person reviewing it must be able to see
+	// exactly what proposed it and why — otherwise a draft register fills
+	// with items nobody can evaluate, and the honest response to that is to
+	// ignore all of them.
+	SourceVulnerabilityID string `json:"source_vulnerability_id,omitempty"`
+	// Lifecycle Management
+	Status RiskStatus `json:"status"`
+	// Tags are free text, authored by the user, unbounded → column
+	// "Étiquettes".
+	Tags []string `json:"tags"`
+	// Multi-tenancy (ABSOLUTE: filter by tenant_id in repository, never in
+	// handler)
+	TenantID string `json:"tenant_id"`
+	// Alias for Name, for compatibility
+	Title string `json:"title"`
+	// Treatment & Mitigation
+	TreatmentPlan RiskTreatment `json:"treatment_plan"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+// RiskAppetiteStatement is one board-approved appetite statement.
+type RiskAppetiteStatement struct {
+	ApprovalReference string `json:"approval_reference,omitempty"`
+	// ApprovedOn is the date the board approved the statement and
+	// ApprovalReference the minute or resolution that records it. A statement
+	// without ApprovedOn is a draft: shown, evaluated for information, never
+	// enforced. Appetite nobody approved is an opinion.
+	ApprovedOn   *time.Time `json:"approved_on,omitempty"`
+	BusinessUnit string     `json:"business_unit,omitempty"`
+	// Exactly one of CategoryID / BusinessUnit is set, matching Scope.
+	CategoryID       string    `json:"category_id,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	ID               string    `json:"id"`
+	MaxAleP90Xaf     *float64  `json:"max_ale_p90_xaf,omitempty"`
+	MaxCriticalCount *int64    `json:"max_critical_count,omitempty"`
+	// Tolerances. nil = not bounded on that metric.
+	MaxScore      *float64      `json:"max_score,omitempty"`
+	MaxSmartScore *float64      `json:"max_smart_score,omitempty"`
+	Scope         AppetiteScope `json:"scope"`
+	// Statement is the board's own wording, kept verbatim: the tolerances are
+	// how the tool checks it, not a replacement for it.
+	Statement string    `json:"statement"`
+	TenantID  string    `json:"tenant_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+}
+
+// RiskCategory is one entry of a tenant's controlled classification
+// vocabulary ("Fraude", "Cyber", "Conformité", …). Controlled means: an
+// admin curates the list, and a risk may only point at an entry that
+// exists. That is the whole difference with a tag — you cannot invent a
+// category by typing it, which is what makes it aggregatable in a
+// dashboard.
+type RiskCategory struct {
+	// Active false keeps history readable (risks already classified keep their
+	// category) while removing the entry from the picker. Categories are never
+	// hard-deleted out from under existing risks.
+	Active bool `json:"active"`
+	// Color is a UI token name (not a hex value) so categories stay
+	// theme-aware in both light and dark.
+	Color       string    `json:"color"`
+	CreatedAt   time.Time `json:"created_at"`
+	Description string    `json:"description"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	// Computed, NOT persisted — how many risks currently carry this
+	// category.
+	RiskCount int64 `json:"risk_count"`
+	// Slug is the stable machine key, unique per tenant. Renaming a category
+	// changes Name, never Slug, so links and saved filters survive.
+	Slug      string    `json:"slug"`
+	SortOrder int64     `json:"sort_order"`
+	TenantID  string    `json:"tenant_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RiskControlMapping links a risk to the compliance control (or, at a
+// coarser grain, the framework) it answers to. This is what the
+// "Référentiel" column renders — and the ONLY thing it may render.
+// Previously that column read from a free-text `frameworks` array
+// populated by a hard-coded dropdown, and fell back to the risk's first
+// TAG when the array was empty. Both halves of that were wrong. ControlID
+// is nullable on purpose: the migration can honestly say "this risk
+// relates to ISO 27001" without inventing a control it never named, and
+// the UI links to the framework's control list in that case instead of a
+// single control.
+type RiskControlMapping struct {
+	ControlCode string    `json:"control_code,omitempty"`
+	ControlID   *string   `json:"control_id,omitempty"`
+	ControlName string    `json:"control_name,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   *string   `json:"created_by,omitempty"`
+	// FrameworkID is always set. ControlID narrows it to a specific control.
+	FrameworkID string `json:"framework_id"`
+	// Computed, NOT persisted — filled by the list use case so the register
+	// can render a badge and a deep link without a round-trip per row.
+	FrameworkName string `json:"framework_name,omitempty"`
+	ID            string `json:"id"`
+	Note          string `json:"note"`
+	RiskID        string `json:"risk_id"`
+	// Source records where the link came from, so a link inferred by the 0046
+	// data migration is distinguishable from one a human made.
+	Source    RiskSource `json:"source"`
+	TenantID  string     `json:"tenant_id"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type RiskDistributionData struct {
+	Count int64 `json:"count"`
+	// CRITICAL, HIGH, MEDIUM, LOW
+	Level string `json:"level"`
+}
+
+// RiskHistory : Trace l'évolution d'un risque dans le temps
+type RiskHistory struct {
+	// CREATE, UPDATE, MITIGATE
+	ChangeType string `json:"change_type"`
+	// Qui et Quand
+	ChangedBy string `json:"changed_by"`
+	// Timestamp du changement
+	CreatedAt   time.Time `json:"created_at"`
+	ID          string    `json:"id"`
+	Impact      int64     `json:"impact"`
+	Probability int64     `json:"probability"`
+	RiskID      string    `json:"risk_id"`
+	// Snapshot des valeurs clés
+	Score  float64    `json:"score"`
+	Status RiskStatus `json:"status"`
+}
+
+// RiskLinkInput is the body of POST /mitigation-programmes/:id/risks.
+type RiskLinkInput struct {
+	ExpectedReduction float64 `json:"expected_reduction"`
+	RiskID            string  `json:"risk_id"`
+}
+
+// RiskMatrixCell représente le décompte des risques pour une cellule
+// (Impact, Proba). Impact/Probability sont des float64 (colonnes NUMERIC
+// en DB) — un ancien bug les déclarait en int, ce qui faisait échouer
+// le Scan GORM dès qu'une valeur n'était pas entière (ex:
+// probability=0.5), donc sur quasiment toute donnée réelle.
+type RiskMatrixCell struct {
+	Count       int64   `json:"count"`
+	Impact      float64 `json:"impact"`
+	Probability float64 `json:"probability"`
+}
+
+// RiskMetrics represents aggregated risk metrics
+type RiskMetrics struct {
+	ActiveRisks      int64            `json:"active_risks"`
+	AverageScore     float64          `json:"average_score"`
+	CreatedThisMonth int64            `json:"created_this_month"`
+	HighRisks        int64            `json:"high_risks"`
+	LowRisks         int64            `json:"low_risks"`
+	MediumRisks      int64            `json:"medium_risks"`
+	MitigatedRisks   int64            `json:"mitigated_risks"`
+	RisksByFramework map[string]int64 `json:"risks_by_framework"`
+	RisksByStatus    map[string]int64 `json:"risks_by_status"`
+	TotalRisks       int64            `json:"total_risks"`
+	UpdatedThisMonth int64            `json:"updated_this_month"`
+}
+
+// RiskPhase represents the ISO 31000 risk-management lifecycle stage of a
+// risk. This is ORTHOGONAL to RiskStatus: Status is the resolution state
+// (open/mitigated/accepted/…) while Phase is where the risk sits in the
+// managed lifecycle "Identifier → Analyser → Évaluer → Traiter →
+// Surveiller → Clôturer". Surfaced live on the real Risk entity
+// (register + drawer stepper).
+type RiskPhase string
+
+const (
+	RiskPhaseAnalyzed   RiskPhase = "analyzed"
+	RiskPhaseClosed     RiskPhase = "closed"
+	RiskPhaseEvaluated  RiskPhase = "evaluated"
+	RiskPhaseIdentified RiskPhase = "identified"
+	RiskPhaseMonitored  RiskPhase = "monitored"
+	RiskPhaseTreated    RiskPhase = "treated"
+)
+
+// RiskProjection is a risk's expected residual from its programmes — as
+// planned, and as they are tracking.
+type RiskProjection struct {
+	// FullReductionAt is when the last programme is projected to finish.
+	FullReductionAt *time.Time `json:"full_reduction_at,omitempty"`
+	Inherent        float64    `json:"inherent"`
+	// Planned*: every programme delivered by its target date.
+	PlannedReduction float64        `json:"planned_reduction"`
+	PlannedResidual  float64        `json:"planned_residual"`
+	Programmes       []Contribution `json:"programmes"`
+	// Projected*: what the programmes deliver by their target dates given
+	// their schedules. Lower than planned when one slips.
+	ProjectedReduction float64 `json:"projected_reduction"`
+	ProjectedResidual  float64 `json:"projected_residual"`
+	RiskID             string  `json:"risk_id"`
+	Slipping           bool    `json:"slipping"`
+	Title              string  `json:"title"`
+}
+
+// RiskResult is one risk above appetite.
+type RiskResult struct {
+	Breaches       []ScopedBreach    `json:"breaches"`
+	Criticality    string            `json:"criticality"`
+	Exception      AppetiteException `json:"exception"`
+	LifecycleState RiskState         `json:"lifecycle_state"`
+	RiskID         string            `json:"risk_id"`
+	Score          float64           `json:"score"`
+	Title          string            `json:"title"`
+}
+
+// RiskScenario is a scenario template. Built-in scenarios are not stored:
+// they live in code with stable ids (see scenario_library.go), and only a
+// tenant's own scenarios have rows.
+type RiskScenario struct {
+	// AssetCategories the scenario applies to. Empty means any asset.
+	AssetCategories []string  `json:"asset_categories"`
+	BuiltIn         bool      `json:"built_in"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       string    `json:"created_by,omitempty"`
+	Description     string    `json:"description"`
+	ID              string    `json:"id"`
+	// Impact is the Score Engine impact (0–10) a drafted risk starts from.
+	Impact float64 `json:"impact"`
+	// Key is a stable slug ("ransomware-erp"). Risks are tagged with it.
+	Key string `json:"key"`
+	// LEF is the loss event frequency, in events per year.
+	Lef FAIRRange `json:"lef"`
+	// LossMagnitude is the loss per event, in XAF.
+	LossMagnitude FAIRRange `json:"loss_magnitude"`
+	// SuggestedControls mitigate the scenario as a whole rather than one
+	// technique: incident response, backups tested for restore, insurance.
+	SuggestedControls ScenarioControlRefs `json:"suggested_controls"`
+	Techniques        ScenarioTechniques  `json:"techniques"`
+	TenantID          string              `json:"tenant_id"`
+	ThreatActors      ThreatActors        `json:"threat_actors"`
+	Title             string              `json:"title"`
+	UpdatedAt         time.Time           `json:"updated_at"`
+	// Version counts content changes. A risk records the version it was
+	// drafted from, so an analyst can tell a risk from an outdated template.
+	Version int64 `json:"version"`
+}
+
+// RiskScoringWeights is the per-tenant configuration of the smart-risk
+// model's eight factor weights (spec §8 "Calcul de risque intelligent").
+// Exactly one row per tenant (unique index on tenant_id); a tenant with no
+// row uses the built-in defaults. Weights are stored as their own columns
+// so they are queryable and a migration can seed defaults. They are
+// relative — pkg/scoring normalises them.
+type RiskScoringWeights struct {
+	BusinessCriticality float64   `json:"business_criticality"`
+	ControlMaturity     float64   `json:"control_maturity"`
+	CreatedAt           time.Time `json:"created_at"`
+	Exploitability      float64   `json:"exploitability"`
+	FinancialValue      float64   `json:"financial_value"`
+	ID                  string    `json:"id"`
+	IncidentHistory     float64   `json:"incident_history"`
+	InternetExposure    float64   `json:"internet_exposure"`
+	TenantID            string    `json:"tenant_id"`
+	ThreatIntel         float64   `json:"threat_intel"`
+	UpdatedAt           time.Time `json:"updated_at"`
+	UpdatedBy           string    `json:"updated_by"`
+	Vulnerabilities     float64   `json:"vulnerabilities"`
+}
+
+// RiskSeverityDistribution represents risk count by severity
+type RiskSeverityDistribution struct {
+	Critical int64 `json:"critical"`
+	High     int64 `json:"high"`
+	Low      int64 `json:"low"`
+	Medium   int64 `json:"medium"`
+}
+
+// RiskSource indicates where the risk originated
+type RiskSource string
+
+const (
+	RiskSourceAI       RiskSource = "ai"
+	RiskSourceCTIAuto  RiskSource = "cti_auto"
+	RiskSourceImport   RiskSource = "import"
+	RiskSourceManual   RiskSource = "manual"
+	RiskSourceScanAuto RiskSource = "scan_auto"
+	RiskSourceScenario RiskSource = "scenario"
+	RiskSourceVendor   RiskSource = "vendor"
+)
+
+// RiskState is the SINGLE canonical lifecycle of a risk. It replaces two
+// overlapping vocabularies that both claimed to say where a risk stood and
+// regularly disagreed: - RiskStatus — open / in_progress / mitigated /
+// accepted / closed, plus a legacy uppercase set (DRAFT / ACTIVE /
+// MITIGATED / ACCEPTED) - RiskPhase — the ISO 31000 phases identified /
+// analyzed / evaluated / treated / monitored / closed Nothing reconciled
+// them, so a risk could read "mitigated" while sitting in the "treated"
+// phase with no completed mitigation anywhere. That is the "cycle de vie
+// flou" bug. Both are now DERIVED from this state (see DerivedStatus and
+// DerivedPhase) and kept in the columns they always occupied, so every
+// existing reader, filter and pill keeps working while there is exactly
+// one writable source of truth. The graph, as specified: DRAFT →
+// IDENTIFIED → ASSESSED → TREATMENT_PLANNED → IN_TREATMENT →
+// (RESIDUAL_ACCEPTED | MITIGATED) → CLOSED ↘ REOPENED ↗
+type RiskState string
+
+const (
+	RiskStateAssessed         RiskState = "assessed"
+	RiskStateClosed           RiskState = "closed"
+	RiskStateDraft            RiskState = "draft"
+	RiskStateIdentified       RiskState = "identified"
+	RiskStateInTreatment      RiskState = "in_treatment"
+	RiskStateMitigated        RiskState = "mitigated"
+	RiskStateReopened         RiskState = "reopened"
+	RiskStateResidualAccepted RiskState = "residual_accepted"
+	RiskStateTreatmentPlanned RiskState = "treatment_planned"
+)
+
+type RiskStats struct {
+	Count int64  `json:"Count"`
+	Level string `json:"Level"`
+}
+
+// RiskStatus represents the lifecycle state of a risk
+type RiskStatus string
+
+const (
+	RiskStatusACCEPTED   RiskStatus = "ACCEPTED"
+	RiskStatusACTIVE     RiskStatus = "ACTIVE"
+	RiskStatusDRAFT      RiskStatus = "DRAFT"
+	RiskStatusMITIGATED  RiskStatus = "MITIGATED"
+	RiskStatusAccepted   RiskStatus = "accepted"
+	RiskStatusClosed     RiskStatus = "closed"
+	RiskStatusInProgress RiskStatus = "in_progress"
+	RiskStatusMitigated  RiskStatus = "mitigated"
+	RiskStatusOpen       RiskStatus = "open"
+)
+
+// RiskSuggestion is a pre-filled first-risk draft. Probability is on the
+// domain's [0,1] scale and Impact on [0,10] — the same scales the Score
+// Engine consumes, so what the user sees in the form is exactly what gets
+// scored.
+type RiskSuggestion struct {
+	Category       string   `json:"category"`
+	Description    string   `json:"description"`
+	Impact         float64  `json:"impact"`
+	Key            string   `json:"key"`
+	Probability    float64  `json:"probability"`
+	SuggestedAsset string   `json:"suggested_asset,omitempty"`
+	SuggestedTags  []string `json:"suggested_tags,omitempty"`
+	Title          string   `json:"title"`
+}
+
+// RiskTreatment represents the chosen treatment strategy
+type RiskTreatment string
+
+const (
+	RiskTreatmentAccept   RiskTreatment = "accept"
+	RiskTreatmentAvoid    RiskTreatment = "avoid"
+	RiskTreatmentMitigate RiskTreatment = "mitigate"
+	RiskTreatmentTransfer RiskTreatment = "transfer"
+)
+
+// RiskTrendDataPoint represents a data point in risk trend
+type RiskTrendDataPoint struct {
+	Count     int64   `json:"count"`
+	Date      string  `json:"date"`
+	Mitigated int64   `json:"mitigated"`
+	NewRisks  int64   `json:"new_risks"`
+	Score     float64 `json:"score"`
+}
+
+// RiskTrendPoint represents a point in a trend
+type RiskTrendPoint struct {
+	AvgScore  float64   `json:"avg_score"`
+	Count     int64     `json:"count"`
+	Date      time.Time `json:"date"`
+	Mitigated int64     `json:"mitigated"`
+	NewRisks  int64     `json:"new_risks"`
+}
+
+// Role represents a set of permissions for RBAC
+type Role struct {
+	CreatedAt   time.Time `json:"created_at"`
+	Description string    `json:"description"`
+	ID          string    `json:"id"`
+	// admin, analyst, viewer
+	Name string `json:"name"`
+	// e.g., ["risk:read", "risk:create"]
+	Permissions []string  `json:"permissions"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// RoleEnhanced extends the existing Role with RBAC support
+type RoleEnhanced struct {
+	CreatedAt    time.Time       `json:"created_at"`
+	Description  string          `json:"description"`
+	ID           string          `json:"id"`
+	IsActive     bool            `json:"is_active"`
+	IsPredefined bool            `json:"is_predefined"`
+	Level        RoleLevel       `json:"level"`
+	Metadata     json.RawMessage `json:"metadata,omitempty"`
+	Name         string          `json:"name"`
+	TenantID     string          `json:"tenant_id"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// RoleLevel represents the hierarchical level of a role
+type RoleLevel int64
+
+// Rule is an automation rule, keyed by name.
+type Rule struct {
+	Actions     AutomationActionList `json:"actions"`
+	Conditions  AutomationConditions `json:"conditions"`
+	Description string               `json:"description,omitempty"`
+	Enabled     bool                 `json:"enabled,omitempty"`
+	Name        string               `json:"name"`
+	Priority    int64                `json:"priority,omitempty"`
+	SLA         AutomationSLAConfig  `json:"sla"`
+	Trigger     AutomationTrigger    `json:"trigger"`
+}
+
+// RuleBody is ruleBody is the create/update payload.
+// Conditions/Actions/SLA bind directly from JSON into their domain shapes.
+type RuleBody struct {
+	Actions     AutomationActionList `json:"actions"`
+	Conditions  AutomationConditions `json:"conditions"`
+	Description string               `json:"description"`
+	Enabled     *bool                `json:"enabled,omitempty"`
+	Name        string               `json:"name"`
+	Priority    int64                `json:"priority"`
+	SLA         AutomationSLAConfig  `json:"sla"`
+	Trigger     string               `json:"trigger"`
+}
+
+// RulePreview reports what a rule WOULD do against the register as it
+// stands.
+type RulePreview struct {
+	// AlreadyLinked is how many satisfy it but already produced a risk.
+	AlreadyLinked int64 `json:"already_linked"`
+	// Evaluated is how many vulnerabilities were considered.
+	Evaluated int64 `json:"evaluated"`
+	// Samples are up to 10 findings that would produce a risk, with the
+	// reason.
+	Samples []RulePreviewSample `json:"samples"`
+	// TopRejections counts why the others did not qualify, so a rule that
+	// produces nothing explains itself instead of just looking broken.
+	TopRejections map[string]int64 `json:"top_rejections"`
+	// WouldCreate is how many open vulnerabilities satisfy the rule and do not
+	// already have a risk.
+	WouldCreate int64 `json:"would_create"`
+}
+
+// RulePreviewSample is one finding the rule would act on.
+type RulePreviewSample struct {
+	AssetName       string `json:"asset_name,omitempty"`
+	CVEID           string `json:"cve_id,omitempty"`
+	Reason          string `json:"reason"`
+	Title           string `json:"title"`
+	VulnerabilityID string `json:"vulnerability_id"`
+}
+
+// RuleState is the live indicator the UI polls for one rule.
+type RuleState struct {
+	Enabled         bool       `json:"enabled"`
+	FailureStreak   int64      `json:"failure_streak"`
+	Health          string     `json:"health"`
+	HealthDetail    string     `json:"health_detail"`
+	LastError       string     `json:"last_error,omitempty"`
+	LastRunAt       *time.Time `json:"last_run_at,omitempty"`
+	LastStatus      string     `json:"last_status,omitempty"`
+	Name            string     `json:"name"`
+	RuleID          string     `json:"rule_id"`
+	Sentence        string     `json:"sentence"`
+	SuspendedAt     *time.Time `json:"suspended_at,omitempty"`
+	SuspendedReason string     `json:"suspended_reason,omitempty"`
+	TemplateKey     string     `json:"template_key,omitempty"`
+	Trigger         string     `json:"trigger"`
+	TriggerCount    int64      `json:"trigger_count"`
+}
+
+// RunInput asks for one hook to run. Ticket is the create_ticket payload.
+type RunInput struct {
+	Hook   PluginHook `json:"hook"`
+	Ticket *Ticket    `json:"ticket,omitempty"`
+}
+
+// RunNowBody is runNowBody is the optional trigger context for a REAL
+// manual run.
+type RunNowBody struct {
+	AssetName string `json:"asset_name"`
+	// Confirm must be true. Running a rule opens risks, files tickets and
+	// pages people; the previous version of this endpoint did all of that
+	// behind a button labelled "Test", which is how production got surprised.
+	// To test without side effects, use POST /automation/rules/:id/dry-run.
+	Confirm      bool    `json:"confirm"`
+	CVEID        string  `json:"cve_id"`
+	CVSS         float64 `json:"cvss"`
+	KEV          bool    `json:"kev"`
+	PriorityTier string  `json:"priority_tier"`
+	Severity     string  `json:"severity"`
+}
+
+// RunResult is a finished run with what it produced.
+type RunResult struct {
+	// PreviewJobID is the scan preview emitted assets were staged under.
+	PreviewJobID string          `json:"preview_job_id,omitempty"`
+	Run          json.RawMessage `json:"run,omitempty"`
+	Ticket       *TicketOutput   `json:"ticket,omitempty"`
+}
+
+// SIEMDestination is one tenant's SIEM endpoint, with the cursors
+// recording how far each source has been shipped. Delivery is
+// at-least-once: a cursor moves only after the transport has accepted the
+// batch, so a crash between sending and recording re-sends that batch.
+// Records carry stable ids (the audit event id and chain sequence, the
+// auth log id) for the SIEM to deduplicate on.
+type SIEMDestination struct {
+	// AuditCursor is the last audit chain sequence shipped.
+	AuditCursor int64 `json:"audit_cursor"`
+	// AuthCursorAt and AuthCursorID are the last auth log entry shipped, in
+	// (created_at, id) order — the auth log has no sequence of its own.
+	AuthCursorAt *time.Time `json:"auth_cursor_at,omitempty"`
+	AuthCursorID string     `json:"auth_cursor_id,omitempty"`
+	// CACertPEM pins the CA the SIEM's certificate must chain to, for
+	// collectors behind a private CA. Empty uses the system roots.
+	CaCertPem           string    `json:"ca_cert_pem,omitempty"`
+	ConsecutiveFailures int64     `json:"consecutive_failures"`
+	CreatedAt           time.Time `json:"created_at"`
+	CreatedBy           string    `json:"created_by,omitempty"`
+	Enabled             bool      `json:"enabled"`
+	// Endpoint is host:port for syslog and the collector URL for HEC.
+	Endpoint    string     `json:"endpoint"`
+	Format      SIEMFormat `json:"format"`
+	ID          string     `json:"id"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+	// Shipping status. ConsecutiveFailures drives the retry backoff and resets
+	// on the first batch accepted.
+	LastShippedAt *time.Time    `json:"last_shipped_at,omitempty"`
+	Name          string        `json:"name"`
+	NextAttemptAt time.Time     `json:"next_attempt_at"`
+	Sources       StringList    `json:"sources"`
+	TenantID      string        `json:"tenant_id"`
+	Transport     SIEMTransport `json:"transport"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+// SIEMFormat is how each record is encoded.
+type SIEMFormat string
+
+const (
+	SIEMFormatCef  SIEMFormat = "cef"
+	SIEMFormatOcsf SIEMFormat = "ocsf"
+)
+
+// SIEMTransport is how records reach the SIEM.
+type SIEMTransport string
+
+const (
+	SIEMTransportHec    SIEMTransport = "hec"
+	SIEMTransportSyslog SIEMTransport = "syslog"
+)
+
+// SLAStats is the tenant SLA dashboard summary.
+type SLAStats struct {
+	// AtRisk is open trackers within 25% of their remaining budget (computed
+	// by the use case, not the repo).
+	AtRisk    int64 `json:"at_risk"`
+	Breached  int64 `json:"breached"`
+	Closed    int64 `json:"closed"`
+	Escalated int64 `json:"escalated"`
+	Met       int64 `json:"met"`
+	Open      int64 `json:"open"`
+}
+
+// SLAStatus is the lifecycle of an SLA countdown.
+type SLAStatus string
+
+const (
+	SLAStatusBreached  SLAStatus = "breached"
+	SLAStatusClosed    SLAStatus = "closed"
+	SLAStatusEscalated SLAStatus = "escalated"
+	SLAStatusMet       SLAStatus = "met"
+	SLAStatusOpen      SLAStatus = "open"
+)
+
+// SLATrackerView is a tracker plus the fields the dashboard computes on
+// read (remaining budget and whether it is currently past due).
+type SLATrackerView struct {
+	BreachedNow bool       `json:"breached_now"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DueAt       time.Time  `json:"due_at"`
+	// Escalation policy snapshot (frozen from the rule at creation time).
+	EscalateAt       *time.Time `json:"escalate_at,omitempty"`
+	EscalateChannels StringList `json:"escalate_channels,omitempty"`
+	EscalateToRole   string     `json:"escalate_to_role,omitempty"`
+	EscalatedAt      *time.Time `json:"escalated_at,omitempty"`
+	EscalationLevel  int64      `json:"escalation_level"`
+	ExecutionID      string     `json:"execution_id,omitempty"`
+	ID               string     `json:"id"`
+	OwnerID          string     `json:"owner_id,omitempty"`
+	RemainingMinutes int64      `json:"remaining_minutes"`
+	RiskID           string     `json:"risk_id,omitempty"`
+	RuleID           string     `json:"rule_id"`
+	Severity         string     `json:"severity"`
+	Status           SLAStatus  `json:"status"`
+	SubjectID        string     `json:"subject_id"`
+	// What the SLA is tracking. RiskID (when set) lets the monitor auto-close
+	// the tracker once the risk is resolved.
+	SubjectType string    `json:"subject_type"`
+	TenantID    string    `json:"tenant_id"`
+	TicketRef   string    `json:"ticket_ref,omitempty"`
+	Title       string    `json:"title"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// SaveIntegrationBody is saveIntegrationBody is the POST/PUT
+// /vulnerabilities/integrations body.
+type SaveIntegrationBody struct {
+	AutoCreateRisk         bool              `json:"auto_create_risk"`
+	AutoCreateTicket       bool              `json:"auto_create_ticket"`
+	BaseURL                string            `json:"base_url"`
+	ClearCredentials       bool              `json:"clear_credentials"`
+	Credentials            map[string]string `json:"credentials"`
+	Enabled                *bool             `json:"enabled,omitempty"`
+	LivePullEnabled        bool              `json:"live_pull_enabled"`
+	Name                   string            `json:"name"`
+	RegenerateWebhookToken bool              `json:"regenerate_webhook_token"`
+	ScheduleMinutes        int64             `json:"schedule_minutes"`
+	Source                 string            `json:"source"`
+	WebhookEnabled         bool              `json:"webhook_enabled"`
+}
+
+type SaveStepInput struct {
+	// Answers is the step's raw payload, stored verbatim so the form
+	// repopulates exactly as the user left it when they come back.
+	Answers map[string]any `json:"answers"`
+	// Next optionally names the step to move to; it may point backwards.
+	Next string `json:"next"`
+}
+
+type SaveTicketingBody struct {
+	BaseURL          string            `json:"base_url"`
+	ClearCredentials bool              `json:"clear_credentials"`
+	Credentials      map[string]string `json:"credentials"`
+	DefaultIssueType string            `json:"default_issue_type"`
+	Enabled          bool              `json:"enabled"`
+	ProjectOrTable   string            `json:"project_or_table"`
+	Provider         string            `json:"provider"`
+}
+
+// ScanConfig is a persisted, tenant-scoped scan configuration. For cloud
+// providers it carries AES-256-GCM-encrypted credentials
+// (EncryptedCredentials) that are decrypted ONLY at scan time inside the
+// worker — never logged, never returned to the client. For agent/nmap
+// providers it carries the target CIDR/hosts and the list of Agent IDs
+// authorised to run it.
+type ScanConfig struct {
+	AgentIDs  []string   `json:"agent_ids"`
+	CreatedAt time.Time  `json:"created_at"`
+	CreatedBy string     `json:"created_by"`
+	Enabled   bool       `json:"enabled"`
+	ID        string     `json:"id"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	Name      string     `json:"name"`
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+	// Options is a free-form provider-specific option bag (nmap flags,
+	// severity floor, feature toggles). Kept as JSONB so new options never
+	// require a migration.
+	Options  json.RawMessage `json:"options,omitempty"`
+	Provider ScannerProvider `json:"provider"`
+	// Cloud scoping. Regions to enumerate (empty = provider default / all).
+	Regions []string `json:"regions"`
+	// Recurring schedule. ScheduleMinutes = 0 means manual-only; > 0 makes the
+	// ScanScheduler trigger this config every N minutes. NextRunAt is when it
+	// is next due; LastRunAt is the last scheduled trigger.
+	ScheduleMinutes int64 `json:"schedule_minutes"`
+	// Agent/nmap scoping. Targets are CIDRs or host lists. AgentIDs lists the
+	// Agents allowed to pick up this config's jobs (empty = any tenant agent).
+	// AgentIDs is a text[] of UUID strings (lib/pq here predates pq.UUIDArray)
+	// — use AgentUUIDs() for the typed slice.
+	Targets   []string  `json:"targets"`
+	TenantID  string    `json:"tenant_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ScanJob is a single scan run for a ScanConfig. It is the coordination
+// record between the SaaS and the runner (cloud worker or on-prem agent).
+// The job NEVER persists assets/risks itself — on completion it points
+// at a Redis preview key.
+type ScanJob struct {
+	AssetsFound int64 `json:"assets_found"`
+	// ClaimedByAgent is set once an agent wins the Redis lock (nil for cloud
+	// jobs).
+	ClaimedByAgent string     `json:"claimed_by_agent,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	ConfigID       string     `json:"config_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Error          string     `json:"error,omitempty"`
+	FindingsFound  int64      `json:"findings_found"`
+	ID             string     `json:"id"`
+	// PreviewKey is the Redis key holding the full ScanPreview (TTL 48h) once
+	// the job completes. The user imports/ignores from that preview.
+	PreviewKey string          `json:"preview_key,omitempty"`
+	Provider   ScannerProvider `json:"provider"`
+	StartedAt  *time.Time      `json:"started_at,omitempty"`
+	Status     ScanJobStatus   `json:"status"`
+	// Targets is a frozen snapshot of the config's targets at trigger time, so
+	// an agent runs exactly what was queued even if the config later changes.
+	Targets     []string  `json:"targets"`
+	TenantID    string    `json:"tenant_id"`
+	TriggeredBy string    `json:"triggered_by"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ScanJobStatus is the lifecycle state of a scan run.
+type ScanJobStatus string
+
+const (
+	ScanJobStatusClaimed   ScanJobStatus = "claimed"
+	ScanJobStatusCompleted ScanJobStatus = "completed"
+	ScanJobStatusFailed    ScanJobStatus = "failed"
+	ScanJobStatusQueued    ScanJobStatus = "queued"
+	ScanJobStatusRunning   ScanJobStatus = "running"
+	ScanJobStatusTimeout   ScanJobStatus = "timeout"
+)
+
+// ScanPreview is the full, immutable snapshot of a scan run, held in Redis
+// with a 48h TTL. The user imports or ignores from this — nothing is in
+// the DB yet.
+type ScanPreview struct {
+	AgentID     string             `json:"agent_id,omitempty"`
+	AgentName   string             `json:"agent_name,omitempty"`
+	Assets      []AssetDiscovery   `json:"assets"`
+	ConfigID    string             `json:"config_id"`
+	CreatedAt   time.Time          `json:"created_at"`
+	Errors      []string           `json:"errors,omitempty"`
+	ExpiresAt   time.Time          `json:"expires_at"`
+	Findings    []FindingDiscovery `json:"findings"`
+	JobID       string             `json:"job_id"`
+	Mitigations []AutoMitigation   `json:"mitigations"`
+	Provider    ScannerProvider    `json:"provider"`
+	TenantID    string             `json:"tenant_id"`
+	// TriggeredBy is the user who launched the scan — used to target the
+	// completion notification (in-app + email).
+	TriggeredBy string `json:"triggered_by,omitempty"`
+}
+
+// ScannerAgent is an on-prem OpenRisk Scanner Agent registered by a
+// tenant. It is stateless on the client side; the SaaS tracks its
+// identity, health and the SHA-256 hash of its scoped "scanner" token
+// (never the token itself).
+type ScannerAgent struct {
+	CreatedAt     time.Time `json:"created_at"`
+	Hostname      string    `json:"hostname"`
+	ID            string    `json:"id"`
+	IP            string    `json:"ip"`
+	LastHeartbeat time.Time `json:"last_heartbeat"`
+	LastScanJobID string    `json:"last_scan_job_id,omitempty"`
+	Name          string    `json:"name"`
+	Os            string    `json:"os"`
+	RegisteredAt  time.Time `json:"registered_at"`
+	// RegistrationConfigID ties the Agent to the ScanConfig whose registration
+	// token was used to enrol it.
+	RegistrationConfigID string      `json:"registration_config_id,omitempty"`
+	Status               AgentStatus `json:"status"`
+	TenantID             string      `json:"tenant_id"`
+	TokenRotatedAt       time.Time   `json:"token_rotated_at"`
+	UpdatedAt            time.Time   `json:"updated_at"`
+	Version              string      `json:"version"`
+}
+
+// ScannerProvider enumerates the supported scan backends. ABSOLUTE RULE
+// (Master Prompt V5): only "nmap" and "agent" are agent-based;
+// nmap/osquery are NEVER executed inside the SaaS backend — an on-prem
+// Agent runs them and pushes results. The cloud providers (aws/azure/gcp)
+// run inside the SaaS worker using the official SDKs.
+type ScannerProvider string
+
+const (
+	ScannerProviderActiveDirectory ScannerProvider = "active_directory"
+	ScannerProviderAgent           ScannerProvider = "agent"
+	ScannerProviderAws             ScannerProvider = "aws"
+	ScannerProviderAzure           ScannerProvider = "azure"
+	ScannerProviderDocker          ScannerProvider = "docker"
+	ScannerProviderGcp             ScannerProvider = "gcp"
+	ScannerProviderGithub          ScannerProvider = "github"
+	ScannerProviderGitlab          ScannerProvider = "gitlab"
+	ScannerProviderKubernetes      ScannerProvider = "kubernetes"
+	ScannerProviderM365            ScannerProvider = "m365"
+	ScannerProviderNmap            ScannerProvider = "nmap"
+	ScannerProviderPlugin          ScannerProvider = "plugin"
+	ScannerProviderVmware          ScannerProvider = "vmware"
+)
+
+// ScenarioControlRef points at one control of a regulatory catalog, e.g.
+// {iso27001-2022, A.8.13}. It is matched against the tenant's controls by
+// the framework's catalog key and the control's reference code.
+type ScenarioControlRef struct {
+	Catalog string `json:"catalog"`
+	Ref     string `json:"ref"`
+}
+
+// ScenarioControlRefs is a jsonb list of control references.
+type ScenarioControlRefs []ScenarioControlRef
+
+// ScenarioInput is the body of POST/PUT /risk-scenarios.
+type ScenarioInput struct {
+	AssetCategories   []string            `json:"asset_categories"`
+	Description       string              `json:"description"`
+	Impact            float64             `json:"impact"`
+	Key               string              `json:"key"`
+	Lef               FAIRRange           `json:"lef"`
+	LossMagnitude     FAIRRange           `json:"loss_magnitude"`
+	SuggestedControls ScenarioControlRefs `json:"suggested_controls"`
+	Techniques        ScenarioTechniques  `json:"techniques"`
+	ThreatActors      ThreatActors        `json:"threat_actors"`
+	Title             string              `json:"title"`
+}
+
+// ScenarioTechnique is one ATT&CK technique a scenario uses, with the
+// controls that mitigate it.
+type ScenarioTechnique struct {
+	// T1486, T1566.001
+	ID          string               `json:"id"`
+	Mitigations []ScenarioControlRef `json:"mitigations"`
+	Name        string               `json:"name"`
+	Tactic      string               `json:"tactic"`
+}
+
+// ScenarioTechniques is a jsonb list of techniques.
+type ScenarioTechniques []ScenarioTechnique
+
+// ScheduledAction is one action laid out on the calendar.
+type ScheduledAction struct {
+	ActionID     string    `json:"action_id"`
+	AssigneeID   string    `json:"assignee_id,omitempty"`
+	Completed    bool      `json:"completed"`
+	Critical     bool      `json:"critical"`
+	DurationDays int64     `json:"duration_days"`
+	EffortDays   float64   `json:"effort_days"`
+	Finish       time.Time `json:"finish"`
+	// SlackDays is how many working days the action can slip without moving
+	// the programme's end.
+	SlackDays int64     `json:"slack_days"`
+	Start     time.Time `json:"start"`
+	Title     string    `json:"title"`
+}
+
+// Schema is an asset category's attribute schema, keyed by category.
+type Schema struct {
+	Attributes []AttributeDef `json:"attributes"`
+	Category   AssetCategory  `json:"category"`
+	Label      string         `json:"label,omitempty"`
+}
+
+// ScopeModel describes one scope's factors and their weights.
+type ScopeModel struct {
+	Factors []ScopeModelFactor `json:"factors"`
+	Scope   ScoringScope       `json:"scope"`
+}
+
+// ScopeModelFactor is one factor's declared weight.
+type ScopeModelFactor struct {
+	Factor       ScoringFactorKey `json:"factor"`
+	LabelI18nKey string           `json:"label_i18n_key"`
+	Weight       float64          `json:"weight"`
+}
+
+// ScopedBreach is a tolerance exceeded under a named statement.
+type ScopedBreach struct {
+	Limit       float64        `json:"limit"`
+	Metric      AppetiteMetric `json:"metric"`
+	ScopeLabel  string         `json:"scope_label"`
+	StatementID string         `json:"statement_id"`
+	Value       float64        `json:"value"`
+}
+
+// ScoreComponent is one axis of the cyber score with the (already
+// normalised) weight it carried. Weights sum to 1 across the axes that had
+// data.
+type ScoreComponent struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	// 0..100 for this axis (higher = safer)
+	Value int64 `json:"value"`
+	// effective weight after renormalisation
+	Weight float64 `json:"weight"`
+}
+
+// ScoringConfig représente la configuration de calcul de score
+// personnalisée
+type ScoringConfig struct {
+	AssetCriticalityMult map[string]float64 `json:"AssetCriticalityMult"`
+	// "impact*probability", "sqrt(impact*probability)", etc.
+	BaseFormula string `json:"BaseFormula"`
+	CreatedAt   string `json:"CreatedAt"`
+	Description string `json:"Description"`
+	ID          string `json:"ID"`
+	IsDefault   bool   `json:"IsDefault"`
+	Name        string `json:"Name"`
+	// "low", "medium", "high", "critical" -> score threshold
+	RiskMatrixThresholds map[string]int64   `json:"RiskMatrixThresholds"`
+	TenantID             string             `json:"TenantID"`
+	UpdatedAt            string             `json:"UpdatedAt"`
+	WeightingFactors     map[string]float64 `json:"WeightingFactors"`
+}
+
+// ScoringCriticalityLevel is criticalityLevel représente le niveau de
+// criticité d'un risque.
+type ScoringCriticalityLevel string
+
+const (
+	ScoringCriticalityLevelCritical ScoringCriticalityLevel = "critical"
+	ScoringCriticalityLevelHigh     ScoringCriticalityLevel = "high"
+	ScoringCriticalityLevelLow      ScoringCriticalityLevel = "low"
+	ScoringCriticalityLevelMedium   ScoringCriticalityLevel = "medium"
+)
+
+// ScoringFactorKey is factorKey identifies one contributing factor. These
+// strings are API contract: the client localises by them and never invents
+// its own.
+type ScoringFactorKey string
+
+const (
+	ScoringFactorKeyAssetCriticality      ScoringFactorKey = "asset_criticality"
+	ScoringFactorKeyControlGaps           ScoringFactorKey = "control_gaps"
+	ScoringFactorKeyCriticality           ScoringFactorKey = "criticality"
+	ScoringFactorKeyImpact                ScoringFactorKey = "impact"
+	ScoringFactorKeyIncidentPressure      ScoringFactorKey = "incident_pressure"
+	ScoringFactorKeyInternetExposure      ScoringFactorKey = "internet_exposure"
+	ScoringFactorKeyLinkedRiskExposure    ScoringFactorKey = "linked_risk_exposure"
+	ScoringFactorKeyProbability           ScoringFactorKey = "probability"
+	ScoringFactorKeyRiskExposure          ScoringFactorKey = "risk_exposure"
+	ScoringFactorKeyVulnerabilityPressure ScoringFactorKey = "vulnerability_pressure"
+)
+
+// ScoringFactorKey2 is factorKey identifies one of the eight factors of
+// the smart-risk model. The string values are stable API contract keys —
+// the frontend localises by them.
+type ScoringFactorKey2 string
+
+const (
+	ScoringFactorKey2BusinessCriticality ScoringFactorKey2 = "business_criticality"
+	ScoringFactorKey2ControlMaturity     ScoringFactorKey2 = "control_maturity"
+	ScoringFactorKey2Exploitability      ScoringFactorKey2 = "exploitability"
+	ScoringFactorKey2FinancialValue      ScoringFactorKey2 = "financial_value"
+	ScoringFactorKey2IncidentHistory     ScoringFactorKey2 = "incident_history"
+	ScoringFactorKey2InternetExposure    ScoringFactorKey2 = "internet_exposure"
+	ScoringFactorKey2ThreatIntel         ScoringFactorKey2 = "threat_intel"
+	ScoringFactorKey2Vulnerabilities     ScoringFactorKey2 = "vulnerabilities"
+)
+
+// ScoringResult is result is one computed score, with everything needed to
+// explain it.
+type ScoringResult struct {
+	Band             Band      `json:"band"`
+	BandLabelI18nKey string    `json:"band_label_i18n_key"`
+	Breakdown        []Factor  `json:"breakdown"`
+	ComputedAt       time.Time `json:"computed_at"`
+	FormulaVersion   string    `json:"formula_version"`
+	// Inherent is the exposure before any mitigation credit; Residual is what
+	// remains once applied mitigations are taken into account. Auditors ask
+	// for both, and a product that shows only one is answering half the
+	// question.
+	Inherent     float64 `json:"inherent"`
+	InherentBand Band    `json:"inherent_band"`
+	// Inputs are the measurements the calculation actually used — the
+	// assumptions the explainer shows. Echoing them back is what lets a user
+	// say "that impact figure is wrong" instead of "the score feels wrong".
+	Inputs map[string]any `json:"inputs"`
+	// MitigationEffectiveness ∈ [0,1] is the reduction applied to get
+	// Residual.
+	MitigationEffectiveness float64      `json:"mitigation_effectiveness"`
+	Residual                float64      `json:"residual"`
+	ResidualBand            Band         `json:"residual_band"`
+	Scope                   ScoringScope `json:"scope"`
+	// Value is the residual score when mitigations are known, otherwise the
+	// inherent one — it is the number every surface displays.
+	Value float64 `json:"value"`
+}
+
+// ScoringScope is scope names what is being scored. The same scale, the
+// same bands and the same explanation shape apply to all three — only
+// the factors differ.
+type ScoringScope string
+
+const (
+	ScoringScopeAsset  ScoringScope = "asset"
+	ScoringScopeRisk   ScoringScope = "risk"
+	ScoringScopeTenant ScoringScope = "tenant"
+)
+
+// SearchResult is result is one hit, shaped for direct rendering by the
+// palette (the icon is chosen from Type on the client, and clicking
+// navigates to URL).
+type SearchResult struct {
+	// severity token, for severity-bearing types
+	Badge string `json:"badge,omitempty"`
+	ID    string `json:"id"`
+	// intra-type ordering hint
+	Score    float64 `json:"score,omitempty"`
+	Subtitle string  `json:"subtitle,omitempty"`
+	Title    string  `json:"title"`
+	// risk|asset|vulnerability|control|audit|report|cve|user
+	Type string `json:"type"`
+	// frontend deep-link / section for the entity
+	URL string `json:"url"`
+}
+
+// Sector is one industry option in the wizard. Labels ship in both
+// languages so the client stays a renderer.
+type Sector struct {
+	Key       string            `json:"key"`
+	LabelI18n map[string]string `json:"label_i18n"`
+}
+
+// SendInput is the body of POST /vendors/:id/assessments. SentTo defaults
+// to the vendor's contact address; ExpiresInDays to DefaultVendorLinkDays.
+type SendInput struct {
+	ExpiresInDays int64 `json:"expires_in_days"`
+	// Locale picks the email's language ("en"; French otherwise).
+	Locale     string `json:"locale"`
+	SentTo     string `json:"sent_to"`
+	TemplateID string `json:"template_id"`
+}
+
+// SendResult is the answer to sending an assessment. LinkURL carries the
+// token and is returned only when the email did not go out (or there was
+// nobody to email), for the sender to deliver by hand — the invitation
+// rule: when mail works, only the recipient holds the link.
+type SendResult struct {
+	Assessment     AssessmentView `json:"assessment"`
+	Delivery       Delivery       `json:"delivery"`
+	DeliveryDetail string         `json:"delivery_detail,omitempty"`
+	LinkURL        string         `json:"link_url,omitempty"`
+}
+
+// ServiceMitigationStatus is mitigationStatus represents mitigation status
+// count
+type ServiceMitigationStatus struct {
+	Completed  int64 `json:"completed"`
+	InProgress int64 `json:"in_progress"`
+	NotStarted int64 `json:"not_started"`
+	Overdue    int64 `json:"overdue"`
+}
+
+// SessionRecord is one signed-in device, as shown in Settings →
+// Sessions. A read model, not a table: the underlying rows are refresh
+// tokens, and this is the shape a person can actually reason about —
+// what device, from where, when last used, and is it the one I'm holding.
+type SessionRecord struct {
+	CreatedAt time.Time `json:"created_at"`
+	// Current marks the session making the request, so the UI can warn before
+	// someone signs out the device they are using.
+	Current bool `json:"current"`
+	// Device is a human label derived from the User-Agent ("Chrome on macOS"),
+	// because a raw UA string is not something a user can judge.
+	Device     string     `json:"device"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	ID         string     `json:"id"`
+	IPAddress  string     `json:"ip_address,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	UserAgent  string     `json:"user_agent,omitempty"`
+}
+
+// SetCurrencyInput is the body of PUT /analytics/financial/currency.
+type SetCurrencyInput struct {
+	Currency string `json:"currency"`
+}
+
+// SetupMFAOutput represents MFA setup response
+type SetupMFAOutput struct {
+	// 8 backup codes
+	BackupCodes []string `json:"backup_codes"`
+	// Base64-encoded JPEG
+	QrCode string `json:"qr_code"`
+	// Base32-encoded TOTP secret
+	Secret string `json:"secret"`
+}
+
+// SideHealth counts one side's barriers by health.
+type SideHealth struct {
+	Degraded int64   `json:"degraded"`
+	Failed   int64   `json:"failed"`
+	Healthy  int64   `json:"healthy"`
+	Loss     float64 `json:"loss"`
+	Unknown  int64   `json:"unknown"`
+}
+
+// SimulateFinancialInput carries per-field overrides for a what-if
+// investment scenario. Every field is optional: nil = use the risk's
+// stored value. This powers the CISO/CFO "investment scenario" simulator
+// without persisting.
+type SimulateFinancialInput struct {
+	Aro                     *float64 `json:"aro,omitempty"`
+	DataLossCostXaf         *float64 `json:"data_loss_cost_xaf,omitempty"`
+	DowntimeHours           *float64 `json:"downtime_hours,omitempty"`
+	FinesXaf                *float64 `json:"fines_xaf,omitempty"`
+	HourlyDowntimeCostXaf   *float64 `json:"hourly_downtime_cost_xaf,omitempty"`
+	MitigationEffectiveness *float64 `json:"mitigation_effectiveness,omitempty"`
+	OtherDirectCostXaf      *float64 `json:"other_direct_cost_xaf,omitempty"`
+	RemediationCostXaf      *float64 `json:"remediation_cost_xaf,omitempty"`
+	SleXaf                  *float64 `json:"sle_xaf,omitempty"`
+}
+
+// SmartResult is the computed smart score plus its full, radar-ready
+// breakdown.
+type SmartResult struct {
+	// low|medium|high|critical
+	Criticality ScoringCriticalityLevel `json:"criticality"`
+	// top drivers, human readable
+	Explanation string `json:"explanation"`
+	// one per factor, in FactorKeys order
+	Factors []FactorScore `json:"factors"`
+	// 0–100
+	Score float64 `json:"score"`
+}
+
+// Snapshot is the full entitlements payload served at GET
+// /api/entitlements. It lists EVERY feature (including the ones off on
+// this plan, so the UI can grey and explain them), each limit with current
+// usage, the trial state, and the region's price table for the
+// paywall/billing page.
+type Snapshot struct {
+	Features  map[string]FeatureDTO `json:"features"`
+	Limits    map[string]LimitDTO   `json:"limits"`
+	Plan      string                `json:"plan"`
+	Prices    map[string]Price      `json:"prices"`
+	Region    string                `json:"region"`
+	Trial     *TrialInfo            `json:"trial,omitempty"`
+	TrialDays int64                 `json:"trial_days"`
+}
+
+// Staged is a batch as the wizard shows it: the detected columns, the
+// fields they can map to, the mapping to start from and the first rows.
+type Staged struct {
+	Batch     json.RawMessage   `json:"batch,omitempty"`
+	Mapping   ImportMapping     `json:"mapping"`
+	Sample    []ImportRowValues `json:"sample"`
+	Suggested bool              `json:"suggested"`
+	Targets   []ImportTarget    `json:"targets"`
+}
+
+// State is the payload of GET /activation/state.
+type State struct {
+	// AhaReachedAt is when the product first proved its value to this tenant
+	// — see MaybeRecordAha for the definition. Nil until then.
+	AhaReachedAt *time.Time `json:"aha_reached_at,omitempty"`
+	Percent      int64      `json:"percent"`
+	SignupAt     *time.Time `json:"signup_at,omitempty"`
+	Steps        []Step     `json:"steps"`
+	// TimeToAhaSeconds mirrors the Prometheus histogram for the UI/debugging.
+	TimeToAhaSeconds float64 `json:"time_to_aha_seconds,omitempty"`
+}
+
+// StatementInput is the body of POST/PUT /risk-appetite/statements.
+type StatementInput struct {
+	ApprovalReference string        `json:"approval_reference"`
+	ApprovedOn        *time.Time    `json:"approved_on,omitempty"`
+	BusinessUnit      string        `json:"business_unit"`
+	CategoryID        *string       `json:"category_id,omitempty"`
+	MaxAleP90Xaf      *float64      `json:"max_ale_p90_xaf,omitempty"`
+	MaxCriticalCount  *int64        `json:"max_critical_count,omitempty"`
+	MaxScore          *float64      `json:"max_score,omitempty"`
+	MaxSmartScore     *float64      `json:"max_smart_score,omitempty"`
+	Scope             AppetiteScope `json:"scope"`
+	Statement         string        `json:"statement"`
+}
+
+// StatementResult is one statement's scope measured against its
+// tolerances.
+type StatementResult struct {
+	// ALEP90XAF is nil when no quantifier is wired or the scope is empty.
+	AleP90Xaf *float64 `json:"ale_p90_xaf,omitempty"`
+	// Breached is true when either the portfolio or any risk in scope is
+	// above.
+	Breached bool `json:"breached"`
+	// Breaches are the portfolio tolerances exceeded.
+	Breaches      []AppetiteBreach      `json:"breaches"`
+	CriticalCount int64                 `json:"critical_count"`
+	InForce       bool                  `json:"in_force"`
+	RisksAbove    int64                 `json:"risks_above"`
+	RisksInScope  int64                 `json:"risks_in_scope"`
+	ScopeLabel    string                `json:"scope_label"`
+	Statement     RiskAppetiteStatement `json:"statement"`
+}
+
+// StatusSummary counts artifacts by effective status.
+type StatusSummary struct {
+	Expired      int64 `json:"expired"`
+	ExpiringSoon int64 `json:"expiring_soon"`
+	Pending      int64 `json:"pending"`
+	Rejected     int64 `json:"rejected"`
+	Valid        int64 `json:"valid"`
+}
+
+// Step is one checklist row as the client renders it. Everything the panel
+// needs is here — including the copy — so the panel itself holds NO
+// logic: it maps this slice to rows and nothing else. That is the direct
+// fix for the checklist that never ticked and the two rows that struck
+// through together.
+type Step struct {
+	// Celebrate is the server's instruction to fire the celebration exactly
+	// once: true only when the step is completed AND this user has not yet
+	// acknowledged it via POST /activation/celebrated. The client never
+	// decides on its own.
+	Celebrate bool `json:"celebrate"`
+	Completed bool `json:"completed"`
+	// CompletedAt is nil until the step's event first occurs, and never moves
+	// afterwards (the repository takes the FIRST occurrence per key).
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	DeepLink    string     `json:"deep_link"`
+	// the ONE event that ticks it
+	EventKey  string            `json:"event_key"`
+	HintI18n  map[string]string `json:"hint_i18n"`
+	Key       string            `json:"key"`
+	LabelI18n map[string]string `json:"label_i18n"`
+	Order     int64             `json:"order"`
+	Primary   bool              `json:"primary"`
+}
+
+// StepProgress is one step's standing, for the UI and for advancement.
+type StepProgress struct {
+	Approvals    int64    `json:"approvals"`
+	ApproverRole string   `json:"approver_role,omitempty"`
+	Approvers    []string `json:"approvers,omitempty"`
+	Name         string   `json:"name"`
+	// may be signed right now
+	Open              bool  `json:"open"`
+	Order             int64 `json:"order"`
+	Rejected          bool  `json:"rejected"`
+	RequiredApprovals int64 `json:"required_approvals"`
+	Satisfied         bool  `json:"satisfied"`
+}
+
+// StringList is a []string stored as jsonb (used for escalation channels).
+type StringList []string
+
+type SubjectAttributesInput struct {
+	Attributes map[string][]string `json:"attributes"`
+}
+
+type SubmitApprovalBody struct {
+	Action      string  `json:"action"`
+	Description string  `json:"description"`
+	EntityID    string  `json:"entity_id"`
+	EntityType  string  `json:"entity_type"`
+	Payload     JSONMap `json:"payload"`
+	Title       string  `json:"title"`
+}
+
+type SubmitNotificationBody struct {
+	Content     map[string]string `json:"content"`
+	EvidenceID  *string           `json:"evidence_id,omitempty"`
+	Reference   string            `json:"reference"`
+	SubmittedAt *time.Time        `json:"submitted_at,omitempty"`
+}
+
+// Subscription is one paid (or trialing) subscription per organization.
+// The effective plan an org gets is derived from this row when it is
+// entitled (trialing/active); otherwise the org falls back to Free.
+type Subscription struct {
+	CancelAtPeriodEnd bool            `json:"cancel_at_period_end"`
+	CanceledAt        *time.Time      `json:"canceled_at,omitempty"`
+	CreatedAt         time.Time       `json:"created_at"`
+	CurrentPeriodEnd  *time.Time      `json:"current_period_end,omitempty"`
+	ID                string          `json:"id"`
+	OrganizationID    string          `json:"organization_id"`
+	Plan              string          `json:"plan"`
+	Provider          BillingProvider `json:"provider,omitempty"`
+	// Provider linkage — opaque ids returned by Stripe/Notchpay/CinetPay.
+	// Never secrets; safe to expose for reconciliation.
+	ProviderCustomerID     string             `json:"provider_customer_id,omitempty"`
+	ProviderSubscriptionID string             `json:"provider_subscription_id,omitempty"`
+	Region                 string             `json:"region"`
+	Status                 SubscriptionStatus `json:"status"`
+	TrialEndsAt            *time.Time         `json:"trial_ends_at,omitempty"`
+	UpdatedAt              time.Time          `json:"updated_at"`
+}
+
+// SubscriptionInput creates or updates a subscription. On update, nil
+// fields are left alone.
+type SubscriptionInput struct {
+	Enabled    *bool    `json:"enabled,omitempty"`
+	EventTypes []string `json:"event_types"`
+	Name       *string  `json:"name,omitempty"`
+	URL        *string  `json:"url,omitempty"`
+}
+
+// SubscriptionStatus is the lifecycle state of a paid subscription.
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatusActive     SubscriptionStatus = "active"
+	SubscriptionStatusCanceled   SubscriptionStatus = "canceled"
+	SubscriptionStatusIncomplete SubscriptionStatus = "incomplete"
+	SubscriptionStatusPastDue    SubscriptionStatus = "past_due"
+	SubscriptionStatusTrialing   SubscriptionStatus = "trialing"
+)
+
+// SubscriptionWithSecret is a subscription with its signing secret,
+// returned once: on create and on rotation.
+type SubscriptionWithSecret struct {
+	// ConsecutiveFailures and FailingSince describe the current failure
+	// streak; both reset on the first successful attempt.
+	ConsecutiveFailures int64     `json:"consecutive_failures"`
+	CreatedAt           time.Time `json:"created_at"`
+	CreatedBy           string    `json:"created_by,omitempty"`
+	// DisabledReason says why delivery stopped when it was not a person's
+	// choice — the auto-disable records the last error here.
+	DisabledReason          string     `json:"disabled_reason,omitempty"`
+	Enabled                 bool       `json:"enabled"`
+	EventTypes              StringList `json:"event_types"`
+	FailingSince            *time.Time `json:"failing_since,omitempty"`
+	ID                      string     `json:"id"`
+	LastDeliveryAt          *time.Time `json:"last_delivery_at,omitempty"`
+	Name                    string     `json:"name"`
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty"`
+	Secret                  string     `json:"secret"`
+	TenantID                string     `json:"tenant_id"`
+	UpdatedAt               time.Time  `json:"updated_at"`
+	URL                     string     `json:"url"`
+}
+
+// Suggestion is what the barriers make of the risk's figures.
+type Suggestion struct {
+	CurrentImpact      float64 `json:"current_impact"`
+	CurrentProbability float64 `json:"current_probability"`
+	Impact             float64 `json:"impact"`
+	Probability        float64 `json:"probability"`
+	Raises             bool    `json:"raises"`
+}
+
+// Suggestions is the payload of GET /onboarding/suggestions: the
+// sector/goal driven content the wizard and the guided first risk render.
+type Suggestions struct {
+	Country    string           `json:"country,omitempty"`
+	Frameworks []string         `json:"frameworks"`
+	Goal       string           `json:"goal,omitempty"`
+	Goals      []Goal           `json:"goals"`
+	Industry   string           `json:"industry,omitempty"`
+	Risks      []RiskSuggestion `json:"risks"`
+	Sectors    []Sector         `json:"sectors"`
+}
+
+// Summary is the headline the dashboard and the board report quote.
+type Summary struct {
+	PendingExceptions int64 `json:"pending_exceptions"`
+	RisksAbove        int64 `json:"risks_above"`
+	ScopesBreached    int64 `json:"scopes_breached"`
+	StatementsInForce int64 `json:"statements_in_force"`
+	// WithoutException are risks above appetite with no valid exception: the
+	// number that should be zero.
+	WithoutException int64 `json:"without_exception"`
+}
+
+// SuspendBody is suspendBody carries the mandatory suspension reason.
+type SuspendBody struct {
+	Reason string `json:"reason"`
+}
+
+// SwitchOrganizationRequest is the switch body.
+type SwitchOrganizationRequest struct {
+	OrganizationID string `json:"organization_id"`
+}
+
+// SyncReport is what one pass did.
+type SyncReport struct {
+	Created  int64    `json:"created"`
+	Errors   []string `json:"errors,omitempty"`
+	Imported int64    `json:"imported"`
+	Pulled   int64    `json:"pulled"`
+	Pushed   int64    `json:"pushed"`
+}
+
+// SyncResult reports what a CRQ feed run changed.
+type SyncResult struct {
+	Processes    int64 `json:"processes"`
+	RisksUpdated int64 `json:"risks_updated"`
+}
+
+type TeamDetailDTO struct {
+	CreatedAt   string          `json:"created_at"`
+	Description string          `json:"description"`
+	ID          string          `json:"id"`
+	MemberCount int64           `json:"member_count"`
+	Members     []TeamMemberDTO `json:"members"`
+	Name        string          `json:"name"`
+}
+
+type TeamMemberDTO struct {
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+	ID       string `json:"id"`
+	JoinedAt string `json:"joined_at"`
+	Role     string `json:"role"`
+}
+
+type TeamResponseDTO struct {
+	CreatedAt   string `json:"created_at"`
+	Description string `json:"description"`
+	ID          string `json:"id"`
+	MemberCount int64  `json:"member_count"`
+	Name        string `json:"name"`
+}
+
+// TechniqueCoverage is one row of the coverage report.
+type TechniqueCoverage struct {
+	ID          string               `json:"id"`
+	Mitigations []MitigationCoverage `json:"mitigations"`
+	Name        string               `json:"name"`
+	Scenarios   []string             `json:"scenarios"`
+	Status      CoverageStatus       `json:"status"`
+	Tactic      string               `json:"tactic"`
+}
+
+type TelemetryInput struct {
+	Enabled bool `json:"enabled"`
+}
+
+// TemplateInput is the body of POST/PUT /vendor-questionnaires.
+type TemplateInput struct {
+	Description string          `json:"description"`
+	Name        string          `json:"name"`
+	PassScore   float64         `json:"pass_score"`
+	Questions   VendorQuestions `json:"questions"`
+}
+
+// Tenant represents a multi-tenant organization
+type Tenant struct {
+	CreatedAt time.Time       `json:"created_at"`
+	ID        string          `json:"id"`
+	IsActive  bool            `json:"is_active"`
+	Metadata  json.RawMessage `json:"metadata,omitempty"`
+	Name      string          `json:"name"`
+	OwnerID   string          `json:"owner_id"`
+	Slug      string          `json:"slug"`
+	// active, suspended, deleted
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TenantconfigResult is result is the outcome of an import. A dry run
+// reports the same changes without writing them.
+type TenantconfigResult struct {
+	Changes   []Change `json:"changes"`
+	Created   int64    `json:"created"`
+	DryRun    bool     `json:"dry_run"`
+	Unchanged int64    `json:"unchanged"`
+	Updated   int64    `json:"updated"`
+}
+
+// TestInput is the body of POST /control-test-plans/:id/tests.
+// SamplesTested defaults to the plan's sample size, TesterID to the caller
+// and PerformedAt to now.
+type TestInput struct {
+	Exceptions    int64             `json:"exceptions"`
+	Notes         string            `json:"notes"`
+	PerformedAt   *time.Time        `json:"performed_at,omitempty"`
+	Result        ControlTestResult `json:"result"`
+	SamplesTested int64             `json:"samples_tested"`
+	TesterID      *string           `json:"tester_id,omitempty"`
+}
+
+type TestIntegrationInput struct {
+	APIKey string `json:"api_key"`
+	APIURL string `json:"api_url"`
+}
+
+// TestOutcome says what recording a test did.
+type TestOutcome struct {
+	Effectiveness Effectiveness `json:"effectiveness"`
+	// Notified counts the owners told that a failed test raised their risk.
+	Notified int64            `json:"notified"`
+	Plan     json.RawMessage  `json:"plan,omitempty"`
+	Risks    []ResidualChange `json:"risks"`
+	Test     json.RawMessage  `json:"test,omitempty"`
+}
+
+type TestRequest struct {
+	Channel string `json:"channel"`
+}
+
+// TheHiveCaseLink pairs one incident with one TheHive case. The two
+// fingerprints are what stop the sync from echoing: each is a hash of the
+// shared fields (title, status, severity) as they stood on that side after
+// the last exchange. A side whose current hash still matches has not
+// changed since, so only the side that moved is propagated — and our own
+// push, coming back as a webhook, hashes to what we just recorded and is
+// ignored.
+type TheHiveCaseLink struct {
+	CaseID        string    `json:"case_id"`
+	CaseNumber    int64     `json:"case_number,omitempty"`
+	ConnectionID  string    `json:"connection_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	ID            string    `json:"id"`
+	IncidentID    int64     `json:"incident_id"`
+	LastDirection string    `json:"last_direction"`
+	// LastError is the most recent refusal, e.g. TheHive closing a CRITICAL
+	// incident whose post-mortem is not published yet. Cleared on success.
+	LastError       string     `json:"last_error,omitempty"`
+	LastSyncedAt    time.Time  `json:"last_synced_at"`
+	RemoteUpdatedAt *time.Time `json:"remote_updated_at,omitempty"`
+	TenantID        string     `json:"tenant_id"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// TheHiveConnection is a tenant's link to its TheHive 5 instance. One per
+// tenant: a SOC runs one case-management system, and two connections would
+// mean two places an incident could be mirrored to with no way to say
+// which is right. Sync is bi-directional. OpenRisk incidents are pushed as
+// cases (PushIncidents), TheHive cases are imported as incidents
+// (ImportCases), and once a pair is linked its status, severity and tasks
+// travel both ways. TheHive's webhooks drive the pull side; PollMinutes is
+// the fallback for instances whose webhook cannot reach us (and for the
+// webhooks that get lost anyway).
+type TheHiveConnection struct {
+	// BaseURL is the instance root (https://thehive.example.org), without
+	// /api. Organisation is sent as X-Organisation so a multi-org API key acts
+	// in the right organisation rather than its default one.
+	BaseURL   string    `json:"base_url"`
+	CreatedAt time.Time `json:"created_at"`
+	// Cursor is the highest remote _updatedAt already pulled; PushedUntil the
+	// highest local incident updated_at already pushed. Both are high-water
+	// marks, so a missed tick is caught up on the next one.
+	Cursor  *time.Time `json:"cursor,omitempty"`
+	Enabled bool       `json:"enabled"`
+	// Computed, NOT persisted.
+	HasAPIKey     bool       `json:"has_api_key"`
+	ID            string     `json:"id"`
+	ImportCases   bool       `json:"import_cases"`
+	LastSyncAt    *time.Time `json:"last_sync_at,omitempty"`
+	LastSyncError string     `json:"last_sync_error,omitempty"`
+	// never|ok|error
+	LastSyncStatus string `json:"last_sync_status"`
+	Name           string `json:"name"`
+	Organisation   string `json:"organisation,omitempty"`
+	// PollMinutes is the polling cadence; 0 means webhook-only.
+	PollMinutes   int64      `json:"poll_minutes"`
+	PushIncidents bool       `json:"push_incidents"`
+	PushedUntil   *time.Time `json:"pushed_until,omitempty"`
+	TenantID      string     `json:"tenant_id"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	// WebhookToken authenticates TheHive's notifier calls to
+	// /api/v1/integrations/thehive/webhook. Unique so the token alone
+	// identifies the tenant.
+	WebhookToken string `json:"webhook_token,omitempty"`
+}
+
+type TheHiveConnectionBody struct {
+	APIKey                 string `json:"api_key"`
+	BaseURL                string `json:"base_url"`
+	Enabled                *bool  `json:"enabled,omitempty"`
+	ImportCases            bool   `json:"import_cases"`
+	Name                   string `json:"name"`
+	Organisation           string `json:"organisation"`
+	PollMinutes            *int64 `json:"poll_minutes,omitempty"`
+	PushIncidents          bool   `json:"push_incidents"`
+	RegenerateWebhookToken bool   `json:"regenerate_webhook_token"`
+}
+
+// TheHiveObservable is one observable of a linked case and the asset it
+// was attributed to. AssetID is nil when nothing in the inventory matched
+// with enough confidence, and always nil for data types that do not name a
+// machine (hashes, URLs, mail addresses): those are kept so the analyst
+// can see what the case carries, not guessed onto an asset.
+type TheHiveObservable struct {
+	AssetID      string    `json:"asset_id,omitempty"`
+	CaseLinkID   string    `json:"case_link_id"`
+	Confidence   float64   `json:"confidence"`
+	CreatedAt    time.Time `json:"created_at"`
+	Data         string    `json:"data"`
+	DataType     string    `json:"data_type"`
+	ID           string    `json:"id"`
+	MatchReason  string    `json:"match_reason,omitempty"`
+	ObservableID string    `json:"observable_id"`
+	TenantID     string    `json:"tenant_id"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ThreatActor is a class of attacker, not a named group: "ransomware
+// affiliate" rather than a brand that changes every quarter.
+type ThreatActor struct {
+	Kind       ThreatActorKind `json:"kind"`
+	Motivation string          `json:"motivation"`
+	Name       string          `json:"name"`
+}
+
+// ThreatActorKind classifies who is behind a scenario.
+type ThreatActorKind string
+
+const (
+	ThreatActorKindCriminal    ThreatActorKind = "criminal"
+	ThreatActorKindHacktivist  ThreatActorKind = "hacktivist"
+	ThreatActorKindInsider     ThreatActorKind = "insider"
+	ThreatActorKindNationState ThreatActorKind = "nation_state"
+	ThreatActorKindPartner     ThreatActorKind = "partner"
+)
+
+// ThreatActors is a jsonb list of threat actors.
+type ThreatActors []ThreatActor
+
+// Ticket is what create_ticket is asked to open.
+type Ticket struct {
+	Description string   `json:"description"`
+	Labels      []string `json:"labels,omitempty"`
+	Priority    string   `json:"priority,omitempty"`
+	Summary     string   `json:"summary"`
+}
+
+// TicketOutput is the create_ticket result.
+type TicketOutput struct {
+	Error string `json:"error,omitempty"`
+	Key   string `json:"key"`
+	URL   string `json:"url,omitempty"`
+}
+
+// TokenCreateRequest represents the request to create a new token
+type TokenCreateRequest struct {
+	Description string         `json:"description"`
+	ExpiresAt   *time.Time     `json:"expires_at,omitempty"`
+	IPWhitelist []string       `json:"ip_whitelist"`
+	Metadata    map[string]any `json:"metadata"`
+	Name        string         `json:"name"`
+	Permissions []string       `json:"permissions"`
+	Scopes      []string       `json:"scopes"`
+	Type        TokenType      `json:"type"`
+}
+
+// TokenPair regroupe access + refresh token pour les réponses API
+type TokenPair struct {
+	AccessToken string `json:"access_token"`
+	// secondes
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	// "Bearer"
+	TokenType string `json:"token_type"`
+}
+
+// TokenResponse represents the response with token details (without the
+// actual token value)
+type TokenResponse struct {
+	CreatedAt   time.Time      `json:"created_at"`
+	Description string         `json:"description"`
+	ExpiresAt   *time.Time     `json:"expires_at,omitempty"`
+	ID          string         `json:"id"`
+	LastUsedAt  *time.Time     `json:"last_used_at,omitempty"`
+	Metadata    map[string]any `json:"metadata"`
+	Name        string         `json:"name"`
+	Permissions []string       `json:"permissions"`
+	RevokedAt   *time.Time     `json:"revoked_at,omitempty"`
+	Scopes      []string       `json:"scopes"`
+	Status      TokenStatus    `json:"status"`
+	TokenPrefix string         `json:"token_prefix"`
+	Type        TokenType      `json:"type"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// TokenRevokeRequest represents the request to revoke a token
+type TokenRevokeRequest struct {
+	Reason string `json:"reason"`
+}
+
+// TokenStatus represents the current status of a token
+type TokenStatus string
+
+const (
+	TokenStatusActive   TokenStatus = "active"
+	TokenStatusDisabled TokenStatus = "disabled"
+	TokenStatusExpired  TokenStatus = "expired"
+	TokenStatusRevoked  TokenStatus = "revoked"
+)
+
+// TokenType defines the type of API token
+type TokenType string
+
+const (
+	TokenTypeBasic  TokenType = "basic"
+	TokenTypeBearer TokenType = "bearer"
+	TokenTypeOauth  TokenType = "oauth"
+)
+
+// TokenUpdateRequest represents the request to update a token
+type TokenUpdateRequest struct {
+	Description string         `json:"description"`
+	IPWhitelist []string       `json:"ip_whitelist"`
+	Metadata    map[string]any `json:"metadata"`
+	Name        string         `json:"name"`
+	Permissions []string       `json:"permissions"`
+	Scopes      []string       `json:"scopes"`
+}
+
+// TokenWithValue represents a newly created token with its actual value
+type TokenWithValue struct {
+	CreatedAt   time.Time      `json:"created_at"`
+	Description string         `json:"description"`
+	ExpiresAt   *time.Time     `json:"expires_at,omitempty"`
+	ID          string         `json:"id"`
+	LastUsedAt  *time.Time     `json:"last_used_at,omitempty"`
+	Metadata    map[string]any `json:"metadata"`
+	Name        string         `json:"name"`
+	Permissions []string       `json:"permissions"`
+	RevokedAt   *time.Time     `json:"revoked_at,omitempty"`
+	Scopes      []string       `json:"scopes"`
+	Status      TokenStatus    `json:"status"`
+	// The actual token value (only shown once)
+	Token       string    `json:"token"`
+	TokenPrefix string    `json:"token_prefix"`
+	Type        TokenType `json:"type"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TopRisk represents a risk with key details
+type TopRisk struct {
+	AssignedTeam    string    `json:"assigned_team,omitempty"`
+	ID              string    `json:"id"`
+	LastUpdated     time.Time `json:"last_updated"`
+	MitigationCount int64     `json:"mitigation_count"`
+	Name            string    `json:"name"`
+	Score           float64   `json:"score"`
+	Severity        string    `json:"severity"`
+	Status          string    `json:"status"`
+	TrendPercent    float64   `json:"trend_percent"`
+}
+
+// TopRiskFinancial is one row of the "biggest financial exposures" table.
+type TopRiskFinancial struct {
+	Ale            Money   `json:"ale"`
+	AleWorst       Money   `json:"ale_worst"`
+	Criticality    string  `json:"criticality"`
+	ID             string  `json:"id"`
+	Rosi           float64 `json:"rosi"`
+	RosiComputable bool    `json:"rosi_computable"`
+	Title          string  `json:"title"`
+}
+
+// TopVulnerability is score/Impact/Probability are float64 — see
+// RiskMatrixCell's comment above for why declaring them as int silently
+// breaks GORM's Scan on real (non-integer) data.
+type TopVulnerability struct {
+	AssetsAffected int64   `json:"assets_affected"`
+	ID             string  `json:"id"`
+	Impact         float64 `json:"impact"`
+	Probability    float64 `json:"probability"`
+	Score          float64 `json:"score"`
+	// Severity mirrors the risks.criticality column (kept in sync by
+	// ScoreWorker via the real Score Engine) — the frontend's
+	// TopVulnerabilities widget colors/labels each row from this field and
+	// used to crash on it being undefined, since this endpoint's Scan error
+	// (see above) meant it had never returned real rows before.
+	Severity string `json:"severity"`
+	Status   string `json:"status"`
+	Title    string `json:"title"`
+}
+
+// TopologyEdge is one directed dependency, folded onto the four-type
+// vocabulary.
+type TopologyEdge struct {
+	Description string `json:"description,omitempty"`
+	ID          string `json:"id"`
+	// RawType is the stored type before folding, so the detail panel can say
+	// "runs_on" where that is what the operator recorded.
+	RawType DependencyType `json:"raw_type"`
+	Source  string         `json:"source"`
+	Target  string         `json:"target"`
+	Type    DependencyType `json:"type"`
+}
+
+// TopologyNode is one asset as the topology view needs it: enough to place
+// it, colour it and label it, and nothing more. The full asset is fetched
+// only when the user opens the detail panel — a 2 000-node graph must
+// not ship 2 000 full asset records with their preloaded risks.
+type TopologyNode struct {
+	Category    AssetCategory    `json:"category,omitempty"`
+	Criticality AssetCriticality `json:"criticality"`
+	// Degree is how many edges touch this node — the layout uses it for
+	// sizing, and it is what makes a hub legible without reading labels.
+	Degree int64  `json:"degree"`
+	ID     string `json:"id"`
+	// InternetExposed drives the second colouring mode. Derived from the typed
+	// attributes; false when the asset never declared one.
+	InternetExposed bool    `json:"internet_exposed"`
+	MaxRiskScore    float64 `json:"max_risk_score"`
+	Name            string  `json:"name"`
+	// RiskCount / MaxRiskScore summarise what the register already knows.
+	RiskCount int64  `json:"risk_count"`
+	Type      string `json:"type"`
+	// VulnCount is the number of open vulnerabilities attributed to this
+	// asset.
+	VulnCount int64 `json:"vuln_count"`
+	// Zone is the cluster this node belongs to (see ZoneOf).
+	Zone string `json:"zone"`
+}
+
+// TopologyZone is a cluster the layout groups nodes into.
+type TopologyZone struct {
+	Count int64  `json:"count"`
+	Key   string `json:"key"`
+	Label string `json:"label"`
+}
+
+// TransitionGuard names a precondition the target state imposes.
+type TransitionGuard string
+
+const (
+	TransitionGuardActiveMitigation   TransitionGuard = "active_mitigation"
+	TransitionGuardAppetiteException  TransitionGuard = "appetite_exception"
+	TransitionGuardGovernanceApproval TransitionGuard = "governance_approval"
+	TransitionGuardSubactionsComplete TransitionGuard = "subactions_complete"
+)
+
+// TransitionOption is one entry of GET /risks/:id/transitions: a reachable
+// state, whether it is currently allowed, and — when it is not —
+// exactly what is in the way, in words a user can act on.
+type TransitionOption struct {
+	Allowed bool `json:"allowed"`
+	// Guard names the failed precondition, so the UI can offer the right way
+	// out (open the mitigation, submit the approval) instead of a dead end.
+	Guard TransitionGuard `json:"guard,omitempty"`
+	// IsForward marks progress along the spine, for styling.
+	IsForward bool   `json:"is_forward"`
+	Label     string `json:"label"`
+	// Reason is empty when Allowed. Otherwise it names the blocker concretely
+	// ("2 sous-actions restantes sur la mitigation MIT-14") rather than
+	// restating the rule.
+	Reason string    `json:"reason,omitempty"`
+	To     RiskState `json:"to"`
+}
+
+// TransitionRiskInput is the body for POST /risks/:id/transition. `to` +
+// `comment` is the specified shape. `phase` + `note` are the previous one
+// and are still accepted: the route predates this change and clients in
+// the wild use it. Both map onto the same canonical state.
+type TransitionRiskInput struct {
+	Comment string `json:"comment"`
+	Note    string `json:"note"`
+	Phase   string `json:"phase"`
+	To      string `json:"to"`
+}
+
+// TransitionsView is the response body of GET /risks/:id/transitions.
+type TransitionsView struct {
+	BlockedReason string    `json:"blocked_reason,omitempty"`
+	Current       RiskState `json:"current"`
+	CurrentLabel  string    `json:"current_label"`
+	// Next is the natural forward step (blocked or not), so the stepper always
+	// has something to point at.
+	Next      RiskState          `json:"next,omitempty"`
+	NextLabel string             `json:"next_label,omitempty"`
+	Options   []TransitionOption `json:"options"`
+	StepCount int64              `json:"step_count"`
+	StepIndex int64              `json:"step_index"`
+}
+
+// TreatmentPlan is the synthesised risk summary plus a suggested
+// remediation plan.
+type TreatmentPlan struct {
+	Actions   []TreatmentPlanAction `json:"actions"`
+	Rationale string                `json:"rationale"`
+	// mitigate|accept|transfer|avoid
+	RecommendedStrategy string `json:"recommended_strategy"`
+	Summary             string `json:"summary"`
+}
+
+// TreatmentPlanAction is one concrete, ordered remediation step.
+type TreatmentPlanAction struct {
+	Description string `json:"description"`
+	// high|medium|low
+	Priority string `json:"priority"`
+	Title    string `json:"title"`
+}
+
+type TreatmentPlanInput struct {
+	Locale string `json:"locale"`
+}
+
+// TreatmentPlanResult wraps the assistant output with the provider that
+// produced it.
+type TreatmentPlanResult struct {
+	GeneratedBy string        `json:"generated_by"`
+	Plan        TreatmentPlan `json:"plan"`
+}
+
+// Trend is a KRI with its recent values, oldest first — what a sparkline
+// draws.
+type Trend struct {
+	AmberThreshold float64 `json:"amber_threshold"`
+	// BumpProbability raises each linked risk's probability by ProbabilityStep
+	// (and puts it up for review) when the KRI crosses into red.
+	BumpProbability bool         `json:"bump_probability"`
+	CreatedAt       time.Time    `json:"created_at"`
+	CreatedBy       string       `json:"created_by,omitempty"`
+	Description     string       `json:"description"`
+	Direction       KRIDirection `json:"direction"`
+	ID              string       `json:"id"`
+	// LastError is the last query evaluation failure, cleared on success.
+	LastError       string     `json:"last_error,omitempty"`
+	LastMeasuredAt  *time.Time `json:"last_measured_at,omitempty"`
+	LastValue       *float64   `json:"last_value,omitempty"`
+	Name            string     `json:"name"`
+	ProbabilityStep float64    `json:"probability_step"`
+	// Query is read only when Source is query.
+	Query          KRIQuery `json:"query"`
+	RedThreshold   float64  `json:"red_threshold"`
+	RefreshMinutes int64    `json:"refresh_minutes"`
+	// RiskIDs are the risks this indicator is an early warning for.
+	RiskIDs StringList `json:"risk_ids"`
+	Source  KRISource  `json:"source"`
+	// Current state, denormalised from the latest value.
+	Status    KRIStatus  `json:"status"`
+	TenantID  string     `json:"tenant_id"`
+	Unit      string     `json:"unit"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Values    []KRIValue `json:"values"`
+}
+
+// TrendPoint représente un point de données pour le graphique de
+// tendance.
+type TrendPoint struct {
+	// Format YYYY-MM-DD
+	Date string `json:"date"`
+	// Score global ce jour-là (AVG() — jamais un entier)
+	Score float64 `json:"score"`
+}
+
+// TrialInfo describes an active no-card trial.
+type TrialInfo struct {
+	Active   bool       `json:"active"`
+	DaysLeft int64      `json:"days_left"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+}
+
+type TypeDTO struct {
+	Description     string      `json:"description"`
+	Formats         []FormatDTO `json:"formats"`
+	Scope           string      `json:"scope"`
+	TemplateKey     string      `json:"template_key"`
+	TemplateVersion string      `json:"template_version"`
+	Title           string      `json:"title"`
+	Type            string      `json:"type"`
+}
+
+// UUIDArray is a PostgreSQL JSONB array of UUIDs
+type UUIDArray []string
+
+// UnmappedRisk is one row of /risks/unmapped.
+type UnmappedRisk struct {
+	CreatedAt      string                 `json:"created_at"`
+	Criticality    DomainCriticalityLevel `json:"criticality"`
+	ID             string                 `json:"id"`
+	LifecycleState RiskState              `json:"lifecycle_state"`
+	Score          float64                `json:"score"`
+	Title          string                 `json:"title"`
+}
+
+type UpdateAssetInput struct {
+	// Attributes replaces the whole bag when present; absent leaves it alone.
+	Attributes map[string]any `json:"attributes"`
+	// BusinessUnit: absent leaves it, "" clears it.
+	BusinessUnit *string `json:"business_unit,omitempty"`
+	Category     *string `json:"category,omitempty"`
+	Criticality  *string `json:"criticality,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	Owner        *string `json:"owner,omitempty"`
+	Type         *string `json:"type,omitempty"`
+}
+
+type UpdateAuditBody struct {
+	Auditor         *string  `json:"auditor,omitempty"`
+	ComplianceScore *float64 `json:"compliance_score,omitempty"`
+	// "" clears (program-wide)
+	FrameworkID    *string `json:"framework_id,omitempty"`
+	ScheduledEnd   *string `json:"scheduled_end,omitempty"`
+	ScheduledStart *string `json:"scheduled_start,omitempty"`
+	Scope          *string `json:"scope,omitempty"`
+	Status         *string `json:"status,omitempty"`
+	Summary        *string `json:"summary,omitempty"`
+	Title          *string `json:"title,omitempty"`
+	Type           *string `json:"type,omitempty"`
+}
+
+type UpdateBoardReportInput struct {
+	ComplianceCommentary *string  `json:"compliance_commentary,omitempty"`
+	ExecutiveSummary     *string  `json:"executive_summary,omitempty"`
+	FinancialCommentary  *string  `json:"financial_commentary,omitempty"`
+	Recommendations      []string `json:"recommendations,omitempty"`
+	RiskCommentary       *string  `json:"risk_commentary,omitempty"`
+	Title                *string  `json:"title,omitempty"`
+}
+
+type UpdateControlInput struct {
+	Description   *string `json:"description,omitempty"`
+	Name          *string `json:"name,omitempty"`
+	ReferenceCode *string `json:"reference_code,omitempty"`
+	Status        *string `json:"status,omitempty"`
+}
+
+// UpdateCustomFieldRequest is the request to update a custom field
+type UpdateCustomFieldRequest struct {
+	DefaultValue string                 `json:"default_value,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	DisplayName  string                 `json:"display_name,omitempty"`
+	Placeholder  string                 `json:"placeholder,omitempty"`
+	Position     int64                  `json:"position,omitempty"`
+	ReadOnly     bool                   `json:"read_only,omitempty"`
+	Validation   *CustomFieldValidation `json:"validation,omitempty"`
+	Visible      bool                   `json:"visible,omitempty"`
+}
+
+type UpdateRemediationBody struct {
+	// "" clears
+	AssignedTo  *string `json:"assigned_to,omitempty"`
+	Description *string `json:"description,omitempty"`
+	// "" clears
+	DueDate  *string `json:"due_date,omitempty"`
+	Priority *string `json:"priority,omitempty"`
+	Status   *string `json:"status,omitempty"`
+	Title    *string `json:"title,omitempty"`
+}
+
+type UpdateRequest struct {
+	DisableAllNotifications     *bool  `json:"disable_all_notifications,omitempty"`
+	EmailDeadlineAdvanceDays    *int64 `json:"email_deadline_advance_days,omitempty"`
+	EmailOnActionAssigned       *bool  `json:"email_on_action_assigned,omitempty"`
+	EmailOnCriticalRisk         *bool  `json:"email_on_critical_risk,omitempty"`
+	EmailOnMitigationDeadline   *bool  `json:"email_on_mitigation_deadline,omitempty"`
+	EnableDesktopNotifications  *bool  `json:"enable_desktop_notifications,omitempty"`
+	EnableSoundNotifications    *bool  `json:"enable_sound_notifications,omitempty"`
+	SlackEnabled                *bool  `json:"slack_enabled,omitempty"`
+	SlackOnActionAssigned       *bool  `json:"slack_on_action_assigned,omitempty"`
+	SlackOnCriticalRisk         *bool  `json:"slack_on_critical_risk,omitempty"`
+	SlackOnMitigationDeadline   *bool  `json:"slack_on_mitigation_deadline,omitempty"`
+	WebhookEnabled              *bool  `json:"webhook_enabled,omitempty"`
+	WebhookOnActionAssigned     *bool  `json:"webhook_on_action_assigned,omitempty"`
+	WebhookOnCriticalRisk       *bool  `json:"webhook_on_critical_risk,omitempty"`
+	WebhookOnMitigationDeadline *bool  `json:"webhook_on_mitigation_deadline,omitempty"`
+}
+
+// UpdateRiskInput : DTO pour la mise à jour partielle
+type UpdateRiskInput struct {
+	Aro        *float64        `json:"aro,omitempty"`
+	AssetIDs   []string        `json:"asset_ids"`
+	AssigneeID json.RawMessage `json:"assignee_id"`
+	// BusinessUnit: absent leaves it, "" clears it.
+	BusinessUnit *string `json:"business_unit,omitempty"`
+	// Category is tri-state like ownership: absent leaves it, null clears it.
+	CategoryID json.RawMessage `json:"category_id"`
+	// Classification: absent leaves it, "" clears it.
+	Classification  *string  `json:"classification,omitempty"`
+	DataLossCostXaf *float64 `json:"data_loss_cost_xaf,omitempty"`
+	Description     string   `json:"description"`
+	// Full financial-quantification drivers (spec §9). nil → leave
+	// unchanged.
+	DowntimeHours           *float64        `json:"downtime_hours,omitempty"`
+	FinesXaf                *float64        `json:"fines_xaf,omitempty"`
+	Frameworks              []string        `json:"frameworks"`
+	HourlyDowntimeCostXaf   *float64        `json:"hourly_downtime_cost_xaf,omitempty"`
+	Impact                  float64         `json:"impact"`
+	MitigationEffectiveness *float64        `json:"mitigation_effectiveness,omitempty"`
+	OtherDirectCostXaf      *float64        `json:"other_direct_cost_xaf,omitempty"`
+	OwnerID                 json.RawMessage `json:"owner_id"`
+	Probability             float64         `json:"probability"`
+	RemediationCostXaf      *float64        `json:"remediation_cost_xaf,omitempty"`
+	// Review cadence in days (0 disables).
+	ReviewIntervalDays *int64          `json:"review_interval_days,omitempty"`
+	ReviewerID         json.RawMessage `json:"reviewer_id"`
+	// CRQ monetary inputs (XAF). Pointers → nil means "leave unchanged".
+	SleXaf *float64 `json:"sle_xaf,omitempty"`
+	Status string   `json:"status"`
+	Tags   []string `json:"tags"`
+	Title  string   `json:"title"`
+}
+
+// UpdateRoleRequest defines request body for updating a role
+type UpdateRoleRequest struct {
+	Description string `json:"description"`
+	IsActive    bool   `json:"is_active"`
+	Name        string `json:"name"`
+}
+
+// UpdateRuleInput is a tenant's edit of the rule.
+type UpdateRuleInput struct {
+	Enabled                 bool             `json:"enabled"`
+	MinAssetCriticality     AssetCriticality `json:"min_asset_criticality"`
+	MinCVSS                 float64          `json:"min_cvss"`
+	NotifyOnCreate          bool             `json:"notify_on_create"`
+	RequireAsset            bool             `json:"require_asset"`
+	RequireInternetExposure bool             `json:"require_internet_exposure"`
+	RequireKEV              bool             `json:"require_kev"`
+}
+
+type UpdateSchemaInput struct {
+	Attributes []AttributeDef `json:"attributes"`
+	Label      string         `json:"label"`
+}
+
+type UpdateTeamInput struct {
+	Description string `json:"description"`
+	Name        string `json:"name"`
+}
+
+// UpdateTenantRequest defines request body for updating a tenant
+type UpdateTenantRequest struct {
+	IsActive bool           `json:"is_active"`
+	Metadata map[string]any `json:"metadata"`
+	Name     string         `json:"name"`
+	Slug     string         `json:"slug"`
+}
+
+type UpdateUserProfileInput struct {
+	Bio        string `json:"bio"`
+	Department string `json:"department"`
+	FullName   string `json:"full_name"`
+	Phone      string `json:"phone"`
+	Timezone   string `json:"timezone"`
+}
+
+type UpdateUserRoleInput struct {
+	Role string `json:"role"`
+}
+
+type UpdateUserStatusInput struct {
+	IsActive bool `json:"is_active"`
+}
+
+// User represents an authenticated system user with a role
+type User struct {
+	AvatarURL   string    `json:"avatar_url"`
+	Bio         string    `json:"bio"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedByID string    `json:"created_by_id,omitempty"`
+	// constraint:- : Organization is migrated after User (it FKs back to
+	// users.owner_id via Organization.Owner), so this reverse belongsTo must
+	// not force GORM to auto-create/FK organizations while migrating User
+	// first.
+	DefaultOrg   *Organization `json:"default_org,omitempty"`
+	DefaultOrgID string        `json:"default_org_id,omitempty"`
+	Department   string        `json:"department"`
+	Email        string        `json:"email"`
+	FullName     string        `json:"full_name"`
+	ID           string        `json:"id"`
+	IsActive     bool          `json:"is_active"`
+	LastLogin    *time.Time    `json:"last_login,omitempty"`
+	Phone        string        `json:"phone"`
+	Role         *Role         `json:"role,omitempty"`
+	RoleID       string        `json:"role_id"`
+	// RBAC Extensions (Phase 5 Priority #5)
+	TenantID  string    `json:"tenant_id,omitempty"`
+	Timezone  string    `json:"timezone"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Username  string    `json:"username"`
+}
+
+type UserDTO struct {
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+	ID       string `json:"id"`
+	Role     string `json:"role"`
+	Username string `json:"username"`
+}
+
+type UserResponseDTO struct {
+	CreatedAt string `json:"created_at"`
+	Email     string `json:"email"`
+	FullName  string `json:"full_name"`
+	ID        string `json:"id"`
+	IsActive  bool   `json:"is_active"`
+	LastLogin string `json:"last_login,omitempty"`
+	Role      string `json:"role"`
+	Username  string `json:"username"`
+}
+
+// UserStats : Profil du joueur
+type UserStats struct {
+	Badges          []Badge `json:"badges"`
+	Level           int64   `json:"level"`
+	MitigationsDone int64   `json:"mitigations_done"`
+	NextLevelXp     int64   `json:"next_level_xp"`
+	// 0-100
+	ProgressPercent float64 `json:"progress_percent"`
+	RisksManaged    int64   `json:"risks_managed"`
+	TotalXp         int64   `json:"total_xp"`
+}
+
+// UserTenant represents the many-to-many relationship between users and
+// tenants
+type UserTenant struct {
+	CreatedAt time.Time `json:"created_at"`
+	// Role association (RoleID → roles.id) so handlers can Preload("Role");
+	// without this field GORM errors ("unsupported relations: Role"), which
+	// was the 500 on GET /rbac/tenants (GetUserTenants preloads it).
+	Role      *RoleEnhanced `json:"role,omitempty"`
+	RoleID    string        `json:"role_id"`
+	TenantID  string        `json:"tenant_id"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	UserID    string        `json:"user_id"`
+}
+
+// ValueInput is one pushed value. MeasuredAt defaults to now.
+type ValueInput struct {
+	MeasuredAt *time.Time `json:"measured_at,omitempty"`
+	Value      float64    `json:"value"`
+}
+
+// Vendor is one supplier in the register.
+type Vendor struct {
+	// AssetID links the vendor to its asset (AssetCategory vendor), when the
+	// inventory has one.
+	AssetID        string            `json:"asset_id,omitempty"`
+	ContactEmail   string            `json:"contact_email"`
+	ContactName    string            `json:"contact_name"`
+	CreatedAt      time.Time         `json:"created_at"`
+	CreatedBy      string            `json:"created_by,omitempty"`
+	Criticality    VendorCriticality `json:"criticality"`
+	DataAccess     VendorDataAccess  `json:"data_access"`
+	ID             string            `json:"id"`
+	LastAssessedAt *time.Time        `json:"last_assessed_at,omitempty"`
+	// Outcome of the latest submitted assessment.
+	LastScore        *float64     `json:"last_score,omitempty"`
+	Name             string       `json:"name"`
+	NextAssessmentAt *time.Time   `json:"next_assessment_at,omitempty"`
+	OwnerID          string       `json:"owner_id,omitempty"`
+	Services         string       `json:"services"`
+	Status           VendorStatus `json:"status"`
+	TenantID         string       `json:"tenant_id"`
+	// Tier is derived from DataAccess and Criticality on every save.
+	Tier      VendorTier `json:"tier"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Website   string     `json:"website"`
+}
+
+// VendorAnswer is the vendor's answer to one question. EvidenceIDs are the
+// evidence records the answer and its attachments became.
+type VendorAnswer struct {
+	Comment     string     `json:"comment,omitempty"`
+	EvidenceIDs StringList `json:"evidence_ids,omitempty"`
+	QuestionID  string     `json:"question_id"`
+	Value       string     `json:"value"`
+}
+
+// VendorAnswers is an assessment's answer list, persisted as jsonb.
+type VendorAnswers []VendorAnswer
+
+// VendorAssessmentStatus is the stored state of an assessment. As with
+// invitations, expiry is not stored: State(now) projects it.
+type VendorAssessmentStatus string
+
+const (
+	VendorAssessmentStatusExpired    VendorAssessmentStatus = "expired"
+	VendorAssessmentStatusInProgress VendorAssessmentStatus = "in_progress"
+	VendorAssessmentStatusRevoked    VendorAssessmentStatus = "revoked"
+	VendorAssessmentStatusSent       VendorAssessmentStatus = "sent"
+	VendorAssessmentStatusSubmitted  VendorAssessmentStatus = "submitted"
+)
+
+// VendorCriticality is how much the business depends on the service.
+type VendorCriticality string
+
+const (
+	VendorCriticalityCritical VendorCriticality = "critical"
+	VendorCriticalityHigh     VendorCriticality = "high"
+	VendorCriticalityLow      VendorCriticality = "low"
+	VendorCriticalityMedium   VendorCriticality = "medium"
+)
+
+// VendorDataAccess is the most sensitive class of data the vendor
+// processes or can reach.
+type VendorDataAccess string
+
+const (
+	VendorDataAccessConfidential VendorDataAccess = "confidential"
+	VendorDataAccessInternal     VendorDataAccess = "internal"
+	VendorDataAccessNone         VendorDataAccess = "none"
+	VendorDataAccessPersonal     VendorDataAccess = "personal"
+	VendorDataAccessRegulated    VendorDataAccess = "regulated"
+)
+
+// VendorDetail is a vendor with its assessments, newest first.
+type VendorDetail struct {
+	Assessments []AssessmentView `json:"assessments"`
+	// AssetID links the vendor to its asset (AssetCategory vendor), when the
+	// inventory has one.
+	AssetID        string            `json:"asset_id,omitempty"`
+	ContactEmail   string            `json:"contact_email"`
+	ContactName    string            `json:"contact_name"`
+	CreatedAt      time.Time         `json:"created_at"`
+	CreatedBy      string            `json:"created_by,omitempty"`
+	Criticality    VendorCriticality `json:"criticality"`
+	DataAccess     VendorDataAccess  `json:"data_access"`
+	ID             string            `json:"id"`
+	LastAssessedAt *time.Time        `json:"last_assessed_at,omitempty"`
+	// Outcome of the latest submitted assessment.
+	LastScore        *float64     `json:"last_score,omitempty"`
+	Name             string       `json:"name"`
+	NextAssessmentAt *time.Time   `json:"next_assessment_at,omitempty"`
+	OwnerID          string       `json:"owner_id,omitempty"`
+	ReassessmentDue  bool         `json:"reassessment_due"`
+	Services         string       `json:"services"`
+	Status           VendorStatus `json:"status"`
+	TenantID         string       `json:"tenant_id"`
+	// Tier is derived from DataAccess and Criticality on every save.
+	Tier      VendorTier `json:"tier"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Website   string     `json:"website"`
+}
+
+// VendorInput is the body of POST/PUT /vendors. The tier is not an input:
+// it is derived from data_access and criticality.
+type VendorInput struct {
+	AssetID      *string           `json:"asset_id,omitempty"`
+	ContactEmail string            `json:"contact_email"`
+	ContactName  string            `json:"contact_name"`
+	Criticality  VendorCriticality `json:"criticality"`
+	DataAccess   VendorDataAccess  `json:"data_access"`
+	Name         string            `json:"name"`
+	OwnerID      *string           `json:"owner_id,omitempty"`
+	Services     string            `json:"services"`
+	Status       VendorStatus      `json:"status"`
+	Website      string            `json:"website"`
+}
+
+// VendorQuestion is one question of a questionnaire.
+type VendorQuestion struct {
+	AllowNa bool   `json:"allow_na"`
+	Help    string `json:"help,omitempty"`
+	ID      string `json:"id"`
+	// Options are read only for choice questions.
+	Options  []VendorQuestionOption `json:"options,omitempty"`
+	Required bool                   `json:"required"`
+	Section  string                 `json:"section"`
+	Text     string                 `json:"text"`
+	Type     VendorQuestionType     `json:"type"`
+	Weight   float64                `json:"weight"`
+}
+
+// VendorQuestionOption is one choice of a choice question.
+type VendorQuestionOption struct {
+	Label string  `json:"label"`
+	Score float64 `json:"score"`
+	Value string  `json:"value"`
+}
+
+// VendorQuestionType is how a question is answered, and therefore scored.
+type VendorQuestionType string
+
+const (
+	VendorQuestionTypeChoice   VendorQuestionType = "choice"
+	VendorQuestionTypeDocument VendorQuestionType = "document"
+	VendorQuestionTypeText     VendorQuestionType = "text"
+	VendorQuestionTypeYesNo    VendorQuestionType = "yes_no"
+)
+
+// VendorQuestionnaireTemplate is a questionnaire an assessment is sent
+// from. Built-in templates are not stored: they live in code
+// (vendor_templates.go) with stable ids, and only custom templates have
+// rows.
+type VendorQuestionnaireTemplate struct {
+	BuiltIn     bool               `json:"built_in"`
+	CreatedAt   time.Time          `json:"created_at"`
+	CreatedBy   string             `json:"created_by,omitempty"`
+	Description string             `json:"description"`
+	ID          string             `json:"id"`
+	Kind        VendorTemplateKind `json:"kind"`
+	Name        string             `json:"name"`
+	// PassScore is the score (0–100) under which a submitted assessment
+	// proposes a vendor risk.
+	PassScore float64         `json:"pass_score"`
+	Questions VendorQuestions `json:"questions"`
+	TenantID  string          `json:"tenant_id"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// VendorQuestions is a questionnaire's question list, persisted as jsonb.
+type VendorQuestions []VendorQuestion
+
+// VendorStatus is the vendor's place in the relationship.
+type VendorStatus string
+
+const (
+	VendorStatusActive     VendorStatus = "active"
+	VendorStatusOffboarded VendorStatus = "offboarded"
+	VendorStatusOnboarding VendorStatus = "onboarding"
+)
+
+// VendorTemplateKind tells built-in questionnaires from tenant-built ones.
+type VendorTemplateKind string
+
+const (
+	VendorTemplateKindCaiqLite VendorTemplateKind = "caiq_lite"
+	VendorTemplateKindCustom   VendorTemplateKind = "custom"
+	VendorTemplateKindSigLite  VendorTemplateKind = "sig_lite"
+)
+
+// VendorTier is the assessment depth and cadence a vendor warrants; tier 1
+// is the most scrutinised.
+type VendorTier int64
+
+// VendorView is a register row.
+type VendorView struct {
+	// AssetID links the vendor to its asset (AssetCategory vendor), when the
+	// inventory has one.
+	AssetID        string            `json:"asset_id,omitempty"`
+	ContactEmail   string            `json:"contact_email"`
+	ContactName    string            `json:"contact_name"`
+	CreatedAt      time.Time         `json:"created_at"`
+	CreatedBy      string            `json:"created_by,omitempty"`
+	Criticality    VendorCriticality `json:"criticality"`
+	DataAccess     VendorDataAccess  `json:"data_access"`
+	ID             string            `json:"id"`
+	LastAssessedAt *time.Time        `json:"last_assessed_at,omitempty"`
+	// Outcome of the latest submitted assessment.
+	LastScore        *float64     `json:"last_score,omitempty"`
+	Name             string       `json:"name"`
+	NextAssessmentAt *time.Time   `json:"next_assessment_at,omitempty"`
+	OwnerID          string       `json:"owner_id,omitempty"`
+	ReassessmentDue  bool         `json:"reassessment_due"`
+	Services         string       `json:"services"`
+	Status           VendorStatus `json:"status"`
+	TenantID         string       `json:"tenant_id"`
+	// Tier is derived from DataAccess and Criticality on every save.
+	Tier      VendorTier `json:"tier"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Website   string     `json:"website"`
+}
+
+// VerifyMFAOutput represents MFA verification response
+type VerifyMFAOutput struct {
+	Message  string `json:"message"`
+	Verified bool   `json:"verified"`
+}
+
+// VerifyResult is verify recomputes the hash and reports the verdict,
+// without downloading.
+type VerifyResult struct {
+	// ContentFingerprint is the value PRINTED on the document, and answers a
+	// different question: "is this the same content as that other copy". The
+	// two are necessarily different numbers — a file cannot carry the hash
+	// of itself.
+	ContentFingerprint string `json:"content_fingerprint"`
+	// ContentHash / Recomputed / Intact answer "are these exact bytes
+	// untampered".
+	ContentHash    string `json:"content_hash"`
+	Intact         bool   `json:"intact"`
+	Message        string `json:"message"`
+	RecomputedHash string `json:"recomputed_hash"`
+	ReportID       string `json:"report_id"`
+	SizeBytes      int64  `json:"size_bytes"`
+}
+
+// VersionDiff is what changed between two versions of a report.
+// Deliberately a comparison of the REQUEST and the RESULT, not of the
+// rendered bytes. Two PDFs differ in every byte the moment a date changes,
+// so a byte diff answers nothing; what a reviewer asks is "same scope?
+// same period? did the figures move?".
+type VersionDiff struct {
+	// Changes lists the differences in plain language.
+	Changes []string      `json:"changes"`
+	From    ReportSummary `json:"from"`
+	// SameDocument is true when the two artifacts are byte-identical — which
+	// means regenerating produced exactly the same document, and the
+	// underlying data has not moved.
+	SameDocument bool          `json:"same_document"`
+	To           ReportSummary `json:"to"`
+}
+
+// VulnIntegration is a tenant-scoped configuration for one vulnerability
+// scanner connector (Nessus, OpenVAS, Qualys, MS Defender, AWS Inspector,
+// Azure Defender, CrowdStrike). It carries the connection details (base
+// URL + encrypted API credentials), the live-pull schedule, the inbound
+// webhook token, and the automation toggles (auto-create risk /
+// auto-create ticket). One integration per (tenant, source). Credentials
+// are stored AES-256-GCM encrypted and are NEVER returned to the API —
+// only HasCredentials is exposed.
+type VulnIntegration struct {
+	// Automation — cross-module wiring, opt-in per integration.
+	AutoCreateRisk bool `json:"auto_create_risk"`
+	// P1 → Jira/ServiceNow ticket
+	AutoCreateTicket bool `json:"auto_create_ticket"`
+	// Connection — BaseURL is the tool's API endpoint (Qualys pod, Nessus
+	// host, ServiceNow instance…). EncryptedCredentials is the AES-256-GCM
+	// JSON of the per-source credential map (api keys / secrets / tenant ids).
+	BaseURL   string    `json:"base_url"`
+	CreatedAt time.Time `json:"created_at"`
+	Enabled   bool      `json:"enabled"`
+	// Computed, NOT persisted.
+	HasCredentials bool       `json:"has_credentials"`
+	ID             string     `json:"id"`
+	LastPullAt     *time.Time `json:"last_pull_at,omitempty"`
+	LastPullCount  int64      `json:"last_pull_count"`
+	LastPullError  string     `json:"last_pull_error,omitempty"`
+	// never|ok|error
+	LastPullStatus string `json:"last_pull_status"`
+	// Live pull — periodic API polling. ScheduleMinutes == 0 means manual
+	// only.
+	LivePullEnabled bool       `json:"live_pull_enabled"`
+	Name            string     `json:"name"`
+	ScheduleMinutes int64      `json:"schedule_minutes"`
+	Source          VulnSource `json:"source"`
+	TenantID        string     `json:"tenant_id"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	// Inbound webhook — the scanner POSTs findings to
+	// /api/v1/vulnerabilities/webhook/:source with this opaque token. Unique
+	// so the token alone identifies the integration + tenant.
+	WebhookEnabled bool   `json:"webhook_enabled"`
+	WebhookToken   string `json:"webhook_token,omitempty"`
+}
+
+// VulnRiskRule is the tenant's configurable rule for turning a
+// vulnerability into a risk in the register. One row per tenant. Before
+// this existed the condition was hardcoded ("P1 or CISA-KEV, on a known
+// asset") in the ingest use case, which meant every tenant got the same
+// appetite and nobody could see what it was.
+type VulnRiskRule struct {
+	CreatedAt time.Time `json:"created_at"`
+	// Enabled gates the whole rule. Disabled by default for a tenant that
+	// never configured one: automatic risk creation is a change to somebody's
+	// register and it should be asked for, not assumed.
+	Enabled bool   `json:"enabled"`
+	ID      string `json:"id"`
+	// MinAssetCriticality is the lowest asset criticality that qualifies
+	// (empty = any). An asset with no criticality never satisfies a floor
+	// above LOW — see EvaluateVulnRiskRule.
+	MinAssetCriticality AssetCriticality `json:"min_asset_criticality"`
+	// MinCVSS is the CVSS floor (0 disables the check).
+	MinCVSS float64 `json:"min_cvss"`
+	// NotifyOnCreate sends an in-app notification when the rule fires.
+	NotifyOnCreate bool `json:"notify_on_create"`
+	// RequireAsset demands the vulnerability be attributed to an asset. On by
+	// default: a risk about "something, somewhere" is not actionable, and
+	// after §3 an unattributed finding is a correlation problem to fix rather
+	// than a risk to open.
+	RequireAsset bool `json:"require_asset"`
+	// RequireInternetExposure limits creation to vulnerabilities on assets
+	// that declare internet exposure in their typed attributes.
+	RequireInternetExposure bool `json:"require_internet_exposure"`
+	// RequireKEV limits creation to CISA known-exploited vulnerabilities.
+	RequireKEV bool      `json:"require_kev"`
+	TenantID   string    `json:"tenant_id"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	UpdatedBy  string    `json:"updated_by"`
+}
+
+// VulnSeverity is the coarse severity band of a vulnerability, aligned
+// with the scanner + CTI vocabularies (critical|high|medium|low|info).
+type VulnSeverity string
+
+const (
+	VulnSeverityCritical VulnSeverity = "critical"
+	VulnSeverityHigh     VulnSeverity = "high"
+	VulnSeverityInfo     VulnSeverity = "info"
+	VulnSeverityLow      VulnSeverity = "low"
+	VulnSeverityMedium   VulnSeverity = "medium"
+)
+
+// VulnSource identifies where a vulnerability came from — the named
+// integrations plus the built-in scanner and manual entry.
+type VulnSource string
+
+const (
+	VulnSourceAwsInspector  VulnSource = "aws_inspector"
+	VulnSourceAzureDefender VulnSource = "azure_defender"
+	VulnSourceCrowdstrike   VulnSource = "crowdstrike"
+	VulnSourceManual        VulnSource = "manual"
+	VulnSourceMsDefender    VulnSource = "ms_defender"
+	VulnSourceNessus        VulnSource = "nessus"
+	VulnSourceOpenvas       VulnSource = "openvas"
+	VulnSourcePlugin        VulnSource = "plugin"
+	VulnSourceQualys        VulnSource = "qualys"
+	VulnSourceSarif         VulnSource = "sarif"
+	VulnSourceSbom          VulnSource = "sbom"
+	VulnSourceScanner       VulnSource = "scanner"
+)
+
+// VulnStats is the aggregate posture returned by GET
+// /vulnerabilities/stats.
+type VulnStats struct {
+	BySeverity   map[string]int64 `json:"by_severity"`
+	BySource     map[string]int64 `json:"by_source"`
+	ByStatus     map[string]int64 `json:"by_status"`
+	ByTier       map[string]int64 `json:"by_tier"`
+	ExploitCount int64            `json:"exploit_count"`
+	KEVCount     int64            `json:"kev_count"`
+	Open         int64            `json:"open"`
+	Total        int64            `json:"total"`
+}
+
+// VulnStatus is the remediation lifecycle state of a vulnerability.
+type VulnStatus string
+
+const (
+	VulnStatusAccepted      VulnStatus = "accepted"
+	VulnStatusFalsePositive VulnStatus = "false_positive"
+	VulnStatusInRemediation VulnStatus = "in_remediation"
+	VulnStatusOpen          VulnStatus = "open"
+	VulnStatusRemediated    VulnStatus = "remediated"
+	VulnStatusTriaged       VulnStatus = "triaged"
+)
+
+// VulnTicketProvider is the ITSM backend used for auto-ticketing.
+type VulnTicketProvider string
+
+const (
+	VulnTicketProviderJira       VulnTicketProvider = "jira"
+	VulnTicketProviderServicenow VulnTicketProvider = "servicenow"
+)
+
+// VulnTicketingConfig is the tenant-level ITSM configuration used to open
+// tickets from vulnerabilities. One row per tenant. Credentials are
+// encrypted at rest and never returned.
+type VulnTicketingConfig struct {
+	// Connection — BaseURL is the Jira/ServiceNow instance URL.
+	// ProjectOrTable is the Jira project key or the ServiceNow table (default:
+	// incident).
+	BaseURL          string    `json:"base_url"`
+	CreatedAt        time.Time `json:"created_at"`
+	DefaultIssueType string    `json:"default_issue_type"`
+	Enabled          bool      `json:"enabled"`
+	// Computed, NOT persisted.
+	HasCredentials bool               `json:"has_credentials"`
+	ID             string             `json:"id"`
+	ProjectOrTable string             `json:"project_or_table"`
+	Provider       VulnTicketProvider `json:"provider"`
+	TenantID       string             `json:"tenant_id"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+// Vulnerability is a persistent, tenant-scoped finding awaiting triage and
+// remediation. It is DISTINCT from a Risk (business-level) and from the
+// scanner's transient FindingDiscovery preview: this is the vulnerability
+// register, prioritised by pkg/vulnprio.
+type Vulnerability struct {
+	AffectedAssetsCount int64 `json:"affected_assets_count"`
+	// snapshot: LOW|MEDIUM|HIGH|CRITICAL
+	AssetCriticality string `json:"asset_criticality"`
+	// Asset linkage — business criticality + blast radius
+	AssetID   *string   `json:"asset_id,omitempty"`
+	AssetName string    `json:"asset_name"`
+	CreatedAt time.Time `json:"created_at"`
+	// Identity
+	CVEID string `json:"cve_id"`
+	// Technical scoring
+	CVSSScore   float64 `json:"cvss_score"`
+	CVSSVector  string  `json:"cvss_vector"`
+	Description string  `json:"description"`
+	// Exploitability signals
+	EPSS             float64 `json:"epss"`
+	ExploitAvailable bool    `json:"exploit_available"`
+	// none|poc|functional|high
+	ExploitMaturity string `json:"exploit_maturity"`
+	// id in the source tool (dedup key)
+	ExternalID string    `json:"external_id"`
+	FirstSeen  time.Time `json:"first_seen"`
+	ID         string    `json:"id"`
+	// CISA Known-Exploited
+	KEV      bool      `json:"kev"`
+	LastSeen time.Time `json:"last_seen"`
+	// MatchAmbiguous marks a finding the correlator refused to attribute
+	// because two or more assets matched equally well. It is NOT the same as
+	// "no match": the difference is what tells the user whether to add an
+	// asset or to fix a duplicate one, so the two states are kept apart.
+	MatchAmbiguous bool `json:"match_ambiguous"`
+	// MatchCandidates is the scored ranking the correlator produced, kept so
+	// the resolution screen can offer the choices without recomputing them
+	// against an inventory that may have changed since.
+	MatchCandidates json.RawMessage `json:"match_candidates,omitempty"`
+	// --- asset correlation (Attack Surface §3) -----------------------------
+	// How the affected asset was decided, and how sure we are. Persisted so
+	// the register can show it, sort by it, and so the "Unassigned
+	// vulnerabilities" screen can be a query rather than a re-run of the
+	// correlator. MatchConfidence is 0 for rows written before correlation
+	// existed and for findings nobody has attributed; MatchMethod says which
+	// signals agreed.
+	MatchConfidence float64 `json:"match_confidence"`
+	MatchMethod     string  `json:"match_method,omitempty"`
+	// MatchResolvedBy is set when a human attributed this finding, which pins
+	// it: a later ingest must not silently overwrite a decision someone made.
+	MatchResolvedBy string `json:"match_resolved_by,omitempty"`
+	// Computed, NOT persisted — filled by the use case for the API response.
+	PriorityExplanation string `json:"priority_explanation,omitempty"`
+	// Prioritisation (computed by pkg/vulnprio, persisted so the register can
+	// sort on it)
+	PriorityScore float64 `json:"priority_score"`
+	// P1|P2|P3|P4
+	PriorityTier    string          `json:"priority_tier"`
+	RawData         json.RawMessage `json:"raw_data,omitempty"`
+	RemediationHint string          `json:"remediation_hint"`
+	// Cross-module linkage (populated by the ingest automation, opt-in per
+	// integration).
+	RiskID   string       `json:"risk_id,omitempty"`
+	Severity VulnSeverity `json:"severity"`
+	// Provenance
+	Source VulnSource `json:"source"`
+	// Remediation lifecycle
+	Status   VulnStatus `json:"status"`
+	TenantID string     `json:"tenant_id"`
+	// e.g. VULN-123 / INC0012345
+	TicketKey string `json:"ticket_key,omitempty"`
+	// jira|servicenow
+	TicketProvider string    `json:"ticket_provider,omitempty"`
+	TicketURL      string    `json:"ticket_url,omitempty"`
+	Title          string    `json:"title"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// WebhookDelivery is one event queued for one subscription, and its log.
+type WebhookDelivery struct {
+	Attempts    int64      `json:"attempts"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+	DurationMs  int64      `json:"duration_ms"`
+	// EventID is the envelope id. An event is queued once per subscription —
+	// the unique index absorbs a second publication of the same event —
+	// except for redeliveries, which are new rows on purpose.
+	EventID        string           `json:"event_id"`
+	EventType      WebhookEventType `json:"event_type"`
+	ID             string           `json:"id"`
+	LastError      string           `json:"last_error,omitempty"`
+	LastStatusCode int64            `json:"last_status_code,omitempty"`
+	NextAttemptAt  time.Time        `json:"next_attempt_at"`
+	// Payload is the envelope exactly as it is sent; the signature is computed
+	// over these bytes at each attempt.
+	Payload json.RawMessage `json:"payload,omitempty"`
+	// RedeliveryOf is the delivery this one was redelivered from.
+	RedeliveryOf   string                `json:"redelivery_of,omitempty"`
+	RequestedBy    string                `json:"requested_by,omitempty"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	SubscriptionID string                `json:"subscription_id"`
+	TenantID       string                `json:"tenant_id"`
+}
+
+// WebhookDeliveryStatus is where a delivery stands.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusDelivering WebhookDeliveryStatus = "delivering"
+	WebhookDeliveryStatusFailed     WebhookDeliveryStatus = "failed"
+	WebhookDeliveryStatusPending    WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded  WebhookDeliveryStatus = "succeeded"
+)
+
+// WebhookEvent is TheHive 5's notifier payload, reduced to what routing
+// needs.
+type WebhookEvent struct {
+	ObjectID   string `json:"objectId"`
+	ObjectType string `json:"objectType"`
+	Operation  string `json:"operation"`
+	RootID     string `json:"rootId"`
+}
+
+// WebhookEventType names a domain event a subscription can receive.
+type WebhookEventType string
+
+const (
+	WebhookEventTypeApprovalDecided        WebhookEventType = "approval.decided"
+	WebhookEventTypeAuditCheckpointCreated WebhookEventType = "audit.checkpoint_created"
+	WebhookEventTypeEvidenceExpired        WebhookEventType = "evidence.expired"
+	WebhookEventTypeIncidentDeclared       WebhookEventType = "incident.declared"
+	WebhookEventTypeRiskScoreUpdated       WebhookEventType = "risk.score_updated"
+	WebhookEventTypeRiskStateChanged       WebhookEventType = "risk.state_changed"
+	WebhookEventTypeVulnerabilityDetected  WebhookEventType = "vulnerability.detected"
+)
+
+// WebhookSubscription is an endpoint and the events it receives.
+type WebhookSubscription struct {
+	// ConsecutiveFailures and FailingSince describe the current failure
+	// streak; both reset on the first successful attempt.
+	ConsecutiveFailures int64     `json:"consecutive_failures"`
+	CreatedAt           time.Time `json:"created_at"`
+	CreatedBy           string    `json:"created_by,omitempty"`
+	// DisabledReason says why delivery stopped when it was not a person's
+	// choice — the auto-disable records the last error here.
+	DisabledReason          string     `json:"disabled_reason,omitempty"`
+	Enabled                 bool       `json:"enabled"`
+	EventTypes              StringList `json:"event_types"`
+	FailingSince            *time.Time `json:"failing_since,omitempty"`
+	ID                      string     `json:"id"`
+	LastDeliveryAt          *time.Time `json:"last_delivery_at,omitempty"`
+	Name                    string     `json:"name"`
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty"`
+	TenantID                string     `json:"tenant_id"`
+	UpdatedAt               time.Time  `json:"updated_at"`
+	URL                     string     `json:"url"`
+}
+
+// Weights are the smart-risk factor weights.
+type Weights struct {
+	BusinessCriticality float64 `json:"business_criticality"`
+	ControlMaturity     float64 `json:"control_maturity"`
+	Exploitability      float64 `json:"exploitability"`
+	FinancialValue      float64 `json:"financial_value"`
+	IncidentHistory     float64 `json:"incident_history"`
+	InternetExposure    float64 `json:"internet_exposure"`
+	ThreatIntel         float64 `json:"threat_intel"`
+	Vulnerabilities     float64 `json:"vulnerabilities"`
+}
+
+// WizardState is the payload of GET /onboarding/state.
+type WizardState struct {
+	Answers     JSONMap    `json:"answers"`
+	Completed   bool       `json:"completed"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Country     string     `json:"country,omitempty"`
+	CurrentStep string     `json:"current_step"`
+	Goal        string     `json:"goal,omitempty"`
+	Industry    string     `json:"industry,omitempty"`
+	// Landing is where the wizard drops the user on completion (derived from
+	// the chosen goal).
+	Landing   string   `json:"landing"`
+	Percent   int64    `json:"percent"`
+	StepIndex int64    `json:"step_index"`
+	Steps     []string `json:"steps"`
+}
+
+type WorkflowBody struct {
+	Action         string             `json:"action"`
+	Description    string             `json:"description"`
+	Enabled        *bool              `json:"enabled,omitempty"`
+	EntityType     string             `json:"entity_type"`
+	ExpiresInHours int64              `json:"expires_in_hours"`
+	Mode           string             `json:"mode"`
+	Name           string             `json:"name"`
+	RequestType    string             `json:"request_type"`
+	Steps          []WorkflowStepBody `json:"steps"`
+}
+
+// WorkflowStep is one gate in an approval chain. ApproverRole names the
+// role that may sign this step ("" or "any" = any tenant member).
+// MinApprovals is how many distinct approvers of that role must sign
+// before the chain advances.
+type WorkflowStep struct {
+	ApproverRole string `json:"approver_role"`
+	// ApproverUserIDs names specific people, for the case a role cannot
+	// express: "the CISO and the DPO, not merely two admins".
+	ApproverUserIDs []string `json:"approver_user_ids,omitempty"`
+	MinApprovals    int64    `json:"min_approvals"`
+	Name            string   `json:"name"`
+	Order           int64    `json:"order"`
+	// QuorumPercent expresses the gate as a share of the named approvers (e.g.
+	// 60% of five people = three). Resolved against MinApprovals, never below
+	// it. Meaningless without named approvers, and treated as such.
+	QuorumPercent int64 `json:"quorum_percent,omitempty"`
+}
+
+type WorkflowStepBody struct {
+	ApproverRole    string   `json:"approver_role"`
+	ApproverUserIDs []string `json:"approver_user_ids"`
+	MinApprovals    int64    `json:"min_approvals"`
+	Name            string   `json:"name"`
+	QuorumPercent   int64    `json:"quorum_percent"`
+}
+
+// WorkflowStepList is a jsonb array of steps.
+type WorkflowStepList []WorkflowStep
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+)
+
+// Signer produces Ed25519 signatures over documents that leave the product —
+// evidence packages, audit checkpoints — and must be checkable by someone who
+// holds only the public key.
+//
+// Ed25519 rather than the HMAC used on in-app exports: an HMAC can only be
+// checked by a holder of the secret, which is exactly who an external auditor
+// is not. The signature verifies with stock OpenSSL (`openssl pkeyutl -verify
+// -rawin`), so the recipient does not need our tooling.
+type Signer struct {
+	priv  ed25519.PrivateKey
+	keyID string
+}
+
+// NewSignerFromSecret derives a deterministic key pair from an operator secret.
+// The secret is hashed to the 32-byte seed (the MFA_ENCRYPTION_KEY convention),
+// so any string the installer generates works, and the same secret always
+// yields the same public key — a restart does not invalidate published keys.
+func NewSignerFromSecret(secret string) (*Signer, error) {
+	if len(secret) < 16 {
+		return nil, errors.New("signing secret must be at least 16 characters")
+	}
+	seed := sha256.Sum256([]byte(secret))
+	priv := ed25519.NewKeyFromSeed(seed[:])
+	return &Signer{priv: priv, keyID: PublicKeyID(priv.Public().(ed25519.PublicKey))}, nil
+}
+
+// PublicKeyID is the key id a Signer reports for pub: the first 8 bytes of its
+// SHA-256, hex-encoded. Verifiers use it to pick the key a signature names.
+func PublicKeyID(pub ed25519.PublicKey) string {
+	kid := sha256.Sum256(pub)
+	return hex.EncodeToString(kid[:8])
+}
+
+// Algorithm names the scheme in manifests, so a verifier never has to guess.
+func (s *Signer) Algorithm() string { return "Ed25519" }
+
+// KeyID is a short fingerprint of the public key (first 8 bytes of its SHA-256).
+func (s *Signer) KeyID() string { return s.keyID }
+
+func (s *Signer) Sign(msg []byte) []byte { return ed25519.Sign(s.priv, msg) }
+
+func (s *Signer) PublicKey() ed25519.PublicKey { return s.priv.Public().(ed25519.PublicKey) }
+
+// PublicKeyPEM is the public key as a SubjectPublicKeyInfo PEM block — the
+// format openssl reads with -pubin.
+func (s *Signer) PublicKeyPEM() []byte {
+	der, err := x509.MarshalPKIXPublicKey(s.PublicKey())
+	if err != nil {
+		// Cannot happen for an Ed25519 key; x509 supports it unconditionally.
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+// ErrBadSignature is returned when a signature does not verify.
+var ErrBadSignature = errors.New("signature does not verify")
+
+// VerifyEd25519PEM checks sig over msg against a PEM public key as written by
+// PublicKeyPEM.
+func VerifyEd25519PEM(pubPEM, msg, sig []byte) error {
+	pub, err := ParseEd25519PublicKeyPEM(pubPEM)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(pub, msg, sig) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// ParseEd25519PublicKeyPEM reads a public key written by PublicKeyPEM.
+func ParseEd25519PublicKeyPEM(pubPEM []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(pubPEM)
+	if block == nil {
+		return nil, errors.New("public key is not PEM")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not Ed25519")
+	}
+	return pub, nil
+}
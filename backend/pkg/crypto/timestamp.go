@@ -0,0 +1,525 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package crypto
+
+import (
+	"bytes"
+	"context"
+	gocrypto "crypto"
+	"crypto/rand"
+	"crypto/x509"
+	encasn1 "encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// RFC 3161 trusted timestamps. A timestamp authority (TSA) signs "this digest
+// existed at this time"; anyone holding the TSA's certificate can check it
+// later without asking us or the TSA. Only the subset a TSA token actually
+// uses is implemented: a CMS SignedData with signed attributes, one signer,
+// SHA-2 digests and RSA, ECDSA or Ed25519 signatures.
+
+var (
+	oidSHA256           = encasn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSHA384           = encasn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidSHA512           = encasn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+	oidSignedData       = encasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidTSTInfo          = encasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 1, 4}
+	oidAttrContentType  = encasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidAttrMessageDigst = encasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidRSAEncryption    = encasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidRSAPSS           = encasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}
+	oidSHA256WithRSA    = encasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 11}
+	oidSHA384WithRSA    = encasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 12}
+	oidSHA512WithRSA    = encasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 13}
+	oidECPublicKey      = encasn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidECDSAWithSHA256  = encasn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+	oidECDSAWithSHA384  = encasn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 3}
+	oidECDSAWithSHA512  = encasn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 4}
+	oidEd25519          = encasn1.ObjectIdentifier{1, 3, 101, 112}
+)
+
+// ErrBadTimestamp is returned when a timestamp token is malformed, does not
+// cover the expected digest, or its signature does not verify.
+var ErrBadTimestamp = errors.New("timestamp token does not verify")
+
+// TimestampClient requests RFC 3161 tokens from a TSA over HTTP.
+type TimestampClient struct {
+	url    string
+	client *http.Client
+}
+
+// NewTimestampClient targets the TSA at url (for example
+// https://freetsa.org/tsr).
+func NewTimestampClient(url string) *TimestampClient {
+	return &TimestampClient{url: url, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// WithHTTPClient swaps the HTTP client (tests, proxies).
+func (c *TimestampClient) WithHTTPClient(h *http.Client) *TimestampClient {
+	c.client = h
+	return c
+}
+
+// URL is the TSA endpoint, recorded next to each token.
+func (c *TimestampClient) URL() string { return c.url }
+
+// Timestamp asks the TSA to timestamp a SHA-256 digest. It returns the DER
+// token and the time the TSA put in it. The token is checked before it is
+// returned — nonce, digest and signature — so a misbehaving TSA is caught
+// when the token is issued, not when an auditor first opens it.
+func (c *TimestampClient) Timestamp(ctx context.Context, digest []byte) ([]byte, time.Time, error) {
+	if len(digest) != gocrypto.SHA256.Size() {
+		return nil, time.Time{}, errors.New("timestamp: digest must be SHA-256")
+	}
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	var b cryptobyte.Builder
+	b.AddASN1(asn1.SEQUENCE, func(req *cryptobyte.Builder) {
+		req.AddASN1Int64(1)
+		addMessageImprint(req, digest)
+		req.AddASN1BigInt(nonce)
+		req.AddASN1Boolean(true) // certReq: the token must carry the TSA certificate
+	})
+	body, err := b.Bytes()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/timestamp-query")
+	req.Header.Set("Accept", "application/timestamp-reply")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("timestamp: %w", err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("timestamp: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, time.Time{}, fmt.Errorf("timestamp: TSA answered HTTP %d", resp.StatusCode)
+	}
+	token, err := parseTimestampResponse(raw)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	info, err := VerifyTimestamp(token, digest, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if info.Nonce == nil || info.Nonce.Cmp(nonce) != 0 {
+		return nil, time.Time{}, errors.New("timestamp: TSA answered with another request's nonce")
+	}
+	return token, info.GenTime, nil
+}
+
+func addMessageImprint(b *cryptobyte.Builder, digest []byte) {
+	b.AddASN1(asn1.SEQUENCE, func(mi *cryptobyte.Builder) {
+		mi.AddASN1(asn1.SEQUENCE, func(alg *cryptobyte.Builder) {
+			alg.AddASN1ObjectIdentifier(oidSHA256)
+			alg.AddASN1NULL()
+		})
+		mi.AddASN1OctetString(digest)
+	})
+}
+
+// parseTimestampResponse unwraps TimeStampResp and returns the token when the
+// TSA granted the request.
+func parseTimestampResponse(raw []byte) ([]byte, error) {
+	in := cryptobyte.String(raw)
+	var resp, status cryptobyte.String
+	var code int64
+	if !in.ReadASN1(&resp, asn1.SEQUENCE) || !resp.ReadASN1(&status, asn1.SEQUENCE) || !status.ReadASN1Integer(&code) {
+		return nil, errors.New("timestamp: malformed TSA response")
+	}
+	// 0 granted, 1 granted with modifications; anything else is a refusal.
+	if code != 0 && code != 1 {
+		msg := ""
+		var text cryptobyte.String
+		if status.ReadOptionalASN1(&text, nil, asn1.SEQUENCE) {
+			var s cryptobyte.String
+			if text.ReadASN1(&s, asn1.UTF8String) {
+				msg = ": " + string(s)
+			}
+		}
+		return nil, fmt.Errorf("timestamp: TSA refused the request (status %d%s)", code, msg)
+	}
+	var token cryptobyte.String
+	if !resp.ReadASN1Element(&token, asn1.SEQUENCE) {
+		return nil, errors.New("timestamp: TSA response carries no token")
+	}
+	return []byte(token), nil
+}
+
+// TimestampInfo is what a verified token says.
+type TimestampInfo struct {
+	GenTime     time.Time
+	Policy      encasn1.ObjectIdentifier
+	Serial      *big.Int
+	Nonce       *big.Int
+	Certificate *x509.Certificate
+	// ChainVerified is true when the TSA certificate was checked against the
+	// roots handed to VerifyTimestamp; without roots only the signature is.
+	ChainVerified bool
+}
+
+// VerifyTimestamp checks a token against the SHA-256 digest it must cover.
+// The signature is always checked with the certificate the token carries.
+// With roots, that certificate must also chain to one of them and be valid
+// for timestamping at the token's time; with nil roots the caller decides
+// whether to trust it (ChainVerified stays false).
+func VerifyTimestamp(token, digest []byte, roots *x509.CertPool) (*TimestampInfo, error) {
+	sd, err := parseSignedData(token)
+	if err != nil {
+		return nil, err
+	}
+	info, err := parseTSTInfo(sd.content)
+	if err != nil {
+		return nil, err
+	}
+	if !info.imprintAlg.Equal(oidSHA256) || !bytes.Equal(info.imprint, digest) {
+		return nil, fmt.Errorf("%w: it covers another digest", ErrBadTimestamp)
+	}
+
+	signer := sd.signerCertificate()
+	if signer == nil {
+		return nil, fmt.Errorf("%w: the TSA certificate is not in the token", ErrBadTimestamp)
+	}
+	if err := sd.checkSignedAttributes(); err != nil {
+		return nil, err
+	}
+	alg, ok := signatureAlgorithm(sd.digestAlg, sd.sigAlg)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported signature algorithm %s", ErrBadTimestamp, sd.sigAlg)
+	}
+	if err := signer.CheckSignature(alg, sd.signedAttrs, sd.signature); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadTimestamp, err)
+	}
+
+	out := &TimestampInfo{GenTime: info.genTime, Policy: info.policy, Serial: info.serial, Nonce: info.nonce, Certificate: signer}
+	if roots != nil {
+		intermediates := x509.NewCertPool()
+		for _, c := range sd.certs {
+			intermediates.AddCert(c)
+		}
+		_, err := signer.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			CurrentTime:   info.genTime,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: TSA certificate: %v", ErrBadTimestamp, err)
+		}
+		out.ChainVerified = true
+	}
+	return out, nil
+}
+
+// signedData is the part of a CMS SignedData a timestamp check needs.
+type signedData struct {
+	content     []byte // the encapsulated TSTInfo
+	certs       []*x509.Certificate
+	sid         []byte // SignerIdentifier element
+	digestAlg   encasn1.ObjectIdentifier
+	signedAttrs []byte // re-tagged as SET OF, the bytes the signature covers
+	attrs       cryptobyte.String
+	sigAlg      encasn1.ObjectIdentifier
+	signature   []byte
+}
+
+func malformed(what string) error { return fmt.Errorf("%w: malformed %s", ErrBadTimestamp, what) }
+
+func parseSignedData(token []byte) (*signedData, error) {
+	in := cryptobyte.String(token)
+	var ci, body cryptobyte.String
+	var ct encasn1.ObjectIdentifier
+	if !in.ReadASN1(&ci, asn1.SEQUENCE) || !ci.ReadASN1ObjectIdentifier(&ct) || !ct.Equal(oidSignedData) ||
+		!ci.ReadASN1(&body, asn1.Tag(0).Constructed().ContextSpecific()) {
+		return nil, malformed("ContentInfo")
+	}
+	var sd, digestAlgs, encap, eContent, certs, signerInfos cryptobyte.String
+	var version int64
+	var eType encasn1.ObjectIdentifier
+	// Version 3: the content type is not id-data (RFC 5652 §5.1).
+	if !body.ReadASN1(&sd, asn1.SEQUENCE) || !sd.ReadASN1Integer(&version) || version != 3 ||
+		!sd.ReadASN1(&digestAlgs, asn1.SET) || !sd.ReadASN1(&encap, asn1.SEQUENCE) ||
+		!encap.ReadASN1ObjectIdentifier(&eType) || !eType.Equal(oidTSTInfo) ||
+		!encap.ReadASN1(&eContent, asn1.Tag(0).Constructed().ContextSpecific()) {
+		return nil, malformed("SignedData")
+	}
+	var content cryptobyte.String
+	if !eContent.ReadASN1(&content, asn1.OCTET_STRING) {
+		return nil, malformed("TSTInfo content")
+	}
+	out := &signedData{content: content}
+	var hasCerts bool
+	if !sd.ReadOptionalASN1(&certs, &hasCerts, asn1.Tag(0).Constructed().ContextSpecific()) {
+		return nil, malformed("certificates")
+	}
+	if hasCerts {
+		parsed, err := x509.ParseCertificates(certs)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrBadTimestamp, err)
+		}
+		out.certs = parsed
+	}
+	if !sd.SkipOptionalASN1(asn1.Tag(1).Constructed().ContextSpecific()) || !sd.ReadASN1(&signerInfos, asn1.SET) {
+		return nil, malformed("SignerInfos")
+	}
+
+	var si, sid cryptobyte.String
+	var siVersion int64
+	if !signerInfos.ReadASN1(&si, asn1.SEQUENCE) || !signerInfos.Empty() {
+		return nil, fmt.Errorf("%w: exactly one signer expected", ErrBadTimestamp)
+	}
+	var sidTag asn1.Tag
+	if !si.ReadASN1Integer(&siVersion) || !si.ReadAnyASN1Element(&sid, &sidTag) || !readAlgorithm(&si, &out.digestAlg) {
+		return nil, malformed("SignerInfo")
+	}
+	// Version 1 names the signer by issuer and serial, version 3 by key id.
+	if (sidTag == asn1.SEQUENCE) != (siVersion == 1) || (sidTag == asn1.Tag(0).ContextSpecific()) != (siVersion == 3) {
+		return nil, malformed("SignerInfo version")
+	}
+	out.sid = sid
+	if !listsAlgorithm(digestAlgs, out.digestAlg) {
+		return nil, fmt.Errorf("%w: digest algorithms do not list the signer's", ErrBadTimestamp)
+	}
+	var attrs cryptobyte.String
+	if !si.ReadASN1Element(&attrs, asn1.Tag(0).Constructed().ContextSpecific()) {
+		return nil, fmt.Errorf("%w: signed attributes are required", ErrBadTimestamp)
+	}
+	// The signature covers the attributes encoded as a SET OF, not with the
+	// [0] IMPLICIT tag they carry inside SignerInfo.
+	out.signedAttrs = append([]byte{0x31}, attrs[1:]...)
+	var attrBody cryptobyte.String
+	if !attrs.ReadASN1(&attrBody, asn1.Tag(0).Constructed().ContextSpecific()) {
+		return nil, malformed("signed attributes")
+	}
+	out.attrs = attrBody
+	if !readAlgorithm(&si, &out.sigAlg) {
+		return nil, malformed("signature algorithm")
+	}
+	var sig cryptobyte.String
+	if !si.ReadASN1(&sig, asn1.OCTET_STRING) {
+		return nil, malformed("signature")
+	}
+	out.signature = sig
+	return out, nil
+}
+
+// listsAlgorithm reports whether a well-formed SET OF AlgorithmIdentifier
+// contains oid.
+func listsAlgorithm(set cryptobyte.String, oid encasn1.ObjectIdentifier) bool {
+	found := false
+	for !set.Empty() {
+		var got encasn1.ObjectIdentifier
+		if !readAlgorithm(&set, &got) {
+			return false
+		}
+		found = found || got.Equal(oid)
+	}
+	return found
+}
+
+// readAlgorithm reads an AlgorithmIdentifier. Parameters must be absent or
+// NULL, except RSASSA-PSS's, which are a SEQUENCE.
+func readAlgorithm(s *cryptobyte.String, oid *encasn1.ObjectIdentifier) bool {
+	var alg cryptobyte.String
+	if !s.ReadASN1(&alg, asn1.SEQUENCE) || !alg.ReadASN1ObjectIdentifier(oid) {
+		return false
+	}
+	switch {
+	case alg.Empty():
+		return true
+	case oid.Equal(oidRSAPSS):
+		var params cryptobyte.String
+		return alg.ReadASN1(&params, asn1.SEQUENCE) && alg.Empty()
+	default:
+		var null cryptobyte.String
+		return alg.ReadASN1(&null, asn1.NULL) && null.Empty() && alg.Empty()
+	}
+}
+
+// signerCertificate finds the certificate the SignerIdentifier names, either
+// by issuer and serial number or by subject key identifier.
+func (sd *signedData) signerCertificate() *x509.Certificate {
+	in := cryptobyte.String(sd.sid)
+	var ias cryptobyte.String
+	if in.ReadASN1(&ias, asn1.SEQUENCE) {
+		var issuer cryptobyte.String
+		serial := new(big.Int)
+		if !ias.ReadASN1Element(&issuer, asn1.SEQUENCE) || !ias.ReadASN1Integer(serial) {
+			return nil
+		}
+		for _, c := range sd.certs {
+			if bytes.Equal(c.RawIssuer, issuer) && c.SerialNumber.Cmp(serial) == 0 {
+				return c
+			}
+		}
+		return nil
+	}
+	var ski cryptobyte.String
+	if in.ReadASN1(&ski, asn1.Tag(0).ContextSpecific()) {
+		for _, c := range sd.certs {
+			if bytes.Equal(c.SubjectKeyId, ski) {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// checkSignedAttributes requires the content-type attribute to name TSTInfo
+// and the message-digest attribute to match the encapsulated content.
+func (sd *signedData) checkSignedAttributes() error {
+	h, ok := hashFor(sd.digestAlg)
+	if !ok {
+		return fmt.Errorf("%w: unsupported digest algorithm %s", ErrBadTimestamp, sd.digestAlg)
+	}
+	var sawType, sawDigest bool
+	attrs := sd.attrs
+	for !attrs.Empty() {
+		var attr, values cryptobyte.String
+		var oid encasn1.ObjectIdentifier
+		if !attrs.ReadASN1(&attr, asn1.SEQUENCE) || !attr.ReadASN1ObjectIdentifier(&oid) || !attr.ReadASN1(&values, asn1.SET) {
+			return malformed("signed attribute")
+		}
+		switch {
+		case oid.Equal(oidAttrContentType):
+			var ct encasn1.ObjectIdentifier
+			if !values.ReadASN1ObjectIdentifier(&ct) || !ct.Equal(oidTSTInfo) {
+				return fmt.Errorf("%w: content type attribute is not TSTInfo", ErrBadTimestamp)
+			}
+			sawType = true
+		case oid.Equal(oidAttrMessageDigst):
+			var d cryptobyte.String
+			if !values.ReadASN1(&d, asn1.OCTET_STRING) {
+				return malformed("message digest attribute")
+			}
+			sum := h.New()
+			sum.Write(sd.content)
+			if !bytes.Equal(sum.Sum(nil), d) {
+				return fmt.Errorf("%w: the TSTInfo was altered", ErrBadTimestamp)
+			}
+			sawDigest = true
+		}
+	}
+	if !sawType || !sawDigest {
+		return fmt.Errorf("%w: content type and message digest attributes are required", ErrBadTimestamp)
+	}
+	return nil
+}
+
+type tstInfo struct {
+	policy     encasn1.ObjectIdentifier
+	imprintAlg encasn1.ObjectIdentifier
+	imprint    []byte
+	serial     *big.Int
+	genTime    time.Time
+	nonce      *big.Int
+}
+
+func parseTSTInfo(der []byte) (*tstInfo, error) {
+	in := cryptobyte.String(der)
+	var tst, mi, alg, imprint, gen cryptobyte.String
+	var version int64
+	out := &tstInfo{serial: new(big.Int)}
+	if !in.ReadASN1(&tst, asn1.SEQUENCE) || !tst.ReadASN1Integer(&version) || version != 1 ||
+		!tst.ReadASN1ObjectIdentifier(&out.policy) ||
+		!tst.ReadASN1(&mi, asn1.SEQUENCE) || !mi.ReadASN1(&alg, asn1.SEQUENCE) ||
+		!alg.ReadASN1ObjectIdentifier(&out.imprintAlg) || !mi.ReadASN1(&imprint, asn1.OCTET_STRING) ||
+		!tst.ReadASN1Integer(out.serial) || !tst.ReadASN1(&gen, asn1.GeneralizedTime) {
+		return nil, malformed("TSTInfo")
+	}
+	out.imprint = imprint
+	// TSAs commonly add fractional seconds, which time.Parse accepts after the
+	// seconds field without the layout naming them.
+	t, err := time.Parse("20060102150405Z0700", string(gen))
+	if err != nil {
+		return nil, malformed("genTime")
+	}
+	out.genTime = t.UTC()
+	if !tst.SkipOptionalASN1(asn1.SEQUENCE) || !tst.SkipOptionalASN1(asn1.BOOLEAN) {
+		return nil, malformed("TSTInfo")
+	}
+	if tst.PeekASN1Tag(asn1.INTEGER) {
+		out.nonce = new(big.Int)
+		if !tst.ReadASN1Integer(out.nonce) {
+			return nil, malformed("nonce")
+		}
+	}
+	return out, nil
+}
+
+func hashFor(oid encasn1.ObjectIdentifier) (gocrypto.Hash, bool) {
+	switch {
+	case oid.Equal(oidSHA256):
+		return gocrypto.SHA256, true
+	case oid.Equal(oidSHA384):
+		return gocrypto.SHA384, true
+	case oid.Equal(oidSHA512):
+		return gocrypto.SHA512, true
+	}
+	return 0, false
+}
+
+// signatureAlgorithm maps a SignerInfo's digest and signature algorithms to
+// the x509 constant. Some TSAs name only the key type (rsaEncryption,
+// id-ecPublicKey) and leave the digest to the digest algorithm field.
+func signatureAlgorithm(digest, sig encasn1.ObjectIdentifier) (x509.SignatureAlgorithm, bool) {
+	h, ok := hashFor(digest)
+	if !ok {
+		return 0, false
+	}
+	byHash := func(a256, a384, a512 x509.SignatureAlgorithm) (x509.SignatureAlgorithm, bool) {
+		switch h {
+		case gocrypto.SHA256:
+			return a256, true
+		case gocrypto.SHA384:
+			return a384, true
+		default:
+			return a512, true
+		}
+	}
+	switch {
+	case sig.Equal(oidRSAEncryption):
+		return byHash(x509.SHA256WithRSA, x509.SHA384WithRSA, x509.SHA512WithRSA)
+	case sig.Equal(oidRSAPSS):
+		return byHash(x509.SHA256WithRSAPSS, x509.SHA384WithRSAPSS, x509.SHA512WithRSAPSS)
+	case sig.Equal(oidECPublicKey):
+		return byHash(x509.ECDSAWithSHA256, x509.ECDSAWithSHA384, x509.ECDSAWithSHA512)
+	case sig.Equal(oidSHA256WithRSA):
+		return x509.SHA256WithRSA, true
+	case sig.Equal(oidSHA384WithRSA):
+		return x509.SHA384WithRSA, true
+	case sig.Equal(oidSHA512WithRSA):
+		return x509.SHA512WithRSA, true
+	case sig.Equal(oidECDSAWithSHA256):
+		return x509.ECDSAWithSHA256, true
+	case sig.Equal(oidECDSAWithSHA384):
+		return x509.ECDSAWithSHA384, true
+	case sig.Equal(oidECDSAWithSHA512):
+		return x509.ECDSAWithSHA512, true
+	case sig.Equal(oidEd25519):
+		return x509.PureEd25519, true
+	}
+	return 0, false
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package crypto_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendefender/openrisk/pkg/crypto"
+	"github.com/opendefender/openrisk/pkg/crypto/tsatest"
+)
+
+func TestTimestamp_IssuedTokenVerifiesOffline(t *testing.T) {
+	tsa := tsatest.NewServer()
+	defer tsa.Close()
+	at := time.Date(2026, 3, 2, 9, 0, 0, 500_000_000, time.UTC)
+	tsa.SetClock(func() time.Time { return at })
+
+	digest := sha256.Sum256([]byte("head"))
+	token, genTime, err := crypto.NewTimestampClient(tsa.URL).Timestamp(context.Background(), digest[:])
+	require.NoError(t, err)
+	assert.Equal(t, at.Truncate(time.Millisecond), genTime, "fractional seconds survive")
+
+	info, err := crypto.VerifyTimestamp(token, digest[:], tsa.Roots)
+	require.NoError(t, err)
+	assert.True(t, info.ChainVerified)
+	assert.Equal(t, "tsatest TSA", info.Certificate.Subject.CommonName)
+
+	other := sha256.Sum256([]byte("another head"))
+	_, err = crypto.VerifyTimestamp(token, other[:], tsa.Roots)
+	assert.True(t, errors.Is(err, crypto.ErrBadTimestamp), "the token covers one digest only")
+
+	stranger := tsatest.NewServer()
+	defer stranger.Close()
+	_, err = crypto.VerifyTimestamp(token, digest[:], stranger.Roots)
+	assert.Error(t, err, "another TSA's root does not vouch for it")
+	_, err = crypto.VerifyTimestamp(token, digest[:], x509.NewCertPool())
+	assert.Error(t, err)
+
+	info, err = crypto.VerifyTimestamp(token, digest[:], nil)
+	require.NoError(t, err, "without roots the signature alone is checked")
+	assert.False(t, info.ChainVerified)
+}
+
+func TestTimestamp_TamperedTokenFails(t *testing.T) {
+	tsa := tsatest.NewServer()
+	defer tsa.Close()
+	digest := sha256.Sum256([]byte("head"))
+	token, _, err := crypto.NewTimestampClient(tsa.URL).Timestamp(context.Background(), digest[:])
+	require.NoError(t, err)
+
+	// Flip one bit in each byte of the token: no variant may verify.
+	for i := range token {
+		bad := append([]byte(nil), token...)
+		bad[i] ^= 0x01
+		_, err := crypto.VerifyTimestamp(bad, digest[:], tsa.Roots)
+		assert.Error(t, err, "byte %d", i)
+	}
+}
+
+func TestTimestamp_RefusalIsAnError(t *testing.T) {
+	tsa := tsatest.NewServer()
+	defer tsa.Close()
+	tsa.Refuse(true)
+	digest := sha256.Sum256([]byte("head"))
+	_, _, err := crypto.NewTimestampClient(tsa.URL).Timestamp(context.Background(), digest[:])
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "refused by test")
+
+	_, _, err = crypto.NewTimestampClient(tsa.URL).Timestamp(context.Background(), []byte("short"))
+	assert.Error(t, err, "only SHA-256 digests")
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package tsatest runs an RFC 3161 timestamp authority in-process, the way
+// net/http/httptest runs a server, so timestamping can be tested end to end
+// without a network. Its root certificate is generated per server: a token is
+// only trusted by a verifier handed that server's Roots.
+package tsatest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	encasn1 "encoding/asn1"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+)
+
+var (
+	oidSHA256          = encasn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSignedData      = encasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidTSTInfo         = encasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 1, 4}
+	oidContentType     = encasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidMessageDigest   = encasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidECDSAWithSHA256 = encasn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+	oidPolicy          = encasn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}
+)
+
+// Server is a running test TSA.
+type Server struct {
+	*httptest.Server
+	// Roots trusts this TSA's root certificate; RootPEM is the same, encoded.
+	Roots   *x509.CertPool
+	RootPEM []byte
+
+	leaf   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	serial atomic.Int64
+
+	mu     sync.Mutex
+	now    func() time.Time
+	refuse bool
+}
+
+// NewServer starts a TSA with a fresh root and signing certificate.
+func NewServer() *Server {
+	rootKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rootTmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "tsatest root"},
+		NotBefore: time.Now().AddDate(-10, 0, 0), NotAfter: time.Now().AddDate(10, 0, 0),
+		IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign,
+	}
+	rootDER, _ := x509.CreateCertificate(rand.Reader, rootTmpl, rootTmpl, &rootKey.PublicKey, rootKey)
+	root, _ := x509.ParseCertificate(rootDER)
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	leafTmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "tsatest TSA"},
+		NotBefore: time.Now().AddDate(-10, 0, 0), NotAfter: time.Now().AddDate(10, 0, 0),
+		KeyUsage: x509.KeyUsageDigitalSignature, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	}
+	leafDER, _ := x509.CreateCertificate(rand.Reader, leafTmpl, root, &key.PublicKey, rootKey)
+	leaf, _ := x509.ParseCertificate(leafDER)
+
+	s := &Server{
+		Roots:   x509.NewCertPool(),
+		RootPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rootDER}),
+		leaf:    leaf,
+		key:     key,
+		now:     time.Now,
+	}
+	s.Roots.AddCert(root)
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// SetClock makes the TSA stamp tokens with now instead of the wall clock.
+func (s *Server) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// Refuse makes the TSA answer every request with status "rejection".
+func (s *Server) Refuse(refuse bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refuse = refuse
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	raw, _ := io.ReadAll(r.Body)
+	digest, nonce, ok := parseRequest(raw)
+	if !ok {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	now, refuse := s.now().UTC(), s.refuse
+	s.mu.Unlock()
+
+	var b cryptobyte.Builder
+	b.AddASN1(asn1.SEQUENCE, func(resp *cryptobyte.Builder) {
+		resp.AddASN1(asn1.SEQUENCE, func(status *cryptobyte.Builder) {
+			if refuse {
+				status.AddASN1Int64(2)
+				status.AddASN1(asn1.SEQUENCE, func(text *cryptobyte.Builder) {
+					text.AddASN1(asn1.UTF8String, func(s *cryptobyte.Builder) { s.AddBytes([]byte("refused by test")) })
+				})
+				return
+			}
+			status.AddASN1Int64(0)
+		})
+		if !refuse {
+			resp.AddBytes(s.token(digest, nonce, now))
+		}
+	})
+	out, err := b.Bytes()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/timestamp-reply")
+	_, _ = w.Write(out)
+}
+
+func parseRequest(raw []byte) (digest []byte, nonce *big.Int, ok bool) {
+	in := cryptobyte.String(raw)
+	var req, mi, alg, d cryptobyte.String
+	var version int64
+	if !in.ReadASN1(&req, asn1.SEQUENCE) || !req.ReadASN1Integer(&version) ||
+		!req.ReadASN1(&mi, asn1.SEQUENCE) || !mi.ReadASN1(&alg, asn1.SEQUENCE) || !mi.ReadASN1(&d, asn1.OCTET_STRING) {
+		return nil, nil, false
+	}
+	if req.PeekASN1Tag(asn1.OBJECT_IDENTIFIER) && !req.SkipASN1(asn1.OBJECT_IDENTIFIER) {
+		return nil, nil, false
+	}
+	if req.PeekASN1Tag(asn1.INTEGER) {
+		nonce = new(big.Int)
+		if !req.ReadASN1Integer(nonce) {
+			return nil, nil, false
+		}
+	}
+	return d, nonce, true
+}
+
+// token builds the CMS SignedData over a TSTInfo for digest.
+func (s *Server) token(digest []byte, nonce *big.Int, now time.Time) []byte {
+	var tst cryptobyte.Builder
+	tst.AddASN1(asn1.SEQUENCE, func(t *cryptobyte.Builder) {
+		t.AddASN1Int64(1)
+		t.AddASN1ObjectIdentifier(oidPolicy)
+		t.AddASN1(asn1.SEQUENCE, func(mi *cryptobyte.Builder) {
+			mi.AddASN1(asn1.SEQUENCE, func(alg *cryptobyte.Builder) {
+				alg.AddASN1ObjectIdentifier(oidSHA256)
+				alg.AddASN1NULL()
+			})
+			mi.AddASN1OctetString(digest)
+		})
+		t.AddASN1Int64(s.serial.Add(1))
+		t.AddASN1(asn1.GeneralizedTime, func(g *cryptobyte.Builder) {
+			g.AddBytes([]byte(now.Format("20060102150405.000Z")))
+		})
+		if nonce != nil {
+			t.AddASN1BigInt(nonce)
+		}
+	})
+	content := tst.BytesOrPanic()
+	sum := sha256.Sum256(content)
+
+	attrs := func(b *cryptobyte.Builder) {
+		b.AddASN1(asn1.SEQUENCE, func(a *cryptobyte.Builder) {
+			a.AddASN1ObjectIdentifier(oidContentType)
+			a.AddASN1(asn1.SET, func(v *cryptobyte.Builder) { v.AddASN1ObjectIdentifier(oidTSTInfo) })
+		})
+		b.AddASN1(asn1.SEQUENCE, func(a *cryptobyte.Builder) {
+			a.AddASN1ObjectIdentifier(oidMessageDigest)
+			a.AddASN1(asn1.SET, func(v *cryptobyte.Builder) { v.AddASN1OctetString(sum[:]) })
+		})
+	}
+	var set cryptobyte.Builder
+	set.AddASN1(asn1.SET, attrs)
+	attrSum := sha256.Sum256(set.BytesOrPanic())
+	sig, _ := ecdsa.SignASN1(rand.Reader, s.key, attrSum[:])
+
+	sha256Alg := func(b *cryptobyte.Builder) {
+		b.AddASN1(asn1.SEQUENCE, func(alg *cryptobyte.Builder) {
+			alg.AddASN1ObjectIdentifier(oidSHA256)
+			alg.AddASN1NULL()
+		})
+	}
+	var ci cryptobyte.Builder
+	ci.AddASN1(asn1.SEQUENCE, func(c *cryptobyte.Builder) {
+		c.AddASN1ObjectIdentifier(oidSignedData)
+		c.AddASN1(asn1.Tag(0).Constructed().ContextSpecific(), func(e *cryptobyte.Builder) {
+			e.AddASN1(asn1.SEQUENCE, func(sd *cryptobyte.Builder) {
+				sd.AddASN1Int64(3)
+				sd.AddASN1(asn1.SET, sha256Alg)
+				sd.AddASN1(asn1.SEQUENCE, func(encap *cryptobyte.Builder) {
+					encap.AddASN1ObjectIdentifier(oidTSTInfo)
+					encap.AddASN1(asn1.Tag(0).Constructed().ContextSpecific(), func(ec *cryptobyte.Builder) {
+						ec.AddASN1OctetString(content)
+					})
+				})
+				sd.AddASN1(asn1.Tag(0).Constructed().ContextSpecific(), func(certs *cryptobyte.Builder) {
+					certs.AddBytes(s.leaf.Raw)
+				})
+				sd.AddASN1(asn1.SET, func(infos *cryptobyte.Builder) {
+					infos.AddASN1(asn1.SEQUENCE, func(si *cryptobyte.Builder) {
+						si.AddASN1Int64(1)
+						si.AddASN1(asn1.SEQUENCE, func(ias *cryptobyte.Builder) {
+							ias.AddBytes(s.leaf.RawIssuer)
+							ias.AddASN1BigInt(s.leaf.SerialNumber)
+						})
+						sha256Alg(si)
+						si.AddASN1(asn1.Tag(0).Constructed().ContextSpecific(), attrs)
+						si.AddASN1(asn1.SEQUENCE, func(alg *cryptobyte.Builder) {
+							alg.AddASN1ObjectIdentifier(oidECDSAWithSHA256)
+						})
+						si.AddASN1OctetString(sig)
+					})
+				})
+			})
+		})
+	})
+	return ci.BytesOrPanic()
+}
@@ -29,6 +29,12 @@ const (
 	// Payload: VulnerabilityDetectedEvent
 	// Consumer: AutomationEngine (spec §10) — déclencheur `vulnerability_detected`.
 	VulnerabilityDetected = "vulnerability.detected"
+
+	// Publié par le service KRI quand un indicateur change de bande
+	// (vert/ambre/rouge) sur une nouvelle mesure.
+	// Payload: KRIThresholdCrossedEvent
+	// Consumer: AutomationEngine — déclencheur `kri_threshold_crossed`.
+	KRIThresholdCrossed = "kri.threshold_crossed"
 )
 
 // VulnerabilityDetectedEvent est le payload publié sur vulnerability.detected.
@@ -50,6 +56,22 @@ type VulnerabilityDetectedEvent struct {
 	TriggeredBy     string  `json:"triggered_by"` // user_id ou "system"
 }
 
+// KRIThresholdCrossedEvent est le payload publié sur kri.threshold_crossed.
+// From/To sont des bandes (unknown|green|amber|red) ; Worsened distingue une
+// dégradation d'une amélioration pour que les règles puissent filtrer dessus.
+type KRIThresholdCrossedEvent struct {
+	KRIID      string   `json:"kri_id"`
+	TenantID   string   `json:"tenant_id"`
+	Name       string   `json:"name"`
+	Value      float64  `json:"value"`
+	Unit       string   `json:"unit"`
+	From       string   `json:"from"`
+	To         string   `json:"to"`
+	Worsened   bool     `json:"worsened"`
+	RiskIDs    []string `json:"risk_ids"`
+	MeasuredAt string   `json:"measured_at"` // RFC3339
+}
+
 // RiskUpdatedEvent est le payload publié sur risk.updated.
 // Format: JSON serializable
 type RiskUpdatedEvent struct {
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+// Package netguard keeps outbound connections to tenant-chosen addresses —
+// webhook URLs, SIEM collectors, plugin hosts, ticketing and AI endpoints —
+// off the network the server runs on. Without it, anyone allowed to type a URL
+// could make the server reach its database, its cloud metadata service or any
+// other internal host on their behalf.
+//
+// The check belongs on the dialer (Control), where it sees the address after
+// name resolution: a public hostname pointed at an internal address is refused
+// too, and so is one that changes between the save and the dial. Checking the
+// URL when it is saved (InternalHost) only gives the person typing it an
+// early, readable error.
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+)
+
+// ErrInternalAddress is returned, wrapped, for a refused address.
+var ErrInternalAddress = errors.New("internal address")
+
+// blocked are the ranges net.IP's predicates do not cover: "this network",
+// carrier-grade NAT (often the cloud's internal fabric), benchmarking and the
+// reserved class E space with the broadcast address.
+var blocked = mustCIDRs("0.0.0.0/8", "100.64.0.0/10", "198.18.0.0/15", "240.0.0.0/4")
+
+// nat64 embeds an IPv4 address in its last four bytes; the embedded address
+// decides.
+var nat64 = mustCIDRs("64:ff9b::/96")
+
+// Internal reports whether ip must not be reached: loopback, private (RFC 1918
+// and IPv6 unique local), link-local, unspecified, multicast, the ranges above,
+// and any of these wrapped in an IPv4-mapped or NAT64 IPv6 address.
+func Internal(ip net.IP) bool {
+	if ip == nil {
+		return true
+	}
+	if len(ip) == net.IPv6len && nat64[0].Contains(ip) {
+		return Internal(net.IP(ip[12:16]))
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast() {
+		return true
+	}
+	for _, n := range blocked {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// InternalHost reports whether a URL host is known to be internal before any
+// lookup: localhost, or a literal internal address. Hostnames are decided on
+// every dial by Control.
+func InternalHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(strings.Trim(host, "[]")), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return Internal(ip)
+	}
+	return false
+}
+
+// Control is a net.Dialer Control function refusing internal addresses.
+// allowPrivate turns it off, for self-hosted deployments whose tools live on
+// the internal network; it is an operator setting, never a tenant one. purpose
+// names the feature in the error ("webhooks", "SIEM export").
+func Control(allowPrivate bool, purpose string) func(network, address string, c syscall.RawConn) error {
+	return func(_, address string, _ syscall.RawConn) error {
+		if allowPrivate {
+			return nil
+		}
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+		if Internal(net.ParseIP(host)) {
+			return fmt.Errorf("%w: %s is not reachable from %s", ErrInternalAddress, host, purpose)
+		}
+		return nil
+	}
+}
+
+func mustCIDRs(cidrs ...string) []*net.IPNet {
+	out := make([]*net.IPNet, len(cidrs))
+	for i, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		out[i] = n
+	}
+	return out
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package netguard
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInternal(t *testing.T) {
+	for _, addr := range []string{
+		"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0",
+		"100.64.0.1", "198.18.0.1", "255.255.255.255", "224.0.0.1",
+		"::1", "::", "fd00:ec2::254", "fe80::1", "ff02::1",
+		"::ffff:10.0.0.1", "::ffff:127.0.0.1", "64:ff9b::a9fe:a9fe",
+	} {
+		assert.True(t, Internal(net.ParseIP(addr)), addr)
+	}
+	for _, addr := range []string{"93.184.216.34", "1.1.1.1", "2606:4700:4700::1111", "64:ff9b::5db8:d822"} {
+		assert.False(t, Internal(net.ParseIP(addr)), addr)
+	}
+	assert.True(t, Internal(nil), "an unparsable address is refused")
+}
+
+func TestInternalHost(t *testing.T) {
+	for _, h := range []string{"localhost", "LOCALHOST.", "api.localhost", "10.0.0.4", "[::1]"} {
+		assert.True(t, InternalHost(h), h)
+	}
+	for _, h := range []string{"hooks.example.com", "93.184.216.34", "localhost.example.com"} {
+		assert.False(t, InternalHost(h), h)
+	}
+}
+
+func TestControl(t *testing.T) {
+	deny := Control(false, "webhooks")
+	err := deny("tcp", "10.0.0.4:443", nil)
+	assert.True(t, errors.Is(err, ErrInternalAddress))
+	assert.Contains(t, err.Error(), "not reachable from webhooks")
+	assert.NoError(t, deny("tcp", "93.184.216.34:443", nil))
+	assert.NoError(t, Control(true, "webhooks")("tcp", "10.0.0.4:443", nil), "operators may allow private networks")
+}
@@ -49,6 +49,29 @@ type boardLabels struct {
 	recoTitle      string
 	frameworksHdr  string
 	noFrameworks   string
+	appetiteTitle  string
+	appetiteLine   string // statements in force, risks above, without exception
+	appetiteWithin string
+	appetiteRisks  string
+	metricALE      string
+	metricCritical string
+	excNone        string
+	excPending     string
+	excApproved    string
+	excExpired     string
+	kriTitle       string
+	kriLine        string // red, amber, green
+	kriNoValue     string
+	kriRed         string
+	kriAmber       string
+	kriGreen       string
+	changesTitle   string
+	changesLine    string // since, new, closed, up, down
+	changesNone    string
+	changesNew     string
+	changesClosed  string
+	changesUp      string
+	changesDown    string
 	draftBanner    string
 	confidential   string
 	page           string
@@ -83,6 +106,29 @@ func boardLabelsFor(l Locale) boardLabels {
 			recoTitle:      "Recommendations",
 			frameworksHdr:  "Compliance by framework",
 			noFrameworks:   "No compliance framework is tracked yet.",
+			appetiteTitle:  "Risk appetite",
+			appetiteLine:   "%d statement(s) in force · %d risk(s) above appetite · %d without an approved exception",
+			appetiteWithin: "The register is within the appetite approved by the board.",
+			appetiteRisks:  "Risks above appetite",
+			metricALE:      "Annual loss (P90)",
+			metricCritical: "Critical risks",
+			excNone:        "No exception",
+			excPending:     "Exception pending",
+			excApproved:    "Exception until %s",
+			excExpired:     "Exception expired",
+			kriTitle:       "Key risk indicators",
+			kriLine:        "%d red · %d amber · %d green — last 90 days",
+			kriNoValue:     "no value yet",
+			kriRed:         "Red",
+			kriAmber:       "Amber",
+			kriGreen:       "Green",
+			changesTitle:   "Register changes",
+			changesLine:    "Since %s: %d new · %d closed · %d up-scored · %d down-scored",
+			changesNone:    "No risk has moved since %s.",
+			changesNew:     "New risks",
+			changesClosed:  "Closed risks",
+			changesUp:      "Up-scored",
+			changesDown:    "Down-scored",
 			draftBanner:    "DRAFT — for internal review, not for distribution",
 			confidential:   "Confidential - generated by OpenRisk",
 			page:           "Page",
@@ -115,6 +161,29 @@ func boardLabelsFor(l Locale) boardLabels {
 		recoTitle:      "Recommandations",
 		frameworksHdr:  "Conformité par référentiel",
 		noFrameworks:   "Aucun référentiel de conformité n'est encore suivi.",
+		appetiteTitle:  "Appétence au risque",
+		appetiteLine:   "%d déclaration(s) en vigueur · %d risque(s) au-delà de l'appétence · %d sans dérogation approuvée",
+		appetiteWithin: "Le registre reste dans l'appétence approuvée par le conseil.",
+		appetiteRisks:  "Risques au-delà de l'appétence",
+		metricALE:      "Perte annuelle (P90)",
+		metricCritical: "Risques critiques",
+		excNone:        "Sans dérogation",
+		excPending:     "Dérogation en attente",
+		excApproved:    "Dérogation jusqu'au %s",
+		excExpired:     "Dérogation expirée",
+		kriTitle:       "Indicateurs clés de risque",
+		kriLine:        "%d rouge(s) · %d ambre(s) · %d vert(s) — 90 derniers jours",
+		kriNoValue:     "aucune valeur",
+		kriRed:         "Rouge",
+		kriAmber:       "Ambre",
+		kriGreen:       "Vert",
+		changesTitle:   "Évolution du registre",
+		changesLine:    "Depuis le %s : %d nouveau(x) · %d clôturé(s) · %d en hausse · %d en baisse",
+		changesNone:    "Aucun risque n'a évolué depuis le %s.",
+		changesNew:     "Nouveaux risques",
+		changesClosed:  "Risques clôturés",
+		changesUp:      "En hausse",
+		changesDown:    "En baisse",
 		draftBanner:    "BROUILLON — revue interne, non diffusable",
 		confidential:   "Confidentiel — généré par OpenRisk",
 		page:           "Page",
@@ -158,6 +227,9 @@ func RenderBoardPDF(data BoardReportData) ([]byte, error) {
 	drawBoardSection(pdf, tr, lbl.execTitle, data.ExecutiveSummary)
 	drawBoardSection(pdf, tr, lbl.riskTitle, data.RiskCommentary)
 	drawRiskChips(pdf, tr, lbl, data)
+	drawRegisterChanges(pdf, tr, lbl, data)
+	drawAppetite(pdf, tr, lbl, data)
+	drawKRIs(pdf, tr, lbl, data)
 	drawBoardSection(pdf, tr, lbl.compTitle, data.ComplianceCommentary)
 	drawFrameworksTable(pdf, tr, lbl, data)
 	drawBoardSection(pdf, tr, lbl.finTitle, data.FinancialCommentary)
@@ -380,6 +452,302 @@ func drawFrameworksTable(pdf *fpdf.Fpdf, tr func(string) string, lbl boardLabels
 	pdf.Ln(3)
 }
 
+// drawAppetite renders the register against the board's appetite: a summary
+// line, each breached scope with its exceeded portfolio tolerances, then the
+// worst risks above appetite with the state of their exception. Skipped when
+// no statement is in force.
+func drawAppetite(pdf *fpdf.Fpdf, tr func(string) string, lbl boardLabels, data BoardReportData) {
+	ap := data.Appetite
+	if ap == nil || ap.StatementsInForce == 0 {
+		return
+	}
+	if pdf.GetY()+24 > pageBottomLimit {
+		pdf.AddPage()
+	}
+	pdf.SetX(pageMarginLeft)
+	pdf.SetFont("Arial", "B", 12)
+	setText(pdf, textDark)
+	pdf.CellFormat(usableWidth, 7, tr(lbl.appetiteTitle), "", 1, "L", false, 0, "")
+
+	pdf.SetX(pageMarginLeft)
+	pdf.SetFont("Arial", "", 10)
+	if ap.WithoutException > 0 {
+		setText(pdf, critRed)
+	} else {
+		setText(pdf, rgb{55, 65, 81})
+	}
+	if ap.RisksAbove == 0 && len(ap.BreachedScopes) == 0 {
+		pdf.MultiCell(usableWidth, 5, tr(lbl.appetiteWithin), "", "L", false)
+		pdf.Ln(3)
+		return
+	}
+	pdf.MultiCell(usableWidth, 5, tr(fmt.Sprintf(lbl.appetiteLine, ap.StatementsInForce, ap.RisksAbove, ap.WithoutException)), "", "L", false)
+	pdf.Ln(1)
+
+	for _, sc := range ap.BreachedScopes {
+		if pdf.GetY()+10 > pageBottomLimit {
+			pdf.AddPage()
+		}
+		pdf.SetX(pageMarginLeft)
+		pdf.SetFont("Arial", "B", 9)
+		setText(pdf, textDark)
+		pdf.CellFormat(usableWidth, 5, tr(sc.Label), "", 1, "L", false, 0, "")
+		detail := sc.Statement
+		for _, b := range sc.Breaches {
+			name := lbl.metricCritical
+			if b.Metric == "ale_p90_xaf" {
+				name = lbl.metricALE
+			}
+			detail += fmt.Sprintf(" — %s : %s / %s", name, b.Value, b.Limit)
+		}
+		pdf.SetX(pageMarginLeft + 4)
+		pdf.SetFont("Arial", "", 9)
+		setText(pdf, textMuted)
+		pdf.MultiCell(usableWidth-4, 4.5, tr(detail), "", "L", false)
+	}
+
+	if len(ap.TopRisks) == 0 {
+		pdf.Ln(3)
+		return
+	}
+	pdf.Ln(1)
+	pdf.SetX(pageMarginLeft)
+	pdf.SetFont("Arial", "B", 10)
+	setText(pdf, textDark)
+	pdf.CellFormat(usableWidth, 6, tr(lbl.appetiteRisks), "", 1, "L", false, 0, "")
+
+	titleW := 95.0
+	critW := 25.0
+	scoreW := 15.0
+	excW := usableWidth - titleW - critW - scoreW
+	rowH := 5.5
+	for _, r := range ap.TopRisks {
+		if pdf.GetY()+rowH > pageBottomLimit {
+			pdf.AddPage()
+		}
+		y := pdf.GetY()
+		pdf.SetFont("Arial", "", 9)
+		setText(pdf, textDark)
+		pdf.SetXY(pageMarginLeft, y)
+		pdf.CellFormat(titleW, rowH, tr(clip(pdf, tr, r.Title, titleW-2)), "", 0, "L", false, 0, "")
+		pdf.CellFormat(critW, rowH, tr(r.Criticality), "", 0, "L", false, 0, "")
+		pdf.CellFormat(scoreW, rowH, fmt.Sprintf("%.1f", r.Score), "", 0, "R", false, 0, "")
+
+		exc, col := lbl.excNone, critRed
+		switch r.ExceptionStatus {
+		case "pending":
+			exc, col = lbl.excPending, critAmber
+		case "expired":
+			exc, col = lbl.excExpired, critRed
+		case "approved":
+			exc, col = lbl.excApproved, critGreen
+			if r.ValidUntil != nil {
+				exc = fmt.Sprintf(exc, r.ValidUntil.Format("2006-01-02"))
+			} else {
+				exc = fmt.Sprintf(exc, "—")
+			}
+		}
+		pdf.SetFont("Arial", "B", 9)
+		setText(pdf, col)
+		pdf.CellFormat(excW, rowH, tr(exc), "", 0, "R", false, 0, "")
+		pdf.SetY(y + rowH)
+	}
+	pdf.Ln(3)
+}
+
+// drawRegisterChanges renders what moved in the register since the previous
+// period: the counts, then each group of moved risks with their score from
+// and to. Skipped when there was nothing to compare against.
+func drawRegisterChanges(pdf *fpdf.Fpdf, tr func(string) string, lbl boardLabels, data BoardReportData) {
+	ch := data.Changes
+	if ch == nil {
+		return
+	}
+	if pdf.GetY()+24 > pageBottomLimit {
+		pdf.AddPage()
+	}
+	pdf.SetX(pageMarginLeft)
+	pdf.SetFont("Arial", "B", 12)
+	setText(pdf, textDark)
+	pdf.CellFormat(usableWidth, 7, tr(lbl.changesTitle), "", 1, "L", false, 0, "")
+	pdf.SetX(pageMarginLeft)
+	pdf.SetFont("Arial", "", 10)
+	setText(pdf, rgb{55, 65, 81})
+	since := ch.Since.Format("2006-01-02")
+	if ch.New+ch.Closed+ch.UpScored+ch.DownScored == 0 {
+		pdf.MultiCell(usableWidth, 5, tr(fmt.Sprintf(lbl.changesNone, since)), "", "L", false)
+		pdf.Ln(3)
+		return
+	}
+	pdf.MultiCell(usableWidth, 5, tr(fmt.Sprintf(lbl.changesLine, since, ch.New, ch.Closed, ch.UpScored, ch.DownScored)), "", "L", false)
+
+	groups := []struct {
+		title string
+		col   rgb
+		risks []BoardRegisterChange
+	}{
+		{lbl.changesNew, critRed, ch.NewRisks},
+		{lbl.changesUp, critAmber, ch.UpScoredRisks},
+		{lbl.changesDown, critGreen, ch.DownScoredRisks},
+		{lbl.changesClosed, critGreen, ch.ClosedRisks},
+	}
+	titleW := 110.0
+	critW := 25.0
+	scoreW := usableWidth - titleW - critW
+	rowH := 5.5
+	for _, g := range groups {
+		if len(g.risks) == 0 {
+			continue
+		}
+		if pdf.GetY()+rowH*2 > pageBottomLimit {
+			pdf.AddPage()
+		}
+		pdf.Ln(1)
+		pdf.SetX(pageMarginLeft)
+		pdf.SetFont("Arial", "B", 10)
+		setText(pdf, g.col)
+		pdf.CellFormat(usableWidth, 6, tr(g.title), "", 1, "L", false, 0, "")
+		for _, r := range g.risks {
+			if pdf.GetY()+rowH > pageBottomLimit {
+				pdf.AddPage()
+			}
+			y := pdf.GetY()
+			pdf.SetFont("Arial", "", 9)
+			setText(pdf, textDark)
+			pdf.SetXY(pageMarginLeft, y)
+			pdf.CellFormat(titleW, rowH, tr(clip(pdf, tr, r.Title, titleW-2)), "", 0, "L", false, 0, "")
+			pdf.CellFormat(critW, rowH, tr(r.Criticality), "", 0, "L", false, 0, "")
+			pdf.CellFormat(scoreW, rowH, tr(scoreMove(r.FromScore, r.ToScore)), "", 0, "R", false, 0, "")
+			pdf.SetY(y + rowH)
+		}
+	}
+	pdf.Ln(3)
+}
+
+// scoreMove formats "from → to", with a dash on the side where the risk did
+// not exist.
+func scoreMove(from, to *float64) string {
+	f := func(v *float64) string {
+		if v == nil {
+			return "—"
+		}
+		return fmt.Sprintf("%.1f", *v)
+	}
+	return f(from) + " → " + f(to)
+}
+
+// drawKRIs renders the key risk indicators: a band count, then one row per
+// indicator with its trend as a sparkline, its current value and its band.
+// Skipped when the tenant has no KRI.
+func drawKRIs(pdf *fpdf.Fpdf, tr func(string) string, lbl boardLabels, data BoardReportData) {
+	k := data.KRIs
+	if k == nil || len(k.Indicators) == 0 {
+		return
+	}
+	if pdf.GetY()+24 > pageBottomLimit {
+		pdf.AddPage()
+	}
+	pdf.SetX(pageMarginLeft)
+	pdf.SetFont("Arial", "B", 12)
+	setText(pdf, textDark)
+	pdf.CellFormat(usableWidth, 7, tr(lbl.kriTitle), "", 1, "L", false, 0, "")
+	pdf.SetX(pageMarginLeft)
+	pdf.SetFont("Arial", "", 10)
+	setText(pdf, rgb{55, 65, 81})
+	pdf.MultiCell(usableWidth, 5, tr(fmt.Sprintf(lbl.kriLine, k.Red, k.Amber, k.Green)), "", "L", false)
+	pdf.Ln(1)
+
+	nameW := 80.0
+	sparkW := 50.0
+	valueW := 30.0
+	bandW := usableWidth - nameW - sparkW - valueW
+	rowH := 6.5
+	for _, ind := range k.Indicators {
+		if pdf.GetY()+rowH > pageBottomLimit {
+			pdf.AddPage()
+		}
+		y := pdf.GetY()
+		col := kriColor(ind.Status)
+		pdf.SetFont("Arial", "", 9)
+		setText(pdf, textDark)
+		pdf.SetXY(pageMarginLeft, y)
+		pdf.CellFormat(nameW, rowH, tr(clip(pdf, tr, ind.Name, nameW-2)), "", 0, "L", false, 0, "")
+		drawSparkline(pdf, pageMarginLeft+nameW+2, y+1, sparkW-4, rowH-2, ind.Points, col)
+		pdf.SetXY(pageMarginLeft+nameW+sparkW, y)
+		value := ind.Value
+		if value == "" {
+			value = lbl.kriNoValue
+			setText(pdf, textMuted)
+		}
+		pdf.CellFormat(valueW, rowH, tr(value), "", 0, "R", false, 0, "")
+		pdf.SetFont("Arial", "B", 9)
+		setText(pdf, col)
+		pdf.CellFormat(bandW, rowH, tr(kriBandLabel(lbl, ind.Status)), "", 0, "R", false, 0, "")
+		pdf.SetY(y + rowH)
+	}
+	pdf.Ln(3)
+}
+
+// drawSparkline draws points as connected line segments scaled into the box.
+// A single point is a dot; a flat series is a line across the middle.
+func drawSparkline(pdf *fpdf.Fpdf, x, y, w, h float64, points []float64, col rgb) {
+	if len(points) == 0 {
+		return
+	}
+	lo, hi := points[0], points[0]
+	for _, p := range points {
+		if p < lo {
+			lo = p
+		}
+		if p > hi {
+			hi = p
+		}
+	}
+	py := func(v float64) float64 {
+		if hi == lo {
+			return y + h/2
+		}
+		return y + h - (v-lo)/(hi-lo)*h
+	}
+	pdf.SetDrawColor(col.r, col.g, col.b)
+	pdf.SetFillColor(col.r, col.g, col.b)
+	pdf.SetLineWidth(0.35)
+	if len(points) == 1 {
+		pdf.Circle(x+w, py(points[0]), 0.6, "F")
+		return
+	}
+	step := w / float64(len(points)-1)
+	for i := 1; i < len(points); i++ {
+		pdf.Line(x+step*float64(i-1), py(points[i-1]), x+step*float64(i), py(points[i]))
+	}
+	pdf.Circle(x+w, py(points[len(points)-1]), 0.6, "F")
+	pdf.SetLineWidth(0.2)
+}
+
+func kriColor(status string) rgb {
+	switch status {
+	case "red":
+		return critRed
+	case "amber":
+		return critAmber
+	case "green":
+		return critGreen
+	}
+	return textMuted
+}
+
+func kriBandLabel(lbl boardLabels, status string) string {
+	switch status {
+	case "red":
+		return lbl.kriRed
+	case "amber":
+		return lbl.kriAmber
+	case "green":
+		return lbl.kriGreen
+	}
+	return "—"
+}
+
 // drawBoardSection renders a titled paragraph; empty bodies are skipped.
 func drawBoardSection(pdf *fpdf.Fpdf, tr func(string) string, title, body string) {
 	if body == "" {
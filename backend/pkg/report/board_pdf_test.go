@@ -14,6 +14,8 @@ import (
 // the typographic characters that previously panicked fpdf.SplitText, plus a
 // draft status and several frameworks, to guard against regressions.
 func TestRenderBoardPDF(t *testing.T) {
+	approvedUntil := time.Now().AddDate(0, 6, 0)
+	before, after := 6.0, 9.0
 	data := BoardReportData{
 		Locale:                   LocaleFR,
 		OrganizationName:         "Banque Atlantique — Côte d'Ivoire",
@@ -35,6 +37,33 @@ func TestRenderBoardPDF(t *testing.T) {
 			{Name: "BCEAO", PercentComplete: 40, Implemented: 14, Applicable: 35},
 			{Name: "COBAC R-2016/04", PercentComplete: 22, Implemented: 10, Applicable: 45},
 		},
+		Appetite: &BoardAppetite{
+			StatementsInForce: 2, RisksAbove: 3, WithoutException: 1, PendingExceptions: 1,
+			BreachedScopes: []BoardAppetiteScope{{
+				Label: "Catégorie : Cyber", Statement: "Aucun risque critique cyber sans plan financé.", RisksAbove: 3,
+				Breaches: []BoardAppetiteBreach{{Metric: "critical_count", Value: "2", Limit: "1"}},
+			}},
+			TopRisks: []BoardAppetiteRisk{
+				{Title: "Rançongiciel sur le core banking", Criticality: "CRITICAL", Score: 25, ExceptionStatus: "none"},
+				{Title: "Fraude au virement", Criticality: "HIGH", Score: 16, ExceptionStatus: "approved", ValidUntil: &approvedUntil},
+				{Title: "Fuite de données clients", Criticality: "HIGH", Score: 15, ExceptionStatus: "pending"},
+			},
+		},
+		KRIs: &BoardKRIs{
+			Red: 1, Amber: 1, Green: 1,
+			Indicators: []BoardKRI{
+				{Name: "Vulnérabilités KEV ouvertes", Status: "red", Value: "12", Points: []float64{3, 4, 4, 7, 9, 12}},
+				{Name: "Taux de réussite des sauvegardes", Status: "amber", Value: "97,5 %", Points: []float64{99, 99, 98, 97.5}},
+				{Name: "Clics sur hameçonnage simulé", Status: "green", Value: "2 %", Points: []float64{2}},
+				{Name: "Comptes à privilèges non revus", Status: "unknown"},
+			},
+		},
+		Changes: &BoardRegisterChanges{
+			Since: time.Now().AddDate(0, -1, 0), New: 1, Closed: 1, UpScored: 1,
+			NewRisks:      []BoardRegisterChange{{Title: "Indisponibilité du GIM-UEMOA", Criticality: "high", ToScore: &after}},
+			ClosedRisks:   []BoardRegisterChange{{Title: "Obsolescence du SGBD", Criticality: "medium", FromScore: &before, ToScore: &before}},
+			UpScoredRisks: []BoardRegisterChange{{Title: "Rançongiciel sur le core banking", Criticality: "critical", FromScore: &before, ToScore: &after}},
+		},
 		ExecutiveSummary:     "La posture d'ensemble est globalement satisfaisante mais perfectible — les risques critiques concentrent l'essentiel de l'exposition.",
 		RiskCommentary:       "Le registre comprend 18 risques actifs, dont 2 critiques appelant un traitement immédiat.",
 		ComplianceCommentary: "La conformité consolidée atteint 62 % ; le référentiel « COBAC » reste le moins avancé.",
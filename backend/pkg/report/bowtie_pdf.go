@@ -0,0 +1,290 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// The bowtie is drawn on one A4 landscape page (297 × 210 mm): threats on the
+// left, consequences on the right, the top event in the middle and each
+// side's barriers in the band between.
+const (
+	bowtieMargin     = 10.0
+	bowtiePageW      = 297.0
+	bowtieTop        = 44.0  // below the header and the identity line
+	bowtieBottom     = 182.0 // above the legend
+	bowtiePathW      = 44.0
+	bowtieCentreW    = 40.0
+	bowtieBarrierMax = 24.0
+)
+
+type bowtieLabels struct {
+	docTitle    string
+	hazard      string
+	topEvent    string
+	threats     string
+	preventive  string
+	recovery    string
+	consequence string
+	generatedAt string
+	dateLayout  string
+	suggestion  string
+	noRaise     string
+	health      map[string]string
+}
+
+func bowtieLabelsFor(l Locale) bowtieLabels {
+	if l == LocaleEN {
+		return bowtieLabels{
+			docTitle:    "BOWTIE ANALYSIS",
+			hazard:      "Hazard",
+			topEvent:    "Top event",
+			threats:     "Threats",
+			preventive:  "Preventive barriers",
+			recovery:    "Recovery barriers",
+			consequence: "Consequences",
+			generatedAt: "Generated on",
+			dateLayout:  "January 2, 2006",
+			suggestion:  "Barriers suggest probability %s -> %s, impact %s -> %s",
+			noRaise:     "Barriers do not call for a higher probability or impact",
+			health: map[string]string{
+				"healthy": "Healthy", "degraded": "Degraded", "failed": "Failed", "unknown": "No evidence",
+			},
+		}
+	}
+	return bowtieLabels{
+		docTitle:    "ANALYSE NŒUD PAPILLON",
+		hazard:      "Danger",
+		topEvent:    "Événement redouté",
+		threats:     "Menaces",
+		preventive:  "Barrières de prévention",
+		recovery:    "Barrières de protection",
+		consequence: "Conséquences",
+		generatedAt: "Généré le",
+		dateLayout:  "02/01/2006",
+		suggestion:  "Les barrières suggèrent probabilité %s -> %s, impact %s -> %s",
+		noRaise:     "Les barrières n'appellent pas de probabilité ni d'impact plus élevés",
+		health: map[string]string{
+			"healthy": "Efficace", "degraded": "Dégradée", "failed": "Défaillante", "unknown": "Sans preuve",
+		},
+	}
+}
+
+func barrierColor(health string) rgb {
+	switch health {
+	case "healthy":
+		return green
+	case "degraded":
+		return amber
+	case "failed":
+		return red
+	default:
+		return gray
+	}
+}
+
+// RenderBowtiePDF renders a risk's bowtie as a one-page figure.
+func RenderBowtiePDF(data BowtieFigureData) ([]byte, error) {
+	lbl := bowtieLabelsFor(data.Locale.normalize())
+
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetMargins(bowtieMargin, bowtieMargin, bowtieMargin)
+	pdf.SetAutoPageBreak(false, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.AddPage()
+	drawBowtieHeader(pdf, tr, lbl, data)
+
+	centreX := (bowtiePageW - bowtieCentreW) / 2
+	midY := (bowtieTop + bowtieBottom) / 2
+	leftBand := [2]float64{bowtieMargin + bowtiePathW + 4, centreX - 6}
+	rightBand := [2]float64{centreX + bowtieCentreW + 6, bowtiePageW - bowtieMargin - bowtiePathW - 4}
+
+	pdf.SetFont("Arial", "B", 8)
+	setText(pdf, textMuted)
+	for _, col := range []struct {
+		x, w float64
+		s    string
+	}{
+		{bowtieMargin, bowtiePathW, lbl.threats},
+		{leftBand[0], leftBand[1] - leftBand[0], lbl.preventive},
+		{rightBand[0], rightBand[1] - rightBand[0], lbl.recovery},
+		{bowtiePageW - bowtieMargin - bowtiePathW, bowtiePathW, lbl.consequence},
+	} {
+		pdf.SetXY(col.x, bowtieTop-7)
+		pdf.CellFormat(col.w, 5, tr(col.s), "", 0, "C", false, 0, "")
+	}
+
+	drawBowtieSide(pdf, tr, data.Threats, bowtieMargin, leftBand, centreX, midY, false)
+	drawBowtieSide(pdf, tr, data.Consequences, bowtiePageW-bowtieMargin-bowtiePathW, rightBand, centreX+bowtieCentreW, midY, true)
+
+	// Top event: the knot of the bowtie, drawn last so it sits over the lines.
+	const h = 30.0
+	setFill(pdf, brandDark)
+	setDraw(pdf, brandDark)
+	pdf.RoundedRect(centreX, midY-h/2, bowtieCentreW, h, 3, "1234", "F")
+	pdf.SetFont("Arial", "B", 7)
+	setText(pdf, rgb{203, 213, 225})
+	pdf.SetXY(centreX, midY-h/2+2)
+	pdf.CellFormat(bowtieCentreW, 4, tr(lbl.topEvent), "", 0, "C", false, 0, "")
+	pdf.SetFont("Arial", "B", 8)
+	setText(pdf, rgb{255, 255, 255})
+	drawBoxText(pdf, tr, data.TopEvent, centreX+2, midY-h/2+7, bowtieCentreW-4, h-9, 3.6)
+
+	drawBowtieLegend(pdf, tr, lbl, data)
+
+	if pdf.Err() {
+		return nil, fmt.Errorf("bowtie pdf render error: %w", pdf.Error())
+	}
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("bowtie pdf output error: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func drawBowtieHeader(pdf *fpdf.Fpdf, tr func(string) string, lbl bowtieLabels, data BowtieFigureData) {
+	setFill(pdf, brandDark)
+	pdf.Rect(0, 0, bowtiePageW, 22, "F")
+	setFill(pdf, brandAccent)
+	pdf.Rect(0, 22, bowtiePageW, 1.2, "F")
+
+	pdf.SetXY(bowtieMargin, 5)
+	pdf.SetFont("Arial", "B", 16)
+	setText(pdf, rgb{255, 255, 255})
+	pdf.CellFormat(bowtiePageW-2*bowtieMargin, 7, tr(lbl.docTitle), "", 1, "L", false, 0, "")
+	pdf.SetX(bowtieMargin)
+	pdf.SetFont("Arial", "", 10)
+	setText(pdf, rgb{203, 213, 225})
+	pdf.CellFormat(bowtiePageW-2*bowtieMargin, 6,
+		tr(clip(pdf, tr, "OpenRisk  -  "+data.RiskTitle, bowtiePageW-2*bowtieMargin)), "", 1, "L", false, 0, "")
+
+	genAt := data.GeneratedAt
+	if genAt.IsZero() {
+		genAt = time.Now()
+	}
+	pdf.SetXY(bowtieMargin, 26)
+	pdf.SetFont("Arial", "", 9)
+	setText(pdf, textDark)
+	if data.Hazard != "" {
+		pdf.SetFont("Arial", "B", 9)
+		pdf.CellFormat(18, 5, tr(lbl.hazard), "", 0, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 9)
+		pdf.CellFormat(180, 5, tr(clip(pdf, tr, data.Hazard, 178)), "", 0, "L", false, 0, "")
+	}
+	setText(pdf, textMuted)
+	pdf.SetX(bowtiePageW - bowtieMargin - 70)
+	pdf.CellFormat(70, 5, tr(lbl.generatedAt+" "+genAt.Format(lbl.dateLayout)), "", 0, "R", false, 0, "")
+}
+
+// drawBowtieSide draws one wing: a box per path at pathX, a line from it to
+// the top event at (joinX, midY), and the path's barriers spread across band.
+func drawBowtieSide(pdf *fpdf.Fpdf, tr func(string) string, paths []BowtieFigurePath, pathX float64, band [2]float64, joinX, midY float64, right bool) {
+	if len(paths) == 0 {
+		return
+	}
+	step := (bowtieBottom - bowtieTop) / float64(len(paths))
+	boxH := math.Min(16, step-2)
+	for i, p := range paths {
+		y := bowtieTop + step*(float64(i)+0.5)
+
+		// Path box.
+		setFill(pdf, cardBg)
+		setDraw(pdf, hairline)
+		pdf.SetLineWidth(0.2)
+		pdf.RoundedRect(pathX, y-boxH/2, bowtiePathW, boxH, 1.5, "1234", "FD")
+		pdf.SetFont("Arial", "", 7)
+		setText(pdf, textDark)
+		drawBoxText(pdf, tr, p.Label, pathX+1.5, y-boxH/2+1, bowtiePathW-3, boxH-2, 3)
+
+		// The line runs level across the band, then slants into the knot.
+		setDraw(pdf, textMuted)
+		pdf.SetLineWidth(0.35)
+		if right {
+			pdf.Line(pathX, y, band[0], y)
+			pdf.Line(band[0], y, joinX, midY)
+		} else {
+			pdf.Line(pathX+bowtiePathW, y, band[1], y)
+			pdf.Line(band[1], y, joinX, midY)
+		}
+
+		n := len(p.Barriers)
+		if n == 0 {
+			continue
+		}
+		slot := (band[1] - band[0]) / float64(n)
+		w := math.Min(bowtieBarrierMax, slot-1.5)
+		bh := math.Min(12, boxH)
+		for j, b := range p.Barriers {
+			// Barriers are listed from the threat inwards and from the top
+			// event outwards: left to right on both wings.
+			x := band[0] + slot*float64(j) + (slot-w)/2
+			col := barrierColor(b.Health)
+			setFill(pdf, col)
+			setDraw(pdf, col)
+			pdf.Rect(x, y-bh/2, w, bh, "F")
+			pdf.SetFont("Arial", "B", 6)
+			setText(pdf, rgb{255, 255, 255})
+			drawBoxText(pdf, tr, b.Label, x+0.8, y-bh/2+0.8, w-1.6, bh-1.6, 2.6)
+		}
+	}
+	pdf.SetLineWidth(0.2)
+}
+
+// drawBoxText wraps s into the box and cuts it to the lines that fit, centred
+// both ways. Wrapping measures the UTF-8 text, a little wider than the
+// translated one, so a line never overflows.
+func drawBoxText(pdf *fpdf.Fpdf, tr func(string) string, s string, x, y, w, h, lineH float64) {
+	lines := wrapToWidth(pdf, s, w)
+	max := int(h / lineH)
+	if max < 1 {
+		max = 1
+	}
+	if len(lines) > max {
+		lines = lines[:max]
+		lines[max-1] = clip(pdf, tr, lines[max-1]+"…", w)
+	}
+	top := y + (h-float64(len(lines))*lineH)/2
+	for i, l := range lines {
+		pdf.SetXY(x, top+float64(i)*lineH)
+		pdf.CellFormat(w, lineH, tr(l), "", 0, "C", false, 0, "")
+	}
+}
+
+func drawBowtieLegend(pdf *fpdf.Fpdf, tr func(string) string, lbl bowtieLabels, data BowtieFigureData) {
+	y := bowtieBottom + 6
+	x := bowtieMargin
+	pdf.SetFont("Arial", "", 8)
+	for _, h := range []string{"healthy", "degraded", "failed", "unknown"} {
+		setFill(pdf, barrierColor(h))
+		pdf.Rect(x, y+1, 4, 3, "F")
+		setText(pdf, textDark)
+		pdf.SetXY(x+5, y)
+		label := tr(lbl.health[h])
+		w := pdf.GetStringWidth(label) + 2
+		pdf.CellFormat(w, 5, label, "", 0, "L", false, 0, "")
+		x += 5 + w + 4
+	}
+
+	msg := lbl.noRaise
+	if data.Raises {
+		msg = fmt.Sprintf(lbl.suggestion,
+			fmt.Sprintf("%.2f", data.Probability), fmt.Sprintf("%.2f", data.SuggestedProbability),
+			fmt.Sprintf("%.1f", data.Impact), fmt.Sprintf("%.1f", data.SuggestedImpact))
+		setText(pdf, red)
+		pdf.SetFont("Arial", "B", 8)
+	} else {
+		setText(pdf, textMuted)
+	}
+	pdf.SetXY(x+6, y)
+	pdf.CellFormat(bowtiePageW-bowtieMargin-x-6, 5, tr(clip(pdf, tr, msg, bowtiePageW-bowtieMargin-x-6)), "", 0, "R", false, 0, "")
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRenderBowtiePDF draws a crowded bowtie with accents and long labels in
+// both locales, and an empty one, to guard against layout panics.
+func TestRenderBowtiePDF(t *testing.T) {
+	long := strings.Repeat("Contrôle d'accès privilégié très détaillé ", 4)
+	var threats []BowtieFigurePath
+	for i := 0; i < 12; i++ {
+		p := BowtieFigurePath{Label: "Menace — hameçonnage ciblé de l'opérateur"}
+		for j, h := range []string{"healthy", "degraded", "failed", "unknown", "healthy"} {
+			p.Barriers = append(p.Barriers, BowtieFigureBarrier{Label: long[:20+j*10], Health: h})
+		}
+		threats = append(threats, p)
+	}
+	data := BowtieFigureData{
+		RiskTitle: "Fraude au virement — agence d'Abidjan", Hazard: "Fonds clients en transit",
+		TopEvent: long, GeneratedAt: time.Now(), Threats: threats,
+		Consequences: []BowtieFigurePath{
+			{Label: "Perte financière", Barriers: []BowtieFigureBarrier{{Label: "Rappel SWIFT", Health: "failed"}}},
+			{Label: "Sanction BCEAO"},
+		},
+		Probability: 0.4, Impact: 6, SuggestedProbability: 0.55, SuggestedImpact: 7, Raises: true,
+	}
+	for _, l := range []Locale{LocaleFR, LocaleEN} {
+		data.Locale = l
+		out, err := RenderBowtiePDF(data)
+		if err != nil {
+			t.Fatalf("%s: %v", l, err)
+		}
+		if !bytes.HasPrefix(out, []byte("%PDF")) {
+			t.Fatalf("%s: not a PDF", l)
+		}
+	}
+	if _, err := RenderBowtiePDF(BowtieFigureData{TopEvent: "x"}); err != nil {
+		t.Fatalf("empty bowtie: %v", err)
+	}
+}
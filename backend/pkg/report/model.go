@@ -106,6 +106,18 @@ type BoardReportData struct {
 	OverallCompliancePercent float64
 	Frameworks               []BoardFrameworkRow
 
+	// Appetite is the register measured against the board's own appetite
+	// statements; nil when none is in force (the section is then omitted).
+	Appetite *BoardAppetite
+
+	// KRIs are the key risk indicators with their recent trend; nil when the
+	// tenant has none (the section is then omitted).
+	KRIs *BoardKRIs
+
+	// Changes is what moved in the register since the previous period; nil
+	// when no snapshot is old enough to compare against.
+	Changes *BoardRegisterChanges
+
 	// Narrative (already reviewed by a human)
 	ExecutiveSummary     string
 	RiskCommentary       string
@@ -114,6 +126,85 @@ type BoardReportData struct {
 	Recommendations      []string
 }
 
+// BoardAppetite is the risk-appetite section of a board report.
+type BoardAppetite struct {
+	StatementsInForce int
+	RisksAbove        int
+	WithoutException  int
+	PendingExceptions int
+	BreachedScopes    []BoardAppetiteScope
+	TopRisks          []BoardAppetiteRisk
+}
+
+// BoardAppetiteScope is one appetite statement whose scope is above appetite.
+type BoardAppetiteScope struct {
+	Label      string
+	Statement  string
+	RisksAbove int
+	Breaches   []BoardAppetiteBreach
+}
+
+// BoardAppetiteBreach is a portfolio tolerance exceeded. Metric is one of
+// "ale_p90_xaf" or "critical_count"; Value and Limit are pre-formatted.
+type BoardAppetiteBreach struct {
+	Metric string
+	Value  string
+	Limit  string
+}
+
+// BoardAppetiteRisk is one risk above appetite. ExceptionStatus is one of
+// "none", "pending", "approved", "expired".
+type BoardAppetiteRisk struct {
+	Title           string
+	Criticality     string
+	Score           float64
+	ExceptionStatus string
+	ValidUntil      *time.Time
+}
+
+// BoardKRIs is the key-risk-indicator section of a board report.
+type BoardKRIs struct {
+	Red        int
+	Amber      int
+	Green      int
+	Indicators []BoardKRI
+}
+
+// BoardKRI is one indicator. Status is one of "red", "amber", "green",
+// "unknown"; Value is pre-formatted with its unit; Points are the trend, oldest
+// first, drawn as a sparkline.
+type BoardKRI struct {
+	Name   string
+	Status string
+	Value  string
+	Points []float64
+}
+
+// BoardRegisterChanges is the "what moved" section of a board report: the
+// live register compared with the snapshot standing for Since.
+type BoardRegisterChanges struct {
+	Since      time.Time
+	New        int
+	Closed     int
+	UpScored   int
+	DownScored int
+	// Groups are the moved risks, in the order New, Closed, UpScored,
+	// DownScored; each is capped, the counts above are not.
+	NewRisks        []BoardRegisterChange
+	ClosedRisks     []BoardRegisterChange
+	UpScoredRisks   []BoardRegisterChange
+	DownScoredRisks []BoardRegisterChange
+}
+
+// BoardRegisterChange is one moved risk. A score is nil on the side where the
+// risk did not exist.
+type BoardRegisterChange struct {
+	Title       string
+	Criticality string
+	FromScore   *float64
+	ToScore     *float64
+}
+
 // BoardFrameworkRow is one line of the compliance-by-framework table.
 type BoardFrameworkRow struct {
 	Name            string
@@ -122,3 +213,36 @@ type BoardFrameworkRow struct {
 	Implemented     int
 	Applicable      int
 }
+
+// BowtieFigureData is everything the bowtie figure of one risk shows. Health
+// values mirror domain.BarrierHealth: "healthy", "degraded", "failed" or
+// "unknown".
+type BowtieFigureData struct {
+	Locale       Locale
+	RiskTitle    string
+	Hazard       string
+	TopEvent     string
+	GeneratedAt  time.Time
+	Threats      []BowtieFigurePath
+	Consequences []BowtieFigurePath
+	// The risk's figures and what its barriers suggest; Raises is false when
+	// the suggestion is the current figures.
+	Probability          float64
+	Impact               float64
+	SuggestedProbability float64
+	SuggestedImpact      float64
+	Raises               bool
+}
+
+// BowtieFigurePath is a threat or a consequence with the barriers crossing
+// it, in drawing order: from the threat inwards, from the top event outwards.
+type BowtieFigurePath struct {
+	Label    string
+	Barriers []BowtieFigureBarrier
+}
+
+// BowtieFigureBarrier is one barrier box.
+type BowtieFigureBarrier struct {
+	Label  string
+	Health string
+}
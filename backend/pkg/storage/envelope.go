@@ -0,0 +1,275 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/opendefender/openrisk/pkg/crypto"
+)
+
+// Envelope encryption for stored files.
+//
+// Every tenant gets its own random 256-bit data key. The data key never leaves
+// the process in the clear: it is persisted only wrapped (AES-256-GCM via
+// pkg/crypto) under the deployment's master key, next to the data it protects.
+// Rotating the master key therefore means re-wrapping one small key per tenant,
+// not re-encrypting every evidence file, and erasing a tenant's wrapped key is
+// enough to make every byte it ever uploaded unreadable.
+
+// ErrCiphertextCorrupt is returned while reading an encrypted object whose
+// bytes fail authentication — truncated, reordered, or altered at rest.
+var ErrCiphertextCorrupt = errors.New("storage: encrypted object failed authentication")
+
+// DataKeyStore persists wrapped per-tenant data keys. Implementations store the
+// opaque wrapped string verbatim; they never see a usable key.
+type DataKeyStore interface {
+	// LoadDataKey returns the wrapped key for tenantID, or found=false if the
+	// tenant has never had one.
+	LoadDataKey(ctx context.Context, tenantID uuid.UUID) (wrapped string, found bool, err error)
+	// StoreDataKey persists a wrapped key only if none exists yet and reports
+	// whether it did. Two replicas racing to create a tenant's first key must
+	// converge on one of them, never on two keys with half the files each.
+	StoreDataKey(ctx context.Context, tenantID uuid.UUID, wrapped string) (stored bool, err error)
+}
+
+// Keyring hands out per-tenant data keys, creating them on first use.
+type Keyring struct {
+	master []byte
+	store  DataKeyStore
+
+	mu    sync.Mutex
+	cache map[uuid.UUID][]byte
+}
+
+// NewKeyring builds a keyring over store. masterKey must be 32 bytes.
+func NewKeyring(masterKey []byte, store DataKeyStore) (*Keyring, error) {
+	if len(masterKey) != 32 {
+		return nil, fmt.Errorf("storage: master key must be 32 bytes, got %d", len(masterKey))
+	}
+	if store == nil {
+		return nil, errors.New("storage: a data key store is required")
+	}
+	return &Keyring{master: masterKey, store: store, cache: map[uuid.UUID][]byte{}}, nil
+}
+
+// DataKey returns tenantID's unwrapped data key, generating and persisting one
+// the first time the tenant stores anything.
+func (k *Keyring) DataKey(ctx context.Context, tenantID uuid.UUID) ([]byte, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if key, ok := k.cache[tenantID]; ok {
+		return key, nil
+	}
+
+	wrapped, found, err := k.store.LoadDataKey(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("storage: failed to load data key: %w", err)
+	}
+	if !found {
+		fresh := make([]byte, 32)
+		if _, err := io.ReadFull(rand.Reader, fresh); err != nil {
+			return nil, fmt.Errorf("storage: failed to generate data key: %w", err)
+		}
+		w, err := crypto.EncryptAES256GCM(base64.StdEncoding.EncodeToString(fresh), k.master)
+		if err != nil {
+			return nil, fmt.Errorf("storage: failed to wrap data key: %w", err)
+		}
+		stored, err := k.store.StoreDataKey(ctx, tenantID, w)
+		if err != nil {
+			return nil, fmt.Errorf("storage: failed to store data key: %w", err)
+		}
+		if stored {
+			k.cache[tenantID] = fresh
+			return fresh, nil
+		}
+		// Another replica won the race; use its key, not ours.
+		if wrapped, found, err = k.store.LoadDataKey(ctx, tenantID); err != nil || !found {
+			return nil, fmt.Errorf("storage: data key vanished after a concurrent create: %v", err)
+		}
+	}
+
+	key, err := k.unwrap(wrapped)
+	if err != nil {
+		return nil, err
+	}
+	k.cache[tenantID] = key
+	return key, nil
+}
+
+func (k *Keyring) unwrap(wrapped string) ([]byte, error) {
+	encoded, err := crypto.DecryptAES256GCM(wrapped, k.master)
+	if err != nil {
+		// Almost always the wrong STORAGE_ENCRYPTION_KEY (or MFA_ENCRYPTION_KEY)
+		// for this bucket.
+		return nil, fmt.Errorf("storage: failed to unwrap data key (wrong master key?): %w", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != 32 {
+		return nil, errors.New("storage: wrapped data key is malformed")
+	}
+	return key, nil
+}
+
+// =============================================================================
+// Streaming AEAD
+// =============================================================================
+
+// The object format is a short header followed by independently sealed chunks:
+//
+//	"ORS1" | 7-byte nonce prefix | chunk_0 | chunk_1 | ... | chunk_n
+//
+// Each chunk is AES-256-GCM over at most encChunkSize plaintext bytes, with a
+// nonce of prefix || big-endian chunk counter || final flag. The counter stops
+// chunks being reordered or dropped from the middle; the final flag, set only on
+// the last (short, possibly empty) chunk, stops the object being truncated at a
+// chunk boundary. Chunking rather than one Seal over the whole file is what lets
+// a 2 GB packet capture be uploaded and downloaded without holding it in memory.
+
+const (
+	encMagic     = "ORS1"
+	encPrefixLen = 7
+	encChunkSize = 64 * 1024
+	encTagSize   = 16
+	encBlockSize = encChunkSize + encTagSize
+)
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("storage: failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkNonce(prefix []byte, counter uint32, final bool) []byte {
+	nonce := make([]byte, 12)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[encPrefixLen:], counter)
+	if final {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+// encryptReader yields the encrypted form of src as it is read.
+type encryptReader struct {
+	src     io.Reader
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	plain   []byte
+	out     bytes.Buffer
+	done    bool
+}
+
+// NewEncryptReader returns a reader producing the encrypted object for src
+// under key (a tenant data key from Keyring.DataKey).
+func NewEncryptReader(src io.Reader, key []byte) (io.Reader, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, encPrefixLen)
+	if _, err := io.ReadFull(rand.Reader, prefix); err != nil {
+		return nil, fmt.Errorf("storage: failed to generate nonce: %w", err)
+	}
+	r := &encryptReader{src: src, aead: aead, prefix: prefix, plain: make([]byte, encChunkSize)}
+	r.out.WriteString(encMagic)
+	r.out.Write(prefix)
+	return r, nil
+}
+
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for r.out.Len() == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		n, err := io.ReadFull(r.src, r.plain)
+		final := false
+		switch {
+		case err == io.EOF || err == io.ErrUnexpectedEOF:
+			final = true
+		case err != nil:
+			return 0, err
+		}
+		r.out.Write(r.aead.Seal(nil, chunkNonce(r.prefix, r.counter, final), r.plain[:n], nil))
+		r.counter++
+		r.done = final
+	}
+	return r.out.Read(p)
+}
+
+// decryptReader authenticates and decrypts an object produced by encryptReader.
+type decryptReader struct {
+	src     io.ReadCloser
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	block   []byte
+	out     []byte
+	done    bool
+}
+
+// NewDecryptReader wraps an encrypted object. Errors surface from Read as
+// ErrCiphertextCorrupt; callers must not trust bytes read before such an
+// error, exactly as with any streaming AEAD.
+func NewDecryptReader(src io.ReadCloser, key []byte) (io.ReadCloser, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, len(encMagic)+encPrefixLen)
+	if _, err := io.ReadFull(src, header); err != nil || string(header[:len(encMagic)]) != encMagic {
+		return nil, ErrCiphertextCorrupt
+	}
+	return &decryptReader{
+		src: src, aead: aead, prefix: header[len(encMagic):], block: make([]byte, encBlockSize),
+	}, nil
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		n, err := io.ReadFull(r.src, r.block)
+		final := false
+		switch {
+		case err == io.ErrUnexpectedEOF:
+			final = true
+		case err == io.EOF:
+			// Clean end of stream without ever seeing the final chunk: truncated.
+			return 0, ErrCiphertextCorrupt
+		case err != nil:
+			return 0, err
+		}
+		plain, openErr := r.aead.Open(r.block[:0:0], chunkNonce(r.prefix, r.counter, final), r.block[:n], nil)
+		if openErr != nil {
+			return 0, ErrCiphertextCorrupt
+		}
+		r.counter++
+		r.out = plain
+		r.done = final
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+func (r *decryptReader) Close() error { return r.src.Close() }
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type memKeyStore struct {
+	mu   sync.Mutex
+	keys map[uuid.UUID]string
+}
+
+func (m *memKeyStore) LoadDataKey(_ context.Context, tenantID uuid.UUID) (string, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w, ok := m.keys[tenantID]
+	return w, ok, nil
+}
+
+func (m *memKeyStore) StoreDataKey(_ context.Context, tenantID uuid.UUID, wrapped string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.keys[tenantID]; ok {
+		return false, nil
+	}
+	m.keys[tenantID] = wrapped
+	return true, nil
+}
+
+func testMasterKey() []byte { return bytes.Repeat([]byte{7}, 32) }
+
+func encrypt(t *testing.T, plain, key []byte) []byte {
+	t.Helper()
+	r, err := NewEncryptReader(bytes.NewReader(plain), key)
+	require.NoError(t, err)
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return out
+}
+
+func decrypt(key, ciphertext []byte) ([]byte, error) {
+	rc, err := NewDecryptReader(io.NopCloser(bytes.NewReader(ciphertext)), key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func TestEnvelope_RoundTripAcrossChunkBoundaries(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 32)
+	for _, size := range []int{0, 1, encChunkSize - 1, encChunkSize, encChunkSize + 1, 3*encChunkSize + 17} {
+		plain := make([]byte, size)
+		_, _ = rand.Read(plain)
+
+		ct := encrypt(t, plain, key)
+		require.NotEqual(t, plain, ct)
+
+		got, err := decrypt(key, ct)
+		require.NoError(t, err, "size %d", size)
+		require.Equal(t, plain, got, "size %d", size)
+	}
+}
+
+func TestEnvelope_DetectsTampering(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 32)
+	plain := bytes.Repeat([]byte("evidence"), encChunkSize/4)
+	ct := encrypt(t, plain, key)
+
+	flipped := append([]byte(nil), ct...)
+	flipped[len(flipped)/2] ^= 0x01
+	_, err := decrypt(key, flipped)
+	require.ErrorIs(t, err, ErrCiphertextCorrupt)
+
+	// Truncated exactly at a chunk boundary: every remaining chunk is intact,
+	// only the final-chunk marker is missing.
+	header := len(encMagic) + encPrefixLen
+	_, err = decrypt(key, ct[:header+encBlockSize])
+	require.ErrorIs(t, err, ErrCiphertextCorrupt)
+
+	_, err = decrypt(bytes.Repeat([]byte{2}, 32), ct)
+	require.ErrorIs(t, err, ErrCiphertextCorrupt)
+}
+
+func TestKeyring_OneKeyPerTenantAndStableAcrossInstances(t *testing.T) {
+	ctx := context.Background()
+	store := &memKeyStore{keys: map[uuid.UUID]string{}}
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	kr, err := NewKeyring(testMasterKey(), store)
+	require.NoError(t, err)
+	a1, err := kr.DataKey(ctx, tenantA)
+	require.NoError(t, err)
+	b1, err := kr.DataKey(ctx, tenantB)
+	require.NoError(t, err)
+	require.NotEqual(t, a1, b1)
+
+	// The store only ever sees wrapped material.
+	require.NotContains(t, store.keys[tenantA], string(a1))
+
+	// A second replica with the same master key unwraps the same data key.
+	other, err := NewKeyring(testMasterKey(), store)
+	require.NoError(t, err)
+	a2, err := other.DataKey(ctx, tenantA)
+	require.NoError(t, err)
+	require.Equal(t, a1, a2)
+
+	// The wrong master key cannot.
+	wrong, err := NewKeyring(bytes.Repeat([]byte{9}, 32), store)
+	require.NoError(t, err)
+	_, err = wrong.DataKey(ctx, tenantA)
+	require.Error(t, err)
+}
@@ -24,9 +24,9 @@ var unsafeFilenameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
 
 // LocalStorage stores files on the local filesystem under BasePath,
 // namespaced by tenant. It satisfies Storage and is meant as the default,
-// zero-dependency driver — a future S3Storage (or similar) implementing
-// the same interface can be swapped in via a STORAGE_DRIVER config switch
-// without any caller (use cases, handlers) changing.
+// zero-dependency driver for single-node installs. Anything with more than one
+// API replica needs S3Storage (STORAGE_DRIVER=s3): a local directory is only
+// shared if every pod mounts the same volume, and nobody should rely on that.
 type LocalStorage struct {
 	BasePath string
 }
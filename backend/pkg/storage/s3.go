@@ -0,0 +1,416 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/google/uuid"
+)
+
+// S3Config configures an S3-compatible bucket (AWS S3, MinIO, Ceph RGW).
+type S3Config struct {
+	Bucket string
+	Region string
+	// Endpoint overrides the AWS endpoint resolver — required for MinIO and
+	// Ceph, empty for AWS.
+	Endpoint string
+	// AccessKeyID/SecretAccessKey are static credentials. Empty falls back to
+	// the SDK's default chain (env, IRSA, instance profile), which is what an
+	// EKS deployment should use.
+	AccessKeyID     string
+	SecretAccessKey string
+	// UsePathStyle addresses the bucket as endpoint/bucket/key. MinIO and most
+	// Ceph installs need it; AWS does not.
+	UsePathStyle bool
+	// Prefix namespaces every object this deployment writes, so one bucket can
+	// serve several environments. Tenants are namespaced below it.
+	Prefix string
+
+	// RetentionMode and RetentionDays apply S3 Object Lock to every evidence
+	// object at write time. The bucket must have been created with Object Lock
+	// enabled. Empty mode disables per-object retention (the bucket's default
+	// retention, if any, still applies).
+	RetentionMode types.ObjectLockMode
+	RetentionDays int
+
+	// PartSize is the multipart chunk size; uploads no larger than one part go
+	// up in a single PUT. Zero means DefaultS3PartSize.
+	PartSize int64
+
+	// MasterKey, when set (32 bytes), turns on envelope encryption: objects are
+	// encrypted client-side with a per-tenant data key wrapped by this key. The
+	// provider's own server-side encryption (SSE-S3/KMS) stays on top of it.
+	MasterKey []byte
+}
+
+// DefaultS3PartSize is large enough that nearly every piece of evidence goes
+// up in one request, and small enough that a multi-gigabyte export buffers
+// only a few megabytes at a time. S3's floor for a non-final part is 5 MiB.
+const DefaultS3PartSize int64 = 8 * 1024 * 1024
+
+const (
+	// metaEncryption marks an object as envelope-encrypted, so a bucket that
+	// had encryption switched on mid-life still serves its older plaintext
+	// objects correctly.
+	metaEncryption   = "openrisk-encryption"
+	encryptionScheme = "aes256gcm-stream-v1"
+	// dataKeyObject is where a tenant's wrapped data key lives, under the
+	// tenant's own prefix so deleting a tenant's prefix crypto-shreds it too.
+	dataKeyObject = "_keys/data-key"
+)
+
+// S3Storage stores files in an S3-compatible bucket. Keys it returns have the
+// same shape as LocalStorage's ("<tenant>/<uuid>-<name>"), so FileRef values
+// stay meaningful across drivers and the local → S3 migration only has to copy
+// bytes, not reinterpret keys.
+type S3Storage struct {
+	client    *s3.Client
+	presign   *s3.PresignClient
+	cfg       S3Config
+	keyring   *Keyring
+	partSize  int64
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewS3Storage builds the client and verifies the bucket is reachable, so a
+// typo in the bucket name fails the boot rather than the first upload.
+func NewS3Storage(ctx context.Context, cfg S3Config) (*S3Storage, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("storage: S3 bucket is required")
+	}
+	if cfg.Region == "" {
+		// MinIO and Ceph ignore the region but the SigV4 signer needs one.
+		cfg.Region = "us-east-1"
+	}
+	if cfg.RetentionMode != "" && cfg.RetentionDays <= 0 {
+		return nil, errors.New("storage: object lock retention mode needs a positive retention period")
+	}
+
+	opts := []func(*awsconfig.LoadOptions) error{awsconfig.WithRegion(cfg.Region)}
+	if cfg.AccessKeyID != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, "")))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("storage: failed to load AWS config: %w", err)
+	}
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+		o.UsePathStyle = cfg.UsePathStyle
+	})
+
+	s := &S3Storage{
+		client:    client,
+		presign:   s3.NewPresignClient(client),
+		cfg:       cfg,
+		partSize:  cfg.PartSize,
+		retention: time.Duration(cfg.RetentionDays) * 24 * time.Hour,
+		now:       time.Now,
+	}
+	if s.partSize <= 0 {
+		s.partSize = DefaultS3PartSize
+	}
+	if len(cfg.MasterKey) > 0 {
+		kr, err := NewKeyring(cfg.MasterKey, s)
+		if err != nil {
+			return nil, err
+		}
+		s.keyring = kr
+	}
+
+	if _, err := client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(cfg.Bucket)}); err != nil {
+		return nil, fmt.Errorf("storage: bucket %q is not reachable: %w", cfg.Bucket, err)
+	}
+	return s, nil
+}
+
+// Encrypted reports whether envelope encryption is on.
+func (s *S3Storage) Encrypted() bool { return s.keyring != nil }
+
+func (s *S3Storage) objectKey(key string) string { return s.cfg.Prefix + key }
+
+// tenantOf recovers the tenant namespace from a key. Every key this driver
+// hands out starts with one, and a key that does not is refused outright rather
+// than resolved somewhere unexpected.
+func tenantOf(key string) (uuid.UUID, bool) {
+	head, rest, ok := strings.Cut(key, "/")
+	if !ok || rest == "" || strings.Contains(rest, "..") {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(head)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (s *S3Storage) Save(ctx context.Context, tenantID uuid.UUID, filename string, content io.Reader) (string, error) {
+	key := tenantID.String() + "/" + uuid.New().String() + "-" + sanitizeFilename(filename)
+
+	body := content
+	meta := map[string]string{}
+	if s.keyring != nil {
+		dk, err := s.keyring.DataKey(ctx, tenantID)
+		if err != nil {
+			return "", err
+		}
+		if body, err = NewEncryptReader(content, dk); err != nil {
+			return "", err
+		}
+		meta[metaEncryption] = encryptionScheme
+	}
+
+	if err := s.put(ctx, s.objectKey(key), body, meta); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// lockFields returns the Object Lock mode and retain-until for a new object.
+func (s *S3Storage) lockFields() (types.ObjectLockMode, *time.Time) {
+	if s.cfg.RetentionMode == "" {
+		return "", nil
+	}
+	until := s.now().Add(s.retention).UTC()
+	return s.cfg.RetentionMode, &until
+}
+
+// put uploads body, in one request when it fits in a single part and as a
+// multipart upload otherwise. Parts are buffered in memory because the SDK
+// needs a seekable body to sign a plain-HTTP request (a local MinIO), and
+// buffering one part is the price of supporting that.
+func (s *S3Storage) put(ctx context.Context, objectKey string, body io.Reader, meta map[string]string) error {
+	buf := make([]byte, s.partSize)
+	n, err := io.ReadFull(body, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return fmt.Errorf("storage: failed to read upload: %w", err)
+	}
+	lockMode, lockUntil := s.lockFields()
+
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		_, putErr := s.client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:                    aws.String(s.cfg.Bucket),
+			Key:                       aws.String(objectKey),
+			Body:                      bytes.NewReader(buf[:n]),
+			ContentLength:             aws.Int64(int64(n)),
+			Metadata:                  meta,
+			ObjectLockMode:            lockMode,
+			ObjectLockRetainUntilDate: lockUntil,
+		})
+		if putErr != nil {
+			return fmt.Errorf("storage: failed to upload object: %w", putErr)
+		}
+		return nil
+	}
+
+	created, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:                    aws.String(s.cfg.Bucket),
+		Key:                       aws.String(objectKey),
+		Metadata:                  meta,
+		ObjectLockMode:            lockMode,
+		ObjectLockRetainUntilDate: lockUntil,
+	})
+	if err != nil {
+		return fmt.Errorf("storage: failed to start multipart upload: %w", err)
+	}
+	abort := func(cause error) error {
+		// Best-effort: an abandoned upload is billed storage until a lifecycle
+		// rule reaps it, so always try to abort, but report the original error.
+		_, _ = s.client.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+			Bucket: aws.String(s.cfg.Bucket), Key: aws.String(objectKey), UploadId: created.UploadId,
+		})
+		return cause
+	}
+
+	var parts []types.CompletedPart
+	for partNumber := int32(1); ; partNumber++ {
+		out, err := s.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:        aws.String(s.cfg.Bucket),
+			Key:           aws.String(objectKey),
+			UploadId:      created.UploadId,
+			PartNumber:    aws.Int32(partNumber),
+			Body:          bytes.NewReader(buf[:n]),
+			ContentLength: aws.Int64(int64(n)),
+		})
+		if err != nil {
+			return abort(fmt.Errorf("storage: failed to upload part %d: %w", partNumber, err))
+		}
+		parts = append(parts, types.CompletedPart{
+			ETag: out.ETag, PartNumber: aws.Int32(partNumber),
+			ChecksumCRC32: out.ChecksumCRC32, ChecksumCRC32C: out.ChecksumCRC32C,
+			ChecksumCRC64NVME: out.ChecksumCRC64NVME, ChecksumSHA1: out.ChecksumSHA1, ChecksumSHA256: out.ChecksumSHA256,
+		})
+
+		n, err = io.ReadFull(body, buf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return abort(fmt.Errorf("storage: failed to read upload: %w", err))
+		}
+	}
+
+	if _, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.cfg.Bucket),
+		Key:             aws.String(objectKey),
+		UploadId:        created.UploadId,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	}); err != nil {
+		return abort(fmt.Errorf("storage: failed to complete multipart upload: %w", err))
+	}
+	return nil
+}
+
+func (s *S3Storage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	tenantID, ok := tenantOf(key)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.cfg.Bucket),
+		Key:    aws.String(s.objectKey(key)),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("storage: failed to open object: %w", err)
+	}
+	if out.Metadata[metaEncryption] == "" {
+		return out.Body, nil
+	}
+	if s.keyring == nil {
+		// Serving ciphertext as if it were the file would hand an auditor a
+		// corrupt PDF with a 200. Refuse instead.
+		out.Body.Close()
+		return nil, errors.New("storage: object is encrypted but no master key is configured")
+	}
+	dk, err := s.keyring.DataKey(ctx, tenantID)
+	if err != nil {
+		out.Body.Close()
+		return nil, err
+	}
+	rc, err := NewDecryptReader(out.Body, dk)
+	if err != nil {
+		out.Body.Close()
+		return nil, err
+	}
+	return rc, nil
+}
+
+// Delete removes the object. On a bucket with Object Lock this only writes a
+// delete marker: the locked version stays until its retention lapses, which is
+// the whole point of WORM evidence — deleting the register row does not erase
+// the proof an auditor may still ask for.
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	if _, ok := tenantOf(key); !ok {
+		return nil
+	}
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.cfg.Bucket),
+		Key:    aws.String(s.objectKey(key)),
+	})
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("storage: failed to delete object: %w", err)
+	}
+	return nil
+}
+
+// PresignGet implements Presigner. Refused when envelope encryption is on: the
+// URL would serve ciphertext that only this server can decrypt.
+func (s *S3Storage) PresignGet(ctx context.Context, key, filename string, ttl time.Duration) (string, error) {
+	if s.keyring != nil {
+		return "", ErrPresignUnsupported
+	}
+	if _, ok := tenantOf(key); !ok {
+		return "", ErrNotFound
+	}
+	in := &s3.GetObjectInput{
+		Bucket: aws.String(s.cfg.Bucket),
+		Key:    aws.String(s.objectKey(key)),
+	}
+	if filename != "" {
+		in.ResponseContentDisposition = aws.String(mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	}
+	req, err := s.presign.PresignGetObject(ctx, in, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", fmt.Errorf("storage: failed to presign download: %w", err)
+	}
+	return req.URL, nil
+}
+
+// LoadDataKey implements DataKeyStore: wrapped keys live in the bucket, under
+// each tenant's prefix, never in the clear.
+func (s *S3Storage) LoadDataKey(ctx context.Context, tenantID uuid.UUID) (string, bool, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.cfg.Bucket),
+		Key:    aws.String(s.objectKey(tenantID.String() + "/" + dataKeyObject)),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	defer out.Body.Close()
+	raw, err := io.ReadAll(io.LimitReader(out.Body, 4096))
+	if err != nil {
+		return "", false, err
+	}
+	return strings.TrimSpace(string(raw)), true, nil
+}
+
+// StoreDataKey implements DataKeyStore with a conditional PUT (If-None-Match),
+// so the first replica to create a tenant's key wins and the rest adopt it.
+func (s *S3Storage) StoreDataKey(ctx context.Context, tenantID uuid.UUID, wrapped string) (bool, error) {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.cfg.Bucket),
+		Key:           aws.String(s.objectKey(tenantID.String() + "/" + dataKeyObject)),
+		Body:          strings.NewReader(wrapped),
+		ContentLength: aws.Int64(int64(len(wrapped))),
+		IfNoneMatch:   aws.String("*"),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && (apiErr.ErrorCode() == "PreconditionFailed" || apiErr.ErrorCode() == "ConditionalRequestConflict") {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func isNotFound(err error) bool {
+	var nsk *types.NoSuchKey
+	if errors.As(err, &nsk) {
+		return true
+	}
+	var nf *types.NotFound
+	if errors.As(err, &nf) {
+		return true
+	}
+	var respErr interface{ HTTPStatusCode() int }
+	return errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound
+}
@@ -14,6 +14,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -21,6 +22,12 @@ import (
 // ErrNotFound is returned by Open/Delete when the key does not exist.
 var ErrNotFound = errors.New("storage: key not found")
 
+// ErrPresignUnsupported is returned by PresignGet when the driver cannot hand
+// out a URL that serves the plaintext — either it has no notion of URLs at all,
+// or the bytes at rest are client-side encrypted and only the server can
+// decrypt them. Callers fall back to streaming through Open.
+var ErrPresignUnsupported = errors.New("storage: presigned URLs are not available for this object")
+
 // Storage is the port for persisting uploaded file content.
 // Save/Open/Delete operate on an opaque key — implementations decide their
 // own internal layout (local filesystem path, S3 object key, etc.).
@@ -38,3 +45,15 @@ type Storage interface {
 	// does not exist is not an error (idempotent).
 	Delete(ctx context.Context, key string) error
 }
+
+// Presigner is implemented by drivers that can hand a client a short-lived,
+// direct download URL instead of streaming the bytes through the API. It is an
+// optional capability: callers type-assert for it and must handle
+// ErrPresignUnsupported, because the same driver may refuse for some
+// configurations (envelope encryption) and not others.
+type Presigner interface {
+	// PresignGet returns a URL valid for ttl that downloads the object stored
+	// under key. filename, when set, becomes the Content-Disposition the
+	// browser saves the file as.
+	PresignGet(ctx context.Context, key, filename string, ttl time.Duration) (string, error)
+}
@@ -41,6 +41,13 @@ const CreateRiskModal = lazy(() => import('./features/risks/CreateRiskModal').th
 const AuthScreen = lazy(() => import('./features/auth/AuthScreen').then(m => ({ default: m.AuthScreen })));
 const StatusPage = lazy(() => import('./features/status/StatusPage').then(m => ({ default: m.StatusPage })));
 const AcceptInvitationPage = lazy(() => import('./features/organization/AcceptInvitationPage').then(m => ({ default: m.AcceptInvitationPage })));
+const VendorAssessmentPage = lazy(() => import('./features/vendors/VendorAssessmentPage').then(m => ({ default: m.VendorAssessmentPage })));
+const VendorsPage = lazy(() => import('./features/vendors/VendorsPage').then(m => ({ default: m.VendorsPage })));
+const BowtiePage = lazy(() => import('./features/bowtie/BowtiePage').then(m => ({ default: m.BowtiePage })));
+const GroupPage = lazy(() => import('./features/group/GroupPage').then(m => ({ default: m.GroupPage })));
+const RegisterSnapshotsPage = lazy(() => import('./features/registersnapshots/RegisterSnapshotsPage').then(m => ({ default: m.RegisterSnapshotsPage })));
+const ControlTestsPage = lazy(() => import('./features/controltests/ControlTestsPage').then(m => ({ default: m.ControlTestsPage })));
+const ScenariosPage = lazy(() => import('./features/scenarios/ScenariosPage').then(m => ({ default: m.ScenariosPage })));
 const ForgotPasswordScreen = lazy(() => import('./features/auth/ForgotPasswordScreen').then(m => ({ default: m.ForgotPasswordScreen })));
 const ResetPasswordScreen = lazy(() => import('./features/auth/ResetPasswordScreen').then(m => ({ default: m.ResetPasswordScreen })));
 
@@ -87,6 +94,9 @@ const RiskTimeline = lazy(() => import('./pages/RiskTimeline'));
 const AuditDetailPage = lazy(() => import('./features/compliance/AuditDetailPage').then(m => ({ default: m.AuditDetailPage })));
 const RemediationDetailPage = lazy(() => import('./features/compliance/RemediationDetailPage').then(m => ({ default: m.RemediationDetailPage })));
 const MitigationDetailPage = lazy(() => import('./features/mitigations/MitigationDetailPage').then(m => ({ default: m.MitigationDetailPage })));
+const ProgrammesPage = lazy(() => import('./features/programmes/ProgrammesPage').then(m => ({ default: m.ProgrammesPage })));
+const ImportsPage = lazy(() => import('./features/imports/ImportsPage').then(m => ({ default: m.ImportsPage })));
+const PluginsPage = lazy(() => import('./features/plugins/PluginsPage').then(m => ({ default: m.PluginsPage })));
 const ReportJobPage = lazy(() => import('./features/reports/ReportJobPage').then(m => ({ default: m.ReportJobPage })));
 const ScorePage = lazy(() => import('./features/score/ScorePage').then(m => ({ default: m.ScorePage })));
 
@@ -308,6 +318,9 @@ function App() {
             the invitation before asking for anything, and the server binds it
             to the invited address. */}
         <Route path="/invitations/accept" element={<AcceptInvitationPage />} />
+        {/* Vendor questionnaire link — answered by a supplier with no account;
+            the token in the link is the credential. */}
+        <Route path="/vendor-assessment" element={<VendorAssessmentPage />} />
 
         {/* Signup wizard — authenticated but OUTSIDE the app shell: the point of
             these five screens is that nothing else competes for attention. The
@@ -353,17 +366,22 @@ function App() {
               this is where it gets caught up. */}
           <Route path="risks/unmapped" element={<UnmappedRisksPage />} />
           <Route path="risks/weighting" element={<RiskWeightsSettings />} />
+          <Route path="risks/snapshots" element={<RegisterSnapshotsPage />} />
           <Route path="risks/:riskId/timeline" element={<RiskTimeline />} />
+          <Route path="risks/:riskId/bowtie" element={<BowtiePage />} />
           {/* Mitigations sit under Risks: a mitigation exists only to reduce a
               risk, so its detail has an unambiguous parent to return to. */}
           <Route path="risks/mitigations" element={<MitigationsBoard />} />
           <Route path="risks/mitigations/:mitigationId" element={<MitigationDetailPage />} />
+          <Route path="risks/programmes" element={<ProgrammesPage />} />
 
           {/* ---------------- Threats ---------------- */}
           <Route path="vulnerabilities" element={<VulnerabilitiesPage />} />
           <Route path="vulnerabilities/unassigned" element={<UnassignedVulnerabilitiesPage />} />
           <Route path="vulnerabilities/risk-rule" element={<RiskRulePage />} />
           <Route path="threat-map" element={<ThreatIntel />} />
+          <Route path="vendors" element={<VendorsPage />} />
+          <Route path="scenarios" element={<ScenariosPage />} />
           <Route path="ai/emerging-risks" element={<EmergingRisksPage />} />
           <Route path="simulations" element={<SimulationsPage />} />
 
@@ -385,6 +403,7 @@ function App() {
               outlives any one framework. */}
           <Route path="compliance/evidence" element={<EvidenceLibraryPage />} />
           <Route path="compliance/evidence/missing" element={<MissingEvidencePage />} />
+          <Route path="compliance/control-tests" element={<ControlTestsPage />} />
           <Route path="compliance/audits" element={<AuditsPage />} />
           <Route path="compliance/audits/:auditId" element={<AuditDetailPage />} />
           <Route path="compliance/remediation" element={<RemediationPage />} />
@@ -409,6 +428,7 @@ function App() {
               hero and the sidebar footer, so all three render one object. */}
           <Route path="score" element={<ScorePage />} />
           <Route path="analytics/financial" element={<FinancialDashboard />} />
+          <Route path="group" element={<GroupPage />} />
           <Route path="leaderboard" element={<LeaderboardPage />} />
 
           {/* ---------------- Reports ----------------
@@ -436,6 +456,8 @@ function App() {
           {/* Members owns invitations AND role assignment — one job, one screen.
               Splitting them is why "Invite a member" landed on Roles. */}
           <Route path="settings/members" element={<SettingsScreen />} />
+          <Route path="settings/imports" element={<ImportsPage />} />
+          <Route path="settings/plugins" element={<PluginsPage />} />
 
           {/* ---------------- Moves and legacy deep links ----------------
               Permanent client-side redirects. `replace` keeps the old URL out of
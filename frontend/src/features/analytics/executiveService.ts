@@ -86,6 +86,17 @@ export interface IncidentTrendPoint {
   high: number;
 }
 
+/** The register against the board's in-force appetite statements. */
+export interface AppetiteHeadline {
+  statements_in_force: number;
+  scopes_breached: number;
+  risks_above: number;
+  /** Risks above appetite with no valid exception — should be zero. */
+  without_exception: number;
+  pending_exceptions: number;
+  breached_scopes: string[];
+}
+
 /** The single consolidated executive dashboard payload. */
 export interface ExecutiveDashboard {
   generated_at: string;
@@ -94,6 +105,7 @@ export interface ExecutiveDashboard {
   cyber_score: CyberScore;
   financial: FinancialHeadline;
   kris: KRI[];
+  appetite?: AppetiteHeadline;
   top_risks: ExecRisk[];
   risk_trend: MonthlyRiskPoint[];
   risk_distribution: DistributionSlice[];
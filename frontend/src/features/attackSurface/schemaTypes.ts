@@ -26,6 +26,7 @@ export const ASSET_CATEGORIES: AssetCategory[] = [
   'cloud',
   'vendor',
   'data_processing',
+  'business_process',
 ];
 
 /** Every attribute type the schema editor can offer. */
@@ -61,6 +62,7 @@ export const CATEGORY_LABELS: Record<AssetCategory, string> = {
   cloud: 'Ressource cloud',
   vendor: 'Fournisseur / tiers',
   data_processing: 'Données / traitement',
+  business_process: 'Processus métier',
 };
 
 export const ATTRIBUTE_TYPE_LABELS: Record<AttributeType, string> = {
@@ -21,7 +21,7 @@ import { useEscapeToClose } from '../../shared/useBackTo';
 import { apiErrorMessage } from '../../lib/apiError';
 
 const TRIGGERS: AutomationTrigger[] = [
-  'vulnerability_detected', 'risk_score_updated', 'risk_created', 'incident_created', 'manual',
+  'vulnerability_detected', 'risk_score_updated', 'risk_created', 'incident_created', 'kri_threshold_crossed', 'manual',
 ];
 const ACTIONS: AutomationActionType[] = [
   'scan_asset', 'create_risk', 'assign_owner', 'create_ticket', 'notify', 'start_sla', 'resolve_risk',
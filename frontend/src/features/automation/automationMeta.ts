@@ -6,7 +6,7 @@
 
 import {
   Bug, ShieldAlert, Activity, Siren, Hand, Radar, FilePlus2, UserCheck,
-  Ticket, Bell, Timer, CheckCircle2, XCircle, type LucideIcon,
+  Ticket, Bell, Timer, CheckCircle2, XCircle, Gauge, type LucideIcon,
 } from 'lucide-react';
 import type {
   AutomationTrigger, AutomationActionType, NotifyChannel, ExecutionStatus, SLAStatus,
@@ -36,6 +36,11 @@ export const TRIGGER_META: Record<AutomationTrigger, { label: { fr: string; en:
     icon: Siren,
     hint: { fr: 'Un incident est déclaré', en: 'An incident is declared' },
   },
+  kri_threshold_crossed: {
+    label: { fr: 'Seuil de KRI franchi', en: 'KRI threshold crossed' },
+    icon: Gauge,
+    hint: { fr: 'Un indicateur de risque change de zone (vert / ambre / rouge)', en: 'A key risk indicator changes band (green / amber / red)' },
+  },
   manual: {
     label: { fr: 'Manuel', en: 'Manual' },
     icon: Hand,
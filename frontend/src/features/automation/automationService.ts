@@ -13,6 +13,7 @@ export type AutomationTrigger =
   | 'risk_created'
   | 'risk_score_updated'
   | 'incident_created'
+  | 'kri_threshold_crossed'
   | 'manual';
 
 export type AutomationActionType =
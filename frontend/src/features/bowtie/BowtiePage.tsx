@@ -0,0 +1,382 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+//
+// /risks/:riskId/bowtie — the risk's bowtie analysis.
+//
+// Threats on the left cross preventive barriers to reach the top event;
+// consequences on the right are reached through recovery barriers. A barrier
+// is linked to a control mapped to the risk and/or one of its mitigations,
+// and its colour is derived by the server from the control's tests and the
+// mitigation's status — it is never typed. When barriers are degraded the
+// server suggests a higher probability (preventive side) or impact (recovery
+// side), which a risk editor applies or not.
+
+import { useMemo, useState } from 'react';
+import { useNavigate, useParams } from 'react-router';
+import { useQuery } from '@tanstack/react-query';
+import { toast } from 'sonner';
+import { ArrowLeft, ArrowDown, ArrowUp, Download, Pencil, Plus, Trash2, TrendingUp, Workflow, X } from 'lucide-react';
+import { PageFrame, PageHeader, Card, Btn, SkeletonRows, EmptyState, ErrorState } from '../../shared/ui';
+import { useUIStore } from '../../store/uiStore';
+import { useAuthStore } from '../../hooks/useAuthStore';
+import { useRiskMappings } from '../risks/useTaxonomy';
+import { mitigationService } from '../../services/mitigationService';
+import { useApplyBowtieSuggestion, useBowtie, useDeleteBowtie, useSaveBowtie } from './useBowtie';
+import { healthColor, healthLabel } from './barrierHealth';
+import { bowtieService, type BarrierHealth, type BarrierKind, type Bowtie, type BowtieBarrier, type BowtieGraph, type BowtieNode, type BowtiePath, type SideHealth } from './bowtieService';
+
+type Tr = (fr: string, en: string) => string;
+
+const field = 'w-full rounded-[10px] border border-border bg-transparent px-3 py-2 text-[13px] text-ink';
+
+function apiMessage(err: unknown, fallback: string): string {
+  return (err as { response?: { data?: { error?: string } } })?.response?.data?.error || fallback;
+}
+
+// A short key for a path or barrier drawn in the editor, so barriers can
+// name their path before the server has seen either.
+const newKey = () => Math.random().toString(36).slice(2, 10);
+
+export function BowtiePage() {
+  const { riskId = '' } = useParams<{ riskId: string }>();
+  const navigate = useNavigate();
+  const lang = useUIStore((s) => s.lang);
+  const tr: Tr = (fr, en) => (lang === 'fr' ? fr : en);
+  const canEdit = useAuthStore((s) => s.hasPermission('risks:update'));
+  const [editing, setEditing] = useState(false);
+
+  const { data: graph, isLoading, isError, refetch } = useBowtie(riskId);
+  const apply = useApplyBowtieSuggestion(riskId);
+  const remove = useDeleteBowtie(riskId);
+
+  const applySuggestion = () => {
+    apply.mutate(undefined, {
+      onSuccess: (g) => toast.success(tr(
+        `Probabilité ${g.suggestion.current_probability.toFixed(2)}, impact ${g.suggestion.current_impact.toFixed(1)}`,
+        `Probability ${g.suggestion.current_probability.toFixed(2)}, impact ${g.suggestion.current_impact.toFixed(1)}`,
+      )),
+      onError: (err) => toast.error(apiMessage(err, tr('La suggestion n’a pas pu être appliquée.', 'Could not apply the suggestion.'))),
+    });
+  };
+  const deleteBowtie = () => {
+    if (!window.confirm(tr('Supprimer ce nœud papillon ?', 'Delete this bowtie?'))) return;
+    remove.mutate(undefined, {
+      onError: (err) => toast.error(apiMessage(err, tr('La suppression a échoué.', 'Delete failed.'))),
+    });
+  };
+  const downloadPDF = () => {
+    bowtieService.downloadPDF(riskId, lang === 'fr' ? 'fr' : 'en').catch((err) => toast.error(apiMessage(err, tr('Le PDF n’a pas pu être généré.', 'Could not render the PDF.'))));
+  };
+
+  const hasBowtie = !!graph?.bowtie;
+
+  return (
+    <PageFrame>
+      <PageHeader
+        title={graph?.risk_title ? `${tr('Nœud papillon', 'Bowtie')} — ${graph.risk_title}` : tr('Nœud papillon', 'Bowtie')}
+        actions={
+          <div className="flex gap-2 flex-wrap">
+            <Btn icon={ArrowLeft} label={tr('Registre', 'Register')} onClick={() => navigate(`/risks?focus=${riskId}`)} />
+            {hasBowtie && <Btn icon={Download} label="PDF" onClick={downloadPDF} />}
+            {hasBowtie && canEdit && <Btn icon={Pencil} label={tr('Modifier', 'Edit')} onClick={() => setEditing(true)} />}
+            {hasBowtie && canEdit && <Btn danger icon={Trash2} label={tr('Supprimer', 'Delete')} onClick={deleteBowtie} disabled={remove.isPending} />}
+          </div>
+        }
+      />
+
+      {isLoading ? (
+        <Card><SkeletonRows rows={6} /></Card>
+      ) : isError || !graph ? (
+        <ErrorState title={tr('Impossible de charger le nœud papillon.', 'Could not load the bowtie.')} onRetry={() => refetch()} retryLabel={tr('Réessayer', 'Retry')} />
+      ) : !graph.bowtie ? (
+        <Card>
+          <EmptyState
+            icon={Workflow}
+            title={tr('Aucun nœud papillon pour ce risque', 'No bowtie for this risk yet')}
+            description={tr(
+              'Décrivez les menaces, l’événement redouté, les conséquences et les barrières qui les séparent.',
+              'Describe the threats, the top event, the consequences and the barriers between them.',
+            )}
+            primaryAction={canEdit && <Btn primary icon={Plus} label={tr('Construire le nœud papillon', 'Build the bowtie')} onClick={() => setEditing(true)} />}
+          />
+        </Card>
+      ) : (
+        <>
+          <div className="grid gap-3 mb-4" style={{ gridTemplateColumns: 'repeat(auto-fit,minmax(240px,1fr))' }}>
+            <SideCard title={tr('Barrières de prévention', 'Preventive barriers')} side={graph.preventive} tr={tr} />
+            <SideCard title={tr('Barrières de protection', 'Recovery barriers')} side={graph.recovery} tr={tr} />
+            <SuggestionCard graph={graph} tr={tr} canApply={canEdit} applying={apply.isPending} onApply={applySuggestion} />
+          </div>
+          <Card style={{ padding: 12, overflowX: 'auto' }}>
+            <BowtieDiagram graph={graph} tr={tr} />
+          </Card>
+        </>
+      )}
+
+      {editing && graph && <BowtieEditor riskId={riskId} initial={graph.bowtie} onClose={() => setEditing(false)} tr={tr} />}
+    </PageFrame>
+  );
+}
+
+function SideCard({ title, side, tr }: { title: string; side: SideHealth; tr: Tr }) {
+  const rows: [BarrierHealth, number][] = [['healthy', side.healthy], ['degraded', side.degraded], ['failed', side.failed], ['unknown', side.unknown]];
+  return (
+    <Card>
+      <div className="text-[11.5px] uppercase tracking-wide text-ink-muted mb-2">{title}</div>
+      <div className="flex flex-wrap gap-3 text-[13px]">
+        {rows.map(([h, n]) => (
+          <span key={h} className="flex items-center gap-1.5">
+            <span className="inline-block w-2.5 h-2.5 rounded-sm" style={{ background: healthColor[h] }} />
+            <span className="text-ink-soft">{healthLabel(h, tr)}</span>
+            <span className="mono font-semibold text-ink">{n}</span>
+          </span>
+        ))}
+      </div>
+      <div className="text-[12px] text-ink-muted mt-2">{tr('Perte moyenne', 'Mean loss')} {Math.round(side.loss * 100)} %</div>
+    </Card>
+  );
+}
+
+function SuggestionCard({ graph, tr, canApply, applying, onApply }: { graph: BowtieGraph; tr: Tr; canApply: boolean; applying: boolean; onApply: () => void }) {
+  const s = graph.suggestion;
+  return (
+    <Card>
+      <div className="text-[11.5px] uppercase tracking-wide text-ink-muted mb-2">{tr('Suggestion', 'Suggestion')}</div>
+      {s.raises ? (
+        <>
+          <div className="text-[13px] text-ink">
+            {tr('Probabilité', 'Probability')} <span className="mono">{s.current_probability.toFixed(2)} → <b>{s.probability.toFixed(2)}</b></span>
+            {' · '}
+            {tr('Impact', 'Impact')} <span className="mono">{s.current_impact.toFixed(1)} → <b>{s.impact.toFixed(1)}</b></span>
+          </div>
+          {canApply && <div className="mt-2.5"><Btn primary icon={TrendingUp} label={tr('Appliquer au risque', 'Apply to the risk')} onClick={onApply} disabled={applying} /></div>}
+        </>
+      ) : (
+        <div className="text-[13px] text-ink-soft">
+          {tr('Les barrières n’appellent pas de probabilité ni d’impact plus élevés.', 'The barriers do not call for a higher probability or impact.')}
+        </div>
+      )}
+    </Card>
+  );
+}
+
+/* ---------------- diagram ---------------- */
+
+const W = 1000;
+const ROW = 74;
+const PATH_W = 150;
+const CENTRE_X = 440;
+const CENTRE_W = 120;
+const BAND_L: [number, number] = [PATH_W + 24, CENTRE_X - 24];
+const BAND_R: [number, number] = [CENTRE_X + CENTRE_W + 24, W - PATH_W - 24];
+
+function barrierTitle(n: BowtieNode, tr: Tr): string {
+  const lines = [n.label, healthLabel(n.health ?? 'unknown', tr)];
+  if (n.control_label || n.control_id) {
+    const eff = n.effectiveness != null ? ` — ${Math.round(n.effectiveness * 100)} %` : ` — ${tr('non testé', 'untested')}`;
+    lines.push(`${tr('Contrôle', 'Control')}: ${n.control_label || n.control_id}${eff}${n.test_overdue ? ` (${tr('test en retard', 'test overdue')})` : ''}`);
+  }
+  if (n.mitigation_id) lines.push(`${tr('Mitigation', 'Mitigation')}: ${n.mitigation_title || n.mitigation_id} (${n.mitigation_status || '—'})`);
+  return lines.join('\n');
+}
+
+function BowtieDiagram({ graph, tr }: { graph: BowtieGraph; tr: Tr }) {
+  const b = graph.bowtie!;
+  const byId = useMemo(() => new Map(graph.nodes.map((n) => [n.id, n])), [graph.nodes]);
+  const rows = Math.max(b.threats.length, b.consequences.length, 1);
+  const H = rows * ROW + 40;
+  const midY = H / 2;
+
+  const side = (paths: BowtiePath[], right: boolean) => {
+    const step = (H - 40) / Math.max(paths.length, 1);
+    const band = right ? BAND_R : BAND_L;
+    const pathX = right ? W - PATH_W : 0;
+    const joinX = right ? CENTRE_X + CENTRE_W : CENTRE_X;
+    return paths.map((p, i) => {
+      const y = 20 + step * (i + 0.5);
+      const barriers = b.barriers.filter((x) => x.path_id === p.id).sort((x, y2) => x.position - y2.position);
+      const slot = (band[1] - band[0]) / Math.max(barriers.length, 1);
+      const bw = Math.min(90, slot - 6);
+      const lineFrom = right ? band[0] : band[1];
+      return (
+        <g key={p.id}>
+          <line x1={right ? pathX : pathX + PATH_W} y1={y} x2={lineFrom} y2={y} stroke="var(--text-muted)" strokeWidth={1.2} />
+          <line x1={lineFrom} y1={y} x2={joinX} y2={midY} stroke="var(--text-muted)" strokeWidth={1.2} />
+          <rect x={pathX} y={y - 22} width={PATH_W} height={44} rx={8} fill="var(--bg-hover)" stroke="var(--border)">
+            <title>{p.description || p.label}</title>
+          </rect>
+          <foreignObject x={pathX + 6} y={y - 20} width={PATH_W - 12} height={40}>
+            <div className="h-full flex items-center justify-center text-center text-[11.5px] leading-tight text-ink overflow-hidden">{p.label}</div>
+          </foreignObject>
+          {barriers.map((br, j) => {
+            const n = byId.get(`barrier:${br.id}`);
+            const h = n?.health ?? 'unknown';
+            const x = band[0] + slot * j + (slot - bw) / 2;
+            return (
+              <g key={br.id}>
+                <rect x={x} y={y - 16} width={bw} height={32} rx={4} fill={healthColor[h]} stroke={healthColor[h]}>
+                  <title>{n ? barrierTitle(n, tr) : br.label}</title>
+                </rect>
+                <foreignObject x={x + 3} y={y - 15} width={bw - 6} height={30} style={{ pointerEvents: 'none' }}>
+                  <div className="h-full flex items-center justify-center text-center text-[10px] font-semibold leading-tight overflow-hidden" style={{ color: '#fff' }}>{br.label}</div>
+                </foreignObject>
+              </g>
+            );
+          })}
+        </g>
+      );
+    });
+  };
+
+  return (
+    <svg viewBox={`0 0 ${W} ${H}`} width="100%" style={{ minWidth: 760 }} role="img" aria-label={tr('Nœud papillon', 'Bowtie')}>
+      {side(b.threats, false)}
+      {side(b.consequences, true)}
+      <rect x={CENTRE_X} y={midY - 40} width={CENTRE_W} height={80} rx={12} fill="var(--accent)">
+        <title>{b.hazard ? `${tr('Danger', 'Hazard')}: ${b.hazard}` : b.top_event}</title>
+      </rect>
+      <foreignObject x={CENTRE_X + 6} y={midY - 36} width={CENTRE_W - 12} height={72}>
+        <div className="h-full flex flex-col items-center justify-center text-center leading-tight overflow-hidden" style={{ color: '#fff' }}>
+          <div className="text-[9.5px] uppercase tracking-wide opacity-80">{tr('Événement redouté', 'Top event')}</div>
+          <div className="text-[12px] font-bold">{b.top_event}</div>
+        </div>
+      </foreignObject>
+    </svg>
+  );
+}
+
+/* ---------------- editor ---------------- */
+
+function Overlay({ children, onClose }: { children: React.ReactNode; onClose: () => void }) {
+  return (
+    <div className="fixed inset-0 z-[80] flex justify-end" style={{ background: 'var(--surface-overlay)' }} onClick={onClose}>
+      <div
+        onClick={(e) => e.stopPropagation()}
+        className="h-full w-full max-w-[720px] overflow-y-auto p-5"
+        style={{ background: 'var(--bg-elevated)', borderLeft: '1px solid var(--border)' }}
+      >
+        {children}
+      </div>
+    </div>
+  );
+}
+
+const emptyBowtie = (): Bowtie => ({ hazard: '', top_event: '', threats: [], consequences: [], barriers: [] });
+
+function BowtieEditor({ riskId, initial, onClose, tr }: { riskId: string; initial: Bowtie | null; onClose: () => void; tr: Tr }) {
+  const save = useSaveBowtie(riskId);
+  const [draft, setDraft] = useState<Bowtie>(() => (initial ? structuredClone(initial) : emptyBowtie()));
+
+  // What a barrier can be linked to: the controls mapped to this risk and its
+  // mitigations. The server checks the links again.
+  const { data: mappings } = useRiskMappings(riskId);
+  const { data: mitigations } = useQuery({
+    queryKey: ['mitigations', 'risk', riskId],
+    queryFn: async () => (await mitigationService.listMitigations({ risk_id: riskId, page: 1, per_page: 200 })).items ?? [],
+  });
+  const controls = (mappings ?? []).filter((m) => m.control_id);
+
+  const setPaths = (kind: 'threats' | 'consequences', paths: BowtiePath[]) => setDraft((d) => ({ ...d, [kind]: paths }));
+  const addPath = (kind: 'threats' | 'consequences') => setPaths(kind, [...draft[kind], { id: newKey(), label: '' }]);
+  const removePath = (kind: 'threats' | 'consequences', id: string) =>
+    setDraft((d) => ({ ...d, [kind]: d[kind].filter((p) => p.id !== id), barriers: d.barriers.filter((b) => b.path_id !== id) }));
+
+  const barriersOn = (pathId: string) => draft.barriers.filter((b) => b.path_id === pathId).sort((a, b) => a.position - b.position);
+  const setBarriers = (pathId: string, list: BowtieBarrier[]) =>
+    setDraft((d) => ({ ...d, barriers: [...d.barriers.filter((b) => b.path_id !== pathId), ...list.map((b, i) => ({ ...b, position: i }))] }));
+  const updateBarrier = (pathId: string, id: string, patch: Partial<BowtieBarrier>) =>
+    setBarriers(pathId, barriersOn(pathId).map((b) => (b.id === id ? { ...b, ...patch } : b)));
+  const moveBarrier = (pathId: string, index: number, delta: number) => {
+    const list = barriersOn(pathId);
+    const j = index + delta;
+    if (j < 0 || j >= list.length) return;
+    [list[index], list[j]] = [list[j], list[index]];
+    setBarriers(pathId, list);
+  };
+
+  const submit = () => {
+    save.mutate(draft, {
+      onSuccess: () => {
+        toast.success(tr('Nœud papillon enregistré', 'Bowtie saved'));
+        onClose();
+      },
+      onError: (err) => toast.error(apiMessage(err, tr('L’enregistrement a échoué.', 'Save failed.'))),
+    });
+  };
+
+  const pathBlock = (kind: 'threats' | 'consequences', barrierKind: BarrierKind) => (
+    <div className="space-y-3">
+      {draft[kind].map((p) => (
+        <div key={p.id} className="rounded-[12px] p-3" style={{ border: '1px solid var(--border)' }}>
+          <div className="flex gap-2 mb-2">
+            <input
+              className={field}
+              maxLength={255}
+              placeholder={kind === 'threats' ? tr('Menace (ex. hameçonnage)', 'Threat (e.g. phishing)') : tr('Conséquence (ex. perte financière)', 'Consequence (e.g. financial loss)')}
+              value={p.label}
+              onChange={(e) => setPaths(kind, draft[kind].map((x) => (x.id === p.id ? { ...x, label: e.target.value } : x)))}
+            />
+            <button type="button" onClick={() => removePath(kind, p.id)} aria-label={tr('Retirer', 'Remove')} className="text-ink-soft"><Trash2 size={16} /></button>
+          </div>
+          {barriersOn(p.id).map((b, i, list) => (
+            <div key={b.id} className="flex flex-wrap items-center gap-1.5 mb-1.5 pl-3">
+              <input className={`${field} flex-1 min-w-[140px]`} maxLength={255} placeholder={tr('Barrière', 'Barrier')} value={b.label} onChange={(e) => updateBarrier(p.id, b.id, { label: e.target.value })} />
+              <select className={`${field} w-[150px]`} value={b.control_id ?? ''} onChange={(e) => updateBarrier(p.id, b.id, { control_id: e.target.value || null })}>
+                <option value="">{tr('— Contrôle —', '— Control —')}</option>
+                {controls.map((m) => (
+                  <option key={m.id} value={m.control_id!}>{[m.control_code, m.control_name].filter(Boolean).join(' ') || m.control_id}</option>
+                ))}
+              </select>
+              <select className={`${field} w-[150px]`} value={b.mitigation_id ?? ''} onChange={(e) => updateBarrier(p.id, b.id, { mitigation_id: e.target.value || null })}>
+                <option value="">{tr('— Mitigation —', '— Mitigation —')}</option>
+                {(mitigations ?? []).map((m) => <option key={m.id} value={m.id}>{m.title}</option>)}
+              </select>
+              <button type="button" disabled={i === 0} onClick={() => moveBarrier(p.id, i, -1)} aria-label={tr('Monter', 'Move up')} className="text-ink-soft disabled:opacity-30"><ArrowUp size={14} /></button>
+              <button type="button" disabled={i === list.length - 1} onClick={() => moveBarrier(p.id, i, 1)} aria-label={tr('Descendre', 'Move down')} className="text-ink-soft disabled:opacity-30"><ArrowDown size={14} /></button>
+              <button type="button" onClick={() => setBarriers(p.id, list.filter((x) => x.id !== b.id))} aria-label={tr('Retirer', 'Remove')} className="text-ink-soft"><X size={14} /></button>
+            </div>
+          ))}
+          <button
+            type="button"
+            className="ml-3 text-[12px] text-accent"
+            onClick={() => setBarriers(p.id, [...barriersOn(p.id), { id: newKey(), kind: barrierKind, path_id: p.id, label: '', position: 0 }])}
+          >
+            + {barrierKind === 'preventive' ? tr('Barrière de prévention', 'Preventive barrier') : tr('Barrière de protection', 'Recovery barrier')}
+          </button>
+        </div>
+      ))}
+      <Btn icon={Plus} label={kind === 'threats' ? tr('Ajouter une menace', 'Add a threat') : tr('Ajouter une conséquence', 'Add a consequence')} onClick={() => addPath(kind)} />
+    </div>
+  );
+
+  return (
+    <Overlay onClose={onClose}>
+      <div className="mb-4 flex items-start justify-between">
+        <h2 className="text-[16px] font-bold text-ink">{initial ? tr('Modifier le nœud papillon', 'Edit the bowtie') : tr('Construire le nœud papillon', 'Build the bowtie')}</h2>
+        <button type="button" onClick={onClose} aria-label={tr('Fermer', 'Close')}><X size={18} /></button>
+      </div>
+      <div className="space-y-4 text-[13px]">
+        <label className="block">
+          <span className="text-ink-soft">{tr('Danger (ce qui est maîtrisé)', 'Hazard (what is being controlled)')}</span>
+          <input className={field} maxLength={255} value={draft.hazard} onChange={(e) => setDraft({ ...draft, hazard: e.target.value })} />
+        </label>
+        <label className="block">
+          <span className="text-ink-soft">{tr('Événement redouté (la perte de maîtrise)', 'Top event (the loss of control)')} *</span>
+          <input className={field} maxLength={255} value={draft.top_event} onChange={(e) => setDraft({ ...draft, top_event: e.target.value })} />
+        </label>
+        <div>
+          <h3 className="text-[13px] font-semibold text-ink mb-2">{tr('Menaces et barrières de prévention', 'Threats and preventive barriers')}</h3>
+          {pathBlock('threats', 'preventive')}
+        </div>
+        <div>
+          <h3 className="text-[13px] font-semibold text-ink mb-2">{tr('Conséquences et barrières de protection', 'Consequences and recovery barriers')}</h3>
+          {pathBlock('consequences', 'recovery')}
+        </div>
+        <div className="flex justify-end gap-2 pt-2">
+          <Btn label={tr('Annuler', 'Cancel')} onClick={onClose} />
+          <Btn primary label={tr('Enregistrer', 'Save')} onClick={submit} disabled={save.isPending || !draft.top_event.trim()} />
+        </div>
+      </div>
+    </Overlay>
+  );
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+//
+// Barrier health colours and labels, shared by the bowtie page and the risk
+// drawer's bowtie tab (which must not pull the lazily-loaded page in).
+
+import type { BarrierHealth } from './bowtieService';
+
+export const healthColor: Record<BarrierHealth, string> = {
+  healthy: 'var(--success)',
+  degraded: 'var(--warning)',
+  failed: 'var(--critical)',
+  unknown: 'var(--text-muted)',
+};
+
+export function healthLabel(h: BarrierHealth, tr: (fr: string, en: string) => string): string {
+  switch (h) {
+    case 'healthy': return tr('Efficace', 'Healthy');
+    case 'degraded': return tr('Dégradée', 'Degraded');
+    case 'failed': return tr('Défaillante', 'Failed');
+    default: return tr('Sans preuve', 'No evidence');
+  }
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+//
+// Typed client for a risk's bowtie. Mirrors domain.Bowtie and the
+// application/bowtie graph.
+
+import { api } from '../../lib/api';
+
+export type BarrierKind = 'preventive' | 'recovery';
+export type BarrierHealth = 'healthy' | 'degraded' | 'failed' | 'unknown';
+export type BowtieNodeKind = 'threat' | 'preventive_barrier' | 'top_event' | 'recovery_barrier' | 'consequence';
+
+export interface BowtiePath {
+  id: string;
+  label: string;
+  description?: string;
+}
+
+export interface BowtieBarrier {
+  id: string;
+  kind: BarrierKind;
+  /** A threat for a preventive barrier, a consequence for a recovery one. */
+  path_id: string;
+  label: string;
+  position: number;
+  control_id?: string | null;
+  mitigation_id?: string | null;
+}
+
+export interface Bowtie {
+  id?: string;
+  risk_id?: string;
+  hazard: string;
+  top_event: string;
+  threats: BowtiePath[];
+  consequences: BowtiePath[];
+  barriers: BowtieBarrier[];
+  applied_preventive_loss?: number;
+  applied_recovery_loss?: number;
+  updated_at?: string;
+}
+
+export interface BowtieNode {
+  id: string;
+  kind: BowtieNodeKind;
+  label: string;
+  description?: string;
+  path_id?: string;
+  health?: BarrierHealth;
+  control_id?: string;
+  control_label?: string;
+  /** 0..1 — the control's tested effectiveness, absent when untested. */
+  effectiveness?: number;
+  test_overdue?: boolean;
+  mitigation_id?: string;
+  mitigation_title?: string;
+  mitigation_status?: string;
+}
+
+export interface SideHealth {
+  healthy: number;
+  degraded: number;
+  failed: number;
+  unknown: number;
+  /** Mean barrier loss, 0 (intact) to 1 (all failed). */
+  loss: number;
+}
+
+export interface BowtieGraph {
+  risk_id: string;
+  risk_title: string;
+  bowtie: Bowtie | null;
+  nodes: BowtieNode[];
+  edges: { from: string; to: string }[];
+  preventive: SideHealth;
+  recovery: SideHealth;
+  suggestion: {
+    current_probability: number;
+    current_impact: number;
+    probability: number;
+    impact: number;
+    raises: boolean;
+  };
+}
+
+export const bowtieService = {
+  get: async (riskId: string): Promise<BowtieGraph> => {
+    const res = await api.get<BowtieGraph>(`/risks/${riskId}/bowtie`);
+    return res.data;
+  },
+
+  save: async (riskId: string, bowtie: Bowtie): Promise<BowtieGraph> => {
+    const res = await api.put<BowtieGraph>(`/risks/${riskId}/bowtie`, bowtie);
+    return res.data;
+  },
+
+  remove: async (riskId: string): Promise<void> => {
+    await api.delete(`/risks/${riskId}/bowtie`);
+  },
+
+  applySuggestion: async (riskId: string): Promise<BowtieGraph> => {
+    const res = await api.post<BowtieGraph>(`/risks/${riskId}/bowtie/apply-suggestion`);
+    return res.data;
+  },
+
+  // downloadPDF fetches the figure and triggers a browser download, honoring
+  // the server's Content-Disposition filename.
+  downloadPDF: async (riskId: string, locale: 'fr' | 'en'): Promise<void> => {
+    const response = await api.get(`/risks/${riskId}/bowtie/pdf`, { params: { locale }, responseType: 'blob' });
+
+    let filename = 'bowtie.pdf';
+    const disposition = response.headers?.['content-disposition'] as string | undefined;
+    const match = disposition?.match(/filename\*?=(?:UTF-8'')?"?([^";]+)"?/i);
+    if (match?.[1]) filename = decodeURIComponent(match[1]);
+
+    const url = URL.createObjectURL(response.data as Blob);
+    const link = document.createElement('a');
+    link.href = url;
+    link.download = filename;
+    link.click();
+    URL.revokeObjectURL(url);
+  },
+};
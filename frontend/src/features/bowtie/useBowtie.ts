@@ -0,0 +1,46 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { bowtieService, type Bowtie } from './bowtieService';
+
+const key = (riskId: string) => ['bowtie', riskId];
+
+export function useBowtie(riskId: string | undefined) {
+  return useQuery({
+    queryKey: key(riskId ?? ''),
+    queryFn: () => bowtieService.get(riskId!),
+    enabled: !!riskId,
+  });
+}
+
+export function useSaveBowtie(riskId: string) {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: (b: Bowtie) => bowtieService.save(riskId, b),
+    onSuccess: (g) => qc.setQueryData(key(riskId), g),
+  });
+}
+
+export function useDeleteBowtie(riskId: string) {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: () => bowtieService.remove(riskId),
+    onSuccess: () => qc.invalidateQueries({ queryKey: key(riskId) }),
+  });
+}
+
+/** Applying the suggestion rewrites the risk's probability and impact, so the
+ *  register's caches go stale along with the bowtie. */
+export function useApplyBowtieSuggestion(riskId: string) {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: () => bowtieService.applySuggestion(riskId),
+    onSuccess: (g) => {
+      qc.setQueryData(key(riskId), g);
+      qc.invalidateQueries({ queryKey: ['risks'] });
+    },
+  });
+}
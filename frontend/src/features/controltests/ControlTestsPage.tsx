@@ -0,0 +1,209 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+//
+// /compliance/control-tests — the testers' worklist.
+//
+// Every plan whose next test date has passed, oldest first. A new plan is due
+// at once: until its control has been tested it earns no credit in the
+// residual of the risks mapped to it, so the list is also the backlog of
+// controls the register is not yet allowed to rely on.
+//
+// Recording a result moves the plan on and re-derives those residuals; the
+// dialog then shows which risks moved and by how much. A failed test raises
+// them and notifies each risk's owner server-side.
+
+import { useState } from 'react';
+import { useNavigate } from 'react-router';
+import { toast } from 'sonner';
+import { ClipboardCheck, X } from 'lucide-react';
+import { PageFrame, PageHeader, Card, Btn, SkeletonRows, EmptyState, ErrorState } from '../../shared/ui';
+import { useUIStore } from '../../store/uiStore';
+import { useAuthStore } from '../../hooks/useAuthStore';
+import { useDueControlTests, useRecordControlTest } from './useControlTests';
+import type { ControlTestKind, ControlTestPlan, ControlTestResult, TestOutcome } from './controlTestService';
+
+type Tr = (fr: string, en: string) => string;
+
+const field = 'w-full rounded-[10px] border border-border bg-transparent px-3 py-2 text-[13px] text-ink';
+
+const RESULT_COLOR: Record<ControlTestResult, string> = {
+  pass: 'var(--low)',
+  exceptions: 'var(--medium)',
+  fail: 'var(--critical)',
+};
+
+function resultLabel(r: ControlTestResult, tr: Tr): string {
+  switch (r) {
+    case 'pass': return tr('Conforme', 'Pass');
+    case 'exceptions': return tr('Avec exceptions', 'Exceptions');
+    default: return tr('Échec', 'Fail');
+  }
+}
+
+function kindLabel(k: ControlTestKind, tr: Tr): string {
+  return k === 'design' ? tr('Conception', 'Design') : tr('Fonctionnement', 'Operating');
+}
+
+function apiMessage(err: unknown, fallback: string): string {
+  return (err as { response?: { data?: { error?: string } } })?.response?.data?.error || fallback;
+}
+
+const pct = (v: number) => `${Math.round(v * 100)} %`;
+
+export function ControlTestsPage() {
+  const lang = useUIStore((s) => s.lang);
+  const tr: Tr = (fr, en) => (lang === 'fr' ? fr : en);
+  const canTest = useAuthStore((s) => s.hasPermission('compliance:controls:update'));
+  const [testing, setTesting] = useState<ControlTestPlan | null>(null);
+
+  const { data: plans, isLoading, isError, refetch } = useDueControlTests();
+  const fmtDate = (d?: string) => (d ? new Date(d).toLocaleDateString(lang === 'fr' ? 'fr-FR' : 'en-GB') : '—');
+
+  return (
+    <PageFrame>
+      <PageHeader title={tr('Tests de contrôles', 'Control tests')} count={plans?.length ? String(plans.length) : null} />
+
+      {isLoading ? (
+        <Card><SkeletonRows rows={5} /></Card>
+      ) : isError ? (
+        <ErrorState title={tr('Impossible de charger les tests à réaliser.', 'Could not load due tests.')} onRetry={() => refetch()} retryLabel={tr('Réessayer', 'Retry')} />
+      ) : !plans?.length ? (
+        <Card>
+          <EmptyState icon={ClipboardCheck} title={tr('Aucun test en retard', 'No tests due')} />
+        </Card>
+      ) : (
+        <Card style={{ padding: 0, overflow: 'hidden' }}>
+          <table className="w-full text-[13px]">
+            <thead>
+              <tr className="border-b border-border text-left text-[11.5px] uppercase tracking-wide text-ink-muted">
+                <th className="px-4 py-2.5">{tr('Contrôle', 'Control')}</th>
+                <th className="px-4 py-2.5">{tr('Type', 'Kind')}</th>
+                <th className="px-4 py-2.5">{tr('Échéance', 'Due')}</th>
+                <th className="px-4 py-2.5">{tr('Dernier résultat', 'Last result')}</th>
+                <th className="px-4 py-2.5" />
+              </tr>
+            </thead>
+            <tbody>
+              {plans.map((p) => (
+                <tr key={p.id} className="border-b border-border align-top last:border-0">
+                  <td className="px-4 py-2.5">
+                    <div className="font-mono text-[11.5px] text-ink-muted">{p.control_id.slice(0, 8)}</div>
+                    <div className="text-ink-soft">{p.procedure || tr('Aucune procédure décrite', 'No procedure described')}</div>
+                  </td>
+                  <td className="px-4 py-2.5 text-ink-soft">{kindLabel(p.kind, tr)} · {p.sample_size} {tr('éch.', 'samples')}</td>
+                  <td className="px-4 py-2.5 text-ink-soft">{fmtDate(p.next_test_at)}</td>
+                  <td className="px-4 py-2.5">
+                    {p.last_result ? (
+                      <span style={{ color: RESULT_COLOR[p.last_result] }}>
+                        {resultLabel(p.last_result, tr)}{p.last_effectiveness != null && ` · ${pct(p.last_effectiveness)}`}
+                      </span>
+                    ) : (
+                      <span className="text-ink-muted">{tr('Jamais testé', 'Never tested')}</span>
+                    )}
+                  </td>
+                  <td className="px-4 py-2.5 text-right">
+                    {canTest && <Btn primary icon={ClipboardCheck} label={tr('Tester', 'Test')} onClick={() => setTesting(p)} />}
+                  </td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        </Card>
+      )}
+
+      {testing && <RecordDialog plan={testing} onClose={() => setTesting(null)} tr={tr} />}
+    </PageFrame>
+  );
+}
+
+function Overlay({ children, onClose }: { children: React.ReactNode; onClose: () => void }) {
+  return (
+    <div className="fixed inset-0 z-[80] flex justify-end" style={{ background: 'var(--surface-overlay)' }} onClick={onClose}>
+      <div
+        onClick={(e) => e.stopPropagation()}
+        className="h-full w-full max-w-[520px] overflow-y-auto p-5"
+        style={{ background: 'var(--bg-elevated)', borderLeft: '1px solid var(--border)' }}
+      >
+        {children}
+      </div>
+    </div>
+  );
+}
+
+function RecordDialog({ plan, onClose, tr }: { plan: ControlTestPlan; onClose: () => void; tr: Tr }) {
+  const navigate = useNavigate();
+  const record = useRecordControlTest();
+  const [result, setResult] = useState<ControlTestResult>('pass');
+  const [samples, setSamples] = useState(plan.sample_size);
+  const [exceptions, setExceptions] = useState(0);
+  const [notes, setNotes] = useState('');
+  const [outcome, setOutcome] = useState<TestOutcome | null>(null);
+
+  const submit = () => {
+    record.mutate({ planId: plan.id, input: { result, samples_tested: samples, exceptions, notes } }, {
+      onSuccess: (res) => {
+        setOutcome(res);
+        toast.success(tr('Test enregistré', 'Test recorded'));
+      },
+      onError: (err) => toast.error(apiMessage(err, tr("L'enregistrement a échoué.", 'Recording failed.'))),
+    });
+  };
+
+  return (
+    <Overlay onClose={onClose}>
+      <div className="mb-4 flex items-start justify-between">
+        <div>
+          <h2 className="text-[16px] font-bold text-ink">{tr('Test de', 'Test of')} {kindLabel(plan.kind, tr).toLowerCase()}</h2>
+          {plan.procedure && <div className="text-[12.5px] text-ink-muted">{plan.procedure}</div>}
+        </div>
+        <button type="button" onClick={onClose} aria-label={tr('Fermer', 'Close')}><X size={18} /></button>
+      </div>
+
+      {outcome ? (
+        <div className="space-y-3 text-[12.5px]">
+          <p className="text-ink-soft">
+            {tr('Efficacité du contrôle', 'Control effectiveness')} : <span className="font-semibold text-ink">{pct(outcome.effectiveness.value)}</span>
+          </p>
+          {outcome.risks.length === 0 ? (
+            <p className="text-ink-muted">{tr('Aucun risque associé à ce contrôle.', 'No risk is mapped to this control.')}</p>
+          ) : outcome.risks.map((r) => (
+            <div key={r.risk_id} className="flex items-center justify-between rounded-[10px] border border-border p-2.5">
+              <button type="button" className="text-left text-accent underline" onClick={() => navigate(`/risks?focus=${r.risk_id}`)}>{r.title}</button>
+              <span style={{ color: r.before != null && r.after > r.before ? 'var(--critical)' : 'var(--ink-soft)' }}>
+                {r.before != null ? r.before.toFixed(2) : '—'} → {r.after.toFixed(2)}
+              </span>
+            </div>
+          ))}
+          {outcome.notified > 0 && (
+            <p className="text-ink-soft">{tr(`${outcome.notified} responsable(s) de risque notifié(s).`, `${outcome.notified} risk owner(s) notified.`)}</p>
+          )}
+          <div className="flex justify-end"><Btn label={tr('Fermer', 'Close')} onClick={onClose} /></div>
+        </div>
+      ) : (
+        <div className="space-y-3 text-[13px]">
+          <label className="block">
+            <span className="mb-1 block text-[12px] text-ink-muted">{tr('Résultat', 'Result')}</span>
+            <select className={field} value={result} onChange={(e) => setResult(e.target.value as ControlTestResult)}>
+              {(['pass', 'exceptions', 'fail'] as ControlTestResult[]).map((r) => <option key={r} value={r}>{resultLabel(r, tr)}</option>)}
+            </select>
+          </label>
+          <div className="grid grid-cols-2 gap-2">
+            <label className="block">
+              <span className="mb-1 block text-[12px] text-ink-muted">{tr('Échantillons testés', 'Samples tested')}</span>
+              <input className={field} type="number" min={1} value={samples} onChange={(e) => setSamples(Number(e.target.value))} />
+            </label>
+            <label className="block">
+              <span className="mb-1 block text-[12px] text-ink-muted">{tr('Exceptions', 'Exceptions')}</span>
+              <input className={field} type="number" min={0} max={samples} value={exceptions} onChange={(e) => setExceptions(Number(e.target.value))} />
+            </label>
+          </div>
+          <textarea className={field} rows={3} placeholder={tr('Constats', 'Findings')} value={notes} onChange={(e) => setNotes(e.target.value)} />
+          <div className="flex justify-end gap-2">
+            <Btn label={tr('Annuler', 'Cancel')} onClick={onClose} />
+            <Btn primary icon={ClipboardCheck} label={tr('Enregistrer', 'Record')} onClick={submit} disabled={record.isPending || samples < 1} />
+          </div>
+        </div>
+      )}
+    </Overlay>
+  );
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+//
+// Typed client for control testing. Mirrors domain.ControlTestPlan /
+// domain.ControlTest and the application/controltest views (outcome, residual
+// derivation).
+
+import { api } from '../../lib/api';
+
+export type ControlTestKind = 'design' | 'operating';
+export type ControlTestResult = 'pass' | 'exceptions' | 'fail';
+
+export interface ControlTestPlan {
+  id: string;
+  control_id: string;
+  kind: ControlTestKind;
+  procedure: string;
+  sample_size: number;
+  frequency_days: number;
+  tester_id?: string;
+  next_test_at: string;
+  last_tested_at?: string;
+  last_result?: ControlTestResult;
+  last_effectiveness?: number;
+  due: boolean;
+}
+
+export interface ControlTest {
+  id: string;
+  plan_id: string;
+  control_id: string;
+  kind: ControlTestKind;
+  tester_id?: string;
+  performed_at: string;
+  samples_tested: number;
+  exceptions: number;
+  result: ControlTestResult;
+  /** 0..1 — share of samples in which the control worked. */
+  effectiveness: number;
+  notes: string;
+}
+
+export interface ControlEffectiveness {
+  design: number | null;
+  operating: number | null;
+  value: number;
+  tested: boolean;
+}
+
+export interface ControlTesting {
+  control_id: string;
+  plans: ControlTestPlan[];
+  tests: ControlTest[];
+  effectiveness: ControlEffectiveness;
+}
+
+export interface PlanInput {
+  kind: ControlTestKind;
+  procedure?: string;
+  sample_size?: number;
+  frequency_days?: number;
+  tester_id?: string | null;
+  next_test_at?: string | null;
+}
+
+export interface TestInput {
+  result: ControlTestResult;
+  samples_tested?: number;
+  exceptions?: number;
+  performed_at?: string;
+  notes?: string;
+}
+
+export interface ResidualChange {
+  risk_id: string;
+  title: string;
+  before: number | null;
+  after: number;
+  mitigation: number;
+}
+
+export interface TestOutcome {
+  test: ControlTest;
+  plan: ControlTestPlan;
+  effectiveness: ControlEffectiveness;
+  risks: ResidualChange[];
+  notified: number;
+}
+
+export interface ResidualDerivation {
+  risk_id: string;
+  inherent: number;
+  controls: (ControlEffectiveness & { control_id: string; framework_id: string; reference_code: string; name: string; status: string })[];
+  mitigation: number;
+  residual: number;
+  current: number | null;
+  source: '' | 'controls';
+}
+
+export const controlTestService = {
+  due: async (): Promise<ControlTestPlan[]> => {
+    const res = await api.get<ControlTestPlan[]>('/control-test-plans/due');
+    return res.data ?? [];
+  },
+
+  testing: async (controlId: string): Promise<ControlTesting> => {
+    const res = await api.get<ControlTesting>(`/compliance/controls/${controlId}/testing`);
+    return res.data;
+  },
+
+  createPlan: async (controlId: string, input: PlanInput): Promise<ControlTestPlan> => {
+    const res = await api.post<ControlTestPlan>(`/compliance/controls/${controlId}/test-plans`, input);
+    return res.data;
+  },
+
+  removePlan: async (id: string): Promise<void> => {
+    await api.delete(`/control-test-plans/${id}`);
+  },
+
+  record: async (planId: string, input: TestInput): Promise<TestOutcome> => {
+    const res = await api.post<TestOutcome>(`/control-test-plans/${planId}/tests`, input);
+    return res.data;
+  },
+
+  residual: async (riskId: string): Promise<ResidualDerivation> => {
+    const res = await api.get<ResidualDerivation>(`/risks/${riskId}/residual`);
+    return res.data;
+  },
+};
@@ -0,0 +1,32 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { controlTestService, type TestInput } from './controlTestService';
+
+export function useDueControlTests() {
+  return useQuery({ queryKey: ['control-tests', 'due'], queryFn: () => controlTestService.due() });
+}
+
+export function useControlTesting(controlId: string | undefined) {
+  return useQuery({
+    queryKey: ['control-tests', 'control', controlId],
+    queryFn: () => controlTestService.testing(controlId!),
+    enabled: !!controlId,
+  });
+}
+
+/** A test re-derives the residual of every mapped risk, so the register's
+ *  caches go stale along with the worklist. */
+export function useRecordControlTest() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: ({ planId, input }: { planId: string; input: TestInput }) => controlTestService.record(planId, input),
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ['control-tests'] });
+      qc.invalidateQueries({ queryKey: ['risks'] });
+    },
+  });
+}
@@ -0,0 +1,469 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+//
+// /group — the holding's view of its subsidiaries.
+//
+// Every figure here is read from the current organization and the
+// subsidiaries below it that accepted the link: the consolidated heatmap and
+// category totals, the portfolio Monte Carlo across entities and the
+// consolidated compliance posture. A heatmap cell, a category or an entity
+// opens the read-only drill-down.
+//
+// The "Liens" tab is where a holding asks an organization to join and where a
+// subsidiary accepts (or later revokes) — consent is given from inside the
+// subsidiary, never on its behalf.
+
+import { useState } from 'react';
+import { toast } from 'sonner';
+import { Building2, Check, Link2, Network, Unlink, X } from 'lucide-react';
+import { PageFrame, PageHeader, Card, Btn, Chip, SkeletonRows, EmptyState, ErrorState } from '../../shared/ui';
+import { useUIStore } from '../../store/uiStore';
+import { useAuthStore } from '../../hooks/useAuthStore';
+import {
+  useAcceptGroupLink, useGroupCategories, useGroupCompliance, useGroupEntities, useGroupHeatmap,
+  useGroupLinks, useGroupMonteCarlo, useGroupRisks, useRequestGroupLink, useRevokeGroupLink,
+} from './useGroup';
+import type { Amount, GroupEntity, GroupHeatmapCell, GroupRiskFilter, OrganizationLink } from './groupService';
+
+type Tr = (fr: string, en: string) => string;
+type Tab = 'overview' | 'financial' | 'compliance' | 'links';
+
+const field = 'w-full rounded-[10px] border border-border bg-transparent px-3 py-2 text-[13px] text-ink';
+
+function apiMessage(err: unknown, fallback: string): string {
+  return (err as { response?: { data?: { error?: string } } })?.response?.data?.error || fallback;
+}
+
+const money = (a?: Amount) => (a ? `${Math.round(a.value).toLocaleString('fr-FR')} ${a.currency}` : '—');
+
+// A cell's colour follows its band product, like the dashboard heatmap.
+function cellColor(p: number, i: number): string {
+  const v = p * i;
+  if (v >= 15) return 'var(--critical)';
+  if (v >= 8) return 'var(--warning)';
+  return 'var(--success)';
+}
+
+export function GroupPage() {
+  const lang = useUIStore((s) => s.lang);
+  const tr: Tr = (fr, en) => (lang === 'fr' ? fr : en);
+  const canRead = useAuthStore((s) => s.hasPermission('group:read'));
+  const canManage = useAuthStore((s) => s.hasPermission('group:manage'));
+  const [tab, setTab] = useState<Tab>(canRead ? 'overview' : 'links');
+  const [drill, setDrill] = useState<{ title: string; filter: GroupRiskFilter } | null>(null);
+
+  const entities = useGroupEntities(canRead);
+  const subsidiaries = (entities.data?.length ?? 1) - 1;
+
+  const tabs: { key: Tab; label: string; show: boolean }[] = [
+    { key: 'overview', label: tr('Vue consolidée', 'Roll-up'), show: canRead },
+    { key: 'financial', label: tr('Exposition financière', 'Financial exposure'), show: canRead },
+    { key: 'compliance', label: tr('Conformité', 'Compliance'), show: canRead },
+    { key: 'links', label: tr('Liens', 'Links'), show: canManage || canRead },
+  ];
+
+  return (
+    <PageFrame wide>
+      <PageHeader
+        title={tr('Groupe', 'Group')}
+        count={entities.data ? tr(`${subsidiaries} filiale(s)`, `${subsidiaries} subsidiar${subsidiaries === 1 ? 'y' : 'ies'}`) : null}
+      />
+      <div className="mb-4 flex flex-wrap gap-2">
+        {tabs.filter((t) => t.show).map((t) => (
+          <Chip key={t.key} label={t.label} active={tab === t.key} onClick={() => setTab(t.key)} />
+        ))}
+      </div>
+
+      {tab === 'overview' && <Overview tr={tr} entities={entities} onDrill={setDrill} />}
+      {tab === 'financial' && <Financial tr={tr} />}
+      {tab === 'compliance' && <Compliance tr={tr} onDrill={setDrill} />}
+      {tab === 'links' && <Links tr={tr} canManage={canManage} />}
+
+      {drill && <DrillDown tr={tr} title={drill.title} filter={drill.filter} onClose={() => setDrill(null)} />}
+    </PageFrame>
+  );
+}
+
+type Drill = (d: { title: string; filter: GroupRiskFilter }) => void;
+
+function Overview({ tr, entities, onDrill }: { tr: Tr; entities: ReturnType<typeof useGroupEntities>; onDrill: Drill }) {
+  const heatmap = useGroupHeatmap();
+  const categories = useGroupCategories();
+
+  if (entities.isLoading) return <Card><SkeletonRows rows={6} /></Card>;
+  if (entities.isError) {
+    return <ErrorState title={tr('Impossible de charger le groupe.', 'Could not load the group.')} onRetry={() => entities.refetch()} retryLabel={tr('Réessayer', 'Retry')} />;
+  }
+  const list = entities.data ?? [];
+  if (list.length <= 1) {
+    return (
+      <Card>
+        <EmptyState
+          icon={Network}
+          title={tr('Aucune filiale rattachée', 'No subsidiary linked')}
+          description={tr(
+            "Demandez le rattachement d'une organisation depuis l'onglet Liens ; il prend effet quand son administrateur l'accepte.",
+            'Ask an organization to join from the Links tab; it takes effect once its administrator accepts.',
+          )}
+        />
+      </Card>
+    );
+  }
+
+  const cells = new Map<string, GroupHeatmapCell>();
+  heatmap.data?.cells.forEach((c) => cells.set(`${c.probability}:${c.impact}`, c));
+
+  return (
+    <div className="grid gap-4 lg:grid-cols-[minmax(0,1fr)_minmax(0,1fr)]">
+      <Card>
+        <h2 className="mb-3 text-[14px] font-semibold text-ink">{tr('Entités', 'Entities')}</h2>
+        <EntityTree tr={tr} entities={list} onDrill={onDrill} />
+      </Card>
+
+      <Card>
+        <h2 className="mb-3 text-[14px] font-semibold text-ink">
+          {tr('Cartographie consolidée', 'Consolidated heatmap')}
+          {heatmap.data && <span className="ml-2 text-[12px] font-normal text-ink-muted">{heatmap.data.total} {tr('risques', 'risks')}</span>}
+        </h2>
+        {heatmap.isLoading ? <SkeletonRows rows={5} /> : (
+          <div className="flex gap-2">
+            <div className="flex flex-col justify-between py-1 text-[11px] text-ink-muted" style={{ writingMode: 'vertical-rl', transform: 'rotate(180deg)' }}>
+              {tr('Probabilité', 'Probability')}
+            </div>
+            <div className="flex-1">
+              <div className="grid grid-cols-5 gap-1">
+                {[5, 4, 3, 2, 1].flatMap((p) => [1, 2, 3, 4, 5].map((i) => {
+                  const c = cells.get(`${p}:${i}`);
+                  const top = c?.by_entity.slice(0, 3).map((e) => `${e.name}: ${e.count}`).join('\n');
+                  return (
+                    <button
+                      key={`${p}:${i}`}
+                      type="button"
+                      disabled={!c}
+                      title={top}
+                      onClick={() => c && onDrill({ title: tr(`Probabilité ${p} × impact ${i}`, `Probability ${p} × impact ${i}`), filter: { probability: p, impact: i } })}
+                      className="flex h-12 items-center justify-center rounded-[8px] text-[13px] font-semibold"
+                      style={{
+                        background: c ? cellColor(p, i) : 'var(--bg-hover)',
+                        color: c ? '#fff' : 'var(--text-muted)',
+                        opacity: c ? Math.min(1, 0.45 + c.count / 10) : 1,
+                        cursor: c ? 'pointer' : 'default',
+                      }}
+                    >
+                      {c?.count ?? ''}
+                    </button>
+                  );
+                }))}
+              </div>
+              <div className="mt-1 text-center text-[11px] text-ink-muted">{tr('Impact', 'Impact')}</div>
+            </div>
+          </div>
+        )}
+      </Card>
+
+      <Card className="lg:col-span-2">
+        <h2 className="mb-3 text-[14px] font-semibold text-ink">{tr('Par catégorie', 'By category')}</h2>
+        {categories.isLoading ? <SkeletonRows rows={4} /> : (
+          <table className="w-full text-[13px]">
+            <thead>
+              <tr className="border-b border-border text-left text-[11.5px] uppercase tracking-wide text-ink-muted">
+                <th className="py-2">{tr('Catégorie', 'Category')}</th>
+                <th className="py-2 text-right">{tr('Risques', 'Risks')}</th>
+                <th className="py-2 text-right">{tr('Critiques', 'Critical')}</th>
+                <th className="py-2 text-right">{tr('Élevés', 'High')}</th>
+                <th className="py-2 text-right">{tr('Score moyen', 'Avg score')}</th>
+                <th className="py-2 pl-4">{tr('Principales entités', 'Main entities')}</th>
+              </tr>
+            </thead>
+            <tbody>
+              {(categories.data ?? []).map((c) => (
+                <tr
+                  key={c.slug || '-'}
+                  className="cursor-pointer border-b border-border last:border-0 hover:bg-[var(--bg-hover)]"
+                  onClick={() => onDrill({ title: c.name || tr('Sans catégorie', 'Uncategorised'), filter: { category: c.slug || '-' } })}
+                >
+                  <td className="py-2 text-ink">{c.name || <span className="text-ink-muted">{tr('Sans catégorie', 'Uncategorised')}</span>}</td>
+                  <td className="py-2 text-right">{c.count}</td>
+                  <td className="py-2 text-right">{c.critical_risks}</td>
+                  <td className="py-2 text-right">{c.high_risks}</td>
+                  <td className="py-2 text-right">{c.avg_score.toFixed(2)}</td>
+                  <td className="py-2 pl-4 text-ink-muted">{c.by_entity.slice(0, 3).map((e) => `${e.name} (${e.count})`).join(', ')}</td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        )}
+      </Card>
+    </div>
+  );
+}
+
+function EntityTree({ tr, entities, onDrill }: { tr: Tr; entities: GroupEntity[]; onDrill: Drill }) {
+  return (
+    <ul className="space-y-1">
+      {entities.map((e) => (
+        <li key={e.org_id}>
+          <button
+            type="button"
+            onClick={() => onDrill({ title: e.name, filter: { org_id: e.org_id } })}
+            className="flex w-full items-center gap-2 rounded-[8px] px-2 py-1.5 text-left text-[13px] hover:bg-[var(--bg-hover)]"
+            style={{ paddingLeft: 8 + e.depth * 18 }}
+          >
+            <Building2 size={14} className="shrink-0 text-ink-muted" />
+            <span className="flex-1 truncate text-ink">{e.name}</span>
+            <span className="text-ink-muted">{e.risks} {tr('risques', 'risks')}</span>
+            {e.critical_risks > 0 && (
+              <span className="rounded-full px-2 text-[11px] font-semibold" style={{ background: 'var(--critical)', color: '#fff' }}>{e.critical_risks}</span>
+            )}
+          </button>
+        </li>
+      ))}
+    </ul>
+  );
+}
+
+function Financial({ tr }: { tr: Tr }) {
+  const { data, isLoading, isError, refetch } = useGroupMonteCarlo();
+  if (isLoading) return <Card><SkeletonRows rows={6} /></Card>;
+  if (isError || !data) {
+    return <ErrorState title={tr('La simulation a échoué.', 'The simulation failed.')} onRetry={() => refetch()} retryLabel={tr('Réessayer', 'Retry')} />;
+  }
+  return (
+    <div className="grid gap-4">
+      <Card>
+        <h2 className="mb-1 text-[14px] font-semibold text-ink">{tr('Perte annuelle du groupe', 'Group annual loss')}</h2>
+        <p className="mb-3 text-[12px] text-ink-muted">
+          {tr(
+            `Une simulation Monte Carlo unique sur ${data.risks} risques, ${data.group.iterations.toLocaleString('fr-FR')} itérations.`,
+            `One Monte Carlo run over ${data.risks} risks, ${data.group.iterations.toLocaleString('en-GB')} iterations.`,
+          )}
+        </p>
+        <div className="grid grid-cols-2 gap-3 md:grid-cols-4">
+          {([['P10', data.group.p10], ['P50', data.group.p50], ['P90', data.group.p90], [tr('Effet de diversification', 'Diversification'), data.diversification]] as [string, Amount][]).map(([k, v]) => (
+            <div key={k} className="rounded-[10px] border border-border p-3">
+              <div className="text-[11.5px] uppercase tracking-wide text-ink-muted">{k}</div>
+              <div className="mt-1 text-[16px] font-semibold text-ink">{money(v)}</div>
+            </div>
+          ))}
+        </div>
+        <p className="mt-3 text-[12px] text-ink-muted">
+          {tr(
+            `La somme des P90 des entités (${money(data.sum_of_entity_p90)}) surestime le P90 du groupe : toutes les filiales ne connaissent pas leur pire année en même temps.`,
+            `The entities' P90s summed (${money(data.sum_of_entity_p90)}) overstate the group's P90: not every subsidiary has its worst year at once.`,
+          )}
+        </p>
+      </Card>
+      <Card style={{ padding: 0, overflow: 'hidden' }}>
+        <table className="w-full text-[13px]">
+          <thead>
+            <tr className="border-b border-border text-left text-[11.5px] uppercase tracking-wide text-ink-muted">
+              <th className="px-4 py-2.5">{tr('Entité', 'Entity')}</th>
+              <th className="px-4 py-2.5 text-right">{tr('Risques', 'Risks')}</th>
+              <th className="px-4 py-2.5 text-right">P50</th>
+              <th className="px-4 py-2.5 text-right">P90</th>
+            </tr>
+          </thead>
+          <tbody>
+            {data.entities.map((e) => (
+              <tr key={e.org_id} className="border-b border-border last:border-0">
+                <td className="px-4 py-2 text-ink">{e.name}</td>
+                <td className="px-4 py-2 text-right">{e.risks}</td>
+                <td className="px-4 py-2 text-right">{money(e.loss.p50)}</td>
+                <td className="px-4 py-2 text-right">{money(e.loss.p90)}</td>
+              </tr>
+            ))}
+          </tbody>
+        </table>
+      </Card>
+    </div>
+  );
+}
+
+function Compliance({ tr, onDrill }: { tr: Tr; onDrill: Drill }) {
+  const { data, isLoading, isError, refetch } = useGroupCompliance();
+  if (isLoading) return <Card><SkeletonRows rows={5} /></Card>;
+  if (isError) {
+    return <ErrorState title={tr('Impossible de charger la conformité.', 'Could not load compliance.')} onRetry={() => refetch()} retryLabel={tr('Réessayer', 'Retry')} />;
+  }
+  if (!data?.length) {
+    return <Card><EmptyState icon={Network} title={tr('Aucun référentiel dans le groupe', 'No framework in the group')} /></Card>;
+  }
+  return (
+    <div className="grid gap-4 md:grid-cols-2">
+      {data.map((f) => (
+        <Card key={f.key}>
+          <div className="mb-2 flex items-baseline justify-between gap-2">
+            <h2 className="text-[14px] font-semibold text-ink">{f.name} <span className="font-normal text-ink-muted">{f.version}</span></h2>
+            <span className="text-[18px] font-bold text-ink">{f.percent_complete.toFixed(1)} %</span>
+          </div>
+          <p className="mb-3 text-[12px] text-ink-muted">
+            {tr(`${f.implemented} / ${f.applicable} contrôles applicables mis en œuvre`, `${f.implemented} / ${f.applicable} applicable controls implemented`)}
+            {f.weakest && tr(` — en retrait : ${f.weakest.name}`, ` — furthest behind: ${f.weakest.name}`)}
+          </p>
+          <ul className="space-y-1.5">
+            {f.by_entity.map((e) => (
+              <li key={e.org_id}>
+                <button type="button" className="w-full text-left" onClick={() => onDrill({ title: e.name, filter: { org_id: e.org_id } })}>
+                  <div className="flex justify-between text-[12px]">
+                    <span className="text-ink">{e.name}</span>
+                    <span className="text-ink-muted">{e.percent_complete.toFixed(1)} %</span>
+                  </div>
+                  <div className="mt-0.5 h-1.5 rounded-full" style={{ background: 'var(--bg-hover)' }}>
+                    <div className="h-1.5 rounded-full" style={{ width: `${e.percent_complete}%`, background: 'var(--accent)' }} />
+                  </div>
+                </button>
+              </li>
+            ))}
+          </ul>
+        </Card>
+      ))}
+    </div>
+  );
+}
+
+function Links({ tr, canManage }: { tr: Tr; canManage: boolean }) {
+  const me = useAuthStore((s) => s.user?.tenant_id);
+  const { data, isLoading, isError, refetch } = useGroupLinks();
+  const request = useRequestGroupLink();
+  const accept = useAcceptGroupLink();
+  const revoke = useRevokeGroupLink();
+  const [slug, setSlug] = useState('');
+
+  const submit = () => {
+    request.mutate(slug.trim(), {
+      onSuccess: () => {
+        toast.success(tr("Demande envoyée ; elle attend l'accord de la filiale.", 'Request sent; it awaits the subsidiary’s approval.'));
+        setSlug('');
+      },
+      onError: (err) => toast.error(apiMessage(err, tr('La demande a échoué.', 'The request failed.'))),
+    });
+  };
+  const onAccept = (l: OrganizationLink) => accept.mutate(l.id, {
+    onSuccess: () => toast.success(tr(`${l.parent_name} peut désormais consolider votre registre.`, `${l.parent_name} can now roll up your register.`)),
+    onError: (err) => toast.error(apiMessage(err, tr("L'acceptation a échoué.", 'Accepting failed.'))),
+  });
+  const onRevoke = (l: OrganizationLink) => revoke.mutate(l.id, {
+    onSuccess: () => toast.success(tr('Lien révoqué.', 'Link revoked.')),
+    onError: (err) => toast.error(apiMessage(err, tr('La révocation a échoué.', 'Revoking failed.'))),
+  });
+
+  const statusLabel = (l: OrganizationLink) =>
+    l.status === 'active' ? tr('Actif', 'Active') : l.status === 'pending' ? tr('En attente', 'Pending') : tr('Révoqué', 'Revoked');
+
+  return (
+    <div className="grid gap-4">
+      {canManage && (
+        <Card>
+          <h2 className="mb-1 text-[14px] font-semibold text-ink">{tr('Rattacher une filiale', 'Link a subsidiary')}</h2>
+          <p className="mb-3 text-[12px] text-ink-muted">
+            {tr(
+              "Saisissez l'identifiant (slug) de l'organisation. Son administrateur devra accepter depuis son propre espace.",
+              "Enter the organization's slug. Its administrator must accept from inside that organization.",
+            )}
+          </p>
+          <div className="flex gap-2">
+            <input className={field} value={slug} onChange={(e) => setSlug(e.target.value)} placeholder="filiale-cameroun" />
+            <Btn primary icon={Link2} label={tr('Demander', 'Request')} onClick={submit} disabled={!slug.trim() || request.isPending} />
+          </div>
+        </Card>
+      )}
+
+      {isLoading ? <Card><SkeletonRows rows={4} /></Card> : isError ? (
+        <ErrorState title={tr('Impossible de charger les liens.', 'Could not load links.')} onRetry={() => refetch()} retryLabel={tr('Réessayer', 'Retry')} />
+      ) : !data?.length ? (
+        <Card><EmptyState icon={Link2} title={tr('Aucun lien', 'No link')} /></Card>
+      ) : (
+        <Card style={{ padding: 0, overflow: 'hidden' }}>
+          <table className="w-full text-[13px]">
+            <thead>
+              <tr className="border-b border-border text-left text-[11.5px] uppercase tracking-wide text-ink-muted">
+                <th className="px-4 py-2.5">{tr('Organisation', 'Organization')}</th>
+                <th className="px-4 py-2.5">{tr('Relation', 'Relation')}</th>
+                <th className="px-4 py-2.5">{tr('Statut', 'Status')}</th>
+                <th className="px-4 py-2.5" />
+              </tr>
+            </thead>
+            <tbody>
+              {data.map((l) => {
+                const incoming = l.child_id === me;
+                return (
+                  <tr key={l.id} className="border-b border-border last:border-0">
+                    <td className="px-4 py-2 text-ink">{incoming ? l.parent_name : (l.child_name ?? (l.status === 'pending' ? tr('Nommée après acceptation', 'Named once accepted') : '—'))}</td>
+                    <td className="px-4 py-2 text-ink-muted">{incoming ? tr('Société mère', 'Parent') : tr('Filiale', 'Subsidiary')}</td>
+                    <td className="px-4 py-2">{statusLabel(l)}</td>
+                    <td className="px-4 py-2 text-right">
+                      {canManage && (
+                        <div className="flex justify-end gap-2">
+                          {incoming && l.status === 'pending' && (
+                            <Btn icon={Check} label={tr('Accepter', 'Accept')} onClick={() => onAccept(l)} disabled={accept.isPending} />
+                          )}
+                          {l.status !== 'revoked' && (
+                            <Btn danger icon={Unlink} label={tr('Révoquer', 'Revoke')} onClick={() => onRevoke(l)} disabled={revoke.isPending} />
+                          )}
+                        </div>
+                      )}
+                    </td>
+                  </tr>
+                );
+              })}
+            </tbody>
+          </table>
+        </Card>
+      )}
+    </div>
+  );
+}
+
+function DrillDown({ tr, title, filter, onClose }: { tr: Tr; title: string; filter: GroupRiskFilter; onClose: () => void }) {
+  const { data, isLoading, isError } = useGroupRisks(filter);
+  return (
+    <Overlay onClose={onClose}>
+      <div className="mb-4 flex items-center justify-between">
+        <h2 className="text-[16px] font-semibold text-ink">{title}</h2>
+        <Btn icon={X} onClick={onClose} />
+      </div>
+      <p className="mb-3 text-[12px] text-ink-muted">{tr('Lecture seule — les risques restent gérés par chaque entité.', 'Read-only — each entity keeps managing its own risks.')}</p>
+      {isLoading ? <SkeletonRows rows={6} /> : isError ? (
+        <p className="text-[13px] text-ink-muted">{tr('Impossible de charger les risques.', 'Could not load the risks.')}</p>
+      ) : !data?.length ? (
+        <p className="text-[13px] text-ink-muted">{tr('Aucun risque.', 'No risk.')}</p>
+      ) : (
+        <table className="w-full text-[13px]">
+          <thead>
+            <tr className="border-b border-border text-left text-[11.5px] uppercase tracking-wide text-ink-muted">
+              <th className="py-2">{tr('Risque', 'Risk')}</th>
+              <th className="py-2">{tr('Entité', 'Entity')}</th>
+              <th className="py-2 text-right">Score</th>
+            </tr>
+          </thead>
+          <tbody>
+            {data.map((r) => (
+              <tr key={r.id} className="border-b border-border last:border-0">
+                <td className="py-2 text-ink">
+                  {r.title}
+                  {r.category && <div className="text-[11.5px] text-ink-muted">{r.category}</div>}
+                </td>
+                <td className="py-2 text-ink-muted">{r.org_name}</td>
+                <td className="py-2 text-right">{r.score.toFixed(2)}</td>
+              </tr>
+            ))}
+          </tbody>
+        </table>
+      )}
+    </Overlay>
+  );
+}
+
+function Overlay({ children, onClose }: { children: React.ReactNode; onClose: () => void }) {
+  return (
+    <div className="fixed inset-0 z-[80] flex justify-end" style={{ background: 'var(--surface-overlay)' }} onClick={onClose}>
+      <div
+        onClick={(e) => e.stopPropagation()}
+        className="h-full w-full max-w-[640px] overflow-y-auto p-5"
+        style={{ background: 'var(--bg-elevated)', borderLeft: '1px solid var(--border)' }}
+      >
+        {children}
+      </div>
+    </div>
+  );
+}
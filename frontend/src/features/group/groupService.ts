@@ -0,0 +1,195 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+//
+// Typed client for the group hierarchy. Mirrors domain.OrganizationLink and the
+// application/group views (entities, heatmap, categories, Monte Carlo,
+// compliance, drill-down).
+
+import { api } from '../../lib/api';
+
+export type OrganizationLinkStatus = 'pending' | 'active' | 'revoked';
+
+export interface OrganizationLink {
+  id: string;
+  parent_id: string;
+  child_id: string;
+  parent_name?: string;
+  child_name?: string;
+  status: OrganizationLinkStatus;
+  requested_by?: string;
+  accepted_by?: string;
+  accepted_at?: string;
+  revoked_at?: string;
+  created_at: string;
+}
+
+// What POST /group/links answers: the subsidiary is only named once it accepts.
+export interface PendingLink {
+  id: string;
+  status: OrganizationLinkStatus;
+}
+
+export interface GroupEntity {
+  org_id: string;
+  name: string;
+  slug: string;
+  parent_id?: string;
+  depth: number;
+  risks: number;
+  critical_risks: number;
+  high_risks: number;
+}
+
+export interface EntityCount {
+  org_id: string;
+  name: string;
+  count: number;
+}
+
+export interface GroupHeatmapCell {
+  probability: number;
+  impact: number;
+  count: number;
+  by_entity: EntityCount[];
+}
+
+export interface GroupHeatmap {
+  entities: number;
+  total: number;
+  cells: GroupHeatmapCell[];
+}
+
+export interface GroupCategory {
+  slug: string;
+  name: string;
+  count: number;
+  critical_risks: number;
+  high_risks: number;
+  avg_score: number;
+  by_entity: EntityCount[];
+}
+
+export interface Amount {
+  xaf: number;
+  usd: number;
+  value: number;
+  currency: string;
+}
+
+export interface LossBand {
+  p10: Amount;
+  p50: Amount;
+  p90: Amount;
+  mean: Amount;
+  iterations: number;
+  seed: number;
+}
+
+export interface GroupSimulation {
+  risks: number;
+  group: LossBand;
+  entities: { org_id: string; name: string; risks: number; loss: LossBand }[];
+  sum_of_entity_p90: Amount;
+  diversification: Amount;
+  computed_at: string;
+}
+
+export interface EntityPosture {
+  org_id: string;
+  name: string;
+  applicable: number;
+  implemented: number;
+  percent_complete: number;
+}
+
+export interface FrameworkPosture {
+  key: string;
+  name: string;
+  version: string;
+  total: number;
+  applicable: number;
+  implemented: number;
+  in_progress: number;
+  percent_complete: number;
+  weakest?: EntityPosture;
+  by_entity: EntityPosture[];
+}
+
+export interface GroupRisk {
+  id: string;
+  org_id: string;
+  org_name: string;
+  title: string;
+  category?: string;
+  probability: number;
+  impact: number;
+  score: number;
+  criticality: string;
+  status: string;
+  business_unit?: string;
+}
+
+export interface GroupRiskFilter {
+  org_id?: string;
+  probability?: number;
+  impact?: number;
+  /** Category slug; '-' selects uncategorised risks. */
+  category?: string;
+  limit?: number;
+}
+
+type Items<T> = { items: T[] };
+
+export const groupService = {
+  links: async (): Promise<OrganizationLink[]> => {
+    const res = await api.get<Items<OrganizationLink>>('/group/links');
+    return res.data.items ?? [];
+  },
+
+  requestLink: async (childSlug: string): Promise<PendingLink> => {
+    const res = await api.post<PendingLink>('/group/links', { child_slug: childSlug });
+    return res.data;
+  },
+
+  acceptLink: async (id: string): Promise<OrganizationLink> => {
+    const res = await api.post<OrganizationLink>(`/group/links/${id}/accept`);
+    return res.data;
+  },
+
+  revokeLink: async (id: string): Promise<OrganizationLink> => {
+    const res = await api.delete<OrganizationLink>(`/group/links/${id}`);
+    return res.data;
+  },
+
+  entities: async (): Promise<GroupEntity[]> => {
+    const res = await api.get<Items<GroupEntity>>('/group/entities');
+    return res.data.items ?? [];
+  },
+
+  heatmap: async (): Promise<GroupHeatmap> => {
+    const res = await api.get<GroupHeatmap>('/group/heatmap');
+    return res.data;
+  },
+
+  categories: async (): Promise<GroupCategory[]> => {
+    const res = await api.get<Items<GroupCategory>>('/group/categories');
+    return res.data.items ?? [];
+  },
+
+  monteCarlo: async (): Promise<GroupSimulation> => {
+    const res = await api.get<GroupSimulation>('/group/monte-carlo');
+    return res.data;
+  },
+
+  compliance: async (): Promise<FrameworkPosture[]> => {
+    const res = await api.get<Items<FrameworkPosture>>('/group/compliance');
+    return res.data.items ?? [];
+  },
+
+  risks: async (filter: GroupRiskFilter): Promise<GroupRisk[]> => {
+    const res = await api.get<Items<GroupRisk>>('/group/risks', { params: filter });
+    return res.data.items ?? [];
+  },
+};
@@ -0,0 +1,51 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { groupService, type GroupRiskFilter } from './groupService';
+
+export function useGroupEntities(enabled: boolean) {
+  return useQuery({ queryKey: ['group', 'entities'], queryFn: () => groupService.entities(), enabled });
+}
+
+export function useGroupHeatmap() {
+  return useQuery({ queryKey: ['group', 'heatmap'], queryFn: () => groupService.heatmap() });
+}
+
+export function useGroupCategories() {
+  return useQuery({ queryKey: ['group', 'categories'], queryFn: () => groupService.categories() });
+}
+
+export function useGroupMonteCarlo() {
+  return useQuery({ queryKey: ['group', 'monte-carlo'], queryFn: () => groupService.monteCarlo() });
+}
+
+export function useGroupCompliance() {
+  return useQuery({ queryKey: ['group', 'compliance'], queryFn: () => groupService.compliance() });
+}
+
+export function useGroupRisks(filter: GroupRiskFilter | null) {
+  return useQuery({
+    queryKey: ['group', 'risks', filter],
+    queryFn: () => groupService.risks(filter!),
+    enabled: !!filter,
+  });
+}
+
+export function useGroupLinks() {
+  return useQuery({ queryKey: ['group', 'links'], queryFn: () => groupService.links() });
+}
+
+function useLinkMutation<T>(fn: (arg: T) => Promise<unknown>) {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: fn,
+    onSuccess: () => qc.invalidateQueries({ queryKey: ['group'] }),
+  });
+}
+
+export const useRequestGroupLink = () => useLinkMutation((slug: string) => groupService.requestLink(slug));
+export const useAcceptGroupLink = () => useLinkMutation((id: string) => groupService.acceptLink(id));
+export const useRevokeGroupLink = () => useLinkMutation((id: string) => groupService.revokeLink(id));
@@ -0,0 +1,377 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+//
+// /settings/imports — the import wizard.
+//
+// Upload an export from another GRC tool (CSV, JSON or XLSX), map its columns
+// onto OpenRisk fields (the server suggests a mapping; a saved profile can
+// replace it), dry-run the mapping to see every row-level error, then apply.
+// Rows are upserted on their external ID, so re-importing the same export
+// updates rather than duplicates. An applied batch can be rolled back whole.
+
+import { useEffect, useState } from 'react';
+import { toast } from 'sonner';
+import { isAxiosError } from 'axios';
+import { Upload, Play, Check, RotateCcw, Trash2, Save, X } from 'lucide-react';
+import { PageFrame, PageHeader, Card, Btn, SkeletonRows, EmptyState, ErrorState } from '../../shared/ui';
+import { useUIStore } from '../../store/uiStore';
+import { apiErrorMessage } from '../../lib/apiError';
+import {
+  useApplyImport,
+  useDeleteImport,
+  useDeleteMappingProfile,
+  useDryRunImport,
+  useImports,
+  useMappingProfiles,
+  useRollbackImport,
+  useSaveMappingProfile,
+  useUploadImport,
+} from './useImports';
+import { importService, type ImportBatch, type ImportEntity, type ImportMapping, type ImportReport, type StagedImport } from './importService';
+
+type Tr = (fr: string, en: string) => string;
+
+const field = 'w-full rounded-[10px] border border-border bg-transparent px-3 py-2 text-[13px] text-ink';
+
+const ENTITIES: ImportEntity[] = ['risk', 'asset', 'control', 'mitigation'];
+
+function entityLabel(e: ImportEntity, tr: Tr): string {
+  switch (e) {
+    case 'asset': return tr('Actifs', 'Assets');
+    case 'control': return tr('Contrôles', 'Controls');
+    case 'mitigation': return tr('Mitigations', 'Mitigations');
+    default: return tr('Risques', 'Risks');
+  }
+}
+
+function statusLabel(b: ImportBatch, tr: Tr): string {
+  switch (b.status) {
+    case 'applied': return tr('Importé', 'Applied');
+    case 'rolled_back': return tr('Annulé', 'Rolled back');
+    default: return tr('En préparation', 'Staged');
+  }
+}
+
+export function ImportsPage() {
+  const lang = useUIStore((s) => s.lang);
+  const tr: Tr = (fr, en) => (lang === 'fr' ? fr : en);
+  const [entity, setEntity] = useState<ImportEntity>('risk');
+  const [staged, setStaged] = useState<StagedImport | null>(null);
+
+  const { data: batches, isLoading, isError, refetch } = useImports();
+  const upload = useUploadImport();
+  const rollback = useRollbackImport();
+  const remove = useDeleteImport();
+  const fmtDate = (d?: string) => (d ? new Date(d).toLocaleString(lang === 'fr' ? 'fr-FR' : 'en-GB') : '—');
+
+  const onFile = (file: File | undefined) => {
+    if (!file) return;
+    upload.mutate({ entity, file }, {
+      onSuccess: (st) => setStaged(st),
+      onError: (err) => toast.error(apiErrorMessage(err) || tr("Le fichier n'a pas pu être lu.", 'The file could not be read.')),
+    });
+  };
+
+  const open = async (b: ImportBatch) => {
+    try {
+      setStaged(await importService.get(b.id));
+    } catch (err) {
+      toast.error(apiErrorMessage(err) || tr('Chargement impossible.', 'Could not load the import.'));
+    }
+  };
+
+  const undo = (b: ImportBatch, force = false) => {
+    if (!force && !window.confirm(tr(
+      `Annuler l'import ${b.file_name} ? Les ${b.created} enregistrements créés seront supprimés et les ${b.updated} mis à jour restaurés.`,
+      `Roll back ${b.file_name}? The ${b.created} created records are deleted and the ${b.updated} updated ones restored.`,
+    ))) return;
+    rollback.mutate({ id: b.id, force }, {
+      onSuccess: () => toast.success(tr('Import annulé', 'Import rolled back')),
+      onError: (err) => {
+        const msg = apiErrorMessage(err);
+        // Records edited since the import: offer to overwrite those edits.
+        if (!force && isAxiosError(err) && err.response?.status === 409 && b.status === 'applied') {
+          if (window.confirm(`${msg}\n\n${tr('Forcer l’annulation ?', 'Force the rollback?')}`)) undo(b, true);
+          return;
+        }
+        toast.error(msg || tr("L'annulation a échoué.", 'Rollback failed.'));
+      },
+    });
+  };
+
+  return (
+    <PageFrame>
+      <PageHeader
+        title={tr('Import de données', 'Data import')}
+        count={batches?.length ? String(batches.length) : null}
+      />
+
+      <Card>
+        <div className="flex flex-wrap items-end gap-3 text-[13px]">
+          <label className="block">
+            <span className="mb-1 block text-[12px] text-ink-muted">{tr('Type de données', 'Data type')}</span>
+            <select className={field} value={entity} onChange={(e) => setEntity(e.target.value as ImportEntity)}>
+              {ENTITIES.map((e) => <option key={e} value={e}>{entityLabel(e, tr)}</option>)}
+            </select>
+          </label>
+          <label className="inline-flex cursor-pointer items-center gap-2 rounded-[10px] border border-border px-3 py-2 font-medium text-ink hover:bg-[var(--bg-hover)]">
+            <Upload size={15} />
+            {upload.isPending ? tr('Lecture…', 'Reading…') : tr('Choisir un fichier (CSV, JSON, XLSX)', 'Choose a file (CSV, JSON, XLSX)')}
+            <input
+              type="file"
+              className="hidden"
+              accept=".csv,.txt,.json,.xlsx"
+              disabled={upload.isPending}
+              onChange={(e) => { onFile(e.target.files?.[0]); e.target.value = ''; }}
+            />
+          </label>
+          <p className="text-[12px] text-ink-muted">
+            {tr(
+              '5 Mo et 5 000 lignes au plus. Les lignes sont rapprochées par identifiant externe : réimporter le même export met à jour au lieu de dupliquer.',
+              'At most 5 MB and 5,000 rows. Rows are matched on external ID: re-importing the same export updates instead of duplicating.',
+            )}
+          </p>
+        </div>
+      </Card>
+
+      {isLoading ? (
+        <Card><SkeletonRows rows={4} /></Card>
+      ) : isError ? (
+        <ErrorState title={tr('Impossible de charger les imports.', 'Could not load imports.')} onRetry={() => refetch()} retryLabel={tr('Réessayer', 'Retry')} />
+      ) : !batches?.length ? (
+        <Card><EmptyState icon={Upload} title={tr('Aucun import', 'No imports yet')} /></Card>
+      ) : (
+        <Card style={{ padding: 0, overflow: 'hidden' }}>
+          <table className="w-full text-[13px]">
+            <thead>
+              <tr className="border-b border-border text-left text-[11.5px] uppercase tracking-wide text-ink-muted">
+                <th className="px-4 py-2.5">{tr('Fichier', 'File')}</th>
+                <th className="px-4 py-2.5">{tr('Type', 'Type')}</th>
+                <th className="px-4 py-2.5">{tr('Statut', 'Status')}</th>
+                <th className="px-4 py-2.5">{tr('Lignes', 'Rows')}</th>
+                <th className="px-4 py-2.5">{tr('Créés / mis à jour / ignorés', 'Created / updated / skipped')}</th>
+                <th className="px-4 py-2.5">{tr('Date', 'Date')}</th>
+                <th className="px-4 py-2.5" />
+              </tr>
+            </thead>
+            <tbody>
+              {batches.map((b) => (
+                <tr key={b.id} className="border-b border-border last:border-0">
+                  <td className="px-4 py-2.5 font-medium text-ink">{b.file_name || '—'}</td>
+                  <td className="px-4 py-2.5 text-ink-soft">{entityLabel(b.entity, tr)}</td>
+                  <td className="px-4 py-2.5 text-ink-soft">{statusLabel(b, tr)}</td>
+                  <td className="px-4 py-2.5 text-ink-soft">{b.row_count}</td>
+                  <td className="px-4 py-2.5 text-ink-soft">{b.status === 'staged' ? '—' : `${b.created} / ${b.updated} / ${b.skipped}`}</td>
+                  <td className="px-4 py-2.5 text-ink-soft">{fmtDate(b.applied_at ?? b.created_at)}</td>
+                  <td className="px-4 py-2.5">
+                    <div className="flex justify-end gap-2">
+                      {b.status === 'staged' && <Btn icon={Play} label={tr('Reprendre', 'Resume')} onClick={() => open(b)} />}
+                      {b.status === 'applied' && <Btn danger icon={RotateCcw} label={tr('Annuler', 'Roll back')} onClick={() => undo(b)} disabled={rollback.isPending} />}
+                      {b.status !== 'applied' && (
+                        <button
+                          type="button"
+                          aria-label={tr('Supprimer', 'Delete')}
+                          className="text-ink-muted hover:text-ink"
+                          onClick={() => remove.mutate(b.id, { onError: (err) => toast.error(apiErrorMessage(err) || tr('Suppression impossible.', 'Delete failed.')) })}
+                        >
+                          <Trash2 size={15} />
+                        </button>
+                      )}
+                    </div>
+                  </td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        </Card>
+      )}
+
+      {staged && <Wizard staged={staged} onClose={() => setStaged(null)} tr={tr} />}
+    </PageFrame>
+  );
+}
+
+function Wizard({ staged, onClose, tr }: { staged: StagedImport; onClose: () => void; tr: Tr }) {
+  const { batch, targets, sample } = staged;
+  const [mapping, setMapping] = useState<ImportMapping>(staged.mapping ?? {});
+  const [report, setReport] = useState<ImportReport | null>(null);
+  const [skipInvalid, setSkipInvalid] = useState(false);
+  const [profileName, setProfileName] = useState('');
+
+  const { data: profiles } = useMappingProfiles(batch.entity);
+  const dryRun = useDryRunImport();
+  const apply = useApplyImport();
+  const saveProfile = useSaveMappingProfile();
+  const deleteProfile = useDeleteMappingProfile();
+
+  // Any change to the mapping invalidates the last dry run.
+  useEffect(() => setReport(null), [mapping]);
+
+  const taken = new Set(Object.values(mapping));
+  const missing = targets.filter((t) => t.required && !taken.has(t.key));
+
+  const setTarget = (col: string, key: string) => {
+    const next = { ...mapping };
+    if (key) next[col] = key;
+    else delete next[col];
+    setMapping(next);
+  };
+
+  // A profile maps the columns of the tool it was saved for; keep only those
+  // present in this file.
+  const loadProfile = (id: string) => {
+    const p = profiles?.find((x) => x.id === id);
+    if (!p) return;
+    const next: ImportMapping = {};
+    for (const [col, key] of Object.entries(p.mapping)) {
+      if (batch.columns.includes(col)) next[col] = key;
+    }
+    setMapping(next);
+    setProfileName(p.name);
+  };
+
+  const runDry = () => dryRun.mutate({ id: batch.id, mapping }, {
+    onSuccess: setReport,
+    onError: (err) => toast.error(apiErrorMessage(err) || tr('La vérification a échoué.', 'The dry run failed.')),
+  });
+
+  const runApply = () => apply.mutate({ id: batch.id, mapping, skipInvalid }, {
+    onSuccess: (r) => {
+      toast.success(tr(`${r.creates} créés, ${r.updates} mis à jour`, `${r.creates} created, ${r.updates} updated`));
+      onClose();
+    },
+    onError: (err) => toast.error(apiErrorMessage(err) || tr("L'import a échoué.", 'The import failed.')),
+  });
+
+  const save = () => saveProfile.mutate({ name: profileName.trim(), entity: batch.entity, mapping }, {
+    onSuccess: () => toast.success(tr('Profil enregistré', 'Profile saved')),
+    onError: (err) => toast.error(apiErrorMessage(err) || tr("Le profil n'a pas pu être enregistré.", 'The profile could not be saved.')),
+  });
+
+  const canApply = report !== null && report.valid > 0 && (report.errors.length === 0 || skipInvalid);
+
+  return (
+    <div className="fixed inset-0 z-[80] flex justify-end" style={{ background: 'var(--surface-overlay)' }} onClick={onClose}>
+      <div
+        onClick={(e) => e.stopPropagation()}
+        className="h-full w-full max-w-[860px] overflow-y-auto p-5"
+        style={{ background: 'var(--bg-elevated)', borderLeft: '1px solid var(--border)' }}
+      >
+        <div className="mb-4 flex items-start justify-between">
+          <div>
+            <h2 className="text-[16px] font-bold text-ink">{batch.file_name}</h2>
+            <p className="text-[12px] text-ink-muted">
+              {entityLabel(batch.entity, tr)} · {batch.row_count} {tr('lignes', 'rows')} · {batch.format.toUpperCase()}
+            </p>
+          </div>
+          <button type="button" onClick={onClose} aria-label={tr('Fermer', 'Close')}><X size={18} /></button>
+        </div>
+
+        <section className="mb-4 flex flex-wrap items-end gap-2 text-[13px]">
+          <label className="block min-w-[220px]">
+            <span className="mb-1 block text-[12px] text-ink-muted">{tr('Profil de correspondance', 'Mapping profile')}</span>
+            <select className={field} value="" onChange={(e) => loadProfile(e.target.value)}>
+              <option value="">{staged.suggested ? tr('Suggestion automatique', 'Automatic suggestion') : tr('Correspondance actuelle', 'Current mapping')}</option>
+              {profiles?.map((p) => <option key={p.id} value={p.id}>{p.name}</option>)}
+            </select>
+          </label>
+          <input className={`${field} max-w-[200px]`} placeholder={tr('Nom du profil', 'Profile name')} value={profileName} onChange={(e) => setProfileName(e.target.value)} />
+          <Btn icon={Save} label={tr('Enregistrer', 'Save')} onClick={save} disabled={!profileName.trim() || saveProfile.isPending} />
+          {profiles?.some((p) => p.name === profileName.trim()) && (
+            <Btn
+              danger
+              icon={Trash2}
+              label={tr('Supprimer le profil', 'Delete profile')}
+              onClick={() => {
+                const p = profiles.find((x) => x.name === profileName.trim());
+                if (p) deleteProfile.mutate(p.id, { onSuccess: () => setProfileName('') });
+              }}
+            />
+          )}
+        </section>
+
+        <table className="mb-4 w-full text-[13px]">
+          <thead>
+            <tr className="border-b border-border text-left text-[11.5px] uppercase tracking-wide text-ink-muted">
+              <th className="py-2 pr-3">{tr('Colonne du fichier', 'File column')}</th>
+              <th className="py-2 pr-3">{tr('Exemple', 'Sample')}</th>
+              <th className="py-2">{tr('Champ OpenRisk', 'OpenRisk field')}</th>
+            </tr>
+          </thead>
+          <tbody>
+            {batch.columns.map((col) => (
+              <tr key={col} className="border-b border-border last:border-0">
+                <td className="py-2 pr-3 font-medium text-ink">{col}</td>
+                <td className="max-w-[220px] truncate py-2 pr-3 text-ink-soft" title={sample[0]?.[col]}>{sample[0]?.[col] || '—'}</td>
+                <td className="py-2">
+                  <select className={field} value={mapping[col] ?? ''} onChange={(e) => setTarget(col, e.target.value)}>
+                    <option value="">{tr('— Ignorer —', '— Ignore —')}</option>
+                    {targets.map((t) => (
+                      <option key={t.key} value={t.key} disabled={taken.has(t.key) && mapping[col] !== t.key}>
+                        {t.label}{t.required ? ' *' : ''}
+                      </option>
+                    ))}
+                  </select>
+                </td>
+              </tr>
+            ))}
+          </tbody>
+        </table>
+
+        {missing.length > 0 && (
+          <p className="mb-3 text-[12.5px]" style={{ color: 'var(--critical)' }}>
+            {tr('Champs obligatoires non associés : ', 'Required fields not mapped: ')}{missing.map((t) => t.label).join(', ')}
+          </p>
+        )}
+
+        <div className="mb-4 flex gap-2">
+          <Btn icon={Play} label={tr('Vérifier (simulation)', 'Dry run')} onClick={runDry} disabled={missing.length > 0 || dryRun.isPending} />
+        </div>
+
+        {report && (
+          <Card>
+            <div className="mb-3 flex flex-wrap gap-4 text-[13px]">
+              <span>{report.rows} {tr('lignes', 'rows')}</span>
+              <span style={{ color: 'var(--low)' }}>{report.creates} {tr('créations', 'to create')}</span>
+              <span>{report.updates} {tr('mises à jour', 'to update')}</span>
+              <span style={{ color: report.errors.length ? 'var(--critical)' : undefined }}>
+                {report.rows - report.valid} {tr('en erreur', 'with errors')}
+              </span>
+            </div>
+            {report.errors.length > 0 && (
+              <div className="mb-3 max-h-[260px] overflow-y-auto">
+                <table className="w-full text-[12.5px]">
+                  <thead>
+                    <tr className="text-left text-[11px] uppercase tracking-wide text-ink-muted">
+                      <th className="py-1 pr-3">{tr('Ligne', 'Row')}</th>
+                      <th className="py-1 pr-3">{tr('Colonne', 'Column')}</th>
+                      <th className="py-1">{tr('Problème', 'Problem')}</th>
+                    </tr>
+                  </thead>
+                  <tbody>
+                    {report.errors.slice(0, 500).map((e, i) => (
+                      <tr key={i} className="border-t border-border">
+                        <td className="py-1 pr-3 text-ink-soft">{e.row}</td>
+                        <td className="py-1 pr-3 text-ink-soft">{e.column || '—'}</td>
+                        <td className="py-1 text-ink">{e.reason}</td>
+                      </tr>
+                    ))}
+                  </tbody>
+                </table>
+              </div>
+            )}
+            <div className="flex flex-wrap items-center justify-end gap-3 text-[13px]">
+              {report.errors.length > 0 && (
+                <label className="inline-flex items-center gap-2 text-ink-soft">
+                  <input type="checkbox" checked={skipInvalid} onChange={(e) => setSkipInvalid(e.target.checked)} />
+                  {tr('Ignorer les lignes en erreur', 'Skip rows with errors')}
+                </label>
+              )}
+              <Btn primary icon={Check} label={tr('Importer', 'Import')} onClick={runApply} disabled={!canApply || apply.isPending} />
+            </div>
+          </Card>
+        )}
+      </div>
+    </div>
+  );
+}
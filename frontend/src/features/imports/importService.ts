@@ -0,0 +1,135 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+//
+// Typed client for the import wizard. Mirrors domain.ImportBatch /
+// domain.ImportMappingProfile and the application/dataimport views (staged
+// batch, dry-run report).
+
+import { api } from '../../lib/api';
+
+export type ImportEntity = 'risk' | 'asset' | 'control' | 'mitigation';
+export type ImportStatus = 'staged' | 'applied' | 'rolled_back';
+
+/** Source column → target key (a fixed field, `custom:<name>` or `attr:<key>`). */
+export type ImportMapping = Record<string, string>;
+
+export interface ImportTarget {
+  key: string;
+  label: string;
+  required: boolean;
+}
+
+export interface ImportBatch {
+  id: string;
+  entity: ImportEntity;
+  status: ImportStatus;
+  file_name: string;
+  format: 'csv' | 'json' | 'xlsx';
+  columns: string[];
+  mapping: ImportMapping | null;
+  row_count: number;
+  created: number;
+  updated: number;
+  skipped: number;
+  applied_at?: string;
+  rolled_back_at?: string;
+  created_at: string;
+}
+
+export interface StagedImport {
+  batch: ImportBatch;
+  targets: ImportTarget[];
+  mapping: ImportMapping;
+  /** True when `mapping` is the server's guess rather than the batch's own. */
+  suggested: boolean;
+  sample: Record<string, string>[];
+}
+
+export interface ImportRowError {
+  /** 1-based data row (the header is not counted). */
+  row: number;
+  column?: string;
+  reason: string;
+}
+
+export interface ImportReport {
+  batch_id: string;
+  status: ImportStatus;
+  rows: number;
+  valid: number;
+  creates: number;
+  updates: number;
+  errors: ImportRowError[];
+}
+
+export interface MappingProfile {
+  id: string;
+  name: string;
+  entity: ImportEntity;
+  mapping: ImportMapping;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface ProfileInput {
+  name: string;
+  entity: ImportEntity;
+  mapping: ImportMapping;
+}
+
+export const importService = {
+  list: async (): Promise<ImportBatch[]> => {
+    const res = await api.get<{ items: ImportBatch[] }>('/imports');
+    return res.data.items ?? [];
+  },
+
+  /** Upload a CSV, JSON or XLSX export; nothing is written to the register yet. */
+  upload: async (entity: ImportEntity, file: File): Promise<StagedImport> => {
+    const form = new FormData();
+    form.append('file', file);
+    form.append('entity', entity);
+    const res = await api.post<StagedImport>('/imports', form);
+    return res.data;
+  },
+
+  get: async (id: string): Promise<StagedImport> => {
+    const res = await api.get<StagedImport>(`/imports/${id}`);
+    return res.data;
+  },
+
+  remove: async (id: string): Promise<void> => {
+    await api.delete(`/imports/${id}`);
+  },
+
+  dryRun: async (id: string, mapping: ImportMapping): Promise<ImportReport> => {
+    const res = await api.post<ImportReport>(`/imports/${id}/dry-run`, { mapping });
+    return res.data;
+  },
+
+  apply: async (id: string, mapping: ImportMapping, skipInvalid: boolean): Promise<ImportReport> => {
+    const res = await api.post<ImportReport>(`/imports/${id}/apply`, { mapping, skip_invalid: skipInvalid });
+    return res.data;
+  },
+
+  /** 409 when a record was edited since the import, unless forced. */
+  rollback: async (id: string, force = false): Promise<ImportBatch> => {
+    const res = await api.post<ImportBatch>(`/imports/${id}/rollback`, undefined, { params: force ? { force: true } : undefined });
+    return res.data;
+  },
+
+  listProfiles: async (entity?: ImportEntity): Promise<MappingProfile[]> => {
+    const res = await api.get<{ items: MappingProfile[] }>('/import-profiles', { params: entity ? { entity } : undefined });
+    return res.data.items ?? [];
+  },
+
+  saveProfile: async (input: ProfileInput): Promise<MappingProfile> => {
+    const res = await api.post<MappingProfile>('/import-profiles', input);
+    return res.data;
+  },
+
+  removeProfile: async (id: string): Promise<void> => {
+    await api.delete(`/import-profiles/${id}`);
+  },
+};
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { importService, type ImportEntity, type ImportMapping, type ProfileInput } from './importService';
+
+export function useImports() {
+  return useQuery({ queryKey: ['imports'], queryFn: () => importService.list() });
+}
+
+export function useMappingProfiles(entity: ImportEntity) {
+  return useQuery({ queryKey: ['import-profiles', entity], queryFn: () => importService.listProfiles(entity) });
+}
+
+/** Applying or rolling back a batch rewrites the register it targets, so the
+ *  stores of every importable entity go stale with the batch list. */
+function useImportMutation<V, R>(fn: (v: V) => Promise<R>) {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: fn,
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ['imports'] });
+      for (const key of ['risks', 'assets', 'mitigations', 'compliance']) {
+        qc.invalidateQueries({ queryKey: [key] });
+      }
+    },
+  });
+}
+
+export function useUploadImport() {
+  return useImportMutation(({ entity, file }: { entity: ImportEntity; file: File }) => importService.upload(entity, file));
+}
+
+export function useDryRunImport() {
+  return useMutation({
+    mutationFn: ({ id, mapping }: { id: string; mapping: ImportMapping }) => importService.dryRun(id, mapping),
+  });
+}
+
+export function useApplyImport() {
+  return useImportMutation(({ id, mapping, skipInvalid }: { id: string; mapping: ImportMapping; skipInvalid: boolean }) =>
+    importService.apply(id, mapping, skipInvalid));
+}
+
+export function useRollbackImport() {
+  return useImportMutation(({ id, force }: { id: string; force?: boolean }) => importService.rollback(id, force));
+}
+
+export function useDeleteImport() {
+  return useImportMutation((id: string) => importService.remove(id));
+}
+
+export function useSaveMappingProfile() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: (input: ProfileInput) => importService.saveProfile(input),
+    onSuccess: () => qc.invalidateQueries({ queryKey: ['import-profiles'] }),
+  });
+}
+
+export function useDeleteMappingProfile() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: (id: string) => importService.removeProfile(id),
+    onSuccess: () => qc.invalidateQueries({ queryKey: ['import-profiles'] }),
+  });
+}
@@ -5,7 +5,7 @@
 // per-provider credential fields, status colors and small formatting helpers.
 
 import {
-  Cloud, Server, Network, Boxes, Container, Building2, GitBranch, Users, type LucideIcon,
+  Cloud, Server, Network, Boxes, Container, Building2, GitBranch, Users, Puzzle, type LucideIcon,
 } from 'lucide-react';
 import type { AgentStatus, AssetCriticality, ScanJobStatus, ScannerProvider } from './scannerService';
 
@@ -33,6 +33,7 @@ export const PROVIDERS: Record<ScannerProvider, ProviderMeta> = {
   gitlab: { short: 'GitLab', color: '#fc6d26', icon: GitBranch, cloud: true, category: 'forge' },
   nmap: { short: 'On-Premise', color: '#7c6cff', icon: Network, cloud: false, category: 'onprem' },
   agent: { short: 'Agent', color: '#64d2ff', icon: Server, cloud: false, category: 'onprem' },
+  plugin: { short: 'Plugin', color: '#bf5af2', icon: Puzzle, cloud: true, category: 'cloud' },
 };
 
 export interface CredField {
@@ -10,7 +10,9 @@ import { api } from '../../lib/api';
 export type ScannerProvider =
   | 'aws' | 'azure' | 'gcp' | 'nmap' | 'agent'
   // Auto-discovery API providers (spec "6. Découverte automatique des actifs").
-  | 'kubernetes' | 'docker' | 'vmware' | 'active_directory' | 'm365' | 'github' | 'gitlab';
+  | 'kubernetes' | 'docker' | 'vmware' | 'active_directory' | 'm365' | 'github' | 'gitlab'
+  // Previews staged by a connector plugin's emit_assets hook; never a config.
+  | 'plugin';
 export type AgentStatus = 'online' | 'offline' | 'scanning' | 'error' | 'revoked';
 export type ScanJobStatus = 'queued' | 'claimed' | 'running' | 'completed' | 'failed' | 'timeout';
 
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+//
+// Typed client for key risk indicators. Mirrors domain.KRI / domain.KRIValue and
+// application/kri's Trend and RecordResult.
+
+import { api } from '../../lib/api';
+
+export type KRISource = 'query' | 'push' | 'csv';
+export type KRIDirection = 'higher_is_worse' | 'lower_is_worse';
+export type KRIStatus = 'unknown' | 'green' | 'amber' | 'red';
+export type KRISubject = 'risks' | 'vulnerabilities' | 'assets' | 'incidents';
+export type KRIAggregate = 'count' | 'sum' | 'avg' | 'min' | 'max';
+
+export interface KRIFilter {
+  field: string;
+  op: 'eq' | 'neq' | 'in' | 'gte' | 'lte';
+  value?: string;
+  values?: string[];
+}
+
+export interface KRIQuery {
+  subject: KRISubject;
+  aggregate: KRIAggregate;
+  field?: string;
+  filters?: KRIFilter[];
+  window_days?: number;
+}
+
+export interface KRIValue {
+  id: string;
+  value: number;
+  status: KRIStatus;
+  source: KRISource;
+  measured_at: string;
+}
+
+export interface KRI {
+  id: string;
+  name: string;
+  description: string;
+  unit: string;
+  source: KRISource;
+  query: KRIQuery;
+  refresh_minutes: number;
+  direction: KRIDirection;
+  amber_threshold: number;
+  red_threshold: number;
+  risk_ids: string[];
+  bump_probability: boolean;
+  probability_step: number;
+  status: KRIStatus;
+  last_value: number | null;
+  last_measured_at: string | null;
+  last_error?: string;
+}
+
+/** A KRI with its recent values, oldest first. */
+export interface KRITrend extends KRI {
+  values: KRIValue[];
+}
+
+export interface KRIInput {
+  name: string;
+  description?: string;
+  unit?: string;
+  source: KRISource;
+  query?: KRIQuery;
+  refresh_minutes?: number;
+  direction: KRIDirection;
+  amber_threshold: number;
+  red_threshold: number;
+  risk_ids: string[];
+  bump_probability: boolean;
+  probability_step?: number;
+}
+
+export interface KRIRecordResult {
+  kri: KRI;
+  recorded: number;
+  crossing?: { from: KRIStatus; to: KRIStatus; worsened: boolean };
+  raised_risks?: string[];
+}
+
+export const kriService = {
+  list: async (days?: number): Promise<KRITrend[]> => {
+    const res = await api.get<KRITrend[]>('/kris', { params: { days } });
+    return res.data;
+  },
+
+  /** The KRIs watching one risk (drawer sparklines). */
+  forRisk: async (riskId: string, days?: number): Promise<KRITrend[]> => {
+    const res = await api.get<KRITrend[]>(`/risks/${riskId}/kris`, { params: { days } });
+    return res.data;
+  },
+
+  create: async (input: KRIInput): Promise<KRI> => {
+    const res = await api.post<KRI>('/kris', input);
+    return res.data;
+  },
+
+  update: async (id: string, input: KRIInput): Promise<KRI> => {
+    const res = await api.put<KRI>(`/kris/${id}`, input);
+    return res.data;
+  },
+
+  remove: async (id: string): Promise<void> => {
+    await api.delete(`/kris/${id}`);
+  },
+
+  pushValue: async (id: string, value: number, measuredAt?: string): Promise<KRIRecordResult> => {
+    const res = await api.post<KRIRecordResult>(`/kris/${id}/values`, { value, measured_at: measuredAt });
+    return res.data;
+  },
+
+  /** Upload a measured_at,value CSV. All-or-nothing. */
+  importCsv: async (id: string, file: File): Promise<KRIRecordResult> => {
+    const form = new FormData();
+    form.append('file', file);
+    const res = await api.post<KRIRecordResult>(`/kris/${id}/values/csv`, form);
+    return res.data;
+  },
+
+  refresh: async (id: string): Promise<KRIRecordResult> => {
+    const res = await api.post<KRIRecordResult>(`/kris/${id}/refresh`);
+    return res.data;
+  },
+};
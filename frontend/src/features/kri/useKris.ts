@@ -0,0 +1,54 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { kriService, type KRIInput } from './kriService';
+
+/** Every KRI with its last 90 days. */
+export function useKris() {
+  return useQuery({ queryKey: ['kris'], queryFn: () => kriService.list() });
+}
+
+/** The KRIs watching one risk. `enabled` gates the fetch (drawer tab). */
+export function useRiskKris(riskId: string | undefined, enabled = true) {
+  return useQuery({
+    queryKey: ['kris', 'risk', riskId],
+    queryFn: () => kriService.forRisk(riskId as string),
+    enabled: Boolean(riskId) && enabled,
+  });
+}
+
+export function useSaveKri() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: ({ id, input }: { id?: string; input: KRIInput }) =>
+      id ? kriService.update(id, input) : kriService.create(input),
+    onSuccess: () => qc.invalidateQueries({ queryKey: ['kris'] }),
+  });
+}
+
+/** Push one value. A red crossing may raise linked risks, so risks are refetched too. */
+export function usePushKriValue() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: ({ id, value, measuredAt }: { id: string; value: number; measuredAt?: string }) =>
+      kriService.pushValue(id, value, measuredAt),
+    onSuccess: (res) => {
+      qc.invalidateQueries({ queryKey: ['kris'] });
+      if (res.raised_risks?.length) qc.invalidateQueries({ queryKey: ['risks'] });
+    },
+  });
+}
+
+export function useImportKriCsv() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: ({ id, file }: { id: string; file: File }) => kriService.importCsv(id, file),
+    onSuccess: (res) => {
+      qc.invalidateQueries({ queryKey: ['kris'] });
+      if (res.raised_risks?.length) qc.invalidateQueries({ queryKey: ['risks'] });
+    },
+  });
+}
@@ -0,0 +1,437 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+//
+// /settings/plugins — connector plugins.
+//
+// Upload a signed WebAssembly bundle, install it with explicit grants (the
+// hosts it may call and the secrets it may read, out of what its manifest
+// requested), memory and time limits and a JSON config, then run its hooks.
+// Findings go through vulnerability ingest; emitted assets land in a scan
+// preview to review before anything reaches the inventory. Every run, failed
+// or not, is in the install's run log. See docs/PLUGINS.md.
+
+import { Fragment, useState, type ReactNode } from 'react';
+import { useNavigate } from 'react-router';
+import { toast } from 'sonner';
+import { Puzzle, Upload, Plus, Play, Trash2, Settings2, X, Copy } from 'lucide-react';
+import { PageFrame, PageHeader, Card, Btn, SkeletonRows, EmptyState, ErrorState } from '../../shared/ui';
+import { useUIStore } from '../../store/uiStore';
+import { apiErrorMessage } from '../../lib/apiError';
+import {
+  useCreateInstall,
+  useDeleteInstall,
+  useDeletePlugin,
+  usePluginInstalls,
+  usePluginPackages,
+  usePluginRuns,
+  useRunPlugin,
+  useUpdateInstall,
+  useUploadPlugin,
+} from './usePlugins';
+import type { PluginHook, PluginInstall, PluginPackage } from './pluginService';
+
+type Tr = (fr: string, en: string) => string;
+
+const field = 'w-full rounded-[10px] border border-border bg-transparent px-3 py-2 text-[13px] text-ink';
+
+function hookLabel(h: PluginHook, tr: Tr): string {
+  switch (h) {
+    case 'emit_assets': return tr('Découvrir des actifs', 'Discover assets');
+    case 'create_ticket': return tr('Créer un ticket', 'Create ticket');
+    case 'transform_webhook': return tr('Webhook entrant', 'Inbound webhook');
+    default: return tr('Récupérer les vulnérabilités', 'Pull findings');
+  }
+}
+
+export function PluginsPage() {
+  const lang = useUIStore((s) => s.lang);
+  const tr: Tr = (fr, en) => (lang === 'fr' ? fr : en);
+  const [editing, setEditing] = useState<PluginInstall | null>(null);
+  const [runsOf, setRunsOf] = useState<PluginInstall | null>(null);
+
+  const packages = usePluginPackages();
+  const installs = usePluginInstalls();
+  const upload = useUploadPlugin();
+  const removePackage = useDeletePlugin();
+  const createInstall = useCreateInstall();
+  const removeInstall = useDeleteInstall();
+  const fmtDate = (d?: string) => (d ? new Date(d).toLocaleString(lang === 'fr' ? 'fr-FR' : 'en-GB') : '—');
+
+  const onFile = (file: File | undefined) => {
+    if (!file) return;
+    upload.mutate(file, {
+      onSuccess: (p) => toast.success(tr(`${p.name} ${p.version} vérifié et ajouté`, `${p.name} ${p.version} verified and added`)),
+      onError: (err) => toast.error(apiErrorMessage(err) || tr("Le paquet n'a pas pu être vérifié.", 'The bundle could not be verified.')),
+    });
+  };
+
+  const install = (p: PluginPackage) => createInstall.mutate({ package_id: p.id }, {
+    onSuccess: (inst) => setEditing(inst),
+    onError: (err) => toast.error(apiErrorMessage(err) || tr("L'installation a échoué.", 'Install failed.')),
+  });
+
+  const error = (fallback: string) => (err: unknown) => toast.error(apiErrorMessage(err) || fallback);
+
+  return (
+    <PageFrame>
+      <PageHeader
+        title={tr('Plugins de connecteurs', 'Connector plugins')}
+        count={installs.data?.length ? String(installs.data.length) : null}
+      />
+
+      <Card>
+        <div className="flex flex-wrap items-center gap-3 text-[13px]">
+          <label className="inline-flex cursor-pointer items-center gap-2 rounded-[10px] border border-border px-3 py-2 font-medium text-ink hover:bg-[var(--bg-hover)]">
+            <Upload size={15} />
+            {upload.isPending ? tr('Vérification…', 'Verifying…') : tr('Téléverser un paquet signé (.zip)', 'Upload a signed bundle (.zip)')}
+            <input
+              type="file"
+              className="hidden"
+              accept=".zip"
+              disabled={upload.isPending}
+              onChange={(e) => { onFile(e.target.files?.[0]); e.target.value = ''; }}
+            />
+          </label>
+          <p className="text-[12px] text-ink-muted">
+            {tr(
+              "Seuls les paquets signés par une clé de confiance (PLUGIN_TRUSTED_KEYS) sont acceptés. Un plugin n'accède qu'aux hôtes et secrets que vous lui accordez.",
+              'Only bundles signed by a trusted key (PLUGIN_TRUSTED_KEYS) are accepted. A plugin reaches only the hosts and secrets you grant it.',
+            )}
+          </p>
+        </div>
+      </Card>
+
+      <h3 className="mb-2 mt-5 text-[13px] font-semibold uppercase tracking-wide text-ink-muted">{tr('Paquets', 'Packages')}</h3>
+      {packages.isLoading ? (
+        <Card><SkeletonRows rows={3} /></Card>
+      ) : packages.isError ? (
+        <ErrorState title={tr('Impossible de charger les plugins.', 'Could not load plugins.')} onRetry={() => packages.refetch()} retryLabel={tr('Réessayer', 'Retry')} />
+      ) : !packages.data?.length ? (
+        <Card><EmptyState icon={Puzzle} title={tr('Aucun plugin', 'No plugins yet')} /></Card>
+      ) : (
+        <Card style={{ padding: 0, overflow: 'hidden' }}>
+          <table className="w-full text-[13px]">
+            <thead>
+              <tr className="border-b border-border text-left text-[11.5px] uppercase tracking-wide text-ink-muted">
+                <th className="px-4 py-2.5">{tr('Plugin', 'Plugin')}</th>
+                <th className="px-4 py-2.5">{tr('Éditeur', 'Publisher')}</th>
+                <th className="px-4 py-2.5">{tr('Points d’entrée', 'Hooks')}</th>
+                <th className="px-4 py-2.5">{tr('Demande', 'Requests')}</th>
+                <th className="px-4 py-2.5">{tr('Signature', 'Signature')}</th>
+                <th className="px-4 py-2.5" />
+              </tr>
+            </thead>
+            <tbody>
+              {packages.data.map((p) => (
+                <tr key={p.id} className="border-b border-border last:border-0">
+                  <td className="px-4 py-2.5">
+                    <div className="font-medium text-ink">{p.name} <span className="text-ink-muted">{p.version}</span></div>
+                    {p.description && <div className="text-[12px] text-ink-muted">{p.description}</div>}
+                  </td>
+                  <td className="px-4 py-2.5 text-ink-soft">{p.publisher}</td>
+                  <td className="px-4 py-2.5 text-ink-soft">{p.hooks.map((h) => hookLabel(h, tr)).join(', ')}</td>
+                  <td className="px-4 py-2.5 text-[12px] text-ink-soft">
+                    {[...(p.requested_hosts ?? []), ...(p.requested_secrets ?? []).map((s) => `secret:${s}`)].join(', ') || '—'}
+                  </td>
+                  <td className="px-4 py-2.5 text-[12px] text-ink-muted" title={p.digest}>{p.key_id} · {p.digest.slice(0, 12)}</td>
+                  <td className="px-4 py-2.5">
+                    <div className="flex justify-end gap-2">
+                      <Btn icon={Plus} label={tr('Installer', 'Install')} onClick={() => install(p)} disabled={createInstall.isPending} />
+                      <button
+                        type="button"
+                        aria-label={tr('Supprimer', 'Delete')}
+                        className="text-ink-muted hover:text-ink"
+                        onClick={() => removePackage.mutate(p.id, { onError: error(tr('Suppression impossible.', 'Delete failed.')) })}
+                      >
+                        <Trash2 size={15} />
+                      </button>
+                    </div>
+                  </td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        </Card>
+      )}
+
+      <h3 className="mb-2 mt-5 text-[13px] font-semibold uppercase tracking-wide text-ink-muted">{tr('Installations', 'Installs')}</h3>
+      {installs.isLoading ? (
+        <Card><SkeletonRows rows={3} /></Card>
+      ) : !installs.data?.length ? (
+        <Card><EmptyState icon={Settings2} title={tr('Aucune installation', 'Nothing installed')} /></Card>
+      ) : (
+        <Card style={{ padding: 0, overflow: 'hidden' }}>
+          <table className="w-full text-[13px]">
+            <thead>
+              <tr className="border-b border-border text-left text-[11.5px] uppercase tracking-wide text-ink-muted">
+                <th className="px-4 py-2.5">{tr('Nom', 'Name')}</th>
+                <th className="px-4 py-2.5">{tr('Accès accordés', 'Grants')}</th>
+                <th className="px-4 py-2.5">{tr('Limites', 'Limits')}</th>
+                <th className="px-4 py-2.5">{tr('Dernière exécution', 'Last run')}</th>
+                <th className="px-4 py-2.5" />
+              </tr>
+            </thead>
+            <tbody>
+              {installs.data.map((inst) => (
+                <tr key={inst.id} className="border-b border-border last:border-0">
+                  <td className="px-4 py-2.5">
+                    <div className="font-medium text-ink">{inst.name}</div>
+                    <div className="text-[12px] text-ink-muted">
+                      {inst.package ? `${inst.package.name} ${inst.package.version}` : '—'}
+                      {!inst.enabled && ` · ${tr('désactivé', 'disabled')}`}
+                    </div>
+                  </td>
+                  <td className="px-4 py-2.5 text-[12px] text-ink-soft">
+                    {[...(inst.allowed_hosts ?? []), ...(inst.secret_names ?? []).map((s) => `secret:${s}`)].join(', ') || tr('aucun', 'none')}
+                  </td>
+                  <td className="px-4 py-2.5 text-[12px] text-ink-soft">{inst.memory_pages * 64 / 1024} MiB · {inst.timeout_ms / 1000} s</td>
+                  <td className="px-4 py-2.5 text-[12px]">
+                    <span style={{ color: inst.last_run_status === 'failed' ? 'var(--critical)' : undefined }}>
+                      {inst.last_run_at ? fmtDate(inst.last_run_at) : '—'}
+                    </span>
+                  </td>
+                  <td className="px-4 py-2.5">
+                    <div className="flex justify-end gap-2">
+                      <Btn icon={Play} label={tr('Exécuter', 'Run')} onClick={() => setRunsOf(inst)} />
+                      <Btn icon={Settings2} label={tr('Accès', 'Grants')} onClick={() => setEditing(inst)} />
+                      <button
+                        type="button"
+                        aria-label={tr('Supprimer', 'Delete')}
+                        className="text-ink-muted hover:text-ink"
+                        onClick={() => window.confirm(tr(`Retirer ${inst.name} et son historique ?`, `Remove ${inst.name} and its run log?`))
+                          && removeInstall.mutate(inst.id, { onError: error(tr('Suppression impossible.', 'Delete failed.')) })}
+                      >
+                        <Trash2 size={15} />
+                      </button>
+                    </div>
+                  </td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        </Card>
+      )}
+
+      {editing && <GrantsDrawer install={editing} onClose={() => setEditing(null)} tr={tr} />}
+      {runsOf && <RunsDrawer install={runsOf} onClose={() => setRunsOf(null)} tr={tr} fmtDate={fmtDate} />}
+    </PageFrame>
+  );
+}
+
+function Drawer({ title, subtitle, onClose, tr, children }: { title: string; subtitle?: string; onClose: () => void; tr: Tr; children: ReactNode }) {
+  return (
+    <div className="fixed inset-0 z-[80] flex justify-end" style={{ background: 'var(--surface-overlay)' }} onClick={onClose}>
+      <div
+        onClick={(e) => e.stopPropagation()}
+        className="h-full w-full max-w-[640px] overflow-y-auto p-5"
+        style={{ background: 'var(--bg-elevated)', borderLeft: '1px solid var(--border)' }}
+      >
+        <div className="mb-4 flex items-start justify-between">
+          <div>
+            <h2 className="text-[16px] font-bold text-ink">{title}</h2>
+            {subtitle && <p className="text-[12px] text-ink-muted">{subtitle}</p>}
+          </div>
+          <button type="button" onClick={onClose} aria-label={tr('Fermer', 'Close')}><X size={18} /></button>
+        </div>
+        {children}
+      </div>
+    </div>
+  );
+}
+
+function GrantsDrawer({ install, onClose, tr }: { install: PluginInstall; onClose: () => void; tr: Tr }) {
+  const pkg = install.package;
+  const [name, setName] = useState(install.name);
+  const [enabled, setEnabled] = useState(install.enabled);
+  const [hosts, setHosts] = useState((install.allowed_hosts ?? []).join('\n'));
+  const [secrets, setSecrets] = useState<Record<string, string>>({});
+  const [memoryMiB, setMemoryMiB] = useState(install.memory_pages * 64 / 1024);
+  const [timeoutS, setTimeoutS] = useState(install.timeout_ms / 1000);
+  const [config, setConfig] = useState(JSON.stringify(install.config ?? {}, null, 2));
+  const update = useUpdateInstall();
+
+  const granted = new Set(install.secret_names ?? []);
+
+  const save = () => {
+    let parsed: Record<string, unknown>;
+    try {
+      parsed = JSON.parse(config || '{}');
+    } catch {
+      toast.error(tr('La configuration doit être un objet JSON.', 'Config must be a JSON object.'));
+      return;
+    }
+    update.mutate({
+      id: install.id,
+      input: {
+        name,
+        enabled,
+        config: parsed,
+        allowed_hosts: hosts.split(/[\s,]+/).filter(Boolean),
+        secrets,
+        memory_pages: Math.round(memoryMiB * 1024 / 64),
+        timeout_ms: Math.round(timeoutS * 1000),
+      },
+    }, {
+      onSuccess: () => { toast.success(tr('Accès enregistrés', 'Grants saved')); onClose(); },
+      onError: (err) => toast.error(apiErrorMessage(err) || tr("Les accès n'ont pas pu être enregistrés.", 'The grants could not be saved.')),
+    });
+  };
+
+  const webhook = `${window.location.origin}/api/v1/plugins/webhook?token=${install.webhook_token ?? ''}`;
+
+  return (
+    <Drawer title={install.name} subtitle={pkg ? `${pkg.name} ${pkg.version} · ${pkg.publisher}` : undefined} onClose={onClose} tr={tr}>
+      <div className="space-y-4 text-[13px]">
+        <label className="block">
+          <span className="mb-1 block text-[12px] text-ink-muted">{tr('Nom', 'Name')}</span>
+          <input className={field} value={name} onChange={(e) => setName(e.target.value)} />
+        </label>
+        <label className="inline-flex items-center gap-2 text-ink-soft">
+          <input type="checkbox" checked={enabled} onChange={(e) => setEnabled(e.target.checked)} />
+          {tr('Activé', 'Enabled')}
+        </label>
+
+        <label className="block">
+          <span className="mb-1 block text-[12px] text-ink-muted">
+            {tr('Hôtes autorisés (un par ligne)', 'Allowed hosts (one per line)')}
+            {pkg?.requested_hosts?.length ? ` — ${tr('demandés', 'requested')} : ${pkg.requested_hosts.join(', ')}` : ''}
+          </span>
+          <textarea className={`${field} font-mono`} rows={3} value={hosts} onChange={(e) => setHosts(e.target.value)} />
+        </label>
+
+        {(pkg?.requested_secrets ?? []).length > 0 && (
+          <div>
+            <span className="mb-1 block text-[12px] text-ink-muted">{tr('Secrets (jamais réaffichés)', 'Secrets (never shown again)')}</span>
+            {(pkg?.requested_secrets ?? []).map((s) => (
+              <div key={s} className="mb-2 flex items-center gap-2">
+                <span className="w-[140px] font-mono text-[12px] text-ink">{s}</span>
+                <input
+                  type="password"
+                  className={field}
+                  placeholder={granted.has(s) ? tr('•••• accordé — saisir pour remplacer', '•••• granted — type to replace') : tr('non accordé', 'not granted')}
+                  value={secrets[s] ?? ''}
+                  onChange={(e) => setSecrets({ ...secrets, [s]: e.target.value })}
+                />
+                {granted.has(s) && (
+                  <Btn danger label={tr('Révoquer', 'Revoke')} onClick={() => setSecrets({ ...secrets, [s]: '' })} />
+                )}
+              </div>
+            ))}
+          </div>
+        )}
+
+        <div className="flex gap-3">
+          <label className="block flex-1">
+            <span className="mb-1 block text-[12px] text-ink-muted">{tr('Mémoire (Mio, 64 max)', 'Memory (MiB, 64 max)')}</span>
+            <input type="number" min={1} max={64} className={field} value={memoryMiB} onChange={(e) => setMemoryMiB(Number(e.target.value))} />
+          </label>
+          <label className="block flex-1">
+            <span className="mb-1 block text-[12px] text-ink-muted">{tr('Durée max (s, 60 max)', 'Time limit (s, 60 max)')}</span>
+            <input type="number" min={0.1} max={60} step={0.1} className={field} value={timeoutS} onChange={(e) => setTimeoutS(Number(e.target.value))} />
+          </label>
+        </div>
+
+        <label className="block">
+          <span className="mb-1 block text-[12px] text-ink-muted">{tr('Configuration (JSON)', 'Config (JSON)')}</span>
+          <textarea className={`${field} font-mono`} rows={6} value={config} onChange={(e) => setConfig(e.target.value)} />
+        </label>
+
+        {pkg?.hooks.includes('transform_webhook') && (
+          <div>
+            <span className="mb-1 block text-[12px] text-ink-muted">{tr('URL du webhook', 'Webhook URL')}</span>
+            <div className="flex gap-2">
+              <input readOnly className={`${field} font-mono text-[12px]`} value={webhook} />
+              <Btn icon={Copy} onClick={() => { navigator.clipboard.writeText(webhook); toast.success(tr('Copié', 'Copied')); }} />
+            </div>
+          </div>
+        )}
+
+        <div className="flex justify-end">
+          <Btn primary label={tr('Enregistrer', 'Save')} onClick={save} disabled={update.isPending} />
+        </div>
+      </div>
+    </Drawer>
+  );
+}
+
+function RunsDrawer({ install, onClose, tr, fmtDate }: { install: PluginInstall; onClose: () => void; tr: Tr; fmtDate: (d?: string) => string }) {
+  const navigate = useNavigate();
+  const runs = usePluginRuns(install.id);
+  const run = useRunPlugin();
+  const [summary, setSummary] = useState('');
+  const [open, setOpen] = useState<string | null>(null);
+  const hooks = (install.package?.hooks ?? []).filter((h) => h !== 'transform_webhook');
+
+  const start = (hook: PluginHook) => run.mutate({ id: install.id, hook, ticket: hook === 'create_ticket' ? { summary } : undefined }, {
+    onSuccess: (res) => {
+      if (res.run.status === 'failed') {
+        toast.error(res.run.error || tr("L'exécution a échoué.", 'The run failed.'));
+        return;
+      }
+      if (res.preview_job_id) {
+        navigate(`/infrastructure/scans/${res.preview_job_id}`);
+        return;
+      }
+      if (res.ticket) {
+        toast.success(tr(`Ticket ${res.ticket.key} créé`, `Ticket ${res.ticket.key} created`));
+        setSummary('');
+        return;
+      }
+      toast.success(tr(`${res.run.items} éléments traités`, `${res.run.items} items processed`));
+    },
+    onError: (err) => toast.error(apiErrorMessage(err) || tr("L'exécution a échoué.", 'The run failed.')),
+  });
+
+  return (
+    <Drawer title={install.name} subtitle={tr('Exécutions', 'Runs')} onClose={onClose} tr={tr}>
+      <div className="mb-4 flex flex-wrap items-end gap-2 text-[13px]">
+        {hooks.map((h) => (h === 'create_ticket' ? (
+          <div key={h} className="flex w-full gap-2">
+            <input className={field} placeholder={tr('Résumé du ticket', 'Ticket summary')} value={summary} onChange={(e) => setSummary(e.target.value)} />
+            <Btn icon={Play} label={hookLabel(h, tr)} onClick={() => start(h)} disabled={!summary.trim() || run.isPending || !install.enabled} />
+          </div>
+        ) : (
+          <Btn key={h} icon={Play} label={hookLabel(h, tr)} onClick={() => start(h)} disabled={run.isPending || !install.enabled} />
+        )))}
+      </div>
+
+      {runs.isLoading ? (
+        <SkeletonRows rows={4} />
+      ) : !runs.data?.length ? (
+        <EmptyState icon={Play} title={tr('Aucune exécution', 'No runs yet')} />
+      ) : (
+        <table className="w-full text-[12.5px]">
+          <thead>
+            <tr className="text-left text-[11px] uppercase tracking-wide text-ink-muted">
+              <th className="py-1 pr-3">{tr('Date', 'Date')}</th>
+              <th className="py-1 pr-3">{tr('Point d’entrée', 'Hook')}</th>
+              <th className="py-1 pr-3">{tr('Résultat', 'Result')}</th>
+              <th className="py-1">{tr('Durée · HTTP', 'Time · HTTP')}</th>
+            </tr>
+          </thead>
+          <tbody>
+            {runs.data.map((r) => (
+              <Fragment key={r.id}>
+                <tr className="cursor-pointer border-t border-border" onClick={() => setOpen(open === r.id ? null : r.id)}>
+                  <td className="py-1.5 pr-3 text-ink-soft">{fmtDate(r.created_at)}</td>
+                  <td className="py-1.5 pr-3 text-ink-soft">{hookLabel(r.hook, tr)}</td>
+                  <td className="py-1.5 pr-3" style={{ color: r.status === 'failed' ? 'var(--critical)' : undefined }}>
+                    {r.status === 'failed' ? r.error || tr('Échec', 'Failed') : `${r.items} ${tr('éléments', 'items')}`}
+                  </td>
+                  <td className="py-1.5 text-ink-soft">{r.duration_ms} ms · {r.http_calls}</td>
+                </tr>
+                {open === r.id && (r.logs ?? []).length > 0 && (
+                  <tr>
+                    <td colSpan={4} className="pb-2">
+                      <pre className="max-h-[200px] overflow-auto rounded-[8px] bg-[var(--bg-hover)] p-2 text-[11.5px] text-ink-soft">{(r.logs ?? []).join('\n')}</pre>
+                    </td>
+                  </tr>
+                )}
+              </Fragment>
+            ))}
+          </tbody>
+        </table>
+      )}
+    </Drawer>
+  );
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+//
+// Typed client for connector plugins. Mirrors domain.PluginPackage /
+// PluginInstall / PluginRun and the application/plugins run result. Module
+// bytes and secret values are never returned.
+
+import { api } from '../../lib/api';
+
+export type PluginHook = 'pull_findings' | 'emit_assets' | 'create_ticket' | 'transform_webhook';
+export type PluginRunStatus = 'ok' | 'failed';
+
+export interface PluginPackage {
+  id: string;
+  name: string;
+  version: string;
+  publisher: string;
+  description: string;
+  abi_version: number;
+  hooks: PluginHook[];
+  requested_hosts: string[] | null;
+  requested_secrets: string[] | null;
+  key_id: string;
+  digest: string;
+  size: number;
+  created_at: string;
+}
+
+export interface PluginInstall {
+  id: string;
+  package_id: string;
+  name: string;
+  enabled: boolean;
+  config: Record<string, unknown> | null;
+  allowed_hosts: string[] | null;
+  secret_names: string[] | null;
+  memory_pages: number;
+  timeout_ms: number;
+  webhook_token?: string;
+  last_run_at?: string;
+  last_run_status?: PluginRunStatus;
+  created_at: string;
+  package?: PluginPackage;
+}
+
+export interface InstallInput {
+  package_id?: string;
+  name?: string;
+  enabled?: boolean;
+  config?: Record<string, unknown>;
+  allowed_hosts?: string[];
+  /** Secret name → value; an empty value revokes the grant. */
+  secrets?: Record<string, string>;
+  memory_pages?: number;
+  timeout_ms?: number;
+}
+
+export interface PluginRun {
+  id: string;
+  install_id: string;
+  hook: PluginHook;
+  status: PluginRunStatus;
+  duration_ms: number;
+  http_calls: number;
+  items: number;
+  error?: string;
+  logs: string[] | null;
+  created_at: string;
+}
+
+export interface TicketInput {
+  summary: string;
+  description?: string;
+  priority?: string;
+  labels?: string[];
+}
+
+export interface RunResult {
+  run: PluginRun;
+  /** Scan preview the emitted assets were staged under (/infrastructure/scans/:id). */
+  preview_job_id?: string;
+  ticket?: { key: string; url?: string };
+}
+
+export const pluginService = {
+  listPackages: async (): Promise<PluginPackage[]> => {
+    const res = await api.get<{ items: PluginPackage[] }>('/plugins');
+    return res.data.items ?? [];
+  },
+
+  /** Upload a signed bundle (zip of manifest.json, plugin.wasm, signature.json). */
+  upload: async (file: File): Promise<PluginPackage> => {
+    const form = new FormData();
+    form.append('bundle', file);
+    const res = await api.post<PluginPackage>('/plugins', form);
+    return res.data;
+  },
+
+  removePackage: async (id: string): Promise<void> => {
+    await api.delete(`/plugins/${id}`);
+  },
+
+  listInstalls: async (): Promise<PluginInstall[]> => {
+    const res = await api.get<{ items: PluginInstall[] }>('/plugin-installs');
+    return res.data.items ?? [];
+  },
+
+  createInstall: async (input: InstallInput): Promise<PluginInstall> => {
+    const res = await api.post<PluginInstall>('/plugin-installs', input);
+    return res.data;
+  },
+
+  updateInstall: async (id: string, input: InstallInput): Promise<PluginInstall> => {
+    const res = await api.put<PluginInstall>(`/plugin-installs/${id}`, input);
+    return res.data;
+  },
+
+  removeInstall: async (id: string): Promise<void> => {
+    await api.delete(`/plugin-installs/${id}`);
+  },
+
+  run: async (id: string, hook: PluginHook, ticket?: TicketInput): Promise<RunResult> => {
+    const res = await api.post<RunResult>(`/plugin-installs/${id}/run`, { hook, ticket });
+    return res.data;
+  },
+
+  runs: async (id: string): Promise<PluginRun[]> => {
+    const res = await api.get<{ items: PluginRun[] }>(`/plugin-installs/${id}/runs`);
+    return res.data.items ?? [];
+  },
+};
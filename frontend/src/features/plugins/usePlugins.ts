@@ -0,0 +1,67 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { pluginService, type InstallInput, type PluginHook, type TicketInput } from './pluginService';
+
+export function usePluginPackages() {
+  return useQuery({ queryKey: ['plugins'], queryFn: () => pluginService.listPackages() });
+}
+
+export function usePluginInstalls() {
+  return useQuery({ queryKey: ['plugin-installs'], queryFn: () => pluginService.listInstalls() });
+}
+
+export function usePluginRuns(installId: string | null) {
+  return useQuery({
+    queryKey: ['plugin-runs', installId],
+    queryFn: () => pluginService.runs(installId as string),
+    enabled: !!installId,
+  });
+}
+
+function usePluginMutation<V, R>(fn: (v: V) => Promise<R>) {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: fn,
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ['plugins'] });
+      qc.invalidateQueries({ queryKey: ['plugin-installs'] });
+    },
+  });
+}
+
+export function useUploadPlugin() {
+  return usePluginMutation((file: File) => pluginService.upload(file));
+}
+
+export function useDeletePlugin() {
+  return usePluginMutation((id: string) => pluginService.removePackage(id));
+}
+
+export function useCreateInstall() {
+  return usePluginMutation((input: InstallInput) => pluginService.createInstall(input));
+}
+
+export function useUpdateInstall() {
+  return usePluginMutation(({ id, input }: { id: string; input: InstallInput }) => pluginService.updateInstall(id, input));
+}
+
+export function useDeleteInstall() {
+  return usePluginMutation((id: string) => pluginService.removeInstall(id));
+}
+
+/** A run stamps the install's last run and may ingest findings. */
+export function useRunPlugin() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: ({ id, hook, ticket }: { id: string; hook: PluginHook; ticket?: TicketInput }) => pluginService.run(id, hook, ticket),
+    onSuccess: (_, { id }) => {
+      qc.invalidateQueries({ queryKey: ['plugin-installs'] });
+      qc.invalidateQueries({ queryKey: ['plugin-runs', id] });
+      qc.invalidateQueries({ queryKey: ['vulnerabilities'] });
+    },
+  });
+}
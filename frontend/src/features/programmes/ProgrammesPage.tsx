@@ -0,0 +1,439 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+//
+// /risks/programmes — mitigation programmes shared by several risks.
+//
+// A programme carries its own actions (effort, assignee, dependencies). The
+// server schedules them against each assignee's capacity and projects the
+// programme's end; when that end passes the target date, every linked risk
+// keeps only the share of its expected reduction delivered by then. The
+// detail panel shows both sides: the schedule with its critical path, and the
+// planned vs projected residual of each risk.
+
+import { useEffect, useMemo, useState } from 'react';
+import { useQuery } from '@tanstack/react-query';
+import { toast } from 'sonner';
+import { FolderKanban, Plus, Trash2, X, Check } from 'lucide-react';
+import { PageFrame, PageHeader, Card, Btn, SkeletonRows, EmptyState, ErrorState } from '../../shared/ui';
+import { useUIStore } from '../../store/uiStore';
+import { useAuthStore } from '../../hooks/useAuthStore';
+import { useRiskStore } from '../../hooks/useRiskStore';
+import { apiErrorMessage } from '../../lib/apiError';
+import { ownershipService, type Assignee } from '../../services/ownershipService';
+import {
+  useAddProgrammeAction,
+  useCreateProgramme,
+  useDeleteProgramme,
+  useDeleteProgrammeAction,
+  useLinkProgrammeRisk,
+  useProgramme,
+  useProgrammeProjection,
+  useProgrammes,
+  useUnlinkProgrammeRisk,
+  useUpdateProgrammeAction,
+} from './useProgrammes';
+import type { MitigationProgramme, ProgrammeAction, ProgrammeSchedule, ProgrammeStatus } from './programmeService';
+
+type Tr = (fr: string, en: string) => string;
+
+const field = 'w-full rounded-[10px] border border-border bg-transparent px-3 py-2 text-[13px] text-ink';
+
+function statusLabel(s: ProgrammeStatus, tr: Tr): string {
+  switch (s) {
+    case 'IN_PROGRESS': return tr('En cours', 'In progress');
+    case 'REVIEW': return tr('En revue', 'Review');
+    case 'DONE': return tr('Terminé', 'Done');
+    case 'CANCELLED': return tr('Annulé', 'Cancelled');
+    default: return tr('Planifié', 'Planned');
+  }
+}
+
+const pct = (v: number) => `${Math.round(v * 100)} %`;
+
+// Date inputs give a calendar day; the API takes an RFC 3339 instant.
+const toInstant = (d: string) => (d ? new Date(`${d}T00:00:00Z`).toISOString() : null);
+
+function SlipBadge({ days, tr }: { days: number; tr: Tr }) {
+  if (days <= 0) return <span style={{ color: 'var(--low)' }}>{tr('Dans les temps', 'On track')}</span>;
+  return <span style={{ color: 'var(--critical)' }}>{tr(`+${days} j de retard`, `${days} d late`)}</span>;
+}
+
+export function ProgrammesPage() {
+  const lang = useUIStore((s) => s.lang);
+  const tr: Tr = (fr, en) => (lang === 'fr' ? fr : en);
+  const canCreate = useAuthStore((s) => s.hasPermission('mitigations:create'));
+  const [open, setOpen] = useState<string | null>(null);
+  const [creating, setCreating] = useState(false);
+
+  const { data: programmes, isLoading, isError, refetch } = useProgrammes();
+  const fmtDate = (d?: string) => (d ? new Date(d).toLocaleDateString(lang === 'fr' ? 'fr-FR' : 'en-GB') : '—');
+
+  return (
+    <PageFrame>
+      <PageHeader
+        title={tr('Programmes de traitement', 'Mitigation programmes')}
+        count={programmes?.length ? String(programmes.length) : null}
+        actions={canCreate ? <Btn primary icon={Plus} label={tr('Nouveau programme', 'New programme')} onClick={() => setCreating(true)} /> : undefined}
+      />
+
+      {isLoading ? (
+        <Card><SkeletonRows rows={5} /></Card>
+      ) : isError ? (
+        <ErrorState title={tr('Impossible de charger les programmes.', 'Could not load programmes.')} onRetry={() => refetch()} retryLabel={tr('Réessayer', 'Retry')} />
+      ) : !programmes?.length ? (
+        <Card>
+          <EmptyState icon={FolderKanban} title={tr('Aucun programme', 'No programmes yet')} />
+        </Card>
+      ) : (
+        <Card style={{ padding: 0, overflow: 'hidden' }}>
+          <table className="w-full text-[13px]">
+            <thead>
+              <tr className="border-b border-border text-left text-[11.5px] uppercase tracking-wide text-ink-muted">
+                <th className="px-4 py-2.5">{tr('Programme', 'Programme')}</th>
+                <th className="px-4 py-2.5">{tr('Statut', 'Status')}</th>
+                <th className="px-4 py-2.5">{tr('Risques', 'Risks')}</th>
+                <th className="px-4 py-2.5">{tr('Avancement', 'Progress')}</th>
+                <th className="px-4 py-2.5">{tr('Cible', 'Target')}</th>
+                <th className="px-4 py-2.5">{tr('Fin projetée', 'Projected end')}</th>
+              </tr>
+            </thead>
+            <tbody>
+              {programmes.map((p) => (
+                <tr key={p.id} className="cursor-pointer border-b border-border last:border-0 hover:bg-[var(--bg-hover)]" onClick={() => setOpen(p.id)}>
+                  <td className="px-4 py-2.5 font-medium text-ink">{p.title}</td>
+                  <td className="px-4 py-2.5 text-ink-soft">{statusLabel(p.status, tr)}</td>
+                  <td className="px-4 py-2.5 text-ink-soft">{p.risk_count}</td>
+                  <td className="px-4 py-2.5 text-ink-soft">{p.progress} %</td>
+                  <td className="px-4 py-2.5 text-ink-soft">{fmtDate(p.target_date)}</td>
+                  <td className="px-4 py-2.5">
+                    {fmtDate(p.projected_end)}
+                    {p.target_date && <div className="text-[11.5px]"><SlipBadge days={p.slip_days} tr={tr} /></div>}
+                  </td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        </Card>
+      )}
+
+      {creating && <CreateDialog onClose={() => setCreating(false)} onCreated={(p) => { setCreating(false); setOpen(p.id); }} tr={tr} />}
+      {open && <DetailPanel id={open} onClose={() => setOpen(null)} tr={tr} fmtDate={fmtDate} />}
+    </PageFrame>
+  );
+}
+
+function Overlay({ children, onClose, wide }: { children: React.ReactNode; onClose: () => void; wide?: boolean }) {
+  return (
+    <div className="fixed inset-0 z-[80] flex justify-end" style={{ background: 'var(--surface-overlay)' }} onClick={onClose}>
+      <div
+        onClick={(e) => e.stopPropagation()}
+        className={`h-full w-full ${wide ? 'max-w-[760px]' : 'max-w-[520px]'} overflow-y-auto p-5`}
+        style={{ background: 'var(--bg-elevated)', borderLeft: '1px solid var(--border)' }}
+      >
+        {children}
+      </div>
+    </div>
+  );
+}
+
+function CreateDialog({ onClose, onCreated, tr }: { onClose: () => void; onCreated: (p: MitigationProgramme) => void; tr: Tr }) {
+  const create = useCreateProgramme();
+  const [title, setTitle] = useState('');
+  const [description, setDescription] = useState('');
+  const [start, setStart] = useState('');
+  const [target, setTarget] = useState('');
+
+  const submit = () => {
+    create.mutate({
+      title: title.trim(),
+      description,
+      start_date: toInstant(start) ?? undefined,
+      target_date: toInstant(target),
+    }, {
+      onSuccess: (p) => {
+        toast.success(tr('Programme créé', 'Programme created'));
+        onCreated(p);
+      },
+      onError: (err) => toast.error(apiErrorMessage(err) || tr('La création a échoué.', 'Creation failed.')),
+    });
+  };
+
+  return (
+    <Overlay onClose={onClose}>
+      <div className="mb-4 flex items-start justify-between">
+        <h2 className="text-[16px] font-bold text-ink">{tr('Nouveau programme', 'New programme')}</h2>
+        <button type="button" onClick={onClose} aria-label={tr('Fermer', 'Close')}><X size={18} /></button>
+      </div>
+      <div className="space-y-3 text-[13px]">
+        <input className={field} placeholder={tr('Titre', 'Title')} value={title} onChange={(e) => setTitle(e.target.value)} />
+        <textarea className={field} rows={3} placeholder={tr('Description', 'Description')} value={description} onChange={(e) => setDescription(e.target.value)} />
+        <div className="grid grid-cols-2 gap-2">
+          <label className="block">
+            <span className="mb-1 block text-[12px] text-ink-muted">{tr('Début', 'Start')}</span>
+            <input className={field} type="date" value={start} onChange={(e) => setStart(e.target.value)} />
+          </label>
+          <label className="block">
+            <span className="mb-1 block text-[12px] text-ink-muted">{tr('Date cible', 'Target date')}</span>
+            <input className={field} type="date" value={target} onChange={(e) => setTarget(e.target.value)} />
+          </label>
+        </div>
+        <div className="flex justify-end gap-2">
+          <Btn label={tr('Annuler', 'Cancel')} onClick={onClose} />
+          <Btn primary label={tr('Créer', 'Create')} onClick={submit} disabled={!title.trim() || create.isPending} />
+        </div>
+      </div>
+    </Overlay>
+  );
+}
+
+function DetailPanel({ id, onClose, tr, fmtDate }: { id: string; onClose: () => void; tr: Tr; fmtDate: (d?: string) => string }) {
+  const canUpdate = useAuthStore((s) => s.hasPermission('mitigations:update'));
+  const canDelete = useAuthStore((s) => s.hasPermission('mitigations:delete'));
+  const { data: detail, isLoading } = useProgramme(id);
+  const { data: projection } = useProgrammeProjection(id);
+  const remove = useDeleteProgramme();
+  const unlink = useUnlinkProgrammeRisk();
+  const updateAction = useUpdateProgrammeAction();
+  const deleteAction = useDeleteProgrammeAction();
+
+  const { data: assignable } = useQuery({
+    queryKey: ['ownership', 'assignable', 'mitigations'],
+    queryFn: () => ownershipService.listAssignable({}),
+  });
+  const users: Assignee[] = assignable?.users ?? [];
+  const userName = (uid?: string) => users.find((u) => u.user_id === uid)?.full_name ?? (uid ? uid.slice(0, 8) : '—');
+
+  const onError = (err: unknown) => toast.error(apiErrorMessage(err) || tr("L'opération a échoué.", 'The operation failed.'));
+
+  const toggleDone = (a: ProgrammeAction) =>
+    updateAction.mutate({
+      actionId: a.id,
+      input: { title: a.title, effort_days: a.effort_days, assignee_id: a.assignee_id ?? null, order: a.order, depends_on: a.depends_on, completed: !a.completed },
+    }, { onError });
+
+  const destroy = () => {
+    if (!window.confirm(tr('Supprimer ce programme ?', 'Delete this programme?'))) return;
+    remove.mutate(id, { onSuccess: onClose, onError });
+  };
+
+  return (
+    <Overlay onClose={onClose} wide>
+      {isLoading || !detail ? (
+        <SkeletonRows rows={6} />
+      ) : (
+        <div className="space-y-5 text-[13px]">
+          <div className="flex items-start justify-between">
+            <div>
+              <h2 className="text-[16px] font-bold text-ink">{detail.programme.title}</h2>
+              <div className="text-[12.5px] text-ink-muted">
+                {statusLabel(detail.programme.status, tr)} · {detail.schedule.progress} % · {tr('fin projetée', 'projected end')} {fmtDate(detail.schedule.projected_end)}
+                {detail.schedule.target_date && <> · <SlipBadge days={detail.schedule.slip_days} tr={tr} /></>}
+              </div>
+            </div>
+            <div className="flex items-center gap-2">
+              {canDelete && <Btn icon={Trash2} label={tr('Supprimer', 'Delete')} onClick={destroy} />}
+              <button type="button" onClick={onClose} aria-label={tr('Fermer', 'Close')}><X size={18} /></button>
+            </div>
+          </div>
+
+          <section>
+            <h3 className="mb-2 text-[12px] font-semibold uppercase tracking-wide text-ink-muted">{tr('Planning', 'Schedule')}</h3>
+            <ScheduleChart schedule={detail.schedule} userName={userName} tr={tr} />
+            {canUpdate && detail.actions.length > 0 && (
+              <div className="mt-2 space-y-1">
+                {detail.actions.map((a) => (
+                  <div key={a.id} className="flex items-center justify-between rounded-[8px] px-2 py-1 hover:bg-[var(--bg-hover)]">
+                    <label className="flex items-center gap-2">
+                      <input type="checkbox" checked={a.completed} onChange={() => toggleDone(a)} />
+                      <span className={a.completed ? 'text-ink-muted line-through' : 'text-ink'}>{a.title}</span>
+                    </label>
+                    <button type="button" aria-label={tr('Supprimer', 'Delete')} onClick={() => deleteAction.mutate(a.id, { onError })}>
+                      <Trash2 size={14} className="text-ink-muted" />
+                    </button>
+                  </div>
+                ))}
+              </div>
+            )}
+            {canUpdate && <ActionForm programmeId={id} actions={detail.actions} users={users} tr={tr} />}
+          </section>
+
+          <section>
+            <h3 className="mb-2 text-[12px] font-semibold uppercase tracking-wide text-ink-muted">{tr('Risques traités', 'Risks treated')}</h3>
+            {!projection?.length ? (
+              <p className="text-ink-muted">{tr('Aucun risque lié.', 'No risk linked yet.')}</p>
+            ) : (
+              <table className="w-full text-[12.5px]">
+                <thead>
+                  <tr className="text-left text-[11px] uppercase tracking-wide text-ink-muted">
+                    <th className="py-1.5">{tr('Risque', 'Risk')}</th>
+                    <th className="py-1.5">{tr('Inhérent', 'Inherent')}</th>
+                    <th className="py-1.5">{tr('Résiduel prévu', 'Planned residual')}</th>
+                    <th className="py-1.5">{tr('Résiduel projeté', 'Projected residual')}</th>
+                    <th />
+                  </tr>
+                </thead>
+                <tbody>
+                  {projection.map((r) => (
+                    <tr key={r.risk_id} className="border-t border-border">
+                      <td className="py-1.5 text-ink">{r.title}</td>
+                      <td className="py-1.5 text-ink-soft">{r.inherent.toFixed(2)}</td>
+                      <td className="py-1.5 text-ink-soft">{r.planned_residual.toFixed(2)} <span className="text-ink-muted">(−{pct(r.planned_reduction)})</span></td>
+                      <td className="py-1.5" style={{ color: r.slipping ? 'var(--critical)' : 'var(--ink-soft)' }}>
+                        {r.projected_residual.toFixed(2)} <span className="text-ink-muted">(−{pct(r.projected_reduction)})</span>
+                      </td>
+                      <td className="py-1.5 text-right">
+                        {canUpdate && (
+                          <button type="button" aria-label={tr('Délier', 'Unlink')} onClick={() => unlink.mutate({ id, riskId: r.risk_id }, { onError })}>
+                            <X size={14} className="text-ink-muted" />
+                          </button>
+                        )}
+                      </td>
+                    </tr>
+                  ))}
+                </tbody>
+              </table>
+            )}
+            {canUpdate && <RiskLinkForm programmeId={id} linked={detail.risks.map((r) => r.risk_id)} tr={tr} />}
+          </section>
+        </div>
+      )}
+    </Overlay>
+  );
+}
+
+// One bar per action on a working-day axis; critical actions are drawn in the
+// critical colour, the target date as a vertical rule.
+function ScheduleChart({ schedule, userName, tr }: { schedule: ProgrammeSchedule; userName: (id?: string) => string; tr: Tr }) {
+  const { start, end } = useMemo(() => {
+    const s = new Date(schedule.start).getTime();
+    let e = new Date(schedule.projected_end).getTime();
+    if (schedule.target_date) e = Math.max(e, new Date(schedule.target_date).getTime());
+    return { start: s, end: Math.max(e, s + 86400000) };
+  }, [schedule]);
+
+  if (!schedule.actions.length) {
+    return <p className="text-ink-muted">{tr('Aucune action planifiée.', 'No actions planned yet.')}</p>;
+  }
+
+  const pos = (d: string) => ((new Date(d).getTime() - start) / (end - start)) * 100;
+  const target = schedule.target_date ? pos(schedule.target_date) : null;
+
+  return (
+    <div className="relative space-y-1.5">
+      {target != null && (
+        <div className="absolute top-0 bottom-0 w-px" style={{ left: `calc(40% + ${target * 0.6}%)`, background: 'var(--medium)' }} title={tr('Date cible', 'Target date')} />
+      )}
+      {schedule.actions.map((a) => {
+        const left = pos(a.start);
+        const width = Math.max(pos(a.finish) - left, 1);
+        return (
+          <div key={a.action_id} className="flex items-center gap-2 text-[12px]">
+            <div className="w-[40%] truncate">
+              <span className={a.completed ? 'text-ink-muted line-through' : 'text-ink'}>{a.title}</span>
+              <span className="text-ink-muted"> · {userName(a.assignee_id)} · {a.duration_days} {tr('j', 'd')}</span>
+            </div>
+            <div className="relative h-3 w-[60%] rounded-full bg-[var(--bg-hover)]">
+              <div
+                className="absolute h-3 rounded-full"
+                style={{
+                  left: `${left}%`,
+                  width: `${width}%`,
+                  background: a.completed ? 'var(--low)' : a.critical ? 'var(--critical)' : 'var(--accent)',
+                  opacity: a.completed ? 0.5 : 1,
+                }}
+                title={a.critical ? tr('Chemin critique', 'Critical path') : tr(`Marge : ${a.slack_days} j`, `Slack: ${a.slack_days} d`)}
+              />
+            </div>
+          </div>
+        );
+      })}
+    </div>
+  );
+}
+
+function ActionForm({ programmeId, actions, users, tr }: { programmeId: string; actions: ProgrammeAction[]; users: Assignee[]; tr: Tr }) {
+  const add = useAddProgrammeAction();
+  const [title, setTitle] = useState('');
+  const [effort, setEffort] = useState(5);
+  const [assignee, setAssignee] = useState('');
+  const [dependsOn, setDependsOn] = useState<string[]>([]);
+
+  const submit = () => {
+    add.mutate({
+      id: programmeId,
+      input: { title: title.trim(), effort_days: effort, assignee_id: assignee || null, order: actions.length, depends_on: dependsOn, completed: false },
+    }, {
+      onSuccess: () => { setTitle(''); setDependsOn([]); },
+      onError: (err) => toast.error(apiErrorMessage(err) || tr("L'ajout a échoué.", 'Could not add the action.')),
+    });
+  };
+
+  return (
+    <div className="mt-3 space-y-2 rounded-[10px] border border-border p-3">
+      <div className="grid grid-cols-[1fr_90px_1fr] gap-2">
+        <input className={field} placeholder={tr('Nouvelle action', 'New action')} value={title} onChange={(e) => setTitle(e.target.value)} />
+        <input className={field} type="number" min={0} step={0.5} value={effort} onChange={(e) => setEffort(Number(e.target.value))} title={tr('Charge (jours)', 'Effort (days)')} />
+        <select className={field} value={assignee} onChange={(e) => setAssignee(e.target.value)}>
+          <option value="">{tr('Non assignée', 'Unassigned')}</option>
+          {users.map((u) => <option key={u.user_id} value={u.user_id}>{u.full_name}</option>)}
+        </select>
+      </div>
+      {actions.length > 0 && (
+        <div className="flex flex-wrap gap-1.5 text-[12px]">
+          <span className="text-ink-muted">{tr('Après :', 'After:')}</span>
+          {actions.map((a) => {
+            const on = dependsOn.includes(a.id);
+            return (
+              <button
+                key={a.id}
+                type="button"
+                className={`rounded-full border px-2 py-0.5 ${on ? 'border-accent text-accent' : 'border-border text-ink-soft'}`}
+                onClick={() => setDependsOn(on ? dependsOn.filter((x) => x !== a.id) : [...dependsOn, a.id])}
+              >
+                {on && <Check size={11} className="mr-1 inline" />}{a.title}
+              </button>
+            );
+          })}
+        </div>
+      )}
+      <div className="flex justify-end">
+        <Btn primary icon={Plus} label={tr('Ajouter', 'Add')} onClick={submit} disabled={!title.trim() || add.isPending} />
+      </div>
+    </div>
+  );
+}
+
+function RiskLinkForm({ programmeId, linked, tr }: { programmeId: string; linked: string[]; tr: Tr }) {
+  const risks = useRiskStore((s) => s.risks);
+  const fetchRisks = useRiskStore((s) => s.fetchRisks);
+  const link = useLinkProgrammeRisk();
+  const [riskId, setRiskId] = useState('');
+  const [reduction, setReduction] = useState(30);
+
+  useEffect(() => {
+    if (!risks.length) fetchRisks({ limit: 200 });
+  }, [risks.length, fetchRisks]);
+
+  const candidates = risks.filter((r) => !linked.includes(r.id));
+
+  const submit = () => {
+    link.mutate({ id: programmeId, riskId, reduction: reduction / 100 }, {
+      onSuccess: () => setRiskId(''),
+      onError: (err) => toast.error(apiErrorMessage(err) || tr('La liaison a échoué.', 'Could not link the risk.')),
+    });
+  };
+
+  return (
+    <div className="mt-3 grid grid-cols-[1fr_110px_auto] gap-2">
+      <select className={field} value={riskId} onChange={(e) => setRiskId(e.target.value)}>
+        <option value="">{tr('Lier un risque…', 'Link a risk…')}</option>
+        {candidates.map((r) => <option key={r.id} value={r.id}>{r.title}</option>)}
+      </select>
+      <label className="flex items-center gap-1 text-[12px] text-ink-muted">
+        <input className={field} type="number" min={0} max={90} value={reduction} onChange={(e) => setReduction(Number(e.target.value))} title={tr('Réduction attendue', 'Expected reduction')} />
+        %
+      </label>
+      <Btn primary label={tr('Lier', 'Link')} onClick={submit} disabled={!riskId || link.isPending} />
+    </div>
+  );
+}
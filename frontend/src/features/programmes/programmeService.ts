@@ -0,0 +1,195 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+//
+// Typed client for mitigation programmes. Mirrors domain.MitigationProgramme /
+// domain.ProgrammeAction / domain.ProgrammeSchedule and the
+// application/programme views (detail, risk projection).
+
+import { api } from '../../lib/api';
+
+export type ProgrammeStatus = 'PLANNED' | 'IN_PROGRESS' | 'REVIEW' | 'DONE' | 'CANCELLED';
+
+export interface MitigationProgramme {
+  id: string;
+  title: string;
+  description: string;
+  status: ProgrammeStatus;
+  owner_id?: string;
+  start_date: string;
+  target_date?: string;
+  risk_count: number;
+  control_count: number;
+  /** 0..100 — share of the effort completed. */
+  progress: number;
+  projected_end?: string;
+  /** Calendar days past the target (negative when ahead). */
+  slip_days: number;
+}
+
+export interface ProgrammeAction {
+  id: string;
+  programme_id: string;
+  title: string;
+  effort_days: number;
+  assignee_id?: string;
+  order: number;
+  completed: boolean;
+  completed_at?: string;
+  depends_on: string[];
+}
+
+export interface ScheduledAction {
+  action_id: string;
+  title: string;
+  assignee_id?: string;
+  effort_days: number;
+  duration_days: number;
+  start: string;
+  finish: string;
+  slack_days: number;
+  critical: boolean;
+  completed: boolean;
+}
+
+export interface ProgrammeSchedule {
+  start: string;
+  projected_end: string;
+  target_date?: string;
+  slip_days: number;
+  progress: number;
+  critical_path: string[];
+  actions: ScheduledAction[];
+}
+
+export interface LinkedRisk {
+  risk_id: string;
+  title: string;
+  score: number;
+  criticality: string;
+  expected_reduction: number;
+}
+
+export interface LinkedControl {
+  control_id: string;
+  reference_code: string;
+  name: string;
+}
+
+export interface ProgrammeDetail {
+  programme: MitigationProgramme;
+  risks: LinkedRisk[];
+  controls: LinkedControl[];
+  actions: ProgrammeAction[];
+  schedule: ProgrammeSchedule;
+}
+
+export interface Contribution {
+  programme_id: string;
+  title: string;
+  status: ProgrammeStatus;
+  expected_reduction: number;
+  target_date?: string;
+  projected_end: string;
+  slip_days: number;
+  reduction_at_target: number;
+}
+
+export interface RiskProjection {
+  risk_id: string;
+  title: string;
+  inherent: number;
+  planned_reduction: number;
+  planned_residual: number;
+  projected_reduction: number;
+  projected_residual: number;
+  full_reduction_at?: string;
+  slipping: boolean;
+  programmes: Contribution[];
+}
+
+export interface ProgrammeInput {
+  title: string;
+  description?: string;
+  status?: ProgrammeStatus;
+  owner_id?: string | null;
+  start_date?: string;
+  target_date?: string | null;
+}
+
+export interface ActionInput {
+  title: string;
+  effort_days: number;
+  assignee_id?: string | null;
+  order?: number;
+  depends_on: string[];
+  completed: boolean;
+}
+
+export interface AssigneeCapacity {
+  user_id: string;
+  fte: number;
+  updated_at: string;
+}
+
+export const programmeService = {
+  async list(): Promise<MitigationProgramme[]> {
+    const { data } = await api.get<{ items: MitigationProgramme[] }>('/mitigation-programmes');
+    return data.items ?? [];
+  },
+  async get(id: string): Promise<ProgrammeDetail> {
+    const { data } = await api.get<ProgrammeDetail>(`/mitigation-programmes/${id}`);
+    return data;
+  },
+  async create(input: ProgrammeInput): Promise<MitigationProgramme> {
+    const { data } = await api.post<MitigationProgramme>('/mitigation-programmes', input);
+    return data;
+  },
+  async update(id: string, input: ProgrammeInput): Promise<MitigationProgramme> {
+    const { data } = await api.put<MitigationProgramme>(`/mitigation-programmes/${id}`, input);
+    return data;
+  },
+  async remove(id: string): Promise<void> {
+    await api.delete(`/mitigation-programmes/${id}`);
+  },
+  async projection(id: string): Promise<RiskProjection[]> {
+    const { data } = await api.get<{ items: RiskProjection[] }>(`/mitigation-programmes/${id}/projection`);
+    return data.items ?? [];
+  },
+  async linkRisk(id: string, riskId: string, expectedReduction: number): Promise<void> {
+    await api.post(`/mitigation-programmes/${id}/risks`, { risk_id: riskId, expected_reduction: expectedReduction });
+  },
+  async unlinkRisk(id: string, riskId: string): Promise<void> {
+    await api.delete(`/mitigation-programmes/${id}/risks/${riskId}`);
+  },
+  async linkControl(id: string, controlId: string): Promise<void> {
+    await api.post(`/mitigation-programmes/${id}/controls`, { control_id: controlId });
+  },
+  async unlinkControl(id: string, controlId: string): Promise<void> {
+    await api.delete(`/mitigation-programmes/${id}/controls/${controlId}`);
+  },
+  async addAction(id: string, input: ActionInput): Promise<ProgrammeAction> {
+    const { data } = await api.post<ProgrammeAction>(`/mitigation-programmes/${id}/actions`, input);
+    return data;
+  },
+  async updateAction(actionId: string, input: ActionInput): Promise<ProgrammeAction> {
+    const { data } = await api.put<ProgrammeAction>(`/programme-actions/${actionId}`, input);
+    return data;
+  },
+  async removeAction(actionId: string): Promise<void> {
+    await api.delete(`/programme-actions/${actionId}`);
+  },
+  async capacities(): Promise<AssigneeCapacity[]> {
+    const { data } = await api.get<{ items: AssigneeCapacity[] }>('/programme-capacities');
+    return data.items ?? [];
+  },
+  async setCapacity(userId: string, fte: number): Promise<AssigneeCapacity> {
+    const { data } = await api.put<AssigneeCapacity>(`/programme-capacities/${userId}`, { fte });
+    return data;
+  },
+  async riskProjection(riskId: string): Promise<RiskProjection> {
+    const { data } = await api.get<RiskProjection>(`/risks/${riskId}/programme-projection`);
+    return data;
+  },
+};
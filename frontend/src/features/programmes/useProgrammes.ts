@@ -0,0 +1,71 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { programmeService, type ActionInput, type ProgrammeInput } from './programmeService';
+
+export function useProgrammes() {
+  return useQuery({ queryKey: ['programmes'], queryFn: () => programmeService.list() });
+}
+
+export function useProgramme(id: string | undefined) {
+  return useQuery({
+    queryKey: ['programmes', id],
+    queryFn: () => programmeService.get(id!),
+    enabled: !!id,
+  });
+}
+
+export function useProgrammeProjection(id: string | undefined) {
+  return useQuery({
+    queryKey: ['programmes', id, 'projection'],
+    queryFn: () => programmeService.projection(id!),
+    enabled: !!id,
+  });
+}
+
+/** Every programme write moves the schedule, and the schedule moves the
+ *  projection of every linked risk — the whole family goes stale together. */
+function useProgrammeMutation<V, R>(fn: (v: V) => Promise<R>) {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: fn,
+    onSuccess: () => qc.invalidateQueries({ queryKey: ['programmes'] }),
+  });
+}
+
+export function useCreateProgramme() {
+  return useProgrammeMutation((input: ProgrammeInput) => programmeService.create(input));
+}
+
+export function useUpdateProgramme() {
+  return useProgrammeMutation(({ id, input }: { id: string; input: ProgrammeInput }) => programmeService.update(id, input));
+}
+
+export function useDeleteProgramme() {
+  return useProgrammeMutation((id: string) => programmeService.remove(id));
+}
+
+export function useLinkProgrammeRisk() {
+  return useProgrammeMutation(({ id, riskId, reduction }: { id: string; riskId: string; reduction: number }) =>
+    programmeService.linkRisk(id, riskId, reduction));
+}
+
+export function useUnlinkProgrammeRisk() {
+  return useProgrammeMutation(({ id, riskId }: { id: string; riskId: string }) => programmeService.unlinkRisk(id, riskId));
+}
+
+export function useAddProgrammeAction() {
+  return useProgrammeMutation(({ id, input }: { id: string; input: ActionInput }) => programmeService.addAction(id, input));
+}
+
+export function useUpdateProgrammeAction() {
+  return useProgrammeMutation(({ actionId, input }: { actionId: string; input: ActionInput }) =>
+    programmeService.updateAction(actionId, input));
+}
+
+export function useDeleteProgrammeAction() {
+  return useProgrammeMutation((actionId: string) => programmeService.removeAction(actionId));
+}
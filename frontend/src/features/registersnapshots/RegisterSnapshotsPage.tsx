@@ -0,0 +1,266 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+//
+// /risks/snapshots — the register as it stood.
+//
+// The platform freezes every organisation's register at each close of day; a
+// risk manager can also take one on demand before an audit or a committee.
+// A snapshot never changes afterwards, so it is what the year-end report and
+// the auditor read, not the live register.
+//
+// Pick a "from" and a "to" (or the live register) to see what moved between
+// them: new, closed, up-scored and down-scored risks. Opening a snapshot
+// pages through its risks and exports it as CSV.
+
+import { useState } from 'react';
+import { toast } from 'sonner';
+import { Camera, Download, GitCompare, History, X } from 'lucide-react';
+import { PageFrame, PageHeader, Card, Btn, SkeletonRows, EmptyState, ErrorState } from '../../shared/ui';
+import { useUIStore } from '../../store/uiStore';
+import { useAuthStore } from '../../hooks/useAuthStore';
+import { useRegisterDiff, useRegisterSnapshotRisks, useRegisterSnapshots, useTakeRegisterSnapshot } from './useRegisterSnapshots';
+import { registerSnapshotService, type RegisterDiffEntry, type RegisterSnapshot } from './registerSnapshotService';
+
+type Tr = (fr: string, en: string) => string;
+
+const field = 'w-full rounded-[10px] border border-border bg-transparent px-3 py-2 text-[13px] text-ink';
+
+const LIVE = 'live';
+
+function apiMessage(err: unknown, fallback: string): string {
+  return (err as { response?: { data?: { error?: string } } })?.response?.data?.error || fallback;
+}
+
+const xaf = (v: number) => `${Math.round(v).toLocaleString('fr-FR')} FCFA`;
+const score = (v?: number) => (v == null ? '—' : v.toFixed(1));
+
+export function RegisterSnapshotsPage() {
+  const lang = useUIStore((s) => s.lang);
+  const tr: Tr = (fr, en) => (lang === 'fr' ? fr : en);
+  const canTake = useAuthStore((s) => s.hasPermission('risks:update'));
+  const [taking, setTaking] = useState(false);
+  const [open, setOpen] = useState<RegisterSnapshot | null>(null);
+  const [from, setFrom] = useState<string>('');
+  const [to, setTo] = useState<string>(LIVE);
+
+  const { data: snapshots, isLoading, isError, refetch } = useRegisterSnapshots();
+  const fmt = (d: string) => new Date(d).toLocaleString(lang === 'fr' ? 'fr-FR' : 'en-GB', { dateStyle: 'medium', timeStyle: 'short', timeZone: 'UTC' });
+  const kindLabel = (s: RegisterSnapshot) => (s.kind === 'scheduled' ? tr('Clôture', 'Daily close') : tr('Manuel', 'Manual'));
+
+  return (
+    <PageFrame>
+      <PageHeader
+        title={tr('Instantanés du registre', 'Register snapshots')}
+        count={snapshots?.length ? String(snapshots.length) : null}
+        actions={canTake && <Btn primary icon={Camera} label={tr('Prendre un instantané', 'Take a snapshot')} onClick={() => setTaking(true)} />}
+      />
+
+      {isLoading ? (
+        <Card><SkeletonRows rows={6} /></Card>
+      ) : isError ? (
+        <ErrorState title={tr('Impossible de charger les instantanés.', 'Could not load snapshots.')} onRetry={() => refetch()} retryLabel={tr('Réessayer', 'Retry')} />
+      ) : !snapshots?.length ? (
+        <Card>
+          <EmptyState icon={History} title={tr('Aucun instantané pour le moment', 'No snapshot yet')} />
+        </Card>
+      ) : (
+        <>
+          <Card style={{ padding: 0, overflow: 'hidden' }}>
+            <table className="w-full text-[13px]">
+              <thead>
+                <tr className="border-b border-border text-left text-[11.5px] uppercase tracking-wide text-ink-muted">
+                  <th className="px-4 py-2.5">{tr('Au', 'As of')} (UTC)</th>
+                  <th className="px-4 py-2.5">{tr('Type', 'Kind')}</th>
+                  <th className="px-4 py-2.5 text-right">{tr('Risques ouverts', 'Open risks')}</th>
+                  <th className="px-4 py-2.5 text-right">{tr('Critiques', 'Critical')}</th>
+                  <th className="px-4 py-2.5 text-right">ALE</th>
+                  <th className="px-4 py-2.5 text-center">{tr('De', 'From')}</th>
+                  <th className="px-4 py-2.5 text-center">{tr('À', 'To')}</th>
+                </tr>
+              </thead>
+              <tbody>
+                <tr className="border-b border-border">
+                  <td className="px-4 py-2.5 text-ink-soft" colSpan={6}>{tr('Registre actuel', 'Live register')}</td>
+                  <td className="px-4 py-2.5 text-center"><input type="radio" name="to" checked={to === LIVE} onChange={() => setTo(LIVE)} /></td>
+                </tr>
+                {snapshots.map((s) => (
+                  <tr key={s.id} className="border-b border-border last:border-0">
+                    <td className="px-4 py-2.5">
+                      <button type="button" className="text-left text-accent underline" onClick={() => setOpen(s)}>{fmt(s.as_of)}</button>
+                      {s.label && <div className="text-[12px] text-ink-muted">{s.label}</div>}
+                    </td>
+                    <td className="px-4 py-2.5 text-ink-soft">{kindLabel(s)}</td>
+                    <td className="px-4 py-2.5 text-right">{s.open_count} / {s.risk_count}</td>
+                    <td className="px-4 py-2.5 text-right" style={{ color: s.critical ? 'var(--critical)' : undefined }}>{s.critical}</td>
+                    <td className="px-4 py-2.5 text-right text-ink-soft">{xaf(s.total_ale_xaf)}</td>
+                    <td className="px-4 py-2.5 text-center"><input type="radio" name="from" checked={from === s.id} onChange={() => setFrom(s.id)} /></td>
+                    <td className="px-4 py-2.5 text-center"><input type="radio" name="to" checked={to === s.id} onChange={() => setTo(s.id)} /></td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          </Card>
+
+          {from && from !== to && <DiffCard from={from} to={to === LIVE ? undefined : to} tr={tr} fmt={fmt} />}
+        </>
+      )}
+
+      {taking && <TakeDialog onClose={() => setTaking(false)} tr={tr} />}
+      {open && <SnapshotDrawer snapshot={open} onClose={() => setOpen(null)} tr={tr} fmt={fmt} />}
+    </PageFrame>
+  );
+}
+
+function DiffCard({ from, to, tr, fmt }: { from: string; to?: string; tr: Tr; fmt: (d: string) => string }) {
+  const { data: diff, isLoading, isError } = useRegisterDiff(from, to);
+
+  if (isLoading) return <Card className="mt-4"><SkeletonRows rows={4} /></Card>;
+  if (isError || !diff) return <Card className="mt-4"><p className="text-[13px] text-ink-muted">{tr('Comparaison impossible.', 'Could not compare.')}</p></Card>;
+
+  const groups: { title: string; color: string; rows: RegisterDiffEntry[] }[] = [
+    { title: tr('Nouveaux', 'New'), color: 'var(--critical)', rows: diff.new },
+    { title: tr('En hausse', 'Up-scored'), color: 'var(--medium)', rows: diff.up_scored },
+    { title: tr('En baisse', 'Down-scored'), color: 'var(--low)', rows: diff.down_scored },
+    { title: tr('Clôturés', 'Closed'), color: 'var(--low)', rows: diff.closed },
+  ];
+
+  return (
+    <Card className="mt-4">
+      <div className="mb-3 flex items-center gap-2 text-[14px] font-semibold text-ink">
+        <GitCompare size={16} />
+        {fmt(diff.from.as_of)} → {diff.to ? fmt(diff.to.as_of) : tr('registre actuel', 'live register')}
+      </div>
+      <p className="mb-3 text-[12.5px] text-ink-soft">
+        {tr(
+          `${diff.summary.new} nouveau(x) · ${diff.summary.closed} clôturé(s) · ${diff.summary.up_scored} en hausse · ${diff.summary.down_scored} en baisse · ${diff.summary.unchanged} inchangé(s)`,
+          `${diff.summary.new} new · ${diff.summary.closed} closed · ${diff.summary.up_scored} up-scored · ${diff.summary.down_scored} down-scored · ${diff.summary.unchanged} unchanged`,
+        )}
+      </p>
+      <div className="grid gap-3 md:grid-cols-2">
+        {groups.filter((g) => g.rows.length > 0).map((g) => (
+          <div key={g.title}>
+            <div className="mb-1.5 text-[12px] font-semibold uppercase tracking-wide" style={{ color: g.color }}>{g.title} ({g.rows.length})</div>
+            {g.rows.map((r) => (
+              <div key={r.risk_id} className="flex items-center justify-between border-b border-border py-1.5 text-[12.5px] last:border-0">
+                <span className="truncate text-ink">{r.title}</span>
+                <span className="shrink-0 pl-2 text-ink-soft">{score(r.from_score)} → {score(r.to_score)}</span>
+              </div>
+            ))}
+          </div>
+        ))}
+      </div>
+    </Card>
+  );
+}
+
+function Overlay({ children, onClose }: { children: React.ReactNode; onClose: () => void }) {
+  return (
+    <div className="fixed inset-0 z-[80] flex justify-end" style={{ background: 'var(--surface-overlay)' }} onClick={onClose}>
+      <div
+        onClick={(e) => e.stopPropagation()}
+        className="h-full w-full max-w-[640px] overflow-y-auto p-5"
+        style={{ background: 'var(--bg-elevated)', borderLeft: '1px solid var(--border)' }}
+      >
+        {children}
+      </div>
+    </div>
+  );
+}
+
+function TakeDialog({ onClose, tr }: { onClose: () => void; tr: Tr }) {
+  const take = useTakeRegisterSnapshot();
+  const [label, setLabel] = useState('');
+
+  const submit = () => {
+    take.mutate(label, {
+      onSuccess: (s) => {
+        toast.success(tr(`Instantané de ${s.risk_count} risque(s) pris`, `Snapshot of ${s.risk_count} risk(s) taken`));
+        onClose();
+      },
+      onError: (err) => toast.error(apiMessage(err, tr("L'instantané a échoué.", 'Snapshot failed.'))),
+    });
+  };
+
+  return (
+    <Overlay onClose={onClose}>
+      <div className="mb-4 flex items-start justify-between">
+        <h2 className="text-[16px] font-bold text-ink">{tr('Prendre un instantané', 'Take a snapshot')}</h2>
+        <button type="button" onClick={onClose} aria-label={tr('Fermer', 'Close')}><X size={18} /></button>
+      </div>
+      <div className="space-y-3 text-[13px]">
+        <p className="text-ink-soft">
+          {tr(
+            "Le registre est figé tel qu'il est maintenant : scores, statuts, responsables, ALE et contrôles. Un instantané ne peut plus être modifié.",
+            'The register is frozen as it stands now: scores, states, owners, ALE and controls. A snapshot can never be changed.',
+          )}
+        </p>
+        <input className={field} maxLength={255} placeholder={tr('Libellé (ex. Avant audit COBAC)', 'Label (e.g. Before the external audit)')} value={label} onChange={(e) => setLabel(e.target.value)} />
+        <div className="flex justify-end gap-2">
+          <Btn label={tr('Annuler', 'Cancel')} onClick={onClose} />
+          <Btn primary icon={Camera} label={tr('Figer le registre', 'Freeze the register')} onClick={submit} disabled={take.isPending} />
+        </div>
+      </div>
+    </Overlay>
+  );
+}
+
+function SnapshotDrawer({ snapshot, onClose, tr, fmt }: { snapshot: RegisterSnapshot; onClose: () => void; tr: Tr; fmt: (d: string) => string }) {
+  const [q, setQ] = useState('');
+  const [page, setPage] = useState(1);
+  const { data, isLoading } = useRegisterSnapshotRisks(snapshot.id, q, page);
+  const pages = data ? Math.max(1, Math.ceil(data.total / 25)) : 1;
+
+  const download = async () => {
+    try {
+      const blob = await registerSnapshotService.exportAsOf(snapshot.as_of, 'csv');
+      const url = URL.createObjectURL(blob);
+      const a = document.createElement('a');
+      a.href = url;
+      a.download = `register-${snapshot.as_of.slice(0, 10)}.csv`;
+      a.click();
+      URL.revokeObjectURL(url);
+    } catch (err) {
+      toast.error(apiMessage(err, tr("L'export a échoué.", 'Export failed.')));
+    }
+  };
+
+  return (
+    <Overlay onClose={onClose}>
+      <div className="mb-4 flex items-start justify-between">
+        <div>
+          <h2 className="text-[16px] font-bold text-ink">{tr('Registre au', 'Register as of')} {fmt(snapshot.as_of)}</h2>
+          {snapshot.label && <div className="text-[12.5px] text-ink-muted">{snapshot.label}</div>}
+        </div>
+        <div className="flex items-center gap-2">
+          <Btn icon={Download} label="CSV" onClick={download} />
+          <button type="button" onClick={onClose} aria-label={tr('Fermer', 'Close')}><X size={18} /></button>
+        </div>
+      </div>
+      <input className={`${field} mb-3`} placeholder={tr('Rechercher un risque', 'Search a risk')} value={q} onChange={(e) => { setQ(e.target.value); setPage(1); }} />
+      {isLoading ? (
+        <SkeletonRows rows={8} />
+      ) : (
+        <>
+          {data?.items.map((r) => (
+            <div key={r.risk_id} className="border-b border-border py-2 text-[12.5px] last:border-0">
+              <div className="flex items-center justify-between">
+                <span className="font-semibold text-ink">{r.title}</span>
+                <span className="text-ink-soft">{score(r.score)} · {r.criticality}</span>
+              </div>
+              <div className="text-[11.5px] text-ink-muted">
+                {r.status}{r.ale_xaf > 0 && ` · ${xaf(r.ale_xaf)}`}{r.controls.length > 0 && ` · ${r.controls.join(', ')}`}
+              </div>
+            </div>
+          ))}
+          {pages > 1 && (
+            <div className="mt-3 flex items-center justify-end gap-2 text-[12.5px] text-ink-soft">
+              <Btn label="‹" onClick={() => setPage((p) => Math.max(1, p - 1))} disabled={page === 1} />
+              {page} / {pages}
+              <Btn label="›" onClick={() => setPage((p) => Math.min(pages, p + 1))} disabled={page === pages} />
+            </div>
+          )}
+        </>
+      )}
+    </Overlay>
+  );
+}
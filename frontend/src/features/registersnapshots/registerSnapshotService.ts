@@ -0,0 +1,106 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+//
+// Typed client for register snapshots. Mirrors domain.RegisterSnapshot /
+// domain.RegisterSnapshotRisk and the application/registersnapshot views
+// (page, diff).
+
+import { api } from '../../lib/api';
+
+export type RegisterSnapshotKind = 'scheduled' | 'manual';
+
+export interface RegisterSnapshot {
+  id: string;
+  kind: RegisterSnapshotKind;
+  label: string;
+  /** The instant the snapshot stands for: UTC midnight for a daily close. */
+  as_of: string;
+  taken_at: string;
+  risk_count: number;
+  open_count: number;
+  critical: number;
+  high: number;
+  medium: number;
+  low: number;
+  average_score: number;
+  total_ale_xaf: number;
+  taken_by?: string;
+}
+
+export interface RegisterSnapshotRisk {
+  snapshot_id: string;
+  risk_id: string;
+  title: string;
+  status: string;
+  lifecycle_state: string;
+  criticality: string;
+  score: number;
+  probability: number;
+  impact: number;
+  residual_risk?: number;
+  owner_id?: string;
+  business_unit?: string;
+  ale_xaf: number;
+  tags: string[];
+  controls: string[];
+  assets: string[];
+}
+
+export interface RegisterSnapshotPage {
+  snapshot: RegisterSnapshot;
+  items: RegisterSnapshotRisk[];
+  total: number;
+}
+
+export interface RegisterDiffEntry {
+  risk_id: string;
+  title: string;
+  criticality: string;
+  from_score?: number;
+  to_score?: number;
+  delta: number;
+  from_status?: string;
+  to_status?: string;
+}
+
+export interface RegisterDiff {
+  from: RegisterSnapshot;
+  /** null when compared with the live register. */
+  to: RegisterSnapshot | null;
+  summary: { new: number; closed: number; up_scored: number; down_scored: number; unchanged: number };
+  new: RegisterDiffEntry[];
+  closed: RegisterDiffEntry[];
+  up_scored: RegisterDiffEntry[];
+  down_scored: RegisterDiffEntry[];
+}
+
+export const registerSnapshotService = {
+  list: async (): Promise<RegisterSnapshot[]> => {
+    const res = await api.get<RegisterSnapshot[]>('/register-snapshots');
+    return res.data ?? [];
+  },
+
+  take: async (label: string): Promise<RegisterSnapshot> => {
+    const res = await api.post<RegisterSnapshot>('/register-snapshots', { label });
+    return res.data;
+  },
+
+  risks: async (id: string, params: { q?: string; page?: number; limit?: number } = {}): Promise<RegisterSnapshotPage> => {
+    const res = await api.get<RegisterSnapshotPage>(`/register-snapshots/${id}/risks`, { params });
+    return res.data;
+  },
+
+  /** Without `to`, the live register is the later side. */
+  diff: async (from: string, to?: string): Promise<RegisterDiff> => {
+    const res = await api.get<RegisterDiff>('/register-snapshots/diff', { params: to ? { from, to } : { from } });
+    return res.data;
+  },
+
+  /** The as_of export of GET /analytics/export, as a file. */
+  exportAsOf: async (asOf: string, format: 'csv' | 'json'): Promise<Blob> => {
+    const res = await api.get('/analytics/export', { params: { as_of: asOf, format }, responseType: 'blob' });
+    return res.data as Blob;
+  },
+};
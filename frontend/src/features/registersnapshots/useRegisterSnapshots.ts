@@ -0,0 +1,35 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { registerSnapshotService } from './registerSnapshotService';
+
+export function useRegisterSnapshots() {
+  return useQuery({ queryKey: ['register-snapshots'], queryFn: () => registerSnapshotService.list() });
+}
+
+export function useRegisterSnapshotRisks(id: string | undefined, q: string, page: number) {
+  return useQuery({
+    queryKey: ['register-snapshots', id, 'risks', q, page],
+    queryFn: () => registerSnapshotService.risks(id!, { q: q || undefined, page, limit: 25 }),
+    enabled: !!id,
+  });
+}
+
+export function useRegisterDiff(from: string | undefined, to: string | undefined) {
+  return useQuery({
+    queryKey: ['register-snapshots', 'diff', from, to ?? 'live'],
+    queryFn: () => registerSnapshotService.diff(from!, to),
+    enabled: !!from,
+  });
+}
+
+export function useTakeRegisterSnapshot() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: (label: string) => registerSnapshotService.take(label),
+    onSuccess: () => qc.invalidateQueries({ queryKey: ['register-snapshots'] }),
+  });
+}
@@ -30,13 +30,14 @@ const GUARD_ACTION: Record<TransitionGuard, { icon: typeof ShieldCheck }> = {
   active_mitigation: { icon: ShieldCheck },
   subactions_complete: { icon: ShieldCheck },
   governance_approval: { icon: Lock },
+  appetite_exception: { icon: Lock },
 };
 
 interface Props {
   riskId: string;
   /** Opens the mitigation plan — the way out of both treatment guards. */
   onOpenMitigations?: () => void;
-  /** Opens Governance — the way out of the residual-acceptance guard. */
+  /** Opens Governance — the way out of the residual-acceptance and appetite guards. */
   onOpenGovernance?: () => void;
 }
 
@@ -89,12 +90,12 @@ export function LifecycleStepper({ riskId, onOpenMitigations, onOpenGovernance }
   };
 
   const wayOut = (guard: TransitionGuard) => {
-    if (guard === 'governance_approval') return onOpenGovernance;
+    if (guard === 'governance_approval' || guard === 'appetite_exception') return onOpenGovernance;
     return onOpenMitigations;
   };
 
   const wayOutLabel = (guard: TransitionGuard) =>
-    guard === 'governance_approval'
+    guard === 'governance_approval' || guard === 'appetite_exception'
       ? tr("Ouvrir la Gouvernance", 'Open Governance')
       : tr('Ouvrir le plan de mitigation', 'Open the mitigation plan');
 
@@ -18,7 +18,7 @@ import { toast } from 'sonner';
 import { Upload, Plus, X, FileText, Pencil, Trash2, Eye, Download, ShieldCheck, ShieldAlert, Clock, Rows3, LayoutGrid, Check, ChevronDown, Coins, Route as RouteIcon, SlidersHorizontal, Sparkles, Loader2, UserCheck, Link2Off } from 'lucide-react';
 import {
   PageFrame, PageHeader, Btn, Card, CritBadge, StatusPill, Avatar, FwBadge, arcPath,
-  SkeletonRows, EmptyState, softFill, type RiskStatus,
+  SkeletonRows, EmptyState, softFill, Sparkline, type RiskStatus,
 } from '../../shared/ui';
 import { DataTable, useTableState, type BulkAction, type Column, type Facet, type RowAction } from '../../shared/datatable';
 import { critColor } from '../../shared/riskColors';
@@ -37,6 +37,11 @@ import { EditRiskModal } from './components/EditRiskModal';
 import { CreateMitigationModal } from '../mitigations/CreateMitigationModal';
 import { useRiskFinancial } from '../financial/useFinancial';
 import { useRiskSmartScore } from './useSmartScore';
+import { useRiskKris } from '../kri/useKris';
+import { useBowtie } from '../bowtie/useBowtie';
+import { healthColor, healthLabel } from '../bowtie/barrierHealth';
+import type { BarrierHealth } from '../bowtie/bowtieService';
+import type { KRIStatus } from '../kri/kriService';
 import { SmartRiskRadar } from './components/SmartRiskRadar';
 import { useTreatmentPlan } from '../ai/useAi';
 import { LifecycleStepper } from './LifecycleStepper';
@@ -209,6 +214,7 @@ export function RiskRegisterPage() {
         { value: 'cti_auto', label: tr('CTI (auto)', 'CTI (auto)') },
         { value: 'scan_auto', label: tr('Scanner (auto)', 'Scanner (auto)') },
         { value: 'import', label: tr('Import', 'Import') },
+        { value: 'scenario', label: tr('Scénario', 'Scenario') },
       ],
     },
   ], [L, lang, categories]); // eslint-disable-line react-hooks/exhaustive-deps
@@ -857,11 +863,11 @@ function RiskDrawer({ r, onClose, onEdit, onExport, onCreateMiti }: { r: UiRisk;
   const L = useUIStrings();
   const lang = useUIStore((s) => s.lang);
   const tr = (fr: string, en: string) => (lang === 'fr' ? fr : en);
-  const [tab, setTab] = useState<'details' | 'lifecycle' | 'score' | 'smart' | 'financial' | 'miti' | 'timeline' | 'cti' | 'ai'>('details');
+  const [tab, setTab] = useState<'details' | 'lifecycle' | 'score' | 'smart' | 'kri' | 'financial' | 'miti' | 'bowtie' | 'timeline' | 'cti' | 'ai'>('details');
   const tabDef: [typeof tab, string][] = [
     ['details', L.tab_details], ['lifecycle', tr('Cycle de vie', 'Lifecycle')], ['score', L.tab_score],
-    ['smart', tr('Score intelligent', 'Smart score')],
-    ['financial', tr('Financier', 'Financial')], ['miti', L.tab_miti],
+    ['smart', tr('Score intelligent', 'Smart score')], ['kri', 'KRI'],
+    ['financial', tr('Financier', 'Financial')], ['miti', L.tab_miti], ['bowtie', tr('Nœud papillon', 'Bowtie')],
     ['timeline', L.tab_timeline], ['cti', L.tab_cti], ['ai', L.tab_ai],
   ];
   return (
@@ -902,8 +908,10 @@ function RiskDrawer({ r, onClose, onEdit, onExport, onCreateMiti }: { r: UiRisk;
           {tab === 'lifecycle' && <DrawerLifecycle r={r} onOpenMitigations={() => setTab('miti')} />}
           {tab === 'score' && <DrawerScore r={r} />}
           {tab === 'smart' && <DrawerSmart r={r} />}
+          {tab === 'kri' && <DrawerKri r={r} />}
           {tab === 'financial' && <DrawerFinancial r={r} />}
           {tab === 'miti' && <DrawerMiti r={r} onCreateMiti={onCreateMiti} />}
+          {tab === 'bowtie' && <DrawerBowtie r={r} />}
           {tab === 'ai' && <DrawerAI r={r} />}
           {tab === 'timeline' && <DrawerTimeline r={r} />}
           {tab === 'cti' && <div className="py-10 px-[22px] text-center text-[13px] text-ink-soft">{L.soon}</div>}
@@ -1141,6 +1149,79 @@ function DrawerSmart({ r }: { r: UiRisk }) {
   );
 }
 
+const kriColor: Record<KRIStatus, string> = {
+  red: 'var(--critical)',
+  amber: 'var(--medium)',
+  green: 'var(--low)',
+  unknown: 'var(--text-muted)',
+};
+
+function DrawerKri({ r }: { r: UiRisk }) {
+  const lang = useUIStore((s) => s.lang);
+  const tr = (fr: string, en: string) => (lang === 'fr' ? fr : en);
+  const { data, isLoading, isError } = useRiskKris(r.id);
+  const band: Record<KRIStatus, string> = {
+    red: tr('Rouge', 'Red'), amber: tr('Ambre', 'Amber'), green: tr('Vert', 'Green'), unknown: tr('Sans mesure', 'No reading'),
+  };
+
+  if (isLoading) {
+    return (
+      <div className="p-[22px]">
+        <SkeletonRows rows={4} />
+      </div>
+    );
+  }
+  if (isError) {
+    return (
+      <div className="py-10 px-[22px] text-center text-[13px] text-ink-soft">
+        {tr('Impossible de charger les indicateurs.', 'Could not load the indicators.')}
+      </div>
+    );
+  }
+  if (!data || data.length === 0) {
+    return (
+      <div className="py-10 px-[22px] text-center text-[13px] text-ink-soft">
+        {tr('Aucun indicateur clé de risque ne surveille ce risque.', 'No key risk indicator watches this risk.')}
+      </div>
+    );
+  }
+  return (
+    <div className="p-[22px] flex flex-col gap-2.5">
+      {data.map((k) => (
+        <div key={k.id} className="rounded-[12px] px-3.5 py-3" style={{ background: 'var(--bg-elevated)', border: '1px solid var(--border)' }}>
+          <div className="flex items-center gap-3">
+            <div className="flex-1 min-w-0">
+              <div className="text-[13px] font-semibold text-ink truncate">{k.name}</div>
+              <div className="text-[11px] text-ink-muted mt-0.5">
+                {tr('Ambre', 'Amber')} {k.amber_threshold} · {tr('Rouge', 'Red')} {k.red_threshold}
+                {k.last_measured_at && ` · ${relTime(k.last_measured_at, lang)}`}
+              </div>
+            </div>
+            <Sparkline points={k.values.map((v) => v.value)} color={kriColor[k.status]} />
+            <div className="text-right w-[72px] shrink-0">
+              <div className="mono text-[14px] font-bold" style={{ color: kriColor[k.status] }}>
+                {k.last_value ?? '—'}{k.unit && k.last_value !== null ? ` ${k.unit}` : ''}
+              </div>
+              <div className="text-[10.5px] font-semibold" style={{ color: kriColor[k.status] }}>{band[k.status]}</div>
+            </div>
+          </div>
+          {k.last_error && (
+            <div className="mt-2 text-[11px]" style={{ color: 'var(--critical)' }}>
+              {tr('Dernière évaluation en échec', 'Last evaluation failed')} : {k.last_error}
+            </div>
+          )}
+        </div>
+      ))}
+      <p className="mt-1 text-[11px] text-ink-muted leading-snug">
+        {tr(
+          'Tendance sur 90 jours. Un indicateur qui passe au rouge déclenche les règles d’automatisation « seuil KRI franchi » et peut relever la probabilité de ce risque pour revue.',
+          '90-day trend. An indicator turning red fires the “KRI threshold crossed” automation rules and may raise this risk’s probability for review.',
+        )}
+      </p>
+    </div>
+  );
+}
+
 /* ---------------- lifecycle ---------------- */
 //
 // There is no client-side copy of the state graph any more. This used to hold a
@@ -1316,6 +1397,68 @@ function DrawerFinancial({ r }: { r: UiRisk }) {
   );
 }
 
+// DrawerBowtie — the "Bowtie" tab: how the risk's barriers stand, side by
+// side, and the figures they suggest. The diagram and its edition live on
+// /risks/:id/bowtie.
+function DrawerBowtie({ r }: { r: UiRisk }) {
+  const lang = useUIStore((s) => s.lang);
+  const tr = (fr: string, en: string) => (lang === 'fr' ? fr : en);
+  const navigate = useNavigate();
+  const { data, isLoading, isError } = useBowtie(r.id);
+  const open = () => navigate(`/risks/${r.id}/bowtie`);
+
+  if (isLoading) {
+    return (
+      <div className="p-[22px]">
+        <SkeletonRows rows={3} />
+      </div>
+    );
+  }
+  if (isError || !data) {
+    return (
+      <div className="py-10 px-[22px] text-center text-[13px] text-ink-soft">
+        {tr('Impossible de charger le nœud papillon.', 'Could not load the bowtie.')}
+      </div>
+    );
+  }
+  if (!data.bowtie) {
+    return (
+      <div className="py-10 px-[22px] text-center">
+        <div className="text-[13px] text-ink-soft mb-3.5">{tr('Aucun nœud papillon pour ce risque.', 'No bowtie for this risk yet.')}</div>
+        <div className="flex justify-center"><Btn label={tr('Construire', 'Build it')} icon={Plus} primary onClick={open} /></div>
+      </div>
+    );
+  }
+  const sides: [string, typeof data.preventive][] = [
+    [tr('Prévention', 'Preventive'), data.preventive],
+    [tr('Protection', 'Recovery'), data.recovery],
+  ];
+  const healths: BarrierHealth[] = ['healthy', 'degraded', 'failed', 'unknown'];
+  const s = data.suggestion;
+  return (
+    <div className="p-[22px] flex flex-col gap-3">
+      <div className="text-[13px] text-ink"><span className="text-ink-muted">{tr('Événement redouté', 'Top event')} · </span>{data.bowtie.top_event}</div>
+      {sides.map(([label, side]) => (
+        <div key={label} className="flex items-center gap-3 text-[12.5px]">
+          <span className="w-[80px] text-ink-soft">{label}</span>
+          {healths.map((h) => (
+            <span key={h} className="flex items-center gap-1" title={healthLabel(h, tr)}>
+              <span className="inline-block w-2.5 h-2.5 rounded-sm" style={{ background: healthColor[h] }} />
+              <span className="mono">{side[h]}</span>
+            </span>
+          ))}
+        </div>
+      ))}
+      {s.raises && (
+        <div className="text-[12.5px] p-2.5 rounded-[10px]" style={{ background: 'var(--bg-hover)' }}>
+          {tr('Les barrières suggèrent', 'Barriers suggest')} P {s.current_probability.toFixed(2)} → <b>{s.probability.toFixed(2)}</b>, I {s.current_impact.toFixed(1)} → <b>{s.impact.toFixed(1)}</b>
+        </div>
+      )}
+      <div><Btn label={tr('Ouvrir le nœud papillon', 'Open the bowtie')} icon={Eye} onClick={open} /></div>
+    </div>
+  );
+}
+
 function DrawerMiti({ r, onCreateMiti }: { r: UiRisk; onCreateMiti: () => void }) {
   const L = useUIStrings();
   const lang = useUIStore((s) => s.lang);
@@ -25,7 +25,11 @@ export type RiskState =
   | 'reopened';
 
 /** Which precondition a blocked transition failed. Drives the "way out" CTA. */
-export type TransitionGuard = 'active_mitigation' | 'subactions_complete' | 'governance_approval';
+export type TransitionGuard =
+  | 'active_mitigation'
+  | 'subactions_complete'
+  | 'governance_approval'
+  | 'appetite_exception';
 
 export interface TransitionOption {
   to: RiskState;
@@ -0,0 +1,302 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+//
+// /scenarios — the risk-scenario library and the ATT&CK coverage it implies.
+//
+// A scenario is a starting point, not a risk: instantiating it against assets
+// drafts one DRAFT risk per asset, pre-filled with the scenario's FAIR ranges
+// and mapped to whichever of its suggested controls the tenant already runs.
+// Running it twice on the same asset returns the existing draft instead of a
+// duplicate, so the dialog can be used freely as assets are added.
+//
+// Coverage reads the other way round: for every technique the library cites,
+// which mitigating controls does the tenant have, and are they implemented?
+// Uncovered techniques come first because they are the only actionable rows.
+
+import { useMemo, useState } from 'react';
+import { useNavigate } from 'react-router';
+import { toast } from 'sonner';
+import { BookOpen, Crosshair, Play, Trash2, X } from 'lucide-react';
+import { PageFrame, PageHeader, Card, Btn, Chip, SkeletonRows, EmptyState, ErrorState } from '../../shared/ui';
+import { useUIStore } from '../../store/uiStore';
+import { useAuthStore } from '../../hooks/useAuthStore';
+import { useAssets } from '../assets/useAssets';
+import { useDeleteScenario, useInstantiateScenario, useScenarioCoverage, useScenarioLibrary } from './useScenarios';
+import type { CoverageStatus, FAIRRange, InstantiateResult, RiskScenario } from './scenarioService';
+
+type Tr = (fr: string, en: string) => string;
+
+const STATUS_COLOR: Record<CoverageStatus, string> = {
+  uncovered: 'var(--critical)',
+  partial: 'var(--medium)',
+  covered: 'var(--low)',
+};
+
+function statusLabel(s: CoverageStatus, tr: Tr): string {
+  switch (s) {
+    case 'uncovered': return tr('Non couverte', 'Uncovered');
+    case 'partial': return tr('Partielle', 'Partial');
+    default: return tr('Couverte', 'Covered');
+  }
+}
+
+function apiMessage(err: unknown, fallback: string): string {
+  return (err as { response?: { data?: { error?: string } } })?.response?.data?.error || fallback;
+}
+
+function fmtRange(r: FAIRRange, lang: string, money?: boolean): string {
+  const nf = new Intl.NumberFormat(lang === 'fr' ? 'fr-FR' : 'en-GB', { maximumFractionDigits: money ? 0 : 2, notation: money ? 'compact' : 'standard' });
+  return `${nf.format(r.min)} · ${nf.format(r.mode)} · ${nf.format(r.max)}`;
+}
+
+export function ScenariosPage() {
+  const lang = useUIStore((s) => s.lang);
+  const tr: Tr = (fr, en) => (lang === 'fr' ? fr : en);
+  const canEdit = useAuthStore((s) => s.hasPermission('risks:update'));
+
+  const [tab, setTab] = useState<'library' | 'coverage'>('library');
+  const [running, setRunning] = useState<RiskScenario | null>(null);
+
+  const { data: library, isLoading, isError, refetch } = useScenarioLibrary();
+  const remove = useDeleteScenario();
+
+  return (
+    <PageFrame>
+      <PageHeader
+        title={tr('Scénarios de risque', 'Risk scenarios')}
+        count={library?.scenarios.length ? String(library.scenarios.length) : null}
+        badge={library ? <span className="text-[12px] text-ink-muted">{tr('Bibliothèque', 'Library')} {library.library_version}</span> : undefined}
+      />
+      <div className="mb-4 flex flex-wrap items-center gap-2">
+        <Chip label={tr('Bibliothèque', 'Library')} active={tab === 'library'} onClick={() => setTab('library')} />
+        <Chip label={tr('Couverture ATT&CK', 'ATT&CK coverage')} active={tab === 'coverage'} onClick={() => setTab('coverage')} />
+      </div>
+
+      {tab === 'coverage' ? (
+        <CoverageView tr={tr} />
+      ) : isLoading ? (
+        <Card><SkeletonRows rows={5} /></Card>
+      ) : isError ? (
+        <ErrorState title={tr('Impossible de charger la bibliothèque.', 'Could not load the library.')} onRetry={() => refetch()} retryLabel={tr('Réessayer', 'Retry')} />
+      ) : !library?.scenarios.length ? (
+        <Card>
+          <EmptyState icon={BookOpen} title={tr('Aucun scénario', 'No scenarios')} />
+        </Card>
+      ) : (
+        <div className="grid gap-3 md:grid-cols-2">
+          {library.scenarios.map((s) => (
+            <Card key={s.id}>
+              <div className="flex items-start justify-between gap-2">
+                <div>
+                  <div className="font-semibold text-ink">{s.title}</div>
+                  <div className="text-[12px] text-ink-muted">
+                    {s.built_in ? tr('Intégré', 'Built-in') : tr('Personnalisé', 'Custom')} · v{s.version} · {s.key}
+                  </div>
+                </div>
+                <div className="flex gap-1">
+                  {canEdit && <Btn primary icon={Play} label={tr('Instancier', 'Instantiate')} onClick={() => setRunning(s)} />}
+                  {canEdit && !s.built_in && (
+                    <Btn danger icon={Trash2} onClick={() => {
+                      if (!window.confirm(tr(`Supprimer le scénario ${s.title} ?`, `Delete scenario ${s.title}?`))) return;
+                      remove.mutate(s.id, { onError: (err) => toast.error(apiMessage(err, tr('La suppression a échoué.', 'Delete failed.'))) });
+                    }} />
+                  )}
+                </div>
+              </div>
+              {s.description && <p className="mt-2 text-[12.5px] text-ink-soft">{s.description}</p>}
+              <dl className="mt-3 grid grid-cols-[auto_1fr] gap-x-3 gap-y-1 text-[12px]">
+                <dt className="text-ink-muted">{tr('Acteurs', 'Actors')}</dt>
+                <dd className="text-ink-soft">{s.threat_actors.map((a) => a.name).join(', ') || '—'}</dd>
+                <dt className="text-ink-muted">{tr('Actifs', 'Assets')}</dt>
+                <dd className="text-ink-soft">{s.asset_categories.join(', ') || tr('Toutes catégories', 'Any category')}</dd>
+                <dt className="text-ink-muted">LEF</dt>
+                <dd className="text-ink-soft">{fmtRange(s.lef, lang)} {tr('/ an', '/ yr')}</dd>
+                <dt className="text-ink-muted">{tr('Perte', 'Loss')}</dt>
+                <dd className="text-ink-soft">{fmtRange(s.loss_magnitude, lang, true)} XAF</dd>
+              </dl>
+              <div className="mt-3 flex flex-wrap gap-1">
+                {s.techniques.map((t) => (
+                  <span key={t.id} title={`${t.name} — ${t.tactic}`} className="rounded-[6px] border border-border px-1.5 py-0.5 font-mono text-[11px] text-ink-soft">
+                    {t.id}
+                  </span>
+                ))}
+              </div>
+            </Card>
+          ))}
+        </div>
+      )}
+
+      {running && <InstantiateDialog scenario={running} onClose={() => setRunning(null)} tr={tr} />}
+    </PageFrame>
+  );
+}
+
+function Overlay({ children, onClose }: { children: React.ReactNode; onClose: () => void }) {
+  return (
+    <div className="fixed inset-0 z-[80] flex justify-end" style={{ background: 'var(--surface-overlay)' }} onClick={onClose}>
+      <div
+        onClick={(e) => e.stopPropagation()}
+        className="h-full w-full max-w-[520px] overflow-y-auto p-5"
+        style={{ background: 'var(--bg-elevated)', borderLeft: '1px solid var(--border)' }}
+      >
+        {children}
+      </div>
+    </div>
+  );
+}
+
+function InstantiateDialog({ scenario, onClose, tr }: { scenario: RiskScenario; onClose: () => void; tr: Tr }) {
+  const navigate = useNavigate();
+  const { assets, isLoading } = useAssets();
+  const run = useInstantiateScenario();
+  const [picked, setPicked] = useState<Set<string>>(new Set());
+  const [result, setResult] = useState<InstantiateResult | null>(null);
+
+  // Same rule as RiskScenario.AppliesTo: no categories, or an uncategorised
+  // asset, means the scenario applies.
+  const eligible = useMemo(() => assets.filter((a) => {
+    const cat = a.category ?? '';
+    return scenario.asset_categories.length === 0 || cat === '' || scenario.asset_categories.includes(cat);
+  }), [assets, scenario.asset_categories]);
+
+  const toggle = (id: string) => setPicked((prev) => {
+    const next = new Set(prev);
+    if (next.has(id)) next.delete(id); else next.add(id);
+    return next;
+  });
+
+  const submit = () => {
+    run.mutate({ id: scenario.id, assetIds: [...picked] }, {
+      onSuccess: (res) => {
+        setResult(res);
+        toast.success(tr(`${res.created.length} risque(s) rédigé(s)`, `${res.created.length} risk(s) drafted`));
+      },
+      onError: (err) => toast.error(apiMessage(err, tr("L'instanciation a échoué.", 'Instantiation failed.'))),
+    });
+  };
+
+  return (
+    <Overlay onClose={onClose}>
+      <div className="mb-4 flex items-start justify-between">
+        <div>
+          <h2 className="text-[16px] font-bold text-ink">{scenario.title}</h2>
+          <div className="text-[12.5px] text-ink-muted">
+            {tr('Un risque brouillon par actif sélectionné.', 'One draft risk per selected asset.')}
+          </div>
+        </div>
+        <button type="button" onClick={onClose} aria-label={tr('Fermer', 'Close')}><X size={18} /></button>
+      </div>
+
+      {result ? (
+        <div className="space-y-3 text-[12.5px]">
+          {[...result.created, ...result.existing].map((d) => (
+            <div key={d.risk_id} className="flex items-center justify-between rounded-[10px] border border-border p-2.5">
+              <span className="text-ink">{d.asset_name}</span>
+              <button type="button" className="text-accent underline" onClick={() => navigate(`/risks?focus=${d.risk_id}`)}>
+                {result.existing.includes(d) ? tr('Risque existant', 'Existing risk') : tr('Nouveau brouillon', 'New draft')}
+              </button>
+            </div>
+          ))}
+          <p className="text-ink-soft">
+            {tr(`${result.mapped_controls} correspondance(s) de contrôle créée(s).`, `${result.mapped_controls} control mapping(s) created.`)}
+          </p>
+          {result.unmatched_controls.length > 0 && (
+            <div className="rounded-[10px] p-3" style={{ background: 'color-mix(in srgb, var(--medium) 12%, transparent)' }}>
+              <p className="mb-1 text-ink-soft">
+                {tr("Contrôles suggérés absents du référentiel de l'organisation :", "Suggested controls your organisation doesn't have:")}
+              </p>
+              <p className="font-mono text-[11.5px] text-ink">{result.unmatched_controls.map((c) => `${c.catalog}:${c.ref}`).join(', ')}</p>
+            </div>
+          )}
+          <div className="flex justify-end"><Btn label={tr('Fermer', 'Close')} onClick={onClose} /></div>
+        </div>
+      ) : isLoading ? (
+        <SkeletonRows rows={4} />
+      ) : eligible.length === 0 ? (
+        <p className="text-[12.5px] text-ink-muted">
+          {tr('Aucun actif ne correspond aux catégories du scénario.', "No asset matches the scenario's categories.")}
+        </p>
+      ) : (
+        <>
+          <ul className="space-y-1">
+            {eligible.map((a) => (
+              <li key={a.id}>
+                <label className="flex cursor-pointer items-center gap-2 rounded-[8px] px-2 py-1.5 text-[13px] hover:bg-hover">
+                  <input type="checkbox" checked={picked.has(a.id)} onChange={() => toggle(a.id)} />
+                  <span className="flex-1 text-ink">{a.name}</span>
+                  <span className="text-[11.5px] text-ink-muted">{a.category || '—'} · {a.criticality}</span>
+                </label>
+              </li>
+            ))}
+          </ul>
+          <div className="mt-4 flex justify-end gap-2">
+            <Btn label={tr('Annuler', 'Cancel')} onClick={onClose} />
+            <Btn primary icon={Play} label={tr(`Instancier (${picked.size})`, `Instantiate (${picked.size})`)} onClick={submit} disabled={run.isPending || picked.size === 0} />
+          </div>
+        </>
+      )}
+    </Overlay>
+  );
+}
+
+function CoverageView({ tr }: { tr: Tr }) {
+  const { data, isLoading, isError, refetch } = useScenarioCoverage();
+
+  if (isLoading) return <Card><SkeletonRows rows={6} /></Card>;
+  if (isError || !data) {
+    return <ErrorState title={tr('Impossible de calculer la couverture.', 'Could not compute coverage.')} onRetry={() => refetch()} retryLabel={tr('Réessayer', 'Retry')} />;
+  }
+  if (data.techniques.length === 0) {
+    return <Card><EmptyState icon={Crosshair} title={tr('Aucune technique référencée', 'No techniques referenced')} /></Card>;
+  }
+
+  const { summary } = data;
+  return (
+    <>
+      <div className="mb-3 flex flex-wrap gap-4 text-[13px]">
+        <span className="text-ink-soft">{summary.techniques} {tr('techniques', 'techniques')}</span>
+        {(['uncovered', 'partial', 'covered'] as CoverageStatus[]).map((s) => (
+          <span key={s} style={{ color: STATUS_COLOR[s] }}>{summary[s]} {statusLabel(s, tr).toLowerCase()}</span>
+        ))}
+      </div>
+      <Card style={{ padding: 0, overflow: 'hidden' }}>
+        <table className="w-full text-[13px]">
+          <thead>
+            <tr className="border-b border-border text-left text-[11.5px] uppercase tracking-wide text-ink-muted">
+              <th className="px-4 py-2.5">{tr('Technique', 'Technique')}</th>
+              <th className="px-4 py-2.5">{tr('Statut', 'Status')}</th>
+              <th className="px-4 py-2.5">{tr('Scénarios', 'Scenarios')}</th>
+              <th className="px-4 py-2.5">{tr('Mesures', 'Mitigations')}</th>
+            </tr>
+          </thead>
+          <tbody>
+            {data.techniques.map((t) => (
+              <tr key={t.id} className="border-b border-border align-top last:border-0">
+                <td className="px-4 py-2.5">
+                  <div className="font-mono text-[12px] text-ink">{t.id}</div>
+                  <div className="text-ink-soft">{t.name}</div>
+                  <div className="text-[11.5px] text-ink-muted">{t.tactic}</div>
+                </td>
+                <td className="px-4 py-2.5 font-semibold" style={{ color: STATUS_COLOR[t.status] }}>{statusLabel(t.status, tr)}</td>
+                <td className="px-4 py-2.5 text-[12px] text-ink-soft">{t.scenarios.join(', ')}</td>
+                <td className="px-4 py-2.5 text-[12px]">
+                  {t.mitigations.map((m) => (
+                    <div key={`${m.catalog}:${m.ref}`} className="text-ink-soft">
+                      <span className="font-mono">{m.catalog}:{m.ref}</span>
+                      {' — '}
+                      {m.controls.length === 0
+                        ? <span className="text-ink-muted">{tr('absent', 'missing')}</span>
+                        : m.controls.map((c) => (
+                          <span key={c.control_id} style={{ color: c.status === 'implemented' ? 'var(--low)' : 'var(--medium)' }}>{c.name}</span>
+                        ))}
+                    </div>
+                  ))}
+                </td>
+              </tr>
+            ))}
+          </tbody>
+        </table>
+      </Card>
+    </>
+  );
+}
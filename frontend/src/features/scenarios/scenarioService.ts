@@ -0,0 +1,122 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+//
+// Typed client for the risk-scenario library. Mirrors domain.RiskScenario and
+// the application/scenario views (library, instantiation, coverage).
+
+import { api } from '../../lib/api';
+
+export type ThreatActorKind = 'criminal' | 'nation_state' | 'hacktivist' | 'insider' | 'partner';
+export type CoverageStatus = 'covered' | 'partial' | 'uncovered';
+export type ControlStatus = 'not_implemented' | 'in_progress' | 'implemented' | 'not_applicable';
+
+export interface ScenarioControlRef {
+  catalog: string;
+  ref: string;
+}
+
+export interface ScenarioTechnique {
+  id: string;
+  name: string;
+  tactic: string;
+  mitigations: ScenarioControlRef[];
+}
+
+export interface ThreatActor {
+  name: string;
+  kind: ThreatActorKind;
+  motivation: string;
+}
+
+/** min / most likely / max, the PERT shape the CRQ simulation samples. */
+export interface FAIRRange {
+  min: number;
+  mode: number;
+  max: number;
+}
+
+export interface RiskScenario {
+  id: string;
+  key: string;
+  version: number;
+  title: string;
+  description: string;
+  threat_actors: ThreatActor[];
+  techniques: ScenarioTechnique[];
+  asset_categories: string[];
+  /** Loss event frequency, events per year. */
+  lef: FAIRRange;
+  /** Loss per event, XAF. */
+  loss_magnitude: FAIRRange;
+  impact: number;
+  suggested_controls: ScenarioControlRef[];
+  built_in: boolean;
+}
+
+export interface ScenarioLibrary {
+  library_version: string;
+  scenarios: RiskScenario[];
+}
+
+export interface DraftedRisk {
+  risk_id: string;
+  asset_id: string;
+  asset_name: string;
+  title?: string;
+}
+
+export interface InstantiateResult {
+  scenario_id: string;
+  scenario_version: number;
+  created: DraftedRisk[];
+  existing: DraftedRisk[];
+  mapped_controls: number;
+  unmatched_controls: ScenarioControlRef[];
+}
+
+export interface CoveredControl {
+  control_id: string;
+  framework_id: string;
+  name: string;
+  status: ControlStatus;
+}
+
+export interface TechniqueCoverage {
+  id: string;
+  name: string;
+  tactic: string;
+  status: CoverageStatus;
+  scenarios: string[];
+  mitigations: (ScenarioControlRef & { controls: CoveredControl[] })[];
+}
+
+export interface CoverageReport {
+  library_version: string;
+  summary: { techniques: number; covered: number; partial: number; uncovered: number };
+  techniques: TechniqueCoverage[];
+}
+
+export const scenarioService = {
+  list: async (): Promise<ScenarioLibrary> => {
+    const res = await api.get<ScenarioLibrary>('/risk-scenarios');
+    return res.data;
+  },
+
+  remove: async (id: string): Promise<void> => {
+    await api.delete(`/risk-scenarios/${id}`);
+  },
+
+  instantiate: async (id: string, assetIds: string[]): Promise<InstantiateResult> => {
+    const res = await api.post<InstantiateResult>(`/risk-scenarios/${id}/instantiate`, { asset_ids: assetIds });
+    return res.data;
+  },
+
+  coverage: async (scenarioId?: string): Promise<CoverageReport> => {
+    const res = await api.get<CoverageReport>('/risk-scenarios/coverage', {
+      params: scenarioId ? { scenario_id: scenarioId } : undefined,
+    });
+    return res.data;
+  },
+};
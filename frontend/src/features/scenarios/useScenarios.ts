@@ -0,0 +1,36 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { scenarioService } from './scenarioService';
+
+export function useScenarioLibrary() {
+  return useQuery({ queryKey: ['risk-scenarios'], queryFn: () => scenarioService.list() });
+}
+
+/** Coverage of the whole library, or of one scenario when `scenarioId` is set. */
+export function useScenarioCoverage(scenarioId?: string) {
+  return useQuery({
+    queryKey: ['risk-scenarios', 'coverage', scenarioId ?? 'all'],
+    queryFn: () => scenarioService.coverage(scenarioId),
+  });
+}
+
+export function useDeleteScenario() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: (id: string) => scenarioService.remove(id),
+    onSuccess: () => qc.invalidateQueries({ queryKey: ['risk-scenarios'] }),
+  });
+}
+
+/** Instantiation drafts risks, so the register's caches go stale too. */
+export function useInstantiateScenario() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: ({ id, assetIds }: { id: string; assetIds: string[] }) => scenarioService.instantiate(id, assetIds),
+    onSuccess: () => qc.invalidateQueries({ queryKey: ['risks'] }),
+  });
+}
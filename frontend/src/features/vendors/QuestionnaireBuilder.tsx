@@ -0,0 +1,148 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+//
+// Vendor questionnaires: the two built-ins (read-only, but copyable as a
+// starting point) and the organisation's own. A question's weight is its share
+// of the score; 0 makes it informational. Choice options score from 0 to 1.
+
+import { useState } from 'react';
+import { toast } from 'sonner';
+import { Copy, Pencil, Plus, Trash2 } from 'lucide-react';
+import { Card, Btn, SkeletonRows } from '../../shared/ui';
+import { useUIStore } from '../../store/uiStore';
+import { useDeleteQuestionnaire, useSaveQuestionnaire, useVendorQuestionnaires } from './useVendors';
+import type { VendorQuestion, VendorQuestionType, VendorQuestionnaire, VendorQuestionnaireInput } from './vendorService';
+
+const field = 'w-full rounded-[10px] border border-border bg-transparent px-3 py-2 text-[13px] text-ink';
+
+function blankQuestion(n: number): VendorQuestion {
+  return { id: `q${n}`, section: '', text: '', type: 'yes_no', weight: 1, required: true, allow_na: false };
+}
+
+export function QuestionnaireBuilder({ canEdit }: { canEdit: boolean }) {
+  const lang = useUIStore((s) => s.lang);
+  const tr = (fr: string, en: string) => (lang === 'fr' ? fr : en);
+  const { data: templates, isLoading } = useVendorQuestionnaires();
+  const remove = useDeleteQuestionnaire();
+  const [draft, setDraft] = useState<{ id?: string; input: VendorQuestionnaireInput } | null>(null);
+
+  if (draft) return <Editor draft={draft} onDone={() => setDraft(null)} tr={tr} />;
+  if (isLoading) return <Card><SkeletonRows rows={3} /></Card>;
+
+  const copy = (t: VendorQuestionnaire) =>
+    setDraft({ input: { name: `${t.name} (${tr('copie', 'copy')})`, description: t.description, questions: t.questions, pass_score: t.pass_score } });
+
+  return (
+    <div className="space-y-3">
+      {canEdit && (
+        <div className="flex justify-end">
+          <Btn primary icon={Plus} label={tr('Nouveau questionnaire', 'New questionnaire')}
+            onClick={() => setDraft({ input: { name: '', questions: [blankQuestion(1)], pass_score: 70 } })} />
+        </div>
+      )}
+      {(templates ?? []).map((t) => (
+        <Card key={t.id}>
+          <div className="flex items-start justify-between gap-3">
+            <div>
+              <div className="font-semibold text-ink">{t.name}{t.built_in && <span className="ml-2 text-[11px] text-ink-muted">{tr('intégré', 'built-in')}</span>}</div>
+              {t.description && <p className="mt-1 text-[12.5px] text-ink-muted">{t.description}</p>}
+              <p className="mt-1 text-[12px] text-ink-soft">
+                {t.questions.length} {tr('questions', 'questions')} · {tr('seuil', 'pass mark')} {t.pass_score}
+              </p>
+            </div>
+            {canEdit && (
+              <div className="flex gap-2">
+                <Btn icon={Copy} onClick={() => copy(t)} />
+                {!t.built_in && (
+                  <>
+                    <Btn icon={Pencil} onClick={() => setDraft({ id: t.id, input: t })} />
+                    <Btn danger icon={Trash2} onClick={() => remove.mutate(t.id)} />
+                  </>
+                )}
+              </div>
+            )}
+          </div>
+        </Card>
+      ))}
+    </div>
+  );
+}
+
+function Editor({ draft, onDone, tr }: {
+  draft: { id?: string; input: VendorQuestionnaireInput }; onDone: () => void; tr: (fr: string, en: string) => string;
+}) {
+  const save = useSaveQuestionnaire();
+  const [form, setForm] = useState<VendorQuestionnaireInput>(draft.input);
+  const setQ = (i: number, patch: Partial<VendorQuestion>) =>
+    setForm((f) => ({ ...f, questions: f.questions.map((q, j) => (j === i ? { ...q, ...patch } : q)) }));
+
+  const submit = () => save.mutate({ id: draft.id, input: form }, {
+    onSuccess: () => { toast.success(tr('Questionnaire enregistré', 'Questionnaire saved')); onDone(); },
+    onError: (err) => toast.error((err as { response?: { data?: { error?: string } } })?.response?.data?.error
+      || tr("L'enregistrement a échoué.", 'Saving failed.')),
+  });
+
+  return (
+    <Card>
+      <div className="grid grid-cols-[1fr_120px] gap-3">
+        <input className={field} placeholder={tr('Nom', 'Name')} value={form.name} onChange={(e) => setForm({ ...form, name: e.target.value })} />
+        <input className={field} type="number" min={0} max={100} value={form.pass_score}
+          title={tr('Seuil de réussite', 'Pass mark')} onChange={(e) => setForm({ ...form, pass_score: Number(e.target.value) })} />
+      </div>
+      <textarea className={`${field} mt-2`} rows={2} placeholder={tr('Description', 'Description')}
+        value={form.description ?? ''} onChange={(e) => setForm({ ...form, description: e.target.value })} />
+
+      <div className="mt-4 space-y-3">
+        {form.questions.map((q, i) => (
+          <div key={i} className="rounded-[10px] border border-border p-3">
+            <div className="grid grid-cols-[100px_1fr_130px_70px_auto] gap-2">
+              <input className={field} value={q.id} onChange={(e) => setQ(i, { id: e.target.value })} title="ID" />
+              <input className={field} value={q.text} placeholder={tr('Question', 'Question')} onChange={(e) => setQ(i, { text: e.target.value })} />
+              <select className={field} value={q.type} onChange={(e) => setQ(i, { type: e.target.value as VendorQuestionType })}>
+                <option value="yes_no">{tr('Oui / non', 'Yes / no')}</option>
+                <option value="choice">{tr('Choix', 'Choice')}</option>
+                <option value="text">{tr('Texte libre', 'Free text')}</option>
+                <option value="document">{tr('Document', 'Document')}</option>
+              </select>
+              <input className={field} type="number" min={0} step={0.5} value={q.weight} title={tr('Poids', 'Weight')}
+                onChange={(e) => setQ(i, { weight: Number(e.target.value) })} />
+              <Btn danger icon={Trash2} onClick={() => setForm((f) => ({ ...f, questions: f.questions.filter((_, j) => j !== i) }))} />
+            </div>
+            <div className="mt-2 flex flex-wrap items-center gap-4 text-[12.5px] text-ink-soft">
+              <input className={`${field} max-w-[220px]`} value={q.section} placeholder={tr('Section', 'Section')}
+                onChange={(e) => setQ(i, { section: e.target.value })} />
+              <label><input type="checkbox" checked={q.required} onChange={(e) => setQ(i, { required: e.target.checked })} /> {tr('Obligatoire', 'Required')}</label>
+              <label><input type="checkbox" checked={q.allow_na} onChange={(e) => setQ(i, { allow_na: e.target.checked })} /> {tr('N/A accepté', 'N/A allowed')}</label>
+            </div>
+            {q.type === 'choice' && (
+              <div className="mt-2 space-y-1">
+                {(q.options ?? []).map((o, k) => (
+                  <div key={k} className="grid grid-cols-[1fr_90px_auto] gap-2">
+                    <input className={field} value={o.label} placeholder={tr('Libellé', 'Label')}
+                      onChange={(e) => setQ(i, { options: q.options!.map((x, m) => (m === k ? { ...x, label: e.target.value, value: x.value || `o${k + 1}` } : x)) })} />
+                    <input className={field} type="number" min={0} max={1} step={0.25} value={o.score} title={tr('Score (0-1)', 'Score (0-1)')}
+                      onChange={(e) => setQ(i, { options: q.options!.map((x, m) => (m === k ? { ...x, score: Number(e.target.value) } : x)) })} />
+                    <Btn icon={Trash2} onClick={() => setQ(i, { options: q.options!.filter((_, m) => m !== k) })} />
+                  </div>
+                ))}
+                <button type="button" className="text-[12.5px] text-accent underline"
+                  onClick={() => setQ(i, { options: [...(q.options ?? []), { value: `o${(q.options?.length ?? 0) + 1}`, label: '', score: 0 }] })}>
+                  {tr('Ajouter une option', 'Add an option')}
+                </button>
+              </div>
+            )}
+          </div>
+        ))}
+      </div>
+
+      <div className="mt-4 flex justify-between">
+        <Btn icon={Plus} label={tr('Question', 'Question')}
+          onClick={() => setForm((f) => ({ ...f, questions: [...f.questions, blankQuestion(f.questions.length + 1)] }))} />
+        <div className="flex gap-2">
+          <Btn label={tr('Annuler', 'Cancel')} onClick={onDone} />
+          <Btn primary label={tr('Enregistrer', 'Save')} onClick={submit} disabled={save.isPending} />
+        </div>
+      </div>
+    </Card>
+  );
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+//
+// /vendor-assessment — where a vendor lands from the questionnaire email.
+//
+// Public by necessity: the person answering works for the supplier and has no
+// OpenRisk account. The link token is the only credential, so the page shows
+// nothing of the organisation beyond its name. Answers save as a draft until
+// the vendor submits; after that the link is read-only.
+
+import { useEffect, useState } from 'react';
+import { useSearchParams } from 'react-router';
+import { toast } from 'sonner';
+import { AlertTriangle, CheckCircle2, Loader2, Paperclip } from 'lucide-react';
+import { useUIStore } from '../../store/uiStore';
+import { OpenRiskLogo } from '../../shared/Logo';
+import { Btn } from '../../shared/ui';
+import { VENDOR_ANSWER_NA, vendorService, type VendorAnswer, type VendorPortalView, type VendorQuestion } from './vendorService';
+
+function apiMessage(err: unknown, fallback: string): string {
+  return (err as { response?: { data?: { error?: string } } })?.response?.data?.error || fallback;
+}
+
+export function VendorAssessmentPage() {
+  const lang = useUIStore((s) => s.lang);
+  const tr = (fr: string, en: string) => (lang === 'fr' ? fr : en);
+  const [params] = useSearchParams();
+  const token = params.get('token') ?? '';
+
+  const [view, setView] = useState<VendorPortalView | null>(null);
+  const [answers, setAnswers] = useState<Record<string, VendorAnswer>>({});
+  const [error, setError] = useState<string | null>(null);
+  const [busy, setBusy] = useState(false);
+
+  const load = (v: VendorPortalView) => {
+    setView(v);
+    setAnswers(Object.fromEntries(v.answers.map((a) => [a.question_id, a])));
+  };
+
+  useEffect(() => {
+    if (!token) {
+      setError(tr('Ce lien est incomplet.', 'This link is incomplete.'));
+      return;
+    }
+    vendorService.portal(token).then(load).catch((err) => {
+      const status = (err as { response?: { status?: number } })?.response?.status;
+      // 410 says why a known link is closed; 404 deliberately says nothing more.
+      setError(status === 410
+        ? apiMessage(err, tr("Ce lien n'est plus valable.", 'This link is no longer valid.'))
+        : tr("Ce lien de questionnaire n'est pas valable.", 'This questionnaire link is not valid.'));
+    });
+  }, [token]); // eslint-disable-line react-hooks/exhaustive-deps
+
+  const setAnswer = (q: VendorQuestion, patch: Partial<VendorAnswer>) =>
+    setAnswers((a) => ({ ...a, [q.id]: { ...(a[q.id] ?? { question_id: q.id, value: '' }), ...patch } }));
+
+  const save = async (submit: boolean) => {
+    setBusy(true);
+    try {
+      load(await vendorService.saveAnswers(token, Object.values(answers), submit));
+      toast.success(submit ? tr('Questionnaire soumis. Merci.', 'Questionnaire submitted. Thank you.') : tr('Brouillon enregistré', 'Draft saved'));
+    } catch (err) {
+      toast.error(apiMessage(err, tr("L'enregistrement a échoué.", 'Saving failed.')));
+    } finally {
+      setBusy(false);
+    }
+  };
+
+  const upload = async (q: VendorQuestion, file: File) => {
+    setBusy(true);
+    try {
+      // Save first, so typed answers are not lost when the upload reloads the view.
+      await vendorService.saveAnswers(token, Object.values(answers), false);
+      load(await vendorService.uploadDocument(token, q.id, file));
+      toast.success(tr('Document joint', 'Document attached'));
+    } catch (err) {
+      toast.error(apiMessage(err, tr("L'envoi du document a échoué.", 'Uploading the document failed.')));
+    } finally {
+      setBusy(false);
+    }
+  };
+
+  const readOnly = view?.state === 'submitted';
+  let section = '';
+
+  return (
+    <div className="min-h-screen p-5" style={{ background: 'var(--bg-app)' }}>
+      <div className="mx-auto w-full max-w-[760px]">
+        <div className="mb-6 flex items-center gap-2.5">
+          <OpenRiskLogo size={28} />
+          <span className="text-[18px] font-bold text-ink">OpenRisk</span>
+        </div>
+
+        {error ? (
+          <div className="rounded-[16px] p-6 text-center" style={{ background: 'var(--bg-elevated)', border: '1px solid var(--border)' }}>
+            <AlertTriangle size={26} className="mx-auto mb-3" style={{ color: 'var(--high)' }} />
+            <p className="text-[14px] text-ink">{error}</p>
+            <p className="mt-2 text-[12.5px] text-ink-muted">{tr('Demandez un nouveau lien à votre contact.', 'Ask your contact for a new link.')}</p>
+          </div>
+        ) : !view ? (
+          <div className="flex justify-center py-16 text-ink-soft"><Loader2 size={26} className="animate-spin" /></div>
+        ) : (
+          <div className="rounded-[16px] p-6" style={{ background: 'var(--bg-elevated)', border: '1px solid var(--border)' }}>
+            <h1 className="text-[18px] font-bold text-ink">{view.questionnaire_name}</h1>
+            <p className="mt-1 text-[13px] text-ink-muted">
+              {view.organization_name
+                ? tr(`${view.organization_name} demande à ${view.vendor_name} de répondre à ce questionnaire.`,
+                  `${view.organization_name} asks ${view.vendor_name} to answer this questionnaire.`)
+                : view.vendor_name}
+              {!readOnly && ` ${tr('Lien valable jusqu\'au', 'Link valid until')} ${new Date(view.expires_at).toLocaleDateString(lang === 'fr' ? 'fr-FR' : 'en-GB')}.`}
+            </p>
+            {readOnly && (
+              <div className="mt-4 flex items-center gap-2 rounded-[10px] p-3 text-[13px]" style={{ background: 'color-mix(in srgb, var(--low) 14%, transparent)' }}>
+                <CheckCircle2 size={16} style={{ color: 'var(--low)' }} />
+                {tr('Ce questionnaire a été soumis. Merci.', 'This questionnaire has been submitted. Thank you.')}
+              </div>
+            )}
+
+            <div className="mt-5 space-y-4">
+              {view.questions.map((q) => {
+                const a = answers[q.id];
+                const header = q.section && q.section !== section ? q.section : null;
+                section = q.section;
+                return (
+                  <div key={q.id}>
+                    {header && <h2 className="mb-2 mt-4 text-[14px] font-semibold text-ink">{header}</h2>}
+                    <div className="rounded-[10px] border border-border p-3">
+                      <div className="text-[13.5px] text-ink">{q.text}{q.required && <span style={{ color: 'var(--critical)' }}> *</span>}</div>
+                      {q.help && <div className="mt-1 text-[12px] text-ink-muted">{q.help}</div>}
+                      <div className="mt-2 flex flex-wrap items-center gap-3 text-[13px]">
+                        {q.type === 'yes_no' && ['yes', 'no'].map((v) => (
+                          <label key={v}><input type="radio" disabled={readOnly} name={q.id} checked={a?.value === v}
+                            onChange={() => setAnswer(q, { value: v })} /> {v === 'yes' ? tr('Oui', 'Yes') : tr('Non', 'No')}</label>
+                        ))}
+                        {q.type === 'choice' && (
+                          <select disabled={readOnly} className="rounded-[8px] border border-border bg-transparent px-2 py-1.5"
+                            value={a?.value ?? ''} onChange={(e) => setAnswer(q, { value: e.target.value })}>
+                            <option value="">—</option>
+                            {(q.options ?? []).map((o) => <option key={o.value} value={o.value}>{o.label}</option>)}
+                          </select>
+                        )}
+                        {q.type === 'text' && (
+                          <textarea disabled={readOnly} rows={3} className="w-full rounded-[8px] border border-border bg-transparent px-2 py-1.5"
+                            value={a?.value ?? ''} onChange={(e) => setAnswer(q, { value: e.target.value })} />
+                        )}
+                        {q.type === 'document' && (
+                          <>
+                            <span className="text-ink-soft"><Paperclip size={13} className="mr-1 inline" />
+                              {tr(`${a?.evidence_ids?.length ?? 0} document(s) joint(s)`, `${a?.evidence_ids?.length ?? 0} document(s) attached`)}</span>
+                            {!readOnly && (
+                              <input type="file" disabled={busy} onChange={(e) => { const f = e.target.files?.[0]; if (f) upload(q, f); e.target.value = ''; }} />
+                            )}
+                          </>
+                        )}
+                        {q.allow_na && (
+                          <label><input type="checkbox" disabled={readOnly} checked={a?.value === VENDOR_ANSWER_NA}
+                            onChange={(e) => setAnswer(q, { value: e.target.checked ? VENDOR_ANSWER_NA : '' })} /> {tr('Non applicable', 'Not applicable')}</label>
+                        )}
+                      </div>
+                      {!readOnly && q.type !== 'text' && (
+                        <input className="mt-2 w-full rounded-[8px] border border-border bg-transparent px-2 py-1.5 text-[12.5px]"
+                          placeholder={tr('Commentaire (facultatif)', 'Comment (optional)')}
+                          value={a?.comment ?? ''} onChange={(e) => setAnswer(q, { comment: e.target.value })} />
+                      )}
+                    </div>
+                  </div>
+                );
+              })}
+            </div>
+
+            {!readOnly && (
+              <div className="mt-6 flex justify-end gap-2">
+                <Btn label={tr('Enregistrer le brouillon', 'Save draft')} onClick={() => save(false)} disabled={busy} />
+                <Btn primary label={tr('Soumettre', 'Submit')} onClick={() => save(true)} disabled={busy} />
+              </div>
+            )}
+          </div>
+        )}
+      </div>
+    </div>
+  );
+}
@@ -0,0 +1,378 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+//
+// /vendors — the third-party register.
+//
+// Tier is not something anyone picks: it falls out of what data the vendor
+// touches and how badly an outage would hurt, which is what makes two
+// procurement officers tier the same supplier the same way. The tier then sets
+// how often the vendor is reassessed; the worker sends the next questionnaire
+// when that date passes, so the list's "due" marker is a warning, not a chore.
+//
+// A questionnaire goes out as a link the vendor answers without an account.
+// The link is shown here only when it could not be emailed — a link sitting in
+// the UI is a credential anyone looking over a shoulder can copy.
+
+import { useMemo, useState } from 'react';
+import { useNavigate } from 'react-router';
+import { toast } from 'sonner';
+import { ClipboardList, Copy, Handshake, Plus, Send, Trash2, X } from 'lucide-react';
+import { PageFrame, PageHeader, Card, Btn, Chip, SkeletonRows, EmptyState, ErrorState } from '../../shared/ui';
+import { useUIStore } from '../../store/uiStore';
+import { useAuthStore } from '../../hooks/useAuthStore';
+import { QuestionnaireBuilder } from './QuestionnaireBuilder';
+import {
+  useDeleteVendor, useRevokeAssessment, useSaveVendor, useSendAssessment, useVendor,
+  useVendorQuestionnaires, useVendors,
+} from './useVendors';
+import type {
+  SendAssessmentResult, Vendor, VendorAssessmentState, VendorCriticality, VendorDataAccess, VendorInput, VendorTier,
+} from './vendorService';
+
+type Tr = (fr: string, en: string) => string;
+
+const TIER_COLOR: Record<VendorTier, string> = { 1: 'var(--critical)', 2: 'var(--medium)', 3: 'var(--low)' };
+
+const DATA_ACCESS: { value: VendorDataAccess; fr: string; en: string }[] = [
+  { value: 'none', fr: 'Aucune donnée', en: 'No data' },
+  { value: 'internal', fr: 'Données internes', en: 'Internal data' },
+  { value: 'confidential', fr: 'Données confidentielles', en: 'Confidential data' },
+  { value: 'personal', fr: 'Données personnelles', en: 'Personal data' },
+  { value: 'regulated', fr: 'Données réglementées', en: 'Regulated data' },
+];
+
+const CRITICALITY: { value: VendorCriticality; fr: string; en: string }[] = [
+  { value: 'low', fr: 'Faible', en: 'Low' },
+  { value: 'medium', fr: 'Moyenne', en: 'Medium' },
+  { value: 'high', fr: 'Élevée', en: 'High' },
+  { value: 'critical', fr: 'Critique', en: 'Critical' },
+];
+
+function stateLabel(s: VendorAssessmentState, tr: Tr): string {
+  switch (s) {
+    case 'sent': return tr('Envoyé', 'Sent');
+    case 'in_progress': return tr('En cours', 'In progress');
+    case 'submitted': return tr('Soumis', 'Submitted');
+    case 'revoked': return tr('Révoqué', 'Revoked');
+    default: return tr('Expiré', 'Expired');
+  }
+}
+
+function apiMessage(err: unknown, fallback: string): string {
+  return (err as { response?: { data?: { error?: string } } })?.response?.data?.error || fallback;
+}
+
+function fmtDate(iso: string | null | undefined, lang: string): string {
+  return iso ? new Date(iso).toLocaleDateString(lang === 'fr' ? 'fr-FR' : 'en-GB') : '—';
+}
+
+export function VendorsPage() {
+  const lang = useUIStore((s) => s.lang);
+  const tr: Tr = (fr, en) => (lang === 'fr' ? fr : en);
+  const canEdit = useAuthStore((s) => s.hasPermission('risks:update'));
+
+  const [tab, setTab] = useState<'register' | 'questionnaires'>('register');
+  const [tierFilter, setTierFilter] = useState<VendorTier | null>(null);
+  const [editing, setEditing] = useState<Vendor | 'new' | null>(null);
+  const [openId, setOpenId] = useState<string | null>(null);
+
+  const { data: vendors, isLoading, isError, refetch } = useVendors();
+  const rows = useMemo(
+    () => (vendors ?? []).filter((v) => tierFilter === null || v.tier === tierFilter),
+    [vendors, tierFilter],
+  );
+  const due = (vendors ?? []).filter((v) => v.reassessment_due).length;
+
+  return (
+    <PageFrame>
+      <PageHeader
+        title={tr('Fournisseurs', 'Vendors')}
+        count={vendors?.length ? String(vendors.length) : null}
+        actions={canEdit && tab === 'register'
+          ? <Btn primary icon={Plus} label={tr('Ajouter', 'Add vendor')} onClick={() => setEditing('new')} />
+          : undefined}
+      />
+      <div className="mb-4 flex flex-wrap items-center gap-2">
+        <Chip label={tr('Registre', 'Register')} active={tab === 'register'} onClick={() => setTab('register')} />
+        <Chip label={tr('Questionnaires', 'Questionnaires')} active={tab === 'questionnaires'} onClick={() => setTab('questionnaires')} />
+        {tab === 'register' && (
+          <>
+            <span className="mx-2 h-5 w-px bg-border" />
+            <Chip label={tr('Tous les tiers', 'All tiers')} active={tierFilter === null} onClick={() => setTierFilter(null)} />
+            {([1, 2, 3] as VendorTier[]).map((t) => (
+              <Chip key={t} label={`Tier ${t}`} color={TIER_COLOR[t]} active={tierFilter === t} onClick={() => setTierFilter(t)} />
+            ))}
+            {due > 0 && (
+              <span className="ml-auto text-[12.5px]" style={{ color: 'var(--medium)' }}>
+                {tr(`${due} réévaluation(s) échue(s)`, `${due} reassessment(s) due`)}
+              </span>
+            )}
+          </>
+        )}
+      </div>
+
+      {tab === 'questionnaires' ? (
+        <QuestionnaireBuilder canEdit={canEdit} />
+      ) : isLoading ? (
+        <Card><SkeletonRows rows={5} /></Card>
+      ) : isError ? (
+        <ErrorState title={tr('Impossible de charger le registre.', 'Could not load the register.')} onRetry={() => refetch()} retryLabel={tr('Réessayer', 'Retry')} />
+      ) : rows.length === 0 ? (
+        <Card>
+          <EmptyState
+            icon={Handshake}
+            title={tr('Aucun fournisseur', 'No vendors yet')}
+            description={tr(
+              "Ajoutez vos fournisseurs : leur tier découle des données qu'ils traitent et de leur criticité.",
+              'Add your vendors: their tier follows from the data they handle and how critical they are.',
+            )}
+          />
+        </Card>
+      ) : (
+        <Card style={{ padding: 0, overflow: 'hidden' }}>
+          <table className="w-full text-[13px]">
+            <thead>
+              <tr className="border-b border-border text-left text-[11.5px] uppercase tracking-wide text-ink-muted">
+                <th className="px-4 py-2.5">{tr('Fournisseur', 'Vendor')}</th>
+                <th className="px-4 py-2.5">Tier</th>
+                <th className="px-4 py-2.5">{tr('Données', 'Data')}</th>
+                <th className="px-4 py-2.5">{tr('Dernier score', 'Last score')}</th>
+                <th className="px-4 py-2.5">{tr('Prochaine évaluation', 'Next assessment')}</th>
+              </tr>
+            </thead>
+            <tbody>
+              {rows.map((v) => (
+                <tr key={v.id} className="cursor-pointer border-b border-border last:border-0 hover:bg-hover" onClick={() => setOpenId(v.id)}>
+                  <td className="px-4 py-2.5">
+                    <div className="font-semibold text-ink">{v.name}</div>
+                    {v.services && <div className="text-[12px] text-ink-muted">{v.services}</div>}
+                  </td>
+                  <td className="px-4 py-2.5 font-semibold" style={{ color: TIER_COLOR[v.tier] }}>Tier {v.tier}</td>
+                  <td className="px-4 py-2.5 text-ink-soft">
+                    {(() => { const d = DATA_ACCESS.find((x) => x.value === v.data_access); return d ? tr(d.fr, d.en) : v.data_access; })()}
+                  </td>
+                  <td className="px-4 py-2.5">{v.last_score === null ? '—' : `${v.last_score.toFixed(1)} / 100`}</td>
+                  <td className="px-4 py-2.5" style={v.reassessment_due ? { color: 'var(--medium)', fontWeight: 600 } : undefined}>
+                    {v.last_assessed_at ? fmtDate(v.next_assessment_at, lang) : tr('Jamais évalué', 'Never assessed')}
+                  </td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        </Card>
+      )}
+
+      {editing && <VendorForm vendor={editing === 'new' ? null : editing} onClose={() => setEditing(null)} tr={tr} />}
+      {openId && (
+        <VendorPanel
+          id={openId}
+          canEdit={canEdit}
+          onEdit={(v) => { setOpenId(null); setEditing(v); }}
+          onClose={() => setOpenId(null)}
+          tr={tr}
+          lang={lang}
+        />
+      )}
+    </PageFrame>
+  );
+}
+
+function Overlay({ children, onClose }: { children: React.ReactNode; onClose: () => void }) {
+  return (
+    <div className="fixed inset-0 z-[80] flex justify-end" style={{ background: 'var(--surface-overlay)' }} onClick={onClose}>
+      <div
+        onClick={(e) => e.stopPropagation()}
+        className="h-full w-full max-w-[520px] overflow-y-auto p-5"
+        style={{ background: 'var(--bg-elevated)', borderLeft: '1px solid var(--border)' }}
+      >
+        {children}
+      </div>
+    </div>
+  );
+}
+
+const field = 'mt-1 w-full rounded-[10px] border border-border bg-transparent px-3 py-2 text-[13px] text-ink';
+
+function VendorForm({ vendor, onClose, tr }: { vendor: Vendor | null; onClose: () => void; tr: Tr }) {
+  const save = useSaveVendor();
+  const [form, setForm] = useState<VendorInput>(() => vendor ?? {
+    name: '', website: '', services: '', contact_name: '', contact_email: '',
+    data_access: 'internal', criticality: 'medium', status: 'active',
+  });
+  const set = <K extends keyof VendorInput>(k: K, v: VendorInput[K]) => setForm((f) => ({ ...f, [k]: v }));
+
+  const submit = (e: React.FormEvent) => {
+    e.preventDefault();
+    save.mutate({ id: vendor?.id, input: form }, {
+      onSuccess: (v) => { toast.success(tr(`${v.name} — tier ${v.tier}`, `${v.name} — tier ${v.tier}`)); onClose(); },
+      onError: (err) => toast.error(apiMessage(err, tr("L'enregistrement a échoué.", 'Saving failed.'))),
+    });
+  };
+
+  return (
+    <Overlay onClose={onClose}>
+      <form onSubmit={submit} className="space-y-3">
+        <div className="flex items-center justify-between">
+          <h2 className="text-[16px] font-bold text-ink">{vendor ? vendor.name : tr('Nouveau fournisseur', 'New vendor')}</h2>
+          <button type="button" onClick={onClose} aria-label={tr('Fermer', 'Close')}><X size={18} /></button>
+        </div>
+        <label className="block text-[12.5px] text-ink-soft">{tr('Nom', 'Name')}
+          <input required className={field} value={form.name} onChange={(e) => set('name', e.target.value)} />
+        </label>
+        <label className="block text-[12.5px] text-ink-soft">{tr('Services fournis', 'Services provided')}
+          <input className={field} value={form.services ?? ''} onChange={(e) => set('services', e.target.value)} />
+        </label>
+        <label className="block text-[12.5px] text-ink-soft">{tr('Site web', 'Website')}
+          <input className={field} value={form.website ?? ''} onChange={(e) => set('website', e.target.value)} />
+        </label>
+        <div className="grid grid-cols-2 gap-3">
+          <label className="block text-[12.5px] text-ink-soft">{tr('Contact', 'Contact')}
+            <input className={field} value={form.contact_name ?? ''} onChange={(e) => set('contact_name', e.target.value)} />
+          </label>
+          <label className="block text-[12.5px] text-ink-soft">{tr('E-mail du contact', 'Contact email')}
+            <input type="email" className={field} value={form.contact_email ?? ''} onChange={(e) => set('contact_email', e.target.value)} />
+          </label>
+        </div>
+        <div className="grid grid-cols-2 gap-3">
+          <label className="block text-[12.5px] text-ink-soft">{tr('Accès aux données', 'Data access')}
+            <select className={field} value={form.data_access} onChange={(e) => set('data_access', e.target.value as VendorDataAccess)}>
+              {DATA_ACCESS.map((d) => <option key={d.value} value={d.value}>{tr(d.fr, d.en)}</option>)}
+            </select>
+          </label>
+          <label className="block text-[12.5px] text-ink-soft">{tr('Criticité', 'Criticality')}
+            <select className={field} value={form.criticality} onChange={(e) => set('criticality', e.target.value as VendorCriticality)}>
+              {CRITICALITY.map((c) => <option key={c.value} value={c.value}>{tr(c.fr, c.en)}</option>)}
+            </select>
+          </label>
+        </div>
+        <label className="block text-[12.5px] text-ink-soft">{tr('Statut', 'Status')}
+          <select className={field} value={form.status ?? 'active'} onChange={(e) => set('status', e.target.value as VendorInput['status'])}>
+            <option value="onboarding">{tr('En référencement', 'Onboarding')}</option>
+            <option value="active">{tr('Actif', 'Active')}</option>
+            <option value="offboarded">{tr('Sorti', 'Offboarded')}</option>
+          </select>
+        </label>
+        <div className="flex justify-end gap-2 pt-2">
+          <Btn label={tr('Annuler', 'Cancel')} onClick={onClose} />
+          <Btn primary type="submit" label={tr('Enregistrer', 'Save')} disabled={save.isPending} />
+        </div>
+      </form>
+    </Overlay>
+  );
+}
+
+function VendorPanel({ id, canEdit, onEdit, onClose, tr, lang }: {
+  id: string; canEdit: boolean; onEdit: (v: Vendor) => void; onClose: () => void; tr: Tr; lang: string;
+}) {
+  const navigate = useNavigate();
+  const { data: v, isLoading } = useVendor(id);
+  const { data: templates } = useVendorQuestionnaires();
+  const send = useSendAssessment();
+  const revoke = useRevokeAssessment();
+  const remove = useDeleteVendor();
+  const [templateId, setTemplateId] = useState('');
+  const [sentTo, setSentTo] = useState('');
+  const [result, setResult] = useState<SendAssessmentResult | null>(null);
+
+  if (isLoading || !v) return <Overlay onClose={onClose}><SkeletonRows rows={4} /></Overlay>;
+
+  const doSend = () => {
+    const tpl = templateId || templates?.[0]?.id;
+    if (!tpl) return;
+    send.mutate({ vendorId: v.id, input: { template_id: tpl, sent_to: sentTo || undefined, locale: lang } }, {
+      onSuccess: (res) => {
+        setResult(res);
+        if (res.delivery === 'sent') toast.success(tr(`Questionnaire envoyé à ${res.assessment.sent_to}`, `Questionnaire sent to ${res.assessment.sent_to}`));
+      },
+      onError: (err) => toast.error(apiMessage(err, tr("L'envoi a échoué.", 'Sending failed.'))),
+    });
+  };
+
+  return (
+    <Overlay onClose={onClose}>
+      <div className="mb-4 flex items-start justify-between">
+        <div>
+          <h2 className="text-[16px] font-bold text-ink">{v.name}</h2>
+          <div className="text-[12.5px]" style={{ color: TIER_COLOR[v.tier] }}>
+            Tier {v.tier} · {tr('réévaluation tous les', 'reassessed every')} {v.tier} {tr('an(s)', 'year(s)')}
+          </div>
+        </div>
+        <button type="button" onClick={onClose} aria-label={tr('Fermer', 'Close')}><X size={18} /></button>
+      </div>
+
+      {canEdit && v.status !== 'offboarded' && (
+        <Card className="mb-4">
+          <div className="mb-2 text-[13px] font-semibold text-ink">{tr('Envoyer un questionnaire', 'Send a questionnaire')}</div>
+          <select className={field} value={templateId} onChange={(e) => setTemplateId(e.target.value)}>
+            {(templates ?? []).map((t) => <option key={t.id} value={t.id}>{t.name}</option>)}
+          </select>
+          <input
+            type="email"
+            className={field}
+            placeholder={v.contact_email || tr('Adresse du destinataire', 'Recipient address')}
+            value={sentTo}
+            onChange={(e) => setSentTo(e.target.value)}
+          />
+          <div className="mt-2 flex justify-end">
+            <Btn primary icon={Send} label={tr('Envoyer', 'Send')} onClick={doSend} disabled={send.isPending || !templates?.length} />
+          </div>
+          {result?.link_url && (
+            <div className="mt-3 rounded-[10px] p-3 text-[12.5px]" style={{ background: 'color-mix(in srgb, var(--medium) 12%, transparent)' }}>
+              <p className="mb-2 text-ink-soft">{result.delivery_detail}</p>
+              <div className="flex items-center gap-2">
+                <code className="flex-1 truncate">{result.link_url}</code>
+                <button type="button" onClick={() => { navigator.clipboard.writeText(result.link_url!); toast.success(tr('Lien copié', 'Link copied')); }}>
+                  <Copy size={15} />
+                </button>
+              </div>
+            </div>
+          )}
+        </Card>
+      )}
+
+      <div className="mb-2 text-[13px] font-semibold text-ink">{tr('Évaluations', 'Assessments')}</div>
+      {v.assessments.length === 0 ? (
+        <p className="text-[12.5px] text-ink-muted">{tr('Aucune évaluation pour le moment.', 'No assessments yet.')}</p>
+      ) : (
+        <ul className="space-y-2">
+          {v.assessments.map((a) => (
+            <li key={a.id} className="rounded-[10px] border border-border p-3 text-[12.5px]">
+              <div className="flex items-center justify-between">
+                <span className="font-semibold text-ink"><ClipboardList size={13} className="mr-1 inline" />{a.template_name}</span>
+                <span className="text-ink-muted">{stateLabel(a.state, tr)}</span>
+              </div>
+              <div className="mt-1 text-ink-soft">
+                {a.score !== null
+                  ? <span style={{ color: a.score >= a.pass_score ? 'var(--low)' : 'var(--critical)' }}>{a.score.toFixed(1)} / 100</span>
+                  : `${tr('Expire le', 'Expires')} ${fmtDate(a.expires_at, lang)}`}
+                {a.sent_to && ` · ${a.sent_to}`}
+              </div>
+              <div className="mt-1 flex gap-3">
+                {a.proposed_risk_id && (
+                  <button type="button" className="text-accent underline" onClick={() => navigate(`/risks?focus=${a.proposed_risk_id}`)}>
+                    {tr('Risque proposé', 'Proposed risk')}
+                  </button>
+                )}
+                {canEdit && (a.state === 'sent' || a.state === 'in_progress') && (
+                  <button type="button" className="underline" style={{ color: 'var(--critical)' }} onClick={() => revoke.mutate(a.id)}>
+                    {tr('Révoquer le lien', 'Revoke link')}
+                  </button>
+                )}
+              </div>
+            </li>
+          ))}
+        </ul>
+      )}
+
+      {canEdit && (
+        <div className="mt-5 flex justify-between">
+          <Btn danger icon={Trash2} label={tr('Supprimer', 'Delete')} onClick={() => {
+            if (!window.confirm(tr(`Supprimer ${v.name} et ses évaluations ?`, `Delete ${v.name} and its assessments?`))) return;
+            remove.mutate(v.id, { onSuccess: onClose });
+          }} />
+          <Btn label={tr('Modifier', 'Edit')} onClick={() => onEdit(v)} />
+        </div>
+      )}
+    </Overlay>
+  );
+}
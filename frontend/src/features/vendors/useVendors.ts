@@ -0,0 +1,80 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import {
+  vendorService,
+  type SendAssessmentInput,
+  type VendorInput,
+  type VendorQuestionnaireInput,
+} from './vendorService';
+
+export function useVendors() {
+  return useQuery({ queryKey: ['vendors'], queryFn: () => vendorService.list() });
+}
+
+/** One vendor with its assessments. `id` undefined disables the fetch. */
+export function useVendor(id: string | undefined) {
+  return useQuery({
+    queryKey: ['vendors', id],
+    queryFn: () => vendorService.get(id as string),
+    enabled: Boolean(id),
+  });
+}
+
+export function useSaveVendor() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: ({ id, input }: { id?: string; input: VendorInput }) =>
+      id ? vendorService.update(id, input) : vendorService.create(input),
+    onSuccess: () => qc.invalidateQueries({ queryKey: ['vendors'] }),
+  });
+}
+
+export function useDeleteVendor() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: (id: string) => vendorService.remove(id),
+    onSuccess: () => qc.invalidateQueries({ queryKey: ['vendors'] }),
+  });
+}
+
+export function useSendAssessment() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: ({ vendorId, input }: { vendorId: string; input: SendAssessmentInput }) =>
+      vendorService.sendAssessment(vendorId, input),
+    onSuccess: () => qc.invalidateQueries({ queryKey: ['vendors'] }),
+  });
+}
+
+export function useRevokeAssessment() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: (id: string) => vendorService.revokeAssessment(id),
+    onSuccess: () => qc.invalidateQueries({ queryKey: ['vendors'] }),
+  });
+}
+
+export function useVendorQuestionnaires() {
+  return useQuery({ queryKey: ['vendor-questionnaires'], queryFn: () => vendorService.listQuestionnaires() });
+}
+
+export function useSaveQuestionnaire() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: ({ id, input }: { id?: string; input: VendorQuestionnaireInput }) =>
+      vendorService.saveQuestionnaire(id, input),
+    onSuccess: () => qc.invalidateQueries({ queryKey: ['vendor-questionnaires'] }),
+  });
+}
+
+export function useDeleteQuestionnaire() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: (id: string) => vendorService.removeQuestionnaire(id),
+    onSuccess: () => qc.invalidateQueries({ queryKey: ['vendor-questionnaires'] }),
+  });
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2026 OpenDefender Contributors
+// SPDX-License-Identifier: AGPL-3.0-only
+// This program is free software: you can redistribute it and/or modify it under
+// the terms of the GNU Affero General Public License v3.0 (see LICENSE).
+//
+// Typed client for the vendor register. Mirrors domain.Vendor /
+// domain.VendorQuestionnaireTemplate / domain.VendorAssessment and the
+// application/vendor views. The portal calls are the vendor's side: no session,
+// the link token is the credential.
+
+import { api } from '../../lib/api';
+
+export type VendorDataAccess = 'none' | 'internal' | 'confidential' | 'personal' | 'regulated';
+export type VendorCriticality = 'low' | 'medium' | 'high' | 'critical';
+export type VendorStatus = 'active' | 'onboarding' | 'offboarded';
+export type VendorTier = 1 | 2 | 3;
+export type VendorQuestionType = 'yes_no' | 'choice' | 'text' | 'document';
+export type VendorTemplateKind = 'sig_lite' | 'caiq_lite' | 'custom';
+export type VendorAssessmentState = 'sent' | 'in_progress' | 'submitted' | 'revoked' | 'expired';
+export type Delivery = 'sent' | 'unavailable' | 'failed' | 'manual';
+
+/** The answer value that excludes a question from the score. */
+export const VENDOR_ANSWER_NA = 'n/a';
+
+export interface VendorInput {
+  name: string;
+  website?: string;
+  services?: string;
+  contact_name?: string;
+  contact_email?: string;
+  data_access: VendorDataAccess;
+  criticality: VendorCriticality;
+  status?: VendorStatus;
+  asset_id?: string | null;
+  owner_id?: string | null;
+}
+
+export interface Vendor extends VendorInput {
+  id: string;
+  tier: VendorTier;
+  status: VendorStatus;
+  last_score: number | null;
+  last_assessed_at: string | null;
+  next_assessment_at: string | null;
+  reassessment_due: boolean;
+}
+
+export interface VendorQuestionOption {
+  value: string;
+  label: string;
+  score: number;
+}
+
+export interface VendorQuestion {
+  id: string;
+  section: string;
+  text: string;
+  help?: string;
+  type: VendorQuestionType;
+  weight: number;
+  required: boolean;
+  allow_na: boolean;
+  options?: VendorQuestionOption[];
+}
+
+export interface VendorAnswer {
+  question_id: string;
+  value: string;
+  comment?: string;
+  evidence_ids?: string[];
+}
+
+export interface VendorQuestionnaireInput {
+  name: string;
+  description?: string;
+  questions: VendorQuestion[];
+  pass_score: number;
+}
+
+export interface VendorQuestionnaire extends VendorQuestionnaireInput {
+  id: string;
+  kind: VendorTemplateKind;
+  built_in: boolean;
+}
+
+export interface VendorAssessment {
+  id: string;
+  vendor_id: string;
+  template_id: string;
+  template_kind: VendorTemplateKind;
+  template_name: string;
+  pass_score: number;
+  sent_to: string;
+  expires_at: string;
+  state: VendorAssessmentState;
+  answers: VendorAnswer[];
+  score: number | null;
+  submitted_at: string | null;
+  response_evidence_id?: string;
+  proposed_risk_id?: string;
+  created_at: string;
+}
+
+export interface VendorDetail extends Vendor {
+  assessments: VendorAssessment[];
+}
+
+export interface SendAssessmentInput {
+  template_id: string;
+  sent_to?: string;
+  expires_in_days?: number;
+  locale?: string;
+}
+
+/** link_url is present only when the link was not emailed. */
+export interface SendAssessmentResult {
+  assessment: VendorAssessment;
+  delivery: Delivery;
+  delivery_detail?: string;
+  link_url?: string;
+}
+
+export interface VendorPortalView {
+  organization_name?: string;
+  vendor_name: string;
+  questionnaire_name: string;
+  questions: VendorQuestion[];
+  answers: VendorAnswer[];
+  expires_at: string;
+  state: VendorAssessmentState;
+  submitted_at?: string;
+}
+
+export const vendorService = {
+  list: async (filter?: { tier?: VendorTier; status?: VendorStatus; q?: string }): Promise<Vendor[]> => {
+    const res = await api.get<Vendor[]>('/vendors', { params: filter });
+    return res.data;
+  },
+
+  get: async (id: string): Promise<VendorDetail> => {
+    const res = await api.get<VendorDetail>(`/vendors/${id}`);
+    return res.data;
+  },
+
+  create: async (input: VendorInput): Promise<Vendor> => {
+    const res = await api.post<Vendor>('/vendors', input);
+    return res.data;
+  },
+
+  update: async (id: string, input: VendorInput): Promise<Vendor> => {
+    const res = await api.put<Vendor>(`/vendors/${id}`, input);
+    return res.data;
+  },
+
+  remove: async (id: string): Promise<void> => {
+    await api.delete(`/vendors/${id}`);
+  },
+
+  sendAssessment: async (vendorId: string, input: SendAssessmentInput): Promise<SendAssessmentResult> => {
+    const res = await api.post<SendAssessmentResult>(`/vendors/${vendorId}/assessments`, input);
+    return res.data;
+  },
+
+  revokeAssessment: async (id: string): Promise<VendorAssessment> => {
+    const res = await api.post<VendorAssessment>(`/vendor-assessments/${id}/revoke`);
+    return res.data;
+  },
+
+  listQuestionnaires: async (): Promise<VendorQuestionnaire[]> => {
+    const res = await api.get<VendorQuestionnaire[]>('/vendor-questionnaires');
+    return res.data;
+  },
+
+  saveQuestionnaire: async (id: string | undefined, input: VendorQuestionnaireInput): Promise<VendorQuestionnaire> => {
+    const res = id
+      ? await api.put<VendorQuestionnaire>(`/vendor-questionnaires/${id}`, input)
+      : await api.post<VendorQuestionnaire>('/vendor-questionnaires', input);
+    return res.data;
+  },
+
+  removeQuestionnaire: async (id: string): Promise<void> => {
+    await api.delete(`/vendor-questionnaires/${id}`);
+  },
+
+  // ---- vendor side (no session) ----
+
+  portal: async (token: string): Promise<VendorPortalView> => {
+    const res = await api.get<VendorPortalView>('/vendor-portal', { params: { token } });
+    return res.data;
+  },
+
+  /** submit false saves a draft; true hands the answers in and closes the link. */
+  saveAnswers: async (token: string, answers: VendorAnswer[], submit: boolean): Promise<VendorPortalView> => {
+    const res = await api.post<VendorPortalView>('/vendor-portal/answers', { token, answers, submit });
+    return res.data;
+  },
+
+  uploadDocument: async (token: string, questionId: string, file: File): Promise<VendorPortalView> => {
+    const form = new FormData();
+    form.append('token', token);
+    form.append('question_id', questionId);
+    form.append('file', file);
+    const res = await api.post<VendorPortalView>('/vendor-portal/documents', form);
+    return res.data;
+  },
+};
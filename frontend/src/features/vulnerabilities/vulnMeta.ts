@@ -44,6 +44,9 @@ export const SOURCE_LABEL: Record<VulnSource, string> = {
   crowdstrike: 'CrowdStrike',
   scanner: 'Scanner',
   manual: 'Manuel',
+  plugin: 'Plugin',
+  sarif: 'SARIF',
+  sbom: 'SBOM (CycloneDX)',
 };
 
 export const pick = <T,>(v: [T, T], lang: 'fr' | 'en'): T => (lang === 'fr' ? v[0] : v[1]);
@@ -14,7 +14,7 @@ export type VulnStatus =
   | 'open' | 'triaged' | 'in_remediation' | 'remediated' | 'accepted' | 'false_positive';
 export type VulnSource =
   | 'nessus' | 'openvas' | 'qualys' | 'ms_defender'
-  | 'aws_inspector' | 'azure_defender' | 'crowdstrike' | 'scanner' | 'manual';
+  | 'aws_inspector' | 'azure_defender' | 'crowdstrike' | 'scanner' | 'manual' | 'plugin' | 'sarif' | 'sbom';
 
 export interface Vulnerability {
   id: string;
@@ -72,7 +72,7 @@ export interface VulnStats {
 export interface ConnectorInfo {
   source: VulnSource;
   label: string;
-  category: 'network_scanner' | 'edr' | 'cloud';
+  category: 'network_scanner' | 'edr' | 'cloud' | 'code';
   ingest: boolean;
   live_pull: boolean;
   notes: string;
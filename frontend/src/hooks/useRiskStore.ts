@@ -35,6 +35,8 @@ export interface Risk {
   /** The tenant's CONTROLLED vocabulary → colonne « Catégorie ». */
   category_id?: string | null;
   category?: { id: string; name: string; slug: string; color: string } | null;
+  // Owning business unit — the other axis risk-appetite statements scope on.
+  business_unit?: string;
   /** Real compliance references → colonne « Référentiel ». */
   control_mappings?: RiskControlMapping[];
   assets?: Asset[]; // Important pour l'association Risk-Asset
@@ -100,10 +100,12 @@ export interface CreateRiskInput {
   asset_ids?: string[];
   source?: string;
   status?: RiskStatus;
+  business_unit?: string;
 }
 
 export interface UpdateRiskInput {
   title?: string;
+  business_unit?: string;
   description?: string;
   probability?: number;
   impact?: number;
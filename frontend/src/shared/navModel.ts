@@ -15,7 +15,7 @@ import {
   FolderCheck,
   LayoutDashboard, TrendingUp, ShieldAlert, ShieldCheck, Siren, Server,
   ClipboardCheck, Globe, Database, Atom, FileText, Sparkles, Settings, Bug, Coins,
-  Workflow, Scale, Users,
+  Workflow, Scale, Users, Handshake, Crosshair, ListChecks, History, Network, FolderKanban, Upload, Puzzle,
   type LucideIcon,
 } from 'lucide-react';
 import type { UIStrings } from './uiStrings';
@@ -89,8 +89,10 @@ export const NAV_GROUPS: NavGroup[] = [
     groupKey: 'g_pilot',
     items: [
       { key: 'risks', labelKey: 'n_risks', icon: ShieldAlert, path: '/risks', perm: 'risks:read' },
+      { key: 'registerSnapshots', labelKey: 'n_registerSnapshots', icon: History, path: '/risks/snapshots', perm: 'risks:snapshots:read' },
       { key: 'vulnerabilities', labelKey: 'n_vulns', icon: Bug, path: '/vulnerabilities', perm: 'vulnerabilities:read' },
       { key: 'mitigations', labelKey: 'n_mitigations', icon: ShieldCheck, path: '/risks/mitigations', perm: 'mitigations:read' },
+      { key: 'programmes', labelKey: 'n_programmes', icon: FolderKanban, path: '/risks/programmes', perm: 'mitigations:read' },
       { key: 'incidents', labelKey: 'n_incidents', icon: Siren, path: '/incidents', perm: 'incidents:read' },
       { key: 'automation', labelKey: 'n_automation', icon: Workflow, path: '/automation', perm: 'automation:read' },
     ],
@@ -102,6 +104,7 @@ export const NAV_GROUPS: NavGroup[] = [
       { key: 'dashboard', labelKey: 'n_dashboard', icon: LayoutDashboard, path: '/', pinned: true },
       { key: 'analytics', labelKey: 'n_analytics', icon: TrendingUp, path: '/', href: '/?view=executive', view: 'executive', perm: 'risks:read' },
       { key: 'financial', labelKey: 'n_financial', icon: Coins, path: '/analytics/financial', perm: 'risks:read' },
+      { key: 'group', labelKey: 'n_group', icon: Network, path: '/group', perm: 'group:read' },
     ],
   },
   // 1 · Identifier — « Qu'est-ce que je possède et qu'est-ce qui me menace ? »
@@ -112,6 +115,8 @@ export const NAV_GROUPS: NavGroup[] = [
       { key: 'universe', labelKey: 'n_universe', icon: Atom, path: '/assets/topology', perm: 'assets:read' },
       { key: 'vulnerabilities', labelKey: 'n_vulns', icon: Bug, path: '/vulnerabilities', perm: 'vulnerabilities:read' },
       { key: 'cti', labelKey: 'n_cti', icon: Globe, path: '/threat-map', perm: 'risks:read' },
+      { key: 'vendors', labelKey: 'n_vendors', icon: Handshake, path: '/vendors', perm: 'risks:read' },
+      { key: 'scenarios', labelKey: 'n_scenarios', icon: Crosshair, path: '/scenarios', perm: 'risks:read' },
       { key: 'infrastructure', labelKey: 'n_infra', icon: Server, path: '/infrastructure', perm: 'scanner:read' },
     ],
   },
@@ -127,6 +132,7 @@ export const NAV_GROUPS: NavGroup[] = [
     groupKey: 'g_treat',
     items: [
       { key: 'mitigations', labelKey: 'n_mitigations', icon: ShieldCheck, path: '/risks/mitigations', perm: 'mitigations:read' },
+      { key: 'programmes', labelKey: 'n_programmes', icon: FolderKanban, path: '/risks/programmes', perm: 'mitigations:read' },
       { key: 'incidents', labelKey: 'n_incidents', icon: Siren, path: '/incidents', perm: 'incidents:read' },
       { key: 'automation', labelKey: 'n_automation', icon: Workflow, path: '/automation', perm: 'automation:read' },
     ],
@@ -140,6 +146,7 @@ export const NAV_GROUPS: NavGroup[] = [
       // framework: the same artifact answers controls in several frameworks, so
       // filing it under one of them would hide it from the others.
       { key: 'evidence', labelKey: 'n_evidence', icon: FolderCheck, path: '/compliance/evidence', perm: 'compliance:evidences:read' },
+      { key: 'controlTests', labelKey: 'n_controlTests', icon: ListChecks, path: '/compliance/control-tests', perm: 'compliance:controls:read' },
       { key: 'reports', labelKey: 'n_reports', icon: FileText, path: '/reports', perm: 'reports:board:read' },
       { key: 'ai', labelKey: 'n_ai', icon: Sparkles, path: '/recommendations', perm: 'risks:read' },
       { key: 'emerging', labelKey: 'n_emerging', icon: Sparkles, path: '/ai/emerging-risks', perm: 'risks:read' },
@@ -154,6 +161,8 @@ export const NAV_GROUPS: NavGroup[] = [
       // beside a nav item should mean "something is waiting for you", and the
       // headcount is not.
       { key: 'roles', labelKey: 'n_roles', icon: Users, path: '/settings/members', adminOnly: true, badge: { count: 'pending_invitations', color: 'var(--info)' } },
+      { key: 'imports', labelKey: 'n_imports', icon: Upload, path: '/settings/imports', adminOnly: true },
+      { key: 'plugins', labelKey: 'n_plugins', icon: Puzzle, path: '/settings/plugins', adminOnly: true },
       { key: 'settings', labelKey: 'n_settings', icon: Settings, path: '/settings' },
     ],
   },
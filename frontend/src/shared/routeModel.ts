@@ -57,15 +57,20 @@ export const ROUTES: RouteNode[] = [
   { path: '/risks', labelKey: 'n_risks', perm: 'risks:read' },
   { path: '/risks/import', label: { fr: 'Importer', en: 'Import' }, parent: '/risks', perm: 'risks:create' },
   { path: '/risks/weighting', label: { fr: 'Pondération', en: 'Weighting' }, parent: '/risks', perm: 'risks:read' },
+  { path: '/risks/snapshots', labelKey: 'n_registerSnapshots', parent: '/risks', perm: 'risks:snapshots:read' },
   { path: '/risks/:riskId/timeline', label: { fr: 'Chronologie', en: 'Timeline' }, parent: '/risks', perm: 'risks:read' },
+  { path: '/risks/:riskId/bowtie', label: { fr: 'Nœud papillon', en: 'Bowtie' }, parent: '/risks', perm: 'risks:read' },
   // Mitigations live under Risks: a mitigation only exists to reduce a risk, and
   // filing it anywhere else is what made "back" ambiguous from its detail view.
   { path: '/risks/mitigations', labelKey: 'n_mitigations', parent: '/risks', perm: 'mitigations:read' },
   { path: '/risks/mitigations/:mitigationId', label: { fr: 'Plan', en: 'Plan' }, parent: '/risks/mitigations', perm: 'mitigations:read', dynamic: true },
+  { path: '/risks/programmes', labelKey: 'n_programmes', parent: '/risks', perm: 'mitigations:read' },
 
   /* ---------------- Vulnerabilities / threats ---------------- */
   { path: '/vulnerabilities', labelKey: 'n_vulns', perm: 'vulnerabilities:read' },
   { path: '/threat-map', labelKey: 'n_cti', perm: 'risks:read' },
+  { path: '/vendors', labelKey: 'n_vendors', perm: 'risks:read' },
+  { path: '/scenarios', labelKey: 'n_scenarios', perm: 'risks:read' },
   { path: '/ai/emerging-risks', labelKey: 'n_emerging', perm: 'risks:read', topLevel: true },
   { path: '/simulations', labelKey: 'n_simulations', perm: 'risks:read' },
 
@@ -80,6 +85,7 @@ export const ROUTES: RouteNode[] = [
   { path: '/compliance/gaps', label: { fr: "Analyse d'écarts", en: 'Gap analysis' }, parent: '/compliance', perm: 'compliance:controls:read' },
   { path: '/compliance/evidence', label: { fr: 'Bibliothèque de preuves', en: 'Evidence library' }, parent: '/compliance', perm: 'compliance:evidences:read' },
   { path: '/compliance/evidence/missing', label: { fr: 'Preuves manquantes', en: 'Missing evidence' }, parent: '/compliance/evidence', perm: 'compliance:evidences:read' },
+  { path: '/compliance/control-tests', labelKey: 'n_controlTests', parent: '/compliance', perm: 'compliance:controls:read' },
   { path: '/compliance/audits', label: { fr: 'Audits', en: 'Audits' }, parent: '/compliance', perm: 'compliance:audits:read' },
   { path: '/compliance/audits/:auditId', label: { fr: 'Audit', en: 'Audit' }, parent: '/compliance/audits', perm: 'compliance:audits:read', dynamic: true },
   { path: '/compliance/remediation', label: { fr: 'Plans de remédiation', en: 'Remediation plans' }, parent: '/compliance', perm: 'compliance:remediations:read' },
@@ -97,6 +103,8 @@ export const ROUTES: RouteNode[] = [
   // a report. It answers "how are we doing", which is the dashboard's question;
   // filing it under Reports is what made people look for it beside PDFs.
   { path: '/analytics/financial', labelKey: 'n_financial', perm: 'risks:read', topLevel: true },
+  // The holding's roll-up of the subsidiaries that accepted its link.
+  { path: '/group', labelKey: 'n_group', perm: 'group:read', topLevel: true },
   { path: '/leaderboard', labelKey: 'n_leaderboard' },
 
   /* ---------------- Reports ---------------- */
@@ -118,6 +126,8 @@ export const ROUTES: RouteNode[] = [
   // person the right access"), and splitting them across two screens is why
   // "Invite a member" used to land on Roles & permissions.
   { path: '/settings/members', label: { fr: 'Membres', en: 'Members' }, parent: '/settings' },
+  { path: '/settings/imports', labelKey: 'n_imports', parent: '/settings' },
+  { path: '/settings/plugins', labelKey: 'n_plugins', parent: '/settings' },
 ];
 
 /* ------------------------------------------------------------------ *
@@ -234,6 +234,23 @@ export function RadialGauge({
   );
 }
 
+/** Inline trend line (KRI history). Points oldest first; a flat series draws across the middle. */
+export function Sparkline({ points, width = 120, height = 28, color = 'var(--accent)' }: { points: number[]; width?: number; height?: number; color?: string }) {
+  if (points.length === 0) return <svg width={width} height={height} aria-hidden />;
+  const lo = Math.min(...points), hi = Math.max(...points);
+  const pad = 3;
+  const x = (i: number) => (points.length === 1 ? width - pad : pad + (i * (width - 2 * pad)) / (points.length - 1));
+  const y = (v: number) => (hi === lo ? height / 2 : pad + (1 - (v - lo) / (hi - lo)) * (height - 2 * pad));
+  const d = points.map((v, i) => `${i === 0 ? 'M' : 'L'}${x(i).toFixed(1)},${y(v).toFixed(1)}`).join(' ');
+  const last = points[points.length - 1];
+  return (
+    <svg width={width} height={height} viewBox={`0 0 ${width} ${height}`} role="img" aria-label={`trend, last value ${last}`}>
+      {points.length > 1 && <path d={d} fill="none" stroke={color} strokeWidth={1.6} strokeLinejoin="round" strokeLinecap="round" />}
+      <circle cx={x(points.length - 1)} cy={y(last)} r={2.4} fill={color} />
+    </svg>
+  );
+}
+
 /** Full-circle progress ring with centered content (compliance / simulation gauges). */
 export function RingGauge({ value, size = 128, color, thickness, children }: { value: number; size?: number; color: string; thickness?: number; children?: React.ReactNode }) {
   const stroke = thickness ?? Math.max(6, size * 0.075);
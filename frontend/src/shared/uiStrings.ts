@@ -15,10 +15,10 @@ const fr = {
   g_overview: 'Aperçu', g_security: 'Sécurité', g_intel: 'Conformité & Intel', g_assets: 'Actifs',
   g_report: 'Reporting & IA', g_admin: 'Admin',
   g_pilot: 'Piloter', g_monitor: 'Surveiller', g_identify: 'Identifier', g_evaluate: 'Évaluer', g_treat: 'Traiter', g_prove: 'Prouver',
-  n_dashboard: 'Tableau de bord', n_analytics: 'Tableau exécutif', n_risks: 'Registre des risques',
-  n_mitigations: 'Mitigations', n_incidents: 'Incidents', n_infra: 'Infrastructure',
-  n_compliance: 'Conformité', n_cti: 'Threat Intel', n_assets: 'Inventaire', n_universe: 'Topologie', n_assetSchemas: 'Attributs par catégorie',
-  n_evidence: 'Preuves', n_reports: 'Rapports', n_ai: 'IA Advisor', n_emerging: 'Risques émergents', n_settings: 'Paramètres', n_superadmin: 'Super Admin',
+  n_dashboard: 'Tableau de bord', n_analytics: 'Tableau exécutif', n_risks: 'Registre des risques', n_registerSnapshots: 'Instantanés du registre', n_group: 'Groupe',
+  n_mitigations: 'Mitigations', n_programmes: 'Programmes', n_incidents: 'Incidents', n_infra: 'Infrastructure',
+  n_compliance: 'Conformité', n_cti: 'Threat Intel', n_vendors: 'Fournisseurs', n_scenarios: 'Scénarios de risque', n_controlTests: 'Tests de contrôles', n_assets: 'Inventaire', n_universe: 'Topologie', n_assetSchemas: 'Attributs par catégorie',
+  n_evidence: 'Preuves', n_reports: 'Rapports', n_ai: 'IA Advisor', n_emerging: 'Risques émergents', n_settings: 'Paramètres', n_imports: 'Import de données', n_plugins: 'Plugins', n_superadmin: 'Super Admin',
   n_simulations: 'Simulations', n_leaderboard: 'Classement', n_vulns: 'Vulnérabilités',
   n_financial: 'Quantification financière', n_automation: 'Automatisation', n_governance: 'Gouvernance',
   n_roles: 'Rôles & accès',
@@ -81,10 +81,10 @@ const en: typeof fr = {
   g_overview: 'Overview', g_security: 'Security', g_intel: 'Compliance & Intel', g_assets: 'Assets',
   g_report: 'Reporting & AI', g_admin: 'Admin',
   g_pilot: 'Pilot', g_monitor: 'Monitor', g_identify: 'Identify', g_evaluate: 'Evaluate', g_treat: 'Treat', g_prove: 'Prove',
-  n_dashboard: 'Dashboard', n_analytics: 'Executive dashboard', n_risks: 'Risk Register',
-  n_mitigations: 'Mitigations', n_incidents: 'Incidents', n_infra: 'Infrastructure',
-  n_compliance: 'Compliance', n_cti: 'Threat Intel', n_assets: 'Inventory', n_universe: 'Topology', n_assetSchemas: 'Attributes by category',
-  n_evidence: 'Evidence', n_reports: 'Reports', n_ai: 'AI Advisor', n_emerging: 'Emerging risks', n_settings: 'Settings', n_superadmin: 'Super Admin',
+  n_dashboard: 'Dashboard', n_analytics: 'Executive dashboard', n_risks: 'Risk Register', n_registerSnapshots: 'Register snapshots', n_group: 'Group',
+  n_mitigations: 'Mitigations', n_programmes: 'Programmes', n_incidents: 'Incidents', n_infra: 'Infrastructure',
+  n_compliance: 'Compliance', n_cti: 'Threat Intel', n_vendors: 'Vendors', n_scenarios: 'Risk scenarios', n_controlTests: 'Control tests', n_assets: 'Inventory', n_universe: 'Topology', n_assetSchemas: 'Attributes by category',
+  n_evidence: 'Evidence', n_reports: 'Reports', n_ai: 'AI Advisor', n_emerging: 'Emerging risks', n_settings: 'Settings', n_imports: 'Data import', n_plugins: 'Plugins', n_superadmin: 'Super Admin',
   n_simulations: 'Simulations', n_leaderboard: 'Leaderboard', n_vulns: 'Vulnerabilities',
   n_financial: 'Financial Quantification', n_automation: 'Automation', n_governance: 'Governance',
   n_roles: 'Roles & access',
@@ -20,6 +20,73 @@ export interface FrameworkSnapshot {
   percent_complete: number;
 }
 
+export type AppetiteExceptionStatus = 'none' | 'pending' | 'approved' | 'expired';
+
+// The register against the board's appetite statements, frozen at generation
+// time. Absent when no statement was in force.
+export interface AppetiteSnapshot {
+  statements_in_force: number;
+  risks_above: number;
+  without_exception: number;
+  pending_exceptions: number;
+  breached_scopes: {
+    scope_label: string;
+    statement: string;
+    risks_above: number;
+    breaches: { metric: string; value: number; limit: number }[];
+  }[];
+  top_risks: {
+    title: string;
+    criticality: string;
+    score: number;
+    exception_status: AppetiteExceptionStatus;
+    valid_until?: string;
+  }[];
+}
+
+export type KRIBand = 'unknown' | 'green' | 'amber' | 'red';
+
+// Key risk indicators and their last 90 days, frozen at generation time.
+// Absent when the organisation had no KRI.
+export interface KRISnapshot {
+  red: number;
+  amber: number;
+  green: number;
+  indicators: {
+    name: string;
+    unit?: string;
+    status: KRIBand;
+    direction: 'higher_is_worse' | 'lower_is_worse';
+    value?: number;
+    amber_threshold: number;
+    red_threshold: number;
+    points: number[];
+  }[];
+}
+
+// One risk that moved in the register; a score is absent on the side where
+// the risk did not exist.
+export interface RegisterChangeRisk {
+  title: string;
+  criticality: string;
+  from_score?: number;
+  to_score?: number;
+}
+
+// What moved in the register over the month before generation, compared with
+// the snapshot standing for `since`. Absent when no snapshot was that old.
+export interface RegisterChanges {
+  since: string;
+  new: number;
+  closed: number;
+  up_scored: number;
+  down_scored: number;
+  new_risks: RegisterChangeRisk[];
+  closed_risks: RegisterChangeRisk[];
+  up_scored_risks: RegisterChangeRisk[];
+  down_scored_risks: RegisterChangeRisk[];
+}
+
 export interface BoardReport {
   id: string;
   tenant_id: string;
@@ -37,6 +104,9 @@ export interface BoardReport {
   financial_exposure_fcfa: number;
   overall_compliance_percent: number;
   frameworks_snapshot: FrameworkSnapshot[] | null;
+  appetite_snapshot?: AppetiteSnapshot | null;
+  kri_snapshot?: KRISnapshot | null;
+  register_changes?: RegisterChanges | null;
 
   executive_summary: string;
   risk_commentary: string;
@@ -8,7 +8,7 @@
 
 export type EvidenceType = 'document' | 'capture' | 'configuration' | 'attestation' | 'log';
 export type EvidenceReview = 'pending' | 'accepted' | 'rejected';
-export type EvidenceSource = 'manual' | 'integration' | 'scanner' | 'automation';
+export type EvidenceSource = 'manual' | 'integration' | 'scanner' | 'automation' | 'vendor';
 
 /**
  * The state an auditor reads. DERIVED by the server from the expiry date and the
@@ -989,7 +989,7 @@ export interface paths {
             query?: never;
             header?: never;
             path: {
-                category: "server" | "workstation" | "application" | "database" | "network" | "cloud" | "vendor" | "data_processing";
+                category: "server" | "workstation" | "application" | "database" | "network" | "cloud" | "vendor" | "data_processing" | "business_process";
             };
             cookie?: never;
         };
@@ -999,7 +999,7 @@ export interface paths {
                 query?: never;
                 header?: never;
                 path: {
-                    category: "server" | "workstation" | "application" | "database" | "network" | "cloud" | "vendor" | "data_processing";
+                    category: "server" | "workstation" | "application" | "database" | "network" | "cloud" | "vendor" | "data_processing" | "business_process";
                 };
                 cookie?: never;
             };
@@ -1032,7 +1032,7 @@ export interface paths {
                 query?: never;
                 header?: never;
                 path: {
-                    category: "server" | "workstation" | "application" | "database" | "network" | "cloud" | "vendor" | "data_processing";
+                    category: "server" | "workstation" | "application" | "database" | "network" | "cloud" | "vendor" | "data_processing" | "business_process";
                 };
                 cookie?: never;
             };
@@ -1462,7 +1462,7 @@ export interface components {
             /** @example db-prod-001 */
             external_id?: string;
             /** @enum {string} */
-            category?: "server" | "workstation" | "application" | "database" | "network" | "cloud" | "vendor" | "data_processing";
+            category?: "server" | "workstation" | "application" | "database" | "network" | "cloud" | "vendor" | "data_processing" | "business_process";
             /** @description Typed attribute values. Requires a category — without one there is no schema to validate them against, and they are rejected. */
             attributes?: {
                 [key: string]: unknown;
@@ -1477,7 +1477,7 @@ export interface components {
             criticality?: "LOW" | "MEDIUM" | "HIGH" | "CRITICAL";
             owner?: string;
             /** @enum {string} */
-            category?: "server" | "workstation" | "application" | "database" | "network" | "cloud" | "vendor" | "data_processing";
+            category?: "server" | "workstation" | "application" | "database" | "network" | "cloud" | "vendor" | "data_processing" | "business_process";
             /** @description When present, REPLACES the whole attribute bag (it is validated as a whole, so a partial merge could drop a required attribute without the validator seeing its absence). */
             attributes?: {
                 [key: string]: unknown;
@@ -1987,7 +1987,7 @@ export interface components {
              * @description Typed category selecting which attribute schema governs this asset. Empty on assets registered before typed attributes existed.
              * @enum {string}
              */
-            category?: "server" | "workstation" | "application" | "database" | "network" | "cloud" | "vendor" | "data_processing";
+            category?: "server" | "workstation" | "application" | "database" | "network" | "cloud" | "vendor" | "data_processing" | "business_process";
             /** @description Typed attribute values, validated server-side against the tenant's schema for this asset's category. */
             attributes?: {
                 [key: string]: unknown;
@@ -2032,7 +2032,7 @@ export interface components {
             /** Format: uuid */
             tenant_id?: string;
             /** @enum {string} */
-            category: "server" | "workstation" | "application" | "database" | "network" | "cloud" | "vendor" | "data_processing";
+            category: "server" | "workstation" | "application" | "database" | "network" | "cloud" | "vendor" | "data_processing" | "business_process";
             label?: string;
             attributes: components["schemas"]["AttributeDef"][];
             /** @description Whether the tenant edited the shipped default. */
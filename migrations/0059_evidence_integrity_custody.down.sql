@@ -0,0 +1,14 @@
+-- Reverses 0059. The custody log is dropped with its table; the audit trail
+-- entries that referenced it remain, as every audit entry does.
+
+BEGIN;
+
+DROP TABLE IF EXISTS evidence_custody_events;
+
+DROP INDEX IF EXISTS idx_evidences_sha256;
+
+ALTER TABLE IF EXISTS evidences
+    DROP COLUMN IF EXISTS size_bytes,
+    DROP COLUMN IF EXISTS sha256;
+
+COMMIT;
@@ -0,0 +1,39 @@
+-- Evidence integrity and chain of custody.
+--
+--   1. evidences gains the SHA-256 digest and size of its file, computed on
+--      upload. Existing rows keep an empty digest and read as "unverifiable"
+--      rather than being hashed retroactively: a digest taken today proves
+--      nothing about the day the file was collected.
+--   2. evidence_custody_events is the append-only custody log. Each row is also
+--      referenced from a hash-chained audit_events entry.
+--
+-- The evidences table itself is created by AutoMigrate, so the ALTER is
+-- guarded for databases where this migration runs first.
+
+BEGIN;
+
+ALTER TABLE IF EXISTS evidences
+    ADD COLUMN IF NOT EXISTS sha256     VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS size_bytes BIGINT      NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_evidences_sha256 ON evidences (sha256);
+
+CREATE TABLE IF NOT EXISTS evidence_custody_events (
+    id          UUID PRIMARY KEY,
+    tenant_id   UUID        NOT NULL,
+    evidence_id UUID        NOT NULL,
+    action      VARCHAR(24) NOT NULL,
+    actor_id    UUID,
+    sha256      VARCHAR(64) NOT NULL DEFAULT '',
+    detail      TEXT,
+    ip_address  VARCHAR(64),
+    created_at  TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_evidence_custody_events_tenant_id   ON evidence_custody_events (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_evidence_custody_events_evidence_id ON evidence_custody_events (evidence_id);
+CREATE INDEX IF NOT EXISTS idx_evidence_custody_events_action      ON evidence_custody_events (action);
+CREATE INDEX IF NOT EXISTS idx_evidence_custody_events_actor_id    ON evidence_custody_events (actor_id);
+CREATE INDEX IF NOT EXISTS idx_evidence_custody_events_created_at  ON evidence_custody_events (created_at);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Reverses 0060. Submission receipts live in the evidence library and survive;
+-- only the clocks, classifications and profiles are dropped.
+
+BEGIN;
+
+DROP TABLE IF EXISTS regulatory_clocks;
+DROP TABLE IF EXISTS incident_regulatory_assessments;
+DROP TABLE IF EXISTS regulatory_profiles;
+
+COMMIT;
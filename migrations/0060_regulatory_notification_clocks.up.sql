@@ -0,0 +1,98 @@
+-- Regulatory breach-notification clocks (GDPR art. 33, NIS2 art. 23, DORA
+-- art. 19).
+--
+--   1. regulatory_profiles: per-tenant configuration of a regime in one
+--      jurisdiction (authority, classification criteria, escalation audience).
+--      One per (tenant, regime, jurisdiction).
+--   2. incident_regulatory_assessments: the classification decision on an
+--      incident, one per incident.
+--   3. regulatory_clocks: one dated reporting obligation per stage, with the
+--      submission record (reference, receipt evidence, frozen content).
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS regulatory_profiles (
+    id                UUID PRIMARY KEY,
+    tenant_id         UUID         NOT NULL,
+    regime            VARCHAR(16)  NOT NULL,
+    jurisdiction      VARCHAR(8)   NOT NULL,
+    authority         VARCHAR(255),
+    authority_contact VARCHAR(512),
+    criteria          JSONB,
+    escalate_channels JSONB,
+    escalate_to_role  VARCHAR(32),
+    contact_point     VARCHAR(255),
+    enabled           BOOLEAN      NOT NULL DEFAULT TRUE,
+    built_in          BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at        TIMESTAMPTZ,
+    updated_at        TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_regulatory_profiles_tenant_id ON regulatory_profiles (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_regulatory_profiles_regime    ON regulatory_profiles (regime);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_regulatory_profiles_scope
+    ON regulatory_profiles (tenant_id, regime, jurisdiction);
+
+CREATE TABLE IF NOT EXISTS incident_regulatory_assessments (
+    id                  UUID PRIMARY KEY,
+    tenant_id           UUID    NOT NULL,
+    incident_id         BIGINT  NOT NULL,
+    personal_data       BOOLEAN NOT NULL DEFAULT FALSE,
+    significant         BOOLEAN NOT NULL DEFAULT FALSE,
+    major               BOOLEAN NOT NULL DEFAULT FALSE,
+    affected_subjects   INTEGER NOT NULL DEFAULT 0,
+    data_categories     JSONB,
+    jurisdictions       JSONB,
+    cross_border        BOOLEAN NOT NULL DEFAULT FALSE,
+    malicious           BOOLEAN NOT NULL DEFAULT FALSE,
+    consequences        TEXT,
+    notes               TEXT,
+    aware_at            TIMESTAMPTZ,
+    classified_at       TIMESTAMPTZ,
+    classified_by       UUID,
+    created_at          TIMESTAMPTZ,
+    updated_at          TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_incident_regulatory_assessments_tenant_id
+    ON incident_regulatory_assessments (tenant_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_incident_regulatory_assessments_incident_id
+    ON incident_regulatory_assessments (incident_id);
+
+CREATE TABLE IF NOT EXISTS regulatory_clocks (
+    id                     UUID PRIMARY KEY,
+    tenant_id              UUID         NOT NULL,
+    incident_id            BIGINT       NOT NULL,
+    profile_id             UUID         NOT NULL,
+    regime                 VARCHAR(16)  NOT NULL,
+    jurisdiction           VARCHAR(8),
+    authority              VARCHAR(255),
+    stage                  VARCHAR(32)  NOT NULL,
+    stage_label            VARCHAR(128),
+    sequence               INTEGER      NOT NULL DEFAULT 0,
+    due_at                 TIMESTAMPTZ,
+    status                 VARCHAR(16)  NOT NULL DEFAULT 'pending',
+    framework_id           UUID,
+    control_id             UUID,
+    escalation_level       INTEGER      NOT NULL DEFAULT 0,
+    last_escalated_at      TIMESTAMPTZ,
+    next_escalation_at     TIMESTAMPTZ,
+    submitted_at           TIMESTAMPTZ,
+    submitted_by           UUID,
+    submission_reference   VARCHAR(255),
+    submission_evidence_id UUID,
+    submitted_content      JSONB,
+    withdrawn_reason       TEXT,
+    created_at             TIMESTAMPTZ,
+    updated_at             TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_regulatory_clocks_tenant_id          ON regulatory_clocks (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_regulatory_clocks_incident_id        ON regulatory_clocks (incident_id);
+CREATE INDEX IF NOT EXISTS idx_regulatory_clocks_profile_id         ON regulatory_clocks (profile_id);
+CREATE INDEX IF NOT EXISTS idx_regulatory_clocks_regime             ON regulatory_clocks (regime);
+CREATE INDEX IF NOT EXISTS idx_regulatory_clocks_due_at             ON regulatory_clocks (due_at);
+CREATE INDEX IF NOT EXISTS idx_regulatory_clocks_status             ON regulatory_clocks (status);
+CREATE INDEX IF NOT EXISTS idx_regulatory_clocks_next_escalation_at ON regulatory_clocks (next_escalation_at);
+
+COMMIT;
@@ -0,0 +1,11 @@
+-- Reverses 0061. Incidents imported from TheHive, their actions and the assets
+-- attributed to them stay; only the connection and the pairing records go.
+
+BEGIN;
+
+DROP TABLE IF EXISTS thehive_observables;
+DROP TABLE IF EXISTS thehive_task_links;
+DROP TABLE IF EXISTS thehive_case_links;
+DROP TABLE IF EXISTS thehive_connections;
+
+COMMIT;
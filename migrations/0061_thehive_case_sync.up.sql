@@ -0,0 +1,93 @@
+-- TheHive 5 bi-directional case sync.
+--
+--   1. thehive_connections: one per tenant — instance URL, organisation,
+--      encrypted API key, sync switches, webhook token and the two high-water
+--      marks (remote cursor, last pushed incident change).
+--   2. thehive_case_links: incident ⇄ case pairs with the fingerprints that
+--      stop the sync echoing its own writes.
+--   3. thehive_task_links: incident action ⇄ case task pairs.
+--   4. thehive_observables: each case observable and the asset it was
+--      attributed to (NULL when nothing matched confidently).
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS thehive_connections (
+    id                UUID PRIMARY KEY,
+    tenant_id         UUID         NOT NULL,
+    name              VARCHAR(128),
+    enabled           BOOLEAN      NOT NULL DEFAULT TRUE,
+    base_url          VARCHAR(512) NOT NULL,
+    organisation      VARCHAR(128),
+    encrypted_api_key TEXT,
+    push_incidents    BOOLEAN      NOT NULL DEFAULT TRUE,
+    import_cases      BOOLEAN      NOT NULL DEFAULT FALSE,
+    poll_minutes      INTEGER      NOT NULL DEFAULT 5,
+    webhook_token     VARCHAR(80),
+    cursor            TIMESTAMPTZ,
+    pushed_until      TIMESTAMPTZ,
+    last_sync_at      TIMESTAMPTZ,
+    last_sync_status  VARCHAR(16)  NOT NULL DEFAULT 'never',
+    last_sync_error   TEXT,
+    created_at        TIMESTAMPTZ,
+    updated_at        TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_thehive_connections_tenant  ON thehive_connections (tenant_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_thehive_connections_webhook ON thehive_connections (webhook_token);
+
+CREATE TABLE IF NOT EXISTS thehive_case_links (
+    id                 UUID PRIMARY KEY,
+    tenant_id          UUID        NOT NULL,
+    connection_id      UUID        NOT NULL,
+    incident_id        BIGINT      NOT NULL,
+    case_id            VARCHAR(64) NOT NULL,
+    case_number        BIGINT,
+    local_fingerprint  VARCHAR(64),
+    remote_fingerprint VARCHAR(64),
+    remote_updated_at  TIMESTAMPTZ,
+    last_synced_at     TIMESTAMPTZ,
+    last_direction     VARCHAR(8),
+    last_error         TEXT,
+    created_at         TIMESTAMPTZ,
+    updated_at         TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_thehive_case_links_connection_id ON thehive_case_links (connection_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_thehive_case_links_incident ON thehive_case_links (tenant_id, incident_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_thehive_case_links_case     ON thehive_case_links (tenant_id, case_id);
+
+CREATE TABLE IF NOT EXISTS thehive_task_links (
+    id                 UUID PRIMARY KEY,
+    tenant_id          UUID        NOT NULL,
+    case_link_id       UUID        NOT NULL,
+    action_id          BIGINT      NOT NULL,
+    task_id            VARCHAR(64) NOT NULL,
+    local_fingerprint  VARCHAR(64),
+    remote_fingerprint VARCHAR(64),
+    created_at         TIMESTAMPTZ,
+    updated_at         TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_thehive_task_links_case_link_id ON thehive_task_links (case_link_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_thehive_task_links_action ON thehive_task_links (tenant_id, action_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_thehive_task_links_task   ON thehive_task_links (tenant_id, task_id);
+
+CREATE TABLE IF NOT EXISTS thehive_observables (
+    id            UUID PRIMARY KEY,
+    tenant_id     UUID        NOT NULL,
+    case_link_id  UUID        NOT NULL,
+    observable_id VARCHAR(64) NOT NULL,
+    data_type     VARCHAR(32) NOT NULL,
+    data          TEXT,
+    asset_id      UUID,
+    confidence    DOUBLE PRECISION,
+    match_reason  VARCHAR(255),
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_thehive_observables_case_link_id ON thehive_observables (case_link_id);
+CREATE INDEX IF NOT EXISTS idx_thehive_observables_asset_id     ON thehive_observables (asset_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_thehive_observables_remote ON thehive_observables (tenant_id, observable_id);
+
+COMMIT;
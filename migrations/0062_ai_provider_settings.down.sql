@@ -0,0 +1,7 @@
+-- Reverses 0062. Organisations fall back to the server-wide AI provider.
+
+BEGIN;
+
+DROP TABLE IF EXISTS ai_provider_settings;
+
+COMMIT;
@@ -0,0 +1,29 @@
+-- Per-organisation LLM provider for the AI assistant and the board report.
+--
+-- One row per tenant. Without a row the organisation uses the server default
+-- (AI_PROVIDER / ANTHROPIC_API_KEY). provider is template | anthropic |
+-- openai_compatible; the latter points at a self-hosted vLLM, llama.cpp or
+-- Ollama endpoint for organisations that may not send risk data to an
+-- external API.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS ai_provider_settings (
+    id                UUID PRIMARY KEY,
+    tenant_id         UUID         NOT NULL,
+    provider          VARCHAR(32)  NOT NULL,
+    base_url          VARCHAR(512),
+    model             VARCHAR(128),
+    encrypted_api_key TEXT,
+    timeout_seconds   INTEGER      NOT NULL DEFAULT 0,
+    max_tokens        INTEGER      NOT NULL DEFAULT 0,
+    output_mode       VARCHAR(16),
+    updated_by        UUID,
+    created_at        TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_ai_provider_settings_tenant
+    ON ai_provider_settings (tenant_id);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Reverses 0063. Downtime values already fed from a BIA stay on the risks,
+-- indistinguishable from hand-entered ones.
+
+BEGIN;
+
+ALTER TABLE risks DROP COLUMN IF EXISTS downtime_hours_source;
+DROP TABLE IF EXISTS recovery_capabilities;
+DROP TABLE IF EXISTS business_impact_analyses;
+
+COMMIT;
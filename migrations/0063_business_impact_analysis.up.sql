@@ -0,0 +1,53 @@
+-- Business impact analysis (BIA) on business processes.
+--
+-- business_impact_analyses holds one row per business_process asset: its
+-- maximum tolerable period of disruption, recovery time and recovery point
+-- objectives, and the impact-over-time curve. recovery_capabilities holds what
+-- each supporting asset can actually deliver (recovery time / point
+-- capability), so objectives propagated down the dependency graph can be
+-- checked against it.
+--
+-- risks.downtime_hours_source records who set downtime_hours: '' for a value
+-- entered by hand, 'bia' for one fed from the process's BIA. Only the latter
+-- is ever overwritten by the feed.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS business_impact_analyses (
+    id           UUID PRIMARY KEY,
+    tenant_id    UUID           NOT NULL,
+    asset_id     UUID           NOT NULL,
+    mtpd_hours   NUMERIC(10,2)  NOT NULL,
+    rto_hours    NUMERIC(10,2)  NOT NULL,
+    rpo_hours    NUMERIC(10,2)  NOT NULL,
+    impact_curve JSONB,
+    owner        VARCHAR(255),
+    notes        TEXT,
+    updated_by   UUID,
+    created_at   TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_bia_tenant_asset
+    ON business_impact_analyses (tenant_id, asset_id);
+
+CREATE TABLE IF NOT EXISTS recovery_capabilities (
+    id             UUID PRIMARY KEY,
+    tenant_id      UUID           NOT NULL,
+    asset_id       UUID           NOT NULL,
+    rtc_hours      NUMERIC(10,2),
+    rpc_hours      NUMERIC(10,2),
+    basis          VARCHAR(16)    NOT NULL,
+    last_tested_at TIMESTAMPTZ,
+    notes          TEXT,
+    updated_by     UUID,
+    created_at     TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_recovery_capabilities_tenant_asset
+    ON recovery_capabilities (tenant_id, asset_id);
+
+ALTER TABLE risks ADD COLUMN IF NOT EXISTS downtime_hours_source VARCHAR(8) NOT NULL DEFAULT '';
+
+COMMIT;
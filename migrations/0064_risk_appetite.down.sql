@@ -0,0 +1,10 @@
+-- Reverses 0064. Appetite exceptions stay in approval_requests as history.
+
+BEGIN;
+
+ALTER TABLE board_reports DROP COLUMN IF EXISTS appetite_snapshot;
+DROP INDEX IF EXISTS idx_risks_business_unit;
+ALTER TABLE risks DROP COLUMN IF EXISTS business_unit;
+DROP TABLE IF EXISTS risk_appetite_statements;
+
+COMMIT;
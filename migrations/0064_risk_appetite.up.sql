@@ -0,0 +1,46 @@
+-- Risk appetite and tolerance.
+--
+-- risk_appetite_statements holds the board's appetite statements, one per
+-- risk category or business unit: the wording as approved, the tolerances
+-- that make it checkable (score, SmartScore, ALE P90 of the scope, number of
+-- critical risks), and the board approval (date + reference). A statement
+-- without approved_on is a draft and is never enforced.
+--
+-- risks.business_unit is the owning part of the organisation, matched
+-- case-insensitively by business-unit statements. board_reports gains the
+-- appetite evaluation frozen at generation time.
+--
+-- Exceptions need no table: they are approval requests of entity_type
+-- 'risk_appetite_exception' against the risk id.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS risk_appetite_statements (
+    id                 UUID PRIMARY KEY,
+    tenant_id          UUID           NOT NULL,
+    scope              VARCHAR(16)    NOT NULL,
+    category_id        UUID,
+    business_unit      VARCHAR(128),
+    statement          TEXT           NOT NULL,
+    max_score          NUMERIC(8,3),
+    max_smart_score    NUMERIC(5,2),
+    max_ale_p90_xaf    NUMERIC(18,2),
+    max_critical_count INTEGER,
+    approved_on        TIMESTAMPTZ,
+    approval_reference VARCHAR(255),
+    updated_by         UUID,
+    created_at         TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_risk_appetite_statements_tenant_id
+    ON risk_appetite_statements (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_risk_appetite_statements_category_id
+    ON risk_appetite_statements (category_id);
+
+ALTER TABLE risks ADD COLUMN IF NOT EXISTS business_unit VARCHAR(128);
+CREATE INDEX IF NOT EXISTS idx_risks_business_unit ON risks (business_unit);
+
+ALTER TABLE board_reports ADD COLUMN IF NOT EXISTS appetite_snapshot JSONB;
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Reverses 0065. Risks raised by a KRI keep their raised probability.
+
+BEGIN;
+
+ALTER TABLE board_reports DROP COLUMN IF EXISTS kri_snapshot;
+DROP TABLE IF EXISTS kri_values;
+DROP TABLE IF EXISTS key_risk_indicators;
+
+COMMIT;
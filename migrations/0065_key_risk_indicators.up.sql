@@ -0,0 +1,65 @@
+-- Key risk indicators.
+--
+-- key_risk_indicators holds each indicator's definition: where its values come
+-- from (a whitelisted query over the tenant's register, an API push, or a CSV
+-- upload), the green/amber/red thresholds and which way is worse, the risks it
+-- is an early warning for, and whether crossing into red raises their
+-- probability. The current value and band are denormalised from the latest
+-- measurement so the register and the board pack read them without a scan.
+--
+-- kri_values is the history: every value ever recorded, with the band it fell
+-- in at the time. Backfilled values land here without moving the current band.
+--
+-- board_reports gains the KRI trends frozen at generation time.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS key_risk_indicators (
+    id               UUID PRIMARY KEY,
+    tenant_id        UUID           NOT NULL,
+    name             VARCHAR(160)   NOT NULL,
+    description      TEXT,
+    unit             VARCHAR(32),
+    source           VARCHAR(16)    NOT NULL,
+    query            JSONB,
+    refresh_minutes  INTEGER        NOT NULL DEFAULT 60,
+    direction        VARCHAR(20)    NOT NULL,
+    amber_threshold  NUMERIC(18,4),
+    red_threshold    NUMERIC(18,4),
+    risk_ids         JSONB,
+    bump_probability BOOLEAN        NOT NULL DEFAULT FALSE,
+    probability_step NUMERIC(4,3)   NOT NULL DEFAULT 0.1,
+    status           VARCHAR(12)    NOT NULL DEFAULT 'unknown',
+    last_value       NUMERIC(18,4),
+    last_measured_at TIMESTAMPTZ,
+    last_error       TEXT,
+    created_by       UUID,
+    created_at       TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_key_risk_indicators_tenant_id
+    ON key_risk_indicators (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_key_risk_indicators_source
+    ON key_risk_indicators (source);
+CREATE INDEX IF NOT EXISTS idx_key_risk_indicators_status
+    ON key_risk_indicators (status);
+
+CREATE TABLE IF NOT EXISTS kri_values (
+    id          UUID PRIMARY KEY,
+    tenant_id   UUID           NOT NULL,
+    kri_id      UUID           NOT NULL REFERENCES key_risk_indicators (id) ON DELETE CASCADE,
+    value       NUMERIC(18,4)  NOT NULL,
+    status      VARCHAR(12),
+    source      VARCHAR(16),
+    measured_at TIMESTAMPTZ    NOT NULL,
+    recorded_by UUID,
+    created_at  TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kri_values_tenant_id ON kri_values (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_kri_values_kri_measured ON kri_values (kri_id, measured_at);
+
+ALTER TABLE board_reports ADD COLUMN IF NOT EXISTS kri_snapshot JSONB;
+
+COMMIT;
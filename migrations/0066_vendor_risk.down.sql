@@ -0,0 +1,12 @@
+-- Reverses 0066. Draft vendor risks stay in the register, without the link to
+-- the vendor they were proposed from; submitted evidence stays in the library.
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_risks_source_vendor_id;
+ALTER TABLE risks DROP COLUMN IF EXISTS source_vendor_id;
+DROP TABLE IF EXISTS vendor_assessments;
+DROP TABLE IF EXISTS vendor_questionnaire_templates;
+DROP TABLE IF EXISTS vendors;
+
+COMMIT;
@@ -0,0 +1,95 @@
+-- Vendor risk.
+--
+-- vendors is the third-party register: what each supplier does, what data it
+-- touches and how critical it is, from which its tier (1 = closest scrutiny)
+-- is derived and stored so the register sorts and filters on it. The last
+-- assessment's score and date are denormalised here, and next_assessment_at is
+-- the tier-driven date the reassessment worker sweeps on.
+--
+-- vendor_questionnaire_templates holds the tenant's custom questionnaires; the
+-- built-in SIG-lite and CAIQ-lite live in code and are never stored.
+--
+-- vendor_assessments is one questionnaire sent to one vendor. The questions
+-- and pass mark are a snapshot taken at send time, so editing a template never
+-- rescores an answer. Only the SHA-256 of the link token is stored.
+--
+-- risks gains source_vendor_id, the vendor a draft vendor risk was proposed
+-- from, which keeps that proposal idempotent per vendor.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS vendors (
+    id                 UUID PRIMARY KEY,
+    tenant_id          UUID          NOT NULL,
+    name               VARCHAR(200)  NOT NULL,
+    website            VARCHAR(255),
+    services           TEXT,
+    contact_name       VARCHAR(160),
+    contact_email      VARCHAR(255),
+    data_access        VARCHAR(16)   NOT NULL,
+    criticality        VARCHAR(16)   NOT NULL,
+    tier               INTEGER       NOT NULL,
+    status             VARCHAR(16)   DEFAULT 'active',
+    asset_id           UUID,
+    owner_id           UUID,
+    last_score         NUMERIC(5,2),
+    last_assessed_at   TIMESTAMPTZ,
+    next_assessment_at TIMESTAMPTZ,
+    created_by         UUID,
+    created_at         TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_vendors_tenant_id ON vendors (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_vendors_tier ON vendors (tier);
+CREATE INDEX IF NOT EXISTS idx_vendors_status ON vendors (status);
+CREATE INDEX IF NOT EXISTS idx_vendors_next_assessment_at ON vendors (next_assessment_at);
+
+CREATE TABLE IF NOT EXISTS vendor_questionnaire_templates (
+    id          UUID PRIMARY KEY,
+    tenant_id   UUID          NOT NULL,
+    kind        VARCHAR(16)   NOT NULL,
+    name        VARCHAR(160)  NOT NULL,
+    description TEXT,
+    questions   JSONB,
+    pass_score  NUMERIC(5,2),
+    created_by  UUID,
+    created_at  TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_vendor_questionnaire_templates_tenant_id
+    ON vendor_questionnaire_templates (tenant_id);
+
+CREATE TABLE IF NOT EXISTS vendor_assessments (
+    id                   UUID PRIMARY KEY,
+    tenant_id            UUID          NOT NULL,
+    vendor_id            UUID          NOT NULL REFERENCES vendors (id) ON DELETE CASCADE,
+    template_id          UUID,
+    template_kind        VARCHAR(16),
+    template_name        VARCHAR(160),
+    questions            JSONB,
+    pass_score           NUMERIC(5,2),
+    token_hash           VARCHAR(64)   NOT NULL,
+    sent_to              VARCHAR(255),
+    expires_at           TIMESTAMPTZ   NOT NULL,
+    status               VARCHAR(16)   NOT NULL,
+    answers              JSONB,
+    score                NUMERIC(5,2),
+    submitted_at         TIMESTAMPTZ,
+    response_evidence_id UUID,
+    proposed_risk_id     UUID,
+    sent_by              UUID,
+    created_at           TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_vendor_assessments_tenant_id ON vendor_assessments (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_vendor_assessments_vendor_id ON vendor_assessments (vendor_id);
+CREATE INDEX IF NOT EXISTS idx_vendor_assessments_status ON vendor_assessments (status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vendor_assessments_token_hash ON vendor_assessments (token_hash);
+
+ALTER TABLE risks ADD COLUMN IF NOT EXISTS source_vendor_id UUID;
+CREATE INDEX IF NOT EXISTS idx_risks_source_vendor_id ON risks (source_vendor_id);
+
+COMMIT;
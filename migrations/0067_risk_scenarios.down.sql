@@ -0,0 +1,11 @@
+-- Reverses 0067. Risks drafted from a scenario stay in the register, without
+-- the link to the template they came from; their control mappings stay too.
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_risks_source_scenario_id;
+ALTER TABLE risks DROP COLUMN IF EXISTS source_scenario_version;
+ALTER TABLE risks DROP COLUMN IF EXISTS source_scenario_id;
+DROP TABLE IF EXISTS risk_scenarios;
+
+COMMIT;
@@ -0,0 +1,42 @@
+-- Risk scenarios.
+--
+-- risk_scenarios holds a tenant's own scenario templates: threat actors,
+-- ATT&CK techniques with the catalog controls that mitigate each, the asset
+-- categories the scenario applies to, and FAIR ranges (loss event frequency
+-- and loss magnitude as min / mode / max). version counts content changes. The
+-- built-in library lives in code and is never stored. A key is unique per
+-- tenant; built-in keys are reserved by the application.
+--
+-- risks gains source_scenario_id and source_scenario_version, the template a
+-- draft risk was instantiated from and the version it had, which keeps
+-- instantiation idempotent per (scenario, asset).
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS risk_scenarios (
+    id                 UUID PRIMARY KEY,
+    tenant_id          UUID          NOT NULL,
+    key                VARCHAR(64)   NOT NULL,
+    version            INTEGER       NOT NULL DEFAULT 1,
+    title              VARCHAR(200)  NOT NULL,
+    description        TEXT,
+    threat_actors      JSONB,
+    techniques         JSONB,
+    asset_categories   TEXT[],
+    lef                JSONB,
+    loss_magnitude     JSONB,
+    impact             NUMERIC(4,2)  NOT NULL,
+    suggested_controls JSONB,
+    created_by         UUID,
+    created_at         TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_risk_scenarios_tenant_id ON risk_scenarios (tenant_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_risk_scenarios_tenant_key ON risk_scenarios (tenant_id, key);
+
+ALTER TABLE risks ADD COLUMN IF NOT EXISTS source_scenario_id UUID;
+ALTER TABLE risks ADD COLUMN IF NOT EXISTS source_scenario_version INTEGER;
+CREATE INDEX IF NOT EXISTS idx_risks_source_scenario_id ON risks (source_scenario_id);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Reverses 0068. Derived residual figures stay on the risks as if they had
+-- been typed; the test history is lost.
+
+BEGIN;
+
+ALTER TABLE risks DROP COLUMN IF EXISTS residual_source;
+DROP TABLE IF EXISTS control_tests;
+DROP TABLE IF EXISTS control_test_plans;
+
+COMMIT;
@@ -0,0 +1,62 @@
+-- Control effectiveness testing.
+--
+-- control_test_plans says how a compliance control is tested: design or
+-- operating effectiveness (one plan per control and kind), the procedure, the
+-- sample size, the cadence, the tester and the next test date. The latest
+-- result and effectiveness are denormalised onto the plan.
+--
+-- control_tests keeps every execution: samples tested, exceptions, result and
+-- the effectiveness score it earned at the time.
+--
+-- risks gains residual_source: '' when residual_risk and
+-- mitigation_effectiveness were typed, 'controls' when they were derived from
+-- the tested effectiveness of the risk's mapped controls.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS control_test_plans (
+    id                 UUID PRIMARY KEY,
+    tenant_id          UUID          NOT NULL,
+    control_id         UUID          NOT NULL REFERENCES compliance_controls (id) ON DELETE CASCADE,
+    kind               VARCHAR(16)   NOT NULL,
+    procedure          TEXT,
+    sample_size        INTEGER       NOT NULL DEFAULT 1,
+    frequency_days     INTEGER       NOT NULL,
+    tester_id          UUID,
+    next_test_at       TIMESTAMPTZ   NOT NULL,
+    last_tested_at     TIMESTAMPTZ,
+    last_result        VARCHAR(16)   DEFAULT '',
+    last_effectiveness NUMERIC(5,4),
+    created_by         UUID,
+    created_at         TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_control_test_plans_tenant_id ON control_test_plans (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_control_test_plans_control_id ON control_test_plans (control_id);
+CREATE INDEX IF NOT EXISTS idx_control_test_plans_next_test_at ON control_test_plans (next_test_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_control_test_plans_control_kind ON control_test_plans (tenant_id, control_id, kind);
+
+CREATE TABLE IF NOT EXISTS control_tests (
+    id             UUID PRIMARY KEY,
+    tenant_id      UUID          NOT NULL,
+    plan_id        UUID          NOT NULL REFERENCES control_test_plans (id) ON DELETE CASCADE,
+    control_id     UUID          NOT NULL,
+    kind           VARCHAR(16)   NOT NULL,
+    tester_id      UUID,
+    performed_at   TIMESTAMPTZ   NOT NULL,
+    samples_tested INTEGER       NOT NULL,
+    exceptions     INTEGER       NOT NULL DEFAULT 0,
+    result         VARCHAR(16)   NOT NULL,
+    effectiveness  NUMERIC(5,4)  NOT NULL,
+    notes          TEXT,
+    created_at     TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_control_tests_tenant_id ON control_tests (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_control_tests_plan_id ON control_tests (plan_id);
+CREATE INDEX IF NOT EXISTS idx_control_tests_control_id ON control_tests (control_id);
+
+ALTER TABLE risks ADD COLUMN IF NOT EXISTS residual_source VARCHAR(16) DEFAULT '';
+
+COMMIT;
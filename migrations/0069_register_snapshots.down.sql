@@ -0,0 +1,9 @@
+-- Reverses 0069. Every snapshot is lost; as_of reads then answer 404.
+
+BEGIN;
+
+ALTER TABLE board_reports DROP COLUMN IF EXISTS register_changes;
+DROP TABLE IF EXISTS register_snapshot_risks;
+DROP TABLE IF EXISTS register_snapshots;
+
+COMMIT;
@@ -0,0 +1,64 @@
+-- Immutable register snapshots.
+--
+-- register_snapshots is one frozen register: taken at every close of day
+-- (kind 'scheduled', as_of at UTC midnight) or on demand ('manual'), with the
+-- headline figures computed once at capture. Rows are only ever inserted.
+--
+-- register_snapshot_risks is each risk as it stood: score, states, owner, ALE
+-- and the control mappings as labels, so a mapping deleted later still reads.
+--
+-- board_reports gains register_changes: what moved in the register over the
+-- month before generation (new, closed, up- and down-scored risks).
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS register_snapshots (
+    id            UUID PRIMARY KEY,
+    tenant_id     UUID             NOT NULL,
+    kind          VARCHAR(16)      NOT NULL,
+    label         VARCHAR(255)     NOT NULL DEFAULT '',
+    as_of         TIMESTAMPTZ      NOT NULL,
+    taken_at      TIMESTAMPTZ      NOT NULL,
+    risk_count    INTEGER          NOT NULL DEFAULT 0,
+    open_count    INTEGER          NOT NULL DEFAULT 0,
+    critical      INTEGER          NOT NULL DEFAULT 0,
+    high          INTEGER          NOT NULL DEFAULT 0,
+    medium        INTEGER          NOT NULL DEFAULT 0,
+    low           INTEGER          NOT NULL DEFAULT 0,
+    average_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    total_ale_xaf DOUBLE PRECISION NOT NULL DEFAULT 0,
+    taken_by      UUID,
+    created_at    TIMESTAMPTZ      NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_register_snapshots_tenant_as_of ON register_snapshots (tenant_id, as_of);
+
+CREATE TABLE IF NOT EXISTS register_snapshot_risks (
+    snapshot_id     UUID             NOT NULL REFERENCES register_snapshots (id) ON DELETE CASCADE,
+    risk_id         UUID             NOT NULL,
+    tenant_id       UUID             NOT NULL,
+    title           VARCHAR(255)     NOT NULL,
+    status          VARCHAR(20),
+    lifecycle_state VARCHAR(24),
+    criticality     VARCHAR(20),
+    score           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    probability     DOUBLE PRECISION NOT NULL DEFAULT 0,
+    impact          DOUBLE PRECISION NOT NULL DEFAULT 0,
+    residual_risk   DOUBLE PRECISION,
+    owner_id        UUID,
+    business_unit   VARCHAR(128),
+    category_id     UUID,
+    ale_xaf         DOUBLE PRECISION NOT NULL DEFAULT 0,
+    tags            TEXT[]           DEFAULT '{}',
+    controls        TEXT[]           DEFAULT '{}',
+    assets          TEXT[]           DEFAULT '{}',
+    risk_created_at TIMESTAMPTZ,
+    risk_updated_at TIMESTAMPTZ,
+    PRIMARY KEY (snapshot_id, risk_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_register_snapshot_risks_tenant_id ON register_snapshot_risks (tenant_id);
+
+ALTER TABLE board_reports ADD COLUMN IF NOT EXISTS register_changes JSONB;
+
+COMMIT;
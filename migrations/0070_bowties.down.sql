@@ -0,0 +1,8 @@
+-- Reverses 0070. Every bowtie is lost; the risks' figures keep any raise
+-- already applied.
+
+BEGIN;
+
+DROP TABLE IF EXISTS bowties;
+
+COMMIT;
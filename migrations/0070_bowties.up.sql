@@ -0,0 +1,34 @@
+-- Bowtie analyses.
+--
+-- One bowtie per risk: the hazard and top event, the threats and
+-- consequences either side, and the barriers crossing them, each optionally
+-- linked to a compliance control and/or one of the risk's mitigations. The
+-- three lists are jsonb: a bowtie is drawn and edited as a whole. Barrier
+-- health is derived on read and never stored.
+--
+-- applied_preventive_loss and applied_recovery_loss remember how much barrier
+-- loss the risk's probability and impact have already been raised for, so the
+-- same degraded barriers do not raise the risk twice.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS bowties (
+    id                      UUID PRIMARY KEY,
+    tenant_id               UUID          NOT NULL,
+    risk_id                 UUID          NOT NULL REFERENCES risks (id) ON DELETE CASCADE,
+    hazard                  VARCHAR(255)  NOT NULL DEFAULT '',
+    top_event               VARCHAR(255)  NOT NULL,
+    threats                 JSONB         NOT NULL DEFAULT '[]',
+    consequences            JSONB         NOT NULL DEFAULT '[]',
+    barriers                JSONB         NOT NULL DEFAULT '[]',
+    applied_preventive_loss NUMERIC(5,4)  NOT NULL DEFAULT 0,
+    applied_recovery_loss   NUMERIC(5,4)  NOT NULL DEFAULT 0,
+    updated_by              UUID,
+    created_at              TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bowties_risk_id ON bowties (risk_id);
+CREATE INDEX IF NOT EXISTS idx_bowties_tenant_id ON bowties (tenant_id);
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Reverses 0071. Every link is lost, and with it every holding's view of its
+-- subsidiaries; the organizations themselves are untouched.
+
+BEGIN;
+
+DROP TABLE IF EXISTS organization_links;
+
+COMMIT;
@@ -0,0 +1,36 @@
+-- Organization hierarchy.
+--
+-- A link makes child_id a subsidiary of parent_id. The parent asks (pending),
+-- the child's administrator accepts (active), either side revokes (revoked).
+-- Only active links let the parent's "group:read" holders read the child's
+-- register, and only in aggregate and read-only.
+--
+-- An organization has at most one live parent: the partial unique index keeps
+-- the hierarchy a tree, so the roll-up never counts a subsidiary twice. Cycles
+-- are refused by the application when a link is requested and again when it is
+-- accepted. Revoked links are kept for the record.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS organization_links (
+    id           UUID PRIMARY KEY,
+    parent_id    UUID        NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    child_id     UUID        NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    status       VARCHAR(16) NOT NULL DEFAULT 'pending'
+                 CHECK (status IN ('pending', 'active', 'revoked')),
+    requested_by UUID,
+    accepted_by  UUID,
+    accepted_at  TIMESTAMPTZ,
+    revoked_by   UUID,
+    revoked_at   TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (parent_id <> child_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_links_parent ON organization_links (parent_id, status);
+CREATE INDEX IF NOT EXISTS idx_organization_links_child ON organization_links (child_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_organization_links_live_parent
+    ON organization_links (child_id) WHERE status IN ('pending', 'active');
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- Reverses 0072. Programmes, their links and work breakdown are lost; the
+-- risks and controls they treated are untouched.
+
+BEGIN;
+
+DROP TABLE IF EXISTS assignee_capacities;
+DROP TABLE IF EXISTS programme_action_dependencies;
+DROP TABLE IF EXISTS programme_actions;
+DROP TABLE IF EXISTS mitigation_programme_controls;
+DROP TABLE IF EXISTS mitigation_programme_risks;
+DROP TABLE IF EXISTS mitigation_programmes;
+
+COMMIT;
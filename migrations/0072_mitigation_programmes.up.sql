@@ -0,0 +1,102 @@
+-- Mitigation programmes.
+--
+-- mitigation_programmes is one mitigation project shared by many risks and
+-- controls ("deploy EDR fleet-wide"), with a start and a target date.
+--
+-- mitigation_programme_risks links it to the risks it treats, each with the
+-- share of the risk it is expected to remove once delivered;
+-- mitigation_programme_controls to the controls it implements.
+--
+-- programme_actions is its work breakdown: effort in person-days, assignee,
+-- completion. programme_action_dependencies holds the finish-to-start edges
+-- between actions of the same programme.
+--
+-- assignee_capacities is the share of a person's week available to programme
+-- work (a person without a row is full time). The schedule, critical path and
+-- projected residuals are computed on read and never stored.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS mitigation_programmes (
+    id          UUID PRIMARY KEY,
+    tenant_id   UUID          NOT NULL,
+    title       VARCHAR(255)  NOT NULL,
+    description TEXT,
+    status      VARCHAR(20)   NOT NULL DEFAULT 'PLANNED',
+    owner_id    UUID,
+    start_date  TIMESTAMPTZ   NOT NULL,
+    target_date TIMESTAMPTZ,
+    created_by  UUID,
+    created_at  TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_mitigation_programmes_dates CHECK (target_date IS NULL OR target_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_mitigation_programmes_tenant_id ON mitigation_programmes (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_mitigation_programmes_owner_id ON mitigation_programmes (owner_id);
+
+CREATE TABLE IF NOT EXISTS mitigation_programme_risks (
+    tenant_id          UUID          NOT NULL,
+    programme_id       UUID          NOT NULL REFERENCES mitigation_programmes (id) ON DELETE CASCADE,
+    risk_id            UUID          NOT NULL REFERENCES risks (id) ON DELETE CASCADE,
+    expected_reduction NUMERIC(5,4)  NOT NULL,
+    created_at         TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (programme_id, risk_id),
+    CONSTRAINT chk_mitigation_programme_risks_reduction CHECK (expected_reduction >= 0 AND expected_reduction <= 0.9)
+);
+
+CREATE INDEX IF NOT EXISTS idx_mitigation_programme_risks_tenant_id ON mitigation_programme_risks (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_mitigation_programme_risks_risk_id ON mitigation_programme_risks (risk_id);
+
+CREATE TABLE IF NOT EXISTS mitigation_programme_controls (
+    tenant_id    UUID          NOT NULL,
+    programme_id UUID          NOT NULL REFERENCES mitigation_programmes (id) ON DELETE CASCADE,
+    control_id   UUID          NOT NULL REFERENCES compliance_controls (id) ON DELETE CASCADE,
+    created_at   TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (programme_id, control_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_mitigation_programme_controls_tenant_id ON mitigation_programme_controls (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_mitigation_programme_controls_control_id ON mitigation_programme_controls (control_id);
+
+CREATE TABLE IF NOT EXISTS programme_actions (
+    id           UUID PRIMARY KEY,
+    tenant_id    UUID          NOT NULL,
+    programme_id UUID          NOT NULL REFERENCES mitigation_programmes (id) ON DELETE CASCADE,
+    title        VARCHAR(255)  NOT NULL,
+    effort_days  NUMERIC(8,2)  NOT NULL DEFAULT 0,
+    assignee_id  UUID,
+    "order"      INTEGER       NOT NULL DEFAULT 0,
+    completed    BOOLEAN       NOT NULL DEFAULT FALSE,
+    completed_at TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_programme_actions_effort CHECK (effort_days >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_programme_actions_tenant_id ON programme_actions (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_programme_actions_programme_id ON programme_actions (programme_id);
+CREATE INDEX IF NOT EXISTS idx_programme_actions_assignee_id ON programme_actions (assignee_id);
+
+CREATE TABLE IF NOT EXISTS programme_action_dependencies (
+    tenant_id     UUID  NOT NULL,
+    programme_id  UUID  NOT NULL REFERENCES mitigation_programmes (id) ON DELETE CASCADE,
+    action_id     UUID  NOT NULL REFERENCES programme_actions (id) ON DELETE CASCADE,
+    depends_on_id UUID  NOT NULL REFERENCES programme_actions (id) ON DELETE CASCADE,
+    PRIMARY KEY (action_id, depends_on_id),
+    CONSTRAINT chk_programme_action_dependencies_self CHECK (action_id <> depends_on_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_programme_action_dependencies_tenant_id ON programme_action_dependencies (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_programme_action_dependencies_programme_id ON programme_action_dependencies (programme_id);
+
+CREATE TABLE IF NOT EXISTS assignee_capacities (
+    tenant_id  UUID          NOT NULL,
+    user_id    UUID          NOT NULL,
+    fte        NUMERIC(4,2)  NOT NULL,
+    updated_at TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, user_id),
+    CONSTRAINT chk_assignee_capacities_fte CHECK (fte > 0 AND fte <= 1)
+);
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- Reverses 0073. Import history and saved mappings are lost, as are the
+-- external IDs of imported controls and mitigations; the records themselves
+-- are untouched.
+
+BEGIN;
+
+DROP TABLE IF EXISTS import_mapping_profiles;
+DROP TABLE IF EXISTS import_batch_records;
+DROP TABLE IF EXISTS import_batch_rows;
+DROP TABLE IF EXISTS import_batches;
+
+DROP INDEX IF EXISTS idx_mitigations_external_id;
+ALTER TABLE mitigations DROP COLUMN IF EXISTS external_id;
+DROP INDEX IF EXISTS idx_compliance_controls_external_id;
+ALTER TABLE compliance_controls DROP COLUMN IF EXISTS external_id;
+
+COMMIT;
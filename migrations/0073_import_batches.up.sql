@@ -0,0 +1,90 @@
+-- Import wizard.
+--
+-- import_batches is one uploaded file of risks, assets, controls or
+-- mitigations from another tool: its detected columns, the mapping chosen for
+-- them, and — once applied — how many records it created, updated and skipped.
+-- import_batch_rows holds the parsed cells of each row until the batch is
+-- applied or discarded.
+--
+-- import_batch_records is what an applied batch wrote, one row per entity:
+-- whether it was created or updated, the columns it overwrote as they were
+-- before (previous), and the entity's updated_at right after the write, which
+-- is how a rollback notices that someone edited the record since.
+--
+-- import_mapping_profiles are saved column mappings, one name per tenant and
+-- entity, to re-import the same tool's export without mapping it again.
+--
+-- compliance_controls and mitigations gain external_id, the upsert key of a
+-- re-import (risks and assets already had one).
+
+BEGIN;
+
+ALTER TABLE compliance_controls ADD COLUMN IF NOT EXISTS external_id VARCHAR(255) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_compliance_controls_external_id ON compliance_controls (tenant_id, external_id);
+
+ALTER TABLE mitigations ADD COLUMN IF NOT EXISTS external_id VARCHAR(255) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_mitigations_external_id ON mitigations (tenant_id, external_id);
+
+CREATE TABLE IF NOT EXISTS import_batches (
+    id             UUID PRIMARY KEY,
+    tenant_id      UUID          NOT NULL,
+    entity         VARCHAR(16)   NOT NULL,
+    status         VARCHAR(16)   NOT NULL DEFAULT 'staged',
+    file_name      VARCHAR(255)  NOT NULL DEFAULT '',
+    format         VARCHAR(8)    NOT NULL,
+    columns        JSONB,
+    mapping        JSONB,
+    row_count      INTEGER       NOT NULL DEFAULT 0,
+    created        INTEGER       NOT NULL DEFAULT 0,
+    updated        INTEGER       NOT NULL DEFAULT 0,
+    skipped        INTEGER       NOT NULL DEFAULT 0,
+    created_by     UUID,
+    applied_at     TIMESTAMPTZ,
+    rolled_back_at TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_import_batches_entity CHECK (entity IN ('risk', 'asset', 'control', 'mitigation')),
+    CONSTRAINT chk_import_batches_status CHECK (status IN ('staged', 'applied', 'rolled_back'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_batches_tenant_id ON import_batches (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_import_batches_status ON import_batches (status);
+
+CREATE TABLE IF NOT EXISTS import_batch_rows (
+    batch_id  UUID     NOT NULL REFERENCES import_batches (id) ON DELETE CASCADE,
+    row_no    INTEGER  NOT NULL,
+    tenant_id UUID     NOT NULL,
+    "values"  JSONB,
+    PRIMARY KEY (batch_id, row_no)
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_batch_rows_tenant_id ON import_batch_rows (tenant_id);
+
+CREATE TABLE IF NOT EXISTS import_batch_records (
+    batch_id   UUID         NOT NULL REFERENCES import_batches (id) ON DELETE CASCADE,
+    entity_id  UUID         NOT NULL,
+    tenant_id  UUID         NOT NULL,
+    row_no     INTEGER      NOT NULL,
+    action     VARCHAR(8)   NOT NULL,
+    previous   JSONB,
+    written_at TIMESTAMPTZ  NOT NULL,
+    PRIMARY KEY (batch_id, entity_id),
+    CONSTRAINT chk_import_batch_records_action CHECK (action IN ('created', 'updated'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_batch_records_tenant_id ON import_batch_records (tenant_id);
+
+CREATE TABLE IF NOT EXISTS import_mapping_profiles (
+    id         UUID PRIMARY KEY,
+    tenant_id  UUID          NOT NULL,
+    entity     VARCHAR(16)   NOT NULL,
+    name       VARCHAR(120)  NOT NULL,
+    mapping    JSONB,
+    created_by UUID,
+    created_at TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_import_profiles_tenant_name ON import_mapping_profiles (tenant_id, entity, name);
+
+COMMIT;
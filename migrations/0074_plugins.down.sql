@@ -0,0 +1,10 @@
+-- Reverses 0074. Uploaded plugins, their installs, granted secrets and run
+-- history are lost; findings and assets they produced are untouched.
+
+BEGIN;
+
+DROP TABLE IF EXISTS plugin_runs;
+DROP TABLE IF EXISTS plugin_installs;
+DROP TABLE IF EXISTS plugin_packages;
+
+COMMIT;
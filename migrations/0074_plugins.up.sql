@@ -0,0 +1,83 @@
+-- Connector plugins.
+--
+-- plugin_packages are signed WebAssembly bundles a tenant uploaded: the
+-- manifest's name, version, publisher, hooks and what it asks for (hosts and
+-- secret names), the key it was signed with, and the module itself. A name and
+-- version is uploaded once per tenant; a fix is a new version.
+--
+-- plugin_installs enable a package with its grants: the hosts it may reach and
+-- the secrets it may read (names in clear, values encrypted with
+-- SCANNER_CREDENTIAL_KEY), its memory and time limits, its configuration, and
+-- the token its transform_webhook push is authenticated by.
+--
+-- plugin_runs is the log of hook invocations, successful or not.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS plugin_packages (
+    id                UUID PRIMARY KEY,
+    tenant_id         UUID          NOT NULL,
+    name              VARCHAR(120)  NOT NULL,
+    version           VARCHAR(40)   NOT NULL,
+    publisher         VARCHAR(120)  NOT NULL,
+    description       TEXT          NOT NULL DEFAULT '',
+    abi_version       INTEGER       NOT NULL,
+    hooks             JSONB,
+    requested_hosts   JSONB,
+    requested_secrets JSONB,
+    key_id            VARCHAR(120)  NOT NULL,
+    digest            VARCHAR(64)   NOT NULL,
+    size              INTEGER       NOT NULL,
+    module            BYTEA         NOT NULL,
+    uploaded_by       UUID,
+    created_at        TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_plugin_packages_tenant_version ON plugin_packages (tenant_id, name, version);
+
+CREATE TABLE IF NOT EXISTS plugin_installs (
+    id                UUID PRIMARY KEY,
+    tenant_id         UUID          NOT NULL,
+    package_id        UUID          NOT NULL REFERENCES plugin_packages (id),
+    name              VARCHAR(120)  NOT NULL,
+    enabled           BOOLEAN       NOT NULL DEFAULT TRUE,
+    config            JSONB,
+    allowed_hosts     JSONB,
+    secret_names      JSONB,
+    encrypted_secrets TEXT          NOT NULL DEFAULT '',
+    memory_pages      INTEGER       NOT NULL,
+    timeout_ms        INTEGER       NOT NULL,
+    webhook_token     VARCHAR(80),
+    last_run_at       TIMESTAMPTZ,
+    last_run_status   VARCHAR(16)   NOT NULL DEFAULT '',
+    created_by        UUID,
+    created_at        TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_plugin_installs_memory CHECK (memory_pages BETWEEN 1 AND 1024),
+    CONSTRAINT chk_plugin_installs_timeout CHECK (timeout_ms BETWEEN 100 AND 60000)
+);
+
+CREATE INDEX IF NOT EXISTS idx_plugin_installs_tenant_id ON plugin_installs (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_plugin_installs_package_id ON plugin_installs (package_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_plugin_installs_webhook_token ON plugin_installs (webhook_token);
+
+CREATE TABLE IF NOT EXISTS plugin_runs (
+    id           UUID PRIMARY KEY,
+    tenant_id    UUID          NOT NULL,
+    install_id   UUID          NOT NULL REFERENCES plugin_installs (id) ON DELETE CASCADE,
+    hook         VARCHAR(32)   NOT NULL,
+    status       VARCHAR(16)   NOT NULL,
+    duration_ms  BIGINT        NOT NULL DEFAULT 0,
+    http_calls   INTEGER       NOT NULL DEFAULT 0,
+    items        INTEGER       NOT NULL DEFAULT 0,
+    error        TEXT          NOT NULL DEFAULT '',
+    logs         JSONB,
+    triggered_by UUID,
+    created_at   TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_plugin_runs_status CHECK (status IN ('ok', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_plugin_runs_tenant_id ON plugin_runs (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_plugin_runs_install_id ON plugin_runs (install_id, created_at DESC);
+
+COMMIT;
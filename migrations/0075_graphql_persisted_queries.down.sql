@@ -0,0 +1,8 @@
+-- Reverses 0075. Clients re-register their persisted queries on the next
+-- PersistedQueryNotFound.
+
+BEGIN;
+
+DROP TABLE IF EXISTS graphql_persisted_queries;
+
+COMMIT;
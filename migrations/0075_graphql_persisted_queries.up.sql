@@ -0,0 +1,21 @@
+-- GraphQL automatic persisted queries.
+--
+-- A client sends a query's SHA-256 instead of its text; the first time, the
+-- server answers PersistedQueryNotFound and the client retries with both, which
+-- registers it. Queries are stored per tenant: one organisation's dashboards
+-- neither see nor fill another's, and a hash names the same text for every
+-- caller of that tenant. A query is registered only once it has validated and
+-- passed the cost limit.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS graphql_persisted_queries (
+    tenant_id  UUID        NOT NULL,
+    hash       VARCHAR(64) NOT NULL,
+    query      TEXT        NOT NULL,
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, hash)
+);
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Reverses 0076. Queued deliveries are dropped with the subscriptions.
+
+BEGIN;
+
+ALTER TABLE evidences DROP COLUMN IF EXISTS expiry_announced_at;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
+
+COMMIT;
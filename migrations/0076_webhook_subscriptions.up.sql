@@ -0,0 +1,71 @@
+-- Outbound webhooks.
+--
+-- webhook_subscriptions are integrators' endpoints and the event types each
+-- receives. The signing secret (and, for a day after a rotation, the one it
+-- replaced) is encrypted with SCANNER_CREDENTIAL_KEY. consecutive_failures and
+-- failing_since track the current failure streak, which is what disables a
+-- subscription whose endpoint has been down for hours.
+--
+-- webhook_deliveries is the queue and the log at once: one row per event per
+-- subscription, holding the envelope exactly as sent, its attempts and the
+-- last response. An event is queued once per subscription — the partial
+-- unique index — while a redelivery is a new row pointing at the original.
+--
+-- evidences.expiry_announced_at stamps the evidence.expired announcement.
+-- Artifacts that had already lapsed are stamped now, so enabling the feature
+-- does not announce the whole history.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id                         UUID PRIMARY KEY,
+    tenant_id                  UUID          NOT NULL,
+    name                       VARCHAR(120)  NOT NULL,
+    url                        TEXT          NOT NULL,
+    event_types                JSONB,
+    enabled                    BOOLEAN       NOT NULL DEFAULT TRUE,
+    disabled_reason            TEXT          NOT NULL DEFAULT '',
+    encrypted_secrets          TEXT          NOT NULL DEFAULT '',
+    previous_secret_expires_at TIMESTAMPTZ,
+    consecutive_failures       INTEGER       NOT NULL DEFAULT 0,
+    failing_since              TIMESTAMPTZ,
+    last_delivery_at           TIMESTAMPTZ,
+    created_by                 UUID,
+    created_at                 TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at                 TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_tenant_id ON webhook_subscriptions (tenant_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id               UUID PRIMARY KEY,
+    tenant_id        UUID          NOT NULL,
+    subscription_id  UUID          NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id         UUID          NOT NULL,
+    event_type       VARCHAR(40)   NOT NULL,
+    payload          JSONB         NOT NULL,
+    status           VARCHAR(16)   NOT NULL,
+    attempts         INTEGER       NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ   NOT NULL,
+    last_status_code INTEGER       NOT NULL DEFAULT 0,
+    last_error       TEXT          NOT NULL DEFAULT '',
+    duration_ms      BIGINT        NOT NULL DEFAULT 0,
+    redelivery_of    UUID,
+    requested_by     UUID,
+    created_at       TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    delivered_at     TIMESTAMPTZ,
+    CONSTRAINT chk_webhook_deliveries_status CHECK (status IN ('pending', 'delivering', 'succeeded', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_tenant_id ON webhook_deliveries (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at)
+    WHERE status IN ('pending', 'delivering');
+CREATE UNIQUE INDEX IF NOT EXISTS ux_webhook_deliveries_event ON webhook_deliveries (subscription_id, event_id)
+    WHERE redelivery_of IS NULL;
+
+ALTER TABLE evidences ADD COLUMN IF NOT EXISTS expiry_announced_at TIMESTAMPTZ;
+UPDATE evidences SET expiry_announced_at = NOW()
+    WHERE valid_until IS NOT NULL AND valid_until <= NOW() AND expiry_announced_at IS NULL;
+
+COMMIT;
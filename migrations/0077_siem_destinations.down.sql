@@ -0,0 +1,9 @@
+-- Reverses 0077. Shipped records stay in the SIEM; only the destinations and
+-- their cursors go.
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_auth_audit_logs_tenant_created;
+DROP TABLE IF EXISTS siem_destinations;
+
+COMMIT;
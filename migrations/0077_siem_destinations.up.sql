@@ -0,0 +1,49 @@
+-- SIEM export of the audit trail.
+--
+-- siem_destinations are each tenant's collectors: transport (syslog over TLS
+-- or HEC), record format (CEF or OCSF), which logs to ship, and the HEC token,
+-- encrypted with SCANNER_CREDENTIAL_KEY. audit_cursor is the last audit chain
+-- sequence shipped; auth_cursor_at/auth_cursor_id the last auth log entry.
+-- Both move only after the collector has accepted a batch. lease_token and
+-- lease_until are the export worker's claim on the row.
+--
+-- The auth log is read per tenant in (created_at, id) order, which its
+-- existing indexes do not cover.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS siem_destinations (
+    id                   UUID PRIMARY KEY,
+    tenant_id            UUID          NOT NULL,
+    name                 VARCHAR(120)  NOT NULL,
+    transport            VARCHAR(16)   NOT NULL,
+    format               VARCHAR(16)   NOT NULL,
+    endpoint             TEXT          NOT NULL,
+    sources              JSONB,
+    enabled              BOOLEAN       NOT NULL DEFAULT TRUE,
+    ca_cert_pem          TEXT          NOT NULL DEFAULT '',
+    encrypted_token      TEXT          NOT NULL DEFAULT '',
+    audit_cursor         BIGINT        NOT NULL DEFAULT 0,
+    auth_cursor_at       TIMESTAMPTZ,
+    auth_cursor_id       UUID,
+    last_shipped_at      TIMESTAMPTZ,
+    last_error           TEXT          NOT NULL DEFAULT '',
+    last_error_at        TIMESTAMPTZ,
+    consecutive_failures INTEGER       NOT NULL DEFAULT 0,
+    next_attempt_at      TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    lease_token          UUID,
+    lease_until          TIMESTAMPTZ,
+    created_by           UUID,
+    created_at           TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_siem_destinations_transport CHECK (transport IN ('syslog', 'hec')),
+    CONSTRAINT chk_siem_destinations_format CHECK (format IN ('cef', 'ocsf'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_siem_destinations_tenant_id ON siem_destinations (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_siem_destinations_due ON siem_destinations (next_attempt_at)
+    WHERE enabled;
+
+CREATE INDEX IF NOT EXISTS idx_auth_audit_logs_tenant_created ON auth_audit_logs (tenant_id, created_at, id);
+
+COMMIT;
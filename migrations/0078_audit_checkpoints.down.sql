@@ -0,0 +1,9 @@
+-- Reverses 0078. Checkpoints already published or delivered stay valid; the
+-- server just stops keeping its own copies.
+
+BEGIN;
+
+DROP TRIGGER IF EXISTS trg_audit_checkpoints_append_only ON audit_checkpoints;
+DROP TABLE IF EXISTS audit_checkpoints;
+
+COMMIT;
@@ -0,0 +1,39 @@
+-- External anchoring of the audit hash chain.
+--
+-- audit_checkpoints are signed statements of a tenant's chain head: the head
+-- sequence and hash, signed with EXPORT_SIGNING_KEY (Ed25519) over the
+-- payload domain.AuditCheckpoint.SignedPayload describes. When AUDIT_TSA_URL
+-- is set, timestamp_token holds an RFC 3161 token over SHA-256(signature).
+-- One checkpoint per (tenant, sequence): replicas racing to checkpoint the
+-- same head insert one row.
+--
+-- Checkpoints are evidence in the same way the events are, so the audit
+-- trail's append-only trigger (0055) guards them too.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS audit_checkpoints (
+    id              UUID PRIMARY KEY,
+    tenant_id       UUID         NOT NULL,
+    sequence        BIGINT       NOT NULL,
+    head_hash       VARCHAR(64)  NOT NULL,
+    signed_at       TIMESTAMPTZ  NOT NULL,
+    algorithm       VARCHAR(16)  NOT NULL,
+    key_id          VARCHAR(32)  NOT NULL,
+    signature       TEXT         NOT NULL,
+    tsa_url         TEXT         NOT NULL DEFAULT '',
+    timestamp_token BYTEA,
+    timestamped_at  TIMESTAMPTZ,
+    timestamp_error TEXT         NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_checkpoint_tenant_seq
+    ON audit_checkpoints (tenant_id, sequence);
+
+DROP TRIGGER IF EXISTS trg_audit_checkpoints_append_only ON audit_checkpoints;
+CREATE TRIGGER trg_audit_checkpoints_append_only
+    BEFORE UPDATE OR DELETE ON audit_checkpoints
+    FOR EACH ROW EXECUTE FUNCTION openrisk_audit_append_only();
+
+COMMIT;
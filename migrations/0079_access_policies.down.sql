@@ -0,0 +1,15 @@
+-- Reverses 0079. Risks and evidence become visible to every member their
+-- permissions allow again; classifications and asset business units are lost.
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_assets_business_unit;
+ALTER TABLE assets DROP COLUMN IF EXISTS business_unit;
+
+DROP INDEX IF EXISTS idx_risks_classification;
+ALTER TABLE risks DROP COLUMN IF EXISTS classification;
+
+DROP TABLE IF EXISTS access_subject_attributes;
+DROP TABLE IF EXISTS access_policies;
+
+COMMIT;
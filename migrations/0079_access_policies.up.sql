@@ -0,0 +1,48 @@
+-- Record-level access policies (docs/ACCESS_POLICIES.md).
+--
+-- access_policies hold allow and deny rules over risks and evidence: who they
+-- apply to (subjects) and which records they match (condition), both JSON as
+-- domain.AccessSubjects and domain.AccessCondition describe. The repositories
+-- compile them into the WHERE clause of every risk and evidence query.
+-- access_subject_attributes are the free-form attributes an administrator
+-- assigns to a member (an audit scope, business units) for conditions to test.
+--
+-- Two attributes policies read did not exist: a risk's classification and an
+-- asset's business unit. Both default to '' so existing rows match no value.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS access_policies (
+    id          UUID PRIMARY KEY,
+    tenant_id   UUID         NOT NULL,
+    name        VARCHAR(120) NOT NULL,
+    description TEXT         NOT NULL DEFAULT '',
+    resource    VARCHAR(32)  NOT NULL,
+    effect      VARCHAR(8)   NOT NULL,
+    subjects    JSONB        NOT NULL,
+    condition   JSONB,
+    enabled     BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_by  UUID,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_access_policies_effect CHECK (effect IN ('allow', 'deny'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_policies_tenant_id ON access_policies (tenant_id);
+
+CREATE TABLE IF NOT EXISTS access_subject_attributes (
+    tenant_id  UUID        NOT NULL,
+    user_id    UUID        NOT NULL,
+    attributes JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    updated_by UUID,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, user_id)
+);
+
+ALTER TABLE risks ADD COLUMN IF NOT EXISTS classification VARCHAR(32) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_risks_classification ON risks (classification);
+
+ALTER TABLE assets ADD COLUMN IF NOT EXISTS business_unit VARCHAR(128) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_assets_business_unit ON assets (business_unit);
+
+COMMIT;
@@ -78,6 +78,7 @@ if [ ! -f .env ]; then
   set_kv MFA_ENCRYPTION_KEY "$(rand_hex 32)"       # 32 bytes hex → 64 chars; backend takes 32 bytes
   set_kv SCANNER_CREDENTIAL_KEY "$(rand_b64_32)"
   set_kv AUDIT_EXPORT_KEY "$(rand_b64_32)"
+  set_kv EXPORT_SIGNING_KEY "$(rand_b64_32)"
   chmod 600 .env
 else
   log ".env already present — keeping your configuration."